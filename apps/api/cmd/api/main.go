@@ -4,12 +4,17 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/real-staging-ai/api/internal/byobucket"
 	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/entitlement"
+	"github.com/real-staging-ai/api/internal/fieldcrypt"
 	"github.com/real-staging-ai/api/internal/http"
 	"github.com/real-staging-ai/api/internal/image"
-	"github.com/real-staging-ai/api/internal/job"
 	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/residency"
 	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/telemetry"
+	"github.com/real-staging-ai/api/internal/webhookarchive"
 )
 
 // main is the entrypoint of the API server.
@@ -24,12 +29,41 @@ func main() {
 		return
 	}
 	log.Info(ctx, fmt.Sprintf("Loaded configuration for environment: %s", cfg.App.Env))
+	if err := cfg.Validate(); err != nil {
+		log.Error(ctx, err.Error())
+		return
+	}
+
+	// Metrics such as SLO burn rates are exported to the collector; serving goes on without it
+	if shutdown, err := telemetry.InitMetrics(ctx, "real-staging-api", cfg.OTEL.MetricsInterval); err != nil {
+		log.Warn(ctx, "metrics export disabled", "error", err)
+	} else {
+		defer func() { _ = shutdown(context.Background()) }()
+	}
+
+	fieldKeys, err := fieldcrypt.NewKeyring(cfg.FieldEncryption)
+	if err != nil {
+		log.Error(ctx, fmt.Sprintf("invalid field encryption configuration: %v", err))
+		return
+	}
+	fieldcrypt.SetDefault(fieldKeys)
 
-	db, err := storage.NewDefaultDatabase(&cfg.DB)
+	production, err := storage.NewDefaultDatabase(&cfg.DB)
 	if err != nil {
 		log.Error(ctx, fmt.Sprintf("failed to connect to database: %v", err))
 		return
 	}
+	var db storage.Database = production
+	// Requests made with sandbox API keys act on the sandbox schema instead of production
+	if cfg.SandboxTenant.Enabled {
+		sandbox, err := storage.NewSandboxDatabase(&cfg.DB)
+		if err != nil {
+			production.Close()
+			log.Error(ctx, fmt.Sprintf("failed to connect to sandbox database: %v", err))
+			return
+		}
+		db = storage.NewTenantDatabase(production, sandbox)
+	}
 	defer db.Close()
 
 	s3Service, err := storage.NewDefaultS3Service(ctx, &cfg.S3)
@@ -42,14 +76,38 @@ func main() {
 		}
 	}
 
-	// Create repositories
-	imageRepo := image.NewDefaultRepository(db)
-	jobRepo := job.NewDefaultRepository(db)
+	// Users placed in a data-residency region keep their images in that region's bucket
+	regions, err := storage.OpenRegions(ctx, &cfg.S3)
+	if err != nil {
+		log.Error(ctx, fmt.Sprintf("failed to set up storage regions: %v", err))
+		return
+	}
+	for region, files := range regions {
+		if err := files.CreateBucket(ctx); err != nil {
+			log.Error(ctx, fmt.Sprintf("failed to ensure %s S3 bucket exists: %v", region, err))
+		}
+	}
+	platform := storage.NewRegionalBuckets(s3Service, regions, residency.NewDefaultService(db, cfg.S3).Region)
+
+	buckets, err := byobucket.NewBuckets(ctx, db, platform, cfg)
+	if err != nil {
+		log.Error(ctx, fmt.Sprintf("failed to set up customer buckets: %v", err))
+		return
+	}
+
 	log.Info(ctx, fmt.Sprintf("Setting up image service (queue: %s)", cfg.Job.QueueName))
-	imageService := image.NewDefaultService(cfg, imageRepo, jobRepo)
+	// Plan checks read users' entitlements from Redis; webhooks invalidate them on change
+	entitlements := entitlement.NewServiceFromConfig(ctx, cfg, db)
+	imageService := image.NewDefaultServiceWithDB(cfg, db, buckets, entitlements)
+
+	// Verified webhook payloads are kept in the home bucket for replay
+	var archive webhookarchive.Service
+	if cfg.WebhookArchive.Enabled && s3Service != nil {
+		archive = webhookarchive.NewDefaultService(s3Service, cfg.WebhookArchive)
+	}
 
-	s := http.NewServer(cfg.Auth0.Audience, cfg.Auth0.Domain, ctx, db, imageService, s3Service)
-	if err := s.Start(":8080"); err != nil {
+	s := http.NewServer(ctx, cfg, db, imageService, buckets, archive, entitlements)
+	if err := s.StartWithConfig(cfg.HTTP); err != nil {
 		log.Error(ctx, fmt.Sprintf("Failed to start server: %v", err))
 	}
 }
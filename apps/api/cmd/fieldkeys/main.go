@@ -0,0 +1,67 @@
+// Command fieldkeys re-encrypts the encrypted user columns with the active field
+// encryption key. Run it after rotating FIELD_ENCRYPTION_ACTIVE_KEY or changing
+// FIELD_ENCRYPTION_INDEX_KEY, and after first enabling encryption to encrypt existing rows.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/fieldcrypt"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func main() {
+	var (
+		batchSize = flag.Int("batch-size", 100, "Number of users to read per batch")
+		dryRun    = flag.Bool("dry-run", false, "Don't apply changes, only count the rows that would change")
+		decrypt   = flag.Bool("decrypt", false, "Write every row back in plaintext instead of re-encrypting it")
+	)
+	flag.Parse()
+
+	opts := user.RotateOptions{BatchSize: *batchSize, DryRun: *dryRun, Decrypt: *decrypt}
+	if err := run(context.Background(), opts); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, opts user.RotateOptions) error {
+	logger := logging.Default()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	keys, err := fieldcrypt.NewKeyring(cfg.FieldEncryption)
+	if err != nil {
+		return fmt.Errorf("invalid field encryption configuration: %w", err)
+	}
+
+	db, err := storage.NewDefaultDatabase(&cfg.DB)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	fmt.Printf("Rotating user field encryption (active_key=%q, decrypt=%v, dry_run=%v)\n",
+		keys.Active(), opts.Decrypt, opts.DryRun)
+	result, err := user.RotateFieldKeys(ctx, queries.New(db), keys, opts)
+	logger.Info(ctx, "field key rotation finished",
+		"scanned", result.Scanned, "updated", result.Updated, "dry_run", opts.DryRun, "error", err)
+	fmt.Printf("  Scanned: %d users\n", result.Scanned)
+	fmt.Printf("  Updated: %d users\n", result.Updated)
+	if err != nil {
+		return fmt.Errorf("rotation stopped: %w", err)
+	}
+	if opts.DryRun {
+		fmt.Println("\nDry run: no changes were written.")
+	}
+	return nil
+}
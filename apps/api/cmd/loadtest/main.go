@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	redis "github.com/redis/go-redis/v9"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/loadtest"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+func main() {
+	def := loadtest.DefaultConfig()
+	var (
+		baseURL      = flag.String("base-url", def.BaseURL, "API origin to load")
+		token        = flag.String("token", os.Getenv("LOADTEST_TOKEN"), "Bearer token (defaults to $LOADTEST_TOKEN)")
+		duration     = flag.Duration("duration", def.Duration, "How long to keep starting new projects")
+		rate         = flag.Float64("rate", def.ProjectsPerSecond, "Projects started per second")
+		images       = flag.Int("images", def.ImagesPerProject, "Images uploaded and staged per project")
+		concurrency  = flag.Int("concurrency", def.Concurrency, "Maximum in-flight projects")
+		uploadBytes  = flag.Int("upload-bytes", def.UploadBytes, "Size of each uploaded image in bytes")
+		pollInterval = flag.Duration("poll-interval", def.PollInterval, "Image status poll interval")
+		jobTimeout   = flag.Duration("job-timeout", def.CompletionTimeout, "Maximum wait for a single job")
+		jsonOut      = flag.Bool("json", false, "Print the report as JSON")
+
+		fake            = flag.Bool("fake-provider", false, "Complete jobs in-process instead of using the worker")
+		fakeConcurrency = flag.Int("provider-concurrency", 20, "Fake provider: jobs processed at once")
+		fakeLatency     = flag.Duration("provider-latency", 0, "Fake provider: simulated prediction time")
+		fakeJitter      = flag.Duration("provider-jitter", 0, "Fake provider: random extra prediction time, up to this much")
+		fakeFailureRate = flag.Float64("provider-failure-rate", 0, "Fake provider: fraction of jobs that fail (0-1)")
+	)
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	logger := logging.Default()
+
+	if *fake {
+		cleanup, err := startFakeProvider(ctx, loadtest.ProviderConfig{
+			Concurrency: *fakeConcurrency,
+			Latency:     *fakeLatency,
+			Jitter:      *fakeJitter,
+			FailureRate: *fakeFailureRate,
+		})
+		if err != nil {
+			logger.Error(ctx, "failed to start fake provider", "error", err)
+			fmt.Fprintf(os.Stderr, "Error: failed to start fake provider: %v\n", err)
+			os.Exit(1)
+		}
+		defer cleanup()
+	}
+
+	cfg := loadtest.Config{
+		BaseURL:           *baseURL,
+		Token:             *token,
+		Duration:          *duration,
+		ProjectsPerSecond: *rate,
+		ImagesPerProject:  *images,
+		Concurrency:       *concurrency,
+		UploadBytes:       *uploadBytes,
+		PollInterval:      *pollInterval,
+		CompletionTimeout: *jobTimeout,
+	}
+	fmt.Fprintf(os.Stderr, "Load testing %s for %s at %.2f projects/s x %d images (fake provider: %v)\n",
+		cfg.BaseURL, cfg.Duration, cfg.ProjectsPerSecond, cfg.ImagesPerProject, *fake)
+
+	report, err := loadtest.NewRunner(cfg, nil).Run(ctx)
+	if report == nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Run interrupted: %v; reporting partial results\n", err)
+	}
+
+	if *jsonOut {
+		out, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(out))
+		return
+	}
+	if err := report.WriteText(os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: write report: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// startFakeProvider consumes the API's job queue in-process. Stop the worker first, or
+// it will compete for the same tasks.
+func startFakeProvider(ctx context.Context, pcfg loadtest.ProviderConfig) (func(), error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load configuration: %w", err)
+	}
+	redisAddr := os.Getenv("REDIS_ADDR")
+	if redisAddr == "" {
+		redisAddr = cfg.Redis.Addr
+	}
+	if redisAddr == "" {
+		return nil, fmt.Errorf("REDIS_ADDR not set")
+	}
+	if pcfg.Keys, err = queue.NewKeyring(cfg.PayloadEncryption); err != nil {
+		return nil, fmt.Errorf("payload encryption: %w", err)
+	}
+
+	db, err := storage.NewDefaultDatabase(&cfg.DB)
+	if err != nil {
+		return nil, fmt.Errorf("connect to database: %w", err)
+	}
+	rdb := redis.NewClient(&redis.Options{Addr: redisAddr})
+	publish := func(ctx context.Context, stream, payload string) error {
+		return rdb.XAdd(ctx, &redis.XAddArgs{Stream: stream, Values: map[string]any{"data": payload}}).Err()
+	}
+
+	pcfg.Queue = cfg.Job.QueueName
+	provider := loadtest.NewFakeProvider(pcfg, queries.New(db), publish)
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() { done <- provider.Serve(ctx, redisAddr) }()
+	return func() {
+		cancel()
+		if err := <-done; err != nil {
+			fmt.Fprintf(os.Stderr, "fake provider: %v\n", err)
+		}
+		_ = rdb.Close()
+		db.Close()
+	}, nil
+}
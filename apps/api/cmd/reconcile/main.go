@@ -6,7 +6,9 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/real-staging-ai/api/internal/byobucket"
 	"github.com/real-staging-ai/api/internal/config"
 	"github.com/real-staging-ai/api/internal/logging"
 	"github.com/real-staging-ai/api/internal/reconcile"
@@ -15,13 +17,17 @@ import (
 
 func main() {
 	var (
-		batchSize   = flag.Int("batch-size", 100, "Number of images to check per batch")
-		concurrency = flag.Int("concurrency", 5, "Number of concurrent S3 checks")
-		dryRun      = flag.Bool("dry-run", false, "Don't apply changes, only report what would be done")
-		projectID   = flag.String("project-id", "", "Optional: filter by project ID")
-		status      = flag.String("status", "", "Optional: filter by status (queued, processing, ready, error)")
+		batchSize     = flag.Int("batch-size", 100, "Number of images to check per batch")
+		concurrency   = flag.Int("concurrency", 5, "Number of concurrent S3 checks")
+		dryRun        = flag.Bool("dry-run", false, "Don't apply changes, only report what would be done")
+		projectID     = flag.String("project-id", "", "Optional: filter by project ID")
+		status        = flag.String("status", "", "Optional: filter by status (queued, processing, ready, error)")
+		createdAfter  = flag.String("created-after", "", "Optional: only images created at or after this RFC 3339 time")
+		createdBefore = flag.String("created-before", "", "Optional: only images created before this RFC 3339 time")
 	)
 	flag.Parse()
+	after := parseTimeFlag("created-after", *createdAfter)
+	before := parseTimeFlag("created-before", *createdBefore)
 
 	ctx := context.Background()
 	logger := logging.Default()
@@ -54,8 +60,24 @@ func main() {
 		return
 	}
 
+	// Images in region buckets are checked in their region, and images in customer-managed
+	// buckets with the customer's role
+	regions, err := storage.OpenRegions(ctx, &cfg.S3)
+	if err != nil {
+		logger.Error(ctx, "failed to set up storage regions", "error", err)
+		fmt.Fprintf(os.Stderr, "Error: failed to set up storage regions: %v\n", err)
+		return
+	}
+	platform := storage.NewRegionalBuckets(s3Service, regions, nil)
+	buckets, err := byobucket.NewBuckets(ctx, db, platform, cfg)
+	if err != nil {
+		logger.Error(ctx, "failed to set up customer buckets", "error", err)
+		fmt.Fprintf(os.Stderr, "Error: failed to set up customer buckets: %v\n", err)
+		return
+	}
+
 	// Create reconcile service
-	svc := reconcile.NewDefaultService(db, s3Service)
+	svc := reconcile.NewDefaultService(db, buckets)
 
 	// Build options
 	opts := reconcile.ReconcileOptions{
@@ -69,6 +91,8 @@ func main() {
 	if *status != "" {
 		opts.Status = status
 	}
+	opts.CreatedAfter = after
+	opts.CreatedBefore = before
 
 	logger.Info(ctx, "starting reconciliation run",
 		"dry_run", *dryRun,
@@ -85,6 +109,9 @@ func main() {
 	if opts.Status != nil {
 		fmt.Printf("  Filtering by status: %s\n", *opts.Status)
 	}
+	if opts.CreatedAfter != nil || opts.CreatedBefore != nil {
+		fmt.Printf("  Created between: %s and %s\n", *createdAfter, *createdBefore)
+	}
 
 	// Run reconciliation
 	result, err := svc.ReconcileImages(ctx, opts)
@@ -98,6 +125,9 @@ func main() {
 		"checked", result.Checked,
 		"missing_original", result.MissingOrig,
 		"missing_staged", result.MissingStaged,
+		"check_errors", result.CheckErrors,
+		"external", result.External,
+		"status_drift", result.StatusDrift,
 		"updated", result.Updated,
 		"dry_run", result.DryRun,
 	)
@@ -107,6 +137,9 @@ func main() {
 	fmt.Printf("  Checked:         %d images\n", result.Checked)
 	fmt.Printf("  Missing original: %d\n", result.MissingOrig)
 	fmt.Printf("  Missing staged:   %d\n", result.MissingStaged)
+	fmt.Printf("  Check errors:     %d (skipped; rerun to retry)\n", result.CheckErrors)
+	fmt.Printf("  External:         %d (skipped; bucket not configured)\n", result.External)
+	fmt.Printf("  Status drift:     %d (status differs from the status log)\n", result.StatusDrift)
 	fmt.Printf("  Updated:         %d\n", result.Updated)
 	fmt.Printf("  Dry run:         %v\n", result.DryRun)
 
@@ -130,3 +163,16 @@ func main() {
 		fmt.Println("\nNote: This was a dry run. No changes were applied.")
 	}
 }
+
+// parseTimeFlag parses an optional RFC 3339 flag value, exiting on invalid input.
+func parseTimeFlag(name, value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --%s must be an RFC 3339 timestamp: %v\n", name, err)
+		os.Exit(1)
+	}
+	return &t
+}
@@ -1,30 +1,31 @@
+// Command token mints an Auth0 access token with the client credentials grant and prints
+// the token response. Credentials come from the usual config layers, with
+// .secrets/auth0.yml, when present, overriding the YAML files:
+//
+//	auth0:
+//	  client_id: ...
+//	  client_secret: ...
+//
+// Environment variables (AUTH0_DOMAIN, AUTH0_CLIENT_ID, ...) override both.
 package main
 
 import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 
-	"gopkg.in/yaml.v2"
-
-	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/httpclient"
 )
 
-type config struct {
-	Auth0 struct {
-		ClientID     string `yaml:"client_id"`
-		ClientSecret string `yaml:"client_secret"`
-		Audience     string `yaml:"audience"`
-		GrantType    string `yaml:"grant_type"`
-		Domain       string `yaml:"domain"`
-	} `yaml:"auth0"`
-}
+// auth0SecretsFile holds local Auth0 client credentials outside the app's secrets.yml.
+const auth0SecretsFile = ".secrets/auth0.yml"
 
-// make requestPayload a struct type
 type requestPayload struct {
 	ClientID     string `json:"client_id"`
 	ClientSecret string `json:"client_secret"`
@@ -33,105 +34,54 @@ type requestPayload struct {
 }
 
 func main() {
-	log := logging.Default()
-	cfg := loadConfig()
-	ctx := context.Background()
-
-	domain := os.Getenv("AUTH0_DOMAIN")
-	if domain == "" {
-		domain = cfg.Auth0.Domain
-	}
-	if domain == "" {
-		log.Error(ctx, "AUTH0_DOMAIN not set (set env or .secrets/auth0.yml)")
+	if err := run(context.Background()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
+}
 
-	url := fmt.Sprintf("https://%s/oauth/token", domain)
-
-	auth0Config := &requestPayload{
-		ClientID:     cfg.Auth0.ClientID,
-		ClientSecret: cfg.Auth0.ClientSecret,
-		Audience:     cfg.Auth0.Audience,
-		GrantType:    cfg.Auth0.GrantType,
+func run(ctx context.Context) error {
+	cfg, err := config.LoadFrom(append(config.DefaultLayerFiles(), auth0SecretsFile)...)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	a := cfg.Auth0
+	if a.Domain == "" || a.ClientID == "" || a.ClientSecret == "" || a.Audience == "" {
+		return errors.New("auth0 domain, client_id, client_secret and audience are required " +
+			"(set AUTH0_* or " + auth0SecretsFile + ")")
 	}
 
-	// convert auth0Config to json
-	payload, err := json.Marshal(auth0Config)
+	payload, err := json.Marshal(requestPayload{
+		ClientID:     a.ClientID,
+		ClientSecret: a.ClientSecret,
+		Audience:     a.Audience,
+		GrantType:    a.GrantType,
+	})
 	if err != nil {
-		log.Error(ctx, "failed to marshal auth0Config", err)
+		return fmt.Errorf("failed to marshal request: %w", err)
 	}
 
+	url := fmt.Sprintf("https://%s/oauth/token", a.Domain)
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(payload))
 	if err != nil {
-		log.Error(ctx, "failed to create request", err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
-
 	req.Header.Add("content-type", "application/json")
 
-	res, err := http.DefaultClient.Do(req)
+	res, err := httpclient.New(httpclient.DestinationAuth0).Do(req)
 	if err != nil {
-		log.Error(ctx, "failed to do request", err)
+		return fmt.Errorf("token request failed: %w", err)
 	}
-
-	defer func() {
-		if err := res.Body.Close(); err != nil {
-			log.Error(ctx, "failed to close response body", err)
-		}
-	}()
+	defer func() { _ = res.Body.Close() }()
 
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		log.Error(ctx, "failed to read response body", err)
-	}
-
-	fmt.Println(string(body))
-}
-
-func loadConfig() config {
-	var cfg config
-	log := logging.Default()
-	ctx := context.Background()
-
-	// 1) Env-first
-	if v := os.Getenv("AUTH0_CLIENT_ID"); v != "" {
-		cfg.Auth0.ClientID = v
-	}
-	if v := os.Getenv("AUTH0_CLIENT_SECRET"); v != "" {
-		cfg.Auth0.ClientSecret = v
+		return fmt.Errorf("failed to read response body: %w", err)
 	}
-	if v := os.Getenv("AUTH0_AUDIENCE"); v != "" {
-		cfg.Auth0.Audience = v
-	}
-	if v := os.Getenv("AUTH0_GRANT_TYPE"); v != "" {
-		cfg.Auth0.GrantType = v
-	}
-	if v := os.Getenv("AUTH0_DOMAIN"); v != "" {
-		cfg.Auth0.Domain = v
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("token request returned %s: %s", res.Status, body)
 	}
 
-	// 2) Fallback to .secrets/auth0.yml or secrets.yml if any critical is missing
-	if cfg.Auth0.ClientID == "" || cfg.Auth0.ClientSecret == "" || cfg.Auth0.Audience == "" || cfg.Auth0.Domain == "" {
-		paths := []string{".secrets/auth0.yml", "secrets.yml"}
-		for _, p := range paths {
-			// #nosec G304 -- Reading from predefined secrets file paths
-			data, err := os.ReadFile(p)
-			if err != nil {
-				continue
-			}
-			if err := yaml.Unmarshal(data, &cfg); err == nil {
-				break
-			}
-		}
-	}
-
-	// Default grant type
-	if cfg.Auth0.GrantType == "" {
-		cfg.Auth0.GrantType = "client_credentials"
-	}
-
-	// Validate
-	if cfg.Auth0.ClientID == "" || cfg.Auth0.ClientSecret == "" || cfg.Auth0.Audience == "" {
-		log.Error(ctx, "missing AUTH0_CLIENT_ID/SECRET/AUDIENCE (set env or .secrets/auth0.yml)")
-	}
-
-	return cfg
+	fmt.Println(string(body))
+	return nil
 }
@@ -0,0 +1,179 @@
+// Package analytics aggregates image staging activity into daily or weekly
+// usage reports for individual users and for the whole system.
+package analytics
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Supported bucket sizes for analytics series.
+const (
+	GranularityDay  = "day"
+	GranularityWeek = "week"
+)
+
+const (
+	// DefaultRangeDays is the report window used when no date range is given.
+	DefaultRangeDays = 30
+	// MaxRangeDays bounds the report window to keep the aggregation cheap.
+	MaxRangeDays = 366
+	// MaxStyles is the number of styles reported in the popularity ranking.
+	MaxStyles = 20
+
+	dateLayout = "2006-01-02"
+)
+
+// ErrInvalidQuery is returned when analytics query parameters are malformed.
+var ErrInvalidQuery = errors.New("invalid analytics query")
+
+// Query selects the report window. From and To are UTC dates; both are inclusive.
+type Query struct {
+	From        time.Time
+	To          time.Time
+	Granularity string
+}
+
+// ParseQuery builds a Query from raw request parameters, applying defaults
+// relative to now for any that are empty.
+func ParseQuery(from, to, granularity string, now time.Time) (Query, error) {
+	q := Query{Granularity: granularity}
+	if q.Granularity == "" {
+		q.Granularity = GranularityDay
+	}
+	if q.Granularity != GranularityDay && q.Granularity != GranularityWeek {
+		return Query{}, fmt.Errorf("%w: granularity must be %q or %q", ErrInvalidQuery, GranularityDay, GranularityWeek)
+	}
+
+	today := truncateDay(now)
+	q.To = today
+	if to != "" {
+		t, err := time.Parse(dateLayout, to)
+		if err != nil {
+			return Query{}, fmt.Errorf("%w: to must be a date in YYYY-MM-DD format", ErrInvalidQuery)
+		}
+		q.To = t
+	}
+
+	q.From = q.To.AddDate(0, 0, -(DefaultRangeDays - 1))
+	if from != "" {
+		t, err := time.Parse(dateLayout, from)
+		if err != nil {
+			return Query{}, fmt.Errorf("%w: from must be a date in YYYY-MM-DD format", ErrInvalidQuery)
+		}
+		q.From = t
+	}
+
+	if q.From.After(q.To) {
+		return Query{}, fmt.Errorf("%w: from must not be after to", ErrInvalidQuery)
+	}
+	if q.To.Sub(q.From) >= MaxRangeDays*24*time.Hour {
+		return Query{}, fmt.Errorf("%w: date range must not exceed %d days", ErrInvalidQuery, MaxRangeDays)
+	}
+
+	return q, nil
+}
+
+// Report is an aggregated view of image staging activity over a date range.
+type Report struct {
+	Scope       string        `json:"scope"`
+	From        string        `json:"from"`
+	To          string        `json:"to"`
+	Granularity string        `json:"granularity"`
+	Totals      Totals        `json:"totals"`
+	Series      []Bucket      `json:"series"`
+	Styles      []StyleCount  `json:"styles"`
+	Sources     []SourceCount `json:"sources"`
+	SLA         []PlanSLA     `json:"sla"`
+}
+
+// Report scopes.
+const (
+	ScopeUser   = "user"
+	ScopeGlobal = "global"
+)
+
+// Totals summarizes a whole report window.
+//
+// SuccessRate and FailureRate are computed over finished images (ready or
+// error); canceled and still-running images do not count against either.
+type Totals struct {
+	Images               int64   `json:"images"`
+	Ready                int64   `json:"ready"`
+	Failed               int64   `json:"failed"`
+	Canceled             int64   `json:"canceled"`
+	InProgress           int64   `json:"in_progress"`
+	SuccessRate          float64 `json:"success_rate"`
+	FailureRate          float64 `json:"failure_rate"`
+	AvgTurnaroundSeconds float64 `json:"avg_turnaround_seconds"`
+}
+
+// Bucket holds the activity for a single day or week, keyed by its first day.
+type Bucket struct {
+	Date                 string  `json:"date"`
+	Images               int64   `json:"images"`
+	Ready                int64   `json:"ready"`
+	Failed               int64   `json:"failed"`
+	Canceled             int64   `json:"canceled"`
+	SuccessRate          float64 `json:"success_rate"`
+	AvgTurnaroundSeconds float64 `json:"avg_turnaround_seconds"`
+}
+
+// StyleCount is the number of images staged with a given style.
+type StyleCount struct {
+	Style  string `json:"style"`
+	Images int64  `json:"images"`
+}
+
+// SourceCount is the activity of images created from one surface: web, mobile, api,
+// import, or unknown for images created before sources were tracked. FailureRate is
+// computed over finished images, as in Totals.
+type SourceCount struct {
+	Source      string  `json:"source"`
+	Images      int64   `json:"images"`
+	Ready       int64   `json:"ready"`
+	Failed      int64   `json:"failed"`
+	Canceled    int64   `json:"canceled"`
+	FailureRate float64 `json:"failure_rate"`
+}
+
+// PlanSLA is the turnaround SLA compliance of one plan's images that became ready in the
+// report window. An image meets the SLA when it is ready within TargetSeconds of first
+// being queued; the plan is Compliant when at least TargetPercent of its images do.
+type PlanSLA struct {
+	// Plan is the plan code at the time the images became ready, "" for images of users
+	// without an active plan.
+	Plan                 string  `json:"plan"`
+	TargetSeconds        int64   `json:"target_seconds"`
+	TargetPercent        float64 `json:"target_percent"`
+	Images               int64   `json:"images"`
+	WithinTarget         int64   `json:"within_target"`
+	Compliance           float64 `json:"compliance"`
+	Compliant            bool    `json:"compliant"`
+	P95TurnaroundSeconds float64 `json:"p95_turnaround_seconds"`
+	// Credits is the number of images credited back for missing the target.
+	Credits int64 `json:"credits"`
+}
+
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// bucketStart returns the first day of the bucket containing day, matching
+// Postgres date_trunc semantics (weeks start on Monday).
+func bucketStart(day time.Time, granularity string) time.Time {
+	if granularity != GranularityWeek {
+		return day
+	}
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+func successRate(ready, failed int64) float64 {
+	if ready+failed == 0 {
+		return 0
+	}
+	return float64(ready) / float64(ready+failed)
+}
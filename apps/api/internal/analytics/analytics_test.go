@@ -0,0 +1,99 @@
+package analytics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuery(t *testing.T) {
+	now := time.Date(2025, 3, 15, 18, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		from        string
+		to          string
+		granularity string
+		expectFrom  string
+		expectTo    string
+		expectGran  string
+		expectError bool
+	}{
+		{
+			name:       "success: defaults to the last 30 days by day",
+			expectFrom: "2025-02-14",
+			expectTo:   "2025-03-15",
+			expectGran: GranularityDay,
+		},
+		{
+			name:        "success: explicit weekly range",
+			from:        "2025-01-01",
+			to:          "2025-01-31",
+			granularity: GranularityWeek,
+			expectFrom:  "2025-01-01",
+			expectTo:    "2025-01-31",
+			expectGran:  GranularityWeek,
+		},
+		{
+			name:       "success: only to given",
+			to:         "2025-01-30",
+			expectFrom: "2025-01-01",
+			expectTo:   "2025-01-30",
+			expectGran: GranularityDay,
+		},
+		{
+			name:        "fail: unknown granularity",
+			granularity: "month",
+			expectError: true,
+		},
+		{
+			name:        "fail: malformed from",
+			from:        "01/02/2025",
+			expectError: true,
+		},
+		{
+			name:        "fail: malformed to",
+			to:          "yesterday",
+			expectError: true,
+		},
+		{
+			name:        "fail: from after to",
+			from:        "2025-02-01",
+			to:          "2025-01-01",
+			expectError: true,
+		},
+		{
+			name:        "fail: range too long",
+			from:        "2023-01-01",
+			to:          "2025-01-01",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := ParseQuery(tc.from, tc.to, tc.granularity, now)
+			if tc.expectError {
+				assert.True(t, errors.Is(err, ErrInvalidQuery))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectFrom, q.From.Format(dateLayout))
+			assert.Equal(t, tc.expectTo, q.To.Format(dateLayout))
+			assert.Equal(t, tc.expectGran, q.Granularity)
+		})
+	}
+}
+
+func TestBucketStart(t *testing.T) {
+	// 2025-01-01 is a Wednesday; Postgres weeks start on Monday.
+	day := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "2024-12-30", bucketStart(day, GranularityWeek).Format(dateLayout))
+	assert.Equal(t, "2025-01-01", bucketStart(day, GranularityDay).Format(dateLayout))
+
+	sunday := time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "2024-12-30", bucketStart(sunday, GranularityWeek).Format(dateLayout))
+}
@@ -0,0 +1,116 @@
+package analytics
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/csvexport"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// DefaultHandler serves analytics reports over HTTP.
+type DefaultHandler struct {
+	service  Service
+	userRepo user.Repository
+	now      func() time.Time
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, userRepo user.Repository) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo, now: time.Now}
+}
+
+// ErrorResponse is a simple JSON error envelope for handler responses.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// GetMyAnalytics handles GET /api/v1/analytics/me.
+func (h *DefaultHandler) GetMyAnalytics(c echo.Context) error {
+	q, err := h.parseQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: err.Error(),
+		})
+	}
+
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Unable to resolve current user",
+		})
+	}
+
+	report, err := h.service.GetUserAnalytics(c.Request().Context(), userID, q)
+	if err != nil {
+		c.Logger().Errorf("Failed to build user analytics: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to retrieve analytics",
+		})
+	}
+
+	if csvexport.Requested(c) {
+		return writeReportCSV(c, report)
+	}
+	return c.JSON(http.StatusOK, report)
+}
+
+// GetGlobalAnalytics handles GET /api/v1/admin/analytics.
+func (h *DefaultHandler) GetGlobalAnalytics(c echo.Context) error {
+	q, err := h.parseQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: err.Error(),
+		})
+	}
+
+	report, err := h.service.GetGlobalAnalytics(c.Request().Context(), q)
+	if err != nil {
+		c.Logger().Errorf("Failed to build global analytics: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to retrieve analytics",
+		})
+	}
+
+	if csvexport.Requested(c) {
+		return writeReportCSV(c, report)
+	}
+	return c.JSON(http.StatusOK, report)
+}
+
+// parseQuery reads the from/to/granularity query params.
+func (h *DefaultHandler) parseQuery(c echo.Context) (Query, error) {
+	return ParseQuery(c.QueryParam("from"), c.QueryParam("to"), c.QueryParam("granularity"), h.now())
+}
+
+// writeReportCSV sends the report's time series as a CSV attachment, one row per bucket.
+func writeReportCSV(c echo.Context, report *Report) error {
+	header := []string{"date", "images", "ready", "failed", "canceled", "success_rate", "avg_turnaround_seconds"}
+	rows := make([][]string, 0, len(report.Series))
+	for _, b := range report.Series {
+		rows = append(rows, []string{
+			b.Date,
+			strconv.FormatInt(b.Images, 10),
+			strconv.FormatInt(b.Ready, 10),
+			strconv.FormatInt(b.Failed, 10),
+			strconv.FormatInt(b.Canceled, 10),
+			strconv.FormatFloat(b.SuccessRate, 'f', 4, 64),
+			strconv.FormatFloat(b.AvgTurnaroundSeconds, 'f', 1, 64),
+		})
+	}
+	filename := fmt.Sprintf("analytics-%s-%s-%s.csv", report.Scope, report.From, report.To)
+	return csvexport.Write(c, filename, header, rows)
+}
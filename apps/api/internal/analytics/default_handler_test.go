@@ -0,0 +1,196 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func TestDefaultHandler_GetMyAnalytics(t *testing.T) {
+	userID := uuid.New()
+	existingUser := func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+		return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}, Auth0Sub: auth0Sub}, nil
+	}
+
+	testCases := []struct {
+		name           string
+		query          string
+		testUser       string
+		getByAuth0Sub  func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error)
+		serviceErr     error
+		expectedStatus int
+		expectCalls    int
+	}{
+		{
+			name:           "success: default range",
+			testUser:       "auth0|user",
+			getByAuth0Sub:  existingUser,
+			expectedStatus: http.StatusOK,
+			expectCalls:    1,
+		},
+		{
+			name:     "success: user is created on first access",
+			query:    "?from=2025-01-01&to=2025-01-31&granularity=week",
+			testUser: "auth0|new",
+			getByAuth0Sub: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+				return nil, pgx.ErrNoRows
+			},
+			expectedStatus: http.StatusOK,
+			expectCalls:    1,
+		},
+		{
+			name:           "fail: invalid granularity",
+			query:          "?granularity=hour",
+			testUser:       "auth0|user",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:     "fail: user lookup error",
+			testUser: "auth0|user",
+			getByAuth0Sub: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+				return nil, errors.New("db error")
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "fail: service error",
+			testUser:       "auth0|user",
+			getByAuth0Sub:  existingUser,
+			serviceErr:     errors.New("db error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectCalls:    1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/analytics/me"+tc.query, nil)
+			if tc.testUser != "" {
+				req.Header.Set("X-Test-User", tc.testUser)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			svc := &ServiceMock{
+				GetUserAnalyticsFunc: func(ctx context.Context, id string, q Query) (*Report, error) {
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+					return &Report{Scope: ScopeUser}, nil
+				},
+			}
+			userRepo := &user.RepositoryMock{
+				GetByAuth0SubFunc: tc.getByAuth0Sub,
+				CreateFunc: func(ctx context.Context, auth0Sub, stripeCustomerID, role string) (*queries.CreateUserRow, error) {
+					return &queries.CreateUserRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, userRepo)
+			h.now = func() time.Time { return time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC) }
+
+			require.NoError(t, h.GetMyAnalytics(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			require.Len(t, svc.GetUserAnalyticsCalls(), tc.expectCalls)
+			if tc.expectCalls > 0 {
+				assert.Equal(t, userID.String(), svc.GetUserAnalyticsCalls()[0].UserID)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_GetGlobalAnalytics(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		serviceErr     error
+		expectedStatus int
+		expectCalls    int
+	}{
+		{
+			name:           "success: explicit range",
+			query:          "?from=2025-01-01&to=2025-01-07",
+			expectedStatus: http.StatusOK,
+			expectCalls:    1,
+		},
+		{
+			name:           "fail: from after to",
+			query:          "?from=2025-02-01&to=2025-01-01",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "fail: service error",
+			serviceErr:     errors.New("db error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectCalls:    1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/analytics"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			svc := &ServiceMock{
+				GetGlobalAnalyticsFunc: func(ctx context.Context, q Query) (*Report, error) {
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+					return &Report{Scope: ScopeGlobal}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, &user.RepositoryMock{})
+
+			require.NoError(t, h.GetGlobalAnalytics(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Len(t, svc.GetGlobalAnalyticsCalls(), tc.expectCalls)
+		})
+	}
+}
+
+func TestDefaultHandler_GetGlobalAnalytics_CSV(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/analytics?format=csv", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	svc := &ServiceMock{
+		GetGlobalAnalyticsFunc: func(ctx context.Context, q Query) (*Report, error) {
+			return &Report{
+				Scope: ScopeGlobal,
+				From:  "2025-01-01",
+				To:    "2025-01-02",
+				Series: []Bucket{
+					{Date: "2025-01-01", Images: 4, Ready: 3, Failed: 1, SuccessRate: 0.75, AvgTurnaroundSeconds: 41.25},
+					{Date: "2025-01-02"},
+				},
+			}, nil
+		},
+	}
+	h := NewDefaultHandler(svc, &user.RepositoryMock{})
+
+	require.NoError(t, h.GetGlobalAnalytics(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `attachment; filename="analytics-global-2025-01-01-2025-01-02.csv"`,
+		rec.Header().Get(echo.HeaderContentDisposition))
+	assert.Equal(t,
+		"date,images,ready,failed,canceled,success_rate,avg_turnaround_seconds\n"+
+			"2025-01-01,4,3,1,0,0.7500,41.2\n"+
+			"2025-01-02,0,0,0,0,0.0000,0.0\n",
+		rec.Body.String())
+}
@@ -0,0 +1,210 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// DefaultService implements the Service interface.
+type DefaultService struct {
+	querier queries.Querier
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService with a database.
+func NewDefaultService(db storage.Database) *DefaultService {
+	return &DefaultService{querier: queries.New(db)}
+}
+
+// NewDefaultServiceWithQuerier creates a new DefaultService with a custom querier (for testing).
+func NewDefaultServiceWithQuerier(querier queries.Querier) *DefaultService {
+	return &DefaultService{querier: querier}
+}
+
+// GetUserAnalytics reports activity across all projects owned by userID.
+func (s *DefaultService) GetUserAnalytics(ctx context.Context, userID string, q Query) (*Report, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	return s.report(ctx, ScopeUser, pgtype.UUID{Bytes: uid, Valid: true}, q)
+}
+
+// GetGlobalAnalytics reports activity across every user.
+func (s *DefaultService) GetGlobalAnalytics(ctx context.Context, q Query) (*Report, error) {
+	return s.report(ctx, ScopeGlobal, pgtype.UUID{}, q)
+}
+
+func (s *DefaultService) report(ctx context.Context, scope string, userID pgtype.UUID, q Query) (*Report, error) {
+	tracer := otel.Tracer("real-staging-api/analytics")
+	ctx, span := tracer.Start(ctx, "analytics.report")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("scope", scope),
+		attribute.String("granularity", q.Granularity),
+		attribute.String("from", q.From.Format(dateLayout)),
+		attribute.String("to", q.To.Format(dateLayout)),
+	)
+
+	fromTime := pgtype.Timestamptz{Time: q.From, Valid: true}
+	toTime := pgtype.Timestamptz{Time: q.To.AddDate(0, 0, 1), Valid: true}
+
+	rows, err := s.querier.GetImageAnalyticsBuckets(ctx, queries.GetImageAnalyticsBucketsParams{
+		Granularity: q.Granularity,
+		FromTime:    fromTime,
+		ToTime:      toTime,
+		UserID:      userID,
+	})
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to aggregate images: %w", err)
+	}
+
+	styles, err := s.querier.GetStylePopularity(ctx, queries.GetStylePopularityParams{
+		FromTime:  fromTime,
+		ToTime:    toTime,
+		UserID:    userID,
+		MaxStyles: MaxStyles,
+	})
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to aggregate styles: %w", err)
+	}
+
+	sources, err := s.querier.GetImageSourceBreakdown(ctx, queries.GetImageSourceBreakdownParams{
+		FromTime: fromTime,
+		ToTime:   toTime,
+		UserID:   userID,
+	})
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to aggregate sources: %w", err)
+	}
+
+	sla, err := s.querier.GetTurnaroundSLAByPlan(ctx, queries.GetTurnaroundSLAByPlanParams{
+		FromTime: fromTime,
+		ToTime:   toTime,
+		UserID:   userID,
+	})
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to aggregate turnaround SLA: %w", err)
+	}
+
+	report := &Report{
+		Scope:       scope,
+		From:        q.From.Format(dateLayout),
+		To:          q.To.Format(dateLayout),
+		Granularity: q.Granularity,
+		Series:      buildSeries(rows, q),
+		Styles:      make([]StyleCount, 0, len(styles)),
+		Sources:     buildSources(sources),
+		SLA:         buildSLA(sla),
+	}
+
+	var turnaroundTotal float64
+	for _, row := range rows {
+		report.Totals.Images += row.Total
+		report.Totals.Ready += row.Ready
+		report.Totals.Failed += row.Failed
+		report.Totals.Canceled += row.Canceled
+		turnaroundTotal += row.AvgTurnaroundSeconds * float64(row.Ready)
+	}
+	report.Totals.InProgress = report.Totals.Images - report.Totals.Ready - report.Totals.Failed - report.Totals.Canceled
+	report.Totals.SuccessRate = successRate(report.Totals.Ready, report.Totals.Failed)
+	if finished := report.Totals.Ready + report.Totals.Failed; finished > 0 {
+		report.Totals.FailureRate = float64(report.Totals.Failed) / float64(finished)
+	}
+	if report.Totals.Ready > 0 {
+		report.Totals.AvgTurnaroundSeconds = turnaroundTotal / float64(report.Totals.Ready)
+	}
+
+	for _, st := range styles {
+		report.Styles = append(report.Styles, StyleCount{Style: st.Style, Images: st.Total})
+	}
+
+	return report, nil
+}
+
+// buildSources converts per-source rows into counts with failure rates.
+func buildSources(rows []*queries.GetImageSourceBreakdownRow) []SourceCount {
+	out := make([]SourceCount, 0, len(rows))
+	for _, row := range rows {
+		sc := SourceCount{
+			Source:   row.Source,
+			Images:   row.Total,
+			Ready:    row.Ready,
+			Failed:   row.Failed,
+			Canceled: row.Canceled,
+		}
+		if finished := row.Ready + row.Failed; finished > 0 {
+			sc.FailureRate = float64(row.Failed) / float64(finished)
+		}
+		out = append(out, sc)
+	}
+	return out
+}
+
+// buildSLA converts per-plan turnaround rows into compliance figures.
+func buildSLA(rows []*queries.GetTurnaroundSLAByPlanRow) []PlanSLA {
+	out := make([]PlanSLA, 0, len(rows))
+	for _, row := range rows {
+		sla := PlanSLA{
+			Plan:                 row.PlanCode,
+			TargetSeconds:        row.TargetMs / 1000,
+			TargetPercent:        row.TargetPercent,
+			Images:               row.Measured,
+			WithinTarget:         row.Met,
+			P95TurnaroundSeconds: row.P95TurnaroundMs / 1000,
+			Credits:              row.Credits,
+		}
+		if row.Measured > 0 {
+			sla.Compliance = float64(row.Met) / float64(row.Measured)
+			sla.Compliant = float64(row.Met)*100 >= row.TargetPercent*float64(row.Measured)
+		}
+		out = append(out, sla)
+	}
+	return out
+}
+
+// buildSeries returns one bucket per day or week in the window, filling gaps
+// with zeroes so clients can chart the series directly.
+func buildSeries(rows []*queries.GetImageAnalyticsBucketsRow, q Query) []Bucket {
+	byDate := make(map[string]*queries.GetImageAnalyticsBucketsRow, len(rows))
+	for _, row := range rows {
+		if row.Bucket.Valid {
+			byDate[row.Bucket.Time.Format(dateLayout)] = row
+		}
+	}
+
+	step := 1
+	if q.Granularity == GranularityWeek {
+		step = 7
+	}
+
+	var series []Bucket
+	for day := bucketStart(q.From, q.Granularity); !day.After(q.To); day = day.AddDate(0, 0, step) {
+		key := day.Format(dateLayout)
+		b := Bucket{Date: key}
+		if row, ok := byDate[key]; ok {
+			b.Images = row.Total
+			b.Ready = row.Ready
+			b.Failed = row.Failed
+			b.Canceled = row.Canceled
+			b.SuccessRate = successRate(row.Ready, row.Failed)
+			b.AvgTurnaroundSeconds = row.AvgTurnaroundSeconds
+		}
+		series = append(series, b)
+	}
+	return series
+}
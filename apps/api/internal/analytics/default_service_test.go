@@ -0,0 +1,254 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+func date(s string) pgtype.Date {
+	t, _ := time.Parse(dateLayout, s)
+	return pgtype.Date{Time: t, Valid: true}
+}
+
+func TestDefaultService_GetUserAnalytics(t *testing.T) {
+	q := Query{
+		From:        time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:          time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC),
+		Granularity: GranularityDay,
+	}
+	userID := uuid.New()
+
+	t.Run("success: aggregates totals and fills gaps", func(t *testing.T) {
+		querier := &queries.QuerierMock{
+			GetImageAnalyticsBucketsFunc: func(
+				ctx context.Context, arg queries.GetImageAnalyticsBucketsParams,
+			) ([]*queries.GetImageAnalyticsBucketsRow, error) {
+				return []*queries.GetImageAnalyticsBucketsRow{
+					{Bucket: date("2025-01-01"), Total: 10, Ready: 6, Failed: 2, Canceled: 1, AvgTurnaroundSeconds: 30},
+					{Bucket: date("2025-01-03"), Total: 4, Ready: 2, Failed: 0, Canceled: 0, AvgTurnaroundSeconds: 60},
+				}, nil
+			},
+			GetStylePopularityFunc: func(
+				ctx context.Context, arg queries.GetStylePopularityParams,
+			) ([]*queries.GetStylePopularityRow, error) {
+				return []*queries.GetStylePopularityRow{
+					{Style: "modern", Total: 9},
+					{Style: "unspecified", Total: 5},
+				}, nil
+			},
+			GetImageSourceBreakdownFunc: func(
+				ctx context.Context, arg queries.GetImageSourceBreakdownParams,
+			) ([]*queries.GetImageSourceBreakdownRow, error) {
+				return []*queries.GetImageSourceBreakdownRow{
+					{Source: "web", Total: 10, Ready: 6, Failed: 2, Canceled: 1},
+					{Source: "mobile", Total: 4, Ready: 2},
+				}, nil
+			},
+			GetTurnaroundSLAByPlanFunc: func(
+				ctx context.Context, arg queries.GetTurnaroundSLAByPlanParams,
+			) ([]*queries.GetTurnaroundSLAByPlanRow, error) {
+				return []*queries.GetTurnaroundSLAByPlanRow{
+					{PlanCode: "basic", TargetMs: 600_000, TargetPercent: 95, Measured: 20, Met: 19, P95TurnaroundMs: 480_000},
+					{
+						PlanCode: "pro", TargetMs: 600_000, TargetPercent: 95, Measured: 10, Met: 9,
+						P95TurnaroundMs: 720_500, Credits: 1,
+					},
+				}, nil
+			},
+		}
+		svc := NewDefaultServiceWithQuerier(querier)
+
+		report, err := svc.GetUserAnalytics(context.Background(), userID.String(), q)
+		require.NoError(t, err)
+
+		assert.Equal(t, ScopeUser, report.Scope)
+		assert.Equal(t, "2025-01-01", report.From)
+		assert.Equal(t, "2025-01-03", report.To)
+		assert.Equal(t, Totals{
+			Images:               14,
+			Ready:                8,
+			Failed:               2,
+			Canceled:             1,
+			InProgress:           3,
+			SuccessRate:          0.8,
+			FailureRate:          0.2,
+			AvgTurnaroundSeconds: 37.5,
+		}, report.Totals)
+
+		require.Len(t, report.Series, 3)
+		assert.Equal(t, "2025-01-02", report.Series[1].Date)
+		assert.Zero(t, report.Series[1].Images)
+		assert.Equal(t, 0.75, report.Series[0].SuccessRate)
+		assert.Equal(t, []StyleCount{{Style: "modern", Images: 9}, {Style: "unspecified", Images: 5}}, report.Styles)
+		assert.Equal(t, []SourceCount{
+			{Source: "web", Images: 10, Ready: 6, Failed: 2, Canceled: 1, FailureRate: 0.25},
+			{Source: "mobile", Images: 4, Ready: 2},
+		}, report.Sources)
+		assert.Equal(t, pgtype.UUID{Bytes: userID, Valid: true}, querier.GetImageSourceBreakdownCalls()[0].Arg.UserID)
+
+		// The query window is half-open and scoped to the user.
+		args := querier.GetImageAnalyticsBucketsCalls()[0].Arg
+		assert.Equal(t, time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC), args.ToTime.Time)
+		assert.Equal(t, pgtype.UUID{Bytes: userID, Valid: true}, args.UserID)
+		assert.Equal(t, int32(MaxStyles), querier.GetStylePopularityCalls()[0].Arg.MaxStyles)
+
+		assert.Equal(t, []PlanSLA{
+			{
+				Plan: "basic", TargetSeconds: 600, TargetPercent: 95, Images: 20, WithinTarget: 19,
+				Compliance: 0.95, Compliant: true, P95TurnaroundSeconds: 480,
+			},
+			{
+				Plan: "pro", TargetSeconds: 600, TargetPercent: 95, Images: 10, WithinTarget: 9,
+				Compliance: 0.9, P95TurnaroundSeconds: 720.5, Credits: 1,
+			},
+		}, report.SLA)
+		assert.Equal(t, pgtype.UUID{Bytes: userID, Valid: true}, querier.GetTurnaroundSLAByPlanCalls()[0].Arg.UserID)
+	})
+
+	t.Run("fail: invalid user id", func(t *testing.T) {
+		svc := NewDefaultServiceWithQuerier(&queries.QuerierMock{})
+		_, err := svc.GetUserAnalytics(context.Background(), "nope", q)
+		assert.Error(t, err)
+	})
+
+	t.Run("fail: bucket query error", func(t *testing.T) {
+		querier := &queries.QuerierMock{
+			GetImageAnalyticsBucketsFunc: func(
+				ctx context.Context, arg queries.GetImageAnalyticsBucketsParams,
+			) ([]*queries.GetImageAnalyticsBucketsRow, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		svc := NewDefaultServiceWithQuerier(querier)
+		_, err := svc.GetUserAnalytics(context.Background(), userID.String(), q)
+		assert.EqualError(t, err, "failed to aggregate images: db error")
+	})
+
+	t.Run("fail: style query error", func(t *testing.T) {
+		querier := &queries.QuerierMock{
+			GetImageAnalyticsBucketsFunc: func(
+				ctx context.Context, arg queries.GetImageAnalyticsBucketsParams,
+			) ([]*queries.GetImageAnalyticsBucketsRow, error) {
+				return nil, nil
+			},
+			GetStylePopularityFunc: func(
+				ctx context.Context, arg queries.GetStylePopularityParams,
+			) ([]*queries.GetStylePopularityRow, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		svc := NewDefaultServiceWithQuerier(querier)
+		_, err := svc.GetUserAnalytics(context.Background(), userID.String(), q)
+		assert.EqualError(t, err, "failed to aggregate styles: db error")
+	})
+
+	t.Run("fail: source query error", func(t *testing.T) {
+		querier := &queries.QuerierMock{
+			GetImageAnalyticsBucketsFunc: func(
+				ctx context.Context, arg queries.GetImageAnalyticsBucketsParams,
+			) ([]*queries.GetImageAnalyticsBucketsRow, error) {
+				return nil, nil
+			},
+			GetStylePopularityFunc: func(
+				ctx context.Context, arg queries.GetStylePopularityParams,
+			) ([]*queries.GetStylePopularityRow, error) {
+				return nil, nil
+			},
+			GetImageSourceBreakdownFunc: func(
+				ctx context.Context, arg queries.GetImageSourceBreakdownParams,
+			) ([]*queries.GetImageSourceBreakdownRow, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		svc := NewDefaultServiceWithQuerier(querier)
+		_, err := svc.GetUserAnalytics(context.Background(), userID.String(), q)
+		assert.EqualError(t, err, "failed to aggregate sources: db error")
+	})
+
+	t.Run("fail: sla query error", func(t *testing.T) {
+		querier := &queries.QuerierMock{
+			GetImageAnalyticsBucketsFunc: func(
+				ctx context.Context, arg queries.GetImageAnalyticsBucketsParams,
+			) ([]*queries.GetImageAnalyticsBucketsRow, error) {
+				return nil, nil
+			},
+			GetStylePopularityFunc: func(
+				ctx context.Context, arg queries.GetStylePopularityParams,
+			) ([]*queries.GetStylePopularityRow, error) {
+				return nil, nil
+			},
+			GetImageSourceBreakdownFunc: func(
+				ctx context.Context, arg queries.GetImageSourceBreakdownParams,
+			) ([]*queries.GetImageSourceBreakdownRow, error) {
+				return nil, nil
+			},
+			GetTurnaroundSLAByPlanFunc: func(
+				ctx context.Context, arg queries.GetTurnaroundSLAByPlanParams,
+			) ([]*queries.GetTurnaroundSLAByPlanRow, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		svc := NewDefaultServiceWithQuerier(querier)
+		_, err := svc.GetUserAnalytics(context.Background(), userID.String(), q)
+		assert.EqualError(t, err, "failed to aggregate turnaround SLA: db error")
+	})
+}
+
+func TestDefaultService_GetGlobalAnalytics(t *testing.T) {
+	q := Query{
+		From:        time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:          time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC),
+		Granularity: GranularityWeek,
+	}
+	querier := &queries.QuerierMock{
+		GetImageAnalyticsBucketsFunc: func(
+			ctx context.Context, arg queries.GetImageAnalyticsBucketsParams,
+		) ([]*queries.GetImageAnalyticsBucketsRow, error) {
+			return []*queries.GetImageAnalyticsBucketsRow{
+				{Bucket: date("2025-01-06"), Total: 3, Ready: 3, AvgTurnaroundSeconds: 12},
+			}, nil
+		},
+		GetStylePopularityFunc: func(
+			ctx context.Context, arg queries.GetStylePopularityParams,
+		) ([]*queries.GetStylePopularityRow, error) {
+			return nil, nil
+		},
+		GetImageSourceBreakdownFunc: func(
+			ctx context.Context, arg queries.GetImageSourceBreakdownParams,
+		) ([]*queries.GetImageSourceBreakdownRow, error) {
+			return nil, nil
+		},
+		GetTurnaroundSLAByPlanFunc: func(
+			ctx context.Context, arg queries.GetTurnaroundSLAByPlanParams,
+		) ([]*queries.GetTurnaroundSLAByPlanRow, error) {
+			return nil, nil
+		},
+	}
+	svc := NewDefaultServiceWithQuerier(querier)
+
+	report, err := svc.GetGlobalAnalytics(context.Background(), q)
+	require.NoError(t, err)
+	assert.Equal(t, ScopeGlobal, report.Scope)
+	assert.False(t, querier.GetImageAnalyticsBucketsCalls()[0].Arg.UserID.Valid)
+
+	// Weekly buckets start on the Monday on or before the range start.
+	var dates []string
+	for _, b := range report.Series {
+		dates = append(dates, b.Date)
+	}
+	assert.Equal(t, []string{"2024-12-30", "2025-01-06", "2025-01-13"}, dates)
+	assert.Equal(t, int64(3), report.Series[1].Images)
+	assert.Equal(t, 1.0, report.Totals.SuccessRate)
+	assert.Empty(t, report.Styles)
+	assert.Empty(t, report.Sources)
+	assert.Empty(t, report.SLA)
+}
@@ -0,0 +1,12 @@
+package analytics
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP-level contract for analytics endpoints.
+// Implementations should be wired to Echo routes in the server.
+type Handler interface {
+	GetMyAnalytics(c echo.Context) error
+	GetGlobalAnalytics(c echo.Context) error
+}
@@ -0,0 +1,13 @@
+package analytics
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service defines the interface for usage analytics.
+type Service interface {
+	// GetUserAnalytics reports activity across all projects owned by userID.
+	GetUserAnalytics(ctx context.Context, userID string, q Query) (*Report, error)
+	// GetGlobalAnalytics reports activity across every user.
+	GetGlobalAnalytics(ctx context.Context, q Query) (*Report, error)
+}
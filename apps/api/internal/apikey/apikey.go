@@ -0,0 +1,80 @@
+// Package apikey issues API keys that let integrations call the API as their owner, limited
+// to the scopes each key was issued with.
+package apikey
+
+import (
+	"crypto/sha256"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Prefix starts every API key, so keys are told apart from Auth0 access tokens.
+const Prefix = "rsk_"
+
+const (
+	// displayLength is how many leading characters of a key are kept to show owners.
+	displayLength = 12
+	// maxKeys caps the active keys an account may hold.
+	maxKeys       = 25
+	maxNameLength = 100
+)
+
+var (
+	// ErrNotFound is returned when the key does not exist, belongs to someone else or is
+	// already revoked.
+	ErrNotFound = errors.New("api key not found")
+	// ErrInvalid is returned for malformed requests.
+	ErrInvalid = errors.New("invalid api key request")
+	// ErrLimit is returned when the account already holds maxKeys active keys.
+	ErrLimit = errors.New("too many api keys")
+	// ErrUnauthorized is returned for unknown, revoked and expired keys.
+	ErrUnauthorized = errors.New("invalid api key")
+)
+
+// Key is an issued API key without its secret.
+type Key struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	// Sandbox keys act on the sandbox tenant, whose data is reset nightly.
+	Sandbox bool `json:"sandbox"`
+}
+
+// CreatedKey is a newly issued key. Its secret is only ever returned here.
+type CreatedKey struct {
+	Key
+	Secret string `json:"key"`
+}
+
+// CreateRequest is the body of POST /api/v1/me/api-keys.
+type CreateRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Sandbox   bool       `json:"sandbox,omitempty"`
+}
+
+// Principal is the account and scopes an API key authenticates as.
+type Principal struct {
+	KeyID    string
+	Auth0Sub string
+	Scopes   []string
+	// Sandbox is set for keys that act on the sandbox tenant.
+	Sandbox bool
+}
+
+// IsKey reports whether a bearer credential is an API key rather than an access token.
+func IsKey(credential string) bool {
+	return strings.HasPrefix(credential, Prefix)
+}
+
+// Hash returns the digest a key is stored and looked up as.
+func Hash(key string) []byte {
+	sum := sha256.Sum256([]byte(key))
+	return sum[:]
+}
@@ -0,0 +1,105 @@
+package apikey
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// DefaultHandler serves the signed-in user's API keys over HTTP.
+type DefaultHandler struct {
+	service  Service
+	userRepo user.Repository
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, userRepo user.Repository) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo}
+}
+
+// ErrorResponse is a simple JSON error envelope for handler responses.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// ListResponse wraps the user's API keys.
+type ListResponse struct {
+	Keys []Key `json:"keys"`
+}
+
+// Create handles POST /api/v1/me/api-keys.
+func (h *DefaultHandler) Create(c echo.Context) error {
+	var req CreateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid request format"})
+	}
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	key, err := h.service.Create(c.Request().Context(), userID, req)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusCreated, key)
+}
+
+// List handles GET /api/v1/me/api-keys.
+func (h *DefaultHandler) List(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	keys, err := h.service.List(c.Request().Context(), userID)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, ListResponse{Keys: keys})
+}
+
+// Revoke handles DELETE /api/v1/me/api-keys/:id.
+func (h *DefaultHandler) Revoke(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	if err := h.service.Revoke(c.Request().Context(), userID, c.Param("id")); err != nil {
+		return h.writeError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func unauthorized(c echo.Context) error {
+	return c.JSON(http.StatusUnauthorized, ErrorResponse{
+		Error:   "unauthorized",
+		Message: "Unable to resolve current user",
+	})
+}
+
+// writeError maps service errors to responses.
+func (h *DefaultHandler) writeError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "API key not found"})
+	case errors.Is(err, ErrInvalid):
+		return c.JSON(http.StatusUnprocessableEntity, ErrorResponse{Error: "validation_failed", Message: err.Error()})
+	case errors.Is(err, ErrLimit):
+		return c.JSON(http.StatusConflict, ErrorResponse{Error: "limit_reached", Message: err.Error()})
+	default:
+		c.Logger().Errorf("API key request failed: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to process API key request",
+		})
+	}
+}
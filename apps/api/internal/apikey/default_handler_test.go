@@ -0,0 +1,165 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func userRepoFor(userID uuid.UUID) *user.RepositoryMock {
+	return &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+		},
+	}
+}
+
+func newContext(method, target, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("X-Test-User", "auth0|user")
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
+
+func TestDefaultHandler_Create(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: created", body: `{"name":"MLS feed","scopes":["images:write"]}`, expectedStatus: http.StatusCreated},
+		{name: "fail: malformed body", body: `{`, expectedStatus: http.StatusBadRequest},
+		{name: "fail: invalid", body: `{}`, err: fmt.Errorf("%w: scopes", ErrInvalid), expectedStatus: http.StatusUnprocessableEntity},
+		{name: "fail: too many keys", body: `{}`, err: ErrLimit, expectedStatus: http.StatusConflict},
+		{name: "fail: database error", body: `{}`, err: errors.New("boom"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				CreateFunc: func(ctx context.Context, uid string, req CreateRequest) (*CreatedKey, error) {
+					assert.Equal(t, userID.String(), uid)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &CreatedKey{Key: Key{Name: req.Name, Scopes: req.Scopes}, Secret: "rsk_secret"}, nil
+				},
+			}
+			c, rec := newContext(http.MethodPost, "/api/v1/me/api-keys", tc.body)
+
+			require.NoError(t, NewDefaultHandler(svc, userRepoFor(userID)).Create(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusCreated {
+				assert.Contains(t, rec.Body.String(), `"key":"rsk_secret"`)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_Revoke(t *testing.T) {
+	svc := &ServiceMock{
+		RevokeFunc: func(ctx context.Context, userID, keyID string) error {
+			if keyID == "missing" {
+				return ErrNotFound
+			}
+			return nil
+		},
+	}
+	h := NewDefaultHandler(svc, userRepoFor(uuid.New()))
+
+	c, rec := newContext(http.MethodDelete, "/api/v1/me/api-keys/k-1", "")
+	c.SetParamNames("id")
+	c.SetParamValues("k-1")
+	require.NoError(t, h.Revoke(c))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	c, rec = newContext(http.MethodDelete, "/api/v1/me/api-keys/missing", "")
+	c.SetParamNames("id")
+	c.SetParamValues("missing")
+	require.NoError(t, h.Revoke(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestMiddleware(t *testing.T) {
+	testCases := []struct {
+		name           string
+		header         string
+		sandbox        bool
+		err            error
+		expectFallback bool
+		expectedStatus int
+	}{
+		{name: "success: API key", header: "Bearer rsk_secret", expectedStatus: http.StatusOK},
+		{name: "success: sandbox API key", header: "Bearer rsk_secret", sandbox: true, expectedStatus: http.StatusOK},
+		{name: "success: access token goes to the fallback", header: "Bearer eyJhbGciOi", expectFallback: true, expectedStatus: http.StatusOK},
+		{name: "success: no header goes to the fallback", expectFallback: true, expectedStatus: http.StatusOK},
+		{name: "fail: revoked key", header: "Bearer rsk_secret", err: ErrUnauthorized, expectedStatus: http.StatusUnauthorized},
+		{name: "fail: lookup error", header: "Bearer rsk_secret", err: errors.New("db down"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				AuthenticateFunc: func(ctx context.Context, key string) (*Principal, error) {
+					assert.Equal(t, "rsk_secret", key)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &Principal{KeyID: "k-1", Auth0Sub: "auth0|jane", Scopes: []string{"images:write", "projects:read"}, Sandbox: tc.sandbox}, nil
+				},
+			}
+			fellBack := false
+			fallback := func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					fellBack = true
+					return next(c)
+				}
+			}
+			c, rec := newContext(http.MethodGet, "/api/v1/projects", "")
+			if tc.header != "" {
+				c.Request().Header.Set(echo.HeaderAuthorization, tc.header)
+			}
+
+			err := Middleware(svc, fallback)(func(c echo.Context) error {
+				if !tc.expectFallback {
+					sub, err := auth.GetUserID(c)
+					require.NoError(t, err)
+					assert.Equal(t, "auth0|jane", sub)
+					scopes, restricted := auth.GetScopes(c)
+					assert.True(t, restricted)
+					assert.Equal(t, []string{"images:write", "projects:read"}, scopes)
+				}
+				assert.Equal(t, tc.sandbox, storage.SandboxFrom(c.Request().Context()))
+				return c.NoContent(http.StatusOK)
+			})(c)
+			var he *echo.HTTPError
+			if errors.As(err, &he) {
+				assert.Equal(t, tc.expectedStatus, he.Code)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedStatus, rec.Code)
+			}
+			assert.Equal(t, tc.expectFallback, fellBack)
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// DefaultService implements Service.
+type DefaultService struct {
+	querier     queries.Querier
+	now         func() time.Time
+	sandboxKeys bool
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// Option configures a DefaultService.
+type Option func(*DefaultService)
+
+// WithSandboxKeys lets accounts issue sandbox keys.
+func WithSandboxKeys() Option {
+	return func(s *DefaultService) { s.sandboxKeys = true }
+}
+
+// NewDefaultService creates a new DefaultService with a database.
+func NewDefaultService(db storage.Database, opts ...Option) *DefaultService {
+	return NewDefaultServiceWithQuerier(queries.New(db), opts...)
+}
+
+// NewDefaultServiceWithQuerier creates a new DefaultService with a custom querier (for testing).
+func NewDefaultServiceWithQuerier(querier queries.Querier, opts ...Option) *DefaultService {
+	s := &DefaultService{querier: querier, now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Create issues a key for the user with the requested scopes.
+func (s *DefaultService) Create(ctx context.Context, userID string, req CreateRequest) (*CreatedKey, error) {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > maxNameLength {
+		return nil, fmt.Errorf("%w: name must be 1 to %d characters", ErrInvalid, maxNameLength)
+	}
+	if len(req.Scopes) == 0 {
+		return nil, fmt.Errorf("%w: at least one scope is required", ErrInvalid)
+	}
+	scopes, err := auth.ParseScopes(req.Scopes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+	if req.Sandbox && !s.sandboxKeys {
+		return nil, fmt.Errorf("%w: sandbox keys are not enabled", ErrInvalid)
+	}
+	expiresAt := pgtype.Timestamptz{}
+	if req.ExpiresAt != nil {
+		if !req.ExpiresAt.After(s.now()) {
+			return nil, fmt.Errorf("%w: expires_at must be in the future", ErrInvalid)
+		}
+		expiresAt = pgtype.Timestamptz{Time: *req.ExpiresAt, Valid: true}
+	}
+
+	count, err := s.querier.CountAPIKeysByUser(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count api keys: %w", err)
+	}
+	if count >= maxKeys {
+		return nil, fmt.Errorf("%w: revoke one of your %d keys first", ErrLimit, maxKeys)
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("failed to generate api key: %w", err)
+	}
+	secret := Prefix + base64.RawURLEncoding.EncodeToString(buf)
+	row, err := s.querier.CreateAPIKey(ctx, queries.CreateAPIKeyParams{
+		UserID:    uid,
+		Name:      name,
+		Prefix:    secret[:displayLength],
+		KeyHash:   Hash(secret),
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+		Sandbox:   req.Sandbox,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save api key: %w", err)
+	}
+	return &CreatedKey{Key: toKey(row), Secret: secret}, nil
+}
+
+// List returns the user's keys that are not revoked, newest first.
+func (s *DefaultService) List(ctx context.Context, userID string) ([]Key, error) {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	rows, err := s.querier.ListAPIKeysByUser(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	keys := make([]Key, 0, len(rows))
+	for _, row := range rows {
+		keys = append(keys, toKey(row))
+	}
+	return keys, nil
+}
+
+// Revoke stops a key of the user from working.
+func (s *DefaultService) Revoke(ctx context.Context, userID, keyID string) error {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+	kid, err := parseUUID(keyID)
+	if err != nil {
+		return ErrNotFound
+	}
+	n, err := s.querier.RevokeAPIKey(ctx, queries.RevokeAPIKeyParams{ID: kid, UserID: uid})
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Authenticate resolves a key to the account and scopes it acts with, and records its use.
+func (s *DefaultService) Authenticate(ctx context.Context, key string) (*Principal, error) {
+	if !IsKey(key) {
+		return nil, ErrUnauthorized
+	}
+	row, err := s.querier.GetAPIKeyByHash(ctx, Hash(key))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrUnauthorized
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up api key: %w", err)
+	}
+	if row.Sandbox && !s.sandboxKeys {
+		// Without the sandbox tenant the key's requests would act on production data
+		return nil, ErrUnauthorized
+	}
+	if err := s.querier.TouchAPIKey(ctx, row.ID); err != nil {
+		// Losing a last-used time is no reason to refuse the request
+		logging.Default().Warn(ctx, "Failed to record api key use", "api_key_id", row.ID.String(), "error", err)
+	}
+	return &Principal{KeyID: row.ID.String(), Auth0Sub: row.Auth0Sub, Scopes: row.Scopes, Sandbox: row.Sandbox}, nil
+}
+
+func parseUUID(s string) (pgtype.UUID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+	return pgtype.UUID{Bytes: id, Valid: true}, nil
+}
+
+func toKey(row *queries.ApiKey) Key {
+	k := Key{
+		ID:        row.ID.String(),
+		Name:      row.Name,
+		Prefix:    row.Prefix,
+		Scopes:    row.Scopes,
+		CreatedAt: row.CreatedAt.Time,
+		Sandbox:   row.Sandbox,
+	}
+	if row.LastUsedAt.Valid {
+		t := row.LastUsedAt.Time
+		k.LastUsedAt = &t
+	}
+	if row.ExpiresAt.Valid {
+		t := row.ExpiresAt.Time
+		k.ExpiresAt = &t
+	}
+	return k
+}
@@ -0,0 +1,159 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+func TestDefaultService_Create(t *testing.T) {
+	userID := uuid.New()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	future := now.Add(24 * time.Hour)
+	past := now.Add(-time.Hour)
+
+	testCases := []struct {
+		name      string
+		req       CreateRequest
+		opts      []Option
+		count     int64
+		expectErr error
+	}{
+		{name: "success: created", req: CreateRequest{Name: " MLS feed ", Scopes: []string{"images:write", "projects:read"}}},
+		{name: "success: expiring", req: CreateRequest{Name: "MLS feed", Scopes: []string{"images:*"}, ExpiresAt: &future}},
+		{name: "success: sandbox key", req: CreateRequest{Name: "MLS feed", Scopes: []string{"images:write"}, Sandbox: true}, opts: []Option{WithSandboxKeys()}},
+		{name: "fail: sandbox keys disabled", req: CreateRequest{Name: "MLS feed", Scopes: []string{"images:write"}, Sandbox: true}, expectErr: ErrInvalid},
+		{name: "fail: no name", req: CreateRequest{Name: " ", Scopes: []string{"images:write"}}, expectErr: ErrInvalid},
+		{name: "fail: no scopes", req: CreateRequest{Name: "MLS feed"}, expectErr: ErrInvalid},
+		{name: "fail: unknown scope", req: CreateRequest{Name: "MLS feed", Scopes: []string{"everything"}}, expectErr: ErrInvalid},
+		{name: "fail: expired", req: CreateRequest{Name: "MLS feed", Scopes: []string{"images:write"}, ExpiresAt: &past}, expectErr: ErrInvalid},
+		{name: "fail: too many keys", req: CreateRequest{Name: "MLS feed", Scopes: []string{"images:write"}}, count: maxKeys, expectErr: ErrLimit},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var saved queries.CreateAPIKeyParams
+			q := &queries.QuerierMock{
+				CountAPIKeysByUserFunc: func(ctx context.Context, uid pgtype.UUID) (int64, error) {
+					return tc.count, nil
+				},
+				CreateAPIKeyFunc: func(ctx context.Context, arg queries.CreateAPIKeyParams) (*queries.ApiKey, error) {
+					saved = arg
+					return &queries.ApiKey{
+						ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, UserID: arg.UserID, Name: arg.Name,
+						Prefix: arg.Prefix, Scopes: arg.Scopes, ExpiresAt: arg.ExpiresAt, Sandbox: arg.Sandbox,
+						CreatedAt: pgtype.Timestamptz{Time: now, Valid: true},
+					}, nil
+				},
+			}
+			svc := NewDefaultServiceWithQuerier(q, tc.opts...)
+			svc.now = func() time.Time { return now }
+
+			key, err := svc.Create(context.Background(), userID.String(), tc.req)
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+				assert.Empty(t, q.CreateAPIKeyCalls())
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(key.Secret, Prefix))
+			assert.Equal(t, key.Secret[:displayLength], key.Prefix)
+			assert.Equal(t, Hash(key.Secret), saved.KeyHash)
+			assert.Equal(t, "MLS feed", key.Name)
+			assert.Equal(t, tc.req.ExpiresAt, key.ExpiresAt)
+			assert.IsIncreasing(t, key.Scopes)
+			assert.Equal(t, tc.req.Sandbox, saved.Sandbox)
+			assert.Equal(t, tc.req.Sandbox, key.Sandbox)
+		})
+	}
+}
+
+func TestDefaultService_Revoke(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name      string
+		keyID     string
+		rows      int64
+		expectErr error
+	}{
+		{name: "success: revoked", keyID: uuid.NewString(), rows: 1},
+		{name: "fail: someone else's or revoked", keyID: uuid.NewString(), expectErr: ErrNotFound},
+		{name: "fail: malformed id", keyID: "key", expectErr: ErrNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				RevokeAPIKeyFunc: func(ctx context.Context, arg queries.RevokeAPIKeyParams) (int64, error) {
+					assert.Equal(t, pgtype.UUID{Bytes: userID, Valid: true}, arg.UserID)
+					return tc.rows, nil
+				},
+			}
+			err := NewDefaultServiceWithQuerier(q).Revoke(context.Background(), userID.String(), tc.keyID)
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDefaultService_Authenticate(t *testing.T) {
+	keyID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	testCases := []struct {
+		name      string
+		key       string
+		lookupErr error
+		sandbox   bool
+		opts      []Option
+		touchErr  error
+		expectErr error
+	}{
+		{name: "success: valid key", key: "rsk_secret"},
+		{name: "success: sandbox key", key: "rsk_secret", sandbox: true, opts: []Option{WithSandboxKeys()}},
+		{name: "fail: sandbox key while the sandbox is disabled", key: "rsk_secret", sandbox: true, expectErr: ErrUnauthorized},
+		{name: "success: last use not recorded", key: "rsk_secret", touchErr: errors.New("db busy")},
+		{name: "fail: not a key", key: "eyJhbGciOi", expectErr: ErrUnauthorized},
+		{name: "fail: unknown, revoked or expired", key: "rsk_other", lookupErr: pgx.ErrNoRows, expectErr: ErrUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				GetAPIKeyByHashFunc: func(ctx context.Context, keyHash []byte) (*queries.GetAPIKeyByHashRow, error) {
+					assert.Equal(t, Hash(tc.key), keyHash)
+					if tc.lookupErr != nil {
+						return nil, tc.lookupErr
+					}
+					return &queries.GetAPIKeyByHashRow{ID: keyID, Scopes: []string{"images:write"}, Auth0Sub: "auth0|jane", Sandbox: tc.sandbox}, nil
+				},
+				TouchAPIKeyFunc: func(ctx context.Context, id pgtype.UUID) error {
+					return tc.touchErr
+				},
+			}
+
+			principal, err := NewDefaultServiceWithQuerier(q, tc.opts...).Authenticate(context.Background(), tc.key)
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, &Principal{
+				KeyID: keyID.String(), Auth0Sub: "auth0|jane", Scopes: []string{"images:write"}, Sandbox: tc.sandbox,
+			}, principal)
+		})
+	}
+}
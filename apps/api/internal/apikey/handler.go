@@ -0,0 +1,13 @@
+package apikey
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP-level contract for the API key endpoints.
+// Implementations should be wired to Echo routes in the server.
+type Handler interface {
+	Create(c echo.Context) error
+	List(c echo.Context) error
+	Revoke(c echo.Context) error
+}
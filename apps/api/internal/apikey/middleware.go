@@ -0,0 +1,55 @@
+package apikey
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// Middleware authenticates requests bearing an API key in the Authorization header and
+// hands every other request to next, normally the JWT middleware. A valid key is stored in
+// the context as a token for its owner carrying the key's scopes, so handlers resolve the
+// user as they do for access tokens and auth.RequireScopes can enforce the scopes. Requests
+// with a sandbox key carry storage.WithSandbox in their context, so they act on the sandbox
+// tenant. Keys are not read from query parameters, where they would end up in logs.
+func Middleware(svc Service, next echo.MiddlewareFunc) echo.MiddlewareFunc {
+	return func(handler echo.HandlerFunc) echo.HandlerFunc {
+		fallback := next(handler)
+		return func(c echo.Context) error {
+			credential, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+			if !ok || !IsKey(credential) {
+				return fallback(c)
+			}
+
+			principal, err := svc.Authenticate(c.Request().Context(), credential)
+			if errors.Is(err, ErrUnauthorized) {
+				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid, expired or revoked API key")
+			}
+			if err != nil {
+				c.Logger().Errorf("API key authentication failed: %v", err)
+				return c.JSON(http.StatusInternalServerError, ErrorResponse{
+					Error:   "internal_server_error",
+					Message: "Failed to authenticate API key",
+				})
+			}
+			if principal.Sandbox {
+				c.SetRequest(c.Request().WithContext(storage.WithSandbox(c.Request().Context())))
+			}
+			c.Set("user", &jwt.Token{
+				Valid: true,
+				Claims: jwt.MapClaims{
+					"sub":            principal.Auth0Sub,
+					auth.ScopeClaim:  strings.Join(principal.Scopes, " "),
+					auth.APIKeyClaim: principal.KeyID,
+				},
+			})
+			return handler(c)
+		}
+	}
+}
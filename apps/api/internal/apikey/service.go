@@ -0,0 +1,17 @@
+package apikey
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service issues, lists and revokes API keys and authenticates requests made with them.
+type Service interface {
+	// Create issues a key for the user with the requested scopes.
+	Create(ctx context.Context, userID string, req CreateRequest) (*CreatedKey, error)
+	// List returns the user's keys that are not revoked, newest first.
+	List(ctx context.Context, userID string) ([]Key, error)
+	// Revoke stops a key of the user from working.
+	Revoke(ctx context.Context, userID, keyID string) error
+	// Authenticate resolves a key to the account and scopes it acts with.
+	Authenticate(ctx context.Context, key string) (*Principal, error)
+}
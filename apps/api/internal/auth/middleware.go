@@ -13,6 +13,8 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	echojwt "github.com/labstack/echo-jwt/v4"
 	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/httpclient"
 )
 
 // JWKSet represents a JSON Web Key Set
@@ -128,6 +130,9 @@ func OptionalJWTMiddleware(config *Auth0Config) echo.MiddlewareFunc {
 	}
 }
 
+// jwksClient fetches Auth0 signing keys.
+var jwksClient = httpclient.New(httpclient.DestinationAuth0)
+
 // getPublicKey fetches and parses the public key from Auth0's JWKS endpoint
 func getPublicKey(ctx context.Context, domain, kid string) (*rsa.PublicKey, error) {
 	jwksURL := fmt.Sprintf("https://%s/.well-known/jwks.json", domain)
@@ -136,7 +141,7 @@ func getPublicKey(ctx context.Context, domain, kid string) (*rsa.PublicKey, erro
 	if err != nil {
 		return nil, fmt.Errorf("failed to create JWKS request: %w", err)
 	}
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := jwksClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
 	}
@@ -185,6 +190,22 @@ func parseRSAPublicKey(jwk JWK) (*rsa.PublicKey, error) {
 	}, nil
 }
 
+// GetClaims returns all claims of the JWT token in context, including custom claims that
+// Auth0 Actions add under a namespace.
+func GetClaims(c echo.Context) (jwt.MapClaims, error) {
+	token, ok := c.Get("user").(*jwt.Token)
+	if !ok {
+		return nil, fmt.Errorf("no JWT token found in context")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, fmt.Errorf("invalid JWT claims")
+	}
+
+	return claims, nil
+}
+
 // GetUserID extracts user ID from JWT token in context
 func GetUserID(c echo.Context) (string, error) {
 	token, ok := c.Get("user").(*jwt.Token)
@@ -247,9 +247,9 @@ func TestJWTMiddleware(t *testing.T) {
 			}))
 			defer server.Close()
 
-			originalClient := http.DefaultClient
-			http.DefaultClient = server.Client()
-			defer func() { http.DefaultClient = originalClient }()
+			originalClient := jwksClient
+			jwksClient = server.Client()
+			defer func() { jwksClient = originalClient }()
 
 			domain := strings.TrimPrefix(server.URL, "https://")
 			if tc.customDomain != "" {
@@ -327,9 +327,9 @@ func TestOptionalJWTMiddleware(t *testing.T) {
 	}))
 	defer server.Close()
 
-	originalClient := http.DefaultClient
-	http.DefaultClient = server.Client()
-	defer func() { http.DefaultClient = originalClient }()
+	originalClient := jwksClient
+	jwksClient = server.Client()
+	defer func() { jwksClient = originalClient }()
 
 	domain := strings.TrimPrefix(server.URL, "https://")
 	config := &Auth0Config{
@@ -536,3 +536,16 @@ func TestGetUserIDOrDefault(t *testing.T) {
 func TestGetUserEmail(t *testing.T) {
 	testJWTClaimExtractor(t, GetUserEmail, "email", "test@example.com", "email claim not found")
 }
+
+func TestGetClaims(t *testing.T) {
+	e := echo.New()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+
+	_, err := GetClaims(c)
+	assert.ErrorContains(t, err, "no JWT token found in context")
+
+	c.Set("user", &jwt.Token{Claims: jwt.MapClaims{"sub": "auth0|1", "https://example.com/groups": []any{"admins"}}})
+	claims, err := GetClaims(c)
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"admins"}, claims["https://example.com/groups"])
+}
@@ -0,0 +1,174 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Scope actions. A scope is "<resource>:<action>"; the action "*" grants every action on
+// the resource.
+const (
+	ActionRead   = "read"
+	ActionWrite  = "write"
+	ActionDelete = "delete"
+	ActionAll    = "*"
+)
+
+const (
+	// ScopeClaim is the access token claim carrying space-separated scopes, as in OAuth 2.0.
+	ScopeClaim = "scope"
+	// APIKeyClaim names the API key a request authenticated with. Such requests are always
+	// limited to their scopes.
+	APIKeyClaim = "api_key_id"
+)
+
+// ErrInvalidScope is returned for scopes outside the catalogue.
+var ErrInvalidScope = errors.New("invalid scope")
+
+// resourceActions lists the API's scope resources and the actions each one has. Resources
+// without delete treat deletes as writes.
+var resourceActions = map[string][]string{
+	"account":       {ActionRead, ActionWrite},
+	"admin":         {ActionRead, ActionWrite},
+	"billing":       {ActionRead, ActionWrite},
+	"images":        {ActionRead, ActionWrite, ActionDelete},
+	"organizations": {ActionRead, ActionWrite},
+	"projects":      {ActionRead, ActionWrite, ActionDelete},
+}
+
+// Scopes returns every scope the API knows, sorted.
+func Scopes() []string {
+	var scopes []string
+	for resource, actions := range resourceActions {
+		for _, action := range append(slices.Clone(actions), ActionAll) {
+			scopes = append(scopes, resource+":"+action)
+		}
+	}
+	slices.Sort(scopes)
+	return scopes
+}
+
+// ParseScopes validates raw scopes and returns them trimmed, deduplicated and sorted.
+func ParseScopes(raw []string) ([]string, error) {
+	scopes := make([]string, 0, len(raw))
+	for _, s := range raw {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if !knownScope(s) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidScope, s)
+		}
+		scopes = append(scopes, s)
+	}
+	slices.Sort(scopes)
+	return slices.Compact(scopes), nil
+}
+
+func knownScope(scope string) bool {
+	resource, action, ok := strings.Cut(scope, ":")
+	if !ok {
+		return false
+	}
+	actions, ok := resourceActions[resource]
+	return ok && (action == ActionAll || slices.Contains(actions, action))
+}
+
+// Grants reports whether scopes include required or a wildcard over its resource.
+func Grants(scopes []string, required string) bool {
+	resource, _, _ := strings.Cut(required, ":")
+	for _, s := range scopes {
+		if s == required || s == resource+":"+ActionAll {
+			return true
+		}
+	}
+	return false
+}
+
+// GetScopes returns the API scopes of the request's token and whether the token is limited
+// to them. Access tokens carrying none, such as those from the web app's sign-in, are
+// unrestricted; the OpenID scopes Auth0 adds are ignored. API keys are always limited.
+func GetScopes(c echo.Context) (scopes []string, restricted bool) {
+	claims, err := GetClaims(c)
+	if err != nil {
+		return nil, false
+	}
+	raw, _ := claims[ScopeClaim].(string)
+	for _, s := range strings.Fields(raw) {
+		if knownScope(s) {
+			scopes = append(scopes, s)
+		}
+	}
+	_, isKey := claims[APIKeyClaim]
+	return scopes, len(scopes) > 0 || isKey
+}
+
+// ScopeRule names the resource that routes under a template prefix belong to. Action is
+// derived from the method when empty. A rule without a resource is closed to scoped tokens.
+type ScopeRule struct {
+	Prefix   string
+	Resource string
+	Action   string
+}
+
+// RequiredScope returns the scope a request needs under the longest matching rule. ok is
+// false when no rule matches or the matching rule is closed to scoped tokens.
+func RequiredScope(rules []ScopeRule, method, path string) (scope string, ok bool) {
+	var match *ScopeRule
+	for i, r := range rules {
+		if strings.HasPrefix(path, r.Prefix) && (match == nil || len(r.Prefix) > len(match.Prefix)) {
+			match = &rules[i]
+		}
+	}
+	if match == nil || match.Resource == "" {
+		return "", false
+	}
+	action := match.Action
+	if action == "" {
+		action = methodAction(match.Resource, method)
+	}
+	return match.Resource + ":" + action, true
+}
+
+func methodAction(resource, method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return ActionRead
+	case http.MethodDelete:
+		if slices.Contains(resourceActions[resource], ActionDelete) {
+			return ActionDelete
+		}
+	}
+	return ActionWrite
+}
+
+// RequireScopes refuses requests whose token carries API scopes that do not grant the route,
+// with 403 insufficient_scope. It runs after authentication and matches rules against the
+// route template. Routes no rule covers are closed to scoped tokens, so a new route is
+// never reachable by an API key until it is given a resource.
+func RequireScopes(rules []ScopeRule) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			scopes, restricted := GetScopes(c)
+			if !restricted {
+				return next(c)
+			}
+			required, ok := RequiredScope(rules, c.Request().Method, c.Path())
+			if !ok {
+				return c.JSON(http.StatusForbidden, map[string]string{
+					"error":   "insufficient_scope",
+					"message": "This route cannot be used with a scoped token",
+				})
+			}
+			if !Grants(scopes, required) {
+				return c.JSON(http.StatusForbidden, map[string]string{
+					"error":   "insufficient_scope",
+					"message": "This route requires the " + required + " scope",
+				})
+			}
+			return next(c)
+		}
+	}
+}
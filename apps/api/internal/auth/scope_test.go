@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScopes(t *testing.T) {
+	testCases := []struct {
+		name      string
+		raw       []string
+		expected  []string
+		expectErr bool
+	}{
+		{
+			name:     "success: normalized",
+			raw:      []string{" Images:Write", "projects:read", "admin:*", "images:write"},
+			expected: []string{"admin:*", "images:write", "projects:read"},
+		},
+		{name: "fail: unknown resource", raw: []string{"webhooks:read"}, expectErr: true},
+		{name: "fail: unknown action", raw: []string{"billing:delete"}, expectErr: true},
+		{name: "fail: no action", raw: []string{"projects"}, expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scopes, err := ParseScopes(tc.raw)
+			if tc.expectErr {
+				assert.ErrorIs(t, err, ErrInvalidScope)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, scopes)
+		})
+	}
+}
+
+func TestGrants(t *testing.T) {
+	scopes := []string{"projects:read", "admin:*"}
+
+	assert.True(t, Grants(scopes, "projects:read"))
+	assert.True(t, Grants(scopes, "admin:write"))
+	assert.False(t, Grants(scopes, "projects:write"))
+	assert.False(t, Grants(nil, "projects:read"))
+}
+
+func TestScopes(t *testing.T) {
+	scopes := Scopes()
+
+	assert.Contains(t, scopes, "billing:read")
+	assert.Contains(t, scopes, "projects:delete")
+	assert.NotContains(t, scopes, "billing:delete")
+	assert.IsIncreasing(t, scopes)
+}
+
+func TestRequireScopes(t *testing.T) {
+	rules := []ScopeRule{
+		{Prefix: "/api/v1/projects", Resource: "projects"},
+		{Prefix: "/api/v1/billing", Resource: "billing"},
+		{Prefix: "/api/v1/me/api-keys"},
+	}
+
+	testCases := []struct {
+		name           string
+		claims         jwt.MapClaims
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{
+			name: "success: sign-in token is unrestricted", claims: jwt.MapClaims{"sub": "u", "scope": "openid profile email"},
+			method: http.MethodDelete, path: "/api/v1/projects/:id", expectedStatus: http.StatusOK,
+		},
+		{
+			name: "success: scope granted", claims: jwt.MapClaims{"sub": "u", "scope": "projects:read"},
+			method: http.MethodGet, path: "/api/v1/projects/:id", expectedStatus: http.StatusOK,
+		},
+		{
+			name: "success: wildcard", claims: jwt.MapClaims{"sub": "u", "scope": "projects:*"},
+			method: http.MethodDelete, path: "/api/v1/projects/:id", expectedStatus: http.StatusOK,
+		},
+		{
+			name: "fail: delete needs its own scope", claims: jwt.MapClaims{"sub": "u", "scope": "projects:read projects:write"},
+			method: http.MethodDelete, path: "/api/v1/projects/:id", expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "fail: other resource", claims: jwt.MapClaims{"sub": "u", "scope": "projects:*"},
+			method: http.MethodGet, path: "/api/v1/billing/invoices", expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "fail: route closed to scoped tokens", claims: jwt.MapClaims{"sub": "u", "scope": "projects:*"},
+			method: http.MethodGet, path: "/api/v1/me/api-keys", expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "fail: route without a rule", claims: jwt.MapClaims{"sub": "u", "scope": "projects:*"},
+			method: http.MethodGet, path: "/api/v1/unmapped", expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "fail: API key with no known scopes", claims: jwt.MapClaims{"sub": "u", "scope": "retired:read", APIKeyClaim: "k"},
+			method: http.MethodGet, path: "/api/v1/projects", expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(tc.method, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(tc.path)
+			c.Set("user", &jwt.Token{Claims: tc.claims})
+
+			err := RequireScopes(rules)(func(c echo.Context) error { return c.NoContent(http.StatusOK) })(c)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusForbidden {
+				assert.Contains(t, rec.Body.String(), "insufficient_scope")
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package backupverify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// listTablesSQL lists the public tables a restore is expected to contain. Partitions are
+// counted through their parent table.
+const listTablesSQL = `SELECT c.relname
+FROM pg_class c
+JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE n.nspname = 'public' AND c.relkind IN ('r', 'p') AND NOT c.relispartition
+ORDER BY c.relname`
+
+// idTypeSQL returns the type of a table's id column, used to cast sampled ids back when
+// looking them up in production.
+const idTypeSQL = `SELECT format_type(a.atttypid, a.atttypmod)
+FROM pg_attribute a
+WHERE a.attrelid = $1::regclass AND a.attname = 'id' AND NOT a.attisdropped`
+
+// DefaultService compares restored backups with the production database.
+type DefaultService struct {
+	db       storage.Database
+	restorer Restorer
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a DefaultService that checks backups against db.
+func NewDefaultService(db storage.Database, restorer Restorer) *DefaultService {
+	return &DefaultService{db: db, restorer: restorer}
+}
+
+// Verify restores the backup, compares each table's row count with production, and
+// checksums a random sample of restored rows against the same rows in production. Rows
+// written or changed since the backup was taken show up as drift, so thresholds should
+// allow for the backup's age.
+func (s *DefaultService) Verify(ctx context.Context, opts VerifyOptions) (*VerifyReport, error) {
+	// Row counts and checksums read whole tables
+	ctx = storage.WithQueryClass(ctx, storage.QueryClassScan)
+	backup, backupAt, err := LatestBackup(opts.Backup)
+	if err != nil {
+		return nil, err
+	}
+
+	restored, drop, err := s.restorer.Restore(ctx, backup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore %s: %w", backup, err)
+	}
+	defer func() {
+		if err := drop(context.WithoutCancel(ctx)); err != nil {
+			logging.Default().Warn(ctx, "failed to drop restored backup", "backup", backup, "error", err)
+		}
+	}()
+
+	tables := opts.Tables
+	if len(tables) == 0 {
+		if tables, err = listTables(ctx, restored); err != nil {
+			return nil, err
+		}
+		if len(tables) == 0 {
+			return nil, fmt.Errorf("backup %s restored no tables", backup)
+		}
+	}
+
+	report := &VerifyReport{Backup: backup, BackupAt: backupAt, Tables: make([]TableReport, 0, len(tables))}
+	for _, table := range tables {
+		tr := s.checkTable(ctx, restored, table, opts)
+		report.Drifted = report.Drifted || tr.Drifted
+		report.Tables = append(report.Tables, tr)
+	}
+	return report, nil
+}
+
+func listTables(ctx context.Context, db storage.Database) ([]string, error) {
+	rows, err := db.Query(ctx, listTablesSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list restored tables: %w", err)
+	}
+	tables, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to list restored tables: %w", err)
+	}
+	return tables, nil
+}
+
+// checkTable compares one table. Errors are recorded on the report and mark the table as
+// drifted rather than stopping the run.
+func (s *DefaultService) checkTable(
+	ctx context.Context, restored storage.Database, table string, opts VerifyOptions,
+) TableReport {
+	tr := TableReport{Table: table}
+	ident := pgx.Identifier(strings.Split(table, ".")).Sanitize()
+	fail := func(err error) TableReport {
+		tr.Drifted = true
+		tr.Error = err.Error()
+		return tr
+	}
+
+	if err := restored.QueryRow(ctx, "SELECT count(*) FROM "+ident).Scan(&tr.RestoredRows); err != nil {
+		return fail(fmt.Errorf("count restored rows: %w", err))
+	}
+	if err := s.db.QueryRow(ctx, "SELECT count(*) FROM "+ident).Scan(&tr.ProductionRows); err != nil {
+		return fail(fmt.Errorf("count production rows: %w", err))
+	}
+	tr.CountDrift = countDrift(tr.ProductionRows, tr.RestoredRows)
+	tr.Drifted = tr.CountDrift > opts.MaxCountDrift
+
+	if opts.SampleSize <= 0 || tr.RestoredRows == 0 {
+		return tr
+	}
+	sampled, mismatched, err := s.spotCheck(ctx, restored, ident, opts.SampleSize)
+	if err != nil {
+		return fail(fmt.Errorf("spot check: %w", err))
+	}
+	tr.Sampled, tr.Mismatched = sampled, mismatched
+	if sampled > 0 && float64(mismatched)/float64(sampled) > opts.MaxChecksumDrift {
+		tr.Drifted = true
+	}
+	return tr
+}
+
+// spotCheck checksums up to n random restored rows and counts how many are missing from,
+// or differ in, production. Tables without an id column are not sampled.
+func (s *DefaultService) spotCheck(
+	ctx context.Context, restored storage.Database, ident string, n int,
+) (int, int, error) {
+	var idType string
+	if err := restored.QueryRow(ctx, idTypeSQL, ident).Scan(&idType); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, 0, nil
+		}
+		return 0, 0, fmt.Errorf("look up id column: %w", err)
+	}
+
+	sample := fmt.Sprintf("SELECT t.id::text, md5(t::text) FROM %s AS t ORDER BY random() LIMIT $1", ident)
+	want, err := checksums(ctx, restored, sample, n)
+	if err != nil {
+		return 0, 0, fmt.Errorf("sample restored rows: %w", err)
+	}
+	ids := make([]string, 0, len(want))
+	for id := range want {
+		ids = append(ids, id)
+	}
+
+	lookup := fmt.Sprintf("SELECT t.id::text, md5(t::text) FROM %s AS t WHERE t.id = ANY($1::text[]::%s[])",
+		ident, idType)
+	got, err := checksums(ctx, s.db, lookup, ids)
+	if err != nil {
+		return 0, 0, fmt.Errorf("read production rows: %w", err)
+	}
+
+	mismatched := 0
+	for id, sum := range want {
+		if got[id] != sum {
+			mismatched++
+		}
+	}
+	return len(want), mismatched, nil
+}
+
+// checksums maps row ids to row checksums for a query returning (id, md5) pairs.
+func checksums(ctx context.Context, db storage.Database, sql string, args ...any) (map[string]string, error) {
+	rows, err := db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sums := make(map[string]string)
+	for rows.Next() {
+		var id, sum string
+		if err := rows.Scan(&id, &sum); err != nil {
+			return nil, err
+		}
+		sums[id] = sum
+	}
+	return sums, rows.Err()
+}
+
+// countDrift returns the difference between two row counts relative to production.
+func countDrift(production, restored int64) float64 {
+	diff := production - restored
+	if diff < 0 {
+		diff = -diff
+	}
+	if production == 0 {
+		if diff == 0 {
+			return 0
+		}
+		return 1
+	}
+	return float64(diff) / float64(production)
+}
@@ -0,0 +1,201 @@
+package backupverify
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// mockDatabase routes Database queries to a pgxmock pool.
+func mockDatabase(t *testing.T) (*storage.DatabaseMock, pgxmock.PgxPoolIface) {
+	t.Helper()
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+	return &storage.DatabaseMock{
+		QueryRowFunc: func(ctx context.Context, sql string, args ...any) pgx.Row {
+			return pool.QueryRow(ctx, sql, args...)
+		},
+		QueryFunc: func(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+			return pool.Query(ctx, sql, args...)
+		},
+	}, pool
+}
+
+func expectCount(pool pgxmock.PgxPoolIface, table string, n int64) {
+	pool.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "` + table + `"`)).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(n))
+}
+
+func writeBackup(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "realstaging.dump")
+	require.NoError(t, os.WriteFile(path, []byte("PGDMP"), 0o600))
+	return path
+}
+
+func TestDefaultService_Verify(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name        string
+		opts        VerifyOptions
+		setup       func(prod, restored pgxmock.PgxPoolIface)
+		restoreErr  error
+		wantErr     string
+		wantDrifted bool
+		validate    func(t *testing.T, report *VerifyReport)
+	}{
+		{
+			name: "success: counts and sampled rows match",
+			opts: VerifyOptions{SampleSize: 2, MaxCountDrift: 0.05, MaxChecksumDrift: 0.1},
+			setup: func(prod, restored pgxmock.PgxPoolIface) {
+				restored.ExpectQuery(regexp.QuoteMeta("SELECT c.relname")).
+					WillReturnRows(pgxmock.NewRows([]string{"relname"}).AddRow("users"))
+				expectCount(restored, "users", 100)
+				expectCount(prod, "users", 102)
+				restored.ExpectQuery(regexp.QuoteMeta("SELECT format_type")).WithArgs(`"users"`).
+					WillReturnRows(pgxmock.NewRows([]string{"format_type"}).AddRow("uuid"))
+				restored.ExpectQuery(regexp.QuoteMeta(`FROM "users" AS t ORDER BY random() LIMIT $1`)).WithArgs(2).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "md5"}).AddRow("a", "sum-a").AddRow("b", "sum-b"))
+				prod.ExpectQuery(regexp.QuoteMeta(`WHERE t.id = ANY($1::text[]::uuid[])`)).WithArgs(pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "md5"}).AddRow("a", "sum-a").AddRow("b", "sum-b"))
+			},
+			validate: func(t *testing.T, report *VerifyReport) {
+				require.Len(t, report.Tables, 1)
+				tr := report.Tables[0]
+				assert.Equal(t, "users", tr.Table)
+				assert.Equal(t, int64(102), tr.ProductionRows)
+				assert.Equal(t, int64(100), tr.RestoredRows)
+				assert.InDelta(t, 2.0/102, tr.CountDrift, 1e-9)
+				assert.Equal(t, 2, tr.Sampled)
+				assert.Zero(t, tr.Mismatched)
+				assert.False(t, tr.Drifted)
+			},
+		},
+		{
+			name: "success: changed rows past the checksum threshold drift",
+			opts: VerifyOptions{
+				Tables: []string{"projects"}, SampleSize: 2, MaxCountDrift: 0.05, MaxChecksumDrift: 0.1,
+			},
+			setup: func(prod, restored pgxmock.PgxPoolIface) {
+				expectCount(restored, "projects", 2)
+				expectCount(prod, "projects", 2)
+				restored.ExpectQuery(regexp.QuoteMeta("SELECT format_type")).WithArgs(`"projects"`).
+					WillReturnRows(pgxmock.NewRows([]string{"format_type"}).AddRow("uuid"))
+				restored.ExpectQuery(regexp.QuoteMeta("ORDER BY random()")).WithArgs(2).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "md5"}).AddRow("a", "sum-a").AddRow("b", "sum-b"))
+				prod.ExpectQuery(regexp.QuoteMeta("WHERE t.id = ANY")).WithArgs(pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "md5"}).AddRow("a", "sum-a").AddRow("b", "changed"))
+			},
+			wantDrifted: true,
+			validate: func(t *testing.T, report *VerifyReport) {
+				assert.Equal(t, 2, report.Tables[0].Sampled)
+				assert.Equal(t, 1, report.Tables[0].Mismatched)
+				assert.True(t, report.Tables[0].Drifted)
+			},
+		},
+		{
+			name: "success: row count past the threshold drifts and tables without id skip sampling",
+			opts: VerifyOptions{Tables: []string{"schema_migrations"}, SampleSize: 10, MaxCountDrift: 0.05},
+			setup: func(prod, restored pgxmock.PgxPoolIface) {
+				expectCount(restored, "schema_migrations", 20)
+				expectCount(prod, "schema_migrations", 28)
+				restored.ExpectQuery(regexp.QuoteMeta("SELECT format_type")).WithArgs(`"schema_migrations"`).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantDrifted: true,
+			validate: func(t *testing.T, report *VerifyReport) {
+				assert.Zero(t, report.Tables[0].Sampled)
+				assert.InDelta(t, 8.0/28, report.Tables[0].CountDrift, 1e-9)
+			},
+		},
+		{
+			name: "success: table missing from production is reported",
+			opts: VerifyOptions{Tables: []string{"old_table"}},
+			setup: func(prod, restored pgxmock.PgxPoolIface) {
+				expectCount(restored, "old_table", 1)
+				prod.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "old_table"`)).
+					WillReturnError(errors.New(`relation "old_table" does not exist`))
+			},
+			wantDrifted: true,
+			validate: func(t *testing.T, report *VerifyReport) {
+				assert.Contains(t, report.Tables[0].Error, "count production rows")
+			},
+		},
+		{
+			name:       "fail: restore error",
+			opts:       VerifyOptions{},
+			setup:      func(prod, restored pgxmock.PgxPoolIface) {},
+			restoreErr: errors.New("pg_restore failed"),
+			wantErr:    "failed to restore",
+		},
+		{
+			name: "fail: backup restored no tables",
+			opts: VerifyOptions{},
+			setup: func(prod, restored pgxmock.PgxPoolIface) {
+				restored.ExpectQuery(regexp.QuoteMeta("SELECT c.relname")).
+					WillReturnRows(pgxmock.NewRows([]string{"relname"}))
+			},
+			wantErr: "restored no tables",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prodDB, prod := mockDatabase(t)
+			restoredDB, restored := mockDatabase(t)
+			tc.setup(prod, restored)
+
+			dropped := false
+			restorer := &RestorerMock{
+				RestoreFunc: func(
+					ctx context.Context, backup string,
+				) (storage.Database, func(context.Context) error, error) {
+					if tc.restoreErr != nil {
+						return nil, nil, tc.restoreErr
+					}
+					return restoredDB, func(context.Context) error {
+						dropped = true
+						return nil
+					}, nil
+				},
+			}
+
+			opts := tc.opts
+			opts.Backup = writeBackup(t)
+			report, err := NewDefaultService(prodDB, restorer).Verify(ctx, opts)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				assert.Equal(t, tc.restoreErr == nil, dropped)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, dropped)
+			assert.Equal(t, opts.Backup, report.Backup)
+			assert.Equal(t, tc.wantDrifted, report.Drifted)
+			assert.NoError(t, prod.ExpectationsWereMet())
+			assert.NoError(t, restored.ExpectationsWereMet())
+			if tc.validate != nil {
+				tc.validate(t, report)
+			}
+		})
+	}
+}
+
+func TestCountDrift(t *testing.T) {
+	assert.Zero(t, countDrift(0, 0))
+	assert.Equal(t, 1.0, countDrift(0, 5))
+	assert.Equal(t, 0.5, countDrift(10, 5))
+	assert.Equal(t, 0.5, countDrift(10, 15))
+}
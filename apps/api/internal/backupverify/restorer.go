@@ -0,0 +1,175 @@
+package backupverify
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// Backup formats LatestBackup recognises: pg_dump custom archives and plain SQL scripts,
+// optionally gzipped.
+var backupExtensions = []string{".dump", ".backup", ".sql", ".sql.gz"}
+
+// LatestBackup resolves path to a backup file. A directory resolves to its most recently
+// modified backup.
+func LatestBackup(path string) (string, time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to read backup: %w", err)
+	}
+	if !info.IsDir() {
+		return path, info.ModTime(), nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to list backups: %w", err)
+	}
+	var latest string
+	var latestAt time.Time
+	for _, entry := range entries {
+		if entry.IsDir() || !isBackup(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to read backup: %w", err)
+		}
+		if latest == "" || info.ModTime().After(latestAt) {
+			latest, latestAt = filepath.Join(path, entry.Name()), info.ModTime()
+		}
+	}
+	if latest == "" {
+		return "", time.Time{}, fmt.Errorf("no backups found in %s", path)
+	}
+	return latest, latestAt, nil
+}
+
+func isBackup(name string) bool {
+	for _, ext := range backupExtensions {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// PgRestorer restores backups with pg_restore or psql into a database it creates for the
+// run on a scratch Postgres server, and drops that database afterwards.
+type PgRestorer struct {
+	serverURL string
+}
+
+// Ensure PgRestorer implements Restorer.
+var _ Restorer = (*PgRestorer)(nil)
+
+// NewPgRestorer creates a PgRestorer for the server at serverURL. The connecting role must
+// be allowed to create databases.
+func NewPgRestorer(serverURL string) *PgRestorer {
+	return &PgRestorer{serverURL: serverURL}
+}
+
+// Restore creates a scratch database, loads the backup into it, and connects to it.
+func (r *PgRestorer) Restore(
+	ctx context.Context, backup string,
+) (storage.Database, func(context.Context) error, error) {
+	admin, err := storage.NewDefaultDatabase(&config.DB{URL: r.serverURL})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to scratch server: %w", err)
+	}
+
+	name := fmt.Sprintf("backupverify_%d", time.Now().Unix())
+	ident := pgx.Identifier{name}.Sanitize()
+	if _, err := admin.Exec(ctx, "CREATE DATABASE "+ident); err != nil {
+		admin.Close()
+		return nil, nil, fmt.Errorf("failed to create scratch database: %w", err)
+	}
+	drop := func(ctx context.Context) error {
+		defer admin.Close()
+		_, err := admin.Exec(ctx, "DROP DATABASE IF EXISTS "+ident+" WITH (FORCE)")
+		return err
+	}
+
+	scratchURL, err := databaseURL(r.serverURL, name)
+	if err == nil {
+		err = runRestore(ctx, backup, scratchURL)
+	}
+	if err != nil {
+		return nil, nil, errors.Join(err, drop(context.WithoutCancel(ctx)))
+	}
+
+	restored, err := storage.NewDefaultDatabase(&config.DB{URL: scratchURL})
+	if err != nil {
+		return nil, nil, errors.Join(fmt.Errorf("failed to connect to scratch database: %w", err),
+			drop(context.WithoutCancel(ctx)))
+	}
+	return restored, func(ctx context.Context) error {
+		restored.Close()
+		return drop(ctx)
+	}, nil
+}
+
+// databaseURL points a connection URL at another database on the same server.
+func databaseURL(serverURL, database string) (string, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid scratch server URL: %w", err)
+	}
+	u.Path = "/" + database
+	return u.String(), nil
+}
+
+// runRestore loads backup into the database at dbURL, stopping at the first error.
+func runRestore(ctx context.Context, backup, dbURL string) error {
+	cmd, gzipped := restoreCommand(ctx, backup, dbURL)
+	if gzipped {
+		f, err := os.Open(backup) // #nosec G304 -- operator-supplied backup path
+		if err != nil {
+			return fmt.Errorf("failed to open backup: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return fmt.Errorf("failed to read gzipped backup: %w", err)
+		}
+		cmd.Stdin = zr
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stdout = io.Discard
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s failed: %w: %s", filepath.Base(cmd.Path), err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// restoreCommand builds the command that restores backup, and reports whether the backup
+// must be decompressed onto its stdin.
+func restoreCommand(ctx context.Context, backup, dbURL string) (*exec.Cmd, bool) {
+	switch {
+	case strings.HasSuffix(backup, ".sql.gz"):
+		return exec.CommandContext(ctx, "psql", "--quiet", "--set", "ON_ERROR_STOP=1",
+			"--dbname", dbURL), true
+	case strings.HasSuffix(backup, ".sql"):
+		return exec.CommandContext(ctx, "psql", "--quiet", "--set", "ON_ERROR_STOP=1",
+			"--dbname", dbURL, "--file", backup), false
+	default:
+		return exec.CommandContext(ctx, "pg_restore", "--no-owner", "--no-privileges", "--exit-on-error",
+			"--dbname", dbURL, backup), false
+	}
+}
@@ -0,0 +1,76 @@
+package backupverify
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestBackup(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, age time.Duration) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte("backup"), 0o600))
+		at := time.Now().Add(-age)
+		require.NoError(t, os.Chtimes(path, at, at))
+		return path
+	}
+	write("realstaging-2026-10-15.dump", 48*time.Hour)
+	newest := write("realstaging-2026-10-16.sql.gz", 24*time.Hour)
+	write("notes.txt", time.Hour)
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "tmp.dump"), 0o700))
+
+	t.Run("success: directory resolves to its newest backup", func(t *testing.T) {
+		path, at, err := LatestBackup(dir)
+		require.NoError(t, err)
+		assert.Equal(t, newest, path)
+		assert.WithinDuration(t, time.Now().Add(-24*time.Hour), at, time.Second)
+	})
+
+	t.Run("success: file resolves to itself", func(t *testing.T) {
+		path, _, err := LatestBackup(newest)
+		require.NoError(t, err)
+		assert.Equal(t, newest, path)
+	})
+
+	t.Run("fail: directory without backups", func(t *testing.T) {
+		_, _, err := LatestBackup(t.TempDir())
+		assert.ErrorContains(t, err, "no backups found")
+	})
+
+	t.Run("fail: missing path", func(t *testing.T) {
+		_, _, err := LatestBackup(filepath.Join(dir, "missing"))
+		assert.ErrorContains(t, err, "failed to read backup")
+	})
+}
+
+func TestDatabaseURL(t *testing.T) {
+	got, err := databaseURL("postgres://u:p@db:5432/realstaging?sslmode=disable", "backupverify_1")
+	require.NoError(t, err)
+	assert.Equal(t, "postgres://u:p@db:5432/backupverify_1?sslmode=disable", got)
+}
+
+func TestRestoreCommand(t *testing.T) {
+	ctx := context.Background()
+	const dbURL = "postgres://db/scratch"
+
+	cmd, gzipped := restoreCommand(ctx, "/backups/a.dump", dbURL)
+	assert.False(t, gzipped)
+	assert.Equal(t, "pg_restore", filepath.Base(cmd.Args[0]))
+	assert.Contains(t, cmd.Args, "/backups/a.dump")
+
+	cmd, gzipped = restoreCommand(ctx, "/backups/a.sql", dbURL)
+	assert.False(t, gzipped)
+	assert.Equal(t, "psql", filepath.Base(cmd.Args[0]))
+	assert.Contains(t, cmd.Args, "/backups/a.sql")
+
+	cmd, gzipped = restoreCommand(ctx, "/backups/a.sql.gz", dbURL)
+	assert.True(t, gzipped)
+	assert.Equal(t, "psql", filepath.Base(cmd.Args[0]))
+	assert.NotContains(t, cmd.Args, "/backups/a.sql.gz")
+}
@@ -0,0 +1,51 @@
+package backupverify
+
+import (
+	"context"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service Restorer
+
+// Service verifies that a logical backup restores and matches production.
+type Service interface {
+	Verify(ctx context.Context, opts VerifyOptions) (*VerifyReport, error)
+}
+
+// Restorer loads a backup into a scratch database.
+type Restorer interface {
+	// Restore returns a connection to a database holding the restored backup and a function
+	// that closes the connection and drops the database.
+	Restore(ctx context.Context, backup string) (storage.Database, func(context.Context) error, error)
+}
+
+// VerifyOptions configures a verification run.
+type VerifyOptions struct {
+	Backup           string   // Backup file, or a directory whose newest backup is verified
+	Tables           []string // Tables to check; empty checks every table in the public schema
+	SampleSize       int      // Rows per table to checksum against production; 0 skips spot checks
+	MaxCountDrift    float64  // Largest tolerated relative difference in a table's row count
+	MaxChecksumDrift float64  // Largest tolerated fraction of sampled rows that differ from production
+}
+
+// VerifyReport summarizes a verification run.
+type VerifyReport struct {
+	Backup   string        `json:"backup"`
+	BackupAt time.Time     `json:"backup_at"` // Modification time of the backup file
+	Tables   []TableReport `json:"tables"`
+	Drifted  bool          `json:"drifted"` // True when any table drifted past its thresholds or failed
+}
+
+// TableReport compares one restored table with production.
+type TableReport struct {
+	Table          string  `json:"table"`
+	ProductionRows int64   `json:"production_rows"`
+	RestoredRows   int64   `json:"restored_rows"`
+	CountDrift     float64 `json:"count_drift"` // |production - restored| / production
+	Sampled        int     `json:"sampled"`
+	Mismatched     int     `json:"mismatched"` // Sampled rows missing from or different in production
+	Drifted        bool    `json:"drifted"`
+	Error          string  `json:"error,omitempty"`
+}
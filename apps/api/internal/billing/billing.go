@@ -2,6 +2,7 @@ package billing
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -14,18 +15,21 @@ import (
 // service contracts (Service), and defines transport-friendly DTOs decoupled
 // from storage-layer types.
 
-//go:generate go run github.com/matryer/moq@v0.5.3 -out billing_mock.go . Handler Service
+//go:generate go run github.com/matryer/moq@v0.5.3 -out billing_mock.go . Handler Service Linker
 
 // Handler defines the HTTP-level billing handlers, typically wired into Echo routes.
 //
 // Expected routes (as used by the API server):
 // - GET /api/v1/billing/subscriptions
 // - GET /api/v1/billing/invoices
+// - POST /api/v1/billing/link
 type Handler interface {
 	// GetMySubscriptions returns the current user's subscriptions with pagination.
 	GetMySubscriptions(c echo.Context) error
 	// GetMyInvoices returns the current user's invoices with pagination.
 	GetMyInvoices(c echo.Context) error
+	// LinkStripeCustomer claims a Stripe customer for the current user.
+	LinkStripeCustomer(c echo.Context) error
 }
 
 // Service defines the data/logic contract used by billing handlers.
@@ -39,6 +43,54 @@ type Service interface {
 	ListInvoicesForUser(ctx context.Context, userID string, p Pagination) ([]InvoiceDTO, error)
 }
 
+// Linker repairs Stripe customers that were never linked to their user, typically because
+// the checkout webhook arrived before the user existed.
+type Linker interface {
+	// LinkByInvoice links the customer billed on the invoice with the given number. The
+	// invoice's email must match the user's profile email.
+	LinkByInvoice(ctx context.Context, userID, code string) (*LinkResponse, error)
+	// StartCheckout starts a zero-amount checkout whose completion webhook links the customer
+	// it creates to the user, identified by auth0Sub.
+	StartCheckout(ctx context.Context, userID, auth0Sub string, req LinkRequest) (*LinkResponse, error)
+}
+
+// Link statuses reported in LinkResponse.Status.
+const (
+	LinkStatusLinked           = "linked"
+	LinkStatusCheckoutRequired = "checkout_required"
+)
+
+var (
+	// ErrInvalidCode is returned when a link code is not a well-formed invoice number.
+	ErrInvalidCode = errors.New("code must be the invoice number from your invoice email")
+	// ErrInvoiceNotFound is returned when no invoice has the given number.
+	ErrInvoiceNotFound = errors.New("no invoice matches that code")
+	// ErrInvoiceNotYours is returned when the invoice was sent to a different email.
+	ErrInvoiceNotYours = errors.New("invoice was not sent to your profile email")
+	// ErrAlreadyLinked is returned when the user is already linked to another customer.
+	ErrAlreadyLinked = errors.New("account is already linked to a different Stripe customer")
+	// ErrCustomerClaimed is returned when the customer is linked to another user.
+	ErrCustomerClaimed = errors.New("customer is linked to another account")
+)
+
+// LinkRequest is the body of POST /api/v1/billing/link. With Code set, the customer billed on
+// that invoice is linked at once; otherwise a zero-amount checkout is started.
+type LinkRequest struct {
+	// Code is the invoice number printed in an invoice email, e.g. "A1B2C3D4-0001".
+	Code       string `json:"code,omitempty"`
+	SuccessURL string `json:"success_url,omitempty"`
+	CancelURL  string `json:"cancel_url,omitempty"`
+}
+
+// LinkResponse reports the outcome of a link request.
+type LinkResponse struct {
+	// Status is LinkStatusLinked or LinkStatusCheckoutRequired.
+	Status           string `json:"status"`
+	StripeCustomerID string `json:"stripe_customer_id,omitempty"`
+	// CheckoutURL is where to send the user to complete the zero-amount checkout.
+	CheckoutURL string `json:"checkout_url,omitempty"`
+}
+
 // SubscriptionDTO mirrors the shape exposed by the billing subscriptions endpoint.
 type SubscriptionDTO struct {
 	ID                   string     `json:"id"`
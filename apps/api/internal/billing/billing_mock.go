@@ -25,6 +25,9 @@ var _ Handler = &HandlerMock{}
 //			GetMySubscriptionsFunc: func(c echo.Context) error {
 //				panic("mock out the GetMySubscriptions method")
 //			},
+//			LinkStripeCustomerFunc: func(c echo.Context) error {
+//				panic("mock out the LinkStripeCustomer method")
+//			},
 //		}
 //
 //		// use mockedHandler in code that requires Handler
@@ -38,6 +41,9 @@ type HandlerMock struct {
 	// GetMySubscriptionsFunc mocks the GetMySubscriptions method.
 	GetMySubscriptionsFunc func(c echo.Context) error
 
+	// LinkStripeCustomerFunc mocks the LinkStripeCustomer method.
+	LinkStripeCustomerFunc func(c echo.Context) error
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// GetMyInvoices holds details about calls to the GetMyInvoices method.
@@ -50,9 +56,15 @@ type HandlerMock struct {
 			// C is the c argument value.
 			C echo.Context
 		}
+		// LinkStripeCustomer holds details about calls to the LinkStripeCustomer method.
+		LinkStripeCustomer []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
 	}
 	lockGetMyInvoices      sync.RWMutex
 	lockGetMySubscriptions sync.RWMutex
+	lockLinkStripeCustomer sync.RWMutex
 }
 
 // GetMyInvoices calls GetMyInvoicesFunc.
@@ -119,6 +131,38 @@ func (mock *HandlerMock) GetMySubscriptionsCalls() []struct {
 	return calls
 }
 
+// LinkStripeCustomer calls LinkStripeCustomerFunc.
+func (mock *HandlerMock) LinkStripeCustomer(c echo.Context) error {
+	if mock.LinkStripeCustomerFunc == nil {
+		panic("HandlerMock.LinkStripeCustomerFunc: method is nil but Handler.LinkStripeCustomer was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockLinkStripeCustomer.Lock()
+	mock.calls.LinkStripeCustomer = append(mock.calls.LinkStripeCustomer, callInfo)
+	mock.lockLinkStripeCustomer.Unlock()
+	return mock.LinkStripeCustomerFunc(c)
+}
+
+// LinkStripeCustomerCalls gets all the calls that were made to LinkStripeCustomer.
+// Check the length with:
+//
+//	len(mockedHandler.LinkStripeCustomerCalls())
+func (mock *HandlerMock) LinkStripeCustomerCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockLinkStripeCustomer.RLock()
+	calls = mock.calls.LinkStripeCustomer
+	mock.lockLinkStripeCustomer.RUnlock()
+	return calls
+}
+
 // Ensure, that ServiceMock does implement Service.
 // If this is not the case, regenerate this file with moq.
 var _ Service = &ServiceMock{}
@@ -252,3 +296,143 @@ func (mock *ServiceMock) ListSubscriptionsForUserCalls() []struct {
 	mock.lockListSubscriptionsForUser.RUnlock()
 	return calls
 }
+
+// Ensure, that LinkerMock does implement Linker.
+// If this is not the case, regenerate this file with moq.
+var _ Linker = &LinkerMock{}
+
+// LinkerMock is a mock implementation of Linker.
+//
+//	func TestSomethingThatUsesLinker(t *testing.T) {
+//
+//		// make and configure a mocked Linker
+//		mockedLinker := &LinkerMock{
+//			LinkByInvoiceFunc: func(ctx context.Context, userID string, code string) (*LinkResponse, error) {
+//				panic("mock out the LinkByInvoice method")
+//			},
+//			StartCheckoutFunc: func(ctx context.Context, userID string, auth0Sub string, req LinkRequest) (*LinkResponse, error) {
+//				panic("mock out the StartCheckout method")
+//			},
+//		}
+//
+//		// use mockedLinker in code that requires Linker
+//		// and then make assertions.
+//
+//	}
+type LinkerMock struct {
+	// LinkByInvoiceFunc mocks the LinkByInvoice method.
+	LinkByInvoiceFunc func(ctx context.Context, userID string, code string) (*LinkResponse, error)
+
+	// StartCheckoutFunc mocks the StartCheckout method.
+	StartCheckoutFunc func(ctx context.Context, userID string, auth0Sub string, req LinkRequest) (*LinkResponse, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// LinkByInvoice holds details about calls to the LinkByInvoice method.
+		LinkByInvoice []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+			// Code is the code argument value.
+			Code string
+		}
+		// StartCheckout holds details about calls to the StartCheckout method.
+		StartCheckout []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+			// Auth0Sub is the auth0Sub argument value.
+			Auth0Sub string
+			// Req is the req argument value.
+			Req LinkRequest
+		}
+	}
+	lockLinkByInvoice sync.RWMutex
+	lockStartCheckout sync.RWMutex
+}
+
+// LinkByInvoice calls LinkByInvoiceFunc.
+func (mock *LinkerMock) LinkByInvoice(ctx context.Context, userID string, code string) (*LinkResponse, error) {
+	if mock.LinkByInvoiceFunc == nil {
+		panic("LinkerMock.LinkByInvoiceFunc: method is nil but Linker.LinkByInvoice was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID string
+		Code   string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Code:   code,
+	}
+	mock.lockLinkByInvoice.Lock()
+	mock.calls.LinkByInvoice = append(mock.calls.LinkByInvoice, callInfo)
+	mock.lockLinkByInvoice.Unlock()
+	return mock.LinkByInvoiceFunc(ctx, userID, code)
+}
+
+// LinkByInvoiceCalls gets all the calls that were made to LinkByInvoice.
+// Check the length with:
+//
+//	len(mockedLinker.LinkByInvoiceCalls())
+func (mock *LinkerMock) LinkByInvoiceCalls() []struct {
+	Ctx    context.Context
+	UserID string
+	Code   string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID string
+		Code   string
+	}
+	mock.lockLinkByInvoice.RLock()
+	calls = mock.calls.LinkByInvoice
+	mock.lockLinkByInvoice.RUnlock()
+	return calls
+}
+
+// StartCheckout calls StartCheckoutFunc.
+func (mock *LinkerMock) StartCheckout(ctx context.Context, userID string, auth0Sub string, req LinkRequest) (*LinkResponse, error) {
+	if mock.StartCheckoutFunc == nil {
+		panic("LinkerMock.StartCheckoutFunc: method is nil but Linker.StartCheckout was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		UserID   string
+		Auth0Sub string
+		Req      LinkRequest
+	}{
+		Ctx:      ctx,
+		UserID:   userID,
+		Auth0Sub: auth0Sub,
+		Req:      req,
+	}
+	mock.lockStartCheckout.Lock()
+	mock.calls.StartCheckout = append(mock.calls.StartCheckout, callInfo)
+	mock.lockStartCheckout.Unlock()
+	return mock.StartCheckoutFunc(ctx, userID, auth0Sub, req)
+}
+
+// StartCheckoutCalls gets all the calls that were made to StartCheckout.
+// Check the length with:
+//
+//	len(mockedLinker.StartCheckoutCalls())
+func (mock *LinkerMock) StartCheckoutCalls() []struct {
+	Ctx      context.Context
+	UserID   string
+	Auth0Sub string
+	Req      LinkRequest
+} {
+	var calls []struct {
+		Ctx      context.Context
+		UserID   string
+		Auth0Sub string
+		Req      LinkRequest
+	}
+	mock.lockStartCheckout.RLock()
+	calls = mock.calls.StartCheckout
+	mock.lockStartCheckout.RUnlock()
+	return calls
+}
@@ -1,7 +1,9 @@
 package billing
 
 import (
+	"errors"
 	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -18,11 +20,25 @@ import (
 // and user resolution logic (Auth0 sub -> ensure users row).
 type DefaultHandler struct {
 	db storage.Database
+	// linker claims Stripe customers. Nil when the Stripe API is not configured.
+	linker Linker
+}
+
+// HandlerOption customizes a DefaultHandler.
+type HandlerOption func(*DefaultHandler)
+
+// WithLinker enables POST /api/v1/billing/link.
+func WithLinker(linker Linker) HandlerOption {
+	return func(h *DefaultHandler) { h.linker = linker }
 }
 
 // NewDefaultHandler constructs a DefaultHandler.
-func NewDefaultHandler(db storage.Database) *DefaultHandler {
-	return &DefaultHandler{db: db}
+func NewDefaultHandler(db storage.Database, opts ...HandlerOption) *DefaultHandler {
+	h := &DefaultHandler{db: db}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 // ErrorResponse is a simple JSON error envelope for handler responses.
@@ -41,28 +57,18 @@ func (h *DefaultHandler) GetMySubscriptions(c echo.Context) error {
 	}
 
 	// Resolve current user (Auth0 sub or test header) and ensure a users row exists.
-	auth0Sub, err := auth.GetUserIDOrDefault(c)
-	if err != nil || auth0Sub == "" {
+	userID, err := user.ResolveID(c, user.NewDefaultRepository(h.db))
+	if errors.Is(err, user.ErrNoSubject) {
 		return c.JSON(http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Unable to resolve current user",
 		})
 	}
-
-	uRepo := user.NewDefaultRepository(h.db)
-	var userID string
-	if existingUser, err := uRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub); err != nil {
-		// Create user on first access
-		if newUser, createErr := uRepo.Create(c.Request().Context(), auth0Sub, "", "user"); createErr != nil {
-			return c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to resolve user",
-			})
-		} else {
-			userID = newUser.ID.String()
-		}
-	} else {
-		userID = existingUser.ID.String()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to resolve user",
+		})
 	}
 
 	subRepo := stripe.NewSubscriptionsRepository(h.db)
@@ -104,28 +110,18 @@ func (h *DefaultHandler) GetMyInvoices(c echo.Context) error {
 	}
 
 	// Resolve current user (Auth0 sub or test header) and ensure a users row exists.
-	auth0Sub, err := auth.GetUserIDOrDefault(c)
-	if err != nil || auth0Sub == "" {
+	userID, err := user.ResolveID(c, user.NewDefaultRepository(h.db))
+	if errors.Is(err, user.ErrNoSubject) {
 		return c.JSON(http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Unable to resolve current user",
 		})
 	}
-
-	uRepo := user.NewDefaultRepository(h.db)
-	var userID string
-	if existingUser, err := uRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub); err != nil {
-		// Create user on first access
-		if newUser, createErr := uRepo.Create(c.Request().Context(), auth0Sub, "", "user"); createErr != nil {
-			return c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to resolve user",
-			})
-		} else {
-			userID = newUser.ID.String()
-		}
-	} else {
-		userID = existingUser.ID.String()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to resolve user",
+		})
 	}
 
 	invRepo := stripe.NewInvoicesRepository(h.db)
@@ -156,6 +152,84 @@ func (h *DefaultHandler) GetMyInvoices(c echo.Context) error {
 	return c.JSON(http.StatusOK, ListResponse[InvoiceDTO]{Items: items, Limit: limit, Offset: offset})
 }
 
+// LinkStripeCustomer handles POST /api/v1/billing/link. With a code from an invoice email it
+// links that invoice's customer to the current user; otherwise it starts a zero-amount checkout
+// whose completion links the customer Stripe creates.
+func (h *DefaultHandler) LinkStripeCustomer(c echo.Context) error {
+	if h.db == nil || h.linker == nil {
+		return c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error:   "service_unavailable",
+			Message: "Stripe is not configured",
+		})
+	}
+
+	var req LinkRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid request body",
+		})
+	}
+	if req.Code == "" && (!absoluteHTTPURL(req.SuccessURL) || !absoluteHTTPURL(req.CancelURL)) {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "success_url and cancel_url must be absolute http(s) URLs when no code is given",
+		})
+	}
+
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil || auth0Sub == "" {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Unable to resolve current user",
+		})
+	}
+
+	ctx := c.Request().Context()
+	userID, err := user.ResolveID(c, user.NewDefaultRepository(h.db))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to resolve user",
+		})
+	}
+
+	var resp *LinkResponse
+	if req.Code != "" {
+		resp, err = h.linker.LinkByInvoice(ctx, userID, req.Code)
+	} else {
+		resp, err = h.linker.StartCheckout(ctx, userID, auth0Sub, req)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidCode):
+			return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: err.Error()})
+		case errors.Is(err, ErrInvoiceNotFound):
+			return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: err.Error()})
+		case errors.Is(err, ErrInvoiceNotYours):
+			return c.JSON(http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: err.Error()})
+		case errors.Is(err, ErrAlreadyLinked), errors.Is(err, ErrCustomerClaimed):
+			return c.JSON(http.StatusConflict, ErrorResponse{Error: "conflict", Message: err.Error()})
+		}
+		c.Logger().Errorf("Failed to link Stripe customer: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to link Stripe customer",
+		})
+	}
+
+	if resp.Status == LinkStatusCheckoutRequired {
+		return c.JSON(http.StatusAccepted, resp)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// absoluteHTTPURL reports whether raw is an absolute http or https URL.
+func absoluteHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
 // parseLimitOffset reads limit/offset from query params and applies defaults/caps.
 func (h *DefaultHandler) parseLimitOffset(c echo.Context) (int32, int32) {
 	limit := DefaultLimit
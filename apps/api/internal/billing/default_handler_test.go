@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -496,3 +497,126 @@ func TestGetMyInvoices(t *testing.T) {
 	handler := NewDefaultHandler(nil)
 	runHandlerTableTest(t, handler.GetMyInvoices, tests)
 }
+
+func TestLinkStripeCustomer(t *testing.T) {
+	linked := &LinkResponse{Status: LinkStatusLinked, StripeCustomerID: "cus_1"}
+	checkout := &LinkResponse{Status: LinkStatusCheckoutRequired, CheckoutURL: "https://checkout.stripe.com/c/cs_1"}
+
+	tests := []struct {
+		name           string
+		body           string
+		noLinker       bool
+		resp           *LinkResponse
+		err            error
+		expectedStatus int
+		wantCall       string
+	}{
+		{
+			name:           "success: linked by code",
+			body:           `{"code":"ABCD-0001"}`,
+			resp:           linked,
+			expectedStatus: http.StatusOK,
+			wantCall:       "invoice",
+		},
+		{
+			name:           "success: checkout started",
+			body:           `{"success_url":"https://app/billing?linked=1","cancel_url":"https://app/billing"}`,
+			resp:           checkout,
+			expectedStatus: http.StatusAccepted,
+			wantCall:       "checkout",
+		},
+		{
+			name:           "fail: stripe not configured",
+			body:           `{"code":"ABCD-0001"}`,
+			noLinker:       true,
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:           "fail: checkout without return urls",
+			body:           `{"success_url":"/billing"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "fail: invalid code",
+			body:           `{"code":"nope"}`,
+			err:            ErrInvalidCode,
+			expectedStatus: http.StatusBadRequest,
+			wantCall:       "invoice",
+		},
+		{
+			name:           "fail: invoice not found",
+			body:           `{"code":"ABCD-0009"}`,
+			err:            ErrInvoiceNotFound,
+			expectedStatus: http.StatusNotFound,
+			wantCall:       "invoice",
+		},
+		{
+			name:           "fail: invoice sent to someone else",
+			body:           `{"code":"ABCD-0001"}`,
+			err:            ErrInvoiceNotYours,
+			expectedStatus: http.StatusForbidden,
+			wantCall:       "invoice",
+		},
+		{
+			name:           "fail: customer claimed",
+			body:           `{"code":"ABCD-0001"}`,
+			err:            ErrCustomerClaimed,
+			expectedStatus: http.StatusConflict,
+			wantCall:       "invoice",
+		},
+		{
+			name:           "fail: stripe error",
+			body:           `{"code":"ABCD-0001"}`,
+			err:            errBoom(),
+			expectedStatus: http.StatusInternalServerError,
+			wantCall:       "invoice",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &storage.DatabaseMock{
+				QueryRowFunc: func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+					return rowStub{scan: mockUserRow(time.Now())}
+				},
+			}
+			linker := &LinkerMock{
+				LinkByInvoiceFunc: func(ctx context.Context, userID, code string) (*LinkResponse, error) {
+					return tt.resp, tt.err
+				},
+				StartCheckoutFunc: func(
+					ctx context.Context, userID, auth0Sub string, req LinkRequest,
+				) (*LinkResponse, error) {
+					return tt.resp, tt.err
+				},
+			}
+			var opts []HandlerOption
+			if !tt.noLinker {
+				opts = append(opts, WithLinker(linker))
+			}
+			h := NewDefaultHandler(db, opts...)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/billing/link", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("X-Test-User", "auth0|testuser")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			if err := h.LinkStripeCustomer(c); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if got := len(linker.LinkByInvoiceCalls()); (tt.wantCall == "invoice") != (got == 1) {
+				t.Fatalf("LinkByInvoice called %d times", got)
+			}
+			if got := len(linker.StartCheckoutCalls()); (tt.wantCall == "checkout") != (got == 1) {
+				t.Fatalf("StartCheckout called %d times", got)
+			}
+			if tt.wantCall == "checkout" && linker.StartCheckoutCalls()[0].Auth0Sub != "auth0|testuser" {
+				t.Fatalf("unexpected auth0 sub %q", linker.StartCheckoutCalls()[0].Auth0Sub)
+			}
+		})
+	}
+}
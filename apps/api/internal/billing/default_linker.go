@@ -0,0 +1,123 @@
+package billing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/stripe"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// linkCheckoutCurrency is the currency of the zero-amount link checkout.
+const linkCheckoutCurrency = "usd"
+
+// invoiceNumberPattern matches Stripe invoice numbers: a prefix, a dash and a sequence.
+var invoiceNumberPattern = regexp.MustCompile(`^[A-Z0-9]{1,20}-[0-9]{1,12}$`)
+
+// DefaultLinker implements Linker against the users table and the Stripe API.
+type DefaultLinker struct {
+	users  user.Repository
+	client stripe.Client
+	// backfill stores the invoice that proved ownership, and its subscription.
+	backfill func(ctx context.Context, inv *stripe.Invoice) error
+}
+
+// NewDefaultLinker returns a Linker that looks invoices up with client. Once a customer is
+// linked, the invoice used to claim it and its subscription are stored in db, with opts
+// applied as for stripe.NewDefaultHandler.
+func NewDefaultLinker(db storage.Database, client stripe.Client, opts ...stripe.HandlerOption) *DefaultLinker {
+	return &DefaultLinker{
+		users:  user.NewDefaultRepository(db),
+		client: client,
+		backfill: func(ctx context.Context, inv *stripe.Invoice) error {
+			return stripe.Backfill(ctx, db, client, inv, opts...)
+		},
+	}
+}
+
+// LinkByInvoice implements Linker.
+func (l *DefaultLinker) LinkByInvoice(ctx context.Context, userID, code string) (*LinkResponse, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if !invoiceNumberPattern.MatchString(code) {
+		return nil, ErrInvalidCode
+	}
+
+	profile, err := l.users.GetProfileByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user profile: %w", err)
+	}
+
+	inv, err := l.client.FindInvoiceByNumber(ctx, code)
+	if err != nil {
+		if errors.Is(err, stripe.ErrNotFound) {
+			return nil, ErrInvoiceNotFound
+		}
+		return nil, fmt.Errorf("find invoice: %w", err)
+	}
+	email := strings.TrimSpace(inv.CustomerEmail)
+	if inv.CustomerID == "" || email == "" || !profile.Email.Valid ||
+		!strings.EqualFold(email, strings.TrimSpace(profile.Email.String)) {
+		return nil, ErrInvoiceNotYours
+	}
+
+	if current := profile.StripeCustomerID; current.Valid && current.String != "" {
+		if current.String != inv.CustomerID {
+			return nil, ErrAlreadyLinked
+		}
+	} else {
+		owner, err := l.users.GetByStripeCustomerID(ctx, inv.CustomerID)
+		switch {
+		case err == nil:
+			if owner.ID.String() != userID {
+				return nil, ErrCustomerClaimed
+			}
+		case !errors.Is(err, pgx.ErrNoRows):
+			return nil, fmt.Errorf("get user by Stripe customer: %w", err)
+		}
+		if _, err := l.users.UpdateStripeCustomerID(ctx, userID, inv.CustomerID); err != nil {
+			return nil, fmt.Errorf("link Stripe customer: %w", err)
+		}
+	}
+
+	// The link already holds; a failed backfill is repaired by the customer's next webhook.
+	if err := l.backfill(ctx, inv); err != nil {
+		logging.Default().Error(ctx, fmt.Sprintf("Failed to backfill invoice %s after linking: %v", inv.ID, err))
+	}
+
+	return &LinkResponse{Status: LinkStatusLinked, StripeCustomerID: inv.CustomerID}, nil
+}
+
+// StartCheckout implements Linker.
+func (l *DefaultLinker) StartCheckout(
+	ctx context.Context, userID, auth0Sub string, req LinkRequest,
+) (*LinkResponse, error) {
+	profile, err := l.users.GetProfileByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user profile: %w", err)
+	}
+	if current := profile.StripeCustomerID; current.Valid && current.String != "" {
+		return &LinkResponse{Status: LinkStatusLinked, StripeCustomerID: current.String}, nil
+	}
+
+	// checkout.session.completed links the new customer through the client reference.
+	session, err := l.client.CreateCheckoutSession(ctx, stripe.CheckoutSessionParams{
+		Mode:              "setup",
+		Currency:          linkCheckoutCurrency,
+		CustomerEmail:     profile.Email.String,
+		ClientReferenceID: auth0Sub,
+		SuccessURL:        req.SuccessURL,
+		CancelURL:         req.CancelURL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create checkout session: %w", err)
+	}
+
+	return &LinkResponse{Status: LinkStatusCheckoutRequired, CheckoutURL: session.URL}, nil
+}
@@ -0,0 +1,194 @@
+package billing
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/stripe"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func TestDefaultLinker_LinkByInvoice(t *testing.T) {
+	userID := uuid.New()
+	otherID := uuid.New()
+	invoice := &stripe.Invoice{ID: "in_1", CustomerID: "cus_1", CustomerEmail: "Ana@Example.com", Number: "ABCD-0001"}
+
+	profile := func(email, customer string) *queries.GetUserProfileByIDRow {
+		return &queries.GetUserProfileByIDRow{
+			ID:               pgtype.UUID{Bytes: userID, Valid: true},
+			Email:            pgtype.Text{String: email, Valid: email != ""},
+			StripeCustomerID: pgtype.Text{String: customer, Valid: customer != ""},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		code       string
+		profile    *queries.GetUserProfileByIDRow
+		findErr    error
+		owner      *uuid.UUID
+		wantErr    error
+		wantLinked bool
+	}{
+		{
+			name:       "success: links unclaimed customer",
+			code:       " abcd-0001 ",
+			profile:    profile("ana@example.com", ""),
+			wantLinked: true,
+		},
+		{
+			name:    "success: already linked to the same customer",
+			code:    "ABCD-0001",
+			profile: profile("ana@example.com", "cus_1"),
+		},
+		{
+			name:    "fail: malformed code",
+			code:    "not a number",
+			wantErr: ErrInvalidCode,
+		},
+		{
+			name:    "fail: unknown invoice",
+			code:    "ABCD-0009",
+			profile: profile("ana@example.com", ""),
+			findErr: stripe.ErrNotFound,
+			wantErr: ErrInvoiceNotFound,
+		},
+		{
+			name:    "fail: email mismatch",
+			code:    "ABCD-0001",
+			profile: profile("someone@example.com", ""),
+			wantErr: ErrInvoiceNotYours,
+		},
+		{
+			name:    "fail: profile without email",
+			code:    "ABCD-0001",
+			profile: profile("", ""),
+			wantErr: ErrInvoiceNotYours,
+		},
+		{
+			name:    "fail: user linked to another customer",
+			code:    "ABCD-0001",
+			profile: profile("ana@example.com", "cus_other"),
+			wantErr: ErrAlreadyLinked,
+		},
+		{
+			name:    "fail: customer claimed by another user",
+			code:    "ABCD-0001",
+			profile: profile("ana@example.com", ""),
+			owner:   &otherID,
+			wantErr: ErrCustomerClaimed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &user.RepositoryMock{
+				GetProfileByIDFunc: func(ctx context.Context, id string) (*queries.GetUserProfileByIDRow, error) {
+					return tt.profile, nil
+				},
+				GetByStripeCustomerIDFunc: func(
+					ctx context.Context, customerID string,
+				) (*queries.GetUserByStripeCustomerIDRow, error) {
+					if tt.owner == nil {
+						return nil, pgx.ErrNoRows
+					}
+					return &queries.GetUserByStripeCustomerIDRow{ID: pgtype.UUID{Bytes: *tt.owner, Valid: true}}, nil
+				},
+				UpdateStripeCustomerIDFunc: func(
+					ctx context.Context, id, customerID string,
+				) (*queries.UpdateUserStripeCustomerIDRow, error) {
+					return &queries.UpdateUserStripeCustomerIDRow{}, nil
+				},
+			}
+			client := &stripe.ClientMock{
+				FindInvoiceByNumberFunc: func(ctx context.Context, number string) (*stripe.Invoice, error) {
+					assert.Equal(t, strings.ToUpper(strings.TrimSpace(tt.code)), number)
+					if tt.findErr != nil {
+						return nil, tt.findErr
+					}
+					return invoice, nil
+				},
+			}
+			var backfilled []*stripe.Invoice
+			backfill := func(ctx context.Context, inv *stripe.Invoice) error {
+				backfilled = append(backfilled, inv)
+				return nil
+			}
+			l := &DefaultLinker{users: users, client: client, backfill: backfill}
+
+			got, err := l.LinkByInvoice(context.Background(), userID.String(), tt.code)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, users.UpdateStripeCustomerIDCalls())
+				assert.Empty(t, backfilled)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, &LinkResponse{Status: LinkStatusLinked, StripeCustomerID: "cus_1"}, got)
+			assert.Equal(t, []*stripe.Invoice{invoice}, backfilled)
+			if tt.wantLinked {
+				require.Len(t, users.UpdateStripeCustomerIDCalls(), 1)
+				assert.Equal(t, "cus_1", users.UpdateStripeCustomerIDCalls()[0].StripeCustomerID)
+			} else {
+				assert.Empty(t, users.UpdateStripeCustomerIDCalls())
+			}
+		})
+	}
+}
+
+func TestDefaultLinker_StartCheckout(t *testing.T) {
+	req := LinkRequest{SuccessURL: "https://app/billing?linked=1", CancelURL: "https://app/billing"}
+
+	t.Run("success: starts setup checkout", func(t *testing.T) {
+		users := &user.RepositoryMock{
+			GetProfileByIDFunc: func(ctx context.Context, id string) (*queries.GetUserProfileByIDRow, error) {
+				return &queries.GetUserProfileByIDRow{Email: pgtype.Text{String: "ana@example.com", Valid: true}}, nil
+			},
+		}
+		client := &stripe.ClientMock{
+			CreateCheckoutSessionFunc: func(
+				ctx context.Context, params stripe.CheckoutSessionParams,
+			) (*stripe.CheckoutSession, error) {
+				return &stripe.CheckoutSession{ID: "cs_1", URL: "https://checkout.stripe.com/c/cs_1"}, nil
+			},
+		}
+		l := &DefaultLinker{users: users, client: client}
+
+		got, err := l.StartCheckout(context.Background(), uuid.NewString(), "auth0|ana", req)
+		require.NoError(t, err)
+		assert.Equal(t, &LinkResponse{
+			Status: LinkStatusCheckoutRequired, CheckoutURL: "https://checkout.stripe.com/c/cs_1",
+		}, got)
+		require.Len(t, client.CreateCheckoutSessionCalls(), 1)
+		assert.Equal(t, stripe.CheckoutSessionParams{
+			Mode:              "setup",
+			Currency:          "usd",
+			CustomerEmail:     "ana@example.com",
+			ClientReferenceID: "auth0|ana",
+			SuccessURL:        req.SuccessURL,
+			CancelURL:         req.CancelURL,
+		}, client.CreateCheckoutSessionCalls()[0].Params)
+	})
+
+	t.Run("success: already linked skips checkout", func(t *testing.T) {
+		users := &user.RepositoryMock{
+			GetProfileByIDFunc: func(ctx context.Context, id string) (*queries.GetUserProfileByIDRow, error) {
+				return &queries.GetUserProfileByIDRow{StripeCustomerID: pgtype.Text{String: "cus_1", Valid: true}}, nil
+			},
+		}
+		client := &stripe.ClientMock{}
+		l := &DefaultLinker{users: users, client: client}
+
+		got, err := l.StartCheckout(context.Background(), uuid.NewString(), "auth0|ana", req)
+		require.NoError(t, err)
+		assert.Equal(t, &LinkResponse{Status: LinkStatusLinked, StripeCustomerID: "cus_1"}, got)
+		assert.Empty(t, client.CreateCheckoutSessionCalls())
+	})
+}
@@ -0,0 +1,89 @@
+// Package byobucket lets accounts on eligible plans keep their images in an S3 bucket of
+// their own. The account grants access through an IAM role that trusts the platform and
+// requires an external ID we generate; the API and worker assume that role through STS.
+// New uploads only go to the bucket once an access check has passed.
+package byobucket
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+var (
+	// ErrDisabled is returned when customer-managed storage is turned off.
+	ErrDisabled = errors.New("customer-managed storage is not enabled")
+	// ErrNotAllowed is returned when the user's plan does not include customer-managed storage.
+	ErrNotAllowed = errors.New("plan does not include customer-managed storage")
+	// ErrNotFound is returned when the user has no bucket configured.
+	ErrNotFound = errors.New("no bucket configured")
+	// ErrInvalid is returned for malformed bucket settings.
+	ErrInvalid = errors.New("invalid bucket settings")
+	// ErrBucketTaken is returned when another account already uses the bucket.
+	ErrBucketTaken = errors.New("bucket is already configured by another account")
+	// ErrAccessCheck is returned when the role cannot write, read and delete in the bucket.
+	ErrAccessCheck = errors.New("bucket access check failed")
+)
+
+// Bucket is an account's storage settings.
+type Bucket struct {
+	Bucket  string `json:"bucket"`
+	Region  string `json:"region"`
+	RoleARN string `json:"role_arn"`
+	// ExternalID must be required by the role's trust policy (sts:ExternalId).
+	ExternalID string `json:"external_id"`
+	// Verified is set once an access check passes; until then uploads stay in the platform bucket.
+	Verified   bool       `json:"verified"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// PutRequest sets the bucket an account stores its images in.
+type PutRequest struct {
+	Bucket  string `json:"bucket"`
+	Region  string `json:"region"`
+	RoleARN string `json:"role_arn"`
+}
+
+var (
+	bucketPattern  = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+	regionPattern  = regexp.MustCompile(`^[a-z]{2}(-gov)?-[a-z]+-\d$`)
+	roleARNPattern = regexp.MustCompile(`^arn:aws(-[a-z]+)*:iam::\d{12}:role/[\w+=,.@/-]{1,512}$`)
+)
+
+// validate checks r against S3 and IAM naming rules.
+func (r *PutRequest) validate() error {
+	r.Bucket = strings.TrimSpace(r.Bucket)
+	r.Region = strings.TrimSpace(r.Region)
+	r.RoleARN = strings.TrimSpace(r.RoleARN)
+	switch {
+	case !bucketPattern.MatchString(r.Bucket) || strings.Contains(r.Bucket, ".."):
+		return fmt.Errorf("%w: bucket must be a valid S3 bucket name", ErrInvalid)
+	case !regionPattern.MatchString(r.Region):
+		return fmt.Errorf("%w: region must be an AWS region such as us-east-1", ErrInvalid)
+	case !roleARNPattern.MatchString(r.RoleARN):
+		return fmt.Errorf("%w: role_arn must be an IAM role ARN", ErrInvalid)
+	}
+	return nil
+}
+
+// bucketFromRow converts a customer_buckets row.
+func bucketFromRow(row *queries.CustomerBucket) *Bucket {
+	b := &Bucket{
+		Bucket:     row.Bucket,
+		Region:     row.Region,
+		RoleARN:    row.RoleArn,
+		ExternalID: row.ExternalID,
+		Verified:   row.VerifiedAt.Valid,
+		UpdatedAt:  row.UpdatedAt.Time,
+	}
+	if row.VerifiedAt.Valid {
+		t := row.VerifiedAt.Time
+		b.VerifiedAt = &t
+	}
+	return b
+}
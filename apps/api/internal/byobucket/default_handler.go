@@ -0,0 +1,121 @@
+package byobucket
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// DefaultHandler serves the current user's bucket settings over HTTP.
+type DefaultHandler struct {
+	service  Service
+	userRepo user.Repository
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, userRepo user.Repository) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo}
+}
+
+// ErrorResponse is a simple JSON error envelope for handler responses.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// Get handles GET /api/v1/me/storage.
+func (h *DefaultHandler) Get(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	b, err := h.service.Get(c.Request().Context(), userID)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, b)
+}
+
+// Put handles PUT /api/v1/me/storage.
+func (h *DefaultHandler) Put(c echo.Context) error {
+	var req PutRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid request format"})
+	}
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	b, err := h.service.Put(c.Request().Context(), userID, req)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, b)
+}
+
+// Verify handles POST /api/v1/me/storage/verify.
+func (h *DefaultHandler) Verify(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	b, err := h.service.Verify(c.Request().Context(), userID)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, b)
+}
+
+// Delete handles DELETE /api/v1/me/storage.
+func (h *DefaultHandler) Delete(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	if err := h.service.Delete(c.Request().Context(), userID); err != nil {
+		return h.writeError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func unauthorized(c echo.Context) error {
+	return c.JSON(http.StatusUnauthorized, ErrorResponse{
+		Error:   "unauthorized",
+		Message: "Unable to resolve current user",
+	})
+}
+
+// writeError maps service errors to responses.
+func (h *DefaultHandler) writeError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, ErrDisabled):
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: err.Error()})
+	case errors.Is(err, ErrNotAllowed):
+		return c.JSON(http.StatusForbidden, ErrorResponse{
+			Error:   "forbidden",
+			Message: "Your plan does not include customer-managed storage",
+		})
+	case errors.Is(err, ErrNotFound):
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "No bucket configured"})
+	case errors.Is(err, ErrInvalid), errors.Is(err, ErrAccessCheck):
+		return c.JSON(http.StatusUnprocessableEntity, ErrorResponse{Error: "validation_failed", Message: err.Error()})
+	case errors.Is(err, ErrBucketTaken):
+		return c.JSON(http.StatusConflict, ErrorResponse{Error: "conflict", Message: err.Error()})
+	default:
+		c.Logger().Errorf("Customer bucket request failed: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to process storage request",
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package byobucket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func userRepoFor(userID uuid.UUID, lookupErr error) *user.RepositoryMock {
+	return &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			if lookupErr != nil {
+				return nil, lookupErr
+			}
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+		},
+	}
+}
+
+func TestDefaultHandler_Errors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: saved", expectedStatus: http.StatusOK},
+		{name: "fail: disabled", err: ErrDisabled, expectedStatus: http.StatusNotFound},
+		{name: "fail: plan does not allow it", err: ErrNotAllowed, expectedStatus: http.StatusForbidden},
+		{name: "fail: invalid settings", err: ErrInvalid, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "fail: access check", err: ErrAccessCheck, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "fail: bucket taken", err: ErrBucketTaken, expectedStatus: http.StatusConflict},
+		{name: "fail: unexpected error", err: errors.New("db down"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userID := uuid.New()
+			svc := &ServiceMock{
+				PutFunc: func(ctx context.Context, uid string, req PutRequest) (*Bucket, error) {
+					assert.Equal(t, userID.String(), uid)
+					assert.Equal(t, "acme-photos", req.Bucket)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &Bucket{Bucket: req.Bucket, ExternalID: "ext-1"}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, userRepoFor(userID, nil))
+
+			e := echo.New()
+			body := `{"bucket":"acme-photos","region":"eu-west-1","role_arn":"arn:aws:iam::123456789012:role/rs"}`
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/me/storage", strings.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("X-Test-User", "auth0|user")
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, h.Put(e.NewContext(req, rec)))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Contains(t, rec.Body.String(), `"external_id":"ext-1"`)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_GetVerifyDelete(t *testing.T) {
+	userID := uuid.New()
+	svc := &ServiceMock{
+		GetFunc: func(ctx context.Context, uid string) (*Bucket, error) {
+			return nil, ErrNotFound
+		},
+		VerifyFunc: func(ctx context.Context, uid string) (*Bucket, error) {
+			return &Bucket{Bucket: "acme-photos", Verified: true}, nil
+		},
+		DeleteFunc: func(ctx context.Context, uid string) error { return nil },
+	}
+	h := NewDefaultHandler(svc, userRepoFor(userID, nil))
+	e := echo.New()
+
+	testCases := []struct {
+		name           string
+		method         string
+		handle         func(echo.Context) error
+		expectedStatus int
+	}{
+		{name: "fail: get with none configured", method: http.MethodGet, handle: h.Get, expectedStatus: http.StatusNotFound},
+		{name: "success: verify", method: http.MethodPost, handle: h.Verify, expectedStatus: http.StatusOK},
+		{name: "success: delete", method: http.MethodDelete, handle: h.Delete, expectedStatus: http.StatusNoContent},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/api/v1/me/storage", nil)
+			req.Header.Set("X-Test-User", "auth0|user")
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, tc.handle(e.NewContext(req, rec)))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+
+	t.Run("fail: user lookup error", func(t *testing.T) {
+		h := NewDefaultHandler(svc, userRepoFor(userID, errors.New("db down")))
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/me/storage", nil)
+		rec := httptest.NewRecorder()
+
+		require.NoError(t, h.Get(e.NewContext(req, rec)))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
@@ -0,0 +1,148 @@
+package byobucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// uniqueViolation is the Postgres error code raised when the bucket is already configured.
+const uniqueViolation = "23505"
+
+// DefaultService implements Service.
+type DefaultService struct {
+	querier queries.Querier
+	checker Checker
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService with a database. A nil checker means
+// customer buckets are disabled, and every call returns ErrDisabled.
+func NewDefaultService(db storage.Database, checker Checker) *DefaultService {
+	return &DefaultService{querier: queries.New(db), checker: checker}
+}
+
+// NewDefaultServiceWithQuerier creates a new DefaultService with a custom querier (for testing).
+func NewDefaultServiceWithQuerier(querier queries.Querier, checker Checker) *DefaultService {
+	return &DefaultService{querier: querier, checker: checker}
+}
+
+// Get returns the user's bucket settings.
+func (s *DefaultService) Get(ctx context.Context, userID string) (*Bucket, error) {
+	uid, err := s.userUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.querier.GetCustomerBucketByUserID(ctx, uid)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer bucket: %w", err)
+	}
+	return bucketFromRow(row), nil
+}
+
+// Put saves the user's bucket settings. The external ID is generated on the first save
+// and kept afterwards, so the customer's trust policy does not have to change.
+func (s *DefaultService) Put(ctx context.Context, userID string, req PutRequest) (*Bucket, error) {
+	uid, err := s.allowedUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+	row, err := s.querier.UpsertCustomerBucket(ctx, queries.UpsertCustomerBucketParams{
+		UserID:     uid,
+		Bucket:     req.Bucket,
+		Region:     req.Region,
+		RoleArn:    req.RoleARN,
+		ExternalID: uuid.NewString(),
+	})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return nil, ErrBucketTaken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to save customer bucket: %w", err)
+	}
+	return bucketFromRow(row), nil
+}
+
+// Verify runs an access check against the user's bucket and marks it verified when it passes.
+func (s *DefaultService) Verify(ctx context.Context, userID string) (*Bucket, error) {
+	uid, err := s.allowedUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.querier.GetCustomerBucketByUserID(ctx, uid)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer bucket: %w", err)
+	}
+	if err := s.checker.Check(ctx, bucketFromRow(row)); err != nil {
+		return nil, err
+	}
+	row, err = s.querier.MarkCustomerBucketVerified(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark customer bucket verified: %w", err)
+	}
+	return bucketFromRow(row), nil
+}
+
+// Delete removes the user's bucket settings.
+func (s *DefaultService) Delete(ctx context.Context, userID string) error {
+	uid, err := s.userUUID(userID)
+	if err != nil {
+		return err
+	}
+	n, err := s.querier.DeleteCustomerBucket(ctx, uid)
+	if err != nil {
+		return fmt.Errorf("failed to delete customer bucket: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// userUUID parses userID, failing with ErrDisabled when customer buckets are turned off.
+func (s *DefaultService) userUUID(userID string) (pgtype.UUID, error) {
+	if s.checker == nil {
+		return pgtype.UUID{}, ErrDisabled
+	}
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf("invalid user ID: %w", err)
+	}
+	return pgtype.UUID{Bytes: id, Valid: true}, nil
+}
+
+// allowedUser is userUUID for users whose plan includes customer-managed storage.
+func (s *DefaultService) allowedUser(ctx context.Context, userID string) (pgtype.UUID, error) {
+	uid, err := s.userUUID(userID)
+	if err != nil {
+		return uid, err
+	}
+	allowed, err := s.querier.GetCustomerBucketAccess(ctx, uid)
+	if err != nil {
+		return uid, fmt.Errorf("failed to check customer bucket access: %w", err)
+	}
+	if !allowed {
+		return uid, ErrNotAllowed
+	}
+	return uid, nil
+}
@@ -0,0 +1,221 @@
+package byobucket
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+func TestDefaultService_Disabled(t *testing.T) {
+	svc := NewDefaultServiceWithQuerier(&queries.QuerierMock{}, nil)
+	userID := uuid.NewString()
+
+	_, err := svc.Get(context.Background(), userID)
+	assert.ErrorIs(t, err, ErrDisabled)
+	_, err = svc.Put(context.Background(), userID, PutRequest{})
+	assert.ErrorIs(t, err, ErrDisabled)
+	_, err = svc.Verify(context.Background(), userID)
+	assert.ErrorIs(t, err, ErrDisabled)
+	assert.ErrorIs(t, svc.Delete(context.Background(), userID), ErrDisabled)
+}
+
+func TestDefaultService_Get(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name      string
+		row       *queries.CustomerBucket
+		lookupErr error
+		wantErr   error
+		errSubstr string
+	}{
+		{name: "success: verified bucket", row: bucketRow(userID, true)},
+		{name: "fail: none configured", lookupErr: pgx.ErrNoRows, wantErr: ErrNotFound},
+		{name: "fail: query error", lookupErr: errors.New("db down"), errSubstr: "db down"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				GetCustomerBucketByUserIDFunc: func(ctx context.Context, uid pgtype.UUID) (*queries.CustomerBucket, error) {
+					return tc.row, tc.lookupErr
+				},
+			}
+			b, err := NewDefaultServiceWithQuerier(q, &CheckerMock{}).Get(context.Background(), userID.String())
+			switch {
+			case tc.wantErr != nil:
+				assert.ErrorIs(t, err, tc.wantErr)
+			case tc.errSubstr != "":
+				assert.ErrorContains(t, err, tc.errSubstr)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, "acme-photos", b.Bucket)
+				assert.Equal(t, "ext-1", b.ExternalID)
+				assert.True(t, b.Verified)
+				assert.NotNil(t, b.VerifiedAt)
+			}
+		})
+	}
+}
+
+func TestDefaultService_Put(t *testing.T) {
+	userID := uuid.New()
+	valid := PutRequest{
+		Bucket:  " acme-photos ",
+		Region:  "eu-west-1",
+		RoleARN: "arn:aws:iam::123456789012:role/real-staging",
+	}
+
+	testCases := []struct {
+		name      string
+		req       PutRequest
+		allowed   bool
+		upsertErr error
+		wantErr   error
+	}{
+		{name: "success: saved unverified", req: valid, allowed: true},
+		{name: "fail: plan does not allow it", req: valid, wantErr: ErrNotAllowed},
+		{
+			name:    "fail: invalid bucket name",
+			req:     PutRequest{Bucket: "Acme_Photos", Region: valid.Region, RoleARN: valid.RoleARN},
+			allowed: true,
+			wantErr: ErrInvalid,
+		},
+		{
+			name:    "fail: invalid region",
+			req:     PutRequest{Bucket: valid.Bucket, Region: "europe", RoleARN: valid.RoleARN},
+			allowed: true,
+			wantErr: ErrInvalid,
+		},
+		{
+			name:    "fail: invalid role ARN",
+			req:     PutRequest{Bucket: valid.Bucket, Region: valid.Region, RoleARN: "arn:aws:iam::123:user/bob"},
+			allowed: true,
+			wantErr: ErrInvalid,
+		},
+		{
+			name:      "fail: bucket used by another account",
+			req:       valid,
+			allowed:   true,
+			upsertErr: &pgconn.PgError{Code: uniqueViolation},
+			wantErr:   ErrBucketTaken,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				GetCustomerBucketAccessFunc: func(ctx context.Context, uid pgtype.UUID) (bool, error) {
+					assert.Equal(t, userID, uuid.UUID(uid.Bytes))
+					return tc.allowed, nil
+				},
+				UpsertCustomerBucketFunc: func(
+					ctx context.Context, arg queries.UpsertCustomerBucketParams,
+				) (*queries.CustomerBucket, error) {
+					if tc.upsertErr != nil {
+						return nil, tc.upsertErr
+					}
+					return &queries.CustomerBucket{
+						UserID: arg.UserID, Bucket: arg.Bucket, Region: arg.Region, RoleArn: arg.RoleArn,
+						ExternalID: arg.ExternalID,
+					}, nil
+				},
+			}
+			b, err := NewDefaultServiceWithQuerier(q, &CheckerMock{}).Put(context.Background(), userID.String(), tc.req)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "acme-photos", b.Bucket)
+			assert.False(t, b.Verified)
+			_, err = uuid.Parse(b.ExternalID)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDefaultService_Verify(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name      string
+		lookupErr error
+		checkErr  error
+		wantErr   error
+	}{
+		{name: "success: check passes"},
+		{name: "fail: none configured", lookupErr: pgx.ErrNoRows, wantErr: ErrNotFound},
+		{name: "fail: check fails", checkErr: errors.Join(ErrAccessCheck, errors.New("AccessDenied")), wantErr: ErrAccessCheck},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				GetCustomerBucketAccessFunc: func(ctx context.Context, uid pgtype.UUID) (bool, error) {
+					return true, nil
+				},
+				GetCustomerBucketByUserIDFunc: func(ctx context.Context, uid pgtype.UUID) (*queries.CustomerBucket, error) {
+					if tc.lookupErr != nil {
+						return nil, tc.lookupErr
+					}
+					return bucketRow(userID, false), nil
+				},
+				MarkCustomerBucketVerifiedFunc: func(ctx context.Context, uid pgtype.UUID) (*queries.CustomerBucket, error) {
+					return bucketRow(userID, true), nil
+				},
+			}
+			checker := &CheckerMock{CheckFunc: func(ctx context.Context, b *Bucket) error { return tc.checkErr }}
+
+			b, err := NewDefaultServiceWithQuerier(q, checker).Verify(context.Background(), userID.String())
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, q.MarkCustomerBucketVerifiedCalls())
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, b.Verified)
+			require.Len(t, checker.CheckCalls(), 1)
+			assert.Equal(t, "acme-photos", checker.CheckCalls()[0].B.Bucket)
+		})
+	}
+}
+
+func TestDefaultService_Delete(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name    string
+		rows    int64
+		err     error
+		wantErr error
+	}{
+		{name: "success: deleted", rows: 1},
+		{name: "fail: none configured", wantErr: ErrNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				DeleteCustomerBucketFunc: func(ctx context.Context, uid pgtype.UUID) (int64, error) {
+					return tc.rows, tc.err
+				},
+			}
+			err := NewDefaultServiceWithQuerier(q, &CheckerMock{}).Delete(context.Background(), userID.String())
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package byobucket
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP-level contract for customer bucket endpoints.
+// Implementations should be wired to Echo routes in the server.
+type Handler interface {
+	Get(c echo.Context) error
+	Put(c echo.Context) error
+	Verify(c echo.Context) error
+	Delete(c echo.Context) error
+}
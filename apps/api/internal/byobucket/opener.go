@@ -0,0 +1,122 @@
+package byobucket
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/telemetry"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out opener_mock.go . Opener
+
+// Opener opens customer buckets with credentials for their IAM role.
+type Opener interface {
+	// Open returns storage backed by b.
+	Open(ctx context.Context, b *Bucket) (storage.S3Service, error)
+	// Check confirms the role can write, read and delete objects in b.
+	Check(ctx context.Context, b *Bucket) error
+}
+
+// accessCheckKey is the object Check writes and removes again.
+const accessCheckKey = ".real-staging/access-check"
+
+// sessionName identifies our sessions in the customer's CloudTrail.
+const sessionName = "real-staging"
+
+// STSOpener assumes each bucket's role with the platform's own AWS credentials. Clients
+// are kept per bucket, and their credentials are cached and refreshed shortly before
+// the session expires, so STS is called about once per session rather than per request.
+type STSOpener struct {
+	base            aws.Config
+	sessionDuration time.Duration
+
+	mu      sync.Mutex
+	clients map[string]openClient
+}
+
+type openClient struct {
+	settings string
+	client   *s3.Client
+}
+
+// Ensure STSOpener implements Opener.
+var _ Opener = (*STSOpener)(nil)
+
+// awsConfigLoader allows overriding AWS config loading in tests.
+var awsConfigLoader = awsconfig.LoadDefaultConfig
+
+// NewSTSOpener creates an STSOpener using the default AWS credential chain.
+func NewSTSOpener(ctx context.Context, cfg config.CustomerBuckets) (*STSOpener, error) {
+	base, err := awsConfigLoader(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	return &STSOpener{base: base, sessionDuration: cfg.SessionDuration, clients: map[string]openClient{}}, nil
+}
+
+// Open returns storage backed by b.
+func (o *STSOpener) Open(_ context.Context, b *Bucket) (storage.S3Service, error) {
+	return storage.NewDefaultS3ServiceWithClient(o.client(b), &config.S3{BucketName: b.Bucket, Region: b.Region}), nil
+}
+
+// Check writes, reads back and deletes a small object in b.
+func (o *STSOpener) Check(ctx context.Context, b *Bucket) error {
+	client := o.client(b)
+	body := []byte("ok")
+	if _, err := client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(b.Bucket), Key: aws.String(accessCheckKey), Body: bytes.NewReader(body),
+	}); err != nil {
+		return fmt.Errorf("%w: write: %w", ErrAccessCheck, err)
+	}
+	out, err := client.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String(b.Bucket), Key: aws.String(accessCheckKey)})
+	if err != nil {
+		return fmt.Errorf("%w: read: %w", ErrAccessCheck, err)
+	}
+	got, err := io.ReadAll(out.Body)
+	_ = out.Body.Close()
+	if err != nil || !bytes.Equal(got, body) {
+		return fmt.Errorf("%w: read back a different object", ErrAccessCheck)
+	}
+	if _, err := client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(b.Bucket), Key: aws.String(accessCheckKey),
+	}); err != nil {
+		return fmt.Errorf("%w: delete: %w", ErrAccessCheck, err)
+	}
+	return nil
+}
+
+// client returns the cached client for b, replacing it when b's settings changed.
+func (o *STSOpener) client(b *Bucket) *s3.Client {
+	settings := b.Region + "\n" + b.RoleARN + "\n" + b.ExternalID
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if c, ok := o.clients[b.Bucket]; ok && c.settings == settings {
+		return c.client
+	}
+
+	stsClient := sts.NewFromConfig(o.base, func(opts *sts.Options) { opts.Region = b.Region })
+	provider := stscreds.NewAssumeRoleProvider(stsClient, b.RoleARN, func(opts *stscreds.AssumeRoleOptions) {
+		opts.ExternalID = aws.String(b.ExternalID)
+		opts.RoleSessionName = sessionName
+		opts.Duration = o.sessionDuration
+	})
+	client := s3.NewFromConfig(o.base, func(opts *s3.Options) {
+		opts.Region = b.Region
+		opts.Credentials = aws.NewCredentialsCache(provider)
+	}, telemetry.CountS3Operations)
+	o.clients[b.Bucket] = openClient{settings: settings, client: client}
+	return client
+}
@@ -0,0 +1,152 @@
+package byobucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// Resolver routes objects in customer buckets to storage opened with the customer's role,
+// and everything else to the platform's buckets.
+type Resolver struct {
+	querier  queries.Querier
+	platform storage.PlatformBuckets
+	opener   Opener
+}
+
+// Ensure Resolver implements storage.Buckets.
+var _ storage.Buckets = (*Resolver)(nil)
+
+// NewResolver creates a Resolver over platform, the platform's own buckets.
+func NewResolver(db storage.Database, platform storage.PlatformBuckets, opener Opener) *Resolver {
+	return NewResolverWithQuerier(queries.New(db), platform, opener)
+}
+
+// NewResolverWithQuerier creates a Resolver with a custom querier (for testing).
+func NewResolverWithQuerier(querier queries.Querier, platform storage.PlatformBuckets, opener Opener) *Resolver {
+	return &Resolver{querier: querier, platform: platform, opener: opener}
+}
+
+// NewBuckets returns the storage.Buckets for cfg: a Resolver when customer buckets are
+// enabled, otherwise the platform's buckets alone.
+func NewBuckets(
+	ctx context.Context, db storage.Database, platform storage.PlatformBuckets, cfg *config.Config,
+) (storage.Buckets, error) {
+	if !cfg.CustomerBuckets.Enabled {
+		return platform, nil
+	}
+	opener, err := NewSTSOpener(ctx, cfg.CustomerBuckets)
+	if err != nil {
+		return nil, err
+	}
+	return NewResolver(db, platform, opener), nil
+}
+
+// ForUser returns the user's own bucket once it has passed an access check, and their
+// platform bucket otherwise.
+func (r *Resolver) ForUser(ctx context.Context, userID string) (storage.S3Service, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return r.platform.ForUser(ctx, userID)
+	}
+	row, err := r.querier.GetCustomerBucketByUserID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return r.platform.ForUser(ctx, userID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up customer bucket: %w", err)
+	}
+	if !row.VerifiedAt.Valid {
+		return r.platform.ForUser(ctx, userID)
+	}
+	return r.opener.Open(ctx, bucketFromRow(row))
+}
+
+// ForURL returns the customer bucket rawURL points into, or the platform's bucket when it
+// names one of them or no bucket at all. URLs that unambiguously name an unconfigured bucket fail with
+// storage.ErrUnknownBucket rather than being looked up in the platform bucket.
+func (r *Resolver) ForURL(ctx context.Context, rawURL string) (storage.S3Service, string, error) {
+	row, key, err := r.customerBucket(ctx, rawURL)
+	if err != nil {
+		return nil, "", err
+	}
+	if row == nil {
+		return r.platform.ForURL(ctx, rawURL)
+	}
+	files, err := r.opener.Open(ctx, bucketFromRow(row))
+	if err != nil {
+		return nil, "", err
+	}
+	return files, key, nil
+}
+
+// Authorize rejects URLs in a customer bucket that the project's owner does not own, so
+// nobody can stage, download or share objects from another account's bucket.
+func (r *Resolver) Authorize(ctx context.Context, projectID, rawURL string) error {
+	row, _, err := r.customerBucket(ctx, rawURL)
+	if err != nil || row == nil {
+		return err
+	}
+	id, err := uuid.Parse(projectID)
+	if err != nil {
+		return fmt.Errorf("invalid project ID: %w", err)
+	}
+	project, err := r.querier.GetProjectByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		return fmt.Errorf("failed to look up project owner: %w", err)
+	}
+	if project.UserID != row.UserID {
+		return fmt.Errorf("%w: %s", storage.ErrForeignBucket, row.Bucket)
+	}
+	return nil
+}
+
+// customerBucket returns the customer bucket rawURL points into and the object's key, or
+// a nil row when the URL is in one of the platform's buckets. Path-style URLs to an unknown bucket
+// are treated as platform URLs, since their first path segment need not be a bucket.
+func (r *Resolver) customerBucket(ctx context.Context, rawURL string) (*queries.CustomerBucket, string, error) {
+	bucket, key, ok := storage.ParseObjectURL(rawURL)
+	if !ok || r.platform.Owns(bucket) {
+		return nil, "", nil
+	}
+	row, err := r.querier.GetCustomerBucketByName(ctx, bucket)
+	if errors.Is(err, pgx.ErrNoRows) {
+		if namesBucket(rawURL) {
+			return nil, "", fmt.Errorf("%w: %s", storage.ErrUnknownBucket, bucket)
+		}
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to look up customer bucket: %w", err)
+	}
+	return row, key, nil
+}
+
+// Check confirms the platform can write, read and delete objects in b with its role.
+func (r *Resolver) Check(ctx context.Context, b *Bucket) error {
+	if r.platform.Owns(b.Bucket) {
+		return fmt.Errorf("%w: bucket must not be a platform bucket", ErrInvalid)
+	}
+	return r.opener.Check(ctx, b)
+}
+
+// namesBucket reports whether rawURL is an s3:// or virtual-hosted AWS URL, whose bucket
+// is part of the address rather than a guess from the path.
+func namesBucket(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := u.Hostname()
+	return u.Scheme == "s3" || (strings.HasSuffix(host, ".amazonaws.com") && !strings.HasPrefix(host, "s3."))
+}
@@ -0,0 +1,228 @@
+package byobucket
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+func bucketRow(userID uuid.UUID, verified bool) *queries.CustomerBucket {
+	return &queries.CustomerBucket{
+		UserID:     pgtype.UUID{Bytes: userID, Valid: true},
+		Bucket:     "acme-photos",
+		Region:     "eu-west-1",
+		RoleArn:    "arn:aws:iam::123456789012:role/real-staging",
+		ExternalID: "ext-1",
+		VerifiedAt: pgtype.Timestamptz{Time: time.Now(), Valid: verified},
+	}
+}
+
+func newResolver(q *queries.QuerierMock) (*Resolver, *OpenerMock, *storage.S3ServiceMock, *storage.S3ServiceMock) {
+	platform := &storage.S3ServiceMock{BucketNameFunc: func() string { return "real-staging" }}
+	customer := &storage.S3ServiceMock{BucketNameFunc: func() string { return "acme-photos" }}
+	opener := &OpenerMock{
+		OpenFunc: func(ctx context.Context, b *Bucket) (storage.S3Service, error) {
+			return customer, nil
+		},
+		CheckFunc: func(ctx context.Context, b *Bucket) error { return nil },
+	}
+	return NewResolverWithQuerier(q, storage.NewPlatformBuckets(platform), opener), opener, platform, customer
+}
+
+func TestResolver_ForUser(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name         string
+		userID       string
+		row          *queries.CustomerBucket
+		lookupErr    error
+		wantCustomer bool
+		wantErr      bool
+	}{
+		{name: "success: verified bucket", userID: userID.String(), row: bucketRow(userID, true), wantCustomer: true},
+		{name: "success: unverified bucket uses the platform", userID: userID.String(), row: bucketRow(userID, false)},
+		{name: "success: no bucket uses the platform", userID: userID.String(), lookupErr: pgx.ErrNoRows},
+		{name: "success: malformed user ID uses the platform", userID: "nope"},
+		{name: "fail: lookup error", userID: userID.String(), lookupErr: errors.New("db down"), wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				GetCustomerBucketByUserIDFunc: func(ctx context.Context, uid pgtype.UUID) (*queries.CustomerBucket, error) {
+					assert.Equal(t, userID, uuid.UUID(uid.Bytes))
+					return tc.row, tc.lookupErr
+				},
+			}
+			r, opener, platform, customer := newResolver(q)
+
+			files, err := r.ForUser(context.Background(), tc.userID)
+			if tc.wantErr {
+				assert.ErrorContains(t, err, "db down")
+				return
+			}
+			require.NoError(t, err)
+			if tc.wantCustomer {
+				assert.Same(t, customer, files)
+				require.Len(t, opener.OpenCalls(), 1)
+				assert.Equal(t, "ext-1", opener.OpenCalls()[0].B.ExternalID)
+			} else {
+				assert.Same(t, platform, files)
+				assert.Empty(t, opener.OpenCalls())
+			}
+		})
+	}
+}
+
+func TestResolver_ForURL(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name         string
+		url          string
+		wantCustomer bool
+		wantKey      string
+		wantErr      error
+	}{
+		{
+			name:         "success: customer bucket",
+			url:          "https://acme-photos.s3.eu-west-1.amazonaws.com/uploads/u1/room.jpg",
+			wantCustomer: true,
+			wantKey:      "uploads/u1/room.jpg",
+		},
+		{
+			name:         "success: customer bucket s3 URL",
+			url:          "s3://acme-photos/staged/room-staged.jpg",
+			wantCustomer: true,
+			wantKey:      "staged/room-staged.jpg",
+		},
+		{
+			name:    "success: platform bucket",
+			url:     "https://real-staging.s3.amazonaws.com/uploads/u1/room.jpg",
+			wantKey: "uploads/u1/room.jpg",
+		},
+		{
+			name:    "success: path-style URL to an unknown host uses the platform",
+			url:     "http://localhost:9000/real-staging/uploads/u1/room.jpg",
+			wantKey: "uploads/u1/room.jpg",
+		},
+		{
+			name:    "fail: removed customer bucket",
+			url:     "https://gone.s3.amazonaws.com/uploads/u1/room.jpg",
+			wantErr: storage.ErrUnknownBucket,
+		},
+		{
+			name:    "fail: not an object URL",
+			url:     "https://real-staging.s3.amazonaws.com/",
+			wantErr: storage.ErrInvalidObjectURL,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				GetCustomerBucketByNameFunc: func(ctx context.Context, bucket string) (*queries.CustomerBucket, error) {
+					if bucket == "acme-photos" {
+						return bucketRow(userID, true), nil
+					}
+					return nil, pgx.ErrNoRows
+				},
+			}
+			r, _, platform, customer := newResolver(q)
+
+			files, key, err := r.ForURL(context.Background(), tc.url)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantKey, key)
+			if tc.wantCustomer {
+				assert.Same(t, customer, files)
+			} else {
+				assert.Same(t, platform, files)
+			}
+		})
+	}
+}
+
+func TestResolver_ForURL_RegionBucket(t *testing.T) {
+	q := &queries.QuerierMock{}
+	home := &storage.S3ServiceMock{BucketNameFunc: func() string { return "real-staging" }}
+	eu := &storage.S3ServiceMock{BucketNameFunc: func() string { return "real-staging-eu" }}
+	platform := storage.NewRegionalBuckets(home, map[string]storage.S3Service{"eu-central-1": eu}, nil)
+	r := NewResolverWithQuerier(q, platform, &OpenerMock{})
+
+	files, key, err := r.ForURL(context.Background(), "https://real-staging-eu.s3.eu-central-1.amazonaws.com/uploads/a.jpg")
+	require.NoError(t, err)
+	assert.Same(t, eu, files)
+	assert.Equal(t, "uploads/a.jpg", key)
+	assert.Empty(t, q.GetCustomerBucketByNameCalls(), "region buckets are not customer buckets")
+
+	err = r.Check(context.Background(), &Bucket{Bucket: "real-staging-eu"})
+	assert.ErrorIs(t, err, ErrInvalid)
+}
+
+func TestResolver_Authorize(t *testing.T) {
+	ownerID, otherID, projectID := uuid.New(), uuid.New(), uuid.New()
+
+	testCases := []struct {
+		name    string
+		url     string
+		owner   uuid.UUID
+		wantErr error
+	}{
+		{name: "success: platform bucket", url: "https://real-staging.s3.amazonaws.com/uploads/a.jpg", owner: otherID},
+		{name: "success: owner's bucket", url: "https://acme-photos.s3.amazonaws.com/uploads/a.jpg", owner: ownerID},
+		{
+			name:    "fail: another account's bucket",
+			url:     "https://acme-photos.s3.amazonaws.com/uploads/a.jpg",
+			owner:   otherID,
+			wantErr: storage.ErrForeignBucket,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				GetCustomerBucketByNameFunc: func(ctx context.Context, bucket string) (*queries.CustomerBucket, error) {
+					return bucketRow(ownerID, true), nil
+				},
+				GetProjectByIDFunc: func(ctx context.Context, id pgtype.UUID) (*queries.GetProjectByIDRow, error) {
+					assert.Equal(t, projectID, uuid.UUID(id.Bytes))
+					return &queries.GetProjectByIDRow{UserID: pgtype.UUID{Bytes: tc.owner, Valid: true}}, nil
+				},
+			}
+			r, _, _, _ := newResolver(q)
+
+			err := r.Authorize(context.Background(), projectID.String(), tc.url)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestResolver_Check(t *testing.T) {
+	r, opener, _, _ := newResolver(&queries.QuerierMock{})
+
+	require.NoError(t, r.Check(context.Background(), &Bucket{Bucket: "acme-photos"}))
+	assert.Len(t, opener.CheckCalls(), 1)
+
+	err := r.Check(context.Background(), &Bucket{Bucket: "real-staging"})
+	assert.ErrorIs(t, err, ErrInvalid)
+	assert.Len(t, opener.CheckCalls(), 1)
+}
@@ -0,0 +1,23 @@
+package byobucket
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service Checker
+
+// Service manages a user's customer-managed bucket. Every call is scoped to the user.
+type Service interface {
+	// Get returns the user's bucket settings.
+	Get(ctx context.Context, userID string) (*Bucket, error)
+	// Put saves the user's bucket settings. Uploads stay in the platform bucket until Verify passes.
+	Put(ctx context.Context, userID string, req PutRequest) (*Bucket, error)
+	// Verify runs an access check against the bucket and, when it passes, routes new uploads to it.
+	Verify(ctx context.Context, userID string) (*Bucket, error)
+	// Delete removes the settings. New uploads go to the platform bucket; images already in
+	// the customer's bucket stay there but can no longer be served.
+	Delete(ctx context.Context, userID string) error
+}
+
+// Checker runs access checks against customer buckets. *Resolver implements it.
+type Checker interface {
+	Check(ctx context.Context, b *Bucket) error
+}
@@ -0,0 +1,54 @@
+// Package catalog manages furniture catalogs: admin-defined style packs of a prompt
+// fragment and reference images that users can pick when staging an image.
+package catalog
+
+import (
+	"errors"
+	"time"
+)
+
+// Limits on a catalog's contents.
+const (
+	MaxNameLength           = 100
+	MaxPromptFragmentLength = 1000
+	MaxReferenceImages      = 3
+)
+
+var (
+	// ErrNotFound is returned when a catalog does not exist, or is inactive where only
+	// active catalogs may be used.
+	ErrNotFound = errors.New("catalog not found")
+	// ErrNameTaken is returned when another catalog already has the name.
+	ErrNameTaken = errors.New("catalog name already in use")
+	// ErrInvalid wraps validation failures of a create or update request.
+	ErrInvalid = errors.New("invalid catalog")
+)
+
+// Catalog is a furniture style pack.
+type Catalog struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// PromptFragment is appended to the staging prompt.
+	PromptFragment string `json:"prompt_fragment"`
+	// ReferenceImageURLs are passed to models that support image conditioning.
+	// Each is an https:// URL or an s3:// URL in the images bucket.
+	ReferenceImageURLs []string  `json:"reference_image_urls"`
+	Active             bool      `json:"active"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// UpsertRequest creates or replaces a catalog. Active defaults to true when omitted.
+type UpsertRequest struct {
+	Name               string   `json:"name"`
+	Description        string   `json:"description"`
+	PromptFragment     string   `json:"prompt_fragment"`
+	ReferenceImageURLs []string `json:"reference_image_urls"`
+	Active             *bool    `json:"active,omitempty"`
+}
+
+// ListResponse lists catalogs.
+type ListResponse struct {
+	Catalogs []Catalog `json:"catalogs"`
+}
@@ -0,0 +1,118 @@
+package catalog
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DefaultHandler serves catalogs over HTTP.
+type DefaultHandler struct {
+	service Service
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service) *DefaultHandler {
+	return &DefaultHandler{service: service}
+}
+
+// ErrorResponse is a simple JSON error envelope for handler responses.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// List handles GET /api/v1/catalogs, returning the catalogs users can pick.
+func (h *DefaultHandler) List(c echo.Context) error {
+	return h.list(c, false)
+}
+
+// AdminList handles GET /api/v1/admin/catalogs, including inactive catalogs.
+func (h *DefaultHandler) AdminList(c echo.Context) error {
+	return h.list(c, true)
+}
+
+func (h *DefaultHandler) list(c echo.Context, includeInactive bool) error {
+	catalogs, err := h.service.List(c.Request().Context(), includeInactive)
+	if err != nil {
+		c.Logger().Errorf("Failed to list catalogs: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to list catalogs",
+		})
+	}
+	return c.JSON(http.StatusOK, ListResponse{Catalogs: catalogs})
+}
+
+// Create handles POST /api/v1/admin/catalogs.
+func (h *DefaultHandler) Create(c echo.Context) error {
+	var req UpsertRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid request format",
+		})
+	}
+
+	created, err := h.service.Create(c.Request().Context(), req)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusCreated, created)
+}
+
+// Update handles PUT /api/v1/admin/catalogs/:id.
+func (h *DefaultHandler) Update(c echo.Context) error {
+	var req UpsertRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid request format",
+		})
+	}
+
+	updated, err := h.service.Update(c.Request().Context(), c.Param("id"), req)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, updated)
+}
+
+// Delete handles DELETE /api/v1/admin/catalogs/:id.
+func (h *DefaultHandler) Delete(c echo.Context) error {
+	if err := h.service.Delete(c.Request().Context(), c.Param("id")); err != nil {
+		return h.writeError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// writeError maps service errors to responses.
+func (h *DefaultHandler) writeError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, ErrInvalid):
+		return c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Error:   "validation_failed",
+			Message: err.Error(),
+		})
+	case errors.Is(err, ErrNotFound):
+		return c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "not_found",
+			Message: "Catalog not found",
+		})
+	case errors.Is(err, ErrNameTaken):
+		return c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "conflict",
+			Message: "A catalog with this name already exists",
+		})
+	default:
+		c.Logger().Errorf("Catalog request failed: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to process catalog request",
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultHandler_List(t *testing.T) {
+	testCases := []struct {
+		name            string
+		admin           bool
+		err             error
+		expectedStatus  int
+		includeInactive bool
+	}{
+		{name: "success: user list", expectedStatus: http.StatusOK},
+		{name: "success: admin list", admin: true, expectedStatus: http.StatusOK, includeInactive: true},
+		{name: "fail: service error", err: errors.New("db down"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				ListFunc: func(ctx context.Context, includeInactive bool) ([]Catalog, error) {
+					assert.Equal(t, tc.includeInactive, includeInactive)
+					return []Catalog{{ID: "c1", Name: "Coastal"}}, tc.err
+				},
+			}
+			h := NewDefaultHandler(svc)
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+			var err error
+			if tc.admin {
+				err = h.AdminList(c)
+			} else {
+				err = h.List(c)
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Contains(t, rec.Body.String(), `"name":"Coastal"`)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_CreateUpdateDelete(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: create", method: http.MethodPost, body: `{"name":"Coastal","prompt_fragment":"oak"}`,
+			expectedStatus: http.StatusCreated},
+		{name: "success: update", method: http.MethodPut, body: `{"name":"Coastal","prompt_fragment":"oak"}`,
+			expectedStatus: http.StatusOK},
+		{name: "success: delete", method: http.MethodDelete, expectedStatus: http.StatusNoContent},
+		{name: "fail: malformed body", method: http.MethodPost, body: `{`, expectedStatus: http.StatusBadRequest},
+		{name: "fail: invalid catalog", method: http.MethodPost, body: `{}`,
+			err: fmt.Errorf("%w: name is required", ErrInvalid), expectedStatus: http.StatusUnprocessableEntity},
+		{name: "fail: name taken", method: http.MethodPut, body: `{"name":"Coastal"}`,
+			err: ErrNameTaken, expectedStatus: http.StatusConflict},
+		{name: "fail: missing catalog", method: http.MethodDelete, err: ErrNotFound, expectedStatus: http.StatusNotFound},
+		{name: "fail: service error", method: http.MethodPost, body: `{"name":"Coastal"}`,
+			err: errors.New("db down"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := func(req UpsertRequest) (*Catalog, error) {
+				if tc.err != nil {
+					return nil, tc.err
+				}
+				return &Catalog{ID: "c1", Name: req.Name}, nil
+			}
+			svc := &ServiceMock{
+				CreateFunc: func(ctx context.Context, req UpsertRequest) (*Catalog, error) {
+					return result(req)
+				},
+				UpdateFunc: func(ctx context.Context, id string, req UpsertRequest) (*Catalog, error) {
+					assert.Equal(t, "c1", id)
+					return result(req)
+				},
+				DeleteFunc: func(ctx context.Context, id string) error {
+					assert.Equal(t, "c1", id)
+					return tc.err
+				},
+			}
+			h := NewDefaultHandler(svc)
+
+			e := echo.New()
+			req := httptest.NewRequest(tc.method, "/", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("c1")
+
+			var err error
+			switch tc.method {
+			case http.MethodPost:
+				err = h.Create(c)
+			case http.MethodPut:
+				err = h.Update(c)
+			case http.MethodDelete:
+				err = h.Delete(c)
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
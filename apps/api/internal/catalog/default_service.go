@@ -0,0 +1,213 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// uniqueViolation is the Postgres error code raised when a catalog name is already taken.
+const uniqueViolation = "23505"
+
+// DefaultService implements Service.
+type DefaultService struct {
+	querier queries.Querier
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService with a database.
+func NewDefaultService(db storage.Database) *DefaultService {
+	return &DefaultService{querier: queries.New(db)}
+}
+
+// NewDefaultServiceWithQuerier creates a new DefaultService with a custom querier (for testing).
+func NewDefaultServiceWithQuerier(querier queries.Querier) *DefaultService {
+	return &DefaultService{querier: querier}
+}
+
+// List returns catalogs ordered by name.
+func (s *DefaultService) List(ctx context.Context, includeInactive bool) ([]Catalog, error) {
+	var (
+		rows []*queries.Catalog
+		err  error
+	)
+	if includeInactive {
+		rows, err = s.querier.ListCatalogs(ctx)
+	} else {
+		rows, err = s.querier.ListActiveCatalogs(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list catalogs: %w", err)
+	}
+
+	catalogs := make([]Catalog, 0, len(rows))
+	for _, row := range rows {
+		catalogs = append(catalogs, toCatalog(row))
+	}
+	return catalogs, nil
+}
+
+// GetActive returns an active catalog, or ErrNotFound.
+func (s *DefaultService) GetActive(ctx context.Context, id string) (*Catalog, error) {
+	catalogID, err := parseUUID(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	row, err := s.querier.GetCatalogByID(ctx, catalogID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get catalog: %w", err)
+	}
+	if !row.Active {
+		return nil, ErrNotFound
+	}
+	c := toCatalog(row)
+	return &c, nil
+}
+
+// Create adds a catalog.
+func (s *DefaultService) Create(ctx context.Context, req UpsertRequest) (*Catalog, error) {
+	req, err := normalize(req)
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.querier.CreateCatalog(ctx, queries.CreateCatalogParams{
+		Name:               req.Name,
+		Description:        req.Description,
+		PromptFragment:     req.PromptFragment,
+		ReferenceImageUrls: req.ReferenceImageURLs,
+		Active:             *req.Active,
+	})
+	if err != nil {
+		return nil, writeError("create", err)
+	}
+	c := toCatalog(row)
+	return &c, nil
+}
+
+// Update replaces a catalog's contents.
+func (s *DefaultService) Update(ctx context.Context, id string, req UpsertRequest) (*Catalog, error) {
+	catalogID, err := parseUUID(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	req, err = normalize(req)
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.querier.UpdateCatalog(ctx, queries.UpdateCatalogParams{
+		ID:                 catalogID,
+		Name:               req.Name,
+		Description:        req.Description,
+		PromptFragment:     req.PromptFragment,
+		ReferenceImageUrls: req.ReferenceImageURLs,
+		Active:             *req.Active,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, writeError("update", err)
+	}
+	c := toCatalog(row)
+	return &c, nil
+}
+
+// Delete removes a catalog.
+func (s *DefaultService) Delete(ctx context.Context, id string) error {
+	catalogID, err := parseUUID(id)
+	if err != nil {
+		return ErrNotFound
+	}
+	n, err := s.querier.DeleteCatalog(ctx, catalogID)
+	if err != nil {
+		return fmt.Errorf("failed to delete catalog: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// normalize trims and validates req, defaulting Active to true.
+func normalize(req UpsertRequest) (UpsertRequest, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	req.Description = strings.TrimSpace(req.Description)
+	req.PromptFragment = strings.TrimSpace(req.PromptFragment)
+	if req.Active == nil {
+		active := true
+		req.Active = &active
+	}
+	if req.ReferenceImageURLs == nil {
+		req.ReferenceImageURLs = []string{}
+	}
+
+	switch {
+	case req.Name == "":
+		return req, fmt.Errorf("%w: name is required", ErrInvalid)
+	case len(req.Name) > MaxNameLength:
+		return req, fmt.Errorf("%w: name must be at most %d characters", ErrInvalid, MaxNameLength)
+	case req.PromptFragment == "":
+		return req, fmt.Errorf("%w: prompt_fragment is required", ErrInvalid)
+	case len(req.PromptFragment) > MaxPromptFragmentLength:
+		return req, fmt.Errorf("%w: prompt_fragment must be at most %d characters",
+			ErrInvalid, MaxPromptFragmentLength)
+	case len(req.ReferenceImageURLs) > MaxReferenceImages:
+		return req, fmt.Errorf("%w: at most %d reference images are allowed", ErrInvalid, MaxReferenceImages)
+	}
+	for i, raw := range req.ReferenceImageURLs {
+		u, err := url.Parse(raw)
+		if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "s3") {
+			return req, fmt.Errorf("%w: reference_image_urls[%d] must be an https:// or s3:// URL", ErrInvalid, i)
+		}
+	}
+	return req, nil
+}
+
+// writeError maps a taken name to ErrNameTaken.
+func writeError(op string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return ErrNameTaken
+	}
+	return fmt.Errorf("failed to %s catalog: %w", op, err)
+}
+
+func parseUUID(id string) (pgtype.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+	return pgtype.UUID{Bytes: parsed, Valid: true}, nil
+}
+
+func toCatalog(row *queries.Catalog) Catalog {
+	refs := row.ReferenceImageUrls
+	if refs == nil {
+		refs = []string{}
+	}
+	return Catalog{
+		ID:                 uuid.UUID(row.ID.Bytes).String(),
+		Name:               row.Name,
+		Description:        row.Description,
+		PromptFragment:     row.PromptFragment,
+		ReferenceImageURLs: refs,
+		Active:             row.Active,
+		CreatedAt:          row.CreatedAt.Time,
+		UpdatedAt:          row.UpdatedAt.Time,
+	}
+}
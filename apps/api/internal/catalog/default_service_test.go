@@ -0,0 +1,268 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+func catalogRow(id uuid.UUID, active bool) *queries.Catalog {
+	return &queries.Catalog{
+		ID:                 pgtype.UUID{Bytes: id, Valid: true},
+		Name:               "Coastal",
+		PromptFragment:     "light oak, linen, rattan accents",
+		ReferenceImageUrls: []string{"https://cdn.example.com/coastal.jpg"},
+		Active:             active,
+	}
+}
+
+func TestDefaultService_List(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("success: active only", func(t *testing.T) {
+		q := &queries.QuerierMock{
+			ListActiveCatalogsFunc: func(ctx context.Context) ([]*queries.Catalog, error) {
+				return []*queries.Catalog{catalogRow(id, true)}, nil
+			},
+		}
+		catalogs, err := NewDefaultServiceWithQuerier(q).List(context.Background(), false)
+		require.NoError(t, err)
+		require.Len(t, catalogs, 1)
+		assert.Equal(t, id.String(), catalogs[0].ID)
+		assert.Equal(t, []string{"https://cdn.example.com/coastal.jpg"}, catalogs[0].ReferenceImageURLs)
+		assert.Empty(t, q.ListCatalogsCalls())
+	})
+
+	t.Run("success: including inactive", func(t *testing.T) {
+		q := &queries.QuerierMock{
+			ListCatalogsFunc: func(ctx context.Context) ([]*queries.Catalog, error) {
+				return []*queries.Catalog{catalogRow(id, false)}, nil
+			},
+		}
+		catalogs, err := NewDefaultServiceWithQuerier(q).List(context.Background(), true)
+		require.NoError(t, err)
+		require.Len(t, catalogs, 1)
+		assert.False(t, catalogs[0].Active)
+	})
+
+	t.Run("fail: query error", func(t *testing.T) {
+		q := &queries.QuerierMock{
+			ListActiveCatalogsFunc: func(ctx context.Context) ([]*queries.Catalog, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		_, err := NewDefaultServiceWithQuerier(q).List(context.Background(), false)
+		assert.ErrorContains(t, err, "db down")
+	})
+}
+
+func TestDefaultService_GetActive(t *testing.T) {
+	id := uuid.New()
+
+	testCases := []struct {
+		name    string
+		id      string
+		row     *queries.Catalog
+		err     error
+		wantErr error
+	}{
+		{name: "success: active catalog", id: id.String(), row: catalogRow(id, true)},
+		{name: "fail: inactive catalog", id: id.String(), row: catalogRow(id, false), wantErr: ErrNotFound},
+		{name: "fail: missing catalog", id: id.String(), err: pgx.ErrNoRows, wantErr: ErrNotFound},
+		{name: "fail: invalid id", id: "nope", wantErr: ErrNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				GetCatalogByIDFunc: func(ctx context.Context, id pgtype.UUID) (*queries.Catalog, error) {
+					return tc.row, tc.err
+				},
+			}
+			got, err := NewDefaultServiceWithQuerier(q).GetActive(context.Background(), tc.id)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Coastal", got.Name)
+		})
+	}
+}
+
+func TestDefaultService_Create(t *testing.T) {
+	inactive := false
+
+	testCases := []struct {
+		name       string
+		req        UpsertRequest
+		createErr  error
+		wantActive bool
+		wantErr    error
+		errSubstr  string
+	}{
+		{
+			name:       "success: defaults to active",
+			req:        UpsertRequest{Name: " Coastal ", PromptFragment: "light oak"},
+			wantActive: true,
+		},
+		{
+			name: "success: inactive with s3 reference",
+			req: UpsertRequest{
+				Name: "Coastal", PromptFragment: "light oak", Active: &inactive,
+				ReferenceImageURLs: []string{"s3://bucket/catalogs/coastal.jpg"},
+			},
+		},
+		{
+			name:      "fail: missing name",
+			req:       UpsertRequest{PromptFragment: "light oak"},
+			wantErr:   ErrInvalid,
+			errSubstr: "name is required",
+		},
+		{
+			name:      "fail: missing prompt fragment",
+			req:       UpsertRequest{Name: "Coastal"},
+			wantErr:   ErrInvalid,
+			errSubstr: "prompt_fragment is required",
+		},
+		{
+			name:      "fail: prompt fragment too long",
+			req:       UpsertRequest{Name: "Coastal", PromptFragment: strings.Repeat("a", MaxPromptFragmentLength+1)},
+			wantErr:   ErrInvalid,
+			errSubstr: "prompt_fragment must be at most",
+		},
+		{
+			name: "fail: too many reference images",
+			req: UpsertRequest{Name: "Coastal", PromptFragment: "light oak", ReferenceImageURLs: []string{
+				"https://a.example.com/1.jpg", "https://a.example.com/2.jpg",
+				"https://a.example.com/3.jpg", "https://a.example.com/4.jpg",
+			}},
+			wantErr:   ErrInvalid,
+			errSubstr: "at most 3 reference images",
+		},
+		{
+			name: "fail: plain http reference",
+			req: UpsertRequest{
+				Name: "Coastal", PromptFragment: "light oak",
+				ReferenceImageURLs: []string{"http://a.example.com/1.jpg"},
+			},
+			wantErr:   ErrInvalid,
+			errSubstr: "reference_image_urls[0]",
+		},
+		{
+			name:      "fail: name taken",
+			req:       UpsertRequest{Name: "Coastal", PromptFragment: "light oak"},
+			createErr: &pgconn.PgError{Code: uniqueViolation},
+			wantErr:   ErrNameTaken,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				CreateCatalogFunc: func(ctx context.Context, arg queries.CreateCatalogParams) (*queries.Catalog, error) {
+					if tc.createErr != nil {
+						return nil, tc.createErr
+					}
+					assert.Equal(t, "Coastal", arg.Name)
+					assert.NotNil(t, arg.ReferenceImageUrls)
+					return &queries.Catalog{
+						ID:                 pgtype.UUID{Bytes: uuid.New(), Valid: true},
+						Name:               arg.Name,
+						PromptFragment:     arg.PromptFragment,
+						ReferenceImageUrls: arg.ReferenceImageUrls,
+						Active:             arg.Active,
+					}, nil
+				},
+			}
+
+			got, err := NewDefaultServiceWithQuerier(q).Create(context.Background(), tc.req)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				if tc.errSubstr != "" {
+					assert.ErrorContains(t, err, tc.errSubstr)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantActive, got.Active)
+		})
+	}
+}
+
+func TestDefaultService_Update(t *testing.T) {
+	id := uuid.New()
+	req := UpsertRequest{Name: "Coastal", PromptFragment: "light oak"}
+
+	t.Run("success: updates catalog", func(t *testing.T) {
+		q := &queries.QuerierMock{
+			UpdateCatalogFunc: func(ctx context.Context, arg queries.UpdateCatalogParams) (*queries.Catalog, error) {
+				assert.Equal(t, id, uuid.UUID(arg.ID.Bytes))
+				return catalogRow(id, arg.Active), nil
+			},
+		}
+		got, err := NewDefaultServiceWithQuerier(q).Update(context.Background(), id.String(), req)
+		require.NoError(t, err)
+		assert.True(t, got.Active)
+	})
+
+	t.Run("fail: missing catalog", func(t *testing.T) {
+		q := &queries.QuerierMock{
+			UpdateCatalogFunc: func(ctx context.Context, arg queries.UpdateCatalogParams) (*queries.Catalog, error) {
+				return nil, pgx.ErrNoRows
+			},
+		}
+		_, err := NewDefaultServiceWithQuerier(q).Update(context.Background(), id.String(), req)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("fail: invalid request skips the query", func(t *testing.T) {
+		q := &queries.QuerierMock{}
+		_, err := NewDefaultServiceWithQuerier(q).Update(context.Background(), id.String(), UpsertRequest{})
+		assert.ErrorIs(t, err, ErrInvalid)
+	})
+}
+
+func TestDefaultService_Delete(t *testing.T) {
+	id := uuid.New()
+
+	testCases := []struct {
+		name    string
+		rows    int64
+		err     error
+		wantErr error
+	}{
+		{name: "success: deleted", rows: 1},
+		{name: "fail: missing catalog", wantErr: ErrNotFound},
+		{name: "fail: query error", err: errors.New("db down")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				DeleteCatalogFunc: func(ctx context.Context, id pgtype.UUID) (int64, error) {
+					return tc.rows, tc.err
+				},
+			}
+			err := NewDefaultServiceWithQuerier(q).Delete(context.Background(), id.String())
+			switch {
+			case tc.wantErr != nil:
+				assert.ErrorIs(t, err, tc.wantErr)
+			case tc.err != nil:
+				assert.ErrorContains(t, err, "db down")
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
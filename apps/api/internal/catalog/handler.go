@@ -0,0 +1,15 @@
+package catalog
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP-level contract for catalog endpoints.
+// Implementations should be wired to Echo routes in the server.
+type Handler interface {
+	List(c echo.Context) error
+	AdminList(c echo.Context) error
+	Create(c echo.Context) error
+	Update(c echo.Context) error
+	Delete(c echo.Context) error
+}
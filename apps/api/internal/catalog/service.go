@@ -0,0 +1,19 @@
+package catalog
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service manages catalogs.
+type Service interface {
+	// List returns catalogs ordered by name; inactive ones only when includeInactive is set.
+	List(ctx context.Context, includeInactive bool) ([]Catalog, error)
+	// GetActive returns an active catalog, or ErrNotFound.
+	GetActive(ctx context.Context, id string) (*Catalog, error)
+	// Create adds a catalog.
+	Create(ctx context.Context, req UpsertRequest) (*Catalog, error)
+	// Update replaces a catalog's contents.
+	Update(ctx context.Context, id string, req UpsertRequest) (*Catalog, error)
+	// Delete removes a catalog. Jobs already queued keep the copy in their payload.
+	Delete(ctx context.Context, id string) error
+}
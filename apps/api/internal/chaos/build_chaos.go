@@ -0,0 +1,6 @@
+//go:build chaos
+
+package chaos
+
+// compiledIn enables FromEnv in binaries built with -tags chaos.
+const compiledIn = true
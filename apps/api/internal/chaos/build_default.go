@@ -0,0 +1,6 @@
+//go:build !chaos
+
+package chaos
+
+// compiledIn keeps FromEnv inert in normal builds.
+const compiledIn = false
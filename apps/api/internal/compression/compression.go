@@ -0,0 +1,138 @@
+// Package compression provides response compression middleware for JSON-heavy routes.
+//
+// Responses are compressed with brotli or gzip, whichever the client prefers, but only
+// when their Content-Type is in the allow-list and the body reaches a minimum size.
+// Event streams and binary downloads are never in the allow-list, so attaching the
+// middleware to a route that streams is harmless.
+package compression
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+// Supported content encodings.
+const (
+	EncodingBrotli = "br"
+	EncodingGzip   = "gzip"
+)
+
+// Config controls which responses are compressed.
+type Config struct {
+	// Enabled turns compression off entirely when false.
+	Enabled bool
+	// MinLength is the smallest body, in bytes, worth compressing. Smaller bodies are
+	// sent as-is because the encoding overhead outweighs the savings.
+	MinLength int
+	// ContentTypes lists the media types (without parameters) that may be compressed.
+	ContentTypes []string
+	// GzipLevel is the gzip compression level.
+	GzipLevel int
+	// BrotliLevel is the brotli compression quality (0-11).
+	BrotliLevel int
+}
+
+// DefaultConfig returns compression settings suited to the API's JSON responses.
+func DefaultConfig() Config {
+	return Config{
+		Enabled:   true,
+		MinLength: 1024,
+		ContentTypes: []string{
+			echo.MIMEApplicationJSON,
+			"application/problem+json",
+			"application/x-ndjson",
+			"text/csv",
+			echo.MIMETextPlain,
+		},
+		GzipLevel:   gzip.DefaultCompression,
+		BrotliLevel: 4,
+	}
+}
+
+// FromConfig returns DefaultConfig overridden by the compression section of the app config.
+// A negative MinBytes is ignored.
+func FromConfig(c config.Compression) Config {
+	cfg := DefaultConfig()
+	cfg.Enabled = c.Enabled
+	if c.MinBytes >= 0 {
+		cfg.MinLength = c.MinBytes
+	}
+	return cfg
+}
+
+// Middleware returns middleware that compresses eligible responses. Attach it to the
+// individual routes that return large JSON bodies.
+func Middleware(cfg Config) echo.MiddlewareFunc {
+	pools := newPools(cfg)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !cfg.Enabled || c.Request().Method == http.MethodHead || c.Request().Header.Get("Range") != "" {
+				return next(c)
+			}
+			encoding := negotiate(c.Request().Header.Get(echo.HeaderAcceptEncoding))
+			if encoding == "" {
+				return next(c)
+			}
+
+			res := c.Response()
+			w := &responseWriter{
+				ResponseWriter: res.Writer,
+				encoding:       encoding,
+				cfg:            &cfg,
+				pools:          pools,
+			}
+			res.Writer = w
+			defer func() {
+				w.finish()
+				res.Writer = w.ResponseWriter
+			}()
+			return next(c)
+		}
+	}
+}
+
+// negotiate picks the encoding to use from an Accept-Encoding header, preferring brotli
+// over gzip when the client weights them equally. It returns "" when neither is acceptable.
+func negotiate(header string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != EncodingBrotli && name != EncodingGzip {
+			continue
+		}
+		q := 1.0
+		if p := strings.TrimSpace(params); strings.HasPrefix(p, "q=") {
+			parsed, err := strconv.ParseFloat(strings.TrimPrefix(p, "q="), 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		if q > bestQ || (q == bestQ && name == EncodingBrotli) {
+			best, bestQ = name, q
+		}
+	}
+	return best
+}
+
+// compressible reports whether a Content-Type header value is in the allow-list.
+func compressible(contentType string, allowed []string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	for _, t := range allowed {
+		if mediaType == t {
+			return true
+		}
+	}
+	return false
+}
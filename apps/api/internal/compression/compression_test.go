@@ -0,0 +1,222 @@
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+func TestNegotiate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "success: brotli preferred on tie", header: "gzip, deflate, br", want: EncodingBrotli},
+		{name: "success: gzip only", header: "gzip", want: EncodingGzip},
+		{name: "success: higher q wins", header: "br;q=0.5, gzip;q=0.8", want: EncodingGzip},
+		{name: "success: q=0 excludes", header: "br;q=0, gzip", want: EncodingGzip},
+		{name: "success: case insensitive", header: "GZIP", want: EncodingGzip},
+		{name: "success: none acceptable", header: "deflate, identity", want: ""},
+		{name: "success: empty header", header: "", want: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, negotiate(tc.header))
+		})
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	cfg := FromConfig(config.Compression{Enabled: false, MinBytes: 2048})
+	assert.False(t, cfg.Enabled)
+	assert.Equal(t, 2048, cfg.MinLength)
+	assert.Equal(t, DefaultConfig().ContentTypes, cfg.ContentTypes)
+
+	cfg = FromConfig(config.Compression{Enabled: true, MinBytes: -1})
+	assert.True(t, cfg.Enabled)
+	assert.Equal(t, DefaultConfig().MinLength, cfg.MinLength)
+}
+
+func decode(t *testing.T, encoding string, body []byte) string {
+	t.Helper()
+
+	var r io.Reader
+	switch encoding {
+	case EncodingGzip:
+		zr, err := gzip.NewReader(bytes.NewReader(body))
+		require.NoError(t, err)
+		r = zr
+	case EncodingBrotli:
+		r = brotli.NewReader(bytes.NewReader(body))
+	default:
+		return string(body)
+	}
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(out)
+}
+
+func TestMiddleware(t *testing.T) {
+	largeJSON := map[string]string{"data": strings.Repeat("staged image ", 200)}
+	largeJSONBody := `{"data":"` + strings.Repeat("staged image ", 200) + `"}` + "\n"
+
+	testCases := []struct {
+		name           string
+		cfg            Config
+		method         string
+		acceptEncoding string
+		handler        echo.HandlerFunc
+		wantEncoding   string
+		wantBody       string
+	}{
+		{
+			name:           "success: large JSON compressed with brotli",
+			cfg:            DefaultConfig(),
+			acceptEncoding: "gzip, br",
+			handler:        func(c echo.Context) error { return c.JSON(http.StatusOK, largeJSON) },
+			wantEncoding:   EncodingBrotli,
+			wantBody:       largeJSONBody,
+		},
+		{
+			name:           "success: large JSON compressed with gzip",
+			cfg:            DefaultConfig(),
+			acceptEncoding: "gzip",
+			handler:        func(c echo.Context) error { return c.JSON(http.StatusOK, largeJSON) },
+			wantEncoding:   EncodingGzip,
+			wantBody:       largeJSONBody,
+		},
+		{
+			name:           "success: small JSON sent as-is",
+			cfg:            DefaultConfig(),
+			acceptEncoding: "gzip, br",
+			handler:        func(c echo.Context) error { return c.JSON(http.StatusOK, map[string]int{"count": 1}) },
+			wantBody:       `{"count":1}` + "\n",
+		},
+		{
+			name:           "success: binary content type sent as-is",
+			cfg:            DefaultConfig(),
+			acceptEncoding: "gzip, br",
+			handler: func(c echo.Context) error {
+				return c.Blob(http.StatusOK, "image/png", bytes.Repeat([]byte{0x89}, 4096))
+			},
+			wantBody: string(bytes.Repeat([]byte{0x89}, 4096)),
+		},
+		{
+			name:           "success: client without compression support",
+			cfg:            DefaultConfig(),
+			acceptEncoding: "",
+			handler:        func(c echo.Context) error { return c.JSON(http.StatusOK, largeJSON) },
+			wantBody:       largeJSONBody,
+		},
+		{
+			name:           "success: disabled",
+			cfg:            Config{Enabled: false},
+			acceptEncoding: "gzip",
+			handler:        func(c echo.Context) error { return c.JSON(http.StatusOK, largeJSON) },
+			wantBody:       largeJSONBody,
+		},
+		{
+			name:           "success: error status still compressed",
+			cfg:            DefaultConfig(),
+			acceptEncoding: "gzip",
+			handler:        func(c echo.Context) error { return c.JSON(http.StatusBadRequest, largeJSON) },
+			wantEncoding:   EncodingGzip,
+			wantBody:       largeJSONBody,
+		},
+		{
+			name:           "success: HEAD skipped",
+			cfg:            DefaultConfig(),
+			method:         http.MethodHead,
+			acceptEncoding: "gzip",
+			handler:        func(c echo.Context) error { return c.JSON(http.StatusOK, largeJSON) },
+			wantBody:       largeJSONBody,
+		},
+		{
+			name:           "success: no content",
+			cfg:            DefaultConfig(),
+			acceptEncoding: "gzip",
+			handler:        func(c echo.Context) error { return c.NoContent(http.StatusNoContent) },
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			method := tc.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, "/", nil)
+			if tc.acceptEncoding != "" {
+				req.Header.Set(echo.HeaderAcceptEncoding, tc.acceptEncoding)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			require.NoError(t, Middleware(tc.cfg)(tc.handler)(c))
+
+			assert.Equal(t, tc.wantEncoding, rec.Header().Get(echo.HeaderContentEncoding))
+			assert.Equal(t, tc.wantBody, decode(t, tc.wantEncoding, rec.Body.Bytes()))
+			if tc.wantEncoding != "" {
+				assert.Contains(t, rec.Header().Values(echo.HeaderVary), echo.HeaderAcceptEncoding)
+				assert.Less(t, rec.Body.Len(), len(tc.wantBody))
+			}
+		})
+	}
+}
+
+func TestMiddleware_EventStreamPassesThrough(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	req.Header.Set(echo.HeaderAcceptEncoding, "gzip, br")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := func(c echo.Context) error {
+		c.Response().Header().Set(echo.HeaderContentType, "text/event-stream")
+		c.Response().WriteHeader(http.StatusOK)
+		_, _ = c.Response().Write([]byte("event: ping\ndata: {}\n\n"))
+		c.Response().Flush()
+		// Each event must reach the client as soon as it is flushed.
+		assert.Equal(t, "event: ping\ndata: {}\n\n", rec.Body.String())
+		assert.True(t, rec.Flushed)
+		return nil
+	}
+
+	require.NoError(t, Middleware(DefaultConfig())(handler)(c))
+	assert.Empty(t, rec.Header().Get(echo.HeaderContentEncoding))
+}
+
+func TestMiddleware_FlushCompressesBufferedBody(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(echo.HeaderAcceptEncoding, "gzip")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := func(c echo.Context) error {
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		c.Response().WriteHeader(http.StatusOK)
+		_, _ = c.Response().Write([]byte(`{"a":1}`))
+		c.Response().Flush()
+		_, _ = c.Response().Write([]byte(`{"b":2}`))
+		return nil
+	}
+
+	require.NoError(t, Middleware(DefaultConfig())(handler)(c))
+	assert.Equal(t, EncodingGzip, rec.Header().Get(echo.HeaderContentEncoding))
+	assert.Equal(t, `{"a":1}{"b":2}`, decode(t, EncodingGzip, rec.Body.Bytes()))
+}
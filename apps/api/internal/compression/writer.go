@@ -0,0 +1,186 @@
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/labstack/echo/v4"
+)
+
+// encoder is the subset of gzip.Writer and brotli.Writer used by responseWriter.
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// pools reuses encoders across requests; they hold large internal buffers.
+type pools struct {
+	gzip   sync.Pool
+	brotli sync.Pool
+}
+
+func newPools(cfg Config) *pools {
+	return &pools{
+		gzip: sync.Pool{New: func() any {
+			w, err := gzip.NewWriterLevel(io.Discard, cfg.GzipLevel)
+			if err != nil {
+				w = gzip.NewWriter(io.Discard)
+			}
+			return w
+		}},
+		brotli: sync.Pool{New: func() any {
+			return brotli.NewWriterLevel(io.Discard, cfg.BrotliLevel)
+		}},
+	}
+}
+
+func (p *pools) get(encoding string, w io.Writer) encoder {
+	var enc encoder
+	if encoding == EncodingBrotli {
+		enc = p.brotli.Get().(*brotli.Writer)
+	} else {
+		enc = p.gzip.Get().(*gzip.Writer)
+	}
+	enc.Reset(w)
+	return enc
+}
+
+func (p *pools) put(encoding string, enc encoder) {
+	enc.Reset(io.Discard)
+	if encoding == EncodingBrotli {
+		p.brotli.Put(enc)
+	} else {
+		p.gzip.Put(enc)
+	}
+}
+
+// responseWriter delays the status line until it knows whether the body will be
+// compressed: eligible bodies are buffered until they reach MinLength, then the
+// encoding headers are set and the buffer is flushed through the encoder.
+type responseWriter struct {
+	http.ResponseWriter
+	encoding string
+	cfg      *Config
+	pools    *pools
+
+	status      int
+	headerSent  bool
+	passthrough bool
+	buf         bytes.Buffer
+	enc         encoder
+}
+
+// WriteHeader records the status. Responses that cannot be compressed are passed
+// straight through; the rest wait for the body.
+func (w *responseWriter) WriteHeader(code int) {
+	if w.headerSent || w.status != 0 {
+		return
+	}
+	w.status = code
+
+	h := w.Header()
+	if !compressible(h.Get(echo.HeaderContentType), w.cfg.ContentTypes) {
+		w.passthrough = true
+		return
+	}
+	h.Add(echo.HeaderVary, echo.HeaderAcceptEncoding)
+	if h.Get(echo.HeaderContentEncoding) != "" || code < http.StatusOK ||
+		code == http.StatusNoContent || code == http.StatusNotModified {
+		w.passthrough = true
+	}
+}
+
+// Write buffers or compresses b.
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		if w.Header().Get(echo.HeaderContentType) == "" {
+			w.Header().Set(echo.HeaderContentType, http.DetectContentType(b))
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.passthrough {
+		w.sendHeader()
+		return w.ResponseWriter.Write(b)
+	}
+	if w.enc != nil {
+		return w.enc.Write(b)
+	}
+
+	w.buf.Write(b)
+	if w.buf.Len() >= w.cfg.MinLength {
+		if err := w.startEncoding(); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+// Flush sends any buffered body. A buffered eligible body is compressed even if it is
+// below MinLength, because the handler asked for the bytes to go out now.
+func (w *responseWriter) Flush() {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	switch {
+	case w.passthrough:
+		w.sendHeader()
+	case w.enc == nil:
+		_ = w.startEncoding()
+		fallthrough
+	default:
+		_ = w.enc.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *responseWriter) sendHeader() {
+	if w.headerSent {
+		return
+	}
+	w.headerSent = true
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+func (w *responseWriter) startEncoding() error {
+	h := w.Header()
+	h.Set(echo.HeaderContentEncoding, w.encoding)
+	h.Del(echo.HeaderContentLength)
+	w.sendHeader()
+
+	w.enc = w.pools.get(w.encoding, w.ResponseWriter)
+	_, err := w.enc.Write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+// finish completes the response once the handler returns: it closes the encoder or,
+// for bodies that stayed below MinLength, writes them uncompressed.
+func (w *responseWriter) finish() {
+	if w.enc != nil {
+		_ = w.enc.Close()
+		w.pools.put(w.encoding, w.enc)
+		w.enc = nil
+		return
+	}
+	if w.status == 0 {
+		// The handler wrote nothing; echo sends its own status if it needs to.
+		return
+	}
+	w.sendHeader()
+	if w.buf.Len() > 0 {
+		_, _ = w.ResponseWriter.Write(w.buf.Bytes())
+		w.buf.Reset()
+	}
+}
@@ -1,51 +1,219 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"net"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
-// Config represents the application configuration.
-
+// Config represents the application configuration. Fields tagged secret:"true" are
+// redacted by Dump.
 type Config struct {
-	App     App     `yaml:"app"`
-	Auth0   Auth0   `yaml:"auth0"`
-	DB      DB      `yaml:"db"`
-	Job     Job     `yaml:"job"`
-	Logging Logging `yaml:"logging"`
-	OTEL    OTEL    `yaml:"otel"`
-	Redis   Redis   `yaml:"redis"`
-	S3      S3      `yaml:"s3"`
+	App               App               `yaml:"app"`
+	Auth0             Auth0             `yaml:"auth0"`
+	Compression       Compression       `yaml:"compression"`
+	CustomerBuckets   CustomerBuckets   `yaml:"customer_buckets"`
+	DB                DB                `yaml:"db"`
+	Entitlements      Entitlements      `yaml:"entitlements"`
+	Expedite          Expedite          `yaml:"expedite"`
+	FieldEncryption   FieldEncryption   `yaml:"field_encryption"`
+	Geocoding         Geocoding         `yaml:"geocoding"`
+	HTTP              HTTP              `yaml:"http"`
+	ImageProxy        ImageProxy        `yaml:"image_proxy"`
+	InternalRoutes    InternalRoutes    `yaml:"internal_routes"`
+	Invitations       Invitations       `yaml:"invitations"`
+	IPAllowlist       IPAllowlist       `yaml:"ip_allowlist"`
+	Job               Job               `yaml:"job"`
+	Logging           Logging           `yaml:"logging"`
+	OTEL              OTEL              `yaml:"otel"`
+	PayloadEncryption PayloadEncryption `yaml:"payload_encryption"`
+	Reconcile         Reconcile         `yaml:"reconcile"`
+	Redis             Redis             `yaml:"redis"`
+	S3                S3                `yaml:"s3"`
+	SandboxTenant     SandboxTenant     `yaml:"sandbox_tenant"`
+	ShareLinks        ShareLinks        `yaml:"share_links"`
+	SLO               SLO               `yaml:"slo"`
+	SMS               SMS               `yaml:"sms"`
+	SMTP              SMTP              `yaml:"smtp"`
+	SSO               SSO               `yaml:"sso"`
+	Stripe            Stripe            `yaml:"stripe"`
+	Usage             Usage             `yaml:"usage"`
+	WebhookArchive    WebhookArchive    `yaml:"webhook_archive"`
+
+	sources []string
 }
 
 type App struct {
 	Env string `yaml:"env" env:"APP_ENV" env-default:"dev"`
 }
 
+// IsDevLike reports whether Env is a development or test environment, where settings
+// that protect production, such as the Stripe webhook secret, may be left unset.
+func (a App) IsDevLike() bool {
+	switch strings.ToLower(strings.TrimSpace(a.Env)) {
+	case "", "dev", "development", "local", "test":
+		return true
+	}
+	return false
+}
+
 type Auth0 struct {
 	Audience     string `yaml:"audience" env:"AUTH0_AUDIENCE"`
 	ClientID     string `yaml:"client_id" env:"AUTH0_CLIENT_ID"`
-	ClientSecret string `yaml:"client_secret" env:"AUTH0_CLIENT_SECRET"`
+	ClientSecret string `yaml:"client_secret" env:"AUTH0_CLIENT_SECRET" secret:"true"`
 	Domain       string `yaml:"domain" env:"AUTH0_DOMAIN"`
 	GrantType    string `yaml:"grant_type" env:"AUTH0_GRANT_TYPE" env-default:"client_credentials"`
 }
 
+// Compression controls gzip/brotli compression of large JSON responses.
+type Compression struct {
+	Enabled bool `yaml:"enabled" env:"COMPRESSION_ENABLED" env-default:"true"`
+	// MinBytes is the smallest response body worth compressing.
+	MinBytes int `yaml:"min_bytes" env:"COMPRESSION_MIN_BYTES" env-default:"1024"`
+}
+
+// CustomerBuckets lets accounts on eligible plans store their images in their own S3
+// bucket. The API reaches each bucket by assuming the customer's IAM role through STS with
+// the platform's default AWS credentials.
+type CustomerBuckets struct {
+	Enabled bool `yaml:"enabled" env:"CUSTOMER_BUCKETS_ENABLED"`
+	// SessionDuration is how long assumed-role credentials last. They are cached per bucket
+	// and refreshed shortly before they expire. STS accepts 15m up to the role's maximum.
+	SessionDuration time.Duration `yaml:"session_duration" env:"CUSTOMER_BUCKETS_SESSION_DURATION" env-default:"1h"`
+}
+
 type DB struct {
-	URL      string `yaml:"url" env:"DATABASE_URL"` // Full connection URL (takes precedence)
+	URL      string `yaml:"url" env:"DATABASE_URL" secret:"true"` // Full connection URL (takes precedence)
 	Database string `yaml:"pgdatabase" env:"PGDATABASE" env-default:"realstaging"`
 	Host     string `yaml:"pghost" env:"PGHOST" env-default:"localhost"`
-	Password string `yaml:"pgpassword" env:"PGPASSWORD" env-default:"postgres"`
+	Password string `yaml:"pgpassword" env:"PGPASSWORD" env-default:"postgres" secret:"true"`
 	Port     int    `yaml:"pgport" env:"PGPORT" env-default:"5432"`
 	User     string `yaml:"pguser" env:"PGUSER" env-default:"postgres"`
 	SSLMode  string `yaml:"pgsslmode" env:"PGSSLMODE" env-default:"disable"`
+	// StatementTimeout bounds each request-path statement; ScanStatementTimeout bounds
+	// statements run as storage.QueryClassScan. Zero disables the bound.
+	StatementTimeout     time.Duration `yaml:"statement_timeout" env:"DB_STATEMENT_TIMEOUT" env-default:"10s"`
+	ScanStatementTimeout time.Duration `yaml:"scan_statement_timeout" env:"DB_SCAN_STATEMENT_TIMEOUT" env-default:"5m"`
+	// SlowQueryThreshold is how long a statement may take before it is logged. Zero disables
+	// slow query logging.
+	SlowQueryThreshold time.Duration `yaml:"slow_query_threshold" env:"DB_SLOW_QUERY_THRESHOLD" env-default:"500ms"`
+}
+
+// Entitlements controls the cache of users' plan limits. Billing changes invalidate a user's
+// entry immediately; the TTLs bound how stale an entry can get if an invalidation is missed.
+type Entitlements struct {
+	// CacheTTL is how long entitlements are kept in Redis.
+	CacheTTL time.Duration `yaml:"cache_ttl" env:"ENTITLEMENTS_CACHE_TTL" env-default:"5m"`
+	// LocalTTL is how long each API instance keeps entitlements in memory.
+	LocalTTL time.Duration `yaml:"local_ttl" env:"ENTITLEMENTS_LOCAL_TTL" env-default:"10s"`
+}
+
+// Expedite bounds how often a user may move queued images to the critical queue.
+type Expedite struct {
+	// DailyLimit is how many images one user may expedite in a rolling 24 hours,
+	// across all of their projects. Zero or less disables expediting.
+	DailyLimit int `yaml:"daily_limit" env:"EXPEDITE_DAILY_LIMIT" env-default:"5"`
+}
+
+// FieldEncryption encrypts sensitive user columns (phone, billing address, Stripe customer
+// id) with AES-256-GCM before they are written to Postgres. Keys may be injected from a KMS
+// or secret store through the environment.
+type FieldEncryption struct {
+	// ActiveKey is the id of the key new values are encrypted with. Empty stores plaintext.
+	ActiveKey string `yaml:"active_key" env:"FIELD_ENCRYPTION_ACTIVE_KEY"`
+	// Keys maps key ids to base64-encoded 32-byte keys, e.g. "2026-10:<base64>". Keep
+	// retired keys listed until cmd/fieldkeys has re-encrypted every row with the active key.
+	Keys map[string]string `yaml:"keys" env:"FIELD_ENCRYPTION_KEYS" secret:"true"`
+	// IndexKey is a base64-encoded 32-byte HMAC key for the blind index Stripe customers are
+	// looked up by. Changing it requires re-running cmd/fieldkeys.
+	IndexKey string `yaml:"index_key" env:"FIELD_ENCRYPTION_INDEX_KEY" secret:"true"`
+}
+
+// HTTP tunes the API's http.Server. WriteTimeout bounds ordinary responses only;
+// SSE handlers clear their connection deadlines so long-lived streams survive it.
+type HTTP struct {
+	Addr                 string        `yaml:"addr" env:"HTTP_ADDR" env-default:":8080"`
+	H2C                  bool          `yaml:"h2c" env:"HTTP_H2C"` // Serve cleartext HTTP/2 (behind a TLS-terminating proxy)
+	IdleTimeout          time.Duration `yaml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT" env-default:"120s"`
+	MaxConcurrentStreams int           `yaml:"max_concurrent_streams" env:"HTTP_MAX_CONCURRENT_STREAMS" env-default:"1000"`
+	ReadHeaderTimeout    time.Duration `yaml:"read_header_timeout" env:"HTTP_READ_HEADER_TIMEOUT" env-default:"10s"`
+	ReadTimeout          time.Duration `yaml:"read_timeout" env:"HTTP_READ_TIMEOUT" env-default:"60s"`
+	WriteTimeout         time.Duration `yaml:"write_timeout" env:"HTTP_WRITE_TIMEOUT" env-default:"60s"`
+}
+
+// ImageProxy configures GET /img/:id, which resizes stored images on request. Renditions
+// are cached in process (memory), in Redis (redis), or not at all (none).
+// Geocoding resolves project listing addresses to coordinates. An empty Provider disables
+// it; listings are then saved without coordinates.
+type Geocoding struct {
+	BaseURL string `yaml:"base_url" env:"GEOCODING_BASE_URL" env-default:"https://nominatim.openstreetmap.org"`
+	// Email identifies the API to the provider; the public Nominatim asks for it.
+	Email string `yaml:"email" env:"GEOCODING_EMAIL"`
+	// Provider is "nominatim" or empty.
+	Provider  string `yaml:"provider" env:"GEOCODING_PROVIDER"`
+	UserAgent string `yaml:"user_agent" env:"GEOCODING_USER_AGENT" env-default:"real-staging-api"`
+}
+
+type ImageProxy struct {
+	Cache string `yaml:"cache" env:"IMAGE_PROXY_CACHE" env-default:"memory"`
+	// CacheMaxBytes bounds the memory cache; the least recently served renditions are evicted.
+	CacheMaxBytes int64 `yaml:"cache_max_bytes" env:"IMAGE_PROXY_CACHE_MAX_BYTES" env-default:"268435456"`
+	// CacheTTL is how long Redis keeps a rendition and how long clients may reuse one.
+	CacheTTL     time.Duration `yaml:"cache_ttl" env:"IMAGE_PROXY_CACHE_TTL" env-default:"24h"`
+	MaxDimension int           `yaml:"max_dimension" env:"IMAGE_PROXY_MAX_DIMENSION" env-default:"2560"`
+	JPEGQuality  int           `yaml:"jpeg_quality" env:"IMAGE_PROXY_JPEG_QUALITY" env-default:"82"`
+}
+
+// InternalRoutes restricts routes that reveal operational detail, such as GET
+// /health/details, to callers presenting Token as a bearer token or connecting from
+// AllowedCIDRs. Paths are route templates; they are also left out of CORS.
+type InternalRoutes struct {
+	Paths        []string `yaml:"paths" env:"INTERNAL_ROUTES_PATHS" env-default:"/health/details,/share-domains/check"`
+	Token        string   `yaml:"token" env:"INTERNAL_ROUTES_TOKEN" secret:"true"`
+	AllowedCIDRs []string `yaml:"allowed_cidrs" env:"INTERNAL_ROUTES_ALLOWED_CIDRS" env-default:"127.0.0.1/32,::1/128"`
+	// TrustForwardedFor takes the client address from X-Forwarded-For when the request
+	// comes through a proxy on a private or loopback network. Enable it only behind a proxy
+	// that overwrites the header, or any caller can claim an allowed address.
+	TrustForwardedFor bool `yaml:"trust_forwarded_for" env:"INTERNAL_ROUTES_TRUST_FORWARDED_FOR"`
+}
+
+// Invitations configures project collaborator invitations. The emailed acceptance link is
+// AcceptURL with the invitation ID and an HMAC signature, made with Secret, over its expiry.
+type Invitations struct {
+	Secret string `yaml:"secret" env:"INVITATION_SECRET" secret:"true"`
+	// AcceptURL is the web app page that accepts an invitation for the signed-in user.
+	AcceptURL string        `yaml:"accept_url" env:"INVITATION_ACCEPT_URL" env-default:"http://localhost:3000/invitations/accept"`
+	TTL       time.Duration `yaml:"ttl" env:"INVITATION_TTL" env-default:"168h"`
+}
+
+// IPAllowlist configures enforcement of organizations' IP allow-lists.
+type IPAllowlist struct {
+	// BreakGlassDuration is how long a break-glass window lets an admin bypass the list.
+	// Zero turns break-glass off.
+	BreakGlassDuration time.Duration `yaml:"break_glass_duration" env:"IP_ALLOWLIST_BREAK_GLASS_DURATION" env-default:"1h"`
+	// TrustForwardedFor takes the client address from X-Forwarded-For when the request
+	// comes through a proxy on a private or loopback network. Enable it only behind a proxy
+	// that overwrites the header, or any caller can claim an allowed address.
+	TrustForwardedFor bool `yaml:"trust_forwarded_for" env:"IP_ALLOWLIST_TRUST_FORWARDED_FOR"`
 }
 
 type Job struct {
+	// BatchWindow groups images created together in one project into a single batch job,
+	// collecting them for this long. Zero enqueues every image as its own job.
+	BatchWindow time.Duration `yaml:"batch_window" env:"JOB_BATCH_WINDOW"`
+	// CriticalQueueName is the queue expedited images are moved to; workers drain it before QueueName.
+	CriticalQueueName string `yaml:"critical_queue_name" env:"JOB_CRITICAL_QUEUE_NAME" env-default:"critical"`
+	// EditQueueName is the queue quick edits go to; workers drain it before the others.
+	EditQueueName     string `yaml:"edit_queue_name" env:"JOB_EDIT_QUEUE_NAME" env-default:"edits"`
 	QueueName         string `yaml:"queue_name" env:"JOB_QUEUE_NAME" env-default:"default"`
 	WorkerConcurrency int    `yaml:"worker_concurrency" env:"WORKER_CONCURRENCY" env-default:"5"`
 }
@@ -56,6 +224,26 @@ type Logging struct {
 
 type OTEL struct {
 	ExporterOTLPEndpoint string `yaml:"exporter_otlp_endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	// MetricsInterval is how often the API exports metrics, such as SLO burn rates.
+	MetricsInterval time.Duration `yaml:"metrics_interval" env:"OTEL_METRICS_INTERVAL" env-default:"30s"`
+}
+
+// PayloadEncryption seals job payloads with AES-256-GCM so original URLs and user
+// identifiers are not readable in Redis. Keys may be injected from a KMS or secret store
+// through PAYLOAD_ENCRYPTION_KEYS.
+type PayloadEncryption struct {
+	// ActiveKey is the id of the key new payloads are sealed with. Empty enqueues plaintext.
+	ActiveKey string `yaml:"active_key" env:"PAYLOAD_ENCRYPTION_ACTIVE_KEY"`
+	// Keys maps key ids to base64-encoded 32-byte keys, e.g. "2026-10:<base64>". Keep
+	// retired keys listed until the payloads sealed with them have drained from the queue.
+	Keys map[string]string `yaml:"keys" env:"PAYLOAD_ENCRYPTION_KEYS" secret:"true"`
+}
+
+// Reconcile gates the admin storage reconciliation endpoint.
+type Reconcile struct {
+	Enabled bool `yaml:"enabled" env:"RECONCILE_ENABLED"`
+	// Concurrency is the default number of concurrent S3 checks per request.
+	Concurrency int `yaml:"concurrency" env:"RECONCILE_CONCURRENCY" env-default:"5"`
 }
 
 type Redis struct {
@@ -63,65 +251,351 @@ type Redis struct {
 }
 
 type S3 struct {
-	AccessKey      string `yaml:"access_key" env:"S3_ACCESS_KEY"`
+	AccessKey      string `yaml:"access_key" env:"S3_ACCESS_KEY" secret:"true"`
 	BucketName     string `yaml:"bucket_name" env:"S3_BUCKET_NAME" env-default:"real-staging"`
 	Endpoint       string `yaml:"endpoint" env:"S3_ENDPOINT"`
 	PublicEndpoint string `yaml:"public_endpoint" env:"S3_PUBLIC_ENDPOINT"`
 	Region         string `yaml:"region" env:"S3_REGION" env-default:"us-west-1"`
-	SecretKey      string `yaml:"secret_key" env:"S3_SECRET_KEY"`
-	UsePathStyle   bool   `yaml:"use_path_style" env:"S3_USE_PATH_STYLE"`
+	// RegionBuckets maps data-residency regions to the bucket holding the images of users
+	// placed there, e.g. "eu-central-1:real-staging-eu". They share the endpoint and
+	// credentials above; users without a region keep using BucketName.
+	RegionBuckets map[string]string `yaml:"region_buckets" env:"S3_REGION_BUCKETS"`
+	SecretKey     string            `yaml:"secret_key" env:"S3_SECRET_KEY" secret:"true"`
+	UsePathStyle  bool              `yaml:"use_path_style" env:"S3_USE_PATH_STYLE"`
+}
+
+// ForRegion returns the settings for region's residency bucket.
+func (s S3) ForRegion(region string) (S3, bool) {
+	bucket, ok := s.RegionBuckets[region]
+	if !ok {
+		return S3{}, false
+	}
+	s.Region = region
+	s.BucketName = bucket
+	s.RegionBuckets = nil
+	return s, true
+}
+
+// SandboxTenant lets accounts issue sandbox API keys for integration testing. Requests made
+// with one act on the sandbox database schema, store objects under the sandbox/ key prefix
+// and queue jobs on QueueName, which a worker configured for the sandbox tenant serves and
+// resets nightly.
+type SandboxTenant struct {
+	Enabled   bool   `yaml:"enabled" env:"SANDBOX_TENANT_ENABLED"`
+	QueueName string `yaml:"queue_name" env:"SANDBOX_TENANT_QUEUE_NAME" env-default:"sandbox"`
+}
+
+// ShareLinks configures signed image links that work without signing in. Each project signs
+// with a key derived from Secret and the project's key version, so bumping the version
+// revokes every link issued before.
+type ShareLinks struct {
+	Secret     string        `yaml:"secret" env:"SHARE_LINK_SECRET" secret:"true"`
+	BaseURL    string        `yaml:"base_url" env:"SHARE_LINK_BASE_URL" env-default:"http://localhost:8080"`
+	DefaultTTL time.Duration `yaml:"default_ttl" env:"SHARE_LINK_DEFAULT_TTL" env-default:"168h"`
+	MaxTTL     time.Duration `yaml:"max_ttl" env:"SHARE_LINK_MAX_TTL" env-default:"720h"`
+	// RedirectTTL is how long the storage URL a share link redirects to stays valid. It
+	// cannot be revoked, so keep it short.
+	RedirectTTL time.Duration `yaml:"redirect_ttl" env:"SHARE_LINK_REDIRECT_TTL" env-default:"60s"`
+	// CustomDomainTarget is the host accounts point their custom share domains at with a
+	// CNAME record, served by a proxy that issues certificates on demand. Empty disables
+	// custom domains.
+	CustomDomainTarget string `yaml:"custom_domain_target" env:"SHARE_LINK_CUSTOM_DOMAIN_TARGET"`
+}
+
+// SLO sets the service level objectives requests are measured against. The top-level
+// objective applies to every route; Routes overrides it for routes keyed by method and
+// path template, e.g. "POST /api/v1/uploads/presign".
+type SLO struct {
+	Enabled bool `yaml:"enabled" env:"SLO_ENABLED" env-default:"true"`
+	// Availability is the share of requests that must not fail with a 5xx.
+	Availability float64 `yaml:"availability" env:"SLO_AVAILABILITY" env-default:"0.995"`
+	// LatencyThreshold is how fast a request must be to count as fast.
+	LatencyThreshold time.Duration `yaml:"latency_threshold" env:"SLO_LATENCY_THRESHOLD" env-default:"1s"`
+	// LatencyTarget is the share of requests that must be faster than LatencyThreshold.
+	LatencyTarget float64                 `yaml:"latency_target" env:"SLO_LATENCY_TARGET" env-default:"0.99"`
+	Routes        map[string]SLOObjective `yaml:"routes"`
+}
+
+// SLOObjective overrides the default objective for one route. Zero fields inherit it.
+type SLOObjective struct {
+	Availability     float64       `yaml:"availability"`
+	LatencyThreshold time.Duration `yaml:"latency_threshold"`
+	LatencyTarget    float64       `yaml:"latency_target"`
+}
+
+// SMS configures outgoing text messages. Provider log, the default, logs messages instead
+// of sending them; twilio sends them through Twilio's Messages API.
+type SMS struct {
+	// From is the sending number in E.164 format, e.g. "+15017122661".
+	From     string `yaml:"from" env:"SMS_FROM"`
+	Provider string `yaml:"provider" env:"SMS_PROVIDER" env-default:"log"`
+	// TwilioAccountSID and TwilioAuthToken authenticate with Twilio.
+	TwilioAccountSID string `yaml:"twilio_account_sid" env:"TWILIO_ACCOUNT_SID"`
+	TwilioAuthToken  string `yaml:"twilio_auth_token" env:"TWILIO_AUTH_TOKEN" secret:"true"`
+	TwilioBaseURL    string `yaml:"twilio_base_url" env:"TWILIO_BASE_URL" env-default:"https://api.twilio.com"`
+}
+
+// SMTP configures outgoing email. Email is logged instead of sent when Host is empty,
+// unless Provider says otherwise.
+type SMTP struct {
+	From     string `yaml:"from" env:"SMTP_FROM"`
+	Host     string `yaml:"host" env:"SMTP_HOST"`
+	Password string `yaml:"password" env:"SMTP_PASSWORD" secret:"true"`
+	Port     int    `yaml:"port" env:"SMTP_PORT" env-default:"587"`
+	// Provider selects the email vendor: smtp or log. Empty uses smtp when Host is set
+	// and logs emails otherwise.
+	Provider string `yaml:"provider" env:"EMAIL_PROVIDER"`
+	Username string `yaml:"username" env:"SMTP_USERNAME"`
+}
+
+// SSO configures single sign-on for enterprise organizations. Auth0 federates each
+// organization's identity provider through an enterprise connection, and an Auth0 Action
+// copies the connection name and the provider's claims into access tokens under
+// ClaimNamespace, e.g. "https://realstaging.ai/connection".
+type SSO struct {
+	ClaimNamespace string `yaml:"claim_namespace" env:"SSO_CLAIM_NAMESPACE" env-default:"https://realstaging.ai/"`
+}
+
+// Stripe configures the billing webhook.
+type Stripe struct {
+	// WebhookSecret verifies the Stripe-Signature header. It is required outside dev-like
+	// environments; without it, dev-like environments accept unsigned webhooks.
+	WebhookSecret string `yaml:"webhook_secret" env:"STRIPE_WEBHOOK_SECRET" secret:"true"`
+	// WebhookTolerance is how far a signature timestamp may be from now.
+	WebhookTolerance time.Duration `yaml:"webhook_tolerance" env:"STRIPE_WEBHOOK_TOLERANCE" env-default:"5m"`
+	// SecretKey authenticates calls to the Stripe API. Empty disables them; webhooks still work.
+	SecretKey string `yaml:"secret_key" env:"STRIPE_SECRET_KEY" secret:"true"`
+	// ExportCreditPriceID is the one-time price of a watermark-free project export. Empty
+	// disables buying export credits.
+	ExportCreditPriceID string `yaml:"export_credit_price_id" env:"STRIPE_EXPORT_CREDIT_PRICE_ID"`
+}
+
+// Usage meters the images each user creates for staging per billing period, their
+// subscription's current period or the UTC calendar month without one, and, when Enforce is
+// set, refuses to create images past their plan's monthly limit.
+type Usage struct {
+	Enforce bool `yaml:"enforce" env:"USAGE_ENFORCE"`
+	// FreeMonthlyLimit is the monthly allowance of users without an active subscription.
+	FreeMonthlyLimit int `yaml:"free_monthly_limit" env:"USAGE_FREE_MONTHLY_LIMIT" env-default:"0"`
+}
+
+// WebhookArchive stores every verified inbound webhook payload in the S3 bucket, so an event
+// can be replayed through its handler after a handler bug is fixed.
+type WebhookArchive struct {
+	Enabled bool `yaml:"enabled" env:"WEBHOOK_ARCHIVE_ENABLED"`
+	// Prefix is the key prefix payloads are stored under, as <prefix>/<source>/<event id>.json.
+	Prefix string `yaml:"prefix" env:"WEBHOOK_ARCHIVE_PREFIX" env-default:"webhooks"`
+}
+
+// Config layers, lowest precedence first. Environment variables override all of them.
+const (
+	baseFile    = "shared.yml"  // Shared by every environment, in CONFIG_DIR
+	secretsFile = "secrets.yml" // Per-app secrets, in the working directory
+)
+
+// LayerFiles returns the YAML files Load reads for env, lowest precedence first: the shared
+// base and the env overlay in configDir, then the app's secrets.yml.
+func LayerFiles(configDir, env string) []string {
+	return []string{
+		filepath.Join(configDir, baseFile),
+		filepath.Join(configDir, env+".yml"),
+		secretsFile,
+	}
 }
 
-// Load loads configuration from YAML files based on APP_ENV.
-// It loads config/shared.yml first, then overlays config/{env}.yml,
-// then apps/api/secrets.yml (if present).
-// Environment variables take precedence over YAML values.
+// Load loads the configuration layers for APP_ENV (default "dev") from CONFIG_DIR
+// (default "config"), in the precedence order described by LayerFiles and LoadFrom.
 func Load() (*Config, error) {
-	cfg := &Config{}
+	return LoadFrom(DefaultLayerFiles()...)
+}
 
-	// Determine environment
+// DefaultLayerFiles returns the LayerFiles Load reads, for APP_ENV in CONFIG_DIR.
+func DefaultLayerFiles() []string {
 	env := os.Getenv("APP_ENV")
 	if env == "" {
 		env = "dev"
 	}
-
-	// Determine config directory (project root /config)
 	configDir := os.Getenv("CONFIG_DIR")
 	if configDir == "" {
-		// Default to config/ relative to project root
 		configDir = "config"
 	}
+	return LayerFiles(configDir, env)
+}
 
-	// Load shared config first
-	sharedPath := filepath.Join(configDir, "shared.yml")
-	if _, err := os.Stat(sharedPath); err == nil {
-		if err := cleanenv.ReadConfig(sharedPath, cfg); err != nil {
-			return nil, fmt.Errorf("failed to read shared config: %w", err)
+// LoadFrom reads files in order, each overriding the ones before it, then applies
+// environment variables, which take precedence over every file. Missing files are skipped.
+func LoadFrom(files ...string) (*Config, error) {
+	cfg := &Config{}
+	for _, path := range files {
+		if _, err := os.Stat(path); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+		}
+		if err := readFile(path, cfg); err != nil {
+			return nil, fmt.Errorf("failed to read config %s: %w", path, err)
 		}
+		cfg.sources = append(cfg.sources, path)
 	}
 
-	// Load environment-specific config
-	envPath := filepath.Join(configDir, fmt.Sprintf("%s.yml", env))
-	if _, err := os.Stat(envPath); err == nil {
-		if err := cleanenv.ReadConfig(envPath, cfg); err != nil {
-			return nil, fmt.Errorf("failed to read %s config: %w", env, err)
-		}
+	if err := cleanenv.ReadEnv(cfg); err != nil {
+		return nil, fmt.Errorf("failed to read environment variables: %w", err)
 	}
+	cfg.sources = append(cfg.sources, SourceEnv)
+	return cfg, nil
+}
 
-	// Load app-specific secrets (if present)
-	secretsPath := "secrets.yml"
-	if _, err := os.Stat(secretsPath); err == nil {
-		if err := cleanenv.ReadConfig(secretsPath, cfg); err != nil {
-			return nil, fmt.Errorf("failed to read secrets: %w", err)
-		}
+// placeholder matches ${NAME} and ${NAME:default} in config files.
+var placeholder = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::([^}]*))?\}`)
+
+// readFile parses a YAML layer into cfg after replacing each placeholder with the named
+// environment variable, or its default when the variable is unset or empty.
+func readFile(path string, cfg *Config) error {
+	// #nosec G304 -- Config layers come from CONFIG_DIR and the app directory
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
 	}
+	expanded := placeholder.ReplaceAllStringFunc(string(data), func(m string) string {
+		sub := placeholder.FindStringSubmatch(m)
+		if v := os.Getenv(sub[1]); v != "" {
+			return v
+		}
+		return sub[2]
+	})
+	return cleanenv.ParseYAML(strings.NewReader(expanded), cfg)
+}
 
-	// Read environment variables (these override YAML values)
-	if err := cleanenv.ReadEnv(cfg); err != nil {
-		return nil, fmt.Errorf("failed to read environment variables: %w", err)
+// FromEnv builds a Config from environment variables and defaults alone, without reading
+// any files. Tests and tools that run outside the repository use it.
+func FromEnv() (*Config, error) {
+	return LoadFrom()
+}
+
+// SourceEnv marks environment variables in Sources.
+const SourceEnv = "env"
+
+// Sources lists the layers cfg was loaded from, lowest precedence first. Files that did not
+// exist are left out; SourceEnv is always last.
+func (c *Config) Sources() []string {
+	return c.sources
+}
+
+// Validate reports settings the API cannot run safely without, so misconfiguration fails at
+// startup rather than on the first request. Dev-like environments only need what local
+// development cannot default.
+func (c *Config) Validate() error {
+	var errs []error
+	if c.Stripe.WebhookTolerance <= 0 {
+		errs = append(errs, errors.New("stripe.webhook_tolerance must be positive"))
+	}
+	if c.ShareLinks.Secret != "" && len(c.ShareLinks.Secret) < 32 {
+		errs = append(errs, errors.New("share_links.secret must be at least 32 characters"))
+	}
+	if c.Invitations.Secret != "" && len(c.Invitations.Secret) < 32 {
+		errs = append(errs, errors.New("invitations.secret must be at least 32 characters"))
+	}
+	if c.InternalRoutes.Token != "" && len(c.InternalRoutes.Token) < 32 {
+		errs = append(errs, errors.New("internal_routes.token must be at least 32 characters"))
+	}
+	for _, cidr := range c.InternalRoutes.AllowedCIDRs {
+		if _, _, err := net.ParseCIDR(strings.TrimSpace(cidr)); err != nil {
+			errs = append(errs, fmt.Errorf("internal_routes.allowed_cidrs entry %q is not a CIDR", cidr))
+		}
 	}
+	if c.IPAllowlist.BreakGlassDuration < 0 || c.IPAllowlist.BreakGlassDuration > 24*time.Hour {
+		errs = append(errs, errors.New("ip_allowlist.break_glass_duration must be between 0 and 24h"))
+	}
+	if c.CustomerBuckets.Enabled && c.CustomerBuckets.SessionDuration < 15*time.Minute {
+		errs = append(errs, errors.New("customer_buckets.session_duration must be at least 15m"))
+	}
+	for region, bucket := range c.S3.RegionBuckets {
+		if region == "" || bucket == "" {
+			errs = append(errs, fmt.Errorf("s3.region_buckets entry %q:%q needs a region and a bucket", region, bucket))
+		} else if bucket == c.S3.BucketName {
+			errs = append(errs, fmt.Errorf("s3.region_buckets %s must not reuse s3.bucket_name", region))
+		}
+	}
+	if c.SLO.Enabled {
+		errs = append(errs, c.SLO.validate()...)
+	}
+	if c.Usage.FreeMonthlyLimit < 0 {
+		errs = append(errs, errors.New("usage.free_monthly_limit must not be negative"))
+	}
+	if c.SandboxTenant.Enabled {
+		switch c.SandboxTenant.QueueName {
+		case "", c.Job.QueueName, c.Job.CriticalQueueName, c.Job.EditQueueName:
+			errs = append(errs, errors.New("sandbox_tenant.queue_name must be set and differ from the job queues"))
+		}
+	}
+	switch c.Geocoding.Provider {
+	case "":
+	case "nominatim":
+		if c.Geocoding.BaseURL == "" {
+			errs = append(errs, errors.New("geocoding.base_url is required for the nominatim provider"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("geocoding.provider must be nominatim or empty, got %q", c.Geocoding.Provider))
+	}
+	switch c.ImageProxy.Cache {
+	case "", "memory", "none":
+	case "redis":
+		if c.Redis.Addr == "" {
+			errs = append(errs, errors.New("image_proxy.cache redis requires redis.addr (REDIS_ADDR)"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("image_proxy.cache must be memory, redis or none, got %q", c.ImageProxy.Cache))
+	}
+	if !c.App.IsDevLike() {
+		if c.Auth0.Domain == "" {
+			errs = append(errs, errors.New("auth0.domain (AUTH0_DOMAIN) is required"))
+		}
+		if c.Auth0.Audience == "" {
+			errs = append(errs, errors.New("auth0.audience (AUTH0_AUDIENCE) is required"))
+		}
+		if c.Stripe.WebhookSecret == "" {
+			errs = append(errs, errors.New("stripe.webhook_secret (STRIPE_WEBHOOK_SECRET) is required"))
+		}
+		if c.ShareLinks.Secret == "" {
+			errs = append(errs, errors.New("share_links.secret (SHARE_LINK_SECRET) is required"))
+		}
+		if c.Invitations.Secret == "" {
+			errs = append(errs, errors.New("invitations.secret (INVITATION_SECRET) is required"))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid %s configuration: %w", c.App.Env, err)
+	}
+	return nil
+}
 
-	return cfg, nil
+func (s SLO) validate() []error {
+	var errs []error
+	check := func(name string, o SLOObjective) {
+		if o.Availability != 0 && (o.Availability <= 0 || o.Availability >= 1) {
+			errs = append(errs, fmt.Errorf("%s.availability must be between 0 and 1", name))
+		}
+		if o.LatencyTarget != 0 && (o.LatencyTarget <= 0 || o.LatencyTarget >= 1) {
+			errs = append(errs, fmt.Errorf("%s.latency_target must be between 0 and 1", name))
+		}
+		if o.LatencyThreshold < 0 {
+			errs = append(errs, fmt.Errorf("%s.latency_threshold must not be negative", name))
+		}
+	}
+	if s.Availability == 0 || s.LatencyTarget == 0 || s.LatencyThreshold == 0 {
+		errs = append(errs, errors.New("slo.availability, slo.latency_threshold and slo.latency_target are required"))
+	}
+	check("slo", SLOObjective{s.Availability, s.LatencyThreshold, s.LatencyTarget})
+	for route, o := range s.Routes {
+		method, path, ok := strings.Cut(route, " ")
+		if !ok || method == "" || !strings.HasPrefix(path, "/") {
+			errs = append(errs, fmt.Errorf("slo.routes key %q must be a method and path, e.g. \"GET /api/v1/projects\"", route))
+			continue
+		}
+		check(fmt.Sprintf("slo.routes[%s]", route), o)
+	}
+	return errs
 }
 
 // DatabaseURL constructs and returns the full PostgreSQL connection URL.
@@ -1,7 +1,14 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDatabaseURL(t *testing.T) {
@@ -78,3 +85,313 @@ func TestDatabaseURL(t *testing.T) {
 		})
 	}
 }
+
+func writeLayer(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadFrom_Precedence(t *testing.T) {
+	dir := t.TempDir()
+	base := writeLayer(t, dir, "shared.yml", `
+job:
+  queue_name: base-queue
+  critical_queue_name: base-critical
+redis:
+  addr: base:6379
+s3:
+  bucket_name: base-bucket
+`)
+	overlay := writeLayer(t, dir, "staging.yml", `
+job:
+  queue_name: staging-queue
+redis:
+  addr: staging:6379
+`)
+	t.Setenv("REDIS_ADDR", "env:6379")
+
+	cfg, err := LoadFrom(base, overlay, filepath.Join(dir, "missing.yml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "env:6379", cfg.Redis.Addr, "environment variables override every file")
+	assert.Equal(t, "staging-queue", cfg.Job.QueueName, "the overlay overrides the base")
+	assert.Equal(t, "base-critical", cfg.Job.CriticalQueueName, "unset overlay keys keep the base value")
+	assert.Equal(t, "base-bucket", cfg.S3.BucketName)
+	assert.Equal(t, 1024, cfg.Compression.MinBytes, "defaults fill keys no layer sets")
+	assert.Equal(t, []string{base, overlay, SourceEnv}, cfg.Sources())
+}
+
+func TestLoadFrom_InvalidFile(t *testing.T) {
+	path := writeLayer(t, t.TempDir(), "shared.yml", "job: [not, a, map]\n")
+	_, err := LoadFrom(path)
+	assert.ErrorContains(t, err, "failed to read config "+path)
+}
+
+func TestLayerFiles(t *testing.T) {
+	assert.Equal(t, []string{
+		filepath.Join("config", "shared.yml"),
+		filepath.Join("config", "prod.yml"),
+		"secrets.yml",
+	}, LayerFiles("config", "prod"))
+}
+
+func TestLoad_UsesAppEnvOverlay(t *testing.T) {
+	dir := t.TempDir()
+	writeLayer(t, dir, "shared.yml", "job:\n  queue_name: base-queue\n")
+	writeLayer(t, dir, "qa.yml", "job:\n  queue_name: qa-queue\n")
+	t.Setenv("CONFIG_DIR", dir)
+	t.Setenv("APP_ENV", "qa")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "qa-queue", cfg.Job.QueueName)
+	assert.Equal(t, "qa", cfg.App.Env)
+}
+
+func TestApp_IsDevLike(t *testing.T) {
+	for _, env := range []string{"", "dev", "local", "test", "Development"} {
+		assert.True(t, App{Env: env}.IsDevLike(), env)
+	}
+	for _, env := range []string{"prod", "staging"} {
+		assert.False(t, App{Env: env}.IsDevLike(), env)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	valid := func() *Config {
+		return &Config{
+			App:         App{Env: "prod"},
+			Auth0:       Auth0{Domain: "tenant.us.auth0.com", Audience: "https://api.example.com"},
+			Stripe:      Stripe{WebhookSecret: "whsec_1", WebhookTolerance: 5 * time.Minute},
+			ShareLinks:  ShareLinks{Secret: strings.Repeat("s", 32)},
+			Invitations: Invitations{Secret: strings.Repeat("i", 32)},
+		}
+	}
+
+	testCases := []struct {
+		name    string
+		mutate  func(c *Config)
+		wantErr []string
+	}{
+		{name: "success: complete prod config", mutate: func(c *Config) {}},
+		{
+			name: "success: dev may omit secrets",
+			mutate: func(c *Config) {
+				c.App.Env = "dev"
+				c.Auth0 = Auth0{}
+				c.Stripe.WebhookSecret = ""
+			},
+		},
+		{
+			name: "fail: prod reports every missing setting",
+			mutate: func(c *Config) {
+				c.Auth0 = Auth0{}
+				c.Stripe.WebhookSecret = ""
+				c.ShareLinks.Secret = ""
+				c.Invitations.Secret = ""
+			},
+			wantErr: []string{
+				"invalid prod configuration",
+				"AUTH0_DOMAIN", "AUTH0_AUDIENCE", "STRIPE_WEBHOOK_SECRET", "SHARE_LINK_SECRET", "INVITATION_SECRET",
+			},
+		},
+		{
+			name: "fail: non-positive webhook tolerance",
+			mutate: func(c *Config) {
+				c.App.Env = "dev"
+				c.Stripe.WebhookTolerance = 0
+			},
+			wantErr: []string{"webhook_tolerance"},
+		},
+		{
+			name:    "fail: short share link secret",
+			mutate:  func(c *Config) { c.ShareLinks.Secret = "short" },
+			wantErr: []string{"at least 32 characters"},
+		},
+		{
+			name:    "fail: short invitation secret",
+			mutate:  func(c *Config) { c.Invitations.Secret = "short" },
+			wantErr: []string{"invitations.secret must be at least 32 characters"},
+		},
+		{
+			name:    "fail: break-glass window too long",
+			mutate:  func(c *Config) { c.IPAllowlist.BreakGlassDuration = 48 * time.Hour },
+			wantErr: []string{"ip_allowlist.break_glass_duration"},
+		},
+		{
+			name: "fail: customer bucket session shorter than STS allows",
+			mutate: func(c *Config) {
+				c.CustomerBuckets = CustomerBuckets{Enabled: true, SessionDuration: 5 * time.Minute}
+			},
+			wantErr: []string{"customer_buckets.session_duration must be at least 15m"},
+		},
+		{
+			name: "success: region buckets",
+			mutate: func(c *Config) {
+				c.S3 = S3{BucketName: "real-staging", RegionBuckets: map[string]string{"eu-central-1": "real-staging-eu"}}
+			},
+		},
+		{
+			name: "fail: region bucket reuses the home bucket",
+			mutate: func(c *Config) {
+				c.S3 = S3{BucketName: "real-staging", RegionBuckets: map[string]string{"eu-central-1": "real-staging"}}
+			},
+			wantErr: []string{"s3.region_buckets eu-central-1 must not reuse s3.bucket_name"},
+		},
+		{
+			name: "success: sandbox tenant",
+			mutate: func(c *Config) {
+				c.Job = Job{QueueName: "default", CriticalQueueName: "critical", EditQueueName: "edits"}
+				c.SandboxTenant = SandboxTenant{Enabled: true, QueueName: "sandbox"}
+			},
+		},
+		{
+			name: "fail: sandbox tenant shares a job queue",
+			mutate: func(c *Config) {
+				c.Job = Job{QueueName: "default", CriticalQueueName: "critical", EditQueueName: "edits"}
+				c.SandboxTenant = SandboxTenant{Enabled: true, QueueName: "default"}
+			},
+			wantErr: []string{"sandbox_tenant.queue_name must be set and differ from the job queues"},
+		},
+		{
+			name:    "fail: negative free usage allowance",
+			mutate:  func(c *Config) { c.Usage = Usage{Enforce: true, FreeMonthlyLimit: -1} },
+			wantErr: []string{"usage.free_monthly_limit must not be negative"},
+		},
+		{
+			name: "success: route objective overrides",
+			mutate: func(c *Config) {
+				c.SLO = SLO{
+					Enabled: true, Availability: 0.995, LatencyThreshold: time.Second, LatencyTarget: 0.99,
+					Routes: map[string]SLOObjective{"POST /api/v1/images": {LatencyThreshold: 3 * time.Second}},
+				}
+			},
+		},
+		{
+			name: "fail: invalid objectives",
+			mutate: func(c *Config) {
+				c.SLO = SLO{
+					Enabled: true, Availability: 1, LatencyThreshold: time.Second, LatencyTarget: 0.99,
+					Routes: map[string]SLOObjective{
+						"/api/v1/images":     {},
+						"GET /api/v1/images": {LatencyTarget: 99},
+					},
+				}
+			},
+			wantErr: []string{
+				"slo.availability must be between 0 and 1",
+				`slo.routes key "/api/v1/images" must be a method and path`,
+				"slo.routes[GET /api/v1/images].latency_target must be between 0 and 1",
+			},
+		},
+		{
+			name:    "fail: unknown image proxy cache",
+			mutate:  func(c *Config) { c.ImageProxy.Cache = "disk" },
+			wantErr: []string{`image_proxy.cache must be memory, redis or none, got "disk"`},
+		},
+		{
+			name:    "fail: redis image proxy cache without redis",
+			mutate:  func(c *Config) { c.ImageProxy.Cache = "redis" },
+			wantErr: []string{"REDIS_ADDR"},
+		},
+		{
+			name: "success: nominatim geocoding",
+			mutate: func(c *Config) {
+				c.Geocoding = Geocoding{Provider: "nominatim", BaseURL: "https://nominatim.example.com"}
+			},
+		},
+		{
+			name:    "fail: unknown geocoding provider",
+			mutate:  func(c *Config) { c.Geocoding.Provider = "google" },
+			wantErr: []string{`geocoding.provider must be nominatim or empty, got "google"`},
+		},
+		{
+			name: "success: internal routes with a token and networks",
+			mutate: func(c *Config) {
+				c.InternalRoutes = InternalRoutes{
+					Token: strings.Repeat("t", 32), AllowedCIDRs: []string{"10.0.0.0/8", "::1/128"},
+				}
+			},
+		},
+		{
+			name: "fail: short internal routes token and bad CIDR",
+			mutate: func(c *Config) {
+				c.InternalRoutes = InternalRoutes{Token: "short", AllowedCIDRs: []string{"10.0.0.1"}}
+			},
+			wantErr: []string{
+				"internal_routes.token must be at least 32 characters",
+				`internal_routes.allowed_cidrs entry "10.0.0.1" is not a CIDR`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := valid()
+			tc.mutate(cfg)
+			err := cfg.Validate()
+			if len(tc.wantErr) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			for _, want := range tc.wantErr {
+				assert.ErrorContains(t, err, want)
+			}
+		})
+	}
+}
+
+func TestS3_ForRegion(t *testing.T) {
+	s := S3{
+		BucketName:    "real-staging",
+		Endpoint:      "http://minio:9000",
+		Region:        "us-west-1",
+		RegionBuckets: map[string]string{"eu-central-1": "real-staging-eu"},
+	}
+
+	eu, ok := s.ForRegion("eu-central-1")
+	require.True(t, ok)
+	assert.Equal(t, "real-staging-eu", eu.BucketName)
+	assert.Equal(t, "eu-central-1", eu.Region)
+	assert.Equal(t, "http://minio:9000", eu.Endpoint)
+	assert.Nil(t, eu.RegionBuckets)
+	assert.Equal(t, "real-staging", s.BucketName)
+
+	_, ok = s.ForRegion("ap-southeast-2")
+	assert.False(t, ok)
+}
+
+func TestLoadFrom_ExpandsPlaceholders(t *testing.T) {
+	path := writeLayer(t, t.TempDir(), "prod.yml", `
+auth0:
+  domain: ${TEST_AUTH0_DOMAIN}
+  audience: ${TEST_AUTH0_AUDIENCE:https://default.example.com}
+db:
+  pgport: ${TEST_PGPORT:6543}
+  pgpassword: pa$$word
+`)
+	t.Setenv("TEST_AUTH0_DOMAIN", "tenant.us.auth0.com")
+
+	cfg, err := LoadFrom(path)
+	require.NoError(t, err)
+	assert.Equal(t, "tenant.us.auth0.com", cfg.Auth0.Domain)
+	assert.Equal(t, "https://default.example.com", cfg.Auth0.Audience, "unset variables use the default")
+	assert.Equal(t, 6543, cfg.DB.Port)
+	assert.Equal(t, "pa$$word", cfg.DB.Password, "only ${...} placeholders are expanded")
+}
+
+func TestLoadFrom_RepoProdOverlay(t *testing.T) {
+	dir := filepath.Join("..", "..", "..", "..", "config")
+	t.Setenv("AUTH0_DOMAIN", "tenant.us.auth0.com")
+	t.Setenv("PGPORT", "")
+	require.NoError(t, os.Unsetenv("PGPORT"))
+
+	cfg, err := LoadFrom(LayerFiles(dir, "prod")[:2]...)
+	require.NoError(t, err)
+	assert.Equal(t, "prod", cfg.App.Env)
+	assert.Equal(t, "tenant.us.auth0.com", cfg.Auth0.Domain)
+	assert.Equal(t, 5432, cfg.DB.Port)
+	assert.ErrorContains(t, cfg.Validate(), "STRIPE_WEBHOOK_SECRET")
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Dump(t *testing.T) {
+	cfg := &Config{
+		DB:                DB{Host: "db", Password: "hunter2", URL: ""},
+		PayloadEncryption: PayloadEncryption{ActiveKey: "k2", Keys: map[string]string{"k2": "c2VjcmV0", "k1": "b2xk"}},
+		S3:                S3{SecretKey: "s3-secret", BucketName: "bucket"},
+		sources:           []string{"config/shared.yml", SourceEnv},
+	}
+
+	t.Run("success: redacted hides secrets but keeps key ids", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, cfg.Dump(&buf, true))
+		out := buf.String()
+
+		assert.Contains(t, out, "# Layers (lowest precedence first): config/shared.yml, env\n")
+		assert.NotContains(t, out, "hunter2")
+		assert.NotContains(t, out, "s3-secret")
+		assert.NotContains(t, out, "c2VjcmV0")
+		assert.Contains(t, out, "pgpassword: '[REDACTED]'")
+		assert.Contains(t, out, "    k1: '[REDACTED]'\n    k2: '[REDACTED]'")
+		assert.Contains(t, out, "active_key: k2")
+		assert.Contains(t, out, "bucket_name: bucket")
+		assert.Contains(t, out, `url: ""`, "empty secrets are shown as empty")
+	})
+
+	t.Run("success: unredacted shows values", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, cfg.Dump(&buf, false))
+		assert.Contains(t, buf.String(), "pgpassword: hunter2")
+	})
+}
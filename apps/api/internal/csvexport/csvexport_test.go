@@ -0,0 +1,109 @@
+package csvexport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newContext(target string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
+
+func TestRequested(t *testing.T) {
+	testCases := []struct {
+		name   string
+		target string
+		want   bool
+	}{
+		{name: "success: csv", target: "/?format=csv", want: true},
+		{name: "success: case insensitive", target: "/?format=CSV", want: true},
+		{name: "success: json", target: "/?format=json", want: false},
+		{name: "success: absent", target: "/", want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, _ := newContext(tc.target)
+			assert.Equal(t, tc.want, Requested(c))
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	c, rec := newContext("/?format=csv")
+
+	err := Write(c, "report.csv", []string{"name", "note"}, [][]string{
+		{"alice", "hello, world"},
+		{"bob", "=HYPERLINK(\"http://evil\")"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, `attachment; filename="report.csv"`, rec.Header().Get(echo.HeaderContentDisposition))
+	assert.Equal(t, "name,note\nalice,\"hello, world\"\nbob,\"'=HYPERLINK(\"\"http://evil\"\")\"\n", rec.Body.String())
+}
+
+func TestStream(t *testing.T) {
+	t.Run("success: pages until a short chunk", func(t *testing.T) {
+		c, rec := newContext("/")
+		total := ChunkSize + 5
+		var offsets []int
+
+		err := Stream(c, "rows.csv", []string{"n"}, func(ctx context.Context, limit, offset int) ([][]string, error) {
+			offsets = append(offsets, offset)
+			var rows [][]string
+			for i := offset; i < total && i < offset+limit; i++ {
+				rows = append(rows, []string{fmt.Sprint(i)})
+			}
+			return rows, nil
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []int{0, ChunkSize}, offsets)
+		lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+		assert.Len(t, lines, total+1)
+		assert.Equal(t, fmt.Sprint(total-1), lines[len(lines)-1])
+	})
+
+	t.Run("fail: first fetch error is returned before writing", func(t *testing.T) {
+		c, rec := newContext("/")
+
+		err := Stream(c, "rows.csv", []string{"n"}, func(ctx context.Context, limit, offset int) ([][]string, error) {
+			return nil, errors.New("db error")
+		})
+		assert.EqualError(t, err, "db error")
+		assert.False(t, c.Response().Committed)
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("fail: later fetch error truncates the download", func(t *testing.T) {
+		c, rec := newContext("/")
+
+		err := Stream(c, "rows.csv", []string{"n"}, func(ctx context.Context, limit, offset int) ([][]string, error) {
+			if offset > 0 {
+				return nil, errors.New("db error")
+			}
+			rows := make([][]string, limit)
+			for i := range rows {
+				rows[i] = []string{"x"}
+			}
+			return rows, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Len(t, strings.Split(strings.TrimSpace(rec.Body.String()), "\n"), ChunkSize+1)
+	})
+}
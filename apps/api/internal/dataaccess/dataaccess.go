@@ -0,0 +1,71 @@
+// Package dataaccess records support's reads of customer data through the admin API and
+// lists them to the customer. A read must name the support ticket it is made for and is
+// recorded before any data is returned, so there is no unaudited path to a customer's
+// project. The reader is the admin behind the request's token, never a user named in the
+// request, so an entry cannot be attributed to someone else.
+package dataaccess
+
+import (
+	"errors"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/image"
+	"github.com/real-staging-ai/api/internal/project"
+)
+
+// Resource types an entry can name.
+const (
+	ResourceProject = "project"
+)
+
+const (
+	// MaxTicketLength matches the data_access_log.ticket check.
+	MaxTicketLength = 200
+	// DefaultLimit and MaxLimit bound a page of the customer's log.
+	DefaultLimit int32 = 50
+	MaxLimit     int32 = 200
+)
+
+var (
+	// ErrNotFound is returned when the resource does not exist.
+	ErrNotFound = errors.New("resource not found")
+	// ErrInvalid is returned for a missing or malformed ticket.
+	ErrInvalid = errors.New("invalid request")
+)
+
+// Entry is one read of a customer's data.
+type Entry struct {
+	ID           int64  `json:"id"`
+	ResourceType string `json:"resource_type"`
+	ResourceID   string `json:"resource_id"`
+	// AccessorID is the user ID of the admin who read the data.
+	AccessorID string `json:"accessor_id"`
+	// Ticket is the support ticket the read was made for.
+	Ticket    string    `json:"ticket"`
+	IP        string    `json:"ip"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Access is a read about to be made by an admin.
+type Access struct {
+	// AccessorID is the user ID of the authenticated admin.
+	AccessorID string
+	Ticket     string
+	IP         string
+}
+
+// ProjectView is a customer's project as support sees it, with the entry its read was
+// recorded under.
+type ProjectView struct {
+	Project project.Project `json:"project"`
+	Images  []*image.Image  `json:"images"`
+	Access  Entry           `json:"access"`
+}
+
+// ListResponse is the paginated response envelope for the customer's log.
+type ListResponse struct {
+	Entries []Entry `json:"entries"`
+	Limit   int32   `json:"limit"`
+	Offset  int32   `json:"offset"`
+	HasMore bool    `json:"has_more"`
+}
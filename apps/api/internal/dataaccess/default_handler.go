@@ -0,0 +1,118 @@
+package dataaccess
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// DefaultHandler serves the support project view and the customer's data access log over HTTP.
+type DefaultHandler struct {
+	service   Service
+	userRepo  user.Repository
+	extractIP echo.IPExtractor
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler. extractIP reads the address recorded
+// with each read.
+func NewDefaultHandler(service Service, userRepo user.Repository, extractIP echo.IPExtractor) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo, extractIP: extractIP}
+}
+
+// ErrorResponse is a simple JSON error envelope for handler responses.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// ViewProject handles GET /api/v1/admin/projects/:id?ticket=.
+func (h *DefaultHandler) ViewProject(c echo.Context) error {
+	projectID := c.Param("id")
+	if _, err := uuid.Parse(projectID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid project ID format"})
+	}
+	accessorID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	view, err := h.service.ViewProject(c.Request().Context(), projectID, Access{
+		AccessorID: accessorID,
+		Ticket:     c.QueryParam("ticket"),
+		IP:         h.extractIP(c.Request()),
+	})
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, view)
+}
+
+// List handles GET /api/v1/me/data-access-log.
+func (h *DefaultHandler) List(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+	limit, offset := parseLimitOffset(c)
+
+	// Fetch one extra row to know whether another page exists.
+	entries, err := h.service.ListForOwner(c.Request().Context(), userID, limit+1, offset)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	hasMore := len(entries) > int(limit)
+	if hasMore {
+		entries = entries[:limit]
+	}
+	return c.JSON(http.StatusOK, ListResponse{Entries: entries, Limit: limit, Offset: offset, HasMore: hasMore})
+}
+
+func unauthorized(c echo.Context) error {
+	return c.JSON(http.StatusUnauthorized, ErrorResponse{
+		Error:   "unauthorized",
+		Message: "Unable to resolve current user",
+	})
+}
+
+// writeError maps service errors to responses.
+func (h *DefaultHandler) writeError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Project not found"})
+	case errors.Is(err, ErrInvalid):
+		return c.JSON(http.StatusUnprocessableEntity, ErrorResponse{Error: "validation_failed", Message: err.Error()})
+	default:
+		c.Logger().Errorf("Data access request failed: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to process data access request",
+		})
+	}
+}
+
+func parseLimitOffset(c echo.Context) (int32, int32) {
+	limit := DefaultLimit
+	offset := int32(0)
+
+	if v := c.QueryParam("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 && n <= int(MaxLimit) {
+			// #nosec G109,G115 -- Value is validated to be positive and within MaxLimit
+			limit = int32(n)
+		}
+	}
+	if v := c.QueryParam("offset"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 && n <= 2147483647 {
+			// #nosec G109,G115 -- Value is validated to fit in int32 range
+			offset = int32(n)
+		}
+	}
+	return limit, offset
+}
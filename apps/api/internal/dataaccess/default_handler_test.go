@@ -0,0 +1,120 @@
+package dataaccess
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func userRepoFor(userID uuid.UUID) *user.RepositoryMock {
+	return &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgUUID(userID)}, nil
+		},
+	}
+}
+
+func newContext(target string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set("X-Test-User", "auth0|admin")
+	req.RemoteAddr = "203.0.113.9:51234"
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
+
+func TestDefaultHandler_ViewProject(t *testing.T) {
+	adminID := uuid.New()
+	projectID := uuid.NewString()
+
+	testCases := []struct {
+		name           string
+		projectID      string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: project returned", projectID: projectID, expectedStatus: http.StatusOK},
+		{name: "fail: invalid project ID", projectID: "nope", expectedStatus: http.StatusBadRequest},
+		{name: "fail: ticket missing", projectID: projectID, err: ErrInvalid, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "fail: project not found", projectID: projectID, err: ErrNotFound, expectedStatus: http.StatusNotFound},
+		{name: "fail: read not recorded", projectID: projectID, err: errors.New("db down"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				ViewProjectFunc: func(ctx context.Context, id string, access Access) (*ProjectView, error) {
+					assert.Equal(t, projectID, id)
+					assert.Equal(t, adminID.String(), access.AccessorID)
+					assert.Equal(t, "SUP-1042", access.Ticket)
+					assert.Equal(t, "203.0.113.9", access.IP)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &ProjectView{Access: Entry{ID: 7}}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, userRepoFor(adminID), echo.ExtractIPDirect())
+			c, rec := newContext("/api/v1/admin/projects/" + tc.projectID + "?ticket=SUP-1042")
+			c.SetParamNames("id")
+			c.SetParamValues(tc.projectID)
+
+			require.NoError(t, h.ViewProject(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusOK {
+				var view ProjectView
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &view))
+				assert.Equal(t, int64(7), view.Access.ID)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_List(t *testing.T) {
+	ownerID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		query         string
+		rows          int
+		expectedLimit int32
+		expectedMore  bool
+	}{
+		{name: "success: defaults", rows: 2, expectedLimit: DefaultLimit},
+		{name: "success: has more", query: "?limit=2&offset=4", rows: 3, expectedLimit: 2, expectedMore: true},
+		{name: "success: limit out of range", query: "?limit=1000", expectedLimit: DefaultLimit},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				ListForOwnerFunc: func(ctx context.Context, id string, limit, offset int32) ([]Entry, error) {
+					assert.Equal(t, ownerID.String(), id)
+					assert.Equal(t, tc.expectedLimit+1, limit)
+					return make([]Entry, tc.rows), nil
+				},
+			}
+			h := NewDefaultHandler(svc, userRepoFor(ownerID), echo.ExtractIPDirect())
+			c, rec := newContext("/api/v1/me/data-access-log" + tc.query)
+
+			require.NoError(t, h.List(c))
+			require.Equal(t, http.StatusOK, rec.Code)
+			var res ListResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+			assert.Equal(t, tc.expectedLimit, res.Limit)
+			assert.Equal(t, tc.expectedMore, res.HasMore)
+			assert.LessOrEqual(t, len(res.Entries), int(tc.expectedLimit))
+		})
+	}
+}
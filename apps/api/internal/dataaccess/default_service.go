@@ -0,0 +1,124 @@
+package dataaccess
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/image"
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// DefaultService implements Service.
+type DefaultService struct {
+	querier queries.Querier
+	images  image.Service
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService with a database.
+func NewDefaultService(db storage.Database, images image.Service) *DefaultService {
+	return &DefaultService{querier: queries.New(db), images: images}
+}
+
+// NewDefaultServiceWithQuerier creates a new DefaultService with a custom querier (for testing).
+func NewDefaultServiceWithQuerier(querier queries.Querier, images image.Service) *DefaultService {
+	return &DefaultService{querier: querier, images: images}
+}
+
+// ViewProject records the read of the project under its owner, then loads it.
+func (s *DefaultService) ViewProject(ctx context.Context, projectID string, access Access) (*ProjectView, error) {
+	ticket := strings.TrimSpace(access.Ticket)
+	if ticket == "" {
+		return nil, fmt.Errorf("%w: ticket is required", ErrInvalid)
+	}
+	if len(ticket) > MaxTicketLength {
+		return nil, fmt.Errorf("%w: ticket must be at most %d characters", ErrInvalid, MaxTicketLength)
+	}
+	accessorID, err := uuid.Parse(access.AccessorID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid accessor ID: %w", err)
+	}
+	id, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	row, err := s.querier.GetProjectByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	entry, err := s.querier.RecordDataAccess(ctx, queries.RecordDataAccessParams{
+		OwnerID:      row.UserID,
+		AccessorID:   pgtype.UUID{Bytes: accessorID, Valid: true},
+		ResourceType: ResourceProject,
+		ResourceID:   row.ID,
+		Ticket:       ticket,
+		Ip:           access.IP,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record data access: %w", err)
+	}
+
+	images, err := s.images.GetImagesByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project images: %w", err)
+	}
+
+	return &ProjectView{
+		Project: project.Project{
+			ID:        row.ID.String(),
+			Name:      row.Name,
+			UserID:    row.UserID.String(),
+			CreatedAt: row.CreatedAt.Time,
+		},
+		Images: images,
+		Access: toEntry(entry),
+	}, nil
+}
+
+// ListForOwner returns a page of reads of the owner's data.
+func (s *DefaultService) ListForOwner(ctx context.Context, ownerID string, limit, offset int32) ([]Entry, error) {
+	id, err := uuid.Parse(ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid owner ID: %w", err)
+	}
+	rows, err := s.querier.ListDataAccessByOwner(ctx, queries.ListDataAccessByOwnerParams{
+		OwnerID: pgtype.UUID{Bytes: id, Valid: true},
+		Limit:   limit,
+		Offset:  offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list data access: %w", err)
+	}
+	out := make([]Entry, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, toEntry(r))
+	}
+	return out, nil
+}
+
+func toEntry(r *queries.DataAccessLog) Entry {
+	return Entry{
+		ID:           r.ID,
+		ResourceType: r.ResourceType,
+		ResourceID:   r.ResourceID.String(),
+		AccessorID:   r.AccessorID.String(),
+		Ticket:       r.Ticket,
+		IP:           r.Ip,
+		CreatedAt:    r.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,120 @@
+package dataaccess
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/image"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+func pgUUID(id uuid.UUID) pgtype.UUID {
+	return pgtype.UUID{Bytes: id, Valid: true}
+}
+
+func TestDefaultService_ViewProject(t *testing.T) {
+	projectID := uuid.New()
+	ownerID := uuid.New()
+	adminID := uuid.New()
+
+	testCases := []struct {
+		name        string
+		projectErr  error
+		recordErr   error
+		ticket      string
+		wantErr     error
+		wantRecords int
+		wantImages  bool
+	}{
+		{name: "success: read recorded before images load", ticket: "  SUP-1042 ", wantRecords: 1, wantImages: true},
+		{name: "fail: ticket missing", ticket: " ", wantErr: ErrInvalid},
+		{name: "fail: ticket too long", ticket: string(make([]byte, MaxTicketLength+1)), wantErr: ErrInvalid},
+		{name: "fail: project not found", ticket: "SUP-1", projectErr: pgx.ErrNoRows, wantErr: ErrNotFound},
+		{name: "fail: nothing returned when the read cannot be recorded", ticket: "SUP-1042", recordErr: errors.New("db down"), wantRecords: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var loadedImages bool
+			q := &queries.QuerierMock{
+				GetProjectByIDFunc: func(ctx context.Context, id pgtype.UUID) (*queries.GetProjectByIDRow, error) {
+					if tc.projectErr != nil {
+						return nil, tc.projectErr
+					}
+					return &queries.GetProjectByIDRow{ID: id, Name: "Maple St", UserID: pgUUID(ownerID)}, nil
+				},
+				RecordDataAccessFunc: func(ctx context.Context, arg queries.RecordDataAccessParams) (*queries.DataAccessLog, error) {
+					assert.False(t, loadedImages, "images loaded before the read was recorded")
+					assert.Equal(t, pgUUID(ownerID), arg.OwnerID)
+					assert.Equal(t, pgUUID(adminID), arg.AccessorID)
+					assert.Equal(t, ResourceProject, arg.ResourceType)
+					assert.Equal(t, pgUUID(projectID), arg.ResourceID)
+					assert.Equal(t, "SUP-1042", arg.Ticket)
+					if tc.recordErr != nil {
+						return nil, tc.recordErr
+					}
+					return &queries.DataAccessLog{
+						ID: 7, OwnerID: arg.OwnerID, AccessorID: arg.AccessorID, ResourceType: arg.ResourceType,
+						ResourceID: arg.ResourceID, Ticket: arg.Ticket, Ip: arg.Ip,
+					}, nil
+				},
+			}
+			images := &image.ServiceMock{
+				GetImagesByProjectIDFunc: func(ctx context.Context, id string) ([]*image.Image, error) {
+					loadedImages = true
+					return []*image.Image{{ID: uuid.New()}}, nil
+				},
+			}
+			svc := NewDefaultServiceWithQuerier(q, images)
+
+			view, err := svc.ViewProject(context.Background(), projectID.String(), Access{
+				AccessorID: adminID.String(), Ticket: tc.ticket, IP: "203.0.113.9",
+			})
+			assert.Len(t, q.RecordDataAccessCalls(), tc.wantRecords)
+			assert.Equal(t, tc.wantImages, loadedImages)
+			if !tc.wantImages {
+				require.Error(t, err)
+				if tc.wantErr != nil {
+					assert.ErrorIs(t, err, tc.wantErr)
+				}
+				assert.Nil(t, view)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, ownerID.String(), view.Project.UserID)
+			assert.Len(t, view.Images, 1)
+			assert.Equal(t, int64(7), view.Access.ID)
+			assert.Equal(t, adminID.String(), view.Access.AccessorID)
+			assert.Equal(t, "203.0.113.9", view.Access.IP)
+		})
+	}
+}
+
+func TestDefaultService_ListForOwner(t *testing.T) {
+	ownerID := uuid.New()
+	adminID := uuid.New()
+	q := &queries.QuerierMock{
+		ListDataAccessByOwnerFunc: func(ctx context.Context, arg queries.ListDataAccessByOwnerParams) ([]*queries.DataAccessLog, error) {
+			assert.Equal(t, pgUUID(ownerID), arg.OwnerID)
+			assert.Equal(t, int32(51), arg.Limit)
+			return []*queries.DataAccessLog{
+				{ID: 2, OwnerID: arg.OwnerID, AccessorID: pgUUID(adminID), ResourceType: ResourceProject, Ticket: "SUP-2"},
+				{ID: 1, OwnerID: arg.OwnerID, AccessorID: pgUUID(adminID), ResourceType: ResourceProject, Ticket: "SUP-1"},
+			}, nil
+		},
+	}
+	svc := NewDefaultServiceWithQuerier(q, &image.ServiceMock{})
+
+	entries, err := svc.ListForOwner(context.Background(), ownerID.String(), 51, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "SUP-2", entries[0].Ticket)
+	assert.Equal(t, adminID.String(), entries[1].AccessorID)
+}
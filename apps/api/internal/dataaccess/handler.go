@@ -0,0 +1,12 @@
+package dataaccess
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP-level contract for the support project view and the customer's
+// data access log. Implementations should be wired to Echo routes in the server.
+type Handler interface {
+	ViewProject(c echo.Context) error
+	List(c echo.Context) error
+}
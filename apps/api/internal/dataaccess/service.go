@@ -0,0 +1,15 @@
+package dataaccess
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service records and lists reads of customer data.
+type Service interface {
+	// ViewProject records the read of a customer's project, then returns the project with
+	// its images. Nothing is returned when the read cannot be recorded. Returns ErrNotFound
+	// or ErrInvalid.
+	ViewProject(ctx context.Context, projectID string, access Access) (*ProjectView, error)
+	// ListForOwner returns reads of the owner's data, newest first.
+	ListForOwner(ctx context.Context, ownerID string, limit, offset int32) ([]Entry, error)
+}
@@ -0,0 +1,46 @@
+// Package deadletter lists the queue tasks that failed every attempt, with the payload and
+// error history workers recorded for them, and puts them back in the queue once the cause
+// of the failures is fixed.
+package deadletter
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned when no task was recorded under the ID.
+	ErrNotFound = errors.New("dead letter not found")
+	// ErrNotDead is returned when requeuing a task that still has retries left or was
+	// already requeued.
+	ErrNotDead = errors.New("task is not dead")
+)
+
+// DeadLetter is a queue task that used up its retries.
+type DeadLetter struct {
+	ID       string `json:"id"`
+	TaskID   string `json:"task_id"`
+	TaskType string `json:"task_type"`
+	Queue    string `json:"queue"`
+	// ImageID is the task's image, or the first image of a batch.
+	ImageID *string `json:"image_id,omitempty"`
+	// Payload is omitted when it is sealed.
+	Payload  json.RawMessage `json:"payload,omitempty"`
+	MaxRetry int             `json:"max_retry"`
+	// Errors are the failed attempts, oldest first, including those before earlier requeues.
+	Errors        []Failure  `json:"errors"`
+	FirstFailedAt time.Time  `json:"first_failed_at"`
+	LastFailedAt  time.Time  `json:"last_failed_at"`
+	DeadAt        *time.Time `json:"dead_at,omitempty"`
+	RequeuedAt    *time.Time `json:"requeued_at,omitempty"`
+	RequeueCount  int        `json:"requeue_count"`
+}
+
+// Failure is one failed attempt of a task.
+type Failure struct {
+	// Attempt counts runs of the task since it was last enqueued, starting at 1.
+	Attempt  int       `json:"attempt"`
+	Error    string    `json:"error"`
+	FailedAt time.Time `json:"failed_at"`
+}
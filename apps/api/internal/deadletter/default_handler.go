@@ -0,0 +1,93 @@
+package deadletter
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Pagination bounds for the dead letter listing.
+const (
+	defaultLimit = 50
+	maxLimit     = 200
+)
+
+// DefaultHandler serves dead letters over HTTP.
+type DefaultHandler struct {
+	service Service
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service) *DefaultHandler {
+	return &DefaultHandler{service: service}
+}
+
+// ErrorResponse is a simple JSON error envelope for handler responses.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// ListResponse is a page of dead letters.
+type ListResponse struct {
+	DeadLetters []DeadLetter `json:"dead_letters"`
+	Limit       int          `json:"limit"`
+	Offset      int          `json:"offset"`
+}
+
+// List handles GET /api/v1/admin/dead-letters.
+func (h *DefaultHandler) List(c echo.Context) error {
+	limit, offset := defaultLimit, 0
+	if v := c.QueryParam("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 && n <= maxLimit {
+			limit = n
+		}
+	}
+	if v := c.QueryParam("offset"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 && n <= 2147483647 {
+			offset = n
+		}
+	}
+
+	deadLetters, err := h.service.List(c.Request().Context(), limit, offset)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, ListResponse{DeadLetters: deadLetters, Limit: limit, Offset: offset})
+}
+
+// Requeue handles POST /api/v1/admin/dead-letters/:id/requeue.
+func (h *DefaultHandler) Requeue(c echo.Context) error {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid dead letter ID format"})
+	}
+
+	deadLetter, err := h.service.Requeue(c.Request().Context(), id)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, deadLetter)
+}
+
+// writeError maps service errors to responses.
+func (h *DefaultHandler) writeError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Dead letter not found"})
+	case errors.Is(err, ErrNotDead):
+		return c.JSON(http.StatusConflict, ErrorResponse{Error: "conflict", Message: err.Error()})
+	default:
+		c.Logger().Errorf("Dead letter request failed: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to process dead letter request",
+		})
+	}
+}
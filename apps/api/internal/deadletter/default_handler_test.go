@@ -0,0 +1,98 @@
+package deadletter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultHandler_List(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		err            error
+		wantLimit      int
+		wantOffset     int
+		expectedStatus int
+	}{
+		{name: "success: default page", wantLimit: 50, expectedStatus: http.StatusOK},
+		{name: "success: custom page", query: "?limit=10&offset=20", wantLimit: 10, wantOffset: 20, expectedStatus: http.StatusOK},
+		{name: "success: limit over the cap is ignored", query: "?limit=1000", wantLimit: 50, expectedStatus: http.StatusOK},
+		{name: "fail: service error", err: errors.New("db down"), wantLimit: 50, expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				ListFunc: func(ctx context.Context, limit, offset int) ([]DeadLetter, error) {
+					assert.Equal(t, tc.wantLimit, limit)
+					assert.Equal(t, tc.wantOffset, offset)
+					return []DeadLetter{{ID: "dl-1", TaskType: "stage:run"}}, tc.err
+				},
+			}
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/admin/dead-letters"+tc.query, nil), rec)
+
+			require.NoError(t, NewDefaultHandler(svc).List(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.err == nil {
+				var body ListResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				require.Len(t, body.DeadLetters, 1)
+				assert.Equal(t, "dl-1", body.DeadLetters[0].ID)
+				assert.Equal(t, tc.wantLimit, body.Limit)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_Requeue(t *testing.T) {
+	id := uuid.NewString()
+
+	testCases := []struct {
+		name           string
+		id             string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: requeued", id: id, expectedStatus: http.StatusOK},
+		{name: "fail: invalid id", id: "not-a-uuid", expectedStatus: http.StatusBadRequest},
+		{name: "fail: not found", id: id, err: ErrNotFound, expectedStatus: http.StatusNotFound},
+		{name: "fail: not dead", id: id, err: ErrNotDead, expectedStatus: http.StatusConflict},
+		{name: "fail: service error", id: id, err: errors.New("redis down"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				RequeueFunc: func(ctx context.Context, gotID string) (*DeadLetter, error) {
+					assert.Equal(t, tc.id, gotID)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &DeadLetter{ID: gotID, RequeueCount: 1}, nil
+				},
+			}
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodPost, "/api/v1/admin/dead-letters/"+tc.id+"/requeue", nil), rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.id)
+
+			require.NoError(t, NewDefaultHandler(svc).Requeue(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusBadRequest {
+				assert.Empty(t, svc.RequeueCalls())
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package deadletter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// DefaultService implements Service.
+type DefaultService struct {
+	querier  queries.Querier
+	requeuer queue.DeadLetterRequeuer
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService with a database. Tasks are requeued
+// through Redis when it is configured and dropped otherwise.
+func NewDefaultService(cfg *config.Config, db storage.Database) *DefaultService {
+	var r queue.DeadLetterRequeuer
+	if rq, err := queue.NewAsynqDeadLetterRequeuerFromConfig(cfg); err == nil {
+		r = rq
+	} else {
+		r = queue.NoopDeadLetterRequeuer{}
+	}
+	return &DefaultService{querier: queries.New(db), requeuer: r}
+}
+
+// NewDefaultServiceWithQuerier creates a new DefaultService with a custom querier and
+// requeuer (for testing).
+func NewDefaultServiceWithQuerier(querier queries.Querier, requeuer queue.DeadLetterRequeuer) *DefaultService {
+	return &DefaultService{querier: querier, requeuer: requeuer}
+}
+
+// List returns a page of dead tasks.
+func (s *DefaultService) List(ctx context.Context, limit, offset int) ([]DeadLetter, error) {
+	rows, err := s.querier.ListDeadLetterJobs(ctx, queries.ListDeadLetterJobsParams{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+	out := make([]DeadLetter, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, toDeadLetter(r))
+	}
+	return out, nil
+}
+
+// Requeue enqueues the task's recorded payload again, then marks it requeued. A task that
+// is already back in the queue is not dead, so it is reported as ErrNotDead.
+func (s *DefaultService) Requeue(ctx context.Context, id string) (*DeadLetter, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	pid := pgtype.UUID{Bytes: uid, Valid: true}
+
+	row, err := s.querier.GetDeadLetterJob(ctx, pid)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dead letter: %w", err)
+	}
+	if !row.DeadAt.Valid {
+		return nil, ErrNotDead
+	}
+
+	err = s.requeuer.Requeue(ctx, queue.DeadLetterTask{
+		TaskID:   row.TaskID,
+		TaskType: row.TaskType,
+		Queue:    row.Queue,
+		Payload:  row.Payload,
+		MaxRetry: int(row.MaxRetry),
+	})
+	if errors.Is(err, queue.ErrTaskInQueue) {
+		return nil, fmt.Errorf("%w: %w", ErrNotDead, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to requeue task: %w", err)
+	}
+
+	row, err = s.querier.MarkDeadLetterJobRequeued(ctx, pid)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotDead
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark dead letter requeued: %w", err)
+	}
+	d := toDeadLetter(row)
+	return &d, nil
+}
+
+func toDeadLetter(r *queries.DeadLetterJob) DeadLetter {
+	d := DeadLetter{
+		ID:            r.ID.String(),
+		TaskID:        r.TaskID,
+		TaskType:      r.TaskType,
+		Queue:         r.Queue,
+		MaxRetry:      int(r.MaxRetry),
+		Errors:        []Failure{},
+		FirstFailedAt: r.FirstFailedAt.Time,
+		LastFailedAt:  r.LastFailedAt.Time,
+		DeadAt:        timePtr(r.DeadAt),
+		RequeuedAt:    timePtr(r.RequeuedAt),
+		RequeueCount:  int(r.RequeueCount),
+	}
+	if r.ImageID.Valid {
+		imageID := r.ImageID.String()
+		d.ImageID = &imageID
+	}
+	if json.Valid(r.Payload) {
+		d.Payload = r.Payload
+	}
+	// The errors are written by workers only; a row that fails to parse lists none.
+	_ = json.Unmarshal(r.Errors, &d.Errors)
+	return d
+}
+
+func timePtr(ts pgtype.Timestamptz) *time.Time {
+	if !ts.Valid {
+		return nil
+	}
+	t := ts.Time
+	return &t
+}
@@ -0,0 +1,149 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+func deadRow(id uuid.UUID, dead bool) *queries.DeadLetterJob {
+	failedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	row := &queries.DeadLetterJob{
+		ID:            pgtype.UUID{Bytes: id, Valid: true},
+		TaskID:        "job-1",
+		TaskType:      "stage:run",
+		Queue:         "default",
+		Payload:       []byte(`{"image_id":"img-1"}`),
+		ImageID:       pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		MaxRetry:      3,
+		Errors:        []byte(`[{"attempt":1,"error":"timeout","failed_at":"2025-03-01T12:00:00Z"}]`),
+		FirstFailedAt: pgtype.Timestamptz{Time: failedAt, Valid: true},
+		LastFailedAt:  pgtype.Timestamptz{Time: failedAt, Valid: true},
+	}
+	if dead {
+		row.DeadAt = pgtype.Timestamptz{Time: failedAt, Valid: true}
+	}
+	return row
+}
+
+func TestDefaultService_List(t *testing.T) {
+	t.Run("success: rows mapped, sealed payloads omitted", func(t *testing.T) {
+		sealed := deadRow(uuid.New(), true)
+		sealed.Payload = []byte{0x01, 0xfe}
+		sealed.ImageID = pgtype.UUID{}
+		q := &queries.QuerierMock{
+			ListDeadLetterJobsFunc: func(ctx context.Context, arg queries.ListDeadLetterJobsParams) ([]*queries.DeadLetterJob, error) {
+				assert.Equal(t, queries.ListDeadLetterJobsParams{Limit: 50, Offset: 10}, arg)
+				return []*queries.DeadLetterJob{deadRow(uuid.New(), true), sealed}, nil
+			},
+		}
+
+		got, err := NewDefaultServiceWithQuerier(q, queue.NoopDeadLetterRequeuer{}).List(context.Background(), 50, 10)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.JSONEq(t, `{"image_id":"img-1"}`, string(got[0].Payload))
+		assert.NotNil(t, got[0].ImageID)
+		require.Len(t, got[0].Errors, 1)
+		assert.Equal(t, Failure{Attempt: 1, Error: "timeout", FailedAt: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)}, got[0].Errors[0])
+		assert.NotNil(t, got[0].DeadAt)
+		assert.Nil(t, got[1].Payload)
+		assert.Nil(t, got[1].ImageID)
+	})
+
+	t.Run("fail: query error", func(t *testing.T) {
+		q := &queries.QuerierMock{
+			ListDeadLetterJobsFunc: func(ctx context.Context, arg queries.ListDeadLetterJobsParams) ([]*queries.DeadLetterJob, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		_, err := NewDefaultServiceWithQuerier(q, queue.NoopDeadLetterRequeuer{}).List(context.Background(), 50, 0)
+		assert.ErrorContains(t, err, "failed to list dead letters")
+	})
+}
+
+func TestDefaultService_Requeue(t *testing.T) {
+	id := uuid.New()
+
+	testCases := []struct {
+		name       string
+		id         string
+		row        *queries.DeadLetterJob
+		getErr     error
+		requeueErr error
+		markErr    error
+		wantErr    error
+		errSubstr  string
+		wantCalls  int
+	}{
+		{name: "success: requeued and marked", id: id.String(), row: deadRow(id, true), wantCalls: 1},
+		{name: "fail: invalid id", id: "nope", wantErr: ErrNotFound},
+		{name: "fail: not recorded", id: id.String(), getErr: pgx.ErrNoRows, wantErr: ErrNotFound},
+		{name: "fail: still has retries", id: id.String(), row: deadRow(id, false), wantErr: ErrNotDead},
+		{
+			name: "fail: task already in the queue", id: id.String(), row: deadRow(id, true),
+			requeueErr: queue.ErrTaskInQueue, wantErr: ErrNotDead, wantCalls: 1,
+		},
+		{
+			name: "fail: requeue error", id: id.String(), row: deadRow(id, true),
+			requeueErr: errors.New("redis down"), errSubstr: "failed to requeue task", wantCalls: 1,
+		},
+		{
+			name: "fail: requeued concurrently", id: id.String(), row: deadRow(id, true),
+			markErr: pgx.ErrNoRows, wantErr: ErrNotDead, wantCalls: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				GetDeadLetterJobFunc: func(ctx context.Context, gotID pgtype.UUID) (*queries.DeadLetterJob, error) {
+					assert.Equal(t, id.String(), gotID.String())
+					return tc.row, tc.getErr
+				},
+				MarkDeadLetterJobRequeuedFunc: func(ctx context.Context, gotID pgtype.UUID) (*queries.DeadLetterJob, error) {
+					if tc.markErr != nil {
+						return nil, tc.markErr
+					}
+					row := deadRow(id, false)
+					row.RequeuedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+					row.RequeueCount = 1
+					return row, nil
+				},
+			}
+			requeuer := &queue.DeadLetterRequeuerMock{
+				RequeueFunc: func(ctx context.Context, task queue.DeadLetterTask) error {
+					assert.Equal(t, queue.DeadLetterTask{
+						TaskID: "job-1", TaskType: "stage:run", Queue: "default",
+						Payload: []byte(`{"image_id":"img-1"}`), MaxRetry: 3,
+					}, task)
+					return tc.requeueErr
+				},
+			}
+
+			got, err := NewDefaultServiceWithQuerier(q, requeuer).Requeue(context.Background(), tc.id)
+			assert.Len(t, requeuer.RequeueCalls(), tc.wantCalls)
+			switch {
+			case tc.wantErr != nil:
+				assert.ErrorIs(t, err, tc.wantErr)
+			case tc.errSubstr != "":
+				assert.ErrorContains(t, err, tc.errSubstr)
+			default:
+				require.NoError(t, err)
+				assert.Nil(t, got.DeadAt)
+				assert.NotNil(t, got.RequeuedAt)
+				assert.Equal(t, 1, got.RequeueCount)
+				assert.Len(t, q.MarkDeadLetterJobRequeuedCalls(), 1)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package deadletter
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service lists and requeues dead queue tasks.
+type Service interface {
+	// List returns dead tasks, most recently dead first.
+	List(ctx context.Context, limit, offset int) ([]DeadLetter, error)
+	// Requeue puts the dead task back in the queue with a fresh set of retries and takes it
+	// off the list. Returns ErrNotFound or ErrNotDead.
+	Requeue(ctx context.Context, id string) (*DeadLetter, error)
+}
@@ -0,0 +1,206 @@
+package entitlement
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	redis "github.com/redis/go-redis/v9"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// maxLocalEntries bounds the in-process cache; it is emptied when full.
+const maxLocalEntries = 10000
+
+// CachedService caches another Service's entitlements in Redis for cfg.CacheTTL and in
+// process for cfg.LocalTTL.
+//
+// Invalidate bumps the user's generation in Redis, which makes every cached copy stale
+// including one written by a lookup that raced with it, and publishes the user ID on
+// InvalidationChannel so Listen drops the in-process copy on every instance. A missed
+// message is covered by the short in-process TTL. Redis failures fall back to the wrapped
+// Service.
+type CachedService struct {
+	next     Service
+	rdb      *redis.Client
+	ttl      time.Duration
+	localTTL time.Duration
+	now      func() time.Time
+
+	mu    sync.Mutex
+	local map[string]localEntry
+	// epoch counts invalidations seen, so a lookup that raced with one is not kept in process.
+	epoch uint64
+}
+
+type localEntry struct {
+	e         Entitlements
+	expiresAt time.Time
+}
+
+// cacheEntry is the JSON stored in Redis: entitlements and the generation they were read at.
+type cacheEntry struct {
+	Gen int64 `json:"gen"`
+	Entitlements
+}
+
+// Ensure CachedService implements Service.
+var _ Service = (*CachedService)(nil)
+
+// NewServiceFromConfig returns a CachedService when Redis is configured, listening for
+// invalidations until ctx is done, and otherwise a DefaultService.
+func NewServiceFromConfig(ctx context.Context, cfg *config.Config, db storage.Database) Service {
+	if cfg.Redis.Addr == "" {
+		return NewDefaultService(db)
+	}
+	c := NewCachedService(NewDefaultService(db), redis.NewClient(&redis.Options{Addr: cfg.Redis.Addr}), cfg.Entitlements)
+	go func() {
+		if err := c.Listen(ctx); err != nil {
+			logging.Default().Error(ctx, "Entitlement invalidation listener stopped", "error", err)
+		}
+	}()
+	return c
+}
+
+// NewCachedService creates a CachedService in front of next.
+func NewCachedService(next Service, rdb *redis.Client, cfg config.Entitlements) *CachedService {
+	if cfg.CacheTTL <= 0 {
+		cfg.CacheTTL = 5 * time.Minute
+	}
+	if cfg.LocalTTL <= 0 {
+		cfg.LocalTTL = 10 * time.Second
+	}
+	return &CachedService{
+		next:     next,
+		rdb:      rdb,
+		ttl:      cfg.CacheTTL,
+		localTTL: cfg.LocalTTL,
+		now:      time.Now,
+		local:    map[string]localEntry{},
+	}
+}
+
+func cacheKey(userID string) string { return "entitlements:" + userID }
+func genKey(userID string) string   { return "entitlements:gen:" + userID }
+
+// Get returns the user's entitlements from the in-process cache, then Redis, then the
+// wrapped Service.
+func (c *CachedService) Get(ctx context.Context, userID string) (*Entitlements, error) {
+	c.mu.Lock()
+	le, ok := c.local[userID]
+	epoch := c.epoch
+	c.mu.Unlock()
+	if ok && c.now().Before(le.expiresAt) {
+		e := le.e
+		return &e, nil
+	}
+
+	vals, err := c.rdb.MGet(ctx, cacheKey(userID), genKey(userID)).Result()
+	if err != nil {
+		logging.Default().Warn(ctx, "entitlement cache unavailable", "error", err)
+		return c.next.Get(ctx, userID)
+	}
+	gen, err := parseGen(vals[1])
+	if err != nil {
+		return nil, err
+	}
+	if raw, ok := vals[0].(string); ok {
+		var entry cacheEntry
+		if err := json.Unmarshal([]byte(raw), &entry); err == nil && entry.Gen == gen {
+			c.keepLocal(userID, entry.Entitlements, epoch)
+			return &entry.Entitlements, nil
+		}
+	}
+
+	e, err := c.next.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := json.Marshal(cacheEntry{Gen: gen, Entitlements: *e})
+	if err != nil {
+		return nil, err
+	}
+	if err := c.rdb.Set(ctx, cacheKey(userID), payload, c.ttl).Err(); err != nil {
+		logging.Default().Warn(ctx, "entitlement cache write failed", "error", err)
+	}
+	c.keepLocal(userID, *e, epoch)
+	return e, nil
+}
+
+// parseGen reads a generation from MGET; a missing key is generation 0.
+func parseGen(v any) (int64, error) {
+	s, ok := v.(string)
+	if !ok {
+		return 0, nil
+	}
+	gen, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid entitlement generation %q: %w", s, err)
+	}
+	return gen, nil
+}
+
+// keepLocal stores e in process unless an invalidation arrived since epoch was read.
+func (c *CachedService) keepLocal(userID string, e Entitlements, epoch uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.epoch != epoch {
+		return
+	}
+	if len(c.local) >= maxLocalEntries {
+		c.local = map[string]localEntry{}
+	}
+	c.local[userID] = localEntry{e: e, expiresAt: c.now().Add(c.localTTL)}
+}
+
+// drop removes the user's in-process entry.
+func (c *CachedService) drop(userID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.local, userID)
+	c.epoch++
+}
+
+// Invalidate makes the user's cached entitlements stale everywhere.
+func (c *CachedService) Invalidate(ctx context.Context, userID string) error {
+	c.drop(userID)
+	if err := c.rdb.Incr(ctx, genKey(userID)).Err(); err != nil {
+		return fmt.Errorf("bump entitlement generation: %w", err)
+	}
+	if err := c.rdb.Publish(ctx, InvalidationChannel, userID).Err(); err != nil {
+		return fmt.Errorf("publish %s: %w", InvalidationChannel, err)
+	}
+	return nil
+}
+
+// Listen drops in-process entries as invalidations are published, until ctx is done.
+func (c *CachedService) Listen(ctx context.Context) error {
+	sub := c.rdb.Subscribe(ctx, InvalidationChannel)
+	defer func() { _ = sub.Close() }()
+	if _, err := sub.Receive(ctx); err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil
+		}
+		return fmt.Errorf("subscribe %s: %w", InvalidationChannel, err)
+	}
+
+	msgs := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-msgs:
+			if !ok {
+				return nil
+			}
+			c.drop(msg.Payload)
+		}
+	}
+}
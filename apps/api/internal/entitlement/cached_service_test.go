@@ -0,0 +1,167 @@
+package entitlement
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+// plans returns a Service mock that serves *plan for every user.
+func plans(plan *Entitlements) *ServiceMock {
+	return &ServiceMock{
+		GetFunc: func(ctx context.Context, userID string) (*Entitlements, error) {
+			e := *plan
+			return &e, nil
+		},
+	}
+}
+
+func newCached(t *testing.T, mr *miniredis.Miniredis, next Service) *CachedService {
+	t.Helper()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	return NewCachedService(next, rdb, config.Entitlements{})
+}
+
+func TestCachedService_Get(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: later lookups are served from cache", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		next := plans(&Entitlements{Plan: "pro", ReferenceImages: true})
+		c := newCached(t, mr, next)
+
+		for range 3 {
+			got, err := c.Get(ctx, userID)
+			require.NoError(t, err)
+			assert.Equal(t, "pro", got.Plan)
+		}
+		assert.Len(t, next.GetCalls(), 1)
+		assert.True(t, mr.Exists(cacheKey(userID)))
+
+		// Another instance finds the entry in Redis.
+		other := newCached(t, mr, next)
+		_, err := other.Get(ctx, userID)
+		require.NoError(t, err)
+		assert.Len(t, next.GetCalls(), 1)
+	})
+
+	t.Run("success: in-process entries expire", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		next := plans(&Entitlements{Plan: "pro"})
+		c := newCached(t, mr, next)
+		now := time.Now()
+		c.now = func() time.Time { return now }
+
+		_, err := c.Get(ctx, userID)
+		require.NoError(t, err)
+		mr.Del(cacheKey(userID))
+		now = now.Add(11 * time.Second)
+
+		_, err = c.Get(ctx, userID)
+		require.NoError(t, err)
+		assert.Len(t, next.GetCalls(), 2)
+	})
+
+	t.Run("success: redis down reads through", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		next := plans(&Entitlements{Plan: "pro"})
+		c := newCached(t, mr, next)
+		mr.Close()
+
+		got, err := c.Get(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, "pro", got.Plan)
+	})
+
+	t.Run("fail: lookup error is not cached", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		next := &ServiceMock{
+			GetFunc: func(ctx context.Context, userID string) (*Entitlements, error) {
+				return nil, errors.New("boom")
+			},
+		}
+		c := newCached(t, mr, next)
+
+		_, err := c.Get(ctx, userID)
+		assert.Error(t, err)
+		assert.False(t, mr.Exists(cacheKey(userID)))
+	})
+}
+
+func TestCachedService_Invalidate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: revocation applies on every instance", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		plan := &Entitlements{Plan: "pro", ReferenceImages: true}
+		next := plans(plan)
+		webhook := newCached(t, mr, next)
+		reader := newCached(t, mr, next)
+
+		listenCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		done := make(chan error, 1)
+		go func() { done <- reader.Listen(listenCtx) }()
+		require.Eventually(t, func() bool { return mr.PubSubNumSub(InvalidationChannel)[InvalidationChannel] == 1 },
+			time.Second, 10*time.Millisecond)
+
+		got, err := reader.Get(ctx, userID)
+		require.NoError(t, err)
+		require.True(t, got.ReferenceImages)
+
+		*plan = Entitlements{}
+		require.NoError(t, webhook.Invalidate(ctx, userID))
+
+		require.Eventually(t, func() bool {
+			got, err := reader.Get(ctx, userID)
+			return err == nil && !got.ReferenceImages
+		}, time.Second, 10*time.Millisecond)
+
+		cancel()
+		assert.NoError(t, <-done)
+	})
+
+	t.Run("success: lookup racing an invalidation is not served", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		var c *CachedService
+		calls := 0
+		next := &ServiceMock{
+			GetFunc: func(ctx context.Context, userID string) (*Entitlements, error) {
+				calls++
+				if calls == 1 {
+					// The subscription is revoked while this stale read is in flight.
+					require.NoError(t, c.Invalidate(ctx, userID))
+					return &Entitlements{Plan: "pro"}, nil
+				}
+				return &Entitlements{}, nil
+			},
+		}
+		c = newCached(t, mr, next)
+
+		got, err := c.Get(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, "pro", got.Plan)
+
+		got, err = c.Get(ctx, userID)
+		require.NoError(t, err)
+		assert.Empty(t, got.Plan)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("fail: redis down", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		c := newCached(t, mr, plans(&Entitlements{}))
+		mr.Close()
+
+		assert.Error(t, c.Invalidate(ctx, userID))
+	})
+}
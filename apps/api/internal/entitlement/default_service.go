@@ -0,0 +1,60 @@
+package entitlement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// DefaultService implements Service by reading the database on every call.
+type DefaultService struct {
+	querier queries.Querier
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService with a database.
+func NewDefaultService(db storage.Database) *DefaultService {
+	return NewDefaultServiceWithQuerier(queries.New(db))
+}
+
+// NewDefaultServiceWithQuerier creates a new DefaultService with a custom querier (for testing).
+func NewDefaultServiceWithQuerier(querier queries.Querier) *DefaultService {
+	return &DefaultService{querier: querier}
+}
+
+// Get returns the plan of the user's most recently updated active subscription.
+func (s *DefaultService) Get(ctx context.Context, userID string) (*Entitlements, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	row, err := s.querier.GetUserEntitlements(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &Entitlements{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entitlements: %w", err)
+	}
+	return &Entitlements{
+		Plan:            row.Code,
+		Status:          row.Status,
+		MonthlyLimit:    int(row.MonthlyLimit),
+		ReferenceImages: row.ReferenceImages,
+		Expedite:        row.Expedite,
+		CustomerBucket:  row.CustomerBucket,
+	}, nil
+}
+
+// Invalidate does nothing; nothing is cached.
+func (s *DefaultService) Invalidate(context.Context, string) error {
+	return nil
+}
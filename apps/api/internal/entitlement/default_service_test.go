@@ -0,0 +1,57 @@
+package entitlement
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+const userID = "3f0c9a52-7d4e-4b8f-9a61-2c5e8d1b7f40"
+
+func TestDefaultService_Get(t *testing.T) {
+	testCases := []struct {
+		name    string
+		userID  string
+		row     *queries.GetUserEntitlementsRow
+		err     error
+		want    *Entitlements
+		wantErr bool
+	}{
+		{
+			name:   "success: active plan",
+			userID: userID,
+			row: &queries.GetUserEntitlementsRow{
+				Code: "pro", Status: "active", MonthlyLimit: 200, ReferenceImages: true, Expedite: true,
+			},
+			want: &Entitlements{Plan: "pro", Status: "active", MonthlyLimit: 200, ReferenceImages: true, Expedite: true},
+		},
+		{name: "success: no active subscription", userID: userID, err: pgx.ErrNoRows, want: &Entitlements{}},
+		{name: "fail: query error", userID: userID, err: errors.New("boom"), wantErr: true},
+		{name: "fail: invalid user ID", userID: "nope", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			querier := &queries.QuerierMock{
+				GetUserEntitlementsFunc: func(ctx context.Context, id pgtype.UUID) (*queries.GetUserEntitlementsRow, error) {
+					return tc.row, tc.err
+				},
+			}
+
+			got, err := NewDefaultServiceWithQuerier(querier).Get(context.Background(), tc.userID)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+// Package entitlement resolves what a user's plan allows. Lookups are cached in Redis and in
+// process; billing changes invalidate a user's entry on every API instance through Redis
+// pub/sub, so a revoked plan stops applying within seconds.
+package entitlement
+
+// InvalidationChannel is the Redis channel user IDs are published to when their
+// entitlements change.
+const InvalidationChannel = "entitlements:invalidate"
+
+// Entitlements are the limits and features of a user's active plan. A user without an active
+// subscription has the zero value: no plan and no paid features.
+type Entitlements struct {
+	// Plan is the plan code, or "" without an active subscription.
+	Plan string `json:"plan"`
+	// Status is the subscription status: active, trialing or past_due.
+	Status string `json:"status,omitempty"`
+	// MonthlyLimit is the number of images the plan includes per month.
+	MonthlyLimit    int  `json:"monthly_limit"`
+	ReferenceImages bool `json:"reference_images"`
+	Expedite        bool `json:"expedite"`
+	CustomerBucket  bool `json:"customer_bucket"`
+}
@@ -0,0 +1,14 @@
+package entitlement
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service resolves users' entitlements.
+type Service interface {
+	// Get returns the user's entitlements.
+	Get(ctx context.Context, userID string) (*Entitlements, error)
+	// Invalidate drops any cached entitlements of the user, so the next Get reads them from
+	// the database. Call it after changing the user's subscriptions.
+	Invalidate(ctx context.Context, userID string) error
+}
@@ -0,0 +1,219 @@
+// Package fieldcrypt encrypts sensitive column values in the application before they are
+// written to Postgres. Values are sealed with AES-256-GCM under a key id, so keys can be
+// rotated while rows encrypted with older keys are still readable, and equality lookups
+// go through a keyed blind index instead of the ciphertext.
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+// prefix marks an encrypted value: "enc:v1:<key id>:<base64 nonce and ciphertext>".
+const prefix = "enc:v1:"
+
+var (
+	defaultMu      sync.RWMutex
+	defaultKeyring *Keyring
+)
+
+// Default returns the process-wide keyring set with SetDefault; nil stores plaintext.
+func Default() *Keyring {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultKeyring
+}
+
+// SetDefault replaces the process-wide keyring.
+func SetDefault(k *Keyring) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultKeyring = k
+}
+
+// Keyring encrypts with its active key and decrypts values encrypted with any of its
+// keys. A nil Keyring leaves values in plaintext.
+type Keyring struct {
+	active   string
+	aeads    map[string]cipher.AEAD
+	indexKey []byte
+}
+
+// NewKeyring builds a keyring from the field encryption config. It returns nil when no
+// keys are configured.
+func NewKeyring(cfg config.FieldEncryption) (*Keyring, error) {
+	if len(cfg.Keys) == 0 {
+		if cfg.ActiveKey != "" {
+			return nil, fmt.Errorf("field encryption key %q is not configured", cfg.ActiveKey)
+		}
+		return nil, nil
+	}
+	k := &Keyring{active: cfg.ActiveKey, aeads: make(map[string]cipher.AEAD, len(cfg.Keys))}
+	for id, encoded := range cfg.Keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("invalid field encryption key id %q", id)
+		}
+		raw, err := decodeKey(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("field encryption key %q: %w", id, err)
+		}
+		block, err := aes.NewCipher(raw)
+		if err != nil {
+			return nil, fmt.Errorf("field encryption key %q: %w", id, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("field encryption key %q: %w", id, err)
+		}
+		k.aeads[id] = aead
+	}
+	if k.active != "" && k.aeads[k.active] == nil {
+		return nil, fmt.Errorf("field encryption key %q is not configured", k.active)
+	}
+	if cfg.IndexKey != "" {
+		raw, err := decodeKey(cfg.IndexKey)
+		if err != nil {
+			return nil, fmt.Errorf("field encryption index key: %w", err)
+		}
+		k.indexKey = raw
+	}
+	if k.active != "" && k.indexKey == nil {
+		return nil, errors.New("field encryption index key is required to encrypt values")
+	}
+	return k, nil
+}
+
+// Active returns the id of the key new values are encrypted with; empty stores plaintext.
+func (k *Keyring) Active() string {
+	if k == nil {
+		return ""
+	}
+	return k.active
+}
+
+func decodeKey(encoded string) ([]byte, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+	if len(raw) != 32 {
+		return nil, fmt.Errorf("must be 32 bytes, got %d", len(raw))
+	}
+	return raw, nil
+}
+
+// Encrypt seals plaintext with the active key. column binds the ciphertext to the column
+// it is stored in, so values cannot be swapped between columns. Without an active key,
+// or for an empty value, plaintext is returned as is.
+func (k *Keyring) Encrypt(column, plaintext string) (string, error) {
+	if k == nil || k.active == "" || plaintext == "" {
+		return plaintext, nil
+	}
+	aead := k.aeads[k.active]
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(column))
+	return prefix + k.active + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens a value sealed by Encrypt for the same column. Values that are not
+// encrypted, such as rows written before encryption was enabled, are returned as is.
+func (k *Keyring) Decrypt(column, value string) (string, error) {
+	rest, ok := strings.CutPrefix(value, prefix)
+	if !ok {
+		return value, nil
+	}
+	if k == nil {
+		return "", errors.New("value is encrypted but no field encryption keys are configured")
+	}
+	id, encoded, ok := strings.Cut(rest, ":")
+	if !ok {
+		return "", errors.New("malformed encrypted value")
+	}
+	aead, ok := k.aeads[id]
+	if !ok {
+		return "", fmt.Errorf("field encryption key %q is not configured", id)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", errors.New("malformed encrypted value")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(column))
+	if err != nil {
+		return "", fmt.Errorf("decrypt %s: %w", column, err)
+	}
+	return string(plaintext), nil
+}
+
+// EncryptJSON seals a JSON document for a JSONB column. The ciphertext is stored as a JSON
+// string, so the column type is unchanged. Without an active key raw is returned as is.
+func (k *Keyring) EncryptJSON(column string, raw []byte) ([]byte, error) {
+	if k == nil || k.active == "" || len(raw) == 0 {
+		return raw, nil
+	}
+	sealed, err := k.Encrypt(column, string(raw))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(sealed)
+}
+
+// DecryptJSON opens a JSON document sealed by EncryptJSON. Plain documents are returned as is.
+func (k *Keyring) DecryptJSON(column string, raw []byte) ([]byte, error) {
+	value, ok := encryptedJSON(raw)
+	if !ok {
+		return raw, nil
+	}
+	plaintext, err := k.Decrypt(column, value)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(plaintext), nil
+}
+
+// encryptedJSON returns the sealed value held in a JSON document, if it holds one.
+func encryptedJSON(raw []byte) (string, bool) {
+	var value string
+	if len(raw) == 0 || raw[0] != '"' || json.Unmarshal(raw, &value) != nil {
+		return "", false
+	}
+	return value, strings.HasPrefix(value, prefix)
+}
+
+// BlindIndex returns a keyed hash of value for equality lookups on an encrypted column.
+// It returns an empty string when no index key is configured or value is empty.
+func (k *Keyring) BlindIndex(value string) string {
+	if k == nil || k.indexKey == nil || value == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, k.indexKey)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// NeedsRotation reports whether a stored value is not yet encrypted with the active key.
+// JSON documents sealed by EncryptJSON are recognised too.
+func (k *Keyring) NeedsRotation(value string) bool {
+	if k == nil || k.active == "" || value == "" {
+		return false
+	}
+	if sealed, ok := encryptedJSON([]byte(value)); ok {
+		value = sealed
+	}
+	return !strings.HasPrefix(value, prefix+k.active+":")
+}
@@ -0,0 +1,206 @@
+package fieldcrypt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, 32))
+}
+
+func newTestKeyring(t *testing.T, active string, keys map[string]string) *Keyring {
+	t.Helper()
+	k, err := NewKeyring(config.FieldEncryption{ActiveKey: active, Keys: keys, IndexKey: testKey(9)})
+	require.NoError(t, err)
+	return k
+}
+
+func TestNewKeyring(t *testing.T) {
+	testCases := []struct {
+		name    string
+		cfg     config.FieldEncryption
+		wantNil bool
+		wantErr string
+	}{
+		{name: "success: no keys disables encryption", wantNil: true},
+		{
+			name: "success: decrypt-only keyring needs no index key",
+			cfg:  config.FieldEncryption{Keys: map[string]string{"k1": testKey(1)}},
+		},
+		{
+			name: "success: active key with index key",
+			cfg: config.FieldEncryption{
+				ActiveKey: "k1", Keys: map[string]string{"k1": testKey(1)}, IndexKey: testKey(9),
+			},
+		},
+		{
+			name:    "fail: active key without keys",
+			cfg:     config.FieldEncryption{ActiveKey: "k1"},
+			wantErr: `field encryption key "k1" is not configured`,
+		},
+		{
+			name: "fail: active key not in keys",
+			cfg: config.FieldEncryption{
+				ActiveKey: "k2", Keys: map[string]string{"k1": testKey(1)}, IndexKey: testKey(9),
+			},
+			wantErr: `field encryption key "k2" is not configured`,
+		},
+		{
+			name:    "fail: active key without index key",
+			cfg:     config.FieldEncryption{ActiveKey: "k1", Keys: map[string]string{"k1": testKey(1)}},
+			wantErr: "index key is required",
+		},
+		{
+			name:    "fail: key id with a colon",
+			cfg:     config.FieldEncryption{Keys: map[string]string{"k:1": testKey(1)}},
+			wantErr: `invalid field encryption key id "k:1"`,
+		},
+		{
+			name: "fail: short key",
+			cfg: config.FieldEncryption{
+				Keys: map[string]string{"k1": base64.StdEncoding.EncodeToString([]byte("short"))},
+			},
+			wantErr: `field encryption key "k1": must be 32 bytes, got 5`,
+		},
+		{
+			name:    "fail: bad index key",
+			cfg:     config.FieldEncryption{Keys: map[string]string{"k1": testKey(1)}, IndexKey: "!"},
+			wantErr: "field encryption index key: decode",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k, err := NewKeyring(tc.cfg)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantNil, k == nil)
+		})
+	}
+}
+
+func TestKeyring_EncryptDecrypt(t *testing.T) {
+	k := newTestKeyring(t, "k1", map[string]string{"k1": testKey(1)})
+
+	t.Run("success: round trip", func(t *testing.T) {
+		sealed, err := k.Encrypt("users.phone", "+1 555 0100")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(sealed, "enc:v1:k1:"))
+		assert.NotContains(t, sealed, "555")
+
+		plain, err := k.Decrypt("users.phone", sealed)
+		require.NoError(t, err)
+		assert.Equal(t, "+1 555 0100", plain)
+	})
+
+	t.Run("success: plaintext passes through", func(t *testing.T) {
+		var nilKeys *Keyring
+		sealed, err := nilKeys.Encrypt("users.phone", "+1 555 0100")
+		require.NoError(t, err)
+		assert.Equal(t, "+1 555 0100", sealed)
+
+		plain, err := k.Decrypt("users.phone", "+1 555 0100")
+		require.NoError(t, err)
+		assert.Equal(t, "+1 555 0100", plain)
+
+		empty, err := k.Encrypt("users.phone", "")
+		require.NoError(t, err)
+		assert.Empty(t, empty)
+	})
+
+	t.Run("success: retired key still decrypts after rotation", func(t *testing.T) {
+		sealed, err := k.Encrypt("users.phone", "+1 555 0100")
+		require.NoError(t, err)
+
+		rotated := newTestKeyring(t, "k2", map[string]string{"k1": testKey(1), "k2": testKey(2)})
+		plain, err := rotated.Decrypt("users.phone", sealed)
+		require.NoError(t, err)
+		assert.Equal(t, "+1 555 0100", plain)
+		assert.True(t, rotated.NeedsRotation(sealed))
+	})
+
+	t.Run("fail: ciphertext moved to another column", func(t *testing.T) {
+		sealed, err := k.Encrypt("users.phone", "+1 555 0100")
+		require.NoError(t, err)
+		_, err = k.Decrypt("users.stripe_customer_id", sealed)
+		assert.ErrorContains(t, err, "decrypt users.stripe_customer_id")
+	})
+
+	t.Run("fail: encrypted value without keys", func(t *testing.T) {
+		sealed, err := k.Encrypt("users.phone", "+1 555 0100")
+		require.NoError(t, err)
+		var nilKeys *Keyring
+		_, err = nilKeys.Decrypt("users.phone", sealed)
+		assert.ErrorContains(t, err, "no field encryption keys are configured")
+	})
+
+	t.Run("fail: unknown key and malformed values", func(t *testing.T) {
+		_, err := k.Decrypt("users.phone", "enc:v1:k9:AAAA")
+		assert.ErrorContains(t, err, `field encryption key "k9" is not configured`)
+		_, err = k.Decrypt("users.phone", "enc:v1:k1")
+		assert.ErrorContains(t, err, "malformed encrypted value")
+		_, err = k.Decrypt("users.phone", "enc:v1:k1:not-base64!")
+		assert.ErrorContains(t, err, "malformed encrypted value")
+	})
+}
+
+func TestKeyring_JSON(t *testing.T) {
+	k := newTestKeyring(t, "k1", map[string]string{"k1": testKey(1)})
+	address := []byte(`{"line1":"1 Main St","city":"Springfield"}`)
+
+	sealed, err := k.EncryptJSON("users.billing_address", address)
+	require.NoError(t, err)
+	assert.True(t, json.Valid(sealed), "sealed document must fit a JSONB column")
+	assert.NotContains(t, string(sealed), "Main St")
+	assert.False(t, k.NeedsRotation(string(sealed)))
+
+	opened, err := k.DecryptJSON("users.billing_address", sealed)
+	require.NoError(t, err)
+	assert.Equal(t, address, opened)
+
+	plain, err := k.DecryptJSON("users.billing_address", address)
+	require.NoError(t, err)
+	assert.Equal(t, address, plain)
+	assert.True(t, k.NeedsRotation(string(address)))
+
+	unsealed, err := k.EncryptJSON("users.billing_address", nil)
+	require.NoError(t, err)
+	assert.Nil(t, unsealed)
+}
+
+func TestKeyring_BlindIndex(t *testing.T) {
+	k := newTestKeyring(t, "k1", map[string]string{"k1": testKey(1)})
+
+	assert.Equal(t, k.BlindIndex("cus_123"), k.BlindIndex("cus_123"))
+	assert.NotEqual(t, k.BlindIndex("cus_123"), k.BlindIndex("cus_124"))
+	assert.Len(t, k.BlindIndex("cus_123"), 64)
+	assert.Empty(t, k.BlindIndex(""))
+
+	var nilKeys *Keyring
+	assert.Empty(t, nilKeys.BlindIndex("cus_123"))
+}
+
+func TestDefault(t *testing.T) {
+	prev := Default()
+	t.Cleanup(func() { SetDefault(prev) })
+
+	k := newTestKeyring(t, "k1", map[string]string{"k1": testKey(1)})
+	SetDefault(k)
+	assert.Same(t, k, Default())
+	assert.Equal(t, "k1", Default().Active())
+
+	SetDefault(nil)
+	assert.Empty(t, Default().Active())
+}
@@ -0,0 +1,71 @@
+// Package geocode resolves postal addresses to coordinates through a configurable
+// provider, so project listings can be placed on a map. Vendors are registered in
+// providers and selected by config.Geocoding.Provider.
+package geocode
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/httpclient"
+	"github.com/real-staging-ai/api/internal/provider"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out geocoder_mock.go . Geocoder
+
+// ErrNotFound is returned when the provider has no match for an address.
+var ErrNotFound = errors.New("address not found")
+
+// Address is a postal address. Country is an ISO 3166-1 alpha-2 code.
+type Address struct {
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2"`
+	City       string `json:"city"`
+	Region     string `json:"region"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+}
+
+// IsZero reports whether every field of the address is empty.
+func (a Address) IsZero() bool {
+	return a == Address{}
+}
+
+// String joins the address's non-empty parts with commas.
+func (a Address) String() string {
+	var parts []string
+	for _, p := range []string{a.Line1, a.Line2, a.City, a.Region, a.PostalCode, a.Country} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Location is a point in WGS 84 coordinates.
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Geocoder resolves addresses to locations.
+type Geocoder interface {
+	// Geocode returns the best match for addr, or ErrNotFound when there is none.
+	Geocode(ctx context.Context, addr Address) (*Location, error)
+}
+
+// providers are the geocoding vendors, by the name config.Geocoding.Provider selects.
+var providers = provider.NewRegistry[config.Geocoding, Geocoder](provider.CapabilityGeocoding).
+	Register("nominatim", func(cfg config.Geocoding) (Geocoder, error) {
+		return NewNominatimGeocoder(httpclient.New(httpclient.DestinationGeocoding), cfg), nil
+	})
+
+// New returns the Geocoder configured by cfg, or nil when geocoding is disabled.
+func New(cfg config.Geocoding) (Geocoder, error) {
+	if cfg.Provider == "" {
+		return nil, nil
+	}
+	return providers.New(cfg.Provider, cfg)
+}
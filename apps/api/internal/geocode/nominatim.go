@@ -0,0 +1,128 @@
+package geocode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/provider"
+)
+
+// NominatimGeocoder geocodes with a Nominatim server, such as the public OpenStreetMap
+// one or a self-hosted instance. The public server allows about one request per second
+// and requires an identifying User-Agent.
+type NominatimGeocoder struct {
+	client    *http.Client
+	baseURL   string
+	email     string
+	userAgent string
+}
+
+// Ensure NominatimGeocoder implements Geocoder and can report its health.
+var (
+	_ Geocoder               = (*NominatimGeocoder)(nil)
+	_ provider.HealthChecker = (*NominatimGeocoder)(nil)
+)
+
+// NewNominatimGeocoder creates a NominatimGeocoder that sends requests through client.
+func NewNominatimGeocoder(client *http.Client, cfg config.Geocoding) *NominatimGeocoder {
+	return &NominatimGeocoder{
+		client:    client,
+		baseURL:   strings.TrimRight(cfg.BaseURL, "/"),
+		email:     cfg.Email,
+		userAgent: cfg.UserAgent,
+	}
+}
+
+// nominatimPlace is the part of a /search result the geocoder reads. Nominatim encodes
+// coordinates as strings.
+type nominatimPlace struct {
+	Lat string `json:"lat"`
+	Lon string `json:"lon"`
+}
+
+// Geocode runs a structured /search for addr. Line2, typically a unit number, is left
+// out because Nominatim does not index it and it would prevent a match.
+func (g *NominatimGeocoder) Geocode(ctx context.Context, addr Address) (*Location, error) {
+	q := url.Values{"format": {"jsonv2"}, "limit": {"1"}}
+	for key, value := range map[string]string{
+		"street":       addr.Line1,
+		"city":         addr.City,
+		"state":        addr.Region,
+		"postalcode":   addr.PostalCode,
+		"countrycodes": strings.ToLower(addr.Country),
+		"email":        g.email,
+	} {
+		if value != "" {
+			q.Set(key, value)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.baseURL+"/search?"+q.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build geocoding request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if g.userAgent != "" {
+		req.Header.Set("User-Agent", g.userAgent)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("geocoding request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return nil, fmt.Errorf("geocoding request failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	var places []nominatimPlace
+	if err := json.NewDecoder(resp.Body).Decode(&places); err != nil {
+		return nil, fmt.Errorf("failed to decode geocoding response: %w", err)
+	}
+	if len(places) == 0 {
+		return nil, ErrNotFound
+	}
+	lat, err := strconv.ParseFloat(places[0].Lat, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid latitude %q in geocoding response", places[0].Lat)
+	}
+	lon, err := strconv.ParseFloat(places[0].Lon, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid longitude %q in geocoding response", places[0].Lon)
+	}
+	return &Location{Latitude: lat, Longitude: lon}, nil
+}
+
+// CheckHealth asks the server's /status endpoint whether it can answer searches.
+func (g *NominatimGeocoder) CheckHealth(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.baseURL+"/status?format=json", nil)
+	if err != nil {
+		return fmt.Errorf("failed to build geocoding status request: %w", err)
+	}
+	if g.userAgent != "" {
+		req.Header.Set("User-Agent", g.userAgent)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("geocoding status request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	var status struct {
+		Status  int    `json:"status"`
+		Message string `json:"message"`
+	}
+	decodeErr := json.NewDecoder(resp.Body).Decode(&status)
+	if resp.StatusCode != http.StatusOK || decodeErr != nil || status.Status != 0 {
+		return fmt.Errorf("geocoding provider unhealthy: status %d %s", resp.StatusCode, status.Message)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package geocode
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/provider"
+)
+
+func TestNominatimGeocoder_Geocode(t *testing.T) {
+	addr := Address{
+		Line1: "1600 Pennsylvania Ave NW", Line2: "Unit 2", City: "Washington", Region: "DC",
+		PostalCode: "20500", Country: "US",
+	}
+
+	cases := []struct {
+		name    string
+		status  int
+		body    string
+		want    *Location
+		wantErr string
+	}{
+		{
+			name:   "success: first match",
+			status: http.StatusOK,
+			body:   `[{"lat":"38.8976633","lon":"-77.0365739"},{"lat":"0","lon":"0"}]`,
+			want:   &Location{Latitude: 38.8976633, Longitude: -77.0365739},
+		},
+		{
+			name:    "fail: no match",
+			status:  http.StatusOK,
+			body:    `[]`,
+			wantErr: ErrNotFound.Error(),
+		},
+		{
+			name:    "fail: provider error",
+			status:  http.StatusTooManyRequests,
+			body:    `rate limited`,
+			wantErr: "geocoding request failed with status 429: rate limited",
+		},
+		{
+			name:    "fail: malformed coordinates",
+			status:  http.StatusOK,
+			body:    `[{"lat":"north","lon":"-77"}]`,
+			wantErr: `invalid latitude "north"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got *http.Request
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			g := NewNominatimGeocoder(srv.Client(), config.Geocoding{
+				BaseURL: srv.URL + "/", Email: "ops@example.com", UserAgent: "real-staging-test",
+			})
+			loc, err := g.Geocode(context.Background(), addr)
+
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, loc)
+
+			require.NotNil(t, got)
+			assert.Equal(t, "/search", got.URL.Path)
+			assert.Equal(t, "real-staging-test", got.UserAgent())
+			q := got.URL.Query()
+			assert.Equal(t, "1600 Pennsylvania Ave NW", q.Get("street"))
+			assert.Equal(t, "us", q.Get("countrycodes"))
+			assert.Equal(t, "ops@example.com", q.Get("email"))
+			assert.False(t, q.Has("q"))
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	g, err := New(config.Geocoding{})
+	assert.NoError(t, err)
+	assert.Nil(t, g)
+
+	g, err = New(config.Geocoding{Provider: "nominatim", BaseURL: "https://nominatim.example.com"})
+	assert.NoError(t, err)
+	assert.IsType(t, &NominatimGeocoder{}, g)
+
+	_, err = New(config.Geocoding{Provider: "google"})
+	assert.ErrorIs(t, err, provider.ErrUnknown)
+	assert.ErrorContains(t, err, `geocoding provider "google"`)
+}
+
+func TestNominatimGeocoder_CheckHealth(t *testing.T) {
+	cases := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr bool
+	}{
+		{name: "success: ok", status: http.StatusOK, body: `{"status":0,"message":"OK"}`},
+		{name: "fail: database unavailable", status: http.StatusInternalServerError,
+			body: `{"status":700,"message":"Database connection failed"}`, wantErr: true},
+		{name: "fail: not json", status: http.StatusOK, body: `OK`, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/status", r.URL.Path)
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			err := NewNominatimGeocoder(srv.Client(), config.Geocoding{BaseURL: srv.URL}).CheckHealth(context.Background())
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAddress_String(t *testing.T) {
+	assert.Equal(t, "1 Main St, Springfield, US", Address{Line1: "1 Main St", City: "Springfield", Country: "US"}.String())
+	assert.True(t, Address{}.IsZero())
+}
@@ -0,0 +1,77 @@
+// Package golden compares HTTP JSON responses against snapshots checked in under
+// testdata/golden, so a change to a response's shape fails a test instead of reaching
+// integrators unnoticed.
+//
+// Run tests with UPDATE_GOLDEN=1 to rewrite the snapshots after an intended change, and
+// review the diff like any other API change.
+package golden
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// UpdateEnv is the environment variable that rewrites snapshots instead of comparing them.
+const UpdateEnv = "UPDATE_GOLDEN"
+
+// Dir is where snapshots live, relative to the test's package directory.
+const Dir = "testdata/golden"
+
+// snapshot is what a golden file holds.
+type snapshot struct {
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body"`
+}
+
+// AssertJSON fails t when the status and JSON body differ from testdata/golden/<name>.json.
+// Bodies are compared after normalizing, so key order and whitespace do not matter.
+func AssertJSON(t testing.TB, name string, status int, body []byte) {
+	t.Helper()
+
+	got, err := Normalize(status, body)
+	if err != nil {
+		t.Fatalf("golden %s: %v", name, err)
+	}
+
+	path := filepath.Join(Dir, name+".json")
+	if os.Getenv(UpdateEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("golden %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, got, 0o600); err != nil {
+			t.Fatalf("golden %s: %v", name, err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("golden %s: %v (run with %s=1 to create it)", name, err, UpdateEnv)
+	}
+	if !bytes.Equal(want, got) {
+		t.Errorf("golden %s: response shape changed (run with %s=1 if intended)\nwant:\n%s\ngot:\n%s",
+			name, UpdateEnv, want, got)
+	}
+}
+
+// Normalize renders a response as a golden file: indented, with object keys sorted.
+func Normalize(status int, body []byte) ([]byte, error) {
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil, fmt.Errorf("response is not JSON: %w", err)
+	}
+	// Marshaling the decoded value sorts object keys.
+	sorted, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	out, err := json.MarshalIndent(snapshot{Status: status, Body: sorted}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
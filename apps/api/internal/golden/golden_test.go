@@ -0,0 +1,129 @@
+package golden
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingTB records the first failure instead of failing the test. Unlike a real
+// testing.TB, Fatalf returns, so later failures are ignored.
+type recordingTB struct {
+	testing.TB
+	failed bool
+	msg    string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	if r.failed {
+		return
+	}
+	r.failed = true
+	r.msg = fmt.Sprintf(format, args...)
+}
+
+func (r *recordingTB) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+}
+
+func TestNormalize(t *testing.T) {
+	cases := []struct {
+		name    string
+		body    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success: sorts keys and indents",
+			body: `{"b":1,"a":{"d":true,"c":null}}`,
+			want: "{\n  \"status\": 200,\n  \"body\": {\n    \"a\": {\n      \"c\": null,\n      \"d\": true\n    },\n" +
+				"    \"b\": 1\n  }\n}\n",
+		},
+		{
+			name:    "fail: body is not JSON",
+			body:    `not json`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Normalize(200, []byte(tc.body))
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, string(got))
+		})
+	}
+}
+
+func TestAssertJSON(t *testing.T) {
+	cases := []struct {
+		name     string
+		existing string
+		body     string
+		update   bool
+		wantFail string
+	}{
+		{
+			name:     "success: matches regardless of key order",
+			existing: `{"a":1,"b":2}`,
+			body:     `{"b":2,"a":1}`,
+		},
+		{
+			name:   "success: update writes the snapshot",
+			body:   `{"a":1}`,
+			update: true,
+		},
+		{
+			name:     "fail: changed shape",
+			existing: `{"a":1}`,
+			body:     `{"a":"1"}`,
+			wantFail: "response shape changed",
+		},
+		{
+			name:     "fail: missing snapshot",
+			body:     `{"a":1}`,
+			wantFail: "no such file",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			if tc.update {
+				t.Setenv(UpdateEnv, "1")
+			}
+			if tc.existing != "" {
+				want, err := Normalize(200, []byte(tc.existing))
+				require.NoError(t, err)
+				require.NoError(t, os.MkdirAll(Dir, 0o755))
+				require.NoError(t, os.WriteFile(filepath.Join(Dir, "resp.json"), want, 0o600))
+			}
+
+			rec := &recordingTB{TB: t}
+			AssertJSON(rec, "resp", 200, []byte(tc.body))
+
+			if tc.wantFail != "" {
+				assert.True(t, rec.failed)
+				assert.Contains(t, rec.msg, tc.wantFail)
+				return
+			}
+			assert.False(t, rec.failed, rec.msg)
+			if tc.update {
+				got, err := os.ReadFile(filepath.Join(Dir, "resp.json"))
+				require.NoError(t, err)
+				want, _ := Normalize(200, []byte(tc.body))
+				assert.Equal(t, string(want), string(got))
+			}
+		})
+	}
+}
@@ -1,16 +1,26 @@
 package http
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/labstack/echo/v4"
 
-	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/csvexport"
+	"github.com/real-staging-ai/api/internal/job"
+	"github.com/real-staging-ai/api/internal/legalhold"
 	"github.com/real-staging-ai/api/internal/logging"
 	"github.com/real-staging-ai/api/internal/settings"
 	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
 	"github.com/real-staging-ai/api/internal/user"
 )
 
@@ -92,7 +102,7 @@ func (h *AdminHandler) UpdateActiveModel(c echo.Context) error {
 	}
 
 	// Get user UUID from Auth0 sub
-	userUUID, err := h.resolveUserUUID(c)
+	userUUID, err := user.ResolveID(c, user.NewDefaultRepository(h.db))
 	if err != nil {
 		h.log.Error(ctx, "failed to resolve user", "error", err)
 		return echo.NewHTTPError(http.StatusUnauthorized, map[string]string{
@@ -159,7 +169,7 @@ func (h *AdminHandler) UpdateSetting(c echo.Context) error {
 	}
 
 	// Get user UUID from Auth0 sub
-	userUUID, err := h.resolveUserUUID(c)
+	userUUID, err := user.ResolveID(c, user.NewDefaultRepository(h.db))
 	if err != nil {
 		h.log.Error(ctx, "failed to resolve user", "error", err)
 		return echo.NewHTTPError(http.StatusUnauthorized, map[string]string{
@@ -182,32 +192,343 @@ func (h *AdminHandler) UpdateSetting(c echo.Context) error {
 	})
 }
 
-// resolveUserUUID looks up or creates a user based on Auth0 sub, returning the user's UUID.
-func (h *AdminHandler) resolveUserUUID(c echo.Context) (string, error) {
+// Pagination bounds for admin listing endpoints.
+const (
+	adminDefaultLimit = 50
+	adminMaxLimit     = 200
+)
+
+// adminJobStatuses are the job states accepted by the ?status filter.
+var adminJobStatuses = map[job.Status]bool{
+	job.StatusQueued:     true,
+	job.StatusProcessing: true,
+	job.StatusCompleted:  true,
+	job.StatusFailed:     true,
+	job.StatusCanceled:   true,
+}
+
+// AdminUser is the admin listing view of a user.
+type AdminUser struct {
+	ID               string    `json:"id"`
+	Auth0Sub         string    `json:"auth0_sub"`
+	StripeCustomerID *string   `json:"stripe_customer_id,omitempty"`
+	Role             string    `json:"role"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// AdminJob is the admin listing view of a job. The payload is omitted.
+type AdminJob struct {
+	ID         string     `json:"id"`
+	ImageID    string     `json:"image_id"`
+	Type       string     `json:"type"`
+	Status     string     `json:"status"`
+	Error      *string    `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// AdminJobDetail is the admin detail view of a job, including the payload and the
+// provider details the worker records while staging.
+type AdminJobDetail struct {
+	AdminJob
+	Payload          json.RawMessage `json:"payload,omitempty"`
+	PredictionID     *string         `json:"prediction_id,omitempty"`
+	OutputKey        *string         `json:"output_key,omitempty"`
+	ModelVersion     *string         `json:"model_version,omitempty"`
+	ProviderResponse *string         `json:"provider_response,omitempty"`
+}
+
+// ListUsers handles GET /admin/users - Lists users, newest first.
+// With ?format=csv every user is streamed as a CSV attachment and limit/offset are ignored.
+func (h *AdminHandler) ListUsers(c echo.Context) error {
 	ctx := c.Request().Context()
+	uRepo := user.NewDefaultRepository(h.db)
 
-	// Get Auth0 sub from JWT token
-	auth0Sub, err := auth.GetUserIDOrDefault(c)
-	if err != nil {
-		return "", err
+	if csvexport.Requested(c) {
+		header := []string{"id", "auth0_sub", "stripe_customer_id", "role", "created_at"}
+		err := csvexport.Stream(c, "users.csv", header, func(ctx context.Context, limit, offset int) ([][]string, error) {
+			rows, err := uRepo.List(ctx, limit, offset)
+			if err != nil {
+				return nil, err
+			}
+			out := make([][]string, 0, len(rows))
+			for _, r := range rows {
+				u := toAdminUser(r)
+				out = append(out, []string{
+					u.ID, u.Auth0Sub, derefString(u.StripeCustomerID), u.Role, u.CreatedAt.Format(time.RFC3339),
+				})
+			}
+			return out, nil
+		})
+		if err != nil {
+			h.log.Error(ctx, "failed to export users", "error", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list users")
+		}
+		return nil
 	}
 
-	// Look up user by Auth0 sub
-	uRepo := user.NewDefaultRepository(h.db)
-	existingUser, err := uRepo.GetByAuth0Sub(ctx, auth0Sub)
+	limit, offset := parseAdminLimitOffset(c)
+	rows, err := uRepo.List(ctx, limit, offset)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			// User doesn't exist, create them
-			newUser, err := uRepo.Create(ctx, auth0Sub, "", "user")
+		h.log.Error(ctx, "failed to list users", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list users")
+	}
+
+	users := make([]AdminUser, 0, len(rows))
+	for _, r := range rows {
+		users = append(users, toAdminUser(r))
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"users":  users,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// ListJobs handles GET /admin/jobs - Lists jobs, newest first, optionally filtered by ?status.
+// With ?format=csv every matching job is streamed as a CSV attachment and limit/offset are ignored.
+func (h *AdminHandler) ListJobs(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	status := c.QueryParam("status")
+	if status != "" && !adminJobStatuses[job.Status(status)] {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid status filter")
+	}
+
+	jRepo := job.NewDefaultRepository(h.db)
+
+	if csvexport.Requested(c) {
+		header := []string{"id", "image_id", "type", "status", "error", "created_at", "started_at", "finished_at"}
+		err := csvexport.Stream(c, "jobs.csv", header, func(ctx context.Context, limit, offset int) ([][]string, error) {
+			rows, err := jRepo.ListJobs(ctx, status, limit, offset)
 			if err != nil {
-				h.log.Error(ctx, "failed to create user", "error", err, "auth0_sub", auth0Sub)
-				return "", err
+				return nil, err
+			}
+			out := make([][]string, 0, len(rows))
+			for _, r := range rows {
+				j := toAdminJob(r)
+				out = append(out, []string{
+					j.ID, j.ImageID, j.Type, j.Status, derefString(j.Error),
+					j.CreatedAt.Format(time.RFC3339), formatTimePtr(j.StartedAt), formatTimePtr(j.FinishedAt),
+				})
 			}
-			return newUser.ID.String(), nil
+			return out, nil
+		})
+		if err != nil {
+			h.log.Error(ctx, "failed to export jobs", "error", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list jobs")
 		}
-		h.log.Error(ctx, "failed to get user by auth0 sub", "error", err, "auth0_sub", auth0Sub)
-		return "", err
+		return nil
+	}
+
+	limit, offset := parseAdminLimitOffset(c)
+	rows, err := jRepo.ListJobs(ctx, status, limit, offset)
+	if err != nil {
+		h.log.Error(ctx, "failed to list jobs", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list jobs")
+	}
+
+	jobs := make([]AdminJob, 0, len(rows))
+	for _, r := range rows {
+		jobs = append(jobs, toAdminJob(r))
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"jobs":   jobs,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// GetJob handles GET /admin/jobs/:id - Gets a job with its payload and provider details.
+func (h *AdminHandler) GetJob(c echo.Context) error {
+	ctx := c.Request().Context()
+	id := c.Param("id")
+
+	if _, err := uuid.Parse(id); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid job ID format")
 	}
 
-	return existingUser.ID.String(), nil
+	r, err := job.NewDefaultRepository(h.db).GetJobByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, "Job not found")
+		}
+		h.log.Error(ctx, "failed to get job", "error", err, "job_id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get job")
+	}
+
+	return c.JSON(http.StatusOK, toAdminJobDetail(r))
+}
+
+// parseAdminLimitOffset reads limit/offset from query params and applies defaults/caps.
+func parseAdminLimitOffset(c echo.Context) (int, int) {
+	limit := adminDefaultLimit
+	offset := 0
+
+	if v := c.QueryParam("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 && n <= adminMaxLimit {
+			limit = n
+		}
+	}
+	if v := c.QueryParam("offset"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 && n <= 2147483647 {
+			offset = n
+		}
+	}
+
+	return limit, offset
+}
+
+func toAdminUser(r *queries.ListUsersRow) AdminUser {
+	u := AdminUser{
+		ID:        r.ID.String(),
+		Auth0Sub:  r.Auth0Sub,
+		Role:      r.Role,
+		CreatedAt: r.CreatedAt.Time,
+	}
+	if r.StripeCustomerID.Valid {
+		u.StripeCustomerID = &r.StripeCustomerID.String
+	}
+	return u
+}
+
+func toAdminJob(r *queries.Job) AdminJob {
+	j := AdminJob{
+		ID:         r.ID.String(),
+		ImageID:    r.ImageID.String(),
+		Type:       r.Type,
+		Status:     r.Status,
+		CreatedAt:  r.CreatedAt.Time,
+		StartedAt:  timestamptzPtr(r.StartedAt),
+		FinishedAt: timestamptzPtr(r.FinishedAt),
+	}
+	if r.Error.Valid {
+		j.Error = &r.Error.String
+	}
+	return j
+}
+
+func toAdminJobDetail(r *queries.Job) AdminJobDetail {
+	d := AdminJobDetail{
+		AdminJob:         toAdminJob(r),
+		PredictionID:     textPtr(r.PredictionID),
+		OutputKey:        textPtr(r.OutputKey),
+		ModelVersion:     textPtr(r.ModelVersion),
+		ProviderResponse: textPtr(r.ProviderResponse),
+	}
+	if json.Valid(r.PayloadJson) {
+		d.Payload = r.PayloadJson
+	}
+	return d
+}
+
+func textPtr(t pgtype.Text) *string {
+	if !t.Valid {
+		return nil
+	}
+	s := t.String
+	return &s
+}
+
+func timestamptzPtr(ts pgtype.Timestamptz) *time.Time {
+	if !ts.Valid {
+		return nil
+	}
+	t := ts.Time
+	return &t
+}
+
+func formatTimePtr(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// ListLegalHolds handles GET /admin/legal-holds - Lists active legal holds.
+func (h *AdminHandler) ListLegalHolds(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	holds, err := legalhold.NewDefaultService(h.db).ListHolds(ctx)
+	if err != nil {
+		h.log.Error(ctx, "failed to list legal holds", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list legal holds")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"holds": holds,
+	})
+}
+
+// UpdateProjectLegalHold handles PUT /admin/projects/:id/legal-hold - Places or releases a project legal hold.
+func (h *AdminHandler) UpdateProjectLegalHold(c echo.Context) error {
+	return h.updateLegalHold(c, "project", legalhold.NewDefaultService(h.db).SetProjectHold)
+}
+
+// UpdateImageLegalHold handles PUT /admin/images/:id/legal-hold - Places or releases an image legal hold.
+func (h *AdminHandler) UpdateImageLegalHold(c echo.Context) error {
+	return h.updateLegalHold(c, "image", legalhold.NewDefaultService(h.db).SetImageHold)
+}
+
+// maxLegalHoldReasonLength caps the reason recorded with a legal hold.
+const maxLegalHoldReasonLength = 1000
+
+// updateLegalHold validates a legal hold update for a project or image and applies it with set.
+func (h *AdminHandler) updateLegalHold(
+	c echo.Context,
+	target string,
+	set func(ctx context.Context, id string, held bool, reason, placedBy string) (*legalhold.Status, error),
+) error {
+	ctx := c.Request().Context()
+	id := c.Param("id")
+
+	if _, err := uuid.Parse(id); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid "+target+" ID format")
+	}
+
+	var req legalhold.UpdateRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	if req.LegalHold == nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "legal_hold is required")
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if *req.LegalHold && reason == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "reason is required when placing a legal hold")
+	}
+	if len(reason) > maxLegalHoldReasonLength {
+		return echo.NewHTTPError(http.StatusBadRequest, "reason must be at most 1000 characters")
+	}
+
+	userUUID, err := user.ResolveID(c, user.NewDefaultRepository(h.db))
+	if err != nil {
+		h.log.Error(ctx, "failed to resolve user", "error", err)
+		return echo.NewHTTPError(http.StatusUnauthorized, map[string]string{
+			"message": "User not authenticated",
+		})
+	}
+
+	status, err := set(ctx, id, *req.LegalHold, reason, userUUID)
+	if err != nil {
+		if errors.Is(err, legalhold.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, strings.ToUpper(target[:1])+target[1:]+" not found")
+		}
+		h.log.Error(ctx, "failed to update legal hold", "error", err, "target", target, "id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update legal hold")
+	}
+
+	h.log.Info(ctx, "legal hold updated", "target", target, "id", id, "legal_hold", status.LegalHold, "user_uuid", userUUID)
+
+	return c.JSON(http.StatusOK, status)
 }
@@ -0,0 +1,579 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/legalhold"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+var adminJobColumns = []string{"id", "image_id", "type", "payload_json", "status",
+	"error", "created_at", "started_at", "finished_at",
+	"prediction_id", "output_key", "model_version", "provider_response"}
+
+var adminUserColumns = []string{"id", "auth0_sub", "stripe_customer_id", "role", "created_at"}
+
+func newAdminHandlerWithPool(t *testing.T) (*AdminHandler, pgxmock.PgxPoolIface) {
+	t.Helper()
+	poolMock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	t.Cleanup(poolMock.Close)
+
+	dbMock := &storage.DatabaseMock{
+		QueryFunc: func(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+			return poolMock.Query(ctx, sql, args...)
+		},
+		QueryRowFunc: func(ctx context.Context, sql string, args ...any) pgx.Row {
+			return poolMock.QueryRow(ctx, sql, args...)
+		},
+	}
+	log := &logging.LoggerMock{
+		ErrorFunc: func(ctx context.Context, msg string, keysAndValues ...any) {},
+		InfoFunc:  func(ctx context.Context, msg string, keysAndValues ...any) {},
+	}
+	return NewAdminHandler(nil, dbMock, log), poolMock
+}
+
+func TestAdminHandler_ListJobs(t *testing.T) {
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	jobRow := func(status string) []any {
+		return []any{
+			pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			"stage:run",
+			[]byte(`{}`),
+			status,
+			pgtype.Text{String: "=boom", Valid: status == "failed"},
+			pgtype.Timestamptz{Time: createdAt, Valid: true},
+			pgtype.Timestamptz{},
+			pgtype.Timestamptz{},
+			pgtype.Text{},
+			pgtype.Text{},
+			pgtype.Text{},
+			pgtype.Text{},
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		query          string
+		setupMock      func(mock pgxmock.PgxPoolIface)
+		expectedStatus int
+		validate       func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "success: json listing with status filter",
+			query: "?status=failed&limit=10&offset=20",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("ListJobs").
+					WithArgs(pgtype.Text{String: "failed", Valid: true}, int32(10), int32(20)).
+					WillReturnRows(pgxmock.NewRows(adminJobColumns).AddRow(jobRow("failed")...))
+			},
+			expectedStatus: http.StatusOK,
+			validate: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var resp struct {
+					Jobs   []AdminJob `json:"jobs"`
+					Limit  int        `json:"limit"`
+					Offset int        `json:"offset"`
+				}
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				require.Len(t, resp.Jobs, 1)
+				assert.Equal(t, "failed", resp.Jobs[0].Status)
+				require.NotNil(t, resp.Jobs[0].Error)
+				assert.Equal(t, "=boom", *resp.Jobs[0].Error)
+				assert.Nil(t, resp.Jobs[0].StartedAt)
+				assert.Equal(t, 10, resp.Limit)
+				assert.Equal(t, 20, resp.Offset)
+			},
+		},
+		{
+			name:  "success: csv export ignores pagination",
+			query: "?format=csv&limit=10",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("ListJobs").
+					WithArgs(pgtype.Text{}, int32(1000), int32(0)).
+					WillReturnRows(pgxmock.NewRows(adminJobColumns).
+						AddRow(jobRow("failed")...).
+						AddRow(jobRow("completed")...))
+			},
+			expectedStatus: http.StatusOK,
+			validate: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+				assert.Contains(t, rec.Header().Get(echo.HeaderContentDisposition), "jobs.csv")
+				lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+				require.Len(t, lines, 3)
+				assert.Equal(t, "id,image_id,type,status,error,created_at,started_at,finished_at", lines[0])
+				assert.Contains(t, lines[1], ",stage:run,failed,'=boom,2025-01-02T03:04:05Z,,")
+			},
+		},
+		{
+			name:           "fail: invalid status filter",
+			query:          "?status=bogus",
+			setupMock:      func(mock pgxmock.PgxPoolIface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "fail: query error",
+			query: "",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("ListJobs").
+					WithArgs(pgtype.Text{}, int32(50), int32(0)).
+					WillReturnError(errors.New("db error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:  "fail: csv export first chunk error",
+			query: "?format=csv",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("ListJobs").
+					WithArgs(pgtype.Text{}, int32(1000), int32(0)).
+					WillReturnError(errors.New("db error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, poolMock := newAdminHandlerWithPool(t)
+			tc.setupMock(poolMock)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/jobs"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := h.ListJobs(c)
+			if tc.expectedStatus == http.StatusOK {
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rec.Code)
+			} else {
+				var httpErr *echo.HTTPError
+				require.ErrorAs(t, err, &httpErr)
+				assert.Equal(t, tc.expectedStatus, httpErr.Code)
+			}
+			if tc.validate != nil {
+				tc.validate(t, rec)
+			}
+			assert.NoError(t, poolMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAdminHandler_GetJob(t *testing.T) {
+	jobID := uuid.New()
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		id             string
+		setupMock      func(mock pgxmock.PgxPoolIface)
+		expectedStatus int
+		validate       func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name: "success: includes payload and provider details",
+			id:   jobID.String(),
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("GetJobByID").
+					WithArgs(pgtype.UUID{Bytes: jobID, Valid: true}).
+					WillReturnRows(pgxmock.NewRows(adminJobColumns).AddRow(
+						pgtype.UUID{Bytes: jobID, Valid: true},
+						pgtype.UUID{Bytes: uuid.New(), Valid: true},
+						"stage:run",
+						[]byte(`{"room_type":"bedroom"}`),
+						"completed",
+						pgtype.Text{},
+						pgtype.Timestamptz{Time: createdAt, Valid: true},
+						pgtype.Timestamptz{Time: createdAt, Valid: true},
+						pgtype.Timestamptz{Time: createdAt, Valid: true},
+						pgtype.Text{String: "pred-123", Valid: true},
+						pgtype.Text{String: "staged/img/staged.jpg", Valid: true},
+						pgtype.Text{String: "v1abc", Valid: true},
+						pgtype.Text{String: `{"id":"pred-123","status":"succeeded"}`, Valid: true},
+					))
+			},
+			expectedStatus: http.StatusOK,
+			validate: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var resp AdminJobDetail
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, jobID.String(), resp.ID)
+				assert.Equal(t, "completed", resp.Status)
+				assert.JSONEq(t, `{"room_type":"bedroom"}`, string(resp.Payload))
+				require.NotNil(t, resp.PredictionID)
+				assert.Equal(t, "pred-123", *resp.PredictionID)
+				require.NotNil(t, resp.ModelVersion)
+				assert.Equal(t, "v1abc", *resp.ModelVersion)
+				require.NotNil(t, resp.ProviderResponse)
+				assert.Contains(t, *resp.ProviderResponse, "succeeded")
+				assert.Nil(t, resp.Error)
+			},
+		},
+		{
+			name: "success: provider details omitted when not recorded",
+			id:   jobID.String(),
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("GetJobByID").
+					WithArgs(pgtype.UUID{Bytes: jobID, Valid: true}).
+					WillReturnRows(pgxmock.NewRows(adminJobColumns).AddRow(jobRowWithID(jobID, createdAt)...))
+			},
+			expectedStatus: http.StatusOK,
+			validate: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.NotContains(t, rec.Body.String(), "prediction_id")
+				assert.NotContains(t, rec.Body.String(), "provider_response")
+			},
+		},
+		{
+			name:           "fail: invalid id",
+			id:             "not-a-uuid",
+			setupMock:      func(mock pgxmock.PgxPoolIface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "fail: not found",
+			id:   jobID.String(),
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("GetJobByID").
+					WithArgs(pgtype.UUID{Bytes: jobID, Valid: true}).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "fail: query error",
+			id:   jobID.String(),
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("GetJobByID").
+					WithArgs(pgtype.UUID{Bytes: jobID, Valid: true}).
+					WillReturnError(errors.New("db error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, poolMock := newAdminHandlerWithPool(t)
+			tc.setupMock(poolMock)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/jobs/"+tc.id, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.id)
+
+			err := h.GetJob(c)
+			if tc.expectedStatus == http.StatusOK {
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rec.Code)
+			} else {
+				var httpErr *echo.HTTPError
+				require.ErrorAs(t, err, &httpErr)
+				assert.Equal(t, tc.expectedStatus, httpErr.Code)
+			}
+			if tc.validate != nil {
+				tc.validate(t, rec)
+			}
+			assert.NoError(t, poolMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func jobRowWithID(id uuid.UUID, createdAt time.Time) []any {
+	return []any{
+		pgtype.UUID{Bytes: id, Valid: true},
+		pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		"stage:run",
+		[]byte(`{}`),
+		"queued",
+		pgtype.Text{},
+		pgtype.Timestamptz{Time: createdAt, Valid: true},
+		pgtype.Timestamptz{},
+		pgtype.Timestamptz{},
+		pgtype.Text{},
+		pgtype.Text{},
+		pgtype.Text{},
+		pgtype.Text{},
+	}
+}
+
+func TestAdminHandler_ListUsers(t *testing.T) {
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	userRow := func(sub string, stripeID pgtype.Text) []any {
+		return []any{
+			pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			sub,
+			stripeID,
+			"user",
+			pgtype.Timestamptz{Time: createdAt, Valid: true},
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		query          string
+		setupMock      func(mock pgxmock.PgxPoolIface)
+		expectedStatus int
+		validate       func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "success: json listing with defaults",
+			query: "?limit=9999",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("ListUsers").
+					WithArgs(int32(50), int32(0)).
+					WillReturnRows(pgxmock.NewRows(adminUserColumns).
+						AddRow(userRow("auth0|a", pgtype.Text{String: "cus_1", Valid: true})...).
+						AddRow(userRow("auth0|b", pgtype.Text{})...))
+			},
+			expectedStatus: http.StatusOK,
+			validate: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var resp struct {
+					Users []AdminUser `json:"users"`
+					Limit int         `json:"limit"`
+				}
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				require.Len(t, resp.Users, 2)
+				require.NotNil(t, resp.Users[0].StripeCustomerID)
+				assert.Equal(t, "cus_1", *resp.Users[0].StripeCustomerID)
+				assert.Nil(t, resp.Users[1].StripeCustomerID)
+				assert.Equal(t, 50, resp.Limit)
+			},
+		},
+		{
+			name:  "success: csv export",
+			query: "?format=csv",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("ListUsers").
+					WithArgs(int32(1000), int32(0)).
+					WillReturnRows(pgxmock.NewRows(adminUserColumns).
+						AddRow(userRow("auth0|a", pgtype.Text{})...))
+			},
+			expectedStatus: http.StatusOK,
+			validate: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Contains(t, rec.Header().Get(echo.HeaderContentDisposition), "users.csv")
+				lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+				require.Len(t, lines, 2)
+				assert.Equal(t, "id,auth0_sub,stripe_customer_id,role,created_at", lines[0])
+				assert.True(t, strings.HasSuffix(lines[1], ",auth0|a,,user,2025-01-02T03:04:05Z"))
+			},
+		},
+		{
+			name:  "fail: query error",
+			query: "",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("ListUsers").
+					WithArgs(int32(50), int32(0)).
+					WillReturnError(errors.New("db error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, poolMock := newAdminHandlerWithPool(t)
+			tc.setupMock(poolMock)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := h.ListUsers(c)
+			if tc.expectedStatus == http.StatusOK {
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rec.Code)
+			} else {
+				var httpErr *echo.HTTPError
+				require.ErrorAs(t, err, &httpErr)
+				assert.Equal(t, tc.expectedStatus, httpErr.Code)
+			}
+			if tc.validate != nil {
+				tc.validate(t, rec)
+			}
+			assert.NoError(t, poolMock.ExpectationsWereMet())
+		})
+	}
+}
+
+var legalHoldColumns = []string{"id", "project_id", "image_id", "reason", "placed_by", "created_at"}
+
+func TestAdminHandler_ListLegalHolds(t *testing.T) {
+	projectID := uuid.New()
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("success: lists holds", func(t *testing.T) {
+		h, poolMock := newAdminHandlerWithPool(t)
+		poolMock.ExpectQuery("ListLegalHolds").
+			WillReturnRows(pgxmock.NewRows(legalHoldColumns).AddRow(
+				pgtype.UUID{Bytes: uuid.New(), Valid: true},
+				pgtype.UUID{Bytes: projectID, Valid: true},
+				pgtype.UUID{},
+				"Dispute #42",
+				pgtype.UUID{},
+				pgtype.Timestamptz{Time: createdAt, Valid: true},
+			))
+
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/admin/legal-holds", nil), rec)
+
+		require.NoError(t, h.ListLegalHolds(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var resp struct {
+			Holds []legalhold.Hold `json:"holds"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.Len(t, resp.Holds, 1)
+		assert.Equal(t, projectID.String(), *resp.Holds[0].ProjectID)
+		assert.Equal(t, "Dispute #42", resp.Holds[0].Reason)
+	})
+
+	t.Run("fail: query error", func(t *testing.T) {
+		h, poolMock := newAdminHandlerWithPool(t)
+		poolMock.ExpectQuery("ListLegalHolds").WillReturnError(errors.New("db error"))
+
+		e := echo.New()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/admin/legal-holds", nil), httptest.NewRecorder())
+
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, h.ListLegalHolds(c), &httpErr)
+		assert.Equal(t, http.StatusInternalServerError, httpErr.Code)
+	})
+}
+
+func TestAdminHandler_UpdateProjectLegalHold(t *testing.T) {
+	projectID := uuid.New()
+	adminID := uuid.New()
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	expectAdmin := func(mock pgxmock.PgxPoolIface) {
+		mock.ExpectQuery("GetUserByAuth0Sub").
+			WithArgs("auth0|testuser").
+			WillReturnRows(pgxmock.NewRows(adminUserColumns).AddRow(
+				pgtype.UUID{Bytes: adminID, Valid: true},
+				"auth0|testuser",
+				pgtype.Text{},
+				"admin",
+				pgtype.Timestamptz{Time: createdAt, Valid: true},
+			))
+	}
+
+	testCases := []struct {
+		name           string
+		projectID      string
+		body           string
+		setupMock      func(mock pgxmock.PgxPoolIface)
+		expectedStatus int
+		validate       func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "success: place hold",
+			projectID: projectID.String(),
+			body:      `{"legal_hold": true, "reason": " Dispute #42 "}`,
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				expectAdmin(mock)
+				mock.ExpectQuery("PlaceProjectLegalHold").
+					WithArgs(pgtype.UUID{Bytes: projectID, Valid: true}, "Dispute #42", pgtype.UUID{Bytes: adminID, Valid: true}).
+					WillReturnRows(pgxmock.NewRows(legalHoldColumns).AddRow(
+						pgtype.UUID{Bytes: uuid.New(), Valid: true},
+						pgtype.UUID{Bytes: projectID, Valid: true},
+						pgtype.UUID{},
+						"Dispute #42",
+						pgtype.UUID{Bytes: adminID, Valid: true},
+						pgtype.Timestamptz{Time: createdAt, Valid: true},
+					))
+			},
+			expectedStatus: http.StatusOK,
+			validate: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var resp legalhold.Status
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.True(t, resp.LegalHold)
+				require.NotNil(t, resp.Hold)
+				assert.Equal(t, adminID.String(), *resp.Hold.PlacedBy)
+			},
+		},
+		{
+			name:           "fail: invalid project id",
+			projectID:      "nope",
+			body:           `{"legal_hold": true, "reason": "x"}`,
+			setupMock:      func(mock pgxmock.PgxPoolIface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "fail: missing legal_hold",
+			projectID:      projectID.String(),
+			body:           `{"reason": "x"}`,
+			setupMock:      func(mock pgxmock.PgxPoolIface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "fail: missing reason when placing",
+			projectID:      projectID.String(),
+			body:           `{"legal_hold": true, "reason": "  "}`,
+			setupMock:      func(mock pgxmock.PgxPoolIface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "fail: project not found",
+			projectID: projectID.String(),
+			body:      `{"legal_hold": true, "reason": "Dispute #42"}`,
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				expectAdmin(mock)
+				mock.ExpectQuery("PlaceProjectLegalHold").
+					WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+					WillReturnError(&pgconn.PgError{Code: "23503"})
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, poolMock := newAdminHandlerWithPool(t)
+			tc.setupMock(poolMock)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.projectID)
+
+			err := h.UpdateProjectLegalHold(c)
+			if tc.expectedStatus == http.StatusOK {
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rec.Code)
+			} else {
+				var httpErr *echo.HTTPError
+				require.ErrorAs(t, err, &httpErr)
+				assert.Equal(t, tc.expectedStatus, httpErr.Code)
+			}
+			if tc.validate != nil {
+				tc.validate(t, rec)
+			}
+			assert.NoError(t, poolMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -1,20 +1,23 @@
 package http
 
 import (
+	"errors"
 	"net/http"
-	"net/url"
-	"os"
 	"strconv"
 	"strings"
 
 	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/storage"
 )
 
 // presignImageDownloadHandler handles GET /api/v1/images/:id/presign
 // Query params:
-// - kind: original|staged (default: original)
+// - kind: original|staged|preview (default: original)
 // - expires_in: seconds (default: 600)
 // - download: 1 to force Content-Disposition=attachment
+//
+// Staged downloads serve the watermarked copy when the image has one.
 func (s *Server) presignImageDownloadHandler(c echo.Context) error {
 	imageID := c.Param("id")
 	if imageID == "" {
@@ -42,41 +45,35 @@ func (s *Server) presignImageDownloadHandler(c echo.Context) error {
 	}
 
 	var rawURL string
-	if kind == "staged" {
+	switch kind {
+	case "staged":
 		if img.StagedURL == nil || *img.StagedURL == "" {
 			return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "image has no staged_url"})
 		}
 		rawURL = *img.StagedURL
-	} else {
+		// Exports carry the account's watermark when it has one; inline views stay clean.
+		if contentDisposition != "" && img.WatermarkedURL != nil && *img.WatermarkedURL != "" {
+			rawURL = *img.WatermarkedURL
+		}
+	case "preview":
+		if img.PreviewURL == nil || *img.PreviewURL == "" {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "image has no preview_url"})
+		}
+		rawURL = *img.PreviewURL
+	default:
 		rawURL = img.OriginalURL
 	}
 
-	u, err := url.Parse(rawURL)
-	if err != nil || u.Path == "" {
+	files, fileKey, err := s.buckets.ForURL(c.Request().Context(), rawURL)
+	if errors.Is(err, storage.ErrInvalidObjectURL) {
 		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "invalid stored URL"})
 	}
-
-	bucket := os.Getenv("S3_BUCKET")
-	if bucket == "" {
-		bucket = os.Getenv("S3_BUCKET_NAME")
-	}
-	if bucket == "" {
-		bucket = "real-staging"
-	}
-
-	// Derive file key from URL path. Expect path-style: /<bucket>/<key>
-	p := strings.TrimPrefix(u.Path, "/")
-	if !strings.HasPrefix(p, bucket+"/") {
-		// fallback: if virtual-hosted style, path is already the key
-	} else {
-		p = strings.TrimPrefix(p, bucket+"/")
-	}
-	fileKey := p
-	if fileKey == "" {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "could not derive file key"})
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError,
+			ErrorResponse{Error: "internal_server_error", Message: "failed to resolve storage"})
 	}
 
-	signed, err := s.s3Service.GeneratePresignedGetURL(c.Request().Context(), fileKey, expiresIn, contentDisposition)
+	signed, err := files.GeneratePresignedGetURL(c.Request().Context(), fileKey, expiresIn, contentDisposition)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError,
 			ErrorResponse{Error: "internal_server_error", Message: "failed to presign URL"})
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	redis "github.com/redis/go-redis/v9"
+
+	"github.com/real-staging-ai/api/internal/provider"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// healthCheckTimeout bounds each dependency check of GET /health/details.
+const healthCheckTimeout = 2 * time.Second
+
+// dependencyCheck returns an error when a dependency cannot be reached.
+type dependencyCheck func(ctx context.Context) error
+
+// DependencyHealth is the state of one dependency in GET /health/details.
+type DependencyHealth struct {
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	LatencyMs int64  `json:"latency_ms"`
+}
+
+// HealthDetails is the body of GET /health/details.
+type HealthDetails struct {
+	Status       string                      `json:"status"`
+	Service      string                      `json:"service"`
+	Dependencies map[string]DependencyHealth `json:"dependencies"`
+}
+
+// dependencyChecks returns the checks GET /health/details runs: the database, and Redis
+// when an address is configured.
+func dependencyChecks(db storage.Database, redisAddr string) map[string]dependencyCheck {
+	checks := map[string]dependencyCheck{}
+	if db != nil {
+		checks["database"] = func(ctx context.Context) error { return db.Pool().Ping(ctx) }
+	}
+	if redisAddr != "" {
+		rdb := redis.NewClient(&redis.Options{Addr: redisAddr})
+		checks["redis"] = func(ctx context.Context) error { return rdb.Ping(ctx).Err() }
+	}
+	return checks
+}
+
+// addProviderCheck reports vendor under its capability when the vendor can check its own
+// health. Vendors that cannot, such as the log mailer, are left out.
+func (s *Server) addProviderCheck(capability provider.Capability, vendor any) {
+	if check, ok := provider.HealthCheck(vendor); ok {
+		s.healthChecks[string(capability)] = check
+	}
+}
+
+// healthDetails handles GET /health/details, reporting each dependency with its error and
+// latency. Errors name hosts and ports, so the route is internal (see internalroute).
+// It answers 503 when any dependency is down.
+func (s *Server) healthDetails(c echo.Context) error {
+	names := make([]string, 0, len(s.healthChecks))
+	for name := range s.healthChecks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	resp := HealthDetails{Status: "ok", Service: "real-staging-api", Dependencies: map[string]DependencyHealth{}}
+	code := http.StatusOK
+	for _, name := range names {
+		ctx, cancel := context.WithTimeout(c.Request().Context(), healthCheckTimeout)
+		start := time.Now()
+		err := s.healthChecks[name](ctx)
+		cancel()
+
+		dep := DependencyHealth{Status: "ok", LatencyMs: time.Since(start).Milliseconds()}
+		if err != nil {
+			dep.Status, dep.Error = "down", err.Error()
+			resp.Status, code = "degraded", http.StatusServiceUnavailable
+		}
+		resp.Dependencies[name] = dep
+	}
+	return c.JSON(code, resp)
+}
@@ -1,7 +1,9 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -31,3 +33,54 @@ func TestServer_HealthCheck(t *testing.T) {
 	assert.Equal(t, "ok", response["status"])
 	assert.Equal(t, "real-staging-api", response["service"])
 }
+
+func TestServer_HealthDetails(t *testing.T) {
+	cases := []struct {
+		name       string
+		checks     map[string]dependencyCheck
+		wantCode   int
+		wantStatus string
+		wantDeps   map[string]string
+	}{
+		{
+			name: "success: every dependency is up",
+			checks: map[string]dependencyCheck{
+				"database": func(ctx context.Context) error { return nil },
+				"redis":    func(ctx context.Context) error { return nil },
+			},
+			wantCode:   http.StatusOK,
+			wantStatus: "ok",
+			wantDeps:   map[string]string{"database": "ok", "redis": "ok"},
+		},
+		{
+			name: "fail: a dependency is down",
+			checks: map[string]dependencyCheck{
+				"database": func(ctx context.Context) error { return nil },
+				"redis":    func(ctx context.Context) error { return errors.New("dial tcp: connection refused") },
+			},
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: "degraded",
+			wantDeps:   map[string]string{"database": "ok", "redis": "down"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			server := &Server{healthChecks: tc.checks}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/health/details", nil), rec)
+
+			require.NoError(t, server.healthDetails(c))
+			assert.Equal(t, tc.wantCode, rec.Code)
+
+			var resp HealthDetails
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, tc.wantStatus, resp.Status)
+			for name, want := range tc.wantDeps {
+				assert.Equal(t, want, resp.Dependencies[name].Status, name)
+				assert.Equal(t, want == "down", resp.Dependencies[name].Error != "", name)
+			}
+		})
+	}
+}
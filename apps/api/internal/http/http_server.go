@@ -0,0 +1,31 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+// NewHTTPServer builds the http.Server the API listens with, applying the timeouts and
+// HTTP/2 limits from cfg. Handlers that stream (SSE) clear their own deadlines, so
+// WriteTimeout and ReadTimeout only bound ordinary request/response exchanges.
+func NewHTTPServer(cfg config.HTTP, h http.Handler) *http.Server {
+	srv := &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           h,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		HTTP2: &http.HTTP2Config{
+			MaxConcurrentStreams: cfg.MaxConcurrentStreams,
+		},
+	}
+	if cfg.H2C {
+		srv.Protocols = new(http.Protocols)
+		srv.Protocols.SetHTTP1(true)
+		srv.Protocols.SetHTTP2(true)
+		srv.Protocols.SetUnencryptedHTTP2(true)
+	}
+	return srv
+}
@@ -0,0 +1,113 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/sse"
+)
+
+func TestNewHTTPServer(t *testing.T) {
+	cfg := config.HTTP{
+		Addr:                 ":9090",
+		IdleTimeout:          2 * time.Minute,
+		MaxConcurrentStreams: 500,
+		ReadHeaderTimeout:    5 * time.Second,
+		ReadTimeout:          30 * time.Second,
+		WriteTimeout:         45 * time.Second,
+	}
+
+	srv := NewHTTPServer(cfg, http.NotFoundHandler())
+	assert.Equal(t, ":9090", srv.Addr)
+	assert.Equal(t, 5*time.Second, srv.ReadHeaderTimeout)
+	assert.Equal(t, 30*time.Second, srv.ReadTimeout)
+	assert.Equal(t, 45*time.Second, srv.WriteTimeout)
+	assert.Equal(t, 2*time.Minute, srv.IdleTimeout)
+	assert.Equal(t, 500, srv.HTTP2.MaxConcurrentStreams)
+	assert.Nil(t, srv.Protocols)
+
+	cfg.H2C = true
+	srv = NewHTTPServer(cfg, http.NotFoundHandler())
+	require.NotNil(t, srv.Protocols)
+	assert.True(t, srv.Protocols.HTTP1())
+	assert.True(t, srv.Protocols.UnencryptedHTTP2())
+}
+
+// streamEvents writes n events spaced by interval, flushing each one.
+func streamEvents(w io.Writer, n int, interval time.Duration) error {
+	for i := 0; i < n; i++ {
+		if _, err := fmt.Fprintf(w, "event: heartbeat\ndata: {\"n\":%d}\n\n", i); err != nil {
+			return err
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+		time.Sleep(interval)
+	}
+	return nil
+}
+
+func TestNewHTTPServer_StreamsOutliveTimeouts(t *testing.T) {
+	const events = 8
+	interval := 50 * time.Millisecond
+
+	e := echo.New()
+	streamer := &sse.SSEMock{
+		StreamImageFunc: func(ctx context.Context, w io.Writer, imageID, lastEventID string) error {
+			return streamEvents(w, events, interval)
+		},
+	}
+	e.GET("/events", sse.NewDefaultHandler(streamer).Events)
+	e.GET("/slow", func(c echo.Context) error {
+		c.Response().Header().Set(echo.HeaderContentType, "text/event-stream")
+		return streamEvents(c.Response(), events, interval)
+	})
+
+	// Timeouts well below the stream's 400ms lifetime.
+	srv := NewHTTPServer(config.HTTP{
+		ReadTimeout:  100 * time.Millisecond,
+		WriteTimeout: 100 * time.Millisecond,
+	}, e)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = srv.Serve(ln) }()
+	t.Cleanup(func() { _ = srv.Close() })
+
+	testCases := []struct {
+		name       string
+		path       string
+		wantEvents int
+		wantErr    bool
+	}{
+		{name: "success: SSE handler clears deadlines", path: "/events?image_id=img-1", wantEvents: events},
+		{name: "fail: ordinary handler is cut off by the write timeout", path: "/slow", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := http.Get("http://" + ln.Addr().String() + tc.path)
+			require.NoError(t, err)
+			defer func() { _ = res.Body.Close() }()
+
+			body, err := io.ReadAll(res.Body)
+			if tc.wantErr {
+				assert.Error(t, err)
+				assert.Less(t, strings.Count(string(body), "event: heartbeat"), events)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantEvents, strings.Count(string(body), "event: heartbeat"))
+		})
+	}
+}
@@ -1,10 +1,8 @@
 package http
 
 import (
-	"errors"
 	"net/http"
 
-	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
 
 	"github.com/real-staging-ai/api/internal/auth"
@@ -44,16 +42,9 @@ func (h *ProfileHandler) GetProfile(c echo.Context) error {
 	}
 
 	// Ensure user exists (create if missing)
-	if _, err := h.userRepo.GetByAuth0Sub(ctx, auth0Sub); err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			if _, createErr := h.userRepo.Create(ctx, auth0Sub, "", "user"); createErr != nil {
-				h.log.Error(ctx, "failed to create user", "error", createErr, "auth0_sub", auth0Sub)
-				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to resolve user")
-			}
-		} else {
-			h.log.Error(ctx, "failed to get user by auth0 sub", "error", err, "auth0_sub", auth0Sub)
-			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to resolve user")
-		}
+	if _, err := user.ResolveID(c, h.userRepo); err != nil {
+		h.log.Error(ctx, "failed to resolve user", "error", err, "auth0_sub", auth0Sub)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to resolve user")
 	}
 
 	// Get profile by Auth0 subject
@@ -78,16 +69,9 @@ func (h *ProfileHandler) UpdateProfile(c echo.Context) error {
 	}
 
 	// Ensure user exists (create if missing)
-	if _, err := h.userRepo.GetByAuth0Sub(ctx, auth0Sub); err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			if _, createErr := h.userRepo.Create(ctx, auth0Sub, "", "user"); createErr != nil {
-				h.log.Error(ctx, "failed to create user", "error", createErr, "auth0_sub", auth0Sub)
-				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to resolve user")
-			}
-		} else {
-			h.log.Error(ctx, "failed to get user by auth0 sub", "error", err, "auth0_sub", auth0Sub)
-			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to resolve user")
-		}
+	if _, err := user.ResolveID(c, h.userRepo); err != nil {
+		h.log.Error(ctx, "failed to resolve user", "error", err, "auth0_sub", auth0Sub)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to resolve user")
 	}
 
 	// Get current profile to obtain user ID
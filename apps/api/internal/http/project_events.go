@@ -0,0 +1,56 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/sse"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// projectEventsHandler streams the status transitions of a project's images over SSE to
+// the project's owner and collaborators. Anyone else is told the project does not exist.
+func (s *Server) projectEventsHandler(cfg sse.Config) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		projectID := c.Param("id")
+		if _, err := uuid.Parse(projectID); err != nil {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "bad_request",
+				Message: "Invalid project ID format",
+			})
+		}
+
+		userID, err := user.ResolveID(c, user.NewDefaultRepository(s.db))
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, ErrorResponse{
+				Error:   "unauthorized",
+				Message: "Invalid or missing JWT token",
+			})
+		}
+		repo := project.NewDefaultRepository(s.db)
+		if _, err := repo.GetProjectForMember(c.Request().Context(), projectID, userID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return c.JSON(http.StatusNotFound, ErrorResponse{
+					Error:   "not_found",
+					Message: "Project not found",
+				})
+			}
+			return c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "internal_server_error",
+				Message: "Failed to retrieve project",
+			})
+		}
+
+		h, err := sse.NewDefaultHandlerFromConfig(cfg)
+		if err != nil {
+			return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "pubsub not configured"})
+		}
+		defer func() { _ = h.Close() }()
+		return h.ProjectEvents(c)
+	}
+}
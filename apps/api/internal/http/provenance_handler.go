@@ -0,0 +1,52 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/provenance"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// imageProvenanceHandler handles GET /api/v1/images/:id/provenance.
+// It reads the staged output and verifies its embedded Content Credentials.
+func (s *Server) imageProvenanceHandler(c echo.Context) error {
+	imageID := c.Param("id")
+	if imageID == "" {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "image id is required"})
+	}
+
+	ctx := c.Request().Context()
+	img, err := s.imageService.GetImageByID(ctx, imageID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "image not found"})
+	}
+	if img.StagedURL == nil || *img.StagedURL == "" {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "image has no staged_url"})
+	}
+
+	files, fileKey, err := s.buckets.ForURL(ctx, *img.StagedURL)
+	if errors.Is(err, storage.ErrInvalidObjectURL) {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "invalid stored URL"})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError,
+			ErrorResponse{Error: "internal_server_error", Message: "failed to resolve storage"})
+	}
+
+	data, err := files.GetFile(ctx, fileKey)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError,
+			ErrorResponse{Error: "internal_server_error", Message: "failed to read staged image"})
+	}
+
+	report, err := provenance.Verify(data)
+	if err != nil {
+		// Outputs staged before credentials were embedded may not be JPEGs.
+		return c.JSON(http.StatusOK, &provenance.Report{Errors: []string{err.Error()}})
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,111 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/image"
+	"github.com/real-staging-ai/api/internal/provenance"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+func TestServer_ImageProvenanceHandler(t *testing.T) {
+	staged, err := os.ReadFile("../provenance/testdata/staged_c2pa.jpg")
+	require.NoError(t, err)
+	stagedURL := "s3://real-staging/staged/aaaaaaaa/aaaaaaaa-staged.jpg"
+
+	testCases := []struct {
+		name            string
+		getImage        func(ctx context.Context, imageID string) (*image.Image, error)
+		getFile         func(ctx context.Context, fileKey string) ([]byte, error)
+		expectedStatus  int
+		wantCredentials bool
+		wantValid       bool
+	}{
+		{
+			name: "success: verified credentials",
+			getImage: func(ctx context.Context, imageID string) (*image.Image, error) {
+				return &image.Image{StagedURL: &stagedURL}, nil
+			},
+			getFile: func(ctx context.Context, fileKey string) ([]byte, error) {
+				assert.Equal(t, "staged/aaaaaaaa/aaaaaaaa-staged.jpg", fileKey)
+				return staged, nil
+			},
+			expectedStatus:  http.StatusOK,
+			wantCredentials: true,
+			wantValid:       true,
+		},
+		{
+			name: "success: staged output is not a jpeg",
+			getImage: func(ctx context.Context, imageID string) (*image.Image, error) {
+				return &image.Image{StagedURL: &stagedURL}, nil
+			},
+			getFile: func(ctx context.Context, fileKey string) ([]byte, error) {
+				return []byte("RIFF....WEBP"), nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "fail: image not found",
+			getImage: func(ctx context.Context, imageID string) (*image.Image, error) {
+				return nil, errors.New("not found")
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "fail: image not staged yet",
+			getImage: func(ctx context.Context, imageID string) (*image.Image, error) {
+				return &image.Image{}, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "fail: storage error",
+			getImage: func(ctx context.Context, imageID string) (*image.Image, error) {
+				return &image.Image{StagedURL: &stagedURL}, nil
+			},
+			getFile: func(ctx context.Context, fileKey string) ([]byte, error) {
+				return nil, errors.New("s3 unavailable")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := &Server{
+				imageService: &image.ServiceMock{GetImageByIDFunc: tc.getImage},
+				buckets: storage.NewPlatformBuckets(&storage.S3ServiceMock{
+					BucketNameFunc: func() string { return "real-staging" },
+					GetFileFunc:    tc.getFile,
+				}),
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/images/aaaaaaaa/provenance", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("aaaaaaaa-0000-0000-0000-000000000000")
+
+			require.NoError(t, server.imageProvenanceHandler(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var report provenance.Report
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+				assert.Equal(t, tc.wantCredentials, report.HasCredentials)
+				assert.Equal(t, tc.wantValid, report.Valid)
+			}
+		})
+	}
+}
@@ -3,7 +3,6 @@ package http
 import (
 	"context"
 	"errors"
-	"os"
 
 	redis "github.com/redis/go-redis/v9"
 )
@@ -13,11 +12,10 @@ type PubSub interface {
 	Subscribe(ctx context.Context, channel string) (<-chan []byte, func() error, error)
 }
 
-// NewDefaultPubSubFromEnv creates a Redis-backed PubSub if REDIS_ADDR is set.
-func NewDefaultPubSubFromEnv() (PubSub, error) {
-	addr := os.Getenv("REDIS_ADDR")
+// NewDefaultPubSub creates a Redis-backed PubSub connected to addr.
+func NewDefaultPubSub(addr string) (PubSub, error) {
 	if addr == "" {
-		return nil, errors.New("REDIS_ADDR not set")
+		return nil, errors.New("redis address is not configured")
 	}
 	rdb := redis.NewClient(&redis.Options{Addr: addr})
 	return &redisPubSub{rdb: rdb}, nil
@@ -0,0 +1,30 @@
+package http
+
+import "github.com/real-staging-ai/api/internal/auth"
+
+// apiScopeRules assigns authenticated routes to scope resources by route template. Reads
+// need <resource>:read, deletes <resource>:delete where the resource has it, and anything
+// else <resource>:write. Routes left out, or listed without a resource, cannot be used with
+// API keys or scoped tokens; add new routes here to open them.
+var apiScopeRules = []auth.ScopeRule{
+	{Prefix: "/api/v1/projects", Resource: "projects"},
+	{Prefix: "/api/v1/invitations", Resource: "projects"},
+	{Prefix: "/api/v1/project-templates", Resource: "projects"},
+	{Prefix: "/api/v1/projects/:project_id/images", Resource: "images"},
+	{Prefix: "/api/v1/projects/:project_id/images/bulk-delete", Resource: "images", Action: auth.ActionDelete},
+	{Prefix: "/api/v1/projects/:project_id/share-links", Resource: "images"},
+	{Prefix: "/api/v1/images", Resource: "images"},
+	{Prefix: "/api/v1/uploads", Resource: "images"},
+	{Prefix: "/api/v1/jobs", Resource: "images"},
+	{Prefix: "/api/v1/events", Resource: "images"},
+	{Prefix: "/api/v1/catalogs", Resource: "images"},
+	{Prefix: "/img/", Resource: "images"},
+	{Prefix: "/api/v1/billing", Resource: "billing"},
+	{Prefix: "/api/v1/analytics", Resource: "account"},
+	{Prefix: "/api/v1/user", Resource: "account"},
+	{Prefix: "/api/v1/me", Resource: "account"},
+	{Prefix: "/api/v1/me/api-keys"},
+	{Prefix: "/api/v1/organizations", Resource: "organizations"},
+	{Prefix: "/api/v1/sso", Resource: "organizations"},
+	{Prefix: "/api/v1/admin", Resource: "admin"},
+}
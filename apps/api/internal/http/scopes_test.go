@@ -0,0 +1,57 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/real-staging-ai/api/internal/auth"
+)
+
+func TestAPIScopeRules(t *testing.T) {
+	testCases := []struct {
+		method   string
+		path     string
+		expected string
+	}{
+		{http.MethodGet, "/api/v1/projects", "projects:read"},
+		{http.MethodPost, "/api/v1/projects", "projects:write"},
+		{http.MethodDelete, "/api/v1/projects/:id", "projects:delete"},
+		{http.MethodPost, "/api/v1/projects/:id/invite", "projects:write"},
+		{http.MethodGet, "/api/v1/projects/:project_id/images", "images:read"},
+		{http.MethodPost, "/api/v1/projects/:project_id/images/bulk-delete", "images:delete"},
+		{http.MethodPost, "/api/v1/images", "images:write"},
+		{http.MethodPost, "/api/v1/uploads/presign", "images:write"},
+		{http.MethodGet, "/img/:id", "images:read"},
+		{http.MethodGet, "/api/v1/billing/invoices", "billing:read"},
+		{http.MethodDelete, "/api/v1/me/watermark", "account:write"},
+		{http.MethodPut, "/api/v1/organizations/:id/ip-allowlist", "organizations:write"},
+		{http.MethodGet, "/api/v1/admin/jobs", "admin:read"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			scope, ok := auth.RequiredScope(apiScopeRules, tc.method, tc.path)
+			assert.True(t, ok)
+			assert.Equal(t, tc.expected, scope)
+		})
+	}
+
+	_, ok := auth.RequiredScope(apiScopeRules, http.MethodPost, "/api/v1/me/api-keys")
+	assert.False(t, ok, "keys must not manage keys")
+}
+
+func TestAPIScopeRules_MLSIntegration(t *testing.T) {
+	scopes := []string{"projects:read", "projects:write", "images:write"}
+	allowed := func(method, path string) bool {
+		required, ok := auth.RequiredScope(apiScopeRules, method, path)
+		return ok && auth.Grants(scopes, required)
+	}
+
+	assert.True(t, allowed(http.MethodPost, "/api/v1/images"))
+	assert.True(t, allowed(http.MethodPost, "/api/v1/images/batch"))
+	assert.False(t, allowed(http.MethodDelete, "/api/v1/projects/:id"))
+	assert.False(t, allowed(http.MethodGet, "/api/v1/billing/subscriptions"))
+	assert.False(t, allowed(http.MethodPost, "/api/v1/billing/link"))
+}
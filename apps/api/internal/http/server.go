@@ -4,55 +4,112 @@ package http
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
 
+	"github.com/real-staging-ai/api/internal/analytics"
+	"github.com/real-staging-ai/api/internal/apikey"
 	"github.com/real-staging-ai/api/internal/auth"
 	"github.com/real-staging-ai/api/internal/billing"
+	"github.com/real-staging-ai/api/internal/byobucket"
+	"github.com/real-staging-ai/api/internal/catalog"
+	"github.com/real-staging-ai/api/internal/compression"
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/dataaccess"
+	"github.com/real-staging-ai/api/internal/deadletter"
+	"github.com/real-staging-ai/api/internal/entitlement"
+	"github.com/real-staging-ai/api/internal/geocode"
 	"github.com/real-staging-ai/api/internal/image"
+	"github.com/real-staging-ai/api/internal/imageedit"
+	"github.com/real-staging-ai/api/internal/imgproxy"
+	"github.com/real-staging-ai/api/internal/internalroute"
+	"github.com/real-staging-ai/api/internal/invitation"
+	"github.com/real-staging-ai/api/internal/ipallowlist"
+	"github.com/real-staging-ai/api/internal/job"
 	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/mailer"
+	"github.com/real-staging-ai/api/internal/notification"
+	"github.com/real-staging-ai/api/internal/organization"
+	"github.com/real-staging-ai/api/internal/overview"
+	"github.com/real-staging-ai/api/internal/preset"
 	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/projectexport"
+	"github.com/real-staging-ai/api/internal/provider"
+	"github.com/real-staging-ai/api/internal/querystats"
+	"github.com/real-staging-ai/api/internal/queue"
 	"github.com/real-staging-ai/api/internal/reconcile"
+	"github.com/real-staging-ai/api/internal/residency"
+	"github.com/real-staging-ai/api/internal/roomgroup"
+	"github.com/real-staging-ai/api/internal/scim"
 	"github.com/real-staging-ai/api/internal/settings"
+	"github.com/real-staging-ai/api/internal/sharebrand"
+	"github.com/real-staging-ai/api/internal/sharelink"
+	"github.com/real-staging-ai/api/internal/slo"
+	"github.com/real-staging-ai/api/internal/sms"
 	"github.com/real-staging-ai/api/internal/sse"
+	"github.com/real-staging-ai/api/internal/status"
 	"github.com/real-staging-ai/api/internal/storage"
 	"github.com/real-staging-ai/api/internal/stripe"
+	"github.com/real-staging-ai/api/internal/teamwebhook"
+	"github.com/real-staging-ai/api/internal/telemetry"
+	"github.com/real-staging-ai/api/internal/usage"
 	"github.com/real-staging-ai/api/internal/user"
+	"github.com/real-staging-ai/api/internal/watermark"
+	"github.com/real-staging-ai/api/internal/webhookarchive"
 	webdocs "github.com/real-staging-ai/api/web"
 )
 
 // Server holds the dependencies for the HTTP server.
 type Server struct {
-	ctx       context.Context
-	echo      *echo.Echo
-	db        storage.Database
-	s3Service storage.S3Service
+	ctx     context.Context
+	echo    *echo.Echo
+	db      storage.Database
+	buckets storage.Buckets
 
 	imageService image.Service
 	authConfig   *auth.Auth0Config
 	pubsub       PubSub
+	healthChecks map[string]dependencyCheck
 }
 
-// NewServer creates and configures a new Echo server.
+// NewServer creates and configures a new Echo server from the loaded application config.
 func NewServer(
-	auth0Audience string,
-	auth0Domain string,
 	ctx context.Context,
+	cfg *config.Config,
 	db storage.Database,
 	imageService image.Service,
-	s3Service storage.S3Service,
+	buckets storage.Buckets,
+	archive webhookarchive.Service,
+	entitlements entitlement.Service,
 ) *Server {
 	e := echo.New()
 
 	// Add OpenTelemetry middleware
 	e.Use(otelecho.Middleware("real-staging-api"))
+	// Summarize each request's DB queries and S3 operations on its span for tail sampling
+	e.Use(telemetry.UsageMiddleware())
+
+	// Every routed request counts towards its route's SLO; burn rates are exported as metrics
+	sloService := slo.NewDefaultService(cfg.SLO)
+	if cfg.SLO.Enabled {
+		e.Use(slo.Middleware(sloService))
+		if err := slo.RegisterMetrics(sloService); err != nil {
+			logging.Default().Warn(ctx, "SLO metrics unavailable", "error", err)
+		}
+	}
+
+	// Internal routes, such as detailed health, need a token or an allowed network and get no CORS headers
+	guard := newInternalRouteGuard(ctx, cfg.InternalRoutes)
 
 	// Add other middleware
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
+	e.Use(guard.Middleware())
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+		Skipper:      guard.CORSSkipper(),
 		AllowOrigins: []string{"http://localhost:3000", "http://localhost:3001"},
 		AllowMethods: []string{
 			http.MethodGet, http.MethodHead, http.MethodPut,
@@ -60,75 +117,172 @@ func NewServer(
 		},
 		AllowHeaders: []string{
 			echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization,
+			image.HeaderSource, "ECT", "Downlink", "Save-Data",
 		},
 	}))
 
 	// Initialize Auth0 config
-	authConfig := auth.NewAuth0Config(ctx, auth0Domain, auth0Audience)
+	authConfig := auth.NewAuth0Config(ctx, cfg.Auth0.Domain, cfg.Auth0.Audience)
 
-	imgHandler := image.NewDefaultHandler(imageService)
+	imgHandler := image.NewDefaultHandler(imageService, user.NewDefaultRepository(db), project.NewDefaultRepository(db))
 
 	// Initialize Pub/Sub (Redis) if configured
 	var ps PubSub
-	if p, err := NewDefaultPubSubFromEnv(); err == nil {
+	if p, err := NewDefaultPubSub(cfg.Redis.Addr); err == nil {
 		ps = p
 	}
 
+	// Queue depth is left out of the admin overview and public status when Redis is not configured
+	var depthReader queue.DepthReader
+	if r, err := queue.NewAsynqDepthReaderFromConfig(cfg); err == nil {
+		depthReader = r
+	}
+
 	s := &Server{
-		ctx: ctx, db: db, s3Service: s3Service, imageService: imageService, echo: e, authConfig: authConfig, pubsub: ps,
+		ctx: ctx, db: db, buckets: buckets, imageService: imageService, echo: e, authConfig: authConfig, pubsub: ps,
+		healthChecks: dependencyChecks(db, cfg.Redis.Addr),
 	}
 
-	// Health check route
+	// Health check routes; the detailed one is internal by default
 	e.GET("/health", s.healthCheck)
+	e.GET("/health/details", s.healthDetails)
+
+	// Resized images, outside /api/v1 so <img> tags can use them with an access_token query param
+	imgCache, err := imgproxy.NewCache(cfg.ImageProxy, cfg.Redis.Addr)
+	if err != nil {
+		logging.Default().Warn(ctx, "image proxy cache unavailable, using memory cache", "error", err)
+		imgCache = imgproxy.NewMemoryCache(cfg.ImageProxy.CacheMaxBytes)
+	}
+	imgProxy := imgproxy.NewDefaultHandler(
+		imgproxy.NewDefaultService(imageService, project.NewDefaultRepository(db), buckets, imgCache, cfg.ImageProxy),
+		user.NewDefaultRepository(db), cfg.ImageProxy.CacheTTL)
+	// Organization IP allow-lists apply to every authenticated route except break-glass,
+	// which a locked-out admin must still reach
+	ipService := ipallowlist.NewDefaultService(s.db, cfg.IPAllowlist)
+	ipGuard := ipallowlist.Middleware(ipService, cfg.IPAllowlist, breakGlassPath)
+	// API keys stand in for access tokens; both are held to the scopes they carry
+	keyService := apikey.NewDefaultService(s.db, apiKeyOptions(cfg)...)
+	authn := apikey.Middleware(keyService, auth.JWTMiddleware(s.authConfig))
+	scopeGuard := auth.RequireScopes(apiScopeRules)
+	e.GET("/img/:id", imgProxy.GetImage, authn, scopeGuard, ipGuard)
+
+	// Notification center; new notifications are pushed to open streams through Redis
+	notifyBroker := newNotificationBroker(cfg.Redis.Addr)
+	notifyService := notification.NewDefaultService(s.db, notifyBroker)
+	// Team notifications posted to the account's Slack and Teams incoming webhooks
+	teamHooks := teamwebhook.NewDefaultService(s.db)
 
 	// Register routes
 	api := e.Group("/api/v1")
 
 	// Public routes (no authentication required)
 	api.POST("/stripe/webhook", func(c echo.Context) error {
-		sh := stripe.NewDefaultHandler(s.db)
+		sh := stripe.NewDefaultHandler(s.db, cfg.Stripe, cfg.App,
+			stripe.WithArchive(archive), stripe.WithNotifier(notifyService), stripe.WithTeamNotifier(teamHooks),
+			stripe.WithEntitlements(entitlements))
 		return sh.Webhook(c)
 	})
 
-	// Protected routes (require JWT authentication)
-	protected := api.Group("")
-	protected.Use(auth.JWTMiddleware(s.authConfig))
+	// Coarse component health for the public status page, cached by clients and CDNs
+	statusHandler := status.NewDefaultHandler(status.NewDefaultService(s.db, depthReader))
+	api.GET("/status", statusHandler.GetStatus)
+
+	// Compression for routes that return large JSON bodies. SSE and binary
+	// responses are skipped by content type, so only list endpoints opt in.
+	compress := compression.Middleware(compression.FromConfig(cfg.Compression))
 
-	// Project routes
-	ph := project.NewDefaultHandler(s.db)
+	// Protected routes (require a JWT or an API key)
+	protected := api.Group("")
+	protected.Use(authn)
+	protected.Use(scopeGuard)
+	protected.Use(ipGuard)
+
+	// Project routes; listing addresses are geocoded when a provider is configured
+	geocoder := newGeocoder(ctx, cfg.Geocoding)
+	s.addProviderCheck(provider.CapabilityGeocoding, geocoder)
+	ph := project.NewDefaultHandler(s.db, s.buckets, geocoder)
 	protected.POST("/projects", ph.Create)
-	protected.GET("/projects", ph.List)
+	protected.GET("/projects", ph.List, compress)
 	protected.GET("/projects/:id", ph.GetByID)
 	protected.DELETE("/projects/:id", ph.Delete)
+	protected.GET("/projects/:project_id/activity", ph.Activity, compress)
+	protected.GET("/projects/:project_id/summary", ph.Summary)
+	protected.POST("/projects/summaries", ph.Summaries)
+	protected.GET("/projects/:project_id/retention", ph.GetRetention)
+	protected.PUT("/projects/:project_id/retention", ph.UpdateRetention)
+	protected.GET("/projects/:project_id/disclosure", ph.GetDisclosure)
+	protected.PUT("/projects/:project_id/disclosure", ph.UpdateDisclosure)
+	protected.GET("/projects/:project_id/listing", ph.GetListing)
+	protected.PUT("/projects/:project_id/listing", ph.UpdateListing)
+	protected.GET("/projects/:project_id/rooms", ph.Rooms)
+	protected.PUT("/projects/:project_id/rooms", ph.UpdateRooms)
+	protected.POST("/projects/:project_id/template", ph.SaveTemplate)
+	protected.GET("/project-templates", ph.ListTemplates)
+	protected.GET("/project-templates/:template_id", ph.GetTemplate)
+	protected.DELETE("/project-templates/:template_id", ph.DeleteTemplate)
 
 	// Upload routes
 	protected.POST("/uploads/presign", s.presignUploadHandler)
+	protected.POST("/uploads/multipart", s.multipartUploadHandler)
+	protected.POST("/uploads/multipart/complete", s.completeMultipartUploadHandler)
+	protected.POST("/uploads/multipart/abort", s.abortMultipartUploadHandler)
+	protected.POST("/uploads/negotiate", s.negotiateUploadHandler)
 
 	// Image routes
 	protected.POST("/images", imgHandler.CreateImage)
 	protected.POST("/images/batch", imgHandler.BatchCreateImages)
 	protected.GET("/images/:id", imgHandler.GetImage)
 	protected.GET("/images/:id/presign", s.presignImageDownloadHandler)
+	protected.GET("/images/:id/provenance", s.imageProvenanceHandler)
 	protected.DELETE("/images/:id", imgHandler.DeleteImage)
-	protected.GET("/projects/:project_id/images", imgHandler.GetProjectImages)
+	protected.POST("/images/:id/cancel", imgHandler.CancelImage)
+	protected.GET("/images/:id/history", imgHandler.GetImageStatusHistory)
+	protected.POST("/images/:id/expedite", imgHandler.ExpediteImage)
+	editHandler := imageedit.NewDefaultHandler(
+		imageedit.NewDefaultService(cfg, s.db, s.buckets, entitlements), user.NewDefaultRepository(s.db))
+	protected.POST("/images/:id/edits", editHandler.Create)
+	protected.GET("/images/:id/edits/:edit_id", editHandler.Get)
+	protected.GET("/projects/:project_id/images", imgHandler.GetProjectImages, compress)
 	protected.GET("/projects/:project_id/cost", imgHandler.GetProjectCost)
+	protected.POST("/projects/:project_id/images/bulk-cancel", imgHandler.BulkCancelImages)
+	protected.POST("/projects/:project_id/images/bulk-delete", imgHandler.BulkDeleteImages)
+
+	// Job routes
+	jobHandler := job.NewDefaultHandler(
+		job.NewDefaultService(job.NewDefaultRepository(s.db)), user.NewDefaultRepository(s.db))
+	protected.GET("/jobs/:id/log", jobHandler.GetJobLog)
 
 	// SSE routes
+	sseConfig := sse.Config{SubscribeTimeout: 2 * time.Second, RedisAddr: cfg.Redis.Addr}
 	protected.GET("/events", func(c echo.Context) error {
-		cfg := sse.Config{
-			SubscribeTimeout: 2000000000,
-		}
-		h, err := sse.NewDefaultHandlerFromEnv(cfg)
+		h, err := sse.NewDefaultHandlerFromConfig(sseConfig)
 		if err != nil {
 			return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "pubsub not configured"})
 		}
+		defer func() { _ = h.Close() }()
 		return h.Events(c)
 	})
+	protected.GET("/projects/:id/events", s.projectEventsHandler(sseConfig))
 
 	// Billing routes
-	bh := billing.NewDefaultHandler(s.db)
-	protected.GET("/billing/subscriptions", bh.GetMySubscriptions)
-	protected.GET("/billing/invoices", bh.GetMyInvoices)
+	var billingOpts []billing.HandlerOption
+	if cfg.Stripe.SecretKey != "" {
+		billingOpts = append(billingOpts,
+			billing.WithLinker(billing.NewDefaultLinker(s.db, stripe.NewDefaultClient(cfg.Stripe.SecretKey),
+				stripe.WithEntitlements(entitlements))))
+	}
+	bh := billing.NewDefaultHandler(s.db, billingOpts...)
+	protected.GET("/billing/subscriptions", bh.GetMySubscriptions, compress)
+	protected.GET("/billing/invoices", bh.GetMyInvoices, compress)
+	protected.POST("/billing/link", bh.LinkStripeCustomer)
+
+	// Analytics routes
+	analyticsHandler := analytics.NewDefaultHandler(analytics.NewDefaultService(s.db), user.NewDefaultRepository(s.db))
+	protected.GET("/analytics/me", analyticsHandler.GetMyAnalytics, compress)
+
+	// Catalog routes
+	catalogHandler := catalog.NewDefaultHandler(catalog.NewDefaultService(s.db))
+	protected.GET("/catalogs", catalogHandler.List)
 
 	// User profile routes
 	userRepo := user.NewDefaultRepository(s.db)
@@ -137,22 +291,174 @@ func NewServer(
 	protected.GET("/user/profile", profileHandler.GetProfile)
 	protected.PATCH("/user/profile", profileHandler.UpdateProfile)
 
+	// Preset routes
+	presetHandler := preset.NewDefaultHandler(preset.NewDefaultService(s.db), userRepo)
+	protected.GET("/me/presets", presetHandler.List)
+	protected.POST("/me/presets", presetHandler.Create)
+	protected.PUT("/me/presets/:id", presetHandler.Update)
+	protected.DELETE("/me/presets/:id", presetHandler.Delete)
+
+	// Notification routes
+	notifyHandler := notification.NewDefaultHandler(notifyService, notifyBroker, userRepo)
+	protected.GET("/me/notifications", notifyHandler.List, compress)
+	protected.GET("/me/notifications/unread-count", notifyHandler.UnreadCount)
+	protected.GET("/me/notifications/stream", notifyHandler.Stream)
+	protected.POST("/me/notifications/read-all", notifyHandler.MarkAllRead)
+	protected.POST("/me/notifications/:id/read", notifyHandler.MarkRead)
+
+	// Team webhook routes: one Slack and one Teams incoming webhook per account
+	teamHookHandler := teamwebhook.NewDefaultHandler(teamHooks, userRepo)
+	protected.GET("/me/integrations/webhooks", teamHookHandler.List)
+	protected.PUT("/me/integrations/webhooks/:provider", teamHookHandler.Put)
+	protected.DELETE("/me/integrations/webhooks/:provider", teamHookHandler.Delete)
+	protected.POST("/me/integrations/webhooks/:provider/test", teamHookHandler.Test)
+	protected.POST("/me/integrations/webhooks/:provider/rotate-secret", teamHookHandler.RotateSecret)
+	protected.GET("/me/integrations/webhooks/:provider/deliveries", teamHookHandler.ListDeliveries)
+	protected.POST("/me/integrations/webhooks/:provider/deliveries/:id/redeliver", teamHookHandler.Redeliver)
+
+	// API keys for integrations; no scope opens these routes, so keys cannot mint keys
+	keyHandler := apikey.NewDefaultHandler(keyService, userRepo)
+	protected.GET("/me/api-keys", keyHandler.List)
+	protected.POST("/me/api-keys", keyHandler.Create)
+	protected.DELETE("/me/api-keys/:id", keyHandler.Revoke)
+
+	// Customer-managed storage routes; a nil checker means the feature is disabled
+	checker, _ := s.buckets.(byobucket.Checker)
+	storageHandler := byobucket.NewDefaultHandler(byobucket.NewDefaultService(s.db, checker), userRepo)
+	protected.GET("/me/storage", storageHandler.Get)
+	protected.PUT("/me/storage", storageHandler.Put)
+	protected.DELETE("/me/storage", storageHandler.Delete)
+	protected.POST("/me/storage/verify", storageHandler.Verify)
+
+	// Account watermark routes: the logo rendered onto share links and staged downloads
+	watermarkHandler := watermark.NewDefaultHandler(watermark.NewDefaultService(s.db, s.buckets), userRepo)
+	protected.GET("/me/watermark", watermarkHandler.Get)
+	protected.PUT("/me/watermark", watermarkHandler.Put)
+	protected.DELETE("/me/watermark", watermarkHandler.Delete)
+
+	// Support reads of the user's data through the admin API, each recorded with its ticket
+	dataAccessHandler := dataaccess.NewDefaultHandler(dataaccess.NewDefaultService(s.db, s.imageService), userRepo,
+		ipallowlist.NewIPExtractor(cfg.IPAllowlist))
+	protected.GET("/me/data-access-log", dataAccessHandler.List)
+
+	// Images staged this billing period against the plan's monthly limit
+	usageHandler := usage.NewDefaultHandler(usage.NewDefaultService(s.db, entitlements, cfg.Usage), userRepo)
+	protected.GET("/me/usage", usageHandler.Get)
+
+	// Share page branding; verified custom domains serve share pages only. The proxy issuing
+	// their certificates asks the internal domain check first
+	brandService := sharebrand.NewDefaultService(s.db, s.buckets, cfg.ShareLinks)
+	brandHandler := sharebrand.NewDefaultHandler(brandService, userRepo)
+	e.Use(sharebrand.HostRouter(brandService, cfg.ShareLinks))
+	protected.GET("/me/share-branding", brandHandler.Get)
+	protected.PUT("/me/share-branding", brandHandler.Put)
+	protected.DELETE("/me/share-branding", brandHandler.Delete)
+	protected.POST("/me/share-branding/domain/verify", brandHandler.VerifyDomain)
+	e.GET("/share-domains/check", brandHandler.CheckDomain)
+
+	// Share links: issued and revoked by the owner, resolved without signing in
+	shareService := sharelink.NewDefaultService(s.db, s.buckets, cfg.ShareLinks,
+		sharelink.WithNotifier(notifyService), sharelink.WithBranding(brandService))
+	shareHandler := sharelink.NewDefaultHandler(shareService, userRepo)
+	protected.POST("/images/:id/share-links", shareHandler.CreateImageLink)
+	protected.POST("/projects/:project_id/share-links/revoke", shareHandler.Revoke)
+	e.GET("/share/images/:id", shareHandler.Resolve)
+	e.GET("/share/images/:id/view", shareHandler.Page)
+
+	// Room groupings: a worker proposes rooms from a project's uploads, the owner confirms them
+	groupHandler := roomgroup.NewDefaultHandler(roomgroup.NewDefaultService(cfg, s.db), userRepo)
+	protected.POST("/projects/:project_id/room-groupings", groupHandler.Create)
+	protected.GET("/projects/:project_id/room-groupings/latest", groupHandler.Latest)
+	protected.GET("/projects/:project_id/room-groupings/:grouping_id", groupHandler.Get)
+	protected.POST("/projects/:project_id/room-groupings/:grouping_id/confirm", groupHandler.Confirm)
+
+	// Project exports: a worker zips the staged photos; export credits unlock watermark-free zips
+	exportHandler := projectexport.NewDefaultHandler(
+		projectexport.NewDefaultService(cfg, s.db, s.buckets, entitlements), userRepo)
+	protected.POST("/projects/:project_id/exports", exportHandler.Create)
+	protected.GET("/projects/:project_id/exports/:export_id", exportHandler.Get)
+	protected.GET("/me/export-credits", exportHandler.Credits)
+	protected.POST("/me/export-credits/checkout", exportHandler.Checkout)
+
+	// Project invitations: sent, listed and revoked by the owner, accepted by the invitee
+	mail := newMailer(ctx, cfg.SMTP)
+	s.addProviderCheck(provider.CapabilityEmail, mail)
+	// Nothing sends text messages yet; the sender is built so bad settings show up in health
+	s.addProviderCheck(provider.CapabilitySMS, newSMSSender(ctx, cfg.SMS))
+	inviteService := invitation.NewDefaultService(s.db, mail, cfg.Invitations)
+	inviteHandler := invitation.NewDefaultHandler(inviteService, userRepo)
+	protected.POST("/projects/:id/invite", inviteHandler.Invite)
+	protected.GET("/projects/:id/invitations", inviteHandler.List)
+	protected.DELETE("/projects/:id/invitations/:invitation_id", inviteHandler.Revoke)
+	protected.POST("/invitations/:id/accept", inviteHandler.Accept)
+
+	// Organizations and their single sign-on; the login page discovers an email's connection
+	// before signing in, and the web app provisions the user into the organization after
+	orgHandler := organization.NewDefaultHandler(organization.NewDefaultService(s.db), userRepo, cfg.SSO)
+	protected.POST("/organizations", orgHandler.Create)
+	protected.GET("/organizations", orgHandler.List)
+	protected.GET("/organizations/:id/members", orgHandler.ListMembers)
+	protected.GET("/organizations/:id/sso", orgHandler.GetSSO)
+	protected.PUT("/organizations/:id/sso", orgHandler.PutSSO)
+	protected.DELETE("/organizations/:id/sso", orgHandler.DeleteSSO)
+	protected.POST("/organizations/:id/scim-token", orgHandler.IssueSCIMToken)
+	protected.DELETE("/organizations/:id/scim-token", orgHandler.RevokeSCIMToken)
+	api.GET("/sso/discover", orgHandler.Discover)
+	protected.POST("/sso/provision", orgHandler.Provision)
+
+	// IP allow-lists, break-glass and the organization audit log
+	ipHandler := ipallowlist.NewDefaultHandler(ipService, userRepo, cfg.IPAllowlist)
+	protected.GET("/organizations/:id/ip-allowlist", ipHandler.Get)
+	protected.PUT("/organizations/:id/ip-allowlist", ipHandler.Put)
+	protected.POST("/organizations/:id/ip-allowlist/break-glass", ipHandler.BreakGlass)
+	protected.GET("/organizations/:id/audit-log", ipHandler.ListAudit)
+
+	// SCIM 2.0 provisioning for identity providers, authenticated by the organization's token
+	registerSCIMRoutes(e, scim.NewDefaultHandler(scim.NewDefaultService(s.db)))
+
 	// Admin routes (feature-flagged)
 	admin := protected.Group("/admin")
-	reconcileSvc := reconcile.NewDefaultService(s.db, s.s3Service)
-	reconcileHandler := reconcile.NewDefaultHandler(reconcileSvc)
+	reconcileSvc := reconcile.NewDefaultService(s.db, s.buckets)
+	reconcileHandler := reconcile.NewDefaultHandler(reconcileSvc, cfg.Reconcile)
 	admin.POST("/reconcile/images", reconcileHandler.ReconcileImages)
+	admin.GET("/analytics", analyticsHandler.GetGlobalAnalytics, compress)
+	overviewHandler := overview.NewDefaultHandler(overview.NewDefaultService(s.db, depthReader))
+	admin.GET("/overview", overviewHandler.GetOverview)
+	queryStatsHandler := querystats.NewDefaultHandler(querystats.NewDefaultService(s.db))
+	admin.GET("/db/queries", queryStatsHandler.GetTopQueries)
+	admin.GET("/slo", slo.NewDefaultHandler(sloService).GetReport)
+	residencyHandler := residency.NewDefaultHandler(residency.NewDefaultService(s.db, cfg.S3))
+	admin.GET("/users/:id/storage-region", residencyHandler.Get)
+	admin.PUT("/users/:id/storage-region", residencyHandler.Put)
+	admin.GET("/catalogs", catalogHandler.AdminList)
+	admin.POST("/catalogs", catalogHandler.Create)
+	admin.PUT("/catalogs/:id", catalogHandler.Update)
+	admin.DELETE("/catalogs/:id", catalogHandler.Delete)
+	webhookReplay := stripe.NewDefaultHandler(s.db, cfg.Stripe, cfg.App,
+		stripe.WithArchive(archive), stripe.WithNotifier(notifyService), stripe.WithTeamNotifier(teamHooks),
+		stripe.WithEntitlements(entitlements))
+	admin.POST("/webhooks/stripe/:event_id/replay", webhookReplay.Replay)
+	deadLetterHandler := deadletter.NewDefaultHandler(deadletter.NewDefaultService(cfg, s.db))
+	admin.GET("/dead-letters", deadLetterHandler.List, compress)
+	admin.POST("/dead-letters/:id/requeue", deadLetterHandler.Requeue)
+	admin.GET("/projects/:id", dataAccessHandler.ViewProject)
 
 	// Admin settings routes
-	settingsRepo := settings.NewDefaultRepository(s.db.Pool())
+	settingsRepo := settings.NewDefaultRepository(s.db)
 	settingsService := settings.NewDefaultService(settingsRepo)
 	adminHandler := NewAdminHandler(settingsService, s.db, logging.Default())
 	admin.GET("/models", adminHandler.ListModels)
 	admin.GET("/models/active", adminHandler.GetActiveModel)
 	admin.PUT("/models/active", adminHandler.UpdateActiveModel)
-	admin.GET("/settings", adminHandler.ListSettings)
+	admin.GET("/settings", adminHandler.ListSettings, compress)
 	admin.GET("/settings/:key", adminHandler.GetSetting)
 	admin.PUT("/settings/:key", adminHandler.UpdateSetting)
+	admin.GET("/users", adminHandler.ListUsers, compress)
+	admin.GET("/jobs", adminHandler.ListJobs, compress)
+	admin.GET("/jobs/:id", adminHandler.GetJob)
+	admin.GET("/legal-holds", adminHandler.ListLegalHolds, compress)
+	admin.PUT("/projects/:id/legal-hold", adminHandler.UpdateProjectLegalHold)
+	admin.PUT("/images/:id/legal-hold", adminHandler.UpdateImageLegalHold)
 
 	// Serve API documentation (embedded)
 	webdocs.RegisterRoutes(e)
@@ -160,66 +466,188 @@ func NewServer(
 	return s
 }
 
+// newMailer returns the configured mailer, or one that logs emails when the settings are invalid.
+func newMailer(ctx context.Context, cfg config.SMTP) mailer.Mailer {
+	m, err := mailer.New(cfg)
+	if err != nil {
+		logging.Default().Error(ctx, "Failed to initialize mailer, logging emails instead", "error", err)
+		return mailer.NewLogMailer(logging.Default())
+	}
+	return m
+}
+
+// newSMSSender returns the configured SMS sender, or one that logs messages when the settings
+// are invalid.
+func newSMSSender(ctx context.Context, cfg config.SMS) sms.Sender {
+	s, err := sms.New(cfg)
+	if err != nil {
+		logging.Default().Error(ctx, "Failed to initialize SMS sender, logging messages instead", "error", err)
+		return sms.NewLogSender(logging.Default())
+	}
+	return s
+}
+
+// newNotificationBroker returns a Redis notification broker, or nil when Redis is not
+// configured: notifications are still recorded but not pushed to open streams.
+func newNotificationBroker(addr string) notification.Broker {
+	b, err := notification.NewRedisBroker(addr)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// apiKeyOptions returns the API key service options the configuration calls for: sandbox
+// keys can only be created while the sandbox tenant is enabled.
+func apiKeyOptions(cfg *config.Config) []apikey.Option {
+	if cfg.SandboxTenant.Enabled {
+		return []apikey.Option{apikey.WithSandboxKeys()}
+	}
+	return nil
+}
+
 // NewTestServer creates a new Echo server for testing without Auth0 middleware.
+// Settings come from environment variables and defaults, see config.FromEnv.
 func NewTestServer(db storage.Database, s3Service storage.S3Service, imageService image.Service) *Server {
+	cfg, err := config.FromEnv()
+	if err != nil {
+		logging.Default().Warn(context.Background(), "invalid config environment, using zero config", "error", err)
+		cfg = &config.Config{}
+	}
+
 	e := echo.New()
 
+	sloService := slo.NewDefaultService(cfg.SLO)
+	if cfg.SLO.Enabled {
+		e.Use(slo.Middleware(sloService))
+	}
+
+	guard := newInternalRouteGuard(context.Background(), cfg.InternalRoutes)
+
 	// Add basic middleware (no Auth0 for testing)
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
-	e.Use(middleware.CORS())
+	e.Use(guard.Middleware())
+	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{Skipper: guard.CORSSkipper()}))
 
-	imgHandler := image.NewDefaultHandler(imageService)
+	imgHandler := image.NewDefaultHandler(imageService, user.NewDefaultRepository(db), project.NewDefaultRepository(db))
 
-	s := &Server{db: db, s3Service: s3Service, imageService: imageService, echo: e, authConfig: nil}
+	s := &Server{
+		db: db, buckets: storage.NewPlatformBuckets(s3Service), imageService: imageService, echo: e, authConfig: nil,
+		healthChecks: dependencyChecks(db, cfg.Redis.Addr),
+	}
 
-	// Health check route (same as main server)
+	// Health check routes (same as main server)
 	e.GET("/health", s.healthCheck)
+	e.GET("/health/details", s.healthDetails)
+
+	imgProxy := imgproxy.NewDefaultHandler(
+		imgproxy.NewDefaultService(imageService, project.NewDefaultRepository(db), s.buckets,
+			imgproxy.NewMemoryCache(cfg.ImageProxy.CacheMaxBytes), cfg.ImageProxy),
+		user.NewDefaultRepository(db), cfg.ImageProxy.CacheTTL)
+	e.GET("/img/:id", imgProxy.GetImage)
 
 	// Register routes without authentication
 	api := e.Group("/api/v1")
+	compress := compression.Middleware(compression.FromConfig(cfg.Compression))
+
+	notifyBroker := newNotificationBroker(cfg.Redis.Addr)
+	notifyService := notification.NewDefaultService(s.db, notifyBroker)
+	teamHooks := teamwebhook.NewDefaultService(s.db)
 
 	// All routes are public for testing
 	api.POST("/stripe/webhook", func(c echo.Context) error {
-		sh := stripe.NewDefaultHandler(s.db)
+		sh := stripe.NewDefaultHandler(s.db, cfg.Stripe, cfg.App,
+			stripe.WithNotifier(notifyService), stripe.WithTeamNotifier(teamHooks))
 		return sh.Webhook(c)
 	})
 
+	statusHandler := status.NewDefaultHandler(status.NewDefaultService(s.db, nil))
+	api.GET("/status", statusHandler.GetStatus)
+
 	// Project routes (no auth required for testing)
-	ph := project.NewDefaultHandler(s.db)
+	ph := project.NewDefaultHandler(s.db, s.buckets, nil)
 	api.POST("/projects", withTestUser(ph.Create))
-	api.GET("/projects", withTestUser(ph.List))
+	api.GET("/projects", withTestUser(ph.List), compress)
 	api.GET("/projects/:id", withTestUser(ph.GetByID))
 	api.PUT("/projects/:id", withTestUser(ph.Update))
 	api.DELETE("/projects/:id", withTestUser(ph.Delete))
+	api.GET("/projects/:project_id/activity", withTestUser(ph.Activity), compress)
+	api.GET("/projects/:project_id/summary", withTestUser(ph.Summary))
+	api.POST("/projects/summaries", withTestUser(ph.Summaries))
+	api.GET("/projects/:project_id/retention", withTestUser(ph.GetRetention))
+	api.PUT("/projects/:project_id/retention", withTestUser(ph.UpdateRetention))
+	api.GET("/projects/:project_id/disclosure", withTestUser(ph.GetDisclosure))
+	api.PUT("/projects/:project_id/disclosure", withTestUser(ph.UpdateDisclosure))
+	api.GET("/projects/:project_id/listing", withTestUser(ph.GetListing))
+	api.PUT("/projects/:project_id/listing", withTestUser(ph.UpdateListing))
+	api.GET("/projects/:project_id/rooms", withTestUser(ph.Rooms))
+	api.PUT("/projects/:project_id/rooms", withTestUser(ph.UpdateRooms))
+	api.POST("/projects/:project_id/template", withTestUser(ph.SaveTemplate))
+	api.GET("/project-templates", withTestUser(ph.ListTemplates))
+	api.GET("/project-templates/:template_id", withTestUser(ph.GetTemplate))
+	api.DELETE("/project-templates/:template_id", withTestUser(ph.DeleteTemplate))
 
 	// Upload routes
 	api.POST("/uploads/presign", s.presignUploadHandler)
+	api.POST("/uploads/multipart", s.multipartUploadHandler)
+	api.POST("/uploads/multipart/complete", s.completeMultipartUploadHandler)
+	api.POST("/uploads/multipart/abort", s.abortMultipartUploadHandler)
+	api.POST("/uploads/negotiate", s.negotiateUploadHandler)
 
 	// Image routes
 	api.POST("/images", imgHandler.CreateImage)
 	api.GET("/images/:id", imgHandler.GetImage)
 	api.GET("/images/:id/presign", s.presignImageDownloadHandler)
+	api.GET("/images/:id/provenance", s.imageProvenanceHandler)
 	api.DELETE("/images/:id", imgHandler.DeleteImage)
-	api.GET("/projects/:project_id/images", imgHandler.GetProjectImages)
+	api.POST("/images/:id/cancel", imgHandler.CancelImage)
+	api.GET("/images/:id/history", imgHandler.GetImageStatusHistory)
+	api.POST("/images/:id/expedite", imgHandler.ExpediteImage)
+	editHandler := imageedit.NewDefaultHandler(
+		imageedit.NewDefaultService(cfg, s.db, s.buckets, nil), user.NewDefaultRepository(s.db))
+	api.POST("/images/:id/edits", editHandler.Create)
+	api.GET("/images/:id/edits/:edit_id", editHandler.Get)
+	api.GET("/projects/:project_id/images", imgHandler.GetProjectImages, compress)
 	api.GET("/projects/:project_id/cost", imgHandler.GetProjectCost)
+	api.POST("/projects/:project_id/images/bulk-cancel", imgHandler.BulkCancelImages)
+	api.POST("/projects/:project_id/images/bulk-delete", imgHandler.BulkDeleteImages)
+
+	// Job routes
+	jobHandler := job.NewDefaultHandler(
+		job.NewDefaultService(job.NewDefaultRepository(s.db)), user.NewDefaultRepository(s.db))
+	api.GET("/jobs/:id/log", jobHandler.GetJobLog)
 
 	// SSE routes
+	sseConfig := sse.Config{SubscribeTimeout: 2 * time.Second, RedisAddr: cfg.Redis.Addr}
 	api.GET("/events", func(c echo.Context) error {
-		cfg := sse.Config{
-			SubscribeTimeout: 2000000000,
-		}
-		h, err := sse.NewDefaultHandlerFromEnv(cfg)
+		h, err := sse.NewDefaultHandlerFromConfig(sseConfig)
 		if err != nil {
 			return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "pubsub not configured"})
 		}
+		defer func() { _ = h.Close() }()
 		return h.Events(c)
 	})
+	api.GET("/projects/:id/events", s.projectEventsHandler(sseConfig))
 
 	// Billing routes (public in test server)
-	bh := billing.NewDefaultHandler(s.db)
-	api.GET("/billing/subscriptions", withTestUser(bh.GetMySubscriptions))
-	api.GET("/billing/invoices", withTestUser(bh.GetMyInvoices))
+	var billingOpts []billing.HandlerOption
+	if cfg.Stripe.SecretKey != "" {
+		billingOpts = append(billingOpts,
+			billing.WithLinker(billing.NewDefaultLinker(s.db, stripe.NewDefaultClient(cfg.Stripe.SecretKey))))
+	}
+	bh := billing.NewDefaultHandler(s.db, billingOpts...)
+	api.GET("/billing/subscriptions", withTestUser(bh.GetMySubscriptions), compress)
+	api.GET("/billing/invoices", withTestUser(bh.GetMyInvoices), compress)
+	api.POST("/billing/link", withTestUser(bh.LinkStripeCustomer))
+
+	// Analytics routes (test server)
+	analyticsHandler := analytics.NewDefaultHandler(analytics.NewDefaultService(s.db), user.NewDefaultRepository(s.db))
+	api.GET("/analytics/me", withTestUser(analyticsHandler.GetMyAnalytics), compress)
+
+	// Catalog routes (test server)
+	catalogHandler := catalog.NewDefaultHandler(catalog.NewDefaultService(s.db))
+	api.GET("/catalogs", catalogHandler.List)
 
 	// User profile routes (test server)
 	userRepo := user.NewDefaultRepository(s.db)
@@ -228,22 +656,163 @@ func NewTestServer(db storage.Database, s3Service storage.S3Service, imageServic
 	api.GET("/user/profile", withTestUser(profileHandler.GetProfile))
 	api.PATCH("/user/profile", withTestUser(profileHandler.UpdateProfile))
 
+	// Preset routes (test server)
+	presetHandler := preset.NewDefaultHandler(preset.NewDefaultService(s.db), userRepo)
+	api.GET("/me/presets", withTestUser(presetHandler.List))
+	api.POST("/me/presets", withTestUser(presetHandler.Create))
+	api.PUT("/me/presets/:id", withTestUser(presetHandler.Update))
+	api.DELETE("/me/presets/:id", withTestUser(presetHandler.Delete))
+
+	// Notification routes (test server)
+	notifyHandler := notification.NewDefaultHandler(notifyService, notifyBroker, userRepo)
+	api.GET("/me/notifications", withTestUser(notifyHandler.List), compress)
+	api.GET("/me/notifications/unread-count", withTestUser(notifyHandler.UnreadCount))
+	api.GET("/me/notifications/stream", withTestUser(notifyHandler.Stream))
+	api.POST("/me/notifications/read-all", withTestUser(notifyHandler.MarkAllRead))
+	api.POST("/me/notifications/:id/read", withTestUser(notifyHandler.MarkRead))
+
+	// Team webhook routes (test server)
+	teamHookHandler := teamwebhook.NewDefaultHandler(teamHooks, userRepo)
+	api.GET("/me/integrations/webhooks", withTestUser(teamHookHandler.List))
+	api.PUT("/me/integrations/webhooks/:provider", withTestUser(teamHookHandler.Put))
+	api.DELETE("/me/integrations/webhooks/:provider", withTestUser(teamHookHandler.Delete))
+	api.POST("/me/integrations/webhooks/:provider/test", withTestUser(teamHookHandler.Test))
+	api.POST("/me/integrations/webhooks/:provider/rotate-secret", withTestUser(teamHookHandler.RotateSecret))
+	api.GET("/me/integrations/webhooks/:provider/deliveries", withTestUser(teamHookHandler.ListDeliveries))
+	api.POST("/me/integrations/webhooks/:provider/deliveries/:id/redeliver", withTestUser(teamHookHandler.Redeliver))
+
+	// API key routes (test server)
+	keyHandler := apikey.NewDefaultHandler(apikey.NewDefaultService(s.db, apiKeyOptions(cfg)...), userRepo)
+	api.GET("/me/api-keys", withTestUser(keyHandler.List))
+	api.POST("/me/api-keys", withTestUser(keyHandler.Create))
+	api.DELETE("/me/api-keys/:id", withTestUser(keyHandler.Revoke))
+
+	// Customer-managed storage routes; always disabled with platform buckets
+	storageHandler := byobucket.NewDefaultHandler(byobucket.NewDefaultService(s.db, nil), userRepo)
+	api.GET("/me/storage", withTestUser(storageHandler.Get))
+	api.PUT("/me/storage", withTestUser(storageHandler.Put))
+	api.DELETE("/me/storage", withTestUser(storageHandler.Delete))
+	api.POST("/me/storage/verify", withTestUser(storageHandler.Verify))
+
+	// Account watermark routes (test server)
+	watermarkHandler := watermark.NewDefaultHandler(watermark.NewDefaultService(s.db, s.buckets), userRepo)
+	api.GET("/me/watermark", withTestUser(watermarkHandler.Get))
+	api.PUT("/me/watermark", withTestUser(watermarkHandler.Put))
+	api.DELETE("/me/watermark", withTestUser(watermarkHandler.Delete))
+
+	// Data access log routes (test server)
+	dataAccessHandler := dataaccess.NewDefaultHandler(dataaccess.NewDefaultService(s.db, s.imageService), userRepo,
+		ipallowlist.NewIPExtractor(cfg.IPAllowlist))
+	api.GET("/me/data-access-log", withTestUser(dataAccessHandler.List))
+
+	// Usage routes (test server)
+	usageHandler := usage.NewDefaultHandler(usage.NewDefaultService(s.db, nil, cfg.Usage), userRepo)
+	api.GET("/me/usage", withTestUser(usageHandler.Get))
+
+	// Share branding routes (test server)
+	brandService := sharebrand.NewDefaultService(s.db, s.buckets, cfg.ShareLinks)
+	brandHandler := sharebrand.NewDefaultHandler(brandService, userRepo)
+	e.Use(sharebrand.HostRouter(brandService, cfg.ShareLinks))
+	api.GET("/me/share-branding", withTestUser(brandHandler.Get))
+	api.PUT("/me/share-branding", withTestUser(brandHandler.Put))
+	api.DELETE("/me/share-branding", withTestUser(brandHandler.Delete))
+	api.POST("/me/share-branding/domain/verify", withTestUser(brandHandler.VerifyDomain))
+	e.GET("/share-domains/check", brandHandler.CheckDomain)
+
+	// Share link routes (test server)
+	shareService := sharelink.NewDefaultService(s.db, s.buckets, cfg.ShareLinks,
+		sharelink.WithNotifier(notifyService), sharelink.WithBranding(brandService))
+	shareHandler := sharelink.NewDefaultHandler(shareService, userRepo)
+	api.POST("/images/:id/share-links", withTestUser(shareHandler.CreateImageLink))
+	api.POST("/projects/:project_id/share-links/revoke", withTestUser(shareHandler.Revoke))
+	e.GET("/share/images/:id", shareHandler.Resolve)
+	e.GET("/share/images/:id/view", shareHandler.Page)
+
+	// Room grouping routes (test server)
+	groupHandler := roomgroup.NewDefaultHandler(roomgroup.NewDefaultService(cfg, s.db), userRepo)
+	api.POST("/projects/:project_id/room-groupings", withTestUser(groupHandler.Create))
+	api.GET("/projects/:project_id/room-groupings/latest", withTestUser(groupHandler.Latest))
+	api.GET("/projects/:project_id/room-groupings/:grouping_id", withTestUser(groupHandler.Get))
+	api.POST("/projects/:project_id/room-groupings/:grouping_id/confirm", withTestUser(groupHandler.Confirm))
+
+	// Project export routes (test server); every project exports without the mark
+	exportHandler := projectexport.NewDefaultHandler(
+		projectexport.NewDefaultService(cfg, s.db, s.buckets, nil), userRepo)
+	api.POST("/projects/:project_id/exports", withTestUser(exportHandler.Create))
+	api.GET("/projects/:project_id/exports/:export_id", withTestUser(exportHandler.Get))
+	api.GET("/me/export-credits", withTestUser(exportHandler.Credits))
+	api.POST("/me/export-credits/checkout", withTestUser(exportHandler.Checkout))
+
+	// Project invitation routes (test server); emails are logged
+	inviteService := invitation.NewDefaultService(s.db, mailer.NewLogMailer(logging.Default()), cfg.Invitations)
+	inviteHandler := invitation.NewDefaultHandler(inviteService, userRepo)
+	api.POST("/projects/:id/invite", withTestUser(inviteHandler.Invite))
+	api.GET("/projects/:id/invitations", withTestUser(inviteHandler.List))
+	api.DELETE("/projects/:id/invitations/:invitation_id", withTestUser(inviteHandler.Revoke))
+	api.POST("/invitations/:id/accept", withTestUser(inviteHandler.Accept))
+
+	// Organization and SSO routes (test server); provisioning needs a token with SSO claims
+	orgHandler := organization.NewDefaultHandler(organization.NewDefaultService(s.db), userRepo, cfg.SSO)
+	api.POST("/organizations", withTestUser(orgHandler.Create))
+	api.GET("/organizations", withTestUser(orgHandler.List))
+	api.GET("/organizations/:id/members", withTestUser(orgHandler.ListMembers))
+	api.GET("/organizations/:id/sso", withTestUser(orgHandler.GetSSO))
+	api.PUT("/organizations/:id/sso", withTestUser(orgHandler.PutSSO))
+	api.DELETE("/organizations/:id/sso", withTestUser(orgHandler.DeleteSSO))
+	api.POST("/organizations/:id/scim-token", withTestUser(orgHandler.IssueSCIMToken))
+	api.DELETE("/organizations/:id/scim-token", withTestUser(orgHandler.RevokeSCIMToken))
+	api.GET("/sso/discover", orgHandler.Discover)
+	api.POST("/sso/provision", withTestUser(orgHandler.Provision))
+	ipHandler := ipallowlist.NewDefaultHandler(
+		ipallowlist.NewDefaultService(s.db, cfg.IPAllowlist), userRepo, cfg.IPAllowlist)
+	api.GET("/organizations/:id/ip-allowlist", withTestUser(ipHandler.Get))
+	api.PUT("/organizations/:id/ip-allowlist", withTestUser(ipHandler.Put))
+	api.POST("/organizations/:id/ip-allowlist/break-glass", withTestUser(ipHandler.BreakGlass))
+	api.GET("/organizations/:id/audit-log", withTestUser(ipHandler.ListAudit))
+	registerSCIMRoutes(e, scim.NewDefaultHandler(scim.NewDefaultService(s.db)))
+
 	// Admin routes (public in test server, feature-flagged)
 	admin := api.Group("/admin")
-	reconcileSvc := reconcile.NewDefaultService(s.db, s.s3Service)
-	reconcileHandler := reconcile.NewDefaultHandler(reconcileSvc)
+	reconcileSvc := reconcile.NewDefaultService(s.db, s.buckets)
+	reconcileHandler := reconcile.NewDefaultHandler(reconcileSvc, cfg.Reconcile)
 	admin.POST("/reconcile/images", reconcileHandler.ReconcileImages)
+	admin.GET("/analytics", analyticsHandler.GetGlobalAnalytics, compress)
+	overviewHandler := overview.NewDefaultHandler(overview.NewDefaultService(s.db, nil))
+	admin.GET("/overview", overviewHandler.GetOverview)
+	queryStatsHandler := querystats.NewDefaultHandler(querystats.NewDefaultService(s.db))
+	admin.GET("/db/queries", queryStatsHandler.GetTopQueries)
+	admin.GET("/slo", slo.NewDefaultHandler(sloService).GetReport)
+	residencyHandler := residency.NewDefaultHandler(residency.NewDefaultService(s.db, cfg.S3))
+	admin.GET("/users/:id/storage-region", residencyHandler.Get)
+	admin.PUT("/users/:id/storage-region", residencyHandler.Put)
+	admin.GET("/catalogs", catalogHandler.AdminList)
+	admin.POST("/catalogs", catalogHandler.Create)
+	admin.PUT("/catalogs/:id", catalogHandler.Update)
+	admin.DELETE("/catalogs/:id", catalogHandler.Delete)
+	webhookReplay := stripe.NewDefaultHandler(s.db, cfg.Stripe, cfg.App,
+		stripe.WithNotifier(notifyService), stripe.WithTeamNotifier(teamHooks))
+	admin.POST("/webhooks/stripe/:event_id/replay", webhookReplay.Replay)
+	deadLetterHandler := deadletter.NewDefaultHandler(deadletter.NewDefaultService(cfg, s.db))
+	admin.GET("/dead-letters", deadLetterHandler.List, compress)
+	admin.POST("/dead-letters/:id/requeue", deadLetterHandler.Requeue)
+	admin.GET("/projects/:id", withTestUser(dataAccessHandler.ViewProject))
 
 	// Admin settings routes (test server)
-	settingsRepo := settings.NewDefaultRepository(s.db.Pool())
+	settingsRepo := settings.NewDefaultRepository(s.db)
 	settingsService := settings.NewDefaultService(settingsRepo)
 	adminHandler := NewAdminHandler(settingsService, s.db, logging.Default())
 	admin.GET("/models", withTestUser(adminHandler.ListModels))
 	admin.GET("/models/active", withTestUser(adminHandler.GetActiveModel))
 	admin.PUT("/models/active", withTestUser(adminHandler.UpdateActiveModel))
-	admin.GET("/settings", withTestUser(adminHandler.ListSettings))
+	admin.GET("/settings", withTestUser(adminHandler.ListSettings), compress)
 	admin.GET("/settings/:key", withTestUser(adminHandler.GetSetting))
 	admin.PUT("/settings/:key", withTestUser(adminHandler.UpdateSetting))
+	admin.GET("/users", withTestUser(adminHandler.ListUsers), compress)
+	admin.GET("/jobs", withTestUser(adminHandler.ListJobs), compress)
+	admin.GET("/jobs/:id", withTestUser(adminHandler.GetJob))
+	admin.GET("/legal-holds", withTestUser(adminHandler.ListLegalHolds), compress)
+	admin.PUT("/projects/:id/legal-hold", withTestUser(adminHandler.UpdateProjectLegalHold))
+	admin.PUT("/images/:id/legal-hold", withTestUser(adminHandler.UpdateImageLegalHold))
 
 	// Serve API documentation (embedded)
 	webdocs.RegisterRoutes(e)
@@ -251,11 +820,16 @@ func NewTestServer(db storage.Database, s3Service storage.S3Service, imageServic
 	return s
 }
 
-// Start starts the HTTP server.
+// Start starts the HTTP server with Go's default timeouts.
 func (s *Server) Start(addr string) error {
 	return s.echo.Start(addr)
 }
 
+// StartWithConfig starts the HTTP server using the timeouts and HTTP/2 settings in cfg.
+func (s *Server) StartWithConfig(cfg config.HTTP) error {
+	return s.echo.StartServer(NewHTTPServer(cfg, s.echo))
+}
+
 // ServeHTTP implements the http.Handler interface.
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	s.echo.ServeHTTP(w, r)
@@ -269,6 +843,50 @@ func (s *Server) healthCheck(c echo.Context) error {
 	})
 }
 
+// newInternalRouteGuard builds the internal route guard from cfg. Validate rejects the
+// settings New fails on, but should they get through, internal routes are closed instead.
+// breakGlassPath is the one authenticated route IP allow-lists do not apply to.
+const breakGlassPath = "/api/v1/organizations/:id/ip-allowlist/break-glass"
+
+// registerSCIMRoutes registers the SCIM endpoints, which authenticate with an organization's
+// SCIM token rather than a user's JWT.
+func registerSCIMRoutes(e *echo.Echo, h scim.Handler) {
+	g := e.Group("/scim/v2", h.Authenticate)
+	g.GET("/ServiceProviderConfig", h.ServiceProviderConfig)
+	g.GET("/Users", h.ListUsers)
+	g.POST("/Users", h.CreateUser)
+	g.GET("/Users/:id", h.GetUser)
+	g.PUT("/Users/:id", h.ReplaceUser)
+	g.PATCH("/Users/:id", h.PatchUser)
+	g.DELETE("/Users/:id", h.DeleteUser)
+	g.GET("/Groups", h.ListGroups)
+	g.POST("/Groups", h.CreateGroup)
+	g.GET("/Groups/:id", h.GetGroup)
+	g.PUT("/Groups/:id", h.ReplaceGroup)
+	g.PATCH("/Groups/:id", h.PatchGroup)
+	g.DELETE("/Groups/:id", h.DeleteGroup)
+}
+
+func newInternalRouteGuard(ctx context.Context, cfg config.InternalRoutes) *internalroute.Guard {
+	guard, err := internalroute.New(cfg)
+	if err != nil {
+		logging.Default().Error(ctx, "invalid internal route settings, closing internal routes", "error", err)
+		guard, _ = internalroute.New(config.InternalRoutes{Paths: cfg.Paths})
+	}
+	return guard
+}
+
+// newGeocoder returns the configured geocoder. Unknown providers disable geocoding rather
+// than the API, since listings are saved without a location anyway.
+func newGeocoder(ctx context.Context, cfg config.Geocoding) geocode.Geocoder {
+	g, err := geocode.New(cfg)
+	if err != nil {
+		logging.Default().Warn(ctx, "invalid geocoding settings, geocoding disabled", "error", err)
+		return nil
+	}
+	return g
+}
+
 // withTestUser ensures an X-Test-User header is present for test-only servers.
 // It defaults to the seeded test user to keep integration tests deterministic.
 func withTestUser(h echo.HandlerFunc) echo.HandlerFunc {
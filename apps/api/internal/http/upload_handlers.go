@@ -1,16 +1,13 @@
 package http
 
 import (
-	"errors"
 	"fmt"
 	"net/http"
 	"path/filepath"
 	"strings"
 
-	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
 
-	"github.com/real-staging-ai/api/internal/auth"
 	"github.com/real-staging-ai/api/internal/storage"
 	"github.com/real-staging-ai/api/internal/user"
 )
@@ -64,8 +61,7 @@ func (s *Server) presignUploadHandler(c echo.Context) error {
 		})
 	}
 
-	// Get user ID from JWT token (or default in tests), ensure user exists
-	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	userID, err := user.ResolveID(c, user.NewDefaultRepository(s.db))
 	if err != nil {
 		return c.JSON(http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
@@ -73,32 +69,15 @@ func (s *Server) presignUploadHandler(c echo.Context) error {
 		})
 	}
 
-	userRepo := user.NewDefaultRepository(s.db)
-	existingUser, err := userRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
-	var userID string
+	// Uploads go to the user's own bucket when they have verified customer-managed storage
+	files, err := s.buckets.ForUser(c.Request().Context(), userID)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			// User not found, create a new one
-			newUser, err := userRepo.Create(c.Request().Context(), auth0Sub, "", "user")
-			if err != nil {
-				return c.JSON(http.StatusInternalServerError, ErrorResponse{
-					Error:   "internal_server_error",
-					Message: "Failed to create user",
-				})
-			}
-			userID = newUser.ID.String()
-		} else {
-			return c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to get user",
-			})
-		}
-	} else {
-		userID = existingUser.ID.String()
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to resolve storage",
+		})
 	}
-
-	// Generate presigned upload URL using injected S3 service
-	result, err := s.s3Service.GeneratePresignedUploadURL(
+	result, err := files.GeneratePresignedUploadURL(
 		c.Request().Context(),
 		userID,
 		req.Filename,
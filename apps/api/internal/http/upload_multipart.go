@@ -0,0 +1,256 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// MultipartUploadRequest starts a multipart upload of a RAW camera file.
+type MultipartUploadRequest struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	FileSize    int64  `json:"file_size"`
+}
+
+// CompleteMultipartUploadRequest assembles the parts of a multipart upload.
+type CompleteMultipartUploadRequest struct {
+	FileKey  string                  `json:"file_key"`
+	UploadID string                  `json:"upload_id"`
+	Parts    []storage.CompletedPart `json:"parts"`
+}
+
+// AbortMultipartUploadRequest discards a multipart upload.
+type AbortMultipartUploadRequest struct {
+	FileKey  string `json:"file_key"`
+	UploadID string `json:"upload_id"`
+}
+
+// CompleteMultipartUploadResponse is the uploaded file, ready to create an image from.
+type CompleteMultipartUploadResponse struct {
+	FileKey string `json:"file_key"`
+}
+
+// multipartUploadHandler starts a multipart upload of a RAW camera file (CR3, NEF or ARW),
+// which are too large for a single presigned PUT, and presigns a URL for each part.
+func (s *Server) multipartUploadHandler(c echo.Context) error {
+	var req MultipartUploadRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid request format",
+		})
+	}
+	if validationErrs := validateMultipartUploadRequest(&req); len(validationErrs) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
+			Error:            "validation_failed",
+			Message:          "The provided data is invalid",
+			ValidationErrors: validationErrs,
+		})
+	}
+
+	userID, err := user.ResolveID(c, user.NewDefaultRepository(s.db))
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid or missing JWT token",
+		})
+	}
+	files, err := s.buckets.ForUser(c.Request().Context(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to resolve storage",
+		})
+	}
+	result, err := files.CreateMultipartUpload(
+		c.Request().Context(), userID, strings.TrimSpace(req.Filename), req.ContentType, req.FileSize,
+	)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: fmt.Sprintf("Failed to start multipart upload: %v", err),
+		})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// completeMultipartUploadHandler assembles the uploaded parts of a multipart upload into
+// the file, which images can then be created from like any other upload.
+func (s *Server) completeMultipartUploadHandler(c echo.Context) error {
+	var req CompleteMultipartUploadRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid request format",
+		})
+	}
+	validationErrs := validateMultipartUploadRef(req.FileKey, req.UploadID)
+	validationErrs = append(validationErrs, validateCompletedParts(req.Parts)...)
+	if len(validationErrs) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
+			Error:            "validation_failed",
+			Message:          "The provided data is invalid",
+			ValidationErrors: validationErrs,
+		})
+	}
+
+	files, httpErr := s.multipartUploadStorage(c, req.FileKey)
+	if httpErr != nil {
+		return c.JSON(httpErr.Code, httpErr.Message)
+	}
+	if err := files.CompleteMultipartUpload(c.Request().Context(), req.FileKey, req.UploadID, req.Parts); err != nil {
+		return c.JSON(http.StatusBadGateway, ErrorResponse{
+			Error:   "upload_failed",
+			Message: fmt.Sprintf("Failed to complete multipart upload: %v", err),
+		})
+	}
+
+	return c.JSON(http.StatusOK, CompleteMultipartUploadResponse{FileKey: req.FileKey})
+}
+
+// abortMultipartUploadHandler discards a multipart upload the client gave up on, so its
+// parts stop taking up storage.
+func (s *Server) abortMultipartUploadHandler(c echo.Context) error {
+	var req AbortMultipartUploadRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid request format",
+		})
+	}
+	if validationErrs := validateMultipartUploadRef(req.FileKey, req.UploadID); len(validationErrs) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
+			Error:            "validation_failed",
+			Message:          "The provided data is invalid",
+			ValidationErrors: validationErrs,
+		})
+	}
+
+	files, httpErr := s.multipartUploadStorage(c, req.FileKey)
+	if httpErr != nil {
+		return c.JSON(httpErr.Code, httpErr.Message)
+	}
+	if err := files.AbortMultipartUpload(c.Request().Context(), req.FileKey, req.UploadID); err != nil {
+		return c.JSON(http.StatusBadGateway, ErrorResponse{
+			Error:   "upload_failed",
+			Message: fmt.Sprintf("Failed to abort multipart upload: %v", err),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// multipartUploadStorage returns the storage a multipart upload of fileKey was started
+// in, after checking the key is one of the requesting user's uploads.
+func (s *Server) multipartUploadStorage(c echo.Context, fileKey string) (storage.S3Service, *echo.HTTPError) {
+	userID, err := user.ResolveID(c, user.NewDefaultRepository(s.db))
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid or missing JWT token",
+		})
+	}
+	if !strings.HasPrefix(fileKey, storage.UploadPrefix(c.Request().Context(), userID)) {
+		return nil, echo.NewHTTPError(http.StatusForbidden, ErrorResponse{
+			Error:   "forbidden",
+			Message: "The upload belongs to another user",
+		})
+	}
+	files, err := s.buckets.ForUser(c.Request().Context(), userID)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to resolve storage",
+		})
+	}
+	return files, nil
+}
+
+// validateMultipartUploadRequest checks a request to start a multipart upload.
+func validateMultipartUploadRequest(req *MultipartUploadRequest) []ValidationErrorDetail {
+	var errs []ValidationErrorDetail
+
+	filename := strings.TrimSpace(req.Filename)
+	expectedContentType := storage.RawContentType(filename)
+	switch {
+	case filename == "":
+		errs = append(errs, ValidationErrorDetail{Field: "filename", Message: "filename is required"})
+	case len(filename) > 255:
+		errs = append(errs, ValidationErrorDetail{Field: "filename", Message: "filename must be 255 characters or less"})
+	case expectedContentType == "":
+		errs = append(errs, ValidationErrorDetail{
+			Field:   "filename",
+			Message: "filename must have a RAW extension (.cr3, .nef, .arw)",
+		})
+	}
+
+	switch {
+	case req.ContentType == "":
+		errs = append(errs, ValidationErrorDetail{Field: "content_type", Message: "content_type is required"})
+	case expectedContentType != "" && req.ContentType != expectedContentType:
+		errs = append(errs, ValidationErrorDetail{
+			Field:   "content_type",
+			Message: fmt.Sprintf("content_type must be %s for a %s file", expectedContentType, strings.ToLower(filepath.Ext(filename))),
+		})
+	}
+
+	if !storage.ValidateRawFileSize(req.FileSize) {
+		errs = append(errs, ValidationErrorDetail{
+			Field:   "file_size",
+			Message: fmt.Sprintf("file_size must be between 1 byte and %dMB", storage.MaxRawFileSize/(1024*1024)),
+		})
+	}
+
+	return errs
+}
+
+// validateMultipartUploadRef checks the file key and upload ID naming a multipart upload.
+func validateMultipartUploadRef(fileKey, uploadID string) []ValidationErrorDetail {
+	var errs []ValidationErrorDetail
+	if strings.TrimSpace(fileKey) == "" {
+		errs = append(errs, ValidationErrorDetail{Field: "file_key", Message: "file_key is required"})
+	}
+	if strings.TrimSpace(uploadID) == "" {
+		errs = append(errs, ValidationErrorDetail{Field: "upload_id", Message: "upload_id is required"})
+	}
+	return errs
+}
+
+// validateCompletedParts checks the parts a multipart upload is completed with: each
+// numbered once, within the parts a RAW upload can have, with its ETag.
+func validateCompletedParts(parts []storage.CompletedPart) []ValidationErrorDetail {
+	if len(parts) == 0 {
+		return []ValidationErrorDetail{{Field: "parts", Message: "parts is required"}}
+	}
+	maxParts := storage.MultipartPartCount(storage.MaxRawFileSize)
+	seen := make(map[int32]bool, len(parts))
+	for _, p := range parts {
+		switch {
+		case p.PartNumber < 1 || p.PartNumber > maxParts:
+			return []ValidationErrorDetail{{
+				Field:   "parts",
+				Message: fmt.Sprintf("part_number must be between 1 and %d", maxParts),
+			}}
+		case seen[p.PartNumber]:
+			return []ValidationErrorDetail{{
+				Field:   "parts",
+				Message: fmt.Sprintf("part %d is listed more than once", p.PartNumber),
+			}}
+		case strings.TrimSpace(p.ETag) == "":
+			return []ValidationErrorDetail{{
+				Field:   "parts",
+				Message: fmt.Sprintf("part %d is missing its etag", p.PartNumber),
+			}}
+		}
+		seen[p.PartNumber] = true
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+func TestValidateMultipartUploadRequest(t *testing.T) {
+	cases := []struct {
+		name       string
+		req        MultipartUploadRequest
+		wantFields []string
+	}{
+		{
+			name: "success: canon raw",
+			req:  MultipartUploadRequest{Filename: "living.CR3", ContentType: "image/x-canon-cr3", FileSize: 60 << 20},
+		},
+		{
+			name:       "fail: jpeg belongs on the presigned upload",
+			req:        MultipartUploadRequest{Filename: "living.jpg", ContentType: "image/jpeg", FileSize: 1 << 20},
+			wantFields: []string{"filename"},
+		},
+		{
+			name:       "fail: content type does not match the extension",
+			req:        MultipartUploadRequest{Filename: "living.nef", ContentType: "image/x-sony-arw", FileSize: 1 << 20},
+			wantFields: []string{"content_type"},
+		},
+		{
+			name:       "fail: too large",
+			req:        MultipartUploadRequest{Filename: "living.arw", ContentType: "image/x-sony-arw", FileSize: storage.MaxRawFileSize + 1},
+			wantFields: []string{"file_size"},
+		},
+		{
+			name:       "fail: empty request",
+			wantFields: []string{"filename", "content_type", "file_size"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var fields []string
+			for _, e := range validateMultipartUploadRequest(&tc.req) {
+				fields = append(fields, e.Field)
+			}
+			assert.Equal(t, tc.wantFields, fields)
+		})
+	}
+}
+
+func TestValidateCompletedParts(t *testing.T) {
+	cases := []struct {
+		name    string
+		parts   []storage.CompletedPart
+		wantMsg string
+	}{
+		{
+			name:  "success: out of order parts",
+			parts: []storage.CompletedPart{{PartNumber: 2, ETag: `"b"`}, {PartNumber: 1, ETag: `"a"`}},
+		},
+		{name: "fail: no parts", wantMsg: "parts is required"},
+		{
+			name:    "fail: part number out of range",
+			parts:   []storage.CompletedPart{{PartNumber: 14, ETag: `"a"`}},
+			wantMsg: "part_number must be between 1 and 13",
+		},
+		{
+			name:    "fail: duplicate part",
+			parts:   []storage.CompletedPart{{PartNumber: 1, ETag: `"a"`}, {PartNumber: 1, ETag: `"b"`}},
+			wantMsg: "part 1 is listed more than once",
+		},
+		{
+			name:    "fail: missing etag",
+			parts:   []storage.CompletedPart{{PartNumber: 1}},
+			wantMsg: "part 1 is missing its etag",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validateCompletedParts(tc.parts)
+			if tc.wantMsg == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			if assert.Len(t, errs, 1) {
+				assert.Equal(t, tc.wantMsg, errs[0].Message)
+			}
+		})
+	}
+}
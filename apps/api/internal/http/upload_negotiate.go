@@ -0,0 +1,56 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/uploadhint"
+)
+
+// negotiateUploadHandler recommends how the client should prepare an upload for its connection.
+// Capabilities missing from the body are filled from the ECT, Downlink and Save-Data client hints.
+func (s *Server) negotiateUploadHandler(c echo.Context) error {
+	var caps uploadhint.Capabilities
+	if err := c.Bind(&caps); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid request format",
+		})
+	}
+	applyClientHints(c.Request().Header, &caps)
+
+	rec, err := uploadhint.Negotiate(caps)
+	if err != nil {
+		if errors.Is(err, uploadhint.ErrInvalidCapabilities) {
+			return c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+				Error:   "validation_failed",
+				Message: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to negotiate upload",
+		})
+	}
+
+	return c.JSON(http.StatusOK, rec)
+}
+
+// applyClientHints fills capabilities the body left unset from the request's client hint headers.
+func applyClientHints(h http.Header, caps *uploadhint.Capabilities) {
+	if caps.EffectiveType == "" {
+		caps.EffectiveType = strings.TrimSpace(h.Get("ECT"))
+	}
+	if caps.DownlinkMbps == 0 {
+		if v, err := strconv.ParseFloat(strings.TrimSpace(h.Get("Downlink")), 64); err == nil && v > 0 {
+			caps.DownlinkMbps = v
+		}
+	}
+	if strings.EqualFold(strings.TrimSpace(h.Get("Save-Data")), "on") {
+		caps.SaveData = true
+	}
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/uploadhint"
+)
+
+func TestServer_NegotiateUpload(t *testing.T) {
+	cases := []struct {
+		name      string
+		body      string
+		headers   map[string]string
+		wantCode  int
+		wantClass string
+		wantWidth int
+	}{
+		{
+			name:      "success: body capabilities",
+			body:      `{"connection_type":"cellular","width":8000,"height":6000}`,
+			wantCode:  http.StatusOK,
+			wantClass: uploadhint.ClassCellular,
+			wantWidth: 3072,
+		},
+		{
+			name:      "success: save-data hint constrains a wifi upload",
+			body:      `{"connection_type":"wifi","width":4000,"height":3000}`,
+			headers:   map[string]string{"Save-Data": "on"},
+			wantCode:  http.StatusOK,
+			wantClass: uploadhint.ClassConstrained,
+			wantWidth: 2048,
+		},
+		{
+			name:      "success: downlink hint marks a fast connection",
+			body:      `{"connection_type":"cellular","width":4000,"height":3000}`,
+			headers:   map[string]string{"Downlink": "75"},
+			wantCode:  http.StatusOK,
+			wantClass: uploadhint.ClassFast,
+			wantWidth: 4000,
+		},
+		{
+			name:      "success: body effective type wins over the ECT hint",
+			body:      `{"effective_type":"4g","width":1000,"height":1000}`,
+			headers:   map[string]string{"ECT": "2g"},
+			wantCode:  http.StatusOK,
+			wantClass: uploadhint.ClassCellular,
+			wantWidth: 1000,
+		},
+		{
+			name:     "fail: missing dimensions",
+			body:     `{"connection_type":"wifi"}`,
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "fail: malformed body",
+			body:     `{"width":`,
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/uploads/negotiate", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+
+			err := (&Server{}).negotiateUploadHandler(e.NewContext(req, rec))
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantCode, rec.Code)
+			if tc.wantCode != http.StatusOK {
+				return
+			}
+
+			var got uploadhint.Recommendation
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+			assert.Equal(t, tc.wantClass, got.Class)
+			assert.Equal(t, tc.wantWidth, got.TargetWidth)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package httpclient
+
+import "time"
+
+// Destination names a service the API calls.
+type Destination string
+
+const (
+	// DestinationAuth0 is the Auth0 tenant: JWKS and token requests.
+	DestinationAuth0 Destination = "auth0"
+	// DestinationStripe is the Stripe API.
+	DestinationStripe Destination = "stripe"
+	// DestinationWebhooks is customer-owned webhook endpoints.
+	DestinationWebhooks Destination = "webhooks"
+	// DestinationGeocoding is the geocoding provider for project listing addresses.
+	DestinationGeocoding Destination = "geocoding"
+	// DestinationSMS is the SMS provider.
+	DestinationSMS Destination = "sms"
+	// DestinationLoadTest is the API under test in load test runs.
+	DestinationLoadTest Destination = "loadtest"
+)
+
+// DefaultPolicy applies to destinations missing from Policies.
+var DefaultPolicy = Policy{
+	Timeout:             30 * time.Second,
+	MaxAttempts:         3,
+	RetryBackoff:        200 * time.Millisecond,
+	MaxIdleConnsPerHost: 4,
+	IdleConnTimeout:     90 * time.Second,
+}
+
+// Policies holds each destination's policy.
+var Policies = map[Destination]Policy{
+	// JWKS fetches sit in the request path of the first authenticated call, so fail fast.
+	DestinationAuth0: {
+		Timeout:             10 * time.Second,
+		MaxAttempts:         3,
+		RetryBackoff:        100 * time.Millisecond,
+		MaxIdleConnsPerHost: 2,
+		IdleConnTimeout:     90 * time.Second,
+	},
+	// Stripe honors Idempotency-Key, so POSTs that set one are retried too.
+	DestinationStripe: {
+		Timeout:             30 * time.Second,
+		MaxAttempts:         3,
+		RetryBackoff:        500 * time.Millisecond,
+		MaxIdleConnsPerHost: 8,
+		IdleConnTimeout:     90 * time.Second,
+	},
+	// Customer endpoints are slow and untrusted: a short timeout, a small pool per host, and
+	// no retries, since a delivery is not known to be idempotent.
+	DestinationWebhooks: {
+		Timeout:             10 * time.Second,
+		MaxAttempts:         1,
+		MaxIdleConnsPerHost: 1,
+		MaxConnsPerHost:     4,
+		IdleConnTimeout:     30 * time.Second,
+	},
+	// Geocoding runs while the user waits to save a listing, which is saved without
+	// coordinates if it fails, so give up quickly.
+	DestinationGeocoding: {
+		Timeout:             5 * time.Second,
+		MaxAttempts:         2,
+		RetryBackoff:        200 * time.Millisecond,
+		MaxIdleConnsPerHost: 2,
+		IdleConnTimeout:     90 * time.Second,
+	},
+	// Sending a message is a POST and never retried; only status checks are.
+	DestinationSMS: {
+		Timeout:             10 * time.Second,
+		MaxAttempts:         2,
+		RetryBackoff:        200 * time.Millisecond,
+		MaxIdleConnsPerHost: 2,
+		IdleConnTimeout:     90 * time.Second,
+	},
+	// Load tests measure the API as it is, so failures are never retried.
+	DestinationLoadTest: {
+		Timeout:         30 * time.Second,
+		MaxAttempts:     1,
+		IdleConnTimeout: 90 * time.Second,
+	},
+}
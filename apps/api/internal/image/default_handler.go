@@ -1,23 +1,37 @@
 package image
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"path"
 	"slices"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/usage"
+	"github.com/real-staging-ai/api/internal/user"
 )
 
 // DefaultHandler contains the HTTP handlers for image operations.
 type DefaultHandler struct {
-	service Service
+	service  Service
+	userRepo user.Repository
+	// projects checks the caller owns or edits the project before changing its images.
+	projects project.Repository
 }
 
 // NewDefaultHandler creates a new Handler instance.
-func NewDefaultHandler(service Service) *DefaultHandler {
+func NewDefaultHandler(service Service, userRepo user.Repository, projects project.Repository) *DefaultHandler {
 	return &DefaultHandler{
-		service: service,
+		service:  service,
+		userRepo: userRepo,
+		projects: projects,
 	}
 }
 
@@ -31,6 +45,12 @@ func (h *DefaultHandler) CreateImage(c echo.Context) error {
 		})
 	}
 
+	source, err := ParseSource(c.Request().Header.Get(HeaderSource))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, invalidSourceResponse())
+	}
+	req.Source = source
+
 	// Validate request
 	if validationErrs := h.validateCreateImageRequest(&req); len(validationErrs) > 0 {
 		return c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
@@ -42,6 +62,66 @@ func (h *DefaultHandler) CreateImage(c echo.Context) error {
 
 	// Create the image
 	img, err := h.service.CreateImage(c.Request().Context(), &req)
+	var quotaErr *usage.QuotaError
+	if errors.As(err, &quotaErr) {
+		return usage.WriteQuotaExceeded(c, quotaErr)
+	}
+	if errors.Is(err, ErrReferenceImageNotAllowed) {
+		return c.JSON(http.StatusForbidden, ErrorResponse{
+			Error:   "forbidden",
+			Message: "Your plan does not include reference images",
+		})
+	}
+	if errors.Is(err, ErrReferenceImageInvalid) {
+		return c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
+			Error:   "validation_failed",
+			Message: "The provided data is invalid",
+			ValidationErrors: []ValidationErrorDetail{{
+				Field:   "reference_image_key",
+				Message: err.Error(),
+			}},
+		})
+	}
+	if errors.Is(err, ErrOriginalURLInvalid) {
+		return c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
+			Error:   "validation_failed",
+			Message: "The provided data is invalid",
+			ValidationErrors: []ValidationErrorDetail{{
+				Field:   "original_url",
+				Message: "original_url must be in your own storage",
+			}},
+		})
+	}
+	if errors.Is(err, ErrBracketURLInvalid) {
+		return c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
+			Error:   "validation_failed",
+			Message: "The provided data is invalid",
+			ValidationErrors: []ValidationErrorDetail{{
+				Field:   "bracket_urls",
+				Message: "bracket_urls must be in your own storage",
+			}},
+		})
+	}
+	if errors.Is(err, ErrCatalogUnavailable) {
+		return c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
+			Error:   "validation_failed",
+			Message: "The provided data is invalid",
+			ValidationErrors: []ValidationErrorDetail{{
+				Field:   "catalog_id",
+				Message: "catalog_id must refer to an active catalog",
+			}},
+		})
+	}
+	if errors.Is(err, ErrPresetUnavailable) {
+		return c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
+			Error:   "validation_failed",
+			Message: "The provided data is invalid",
+			ValidationErrors: []ValidationErrorDetail{{
+				Field:   "preset_id",
+				Message: "preset_id must refer to one of your presets",
+			}},
+		})
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_server_error",
@@ -62,6 +142,14 @@ func (h *DefaultHandler) BatchCreateImages(c echo.Context) error {
 		})
 	}
 
+	source, err := ParseSource(c.Request().Header.Get(HeaderSource))
+	if err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, invalidSourceResponse())
+	}
+	for i := range req.Images {
+		req.Images[i].Source = source
+	}
+
 	// Validate batch request
 	if len(req.Images) == 0 {
 		return c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
@@ -108,6 +196,28 @@ func (h *DefaultHandler) BatchCreateImages(c echo.Context) error {
 
 	// Create the images in batch
 	response, err := h.service.BatchCreateImages(c.Request().Context(), req.Images)
+	var quotaErr *usage.QuotaError
+	if errors.As(err, &quotaErr) {
+		return usage.WriteQuotaExceeded(c, quotaErr)
+	}
+	if errors.Is(err, ErrReferenceImageNotAllowed) {
+		return c.JSON(http.StatusForbidden, ErrorResponse{
+			Error:   "forbidden",
+			Message: "Your plan does not include reference images",
+		})
+	}
+	if errors.Is(err, ErrCatalogUnavailable) || errors.Is(err, ErrReferenceImageInvalid) ||
+		errors.Is(err, ErrPresetUnavailable) || errors.Is(err, ErrOriginalURLInvalid) ||
+		errors.Is(err, ErrBracketURLInvalid) {
+		return c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
+			Error:   "validation_failed",
+			Message: "One or more images have invalid data",
+			ValidationErrors: []ValidationErrorDetail{{
+				Field:   "images",
+				Message: err.Error(),
+			}},
+		})
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_server_error",
@@ -213,6 +323,12 @@ func (h *DefaultHandler) DeleteImage(c echo.Context) error {
 
 	err := h.service.DeleteImage(c.Request().Context(), imageID)
 	if err != nil {
+		if errors.Is(err, ErrLegalHold) {
+			return c.JSON(http.StatusConflict, ErrorResponse{
+				Error:   "conflict",
+				Message: "Image is under legal hold and cannot be deleted",
+			})
+		}
 		// Check if it's a not found error
 		if err.Error() == "no rows in result set" {
 			return c.JSON(http.StatusNotFound, ErrorResponse{
@@ -229,6 +345,303 @@ func (h *DefaultHandler) DeleteImage(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+// CancelImage handles POST /api/v1/images/{id}/cancel requests.
+func (h *DefaultHandler) CancelImage(c echo.Context) error {
+	imageID := c.Param("id")
+	if imageID == "" {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Image ID is required",
+		})
+	}
+
+	// Validate UUID format
+	if _, err := uuid.Parse(imageID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid image ID format",
+		})
+	}
+
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get user",
+		})
+	}
+
+	img, err := h.service.CancelImage(c.Request().Context(), imageID, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "not_found",
+				Message: "Image not found",
+			})
+		case errors.Is(err, ErrImageReadOnly):
+			return c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "forbidden",
+				Message: "Viewers cannot change the project's images",
+			})
+		case errors.Is(err, ErrImageNotCancelable):
+			return c.JSON(http.StatusConflict, ErrorResponse{
+				Error:   "conflict",
+				Message: "Image has already finished processing and cannot be canceled",
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to cancel image",
+		})
+	}
+
+	return c.JSON(http.StatusOK, img)
+}
+
+// GetImageStatusHistory handles GET /api/v1/images/{id}/history requests.
+func (h *DefaultHandler) GetImageStatusHistory(c echo.Context) error {
+	imageID := c.Param("id")
+	if imageID == "" {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Image ID is required",
+		})
+	}
+
+	// Validate UUID format
+	if _, err := uuid.Parse(imageID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid image ID format",
+		})
+	}
+
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get user",
+		})
+	}
+
+	history, err := h.service.GetImageStatusHistory(c.Request().Context(), imageID, userID)
+	if err != nil {
+		if errors.Is(err, ErrNoStatusHistory) || errors.Is(err, pgx.ErrNoRows) {
+			return c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "not_found",
+				Message: "Image not found",
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get image status history",
+		})
+	}
+
+	return c.JSON(http.StatusOK, history)
+}
+
+// ExpediteImage handles POST /api/v1/images/{id}/expedite requests.
+func (h *DefaultHandler) ExpediteImage(c echo.Context) error {
+	imageID := c.Param("id")
+	if imageID == "" {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Image ID is required",
+		})
+	}
+
+	// Validate UUID format
+	if _, err := uuid.Parse(imageID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid image ID format",
+		})
+	}
+
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get user",
+		})
+	}
+
+	img, err := h.service.ExpediteImage(c.Request().Context(), imageID, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "not_found",
+				Message: "Image not found",
+			})
+		case errors.Is(err, ErrImageReadOnly):
+			return c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "forbidden",
+				Message: "Viewers cannot change the project's images",
+			})
+		case errors.Is(err, ErrExpediteNotAllowed):
+			return c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "forbidden",
+				Message: "Your plan does not include expedited staging",
+			})
+		case errors.Is(err, ErrExpediteLimitReached):
+			return c.JSON(http.StatusTooManyRequests, ErrorResponse{
+				Error:   "too_many_requests",
+				Message: "Daily expedite limit reached",
+			})
+		case errors.Is(err, ErrImageNotExpeditable):
+			return c.JSON(http.StatusConflict, ErrorResponse{
+				Error:   "conflict",
+				Message: "Image is no longer queued and cannot be expedited",
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to expedite image",
+		})
+	}
+
+	return c.JSON(http.StatusOK, img)
+}
+
+// BulkCancelImages handles POST /api/v1/projects/{project_id}/images/bulk-cancel requests.
+func (h *DefaultHandler) BulkCancelImages(c echo.Context) error {
+	return h.handleBulkImages(c, h.service.BulkCancelImages, "Failed to cancel images")
+}
+
+// BulkDeleteImages handles POST /api/v1/projects/{project_id}/images/bulk-delete requests.
+func (h *DefaultHandler) BulkDeleteImages(c echo.Context) error {
+	return h.handleBulkImages(c, h.service.BulkDeleteImages, "Failed to delete images")
+}
+
+// handleBulkImages validates a bulk image request and reports per-item results.
+// Returns 200 when every image succeeded, 207 Multi-Status otherwise, 404 when the caller
+// does not own or collaborate on the project and 403 when they only view it.
+func (h *DefaultHandler) handleBulkImages(
+	c echo.Context,
+	op func(ctx context.Context, projectID string, imageIDs []string) (*BulkImagesResponse, error),
+	failureMessage string,
+) error {
+	projectID := c.Param("project_id")
+	if _, err := uuid.Parse(projectID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid project ID format",
+		})
+	}
+
+	if _, httpErr := h.projectMember(c, projectID, true); httpErr != nil {
+		return c.JSON(httpErr.Code, httpErr.Message)
+	}
+
+	var req BulkImageIDsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid request format",
+		})
+	}
+
+	if validationErrs := h.validateBulkImageIDsRequest(&req); len(validationErrs) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
+			Error:            "validation_failed",
+			Message:          "The provided data is invalid",
+			ValidationErrors: validationErrs,
+		})
+	}
+
+	response, err := op(c.Request().Context(), projectID, req.ImageIDs)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: failureMessage,
+		})
+	}
+
+	statusCode := http.StatusOK
+	if response.Failed > 0 {
+		statusCode = http.StatusMultiStatus
+	}
+	return c.JSON(statusCode, response)
+}
+
+// projectMember resolves the request's user and checks they own or collaborate on the
+// project, returning their ID. Anyone else is told the project does not exist. Changes (write)
+// also need owner or editor access; viewers are forbidden.
+func (h *DefaultHandler) projectMember(c echo.Context, projectID string, write bool) (string, *echo.HTTPError) {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return "", echo.NewHTTPError(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get user",
+		})
+	}
+
+	member, err := h.projects.GetProjectForMember(c.Request().Context(), projectID, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", echo.NewHTTPError(http.StatusNotFound, ErrorResponse{
+				Error:   "not_found",
+				Message: "Project not found",
+			})
+		}
+		return "", echo.NewHTTPError(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to retrieve project",
+		})
+	}
+	if write && member.Role == project.RoleViewer {
+		return "", echo.NewHTTPError(http.StatusForbidden, ErrorResponse{
+			Error:   "forbidden",
+			Message: "Viewers cannot change the project's images",
+		})
+	}
+	return userID, nil
+}
+
+// validateBulkImageIDsRequest validates the bulk image IDs request.
+func (h *DefaultHandler) validateBulkImageIDsRequest(req *BulkImageIDsRequest) []ValidationErrorDetail {
+	var errs []ValidationErrorDetail
+
+	if len(req.ImageIDs) == 0 {
+		return append(errs, ValidationErrorDetail{
+			Field:   "image_ids",
+			Message: "image_ids cannot be empty",
+		})
+	}
+	if len(req.ImageIDs) > MaxBulkImageIDs {
+		return append(errs, ValidationErrorDetail{
+			Field:   "image_ids",
+			Message: fmt.Sprintf("maximum %d images per bulk request", MaxBulkImageIDs),
+		})
+	}
+
+	for i, id := range req.ImageIDs {
+		if _, err := uuid.Parse(id); err != nil {
+			errs = append(errs, ValidationErrorDetail{
+				Field:   fmt.Sprintf("image_ids[%d]", i),
+				Message: "must be a valid UUID",
+			})
+		}
+	}
+
+	return errs
+}
+
+// invalidSourceResponse is the response to an X-Client-Source header naming an unknown surface.
+func invalidSourceResponse() ValidationErrorResponse {
+	return ValidationErrorResponse{
+		Error:   "validation_failed",
+		Message: "The provided data is invalid",
+		ValidationErrors: []ValidationErrorDetail{{
+			Field:   HeaderSource,
+			Message: HeaderSource + " must be one of: web, mobile, api, import",
+		}},
+	}
+}
+
 // validateCreateImageRequest validates the create image request.
 func (h *DefaultHandler) validateCreateImageRequest(req *CreateImageRequest) []ValidationErrorDetail {
 	var errors []ValidationErrorDetail
@@ -285,6 +698,80 @@ func (h *DefaultHandler) validateCreateImageRequest(req *CreateImageRequest) []V
 		}
 	}
 
+	// Validate room annotations if provided
+	errors = append(errors, validateAnnotations(req.Annotations)...)
+
+	// Validate bracketed exposures if provided
+	errors = append(errors, validateBracketURLs(req.OriginalURL, req.BracketURLs)...)
+
+	// Validate reference image key if provided
+	if req.ReferenceImageKey != "" && !storage.ValidateFilename(path.Base(req.ReferenceImageKey)) {
+		errors = append(errors, ValidationErrorDetail{
+			Field:   "reference_image_key",
+			Message: "reference_image_key must be an image file_key from /uploads/presign (.jpg, .jpeg, .png, .webp)",
+		})
+	}
+
+	return errors
+}
+
+// validateBracketURLs checks that the other exposures of a shot are few enough, set,
+// distinct from each other and from the original, and not RAW files, which are only
+// converted when they are the original.
+func validateBracketURLs(originalURL string, urls []string) []ValidationErrorDetail {
+	if len(urls) > MaxBracketURLs {
+		return []ValidationErrorDetail{{
+			Field:   "bracket_urls",
+			Message: fmt.Sprintf("bracket_urls must list at most %d exposures", MaxBracketURLs),
+		}}
+	}
+	seen := map[string]bool{originalURL: true}
+	for _, u := range urls {
+		if u == "" {
+			return []ValidationErrorDetail{{Field: "bracket_urls", Message: "bracket_urls must not contain empty URLs"}}
+		}
+		if seen[u] {
+			return []ValidationErrorDetail{{
+				Field:   "bracket_urls",
+				Message: "bracket_urls must be distinct from each other and from original_url",
+			}}
+		}
+		if storage.RawContentType(path.Base(u)) != "" {
+			return []ValidationErrorDetail{{
+				Field:   "bracket_urls",
+				Message: "bracket_urls must be JPEG, PNG or WebP images; RAW files can only be the original",
+			}}
+		}
+		seen[u] = true
+	}
+	return nil
+}
+
+// validateAnnotations checks that room measurements are set and within realistic bounds.
+func validateAnnotations(a *Annotations) []ValidationErrorDetail {
+	if a == nil {
+		return nil
+	}
+	if a.WallLengthM == nil && a.CeilingHeightM == nil {
+		return []ValidationErrorDetail{{
+			Field:   "annotations",
+			Message: "annotations must set wall_length_m or ceiling_height_m",
+		}}
+	}
+
+	var errors []ValidationErrorDetail
+	if v := a.WallLengthM; v != nil && (*v < MinWallLengthM || *v > MaxWallLengthM) {
+		errors = append(errors, ValidationErrorDetail{
+			Field:   "annotations.wall_length_m",
+			Message: fmt.Sprintf("wall_length_m must be between %g and %g meters", MinWallLengthM, MaxWallLengthM),
+		})
+	}
+	if v := a.CeilingHeightM; v != nil && (*v < MinCeilingHeightM || *v > MaxCeilingHeightM) {
+		errors = append(errors, ValidationErrorDetail{
+			Field:   "annotations.ceiling_height_m",
+			Message: fmt.Sprintf("ceiling_height_m must be between %g and %g meters", MinCeilingHeightM, MaxCeilingHeightM),
+		})
+	}
 	return errors
 }
 
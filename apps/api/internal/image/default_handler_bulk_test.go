@@ -0,0 +1,236 @@
+package image
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// callerRepo returns a user repository that resolves every request to userID.
+func callerRepo(userID uuid.UUID) *user.RepositoryMock {
+	return &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+		},
+	}
+}
+
+func TestDefaultHandler_BulkImages(t *testing.T) {
+	projectID := uuid.New().String()
+	callerID := uuid.New()
+	id1 := uuid.New().String()
+	id2 := uuid.New().String()
+
+	tooMany := make([]string, MaxBulkImageIDs+1)
+	for i := range tooMany {
+		tooMany[i] = uuid.New().String()
+	}
+
+	allOK := func(ctx context.Context, projectID string, imageIDs []string) (*BulkImagesResponse, error) {
+		resp := &BulkImagesResponse{}
+		for _, id := range imageIDs {
+			resp.Results = append(resp.Results, BulkImageResult{ImageID: id, Result: BulkResultCanceled})
+			resp.Success++
+		}
+		return resp, nil
+	}
+	partial := func(ctx context.Context, projectID string, imageIDs []string) (*BulkImagesResponse, error) {
+		return &BulkImagesResponse{
+			Results: []BulkImageResult{
+				{ImageID: imageIDs[0], Result: BulkResultDeleted},
+				{ImageID: imageIDs[1], Result: BulkResultNotFound},
+			},
+			Success: 1,
+			Failed:  1,
+		}, nil
+	}
+
+	testCases := []struct {
+		name          string
+		operation     string
+		projectID     string
+		body          string
+		serviceFunc   func(ctx context.Context, projectID string, imageIDs []string) (*BulkImagesResponse, error)
+		memberErr     error
+		role          string
+		expectedCode  int
+		expectedCalls int
+	}{
+		{
+			name:          "success: bulk cancel all images",
+			operation:     "cancel",
+			projectID:     projectID,
+			body:          `{"image_ids":["` + id1 + `","` + id2 + `"]}`,
+			serviceFunc:   allOK,
+			expectedCode:  http.StatusOK,
+			expectedCalls: 1,
+		},
+		{
+			name:          "success: bulk delete partial result",
+			operation:     "delete",
+			projectID:     projectID,
+			body:          `{"image_ids":["` + id1 + `","` + id2 + `"]}`,
+			serviceFunc:   partial,
+			expectedCode:  http.StatusMultiStatus,
+			expectedCalls: 1,
+		},
+		{
+			name:         "fail: invalid project ID",
+			operation:    "cancel",
+			projectID:    "invalid-uuid",
+			body:         `{"image_ids":["` + id1 + `"]}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "fail: caller is not a project member",
+			operation:    "cancel",
+			projectID:    projectID,
+			body:         `{"image_ids":["` + id1 + `"]}`,
+			serviceFunc:  allOK,
+			memberErr:    pgx.ErrNoRows,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "fail: non-member cannot bulk delete",
+			operation:    "delete",
+			projectID:    projectID,
+			body:         `{"image_ids":["` + id1 + `"]}`,
+			serviceFunc:  allOK,
+			memberErr:    pgx.ErrNoRows,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "fail: viewer cannot bulk cancel",
+			operation:    "cancel",
+			projectID:    projectID,
+			body:         `{"image_ids":["` + id1 + `"]}`,
+			serviceFunc:  allOK,
+			role:         project.RoleViewer,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "fail: viewer cannot bulk delete",
+			operation:    "delete",
+			projectID:    projectID,
+			body:         `{"image_ids":["` + id1 + `"]}`,
+			serviceFunc:  allOK,
+			role:         project.RoleViewer,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "fail: membership lookup error",
+			operation:    "delete",
+			projectID:    projectID,
+			body:         `{"image_ids":["` + id1 + `"]}`,
+			serviceFunc:  allOK,
+			memberErr:    errors.New("db error"),
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name:         "fail: malformed body",
+			operation:    "delete",
+			projectID:    projectID,
+			body:         `{"image_ids":`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "fail: empty image ids",
+			operation:    "cancel",
+			projectID:    projectID,
+			body:         `{"image_ids":[]}`,
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:         "fail: too many image ids",
+			operation:    "delete",
+			projectID:    projectID,
+			body:         `{"image_ids":["` + strings.Join(tooMany, `","`) + `"]}`,
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:         "fail: invalid image id",
+			operation:    "cancel",
+			projectID:    projectID,
+			body:         `{"image_ids":["` + id1 + `","nope"]}`,
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:      "fail: service error",
+			operation: "delete",
+			projectID: projectID,
+			body:      `{"image_ids":["` + id1 + `"]}`,
+			serviceFunc: func(ctx context.Context, projectID string, imageIDs []string) (*BulkImagesResponse, error) {
+				return nil, errors.New("db error")
+			},
+			expectedCode:  http.StatusInternalServerError,
+			expectedCalls: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("project_id")
+			c.SetParamValues(tc.projectID)
+
+			serviceMock := &ServiceMock{
+				BulkCancelImagesFunc: tc.serviceFunc,
+				BulkDeleteImagesFunc: tc.serviceFunc,
+			}
+			projects := &project.RepositoryMock{
+				GetProjectForMemberFunc: func(ctx context.Context, gotProjectID, userID string) (*project.Project, error) {
+					assert.Equal(t, projectID, gotProjectID)
+					assert.Equal(t, callerID.String(), userID)
+					if tc.memberErr != nil {
+						return nil, tc.memberErr
+					}
+					role := project.RoleEditor
+					if tc.role != "" {
+						role = tc.role
+					}
+					return &project.Project{ID: gotProjectID, Role: role}, nil
+				},
+			}
+			h := NewDefaultHandler(serviceMock, callerRepo(callerID), projects)
+
+			var err error
+			cancelCalls, deleteCalls := 0, 0
+			if tc.operation == "cancel" {
+				err = h.BulkCancelImages(c)
+				cancelCalls = tc.expectedCalls
+			} else {
+				err = h.BulkDeleteImages(c)
+				deleteCalls = tc.expectedCalls
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Len(t, serviceMock.BulkCancelImagesCalls(), cancelCalls)
+			assert.Len(t, serviceMock.BulkDeleteImagesCalls(), deleteCalls)
+
+			if tc.expectedCode == http.StatusOK || tc.expectedCode == http.StatusMultiStatus {
+				var resp BulkImagesResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Len(t, resp.Results, 2)
+			}
+		})
+	}
+}
@@ -3,14 +3,23 @@ package image
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/usage"
 )
 
 func TestDefaultHandler_CreateImage(t *testing.T) {
@@ -61,6 +70,170 @@ func TestDefaultHandler_CreateImage(t *testing.T) {
 			},
 			expectedCode: http.StatusInternalServerError,
 		},
+		{
+			name: "fail: unavailable catalog",
+			requestBody: `{"project_id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", ` +
+				`"original_url": "http://example.com/image.jpg", "catalog_id": "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"}`,
+			setupMock: func(mock *ServiceMock) {
+				mock.CreateImageFunc = func(ctx context.Context, req *CreateImageRequest) (*Image, error) {
+					return nil, ErrCatalogUnavailable
+				}
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "fail: unavailable preset",
+			requestBody: `{"project_id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", ` +
+				`"original_url": "http://example.com/image.jpg", "preset_id": "c0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"}`,
+			setupMock: func(mock *ServiceMock) {
+				mock.CreateImageFunc = func(ctx context.Context, req *CreateImageRequest) (*Image, error) {
+					return nil, ErrPresetUnavailable
+				}
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "success: with room annotations",
+			requestBody: `{"project_id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", ` +
+				`"original_url": "http://example.com/image.jpg", "annotations": {"wall_length_m": 4.2}}`,
+			setupMock: func(mock *ServiceMock) {
+				mock.CreateImageFunc = func(ctx context.Context, req *CreateImageRequest) (*Image, error) {
+					return &Image{ID: uuid.New(), Annotations: req.Annotations}, nil
+				}
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "fail: empty room annotations",
+			requestBody: `{"project_id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", ` +
+				`"original_url": "http://example.com/image.jpg", "annotations": {}}`,
+			setupMock:    func(mock *ServiceMock) {},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "fail: implausible ceiling height",
+			requestBody: `{"project_id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", ` +
+				`"original_url": "http://example.com/image.jpg", "annotations": {"ceiling_height_m": 24}}`,
+			setupMock:    func(mock *ServiceMock) {},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "success: with bracketed exposures",
+			requestBody: `{"project_id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", ` +
+				`"original_url": "http://example.com/image.jpg", "bracket_urls": ["http://example.com/under.jpg"]}`,
+			setupMock: func(mock *ServiceMock) {
+				mock.CreateImageFunc = func(ctx context.Context, req *CreateImageRequest) (*Image, error) {
+					return &Image{ID: uuid.New(), BracketURLs: req.BracketURLs}, nil
+				}
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "fail: bracket repeats the original",
+			requestBody: `{"project_id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", ` +
+				`"original_url": "http://example.com/image.jpg", "bracket_urls": ["http://example.com/image.jpg"]}`,
+			setupMock:    func(mock *ServiceMock) {},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "fail: empty bracket URL",
+			requestBody: `{"project_id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", ` +
+				`"original_url": "http://example.com/image.jpg", "bracket_urls": [""]}`,
+			setupMock:    func(mock *ServiceMock) {},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "fail: RAW bracketed exposure",
+			requestBody: `{"project_id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", ` +
+				`"original_url": "http://example.com/image.jpg", "bracket_urls": ["http://example.com/under.NEF"]}`,
+			setupMock:    func(mock *ServiceMock) {},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "fail: too many bracketed exposures",
+			requestBody: `{"project_id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", ` +
+				`"original_url": "http://example.com/image.jpg", "bracket_urls": ["http://example.com/1.jpg", ` +
+				`"http://example.com/2.jpg", "http://example.com/3.jpg", "http://example.com/4.jpg", ` +
+				`"http://example.com/5.jpg", "http://example.com/6.jpg", "http://example.com/7.jpg"]}`,
+			setupMock:    func(mock *ServiceMock) {},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "fail: bracket in another account's bucket",
+			requestBody: `{"project_id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", ` +
+				`"original_url": "http://example.com/image.jpg", "bracket_urls": ["http://example.com/under.jpg"]}`,
+			setupMock: func(mock *ServiceMock) {
+				mock.CreateImageFunc = func(ctx context.Context, req *CreateImageRequest) (*Image, error) {
+					return nil, ErrBracketURLInvalid
+				}
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "fail: reference image key is not an image",
+			requestBody: `{"project_id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", ` +
+				`"original_url": "http://example.com/image.jpg", "reference_image_key": "uploads/u1/notes.txt"}`,
+			setupMock:    func(mock *ServiceMock) {},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "fail: plan does not include reference images",
+			requestBody: `{"project_id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", ` +
+				`"original_url": "http://example.com/image.jpg", "reference_image_key": "uploads/u1/room.jpg"}`,
+			setupMock: func(mock *ServiceMock) {
+				mock.CreateImageFunc = func(ctx context.Context, req *CreateImageRequest) (*Image, error) {
+					return nil, ErrReferenceImageNotAllowed
+				}
+			},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name: "fail: invalid reference image upload",
+			requestBody: `{"project_id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", ` +
+				`"original_url": "http://example.com/image.jpg", "reference_image_key": "uploads/u1/room.jpg"}`,
+			setupMock: func(mock *ServiceMock) {
+				mock.CreateImageFunc = func(ctx context.Context, req *CreateImageRequest) (*Image, error) {
+					return nil, fmt.Errorf("%w: upload not found", ErrReferenceImageInvalid)
+				}
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "fail: original URL in another account's bucket",
+			requestBody: `{"project_id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", ` +
+				`"original_url": "https://acme.s3.amazonaws.com/uploads/u2/room.jpg"}`,
+			setupMock: func(mock *ServiceMock) {
+				mock.CreateImageFunc = func(ctx context.Context, req *CreateImageRequest) (*Image, error) {
+					return nil, fmt.Errorf("%w: %w", ErrOriginalURLInvalid, storage.ErrForeignBucket)
+				}
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "fail: free allowance used up",
+			requestBody: `{"project_id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", ` +
+				`"original_url": "http://example.com/image.jpg"}`,
+			setupMock: func(mock *ServiceMock) {
+				mock.CreateImageFunc = func(ctx context.Context, req *CreateImageRequest) (*Image, error) {
+					return nil, &usage.QuotaError{Usage: usage.Usage{Limit: 5, Used: 5}, Requested: 1}
+				}
+			},
+			expectedCode: http.StatusPaymentRequired,
+		},
+		{
+			name: "fail: plan limit reached",
+			requestBody: `{"project_id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", ` +
+				`"original_url": "http://example.com/image.jpg"}`,
+			setupMock: func(mock *ServiceMock) {
+				mock.CreateImageFunc = func(ctx context.Context, req *CreateImageRequest) (*Image, error) {
+					return nil, &usage.QuotaError{
+						Usage:     usage.Usage{Plan: "pro", Limit: 100, Used: 100, PeriodEnd: time.Now().Add(time.Hour)},
+						Requested: 1,
+					}
+				}
+			},
+			expectedCode: http.StatusTooManyRequests,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -74,7 +247,7 @@ func TestDefaultHandler_CreateImage(t *testing.T) {
 			serviceMock := &ServiceMock{}
 			tc.setupMock(serviceMock)
 
-			h := NewDefaultHandler(serviceMock)
+			h := NewDefaultHandler(serviceMock, nil, nil)
 
 			if assert.NoError(t, h.CreateImage(c)) {
 				assert.Equal(t, tc.expectedCode, rec.Code)
@@ -83,6 +256,80 @@ func TestDefaultHandler_CreateImage(t *testing.T) {
 	}
 }
 
+func TestDefaultHandler_CreateImage_QuotaExceeded(t *testing.T) {
+	periodEnd := time.Now().Add(2 * time.Hour)
+	serviceMock := &ServiceMock{
+		CreateImageFunc: func(ctx context.Context, req *CreateImageRequest) (*Image, error) {
+			return nil, fmt.Errorf("reserve: %w", &usage.QuotaError{
+				Usage:     usage.Usage{Plan: "pro", Limit: 100, Used: 100, Enforced: true, PeriodEnd: periodEnd},
+				Requested: 1,
+			})
+		},
+	}
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(
+		`{"project_id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", "original_url": "http://example.com/image.jpg"}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, NewDefaultHandler(serviceMock, nil, nil).CreateImage(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	retryAfter, err := strconv.Atoi(rec.Header().Get(echo.HeaderRetryAfter))
+	require.NoError(t, err)
+	assert.InDelta(t, 2*60*60, retryAfter, 5)
+
+	var body QuotaExceededResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "quota_exceeded", body.Error)
+	assert.Equal(t, 1, body.Requested)
+	assert.Equal(t, 100, body.Usage.Used)
+	assert.Equal(t, "pro", body.Usage.Plan)
+}
+
+func TestDefaultHandler_CreateImage_Source(t *testing.T) {
+	testCases := []struct {
+		name         string
+		header       string
+		expectedCode int
+		wantSource   Source
+	}{
+		{name: "success: web app", header: "web", expectedCode: http.StatusCreated, wantSource: SourceWeb},
+		{name: "success: mobile app", header: " Mobile ", expectedCode: http.StatusCreated, wantSource: SourceMobile},
+		{name: "success: no header counts as api", expectedCode: http.StatusCreated, wantSource: SourceAPI},
+		{name: "fail: unknown surface", header: "unknown", expectedCode: http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body := `{"project_id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", "original_url": "http://example.com/image.jpg"}`
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(body)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if tc.header != "" {
+				req.Header.Set(HeaderSource, tc.header)
+			}
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			serviceMock := &ServiceMock{
+				CreateImageFunc: func(ctx context.Context, req *CreateImageRequest) (*Image, error) {
+					return &Image{ID: uuid.New(), Source: req.Source}, nil
+				},
+			}
+
+			if assert.NoError(t, NewDefaultHandler(serviceMock, nil, nil).CreateImage(c)) {
+				assert.Equal(t, tc.expectedCode, rec.Code)
+			}
+			if tc.wantSource == "" {
+				assert.Empty(t, serviceMock.CreateImageCalls())
+				return
+			}
+			if assert.Len(t, serviceMock.CreateImageCalls(), 1) {
+				assert.Equal(t, tc.wantSource, serviceMock.CreateImageCalls()[0].Req.Source)
+			}
+		})
+	}
+}
+
 func TestDefaultHandler_GetImage(t *testing.T) {
 	testCases := []struct {
 		name         string
@@ -146,7 +393,7 @@ func TestDefaultHandler_GetImage(t *testing.T) {
 			serviceMock := &ServiceMock{}
 			tc.setupMock(serviceMock)
 
-			h := NewDefaultHandler(serviceMock)
+			h := NewDefaultHandler(serviceMock, nil, nil)
 
 			if assert.NoError(t, h.GetImage(c)) {
 				assert.Equal(t, tc.expectedCode, rec.Code)
@@ -208,7 +455,7 @@ func TestDefaultHandler_GetProjectImages(t *testing.T) {
 			serviceMock := &ServiceMock{}
 			tc.setupMock(serviceMock)
 
-			h := NewDefaultHandler(serviceMock)
+			h := NewDefaultHandler(serviceMock, nil, nil)
 
 			if assert.NoError(t, h.GetProjectImages(c)) {
 				assert.Equal(t, tc.expectedCode, rec.Code)
@@ -256,6 +503,16 @@ func TestDefaultHandler_DeleteImage(t *testing.T) {
 			},
 			expectedCode: http.StatusNotFound,
 		},
+		{
+			name:    "fail: conflict - legal hold",
+			imageID: uuid.New().String(),
+			setupMock: func(mock *ServiceMock) {
+				mock.DeleteImageFunc = func(ctx context.Context, imageID string) error {
+					return ErrLegalHold
+				}
+			},
+			expectedCode: http.StatusConflict,
+		},
 		{
 			name:    "fail: service error - internal server error",
 			imageID: uuid.New().String(),
@@ -280,7 +537,7 @@ func TestDefaultHandler_DeleteImage(t *testing.T) {
 			serviceMock := &ServiceMock{}
 			tc.setupMock(serviceMock)
 
-			h := NewDefaultHandler(serviceMock)
+			h := NewDefaultHandler(serviceMock, nil, nil)
 
 			if assert.NoError(t, h.DeleteImage(c)) {
 				assert.Equal(t, tc.expectedCode, rec.Code)
@@ -289,6 +546,282 @@ func TestDefaultHandler_DeleteImage(t *testing.T) {
 	}
 }
 
+func TestDefaultHandler_CancelImage(t *testing.T) {
+	callerID := uuid.New()
+
+	testCases := []struct {
+		name         string
+		imageID      string
+		setupMock    func(*ServiceMock)
+		expectedCode int
+	}{
+		{
+			name:    "success: cancel image",
+			imageID: uuid.New().String(),
+			setupMock: func(mock *ServiceMock) {
+				mock.CancelImageFunc = func(ctx context.Context, imageID, userID string) (*Image, error) {
+					return &Image{ID: uuid.MustParse(imageID), Status: StatusCanceled}, nil
+				}
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "fail: bad request - missing image ID",
+			imageID:      "",
+			setupMock:    func(mock *ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "fail: bad request - invalid image ID",
+			imageID:      "invalid-uuid",
+			setupMock:    func(mock *ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:    "fail: service error - not found",
+			imageID: uuid.New().String(),
+			setupMock: func(mock *ServiceMock) {
+				mock.CancelImageFunc = func(ctx context.Context, imageID, userID string) (*Image, error) {
+					return nil, fmt.Errorf("failed to get image: %w", pgx.ErrNoRows)
+				}
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:    "fail: caller does not own or collaborate on the project",
+			imageID: uuid.New().String(),
+			setupMock: func(mock *ServiceMock) {
+				mock.CancelImageFunc = func(ctx context.Context, imageID, userID string) (*Image, error) {
+					return nil, pgx.ErrNoRows
+				}
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:    "fail: viewers cannot cancel",
+			imageID: uuid.New().String(),
+			setupMock: func(mock *ServiceMock) {
+				mock.CancelImageFunc = func(ctx context.Context, imageID, userID string) (*Image, error) {
+					return nil, ErrImageReadOnly
+				}
+			},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:    "fail: service error - already finished",
+			imageID: uuid.New().String(),
+			setupMock: func(mock *ServiceMock) {
+				mock.CancelImageFunc = func(ctx context.Context, imageID, userID string) (*Image, error) {
+					return nil, ErrImageNotCancelable
+				}
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:    "fail: service error - internal server error",
+			imageID: uuid.New().String(),
+			setupMock: func(mock *ServiceMock) {
+				mock.CancelImageFunc = func(ctx context.Context, imageID, userID string) (*Image, error) {
+					return nil, errors.New("some other error")
+				}
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.imageID)
+
+			serviceMock := &ServiceMock{}
+			tc.setupMock(serviceMock)
+
+			h := NewDefaultHandler(serviceMock, callerRepo(callerID), nil)
+
+			if assert.NoError(t, h.CancelImage(c)) {
+				assert.Equal(t, tc.expectedCode, rec.Code)
+			}
+			for _, call := range serviceMock.CancelImageCalls() {
+				assert.Equal(t, callerID.String(), call.UserID)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_GetImageStatusHistory(t *testing.T) {
+	callerID := uuid.New()
+
+	testCases := []struct {
+		name         string
+		imageID      string
+		setupMock    func(*ServiceMock)
+		expectedCode int
+	}{
+		{
+			name:    "success: get status history",
+			imageID: uuid.New().String(),
+			setupMock: func(mock *ServiceMock) {
+				mock.GetImageStatusHistoryFunc = func(ctx context.Context, imageID, userID string) (*StatusHistory, error) {
+					return &StatusHistory{
+						ImageID: uuid.MustParse(imageID),
+						Status:  StatusQueued,
+						Events:  []StatusEvent{{To: StatusQueued, Source: "real-staging-api"}},
+					}, nil
+				}
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "fail: bad request - invalid image ID",
+			imageID:      "invalid-uuid",
+			setupMock:    func(mock *ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:    "fail: no history",
+			imageID: uuid.New().String(),
+			setupMock: func(mock *ServiceMock) {
+				mock.GetImageStatusHistoryFunc = func(ctx context.Context, imageID, userID string) (*StatusHistory, error) {
+					return nil, ErrNoStatusHistory
+				}
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:    "fail: caller is not a project member",
+			imageID: uuid.New().String(),
+			setupMock: func(mock *ServiceMock) {
+				mock.GetImageStatusHistoryFunc = func(ctx context.Context, imageID, userID string) (*StatusHistory, error) {
+					return nil, pgx.ErrNoRows
+				}
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:    "fail: service error - internal server error",
+			imageID: uuid.New().String(),
+			setupMock: func(mock *ServiceMock) {
+				mock.GetImageStatusHistoryFunc = func(ctx context.Context, imageID, userID string) (*StatusHistory, error) {
+					return nil, errors.New("some other error")
+				}
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.imageID)
+
+			serviceMock := &ServiceMock{}
+			tc.setupMock(serviceMock)
+
+			h := NewDefaultHandler(serviceMock, callerRepo(callerID), nil)
+
+			if assert.NoError(t, h.GetImageStatusHistory(c)) {
+				assert.Equal(t, tc.expectedCode, rec.Code)
+			}
+			for _, call := range serviceMock.GetImageStatusHistoryCalls() {
+				assert.Equal(t, callerID.String(), call.UserID)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_ExpediteImage(t *testing.T) {
+	callerID := uuid.New()
+
+	testCases := []struct {
+		name         string
+		imageID      string
+		serviceErr   error
+		expectedCode int
+	}{
+		{name: "success: expedite image", imageID: uuid.New().String(), expectedCode: http.StatusOK},
+		{name: "fail: bad request - missing image ID", imageID: "", expectedCode: http.StatusBadRequest},
+		{name: "fail: bad request - invalid image ID", imageID: "invalid-uuid", expectedCode: http.StatusBadRequest},
+		{
+			name:         "fail: service error - not found",
+			imageID:      uuid.New().String(),
+			serviceErr:   fmt.Errorf("failed to get image: %w", pgx.ErrNoRows),
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "fail: caller is not a project member",
+			imageID:      uuid.New().String(),
+			serviceErr:   pgx.ErrNoRows,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "fail: viewers cannot expedite",
+			imageID:      uuid.New().String(),
+			serviceErr:   ErrImageReadOnly,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "fail: service error - plan not allowed",
+			imageID:      uuid.New().String(),
+			serviceErr:   ErrExpediteNotAllowed,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "fail: service error - daily limit reached",
+			imageID:      uuid.New().String(),
+			serviceErr:   ErrExpediteLimitReached,
+			expectedCode: http.StatusTooManyRequests,
+		},
+		{
+			name:         "fail: service error - no longer queued",
+			imageID:      uuid.New().String(),
+			serviceErr:   ErrImageNotExpeditable,
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:         "fail: service error - internal server error",
+			imageID:      uuid.New().String(),
+			serviceErr:   errors.New("some other error"),
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.imageID)
+
+			serviceMock := &ServiceMock{
+				ExpediteImageFunc: func(ctx context.Context, imageID, userID string) (*Image, error) {
+					assert.Equal(t, callerID.String(), userID)
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+					return &Image{ID: uuid.MustParse(imageID), Status: StatusQueued}, nil
+				},
+			}
+
+			h := NewDefaultHandler(serviceMock, callerRepo(callerID), nil)
+
+			if assert.NoError(t, h.ExpediteImage(c)) {
+				assert.Equal(t, tc.expectedCode, rec.Code)
+			}
+		})
+	}
+}
+
 func TestDefaultHandler_validateCreateImageRequest(t *testing.T) {
 	projectID := uuid.New()
 	roomType := "living_room"
@@ -365,7 +898,7 @@ func TestDefaultHandler_validateCreateImageRequest(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			h := NewDefaultHandler(nil)
+			h := NewDefaultHandler(nil, nil, nil)
 			errs := h.validateCreateImageRequest(tc.req)
 			if tc.expectError {
 				assert.NotEmpty(t, errs)
@@ -2,9 +2,13 @@ package image
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/real-staging-ai/api/internal/storage"
@@ -26,7 +30,7 @@ func NewDefaultRepository(db storage.Database) *DefaultRepository {
 
 // CreateImage creates a new image in the database.
 func (r *DefaultRepository) CreateImage(
-	ctx context.Context, projectID string, originalURL string, roomType, style *string, seed *int64,
+	ctx context.Context, projectID string, originalURL string, roomType, style *string, seed *int64, source Source,
 ) (*queries.Image, error) {
 	q := queries.New(r.db)
 
@@ -56,6 +60,7 @@ func (r *DefaultRepository) CreateImage(
 		RoomType:    roomTypeText,
 		Style:       styleText,
 		Seed:        seedInt8,
+		Source:      source.column(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create image: %w", err)
@@ -74,11 +79,114 @@ func (r *DefaultRepository) CreateImage(
 		Error:       row.Error,
 		CreatedAt:   row.CreatedAt,
 		UpdatedAt:   row.UpdatedAt,
+		Source:      row.Source,
 	}
 
 	return image, nil
 }
 
+// CreateImageAnnotations stores room measurements for an image.
+func (r *DefaultRepository) CreateImageAnnotations(ctx context.Context, imageID string, annotations Annotations) error {
+	imageUUID, err := uuid.Parse(imageID)
+	if err != nil {
+		return fmt.Errorf("invalid image ID: %w", err)
+	}
+
+	params := queries.CreateImageAnnotationsParams{ImageID: pgtype.UUID{Bytes: imageUUID, Valid: true}}
+	if annotations.WallLengthM != nil {
+		params.WallLengthM = pgtype.Float8{Float64: *annotations.WallLengthM, Valid: true}
+	}
+	if annotations.CeilingHeightM != nil {
+		params.CeilingHeightM = pgtype.Float8{Float64: *annotations.CeilingHeightM, Valid: true}
+	}
+	if err := queries.New(r.db).CreateImageAnnotations(ctx, params); err != nil {
+		return fmt.Errorf("failed to create image annotations: %w", err)
+	}
+	return nil
+}
+
+// CreateImageBrackets stores the other exposures of an image's shot.
+func (r *DefaultRepository) CreateImageBrackets(ctx context.Context, imageID string, urls []string) error {
+	imageUUID, err := uuid.Parse(imageID)
+	if err != nil {
+		return fmt.Errorf("invalid image ID: %w", err)
+	}
+
+	params := queries.CreateImageBracketsParams{ImageID: pgtype.UUID{Bytes: imageUUID, Valid: true}, Urls: urls}
+	if err := queries.New(r.db).CreateImageBrackets(ctx, params); err != nil {
+		return fmt.Errorf("failed to create image brackets: %w", err)
+	}
+	return nil
+}
+
+// CreateImages inserts images with the COPY protocol and reads them back in a single query,
+// which keeps large batches to two round trips instead of one INSERT per image.
+func (r *DefaultRepository) CreateImages(ctx context.Context, reqs []CreateImageRequest) ([]*queries.Image, error) {
+	q := queries.New(r.db)
+
+	params := make([]queries.CreateImagesParams, len(reqs))
+	ids := make([]pgtype.UUID, len(reqs))
+	for i, req := range reqs {
+		ids[i] = pgtype.UUID{Bytes: uuid.New(), Valid: true}
+		params[i] = queries.CreateImagesParams{
+			ID:          ids[i],
+			ProjectID:   pgtype.UUID{Bytes: req.ProjectID, Valid: true},
+			OriginalUrl: req.OriginalURL,
+			Source:      req.Source.column(),
+		}
+		if req.RoomType != nil {
+			params[i].RoomType = pgtype.Text{String: *req.RoomType, Valid: true}
+		}
+		if req.Style != nil {
+			params[i].Style = pgtype.Text{String: *req.Style, Valid: true}
+		}
+		if req.Seed != nil {
+			params[i].Seed = pgtype.Int8{Int64: *req.Seed, Valid: true}
+		}
+	}
+
+	if _, err := q.CreateImages(ctx, params); err != nil {
+		return nil, fmt.Errorf("failed to create images: %w", err)
+	}
+
+	rows, err := q.GetImagesByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get created images: %w", err)
+	}
+
+	byID := make(map[[16]byte]*queries.GetImagesByIDsRow, len(rows))
+	for _, row := range rows {
+		byID[row.ID.Bytes] = row
+	}
+
+	images := make([]*queries.Image, len(ids))
+	for i, id := range ids {
+		row, ok := byID[id.Bytes]
+		if !ok {
+			return nil, fmt.Errorf("created image %s not found", uuid.UUID(id.Bytes))
+		}
+		images[i] = &queries.Image{
+			ID:             row.ID,
+			ProjectID:      row.ProjectID,
+			OriginalUrl:    row.OriginalUrl,
+			StagedUrl:      row.StagedUrl,
+			RoomType:       row.RoomType,
+			Style:          row.Style,
+			Seed:           row.Seed,
+			Status:         row.Status,
+			Error:          row.Error,
+			CreatedAt:      row.CreatedAt,
+			UpdatedAt:      row.UpdatedAt,
+			Source:         row.Source,
+			PreviewUrl:     row.PreviewUrl,
+			WatermarkedUrl: row.WatermarkedUrl,
+			RawUrl:         row.RawUrl,
+		}
+	}
+
+	return images, nil
+}
+
 // GetImageByID retrieves a specific image by its ID.
 func (r *DefaultRepository) GetImageByID(ctx context.Context, imageID string) (*queries.Image, error) {
 	q := queries.New(r.db)
@@ -95,17 +203,21 @@ func (r *DefaultRepository) GetImageByID(ctx context.Context, imageID string) (*
 
 	// Convert GetImageByIDRow to Image
 	image := &queries.Image{
-		ID:          row.ID,
-		ProjectID:   row.ProjectID,
-		OriginalUrl: row.OriginalUrl,
-		StagedUrl:   row.StagedUrl,
-		RoomType:    row.RoomType,
-		Style:       row.Style,
-		Seed:        row.Seed,
-		Status:      row.Status,
-		Error:       row.Error,
-		CreatedAt:   row.CreatedAt,
-		UpdatedAt:   row.UpdatedAt,
+		ID:             row.ID,
+		ProjectID:      row.ProjectID,
+		OriginalUrl:    row.OriginalUrl,
+		StagedUrl:      row.StagedUrl,
+		RoomType:       row.RoomType,
+		Style:          row.Style,
+		Seed:           row.Seed,
+		Status:         row.Status,
+		Error:          row.Error,
+		CreatedAt:      row.CreatedAt,
+		UpdatedAt:      row.UpdatedAt,
+		Source:         row.Source,
+		PreviewUrl:     row.PreviewUrl,
+		WatermarkedUrl: row.WatermarkedUrl,
+		RawUrl:         row.RawUrl,
 	}
 
 	return image, nil
@@ -129,17 +241,21 @@ func (r *DefaultRepository) GetImagesByProjectID(ctx context.Context, projectID
 	images := make([]*queries.Image, len(rows))
 	for i, row := range rows {
 		images[i] = &queries.Image{
-			ID:          row.ID,
-			ProjectID:   row.ProjectID,
-			OriginalUrl: row.OriginalUrl,
-			StagedUrl:   row.StagedUrl,
-			RoomType:    row.RoomType,
-			Style:       row.Style,
-			Seed:        row.Seed,
-			Status:      row.Status,
-			Error:       row.Error,
-			CreatedAt:   row.CreatedAt,
-			UpdatedAt:   row.UpdatedAt,
+			ID:             row.ID,
+			ProjectID:      row.ProjectID,
+			OriginalUrl:    row.OriginalUrl,
+			StagedUrl:      row.StagedUrl,
+			RoomType:       row.RoomType,
+			Style:          row.Style,
+			Seed:           row.Seed,
+			Status:         row.Status,
+			Error:          row.Error,
+			CreatedAt:      row.CreatedAt,
+			UpdatedAt:      row.UpdatedAt,
+			Source:         row.Source,
+			PreviewUrl:     row.PreviewUrl,
+			WatermarkedUrl: row.WatermarkedUrl,
+			RawUrl:         row.RawUrl,
 		}
 	}
 
@@ -178,6 +294,7 @@ func (r *DefaultRepository) UpdateImageStatus(
 		Error:       row.Error,
 		CreatedAt:   row.CreatedAt,
 		UpdatedAt:   row.UpdatedAt,
+		Source:      row.Source,
 	}
 
 	return image, nil
@@ -216,6 +333,7 @@ func (r *DefaultRepository) UpdateImageWithStagedURL(
 		Error:       row.Error,
 		CreatedAt:   row.CreatedAt,
 		UpdatedAt:   row.UpdatedAt,
+		Source:      row.Source,
 	}
 
 	return image, nil
@@ -253,11 +371,159 @@ func (r *DefaultRepository) UpdateImageWithError(
 		Error:       row.Error,
 		CreatedAt:   row.CreatedAt,
 		UpdatedAt:   row.UpdatedAt,
+		Source:      row.Source,
 	}
 
 	return image, nil
 }
 
+// CancelImage marks an unfinished image as canceled and clears its cost.
+func (r *DefaultRepository) CancelImage(ctx context.Context, imageID string) (*queries.Image, error) {
+	q := queries.New(r.db)
+
+	imageUUID, err := uuid.Parse(imageID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image ID: %w", err)
+	}
+
+	row, err := q.CancelImage(ctx, pgtype.UUID{Bytes: imageUUID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel image: %w", err)
+	}
+
+	// Convert CancelImageRow to Image
+	image := &queries.Image{
+		ID:          row.ID,
+		ProjectID:   row.ProjectID,
+		OriginalUrl: row.OriginalUrl,
+		StagedUrl:   row.StagedUrl,
+		RoomType:    row.RoomType,
+		Style:       row.Style,
+		Seed:        row.Seed,
+		Status:      row.Status,
+		Error:       row.Error,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+		Source:      row.Source,
+	}
+
+	return image, nil
+}
+
+// GetImageStatusesByIDs returns the status of each listed image that belongs to the project.
+func (r *DefaultRepository) GetImageStatusesByIDs(
+	ctx context.Context, projectID string, imageIDs []string,
+) ([]*queries.GetImageStatusesByIDsRow, error) {
+	q := queries.New(r.db)
+
+	projectUUID, ids, err := parseBulkIDs(projectID, imageIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.GetImageStatusesByIDs(ctx, queries.GetImageStatusesByIDsParams{
+		ProjectID: projectUUID,
+		ImageIds:  ids,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image statuses: %w", err)
+	}
+
+	return rows, nil
+}
+
+// ListStatusTransitions returns the image's status events oldest first.
+func (r *DefaultRepository) ListStatusTransitions(
+	ctx context.Context, imageID string,
+) ([]*queries.ImageStatusTransition, error) {
+	q := queries.New(r.db)
+
+	imageUUID, err := uuid.Parse(imageID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image ID: %w", err)
+	}
+
+	rows, err := q.ListImageStatusTransitions(ctx, pgtype.UUID{Bytes: imageUUID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list image status transitions: %w", err)
+	}
+
+	return rows, nil
+}
+
+// BulkCancelImages cancels the listed unfinished images and their in-flight jobs atomically.
+func (r *DefaultRepository) BulkCancelImages(
+	ctx context.Context, projectID string, imageIDs []string,
+) ([]*queries.BulkCancelImagesRow, error) {
+	q := queries.New(r.db)
+
+	projectUUID, ids, err := parseBulkIDs(projectID, imageIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.BulkCancelImages(ctx, queries.BulkCancelImagesParams{
+		ProjectID: projectUUID,
+		ImageIds:  ids,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to bulk cancel images: %w", err)
+	}
+
+	return rows, nil
+}
+
+// BulkDeleteImages deletes the listed images that are not under legal hold atomically,
+// returning held images with Held set.
+func (r *DefaultRepository) BulkDeleteImages(
+	ctx context.Context, projectID string, imageIDs []string,
+) ([]*queries.BulkDeleteImagesRow, error) {
+	q := queries.New(r.db)
+
+	projectUUID, ids, err := parseBulkIDs(projectID, imageIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	params := queries.BulkDeleteImagesParams{ProjectID: projectUUID, ImageIds: ids}
+	rows, err := q.BulkDeleteImages(ctx, params)
+	// A hold placed after the statement read the holds makes the delete trigger reject it.
+	// Running it again sees the hold and skips that image.
+	if isLegalHoldViolation(err) {
+		rows, err = q.BulkDeleteImages(ctx, params)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to bulk delete images: %w", err)
+	}
+
+	return rows, nil
+}
+
+// isLegalHoldViolation reports whether err is the delete trigger rejecting a held image.
+func isLegalHoldViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503" && pgErr.TableName == "legal_holds"
+}
+
+// parseBulkIDs converts a project ID and list of image IDs to pgtype UUIDs.
+func parseBulkIDs(projectID string, imageIDs []string) (pgtype.UUID, []pgtype.UUID, error) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return pgtype.UUID{}, nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+
+	ids := make([]pgtype.UUID, len(imageIDs))
+	for i, id := range imageIDs {
+		imageUUID, err := uuid.Parse(id)
+		if err != nil {
+			return pgtype.UUID{}, nil, fmt.Errorf("invalid image ID: %w", err)
+		}
+		ids[i] = pgtype.UUID{Bytes: imageUUID, Valid: true}
+	}
+
+	return pgtype.UUID{Bytes: projectUUID, Valid: true}, ids, nil
+}
+
 // DeleteImage deletes an image from the database.
 func (r *DefaultRepository) DeleteImage(ctx context.Context, imageID string) error {
 	q := queries.New(r.db)
@@ -292,6 +558,31 @@ func (r *DefaultRepository) DeleteImagesByProjectID(ctx context.Context, project
 	return nil
 }
 
+// ListLegalHeldImageIDs returns the images in imageIDs that are under legal hold.
+func (r *DefaultRepository) ListLegalHeldImageIDs(ctx context.Context, imageIDs []string) ([]string, error) {
+	q := queries.New(r.db)
+
+	ids := make([]pgtype.UUID, len(imageIDs))
+	for i, id := range imageIDs {
+		imageUUID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid image ID: %w", err)
+		}
+		ids[i] = pgtype.UUID{Bytes: imageUUID, Valid: true}
+	}
+
+	rows, err := q.ListLegalHeldImageIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list legal held images: %w", err)
+	}
+
+	held := make([]string, len(rows))
+	for i, row := range rows {
+		held[i] = uuid.UUID(row.Bytes).String()
+	}
+	return held, nil
+}
+
 // UpdateImageCost updates cost tracking information for an image.
 func (r *DefaultRepository) UpdateImageCost(
 	ctx context.Context, imageID string, costUSD float64, modelUsed string, processingTimeMs int, predictionID string,
@@ -301,17 +592,13 @@ func (r *DefaultRepository) UpdateImageCost(
 		return fmt.Errorf("invalid image ID: %w", err)
 	}
 
-	query := `
-		UPDATE images
-		SET cost_usd = $1,
-		    model_used = $2,
-		    processing_time_ms = $3,
-		    replicate_prediction_id = $4,
-		    updated_at = NOW()
-		WHERE id = $5
-	`
-
-	_, err = r.db.Exec(ctx, query, costUSD, modelUsed, processingTimeMs, predictionID, imageUUID)
+	err = queries.New(r.db).UpdateImageCost(ctx, queries.UpdateImageCostParams{
+		CostUsd:               costUSD,
+		ModelUsed:             pgtype.Text{String: modelUsed, Valid: true},
+		ProcessingTimeMs:      pgtype.Int4{Int32: int32(processingTimeMs), Valid: true},
+		ReplicatePredictionID: pgtype.Text{String: predictionID, Valid: true},
+		ID:                    pgtype.UUID{Bytes: imageUUID, Valid: true},
+	})
 	if err != nil {
 		return fmt.Errorf("failed to update image cost: %w", err)
 	}
@@ -319,48 +606,143 @@ func (r *DefaultRepository) UpdateImageCost(
 	return nil
 }
 
-// GetProjectCostSummary retrieves cost summary for a project.
+// GetProjectCostSummary retrieves cost summary for a project. Projects without images report zero costs.
 func (r *DefaultRepository) GetProjectCostSummary(ctx context.Context, projectID string) (*ProjectCostSummary, error) {
 	projectUUID, err := uuid.Parse(projectID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid project ID: %w", err)
 	}
 
-	query := `
-		SELECT 
-			project_id,
-			COALESCE(SUM(cost_usd), 0) as total_cost_usd,
-			COUNT(*) as image_count,
-			COALESCE(AVG(cost_usd), 0) as avg_cost_usd
-		FROM images
-		WHERE project_id = $1
-		GROUP BY project_id
-	`
-
-	var summary ProjectCostSummary
-	var dbProjectID pgtype.UUID
-
-	err = r.db.QueryRow(ctx, query, projectUUID).Scan(
-		&dbProjectID,
-		&summary.TotalCostUSD,
-		&summary.ImageCount,
-		&summary.AvgCostUSD,
-	)
-
-	if err != nil {
-		// If no images found, return zero costs
-		if err.Error() == "no rows in result set" {
-			return &ProjectCostSummary{
-				ProjectID:    projectUUID,
-				TotalCostUSD: 0,
-				ImageCount:   0,
-				AvgCostUSD:   0,
-			}, nil
-		}
+	row, err := queries.New(r.db).GetProjectCostSummary(ctx, pgtype.UUID{Bytes: projectUUID, Valid: true})
+	if err != nil {
 		return nil, fmt.Errorf("failed to get project cost summary: %w", err)
 	}
 
-	summary.ProjectID = projectUUID
+	return &ProjectCostSummary{
+		ProjectID:    projectUUID,
+		TotalCostUSD: row.TotalCostUsd,
+		ImageCount:   int(row.ImageCount),
+		AvgCostUSD:   row.AvgCostUsd,
+	}, nil
+}
+
+// GetReferenceImageAccess returns the project's owner and whether their plan allows reference images.
+func (r *DefaultRepository) GetReferenceImageAccess(
+	ctx context.Context, projectID string,
+) (*queries.GetReferenceImageAccessRow, error) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+
+	row, err := queries.New(r.db).GetReferenceImageAccess(ctx, pgtype.UUID{Bytes: projectUUID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reference image access: %w", err)
+	}
+
+	return row, nil
+}
+
+// GetProjectOwnerID returns the ID of the project's owner.
+func (r *DefaultRepository) GetProjectOwnerID(ctx context.Context, projectID string) (string, error) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return "", fmt.Errorf("invalid project ID: %w", err)
+	}
+
+	row, err := queries.New(r.db).GetProjectByID(ctx, pgtype.UUID{Bytes: projectUUID, Valid: true})
+	if err != nil {
+		return "", fmt.Errorf("failed to get project owner: %w", err)
+	}
 
-	return &summary, nil
+	return uuid.UUID(row.UserID.Bytes).String(), nil
+}
+
+// GetProjectRole returns the user's role on the project: owner, editor or viewer. Returns ""
+// when the user neither owns nor collaborates on the project.
+func (r *DefaultRepository) GetProjectRole(ctx context.Context, projectID, userID string) (string, error) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return "", fmt.Errorf("invalid project ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return "", fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	row, err := queries.New(r.db).GetProjectByIDForMember(ctx, queries.GetProjectByIDForMemberParams{
+		ID:     pgtype.UUID{Bytes: projectUUID, Valid: true},
+		UserID: pgtype.UUID{Bytes: userUUID, Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to check project membership: %w", err)
+	}
+	return row.Role, nil
+}
+
+// GetProjectRoomStyle returns the style the project's room checklist gives roomType, or "".
+func (r *DefaultRepository) GetProjectRoomStyle(ctx context.Context, projectID, roomType string) (string, error) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return "", fmt.Errorf("invalid project ID: %w", err)
+	}
+
+	style, err := queries.New(r.db).GetProjectRoomStyle(ctx, queries.GetProjectRoomStyleParams{
+		ProjectID: pgtype.UUID{Bytes: projectUUID, Valid: true},
+		RoomType:  roomType,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get project room style: %w", err)
+	}
+	return style, nil
+}
+
+// GetExpediteAccess returns the image's owner, whether their plan allows expediting, and
+// how many images they have expedited since the given time.
+func (r *DefaultRepository) GetExpediteAccess(
+	ctx context.Context, imageID string, since time.Time,
+) (*queries.GetExpediteAccessRow, error) {
+	imageUUID, err := uuid.Parse(imageID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image ID: %w", err)
+	}
+
+	row, err := queries.New(r.db).GetExpediteAccess(ctx, queries.GetExpediteAccessParams{
+		Since:   pgtype.Timestamptz{Time: since, Valid: true},
+		ImageID: pgtype.UUID{Bytes: imageUUID, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expedite access: %w", err)
+	}
+
+	return row, nil
+}
+
+// RecordImageExpedited appends an image.expedited event to the project's activity log.
+func (r *DefaultRepository) RecordImageExpedited(ctx context.Context, projectID, imageID, jobID, userID string) error {
+	ids := make([]pgtype.UUID, 4)
+	for i, id := range []string{projectID, imageID, jobID, userID} {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			return fmt.Errorf("invalid ID %q: %w", id, err)
+		}
+		ids[i] = pgtype.UUID{Bytes: parsed, Valid: true}
+	}
+
+	err := queries.New(r.db).RecordImageExpedited(ctx, queries.RecordImageExpeditedParams{
+		ProjectID: ids[0],
+		ImageID:   ids[1],
+		JobID:     ids[2],
+		UserID:    ids[3],
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record image expedited: %w", err)
+	}
+	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -43,6 +44,7 @@ func TestDefaultRepository_CreateImage(t *testing.T) {
 		roomType    *string
 		style       *string
 		seed        *int64
+		source      Source
 		setupMock   func(mock pgxmock.PgxPoolIface)
 		expectError bool
 	}{
@@ -53,6 +55,7 @@ func TestDefaultRepository_CreateImage(t *testing.T) {
 			roomType:    &roomType,
 			style:       &style,
 			seed:        &seed,
+			source:      SourceMobile,
 			setupMock: func(mock pgxmock.PgxPoolIface) {
 				mock.ExpectQuery("INSERT INTO images").
 					WithArgs(
@@ -60,11 +63,12 @@ func TestDefaultRepository_CreateImage(t *testing.T) {
 						pgtype.Text{String: "living_room", Valid: true},
 						pgtype.Text{String: "modern", Valid: true},
 						pgtype.Int8{Int64: 123, Valid: true},
+						"mobile",
 					).
 					WillReturnRows(
 						pgxmock.NewRows([]string{
-							"id", "project_id", "original_url", "staged_url",
-							"room_type", "style", "seed", "status", "error", "created_at", "updated_at",
+							"id", "project_id", "original_url", "staged_url", "room_type", "style", "seed",
+							"status", "error", "created_at", "updated_at", "source",
 						}).
 							AddRow(
 								pgtype.UUID{Bytes: uuid.New(), Valid: true},
@@ -73,7 +77,7 @@ func TestDefaultRepository_CreateImage(t *testing.T) {
 								pgtype.Text{String: "living_room", Valid: true},
 								pgtype.Text{String: "modern", Valid: true},
 								pgtype.Int8{Int64: 123, Valid: true},
-								"queued", pgtype.Text{}, pgtype.Timestamptz{}, pgtype.Timestamptz{},
+								"queued", pgtype.Text{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, "mobile",
 							))
 			},
 			expectError: false,
@@ -99,6 +103,7 @@ func TestDefaultRepository_CreateImage(t *testing.T) {
 						pgtype.Text{String: "living_room", Valid: true},
 						pgtype.Text{String: "modern", Valid: true},
 						pgtype.Int8{Int64: 123, Valid: true},
+						"api",
 					).
 					WillReturnError(errors.New("db error"))
 			},
@@ -109,7 +114,7 @@ func TestDefaultRepository_CreateImage(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setupMock(poolMock)
-			_, err := repo.CreateImage(ctx, tc.projectID, tc.originalURL, tc.roomType, tc.style, tc.seed)
+			_, err := repo.CreateImage(ctx, tc.projectID, tc.originalURL, tc.roomType, tc.style, tc.seed, tc.source)
 
 			if tc.expectError {
 				assert.Error(t, err)
@@ -154,7 +159,8 @@ func TestDefaultRepository_GetImageByID(t *testing.T) {
 					WillReturnRows(
 						pgxmock.NewRows([]string{
 							"id", "project_id", "original_url", "staged_url",
-							"room_type", "style", "seed", "status", "error", "created_at", "updated_at",
+							"room_type", "style", "seed", "status", "error", "created_at", "updated_at", "source",
+							"preview_url", "watermarked_url", "raw_url",
 						}).
 							AddRow(
 								pgtype.UUID{Bytes: uuid.New(), Valid: true},
@@ -163,7 +169,8 @@ func TestDefaultRepository_GetImageByID(t *testing.T) {
 								pgtype.Text{String: "living_room", Valid: true},
 								pgtype.Text{String: "modern", Valid: true},
 								pgtype.Int8{Int64: 123, Valid: true},
-								"queued", pgtype.Text{}, pgtype.Timestamptz{}, pgtype.Timestamptz{},
+								"queued", pgtype.Text{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, "web",
+								pgtype.Text{}, pgtype.Text{}, pgtype.Text{},
 							))
 			},
 			expectError: false,
@@ -328,7 +335,7 @@ func TestDefaultRepository_UpdateImageStatus(t *testing.T) {
 					WithArgs(pgtype.UUID{Bytes: imageID, Valid: true}, queries.ImageStatusProcessing).
 					WillReturnRows(pgxmock.NewRows(
 						[]string{"id", "project_id", "original_url", "staged_url", "room_type", "style",
-							"seed", "status", "error", "created_at", "updated_at"}).
+							"seed", "status", "error", "created_at", "updated_at", "source"}).
 						AddRow(
 							pgtype.UUID{Bytes: imageID, Valid: true},
 							pgtype.UUID{Bytes: uuid.New(), Valid: true},
@@ -340,7 +347,8 @@ func TestDefaultRepository_UpdateImageStatus(t *testing.T) {
 							"processing",
 							pgtype.Text{},
 							pgtype.Timestamptz{},
-							pgtype.Timestamptz{}))
+							pgtype.Timestamptz{},
+							"web"))
 
 			},
 			expectError: false,
@@ -419,7 +427,7 @@ func TestDefaultRepository_UpdateImageWithStagedURL(t *testing.T) {
 						queries.ImageStatusReady).
 					WillReturnRows(pgxmock.NewRows(
 						[]string{"id", "project_id", "original_url", "staged_url", "room_type", "style",
-							"seed", "status", "error", "created_at", "updated_at"}).
+							"seed", "status", "error", "created_at", "updated_at", "source"}).
 						AddRow(
 							pgtype.UUID{Bytes: imageID, Valid: true},
 							pgtype.UUID{Bytes: uuid.New(), Valid: true},
@@ -431,7 +439,8 @@ func TestDefaultRepository_UpdateImageWithStagedURL(t *testing.T) {
 							"ready",
 							pgtype.Text{},
 							pgtype.Timestamptz{},
-							pgtype.Timestamptz{}))
+							pgtype.Timestamptz{},
+							"web"))
 
 			},
 			expectError: false,
@@ -518,7 +527,8 @@ func TestDefaultRepository_UpdateImageWithError(t *testing.T) {
 							"status",
 							"error",
 							"created_at",
-							"updated_at"}).
+							"updated_at",
+							"source"}).
 						AddRow(
 							pgtype.UUID{Bytes: imageID, Valid: true},
 							pgtype.UUID{Bytes: uuid.New(), Valid: true},
@@ -530,7 +540,8 @@ func TestDefaultRepository_UpdateImageWithError(t *testing.T) {
 							"error",
 							pgtype.Text{String: errorMsg, Valid: true},
 							pgtype.Timestamptz{},
-							pgtype.Timestamptz{}))
+							pgtype.Timestamptz{},
+							"web"))
 			},
 			expectError: false,
 		},
@@ -569,6 +580,99 @@ func TestDefaultRepository_UpdateImageWithError(t *testing.T) {
 	}
 }
 
+func TestDefaultRepository_CancelImage(t *testing.T) {
+	ctx := context.Background()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	dbMock := &storage.DatabaseMock{
+		QueryRowFunc: func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+			return mock.QueryRow(ctx, sql, args...)
+		},
+	}
+
+	repo := NewDefaultRepository(dbMock)
+
+	imageID := uuid.New()
+
+	testCases := []struct {
+		name        string
+		imageID     string
+		setupMock   func(mock pgxmock.PgxPoolIface)
+		expectError error
+	}{
+		{
+			name:    "success: cancel image",
+			imageID: imageID.String(),
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("CancelImage").
+					WithArgs(pgtype.UUID{Bytes: imageID, Valid: true}).
+					WillReturnRows(pgxmock.NewRows(
+						[]string{"id",
+							"project_id",
+							"original_url",
+							"staged_url",
+							"room_type",
+							"style",
+							"seed",
+							"status",
+							"error",
+							"created_at",
+							"updated_at",
+							"source"}).
+						AddRow(
+							pgtype.UUID{Bytes: imageID, Valid: true},
+							pgtype.UUID{Bytes: uuid.New(), Valid: true},
+							"http://example.com/image.jpg",
+							pgtype.Text{},
+							pgtype.Text{},
+							pgtype.Text{},
+							pgtype.Int8{},
+							"canceled",
+							pgtype.Text{},
+							pgtype.Timestamptz{},
+							pgtype.Timestamptz{},
+							"web"))
+			},
+		},
+		{
+			name:        "fail: invalid image ID",
+			imageID:     "invalid-uuid",
+			setupMock:   func(mock pgxmock.PgxPoolIface) {},
+			expectError: errors.New("invalid image ID: invalid UUID length: 12"),
+		},
+		{
+			name:    "fail: image not cancelable",
+			imageID: imageID.String(),
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("CancelImage").
+					WithArgs(pgtype.UUID{Bytes: imageID, Valid: true}).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			expectError: pgx.ErrNoRows,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock(mock)
+			img, err := repo.CancelImage(ctx, tc.imageID)
+
+			if tc.expectError != nil {
+				assert.Error(t, err)
+				if errors.Is(tc.expectError, pgx.ErrNoRows) {
+					assert.ErrorIs(t, err, pgx.ErrNoRows)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, queries.ImageStatusCanceled, img.Status)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestDefaultRepository_DeleteImage(t *testing.T) {
 	ctx := context.Background()
 	poolMock, err := pgxmock.NewPool()
@@ -724,3 +828,369 @@ func TestDefaultRepository_DeleteImagesByProjectID(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultRepository_GetProjectCostSummary(t *testing.T) {
+	ctx := context.Background()
+	poolMock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer poolMock.Close()
+
+	dbMock := &storage.DatabaseMock{
+		QueryRowFunc: func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+			return poolMock.QueryRow(ctx, sql, args...)
+		},
+	}
+
+	repo := NewDefaultRepository(dbMock)
+
+	projectID := uuid.New()
+
+	testCases := []struct {
+		name        string
+		projectID   string
+		setupMock   func()
+		expected    *ProjectCostSummary
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name:      "success: sums image costs",
+			projectID: projectID.String(),
+			setupMock: func() {
+				poolMock.ExpectQuery("FROM images").
+					WithArgs(pgtype.UUID{Bytes: projectID, Valid: true}).
+					WillReturnRows(pgxmock.NewRows([]string{"total_cost_usd", "image_count", "avg_cost_usd"}).
+						AddRow(0.075, int64(3), 0.025))
+			},
+			expected: &ProjectCostSummary{ProjectID: projectID, TotalCostUSD: 0.075, ImageCount: 3, AvgCostUSD: 0.025},
+		},
+		{
+			name:      "success: project without images reports zero",
+			projectID: projectID.String(),
+			setupMock: func() {
+				poolMock.ExpectQuery("FROM images").
+					WithArgs(pgtype.UUID{Bytes: projectID, Valid: true}).
+					WillReturnRows(pgxmock.NewRows([]string{"total_cost_usd", "image_count", "avg_cost_usd"}).
+						AddRow(0.0, int64(0), 0.0))
+			},
+			expected: &ProjectCostSummary{ProjectID: projectID},
+		},
+		{
+			name:        "fail: invalid project ID",
+			projectID:   "invalid-uuid",
+			setupMock:   func() {},
+			expectError: true,
+			errorMsg:    "invalid project ID",
+		},
+		{
+			name:      "fail: query error",
+			projectID: projectID.String(),
+			setupMock: func() {
+				poolMock.ExpectQuery("FROM images").
+					WithArgs(pgtype.UUID{Bytes: projectID, Valid: true}).
+					WillReturnError(errors.New("database error"))
+			},
+			expectError: true,
+			errorMsg:    "failed to get project cost summary",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+
+			summary, err := repo.GetProjectCostSummary(ctx, tc.projectID)
+
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorMsg)
+				assert.Nil(t, summary)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, summary)
+			}
+
+			assert.NoError(t, poolMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDefaultRepository_CreateImages(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+	roomType := "bedroom"
+	reqs := []CreateImageRequest{
+		{ProjectID: projectID, OriginalURL: "https://example.com/1.jpg", RoomType: &roomType},
+		{ProjectID: projectID, OriginalURL: "https://example.com/2.jpg"},
+	}
+	copyColumns := []string{"id", "project_id", "original_url", "room_type", "style", "seed", "source"}
+	rowColumns := []string{
+		"id", "project_id", "original_url", "staged_url", "room_type", "style",
+		"seed", "status", "error", "created_at", "updated_at", "source", "preview_url", "watermarked_url",
+		"raw_url",
+	}
+
+	testCases := []struct {
+		name        string
+		setupMock   func(mock pgxmock.PgxPoolIface, ids *[]pgtype.UUID)
+		expectError string
+	}{
+		{
+			name: "success: copies and reads back in request order",
+			setupMock: func(mock pgxmock.PgxPoolIface, ids *[]pgtype.UUID) {
+				mock.ExpectCopyFrom(pgx.Identifier{"images"}, copyColumns).WillReturnResult(int64(len(reqs)))
+				mock.ExpectQuery("FROM images").
+					WithArgs(pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows(rowColumns).AddRows(
+						// Returned in reverse to check the repository restores request order.
+						[]any{(*ids)[1], pgtype.UUID{Bytes: projectID, Valid: true}, reqs[1].OriginalURL, pgtype.Text{},
+							pgtype.Text{}, pgtype.Text{}, pgtype.Int8{}, queries.ImageStatusQueued, pgtype.Text{},
+							pgtype.Timestamptz{}, pgtype.Timestamptz{}, "web", pgtype.Text{}, pgtype.Text{}, pgtype.Text{}},
+						[]any{(*ids)[0], pgtype.UUID{Bytes: projectID, Valid: true}, reqs[0].OriginalURL, pgtype.Text{},
+							pgtype.Text{String: roomType, Valid: true}, pgtype.Text{}, pgtype.Int8{},
+							queries.ImageStatusQueued, pgtype.Text{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, "web",
+							pgtype.Text{}, pgtype.Text{}, pgtype.Text{}},
+					))
+			},
+		},
+		{
+			name: "fail: copy error",
+			setupMock: func(mock pgxmock.PgxPoolIface, ids *[]pgtype.UUID) {
+				mock.ExpectCopyFrom(pgx.Identifier{"images"}, copyColumns).WillReturnError(errors.New("copy failed"))
+			},
+			expectError: "failed to create images",
+		},
+		{
+			name: "fail: read back error",
+			setupMock: func(mock pgxmock.PgxPoolIface, ids *[]pgtype.UUID) {
+				mock.ExpectCopyFrom(pgx.Identifier{"images"}, copyColumns).WillReturnResult(int64(len(reqs)))
+				mock.ExpectQuery("FROM images").WithArgs(pgxmock.AnyArg()).WillReturnError(errors.New("db down"))
+			},
+			expectError: "failed to get created images",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			poolMock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer poolMock.Close()
+
+			// The repository generates IDs, so capture them from the COPY source before the read back.
+			var ids []pgtype.UUID
+			dbMock := &storage.DatabaseMock{
+				CopyFromFunc: func(
+					ctx context.Context, table pgx.Identifier, columns []string, rowSrc pgx.CopyFromSource,
+				) (int64, error) {
+					for rowSrc.Next() {
+						values, err := rowSrc.Values()
+						require.NoError(t, err)
+						ids = append(ids, values[0].(pgtype.UUID))
+					}
+					tc.setupMock(poolMock, &ids)
+					return poolMock.CopyFrom(ctx, table, columns, rowSrc)
+				},
+				QueryFunc: poolMock.Query,
+			}
+
+			images, err := NewDefaultRepository(dbMock).CreateImages(ctx, reqs)
+
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				assert.Nil(t, images)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, images, len(reqs))
+				assert.Equal(t, ids[0], images[0].ID)
+				assert.Equal(t, reqs[0].OriginalURL, images[0].OriginalUrl)
+				assert.Equal(t, roomType, images[0].RoomType.String)
+				assert.Equal(t, ids[1], images[1].ID)
+			}
+			assert.NoError(t, poolMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDefaultRepository_GetExpediteAccess(t *testing.T) {
+	ctx := context.Background()
+	poolMock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer poolMock.Close()
+
+	dbMock := &storage.DatabaseMock{
+		QueryRowFunc: func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+			return poolMock.QueryRow(ctx, sql, args...)
+		},
+	}
+	repo := NewDefaultRepository(dbMock)
+
+	imageID := uuid.New()
+	projectID := uuid.New()
+	userID := uuid.New()
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success: returns access row", func(t *testing.T) {
+		poolMock.ExpectQuery("-- name: GetExpediteAccess :one").
+			WithArgs(pgtype.Timestamptz{Time: since, Valid: true}, pgtype.UUID{Bytes: imageID, Valid: true}).
+			WillReturnRows(pgxmock.NewRows([]string{"project_id", "user_id", "allowed", "expedited_since"}).
+				AddRow(
+					pgtype.UUID{Bytes: projectID, Valid: true}, pgtype.UUID{Bytes: userID, Valid: true},
+					true, int64(2),
+				))
+
+		row, err := repo.GetExpediteAccess(ctx, imageID.String(), since)
+		require.NoError(t, err)
+		assert.True(t, row.Allowed)
+		assert.Equal(t, int64(2), row.ExpeditedSince)
+		assert.Equal(t, userID, uuid.UUID(row.UserID.Bytes))
+		assert.NoError(t, poolMock.ExpectationsWereMet())
+	})
+
+	t.Run("fail: invalid image ID", func(t *testing.T) {
+		_, err := repo.GetExpediteAccess(ctx, "invalid-uuid", since)
+		assert.Error(t, err)
+	})
+
+	t.Run("fail: image not found", func(t *testing.T) {
+		poolMock.ExpectQuery("-- name: GetExpediteAccess :one").
+			WithArgs(pgtype.Timestamptz{Time: since, Valid: true}, pgtype.UUID{Bytes: imageID, Valid: true}).
+			WillReturnError(pgx.ErrNoRows)
+
+		_, err := repo.GetExpediteAccess(ctx, imageID.String(), since)
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+		assert.NoError(t, poolMock.ExpectationsWereMet())
+	})
+}
+
+func TestDefaultRepository_RecordImageExpedited(t *testing.T) {
+	ctx := context.Background()
+	poolMock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer poolMock.Close()
+
+	dbMock := &storage.DatabaseMock{
+		ExecFunc: func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+			return poolMock.Exec(ctx, sql, args...)
+		},
+	}
+	repo := NewDefaultRepository(dbMock)
+
+	projectID, imageID, jobID, userID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	t.Run("success: inserts activity", func(t *testing.T) {
+		poolMock.ExpectExec("INSERT INTO project_activity").
+			WithArgs(
+				pgtype.UUID{Bytes: projectID, Valid: true},
+				pgtype.UUID{Bytes: imageID, Valid: true},
+				pgtype.UUID{Bytes: jobID, Valid: true},
+				pgtype.UUID{Bytes: userID, Valid: true},
+			).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		err := repo.RecordImageExpedited(ctx, projectID.String(), imageID.String(), jobID.String(), userID.String())
+		assert.NoError(t, err)
+		assert.NoError(t, poolMock.ExpectationsWereMet())
+	})
+
+	t.Run("fail: invalid job ID", func(t *testing.T) {
+		err := repo.RecordImageExpedited(ctx, projectID.String(), imageID.String(), "invalid-uuid", userID.String())
+		assert.Error(t, err)
+	})
+}
+
+func TestDefaultRepository_GetProjectRole(t *testing.T) {
+	ctx := context.Background()
+	poolMock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer poolMock.Close()
+
+	dbMock := &storage.DatabaseMock{
+		QueryRowFunc: func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+			return poolMock.QueryRow(ctx, sql, args...)
+		},
+	}
+	repo := NewDefaultRepository(dbMock)
+
+	projectID, userID := uuid.New(), uuid.New()
+	args := []interface{}{pgtype.UUID{Bytes: projectID, Valid: true}, pgtype.UUID{Bytes: userID, Valid: true}}
+
+	t.Run("success: collaborator's role", func(t *testing.T) {
+		poolMock.ExpectQuery("-- name: GetProjectByIDForMember :one").
+			WithArgs(args...).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "user_id", "created_at", "role"}).
+				AddRow(
+					pgtype.UUID{Bytes: projectID, Valid: true}, "Listing", pgtype.UUID{Bytes: uuid.New(), Valid: true},
+					pgtype.Timestamptz{Time: time.Now(), Valid: true}, "editor",
+				))
+
+		role, err := repo.GetProjectRole(ctx, projectID.String(), userID.String())
+		require.NoError(t, err)
+		assert.Equal(t, "editor", role)
+		assert.NoError(t, poolMock.ExpectationsWereMet())
+	})
+
+	t.Run("success: anyone else has no role", func(t *testing.T) {
+		poolMock.ExpectQuery("-- name: GetProjectByIDForMember :one").
+			WithArgs(args...).
+			WillReturnError(pgx.ErrNoRows)
+
+		role, err := repo.GetProjectRole(ctx, projectID.String(), userID.String())
+		require.NoError(t, err)
+		assert.Empty(t, role)
+		assert.NoError(t, poolMock.ExpectationsWereMet())
+	})
+
+	t.Run("fail: invalid user ID", func(t *testing.T) {
+		_, err := repo.GetProjectRole(ctx, projectID.String(), "invalid-uuid")
+		assert.Error(t, err)
+	})
+}
+
+func TestDefaultRepository_BulkDeleteImages(t *testing.T) {
+	ctx := context.Background()
+	poolMock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer poolMock.Close()
+
+	dbMock := &storage.DatabaseMock{
+		QueryFunc: func(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+			return poolMock.Query(ctx, sql, args...)
+		},
+	}
+	repo := NewDefaultRepository(dbMock)
+
+	projectID, imageID, heldID := uuid.New(), uuid.New(), uuid.New()
+	args := []interface{}{
+		pgtype.UUID{Bytes: projectID, Valid: true},
+		[]pgtype.UUID{{Bytes: imageID, Valid: true}, {Bytes: heldID, Valid: true}},
+	}
+	holdErr := &pgconn.PgError{Code: "23503", TableName: "legal_holds"}
+
+	t.Run("success: retries when a hold lands mid-statement", func(t *testing.T) {
+		poolMock.ExpectQuery("-- name: BulkDeleteImages :many").
+			WithArgs(args...).
+			WillReturnError(holdErr)
+		poolMock.ExpectQuery("-- name: BulkDeleteImages :many").
+			WithArgs(args...).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "status", "held"}).
+				AddRow(pgtype.UUID{Bytes: imageID, Valid: true}, queries.ImageStatusReady, false).
+				AddRow(pgtype.UUID{Bytes: heldID, Valid: true}, queries.ImageStatusReady, true))
+
+		rows, err := repo.BulkDeleteImages(ctx, projectID.String(), []string{imageID.String(), heldID.String()})
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.False(t, rows[0].Held)
+		assert.True(t, rows[1].Held)
+		assert.NoError(t, poolMock.ExpectationsWereMet())
+	})
+
+	t.Run("fail: other database errors are not retried", func(t *testing.T) {
+		poolMock.ExpectQuery("-- name: BulkDeleteImages :many").
+			WithArgs(args...).
+			WillReturnError(errors.New("db error"))
+
+		_, err := repo.BulkDeleteImages(ctx, projectID.String(), []string{imageID.String(), heldID.String()})
+		assert.EqualError(t, err, "failed to bulk delete images: db error")
+		assert.NoError(t, poolMock.ExpectationsWereMet())
+	})
+}
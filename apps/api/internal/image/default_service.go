@@ -3,40 +3,123 @@ package image
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/catalog"
 	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/entitlement"
 	"github.com/real-staging-ai/api/internal/job"
 	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/preset"
+	"github.com/real-staging-ai/api/internal/project"
 	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/sse"
+	"github.com/real-staging-ai/api/internal/storage"
 	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/usage"
 )
 
 var jsonMarshal = json.Marshal
 
+// expediteWindow is the rolling window the expedite daily limit is counted over.
+const expediteWindow = 24 * time.Hour
+
 // DefaultService handles business logic for image operations.
 type DefaultService struct {
+	// db, when set, lets an image and its job be created in one transaction.
+	db        storage.Database
 	imageRepo Repository
 	jobRepo   job.Repository
 	enqueuer  queue.Enqueuer
+	canceler  queue.Canceler
+	expediter queue.Expediter
+	// statuses announces queued images on their project's event stream.
+	statuses sse.Publisher
+	// expediteLimit is how many images one user may expedite per expediteWindow.
+	expediteLimit int
+	// catalogs resolves catalog_id on create requests. Nil rejects requests that set one.
+	catalogs catalog.Service
+	// presets resolves preset_id on create requests. Nil rejects requests that set one.
+	presets preset.Service
+	// buckets checks original URLs and reference image uploads. Nil skips the original URL
+	// check and fails requests that set a reference image.
+	buckets storage.Buckets
+	// entitlements answers plan checks on create requests without querying subscriptions.
+	// Nil reads the plan from the database with each check.
+	entitlements entitlement.Service
+	// usage counts created images against their project owner's monthly limit. Nil skips
+	// metering.
+	usage usage.Service
+	// batch groups the stage:run tasks of batch-created images by project, for the worker
+	// to run each group as one batch job.
+	batch bool
 }
 
 // NewDefaultService creates a new DefaultService instance.
 func NewDefaultService(cfg *config.Config, imageRepo Repository, jobRepo job.Repository) *DefaultService {
-	// Best-effort build an enqueuer from env or config; fall back to Noop if not configured.
+	// Best-effort build an enqueuer from config; fall back to Noop if Redis is not configured.
 	var enq queue.Enqueuer
-	if e, err := queue.NewAsynqEnqueuerFromEnv(cfg); err == nil {
+	if e, err := queue.NewAsynqEnqueuerFromConfig(cfg); err == nil {
 		enq = e
 	} else {
 		enq = queue.NoopEnqueuer{}
 	}
+	var canc queue.Canceler
+	if c, err := queue.NewAsynqCancelerFromConfig(cfg); err == nil {
+		canc = c
+	} else {
+		canc = queue.NoopCanceler{}
+	}
+	var exp queue.Expediter
+	if e, err := queue.NewAsynqExpediterFromConfig(cfg); err == nil {
+		exp = e
+	} else {
+		exp = queue.NoopExpediter{}
+	}
+	var statuses sse.Publisher
+	if p, err := sse.NewDefaultPublisherFromConfig(sse.Config{RedisAddr: cfg.Redis.Addr}); err == nil {
+		statuses = p
+	} else {
+		statuses = sse.NoopPublisher{}
+	}
 	return &DefaultService{
-		imageRepo: imageRepo,
-		jobRepo:   jobRepo,
-		enqueuer:  enq,
+		imageRepo:     imageRepo,
+		jobRepo:       jobRepo,
+		enqueuer:      enq,
+		canceler:      canc,
+		expediter:     exp,
+		statuses:      statuses,
+		expediteLimit: cfg.Expedite.DailyLimit,
+		batch:         cfg.Job.BatchWindow > 0,
 	}
 }
 
+// NewDefaultServiceWithDB creates a DefaultService whose repositories are backed by db, creating
+// each image and its job in one transaction. buckets is used to check original URLs and
+// reference image uploads, and entitlements, when not nil, to check the owner's plan and
+// monthly limit.
+func NewDefaultServiceWithDB(
+	cfg *config.Config, db storage.Database, buckets storage.Buckets, entitlements entitlement.Service,
+) *DefaultService {
+	s := NewDefaultService(cfg, NewDefaultRepository(db), job.NewDefaultRepository(db))
+	s.db = db
+	s.catalogs = catalog.NewDefaultService(db)
+	s.presets = preset.NewDefaultService(db)
+	s.buckets = buckets
+	s.entitlements = entitlements
+	s.usage = usage.NewDefaultService(db, entitlements, cfg.Usage)
+	return s
+}
+
 // CreateImage creates a new image and queues it for processing.
 func (s *DefaultService) CreateImage(ctx context.Context, req *CreateImageRequest) (*Image, error) {
 	log := logging.NewDefaultLogger()
@@ -46,19 +129,395 @@ func (s *DefaultService) CreateImage(ctx context.Context, req *CreateImageReques
 		return nil, err
 	}
 
+	opts, err := s.stageOptions(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	release, err := s.reserveUsage(ctx, []CreateImageRequest{*req})
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the image and its job together so an image is never left without a job.
+	var created *createdImage
+	err = s.withTx(ctx, func(imageRepo Repository, jobRepo job.Repository) error {
+		var err error
+		created, err = s.createImageWithJob(ctx, imageRepo, jobRepo, req, opts)
+		return err
+	})
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	// Enqueue only after the rows are committed so the worker can see them.
+	if err := s.enqueue(ctx, created, false); err != nil {
+		return nil, err
+	}
+
+	return created.image, nil
+}
+
+// BatchCreateImages creates multiple images in a single transaction.
+func (s *DefaultService) BatchCreateImages(
+	ctx context.Context, reqs []CreateImageRequest,
+) (*BatchCreateImagesResponse, error) {
+	log := logging.NewDefaultLogger()
+
+	response := &BatchCreateImagesResponse{
+		Images: []*Image{},
+		Errors: []BatchImageError{},
+	}
+
+	opts := make([]stageOptions, len(reqs))
+	for i := range reqs {
+		var err error
+		if opts[i], err = s.stageOptions(ctx, &reqs[i]); err != nil {
+			return nil, fmt.Errorf("images[%d]: %w", i, err)
+		}
+	}
+	release, err := s.reserveUsage(ctx, reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create every image and job first; a failure rolls back the whole batch.
+	var created []*createdImage
+	err = s.withTx(ctx, func(imageRepo Repository, jobRepo job.Repository) error {
+		var err error
+		created, err = s.createImagesWithJobs(ctx, imageRepo, jobRepo, reqs, opts)
+		return err
+	})
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	for _, c := range created {
+		if err := s.enqueue(ctx, c, s.batch && len(created) > 1); err != nil {
+			return nil, err
+		}
+		response.Images = append(response.Images, c.image)
+	}
+
+	log.Info(ctx, "batch create completed",
+		"total", len(reqs),
+		"success", len(response.Images),
+		"failed", len(response.Errors))
+	return response, nil
+}
+
+// reserveUsage counts reqs against their project owners' monthly limits before anything is
+// created, failing with a *usage.QuotaError when an owner would pass theirs. The returned
+// func gives the counts back for when the images are not created. Requests of the sandbox
+// tenant, set from the authenticated key, are not counted; the request's own sandbox flag
+// only picks the provider and is counted like any other image.
+func (s *DefaultService) reserveUsage(ctx context.Context, reqs []CreateImageRequest) (func(), error) {
+	if s.usage == nil || storage.SandboxFrom(ctx) {
+		return func() {}, nil
+	}
+
+	// Count per owner in request order, resolving each project once.
+	var owners []string
+	counts := map[string]int{}
+	projectOwners := map[uuid.UUID]string{}
+	for _, req := range reqs {
+		ownerID, ok := projectOwners[req.ProjectID]
+		if !ok {
+			var err error
+			if ownerID, err = s.imageRepo.GetProjectOwnerID(ctx, req.ProjectID.String()); err != nil {
+				return nil, err
+			}
+			projectOwners[req.ProjectID] = ownerID
+		}
+		if counts[ownerID] == 0 {
+			owners = append(owners, ownerID)
+		}
+		counts[ownerID]++
+	}
+
+	var reserved []*usage.Usage
+	release := func() {
+		log := logging.NewDefaultLogger()
+		ctx := context.WithoutCancel(ctx)
+		for i, u := range reserved {
+			if err := s.usage.Release(ctx, owners[i], u.PeriodStart, counts[owners[i]]); err != nil {
+				log.Error(ctx, "failed to release usage", "user_id", owners[i], "error", err)
+			}
+		}
+	}
+	for _, ownerID := range owners {
+		u, err := s.usage.Reserve(ctx, ownerID, counts[ownerID])
+		if err != nil {
+			release()
+			return nil, err
+		}
+		reserved = append(reserved, u)
+	}
+	return release, nil
+}
+
+// releaseCanceled gives canceled images of projectID, created at the given times, back to
+// the project owner's usage. Only images created in the owner's current period are given
+// back; earlier periods have closed. Failures are logged, as the images are already canceled.
+func (s *DefaultService) releaseCanceled(ctx context.Context, projectID string, created []time.Time) {
+	if s.usage == nil || storage.SandboxFrom(ctx) || len(created) == 0 {
+		return
+	}
+	log := logging.NewDefaultLogger()
+	ctx = context.WithoutCancel(ctx)
+
+	ownerID, err := s.imageRepo.GetProjectOwnerID(ctx, projectID)
+	if err != nil {
+		log.Error(ctx, "failed to release canceled usage", "project_id", projectID, "error", err)
+		return
+	}
+	u, err := s.usage.Get(ctx, ownerID)
+	if err != nil {
+		log.Error(ctx, "failed to release canceled usage", "user_id", ownerID, "error", err)
+		return
+	}
+	n := 0
+	for _, at := range created {
+		if !at.Before(u.PeriodStart) {
+			n++
+		}
+	}
+	if n == 0 {
+		return
+	}
+	if err := s.usage.Release(ctx, ownerID, u.PeriodStart, n); err != nil {
+		log.Error(ctx, "failed to release canceled usage", "user_id", ownerID, "error", err)
+	}
+}
+
+// createdImage is an image and its job that have been persisted but not yet enqueued.
+type createdImage struct {
+	image *Image
+	job   *queries.Job
+	opts  stageOptions
+}
+
+// stageOptions are the per-request staging settings carried in the job payload.
+type stageOptions struct {
+	sandbox            bool
+	catalog            *queue.CatalogPayload
+	referenceImageURL  string
+	bracketURLs        []string
+	correctPerspective bool
+	annotations        *queue.Annotations
+	instructions       string
+}
+
+// stageOptions resolves req's staging settings, checking the original and bracket URLs,
+// applying the picked preset, copying the picked catalog and checking the reference image.
+func (s *DefaultService) stageOptions(ctx context.Context, req *CreateImageRequest) (stageOptions, error) {
+	opts := stageOptions{sandbox: req.Sandbox, bracketURLs: req.BracketURLs, correctPerspective: req.CorrectPerspective}
+	if s.buckets != nil {
+		err := s.buckets.Authorize(ctx, req.ProjectID.String(), req.OriginalURL)
+		if errors.Is(err, storage.ErrForeignBucket) {
+			return opts, fmt.Errorf("%w: %w", ErrOriginalURLInvalid, err)
+		}
+		if err != nil {
+			return opts, fmt.Errorf("failed to check original URL: %w", err)
+		}
+		for _, bracketURL := range req.BracketURLs {
+			err := s.buckets.Authorize(ctx, req.ProjectID.String(), bracketURL)
+			if errors.Is(err, storage.ErrForeignBucket) {
+				return opts, fmt.Errorf("%w: %w", ErrBracketURLInvalid, err)
+			}
+			if err != nil {
+				return opts, fmt.Errorf("failed to check bracket URL: %w", err)
+			}
+		}
+	}
+	if req.PresetID != nil {
+		var err error
+		if opts.instructions, err = s.applyPreset(ctx, req); err != nil {
+			return opts, err
+		}
+	}
+	if req.RoomType != nil && req.Style == nil {
+		if err := s.applyRoomStyle(ctx, req); err != nil {
+			return opts, err
+		}
+	}
+	if a := req.Annotations; a != nil {
+		opts.annotations = &queue.Annotations{WallLengthM: a.WallLengthM, CeilingHeightM: a.CeilingHeightM}
+	}
+	if req.ReferenceImageKey != "" {
+		var err error
+		if opts.referenceImageURL, err = s.referenceImageURL(ctx, req); err != nil {
+			return opts, err
+		}
+	}
+	if req.CatalogID == nil {
+		return opts, nil
+	}
+	if s.catalogs == nil {
+		return opts, ErrCatalogUnavailable
+	}
+	c, err := s.catalogs.GetActive(ctx, req.CatalogID.String())
+	if errors.Is(err, catalog.ErrNotFound) {
+		return opts, ErrCatalogUnavailable
+	}
+	if err != nil {
+		return opts, fmt.Errorf("failed to resolve catalog: %w", err)
+	}
+	opts.catalog = &queue.CatalogPayload{
+		ID:                 c.ID,
+		Name:               c.Name,
+		PromptFragment:     c.PromptFragment,
+		ReferenceImageURLs: c.ReferenceImageURLs,
+	}
+	return opts, nil
+}
+
+// applyPreset fills the room type, style, seed and catalog req leaves unset from its
+// preset, which must belong to the project owner, and returns the preset's prompt.
+func (s *DefaultService) applyPreset(ctx context.Context, req *CreateImageRequest) (string, error) {
+	if s.presets == nil {
+		return "", ErrPresetUnavailable
+	}
+	p, err := s.presets.GetForProject(ctx, req.PresetID.String(), req.ProjectID.String())
+	if errors.Is(err, preset.ErrNotFound) {
+		return "", ErrPresetUnavailable
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve preset: %w", err)
+	}
+
+	if req.RoomType == nil {
+		req.RoomType = p.RoomType
+	}
+	if req.Style == nil {
+		req.Style = p.Style
+	}
+	if req.Seed == nil {
+		req.Seed = p.Seed
+	}
+	if req.CatalogID == nil && p.CatalogID != nil {
+		catalogID, err := uuid.Parse(*p.CatalogID)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse preset catalog ID: %w", err)
+		}
+		req.CatalogID = &catalogID
+	}
+	return p.Prompt, nil
+}
+
+// applyRoomStyle sets the style of a request that names only a room type to the style the
+// project's room checklist gives that room type, if any.
+func (s *DefaultService) applyRoomStyle(ctx context.Context, req *CreateImageRequest) error {
+	style, err := s.imageRepo.GetProjectRoomStyle(ctx, req.ProjectID.String(), *req.RoomType)
+	if err != nil {
+		return fmt.Errorf("failed to get project room style: %w", err)
+	}
+	if style != "" {
+		req.Style = &style
+	}
+	return nil
+}
+
+// referenceImageURL checks that the project owner's plan includes reference images and
+// that req.ReferenceImageKey is their own upload of an allowed type and size, returning
+// its s3:// URL.
+func (s *DefaultService) referenceImageURL(ctx context.Context, req *CreateImageRequest) (string, error) {
+	ownerID, allowed, err := s.referenceImageAccess(ctx, req.ProjectID.String())
+	if err != nil {
+		return "", fmt.Errorf("failed to check reference image access: %w", err)
+	}
+	if !allowed {
+		return "", ErrReferenceImageNotAllowed
+	}
+
+	// Presigned uploads are keyed under the uploader's ID; see storage.GeneratePresignedUploadURL.
+	key := req.ReferenceImageKey
+	ownerPrefix := storage.UploadPrefix(ctx, ownerID)
+	if !strings.HasPrefix(key, ownerPrefix) || strings.Contains(key, "..") {
+		return "", fmt.Errorf("%w: not an upload by the project owner", ErrReferenceImageInvalid)
+	}
+
+	if s.buckets == nil {
+		return "", errors.New("reference images are unavailable: storage is not configured")
+	}
+	// The upload went to the owner's bucket, which is their own for customer-managed storage.
+	files, err := s.buckets.ForUser(ctx, ownerID)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve reference image storage: %w", err)
+	}
+	head, err := files.HeadFile(ctx, key)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return "", fmt.Errorf("%w: upload not found", ErrReferenceImageInvalid)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to check reference image: %w", err)
+	}
+	if obj, ok := head.(*s3.HeadObjectOutput); ok {
+		if !storage.ValidateContentType(aws.ToString(obj.ContentType)) {
+			return "", fmt.Errorf("%w: must be a JPEG, PNG or WebP image", ErrReferenceImageInvalid)
+		}
+		if !storage.ValidateFileSize(aws.ToInt64(obj.ContentLength)) {
+			return "", fmt.Errorf("%w: must be 10MB or smaller", ErrReferenceImageInvalid)
+		}
+	}
+
+	return fmt.Sprintf("s3://%s/%s", files.BucketName(), key), nil
+}
+
+// referenceImageAccess returns the project's owner and whether their plan includes reference
+// images, from the cached entitlements when available.
+func (s *DefaultService) referenceImageAccess(ctx context.Context, projectID string) (string, bool, error) {
+	if s.entitlements == nil {
+		access, err := s.imageRepo.GetReferenceImageAccess(ctx, projectID)
+		if err != nil {
+			return "", false, err
+		}
+		return uuid.UUID(access.UserID.Bytes).String(), access.Allowed, nil
+	}
+	ownerID, err := s.imageRepo.GetProjectOwnerID(ctx, projectID)
+	if err != nil {
+		return "", false, err
+	}
+	ents, err := s.entitlements.Get(ctx, ownerID)
+	if err != nil {
+		return "", false, err
+	}
+	return ownerID, ents.ReferenceImages, nil
+}
+
+// withTx runs fn with repositories that share one transaction. Services built without a
+// database (NewDefaultService) run fn with the injected repositories.
+func (s *DefaultService) withTx(ctx context.Context, fn func(imageRepo Repository, jobRepo job.Repository) error) error {
+	if s.db == nil {
+		return fn(s.imageRepo, s.jobRepo)
+	}
+	return s.db.WithTx(ctx, func(tx storage.Database) error {
+		return fn(NewDefaultRepository(tx), job.NewDefaultRepository(tx))
+	})
+}
+
+// createImageWithJob persists an image and its stage:run job.
+func (s *DefaultService) createImageWithJob(
+	ctx context.Context, imageRepo Repository, jobRepo job.Repository, req *CreateImageRequest, opts stageOptions,
+) (*createdImage, error) {
+	log := logging.NewDefaultLogger()
+
 	// Create the image in the database
-	dbImage, err := s.imageRepo.CreateImage(
+	dbImage, err := imageRepo.CreateImage(
 		ctx,
 		req.ProjectID.String(),
 		req.OriginalURL,
 		req.RoomType,
 		req.Style,
 		req.Seed,
+		req.Source,
 	)
 	if err != nil {
 		log.Error(ctx, "create image: repo failure",
 			"project_id", req.ProjectID.String(),
 			"original_url", req.OriginalURL,
+			"source", req.Source,
 			"error", err)
 		return nil, fmt.Errorf("failed to create image: %w", err)
 	}
@@ -66,73 +525,155 @@ func (s *DefaultService) CreateImage(ctx context.Context, req *CreateImageReques
 	// Convert database image to domain image
 	domainImage := s.convertToImage(dbImage)
 
-	// Create job payload
-	payload := JobPayload{
-		ImageID:     domainImage.ID,
-		OriginalURL: domainImage.OriginalURL,
-		RoomType:    domainImage.RoomType,
-		Style:       domainImage.Style,
-		Seed:        domainImage.Seed,
+	if req.Annotations != nil {
+		if err := imageRepo.CreateImageAnnotations(ctx, domainImage.ID.String(), *req.Annotations); err != nil {
+			log.Error(ctx, "create image: annotations failed", "image_id", domainImage.ID.String(), "error", err)
+			return nil, err
+		}
+		domainImage.Annotations = req.Annotations
+	}
+	if len(req.BracketURLs) > 0 {
+		if err := imageRepo.CreateImageBrackets(ctx, domainImage.ID.String(), req.BracketURLs); err != nil {
+			log.Error(ctx, "create image: brackets failed", "image_id", domainImage.ID.String(), "error", err)
+			return nil, err
+		}
+		domainImage.BracketURLs = req.BracketURLs
 	}
-	payloadJSON, err := jsonMarshal(payload)
+
+	payloadJSON, err := stageRunJobPayload(domainImage, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal job payload: %w", err)
+		return nil, err
 	}
 
 	// Create a job for processing the image (persist metadata)
-	_, err = s.jobRepo.CreateJob(ctx, domainImage.ID.String(), "stage:run", payloadJSON)
+	dbJob, err := jobRepo.CreateJob(ctx, domainImage.ID.String(), "stage:run", payloadJSON)
 	if err != nil {
 		log.Error(ctx, "create image: job create failed", "image_id", domainImage.ID.String(), "error", err)
 		return nil, fmt.Errorf("failed to create job: %w", err)
 	}
 
-	// Enqueue processing task to the queue
-	log.Info(ctx, "enqueue stage:run", "image_id", domainImage.ID.String())
-	if _, err := s.enqueuer.EnqueueStageRun(ctx, queue.StageRunPayload{
-		ImageID:     domainImage.ID.String(),
-		OriginalURL: domainImage.OriginalURL,
-		RoomType:    domainImage.RoomType,
-		Style:       domainImage.Style,
-		Seed:        domainImage.Seed,
-	}, nil); err != nil {
-		log.Error(ctx, "enqueue stage:run failed", "image_id", domainImage.ID.String(), "error", err)
-		return nil, fmt.Errorf("failed to enqueue stage:run: %w", err)
-	}
-	log.Info(ctx, "image enqueued", "image_id", domainImage.ID.String())
-
-	return domainImage, nil
+	return &createdImage{image: domainImage, job: dbJob, opts: opts}, nil
 }
 
-// BatchCreateImages creates multiple images in a single transaction.
-func (s *DefaultService) BatchCreateImages(
-	ctx context.Context, reqs []CreateImageRequest,
-) (*BatchCreateImagesResponse, error) {
+// createImagesWithJobs persists a batch of images and their stage:run jobs with one bulk
+// insert per table, returning them in request order.
+func (s *DefaultService) createImagesWithJobs(
+	ctx context.Context, imageRepo Repository, jobRepo job.Repository, reqs []CreateImageRequest, opts []stageOptions,
+) ([]*createdImage, error) {
 	log := logging.NewDefaultLogger()
 
-	response := &BatchCreateImagesResponse{
-		Images: []*Image{},
-		Errors: []BatchImageError{},
+	dbImages, err := imageRepo.CreateImages(ctx, reqs)
+	if err != nil {
+		log.Error(ctx, "batch create: failed to create images", "count", len(reqs), "error", err)
+		return nil, fmt.Errorf("failed to create images: %w", err)
 	}
 
-	// Process each image request
-	for i, req := range reqs {
-		img, err := s.CreateImage(ctx, &req)
+	created := make([]*createdImage, len(dbImages))
+	jobReqs := make([]job.CreateJobRequest, len(dbImages))
+	for i, dbImage := range dbImages {
+		domainImage := s.convertToImage(dbImage)
+		if a := reqs[i].Annotations; a != nil {
+			if err := imageRepo.CreateImageAnnotations(ctx, domainImage.ID.String(), *a); err != nil {
+				log.Error(ctx, "batch create: failed to create annotations", "index", i, "error", err)
+				return nil, err
+			}
+			domainImage.Annotations = a
+		}
+		if urls := reqs[i].BracketURLs; len(urls) > 0 {
+			if err := imageRepo.CreateImageBrackets(ctx, domainImage.ID.String(), urls); err != nil {
+				log.Error(ctx, "batch create: failed to create brackets", "index", i, "error", err)
+				return nil, err
+			}
+			domainImage.BracketURLs = urls
+		}
+		payloadJSON, err := stageRunJobPayload(domainImage, opts[i])
 		if err != nil {
-			log.Error(ctx, "batch create: failed to create image",
-				"index", i,
-				"project_id", req.ProjectID.String(),
-				"error", err)
-			return nil, fmt.Errorf("failed to create image at index %d: %w", i, err)
-		} else {
-			response.Images = append(response.Images, img)
+			return nil, err
 		}
+		created[i] = &createdImage{image: domainImage, opts: opts[i]}
+		jobReqs[i] = job.CreateJobRequest{ImageID: domainImage.ID, Type: "stage:run", Payload: payloadJSON}
 	}
 
-	log.Info(ctx, "batch create completed",
-		"total", len(reqs),
-		"success", len(response.Images),
-		"failed", len(response.Errors))
-	return response, nil
+	dbJobs, err := jobRepo.CreateJobs(ctx, jobReqs)
+	if err != nil {
+		log.Error(ctx, "batch create: failed to create jobs", "count", len(jobReqs), "error", err)
+		return nil, fmt.Errorf("failed to create jobs: %w", err)
+	}
+	for i, dbJob := range dbJobs {
+		created[i].job = dbJob
+	}
+
+	return created, nil
+}
+
+// stageRunJobPayload builds the persisted job payload for an image.
+func stageRunJobPayload(img *Image, opts stageOptions) ([]byte, error) {
+	payloadJSON, err := jsonMarshal(JobPayload{
+		ImageID:            img.ID,
+		ProjectID:          img.ProjectID,
+		OriginalURL:        img.OriginalURL,
+		RoomType:           img.RoomType,
+		Style:              img.Style,
+		Seed:               img.Seed,
+		Sandbox:            opts.sandbox,
+		Catalog:            opts.catalog,
+		ReferenceImageURL:  opts.referenceImageURL,
+		BracketURLs:        opts.bracketURLs,
+		CorrectPerspective: opts.correctPerspective,
+		Annotations:        opts.annotations,
+		Instructions:       opts.instructions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal job payload: %w", err)
+	}
+	return payloadJSON, nil
+}
+
+// enqueue queues the stage:run task for a created image. batch adds the task to its
+// project's batch group.
+func (s *DefaultService) enqueue(ctx context.Context, c *createdImage, batch bool) error {
+	log := logging.NewDefaultLogger()
+	domainImage := c.image
+
+	// Use the job ID as the task ID so the task can be located again on cancel.
+	var opts *queue.EnqueueOpts
+	if c.job != nil && c.job.ID.Valid {
+		opts = &queue.EnqueueOpts{Retry: -1, TaskID: uuid.UUID(c.job.ID.Bytes).String()}
+	}
+	if batch {
+		if opts == nil {
+			opts = &queue.EnqueueOpts{Retry: -1}
+		}
+		opts.Group = queue.BatchGroup(domainImage.ProjectID.String())
+	}
+
+	// Enqueue processing task to the queue
+	log.Info(ctx, "enqueue stage:run", "image_id", domainImage.ID.String())
+	if _, err := s.enqueuer.EnqueueStageRun(ctx, queue.StageRunPayload{
+		ImageID:            domainImage.ID.String(),
+		ProjectID:          domainImage.ProjectID.String(),
+		OriginalURL:        domainImage.OriginalURL,
+		RoomType:           domainImage.RoomType,
+		Style:              domainImage.Style,
+		Seed:               domainImage.Seed,
+		Sandbox:            c.opts.sandbox,
+		Catalog:            c.opts.catalog,
+		ReferenceImageURL:  c.opts.referenceImageURL,
+		BracketURLs:        c.opts.bracketURLs,
+		CorrectPerspective: c.opts.correctPerspective,
+		Annotations:        c.opts.annotations,
+		Instructions:       c.opts.instructions,
+	}, opts); err != nil {
+		log.Error(ctx, "enqueue stage:run failed", "image_id", domainImage.ID.String(), "error", err)
+		return fmt.Errorf("failed to enqueue stage:run: %w", err)
+	}
+	log.Info(ctx, "image enqueued", "image_id", domainImage.ID.String())
+
+	// Best-effort: a project page missing the queued event still sees the later ones.
+	if err := s.statuses.PublishQueued(ctx, domainImage.ProjectID.String(), domainImage.ID.String()); err != nil {
+		log.Warn(ctx, "enqueue: failed to publish queued status", "image_id", domainImage.ID.String(), "error", err)
+	}
+	return nil
 }
 
 // GetImageByID retrieves a specific image by its ID.
@@ -169,10 +710,17 @@ func (s *DefaultService) GetImagesByProjectID(ctx context.Context, projectID str
 }
 
 // UpdateImageStatus updates an image's processing status.
+// Returns an error wrapping ErrInvalidTransition when the image may not move to status.
 func (s *DefaultService) UpdateImageStatus(ctx context.Context, imageID string, status Status) (*Image, error) {
 	if imageID == "" {
 		return nil, fmt.Errorf("image ID cannot be empty")
 	}
+	if !status.Valid() {
+		return nil, fmt.Errorf("unknown image status %q", status)
+	}
+	if err := s.checkTransition(ctx, imageID, status); err != nil {
+		return nil, err
+	}
 
 	dbImage, err := s.imageRepo.UpdateImageStatus(ctx, imageID, status.String())
 	if err != nil {
@@ -192,6 +740,9 @@ func (s *DefaultService) UpdateImageWithStagedURL(
 	if stagedURL == "" {
 		return nil, fmt.Errorf("staged URL cannot be empty")
 	}
+	if err := s.checkTransition(ctx, imageID, StatusReady); err != nil {
+		return nil, err
+	}
 
 	dbImage, err := s.imageRepo.UpdateImageWithStagedURL(ctx, imageID, stagedURL, StatusReady.String())
 	if err != nil {
@@ -209,6 +760,9 @@ func (s *DefaultService) UpdateImageWithError(ctx context.Context, imageID strin
 	if errorMsg == "" {
 		return nil, fmt.Errorf("error message cannot be empty")
 	}
+	if err := s.checkTransition(ctx, imageID, StatusError); err != nil {
+		return nil, err
+	}
 
 	dbImage, err := s.imageRepo.UpdateImageWithError(ctx, imageID, errorMsg)
 	if err != nil {
@@ -218,13 +772,32 @@ func (s *DefaultService) UpdateImageWithError(ctx context.Context, imageID strin
 	return s.convertToImage(dbImage), nil
 }
 
+// checkTransition returns an error wrapping ErrInvalidTransition when the image's current
+// status may not move to next.
+func (s *DefaultService) checkTransition(ctx context.Context, imageID string, next Status) error {
+	current, err := s.imageRepo.GetImageByID(ctx, imageID)
+	if err != nil {
+		return fmt.Errorf("failed to get image: %w", err)
+	}
+	return ValidateTransition(Status(current.Status), next)
+}
+
 // DeleteImage deletes an image from the database.
+// Returns ErrLegalHold when the image or its project is under legal hold.
 func (s *DefaultService) DeleteImage(ctx context.Context, imageID string) error {
 	if imageID == "" {
 		return fmt.Errorf("image ID cannot be empty")
 	}
 
-	err := s.imageRepo.DeleteImage(ctx, imageID)
+	held, err := s.imageRepo.ListLegalHeldImageIDs(ctx, []string{imageID})
+	if err != nil {
+		return fmt.Errorf("failed to check legal hold: %w", err)
+	}
+	if len(held) > 0 {
+		return ErrLegalHold
+	}
+
+	err = s.imageRepo.DeleteImage(ctx, imageID)
 	if err != nil {
 		return fmt.Errorf("failed to delete image: %w", err)
 	}
@@ -232,6 +805,364 @@ func (s *DefaultService) DeleteImage(ctx context.Context, imageID string) error
 	return nil
 }
 
+// CancelImage cancels an image that has not finished: awaiting upload, queued or processing.
+// Pending queue tasks are removed outright; tasks a worker has already picked up
+// are signaled to abort at the worker's next checkpoint. The image cost is cleared and,
+// when it was created in the current period, the image is given back to the owner's monthly
+// limit. userID must own or edit the image's project.
+func (s *DefaultService) CancelImage(ctx context.Context, imageID, userID string) (*Image, error) {
+	log := logging.NewDefaultLogger()
+	if imageID == "" {
+		return nil, fmt.Errorf("image ID cannot be empty")
+	}
+
+	current, err := s.imageRepo.GetImageByID(ctx, imageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image: %w", err)
+	}
+	if err := s.authorizeImage(ctx, current, userID, true); err != nil {
+		return nil, err
+	}
+	if !Status(current.Status).CanTransitionTo(StatusCanceled) {
+		return nil, ErrImageNotCancelable
+	}
+
+	dbImage, err := s.imageRepo.CancelImage(ctx, imageID)
+	if err != nil {
+		// The image finished between the read and the update.
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrImageNotCancelable
+		}
+		return nil, fmt.Errorf("failed to cancel image: %w", err)
+	}
+
+	jobs, err := s.jobRepo.CancelJobsByImageID(ctx, imageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel jobs: %w", err)
+	}
+	jobIDs := make([]pgtype.UUID, len(jobs))
+	for i, j := range jobs {
+		jobIDs[i] = j.ID
+	}
+	s.abortInFlight(ctx, imageID, jobIDs, current.Status == queries.ImageStatusProcessing)
+	s.releaseCanceled(ctx, uuid.UUID(current.ProjectID.Bytes).String(), []time.Time{current.CreatedAt.Time})
+
+	log.Info(ctx, "image canceled", "image_id", imageID, "previous_status", string(current.Status))
+	return s.convertToImage(dbImage), nil
+}
+
+// ExpediteImage moves a queued image's stage:run task to the critical queue, which workers
+// drain before the default queue. The project owner's plan must include expediting, and each
+// owner may expedite at most expediteLimit images per rolling day across their projects.
+// Every expedite is recorded in the project's activity log under userID, who must own or
+// edit the project; the limit is counted from that log.
+func (s *DefaultService) ExpediteImage(ctx context.Context, imageID, userID string) (*Image, error) {
+	log := logging.NewDefaultLogger()
+	if imageID == "" {
+		return nil, fmt.Errorf("image ID cannot be empty")
+	}
+
+	current, err := s.imageRepo.GetImageByID(ctx, imageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image: %w", err)
+	}
+	if err := s.authorizeImage(ctx, current, userID, true); err != nil {
+		return nil, err
+	}
+	if current.Status != queries.ImageStatusQueued {
+		return nil, ErrImageNotExpeditable
+	}
+
+	access, err := s.imageRepo.GetExpediteAccess(ctx, imageID, time.Now().Add(-expediteWindow))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check expedite access: %w", err)
+	}
+	if !access.Allowed || s.expediteLimit <= 0 {
+		return nil, ErrExpediteNotAllowed
+	}
+	if access.ExpeditedSince >= int64(s.expediteLimit) {
+		return nil, ErrExpediteLimitReached
+	}
+
+	jobs, err := s.jobRepo.GetJobsByImageID(ctx, imageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get jobs: %w", err)
+	}
+	var taskID string
+	for _, j := range jobs {
+		if j.Type == queue.TaskTypeStageRun && j.Status == job.StatusQueued.String() {
+			taskID = uuid.UUID(j.ID.Bytes).String()
+			break
+		}
+	}
+	if taskID == "" {
+		return nil, ErrImageNotExpeditable
+	}
+
+	moved, err := s.expediter.ExpediteStageRun(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expedite job: %w", err)
+	}
+	// A worker picked the task up between the status check and the move.
+	if !moved {
+		return nil, ErrImageNotExpeditable
+	}
+
+	projectID := uuid.UUID(access.ProjectID.Bytes).String()
+	if err := s.imageRepo.RecordImageExpedited(ctx, projectID, imageID, taskID, userID); err != nil {
+		log.Error(ctx, "expedite image: failed to record activity",
+			"image_id", imageID, "task_id", taskID, "error", err)
+	}
+
+	log.Info(ctx, "image expedited", "image_id", imageID, "task_id", taskID, "user_id", userID)
+	return s.convertToImage(current), nil
+}
+
+// authorizeImage returns pgx.ErrNoRows unless the user owns or collaborates on the image's
+// project, so that anyone else is told the image does not exist. Changes (write) also need
+// owner or editor access; viewers get ErrImageReadOnly.
+func (s *DefaultService) authorizeImage(ctx context.Context, img *queries.Image, userID string, write bool) error {
+	return s.authorizeProject(ctx, img.ProjectID, userID, write)
+}
+
+// authorizeProject applies authorizeImage's rules to an image in projectID.
+func (s *DefaultService) authorizeProject(ctx context.Context, projectID pgtype.UUID, userID string, write bool) error {
+	role, err := s.imageRepo.GetProjectRole(ctx, uuid.UUID(projectID.Bytes).String(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to check image access: %w", err)
+	}
+	if role == "" {
+		return pgx.ErrNoRows
+	}
+	if write && role == project.RoleViewer {
+		return ErrImageReadOnly
+	}
+	return nil
+}
+
+// BulkCancelImages cancels the listed images in a project. The database update is
+// atomic; each image is reported as canceled, not_found, or not_cancelable. Canceled images
+// created in the current period are given back to the owner's monthly limit.
+func (s *DefaultService) BulkCancelImages(
+	ctx context.Context, projectID string, imageIDs []string,
+) (*BulkImagesResponse, error) {
+	log := logging.NewDefaultLogger()
+	if projectID == "" {
+		return nil, fmt.Errorf("project ID cannot be empty")
+	}
+	imageIDs = dedupeIDs(imageIDs)
+
+	current, err := s.imageRepo.GetImageStatusesByIDs(ctx, projectID, imageIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get images: %w", err)
+	}
+	statuses := make(map[string]queries.ImageStatus, len(current))
+	for _, row := range current {
+		statuses[uuid.UUID(row.ID.Bytes).String()] = row.Status
+	}
+
+	rows, err := s.imageRepo.BulkCancelImages(ctx, projectID, imageIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel images: %w", err)
+	}
+	canceledJobs := make(map[string][]pgtype.UUID, len(rows))
+	var created []time.Time
+	for _, row := range rows {
+		id := uuid.UUID(row.ImageID.Bytes).String()
+		if _, ok := canceledJobs[id]; !ok {
+			canceledJobs[id] = nil
+			created = append(created, row.CreatedAt.Time)
+		}
+		if row.JobID.Valid {
+			canceledJobs[id] = append(canceledJobs[id], row.JobID)
+		}
+	}
+	s.releaseCanceled(ctx, projectID, created)
+
+	response := &BulkImagesResponse{Results: make([]BulkImageResult, 0, len(imageIDs))}
+	for _, id := range imageIDs {
+		jobIDs, canceled := canceledJobs[id]
+		switch {
+		case canceled:
+			s.abortInFlight(ctx, id, jobIDs, statuses[id] == queries.ImageStatusProcessing)
+			response.Results = append(response.Results, BulkImageResult{ImageID: id, Result: BulkResultCanceled})
+			response.Success++
+		case statuses[id] == "":
+			response.Results = append(response.Results, BulkImageResult{ImageID: id, Result: BulkResultNotFound})
+			response.Failed++
+		default:
+			response.Results = append(response.Results, BulkImageResult{ImageID: id, Result: BulkResultNotCancelable})
+			response.Failed++
+		}
+	}
+
+	log.Info(ctx, "bulk cancel completed",
+		"project_id", projectID,
+		"total", len(imageIDs),
+		"success", response.Success,
+		"failed", response.Failed)
+	return response, nil
+}
+
+// BulkDeleteImages deletes the listed images in a project atomically; each image
+// is reported as deleted, legal_hold, or not_found. Images under legal hold are
+// skipped. Workers processing a deleted image are signaled to abort.
+func (s *DefaultService) BulkDeleteImages(
+	ctx context.Context, projectID string, imageIDs []string,
+) (*BulkImagesResponse, error) {
+	log := logging.NewDefaultLogger()
+	if projectID == "" {
+		return nil, fmt.Errorf("project ID cannot be empty")
+	}
+	imageIDs = dedupeIDs(imageIDs)
+
+	// The hold check is part of the delete statement, so a hold placed mid-request cannot
+	// fail the batch.
+	rows, err := s.imageRepo.BulkDeleteImages(ctx, projectID, imageIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete images: %w", err)
+	}
+	held := make(map[string]bool)
+	deleted := make(map[string]queries.ImageStatus, len(rows))
+	for _, row := range rows {
+		id := uuid.UUID(row.ID.Bytes).String()
+		if row.Held {
+			held[id] = true
+			continue
+		}
+		deleted[id] = row.Status
+	}
+
+	response := &BulkImagesResponse{Results: make([]BulkImageResult, 0, len(imageIDs))}
+	for _, id := range imageIDs {
+		if held[id] {
+			response.Results = append(response.Results, BulkImageResult{ImageID: id, Result: BulkResultLegalHold})
+			response.Failed++
+			continue
+		}
+		status, ok := deleted[id]
+		if !ok {
+			response.Results = append(response.Results, BulkImageResult{ImageID: id, Result: BulkResultNotFound})
+			response.Failed++
+			continue
+		}
+		if status == queries.ImageStatusQueued || status == queries.ImageStatusProcessing {
+			// Job rows are gone with the image, so the queued task can't be located; let the worker drop it.
+			if err := s.canceler.SignalCancel(ctx, id); err != nil {
+				log.Warn(ctx, "bulk delete: failed to signal worker", "image_id", id, "error", err)
+			}
+		}
+		response.Results = append(response.Results, BulkImageResult{ImageID: id, Result: BulkResultDeleted})
+		response.Success++
+	}
+
+	log.Info(ctx, "bulk delete completed",
+		"project_id", projectID,
+		"total", len(imageIDs),
+		"success", response.Success,
+		"failed", response.Failed)
+	return response, nil
+}
+
+// abortInFlight removes the canceled jobs' tasks from the queue and, when a worker
+// may already hold one, signals it to abort at its next checkpoint. Failures are
+// logged only: the worker never overwrites a canceled image even if it misses the signal.
+func (s *DefaultService) abortInFlight(ctx context.Context, imageID string, jobIDs []pgtype.UUID, processing bool) {
+	log := logging.NewDefaultLogger()
+
+	signal := processing || len(jobIDs) == 0
+	for _, jobID := range jobIDs {
+		taskID := uuid.UUID(jobID.Bytes).String()
+		removed, err := s.canceler.CancelStageRun(ctx, taskID)
+		if err != nil {
+			log.Warn(ctx, "cancel image: failed to remove queued task",
+				"image_id", imageID, "task_id", taskID, "error", err)
+		}
+		if !removed {
+			signal = true
+		}
+	}
+
+	if signal {
+		if err := s.canceler.SignalCancel(ctx, imageID); err != nil {
+			log.Warn(ctx, "cancel image: failed to signal worker", "image_id", imageID, "error", err)
+		}
+	}
+}
+
+// GetImageStatusHistory returns the image's status events and the status derived from the
+// latest one. Returns ErrNoStatusHistory when none were recorded for imageID, and pgx.ErrNoRows
+// when userID does not own or collaborate on the image's project.
+func (s *DefaultService) GetImageStatusHistory(ctx context.Context, imageID, userID string) (*StatusHistory, error) {
+	if imageID == "" {
+		return nil, fmt.Errorf("image ID cannot be empty")
+	}
+
+	rows, err := s.imageRepo.ListStatusTransitions(ctx, imageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status history: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, ErrNoStatusHistory
+	}
+	// The log outlives the image, so access is checked against the project it records.
+	if err := s.authorizeProject(ctx, rows[len(rows)-1].ProjectID, userID, false); err != nil {
+		return nil, err
+	}
+
+	history := &StatusHistory{
+		ImageID: rows[0].ImageID.Bytes,
+		Status:  Status(rows[len(rows)-1].ToStatus),
+		Events:  make([]StatusEvent, len(rows)),
+	}
+	latestAttempt := make(map[string]int)
+	for i, row := range rows {
+		ev := StatusEvent{
+			To:     Status(row.ToStatus),
+			Source: row.Source,
+			At:     row.CreatedAt.Time,
+		}
+		if row.FromStatus.Valid {
+			from := Status(row.FromStatus.ImageStatus)
+			ev.From = &from
+		}
+		if row.Error.Valid {
+			ev.Error = &row.Error.String
+		}
+		if row.JobID.Valid {
+			ev.JobID = &row.JobID.String
+		}
+		if row.Attempt.Valid {
+			attempt := int(row.Attempt.Int32)
+			ev.Attempt = &attempt
+			if row.JobID.Valid {
+				if latest, ok := latestAttempt[row.JobID.String]; ok && attempt < latest {
+					ev.Stale = true
+				} else {
+					latestAttempt[row.JobID.String] = attempt
+				}
+			}
+		}
+		history.Events[i] = ev
+	}
+
+	return history, nil
+}
+
+// dedupeIDs removes duplicate IDs while preserving the caller's order.
+func dedupeIDs(ids []string) []string {
+	seen := make(map[string]struct{}, len(ids))
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		out = append(out, id)
+	}
+	return out
+}
+
 // convertToImage converts a database image to a domain image.
 func (s *DefaultService) convertToImage(dbImage *queries.Image) *Image {
 	image := &Image{
@@ -241,12 +1172,25 @@ func (s *DefaultService) convertToImage(dbImage *queries.Image) *Image {
 		Status:      Status(dbImage.Status),
 		CreatedAt:   dbImage.CreatedAt.Time,
 		UpdatedAt:   dbImage.UpdatedAt.Time,
+		Source:      Source(dbImage.Source),
 	}
 
 	if dbImage.StagedUrl.Valid {
 		image.StagedURL = &dbImage.StagedUrl.String
 	}
 
+	if dbImage.PreviewUrl.Valid {
+		image.PreviewURL = &dbImage.PreviewUrl.String
+	}
+
+	if dbImage.WatermarkedUrl.Valid {
+		image.WatermarkedURL = &dbImage.WatermarkedUrl.String
+	}
+
+	if dbImage.RawUrl.Valid {
+		image.RawURL = &dbImage.RawUrl.String
+	}
+
 	if dbImage.RoomType.Valid {
 		image.RoomType = &dbImage.RoomType.String
 	}
@@ -4,16 +4,31 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/real-staging-ai/api/internal/catalog"
 	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/entitlement"
 	"github.com/real-staging-ai/api/internal/job"
+	"github.com/real-staging-ai/api/internal/preset"
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/sse"
+	"github.com/real-staging-ai/api/internal/storage"
 	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/usage"
 )
 
 func TestNewDefaultService(t *testing.T) {
@@ -59,6 +74,7 @@ func TestDefaultService_CreateImage(t *testing.T) {
 					projectIDStr, originalURL string,
 					roomType, style *string,
 					seed *int64,
+					source Source,
 				) (*queries.Image, error) {
 					return &queries.Image{
 						ID:          pgtype.UUID{Bytes: imageID, Valid: true},
@@ -101,6 +117,7 @@ func TestDefaultService_CreateImage(t *testing.T) {
 					projectIDStr, originalURL string,
 					roomType, style *string,
 					seed *int64,
+					source Source,
 				) (*queries.Image, error) {
 					return nil, errors.New("db error")
 				}
@@ -208,6 +225,10 @@ func TestDefaultService_GetImageByID(t *testing.T) {
 						Style:       pgtype.Text{String: "modern", Valid: true},
 						Seed:        pgtype.Int8{Int64: 123, Valid: true},
 						Error:       pgtype.Text{String: "some error", Valid: true},
+						PreviewUrl:  pgtype.Text{String: "s3://bucket/staged/preview.jpg", Valid: true},
+						WatermarkedUrl: pgtype.Text{
+							String: "s3://bucket/staged/img-watermarked.jpg", Valid: true,
+						},
 					}, nil
 				}
 			},
@@ -281,12 +302,16 @@ func TestDefaultService_GetImageByID(t *testing.T) {
 					assert.NotNil(t, image.Style)
 					assert.NotNil(t, image.Seed)
 					assert.NotNil(t, image.Error)
+					assert.NotNil(t, image.PreviewURL)
+					assert.NotNil(t, image.WatermarkedURL)
 				} else {
 					assert.Nil(t, image.StagedURL)
 					assert.Nil(t, image.RoomType)
 					assert.Nil(t, image.Style)
 					assert.Nil(t, image.Seed)
 					assert.Nil(t, image.Error)
+					assert.Nil(t, image.PreviewURL)
+					assert.Nil(t, image.WatermarkedURL)
 				}
 			}
 		})
@@ -384,6 +409,9 @@ func TestDefaultService_UpdateImageStatus(t *testing.T) {
 			imageID: imageID.String(),
 			status:  StatusProcessing,
 			setupMocks: func(imageRepo *RepositoryMock) {
+				imageRepo.GetImageByIDFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return &queries.Image{Status: queries.ImageStatusQueued}, nil
+				}
 				imageRepo.UpdateImageStatusFunc = func(ctx context.Context, imageID, status string) (*queries.Image, error) {
 					return &queries.Image{},
 						nil
@@ -404,12 +432,44 @@ func TestDefaultService_UpdateImageStatus(t *testing.T) {
 			imageID: imageID.String(),
 			status:  StatusProcessing,
 			setupMocks: func(imageRepo *RepositoryMock) {
+				imageRepo.GetImageByIDFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return &queries.Image{Status: queries.ImageStatusQueued}, nil
+				}
 				imageRepo.UpdateImageStatusFunc = func(ctx context.Context, imageID, status string) (*queries.Image, error) {
 					return nil, errors.New("db error")
 				}
 			},
 			expectedErr: errors.New("failed to update image status: db error"),
 		},
+		{
+			name:        "fail: unknown status",
+			imageID:     imageID.String(),
+			status:      Status("paused"),
+			setupMocks:  func(imageRepo *RepositoryMock) {},
+			expectedErr: errors.New(`unknown image status "paused"`),
+		},
+		{
+			name:    "fail: invalid transition",
+			imageID: imageID.String(),
+			status:  StatusProcessing,
+			setupMocks: func(imageRepo *RepositoryMock) {
+				imageRepo.GetImageByIDFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return &queries.Image{Status: queries.ImageStatusReady}, nil
+				}
+			},
+			expectedErr: errors.New("invalid image status transition: ready to processing"),
+		},
+		{
+			name:    "fail: get image error",
+			imageID: imageID.String(),
+			status:  StatusProcessing,
+			setupMocks: func(imageRepo *RepositoryMock) {
+				imageRepo.GetImageByIDFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return nil, errors.New("db error")
+				}
+			},
+			expectedErr: errors.New("failed to get image: db error"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -453,6 +513,9 @@ func TestDefaultService_UpdateImageWithStagedURL(t *testing.T) {
 			imageID:   imageID.String(),
 			stagedURL: "http://example.com/staged.jpg",
 			setupMocks: func(imageRepo *RepositoryMock) {
+				imageRepo.GetImageByIDFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return &queries.Image{Status: queries.ImageStatusProcessing}, nil
+				}
 				imageRepo.UpdateImageWithStagedURLFunc = func(
 					ctx context.Context, imageID, stagedURL, status string,
 				) (*queries.Image, error) {
@@ -482,6 +545,9 @@ func TestDefaultService_UpdateImageWithStagedURL(t *testing.T) {
 			imageID:   imageID.String(),
 			stagedURL: "http://example.com/staged.jpg",
 			setupMocks: func(imageRepo *RepositoryMock) {
+				imageRepo.GetImageByIDFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return &queries.Image{Status: queries.ImageStatusProcessing}, nil
+				}
 				imageRepo.UpdateImageWithStagedURLFunc = func(
 					ctx context.Context, imageID, stagedURL, status string,
 				) (*queries.Image, error) {
@@ -490,6 +556,17 @@ func TestDefaultService_UpdateImageWithStagedURL(t *testing.T) {
 			},
 			expectedErr: errors.New("failed to update image with staged URL: db error"),
 		},
+		{
+			name:      "fail: canceled image",
+			imageID:   imageID.String(),
+			stagedURL: "http://example.com/staged.jpg",
+			setupMocks: func(imageRepo *RepositoryMock) {
+				imageRepo.GetImageByIDFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return &queries.Image{Status: queries.ImageStatusCanceled}, nil
+				}
+			},
+			expectedErr: errors.New("invalid image status transition: canceled to ready"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -533,6 +610,9 @@ func TestDefaultService_UpdateImageWithError(t *testing.T) {
 			imageID:  imageID.String(),
 			errorMsg: "some error",
 			setupMocks: func(imageRepo *RepositoryMock) {
+				imageRepo.GetImageByIDFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return &queries.Image{Status: queries.ImageStatusProcessing}, nil
+				}
 				imageRepo.UpdateImageWithErrorFunc = func(ctx context.Context, imageID, errorMsg string) (*queries.Image, error) {
 					return &queries.Image{},
 						nil
@@ -560,12 +640,26 @@ func TestDefaultService_UpdateImageWithError(t *testing.T) {
 			imageID:  imageID.String(),
 			errorMsg: "some error",
 			setupMocks: func(imageRepo *RepositoryMock) {
+				imageRepo.GetImageByIDFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return &queries.Image{Status: queries.ImageStatusProcessing}, nil
+				}
 				imageRepo.UpdateImageWithErrorFunc = func(ctx context.Context, imageID, errorMsg string) (*queries.Image, error) {
 					return nil, errors.New("db error")
 				}
 			},
 			expectedErr: errors.New("failed to update image with error: db error"),
 		},
+		{
+			name:     "fail: ready image",
+			imageID:  imageID.String(),
+			errorMsg: "some error",
+			setupMocks: func(imageRepo *RepositoryMock) {
+				imageRepo.GetImageByIDFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return &queries.Image{Status: queries.ImageStatusReady}, nil
+				}
+			},
+			expectedErr: errors.New("invalid image status transition: ready to error"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -587,6 +681,113 @@ func TestDefaultService_UpdateImageWithError(t *testing.T) {
 	}
 }
 
+func TestDefaultService_GetImageStatusHistory(t *testing.T) {
+	t.Setenv("APP_ENV", "test")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	imageID, projectID, callerID := uuid.New(), uuid.New(), uuid.New()
+	start := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	event := func(from, to queries.ImageStatus, jobID string, attempt int32, minute int) *queries.ImageStatusTransition {
+		row := &queries.ImageStatusTransition{
+			ImageID:    pgtype.UUID{Bytes: imageID, Valid: true},
+			ProjectID:  pgtype.UUID{Bytes: projectID, Valid: true},
+			FromStatus: queries.NullImageStatus{ImageStatus: from, Valid: from != ""},
+			ToStatus:   to,
+			Source:     "real-staging-worker",
+			CreatedAt:  pgtype.Timestamptz{Time: start.Add(time.Duration(minute) * time.Minute), Valid: true},
+		}
+		if jobID != "" {
+			row.JobID = pgtype.Text{String: jobID, Valid: true}
+			row.Attempt = pgtype.Int4{Int32: attempt, Valid: true}
+		}
+		return row
+	}
+	// Viewers may read the history too.
+	role := func(want string) func(ctx context.Context, gotProjectID, userID string) (string, error) {
+		return func(ctx context.Context, gotProjectID, userID string) (string, error) {
+			assert.Equal(t, projectID.String(), gotProjectID)
+			assert.Equal(t, callerID.String(), userID)
+			return want, nil
+		}
+	}
+
+	t.Run("success: derives status and flags late attempts", func(t *testing.T) {
+		imageRepo := &RepositoryMock{
+			ListStatusTransitionsFunc: func(ctx context.Context, id string) ([]*queries.ImageStatusTransition, error) {
+				created := event("", queries.ImageStatusQueued, "", 0, 0)
+				created.Source = "real-staging-api"
+				return []*queries.ImageStatusTransition{
+					created,
+					event(queries.ImageStatusQueued, queries.ImageStatusProcessing, "job-1", 2, 5),
+					// The first attempt timed out and was retried, but still reported its failure.
+					event(queries.ImageStatusProcessing, queries.ImageStatusError, "job-1", 1, 9),
+				}, nil
+			},
+			GetProjectRoleFunc: role(project.RoleViewer),
+		}
+
+		service := NewDefaultService(cfg, imageRepo, nil)
+		history, err := service.GetImageStatusHistory(context.Background(), imageID.String(), callerID.String())
+		require.NoError(t, err)
+
+		assert.Equal(t, imageID, history.ImageID)
+		assert.Equal(t, StatusError, history.Status)
+		require.Len(t, history.Events, 3)
+		assert.Nil(t, history.Events[0].From)
+		assert.Nil(t, history.Events[0].JobID)
+		assert.Equal(t, "real-staging-api", history.Events[0].Source)
+		require.NotNil(t, history.Events[1].From)
+		assert.Equal(t, StatusQueued, *history.Events[1].From)
+		assert.Equal(t, 2, *history.Events[1].Attempt)
+		assert.False(t, history.Events[1].Stale)
+		assert.True(t, history.Events[2].Stale)
+		assert.Equal(t, start.Add(9*time.Minute), history.Events[2].At)
+	})
+
+	t.Run("fail: no events", func(t *testing.T) {
+		imageRepo := &RepositoryMock{
+			ListStatusTransitionsFunc: func(ctx context.Context, id string) ([]*queries.ImageStatusTransition, error) {
+				return []*queries.ImageStatusTransition{}, nil
+			},
+		}
+		service := NewDefaultService(cfg, imageRepo, nil)
+		_, err := service.GetImageStatusHistory(context.Background(), imageID.String(), callerID.String())
+		assert.ErrorIs(t, err, ErrNoStatusHistory)
+	})
+
+	t.Run("fail: caller is not a project member", func(t *testing.T) {
+		imageRepo := &RepositoryMock{
+			ListStatusTransitionsFunc: func(ctx context.Context, id string) ([]*queries.ImageStatusTransition, error) {
+				return []*queries.ImageStatusTransition{event("", queries.ImageStatusQueued, "", 0, 0)}, nil
+			},
+			GetProjectRoleFunc: role(""),
+		}
+		service := NewDefaultService(cfg, imageRepo, nil)
+		_, err := service.GetImageStatusHistory(context.Background(), imageID.String(), callerID.String())
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("fail: db error", func(t *testing.T) {
+		imageRepo := &RepositoryMock{
+			ListStatusTransitionsFunc: func(ctx context.Context, id string) ([]*queries.ImageStatusTransition, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		service := NewDefaultService(cfg, imageRepo, nil)
+		_, err := service.GetImageStatusHistory(context.Background(), imageID.String(), callerID.String())
+		assert.EqualError(t, err, "failed to get status history: db error")
+	})
+
+	t.Run("fail: empty image id", func(t *testing.T) {
+		service := NewDefaultService(cfg, &RepositoryMock{}, nil)
+		_, err := service.GetImageStatusHistory(context.Background(), "", callerID.String())
+		assert.EqualError(t, err, "image ID cannot be empty")
+	})
+}
+
 func TestDefaultService_DeleteImage(t *testing.T) {
 	t.Setenv("APP_ENV", "test")
 	cfg, err := config.Load()
@@ -606,6 +807,9 @@ func TestDefaultService_DeleteImage(t *testing.T) {
 			name:    "success: delete image",
 			imageID: imageID.String(),
 			setupMocks: func(imageRepo *RepositoryMock) {
+				imageRepo.ListLegalHeldImageIDsFunc = func(ctx context.Context, imageIDs []string) ([]string, error) {
+					return nil, nil
+				}
 				imageRepo.DeleteImageFunc = func(ctx context.Context, imageID string) error {
 					return nil
 				}
@@ -618,10 +822,33 @@ func TestDefaultService_DeleteImage(t *testing.T) {
 			setupMocks:  func(imageRepo *RepositoryMock) {},
 			expectedErr: errors.New("image ID cannot be empty"),
 		},
+		{
+			name:    "fail: under legal hold",
+			imageID: imageID.String(),
+			setupMocks: func(imageRepo *RepositoryMock) {
+				imageRepo.ListLegalHeldImageIDsFunc = func(ctx context.Context, imageIDs []string) ([]string, error) {
+					return imageIDs, nil
+				}
+			},
+			expectedErr: ErrLegalHold,
+		},
+		{
+			name:    "fail: legal hold check error",
+			imageID: imageID.String(),
+			setupMocks: func(imageRepo *RepositoryMock) {
+				imageRepo.ListLegalHeldImageIDsFunc = func(ctx context.Context, imageIDs []string) ([]string, error) {
+					return nil, errors.New("db error")
+				}
+			},
+			expectedErr: errors.New("failed to check legal hold: db error"),
+		},
 		{
 			name:    "fail: db error",
 			imageID: imageID.String(),
 			setupMocks: func(imageRepo *RepositoryMock) {
+				imageRepo.ListLegalHeldImageIDsFunc = func(ctx context.Context, imageIDs []string) ([]string, error) {
+					return nil, nil
+				}
 				imageRepo.DeleteImageFunc = func(ctx context.Context, imageID string) error {
 					return errors.New("db error")
 				}
@@ -647,23 +874,2130 @@ func TestDefaultService_DeleteImage(t *testing.T) {
 	}
 }
 
-// Helper to create a successful image creation mock
-func mockSuccessfulImageCreation(imageID, projectID uuid.UUID) func(*RepositoryMock, *job.RepositoryMock) {
-	return func(imageRepo *RepositoryMock, _ *job.RepositoryMock) {
-		imageRepo.CreateImageFunc = func(
-			ctx context.Context,
-			projectIDStr, originalURL string,
-			roomType, style *string,
-			seed *int64,
-		) (*queries.Image, error) {
+func TestDefaultService_CancelImage(t *testing.T) {
+	t.Setenv("APP_ENV", "test")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	imageID := uuid.New()
+	jobID := uuid.New()
+	projectID := uuid.New()
+	userID := uuid.New()
+
+	imageWithStatus := func(status queries.ImageStatus) *queries.Image {
+		return &queries.Image{
+			ID:          pgtype.UUID{Bytes: imageID, Valid: true},
+			ProjectID:   pgtype.UUID{Bytes: projectID, Valid: true},
+			OriginalUrl: "http://example.com/image.jpg",
+			Status:      status,
+		}
+	}
+	inFlightJobs := func(ctx context.Context, imageID string) ([]*queries.Job, error) {
+		return []*queries.Job{{ID: pgtype.UUID{Bytes: jobID, Valid: true}}}, nil
+	}
+
+	testCases := []struct {
+		name         string
+		imageID      string
+		setupMocks   func(*RepositoryMock, *job.RepositoryMock, *queue.CancelerMock)
+		expectSignal bool
+		expectedErr  error
+	}{
+		{
+			name:    "success: image awaiting upload has no task to remove",
+			imageID: imageID.String(),
+			setupMocks: func(imageRepo *RepositoryMock, jobRepo *job.RepositoryMock, canceler *queue.CancelerMock) {
+				imageRepo.GetImageByIDFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return imageWithStatus(queries.ImageStatusAwaitingUpload), nil
+				}
+				imageRepo.CancelImageFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return imageWithStatus(queries.ImageStatusCanceled), nil
+				}
+				jobRepo.CancelJobsByImageIDFunc = func(ctx context.Context, imageID string) ([]*queries.Job, error) {
+					return nil, nil
+				}
+				canceler.SignalCancelFunc = func(ctx context.Context, imageID string) error {
+					return nil
+				}
+			},
+			expectSignal: true,
+		},
+		{
+			name:    "success: queued task removed from queue",
+			imageID: imageID.String(),
+			setupMocks: func(imageRepo *RepositoryMock, jobRepo *job.RepositoryMock, canceler *queue.CancelerMock) {
+				imageRepo.GetImageByIDFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return imageWithStatus(queries.ImageStatusQueued), nil
+				}
+				imageRepo.CancelImageFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return imageWithStatus(queries.ImageStatusCanceled), nil
+				}
+				jobRepo.CancelJobsByImageIDFunc = inFlightJobs
+				canceler.CancelStageRunFunc = func(ctx context.Context, taskID string) (bool, error) {
+					return true, nil
+				}
+			},
+			expectSignal: false,
+		},
+		{
+			name:    "success: queued task already picked up signals worker",
+			imageID: imageID.String(),
+			setupMocks: func(imageRepo *RepositoryMock, jobRepo *job.RepositoryMock, canceler *queue.CancelerMock) {
+				imageRepo.GetImageByIDFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return imageWithStatus(queries.ImageStatusQueued), nil
+				}
+				imageRepo.CancelImageFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return imageWithStatus(queries.ImageStatusCanceled), nil
+				}
+				jobRepo.CancelJobsByImageIDFunc = inFlightJobs
+				canceler.CancelStageRunFunc = func(ctx context.Context, taskID string) (bool, error) {
+					return false, nil
+				}
+			},
+			expectSignal: true,
+		},
+		{
+			name:    "success: processing image signals worker",
+			imageID: imageID.String(),
+			setupMocks: func(imageRepo *RepositoryMock, jobRepo *job.RepositoryMock, canceler *queue.CancelerMock) {
+				imageRepo.GetImageByIDFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return imageWithStatus(queries.ImageStatusProcessing), nil
+				}
+				imageRepo.CancelImageFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return imageWithStatus(queries.ImageStatusCanceled), nil
+				}
+				jobRepo.CancelJobsByImageIDFunc = inFlightJobs
+				canceler.CancelStageRunFunc = func(ctx context.Context, taskID string) (bool, error) {
+					return false, nil
+				}
+			},
+			expectSignal: true,
+		},
+		{
+			name:    "success: queue errors are not fatal",
+			imageID: imageID.String(),
+			setupMocks: func(imageRepo *RepositoryMock, jobRepo *job.RepositoryMock, canceler *queue.CancelerMock) {
+				imageRepo.GetImageByIDFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return imageWithStatus(queries.ImageStatusQueued), nil
+				}
+				imageRepo.CancelImageFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return imageWithStatus(queries.ImageStatusCanceled), nil
+				}
+				jobRepo.CancelJobsByImageIDFunc = inFlightJobs
+				canceler.CancelStageRunFunc = func(ctx context.Context, taskID string) (bool, error) {
+					return false, errors.New("redis down")
+				}
+				canceler.SignalCancelFunc = func(ctx context.Context, imageID string) error {
+					return errors.New("redis down")
+				}
+			},
+			expectSignal: true,
+		},
+		{
+			name:        "fail: empty image id",
+			imageID:     "",
+			setupMocks:  func(*RepositoryMock, *job.RepositoryMock, *queue.CancelerMock) {},
+			expectedErr: errors.New("image ID cannot be empty"),
+		},
+		{
+			name:    "fail: get image error",
+			imageID: imageID.String(),
+			setupMocks: func(imageRepo *RepositoryMock, jobRepo *job.RepositoryMock, canceler *queue.CancelerMock) {
+				imageRepo.GetImageByIDFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return nil, errors.New("db error")
+				}
+			},
+			expectedErr: errors.New("failed to get image: db error"),
+		},
+		{
+			name:    "fail: caller does not own or collaborate on the project",
+			imageID: imageID.String(),
+			setupMocks: func(imageRepo *RepositoryMock, jobRepo *job.RepositoryMock, canceler *queue.CancelerMock) {
+				imageRepo.GetImageByIDFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return imageWithStatus(queries.ImageStatusQueued), nil
+				}
+				imageRepo.GetProjectRoleFunc = func(ctx context.Context, projectID, userID string) (string, error) {
+					return "", nil
+				}
+			},
+			expectedErr: pgx.ErrNoRows,
+		},
+		{
+			name:    "fail: viewers cannot cancel",
+			imageID: imageID.String(),
+			setupMocks: func(imageRepo *RepositoryMock, jobRepo *job.RepositoryMock, canceler *queue.CancelerMock) {
+				imageRepo.GetImageByIDFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return imageWithStatus(queries.ImageStatusQueued), nil
+				}
+				imageRepo.GetProjectRoleFunc = func(ctx context.Context, projectID, userID string) (string, error) {
+					return project.RoleViewer, nil
+				}
+			},
+			expectedErr: ErrImageReadOnly,
+		},
+		{
+			name:    "fail: image already ready",
+			imageID: imageID.String(),
+			setupMocks: func(imageRepo *RepositoryMock, jobRepo *job.RepositoryMock, canceler *queue.CancelerMock) {
+				imageRepo.GetImageByIDFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return imageWithStatus(queries.ImageStatusReady), nil
+				}
+			},
+			expectedErr: ErrImageNotCancelable,
+		},
+		{
+			name:    "fail: image finished before update",
+			imageID: imageID.String(),
+			setupMocks: func(imageRepo *RepositoryMock, jobRepo *job.RepositoryMock, canceler *queue.CancelerMock) {
+				imageRepo.GetImageByIDFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return imageWithStatus(queries.ImageStatusProcessing), nil
+				}
+				imageRepo.CancelImageFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return nil, fmt.Errorf("failed to cancel image: %w", pgx.ErrNoRows)
+				}
+			},
+			expectedErr: ErrImageNotCancelable,
+		},
+		{
+			name:    "fail: cancel jobs error",
+			imageID: imageID.String(),
+			setupMocks: func(imageRepo *RepositoryMock, jobRepo *job.RepositoryMock, canceler *queue.CancelerMock) {
+				imageRepo.GetImageByIDFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return imageWithStatus(queries.ImageStatusQueued), nil
+				}
+				imageRepo.CancelImageFunc = func(ctx context.Context, imageID string) (*queries.Image, error) {
+					return imageWithStatus(queries.ImageStatusCanceled), nil
+				}
+				jobRepo.CancelJobsByImageIDFunc = func(ctx context.Context, imageID string) ([]*queries.Job, error) {
+					return nil, errors.New("db error")
+				}
+			},
+			expectedErr: errors.New("failed to cancel jobs: db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			imageRepo := &RepositoryMock{
+				GetProjectRoleFunc: func(ctx context.Context, gotProjectID, gotUserID string) (string, error) {
+					assert.Equal(t, projectID.String(), gotProjectID)
+					assert.Equal(t, userID.String(), gotUserID)
+					return project.RoleEditor, nil
+				},
+			}
+			jobRepo := &job.RepositoryMock{}
+			canceler := &queue.CancelerMock{
+				SignalCancelFunc: func(ctx context.Context, imageID string) error { return nil },
+			}
+			tc.setupMocks(imageRepo, jobRepo, canceler)
+
+			service := NewDefaultService(cfg, imageRepo, jobRepo)
+			service.canceler = canceler
+
+			img, err := service.CancelImage(context.Background(), tc.imageID, userID.String())
+
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+				assert.Nil(t, img)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, StatusCanceled, img.Status)
+			if tc.expectSignal {
+				assert.Len(t, canceler.SignalCancelCalls(), 1)
+			} else {
+				assert.Empty(t, canceler.SignalCancelCalls())
+			}
+		})
+	}
+}
+
+func TestDefaultService_ExpediteImage(t *testing.T) {
+	t.Setenv("APP_ENV", "test")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Expedite.DailyLimit = 3
+
+	imageID := uuid.New()
+	jobID := uuid.New()
+	projectID := uuid.New()
+	ownerID := uuid.New()
+	// The requester collaborates on the owner's project.
+	requesterID := uuid.New()
+
+	imageWithStatus := func(status queries.ImageStatus) func(context.Context, string) (*queries.Image, error) {
+		return func(ctx context.Context, imageID string) (*queries.Image, error) {
 			return &queries.Image{
-				ID:          pgtype.UUID{Bytes: imageID, Valid: true},
+				ID:          pgtype.UUID{Bytes: uuid.MustParse(imageID), Valid: true},
 				ProjectID:   pgtype.UUID{Bytes: projectID, Valid: true},
 				OriginalUrl: "http://example.com/image.jpg",
-				Status:      queries.ImageStatusQueued,
-				CreatedAt:   pgtype.Timestamptz{Time: time.Now(), Valid: true},
-				UpdatedAt:   pgtype.Timestamptz{Time: time.Now(), Valid: true},
+				Status:      status,
 			}, nil
 		}
 	}
+	type accessFunc func(context.Context, string, time.Time) (*queries.GetExpediteAccessRow, error)
+	access := func(allowed bool, used int64) accessFunc {
+		return func(ctx context.Context, imageID string, since time.Time) (*queries.GetExpediteAccessRow, error) {
+			return &queries.GetExpediteAccessRow{
+				ProjectID:      pgtype.UUID{Bytes: projectID, Valid: true},
+				UserID:         pgtype.UUID{Bytes: ownerID, Valid: true},
+				Allowed:        allowed,
+				ExpeditedSince: used,
+			}, nil
+		}
+	}
+	queuedJobs := func(ctx context.Context, imageID string) ([]*queries.Job, error) {
+		return []*queries.Job{
+			{ID: pgtype.UUID{Bytes: jobID, Valid: true}, Type: queue.TaskTypeStageRun, Status: "queued"},
+			{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, Type: queue.TaskTypeStageRun, Status: "failed"},
+		}, nil
+	}
+
+	testCases := []struct {
+		name        string
+		imageID     string
+		setupMocks  func(*RepositoryMock, *job.RepositoryMock, *queue.ExpediterMock)
+		expectMove  bool
+		expectedErr error
+	}{
+		{
+			name:    "success: queued task moved and recorded",
+			imageID: imageID.String(),
+			setupMocks: func(imageRepo *RepositoryMock, jobRepo *job.RepositoryMock, expediter *queue.ExpediterMock) {
+				imageRepo.GetImageByIDFunc = imageWithStatus(queries.ImageStatusQueued)
+				imageRepo.GetExpediteAccessFunc = access(true, 2)
+				jobRepo.GetJobsByImageIDFunc = queuedJobs
+			},
+			expectMove: true,
+		},
+		{
+			name:    "success: failing to record activity is not fatal",
+			imageID: imageID.String(),
+			setupMocks: func(imageRepo *RepositoryMock, jobRepo *job.RepositoryMock, expediter *queue.ExpediterMock) {
+				imageRepo.GetImageByIDFunc = imageWithStatus(queries.ImageStatusQueued)
+				imageRepo.GetExpediteAccessFunc = access(true, 0)
+				imageRepo.RecordImageExpeditedFunc = func(
+					ctx context.Context, projectID, imageID, jobID, userID string,
+				) error {
+					return errors.New("db error")
+				}
+				jobRepo.GetJobsByImageIDFunc = queuedJobs
+			},
+			expectMove: true,
+		},
+		{
+			name:        "fail: empty image id",
+			imageID:     "",
+			setupMocks:  func(*RepositoryMock, *job.RepositoryMock, *queue.ExpediterMock) {},
+			expectedErr: errors.New("image ID cannot be empty"),
+		},
+		{
+			name:    "fail: requester is not a project member",
+			imageID: imageID.String(),
+			setupMocks: func(imageRepo *RepositoryMock, jobRepo *job.RepositoryMock, expediter *queue.ExpediterMock) {
+				imageRepo.GetImageByIDFunc = imageWithStatus(queries.ImageStatusQueued)
+				imageRepo.GetProjectRoleFunc = func(ctx context.Context, projectID, userID string) (string, error) {
+					return "", nil
+				}
+			},
+			expectedErr: pgx.ErrNoRows,
+		},
+		{
+			name:    "fail: viewers cannot expedite",
+			imageID: imageID.String(),
+			setupMocks: func(imageRepo *RepositoryMock, jobRepo *job.RepositoryMock, expediter *queue.ExpediterMock) {
+				imageRepo.GetImageByIDFunc = imageWithStatus(queries.ImageStatusQueued)
+				imageRepo.GetProjectRoleFunc = func(ctx context.Context, projectID, userID string) (string, error) {
+					return project.RoleViewer, nil
+				}
+			},
+			expectedErr: ErrImageReadOnly,
+		},
+		{
+			name:    "fail: membership lookup error",
+			imageID: imageID.String(),
+			setupMocks: func(imageRepo *RepositoryMock, jobRepo *job.RepositoryMock, expediter *queue.ExpediterMock) {
+				imageRepo.GetImageByIDFunc = imageWithStatus(queries.ImageStatusQueued)
+				imageRepo.GetProjectRoleFunc = func(ctx context.Context, projectID, userID string) (string, error) {
+					return "", errors.New("db error")
+				}
+			},
+			expectedErr: errors.New("failed to check image access: db error"),
+		},
+		{
+			name:    "fail: image already processing",
+			imageID: imageID.String(),
+			setupMocks: func(imageRepo *RepositoryMock, jobRepo *job.RepositoryMock, expediter *queue.ExpediterMock) {
+				imageRepo.GetImageByIDFunc = imageWithStatus(queries.ImageStatusProcessing)
+			},
+			expectedErr: ErrImageNotExpeditable,
+		},
+		{
+			name:    "fail: plan does not include expediting",
+			imageID: imageID.String(),
+			setupMocks: func(imageRepo *RepositoryMock, jobRepo *job.RepositoryMock, expediter *queue.ExpediterMock) {
+				imageRepo.GetImageByIDFunc = imageWithStatus(queries.ImageStatusQueued)
+				imageRepo.GetExpediteAccessFunc = access(false, 0)
+			},
+			expectedErr: ErrExpediteNotAllowed,
+		},
+		{
+			name:    "fail: daily limit reached",
+			imageID: imageID.String(),
+			setupMocks: func(imageRepo *RepositoryMock, jobRepo *job.RepositoryMock, expediter *queue.ExpediterMock) {
+				imageRepo.GetImageByIDFunc = imageWithStatus(queries.ImageStatusQueued)
+				imageRepo.GetExpediteAccessFunc = access(true, 3)
+			},
+			expectedErr: ErrExpediteLimitReached,
+		},
+		{
+			name:    "fail: no queued job",
+			imageID: imageID.String(),
+			setupMocks: func(imageRepo *RepositoryMock, jobRepo *job.RepositoryMock, expediter *queue.ExpediterMock) {
+				imageRepo.GetImageByIDFunc = imageWithStatus(queries.ImageStatusQueued)
+				imageRepo.GetExpediteAccessFunc = access(true, 0)
+				jobRepo.GetJobsByImageIDFunc = func(ctx context.Context, imageID string) ([]*queries.Job, error) {
+					return []*queries.Job{}, nil
+				}
+			},
+			expectedErr: ErrImageNotExpeditable,
+		},
+		{
+			name:    "fail: task picked up before the move",
+			imageID: imageID.String(),
+			setupMocks: func(imageRepo *RepositoryMock, jobRepo *job.RepositoryMock, expediter *queue.ExpediterMock) {
+				imageRepo.GetImageByIDFunc = imageWithStatus(queries.ImageStatusQueued)
+				imageRepo.GetExpediteAccessFunc = access(true, 0)
+				jobRepo.GetJobsByImageIDFunc = queuedJobs
+				expediter.ExpediteStageRunFunc = func(ctx context.Context, taskID string) (bool, error) {
+					return false, nil
+				}
+			},
+			expectedErr: ErrImageNotExpeditable,
+		},
+		{
+			name:    "fail: queue error",
+			imageID: imageID.String(),
+			setupMocks: func(imageRepo *RepositoryMock, jobRepo *job.RepositoryMock, expediter *queue.ExpediterMock) {
+				imageRepo.GetImageByIDFunc = imageWithStatus(queries.ImageStatusQueued)
+				imageRepo.GetExpediteAccessFunc = access(true, 0)
+				jobRepo.GetJobsByImageIDFunc = queuedJobs
+				expediter.ExpediteStageRunFunc = func(ctx context.Context, taskID string) (bool, error) {
+					return false, errors.New("redis down")
+				}
+			},
+			expectedErr: errors.New("failed to expedite job: redis down"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			imageRepo := &RepositoryMock{
+				GetProjectRoleFunc: func(ctx context.Context, gotProjectID, userID string) (string, error) {
+					assert.Equal(t, projectID.String(), gotProjectID)
+					assert.Equal(t, requesterID.String(), userID)
+					return project.RoleEditor, nil
+				},
+				RecordImageExpeditedFunc: func(ctx context.Context, projectID, imageID, jobID, userID string) error {
+					return nil
+				},
+			}
+			jobRepo := &job.RepositoryMock{}
+			expediter := &queue.ExpediterMock{
+				ExpediteStageRunFunc: func(ctx context.Context, taskID string) (bool, error) { return true, nil },
+			}
+			tc.setupMocks(imageRepo, jobRepo, expediter)
+
+			service := NewDefaultService(cfg, imageRepo, jobRepo)
+			service.expediter = expediter
+
+			img, err := service.ExpediteImage(context.Background(), tc.imageID, requesterID.String())
+
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+				assert.Nil(t, img)
+				assert.Empty(t, imageRepo.RecordImageExpeditedCalls())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, StatusQueued, img.Status)
+			require.Len(t, expediter.ExpediteStageRunCalls(), 1)
+			assert.Equal(t, jobID.String(), expediter.ExpediteStageRunCalls()[0].TaskID)
+			require.Len(t, imageRepo.RecordImageExpeditedCalls(), 1)
+			record := imageRepo.RecordImageExpeditedCalls()[0]
+			assert.Equal(t, projectID.String(), record.ProjectID)
+			assert.Equal(t, jobID.String(), record.JobID)
+			assert.Equal(t, requesterID.String(), record.UserID)
+		})
+	}
+
+	t.Run("fail: zero daily limit disables expediting", func(t *testing.T) {
+		disabled := *cfg
+		disabled.Expedite.DailyLimit = 0
+		imageRepo := &RepositoryMock{
+			GetImageByIDFunc:      imageWithStatus(queries.ImageStatusQueued),
+			GetExpediteAccessFunc: access(true, 0),
+			GetProjectRoleFunc: func(ctx context.Context, projectID, userID string) (string, error) {
+				return project.RoleOwner, nil
+			},
+		}
+		service := NewDefaultService(&disabled, imageRepo, &job.RepositoryMock{})
+
+		_, err := service.ExpediteImage(context.Background(), imageID.String(), requesterID.String())
+		assert.ErrorIs(t, err, ErrExpediteNotAllowed)
+	})
+}
+
+func TestDefaultService_CancelImage_ReleasesUsage(t *testing.T) {
+	t.Setenv("APP_ENV", "test")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	imageID, projectID, ownerID := uuid.New(), uuid.New(), uuid.NewString()
+	periodStart := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		ctx         context.Context
+		createdAt   time.Time
+		wantRelease int
+	}{
+		{
+			name:        "success: image from the current period is given back",
+			ctx:         context.Background(),
+			createdAt:   periodStart.Add(time.Hour),
+			wantRelease: 1,
+		},
+		{
+			name:      "success: image from a closed period is not",
+			ctx:       context.Background(),
+			createdAt: periodStart.Add(-time.Hour),
+		},
+		{
+			name:      "success: the sandbox tenant was never counted",
+			ctx:       storage.WithSandbox(context.Background()),
+			createdAt: periodStart.Add(time.Hour),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			img := &queries.Image{
+				ID:        pgtype.UUID{Bytes: imageID, Valid: true},
+				ProjectID: pgtype.UUID{Bytes: projectID, Valid: true},
+				Status:    queries.ImageStatusAwaitingUpload,
+				CreatedAt: pgtype.Timestamptz{Time: tc.createdAt, Valid: true},
+			}
+			imageRepo := &RepositoryMock{
+				GetImageByIDFunc: func(ctx context.Context, id string) (*queries.Image, error) { return img, nil },
+				GetProjectRoleFunc: func(ctx context.Context, projectID, userID string) (string, error) {
+					return project.RoleOwner, nil
+				},
+				CancelImageFunc: func(ctx context.Context, id string) (*queries.Image, error) { return img, nil },
+				GetProjectOwnerIDFunc: func(ctx context.Context, gotProjectID string) (string, error) {
+					assert.Equal(t, projectID.String(), gotProjectID)
+					return ownerID, nil
+				},
+			}
+			jobRepo := &job.RepositoryMock{
+				CancelJobsByImageIDFunc: func(ctx context.Context, imageID string) ([]*queries.Job, error) { return nil, nil },
+			}
+			usageMock := &usage.ServiceMock{
+				GetFunc: func(ctx context.Context, userID string) (*usage.Usage, error) {
+					return &usage.Usage{PeriodStart: periodStart}, nil
+				},
+				ReleaseFunc: func(ctx context.Context, userID string, start time.Time, n int) error { return nil },
+			}
+			service := NewDefaultService(cfg, imageRepo, jobRepo)
+			service.canceler = &queue.CancelerMock{
+				SignalCancelFunc: func(ctx context.Context, imageID string) error { return nil },
+			}
+			service.usage = usageMock
+
+			_, err := service.CancelImage(tc.ctx, imageID.String(), ownerID)
+			require.NoError(t, err)
+			if tc.wantRelease == 0 {
+				assert.Empty(t, usageMock.ReleaseCalls())
+				return
+			}
+			require.Len(t, usageMock.ReleaseCalls(), 1)
+			call := usageMock.ReleaseCalls()[0]
+			assert.Equal(t, ownerID, call.UserID)
+			assert.Equal(t, periodStart, call.PeriodStart)
+			assert.Equal(t, tc.wantRelease, call.N)
+		})
+	}
+}
+
+func TestDefaultService_BulkCancelImages(t *testing.T) {
+	t.Setenv("APP_ENV", "test")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	projectID := uuid.New().String()
+	queuedID := uuid.New()
+	processingID := uuid.New()
+	readyID := uuid.New()
+	missingID := uuid.New()
+	jobID := uuid.New()
+
+	t.Run("success: per-item results", func(t *testing.T) {
+		imageRepo := &RepositoryMock{
+			GetImageStatusesByIDsFunc: func(
+				ctx context.Context, projectID string, imageIDs []string,
+			) ([]*queries.GetImageStatusesByIDsRow, error) {
+				return []*queries.GetImageStatusesByIDsRow{
+					{ID: pgtype.UUID{Bytes: queuedID, Valid: true}, Status: queries.ImageStatusQueued},
+					{ID: pgtype.UUID{Bytes: processingID, Valid: true}, Status: queries.ImageStatusProcessing},
+					{ID: pgtype.UUID{Bytes: readyID, Valid: true}, Status: queries.ImageStatusReady},
+				}, nil
+			},
+			BulkCancelImagesFunc: func(
+				ctx context.Context, projectID string, imageIDs []string,
+			) ([]*queries.BulkCancelImagesRow, error) {
+				return []*queries.BulkCancelImagesRow{
+					{ImageID: pgtype.UUID{Bytes: queuedID, Valid: true}, JobID: pgtype.UUID{Bytes: jobID, Valid: true}},
+					{ImageID: pgtype.UUID{Bytes: processingID, Valid: true}},
+				}, nil
+			},
+		}
+		canceler := &queue.CancelerMock{
+			CancelStageRunFunc: func(ctx context.Context, taskID string) (bool, error) { return true, nil },
+			SignalCancelFunc:   func(ctx context.Context, imageID string) error { return nil },
+		}
+		service := NewDefaultService(cfg, imageRepo, nil)
+		service.canceler = canceler
+
+		ids := []string{queuedID.String(), processingID.String(), readyID.String(), missingID.String(), queuedID.String()}
+		resp, err := service.BulkCancelImages(context.Background(), projectID, ids)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, resp.Success)
+		assert.Equal(t, 2, resp.Failed)
+		assert.Equal(t, []BulkImageResult{
+			{ImageID: queuedID.String(), Result: BulkResultCanceled},
+			{ImageID: processingID.String(), Result: BulkResultCanceled},
+			{ImageID: readyID.String(), Result: BulkResultNotCancelable},
+			{ImageID: missingID.String(), Result: BulkResultNotFound},
+		}, resp.Results)
+
+		// Duplicates are collapsed before hitting the database.
+		assert.Len(t, imageRepo.BulkCancelImagesCalls()[0].ImageIDs, 4)
+		// Only the processing image needs a worker signal; the queued task was removed.
+		assert.Len(t, canceler.CancelStageRunCalls(), 1)
+		if assert.Len(t, canceler.SignalCancelCalls(), 1) {
+			assert.Equal(t, processingID.String(), canceler.SignalCancelCalls()[0].ImageID)
+		}
+	})
+
+	t.Run("success: gives canceled images of the current period back", func(t *testing.T) {
+		periodStart := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+		current := pgtype.Timestamptz{Time: periodStart.Add(time.Hour), Valid: true}
+		closed := pgtype.Timestamptz{Time: periodStart.Add(-time.Hour), Valid: true}
+		imageRepo := &RepositoryMock{
+			GetImageStatusesByIDsFunc: func(
+				ctx context.Context, projectID string, imageIDs []string,
+			) ([]*queries.GetImageStatusesByIDsRow, error) {
+				return []*queries.GetImageStatusesByIDsRow{
+					{ID: pgtype.UUID{Bytes: queuedID, Valid: true}, Status: queries.ImageStatusAwaitingUpload},
+					{ID: pgtype.UUID{Bytes: processingID, Valid: true}, Status: queries.ImageStatusAwaitingUpload},
+					{ID: pgtype.UUID{Bytes: readyID, Valid: true}, Status: queries.ImageStatusAwaitingUpload},
+				}, nil
+			},
+			BulkCancelImagesFunc: func(
+				ctx context.Context, projectID string, imageIDs []string,
+			) ([]*queries.BulkCancelImagesRow, error) {
+				// An image with several jobs has a row per job but is given back once.
+				return []*queries.BulkCancelImagesRow{
+					{ImageID: pgtype.UUID{Bytes: queuedID, Valid: true}, CreatedAt: current,
+						JobID: pgtype.UUID{Bytes: jobID, Valid: true}},
+					{ImageID: pgtype.UUID{Bytes: queuedID, Valid: true}, CreatedAt: current,
+						JobID: pgtype.UUID{Bytes: uuid.New(), Valid: true}},
+					{ImageID: pgtype.UUID{Bytes: processingID, Valid: true}, CreatedAt: current},
+					{ImageID: pgtype.UUID{Bytes: readyID, Valid: true}, CreatedAt: closed},
+				}, nil
+			},
+			GetProjectOwnerIDFunc: func(ctx context.Context, projectID string) (string, error) { return "owner", nil },
+		}
+		usageMock := &usage.ServiceMock{
+			GetFunc: func(ctx context.Context, userID string) (*usage.Usage, error) {
+				return &usage.Usage{PeriodStart: periodStart}, nil
+			},
+			ReleaseFunc: func(ctx context.Context, userID string, start time.Time, n int) error { return nil },
+		}
+		service := NewDefaultService(cfg, imageRepo, nil)
+		service.canceler = &queue.CancelerMock{
+			CancelStageRunFunc: func(ctx context.Context, taskID string) (bool, error) { return true, nil },
+			SignalCancelFunc:   func(ctx context.Context, imageID string) error { return nil },
+		}
+		service.usage = usageMock
+
+		ids := []string{queuedID.String(), processingID.String(), readyID.String()}
+		resp, err := service.BulkCancelImages(context.Background(), projectID, ids)
+		require.NoError(t, err)
+		assert.Equal(t, 3, resp.Success)
+		require.Len(t, usageMock.ReleaseCalls(), 1)
+		assert.Equal(t, "owner", usageMock.ReleaseCalls()[0].UserID)
+		assert.Equal(t, 2, usageMock.ReleaseCalls()[0].N)
+	})
+
+	t.Run("fail: empty project id", func(t *testing.T) {
+		service := NewDefaultService(cfg, &RepositoryMock{}, nil)
+		_, err := service.BulkCancelImages(context.Background(), "", []string{queuedID.String()})
+		assert.EqualError(t, err, "project ID cannot be empty")
+	})
+
+	t.Run("fail: status lookup error", func(t *testing.T) {
+		imageRepo := &RepositoryMock{
+			GetImageStatusesByIDsFunc: func(
+				ctx context.Context, projectID string, imageIDs []string,
+			) ([]*queries.GetImageStatusesByIDsRow, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		service := NewDefaultService(cfg, imageRepo, nil)
+		_, err := service.BulkCancelImages(context.Background(), projectID, []string{queuedID.String()})
+		assert.EqualError(t, err, "failed to get images: db error")
+	})
+
+	t.Run("fail: cancel error", func(t *testing.T) {
+		imageRepo := &RepositoryMock{
+			GetImageStatusesByIDsFunc: func(
+				ctx context.Context, projectID string, imageIDs []string,
+			) ([]*queries.GetImageStatusesByIDsRow, error) {
+				return nil, nil
+			},
+			BulkCancelImagesFunc: func(
+				ctx context.Context, projectID string, imageIDs []string,
+			) ([]*queries.BulkCancelImagesRow, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		service := NewDefaultService(cfg, imageRepo, nil)
+		_, err := service.BulkCancelImages(context.Background(), projectID, []string{queuedID.String()})
+		assert.EqualError(t, err, "failed to cancel images: db error")
+	})
+}
+
+func TestDefaultService_BulkDeleteImages(t *testing.T) {
+	t.Setenv("APP_ENV", "test")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	projectID := uuid.New().String()
+	readyID := uuid.New()
+	processingID := uuid.New()
+	missingID := uuid.New()
+	heldID := uuid.New()
+
+	t.Run("success: per-item results", func(t *testing.T) {
+		imageRepo := &RepositoryMock{
+			BulkDeleteImagesFunc: func(
+				ctx context.Context, projectID string, imageIDs []string,
+			) ([]*queries.BulkDeleteImagesRow, error) {
+				assert.Contains(t, imageIDs, heldID.String())
+				return []*queries.BulkDeleteImagesRow{
+					{ID: pgtype.UUID{Bytes: readyID, Valid: true}, Status: queries.ImageStatusReady},
+					{ID: pgtype.UUID{Bytes: processingID, Valid: true}, Status: queries.ImageStatusProcessing},
+					{ID: pgtype.UUID{Bytes: heldID, Valid: true}, Status: queries.ImageStatusProcessing, Held: true},
+				}, nil
+			},
+		}
+		canceler := &queue.CancelerMock{
+			SignalCancelFunc: func(ctx context.Context, imageID string) error { return nil },
+		}
+		service := NewDefaultService(cfg, imageRepo, nil)
+		service.canceler = canceler
+
+		ids := []string{readyID.String(), processingID.String(), missingID.String(), heldID.String()}
+		resp, err := service.BulkDeleteImages(context.Background(), projectID, ids)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, resp.Success)
+		assert.Equal(t, 2, resp.Failed)
+		assert.Equal(t, []BulkImageResult{
+			{ImageID: readyID.String(), Result: BulkResultDeleted},
+			{ImageID: processingID.String(), Result: BulkResultDeleted},
+			{ImageID: missingID.String(), Result: BulkResultNotFound},
+			{ImageID: heldID.String(), Result: BulkResultLegalHold},
+		}, resp.Results)
+		if assert.Len(t, canceler.SignalCancelCalls(), 1) {
+			assert.Equal(t, processingID.String(), canceler.SignalCancelCalls()[0].ImageID)
+		}
+	})
+
+	t.Run("fail: delete error", func(t *testing.T) {
+		imageRepo := &RepositoryMock{
+			BulkDeleteImagesFunc: func(
+				ctx context.Context, projectID string, imageIDs []string,
+			) ([]*queries.BulkDeleteImagesRow, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		service := NewDefaultService(cfg, imageRepo, nil)
+		_, err := service.BulkDeleteImages(context.Background(), projectID, []string{readyID.String()})
+		assert.EqualError(t, err, "failed to delete images: db error")
+	})
+}
+
+// Helper to create a successful image creation mock
+func mockSuccessfulImageCreation(imageID, projectID uuid.UUID) func(*RepositoryMock, *job.RepositoryMock) {
+	return func(imageRepo *RepositoryMock, _ *job.RepositoryMock) {
+		imageRepo.CreateImageFunc = func(
+			ctx context.Context,
+			projectIDStr, originalURL string,
+			roomType, style *string,
+			seed *int64,
+			source Source,
+		) (*queries.Image, error) {
+			return &queries.Image{
+				ID:          pgtype.UUID{Bytes: imageID, Valid: true},
+				ProjectID:   pgtype.UUID{Bytes: projectID, Valid: true},
+				OriginalUrl: "http://example.com/image.jpg",
+				Status:      queries.ImageStatusQueued,
+				CreatedAt:   pgtype.Timestamptz{Time: time.Now(), Valid: true},
+				UpdatedAt:   pgtype.Timestamptz{Time: time.Now(), Valid: true},
+			}, nil
+		}
+	}
+}
+
+// recordingEnqueuer records enqueued images and the transaction outcomes seen at enqueue time.
+type recordingEnqueuer struct {
+	events *[]string
+}
+
+func (e recordingEnqueuer) EnqueueStageRun(
+	ctx context.Context, payload queue.StageRunPayload, opts *queue.EnqueueOpts,
+) (string, error) {
+	*e.events = append(*e.events, "enqueue")
+	return "task", nil
+}
+
+type scanRow func(dest ...any) error
+
+func (r scanRow) Scan(dest ...any) error { return r(dest...) }
+
+func TestDefaultService_CreateImage_Transaction(t *testing.T) {
+	t.Setenv("APP_ENV", "test")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	projectID := uuid.New()
+	ownerID := uuid.New()
+
+	// newDB fakes single-row INSERTs for CreateImage and COPY plus a read-back for BatchCreateImages.
+	newDB := func(events *[]string, failJobs bool) *storage.DatabaseMock {
+		var copied [][]any
+		db := &storage.DatabaseMock{
+			QueryRowFunc: func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+				switch {
+				case strings.Contains(sql, "FROM projects"):
+					return scanRow(func(dest ...any) error {
+						*(dest[2].(*pgtype.UUID)) = pgtype.UUID{Bytes: ownerID, Valid: true}
+						return nil
+					})
+				case strings.Contains(sql, "INSERT INTO images"):
+					return scanRow(func(dest ...any) error {
+						*(dest[0].(*pgtype.UUID)) = pgtype.UUID{Bytes: uuid.New(), Valid: true}
+						return nil
+					})
+				case strings.Contains(sql, "INSERT INTO jobs"):
+					if failJobs {
+						return scanRow(func(dest ...any) error { return errors.New("job error") })
+					}
+					return scanRow(func(dest ...any) error {
+						*(dest[0].(*pgtype.UUID)) = pgtype.UUID{Bytes: uuid.New(), Valid: true}
+						return nil
+					})
+				}
+				return scanRow(func(dest ...any) error { return fmt.Errorf("unexpected query: %s", sql) })
+			},
+			CopyFromFunc: func(
+				ctx context.Context, table pgx.Identifier, columns []string, rowSrc pgx.CopyFromSource,
+			) (int64, error) {
+				if table[0] == "jobs" && failJobs {
+					return 0, errors.New("job error")
+				}
+				var n int64
+				for rowSrc.Next() {
+					values, err := rowSrc.Values()
+					if err != nil {
+						return n, err
+					}
+					if table[0] == "images" {
+						copied = append(copied, values)
+					}
+					n++
+				}
+				return n, nil
+			},
+			QueryFunc: func(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+				pool, err := pgxmock.NewPool()
+				if err != nil {
+					return nil, err
+				}
+				rows := pgxmock.NewRows([]string{
+					"id", "project_id", "original_url", "staged_url", "room_type", "style",
+					"seed", "status", "error", "created_at", "updated_at", "source", "preview_url", "watermarked_url",
+					"raw_url",
+				})
+				for _, v := range copied {
+					rows.AddRow(v[0], v[1], v[2], pgtype.Text{}, v[3], v[4], v[5], queries.ImageStatusQueued,
+						pgtype.Text{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, v[6], pgtype.Text{}, pgtype.Text{},
+						pgtype.Text{})
+				}
+				pool.ExpectQuery("FROM images").WithArgs(pgxmock.AnyArg()).WillReturnRows(rows)
+				return pool.Query(ctx, sql, args...)
+			},
+		}
+		db.WithTxFunc = func(ctx context.Context, fn func(tx storage.Database) error) error {
+			if err := fn(db); err != nil {
+				*events = append(*events, "rollback")
+				return err
+			}
+			*events = append(*events, "commit")
+			return nil
+		}
+		return db
+	}
+
+	testCases := []struct {
+		name       string
+		batch      int
+		failJobs   bool
+		wantEvents []string
+		wantErr    string
+	}{
+		{
+			name:       "success: image and job commit before enqueue",
+			batch:      1,
+			wantEvents: []string{"reserve 1", "commit", "enqueue"},
+		},
+		{
+			name:       "fail: job error rolls back the image and releases its usage",
+			batch:      1,
+			failJobs:   true,
+			wantEvents: []string{"reserve 1", "rollback", "release 1"},
+			wantErr:    "failed to create job: failed to create job: job error",
+		},
+		{
+			name:       "success: batch commits once then enqueues each image",
+			batch:      3,
+			wantEvents: []string{"reserve 3", "commit", "enqueue", "enqueue", "enqueue"},
+		},
+		{
+			name:       "fail: batch rolls back entirely and enqueues nothing",
+			batch:      3,
+			failJobs:   true,
+			wantEvents: []string{"reserve 3", "rollback", "release 3"},
+			wantErr:    "failed to create jobs: failed to create jobs: job error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var events []string
+			service := NewDefaultServiceWithDB(cfg, newDB(&events, tc.failJobs), nil, nil)
+			service.enqueuer = recordingEnqueuer{events: &events}
+			service.usage = &usage.ServiceMock{
+				ReserveFunc: func(ctx context.Context, userID string, n int) (*usage.Usage, error) {
+					assert.Equal(t, ownerID.String(), userID)
+					events = append(events, fmt.Sprintf("reserve %d", n))
+					return &usage.Usage{}, nil
+				},
+				ReleaseFunc: func(ctx context.Context, userID string, periodStart time.Time, n int) error {
+					events = append(events, fmt.Sprintf("release %d", n))
+					return nil
+				},
+			}
+
+			reqs := make([]CreateImageRequest, tc.batch)
+			for i := range reqs {
+				reqs[i] = CreateImageRequest{ProjectID: projectID, OriginalURL: "http://example.com/image.jpg"}
+			}
+
+			if tc.batch == 1 {
+				_, err = service.CreateImage(context.Background(), &reqs[0])
+			} else {
+				_, err = service.BatchCreateImages(context.Background(), reqs)
+			}
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantEvents, events)
+		})
+	}
+}
+
+// capturingEnqueuer records the payloads it is asked to enqueue.
+type capturingEnqueuer struct {
+	payloads *[]queue.StageRunPayload
+}
+
+func (e capturingEnqueuer) EnqueueStageRun(
+	ctx context.Context, payload queue.StageRunPayload, opts *queue.EnqueueOpts,
+) (string, error) {
+	*e.payloads = append(*e.payloads, payload)
+	return "task", nil
+}
+
+func TestDefaultService_CreateImage_Sandbox(t *testing.T) {
+	t.Setenv("APP_ENV", "test")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, sandbox := range []bool{true, false} {
+		t.Run(fmt.Sprintf("success: sandbox=%v reaches the job and task payloads", sandbox), func(t *testing.T) {
+			imageRepo := &RepositoryMock{
+				CreateImageFunc: func(
+					ctx context.Context, projectIDStr, originalURL string, roomType, style *string, seed *int64, source Source,
+				) (*queries.Image, error) {
+					return &queries.Image{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, OriginalUrl: originalURL}, nil
+				},
+			}
+			var jobPayload JobPayload
+			jobRepo := &job.RepositoryMock{
+				CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+					return &queries.Job{}, json.Unmarshal(payloadJSON, &jobPayload)
+				},
+			}
+			var enqueued []queue.StageRunPayload
+			service := NewDefaultService(cfg, imageRepo, jobRepo)
+			service.enqueuer = capturingEnqueuer{payloads: &enqueued}
+
+			_, err := service.CreateImage(context.Background(), &CreateImageRequest{
+				ProjectID:   uuid.New(),
+				OriginalURL: "http://example.com/image.jpg",
+				Sandbox:     sandbox,
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, sandbox, jobPayload.Sandbox)
+			require.Len(t, enqueued, 1)
+			assert.Equal(t, sandbox, enqueued[0].Sandbox)
+		})
+	}
+}
+
+func TestDefaultService_CreateImage_Usage(t *testing.T) {
+	t.Setenv("APP_ENV", "test")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ownerA, ownerB := uuid.NewString(), uuid.NewString()
+	projectA, projectB, projectA2 := uuid.New(), uuid.New(), uuid.New()
+	owners := map[string]string{projectA.String(): ownerA, projectA2.String(): ownerA, projectB.String(): ownerB}
+	quotaErr := &usage.QuotaError{Usage: usage.Usage{Plan: "pro", Limit: 10, Used: 10}, Requested: 1}
+
+	testCases := []struct {
+		name        string
+		ctx         context.Context
+		reqs        []CreateImageRequest
+		quotaOwner  string
+		wantReserve map[string]int
+		wantRelease map[string]int
+		wantCreated int
+		wantErr     error
+	}{
+		{
+			name:        "success: images counted per project owner",
+			ctx:         context.Background(),
+			reqs:        []CreateImageRequest{{ProjectID: projectA}, {ProjectID: projectB}, {ProjectID: projectA2}},
+			wantReserve: map[string]int{ownerA: 2, ownerB: 1},
+			wantCreated: 3,
+		},
+		{
+			name:        "success: the request's sandbox flag does not skip the quota",
+			ctx:         context.Background(),
+			reqs:        []CreateImageRequest{{ProjectID: projectA, Sandbox: true}, {ProjectID: projectB}},
+			wantReserve: map[string]int{ownerA: 1, ownerB: 1},
+			wantCreated: 2,
+		},
+		{
+			name:        "success: the sandbox tenant is not counted",
+			ctx:         storage.WithSandbox(context.Background()),
+			reqs:        []CreateImageRequest{{ProjectID: projectA}},
+			wantReserve: map[string]int{},
+			wantCreated: 1,
+		},
+		{
+			name:        "fail: owner over quota creates nothing and releases the others",
+			ctx:         context.Background(),
+			reqs:        []CreateImageRequest{{ProjectID: projectA}, {ProjectID: projectB}},
+			quotaOwner:  ownerB,
+			wantReserve: map[string]int{ownerA: 1},
+			wantRelease: map[string]int{ownerA: 1},
+			wantErr:     usage.ErrQuotaExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var created int
+			imageRepo := &RepositoryMock{
+				GetProjectOwnerIDFunc: func(ctx context.Context, projectID string) (string, error) {
+					return owners[projectID], nil
+				},
+				CreateImageFunc: func(
+					ctx context.Context, projectIDStr, originalURL string, roomType, style *string, seed *int64, source Source,
+				) (*queries.Image, error) {
+					created++
+					return &queries.Image{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}}, nil
+				},
+				CreateImagesFunc: func(ctx context.Context, reqs []CreateImageRequest) ([]*queries.Image, error) {
+					images := make([]*queries.Image, len(reqs))
+					for i := range reqs {
+						created++
+						images[i] = &queries.Image{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}}
+					}
+					return images, nil
+				},
+			}
+			jobRepo := &job.RepositoryMock{
+				CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+					return &queries.Job{}, nil
+				},
+				CreateJobsFunc: func(ctx context.Context, reqs []job.CreateJobRequest) ([]*queries.Job, error) {
+					return make([]*queries.Job, len(reqs)), nil
+				},
+			}
+			reserved, released := map[string]int{}, map[string]int{}
+			service := NewDefaultService(cfg, imageRepo, jobRepo)
+			service.enqueuer = queue.NoopEnqueuer{}
+			service.usage = &usage.ServiceMock{
+				ReserveFunc: func(ctx context.Context, userID string, n int) (*usage.Usage, error) {
+					if userID == tc.quotaOwner {
+						return nil, quotaErr
+					}
+					reserved[userID] += n
+					return &usage.Usage{}, nil
+				},
+				ReleaseFunc: func(ctx context.Context, userID string, periodStart time.Time, n int) error {
+					released[userID] += n
+					return nil
+				},
+			}
+
+			for i := range tc.reqs {
+				tc.reqs[i].OriginalURL = "http://example.com/image.jpg"
+			}
+			if len(tc.reqs) == 1 {
+				_, err = service.CreateImage(tc.ctx, &tc.reqs[0])
+			} else {
+				_, err = service.BatchCreateImages(tc.ctx, tc.reqs)
+			}
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantReserve, reserved)
+			if tc.wantRelease == nil {
+				tc.wantRelease = map[string]int{}
+			}
+			assert.Equal(t, tc.wantRelease, released)
+			assert.Equal(t, tc.wantCreated, created)
+		})
+	}
+}
+
+func TestDefaultService_CreateImage_PublishesQueued(t *testing.T) {
+	t.Setenv("APP_ENV", "test")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name       string
+		publishErr error
+	}{
+		{name: "success: queued image announced on its project stream"},
+		{name: "success: publish failure does not fail the create", publishErr: errors.New("redis down")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			projectID := uuid.New()
+			imageID := uuid.New()
+			imageRepo := &RepositoryMock{
+				CreateImageFunc: func(
+					ctx context.Context, projectIDStr, originalURL string, roomType, style *string, seed *int64, source Source,
+				) (*queries.Image, error) {
+					return &queries.Image{
+						ID:          pgtype.UUID{Bytes: imageID, Valid: true},
+						ProjectID:   pgtype.UUID{Bytes: uuid.MustParse(projectIDStr), Valid: true},
+						OriginalUrl: originalURL,
+					}, nil
+				},
+			}
+			var jobPayload JobPayload
+			jobRepo := &job.RepositoryMock{
+				CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+					return &queries.Job{}, json.Unmarshal(payloadJSON, &jobPayload)
+				},
+			}
+			var enqueued []queue.StageRunPayload
+			statuses := &sse.PublisherMock{
+				PublishQueuedFunc: func(ctx context.Context, projectID, imageID string) error { return tc.publishErr },
+			}
+			service := NewDefaultService(cfg, imageRepo, jobRepo)
+			service.enqueuer = capturingEnqueuer{payloads: &enqueued}
+			service.statuses = statuses
+
+			_, err := service.CreateImage(context.Background(), &CreateImageRequest{
+				ProjectID:   projectID,
+				OriginalURL: "http://example.com/image.jpg",
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, projectID, jobPayload.ProjectID)
+			require.Len(t, enqueued, 1)
+			assert.Equal(t, projectID.String(), enqueued[0].ProjectID)
+			require.Len(t, statuses.PublishQueuedCalls(), 1)
+			assert.Equal(t, projectID.String(), statuses.PublishQueuedCalls()[0].ProjectID)
+			assert.Equal(t, imageID.String(), statuses.PublishQueuedCalls()[0].ImageID)
+		})
+	}
+}
+
+func TestDefaultService_CreateImage_OriginalURLBucket(t *testing.T) {
+	t.Setenv("APP_ENV", "test")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name         string
+		authorizeErr error
+		wantErr      error
+		errSubstr    string
+	}{
+		{name: "success: URL in the owner's storage"},
+		{
+			name:         "fail: URL in another account's bucket",
+			authorizeErr: fmt.Errorf("%w: acme-photos", storage.ErrForeignBucket),
+			wantErr:      ErrOriginalURLInvalid,
+		},
+		{
+			name:         "fail: bucket lookup error",
+			authorizeErr: errors.New("db down"),
+			errSubstr:    "failed to check original URL",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			projectID := uuid.New()
+			originalURL := "https://acme-photos.s3.us-east-1.amazonaws.com/uploads/room.jpg"
+			imageRepo := &RepositoryMock{
+				CreateImageFunc: func(
+					ctx context.Context, projectIDStr, originalURL string, roomType, style *string, seed *int64, source Source,
+				) (*queries.Image, error) {
+					return &queries.Image{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, OriginalUrl: originalURL}, nil
+				},
+			}
+			jobRepo := &job.RepositoryMock{
+				CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+					return &queries.Job{}, nil
+				},
+			}
+			buckets := &storage.BucketsMock{
+				AuthorizeFunc: func(ctx context.Context, id, rawURL string) error {
+					assert.Equal(t, projectID.String(), id)
+					assert.Equal(t, originalURL, rawURL)
+					return tc.authorizeErr
+				},
+			}
+			service := NewDefaultService(cfg, imageRepo, jobRepo)
+			service.enqueuer = capturingEnqueuer{payloads: &[]queue.StageRunPayload{}}
+			service.buckets = buckets
+
+			_, err := service.CreateImage(context.Background(), &CreateImageRequest{
+				ProjectID:   projectID,
+				OriginalURL: originalURL,
+			})
+			switch {
+			case tc.wantErr != nil:
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, imageRepo.CreateImageCalls())
+			case tc.errSubstr != "":
+				assert.ErrorContains(t, err, tc.errSubstr)
+				assert.Empty(t, imageRepo.CreateImageCalls())
+			default:
+				require.NoError(t, err)
+				assert.Len(t, imageRepo.CreateImageCalls(), 1)
+			}
+		})
+	}
+}
+
+func TestDefaultService_CreateImage_Catalog(t *testing.T) {
+	t.Setenv("APP_ENV", "test")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	catalogID := uuid.New()
+	coastal := &catalog.Catalog{
+		ID:                 catalogID.String(),
+		Name:               "Coastal",
+		PromptFragment:     "light oak and linen",
+		ReferenceImageURLs: []string{"https://cdn.example.com/coastal.jpg"},
+	}
+
+	testCases := []struct {
+		name      string
+		catalogs  catalog.Service
+		getErr    error
+		wantErr   error
+		errSubstr string
+	}{
+		{name: "success: catalog copied into job and task payloads"},
+		{name: "fail: inactive or missing catalog", getErr: catalog.ErrNotFound, wantErr: ErrCatalogUnavailable},
+		{name: "fail: lookup error", getErr: errors.New("db down"), errSubstr: "failed to resolve catalog"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			imageRepo := &RepositoryMock{
+				CreateImageFunc: func(
+					ctx context.Context, projectIDStr, originalURL string, roomType, style *string, seed *int64, source Source,
+				) (*queries.Image, error) {
+					return &queries.Image{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, OriginalUrl: originalURL}, nil
+				},
+			}
+			var jobPayload JobPayload
+			jobRepo := &job.RepositoryMock{
+				CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+					return &queries.Job{}, json.Unmarshal(payloadJSON, &jobPayload)
+				},
+			}
+			var enqueued []queue.StageRunPayload
+			service := NewDefaultService(cfg, imageRepo, jobRepo)
+			service.enqueuer = capturingEnqueuer{payloads: &enqueued}
+			service.catalogs = &catalog.ServiceMock{
+				GetActiveFunc: func(ctx context.Context, id string) (*catalog.Catalog, error) {
+					assert.Equal(t, catalogID.String(), id)
+					if tc.getErr != nil {
+						return nil, tc.getErr
+					}
+					return coastal, nil
+				},
+			}
+
+			_, err := service.CreateImage(context.Background(), &CreateImageRequest{
+				ProjectID:   uuid.New(),
+				OriginalURL: "http://example.com/image.jpg",
+				CatalogID:   &catalogID,
+			})
+			switch {
+			case tc.wantErr != nil:
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, imageRepo.CreateImageCalls())
+				return
+			case tc.errSubstr != "":
+				assert.ErrorContains(t, err, tc.errSubstr)
+				assert.Empty(t, imageRepo.CreateImageCalls())
+				return
+			}
+			require.NoError(t, err)
+
+			want := &queue.CatalogPayload{
+				ID:                 catalogID.String(),
+				Name:               "Coastal",
+				PromptFragment:     "light oak and linen",
+				ReferenceImageURLs: []string{"https://cdn.example.com/coastal.jpg"},
+			}
+			assert.Equal(t, want, jobPayload.Catalog)
+			require.Len(t, enqueued, 1)
+			assert.Equal(t, want, enqueued[0].Catalog)
+		})
+	}
+
+	t.Run("fail: catalogs not configured", func(t *testing.T) {
+		service := NewDefaultService(cfg, &RepositoryMock{}, &job.RepositoryMock{})
+		_, err := service.CreateImage(context.Background(), &CreateImageRequest{
+			ProjectID:   uuid.New(),
+			OriginalURL: "http://example.com/image.jpg",
+			CatalogID:   &catalogID,
+		})
+		assert.ErrorIs(t, err, ErrCatalogUnavailable)
+	})
+}
+
+func TestDefaultService_CreateImage_Preset(t *testing.T) {
+	t.Setenv("APP_ENV", "test")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	projectID := uuid.New()
+	presetID := uuid.New()
+	catalogID := uuid.New()
+	bedroom, modern, industrial := "bedroom", "modern", "industrial"
+	seed := int64(42)
+	catalogStr := catalogID.String()
+	saved := &preset.Preset{
+		ID:        presetID.String(),
+		Name:      "Listing default",
+		RoomType:  &bedroom,
+		Style:     &modern,
+		Seed:      &seed,
+		CatalogID: &catalogStr,
+		Prompt:    "keep the fireplace visible",
+	}
+
+	testCases := []struct {
+		name      string
+		style     *string
+		getErr    error
+		wantStyle string
+		wantErr   error
+		errSubstr string
+	}{
+		{name: "success: preset fills unset options", wantStyle: modern},
+		{name: "success: request overrides preset", style: &industrial, wantStyle: industrial},
+		{name: "fail: missing or foreign preset", getErr: preset.ErrNotFound, wantErr: ErrPresetUnavailable},
+		{name: "fail: lookup error", getErr: errors.New("db down"), errSubstr: "failed to resolve preset"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			imageRepo := &RepositoryMock{
+				CreateImageFunc: func(
+					ctx context.Context, projectIDStr, originalURL string, roomType, style *string, seed *int64, source Source,
+				) (*queries.Image, error) {
+					return &queries.Image{
+						ID:       pgtype.UUID{Bytes: uuid.New(), Valid: true},
+						RoomType: pgtype.Text{String: *roomType, Valid: true},
+						Style:    pgtype.Text{String: *style, Valid: true},
+						Seed:     pgtype.Int8{Int64: *seed, Valid: true},
+					}, nil
+				},
+			}
+			var jobPayload JobPayload
+			jobRepo := &job.RepositoryMock{
+				CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+					return &queries.Job{}, json.Unmarshal(payloadJSON, &jobPayload)
+				},
+			}
+			var enqueued []queue.StageRunPayload
+			service := NewDefaultService(cfg, imageRepo, jobRepo)
+			service.enqueuer = capturingEnqueuer{payloads: &enqueued}
+			service.presets = &preset.ServiceMock{
+				GetForProjectFunc: func(ctx context.Context, id, pid string) (*preset.Preset, error) {
+					assert.Equal(t, presetID.String(), id)
+					assert.Equal(t, projectID.String(), pid)
+					if tc.getErr != nil {
+						return nil, tc.getErr
+					}
+					return saved, nil
+				},
+			}
+			service.catalogs = &catalog.ServiceMock{
+				GetActiveFunc: func(ctx context.Context, id string) (*catalog.Catalog, error) {
+					assert.Equal(t, catalogID.String(), id)
+					return &catalog.Catalog{ID: id, Name: "Coastal", PromptFragment: "light oak"}, nil
+				},
+			}
+
+			_, err := service.CreateImage(context.Background(), &CreateImageRequest{
+				ProjectID:   projectID,
+				OriginalURL: "http://example.com/image.jpg",
+				Style:       tc.style,
+				PresetID:    &presetID,
+			})
+			switch {
+			case tc.wantErr != nil:
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, imageRepo.CreateImageCalls())
+				return
+			case tc.errSubstr != "":
+				assert.ErrorContains(t, err, tc.errSubstr)
+				assert.Empty(t, imageRepo.CreateImageCalls())
+				return
+			}
+			require.NoError(t, err)
+
+			require.Len(t, imageRepo.CreateImageCalls(), 1)
+			call := imageRepo.CreateImageCalls()[0]
+			assert.Equal(t, bedroom, *call.RoomType)
+			assert.Equal(t, tc.wantStyle, *call.Style)
+			assert.Equal(t, seed, *call.Seed)
+			assert.Equal(t, "keep the fireplace visible", jobPayload.Instructions)
+			require.NotNil(t, jobPayload.Catalog)
+			assert.Equal(t, catalogID.String(), jobPayload.Catalog.ID)
+			require.Len(t, enqueued, 1)
+			assert.Equal(t, "keep the fireplace visible", enqueued[0].Instructions)
+			assert.Equal(t, tc.wantStyle, *enqueued[0].Style)
+		})
+	}
+
+	t.Run("fail: presets not configured", func(t *testing.T) {
+		service := NewDefaultService(cfg, &RepositoryMock{}, &job.RepositoryMock{})
+		_, err := service.CreateImage(context.Background(), &CreateImageRequest{
+			ProjectID:   projectID,
+			OriginalURL: "http://example.com/image.jpg",
+			PresetID:    &presetID,
+		})
+		assert.ErrorIs(t, err, ErrPresetUnavailable)
+	})
+}
+
+func TestDefaultService_CreateImage_RoomStyle(t *testing.T) {
+	t.Setenv("APP_ENV", "test")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	projectID := uuid.New()
+	kitchen, modern, industrial := "kitchen", "modern", "industrial"
+
+	testCases := []struct {
+		name       string
+		roomType   *string
+		style      *string
+		roomStyle  string
+		lookupErr  error
+		wantStyle  *string
+		wantLookup bool
+		errSubstr  string
+	}{
+		{name: "success: checklist style fills unset style", roomType: &kitchen, roomStyle: modern, wantStyle: &modern, wantLookup: true},
+		{name: "success: room without a style leaves it unset", roomType: &kitchen, wantLookup: true},
+		{name: "success: request style wins", roomType: &kitchen, style: &industrial, wantStyle: &industrial},
+		{name: "success: no room type skips the checklist"},
+		{name: "fail: lookup error", roomType: &kitchen, lookupErr: errors.New("db down"), wantLookup: true, errSubstr: "failed to get project room style"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			imageRepo := &RepositoryMock{
+				GetProjectRoomStyleFunc: func(ctx context.Context, pid, roomType string) (string, error) {
+					assert.Equal(t, projectID.String(), pid)
+					assert.Equal(t, kitchen, roomType)
+					return tc.roomStyle, tc.lookupErr
+				},
+				CreateImageFunc: func(
+					ctx context.Context, projectIDStr, originalURL string, roomType, style *string, seed *int64, source Source,
+				) (*queries.Image, error) {
+					return &queries.Image{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}}, nil
+				},
+			}
+			jobRepo := &job.RepositoryMock{
+				CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+					return &queries.Job{}, nil
+				},
+			}
+			var enqueued []queue.StageRunPayload
+			service := NewDefaultService(cfg, imageRepo, jobRepo)
+			service.enqueuer = capturingEnqueuer{payloads: &enqueued}
+
+			_, err := service.CreateImage(context.Background(), &CreateImageRequest{
+				ProjectID:   projectID,
+				OriginalURL: "http://example.com/image.jpg",
+				RoomType:    tc.roomType,
+				Style:       tc.style,
+			})
+			assert.Equal(t, tc.wantLookup, len(imageRepo.GetProjectRoomStyleCalls()) == 1)
+			if tc.errSubstr != "" {
+				assert.ErrorContains(t, err, tc.errSubstr)
+				assert.Empty(t, imageRepo.CreateImageCalls())
+				return
+			}
+			require.NoError(t, err)
+
+			require.Len(t, imageRepo.CreateImageCalls(), 1)
+			assert.Equal(t, tc.wantStyle, imageRepo.CreateImageCalls()[0].Style)
+		})
+	}
+}
+
+func TestDefaultService_CreateImage_ReferenceImage(t *testing.T) {
+	t.Setenv("APP_ENV", "test")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ownerID := uuid.New()
+	ownKey := fmt.Sprintf("uploads/%s/inspiration-1.jpg", ownerID)
+	jpeg := &s3.HeadObjectOutput{ContentType: aws.String("image/jpeg"), ContentLength: aws.Int64(2 << 20)}
+
+	testCases := []struct {
+		name      string
+		key       string
+		allowed   bool
+		accessErr error
+		head      any
+		headErr   error
+		wantErr   error
+		errSubstr string
+	}{
+		{name: "success: reference image copied into job and task payloads", key: ownKey, allowed: true, head: jpeg},
+		{name: "fail: plan does not include reference images", key: ownKey, wantErr: ErrReferenceImageNotAllowed},
+		{
+			name:    "fail: another user's upload",
+			key:     fmt.Sprintf("uploads/%s/inspiration-1.jpg", uuid.New()),
+			allowed: true,
+			wantErr: ErrReferenceImageInvalid,
+		},
+		{
+			name:    "fail: path traversal out of the owner's uploads",
+			key:     fmt.Sprintf("uploads/%s/../other/inspiration-1.jpg", ownerID),
+			allowed: true,
+			wantErr: ErrReferenceImageInvalid,
+		},
+		{
+			name:    "fail: upload missing",
+			key:     ownKey,
+			allowed: true,
+			headErr: storage.ErrObjectNotFound,
+			wantErr: ErrReferenceImageInvalid,
+		},
+		{
+			name:    "fail: not an allowed image type",
+			key:     ownKey,
+			allowed: true,
+			head:    &s3.HeadObjectOutput{ContentType: aws.String("image/gif"), ContentLength: aws.Int64(1024)},
+			wantErr: ErrReferenceImageInvalid,
+		},
+		{
+			name:    "fail: too large",
+			key:     ownKey,
+			allowed: true,
+			head:    &s3.HeadObjectOutput{ContentType: aws.String("image/png"), ContentLength: aws.Int64(11 << 20)},
+			wantErr: ErrReferenceImageInvalid,
+		},
+		{
+			name:      "fail: access lookup error",
+			key:       ownKey,
+			accessErr: errors.New("db down"),
+			errSubstr: "reference image access",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			projectID := uuid.New()
+			imageRepo := &RepositoryMock{
+				GetReferenceImageAccessFunc: func(
+					ctx context.Context, id string,
+				) (*queries.GetReferenceImageAccessRow, error) {
+					assert.Equal(t, projectID.String(), id)
+					return &queries.GetReferenceImageAccessRow{
+						UserID:  pgtype.UUID{Bytes: ownerID, Valid: true},
+						Allowed: tc.allowed,
+					}, tc.accessErr
+				},
+				CreateImageFunc: func(
+					ctx context.Context, projectIDStr, originalURL string, roomType, style *string, seed *int64, source Source,
+				) (*queries.Image, error) {
+					return &queries.Image{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, OriginalUrl: originalURL}, nil
+				},
+			}
+			var jobPayload JobPayload
+			jobRepo := &job.RepositoryMock{
+				CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+					return &queries.Job{}, json.Unmarshal(payloadJSON, &jobPayload)
+				},
+			}
+			var enqueued []queue.StageRunPayload
+			service := NewDefaultService(cfg, imageRepo, jobRepo)
+			service.enqueuer = capturingEnqueuer{payloads: &enqueued}
+			service.buckets = storage.NewPlatformBuckets(&storage.S3ServiceMock{
+				HeadFileFunc: func(ctx context.Context, fileKey string) (interface{}, error) {
+					assert.Equal(t, tc.key, fileKey)
+					return tc.head, tc.headErr
+				},
+				BucketNameFunc: func() string { return cfg.S3.BucketName },
+			})
+
+			_, err := service.CreateImage(context.Background(), &CreateImageRequest{
+				ProjectID:         projectID,
+				OriginalURL:       "http://example.com/image.jpg",
+				ReferenceImageKey: tc.key,
+			})
+			switch {
+			case tc.wantErr != nil:
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, imageRepo.CreateImageCalls())
+				return
+			case tc.errSubstr != "":
+				assert.ErrorContains(t, err, tc.errSubstr)
+				assert.Empty(t, imageRepo.CreateImageCalls())
+				return
+			}
+			require.NoError(t, err)
+
+			want := "s3://" + cfg.S3.BucketName + "/" + ownKey
+			assert.Equal(t, want, jobPayload.ReferenceImageURL)
+			require.Len(t, enqueued, 1)
+			assert.Equal(t, want, enqueued[0].ReferenceImageURL)
+		})
+	}
+}
+
+func TestDefaultService_CreateImage_ReferenceImageEntitlements(t *testing.T) {
+	errEntitlements := errors.New("redis and db down")
+	t.Setenv("APP_ENV", "test")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ownerID := uuid.New()
+	ownKey := fmt.Sprintf("uploads/%s/inspiration-1.jpg", ownerID)
+
+	testCases := []struct {
+		name     string
+		allowed  bool
+		ownerErr error
+		entErr   error
+		wantErr  error
+	}{
+		{name: "success: plan from entitlements", allowed: true},
+		{name: "fail: entitlements without reference images", wantErr: ErrReferenceImageNotAllowed},
+		{name: "fail: project not found", ownerErr: pgx.ErrNoRows, wantErr: pgx.ErrNoRows},
+		{name: "fail: entitlements lookup error", entErr: errEntitlements, wantErr: errEntitlements},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			imageRepo := &RepositoryMock{
+				GetProjectOwnerIDFunc: func(ctx context.Context, projectID string) (string, error) {
+					return ownerID.String(), tc.ownerErr
+				},
+				CreateImageFunc: func(
+					ctx context.Context, projectIDStr, originalURL string, roomType, style *string, seed *int64, source Source,
+				) (*queries.Image, error) {
+					return &queries.Image{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, OriginalUrl: originalURL}, nil
+				},
+			}
+			ents := &entitlement.ServiceMock{
+				GetFunc: func(ctx context.Context, userID string) (*entitlement.Entitlements, error) {
+					assert.Equal(t, ownerID.String(), userID)
+					return &entitlement.Entitlements{ReferenceImages: tc.allowed}, tc.entErr
+				},
+			}
+			jobRepo := &job.RepositoryMock{
+				CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+					return &queries.Job{}, nil
+				},
+			}
+			service := NewDefaultService(cfg, imageRepo, jobRepo)
+			service.enqueuer = capturingEnqueuer{payloads: &[]queue.StageRunPayload{}}
+			service.entitlements = ents
+			service.buckets = storage.NewPlatformBuckets(&storage.S3ServiceMock{
+				HeadFileFunc: func(ctx context.Context, fileKey string) (interface{}, error) {
+					return &s3.HeadObjectOutput{ContentType: aws.String("image/jpeg"), ContentLength: aws.Int64(1024)}, nil
+				},
+				BucketNameFunc: func() string { return cfg.S3.BucketName },
+			})
+
+			_, err := service.CreateImage(context.Background(), &CreateImageRequest{
+				ProjectID:         uuid.New(),
+				OriginalURL:       "http://example.com/image.jpg",
+				ReferenceImageKey: ownKey,
+			})
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, imageRepo.CreateImageCalls())
+				return
+			}
+			require.NoError(t, err)
+			assert.Empty(t, imageRepo.GetReferenceImageAccessCalls())
+			assert.Len(t, imageRepo.CreateImageCalls(), 1)
+		})
+	}
+}
+
+func TestDefaultService_CreateImage_Annotations(t *testing.T) {
+	t.Setenv("APP_ENV", "test")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wall, ceiling := 4.2, 2.4
+	annotations := &Annotations{WallLengthM: &wall, CeilingHeightM: &ceiling}
+
+	newRepos := func(annotateErr error) (*RepositoryMock, *job.RepositoryMock, *[]JobPayload) {
+		imageRepo := &RepositoryMock{
+			CreateImageFunc: func(
+				ctx context.Context, projectIDStr, originalURL string, roomType, style *string, seed *int64, source Source,
+			) (*queries.Image, error) {
+				return &queries.Image{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, OriginalUrl: originalURL}, nil
+			},
+			CreateImagesFunc: func(ctx context.Context, reqs []CreateImageRequest) ([]*queries.Image, error) {
+				images := make([]*queries.Image, len(reqs))
+				for i, req := range reqs {
+					images[i] = &queries.Image{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, OriginalUrl: req.OriginalURL}
+				}
+				return images, nil
+			},
+			CreateImageAnnotationsFunc: func(ctx context.Context, imageID string, a Annotations) error {
+				return annotateErr
+			},
+		}
+		var payloads []JobPayload
+		jobRepo := &job.RepositoryMock{
+			CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+				var p JobPayload
+				err := json.Unmarshal(payloadJSON, &p)
+				payloads = append(payloads, p)
+				return &queries.Job{}, err
+			},
+			CreateJobsFunc: func(ctx context.Context, reqs []job.CreateJobRequest) ([]*queries.Job, error) {
+				jobs := make([]*queries.Job, len(reqs))
+				for i, req := range reqs {
+					var p JobPayload
+					if err := json.Unmarshal(req.Payload, &p); err != nil {
+						return nil, err
+					}
+					payloads = append(payloads, p)
+					jobs[i] = &queries.Job{}
+				}
+				return jobs, nil
+			},
+		}
+		return imageRepo, jobRepo, &payloads
+	}
+	wantPayload := &queue.Annotations{WallLengthM: &wall, CeilingHeightM: &ceiling}
+
+	t.Run("success: annotations stored and copied into the job and task payloads", func(t *testing.T) {
+		imageRepo, jobRepo, payloads := newRepos(nil)
+		var enqueued []queue.StageRunPayload
+		service := NewDefaultService(cfg, imageRepo, jobRepo)
+		service.enqueuer = capturingEnqueuer{payloads: &enqueued}
+
+		img, err := service.CreateImage(context.Background(), &CreateImageRequest{
+			ProjectID:   uuid.New(),
+			OriginalURL: "http://example.com/image.jpg",
+			Annotations: annotations,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, annotations, img.Annotations)
+		calls := imageRepo.CreateImageAnnotationsCalls()
+		require.Len(t, calls, 1)
+		assert.Equal(t, img.ID.String(), calls[0].ImageID)
+		assert.Equal(t, *annotations, calls[0].Annotations)
+		require.Len(t, *payloads, 1)
+		assert.Equal(t, wantPayload, (*payloads)[0].Annotations)
+		require.Len(t, enqueued, 1)
+		assert.Equal(t, wantPayload, enqueued[0].Annotations)
+	})
+
+	t.Run("success: batch stores annotations only for the images that set them", func(t *testing.T) {
+		imageRepo, jobRepo, payloads := newRepos(nil)
+		var enqueued []queue.StageRunPayload
+		service := NewDefaultService(cfg, imageRepo, jobRepo)
+		service.enqueuer = capturingEnqueuer{payloads: &enqueued}
+
+		resp, err := service.BatchCreateImages(context.Background(), []CreateImageRequest{
+			{ProjectID: uuid.New(), OriginalURL: "http://example.com/1.jpg"},
+			{ProjectID: uuid.New(), OriginalURL: "http://example.com/2.jpg", Annotations: annotations},
+		})
+		require.NoError(t, err)
+
+		calls := imageRepo.CreateImageAnnotationsCalls()
+		require.Len(t, calls, 1)
+		assert.Equal(t, resp.Images[1].ID.String(), calls[0].ImageID)
+		require.Len(t, *payloads, 2)
+		assert.Nil(t, (*payloads)[0].Annotations)
+		assert.Equal(t, wantPayload, (*payloads)[1].Annotations)
+	})
+
+	t.Run("fail: annotations insert error", func(t *testing.T) {
+		imageRepo, jobRepo, _ := newRepos(errors.New("db down"))
+		service := NewDefaultService(cfg, imageRepo, jobRepo)
+		service.enqueuer = capturingEnqueuer{payloads: &[]queue.StageRunPayload{}}
+
+		_, err := service.CreateImage(context.Background(), &CreateImageRequest{
+			ProjectID:   uuid.New(),
+			OriginalURL: "http://example.com/image.jpg",
+			Annotations: annotations,
+		})
+		assert.ErrorContains(t, err, "db down")
+		assert.Empty(t, jobRepo.CreateJobCalls())
+	})
+}
+
+func TestDefaultService_CreateImage_BracketURLs(t *testing.T) {
+	t.Setenv("APP_ENV", "test")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	brackets := []string{"http://example.com/image-2ev.jpg", "http://example.com/image+2ev.jpg"}
+
+	newService := func(bracketErr, authorizeErr error) (*DefaultService, *RepositoryMock, *[]JobPayload, *[]queue.StageRunPayload) {
+		imageRepo := &RepositoryMock{
+			CreateImageFunc: func(
+				ctx context.Context, projectIDStr, originalURL string, roomType, style *string, seed *int64, source Source,
+			) (*queries.Image, error) {
+				return &queries.Image{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, OriginalUrl: originalURL}, nil
+			},
+			CreateImageBracketsFunc: func(ctx context.Context, imageID string, urls []string) error {
+				return bracketErr
+			},
+		}
+		var payloads []JobPayload
+		jobRepo := &job.RepositoryMock{
+			CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+				var p JobPayload
+				err := json.Unmarshal(payloadJSON, &p)
+				payloads = append(payloads, p)
+				return &queries.Job{}, err
+			},
+		}
+		var enqueued []queue.StageRunPayload
+		service := NewDefaultService(cfg, imageRepo, jobRepo)
+		service.enqueuer = capturingEnqueuer{payloads: &enqueued}
+		service.buckets = &storage.BucketsMock{
+			AuthorizeFunc: func(ctx context.Context, id, rawURL string) error {
+				if rawURL == brackets[1] {
+					return authorizeErr
+				}
+				return nil
+			},
+		}
+		return service, imageRepo, &payloads, &enqueued
+	}
+
+	t.Run("success: brackets stored and copied into the job and task payloads", func(t *testing.T) {
+		service, imageRepo, payloads, enqueued := newService(nil, nil)
+
+		img, err := service.CreateImage(context.Background(), &CreateImageRequest{
+			ProjectID:   uuid.New(),
+			OriginalURL: "http://example.com/image.jpg",
+			BracketURLs: brackets,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, brackets, img.BracketURLs)
+		calls := imageRepo.CreateImageBracketsCalls()
+		require.Len(t, calls, 1)
+		assert.Equal(t, img.ID.String(), calls[0].ImageID)
+		assert.Equal(t, brackets, calls[0].Urls)
+		require.Len(t, *payloads, 1)
+		assert.Equal(t, brackets, (*payloads)[0].BracketURLs)
+		require.Len(t, *enqueued, 1)
+		assert.Equal(t, brackets, (*enqueued)[0].BracketURLs)
+	})
+
+	t.Run("success: no brackets stores nothing", func(t *testing.T) {
+		service, imageRepo, payloads, _ := newService(nil, nil)
+
+		img, err := service.CreateImage(context.Background(), &CreateImageRequest{
+			ProjectID:   uuid.New(),
+			OriginalURL: "http://example.com/image.jpg",
+		})
+		require.NoError(t, err)
+
+		assert.Nil(t, img.BracketURLs)
+		assert.Empty(t, imageRepo.CreateImageBracketsCalls())
+		require.Len(t, *payloads, 1)
+		assert.Nil(t, (*payloads)[0].BracketURLs)
+	})
+
+	t.Run("fail: bracket in another account's bucket", func(t *testing.T) {
+		service, imageRepo, _, _ := newService(nil, fmt.Errorf("%w: acme-photos", storage.ErrForeignBucket))
+
+		_, err := service.CreateImage(context.Background(), &CreateImageRequest{
+			ProjectID:   uuid.New(),
+			OriginalURL: "http://example.com/image.jpg",
+			BracketURLs: brackets,
+		})
+		assert.ErrorIs(t, err, ErrBracketURLInvalid)
+		assert.Empty(t, imageRepo.CreateImageCalls())
+	})
+
+	t.Run("fail: brackets insert error", func(t *testing.T) {
+		service, _, payloads, _ := newService(errors.New("db down"), nil)
+
+		_, err := service.CreateImage(context.Background(), &CreateImageRequest{
+			ProjectID:   uuid.New(),
+			OriginalURL: "http://example.com/image.jpg",
+			BracketURLs: brackets,
+		})
+		assert.ErrorContains(t, err, "db down")
+		assert.Empty(t, *payloads)
+	})
+}
+
+func TestDefaultService_CreateImage_CorrectPerspective(t *testing.T) {
+	t.Setenv("APP_ENV", "test")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name    string
+		correct bool
+	}{
+		{name: "success: correction requested", correct: true},
+		{name: "success: correction not requested", correct: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			imageRepo := &RepositoryMock{
+				CreateImageFunc: func(
+					ctx context.Context, projectIDStr, originalURL string, roomType, style *string, seed *int64, source Source,
+				) (*queries.Image, error) {
+					return &queries.Image{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, OriginalUrl: originalURL}, nil
+				},
+			}
+			var payloads []JobPayload
+			jobRepo := &job.RepositoryMock{
+				CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+					var p JobPayload
+					err := json.Unmarshal(payloadJSON, &p)
+					payloads = append(payloads, p)
+					return &queries.Job{}, err
+				},
+			}
+			var enqueued []queue.StageRunPayload
+			service := NewDefaultService(cfg, imageRepo, jobRepo)
+			service.enqueuer = capturingEnqueuer{payloads: &enqueued}
+
+			_, err := service.CreateImage(context.Background(), &CreateImageRequest{
+				ProjectID:          uuid.New(),
+				OriginalURL:        "http://example.com/image.jpg",
+				CorrectPerspective: tc.correct,
+			})
+			require.NoError(t, err)
+
+			require.Len(t, payloads, 1)
+			assert.Equal(t, tc.correct, payloads[0].CorrectPerspective)
+			require.Len(t, enqueued, 1)
+			assert.Equal(t, tc.correct, enqueued[0].CorrectPerspective)
+		})
+	}
+}
+
+// optsEnqueuer records the options each task is enqueued with.
+type optsEnqueuer struct {
+	opts *[]queue.EnqueueOpts
+}
+
+func (e optsEnqueuer) EnqueueStageRun(
+	ctx context.Context, payload queue.StageRunPayload, opts *queue.EnqueueOpts,
+) (string, error) {
+	*e.opts = append(*e.opts, *opts)
+	return "task", nil
+}
+
+func TestDefaultService_BatchCreateImages_BatchGroup(t *testing.T) {
+	t.Setenv("APP_ENV", "test")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	projectA, projectB := uuid.New(), uuid.New()
+
+	imageRepo := &RepositoryMock{
+		CreateImageFunc: func(
+			ctx context.Context, projectIDStr, originalURL string, roomType, style *string, seed *int64, source Source,
+		) (*queries.Image, error) {
+			return &queries.Image{
+				ID:        pgtype.UUID{Bytes: uuid.New(), Valid: true},
+				ProjectID: pgtype.UUID{Bytes: uuid.MustParse(projectIDStr), Valid: true},
+			}, nil
+		},
+		CreateImagesFunc: func(ctx context.Context, reqs []CreateImageRequest) ([]*queries.Image, error) {
+			images := make([]*queries.Image, len(reqs))
+			for i, req := range reqs {
+				images[i] = &queries.Image{
+					ID:        pgtype.UUID{Bytes: uuid.New(), Valid: true},
+					ProjectID: pgtype.UUID{Bytes: req.ProjectID, Valid: true},
+				}
+			}
+			return images, nil
+		},
+	}
+	jobRepo := &job.RepositoryMock{
+		CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+			return &queries.Job{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}}, nil
+		},
+		CreateJobsFunc: func(ctx context.Context, reqs []job.CreateJobRequest) ([]*queries.Job, error) {
+			jobs := make([]*queries.Job, len(reqs))
+			for i := range reqs {
+				jobs[i] = &queries.Job{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}}
+			}
+			return jobs, nil
+		},
+	}
+	batch := []CreateImageRequest{
+		{ProjectID: projectA, OriginalURL: "http://example.com/1.jpg"},
+		{ProjectID: projectB, OriginalURL: "http://example.com/2.jpg"},
+		{ProjectID: projectA, OriginalURL: "http://example.com/3.jpg"},
+	}
+
+	testCases := []struct {
+		name       string
+		window     time.Duration
+		reqs       []CreateImageRequest
+		wantGroups []string
+	}{
+		{
+			name:   "success: batch tasks grouped by project",
+			window: 5 * time.Second,
+			reqs:   batch,
+			wantGroups: []string{
+				"project:" + projectA.String(), "project:" + projectB.String(), "project:" + projectA.String(),
+			},
+		},
+		{
+			name:       "success: no window leaves tasks ungrouped",
+			reqs:       batch,
+			wantGroups: []string{"", "", ""},
+		},
+		{
+			name:       "success: a single image is never grouped",
+			window:     5 * time.Second,
+			reqs:       batch[:1],
+			wantGroups: []string{""},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			batchCfg := *cfg
+			batchCfg.Job.BatchWindow = tc.window
+			var enqueued []queue.EnqueueOpts
+			service := NewDefaultService(&batchCfg, imageRepo, jobRepo)
+			service.enqueuer = optsEnqueuer{opts: &enqueued}
+
+			if len(tc.reqs) == 1 {
+				_, err = service.CreateImage(context.Background(), &tc.reqs[0])
+			} else {
+				_, err = service.BatchCreateImages(context.Background(), tc.reqs)
+			}
+			require.NoError(t, err)
+
+			groups := make([]string, len(enqueued))
+			for i, o := range enqueued {
+				groups[i] = o.Group
+				assert.NotEmpty(t, o.TaskID)
+			}
+			assert.Equal(t, tc.wantGroups, groups)
+		})
+	}
 }
@@ -0,0 +1,99 @@
+package image
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/golden"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func TestDefaultHandler_GetImage_Golden(t *testing.T) {
+	imageID := uuid.MustParse("33333333-3333-4333-8333-333333333333")
+	projectID := uuid.MustParse("22222222-2222-4222-8222-222222222221")
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	cases := []struct {
+		name    string
+		golden  string
+		imageID string
+		image   *Image
+		err     error
+	}{
+		{
+			name:    "success: ready image with every field",
+			golden:  "image_detail",
+			imageID: imageID.String(),
+			image: &Image{
+				ID:                    imageID,
+				ProjectID:             projectID,
+				OriginalURL:           "https://bucket.example.com/uploads/original.jpg",
+				StagedURL:             ptr("https://bucket.example.com/staged/staged.jpg"),
+				RoomType:              ptr("living_room"),
+				Style:                 ptr("modern"),
+				Seed:                  ptr(int64(42)),
+				Status:                StatusReady,
+				CostUSD:               ptr(0.05),
+				ModelUsed:             ptr("qwen-image-edit"),
+				ProcessingTimeMs:      ptr(12500),
+				ReplicatePredictionID: ptr("pred_123"),
+				Annotations:           &Annotations{WallLengthM: ptr(4.2), CeilingHeightM: ptr(2.7)},
+				CreatedAt:             createdAt,
+				UpdatedAt:             createdAt.Add(time.Minute),
+			},
+		},
+		{
+			name:    "success: queued image omits unset fields",
+			golden:  "image_detail_queued",
+			imageID: imageID.String(),
+			image: &Image{
+				ID:          imageID,
+				ProjectID:   projectID,
+				OriginalURL: "https://bucket.example.com/uploads/original.jpg",
+				Status:      StatusQueued,
+				CreatedAt:   createdAt,
+				UpdatedAt:   createdAt,
+			},
+		},
+		{
+			name:    "fail: not found error envelope",
+			golden:  "error_not_found",
+			imageID: imageID.String(),
+			err:     errors.New("no rows in result set"),
+		},
+		{
+			name:    "fail: bad request error envelope",
+			golden:  "error_bad_request",
+			imageID: "invalid-uuid",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			serviceMock := &ServiceMock{
+				GetImageByIDFunc: func(ctx context.Context, imageID string) (*Image, error) {
+					return tc.image, tc.err
+				},
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/images/"+tc.imageID, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.imageID)
+
+			require.NoError(t, NewDefaultHandler(serviceMock, nil, nil).GetImage(c))
+			golden.AssertJSON(t, tc.golden, rec.Code, rec.Body.Bytes())
+		})
+	}
+}
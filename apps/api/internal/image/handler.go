@@ -2,6 +2,8 @@ package image
 
 import (
 	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/usage"
 )
 
 // ErrorResponse represents an error response.
@@ -23,6 +25,9 @@ type ValidationErrorResponse struct {
 	ValidationErrors []ValidationErrorDetail `json:"validation_errors"`
 }
 
+// QuotaExceededResponse represents a refusal to create images past the monthly limit.
+type QuotaExceededResponse = usage.QuotaExceededResponse
+
 //go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
 
 type Handler interface {
@@ -30,5 +35,10 @@ type Handler interface {
 	GetImage(c echo.Context) error
 	GetProjectImages(c echo.Context) error
 	DeleteImage(c echo.Context) error
+	CancelImage(c echo.Context) error
+	GetImageStatusHistory(c echo.Context) error
+	ExpediteImage(c echo.Context) error
+	BulkCancelImages(c echo.Context) error
+	BulkDeleteImages(c echo.Context) error
 	GetProjectCost(c echo.Context) error
 }
@@ -18,15 +18,30 @@ var _ Handler = &HandlerMock{}
 //
 //		// make and configure a mocked Handler
 //		mockedHandler := &HandlerMock{
+//			BulkCancelImagesFunc: func(c echo.Context) error {
+//				panic("mock out the BulkCancelImages method")
+//			},
+//			BulkDeleteImagesFunc: func(c echo.Context) error {
+//				panic("mock out the BulkDeleteImages method")
+//			},
+//			CancelImageFunc: func(c echo.Context) error {
+//				panic("mock out the CancelImage method")
+//			},
 //			CreateImageFunc: func(c echo.Context) error {
 //				panic("mock out the CreateImage method")
 //			},
 //			DeleteImageFunc: func(c echo.Context) error {
 //				panic("mock out the DeleteImage method")
 //			},
+//			ExpediteImageFunc: func(c echo.Context) error {
+//				panic("mock out the ExpediteImage method")
+//			},
 //			GetImageFunc: func(c echo.Context) error {
 //				panic("mock out the GetImage method")
 //			},
+//			GetImageStatusHistoryFunc: func(c echo.Context) error {
+//				panic("mock out the GetImageStatusHistory method")
+//			},
 //			GetProjectCostFunc: func(c echo.Context) error {
 //				panic("mock out the GetProjectCost method")
 //			},
@@ -40,15 +55,30 @@ var _ Handler = &HandlerMock{}
 //
 //	}
 type HandlerMock struct {
+	// BulkCancelImagesFunc mocks the BulkCancelImages method.
+	BulkCancelImagesFunc func(c echo.Context) error
+
+	// BulkDeleteImagesFunc mocks the BulkDeleteImages method.
+	BulkDeleteImagesFunc func(c echo.Context) error
+
+	// CancelImageFunc mocks the CancelImage method.
+	CancelImageFunc func(c echo.Context) error
+
 	// CreateImageFunc mocks the CreateImage method.
 	CreateImageFunc func(c echo.Context) error
 
 	// DeleteImageFunc mocks the DeleteImage method.
 	DeleteImageFunc func(c echo.Context) error
 
+	// ExpediteImageFunc mocks the ExpediteImage method.
+	ExpediteImageFunc func(c echo.Context) error
+
 	// GetImageFunc mocks the GetImage method.
 	GetImageFunc func(c echo.Context) error
 
+	// GetImageStatusHistoryFunc mocks the GetImageStatusHistory method.
+	GetImageStatusHistoryFunc func(c echo.Context) error
+
 	// GetProjectCostFunc mocks the GetProjectCost method.
 	GetProjectCostFunc func(c echo.Context) error
 
@@ -57,6 +87,21 @@ type HandlerMock struct {
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// BulkCancelImages holds details about calls to the BulkCancelImages method.
+		BulkCancelImages []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
+		// BulkDeleteImages holds details about calls to the BulkDeleteImages method.
+		BulkDeleteImages []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
+		// CancelImage holds details about calls to the CancelImage method.
+		CancelImage []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
 		// CreateImage holds details about calls to the CreateImage method.
 		CreateImage []struct {
 			// C is the c argument value.
@@ -67,11 +112,21 @@ type HandlerMock struct {
 			// C is the c argument value.
 			C echo.Context
 		}
+		// ExpediteImage holds details about calls to the ExpediteImage method.
+		ExpediteImage []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
 		// GetImage holds details about calls to the GetImage method.
 		GetImage []struct {
 			// C is the c argument value.
 			C echo.Context
 		}
+		// GetImageStatusHistory holds details about calls to the GetImageStatusHistory method.
+		GetImageStatusHistory []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
 		// GetProjectCost holds details about calls to the GetProjectCost method.
 		GetProjectCost []struct {
 			// C is the c argument value.
@@ -83,11 +138,112 @@ type HandlerMock struct {
 			C echo.Context
 		}
 	}
-	lockCreateImage      sync.RWMutex
-	lockDeleteImage      sync.RWMutex
-	lockGetImage         sync.RWMutex
-	lockGetProjectCost   sync.RWMutex
-	lockGetProjectImages sync.RWMutex
+	lockBulkCancelImages      sync.RWMutex
+	lockBulkDeleteImages      sync.RWMutex
+	lockCancelImage           sync.RWMutex
+	lockCreateImage           sync.RWMutex
+	lockDeleteImage           sync.RWMutex
+	lockExpediteImage         sync.RWMutex
+	lockGetImage              sync.RWMutex
+	lockGetImageStatusHistory sync.RWMutex
+	lockGetProjectCost        sync.RWMutex
+	lockGetProjectImages      sync.RWMutex
+}
+
+// BulkCancelImages calls BulkCancelImagesFunc.
+func (mock *HandlerMock) BulkCancelImages(c echo.Context) error {
+	if mock.BulkCancelImagesFunc == nil {
+		panic("HandlerMock.BulkCancelImagesFunc: method is nil but Handler.BulkCancelImages was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockBulkCancelImages.Lock()
+	mock.calls.BulkCancelImages = append(mock.calls.BulkCancelImages, callInfo)
+	mock.lockBulkCancelImages.Unlock()
+	return mock.BulkCancelImagesFunc(c)
+}
+
+// BulkCancelImagesCalls gets all the calls that were made to BulkCancelImages.
+// Check the length with:
+//
+//	len(mockedHandler.BulkCancelImagesCalls())
+func (mock *HandlerMock) BulkCancelImagesCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockBulkCancelImages.RLock()
+	calls = mock.calls.BulkCancelImages
+	mock.lockBulkCancelImages.RUnlock()
+	return calls
+}
+
+// BulkDeleteImages calls BulkDeleteImagesFunc.
+func (mock *HandlerMock) BulkDeleteImages(c echo.Context) error {
+	if mock.BulkDeleteImagesFunc == nil {
+		panic("HandlerMock.BulkDeleteImagesFunc: method is nil but Handler.BulkDeleteImages was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockBulkDeleteImages.Lock()
+	mock.calls.BulkDeleteImages = append(mock.calls.BulkDeleteImages, callInfo)
+	mock.lockBulkDeleteImages.Unlock()
+	return mock.BulkDeleteImagesFunc(c)
+}
+
+// BulkDeleteImagesCalls gets all the calls that were made to BulkDeleteImages.
+// Check the length with:
+//
+//	len(mockedHandler.BulkDeleteImagesCalls())
+func (mock *HandlerMock) BulkDeleteImagesCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockBulkDeleteImages.RLock()
+	calls = mock.calls.BulkDeleteImages
+	mock.lockBulkDeleteImages.RUnlock()
+	return calls
+}
+
+// CancelImage calls CancelImageFunc.
+func (mock *HandlerMock) CancelImage(c echo.Context) error {
+	if mock.CancelImageFunc == nil {
+		panic("HandlerMock.CancelImageFunc: method is nil but Handler.CancelImage was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockCancelImage.Lock()
+	mock.calls.CancelImage = append(mock.calls.CancelImage, callInfo)
+	mock.lockCancelImage.Unlock()
+	return mock.CancelImageFunc(c)
+}
+
+// CancelImageCalls gets all the calls that were made to CancelImage.
+// Check the length with:
+//
+//	len(mockedHandler.CancelImageCalls())
+func (mock *HandlerMock) CancelImageCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockCancelImage.RLock()
+	calls = mock.calls.CancelImage
+	mock.lockCancelImage.RUnlock()
+	return calls
 }
 
 // CreateImage calls CreateImageFunc.
@@ -154,6 +310,38 @@ func (mock *HandlerMock) DeleteImageCalls() []struct {
 	return calls
 }
 
+// ExpediteImage calls ExpediteImageFunc.
+func (mock *HandlerMock) ExpediteImage(c echo.Context) error {
+	if mock.ExpediteImageFunc == nil {
+		panic("HandlerMock.ExpediteImageFunc: method is nil but Handler.ExpediteImage was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockExpediteImage.Lock()
+	mock.calls.ExpediteImage = append(mock.calls.ExpediteImage, callInfo)
+	mock.lockExpediteImage.Unlock()
+	return mock.ExpediteImageFunc(c)
+}
+
+// ExpediteImageCalls gets all the calls that were made to ExpediteImage.
+// Check the length with:
+//
+//	len(mockedHandler.ExpediteImageCalls())
+func (mock *HandlerMock) ExpediteImageCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockExpediteImage.RLock()
+	calls = mock.calls.ExpediteImage
+	mock.lockExpediteImage.RUnlock()
+	return calls
+}
+
 // GetImage calls GetImageFunc.
 func (mock *HandlerMock) GetImage(c echo.Context) error {
 	if mock.GetImageFunc == nil {
@@ -186,6 +374,38 @@ func (mock *HandlerMock) GetImageCalls() []struct {
 	return calls
 }
 
+// GetImageStatusHistory calls GetImageStatusHistoryFunc.
+func (mock *HandlerMock) GetImageStatusHistory(c echo.Context) error {
+	if mock.GetImageStatusHistoryFunc == nil {
+		panic("HandlerMock.GetImageStatusHistoryFunc: method is nil but Handler.GetImageStatusHistory was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockGetImageStatusHistory.Lock()
+	mock.calls.GetImageStatusHistory = append(mock.calls.GetImageStatusHistory, callInfo)
+	mock.lockGetImageStatusHistory.Unlock()
+	return mock.GetImageStatusHistoryFunc(c)
+}
+
+// GetImageStatusHistoryCalls gets all the calls that were made to GetImageStatusHistory.
+// Check the length with:
+//
+//	len(mockedHandler.GetImageStatusHistoryCalls())
+func (mock *HandlerMock) GetImageStatusHistoryCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockGetImageStatusHistory.RLock()
+	calls = mock.calls.GetImageStatusHistory
+	mock.lockGetImageStatusHistory.RUnlock()
+	return calls
+}
+
 // GetProjectCost calls GetProjectCostFunc.
 func (mock *HandlerMock) GetProjectCost(c echo.Context) error {
 	if mock.GetProjectCostFunc == nil {
@@ -2,15 +2,22 @@
 package image
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/real-staging-ai/api/internal/queue"
 )
 
 // Status represents the processing status of an image.
 type Status string
 
 const (
+	// StatusAwaitingUpload indicates the image is registered but its original is still uploading.
+	StatusAwaitingUpload Status = "awaiting_upload"
 	// StatusQueued indicates the image is waiting to be processed.
 	StatusQueued Status = "queued"
 	// StatusProcessing indicates the image is currently being processed.
@@ -19,30 +26,197 @@ const (
 	StatusReady Status = "ready"
 	// StatusError indicates an error occurred during processing.
 	StatusError Status = "error"
+	// StatusCanceled indicates processing was canceled by the user.
+	StatusCanceled Status = "canceled"
+	// StatusExpired indicates the image's files were removed under retention.
+	StatusExpired Status = "expired"
 )
 
+// transitions lists the statuses each status may move to. The worker may finish a queued
+// image without reporting processing first. Canceled and expired are final.
+var transitions = map[Status][]Status{
+	StatusAwaitingUpload: {StatusQueued, StatusCanceled, StatusExpired},
+	StatusQueued:         {StatusProcessing, StatusReady, StatusError, StatusCanceled},
+	StatusProcessing:     {StatusReady, StatusError, StatusCanceled},
+	StatusReady:          {StatusExpired},
+	StatusError:          {StatusExpired},
+}
+
+// ErrNoStatusHistory is returned when no status events were ever recorded for an image.
+var ErrNoStatusHistory = errors.New("image has no status history")
+
+// ErrInvalidTransition is returned when an image cannot move from its current status to the requested one.
+var ErrInvalidTransition = errors.New("invalid image status transition")
+
+// ErrImageNotCancelable is returned when an image has already finished processing.
+var ErrImageNotCancelable = errors.New("image cannot be canceled in its current state")
+
+// ErrImageReadOnly is returned when a project viewer tries to change one of its images.
+var ErrImageReadOnly = errors.New("viewers cannot change images")
+
+// ErrImageNotExpeditable is returned when an image is no longer waiting in the queue.
+var ErrImageNotExpeditable = errors.New("image cannot be expedited in its current state")
+
+// ErrExpediteNotAllowed is returned when the project owner's plan does not include expediting.
+var ErrExpediteNotAllowed = errors.New("plan does not include expediting")
+
+// ErrExpediteLimitReached is returned when the project owner has used up their daily expedites.
+var ErrExpediteLimitReached = errors.New("daily expedite limit reached")
+
+// ErrCatalogUnavailable is returned when the requested catalog does not exist or is inactive.
+var ErrCatalogUnavailable = errors.New("catalog not found or inactive")
+
+// ErrPresetUnavailable is returned when the requested preset does not exist or belongs to
+// someone other than the project owner.
+var ErrPresetUnavailable = errors.New("preset not found")
+
+// ErrReferenceImageNotAllowed is returned when the project owner's plan does not include
+// reference images.
+var ErrReferenceImageNotAllowed = errors.New("plan does not include reference images")
+
+// ErrReferenceImageInvalid is returned when the reference image upload is missing, belongs
+// to another user, or is not an allowed image type and size.
+var ErrReferenceImageInvalid = errors.New("invalid reference image")
+
+// ErrOriginalURLInvalid is returned when the original URL points into a customer-managed
+// bucket that the project owner does not own.
+var ErrOriginalURLInvalid = errors.New("invalid original URL")
+
+// ErrBracketURLInvalid is returned when a bracket URL points into a customer-managed bucket
+// that the project owner does not own.
+var ErrBracketURLInvalid = errors.New("invalid bracket URL")
+
+// ErrLegalHold is returned when an image, or its project, is under legal hold and cannot be deleted.
+var ErrLegalHold = errors.New("image is under legal hold")
+
 // String returns the string representation of the status.
 func (s Status) String() string {
 	return string(s)
 }
 
+// Valid reports whether s is a known status.
+func (s Status) Valid() bool {
+	switch s {
+	case StatusAwaitingUpload, StatusQueued, StatusProcessing, StatusReady, StatusError, StatusCanceled, StatusExpired:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo reports whether an image in status s may move to next.
+func (s Status) CanTransitionTo(next Status) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTransition returns an error wrapping ErrInvalidTransition when an image in
+// status from may not move to status to.
+func ValidateTransition(from, to Status) error {
+	if !from.CanTransitionTo(to) {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, from, to)
+	}
+	return nil
+}
+
+// Source is the surface an image was created from.
+type Source string
+
+const (
+	// SourceWeb marks images created from the web app.
+	SourceWeb Source = "web"
+	// SourceMobile marks images created from the mobile app.
+	SourceMobile Source = "mobile"
+	// SourceAPI marks images created by direct API clients.
+	SourceAPI Source = "api"
+	// SourceImport marks images created by bulk import tools.
+	SourceImport Source = "import"
+	// SourceUnknown marks images created before sources were tracked. Clients cannot send it.
+	SourceUnknown Source = "unknown"
+)
+
+// HeaderSource is the request header a client names its surface in. Requests without it
+// count as SourceAPI, since first-party surfaces always send it.
+const HeaderSource = "X-Client-Source"
+
+// ErrSourceInvalid is returned when the X-Client-Source header names an unknown surface.
+var ErrSourceInvalid = errors.New("invalid client source")
+
+// ParseSource returns the source named by an X-Client-Source header value.
+func ParseSource(header string) (Source, error) {
+	switch s := Source(strings.ToLower(strings.TrimSpace(header))); s {
+	case "":
+		return SourceAPI, nil
+	case SourceWeb, SourceMobile, SourceAPI, SourceImport:
+		return s, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrSourceInvalid, header)
+}
+
+// column returns the value stored for s. Requests without a source count as SourceAPI.
+func (s Source) column() string {
+	if s == "" {
+		return string(SourceAPI)
+	}
+	return string(s)
+}
+
 // Image represents a staging image in the system.
 type Image struct {
-	ID                    uuid.UUID `json:"id"`
-	ProjectID             uuid.UUID `json:"project_id"`
-	OriginalURL           string    `json:"original_url"`
-	StagedURL             *string   `json:"staged_url,omitempty"`
-	RoomType              *string   `json:"room_type,omitempty"`
-	Style                 *string   `json:"style,omitempty"`
-	Seed                  *int64    `json:"seed,omitempty"`
-	Status                Status    `json:"status"`
-	Error                 *string   `json:"error,omitempty"`
-	CostUSD               *float64  `json:"cost_usd,omitempty"`
-	ModelUsed             *string   `json:"model_used,omitempty"`
-	ProcessingTimeMs      *int      `json:"processing_time_ms,omitempty"`
-	ReplicatePredictionID *string   `json:"replicate_prediction_id,omitempty"`
-	CreatedAt             time.Time `json:"created_at"`
-	UpdatedAt             time.Time `json:"updated_at"`
+	ID          uuid.UUID `json:"id"`
+	ProjectID   uuid.UUID `json:"project_id"`
+	OriginalURL string    `json:"original_url"`
+	StagedURL   *string   `json:"staged_url,omitempty"`
+	// PreviewURL is a low-resolution preview of the staged result, set while the image is
+	// processing. Fetch it through the presign endpoint with kind=preview.
+	PreviewURL *string `json:"preview_url,omitempty"`
+	// WatermarkedURL is the staged output with the account's watermark, set when the image
+	// was staged while the account had one. Share links and staged downloads serve it.
+	WatermarkedURL        *string  `json:"watermarked_url,omitempty"`
+	RoomType              *string  `json:"room_type,omitempty"`
+	Style                 *string  `json:"style,omitempty"`
+	Seed                  *int64   `json:"seed,omitempty"`
+	Status                Status   `json:"status"`
+	Error                 *string  `json:"error,omitempty"`
+	CostUSD               *float64 `json:"cost_usd,omitempty"`
+	ModelUsed             *string  `json:"model_used,omitempty"`
+	ProcessingTimeMs      *int     `json:"processing_time_ms,omitempty"`
+	ReplicatePredictionID *string  `json:"replicate_prediction_id,omitempty"`
+	// Source is the surface the image was created from.
+	Source Source `json:"source,omitempty"`
+	// Annotations are the room measurements given when the image was created.
+	Annotations *Annotations `json:"annotations,omitempty"`
+	// BracketURLs are the other exposures of the shot given when the image was created.
+	BracketURLs []string `json:"bracket_urls,omitempty"`
+	// RawURL is the RAW camera file (CR3, NEF or ARW) the image was uploaded as, kept as
+	// the archival original once the worker converted it. OriginalURL then points at the
+	// converted JPEG.
+	RawURL    *string   `json:"raw_url,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// MaxBracketURLs is how many other exposures of a shot an image can be created with.
+const MaxBracketURLs = 6
+
+// Limits on room annotations, in meters.
+const (
+	MinWallLengthM    = 0.5
+	MaxWallLengthM    = 50.0
+	MinCeilingHeightM = 1.5
+	MaxCeilingHeightM = 10.0
+)
+
+// Annotations are known room measurements, in meters, that the worker sizes staged
+// furniture to. At least one must be set.
+type Annotations struct {
+	// WallLengthM is the length of the main wall facing the camera.
+	WallLengthM *float64 `json:"wall_length_m,omitempty"`
+	// CeilingHeightM is the floor-to-ceiling height.
+	CeilingHeightM *float64 `json:"ceiling_height_m,omitempty"`
 }
 
 // CreateImageRequest represents the request to create a new staging image.
@@ -54,15 +228,52 @@ type CreateImageRequest struct {
 	//nolint:lll // struct tags are long
 	Style *string `json:"style,omitempty" validate:"omitempty,oneof=modern contemporary traditional industrial scandinavian"`
 	Seed  *int64  `json:"seed,omitempty" validate:"omitempty,min=1,max=4294967295"`
+	// Sandbox routes the job to the worker's sandbox provider (a cheap model or a fake that
+	// returns the original image) instead of the production model.
+	Sandbox bool `json:"sandbox,omitempty"`
+	// CatalogID picks an active furniture catalog to stage with.
+	CatalogID *uuid.UUID `json:"catalog_id,omitempty"`
+	// ReferenceImageKey is the file_key of an inspiration photo uploaded through
+	// /uploads/presign. Models that accept reference images stage toward its look.
+	ReferenceImageKey string `json:"reference_image_key,omitempty"`
+	// Annotations are optional room measurements used to size the staged furniture.
+	Annotations *Annotations `json:"annotations,omitempty"`
+	// BracketURLs are uploads of other exposures of the original's shot, up to
+	// MaxBracketURLs. The worker merges them with the original into an HDR base image and
+	// stages that instead, which helps dim interiors shot on phones.
+	BracketURLs []string `json:"bracket_urls,omitempty"`
+	// CorrectPerspective straightens converging walls and door frames before staging, for
+	// photos taken with the camera tilted up or down or not held level.
+	CorrectPerspective bool `json:"correct_perspective,omitempty"`
+	// PresetID picks one of the project owner's saved presets. Its room type, style, seed
+	// and catalog apply where the request leaves them unset; its prompt is always added.
+	PresetID *uuid.UUID `json:"preset_id,omitempty"`
+	// Source is set from the X-Client-Source header, not the body.
+	Source Source `json:"-"`
 }
 
 // JobPayload represents the payload for image processing jobs.
 type JobPayload struct {
-	ImageID     uuid.UUID `json:"image_id"`
+	ImageID uuid.UUID `json:"image_id"`
+	// ProjectID is the image's project, whose event stream also carries its status changes.
+	ProjectID   uuid.UUID `json:"project_id"`
 	OriginalURL string    `json:"original_url"`
 	RoomType    *string   `json:"room_type,omitempty"`
 	Style       *string   `json:"style,omitempty"`
 	Seed        *int64    `json:"seed,omitempty"`
+	Sandbox     bool      `json:"sandbox,omitempty"`
+	// Catalog is a copy of the picked furniture catalog, if any.
+	Catalog *queue.CatalogPayload `json:"catalog,omitempty"`
+	// ReferenceImageURL is the s3:// URL of the user's inspiration photo, if any.
+	ReferenceImageURL string `json:"reference_image_url,omitempty"`
+	// BracketURLs are the other exposures of the original's shot, if any.
+	BracketURLs []string `json:"bracket_urls,omitempty"`
+	// CorrectPerspective straightens converging and leaning verticals before staging.
+	CorrectPerspective bool `json:"correct_perspective,omitempty"`
+	// Annotations are the room measurements given with the image, if any.
+	Annotations *queue.Annotations `json:"annotations,omitempty"`
+	// Instructions are extra prompt instructions from the picked preset, if any.
+	Instructions string `json:"instructions,omitempty"`
 }
 
 // ProjectCostSummary represents cost aggregation for a project.
@@ -99,3 +310,58 @@ type BatchImageError struct {
 	Index   int    `json:"index"`
 	Message string `json:"message"`
 }
+
+// MaxBulkImageIDs caps the number of images accepted by a single bulk operation.
+const MaxBulkImageIDs = 100
+
+// Per-item outcomes reported by bulk image operations.
+const (
+	BulkResultCanceled      = "canceled"
+	BulkResultDeleted       = "deleted"
+	BulkResultLegalHold     = "legal_hold"
+	BulkResultNotFound      = "not_found"
+	BulkResultNotCancelable = "not_cancelable"
+)
+
+// BulkImageIDsRequest represents a bulk operation on a list of images within a project.
+type BulkImageIDsRequest struct {
+	ImageIDs []string `json:"image_ids" validate:"required,min=1,max=100,dive,uuid"`
+}
+
+// BulkImageResult reports the outcome of a bulk operation for a single image.
+type BulkImageResult struct {
+	ImageID string `json:"image_id"`
+	Result  string `json:"result"`
+}
+
+// BulkImagesResponse represents the response for bulk image operations.
+type BulkImagesResponse struct {
+	Results []BulkImageResult `json:"results"`
+	Success int               `json:"success"`
+	Failed  int               `json:"failed"`
+}
+
+// StatusEvent is one recorded change of an image's status.
+type StatusEvent struct {
+	// From is nil for the event recorded when the image was created.
+	From  *Status `json:"from,omitempty"`
+	To    Status  `json:"to"`
+	Error *string `json:"error,omitempty"`
+	// Source is what made the change, e.g. real-staging-api or real-staging-worker.
+	Source  string  `json:"source"`
+	JobID   *string `json:"job_id,omitempty"`
+	Attempt *int    `json:"attempt,omitempty"`
+	// Stale marks a change made by an attempt of a job after a later attempt of the same
+	// job had already changed the status, such as a timed-out attempt reporting late.
+	Stale bool      `json:"stale,omitempty"`
+	At    time.Time `json:"at"`
+}
+
+// StatusHistory is an image's status event log, oldest first, and the status derived from it.
+type StatusHistory struct {
+	ImageID uuid.UUID `json:"image_id"`
+	// Status is the status of the latest event: the image's current status, or its last
+	// one if the image has been deleted.
+	Status Status        `json:"status"`
+	Events []StatusEvent `json:"events"`
+}
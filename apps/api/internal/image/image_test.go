@@ -0,0 +1,71 @@
+package image
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTransition(t *testing.T) {
+	testCases := []struct {
+		name    string
+		from    Status
+		to      Status
+		wantErr bool
+	}{
+		{name: "success: upload finished", from: StatusAwaitingUpload, to: StatusQueued},
+		{name: "success: worker picks up", from: StatusQueued, to: StatusProcessing},
+		{name: "success: worker finishes queued image", from: StatusQueued, to: StatusReady},
+		{name: "success: processing succeeds", from: StatusProcessing, to: StatusReady},
+		{name: "success: processing fails", from: StatusProcessing, to: StatusError},
+		{name: "success: canceled while processing", from: StatusProcessing, to: StatusCanceled},
+		{name: "success: ready expires", from: StatusReady, to: StatusExpired},
+		{name: "success: upload never finished", from: StatusAwaitingUpload, to: StatusExpired},
+		{name: "fail: skip the upload", from: StatusAwaitingUpload, to: StatusProcessing, wantErr: true},
+		{name: "fail: ready reprocessed", from: StatusReady, to: StatusProcessing, wantErr: true},
+		{name: "fail: canceled is final", from: StatusCanceled, to: StatusQueued, wantErr: true},
+		{name: "fail: expired is final", from: StatusExpired, to: StatusReady, wantErr: true},
+		{name: "fail: same status", from: StatusProcessing, to: StatusProcessing, wantErr: true},
+		{name: "fail: unknown status", from: Status("paused"), to: StatusQueued, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateTransition(tc.from, tc.to)
+			if tc.wantErr {
+				assert.True(t, errors.Is(err, ErrInvalidTransition))
+				assert.EqualError(t, err, "invalid image status transition: "+string(tc.from)+" to "+string(tc.to))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestParseSource(t *testing.T) {
+	testCases := []struct {
+		name    string
+		header  string
+		want    Source
+		wantErr bool
+	}{
+		{name: "success: web", header: "web", want: SourceWeb},
+		{name: "success: case and spaces are ignored", header: " Import ", want: SourceImport},
+		{name: "success: empty header counts as api", header: "", want: SourceAPI},
+		{name: "fail: unknown is reserved for old images", header: "unknown", wantErr: true},
+		{name: "fail: unrecognized surface", header: "desktop", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseSource(tc.header)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrSourceInvalid)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
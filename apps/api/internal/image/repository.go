@@ -2,6 +2,7 @@ package image
 
 import (
 	"context"
+	"time"
 
 	"github.com/real-staging-ai/api/internal/storage/queries"
 )
@@ -10,15 +11,24 @@ import (
 
 // Repository defines the interface for image data access operations.
 type Repository interface {
-	// CreateImage creates a new image in the database.
+	// CreateImage creates a new image in the database, recording the surface it came from.
 	CreateImage(
 		ctx context.Context,
 		projectID string,
 		originalURL string,
 		roomType, style *string,
 		seed *int64,
+		source Source,
 	) (*queries.Image, error)
 
+	// CreateImageAnnotations stores room measurements for an image.
+	CreateImageAnnotations(ctx context.Context, imageID string, annotations Annotations) error
+	// CreateImageBrackets stores the other exposures of an image's shot.
+	CreateImageBrackets(ctx context.Context, imageID string, urls []string) error
+
+	// CreateImages inserts the images in one COPY round trip and returns them in request order.
+	CreateImages(ctx context.Context, reqs []CreateImageRequest) ([]*queries.Image, error)
+
 	// GetImageByID retrieves a specific image by its ID.
 	GetImageByID(ctx context.Context, imageID string) (*queries.Image, error)
 
@@ -36,12 +46,37 @@ type Repository interface {
 	// UpdateImageWithError updates an image with an error status and message.
 	UpdateImageWithError(ctx context.Context, imageID string, errorMsg string) (*queries.Image, error)
 
+	// CancelImage marks an unfinished image as canceled and clears its cost.
+	// Returns pgx.ErrNoRows when the image is not in a cancelable state.
+	CancelImage(ctx context.Context, imageID string) (*queries.Image, error)
+
+	// GetImageStatusesByIDs returns the status of each listed image that belongs to the project.
+	GetImageStatusesByIDs(
+		ctx context.Context, projectID string, imageIDs []string,
+	) ([]*queries.GetImageStatusesByIDsRow, error)
+
+	// ListStatusTransitions returns the image's status events oldest first, including those
+	// of an image that has since been deleted.
+	ListStatusTransitions(ctx context.Context, imageID string) ([]*queries.ImageStatusTransition, error)
+
+	// BulkCancelImages cancels the listed unfinished images and their in-flight
+	// jobs atomically, returning one row per canceled image/job pair.
+	BulkCancelImages(ctx context.Context, projectID string, imageIDs []string) ([]*queries.BulkCancelImagesRow, error)
+
+	// BulkDeleteImages deletes the listed images that are not under legal hold atomically,
+	// returning the deleted rows and the held images with Held set.
+	BulkDeleteImages(ctx context.Context, projectID string, imageIDs []string) ([]*queries.BulkDeleteImagesRow, error)
+
 	// DeleteImage deletes an image from the database.
 	DeleteImage(ctx context.Context, imageID string) error
 
 	// DeleteImagesByProjectID deletes all images for a specific project.
 	DeleteImagesByProjectID(ctx context.Context, projectID string) error
 
+	// ListLegalHeldImageIDs returns the images in imageIDs that are under legal hold,
+	// either directly or through their project.
+	ListLegalHeldImageIDs(ctx context.Context, imageIDs []string) ([]string, error)
+
 	// UpdateImageCost updates cost tracking information for an image.
 	UpdateImageCost(
 		ctx context.Context,
@@ -54,4 +89,28 @@ type Repository interface {
 
 	// GetProjectCostSummary retrieves cost summary for a project.
 	GetProjectCostSummary(ctx context.Context, projectID string) (*ProjectCostSummary, error)
+
+	// GetReferenceImageAccess returns the project's owner and whether their plan allows
+	// reference images. Returns pgx.ErrNoRows when the project does not exist.
+	GetReferenceImageAccess(ctx context.Context, projectID string) (*queries.GetReferenceImageAccessRow, error)
+
+	// GetProjectOwnerID returns the ID of the user who owns the project. Returns pgx.ErrNoRows
+	// when the project does not exist.
+	GetProjectOwnerID(ctx context.Context, projectID string) (string, error)
+
+	// GetProjectRole returns the user's role on the project: owner, editor or viewer. Returns ""
+	// when the user neither owns nor collaborates on the project.
+	GetProjectRole(ctx context.Context, projectID, userID string) (string, error)
+
+	// GetProjectRoomStyle returns the style the project's room checklist gives roomType, or ""
+	// when it gives none.
+	GetProjectRoomStyle(ctx context.Context, projectID, roomType string) (string, error)
+
+	// GetExpediteAccess returns the image's project and owner, whether the owner's plan allows
+	// expediting, and how many images the owner has expedited since the given time.
+	// Returns pgx.ErrNoRows when the image does not exist.
+	GetExpediteAccess(ctx context.Context, imageID string, since time.Time) (*queries.GetExpediteAccessRow, error)
+
+	// RecordImageExpedited appends an image.expedited event to the project's activity log.
+	RecordImageExpedited(ctx context.Context, projectID, imageID, jobID, userID string) error
 }
@@ -7,6 +7,7 @@ import (
 	"context"
 	"github.com/real-staging-ai/api/internal/storage/queries"
 	"sync"
+	"time"
 )
 
 // Ensure, that RepositoryMock does implement Repository.
@@ -19,24 +20,69 @@ var _ Repository = &RepositoryMock{}
 //
 //		// make and configure a mocked Repository
 //		mockedRepository := &RepositoryMock{
-//			CreateImageFunc: func(ctx context.Context, projectID string, originalURL string, roomType *string, style *string, seed *int64) (*queries.Image, error) {
+//			BulkCancelImagesFunc: func(ctx context.Context, projectID string, imageIDs []string) ([]*queries.BulkCancelImagesRow, error) {
+//				panic("mock out the BulkCancelImages method")
+//			},
+//			BulkDeleteImagesFunc: func(ctx context.Context, projectID string, imageIDs []string) ([]*queries.BulkDeleteImagesRow, error) {
+//				panic("mock out the BulkDeleteImages method")
+//			},
+//			CancelImageFunc: func(ctx context.Context, imageID string) (*queries.Image, error) {
+//				panic("mock out the CancelImage method")
+//			},
+//			CreateImageFunc: func(ctx context.Context, projectID string, originalURL string, roomType *string, style *string, seed *int64, source Source) (*queries.Image, error) {
 //				panic("mock out the CreateImage method")
 //			},
+//			CreateImageAnnotationsFunc: func(ctx context.Context, imageID string, annotations Annotations) error {
+//				panic("mock out the CreateImageAnnotations method")
+//			},
+//			CreateImageBracketsFunc: func(ctx context.Context, imageID string, urls []string) error {
+//				panic("mock out the CreateImageBrackets method")
+//			},
+//			CreateImagesFunc: func(ctx context.Context, reqs []CreateImageRequest) ([]*queries.Image, error) {
+//				panic("mock out the CreateImages method")
+//			},
 //			DeleteImageFunc: func(ctx context.Context, imageID string) error {
 //				panic("mock out the DeleteImage method")
 //			},
 //			DeleteImagesByProjectIDFunc: func(ctx context.Context, projectID string) error {
 //				panic("mock out the DeleteImagesByProjectID method")
 //			},
+//			GetExpediteAccessFunc: func(ctx context.Context, imageID string, since time.Time) (*queries.GetExpediteAccessRow, error) {
+//				panic("mock out the GetExpediteAccess method")
+//			},
 //			GetImageByIDFunc: func(ctx context.Context, imageID string) (*queries.Image, error) {
 //				panic("mock out the GetImageByID method")
 //			},
+//			GetImageStatusesByIDsFunc: func(ctx context.Context, projectID string, imageIDs []string) ([]*queries.GetImageStatusesByIDsRow, error) {
+//				panic("mock out the GetImageStatusesByIDs method")
+//			},
 //			GetImagesByProjectIDFunc: func(ctx context.Context, projectID string) ([]*queries.Image, error) {
 //				panic("mock out the GetImagesByProjectID method")
 //			},
 //			GetProjectCostSummaryFunc: func(ctx context.Context, projectID string) (*ProjectCostSummary, error) {
 //				panic("mock out the GetProjectCostSummary method")
 //			},
+//			GetProjectOwnerIDFunc: func(ctx context.Context, projectID string) (string, error) {
+//				panic("mock out the GetProjectOwnerID method")
+//			},
+//			GetProjectRoleFunc: func(ctx context.Context, projectID string, userID string) (string, error) {
+//				panic("mock out the GetProjectRole method")
+//			},
+//			GetProjectRoomStyleFunc: func(ctx context.Context, projectID string, roomType string) (string, error) {
+//				panic("mock out the GetProjectRoomStyle method")
+//			},
+//			GetReferenceImageAccessFunc: func(ctx context.Context, projectID string) (*queries.GetReferenceImageAccessRow, error) {
+//				panic("mock out the GetReferenceImageAccess method")
+//			},
+//			ListLegalHeldImageIDsFunc: func(ctx context.Context, imageIDs []string) ([]string, error) {
+//				panic("mock out the ListLegalHeldImageIDs method")
+//			},
+//			ListStatusTransitionsFunc: func(ctx context.Context, imageID string) ([]*queries.ImageStatusTransition, error) {
+//				panic("mock out the ListStatusTransitions method")
+//			},
+//			RecordImageExpeditedFunc: func(ctx context.Context, projectID string, imageID string, jobID string, userID string) error {
+//				panic("mock out the RecordImageExpedited method")
+//			},
 //			UpdateImageCostFunc: func(ctx context.Context, imageID string, costUSD float64, modelUsed string, processingTimeMs int, predictionID string) error {
 //				panic("mock out the UpdateImageCost method")
 //			},
@@ -56,8 +102,26 @@ var _ Repository = &RepositoryMock{}
 //
 //	}
 type RepositoryMock struct {
+	// BulkCancelImagesFunc mocks the BulkCancelImages method.
+	BulkCancelImagesFunc func(ctx context.Context, projectID string, imageIDs []string) ([]*queries.BulkCancelImagesRow, error)
+
+	// BulkDeleteImagesFunc mocks the BulkDeleteImages method.
+	BulkDeleteImagesFunc func(ctx context.Context, projectID string, imageIDs []string) ([]*queries.BulkDeleteImagesRow, error)
+
+	// CancelImageFunc mocks the CancelImage method.
+	CancelImageFunc func(ctx context.Context, imageID string) (*queries.Image, error)
+
 	// CreateImageFunc mocks the CreateImage method.
-	CreateImageFunc func(ctx context.Context, projectID string, originalURL string, roomType *string, style *string, seed *int64) (*queries.Image, error)
+	CreateImageFunc func(ctx context.Context, projectID string, originalURL string, roomType *string, style *string, seed *int64, source Source) (*queries.Image, error)
+
+	// CreateImageAnnotationsFunc mocks the CreateImageAnnotations method.
+	CreateImageAnnotationsFunc func(ctx context.Context, imageID string, annotations Annotations) error
+
+	// CreateImageBracketsFunc mocks the CreateImageBrackets method.
+	CreateImageBracketsFunc func(ctx context.Context, imageID string, urls []string) error
+
+	// CreateImagesFunc mocks the CreateImages method.
+	CreateImagesFunc func(ctx context.Context, reqs []CreateImageRequest) ([]*queries.Image, error)
 
 	// DeleteImageFunc mocks the DeleteImage method.
 	DeleteImageFunc func(ctx context.Context, imageID string) error
@@ -65,15 +129,42 @@ type RepositoryMock struct {
 	// DeleteImagesByProjectIDFunc mocks the DeleteImagesByProjectID method.
 	DeleteImagesByProjectIDFunc func(ctx context.Context, projectID string) error
 
+	// GetExpediteAccessFunc mocks the GetExpediteAccess method.
+	GetExpediteAccessFunc func(ctx context.Context, imageID string, since time.Time) (*queries.GetExpediteAccessRow, error)
+
 	// GetImageByIDFunc mocks the GetImageByID method.
 	GetImageByIDFunc func(ctx context.Context, imageID string) (*queries.Image, error)
 
+	// GetImageStatusesByIDsFunc mocks the GetImageStatusesByIDs method.
+	GetImageStatusesByIDsFunc func(ctx context.Context, projectID string, imageIDs []string) ([]*queries.GetImageStatusesByIDsRow, error)
+
 	// GetImagesByProjectIDFunc mocks the GetImagesByProjectID method.
 	GetImagesByProjectIDFunc func(ctx context.Context, projectID string) ([]*queries.Image, error)
 
 	// GetProjectCostSummaryFunc mocks the GetProjectCostSummary method.
 	GetProjectCostSummaryFunc func(ctx context.Context, projectID string) (*ProjectCostSummary, error)
 
+	// GetProjectOwnerIDFunc mocks the GetProjectOwnerID method.
+	GetProjectOwnerIDFunc func(ctx context.Context, projectID string) (string, error)
+
+	// GetProjectRoleFunc mocks the GetProjectRole method.
+	GetProjectRoleFunc func(ctx context.Context, projectID string, userID string) (string, error)
+
+	// GetProjectRoomStyleFunc mocks the GetProjectRoomStyle method.
+	GetProjectRoomStyleFunc func(ctx context.Context, projectID string, roomType string) (string, error)
+
+	// GetReferenceImageAccessFunc mocks the GetReferenceImageAccess method.
+	GetReferenceImageAccessFunc func(ctx context.Context, projectID string) (*queries.GetReferenceImageAccessRow, error)
+
+	// ListLegalHeldImageIDsFunc mocks the ListLegalHeldImageIDs method.
+	ListLegalHeldImageIDsFunc func(ctx context.Context, imageIDs []string) ([]string, error)
+
+	// ListStatusTransitionsFunc mocks the ListStatusTransitions method.
+	ListStatusTransitionsFunc func(ctx context.Context, imageID string) ([]*queries.ImageStatusTransition, error)
+
+	// RecordImageExpeditedFunc mocks the RecordImageExpedited method.
+	RecordImageExpeditedFunc func(ctx context.Context, projectID string, imageID string, jobID string, userID string) error
+
 	// UpdateImageCostFunc mocks the UpdateImageCost method.
 	UpdateImageCostFunc func(ctx context.Context, imageID string, costUSD float64, modelUsed string, processingTimeMs int, predictionID string) error
 
@@ -88,6 +179,31 @@ type RepositoryMock struct {
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// BulkCancelImages holds details about calls to the BulkCancelImages method.
+		BulkCancelImages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+			// ImageIDs is the imageIDs argument value.
+			ImageIDs []string
+		}
+		// BulkDeleteImages holds details about calls to the BulkDeleteImages method.
+		BulkDeleteImages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+			// ImageIDs is the imageIDs argument value.
+			ImageIDs []string
+		}
+		// CancelImage holds details about calls to the CancelImage method.
+		CancelImage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID string
+		}
 		// CreateImage holds details about calls to the CreateImage method.
 		CreateImage []struct {
 			// Ctx is the ctx argument value.
@@ -102,6 +218,33 @@ type RepositoryMock struct {
 			Style *string
 			// Seed is the seed argument value.
 			Seed *int64
+			// Source is the source argument value.
+			Source Source
+		}
+		// CreateImageAnnotations holds details about calls to the CreateImageAnnotations method.
+		CreateImageAnnotations []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID string
+			// Annotations is the annotations argument value.
+			Annotations Annotations
+		}
+		// CreateImageBrackets holds details about calls to the CreateImageBrackets method.
+		CreateImageBrackets []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID string
+			// Urls is the urls argument value.
+			Urls []string
+		}
+		// CreateImages holds details about calls to the CreateImages method.
+		CreateImages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Reqs is the reqs argument value.
+			Reqs []CreateImageRequest
 		}
 		// DeleteImage holds details about calls to the DeleteImage method.
 		DeleteImage []struct {
@@ -117,6 +260,15 @@ type RepositoryMock struct {
 			// ProjectID is the projectID argument value.
 			ProjectID string
 		}
+		// GetExpediteAccess holds details about calls to the GetExpediteAccess method.
+		GetExpediteAccess []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID string
+			// Since is the since argument value.
+			Since time.Time
+		}
 		// GetImageByID holds details about calls to the GetImageByID method.
 		GetImageByID []struct {
 			// Ctx is the ctx argument value.
@@ -124,6 +276,15 @@ type RepositoryMock struct {
 			// ImageID is the imageID argument value.
 			ImageID string
 		}
+		// GetImageStatusesByIDs holds details about calls to the GetImageStatusesByIDs method.
+		GetImageStatusesByIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+			// ImageIDs is the imageIDs argument value.
+			ImageIDs []string
+		}
 		// GetImagesByProjectID holds details about calls to the GetImagesByProjectID method.
 		GetImagesByProjectID []struct {
 			// Ctx is the ctx argument value.
@@ -138,6 +299,65 @@ type RepositoryMock struct {
 			// ProjectID is the projectID argument value.
 			ProjectID string
 		}
+		// GetProjectOwnerID holds details about calls to the GetProjectOwnerID method.
+		GetProjectOwnerID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+		}
+		// GetProjectRole holds details about calls to the GetProjectRole method.
+		GetProjectRole []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+			// UserID is the userID argument value.
+			UserID string
+		}
+		// GetProjectRoomStyle holds details about calls to the GetProjectRoomStyle method.
+		GetProjectRoomStyle []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+			// RoomType is the roomType argument value.
+			RoomType string
+		}
+		// GetReferenceImageAccess holds details about calls to the GetReferenceImageAccess method.
+		GetReferenceImageAccess []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+		}
+		// ListLegalHeldImageIDs holds details about calls to the ListLegalHeldImageIDs method.
+		ListLegalHeldImageIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageIDs is the imageIDs argument value.
+			ImageIDs []string
+		}
+		// ListStatusTransitions holds details about calls to the ListStatusTransitions method.
+		ListStatusTransitions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID string
+		}
+		// RecordImageExpedited holds details about calls to the RecordImageExpedited method.
+		RecordImageExpedited []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+			// ImageID is the imageID argument value.
+			ImageID string
+			// JobID is the jobID argument value.
+			JobID string
+			// UserID is the userID argument value.
+			UserID string
+		}
 		// UpdateImageCost holds details about calls to the UpdateImageCost method.
 		UpdateImageCost []struct {
 			// Ctx is the ctx argument value.
@@ -183,20 +403,151 @@ type RepositoryMock struct {
 			Status string
 		}
 	}
+	lockBulkCancelImages         sync.RWMutex
+	lockBulkDeleteImages         sync.RWMutex
+	lockCancelImage              sync.RWMutex
 	lockCreateImage              sync.RWMutex
+	lockCreateImageAnnotations   sync.RWMutex
+	lockCreateImageBrackets      sync.RWMutex
+	lockCreateImages             sync.RWMutex
 	lockDeleteImage              sync.RWMutex
 	lockDeleteImagesByProjectID  sync.RWMutex
+	lockGetExpediteAccess        sync.RWMutex
 	lockGetImageByID             sync.RWMutex
+	lockGetImageStatusesByIDs    sync.RWMutex
 	lockGetImagesByProjectID     sync.RWMutex
 	lockGetProjectCostSummary    sync.RWMutex
+	lockGetProjectOwnerID        sync.RWMutex
+	lockGetProjectRole           sync.RWMutex
+	lockGetProjectRoomStyle      sync.RWMutex
+	lockGetReferenceImageAccess  sync.RWMutex
+	lockListLegalHeldImageIDs    sync.RWMutex
+	lockListStatusTransitions    sync.RWMutex
+	lockRecordImageExpedited     sync.RWMutex
 	lockUpdateImageCost          sync.RWMutex
 	lockUpdateImageStatus        sync.RWMutex
 	lockUpdateImageWithError     sync.RWMutex
 	lockUpdateImageWithStagedURL sync.RWMutex
 }
 
+// BulkCancelImages calls BulkCancelImagesFunc.
+func (mock *RepositoryMock) BulkCancelImages(ctx context.Context, projectID string, imageIDs []string) ([]*queries.BulkCancelImagesRow, error) {
+	if mock.BulkCancelImagesFunc == nil {
+		panic("RepositoryMock.BulkCancelImagesFunc: method is nil but Repository.BulkCancelImages was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+		ImageIDs  []string
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+		ImageIDs:  imageIDs,
+	}
+	mock.lockBulkCancelImages.Lock()
+	mock.calls.BulkCancelImages = append(mock.calls.BulkCancelImages, callInfo)
+	mock.lockBulkCancelImages.Unlock()
+	return mock.BulkCancelImagesFunc(ctx, projectID, imageIDs)
+}
+
+// BulkCancelImagesCalls gets all the calls that were made to BulkCancelImages.
+// Check the length with:
+//
+//	len(mockedRepository.BulkCancelImagesCalls())
+func (mock *RepositoryMock) BulkCancelImagesCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+	ImageIDs  []string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+		ImageIDs  []string
+	}
+	mock.lockBulkCancelImages.RLock()
+	calls = mock.calls.BulkCancelImages
+	mock.lockBulkCancelImages.RUnlock()
+	return calls
+}
+
+// BulkDeleteImages calls BulkDeleteImagesFunc.
+func (mock *RepositoryMock) BulkDeleteImages(ctx context.Context, projectID string, imageIDs []string) ([]*queries.BulkDeleteImagesRow, error) {
+	if mock.BulkDeleteImagesFunc == nil {
+		panic("RepositoryMock.BulkDeleteImagesFunc: method is nil but Repository.BulkDeleteImages was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+		ImageIDs  []string
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+		ImageIDs:  imageIDs,
+	}
+	mock.lockBulkDeleteImages.Lock()
+	mock.calls.BulkDeleteImages = append(mock.calls.BulkDeleteImages, callInfo)
+	mock.lockBulkDeleteImages.Unlock()
+	return mock.BulkDeleteImagesFunc(ctx, projectID, imageIDs)
+}
+
+// BulkDeleteImagesCalls gets all the calls that were made to BulkDeleteImages.
+// Check the length with:
+//
+//	len(mockedRepository.BulkDeleteImagesCalls())
+func (mock *RepositoryMock) BulkDeleteImagesCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+	ImageIDs  []string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+		ImageIDs  []string
+	}
+	mock.lockBulkDeleteImages.RLock()
+	calls = mock.calls.BulkDeleteImages
+	mock.lockBulkDeleteImages.RUnlock()
+	return calls
+}
+
+// CancelImage calls CancelImageFunc.
+func (mock *RepositoryMock) CancelImage(ctx context.Context, imageID string) (*queries.Image, error) {
+	if mock.CancelImageFunc == nil {
+		panic("RepositoryMock.CancelImageFunc: method is nil but Repository.CancelImage was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ImageID string
+	}{
+		Ctx:     ctx,
+		ImageID: imageID,
+	}
+	mock.lockCancelImage.Lock()
+	mock.calls.CancelImage = append(mock.calls.CancelImage, callInfo)
+	mock.lockCancelImage.Unlock()
+	return mock.CancelImageFunc(ctx, imageID)
+}
+
+// CancelImageCalls gets all the calls that were made to CancelImage.
+// Check the length with:
+//
+//	len(mockedRepository.CancelImageCalls())
+func (mock *RepositoryMock) CancelImageCalls() []struct {
+	Ctx     context.Context
+	ImageID string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ImageID string
+	}
+	mock.lockCancelImage.RLock()
+	calls = mock.calls.CancelImage
+	mock.lockCancelImage.RUnlock()
+	return calls
+}
+
 // CreateImage calls CreateImageFunc.
-func (mock *RepositoryMock) CreateImage(ctx context.Context, projectID string, originalURL string, roomType *string, style *string, seed *int64) (*queries.Image, error) {
+func (mock *RepositoryMock) CreateImage(ctx context.Context, projectID string, originalURL string, roomType *string, style *string, seed *int64, source Source) (*queries.Image, error) {
 	if mock.CreateImageFunc == nil {
 		panic("RepositoryMock.CreateImageFunc: method is nil but Repository.CreateImage was just called")
 	}
@@ -207,6 +558,7 @@ func (mock *RepositoryMock) CreateImage(ctx context.Context, projectID string, o
 		RoomType    *string
 		Style       *string
 		Seed        *int64
+		Source      Source
 	}{
 		Ctx:         ctx,
 		ProjectID:   projectID,
@@ -214,11 +566,12 @@ func (mock *RepositoryMock) CreateImage(ctx context.Context, projectID string, o
 		RoomType:    roomType,
 		Style:       style,
 		Seed:        seed,
+		Source:      source,
 	}
 	mock.lockCreateImage.Lock()
 	mock.calls.CreateImage = append(mock.calls.CreateImage, callInfo)
 	mock.lockCreateImage.Unlock()
-	return mock.CreateImageFunc(ctx, projectID, originalURL, roomType, style, seed)
+	return mock.CreateImageFunc(ctx, projectID, originalURL, roomType, style, seed, source)
 }
 
 // CreateImageCalls gets all the calls that were made to CreateImage.
@@ -232,6 +585,7 @@ func (mock *RepositoryMock) CreateImageCalls() []struct {
 	RoomType    *string
 	Style       *string
 	Seed        *int64
+	Source      Source
 } {
 	var calls []struct {
 		Ctx         context.Context
@@ -240,6 +594,7 @@ func (mock *RepositoryMock) CreateImageCalls() []struct {
 		RoomType    *string
 		Style       *string
 		Seed        *int64
+		Source      Source
 	}
 	mock.lockCreateImage.RLock()
 	calls = mock.calls.CreateImage
@@ -247,6 +602,122 @@ func (mock *RepositoryMock) CreateImageCalls() []struct {
 	return calls
 }
 
+// CreateImageAnnotations calls CreateImageAnnotationsFunc.
+func (mock *RepositoryMock) CreateImageAnnotations(ctx context.Context, imageID string, annotations Annotations) error {
+	if mock.CreateImageAnnotationsFunc == nil {
+		panic("RepositoryMock.CreateImageAnnotationsFunc: method is nil but Repository.CreateImageAnnotations was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		ImageID     string
+		Annotations Annotations
+	}{
+		Ctx:         ctx,
+		ImageID:     imageID,
+		Annotations: annotations,
+	}
+	mock.lockCreateImageAnnotations.Lock()
+	mock.calls.CreateImageAnnotations = append(mock.calls.CreateImageAnnotations, callInfo)
+	mock.lockCreateImageAnnotations.Unlock()
+	return mock.CreateImageAnnotationsFunc(ctx, imageID, annotations)
+}
+
+// CreateImageAnnotationsCalls gets all the calls that were made to CreateImageAnnotations.
+// Check the length with:
+//
+//	len(mockedRepository.CreateImageAnnotationsCalls())
+func (mock *RepositoryMock) CreateImageAnnotationsCalls() []struct {
+	Ctx         context.Context
+	ImageID     string
+	Annotations Annotations
+} {
+	var calls []struct {
+		Ctx         context.Context
+		ImageID     string
+		Annotations Annotations
+	}
+	mock.lockCreateImageAnnotations.RLock()
+	calls = mock.calls.CreateImageAnnotations
+	mock.lockCreateImageAnnotations.RUnlock()
+	return calls
+}
+
+// CreateImageBrackets calls CreateImageBracketsFunc.
+func (mock *RepositoryMock) CreateImageBrackets(ctx context.Context, imageID string, urls []string) error {
+	if mock.CreateImageBracketsFunc == nil {
+		panic("RepositoryMock.CreateImageBracketsFunc: method is nil but Repository.CreateImageBrackets was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ImageID string
+		Urls    []string
+	}{
+		Ctx:     ctx,
+		ImageID: imageID,
+		Urls:    urls,
+	}
+	mock.lockCreateImageBrackets.Lock()
+	mock.calls.CreateImageBrackets = append(mock.calls.CreateImageBrackets, callInfo)
+	mock.lockCreateImageBrackets.Unlock()
+	return mock.CreateImageBracketsFunc(ctx, imageID, urls)
+}
+
+// CreateImageBracketsCalls gets all the calls that were made to CreateImageBrackets.
+// Check the length with:
+//
+//	len(mockedRepository.CreateImageBracketsCalls())
+func (mock *RepositoryMock) CreateImageBracketsCalls() []struct {
+	Ctx     context.Context
+	ImageID string
+	Urls    []string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ImageID string
+		Urls    []string
+	}
+	mock.lockCreateImageBrackets.RLock()
+	calls = mock.calls.CreateImageBrackets
+	mock.lockCreateImageBrackets.RUnlock()
+	return calls
+}
+
+// CreateImages calls CreateImagesFunc.
+func (mock *RepositoryMock) CreateImages(ctx context.Context, reqs []CreateImageRequest) ([]*queries.Image, error) {
+	if mock.CreateImagesFunc == nil {
+		panic("RepositoryMock.CreateImagesFunc: method is nil but Repository.CreateImages was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Reqs []CreateImageRequest
+	}{
+		Ctx:  ctx,
+		Reqs: reqs,
+	}
+	mock.lockCreateImages.Lock()
+	mock.calls.CreateImages = append(mock.calls.CreateImages, callInfo)
+	mock.lockCreateImages.Unlock()
+	return mock.CreateImagesFunc(ctx, reqs)
+}
+
+// CreateImagesCalls gets all the calls that were made to CreateImages.
+// Check the length with:
+//
+//	len(mockedRepository.CreateImagesCalls())
+func (mock *RepositoryMock) CreateImagesCalls() []struct {
+	Ctx  context.Context
+	Reqs []CreateImageRequest
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Reqs []CreateImageRequest
+	}
+	mock.lockCreateImages.RLock()
+	calls = mock.calls.CreateImages
+	mock.lockCreateImages.RUnlock()
+	return calls
+}
+
 // DeleteImage calls DeleteImageFunc.
 func (mock *RepositoryMock) DeleteImage(ctx context.Context, imageID string) error {
 	if mock.DeleteImageFunc == nil {
@@ -319,6 +790,46 @@ func (mock *RepositoryMock) DeleteImagesByProjectIDCalls() []struct {
 	return calls
 }
 
+// GetExpediteAccess calls GetExpediteAccessFunc.
+func (mock *RepositoryMock) GetExpediteAccess(ctx context.Context, imageID string, since time.Time) (*queries.GetExpediteAccessRow, error) {
+	if mock.GetExpediteAccessFunc == nil {
+		panic("RepositoryMock.GetExpediteAccessFunc: method is nil but Repository.GetExpediteAccess was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ImageID string
+		Since   time.Time
+	}{
+		Ctx:     ctx,
+		ImageID: imageID,
+		Since:   since,
+	}
+	mock.lockGetExpediteAccess.Lock()
+	mock.calls.GetExpediteAccess = append(mock.calls.GetExpediteAccess, callInfo)
+	mock.lockGetExpediteAccess.Unlock()
+	return mock.GetExpediteAccessFunc(ctx, imageID, since)
+}
+
+// GetExpediteAccessCalls gets all the calls that were made to GetExpediteAccess.
+// Check the length with:
+//
+//	len(mockedRepository.GetExpediteAccessCalls())
+func (mock *RepositoryMock) GetExpediteAccessCalls() []struct {
+	Ctx     context.Context
+	ImageID string
+	Since   time.Time
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ImageID string
+		Since   time.Time
+	}
+	mock.lockGetExpediteAccess.RLock()
+	calls = mock.calls.GetExpediteAccess
+	mock.lockGetExpediteAccess.RUnlock()
+	return calls
+}
+
 // GetImageByID calls GetImageByIDFunc.
 func (mock *RepositoryMock) GetImageByID(ctx context.Context, imageID string) (*queries.Image, error) {
 	if mock.GetImageByIDFunc == nil {
@@ -355,6 +866,46 @@ func (mock *RepositoryMock) GetImageByIDCalls() []struct {
 	return calls
 }
 
+// GetImageStatusesByIDs calls GetImageStatusesByIDsFunc.
+func (mock *RepositoryMock) GetImageStatusesByIDs(ctx context.Context, projectID string, imageIDs []string) ([]*queries.GetImageStatusesByIDsRow, error) {
+	if mock.GetImageStatusesByIDsFunc == nil {
+		panic("RepositoryMock.GetImageStatusesByIDsFunc: method is nil but Repository.GetImageStatusesByIDs was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+		ImageIDs  []string
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+		ImageIDs:  imageIDs,
+	}
+	mock.lockGetImageStatusesByIDs.Lock()
+	mock.calls.GetImageStatusesByIDs = append(mock.calls.GetImageStatusesByIDs, callInfo)
+	mock.lockGetImageStatusesByIDs.Unlock()
+	return mock.GetImageStatusesByIDsFunc(ctx, projectID, imageIDs)
+}
+
+// GetImageStatusesByIDsCalls gets all the calls that were made to GetImageStatusesByIDs.
+// Check the length with:
+//
+//	len(mockedRepository.GetImageStatusesByIDsCalls())
+func (mock *RepositoryMock) GetImageStatusesByIDsCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+	ImageIDs  []string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+		ImageIDs  []string
+	}
+	mock.lockGetImageStatusesByIDs.RLock()
+	calls = mock.calls.GetImageStatusesByIDs
+	mock.lockGetImageStatusesByIDs.RUnlock()
+	return calls
+}
+
 // GetImagesByProjectID calls GetImagesByProjectIDFunc.
 func (mock *RepositoryMock) GetImagesByProjectID(ctx context.Context, projectID string) ([]*queries.Image, error) {
 	if mock.GetImagesByProjectIDFunc == nil {
@@ -427,6 +978,278 @@ func (mock *RepositoryMock) GetProjectCostSummaryCalls() []struct {
 	return calls
 }
 
+// GetProjectOwnerID calls GetProjectOwnerIDFunc.
+func (mock *RepositoryMock) GetProjectOwnerID(ctx context.Context, projectID string) (string, error) {
+	if mock.GetProjectOwnerIDFunc == nil {
+		panic("RepositoryMock.GetProjectOwnerIDFunc: method is nil but Repository.GetProjectOwnerID was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockGetProjectOwnerID.Lock()
+	mock.calls.GetProjectOwnerID = append(mock.calls.GetProjectOwnerID, callInfo)
+	mock.lockGetProjectOwnerID.Unlock()
+	return mock.GetProjectOwnerIDFunc(ctx, projectID)
+}
+
+// GetProjectOwnerIDCalls gets all the calls that were made to GetProjectOwnerID.
+// Check the length with:
+//
+//	len(mockedRepository.GetProjectOwnerIDCalls())
+func (mock *RepositoryMock) GetProjectOwnerIDCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+	}
+	mock.lockGetProjectOwnerID.RLock()
+	calls = mock.calls.GetProjectOwnerID
+	mock.lockGetProjectOwnerID.RUnlock()
+	return calls
+}
+
+// GetProjectRole calls GetProjectRoleFunc.
+func (mock *RepositoryMock) GetProjectRole(ctx context.Context, projectID string, userID string) (string, error) {
+	if mock.GetProjectRoleFunc == nil {
+		panic("RepositoryMock.GetProjectRoleFunc: method is nil but Repository.GetProjectRole was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+		UserID    string
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+		UserID:    userID,
+	}
+	mock.lockGetProjectRole.Lock()
+	mock.calls.GetProjectRole = append(mock.calls.GetProjectRole, callInfo)
+	mock.lockGetProjectRole.Unlock()
+	return mock.GetProjectRoleFunc(ctx, projectID, userID)
+}
+
+// GetProjectRoleCalls gets all the calls that were made to GetProjectRole.
+// Check the length with:
+//
+//	len(mockedRepository.GetProjectRoleCalls())
+func (mock *RepositoryMock) GetProjectRoleCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+	UserID    string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+		UserID    string
+	}
+	mock.lockGetProjectRole.RLock()
+	calls = mock.calls.GetProjectRole
+	mock.lockGetProjectRole.RUnlock()
+	return calls
+}
+
+// GetProjectRoomStyle calls GetProjectRoomStyleFunc.
+func (mock *RepositoryMock) GetProjectRoomStyle(ctx context.Context, projectID string, roomType string) (string, error) {
+	if mock.GetProjectRoomStyleFunc == nil {
+		panic("RepositoryMock.GetProjectRoomStyleFunc: method is nil but Repository.GetProjectRoomStyle was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+		RoomType  string
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+		RoomType:  roomType,
+	}
+	mock.lockGetProjectRoomStyle.Lock()
+	mock.calls.GetProjectRoomStyle = append(mock.calls.GetProjectRoomStyle, callInfo)
+	mock.lockGetProjectRoomStyle.Unlock()
+	return mock.GetProjectRoomStyleFunc(ctx, projectID, roomType)
+}
+
+// GetProjectRoomStyleCalls gets all the calls that were made to GetProjectRoomStyle.
+// Check the length with:
+//
+//	len(mockedRepository.GetProjectRoomStyleCalls())
+func (mock *RepositoryMock) GetProjectRoomStyleCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+	RoomType  string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+		RoomType  string
+	}
+	mock.lockGetProjectRoomStyle.RLock()
+	calls = mock.calls.GetProjectRoomStyle
+	mock.lockGetProjectRoomStyle.RUnlock()
+	return calls
+}
+
+// GetReferenceImageAccess calls GetReferenceImageAccessFunc.
+func (mock *RepositoryMock) GetReferenceImageAccess(ctx context.Context, projectID string) (*queries.GetReferenceImageAccessRow, error) {
+	if mock.GetReferenceImageAccessFunc == nil {
+		panic("RepositoryMock.GetReferenceImageAccessFunc: method is nil but Repository.GetReferenceImageAccess was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockGetReferenceImageAccess.Lock()
+	mock.calls.GetReferenceImageAccess = append(mock.calls.GetReferenceImageAccess, callInfo)
+	mock.lockGetReferenceImageAccess.Unlock()
+	return mock.GetReferenceImageAccessFunc(ctx, projectID)
+}
+
+// GetReferenceImageAccessCalls gets all the calls that were made to GetReferenceImageAccess.
+// Check the length with:
+//
+//	len(mockedRepository.GetReferenceImageAccessCalls())
+func (mock *RepositoryMock) GetReferenceImageAccessCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+	}
+	mock.lockGetReferenceImageAccess.RLock()
+	calls = mock.calls.GetReferenceImageAccess
+	mock.lockGetReferenceImageAccess.RUnlock()
+	return calls
+}
+
+// ListLegalHeldImageIDs calls ListLegalHeldImageIDsFunc.
+func (mock *RepositoryMock) ListLegalHeldImageIDs(ctx context.Context, imageIDs []string) ([]string, error) {
+	if mock.ListLegalHeldImageIDsFunc == nil {
+		panic("RepositoryMock.ListLegalHeldImageIDsFunc: method is nil but Repository.ListLegalHeldImageIDs was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ImageIDs []string
+	}{
+		Ctx:      ctx,
+		ImageIDs: imageIDs,
+	}
+	mock.lockListLegalHeldImageIDs.Lock()
+	mock.calls.ListLegalHeldImageIDs = append(mock.calls.ListLegalHeldImageIDs, callInfo)
+	mock.lockListLegalHeldImageIDs.Unlock()
+	return mock.ListLegalHeldImageIDsFunc(ctx, imageIDs)
+}
+
+// ListLegalHeldImageIDsCalls gets all the calls that were made to ListLegalHeldImageIDs.
+// Check the length with:
+//
+//	len(mockedRepository.ListLegalHeldImageIDsCalls())
+func (mock *RepositoryMock) ListLegalHeldImageIDsCalls() []struct {
+	Ctx      context.Context
+	ImageIDs []string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ImageIDs []string
+	}
+	mock.lockListLegalHeldImageIDs.RLock()
+	calls = mock.calls.ListLegalHeldImageIDs
+	mock.lockListLegalHeldImageIDs.RUnlock()
+	return calls
+}
+
+// ListStatusTransitions calls ListStatusTransitionsFunc.
+func (mock *RepositoryMock) ListStatusTransitions(ctx context.Context, imageID string) ([]*queries.ImageStatusTransition, error) {
+	if mock.ListStatusTransitionsFunc == nil {
+		panic("RepositoryMock.ListStatusTransitionsFunc: method is nil but Repository.ListStatusTransitions was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ImageID string
+	}{
+		Ctx:     ctx,
+		ImageID: imageID,
+	}
+	mock.lockListStatusTransitions.Lock()
+	mock.calls.ListStatusTransitions = append(mock.calls.ListStatusTransitions, callInfo)
+	mock.lockListStatusTransitions.Unlock()
+	return mock.ListStatusTransitionsFunc(ctx, imageID)
+}
+
+// ListStatusTransitionsCalls gets all the calls that were made to ListStatusTransitions.
+// Check the length with:
+//
+//	len(mockedRepository.ListStatusTransitionsCalls())
+func (mock *RepositoryMock) ListStatusTransitionsCalls() []struct {
+	Ctx     context.Context
+	ImageID string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ImageID string
+	}
+	mock.lockListStatusTransitions.RLock()
+	calls = mock.calls.ListStatusTransitions
+	mock.lockListStatusTransitions.RUnlock()
+	return calls
+}
+
+// RecordImageExpedited calls RecordImageExpeditedFunc.
+func (mock *RepositoryMock) RecordImageExpedited(ctx context.Context, projectID string, imageID string, jobID string, userID string) error {
+	if mock.RecordImageExpeditedFunc == nil {
+		panic("RepositoryMock.RecordImageExpeditedFunc: method is nil but Repository.RecordImageExpedited was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+		ImageID   string
+		JobID     string
+		UserID    string
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+		ImageID:   imageID,
+		JobID:     jobID,
+		UserID:    userID,
+	}
+	mock.lockRecordImageExpedited.Lock()
+	mock.calls.RecordImageExpedited = append(mock.calls.RecordImageExpedited, callInfo)
+	mock.lockRecordImageExpedited.Unlock()
+	return mock.RecordImageExpeditedFunc(ctx, projectID, imageID, jobID, userID)
+}
+
+// RecordImageExpeditedCalls gets all the calls that were made to RecordImageExpedited.
+// Check the length with:
+//
+//	len(mockedRepository.RecordImageExpeditedCalls())
+func (mock *RepositoryMock) RecordImageExpeditedCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+	ImageID   string
+	JobID     string
+	UserID    string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+		ImageID   string
+		JobID     string
+		UserID    string
+	}
+	mock.lockRecordImageExpedited.RLock()
+	calls = mock.calls.RecordImageExpedited
+	mock.lockRecordImageExpedited.RUnlock()
+	return calls
+}
+
 // UpdateImageCost calls UpdateImageCostFunc.
 func (mock *RepositoryMock) UpdateImageCost(ctx context.Context, imageID string, costUSD float64, modelUsed string, processingTimeMs int, predictionID string) error {
 	if mock.UpdateImageCostFunc == nil {
@@ -17,6 +17,11 @@ type Service interface {
 	UpdateImageStatus(ctx context.Context, imageID string, status Status) (*Image, error)
 	UpdateImageWithStagedURL(ctx context.Context, imageID string, stagedURL string) (*Image, error)
 	UpdateImageWithError(ctx context.Context, imageID string, errorMsg string) (*Image, error)
+	CancelImage(ctx context.Context, imageID, userID string) (*Image, error)
+	GetImageStatusHistory(ctx context.Context, imageID, userID string) (*StatusHistory, error)
+	ExpediteImage(ctx context.Context, imageID, userID string) (*Image, error)
+	BulkCancelImages(ctx context.Context, projectID string, imageIDs []string) (*BulkImagesResponse, error)
+	BulkDeleteImages(ctx context.Context, projectID string, imageIDs []string) (*BulkImagesResponse, error)
 	DeleteImage(ctx context.Context, imageID string) error
 	GetProjectCostSummary(ctx context.Context, projectID string) (*ProjectCostSummary, error)
 	convertToImage(dbImage *queries.Image) *Image
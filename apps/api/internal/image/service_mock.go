@@ -22,15 +22,30 @@ var _ Service = &ServiceMock{}
 //			BatchCreateImagesFunc: func(ctx context.Context, reqs []CreateImageRequest) (*BatchCreateImagesResponse, error) {
 //				panic("mock out the BatchCreateImages method")
 //			},
+//			BulkCancelImagesFunc: func(ctx context.Context, projectID string, imageIDs []string) (*BulkImagesResponse, error) {
+//				panic("mock out the BulkCancelImages method")
+//			},
+//			BulkDeleteImagesFunc: func(ctx context.Context, projectID string, imageIDs []string) (*BulkImagesResponse, error) {
+//				panic("mock out the BulkDeleteImages method")
+//			},
+//			CancelImageFunc: func(ctx context.Context, imageID string, userID string) (*Image, error) {
+//				panic("mock out the CancelImage method")
+//			},
 //			CreateImageFunc: func(ctx context.Context, req *CreateImageRequest) (*Image, error) {
 //				panic("mock out the CreateImage method")
 //			},
 //			DeleteImageFunc: func(ctx context.Context, imageID string) error {
 //				panic("mock out the DeleteImage method")
 //			},
+//			ExpediteImageFunc: func(ctx context.Context, imageID string, userID string) (*Image, error) {
+//				panic("mock out the ExpediteImage method")
+//			},
 //			GetImageByIDFunc: func(ctx context.Context, imageID string) (*Image, error) {
 //				panic("mock out the GetImageByID method")
 //			},
+//			GetImageStatusHistoryFunc: func(ctx context.Context, imageID string, userID string) (*StatusHistory, error) {
+//				panic("mock out the GetImageStatusHistory method")
+//			},
 //			GetImagesByProjectIDFunc: func(ctx context.Context, projectID string) ([]*Image, error) {
 //				panic("mock out the GetImagesByProjectID method")
 //			},
@@ -59,15 +74,30 @@ type ServiceMock struct {
 	// BatchCreateImagesFunc mocks the BatchCreateImages method.
 	BatchCreateImagesFunc func(ctx context.Context, reqs []CreateImageRequest) (*BatchCreateImagesResponse, error)
 
+	// BulkCancelImagesFunc mocks the BulkCancelImages method.
+	BulkCancelImagesFunc func(ctx context.Context, projectID string, imageIDs []string) (*BulkImagesResponse, error)
+
+	// BulkDeleteImagesFunc mocks the BulkDeleteImages method.
+	BulkDeleteImagesFunc func(ctx context.Context, projectID string, imageIDs []string) (*BulkImagesResponse, error)
+
+	// CancelImageFunc mocks the CancelImage method.
+	CancelImageFunc func(ctx context.Context, imageID string, userID string) (*Image, error)
+
 	// CreateImageFunc mocks the CreateImage method.
 	CreateImageFunc func(ctx context.Context, req *CreateImageRequest) (*Image, error)
 
 	// DeleteImageFunc mocks the DeleteImage method.
 	DeleteImageFunc func(ctx context.Context, imageID string) error
 
+	// ExpediteImageFunc mocks the ExpediteImage method.
+	ExpediteImageFunc func(ctx context.Context, imageID string, userID string) (*Image, error)
+
 	// GetImageByIDFunc mocks the GetImageByID method.
 	GetImageByIDFunc func(ctx context.Context, imageID string) (*Image, error)
 
+	// GetImageStatusHistoryFunc mocks the GetImageStatusHistory method.
+	GetImageStatusHistoryFunc func(ctx context.Context, imageID string, userID string) (*StatusHistory, error)
+
 	// GetImagesByProjectIDFunc mocks the GetImagesByProjectID method.
 	GetImagesByProjectIDFunc func(ctx context.Context, projectID string) ([]*Image, error)
 
@@ -95,6 +125,33 @@ type ServiceMock struct {
 			// Reqs is the reqs argument value.
 			Reqs []CreateImageRequest
 		}
+		// BulkCancelImages holds details about calls to the BulkCancelImages method.
+		BulkCancelImages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+			// ImageIDs is the imageIDs argument value.
+			ImageIDs []string
+		}
+		// BulkDeleteImages holds details about calls to the BulkDeleteImages method.
+		BulkDeleteImages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+			// ImageIDs is the imageIDs argument value.
+			ImageIDs []string
+		}
+		// CancelImage holds details about calls to the CancelImage method.
+		CancelImage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID string
+			// UserID is the userID argument value.
+			UserID string
+		}
 		// CreateImage holds details about calls to the CreateImage method.
 		CreateImage []struct {
 			// Ctx is the ctx argument value.
@@ -109,6 +166,15 @@ type ServiceMock struct {
 			// ImageID is the imageID argument value.
 			ImageID string
 		}
+		// ExpediteImage holds details about calls to the ExpediteImage method.
+		ExpediteImage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID string
+			// UserID is the userID argument value.
+			UserID string
+		}
 		// GetImageByID holds details about calls to the GetImageByID method.
 		GetImageByID []struct {
 			// Ctx is the ctx argument value.
@@ -116,6 +182,15 @@ type ServiceMock struct {
 			// ImageID is the imageID argument value.
 			ImageID string
 		}
+		// GetImageStatusHistory holds details about calls to the GetImageStatusHistory method.
+		GetImageStatusHistory []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID string
+			// UserID is the userID argument value.
+			UserID string
+		}
 		// GetImagesByProjectID holds details about calls to the GetImagesByProjectID method.
 		GetImagesByProjectID []struct {
 			// Ctx is the ctx argument value.
@@ -164,9 +239,14 @@ type ServiceMock struct {
 		}
 	}
 	lockBatchCreateImages        sync.RWMutex
+	lockBulkCancelImages         sync.RWMutex
+	lockBulkDeleteImages         sync.RWMutex
+	lockCancelImage              sync.RWMutex
 	lockCreateImage              sync.RWMutex
 	lockDeleteImage              sync.RWMutex
+	lockExpediteImage            sync.RWMutex
 	lockGetImageByID             sync.RWMutex
+	lockGetImageStatusHistory    sync.RWMutex
 	lockGetImagesByProjectID     sync.RWMutex
 	lockGetProjectCostSummary    sync.RWMutex
 	lockUpdateImageStatus        sync.RWMutex
@@ -211,6 +291,126 @@ func (mock *ServiceMock) BatchCreateImagesCalls() []struct {
 	return calls
 }
 
+// BulkCancelImages calls BulkCancelImagesFunc.
+func (mock *ServiceMock) BulkCancelImages(ctx context.Context, projectID string, imageIDs []string) (*BulkImagesResponse, error) {
+	if mock.BulkCancelImagesFunc == nil {
+		panic("ServiceMock.BulkCancelImagesFunc: method is nil but Service.BulkCancelImages was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+		ImageIDs  []string
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+		ImageIDs:  imageIDs,
+	}
+	mock.lockBulkCancelImages.Lock()
+	mock.calls.BulkCancelImages = append(mock.calls.BulkCancelImages, callInfo)
+	mock.lockBulkCancelImages.Unlock()
+	return mock.BulkCancelImagesFunc(ctx, projectID, imageIDs)
+}
+
+// BulkCancelImagesCalls gets all the calls that were made to BulkCancelImages.
+// Check the length with:
+//
+//	len(mockedService.BulkCancelImagesCalls())
+func (mock *ServiceMock) BulkCancelImagesCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+	ImageIDs  []string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+		ImageIDs  []string
+	}
+	mock.lockBulkCancelImages.RLock()
+	calls = mock.calls.BulkCancelImages
+	mock.lockBulkCancelImages.RUnlock()
+	return calls
+}
+
+// BulkDeleteImages calls BulkDeleteImagesFunc.
+func (mock *ServiceMock) BulkDeleteImages(ctx context.Context, projectID string, imageIDs []string) (*BulkImagesResponse, error) {
+	if mock.BulkDeleteImagesFunc == nil {
+		panic("ServiceMock.BulkDeleteImagesFunc: method is nil but Service.BulkDeleteImages was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+		ImageIDs  []string
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+		ImageIDs:  imageIDs,
+	}
+	mock.lockBulkDeleteImages.Lock()
+	mock.calls.BulkDeleteImages = append(mock.calls.BulkDeleteImages, callInfo)
+	mock.lockBulkDeleteImages.Unlock()
+	return mock.BulkDeleteImagesFunc(ctx, projectID, imageIDs)
+}
+
+// BulkDeleteImagesCalls gets all the calls that were made to BulkDeleteImages.
+// Check the length with:
+//
+//	len(mockedService.BulkDeleteImagesCalls())
+func (mock *ServiceMock) BulkDeleteImagesCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+	ImageIDs  []string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+		ImageIDs  []string
+	}
+	mock.lockBulkDeleteImages.RLock()
+	calls = mock.calls.BulkDeleteImages
+	mock.lockBulkDeleteImages.RUnlock()
+	return calls
+}
+
+// CancelImage calls CancelImageFunc.
+func (mock *ServiceMock) CancelImage(ctx context.Context, imageID string, userID string) (*Image, error) {
+	if mock.CancelImageFunc == nil {
+		panic("ServiceMock.CancelImageFunc: method is nil but Service.CancelImage was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ImageID string
+		UserID  string
+	}{
+		Ctx:     ctx,
+		ImageID: imageID,
+		UserID:  userID,
+	}
+	mock.lockCancelImage.Lock()
+	mock.calls.CancelImage = append(mock.calls.CancelImage, callInfo)
+	mock.lockCancelImage.Unlock()
+	return mock.CancelImageFunc(ctx, imageID, userID)
+}
+
+// CancelImageCalls gets all the calls that were made to CancelImage.
+// Check the length with:
+//
+//	len(mockedService.CancelImageCalls())
+func (mock *ServiceMock) CancelImageCalls() []struct {
+	Ctx     context.Context
+	ImageID string
+	UserID  string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ImageID string
+		UserID  string
+	}
+	mock.lockCancelImage.RLock()
+	calls = mock.calls.CancelImage
+	mock.lockCancelImage.RUnlock()
+	return calls
+}
+
 // CreateImage calls CreateImageFunc.
 func (mock *ServiceMock) CreateImage(ctx context.Context, req *CreateImageRequest) (*Image, error) {
 	if mock.CreateImageFunc == nil {
@@ -283,6 +483,46 @@ func (mock *ServiceMock) DeleteImageCalls() []struct {
 	return calls
 }
 
+// ExpediteImage calls ExpediteImageFunc.
+func (mock *ServiceMock) ExpediteImage(ctx context.Context, imageID string, userID string) (*Image, error) {
+	if mock.ExpediteImageFunc == nil {
+		panic("ServiceMock.ExpediteImageFunc: method is nil but Service.ExpediteImage was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ImageID string
+		UserID  string
+	}{
+		Ctx:     ctx,
+		ImageID: imageID,
+		UserID:  userID,
+	}
+	mock.lockExpediteImage.Lock()
+	mock.calls.ExpediteImage = append(mock.calls.ExpediteImage, callInfo)
+	mock.lockExpediteImage.Unlock()
+	return mock.ExpediteImageFunc(ctx, imageID, userID)
+}
+
+// ExpediteImageCalls gets all the calls that were made to ExpediteImage.
+// Check the length with:
+//
+//	len(mockedService.ExpediteImageCalls())
+func (mock *ServiceMock) ExpediteImageCalls() []struct {
+	Ctx     context.Context
+	ImageID string
+	UserID  string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ImageID string
+		UserID  string
+	}
+	mock.lockExpediteImage.RLock()
+	calls = mock.calls.ExpediteImage
+	mock.lockExpediteImage.RUnlock()
+	return calls
+}
+
 // GetImageByID calls GetImageByIDFunc.
 func (mock *ServiceMock) GetImageByID(ctx context.Context, imageID string) (*Image, error) {
 	if mock.GetImageByIDFunc == nil {
@@ -319,6 +559,46 @@ func (mock *ServiceMock) GetImageByIDCalls() []struct {
 	return calls
 }
 
+// GetImageStatusHistory calls GetImageStatusHistoryFunc.
+func (mock *ServiceMock) GetImageStatusHistory(ctx context.Context, imageID string, userID string) (*StatusHistory, error) {
+	if mock.GetImageStatusHistoryFunc == nil {
+		panic("ServiceMock.GetImageStatusHistoryFunc: method is nil but Service.GetImageStatusHistory was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ImageID string
+		UserID  string
+	}{
+		Ctx:     ctx,
+		ImageID: imageID,
+		UserID:  userID,
+	}
+	mock.lockGetImageStatusHistory.Lock()
+	mock.calls.GetImageStatusHistory = append(mock.calls.GetImageStatusHistory, callInfo)
+	mock.lockGetImageStatusHistory.Unlock()
+	return mock.GetImageStatusHistoryFunc(ctx, imageID, userID)
+}
+
+// GetImageStatusHistoryCalls gets all the calls that were made to GetImageStatusHistory.
+// Check the length with:
+//
+//	len(mockedService.GetImageStatusHistoryCalls())
+func (mock *ServiceMock) GetImageStatusHistoryCalls() []struct {
+	Ctx     context.Context
+	ImageID string
+	UserID  string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ImageID string
+		UserID  string
+	}
+	mock.lockGetImageStatusHistory.RLock()
+	calls = mock.calls.GetImageStatusHistory
+	mock.lockGetImageStatusHistory.RUnlock()
+	return calls
+}
+
 // GetImagesByProjectID calls GetImagesByProjectIDFunc.
 func (mock *ServiceMock) GetImagesByProjectID(ctx context.Context, projectID string) ([]*Image, error) {
 	if mock.GetImagesByProjectIDFunc == nil {
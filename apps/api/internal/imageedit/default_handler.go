@@ -0,0 +1,126 @@
+package imageedit
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/usage"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// DefaultHandler serves image edits over HTTP.
+type DefaultHandler struct {
+	service  Service
+	userRepo user.Repository
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, userRepo user.Repository) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo}
+}
+
+// ErrorResponse is a simple JSON error envelope for handler responses.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// Create handles POST /api/v1/images/:id/edits. The edit runs in the background; clients
+// poll GET /api/v1/images/:id/edits/:edit_id until it is ready.
+func (h *DefaultHandler) Create(c echo.Context) error {
+	imageID := c.Param("id")
+	if _, err := uuid.Parse(imageID); err != nil {
+		return badRequest(c, "Invalid image ID format")
+	}
+	var req CreateRequest
+	if err := c.Bind(&req); err != nil {
+		return badRequest(c, "Invalid request format")
+	}
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	edit, err := h.service.Create(c.Request().Context(), imageID, userID, req)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusAccepted, edit)
+}
+
+// Get handles GET /api/v1/images/:id/edits/:edit_id.
+func (h *DefaultHandler) Get(c echo.Context) error {
+	imageID := c.Param("id")
+	if _, err := uuid.Parse(imageID); err != nil {
+		return badRequest(c, "Invalid image ID format")
+	}
+	editID := c.Param("edit_id")
+	if _, err := uuid.Parse(editID); err != nil {
+		return badRequest(c, "Invalid edit ID format")
+	}
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	edit, err := h.service.Get(c.Request().Context(), imageID, editID, userID)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, edit)
+}
+
+func badRequest(c echo.Context, message string) error {
+	return c.JSON(http.StatusBadRequest, ErrorResponse{
+		Error:   "bad_request",
+		Message: message,
+	})
+}
+
+func unauthorized(c echo.Context) error {
+	return c.JSON(http.StatusUnauthorized, ErrorResponse{
+		Error:   "unauthorized",
+		Message: "Unable to resolve current user",
+	})
+}
+
+// writeError maps service errors to responses.
+func (h *DefaultHandler) writeError(c echo.Context, err error) error {
+	var quotaErr *usage.QuotaError
+	switch {
+	case errors.As(err, &quotaErr):
+		return usage.WriteQuotaExceeded(c, quotaErr)
+	case errors.Is(err, ErrInvalid):
+		return c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Error:   "validation_failed",
+			Message: err.Error(),
+		})
+	case errors.Is(err, ErrNotFound):
+		return c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "not_found",
+			Message: "Image or edit not found",
+		})
+	case errors.Is(err, ErrForbidden):
+		return c.JSON(http.StatusForbidden, ErrorResponse{
+			Error:   "forbidden",
+			Message: "Viewers cannot edit the project's images",
+		})
+	case errors.Is(err, ErrNotEditable):
+		return c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "conflict",
+			Message: "Image has no staged output to edit yet",
+		})
+	default:
+		c.Logger().Errorf("Image edit request failed: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to process image edit request",
+		})
+	}
+}
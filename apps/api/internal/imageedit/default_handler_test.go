@@ -0,0 +1,172 @@
+package imageedit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/usage"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func newUserRepo(userID uuid.UUID) *user.RepositoryMock {
+	return &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+		},
+	}
+}
+
+func TestDefaultHandler_Create(t *testing.T) {
+	imageID := uuid.NewString()
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		imageID        string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{
+			name:           "success: edit accepted",
+			imageID:        imageID,
+			body:           `{"kind":"erase","mask_file_key":"uploads/u/mask.png"}`,
+			expectedStatus: http.StatusAccepted,
+		},
+		{name: "fail: invalid image ID", imageID: "nope", body: `{}`, expectedStatus: http.StatusBadRequest},
+		{name: "fail: malformed body", imageID: imageID, body: `{`, expectedStatus: http.StatusBadRequest},
+		{
+			name:           "fail: invalid request",
+			imageID:        imageID,
+			body:           `{"kind":"recolor"}`,
+			err:            fmt.Errorf("%w: kind must be \"erase\"", ErrInvalid),
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "fail: image not found",
+			imageID:        imageID,
+			body:           `{"kind":"erase"}`,
+			err:            ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "fail: viewer cannot edit",
+			imageID:        imageID,
+			body:           `{"kind":"erase"}`,
+			err:            ErrForbidden,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "fail: owner has no plan left",
+			imageID:        imageID,
+			body:           `{"kind":"erase"}`,
+			err:            &usage.QuotaError{Usage: usage.Usage{Limit: 10, Used: 10}, Requested: 1},
+			expectedStatus: http.StatusPaymentRequired,
+		},
+		{
+			name:           "fail: image not staged yet",
+			imageID:        imageID,
+			body:           `{"kind":"erase"}`,
+			err:            ErrNotEditable,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "fail: service error",
+			imageID:        imageID,
+			body:           `{"kind":"erase"}`,
+			err:            errors.New("db down"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				CreateFunc: func(ctx context.Context, id, uid string, req CreateRequest) (*Edit, error) {
+					assert.Equal(t, tc.imageID, id)
+					assert.Equal(t, userID.String(), uid)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &Edit{ID: "e1", ImageID: id, Kind: req.Kind, Status: StatusQueued}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, newUserRepo(userID))
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("X-Test-User", "auth0|user")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.imageID)
+
+			require.NoError(t, h.Create(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusAccepted {
+				assert.Contains(t, rec.Body.String(), `"status":"queued"`)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_Get(t *testing.T) {
+	imageID := uuid.NewString()
+	editID := uuid.NewString()
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		editID         string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: edit returned", editID: editID, expectedStatus: http.StatusOK},
+		{name: "fail: invalid edit ID", editID: "nope", expectedStatus: http.StatusBadRequest},
+		{name: "fail: not found", editID: editID, err: ErrNotFound, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				GetFunc: func(ctx context.Context, iid, eid, uid string) (*Edit, error) {
+					assert.Equal(t, imageID, iid)
+					assert.Equal(t, userID.String(), uid)
+					assert.Equal(t, tc.editID, eid)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &Edit{ID: eid, ImageID: iid, Status: StatusReady, DownloadURL: "https://signed"}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, newUserRepo(userID))
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("X-Test-User", "auth0|user")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id", "edit_id")
+			c.SetParamValues(imageID, tc.editID)
+
+			require.NoError(t, h.Get(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Contains(t, rec.Body.String(), `"download_url":"https://signed"`)
+			}
+		})
+	}
+}
@@ -0,0 +1,297 @@
+package imageedit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/entitlement"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/usage"
+)
+
+// DefaultService implements Service.
+type DefaultService struct {
+	querier  queries.Querier
+	enqueuer queue.EditEnqueuer
+	// buckets checks mask uploads and presigns results. Nil fails every create request.
+	buckets storage.Buckets
+	// usage counts each edit against the project owner's monthly limit. Nil skips metering.
+	usage usage.Service
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService with a database. Edits are queued through
+// Redis when it is configured and dropped otherwise, and metered against the owner's plan
+// through entitlements when it is not nil.
+func NewDefaultService(
+	cfg *config.Config, db storage.Database, buckets storage.Buckets, entitlements entitlement.Service,
+) *DefaultService {
+	var enq queue.EditEnqueuer
+	if e, err := queue.NewAsynqEditEnqueuerFromConfig(cfg); err == nil {
+		enq = e
+	} else {
+		enq = queue.NoopEditEnqueuer{}
+	}
+	return &DefaultService{
+		querier:  queries.New(db),
+		enqueuer: enq,
+		buckets:  buckets,
+		usage:    usage.NewDefaultService(db, entitlements, cfg.Usage),
+	}
+}
+
+// NewDefaultServiceWithQuerier creates a new DefaultService with a custom querier, enqueuer
+// and usage service (for testing).
+func NewDefaultServiceWithQuerier(
+	querier queries.Querier, enqueuer queue.EditEnqueuer, buckets storage.Buckets, usage usage.Service,
+) *DefaultService {
+	return &DefaultService{querier: querier, enqueuer: enqueuer, buckets: buckets, usage: usage}
+}
+
+// Create records an edit of the image for userID and queues it. Only owners and editors of
+// the image's project may edit it, and each edit counts against the project owner's monthly
+// limit. The mask must be the project owner's own upload, and a staged base needs the image
+// to be ready.
+func (s *DefaultService) Create(ctx context.Context, imageID, userID string, req CreateRequest) (*Edit, error) {
+	id, err := parseUUID(imageID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image ID: %w", err)
+	}
+	if req.Kind != KindErase {
+		return nil, fmt.Errorf("%w: kind must be %q", ErrInvalid, KindErase)
+	}
+	if req.Base == "" {
+		req.Base = BaseStaged
+	}
+	if req.Base != BaseStaged && req.Base != BaseOriginal {
+		return nil, fmt.Errorf("%w: base must be %q or %q", ErrInvalid, BaseStaged, BaseOriginal)
+	}
+	if req.MaskFileKey == "" {
+		return nil, fmt.Errorf("%w: mask_file_key is required", ErrInvalid)
+	}
+
+	src, err := s.querier.GetImageEditSource(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image: %w", err)
+	}
+	if err := s.authorize(ctx, src.ProjectID, userID, true); err != nil {
+		return nil, err
+	}
+	baseURL := src.OriginalUrl
+	if req.Base == BaseStaged {
+		if src.Status != queries.ImageStatusReady || !src.StagedUrl.Valid || src.StagedUrl.String == "" {
+			return nil, fmt.Errorf("%w: image has no staged output", ErrNotEditable)
+		}
+		baseURL = src.StagedUrl.String
+	}
+
+	ownerID := uuid.UUID(src.UserID.Bytes).String()
+	maskURL, err := s.maskURL(ctx, ownerID, req.MaskFileKey)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := s.reserveUsage(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.querier.CreateImageEdit(ctx, queries.CreateImageEditParams{
+		ImageID: id,
+		Kind:    req.Kind,
+		Base:    req.Base,
+		MaskUrl: maskURL,
+	})
+	if err != nil {
+		release()
+		return nil, fmt.Errorf("failed to create image edit: %w", err)
+	}
+	edit := toEdit(row)
+
+	if _, err := s.enqueuer.EnqueueEdit(ctx, queue.TaskTypeEditErase, queue.EditPayload{
+		EditID:  edit.ID,
+		ImageID: edit.ImageID,
+		Base:    req.Base,
+		BaseURL: baseURL,
+		MaskURL: maskURL,
+		Sandbox: req.Sandbox,
+	}); err != nil {
+		// Leave no edit queued that no worker will pick up.
+		failErr := s.querier.FailImageEdit(ctx, queries.FailImageEditParams{
+			ID:    row.ID,
+			Error: pgtype.Text{String: "failed to queue edit", Valid: true},
+		})
+		if failErr != nil {
+			logging.NewDefaultLogger().Error(ctx, "failed to mark image edit failed", "edit_id", edit.ID, "error", failErr)
+		}
+		release()
+		return nil, fmt.Errorf("failed to enqueue image edit: %w", err)
+	}
+	return &edit, nil
+}
+
+// Get returns one of the image's edits to a member of its project. Once the edit is ready it
+// carries a presigned download URL of the result.
+func (s *DefaultService) Get(ctx context.Context, imageID, editID, userID string) (*Edit, error) {
+	iid, err := parseUUID(imageID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image ID: %w", err)
+	}
+	eid, err := parseUUID(editID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid edit ID: %w", err)
+	}
+	src, err := s.querier.GetImageEditSource(ctx, iid)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image: %w", err)
+	}
+	if err := s.authorize(ctx, src.ProjectID, userID, false); err != nil {
+		return nil, err
+	}
+
+	row, err := s.querier.GetImageEdit(ctx, queries.GetImageEditParams{ID: eid, ImageID: iid})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image edit: %w", err)
+	}
+	edit := toEdit(row)
+
+	if edit.Status == StatusReady && edit.ResultURL != nil && s.buckets != nil {
+		files, key, err := s.buckets.ForURL(ctx, *edit.ResultURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve image edit storage: %w", err)
+		}
+		signed, err := files.GeneratePresignedGetURL(ctx, key, int64(downloadURLTTL.Seconds()), "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to presign image edit: %w", err)
+		}
+		edit.DownloadURL = signed
+	}
+	return &edit, nil
+}
+
+// authorize checks that userID is a member of the project, and an owner or editor when write
+// is set. Non-members get ErrNotFound, so image IDs of other projects are not revealed.
+func (s *DefaultService) authorize(ctx context.Context, projectID pgtype.UUID, userID string, write bool) error {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+	member, err := s.querier.GetProjectByIDForMember(ctx, queries.GetProjectByIDForMemberParams{
+		ID:     projectID,
+		UserID: uid,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check project access: %w", err)
+	}
+	if write && member.Role == project.RoleViewer {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// reserveUsage counts one edit against ownerID's monthly limit, failing with a
+// *usage.QuotaError once it is used up. The returned func gives the edit back when it
+// could not be queued. Sandbox tenants are not metered.
+func (s *DefaultService) reserveUsage(ctx context.Context, ownerID string) (func(), error) {
+	if s.usage == nil || storage.SandboxFrom(ctx) {
+		return func() {}, nil
+	}
+	u, err := s.usage.Reserve(ctx, ownerID, 1)
+	if err != nil {
+		return nil, err
+	}
+	return func() {
+		ctx := context.WithoutCancel(ctx)
+		if err := s.usage.Release(ctx, ownerID, u.PeriodStart, 1); err != nil {
+			logging.NewDefaultLogger().Error(ctx, "failed to release usage", "user_id", ownerID, "error", err)
+		}
+	}, nil
+}
+
+// maskURL checks that key is ownerID's own upload of an allowed type and size, returning
+// its s3:// URL.
+func (s *DefaultService) maskURL(ctx context.Context, ownerID, key string) (string, error) {
+	// Presigned uploads are keyed under the uploader's ID; see storage.GeneratePresignedUploadURL.
+	ownerPrefix := storage.UploadPrefix(ctx, ownerID)
+	if !strings.HasPrefix(key, ownerPrefix) || strings.Contains(key, "..") {
+		return "", fmt.Errorf("%w: mask is not an upload by the project owner", ErrInvalid)
+	}
+
+	if s.buckets == nil {
+		return "", errors.New("image edits are unavailable: storage is not configured")
+	}
+	files, err := s.buckets.ForUser(ctx, ownerID)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve mask storage: %w", err)
+	}
+	head, err := files.HeadFile(ctx, key)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return "", fmt.Errorf("%w: mask upload not found", ErrInvalid)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to check mask: %w", err)
+	}
+	if obj, ok := head.(*s3.HeadObjectOutput); ok {
+		if !storage.ValidateContentType(aws.ToString(obj.ContentType)) {
+			return "", fmt.Errorf("%w: mask must be a JPEG, PNG or WebP image", ErrInvalid)
+		}
+		if !storage.ValidateFileSize(aws.ToInt64(obj.ContentLength)) {
+			return "", fmt.Errorf("%w: mask must be 10MB or smaller", ErrInvalid)
+		}
+	}
+
+	return fmt.Sprintf("s3://%s/%s", files.BucketName(), key), nil
+}
+
+func parseUUID(id string) (pgtype.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+	return pgtype.UUID{Bytes: parsed, Valid: true}, nil
+}
+
+func toEdit(row *queries.ImageEdit) Edit {
+	e := Edit{
+		ID:        uuid.UUID(row.ID.Bytes).String(),
+		ImageID:   uuid.UUID(row.ImageID.Bytes).String(),
+		Kind:      row.Kind,
+		Base:      row.Base,
+		Status:    row.Status,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+	if row.ResultUrl.Valid {
+		e.ResultURL = &row.ResultUrl.String
+	}
+	if row.Error.Valid {
+		e.Error = &row.Error.String
+	}
+	return e
+}
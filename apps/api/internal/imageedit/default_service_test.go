@@ -0,0 +1,361 @@
+package imageedit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/usage"
+)
+
+// memberQuerier answers project membership as role, or as a non-member when role is "".
+func memberQuerier(querier *queries.QuerierMock, userID uuid.UUID, role string) *queries.QuerierMock {
+	querier.GetProjectByIDForMemberFunc = func(
+		ctx context.Context, arg queries.GetProjectByIDForMemberParams,
+	) (*queries.GetProjectByIDForMemberRow, error) {
+		if uuid.UUID(arg.UserID.Bytes) != userID || role == "" {
+			return nil, pgx.ErrNoRows
+		}
+		return &queries.GetProjectByIDForMemberRow{ID: arg.ID, Role: role}, nil
+	}
+	return querier
+}
+
+func TestDefaultService_Create(t *testing.T) {
+	imageID := uuid.New()
+	editID := uuid.New()
+	ownerID := uuid.New()
+	userID := uuid.New()
+	periodStart := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	maskKey := fmt.Sprintf("uploads/%s/mask.png", ownerID)
+	png := &s3.HeadObjectOutput{ContentType: aws.String("image/png"), ContentLength: aws.Int64(4 << 10)}
+	ready := &queries.GetImageEditSourceRow{
+		OriginalUrl: "s3://bucket/uploads/original.jpg",
+		StagedUrl:   pgtype.Text{String: "s3://bucket/staged/abc-staged.jpg", Valid: true},
+		Status:      queries.ImageStatusReady,
+		ProjectID:   pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		UserID:      pgtype.UUID{Bytes: ownerID, Valid: true},
+	}
+	processing := &queries.GetImageEditSourceRow{
+		OriginalUrl: ready.OriginalUrl,
+		Status:      queries.ImageStatusProcessing,
+		ProjectID:   ready.ProjectID,
+		UserID:      ready.UserID,
+	}
+
+	testCases := []struct {
+		name string
+		// role is the caller's project role, owner when empty.
+		role        string
+		notMember   bool
+		sandbox     bool
+		reserveErr  error
+		req         CreateRequest
+		source      *queries.GetImageEditSourceRow
+		sourceErr   error
+		head        any
+		headErr     error
+		enqueueErr  error
+		wantBaseURL string
+		wantErr     error
+		errSubstr   string
+	}{
+		{
+			name:        "success: staged base is the default",
+			req:         CreateRequest{Kind: KindErase, MaskFileKey: maskKey},
+			source:      ready,
+			head:        png,
+			wantBaseURL: ready.StagedUrl.String,
+		},
+		{
+			name:        "success: original base of an image still processing",
+			req:         CreateRequest{Kind: KindErase, MaskFileKey: maskKey, Base: BaseOriginal},
+			source:      processing,
+			head:        png,
+			wantBaseURL: ready.OriginalUrl,
+		},
+		{
+			name:        "success: editor edits a collaborator's image",
+			role:        project.RoleEditor,
+			req:         CreateRequest{Kind: KindErase, MaskFileKey: maskKey},
+			source:      ready,
+			head:        png,
+			wantBaseURL: ready.StagedUrl.String,
+		},
+		{
+			name:        "success: sandbox tenants are not metered",
+			sandbox:     true,
+			req:         CreateRequest{Kind: KindErase, MaskFileKey: storage.SandboxKeyPrefix + maskKey},
+			source:      ready,
+			head:        png,
+			wantBaseURL: ready.StagedUrl.String,
+		},
+		{
+			name:    "fail: viewer cannot edit",
+			role:    project.RoleViewer,
+			req:     CreateRequest{Kind: KindErase, MaskFileKey: maskKey},
+			source:  ready,
+			wantErr: ErrForbidden,
+		},
+		{
+			name:      "fail: not a project member",
+			notMember: true,
+			req:       CreateRequest{Kind: KindErase, MaskFileKey: maskKey},
+			source:    ready,
+			wantErr:   ErrNotFound,
+		},
+		{
+			name:       "fail: owner's monthly limit used up",
+			req:        CreateRequest{Kind: KindErase, MaskFileKey: maskKey},
+			source:     ready,
+			head:       png,
+			reserveErr: &usage.QuotaError{Usage: usage.Usage{Plan: "pro", Limit: 10, Used: 10}, Requested: 1},
+			wantErr:    usage.ErrQuotaExceeded,
+		},
+		{name: "fail: unsupported kind", req: CreateRequest{Kind: "recolor", MaskFileKey: maskKey}, wantErr: ErrInvalid},
+		{
+			name:    "fail: unsupported base",
+			req:     CreateRequest{Kind: KindErase, MaskFileKey: maskKey, Base: "preview"},
+			wantErr: ErrInvalid,
+		},
+		{name: "fail: mask missing", req: CreateRequest{Kind: KindErase}, wantErr: ErrInvalid},
+		{
+			name:      "fail: image not found",
+			req:       CreateRequest{Kind: KindErase, MaskFileKey: maskKey},
+			sourceErr: pgx.ErrNoRows,
+			wantErr:   ErrNotFound,
+		},
+		{
+			name:    "fail: staged base before the image is ready",
+			req:     CreateRequest{Kind: KindErase, MaskFileKey: maskKey},
+			source:  processing,
+			wantErr: ErrNotEditable,
+		},
+		{
+			name:    "fail: another user's upload",
+			req:     CreateRequest{Kind: KindErase, MaskFileKey: fmt.Sprintf("uploads/%s/mask.png", uuid.New())},
+			source:  ready,
+			wantErr: ErrInvalid,
+		},
+		{
+			name:    "fail: path traversal out of the owner's uploads",
+			req:     CreateRequest{Kind: KindErase, MaskFileKey: fmt.Sprintf("uploads/%s/../x/mask.png", ownerID)},
+			source:  ready,
+			wantErr: ErrInvalid,
+		},
+		{
+			name:    "fail: mask upload missing",
+			req:     CreateRequest{Kind: KindErase, MaskFileKey: maskKey},
+			source:  ready,
+			headErr: storage.ErrObjectNotFound,
+			wantErr: ErrInvalid,
+		},
+		{
+			name:    "fail: mask not an image",
+			req:     CreateRequest{Kind: KindErase, MaskFileKey: maskKey},
+			source:  ready,
+			head:    &s3.HeadObjectOutput{ContentType: aws.String("image/gif"), ContentLength: aws.Int64(1024)},
+			wantErr: ErrInvalid,
+		},
+		{
+			name:       "fail: enqueue error marks the edit failed",
+			req:        CreateRequest{Kind: KindErase, MaskFileKey: maskKey},
+			source:     ready,
+			head:       png,
+			enqueueErr: errors.New("redis down"),
+			errSubstr:  "failed to enqueue image edit",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			role := tc.role
+			switch {
+			case tc.notMember:
+				role = ""
+			case role == "":
+				role = project.RoleOwner
+			}
+			querier := memberQuerier(&queries.QuerierMock{
+				GetImageEditSourceFunc: func(ctx context.Context, id pgtype.UUID) (*queries.GetImageEditSourceRow, error) {
+					assert.Equal(t, imageID, uuid.UUID(id.Bytes))
+					return tc.source, tc.sourceErr
+				},
+				CreateImageEditFunc: func(ctx context.Context, arg queries.CreateImageEditParams) (*queries.ImageEdit, error) {
+					return &queries.ImageEdit{
+						ID:      pgtype.UUID{Bytes: editID, Valid: true},
+						ImageID: arg.ImageID,
+						Kind:    arg.Kind,
+						Base:    arg.Base,
+						MaskUrl: arg.MaskUrl,
+						Status:  StatusQueued,
+					}, nil
+				},
+				FailImageEditFunc: func(ctx context.Context, arg queries.FailImageEditParams) error {
+					return nil
+				},
+			}, userID, role)
+			usageSvc := &usage.ServiceMock{
+				ReserveFunc: func(ctx context.Context, id string, n int) (*usage.Usage, error) {
+					assert.Equal(t, ownerID.String(), id)
+					if tc.reserveErr != nil {
+						return nil, tc.reserveErr
+					}
+					return &usage.Usage{PeriodStart: periodStart}, nil
+				},
+				ReleaseFunc: func(ctx context.Context, id string, start time.Time, n int) error {
+					return nil
+				},
+			}
+			enqueuer := &queue.EditEnqueuerMock{
+				EnqueueEditFunc: func(ctx context.Context, taskType string, payload queue.EditPayload) (string, error) {
+					return payload.EditID, tc.enqueueErr
+				},
+			}
+			buckets := storage.NewPlatformBuckets(&storage.S3ServiceMock{
+				HeadFileFunc: func(ctx context.Context, fileKey string) (interface{}, error) {
+					return tc.head, tc.headErr
+				},
+				BucketNameFunc: func() string { return "bucket" },
+			})
+			svc := NewDefaultServiceWithQuerier(querier, enqueuer, buckets, usageSvc)
+
+			ctx := context.Background()
+			if tc.sandbox {
+				ctx = storage.WithSandbox(ctx)
+			}
+			edit, err := svc.Create(ctx, imageID.String(), userID.String(), tc.req)
+			switch {
+			case tc.wantErr != nil:
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, querier.CreateImageEditCalls())
+				return
+			case tc.errSubstr != "":
+				assert.ErrorContains(t, err, tc.errSubstr)
+				require.Len(t, querier.FailImageEditCalls(), 1)
+				assert.Equal(t, editID, uuid.UUID(querier.FailImageEditCalls()[0].Arg.ID.Bytes))
+				// The edit that never ran is given back to the owner's usage.
+				require.Len(t, usageSvc.ReleaseCalls(), 1)
+				assert.Equal(t, periodStart, usageSvc.ReleaseCalls()[0].PeriodStart)
+				assert.Equal(t, 1, usageSvc.ReleaseCalls()[0].N)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, editID.String(), edit.ID)
+			assert.Equal(t, StatusQueued, edit.Status)
+			if tc.sandbox {
+				assert.Empty(t, usageSvc.ReserveCalls())
+			} else {
+				require.Len(t, usageSvc.ReserveCalls(), 1)
+				assert.Equal(t, 1, usageSvc.ReserveCalls()[0].N)
+			}
+			assert.Empty(t, usageSvc.ReleaseCalls())
+
+			require.Len(t, enqueuer.EnqueueEditCalls(), 1)
+			call := enqueuer.EnqueueEditCalls()[0]
+			assert.Equal(t, queue.TaskTypeEditErase, call.TaskType)
+			assert.Equal(t, queue.EditPayload{
+				EditID:  editID.String(),
+				ImageID: imageID.String(),
+				Base:    edit.Base,
+				BaseURL: tc.wantBaseURL,
+				MaskURL: "s3://bucket/" + tc.req.MaskFileKey,
+			}, call.Payload)
+		})
+	}
+}
+
+func TestDefaultService_Get(t *testing.T) {
+	imageID := uuid.New()
+	editID := uuid.New()
+	userID := uuid.New()
+
+	testCases := []struct {
+		name string
+		// role is the caller's project role, or "" for a non-member.
+		role         string
+		row          *queries.ImageEdit
+		err          error
+		wantDownload string
+		wantErr      error
+	}{
+		{
+			name: "success: ready edit carries a download URL",
+			role: project.RoleOwner,
+			row: &queries.ImageEdit{
+				Status:    StatusReady,
+				ResultUrl: pgtype.Text{String: "s3://bucket/staged/abc/abc-edit-1", Valid: true},
+			},
+			wantDownload: "https://signed.example.com/staged/abc/abc-edit-1",
+		},
+		{name: "success: viewer reads a queued edit", role: project.RoleViewer, row: &queries.ImageEdit{Status: StatusQueued}},
+		{
+			name: "success: failed edit carries its error",
+			role: project.RoleEditor,
+			row:  &queries.ImageEdit{Status: StatusError, Error: pgtype.Text{String: "prediction failed", Valid: true}},
+		},
+		{name: "fail: not found", role: project.RoleOwner, err: pgx.ErrNoRows, wantErr: ErrNotFound},
+		{name: "fail: not a project member", row: &queries.ImageEdit{Status: StatusQueued}, wantErr: ErrNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			querier := memberQuerier(&queries.QuerierMock{
+				GetImageEditSourceFunc: func(ctx context.Context, id pgtype.UUID) (*queries.GetImageEditSourceRow, error) {
+					assert.Equal(t, imageID, uuid.UUID(id.Bytes))
+					return &queries.GetImageEditSourceRow{ProjectID: pgtype.UUID{Bytes: uuid.New(), Valid: true}}, nil
+				},
+				GetImageEditFunc: func(ctx context.Context, arg queries.GetImageEditParams) (*queries.ImageEdit, error) {
+					assert.Equal(t, editID, uuid.UUID(arg.ID.Bytes))
+					assert.Equal(t, imageID, uuid.UUID(arg.ImageID.Bytes))
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					row := *tc.row
+					row.ID, row.ImageID = arg.ID, arg.ImageID
+					return &row, nil
+				},
+			}, userID, tc.role)
+			buckets := storage.NewPlatformBuckets(&storage.S3ServiceMock{
+				BucketNameFunc: func() string { return "real-staging" },
+				GeneratePresignedGetURLFunc: func(
+					ctx context.Context, fileKey string, expiresIn int64, contentDisposition string,
+				) (string, error) {
+					return "https://signed.example.com/" + fileKey, nil
+				},
+			})
+			svc := NewDefaultServiceWithQuerier(querier, queue.NoopEditEnqueuer{}, buckets, nil)
+
+			edit, err := svc.Get(context.Background(), imageID.String(), editID.String(), userID.String())
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				if tc.role == "" {
+					assert.Empty(t, querier.GetImageEditCalls())
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, editID.String(), edit.ID)
+			assert.Equal(t, tc.row.Status, edit.Status)
+			assert.Equal(t, tc.wantDownload, edit.DownloadURL)
+			if tc.row.Error.Valid {
+				require.NotNil(t, edit.Error)
+				assert.Equal(t, tc.row.Error.String, *edit.Error)
+			}
+		})
+	}
+}
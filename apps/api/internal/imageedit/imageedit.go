@@ -0,0 +1,75 @@
+// Package imageedit manages quick edits of an image, such as erasing an object under a
+// mask. Edits run on their own queue with a fast inpainting model, apart from staging jobs,
+// and leave the image's staged output unchanged.
+package imageedit
+
+import (
+	"errors"
+	"time"
+)
+
+// Kinds of edit.
+const (
+	// KindErase removes whatever the mask covers and fills the area in.
+	KindErase = "erase"
+)
+
+// Bases an edit can start from.
+const (
+	BaseOriginal = "original"
+	BaseStaged   = "staged"
+)
+
+// Edit statuses.
+const (
+	StatusQueued     = "queued"
+	StatusProcessing = "processing"
+	StatusReady      = "ready"
+	StatusError      = "error"
+)
+
+// downloadURLTTL is how long the presigned URL of a ready edit stays valid.
+const downloadURLTTL = 10 * time.Minute
+
+var (
+	// ErrNotFound is returned when the image or the edit does not exist, or the caller is
+	// not a member of the image's project.
+	ErrNotFound = errors.New("image edit not found")
+	// ErrForbidden is returned when a project viewer tries to edit an image.
+	ErrForbidden = errors.New("viewers cannot edit images")
+	// ErrInvalid wraps validation failures of a create request.
+	ErrInvalid = errors.New("invalid image edit")
+	// ErrNotEditable is returned when the requested base does not exist yet, such as the
+	// staged output of an image that is still processing.
+	ErrNotEditable = errors.New("image cannot be edited yet")
+)
+
+// Edit is a quick edit of an image.
+type Edit struct {
+	ID      string `json:"id"`
+	ImageID string `json:"image_id"`
+	Kind    string `json:"kind"`
+	Base    string `json:"base"`
+	Status  string `json:"status"`
+	// ResultURL is the stored URL of the edited image, set once the edit is ready.
+	ResultURL *string `json:"result_url,omitempty"`
+	// DownloadURL is a short-lived presigned URL of the edited image, set by Get once the
+	// edit is ready.
+	DownloadURL string    `json:"download_url,omitempty"`
+	Error       *string   `json:"error,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CreateRequest starts an edit of an image.
+type CreateRequest struct {
+	// Kind is the edit to run; only "erase" is supported.
+	Kind string `json:"kind"`
+	// MaskFileKey is the key of the mask, uploaded through /uploads/presign by the project
+	// owner. White pixels mark the area to erase.
+	MaskFileKey string `json:"mask_file_key"`
+	// Base is the version of the image to edit: "staged" (default) or "original".
+	Base string `json:"base,omitempty"`
+	// Sandbox routes the edit to the worker's sandbox provider.
+	Sandbox bool `json:"sandbox,omitempty"`
+}
@@ -0,0 +1,15 @@
+package imageedit
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service creates and reads quick edits of images.
+type Service interface {
+	// Create records an edit of the image for userID, an owner or editor of its project,
+	// and queues it.
+	Create(ctx context.Context, imageID, userID string, req CreateRequest) (*Edit, error)
+	// Get returns one of the image's edits to a member of its project, with a download URL
+	// once it is ready.
+	Get(ctx context.Context, imageID, editID, userID string) (*Edit, error)
+}
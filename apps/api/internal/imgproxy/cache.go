@@ -0,0 +1,136 @@
+package imgproxy
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	redis "github.com/redis/go-redis/v9"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// Cache stores encoded renditions by key. Failures are treated as misses.
+type Cache interface {
+	Get(ctx context.Context, key string) (*Rendition, bool)
+	Set(ctx context.Context, key string, r *Rendition)
+}
+
+// NewCache creates the cache selected by cfg.Cache. The redis cache needs redisAddr.
+func NewCache(cfg config.ImageProxy, redisAddr string) (Cache, error) {
+	switch cfg.Cache {
+	case "", "memory":
+		return NewMemoryCache(cfg.CacheMaxBytes), nil
+	case "redis":
+		if redisAddr == "" {
+			return nil, errors.New("image proxy redis cache requires REDIS_ADDR or redis.addr")
+		}
+		return NewRedisCache(redis.NewClient(&redis.Options{Addr: redisAddr}), cfg.CacheTTL), nil
+	case "none":
+		return noCache{}, nil
+	}
+	return nil, fmt.Errorf("unknown image proxy cache %q", cfg.Cache)
+}
+
+type noCache struct{}
+
+func (noCache) Get(context.Context, string) (*Rendition, bool) { return nil, false }
+func (noCache) Set(context.Context, string, *Rendition)        {}
+
+// MemoryCache is an in-process LRU cache bounded by the total size of the renditions.
+type MemoryCache struct {
+	maxBytes int64
+
+	mu    sync.Mutex
+	size  int64
+	order *list.List // front is the most recently used
+	items map[string]*list.Element
+}
+
+type memoryEntry struct {
+	key string
+	r   *Rendition
+}
+
+// NewMemoryCache creates a MemoryCache holding at most maxBytes of rendition bodies.
+func NewMemoryCache(maxBytes int64) *MemoryCache {
+	return &MemoryCache{maxBytes: maxBytes, order: list.New(), items: map[string]*list.Element{}}
+}
+
+// Get returns the rendition for key and marks it as recently used.
+func (c *MemoryCache) Get(_ context.Context, key string) (*Rendition, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*memoryEntry).r, true
+}
+
+// Set stores r, evicting the least recently used renditions to stay within the size bound.
+// Renditions larger than the whole cache are not stored.
+func (c *MemoryCache) Set(_ context.Context, key string, r *Rendition) {
+	size := int64(len(r.Body))
+	if size > c.maxBytes {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.size -= int64(len(el.Value.(*memoryEntry).r.Body))
+		c.order.Remove(el)
+	}
+	c.items[key] = c.order.PushFront(&memoryEntry{key: key, r: r})
+	c.size += size
+	for c.size > c.maxBytes {
+		oldest := c.order.Back()
+		entry := oldest.Value.(*memoryEntry)
+		c.order.Remove(oldest)
+		delete(c.items, entry.key)
+		c.size -= int64(len(entry.r.Body))
+	}
+}
+
+// RedisCache shares renditions between API instances. Each value is the content type, a
+// newline, then the body.
+type RedisCache struct {
+	rdb redis.Cmdable
+	ttl time.Duration
+}
+
+// NewRedisCache creates a RedisCache whose entries expire after ttl.
+func NewRedisCache(rdb redis.Cmdable, ttl time.Duration) *RedisCache {
+	return &RedisCache{rdb: rdb, ttl: ttl}
+}
+
+// Get returns the rendition for key.
+func (c *RedisCache) Get(ctx context.Context, key string) (*Rendition, bool) {
+	val, err := c.rdb.Get(ctx, key).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			logging.Default().Warn(ctx, "image proxy cache read failed", "error", err)
+		}
+		return nil, false
+	}
+	contentType, body, ok := bytes.Cut(val, []byte("\n"))
+	if !ok {
+		return nil, false
+	}
+	return &Rendition{Body: body, ContentType: string(contentType), ETag: etag(key)}, true
+}
+
+// Set stores r for the cache TTL.
+func (c *RedisCache) Set(ctx context.Context, key string, r *Rendition) {
+	val := make([]byte, 0, len(r.ContentType)+1+len(r.Body))
+	val = append(append(append(val, r.ContentType...), '\n'), r.Body...)
+	if err := c.rdb.Set(ctx, key, val, c.ttl).Err(); err != nil {
+		logging.Default().Warn(ctx, "image proxy cache write failed", "error", err)
+	}
+}
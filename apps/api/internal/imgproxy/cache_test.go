@@ -0,0 +1,84 @@
+package imgproxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+func rendition(size int) *Rendition {
+	return &Rendition{Body: make([]byte, size), ContentType: "image/jpeg"}
+}
+
+func TestMemoryCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(10)
+
+	c.Set(ctx, "a", rendition(4))
+	c.Set(ctx, "b", rendition(4))
+	_, ok := c.Get(ctx, "a") // a is now more recent than b
+	require.True(t, ok)
+	c.Set(ctx, "c", rendition(4))
+
+	_, ok = c.Get(ctx, "b")
+	assert.False(t, ok, "least recently used entry should be evicted")
+	_, ok = c.Get(ctx, "a")
+	assert.True(t, ok)
+	_, ok = c.Get(ctx, "c")
+	assert.True(t, ok)
+	assert.Equal(t, int64(8), c.size)
+
+	c.Set(ctx, "huge", rendition(11))
+	_, ok = c.Get(ctx, "huge")
+	assert.False(t, ok, "entries larger than the cache are not stored")
+
+	c.Set(ctx, "a", rendition(2))
+	assert.Equal(t, int64(6), c.size, "replacing an entry accounts for the old size")
+}
+
+func TestRedisCache(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	c := NewRedisCache(redis.NewClient(&redis.Options{Addr: mr.Addr()}), time.Hour)
+
+	_, ok := c.Get(ctx, "k")
+	assert.False(t, ok)
+
+	c.Set(ctx, "k", &Rendition{Body: []byte("png\nbytes"), ContentType: "image/png"})
+	r, ok := c.Get(ctx, "k")
+	require.True(t, ok)
+	assert.Equal(t, "image/png", r.ContentType)
+	assert.Equal(t, []byte("png\nbytes"), r.Body)
+	assert.Equal(t, etag("k"), r.ETag)
+
+	mr.FastForward(2 * time.Hour)
+	_, ok = c.Get(ctx, "k")
+	assert.False(t, ok, "entries expire after the TTL")
+}
+
+func TestNewCache(t *testing.T) {
+	c, err := NewCache(config.ImageProxy{Cache: "memory", CacheMaxBytes: 1}, "")
+	require.NoError(t, err)
+	assert.IsType(t, &MemoryCache{}, c)
+
+	c, err = NewCache(config.ImageProxy{Cache: "none"}, "")
+	require.NoError(t, err)
+	assert.IsType(t, noCache{}, c)
+
+	c, err = NewCache(config.ImageProxy{Cache: "redis"}, "localhost:6379")
+	require.NoError(t, err)
+	assert.IsType(t, &RedisCache{}, c)
+
+	_, err = NewCache(config.ImageProxy{Cache: "redis"}, "")
+	assert.ErrorContains(t, err, "REDIS_ADDR")
+
+	_, err = NewCache(config.ImageProxy{Cache: "disk"}, "")
+	assert.ErrorContains(t, err, `unknown image proxy cache "disk"`)
+}
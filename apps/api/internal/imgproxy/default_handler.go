@@ -0,0 +1,95 @@
+package imgproxy
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// DefaultHandler serves resized images over HTTP.
+type DefaultHandler struct {
+	service  Service
+	userRepo user.Repository
+	maxAge   time.Duration
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler whose responses may be reused by the
+// client for maxAge.
+func NewDefaultHandler(service Service, userRepo user.Repository, maxAge time.Duration) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo, maxAge: maxAge}
+}
+
+// ErrorResponse is a simple JSON error envelope for handler responses.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// GetImage handles GET /img/:id?w=&h=&fit=&kind= requests.
+func (h *DefaultHandler) GetImage(c echo.Context) error {
+	imageID := c.Param("id")
+	if _, err := uuid.Parse(imageID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid image ID format"})
+	}
+
+	opts := Options{Fit: Fit(c.QueryParam("fit")), Kind: Kind(c.QueryParam("kind"))}
+	for name, dst := range map[string]*int{"w": &opts.Width, "h": &opts.Height} {
+		raw := c.QueryParam(name)
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "bad_request",
+				Message: fmt.Sprintf("%s must be a positive integer", name),
+			})
+		}
+		*dst = n
+	}
+
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Unable to resolve current user",
+		})
+	}
+
+	r, err := h.service.Render(c.Request().Context(), imageID, userID, opts)
+	switch {
+	case errors.Is(err, ErrInvalidOptions):
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: err.Error()})
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrNotStaged):
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: err.Error()})
+	case errors.Is(err, ErrUnsupported):
+		return c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Error:   "unprocessable_entity",
+			Message: "Stored image cannot be resized",
+		})
+	case err != nil:
+		c.Logger().Errorf("Failed to render image %s: %v", imageID, err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to render image",
+		})
+	}
+
+	header := c.Response().Header()
+	header.Set("Cache-Control", fmt.Sprintf("private, max-age=%d", int(h.maxAge.Seconds())))
+	header.Set("ETag", r.ETag)
+	if c.Request().Header.Get("If-None-Match") == r.ETag {
+		return c.NoContent(http.StatusNotModified)
+	}
+	return c.Blob(http.StatusOK, r.ContentType, r.Body)
+}
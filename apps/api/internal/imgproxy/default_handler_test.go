@@ -0,0 +1,150 @@
+package imgproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func TestDefaultHandler_GetImage(t *testing.T) {
+	imageID := uuid.NewString()
+	userID := uuid.New()
+	userRepo := &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+		},
+	}
+	rendered := &Rendition{Body: []byte("jpeg-bytes"), ContentType: "image/jpeg", ETag: `"abc"`}
+
+	testCases := []struct {
+		name           string
+		id             string
+		query          string
+		ifNoneMatch    string
+		renderErr      error
+		expectedStatus int
+		expectedOpts   *Options
+	}{
+		{
+			name:           "success: rendition served with caching headers",
+			id:             imageID,
+			query:          "?w=800&h=600&fit=cover",
+			expectedStatus: http.StatusOK,
+			expectedOpts:   &Options{Width: 800, Height: 600, Fit: FitCover},
+		},
+		{
+			name:           "success: matching ETag is not modified",
+			id:             imageID,
+			query:          "?w=800&kind=staged",
+			ifNoneMatch:    `"abc"`,
+			expectedStatus: http.StatusNotModified,
+			expectedOpts:   &Options{Width: 800, Kind: KindStaged},
+		},
+		{
+			name:           "fail: invalid image id",
+			id:             "not-a-uuid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "fail: width is not a number",
+			id:             imageID,
+			query:          "?w=wide",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "fail: invalid options",
+			id:             imageID,
+			query:          "?w=99999",
+			renderErr:      fmt.Errorf("%w: dimensions must be at most 2560", ErrInvalidOptions),
+			expectedStatus: http.StatusBadRequest,
+			expectedOpts:   &Options{Width: 99999},
+		},
+		{
+			name:           "fail: not staged",
+			id:             imageID,
+			query:          "?kind=staged",
+			renderErr:      ErrNotStaged,
+			expectedStatus: http.StatusNotFound,
+			expectedOpts:   &Options{Kind: KindStaged},
+		},
+		{
+			name:           "fail: not a project member",
+			id:             imageID,
+			renderErr:      ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedOpts:   &Options{},
+		},
+		{
+			name:           "fail: unsupported source",
+			id:             imageID,
+			renderErr:      ErrUnsupported,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedOpts:   &Options{},
+		},
+		{
+			name:           "fail: render error",
+			id:             imageID,
+			renderErr:      errors.New("s3 down"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedOpts:   &Options{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				RenderFunc: func(ctx context.Context, imageID, uid string, opts Options) (*Rendition, error) {
+					assert.Equal(t, userID.String(), uid)
+					if tc.renderErr != nil {
+						return nil, tc.renderErr
+					}
+					return rendered, nil
+				},
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/img/"+tc.id+tc.query, nil)
+			req.Header.Set("X-Test-User", "auth0|user")
+			if tc.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tc.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.id)
+
+			err := NewDefaultHandler(svc, userRepo, time.Hour).GetImage(c)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedOpts == nil {
+				assert.Empty(t, svc.RenderCalls())
+				return
+			}
+			require.Len(t, svc.RenderCalls(), 1)
+			assert.Equal(t, *tc.expectedOpts, svc.RenderCalls()[0].Opts)
+
+			if tc.renderErr == nil {
+				assert.Equal(t, "private, max-age=3600", rec.Header().Get("Cache-Control"))
+				assert.Equal(t, `"abc"`, rec.Header().Get("ETag"))
+			}
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, "image/jpeg", rec.Header().Get(echo.HeaderContentType))
+				assert.Equal(t, "jpeg-bytes", rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package imgproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/image"
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultService renders renditions from S3 and caches them.
+type DefaultService struct {
+	images image.Service
+	// projects checks the caller is a member of the image's project before anything is served.
+	projects     project.Repository
+	buckets      storage.Buckets
+	cache        Cache
+	maxDimension int
+	quality      int
+	// slots bounds how many images are decoded and resized at once.
+	slots chan struct{}
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService. A nil cache disables caching.
+func NewDefaultService(
+	images image.Service, projects project.Repository, buckets storage.Buckets, cache Cache, cfg config.ImageProxy,
+) *DefaultService {
+	if cache == nil {
+		cache = noCache{}
+	}
+	quality := cfg.JPEGQuality
+	if quality < 1 || quality > 100 {
+		quality = 82
+	}
+	return &DefaultService{
+		images:       images,
+		projects:     projects,
+		buckets:      buckets,
+		cache:        cache,
+		maxDimension: cfg.MaxDimension,
+		quality:      quality,
+		slots:        make(chan struct{}, runtime.GOMAXPROCS(0)),
+	}
+}
+
+// Render returns the image resized per opts to a member of its project. Membership is
+// checked before the cache is read, so cached renditions are only served to members.
+func (s *DefaultService) Render(ctx context.Context, imageID, userID string, opts Options) (*Rendition, error) {
+	ctx, span := otel.Tracer("real-staging-api/imgproxy").Start(ctx, "imgproxy.render")
+	defer span.End()
+
+	opts, err := opts.normalize(s.maxDimension)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := s.images.GetImageByID(ctx, imageID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get image: %w", err)
+	}
+	// Non-members get ErrNotFound, so image IDs of other projects are not revealed.
+	if _, err := s.projects.GetProjectForMember(ctx, img.ProjectID.String(), userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to check project access: %w", err)
+	}
+	rawURL := img.OriginalURL
+	if opts.Kind == KindStaged {
+		if img.StagedURL == nil || *img.StagedURL == "" {
+			return nil, ErrNotStaged
+		}
+		rawURL = *img.StagedURL
+	}
+
+	key := opts.cacheKey(imageID, img.UpdatedAt)
+	if r, ok := s.cache.Get(ctx, key); ok {
+		span.SetAttributes(attribute.Bool("imgproxy.cache_hit", true))
+		return r, nil
+	}
+	span.SetAttributes(attribute.Bool("imgproxy.cache_hit", false))
+
+	files, fileKey, err := s.buckets.ForURL(ctx, rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve storage for image %s: %w", imageID, err)
+	}
+	src, err := files.GetFile(ctx, fileKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return nil, ErrNotFound
+		}
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+
+	select {
+	case s.slots <- struct{}{}:
+		defer func() { <-s.slots }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	body, contentType, err := resize(src, opts, s.quality)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	r := &Rendition{Body: body, ContentType: contentType, ETag: etag(key)}
+	s.cache.Set(ctx, key, r)
+	return r, nil
+}
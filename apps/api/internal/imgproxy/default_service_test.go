@@ -0,0 +1,221 @@
+package imgproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/image"
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+const memberID = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+
+// members lets only memberID into any project.
+func members() *project.RepositoryMock {
+	return &project.RepositoryMock{
+		GetProjectForMemberFunc: func(ctx context.Context, projectID, userID string) (*project.Project, error) {
+			if userID != memberID {
+				return nil, pgx.ErrNoRows
+			}
+			return &project.Project{ID: projectID, Role: project.RoleViewer}, nil
+		},
+	}
+}
+
+func TestDefaultService_Render(t *testing.T) {
+	imageID := uuid.NewString()
+	updatedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	staged := "http://localhost:9000/real-staging/staged/out.jpg"
+	stored := &image.Image{
+		OriginalURL: "http://localhost:9000/real-staging/uploads/in.jpg",
+		StagedURL:   &staged,
+		UpdatedAt:   updatedAt,
+	}
+
+	testCases := []struct {
+		name    string
+		opts    Options
+		img     *image.Image
+		getErr  error
+		fileErr error
+		wantErr error
+		wantKey string
+		wantW   int
+	}{
+		{
+			name:    "success: original resized",
+			opts:    Options{Width: 60},
+			img:     stored,
+			wantKey: "uploads/in.jpg",
+			wantW:   60,
+		},
+		{
+			name:    "success: staged kind",
+			opts:    Options{Width: 30, Kind: KindStaged},
+			img:     stored,
+			wantKey: "staged/out.jpg",
+			wantW:   30,
+		},
+		{
+			name:    "fail: dimension above the maximum",
+			opts:    Options{Width: 5000},
+			wantErr: ErrInvalidOptions,
+		},
+		{
+			name:    "fail: unknown fit",
+			opts:    Options{Width: 10, Fit: "stretch"},
+			wantErr: ErrInvalidOptions,
+		},
+		{
+			name:    "fail: image not found",
+			opts:    Options{Width: 10},
+			getErr:  pgx.ErrNoRows,
+			wantErr: ErrNotFound,
+		},
+		{
+			name:    "fail: not staged yet",
+			opts:    Options{Kind: KindStaged},
+			img:     &image.Image{OriginalURL: stored.OriginalURL},
+			wantErr: ErrNotStaged,
+		},
+		{
+			name:    "fail: stored object missing",
+			opts:    Options{Width: 10},
+			img:     stored,
+			fileErr: fmt.Errorf("failed to get file: %w", storage.ErrObjectNotFound),
+			wantErr: ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			images := &image.ServiceMock{
+				GetImageByIDFunc: func(ctx context.Context, id string) (*image.Image, error) {
+					return tc.img, tc.getErr
+				},
+			}
+			s3 := &storage.S3ServiceMock{
+				BucketNameFunc: func() string { return "real-staging" },
+				GetFileFunc: func(ctx context.Context, fileKey string) ([]byte, error) {
+					if tc.fileErr != nil {
+						return nil, tc.fileErr
+					}
+					return encodeTestImage(t, "jpeg", 120, 80), nil
+				},
+			}
+			cache := NewMemoryCache(1 << 20)
+			cfg := config.ImageProxy{MaxDimension: 2560, JPEGQuality: 80}
+			svc := NewDefaultService(images, members(), storage.NewPlatformBuckets(s3), cache, cfg)
+
+			r, err := svc.Render(context.Background(), imageID, memberID, tc.opts)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "image/jpeg", r.ContentType)
+			require.Len(t, s3.GetFileCalls(), 1)
+			assert.Equal(t, tc.wantKey, s3.GetFileCalls()[0].FileKey)
+			w, _ := decodeSize(t, r.Body)
+			assert.Equal(t, tc.wantW, w)
+
+			// A second request is served from the cache with the same ETag.
+			again, err := svc.Render(context.Background(), imageID, memberID, tc.opts)
+			require.NoError(t, err)
+			assert.Len(t, s3.GetFileCalls(), 1)
+			assert.Equal(t, r.ETag, again.ETag)
+		})
+	}
+}
+
+func TestDefaultService_Render_NewVersionMissesCache(t *testing.T) {
+	img := &image.Image{OriginalURL: "http://localhost:9000/real-staging/uploads/in.jpg", UpdatedAt: time.Now()}
+	images := &image.ServiceMock{
+		GetImageByIDFunc: func(ctx context.Context, id string) (*image.Image, error) {
+			copied := *img
+			return &copied, nil
+		},
+	}
+	s3 := &storage.S3ServiceMock{
+		BucketNameFunc: func() string { return "real-staging" },
+		GetFileFunc: func(ctx context.Context, fileKey string) ([]byte, error) {
+			return encodeTestImage(t, "jpeg", 40, 40), nil
+		},
+	}
+	svc := NewDefaultService(images, members(), storage.NewPlatformBuckets(s3), NewMemoryCache(1<<20), config.ImageProxy{})
+
+	first, err := svc.Render(context.Background(), "id", memberID, Options{Width: 20})
+	require.NoError(t, err)
+	img.UpdatedAt = img.UpdatedAt.Add(time.Second)
+	second, err := svc.Render(context.Background(), "id", memberID, Options{Width: 20})
+	require.NoError(t, err)
+
+	assert.Len(t, s3.GetFileCalls(), 2)
+	assert.NotEqual(t, first.ETag, second.ETag)
+}
+
+func TestDefaultService_Render_ReadError(t *testing.T) {
+	images := &image.ServiceMock{
+		GetImageByIDFunc: func(ctx context.Context, id string) (*image.Image, error) {
+			return &image.Image{OriginalURL: "http://localhost:9000/real-staging/uploads/in.jpg"}, nil
+		},
+	}
+	s3 := &storage.S3ServiceMock{
+		BucketNameFunc: func() string { return "real-staging" },
+		GetFileFunc: func(ctx context.Context, fileKey string) ([]byte, error) {
+			return nil, errors.New("connection reset")
+		},
+	}
+	svc := NewDefaultService(images, members(), storage.NewPlatformBuckets(s3), nil, config.ImageProxy{})
+	_, err := svc.Render(context.Background(), "id", memberID, Options{})
+	assert.ErrorContains(t, err, "failed to read image: connection reset")
+}
+
+func TestDefaultService_Render_NonMember(t *testing.T) {
+	images := &image.ServiceMock{
+		GetImageByIDFunc: func(ctx context.Context, id string) (*image.Image, error) {
+			return &image.Image{
+				ProjectID:   uuid.New(),
+				OriginalURL: "http://localhost:9000/real-staging/uploads/in.jpg",
+				UpdatedAt:   time.Now(),
+			}, nil
+		},
+	}
+	s3 := &storage.S3ServiceMock{
+		BucketNameFunc: func() string { return "real-staging" },
+		GetFileFunc: func(ctx context.Context, fileKey string) ([]byte, error) {
+			return encodeTestImage(t, "jpeg", 40, 40), nil
+		},
+	}
+	svc := NewDefaultService(images, members(), storage.NewPlatformBuckets(s3), NewMemoryCache(1<<20), config.ImageProxy{})
+
+	t.Run("fail: a rendition a member cached is not served to others", func(t *testing.T) {
+		_, err := svc.Render(context.Background(), "id", memberID, Options{Width: 20})
+		require.NoError(t, err)
+
+		_, err = svc.Render(context.Background(), "id", uuid.NewString(), Options{Width: 20})
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Len(t, s3.GetFileCalls(), 1)
+	})
+
+	t.Run("fail: membership check error", func(t *testing.T) {
+		projects := &project.RepositoryMock{
+			GetProjectForMemberFunc: func(ctx context.Context, projectID, userID string) (*project.Project, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		svc := NewDefaultService(images, projects, storage.NewPlatformBuckets(s3), nil, config.ImageProxy{})
+		_, err := svc.Render(context.Background(), "id", memberID, Options{})
+		assert.ErrorContains(t, err, "failed to check project access: db down")
+	})
+}
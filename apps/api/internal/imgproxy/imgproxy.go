@@ -0,0 +1,92 @@
+// Package imgproxy serves stored images resized on request, so the frontend can ask for
+// the rendition it needs instead of every size being generated ahead of time.
+package imgproxy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Fit says how an image is sized into a box given by both width and height.
+type Fit string
+
+const (
+	// FitContain scales the image to fit inside the box, keeping all of it. It is the default.
+	FitContain Fit = "contain"
+	// FitCover scales the image to fill the box and crops the overflow around the center.
+	FitCover Fit = "cover"
+)
+
+// Kind selects which stored image is served.
+type Kind string
+
+const (
+	// KindOriginal is the upload. It is the default.
+	KindOriginal Kind = "original"
+	// KindStaged is the staged output.
+	KindStaged Kind = "staged"
+)
+
+var (
+	// ErrInvalidOptions is returned for dimensions, fits or kinds the proxy does not serve.
+	ErrInvalidOptions = errors.New("invalid image options")
+	// ErrNotFound is returned when the image or its stored object does not exist.
+	ErrNotFound = errors.New("image not found")
+	// ErrNotStaged is returned when the staged kind is requested before the image is staged.
+	ErrNotStaged = errors.New("image has not been staged")
+	// ErrUnsupported is returned when the stored object is not an image the proxy can decode,
+	// or is too large to decode.
+	ErrUnsupported = errors.New("unsupported image")
+)
+
+// Options describe a rendition. A zero Width or Height is derived from the other by the
+// image's aspect ratio; when both are zero the image keeps its size. Images are never
+// scaled up.
+type Options struct {
+	Width  int
+	Height int
+	Fit    Fit
+	Kind   Kind
+}
+
+// normalize fills in defaults and checks o against maxDimension.
+func (o Options) normalize(maxDimension int) (Options, error) {
+	if o.Fit == "" {
+		o.Fit = FitContain
+	}
+	if o.Kind == "" {
+		o.Kind = KindOriginal
+	}
+	switch {
+	case o.Width < 0 || o.Height < 0:
+		return o, fmt.Errorf("%w: dimensions must not be negative", ErrInvalidOptions)
+	case maxDimension > 0 && (o.Width > maxDimension || o.Height > maxDimension):
+		return o, fmt.Errorf("%w: dimensions must be at most %d", ErrInvalidOptions, maxDimension)
+	case o.Fit != FitContain && o.Fit != FitCover:
+		return o, fmt.Errorf("%w: fit must be contain or cover", ErrInvalidOptions)
+	case o.Kind != KindOriginal && o.Kind != KindStaged:
+		return o, fmt.Errorf("%w: kind must be original or staged", ErrInvalidOptions)
+	}
+	return o, nil
+}
+
+// cacheKey identifies a rendition of one version of an image.
+func (o Options) cacheKey(imageID string, version time.Time) string {
+	return fmt.Sprintf("imgproxy:%s:%s:%d:%dx%d:%s", imageID, o.Kind, version.UnixNano(), o.Width, o.Height, o.Fit)
+}
+
+// Rendition is an encoded, resized image.
+type Rendition struct {
+	Body        []byte
+	ContentType string
+	// ETag changes whenever the image or the requested options do.
+	ETag string
+}
+
+func etag(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return `"` + hex.EncodeToString(sum[:12]) + `"`
+}
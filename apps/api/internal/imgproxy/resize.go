@@ -0,0 +1,86 @@
+package imgproxy
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"math"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // register decoder for staged outputs
+)
+
+// maxSourcePixels bounds the images the proxy decodes, keeping one request's memory in check.
+const maxSourcePixels = 50_000_000
+
+// resize decodes src, sizes it per opts and encodes it again. PNG sources stay PNG to keep
+// transparency; everything else is served as JPEG.
+func resize(src []byte, opts Options, quality int) ([]byte, string, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(src))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrUnsupported, err)
+	}
+	if cfg.Width*cfg.Height > maxSourcePixels {
+		return nil, "", fmt.Errorf("%w: %dx%d exceeds %d pixels",
+			ErrUnsupported, cfg.Width, cfg.Height, maxSourcePixels)
+	}
+	img, _, err := image.Decode(bytes.NewReader(src))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrUnsupported, err)
+	}
+
+	crop, width, height := plan(img.Bounds(), opts)
+	out := img
+	if crop != img.Bounds() || width != crop.Dx() || height != crop.Dy() {
+		dst := image.NewRGBA(image.Rect(0, 0, width, height))
+		draw.CatmullRom.Scale(dst, dst.Bounds(), img, crop, draw.Src, nil)
+		out = dst
+	}
+
+	var buf bytes.Buffer
+	if format == "png" {
+		if err := png.Encode(&buf, out); err != nil {
+			return nil, "", fmt.Errorf("failed to encode png: %w", err)
+		}
+		return buf.Bytes(), "image/png", nil
+	}
+	if err := jpeg.Encode(&buf, out, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, "", fmt.Errorf("failed to encode jpeg: %w", err)
+	}
+	return buf.Bytes(), "image/jpeg", nil
+}
+
+// plan returns the part of the source to keep and the size to scale it to.
+func plan(src image.Rectangle, opts Options) (image.Rectangle, int, int) {
+	srcW, srcH := float64(src.Dx()), float64(src.Dy())
+	w, h := float64(opts.Width), float64(opts.Height)
+
+	var scale float64
+	switch {
+	case w == 0 && h == 0:
+		scale = 1
+	case h == 0:
+		scale = w / srcW
+	case w == 0:
+		scale = h / srcH
+	case opts.Fit == FitCover:
+		scale = math.Max(w/srcW, h/srcH)
+	default:
+		scale = math.Min(w/srcW, h/srcH)
+	}
+	scale = math.Min(scale, 1)
+
+	crop := src
+	if opts.Fit == FitCover && w > 0 && h > 0 {
+		cropW := min(src.Dx(), int(math.Round(w/scale)))
+		cropH := min(src.Dy(), int(math.Round(h/scale)))
+		x := src.Min.X + (src.Dx()-cropW)/2
+		y := src.Min.Y + (src.Dy()-cropH)/2
+		crop = image.Rect(x, y, x+cropW, y+cropH)
+	}
+	width := max(1, int(math.Round(float64(crop.Dx())*scale)))
+	height := max(1, int(math.Round(float64(crop.Dy())*scale)))
+	return crop, width, height
+}
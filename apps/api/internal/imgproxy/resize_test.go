@@ -0,0 +1,119 @@
+package imgproxy
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeTestImage(t *testing.T, format string, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if format == "png" {
+		require.NoError(t, png.Encode(&buf, img))
+	} else {
+		require.NoError(t, jpeg.Encode(&buf, img, nil))
+	}
+	return buf.Bytes()
+}
+
+func TestPlan(t *testing.T) {
+	src := image.Rect(0, 0, 1600, 1200)
+
+	testCases := []struct {
+		name     string
+		opts     Options
+		wantCrop image.Rectangle
+		wantW    int
+		wantH    int
+	}{
+		{name: "success: no dimensions keeps the size", opts: Options{}, wantCrop: src, wantW: 1600, wantH: 1200},
+		{
+			name:     "success: width only keeps the aspect ratio",
+			opts:     Options{Width: 800},
+			wantCrop: src, wantW: 800, wantH: 600,
+		},
+		{
+			name:     "success: height only keeps the aspect ratio",
+			opts:     Options{Height: 300},
+			wantCrop: src, wantW: 400, wantH: 300,
+		},
+		{
+			name:     "success: contain fits inside the box",
+			opts:     Options{Width: 800, Height: 800, Fit: FitContain},
+			wantCrop: src, wantW: 800, wantH: 600,
+		},
+		{
+			name:     "success: cover fills the box and crops the center",
+			opts:     Options{Width: 800, Height: 800, Fit: FitCover},
+			wantCrop: image.Rect(200, 0, 1400, 1200), wantW: 800, wantH: 800,
+		},
+		{
+			name:     "success: never scales up",
+			opts:     Options{Width: 3200},
+			wantCrop: src, wantW: 1600, wantH: 1200,
+		},
+		{
+			name:     "success: cover larger than the source crops without scaling",
+			opts:     Options{Width: 2000, Height: 1000, Fit: FitCover},
+			wantCrop: image.Rect(0, 100, 1600, 1100), wantW: 1600, wantH: 1000,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			crop, w, h := plan(src, tc.opts)
+			assert.Equal(t, tc.wantCrop, crop)
+			assert.Equal(t, tc.wantW, w)
+			assert.Equal(t, tc.wantH, h)
+		})
+	}
+}
+
+func TestResize(t *testing.T) {
+	t.Run("success: jpeg is resized to jpeg", func(t *testing.T) {
+		body, contentType, err := resize(encodeTestImage(t, "jpeg", 200, 100), Options{Width: 50}, 80)
+		require.NoError(t, err)
+		assert.Equal(t, "image/jpeg", contentType)
+		cfg, format, err := image.DecodeConfig(bytes.NewReader(body))
+		require.NoError(t, err)
+		assert.Equal(t, "jpeg", format)
+		assert.Equal(t, 50, cfg.Width)
+		assert.Equal(t, 25, cfg.Height)
+	})
+
+	t.Run("success: png stays png", func(t *testing.T) {
+		opts := Options{Width: 40, Height: 20, Fit: FitCover}
+		body, contentType, err := resize(encodeTestImage(t, "png", 100, 100), opts, 80)
+		require.NoError(t, err)
+		assert.Equal(t, "image/png", contentType)
+		cfg, err := png.DecodeConfig(bytes.NewReader(body))
+		require.NoError(t, err)
+		assert.Equal(t, 40, cfg.Width)
+		assert.Equal(t, 20, cfg.Height)
+	})
+
+	t.Run("fail: not an image", func(t *testing.T) {
+		_, _, err := resize([]byte("%PDF-1.7"), Options{Width: 10}, 80)
+		assert.ErrorIs(t, err, ErrUnsupported)
+	})
+}
+
+func decodeSize(t *testing.T, body []byte) (int, int) {
+	t.Helper()
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(body))
+	require.NoError(t, err)
+	return cfg.Width, cfg.Height
+}
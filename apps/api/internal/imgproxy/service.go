@@ -0,0 +1,12 @@
+package imgproxy
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service defines the interface for rendering resized images.
+type Service interface {
+	// Render returns the image resized per opts to a member of its project, from the cache
+	// when it was served before.
+	Render(ctx context.Context, imageID, userID string, opts Options) (*Rendition, error)
+}
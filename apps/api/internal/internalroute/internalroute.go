@@ -0,0 +1,113 @@
+// Package internalroute restricts routes that reveal operational detail, such as
+// dependency health, to operators.
+//
+// A request to an internal route is let through when it carries the configured static
+// bearer token or comes from an allowed network. Internal routes are also left out of
+// CORS, so a browser on another origin cannot read them even when the caller qualifies.
+package internalroute
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+// Guard decides which routes are internal and who may call them.
+type Guard struct {
+	paths     map[string]struct{}
+	token     []byte
+	networks  []*net.IPNet
+	extractIP echo.IPExtractor
+}
+
+// New builds a Guard from the internal_routes config section. Paths are matched against
+// echo route templates, e.g. "/health/details".
+func New(cfg config.InternalRoutes) (*Guard, error) {
+	g := &Guard{
+		paths:     make(map[string]struct{}, len(cfg.Paths)),
+		token:     []byte(cfg.Token),
+		extractIP: echo.ExtractIPDirect(),
+	}
+	if cfg.TrustForwardedFor {
+		g.extractIP = echo.ExtractIPFromXFFHeader()
+	}
+	for _, p := range cfg.Paths {
+		if p = strings.TrimSpace(p); p != "" {
+			g.paths[p] = struct{}{}
+		}
+	}
+	for _, cidr := range cfg.AllowedCIDRs {
+		_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid internal route CIDR %q: %w", cidr, err)
+		}
+		g.networks = append(g.networks, network)
+	}
+	return g, nil
+}
+
+// IsInternal reports whether the route c was matched to is internal.
+func (g *Guard) IsInternal(c echo.Context) bool {
+	_, ok := g.paths[c.Path()]
+	return ok
+}
+
+// CORSSkipper skips CORS handling for internal routes, so no browser on another origin
+// is allowed to read them.
+func (g *Guard) CORSSkipper() middleware.Skipper {
+	return g.IsInternal
+}
+
+// Middleware rejects requests to internal routes from callers without the token and
+// outside the allowed networks. Other routes pass through untouched. With neither a token
+// nor networks configured, internal routes are closed to everyone.
+func (g *Guard) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !g.IsInternal(c) {
+				return next(c)
+			}
+			if g.allowedNetwork(c.Request()) {
+				return next(c)
+			}
+			token, hasToken := bearerToken(c.Request())
+			if hasToken && len(g.token) > 0 && subtle.ConstantTimeCompare([]byte(token), g.token) == 1 {
+				return next(c)
+			}
+			if len(g.token) > 0 {
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid or missing internal route token")
+			}
+			return echo.NewHTTPError(http.StatusForbidden, "Internal route is not available from this address")
+		}
+	}
+}
+
+func (g *Guard) allowedNetwork(r *http.Request) bool {
+	ip := net.ParseIP(g.extractIP(r))
+	if ip == nil {
+		return false
+	}
+	for _, network := range g.networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// bearerToken returns the token of an "Authorization: Bearer" header.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get(echo.HeaderAuthorization), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return token, true
+}
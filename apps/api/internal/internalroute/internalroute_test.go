@@ -0,0 +1,162 @@
+package internalroute
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+var testToken = strings.Repeat("t", 32)
+
+func newTestEcho(t *testing.T, cfg config.InternalRoutes) *echo.Echo {
+	t.Helper()
+	guard, err := New(cfg)
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.Use(guard.Middleware())
+	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+		Skipper:      guard.CORSSkipper(),
+		AllowOrigins: []string{"http://localhost:3000"},
+	}))
+	ok := func(c echo.Context) error { return c.String(http.StatusOK, "ok") }
+	e.GET("/health", ok)
+	e.GET("/health/details", ok)
+	return e
+}
+
+func TestGuard_Middleware(t *testing.T) {
+	cases := []struct {
+		name       string
+		cfg        config.InternalRoutes
+		path       string
+		remoteAddr string
+		header     http.Header
+		wantCode   int
+		wantCORS   bool
+	}{
+		{
+			name:       "success: public route is untouched",
+			cfg:        config.InternalRoutes{Paths: []string{"/health/details"}, Token: testToken},
+			path:       "/health",
+			remoteAddr: "203.0.113.7:4000",
+			wantCode:   http.StatusOK,
+			wantCORS:   true,
+		},
+		{
+			name:       "success: valid bearer token",
+			cfg:        config.InternalRoutes{Paths: []string{"/health/details"}, Token: testToken},
+			path:       "/health/details",
+			remoteAddr: "203.0.113.7:4000",
+			header:     http.Header{"Authorization": {"Bearer " + testToken}},
+			wantCode:   http.StatusOK,
+		},
+		{
+			name: "success: allowed network without a token",
+			cfg: config.InternalRoutes{
+				Paths: []string{"/health/details"}, Token: testToken, AllowedCIDRs: []string{"10.0.0.0/8"},
+			},
+			path:       "/health/details",
+			remoteAddr: "10.1.2.3:4000",
+			wantCode:   http.StatusOK,
+		},
+		{
+			name: "success: forwarded address when trusted",
+			cfg: config.InternalRoutes{
+				Paths: []string{"/health/details"}, AllowedCIDRs: []string{"10.0.0.0/8"}, TrustForwardedFor: true,
+			},
+			path:       "/health/details",
+			remoteAddr: "172.16.0.5:4000",
+			header:     http.Header{"X-Forwarded-For": {"10.1.2.3"}},
+			wantCode:   http.StatusOK,
+		},
+		{
+			name:       "fail: wrong bearer token",
+			cfg:        config.InternalRoutes{Paths: []string{"/health/details"}, Token: testToken},
+			path:       "/health/details",
+			remoteAddr: "203.0.113.7:4000",
+			header:     http.Header{"Authorization": {"Bearer " + strings.Repeat("x", 32)}},
+			wantCode:   http.StatusUnauthorized,
+		},
+		{
+			name:       "fail: missing bearer token",
+			cfg:        config.InternalRoutes{Paths: []string{"/health/details"}, Token: testToken},
+			path:       "/health/details",
+			remoteAddr: "203.0.113.7:4000",
+			wantCode:   http.StatusUnauthorized,
+		},
+		{
+			name: "fail: forwarded address is ignored unless trusted",
+			cfg: config.InternalRoutes{
+				Paths: []string{"/health/details"}, AllowedCIDRs: []string{"10.0.0.0/8"},
+			},
+			path:       "/health/details",
+			remoteAddr: "172.16.0.5:4000",
+			header:     http.Header{"X-Forwarded-For": {"10.1.2.3"}},
+			wantCode:   http.StatusForbidden,
+		},
+		{
+			name:       "fail: closed without a token or networks",
+			cfg:        config.InternalRoutes{Paths: []string{"/health/details"}},
+			path:       "/health/details",
+			remoteAddr: "127.0.0.1:4000",
+			header:     http.Header{"Authorization": {"Bearer "}},
+			wantCode:   http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := newTestEcho(t, tc.cfg)
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			req.RemoteAddr = tc.remoteAddr
+			for k, v := range tc.header {
+				req.Header[k] = v
+			}
+			req.Header.Set(echo.HeaderOrigin, "http://localhost:3000")
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.wantCode, rec.Code)
+			if tc.wantCode == http.StatusUnauthorized {
+				assert.Equal(t, "Bearer", rec.Header().Get(echo.HeaderWWWAuthenticate))
+			}
+			if tc.wantCORS {
+				assert.Equal(t, "http://localhost:3000", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+			} else {
+				assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	cases := []struct {
+		name    string
+		cidrs   []string
+		wantErr string
+	}{
+		{name: "success: IPv4 and IPv6 networks", cidrs: []string{"10.0.0.0/8", " ::1/128 "}},
+		{name: "fail: address without a prefix", cidrs: []string{"10.0.0.1"}, wantErr: `"10.0.0.1"`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := New(config.InternalRoutes{AllowedCIDRs: tc.cidrs})
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
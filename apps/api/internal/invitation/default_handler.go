@@ -0,0 +1,134 @@
+package invitation
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// DefaultHandler serves project invitations over HTTP.
+type DefaultHandler struct {
+	service  Service
+	userRepo user.Repository
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, userRepo user.Repository) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo}
+}
+
+// ErrorResponse is a simple JSON error envelope for handler responses.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// ListResponse wraps a project's invitations.
+type ListResponse struct {
+	Invitations []*Invitation `json:"invitations"`
+}
+
+// Invite handles POST /api/v1/projects/:id/invite.
+func (h *DefaultHandler) Invite(c echo.Context) error {
+	var req InviteRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid request format"})
+	}
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	inv, err := h.service.Invite(c.Request().Context(), userID, c.Param("id"), req)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusCreated, inv)
+}
+
+// List handles GET /api/v1/projects/:id/invitations.
+func (h *DefaultHandler) List(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	invitations, err := h.service.List(c.Request().Context(), userID, c.Param("id"))
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, ListResponse{Invitations: invitations})
+}
+
+// Revoke handles DELETE /api/v1/projects/:id/invitations/:invitation_id.
+func (h *DefaultHandler) Revoke(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	inv, err := h.service.Revoke(c.Request().Context(), userID, c.Param("id"), c.Param("invitation_id"))
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, inv)
+}
+
+// Accept handles POST /api/v1/invitations/:id/accept with the exp and sig of the emailed link.
+func (h *DefaultHandler) Accept(c echo.Context) error {
+	var req AcceptRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid request format"})
+	}
+	if req.Signature == "" {
+		return h.writeError(c, ErrBadSignature)
+	}
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	collaborator, err := h.service.Accept(c.Request().Context(), userID, c.Param("id"), req)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, collaborator)
+}
+
+func unauthorized(c echo.Context) error {
+	return c.JSON(http.StatusUnauthorized, ErrorResponse{
+		Error:   "unauthorized",
+		Message: "Unable to resolve current user",
+	})
+}
+
+// writeError maps service errors to responses.
+func (h *DefaultHandler) writeError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, ErrInvalid):
+		return c.JSON(http.StatusUnprocessableEntity, ErrorResponse{Error: "validation_failed", Message: err.Error()})
+	case errors.Is(err, ErrNotFound):
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Project or invitation not found"})
+	case errors.Is(err, ErrBadSignature), errors.Is(err, ErrWrongRecipient):
+		return c.JSON(http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: err.Error()})
+	case errors.Is(err, ErrAlreadyAccepted):
+		return c.JSON(http.StatusConflict, ErrorResponse{Error: "conflict", Message: err.Error()})
+	case errors.Is(err, ErrRevoked), errors.Is(err, ErrExpired):
+		return c.JSON(http.StatusGone, ErrorResponse{Error: "gone", Message: err.Error()})
+	case errors.Is(err, ErrDelivery):
+		c.Logger().Errorf("Invitation email failed: %v", err)
+		return c.JSON(http.StatusBadGateway, ErrorResponse{Error: "bad_gateway", Message: ErrDelivery.Error()})
+	default:
+		c.Logger().Errorf("Invitation request failed: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to process invitation request",
+		})
+	}
+}
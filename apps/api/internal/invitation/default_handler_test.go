@@ -0,0 +1,194 @@
+package invitation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func userRepoFor(userID uuid.UUID) *user.RepositoryMock {
+	return &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+		},
+	}
+}
+
+func TestDefaultHandler_Invite(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: created", body: `{"email":"buyer@example.com","role":"editor"}`, expectedStatus: http.StatusCreated},
+		{name: "fail: malformed body", body: `{`, expectedStatus: http.StatusBadRequest},
+		{name: "fail: invalid", body: `{}`, err: ErrInvalid, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "fail: not found", body: `{}`, err: ErrNotFound, expectedStatus: http.StatusNotFound},
+		{
+			name:           "fail: email not delivered",
+			body:           `{}`,
+			err:            fmt.Errorf("%w: connection refused", ErrDelivery),
+			expectedStatus: http.StatusBadGateway,
+		},
+		{
+			name:           "fail: service error",
+			body:           `{}`,
+			err:            errors.New("db down"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				InviteFunc: func(ctx context.Context, uid, projectID string, req InviteRequest) (*Invitation, error) {
+					assert.Equal(t, userID.String(), uid)
+					assert.Equal(t, "proj-1", projectID)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					assert.Equal(t, InviteRequest{Email: "buyer@example.com", Role: RoleEditor}, req)
+					return &Invitation{ID: "inv-1", Email: req.Email, Role: req.Role, Status: StatusPending}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, userRepoFor(userID))
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/projects/proj-1/invite", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("X-Test-User", "auth0|user")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("proj-1")
+
+			require.NoError(t, h.Invite(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusCreated {
+				assert.Contains(t, rec.Body.String(), `"status":"pending"`)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_List(t *testing.T) {
+	userID := uuid.New()
+	svc := &ServiceMock{
+		ListFunc: func(ctx context.Context, uid, projectID string) ([]*Invitation, error) {
+			if projectID != "proj-1" {
+				return nil, ErrNotFound
+			}
+			return []*Invitation{{ID: "inv-1", Status: StatusAccepted}}, nil
+		},
+	}
+	h := NewDefaultHandler(svc, userRepoFor(userID))
+
+	for projectID, want := range map[string]int{"proj-1": http.StatusOK, "other": http.StatusNotFound} {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/projects/"+projectID+"/invitations", nil)
+		req.Header.Set("X-Test-User", "auth0|user")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(projectID)
+
+		require.NoError(t, h.List(c))
+		assert.Equal(t, want, rec.Code, projectID)
+		if want == http.StatusOK {
+			assert.Contains(t, rec.Body.String(), `"invitations":[{"id":"inv-1"`)
+		}
+	}
+}
+
+func TestDefaultHandler_Revoke(t *testing.T) {
+	userID := uuid.New()
+	svc := &ServiceMock{
+		RevokeFunc: func(ctx context.Context, uid, projectID, invitationID string) (*Invitation, error) {
+			if invitationID != "inv-1" {
+				return nil, ErrNotFound
+			}
+			return &Invitation{ID: invitationID, ProjectID: projectID, Status: StatusRevoked}, nil
+		},
+	}
+	h := NewDefaultHandler(svc, userRepoFor(userID))
+
+	for invitationID, want := range map[string]int{"inv-1": http.StatusOK, "other": http.StatusNotFound} {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/projects/proj-1/invitations/"+invitationID, nil)
+		req.Header.Set("X-Test-User", "auth0|user")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id", "invitation_id")
+		c.SetParamValues("proj-1", invitationID)
+
+		require.NoError(t, h.Revoke(c))
+		assert.Equal(t, want, rec.Code, invitationID)
+	}
+}
+
+func TestDefaultHandler_Accept(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: accepted", body: `{"exp":1777777777,"sig":"abc"}`, expectedStatus: http.StatusOK},
+		{name: "fail: missing signature", body: `{"exp":1777777777}`, expectedStatus: http.StatusForbidden},
+		{name: "fail: bad signature", body: `{"exp":1,"sig":"x"}`, err: ErrBadSignature, expectedStatus: http.StatusForbidden},
+		{name: "fail: wrong recipient", body: `{"sig":"x"}`, err: ErrWrongRecipient, expectedStatus: http.StatusForbidden},
+		{name: "fail: accepted by another user", body: `{"sig":"x"}`, err: ErrAlreadyAccepted, expectedStatus: http.StatusConflict},
+		{name: "fail: revoked", body: `{"sig":"x"}`, err: ErrRevoked, expectedStatus: http.StatusGone},
+		{name: "fail: expired", body: `{"sig":"x"}`, err: ErrExpired, expectedStatus: http.StatusGone},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				AcceptFunc: func(ctx context.Context, uid, invitationID string, req AcceptRequest) (*Collaborator, error) {
+					assert.Equal(t, userID.String(), uid)
+					assert.Equal(t, "inv-1", invitationID)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					assert.Equal(t, AcceptRequest{Expires: 1777777777, Signature: "abc"}, req)
+					return &Collaborator{ProjectID: "proj-1", UserID: uid, Role: RoleViewer, InvitationID: invitationID}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, userRepoFor(userID))
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/invitations/inv-1/accept", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("X-Test-User", "auth0|user")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("inv-1")
+
+			require.NoError(t, h.Accept(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Contains(t, rec.Body.String(), `"project_id":"proj-1"`)
+			}
+		})
+	}
+}
@@ -0,0 +1,364 @@
+package invitation
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/mailer"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// DefaultService implements Service.
+type DefaultService struct {
+	querier queries.Querier
+	mailer  mailer.Mailer
+	signer  signer
+	cfg     config.Invitations
+	now     func() time.Time
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService with a database.
+func NewDefaultService(db storage.Database, mail mailer.Mailer, cfg config.Invitations) *DefaultService {
+	return NewDefaultServiceWithQuerier(queries.New(db), mail, cfg)
+}
+
+// NewDefaultServiceWithQuerier creates a new DefaultService with a custom querier (for testing).
+// Without a configured secret, links are signed with a random one and stop working when the
+// process restarts; config.Validate requires a secret outside development.
+func NewDefaultServiceWithQuerier(querier queries.Querier, mail mailer.Mailer, cfg config.Invitations) *DefaultService {
+	secret := []byte(cfg.Secret)
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		_, _ = rand.Read(secret)
+		logging.Default().Warn(context.Background(),
+			"invitations.secret is not set; invitation links will stop working on restart")
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = 7 * 24 * time.Hour
+	}
+	return &DefaultService{querier: querier, mailer: mail, signer: signer{secret: secret}, cfg: cfg, now: time.Now}
+}
+
+// Invite records the invitation and emails its acceptance link. If the email cannot be
+// sent, the invitation is revoked and ErrDelivery is returned.
+func (s *DefaultService) Invite(ctx context.Context, userID, projectID string, req InviteRequest) (*Invitation, error) {
+	email, err := normalizeEmail(req.Email)
+	if err != nil {
+		return nil, err
+	}
+	if req.Role == "" {
+		req.Role = RoleViewer
+	}
+	if req.Role != RoleViewer && req.Role != RoleEditor {
+		return nil, fmt.Errorf("%w: role must be viewer or editor", ErrInvalid)
+	}
+
+	uid, pid, err := parseIDs(userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	project, err := s.ownedProject(ctx, uid, pid)
+	if err != nil {
+		return nil, err
+	}
+	if own, err := s.userEmail(ctx, uid); err != nil {
+		return nil, err
+	} else if strings.EqualFold(own, email) {
+		return nil, fmt.Errorf("%w: you already own this project", ErrInvalid)
+	}
+
+	row, err := s.querier.CreateProjectInvitation(ctx, queries.CreateProjectInvitationParams{
+		ProjectID: pid,
+		InvitedBy: uid,
+		Email:     email,
+		Role:      req.Role,
+		ExpiresAt: pgtype.Timestamptz{Time: s.now().Add(s.cfg.TTL).Truncate(time.Second), Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+	inv := s.toInvitation(row)
+
+	if err := s.mailer.Send(ctx, s.inviteMessage(inv, project.Name)); err != nil {
+		if _, rerr := s.querier.RevokeProjectInvitation(ctx, queries.RevokeProjectInvitationParams{
+			ID: row.ID, ProjectID: pid,
+		}); rerr != nil {
+			logging.Default().Error(ctx, "Failed to revoke undelivered invitation", "invitation_id", inv.ID, "error", rerr)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrDelivery, err)
+	}
+	return inv, nil
+}
+
+// List returns the project's invitations, newest first.
+func (s *DefaultService) List(ctx context.Context, userID, projectID string) ([]*Invitation, error) {
+	uid, pid, err := parseIDs(userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.ownedProject(ctx, uid, pid); err != nil {
+		return nil, err
+	}
+	rows, err := s.querier.ListProjectInvitations(ctx, pid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+	invitations := make([]*Invitation, 0, len(rows))
+	for _, row := range rows {
+		invitations = append(invitations, s.toInvitation(row))
+	}
+	return invitations, nil
+}
+
+// Revoke cancels the invitation and removes the collaborator it added, if any. Revoking an
+// invitation again returns it unchanged.
+func (s *DefaultService) Revoke(ctx context.Context, userID, projectID, invitationID string) (*Invitation, error) {
+	uid, pid, err := parseIDs(userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	id, err := parseUUID(invitationID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	if _, err := s.ownedProject(ctx, uid, pid); err != nil {
+		return nil, err
+	}
+
+	row, err := s.querier.RevokeProjectInvitation(ctx, queries.RevokeProjectInvitationParams{ID: id, ProjectID: pid})
+	if err == nil {
+		return s.toInvitation((*queries.ProjectInvitation)(row)), nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to revoke invitation: %w", err)
+	}
+	existing, err := s.querier.GetProjectInvitation(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && existing.ProjectID != pid) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+	return s.toInvitation(existing), nil
+}
+
+// Accept verifies the link and adds the user as a collaborator with the invited role.
+// Accepting an invitation the user has already accepted returns their access again.
+func (s *DefaultService) Accept(
+	ctx context.Context, userID, invitationID string, req AcceptRequest,
+) (*Collaborator, error) {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	id, err := parseUUID(invitationID)
+	if err != nil {
+		return nil, ErrBadSignature
+	}
+	inv, err := s.querier.GetProjectInvitation(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBadSignature
+		}
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+	if req.Expires != inv.ExpiresAt.Time.Unix() || !s.signer.verify(invitationID, inv.Email, req.Expires, req.Signature) {
+		return nil, ErrBadSignature
+	}
+	if collaborator, err := s.settled(inv, uid); collaborator != nil || err != nil {
+		return collaborator, err
+	}
+
+	if email, err := s.userEmail(ctx, uid); err != nil {
+		return nil, err
+	} else if email != "" && !strings.EqualFold(email, inv.Email) {
+		return nil, ErrWrongRecipient
+	}
+	project, err := s.querier.GetProjectByID(ctx, inv.ProjectID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	if project.UserID == uid {
+		return nil, fmt.Errorf("%w: you already own this project", ErrInvalid)
+	}
+
+	row, err := s.querier.AcceptProjectInvitation(ctx, queries.AcceptProjectInvitationParams{ID: id, AcceptedBy: uid})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Revoked, accepted or expired since it was read.
+		inv, err = s.querier.GetProjectInvitation(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get invitation: %w", err)
+		}
+		if collaborator, err := s.settled(inv, uid); collaborator != nil || err != nil {
+			return collaborator, err
+		}
+		return nil, ErrExpired
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to accept invitation: %w", err)
+	}
+	return &Collaborator{
+		ProjectID:    uuidString(row.ProjectID),
+		UserID:       uuidString(row.UserID),
+		Role:         row.Role,
+		InvitationID: uuidString(row.InvitationID),
+		CreatedAt:    row.CreatedAt.Time.UTC(),
+	}, nil
+}
+
+// settled reports the outcome of accepting an invitation that is no longer pending: the
+// user's access when they accepted it themselves, or why it cannot be accepted. Both are nil
+// while the invitation can still be accepted.
+func (s *DefaultService) settled(inv *queries.ProjectInvitation, uid pgtype.UUID) (*Collaborator, error) {
+	switch {
+	case inv.Status == StatusRevoked:
+		return nil, ErrRevoked
+	case inv.Status == StatusAccepted && inv.AcceptedBy == uid:
+		return &Collaborator{
+			ProjectID:    uuidString(inv.ProjectID),
+			UserID:       uuidString(uid),
+			Role:         inv.Role,
+			InvitationID: uuidString(inv.ID),
+			CreatedAt:    inv.AcceptedAt.Time.UTC(),
+		}, nil
+	case inv.Status == StatusAccepted:
+		return nil, ErrAlreadyAccepted
+	case !s.now().Before(inv.ExpiresAt.Time):
+		return nil, ErrExpired
+	default:
+		return nil, nil
+	}
+}
+
+// ownedProject returns the project when the user owns it. Collaborators cannot invite others.
+func (s *DefaultService) ownedProject(
+	ctx context.Context, uid, pid pgtype.UUID,
+) (*queries.GetProjectByIDAndUserIDRow, error) {
+	project, err := s.querier.GetProjectByIDAndUserID(ctx, queries.GetProjectByIDAndUserIDParams{ID: pid, UserID: uid})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	return project, nil
+}
+
+// userEmail returns the email on the user's profile, empty when they have none.
+func (s *DefaultService) userEmail(ctx context.Context, uid pgtype.UUID) (string, error) {
+	profile, err := s.querier.GetUserProfileByID(ctx, uid)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+	return strings.TrimSpace(profile.Email.String), nil
+}
+
+func (s *DefaultService) inviteMessage(inv *Invitation, projectName string) mailer.Message {
+	q := url.Values{}
+	q.Set("invitation", inv.ID)
+	q.Set("exp", strconv.FormatInt(inv.ExpiresAt.Unix(), 10))
+	q.Set("sig", s.signer.sign(inv.ID, inv.Email, inv.ExpiresAt.Unix()))
+	link := s.cfg.AcceptURL + "?" + q.Encode()
+	if strings.Contains(s.cfg.AcceptURL, "?") {
+		link = s.cfg.AcceptURL + "&" + q.Encode()
+	}
+
+	access := "view"
+	if inv.Role == RoleEditor {
+		access = "view and edit"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "You have been invited to %s the project %q on Real Staging AI.\n\n", access, projectName)
+	fmt.Fprintf(&b, "Sign in and accept the invitation here:\n%s\n\n", link)
+	fmt.Fprintf(&b, "The invitation expires on %s. If you were not expecting it, you can ignore this email.\n",
+		inv.ExpiresAt.Format("January 2, 2006"))
+
+	return mailer.Message{
+		To:      inv.Email,
+		Subject: "You're invited to a project on Real Staging AI",
+		Body:    b.String(),
+	}
+}
+
+func (s *DefaultService) toInvitation(row *queries.ProjectInvitation) *Invitation {
+	inv := &Invitation{
+		ID:        uuidString(row.ID),
+		ProjectID: uuidString(row.ProjectID),
+		Email:     row.Email,
+		Role:      row.Role,
+		Status:    row.Status,
+		InvitedBy: uuidString(row.InvitedBy),
+		ExpiresAt: row.ExpiresAt.Time.UTC(),
+		CreatedAt: row.CreatedAt.Time.UTC(),
+	}
+	if inv.Status == StatusPending && !s.now().Before(inv.ExpiresAt) {
+		inv.Status = StatusExpired
+	}
+	if row.AcceptedBy.Valid {
+		acceptedBy := uuidString(row.AcceptedBy)
+		inv.AcceptedBy = &acceptedBy
+	}
+	if row.AcceptedAt.Valid {
+		t := row.AcceptedAt.Time.UTC()
+		inv.AcceptedAt = &t
+	}
+	if row.RevokedAt.Valid {
+		t := row.RevokedAt.Time.UTC()
+		inv.RevokedAt = &t
+	}
+	return inv
+}
+
+// normalizeEmail returns the bare, lower-cased address, rejecting display names and lists.
+func normalizeEmail(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	addr, err := mail.ParseAddress(raw)
+	if err != nil || addr.Address != raw || len(raw) > 255 {
+		return "", fmt.Errorf("%w: email must be a single email address", ErrInvalid)
+	}
+	return strings.ToLower(addr.Address), nil
+}
+
+func parseIDs(userID, projectID string) (pgtype.UUID, pgtype.UUID, error) {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, fmt.Errorf("invalid user ID: %w", err)
+	}
+	pid, err := parseUUID(projectID)
+	if err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, ErrNotFound
+	}
+	return uid, pid, nil
+}
+
+func parseUUID(id string) (pgtype.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+	return pgtype.UUID{Bytes: parsed, Valid: true}, nil
+}
+
+func uuidString(id pgtype.UUID) string {
+	return uuid.UUID(id.Bytes).String()
+}
@@ -0,0 +1,407 @@
+package invitation
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/mailer"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+type fixture struct {
+	ownerID     string
+	projectID   uuid.UUID
+	emails      map[string]string
+	invitations map[uuid.UUID]*queries.ProjectInvitation
+	mailErr     error
+	querier     *queries.QuerierMock
+	mail        *mailer.MailerMock
+	svc         *DefaultService
+	now         time.Time
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	f := &fixture{
+		ownerID:     uuid.NewString(),
+		projectID:   uuid.New(),
+		emails:      map[string]string{},
+		invitations: map[uuid.UUID]*queries.ProjectInvitation{},
+		now:         time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+	f.emails[f.ownerID] = "owner@example.com"
+	f.querier = &queries.QuerierMock{
+		GetProjectByIDAndUserIDFunc: func(
+			ctx context.Context, arg queries.GetProjectByIDAndUserIDParams,
+		) (*queries.GetProjectByIDAndUserIDRow, error) {
+			if uuid.UUID(arg.ID.Bytes) != f.projectID || uuid.UUID(arg.UserID.Bytes).String() != f.ownerID {
+				return nil, pgx.ErrNoRows
+			}
+			return &queries.GetProjectByIDAndUserIDRow{ID: arg.ID, UserID: arg.UserID, Name: "Maple St"}, nil
+		},
+		GetProjectByIDFunc: func(ctx context.Context, id pgtype.UUID) (*queries.GetProjectByIDRow, error) {
+			if uuid.UUID(id.Bytes) != f.projectID {
+				return nil, pgx.ErrNoRows
+			}
+			owner, _ := parseUUID(f.ownerID)
+			return &queries.GetProjectByIDRow{ID: id, UserID: owner, Name: "Maple St"}, nil
+		},
+		GetUserProfileByIDFunc: func(ctx context.Context, id pgtype.UUID) (*queries.GetUserProfileByIDRow, error) {
+			email, ok := f.emails[uuidString(id)]
+			return &queries.GetUserProfileByIDRow{ID: id, Email: pgtype.Text{String: email, Valid: ok}}, nil
+		},
+		CreateProjectInvitationFunc: func(
+			ctx context.Context, arg queries.CreateProjectInvitationParams,
+		) (*queries.ProjectInvitation, error) {
+			id := uuid.New()
+			row := &queries.ProjectInvitation{
+				ID:        pgtype.UUID{Bytes: id, Valid: true},
+				ProjectID: arg.ProjectID,
+				InvitedBy: arg.InvitedBy,
+				Email:     arg.Email,
+				Role:      arg.Role,
+				Status:    StatusPending,
+				ExpiresAt: arg.ExpiresAt,
+				CreatedAt: pgtype.Timestamptz{Time: f.now, Valid: true},
+			}
+			f.invitations[id] = row
+			copied := *row
+			return &copied, nil
+		},
+		GetProjectInvitationFunc: func(ctx context.Context, id pgtype.UUID) (*queries.ProjectInvitation, error) {
+			row, ok := f.invitations[uuid.UUID(id.Bytes)]
+			if !ok {
+				return nil, pgx.ErrNoRows
+			}
+			copied := *row
+			return &copied, nil
+		},
+		ListProjectInvitationsFunc: func(ctx context.Context, projectID pgtype.UUID) ([]*queries.ProjectInvitation, error) {
+			var rows []*queries.ProjectInvitation
+			for _, row := range f.invitations {
+				if row.ProjectID == projectID {
+					rows = append(rows, row)
+				}
+			}
+			return rows, nil
+		},
+		RevokeProjectInvitationFunc: func(
+			ctx context.Context, arg queries.RevokeProjectInvitationParams,
+		) (*queries.RevokeProjectInvitationRow, error) {
+			row, ok := f.invitations[uuid.UUID(arg.ID.Bytes)]
+			if !ok || row.ProjectID != arg.ProjectID || row.Status == StatusRevoked {
+				return nil, pgx.ErrNoRows
+			}
+			row.Status = StatusRevoked
+			row.RevokedAt = pgtype.Timestamptz{Time: f.now, Valid: true}
+			copied := queries.RevokeProjectInvitationRow(*row)
+			return &copied, nil
+		},
+		AcceptProjectInvitationFunc: func(
+			ctx context.Context, arg queries.AcceptProjectInvitationParams,
+		) (*queries.ProjectCollaborator, error) {
+			row, ok := f.invitations[uuid.UUID(arg.ID.Bytes)]
+			if !ok || row.Status != StatusPending || !f.now.Before(row.ExpiresAt.Time) {
+				return nil, pgx.ErrNoRows
+			}
+			row.Status = StatusAccepted
+			row.AcceptedBy = arg.AcceptedBy
+			row.AcceptedAt = pgtype.Timestamptz{Time: f.now, Valid: true}
+			return &queries.ProjectCollaborator{
+				ProjectID:    row.ProjectID,
+				UserID:       arg.AcceptedBy,
+				Role:         row.Role,
+				InvitationID: row.ID,
+				CreatedAt:    pgtype.Timestamptz{Time: f.now, Valid: true},
+			}, nil
+		},
+	}
+	f.mail = &mailer.MailerMock{
+		SendFunc: func(ctx context.Context, msg mailer.Message) error { return f.mailErr },
+	}
+	f.svc = NewDefaultServiceWithQuerier(f.querier, f.mail, config.Invitations{
+		Secret:    strings.Repeat("k", 32),
+		AcceptURL: "https://app.example.com/invitations/accept",
+		TTL:       48 * time.Hour,
+	})
+	f.svc.now = func() time.Time { return f.now }
+	return f
+}
+
+// invite issues an invitation and returns it with the parameters of the emailed link.
+func (f *fixture) invite(t *testing.T, req InviteRequest) (*Invitation, AcceptRequest) {
+	t.Helper()
+	inv, err := f.svc.Invite(context.Background(), f.ownerID, f.projectID.String(), req)
+	require.NoError(t, err)
+	calls := f.mail.SendCalls()
+	return inv, linkParams(t, calls[len(calls)-1].Msg)
+}
+
+// linkParams reads the acceptance link out of an invitation email.
+func linkParams(t *testing.T, msg mailer.Message) AcceptRequest {
+	t.Helper()
+	for _, line := range strings.Split(msg.Body, "\n") {
+		if !strings.HasPrefix(line, "https://") {
+			continue
+		}
+		u, err := url.Parse(line)
+		require.NoError(t, err)
+		exp, err := strconv.ParseInt(u.Query().Get("exp"), 10, 64)
+		require.NoError(t, err)
+		return AcceptRequest{Expires: exp, Signature: u.Query().Get("sig")}
+	}
+	t.Fatal("no link in email")
+	return AcceptRequest{}
+}
+
+func TestDefaultService_Invite(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mutate   func(f *fixture)
+		stranger bool
+		req      InviteRequest
+		wantErr  error
+		validate func(t *testing.T, f *fixture, inv *Invitation)
+	}{
+		{
+			name: "success: emails a signed link for a viewer",
+			req:  InviteRequest{Email: "Buyer@Example.com"},
+			validate: func(t *testing.T, f *fixture, inv *Invitation) {
+				assert.Equal(t, "buyer@example.com", inv.Email)
+				assert.Equal(t, RoleViewer, inv.Role)
+				assert.Equal(t, StatusPending, inv.Status)
+				assert.Equal(t, f.now.Add(48*time.Hour), inv.ExpiresAt)
+
+				msg := f.mail.SendCalls()[0].Msg
+				assert.Equal(t, "buyer@example.com", msg.To)
+				assert.Contains(t, msg.Body, `view the project "Maple St"`)
+				assert.Contains(t, msg.Body, "https://app.example.com/invitations/accept?")
+				assert.Contains(t, msg.Body, "invitation="+inv.ID)
+			},
+		},
+		{
+			name: "success: editor",
+			req:  InviteRequest{Email: "agent@example.com", Role: RoleEditor},
+			validate: func(t *testing.T, f *fixture, inv *Invitation) {
+				assert.Equal(t, RoleEditor, inv.Role)
+				assert.Contains(t, f.mail.SendCalls()[0].Msg.Body, "view and edit")
+			},
+		},
+		{name: "fail: not an email address", req: InviteRequest{Email: "buyer"}, wantErr: ErrInvalid},
+		{name: "fail: display name", req: InviteRequest{Email: "Buyer <buyer@example.com>"}, wantErr: ErrInvalid},
+		{name: "fail: unknown role", req: InviteRequest{Email: "buyer@example.com", Role: "admin"}, wantErr: ErrInvalid},
+		{name: "fail: inviting yourself", req: InviteRequest{Email: "OWNER@example.com"}, wantErr: ErrInvalid},
+		{
+			name:     "fail: another user's project",
+			stranger: true,
+			req:      InviteRequest{Email: "buyer@example.com"},
+			wantErr:  ErrNotFound,
+		},
+		{
+			name:    "fail: email not delivered revokes the invitation",
+			mutate:  func(f *fixture) { f.mailErr = errors.New("connection refused") },
+			req:     InviteRequest{Email: "buyer@example.com"},
+			wantErr: ErrDelivery,
+			validate: func(t *testing.T, f *fixture, _ *Invitation) {
+				require.Len(t, f.querier.RevokeProjectInvitationCalls(), 1)
+				for _, row := range f.invitations {
+					assert.Equal(t, StatusRevoked, row.Status)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFixture(t)
+			if tc.mutate != nil {
+				tc.mutate(f)
+			}
+			userID := f.ownerID
+			if tc.stranger {
+				userID = uuid.NewString()
+			}
+
+			inv, err := f.svc.Invite(context.Background(), userID, f.projectID.String(), tc.req)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			if tc.validate != nil {
+				tc.validate(t, f, inv)
+			}
+		})
+	}
+}
+
+func TestDefaultService_Accept(t *testing.T) {
+	invitee := uuid.NewString()
+
+	testCases := []struct {
+		name     string
+		mutate   func(f *fixture, inv *Invitation, req *AcceptRequest)
+		userID   func(f *fixture) string
+		wantErr  error
+		validate func(t *testing.T, f *fixture, c *Collaborator)
+	}{
+		{
+			name: "success: invitee becomes a collaborator",
+			validate: func(t *testing.T, f *fixture, c *Collaborator) {
+				assert.Equal(t, f.projectID.String(), c.ProjectID)
+				assert.Equal(t, invitee, c.UserID)
+				assert.Equal(t, RoleEditor, c.Role)
+			},
+		},
+		{
+			name:   "success: user without an email on file",
+			mutate: func(f *fixture, inv *Invitation, req *AcceptRequest) { delete(f.emails, invitee) },
+		},
+		{
+			name: "success: accepting again is idempotent",
+			mutate: func(f *fixture, inv *Invitation, req *AcceptRequest) {
+				_, err := f.svc.Accept(context.Background(), invitee, inv.ID, *req)
+				require.NoError(t, err)
+			},
+			validate: func(t *testing.T, f *fixture, c *Collaborator) {
+				assert.Equal(t, invitee, c.UserID)
+				assert.Len(t, f.querier.AcceptProjectInvitationCalls(), 1)
+			},
+		},
+		{
+			name:    "fail: altered signature",
+			mutate:  func(f *fixture, inv *Invitation, req *AcceptRequest) { req.Signature += "x" },
+			wantErr: ErrBadSignature,
+		},
+		{
+			name:    "fail: extended expiry",
+			mutate:  func(f *fixture, inv *Invitation, req *AcceptRequest) { req.Expires += 3600 },
+			wantErr: ErrBadSignature,
+		},
+		{
+			name:    "fail: expired",
+			mutate:  func(f *fixture, inv *Invitation, req *AcceptRequest) { f.now = f.now.Add(49 * time.Hour) },
+			wantErr: ErrExpired,
+		},
+		{
+			name: "fail: revoked",
+			mutate: func(f *fixture, inv *Invitation, req *AcceptRequest) {
+				_, err := f.svc.Revoke(context.Background(), f.ownerID, f.projectID.String(), inv.ID)
+				require.NoError(t, err)
+			},
+			wantErr: ErrRevoked,
+		},
+		{
+			name: "fail: accepted by someone else",
+			mutate: func(f *fixture, inv *Invitation, req *AcceptRequest) {
+				other := uuid.NewString()
+				f.emails[other] = inv.Email
+				_, err := f.svc.Accept(context.Background(), other, inv.ID, *req)
+				require.NoError(t, err)
+			},
+			wantErr: ErrAlreadyAccepted,
+		},
+		{
+			name:    "fail: signed in with a different email",
+			mutate:  func(f *fixture, inv *Invitation, req *AcceptRequest) { f.emails[invitee] = "someone@example.com" },
+			wantErr: ErrWrongRecipient,
+		},
+		{
+			name: "fail: owner cannot accept",
+			mutate: func(f *fixture, inv *Invitation, req *AcceptRequest) {
+				f.emails[f.ownerID] = ""
+			},
+			userID:  func(f *fixture) string { return f.ownerID },
+			wantErr: ErrInvalid,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFixture(t)
+			f.emails[invitee] = "buyer@example.com"
+			inv, req := f.invite(t, InviteRequest{Email: "buyer@example.com", Role: RoleEditor})
+			if tc.mutate != nil {
+				tc.mutate(f, inv, &req)
+			}
+			userID := invitee
+			if tc.userID != nil {
+				userID = tc.userID(f)
+			}
+
+			c, err := f.svc.Accept(context.Background(), userID, inv.ID, req)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if tc.validate != nil {
+				tc.validate(t, f, c)
+			}
+		})
+	}
+}
+
+func TestDefaultService_Revoke(t *testing.T) {
+	t.Run("success: revokes and is idempotent", func(t *testing.T) {
+		f := newFixture(t)
+		inv, _ := f.invite(t, InviteRequest{Email: "buyer@example.com"})
+
+		revoked, err := f.svc.Revoke(context.Background(), f.ownerID, f.projectID.String(), inv.ID)
+		require.NoError(t, err)
+		assert.Equal(t, StatusRevoked, revoked.Status)
+		require.NotNil(t, revoked.RevokedAt)
+
+		again, err := f.svc.Revoke(context.Background(), f.ownerID, f.projectID.String(), inv.ID)
+		require.NoError(t, err)
+		assert.Equal(t, StatusRevoked, again.Status)
+	})
+
+	t.Run("fail: another user's project", func(t *testing.T) {
+		f := newFixture(t)
+		inv, _ := f.invite(t, InviteRequest{Email: "buyer@example.com"})
+
+		_, err := f.svc.Revoke(context.Background(), uuid.NewString(), f.projectID.String(), inv.ID)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Empty(t, f.querier.RevokeProjectInvitationCalls())
+	})
+
+	t.Run("fail: unknown invitation", func(t *testing.T) {
+		f := newFixture(t)
+
+		_, err := f.svc.Revoke(context.Background(), f.ownerID, f.projectID.String(), uuid.NewString())
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestDefaultService_List(t *testing.T) {
+	t.Run("success: reports pending invitations past their expiry as expired", func(t *testing.T) {
+		f := newFixture(t)
+		f.invite(t, InviteRequest{Email: "buyer@example.com"})
+		f.now = f.now.Add(72 * time.Hour)
+
+		invitations, err := f.svc.List(context.Background(), f.ownerID, f.projectID.String())
+		require.NoError(t, err)
+		require.Len(t, invitations, 1)
+		assert.Equal(t, StatusExpired, invitations[0].Status)
+	})
+
+	t.Run("fail: another user's project", func(t *testing.T) {
+		f := newFixture(t)
+
+		_, err := f.svc.List(context.Background(), uuid.NewString(), f.projectID.String())
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
@@ -0,0 +1,83 @@
+// Package invitation lets a project's owner invite someone by email to view or edit that one
+// project without joining the rest of the account. The email carries a signed acceptance
+// link; accepting it as a signed-in user makes them a collaborator. Invitations are tracked
+// per project and can be revoked, which also removes any access they granted.
+package invitation
+
+import (
+	"errors"
+	"time"
+)
+
+// Roles an invitation can grant.
+const (
+	RoleViewer = "viewer"
+	RoleEditor = "editor"
+)
+
+// Invitation states. Expired is never stored: it is reported for pending invitations past
+// their expiry.
+const (
+	StatusPending  = "pending"
+	StatusAccepted = "accepted"
+	StatusRevoked  = "revoked"
+	StatusExpired  = "expired"
+)
+
+var (
+	// ErrNotFound is returned when the project or invitation does not exist or the caller does not own the project.
+	ErrNotFound = errors.New("not found")
+	// ErrInvalid wraps validation failures of an invite request.
+	ErrInvalid = errors.New("invalid invitation request")
+	// ErrBadSignature is returned for acceptance links that were not issued by this server or were altered.
+	ErrBadSignature = errors.New("invalid invitation link")
+	// ErrExpired is returned when accepting an invitation past its expiry.
+	ErrExpired = errors.New("invitation has expired")
+	// ErrRevoked is returned when accepting an invitation the owner has revoked.
+	ErrRevoked = errors.New("invitation has been revoked")
+	// ErrAlreadyAccepted is returned when another user has already accepted the invitation.
+	ErrAlreadyAccepted = errors.New("invitation has already been accepted")
+	// ErrWrongRecipient is returned when the accepting user's email differs from the invited one.
+	ErrWrongRecipient = errors.New("invitation was sent to a different email address")
+	// ErrDelivery is returned when the invitation email could not be sent. The invitation is
+	// revoked so it cannot be accepted later.
+	ErrDelivery = errors.New("failed to send invitation email")
+)
+
+// InviteRequest invites one email address to a project.
+type InviteRequest struct {
+	Email string `json:"email"`
+	// Role is viewer (default) or editor.
+	Role string `json:"role"`
+}
+
+// AcceptRequest carries the signed query parameters of an acceptance link.
+type AcceptRequest struct {
+	Expires   int64  `json:"exp"`
+	Signature string `json:"sig"`
+}
+
+// Invitation is an invitation as shown to the project owner. The acceptance link is only
+// ever sent to the invited address.
+type Invitation struct {
+	ID         string     `json:"id"`
+	ProjectID  string     `json:"project_id"`
+	Email      string     `json:"email"`
+	Role       string     `json:"role"`
+	Status     string     `json:"status"`
+	InvitedBy  string     `json:"invited_by"`
+	AcceptedBy *string    `json:"accepted_by,omitempty"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// Collaborator is a user's access to a project granted by an accepted invitation.
+type Collaborator struct {
+	ProjectID    string    `json:"project_id"`
+	UserID       string    `json:"user_id"`
+	Role         string    `json:"role"`
+	InvitationID string    `json:"invitation_id"`
+	CreatedAt    time.Time `json:"created_at"`
+}
@@ -0,0 +1,17 @@
+package invitation
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service defines the interface for inviting collaborators to a project.
+type Service interface {
+	// Invite records an invitation to one of the user's projects and emails its acceptance link.
+	Invite(ctx context.Context, userID, projectID string, req InviteRequest) (*Invitation, error)
+	// List returns the invitations of one of the user's projects, newest first.
+	List(ctx context.Context, userID, projectID string) ([]*Invitation, error)
+	// Revoke cancels an invitation to one of the user's projects and removes the access it granted.
+	Revoke(ctx context.Context, userID, projectID, invitationID string) (*Invitation, error)
+	// Accept checks an acceptance link and makes the user a collaborator on the invitation's project.
+	Accept(ctx context.Context, userID, invitationID string, req AcceptRequest) (*Collaborator, error)
+}
@@ -0,0 +1,28 @@
+package invitation
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+)
+
+// signer computes acceptance link signatures. The invited address is signed along with the
+// invitation and its expiry, so a link is only good for the invitation it was sent for.
+type signer struct {
+	secret []byte
+}
+
+func (s signer) sign(invitationID, email string, expires int64) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(strings.Join([]string{
+		"invitation-v1", invitationID, strings.ToLower(email), strconv.FormatInt(expires, 10),
+	}, "\n")))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s signer) verify(invitationID, email string, expires int64, signature string) bool {
+	want := s.sign(invitationID, email, expires)
+	return hmac.Equal([]byte(want), []byte(signature))
+}
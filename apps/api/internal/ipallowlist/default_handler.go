@@ -0,0 +1,150 @@
+package ipallowlist
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// DefaultHandler serves IP allow-lists, break-glass and the audit log over HTTP.
+type DefaultHandler struct {
+	service   Service
+	userRepo  user.Repository
+	extractIP echo.IPExtractor
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, userRepo user.Repository, cfg config.IPAllowlist) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo, extractIP: NewIPExtractor(cfg)}
+}
+
+// ErrorResponse is a simple JSON error envelope for handler responses.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// Get handles GET /api/v1/organizations/:id/ip-allowlist.
+func (h *DefaultHandler) Get(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	list, err := h.service.Get(c.Request().Context(), userID, c.Param("id"), h.extractIP(c.Request()))
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, list)
+}
+
+// Put handles PUT /api/v1/organizations/:id/ip-allowlist.
+func (h *DefaultHandler) Put(c echo.Context) error {
+	var req PutRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid request format"})
+	}
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	list, err := h.service.Put(c.Request().Context(), userID, c.Param("id"), h.extractIP(c.Request()), req)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, list)
+}
+
+// BreakGlass handles POST /api/v1/organizations/:id/ip-allowlist/break-glass. The route is
+// exempt from allow-list enforcement, so a locked-out admin can reach it.
+func (h *DefaultHandler) BreakGlass(c echo.Context) error {
+	var req BreakGlassRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid request format"})
+	}
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	window, err := h.service.BreakGlass(c.Request().Context(), userID, c.Param("id"), h.extractIP(c.Request()), req)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusCreated, window)
+}
+
+// ListAudit handles GET /api/v1/organizations/:id/audit-log.
+func (h *DefaultHandler) ListAudit(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+	limit, offset := parseLimitOffset(c)
+
+	// Fetch one extra row to know whether another page exists.
+	events, err := h.service.ListAudit(c.Request().Context(), userID, c.Param("id"), limit+1, offset)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	hasMore := len(events) > int(limit)
+	if hasMore {
+		events = events[:limit]
+	}
+	return c.JSON(http.StatusOK, AuditListResponse{Events: events, Limit: limit, Offset: offset, HasMore: hasMore})
+}
+
+func unauthorized(c echo.Context) error {
+	return c.JSON(http.StatusUnauthorized, ErrorResponse{
+		Error:   "unauthorized",
+		Message: "Unable to resolve current user",
+	})
+}
+
+// writeError maps service errors to responses.
+func (h *DefaultHandler) writeError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Organization not found"})
+	case errors.Is(err, ErrForbidden), errors.Is(err, ErrBreakGlassDisabled):
+		return c.JSON(http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: err.Error()})
+	case errors.Is(err, ErrInvalid):
+		return c.JSON(http.StatusUnprocessableEntity, ErrorResponse{Error: "validation_failed", Message: err.Error()})
+	case errors.Is(err, ErrLockout):
+		return c.JSON(http.StatusConflict, ErrorResponse{Error: "lockout", Message: err.Error()})
+	default:
+		c.Logger().Errorf("IP allow-list request failed: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to process IP allow-list request",
+		})
+	}
+}
+
+func parseLimitOffset(c echo.Context) (int32, int32) {
+	limit := DefaultAuditLimit
+	offset := int32(0)
+
+	if v := c.QueryParam("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 && n <= int(MaxAuditLimit) {
+			// #nosec G109,G115 -- Value is validated to be positive and within MaxAuditLimit
+			limit = int32(n)
+		}
+	}
+	if v := c.QueryParam("offset"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 && n <= 2147483647 {
+			// #nosec G109,G115 -- Value is validated to fit in int32 range
+			offset = int32(n)
+		}
+	}
+	return limit, offset
+}
@@ -0,0 +1,199 @@
+package ipallowlist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func userRepoFor(userID uuid.UUID) *user.RepositoryMock {
+	return &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+		},
+	}
+}
+
+func newContext(method, target, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("X-Test-User", "auth0|user")
+	req.RemoteAddr = "203.0.113.9:51234"
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
+
+func TestDefaultHandler_Put(t *testing.T) {
+	userID := uuid.New()
+	orgID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+		expectedError  string
+	}{
+		{name: "success: saved", body: `{"cidrs":["203.0.113.0/24"]}`, expectedStatus: http.StatusOK},
+		{name: "fail: malformed body", body: `{`, expectedStatus: http.StatusBadRequest, expectedError: "bad_request"},
+		{name: "fail: not a member", body: `{}`, err: ErrNotFound, expectedStatus: http.StatusNotFound, expectedError: "not_found"},
+		{name: "fail: not an admin", body: `{}`, err: ErrForbidden, expectedStatus: http.StatusForbidden, expectedError: "forbidden"},
+		{
+			name: "fail: invalid range", body: `{"cidrs":["office"]}`, err: fmt.Errorf("%w: office", ErrInvalid),
+			expectedStatus: http.StatusUnprocessableEntity, expectedError: "validation_failed",
+		},
+		{
+			name: "fail: lockout", body: `{"cidrs":["192.0.2.0/24"]}`, err: ErrLockout,
+			expectedStatus: http.StatusConflict, expectedError: "lockout",
+		},
+		{
+			name: "fail: database error", body: `{}`, err: errors.New("boom"),
+			expectedStatus: http.StatusInternalServerError, expectedError: "internal_server_error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				PutFunc: func(ctx context.Context, uid, oid, clientIP string, req PutRequest) (*Allowlist, error) {
+					assert.Equal(t, userID.String(), uid)
+					assert.Equal(t, orgID.String(), oid)
+					assert.Equal(t, "203.0.113.9", clientIP)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &Allowlist{OrganizationID: oid, CIDRs: req.CIDRs, ClientIP: clientIP}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, userRepoFor(userID), config.IPAllowlist{})
+			c, rec := newContext(http.MethodPut, "/api/v1/organizations/"+orgID.String()+"/ip-allowlist", tc.body)
+			c.SetParamNames("id")
+			c.SetParamValues(orgID.String())
+
+			require.NoError(t, h.Put(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedError != "" {
+				var res ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				assert.Equal(t, tc.expectedError, res.Error)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_ListAudit(t *testing.T) {
+	testCases := []struct {
+		name          string
+		query         string
+		rows          int
+		expectedLimit int32
+		expectedMore  bool
+	}{
+		{name: "success: defaults", rows: 2, expectedLimit: DefaultAuditLimit},
+		{name: "success: has more", query: "?limit=2&offset=4", rows: 3, expectedLimit: 2, expectedMore: true},
+		{name: "success: limit out of range", query: "?limit=1000", expectedLimit: DefaultAuditLimit},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				ListAuditFunc: func(ctx context.Context, userID, orgID string, limit, offset int32) ([]AuditEvent, error) {
+					assert.Equal(t, tc.expectedLimit+1, limit)
+					return make([]AuditEvent, tc.rows), nil
+				},
+			}
+			h := NewDefaultHandler(svc, userRepoFor(uuid.New()), config.IPAllowlist{})
+			c, rec := newContext(http.MethodGet, "/api/v1/organizations/o/audit-log"+tc.query, "")
+
+			require.NoError(t, h.ListAudit(c))
+			require.Equal(t, http.StatusOK, rec.Code)
+			var res AuditListResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+			assert.Equal(t, tc.expectedLimit, res.Limit)
+			assert.Equal(t, tc.expectedMore, res.HasMore)
+			assert.LessOrEqual(t, len(res.Events), int(tc.expectedLimit))
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	testCases := []struct {
+		name           string
+		cfg            config.IPAllowlist
+		path           string
+		forwardedFor   string
+		authenticated  bool
+		err            error
+		expectedIP     string
+		expectedStatus int
+	}{
+		{name: "success: allowed", path: "/api/v1/projects", authenticated: true, expectedIP: "203.0.113.9", expectedStatus: http.StatusOK},
+		{name: "success: anonymous passes through", path: "/api/v1/projects", expectedStatus: http.StatusOK},
+		{name: "success: exempt route", path: breakGlassRoute, authenticated: true, expectedStatus: http.StatusOK},
+		{
+			name: "success: trusted forwarded address", cfg: config.IPAllowlist{TrustForwardedFor: true},
+			path: "/api/v1/projects", forwardedFor: "198.51.100.7, 10.0.0.1", authenticated: true,
+			expectedIP: "198.51.100.7", expectedStatus: http.StatusOK,
+		},
+		{
+			name: "fail: outside the allow-list", path: "/api/v1/projects", authenticated: true,
+			err: ErrIPNotAllowed, expectedIP: "203.0.113.9", expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "fail: check errors", path: "/api/v1/projects", authenticated: true,
+			err: errors.New("db down"), expectedIP: "203.0.113.9", expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				CheckFunc: func(ctx context.Context, req Request) error {
+					assert.Equal(t, "auth0|jane", req.Auth0Sub)
+					assert.Equal(t, tc.expectedIP, req.IP)
+					assert.Equal(t, tc.path, req.Path)
+					return tc.err
+				},
+			}
+			c, rec := newContext(http.MethodGet, "/", "")
+			c.SetPath(tc.path)
+			if tc.forwardedFor != "" {
+				// Forwarded addresses are only read from a proxy on a private network.
+				c.Request().RemoteAddr = "10.0.0.2:443"
+				c.Request().Header.Set(echo.HeaderXForwardedFor, tc.forwardedFor)
+			}
+			if tc.authenticated {
+				c.Set("user", &jwt.Token{Claims: jwt.MapClaims{"sub": "auth0|jane"}})
+			}
+
+			mw := Middleware(svc, tc.cfg, breakGlassRoute)
+			require.NoError(t, mw(func(c echo.Context) error { return c.NoContent(http.StatusOK) })(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusForbidden {
+				assert.Contains(t, rec.Body.String(), `"error":"ip_not_allowed"`)
+			}
+			if tc.expectedIP == "" {
+				assert.Empty(t, svc.CheckCalls())
+			}
+		})
+	}
+}
+
+const breakGlassRoute = "/api/v1/organizations/:id/ip-allowlist/break-glass"
@@ -0,0 +1,261 @@
+package ipallowlist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/organization"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// DefaultService implements Service.
+type DefaultService struct {
+	querier queries.Querier
+	cfg     config.IPAllowlist
+	now     func() time.Time
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService with a database.
+func NewDefaultService(db storage.Database, cfg config.IPAllowlist) *DefaultService {
+	return NewDefaultServiceWithQuerier(queries.New(db), cfg)
+}
+
+// NewDefaultServiceWithQuerier creates a new DefaultService with a custom querier (for testing).
+func NewDefaultServiceWithQuerier(querier queries.Querier, cfg config.IPAllowlist) *DefaultService {
+	return &DefaultService{querier: querier, cfg: cfg, now: time.Now}
+}
+
+// Get returns the organization's allow-list.
+func (s *DefaultService) Get(ctx context.Context, userID, orgID, clientIP string) (*Allowlist, error) {
+	oid, _, err := s.authorizeAdmin(ctx, userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	ranges, err := s.querier.GetOrganizationIPAllowlist(ctx, oid)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get IP allow-list: %w", err)
+	}
+	return &Allowlist{OrganizationID: oid.String(), CIDRs: ranges, ClientIP: clientIP}, nil
+}
+
+// Put replaces the organization's allow-list.
+func (s *DefaultService) Put(ctx context.Context, userID, orgID, clientIP string, req PutRequest) (*Allowlist, error) {
+	oid, uid, err := s.authorizeAdmin(ctx, userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	ranges, err := ParseRanges(req.CIDRs)
+	if err != nil {
+		return nil, err
+	}
+	if len(ranges) > 0 && !allows(ranges, clientIP) {
+		return nil, fmt.Errorf("%w (%s); add it first or you will be locked out", ErrLockout, clientIP)
+	}
+
+	before, err := s.querier.GetOrganizationIPAllowlist(ctx, oid)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get IP allow-list: %w", err)
+	}
+	saved, err := s.querier.SetOrganizationIPAllowlist(ctx, queries.SetOrganizationIPAllowlistParams{
+		ID:          oid,
+		IpAllowlist: ranges,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save IP allow-list: %w", err)
+	}
+	err = s.audit(ctx, oid, uid, EventUpdated, clientIP, map[string]any{"before": before, "after": saved})
+	if err != nil {
+		return nil, err
+	}
+	return &Allowlist{OrganizationID: oid.String(), CIDRs: saved, ClientIP: clientIP}, nil
+}
+
+// BreakGlass opens a break-glass window for the admin.
+func (s *DefaultService) BreakGlass(
+	ctx context.Context, userID, orgID, clientIP string, req BreakGlassRequest,
+) (*BreakGlass, error) {
+	if s.cfg.BreakGlassDuration <= 0 {
+		return nil, ErrBreakGlassDisabled
+	}
+	oid, uid, err := s.authorizeAdmin(ctx, userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if len(reason) < minReasonLength || len(reason) > maxReasonLength {
+		return nil, fmt.Errorf("%w: reason must be %d to %d characters", ErrInvalid, minReasonLength, maxReasonLength)
+	}
+	ranges, err := s.querier.GetOrganizationIPAllowlist(ctx, oid)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get IP allow-list: %w", err)
+	}
+	if len(ranges) == 0 {
+		return nil, fmt.Errorf("%w: the organization has no IP allow-list", ErrInvalid)
+	}
+
+	row, err := s.querier.CreateOrganizationBreakGlass(ctx, queries.CreateOrganizationBreakGlassParams{
+		OrganizationID: oid,
+		UserID:         uid,
+		Reason:         reason,
+		Ip:             clientIP,
+		ExpiresAt:      pgtype.Timestamptz{Time: s.now().Add(s.cfg.BreakGlassDuration), Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open break-glass window: %w", err)
+	}
+	err = s.audit(ctx, oid, uid, EventBreakGlassOpened, clientIP, map[string]any{
+		"break_glass_id": row.ID.String(),
+		"reason":         reason,
+		"expires_at":     row.ExpiresAt.Time,
+	})
+	if err != nil {
+		return nil, err
+	}
+	logging.Default().Warn(ctx, "IP allow-list break-glass opened",
+		"organization_id", oid.String(), "user_id", userID, "ip", clientIP, "reason", reason,
+		"expires_at", row.ExpiresAt.Time)
+
+	return &BreakGlass{
+		ID:             row.ID.String(),
+		OrganizationID: row.OrganizationID.String(),
+		Reason:         row.Reason,
+		IP:             row.Ip,
+		CreatedAt:      row.CreatedAt.Time,
+		ExpiresAt:      row.ExpiresAt.Time,
+	}, nil
+}
+
+// ListAudit returns a page of the organization's audit log.
+func (s *DefaultService) ListAudit(ctx context.Context, userID, orgID string, limit, offset int32) ([]AuditEvent, error) {
+	oid, _, err := s.authorizeAdmin(ctx, userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.querier.ListOrganizationAuditLog(ctx, queries.ListOrganizationAuditLogParams{
+		OrganizationID: oid,
+		Limit:          limit,
+		Offset:         offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit log: %w", err)
+	}
+	events := make([]AuditEvent, 0, len(rows))
+	for _, row := range rows {
+		event := AuditEvent{
+			ID:             row.ID,
+			OrganizationID: row.OrganizationID.String(),
+			Type:           row.EventType,
+			IP:             row.Ip,
+			Metadata:       json.RawMessage(row.Metadata),
+			CreatedAt:      row.CreatedAt.Time,
+		}
+		if row.ActorID.Valid {
+			actor := row.ActorID.String()
+			event.ActorID = &actor
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// Check enforces the allow-lists of the organizations the requesting user belongs to. A
+// request let through by a break-glass window is refused when it cannot be audited, so no
+// bypass goes unrecorded.
+func (s *DefaultService) Check(ctx context.Context, req Request) error {
+	rows, err := s.querier.ListIPAllowlistsForSubject(ctx, req.Auth0Sub)
+	if err != nil {
+		return fmt.Errorf("failed to list IP allow-lists: %w", err)
+	}
+	for _, row := range rows {
+		if allows(row.IpAllowlist, req.IP) {
+			continue
+		}
+		if !row.BreakGlassID.Valid {
+			return fmt.Errorf("%w: %s", ErrIPNotAllowed, req.IP)
+		}
+		err := s.audit(ctx, row.OrganizationID, row.UserID, EventBreakGlassRequest, req.IP, map[string]any{
+			"break_glass_id": row.BreakGlassID.String(),
+			"method":         req.Method,
+			"path":           req.Path,
+		})
+		if err != nil {
+			return err
+		}
+		logging.Default().Warn(ctx, "Request allowed by IP allow-list break-glass",
+			"organization_id", row.OrganizationID.String(), "user_id", row.UserID.String(), "ip", req.IP,
+			"method", req.Method, "path", req.Path)
+	}
+	return nil
+}
+
+// authorizeAdmin returns the parsed organization and user IDs when the user is an admin of
+// the organization. Non-members get ErrNotFound, so organizations are not revealed to
+// outsiders.
+func (s *DefaultService) authorizeAdmin(ctx context.Context, userID, orgID string) (pgtype.UUID, pgtype.UUID, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, fmt.Errorf("invalid user ID: %w", err)
+	}
+	parsed, err := uuid.Parse(orgID)
+	if err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, ErrNotFound
+	}
+	oid := pgtype.UUID{Bytes: parsed, Valid: true}
+	member, err := s.querier.GetOrganizationMember(ctx, queries.GetOrganizationMemberParams{
+		OrganizationID: oid,
+		UserID:         pgtype.UUID{Bytes: uid, Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return pgtype.UUID{}, pgtype.UUID{}, ErrNotFound
+	}
+	if err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, fmt.Errorf("failed to get organization membership: %w", err)
+	}
+	if member.Role != organization.RoleAdmin {
+		return pgtype.UUID{}, pgtype.UUID{}, ErrForbidden
+	}
+	return oid, member.UserID, nil
+}
+
+func (s *DefaultService) audit(
+	ctx context.Context, oid, actorID pgtype.UUID, eventType, ip string, metadata map[string]any,
+) error {
+	body, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit event: %w", err)
+	}
+	err = s.querier.RecordOrganizationAuditEvent(ctx, queries.RecordOrganizationAuditEventParams{
+		OrganizationID: oid,
+		ActorID:        actorID,
+		EventType:      eventType,
+		Ip:             ip,
+		Metadata:       body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record audit event: %w", err)
+	}
+	return nil
+}
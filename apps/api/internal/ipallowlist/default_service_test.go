@@ -0,0 +1,256 @@
+package ipallowlist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/organization"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+var testConfig = config.IPAllowlist{BreakGlassDuration: time.Hour}
+
+func pgUUID(id uuid.UUID) pgtype.UUID {
+	return pgtype.UUID{Bytes: id, Valid: true}
+}
+
+// memberOf returns a GetOrganizationMember stub: the user holds role, or is no member when
+// role is empty.
+func memberOf(role string) func(context.Context, queries.GetOrganizationMemberParams) (*queries.OrganizationMember, error) {
+	return func(ctx context.Context, arg queries.GetOrganizationMemberParams) (*queries.OrganizationMember, error) {
+		if role == "" {
+			return nil, pgx.ErrNoRows
+		}
+		return &queries.OrganizationMember{OrganizationID: arg.OrganizationID, UserID: arg.UserID, Role: role}, nil
+	}
+}
+
+func TestDefaultService_Put(t *testing.T) {
+	userID := uuid.New()
+	orgID := uuid.New()
+
+	testCases := []struct {
+		name      string
+		role      string
+		cidrs     []string
+		clientIP  string
+		expectErr error
+	}{
+		{name: "success: saved", role: organization.RoleAdmin, cidrs: []string{"203.0.113.0/24"}, clientIP: "203.0.113.9"},
+		{name: "success: cleared from anywhere", role: organization.RoleAdmin, cidrs: []string{}, clientIP: "198.51.100.1"},
+		{name: "fail: not a member", cidrs: []string{}, expectErr: ErrNotFound},
+		{name: "fail: not an admin", role: organization.RoleMember, cidrs: []string{}, expectErr: ErrForbidden},
+		{name: "fail: invalid range", role: organization.RoleAdmin, cidrs: []string{"office"}, expectErr: ErrInvalid},
+		{
+			name: "fail: would lock the admin out", role: organization.RoleAdmin,
+			cidrs: []string{"203.0.113.0/24"}, clientIP: "198.51.100.1", expectErr: ErrLockout,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var audited *queries.RecordOrganizationAuditEventParams
+			q := &queries.QuerierMock{
+				GetOrganizationMemberFunc: memberOf(tc.role),
+				GetOrganizationIPAllowlistFunc: func(ctx context.Context, id pgtype.UUID) ([]string, error) {
+					return []string{"192.0.2.0/24"}, nil
+				},
+				SetOrganizationIPAllowlistFunc: func(ctx context.Context, arg queries.SetOrganizationIPAllowlistParams) ([]string, error) {
+					assert.Equal(t, pgUUID(orgID), arg.ID)
+					return arg.IpAllowlist, nil
+				},
+				RecordOrganizationAuditEventFunc: func(ctx context.Context, arg queries.RecordOrganizationAuditEventParams) error {
+					audited = &arg
+					return nil
+				},
+			}
+			svc := NewDefaultServiceWithQuerier(q, testConfig)
+
+			list, err := svc.Put(context.Background(), userID.String(), orgID.String(), tc.clientIP, PutRequest{CIDRs: tc.cidrs})
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+				assert.Empty(t, q.SetOrganizationIPAllowlistCalls())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.cidrs, list.CIDRs)
+			assert.Equal(t, tc.clientIP, list.ClientIP)
+			require.NotNil(t, audited)
+			assert.Equal(t, EventUpdated, audited.EventType)
+			assert.Equal(t, pgUUID(userID), audited.ActorID)
+			assert.JSONEq(t, `{"before":["192.0.2.0/24"],"after":`+mustJSON(t, tc.cidrs)+`}`, string(audited.Metadata))
+		})
+	}
+}
+
+func TestDefaultService_BreakGlass(t *testing.T) {
+	userID := uuid.New()
+	orgID := uuid.New()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name      string
+		cfg       config.IPAllowlist
+		role      string
+		reason    string
+		ranges    []string
+		expectErr error
+	}{
+		{name: "success: opened", cfg: testConfig, role: organization.RoleAdmin, reason: "Office IP changed overnight", ranges: []string{"192.0.2.0/24"}},
+		{name: "fail: disabled", role: organization.RoleAdmin, reason: "Office IP changed overnight", expectErr: ErrBreakGlassDisabled},
+		{name: "fail: not an admin", cfg: testConfig, role: organization.RoleViewer, reason: "Office IP changed overnight", expectErr: ErrForbidden},
+		{name: "fail: short reason", cfg: testConfig, role: organization.RoleAdmin, reason: " locked ", expectErr: ErrInvalid},
+		{name: "fail: no allow-list", cfg: testConfig, role: organization.RoleAdmin, reason: "Office IP changed overnight", ranges: []string{}, expectErr: ErrInvalid},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				GetOrganizationMemberFunc: memberOf(tc.role),
+				GetOrganizationIPAllowlistFunc: func(ctx context.Context, id pgtype.UUID) ([]string, error) {
+					return tc.ranges, nil
+				},
+				CreateOrganizationBreakGlassFunc: func(ctx context.Context, arg queries.CreateOrganizationBreakGlassParams) (*queries.OrganizationBreakGlass, error) {
+					assert.Equal(t, now.Add(time.Hour), arg.ExpiresAt.Time)
+					assert.Equal(t, "198.51.100.1", arg.Ip)
+					return &queries.OrganizationBreakGlass{
+						ID: pgUUID(uuid.New()), OrganizationID: arg.OrganizationID, UserID: arg.UserID,
+						Reason: arg.Reason, Ip: arg.Ip, ExpiresAt: arg.ExpiresAt,
+					}, nil
+				},
+				RecordOrganizationAuditEventFunc: func(ctx context.Context, arg queries.RecordOrganizationAuditEventParams) error {
+					assert.Equal(t, EventBreakGlassOpened, arg.EventType)
+					assert.Contains(t, string(arg.Metadata), "Office IP changed overnight")
+					return nil
+				},
+			}
+			svc := NewDefaultServiceWithQuerier(q, tc.cfg)
+			svc.now = func() time.Time { return now }
+
+			window, err := svc.BreakGlass(context.Background(), userID.String(), orgID.String(), "198.51.100.1",
+				BreakGlassRequest{Reason: tc.reason})
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+				assert.Empty(t, q.CreateOrganizationBreakGlassCalls())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, now.Add(time.Hour), window.ExpiresAt)
+			assert.Len(t, q.RecordOrganizationAuditEventCalls(), 1)
+		})
+	}
+}
+
+func TestDefaultService_Check(t *testing.T) {
+	orgA, orgB := uuid.New(), uuid.New()
+	userID := uuid.New()
+	breakGlassID := uuid.New()
+	row := func(orgID uuid.UUID, ranges []string, breakGlass bool) *queries.ListIPAllowlistsForSubjectRow {
+		r := &queries.ListIPAllowlistsForSubjectRow{OrganizationID: pgUUID(orgID), UserID: pgUUID(userID), IpAllowlist: ranges}
+		if breakGlass {
+			r.BreakGlassID = pgUUID(breakGlassID)
+		}
+		return r
+	}
+
+	testCases := []struct {
+		name        string
+		rows        []*queries.ListIPAllowlistsForSubjectRow
+		auditErr    error
+		expectErr   error
+		expectAudit int
+	}{
+		{name: "success: no allow-lists"},
+		{
+			name: "success: inside every list",
+			rows: []*queries.ListIPAllowlistsForSubjectRow{row(orgA, []string{"203.0.113.0/24"}, false), row(orgB, []string{"203.0.113.9/32"}, false)},
+		},
+		{
+			name:        "success: outside, through break-glass",
+			rows:        []*queries.ListIPAllowlistsForSubjectRow{row(orgA, []string{"192.0.2.0/24"}, true)},
+			expectAudit: 1,
+		},
+		{
+			name:      "fail: outside one of several lists",
+			rows:      []*queries.ListIPAllowlistsForSubjectRow{row(orgA, []string{"203.0.113.0/24"}, false), row(orgB, []string{"192.0.2.0/24"}, false)},
+			expectErr: ErrIPNotAllowed,
+		},
+		{
+			name:        "fail: break-glass request cannot be audited",
+			rows:        []*queries.ListIPAllowlistsForSubjectRow{row(orgA, []string{"192.0.2.0/24"}, true)},
+			auditErr:    errors.New("db down"),
+			expectAudit: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				ListIPAllowlistsForSubjectFunc: func(ctx context.Context, auth0Sub string) ([]*queries.ListIPAllowlistsForSubjectRow, error) {
+					assert.Equal(t, "auth0|jane", auth0Sub)
+					return tc.rows, nil
+				},
+				RecordOrganizationAuditEventFunc: func(ctx context.Context, arg queries.RecordOrganizationAuditEventParams) error {
+					assert.Equal(t, EventBreakGlassRequest, arg.EventType)
+					assert.JSONEq(t, `{"break_glass_id":"`+breakGlassID.String()+`","method":"GET","path":"/api/v1/projects"}`,
+						string(arg.Metadata))
+					return tc.auditErr
+				},
+			}
+			svc := NewDefaultServiceWithQuerier(q, testConfig)
+
+			err := svc.Check(context.Background(), Request{
+				Auth0Sub: "auth0|jane", IP: "203.0.113.9", Method: "GET", Path: "/api/v1/projects",
+			})
+			switch {
+			case tc.expectErr != nil:
+				assert.ErrorIs(t, err, tc.expectErr)
+			case tc.auditErr != nil:
+				assert.Error(t, err)
+				assert.NotErrorIs(t, err, ErrIPNotAllowed)
+			default:
+				assert.NoError(t, err)
+			}
+			assert.Len(t, q.RecordOrganizationAuditEventCalls(), tc.expectAudit)
+		})
+	}
+}
+
+func TestDefaultService_ListAudit(t *testing.T) {
+	userID := uuid.New()
+	orgID := uuid.New()
+	q := &queries.QuerierMock{
+		GetOrganizationMemberFunc: memberOf(organization.RoleAdmin),
+		ListOrganizationAuditLogFunc: func(ctx context.Context, arg queries.ListOrganizationAuditLogParams) ([]*queries.OrganizationAuditLog, error) {
+			assert.Equal(t, int32(51), arg.Limit)
+			return []*queries.OrganizationAuditLog{
+				{ID: 2, OrganizationID: pgUUID(orgID), ActorID: pgUUID(userID), EventType: EventBreakGlassOpened, Metadata: []byte(`{}`)},
+				{ID: 1, OrganizationID: pgUUID(orgID), EventType: EventUpdated, Metadata: []byte(`{}`)},
+			}, nil
+		},
+	}
+	svc := NewDefaultServiceWithQuerier(q, testConfig)
+
+	events, err := svc.ListAudit(context.Background(), userID.String(), orgID.String(), 51, 0)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, userID.String(), *events[0].ActorID)
+	assert.Nil(t, events[1].ActorID)
+}
+
+func mustJSON(t *testing.T, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(b)
+}
@@ -0,0 +1,50 @@
+package ipallowlist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRanges(t *testing.T) {
+	testCases := []struct {
+		name      string
+		raw       []string
+		expected  []string
+		expectErr bool
+	}{
+		{name: "success: empty", raw: nil, expected: []string{}},
+		{
+			name:     "success: canonical and deduplicated",
+			raw:      []string{" 203.0.113.7/24", "203.0.113.0/24", "198.51.100.4", "2001:db8::1/32"},
+			expected: []string{"203.0.113.0/24", "198.51.100.4/32", "2001:db8::/32"},
+		},
+		{name: "fail: not a range", raw: []string{"office"}, expectErr: true},
+		{name: "fail: prefix too long", raw: []string{"10.0.0.0/33"}, expectErr: true},
+		{name: "fail: too many ranges", raw: make([]string, maxRanges+1), expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ranges, err := ParseRanges(tc.raw)
+			if tc.expectErr {
+				assert.ErrorIs(t, err, ErrInvalid)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ranges)
+		})
+	}
+}
+
+func TestAllows(t *testing.T) {
+	ranges := []string{"203.0.113.0/24", "2001:db8::/32"}
+
+	assert.True(t, allows(ranges, "203.0.113.50"))
+	assert.True(t, allows(ranges, "::ffff:203.0.113.50"))
+	assert.True(t, allows(ranges, "2001:db8::beef"))
+	assert.False(t, allows(ranges, "198.51.100.1"))
+	assert.False(t, allows(ranges, "not-an-ip"))
+	assert.False(t, allows(nil, "203.0.113.50"))
+}
@@ -0,0 +1,66 @@
+package ipallowlist
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+// NewIPExtractor returns how client addresses are read under cfg.
+func NewIPExtractor(cfg config.IPAllowlist) echo.IPExtractor {
+	if cfg.TrustForwardedFor {
+		return echo.ExtractIPFromXFFHeader()
+	}
+	return echo.ExtractIPDirect()
+}
+
+// Middleware refuses authenticated requests from outside the allow-lists of the user's
+// organizations with 403 ip_not_allowed. It must run after JWT validation; requests
+// without a token pass through to be rejected by the route. Routes whose templates are in
+// exempt, such as break-glass, are not checked. Enforcement fails closed: a request is
+// refused when its allow-lists cannot be read.
+func Middleware(svc Service, cfg config.IPAllowlist, exempt ...string) echo.MiddlewareFunc {
+	extractIP := NewIPExtractor(cfg)
+	skip := make(map[string]bool, len(exempt))
+	for _, path := range exempt {
+		skip[path] = true
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if skip[c.Path()] {
+				return next(c)
+			}
+			sub, err := auth.GetUserID(c)
+			if err != nil {
+				return next(c)
+			}
+
+			ip := extractIP(c.Request())
+			err = svc.Check(c.Request().Context(), Request{
+				Auth0Sub: sub,
+				IP:       ip,
+				Method:   c.Request().Method,
+				Path:     c.Path(),
+			})
+			if errors.Is(err, ErrIPNotAllowed) {
+				return c.JSON(http.StatusForbidden, ErrorResponse{
+					Error:   "ip_not_allowed",
+					Message: "Your organization does not allow access from " + ip,
+				})
+			}
+			if err != nil {
+				c.Logger().Errorf("IP allow-list check failed: %v", err)
+				return c.JSON(http.StatusInternalServerError, ErrorResponse{
+					Error:   "internal_server_error",
+					Message: "Failed to check IP allow-list",
+				})
+			}
+			return next(c)
+		}
+	}
+}
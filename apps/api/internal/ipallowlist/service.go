@@ -0,0 +1,23 @@
+package ipallowlist
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service manages organizations' IP allow-lists and enforces them.
+type Service interface {
+	// Get returns the organization's allow-list. Only admins may read it.
+	Get(ctx context.Context, userID, orgID, clientIP string) (*Allowlist, error)
+	// Put replaces the organization's allow-list and audits the change. It fails with
+	// ErrLockout when clientIP, the admin's own address, would be refused.
+	Put(ctx context.Context, userID, orgID, clientIP string, req PutRequest) (*Allowlist, error)
+	// BreakGlass opens a window in which the admin bypasses the organization's allow-list.
+	BreakGlass(ctx context.Context, userID, orgID, clientIP string, req BreakGlassRequest) (*BreakGlass, error)
+	// ListAudit returns a page of the organization's audit log, newest first. Only admins
+	// may read it.
+	ListAudit(ctx context.Context, userID, orgID string, limit, offset int32) ([]AuditEvent, error)
+	// Check returns ErrIPNotAllowed when req comes from outside the allow-list of any
+	// organization the user belongs to, unless the user holds an open break-glass window
+	// for it; requests let through by a window are audited.
+	Check(ctx context.Context, req Request) error
+}
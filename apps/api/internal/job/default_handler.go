@@ -0,0 +1,65 @@
+package job
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// DefaultHandler serves job details over HTTP.
+type DefaultHandler struct {
+	service  Service
+	userRepo user.Repository
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, userRepo user.Repository) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo}
+}
+
+// ErrorResponse is a simple JSON error envelope for handler responses.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// GetJobLog handles GET /api/v1/jobs/{id}/log, returning the job's sanitized processing log.
+func (h *DefaultHandler) GetJobLog(c echo.Context) error {
+	if _, err := uuid.Parse(c.Param("id")); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid job ID format",
+		})
+	}
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Unable to resolve current user",
+		})
+	}
+
+	jobLog, err := h.service.GetJobLog(c.Request().Context(), c.Param("id"), userID)
+	if err != nil {
+		if errors.Is(err, ErrLogNotFound) {
+			return c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "not_found",
+				Message: "No log recorded for this job",
+			})
+		}
+		c.Logger().Errorf("Failed to get job log: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get job log",
+		})
+	}
+
+	return c.JSON(http.StatusOK, jobLog)
+}
@@ -0,0 +1,75 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func TestDefaultHandler_GetJobLog(t *testing.T) {
+	jobID := uuid.New()
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		jobID          string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: log returned", jobID: jobID.String(), expectedStatus: http.StatusOK},
+		{name: "fail: invalid job id", jobID: "not-a-uuid", expectedStatus: http.StatusBadRequest},
+		{name: "fail: no log recorded, or not a project member", jobID: jobID.String(), err: ErrLogNotFound,
+			expectedStatus: http.StatusNotFound},
+		{name: "fail: service error", jobID: jobID.String(), err: errors.New("db down"),
+			expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				GetJobLogFunc: func(ctx context.Context, id, uid string) (*Log, error) {
+					assert.Equal(t, tc.jobID, id)
+					assert.Equal(t, userID.String(), uid)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &Log{JobID: jobID, Lines: []string{"Prediction started"}}, nil
+				},
+			}
+			userRepo := &user.RepositoryMock{
+				GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+					return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, userRepo)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("X-Test-User", "auth0|user")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.jobID)
+
+			require.NoError(t, h.GetJobLog(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Contains(t, rec.Body.String(), `"lines":["Prediction started"]`)
+			}
+			if tc.expectedStatus == http.StatusBadRequest {
+				assert.Empty(t, svc.GetJobLogCalls())
+			}
+		})
+	}
+}
@@ -47,6 +47,34 @@ func (r *DefaultRepository) CreateJob(
 	return job, nil
 }
 
+// CreateJobs inserts jobs with the COPY protocol. IDs are generated here so they can be
+// returned without reading the rows back.
+func (r *DefaultRepository) CreateJobs(ctx context.Context, reqs []CreateJobRequest) ([]*queries.Job, error) {
+	params := make([]queries.CreateJobsParams, len(reqs))
+	jobs := make([]*queries.Job, len(reqs))
+	for i, req := range reqs {
+		params[i] = queries.CreateJobsParams{
+			ID:          pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			ImageID:     pgtype.UUID{Bytes: req.ImageID, Valid: true},
+			Type:        req.Type.String(),
+			PayloadJson: req.Payload,
+		}
+		jobs[i] = &queries.Job{
+			ID:          params[i].ID,
+			ImageID:     params[i].ImageID,
+			Type:        params[i].Type,
+			PayloadJson: params[i].PayloadJson,
+			Status:      StatusQueued.String(),
+		}
+	}
+
+	if _, err := queries.New(r.db).CreateJobs(ctx, params); err != nil {
+		return nil, fmt.Errorf("failed to create jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
 // GetJobByID retrieves a specific job by its ID.
 func (r *DefaultRepository) GetJobByID(ctx context.Context, jobID string) (*queries.Job, error) {
 	q := queries.New(r.db)
@@ -64,6 +92,31 @@ func (r *DefaultRepository) GetJobByID(ctx context.Context, jobID string) (*quer
 	return job, nil
 }
 
+// GetJobLog retrieves the processing log the worker recorded for a job, when userID owns or
+// collaborates on the project of the job's image.
+func (r *DefaultRepository) GetJobLog(ctx context.Context, jobID, userID string) (*queries.JobLog, error) {
+	q := queries.New(r.db)
+
+	jobUUID, err := uuid.Parse(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid job ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	jobLog, err := q.GetJobLog(ctx, queries.GetJobLogParams{
+		JobID:  pgtype.UUID{Bytes: jobUUID, Valid: true},
+		UserID: pgtype.UUID{Bytes: userUUID, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job log: %w", err)
+	}
+
+	return jobLog, nil
+}
+
 // GetJobsByImageID retrieves all jobs for a specific image.
 func (r *DefaultRepository) GetJobsByImageID(ctx context.Context, imageID string) ([]*queries.Job, error) {
 	q := queries.New(r.db)
@@ -155,6 +208,23 @@ func (r *DefaultRepository) FailJob(ctx context.Context, jobID string, errorMsg
 	return job, nil
 }
 
+// CancelJobsByImageID marks all queued or processing jobs for an image as canceled.
+func (r *DefaultRepository) CancelJobsByImageID(ctx context.Context, imageID string) ([]*queries.Job, error) {
+	q := queries.New(r.db)
+
+	imageUUID, err := uuid.Parse(imageID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image ID: %w", err)
+	}
+
+	jobs, err := q.CancelJobsByImageID(ctx, pgtype.UUID{Bytes: imageUUID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
 // GetPendingJobs retrieves a limited number of pending jobs.
 func (r *DefaultRepository) GetPendingJobs(ctx context.Context, limit int) ([]*queries.Job, error) {
 	q := queries.New(r.db)
@@ -168,6 +238,22 @@ func (r *DefaultRepository) GetPendingJobs(ctx context.Context, limit int) ([]*q
 	return jobs, nil
 }
 
+// ListJobs retrieves a page of jobs, newest first. An empty status lists all jobs.
+func (r *DefaultRepository) ListJobs(ctx context.Context, status string, limit, offset int) ([]*queries.Job, error) {
+	q := queries.New(r.db)
+
+	jobs, err := q.ListJobs(ctx, queries.ListJobsParams{
+		Status: pgtype.Text{String: status, Valid: status != ""},
+		Limit:  int32(limit),  // #nosec G115 -- Limit/offset are validated by caller
+		Offset: int32(offset), // #nosec G115 -- Limit/offset are validated by caller
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
 // DeleteJob deletes a job from the database.
 func (r *DefaultRepository) DeleteJob(ctx context.Context, jobID string) error {
 	q := queries.New(r.db)
@@ -60,7 +60,8 @@ func testJobQueryRowOperation(
 					WithArgs(pgtype.UUID{Bytes: jobID, Valid: true}).
 					WillReturnRows(pgxmock.NewRows(
 						[]string{"id", "image_id", "type", "payload_json", "status",
-							"error", "created_at", "started_at", "finished_at"}).
+							"error", "created_at", "started_at", "finished_at",
+							"prediction_id", "output_key", "model_version", "provider_response"}).
 						AddRow(
 							pgtype.UUID{Bytes: jobID, Valid: true},
 							pgtype.UUID{Bytes: imageID, Valid: true},
@@ -71,6 +72,10 @@ func testJobQueryRowOperation(
 							pgtype.Timestamptz{},
 							pgtype.Timestamptz{},
 							pgtype.Timestamptz{},
+							pgtype.Text{},
+							pgtype.Text{},
+							pgtype.Text{},
+							pgtype.Text{},
 						))
 			},
 			expectError: false,
@@ -217,7 +222,8 @@ func TestDefaultRepository_CreateJob(t *testing.T) {
 					WithArgs(pgtype.UUID{Bytes: imageID, Valid: true}, jobType, payloadJSON).
 					WillReturnRows(pgxmock.NewRows(
 						[]string{"id", "image_id", "type", "payload_json", "status",
-							"error", "created_at", "started_at", "finished_at"}).
+							"error", "created_at", "started_at", "finished_at",
+							"prediction_id", "output_key", "model_version", "provider_response"}).
 						AddRow(
 							pgtype.UUID{Bytes: uuid.New(), Valid: true},
 							pgtype.UUID{Bytes: imageID, Valid: true},
@@ -228,6 +234,10 @@ func TestDefaultRepository_CreateJob(t *testing.T) {
 							pgtype.Timestamptz{},
 							pgtype.Timestamptz{},
 							pgtype.Timestamptz{},
+							pgtype.Text{},
+							pgtype.Text{},
+							pgtype.Text{},
+							pgtype.Text{},
 						))
 			},
 			expectError: false,
@@ -276,6 +286,88 @@ func TestDefaultRepository_GetJobByID(t *testing.T) {
 		})
 }
 
+func TestDefaultRepository_GetJobLog(t *testing.T) {
+	ctx := context.Background()
+	poolMock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer poolMock.Close()
+
+	dbMock := &storage.DatabaseMock{
+		QueryRowFunc: func(ctx context.Context, sql string, args ...any) pgx.Row {
+			return poolMock.QueryRow(ctx, sql, args...)
+		},
+	}
+	repo := NewDefaultRepository(dbMock)
+
+	jobID := uuid.New()
+	imageID := uuid.New()
+	userID := uuid.New()
+	args := []any{pgtype.UUID{Bytes: jobID, Valid: true}, pgtype.UUID{Bytes: userID, Valid: true}}
+
+	testCases := []struct {
+		name        string
+		jobID       string
+		userID      string
+		setupMock   func(mock pgxmock.PgxPoolIface)
+		expectError bool
+	}{
+		{
+			name:   "success: get job log",
+			jobID:  jobID.String(),
+			userID: userID.String(),
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("GetJobLog").
+					WithArgs(args...).
+					WillReturnRows(pgxmock.NewRows([]string{"job_id", "image_id", "log", "truncated", "updated_at"}).
+						AddRow(pgtype.UUID{Bytes: jobID, Valid: true}, pgtype.UUID{Bytes: imageID, Valid: true},
+							"12:00:00 Prediction started\n", false, pgtype.Timestamptz{Valid: true}))
+			},
+		},
+		{
+			name:        "fail: invalid job ID",
+			jobID:       "invalid-uuid",
+			userID:      userID.String(),
+			setupMock:   func(mock pgxmock.PgxPoolIface) {},
+			expectError: true,
+		},
+		{
+			name:        "fail: invalid user ID",
+			jobID:       jobID.String(),
+			userID:      "invalid-uuid",
+			setupMock:   func(mock pgxmock.PgxPoolIface) {},
+			expectError: true,
+		},
+		{
+			name:   "fail: no log recorded, or the user is not a project member",
+			jobID:  jobID.String(),
+			userID: userID.String(),
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("GetJobLog").
+					WithArgs(args...).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock(poolMock)
+
+			jobLog, err := repo.GetJobLog(ctx, tc.jobID, tc.userID)
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, jobLog)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "12:00:00 Prediction started\n", jobLog.Log)
+			}
+
+			assert.NoError(t, poolMock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestDefaultRepository_GetJobsByImageID(t *testing.T) {
 	ctx := context.Background()
 	poolMock, err := pgxmock.NewPool()
@@ -312,7 +404,8 @@ func TestDefaultRepository_GetJobsByImageID(t *testing.T) {
 					WithArgs(pgtype.UUID{Bytes: imageID, Valid: true}).
 					WillReturnRows(pgxmock.NewRows(
 						[]string{"id", "image_id", "type", "payload_json", "status",
-							"error", "created_at", "started_at", "finished_at"}).
+							"error", "created_at", "started_at", "finished_at",
+							"prediction_id", "output_key", "model_version", "provider_response"}).
 						AddRow(
 							pgtype.UUID{Bytes: jobID1, Valid: true},
 							pgtype.UUID{Bytes: imageID, Valid: true},
@@ -323,6 +416,10 @@ func TestDefaultRepository_GetJobsByImageID(t *testing.T) {
 							pgtype.Timestamptz{},
 							pgtype.Timestamptz{},
 							pgtype.Timestamptz{},
+							pgtype.Text{},
+							pgtype.Text{},
+							pgtype.Text{},
+							pgtype.Text{},
 						).
 						AddRow(
 							pgtype.UUID{Bytes: jobID2, Valid: true},
@@ -334,6 +431,10 @@ func TestDefaultRepository_GetJobsByImageID(t *testing.T) {
 							pgtype.Timestamptz{},
 							pgtype.Timestamptz{},
 							pgtype.Timestamptz{},
+							pgtype.Text{},
+							pgtype.Text{},
+							pgtype.Text{},
+							pgtype.Text{},
 						))
 			},
 			expectError: false,
@@ -409,7 +510,8 @@ func TestDefaultRepository_UpdateJobStatus(t *testing.T) {
 					WithArgs(pgtype.UUID{Bytes: jobID, Valid: true}, status).
 					WillReturnRows(pgxmock.NewRows(
 						[]string{"id", "image_id", "type", "payload_json", "status",
-							"error", "created_at", "started_at", "finished_at"}).
+							"error", "created_at", "started_at", "finished_at",
+							"prediction_id", "output_key", "model_version", "provider_response"}).
 						AddRow(
 							pgtype.UUID{Bytes: jobID, Valid: true},
 							pgtype.UUID{Bytes: imageID, Valid: true},
@@ -420,6 +522,10 @@ func TestDefaultRepository_UpdateJobStatus(t *testing.T) {
 							pgtype.Timestamptz{},
 							pgtype.Timestamptz{},
 							pgtype.Timestamptz{},
+							pgtype.Text{},
+							pgtype.Text{},
+							pgtype.Text{},
+							pgtype.Text{},
 						))
 			},
 			expectError: false,
@@ -511,7 +617,8 @@ func TestDefaultRepository_FailJob(t *testing.T) {
 					WithArgs(pgtype.UUID{Bytes: jobID, Valid: true}, pgtype.Text{String: errorMsg, Valid: true}).
 					WillReturnRows(pgxmock.NewRows(
 						[]string{"id", "image_id", "type", "payload_json", "status",
-							"error", "created_at", "started_at", "finished_at"}).
+							"error", "created_at", "started_at", "finished_at",
+							"prediction_id", "output_key", "model_version", "provider_response"}).
 						AddRow(
 							pgtype.UUID{Bytes: jobID, Valid: true},
 							pgtype.UUID{Bytes: imageID, Valid: true},
@@ -522,6 +629,10 @@ func TestDefaultRepository_FailJob(t *testing.T) {
 							pgtype.Timestamptz{},
 							pgtype.Timestamptz{},
 							pgtype.Timestamptz{},
+							pgtype.Text{},
+							pgtype.Text{},
+							pgtype.Text{},
+							pgtype.Text{},
 						))
 			},
 			expectError: false,
@@ -561,6 +672,106 @@ func TestDefaultRepository_FailJob(t *testing.T) {
 	}
 }
 
+func TestDefaultRepository_CancelJobsByImageID(t *testing.T) {
+	ctx := context.Background()
+	poolMock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer poolMock.Close()
+
+	dbMock := &storage.DatabaseMock{
+		QueryFunc: func(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+			return poolMock.Query(ctx, sql, args...)
+		},
+	}
+
+	repo := NewDefaultRepository(dbMock)
+
+	jobID := uuid.New()
+	imageID := uuid.New()
+	payloadJSON := []byte(`{"image_id":"` + imageID.String() + `"}`)
+
+	testCases := []struct {
+		name        string
+		imageID     string
+		setupMock   func(mock pgxmock.PgxPoolIface)
+		expectCount int
+		expectError bool
+	}{
+		{
+			name:    "success: cancel in-flight jobs",
+			imageID: imageID.String(),
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("CancelJobsByImageID").
+					WithArgs(pgtype.UUID{Bytes: imageID, Valid: true}).
+					WillReturnRows(pgxmock.NewRows(
+						[]string{"id", "image_id", "type", "payload_json", "status",
+							"error", "created_at", "started_at", "finished_at",
+							"prediction_id", "output_key", "model_version", "provider_response"}).
+						AddRow(
+							pgtype.UUID{Bytes: jobID, Valid: true},
+							pgtype.UUID{Bytes: imageID, Valid: true},
+							"stage:run",
+							payloadJSON,
+							"canceled",
+							pgtype.Text{},
+							pgtype.Timestamptz{},
+							pgtype.Timestamptz{},
+							pgtype.Timestamptz{},
+							pgtype.Text{},
+							pgtype.Text{},
+							pgtype.Text{},
+							pgtype.Text{},
+						))
+			},
+			expectCount: 1,
+		},
+		{
+			name:    "success: nothing to cancel",
+			imageID: imageID.String(),
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("CancelJobsByImageID").
+					WithArgs(pgtype.UUID{Bytes: imageID, Valid: true}).
+					WillReturnRows(pgxmock.NewRows(
+						[]string{"id", "image_id", "type", "payload_json", "status",
+							"error", "created_at", "started_at", "finished_at",
+							"prediction_id", "output_key", "model_version", "provider_response"}))
+			},
+			expectCount: 0,
+		},
+		{
+			name:        "fail: invalid image ID",
+			imageID:     "invalid-uuid",
+			setupMock:   func(mock pgxmock.PgxPoolIface) {},
+			expectError: true,
+		},
+		{
+			name:    "fail: query error",
+			imageID: imageID.String(),
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("CancelJobsByImageID").
+					WithArgs(pgtype.UUID{Bytes: imageID, Valid: true}).
+					WillReturnError(errors.New("db error"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock(poolMock)
+			jobs, err := repo.CancelJobsByImageID(ctx, tc.imageID)
+
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, jobs, tc.expectCount)
+			}
+			assert.NoError(t, poolMock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestDefaultRepository_GetPendingJobs(t *testing.T) {
 	ctx := context.Background()
 	poolMock, err := pgxmock.NewPool()
@@ -598,7 +809,8 @@ func TestDefaultRepository_GetPendingJobs(t *testing.T) {
 					WithArgs(int32(limit)). // #nosec G115 -- Test value with safe conversion
 					WillReturnRows(pgxmock.NewRows(
 						[]string{"id", "image_id", "type", "payload_json", "status",
-							"error", "created_at", "started_at", "finished_at"}).
+							"error", "created_at", "started_at", "finished_at",
+							"prediction_id", "output_key", "model_version", "provider_response"}).
 						AddRow(
 							pgtype.UUID{Bytes: jobID1, Valid: true},
 							pgtype.UUID{Bytes: imageID, Valid: true},
@@ -609,6 +821,10 @@ func TestDefaultRepository_GetPendingJobs(t *testing.T) {
 							pgtype.Timestamptz{},
 							pgtype.Timestamptz{},
 							pgtype.Timestamptz{},
+							pgtype.Text{},
+							pgtype.Text{},
+							pgtype.Text{},
+							pgtype.Text{},
 						).
 						AddRow(
 							pgtype.UUID{Bytes: jobID2, Valid: true},
@@ -620,6 +836,10 @@ func TestDefaultRepository_GetPendingJobs(t *testing.T) {
 							pgtype.Timestamptz{},
 							pgtype.Timestamptz{},
 							pgtype.Timestamptz{},
+							pgtype.Text{},
+							pgtype.Text{},
+							pgtype.Text{},
+							pgtype.Text{},
 						))
 			},
 			expectError: false,
@@ -651,6 +871,97 @@ func TestDefaultRepository_GetPendingJobs(t *testing.T) {
 	}
 }
 
+func TestDefaultRepository_ListJobs(t *testing.T) {
+	ctx := context.Background()
+	poolMock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer poolMock.Close()
+
+	dbMock := &storage.DatabaseMock{
+		QueryFunc: func(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+			return poolMock.Query(ctx, sql, args...)
+		},
+	}
+
+	repo := NewDefaultRepository(dbMock)
+
+	jobID := uuid.New()
+	imageID := uuid.New()
+
+	testCases := []struct {
+		name        string
+		status      string
+		setupMock   func(mock pgxmock.PgxPoolIface)
+		expectCount int
+		expectError bool
+	}{
+		{
+			name:   "success: filtered by status",
+			status: "failed",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("ListJobs").
+					WithArgs(pgtype.Text{String: "failed", Valid: true}, int32(50), int32(100)).
+					WillReturnRows(pgxmock.NewRows(
+						[]string{"id", "image_id", "type", "payload_json", "status",
+							"error", "created_at", "started_at", "finished_at",
+							"prediction_id", "output_key", "model_version", "provider_response"}).
+						AddRow(
+							pgtype.UUID{Bytes: jobID, Valid: true},
+							pgtype.UUID{Bytes: imageID, Valid: true},
+							"stage:run",
+							[]byte(`{}`),
+							"failed",
+							pgtype.Text{String: "boom", Valid: true},
+							pgtype.Timestamptz{},
+							pgtype.Timestamptz{},
+							pgtype.Timestamptz{},
+							pgtype.Text{},
+							pgtype.Text{},
+							pgtype.Text{},
+							pgtype.Text{},
+						))
+			},
+			expectCount: 1,
+		},
+		{
+			name: "success: all statuses",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("ListJobs").
+					WithArgs(pgtype.Text{}, int32(50), int32(100)).
+					WillReturnRows(pgxmock.NewRows(
+						[]string{"id", "image_id", "type", "payload_json", "status",
+							"error", "created_at", "started_at", "finished_at",
+							"prediction_id", "output_key", "model_version", "provider_response"}))
+			},
+			expectCount: 0,
+		},
+		{
+			name: "fail: query error",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("ListJobs").
+					WithArgs(pgtype.Text{}, int32(50), int32(100)).
+					WillReturnError(errors.New("db error"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock(poolMock)
+			jobs, err := repo.ListJobs(ctx, tc.status, 50, 100)
+
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, jobs, tc.expectCount)
+			}
+			assert.NoError(t, poolMock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestDefaultRepository_DeleteJob(t *testing.T) {
 	testJobExecOperation(t, "delete job", "DeleteJob", "job ID",
 		func(repo *DefaultRepository, ctx context.Context, id string) error {
@@ -664,3 +975,59 @@ func TestDefaultRepository_DeleteJobsByImageID(t *testing.T) {
 			return repo.DeleteJobsByImageID(ctx, id)
 		})
 }
+
+func TestDefaultRepository_CreateJobs(t *testing.T) {
+	ctx := context.Background()
+	imageIDs := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	reqs := make([]CreateJobRequest, len(imageIDs))
+	for i, id := range imageIDs {
+		reqs[i] = CreateJobRequest{ImageID: id, Type: "stage:run", Payload: json.RawMessage(`{}`)}
+	}
+	columns := []string{"id", "image_id", "type", "payload_json"}
+
+	testCases := []struct {
+		name        string
+		setupMock   func(mock pgxmock.PgxPoolIface)
+		expectError bool
+	}{
+		{
+			name: "success: copies every job",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectCopyFrom(pgx.Identifier{"jobs"}, columns).WillReturnResult(int64(len(reqs)))
+			},
+		},
+		{
+			name: "fail: copy error",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectCopyFrom(pgx.Identifier{"jobs"}, columns).WillReturnError(errors.New("copy failed"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			poolMock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer poolMock.Close()
+			tc.setupMock(poolMock)
+
+			repo := NewDefaultRepository(&storage.DatabaseMock{CopyFromFunc: poolMock.CopyFrom})
+			jobs, err := repo.CreateJobs(ctx, reqs)
+
+			if tc.expectError {
+				assert.ErrorContains(t, err, "failed to create jobs")
+				assert.Nil(t, jobs)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, jobs, len(reqs))
+				for i, j := range jobs {
+					assert.True(t, j.ID.Valid)
+					assert.Equal(t, imageIDs[i], uuid.UUID(j.ImageID.Bytes))
+					assert.Equal(t, StatusQueued.String(), j.Status)
+				}
+			}
+			assert.NoError(t, poolMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// DefaultService implements Service on top of a Repository.
+type DefaultService struct {
+	repo Repository
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService.
+func NewDefaultService(repo Repository) *DefaultService {
+	return &DefaultService{repo: repo}
+}
+
+// GetJobLog returns the job's processing log split into lines. Callers who are not members
+// of the image's project get ErrLogNotFound, so job IDs of other projects are not revealed.
+func (s *DefaultService) GetJobLog(ctx context.Context, jobID, userID string) (*Log, error) {
+	row, err := s.repo.GetJobLog(ctx, jobID, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrLogNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	lines := []string{}
+	for _, line := range strings.Split(row.Log, "\n") {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return &Log{
+		JobID:     row.JobID.Bytes,
+		ImageID:   row.ImageID.Bytes,
+		Lines:     lines,
+		Truncated: row.Truncated,
+		UpdatedAt: row.UpdatedAt.Time,
+	}, nil
+}
@@ -0,0 +1,93 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+func TestDefaultService_GetJobLog(t *testing.T) {
+	jobID := uuid.New()
+	imageID := uuid.New()
+	userID := uuid.New()
+	updatedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name      string
+		row       *queries.JobLog
+		err       error
+		expected  *Log
+		expectErr error
+	}{
+		{
+			name: "success: log split into lines",
+			row: &queries.JobLog{
+				JobID:     pgtype.UUID{Bytes: jobID, Valid: true},
+				ImageID:   pgtype.UUID{Bytes: imageID, Valid: true},
+				Log:       "12:00:00 Prediction started\n12:00:15 Progress: 40%\n",
+				Truncated: true,
+				UpdatedAt: pgtype.Timestamptz{Time: updatedAt, Valid: true},
+			},
+			expected: &Log{
+				JobID:     jobID,
+				ImageID:   imageID,
+				Lines:     []string{"12:00:00 Prediction started", "12:00:15 Progress: 40%"},
+				Truncated: true,
+				UpdatedAt: updatedAt,
+			},
+		},
+		{
+			name: "success: empty log",
+			row: &queries.JobLog{
+				JobID:   pgtype.UUID{Bytes: jobID, Valid: true},
+				ImageID: pgtype.UUID{Bytes: imageID, Valid: true},
+			},
+			expected: &Log{JobID: jobID, ImageID: imageID, Lines: []string{}},
+		},
+		{
+			name:      "fail: no log recorded",
+			err:       fmt.Errorf("failed to get job log: %w", pgx.ErrNoRows),
+			expectErr: ErrLogNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &RepositoryMock{
+				GetJobLogFunc: func(ctx context.Context, id, uid string) (*queries.JobLog, error) {
+					assert.Equal(t, jobID.String(), id)
+					assert.Equal(t, userID.String(), uid)
+					return tc.row, tc.err
+				},
+			}
+
+			got, err := NewDefaultService(repo).GetJobLog(context.Background(), jobID.String(), userID.String())
+			if tc.expectErr != nil {
+				require.True(t, errors.Is(err, tc.expectErr), "got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+
+	t.Run("fail: repository error", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetJobLogFunc: func(ctx context.Context, id, uid string) (*queries.JobLog, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		_, err := NewDefaultService(repo).GetJobLog(context.Background(), jobID.String(), userID.String())
+		assert.EqualError(t, err, "db down")
+	})
+}
@@ -3,6 +3,7 @@ package job
 
 import (
 	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,6 +21,8 @@ const (
 	StatusCompleted Status = "completed"
 	// StatusFailed indicates the job failed during processing.
 	StatusFailed Status = "failed"
+	// StatusCanceled indicates the job was canceled by the user before it finished.
+	StatusCanceled Status = "canceled"
 )
 
 // String returns the string representation of the status.
@@ -59,3 +62,19 @@ type CreateJobRequest struct {
 	Type    Type            `json:"type" validate:"required"`
 	Payload json.RawMessage `json:"payload" validate:"required"`
 }
+
+// ErrLogNotFound is returned when the worker has not recorded a processing log for a job,
+// or the caller is not a member of the image's project.
+var ErrLogNotFound = errors.New("job log not found")
+
+// Log is a job's processing log as shown to the image owner: provider progress lines,
+// retry notices and the outcome. The worker sanitizes every line before storing it.
+type Log struct {
+	JobID   uuid.UUID `json:"job_id"`
+	ImageID uuid.UUID `json:"image_id"`
+	// Lines are the log lines, oldest first.
+	Lines []string `json:"lines"`
+	// Truncated reports that older lines were dropped to keep the log under its size cap.
+	Truncated bool      `json:"truncated"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
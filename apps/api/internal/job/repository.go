@@ -13,9 +13,17 @@ type Repository interface {
 	// CreateJob creates a new job in the database.
 	CreateJob(ctx context.Context, imageID string, jobType string, payloadJSON []byte) (*queries.Job, error)
 
+	// CreateJobs inserts jobs in one COPY round trip and returns them in request order.
+	// Timestamps are left unset because COPY does not return the inserted rows.
+	CreateJobs(ctx context.Context, reqs []CreateJobRequest) ([]*queries.Job, error)
+
 	// GetJobByID retrieves a specific job by its ID.
 	GetJobByID(ctx context.Context, jobID string) (*queries.Job, error)
 
+	// GetJobLog retrieves the processing log the worker recorded for a job, when userID owns
+	// or collaborates on the project of the job's image.
+	GetJobLog(ctx context.Context, jobID, userID string) (*queries.JobLog, error)
+
 	// GetJobsByImageID retrieves all jobs for a specific image.
 	GetJobsByImageID(ctx context.Context, imageID string) ([]*queries.Job, error)
 
@@ -31,6 +39,13 @@ type Repository interface {
 	// FailJob marks a job as failed with an error message and sets the finished timestamp.
 	FailJob(ctx context.Context, jobID string, errorMsg string) (*queries.Job, error)
 
+	// CancelJobsByImageID marks all queued or processing jobs for an image as canceled
+	// and returns the jobs that were transitioned.
+	CancelJobsByImageID(ctx context.Context, imageID string) ([]*queries.Job, error)
+
+	// ListJobs retrieves a page of jobs, newest first. An empty status lists all jobs.
+	ListJobs(ctx context.Context, status string, limit, offset int) ([]*queries.Job, error)
+
 	// GetPendingJobs retrieves a limited number of pending jobs.
 	GetPendingJobs(ctx context.Context, limit int) ([]*queries.Job, error)
 
@@ -19,12 +19,18 @@ var _ Repository = &RepositoryMock{}
 //
 //		// make and configure a mocked Repository
 //		mockedRepository := &RepositoryMock{
+//			CancelJobsByImageIDFunc: func(ctx context.Context, imageID string) ([]*queries.Job, error) {
+//				panic("mock out the CancelJobsByImageID method")
+//			},
 //			CompleteJobFunc: func(ctx context.Context, jobID string) (*queries.Job, error) {
 //				panic("mock out the CompleteJob method")
 //			},
 //			CreateJobFunc: func(ctx context.Context, imageID string, jobType string, payloadJSON []byte) (*queries.Job, error) {
 //				panic("mock out the CreateJob method")
 //			},
+//			CreateJobsFunc: func(ctx context.Context, reqs []CreateJobRequest) ([]*queries.Job, error) {
+//				panic("mock out the CreateJobs method")
+//			},
 //			DeleteJobFunc: func(ctx context.Context, jobID string) error {
 //				panic("mock out the DeleteJob method")
 //			},
@@ -37,12 +43,18 @@ var _ Repository = &RepositoryMock{}
 //			GetJobByIDFunc: func(ctx context.Context, jobID string) (*queries.Job, error) {
 //				panic("mock out the GetJobByID method")
 //			},
+//			GetJobLogFunc: func(ctx context.Context, jobID string, userID string) (*queries.JobLog, error) {
+//				panic("mock out the GetJobLog method")
+//			},
 //			GetJobsByImageIDFunc: func(ctx context.Context, imageID string) ([]*queries.Job, error) {
 //				panic("mock out the GetJobsByImageID method")
 //			},
 //			GetPendingJobsFunc: func(ctx context.Context, limit int) ([]*queries.Job, error) {
 //				panic("mock out the GetPendingJobs method")
 //			},
+//			ListJobsFunc: func(ctx context.Context, status string, limit int, offset int) ([]*queries.Job, error) {
+//				panic("mock out the ListJobs method")
+//			},
 //			StartJobFunc: func(ctx context.Context, jobID string) (*queries.Job, error) {
 //				panic("mock out the StartJob method")
 //			},
@@ -56,12 +68,18 @@ var _ Repository = &RepositoryMock{}
 //
 //	}
 type RepositoryMock struct {
+	// CancelJobsByImageIDFunc mocks the CancelJobsByImageID method.
+	CancelJobsByImageIDFunc func(ctx context.Context, imageID string) ([]*queries.Job, error)
+
 	// CompleteJobFunc mocks the CompleteJob method.
 	CompleteJobFunc func(ctx context.Context, jobID string) (*queries.Job, error)
 
 	// CreateJobFunc mocks the CreateJob method.
 	CreateJobFunc func(ctx context.Context, imageID string, jobType string, payloadJSON []byte) (*queries.Job, error)
 
+	// CreateJobsFunc mocks the CreateJobs method.
+	CreateJobsFunc func(ctx context.Context, reqs []CreateJobRequest) ([]*queries.Job, error)
+
 	// DeleteJobFunc mocks the DeleteJob method.
 	DeleteJobFunc func(ctx context.Context, jobID string) error
 
@@ -74,12 +92,18 @@ type RepositoryMock struct {
 	// GetJobByIDFunc mocks the GetJobByID method.
 	GetJobByIDFunc func(ctx context.Context, jobID string) (*queries.Job, error)
 
+	// GetJobLogFunc mocks the GetJobLog method.
+	GetJobLogFunc func(ctx context.Context, jobID string, userID string) (*queries.JobLog, error)
+
 	// GetJobsByImageIDFunc mocks the GetJobsByImageID method.
 	GetJobsByImageIDFunc func(ctx context.Context, imageID string) ([]*queries.Job, error)
 
 	// GetPendingJobsFunc mocks the GetPendingJobs method.
 	GetPendingJobsFunc func(ctx context.Context, limit int) ([]*queries.Job, error)
 
+	// ListJobsFunc mocks the ListJobs method.
+	ListJobsFunc func(ctx context.Context, status string, limit int, offset int) ([]*queries.Job, error)
+
 	// StartJobFunc mocks the StartJob method.
 	StartJobFunc func(ctx context.Context, jobID string) (*queries.Job, error)
 
@@ -88,6 +112,13 @@ type RepositoryMock struct {
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// CancelJobsByImageID holds details about calls to the CancelJobsByImageID method.
+		CancelJobsByImageID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID string
+		}
 		// CompleteJob holds details about calls to the CompleteJob method.
 		CompleteJob []struct {
 			// Ctx is the ctx argument value.
@@ -106,6 +137,13 @@ type RepositoryMock struct {
 			// PayloadJSON is the payloadJSON argument value.
 			PayloadJSON []byte
 		}
+		// CreateJobs holds details about calls to the CreateJobs method.
+		CreateJobs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Reqs is the reqs argument value.
+			Reqs []CreateJobRequest
+		}
 		// DeleteJob holds details about calls to the DeleteJob method.
 		DeleteJob []struct {
 			// Ctx is the ctx argument value.
@@ -136,6 +174,15 @@ type RepositoryMock struct {
 			// JobID is the jobID argument value.
 			JobID string
 		}
+		// GetJobLog holds details about calls to the GetJobLog method.
+		GetJobLog []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// JobID is the jobID argument value.
+			JobID string
+			// UserID is the userID argument value.
+			UserID string
+		}
 		// GetJobsByImageID holds details about calls to the GetJobsByImageID method.
 		GetJobsByImageID []struct {
 			// Ctx is the ctx argument value.
@@ -150,6 +197,17 @@ type RepositoryMock struct {
 			// Limit is the limit argument value.
 			Limit int
 		}
+		// ListJobs holds details about calls to the ListJobs method.
+		ListJobs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Status is the status argument value.
+			Status string
+			// Limit is the limit argument value.
+			Limit int
+			// Offset is the offset argument value.
+			Offset int
+		}
 		// StartJob holds details about calls to the StartJob method.
 		StartJob []struct {
 			// Ctx is the ctx argument value.
@@ -167,18 +225,58 @@ type RepositoryMock struct {
 			Status string
 		}
 	}
+	lockCancelJobsByImageID sync.RWMutex
 	lockCompleteJob         sync.RWMutex
 	lockCreateJob           sync.RWMutex
+	lockCreateJobs          sync.RWMutex
 	lockDeleteJob           sync.RWMutex
 	lockDeleteJobsByImageID sync.RWMutex
 	lockFailJob             sync.RWMutex
 	lockGetJobByID          sync.RWMutex
+	lockGetJobLog           sync.RWMutex
 	lockGetJobsByImageID    sync.RWMutex
 	lockGetPendingJobs      sync.RWMutex
+	lockListJobs            sync.RWMutex
 	lockStartJob            sync.RWMutex
 	lockUpdateJobStatus     sync.RWMutex
 }
 
+// CancelJobsByImageID calls CancelJobsByImageIDFunc.
+func (mock *RepositoryMock) CancelJobsByImageID(ctx context.Context, imageID string) ([]*queries.Job, error) {
+	if mock.CancelJobsByImageIDFunc == nil {
+		panic("RepositoryMock.CancelJobsByImageIDFunc: method is nil but Repository.CancelJobsByImageID was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ImageID string
+	}{
+		Ctx:     ctx,
+		ImageID: imageID,
+	}
+	mock.lockCancelJobsByImageID.Lock()
+	mock.calls.CancelJobsByImageID = append(mock.calls.CancelJobsByImageID, callInfo)
+	mock.lockCancelJobsByImageID.Unlock()
+	return mock.CancelJobsByImageIDFunc(ctx, imageID)
+}
+
+// CancelJobsByImageIDCalls gets all the calls that were made to CancelJobsByImageID.
+// Check the length with:
+//
+//	len(mockedRepository.CancelJobsByImageIDCalls())
+func (mock *RepositoryMock) CancelJobsByImageIDCalls() []struct {
+	Ctx     context.Context
+	ImageID string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ImageID string
+	}
+	mock.lockCancelJobsByImageID.RLock()
+	calls = mock.calls.CancelJobsByImageID
+	mock.lockCancelJobsByImageID.RUnlock()
+	return calls
+}
+
 // CompleteJob calls CompleteJobFunc.
 func (mock *RepositoryMock) CompleteJob(ctx context.Context, jobID string) (*queries.Job, error) {
 	if mock.CompleteJobFunc == nil {
@@ -259,6 +357,42 @@ func (mock *RepositoryMock) CreateJobCalls() []struct {
 	return calls
 }
 
+// CreateJobs calls CreateJobsFunc.
+func (mock *RepositoryMock) CreateJobs(ctx context.Context, reqs []CreateJobRequest) ([]*queries.Job, error) {
+	if mock.CreateJobsFunc == nil {
+		panic("RepositoryMock.CreateJobsFunc: method is nil but Repository.CreateJobs was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Reqs []CreateJobRequest
+	}{
+		Ctx:  ctx,
+		Reqs: reqs,
+	}
+	mock.lockCreateJobs.Lock()
+	mock.calls.CreateJobs = append(mock.calls.CreateJobs, callInfo)
+	mock.lockCreateJobs.Unlock()
+	return mock.CreateJobsFunc(ctx, reqs)
+}
+
+// CreateJobsCalls gets all the calls that were made to CreateJobs.
+// Check the length with:
+//
+//	len(mockedRepository.CreateJobsCalls())
+func (mock *RepositoryMock) CreateJobsCalls() []struct {
+	Ctx  context.Context
+	Reqs []CreateJobRequest
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Reqs []CreateJobRequest
+	}
+	mock.lockCreateJobs.RLock()
+	calls = mock.calls.CreateJobs
+	mock.lockCreateJobs.RUnlock()
+	return calls
+}
+
 // DeleteJob calls DeleteJobFunc.
 func (mock *RepositoryMock) DeleteJob(ctx context.Context, jobID string) error {
 	if mock.DeleteJobFunc == nil {
@@ -407,6 +541,46 @@ func (mock *RepositoryMock) GetJobByIDCalls() []struct {
 	return calls
 }
 
+// GetJobLog calls GetJobLogFunc.
+func (mock *RepositoryMock) GetJobLog(ctx context.Context, jobID string, userID string) (*queries.JobLog, error) {
+	if mock.GetJobLogFunc == nil {
+		panic("RepositoryMock.GetJobLogFunc: method is nil but Repository.GetJobLog was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		JobID  string
+		UserID string
+	}{
+		Ctx:    ctx,
+		JobID:  jobID,
+		UserID: userID,
+	}
+	mock.lockGetJobLog.Lock()
+	mock.calls.GetJobLog = append(mock.calls.GetJobLog, callInfo)
+	mock.lockGetJobLog.Unlock()
+	return mock.GetJobLogFunc(ctx, jobID, userID)
+}
+
+// GetJobLogCalls gets all the calls that were made to GetJobLog.
+// Check the length with:
+//
+//	len(mockedRepository.GetJobLogCalls())
+func (mock *RepositoryMock) GetJobLogCalls() []struct {
+	Ctx    context.Context
+	JobID  string
+	UserID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		JobID  string
+		UserID string
+	}
+	mock.lockGetJobLog.RLock()
+	calls = mock.calls.GetJobLog
+	mock.lockGetJobLog.RUnlock()
+	return calls
+}
+
 // GetJobsByImageID calls GetJobsByImageIDFunc.
 func (mock *RepositoryMock) GetJobsByImageID(ctx context.Context, imageID string) ([]*queries.Job, error) {
 	if mock.GetJobsByImageIDFunc == nil {
@@ -479,6 +653,50 @@ func (mock *RepositoryMock) GetPendingJobsCalls() []struct {
 	return calls
 }
 
+// ListJobs calls ListJobsFunc.
+func (mock *RepositoryMock) ListJobs(ctx context.Context, status string, limit int, offset int) ([]*queries.Job, error) {
+	if mock.ListJobsFunc == nil {
+		panic("RepositoryMock.ListJobsFunc: method is nil but Repository.ListJobs was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Status string
+		Limit  int
+		Offset int
+	}{
+		Ctx:    ctx,
+		Status: status,
+		Limit:  limit,
+		Offset: offset,
+	}
+	mock.lockListJobs.Lock()
+	mock.calls.ListJobs = append(mock.calls.ListJobs, callInfo)
+	mock.lockListJobs.Unlock()
+	return mock.ListJobsFunc(ctx, status, limit, offset)
+}
+
+// ListJobsCalls gets all the calls that were made to ListJobs.
+// Check the length with:
+//
+//	len(mockedRepository.ListJobsCalls())
+func (mock *RepositoryMock) ListJobsCalls() []struct {
+	Ctx    context.Context
+	Status string
+	Limit  int
+	Offset int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Status string
+		Limit  int
+		Offset int
+	}
+	mock.lockListJobs.RLock()
+	calls = mock.calls.ListJobs
+	mock.lockListJobs.RUnlock()
+	return calls
+}
+
 // StartJob calls StartJobFunc.
 func (mock *RepositoryMock) StartJob(ctx context.Context, jobID string) (*queries.Job, error) {
 	if mock.StartJobFunc == nil {
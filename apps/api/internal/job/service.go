@@ -0,0 +1,12 @@
+package job
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service exposes job details to members of the image's project.
+type Service interface {
+	// GetJobLog returns the job's processing log to a member of the image's project, or
+	// ErrLogNotFound.
+	GetJobLog(ctx context.Context, jobID, userID string) (*Log, error)
+}
@@ -0,0 +1,161 @@
+package legalhold
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// foreignKeyViolation is the Postgres error code raised when the held project or image does not exist.
+const foreignKeyViolation = "23503"
+
+// DefaultService implements Service.
+type DefaultService struct {
+	querier queries.Querier
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService with a database.
+func NewDefaultService(db storage.Database) *DefaultService {
+	return &DefaultService{querier: queries.New(db)}
+}
+
+// NewDefaultServiceWithQuerier creates a new DefaultService with a custom querier (for testing).
+func NewDefaultServiceWithQuerier(querier queries.Querier) *DefaultService {
+	return &DefaultService{querier: querier}
+}
+
+// ListHolds returns every active legal hold, newest first.
+func (s *DefaultService) ListHolds(ctx context.Context) ([]Hold, error) {
+	rows, err := s.querier.ListLegalHolds(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list legal holds: %w", err)
+	}
+
+	holds := make([]Hold, 0, len(rows))
+	for _, row := range rows {
+		holds = append(holds, toHold(row))
+	}
+	return holds, nil
+}
+
+// SetProjectHold places or releases a hold on a project.
+func (s *DefaultService) SetProjectHold(
+	ctx context.Context, projectID string, held bool, reason, placedBy string,
+) (*Status, error) {
+	id, err := parseUUID(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+
+	if !held {
+		if _, err := s.querier.ReleaseProjectLegalHold(ctx, id); err != nil {
+			return nil, fmt.Errorf("failed to release project legal hold: %w", err)
+		}
+		return &Status{LegalHold: false}, nil
+	}
+
+	placedByID, err := parsePlacedBy(placedBy)
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.querier.PlaceProjectLegalHold(ctx, queries.PlaceProjectLegalHoldParams{
+		ProjectID: id,
+		Reason:    reason,
+		PlacedBy:  placedByID,
+	})
+	if err != nil {
+		return nil, placeError("project", err)
+	}
+	hold := toHold(row)
+	return &Status{LegalHold: true, Hold: &hold}, nil
+}
+
+// SetImageHold places or releases a hold on an image.
+func (s *DefaultService) SetImageHold(
+	ctx context.Context, imageID string, held bool, reason, placedBy string,
+) (*Status, error) {
+	id, err := parseUUID(imageID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image ID: %w", err)
+	}
+
+	if !held {
+		if _, err := s.querier.ReleaseImageLegalHold(ctx, id); err != nil {
+			return nil, fmt.Errorf("failed to release image legal hold: %w", err)
+		}
+		return &Status{LegalHold: false}, nil
+	}
+
+	placedByID, err := parsePlacedBy(placedBy)
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.querier.PlaceImageLegalHold(ctx, queries.PlaceImageLegalHoldParams{
+		ImageID:  id,
+		Reason:   reason,
+		PlacedBy: placedByID,
+	})
+	if err != nil {
+		return nil, placeError("image", err)
+	}
+	hold := toHold(row)
+	return &Status{LegalHold: true, Hold: &hold}, nil
+}
+
+// placeError maps a missing hold target to ErrNotFound.
+func placeError(target string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+		return ErrNotFound
+	}
+	return fmt.Errorf("failed to place %s legal hold: %w", target, err)
+}
+
+func parseUUID(id string) (pgtype.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+	return pgtype.UUID{Bytes: parsed, Valid: true}, nil
+}
+
+// parsePlacedBy converts the placing admin's user ID; an empty ID is stored as NULL.
+func parsePlacedBy(placedBy string) (pgtype.UUID, error) {
+	if placedBy == "" {
+		return pgtype.UUID{}, nil
+	}
+	id, err := parseUUID(placedBy)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf("invalid placed_by user ID: %w", err)
+	}
+	return id, nil
+}
+
+func uuidPtr(id pgtype.UUID) *string {
+	if !id.Valid {
+		return nil
+	}
+	s := uuid.UUID(id.Bytes).String()
+	return &s
+}
+
+func toHold(row *queries.LegalHold) Hold {
+	return Hold{
+		ID:        uuid.UUID(row.ID.Bytes).String(),
+		ProjectID: uuidPtr(row.ProjectID),
+		ImageID:   uuidPtr(row.ImageID),
+		Reason:    row.Reason,
+		PlacedBy:  uuidPtr(row.PlacedBy),
+		CreatedAt: row.CreatedAt.Time,
+	}
+}
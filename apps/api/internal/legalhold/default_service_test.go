@@ -0,0 +1,208 @@
+package legalhold
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+func TestDefaultService_ListHolds(t *testing.T) {
+	projectID := uuid.New()
+	holdID := uuid.New()
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("success: maps rows", func(t *testing.T) {
+		q := &queries.QuerierMock{
+			ListLegalHoldsFunc: func(ctx context.Context) ([]*queries.LegalHold, error) {
+				return []*queries.LegalHold{{
+					ID:        pgtype.UUID{Bytes: holdID, Valid: true},
+					ProjectID: pgtype.UUID{Bytes: projectID, Valid: true},
+					Reason:    "Dispute #42",
+					CreatedAt: pgtype.Timestamptz{Time: createdAt, Valid: true},
+				}}, nil
+			},
+		}
+
+		holds, err := NewDefaultServiceWithQuerier(q).ListHolds(context.Background())
+		require.NoError(t, err)
+		require.Len(t, holds, 1)
+		assert.Equal(t, holdID.String(), holds[0].ID)
+		assert.Equal(t, projectID.String(), *holds[0].ProjectID)
+		assert.Nil(t, holds[0].ImageID)
+		assert.Nil(t, holds[0].PlacedBy)
+		assert.Equal(t, createdAt, holds[0].CreatedAt)
+	})
+
+	t.Run("fail: query error", func(t *testing.T) {
+		q := &queries.QuerierMock{
+			ListLegalHoldsFunc: func(ctx context.Context) ([]*queries.LegalHold, error) {
+				return nil, errors.New("db down")
+			},
+		}
+
+		_, err := NewDefaultServiceWithQuerier(q).ListHolds(context.Background())
+		assert.ErrorContains(t, err, "db down")
+	})
+}
+
+func TestDefaultService_SetProjectHold(t *testing.T) {
+	projectID := uuid.New()
+	adminID := uuid.New()
+
+	testCases := []struct {
+		name      string
+		projectID string
+		held      bool
+		placedBy  string
+		setup     func(q *queries.QuerierMock)
+		want      *Status
+		wantErr   error
+		errSubstr string
+	}{
+		{
+			name:      "success: place hold",
+			projectID: projectID.String(),
+			held:      true,
+			placedBy:  adminID.String(),
+			setup: func(q *queries.QuerierMock) {
+				q.PlaceProjectLegalHoldFunc = func(
+					ctx context.Context, arg queries.PlaceProjectLegalHoldParams,
+				) (*queries.LegalHold, error) {
+					assert.Equal(t, "Dispute #42", arg.Reason)
+					assert.Equal(t, adminID, uuid.UUID(arg.PlacedBy.Bytes))
+					return &queries.LegalHold{
+						ID:        pgtype.UUID{Bytes: uuid.New(), Valid: true},
+						ProjectID: arg.ProjectID,
+						Reason:    arg.Reason,
+						PlacedBy:  arg.PlacedBy,
+					}, nil
+				}
+			},
+		},
+		{
+			name:      "success: release hold",
+			projectID: projectID.String(),
+			held:      false,
+			setup: func(q *queries.QuerierMock) {
+				q.ReleaseProjectLegalHoldFunc = func(ctx context.Context, id pgtype.UUID) (int64, error) {
+					return 1, nil
+				}
+			},
+			want: &Status{LegalHold: false},
+		},
+		{
+			name:      "fail: project does not exist",
+			projectID: projectID.String(),
+			held:      true,
+			setup: func(q *queries.QuerierMock) {
+				q.PlaceProjectLegalHoldFunc = func(
+					ctx context.Context, arg queries.PlaceProjectLegalHoldParams,
+				) (*queries.LegalHold, error) {
+					return nil, &pgconn.PgError{Code: "23503"}
+				}
+			},
+			wantErr: ErrNotFound,
+		},
+		{
+			name:      "fail: invalid project id",
+			projectID: "nope",
+			held:      true,
+			setup:     func(q *queries.QuerierMock) {},
+			errSubstr: "invalid project ID",
+		},
+		{
+			name:      "fail: release error",
+			projectID: projectID.String(),
+			setup: func(q *queries.QuerierMock) {
+				q.ReleaseProjectLegalHoldFunc = func(ctx context.Context, id pgtype.UUID) (int64, error) {
+					return 0, errors.New("db down")
+				}
+			},
+			errSubstr: "db down",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{}
+			tc.setup(q)
+
+			got, err := NewDefaultServiceWithQuerier(q).SetProjectHold(
+				context.Background(), tc.projectID, tc.held, "Dispute #42", tc.placedBy)
+			switch {
+			case tc.wantErr != nil:
+				assert.ErrorIs(t, err, tc.wantErr)
+			case tc.errSubstr != "":
+				assert.ErrorContains(t, err, tc.errSubstr)
+			default:
+				require.NoError(t, err)
+				if tc.want != nil {
+					assert.Equal(t, tc.want, got)
+					return
+				}
+				assert.True(t, got.LegalHold)
+				require.NotNil(t, got.Hold)
+				assert.Equal(t, projectID.String(), *got.Hold.ProjectID)
+				assert.Equal(t, adminID.String(), *got.Hold.PlacedBy)
+			}
+		})
+	}
+}
+
+func TestDefaultService_SetImageHold(t *testing.T) {
+	imageID := uuid.New()
+
+	t.Run("success: place hold without placed_by", func(t *testing.T) {
+		q := &queries.QuerierMock{
+			PlaceImageLegalHoldFunc: func(
+				ctx context.Context, arg queries.PlaceImageLegalHoldParams,
+			) (*queries.LegalHold, error) {
+				assert.False(t, arg.PlacedBy.Valid)
+				return &queries.LegalHold{
+					ID:      pgtype.UUID{Bytes: uuid.New(), Valid: true},
+					ImageID: arg.ImageID,
+					Reason:  arg.Reason,
+				}, nil
+			},
+		}
+
+		got, err := NewDefaultServiceWithQuerier(q).SetImageHold(context.Background(), imageID.String(), true, "Case 7", "")
+		require.NoError(t, err)
+		assert.True(t, got.LegalHold)
+		assert.Equal(t, imageID.String(), *got.Hold.ImageID)
+	})
+
+	t.Run("success: release hold", func(t *testing.T) {
+		q := &queries.QuerierMock{
+			ReleaseImageLegalHoldFunc: func(ctx context.Context, id pgtype.UUID) (int64, error) {
+				return 0, nil
+			},
+		}
+
+		got, err := NewDefaultServiceWithQuerier(q).SetImageHold(context.Background(), imageID.String(), false, "", "")
+		require.NoError(t, err)
+		assert.Equal(t, &Status{LegalHold: false}, got)
+	})
+
+	t.Run("fail: place error", func(t *testing.T) {
+		q := &queries.QuerierMock{
+			PlaceImageLegalHoldFunc: func(
+				ctx context.Context, arg queries.PlaceImageLegalHoldParams,
+			) (*queries.LegalHold, error) {
+				return nil, errors.New("db down")
+			},
+		}
+
+		_, err := NewDefaultServiceWithQuerier(q).SetImageHold(context.Background(), imageID.String(), true, "Case 7", "")
+		assert.ErrorContains(t, err, "failed to place image legal hold")
+	})
+}
@@ -0,0 +1,33 @@
+// Package legalhold manages admin-placed legal holds. A project or image under
+// legal hold cannot be deleted or purged until the hold is released.
+package legalhold
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned when the project or image to hold does not exist.
+var ErrNotFound = errors.New("legal hold target not found")
+
+// Hold is a legal hold on a single project or image.
+type Hold struct {
+	ID        string    `json:"id"`
+	ProjectID *string   `json:"project_id,omitempty"`
+	ImageID   *string   `json:"image_id,omitempty"`
+	Reason    string    `json:"reason"`
+	PlacedBy  *string   `json:"placed_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Status reports whether a project or image is directly under legal hold.
+type Status struct {
+	LegalHold bool  `json:"legal_hold"`
+	Hold      *Hold `json:"hold,omitempty"`
+}
+
+// UpdateRequest places or releases a legal hold. Reason is required when placing a hold.
+type UpdateRequest struct {
+	LegalHold *bool  `json:"legal_hold"`
+	Reason    string `json:"reason"`
+}
@@ -0,0 +1,17 @@
+package legalhold
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service places, releases, and lists legal holds.
+type Service interface {
+	// ListHolds returns every active legal hold, newest first.
+	ListHolds(ctx context.Context) ([]Hold, error)
+	// SetProjectHold places (held=true) or releases a hold on a project.
+	// Placing a hold on a held project updates its reason.
+	SetProjectHold(ctx context.Context, projectID string, held bool, reason, placedBy string) (*Status, error)
+	// SetImageHold places (held=true) or releases a hold on an image.
+	// Placing a hold on a held image updates its reason.
+	SetImageHold(ctx context.Context, imageID string, held bool, reason, placedBy string) (*Status, error)
+}
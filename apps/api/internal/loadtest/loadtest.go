@@ -0,0 +1,298 @@
+// Package loadtest drives the staging pipeline end to end at a configurable rate and
+// reports per-stage latency percentiles for capacity planning.
+//
+// Each iteration creates a project, then for every image presigns an upload, PUTs the
+// bytes to object storage (MinIO locally), creates the image, and polls until the job
+// finishes. Pair it with FakeProvider so job completion does not depend on, or pay for,
+// the real model provider.
+package loadtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/httpclient"
+	"github.com/real-staging-ai/api/internal/image"
+)
+
+// Config controls the shape and rate of a load test.
+type Config struct {
+	// BaseURL is the API origin, e.g. http://localhost:8080.
+	BaseURL string
+	// Token is sent as a bearer token. Leave empty for a test server without auth.
+	Token string
+	// Duration is how long new projects are started for; in-flight work then drains.
+	Duration time.Duration
+	// ProjectsPerSecond is the rate new projects are started at.
+	ProjectsPerSecond float64
+	// ImagesPerProject is how many images each project uploads and stages.
+	ImagesPerProject int
+	// Concurrency caps in-flight projects. Ticks that find it saturated are skipped and
+	// counted, so an overloaded system shows up in the report rather than as drift.
+	Concurrency int
+	// UploadBytes is the size of each uploaded image.
+	UploadBytes int
+	// PollInterval is how often image status is polled while waiting for a job.
+	PollInterval time.Duration
+	// CompletionTimeout bounds the wait for a single job to finish.
+	CompletionTimeout time.Duration
+}
+
+// DefaultConfig returns a gentle load suitable for a laptop stack.
+func DefaultConfig() Config {
+	return Config{
+		BaseURL:           "http://localhost:8080",
+		Duration:          time.Minute,
+		ProjectsPerSecond: 1,
+		ImagesPerProject:  5,
+		Concurrency:       50,
+		UploadBytes:       256 * 1024,
+		PollInterval:      250 * time.Millisecond,
+		CompletionTimeout: 2 * time.Minute,
+	}
+}
+
+// Runner executes a load test against a running API.
+type Runner struct {
+	cfg     Config
+	client  *http.Client
+	rec     *Recorder
+	payload []byte
+}
+
+// NewRunner returns a Runner. A nil client uses a client sized for cfg.Concurrency.
+func NewRunner(cfg Config, client *http.Client) *Runner {
+	if client == nil {
+		policy := httpclient.PolicyFor(httpclient.DestinationLoadTest)
+		policy.MaxIdleConnsPerHost = cfg.Concurrency * (cfg.ImagesPerProject + 1)
+		client = httpclient.New(httpclient.DestinationLoadTest, httpclient.WithPolicy(policy))
+	}
+	return &Runner{
+		cfg:     cfg,
+		client:  client,
+		rec:     NewRecorder(),
+		payload: bytes.Repeat([]byte{0xFF}, cfg.UploadBytes),
+	}
+}
+
+// Run starts projects at the configured rate for cfg.Duration, waits for in-flight
+// projects to finish, and returns the latency report. Cancelling ctx stops both.
+func (r *Runner) Run(ctx context.Context) (*Report, error) {
+	if r.cfg.ProjectsPerSecond <= 0 {
+		return nil, errors.New("projects per second must be positive")
+	}
+	if r.cfg.Concurrency <= 0 || r.cfg.ImagesPerProject < 0 {
+		return nil, errors.New("concurrency must be positive and images per project non-negative")
+	}
+
+	start := time.Now()
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / r.cfg.ProjectsPerSecond))
+	defer ticker.Stop()
+	stop := time.NewTimer(r.cfg.Duration)
+	defer stop.Stop()
+
+	sem := make(chan struct{}, r.cfg.Concurrency)
+	var wg sync.WaitGroup
+	var projects, skipped int64
+
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-stop.C:
+			break loop
+		case <-ticker.C:
+			select {
+			case sem <- struct{}{}:
+			default:
+				skipped++
+				continue
+			}
+			n := atomic.AddInt64(&projects, 1)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+				r.runProject(ctx, n)
+			}()
+		}
+	}
+	wg.Wait()
+
+	return &Report{
+		Duration: time.Since(start),
+		Projects: int(projects),
+		Skipped:  int(skipped),
+		Stages:   r.rec.Summarize(),
+	}, ctx.Err()
+}
+
+func (r *Runner) runProject(ctx context.Context, n int64) {
+	var project struct {
+		ID string `json:"id"`
+	}
+	body := map[string]string{"name": fmt.Sprintf("loadtest-%d-%d", time.Now().Unix(), n)}
+	if err := r.timed(StageProjectCreate, func() error {
+		return r.doJSON(ctx, http.MethodPost, "/api/v1/projects", body, http.StatusCreated, &project)
+	}); err != nil {
+		return
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < r.cfg.ImagesPerProject; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r.runImage(ctx, project.ID, i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func (r *Runner) runImage(ctx context.Context, projectID string, i int) {
+	var presign struct {
+		UploadURL string `json:"upload_url"`
+	}
+	presignReq := map[string]any{
+		"filename":     fmt.Sprintf("loadtest-%d.jpg", i),
+		"content_type": "image/jpeg",
+		"file_size":    len(r.payload),
+	}
+	if err := r.timed(StagePresign, func() error {
+		return r.doJSON(ctx, http.MethodPost, "/api/v1/uploads/presign", presignReq, http.StatusOK, &presign)
+	}); err != nil {
+		return
+	}
+
+	if err := r.timed(StageUpload, func() error { return r.upload(ctx, presign.UploadURL) }); err != nil {
+		return
+	}
+
+	// The API expects the object URL without the presign query, as the web app sends it.
+	originalURL, err := url.Parse(presign.UploadURL)
+	if err != nil {
+		r.rec.Fail(StageImageCreate, err)
+		return
+	}
+	originalURL.RawQuery = ""
+
+	var img struct {
+		ID string `json:"id"`
+	}
+	imageReq := map[string]string{"project_id": projectID, "original_url": originalURL.String()}
+	created := time.Now()
+	if err := r.timed(StageImageCreate, func() error {
+		return r.doJSON(ctx, http.MethodPost, "/api/v1/images", imageReq, http.StatusCreated, &img)
+	}); err != nil {
+		return
+	}
+
+	// Job completion is measured from image creation, so it includes queueing time.
+	if err := r.awaitJob(ctx, img.ID); err != nil {
+		r.rec.Fail(StageJobComplete, err)
+		return
+	}
+	r.rec.Observe(StageJobComplete, time.Since(created))
+}
+
+// timed runs fn and records its latency, or a failure, against stage.
+func (r *Runner) timed(stage Stage, fn func() error) error {
+	started := time.Now()
+	if err := fn(); err != nil {
+		r.rec.Fail(stage, err)
+		return err
+	}
+	r.rec.Observe(stage, time.Since(started))
+	return nil
+}
+
+func (r *Runner) upload(ctx context.Context, uploadURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uploadURL, bytes.NewReader(r.payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "image/jpeg")
+	res, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+	_, _ = io.Copy(io.Discard, res.Body)
+	if res.StatusCode/100 != 2 {
+		return fmt.Errorf("upload returned %d", res.StatusCode)
+	}
+	return nil
+}
+
+// awaitJob polls the image until it reaches a terminal status.
+func (r *Runner) awaitJob(ctx context.Context, imageID string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.CompletionTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		var img struct {
+			Status image.Status `json:"status"`
+		}
+		if err := r.doJSON(ctx, http.MethodGet, "/api/v1/images/"+imageID, nil, http.StatusOK, &img); err != nil {
+			return err
+		}
+		switch img.Status {
+		case image.StatusReady:
+			return nil
+		case image.StatusError, image.StatusCanceled:
+			return fmt.Errorf("image %s finished with status %s", imageID, img.Status)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Runner) doJSON(ctx context.Context, method, path string, in any, want int, out any) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(r.cfg.BaseURL, "/")+path, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if r.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.cfg.Token)
+	}
+	res, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.StatusCode != want {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return fmt.Errorf("%s %s returned %d: %s", method, path, res.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
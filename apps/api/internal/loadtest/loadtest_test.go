@@ -0,0 +1,138 @@
+package loadtest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAPI mimics the endpoints a load test touches. Images become ready on their
+// second poll, or finish with an error when failPoll is set.
+type fakeAPI struct {
+	mu       sync.Mutex
+	polls    map[string]int
+	uploads  int64
+	images   int64
+	auth     string
+	failPoll bool
+}
+
+func (f *fakeAPI) handler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/projects", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		f.auth = r.Header.Get("Authorization")
+		f.mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": "p1"})
+	})
+	mux.HandleFunc("POST /api/v1/uploads/presign", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Filename string `json:"filename"`
+			FileSize int    `json:"file_size"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, 64, req.FileSize)
+		uploadURL := "http://" + r.Host + "/bucket/" + req.Filename + "?X-Amz-Signature=abc"
+		_ = json.NewEncoder(w).Encode(map[string]string{"upload_url": uploadURL})
+	})
+	mux.HandleFunc("PUT /bucket/", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Len(t, body, 64)
+		atomic.AddInt64(&f.uploads, 1)
+	})
+	mux.HandleFunc("POST /api/v1/images", func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.NotContains(t, req["original_url"], "X-Amz-Signature")
+		id := strings.TrimPrefix(req["original_url"], "http://"+r.Host+"/bucket/")
+		atomic.AddInt64(&f.images, 1)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": id})
+	})
+	mux.HandleFunc("GET /api/v1/images/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		f.polls[r.PathValue("id")]++
+		n := f.polls[r.PathValue("id")]
+		f.mu.Unlock()
+		status := "processing"
+		switch {
+		case f.failPoll:
+			status = "error"
+		case n >= 2:
+			status = "ready"
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"status": status})
+	})
+	return mux
+}
+
+func TestRunner_Run(t *testing.T) {
+	testCases := []struct {
+		name     string
+		failPoll bool
+	}{
+		{name: "success: every stage recorded"},
+		{name: "fail: errored jobs counted against job_complete", failPoll: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			api := &fakeAPI{polls: map[string]int{}, failPoll: tc.failPoll}
+			srv := httptest.NewServer(api.handler(t))
+			defer srv.Close()
+
+			cfg := Config{
+				BaseURL:           srv.URL,
+				Token:             "tok",
+				Duration:          250 * time.Millisecond,
+				ProjectsPerSecond: 10,
+				ImagesPerProject:  3,
+				Concurrency:       2,
+				UploadBytes:       64,
+				PollInterval:      5 * time.Millisecond,
+				CompletionTimeout: time.Second,
+			}
+			rep, err := NewRunner(cfg, srv.Client()).Run(context.Background())
+			require.NoError(t, err)
+
+			assert.Equal(t, "Bearer tok", api.auth)
+			assert.Positive(t, rep.Projects)
+			byStage := map[Stage]StageStats{}
+			for _, s := range rep.Stages {
+				byStage[s.Stage] = s
+			}
+			images := rep.Projects * cfg.ImagesPerProject
+			assert.Equal(t, rep.Projects, byStage[StageProjectCreate].Count)
+			assert.Equal(t, images, byStage[StageUpload].Count)
+			assert.Equal(t, int64(images), atomic.LoadInt64(&api.uploads))
+			assert.Equal(t, images, byStage[StageImageCreate].Count)
+			// Every started project runs to completion before Run returns.
+			assert.Equal(t, images, byStage[StageJobComplete].Count+byStage[StageJobComplete].Errors)
+			if tc.failPoll {
+				assert.Zero(t, byStage[StageJobComplete].Count)
+				assert.Contains(t, byStage[StageJobComplete].FirstError, "finished with status error")
+			} else {
+				assert.Zero(t, byStage[StageJobComplete].Errors)
+			}
+		})
+	}
+}
+
+func TestRunner_Run_InvalidConfig(t *testing.T) {
+	_, err := NewRunner(Config{ProjectsPerSecond: 0, Concurrency: 1}, nil).Run(context.Background())
+	assert.Error(t, err)
+
+	_, err = NewRunner(Config{ProjectsPerSecond: 1}, nil).Run(context.Background())
+	assert.Error(t, err)
+}
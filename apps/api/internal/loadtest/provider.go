@@ -0,0 +1,138 @@
+package loadtest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/sse"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// ImageStore is the subset of queries.Querier the fake provider writes job outcomes with.
+type ImageStore interface {
+	UpdateImageStatus(ctx context.Context, arg queries.UpdateImageStatusParams) (*queries.UpdateImageStatusRow, error)
+	UpdateImageWithStagedURL(
+		ctx context.Context, arg queries.UpdateImageWithStagedURLParams,
+	) (*queries.UpdateImageWithStagedURLRow, error)
+	UpdateImageWithError(ctx context.Context, arg queries.UpdateImageWithErrorParams) (*queries.UpdateImageWithErrorRow, error)
+}
+
+// Publisher appends an SSE status payload to an image's Redis stream.
+type Publisher func(ctx context.Context, stream, payload string) error
+
+// ProviderConfig shapes the fake provider's behavior.
+type ProviderConfig struct {
+	// Queue is the Asynq queue to consume; it must match the API's JOB_QUEUE_NAME.
+	Queue string
+	// Concurrency is how many jobs are processed at once, i.e. the provider's capacity.
+	Concurrency int
+	// Latency is the simulated time a prediction takes.
+	Latency time.Duration
+	// Jitter adds up to this much uniformly random time on top of Latency.
+	Jitter time.Duration
+	// FailureRate is the fraction of jobs (0-1) that finish with an error.
+	FailureRate float64
+	// Keys opens sealed payloads; it must match the API's payload encryption config.
+	Keys *queue.Keyring
+}
+
+// FakeProvider stands in for the worker and model provider: it consumes stage:run tasks,
+// waits a simulated prediction time, and marks the image ready (or failed) the way the
+// worker does, including the SSE status events.
+type FakeProvider struct {
+	cfg     ProviderConfig
+	store   ImageStore
+	publish Publisher
+}
+
+// NewFakeProvider returns a FakeProvider.
+func NewFakeProvider(cfg ProviderConfig, store ImageStore, publish Publisher) *FakeProvider {
+	return &FakeProvider{cfg: cfg, store: store, publish: publish}
+}
+
+// Serve consumes tasks from Redis until ctx is cancelled.
+func (p *FakeProvider) Serve(ctx context.Context, redisAddr string) error {
+	srv := asynq.NewServer(asynq.RedisClientOpt{Addr: redisAddr}, asynq.Config{
+		Concurrency: p.cfg.Concurrency,
+		Queues:      map[string]int{p.cfg.Queue: 1},
+	})
+	mux := asynq.NewServeMux()
+	mux.HandleFunc(queue.TaskTypeStageRun, p.ProcessTask)
+	if err := srv.Start(mux); err != nil {
+		return fmt.Errorf("start fake provider: %w", err)
+	}
+	<-ctx.Done()
+	srv.Shutdown()
+	return nil
+}
+
+// ProcessTask handles a single stage:run task.
+func (p *FakeProvider) ProcessTask(ctx context.Context, task *asynq.Task) error {
+	raw, err := p.cfg.Keys.Open(task.Payload())
+	if err != nil {
+		return fmt.Errorf("open payload: %w: %w", err, asynq.SkipRetry)
+	}
+	var payload queue.StageRunPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return fmt.Errorf("decode payload: %w: %w", err, asynq.SkipRetry)
+	}
+	parsed, err := uuid.Parse(payload.ImageID)
+	if err != nil {
+		return fmt.Errorf("invalid image id %q: %w", payload.ImageID, asynq.SkipRetry)
+	}
+	id := pgtype.UUID{Bytes: parsed, Valid: true}
+	stream := sse.StreamKey(payload.ImageID)
+
+	if _, err := p.store.UpdateImageStatus(ctx, queries.UpdateImageStatusParams{
+		ID: id, Status: queries.ImageStatusProcessing,
+	}); err != nil {
+		return fmt.Errorf("mark processing: %w", err)
+	}
+	p.notify(ctx, stream, queries.ImageStatusProcessing)
+
+	delay := p.cfg.Latency
+	if p.cfg.Jitter > 0 {
+		delay += rand.N(p.cfg.Jitter)
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(delay):
+	}
+
+	// Failures are final rather than retried so the configured rate is what the API sees.
+	if p.cfg.FailureRate > 0 && rand.Float64() < p.cfg.FailureRate {
+		if _, err := p.store.UpdateImageWithError(ctx, queries.UpdateImageWithErrorParams{
+			ID: id, Error: pgtype.Text{String: "simulated provider failure", Valid: true},
+		}); err != nil {
+			return fmt.Errorf("mark error: %w", err)
+		}
+		p.notify(ctx, stream, queries.ImageStatusError)
+		return nil
+	}
+
+	if _, err := p.store.UpdateImageWithStagedURL(ctx, queries.UpdateImageWithStagedURLParams{
+		ID:        id,
+		StagedUrl: pgtype.Text{String: payload.OriginalURL + "-staged.jpg", Valid: true},
+		Status:    queries.ImageStatusReady,
+	}); err != nil {
+		return fmt.Errorf("mark ready: %w", err)
+	}
+	p.notify(ctx, stream, queries.ImageStatusReady)
+	return nil
+}
+
+func (p *FakeProvider) notify(ctx context.Context, stream string, status queries.ImageStatus) {
+	if p.publish == nil {
+		return
+	}
+	_ = p.publish(ctx, stream, fmt.Sprintf(`{"status":%q}`, status))
+}
@@ -0,0 +1,111 @@
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/sse"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+func TestFakeProvider_ProcessTask(t *testing.T) {
+	const imageID = "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12"
+	payload := `{"image_id":"` + imageID + `","original_url":"http://minio/bucket/a.jpg"}`
+
+	testCases := []struct {
+		name        string
+		payload     string
+		failureRate float64
+		statusErr   error
+		wantErr     bool
+		wantSkip    bool
+		wantEvents  []string
+		wantStaged  string
+		wantFailed  bool
+	}{
+		{
+			name:       "success: marks ready",
+			payload:    payload,
+			wantEvents: []string{`{"status":"processing"}`, `{"status":"ready"}`},
+			wantStaged: "http://minio/bucket/a.jpg-staged.jpg",
+		},
+		{
+			name:        "success: simulated failure marks error",
+			payload:     payload,
+			failureRate: 1,
+			wantEvents:  []string{`{"status":"processing"}`, `{"status":"error"}`},
+			wantFailed:  true,
+		},
+		{
+			name:     "fail: malformed payload is not retried",
+			payload:  `{`,
+			wantErr:  true,
+			wantSkip: true,
+		},
+		{
+			name:     "fail: invalid image id is not retried",
+			payload:  `{"image_id":"nope"}`,
+			wantErr:  true,
+			wantSkip: true,
+		},
+		{
+			name:      "fail: status update error",
+			payload:   payload,
+			statusErr: errors.New("db down"),
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var stagedURL string
+			var failed bool
+			store := &queries.QuerierMock{
+				UpdateImageStatusFunc: func(ctx context.Context, arg queries.UpdateImageStatusParams) (*queries.UpdateImageStatusRow, error) {
+					assert.Equal(t, queries.ImageStatusProcessing, arg.Status)
+					return &queries.UpdateImageStatusRow{}, tc.statusErr
+				},
+				UpdateImageWithStagedURLFunc: func(
+					ctx context.Context, arg queries.UpdateImageWithStagedURLParams,
+				) (*queries.UpdateImageWithStagedURLRow, error) {
+					stagedURL = arg.StagedUrl.String
+					return &queries.UpdateImageWithStagedURLRow{}, nil
+				},
+				UpdateImageWithErrorFunc: func(
+					ctx context.Context, arg queries.UpdateImageWithErrorParams,
+				) (*queries.UpdateImageWithErrorRow, error) {
+					failed = true
+					return &queries.UpdateImageWithErrorRow{}, nil
+				},
+			}
+			var mu sync.Mutex
+			var events []string
+			publish := func(ctx context.Context, stream, payload string) error {
+				mu.Lock()
+				defer mu.Unlock()
+				assert.Equal(t, sse.StreamKey(imageID), stream)
+				events = append(events, payload)
+				return nil
+			}
+
+			p := NewFakeProvider(ProviderConfig{FailureRate: tc.failureRate}, store, publish)
+			err := p.ProcessTask(context.Background(), asynq.NewTask(queue.TaskTypeStageRun, []byte(tc.payload)))
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, tc.wantSkip, errors.Is(err, asynq.SkipRetry))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantEvents, events)
+			assert.Equal(t, tc.wantStaged, stagedURL)
+			assert.Equal(t, tc.wantFailed, failed)
+		})
+	}
+}
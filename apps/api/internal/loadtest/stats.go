@@ -0,0 +1,145 @@
+package loadtest
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Stage names a step of the staging pipeline whose latency is measured.
+type Stage string
+
+// Stages in the order a user goes through them.
+const (
+	StageProjectCreate Stage = "project_create"
+	StagePresign       Stage = "presign"
+	StageUpload        Stage = "upload"
+	StageImageCreate   Stage = "image_create"
+	StageJobComplete   Stage = "job_complete"
+)
+
+// Stages lists every stage in report order.
+var Stages = []Stage{StageProjectCreate, StagePresign, StageUpload, StageImageCreate, StageJobComplete}
+
+// Recorder collects per-stage latencies and errors. It is safe for concurrent use.
+type Recorder struct {
+	mu        sync.Mutex
+	latencies map[Stage][]time.Duration
+	errors    map[Stage]int
+	firstErr  map[Stage]string
+}
+
+// NewRecorder returns an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{
+		latencies: make(map[Stage][]time.Duration),
+		errors:    make(map[Stage]int),
+		firstErr:  make(map[Stage]string),
+	}
+}
+
+// Observe records a successful stage that took d.
+func (r *Recorder) Observe(stage Stage, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.latencies[stage] = append(r.latencies[stage], d)
+}
+
+// Fail records a failed attempt at stage. The first error per stage is kept for the report.
+func (r *Recorder) Fail(stage Stage, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors[stage]++
+	if _, ok := r.firstErr[stage]; !ok && err != nil {
+		r.firstErr[stage] = err.Error()
+	}
+}
+
+// StageStats summarizes one stage.
+type StageStats struct {
+	Stage  Stage         `json:"stage"`
+	Count  int           `json:"count"`
+	Errors int           `json:"errors"`
+	P50    time.Duration `json:"p50_ns"`
+	P95    time.Duration `json:"p95_ns"`
+	P99    time.Duration `json:"p99_ns"`
+	Max    time.Duration `json:"max_ns"`
+	// FirstError is the first failure seen, to tell systemic errors from noise.
+	FirstError string `json:"first_error,omitempty"`
+}
+
+// Report is the outcome of a load test run.
+type Report struct {
+	Duration time.Duration `json:"duration_ns"`
+	Projects int           `json:"projects"`
+	Skipped  int           `json:"skipped"`
+	Stages   []StageStats  `json:"stages"`
+}
+
+// Summarize computes percentiles for every stage that saw traffic.
+func (r *Recorder) Summarize() []StageStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var out []StageStats
+	for _, stage := range Stages {
+		samples := append([]time.Duration(nil), r.latencies[stage]...)
+		errs := r.errors[stage]
+		if len(samples) == 0 && errs == 0 {
+			continue
+		}
+		sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+		s := StageStats{Stage: stage, Count: len(samples), Errors: errs, FirstError: r.firstErr[stage]}
+		if len(samples) > 0 {
+			s.P50 = percentile(samples, 50)
+			s.P95 = percentile(samples, 95)
+			s.P99 = percentile(samples, 99)
+			s.Max = samples[len(samples)-1]
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// percentile returns the nearest-rank p-th percentile of sorted samples.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100 // ceil(p/100 * n)
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// WriteText writes the report as an aligned table.
+func (rep *Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	_, _ = fmt.Fprintf(tw, "stage\tcount\terrors\tp50\tp95\tp99\tmax\t\n")
+	for _, s := range rep.Stages {
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\t%s\t%s\t\n",
+			s.Stage, s.Count, s.Errors, round(s.P50), round(s.P95), round(s.P99), round(s.Max))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	for _, s := range rep.Stages {
+		if s.FirstError != "" {
+			_, _ = fmt.Fprintf(w, "first %s error: %s\n", s.Stage, s.FirstError)
+		}
+	}
+	_, err := fmt.Fprintf(w, "\n%d projects in %s (%d skipped: concurrency limit reached)\n",
+		rep.Projects, round(rep.Duration), rep.Skipped)
+	return err
+}
+
+func round(d time.Duration) time.Duration {
+	if d >= time.Second {
+		return d.Round(time.Millisecond)
+	}
+	return d.Round(100 * time.Microsecond)
+}
@@ -0,0 +1,84 @@
+package loadtest
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPercentile(t *testing.T) {
+	samples := make([]time.Duration, 100)
+	for i := range samples {
+		samples[i] = time.Duration(i+1) * time.Millisecond
+	}
+
+	testCases := []struct {
+		name    string
+		samples []time.Duration
+		p       int
+		want    time.Duration
+	}{
+		{name: "success: p50 of 1..100", samples: samples, p: 50, want: 50 * time.Millisecond},
+		{name: "success: p95 of 1..100", samples: samples, p: 95, want: 95 * time.Millisecond},
+		{name: "success: p99 of 1..100", samples: samples, p: 99, want: 99 * time.Millisecond},
+		{name: "success: single sample", samples: samples[:1], p: 95, want: time.Millisecond},
+		{name: "success: p95 of three rounds up", samples: samples[:3], p: 95, want: 3 * time.Millisecond},
+		{name: "success: no samples", samples: nil, p: 50, want: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, percentile(tc.samples, tc.p))
+		})
+	}
+}
+
+func TestRecorder_Summarize(t *testing.T) {
+	rec := NewRecorder()
+	for _, ms := range []int{30, 10, 20} {
+		rec.Observe(StageUpload, time.Duration(ms)*time.Millisecond)
+	}
+	rec.Observe(StageProjectCreate, 5*time.Millisecond)
+	rec.Fail(StageJobComplete, errors.New("image x finished with status error"))
+	rec.Fail(StageJobComplete, errors.New("second"))
+
+	stats := rec.Summarize()
+	require.Len(t, stats, 3)
+
+	// Stages come back in pipeline order, not insertion order.
+	assert.Equal(t, StageProjectCreate, stats[0].Stage)
+	assert.Equal(t, StageUpload, stats[1].Stage)
+	assert.Equal(t, 3, stats[1].Count)
+	assert.Equal(t, 20*time.Millisecond, stats[1].P50)
+	assert.Equal(t, 30*time.Millisecond, stats[1].Max)
+
+	assert.Equal(t, StageJobComplete, stats[2].Stage)
+	assert.Zero(t, stats[2].Count)
+	assert.Equal(t, 2, stats[2].Errors)
+	assert.Equal(t, "image x finished with status error", stats[2].FirstError)
+}
+
+func TestReport_WriteText(t *testing.T) {
+	rep := &Report{
+		Duration: 2 * time.Second,
+		Projects: 4,
+		Skipped:  1,
+		Stages: []StageStats{
+			{Stage: StagePresign, Count: 4, P50: 1200 * time.Microsecond, P95: 3 * time.Millisecond, Max: 4 * time.Millisecond},
+			{Stage: StageJobComplete, Count: 3, Errors: 1, FirstError: "timeout", P50: 1500 * time.Millisecond},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, rep.WriteText(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "presign")
+	assert.Contains(t, out, "1.2ms")
+	assert.Contains(t, out, "1.5s")
+	assert.Contains(t, out, "first job_complete error: timeout")
+	assert.Contains(t, out, "4 projects in 2s (1 skipped")
+}
@@ -0,0 +1,43 @@
+// Package mailer sends transactional email from the API, such as project invitations.
+// Vendors are registered in providers and selected by config.SMTP.Provider.
+package mailer
+
+import (
+	"context"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/provider"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out mailer_mock.go . Mailer
+
+// Message is a plain-text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers email messages.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// providers are the email vendors, by the name config.SMTP.Provider selects.
+var providers = provider.NewRegistry[config.SMTP, Mailer](provider.CapabilityEmail).
+	Register("smtp", func(cfg config.SMTP) (Mailer, error) { return NewSMTPMailer(cfg) }).
+	Register("log", func(cfg config.SMTP) (Mailer, error) { return NewLogMailer(logging.Default()), nil })
+
+// New returns the Mailer configured by cfg. Without a provider, email goes through SMTP
+// when a host is set and is logged otherwise.
+func New(cfg config.SMTP) (Mailer, error) {
+	name := cfg.Provider
+	if name == "" {
+		name = "log"
+		if cfg.Host != "" {
+			name = "smtp"
+		}
+	}
+	return providers.New(name, cfg)
+}
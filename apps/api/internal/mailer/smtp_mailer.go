@@ -0,0 +1,100 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/provider"
+)
+
+// SMTPMailer sends email through an SMTP relay.
+type SMTPMailer struct {
+	host string
+	addr string
+	auth smtp.Auth
+	from string
+	now  func() time.Time
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// Ensure SMTPMailer implements Mailer and can report its health.
+var (
+	_ Mailer                 = (*SMTPMailer)(nil)
+	_ provider.HealthChecker = (*SMTPMailer)(nil)
+)
+
+// NewSMTPMailer creates an SMTPMailer from configuration.
+// PLAIN auth is used when a username is configured.
+func NewSMTPMailer(cfg config.SMTP) (*SMTPMailer, error) {
+	if cfg.Host == "" {
+		return nil, fmt.Errorf("smtp host is required")
+	}
+	if cfg.From == "" {
+		return nil, fmt.Errorf("smtp from address is required")
+	}
+
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+
+	return &SMTPMailer{
+		host: cfg.Host,
+		addr: net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		auth: auth,
+		from: cfg.From,
+		now:  time.Now,
+		send: smtp.SendMail,
+	}, nil
+}
+
+// Send delivers msg. The SMTP exchange does not take a context, so ctx is only
+// checked before sending.
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if strings.ContainsAny(msg.To, "\r\n") || strings.ContainsAny(msg.Subject, "\r\n") {
+		return fmt.Errorf("invalid header value")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", m.from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", m.now().UTC().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+
+	if err := m.send(m.addr, m.auth, m.from, []string{msg.To}, []byte(b.String())); err != nil {
+		return fmt.Errorf("send email to %s: %w", msg.To, err)
+	}
+	return nil
+}
+
+// CheckHealth connects to the relay and exchanges greetings, then hangs up without
+// sending anything.
+func (m *SMTPMailer) CheckHealth(ctx context.Context) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", m.addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP relay: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	c, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("SMTP relay did not greet: %w", err)
+	}
+	return c.Quit()
+}
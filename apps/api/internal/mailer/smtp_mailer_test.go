@@ -0,0 +1,165 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+func TestNewSMTPMailer(t *testing.T) {
+	testCases := []struct {
+		name    string
+		cfg     config.SMTP
+		wantErr bool
+	}{
+		{name: "success: with auth", cfg: config.SMTP{Host: "smtp.example.com", Port: 587, Username: "u", From: "a@b.c"}},
+		{name: "success: without auth", cfg: config.SMTP{Host: "localhost", Port: 1025, From: "a@b.c"}},
+		{name: "fail: missing host", cfg: config.SMTP{From: "a@b.c"}, wantErr: true},
+		{name: "fail: missing from", cfg: config.SMTP{Host: "localhost"}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := NewSMTPMailer(tc.cfg)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.cfg.Username != "", m.auth != nil)
+		})
+	}
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	newMailer := func(sendErr error, sent *[]byte) *SMTPMailer {
+		m, err := NewSMTPMailer(config.SMTP{Host: "localhost", Port: 1025, From: "noreply@example.com"})
+		require.NoError(t, err)
+		m.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+		m.send = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+			assert.Equal(t, "localhost:1025", addr)
+			assert.Equal(t, []string{"user@example.com"}, to)
+			*sent = msg
+			return sendErr
+		}
+		return m
+	}
+
+	t.Run("success: formats headers and body", func(t *testing.T) {
+		var sent []byte
+		err := newMailer(nil, &sent).Send(context.Background(), Message{
+			To: "user@example.com", Subject: "Hello", Body: "line 1\nline 2",
+		})
+		require.NoError(t, err)
+		assert.Contains(t, string(sent), "From: noreply@example.com\r\n")
+		assert.Contains(t, string(sent), "Subject: Hello\r\n")
+		assert.Contains(t, string(sent), "\r\n\r\nline 1\r\nline 2")
+	})
+
+	t.Run("fail: header injection", func(t *testing.T) {
+		var sent []byte
+		err := newMailer(nil, &sent).Send(context.Background(), Message{
+			To: "user@example.com", Subject: "Hi\r\nBcc: x@y.z", Body: "b",
+		})
+		assert.Error(t, err)
+		assert.Nil(t, sent)
+	})
+
+	t.Run("fail: relay error", func(t *testing.T) {
+		var sent []byte
+		err := newMailer(errors.New("refused"), &sent).Send(context.Background(), Message{
+			To: "user@example.com", Subject: "Hello", Body: "b",
+		})
+		assert.ErrorContains(t, err, "refused")
+	})
+}
+
+func TestSMTPMailer_CheckHealth(t *testing.T) {
+	testCases := []struct {
+		name     string
+		greeting string
+		wantErr  bool
+	}{
+		{name: "success: relay greets", greeting: "220 smtp.example.com ESMTP ready\r\n"},
+		{name: "fail: relay refuses service", greeting: "554 no service\r\n", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			defer func() { _ = ln.Close() }()
+			go func() {
+				conn, err := ln.Accept()
+				if err != nil {
+					return
+				}
+				defer func() { _ = conn.Close() }()
+				_, _ = conn.Write([]byte(tc.greeting))
+				r := bufio.NewReader(conn)
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if strings.HasPrefix(line, "QUIT") {
+						_, _ = conn.Write([]byte("221 bye\r\n"))
+						return
+					}
+					_, _ = conn.Write([]byte("250 ok\r\n"))
+				}
+			}()
+			host, port, _ := net.SplitHostPort(ln.Addr().String())
+			portNum, _ := strconv.Atoi(port)
+			m, err := NewSMTPMailer(config.SMTP{Host: host, Port: portNum, From: "a@b.c"})
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			err = m.CheckHealth(ctx)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		name    string
+		cfg     config.SMTP
+		want    Mailer
+		wantErr bool
+	}{
+		{name: "success: logs without a host", cfg: config.SMTP{}, want: &LogMailer{}},
+		{name: "success: SMTP with a host", cfg: config.SMTP{Host: "localhost", From: "a@b.c"}, want: &SMTPMailer{}},
+		{name: "success: explicit log", cfg: config.SMTP{Provider: "log", Host: "localhost"}, want: &LogMailer{}},
+		{name: "fail: unknown provider", cfg: config.SMTP{Provider: "carrier-pigeon"}, wantErr: true},
+		{name: "fail: SMTP without a host", cfg: config.SMTP{Provider: "smtp"}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := New(tc.cfg)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.IsType(t, tc.want, m)
+		})
+	}
+}
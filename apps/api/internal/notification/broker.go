@@ -0,0 +1,15 @@
+package notification
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out broker_mock.go . Broker
+
+// Broker fans new notifications out to every API replica holding a stream open for the
+// user. Delivery is best effort: a client that was not connected lists what it missed.
+type Broker interface {
+	// Publish sends n to the user's channel.
+	Publish(ctx context.Context, userID string, n *Notification) error
+	// Subscribe returns the notifications published to the user's channel until ctx is done
+	// or unsubscribe is called. The subscription is active when Subscribe returns.
+	Subscribe(ctx context.Context, userID string) (<-chan *Notification, func() error, error)
+}
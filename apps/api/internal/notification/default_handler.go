@@ -0,0 +1,245 @@
+package notification
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// SSE event names on the notification stream.
+const (
+	EventConnected    = "connected"
+	EventHeartbeat    = "heartbeat"
+	EventNotification = "notification"
+)
+
+// defaultHeartbeat is how often an idle stream sends a heartbeat event.
+const defaultHeartbeat = 30 * time.Second
+
+// DefaultHandler serves the notification center over HTTP.
+type DefaultHandler struct {
+	service  Service
+	broker   Broker
+	userRepo user.Repository
+	// heartbeat is the idle interval between heartbeat events on the stream.
+	heartbeat time.Duration
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler. A nil broker disables the stream.
+func NewDefaultHandler(service Service, broker Broker, userRepo user.Repository) *DefaultHandler {
+	return &DefaultHandler{service: service, broker: broker, userRepo: userRepo, heartbeat: defaultHeartbeat}
+}
+
+// ErrorResponse is a simple JSON error envelope for handler responses.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// UnreadCountResponse carries the user's unread count.
+type UnreadCountResponse struct {
+	UnreadCount int64 `json:"unread_count"`
+}
+
+// MarkAllReadResponse reports how many notifications were marked read.
+type MarkAllReadResponse struct {
+	Updated int64 `json:"updated"`
+}
+
+// List handles GET /api/v1/me/notifications?limit=&offset=&unread=true.
+func (h *DefaultHandler) List(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	p := ListParams{Limit: DefaultLimit}
+	if v := c.QueryParam("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 && n <= int(MaxLimit) {
+			// #nosec G109,G115 -- Value is validated to be positive and within MaxLimit
+			p.Limit = int32(n)
+		}
+	}
+	if v := c.QueryParam("offset"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 && n <= 2147483647 {
+			// #nosec G109,G115 -- Value is validated to fit in int32 range
+			p.Offset = int32(n)
+		}
+	}
+	p.UnreadOnly, _ = strconv.ParseBool(c.QueryParam("unread"))
+
+	result, err := h.service.List(c.Request().Context(), userID, p)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// UnreadCount handles GET /api/v1/me/notifications/unread-count.
+func (h *DefaultHandler) UnreadCount(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	count, err := h.service.UnreadCount(c.Request().Context(), userID)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, UnreadCountResponse{UnreadCount: count})
+}
+
+// MarkRead handles POST /api/v1/me/notifications/:id/read.
+func (h *DefaultHandler) MarkRead(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	n, err := h.service.MarkRead(c.Request().Context(), userID, c.Param("id"))
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, n)
+}
+
+// MarkAllRead handles POST /api/v1/me/notifications/read-all.
+func (h *DefaultHandler) MarkAllRead(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	updated, err := h.service.MarkAllRead(c.Request().Context(), userID)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, MarkAllReadResponse{Updated: updated})
+}
+
+// Stream handles GET /api/v1/me/notifications/stream, pushing the user's new notifications
+// as Server-Sent Events. It subscribes before reading the unread count, so nothing created
+// in between is missed, and sends it in the initial event:
+//
+//	event: connected
+//	data: {"unread_count":3}
+//
+//	id: 6f1c...
+//	event: notification
+//	data: {"id":"6f1c...","type":"image_ready",...}
+//
+// Heartbeat events keep idle connections open. Notifications created while the client
+// was disconnected are not replayed; it lists them on reconnect.
+func (h *DefaultHandler) Stream(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+	if h.broker == nil {
+		return h.writeError(c, ErrStreamUnavailable)
+	}
+
+	ctx := c.Request().Context()
+	log := logging.Default()
+	notifications, unsubscribe, err := h.broker.Subscribe(ctx, userID)
+	if err != nil {
+		log.Error(ctx, "notification subscribe failed", "user_id", userID, "error", err)
+		return h.writeError(c, ErrStreamUnavailable)
+	}
+	defer func() { _ = unsubscribe() }()
+
+	unread, err := h.service.UnreadCount(ctx, userID)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+
+	c.Response().Header().Set("Content-Type", "text/event-stream")
+	c.Response().Header().Set("Cache-Control", "no-cache")
+	c.Response().Header().Set("Connection", "keep-alive")
+	c.Response().WriteHeader(http.StatusOK)
+
+	// The server's read/write timeouts are sized for ordinary requests and would cut the
+	// stream off; heartbeats detect dead clients instead.
+	rc := http.NewResponseController(c.Response())
+	_ = rc.SetReadDeadline(time.Time{})
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w := c.Response()
+	if err := writeEvent(w, "", EventConnected, UnreadCountResponse{UnreadCount: unread}); err != nil {
+		return err
+	}
+	w.Flush()
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := writeEvent(w, "", EventHeartbeat, map[string]int64{"timestamp": time.Now().Unix()}); err != nil {
+				return err
+			}
+			w.Flush()
+		case n, ok := <-notifications:
+			if !ok {
+				return nil
+			}
+			if err := writeEvent(w, n.ID, EventNotification, n); err != nil {
+				log.Error(ctx, "notification stream write failed", "user_id", userID, "error", err)
+				return err
+			}
+			w.Flush()
+		}
+	}
+}
+
+// writeEvent writes one Server-Sent Event to w.
+func writeEvent(w io.Writer, id, event string, data any) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if id != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, b)
+	return err
+}
+
+func unauthorized(c echo.Context) error {
+	return c.JSON(http.StatusUnauthorized, ErrorResponse{
+		Error:   "unauthorized",
+		Message: "Unable to resolve current user",
+	})
+}
+
+// writeError maps service errors to responses.
+func (h *DefaultHandler) writeError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Notification not found"})
+	case errors.Is(err, ErrStreamUnavailable):
+		return c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: "service_unavailable", Message: err.Error()})
+	default:
+		c.Logger().Errorf("Notification request failed: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to process notification request",
+		})
+	}
+}
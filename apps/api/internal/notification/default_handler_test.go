@@ -0,0 +1,228 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func userRepoFor(userID uuid.UUID) *user.RepositoryMock {
+	return &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+		},
+	}
+}
+
+func newContext(method, target string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, target, nil)
+	req.Header.Set("X-Test-User", "auth0|user")
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
+
+func TestDefaultHandler_List(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		query          string
+		wantParams     ListParams
+		err            error
+		expectedStatus int
+	}{
+		{
+			name:           "success: defaults",
+			wantParams:     ListParams{Limit: DefaultLimit},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "success: unread page",
+			query:          "?limit=5&offset=10&unread=true",
+			wantParams:     ListParams{Limit: 5, Offset: 10, UnreadOnly: true},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "success: out of range limit is ignored",
+			query:          "?limit=1000",
+			wantParams:     ListParams{Limit: DefaultLimit},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "fail: service error",
+			wantParams:     ListParams{Limit: DefaultLimit},
+			err:            errors.New("db down"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				ListFunc: func(ctx context.Context, uid string, p ListParams) (*ListResult, error) {
+					assert.Equal(t, userID.String(), uid)
+					assert.Equal(t, tc.wantParams, p)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &ListResult{
+						Notifications: []*Notification{{ID: "n-1", Type: TypeImageReady, Data: map[string]string{}}},
+						UnreadCount:   1,
+						Limit:         p.Limit,
+						Offset:        p.Offset,
+					}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, nil, userRepoFor(userID))
+			c, rec := newContext(http.MethodGet, "/api/v1/me/notifications"+tc.query)
+
+			require.NoError(t, h.List(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Contains(t, rec.Body.String(), `"unread_count":1`)
+				assert.Contains(t, rec.Body.String(), `"notifications":[{"id":"n-1"`)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_UnreadCount(t *testing.T) {
+	userID := uuid.New()
+	svc := &ServiceMock{
+		UnreadCountFunc: func(ctx context.Context, uid string) (int64, error) {
+			return 7, nil
+		},
+	}
+	h := NewDefaultHandler(svc, nil, userRepoFor(userID))
+	c, rec := newContext(http.MethodGet, "/api/v1/me/notifications/unread-count")
+
+	require.NoError(t, h.UnreadCount(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"unread_count":7}`, rec.Body.String())
+}
+
+func TestDefaultHandler_MarkRead(t *testing.T) {
+	userID := uuid.New()
+	svc := &ServiceMock{
+		MarkReadFunc: func(ctx context.Context, uid, id string) (*Notification, error) {
+			if id != "n-1" {
+				return nil, ErrNotFound
+			}
+			now := time.Now()
+			return &Notification{ID: id, ReadAt: &now}, nil
+		},
+	}
+	h := NewDefaultHandler(svc, nil, userRepoFor(userID))
+
+	for id, want := range map[string]int{"n-1": http.StatusOK, "other": http.StatusNotFound} {
+		c, rec := newContext(http.MethodPost, "/api/v1/me/notifications/"+id+"/read")
+		c.SetParamNames("id")
+		c.SetParamValues(id)
+
+		require.NoError(t, h.MarkRead(c))
+		assert.Equal(t, want, rec.Code, id)
+	}
+}
+
+func TestDefaultHandler_MarkAllRead(t *testing.T) {
+	userID := uuid.New()
+	svc := &ServiceMock{
+		MarkAllReadFunc: func(ctx context.Context, uid string) (int64, error) {
+			assert.Equal(t, userID.String(), uid)
+			return 3, nil
+		},
+	}
+	h := NewDefaultHandler(svc, nil, userRepoFor(userID))
+	c, rec := newContext(http.MethodPost, "/api/v1/me/notifications/read-all")
+
+	require.NoError(t, h.MarkAllRead(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"updated":3}`, rec.Body.String())
+}
+
+func TestDefaultHandler_Stream(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("success: sends unread count then notifications", func(t *testing.T) {
+		ch := make(chan *Notification, 1)
+		ch <- &Notification{ID: "n-1", Type: TypeImageReady, Title: "Your image is ready", Data: map[string]string{}}
+		close(ch)
+		unsubscribed := false
+		broker := &BrokerMock{
+			SubscribeFunc: func(ctx context.Context, uid string) (<-chan *Notification, func() error, error) {
+				assert.Equal(t, userID.String(), uid)
+				return ch, func() error { unsubscribed = true; return nil }, nil
+			},
+		}
+		svc := &ServiceMock{
+			UnreadCountFunc: func(ctx context.Context, uid string) (int64, error) {
+				assert.Len(t, broker.SubscribeCalls(), 1, "subscribe before counting")
+				return 2, nil
+			},
+		}
+		h := NewDefaultHandler(svc, broker, userRepoFor(userID))
+		c, rec := newContext(http.MethodGet, "/api/v1/me/notifications/stream")
+
+		require.NoError(t, h.Stream(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+		body := rec.Body.String()
+		assert.True(t, strings.HasPrefix(body, "event: connected\ndata: {\"unread_count\":2}\n\n"), body)
+		assert.Contains(t, body, "id: n-1\nevent: notification\ndata: {\"id\":\"n-1\",\"type\":\"image_ready\"")
+		assert.True(t, unsubscribed)
+	})
+
+	t.Run("success: heartbeats until the client leaves", func(t *testing.T) {
+		broker := &BrokerMock{
+			SubscribeFunc: func(ctx context.Context, uid string) (<-chan *Notification, func() error, error) {
+				return make(chan *Notification), func() error { return nil }, nil
+			},
+		}
+		svc := &ServiceMock{UnreadCountFunc: func(ctx context.Context, uid string) (int64, error) { return 0, nil }}
+		h := NewDefaultHandler(svc, broker, userRepoFor(userID))
+		h.heartbeat = 5 * time.Millisecond
+
+		c, rec := newContext(http.MethodGet, "/api/v1/me/notifications/stream")
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+		defer cancel()
+		c.SetRequest(c.Request().WithContext(ctx))
+
+		require.NoError(t, h.Stream(c))
+		assert.Contains(t, rec.Body.String(), "event: heartbeat\n")
+	})
+
+	t.Run("fail: stream not configured", func(t *testing.T) {
+		h := NewDefaultHandler(&ServiceMock{}, nil, userRepoFor(userID))
+		c, rec := newContext(http.MethodGet, "/api/v1/me/notifications/stream")
+
+		require.NoError(t, h.Stream(c))
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+
+	t.Run("fail: subscribe error", func(t *testing.T) {
+		broker := &BrokerMock{
+			SubscribeFunc: func(ctx context.Context, uid string) (<-chan *Notification, func() error, error) {
+				return nil, nil, errors.New("redis down")
+			},
+		}
+		h := NewDefaultHandler(&ServiceMock{}, broker, userRepoFor(userID))
+		c, rec := newContext(http.MethodGet, "/api/v1/me/notifications/stream")
+
+		require.NoError(t, h.Stream(c))
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+}
@@ -0,0 +1,198 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// DefaultService implements Service and Notifier.
+type DefaultService struct {
+	querier queries.Querier
+	// broker pushes new notifications to open streams. Nil disables live updates.
+	broker Broker
+}
+
+// Ensure DefaultService implements Service and Notifier.
+var (
+	_ Service  = (*DefaultService)(nil)
+	_ Notifier = (*DefaultService)(nil)
+)
+
+// NewDefaultService creates a new DefaultService with a database. A nil broker records
+// notifications without pushing them to open streams.
+func NewDefaultService(db storage.Database, broker Broker) *DefaultService {
+	return NewDefaultServiceWithQuerier(queries.New(db), broker)
+}
+
+// NewDefaultServiceWithQuerier creates a new DefaultService with a custom querier (for testing).
+func NewDefaultServiceWithQuerier(querier queries.Querier, broker Broker) *DefaultService {
+	return &DefaultService{querier: querier, broker: broker}
+}
+
+// Notify records n and publishes it to the user's channel. A failed publish is logged:
+// the notification is stored and shows up the next time the client lists.
+func (s *DefaultService) Notify(ctx context.Context, n NewNotification) (*Notification, error) {
+	uid, err := parseUUID(n.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid user ID", ErrInvalid)
+	}
+	if !slices.Contains(Types, n.Type) {
+		return nil, fmt.Errorf("%w: unknown type %q", ErrInvalid, n.Type)
+	}
+	if strings.TrimSpace(n.Title) == "" {
+		return nil, fmt.Errorf("%w: title is required", ErrInvalid)
+	}
+	data := n.Data
+	if data == nil {
+		data = map[string]string{}
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode notification data: %w", err)
+	}
+
+	row, err := s.querier.CreateNotification(ctx, queries.CreateNotificationParams{
+		UserID:    uid,
+		Type:      n.Type,
+		Title:     n.Title,
+		Body:      n.Body,
+		Data:      raw,
+		DedupeKey: pgtype.Text{String: n.DedupeKey, Valid: n.DedupeKey != ""},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// An unread notification with the same dedupe key already covers this event.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	created := toNotification(row)
+	if s.broker != nil {
+		if err := s.broker.Publish(ctx, n.UserID, created); err != nil {
+			logging.Default().Warn(ctx, "Failed to publish notification",
+				"notification_id", created.ID, "user_id", n.UserID, "error", err)
+		}
+	}
+	return created, nil
+}
+
+// List returns a page of the user's notifications newest first, with their unread count.
+func (s *DefaultService) List(ctx context.Context, userID string, p ListParams) (*ListResult, error) {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	if p.Limit <= 0 || p.Limit > MaxLimit {
+		p.Limit = DefaultLimit
+	}
+	if p.Offset < 0 {
+		p.Offset = 0
+	}
+
+	rows, err := s.querier.ListNotifications(ctx, queries.ListNotificationsParams{
+		UserID:     uid,
+		UnreadOnly: p.UnreadOnly,
+		Limit:      p.Limit,
+		Offset:     p.Offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	unread, err := s.querier.CountUnreadNotifications(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	items := make([]*Notification, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, toNotification(row))
+	}
+	return &ListResult{Notifications: items, UnreadCount: unread, Limit: p.Limit, Offset: p.Offset}, nil
+}
+
+// UnreadCount returns how many of the user's notifications are unread.
+func (s *DefaultService) UnreadCount(ctx context.Context, userID string) (int64, error) {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid user ID: %w", err)
+	}
+	count, err := s.querier.CountUnreadNotifications(ctx, uid)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+// MarkRead marks one of the user's notifications read.
+func (s *DefaultService) MarkRead(ctx context.Context, userID, id string) (*Notification, error) {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	nid, err := parseUUID(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	row, err := s.querier.MarkNotificationRead(ctx, queries.MarkNotificationReadParams{ID: nid, UserID: uid})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark notification read: %w", err)
+	}
+	return toNotification(row), nil
+}
+
+// MarkAllRead marks every unread notification of the user read.
+func (s *DefaultService) MarkAllRead(ctx context.Context, userID string) (int64, error) {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid user ID: %w", err)
+	}
+	updated, err := s.querier.MarkAllNotificationsRead(ctx, uid)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+	return updated, nil
+}
+
+func toNotification(row *queries.Notification) *Notification {
+	n := &Notification{
+		ID:        uuid.UUID(row.ID.Bytes).String(),
+		Type:      row.Type,
+		Title:     row.Title,
+		Body:      row.Body,
+		Data:      map[string]string{},
+		CreatedAt: row.CreatedAt.Time,
+	}
+	if len(row.Data) > 0 {
+		// Producers only write string values; anything else is dropped rather than failing the read.
+		_ = json.Unmarshal(row.Data, &n.Data)
+	}
+	if row.ReadAt.Valid {
+		readAt := row.ReadAt.Time
+		n.ReadAt = &readAt
+	}
+	return n
+}
+
+func parseUUID(id string) (pgtype.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+	return pgtype.UUID{Bytes: parsed, Valid: true}, nil
+}
@@ -0,0 +1,225 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+func notificationRow(id, userID uuid.UUID, read bool) *queries.Notification {
+	row := &queries.Notification{
+		ID:        pgtype.UUID{Bytes: id, Valid: true},
+		UserID:    pgtype.UUID{Bytes: userID, Valid: true},
+		Type:      TypeImageReady,
+		Title:     "Your image is ready",
+		Data:      []byte(`{"image_id":"img-1"}`),
+		CreatedAt: pgtype.Timestamptz{Time: time.Unix(1700000000, 0).UTC(), Valid: true},
+	}
+	if read {
+		row.ReadAt = pgtype.Timestamptz{Time: time.Unix(1700000100, 0).UTC(), Valid: true}
+	}
+	return row
+}
+
+func TestDefaultService_Notify(t *testing.T) {
+	id, userID := uuid.New(), uuid.New()
+	valid := NewNotification{
+		UserID:    userID.String(),
+		Type:      TypeShareLinkViewed,
+		Title:     "Someone opened your share link",
+		Data:      map[string]string{"image_id": "img-1"},
+		DedupeKey: "share_link_viewed:img-1",
+	}
+
+	testCases := []struct {
+		name        string
+		n           NewNotification
+		createErr   error
+		publishErr  error
+		wantErr     error
+		wantNil     bool
+		wantPublish int
+	}{
+		{name: "success: created and published", n: valid, wantPublish: 1},
+		{name: "success: publish failure is not an error", n: valid, publishErr: errors.New("redis down"), wantPublish: 1},
+		{name: "success: collapsed into an unread duplicate", n: valid, createErr: pgx.ErrNoRows, wantNil: true},
+		{name: "fail: unknown type", n: NewNotification{UserID: userID.String(), Type: "nope", Title: "x"}, wantErr: ErrInvalid},
+		{name: "fail: missing title", n: NewNotification{UserID: userID.String(), Type: TypeImageReady}, wantErr: ErrInvalid},
+		{name: "fail: invalid user", n: NewNotification{UserID: "nope", Type: TypeImageReady, Title: "x"}, wantErr: ErrInvalid},
+		{name: "fail: insert error", n: valid, createErr: errors.New("db down")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				CreateNotificationFunc: func(ctx context.Context, arg queries.CreateNotificationParams) (*queries.Notification, error) {
+					assert.Equal(t, userID, uuid.UUID(arg.UserID.Bytes))
+					assert.JSONEq(t, `{"image_id":"img-1"}`, string(arg.Data))
+					assert.Equal(t, pgtype.Text{String: "share_link_viewed:img-1", Valid: true}, arg.DedupeKey)
+					if tc.createErr != nil {
+						return nil, tc.createErr
+					}
+					row := notificationRow(id, userID, false)
+					row.Type, row.Title, row.Data = arg.Type, arg.Title, arg.Data
+					return row, nil
+				},
+			}
+			broker := &BrokerMock{
+				PublishFunc: func(ctx context.Context, uid string, n *Notification) error {
+					assert.Equal(t, userID.String(), uid)
+					assert.Equal(t, id.String(), n.ID)
+					return tc.publishErr
+				},
+			}
+
+			n, err := NewDefaultServiceWithQuerier(q, broker).Notify(context.Background(), tc.n)
+			assert.Len(t, broker.PublishCalls(), tc.wantPublish)
+			switch {
+			case tc.wantErr != nil:
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, q.CreateNotificationCalls())
+			case tc.createErr != nil && !tc.wantNil:
+				assert.ErrorContains(t, err, "db down")
+			case tc.wantNil:
+				require.NoError(t, err)
+				assert.Nil(t, n)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, TypeShareLinkViewed, n.Type)
+				assert.Equal(t, map[string]string{"image_id": "img-1"}, n.Data)
+				assert.Nil(t, n.ReadAt)
+			}
+		})
+	}
+
+	t.Run("success: without a broker", func(t *testing.T) {
+		q := &queries.QuerierMock{
+			CreateNotificationFunc: func(ctx context.Context, arg queries.CreateNotificationParams) (*queries.Notification, error) {
+				assert.False(t, arg.DedupeKey.Valid)
+				assert.JSONEq(t, `{}`, string(arg.Data))
+				return notificationRow(id, userID, false), nil
+			},
+		}
+		n, err := NewDefaultServiceWithQuerier(q, nil).Notify(context.Background(), NewNotification{
+			UserID: userID.String(), Type: TypeImageReady, Title: "Your image is ready",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, id.String(), n.ID)
+	})
+}
+
+func TestDefaultService_List(t *testing.T) {
+	id, userID := uuid.New(), uuid.New()
+
+	testCases := []struct {
+		name      string
+		params    ListParams
+		wantLimit int32
+		listErr   error
+	}{
+		{name: "success: unread page", params: ListParams{Limit: 5, Offset: 10, UnreadOnly: true}, wantLimit: 5},
+		{name: "success: limit defaults when out of range", params: ListParams{Limit: MaxLimit + 1}, wantLimit: DefaultLimit},
+		{name: "fail: query error", params: ListParams{}, wantLimit: DefaultLimit, listErr: errors.New("db down")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				ListNotificationsFunc: func(ctx context.Context, arg queries.ListNotificationsParams) ([]*queries.Notification, error) {
+					assert.Equal(t, userID, uuid.UUID(arg.UserID.Bytes))
+					assert.Equal(t, tc.wantLimit, arg.Limit)
+					assert.Equal(t, tc.params.Offset, arg.Offset)
+					assert.Equal(t, tc.params.UnreadOnly, arg.UnreadOnly)
+					if tc.listErr != nil {
+						return nil, tc.listErr
+					}
+					return []*queries.Notification{notificationRow(id, userID, true)}, nil
+				},
+				CountUnreadNotificationsFunc: func(ctx context.Context, uid pgtype.UUID) (int64, error) {
+					return 3, nil
+				},
+			}
+
+			result, err := NewDefaultServiceWithQuerier(q, nil).List(context.Background(), userID.String(), tc.params)
+			if tc.listErr != nil {
+				assert.ErrorContains(t, err, "db down")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(3), result.UnreadCount)
+			assert.Equal(t, tc.wantLimit, result.Limit)
+			require.Len(t, result.Notifications, 1)
+			assert.Equal(t, id.String(), result.Notifications[0].ID)
+			assert.Equal(t, "img-1", result.Notifications[0].Data["image_id"])
+			require.NotNil(t, result.Notifications[0].ReadAt)
+		})
+	}
+}
+
+func TestDefaultService_MarkRead(t *testing.T) {
+	id, userID := uuid.New(), uuid.New()
+
+	testCases := []struct {
+		name    string
+		id      string
+		markErr error
+		wantErr error
+	}{
+		{name: "success: marked read", id: id.String()},
+		{name: "fail: another user's notification", id: id.String(), markErr: pgx.ErrNoRows, wantErr: ErrNotFound},
+		{name: "fail: malformed id", id: "nope", wantErr: ErrNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				MarkNotificationReadFunc: func(ctx context.Context, arg queries.MarkNotificationReadParams) (*queries.Notification, error) {
+					assert.Equal(t, id, uuid.UUID(arg.ID.Bytes))
+					assert.Equal(t, userID, uuid.UUID(arg.UserID.Bytes))
+					if tc.markErr != nil {
+						return nil, tc.markErr
+					}
+					return notificationRow(id, userID, true), nil
+				},
+			}
+
+			n, err := NewDefaultServiceWithQuerier(q, nil).MarkRead(context.Background(), userID.String(), tc.id)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, n.ReadAt)
+		})
+	}
+}
+
+func TestDefaultService_MarkAllRead(t *testing.T) {
+	userID := uuid.New()
+	q := &queries.QuerierMock{
+		MarkAllNotificationsReadFunc: func(ctx context.Context, uid pgtype.UUID) (int64, error) {
+			assert.Equal(t, userID, uuid.UUID(uid.Bytes))
+			return 4, nil
+		},
+		CountUnreadNotificationsFunc: func(ctx context.Context, uid pgtype.UUID) (int64, error) {
+			return 0, errors.New("db down")
+		},
+	}
+	svc := NewDefaultServiceWithQuerier(q, nil)
+
+	updated, err := svc.MarkAllRead(context.Background(), userID.String())
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), updated)
+
+	_, err = svc.UnreadCount(context.Background(), userID.String())
+	assert.ErrorContains(t, err, "db down")
+}
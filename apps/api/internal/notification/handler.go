@@ -0,0 +1,15 @@
+package notification
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP-level contract for notification endpoints.
+// Implementations should be wired to Echo routes in the server.
+type Handler interface {
+	List(c echo.Context) error
+	UnreadCount(c echo.Context) error
+	MarkRead(c echo.Context) error
+	MarkAllRead(c echo.Context) error
+	Stream(c echo.Context) error
+}
@@ -0,0 +1,86 @@
+// Package notification is the in-app notification center. Producers such as the Stripe
+// webhook and share links, and the worker for image and export events, record a
+// notification for a user and publish it to the user's Redis channel. Users list their
+// notifications, read an unread count, mark them read, and hold open an SSE stream that
+// pushes new ones as they arrive.
+package notification
+
+import (
+	"errors"
+	"time"
+)
+
+// Notification types. The worker writes image_ready, export_complete and sla_credit with
+// the same values, so they must stay in sync with the notifications table's check constraint.
+const (
+	TypeImageReady      = "image_ready"
+	TypePaymentFailed   = "payment_failed"
+	TypeExportComplete  = "export_complete"
+	TypeShareLinkViewed = "share_link_viewed"
+	TypeSLACredit       = "sla_credit"
+)
+
+// Types lists every notification type.
+var Types = []string{TypeImageReady, TypePaymentFailed, TypeExportComplete, TypeShareLinkViewed, TypeSLACredit}
+
+// ChannelPrefix namespaces the per-user Redis channels new notifications are published to.
+const ChannelPrefix = "notifications:user:"
+
+// Channel returns the Redis channel a user's new notifications are published to.
+func Channel(userID string) string {
+	return ChannelPrefix + userID
+}
+
+// Paging limits for List.
+const (
+	DefaultLimit int32 = 20
+	MaxLimit     int32 = 100
+)
+
+var (
+	// ErrNotFound is returned when a notification does not exist or belongs to another user.
+	ErrNotFound = errors.New("notification not found")
+	// ErrInvalid wraps validation failures of a new notification.
+	ErrInvalid = errors.New("invalid notification")
+	// ErrStreamUnavailable is returned when live updates are not configured.
+	ErrStreamUnavailable = errors.New("notification stream not configured")
+)
+
+// Notification is one entry in a user's notification center.
+type Notification struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	// Data holds type-specific references for the client, such as image_id or project_id.
+	Data      map[string]string `json:"data"`
+	ReadAt    *time.Time        `json:"read_at,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// NewNotification describes a notification to record for a user.
+type NewNotification struct {
+	UserID string
+	Type   string
+	Title  string
+	Body   string
+	Data   map[string]string
+	// DedupeKey collapses repeats of the same event: while a notification with the key is
+	// unread, further ones are dropped. Empty never collapses.
+	DedupeKey string
+}
+
+// ListParams pages through a user's notifications, newest first.
+type ListParams struct {
+	Limit      int32
+	Offset     int32
+	UnreadOnly bool
+}
+
+// ListResult is a page of notifications with the user's total unread count.
+type ListResult struct {
+	Notifications []*Notification `json:"notifications"`
+	UnreadCount   int64           `json:"unread_count"`
+	Limit         int32           `json:"limit"`
+	Offset        int32           `json:"offset"`
+}
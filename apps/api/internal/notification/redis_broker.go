@@ -0,0 +1,74 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	redis "github.com/redis/go-redis/v9"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// RedisBroker is a Redis pub/sub Broker. The worker publishes to the same channels.
+type RedisBroker struct {
+	rdb *redis.Client
+}
+
+// Ensure RedisBroker implements Broker.
+var _ Broker = (*RedisBroker)(nil)
+
+// NewRedisBroker creates a RedisBroker connected to addr.
+func NewRedisBroker(addr string) (*RedisBroker, error) {
+	if addr == "" {
+		return nil, errors.New("redis address is not configured")
+	}
+	return NewRedisBrokerWithClient(redis.NewClient(&redis.Options{Addr: addr})), nil
+}
+
+// NewRedisBrokerWithClient creates a RedisBroker with an existing Redis client.
+func NewRedisBrokerWithClient(rdb *redis.Client) *RedisBroker {
+	return &RedisBroker{rdb: rdb}
+}
+
+// Publish sends n to the user's channel as JSON.
+func (b *RedisBroker) Publish(ctx context.Context, userID string, n *Notification) error {
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	if err := b.rdb.Publish(ctx, Channel(userID), payload).Err(); err != nil {
+		return fmt.Errorf("publish %s: %w", Channel(userID), err)
+	}
+	return nil
+}
+
+// Subscribe waits for Redis to confirm the subscription, then forwards each notification
+// published to the user's channel. Malformed messages are skipped.
+func (b *RedisBroker) Subscribe(ctx context.Context, userID string) (<-chan *Notification, func() error, error) {
+	channel := Channel(userID)
+	sub := b.rdb.Subscribe(ctx, channel)
+	if _, err := sub.Receive(ctx); err != nil {
+		_ = sub.Close()
+		return nil, nil, fmt.Errorf("subscribe %s: %w", channel, err)
+	}
+
+	ch := make(chan *Notification)
+	go func() {
+		defer close(ch)
+		for msg := range sub.Channel() {
+			var n Notification
+			if err := json.Unmarshal([]byte(msg.Payload), &n); err != nil {
+				logging.Default().Warn(ctx, "notification malformed payload", "channel", channel, "error", err)
+				continue
+			}
+			select {
+			case ch <- &n:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, sub.Close, nil
+}
@@ -0,0 +1,42 @@
+package notification
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisBroker_PublishSubscribe(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	b := NewRedisBrokerWithClient(rdb)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	ch, unsubscribe, err := b.Subscribe(ctx, "user-1")
+	require.NoError(t, err)
+	defer func() { _ = unsubscribe() }()
+
+	// Malformed payloads, such as from an older producer, are skipped.
+	require.NoError(t, rdb.Publish(ctx, Channel("user-1"), "not json").Err())
+	require.NoError(t, b.Publish(ctx, "user-2", &Notification{ID: "other-user"}))
+	require.NoError(t, b.Publish(ctx, "user-1", &Notification{ID: "n-1", Type: TypeExportComplete}))
+
+	select {
+	case n := <-ch:
+		assert.Equal(t, "n-1", n.ID)
+		assert.Equal(t, TypeExportComplete, n.Type)
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for notification")
+	}
+}
+
+func TestNewRedisBroker(t *testing.T) {
+	_, err := NewRedisBroker("")
+	assert.Error(t, err)
+}
@@ -0,0 +1,24 @@
+package notification
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service Notifier
+
+// Service reads and updates a user's notifications. Every call is scoped to the user.
+type Service interface {
+	// List returns a page of the user's notifications newest first, with their unread count.
+	List(ctx context.Context, userID string, p ListParams) (*ListResult, error)
+	// UnreadCount returns how many of the user's notifications are unread.
+	UnreadCount(ctx context.Context, userID string) (int64, error)
+	// MarkRead marks one of the user's notifications read. Marking a read one again is a no-op.
+	MarkRead(ctx context.Context, userID, id string) (*Notification, error)
+	// MarkAllRead marks every unread notification of the user read and returns how many changed.
+	MarkAllRead(ctx context.Context, userID string) (int64, error)
+}
+
+// Notifier records notifications for users. Producers depend on it rather than on Service.
+type Notifier interface {
+	// Notify records n and publishes it to the user's live stream. It returns nil without
+	// error when n was collapsed into an unread notification with the same dedupe key.
+	Notify(ctx context.Context, n NewNotification) (*Notification, error)
+}
@@ -0,0 +1,256 @@
+package organization
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// DefaultHandler serves organizations and their SSO settings over HTTP.
+type DefaultHandler struct {
+	service  Service
+	userRepo user.Repository
+	// namespace prefixes the custom claims carrying the connection and provider claims.
+	namespace string
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, userRepo user.Repository, cfg config.SSO) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo, namespace: cfg.ClaimNamespace}
+}
+
+// ErrorResponse is a simple JSON error envelope for handler responses.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// ListResponse is the body of organization list responses.
+type ListResponse struct {
+	Organizations []*Organization `json:"organizations"`
+}
+
+// MembersResponse is the body of member list responses.
+type MembersResponse struct {
+	Members []*Member `json:"members"`
+}
+
+// Create handles POST /api/v1/organizations.
+func (h *DefaultHandler) Create(c echo.Context) error {
+	var req CreateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid request format"})
+	}
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	org, err := h.service.Create(c.Request().Context(), userID, req)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusCreated, org)
+}
+
+// List handles GET /api/v1/organizations.
+func (h *DefaultHandler) List(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	orgs, err := h.service.List(c.Request().Context(), userID)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, ListResponse{Organizations: orgs})
+}
+
+// ListMembers handles GET /api/v1/organizations/:id/members.
+func (h *DefaultHandler) ListMembers(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	members, err := h.service.ListMembers(c.Request().Context(), userID, c.Param("id"))
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, MembersResponse{Members: members})
+}
+
+// GetSSO handles GET /api/v1/organizations/:id/sso.
+func (h *DefaultHandler) GetSSO(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	sso, err := h.service.GetSSO(c.Request().Context(), userID, c.Param("id"))
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, sso)
+}
+
+// PutSSO handles PUT /api/v1/organizations/:id/sso.
+func (h *DefaultHandler) PutSSO(c echo.Context) error {
+	var req PutSSORequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid request format"})
+	}
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	sso, err := h.service.PutSSO(c.Request().Context(), userID, c.Param("id"), req)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, sso)
+}
+
+// DeleteSSO handles DELETE /api/v1/organizations/:id/sso.
+func (h *DefaultHandler) DeleteSSO(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	if err := h.service.DeleteSSO(c.Request().Context(), userID, c.Param("id")); err != nil {
+		return h.writeError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Discover handles GET /api/v1/sso/discover?email=. The login page asks it before signing
+// in, to send addresses of SSO domains straight to their connection.
+func (h *DefaultHandler) Discover(c echo.Context) error {
+	d, err := h.service.Discover(c.Request().Context(), c.QueryParam("email"))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "No SSO connection for this domain"})
+		}
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, d)
+}
+
+// Provision handles POST /api/v1/sso/provision. The web app calls it after every SSO
+// sign-in; the user is created if needed and added to the connection's organization. A user
+// the organization's SCIM client created signs in to that account.
+func (h *DefaultHandler) Provision(c echo.Context) error {
+	claims, err := auth.GetClaims(c)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	login := Login{Claims: map[string]any{}}
+	for key, value := range claims {
+		name, ok := strings.CutPrefix(key, h.namespace)
+		if !ok {
+			continue
+		}
+		if name == "connection" {
+			login.Connection, _ = value.(string)
+			continue
+		}
+		login.Claims[name] = value
+	}
+
+	if sub, err := claims.GetSubject(); err == nil {
+		if err := h.service.ClaimSCIMUser(c.Request().Context(), sub, login); err != nil {
+			return h.writeError(c, err)
+		}
+	}
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	member, err := h.service.Provision(c.Request().Context(), userID, login)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "not_found",
+				Message: "Sign-in did not come through an organization's SSO connection",
+			})
+		}
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, member)
+}
+
+// IssueSCIMToken handles POST /api/v1/organizations/:id/scim-token. The token is only shown
+// in this response.
+func (h *DefaultHandler) IssueSCIMToken(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	token, err := h.service.IssueSCIMToken(c.Request().Context(), userID, c.Param("id"))
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusCreated, token)
+}
+
+// RevokeSCIMToken handles DELETE /api/v1/organizations/:id/scim-token.
+func (h *DefaultHandler) RevokeSCIMToken(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	if err := h.service.RevokeSCIMToken(c.Request().Context(), userID, c.Param("id")); err != nil {
+		return h.writeError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func unauthorized(c echo.Context) error {
+	return c.JSON(http.StatusUnauthorized, ErrorResponse{
+		Error:   "unauthorized",
+		Message: "Unable to resolve current user",
+	})
+}
+
+// writeError maps service errors to responses.
+func (h *DefaultHandler) writeError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Organization or SSO settings not found"})
+	case errors.Is(err, ErrForbidden):
+		return c.JSON(http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: "Only organization admins can do this"})
+	case errors.Is(err, ErrNotAllowed):
+		return c.JSON(http.StatusForbidden, ErrorResponse{
+			Error:   "forbidden",
+			Message: "The organization's plan does not include single sign-on",
+		})
+	case errors.Is(err, ErrDeprovisioned):
+		return c.JSON(http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: err.Error()})
+	case errors.Is(err, ErrInvalid):
+		return c.JSON(http.StatusUnprocessableEntity, ErrorResponse{Error: "validation_failed", Message: err.Error()})
+	case errors.Is(err, ErrConnectionTaken), errors.Is(err, ErrDomainTaken):
+		return c.JSON(http.StatusConflict, ErrorResponse{Error: "conflict", Message: err.Error()})
+	default:
+		c.Logger().Errorf("Organization request failed: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to process organization request",
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+var testSSOConfig = config.SSO{ClaimNamespace: "https://realstaging.ai/"}
+
+func userRepoFor(userID uuid.UUID) *user.RepositoryMock {
+	return &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+		},
+	}
+}
+
+func newContext(method, target, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("X-Test-User", "auth0|user")
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
+
+func TestDefaultHandler_PutSSO(t *testing.T) {
+	userID := uuid.New()
+	orgID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: saved", body: `{"protocol":"saml","connection":"acme"}`, expectedStatus: http.StatusOK},
+		{name: "fail: malformed body", body: `{`, expectedStatus: http.StatusBadRequest},
+		{name: "fail: not a member", body: `{}`, err: ErrNotFound, expectedStatus: http.StatusNotFound},
+		{name: "fail: not an admin", body: `{}`, err: ErrForbidden, expectedStatus: http.StatusForbidden},
+		{name: "fail: plan without SSO", body: `{}`, err: ErrNotAllowed, expectedStatus: http.StatusForbidden},
+		{
+			name: "fail: invalid", body: `{}`, err: fmt.Errorf("%w: protocol", ErrInvalid),
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{name: "fail: connection taken", body: `{}`, err: ErrConnectionTaken, expectedStatus: http.StatusConflict},
+		{name: "fail: service error", body: `{}`, err: errors.New("db down"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				PutSSOFunc: func(ctx context.Context, uid, oid string, req PutSSORequest) (*SSO, error) {
+					assert.Equal(t, userID.String(), uid)
+					assert.Equal(t, orgID.String(), oid)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &SSO{OrganizationID: oid, Protocol: req.Protocol, Connection: req.Connection}, nil
+				},
+			}
+			c, rec := newContext(http.MethodPut, "/api/v1/organizations/"+orgID.String()+"/sso", tc.body)
+			c.SetParamNames("id")
+			c.SetParamValues(orgID.String())
+
+			require.NoError(t, NewDefaultHandler(svc, userRepoFor(userID), testSSOConfig).PutSSO(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Contains(t, rec.Body.String(), `"connection":"acme"`)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_Create(t *testing.T) {
+	userID := uuid.New()
+	svc := &ServiceMock{
+		CreateFunc: func(ctx context.Context, uid string, req CreateRequest) (*Organization, error) {
+			return &Organization{ID: uuid.NewString(), Name: req.Name, OwnerID: uid, Role: RoleAdmin}, nil
+		},
+	}
+	c, rec := newContext(http.MethodPost, "/api/v1/organizations", `{"name":"Acme Realty"}`)
+
+	require.NoError(t, NewDefaultHandler(svc, userRepoFor(userID), testSSOConfig).Create(c))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"role":"admin"`)
+}
+
+func TestDefaultHandler_Discover(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: connection found", expectedStatus: http.StatusOK},
+		{name: "fail: unknown domain", err: ErrNotFound, expectedStatus: http.StatusNotFound},
+		{name: "fail: not an email", err: fmt.Errorf("%w: email", ErrInvalid), expectedStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				DiscoverFunc: func(ctx context.Context, email string) (*Discovery, error) {
+					assert.Equal(t, "jo@acme.com", email)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &Discovery{Connection: "acme-okta", Protocol: ProtocolOIDC}, nil
+				},
+			}
+			c, rec := newContext(http.MethodGet, "/api/v1/sso/discover?email=jo@acme.com", "")
+
+			require.NoError(t, NewDefaultHandler(svc, &user.RepositoryMock{}, testSSOConfig).Discover(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
+
+func TestDefaultHandler_Provision(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		claims         jwt.MapClaims
+		err            error
+		expectedStatus int
+	}{
+		{
+			name: "success: provisioned",
+			claims: jwt.MapClaims{
+				"sub":                               "auth0|user",
+				"https://realstaging.ai/connection": "acme-okta",
+				"https://realstaging.ai/groups":     []any{"staging-admins"},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{name: "fail: no token", expectedStatus: http.StatusUnauthorized},
+		{name: "fail: not an SSO login", claims: jwt.MapClaims{"sub": "auth0|user"}, err: ErrNotFound, expectedStatus: http.StatusNotFound},
+		{name: "fail: plan without SSO", claims: jwt.MapClaims{"sub": "auth0|user"}, err: ErrNotAllowed, expectedStatus: http.StatusForbidden},
+		{
+			name: "fail: deactivated by SCIM", claims: jwt.MapClaims{"sub": "auth0|user"}, err: ErrDeprovisioned,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				ClaimSCIMUserFunc: func(ctx context.Context, auth0Sub string, login Login) error {
+					assert.Equal(t, "auth0|user", auth0Sub)
+					return nil
+				},
+				ProvisionFunc: func(ctx context.Context, uid string, login Login) (*Member, error) {
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					assert.Equal(t, "acme-okta", login.Connection)
+					assert.Equal(t, map[string]any{"groups": []any{"staging-admins"}}, login.Claims)
+					return &Member{UserID: uid, Role: RoleAdmin, Source: SourceSSO}, nil
+				},
+			}
+			c, rec := newContext(http.MethodPost, "/api/v1/sso/provision", "")
+			if tc.claims != nil {
+				c.Set("user", &jwt.Token{Claims: tc.claims})
+			}
+
+			require.NoError(t, NewDefaultHandler(svc, userRepoFor(userID), testSSOConfig).Provision(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.claims != nil {
+				require.Len(t, svc.ClaimSCIMUserCalls(), 1)
+				assert.Less(t, 0, len(svc.ProvisionCalls()))
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_IssueSCIMToken(t *testing.T) {
+	userID := uuid.New()
+	orgID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: issued", expectedStatus: http.StatusCreated},
+		{name: "fail: not an admin", err: ErrForbidden, expectedStatus: http.StatusForbidden},
+		{name: "fail: plan without SSO", err: ErrNotAllowed, expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				IssueSCIMTokenFunc: func(ctx context.Context, uid, oid string) (*SCIMToken, error) {
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &SCIMToken{Token: "scim_abc"}, nil
+				},
+			}
+			c, rec := newContext(http.MethodPost, "/api/v1/organizations/"+orgID.String()+"/scim-token", "")
+			c.SetParamNames("id")
+			c.SetParamValues(orgID.String())
+
+			require.NoError(t, NewDefaultHandler(svc, userRepoFor(userID), testSSOConfig).IssueSCIMToken(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.err == nil {
+				assert.Contains(t, rec.Body.String(), `"token":"scim_abc"`)
+			}
+		})
+	}
+}
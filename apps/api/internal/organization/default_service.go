@@ -0,0 +1,361 @@
+package organization
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// DefaultService implements Service.
+type DefaultService struct {
+	querier queries.Querier
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService with a database.
+func NewDefaultService(db storage.Database) *DefaultService {
+	return NewDefaultServiceWithQuerier(queries.New(db))
+}
+
+// NewDefaultServiceWithQuerier creates a new DefaultService with a custom querier (for testing).
+func NewDefaultServiceWithQuerier(querier queries.Querier) *DefaultService {
+	return &DefaultService{querier: querier}
+}
+
+// Create creates an organization owned by the user.
+func (s *DefaultService) Create(ctx context.Context, userID string, req CreateRequest) (*Organization, error) {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+	row, err := s.querier.CreateOrganization(ctx, queries.CreateOrganizationParams{Name: req.Name, OwnerID: uid})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create organization: %w", err)
+	}
+	return &Organization{
+		ID:        row.ID.String(),
+		Name:      row.Name,
+		OwnerID:   row.OwnerID.String(),
+		Role:      RoleAdmin,
+		CreatedAt: row.CreatedAt.Time,
+	}, nil
+}
+
+// List returns the user's organizations.
+func (s *DefaultService) List(ctx context.Context, userID string) ([]*Organization, error) {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.querier.ListOrganizationsByUser(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	orgs := make([]*Organization, 0, len(rows))
+	for _, row := range rows {
+		orgs = append(orgs, &Organization{
+			ID:        row.ID.String(),
+			Name:      row.Name,
+			OwnerID:   row.OwnerID.String(),
+			Role:      row.Role,
+			CreatedAt: row.CreatedAt.Time,
+		})
+	}
+	return orgs, nil
+}
+
+// ListMembers returns the organization's members to any of them.
+func (s *DefaultService) ListMembers(ctx context.Context, userID, orgID string) ([]*Member, error) {
+	oid, err := s.authorize(ctx, userID, orgID, RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.querier.ListOrganizationMembers(ctx, oid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization members: %w", err)
+	}
+	members := make([]*Member, 0, len(rows))
+	for _, row := range rows {
+		members = append(members, memberFromRow(row))
+	}
+	return members, nil
+}
+
+// GetSSO returns the organization's SSO settings to an admin.
+func (s *DefaultService) GetSSO(ctx context.Context, userID, orgID string) (*SSO, error) {
+	oid, err := s.authorize(ctx, userID, orgID, RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.querier.GetOrganizationSSO(ctx, oid)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization SSO: %w", err)
+	}
+	return ssoFromRow(row)
+}
+
+// PutSSO saves the organization's SSO settings. The connection and email domains must not
+// be used by another organization.
+func (s *DefaultService) PutSSO(ctx context.Context, userID, orgID string, req PutSSORequest) (*SSO, error) {
+	oid, err := s.authorize(ctx, userID, orgID, RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkAccess(ctx, oid); err != nil {
+		return nil, err
+	}
+	if len(req.EmailDomains) > 0 {
+		taken, err := s.querier.OrganizationSSODomainsTaken(ctx, queries.OrganizationSSODomainsTakenParams{
+			OrganizationID: oid,
+			Domains:        req.EmailDomains,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to check email domains: %w", err)
+		}
+		if taken {
+			return nil, ErrDomainTaken
+		}
+	}
+
+	mappings := req.RoleMappings
+	if mappings == nil {
+		mappings = map[string]string{}
+	}
+	mappingsJSON, err := json.Marshal(mappings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode role mappings: %w", err)
+	}
+	row, err := s.querier.UpsertOrganizationSSO(ctx, queries.UpsertOrganizationSSOParams{
+		OrganizationID:  oid,
+		Protocol:        req.Protocol,
+		Connection:      req.Connection,
+		OidcIssuer:      optionalText(req.OIDCIssuer),
+		OidcClientID:    optionalText(req.OIDCClientID),
+		SamlMetadataUrl: optionalText(req.SAMLMetadataURL),
+		EmailDomains:    req.EmailDomains,
+		RoleClaim:       req.RoleClaim,
+		RoleMappings:    mappingsJSON,
+		DefaultRole:     req.DefaultRole,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, ErrConnectionTaken
+		}
+		return nil, fmt.Errorf("failed to save organization SSO: %w", err)
+	}
+	return ssoFromRow(row)
+}
+
+// DeleteSSO removes the organization's SSO settings.
+func (s *DefaultService) DeleteSSO(ctx context.Context, userID, orgID string) error {
+	oid, err := s.authorize(ctx, userID, orgID, RoleAdmin)
+	if err != nil {
+		return err
+	}
+	n, err := s.querier.DeleteOrganizationSSO(ctx, oid)
+	if err != nil {
+		return fmt.Errorf("failed to delete organization SSO: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Discover looks up the connection routing email's domain.
+func (s *DefaultService) Discover(ctx context.Context, email string) (*Discovery, error) {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return nil, fmt.Errorf("%w: email address is required", ErrInvalid)
+	}
+	domain := strings.ToLower(strings.TrimSpace(email[at+1:]))
+	row, err := s.querier.GetOrganizationSSOByEmailDomain(ctx, domain)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up SSO domain: %w", err)
+	}
+	return &Discovery{Connection: row.Connection, Protocol: row.Protocol}, nil
+}
+
+// Provision adds the user to the organization behind the login's connection with the role
+// its claims map to. Members added by hand or by SCIM keep their role, and users the SCIM
+// client deactivated are not added back.
+func (s *DefaultService) Provision(ctx context.Context, userID string, login Login) (*Member, error) {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if login.Connection == "" {
+		return nil, ErrNotFound
+	}
+	row, err := s.querier.GetOrganizationSSOByConnection(ctx, login.Connection)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up SSO connection: %w", err)
+	}
+	if err := s.checkAccess(ctx, row.OrganizationID); err != nil {
+		return nil, err
+	}
+	sso, err := ssoFromRow(row)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.querier.UpsertOrganizationSSOMember(ctx, queries.UpsertOrganizationSSOMemberParams{
+		OrganizationID: row.OrganizationID,
+		UserID:         uid,
+		Role:           sso.MapRole(login.Claims),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		member, err = s.querier.GetOrganizationMember(ctx, queries.GetOrganizationMemberParams{
+			OrganizationID: row.OrganizationID,
+			UserID:         uid,
+		})
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrDeprovisioned
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to provision organization member: %w", err)
+	}
+	return memberFromRow(member), nil
+}
+
+// ClaimSCIMUser gives the SCIM-provisioned account whose user name is the login's email
+// claim the signed-in subject.
+func (s *DefaultService) ClaimSCIMUser(ctx context.Context, auth0Sub string, login Login) error {
+	email, _ := login.Claims["email"].(string)
+	if login.Connection == "" || email == "" || auth0Sub == "" {
+		return nil
+	}
+	_, err := s.querier.ClaimSCIMUser(ctx, queries.ClaimSCIMUserParams{
+		Auth0Sub:   auth0Sub,
+		Connection: login.Connection,
+		Email:      email,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to claim SCIM user: %w", err)
+	}
+	return nil
+}
+
+// IssueSCIMToken generates a new SCIM token and stores its hash in place of the old one.
+func (s *DefaultService) IssueSCIMToken(ctx context.Context, userID, orgID string) (*SCIMToken, error) {
+	oid, err := s.authorize(ctx, userID, orgID, RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkAccess(ctx, oid); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("failed to generate SCIM token: %w", err)
+	}
+	token := scimTokenPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	row, err := s.querier.UpsertOrganizationSCIMToken(ctx, queries.UpsertOrganizationSCIMTokenParams{
+		OrganizationID: oid,
+		TokenHash:      HashSCIMToken(token),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save SCIM token: %w", err)
+	}
+	return &SCIMToken{Token: token, CreatedAt: row.CreatedAt.Time}, nil
+}
+
+// RevokeSCIMToken deletes the organization's SCIM token.
+func (s *DefaultService) RevokeSCIMToken(ctx context.Context, userID, orgID string) error {
+	oid, err := s.authorize(ctx, userID, orgID, RoleAdmin)
+	if err != nil {
+		return err
+	}
+	n, err := s.querier.DeleteOrganizationSCIMToken(ctx, oid)
+	if err != nil {
+		return fmt.Errorf("failed to revoke SCIM token: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// authorize returns the parsed organization ID when the user is a member with at least
+// role. Non-members get ErrNotFound, so organizations are not revealed to outsiders.
+func (s *DefaultService) authorize(ctx context.Context, userID, orgID, role string) (pgtype.UUID, error) {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+	parsed, err := uuid.Parse(orgID)
+	if err != nil {
+		return pgtype.UUID{}, ErrNotFound
+	}
+	oid := pgtype.UUID{Bytes: parsed, Valid: true}
+	member, err := s.querier.GetOrganizationMember(ctx, queries.GetOrganizationMemberParams{
+		OrganizationID: oid,
+		UserID:         uid,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return pgtype.UUID{}, ErrNotFound
+	}
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf("failed to get organization membership: %w", err)
+	}
+	if rank[member.Role] < rank[role] {
+		return pgtype.UUID{}, ErrForbidden
+	}
+	return oid, nil
+}
+
+// checkAccess returns ErrNotAllowed unless the organization owner's plan includes SSO.
+func (s *DefaultService) checkAccess(ctx context.Context, oid pgtype.UUID) error {
+	allowed, err := s.querier.GetOrganizationSSOAccess(ctx, oid)
+	if err != nil {
+		return fmt.Errorf("failed to check SSO access: %w", err)
+	}
+	if !allowed {
+		return ErrNotAllowed
+	}
+	return nil
+}
+
+func optionalText(s string) pgtype.Text {
+	return pgtype.Text{String: s, Valid: s != ""}
+}
+
+func parseUUID(id string) (pgtype.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf("invalid user ID: %w", err)
+	}
+	return pgtype.UUID{Bytes: parsed, Valid: true}, nil
+}
@@ -0,0 +1,412 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+func pgUUID(id uuid.UUID) pgtype.UUID {
+	return pgtype.UUID{Bytes: id, Valid: true}
+}
+
+func memberRow(orgID, userID uuid.UUID, role, source string) *queries.OrganizationMember {
+	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	return &queries.OrganizationMember{
+		OrganizationID: pgUUID(orgID),
+		UserID:         pgUUID(userID),
+		Role:           role,
+		Source:         source,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+func ssoRow(orgID uuid.UUID) *queries.OrganizationSso {
+	return &queries.OrganizationSso{
+		OrganizationID: pgUUID(orgID),
+		Protocol:       ProtocolOIDC,
+		Connection:     "acme-okta",
+		OidcIssuer:     pgtype.Text{String: "https://acme.okta.com", Valid: true},
+		OidcClientID:   pgtype.Text{String: "client-123", Valid: true},
+		EmailDomains:   []string{"acme.com"},
+		RoleClaim:      "groups",
+		RoleMappings:   []byte(`{"staging-admins":"admin"}`),
+		DefaultRole:    RoleViewer,
+	}
+}
+
+// memberOf returns a GetOrganizationMember stub: the user holds role, or is no member when
+// role is empty.
+func memberOf(role string) func(context.Context, queries.GetOrganizationMemberParams) (*queries.OrganizationMember, error) {
+	return func(ctx context.Context, arg queries.GetOrganizationMemberParams) (*queries.OrganizationMember, error) {
+		if role == "" {
+			return nil, pgx.ErrNoRows
+		}
+		return memberRow(arg.OrganizationID.Bytes, arg.UserID.Bytes, role, SourceManual), nil
+	}
+}
+
+func TestDefaultService_Create(t *testing.T) {
+	userID := uuid.New()
+	orgID := uuid.New()
+	q := &queries.QuerierMock{
+		CreateOrganizationFunc: func(ctx context.Context, arg queries.CreateOrganizationParams) (*queries.CreateOrganizationRow, error) {
+			assert.Equal(t, "Acme Realty", arg.Name)
+			assert.Equal(t, pgUUID(userID), arg.OwnerID)
+			return &queries.CreateOrganizationRow{ID: pgUUID(orgID), Name: arg.Name, OwnerID: arg.OwnerID}, nil
+		},
+	}
+	svc := NewDefaultServiceWithQuerier(q)
+
+	org, err := svc.Create(context.Background(), userID.String(), CreateRequest{Name: "  Acme Realty "})
+	require.NoError(t, err)
+	assert.Equal(t, orgID.String(), org.ID)
+	assert.Equal(t, RoleAdmin, org.Role)
+
+	_, err = svc.Create(context.Background(), userID.String(), CreateRequest{Name: " "})
+	assert.ErrorIs(t, err, ErrInvalid)
+}
+
+func TestDefaultService_PutSSO(t *testing.T) {
+	userID := uuid.New()
+	orgID := uuid.New()
+
+	testCases := []struct {
+		name      string
+		orgID     string
+		role      string
+		allowed   bool
+		taken     bool
+		upsertErr error
+		wantErr   error
+	}{
+		{name: "success: admin on enterprise plan", orgID: orgID.String(), role: RoleAdmin, allowed: true},
+		{name: "fail: not a member", orgID: orgID.String(), allowed: true, wantErr: ErrNotFound},
+		{name: "fail: malformed organization ID", orgID: "nope", role: RoleAdmin, allowed: true, wantErr: ErrNotFound},
+		{name: "fail: member is not admin", orgID: orgID.String(), role: RoleMember, allowed: true, wantErr: ErrForbidden},
+		{name: "fail: plan without SSO", orgID: orgID.String(), role: RoleAdmin, wantErr: ErrNotAllowed},
+		{name: "fail: domain taken", orgID: orgID.String(), role: RoleAdmin, allowed: true, taken: true, wantErr: ErrDomainTaken},
+		{
+			name: "fail: connection taken", orgID: orgID.String(), role: RoleAdmin, allowed: true,
+			upsertErr: &pgconn.PgError{Code: "23505"}, wantErr: ErrConnectionTaken,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				GetOrganizationMemberFunc: memberOf(tc.role),
+				GetOrganizationSSOAccessFunc: func(ctx context.Context, id pgtype.UUID) (bool, error) {
+					return tc.allowed, nil
+				},
+				OrganizationSSODomainsTakenFunc: func(ctx context.Context, arg queries.OrganizationSSODomainsTakenParams) (bool, error) {
+					assert.Equal(t, []string{"acme.com"}, arg.Domains)
+					return tc.taken, nil
+				},
+				UpsertOrganizationSSOFunc: func(ctx context.Context, arg queries.UpsertOrganizationSSOParams) (*queries.OrganizationSso, error) {
+					if tc.upsertErr != nil {
+						return nil, tc.upsertErr
+					}
+					assert.Equal(t, "acme-okta", arg.Connection)
+					assert.False(t, arg.SamlMetadataUrl.Valid)
+					assert.JSONEq(t, `{"staging-admins":"admin"}`, string(arg.RoleMappings))
+					return &queries.OrganizationSso{
+						OrganizationID: arg.OrganizationID, Protocol: arg.Protocol, Connection: arg.Connection,
+						OidcIssuer: arg.OidcIssuer, OidcClientID: arg.OidcClientID, EmailDomains: arg.EmailDomains,
+						RoleClaim: arg.RoleClaim, RoleMappings: arg.RoleMappings, DefaultRole: arg.DefaultRole,
+					}, nil
+				},
+			}
+			req := validOIDC()
+			req.EmailDomains = []string{"ACME.com"}
+			req.RoleClaim = "groups"
+			req.RoleMappings = map[string]string{"staging-admins": RoleAdmin}
+
+			sso, err := NewDefaultServiceWithQuerier(q).PutSSO(context.Background(), userID.String(), tc.orgID, req)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, orgID.String(), sso.OrganizationID)
+			assert.Equal(t, RoleMember, sso.DefaultRole)
+			assert.Equal(t, map[string]string{"staging-admins": RoleAdmin}, sso.RoleMappings)
+		})
+	}
+}
+
+func TestDefaultService_DeleteSSO(t *testing.T) {
+	userID := uuid.New()
+	orgID := uuid.New()
+
+	testCases := []struct {
+		name    string
+		deleted int64
+		wantErr error
+	}{
+		{name: "success: removed", deleted: 1},
+		{name: "fail: none configured", deleted: 0, wantErr: ErrNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				GetOrganizationMemberFunc: memberOf(RoleAdmin),
+				DeleteOrganizationSSOFunc: func(ctx context.Context, id pgtype.UUID) (int64, error) {
+					return tc.deleted, nil
+				},
+			}
+			err := NewDefaultServiceWithQuerier(q).DeleteSSO(context.Background(), userID.String(), orgID.String())
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDefaultService_Discover(t *testing.T) {
+	orgID := uuid.New()
+
+	testCases := []struct {
+		name      string
+		email     string
+		lookupErr error
+		wantErr   error
+	}{
+		{name: "success: routed domain", email: "Jo@ACME.com"},
+		{name: "fail: not an email", email: "acme.com", wantErr: ErrInvalid},
+		{name: "fail: unknown domain", email: "jo@example.com", lookupErr: pgx.ErrNoRows, wantErr: ErrNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				GetOrganizationSSOByEmailDomainFunc: func(ctx context.Context, domain string) (*queries.OrganizationSso, error) {
+					if tc.lookupErr != nil {
+						return nil, tc.lookupErr
+					}
+					assert.Equal(t, "acme.com", domain)
+					return ssoRow(orgID), nil
+				},
+			}
+			d, err := NewDefaultServiceWithQuerier(q).Discover(context.Background(), tc.email)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, &Discovery{Connection: "acme-okta", Protocol: ProtocolOIDC}, d)
+		})
+	}
+}
+
+func TestDefaultService_Provision(t *testing.T) {
+	userID := uuid.New()
+	orgID := uuid.New()
+
+	testCases := []struct {
+		name       string
+		login      Login
+		lookupErr  error
+		allowed    bool
+		manualRole string
+		scimUser   bool
+		wantRole   string
+		wantSource string
+		wantErr    error
+	}{
+		{
+			name:    "success: new member gets mapped role",
+			login:   Login{Connection: "acme-okta", Claims: map[string]any{"groups": []any{"staging-admins"}}},
+			allowed: true, wantRole: RoleAdmin, wantSource: SourceSSO,
+		},
+		{
+			name:    "success: unmatched claims get default role",
+			login:   Login{Connection: "acme-okta", Claims: map[string]any{}},
+			allowed: true, wantRole: RoleViewer, wantSource: SourceSSO,
+		},
+		{
+			name:    "success: manual member keeps role",
+			login:   Login{Connection: "acme-okta", Claims: map[string]any{"groups": "staging-admins"}},
+			allowed: true, manualRole: RoleMember, wantRole: RoleMember, wantSource: SourceManual,
+		},
+		{name: "fail: no connection claim", login: Login{}, wantErr: ErrNotFound},
+		{name: "fail: unknown connection", login: Login{Connection: "other"}, lookupErr: pgx.ErrNoRows, wantErr: ErrNotFound},
+		{name: "fail: plan without SSO", login: Login{Connection: "acme-okta"}, wantErr: ErrNotAllowed},
+		{
+			name:  "fail: deactivated by SCIM",
+			login: Login{Connection: "acme-okta"}, allowed: true, scimUser: true, wantErr: ErrDeprovisioned,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				GetOrganizationSSOByConnectionFunc: func(ctx context.Context, connection string) (*queries.OrganizationSso, error) {
+					if tc.lookupErr != nil {
+						return nil, tc.lookupErr
+					}
+					return ssoRow(orgID), nil
+				},
+				GetOrganizationSSOAccessFunc: func(ctx context.Context, id pgtype.UUID) (bool, error) {
+					assert.Equal(t, pgUUID(orgID), id)
+					return tc.allowed, nil
+				},
+				UpsertOrganizationSSOMemberFunc: func(
+					ctx context.Context, arg queries.UpsertOrganizationSSOMemberParams,
+				) (*queries.OrganizationMember, error) {
+					if tc.manualRole != "" || tc.scimUser {
+						return nil, pgx.ErrNoRows
+					}
+					return memberRow(orgID, userID, arg.Role, SourceSSO), nil
+				},
+				GetOrganizationMemberFunc: func(
+					ctx context.Context, arg queries.GetOrganizationMemberParams,
+				) (*queries.OrganizationMember, error) {
+					if tc.scimUser {
+						return nil, pgx.ErrNoRows
+					}
+					return memberRow(orgID, userID, tc.manualRole, SourceManual), nil
+				},
+			}
+			m, err := NewDefaultServiceWithQuerier(q).Provision(context.Background(), userID.String(), tc.login)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRole, m.Role)
+			assert.Equal(t, tc.wantSource, m.Source)
+			assert.Equal(t, orgID.String(), m.OrganizationID)
+		})
+	}
+}
+
+func TestDefaultService_ListMembers(t *testing.T) {
+	userID := uuid.New()
+	orgID := uuid.New()
+	q := &queries.QuerierMock{
+		GetOrganizationMemberFunc: memberOf(RoleViewer),
+		ListOrganizationMembersFunc: func(ctx context.Context, id pgtype.UUID) ([]*queries.OrganizationMember, error) {
+			return []*queries.OrganizationMember{memberRow(orgID, userID, RoleViewer, SourceSSO)}, nil
+		},
+	}
+
+	members, err := NewDefaultServiceWithQuerier(q).ListMembers(context.Background(), userID.String(), orgID.String())
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	assert.Equal(t, userID.String(), members[0].UserID)
+
+	q.GetOrganizationMemberFunc = func(ctx context.Context, arg queries.GetOrganizationMemberParams) (*queries.OrganizationMember, error) {
+		return nil, errors.New("db down")
+	}
+	_, err = NewDefaultServiceWithQuerier(q).ListMembers(context.Background(), userID.String(), orgID.String())
+	assert.ErrorContains(t, err, "db down")
+}
+
+func TestDefaultService_ClaimSCIMUser(t *testing.T) {
+	testCases := []struct {
+		name      string
+		login     Login
+		wantClaim bool
+	}{
+		{
+			name:      "success: claims by email",
+			login:     Login{Connection: "acme-okta", Claims: map[string]any{"email": "jo@acme.com"}},
+			wantClaim: true,
+		},
+		{name: "success: no email claim", login: Login{Connection: "acme-okta", Claims: map[string]any{}}},
+		{name: "success: not an SSO login", login: Login{Claims: map[string]any{"email": "jo@acme.com"}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				ClaimSCIMUserFunc: func(ctx context.Context, arg queries.ClaimSCIMUserParams) (int64, error) {
+					assert.Equal(t, queries.ClaimSCIMUserParams{
+						Auth0Sub:   "samlp|acme-okta|jo@acme.com",
+						Connection: "acme-okta",
+						Email:      "jo@acme.com",
+					}, arg)
+					return 1, nil
+				},
+			}
+			err := NewDefaultServiceWithQuerier(q).ClaimSCIMUser(context.Background(), "samlp|acme-okta|jo@acme.com", tc.login)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantClaim, len(q.ClaimSCIMUserCalls()) == 1)
+		})
+	}
+}
+
+func TestDefaultService_IssueSCIMToken(t *testing.T) {
+	userID := uuid.New()
+	orgID := uuid.New()
+
+	testCases := []struct {
+		name    string
+		role    string
+		allowed bool
+		wantErr error
+	}{
+		{name: "success: admin", role: RoleAdmin, allowed: true},
+		{name: "fail: not an admin", role: RoleMember, allowed: true, wantErr: ErrForbidden},
+		{name: "fail: plan without SSO", role: RoleAdmin, wantErr: ErrNotAllowed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var stored []byte
+			q := &queries.QuerierMock{
+				GetOrganizationMemberFunc: memberOf(tc.role),
+				GetOrganizationSSOAccessFunc: func(ctx context.Context, id pgtype.UUID) (bool, error) {
+					return tc.allowed, nil
+				},
+				UpsertOrganizationSCIMTokenFunc: func(
+					ctx context.Context, arg queries.UpsertOrganizationSCIMTokenParams,
+				) (*queries.OrganizationScimToken, error) {
+					assert.Equal(t, pgUUID(orgID), arg.OrganizationID)
+					stored = arg.TokenHash
+					return &queries.OrganizationScimToken{
+						OrganizationID: arg.OrganizationID,
+						TokenHash:      arg.TokenHash,
+						CreatedAt:      pgtype.Timestamptz{Time: time.Now(), Valid: true},
+					}, nil
+				},
+			}
+			token, err := NewDefaultServiceWithQuerier(q).IssueSCIMToken(context.Background(), userID.String(), orgID.String())
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, q.UpsertOrganizationSCIMTokenCalls())
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(token.Token, "scim_"))
+			assert.Equal(t, HashSCIMToken(token.Token), stored)
+		})
+	}
+}
+
+func TestDefaultService_RevokeSCIMToken(t *testing.T) {
+	q := &queries.QuerierMock{
+		GetOrganizationMemberFunc: memberOf(RoleAdmin),
+		DeleteOrganizationSCIMTokenFunc: func(ctx context.Context, id pgtype.UUID) (int64, error) {
+			return 0, nil
+		},
+	}
+	err := NewDefaultServiceWithQuerier(q).RevokeSCIMToken(context.Background(), uuid.NewString(), uuid.NewString())
+	assert.ErrorIs(t, err, ErrNotFound)
+}
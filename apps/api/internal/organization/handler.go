@@ -0,0 +1,20 @@
+package organization
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP-level contract for organization and SSO endpoints.
+// Implementations should be wired to Echo routes in the server.
+type Handler interface {
+	Create(c echo.Context) error
+	List(c echo.Context) error
+	ListMembers(c echo.Context) error
+	GetSSO(c echo.Context) error
+	PutSSO(c echo.Context) error
+	DeleteSSO(c echo.Context) error
+	Discover(c echo.Context) error
+	Provision(c echo.Context) error
+	IssueSCIMToken(c echo.Context) error
+	RevokeSCIMToken(c echo.Context) error
+}
@@ -0,0 +1,310 @@
+// Package organization groups users of an enterprise account and signs them in through the
+// account's identity provider. Auth0 federates the provider, over OIDC or SAML, through an
+// enterprise connection named in the organization's SSO settings; an Auth0 Action adds the
+// connection and the provider's claims to access tokens. The first time a user signs in
+// through the connection they are provisioned into the organization, with a role mapped
+// from those claims. An identity provider can also push members over SCIM with a token the
+// organization's admins issue; see package scim. SSO and SCIM are only available while the
+// owner's plan includes SSO.
+package organization
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// Member roles, from most to least privileged. Admins manage the organization's SSO
+// settings.
+const (
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+	RoleViewer = "viewer"
+)
+
+// SSO protocols an identity provider can speak to Auth0.
+const (
+	ProtocolOIDC = "oidc"
+	ProtocolSAML = "saml"
+)
+
+// Where a membership came from. SSO memberships take the mapped role at every login;
+// manual ones keep theirs. SCIM memberships are managed by the organization's SCIM client.
+const (
+	SourceManual = "manual"
+	SourceSSO    = "sso"
+	SourceSCIM   = "scim"
+)
+
+// scimTokenPrefix marks SCIM tokens, so leaked ones are easy to spot.
+const scimTokenPrefix = "scim_"
+
+// maxEmailDomains caps the email domains one organization routes to its connection.
+const maxEmailDomains = 20
+
+var (
+	// ErrNotFound is returned when the organization, its SSO settings or a connection do not
+	// exist, or the caller is not a member.
+	ErrNotFound = errors.New("organization not found")
+	// ErrForbidden is returned when the caller's role does not allow the change.
+	ErrForbidden = errors.New("organization role does not allow this")
+	// ErrNotAllowed is returned when the owner's plan does not include single sign-on.
+	ErrNotAllowed = errors.New("plan does not include single sign-on")
+	// ErrInvalid is returned for malformed organization or SSO settings.
+	ErrInvalid = errors.New("invalid organization settings")
+	// ErrConnectionTaken is returned when another organization already uses the connection.
+	ErrConnectionTaken = errors.New("connection is already used by another organization")
+	// ErrDomainTaken is returned when another organization already routes an email domain.
+	ErrDomainTaken = errors.New("email domain is already used by another organization")
+	// ErrDeprovisioned is returned when the organization's SCIM client deactivated the user.
+	ErrDeprovisioned = errors.New("user was deprovisioned by the organization's identity provider")
+)
+
+var (
+	connectionPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]{0,127}$`)
+	domainPattern     = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+)
+
+// rank orders roles by privilege, so the most privileged mapped role wins.
+var rank = map[string]int{RoleViewer: 1, RoleMember: 2, RoleAdmin: 3}
+
+// Organization is an organization as seen by one of its members.
+type Organization struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	OwnerID   string    `json:"owner_id"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Member is a user's membership of an organization.
+type Member struct {
+	OrganizationID string    `json:"organization_id"`
+	UserID         string    `json:"user_id"`
+	Role           string    `json:"role"`
+	Source         string    `json:"source"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// CreateRequest creates an organization owned by the caller.
+type CreateRequest struct {
+	Name string `json:"name"`
+}
+
+// SSO is an organization's single sign-on settings.
+type SSO struct {
+	OrganizationID string `json:"organization_id"`
+	Protocol       string `json:"protocol"`
+	// Connection is the Auth0 enterprise connection federating the identity provider.
+	Connection      string            `json:"connection"`
+	OIDCIssuer      string            `json:"oidc_issuer,omitempty"`
+	OIDCClientID    string            `json:"oidc_client_id,omitempty"`
+	SAMLMetadataURL string            `json:"saml_metadata_url,omitempty"`
+	EmailDomains    []string          `json:"email_domains"`
+	RoleClaim       string            `json:"role_claim,omitempty"`
+	RoleMappings    map[string]string `json:"role_mappings"`
+	DefaultRole     string            `json:"default_role"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+// PutSSORequest sets an organization's SSO settings, replacing any earlier ones.
+type PutSSORequest struct {
+	// Protocol is oidc or saml. OIDC needs an issuer and client ID; SAML a metadata URL.
+	Protocol        string `json:"protocol"`
+	Connection      string `json:"connection"`
+	OIDCIssuer      string `json:"oidc_issuer"`
+	OIDCClientID    string `json:"oidc_client_id"`
+	SAMLMetadataURL string `json:"saml_metadata_url"`
+	// EmailDomains route sign-ins from those domains to the connection.
+	EmailDomains []string `json:"email_domains"`
+	// RoleClaim names the provider claim, e.g. groups, whose values RoleMappings maps to
+	// roles. Users matching no mapping get DefaultRole, member unless set.
+	RoleClaim    string            `json:"role_claim"`
+	RoleMappings map[string]string `json:"role_mappings"`
+	DefaultRole  string            `json:"default_role"`
+}
+
+// Discovery tells the login page which connection to send an email address to.
+type Discovery struct {
+	Connection string `json:"connection"`
+	Protocol   string `json:"protocol"`
+}
+
+// SCIMToken is the bearer token an organization's SCIM client authenticates with. Only its
+// hash is stored, so it is shown once.
+type SCIMToken struct {
+	Token     string    `json:"token"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Login is a sign-in through an enterprise connection, as the access token describes it.
+type Login struct {
+	Connection string
+	// Claims are the identity provider's claims, without the token's claim namespace.
+	Claims map[string]any
+}
+
+func (r *CreateRequest) validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" || len(r.Name) > 200 {
+		return fmt.Errorf("%w: name must be 1 to 200 characters", ErrInvalid)
+	}
+	return nil
+}
+
+// validate checks the settings and normalizes email domains and the default role.
+func (r *PutSSORequest) validate() error {
+	if !connectionPattern.MatchString(r.Connection) {
+		return fmt.Errorf("%w: connection must be an Auth0 connection name", ErrInvalid)
+	}
+	switch r.Protocol {
+	case ProtocolOIDC:
+		if !isHTTPSURL(r.OIDCIssuer) || r.OIDCClientID == "" {
+			return fmt.Errorf("%w: oidc needs an https oidc_issuer and an oidc_client_id", ErrInvalid)
+		}
+		r.SAMLMetadataURL = ""
+	case ProtocolSAML:
+		if !isHTTPSURL(r.SAMLMetadataURL) {
+			return fmt.Errorf("%w: saml needs an https saml_metadata_url", ErrInvalid)
+		}
+		r.OIDCIssuer, r.OIDCClientID = "", ""
+	default:
+		return fmt.Errorf("%w: protocol must be oidc or saml", ErrInvalid)
+	}
+
+	if len(r.EmailDomains) > maxEmailDomains {
+		return fmt.Errorf("%w: at most %d email domains", ErrInvalid, maxEmailDomains)
+	}
+	domains := make([]string, 0, len(r.EmailDomains))
+	for _, d := range r.EmailDomains {
+		d = strings.ToLower(strings.TrimSpace(d))
+		if !domainPattern.MatchString(d) {
+			return fmt.Errorf("%w: %q is not an email domain", ErrInvalid, d)
+		}
+		if !slices.Contains(domains, d) {
+			domains = append(domains, d)
+		}
+	}
+	r.EmailDomains = domains
+
+	if len(r.RoleMappings) > 0 && r.RoleClaim == "" {
+		return fmt.Errorf("%w: role_mappings need a role_claim", ErrInvalid)
+	}
+	for value, role := range r.RoleMappings {
+		if _, ok := rank[role]; !ok {
+			return fmt.Errorf("%w: %q maps to unknown role %q", ErrInvalid, value, role)
+		}
+	}
+	if r.DefaultRole == "" {
+		r.DefaultRole = RoleMember
+	}
+	if _, ok := rank[r.DefaultRole]; !ok {
+		return fmt.Errorf("%w: default_role must be admin, member or viewer", ErrInvalid)
+	}
+	return nil
+}
+
+func isHTTPSURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && u.Scheme == "https" && u.Host != ""
+}
+
+// MapRole returns the role claims earn under the settings: the most privileged role any
+// value of the role claim maps to, or the default role. The claim may be a string or a
+// list of strings, as providers send groups either way.
+func (s *SSO) MapRole(claims map[string]any) string {
+	return s.roleFor(claimValues(claims[s.RoleClaim]))
+}
+
+func (s *SSO) roleFor(values []string) string {
+	role := ""
+	for _, value := range values {
+		if mapped, ok := s.RoleMappings[value]; ok && rank[mapped] > rank[role] {
+			role = mapped
+		}
+	}
+	if role == "" {
+		return s.DefaultRole
+	}
+	return role
+}
+
+// GroupRole returns the role members of the named SCIM groups get: the most privileged role
+// a group maps to under the organization's SSO role mappings, or the default role. Without
+// SSO settings, sso is nil and every member gets RoleMember.
+func GroupRole(sso *queries.OrganizationSso, groups []string) (string, error) {
+	if sso == nil {
+		return RoleMember, nil
+	}
+	settings, err := ssoFromRow(sso)
+	if err != nil {
+		return "", err
+	}
+	return settings.roleFor(groups), nil
+}
+
+// HashSCIMToken returns the digest a SCIM token is stored and looked up as.
+func HashSCIMToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
+
+func claimValues(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+func ssoFromRow(row *queries.OrganizationSso) (*SSO, error) {
+	mappings := map[string]string{}
+	if len(row.RoleMappings) > 0 {
+		if err := json.Unmarshal(row.RoleMappings, &mappings); err != nil {
+			return nil, fmt.Errorf("failed to decode role mappings: %w", err)
+		}
+	}
+	return &SSO{
+		OrganizationID:  row.OrganizationID.String(),
+		Protocol:        row.Protocol,
+		Connection:      row.Connection,
+		OIDCIssuer:      row.OidcIssuer.String,
+		OIDCClientID:    row.OidcClientID.String,
+		SAMLMetadataURL: row.SamlMetadataUrl.String,
+		EmailDomains:    row.EmailDomains,
+		RoleClaim:       row.RoleClaim,
+		RoleMappings:    mappings,
+		DefaultRole:     row.DefaultRole,
+		UpdatedAt:       row.UpdatedAt.Time,
+	}, nil
+}
+
+func memberFromRow(row *queries.OrganizationMember) *Member {
+	return &Member{
+		OrganizationID: row.OrganizationID.String(),
+		UserID:         row.UserID.String(),
+		Role:           row.Role,
+		Source:         row.Source,
+		CreatedAt:      row.CreatedAt.Time,
+		UpdatedAt:      row.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,123 @@
+package organization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+func validOIDC() PutSSORequest {
+	return PutSSORequest{
+		Protocol:     ProtocolOIDC,
+		Connection:   "acme-okta",
+		OIDCIssuer:   "https://acme.okta.com",
+		OIDCClientID: "client-123",
+	}
+}
+
+func TestPutSSORequest_validate(t *testing.T) {
+	testCases := []struct {
+		name      string
+		mutate    func(r *PutSSORequest)
+		errSubstr string
+	}{
+		{name: "success: oidc", mutate: func(r *PutSSORequest) {}},
+		{name: "success: saml", mutate: func(r *PutSSORequest) {
+			r.Protocol = ProtocolSAML
+			r.SAMLMetadataURL = "https://idp.acme.com/metadata.xml"
+		}},
+		{name: "fail: unknown protocol", mutate: func(r *PutSSORequest) { r.Protocol = "ldap" }, errSubstr: "protocol"},
+		{name: "fail: bad connection", mutate: func(r *PutSSORequest) { r.Connection = "acme okta" }, errSubstr: "connection"},
+		{name: "fail: http issuer", mutate: func(r *PutSSORequest) { r.OIDCIssuer = "http://acme.okta.com" }, errSubstr: "oidc_issuer"},
+		{name: "fail: saml without metadata", mutate: func(r *PutSSORequest) { r.Protocol = ProtocolSAML }, errSubstr: "saml_metadata_url"},
+		{name: "fail: bad domain", mutate: func(r *PutSSORequest) { r.EmailDomains = []string{"acme"} }, errSubstr: `"acme"`},
+		{name: "fail: mappings without claim", mutate: func(r *PutSSORequest) {
+			r.RoleMappings = map[string]string{"admins": RoleAdmin}
+		}, errSubstr: "role_claim"},
+		{name: "fail: unknown mapped role", mutate: func(r *PutSSORequest) {
+			r.RoleClaim = "groups"
+			r.RoleMappings = map[string]string{"admins": "owner"}
+		}, errSubstr: `unknown role "owner"`},
+		{name: "fail: unknown default role", mutate: func(r *PutSSORequest) { r.DefaultRole = "owner" }, errSubstr: "default_role"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := validOIDC()
+			tc.mutate(&req)
+			err := req.validate()
+			if tc.errSubstr != "" {
+				assert.ErrorIs(t, err, ErrInvalid)
+				assert.ErrorContains(t, err, tc.errSubstr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, RoleMember, req.DefaultRole)
+		})
+	}
+}
+
+func TestPutSSORequest_validate_normalizes(t *testing.T) {
+	req := validOIDC()
+	req.SAMLMetadataURL = "https://ignored.example.com"
+	req.EmailDomains = []string{" Acme.com", "acme.com", "eu.acme.com"}
+
+	require.NoError(t, req.validate())
+	assert.Equal(t, []string{"acme.com", "eu.acme.com"}, req.EmailDomains)
+	assert.Empty(t, req.SAMLMetadataURL)
+}
+
+func TestSSO_MapRole(t *testing.T) {
+	sso := &SSO{
+		RoleClaim:    "groups",
+		RoleMappings: map[string]string{"staging-admins": RoleAdmin, "contractors": RoleViewer},
+		DefaultRole:  RoleMember,
+	}
+
+	testCases := []struct {
+		name   string
+		claims map[string]any
+		want   string
+	}{
+		{name: "success: single string", claims: map[string]any{"groups": "contractors"}, want: RoleViewer},
+		{name: "success: most privileged wins", claims: map[string]any{"groups": []any{"contractors", "staging-admins"}}, want: RoleAdmin},
+		{name: "success: unmatched gets default", claims: map[string]any{"groups": []any{"sales"}}, want: RoleMember},
+		{name: "success: missing claim gets default", claims: map[string]any{}, want: RoleMember},
+		{name: "success: non-string values ignored", claims: map[string]any{"groups": []any{42, "contractors"}}, want: RoleViewer},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, sso.MapRole(tc.claims))
+		})
+	}
+}
+
+func TestGroupRole(t *testing.T) {
+	sso := &queries.OrganizationSso{
+		RoleMappings: []byte(`{"staging-admins":"admin","contractors":"viewer"}`),
+		DefaultRole:  RoleMember,
+	}
+
+	testCases := []struct {
+		name   string
+		sso    *queries.OrganizationSso
+		groups []string
+		want   string
+	}{
+		{name: "success: most privileged group wins", sso: sso, groups: []string{"contractors", "staging-admins"}, want: RoleAdmin},
+		{name: "success: unmapped groups get default", sso: sso, groups: []string{"sales"}, want: RoleMember},
+		{name: "success: no SSO settings", groups: []string{"staging-admins"}, want: RoleMember},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			role, err := GroupRole(tc.sso, tc.groups)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, role)
+		})
+	}
+}
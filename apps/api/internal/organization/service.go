@@ -0,0 +1,37 @@
+package organization
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service manages organizations and their single sign-on. Calls are scoped to the
+// calling user's memberships.
+type Service interface {
+	// Create creates an organization owned by the user, who becomes its first admin.
+	Create(ctx context.Context, userID string, req CreateRequest) (*Organization, error)
+	// List returns the organizations the user belongs to, with their role in each.
+	List(ctx context.Context, userID string) ([]*Organization, error)
+	// ListMembers returns the organization's members, or ErrNotFound when the user is not one.
+	ListMembers(ctx context.Context, userID, orgID string) ([]*Member, error)
+	// GetSSO returns the organization's SSO settings. Only admins may read them.
+	GetSSO(ctx context.Context, userID, orgID string) (*SSO, error)
+	// PutSSO saves the organization's SSO settings, replacing any earlier ones. Only admins
+	// may change them, and only while the owner's plan includes SSO.
+	PutSSO(ctx context.Context, userID, orgID string, req PutSSORequest) (*SSO, error)
+	// DeleteSSO removes the organization's SSO settings. Members keep their memberships.
+	DeleteSSO(ctx context.Context, userID, orgID string) error
+	// Discover returns the connection sign-ins from email's domain go through, or ErrNotFound.
+	Discover(ctx context.Context, email string) (*Discovery, error)
+	// Provision adds the user to the organization using the login's connection, or updates
+	// the role of an SSO-provisioned member from the login's claims.
+	Provision(ctx context.Context, userID string, login Login) (*Member, error)
+	// ClaimSCIMUser hands the account the organization's SCIM client created for the login's
+	// email to the signed-in Auth0 subject, the first time they sign in. It does nothing when
+	// there is no such account or the subject already has one.
+	ClaimSCIMUser(ctx context.Context, auth0Sub string, login Login) error
+	// IssueSCIMToken issues the organization's SCIM token, revoking any earlier one. Only
+	// admins may issue it, and only while the owner's plan includes SSO.
+	IssueSCIMToken(ctx context.Context, userID, orgID string) (*SCIMToken, error)
+	// RevokeSCIMToken revokes the organization's SCIM token. Provisioned members stay.
+	RevokeSCIMToken(ctx context.Context, userID, orgID string) error
+}
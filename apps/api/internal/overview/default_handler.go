@@ -0,0 +1,39 @@
+package overview
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DefaultHandler serves the admin overview over HTTP.
+type DefaultHandler struct {
+	service Service
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service) *DefaultHandler {
+	return &DefaultHandler{service: service}
+}
+
+// ErrorResponse is a simple JSON error envelope for handler responses.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// GetOverview handles GET /api/v1/admin/overview.
+func (h *DefaultHandler) GetOverview(c echo.Context) error {
+	o, err := h.service.GetOverview(c.Request().Context())
+	if err != nil {
+		c.Logger().Errorf("Failed to build admin overview: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to retrieve overview",
+		})
+	}
+	return c.JSON(http.StatusOK, o)
+}
@@ -0,0 +1,61 @@
+package overview
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultHandler_GetOverview(t *testing.T) {
+	testCases := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{
+			name:           "success: overview returned",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "fail: service error",
+			serviceErr:     errors.New("db down"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				GetOverviewFunc: func(ctx context.Context) (*Overview, error) {
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+					return &Overview{Jobs: map[string]int64{"queued": 2}, ActiveUsers: 5}, nil
+				},
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/overview", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := NewDefaultHandler(svc).GetOverview(c)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var body map[string]any
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Equal(t, float64(5), body["active_users"])
+				assert.NotContains(t, body, "queue_depth")
+			}
+		})
+	}
+}
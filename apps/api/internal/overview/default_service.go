@@ -0,0 +1,135 @@
+package overview
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// DefaultService implements the Service interface, caching the computed overview.
+type DefaultService struct {
+	querier queries.Querier
+	// depth reads the job queue. Nil leaves queue depth out of the overview.
+	depth queue.DepthReader
+	now   func() time.Time
+
+	mu      sync.Mutex
+	cached  *Overview
+	expires time.Time
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService with a database and an optional queue
+// depth reader.
+func NewDefaultService(db storage.Database, depth queue.DepthReader) *DefaultService {
+	return NewDefaultServiceWithQuerier(queries.New(db), depth)
+}
+
+// NewDefaultServiceWithQuerier creates a new DefaultService with a custom querier (for testing).
+func NewDefaultServiceWithQuerier(querier queries.Querier, depth queue.DepthReader) *DefaultService {
+	return &DefaultService{querier: querier, depth: depth, now: time.Now}
+}
+
+// GetOverview returns the cached overview, recomputing it once it is older than CacheTTL.
+// Concurrent callers wait for a single recomputation rather than each running the queries.
+func (s *DefaultService) GetOverview(ctx context.Context) (*Overview, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.cached != nil && now.Before(s.expires) {
+		return s.cached, nil
+	}
+
+	o, err := s.compute(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	s.cached = o
+	s.expires = now.Add(CacheTTL)
+	return o, nil
+}
+
+func (s *DefaultService) compute(ctx context.Context, now time.Time) (*Overview, error) {
+	tracer := otel.Tracer("real-staging-api/overview")
+	ctx, span := tracer.Start(ctx, "overview.compute")
+	defer span.End()
+
+	o := &Overview{Jobs: map[string]int64{}, RevenueMTD: map[string]int64{}, GeneratedAt: now.UTC()}
+
+	jobs, err := s.querier.CountJobsByStatus(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to count jobs: %w", err)
+	}
+	for _, row := range jobs {
+		o.Jobs[row.Status] = row.Total
+	}
+
+	outcomes, err := s.querier.GetJobOutcomesSince(ctx, timestamptz(now.Add(-ErrorRateWindow)))
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to count job outcomes: %w", err)
+	}
+	if finished := outcomes.Completed + outcomes.Failed; finished > 0 {
+		o.ErrorRate24h = float64(outcomes.Failed) / float64(finished)
+	}
+
+	o.ActiveUsers, err = s.querier.CountActiveUsersSince(ctx, timestamptz(now.Add(-ActiveUserWindow)))
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to count active users: %w", err)
+	}
+
+	sources, err := s.querier.GetImageSourceBreakdown(ctx, queries.GetImageSourceBreakdownParams{
+		FromTime: timestamptz(now.Add(-SourceWindow)),
+		ToTime:   timestamptz(now),
+	})
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to count images by source: %w", err)
+	}
+	o.Sources24h = make([]SourceActivity, 0, len(sources))
+	for _, row := range sources {
+		o.Sources24h = append(o.Sources24h, SourceActivity{Source: row.Source, Images: row.Total, Failed: row.Failed})
+	}
+
+	utc := now.UTC()
+	monthStart := time.Date(utc.Year(), utc.Month(), 1, 0, 0, 0, 0, time.UTC)
+	revenue, err := s.querier.SumPaidInvoicesSince(ctx, timestamptz(monthStart))
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to sum revenue: %w", err)
+	}
+	for _, row := range revenue {
+		o.RevenueMTD[row.Currency] = row.AmountPaid
+	}
+
+	// The rest of the overview is still worth showing when Redis is down
+	if s.depth != nil {
+		depth, err := s.depth.Depth(ctx)
+		if err != nil {
+			span.RecordError(err)
+		} else {
+			o.QueueDepth = depth
+		}
+	}
+	span.SetAttributes(attribute.Bool("queue_depth.available", o.QueueDepth != nil))
+
+	return o, nil
+}
+
+func timestamptz(t time.Time) pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: t, Valid: true}
+}
@@ -0,0 +1,209 @@
+package overview
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+func newQuerier() *queries.QuerierMock {
+	return &queries.QuerierMock{
+		CountJobsByStatusFunc: func(ctx context.Context) ([]*queries.CountJobsByStatusRow, error) {
+			return []*queries.CountJobsByStatusRow{
+				{Status: "completed", Total: 90},
+				{Status: "failed", Total: 6},
+				{Status: "queued", Total: 4},
+			}, nil
+		},
+		GetJobOutcomesSinceFunc: func(
+			ctx context.Context, since pgtype.Timestamptz,
+		) (*queries.GetJobOutcomesSinceRow, error) {
+			return &queries.GetJobOutcomesSinceRow{Completed: 15, Failed: 5}, nil
+		},
+		CountActiveUsersSinceFunc: func(ctx context.Context, since pgtype.Timestamptz) (int64, error) {
+			return 12, nil
+		},
+		SumPaidInvoicesSinceFunc: func(
+			ctx context.Context, since pgtype.Timestamptz,
+		) ([]*queries.SumPaidInvoicesSinceRow, error) {
+			return []*queries.SumPaidInvoicesSinceRow{{Currency: "usd", AmountPaid: 4900}}, nil
+		},
+		GetImageSourceBreakdownFunc: func(
+			ctx context.Context, arg queries.GetImageSourceBreakdownParams,
+		) ([]*queries.GetImageSourceBreakdownRow, error) {
+			return []*queries.GetImageSourceBreakdownRow{
+				{Source: "web", Total: 40, Ready: 35, Failed: 2},
+				{Source: "mobile", Total: 8, Ready: 5, Failed: 3},
+			}, nil
+		},
+	}
+}
+
+func TestDefaultService_GetOverview(t *testing.T) {
+	now := time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)
+
+	testCases := []struct {
+		name      string
+		setup     func(q *queries.QuerierMock)
+		depth     queue.DepthReader
+		wantErr   string
+		wantDepth *queue.Depth
+		wantRate  float64
+	}{
+		{
+			name: "success: all aggregates",
+			depth: &queue.DepthReaderMock{
+				DepthFunc: func(ctx context.Context) (*queue.Depth, error) {
+					return &queue.Depth{Pending: 3, Active: 1}, nil
+				},
+			},
+			wantDepth: &queue.Depth{Pending: 3, Active: 1},
+			wantRate:  0.25,
+		},
+		{
+			name:     "success: no queue configured",
+			wantRate: 0.25,
+		},
+		{
+			name: "success: unreachable queue is left out",
+			depth: &queue.DepthReaderMock{
+				DepthFunc: func(ctx context.Context) (*queue.Depth, error) {
+					return nil, errors.New("redis down")
+				},
+			},
+			wantRate: 0.25,
+		},
+		{
+			name: "success: no finished jobs means no errors",
+			setup: func(q *queries.QuerierMock) {
+				q.GetJobOutcomesSinceFunc = func(
+					ctx context.Context, since pgtype.Timestamptz,
+				) (*queries.GetJobOutcomesSinceRow, error) {
+					return &queries.GetJobOutcomesSinceRow{}, nil
+				}
+			},
+		},
+		{
+			name: "fail: job counts",
+			setup: func(q *queries.QuerierMock) {
+				q.CountJobsByStatusFunc = func(ctx context.Context) ([]*queries.CountJobsByStatusRow, error) {
+					return nil, errors.New("db down")
+				}
+			},
+			wantErr: "failed to count jobs",
+		},
+		{
+			name: "fail: sources",
+			setup: func(q *queries.QuerierMock) {
+				q.GetImageSourceBreakdownFunc = func(
+					ctx context.Context, arg queries.GetImageSourceBreakdownParams,
+				) ([]*queries.GetImageSourceBreakdownRow, error) {
+					return nil, errors.New("db down")
+				}
+			},
+			wantErr: "failed to count images by source",
+		},
+		{
+			name: "fail: revenue",
+			setup: func(q *queries.QuerierMock) {
+				q.SumPaidInvoicesSinceFunc = func(
+					ctx context.Context, since pgtype.Timestamptz,
+				) ([]*queries.SumPaidInvoicesSinceRow, error) {
+					return nil, errors.New("db down")
+				}
+			},
+			wantErr: "failed to sum revenue",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := newQuerier()
+			if tc.setup != nil {
+				tc.setup(q)
+			}
+			s := NewDefaultServiceWithQuerier(q, tc.depth)
+			s.now = func() time.Time { return now }
+
+			o, err := s.GetOverview(context.Background())
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, map[string]int64{"completed": 90, "failed": 6, "queued": 4}, o.Jobs)
+			assert.InDelta(t, tc.wantRate, o.ErrorRate24h, 1e-9)
+			assert.Equal(t, int64(12), o.ActiveUsers)
+			assert.Equal(t, map[string]int64{"usd": 4900}, o.RevenueMTD)
+			assert.Equal(t, []SourceActivity{
+				{Source: "web", Images: 40, Failed: 2},
+				{Source: "mobile", Images: 8, Failed: 3},
+			}, o.Sources24h)
+			assert.Equal(t, tc.wantDepth, o.QueueDepth)
+			assert.Equal(t, now, o.GeneratedAt)
+
+			require.Len(t, q.GetJobOutcomesSinceCalls(), 1)
+			assert.Equal(t, now.Add(-ErrorRateWindow), q.GetJobOutcomesSinceCalls()[0].Since.Time)
+			require.Len(t, q.CountActiveUsersSinceCalls(), 1)
+			assert.Equal(t, now.Add(-ActiveUserWindow), q.CountActiveUsersSinceCalls()[0].Since.Time)
+			require.Len(t, q.GetImageSourceBreakdownCalls(), 1)
+			sourceArg := q.GetImageSourceBreakdownCalls()[0].Arg
+			assert.Equal(t, now.Add(-SourceWindow), sourceArg.FromTime.Time)
+			assert.False(t, sourceArg.UserID.Valid)
+			require.Len(t, q.SumPaidInvoicesSinceCalls(), 1)
+			assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), q.SumPaidInvoicesSinceCalls()[0].Since.Time)
+		})
+	}
+}
+
+func TestDefaultService_GetOverview_Cache(t *testing.T) {
+	now := time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)
+	q := newQuerier()
+	s := NewDefaultServiceWithQuerier(q, nil)
+	s.now = func() time.Time { return now }
+
+	first, err := s.GetOverview(context.Background())
+	require.NoError(t, err)
+
+	now = now.Add(CacheTTL - time.Second)
+	cached, err := s.GetOverview(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, first, cached)
+	assert.Len(t, q.CountJobsByStatusCalls(), 1)
+
+	now = now.Add(time.Second)
+	fresh, err := s.GetOverview(context.Background())
+	require.NoError(t, err)
+	assert.NotSame(t, first, fresh)
+	assert.Len(t, q.CountJobsByStatusCalls(), 2)
+}
+
+func TestDefaultService_GetOverview_ErrorsAreNotCached(t *testing.T) {
+	q := newQuerier()
+	fail := true
+	q.CountActiveUsersSinceFunc = func(ctx context.Context, since pgtype.Timestamptz) (int64, error) {
+		if fail {
+			return 0, errors.New("db down")
+		}
+		return 12, nil
+	}
+	s := NewDefaultServiceWithQuerier(q, nil)
+
+	_, err := s.GetOverview(context.Background())
+	require.Error(t, err)
+
+	fail = false
+	o, err := s.GetOverview(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), o.ActiveUsers)
+}
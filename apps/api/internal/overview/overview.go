@@ -0,0 +1,50 @@
+// Package overview aggregates system-wide health and business metrics for the
+// internal ops dashboard.
+package overview
+
+import (
+	"time"
+
+	"github.com/real-staging-ai/api/internal/queue"
+)
+
+const (
+	// CacheTTL is how long a computed overview is served before the queries run again.
+	CacheTTL = 30 * time.Second
+	// ErrorRateWindow is how far back jobs count towards the error rate.
+	ErrorRateWindow = 24 * time.Hour
+	// ActiveUserWindow is how recently a user must have created an image to count as active.
+	ActiveUserWindow = 30 * 24 * time.Hour
+	// SourceWindow is how far back images count towards the per-source breakdown.
+	SourceWindow = 24 * time.Hour
+)
+
+// Overview is a snapshot of system-wide aggregates.
+type Overview struct {
+	// Jobs counts every job by status.
+	Jobs map[string]int64 `json:"jobs"`
+	// ErrorRate24h is the share of jobs created in the last 24 hours that failed, out of
+	// those that finished. It is 0 when none finished.
+	ErrorRate24h float64 `json:"error_rate_24h"`
+	// ActiveUsers counts the users who created an image within ActiveUserWindow.
+	ActiveUsers int64 `json:"active_users"`
+	// Sources24h breaks down the images created within SourceWindow by the surface they
+	// came from, busiest first.
+	Sources24h []SourceActivity `json:"sources_24h"`
+	// RevenueMTD totals paid invoices since the start of the UTC month, in cents per
+	// currency.
+	RevenueMTD map[string]int64 `json:"revenue_mtd"`
+	// QueueDepth counts the tasks in the job queue. It is omitted when the queue is not
+	// configured or cannot be reached.
+	QueueDepth *queue.Depth `json:"queue_depth,omitempty"`
+	// GeneratedAt is when the aggregates were computed; responses are cached for CacheTTL.
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// SourceActivity is the volume and failures of images created from one surface: web,
+// mobile, api or import.
+type SourceActivity struct {
+	Source string `json:"source"`
+	Images int64  `json:"images"`
+	Failed int64  `json:"failed"`
+}
@@ -0,0 +1,12 @@
+package overview
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service defines the interface for the admin overview.
+type Service interface {
+	// GetOverview returns the system-wide aggregates, computing them at most once per
+	// CacheTTL.
+	GetOverview(ctx context.Context) (*Overview, error)
+}
@@ -0,0 +1,134 @@
+package preset
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// DefaultHandler serves the current user's presets over HTTP.
+type DefaultHandler struct {
+	service  Service
+	userRepo user.Repository
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, userRepo user.Repository) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo}
+}
+
+// ErrorResponse is a simple JSON error envelope for handler responses.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// List handles GET /api/v1/me/presets.
+func (h *DefaultHandler) List(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	presets, err := h.service.List(c.Request().Context(), userID)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, ListResponse{Presets: presets})
+}
+
+// Create handles POST /api/v1/me/presets.
+func (h *DefaultHandler) Create(c echo.Context) error {
+	var req UpsertRequest
+	if err := c.Bind(&req); err != nil {
+		return badRequest(c)
+	}
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	created, err := h.service.Create(c.Request().Context(), userID, req)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusCreated, created)
+}
+
+// Update handles PUT /api/v1/me/presets/:id.
+func (h *DefaultHandler) Update(c echo.Context) error {
+	var req UpsertRequest
+	if err := c.Bind(&req); err != nil {
+		return badRequest(c)
+	}
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	updated, err := h.service.Update(c.Request().Context(), userID, c.Param("id"), req)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, updated)
+}
+
+// Delete handles DELETE /api/v1/me/presets/:id.
+func (h *DefaultHandler) Delete(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	if err := h.service.Delete(c.Request().Context(), userID, c.Param("id")); err != nil {
+		return h.writeError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func badRequest(c echo.Context) error {
+	return c.JSON(http.StatusBadRequest, ErrorResponse{
+		Error:   "bad_request",
+		Message: "Invalid request format",
+	})
+}
+
+func unauthorized(c echo.Context) error {
+	return c.JSON(http.StatusUnauthorized, ErrorResponse{
+		Error:   "unauthorized",
+		Message: "Unable to resolve current user",
+	})
+}
+
+// writeError maps service errors to responses.
+func (h *DefaultHandler) writeError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, ErrInvalid):
+		return c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Error:   "validation_failed",
+			Message: err.Error(),
+		})
+	case errors.Is(err, ErrNotFound):
+		return c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "not_found",
+			Message: "Preset not found",
+		})
+	case errors.Is(err, ErrNameTaken):
+		return c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "conflict",
+			Message: "A preset with this name already exists",
+		})
+	default:
+		c.Logger().Errorf("Preset request failed: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to process preset request",
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package preset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func userRepoFor(userID uuid.UUID, lookupErr error) *user.RepositoryMock {
+	return &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			if lookupErr != nil {
+				return nil, lookupErr
+			}
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+		},
+		CreateFunc: func(ctx context.Context, auth0Sub, stripeCustomerID, role string) (*queries.CreateUserRow, error) {
+			return &queries.CreateUserRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+		},
+	}
+}
+
+func TestDefaultHandler_List(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		lookupErr      error
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: list", expectedStatus: http.StatusOK},
+		{name: "success: user is created on first access", lookupErr: pgx.ErrNoRows, expectedStatus: http.StatusOK},
+		{name: "fail: user lookup error", lookupErr: errors.New("db down"), expectedStatus: http.StatusUnauthorized},
+		{name: "fail: service error", err: errors.New("db down"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				ListFunc: func(ctx context.Context, uid string) ([]Preset, error) {
+					assert.Equal(t, userID.String(), uid)
+					return []Preset{{ID: "p1", Name: "Listing default"}}, tc.err
+				},
+			}
+			h := NewDefaultHandler(svc, userRepoFor(userID, tc.lookupErr))
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/me/presets", nil)
+			req.Header.Set("X-Test-User", "auth0|user")
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, h.List(e.NewContext(req, rec)))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Contains(t, rec.Body.String(), `"name":"Listing default"`)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_CreateUpdateDelete(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		method         string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: create", method: http.MethodPost, body: `{"name":"Listing default","style":"modern"}`,
+			expectedStatus: http.StatusCreated},
+		{name: "success: update", method: http.MethodPut, body: `{"name":"Listing default"}`,
+			expectedStatus: http.StatusOK},
+		{name: "success: delete", method: http.MethodDelete, expectedStatus: http.StatusNoContent},
+		{name: "fail: malformed body", method: http.MethodPost, body: `{`, expectedStatus: http.StatusBadRequest},
+		{name: "fail: invalid preset", method: http.MethodPost, body: `{}`,
+			err: fmt.Errorf("%w: name is required", ErrInvalid), expectedStatus: http.StatusUnprocessableEntity},
+		{name: "fail: name taken", method: http.MethodPut, body: `{"name":"Listing default"}`,
+			err: ErrNameTaken, expectedStatus: http.StatusConflict},
+		{name: "fail: another user's preset", method: http.MethodDelete, err: ErrNotFound,
+			expectedStatus: http.StatusNotFound},
+		{name: "fail: service error", method: http.MethodPost, body: `{"name":"Listing default"}`,
+			err: errors.New("db down"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := func(req UpsertRequest) (*Preset, error) {
+				if tc.err != nil {
+					return nil, tc.err
+				}
+				return &Preset{ID: "p1", Name: req.Name, Style: req.Style}, nil
+			}
+			svc := &ServiceMock{
+				CreateFunc: func(ctx context.Context, uid string, req UpsertRequest) (*Preset, error) {
+					assert.Equal(t, userID.String(), uid)
+					return result(req)
+				},
+				UpdateFunc: func(ctx context.Context, uid, id string, req UpsertRequest) (*Preset, error) {
+					assert.Equal(t, userID.String(), uid)
+					assert.Equal(t, "p1", id)
+					return result(req)
+				},
+				DeleteFunc: func(ctx context.Context, uid, id string) error {
+					assert.Equal(t, userID.String(), uid)
+					assert.Equal(t, "p1", id)
+					return tc.err
+				},
+			}
+			h := NewDefaultHandler(svc, userRepoFor(userID, nil))
+
+			e := echo.New()
+			req := httptest.NewRequest(tc.method, "/", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("X-Test-User", "auth0|user")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("p1")
+
+			var err error
+			switch tc.method {
+			case http.MethodPost:
+				err = h.Create(c)
+			case http.MethodPut:
+				err = h.Update(c)
+			case http.MethodDelete:
+				err = h.Delete(c)
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
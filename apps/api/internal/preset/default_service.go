@@ -0,0 +1,251 @@
+package preset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// Postgres error codes raised when a preset name is already taken or its catalog is missing.
+const (
+	uniqueViolation     = "23505"
+	foreignKeyViolation = "23503"
+)
+
+// DefaultService implements Service.
+type DefaultService struct {
+	querier queries.Querier
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService with a database.
+func NewDefaultService(db storage.Database) *DefaultService {
+	return &DefaultService{querier: queries.New(db)}
+}
+
+// NewDefaultServiceWithQuerier creates a new DefaultService with a custom querier (for testing).
+func NewDefaultServiceWithQuerier(querier queries.Querier) *DefaultService {
+	return &DefaultService{querier: querier}
+}
+
+// List returns the user's presets ordered by name.
+func (s *DefaultService) List(ctx context.Context, userID string) ([]Preset, error) {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	rows, err := s.querier.ListPresetsByUser(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list presets: %w", err)
+	}
+
+	presets := make([]Preset, 0, len(rows))
+	for _, row := range rows {
+		presets = append(presets, toPreset(row))
+	}
+	return presets, nil
+}
+
+// Create adds a preset for the user.
+func (s *DefaultService) Create(ctx context.Context, userID string, req UpsertRequest) (*Preset, error) {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	req, catalogID, err := normalize(req)
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.querier.CreatePreset(ctx, queries.CreatePresetParams{
+		UserID:    uid,
+		Name:      req.Name,
+		RoomType:  toText(req.RoomType),
+		Style:     toText(req.Style),
+		Seed:      toInt8(req.Seed),
+		CatalogID: catalogID,
+		Prompt:    req.Prompt,
+	})
+	if err != nil {
+		return nil, writeError("create", err)
+	}
+	p := toPreset(row)
+	return &p, nil
+}
+
+// Update replaces one of the user's presets.
+func (s *DefaultService) Update(ctx context.Context, userID, id string, req UpsertRequest) (*Preset, error) {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	presetID, err := parseUUID(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	req, catalogID, err := normalize(req)
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.querier.UpdatePreset(ctx, queries.UpdatePresetParams{
+		ID:        presetID,
+		UserID:    uid,
+		Name:      req.Name,
+		RoomType:  toText(req.RoomType),
+		Style:     toText(req.Style),
+		Seed:      toInt8(req.Seed),
+		CatalogID: catalogID,
+		Prompt:    req.Prompt,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, writeError("update", err)
+	}
+	p := toPreset(row)
+	return &p, nil
+}
+
+// Delete removes one of the user's presets.
+func (s *DefaultService) Delete(ctx context.Context, userID, id string) error {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+	presetID, err := parseUUID(id)
+	if err != nil {
+		return ErrNotFound
+	}
+	n, err := s.querier.DeletePreset(ctx, queries.DeletePresetParams{ID: presetID, UserID: uid})
+	if err != nil {
+		return fmt.Errorf("failed to delete preset: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// GetForProject returns a preset owned by the project's owner, or ErrNotFound.
+func (s *DefaultService) GetForProject(ctx context.Context, id, projectID string) (*Preset, error) {
+	presetID, err := parseUUID(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	pid, err := parseUUID(projectID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	row, err := s.querier.GetPresetForProject(ctx, queries.GetPresetForProjectParams{ID: presetID, ProjectID: pid})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get preset: %w", err)
+	}
+	p := toPreset(row)
+	return &p, nil
+}
+
+// normalize trims and validates req, returning the parsed catalog ID.
+func normalize(req UpsertRequest) (UpsertRequest, pgtype.UUID, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	req.Prompt = strings.TrimSpace(req.Prompt)
+
+	switch {
+	case req.Name == "":
+		return req, pgtype.UUID{}, fmt.Errorf("%w: name is required", ErrInvalid)
+	case len(req.Name) > MaxNameLength:
+		return req, pgtype.UUID{}, fmt.Errorf("%w: name must be at most %d characters", ErrInvalid, MaxNameLength)
+	case len(req.Prompt) > MaxPromptLength:
+		return req, pgtype.UUID{}, fmt.Errorf("%w: prompt must be at most %d characters", ErrInvalid, MaxPromptLength)
+	case req.RoomType != nil && !slices.Contains(RoomTypes, *req.RoomType):
+		return req, pgtype.UUID{}, fmt.Errorf("%w: room_type must be one of: %s",
+			ErrInvalid, strings.Join(RoomTypes, ", "))
+	case req.Style != nil && !slices.Contains(Styles, *req.Style):
+		return req, pgtype.UUID{}, fmt.Errorf("%w: style must be one of: %s", ErrInvalid, strings.Join(Styles, ", "))
+	case req.Seed != nil && (*req.Seed < 1 || *req.Seed > MaxSeed):
+		return req, pgtype.UUID{}, fmt.Errorf("%w: seed must be between 1 and %d", ErrInvalid, int64(MaxSeed))
+	}
+
+	if req.CatalogID == nil {
+		return req, pgtype.UUID{}, nil
+	}
+	catalogID, err := parseUUID(*req.CatalogID)
+	if err != nil {
+		return req, pgtype.UUID{}, fmt.Errorf("%w: catalog_id must be a UUID", ErrInvalid)
+	}
+	return req, catalogID, nil
+}
+
+// writeError maps a taken name to ErrNameTaken and an unknown catalog to ErrInvalid.
+func writeError(op string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case uniqueViolation:
+			return ErrNameTaken
+		case foreignKeyViolation:
+			return fmt.Errorf("%w: catalog_id does not exist", ErrInvalid)
+		}
+	}
+	return fmt.Errorf("failed to %s preset: %w", op, err)
+}
+
+func parseUUID(id string) (pgtype.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+	return pgtype.UUID{Bytes: parsed, Valid: true}, nil
+}
+
+func toText(s *string) pgtype.Text {
+	if s == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: *s, Valid: true}
+}
+
+func toInt8(n *int64) pgtype.Int8 {
+	if n == nil {
+		return pgtype.Int8{}
+	}
+	return pgtype.Int8{Int64: *n, Valid: true}
+}
+
+func toPreset(row *queries.Preset) Preset {
+	p := Preset{
+		ID:        uuid.UUID(row.ID.Bytes).String(),
+		Name:      row.Name,
+		Prompt:    row.Prompt,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+	if row.RoomType.Valid {
+		p.RoomType = &row.RoomType.String
+	}
+	if row.Style.Valid {
+		p.Style = &row.Style.String
+	}
+	if row.Seed.Valid {
+		p.Seed = &row.Seed.Int64
+	}
+	if row.CatalogID.Valid {
+		id := uuid.UUID(row.CatalogID.Bytes).String()
+		p.CatalogID = &id
+	}
+	return p
+}
@@ -0,0 +1,274 @@
+package preset
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+func presetRow(id, userID uuid.UUID) *queries.Preset {
+	return &queries.Preset{
+		ID:       pgtype.UUID{Bytes: id, Valid: true},
+		UserID:   pgtype.UUID{Bytes: userID, Valid: true},
+		Name:     "Listing default",
+		RoomType: pgtype.Text{String: "bedroom", Valid: true},
+		Seed:     pgtype.Int8{Int64: 42, Valid: true},
+		Prompt:   "keep the fireplace visible",
+	}
+}
+
+func TestDefaultService_List(t *testing.T) {
+	id, userID := uuid.New(), uuid.New()
+
+	t.Run("success: lists the user's presets", func(t *testing.T) {
+		q := &queries.QuerierMock{
+			ListPresetsByUserFunc: func(ctx context.Context, uid pgtype.UUID) ([]*queries.Preset, error) {
+				assert.Equal(t, userID, uuid.UUID(uid.Bytes))
+				return []*queries.Preset{presetRow(id, userID)}, nil
+			},
+		}
+		presets, err := NewDefaultServiceWithQuerier(q).List(context.Background(), userID.String())
+		require.NoError(t, err)
+		require.Len(t, presets, 1)
+		assert.Equal(t, id.String(), presets[0].ID)
+		assert.Equal(t, "bedroom", *presets[0].RoomType)
+		assert.Nil(t, presets[0].Style)
+		assert.Equal(t, int64(42), *presets[0].Seed)
+		assert.Nil(t, presets[0].CatalogID)
+	})
+
+	t.Run("fail: query error", func(t *testing.T) {
+		q := &queries.QuerierMock{
+			ListPresetsByUserFunc: func(ctx context.Context, uid pgtype.UUID) ([]*queries.Preset, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		_, err := NewDefaultServiceWithQuerier(q).List(context.Background(), userID.String())
+		assert.ErrorContains(t, err, "db down")
+	})
+}
+
+func TestDefaultService_Create(t *testing.T) {
+	userID := uuid.New()
+	catalogID := uuid.New().String()
+	kitchen, gothic := "kitchen", "gothic"
+	seed, badSeed := int64(7), int64(0)
+	badCatalog := "nope"
+
+	testCases := []struct {
+		name      string
+		req       UpsertRequest
+		createErr error
+		wantErr   error
+		errSubstr string
+	}{
+		{
+			name: "success: all options",
+			req: UpsertRequest{
+				Name: " Listing default ", RoomType: &kitchen, Seed: &seed, CatalogID: &catalogID,
+				Prompt: " keep the fireplace visible ",
+			},
+		},
+		{
+			name:      "fail: missing name",
+			req:       UpsertRequest{},
+			wantErr:   ErrInvalid,
+			errSubstr: "name is required",
+		},
+		{
+			name:      "fail: prompt too long",
+			req:       UpsertRequest{Name: "Listing default", Prompt: strings.Repeat("a", MaxPromptLength+1)},
+			wantErr:   ErrInvalid,
+			errSubstr: "prompt must be at most",
+		},
+		{
+			name:      "fail: unknown style",
+			req:       UpsertRequest{Name: "Listing default", Style: &gothic},
+			wantErr:   ErrInvalid,
+			errSubstr: "style must be one of",
+		},
+		{
+			name:      "fail: seed out of range",
+			req:       UpsertRequest{Name: "Listing default", Seed: &badSeed},
+			wantErr:   ErrInvalid,
+			errSubstr: "seed must be between",
+		},
+		{
+			name:      "fail: malformed catalog id",
+			req:       UpsertRequest{Name: "Listing default", CatalogID: &badCatalog},
+			wantErr:   ErrInvalid,
+			errSubstr: "catalog_id must be a UUID",
+		},
+		{
+			name:      "fail: unknown catalog",
+			req:       UpsertRequest{Name: "Listing default", CatalogID: &catalogID},
+			createErr: &pgconn.PgError{Code: foreignKeyViolation},
+			wantErr:   ErrInvalid,
+			errSubstr: "catalog_id does not exist",
+		},
+		{
+			name:      "fail: name taken",
+			req:       UpsertRequest{Name: "Listing default"},
+			createErr: &pgconn.PgError{Code: uniqueViolation},
+			wantErr:   ErrNameTaken,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				CreatePresetFunc: func(ctx context.Context, arg queries.CreatePresetParams) (*queries.Preset, error) {
+					if tc.createErr != nil {
+						return nil, tc.createErr
+					}
+					assert.Equal(t, userID, uuid.UUID(arg.UserID.Bytes))
+					assert.Equal(t, "Listing default", arg.Name)
+					assert.Equal(t, "keep the fireplace visible", arg.Prompt)
+					assert.Equal(t, catalogID, uuid.UUID(arg.CatalogID.Bytes).String())
+					return &queries.Preset{
+						ID:        pgtype.UUID{Bytes: uuid.New(), Valid: true},
+						Name:      arg.Name,
+						RoomType:  arg.RoomType,
+						Seed:      arg.Seed,
+						CatalogID: arg.CatalogID,
+						Prompt:    arg.Prompt,
+					}, nil
+				},
+			}
+
+			got, err := NewDefaultServiceWithQuerier(q).Create(context.Background(), userID.String(), tc.req)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				if tc.errSubstr != "" {
+					assert.ErrorContains(t, err, tc.errSubstr)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, kitchen, *got.RoomType)
+			assert.Equal(t, catalogID, *got.CatalogID)
+		})
+	}
+}
+
+func TestDefaultService_Update(t *testing.T) {
+	id, userID := uuid.New(), uuid.New()
+	req := UpsertRequest{Name: "Listing default"}
+
+	t.Run("success: updates preset", func(t *testing.T) {
+		q := &queries.QuerierMock{
+			UpdatePresetFunc: func(ctx context.Context, arg queries.UpdatePresetParams) (*queries.Preset, error) {
+				assert.Equal(t, id, uuid.UUID(arg.ID.Bytes))
+				assert.Equal(t, userID, uuid.UUID(arg.UserID.Bytes))
+				assert.False(t, arg.CatalogID.Valid)
+				return presetRow(id, userID), nil
+			},
+		}
+		got, err := NewDefaultServiceWithQuerier(q).Update(context.Background(), userID.String(), id.String(), req)
+		require.NoError(t, err)
+		assert.Equal(t, "Listing default", got.Name)
+	})
+
+	t.Run("fail: missing or another user's preset", func(t *testing.T) {
+		q := &queries.QuerierMock{
+			UpdatePresetFunc: func(ctx context.Context, arg queries.UpdatePresetParams) (*queries.Preset, error) {
+				return nil, pgx.ErrNoRows
+			},
+		}
+		_, err := NewDefaultServiceWithQuerier(q).Update(context.Background(), userID.String(), id.String(), req)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("fail: invalid request skips the query", func(t *testing.T) {
+		q := &queries.QuerierMock{}
+		_, err := NewDefaultServiceWithQuerier(q).Update(
+			context.Background(), userID.String(), id.String(), UpsertRequest{},
+		)
+		assert.ErrorIs(t, err, ErrInvalid)
+	})
+}
+
+func TestDefaultService_Delete(t *testing.T) {
+	id, userID := uuid.New(), uuid.New()
+
+	testCases := []struct {
+		name    string
+		id      string
+		rows    int64
+		err     error
+		wantErr error
+	}{
+		{name: "success: deleted", id: id.String(), rows: 1},
+		{name: "fail: missing or another user's preset", id: id.String(), wantErr: ErrNotFound},
+		{name: "fail: invalid id", id: "nope", wantErr: ErrNotFound},
+		{name: "fail: query error", id: id.String(), err: errors.New("db down")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				DeletePresetFunc: func(ctx context.Context, arg queries.DeletePresetParams) (int64, error) {
+					assert.Equal(t, userID, uuid.UUID(arg.UserID.Bytes))
+					return tc.rows, tc.err
+				},
+			}
+			err := NewDefaultServiceWithQuerier(q).Delete(context.Background(), userID.String(), tc.id)
+			switch {
+			case tc.wantErr != nil:
+				assert.ErrorIs(t, err, tc.wantErr)
+			case tc.err != nil:
+				assert.ErrorContains(t, err, "db down")
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDefaultService_GetForProject(t *testing.T) {
+	id, userID, projectID := uuid.New(), uuid.New(), uuid.New()
+
+	testCases := []struct {
+		name    string
+		id      string
+		err     error
+		wantErr error
+	}{
+		{name: "success: owner's preset", id: id.String()},
+		{name: "fail: not the project owner's", id: id.String(), err: pgx.ErrNoRows, wantErr: ErrNotFound},
+		{name: "fail: invalid id", id: "nope", wantErr: ErrNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				GetPresetForProjectFunc: func(
+					ctx context.Context, arg queries.GetPresetForProjectParams,
+				) (*queries.Preset, error) {
+					assert.Equal(t, projectID, uuid.UUID(arg.ProjectID.Bytes))
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return presetRow(id, userID), nil
+				},
+			}
+			got, err := NewDefaultServiceWithQuerier(q).GetForProject(context.Background(), tc.id, projectID.String())
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "keep the fireplace visible", got.Prompt)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Package preset manages staging presets: named room type, style, seed, catalog and prompt
+// combinations a user saves once and selects by preset_id when creating images.
+package preset
+
+import (
+	"errors"
+	"time"
+)
+
+// Limits on a preset's contents.
+const (
+	MaxNameLength   = 100
+	MaxPromptLength = 500
+	MaxSeed         = 4294967295
+)
+
+// RoomTypes and Styles are the values a preset may set, matching image creation.
+var (
+	RoomTypes = []string{"living_room", "bedroom", "kitchen", "bathroom", "dining_room", "office", "entryway", "outdoor"}
+	Styles    = []string{"modern", "contemporary", "traditional", "industrial", "scandinavian"}
+)
+
+var (
+	// ErrNotFound is returned when a preset does not exist or belongs to another user.
+	ErrNotFound = errors.New("preset not found")
+	// ErrNameTaken is returned when the user already has a preset with the name.
+	ErrNameTaken = errors.New("preset name already in use")
+	// ErrInvalid wraps validation failures of a create or update request.
+	ErrInvalid = errors.New("invalid preset")
+)
+
+// Preset is a user's saved set of staging options.
+type Preset struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	RoomType  *string `json:"room_type,omitempty"`
+	Style     *string `json:"style,omitempty"`
+	Seed      *int64  `json:"seed,omitempty"`
+	CatalogID *string `json:"catalog_id,omitempty"`
+	// Prompt holds extra instructions appended to the staging prompt.
+	Prompt    string    `json:"prompt"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// UpsertRequest creates or replaces a preset. Unset options are left to each image.
+type UpsertRequest struct {
+	Name      string  `json:"name"`
+	RoomType  *string `json:"room_type,omitempty"`
+	Style     *string `json:"style,omitempty"`
+	Seed      *int64  `json:"seed,omitempty"`
+	CatalogID *string `json:"catalog_id,omitempty"`
+	Prompt    string  `json:"prompt"`
+}
+
+// ListResponse lists presets.
+type ListResponse struct {
+	Presets []Preset `json:"presets"`
+}
@@ -0,0 +1,19 @@
+package preset
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service manages a user's presets. Every call is scoped to the owning user.
+type Service interface {
+	// List returns the user's presets ordered by name.
+	List(ctx context.Context, userID string) ([]Preset, error)
+	// Create adds a preset for the user.
+	Create(ctx context.Context, userID string, req UpsertRequest) (*Preset, error)
+	// Update replaces one of the user's presets.
+	Update(ctx context.Context, userID, id string, req UpsertRequest) (*Preset, error)
+	// Delete removes one of the user's presets. Images already created are unaffected.
+	Delete(ctx context.Context, userID, id string) error
+	// GetForProject returns a preset owned by the project's owner, or ErrNotFound.
+	GetForProject(ctx context.Context, id, projectID string) (*Preset, error)
+}
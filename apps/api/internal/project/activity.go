@@ -0,0 +1,39 @@
+package project
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Activity event types recorded in a project's timeline. Image events are
+// written by a database trigger, so transitions made by the worker are
+// captured as well.
+const (
+	ActivityImageCreated       = "image.created"
+	ActivityImageStatusChanged = "image.status_changed"
+	ActivityImageDeleted       = "image.deleted"
+)
+
+// Pagination bounds for the activity timeline.
+const (
+	DefaultActivityLimit int32 = 50
+	MaxActivityLimit     int32 = 200
+)
+
+// ActivityEvent is a single entry in a project's activity timeline.
+type ActivityEvent struct {
+	ID        int64           `json:"id"`
+	ProjectID string          `json:"project_id"`
+	ImageID   *string         `json:"image_id,omitempty"`
+	Type      string          `json:"type"`
+	Metadata  json.RawMessage `json:"metadata"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// ActivityListResponse is the paginated response envelope for the activity timeline.
+type ActivityListResponse struct {
+	Events  []ActivityEvent `json:"events"`
+	Limit   int32           `json:"limit"`
+	Offset  int32           `json:"offset"`
+	HasMore bool            `json:"has_more"`
+}
@@ -4,14 +4,15 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/labstack/echo/v4"
 
-	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/geocode"
 	"github.com/real-staging-ai/api/internal/storage"
 	"github.com/real-staging-ai/api/internal/user"
 )
@@ -20,11 +21,17 @@ import (
 // It lives in the project package to follow the “handlers in their own package” pattern.
 type DefaultHandler struct {
 	db storage.Database
+	// buckets signs thumbnail URLs in project summaries. Nil leaves them out.
+	buckets storage.Buckets
+	// geocoder locates listing addresses. Nil saves listings without a location.
+	geocoder geocode.Geocoder
 }
 
-// NewDefaultHandler constructs a project HTTP handler backed by the provided DB.
-func NewDefaultHandler(db storage.Database) *DefaultHandler {
-	return &DefaultHandler{db: db}
+// NewDefaultHandler constructs a project HTTP handler backed by the provided DB,
+// signing summary thumbnails in whichever bucket holds them and geocoding listing
+// addresses with geocoder.
+func NewDefaultHandler(db storage.Database, buckets storage.Buckets, geocoder geocode.Geocoder) *DefaultHandler {
+	return &DefaultHandler{db: db, buckets: buckets, geocoder: geocoder}
 }
 
 // Ensure DefaultHandler implements Handler.
@@ -72,113 +79,579 @@ func (h *DefaultHandler) Create(c echo.Context) error {
 		})
 	}
 
-	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	// Projects can start from one of the user's templates.
+	templateID := c.QueryParam("template_id")
+	if templateID != "" {
+		if _, err := uuid.Parse(templateID); err != nil {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "bad_request",
+				Message: "Invalid template ID format",
+			})
+		}
+	}
+
+	userID, err := user.ResolveID(c, user.NewDefaultRepository(h.db))
 	if err != nil {
+		return unauthorized(c)
+	}
+
+	if templateID != "" {
+		return h.createFromTemplate(c, &CreateRequest{Name: req.Name, UserID: userID}, templateID)
+	}
+
+	p := Project{Name: req.Name}
+
+	repo := NewDefaultRepository(h.db)
+	created, err := repo.CreateProject(c.Request().Context(), &p, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   fmt.Sprintf("internal_server_error > %v", err),
+			Message: "Failed to create project",
+		})
+	}
+
+	return c.JSON(http.StatusCreated, created)
+}
+
+// List handles GET /api/v1/projects
+func (h *DefaultHandler) List(c echo.Context) error {
+	if h.db == nil {
 		return c.JSON(http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid or missing JWT token",
 		})
 	}
+	userID, err := user.ResolveID(c, user.NewDefaultRepository(h.db))
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	repo := NewDefaultRepository(h.db)
+	projects, err := repo.GetProjectsByUserID(c.Request().Context(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to retrieve projects",
+		})
+	}
+
+	return c.JSON(http.StatusOK, ProjectListResponse{Projects: projects})
+}
+
+// GetByID handles GET /api/v1/projects/:id. Collaborators see the project too; the
+// response's role says what the caller may do with it.
+func (h *DefaultHandler) GetByID(c echo.Context) error {
+	projectID := c.Param("id")
+
+	if _, err := uuid.Parse(projectID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid project ID format",
+		})
+	}
+
+	userID, err := user.ResolveID(c, user.NewDefaultRepository(h.db))
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	repo := NewDefaultRepository(h.db)
+	p, err := repo.GetProjectForMember(c.Request().Context(), projectID, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "not_found",
+				Message: "Project not found",
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to retrieve project",
+		})
+	}
+
+	return c.JSON(http.StatusOK, p)
+}
+
+// Update handles PUT /api/v1/projects/:id. Owners and editors may rename the project.
+func (h *DefaultHandler) Update(c echo.Context) error {
+	projectID := c.Param("id")
+
+	if _, err := uuid.Parse(projectID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid project ID format",
+		})
+	}
+
+	var req UpdateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid request format",
+		})
+	}
+
+	if validationErrs := validateUpdateProjectRequest(&req); len(validationErrs) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
+			Error:            "validation_failed",
+			Message:          "The provided data is invalid",
+			ValidationErrors: validationErrs,
+		})
+	}
+
+	userID, err := user.ResolveID(c, user.NewDefaultRepository(h.db))
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	// Owners and editors may rename the project; viewers may only see it.
+	repo := NewDefaultRepository(h.db)
+	member, err := repo.GetProjectForMember(c.Request().Context(), projectID, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "not_found",
+				Message: "Project not found",
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to retrieve project",
+		})
+	}
+	if member.Role == RoleViewer {
+		return c.JSON(http.StatusForbidden, ErrorResponse{
+			Error:   "forbidden",
+			Message: "Viewers cannot update the project",
+		})
+	}
+
+	updated, err := repo.UpdateProject(c.Request().Context(), projectID, req.Name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "not_found",
+				Message: "Project not found",
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to update project",
+		})
+	}
+	updated.Role = member.Role
+
+	return c.JSON(http.StatusOK, updated)
+}
+
+// Delete handles DELETE /api/v1/projects/:id
+func (h *DefaultHandler) Delete(c echo.Context) error {
+	projectID := c.Param("id")
+
+	if _, err := uuid.Parse(projectID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid project ID format",
+		})
+	}
+
+	userID, err := user.ResolveID(c, user.NewDefaultRepository(h.db))
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	// Projects with staged images need a second call carrying the confirmation token.
+	confirmationToken := c.QueryParam("confirmation_token")
+
+	// Check legal holds and delete in one transaction so the project's cascade is all-or-nothing.
+	var intent *DeletionIntent
+	err = h.db.WithTx(c.Request().Context(), func(tx storage.Database) error {
+		svc := NewDefaultService(NewDefaultRepository(tx), nil)
+		var err error
+		intent, err = svc.DeleteProject(c.Request().Context(), projectID, userID, confirmationToken)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "not_found",
+				Message: "Project not found",
+			})
+		}
+		if errors.Is(err, ErrLegalHold) {
+			return c.JSON(http.StatusConflict, ErrorResponse{
+				Error:   "conflict",
+				Message: "Project is under legal hold and cannot be deleted",
+			})
+		}
+		if errors.Is(err, ErrInvalidConfirmation) {
+			return c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+				Error:   "validation_failed",
+				Message: "Confirmation token is invalid or has expired",
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to delete project",
+		})
+	}
+	if intent != nil {
+		return c.JSON(http.StatusAccepted, intent)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Activity handles GET /api/v1/projects/:project_id/activity
+func (h *DefaultHandler) Activity(c echo.Context) error {
+	projectID := c.Param("project_id")
+
+	if _, err := uuid.Parse(projectID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid project ID format",
+		})
+	}
+
+	limit, offset := parseActivityLimitOffset(c)
+
+	if ok, err := h.authorizeProject(c, projectID); !ok {
+		return err
+	}
+
+	repo := NewDefaultRepository(h.db)
+
+	// Fetch one extra row to know whether another page exists.
+	events, err := repo.ListProjectActivity(c.Request().Context(), projectID, limit+1, offset)
+	if err != nil {
+		c.Logger().Errorf("Failed to list project activity: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to retrieve project activity",
+		})
+	}
+
+	hasMore := len(events) > int(limit)
+	if hasMore {
+		events = events[:limit]
+	}
+
+	return c.JSON(http.StatusOK, ActivityListResponse{
+		Events:  events,
+		Limit:   limit,
+		Offset:  offset,
+		HasMore: hasMore,
+	})
+}
+
+// Summary handles GET /api/v1/projects/:project_id/summary
+func (h *DefaultHandler) Summary(c echo.Context) error {
+	projectID := c.Param("project_id")
+
+	if _, err := uuid.Parse(projectID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid project ID format",
+		})
+	}
+
+	if ok, err := h.authorizeProject(c, projectID); !ok {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	repo := NewDefaultRepository(h.db)
+
+	summary, err := repo.GetProjectSummary(ctx, projectID)
+	if err != nil {
+		c.Logger().Errorf("Failed to get project summary: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to retrieve project summary",
+		})
+	}
+
+	thumbnails, err := repo.ListProjectThumbnails(ctx, projectID, SummaryThumbnailLimit)
+	if err != nil {
+		c.Logger().Errorf("Failed to list project thumbnails: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to retrieve project summary",
+		})
+	}
+	summary.Thumbnails = h.signThumbnails(c, thumbnails)
+
+	return c.JSON(http.StatusOK, summary)
+}
+
+// signThumbnails sets each thumbnail's URL to a presigned link. A thumbnail that cannot
+// be signed is returned without one rather than failing the summary.
+func (h *DefaultHandler) signThumbnails(c echo.Context, thumbnails []Thumbnail) []Thumbnail {
+	if h.buckets == nil {
+		return thumbnails
+	}
+	ctx := c.Request().Context()
+	for i := range thumbnails {
+		files, key, err := h.buckets.ForURL(ctx, thumbnails[i].storedURL)
+		if err != nil {
+			continue
+		}
+		signed, err := files.GeneratePresignedGetURL(ctx, key, summaryThumbnailExpiry, "")
+		if err != nil {
+			c.Logger().Warnf("Failed to sign thumbnail for image %s: %v", thumbnails[i].ImageID, err)
+			continue
+		}
+		thumbnails[i].URL = signed
+	}
+	return thumbnails
+}
+
+// Summaries handles POST /api/v1/projects/summaries
+func (h *DefaultHandler) Summaries(c echo.Context) error {
+	var req SummariesRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid request format",
+		})
+	}
+
+	if validationErrs := validateSummariesRequest(&req); len(validationErrs) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
+			Error:            "validation_failed",
+			Message:          "The provided data is invalid",
+			ValidationErrors: validationErrs,
+		})
+	}
+
+	userID, err := user.ResolveID(c, user.NewDefaultRepository(h.db))
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	// Duplicates are answered once, in the position they were first asked for
+	projectIDs := make([]string, 0, len(req.ProjectIDs))
+	seen := make(map[string]bool, len(req.ProjectIDs))
+	for _, raw := range req.ProjectIDs {
+		id := uuid.MustParse(raw).String()
+		if !seen[id] {
+			seen[id] = true
+			projectIDs = append(projectIDs, id)
+		}
+	}
+
+	summaries, err := NewDefaultRepository(h.db).ListSummariesByUser(
+		c.Request().Context(), projectIDs, userID,
+	)
+	if err != nil {
+		c.Logger().Errorf("Failed to list project summaries: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to retrieve project summaries",
+		})
+	}
+
+	byID := make(map[string]Summary, len(summaries))
+	for _, s := range summaries {
+		byID[s.ProjectID] = s
+	}
+	resp := SummariesResponse{Summaries: []Summary{}, NotFound: []string{}}
+	for _, id := range projectIDs {
+		s, found := byID[id]
+		if !found {
+			resp.NotFound = append(resp.NotFound, id)
+			continue
+		}
+		s.Thumbnails = h.signThumbnails(c, s.Thumbnails)
+		resp.Summaries = append(resp.Summaries, s)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetRetention handles GET /api/v1/projects/:project_id/retention
+func (h *DefaultHandler) GetRetention(c echo.Context) error {
+	projectID := c.Param("project_id")
+
+	if _, err := uuid.Parse(projectID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid project ID format",
+		})
+	}
+
+	if ok, err := h.authorizeProject(c, projectID); !ok {
+		return err
+	}
+
+	exempt, err := NewDefaultRepository(h.db).IsRetentionExempt(c.Request().Context(), projectID)
+	if err != nil {
+		c.Logger().Errorf("Failed to get project retention: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to retrieve project retention",
+		})
+	}
+
+	return c.JSON(http.StatusOK, RetentionResponse{ProjectID: projectID, Exempt: exempt})
+}
+
+// UpdateRetention handles PUT /api/v1/projects/:project_id/retention
+func (h *DefaultHandler) UpdateRetention(c echo.Context) error {
+	projectID := c.Param("project_id")
+
+	if _, err := uuid.Parse(projectID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid project ID format",
+		})
+	}
+
+	var req RetentionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid request format",
+		})
+	}
+
+	if req.Exempt == nil {
+		return c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
+			Error:   "validation_failed",
+			Message: "The provided data is invalid",
+			ValidationErrors: []ValidationErrorDetail{
+				{Field: "exempt", Message: "exempt is required"},
+			},
+		})
+	}
+
+	if ok, err := h.authorizeProject(c, projectID); !ok {
+		return err
+	}
+
+	if err := NewDefaultRepository(h.db).SetRetentionExempt(c.Request().Context(), projectID, *req.Exempt); err != nil {
+		c.Logger().Errorf("Failed to update project retention: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to update project retention",
+		})
+	}
+
+	return c.JSON(http.StatusOK, RetentionResponse{ProjectID: projectID, Exempt: *req.Exempt})
+}
+
+// GetDisclosure handles GET /api/v1/projects/:project_id/disclosure
+func (h *DefaultHandler) GetDisclosure(c echo.Context) error {
+	projectID := c.Param("project_id")
+
+	if _, err := uuid.Parse(projectID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid project ID format",
+		})
+	}
 
-	uRepo := user.NewDefaultRepository(h.db)
+	if ok, err := h.authorizeProject(c, projectID); !ok {
+		return err
+	}
 
-	// Get or create user - we only need the ID
-	var userID pgtype.UUID
-	existingUser, err := uRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
+	d, err := NewDefaultRepository(h.db).GetDisclosure(c.Request().Context(), projectID)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			newUser, err := uRepo.Create(c.Request().Context(), auth0Sub, "", "user")
-			if err != nil {
-				c.Logger().Errorf("Failed to create user: %v", err)
-				return c.JSON(http.StatusInternalServerError, ErrorResponse{
-					Error:   "internal_server_error",
-					Message: "Failed to create user",
-				})
-			}
-			userID = newUser.ID
-		} else {
-			c.Logger().Errorf("Failed to get user by auth0 sub: %v", err)
-			return c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to get user",
-			})
-		}
-	} else {
-		userID = existingUser.ID
+		c.Logger().Errorf("Failed to get project disclosure: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to retrieve project disclosure",
+		})
 	}
 
-	p := Project{Name: req.Name}
+	return c.JSON(http.StatusOK, d)
+}
+
+// UpdateDisclosure handles PUT /api/v1/projects/:project_id/disclosure
+func (h *DefaultHandler) UpdateDisclosure(c echo.Context) error {
+	projectID := c.Param("project_id")
+
+	if _, err := uuid.Parse(projectID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid project ID format",
+		})
+	}
+
+	var req DisclosureRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid request format",
+		})
+	}
+
+	if ok, err := h.authorizeProject(c, projectID); !ok {
+		return err
+	}
 
 	repo := NewDefaultRepository(h.db)
-	created, err := repo.CreateProject(c.Request().Context(), &p, userID.String())
+	d, err := repo.GetDisclosure(c.Request().Context(), projectID)
 	if err != nil {
+		c.Logger().Errorf("Failed to get project disclosure: %v", err)
 		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   fmt.Sprintf("internal_server_error > %v", err),
-			Message: "Failed to create project",
+			Error:   "internal_server_error",
+			Message: "Failed to retrieve project disclosure",
 		})
 	}
 
-	return c.JSON(http.StatusCreated, created)
-}
-
-// List handles GET /api/v1/projects
-func (h *DefaultHandler) List(c echo.Context) error {
-	if h.db == nil {
-		return c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "unauthorized",
-			Message: "Invalid or missing JWT token",
+	if errs := req.Apply(d); len(errs) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
+			Error:            "validation_failed",
+			Message:          "The provided data is invalid",
+			ValidationErrors: errs,
 		})
 	}
-	auth0Sub, err := auth.GetUserIDOrDefault(c)
+
+	saved, err := repo.SaveDisclosure(c.Request().Context(), d)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "unauthorized",
-			Message: "Invalid or missing JWT token",
+		c.Logger().Errorf("Failed to update project disclosure: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to update project disclosure",
 		})
 	}
 
-	uRepo := user.NewDefaultRepository(h.db)
+	return c.JSON(http.StatusOK, saved)
+}
 
-	// Get or create user - we only need the ID
-	var userID pgtype.UUID
-	existingUser, err := uRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			newUser, err := uRepo.Create(c.Request().Context(), auth0Sub, "", "user")
-			if err != nil {
-				c.Logger().Errorf("Failed to create user: %v", err)
-				return c.JSON(http.StatusInternalServerError, ErrorResponse{
-					Error:   "internal_server_error",
-					Message: "Failed to create user",
-				})
-			}
-			userID = newUser.ID
-		} else {
-			c.Logger().Errorf("Failed to get user by auth0 sub: %v", err)
-			return c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to get user",
-			})
-		}
-	} else {
-		userID = existingUser.ID
+// GetListing handles GET /api/v1/projects/:project_id/listing
+func (h *DefaultHandler) GetListing(c echo.Context) error {
+	projectID := c.Param("project_id")
+
+	if _, err := uuid.Parse(projectID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid project ID format",
+		})
 	}
 
-	repo := NewDefaultRepository(h.db)
-	projects, err := repo.GetProjectsByUserID(c.Request().Context(), userID.String())
+	if ok, err := h.authorizeProject(c, projectID); !ok {
+		return err
+	}
+
+	l, err := NewDefaultRepository(h.db).GetListing(c.Request().Context(), projectID)
 	if err != nil {
+		c.Logger().Errorf("Failed to get project listing: %v", err)
 		return c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_server_error",
-			Message: "Failed to retrieve projects",
+			Message: "Failed to retrieve project listing",
 		})
 	}
 
-	return c.JSON(http.StatusOK, ProjectListResponse{Projects: projects})
+	return c.JSON(http.StatusOK, l)
 }
 
-// GetByID handles GET /api/v1/projects/:id
-func (h *DefaultHandler) GetByID(c echo.Context) error {
-	projectID := c.Param("id")
+// UpdateListing handles PUT /api/v1/projects/:project_id/listing. A changed address is
+// geocoded before saving; the listing is saved without a location if geocoding fails.
+func (h *DefaultHandler) UpdateListing(c echo.Context) error {
+	projectID := c.Param("project_id")
 
 	if _, err := uuid.Parse(projectID); err != nil {
 		return c.JSON(http.StatusBadRequest, ErrorResponse{
@@ -187,62 +660,86 @@ func (h *DefaultHandler) GetByID(c echo.Context) error {
 		})
 	}
 
-	auth0Sub, err := auth.GetUserIDOrDefault(c)
-	if err != nil {
-		return c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "unauthorized",
-			Message: "Invalid or missing JWT token",
+	var req ListingRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid request format",
 		})
 	}
 
-	uRepo := user.NewDefaultRepository(h.db)
+	if ok, err := h.authorizeProject(c, projectID); !ok {
+		return err
+	}
 
-	// Get or create user - we only need the ID
-	var userID pgtype.UUID
-	existingUser, err := uRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
+	ctx := c.Request().Context()
+	repo := NewDefaultRepository(h.db)
+	l, err := repo.GetListing(ctx, projectID)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			newUser, err := uRepo.Create(c.Request().Context(), auth0Sub, "", "user")
-			if err != nil {
-				c.Logger().Errorf("Failed to create user: %v", err)
-				return c.JSON(http.StatusInternalServerError, ErrorResponse{
-					Error:   "internal_server_error",
-					Message: "Failed to create user",
-				})
-			}
-			userID = newUser.ID
-		} else {
-			c.Logger().Errorf("Failed to get user by auth0 sub: %v", err)
-			return c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to get user",
-			})
+		c.Logger().Errorf("Failed to get project listing: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to retrieve project listing",
+		})
+	}
+
+	previous := l.Address
+	if errs := req.Apply(l); len(errs) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
+			Error:            "validation_failed",
+			Message:          "The provided data is invalid",
+			ValidationErrors: errs,
+		})
+	}
+	if l.Address != previous {
+		if err := geocodeListing(ctx, h.geocoder, l, time.Now()); err != nil {
+			c.Logger().Warnf("Failed to geocode project listing %s: %v", projectID, err)
 		}
-	} else {
-		userID = existingUser.ID
 	}
 
-	repo := NewDefaultRepository(h.db)
-	p, err := repo.GetProjectByIDAndUserID(c.Request().Context(), projectID, userID.String())
+	saved, err := repo.SaveListing(ctx, l)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
+		c.Logger().Errorf("Failed to update project listing: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to update project listing",
+		})
+	}
+
+	return c.JSON(http.StatusOK, saved)
+}
+
+// createFromTemplate creates the project with the template's settings and room checklist,
+// in one transaction.
+func (h *DefaultHandler) createFromTemplate(c echo.Context, req *CreateRequest, templateID string) error {
+	ctx := c.Request().Context()
+	var created *Project
+	err := h.db.WithTx(ctx, func(tx storage.Database) error {
+		svc := NewDefaultService(NewDefaultRepository(tx), nil)
+		var err error
+		created, err = svc.CreateProjectFromTemplate(ctx, req, templateID)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, ErrTemplateNotFound) {
 			return c.JSON(http.StatusNotFound, ErrorResponse{
 				Error:   "not_found",
-				Message: "Project not found",
+				Message: "Project template not found",
 			})
 		}
+		c.Logger().Errorf("Failed to create project from template: %v", err)
 		return c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_server_error",
-			Message: "Failed to retrieve project",
+			Message: "Failed to create project",
 		})
 	}
 
-	return c.JSON(http.StatusOK, p)
+	return c.JSON(http.StatusCreated, created)
 }
 
-// Update handles PUT /api/v1/projects/:id
-func (h *DefaultHandler) Update(c echo.Context) error {
-	projectID := c.Param("id")
+// Rooms handles GET /api/v1/projects/:project_id/rooms
+func (h *DefaultHandler) Rooms(c echo.Context) error {
+	projectID := c.Param("project_id")
 
 	if _, err := uuid.Parse(projectID); err != nil {
 		return c.JSON(http.StatusBadRequest, ErrorResponse{
@@ -251,7 +748,25 @@ func (h *DefaultHandler) Update(c echo.Context) error {
 		})
 	}
 
-	var req UpdateRequest
+	if ok, err := h.authorizeProject(c, projectID); !ok {
+		return err
+	}
+
+	return h.writeRooms(c, projectID)
+}
+
+// UpdateRooms handles PUT /api/v1/projects/:project_id/rooms
+func (h *DefaultHandler) UpdateRooms(c echo.Context) error {
+	projectID := c.Param("project_id")
+
+	if _, err := uuid.Parse(projectID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid project ID format",
+		})
+	}
+
+	var req RoomsRequest
 	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error:   "bad_request",
@@ -259,128 +774,263 @@ func (h *DefaultHandler) Update(c echo.Context) error {
 		})
 	}
 
-	if validationErrs := validateUpdateProjectRequest(&req); len(validationErrs) > 0 {
+	if errs := req.Validate(); len(errs) > 0 {
 		return c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
 			Error:            "validation_failed",
 			Message:          "The provided data is invalid",
-			ValidationErrors: validationErrs,
+			ValidationErrors: errs,
 		})
 	}
 
-	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if ok, err := h.authorizeProject(c, projectID); !ok {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	err := h.db.WithTx(ctx, func(tx storage.Database) error {
+		return NewDefaultRepository(tx).SaveRooms(ctx, projectID, *req.Rooms)
+	})
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "unauthorized",
-			Message: "Invalid or missing JWT token",
+		c.Logger().Errorf("Failed to update project rooms: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to update project rooms",
 		})
 	}
 
-	uRepo := user.NewDefaultRepository(h.db)
+	return h.writeRooms(c, projectID)
+}
+
+// writeRooms responds with the project's room checklist and how far its images cover it.
+func (h *DefaultHandler) writeRooms(c echo.Context, projectID string) error {
+	ctx := c.Request().Context()
+	repo := NewDefaultRepository(h.db)
 
-	// Get or create user - we only need the ID
-	var userID pgtype.UUID
-	existingUser, err := uRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
+	rooms, err := repo.ListRooms(ctx, projectID)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			newUser, err := uRepo.Create(c.Request().Context(), auth0Sub, "", "user")
-			if err != nil {
-				c.Logger().Errorf("Failed to create user: %v", err)
-				return c.JSON(http.StatusInternalServerError, ErrorResponse{
-					Error:   "internal_server_error",
-					Message: "Failed to create user",
-				})
-			}
-			userID = newUser.ID
-		} else {
-			c.Logger().Errorf("Failed to get user by auth0 sub: %v", err)
-			return c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to get user",
-			})
-		}
-	} else {
-		userID = existingUser.ID
+		c.Logger().Errorf("Failed to list project rooms: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to retrieve project rooms",
+		})
 	}
 
-	repo := NewDefaultRepository(h.db)
-	updated, err := repo.UpdateProjectByUserID(c.Request().Context(), projectID, userID.String(), req.Name)
+	counts, err := repo.CountImagesByRoomType(ctx, projectID)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
+		c.Logger().Errorf("Failed to count project images by room type: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to retrieve project rooms",
+		})
+	}
+
+	return c.JSON(http.StatusOK, newRoomsResponse(projectID, rooms, counts))
+}
+
+// SaveTemplate handles POST /api/v1/projects/:project_id/template
+func (h *DefaultHandler) SaveTemplate(c echo.Context) error {
+	projectID := c.Param("project_id")
+
+	if _, err := uuid.Parse(projectID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid project ID format",
+		})
+	}
+
+	var req TemplateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid request format",
+		})
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
+			Error:            "validation_failed",
+			Message:          "The provided data is invalid",
+			ValidationErrors: errs,
+		})
+	}
+
+	userID, err := user.ResolveID(c, user.NewDefaultRepository(h.db))
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	svc := NewDefaultService(NewDefaultRepository(h.db), nil)
+	t, err := svc.SaveTemplate(c.Request().Context(), projectID, userID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
 			return c.JSON(http.StatusNotFound, ErrorResponse{
 				Error:   "not_found",
 				Message: "Project not found",
 			})
+		case errors.Is(err, ErrTemplateNameTaken):
+			return c.JSON(http.StatusConflict, ErrorResponse{
+				Error:   "conflict",
+				Message: "A project template with this name already exists",
+			})
+		case errors.Is(err, ErrTemplateLimit):
+			return c.JSON(http.StatusConflict, ErrorResponse{
+				Error:   "conflict",
+				Message: fmt.Sprintf("Accounts can keep at most %d project templates", MaxTemplatesPerUser),
+			})
 		}
+		c.Logger().Errorf("Failed to save project template: %v", err)
 		return c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_server_error",
-			Message: "Failed to update project",
+			Message: "Failed to save project template",
 		})
 	}
 
-	return c.JSON(http.StatusOK, updated)
+	return c.JSON(http.StatusCreated, t)
 }
 
-// Delete handles DELETE /api/v1/projects/:id
-func (h *DefaultHandler) Delete(c echo.Context) error {
-	projectID := c.Param("id")
+// ListTemplates handles GET /api/v1/project-templates
+func (h *DefaultHandler) ListTemplates(c echo.Context) error {
+	userID, err := user.ResolveID(c, user.NewDefaultRepository(h.db))
+	if err != nil {
+		return unauthorized(c)
+	}
 
-	if _, err := uuid.Parse(projectID); err != nil {
+	templates, err := NewDefaultRepository(h.db).ListTemplates(c.Request().Context(), userID)
+	if err != nil {
+		c.Logger().Errorf("Failed to list project templates: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to retrieve project templates",
+		})
+	}
+
+	return c.JSON(http.StatusOK, TemplateListResponse{Templates: templates})
+}
+
+// GetTemplate handles GET /api/v1/project-templates/:template_id
+func (h *DefaultHandler) GetTemplate(c echo.Context) error {
+	templateID := c.Param("template_id")
+
+	if _, err := uuid.Parse(templateID); err != nil {
 		return c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error:   "bad_request",
-			Message: "Invalid project ID format",
+			Message: "Invalid template ID format",
 		})
 	}
 
-	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	userID, err := user.ResolveID(c, user.NewDefaultRepository(h.db))
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "unauthorized",
-			Message: "Invalid or missing JWT token",
+		return unauthorized(c)
+	}
+
+	t, err := NewDefaultRepository(h.db).GetTemplate(c.Request().Context(), templateID, userID)
+	if err != nil {
+		if errors.Is(err, ErrTemplateNotFound) {
+			return c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "not_found",
+				Message: "Project template not found",
+			})
+		}
+		c.Logger().Errorf("Failed to get project template: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to retrieve project template",
+		})
+	}
+
+	return c.JSON(http.StatusOK, t)
+}
+
+// DeleteTemplate handles DELETE /api/v1/project-templates/:template_id. Projects created
+// from the template keep their settings and rooms.
+func (h *DefaultHandler) DeleteTemplate(c echo.Context) error {
+	templateID := c.Param("template_id")
+
+	if _, err := uuid.Parse(templateID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "Invalid template ID format",
 		})
 	}
 
-	uRepo := user.NewDefaultRepository(h.db)
+	userID, err := user.ResolveID(c, user.NewDefaultRepository(h.db))
+	if err != nil {
+		return unauthorized(c)
+	}
 
-	// Get or create user - we only need the ID
-	var userID pgtype.UUID
-	existingUser, err := uRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
+	err = NewDefaultRepository(h.db).DeleteTemplate(c.Request().Context(), templateID, userID)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			newUser, err := uRepo.Create(c.Request().Context(), auth0Sub, "", "user")
-			if err != nil {
-				c.Logger().Errorf("Failed to create user: %v", err)
-				return c.JSON(http.StatusInternalServerError, ErrorResponse{
-					Error:   "internal_server_error",
-					Message: "Failed to create user",
-				})
-			}
-			userID = newUser.ID
-		} else {
-			c.Logger().Errorf("Failed to get user by auth0 sub: %v", err)
-			return c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to get user",
+		if errors.Is(err, ErrTemplateNotFound) {
+			return c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "not_found",
+				Message: "Project template not found",
 			})
 		}
-	} else {
-		userID = existingUser.ID
+		c.Logger().Errorf("Failed to delete project template: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to delete project template",
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// authorizeProject resolves the caller (creating the user on first use) and
+// verifies they own projectID. When it returns false the error response has
+// already been written and the returned error should be passed back to Echo.
+func (h *DefaultHandler) authorizeProject(c echo.Context, projectID string) (bool, error) {
+	userID, err := user.ResolveID(c, user.NewDefaultRepository(h.db))
+	if err != nil {
+		return false, unauthorized(c)
 	}
 
 	repo := NewDefaultRepository(h.db)
-	if err := repo.DeleteProjectByUserID(c.Request().Context(), projectID, userID.String()); err != nil {
+	if _, err := repo.GetProjectByIDAndUserID(c.Request().Context(), projectID, userID); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return c.JSON(http.StatusNotFound, ErrorResponse{
+			return false, c.JSON(http.StatusNotFound, ErrorResponse{
 				Error:   "not_found",
 				Message: "Project not found",
 			})
 		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+		return false, c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_server_error",
-			Message: "Failed to delete project",
+			Message: "Failed to retrieve project",
 		})
 	}
 
-	return c.NoContent(http.StatusNoContent)
+	return true, nil
+}
+
+// unauthorized writes the response for a request whose user cannot be resolved.
+func unauthorized(c echo.Context) error {
+	return c.JSON(http.StatusUnauthorized, ErrorResponse{
+		Error:   "unauthorized",
+		Message: "Invalid or missing JWT token",
+	})
+}
+
+// parseActivityLimitOffset reads limit/offset from query params and applies defaults/caps.
+func parseActivityLimitOffset(c echo.Context) (int32, int32) {
+	limit := DefaultActivityLimit
+	offset := int32(0)
+
+	if v := c.QueryParam("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 && n <= int(MaxActivityLimit) {
+			// #nosec G109,G115 -- Value is validated to be positive and within MaxActivityLimit
+			limit = int32(n)
+		}
+	}
+
+	if v := c.QueryParam("offset"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 && n <= 2147483647 {
+			// #nosec G109,G115 -- Value is validated to fit in int32 range
+			offset = int32(n)
+		}
+	}
+
+	return limit, offset
 }
 
 // Validation helpers
@@ -422,3 +1072,31 @@ func validateUpdateProjectRequest(req *UpdateRequest) []ValidationErrorDetail {
 
 	return errors
 }
+
+func validateSummariesRequest(req *SummariesRequest) []ValidationErrorDetail {
+	var errors []ValidationErrorDetail
+
+	switch {
+	case len(req.ProjectIDs) == 0:
+		errors = append(errors, ValidationErrorDetail{
+			Field:   "project_ids",
+			Message: "project_ids is required",
+		})
+	case len(req.ProjectIDs) > MaxSummaryBatch:
+		errors = append(errors, ValidationErrorDetail{
+			Field:   "project_ids",
+			Message: fmt.Sprintf("project_ids must contain at most %d IDs", MaxSummaryBatch),
+		})
+	}
+
+	for i, id := range req.ProjectIDs {
+		if _, err := uuid.Parse(id); err != nil {
+			errors = append(errors, ValidationErrorDetail{
+				Field:   fmt.Sprintf("project_ids[%d]", i),
+				Message: "must be a valid UUID",
+			})
+		}
+	}
+
+	return errors
+}
@@ -3,18 +3,25 @@ package project
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/labstack/echo/v4"
+	"github.com/pashagolub/pgxmock/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/real-staging-ai/api/internal/geocode"
 	"github.com/real-staging-ai/api/internal/storage"
 )
 
@@ -134,9 +141,9 @@ func TestDefaultHandler_Create(t *testing.T) {
 
 			var h *DefaultHandler
 			if tc.setupDB != nil {
-				h = NewDefaultHandler(tc.setupDB())
+				h = NewDefaultHandler(tc.setupDB(), nil, nil)
 			} else {
-				h = NewDefaultHandler(nil)
+				h = NewDefaultHandler(nil, nil, nil)
 			}
 
 			err := h.Create(c)
@@ -173,6 +180,16 @@ func TestDefaultHandler_GetByID(t *testing.T) {
 				r.Header.Set("X-Test-User", "auth0|testuser")
 			},
 		},
+		{
+			name:           "success: collaborator sees their role",
+			projectID:      uuid.New().String(),
+			wantStatusCode: http.StatusOK,
+			contains:       `"role":"viewer"`,
+			setupDB:        func() *storage.DatabaseMock { return newDBMockForProjectMember(RoleViewer) },
+			setHeaders: func(r *http.Request) {
+				r.Header.Set("X-Test-User", "auth0|testuser")
+			},
+		},
 		{
 			name:           "fail: project not found",
 			projectID:      uuid.New().String(),
@@ -199,9 +216,9 @@ func TestDefaultHandler_GetByID(t *testing.T) {
 
 			var h *DefaultHandler
 			if tc.setupDB != nil {
-				h = NewDefaultHandler(tc.setupDB())
+				h = NewDefaultHandler(tc.setupDB(), nil, nil)
 			} else {
-				h = NewDefaultHandler(nil)
+				h = NewDefaultHandler(nil, nil, nil)
 			}
 
 			err := h.GetByID(c)
@@ -234,7 +251,7 @@ func TestDefaultHandler_List(t *testing.T) {
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 
-			h := NewDefaultHandler(nil)
+			h := NewDefaultHandler(nil, nil, nil)
 			err := h.List(c)
 			assert.NoError(t, err)
 			assert.Equal(t, tc.wantStatusCode, rec.Code)
@@ -252,7 +269,24 @@ func TestDefaultHandler_Update(t *testing.T) {
 		body           string
 		wantStatusCode int
 		contains       string
+		setupDB        func() *storage.DatabaseMock
 	}{
+		{
+			name:           "success: editor renames the project",
+			projectID:      uuid.New().String(),
+			body:           `{"name":"Renamed"}`,
+			wantStatusCode: http.StatusOK,
+			contains:       `"role":"editor"`,
+			setupDB:        func() *storage.DatabaseMock { return newDBMockForProjectMember(RoleEditor) },
+		},
+		{
+			name:           "fail: viewer cannot rename the project",
+			projectID:      uuid.New().String(),
+			body:           `{"name":"Renamed"}`,
+			wantStatusCode: http.StatusForbidden,
+			contains:       "Viewers cannot update the project",
+			setupDB:        func() *storage.DatabaseMock { return newDBMockForProjectMember(RoleViewer) },
+		},
 		{
 			name:           "fail: bad request - invalid uuid",
 			projectID:      "invalid-uuid",
@@ -278,8 +312,13 @@ func TestDefaultHandler_Update(t *testing.T) {
 			c := e.NewContext(req, rec)
 			c.SetParamNames("id")
 			c.SetParamValues(tc.projectID)
+			req.Header.Set("X-Test-User", "auth0|testuser")
 
-			h := NewDefaultHandler(nil)
+			var db storage.Database
+			if tc.setupDB != nil {
+				db = tc.setupDB()
+			}
+			h := NewDefaultHandler(db, nil, nil)
 			err := h.Update(c)
 			assert.NoError(t, err)
 			assert.Equal(t, tc.wantStatusCode, rec.Code)
@@ -291,11 +330,48 @@ func TestDefaultHandler_Update(t *testing.T) {
 }
 
 func TestDefaultHandler_Delete(t *testing.T) {
+	// newDB returns a mock whose WithTx runs fn on the same mock, recording the outcome.
+	// intentValid controls whether a confirmation token matches a pending deletion.
+	newDB := func(held, intentValid bool) (*storage.DatabaseMock, *[]string) {
+		var outcomes []string
+		db := newDBMockForCreateProjectSuccess()
+		userRow := db.QueryRowFunc
+		db.QueryRowFunc = func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+			if strings.Contains(sql, "FROM legal_holds") {
+				return fakeRow{scan: func(dest ...any) error {
+					*(dest[0].(*bool)) = held
+					return nil
+				}}
+			}
+			return userRow(ctx, sql, args...)
+		}
+		db.ExecFunc = func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+			if strings.Contains(sql, "DELETE FROM project_deletion_intents") && !intentValid {
+				return pgconn.NewCommandTag("DELETE 0"), nil
+			}
+			return pgconn.NewCommandTag("DELETE 1"), nil
+		}
+		db.WithTxFunc = func(ctx context.Context, fn func(tx storage.Database) error) error {
+			if err := fn(db); err != nil {
+				outcomes = append(outcomes, "rollback")
+				return err
+			}
+			outcomes = append(outcomes, "commit")
+			return nil
+		}
+		return db, &outcomes
+	}
+
 	cases := []struct {
 		name           string
 		projectID      string
+		held           bool
+		token          string
+		intentValid    bool
 		wantStatusCode int
 		contains       string
+		wantOutcomes   []string
+		wantDeletes    int
 	}{
 		{
 			name:           "fail: bad request - invalid uuid",
@@ -303,24 +379,602 @@ func TestDefaultHandler_Delete(t *testing.T) {
 			wantStatusCode: http.StatusBadRequest,
 			contains:       "Invalid project ID format",
 		},
+		{
+			name:           "success: hold check and delete commit together",
+			projectID:      uuid.New().String(),
+			wantStatusCode: http.StatusNoContent,
+			wantOutcomes:   []string{"commit"},
+			wantDeletes:    1,
+		},
+		{
+			name:           "fail: legal hold rolls back",
+			projectID:      uuid.New().String(),
+			held:           true,
+			wantStatusCode: http.StatusConflict,
+			contains:       "legal hold",
+			wantOutcomes:   []string{"rollback"},
+		},
+		{
+			name:           "success: confirmation token deletes",
+			projectID:      uuid.New().String(),
+			token:          "token",
+			intentValid:    true,
+			wantStatusCode: http.StatusNoContent,
+			wantOutcomes:   []string{"commit"},
+			wantDeletes:    2,
+		},
+		{
+			name:           "fail: invalid confirmation token rolls back",
+			projectID:      uuid.New().String(),
+			token:          "stale",
+			wantStatusCode: http.StatusUnprocessableEntity,
+			contains:       "validation_failed",
+			wantOutcomes:   []string{"rollback"},
+			wantDeletes:    1,
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			e := echo.New()
-			req := httptest.NewRequest(http.MethodDelete, "/api/v1/projects/"+tc.projectID, nil)
+			target := "/api/v1/projects/" + tc.projectID
+			if tc.token != "" {
+				target += "?confirmation_token=" + tc.token
+			}
+			req := httptest.NewRequest(http.MethodDelete, target, nil)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 			c.SetParamNames("id")
 			c.SetParamValues(tc.projectID)
 
-			h := NewDefaultHandler(nil)
+			db, outcomes := newDB(tc.held, tc.intentValid)
+			h := NewDefaultHandler(db, nil, nil)
 			err := h.Delete(c)
 			assert.NoError(t, err)
 			assert.Equal(t, tc.wantStatusCode, rec.Code)
 			if tc.contains != "" {
 				assert.Contains(t, rec.Body.String(), tc.contains)
 			}
+			assert.Equal(t, tc.wantOutcomes, *outcomes)
+			assert.Len(t, db.ExecCalls(), tc.wantDeletes)
+		})
+	}
+}
+
+// templateRow fills a project_templates row scan with a template saved for the rooms JSON.
+func templateRow(id pgtype.UUID, name string, disclosure, rooms []byte) func(dest ...any) error {
+	return func(dest ...any) error {
+		// id, user_id, name, disclosure, rooms, created_at, updated_at
+		*dest[0].(*pgtype.UUID) = id
+		*dest[2].(*string) = name
+		*dest[3].(*[]byte) = disclosure
+		*dest[4].(*[]byte) = rooms
+		*dest[5].(*pgtype.Timestamptz) = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+		*dest[6].(*pgtype.Timestamptz) = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+		return nil
+	}
+}
+
+func TestDefaultHandler_SaveTemplate(t *testing.T) {
+	projectID := uuid.New().String()
+
+	cases := []struct {
+		name           string
+		projectID      string
+		body           string
+		projectFound   bool
+		count          int64
+		insertErr      error
+		wantStatusCode int
+		wantInsert     bool
+		wantRooms      string
+		contains       string
+	}{
+		{
+			name:           "success: requested rooms",
+			projectID:      projectID,
+			body:           `{"name":" Condo ","rooms":[{"room_type":"kitchen","style":"modern"},{"room_type":"bedroom","label":" Primary "}]}`,
+			projectFound:   true,
+			wantStatusCode: http.StatusCreated,
+			wantInsert:     true,
+			wantRooms:      `[{"room_type":"kitchen","style":"modern"},{"room_type":"bedroom","label":"Primary"}]`,
+		},
+		{
+			name:           "fail: invalid uuid",
+			projectID:      "invalid-uuid",
+			body:           `{"name":"Condo"}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "fail: invalid rooms",
+			projectID:      projectID,
+			body:           `{"name":"Condo","rooms":[{"room_type":"attic","style":"baroque"}]}`,
+			projectFound:   true,
+			wantStatusCode: http.StatusUnprocessableEntity,
+			contains:       "rooms[0].style",
+		},
+		{
+			name:           "fail: missing name",
+			projectID:      projectID,
+			body:           `{"name":"  "}`,
+			projectFound:   true,
+			wantStatusCode: http.StatusUnprocessableEntity,
+			contains:       "name is required",
+		},
+		{
+			name:           "fail: project not found",
+			projectID:      projectID,
+			body:           `{"name":"Condo","rooms":[]}`,
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "fail: template limit reached",
+			projectID:      projectID,
+			body:           `{"name":"Condo","rooms":[]}`,
+			projectFound:   true,
+			count:          MaxTemplatesPerUser,
+			wantStatusCode: http.StatusConflict,
+			contains:       "at most",
+		},
+		{
+			name:           "fail: name taken",
+			projectID:      projectID,
+			body:           `{"name":"Condo","rooms":[]}`,
+			projectFound:   true,
+			insertErr:      &pgconn.PgError{Code: "23505"},
+			wantStatusCode: http.StatusConflict,
+			wantInsert:     true,
+			contains:       "already exists",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var db *storage.DatabaseMock
+			if tc.projectFound {
+				db = newDBMockForGetProjectByIDSuccess()
+			} else {
+				db = newDBMockForGetProjectByID_NotFound()
+			}
+			var inserted []any
+			queryRow := db.QueryRowFunc
+			db.QueryRowFunc = func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+				switch {
+				case strings.Contains(sql, "COUNT(*) FROM project_templates"):
+					return fakeRow{scan: func(dest ...any) error {
+						*dest[0].(*int64) = tc.count
+						return nil
+					}}
+				case strings.Contains(sql, "FROM project_disclosures"):
+					return fakeRow{scan: func(dest ...any) error { return pgx.ErrNoRows }}
+				case strings.Contains(sql, "INSERT INTO project_templates"):
+					inserted = args
+					if tc.insertErr != nil {
+						return fakeRow{scan: func(dest ...any) error { return tc.insertErr }}
+					}
+					id := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+					return fakeRow{scan: templateRow(id, args[1].(string), args[2].([]byte), args[3].([]byte))}
+				}
+				return queryRow(ctx, sql, args...)
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/projects/"+tc.projectID+"/template", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("X-Test-User", "auth0|testuser")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("project_id")
+			c.SetParamValues(tc.projectID)
+
+			h := NewDefaultHandler(db, nil, nil)
+			err := h.SaveTemplate(c)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.Equal(t, tc.wantInsert, inserted != nil)
+			if tc.contains != "" {
+				assert.Contains(t, rec.Body.String(), tc.contains)
+			}
+
+			if tc.wantStatusCode == http.StatusCreated {
+				assert.Equal(t, "Condo", inserted[1])
+				assert.Nil(t, inserted[2], "a project that never configured its banner leaves the defaults")
+				assert.JSONEq(t, tc.wantRooms, string(inserted[3].([]byte)))
+
+				var resp Template
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, "Condo", resp.Name)
+				assert.Len(t, resp.Rooms, 2)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_CreateFromTemplate(t *testing.T) {
+	templateID := uuid.New().String()
+
+	cases := []struct {
+		name           string
+		templateID     string
+		templateFound  bool
+		applyErr       error
+		wantStatusCode int
+		wantOutcome    string
+		wantApplies    int
+	}{
+		{
+			name:           "success: project created with the template's settings",
+			templateID:     templateID,
+			templateFound:  true,
+			wantStatusCode: http.StatusCreated,
+			wantOutcome:    "commit",
+			wantApplies:    2,
+		},
+		{
+			name:           "fail: invalid template id",
+			templateID:     "invalid-uuid",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "fail: template not found",
+			templateID:     templateID,
+			wantStatusCode: http.StatusNotFound,
+			wantOutcome:    "rollback",
+		},
+		{
+			name:           "fail: apply error rolls back",
+			templateID:     templateID,
+			templateFound:  true,
+			applyErr:       errors.New("db down"),
+			wantStatusCode: http.StatusInternalServerError,
+			wantOutcome:    "rollback",
+			wantApplies:    1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := newDBMockForCreateProjectSuccess()
+			queryRow := db.QueryRowFunc
+			db.QueryRowFunc = func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+				if strings.Contains(sql, "FROM project_templates") {
+					if !tc.templateFound {
+						return fakeRow{scan: func(dest ...any) error { return pgx.ErrNoRows }}
+					}
+					return fakeRow{scan: templateRow(args[0].(pgtype.UUID), "Condo", nil, []byte(`[]`))}
+				}
+				return queryRow(ctx, sql, args...)
+			}
+			var applies int
+			db.ExecFunc = func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+				applies++
+				return pgconn.NewCommandTag("INSERT 0 1"), tc.applyErr
+			}
+			var outcome string
+			db.WithTxFunc = func(ctx context.Context, fn func(tx storage.Database) error) error {
+				if err := fn(db); err != nil {
+					outcome = "rollback"
+					return err
+				}
+				outcome = "commit"
+				return nil
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/projects?template_id="+tc.templateID,
+				strings.NewReader(`{"name":"12 Elm St"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("X-Test-User", "auth0|testuser")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			h := NewDefaultHandler(db, nil, nil)
+			err := h.Create(c)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.Equal(t, tc.wantOutcome, outcome)
+			assert.Equal(t, tc.wantApplies, applies)
+		})
+	}
+}
+
+func TestDefaultHandler_Templates(t *testing.T) {
+	templateUUID := uuid.New()
+	templateID := templateUUID.String()
+	columns := []string{"id", "user_id", "name", "disclosure", "rooms", "created_at", "updated_at"}
+	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+
+	t.Run("success: list", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		mock.ExpectQuery("FROM project_templates").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows(columns).
+				AddRow(pgtype.UUID{Bytes: templateUUID, Valid: true}, pgtype.UUID{}, "Condo",
+					[]byte(`{"enabled":true,"locale":"es","text":null,"position":"top_left","opacity":0.5}`),
+					[]byte(`[{"room_type":"kitchen","style":"modern"}]`), now, now))
+
+		db := newDBMockForGetProjectByIDSuccess()
+		db.QueryFunc = mock.Query
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/project-templates", nil)
+		req.Header.Set("X-Test-User", "auth0|testuser")
+		rec := httptest.NewRecorder()
+
+		h := NewDefaultHandler(db, nil, nil)
+		require.NoError(t, h.ListTemplates(e.NewContext(req, rec)))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+
+		var resp TemplateListResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.Len(t, resp.Templates, 1)
+		assert.Equal(t, templateID, resp.Templates[0].ID)
+		require.NotNil(t, resp.Templates[0].Disclosure)
+		assert.Equal(t, "es", resp.Templates[0].Disclosure.Locale)
+		require.Len(t, resp.Templates[0].Rooms, 1)
+		assert.Equal(t, "modern", *resp.Templates[0].Rooms[0].Style)
+	})
+
+	cases := []struct {
+		name           string
+		method         string
+		templateID     string
+		found          bool
+		wantStatusCode int
+	}{
+		{name: "success: get", method: http.MethodGet, templateID: templateID, found: true, wantStatusCode: http.StatusOK},
+		{name: "fail: get not found", method: http.MethodGet, templateID: templateID, wantStatusCode: http.StatusNotFound},
+		{name: "fail: get invalid uuid", method: http.MethodGet, templateID: "invalid-uuid", wantStatusCode: http.StatusBadRequest},
+		{name: "success: delete", method: http.MethodDelete, templateID: templateID, found: true, wantStatusCode: http.StatusNoContent},
+		{name: "fail: delete not found", method: http.MethodDelete, templateID: templateID, wantStatusCode: http.StatusNotFound},
+		{name: "fail: delete invalid uuid", method: http.MethodDelete, templateID: "invalid-uuid", wantStatusCode: http.StatusBadRequest},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := newDBMockForGetProjectByIDSuccess()
+			queryRow := db.QueryRowFunc
+			db.QueryRowFunc = func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+				if strings.Contains(sql, "FROM project_templates") {
+					if !tc.found {
+						return fakeRow{scan: func(dest ...any) error { return pgx.ErrNoRows }}
+					}
+					return fakeRow{scan: templateRow(args[0].(pgtype.UUID), "Condo", nil, []byte(`[]`))}
+				}
+				return queryRow(ctx, sql, args...)
+			}
+			db.ExecFunc = func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+				if !tc.found {
+					return pgconn.NewCommandTag("DELETE 0"), nil
+				}
+				return pgconn.NewCommandTag("DELETE 1"), nil
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(tc.method, "/api/v1/project-templates/"+tc.templateID, nil)
+			req.Header.Set("X-Test-User", "auth0|testuser")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("template_id")
+			c.SetParamValues(tc.templateID)
+
+			h := NewDefaultHandler(db, nil, nil)
+			var err error
+			if tc.method == http.MethodGet {
+				err = h.GetTemplate(c)
+			} else {
+				err = h.DeleteTemplate(c)
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+
+			if tc.wantStatusCode == http.StatusOK {
+				var resp Template
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, templateID, resp.ID)
+				assert.Nil(t, resp.Disclosure)
+				assert.Empty(t, resp.Rooms)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_Rooms(t *testing.T) {
+	projectUUID := uuid.New()
+	projectID := projectUUID.String()
+	projectPg := pgtype.UUID{Bytes: projectUUID, Valid: true}
+	roomColumns := []string{"project_id", "position", "room_type", "style", "label"}
+	countColumns := []string{"room_type", "uploaded", "staged"}
+
+	// A two-bedroom checklist with one bedroom and the kitchen photographed.
+	expectChecklist := func(mock pgxmock.PgxPoolIface) {
+		mock.ExpectQuery("FROM project_rooms").
+			WithArgs(projectPg).
+			WillReturnRows(pgxmock.NewRows(roomColumns).
+				AddRow(projectPg, int32(1), "kitchen", pgtype.Text{String: "modern", Valid: true}, "").
+				AddRow(projectPg, int32(2), "bedroom", pgtype.Text{}, "Primary bedroom").
+				AddRow(projectPg, int32(3), "bedroom", pgtype.Text{}, "Guest bedroom"))
+		mock.ExpectQuery("FROM images").
+			WithArgs(projectPg).
+			WillReturnRows(pgxmock.NewRows(countColumns).
+				AddRow("bedroom", int64(1), int64(0)).
+				AddRow("kitchen", int64(2), int64(1)))
+	}
+
+	cases := []struct {
+		name           string
+		method         string
+		projectID      string
+		body           string
+		projectFound   bool
+		setupRows      func(mock pgxmock.PgxPoolIface)
+		saveErr        error
+		wantStatusCode int
+		wantSave       []string
+		contains       string
+	}{
+		{
+			name:           "success: checklist with coverage",
+			method:         http.MethodGet,
+			projectID:      projectID,
+			projectFound:   true,
+			setupRows:      expectChecklist,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "fail: get invalid uuid",
+			method:         http.MethodGet,
+			projectID:      "invalid-uuid",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "fail: get project not found",
+			method:         http.MethodGet,
+			projectID:      projectID,
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:         "fail: list query error",
+			method:       http.MethodGet,
+			projectID:    projectID,
+			projectFound: true,
+			setupRows: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("FROM project_rooms").WithArgs(projectPg).WillReturnError(errors.New("db down"))
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "success: replace checklist",
+			method:         http.MethodPut,
+			projectID:      projectID,
+			body:           `{"rooms":[{"room_type":"kitchen","style":"modern"},{"room_type":"bedroom","label":" Primary bedroom "},{"room_type":"bedroom","label":"Guest bedroom"}]}`,
+			projectFound:   true,
+			setupRows:      expectChecklist,
+			wantStatusCode: http.StatusOK,
+			wantSave:       []string{"DELETE FROM project_rooms", "INSERT INTO project_rooms"},
+		},
+		{
+			name:           "success: clear checklist",
+			method:         http.MethodPut,
+			projectID:      projectID,
+			body:           `{"rooms":[]}`,
+			projectFound:   true,
+			setupRows:      expectChecklist,
+			wantStatusCode: http.StatusOK,
+			wantSave:       []string{"DELETE FROM project_rooms"},
+		},
+		{
+			name:           "fail: missing rooms",
+			method:         http.MethodPut,
+			projectID:      projectID,
+			body:           `{}`,
+			projectFound:   true,
+			wantStatusCode: http.StatusUnprocessableEntity,
+			contains:       "rooms is required",
+		},
+		{
+			name:           "fail: too many rooms",
+			method:         http.MethodPut,
+			projectID:      projectID,
+			body:           `{"rooms":[` + strings.TrimSuffix(strings.Repeat(`{"room_type":"bedroom"},`, MaxRooms+1), ",") + `]}`,
+			projectFound:   true,
+			wantStatusCode: http.StatusUnprocessableEntity,
+			contains:       "at most",
+		},
+		{
+			name:           "fail: update project not found",
+			method:         http.MethodPut,
+			projectID:      projectID,
+			body:           `{"rooms":[]}`,
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "fail: save error",
+			method:         http.MethodPut,
+			projectID:      projectID,
+			body:           `{"rooms":[{"room_type":"kitchen"}]}`,
+			projectFound:   true,
+			saveErr:        errors.New("db down"),
+			wantStatusCode: http.StatusInternalServerError,
+			wantSave:       []string{"DELETE FROM project_rooms", "INSERT INTO project_rooms"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+			if tc.setupRows != nil {
+				tc.setupRows(mock)
+			}
+
+			var db *storage.DatabaseMock
+			if tc.projectFound {
+				db = newDBMockForGetProjectByIDSuccess()
+			} else {
+				db = newDBMockForGetProjectByID_NotFound()
+			}
+			db.QueryFunc = mock.Query
+			var saves []string
+			var inserted []any
+			db.ExecFunc = func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+				if strings.Contains(sql, "INSERT INTO project_rooms") {
+					saves = append(saves, "INSERT INTO project_rooms")
+					inserted = args
+					return pgconn.NewCommandTag("INSERT 0 1"), tc.saveErr
+				}
+				saves = append(saves, "DELETE FROM project_rooms")
+				return pgconn.NewCommandTag("DELETE 1"), nil
+			}
+			db.WithTxFunc = func(ctx context.Context, fn func(tx storage.Database) error) error {
+				return fn(db)
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(tc.method, "/api/v1/projects/"+tc.projectID+"/rooms", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("X-Test-User", "auth0|testuser")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("project_id")
+			c.SetParamValues(tc.projectID)
+
+			h := NewDefaultHandler(db, nil, nil)
+			if tc.method == http.MethodGet {
+				err = h.Rooms(c)
+			} else {
+				err = h.UpdateRooms(c)
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.Equal(t, tc.wantSave, saves)
+			assert.NoError(t, mock.ExpectationsWereMet())
+			if tc.contains != "" {
+				assert.Contains(t, rec.Body.String(), tc.contains)
+			}
+			if tc.name == "success: replace checklist" {
+				assert.Equal(t, []string{"kitchen", "bedroom", "bedroom"}, inserted[1])
+				assert.Equal(t, []string{"modern", "", ""}, inserted[2])
+				assert.Equal(t, []string{"", "Primary bedroom", "Guest bedroom"}, inserted[3])
+			}
+
+			if tc.wantStatusCode == http.StatusOK {
+				var resp RoomsResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				require.Len(t, resp.Rooms, 3)
+				assert.Equal(t, "modern", *resp.Rooms[0].Style)
+				assert.Equal(t, "Primary bedroom", resp.Rooms[1].Label)
+				assert.Equal(t, []RoomCoverage{
+					{RoomType: "kitchen", Expected: 1, Uploaded: 2, Staged: 1},
+					{RoomType: "bedroom", Expected: 2, Uploaded: 1, Missing: 1},
+				}, resp.Coverage)
+				assert.Equal(t, int64(1), resp.Missing)
+				assert.False(t, resp.Complete)
+			}
 		})
 	}
 }
@@ -366,16 +1020,11 @@ func newDBMockForCreateProjectSuccess() *storage.DatabaseMock {
 			// INSERT project
 			case strings.Contains(sql, "INSERT INTO projects"):
 				return fakeRow{scan: func(dest ...any) error {
-					// id, user_id, created_at
-					if id, ok := dest[0].(*string); ok {
-						*id = uuid.New().String()
-					}
-					if uid, ok := dest[1].(*string); ok && len(args) > 1 {
-						*uid = args[1].(string)
-					}
-					if ts, ok := dest[2].(*time.Time); ok {
-						*ts = now
-					}
+					// id, name, user_id, created_at
+					*(dest[0].(*pgtype.UUID)) = pgtype.UUID{Bytes: uuid.New(), Valid: true}
+					*(dest[1].(*string)) = args[0].(string)
+					*(dest[2].(*pgtype.UUID)) = args[1].(pgtype.UUID)
+					*(dest[3].(*pgtype.Timestamptz)) = pgtype.Timestamptz{Time: now, Valid: true}
 					return nil
 				}}
 			default:
@@ -421,15 +1070,10 @@ func newDBMockForCreateProject_UserNotFoundThenCreate() *storage.DatabaseMock {
 			// Insert project
 			case strings.Contains(sql, "INSERT INTO projects"):
 				return fakeRow{scan: func(dest ...any) error {
-					if id, ok := dest[0].(*string); ok {
-						*id = uuid.New().String()
-					}
-					if uid, ok := dest[1].(*string); ok {
-						*uid = newUserID.String()
-					}
-					if ts, ok := dest[2].(*time.Time); ok {
-						*ts = now
-					}
+					*(dest[0].(*pgtype.UUID)) = pgtype.UUID{Bytes: uuid.New(), Valid: true}
+					*(dest[1].(*string)) = args[0].(string)
+					*(dest[2].(*pgtype.UUID)) = pgtype.UUID{Bytes: newUserID, Valid: true}
+					*(dest[3].(*pgtype.Timestamptz)) = pgtype.Timestamptz{Time: now, Valid: true}
 					return nil
 				}}
 			default:
@@ -466,18 +1110,10 @@ func newDBMockForGetProjectByIDSuccess() *storage.DatabaseMock {
 			// Project found
 			case strings.Contains(sql, "FROM projects") && strings.Contains(sql, "WHERE"):
 				return fakeRow{scan: func(dest ...any) error {
-					if id, ok := dest[0].(*string); ok && len(args) > 0 {
-						*id = args[0].(string)
-					}
-					if name, ok := dest[1].(*string); ok {
-						*name = "My Project"
-					}
-					if uid, ok := dest[2].(*string); ok {
-						*uid = userID.String()
-					}
-					if ts, ok := dest[3].(*time.Time); ok {
-						*ts = now
-					}
+					*(dest[0].(*pgtype.UUID)) = args[0].(pgtype.UUID)
+					*(dest[1].(*string)) = "My Project"
+					*(dest[2].(*pgtype.UUID)) = pgtype.UUID{Bytes: userID, Valid: true}
+					*(dest[3].(*pgtype.Timestamptz)) = pgtype.Timestamptz{Time: now, Valid: true}
 					return nil
 				}}
 			default:
@@ -487,6 +1123,45 @@ func newDBMockForGetProjectByIDSuccess() *storage.DatabaseMock {
 	}
 }
 
+// newDBMockForProjectMember resolves the user as a collaborator with role on any project,
+// and echoes renames back.
+func newDBMockForProjectMember(role string) *storage.DatabaseMock {
+	now := time.Now()
+	userID := uuid.New()
+	ownerID := uuid.New()
+
+	return &storage.DatabaseMock{
+		QueryRowFunc: func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+			switch {
+			case strings.Contains(sql, "FROM users") && strings.Contains(sql, "auth0_sub"):
+				return fakeRow{scan: func(dest ...any) error {
+					*(dest[0].(*pgtype.UUID)) = pgtype.UUID{Bytes: userID, Valid: true}
+					return nil
+				}}
+			case strings.Contains(sql, "GetProjectByIDForMember"):
+				return fakeRow{scan: func(dest ...any) error {
+					*(dest[0].(*pgtype.UUID)) = args[0].(pgtype.UUID)
+					*(dest[1].(*string)) = "Shared Project"
+					*(dest[2].(*pgtype.UUID)) = pgtype.UUID{Bytes: ownerID, Valid: true}
+					*(dest[3].(*pgtype.Timestamptz)) = pgtype.Timestamptz{Time: now, Valid: true}
+					*(dest[4].(*string)) = role
+					return nil
+				}}
+			case strings.Contains(sql, "name: UpdateProject :one"):
+				return fakeRow{scan: func(dest ...any) error {
+					*(dest[0].(*pgtype.UUID)) = args[0].(pgtype.UUID)
+					*(dest[1].(*string)) = args[1].(string)
+					*(dest[2].(*pgtype.UUID)) = pgtype.UUID{Bytes: ownerID, Valid: true}
+					*(dest[3].(*pgtype.Timestamptz)) = pgtype.Timestamptz{Time: now, Valid: true}
+					return nil
+				}}
+			default:
+				return fakeRow{scan: func(dest ...any) error { return pgx.ErrNoRows }}
+			}
+		},
+	}
+}
+
 // not found path for GetByID: user exists, project missing
 func newDBMockForGetProjectByID_NotFound() *storage.DatabaseMock {
 	now := time.Now()
@@ -522,3 +1197,993 @@ func newDBMockForGetProjectByID_NotFound() *storage.DatabaseMock {
 		},
 	}
 }
+
+func TestDefaultHandler_Activity(t *testing.T) {
+	projectUUID := uuid.New()
+	projectID := projectUUID.String()
+	projectPg := pgtype.UUID{Bytes: projectUUID, Valid: true}
+	imageID := uuid.New()
+	now := time.Now()
+	columns := []string{"id", "project_id", "image_id", "event_type", "metadata", "created_at"}
+
+	cases := []struct {
+		name           string
+		projectID      string
+		query          string
+		projectFound   bool
+		setupRows      func(mock pgxmock.PgxPoolIface)
+		wantStatusCode int
+		wantEvents     int
+		wantHasMore    bool
+		wantLimit      int32
+	}{
+		{
+			name:           "fail: bad request - invalid uuid",
+			projectID:      "invalid-uuid",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "fail: project not found",
+			projectID:      projectID,
+			projectFound:   false,
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:         "success: first page with more available",
+			projectID:    projectID,
+			query:        "?limit=2",
+			projectFound: true,
+			setupRows: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("FROM project_activity").
+					WithArgs(projectPg, int32(3), int32(0)).
+					WillReturnRows(pgxmock.NewRows(columns).
+						AddRow(int64(3), projectPg, pgtype.UUID{Bytes: imageID, Valid: true},
+							ActivityImageStatusChanged, json.RawMessage(`{"from":"processing","to":"ready"}`), pgtype.Timestamptz{Time: now, Valid: true}).
+						AddRow(int64(2), projectPg, pgtype.UUID{Bytes: imageID, Valid: true},
+							ActivityImageStatusChanged, json.RawMessage(`{"from":"queued","to":"processing"}`), pgtype.Timestamptz{Time: now, Valid: true}).
+						AddRow(int64(1), projectPg, pgtype.UUID{Bytes: imageID, Valid: true},
+							ActivityImageCreated, json.RawMessage(`{}`), pgtype.Timestamptz{Time: now, Valid: true}))
+			},
+			wantStatusCode: http.StatusOK,
+			wantEvents:     2,
+			wantHasMore:    true,
+			wantLimit:      2,
+		},
+		{
+			name:         "success: out of range limit falls back to default",
+			projectID:    projectID,
+			query:        "?limit=5000&offset=10",
+			projectFound: true,
+			setupRows: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("FROM project_activity").
+					WithArgs(projectPg, DefaultActivityLimit+1, int32(10)).
+					WillReturnRows(pgxmock.NewRows(columns))
+			},
+			wantStatusCode: http.StatusOK,
+			wantEvents:     0,
+			wantLimit:      DefaultActivityLimit,
+		},
+		{
+			name:         "fail: list query error",
+			projectID:    projectID,
+			projectFound: true,
+			setupRows: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("FROM project_activity").
+					WithArgs(projectPg, DefaultActivityLimit+1, int32(0)).
+					WillReturnError(errors.New("db down"))
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+			if tc.setupRows != nil {
+				tc.setupRows(mock)
+			}
+
+			var db *storage.DatabaseMock
+			if tc.projectFound {
+				db = newDBMockForGetProjectByIDSuccess()
+			} else {
+				db = newDBMockForGetProjectByID_NotFound()
+			}
+			db.QueryFunc = mock.Query
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/projects/"+tc.projectID+"/activity"+tc.query, nil)
+			req.Header.Set("X-Test-User", "auth0|testuser")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("project_id")
+			c.SetParamValues(tc.projectID)
+
+			h := NewDefaultHandler(db, nil, nil)
+			err = h.Activity(c)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.NoError(t, mock.ExpectationsWereMet())
+
+			if tc.wantStatusCode == http.StatusOK {
+				var resp ActivityListResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Len(t, resp.Events, tc.wantEvents)
+				assert.Equal(t, tc.wantHasMore, resp.HasMore)
+				assert.Equal(t, tc.wantLimit, resp.Limit)
+				if tc.wantEvents > 0 {
+					require.NotNil(t, resp.Events[0].ImageID)
+					assert.Equal(t, imageID.String(), *resp.Events[0].ImageID)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_Summary(t *testing.T) {
+	projectUUID := uuid.New()
+	projectID := projectUUID.String()
+	projectPg := pgtype.UUID{Bytes: projectUUID, Valid: true}
+	readyID := uuid.New()
+	queuedID := uuid.New()
+	lastActivity := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+	summaryColumns := []string{
+		"total_images", "queued_count", "processing_count", "ready_count",
+		"error_count", "canceled_count", "storage_bytes", "last_activity_at",
+	}
+	thumbnailColumns := []string{"id", "status", "original_url", "staged_url"}
+
+	expectSummary := func(mock pgxmock.PgxPoolIface) {
+		mock.ExpectQuery("FROM projects p").
+			WithArgs(projectPg).
+			WillReturnRows(pgxmock.NewRows(summaryColumns).
+				AddRow(int64(3), int64(1), int64(0), int64(2), int64(0), int64(0), int64(4096),
+					pgtype.Timestamptz{Time: lastActivity, Valid: true}))
+	}
+
+	cases := []struct {
+		name           string
+		projectID      string
+		projectFound   bool
+		setupRows      func(mock pgxmock.PgxPoolIface)
+		signErr        error
+		wantStatusCode int
+		wantURLs       []string
+	}{
+		{
+			name:           "fail: bad request - invalid uuid",
+			projectID:      "invalid-uuid",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "fail: project not found",
+			projectID:      projectID,
+			projectFound:   false,
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:         "success: thumbnails signed, staged output preferred",
+			projectID:    projectID,
+			projectFound: true,
+			setupRows: func(mock pgxmock.PgxPoolIface) {
+				expectSummary(mock)
+				mock.ExpectQuery("FROM images").
+					WithArgs(projectPg, SummaryThumbnailLimit).
+					WillReturnRows(pgxmock.NewRows(thumbnailColumns).
+						AddRow(pgtype.UUID{Bytes: readyID, Valid: true}, "ready",
+							"s3://real-staging/uploads/a.jpg", pgtype.Text{String: "s3://real-staging/staged/a.jpg", Valid: true}).
+						AddRow(pgtype.UUID{Bytes: queuedID, Valid: true}, "queued",
+							"s3://real-staging/uploads/b.jpg", pgtype.Text{}))
+			},
+			wantStatusCode: http.StatusOK,
+			wantURLs:       []string{"https://signed/staged/a.jpg", "https://signed/uploads/b.jpg"},
+		},
+		{
+			name:         "success: signing failure leaves url out",
+			projectID:    projectID,
+			projectFound: true,
+			setupRows: func(mock pgxmock.PgxPoolIface) {
+				expectSummary(mock)
+				mock.ExpectQuery("FROM images").
+					WithArgs(projectPg, SummaryThumbnailLimit).
+					WillReturnRows(pgxmock.NewRows(thumbnailColumns).
+						AddRow(pgtype.UUID{Bytes: queuedID, Valid: true}, "queued",
+							"s3://real-staging/uploads/b.jpg", pgtype.Text{}))
+			},
+			signErr:        errors.New("s3 down"),
+			wantStatusCode: http.StatusOK,
+			wantURLs:       []string{""},
+		},
+		{
+			name:         "fail: summary query error",
+			projectID:    projectID,
+			projectFound: true,
+			setupRows: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("FROM projects p").
+					WithArgs(projectPg).
+					WillReturnError(errors.New("db down"))
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:         "fail: thumbnail query error",
+			projectID:    projectID,
+			projectFound: true,
+			setupRows: func(mock pgxmock.PgxPoolIface) {
+				expectSummary(mock)
+				mock.ExpectQuery("FROM images").
+					WithArgs(projectPg, SummaryThumbnailLimit).
+					WillReturnError(errors.New("db down"))
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+			if tc.setupRows != nil {
+				tc.setupRows(mock)
+			}
+
+			var db *storage.DatabaseMock
+			if tc.projectFound {
+				db = newDBMockForGetProjectByIDSuccess()
+			} else {
+				db = newDBMockForGetProjectByID_NotFound()
+			}
+			queryRow := db.QueryRowFunc
+			db.QueryRowFunc = func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+				if strings.Contains(sql, "last_activity_at") {
+					return mock.QueryRow(ctx, sql, args...)
+				}
+				return queryRow(ctx, sql, args...)
+			}
+			db.QueryFunc = mock.Query
+
+			files := &storage.S3ServiceMock{
+				BucketNameFunc: func() string { return "real-staging" },
+				GeneratePresignedGetURLFunc: func(
+					_ context.Context, fileKey string, _ int64, _ string,
+				) (string, error) {
+					if tc.signErr != nil {
+						return "", tc.signErr
+					}
+					return "https://signed/" + fileKey, nil
+				},
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/projects/"+tc.projectID+"/summary", nil)
+			req.Header.Set("X-Test-User", "auth0|testuser")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("project_id")
+			c.SetParamValues(tc.projectID)
+
+			h := NewDefaultHandler(db, storage.NewPlatformBuckets(files), nil)
+			err = h.Summary(c)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.NoError(t, mock.ExpectationsWereMet())
+
+			if tc.wantStatusCode == http.StatusOK {
+				var resp Summary
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, projectID, resp.ProjectID)
+				assert.Equal(t, int64(3), resp.TotalImages)
+				assert.Equal(t, int64(4096), resp.StorageBytes)
+				assert.True(t, lastActivity.Equal(resp.LastActivityAt))
+				assert.Equal(t, map[string]int64{
+					"queued": 1, "processing": 0, "ready": 2, "error": 0, "canceled": 0,
+				}, resp.StatusCounts)
+				require.Len(t, resp.Thumbnails, len(tc.wantURLs))
+				for i, want := range tc.wantURLs {
+					assert.Equal(t, want, resp.Thumbnails[i].URL)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_Summaries(t *testing.T) {
+	ownedUUID := uuid.New()
+	missingUUID := uuid.New()
+	imageID := uuid.New()
+	lastActivity := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+	columns := []string{
+		"project_id", "total_images", "queued_count", "processing_count", "ready_count",
+		"error_count", "canceled_count", "storage_bytes", "last_activity_at", "thumbnails",
+	}
+	thumbnails := `[{"id":"` + imageID.String() + `","status":"ready",` +
+		`"original_url":"s3://real-staging/uploads/a.jpg","staged_url":"s3://real-staging/staged/a.jpg"}]`
+
+	tooMany := make([]string, MaxSummaryBatch+1)
+	for i := range tooMany {
+		tooMany[i] = uuid.NewString()
+	}
+
+	cases := []struct {
+		name           string
+		body           string
+		setupRows      func(mock pgxmock.PgxPoolIface)
+		wantStatusCode int
+		wantSummaries  []string
+		wantNotFound   []string
+	}{
+		{
+			name:           "fail: malformed body",
+			body:           `{"project_ids":`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "fail: no project ids",
+			body:           `{"project_ids":[]}`,
+			wantStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "fail: too many project ids",
+			body:           `{"project_ids":["` + strings.Join(tooMany, `","`) + `"]}`,
+			wantStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "fail: invalid uuid",
+			body:           `{"project_ids":["` + ownedUUID.String() + `","nope"]}`,
+			wantStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "success: owned summaries in request order, others not found",
+			body: `{"project_ids":["` + missingUUID.String() + `","` + strings.ToUpper(ownedUUID.String()) +
+				`","` + ownedUUID.String() + `"]}`,
+			setupRows: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("FROM projects p").
+					WithArgs(SummaryThumbnailLimit, []pgtype.UUID{
+						{Bytes: missingUUID, Valid: true}, {Bytes: ownedUUID, Valid: true},
+					}, pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows(columns).
+						AddRow(pgtype.UUID{Bytes: ownedUUID, Valid: true}, int64(1), int64(0), int64(0), int64(1),
+							int64(0), int64(0), int64(1024), pgtype.Timestamptz{Time: lastActivity, Valid: true},
+							[]byte(thumbnails)))
+			},
+			wantStatusCode: http.StatusOK,
+			wantSummaries:  []string{ownedUUID.String()},
+			wantNotFound:   []string{missingUUID.String()},
+		},
+		{
+			name: "fail: query error",
+			body: `{"project_ids":["` + ownedUUID.String() + `"]}`,
+			setupRows: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("FROM projects p").
+					WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+					WillReturnError(errors.New("db down"))
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+			if tc.setupRows != nil {
+				tc.setupRows(mock)
+			}
+
+			db := newDBMockForGetProjectByIDSuccess()
+			db.QueryFunc = mock.Query
+
+			files := &storage.S3ServiceMock{
+				BucketNameFunc: func() string { return "real-staging" },
+				GeneratePresignedGetURLFunc: func(
+					_ context.Context, fileKey string, _ int64, _ string,
+				) (string, error) {
+					return "https://signed/" + fileKey, nil
+				},
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/projects/summaries", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("X-Test-User", "auth0|testuser")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			h := NewDefaultHandler(db, storage.NewPlatformBuckets(files), nil)
+			err = h.Summaries(c)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.NoError(t, mock.ExpectationsWereMet())
+
+			if tc.wantStatusCode == http.StatusOK {
+				var resp SummariesResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, tc.wantNotFound, resp.NotFound)
+				require.Len(t, resp.Summaries, len(tc.wantSummaries))
+				for i, want := range tc.wantSummaries {
+					assert.Equal(t, want, resp.Summaries[i].ProjectID)
+				}
+				got := resp.Summaries[0]
+				assert.Equal(t, int64(1024), got.StorageBytes)
+				assert.Equal(t, int64(1), got.StatusCounts["ready"])
+				require.Len(t, got.Thumbnails, 1)
+				assert.Equal(t, imageID.String(), got.Thumbnails[0].ImageID)
+				assert.Equal(t, "https://signed/staged/a.jpg", got.Thumbnails[0].URL)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_Retention(t *testing.T) {
+	projectID := uuid.New().String()
+
+	cases := []struct {
+		name           string
+		method         string
+		projectID      string
+		body           string
+		projectFound   bool
+		exempt         bool
+		execErr        error
+		wantStatusCode int
+		wantExempt     bool
+		wantExecSQL    string
+	}{
+		{
+			name:           "success: get retention exempt",
+			method:         http.MethodGet,
+			projectID:      projectID,
+			projectFound:   true,
+			exempt:         true,
+			wantStatusCode: http.StatusOK,
+			wantExempt:     true,
+		},
+		{
+			name:           "fail: get retention invalid uuid",
+			method:         http.MethodGet,
+			projectID:      "invalid-uuid",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "fail: get retention project not found",
+			method:         http.MethodGet,
+			projectID:      projectID,
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "success: exempt project",
+			method:         http.MethodPut,
+			projectID:      projectID,
+			body:           `{"exempt":true}`,
+			projectFound:   true,
+			wantStatusCode: http.StatusOK,
+			wantExempt:     true,
+			wantExecSQL:    "INSERT INTO project_retention_exemptions",
+		},
+		{
+			name:           "success: remove exemption",
+			method:         http.MethodPut,
+			projectID:      projectID,
+			body:           `{"exempt":false}`,
+			projectFound:   true,
+			wantStatusCode: http.StatusOK,
+			wantExempt:     false,
+			wantExecSQL:    "DELETE FROM project_retention_exemptions",
+		},
+		{
+			name:           "fail: missing exempt",
+			method:         http.MethodPut,
+			projectID:      projectID,
+			body:           `{}`,
+			projectFound:   true,
+			wantStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "fail: malformed body",
+			method:         http.MethodPut,
+			projectID:      projectID,
+			body:           `{"exempt":`,
+			projectFound:   true,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "fail: update project not found",
+			method:         http.MethodPut,
+			projectID:      projectID,
+			body:           `{"exempt":true}`,
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "fail: update error",
+			method:         http.MethodPut,
+			projectID:      projectID,
+			body:           `{"exempt":true}`,
+			projectFound:   true,
+			execErr:        errors.New("db down"),
+			wantStatusCode: http.StatusInternalServerError,
+			wantExecSQL:    "INSERT INTO project_retention_exemptions",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var db *storage.DatabaseMock
+			if tc.projectFound {
+				db = newDBMockForGetProjectByIDSuccess()
+			} else {
+				db = newDBMockForGetProjectByID_NotFound()
+			}
+			queryRow := db.QueryRowFunc
+			db.QueryRowFunc = func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+				if strings.Contains(sql, "project_retention_exemptions") {
+					return fakeRow{scan: func(dest ...any) error {
+						*dest[0].(*bool) = tc.exempt
+						return nil
+					}}
+				}
+				return queryRow(ctx, sql, args...)
+			}
+			db.ExecFunc = func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+				return pgconn.NewCommandTag("INSERT 0 1"), tc.execErr
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(tc.method, "/api/v1/projects/"+tc.projectID+"/retention", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("X-Test-User", "auth0|testuser")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("project_id")
+			c.SetParamValues(tc.projectID)
+
+			h := NewDefaultHandler(db, nil, nil)
+			var err error
+			if tc.method == http.MethodGet {
+				err = h.GetRetention(c)
+			} else {
+				err = h.UpdateRetention(c)
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+
+			if tc.wantExecSQL != "" {
+				require.Len(t, db.ExecCalls(), 1)
+				assert.Contains(t, db.ExecCalls()[0].SQL, tc.wantExecSQL)
+			} else {
+				assert.Empty(t, db.ExecCalls())
+			}
+
+			if tc.wantStatusCode == http.StatusOK {
+				var resp RetentionResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, tc.projectID, resp.ProjectID)
+				assert.Equal(t, tc.wantExempt, resp.Exempt)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_Disclosure(t *testing.T) {
+	projectID := uuid.New().String()
+
+	cases := []struct {
+		name           string
+		method         string
+		projectID      string
+		body           string
+		projectFound   bool
+		saved          bool
+		saveErr        error
+		wantStatusCode int
+		wantSave       bool
+		wantEnabled    bool
+		wantLocale     string
+		wantPosition   string
+		wantText       *string
+	}{
+		{
+			name:           "success: get defaults",
+			method:         http.MethodGet,
+			projectID:      projectID,
+			projectFound:   true,
+			wantStatusCode: http.StatusOK,
+			wantLocale:     "en",
+			wantPosition:   DisclosurePositionBottomRight,
+		},
+		{
+			name:           "success: get saved settings",
+			method:         http.MethodGet,
+			projectID:      projectID,
+			projectFound:   true,
+			saved:          true,
+			wantStatusCode: http.StatusOK,
+			wantEnabled:    true,
+			wantLocale:     "es",
+			wantPosition:   DisclosurePositionTopLeft,
+			wantText:       stringPtr("Imagen virtual"),
+		},
+		{
+			name:           "fail: get invalid uuid",
+			method:         http.MethodGet,
+			projectID:      "invalid-uuid",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "fail: get project not found",
+			method:         http.MethodGet,
+			projectID:      projectID,
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "success: enable with locale",
+			method:         http.MethodPut,
+			projectID:      projectID,
+			body:           `{"enabled":true,"locale":"fr-CA","position":"bottom_center"}`,
+			projectFound:   true,
+			wantStatusCode: http.StatusOK,
+			wantSave:       true,
+			wantEnabled:    true,
+			wantLocale:     "fr-ca",
+			wantPosition:   DisclosurePositionBottomCenter,
+		},
+		{
+			name:           "success: partial update keeps saved fields and clears text",
+			method:         http.MethodPut,
+			projectID:      projectID,
+			body:           `{"text":"","opacity":0.25}`,
+			projectFound:   true,
+			saved:          true,
+			wantStatusCode: http.StatusOK,
+			wantSave:       true,
+			wantEnabled:    true,
+			wantLocale:     "es",
+			wantPosition:   DisclosurePositionTopLeft,
+		},
+		{
+			name:           "fail: invalid fields",
+			method:         http.MethodPut,
+			projectID:      projectID,
+			body:           `{"locale":"xx","position":"middle","opacity":1.5}`,
+			projectFound:   true,
+			wantStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "fail: text too long",
+			method:         http.MethodPut,
+			projectID:      projectID,
+			body:           `{"text":"` + strings.Repeat("a", MaxDisclosureTextLength+1) + `"}`,
+			projectFound:   true,
+			wantStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "fail: malformed body",
+			method:         http.MethodPut,
+			projectID:      projectID,
+			body:           `{"enabled":`,
+			projectFound:   true,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "fail: update project not found",
+			method:         http.MethodPut,
+			projectID:      projectID,
+			body:           `{"enabled":true}`,
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "fail: save error",
+			method:         http.MethodPut,
+			projectID:      projectID,
+			body:           `{"enabled":true}`,
+			projectFound:   true,
+			saveErr:        errors.New("db down"),
+			wantStatusCode: http.StatusInternalServerError,
+			wantSave:       true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var db *storage.DatabaseMock
+			if tc.projectFound {
+				db = newDBMockForGetProjectByIDSuccess()
+			} else {
+				db = newDBMockForGetProjectByID_NotFound()
+			}
+			var saves int
+			queryRow := db.QueryRowFunc
+			db.QueryRowFunc = func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+				switch {
+				case strings.Contains(sql, "INSERT INTO project_disclosures"):
+					saves++
+					return fakeRow{scan: func(dest ...any) error {
+						if tc.saveErr != nil {
+							return tc.saveErr
+						}
+						// project_id, enabled, locale, text, position, opacity, updated_at
+						for i, arg := range args {
+							reflect.ValueOf(dest[i]).Elem().Set(reflect.ValueOf(arg))
+						}
+						*dest[6].(*pgtype.Timestamptz) = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+						return nil
+					}}
+				case strings.Contains(sql, "FROM project_disclosures"):
+					return fakeRow{scan: func(dest ...any) error {
+						if !tc.saved {
+							return pgx.ErrNoRows
+						}
+						*dest[0].(*pgtype.UUID) = args[0].(pgtype.UUID)
+						*dest[1].(*bool) = true
+						*dest[2].(*string) = "es"
+						*dest[3].(*pgtype.Text) = pgtype.Text{String: "Imagen virtual", Valid: true}
+						*dest[4].(*string) = DisclosurePositionTopLeft
+						*dest[5].(*float32) = 0.5
+						*dest[6].(*pgtype.Timestamptz) = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+						return nil
+					}}
+				}
+				return queryRow(ctx, sql, args...)
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(tc.method, "/api/v1/projects/"+tc.projectID+"/disclosure", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("X-Test-User", "auth0|testuser")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("project_id")
+			c.SetParamValues(tc.projectID)
+
+			h := NewDefaultHandler(db, nil, nil)
+			var err error
+			if tc.method == http.MethodGet {
+				err = h.GetDisclosure(c)
+			} else {
+				err = h.UpdateDisclosure(c)
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.Equal(t, tc.wantSave, saves == 1)
+
+			if tc.wantStatusCode == http.StatusOK {
+				var resp Disclosure
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, tc.projectID, resp.ProjectID)
+				assert.Equal(t, tc.wantEnabled, resp.Enabled)
+				assert.Equal(t, tc.wantLocale, resp.Locale)
+				assert.Equal(t, tc.wantPosition, resp.Position)
+				assert.Equal(t, tc.wantText, resp.Text)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_Listing(t *testing.T) {
+	projectID := uuid.New().String()
+	savedAddress := geocode.Address{Line1: "1 Main St", City: "Springfield", Region: "IL", Country: "US"}
+	savedLocation := &geocode.Location{Latitude: 39.8, Longitude: -89.6}
+
+	cases := []struct {
+		name           string
+		method         string
+		projectID      string
+		body           string
+		projectFound   bool
+		saved          bool
+		geocodeErr     error
+		saveErr        error
+		wantStatusCode int
+		wantSave       bool
+		wantGeocodes   int
+		wantAddress    geocode.Address
+		wantMLS        string
+		wantURL        string
+		wantLocation   *geocode.Location
+	}{
+		{
+			name:           "success: get empty listing",
+			method:         http.MethodGet,
+			projectID:      projectID,
+			projectFound:   true,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "success: get saved listing",
+			method:         http.MethodGet,
+			projectID:      projectID,
+			projectFound:   true,
+			saved:          true,
+			wantStatusCode: http.StatusOK,
+			wantAddress:    savedAddress,
+			wantMLS:        "MLS-1",
+			wantLocation:   savedLocation,
+		},
+		{
+			name:           "fail: get project not found",
+			method:         http.MethodGet,
+			projectID:      projectID,
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:      "success: new address is geocoded",
+			method:    http.MethodPut,
+			projectID: projectID,
+			body: `{"address":{"line1":" 5 Oak Ave ","city":"Portland","region":"OR","country":"us"},` +
+				`"mls_number":"RMLS-22","listing_url":"https://homes.example.com/5-oak"}`,
+			projectFound:   true,
+			wantStatusCode: http.StatusOK,
+			wantSave:       true,
+			wantGeocodes:   1,
+			wantAddress:    geocode.Address{Line1: "5 Oak Ave", City: "Portland", Region: "OR", Country: "US"},
+			wantMLS:        "RMLS-22",
+			wantURL:        "https://homes.example.com/5-oak",
+			wantLocation:   &geocode.Location{Latitude: 45.5, Longitude: -122.6},
+		},
+		{
+			name:           "success: unchanged address keeps its location",
+			method:         http.MethodPut,
+			projectID:      projectID,
+			body:           `{"mls_number":""}`,
+			projectFound:   true,
+			saved:          true,
+			wantStatusCode: http.StatusOK,
+			wantSave:       true,
+			wantAddress:    savedAddress,
+			wantLocation:   savedLocation,
+		},
+		{
+			name:           "success: geocoding failure saves without a location",
+			method:         http.MethodPut,
+			projectID:      projectID,
+			body:           `{"address":{"line1":"5 Oak Ave","city":"Portland","country":"US"}}`,
+			projectFound:   true,
+			saved:          true,
+			geocodeErr:     errors.New("provider down"),
+			wantStatusCode: http.StatusOK,
+			wantSave:       true,
+			wantGeocodes:   1,
+			wantAddress:    geocode.Address{Line1: "5 Oak Ave", City: "Portland", Country: "US"},
+			wantMLS:        "MLS-1",
+		},
+		{
+			name:           "success: empty address clears it and its location",
+			method:         http.MethodPut,
+			projectID:      projectID,
+			body:           `{"address":{}}`,
+			projectFound:   true,
+			saved:          true,
+			wantStatusCode: http.StatusOK,
+			wantSave:       true,
+			wantMLS:        "MLS-1",
+		},
+		{
+			name:           "fail: invalid fields",
+			method:         http.MethodPut,
+			projectID:      projectID,
+			body:           `{"address":{"line1":"5 Oak Ave","country":"USA"},"mls_number":"#1","listing_url":"ftp://x"}`,
+			projectFound:   true,
+			wantStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "fail: invalid uuid",
+			method:         http.MethodPut,
+			projectID:      "invalid-uuid",
+			body:           `{}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "fail: save error",
+			method:         http.MethodPut,
+			projectID:      projectID,
+			body:           `{"mls_number":"MLS-2"}`,
+			projectFound:   true,
+			saveErr:        errors.New("db down"),
+			wantStatusCode: http.StatusInternalServerError,
+			wantSave:       true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var db *storage.DatabaseMock
+			if tc.projectFound {
+				db = newDBMockForGetProjectByIDSuccess()
+			} else {
+				db = newDBMockForGetProjectByID_NotFound()
+			}
+			var saves int
+			queryRow := db.QueryRowFunc
+			db.QueryRowFunc = func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+				switch {
+				case strings.Contains(sql, "INSERT INTO project_listings"):
+					saves++
+					return fakeRow{scan: func(dest ...any) error {
+						if tc.saveErr != nil {
+							return tc.saveErr
+						}
+						for i, arg := range args {
+							reflect.ValueOf(dest[i]).Elem().Set(reflect.ValueOf(arg))
+						}
+						*dest[12].(*pgtype.Timestamptz) = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+						return nil
+					}}
+				case strings.Contains(sql, "FROM project_listings"):
+					return fakeRow{scan: func(dest ...any) error {
+						if !tc.saved {
+							return pgx.ErrNoRows
+						}
+						*dest[0].(*pgtype.UUID) = args[0].(pgtype.UUID)
+						*dest[1].(*string) = savedAddress.Line1
+						*dest[3].(*string) = savedAddress.City
+						*dest[4].(*string) = savedAddress.Region
+						*dest[6].(*string) = savedAddress.Country
+						*dest[7].(*string) = "MLS-1"
+						*dest[9].(*pgtype.Float8) = pgtype.Float8{Float64: savedLocation.Latitude, Valid: true}
+						*dest[10].(*pgtype.Float8) = pgtype.Float8{Float64: savedLocation.Longitude, Valid: true}
+						*dest[11].(*pgtype.Timestamptz) = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+						*dest[12].(*pgtype.Timestamptz) = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+						return nil
+					}}
+				}
+				return queryRow(ctx, sql, args...)
+			}
+			geocoder := &geocode.GeocoderMock{
+				GeocodeFunc: func(ctx context.Context, addr geocode.Address) (*geocode.Location, error) {
+					if tc.geocodeErr != nil {
+						return nil, tc.geocodeErr
+					}
+					return &geocode.Location{Latitude: 45.5, Longitude: -122.6}, nil
+				},
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(tc.method, "/api/v1/projects/"+tc.projectID+"/listing", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("X-Test-User", "auth0|testuser")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("project_id")
+			c.SetParamValues(tc.projectID)
+
+			h := NewDefaultHandler(db, nil, geocoder)
+			var err error
+			if tc.method == http.MethodGet {
+				err = h.GetListing(c)
+			} else {
+				err = h.UpdateListing(c)
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.Equal(t, tc.wantSave, saves == 1)
+			assert.Len(t, geocoder.GeocodeCalls(), tc.wantGeocodes)
+
+			if tc.wantStatusCode == http.StatusOK {
+				var resp Listing
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, tc.projectID, resp.ProjectID)
+				assert.Equal(t, tc.wantAddress, resp.Address)
+				assert.Equal(t, tc.wantMLS, resp.MLSNumber)
+				assert.Equal(t, tc.wantURL, resp.ListingURL)
+				assert.Equal(t, tc.wantLocation, resp.Location)
+				assert.Equal(t, tc.wantLocation != nil, resp.GeocodedAt != nil)
+			}
+			if tc.wantStatusCode == http.StatusUnprocessableEntity {
+				var resp ValidationErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				var fields []string
+				for _, v := range resp.ValidationErrors {
+					fields = append(fields, v.Field)
+				}
+				assert.Equal(t, []string{"address.city", "address.country", "mls_number", "listing_url"}, fields)
+			}
+		})
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -2,15 +2,21 @@ package project
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
+	"time"
 
 	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
 )
 
 // DefaultService provides business logic for project operations.
 type DefaultService struct {
 	projectRepo Repository
 	s3Service   storage.S3Service
+	now         func() time.Time
 }
 
 // NewDefaultService creates a new DefaultService with the provided dependencies.
@@ -18,6 +24,7 @@ func NewDefaultService(projectRepo Repository, s3Service storage.S3Service) *Def
 	return &DefaultService{
 		projectRepo: projectRepo,
 		s3Service:   s3Service,
+		now:         time.Now,
 	}
 }
 
@@ -132,20 +139,170 @@ func (s *DefaultService) UpdateProject(ctx context.Context, projectID, userID, n
 }
 
 // DeleteProject deletes a project, ensuring the user owns it.
-func (s *DefaultService) DeleteProject(ctx context.Context, projectID, userID string) error {
+// Returns ErrLegalHold when the project or any of its images is under legal hold.
+//
+// A project with staged images is not deleted straight away: without a confirmation token the
+// call returns a DeletionIntent whose token, passed back within DeletionConfirmTTL, deletes it.
+// Returns ErrInvalidConfirmation when the token does not match or has expired.
+func (s *DefaultService) DeleteProject(
+	ctx context.Context, projectID, userID, confirmationToken string,
+) (*DeletionIntent, error) {
 	if projectID == "" {
-		return fmt.Errorf("project ID is required")
+		return nil, fmt.Errorf("project ID is required")
 	}
 	if userID == "" {
-		return fmt.Errorf("user ID is required")
+		return nil, fmt.Errorf("user ID is required")
+	}
+
+	held, err := s.projectRepo.IsUnderLegalHold(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check legal hold: %w", err)
+	}
+	if held {
+		return nil, ErrLegalHold
+	}
+
+	if confirmationToken == "" {
+		intent, err := s.requestDeletion(ctx, projectID, userID)
+		if err != nil || intent != nil {
+			return intent, err
+		}
+	} else {
+		ok, err := s.projectRepo.ConsumeDeletionIntent(ctx, projectID, userID, hashToken(confirmationToken))
+		if err != nil {
+			return nil, fmt.Errorf("failed to confirm deletion: %w", err)
+		}
+		if !ok {
+			return nil, ErrInvalidConfirmation
+		}
+	}
+
+	err = s.projectRepo.DeleteProjectByUserID(ctx, projectID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete project: %w", err)
+	}
+
+	return nil, nil
+}
+
+// requestDeletion issues a DeletionIntent when the project has staged images, and returns nil
+// when it can be deleted without confirmation.
+func (s *DefaultService) requestDeletion(ctx context.Context, projectID, userID string) (*DeletionIntent, error) {
+	if _, err := s.projectRepo.GetProjectByIDAndUserID(ctx, projectID, userID); err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	summary, err := s.projectRepo.GetProjectSummary(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project summary: %w", err)
+	}
+	ready := summary.StatusCounts[string(queries.ImageStatusReady)]
+	if ready == 0 {
+		return nil, nil
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("failed to generate confirmation token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	expiresAt := s.now().Add(DeletionConfirmTTL)
+
+	err = s.projectRepo.SaveDeletionIntent(ctx, projectID, userID, hashToken(token), expiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save deletion intent: %w", err)
+	}
+
+	return &DeletionIntent{
+		ProjectID:         projectID,
+		ConfirmationToken: token,
+		ExpiresAt:         expiresAt,
+		ReadyImages:       ready,
+	}, nil
+}
+
+// hashToken returns the digest a confirmation token is stored as.
+func hashToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
+
+// SaveTemplate saves the user's project as a template, with its disclosure settings and
+// room checklist. Without rooms in req, the project's own checklist is used, or failing
+// that one room per room type among its images. The caller validates req.
+// Returns ErrTemplateLimit when the user already has MaxTemplatesPerUser templates and
+// ErrTemplateNameTaken when the name is in use.
+func (s *DefaultService) SaveTemplate(
+	ctx context.Context, projectID, userID string, req *TemplateRequest,
+) (*Template, error) {
+	if _, err := s.projectRepo.GetProjectByIDAndUserID(ctx, projectID, userID); err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	count, err := s.projectRepo.CountTemplates(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count templates: %w", err)
+	}
+	if count >= MaxTemplatesPerUser {
+		return nil, ErrTemplateLimit
+	}
+
+	disclosure, err := s.projectRepo.GetDisclosure(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project disclosure: %w", err)
+	}
+
+	var rooms []Room
+	if req.Rooms != nil {
+		rooms = *req.Rooms
+	} else if rooms, err = s.projectRooms(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	return s.projectRepo.CreateTemplate(ctx, userID, &Template{
+		Name:       req.Name,
+		Disclosure: templateDisclosure(disclosure),
+		Rooms:      rooms,
+	})
+}
+
+// projectRooms returns the project's checklist, or one room per room type among its images
+// when it has none, capped at MaxRooms.
+func (s *DefaultService) projectRooms(ctx context.Context, projectID string) ([]Room, error) {
+	rooms, err := s.projectRepo.ListRooms(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project rooms: %w", err)
+	}
+	if len(rooms) == 0 {
+		if rooms, err = s.projectRepo.ListImageRooms(ctx, projectID); err != nil {
+			return nil, fmt.Errorf("failed to list project image rooms: %w", err)
+		}
 	}
+	if len(rooms) > MaxRooms {
+		rooms = rooms[:MaxRooms]
+	}
+	return rooms, nil
+}
 
-	err := s.projectRepo.DeleteProjectByUserID(ctx, projectID, userID)
+// CreateProjectFromTemplate creates a project with the settings and room checklist of one of
+// the user's templates. Returns ErrTemplateNotFound when the template is not the user's.
+// Run it in a transaction so a project is not left half set up.
+func (s *DefaultService) CreateProjectFromTemplate(
+	ctx context.Context, req *CreateRequest, templateID string,
+) (*Project, error) {
+	if _, err := s.projectRepo.GetTemplate(ctx, templateID, req.UserID); err != nil {
+		return nil, err
+	}
+
+	created, err := s.CreateProject(ctx, req)
 	if err != nil {
-		return fmt.Errorf("failed to delete project: %w", err)
+		return nil, err
 	}
 
-	return nil
+	if err := s.projectRepo.ApplyTemplate(ctx, created.ID, templateID); err != nil {
+		return nil, fmt.Errorf("failed to apply template: %w", err)
+	}
+	return created, nil
 }
 
 // GetProjectStats returns statistics about a user's projects.
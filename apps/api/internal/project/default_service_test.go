@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -545,25 +546,133 @@ func TestProjectService_UpdateProject(t *testing.T) {
 	}
 }
 
+// stagedImages makes the mock report a project with the given number of ready images.
+func stagedImages(mock *project.RepositoryMock, ready int64) {
+	mock.GetProjectByIDAndUserIDFunc = func(ctx context.Context, projectID, userID string) (*project.Project, error) {
+		return &project.Project{ID: projectID, UserID: userID}, nil
+	}
+	mock.GetProjectSummaryFunc = func(ctx context.Context, projectID string) (*project.Summary, error) {
+		return &project.Summary{ProjectID: projectID, StatusCounts: map[string]int64{"ready": ready}}, nil
+	}
+}
+
 func TestProjectService_DeleteProject(t *testing.T) {
 	testCases := []struct {
-		name        string
-		projectID   string
-		userID      string
-		setupMock   func(*project.RepositoryMock)
-		expectError bool
-		errorMsg    string
+		name              string
+		projectID         string
+		userID            string
+		confirmationToken string
+		setupMock         func(*project.RepositoryMock)
+		expectError       bool
+		errorMsg          string
+		expectIntent      bool
+		expectDelete      bool
 	}{
 		{
 			name:      "success: delete project",
 			projectID: "proj123",
 			userID:    "user123",
 			setupMock: func(mock *project.RepositoryMock) {
+				mock.IsUnderLegalHoldFunc = func(ctx context.Context, projectID string) (bool, error) {
+					return false, nil
+				}
+				stagedImages(mock, 0)
 				mock.DeleteProjectByUserIDFunc = func(ctx context.Context, projectID string, userID string) error {
 					return nil
 				}
 			},
-			expectError: false,
+			expectError:  false,
+			expectDelete: true,
+		},
+		{
+			name:      "success: staged images need confirmation",
+			projectID: "proj123",
+			userID:    "user123",
+			setupMock: func(mock *project.RepositoryMock) {
+				mock.IsUnderLegalHoldFunc = func(ctx context.Context, projectID string) (bool, error) {
+					return false, nil
+				}
+				stagedImages(mock, 3)
+				mock.SaveDeletionIntentFunc = func(
+					ctx context.Context, projectID, userID string, tokenHash []byte, expiresAt time.Time,
+				) error {
+					return nil
+				}
+			},
+			expectIntent: true,
+		},
+		{
+			name:              "success: confirmed deletion",
+			projectID:         "proj123",
+			userID:            "user123",
+			confirmationToken: "token",
+			setupMock: func(mock *project.RepositoryMock) {
+				mock.IsUnderLegalHoldFunc = func(ctx context.Context, projectID string) (bool, error) {
+					return false, nil
+				}
+				mock.ConsumeDeletionIntentFunc = func(
+					ctx context.Context, projectID, userID string, tokenHash []byte,
+				) (bool, error) {
+					return true, nil
+				}
+				mock.DeleteProjectByUserIDFunc = func(ctx context.Context, projectID string, userID string) error {
+					return nil
+				}
+			},
+			expectDelete: true,
+		},
+		{
+			name:              "failure: invalid confirmation token",
+			projectID:         "proj123",
+			userID:            "user123",
+			confirmationToken: "stale",
+			setupMock: func(mock *project.RepositoryMock) {
+				mock.IsUnderLegalHoldFunc = func(ctx context.Context, projectID string) (bool, error) {
+					return false, nil
+				}
+				mock.ConsumeDeletionIntentFunc = func(
+					ctx context.Context, projectID, userID string, tokenHash []byte,
+				) (bool, error) {
+					return false, nil
+				}
+			},
+			expectError: true,
+			errorMsg:    "deletion confirmation token is invalid",
+		},
+		{
+			name:      "failure: project not found",
+			projectID: "proj123",
+			userID:    "user123",
+			setupMock: func(mock *project.RepositoryMock) {
+				mock.IsUnderLegalHoldFunc = func(ctx context.Context, projectID string) (bool, error) {
+					return false, nil
+				}
+				mock.GetProjectByIDAndUserIDFunc = func(
+					ctx context.Context, projectID, userID string,
+				) (*project.Project, error) {
+					return nil, errors.New("no rows")
+				}
+			},
+			expectError: true,
+			errorMsg:    "failed to get project",
+		},
+		{
+			name:      "failure: save deletion intent error",
+			projectID: "proj123",
+			userID:    "user123",
+			setupMock: func(mock *project.RepositoryMock) {
+				mock.IsUnderLegalHoldFunc = func(ctx context.Context, projectID string) (bool, error) {
+					return false, nil
+				}
+				stagedImages(mock, 1)
+				mock.SaveDeletionIntentFunc = func(
+					ctx context.Context, projectID, userID string, tokenHash []byte, expiresAt time.Time,
+				) error {
+					return errors.New("db error")
+				}
+			},
+			expectError: true,
+			errorMsg:    "failed to save deletion intent",
 		},
 		{
 			name:      "failure: empty project ID",
@@ -585,17 +694,46 @@ func TestProjectService_DeleteProject(t *testing.T) {
 			expectError: true,
 			errorMsg:    "user ID is required",
 		},
+		{
+			name:      "failure: under legal hold",
+			projectID: "proj123",
+			userID:    "user123",
+			setupMock: func(mock *project.RepositoryMock) {
+				mock.IsUnderLegalHoldFunc = func(ctx context.Context, projectID string) (bool, error) {
+					return true, nil
+				}
+			},
+			expectError: true,
+			errorMsg:    "project is under legal hold",
+		},
+		{
+			name:      "failure: legal hold check error",
+			projectID: "proj123",
+			userID:    "user123",
+			setupMock: func(mock *project.RepositoryMock) {
+				mock.IsUnderLegalHoldFunc = func(ctx context.Context, projectID string) (bool, error) {
+					return false, errors.New("db error")
+				}
+			},
+			expectError: true,
+			errorMsg:    "failed to check legal hold",
+		},
 		{
 			name:      "failure: repository error",
 			projectID: "proj123",
 			userID:    "user123",
 			setupMock: func(mock *project.RepositoryMock) {
+				mock.IsUnderLegalHoldFunc = func(ctx context.Context, projectID string) (bool, error) {
+					return false, nil
+				}
+				stagedImages(mock, 0)
 				mock.DeleteProjectByUserIDFunc = func(ctx context.Context, projectID string, userID string) error {
 					return errors.New("project not found")
 				}
 			},
-			expectError: true,
-			errorMsg:    "failed to delete project",
+			expectError:  true,
+			errorMsg:     "failed to delete project",
+			expectDelete: true,
 		},
 	}
 
@@ -609,14 +747,35 @@ func TestProjectService_DeleteProject(t *testing.T) {
 
 			projectService := project.NewDefaultService(projectRepositoryMock, s3ServiceMock)
 
-			err = projectService.DeleteProject(context.Background(), tc.projectID, tc.userID)
+			intent, err := projectService.DeleteProject(
+				context.Background(), tc.projectID, tc.userID, tc.confirmationToken)
 
 			if tc.expectError {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tc.errorMsg)
+				assert.Nil(t, intent)
 			} else {
 				assert.NoError(t, err)
 			}
+			if tc.expectDelete {
+				assert.Len(t, projectRepositoryMock.DeleteProjectByUserIDCalls(), 1)
+			} else {
+				assert.Empty(t, projectRepositoryMock.DeleteProjectByUserIDCalls())
+			}
+
+			if !tc.expectIntent {
+				assert.Nil(t, intent)
+				return
+			}
+			require.NotNil(t, intent)
+			assert.NotEmpty(t, intent.ConfirmationToken)
+			assert.Equal(t, int64(3), intent.ReadyImages)
+			assert.WithinDuration(t, time.Now().Add(project.DeletionConfirmTTL), intent.ExpiresAt, time.Minute)
+
+			saves := projectRepositoryMock.SaveDeletionIntentCalls()
+			require.Len(t, saves, 1)
+			assert.NotEqual(t, []byte(intent.ConfirmationToken), saves[0].TokenHash)
+			assert.Equal(t, intent.ExpiresAt, saves[0].ExpiresAt)
 		})
 	}
 }
@@ -775,11 +934,209 @@ func TestProjectService_ComplexScenarios(t *testing.T) {
 		assert.Equal(t, "Updated Workflow Project", updatedProject.Name)
 
 		// Step 4: Delete the project
+		projectRepositoryMock.IsUnderLegalHoldFunc = func(ctx context.Context, projectID string) (bool, error) {
+			return false, nil
+		}
+		stagedImages(projectRepositoryMock, 0)
 		projectRepositoryMock.DeleteProjectByUserIDFunc = func(ctx context.Context, projectID string, userID string) error {
 			return nil
 		}
 
-		err = projectService.DeleteProject(context.Background(), projectID, userID)
+		intent, err := projectService.DeleteProject(context.Background(), projectID, userID, "")
 		assert.NoError(t, err)
+		assert.Nil(t, intent)
 	})
 }
+
+func TestProjectService_SaveTemplate(t *testing.T) {
+	updated := time.Now()
+	modern := "modern"
+	kitchen := project.Room{RoomType: "kitchen", Style: &modern}
+	bedroom := project.Room{RoomType: "bedroom", Label: "Primary bedroom"}
+
+	testCases := []struct {
+		name           string
+		req            project.TemplateRequest
+		setupMock      func(*project.RepositoryMock)
+		errorIs        error
+		errorMsg       string
+		wantRooms      []project.Room
+		wantDisclosure bool
+	}{
+		{
+			name: "success: requested rooms",
+			req:  project.TemplateRequest{Name: "Condo", Rooms: &[]project.Room{bedroom}},
+			setupMock: func(mock *project.RepositoryMock) {
+				mock.GetDisclosureFunc = func(ctx context.Context, projectID string) (*project.Disclosure, error) {
+					d := project.DefaultDisclosure(projectID)
+					d.Enabled, d.UpdatedAt = true, &updated
+					return d, nil
+				}
+			},
+			wantRooms:      []project.Room{bedroom},
+			wantDisclosure: true,
+		},
+		{
+			name: "success: rooms from the project's checklist",
+			req:  project.TemplateRequest{Name: "Condo"},
+			setupMock: func(mock *project.RepositoryMock) {
+				mock.ListRoomsFunc = func(ctx context.Context, projectID string) ([]project.Room, error) {
+					return []project.Room{kitchen, bedroom}, nil
+				}
+			},
+			wantRooms: []project.Room{kitchen, bedroom},
+		},
+		{
+			name: "success: rooms from the project's images",
+			req:  project.TemplateRequest{Name: "Condo"},
+			setupMock: func(mock *project.RepositoryMock) {
+				mock.ListImageRoomsFunc = func(ctx context.Context, projectID string) ([]project.Room, error) {
+					return []project.Room{kitchen}, nil
+				}
+			},
+			wantRooms: []project.Room{kitchen},
+		},
+		{
+			name: "failure: template limit reached",
+			req:  project.TemplateRequest{Name: "Condo"},
+			setupMock: func(mock *project.RepositoryMock) {
+				mock.CountTemplatesFunc = func(ctx context.Context, userID string) (int64, error) {
+					return project.MaxTemplatesPerUser, nil
+				}
+			},
+			errorIs: project.ErrTemplateLimit,
+		},
+		{
+			name: "failure: name taken",
+			req:  project.TemplateRequest{Name: "Condo", Rooms: &[]project.Room{}},
+			setupMock: func(mock *project.RepositoryMock) {
+				mock.CreateTemplateFunc = func(ctx context.Context, userID string, t *project.Template) (*project.Template, error) {
+					return nil, project.ErrTemplateNameTaken
+				}
+			},
+			errorIs: project.ErrTemplateNameTaken,
+		},
+		{
+			name: "failure: project not found",
+			req:  project.TemplateRequest{Name: "Condo"},
+			setupMock: func(mock *project.RepositoryMock) {
+				mock.GetProjectByIDAndUserIDFunc = func(ctx context.Context, projectID, userID string) (*project.Project, error) {
+					return nil, errors.New("no rows")
+				}
+			},
+			errorMsg: "failed to get project",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &project.RepositoryMock{
+				GetProjectByIDAndUserIDFunc: func(ctx context.Context, projectID, userID string) (*project.Project, error) {
+					return &project.Project{ID: projectID, UserID: userID}, nil
+				},
+				CountTemplatesFunc: func(ctx context.Context, userID string) (int64, error) {
+					return 2, nil
+				},
+				GetDisclosureFunc: func(ctx context.Context, projectID string) (*project.Disclosure, error) {
+					return project.DefaultDisclosure(projectID), nil
+				},
+				ListRoomsFunc: func(ctx context.Context, projectID string) ([]project.Room, error) {
+					return nil, nil
+				},
+				ListImageRoomsFunc: func(ctx context.Context, projectID string) ([]project.Room, error) {
+					return nil, nil
+				},
+				CreateTemplateFunc: func(ctx context.Context, userID string, t *project.Template) (*project.Template, error) {
+					saved := *t
+					saved.ID = "template-1"
+					return &saved, nil
+				},
+			}
+			tc.setupMock(repo)
+
+			svc := project.NewDefaultService(repo, nil)
+			result, err := svc.SaveTemplate(context.Background(), "project-1", "user-1", &tc.req)
+
+			switch {
+			case tc.errorIs != nil:
+				assert.ErrorIs(t, err, tc.errorIs)
+				return
+			case tc.errorMsg != "":
+				assert.ErrorContains(t, err, tc.errorMsg)
+				assert.Empty(t, repo.CreateTemplateCalls())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "template-1", result.ID)
+			assert.Equal(t, "Condo", result.Name)
+			assert.Equal(t, tc.wantRooms, result.Rooms)
+			if tc.wantDisclosure {
+				require.NotNil(t, result.Disclosure)
+				assert.True(t, result.Disclosure.Enabled)
+				assert.Equal(t, project.DisclosurePositionBottomRight, result.Disclosure.Position)
+			} else {
+				assert.Nil(t, result.Disclosure)
+			}
+		})
+	}
+}
+
+func TestProjectService_CreateProjectFromTemplate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		getErr     error
+		applyErr   error
+		errorIs    error
+		errorMsg   string
+		wantCreate bool
+	}{
+		{name: "success: project gets the template's settings", wantCreate: true},
+		{name: "failure: template not found", getErr: project.ErrTemplateNotFound, errorIs: project.ErrTemplateNotFound},
+		{
+			name:       "failure: apply error",
+			applyErr:   errors.New("database error"),
+			errorMsg:   "failed to apply template",
+			wantCreate: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &project.RepositoryMock{
+				GetTemplateFunc: func(ctx context.Context, templateID, userID string) (*project.Template, error) {
+					assert.Equal(t, "template-1", templateID)
+					assert.Equal(t, "user-1", userID)
+					if tc.getErr != nil {
+						return nil, tc.getErr
+					}
+					return &project.Template{ID: templateID, Name: "Condo"}, nil
+				},
+				CreateProjectFunc: func(ctx context.Context, p *project.Project, userID string) (*project.Project, error) {
+					return &project.Project{ID: "project-1", Name: p.Name, UserID: userID}, nil
+				},
+				ApplyTemplateFunc: func(ctx context.Context, projectID, templateID string) error {
+					assert.Equal(t, "project-1", projectID)
+					assert.Equal(t, "template-1", templateID)
+					return tc.applyErr
+				},
+			}
+
+			svc := project.NewDefaultService(repo, nil)
+			result, err := svc.CreateProjectFromTemplate(
+				context.Background(), &project.CreateRequest{Name: "12 Elm St", UserID: "user-1"}, "template-1")
+
+			assert.Equal(t, tc.wantCreate, len(repo.CreateProjectCalls()) == 1)
+			switch {
+			case tc.errorIs != nil:
+				assert.ErrorIs(t, err, tc.errorIs)
+				return
+			case tc.errorMsg != "":
+				assert.ErrorContains(t, err, tc.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "12 Elm St", result.Name)
+			assert.Len(t, repo.ApplyTemplateCalls(), 1)
+		})
+	}
+}
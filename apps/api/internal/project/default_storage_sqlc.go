@@ -2,13 +2,17 @@ package project
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 
+	"github.com/real-staging-ai/api/internal/geocode"
 	"github.com/real-staging-ai/api/internal/storage"
 	"github.com/real-staging-ai/api/internal/storage/queries"
 )
@@ -150,20 +154,69 @@ func (s *DefaultStorageSQLc) GetProjectByID(ctx context.Context, projectID strin
 
 // GetProjectByIDAndUserID retrieves a specific project by its ID and user ID.
 func (s *DefaultStorageSQLc) GetProjectByIDAndUserID(ctx context.Context, projectID, userID string) (*Project, error) {
-	// For now, we'll get the p and check the user ID manually
-	// This could be optimized with a dedicated query if needed
-	p, err := s.GetProjectByID(ctx, projectID)
+	projectUUID, err := uuid.Parse(projectID)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid project ID format: %w", err)
 	}
 
-	if p.UserID != userID {
-		return nil, pgx.ErrNoRows // Project not found for this user
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	result, err := s.queries.GetProjectByIDAndUserID(ctx, queries.GetProjectByIDAndUserIDParams{
+		ID:     pgtype.UUID{Bytes: projectUUID, Valid: true},
+		UserID: pgtype.UUID{Bytes: userUUID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		return nil, fmt.Errorf("unable to get project by ID and user ID: %w", err)
+	}
+
+	p := &Project{
+		ID:        uuid.UUID(result.ID.Bytes).String(),
+		Name:      result.Name,
+		UserID:    uuid.UUID(result.UserID.Bytes).String(),
+		CreatedAt: result.CreatedAt.Time,
 	}
 
 	return p, nil
 }
 
+// GetProjectForMember retrieves a project the user owns or collaborates on, with the user's role.
+func (s *DefaultStorageSQLc) GetProjectForMember(ctx context.Context, projectID, userID string) (*Project, error) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID format: %w", err)
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	result, err := s.queries.GetProjectByIDForMember(ctx, queries.GetProjectByIDForMemberParams{
+		ID:     pgtype.UUID{Bytes: projectUUID, Valid: true},
+		UserID: pgtype.UUID{Bytes: userUUID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		return nil, fmt.Errorf("unable to get project for member: %w", err)
+	}
+
+	return &Project{
+		ID:        uuid.UUID(result.ID.Bytes).String(),
+		Name:      result.Name,
+		UserID:    uuid.UUID(result.UserID.Bytes).String(),
+		CreatedAt: result.CreatedAt.Time,
+		Role:      result.Role,
+	}, nil
+}
+
 // UpdateProject updates an existing project's name.
 // TODO: Add user_id filtering when auth middleware is implemented.
 func (s *DefaultStorageSQLc) UpdateProject(ctx context.Context, projectID, name string) (*Project, error) {
@@ -265,15 +318,11 @@ func (s *DefaultStorageSQLc) DeleteProject(ctx context.Context, projectID string
 		return fmt.Errorf("failed to convert project ID to pgtype.UUID: %w", err)
 	}
 
-	err = s.queries.DeleteProject(ctx, projectUUIDType)
+	_, err = s.queries.DeleteProject(ctx, projectUUIDType)
 	if err != nil {
 		return fmt.Errorf("unable to delete project: %w", err)
 	}
 
-	// Note: sqlc's :exec queries don't return the number of affected rows
-	// We would need to check if the project exists first if we want to return pgx.ErrNoRows
-	// For now, we'll assume the delete was successful
-
 	return nil
 }
 
@@ -306,7 +355,7 @@ func (s *DefaultStorageSQLc) DeleteProjectByUserID(ctx context.Context, projectI
 		UserID: userUUIDType,
 	}
 
-	err = s.queries.DeleteProjectByUserID(ctx, params)
+	_, err = s.queries.DeleteProjectByUserID(ctx, params)
 	if err != nil {
 		return fmt.Errorf("unable to delete project: %w", err)
 	}
@@ -334,3 +383,674 @@ func (s *DefaultStorageSQLc) CountProjectsByUserID(ctx context.Context, userID s
 
 	return count, nil
 }
+
+// ListProjectActivity returns a page of a project's activity timeline, newest first.
+func (s *DefaultStorageSQLc) ListProjectActivity(
+	ctx context.Context, projectID string, limit, offset int32,
+) ([]ActivityEvent, error) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID format: %w", err)
+	}
+
+	rows, err := s.queries.ListProjectActivity(ctx, queries.ListProjectActivityParams{
+		ProjectID: pgtype.UUID{Bytes: projectUUID, Valid: true},
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list project activity: %w", err)
+	}
+
+	events := make([]ActivityEvent, 0, len(rows))
+	for _, row := range rows {
+		ev := ActivityEvent{
+			ID:        row.ID,
+			ProjectID: uuid.UUID(row.ProjectID.Bytes).String(),
+			Type:      row.EventType,
+			Metadata:  row.Metadata,
+			CreatedAt: row.CreatedAt.Time,
+		}
+		if row.ImageID.Valid {
+			id := uuid.UUID(row.ImageID.Bytes).String()
+			ev.ImageID = &id
+		}
+		events = append(events, ev)
+	}
+
+	return events, nil
+}
+
+// GetProjectSummary returns the project's image counts, storage use and last activity.
+func (s *DefaultStorageSQLc) GetProjectSummary(ctx context.Context, projectID string) (*Summary, error) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID format: %w", err)
+	}
+
+	row, err := s.queries.GetProjectSummary(ctx, pgtype.UUID{Bytes: projectUUID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get project summary: %w", err)
+	}
+
+	return &Summary{
+		ProjectID:   projectID,
+		TotalImages: row.TotalImages,
+		StatusCounts: statusCounts(
+			row.QueuedCount, row.ProcessingCount, row.ReadyCount, row.ErrorCount, row.CanceledCount,
+		),
+		StorageBytes:   row.StorageBytes,
+		LastActivityAt: row.LastActivityAt.Time,
+		Thumbnails:     []Thumbnail{},
+	}, nil
+}
+
+// ListProjectThumbnails returns up to limit of the project's most recent images, newest first.
+func (s *DefaultStorageSQLc) ListProjectThumbnails(
+	ctx context.Context, projectID string, limit int32,
+) ([]Thumbnail, error) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID format: %w", err)
+	}
+
+	rows, err := s.queries.ListProjectThumbnails(ctx, queries.ListProjectThumbnailsParams{
+		ProjectID: pgtype.UUID{Bytes: projectUUID, Valid: true},
+		Limit:     limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list project thumbnails: %w", err)
+	}
+
+	thumbnails := make([]Thumbnail, 0, len(rows))
+	for _, row := range rows {
+		thumbnails = append(thumbnails, newThumbnail(
+			uuid.UUID(row.ID.Bytes).String(), string(row.Status), row.OriginalUrl, row.StagedUrl.String,
+		))
+	}
+
+	return thumbnails, nil
+}
+
+// ListSummariesByUser returns the summaries of those projectIDs the user owns, each with
+// up to SummaryThumbnailLimit thumbnails, from a single query. Order is unspecified.
+func (s *DefaultStorageSQLc) ListSummariesByUser(
+	ctx context.Context, projectIDs []string, userID string,
+) ([]Summary, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	ids := make([]pgtype.UUID, 0, len(projectIDs))
+	for _, projectID := range projectIDs {
+		projectUUID, err := uuid.Parse(projectID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid project ID format: %w", err)
+		}
+		ids = append(ids, pgtype.UUID{Bytes: projectUUID, Valid: true})
+	}
+
+	rows, err := s.queries.ListProjectSummariesByUser(ctx, queries.ListProjectSummariesByUserParams{
+		ThumbnailLimit: SummaryThumbnailLimit,
+		ProjectIds:     ids,
+		UserID:         pgtype.UUID{Bytes: userUUID, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list project summaries: %w", err)
+	}
+
+	summaries := make([]Summary, 0, len(rows))
+	for _, row := range rows {
+		var images []struct {
+			ID          string  `json:"id"`
+			Status      string  `json:"status"`
+			OriginalURL string  `json:"original_url"`
+			StagedURL   *string `json:"staged_url"`
+		}
+		if err := json.Unmarshal(row.Thumbnails, &images); err != nil {
+			return nil, fmt.Errorf("unable to decode project thumbnails: %w", err)
+		}
+		thumbnails := make([]Thumbnail, 0, len(images))
+		for _, img := range images {
+			var staged string
+			if img.StagedURL != nil {
+				staged = *img.StagedURL
+			}
+			thumbnails = append(thumbnails, newThumbnail(img.ID, img.Status, img.OriginalURL, staged))
+		}
+
+		summaries = append(summaries, Summary{
+			ProjectID:   uuid.UUID(row.ProjectID.Bytes).String(),
+			TotalImages: row.TotalImages,
+			StatusCounts: statusCounts(
+				row.QueuedCount, row.ProcessingCount, row.ReadyCount, row.ErrorCount, row.CanceledCount,
+			),
+			StorageBytes:   row.StorageBytes,
+			LastActivityAt: row.LastActivityAt.Time,
+			Thumbnails:     thumbnails,
+		})
+	}
+
+	return summaries, nil
+}
+
+// statusCounts keys image counts by status, keeping the zeros.
+func statusCounts(queued, processing, ready, errored, canceled int64) map[string]int64 {
+	return map[string]int64{
+		string(queries.ImageStatusQueued):     queued,
+		string(queries.ImageStatusProcessing): processing,
+		string(queries.ImageStatusReady):      ready,
+		string(queries.ImageStatusError):      errored,
+		string(queries.ImageStatusCanceled):   canceled,
+	}
+}
+
+// newThumbnail previews an image by its staged output when there is one, otherwise by
+// its original.
+func newThumbnail(imageID, status, originalURL, stagedURL string) Thumbnail {
+	t := Thumbnail{ImageID: imageID, Status: status, storedURL: originalURL}
+	if stagedURL != "" {
+		t.storedURL = stagedURL
+	}
+	return t
+}
+
+// IsRetentionExempt reports whether the project is excluded from retention purging.
+func (s *DefaultStorageSQLc) IsRetentionExempt(ctx context.Context, projectID string) (bool, error) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return false, fmt.Errorf("invalid project ID format: %w", err)
+	}
+
+	exempt, err := s.queries.IsProjectRetentionExempt(ctx, pgtype.UUID{Bytes: projectUUID, Valid: true})
+	if err != nil {
+		return false, fmt.Errorf("unable to get project retention exemption: %w", err)
+	}
+	return exempt, nil
+}
+
+// SetRetentionExempt adds or removes the project's retention exemption.
+func (s *DefaultStorageSQLc) SetRetentionExempt(ctx context.Context, projectID string, exempt bool) error {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return fmt.Errorf("invalid project ID format: %w", err)
+	}
+
+	id := pgtype.UUID{Bytes: projectUUID, Valid: true}
+	if exempt {
+		err = s.queries.AddProjectRetentionExemption(ctx, id)
+	} else {
+		err = s.queries.RemoveProjectRetentionExemption(ctx, id)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to update project retention exemption: %w", err)
+	}
+	return nil
+}
+
+// IsUnderLegalHold reports whether the project or any of its images is under legal hold.
+func (s *DefaultStorageSQLc) IsUnderLegalHold(ctx context.Context, projectID string) (bool, error) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return false, fmt.Errorf("invalid project ID format: %w", err)
+	}
+
+	held, err := s.queries.IsProjectUnderLegalHold(ctx, pgtype.UUID{Bytes: projectUUID, Valid: true})
+	if err != nil {
+		return false, fmt.Errorf("unable to get project legal hold: %w", err)
+	}
+	return held, nil
+}
+
+// SaveDeletionIntent stores the project's pending deletion, replacing any earlier one.
+func (s *DefaultStorageSQLc) SaveDeletionIntent(
+	ctx context.Context, projectID, userID string, tokenHash []byte, expiresAt time.Time,
+) error {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return fmt.Errorf("invalid project ID format: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	err = s.queries.UpsertProjectDeletionIntent(ctx, queries.UpsertProjectDeletionIntentParams{
+		ProjectID: pgtype.UUID{Bytes: projectUUID, Valid: true},
+		UserID:    pgtype.UUID{Bytes: userUUID, Valid: true},
+		TokenHash: tokenHash,
+		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to save deletion intent: %w", err)
+	}
+	return nil
+}
+
+// ConsumeDeletionIntent removes the project's matching, unexpired pending deletion.
+func (s *DefaultStorageSQLc) ConsumeDeletionIntent(
+	ctx context.Context, projectID, userID string, tokenHash []byte,
+) (bool, error) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return false, fmt.Errorf("invalid project ID format: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return false, fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	n, err := s.queries.ConsumeProjectDeletionIntent(ctx, queries.ConsumeProjectDeletionIntentParams{
+		ProjectID: pgtype.UUID{Bytes: projectUUID, Valid: true},
+		UserID:    pgtype.UUID{Bytes: userUUID, Valid: true},
+		TokenHash: tokenHash,
+	})
+	if err != nil {
+		return false, fmt.Errorf("unable to consume deletion intent: %w", err)
+	}
+	return n > 0, nil
+}
+
+// GetDisclosure returns the project's disclosure banner settings, or the defaults if none are saved.
+func (s *DefaultStorageSQLc) GetDisclosure(ctx context.Context, projectID string) (*Disclosure, error) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID format: %w", err)
+	}
+
+	row, err := s.queries.GetProjectDisclosure(ctx, pgtype.UUID{Bytes: projectUUID, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return DefaultDisclosure(projectID), nil
+		}
+		return nil, fmt.Errorf("unable to get project disclosure: %w", err)
+	}
+	return disclosureFromRow(projectID, row), nil
+}
+
+// SaveDisclosure creates or replaces the project's disclosure banner settings.
+func (s *DefaultStorageSQLc) SaveDisclosure(ctx context.Context, d *Disclosure) (*Disclosure, error) {
+	projectUUID, err := uuid.Parse(d.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID format: %w", err)
+	}
+
+	var text pgtype.Text
+	if d.Text != nil {
+		text = pgtype.Text{String: *d.Text, Valid: true}
+	}
+	row, err := s.queries.UpsertProjectDisclosure(ctx, queries.UpsertProjectDisclosureParams{
+		ProjectID: pgtype.UUID{Bytes: projectUUID, Valid: true},
+		Enabled:   d.Enabled,
+		Locale:    d.Locale,
+		Text:      text,
+		Position:  d.Position,
+		Opacity:   d.Opacity,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to save project disclosure: %w", err)
+	}
+	return disclosureFromRow(d.ProjectID, row), nil
+}
+
+func disclosureFromRow(projectID string, row *queries.ProjectDisclosure) *Disclosure {
+	d := &Disclosure{
+		ProjectID: projectID,
+		Enabled:   row.Enabled,
+		Locale:    row.Locale,
+		Position:  row.Position,
+		Opacity:   row.Opacity,
+	}
+	if row.Text.Valid {
+		d.Text = &row.Text.String
+	}
+	if row.UpdatedAt.Valid {
+		d.UpdatedAt = &row.UpdatedAt.Time
+	}
+	return d
+}
+
+// GetListing returns the project's listing, or an empty one if none is saved.
+func (s *DefaultStorageSQLc) GetListing(ctx context.Context, projectID string) (*Listing, error) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID format: %w", err)
+	}
+
+	row, err := s.queries.GetProjectListing(ctx, pgtype.UUID{Bytes: projectUUID, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &Listing{ProjectID: projectID}, nil
+		}
+		return nil, fmt.Errorf("unable to get project listing: %w", err)
+	}
+	return listingFromRow(projectID, row), nil
+}
+
+// SaveListing creates or replaces the project's listing.
+func (s *DefaultStorageSQLc) SaveListing(ctx context.Context, l *Listing) (*Listing, error) {
+	projectUUID, err := uuid.Parse(l.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID format: %w", err)
+	}
+
+	params := queries.UpsertProjectListingParams{
+		ProjectID:    pgtype.UUID{Bytes: projectUUID, Valid: true},
+		AddressLine1: l.Address.Line1,
+		AddressLine2: l.Address.Line2,
+		City:         l.Address.City,
+		Region:       l.Address.Region,
+		PostalCode:   l.Address.PostalCode,
+		Country:      l.Address.Country,
+		MlsNumber:    l.MLSNumber,
+		ListingUrl:   l.ListingURL,
+	}
+	if l.Location != nil {
+		params.Latitude = pgtype.Float8{Float64: l.Location.Latitude, Valid: true}
+		params.Longitude = pgtype.Float8{Float64: l.Location.Longitude, Valid: true}
+	}
+	if l.GeocodedAt != nil {
+		params.GeocodedAt = pgtype.Timestamptz{Time: *l.GeocodedAt, Valid: true}
+	}
+	row, err := s.queries.UpsertProjectListing(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("unable to save project listing: %w", err)
+	}
+	return listingFromRow(l.ProjectID, row), nil
+}
+
+func listingFromRow(projectID string, row *queries.ProjectListing) *Listing {
+	l := &Listing{
+		ProjectID: projectID,
+		Address: geocode.Address{
+			Line1:      row.AddressLine1,
+			Line2:      row.AddressLine2,
+			City:       row.City,
+			Region:     row.Region,
+			PostalCode: row.PostalCode,
+			Country:    row.Country,
+		},
+		MLSNumber:  row.MlsNumber,
+		ListingURL: row.ListingUrl,
+	}
+	if row.Latitude.Valid && row.Longitude.Valid {
+		l.Location = &geocode.Location{Latitude: row.Latitude.Float64, Longitude: row.Longitude.Float64}
+	}
+	if row.GeocodedAt.Valid {
+		l.GeocodedAt = &row.GeocodedAt.Time
+	}
+	if row.UpdatedAt.Valid {
+		l.UpdatedAt = &row.UpdatedAt.Time
+	}
+	return l
+}
+
+// uniqueViolation is the Postgres error code raised when a template name is already taken.
+const uniqueViolation = "23505"
+
+// CreateTemplate saves t as one of the user's templates.
+func (s *DefaultStorageSQLc) CreateTemplate(ctx context.Context, userID string, t *Template) (*Template, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	var disclosure []byte
+	if t.Disclosure != nil {
+		if disclosure, err = json.Marshal(t.Disclosure); err != nil {
+			return nil, fmt.Errorf("unable to encode template disclosure: %w", err)
+		}
+	}
+	rooms := t.Rooms
+	if rooms == nil {
+		rooms = []Room{}
+	}
+	roomsJSON, err := json.Marshal(rooms)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode template rooms: %w", err)
+	}
+
+	row, err := s.queries.CreateProjectTemplate(ctx, queries.CreateProjectTemplateParams{
+		UserID:     pgtype.UUID{Bytes: userUUID, Valid: true},
+		Name:       t.Name,
+		Disclosure: disclosure,
+		Rooms:      roomsJSON,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return nil, ErrTemplateNameTaken
+		}
+		return nil, fmt.Errorf("unable to create project template: %w", err)
+	}
+	return templateFromRow(row)
+}
+
+// CountTemplates returns how many templates the user has.
+func (s *DefaultStorageSQLc) CountTemplates(ctx context.Context, userID string) (int64, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	n, err := s.queries.CountProjectTemplates(ctx, pgtype.UUID{Bytes: userUUID, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("unable to count project templates: %w", err)
+	}
+	return n, nil
+}
+
+// GetTemplate returns one of the user's templates, or ErrTemplateNotFound.
+func (s *DefaultStorageSQLc) GetTemplate(ctx context.Context, templateID, userID string) (*Template, error) {
+	templateUUID, err := uuid.Parse(templateID)
+	if err != nil {
+		return nil, ErrTemplateNotFound
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	row, err := s.queries.GetProjectTemplate(ctx, queries.GetProjectTemplateParams{
+		ID:     pgtype.UUID{Bytes: templateUUID, Valid: true},
+		UserID: pgtype.UUID{Bytes: userUUID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, fmt.Errorf("unable to get project template: %w", err)
+	}
+	return templateFromRow(row)
+}
+
+// ListTemplates returns the user's templates ordered by name.
+func (s *DefaultStorageSQLc) ListTemplates(ctx context.Context, userID string) ([]Template, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	rows, err := s.queries.ListProjectTemplates(ctx, pgtype.UUID{Bytes: userUUID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list project templates: %w", err)
+	}
+	templates := make([]Template, 0, len(rows))
+	for _, row := range rows {
+		t, err := templateFromRow(row)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, *t)
+	}
+	return templates, nil
+}
+
+// DeleteTemplate deletes one of the user's templates, or returns ErrTemplateNotFound.
+func (s *DefaultStorageSQLc) DeleteTemplate(ctx context.Context, templateID, userID string) error {
+	templateUUID, err := uuid.Parse(templateID)
+	if err != nil {
+		return ErrTemplateNotFound
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	n, err := s.queries.DeleteProjectTemplate(ctx, queries.DeleteProjectTemplateParams{
+		ID:     pgtype.UUID{Bytes: templateUUID, Valid: true},
+		UserID: pgtype.UUID{Bytes: userUUID, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to delete project template: %w", err)
+	}
+	if n == 0 {
+		return ErrTemplateNotFound
+	}
+	return nil
+}
+
+// ApplyTemplate copies the template's disclosure settings and room checklist to the project.
+func (s *DefaultStorageSQLc) ApplyTemplate(ctx context.Context, projectID, templateID string) error {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return fmt.Errorf("invalid project ID format: %w", err)
+	}
+	templateUUID, err := uuid.Parse(templateID)
+	if err != nil {
+		return fmt.Errorf("invalid template ID format: %w", err)
+	}
+
+	project := pgtype.UUID{Bytes: projectUUID, Valid: true}
+	template := pgtype.UUID{Bytes: templateUUID, Valid: true}
+	if err := s.queries.ApplyProjectTemplateDisclosure(ctx, queries.ApplyProjectTemplateDisclosureParams{
+		ProjectID:  project,
+		TemplateID: template,
+	}); err != nil {
+		return fmt.Errorf("unable to apply template disclosure: %w", err)
+	}
+	if err := s.queries.ApplyProjectTemplateRooms(ctx, queries.ApplyProjectTemplateRoomsParams{
+		ProjectID:  project,
+		TemplateID: template,
+	}); err != nil {
+		return fmt.Errorf("unable to apply template rooms: %w", err)
+	}
+	return nil
+}
+
+// ListRooms returns the project's room checklist.
+func (s *DefaultStorageSQLc) ListRooms(ctx context.Context, projectID string) ([]Room, error) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID format: %w", err)
+	}
+
+	rows, err := s.queries.ListProjectRooms(ctx, pgtype.UUID{Bytes: projectUUID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list project rooms: %w", err)
+	}
+	rooms := make([]Room, 0, len(rows))
+	for _, row := range rows {
+		r := Room{RoomType: row.RoomType, Label: row.Label}
+		if row.Style.Valid {
+			r.Style = &row.Style.String
+		}
+		rooms = append(rooms, r)
+	}
+	return rooms, nil
+}
+
+// SaveRooms replaces the project's room checklist with rooms. Run it in a transaction so the
+// checklist is never left empty by a failed insert.
+func (s *DefaultStorageSQLc) SaveRooms(ctx context.Context, projectID string, rooms []Room) error {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return fmt.Errorf("invalid project ID format: %w", err)
+	}
+
+	project := pgtype.UUID{Bytes: projectUUID, Valid: true}
+	if err := s.queries.DeleteProjectRooms(ctx, project); err != nil {
+		return fmt.Errorf("unable to clear project rooms: %w", err)
+	}
+	if len(rooms) == 0 {
+		return nil
+	}
+
+	params := queries.CreateProjectRoomsParams{
+		ProjectID: project,
+		RoomTypes: make([]string, len(rooms)),
+		Styles:    make([]string, len(rooms)),
+		Labels:    make([]string, len(rooms)),
+	}
+	for i, r := range rooms {
+		params.RoomTypes[i] = r.RoomType
+		params.Labels[i] = r.Label
+		if r.Style != nil {
+			params.Styles[i] = *r.Style
+		}
+	}
+	if err := s.queries.CreateProjectRooms(ctx, params); err != nil {
+		return fmt.Errorf("unable to save project rooms: %w", err)
+	}
+	return nil
+}
+
+// CountImagesByRoomType returns how many of the project's images are of each room type, and
+// how many of those are staged.
+func (s *DefaultStorageSQLc) CountImagesByRoomType(ctx context.Context, projectID string) ([]RoomImageCount, error) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID format: %w", err)
+	}
+
+	rows, err := s.queries.CountProjectImagesByRoomType(ctx, pgtype.UUID{Bytes: projectUUID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("unable to count project images by room type: %w", err)
+	}
+	counts := make([]RoomImageCount, 0, len(rows))
+	for _, row := range rows {
+		counts = append(counts, RoomImageCount{RoomType: row.RoomType, Uploaded: row.Uploaded, Staged: row.Staged})
+	}
+	return counts, nil
+}
+
+// ListImageRooms returns a room for each room type among the project's images, in the order
+// they were first uploaded, styled as most of its images were.
+func (s *DefaultStorageSQLc) ListImageRooms(ctx context.Context, projectID string) ([]Room, error) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID format: %w", err)
+	}
+
+	rows, err := s.queries.ListProjectImageRooms(ctx, pgtype.UUID{Bytes: projectUUID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list project image rooms: %w", err)
+	}
+	rooms := make([]Room, 0, len(rows))
+	for _, row := range rows {
+		r := Room{RoomType: row.RoomType}
+		if row.Style != "" {
+			r.Style = &row.Style
+		}
+		rooms = append(rooms, r)
+	}
+	return rooms, nil
+}
+
+func templateFromRow(row *queries.ProjectTemplate) (*Template, error) {
+	t := &Template{
+		ID:        uuid.UUID(row.ID.Bytes).String(),
+		Name:      row.Name,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+	if row.Disclosure != nil {
+		if err := json.Unmarshal(row.Disclosure, &t.Disclosure); err != nil {
+			return nil, fmt.Errorf("unable to decode template disclosure: %w", err)
+		}
+	}
+	if err := json.Unmarshal(row.Rooms, &t.Rooms); err != nil {
+		return nil, fmt.Errorf("unable to decode template rooms: %w", err)
+	}
+	return t, nil
+}
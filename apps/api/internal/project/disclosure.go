@@ -0,0 +1,120 @@
+package project
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Disclosure banner positions.
+const (
+	DisclosurePositionTopLeft      = "top_left"
+	DisclosurePositionTopCenter    = "top_center"
+	DisclosurePositionTopRight     = "top_right"
+	DisclosurePositionBottomLeft   = "bottom_left"
+	DisclosurePositionBottomCenter = "bottom_center"
+	DisclosurePositionBottomRight  = "bottom_right"
+)
+
+// MaxDisclosureTextLength is the longest custom banner text accepted.
+const MaxDisclosureTextLength = 80
+
+// DisclosurePositions lists the valid banner positions.
+var DisclosurePositions = []string{
+	DisclosurePositionTopLeft, DisclosurePositionTopCenter, DisclosurePositionTopRight,
+	DisclosurePositionBottomLeft, DisclosurePositionBottomCenter, DisclosurePositionBottomRight,
+}
+
+// DisclosureLocales lists the languages the worker has default banner text for.
+// Regional variants such as es-MX use their base language.
+var DisclosureLocales = []string{"de", "en", "es", "fr", "it", "pt"}
+
+// Disclosure configures the "virtually staged" banner rendered onto a project's staged images.
+type Disclosure struct {
+	ProjectID string `json:"project_id"`
+	Enabled   bool   `json:"enabled"`
+	Locale    string `json:"locale"`
+	// Text overrides the locale's default banner text when set.
+	Text      *string    `json:"text"`
+	Position  string     `json:"position"`
+	Opacity   float32    `json:"opacity"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// DefaultDisclosure returns the settings used for projects that have never configured a banner.
+func DefaultDisclosure(projectID string) *Disclosure {
+	return &Disclosure{
+		ProjectID: projectID,
+		Locale:    "en",
+		Position:  DisclosurePositionBottomRight,
+		Opacity:   0.6,
+	}
+}
+
+// DisclosureRequest updates a project's disclosure banner. Omitted fields keep their current value;
+// an empty text clears the custom text.
+type DisclosureRequest struct {
+	Enabled  *bool    `json:"enabled"`
+	Locale   *string  `json:"locale"`
+	Text     *string  `json:"text"`
+	Position *string  `json:"position"`
+	Opacity  *float32 `json:"opacity"`
+}
+
+// Apply validates the request and merges it onto d.
+func (r *DisclosureRequest) Apply(d *Disclosure) []ValidationErrorDetail {
+	var errs []ValidationErrorDetail
+
+	if r.Locale != nil {
+		locale := strings.ToLower(strings.TrimSpace(*r.Locale))
+		base, _, _ := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+		if slices.Contains(DisclosureLocales, base) {
+			d.Locale = locale
+		} else {
+			errs = append(errs, ValidationErrorDetail{
+				Field:   "locale",
+				Message: "locale must be one of " + strings.Join(DisclosureLocales, ", "),
+			})
+		}
+	}
+	if r.Text != nil {
+		text := strings.TrimSpace(*r.Text)
+		switch {
+		case text == "":
+			d.Text = nil
+		case utf8.RuneCountInString(text) > MaxDisclosureTextLength:
+			errs = append(errs, ValidationErrorDetail{
+				Field:   "text",
+				Message: "text must be 80 characters or fewer",
+			})
+		default:
+			d.Text = &text
+		}
+	}
+	if r.Position != nil {
+		if slices.Contains(DisclosurePositions, *r.Position) {
+			d.Position = *r.Position
+		} else {
+			errs = append(errs, ValidationErrorDetail{
+				Field:   "position",
+				Message: "position must be one of " + strings.Join(DisclosurePositions, ", "),
+			})
+		}
+	}
+	if r.Opacity != nil {
+		if *r.Opacity >= 0 && *r.Opacity <= 1 {
+			d.Opacity = *r.Opacity
+		} else {
+			errs = append(errs, ValidationErrorDetail{
+				Field:   "opacity",
+				Message: "opacity must be between 0 and 1",
+			})
+		}
+	}
+	if r.Enabled != nil {
+		d.Enabled = *r.Enabled
+	}
+
+	return errs
+}
@@ -0,0 +1,139 @@
+package project
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/golden"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// Fixed values keep golden responses stable between runs.
+var (
+	goldenUserID     = uuid.MustParse("11111111-1111-4111-8111-111111111111")
+	goldenProjectIDs = []uuid.UUID{
+		uuid.MustParse("22222222-2222-4222-8222-222222222221"),
+		uuid.MustParse("22222222-2222-4222-8222-222222222222"),
+	}
+	goldenTime = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+)
+
+// newGoldenListDB resolves the caller to goldenUserID and lists projects from pool.
+func newGoldenListDB(pool pgxmock.PgxPoolIface) *storage.DatabaseMock {
+	return &storage.DatabaseMock{
+		QueryRowFunc: func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+			return fakeRow{scan: func(dest ...any) error {
+				if !strings.Contains(sql, "FROM users") {
+					return pgx.ErrNoRows
+				}
+				*(dest[0].(*pgtype.UUID)) = pgtype.UUID{Bytes: goldenUserID, Valid: true}
+				*(dest[1].(*string)) = args[0].(string)
+				*(dest[3].(*string)) = "user"
+				*(dest[4].(*pgtype.Timestamptz)) = pgtype.Timestamptz{Time: goldenTime, Valid: true}
+				return nil
+			}}
+		},
+		QueryFunc: pool.Query,
+	}
+}
+
+func TestDefaultHandler_Golden(t *testing.T) {
+	projectColumns := []string{"id", "name", "user_id", "created_at"}
+	userPg := pgtype.UUID{Bytes: goldenUserID, Valid: true}
+
+	cases := []struct {
+		name      string
+		golden    string
+		method    string
+		body      string
+		setupRows func(mock pgxmock.PgxPoolIface)
+		noDB      bool
+		handle    func(h *DefaultHandler, c echo.Context) error
+	}{
+		{
+			name:   "success: project list",
+			golden: "project_list",
+			method: http.MethodGet,
+			setupRows: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("FROM projects").
+					WithArgs(userPg).
+					WillReturnRows(pgxmock.NewRows(projectColumns).
+						AddRow(pgtype.UUID{Bytes: goldenProjectIDs[0], Valid: true}, "Maple Street", userPg,
+							pgtype.Timestamptz{Time: goldenTime, Valid: true}).
+						AddRow(pgtype.UUID{Bytes: goldenProjectIDs[1], Valid: true}, "Harbor Loft", userPg,
+							pgtype.Timestamptz{Time: goldenTime.Add(time.Hour), Valid: true}))
+			},
+			handle: (*DefaultHandler).List,
+		},
+		{
+			name:   "success: empty project list is an empty array",
+			golden: "project_list_empty",
+			method: http.MethodGet,
+			setupRows: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("FROM projects").
+					WithArgs(userPg).
+					WillReturnRows(pgxmock.NewRows(projectColumns))
+			},
+			handle: (*DefaultHandler).List,
+		},
+		{
+			name:   "fail: unauthorized error envelope",
+			golden: "error_unauthorized",
+			method: http.MethodGet,
+			noDB:   true,
+			handle: (*DefaultHandler).List,
+		},
+		{
+			name:   "fail: bad request error envelope",
+			golden: "error_bad_request",
+			method: http.MethodPost,
+			body:   `{"name": "missing-quote}`,
+			handle: (*DefaultHandler).Create,
+		},
+		{
+			name:   "fail: validation error envelope",
+			golden: "error_validation",
+			method: http.MethodPost,
+			body:   `{"name": ""}`,
+			handle: (*DefaultHandler).Create,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+			if tc.setupRows != nil {
+				tc.setupRows(mock)
+			}
+
+			h := NewDefaultHandler(newGoldenListDB(mock), nil, nil)
+			if tc.noDB {
+				h = NewDefaultHandler(nil, nil, nil)
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(tc.method, "/api/v1/projects", bytes.NewBufferString(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("X-Test-User", "auth0|testuser")
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, tc.handle(h, e.NewContext(req, rec)))
+			require.NoError(t, mock.ExpectationsWereMet())
+			golden.AssertJSON(t, tc.golden, rec.Code, rec.Body.Bytes())
+		})
+	}
+}
@@ -14,4 +14,19 @@ type Handler interface {
 	GetByID(c echo.Context) error
 	Update(c echo.Context) error
 	Delete(c echo.Context) error
+	Activity(c echo.Context) error
+	Summary(c echo.Context) error
+	Summaries(c echo.Context) error
+	GetRetention(c echo.Context) error
+	UpdateRetention(c echo.Context) error
+	GetDisclosure(c echo.Context) error
+	UpdateDisclosure(c echo.Context) error
+	GetListing(c echo.Context) error
+	UpdateListing(c echo.Context) error
+	Rooms(c echo.Context) error
+	UpdateRooms(c echo.Context) error
+	SaveTemplate(c echo.Context) error
+	ListTemplates(c echo.Context) error
+	GetTemplate(c echo.Context) error
+	DeleteTemplate(c echo.Context) error
 }
@@ -18,21 +18,66 @@ var _ Handler = &HandlerMock{}
 //
 //		// make and configure a mocked Handler
 //		mockedHandler := &HandlerMock{
+//			ActivityFunc: func(c echo.Context) error {
+//				panic("mock out the Activity method")
+//			},
 //			CreateFunc: func(c echo.Context) error {
 //				panic("mock out the Create method")
 //			},
 //			DeleteFunc: func(c echo.Context) error {
 //				panic("mock out the Delete method")
 //			},
+//			DeleteTemplateFunc: func(c echo.Context) error {
+//				panic("mock out the DeleteTemplate method")
+//			},
 //			GetByIDFunc: func(c echo.Context) error {
 //				panic("mock out the GetByID method")
 //			},
+//			GetDisclosureFunc: func(c echo.Context) error {
+//				panic("mock out the GetDisclosure method")
+//			},
+//			GetListingFunc: func(c echo.Context) error {
+//				panic("mock out the GetListing method")
+//			},
+//			GetRetentionFunc: func(c echo.Context) error {
+//				panic("mock out the GetRetention method")
+//			},
+//			GetTemplateFunc: func(c echo.Context) error {
+//				panic("mock out the GetTemplate method")
+//			},
 //			ListFunc: func(c echo.Context) error {
 //				panic("mock out the List method")
 //			},
+//			ListTemplatesFunc: func(c echo.Context) error {
+//				panic("mock out the ListTemplates method")
+//			},
+//			RoomsFunc: func(c echo.Context) error {
+//				panic("mock out the Rooms method")
+//			},
+//			SaveTemplateFunc: func(c echo.Context) error {
+//				panic("mock out the SaveTemplate method")
+//			},
+//			SummariesFunc: func(c echo.Context) error {
+//				panic("mock out the Summaries method")
+//			},
+//			SummaryFunc: func(c echo.Context) error {
+//				panic("mock out the Summary method")
+//			},
 //			UpdateFunc: func(c echo.Context) error {
 //				panic("mock out the Update method")
 //			},
+//			UpdateDisclosureFunc: func(c echo.Context) error {
+//				panic("mock out the UpdateDisclosure method")
+//			},
+//			UpdateListingFunc: func(c echo.Context) error {
+//				panic("mock out the UpdateListing method")
+//			},
+//			UpdateRetentionFunc: func(c echo.Context) error {
+//				panic("mock out the UpdateRetention method")
+//			},
+//			UpdateRoomsFunc: func(c echo.Context) error {
+//				panic("mock out the UpdateRooms method")
+//			},
 //		}
 //
 //		// use mockedHandler in code that requires Handler
@@ -40,23 +85,73 @@ var _ Handler = &HandlerMock{}
 //
 //	}
 type HandlerMock struct {
+	// ActivityFunc mocks the Activity method.
+	ActivityFunc func(c echo.Context) error
+
 	// CreateFunc mocks the Create method.
 	CreateFunc func(c echo.Context) error
 
 	// DeleteFunc mocks the Delete method.
 	DeleteFunc func(c echo.Context) error
 
+	// DeleteTemplateFunc mocks the DeleteTemplate method.
+	DeleteTemplateFunc func(c echo.Context) error
+
 	// GetByIDFunc mocks the GetByID method.
 	GetByIDFunc func(c echo.Context) error
 
+	// GetDisclosureFunc mocks the GetDisclosure method.
+	GetDisclosureFunc func(c echo.Context) error
+
+	// GetListingFunc mocks the GetListing method.
+	GetListingFunc func(c echo.Context) error
+
+	// GetRetentionFunc mocks the GetRetention method.
+	GetRetentionFunc func(c echo.Context) error
+
+	// GetTemplateFunc mocks the GetTemplate method.
+	GetTemplateFunc func(c echo.Context) error
+
 	// ListFunc mocks the List method.
 	ListFunc func(c echo.Context) error
 
+	// ListTemplatesFunc mocks the ListTemplates method.
+	ListTemplatesFunc func(c echo.Context) error
+
+	// RoomsFunc mocks the Rooms method.
+	RoomsFunc func(c echo.Context) error
+
+	// SaveTemplateFunc mocks the SaveTemplate method.
+	SaveTemplateFunc func(c echo.Context) error
+
+	// SummariesFunc mocks the Summaries method.
+	SummariesFunc func(c echo.Context) error
+
+	// SummaryFunc mocks the Summary method.
+	SummaryFunc func(c echo.Context) error
+
 	// UpdateFunc mocks the Update method.
 	UpdateFunc func(c echo.Context) error
 
+	// UpdateDisclosureFunc mocks the UpdateDisclosure method.
+	UpdateDisclosureFunc func(c echo.Context) error
+
+	// UpdateListingFunc mocks the UpdateListing method.
+	UpdateListingFunc func(c echo.Context) error
+
+	// UpdateRetentionFunc mocks the UpdateRetention method.
+	UpdateRetentionFunc func(c echo.Context) error
+
+	// UpdateRoomsFunc mocks the UpdateRooms method.
+	UpdateRoomsFunc func(c echo.Context) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// Activity holds details about calls to the Activity method.
+		Activity []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
 		// Create holds details about calls to the Create method.
 		Create []struct {
 			// C is the c argument value.
@@ -67,27 +162,144 @@ type HandlerMock struct {
 			// C is the c argument value.
 			C echo.Context
 		}
+		// DeleteTemplate holds details about calls to the DeleteTemplate method.
+		DeleteTemplate []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
 		// GetByID holds details about calls to the GetByID method.
 		GetByID []struct {
 			// C is the c argument value.
 			C echo.Context
 		}
+		// GetDisclosure holds details about calls to the GetDisclosure method.
+		GetDisclosure []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
+		// GetListing holds details about calls to the GetListing method.
+		GetListing []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
+		// GetRetention holds details about calls to the GetRetention method.
+		GetRetention []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
+		// GetTemplate holds details about calls to the GetTemplate method.
+		GetTemplate []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
 		// List holds details about calls to the List method.
 		List []struct {
 			// C is the c argument value.
 			C echo.Context
 		}
+		// ListTemplates holds details about calls to the ListTemplates method.
+		ListTemplates []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
+		// Rooms holds details about calls to the Rooms method.
+		Rooms []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
+		// SaveTemplate holds details about calls to the SaveTemplate method.
+		SaveTemplate []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
+		// Summaries holds details about calls to the Summaries method.
+		Summaries []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
+		// Summary holds details about calls to the Summary method.
+		Summary []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
 		// Update holds details about calls to the Update method.
 		Update []struct {
 			// C is the c argument value.
 			C echo.Context
 		}
+		// UpdateDisclosure holds details about calls to the UpdateDisclosure method.
+		UpdateDisclosure []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
+		// UpdateListing holds details about calls to the UpdateListing method.
+		UpdateListing []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
+		// UpdateRetention holds details about calls to the UpdateRetention method.
+		UpdateRetention []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
+		// UpdateRooms holds details about calls to the UpdateRooms method.
+		UpdateRooms []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
 	}
-	lockCreate  sync.RWMutex
-	lockDelete  sync.RWMutex
-	lockGetByID sync.RWMutex
-	lockList    sync.RWMutex
-	lockUpdate  sync.RWMutex
+	lockActivity         sync.RWMutex
+	lockCreate           sync.RWMutex
+	lockDelete           sync.RWMutex
+	lockDeleteTemplate   sync.RWMutex
+	lockGetByID          sync.RWMutex
+	lockGetDisclosure    sync.RWMutex
+	lockGetListing       sync.RWMutex
+	lockGetRetention     sync.RWMutex
+	lockGetTemplate      sync.RWMutex
+	lockList             sync.RWMutex
+	lockListTemplates    sync.RWMutex
+	lockRooms            sync.RWMutex
+	lockSaveTemplate     sync.RWMutex
+	lockSummaries        sync.RWMutex
+	lockSummary          sync.RWMutex
+	lockUpdate           sync.RWMutex
+	lockUpdateDisclosure sync.RWMutex
+	lockUpdateListing    sync.RWMutex
+	lockUpdateRetention  sync.RWMutex
+	lockUpdateRooms      sync.RWMutex
+}
+
+// Activity calls ActivityFunc.
+func (mock *HandlerMock) Activity(c echo.Context) error {
+	if mock.ActivityFunc == nil {
+		panic("HandlerMock.ActivityFunc: method is nil but Handler.Activity was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockActivity.Lock()
+	mock.calls.Activity = append(mock.calls.Activity, callInfo)
+	mock.lockActivity.Unlock()
+	return mock.ActivityFunc(c)
+}
+
+// ActivityCalls gets all the calls that were made to Activity.
+// Check the length with:
+//
+//	len(mockedHandler.ActivityCalls())
+func (mock *HandlerMock) ActivityCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockActivity.RLock()
+	calls = mock.calls.Activity
+	mock.lockActivity.RUnlock()
+	return calls
 }
 
 // Create calls CreateFunc.
@@ -154,6 +366,38 @@ func (mock *HandlerMock) DeleteCalls() []struct {
 	return calls
 }
 
+// DeleteTemplate calls DeleteTemplateFunc.
+func (mock *HandlerMock) DeleteTemplate(c echo.Context) error {
+	if mock.DeleteTemplateFunc == nil {
+		panic("HandlerMock.DeleteTemplateFunc: method is nil but Handler.DeleteTemplate was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockDeleteTemplate.Lock()
+	mock.calls.DeleteTemplate = append(mock.calls.DeleteTemplate, callInfo)
+	mock.lockDeleteTemplate.Unlock()
+	return mock.DeleteTemplateFunc(c)
+}
+
+// DeleteTemplateCalls gets all the calls that were made to DeleteTemplate.
+// Check the length with:
+//
+//	len(mockedHandler.DeleteTemplateCalls())
+func (mock *HandlerMock) DeleteTemplateCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockDeleteTemplate.RLock()
+	calls = mock.calls.DeleteTemplate
+	mock.lockDeleteTemplate.RUnlock()
+	return calls
+}
+
 // GetByID calls GetByIDFunc.
 func (mock *HandlerMock) GetByID(c echo.Context) error {
 	if mock.GetByIDFunc == nil {
@@ -186,6 +430,134 @@ func (mock *HandlerMock) GetByIDCalls() []struct {
 	return calls
 }
 
+// GetDisclosure calls GetDisclosureFunc.
+func (mock *HandlerMock) GetDisclosure(c echo.Context) error {
+	if mock.GetDisclosureFunc == nil {
+		panic("HandlerMock.GetDisclosureFunc: method is nil but Handler.GetDisclosure was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockGetDisclosure.Lock()
+	mock.calls.GetDisclosure = append(mock.calls.GetDisclosure, callInfo)
+	mock.lockGetDisclosure.Unlock()
+	return mock.GetDisclosureFunc(c)
+}
+
+// GetDisclosureCalls gets all the calls that were made to GetDisclosure.
+// Check the length with:
+//
+//	len(mockedHandler.GetDisclosureCalls())
+func (mock *HandlerMock) GetDisclosureCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockGetDisclosure.RLock()
+	calls = mock.calls.GetDisclosure
+	mock.lockGetDisclosure.RUnlock()
+	return calls
+}
+
+// GetListing calls GetListingFunc.
+func (mock *HandlerMock) GetListing(c echo.Context) error {
+	if mock.GetListingFunc == nil {
+		panic("HandlerMock.GetListingFunc: method is nil but Handler.GetListing was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockGetListing.Lock()
+	mock.calls.GetListing = append(mock.calls.GetListing, callInfo)
+	mock.lockGetListing.Unlock()
+	return mock.GetListingFunc(c)
+}
+
+// GetListingCalls gets all the calls that were made to GetListing.
+// Check the length with:
+//
+//	len(mockedHandler.GetListingCalls())
+func (mock *HandlerMock) GetListingCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockGetListing.RLock()
+	calls = mock.calls.GetListing
+	mock.lockGetListing.RUnlock()
+	return calls
+}
+
+// GetRetention calls GetRetentionFunc.
+func (mock *HandlerMock) GetRetention(c echo.Context) error {
+	if mock.GetRetentionFunc == nil {
+		panic("HandlerMock.GetRetentionFunc: method is nil but Handler.GetRetention was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockGetRetention.Lock()
+	mock.calls.GetRetention = append(mock.calls.GetRetention, callInfo)
+	mock.lockGetRetention.Unlock()
+	return mock.GetRetentionFunc(c)
+}
+
+// GetRetentionCalls gets all the calls that were made to GetRetention.
+// Check the length with:
+//
+//	len(mockedHandler.GetRetentionCalls())
+func (mock *HandlerMock) GetRetentionCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockGetRetention.RLock()
+	calls = mock.calls.GetRetention
+	mock.lockGetRetention.RUnlock()
+	return calls
+}
+
+// GetTemplate calls GetTemplateFunc.
+func (mock *HandlerMock) GetTemplate(c echo.Context) error {
+	if mock.GetTemplateFunc == nil {
+		panic("HandlerMock.GetTemplateFunc: method is nil but Handler.GetTemplate was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockGetTemplate.Lock()
+	mock.calls.GetTemplate = append(mock.calls.GetTemplate, callInfo)
+	mock.lockGetTemplate.Unlock()
+	return mock.GetTemplateFunc(c)
+}
+
+// GetTemplateCalls gets all the calls that were made to GetTemplate.
+// Check the length with:
+//
+//	len(mockedHandler.GetTemplateCalls())
+func (mock *HandlerMock) GetTemplateCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockGetTemplate.RLock()
+	calls = mock.calls.GetTemplate
+	mock.lockGetTemplate.RUnlock()
+	return calls
+}
+
 // List calls ListFunc.
 func (mock *HandlerMock) List(c echo.Context) error {
 	if mock.ListFunc == nil {
@@ -218,6 +590,166 @@ func (mock *HandlerMock) ListCalls() []struct {
 	return calls
 }
 
+// ListTemplates calls ListTemplatesFunc.
+func (mock *HandlerMock) ListTemplates(c echo.Context) error {
+	if mock.ListTemplatesFunc == nil {
+		panic("HandlerMock.ListTemplatesFunc: method is nil but Handler.ListTemplates was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockListTemplates.Lock()
+	mock.calls.ListTemplates = append(mock.calls.ListTemplates, callInfo)
+	mock.lockListTemplates.Unlock()
+	return mock.ListTemplatesFunc(c)
+}
+
+// ListTemplatesCalls gets all the calls that were made to ListTemplates.
+// Check the length with:
+//
+//	len(mockedHandler.ListTemplatesCalls())
+func (mock *HandlerMock) ListTemplatesCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockListTemplates.RLock()
+	calls = mock.calls.ListTemplates
+	mock.lockListTemplates.RUnlock()
+	return calls
+}
+
+// Rooms calls RoomsFunc.
+func (mock *HandlerMock) Rooms(c echo.Context) error {
+	if mock.RoomsFunc == nil {
+		panic("HandlerMock.RoomsFunc: method is nil but Handler.Rooms was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockRooms.Lock()
+	mock.calls.Rooms = append(mock.calls.Rooms, callInfo)
+	mock.lockRooms.Unlock()
+	return mock.RoomsFunc(c)
+}
+
+// RoomsCalls gets all the calls that were made to Rooms.
+// Check the length with:
+//
+//	len(mockedHandler.RoomsCalls())
+func (mock *HandlerMock) RoomsCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockRooms.RLock()
+	calls = mock.calls.Rooms
+	mock.lockRooms.RUnlock()
+	return calls
+}
+
+// SaveTemplate calls SaveTemplateFunc.
+func (mock *HandlerMock) SaveTemplate(c echo.Context) error {
+	if mock.SaveTemplateFunc == nil {
+		panic("HandlerMock.SaveTemplateFunc: method is nil but Handler.SaveTemplate was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockSaveTemplate.Lock()
+	mock.calls.SaveTemplate = append(mock.calls.SaveTemplate, callInfo)
+	mock.lockSaveTemplate.Unlock()
+	return mock.SaveTemplateFunc(c)
+}
+
+// SaveTemplateCalls gets all the calls that were made to SaveTemplate.
+// Check the length with:
+//
+//	len(mockedHandler.SaveTemplateCalls())
+func (mock *HandlerMock) SaveTemplateCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockSaveTemplate.RLock()
+	calls = mock.calls.SaveTemplate
+	mock.lockSaveTemplate.RUnlock()
+	return calls
+}
+
+// Summaries calls SummariesFunc.
+func (mock *HandlerMock) Summaries(c echo.Context) error {
+	if mock.SummariesFunc == nil {
+		panic("HandlerMock.SummariesFunc: method is nil but Handler.Summaries was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockSummaries.Lock()
+	mock.calls.Summaries = append(mock.calls.Summaries, callInfo)
+	mock.lockSummaries.Unlock()
+	return mock.SummariesFunc(c)
+}
+
+// SummariesCalls gets all the calls that were made to Summaries.
+// Check the length with:
+//
+//	len(mockedHandler.SummariesCalls())
+func (mock *HandlerMock) SummariesCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockSummaries.RLock()
+	calls = mock.calls.Summaries
+	mock.lockSummaries.RUnlock()
+	return calls
+}
+
+// Summary calls SummaryFunc.
+func (mock *HandlerMock) Summary(c echo.Context) error {
+	if mock.SummaryFunc == nil {
+		panic("HandlerMock.SummaryFunc: method is nil but Handler.Summary was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockSummary.Lock()
+	mock.calls.Summary = append(mock.calls.Summary, callInfo)
+	mock.lockSummary.Unlock()
+	return mock.SummaryFunc(c)
+}
+
+// SummaryCalls gets all the calls that were made to Summary.
+// Check the length with:
+//
+//	len(mockedHandler.SummaryCalls())
+func (mock *HandlerMock) SummaryCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockSummary.RLock()
+	calls = mock.calls.Summary
+	mock.lockSummary.RUnlock()
+	return calls
+}
+
 // Update calls UpdateFunc.
 func (mock *HandlerMock) Update(c echo.Context) error {
 	if mock.UpdateFunc == nil {
@@ -249,3 +781,131 @@ func (mock *HandlerMock) UpdateCalls() []struct {
 	mock.lockUpdate.RUnlock()
 	return calls
 }
+
+// UpdateDisclosure calls UpdateDisclosureFunc.
+func (mock *HandlerMock) UpdateDisclosure(c echo.Context) error {
+	if mock.UpdateDisclosureFunc == nil {
+		panic("HandlerMock.UpdateDisclosureFunc: method is nil but Handler.UpdateDisclosure was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockUpdateDisclosure.Lock()
+	mock.calls.UpdateDisclosure = append(mock.calls.UpdateDisclosure, callInfo)
+	mock.lockUpdateDisclosure.Unlock()
+	return mock.UpdateDisclosureFunc(c)
+}
+
+// UpdateDisclosureCalls gets all the calls that were made to UpdateDisclosure.
+// Check the length with:
+//
+//	len(mockedHandler.UpdateDisclosureCalls())
+func (mock *HandlerMock) UpdateDisclosureCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockUpdateDisclosure.RLock()
+	calls = mock.calls.UpdateDisclosure
+	mock.lockUpdateDisclosure.RUnlock()
+	return calls
+}
+
+// UpdateListing calls UpdateListingFunc.
+func (mock *HandlerMock) UpdateListing(c echo.Context) error {
+	if mock.UpdateListingFunc == nil {
+		panic("HandlerMock.UpdateListingFunc: method is nil but Handler.UpdateListing was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockUpdateListing.Lock()
+	mock.calls.UpdateListing = append(mock.calls.UpdateListing, callInfo)
+	mock.lockUpdateListing.Unlock()
+	return mock.UpdateListingFunc(c)
+}
+
+// UpdateListingCalls gets all the calls that were made to UpdateListing.
+// Check the length with:
+//
+//	len(mockedHandler.UpdateListingCalls())
+func (mock *HandlerMock) UpdateListingCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockUpdateListing.RLock()
+	calls = mock.calls.UpdateListing
+	mock.lockUpdateListing.RUnlock()
+	return calls
+}
+
+// UpdateRetention calls UpdateRetentionFunc.
+func (mock *HandlerMock) UpdateRetention(c echo.Context) error {
+	if mock.UpdateRetentionFunc == nil {
+		panic("HandlerMock.UpdateRetentionFunc: method is nil but Handler.UpdateRetention was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockUpdateRetention.Lock()
+	mock.calls.UpdateRetention = append(mock.calls.UpdateRetention, callInfo)
+	mock.lockUpdateRetention.Unlock()
+	return mock.UpdateRetentionFunc(c)
+}
+
+// UpdateRetentionCalls gets all the calls that were made to UpdateRetention.
+// Check the length with:
+//
+//	len(mockedHandler.UpdateRetentionCalls())
+func (mock *HandlerMock) UpdateRetentionCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockUpdateRetention.RLock()
+	calls = mock.calls.UpdateRetention
+	mock.lockUpdateRetention.RUnlock()
+	return calls
+}
+
+// UpdateRooms calls UpdateRoomsFunc.
+func (mock *HandlerMock) UpdateRooms(c echo.Context) error {
+	if mock.UpdateRoomsFunc == nil {
+		panic("HandlerMock.UpdateRoomsFunc: method is nil but Handler.UpdateRooms was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockUpdateRooms.Lock()
+	mock.calls.UpdateRooms = append(mock.calls.UpdateRooms, callInfo)
+	mock.lockUpdateRooms.Unlock()
+	return mock.UpdateRoomsFunc(c)
+}
+
+// UpdateRoomsCalls gets all the calls that were made to UpdateRooms.
+// Check the length with:
+//
+//	len(mockedHandler.UpdateRoomsCalls())
+func (mock *HandlerMock) UpdateRoomsCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockUpdateRooms.RLock()
+	calls = mock.calls.UpdateRooms
+	mock.lockUpdateRooms.RUnlock()
+	return calls
+}
@@ -0,0 +1,156 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/real-staging-ai/api/internal/geocode"
+)
+
+// Listing field limits.
+const (
+	MaxAddressLineLength = 200
+	MaxCityLength        = 100
+	MaxRegionLength      = 100
+	MaxPostalCodeLength  = 20
+	MaxListingURLLength  = 2048
+)
+
+var (
+	countryPattern   = regexp.MustCompile(`^[A-Z]{2}$`)
+	mlsNumberPattern = regexp.MustCompile(`^[A-Za-z0-9-]{1,32}$`)
+)
+
+// Listing describes the property a project stages: its address, MLS listing number and
+// listing page. Location is geocoded from the address and is nil until that succeeds.
+type Listing struct {
+	ProjectID  string            `json:"project_id"`
+	Address    geocode.Address   `json:"address"`
+	MLSNumber  string            `json:"mls_number"`
+	ListingURL string            `json:"listing_url"`
+	Location   *geocode.Location `json:"location"`
+	GeocodedAt *time.Time        `json:"geocoded_at,omitempty"`
+	UpdatedAt  *time.Time        `json:"updated_at,omitempty"`
+}
+
+// ListingRequest updates a project's listing. Omitted fields keep their current value; an
+// empty string clears a field, and an address with every field empty clears the address.
+type ListingRequest struct {
+	Address    *geocode.Address `json:"address"`
+	MLSNumber  *string          `json:"mls_number"`
+	ListingURL *string          `json:"listing_url"`
+}
+
+// Apply validates the request and merges it onto l.
+func (r *ListingRequest) Apply(l *Listing) []ValidationErrorDetail {
+	var errs []ValidationErrorDetail
+
+	if r.Address != nil {
+		addr := geocode.Address{
+			Line1:      strings.TrimSpace(r.Address.Line1),
+			Line2:      strings.TrimSpace(r.Address.Line2),
+			City:       strings.TrimSpace(r.Address.City),
+			Region:     strings.TrimSpace(r.Address.Region),
+			PostalCode: strings.TrimSpace(r.Address.PostalCode),
+			Country:    strings.ToUpper(strings.TrimSpace(r.Address.Country)),
+		}
+		if addrErrs := validateAddress(addr); len(addrErrs) > 0 {
+			errs = append(errs, addrErrs...)
+		} else {
+			l.Address = addr
+		}
+	}
+	if r.MLSNumber != nil {
+		mls := strings.TrimSpace(*r.MLSNumber)
+		if mls == "" || mlsNumberPattern.MatchString(mls) {
+			l.MLSNumber = mls
+		} else {
+			errs = append(errs, ValidationErrorDetail{
+				Field:   "mls_number",
+				Message: "mls_number must be 1 to 32 letters, digits or hyphens",
+			})
+		}
+	}
+	if r.ListingURL != nil {
+		raw := strings.TrimSpace(*r.ListingURL)
+		if raw == "" || validListingURL(raw) {
+			l.ListingURL = raw
+		} else {
+			errs = append(errs, ValidationErrorDetail{
+				Field:   "listing_url",
+				Message: "listing_url must be an http or https URL of 2048 characters or fewer",
+			})
+		}
+	}
+
+	return errs
+}
+
+// validateAddress checks a trimmed address. An empty address is valid and clears it;
+// otherwise line1, city and country are required.
+func validateAddress(a geocode.Address) []ValidationErrorDetail {
+	if a.IsZero() {
+		return nil
+	}
+	var errs []ValidationErrorDetail
+	required := func(field, value string) {
+		if value == "" {
+			errs = append(errs, ValidationErrorDetail{Field: field, Message: field + " is required"})
+		}
+	}
+	maxLength := func(field, value string, limit int) {
+		if utf8.RuneCountInString(value) > limit {
+			errs = append(errs, ValidationErrorDetail{
+				Field:   field,
+				Message: field + " is too long",
+			})
+		}
+	}
+	required("address.line1", a.Line1)
+	required("address.city", a.City)
+	maxLength("address.line1", a.Line1, MaxAddressLineLength)
+	maxLength("address.line2", a.Line2, MaxAddressLineLength)
+	maxLength("address.city", a.City, MaxCityLength)
+	maxLength("address.region", a.Region, MaxRegionLength)
+	maxLength("address.postal_code", a.PostalCode, MaxPostalCodeLength)
+	if !countryPattern.MatchString(a.Country) {
+		errs = append(errs, ValidationErrorDetail{
+			Field:   "address.country",
+			Message: "address.country must be a two-letter ISO 3166-1 country code",
+		})
+	}
+	return errs
+}
+
+func validListingURL(raw string) bool {
+	if len(raw) > MaxListingURLLength {
+		return false
+	}
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// geocodeListing refreshes l.Location for a changed address. Coordinates of the old
+// address are always dropped; they are replaced when g finds the new one. A nil g, an
+// empty address or no match leaves the listing without a location. Provider errors are
+// returned for logging only: the listing is still saved.
+func geocodeListing(ctx context.Context, g geocode.Geocoder, l *Listing, now time.Time) error {
+	l.Location, l.GeocodedAt = nil, nil
+	if g == nil || l.Address.IsZero() {
+		return nil
+	}
+	loc, err := g.Geocode(ctx, l.Address)
+	if errors.Is(err, geocode.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	l.Location, l.GeocodedAt = loc, &now
+	return nil
+}
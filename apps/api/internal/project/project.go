@@ -2,17 +2,48 @@
 package project
 
 import (
+	"errors"
 	"time"
 )
 
+// ErrLegalHold is returned when a project, or any of its images, is under legal hold and cannot be deleted.
+var ErrLegalHold = errors.New("project is under legal hold")
+
+// ErrInvalidConfirmation is returned when a deletion confirmation token does not match the
+// project's pending deletion or has expired.
+var ErrInvalidConfirmation = errors.New("deletion confirmation token is invalid or has expired")
+
+// DeletionConfirmTTL is how long a deletion confirmation token stays valid.
+const DeletionConfirmTTL = 10 * time.Minute
+
+// DeletionIntent is a pending deletion of a project with staged work. Deleting the project
+// again with ConfirmationToken before ExpiresAt deletes it.
+type DeletionIntent struct {
+	ProjectID         string    `json:"project_id"`
+	ConfirmationToken string    `json:"confirmation_token"`
+	ExpiresAt         time.Time `json:"expires_at"`
+	// ReadyImages is how many staged images would be lost.
+	ReadyImages int64 `json:"ready_images"`
+}
+
 // Project represents a user's project.
 type Project struct {
 	ID        string    `json:"id"`
 	Name      string    `json:"name" validate:"required,min=1,max=100"`
 	UserID    string    `json:"user_id"`
 	CreatedAt time.Time `json:"created_at"`
+	// Role is the caller's access to the project (owner, editor or viewer). It is only set
+	// where collaborators can reach the project.
+	Role string `json:"role,omitempty"`
 }
 
+// Roles a user can have on a project. Collaborators are added through invitations.
+const (
+	RoleOwner  = "owner"
+	RoleEditor = "editor"
+	RoleViewer = "viewer"
+)
+
 // CreateRequest represents the input for creating a project.
 type CreateRequest struct {
 	Name   string `json:"name" validate:"required,min=1,max=100"`
@@ -2,6 +2,7 @@ package project
 
 import (
 	"context"
+	"time"
 )
 
 //go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
@@ -23,6 +24,10 @@ type Repository interface {
 	// GetProjectByIDAndUserID retrieves a specific project by its ID and user ID.
 	GetProjectByIDAndUserID(ctx context.Context, projectID, userID string) (*Project, error)
 
+	// GetProjectForMember retrieves a project the user owns or collaborates on, with the
+	// user's Role set.
+	GetProjectForMember(ctx context.Context, projectID, userID string) (*Project, error)
+
 	// UpdateProject updates an existing project's name.
 	UpdateProject(ctx context.Context, projectID, name string) (*Project, error)
 
@@ -37,4 +42,55 @@ type Repository interface {
 
 	// CountProjectsByUserID returns the number of projects for a specific user.
 	CountProjectsByUserID(ctx context.Context, userID string) (int64, error)
+
+	// ListProjectActivity returns a page of a project's activity timeline, newest first.
+	ListProjectActivity(ctx context.Context, projectID string, limit, offset int32) ([]ActivityEvent, error)
+	// GetProjectSummary returns the project's image counts, storage use and last activity.
+	GetProjectSummary(ctx context.Context, projectID string) (*Summary, error)
+	// ListProjectThumbnails returns up to limit of the project's most recent images, newest first.
+	ListProjectThumbnails(ctx context.Context, projectID string, limit int32) ([]Thumbnail, error)
+	// ListSummariesByUser returns the summaries of those projectIDs the user owns, from a
+	// single query. Order is unspecified.
+	ListSummariesByUser(ctx context.Context, projectIDs []string, userID string) ([]Summary, error)
+	// IsRetentionExempt reports whether the project is excluded from retention purging.
+	IsRetentionExempt(ctx context.Context, projectID string) (bool, error)
+	// SetRetentionExempt adds or removes the project's retention exemption.
+	SetRetentionExempt(ctx context.Context, projectID string, exempt bool) error
+	// GetDisclosure returns the project's disclosure banner settings, or the defaults if none are saved.
+	GetDisclosure(ctx context.Context, projectID string) (*Disclosure, error)
+	// SaveDisclosure creates or replaces the project's disclosure banner settings.
+	SaveDisclosure(ctx context.Context, d *Disclosure) (*Disclosure, error)
+	// GetListing returns the project's listing, or an empty one if none is saved.
+	GetListing(ctx context.Context, projectID string) (*Listing, error)
+	// SaveListing creates or replaces the project's listing.
+	SaveListing(ctx context.Context, l *Listing) (*Listing, error)
+	// IsUnderLegalHold reports whether the project or any of its images is under legal hold.
+	IsUnderLegalHold(ctx context.Context, projectID string) (bool, error)
+	// SaveDeletionIntent stores the project's pending deletion, replacing any earlier one.
+	SaveDeletionIntent(ctx context.Context, projectID, userID string, tokenHash []byte, expiresAt time.Time) error
+	// ConsumeDeletionIntent removes the project's pending deletion if it was issued to the user
+	// for tokenHash and has not expired, and reports whether it did.
+	ConsumeDeletionIntent(ctx context.Context, projectID, userID string, tokenHash []byte) (bool, error)
+	// CreateTemplate saves t as one of the user's templates, or returns ErrTemplateNameTaken.
+	CreateTemplate(ctx context.Context, userID string, t *Template) (*Template, error)
+	// CountTemplates returns how many templates the user has.
+	CountTemplates(ctx context.Context, userID string) (int64, error)
+	// GetTemplate returns one of the user's templates, or ErrTemplateNotFound.
+	GetTemplate(ctx context.Context, templateID, userID string) (*Template, error)
+	// ListTemplates returns the user's templates ordered by name.
+	ListTemplates(ctx context.Context, userID string) ([]Template, error)
+	// DeleteTemplate deletes one of the user's templates, or returns ErrTemplateNotFound.
+	DeleteTemplate(ctx context.Context, templateID, userID string) error
+	// ApplyTemplate copies the template's disclosure settings and room checklist to the project.
+	ApplyTemplate(ctx context.Context, projectID, templateID string) error
+	// ListRooms returns the project's room checklist.
+	ListRooms(ctx context.Context, projectID string) ([]Room, error)
+	// SaveRooms replaces the project's room checklist with rooms.
+	SaveRooms(ctx context.Context, projectID string, rooms []Room) error
+	// CountImagesByRoomType returns how many of the project's images are of each room type,
+	// and how many of those are staged.
+	CountImagesByRoomType(ctx context.Context, projectID string) ([]RoomImageCount, error)
+	// ListImageRooms returns a room for each room type among the project's images, in the
+	// order they were first uploaded, styled as most of its images were.
+	ListImageRooms(ctx context.Context, projectID string) ([]Room, error)
 }
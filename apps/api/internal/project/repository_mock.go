@@ -6,6 +6,7 @@ package project
 import (
 	"context"
 	"sync"
+	"time"
 )
 
 // Ensure, that RepositoryMock does implement Repository.
@@ -18,30 +19,102 @@ var _ Repository = &RepositoryMock{}
 //
 //		// make and configure a mocked Repository
 //		mockedRepository := &RepositoryMock{
+//			ApplyTemplateFunc: func(ctx context.Context, projectID string, templateID string) error {
+//				panic("mock out the ApplyTemplate method")
+//			},
+//			ConsumeDeletionIntentFunc: func(ctx context.Context, projectID string, userID string, tokenHash []byte) (bool, error) {
+//				panic("mock out the ConsumeDeletionIntent method")
+//			},
+//			CountImagesByRoomTypeFunc: func(ctx context.Context, projectID string) ([]RoomImageCount, error) {
+//				panic("mock out the CountImagesByRoomType method")
+//			},
 //			CountProjectsByUserIDFunc: func(ctx context.Context, userID string) (int64, error) {
 //				panic("mock out the CountProjectsByUserID method")
 //			},
+//			CountTemplatesFunc: func(ctx context.Context, userID string) (int64, error) {
+//				panic("mock out the CountTemplates method")
+//			},
 //			CreateProjectFunc: func(ctx context.Context, p *Project, userID string) (*Project, error) {
 //				panic("mock out the CreateProject method")
 //			},
+//			CreateTemplateFunc: func(ctx context.Context, userID string, t *Template) (*Template, error) {
+//				panic("mock out the CreateTemplate method")
+//			},
 //			DeleteProjectFunc: func(ctx context.Context, projectID string) error {
 //				panic("mock out the DeleteProject method")
 //			},
 //			DeleteProjectByUserIDFunc: func(ctx context.Context, projectID string, userID string) error {
 //				panic("mock out the DeleteProjectByUserID method")
 //			},
+//			DeleteTemplateFunc: func(ctx context.Context, templateID string, userID string) error {
+//				panic("mock out the DeleteTemplate method")
+//			},
+//			GetDisclosureFunc: func(ctx context.Context, projectID string) (*Disclosure, error) {
+//				panic("mock out the GetDisclosure method")
+//			},
+//			GetListingFunc: func(ctx context.Context, projectID string) (*Listing, error) {
+//				panic("mock out the GetListing method")
+//			},
 //			GetProjectByIDFunc: func(ctx context.Context, projectID string) (*Project, error) {
 //				panic("mock out the GetProjectByID method")
 //			},
 //			GetProjectByIDAndUserIDFunc: func(ctx context.Context, projectID string, userID string) (*Project, error) {
 //				panic("mock out the GetProjectByIDAndUserID method")
 //			},
+//			GetProjectForMemberFunc: func(ctx context.Context, projectID string, userID string) (*Project, error) {
+//				panic("mock out the GetProjectForMember method")
+//			},
+//			GetProjectSummaryFunc: func(ctx context.Context, projectID string) (*Summary, error) {
+//				panic("mock out the GetProjectSummary method")
+//			},
 //			GetProjectsFunc: func(ctx context.Context) ([]Project, error) {
 //				panic("mock out the GetProjects method")
 //			},
 //			GetProjectsByUserIDFunc: func(ctx context.Context, userID string) ([]Project, error) {
 //				panic("mock out the GetProjectsByUserID method")
 //			},
+//			GetTemplateFunc: func(ctx context.Context, templateID string, userID string) (*Template, error) {
+//				panic("mock out the GetTemplate method")
+//			},
+//			IsRetentionExemptFunc: func(ctx context.Context, projectID string) (bool, error) {
+//				panic("mock out the IsRetentionExempt method")
+//			},
+//			IsUnderLegalHoldFunc: func(ctx context.Context, projectID string) (bool, error) {
+//				panic("mock out the IsUnderLegalHold method")
+//			},
+//			ListImageRoomsFunc: func(ctx context.Context, projectID string) ([]Room, error) {
+//				panic("mock out the ListImageRooms method")
+//			},
+//			ListProjectActivityFunc: func(ctx context.Context, projectID string, limit int32, offset int32) ([]ActivityEvent, error) {
+//				panic("mock out the ListProjectActivity method")
+//			},
+//			ListProjectThumbnailsFunc: func(ctx context.Context, projectID string, limit int32) ([]Thumbnail, error) {
+//				panic("mock out the ListProjectThumbnails method")
+//			},
+//			ListRoomsFunc: func(ctx context.Context, projectID string) ([]Room, error) {
+//				panic("mock out the ListRooms method")
+//			},
+//			ListSummariesByUserFunc: func(ctx context.Context, projectIDs []string, userID string) ([]Summary, error) {
+//				panic("mock out the ListSummariesByUser method")
+//			},
+//			ListTemplatesFunc: func(ctx context.Context, userID string) ([]Template, error) {
+//				panic("mock out the ListTemplates method")
+//			},
+//			SaveDeletionIntentFunc: func(ctx context.Context, projectID string, userID string, tokenHash []byte, expiresAt time.Time) error {
+//				panic("mock out the SaveDeletionIntent method")
+//			},
+//			SaveDisclosureFunc: func(ctx context.Context, d *Disclosure) (*Disclosure, error) {
+//				panic("mock out the SaveDisclosure method")
+//			},
+//			SaveListingFunc: func(ctx context.Context, l *Listing) (*Listing, error) {
+//				panic("mock out the SaveListing method")
+//			},
+//			SaveRoomsFunc: func(ctx context.Context, projectID string, rooms []Room) error {
+//				panic("mock out the SaveRooms method")
+//			},
+//			SetRetentionExemptFunc: func(ctx context.Context, projectID string, exempt bool) error {
+//				panic("mock out the SetRetentionExempt method")
+//			},
 //			UpdateProjectFunc: func(ctx context.Context, projectID string, name string) (*Project, error) {
 //				panic("mock out the UpdateProject method")
 //			},
@@ -55,30 +128,102 @@ var _ Repository = &RepositoryMock{}
 //
 //	}
 type RepositoryMock struct {
+	// ApplyTemplateFunc mocks the ApplyTemplate method.
+	ApplyTemplateFunc func(ctx context.Context, projectID string, templateID string) error
+
+	// ConsumeDeletionIntentFunc mocks the ConsumeDeletionIntent method.
+	ConsumeDeletionIntentFunc func(ctx context.Context, projectID string, userID string, tokenHash []byte) (bool, error)
+
+	// CountImagesByRoomTypeFunc mocks the CountImagesByRoomType method.
+	CountImagesByRoomTypeFunc func(ctx context.Context, projectID string) ([]RoomImageCount, error)
+
 	// CountProjectsByUserIDFunc mocks the CountProjectsByUserID method.
 	CountProjectsByUserIDFunc func(ctx context.Context, userID string) (int64, error)
 
+	// CountTemplatesFunc mocks the CountTemplates method.
+	CountTemplatesFunc func(ctx context.Context, userID string) (int64, error)
+
 	// CreateProjectFunc mocks the CreateProject method.
 	CreateProjectFunc func(ctx context.Context, p *Project, userID string) (*Project, error)
 
+	// CreateTemplateFunc mocks the CreateTemplate method.
+	CreateTemplateFunc func(ctx context.Context, userID string, t *Template) (*Template, error)
+
 	// DeleteProjectFunc mocks the DeleteProject method.
 	DeleteProjectFunc func(ctx context.Context, projectID string) error
 
 	// DeleteProjectByUserIDFunc mocks the DeleteProjectByUserID method.
 	DeleteProjectByUserIDFunc func(ctx context.Context, projectID string, userID string) error
 
+	// DeleteTemplateFunc mocks the DeleteTemplate method.
+	DeleteTemplateFunc func(ctx context.Context, templateID string, userID string) error
+
+	// GetDisclosureFunc mocks the GetDisclosure method.
+	GetDisclosureFunc func(ctx context.Context, projectID string) (*Disclosure, error)
+
+	// GetListingFunc mocks the GetListing method.
+	GetListingFunc func(ctx context.Context, projectID string) (*Listing, error)
+
 	// GetProjectByIDFunc mocks the GetProjectByID method.
 	GetProjectByIDFunc func(ctx context.Context, projectID string) (*Project, error)
 
 	// GetProjectByIDAndUserIDFunc mocks the GetProjectByIDAndUserID method.
 	GetProjectByIDAndUserIDFunc func(ctx context.Context, projectID string, userID string) (*Project, error)
 
+	// GetProjectForMemberFunc mocks the GetProjectForMember method.
+	GetProjectForMemberFunc func(ctx context.Context, projectID string, userID string) (*Project, error)
+
+	// GetProjectSummaryFunc mocks the GetProjectSummary method.
+	GetProjectSummaryFunc func(ctx context.Context, projectID string) (*Summary, error)
+
 	// GetProjectsFunc mocks the GetProjects method.
 	GetProjectsFunc func(ctx context.Context) ([]Project, error)
 
 	// GetProjectsByUserIDFunc mocks the GetProjectsByUserID method.
 	GetProjectsByUserIDFunc func(ctx context.Context, userID string) ([]Project, error)
 
+	// GetTemplateFunc mocks the GetTemplate method.
+	GetTemplateFunc func(ctx context.Context, templateID string, userID string) (*Template, error)
+
+	// IsRetentionExemptFunc mocks the IsRetentionExempt method.
+	IsRetentionExemptFunc func(ctx context.Context, projectID string) (bool, error)
+
+	// IsUnderLegalHoldFunc mocks the IsUnderLegalHold method.
+	IsUnderLegalHoldFunc func(ctx context.Context, projectID string) (bool, error)
+
+	// ListImageRoomsFunc mocks the ListImageRooms method.
+	ListImageRoomsFunc func(ctx context.Context, projectID string) ([]Room, error)
+
+	// ListProjectActivityFunc mocks the ListProjectActivity method.
+	ListProjectActivityFunc func(ctx context.Context, projectID string, limit int32, offset int32) ([]ActivityEvent, error)
+
+	// ListProjectThumbnailsFunc mocks the ListProjectThumbnails method.
+	ListProjectThumbnailsFunc func(ctx context.Context, projectID string, limit int32) ([]Thumbnail, error)
+
+	// ListRoomsFunc mocks the ListRooms method.
+	ListRoomsFunc func(ctx context.Context, projectID string) ([]Room, error)
+
+	// ListSummariesByUserFunc mocks the ListSummariesByUser method.
+	ListSummariesByUserFunc func(ctx context.Context, projectIDs []string, userID string) ([]Summary, error)
+
+	// ListTemplatesFunc mocks the ListTemplates method.
+	ListTemplatesFunc func(ctx context.Context, userID string) ([]Template, error)
+
+	// SaveDeletionIntentFunc mocks the SaveDeletionIntent method.
+	SaveDeletionIntentFunc func(ctx context.Context, projectID string, userID string, tokenHash []byte, expiresAt time.Time) error
+
+	// SaveDisclosureFunc mocks the SaveDisclosure method.
+	SaveDisclosureFunc func(ctx context.Context, d *Disclosure) (*Disclosure, error)
+
+	// SaveListingFunc mocks the SaveListing method.
+	SaveListingFunc func(ctx context.Context, l *Listing) (*Listing, error)
+
+	// SaveRoomsFunc mocks the SaveRooms method.
+	SaveRoomsFunc func(ctx context.Context, projectID string, rooms []Room) error
+
+	// SetRetentionExemptFunc mocks the SetRetentionExempt method.
+	SetRetentionExemptFunc func(ctx context.Context, projectID string, exempt bool) error
+
 	// UpdateProjectFunc mocks the UpdateProject method.
 	UpdateProjectFunc func(ctx context.Context, projectID string, name string) (*Project, error)
 
@@ -87,6 +232,33 @@ type RepositoryMock struct {
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// ApplyTemplate holds details about calls to the ApplyTemplate method.
+		ApplyTemplate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+			// TemplateID is the templateID argument value.
+			TemplateID string
+		}
+		// ConsumeDeletionIntent holds details about calls to the ConsumeDeletionIntent method.
+		ConsumeDeletionIntent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+			// UserID is the userID argument value.
+			UserID string
+			// TokenHash is the tokenHash argument value.
+			TokenHash []byte
+		}
+		// CountImagesByRoomType holds details about calls to the CountImagesByRoomType method.
+		CountImagesByRoomType []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+		}
 		// CountProjectsByUserID holds details about calls to the CountProjectsByUserID method.
 		CountProjectsByUserID []struct {
 			// Ctx is the ctx argument value.
@@ -94,6 +266,13 @@ type RepositoryMock struct {
 			// UserID is the userID argument value.
 			UserID string
 		}
+		// CountTemplates holds details about calls to the CountTemplates method.
+		CountTemplates []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+		}
 		// CreateProject holds details about calls to the CreateProject method.
 		CreateProject []struct {
 			// Ctx is the ctx argument value.
@@ -103,6 +282,15 @@ type RepositoryMock struct {
 			// UserID is the userID argument value.
 			UserID string
 		}
+		// CreateTemplate holds details about calls to the CreateTemplate method.
+		CreateTemplate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+			// T is the t argument value.
+			T *Template
+		}
 		// DeleteProject holds details about calls to the DeleteProject method.
 		DeleteProject []struct {
 			// Ctx is the ctx argument value.
@@ -119,6 +307,29 @@ type RepositoryMock struct {
 			// UserID is the userID argument value.
 			UserID string
 		}
+		// DeleteTemplate holds details about calls to the DeleteTemplate method.
+		DeleteTemplate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TemplateID is the templateID argument value.
+			TemplateID string
+			// UserID is the userID argument value.
+			UserID string
+		}
+		// GetDisclosure holds details about calls to the GetDisclosure method.
+		GetDisclosure []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+		}
+		// GetListing holds details about calls to the GetListing method.
+		GetListing []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+		}
 		// GetProjectByID holds details about calls to the GetProjectByID method.
 		GetProjectByID []struct {
 			// Ctx is the ctx argument value.
@@ -135,6 +346,22 @@ type RepositoryMock struct {
 			// UserID is the userID argument value.
 			UserID string
 		}
+		// GetProjectForMember holds details about calls to the GetProjectForMember method.
+		GetProjectForMember []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+			// UserID is the userID argument value.
+			UserID string
+		}
+		// GetProjectSummary holds details about calls to the GetProjectSummary method.
+		GetProjectSummary []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+		}
 		// GetProjects holds details about calls to the GetProjects method.
 		GetProjects []struct {
 			// Ctx is the ctx argument value.
@@ -147,6 +374,124 @@ type RepositoryMock struct {
 			// UserID is the userID argument value.
 			UserID string
 		}
+		// GetTemplate holds details about calls to the GetTemplate method.
+		GetTemplate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TemplateID is the templateID argument value.
+			TemplateID string
+			// UserID is the userID argument value.
+			UserID string
+		}
+		// IsRetentionExempt holds details about calls to the IsRetentionExempt method.
+		IsRetentionExempt []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+		}
+		// IsUnderLegalHold holds details about calls to the IsUnderLegalHold method.
+		IsUnderLegalHold []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+		}
+		// ListImageRooms holds details about calls to the ListImageRooms method.
+		ListImageRooms []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+		}
+		// ListProjectActivity holds details about calls to the ListProjectActivity method.
+		ListProjectActivity []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+			// Limit is the limit argument value.
+			Limit int32
+			// Offset is the offset argument value.
+			Offset int32
+		}
+		// ListProjectThumbnails holds details about calls to the ListProjectThumbnails method.
+		ListProjectThumbnails []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+			// Limit is the limit argument value.
+			Limit int32
+		}
+		// ListRooms holds details about calls to the ListRooms method.
+		ListRooms []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+		}
+		// ListSummariesByUser holds details about calls to the ListSummariesByUser method.
+		ListSummariesByUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectIDs is the projectIDs argument value.
+			ProjectIDs []string
+			// UserID is the userID argument value.
+			UserID string
+		}
+		// ListTemplates holds details about calls to the ListTemplates method.
+		ListTemplates []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+		}
+		// SaveDeletionIntent holds details about calls to the SaveDeletionIntent method.
+		SaveDeletionIntent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+			// UserID is the userID argument value.
+			UserID string
+			// TokenHash is the tokenHash argument value.
+			TokenHash []byte
+			// ExpiresAt is the expiresAt argument value.
+			ExpiresAt time.Time
+		}
+		// SaveDisclosure holds details about calls to the SaveDisclosure method.
+		SaveDisclosure []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// D is the d argument value.
+			D *Disclosure
+		}
+		// SaveListing holds details about calls to the SaveListing method.
+		SaveListing []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// L is the l argument value.
+			L *Listing
+		}
+		// SaveRooms holds details about calls to the SaveRooms method.
+		SaveRooms []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+			// Rooms is the rooms argument value.
+			Rooms []Room
+		}
+		// SetRetentionExempt holds details about calls to the SetRetentionExempt method.
+		SetRetentionExempt []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+			// Exempt is the exempt argument value.
+			Exempt bool
+		}
 		// UpdateProject holds details about calls to the UpdateProject method.
 		UpdateProject []struct {
 			// Ctx is the ctx argument value.
@@ -168,18 +513,162 @@ type RepositoryMock struct {
 			Name string
 		}
 	}
+	lockApplyTemplate           sync.RWMutex
+	lockConsumeDeletionIntent   sync.RWMutex
+	lockCountImagesByRoomType   sync.RWMutex
 	lockCountProjectsByUserID   sync.RWMutex
+	lockCountTemplates          sync.RWMutex
 	lockCreateProject           sync.RWMutex
+	lockCreateTemplate          sync.RWMutex
 	lockDeleteProject           sync.RWMutex
 	lockDeleteProjectByUserID   sync.RWMutex
+	lockDeleteTemplate          sync.RWMutex
+	lockGetDisclosure           sync.RWMutex
+	lockGetListing              sync.RWMutex
 	lockGetProjectByID          sync.RWMutex
 	lockGetProjectByIDAndUserID sync.RWMutex
+	lockGetProjectForMember     sync.RWMutex
+	lockGetProjectSummary       sync.RWMutex
 	lockGetProjects             sync.RWMutex
 	lockGetProjectsByUserID     sync.RWMutex
+	lockGetTemplate             sync.RWMutex
+	lockIsRetentionExempt       sync.RWMutex
+	lockIsUnderLegalHold        sync.RWMutex
+	lockListImageRooms          sync.RWMutex
+	lockListProjectActivity     sync.RWMutex
+	lockListProjectThumbnails   sync.RWMutex
+	lockListRooms               sync.RWMutex
+	lockListSummariesByUser     sync.RWMutex
+	lockListTemplates           sync.RWMutex
+	lockSaveDeletionIntent      sync.RWMutex
+	lockSaveDisclosure          sync.RWMutex
+	lockSaveListing             sync.RWMutex
+	lockSaveRooms               sync.RWMutex
+	lockSetRetentionExempt      sync.RWMutex
 	lockUpdateProject           sync.RWMutex
 	lockUpdateProjectByUserID   sync.RWMutex
 }
 
+// ApplyTemplate calls ApplyTemplateFunc.
+func (mock *RepositoryMock) ApplyTemplate(ctx context.Context, projectID string, templateID string) error {
+	if mock.ApplyTemplateFunc == nil {
+		panic("RepositoryMock.ApplyTemplateFunc: method is nil but Repository.ApplyTemplate was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		ProjectID  string
+		TemplateID string
+	}{
+		Ctx:        ctx,
+		ProjectID:  projectID,
+		TemplateID: templateID,
+	}
+	mock.lockApplyTemplate.Lock()
+	mock.calls.ApplyTemplate = append(mock.calls.ApplyTemplate, callInfo)
+	mock.lockApplyTemplate.Unlock()
+	return mock.ApplyTemplateFunc(ctx, projectID, templateID)
+}
+
+// ApplyTemplateCalls gets all the calls that were made to ApplyTemplate.
+// Check the length with:
+//
+//	len(mockedRepository.ApplyTemplateCalls())
+func (mock *RepositoryMock) ApplyTemplateCalls() []struct {
+	Ctx        context.Context
+	ProjectID  string
+	TemplateID string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		ProjectID  string
+		TemplateID string
+	}
+	mock.lockApplyTemplate.RLock()
+	calls = mock.calls.ApplyTemplate
+	mock.lockApplyTemplate.RUnlock()
+	return calls
+}
+
+// ConsumeDeletionIntent calls ConsumeDeletionIntentFunc.
+func (mock *RepositoryMock) ConsumeDeletionIntent(ctx context.Context, projectID string, userID string, tokenHash []byte) (bool, error) {
+	if mock.ConsumeDeletionIntentFunc == nil {
+		panic("RepositoryMock.ConsumeDeletionIntentFunc: method is nil but Repository.ConsumeDeletionIntent was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+		UserID    string
+		TokenHash []byte
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+		UserID:    userID,
+		TokenHash: tokenHash,
+	}
+	mock.lockConsumeDeletionIntent.Lock()
+	mock.calls.ConsumeDeletionIntent = append(mock.calls.ConsumeDeletionIntent, callInfo)
+	mock.lockConsumeDeletionIntent.Unlock()
+	return mock.ConsumeDeletionIntentFunc(ctx, projectID, userID, tokenHash)
+}
+
+// ConsumeDeletionIntentCalls gets all the calls that were made to ConsumeDeletionIntent.
+// Check the length with:
+//
+//	len(mockedRepository.ConsumeDeletionIntentCalls())
+func (mock *RepositoryMock) ConsumeDeletionIntentCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+	UserID    string
+	TokenHash []byte
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+		UserID    string
+		TokenHash []byte
+	}
+	mock.lockConsumeDeletionIntent.RLock()
+	calls = mock.calls.ConsumeDeletionIntent
+	mock.lockConsumeDeletionIntent.RUnlock()
+	return calls
+}
+
+// CountImagesByRoomType calls CountImagesByRoomTypeFunc.
+func (mock *RepositoryMock) CountImagesByRoomType(ctx context.Context, projectID string) ([]RoomImageCount, error) {
+	if mock.CountImagesByRoomTypeFunc == nil {
+		panic("RepositoryMock.CountImagesByRoomTypeFunc: method is nil but Repository.CountImagesByRoomType was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockCountImagesByRoomType.Lock()
+	mock.calls.CountImagesByRoomType = append(mock.calls.CountImagesByRoomType, callInfo)
+	mock.lockCountImagesByRoomType.Unlock()
+	return mock.CountImagesByRoomTypeFunc(ctx, projectID)
+}
+
+// CountImagesByRoomTypeCalls gets all the calls that were made to CountImagesByRoomType.
+// Check the length with:
+//
+//	len(mockedRepository.CountImagesByRoomTypeCalls())
+func (mock *RepositoryMock) CountImagesByRoomTypeCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+	}
+	mock.lockCountImagesByRoomType.RLock()
+	calls = mock.calls.CountImagesByRoomType
+	mock.lockCountImagesByRoomType.RUnlock()
+	return calls
+}
+
 // CountProjectsByUserID calls CountProjectsByUserIDFunc.
 func (mock *RepositoryMock) CountProjectsByUserID(ctx context.Context, userID string) (int64, error) {
 	if mock.CountProjectsByUserIDFunc == nil {
@@ -216,6 +705,42 @@ func (mock *RepositoryMock) CountProjectsByUserIDCalls() []struct {
 	return calls
 }
 
+// CountTemplates calls CountTemplatesFunc.
+func (mock *RepositoryMock) CountTemplates(ctx context.Context, userID string) (int64, error) {
+	if mock.CountTemplatesFunc == nil {
+		panic("RepositoryMock.CountTemplatesFunc: method is nil but Repository.CountTemplates was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockCountTemplates.Lock()
+	mock.calls.CountTemplates = append(mock.calls.CountTemplates, callInfo)
+	mock.lockCountTemplates.Unlock()
+	return mock.CountTemplatesFunc(ctx, userID)
+}
+
+// CountTemplatesCalls gets all the calls that were made to CountTemplates.
+// Check the length with:
+//
+//	len(mockedRepository.CountTemplatesCalls())
+func (mock *RepositoryMock) CountTemplatesCalls() []struct {
+	Ctx    context.Context
+	UserID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID string
+	}
+	mock.lockCountTemplates.RLock()
+	calls = mock.calls.CountTemplates
+	mock.lockCountTemplates.RUnlock()
+	return calls
+}
+
 // CreateProject calls CreateProjectFunc.
 func (mock *RepositoryMock) CreateProject(ctx context.Context, p *Project, userID string) (*Project, error) {
 	if mock.CreateProjectFunc == nil {
@@ -256,8 +781,48 @@ func (mock *RepositoryMock) CreateProjectCalls() []struct {
 	return calls
 }
 
-// DeleteProject calls DeleteProjectFunc.
-func (mock *RepositoryMock) DeleteProject(ctx context.Context, projectID string) error {
+// CreateTemplate calls CreateTemplateFunc.
+func (mock *RepositoryMock) CreateTemplate(ctx context.Context, userID string, t *Template) (*Template, error) {
+	if mock.CreateTemplateFunc == nil {
+		panic("RepositoryMock.CreateTemplateFunc: method is nil but Repository.CreateTemplate was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID string
+		T      *Template
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		T:      t,
+	}
+	mock.lockCreateTemplate.Lock()
+	mock.calls.CreateTemplate = append(mock.calls.CreateTemplate, callInfo)
+	mock.lockCreateTemplate.Unlock()
+	return mock.CreateTemplateFunc(ctx, userID, t)
+}
+
+// CreateTemplateCalls gets all the calls that were made to CreateTemplate.
+// Check the length with:
+//
+//	len(mockedRepository.CreateTemplateCalls())
+func (mock *RepositoryMock) CreateTemplateCalls() []struct {
+	Ctx    context.Context
+	UserID string
+	T      *Template
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID string
+		T      *Template
+	}
+	mock.lockCreateTemplate.RLock()
+	calls = mock.calls.CreateTemplate
+	mock.lockCreateTemplate.RUnlock()
+	return calls
+}
+
+// DeleteProject calls DeleteProjectFunc.
+func (mock *RepositoryMock) DeleteProject(ctx context.Context, projectID string) error {
 	if mock.DeleteProjectFunc == nil {
 		panic("RepositoryMock.DeleteProjectFunc: method is nil but Repository.DeleteProject was just called")
 	}
@@ -332,6 +897,118 @@ func (mock *RepositoryMock) DeleteProjectByUserIDCalls() []struct {
 	return calls
 }
 
+// DeleteTemplate calls DeleteTemplateFunc.
+func (mock *RepositoryMock) DeleteTemplate(ctx context.Context, templateID string, userID string) error {
+	if mock.DeleteTemplateFunc == nil {
+		panic("RepositoryMock.DeleteTemplateFunc: method is nil but Repository.DeleteTemplate was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		TemplateID string
+		UserID     string
+	}{
+		Ctx:        ctx,
+		TemplateID: templateID,
+		UserID:     userID,
+	}
+	mock.lockDeleteTemplate.Lock()
+	mock.calls.DeleteTemplate = append(mock.calls.DeleteTemplate, callInfo)
+	mock.lockDeleteTemplate.Unlock()
+	return mock.DeleteTemplateFunc(ctx, templateID, userID)
+}
+
+// DeleteTemplateCalls gets all the calls that were made to DeleteTemplate.
+// Check the length with:
+//
+//	len(mockedRepository.DeleteTemplateCalls())
+func (mock *RepositoryMock) DeleteTemplateCalls() []struct {
+	Ctx        context.Context
+	TemplateID string
+	UserID     string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		TemplateID string
+		UserID     string
+	}
+	mock.lockDeleteTemplate.RLock()
+	calls = mock.calls.DeleteTemplate
+	mock.lockDeleteTemplate.RUnlock()
+	return calls
+}
+
+// GetDisclosure calls GetDisclosureFunc.
+func (mock *RepositoryMock) GetDisclosure(ctx context.Context, projectID string) (*Disclosure, error) {
+	if mock.GetDisclosureFunc == nil {
+		panic("RepositoryMock.GetDisclosureFunc: method is nil but Repository.GetDisclosure was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockGetDisclosure.Lock()
+	mock.calls.GetDisclosure = append(mock.calls.GetDisclosure, callInfo)
+	mock.lockGetDisclosure.Unlock()
+	return mock.GetDisclosureFunc(ctx, projectID)
+}
+
+// GetDisclosureCalls gets all the calls that were made to GetDisclosure.
+// Check the length with:
+//
+//	len(mockedRepository.GetDisclosureCalls())
+func (mock *RepositoryMock) GetDisclosureCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+	}
+	mock.lockGetDisclosure.RLock()
+	calls = mock.calls.GetDisclosure
+	mock.lockGetDisclosure.RUnlock()
+	return calls
+}
+
+// GetListing calls GetListingFunc.
+func (mock *RepositoryMock) GetListing(ctx context.Context, projectID string) (*Listing, error) {
+	if mock.GetListingFunc == nil {
+		panic("RepositoryMock.GetListingFunc: method is nil but Repository.GetListing was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockGetListing.Lock()
+	mock.calls.GetListing = append(mock.calls.GetListing, callInfo)
+	mock.lockGetListing.Unlock()
+	return mock.GetListingFunc(ctx, projectID)
+}
+
+// GetListingCalls gets all the calls that were made to GetListing.
+// Check the length with:
+//
+//	len(mockedRepository.GetListingCalls())
+func (mock *RepositoryMock) GetListingCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+	}
+	mock.lockGetListing.RLock()
+	calls = mock.calls.GetListing
+	mock.lockGetListing.RUnlock()
+	return calls
+}
+
 // GetProjectByID calls GetProjectByIDFunc.
 func (mock *RepositoryMock) GetProjectByID(ctx context.Context, projectID string) (*Project, error) {
 	if mock.GetProjectByIDFunc == nil {
@@ -408,6 +1085,82 @@ func (mock *RepositoryMock) GetProjectByIDAndUserIDCalls() []struct {
 	return calls
 }
 
+// GetProjectForMember calls GetProjectForMemberFunc.
+func (mock *RepositoryMock) GetProjectForMember(ctx context.Context, projectID string, userID string) (*Project, error) {
+	if mock.GetProjectForMemberFunc == nil {
+		panic("RepositoryMock.GetProjectForMemberFunc: method is nil but Repository.GetProjectForMember was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+		UserID    string
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+		UserID:    userID,
+	}
+	mock.lockGetProjectForMember.Lock()
+	mock.calls.GetProjectForMember = append(mock.calls.GetProjectForMember, callInfo)
+	mock.lockGetProjectForMember.Unlock()
+	return mock.GetProjectForMemberFunc(ctx, projectID, userID)
+}
+
+// GetProjectForMemberCalls gets all the calls that were made to GetProjectForMember.
+// Check the length with:
+//
+//	len(mockedRepository.GetProjectForMemberCalls())
+func (mock *RepositoryMock) GetProjectForMemberCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+	UserID    string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+		UserID    string
+	}
+	mock.lockGetProjectForMember.RLock()
+	calls = mock.calls.GetProjectForMember
+	mock.lockGetProjectForMember.RUnlock()
+	return calls
+}
+
+// GetProjectSummary calls GetProjectSummaryFunc.
+func (mock *RepositoryMock) GetProjectSummary(ctx context.Context, projectID string) (*Summary, error) {
+	if mock.GetProjectSummaryFunc == nil {
+		panic("RepositoryMock.GetProjectSummaryFunc: method is nil but Repository.GetProjectSummary was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockGetProjectSummary.Lock()
+	mock.calls.GetProjectSummary = append(mock.calls.GetProjectSummary, callInfo)
+	mock.lockGetProjectSummary.Unlock()
+	return mock.GetProjectSummaryFunc(ctx, projectID)
+}
+
+// GetProjectSummaryCalls gets all the calls that were made to GetProjectSummary.
+// Check the length with:
+//
+//	len(mockedRepository.GetProjectSummaryCalls())
+func (mock *RepositoryMock) GetProjectSummaryCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+	}
+	mock.lockGetProjectSummary.RLock()
+	calls = mock.calls.GetProjectSummary
+	mock.lockGetProjectSummary.RUnlock()
+	return calls
+}
+
 // GetProjects calls GetProjectsFunc.
 func (mock *RepositoryMock) GetProjects(ctx context.Context) ([]Project, error) {
 	if mock.GetProjectsFunc == nil {
@@ -476,6 +1229,550 @@ func (mock *RepositoryMock) GetProjectsByUserIDCalls() []struct {
 	return calls
 }
 
+// GetTemplate calls GetTemplateFunc.
+func (mock *RepositoryMock) GetTemplate(ctx context.Context, templateID string, userID string) (*Template, error) {
+	if mock.GetTemplateFunc == nil {
+		panic("RepositoryMock.GetTemplateFunc: method is nil but Repository.GetTemplate was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		TemplateID string
+		UserID     string
+	}{
+		Ctx:        ctx,
+		TemplateID: templateID,
+		UserID:     userID,
+	}
+	mock.lockGetTemplate.Lock()
+	mock.calls.GetTemplate = append(mock.calls.GetTemplate, callInfo)
+	mock.lockGetTemplate.Unlock()
+	return mock.GetTemplateFunc(ctx, templateID, userID)
+}
+
+// GetTemplateCalls gets all the calls that were made to GetTemplate.
+// Check the length with:
+//
+//	len(mockedRepository.GetTemplateCalls())
+func (mock *RepositoryMock) GetTemplateCalls() []struct {
+	Ctx        context.Context
+	TemplateID string
+	UserID     string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		TemplateID string
+		UserID     string
+	}
+	mock.lockGetTemplate.RLock()
+	calls = mock.calls.GetTemplate
+	mock.lockGetTemplate.RUnlock()
+	return calls
+}
+
+// IsRetentionExempt calls IsRetentionExemptFunc.
+func (mock *RepositoryMock) IsRetentionExempt(ctx context.Context, projectID string) (bool, error) {
+	if mock.IsRetentionExemptFunc == nil {
+		panic("RepositoryMock.IsRetentionExemptFunc: method is nil but Repository.IsRetentionExempt was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockIsRetentionExempt.Lock()
+	mock.calls.IsRetentionExempt = append(mock.calls.IsRetentionExempt, callInfo)
+	mock.lockIsRetentionExempt.Unlock()
+	return mock.IsRetentionExemptFunc(ctx, projectID)
+}
+
+// IsRetentionExemptCalls gets all the calls that were made to IsRetentionExempt.
+// Check the length with:
+//
+//	len(mockedRepository.IsRetentionExemptCalls())
+func (mock *RepositoryMock) IsRetentionExemptCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+	}
+	mock.lockIsRetentionExempt.RLock()
+	calls = mock.calls.IsRetentionExempt
+	mock.lockIsRetentionExempt.RUnlock()
+	return calls
+}
+
+// IsUnderLegalHold calls IsUnderLegalHoldFunc.
+func (mock *RepositoryMock) IsUnderLegalHold(ctx context.Context, projectID string) (bool, error) {
+	if mock.IsUnderLegalHoldFunc == nil {
+		panic("RepositoryMock.IsUnderLegalHoldFunc: method is nil but Repository.IsUnderLegalHold was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockIsUnderLegalHold.Lock()
+	mock.calls.IsUnderLegalHold = append(mock.calls.IsUnderLegalHold, callInfo)
+	mock.lockIsUnderLegalHold.Unlock()
+	return mock.IsUnderLegalHoldFunc(ctx, projectID)
+}
+
+// IsUnderLegalHoldCalls gets all the calls that were made to IsUnderLegalHold.
+// Check the length with:
+//
+//	len(mockedRepository.IsUnderLegalHoldCalls())
+func (mock *RepositoryMock) IsUnderLegalHoldCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+	}
+	mock.lockIsUnderLegalHold.RLock()
+	calls = mock.calls.IsUnderLegalHold
+	mock.lockIsUnderLegalHold.RUnlock()
+	return calls
+}
+
+// ListImageRooms calls ListImageRoomsFunc.
+func (mock *RepositoryMock) ListImageRooms(ctx context.Context, projectID string) ([]Room, error) {
+	if mock.ListImageRoomsFunc == nil {
+		panic("RepositoryMock.ListImageRoomsFunc: method is nil but Repository.ListImageRooms was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockListImageRooms.Lock()
+	mock.calls.ListImageRooms = append(mock.calls.ListImageRooms, callInfo)
+	mock.lockListImageRooms.Unlock()
+	return mock.ListImageRoomsFunc(ctx, projectID)
+}
+
+// ListImageRoomsCalls gets all the calls that were made to ListImageRooms.
+// Check the length with:
+//
+//	len(mockedRepository.ListImageRoomsCalls())
+func (mock *RepositoryMock) ListImageRoomsCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+	}
+	mock.lockListImageRooms.RLock()
+	calls = mock.calls.ListImageRooms
+	mock.lockListImageRooms.RUnlock()
+	return calls
+}
+
+// ListProjectActivity calls ListProjectActivityFunc.
+func (mock *RepositoryMock) ListProjectActivity(ctx context.Context, projectID string, limit int32, offset int32) ([]ActivityEvent, error) {
+	if mock.ListProjectActivityFunc == nil {
+		panic("RepositoryMock.ListProjectActivityFunc: method is nil but Repository.ListProjectActivity was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+		Limit     int32
+		Offset    int32
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+		Limit:     limit,
+		Offset:    offset,
+	}
+	mock.lockListProjectActivity.Lock()
+	mock.calls.ListProjectActivity = append(mock.calls.ListProjectActivity, callInfo)
+	mock.lockListProjectActivity.Unlock()
+	return mock.ListProjectActivityFunc(ctx, projectID, limit, offset)
+}
+
+// ListProjectActivityCalls gets all the calls that were made to ListProjectActivity.
+// Check the length with:
+//
+//	len(mockedRepository.ListProjectActivityCalls())
+func (mock *RepositoryMock) ListProjectActivityCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+	Limit     int32
+	Offset    int32
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+		Limit     int32
+		Offset    int32
+	}
+	mock.lockListProjectActivity.RLock()
+	calls = mock.calls.ListProjectActivity
+	mock.lockListProjectActivity.RUnlock()
+	return calls
+}
+
+// ListProjectThumbnails calls ListProjectThumbnailsFunc.
+func (mock *RepositoryMock) ListProjectThumbnails(ctx context.Context, projectID string, limit int32) ([]Thumbnail, error) {
+	if mock.ListProjectThumbnailsFunc == nil {
+		panic("RepositoryMock.ListProjectThumbnailsFunc: method is nil but Repository.ListProjectThumbnails was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+		Limit     int32
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+		Limit:     limit,
+	}
+	mock.lockListProjectThumbnails.Lock()
+	mock.calls.ListProjectThumbnails = append(mock.calls.ListProjectThumbnails, callInfo)
+	mock.lockListProjectThumbnails.Unlock()
+	return mock.ListProjectThumbnailsFunc(ctx, projectID, limit)
+}
+
+// ListProjectThumbnailsCalls gets all the calls that were made to ListProjectThumbnails.
+// Check the length with:
+//
+//	len(mockedRepository.ListProjectThumbnailsCalls())
+func (mock *RepositoryMock) ListProjectThumbnailsCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+	Limit     int32
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+		Limit     int32
+	}
+	mock.lockListProjectThumbnails.RLock()
+	calls = mock.calls.ListProjectThumbnails
+	mock.lockListProjectThumbnails.RUnlock()
+	return calls
+}
+
+// ListRooms calls ListRoomsFunc.
+func (mock *RepositoryMock) ListRooms(ctx context.Context, projectID string) ([]Room, error) {
+	if mock.ListRoomsFunc == nil {
+		panic("RepositoryMock.ListRoomsFunc: method is nil but Repository.ListRooms was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockListRooms.Lock()
+	mock.calls.ListRooms = append(mock.calls.ListRooms, callInfo)
+	mock.lockListRooms.Unlock()
+	return mock.ListRoomsFunc(ctx, projectID)
+}
+
+// ListRoomsCalls gets all the calls that were made to ListRooms.
+// Check the length with:
+//
+//	len(mockedRepository.ListRoomsCalls())
+func (mock *RepositoryMock) ListRoomsCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+	}
+	mock.lockListRooms.RLock()
+	calls = mock.calls.ListRooms
+	mock.lockListRooms.RUnlock()
+	return calls
+}
+
+// ListSummariesByUser calls ListSummariesByUserFunc.
+func (mock *RepositoryMock) ListSummariesByUser(ctx context.Context, projectIDs []string, userID string) ([]Summary, error) {
+	if mock.ListSummariesByUserFunc == nil {
+		panic("RepositoryMock.ListSummariesByUserFunc: method is nil but Repository.ListSummariesByUser was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		ProjectIDs []string
+		UserID     string
+	}{
+		Ctx:        ctx,
+		ProjectIDs: projectIDs,
+		UserID:     userID,
+	}
+	mock.lockListSummariesByUser.Lock()
+	mock.calls.ListSummariesByUser = append(mock.calls.ListSummariesByUser, callInfo)
+	mock.lockListSummariesByUser.Unlock()
+	return mock.ListSummariesByUserFunc(ctx, projectIDs, userID)
+}
+
+// ListSummariesByUserCalls gets all the calls that were made to ListSummariesByUser.
+// Check the length with:
+//
+//	len(mockedRepository.ListSummariesByUserCalls())
+func (mock *RepositoryMock) ListSummariesByUserCalls() []struct {
+	Ctx        context.Context
+	ProjectIDs []string
+	UserID     string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		ProjectIDs []string
+		UserID     string
+	}
+	mock.lockListSummariesByUser.RLock()
+	calls = mock.calls.ListSummariesByUser
+	mock.lockListSummariesByUser.RUnlock()
+	return calls
+}
+
+// ListTemplates calls ListTemplatesFunc.
+func (mock *RepositoryMock) ListTemplates(ctx context.Context, userID string) ([]Template, error) {
+	if mock.ListTemplatesFunc == nil {
+		panic("RepositoryMock.ListTemplatesFunc: method is nil but Repository.ListTemplates was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockListTemplates.Lock()
+	mock.calls.ListTemplates = append(mock.calls.ListTemplates, callInfo)
+	mock.lockListTemplates.Unlock()
+	return mock.ListTemplatesFunc(ctx, userID)
+}
+
+// ListTemplatesCalls gets all the calls that were made to ListTemplates.
+// Check the length with:
+//
+//	len(mockedRepository.ListTemplatesCalls())
+func (mock *RepositoryMock) ListTemplatesCalls() []struct {
+	Ctx    context.Context
+	UserID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID string
+	}
+	mock.lockListTemplates.RLock()
+	calls = mock.calls.ListTemplates
+	mock.lockListTemplates.RUnlock()
+	return calls
+}
+
+// SaveDeletionIntent calls SaveDeletionIntentFunc.
+func (mock *RepositoryMock) SaveDeletionIntent(ctx context.Context, projectID string, userID string, tokenHash []byte, expiresAt time.Time) error {
+	if mock.SaveDeletionIntentFunc == nil {
+		panic("RepositoryMock.SaveDeletionIntentFunc: method is nil but Repository.SaveDeletionIntent was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+		UserID    string
+		TokenHash []byte
+		ExpiresAt time.Time
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+		UserID:    userID,
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
+	}
+	mock.lockSaveDeletionIntent.Lock()
+	mock.calls.SaveDeletionIntent = append(mock.calls.SaveDeletionIntent, callInfo)
+	mock.lockSaveDeletionIntent.Unlock()
+	return mock.SaveDeletionIntentFunc(ctx, projectID, userID, tokenHash, expiresAt)
+}
+
+// SaveDeletionIntentCalls gets all the calls that were made to SaveDeletionIntent.
+// Check the length with:
+//
+//	len(mockedRepository.SaveDeletionIntentCalls())
+func (mock *RepositoryMock) SaveDeletionIntentCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+	UserID    string
+	TokenHash []byte
+	ExpiresAt time.Time
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+		UserID    string
+		TokenHash []byte
+		ExpiresAt time.Time
+	}
+	mock.lockSaveDeletionIntent.RLock()
+	calls = mock.calls.SaveDeletionIntent
+	mock.lockSaveDeletionIntent.RUnlock()
+	return calls
+}
+
+// SaveDisclosure calls SaveDisclosureFunc.
+func (mock *RepositoryMock) SaveDisclosure(ctx context.Context, d *Disclosure) (*Disclosure, error) {
+	if mock.SaveDisclosureFunc == nil {
+		panic("RepositoryMock.SaveDisclosureFunc: method is nil but Repository.SaveDisclosure was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		D   *Disclosure
+	}{
+		Ctx: ctx,
+		D:   d,
+	}
+	mock.lockSaveDisclosure.Lock()
+	mock.calls.SaveDisclosure = append(mock.calls.SaveDisclosure, callInfo)
+	mock.lockSaveDisclosure.Unlock()
+	return mock.SaveDisclosureFunc(ctx, d)
+}
+
+// SaveDisclosureCalls gets all the calls that were made to SaveDisclosure.
+// Check the length with:
+//
+//	len(mockedRepository.SaveDisclosureCalls())
+func (mock *RepositoryMock) SaveDisclosureCalls() []struct {
+	Ctx context.Context
+	D   *Disclosure
+} {
+	var calls []struct {
+		Ctx context.Context
+		D   *Disclosure
+	}
+	mock.lockSaveDisclosure.RLock()
+	calls = mock.calls.SaveDisclosure
+	mock.lockSaveDisclosure.RUnlock()
+	return calls
+}
+
+// SaveListing calls SaveListingFunc.
+func (mock *RepositoryMock) SaveListing(ctx context.Context, l *Listing) (*Listing, error) {
+	if mock.SaveListingFunc == nil {
+		panic("RepositoryMock.SaveListingFunc: method is nil but Repository.SaveListing was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		L   *Listing
+	}{
+		Ctx: ctx,
+		L:   l,
+	}
+	mock.lockSaveListing.Lock()
+	mock.calls.SaveListing = append(mock.calls.SaveListing, callInfo)
+	mock.lockSaveListing.Unlock()
+	return mock.SaveListingFunc(ctx, l)
+}
+
+// SaveListingCalls gets all the calls that were made to SaveListing.
+// Check the length with:
+//
+//	len(mockedRepository.SaveListingCalls())
+func (mock *RepositoryMock) SaveListingCalls() []struct {
+	Ctx context.Context
+	L   *Listing
+} {
+	var calls []struct {
+		Ctx context.Context
+		L   *Listing
+	}
+	mock.lockSaveListing.RLock()
+	calls = mock.calls.SaveListing
+	mock.lockSaveListing.RUnlock()
+	return calls
+}
+
+// SaveRooms calls SaveRoomsFunc.
+func (mock *RepositoryMock) SaveRooms(ctx context.Context, projectID string, rooms []Room) error {
+	if mock.SaveRoomsFunc == nil {
+		panic("RepositoryMock.SaveRoomsFunc: method is nil but Repository.SaveRooms was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+		Rooms     []Room
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+		Rooms:     rooms,
+	}
+	mock.lockSaveRooms.Lock()
+	mock.calls.SaveRooms = append(mock.calls.SaveRooms, callInfo)
+	mock.lockSaveRooms.Unlock()
+	return mock.SaveRoomsFunc(ctx, projectID, rooms)
+}
+
+// SaveRoomsCalls gets all the calls that were made to SaveRooms.
+// Check the length with:
+//
+//	len(mockedRepository.SaveRoomsCalls())
+func (mock *RepositoryMock) SaveRoomsCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+	Rooms     []Room
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+		Rooms     []Room
+	}
+	mock.lockSaveRooms.RLock()
+	calls = mock.calls.SaveRooms
+	mock.lockSaveRooms.RUnlock()
+	return calls
+}
+
+// SetRetentionExempt calls SetRetentionExemptFunc.
+func (mock *RepositoryMock) SetRetentionExempt(ctx context.Context, projectID string, exempt bool) error {
+	if mock.SetRetentionExemptFunc == nil {
+		panic("RepositoryMock.SetRetentionExemptFunc: method is nil but Repository.SetRetentionExempt was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+		Exempt    bool
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+		Exempt:    exempt,
+	}
+	mock.lockSetRetentionExempt.Lock()
+	mock.calls.SetRetentionExempt = append(mock.calls.SetRetentionExempt, callInfo)
+	mock.lockSetRetentionExempt.Unlock()
+	return mock.SetRetentionExemptFunc(ctx, projectID, exempt)
+}
+
+// SetRetentionExemptCalls gets all the calls that were made to SetRetentionExempt.
+// Check the length with:
+//
+//	len(mockedRepository.SetRetentionExemptCalls())
+func (mock *RepositoryMock) SetRetentionExemptCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+	Exempt    bool
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+		Exempt    bool
+	}
+	mock.lockSetRetentionExempt.RLock()
+	calls = mock.calls.SetRetentionExempt
+	mock.lockSetRetentionExempt.RUnlock()
+	return calls
+}
+
 // UpdateProject calls UpdateProjectFunc.
 func (mock *RepositoryMock) UpdateProject(ctx context.Context, projectID string, name string) (*Project, error) {
 	if mock.UpdateProjectFunc == nil {
@@ -0,0 +1,12 @@
+package project
+
+// RetentionResponse reports whether a project is excluded from retention purging.
+type RetentionResponse struct {
+	ProjectID string `json:"project_id"`
+	Exempt    bool   `json:"exempt"`
+}
+
+// RetentionRequest sets whether a project is excluded from retention purging.
+type RetentionRequest struct {
+	Exempt *bool `json:"exempt"`
+}
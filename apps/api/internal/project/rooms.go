@@ -0,0 +1,145 @@
+package project
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/real-staging-ai/api/internal/preset"
+)
+
+// Room checklist limits.
+const (
+	MaxRooms           = 30
+	MaxRoomLabelLength = 50
+)
+
+// Room is one entry of a room checklist: a room the stager expects to shoot, and the style
+// its images default to.
+type Room struct {
+	RoomType string `json:"room_type"`
+	// Style is used for new images of the room type that do not ask for one.
+	Style *string `json:"style,omitempty"`
+	// Label tells apart rooms of the same type, e.g. "Primary bedroom".
+	Label string `json:"label,omitempty"`
+}
+
+// RoomImageCount is how many of a project's images are of a room type, and how many of
+// those are staged.
+type RoomImageCount struct {
+	RoomType string
+	Uploaded int64
+	Staged   int64
+}
+
+// RoomCoverage compares the photos of a room type with the rooms of that type on the
+// checklist.
+type RoomCoverage struct {
+	RoomType string `json:"room_type"`
+	// Expected is how many rooms of the type are on the checklist.
+	Expected int64 `json:"expected"`
+	Uploaded int64 `json:"uploaded"`
+	Staged   int64 `json:"staged"`
+	// Missing is how many of the expected rooms still lack a photo.
+	Missing int64 `json:"missing"`
+}
+
+// RoomsResponse is a project's room checklist and how far its photos cover it.
+type RoomsResponse struct {
+	ProjectID string `json:"project_id"`
+	Rooms     []Room `json:"rooms"`
+	// Coverage has an entry per room type on the checklist, in checklist order, followed by
+	// room types the project has images of but the checklist leaves out.
+	Coverage []RoomCoverage `json:"coverage"`
+	// Missing is how many rooms on the checklist still lack a photo.
+	Missing int64 `json:"missing"`
+	// Complete reports whether every room on the checklist has a photo.
+	Complete bool `json:"complete"`
+}
+
+// RoomsRequest replaces a project's room checklist. An empty list clears it.
+type RoomsRequest struct {
+	Rooms *[]Room `json:"rooms"`
+}
+
+// Validate checks the request, trimming room labels in place.
+func (r *RoomsRequest) Validate() []ValidationErrorDetail {
+	if r.Rooms == nil {
+		return []ValidationErrorDetail{{Field: "rooms", Message: "rooms is required"}}
+	}
+	return validateRooms(*r.Rooms)
+}
+
+// newRoomsResponse builds the response for a project's checklist and image counts.
+func newRoomsResponse(projectID string, rooms []Room, counts []RoomImageCount) *RoomsResponse {
+	resp := &RoomsResponse{ProjectID: projectID, Rooms: rooms, Coverage: roomCoverage(rooms, counts)}
+	for _, c := range resp.Coverage {
+		resp.Missing += c.Missing
+	}
+	resp.Complete = resp.Missing == 0
+	return resp
+}
+
+// roomCoverage counts the checklist's rooms of each type against the project's images.
+func roomCoverage(rooms []Room, counts []RoomImageCount) []RoomCoverage {
+	coverage := []RoomCoverage{}
+	index := map[string]int{}
+	entry := func(roomType string) *RoomCoverage {
+		i, ok := index[roomType]
+		if !ok {
+			i = len(coverage)
+			index[roomType] = i
+			coverage = append(coverage, RoomCoverage{RoomType: roomType})
+		}
+		return &coverage[i]
+	}
+
+	for _, r := range rooms {
+		entry(r.RoomType).Expected++
+	}
+	// Images of types off the checklist are listed in a stable order.
+	slices.SortFunc(counts, func(a, b RoomImageCount) int { return strings.Compare(a.RoomType, b.RoomType) })
+	for _, n := range counts {
+		c := entry(n.RoomType)
+		c.Uploaded = n.Uploaded
+		c.Staged = n.Staged
+	}
+	for i := range coverage {
+		coverage[i].Missing = max(coverage[i].Expected-coverage[i].Uploaded, 0)
+	}
+	return coverage
+}
+
+func validateRooms(rooms []Room) []ValidationErrorDetail {
+	var errs []ValidationErrorDetail
+	if len(rooms) > MaxRooms {
+		return append(errs, ValidationErrorDetail{
+			Field:   "rooms",
+			Message: fmt.Sprintf("rooms must contain at most %d rooms", MaxRooms),
+		})
+	}
+	for i := range rooms {
+		room := &rooms[i]
+		room.Label = strings.TrimSpace(room.Label)
+		if !slices.Contains(preset.RoomTypes, room.RoomType) {
+			errs = append(errs, ValidationErrorDetail{
+				Field:   fmt.Sprintf("rooms[%d].room_type", i),
+				Message: "room_type must be one of " + strings.Join(preset.RoomTypes, ", "),
+			})
+		}
+		if room.Style != nil && !slices.Contains(preset.Styles, *room.Style) {
+			errs = append(errs, ValidationErrorDetail{
+				Field:   fmt.Sprintf("rooms[%d].style", i),
+				Message: "style must be one of " + strings.Join(preset.Styles, ", "),
+			})
+		}
+		if utf8.RuneCountInString(room.Label) > MaxRoomLabelLength {
+			errs = append(errs, ValidationErrorDetail{
+				Field:   fmt.Sprintf("rooms[%d].label", i),
+				Message: fmt.Sprintf("label must be %d characters or less", MaxRoomLabelLength),
+			})
+		}
+	}
+	return errs
+}
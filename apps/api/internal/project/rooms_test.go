@@ -0,0 +1,63 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoomCoverage(t *testing.T) {
+	cases := []struct {
+		name   string
+		rooms  []Room
+		counts []RoomImageCount
+		want   []RoomCoverage
+	}{
+		{
+			name: "success: empty checklist and no images",
+			want: []RoomCoverage{},
+		},
+		{
+			name:  "success: rooms of a type share its photos",
+			rooms: []Room{{RoomType: "bedroom"}, {RoomType: "kitchen"}, {RoomType: "bedroom"}, {RoomType: "bedroom"}},
+			counts: []RoomImageCount{
+				{RoomType: "bedroom", Uploaded: 2, Staged: 2},
+			},
+			want: []RoomCoverage{
+				{RoomType: "bedroom", Expected: 3, Uploaded: 2, Staged: 2, Missing: 1},
+				{RoomType: "kitchen", Expected: 1, Missing: 1},
+			},
+		},
+		{
+			name:  "success: extra photos do not count against other rooms",
+			rooms: []Room{{RoomType: "kitchen"}},
+			counts: []RoomImageCount{
+				{RoomType: "outdoor", Uploaded: 1},
+				{RoomType: "kitchen", Uploaded: 4, Staged: 1},
+				{RoomType: "bathroom", Uploaded: 2, Staged: 2},
+			},
+			want: []RoomCoverage{
+				{RoomType: "kitchen", Expected: 1, Uploaded: 4, Staged: 1},
+				{RoomType: "bathroom", Uploaded: 2, Staged: 2},
+				{RoomType: "outdoor", Uploaded: 1},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, roomCoverage(tc.rooms, tc.counts))
+		})
+	}
+}
+
+func TestNewRoomsResponse(t *testing.T) {
+	resp := newRoomsResponse("p1", []Room{{RoomType: "bedroom"}, {RoomType: "bedroom"}},
+		[]RoomImageCount{{RoomType: "bedroom", Uploaded: 1}})
+	assert.Equal(t, int64(1), resp.Missing)
+	assert.False(t, resp.Complete)
+
+	resp = newRoomsResponse("p1", nil, nil)
+	assert.Equal(t, int64(0), resp.Missing)
+	assert.True(t, resp.Complete)
+}
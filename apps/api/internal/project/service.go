@@ -12,6 +12,8 @@ type Service interface {
 	GetProjectsByUser(ctx context.Context, userID string) ([]Project, error)
 	GetProjectByID(ctx context.Context, projectID, userID string) (*Project, error)
 	UpdateProject(ctx context.Context, projectID, userID, newName string) (*Project, error)
-	DeleteProject(ctx context.Context, projectID, userID string) error
+	DeleteProject(ctx context.Context, projectID, userID, confirmationToken string) (*DeletionIntent, error)
 	GetProjectStats(ctx context.Context, userID string) (*ProjectStats, error)
+	SaveTemplate(ctx context.Context, projectID, userID string, req *TemplateRequest) (*Template, error)
+	CreateProjectFromTemplate(ctx context.Context, req *CreateRequest, templateID string) (*Project, error)
 }
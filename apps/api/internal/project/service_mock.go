@@ -21,10 +21,13 @@ var _ Service = &ServiceMock{}
 //			CreateProjectFunc: func(ctx context.Context, req *CreateRequest) (*Project, error) {
 //				panic("mock out the CreateProject method")
 //			},
+//			CreateProjectFromTemplateFunc: func(ctx context.Context, req *CreateRequest, templateID string) (*Project, error) {
+//				panic("mock out the CreateProjectFromTemplate method")
+//			},
 //			CreateProjectWithUploadFunc: func(ctx context.Context, req *CreateRequest, filename string, contentType string, fileSize int64) (*WithUploadURL, error) {
 //				panic("mock out the CreateProjectWithUpload method")
 //			},
-//			DeleteProjectFunc: func(ctx context.Context, projectID string, userID string) error {
+//			DeleteProjectFunc: func(ctx context.Context, projectID string, userID string, confirmationToken string) (*DeletionIntent, error) {
 //				panic("mock out the DeleteProject method")
 //			},
 //			GetProjectByIDFunc: func(ctx context.Context, projectID string, userID string) (*Project, error) {
@@ -36,6 +39,9 @@ var _ Service = &ServiceMock{}
 //			GetProjectsByUserFunc: func(ctx context.Context, userID string) ([]Project, error) {
 //				panic("mock out the GetProjectsByUser method")
 //			},
+//			SaveTemplateFunc: func(ctx context.Context, projectID string, userID string, req *TemplateRequest) (*Template, error) {
+//				panic("mock out the SaveTemplate method")
+//			},
 //			UpdateProjectFunc: func(ctx context.Context, projectID string, userID string, newName string) (*Project, error) {
 //				panic("mock out the UpdateProject method")
 //			},
@@ -49,11 +55,14 @@ type ServiceMock struct {
 	// CreateProjectFunc mocks the CreateProject method.
 	CreateProjectFunc func(ctx context.Context, req *CreateRequest) (*Project, error)
 
+	// CreateProjectFromTemplateFunc mocks the CreateProjectFromTemplate method.
+	CreateProjectFromTemplateFunc func(ctx context.Context, req *CreateRequest, templateID string) (*Project, error)
+
 	// CreateProjectWithUploadFunc mocks the CreateProjectWithUpload method.
 	CreateProjectWithUploadFunc func(ctx context.Context, req *CreateRequest, filename string, contentType string, fileSize int64) (*WithUploadURL, error)
 
 	// DeleteProjectFunc mocks the DeleteProject method.
-	DeleteProjectFunc func(ctx context.Context, projectID string, userID string) error
+	DeleteProjectFunc func(ctx context.Context, projectID string, userID string, confirmationToken string) (*DeletionIntent, error)
 
 	// GetProjectByIDFunc mocks the GetProjectByID method.
 	GetProjectByIDFunc func(ctx context.Context, projectID string, userID string) (*Project, error)
@@ -64,6 +73,9 @@ type ServiceMock struct {
 	// GetProjectsByUserFunc mocks the GetProjectsByUser method.
 	GetProjectsByUserFunc func(ctx context.Context, userID string) ([]Project, error)
 
+	// SaveTemplateFunc mocks the SaveTemplate method.
+	SaveTemplateFunc func(ctx context.Context, projectID string, userID string, req *TemplateRequest) (*Template, error)
+
 	// UpdateProjectFunc mocks the UpdateProject method.
 	UpdateProjectFunc func(ctx context.Context, projectID string, userID string, newName string) (*Project, error)
 
@@ -76,6 +88,15 @@ type ServiceMock struct {
 			// Req is the req argument value.
 			Req *CreateRequest
 		}
+		// CreateProjectFromTemplate holds details about calls to the CreateProjectFromTemplate method.
+		CreateProjectFromTemplate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *CreateRequest
+			// TemplateID is the templateID argument value.
+			TemplateID string
+		}
 		// CreateProjectWithUpload holds details about calls to the CreateProjectWithUpload method.
 		CreateProjectWithUpload []struct {
 			// Ctx is the ctx argument value.
@@ -97,6 +118,8 @@ type ServiceMock struct {
 			ProjectID string
 			// UserID is the userID argument value.
 			UserID string
+			// ConfirmationToken is the confirmationToken argument value.
+			ConfirmationToken string
 		}
 		// GetProjectByID holds details about calls to the GetProjectByID method.
 		GetProjectByID []struct {
@@ -121,6 +144,17 @@ type ServiceMock struct {
 			// UserID is the userID argument value.
 			UserID string
 		}
+		// SaveTemplate holds details about calls to the SaveTemplate method.
+		SaveTemplate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+			// UserID is the userID argument value.
+			UserID string
+			// Req is the req argument value.
+			Req *TemplateRequest
+		}
 		// UpdateProject holds details about calls to the UpdateProject method.
 		UpdateProject []struct {
 			// Ctx is the ctx argument value.
@@ -133,13 +167,15 @@ type ServiceMock struct {
 			NewName string
 		}
 	}
-	lockCreateProject           sync.RWMutex
-	lockCreateProjectWithUpload sync.RWMutex
-	lockDeleteProject           sync.RWMutex
-	lockGetProjectByID          sync.RWMutex
-	lockGetProjectStats         sync.RWMutex
-	lockGetProjectsByUser       sync.RWMutex
-	lockUpdateProject           sync.RWMutex
+	lockCreateProject             sync.RWMutex
+	lockCreateProjectFromTemplate sync.RWMutex
+	lockCreateProjectWithUpload   sync.RWMutex
+	lockDeleteProject             sync.RWMutex
+	lockGetProjectByID            sync.RWMutex
+	lockGetProjectStats           sync.RWMutex
+	lockGetProjectsByUser         sync.RWMutex
+	lockSaveTemplate              sync.RWMutex
+	lockUpdateProject             sync.RWMutex
 }
 
 // CreateProject calls CreateProjectFunc.
@@ -178,6 +214,46 @@ func (mock *ServiceMock) CreateProjectCalls() []struct {
 	return calls
 }
 
+// CreateProjectFromTemplate calls CreateProjectFromTemplateFunc.
+func (mock *ServiceMock) CreateProjectFromTemplate(ctx context.Context, req *CreateRequest, templateID string) (*Project, error) {
+	if mock.CreateProjectFromTemplateFunc == nil {
+		panic("ServiceMock.CreateProjectFromTemplateFunc: method is nil but Service.CreateProjectFromTemplate was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Req        *CreateRequest
+		TemplateID string
+	}{
+		Ctx:        ctx,
+		Req:        req,
+		TemplateID: templateID,
+	}
+	mock.lockCreateProjectFromTemplate.Lock()
+	mock.calls.CreateProjectFromTemplate = append(mock.calls.CreateProjectFromTemplate, callInfo)
+	mock.lockCreateProjectFromTemplate.Unlock()
+	return mock.CreateProjectFromTemplateFunc(ctx, req, templateID)
+}
+
+// CreateProjectFromTemplateCalls gets all the calls that were made to CreateProjectFromTemplate.
+// Check the length with:
+//
+//	len(mockedService.CreateProjectFromTemplateCalls())
+func (mock *ServiceMock) CreateProjectFromTemplateCalls() []struct {
+	Ctx        context.Context
+	Req        *CreateRequest
+	TemplateID string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Req        *CreateRequest
+		TemplateID string
+	}
+	mock.lockCreateProjectFromTemplate.RLock()
+	calls = mock.calls.CreateProjectFromTemplate
+	mock.lockCreateProjectFromTemplate.RUnlock()
+	return calls
+}
+
 // CreateProjectWithUpload calls CreateProjectWithUploadFunc.
 func (mock *ServiceMock) CreateProjectWithUpload(ctx context.Context, req *CreateRequest, filename string, contentType string, fileSize int64) (*WithUploadURL, error) {
 	if mock.CreateProjectWithUploadFunc == nil {
@@ -227,23 +303,25 @@ func (mock *ServiceMock) CreateProjectWithUploadCalls() []struct {
 }
 
 // DeleteProject calls DeleteProjectFunc.
-func (mock *ServiceMock) DeleteProject(ctx context.Context, projectID string, userID string) error {
+func (mock *ServiceMock) DeleteProject(ctx context.Context, projectID string, userID string, confirmationToken string) (*DeletionIntent, error) {
 	if mock.DeleteProjectFunc == nil {
 		panic("ServiceMock.DeleteProjectFunc: method is nil but Service.DeleteProject was just called")
 	}
 	callInfo := struct {
-		Ctx       context.Context
-		ProjectID string
-		UserID    string
+		Ctx               context.Context
+		ProjectID         string
+		UserID            string
+		ConfirmationToken string
 	}{
-		Ctx:       ctx,
-		ProjectID: projectID,
-		UserID:    userID,
+		Ctx:               ctx,
+		ProjectID:         projectID,
+		UserID:            userID,
+		ConfirmationToken: confirmationToken,
 	}
 	mock.lockDeleteProject.Lock()
 	mock.calls.DeleteProject = append(mock.calls.DeleteProject, callInfo)
 	mock.lockDeleteProject.Unlock()
-	return mock.DeleteProjectFunc(ctx, projectID, userID)
+	return mock.DeleteProjectFunc(ctx, projectID, userID, confirmationToken)
 }
 
 // DeleteProjectCalls gets all the calls that were made to DeleteProject.
@@ -251,14 +329,16 @@ func (mock *ServiceMock) DeleteProject(ctx context.Context, projectID string, us
 //
 //	len(mockedService.DeleteProjectCalls())
 func (mock *ServiceMock) DeleteProjectCalls() []struct {
-	Ctx       context.Context
-	ProjectID string
-	UserID    string
+	Ctx               context.Context
+	ProjectID         string
+	UserID            string
+	ConfirmationToken string
 } {
 	var calls []struct {
-		Ctx       context.Context
-		ProjectID string
-		UserID    string
+		Ctx               context.Context
+		ProjectID         string
+		UserID            string
+		ConfirmationToken string
 	}
 	mock.lockDeleteProject.RLock()
 	calls = mock.calls.DeleteProject
@@ -378,6 +458,50 @@ func (mock *ServiceMock) GetProjectsByUserCalls() []struct {
 	return calls
 }
 
+// SaveTemplate calls SaveTemplateFunc.
+func (mock *ServiceMock) SaveTemplate(ctx context.Context, projectID string, userID string, req *TemplateRequest) (*Template, error) {
+	if mock.SaveTemplateFunc == nil {
+		panic("ServiceMock.SaveTemplateFunc: method is nil but Service.SaveTemplate was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+		UserID    string
+		Req       *TemplateRequest
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+		UserID:    userID,
+		Req:       req,
+	}
+	mock.lockSaveTemplate.Lock()
+	mock.calls.SaveTemplate = append(mock.calls.SaveTemplate, callInfo)
+	mock.lockSaveTemplate.Unlock()
+	return mock.SaveTemplateFunc(ctx, projectID, userID, req)
+}
+
+// SaveTemplateCalls gets all the calls that were made to SaveTemplate.
+// Check the length with:
+//
+//	len(mockedService.SaveTemplateCalls())
+func (mock *ServiceMock) SaveTemplateCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+	UserID    string
+	Req       *TemplateRequest
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+		UserID    string
+		Req       *TemplateRequest
+	}
+	mock.lockSaveTemplate.RLock()
+	calls = mock.calls.SaveTemplate
+	mock.lockSaveTemplate.RUnlock()
+	return calls
+}
+
 // UpdateProject calls UpdateProjectFunc.
 func (mock *ServiceMock) UpdateProject(ctx context.Context, projectID string, userID string, newName string) (*Project, error) {
 	if mock.UpdateProjectFunc == nil {
@@ -1,6 +1,9 @@
 package project
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 //go:generate go run github.com/matryer/moq@v0.5.3 -out storage_sqlc_mock.go . StorageSQLc
 
@@ -10,9 +13,33 @@ type StorageSQLc interface {
 	GetProjectsByUserID(ctx context.Context, userID string) ([]Project, error)
 	GetProjectByID(ctx context.Context, projectID string) (*Project, error)
 	GetProjectByIDAndUserID(ctx context.Context, projectID, userID string) (*Project, error)
+	GetProjectForMember(ctx context.Context, projectID, userID string) (*Project, error)
 	UpdateProject(ctx context.Context, projectID, name string) (*Project, error)
 	UpdateProjectByUserID(ctx context.Context, projectID, userID, name string) (*Project, error)
 	DeleteProject(ctx context.Context, projectID string) error
 	DeleteProjectByUserID(ctx context.Context, projectID, userID string) error
 	CountProjectsByUserID(ctx context.Context, userID string) (int64, error)
+	ListProjectActivity(ctx context.Context, projectID string, limit, offset int32) ([]ActivityEvent, error)
+	GetProjectSummary(ctx context.Context, projectID string) (*Summary, error)
+	ListProjectThumbnails(ctx context.Context, projectID string, limit int32) ([]Thumbnail, error)
+	ListSummariesByUser(ctx context.Context, projectIDs []string, userID string) ([]Summary, error)
+	IsRetentionExempt(ctx context.Context, projectID string) (bool, error)
+	SetRetentionExempt(ctx context.Context, projectID string, exempt bool) error
+	IsUnderLegalHold(ctx context.Context, projectID string) (bool, error)
+	SaveDeletionIntent(ctx context.Context, projectID, userID string, tokenHash []byte, expiresAt time.Time) error
+	ConsumeDeletionIntent(ctx context.Context, projectID, userID string, tokenHash []byte) (bool, error)
+	GetDisclosure(ctx context.Context, projectID string) (*Disclosure, error)
+	SaveDisclosure(ctx context.Context, d *Disclosure) (*Disclosure, error)
+	GetListing(ctx context.Context, projectID string) (*Listing, error)
+	SaveListing(ctx context.Context, l *Listing) (*Listing, error)
+	CreateTemplate(ctx context.Context, userID string, t *Template) (*Template, error)
+	CountTemplates(ctx context.Context, userID string) (int64, error)
+	GetTemplate(ctx context.Context, templateID, userID string) (*Template, error)
+	ListTemplates(ctx context.Context, userID string) ([]Template, error)
+	DeleteTemplate(ctx context.Context, templateID, userID string) error
+	ApplyTemplate(ctx context.Context, projectID, templateID string) error
+	ListRooms(ctx context.Context, projectID string) ([]Room, error)
+	SaveRooms(ctx context.Context, projectID string, rooms []Room) error
+	CountImagesByRoomType(ctx context.Context, projectID string) ([]RoomImageCount, error)
+	ListImageRooms(ctx context.Context, projectID string) ([]Room, error)
 }
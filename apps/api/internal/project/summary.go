@@ -0,0 +1,48 @@
+package project
+
+import "time"
+
+// SummaryThumbnailLimit is how many of a project's most recent images a summary previews.
+const SummaryThumbnailLimit int32 = 4
+
+// summaryThumbnailExpiry is how long, in seconds, a summary's thumbnail URLs stay valid.
+const summaryThumbnailExpiry int64 = 600
+
+// MaxSummaryBatch caps the number of projects a single batch summary request may ask for.
+const MaxSummaryBatch = 50
+
+// Summary aggregates what a dashboard project card shows, so it can be rendered from a
+// single request.
+type Summary struct {
+	ProjectID   string `json:"project_id"`
+	TotalImages int64  `json:"total_images"`
+	// StatusCounts has an entry for every image status, including those with no images.
+	StatusCounts map[string]int64 `json:"status_counts"`
+	// StorageBytes adds up the originals and staged outputs the worker has recorded sizes for.
+	StorageBytes   int64       `json:"storage_bytes"`
+	LastActivityAt time.Time   `json:"last_activity_at"`
+	Thumbnails     []Thumbnail `json:"thumbnails"`
+}
+
+// Thumbnail previews one of a project's most recent images.
+type Thumbnail struct {
+	ImageID string `json:"image_id"`
+	Status  string `json:"status"`
+	// URL is a short-lived presigned link to the staged output once there is one, otherwise
+	// to the original. It is omitted when the link cannot be signed.
+	URL string `json:"url,omitempty"`
+
+	storedURL string
+}
+
+// SummariesRequest asks for the summaries of several projects at once.
+type SummariesRequest struct {
+	ProjectIDs []string `json:"project_ids"`
+}
+
+// SummariesResponse holds the requested summaries, in request order. Projects that do not
+// exist or belong to someone else are listed in NotFound instead.
+type SummariesResponse struct {
+	Summaries []Summary `json:"summaries"`
+	NotFound  []string  `json:"not_found"`
+}
@@ -0,0 +1,96 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Template limits.
+const (
+	MaxTemplateNameLength = 100
+	// MaxTemplatesPerUser caps how many templates an account keeps.
+	MaxTemplatesPerUser = 50
+)
+
+var (
+	// ErrTemplateNotFound is returned when a template does not exist or belongs to another user.
+	ErrTemplateNotFound = errors.New("project template not found")
+	// ErrTemplateNameTaken is returned when the user already has a template with the name.
+	ErrTemplateNameTaken = errors.New("project template name already in use")
+	// ErrTemplateLimit is returned when the user already has MaxTemplatesPerUser templates.
+	ErrTemplateLimit = errors.New("project template limit reached")
+)
+
+// Template is a project's settings and room checklist saved for reuse. Projects created from
+// it copy both; later changes to either side are independent.
+type Template struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Disclosure is the banner configuration new projects start with. Nil leaves them on
+	// the defaults.
+	Disclosure *TemplateDisclosure `json:"disclosure"`
+	Rooms      []Room              `json:"rooms"`
+	CreatedAt  time.Time           `json:"created_at"`
+	UpdatedAt  time.Time           `json:"updated_at"`
+}
+
+// TemplateDisclosure is the disclosure banner part of a template. Its JSON form is also how
+// it is stored.
+type TemplateDisclosure struct {
+	Enabled  bool    `json:"enabled"`
+	Locale   string  `json:"locale"`
+	Text     *string `json:"text"`
+	Position string  `json:"position"`
+	Opacity  float32 `json:"opacity"`
+}
+
+// TemplateListResponse is the response envelope for listing templates.
+type TemplateListResponse struct {
+	Templates []Template `json:"templates"`
+}
+
+// TemplateRequest saves a project as a template. Omitted rooms are taken from the project's
+// own checklist, or failing that from the room types of its images.
+type TemplateRequest struct {
+	Name  string  `json:"name"`
+	Rooms *[]Room `json:"rooms"`
+}
+
+// Validate checks the request, trimming the name and room labels in place.
+func (r *TemplateRequest) Validate() []ValidationErrorDetail {
+	var errs []ValidationErrorDetail
+
+	r.Name = strings.TrimSpace(r.Name)
+	switch {
+	case r.Name == "":
+		errs = append(errs, ValidationErrorDetail{Field: "name", Message: "name is required"})
+	case utf8.RuneCountInString(r.Name) > MaxTemplateNameLength:
+		errs = append(errs, ValidationErrorDetail{
+			Field:   "name",
+			Message: fmt.Sprintf("name must be %d characters or less", MaxTemplateNameLength),
+		})
+	}
+
+	if r.Rooms != nil {
+		errs = append(errs, validateRooms(*r.Rooms)...)
+	}
+	return errs
+}
+
+// templateDisclosure returns the part of d a template keeps, or nil if the project never
+// configured its banner.
+func templateDisclosure(d *Disclosure) *TemplateDisclosure {
+	if d == nil || d.UpdatedAt == nil {
+		return nil
+	}
+	return &TemplateDisclosure{
+		Enabled:  d.Enabled,
+		Locale:   d.Locale,
+		Text:     d.Text,
+		Position: d.Position,
+		Opacity:  d.Opacity,
+	}
+}
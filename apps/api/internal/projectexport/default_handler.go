@@ -0,0 +1,148 @@
+package projectexport
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// DefaultHandler serves project exports and export credits over HTTP.
+type DefaultHandler struct {
+	service  Service
+	userRepo user.Repository
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, userRepo user.Repository) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo}
+}
+
+// ErrorResponse is a simple JSON error envelope for handler responses.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// Create handles POST /api/v1/projects/:project_id/exports. The zip is built in the
+// background; clients poll GET /api/v1/projects/:project_id/exports/:export_id until it is
+// ready.
+func (h *DefaultHandler) Create(c echo.Context) error {
+	var req CreateRequest
+	if err := c.Bind(&req); err != nil {
+		return badRequest(c, "Invalid request format")
+	}
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	export, err := h.service.Create(c.Request().Context(), userID, c.Param("project_id"), req)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusAccepted, export)
+}
+
+// Get handles GET /api/v1/projects/:project_id/exports/:export_id.
+func (h *DefaultHandler) Get(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	export, err := h.service.Get(c.Request().Context(), userID, c.Param("project_id"), c.Param("export_id"))
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, export)
+}
+
+// Credits handles GET /api/v1/me/export-credits.
+func (h *DefaultHandler) Credits(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	credits, err := h.service.Credits(c.Request().Context(), userID)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, credits)
+}
+
+// Checkout handles POST /api/v1/me/export-credits/checkout. The credit is added once Stripe
+// reports the checkout paid.
+func (h *DefaultHandler) Checkout(c echo.Context) error {
+	var req CheckoutRequest
+	if err := c.Bind(&req); err != nil {
+		return badRequest(c, "Invalid request format")
+	}
+	if !absoluteHTTPURL(req.SuccessURL) || !absoluteHTTPURL(req.CancelURL) {
+		return badRequest(c, "success_url and cancel_url must be absolute http(s) URLs")
+	}
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil || auth0Sub == "" {
+		return unauthorized(c)
+	}
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	resp, err := h.service.Checkout(c.Request().Context(), userID, auth0Sub, req)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// absoluteHTTPURL reports whether raw is an absolute http or https URL.
+func absoluteHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func badRequest(c echo.Context, message string) error {
+	return c.JSON(http.StatusBadRequest, ErrorResponse{
+		Error:   "bad_request",
+		Message: message,
+	})
+}
+
+func unauthorized(c echo.Context) error {
+	return c.JSON(http.StatusUnauthorized, ErrorResponse{
+		Error:   "unauthorized",
+		Message: "Unable to resolve current user",
+	})
+}
+
+// writeError maps service errors to responses.
+func (h *DefaultHandler) writeError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, ErrInvalid):
+		return c.JSON(http.StatusUnprocessableEntity, ErrorResponse{Error: "validation_failed", Message: err.Error()})
+	case errors.Is(err, ErrNotFound):
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Project or export not found"})
+	case errors.Is(err, ErrForbidden):
+		return c.JSON(http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: err.Error()})
+	case errors.Is(err, ErrCreditRequired):
+		return c.JSON(http.StatusPaymentRequired, ErrorResponse{Error: "export_credit_required", Message: err.Error()})
+	case errors.Is(err, ErrCheckoutUnavailable):
+		return c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: "service_unavailable", Message: err.Error()})
+	default:
+		c.Logger().Errorf("Project export request failed: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to process project export request",
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package projectexport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func userRepoFor(userID uuid.UUID) *user.RepositoryMock {
+	return &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+		},
+	}
+}
+
+func newContext(method, target, body string, params ...string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("X-Test-User", "auth0|user")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("project_id", "export_id")
+	c.SetParamValues(params...)
+	return c, rec
+}
+
+func TestDefaultHandler_Create(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: accepted", body: `{"watermark_free":true}`, expectedStatus: http.StatusAccepted},
+		{name: "fail: invalid body", body: `{`, expectedStatus: http.StatusBadRequest},
+		{name: "fail: no staged photos", err: ErrInvalid, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "fail: not found", err: ErrNotFound, expectedStatus: http.StatusNotFound},
+		{name: "fail: viewer", err: ErrForbidden, expectedStatus: http.StatusForbidden},
+		{name: "fail: no credit", err: ErrCreditRequired, expectedStatus: http.StatusPaymentRequired},
+		{name: "fail: service error", err: errors.New("db down"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				CreateFunc: func(ctx context.Context, uid, projectID string, req CreateRequest) (*Export, error) {
+					assert.Equal(t, userID.String(), uid)
+					assert.Equal(t, "proj-1", projectID)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					assert.True(t, req.WatermarkFree)
+					return &Export{ID: "exp-1", ProjectID: projectID, Status: StatusQueued}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, userRepoFor(userID))
+
+			body := tc.body
+			if body == "" {
+				body = "{}"
+			}
+			c, rec := newContext(http.MethodPost, "/api/v1/projects/proj-1/exports", body, "proj-1", "")
+			require.NoError(t, h.Create(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusAccepted {
+				assert.Contains(t, rec.Body.String(), `"status":"queued"`)
+			}
+			if tc.expectedStatus == http.StatusPaymentRequired {
+				assert.Contains(t, rec.Body.String(), `"export_credit_required"`)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_Get(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: ok", expectedStatus: http.StatusOK},
+		{name: "fail: not found", err: ErrNotFound, expectedStatus: http.StatusNotFound},
+		{name: "fail: service error", err: errors.New("db down"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				GetFunc: func(ctx context.Context, uid, projectID, exportID string) (*Export, error) {
+					assert.Equal(t, "proj-1", projectID)
+					assert.Equal(t, "exp-1", exportID)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &Export{ID: exportID, Status: StatusReady, DownloadURL: "https://signed"}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, userRepoFor(userID))
+
+			c, rec := newContext(http.MethodGet, "/api/v1/projects/proj-1/exports/exp-1", "", "proj-1", "exp-1")
+			require.NoError(t, h.Get(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Contains(t, rec.Body.String(), `"download_url":"https://signed"`)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_Credits(t *testing.T) {
+	userID := uuid.New()
+	svc := &ServiceMock{
+		CreditsFunc: func(ctx context.Context, uid string) (*Credits, error) {
+			assert.Equal(t, userID.String(), uid)
+			return &Credits{Available: 2}, nil
+		},
+	}
+	h := NewDefaultHandler(svc, userRepoFor(userID))
+
+	c, rec := newContext(http.MethodGet, "/api/v1/me/export-credits", "")
+	require.NoError(t, h.Credits(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"available":2}`, rec.Body.String())
+}
+
+func TestDefaultHandler_Checkout(t *testing.T) {
+	userID := uuid.New()
+	validBody := `{"success_url":"https://app.example.com/ok","cancel_url":"https://app.example.com/cancel"}`
+
+	testCases := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: checkout started", body: validBody, expectedStatus: http.StatusOK},
+		{
+			name:           "fail: relative success URL",
+			body:           `{"success_url":"/ok","cancel_url":"https://app.example.com/cancel"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{name: "fail: not for sale", body: validBody, err: ErrCheckoutUnavailable, expectedStatus: http.StatusServiceUnavailable},
+		{name: "fail: service error", body: validBody, err: errors.New("stripe down"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				CheckoutFunc: func(
+					ctx context.Context, uid, auth0Sub string, req CheckoutRequest,
+				) (*CheckoutResponse, error) {
+					assert.Equal(t, userID.String(), uid)
+					assert.Equal(t, "auth0|user", auth0Sub)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &CheckoutResponse{CheckoutURL: "https://checkout.stripe.com/c/1"}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, userRepoFor(userID))
+
+			c, rec := newContext(http.MethodPost, "/api/v1/me/export-credits/checkout", tc.body)
+			require.NoError(t, h.Checkout(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Contains(t, rec.Body.String(), `"checkout_url":"https://checkout.stripe.com/c/1"`)
+			} else if tc.expectedStatus == http.StatusBadRequest {
+				assert.Empty(t, svc.CheckoutCalls())
+			}
+		})
+	}
+}
@@ -0,0 +1,353 @@
+package projectexport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/entitlement"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/stripe"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// downloadURLTTL is how long the download link of a ready export stays valid.
+const downloadURLTTL = 15 * time.Minute
+
+// DefaultService implements Service.
+type DefaultService struct {
+	// db, when set, lets a credit be spent in the transaction that records the export.
+	db       storage.Database
+	querier  queries.Querier
+	enqueuer queue.ExportEnqueuer
+	buckets  storage.Buckets
+	// entitlements tells owners with an active plan apart. Nil exports every project without
+	// the platform mark.
+	entitlements entitlement.Service
+	// checkout starts export credit checkouts. Nil when Stripe or the price is not configured.
+	checkout stripe.Client
+	users    user.Repository
+	priceID  string
+}
+
+// Option customizes a DefaultService.
+type Option func(*DefaultService)
+
+// WithCheckout sells export credits at the one-time Stripe price priceID, prefilling the
+// checkout from the profiles in users.
+func WithCheckout(client stripe.Client, users user.Repository, priceID string) Option {
+	return func(s *DefaultService) {
+		s.checkout = client
+		s.users = users
+		s.priceID = priceID
+	}
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService with a database. Exports are queued through
+// Redis when it is configured and dropped otherwise; credits are for sale when the Stripe
+// secret key and the export credit price are configured.
+func NewDefaultService(
+	cfg *config.Config, db storage.Database, buckets storage.Buckets, entitlements entitlement.Service,
+) *DefaultService {
+	var enq queue.ExportEnqueuer
+	if e, err := queue.NewAsynqExportEnqueuerFromConfig(cfg); err == nil {
+		enq = e
+	} else {
+		enq = queue.NoopExportEnqueuer{}
+	}
+	s := NewDefaultServiceWithQuerier(queries.New(db), enq, buckets, entitlements)
+	s.db = db
+	if cfg.Stripe.SecretKey != "" && cfg.Stripe.ExportCreditPriceID != "" {
+		WithCheckout(stripe.NewDefaultClient(cfg.Stripe.SecretKey), user.NewDefaultRepository(db),
+			cfg.Stripe.ExportCreditPriceID)(s)
+	}
+	return s
+}
+
+// NewDefaultServiceWithQuerier creates a new DefaultService with a custom querier and
+// enqueuer (for testing). Create then spends credits without a transaction.
+func NewDefaultServiceWithQuerier(
+	querier queries.Querier, enqueuer queue.ExportEnqueuer, buckets storage.Buckets,
+	entitlements entitlement.Service, opts ...Option,
+) *DefaultService {
+	s := &DefaultService{querier: querier, enqueuer: enqueuer, buckets: buckets, entitlements: entitlements}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Create records an export of the project's staged photos and queues it. The photos carry the
+// platform mark when the owner has no active plan, unless the project was unlocked with an
+// export credit. With req.WatermarkFree, a locked project is unlocked by spending the user's
+// oldest credit in the transaction that records the export, or ErrCreditRequired is returned.
+// A spent credit stays with the project, so later exports of it are watermark-free too, even
+// when this one fails. Returns ErrForbidden for viewers and ErrInvalid when the project has no
+// staged photos.
+func (s *DefaultService) Create(ctx context.Context, userID, projectID string, req CreateRequest) (*Export, error) {
+	member, uid, err := s.authorize(ctx, userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role == project.RoleViewer {
+		return nil, ErrForbidden
+	}
+
+	count, err := s.querier.CountExportableImages(ctx, member.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count staged images: %w", err)
+	}
+	if count == 0 {
+		return nil, fmt.Errorf("%w: project has no staged photos to export", ErrInvalid)
+	}
+	locked, err := s.needsMark(ctx, member.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := queries.CreateProjectExportParams{ProjectID: member.ID, UserID: uid}
+	var row *queries.ProjectExport
+	record := func(q queries.Querier) error {
+		params.Watermarked, params.CreditID = locked, pgtype.UUID{}
+		if locked {
+			credit, err := unlock(ctx, q, member.ID, uid, req.WatermarkFree)
+			if err != nil {
+				return err
+			}
+			if credit != nil {
+				params.Watermarked, params.CreditID = false, credit.ID
+			}
+		}
+		row, err = q.CreateProjectExport(ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to create project export: %w", err)
+		}
+		return nil
+	}
+	err = s.inTx(ctx, record)
+	if isUnlockConflict(err) {
+		// A concurrent export unlocked the project first; this one reuses its credit.
+		err = s.inTx(ctx, record)
+	}
+	if err != nil {
+		return nil, err
+	}
+	export := toExport(row)
+
+	if _, err := s.enqueuer.EnqueueExport(ctx, queue.ExportPayload{
+		ExportID:  export.ID,
+		ProjectID: export.ProjectID,
+	}); err != nil {
+		// Leave no export queued that no worker will pick up.
+		failErr := s.querier.FailProjectExport(ctx, queries.FailProjectExportParams{
+			ID:    row.ID,
+			Error: pgtype.Text{String: "failed to queue project export", Valid: true},
+		})
+		if failErr != nil {
+			logging.NewDefaultLogger().Error(ctx, "failed to mark project export failed",
+				"export_id", export.ID, "error", failErr)
+		}
+		return nil, fmt.Errorf("failed to enqueue project export: %w", err)
+	}
+	return &export, nil
+}
+
+// Get returns one of the project's exports, with a download link once it is ready. Every
+// member of the project can read its exports.
+func (s *DefaultService) Get(ctx context.Context, userID, projectID, exportID string) (*Export, error) {
+	member, _, err := s.authorize(ctx, userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	eid, err := parseUUID(exportID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	row, err := s.querier.GetProjectExport(ctx, queries.GetProjectExportParams{ID: eid, ProjectID: member.ID})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project export: %w", err)
+	}
+	export := toExport(row)
+
+	if export.Status == StatusReady && row.ResultUrl.Valid && s.buckets != nil {
+		files, key, err := s.buckets.ForURL(ctx, row.ResultUrl.String)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve project export storage: %w", err)
+		}
+		signed, err := files.GeneratePresignedGetURL(ctx, key, int64(downloadURLTTL.Seconds()),
+			fmt.Sprintf(`attachment; filename="project-export-%s.zip"`, export.ID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to presign project export: %w", err)
+		}
+		export.DownloadURL = signed
+	}
+	return &export, nil
+}
+
+// Credits returns how many export credits the user has bought and not yet spent.
+func (s *DefaultService) Credits(ctx context.Context, userID string) (*Credits, error) {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	n, err := s.querier.CountAvailableExportCredits(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count export credits: %w", err)
+	}
+	return &Credits{Available: int(n)}, nil
+}
+
+// Checkout starts a Stripe one-time checkout for one export credit. The checkout is tagged
+// so that its completion webhook records the credit for the user in its client reference.
+func (s *DefaultService) Checkout(
+	ctx context.Context, userID, auth0Sub string, req CheckoutRequest,
+) (*CheckoutResponse, error) {
+	if s.checkout == nil || s.users == nil || s.priceID == "" {
+		return nil, ErrCheckoutUnavailable
+	}
+	profile, err := s.users.GetProfileByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user profile: %w", err)
+	}
+
+	params := stripe.CheckoutSessionParams{
+		Mode:              "payment",
+		PriceID:           s.priceID,
+		ClientReferenceID: auth0Sub,
+		SuccessURL:        req.SuccessURL,
+		CancelURL:         req.CancelURL,
+		Metadata:          map[string]string{stripe.MetadataPurpose: stripe.PurposeExportCredit},
+	}
+	if current := profile.StripeCustomerID; current.Valid && current.String != "" {
+		params.CustomerID = current.String
+	} else {
+		params.CustomerEmail = profile.Email.String
+	}
+	session, err := s.checkout.CreateCheckoutSession(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("create checkout session: %w", err)
+	}
+	return &CheckoutResponse{CheckoutURL: session.URL}, nil
+}
+
+// needsMark reports whether exports of ownerID's projects carry the platform mark: whether
+// the owner has no active plan.
+func (s *DefaultService) needsMark(ctx context.Context, ownerID pgtype.UUID) (bool, error) {
+	if s.entitlements == nil {
+		return false, nil
+	}
+	ent, err := s.entitlements.Get(ctx, uuid.UUID(ownerID.Bytes).String())
+	if err != nil {
+		return false, fmt.Errorf("failed to get owner entitlements: %w", err)
+	}
+	return ent.Plan == "", nil
+}
+
+// unlock returns the credit that unlocked the project. When there is none and spend is set,
+// it spends the user's oldest credit on the project, returning ErrCreditRequired when the
+// user has none left. It returns nil when the project stays locked.
+func unlock(
+	ctx context.Context, q queries.Querier, projectID, userID pgtype.UUID, spend bool,
+) (*queries.ExportCredit, error) {
+	credit, err := q.GetProjectExportCredit(ctx, projectID)
+	if err == nil {
+		return credit, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get project export credit: %w", err)
+	}
+	if !spend {
+		return nil, nil
+	}
+	credit, err = q.ConsumeExportCredit(ctx, queries.ConsumeExportCreditParams{ProjectID: projectID, UserID: userID})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrCreditRequired
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to spend export credit: %w", err)
+	}
+	return credit, nil
+}
+
+// isUnlockConflict reports whether err is a second credit being spent on a project that a
+// concurrent export just unlocked.
+func isUnlockConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" &&
+		pgErr.ConstraintName == "idx_export_credits_project_id"
+}
+
+// inTx runs fn in a transaction when the service has a database, and on the querier
+// otherwise.
+func (s *DefaultService) inTx(ctx context.Context, fn func(q queries.Querier) error) error {
+	if s.db == nil {
+		return fn(s.querier)
+	}
+	return s.db.WithTx(ctx, func(tx storage.Database) error {
+		return fn(queries.New(tx))
+	})
+}
+
+// authorize returns the project with the user's role when the user is a member of it.
+// Non-members get ErrNotFound, so IDs of other projects are not revealed.
+func (s *DefaultService) authorize(
+	ctx context.Context, userID, projectID string,
+) (*queries.GetProjectByIDForMemberRow, pgtype.UUID, error) {
+	pid, err := parseUUID(projectID)
+	if err != nil {
+		return nil, pgtype.UUID{}, ErrNotFound
+	}
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return nil, pgtype.UUID{}, fmt.Errorf("invalid user ID: %w", err)
+	}
+	member, err := s.querier.GetProjectByIDForMember(ctx, queries.GetProjectByIDForMemberParams{ID: pid, UserID: uid})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, pgtype.UUID{}, ErrNotFound
+	}
+	if err != nil {
+		return nil, pgtype.UUID{}, fmt.Errorf("failed to check project access: %w", err)
+	}
+	return member, uid, nil
+}
+
+func parseUUID(id string) (pgtype.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+	return pgtype.UUID{Bytes: parsed, Valid: true}, nil
+}
+
+func toExport(row *queries.ProjectExport) Export {
+	e := Export{
+		ID:          uuid.UUID(row.ID.Bytes).String(),
+		ProjectID:   uuid.UUID(row.ProjectID.Bytes).String(),
+		Status:      row.Status,
+		Watermarked: row.Watermarked,
+		ImageCount:  int(row.ImageCount),
+		CreatedAt:   row.CreatedAt.Time,
+		UpdatedAt:   row.UpdatedAt.Time,
+	}
+	if row.Error.Valid {
+		e.Error = &row.Error.String
+	}
+	return e
+}
@@ -0,0 +1,414 @@
+package projectexport
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/entitlement"
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/stripe"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+type fixture struct {
+	userID    uuid.UUID
+	ownerID   uuid.UUID
+	projectID uuid.UUID
+	exportID  uuid.UUID
+	creditID  uuid.UUID
+	role      string
+	plan      string
+	// unlocked is set when the project already has a credit.
+	unlocked bool
+	// credits is the number of credits the user can spend.
+	credits  int
+	querier  *queries.QuerierMock
+	enqueuer *queue.ExportEnqueuerMock
+	svc      *DefaultService
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	f := &fixture{
+		userID:    uuid.New(),
+		ownerID:   uuid.New(),
+		projectID: uuid.New(),
+		exportID:  uuid.New(),
+		creditID:  uuid.New(),
+		role:      project.RoleEditor,
+	}
+	f.querier = &queries.QuerierMock{
+		GetProjectByIDForMemberFunc: func(
+			ctx context.Context, arg queries.GetProjectByIDForMemberParams,
+		) (*queries.GetProjectByIDForMemberRow, error) {
+			if arg.ID.Bytes != f.projectID || arg.UserID.Bytes != f.userID || f.role == "" {
+				return nil, pgx.ErrNoRows
+			}
+			return &queries.GetProjectByIDForMemberRow{
+				ID:     arg.ID,
+				UserID: pgtype.UUID{Bytes: f.ownerID, Valid: true},
+				Role:   f.role,
+			}, nil
+		},
+		CountExportableImagesFunc: func(ctx context.Context, projectID pgtype.UUID) (int64, error) {
+			return 4, nil
+		},
+		GetProjectExportCreditFunc: func(ctx context.Context, projectID pgtype.UUID) (*queries.ExportCredit, error) {
+			if !f.unlocked {
+				return nil, pgx.ErrNoRows
+			}
+			return &queries.ExportCredit{ID: pgtype.UUID{Bytes: f.creditID, Valid: true}, ProjectID: projectID}, nil
+		},
+		ConsumeExportCreditFunc: func(
+			ctx context.Context, arg queries.ConsumeExportCreditParams,
+		) (*queries.ExportCredit, error) {
+			if f.credits == 0 {
+				return nil, pgx.ErrNoRows
+			}
+			f.credits--
+			f.unlocked = true
+			return &queries.ExportCredit{ID: pgtype.UUID{Bytes: f.creditID, Valid: true}, ProjectID: arg.ProjectID}, nil
+		},
+		CreateProjectExportFunc: func(
+			ctx context.Context, arg queries.CreateProjectExportParams,
+		) (*queries.ProjectExport, error) {
+			return &queries.ProjectExport{
+				ID:          pgtype.UUID{Bytes: f.exportID, Valid: true},
+				ProjectID:   arg.ProjectID,
+				UserID:      arg.UserID,
+				Status:      StatusQueued,
+				Watermarked: arg.Watermarked,
+				CreditID:    arg.CreditID,
+			}, nil
+		},
+		FailProjectExportFunc: func(ctx context.Context, arg queries.FailProjectExportParams) error {
+			return nil
+		},
+	}
+	f.enqueuer = &queue.ExportEnqueuerMock{
+		EnqueueExportFunc: func(ctx context.Context, payload queue.ExportPayload) (string, error) {
+			return "task-1", nil
+		},
+	}
+	entitlements := &entitlement.ServiceMock{
+		GetFunc: func(ctx context.Context, userID string) (*entitlement.Entitlements, error) {
+			if userID != f.ownerID.String() {
+				return nil, errors.New("entitlements asked for a user other than the owner")
+			}
+			return &entitlement.Entitlements{Plan: f.plan}, nil
+		},
+	}
+	f.svc = NewDefaultServiceWithQuerier(f.querier, f.enqueuer, nil, entitlements)
+	return f
+}
+
+func TestDefaultService_Create(t *testing.T) {
+	testCases := []struct {
+		name            string
+		setup           func(f *fixture)
+		req             CreateRequest
+		expectedErr     error
+		wantWatermarked bool
+		wantCredit      bool
+		wantConsumed    int
+	}{
+		{
+			name:  "success: owner with a plan exports without the mark",
+			setup: func(f *fixture) { f.plan = "pro" },
+		},
+		{
+			name:            "success: owner without a plan exports with the mark",
+			wantWatermarked: true,
+		},
+		{
+			name:         "success: watermark-free export spends a credit",
+			setup:        func(f *fixture) { f.credits = 2 },
+			req:          CreateRequest{WatermarkFree: true},
+			wantCredit:   true,
+			wantConsumed: 1,
+		},
+		{
+			name:       "success: unlocked project exports without the mark and spends nothing",
+			setup:      func(f *fixture) { f.unlocked = true },
+			wantCredit: true,
+		},
+		{
+			name:  "success: watermark-free export with a plan spends nothing",
+			setup: func(f *fixture) { f.plan = "pro"; f.credits = 1 },
+			req:   CreateRequest{WatermarkFree: true},
+		},
+		{
+			name:        "fail: watermark-free export without a credit",
+			req:         CreateRequest{WatermarkFree: true},
+			expectedErr: ErrCreditRequired,
+		},
+		{
+			name:        "fail: viewer",
+			setup:       func(f *fixture) { f.role = project.RoleViewer },
+			expectedErr: ErrForbidden,
+		},
+		{
+			name:        "fail: not a member",
+			setup:       func(f *fixture) { f.role = "" },
+			expectedErr: ErrNotFound,
+		},
+		{
+			name: "fail: no staged photos",
+			setup: func(f *fixture) {
+				f.querier.CountExportableImagesFunc = func(ctx context.Context, projectID pgtype.UUID) (int64, error) {
+					return 0, nil
+				}
+			},
+			expectedErr: ErrInvalid,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFixture(t)
+			if tc.setup != nil {
+				tc.setup(f)
+			}
+			credits := f.credits
+
+			export, err := f.svc.Create(context.Background(), f.userID.String(), f.projectID.String(), tc.req)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Empty(t, f.querier.CreateProjectExportCalls())
+				assert.Empty(t, f.enqueuer.EnqueueExportCalls())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, f.exportID.String(), export.ID)
+			assert.Equal(t, StatusQueued, export.Status)
+			assert.Equal(t, tc.wantWatermarked, export.Watermarked)
+			assert.Equal(t, tc.wantConsumed, credits-f.credits)
+
+			created := f.querier.CreateProjectExportCalls()
+			require.Len(t, created, 1)
+			assert.Equal(t, f.userID, uuid.UUID(created[0].Arg.UserID.Bytes))
+			assert.Equal(t, tc.wantCredit, created[0].Arg.CreditID.Valid)
+
+			calls := f.enqueuer.EnqueueExportCalls()
+			require.Len(t, calls, 1)
+			assert.Equal(t, queue.ExportPayload{ExportID: f.exportID.String(), ProjectID: f.projectID.String()},
+				calls[0].Payload)
+		})
+	}
+
+	t.Run("success: concurrent unlock reuses the other export's credit", func(t *testing.T) {
+		f := newFixture(t)
+		f.credits = 1
+		f.querier.ConsumeExportCreditFunc = func(
+			ctx context.Context, arg queries.ConsumeExportCreditParams,
+		) (*queries.ExportCredit, error) {
+			f.unlocked = true
+			return nil, &pgconn.PgError{Code: "23505", ConstraintName: "idx_export_credits_project_id"}
+		}
+
+		export, err := f.svc.Create(context.Background(), f.userID.String(), f.projectID.String(),
+			CreateRequest{WatermarkFree: true})
+		require.NoError(t, err)
+		assert.False(t, export.Watermarked)
+		assert.Len(t, f.querier.ConsumeExportCreditCalls(), 1)
+		assert.Len(t, f.querier.GetProjectExportCreditCalls(), 2)
+	})
+
+	t.Run("fail: enqueue error marks the export failed", func(t *testing.T) {
+		f := newFixture(t)
+		f.enqueuer.EnqueueExportFunc = func(ctx context.Context, payload queue.ExportPayload) (string, error) {
+			return "", errors.New("redis down")
+		}
+
+		_, err := f.svc.Create(context.Background(), f.userID.String(), f.projectID.String(), CreateRequest{})
+		require.Error(t, err)
+		failed := f.querier.FailProjectExportCalls()
+		require.Len(t, failed, 1)
+		assert.Equal(t, f.exportID, uuid.UUID(failed[0].Arg.ID.Bytes))
+	})
+}
+
+func TestDefaultService_Get(t *testing.T) {
+	resultURL := "s3://bucket/exports/p/e.zip"
+	files := &storage.S3ServiceMock{
+		GeneratePresignedGetURLFunc: func(
+			ctx context.Context, fileKey string, expiresInSeconds int64, contentDisposition string,
+		) (string, error) {
+			return "https://signed.example.com/" + fileKey, nil
+		},
+	}
+	buckets := &storage.BucketsMock{
+		ForURLFunc: func(ctx context.Context, rawURL string) (storage.S3Service, string, error) {
+			return files, "exports/p/e.zip", nil
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		setup       func(f *fixture)
+		status      string
+		exportID    func(f *fixture) string
+		expectedErr error
+		wantURL     string
+	}{
+		{
+			name:    "success: ready export has a download link",
+			status:  StatusReady,
+			wantURL: "https://signed.example.com/exports/p/e.zip",
+		},
+		{
+			name:   "success: viewer reads a queued export",
+			setup:  func(f *fixture) { f.role = project.RoleViewer },
+			status: StatusQueued,
+		},
+		{
+			name:        "fail: not a member",
+			setup:       func(f *fixture) { f.role = "" },
+			status:      StatusReady,
+			expectedErr: ErrNotFound,
+		},
+		{
+			name:        "fail: unknown export",
+			status:      StatusReady,
+			exportID:    func(f *fixture) string { return uuid.NewString() },
+			expectedErr: ErrNotFound,
+		},
+		{
+			name:        "fail: malformed export ID",
+			status:      StatusReady,
+			exportID:    func(f *fixture) string { return "nope" },
+			expectedErr: ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFixture(t)
+			if tc.setup != nil {
+				tc.setup(f)
+			}
+			f.svc.buckets = buckets
+			f.querier.GetProjectExportFunc = func(
+				ctx context.Context, arg queries.GetProjectExportParams,
+			) (*queries.ProjectExport, error) {
+				if arg.ID.Bytes != f.exportID || arg.ProjectID.Bytes != f.projectID {
+					return nil, pgx.ErrNoRows
+				}
+				row := &queries.ProjectExport{ID: arg.ID, ProjectID: arg.ProjectID, Status: tc.status}
+				if tc.status == StatusReady {
+					row.ResultUrl = pgtype.Text{String: resultURL, Valid: true}
+					row.ImageCount = 4
+				}
+				return row, nil
+			}
+			exportID := f.exportID.String()
+			if tc.exportID != nil {
+				exportID = tc.exportID(f)
+			}
+
+			export, err := f.svc.Get(context.Background(), f.userID.String(), f.projectID.String(), exportID)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.status, export.Status)
+			assert.Equal(t, tc.wantURL, export.DownloadURL)
+		})
+	}
+}
+
+func TestDefaultService_Credits(t *testing.T) {
+	f := newFixture(t)
+	f.querier.CountAvailableExportCreditsFunc = func(ctx context.Context, userID pgtype.UUID) (int64, error) {
+		assert.Equal(t, f.userID, uuid.UUID(userID.Bytes))
+		return 3, nil
+	}
+
+	credits, err := f.svc.Credits(context.Background(), f.userID.String())
+	require.NoError(t, err)
+	assert.Equal(t, &Credits{Available: 3}, credits)
+}
+
+func TestDefaultService_Checkout(t *testing.T) {
+	req := CheckoutRequest{SuccessURL: "https://app.example.com/ok", CancelURL: "https://app.example.com/cancel"}
+
+	testCases := []struct {
+		name         string
+		customerID   string
+		configured   bool
+		expectedErr  error
+		wantCustomer string
+		wantEmail    string
+	}{
+		{
+			name:         "success: linked customer",
+			customerID:   "cus_123",
+			configured:   true,
+			wantCustomer: "cus_123",
+		},
+		{
+			name:       "success: new customer is prefilled by email",
+			configured: true,
+			wantEmail:  "owner@example.com",
+		},
+		{
+			name:        "fail: not configured",
+			expectedErr: ErrCheckoutUnavailable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFixture(t)
+			client := &stripe.ClientMock{
+				CreateCheckoutSessionFunc: func(
+					ctx context.Context, params stripe.CheckoutSessionParams,
+				) (*stripe.CheckoutSession, error) {
+					return &stripe.CheckoutSession{URL: "https://checkout.stripe.com/c/1"}, nil
+				},
+			}
+			users := &user.RepositoryMock{
+				GetProfileByIDFunc: func(ctx context.Context, userID string) (*queries.GetUserProfileByIDRow, error) {
+					return &queries.GetUserProfileByIDRow{
+						StripeCustomerID: pgtype.Text{String: tc.customerID, Valid: tc.customerID != ""},
+						Email:            pgtype.Text{String: "owner@example.com", Valid: true},
+					}, nil
+				},
+			}
+			if tc.configured {
+				WithCheckout(client, users, "price_export")(f.svc)
+			}
+
+			resp, err := f.svc.Checkout(context.Background(), f.userID.String(), "auth0|user", req)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Empty(t, client.CreateCheckoutSessionCalls())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "https://checkout.stripe.com/c/1", resp.CheckoutURL)
+
+			calls := client.CreateCheckoutSessionCalls()
+			require.Len(t, calls, 1)
+			params := calls[0].Params
+			assert.Equal(t, "payment", params.Mode)
+			assert.Equal(t, "price_export", params.PriceID)
+			assert.Equal(t, "auth0|user", params.ClientReferenceID)
+			assert.Equal(t, tc.wantCustomer, params.CustomerID)
+			assert.Equal(t, tc.wantEmail, params.CustomerEmail)
+			assert.Equal(t, stripe.PurposeExportCredit, params.Metadata[stripe.MetadataPurpose])
+		})
+	}
+}
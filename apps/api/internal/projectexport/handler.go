@@ -0,0 +1,14 @@
+package projectexport
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP-level contract for project exports and export credits.
+// Implementations should be wired to Echo routes in the server.
+type Handler interface {
+	Create(c echo.Context) error
+	Get(c echo.Context) error
+	Credits(c echo.Context) error
+	Checkout(c echo.Context) error
+}
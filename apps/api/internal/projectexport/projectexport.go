@@ -0,0 +1,74 @@
+// Package projectexport bundles a project's staged photos into one zip, built by a worker.
+// Exports of projects whose owner has no active plan carry the platform mark on every photo.
+// Users without a plan can buy export credits through a Stripe one-time checkout; spending
+// one unlocks a project, whose exports are then watermark-free.
+package projectexport
+
+import (
+	"errors"
+	"time"
+)
+
+// Export statuses.
+const (
+	StatusQueued     = "queued"
+	StatusProcessing = "processing"
+	StatusReady      = "ready"
+	StatusError      = "error"
+)
+
+var (
+	// ErrNotFound is returned when the project or the export does not exist or the user is not
+	// a member of the project.
+	ErrNotFound = errors.New("project export not found")
+	// ErrForbidden is returned when a viewer asks for an export.
+	ErrForbidden = errors.New("viewers cannot export the project")
+	// ErrInvalid wraps validation failures of a request.
+	ErrInvalid = errors.New("invalid project export")
+	// ErrCreditRequired is returned when a watermark-free export of a locked project is asked
+	// for without an export credit to spend.
+	ErrCreditRequired = errors.New("an export credit is required for a watermark-free export")
+	// ErrCheckoutUnavailable is returned when export credits cannot be bought because Stripe
+	// or the export credit price is not configured.
+	ErrCheckoutUnavailable = errors.New("export credits are not for sale")
+)
+
+// Export is a zip of a project's staged photos.
+type Export struct {
+	ID        string `json:"id"`
+	ProjectID string `json:"project_id"`
+	Status    string `json:"status"`
+	// Watermarked reports whether the photos carry the platform mark.
+	Watermarked bool `json:"watermarked"`
+	// ImageCount is the number of photos in the zip, once ready.
+	ImageCount int `json:"image_count"`
+	// DownloadURL is a short-lived link to the zip, set once ready.
+	DownloadURL string    `json:"download_url,omitempty"`
+	Error       *string   `json:"error,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CreateRequest is the body of POST /api/v1/projects/:project_id/exports.
+type CreateRequest struct {
+	// WatermarkFree spends one of the user's export credits when the project's owner has no
+	// active plan and the project is not unlocked yet.
+	WatermarkFree bool `json:"watermark_free"`
+}
+
+// Credits are a user's export credits.
+type Credits struct {
+	// Available is the number of credits bought and not yet spent.
+	Available int `json:"available"`
+}
+
+// CheckoutRequest is the body of POST /api/v1/me/export-credits/checkout.
+type CheckoutRequest struct {
+	SuccessURL string `json:"success_url"`
+	CancelURL  string `json:"cancel_url"`
+}
+
+// CheckoutResponse is where to send the user to pay for an export credit.
+type CheckoutResponse struct {
+	CheckoutURL string `json:"checkout_url"`
+}
@@ -0,0 +1,18 @@
+package projectexport
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service exports projects and sells export credits.
+type Service interface {
+	// Create records an export of the project and queues it.
+	Create(ctx context.Context, userID, projectID string, req CreateRequest) (*Export, error)
+	// Get returns one of the project's exports.
+	Get(ctx context.Context, userID, projectID, exportID string) (*Export, error)
+	// Credits returns the user's export credits.
+	Credits(ctx context.Context, userID string) (*Credits, error)
+	// Checkout starts a one-time checkout buying one export credit for the user, identified to
+	// Stripe by auth0Sub.
+	Checkout(ctx context.Context, userID, auth0Sub string, req CheckoutRequest) (*CheckoutResponse, error)
+}
@@ -0,0 +1,180 @@
+package provenance
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Mirrors the layout written by the worker's provenance package.
+const (
+	boxTypeSuperbox    = "jumb"
+	boxTypeDescription = "jumd"
+	boxTypeCBOR        = "cbor"
+
+	toggleLabelPresent = 0x02
+
+	markerAPP11 = 0xEB
+	markerSOS   = 0xDA
+
+	labelManifestStore = "c2pa"
+	labelAssertions    = "c2pa.assertions"
+	labelActions       = "c2pa.actions"
+	labelDataHash      = "c2pa.hash.data"
+	labelClaim         = "c2pa.claim"
+	labelSignature     = "c2pa.signature"
+)
+
+var errNotJPEG = errors.New("not a jpeg")
+
+// rawBox is an undecoded ISO BMFF style box.
+type rawBox struct {
+	boxType string
+	payload []byte
+}
+
+// superbox is a decoded JUMBF superbox.
+type superbox struct {
+	label    string
+	children []rawBox
+	// payload is everything after the superbox header, which is what C2PA
+	// hashed URIs are computed over.
+	payload []byte
+}
+
+// extractManifestStore reassembles the JUMBF manifest store carried in
+// APP11 segments. It returns nil when the image has none.
+func extractManifestStore(img []byte) (*superbox, error) {
+	if len(img) < 2 || img[0] != 0xFF || img[1] != 0xD8 {
+		return nil, errNotJPEG
+	}
+
+	type packet struct {
+		seq  uint32
+		data []byte
+	}
+	instances := map[uint16][]packet{}
+	var order []uint16
+
+	for pos := 2; pos+4 <= len(img); {
+		if img[pos] != 0xFF {
+			return nil, fmt.Errorf("malformed jpeg marker at offset %d", pos)
+		}
+		marker := img[pos+1]
+		if marker == markerSOS {
+			break
+		}
+		if marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			pos += 2
+			continue
+		}
+		length := int(binary.BigEndian.Uint16(img[pos+2:]))
+		end := pos + 2 + length
+		if length < 2 || end > len(img) {
+			return nil, fmt.Errorf("truncated jpeg segment at offset %d", pos)
+		}
+		seg := img[pos+4 : end]
+		pos = end
+
+		// CI "JP", En, Z, then the box data.
+		if marker != markerAPP11 || len(seg) < 16 || !bytes.Equal(seg[:2], []byte("JP")) {
+			continue
+		}
+		en := binary.BigEndian.Uint16(seg[2:])
+		if _, ok := instances[en]; !ok {
+			order = append(order, en)
+		}
+		instances[en] = append(instances[en], packet{seq: binary.BigEndian.Uint32(seg[4:]), data: seg[8:]})
+	}
+
+	for _, en := range order {
+		packets := instances[en]
+		sort.Slice(packets, func(i, j int) bool { return packets[i].seq < packets[j].seq })
+
+		// Every packet repeats the 8 byte box header; keep only the first.
+		jumbf := append([]byte{}, packets[0].data...)
+		for _, p := range packets[1:] {
+			jumbf = append(jumbf, p.data[8:]...)
+		}
+		if len(jumbf) < 8 || string(jumbf[4:8]) != boxTypeSuperbox {
+			continue
+		}
+		sb, err := parseSuperbox(rawBox{boxType: boxTypeSuperbox, payload: jumbf[8:]})
+		if err == nil && sb.label == labelManifestStore {
+			return sb, nil
+		}
+	}
+	return nil, nil
+}
+
+// parseBoxes splits data into consecutive boxes.
+func parseBoxes(data []byte) ([]rawBox, error) {
+	var boxes []rawBox
+	for len(data) > 0 {
+		if len(data) < 8 {
+			return nil, errors.New("truncated box header")
+		}
+		size := int(binary.BigEndian.Uint32(data))
+		if size == 0 {
+			size = len(data)
+		}
+		if size < 8 || size > len(data) {
+			return nil, fmt.Errorf("invalid box size %d", size)
+		}
+		boxes = append(boxes, rawBox{boxType: string(data[4:8]), payload: data[8:size]})
+		data = data[size:]
+	}
+	return boxes, nil
+}
+
+// parseSuperbox decodes the description box and children of a jumb box.
+func parseSuperbox(b rawBox) (*superbox, error) {
+	if b.boxType != boxTypeSuperbox {
+		return nil, fmt.Errorf("expected %s box, got %q", boxTypeSuperbox, b.boxType)
+	}
+	boxes, err := parseBoxes(b.payload)
+	if err != nil {
+		return nil, err
+	}
+	if len(boxes) == 0 || boxes[0].boxType != boxTypeDescription {
+		return nil, errors.New("superbox is missing its description box")
+	}
+	desc := boxes[0].payload
+	if len(desc) < 17 {
+		return nil, errors.New("description box too short")
+	}
+	sb := &superbox{children: boxes[1:], payload: b.payload}
+	if desc[16]&toggleLabelPresent != 0 {
+		label, _, ok := bytes.Cut(desc[17:], []byte{0})
+		if !ok {
+			return nil, errors.New("unterminated description label")
+		}
+		sb.label = string(label)
+	}
+	return sb, nil
+}
+
+// child returns the child superbox with the given label.
+func (sb *superbox) child(label string) (*superbox, bool) {
+	for _, c := range sb.children {
+		if c.boxType != boxTypeSuperbox {
+			continue
+		}
+		if child, err := parseSuperbox(c); err == nil && child.label == label {
+			return child, true
+		}
+	}
+	return nil, false
+}
+
+// cbor returns the payload of the first cbor content box.
+func (sb *superbox) cbor() ([]byte, bool) {
+	for _, c := range sb.children {
+		if c.boxType == boxTypeCBOR {
+			return c.payload, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,34 @@
+// Package provenance reads and verifies the C2PA Content Credentials the
+// worker embeds into staged images.
+package provenance
+
+// Report is the result of verifying an image's Content Credentials.
+type Report struct {
+	// HasCredentials is true when the image carries a C2PA manifest store.
+	HasCredentials bool `json:"has_credentials"`
+	// Valid is true when the signature, every referenced assertion and the
+	// image data hash all check out.
+	Valid          bool     `json:"valid"`
+	ClaimGenerator string   `json:"claim_generator,omitempty"`
+	Signer         *Signer  `json:"signer,omitempty"`
+	Actions        []Action `json:"actions,omitempty"`
+	Errors         []string `json:"errors,omitempty"`
+}
+
+// Signer identifies the certificate that signed the claim.
+type Signer struct {
+	CommonName   string `json:"common_name"`
+	Organization string `json:"organization,omitempty"`
+	// SelfSigned means the certificate is not issued by a trust anchor, so
+	// the signature proves integrity but not who produced the image.
+	SelfSigned bool `json:"self_signed"`
+}
+
+// Action is an entry from the c2pa.actions assertion.
+type Action struct {
+	Action            string            `json:"action"`
+	When              string            `json:"when,omitempty"`
+	SoftwareAgent     string            `json:"software_agent,omitempty"`
+	DigitalSourceType string            `json:"digital_source_type,omitempty"`
+	Parameters        map[string]string `json:"parameters,omitempty"`
+}
@@ -0,0 +1,293 @@
+package provenance
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+const (
+	coseTagSign1   = 18
+	coseHeaderAlg  = 1
+	coseHeaderX5   = 33
+	coseAlgES256   = -7
+	hashAlgSHA256  = "sha256"
+	assertionURIPf = "self#jumbf=" + labelAssertions + "/"
+)
+
+type claim struct {
+	ClaimGenerator string      `cbor:"claim_generator"`
+	Signature      string      `cbor:"signature"`
+	Assertions     []hashedURI `cbor:"assertions"`
+	Format         string      `cbor:"dc:format"`
+	InstanceID     string      `cbor:"instanceID"`
+	Alg            string      `cbor:"alg"`
+}
+
+type hashedURI struct {
+	URL  string `cbor:"url"`
+	Alg  string `cbor:"alg"`
+	Hash []byte `cbor:"hash"`
+}
+
+type coseSign1 struct {
+	_           struct{} `cbor:",toarray"`
+	Protected   []byte
+	Unprotected cbor.RawMessage
+	Payload     []byte
+	Signature   []byte
+}
+
+type actionsAssertion struct {
+	Actions []struct {
+		Action            string            `cbor:"action"`
+		When              string            `cbor:"when"`
+		SoftwareAgent     string            `cbor:"softwareAgent"`
+		DigitalSourceType string            `cbor:"digitalSourceType"`
+		Parameters        map[string]string `cbor:"parameters"`
+	} `cbor:"actions"`
+}
+
+type dataHashAssertion struct {
+	Exclusions []struct {
+		Start  int `cbor:"start"`
+		Length int `cbor:"length"`
+	} `cbor:"exclusions"`
+	Alg  string `cbor:"alg"`
+	Hash []byte `cbor:"hash"`
+}
+
+// Verify reads the Content Credentials embedded in a JPEG and checks them.
+// Problems with the manifest are reported in Report.Errors; an error is only
+// returned when img is not a readable JPEG.
+func Verify(img []byte) (*Report, error) {
+	store, err := extractManifestStore(img)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{}
+	if store == nil {
+		return report, nil
+	}
+	report.HasCredentials = true
+
+	v := &verifier{img: img, report: report}
+	v.verifyStore(store)
+	report.Valid = len(report.Errors) == 0
+	return report, nil
+}
+
+type verifier struct {
+	img    []byte
+	report *Report
+}
+
+func (v *verifier) fail(format string, args ...any) {
+	v.report.Errors = append(v.report.Errors, fmt.Sprintf(format, args...))
+}
+
+func (v *verifier) verifyStore(store *superbox) {
+	// The active manifest is the last one in the store.
+	var manifest *superbox
+	for _, c := range store.children {
+		if sb, err := parseSuperbox(c); err == nil {
+			manifest = sb
+		}
+	}
+	if manifest == nil {
+		v.fail("manifest store contains no manifest")
+		return
+	}
+
+	claimBox, ok := manifest.child(labelClaim)
+	if !ok {
+		v.fail("manifest has no claim")
+		return
+	}
+	claimBytes, ok := claimBox.cbor()
+	if !ok {
+		v.fail("claim has no cbor content")
+		return
+	}
+	var cl claim
+	if err := cbor.Unmarshal(claimBytes, &cl); err != nil {
+		v.fail("decode claim: %v", err)
+		return
+	}
+	v.report.ClaimGenerator = cl.ClaimGenerator
+
+	if sigBox, ok := manifest.child(labelSignature); ok {
+		v.verifySignature(sigBox, claimBytes)
+	} else {
+		v.fail("manifest has no signature")
+	}
+
+	assertions, ok := manifest.child(labelAssertions)
+	if !ok {
+		v.fail("manifest has no assertion store")
+		return
+	}
+	v.verifyAssertions(assertions, cl.Assertions)
+}
+
+func (v *verifier) verifySignature(sigBox *superbox, claimBytes []byte) {
+	raw, ok := sigBox.cbor()
+	if !ok {
+		v.fail("signature has no cbor content")
+		return
+	}
+	var tag cbor.RawTag
+	if err := cbor.Unmarshal(raw, &tag); err != nil || tag.Number != coseTagSign1 {
+		v.fail("signature is not a COSE_Sign1")
+		return
+	}
+	var sign1 coseSign1
+	if err := cbor.Unmarshal(tag.Content, &sign1); err != nil {
+		v.fail("decode COSE_Sign1: %v", err)
+		return
+	}
+
+	var headers map[int]cbor.RawMessage
+	if err := cbor.Unmarshal(sign1.Protected, &headers); err != nil {
+		v.fail("decode protected header: %v", err)
+		return
+	}
+	var alg int
+	if err := cbor.Unmarshal(headers[coseHeaderAlg], &alg); err != nil || alg != coseAlgES256 {
+		v.fail("unsupported signature algorithm")
+		return
+	}
+	cert, err := leafCertificate(headers[coseHeaderX5])
+	if err != nil {
+		v.fail("signing certificate: %v", err)
+		return
+	}
+	v.report.Signer = &Signer{
+		CommonName: cert.Subject.CommonName,
+		SelfSigned: bytes.Equal(cert.RawSubject, cert.RawIssuer) &&
+			cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) == nil,
+	}
+	if len(cert.Subject.Organization) > 0 {
+		v.report.Signer.Organization = cert.Subject.Organization[0]
+	}
+
+	pub, ok := cert.PublicKey.(*ecdsa.PublicKey)
+	if !ok || len(sign1.Signature) != 64 {
+		v.fail("signature does not match certificate key type")
+		return
+	}
+	toBeSigned, err := cbor.Marshal([]any{"Signature1", sign1.Protected, []byte{}, claimBytes})
+	if err != nil {
+		v.fail("encode sig structure: %v", err)
+		return
+	}
+	digest := sha256.Sum256(toBeSigned)
+	r := new(big.Int).SetBytes(sign1.Signature[:32])
+	s := new(big.Int).SetBytes(sign1.Signature[32:])
+	if !ecdsa.Verify(pub, digest[:], r, s) {
+		v.fail("claim signature is invalid")
+	}
+}
+
+// leafCertificate parses an x5chain header, which is either a single
+// certificate or an array with the leaf first.
+func leafCertificate(x5chain cbor.RawMessage) (*x509.Certificate, error) {
+	if len(x5chain) == 0 {
+		return nil, fmt.Errorf("missing x5chain")
+	}
+	var der []byte
+	if err := cbor.Unmarshal(x5chain, &der); err != nil {
+		var chain [][]byte
+		if err := cbor.Unmarshal(x5chain, &chain); err != nil || len(chain) == 0 {
+			return nil, fmt.Errorf("malformed x5chain")
+		}
+		der = chain[0]
+	}
+	return x509.ParseCertificate(der)
+}
+
+func (v *verifier) verifyAssertions(store *superbox, refs []hashedURI) {
+	var sawDataHash bool
+	for _, ref := range refs {
+		label, ok := strings.CutPrefix(ref.URL, assertionURIPf)
+		if !ok {
+			v.fail("unsupported assertion reference %q", ref.URL)
+			continue
+		}
+		assertion, ok := store.child(label)
+		if !ok {
+			v.fail("assertion %s is missing", label)
+			continue
+		}
+		sum := sha256.Sum256(assertion.payload)
+		if (ref.Alg != "" && ref.Alg != hashAlgSHA256) || !bytes.Equal(sum[:], ref.Hash) {
+			v.fail("assertion %s does not match its hash in the claim", label)
+			continue
+		}
+		data, ok := assertion.cbor()
+		if !ok {
+			continue
+		}
+
+		switch label {
+		case labelActions:
+			v.readActions(data)
+		case labelDataHash:
+			sawDataHash = true
+			v.verifyDataHash(data)
+		}
+	}
+	if !sawDataHash {
+		v.fail("claim has no data hash binding it to the image")
+	}
+}
+
+func (v *verifier) readActions(data []byte) {
+	var a actionsAssertion
+	if err := cbor.Unmarshal(data, &a); err != nil {
+		v.fail("decode %s: %v", labelActions, err)
+		return
+	}
+	for _, act := range a.Actions {
+		v.report.Actions = append(v.report.Actions, Action{
+			Action:            act.Action,
+			When:              act.When,
+			SoftwareAgent:     act.SoftwareAgent,
+			DigitalSourceType: act.DigitalSourceType,
+			Parameters:        act.Parameters,
+		})
+	}
+}
+
+func (v *verifier) verifyDataHash(data []byte) {
+	var dh dataHashAssertion
+	if err := cbor.Unmarshal(data, &dh); err != nil {
+		v.fail("decode %s: %v", labelDataHash, err)
+		return
+	}
+	if dh.Alg != hashAlgSHA256 {
+		v.fail("unsupported data hash algorithm %q", dh.Alg)
+		return
+	}
+
+	h := sha256.New()
+	pos := 0
+	for _, ex := range dh.Exclusions {
+		if ex.Start < pos || ex.Length < 0 || ex.Start+ex.Length > len(v.img) {
+			v.fail("data hash exclusions are out of range")
+			return
+		}
+		h.Write(v.img[pos:ex.Start])
+		pos = ex.Start + ex.Length
+	}
+	h.Write(v.img[pos:])
+	if !bytes.Equal(h.Sum(nil), dh.Hash) {
+		v.fail("image data does not match the signed hash; the image was modified after signing")
+	}
+}
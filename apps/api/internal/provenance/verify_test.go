@@ -0,0 +1,120 @@
+package provenance
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name)
+	require.NoError(t, err)
+	return data
+}
+
+func replaceOnce(t *testing.T, data []byte, old, repl string) []byte {
+	t.Helper()
+	require.True(t, bytes.Contains(data, []byte(old)), "fixture does not contain %q", old)
+	return bytes.Replace(append([]byte{}, data...), []byte(old), []byte(repl), 1)
+}
+
+func TestVerify(t *testing.T) {
+	staged := readFixture(t, "staged_c2pa.jpg")
+
+	// Flip a byte in the entropy-coded image data, just before EOI.
+	pixelsTampered := append([]byte{}, staged...)
+	pixelsTampered[len(pixelsTampered)-10] ^= 0xFF
+
+	testCases := []struct {
+		name            string
+		img             []byte
+		wantErr         bool
+		wantCredentials bool
+		wantValid       bool
+		wantErrContains string
+	}{
+		{
+			name:            "success: signed staged image",
+			img:             staged,
+			wantCredentials: true,
+			wantValid:       true,
+		},
+		{
+			name: "success: jpeg without credentials",
+			img:  readFixture(t, "plain.jpg"),
+		},
+		{
+			name:            "fail: pixels modified after signing",
+			img:             pixelsTampered,
+			wantCredentials: true,
+			wantErrContains: "modified after signing",
+		},
+		{
+			name:            "fail: assertion edited",
+			img:             replaceOnce(t, staged, "living_room", "dining_room"),
+			wantCredentials: true,
+			wantErrContains: "c2pa.actions does not match",
+		},
+		{
+			name:            "fail: claim edited",
+			img:             replaceOnce(t, staged, "xmp:iid:", "xmp:iid;"),
+			wantCredentials: true,
+			wantErrContains: "claim signature is invalid",
+		},
+		{
+			name:    "fail: not a jpeg",
+			img:     []byte("\x89PNG\r\n\x1a\n"),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			report, err := Verify(tc.img)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantCredentials, report.HasCredentials)
+			assert.Equal(t, tc.wantValid, report.Valid)
+			if tc.wantErrContains != "" {
+				assert.Contains(t, report.Errors, findError(report.Errors, tc.wantErrContains))
+			}
+		})
+	}
+}
+
+func findError(errs []string, substr string) string {
+	for _, e := range errs {
+		if bytes.Contains([]byte(e), []byte(substr)) {
+			return e
+		}
+	}
+	return "<no error containing " + substr + ">"
+}
+
+func TestVerify_Report(t *testing.T) {
+	report, err := Verify(readFixture(t, "staged_c2pa.jpg"))
+	require.NoError(t, err)
+	require.Empty(t, report.Errors)
+
+	assert.Equal(t, "Real Staging AI", report.ClaimGenerator)
+	require.NotNil(t, report.Signer)
+	assert.Equal(t, "Real Staging AI (fixture)", report.Signer.CommonName)
+	assert.True(t, report.Signer.SelfSigned)
+
+	require.Len(t, report.Actions, 1)
+	act := report.Actions[0]
+	assert.Equal(t, "c2pa.edited", act.Action)
+	assert.Equal(t, "2025-06-01T12:00:00Z", act.When)
+	assert.Equal(t, "Real Staging AI", act.SoftwareAgent)
+	assert.Equal(t, "http://cv.iptc.org/newscodes/digitalsourcetype/compositeWithTrainedAlgorithmicMedia",
+		act.DigitalSourceType)
+	assert.Equal(t, "black-forest-labs/flux-kontext-max", act.Parameters["model"])
+	assert.Equal(t, "living_room", act.Parameters["room_type"])
+}
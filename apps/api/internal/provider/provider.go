@@ -0,0 +1,102 @@
+// Package provider selects the vendor behind each external capability, such as geocoding,
+// email or SMS, by the name configured for it. A capability's package keeps a Registry of
+// its vendors; business code only sees the capability's interface, so adding a vendor is
+// a new implementation and one Register call.
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Capability names a kind of external provider.
+type Capability string
+
+// Capabilities served through registries.
+const (
+	CapabilityModeration Capability = "moderation"
+	CapabilityGeocoding  Capability = "geocoding"
+	CapabilityEmail      Capability = "email"
+	CapabilitySMS        Capability = "sms"
+)
+
+// ErrUnknown is returned when no vendor is registered under the configured name.
+var ErrUnknown = errors.New("unknown provider")
+
+// HealthChecker is implemented by vendors that can check they are reachable and
+// accepting requests, without side effects.
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+// Factory builds a vendor from its capability's settings.
+type Factory[C, T any] func(cfg C) (T, error)
+
+// Registry holds the vendors of one capability, keyed by the name config selects them by.
+// Vendors are registered while the registry is built, before it is used, so it needs no
+// locking.
+type Registry[C, T any] struct {
+	capability Capability
+	factories  map[string]Factory[C, T]
+}
+
+// NewRegistry creates an empty registry for capability.
+func NewRegistry[C, T any](capability Capability) *Registry[C, T] {
+	return &Registry[C, T]{capability: capability, factories: map[string]Factory[C, T]{}}
+}
+
+// Register adds a vendor under name and returns the registry for chaining. Registering a
+// name twice is a programming error and panics.
+func (r *Registry[C, T]) Register(name string, factory Factory[C, T]) *Registry[C, T] {
+	if name == "" || factory == nil {
+		panic(fmt.Sprintf("provider: %s vendor needs a name and a factory", r.capability))
+	}
+	if _, ok := r.factories[name]; ok {
+		panic(fmt.Sprintf("provider: %s vendor %q registered twice", r.capability, name))
+	}
+	r.factories[name] = factory
+	return r
+}
+
+// Capability returns the capability the registry's vendors provide.
+func (r *Registry[C, T]) Capability() Capability {
+	return r.capability
+}
+
+// Names returns the registered vendor names, sorted.
+func (r *Registry[C, T]) Names() []string {
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New builds the vendor registered as name from cfg.
+func (r *Registry[C, T]) New(name string, cfg C) (T, error) {
+	factory, ok := r.factories[name]
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("%w: %s provider %q, want one of %s",
+			ErrUnknown, r.capability, name, strings.Join(r.Names(), ", "))
+	}
+	vendor, err := factory(cfg)
+	if err != nil {
+		var zero T
+		return zero, fmt.Errorf("failed to create %s provider %q: %w", r.capability, name, err)
+	}
+	return vendor, nil
+}
+
+// HealthCheck returns vendor's health check, or false when it cannot check itself.
+func HealthCheck(vendor any) (func(ctx context.Context) error, bool) {
+	checker, ok := vendor.(HealthChecker)
+	if !ok {
+		return nil, false
+	}
+	return checker.CheckHealth, true
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type greeter interface{ Greet() string }
+
+type english struct{ name string }
+
+func (e english) Greet() string { return "hello " + e.name }
+
+type checked struct {
+	english
+	err error
+}
+
+func (c checked) CheckHealth(ctx context.Context) error { return c.err }
+
+func newTestRegistry() *Registry[string, greeter] {
+	return NewRegistry[string, greeter](CapabilityEmail).
+		Register("english", func(cfg string) (greeter, error) { return english{name: cfg}, nil }).
+		Register("broken", func(cfg string) (greeter, error) { return nil, errors.New("missing api key") })
+}
+
+func TestRegistry_New(t *testing.T) {
+	testCases := []struct {
+		name       string
+		vendor     string
+		want       string
+		wantErr    error
+		wantErrSub string
+	}{
+		{name: "success: registered vendor", vendor: "english", want: "hello ada"},
+		{name: "fail: unknown vendor", vendor: "french", wantErr: ErrUnknown, wantErrSub: "want one of broken, english"},
+		{name: "fail: factory error", vendor: "broken", wantErrSub: `email provider "broken": missing api key`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g, err := newTestRegistry().New(tc.vendor, "ada")
+			if tc.wantErrSub != "" {
+				if tc.wantErr != nil {
+					assert.ErrorIs(t, err, tc.wantErr)
+				}
+				assert.ErrorContains(t, err, tc.wantErrSub)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, g.Greet())
+		})
+	}
+}
+
+func TestRegistry_Register(t *testing.T) {
+	r := newTestRegistry()
+	assert.Equal(t, []string{"broken", "english"}, r.Names())
+	assert.Equal(t, CapabilityEmail, r.Capability())
+	assert.Panics(t, func() {
+		r.Register("english", func(cfg string) (greeter, error) { return english{}, nil })
+	})
+	assert.Panics(t, func() { r.Register("", nil) })
+}
+
+func TestHealthCheck(t *testing.T) {
+	_, ok := HealthCheck(english{})
+	assert.False(t, ok)
+	_, ok = HealthCheck(nil)
+	assert.False(t, ok)
+
+	check, ok := HealthCheck(checked{err: errors.New("unreachable")})
+	require.True(t, ok)
+	assert.EqualError(t, check(context.Background()), "unreachable")
+}
@@ -0,0 +1,74 @@
+package querystats
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DefaultHandler serves statement statistics over HTTP.
+type DefaultHandler struct {
+	service Service
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service) *DefaultHandler {
+	return &DefaultHandler{service: service}
+}
+
+// ErrorResponse is a simple JSON error envelope for handler responses.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// TopQueriesResponse lists the heaviest statements.
+type TopQueriesResponse struct {
+	Order   Order       `json:"order"`
+	Queries []QueryStat `json:"queries"`
+}
+
+// GetTopQueries handles GET /api/v1/admin/db/queries?limit=&order=.
+func (h *DefaultHandler) GetTopQueries(c echo.Context) error {
+	opts := Options{Order: Order(c.QueryParam("order"))}
+	if opts.Order == "" {
+		opts.Order = OrderTotalTime
+	}
+	if !opts.Order.Valid() {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "order must be one of total_time, mean_time, calls",
+		})
+	}
+	if raw := c.QueryParam("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "bad_request",
+				Message: "limit must be a positive integer",
+			})
+		}
+		opts.Limit = limit
+	}
+
+	stats, err := h.service.TopQueries(c.Request().Context(), opts)
+	if errors.Is(err, ErrUnavailable) {
+		return c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error:   "unavailable",
+			Message: "pg_stat_statements is not enabled on this database",
+		})
+	}
+	if err != nil {
+		c.Logger().Errorf("Failed to read query stats: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to retrieve query stats",
+		})
+	}
+	return c.JSON(http.StatusOK, TopQueriesResponse{Order: opts.Order, Queries: stats})
+}
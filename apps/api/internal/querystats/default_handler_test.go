@@ -0,0 +1,96 @@
+package querystats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultHandler_GetTopQueries(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		serviceErr     error
+		expectedStatus int
+		expectedOpts   Options
+	}{
+		{
+			name:           "success: defaults",
+			expectedStatus: http.StatusOK,
+			expectedOpts:   Options{Order: OrderTotalTime},
+		},
+		{
+			name:           "success: order and limit",
+			query:          "?order=calls&limit=5",
+			expectedStatus: http.StatusOK,
+			expectedOpts:   Options{Order: OrderCalls, Limit: 5},
+		},
+		{
+			name:           "fail: unknown order",
+			query:          "?order=rows",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "fail: invalid limit",
+			query:          "?limit=0",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "fail: extension unavailable",
+			serviceErr:     fmt.Errorf("%w: relation does not exist", ErrUnavailable),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedOpts:   Options{Order: OrderTotalTime},
+		},
+		{
+			name:           "fail: service error",
+			serviceErr:     errors.New("db down"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedOpts:   Options{Order: OrderTotalTime},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				TopQueriesFunc: func(ctx context.Context, opts Options) ([]QueryStat, error) {
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+					return []QueryStat{{QueryID: "42", Name: "ListProjects", Calls: 3}}, nil
+				},
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/db/queries"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := NewDefaultHandler(svc).GetTopQueries(c)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusBadRequest {
+				assert.Empty(t, svc.TopQueriesCalls())
+				return
+			}
+			require.Len(t, svc.TopQueriesCalls(), 1)
+			assert.Equal(t, tc.expectedOpts, svc.TopQueriesCalls()[0].Opts)
+
+			if tc.expectedStatus == http.StatusOK {
+				var body TopQueriesResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Equal(t, tc.expectedOpts.Order, body.Order)
+				require.Len(t, body.Queries, 1)
+				assert.Equal(t, "ListProjects", body.Queries[0].Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package querystats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// orderColumns maps each Order to the pg_stat_statements column it sorts by. Only these
+// constants are ever interpolated into the statement.
+var orderColumns = map[Order]string{
+	OrderTotalTime: "total_exec_time",
+	OrderMeanTime:  "mean_exec_time",
+	OrderCalls:     "calls",
+}
+
+const topQueriesSQL = `
+SELECT queryid::text, query, calls, total_exec_time, mean_exec_time, max_exec_time, rows,
+       CASE WHEN shared_blks_hit + shared_blks_read = 0 THEN 1
+            ELSE shared_blks_hit::float8 / (shared_blks_hit + shared_blks_read) END
+FROM pg_stat_statements
+WHERE dbid = (SELECT oid FROM pg_database WHERE datname = current_database())
+ORDER BY %s DESC
+LIMIT $1`
+
+// DefaultService reads statement statistics from pg_stat_statements.
+type DefaultService struct {
+	db storage.Database
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService.
+func NewDefaultService(db storage.Database) *DefaultService {
+	return &DefaultService{db: db}
+}
+
+// TopQueries returns the heaviest statements of the current database.
+func (s *DefaultService) TopQueries(ctx context.Context, opts Options) ([]QueryStat, error) {
+	ctx, span := otel.Tracer("real-staging-api/querystats").Start(ctx, "querystats.top_queries")
+	defer span.End()
+
+	limit := opts.Limit
+	switch {
+	case limit <= 0:
+		limit = DefaultLimit
+	case limit > MaxLimit:
+		limit = MaxLimit
+	}
+	order := opts.Order
+	if order == "" {
+		order = OrderTotalTime
+	}
+	column, ok := orderColumns[order]
+	if !ok {
+		return nil, fmt.Errorf("unknown order %q", order)
+	}
+
+	rows, err := s.db.Query(ctx, fmt.Sprintf(topQueriesSQL, column), limit)
+	if err != nil {
+		span.RecordError(err)
+		return nil, wrapErr(err)
+	}
+	defer rows.Close()
+
+	stats := []QueryStat{}
+	for rows.Next() {
+		var q QueryStat
+		if err := rows.Scan(
+			&q.QueryID, &q.Query, &q.Calls, &q.TotalTimeMs, &q.MeanTimeMs, &q.MaxTimeMs, &q.Rows, &q.CacheHitRatio,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan query stats: %w", err)
+		}
+		q.Name = storage.QueryName(q.Query)
+		stats = append(stats, q)
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		return nil, wrapErr(err)
+	}
+	return stats, nil
+}
+
+// wrapErr reports a missing or unloaded extension as ErrUnavailable.
+func wrapErr(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "42P01", // undefined_table: the extension is not installed
+			"55000": // object_not_in_prerequisite_state: not in shared_preload_libraries
+			return fmt.Errorf("%w: %s", ErrUnavailable, pgErr.Message)
+		}
+	}
+	return fmt.Errorf("failed to read query stats: %w", err)
+}
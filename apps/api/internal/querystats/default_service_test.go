@@ -0,0 +1,121 @@
+package querystats
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+var statColumns = []string{
+	"queryid", "query", "calls", "total_exec_time", "mean_exec_time", "max_exec_time", "rows", "hit_ratio",
+}
+
+func TestDefaultService_TopQueries(t *testing.T) {
+	testCases := []struct {
+		name     string
+		opts     Options
+		setup    func(mock pgxmock.PgxPoolIface)
+		wantErr  error
+		errMsg   string
+		validate func(t *testing.T, stats []QueryStat)
+	}{
+		{
+			name: "success: defaults rank by total time",
+			setup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(regexp.QuoteMeta("ORDER BY total_exec_time DESC")).WithArgs(DefaultLimit).
+					WillReturnRows(pgxmock.NewRows(statColumns).
+						AddRow("42", "-- name: ListProjects :many\nSELECT 1",
+							int64(10), 120.5, 12.05, 40.0, int64(30), 0.99).
+						AddRow("43", "SELECT count(*) FROM users", int64(1), 3.0, 3.0, 3.0, int64(1), 1.0))
+			},
+			validate: func(t *testing.T, stats []QueryStat) {
+				require.Len(t, stats, 2)
+				assert.Equal(t, "42", stats[0].QueryID)
+				assert.Equal(t, "ListProjects", stats[0].Name)
+				assert.Equal(t, int64(10), stats[0].Calls)
+				assert.Equal(t, 12.05, stats[0].MeanTimeMs)
+				assert.Equal(t, "unnamed", stats[1].Name)
+			},
+		},
+		{
+			name: "success: mean time order and clamped limit",
+			opts: Options{Order: OrderMeanTime, Limit: 1000},
+			setup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(regexp.QuoteMeta("ORDER BY mean_exec_time DESC")).WithArgs(MaxLimit).
+					WillReturnRows(pgxmock.NewRows(statColumns))
+			},
+			validate: func(t *testing.T, stats []QueryStat) {
+				assert.NotNil(t, stats)
+				assert.Empty(t, stats)
+			},
+		},
+		{
+			name:   "fail: unknown order",
+			opts:   Options{Order: "rows; DROP TABLE users"},
+			setup:  func(mock pgxmock.PgxPoolIface) {},
+			errMsg: "unknown order",
+		},
+		{
+			name: "fail: extension not installed",
+			setup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("pg_stat_statements").WithArgs(DefaultLimit).
+					WillReturnError(&pgconn.PgError{
+						Code: "42P01", Message: `relation "pg_stat_statements" does not exist`,
+					})
+			},
+			wantErr: ErrUnavailable,
+		},
+		{
+			name: "fail: extension not preloaded",
+			setup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("pg_stat_statements").WithArgs(DefaultLimit).
+					WillReturnError(&pgconn.PgError{Code: "55000", Message: "pg_stat_statements must be loaded"})
+			},
+			wantErr: ErrUnavailable,
+		},
+		{
+			name: "fail: other database error",
+			setup: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery("pg_stat_statements").WithArgs(DefaultLimit).
+					WillReturnError(errors.New("connection reset"))
+			},
+			errMsg: "failed to read query stats: connection reset",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+			tc.setup(mock)
+
+			db := &storage.DatabaseMock{
+				QueryFunc: func(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+					return mock.Query(ctx, sql, args...)
+				},
+			}
+			stats, err := NewDefaultService(db).TopQueries(context.Background(), tc.opts)
+
+			switch {
+			case tc.wantErr != nil:
+				assert.ErrorIs(t, err, tc.wantErr)
+			case tc.errMsg != "":
+				assert.ErrorContains(t, err, tc.errMsg)
+			default:
+				require.NoError(t, err)
+				tc.validate(t, stats)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
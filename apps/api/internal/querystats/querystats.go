@@ -0,0 +1,59 @@
+// Package querystats surfaces the heaviest statements recorded by the pg_stat_statements
+// extension, so regressions from new queries show up on the internal ops dashboard.
+package querystats
+
+import "errors"
+
+const (
+	// DefaultLimit is how many statements are returned when the caller does not ask.
+	DefaultLimit = 20
+	// MaxLimit caps how many statements one request may return.
+	MaxLimit = 100
+)
+
+// Order selects the statistic statements are ranked by.
+type Order string
+
+const (
+	// OrderTotalTime ranks by the cumulative execution time. It is the default.
+	OrderTotalTime Order = "total_time"
+	// OrderMeanTime ranks by the average execution time per call.
+	OrderMeanTime Order = "mean_time"
+	// OrderCalls ranks by the number of executions.
+	OrderCalls Order = "calls"
+)
+
+// Valid reports whether o is a known order.
+func (o Order) Valid() bool {
+	switch o {
+	case OrderTotalTime, OrderMeanTime, OrderCalls:
+		return true
+	}
+	return false
+}
+
+// ErrUnavailable is returned when pg_stat_statements is not installed or not loaded.
+var ErrUnavailable = errors.New("pg_stat_statements is not available")
+
+// Options selects which statements TopQueries returns.
+type Options struct {
+	// Limit is how many statements to return, clamped to 1..MaxLimit. Zero uses DefaultLimit.
+	Limit int
+	// Order ranks the statements. Empty uses OrderTotalTime.
+	Order Order
+}
+
+// QueryStat aggregates the executions of one normalized statement in the current database.
+type QueryStat struct {
+	QueryID string `json:"query_id"`
+	// Name is the sqlc query name, or "unnamed" for hand-written SQL.
+	Name        string  `json:"name"`
+	Query       string  `json:"query"`
+	Calls       int64   `json:"calls"`
+	TotalTimeMs float64 `json:"total_time_ms"`
+	MeanTimeMs  float64 `json:"mean_time_ms"`
+	MaxTimeMs   float64 `json:"max_time_ms"`
+	Rows        int64   `json:"rows"`
+	// CacheHitRatio is the share of shared blocks found in the buffer cache, from 0 to 1.
+	CacheHitRatio float64 `json:"cache_hit_ratio"`
+}
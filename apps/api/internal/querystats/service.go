@@ -0,0 +1,12 @@
+package querystats
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service defines the interface for reading statement statistics.
+type Service interface {
+	// TopQueries returns the statements of the current database ranked by opts.Order. It
+	// returns ErrUnavailable when pg_stat_statements is not installed.
+	TopQueries(ctx context.Context, opts Options) ([]QueryStat, error)
+}
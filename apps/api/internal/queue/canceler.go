@@ -0,0 +1,168 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	redis "github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+// CancelKeyPrefix namespaces the Redis keys used to signal workers that an
+// image's in-flight stage:run task should be aborted.
+const CancelKeyPrefix = "jobs:cancel:"
+
+// CancelSignalTTL bounds how long a cancel signal is retained in Redis. It only
+// needs to outlive the longest provider call a worker may be waiting on.
+const CancelSignalTTL = time.Hour
+
+// CancelKey returns the Redis key a worker checks to decide whether to abort
+// processing of the given image.
+func CancelKey(imageID string) string {
+	return CancelKeyPrefix + imageID
+}
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out canceler_mock.go . Canceler
+
+// Canceler removes pending stage:run tasks and signals workers to abort
+// tasks that are already being processed.
+type Canceler interface {
+	// CancelStageRun removes a pending task from the queue. It reports false
+	// when the task is no longer pending (already picked up or finished).
+	CancelStageRun(ctx context.Context, taskID string) (bool, error)
+
+	// SignalCancel asks any worker processing the image to abort at its next checkpoint.
+	SignalCancel(ctx context.Context, imageID string) error
+}
+
+// AsynqCanceler implements Canceler using the asynq inspector and Redis.
+type AsynqCanceler struct {
+	inspector     *asynq.Inspector
+	rdb           *redis.Client
+	defaultQueue  string
+	criticalQueue string
+}
+
+// NewAsynqCancelerFromConfig creates a canceler using the same Redis settings as the enqueuer.
+// - redis.addr (REDIS_ADDR): required (e.g., "localhost:6379")
+// - job.queue_name (JOB_QUEUE_NAME): optional (defaults to "default")
+// - job.critical_queue_name (JOB_CRITICAL_QUEUE_NAME): optional (defaults to "critical")
+func NewAsynqCancelerFromConfig(cfg *config.Config) (*AsynqCanceler, error) {
+	addr, err := redisAddr(cfg)
+	if err != nil {
+		return nil, err
+	}
+	rdb := redis.NewClient(&redis.Options{Addr: addr})
+	return NewAsynqCancelerWithClient(rdb, cfg.Job.QueueName, cfg.Job.CriticalQueueName), nil
+}
+
+// NewAsynqCancelerWithClient constructs a canceler with a provided redis client.
+// Tasks are looked up in queueName and then in criticalQueueName, where expedited
+// tasks are moved.
+func NewAsynqCancelerWithClient(rdb *redis.Client, queueName, criticalQueueName string) *AsynqCanceler {
+	if queueName == "" {
+		queueName = "default"
+	}
+	if criticalQueueName == "" {
+		criticalQueueName = "critical"
+	}
+	return &AsynqCanceler{
+		inspector:     asynq.NewInspectorFromRedisClient(rdb),
+		rdb:           rdb,
+		defaultQueue:  queueName,
+		criticalQueue: criticalQueueName,
+	}
+}
+
+// CancelStageRun deletes a pending, scheduled, or retrying task from the default
+// queue, or from the critical queue if the image was expedited.
+func (c *AsynqCanceler) CancelStageRun(ctx context.Context, taskID string) (bool, error) {
+	tracer := otel.Tracer("real-staging-api/queue")
+	_, span := tracer.Start(ctx, "queue.CancelStageRun")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("queue.id", taskID),
+		attribute.String("queue.name", c.defaultQueue),
+	)
+
+	for _, queueName := range []string{c.defaultQueue, c.criticalQueue} {
+		removed, err := c.deleteTask(queueName, taskID)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "cancel task")
+			return false, err
+		}
+		if removed {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// deleteTask removes the task from queueName unless it is missing, active, or completed.
+func (c *AsynqCanceler) deleteTask(queueName, taskID string) (bool, error) {
+	info, err := c.inspector.GetTaskInfo(queueName, taskID)
+	if err != nil {
+		if errors.Is(err, asynq.ErrTaskNotFound) || errors.Is(err, asynq.ErrQueueNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("get task info: %w", err)
+	}
+	if info.State == asynq.TaskStateActive || info.State == asynq.TaskStateCompleted {
+		return false, nil
+	}
+
+	if err := c.inspector.DeleteTask(queueName, taskID); err != nil {
+		if errors.Is(err, asynq.ErrTaskNotFound) || errors.Is(err, asynq.ErrQueueNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("delete task: %w", err)
+	}
+	return true, nil
+}
+
+// SignalCancel records a cancel signal for the image that workers poll at checkpoints.
+func (c *AsynqCanceler) SignalCancel(ctx context.Context, imageID string) error {
+	tracer := otel.Tracer("real-staging-api/queue")
+	ctx, span := tracer.Start(ctx, "queue.SignalCancel")
+	defer span.End()
+	span.SetAttributes(attribute.String("image.id", imageID))
+
+	if imageID == "" {
+		err := errors.New("image_id is required")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	if err := c.rdb.Set(ctx, CancelKey(imageID), "1", CancelSignalTTL).Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "set cancel signal")
+		return fmt.Errorf("set cancel signal: %w", err)
+	}
+	return nil
+}
+
+// Close releases the underlying Redis resources.
+func (c *AsynqCanceler) Close() error {
+	return c.rdb.Close()
+}
+
+// NoopCanceler is a drop-in Canceler that does nothing (useful for tests).
+type NoopCanceler struct{}
+
+// CancelStageRun implements Canceler by reporting that no task was removed.
+func (NoopCanceler) CancelStageRun(_ context.Context, _ string) (bool, error) {
+	return false, nil
+}
+
+// SignalCancel implements Canceler without side effects.
+func (NoopCanceler) SignalCancel(_ context.Context, _ string) error {
+	return nil
+}
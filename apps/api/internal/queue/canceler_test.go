@@ -0,0 +1,117 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/hibiken/asynq"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelKey(t *testing.T) {
+	assert.Equal(t, "jobs:cancel:img-1", CancelKey("img-1"))
+}
+
+func TestAsynqCanceler_SignalCancel(t *testing.T) {
+	testCases := []struct {
+		name        string
+		imageID     string
+		expectError bool
+	}{
+		{
+			name:    "success: sets cancel key with ttl",
+			imageID: "img-1",
+		},
+		{
+			name:        "fail: empty image id",
+			imageID:     "",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mr := miniredis.RunT(t)
+			rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+			c := NewAsynqCancelerWithClient(rdb, "", "")
+			t.Cleanup(func() { _ = c.Close() })
+
+			err := c.SignalCancel(context.Background(), tc.imageID)
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, mr.Exists(CancelKey(tc.imageID)))
+			assert.Equal(t, CancelSignalTTL, mr.TTL(CancelKey(tc.imageID)))
+		})
+	}
+}
+
+func TestAsynqCanceler_CancelStageRun(t *testing.T) {
+	t.Run("success: unknown task is reported as not removed", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		c := NewAsynqCancelerWithClient(rdb, "default", "critical")
+		t.Cleanup(func() { _ = c.Close() })
+
+		removed, err := c.CancelStageRun(context.Background(), "missing-task")
+		require.NoError(t, err)
+		assert.False(t, removed)
+	})
+
+	t.Run("success: pending task is removed from the queue", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		client := asynq.NewClient(asynq.RedisClientOpt{Addr: mr.Addr()})
+		t.Cleanup(func() { _ = client.Close() })
+		_, err := client.Enqueue(asynq.NewTask(TaskTypeStageRun, []byte(`{}`)), asynq.TaskID("job-1"))
+		require.NoError(t, err)
+
+		rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		c := NewAsynqCancelerWithClient(rdb, "default", "critical")
+		t.Cleanup(func() { _ = c.Close() })
+
+		removed, err := c.CancelStageRun(context.Background(), "job-1")
+		require.NoError(t, err)
+		assert.True(t, removed)
+	})
+
+	t.Run("success: expedited task is removed from the critical queue", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		client := asynq.NewClient(asynq.RedisClientOpt{Addr: mr.Addr()})
+		t.Cleanup(func() { _ = client.Close() })
+		_, err := client.Enqueue(asynq.NewTask(TaskTypeStageRun, []byte(`{}`)),
+			asynq.TaskID("job-1"), asynq.Queue("critical"))
+		require.NoError(t, err)
+
+		rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		c := NewAsynqCancelerWithClient(rdb, "default", "critical")
+		t.Cleanup(func() { _ = c.Close() })
+
+		removed, err := c.CancelStageRun(context.Background(), "job-1")
+		require.NoError(t, err)
+		assert.True(t, removed)
+	})
+
+	t.Run("fail: redis unavailable", func(t *testing.T) {
+		rdb := redis.NewClient(&redis.Options{Addr: "127.0.0.1:6391", DialTimeout: 50 * time.Millisecond})
+		c := NewAsynqCancelerWithClient(rdb, "default", "critical")
+		t.Cleanup(func() { _ = c.Close() })
+
+		removed, err := c.CancelStageRun(context.Background(), "task-1")
+		assert.Error(t, err)
+		assert.False(t, removed)
+	})
+}
+
+func TestNoopCanceler(t *testing.T) {
+	var c Canceler = NoopCanceler{}
+	removed, err := c.CancelStageRun(context.Background(), "task-1")
+	assert.NoError(t, err)
+	assert.False(t, removed)
+	assert.NoError(t, c.SignalCancel(context.Background(), "img-1"))
+}
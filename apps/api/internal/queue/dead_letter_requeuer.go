@@ -0,0 +1,120 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	redis "github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+// ErrTaskInQueue is returned when requeuing a task that is already back in the queue.
+var ErrTaskInQueue = errors.New("task is already in the queue")
+
+// DeadLetterTask is a task that used up its retries, as workers recorded it.
+type DeadLetterTask struct {
+	TaskID   string
+	TaskType string
+	Queue    string
+	// Payload is the task payload as enqueued, still sealed if it was.
+	Payload  []byte
+	MaxRetry int
+}
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out dead_letter_requeuer_mock.go . DeadLetterRequeuer
+
+// DeadLetterRequeuer puts dead tasks back in the queue.
+type DeadLetterRequeuer interface {
+	// Requeue enqueues the task again under its ID with a fresh set of retries. Returns
+	// ErrTaskInQueue when the task is pending, scheduled, retrying or running.
+	Requeue(ctx context.Context, task DeadLetterTask) error
+}
+
+// AsynqDeadLetterRequeuer implements DeadLetterRequeuer using the asynq inspector and client.
+type AsynqDeadLetterRequeuer struct {
+	inspector *asynq.Inspector
+	client    *asynq.Client
+	rdb       *redis.Client
+}
+
+// NewAsynqDeadLetterRequeuerFromConfig creates a requeuer using the same Redis settings as the enqueuer.
+// - redis.addr (REDIS_ADDR): required (e.g., "localhost:6379")
+func NewAsynqDeadLetterRequeuerFromConfig(cfg *config.Config) (*AsynqDeadLetterRequeuer, error) {
+	addr, err := redisAddr(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return NewAsynqDeadLetterRequeuerWithClient(redis.NewClient(&redis.Options{Addr: addr})), nil
+}
+
+// NewAsynqDeadLetterRequeuerWithClient constructs a requeuer with a provided redis client.
+func NewAsynqDeadLetterRequeuerWithClient(rdb *redis.Client) *AsynqDeadLetterRequeuer {
+	return &AsynqDeadLetterRequeuer{
+		inspector: asynq.NewInspectorFromRedisClient(rdb),
+		client:    asynq.NewClientFromRedisClient(rdb),
+		rdb:       rdb,
+	}
+}
+
+// Requeue deletes the archived copy of the task, if asynq still keeps one, and enqueues
+// the recorded payload under the same ID. Running the archived copy instead would keep its
+// retry count, leaving the task a single attempt.
+func (r *AsynqDeadLetterRequeuer) Requeue(ctx context.Context, task DeadLetterTask) error {
+	tracer := otel.Tracer("real-staging-api/queue")
+	ctx, span := tracer.Start(ctx, "queue.RequeueDeadLetter")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("queue.id", task.TaskID),
+		attribute.String("queue.task_type", task.TaskType),
+		attribute.String("queue.name", task.Queue),
+	)
+
+	info, err := r.inspector.GetTaskInfo(task.Queue, task.TaskID)
+	switch {
+	case errors.Is(err, asynq.ErrTaskNotFound), errors.Is(err, asynq.ErrQueueNotFound):
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "get task info")
+		return fmt.Errorf("get task info: %w", err)
+	case info.State != asynq.TaskStateArchived && info.State != asynq.TaskStateCompleted:
+		return fmt.Errorf("%w: %s", ErrTaskInQueue, info.State)
+	default:
+		err := r.inspector.DeleteTask(task.Queue, task.TaskID)
+		if err != nil && !errors.Is(err, asynq.ErrTaskNotFound) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "delete task")
+			return fmt.Errorf("delete archived task: %w", err)
+		}
+	}
+
+	_, err = r.client.EnqueueContext(ctx, asynq.NewTask(task.TaskType, task.Payload),
+		asynq.Queue(task.Queue), asynq.TaskID(task.TaskID), asynq.MaxRetry(task.MaxRetry))
+	if errors.Is(err, asynq.ErrTaskIDConflict) {
+		return ErrTaskInQueue
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "enqueue error")
+		return fmt.Errorf("enqueue %s: %w", task.TaskType, err)
+	}
+	return nil
+}
+
+// Close releases the underlying Redis resources.
+func (r *AsynqDeadLetterRequeuer) Close() error {
+	return r.rdb.Close()
+}
+
+// NoopDeadLetterRequeuer is a drop-in DeadLetterRequeuer that does nothing (useful for tests).
+type NoopDeadLetterRequeuer struct{}
+
+// Requeue implements DeadLetterRequeuer without side effects.
+func (NoopDeadLetterRequeuer) Requeue(_ context.Context, _ DeadLetterTask) error {
+	return nil
+}
@@ -0,0 +1,77 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/hibiken/asynq"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsynqDeadLetterRequeuer_Requeue(t *testing.T) {
+	task := DeadLetterTask{
+		TaskID:   "job-1",
+		TaskType: TaskTypeStageRun,
+		Queue:    "default",
+		Payload:  []byte(`{"image_id":"img-1"}`),
+		MaxRetry: 5,
+	}
+	setup := func(t *testing.T) (*miniredis.Miniredis, *asynq.Inspector, *AsynqDeadLetterRequeuer) {
+		mr := miniredis.RunT(t)
+		rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		r := NewAsynqDeadLetterRequeuerWithClient(rdb)
+		t.Cleanup(func() { _ = r.Close() })
+		return mr, asynq.NewInspectorFromRedisClient(rdb), r
+	}
+	enqueue := func(t *testing.T, mr *miniredis.Miniredis) {
+		client := asynq.NewClient(asynq.RedisClientOpt{Addr: mr.Addr()})
+		t.Cleanup(func() { _ = client.Close() })
+		_, err := client.Enqueue(asynq.NewTask(TaskTypeStageRun, task.Payload), asynq.TaskID(task.TaskID), asynq.MaxRetry(1))
+		require.NoError(t, err)
+	}
+
+	t.Run("success: archived task is replaced with a fresh one", func(t *testing.T) {
+		mr, inspector, r := setup(t)
+		enqueue(t, mr)
+		require.NoError(t, inspector.ArchiveTask("default", task.TaskID))
+
+		require.NoError(t, r.Requeue(context.Background(), task))
+
+		info, err := inspector.GetTaskInfo("default", task.TaskID)
+		require.NoError(t, err)
+		assert.Equal(t, asynq.TaskStatePending, info.State)
+		assert.Equal(t, TaskTypeStageRun, info.Type)
+		assert.JSONEq(t, `{"image_id":"img-1"}`, string(info.Payload))
+		assert.Equal(t, 5, info.MaxRetry)
+		assert.Zero(t, info.Retried)
+	})
+
+	t.Run("success: task asynq no longer keeps is enqueued", func(t *testing.T) {
+		_, inspector, r := setup(t)
+
+		require.NoError(t, r.Requeue(context.Background(), task))
+
+		info, err := inspector.GetTaskInfo("default", task.TaskID)
+		require.NoError(t, err)
+		assert.Equal(t, asynq.TaskStatePending, info.State)
+	})
+
+	t.Run("fail: task already back in the queue", func(t *testing.T) {
+		mr, _, r := setup(t)
+		enqueue(t, mr)
+
+		assert.ErrorIs(t, r.Requeue(context.Background(), task), ErrTaskInQueue)
+	})
+
+	t.Run("fail: redis unavailable", func(t *testing.T) {
+		rdb := redis.NewClient(&redis.Options{Addr: "127.0.0.1:6391", DialTimeout: 50 * time.Millisecond})
+		r := NewAsynqDeadLetterRequeuerWithClient(rdb)
+		t.Cleanup(func() { _ = r.Close() })
+
+		assert.Error(t, r.Requeue(context.Background(), task))
+	})
+}
@@ -0,0 +1,88 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hibiken/asynq"
+	redis "github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+// Depth counts the tasks in the job queue by state.
+type Depth struct {
+	// Pending tasks are ready to be picked up by a worker.
+	Pending int `json:"pending"`
+	// Active tasks are being processed.
+	Active int `json:"active"`
+	// Scheduled tasks become pending at a later time.
+	Scheduled int `json:"scheduled"`
+	// Retry tasks failed and are waiting to be retried.
+	Retry int `json:"retry"`
+}
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out depth_reader_mock.go . DepthReader
+
+// DepthReader reports how many tasks are waiting in the job queue.
+type DepthReader interface {
+	Depth(ctx context.Context) (*Depth, error)
+}
+
+// AsynqDepthReader implements DepthReader using the asynq inspector.
+type AsynqDepthReader struct {
+	inspector *asynq.Inspector
+	queue     string
+}
+
+// NewAsynqDepthReaderFromConfig creates a depth reader for the queue the enqueuer uses.
+func NewAsynqDepthReaderFromConfig(cfg *config.Config) (*AsynqDepthReader, error) {
+	addr, err := redisAddr(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return NewAsynqDepthReaderWithClient(redis.NewClient(&redis.Options{Addr: addr}), cfg.Job.QueueName), nil
+}
+
+// NewAsynqDepthReaderWithClient constructs a depth reader with a provided redis client.
+func NewAsynqDepthReaderWithClient(rdb *redis.Client, queueName string) *AsynqDepthReader {
+	if queueName == "" {
+		queueName = "default"
+	}
+	return &AsynqDepthReader{inspector: asynq.NewInspectorFromRedisClient(rdb), queue: queueName}
+}
+
+// Depth reports the queue's task counts. A queue nothing was ever enqueued to is empty.
+func (r *AsynqDepthReader) Depth(ctx context.Context) (*Depth, error) {
+	tracer := otel.Tracer("real-staging-api/queue")
+	_, span := tracer.Start(ctx, "queue.Depth")
+	defer span.End()
+	span.SetAttributes(attribute.String("queue.name", r.queue))
+
+	queues, err := r.inspector.Queues()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "list queues")
+		return nil, fmt.Errorf("list queues: %w", err)
+	}
+	if !slices.Contains(queues, r.queue) {
+		return &Depth{}, nil
+	}
+
+	info, err := r.inspector.GetQueueInfo(r.queue)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "get queue info")
+		return nil, fmt.Errorf("get queue info: %w", err)
+	}
+	return &Depth{
+		Pending:   info.Pending,
+		Active:    info.Active,
+		Scheduled: info.Scheduled,
+		Retry:     info.Retry,
+	}, nil
+}
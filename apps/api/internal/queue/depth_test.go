@@ -0,0 +1,41 @@
+package queue
+
+import (
+	"context"
+	"testing"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/hibiken/asynq"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsynqDepthReader_Depth(t *testing.T) {
+	t.Run("success: unknown queue is empty", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		r := NewAsynqDepthReaderWithClient(rdb, "")
+
+		depth, err := r.Depth(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, &Depth{}, depth)
+	})
+
+	t.Run("success: pending tasks are counted", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		client := asynq.NewClient(asynq.RedisClientOpt{Addr: mr.Addr()})
+		t.Cleanup(func() { _ = client.Close() })
+		for range 2 {
+			_, err := client.Enqueue(asynq.NewTask(TaskTypeStageRun, []byte(`{}`)))
+			require.NoError(t, err)
+		}
+
+		rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		r := NewAsynqDepthReaderWithClient(rdb, "default")
+
+		depth, err := r.Depth(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 2, depth.Pending)
+	})
+}
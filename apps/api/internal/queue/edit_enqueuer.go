@@ -0,0 +1,145 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// TaskTypeEditErase is the queue task type for erasing an object from an image.
+const TaskTypeEditErase = "edit:erase"
+
+// EditPayload is the contract for a quick-edit task payload.
+type EditPayload struct {
+	EditID  string `json:"edit_id"`
+	ImageID string `json:"image_id"`
+	// Base is which version of the image is edited: "original" or "staged".
+	Base string `json:"base"`
+	// BaseURL is the s3:// URL of that version.
+	BaseURL string `json:"base_url"`
+	// MaskURL is the s3:// URL of the mask; white pixels mark the area to erase.
+	MaskURL string `json:"mask_url"`
+	Sandbox bool   `json:"sandbox,omitempty"`
+}
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out edit_enqueuer_mock.go . EditEnqueuer
+
+// EditEnqueuer enqueues quick edits on their own queue, which workers drain ahead of
+// staging jobs so an edit comes back within seconds.
+type EditEnqueuer interface {
+	// EnqueueEdit enqueues a task of taskType with the given payload, using the edit ID
+	// as the task ID. Returns the task ID assigned by the queue backend.
+	EnqueueEdit(ctx context.Context, taskType string, payload EditPayload) (string, error)
+}
+
+// AsynqEditEnqueuer implements EditEnqueuer using Redis + asynq.
+type AsynqEditEnqueuer struct {
+	client       *asynq.Client
+	queue        string
+	sandboxQueue string
+	keys         *Keyring
+}
+
+// NewAsynqEditEnqueuerFromConfig creates an edit enqueuer from the Redis and job settings.
+// - redis.addr (REDIS_ADDR): required (e.g., "localhost:6379")
+// - job.edit_queue_name (JOB_EDIT_QUEUE_NAME): optional (defaults to "edits")
+// - sandbox_tenant.queue_name (SANDBOX_TENANT_QUEUE_NAME): where sandbox requests' tasks go
+// - payload_encryption: optional; seal payloads when an active key is set
+func NewAsynqEditEnqueuerFromConfig(cfg *config.Config) (*AsynqEditEnqueuer, error) {
+	addr, err := redisAddr(cfg)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := NewKeyring(cfg.PayloadEncryption)
+	if err != nil {
+		return nil, err
+	}
+	q := cfg.Job.EditQueueName
+	if q == "" {
+		q = "edits"
+	}
+	return &AsynqEditEnqueuer{
+		client:       asynq.NewClient(asynq.RedisClientOpt{Addr: addr}),
+		queue:        q,
+		sandboxQueue: sandboxQueueName(cfg),
+		keys:         keys,
+	}, nil
+}
+
+// EnqueueEdit enqueues an edit task. Edits are not retried: a failed edit is reported to
+// the user, who can draw the mask again, rather than arriving late.
+func (e *AsynqEditEnqueuer) EnqueueEdit(ctx context.Context, taskType string, payload EditPayload) (string, error) {
+	tracer := otel.Tracer("real-staging-api/queue")
+	ctx, span := tracer.Start(ctx, "queue.EnqueueEdit")
+	defer span.End()
+	queue := tenantQueue(ctx, e.queue, e.sandboxQueue)
+	span.SetAttributes(
+		attribute.String("queue.task_type", taskType),
+		attribute.String("queue.name", queue),
+		attribute.String("image.id", payload.ImageID),
+		attribute.String("edit.id", payload.EditID),
+	)
+
+	log := logging.NewDefaultLogger()
+
+	if payload.EditID == "" || payload.ImageID == "" || payload.BaseURL == "" || payload.MaskURL == "" {
+		err := errors.New("payload edit_id, image_id, base_url and mask_url are required")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		log.Error(ctx, "enqueue validation failed", "task_type", taskType, "edit_id", payload.EditID, "error", err)
+		return "", err
+	}
+
+	// Sandbox tenant edits never reach the production model
+	if storage.SandboxFrom(ctx) {
+		payload.Sandbox = true
+	}
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "marshal payload")
+		return "", fmt.Errorf("marshal payload: %w", err)
+	}
+	b, err = e.keys.Seal(b)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "seal payload")
+		return "", fmt.Errorf("seal payload: %w", err)
+	}
+
+	info, err := e.client.EnqueueContext(ctx, asynq.NewTask(taskType, b),
+		asynq.Queue(queue), asynq.MaxRetry(0), asynq.TaskID(payload.EditID))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "enqueue error")
+		log.Error(ctx, "enqueue failed", "task_type", taskType, "edit_id", payload.EditID, "queue", queue, "error", err)
+		return "", fmt.Errorf("enqueue %s: %w", taskType, err)
+	}
+	span.SetAttributes(attribute.String("queue.id", info.ID))
+	log.Info(ctx, "enqueued edit", "task_type", taskType, "edit_id", payload.EditID, "queue", queue)
+	return info.ID, nil
+}
+
+// Close releases the underlying asynq client resources.
+func (e *AsynqEditEnqueuer) Close() error {
+	return e.client.Close()
+}
+
+// NoopEditEnqueuer is a drop-in EditEnqueuer that does nothing (useful for tests).
+type NoopEditEnqueuer struct{}
+
+// EnqueueEdit implements EditEnqueuer by returning a static ID without side effects.
+func (NoopEditEnqueuer) EnqueueEdit(_ context.Context, _ string, _ EditPayload) (string, error) {
+	return "noop", nil
+}
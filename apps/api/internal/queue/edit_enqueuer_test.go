@@ -0,0 +1,85 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+func TestAsynqEditEnqueuer_EnqueueEdit(t *testing.T) {
+	payload := EditPayload{
+		EditID:  "2e1aa86e-0f27-4f0f-9e57-0d7b53b4d9b9",
+		ImageID: "img-1",
+		Base:    "staged",
+		BaseURL: "s3://bucket/staged/img-1/img-1-staged.jpg",
+		MaskURL: "s3://bucket/uploads/user-1/mask.png",
+	}
+
+	testCases := []struct {
+		name        string
+		ctx         context.Context
+		payload     EditPayload
+		wantQueue   string
+		wantSandbox bool
+		wantErr     string
+	}{
+		{name: "success: enqueued on the edit queue without retries", ctx: context.Background(), payload: payload, wantQueue: "edits"},
+		{
+			name:        "success: sandbox edit enqueued on the sandbox queue",
+			ctx:         storage.WithSandbox(context.Background()),
+			payload:     payload,
+			wantQueue:   "sandbox",
+			wantSandbox: true,
+		},
+		{
+			name:    "fail: mask URL missing",
+			ctx:     context.Background(),
+			payload: EditPayload{EditID: payload.EditID, ImageID: "img-1", BaseURL: payload.BaseURL},
+			wantErr: "mask_url are required",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mr := miniredis.RunT(t)
+			e, err := NewAsynqEditEnqueuerFromConfig(&config.Config{Redis: config.Redis{Addr: mr.Addr()}})
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = e.Close() })
+
+			id, err := e.EnqueueEdit(tc.ctx, TaskTypeEditErase, tc.payload)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, payload.EditID, id)
+
+			inspector := asynq.NewInspector(asynq.RedisClientOpt{Addr: mr.Addr()})
+			t.Cleanup(func() { _ = inspector.Close() })
+			info, err := inspector.GetTaskInfo(tc.wantQueue, id)
+			require.NoError(t, err)
+			assert.Equal(t, TaskTypeEditErase, info.Type)
+			assert.Equal(t, 0, info.MaxRetry)
+
+			var got EditPayload
+			require.NoError(t, json.Unmarshal(info.Payload, &got))
+			want := payload
+			want.Sandbox = tc.wantSandbox
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestNoopEditEnqueuer(t *testing.T) {
+	id, err := NoopEditEnqueuer{}.EnqueueEdit(context.Background(), TaskTypeEditErase, EditPayload{})
+	require.NoError(t, err)
+	assert.Equal(t, "noop", id)
+}
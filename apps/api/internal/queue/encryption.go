@@ -0,0 +1,113 @@
+package queue
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+// PayloadCipher names the algorithm sealed payloads are encrypted with.
+const PayloadCipher = "aes-256-gcm"
+
+// SealedPayload is the envelope a sealed task payload is stored as. It is itself JSON,
+// so sealed payloads still pass the worker's batch aggregation checks. The worker keeps
+// a copy of this contract.
+type SealedPayload struct {
+	Enc        string `json:"enc"`
+	KeyID      string `json:"kid"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Keyring seals task payloads with its active key and opens payloads sealed with any of
+// its keys, so keys can be rotated while older payloads are still queued. A nil Keyring
+// leaves payloads in plaintext.
+type Keyring struct {
+	active string
+	aeads  map[string]cipher.AEAD
+}
+
+// NewKeyring builds a keyring from the payload encryption config. It returns nil when no
+// keys are configured.
+func NewKeyring(cfg config.PayloadEncryption) (*Keyring, error) {
+	if len(cfg.Keys) == 0 {
+		if cfg.ActiveKey != "" {
+			return nil, fmt.Errorf("payload encryption key %q is not configured", cfg.ActiveKey)
+		}
+		return nil, nil
+	}
+	k := &Keyring{active: cfg.ActiveKey, aeads: make(map[string]cipher.AEAD, len(cfg.Keys))}
+	for id, encoded := range cfg.Keys {
+		raw, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("decode payload encryption key %q: %w", id, err)
+		}
+		if len(raw) != 32 {
+			return nil, fmt.Errorf("payload encryption key %q must be 32 bytes, got %d", id, len(raw))
+		}
+		block, err := aes.NewCipher(raw)
+		if err != nil {
+			return nil, fmt.Errorf("payload encryption key %q: %w", id, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("payload encryption key %q: %w", id, err)
+		}
+		k.aeads[id] = aead
+	}
+	if k.active != "" && k.aeads[k.active] == nil {
+		return nil, fmt.Errorf("payload encryption key %q is not configured", k.active)
+	}
+	return k, nil
+}
+
+// Seal encrypts payload with the active key. Without an active key it returns payload as is.
+func (k *Keyring) Seal(payload []byte) ([]byte, error) {
+	if k == nil || k.active == "" {
+		return payload, nil
+	}
+	aead := k.aeads[k.active]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	return json.Marshal(SealedPayload{
+		Enc:        PayloadCipher,
+		KeyID:      k.active,
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, payload, []byte(k.active)),
+	})
+}
+
+// Open decrypts a sealed payload. Plaintext payloads, such as those enqueued before
+// encryption was enabled, are returned as is.
+func (k *Keyring) Open(payload []byte) ([]byte, error) {
+	var sealed SealedPayload
+	if err := json.Unmarshal(payload, &sealed); err != nil || sealed.Enc == "" {
+		return payload, nil
+	}
+	if sealed.Enc != PayloadCipher {
+		return nil, fmt.Errorf("unsupported payload cipher %q", sealed.Enc)
+	}
+	if k == nil {
+		return nil, errors.New("payload is encrypted but no payload encryption keys are configured")
+	}
+	aead, ok := k.aeads[sealed.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("payload encryption key %q is not configured", sealed.KeyID)
+	}
+	if len(sealed.Nonce) != aead.NonceSize() {
+		return nil, errors.New("invalid payload nonce")
+	}
+	plaintext, err := aead.Open(nil, sealed.Nonce, sealed.Ciphertext, []byte(sealed.KeyID))
+	if err != nil {
+		return nil, fmt.Errorf("decrypt payload: %w", err)
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,210 @@
+package queue
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, 32))
+}
+
+func TestNewKeyring(t *testing.T) {
+	testCases := []struct {
+		name    string
+		cfg     config.PayloadEncryption
+		wantNil bool
+		wantErr string
+	}{
+		{name: "success: no keys disables encryption", wantNil: true},
+		{
+			name: "success: keys without an active key",
+			cfg:  config.PayloadEncryption{Keys: map[string]string{"k1": testKey(1)}},
+		},
+		{
+			name: "success: active key",
+			cfg:  config.PayloadEncryption{ActiveKey: "k1", Keys: map[string]string{"k1": testKey(1)}},
+		},
+		{
+			name:    "fail: active key without keys",
+			cfg:     config.PayloadEncryption{ActiveKey: "k1"},
+			wantErr: `payload encryption key "k1" is not configured`,
+		},
+		{
+			name:    "fail: active key not in keys",
+			cfg:     config.PayloadEncryption{ActiveKey: "k2", Keys: map[string]string{"k1": testKey(1)}},
+			wantErr: `payload encryption key "k2" is not configured`,
+		},
+		{
+			name:    "fail: key is not base64",
+			cfg:     config.PayloadEncryption{Keys: map[string]string{"k1": "not base64!"}},
+			wantErr: `decode payload encryption key "k1"`,
+		},
+		{
+			name: "fail: key is not 32 bytes",
+			cfg: config.PayloadEncryption{
+				Keys: map[string]string{"k1": base64.StdEncoding.EncodeToString([]byte("short"))},
+			},
+			wantErr: `payload encryption key "k1" must be 32 bytes, got 5`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k, err := NewKeyring(tc.cfg)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantNil, k == nil)
+		})
+	}
+}
+
+func TestKeyring_SealOpen(t *testing.T) {
+	plaintext := []byte(`{"image_id":"img-1","original_url":"s3://bucket/uploads/user-1/img.jpg"}`)
+
+	t.Run("success: round trip hides the plaintext", func(t *testing.T) {
+		k, err := NewKeyring(config.PayloadEncryption{ActiveKey: "k1", Keys: map[string]string{"k1": testKey(1)}})
+		require.NoError(t, err)
+
+		sealed, err := k.Seal(plaintext)
+		require.NoError(t, err)
+		assert.NotContains(t, string(sealed), "s3://bucket")
+		assert.True(t, json.Valid(sealed))
+
+		var env SealedPayload
+		require.NoError(t, json.Unmarshal(sealed, &env))
+		assert.Equal(t, PayloadCipher, env.Enc)
+		assert.Equal(t, "k1", env.KeyID)
+
+		opened, err := k.Open(sealed)
+		require.NoError(t, err)
+		assert.Equal(t, plaintext, opened)
+	})
+
+	t.Run("success: rotated keyring opens payloads sealed with a retired key", func(t *testing.T) {
+		old, err := NewKeyring(config.PayloadEncryption{ActiveKey: "k1", Keys: map[string]string{"k1": testKey(1)}})
+		require.NoError(t, err)
+		sealed, err := old.Seal(plaintext)
+		require.NoError(t, err)
+
+		rotated, err := NewKeyring(config.PayloadEncryption{
+			ActiveKey: "k2", Keys: map[string]string{"k1": testKey(1), "k2": testKey(2)},
+		})
+		require.NoError(t, err)
+		opened, err := rotated.Open(sealed)
+		require.NoError(t, err)
+		assert.Equal(t, plaintext, opened)
+
+		resealed, err := rotated.Seal(plaintext)
+		require.NoError(t, err)
+		var env SealedPayload
+		require.NoError(t, json.Unmarshal(resealed, &env))
+		assert.Equal(t, "k2", env.KeyID)
+	})
+
+	t.Run("success: nil keyring and no active key leave payloads in plaintext", func(t *testing.T) {
+		var nilKeys *Keyring
+		out, err := nilKeys.Seal(plaintext)
+		require.NoError(t, err)
+		assert.Equal(t, plaintext, out)
+
+		decryptOnly, err := NewKeyring(config.PayloadEncryption{Keys: map[string]string{"k1": testKey(1)}})
+		require.NoError(t, err)
+		out, err = decryptOnly.Seal(plaintext)
+		require.NoError(t, err)
+		assert.Equal(t, plaintext, out)
+	})
+
+	t.Run("success: plaintext payloads open as is", func(t *testing.T) {
+		var nilKeys *Keyring
+		out, err := nilKeys.Open(plaintext)
+		require.NoError(t, err)
+		assert.Equal(t, plaintext, out)
+	})
+
+	t.Run("fail: sealed payload without keys", func(t *testing.T) {
+		k, err := NewKeyring(config.PayloadEncryption{ActiveKey: "k1", Keys: map[string]string{"k1": testKey(1)}})
+		require.NoError(t, err)
+		sealed, err := k.Seal(plaintext)
+		require.NoError(t, err)
+
+		var nilKeys *Keyring
+		_, err = nilKeys.Open(sealed)
+		assert.ErrorContains(t, err, "no payload encryption keys are configured")
+	})
+
+	t.Run("fail: unknown key id", func(t *testing.T) {
+		k, err := NewKeyring(config.PayloadEncryption{ActiveKey: "k1", Keys: map[string]string{"k1": testKey(1)}})
+		require.NoError(t, err)
+		sealed, err := k.Seal(plaintext)
+		require.NoError(t, err)
+
+		other, err := NewKeyring(config.PayloadEncryption{Keys: map[string]string{"k2": testKey(2)}})
+		require.NoError(t, err)
+		_, err = other.Open(sealed)
+		assert.ErrorContains(t, err, `payload encryption key "k1" is not configured`)
+	})
+
+	t.Run("fail: tampered ciphertext", func(t *testing.T) {
+		k, err := NewKeyring(config.PayloadEncryption{ActiveKey: "k1", Keys: map[string]string{"k1": testKey(1)}})
+		require.NoError(t, err)
+		sealed, err := k.Seal(plaintext)
+		require.NoError(t, err)
+
+		var env SealedPayload
+		require.NoError(t, json.Unmarshal(sealed, &env))
+		env.Ciphertext[0] ^= 0xff
+		tampered, err := json.Marshal(env)
+		require.NoError(t, err)
+		_, err = k.Open(tampered)
+		assert.ErrorContains(t, err, "decrypt payload")
+	})
+
+	t.Run("fail: unsupported cipher", func(t *testing.T) {
+		k, err := NewKeyring(config.PayloadEncryption{Keys: map[string]string{"k1": testKey(1)}})
+		require.NoError(t, err)
+		_, err = k.Open([]byte(`{"enc":"rot13","kid":"k1"}`))
+		assert.ErrorContains(t, err, `unsupported payload cipher "rot13"`)
+	})
+}
+
+func TestAsynqEnqueuer_SealsPayload(t *testing.T) {
+	mr := miniredis.RunT(t)
+	keys, err := NewKeyring(config.PayloadEncryption{ActiveKey: "k1", Keys: map[string]string{"k1": testKey(1)}})
+	require.NoError(t, err)
+	client := asynq.NewClient(asynq.RedisClientOpt{Addr: mr.Addr()})
+	e := &AsynqEnqueuer{client: client, defaultQueue: "default", keys: keys}
+	t.Cleanup(func() { _ = e.Close() })
+
+	id, err := e.EnqueueStageRun(context.Background(), StageRunPayload{
+		ImageID: "img-1", OriginalURL: "s3://bucket/uploads/user-1/img.jpg",
+	}, nil)
+	require.NoError(t, err)
+
+	inspector := asynq.NewInspector(asynq.RedisClientOpt{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = inspector.Close() })
+	info, err := inspector.GetTaskInfo("default", id)
+	require.NoError(t, err)
+	assert.NotContains(t, string(info.Payload), "s3://bucket")
+
+	opened, err := keys.Open(info.Payload)
+	require.NoError(t, err)
+	var payload StageRunPayload
+	require.NoError(t, json.Unmarshal(opened, &payload))
+	assert.Equal(t, "img-1", payload.ImageID)
+	assert.Equal(t, "s3://bucket/uploads/user-1/img.jpg", payload.OriginalURL)
+}
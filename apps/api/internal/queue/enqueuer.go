@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/hibiken/asynq"
@@ -15,6 +14,7 @@ import (
 
 	"github.com/real-staging-ai/api/internal/config"
 	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/storage"
 )
 
 // TaskTypeStageRun is the queue task type for running the staging pipeline.
@@ -24,11 +24,41 @@ const TaskTypeStageRun = "stage:run"
 //
 // The fields align with the worker's processor expectations for Phase 1.
 type StageRunPayload struct {
-	ImageID     string  `json:"image_id"`
+	ImageID string `json:"image_id"`
+	// ProjectID is the image's project, whose event stream also carries its status changes.
+	ProjectID   string  `json:"project_id,omitempty"`
 	OriginalURL string  `json:"original_url"`
 	RoomType    *string `json:"room_type,omitempty"`
 	Style       *string `json:"style,omitempty"`
 	Seed        *int64  `json:"seed,omitempty"`
+	Sandbox     bool    `json:"sandbox,omitempty"`
+	// Catalog is a copy of the furniture catalog picked for the image, if any.
+	Catalog *CatalogPayload `json:"catalog,omitempty"`
+	// ReferenceImageURL is the s3:// URL of the user's inspiration photo, if any.
+	ReferenceImageURL string `json:"reference_image_url,omitempty"`
+	// BracketURLs are other exposures of the original's shot to merge with it, if any.
+	BracketURLs []string `json:"bracket_urls,omitempty"`
+	// CorrectPerspective straightens converging and leaning verticals before staging.
+	CorrectPerspective bool `json:"correct_perspective,omitempty"`
+	// Annotations are the room measurements given with the image, if any.
+	Annotations *Annotations `json:"annotations,omitempty"`
+	// Instructions are extra prompt instructions from the picked preset, if any.
+	Instructions string `json:"instructions,omitempty"`
+}
+
+// Annotations are known room measurements, in meters, that staged furniture is sized to.
+type Annotations struct {
+	WallLengthM    *float64 `json:"wall_length_m,omitempty"`
+	CeilingHeightM *float64 `json:"ceiling_height_m,omitempty"`
+}
+
+// CatalogPayload is the furniture catalog a stage:run task stages with. It is copied into
+// the payload so later edits to the catalog do not change queued jobs.
+type CatalogPayload struct {
+	ID                 string   `json:"id"`
+	Name               string   `json:"name"`
+	PromptFragment     string   `json:"prompt_fragment"`
+	ReferenceImageURLs []string `json:"reference_image_urls,omitempty"`
 }
 
 // EnqueueOpts controls per-task enqueue behavior (queue, retries, schedule, etc.).
@@ -51,6 +81,19 @@ type EnqueueOpts struct {
 	// Deadline sets the absolute deadline for the task.
 	// Zero time means "not set".
 	Deadline time.Time
+
+	// TaskID assigns a caller-chosen ID to the task so it can be located later
+	// (e.g., to cancel it). Empty means "not set" (the backend generates one).
+	TaskID string
+
+	// Group collects the task with others in the same group for the worker's batching
+	// window, after which they run as one batch job. Empty means "not set".
+	Group string
+}
+
+// BatchGroup returns the group that batches stage:run tasks for a project.
+func BatchGroup(projectID string) string {
+	return "project:" + projectID
 }
 
 // Enqueuer defines the interface for enqueuing background jobs from the API.
@@ -60,39 +103,50 @@ type Enqueuer interface {
 	EnqueueStageRun(ctx context.Context, payload StageRunPayload, opts *EnqueueOpts) (string, error)
 }
 
+// redisAddr returns the configured Redis address.
+func redisAddr(cfg *config.Config) (string, error) {
+	if cfg == nil {
+		return "", errors.New("config is nil")
+	}
+	if cfg.Redis.Addr == "" {
+		return "", errors.New(
+			"redis address is not configured. " +
+				"Please set REDIS_ADDR environment variable or configure in config file")
+	}
+	return cfg.Redis.Addr, nil
+}
+
 // AsynqEnqueuer implements Enqueuer using Redis + asynq.
 type AsynqEnqueuer struct {
 	client       *asynq.Client
 	defaultQueue string
+	sandboxQueue string
+	keys         *Keyring
 }
 
-// NewAsynqEnqueuerFromEnv creates an enqueuer using environment variables.
-// - REDIS_ADDR: required (e.g., "localhost:6379")
-// - JOB_QUEUE_NAME: optional (defaults to "default")
-func NewAsynqEnqueuerFromEnv(cfg *config.Config) (*AsynqEnqueuer, error) {
-	if cfg == nil {
-		return nil, errors.New("config is nil")
-	}
-
-	addr := os.Getenv("REDIS_ADDR")
-	if addr == "" {
-		addr = cfg.Redis.Addr
-	}
-	if addr == "" {
-		return nil, errors.New(
-			"redis address is not configured. " +
-				"Please set REDIS_ADDR environment variable or configure in config file")
+// NewAsynqEnqueuerFromConfig creates an enqueuer from the Redis and job settings.
+// - redis.addr (REDIS_ADDR): required (e.g., "localhost:6379")
+// - job.queue_name (JOB_QUEUE_NAME): optional (defaults to "default")
+// - sandbox_tenant.queue_name (SANDBOX_TENANT_QUEUE_NAME): where sandbox requests' tasks go
+// - payload_encryption: optional; seal payloads when an active key is set
+func NewAsynqEnqueuerFromConfig(cfg *config.Config) (*AsynqEnqueuer, error) {
+	addr, err := redisAddr(cfg)
+	if err != nil {
+		return nil, err
 	}
+	q := cfg.Job.QueueName
 
-	q := os.Getenv("JOB_QUEUE_NAME")
-	if q == "" {
-		q = cfg.Job.QueueName
+	keys, err := NewKeyring(cfg.PayloadEncryption)
+	if err != nil {
+		return nil, err
 	}
 
 	client := asynq.NewClient(asynq.RedisClientOpt{Addr: addr})
 	return &AsynqEnqueuer{
 		client:       client,
 		defaultQueue: q,
+		sandboxQueue: sandboxQueueName(cfg),
+		keys:         keys,
 	}, nil
 }
 
@@ -127,6 +181,12 @@ func (e *AsynqEnqueuer) EnqueueStageRun(
 		attribute.String("image.id", payload.ImageID),
 	)
 
+	// Sandbox tenant images never reach the production model
+	sandbox := storage.SandboxFrom(ctx)
+	if sandbox {
+		payload.Sandbox = true
+	}
+
 	b, err := json.Marshal(payload)
 	if err != nil {
 		span.RecordError(err)
@@ -134,6 +194,13 @@ func (e *AsynqEnqueuer) EnqueueStageRun(
 		log.Error(ctx, "marshal payload failed", "task_type", TaskTypeStageRun, "image_id", payload.ImageID, "error", err)
 		return "", fmt.Errorf("marshal payload: %w", err)
 	}
+	b, err = e.keys.Seal(b)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "seal payload")
+		log.Error(ctx, "seal payload failed", "task_type", TaskTypeStageRun, "image_id", payload.ImageID, "error", err)
+		return "", fmt.Errorf("seal payload: %w", err)
+	}
 
 	task := asynq.NewTask(TaskTypeStageRun, b)
 
@@ -157,6 +224,17 @@ func (e *AsynqEnqueuer) EnqueueStageRun(
 		if !opts.Deadline.IsZero() {
 			asynqOpts = append(asynqOpts, asynq.Deadline(opts.Deadline))
 		}
+		if opts.TaskID != "" {
+			asynqOpts = append(asynqOpts, asynq.TaskID(opts.TaskID))
+		}
+		if opts.Group != "" {
+			asynqOpts = append(asynqOpts, asynq.Group(opts.Group))
+		}
+	}
+	if sandbox {
+		// Only the sandbox tenant worker, which acts on the sandbox schema, may run the task
+		selectedQueue = e.sandboxQueue
+		asynqOpts[0] = asynq.Queue(selectedQueue)
 	}
 
 	log.Info(ctx, "enqueue attempt", "task_type", TaskTypeStageRun, "image_id", payload.ImageID, "queue", selectedQueue)
@@ -0,0 +1,63 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+func TestNewAsynqEnqueuerFromConfig(t *testing.T) {
+	t.Run("success: uses the configured redis address and queue", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		cfg := &config.Config{Redis: config.Redis{Addr: mr.Addr()}, Job: config.Job{QueueName: "staging"}}
+
+		e, err := NewAsynqEnqueuerFromConfig(cfg)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = e.Close() })
+		assert.Equal(t, "staging", e.defaultQueue)
+	})
+
+	t.Run("fail: redis address not configured", func(t *testing.T) {
+		t.Setenv("REDIS_ADDR", "localhost:6379")
+		_, err := NewAsynqEnqueuerFromConfig(&config.Config{})
+		assert.ErrorContains(t, err, "redis address is not configured")
+	})
+
+	t.Run("fail: nil config", func(t *testing.T) {
+		_, err := NewAsynqEnqueuerFromConfig(nil)
+		assert.ErrorContains(t, err, "config is nil")
+	})
+}
+
+func TestAsynqEnqueuer_EnqueueStageRun_Sandbox(t *testing.T) {
+	mr := miniredis.RunT(t)
+	cfg := &config.Config{
+		Redis:         config.Redis{Addr: mr.Addr()},
+		Job:           config.Job{QueueName: "staging"},
+		SandboxTenant: config.SandboxTenant{QueueName: "partner-sandbox"},
+	}
+	e, err := NewAsynqEnqueuerFromConfig(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = e.Close() })
+
+	ctx := storage.WithSandbox(context.Background())
+	id, err := e.EnqueueStageRun(ctx, StageRunPayload{ImageID: "img-1", OriginalURL: "s3://bucket/a.jpg"},
+		&EnqueueOpts{Queue: "critical", Retry: -1})
+	require.NoError(t, err)
+
+	inspector := asynq.NewInspector(asynq.RedisClientOpt{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = inspector.Close() })
+	info, err := inspector.GetTaskInfo("partner-sandbox", id)
+	require.NoError(t, err)
+	var got StageRunPayload
+	require.NoError(t, json.Unmarshal(info.Payload, &got))
+	assert.True(t, got.Sandbox)
+}
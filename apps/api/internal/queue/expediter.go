@@ -0,0 +1,137 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	redis "github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out expediter_mock.go . Expediter
+
+// Expediter moves pending stage:run tasks to the critical queue, which workers
+// drain before the default queue.
+type Expediter interface {
+	// ExpediteStageRun moves a pending task to the critical queue. It reports false
+	// when the task is no longer pending (already picked up or finished).
+	ExpediteStageRun(ctx context.Context, taskID string) (bool, error)
+}
+
+// AsynqExpediter implements Expediter using the asynq inspector and client.
+type AsynqExpediter struct {
+	inspector     *asynq.Inspector
+	client        *asynq.Client
+	rdb           *redis.Client
+	defaultQueue  string
+	criticalQueue string
+}
+
+// NewAsynqExpediterFromConfig creates an expediter using the same Redis settings as the enqueuer.
+// - redis.addr (REDIS_ADDR): required (e.g., "localhost:6379")
+// - job.queue_name (JOB_QUEUE_NAME): optional (defaults to "default")
+// - job.critical_queue_name (JOB_CRITICAL_QUEUE_NAME): optional (defaults to "critical")
+func NewAsynqExpediterFromConfig(cfg *config.Config) (*AsynqExpediter, error) {
+	addr, err := redisAddr(cfg)
+	if err != nil {
+		return nil, err
+	}
+	rdb := redis.NewClient(&redis.Options{Addr: addr})
+	return NewAsynqExpediterWithClient(rdb, cfg.Job.QueueName, cfg.Job.CriticalQueueName), nil
+}
+
+// NewAsynqExpediterWithClient constructs an expediter with a provided redis client.
+func NewAsynqExpediterWithClient(rdb *redis.Client, queueName, criticalQueueName string) *AsynqExpediter {
+	if queueName == "" {
+		queueName = "default"
+	}
+	if criticalQueueName == "" {
+		criticalQueueName = "critical"
+	}
+	return &AsynqExpediter{
+		inspector:     asynq.NewInspectorFromRedisClient(rdb),
+		client:        asynq.NewClientFromRedisClient(rdb),
+		rdb:           rdb,
+		defaultQueue:  queueName,
+		criticalQueue: criticalQueueName,
+	}
+}
+
+// ExpediteStageRun deletes a pending, scheduled, retrying, or batching task from the
+// default queue and enqueues it again, under the same ID, on the critical queue.
+// Asynq cannot move a task between queues, so if the second enqueue fails the task is
+// put back on the default queue rather than lost.
+func (e *AsynqExpediter) ExpediteStageRun(ctx context.Context, taskID string) (bool, error) {
+	tracer := otel.Tracer("real-staging-api/queue")
+	ctx, span := tracer.Start(ctx, "queue.ExpediteStageRun")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("queue.id", taskID),
+		attribute.String("queue.name", e.defaultQueue),
+		attribute.String("queue.critical_name", e.criticalQueue),
+	)
+
+	info, err := e.inspector.GetTaskInfo(e.defaultQueue, taskID)
+	if err != nil {
+		if errors.Is(err, asynq.ErrTaskNotFound) || errors.Is(err, asynq.ErrQueueNotFound) {
+			return false, nil
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "get task info")
+		return false, fmt.Errorf("get task info: %w", err)
+	}
+	if info.State == asynq.TaskStateActive || info.State == asynq.TaskStateCompleted {
+		return false, nil
+	}
+
+	if err := e.inspector.DeleteTask(e.defaultQueue, taskID); err != nil {
+		if errors.Is(err, asynq.ErrTaskNotFound) || errors.Is(err, asynq.ErrQueueNotFound) {
+			return false, nil
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "delete task")
+		return false, fmt.Errorf("delete task: %w", err)
+	}
+
+	task := asynq.NewTask(info.Type, info.Payload)
+	opts := []asynq.Option{asynq.TaskID(taskID), asynq.MaxRetry(info.MaxRetry)}
+	if info.Timeout > 0 {
+		opts = append(opts, asynq.Timeout(info.Timeout))
+	}
+	if !info.Deadline.IsZero() {
+		opts = append(opts, asynq.Deadline(info.Deadline))
+	}
+
+	inQueue := func(queueName string) []asynq.Option {
+		return append([]asynq.Option{asynq.Queue(queueName)}, opts...)
+	}
+
+	if _, err := e.client.EnqueueContext(ctx, task, inQueue(e.criticalQueue)...); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "enqueue critical")
+		if _, restoreErr := e.client.EnqueueContext(ctx, task, inQueue(e.defaultQueue)...); restoreErr != nil {
+			return false, fmt.Errorf("enqueue critical: %w (restore failed: %v)", err, restoreErr)
+		}
+		return false, fmt.Errorf("enqueue critical: %w", err)
+	}
+	return true, nil
+}
+
+// Close releases the underlying Redis resources.
+func (e *AsynqExpediter) Close() error {
+	return e.rdb.Close()
+}
+
+// NoopExpediter is a drop-in Expediter that does nothing (useful for tests).
+type NoopExpediter struct{}
+
+// ExpediteStageRun implements Expediter by reporting that no task was moved.
+func (NoopExpediter) ExpediteStageRun(_ context.Context, _ string) (bool, error) {
+	return false, nil
+}
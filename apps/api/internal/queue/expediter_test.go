@@ -0,0 +1,71 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/hibiken/asynq"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsynqExpediter_ExpediteStageRun(t *testing.T) {
+	t.Run("success: unknown task is reported as not moved", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		e := NewAsynqExpediterWithClient(rdb, "", "")
+		t.Cleanup(func() { _ = e.Close() })
+
+		moved, err := e.ExpediteStageRun(context.Background(), "missing-task")
+		require.NoError(t, err)
+		assert.False(t, moved)
+	})
+
+	t.Run("success: pending task moves to the critical queue with its id and payload", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		client := asynq.NewClient(asynq.RedisClientOpt{Addr: mr.Addr()})
+		t.Cleanup(func() { _ = client.Close() })
+		_, err := client.Enqueue(asynq.NewTask(TaskTypeStageRun, []byte(`{"image_id":"img-1"}`)),
+			asynq.TaskID("job-1"), asynq.MaxRetry(7), asynq.Timeout(time.Minute))
+		require.NoError(t, err)
+
+		rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		e := NewAsynqExpediterWithClient(rdb, "default", "critical")
+		t.Cleanup(func() { _ = e.Close() })
+
+		moved, err := e.ExpediteStageRun(context.Background(), "job-1")
+		require.NoError(t, err)
+		assert.True(t, moved)
+
+		inspector := asynq.NewInspectorFromRedisClient(rdb)
+		_, err = inspector.GetTaskInfo("default", "job-1")
+		assert.ErrorIs(t, err, asynq.ErrTaskNotFound)
+		info, err := inspector.GetTaskInfo("critical", "job-1")
+		require.NoError(t, err)
+		assert.Equal(t, TaskTypeStageRun, info.Type)
+		assert.JSONEq(t, `{"image_id":"img-1"}`, string(info.Payload))
+		assert.Equal(t, 7, info.MaxRetry)
+		assert.Equal(t, time.Minute, info.Timeout)
+		assert.Equal(t, asynq.TaskStatePending, info.State)
+	})
+
+	t.Run("fail: redis unavailable", func(t *testing.T) {
+		rdb := redis.NewClient(&redis.Options{Addr: "127.0.0.1:6391", DialTimeout: 50 * time.Millisecond})
+		e := NewAsynqExpediterWithClient(rdb, "default", "critical")
+		t.Cleanup(func() { _ = e.Close() })
+
+		moved, err := e.ExpediteStageRun(context.Background(), "task-1")
+		assert.Error(t, err)
+		assert.False(t, moved)
+	})
+}
+
+func TestNoopExpediter(t *testing.T) {
+	var e Expediter = NoopExpediter{}
+	moved, err := e.ExpediteStageRun(context.Background(), "task-1")
+	assert.NoError(t, err)
+	assert.False(t, moved)
+}
@@ -0,0 +1,137 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// TaskTypeProjectExport is the queue task type for bundling a project's staged photos.
+const TaskTypeProjectExport = "projects:export"
+
+// exportMaxRetry is how often a failed export is retried before it is marked failed.
+const exportMaxRetry = 2
+
+// ExportPayload is the contract for a projects:export task payload.
+type ExportPayload struct {
+	ExportID  string `json:"export_id"`
+	ProjectID string `json:"project_id"`
+}
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out export_enqueuer_mock.go . ExportEnqueuer
+
+// ExportEnqueuer enqueues project exports.
+type ExportEnqueuer interface {
+	// EnqueueExport enqueues a projects:export task, using the export ID as the task ID.
+	// Returns the task ID assigned by the queue backend.
+	EnqueueExport(ctx context.Context, payload ExportPayload) (string, error)
+}
+
+// AsynqExportEnqueuer implements ExportEnqueuer using Redis + asynq.
+type AsynqExportEnqueuer struct {
+	client       *asynq.Client
+	queue        string
+	sandboxQueue string
+	keys         *Keyring
+}
+
+// NewAsynqExportEnqueuerFromConfig creates a project export enqueuer from the Redis and job
+// settings. Exports share the staging queue.
+// - redis.addr (REDIS_ADDR): required (e.g., "localhost:6379")
+// - job.queue_name (JOB_QUEUE_NAME): optional (defaults to "default")
+// - sandbox_tenant.queue_name (SANDBOX_TENANT_QUEUE_NAME): where sandbox requests' tasks go
+// - payload_encryption: optional; seal payloads when an active key is set
+func NewAsynqExportEnqueuerFromConfig(cfg *config.Config) (*AsynqExportEnqueuer, error) {
+	addr, err := redisAddr(cfg)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := NewKeyring(cfg.PayloadEncryption)
+	if err != nil {
+		return nil, err
+	}
+	q := cfg.Job.QueueName
+	if q == "" {
+		q = "default"
+	}
+	return &AsynqExportEnqueuer{
+		client:       asynq.NewClient(asynq.RedisClientOpt{Addr: addr}),
+		queue:        q,
+		sandboxQueue: sandboxQueueName(cfg),
+		keys:         keys,
+	}, nil
+}
+
+// EnqueueExport enqueues an export task. An export reads every staged photo of the project,
+// so a failed one is retried a couple of times rather than left to the user.
+func (e *AsynqExportEnqueuer) EnqueueExport(ctx context.Context, payload ExportPayload) (string, error) {
+	tracer := otel.Tracer("real-staging-api/queue")
+	ctx, span := tracer.Start(ctx, "queue.EnqueueExport")
+	defer span.End()
+	queue := tenantQueue(ctx, e.queue, e.sandboxQueue)
+	span.SetAttributes(
+		attribute.String("queue.task_type", TaskTypeRoomsGroup),
+		attribute.String("queue.name", queue),
+		attribute.String("project.id", payload.ProjectID),
+		attribute.String("export.id", payload.ExportID),
+	)
+
+	log := logging.NewDefaultLogger()
+
+	if payload.ExportID == "" || payload.ProjectID == "" {
+		err := errors.New("payload export_id and project_id are required")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		log.Error(ctx, "enqueue validation failed", "task_type", TaskTypeProjectExport, "export_id", payload.ExportID, "error", err)
+		return "", err
+	}
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "marshal payload")
+		return "", fmt.Errorf("marshal payload: %w", err)
+	}
+	b, err = e.keys.Seal(b)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "seal payload")
+		return "", fmt.Errorf("seal payload: %w", err)
+	}
+
+	info, err := e.client.EnqueueContext(ctx, asynq.NewTask(TaskTypeProjectExport, b),
+		asynq.Queue(queue), asynq.MaxRetry(exportMaxRetry), asynq.TaskID(payload.ExportID))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "enqueue error")
+		log.Error(ctx, "enqueue failed", "task_type", TaskTypeProjectExport, "export_id", payload.ExportID,
+			"queue", queue, "error", err)
+		return "", fmt.Errorf("enqueue %s: %w", TaskTypeProjectExport, err)
+	}
+	span.SetAttributes(attribute.String("queue.id", info.ID))
+	log.Info(ctx, "enqueued project export", "export_id", payload.ExportID, "project_id", payload.ProjectID,
+		"queue", queue)
+	return info.ID, nil
+}
+
+// Close releases the underlying asynq client resources.
+func (e *AsynqExportEnqueuer) Close() error {
+	return e.client.Close()
+}
+
+// NoopExportEnqueuer is a drop-in ExportEnqueuer that does nothing (useful for tests).
+type NoopExportEnqueuer struct{}
+
+// EnqueueExport implements ExportEnqueuer by returning a static ID without side effects.
+func (NoopExportEnqueuer) EnqueueExport(_ context.Context, _ ExportPayload) (string, error) {
+	return "noop", nil
+}
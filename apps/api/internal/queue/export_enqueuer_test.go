@@ -0,0 +1,62 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+func TestAsynqExportEnqueuer_EnqueueExport(t *testing.T) {
+	payload := ExportPayload{
+		ExportID:  "6f0c7f0e-8c1b-4a55-9a0e-2f4f1b8a7c3d",
+		ProjectID: "2b7d3f5a-1c4e-4d8f-9a6b-3e5c7d9f1a2b",
+	}
+
+	testCases := []struct {
+		name    string
+		payload ExportPayload
+		wantErr string
+	}{
+		{name: "success: enqueued on the staging queue with retries", payload: payload},
+		{
+			name:    "fail: project ID missing",
+			payload: ExportPayload{ExportID: payload.ExportID},
+			wantErr: "project_id are required",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mr := miniredis.RunT(t)
+			e, err := NewAsynqExportEnqueuerFromConfig(&config.Config{Redis: config.Redis{Addr: mr.Addr()}})
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = e.Close() })
+
+			id, err := e.EnqueueExport(context.Background(), tc.payload)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, payload.ExportID, id)
+
+			inspector := asynq.NewInspector(asynq.RedisClientOpt{Addr: mr.Addr()})
+			t.Cleanup(func() { _ = inspector.Close() })
+			info, err := inspector.GetTaskInfo("default", id)
+			require.NoError(t, err)
+			assert.Equal(t, TaskTypeProjectExport, info.Type)
+			assert.Equal(t, exportMaxRetry, info.MaxRetry)
+
+			var got ExportPayload
+			require.NoError(t, json.Unmarshal(info.Payload, &got))
+			assert.Equal(t, payload, got)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// TaskTypeRoomsGroup is the queue task type for grouping a project's images into rooms.
+const TaskTypeRoomsGroup = "rooms:group"
+
+// roomGroupMaxRetry is how often a failed grouping is retried before it is marked failed.
+const roomGroupMaxRetry = 2
+
+// RoomGroupPayload is the contract for a rooms:group task payload.
+type RoomGroupPayload struct {
+	GroupingID string `json:"grouping_id"`
+	ProjectID  string `json:"project_id"`
+}
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out room_group_enqueuer_mock.go . RoomGroupEnqueuer
+
+// RoomGroupEnqueuer enqueues room grouping analyses of projects.
+type RoomGroupEnqueuer interface {
+	// EnqueueRoomGroup enqueues a rooms:group task, using the grouping ID as the task ID.
+	// Returns the task ID assigned by the queue backend.
+	EnqueueRoomGroup(ctx context.Context, payload RoomGroupPayload) (string, error)
+}
+
+// AsynqRoomGroupEnqueuer implements RoomGroupEnqueuer using Redis + asynq.
+type AsynqRoomGroupEnqueuer struct {
+	client       *asynq.Client
+	queue        string
+	sandboxQueue string
+	keys         *Keyring
+}
+
+// NewAsynqRoomGroupEnqueuerFromConfig creates a room grouping enqueuer from the Redis and
+// job settings. Groupings share the staging queue.
+// - redis.addr (REDIS_ADDR): required (e.g., "localhost:6379")
+// - job.queue_name (JOB_QUEUE_NAME): optional (defaults to "default")
+// - sandbox_tenant.queue_name (SANDBOX_TENANT_QUEUE_NAME): where sandbox requests' tasks go
+// - payload_encryption: optional; seal payloads when an active key is set
+func NewAsynqRoomGroupEnqueuerFromConfig(cfg *config.Config) (*AsynqRoomGroupEnqueuer, error) {
+	addr, err := redisAddr(cfg)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := NewKeyring(cfg.PayloadEncryption)
+	if err != nil {
+		return nil, err
+	}
+	q := cfg.Job.QueueName
+	if q == "" {
+		q = "default"
+	}
+	return &AsynqRoomGroupEnqueuer{
+		client:       asynq.NewClient(asynq.RedisClientOpt{Addr: addr}),
+		queue:        q,
+		sandboxQueue: sandboxQueueName(cfg),
+		keys:         keys,
+	}, nil
+}
+
+// EnqueueRoomGroup enqueues a grouping task. A grouping reads every image of the project,
+// so a failed one is retried a couple of times rather than left to the user.
+func (e *AsynqRoomGroupEnqueuer) EnqueueRoomGroup(ctx context.Context, payload RoomGroupPayload) (string, error) {
+	tracer := otel.Tracer("real-staging-api/queue")
+	ctx, span := tracer.Start(ctx, "queue.EnqueueRoomGroup")
+	defer span.End()
+	queue := tenantQueue(ctx, e.queue, e.sandboxQueue)
+	span.SetAttributes(
+		attribute.String("queue.task_type", TaskTypeRoomsGroup),
+		attribute.String("queue.name", queue),
+		attribute.String("project.id", payload.ProjectID),
+		attribute.String("room_grouping.id", payload.GroupingID),
+	)
+
+	log := logging.NewDefaultLogger()
+
+	if payload.GroupingID == "" || payload.ProjectID == "" {
+		err := errors.New("payload grouping_id and project_id are required")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		log.Error(ctx, "enqueue validation failed", "task_type", TaskTypeRoomsGroup, "grouping_id", payload.GroupingID, "error", err)
+		return "", err
+	}
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "marshal payload")
+		return "", fmt.Errorf("marshal payload: %w", err)
+	}
+	b, err = e.keys.Seal(b)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "seal payload")
+		return "", fmt.Errorf("seal payload: %w", err)
+	}
+
+	info, err := e.client.EnqueueContext(ctx, asynq.NewTask(TaskTypeRoomsGroup, b),
+		asynq.Queue(queue), asynq.MaxRetry(roomGroupMaxRetry), asynq.TaskID(payload.GroupingID))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "enqueue error")
+		log.Error(ctx, "enqueue failed", "task_type", TaskTypeRoomsGroup, "grouping_id", payload.GroupingID,
+			"queue", queue, "error", err)
+		return "", fmt.Errorf("enqueue %s: %w", TaskTypeRoomsGroup, err)
+	}
+	span.SetAttributes(attribute.String("queue.id", info.ID))
+	log.Info(ctx, "enqueued room grouping", "grouping_id", payload.GroupingID, "project_id", payload.ProjectID,
+		"queue", queue)
+	return info.ID, nil
+}
+
+// Close releases the underlying asynq client resources.
+func (e *AsynqRoomGroupEnqueuer) Close() error {
+	return e.client.Close()
+}
+
+// NoopRoomGroupEnqueuer is a drop-in RoomGroupEnqueuer that does nothing (useful for tests).
+type NoopRoomGroupEnqueuer struct{}
+
+// EnqueueRoomGroup implements RoomGroupEnqueuer by returning a static ID without side effects.
+func (NoopRoomGroupEnqueuer) EnqueueRoomGroup(_ context.Context, _ RoomGroupPayload) (string, error) {
+	return "noop", nil
+}
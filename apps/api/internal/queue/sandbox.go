@@ -0,0 +1,26 @@
+package queue
+
+import (
+	"context"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// sandboxQueueName returns the queue that sandbox tenant tasks are enqueued on.
+func sandboxQueueName(cfg *config.Config) string {
+	if cfg.SandboxTenant.QueueName == "" {
+		return "sandbox"
+	}
+	return cfg.SandboxTenant.QueueName
+}
+
+// tenantQueue returns the queue that a task enqueued with ctx goes to. Sandbox tasks
+// go to sandboxQueue, which only the sandbox tenant worker serves. All other tasks go
+// to queue.
+func tenantQueue(ctx context.Context, queue, sandboxQueue string) string {
+	if storage.SandboxFrom(ctx) {
+		return sandboxQueue
+	}
+	return queue
+}
@@ -2,20 +2,23 @@ package reconcile
 
 import (
 	"net/http"
-	"os"
 	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/config"
 )
 
 // DefaultHandler handles HTTP requests for reconciliation operations.
 type DefaultHandler struct {
 	service Service
+	cfg     config.Reconcile
 }
 
-// NewDefaultHandler creates a new DefaultHandler.
-func NewDefaultHandler(service Service) *DefaultHandler {
-	return &DefaultHandler{service: service}
+// NewDefaultHandler creates a new DefaultHandler. The endpoint is disabled unless cfg.Enabled is set.
+func NewDefaultHandler(service Service, cfg config.Reconcile) *DefaultHandler {
+	return &DefaultHandler{service: service, cfg: cfg}
 }
 
 // ReconcileImagesRequest contains the request parameters for image reconciliation.
@@ -25,19 +28,22 @@ type ReconcileImagesRequest struct {
 	Limit     int     `json:"limit" query:"limit"`
 	Cursor    *string `json:"cursor" query:"cursor"`
 	DryRun    bool    `json:"dry_run" query:"dry_run"`
+	// CreatedAfter and CreatedBefore are RFC 3339 timestamps bounding created_at.
+	CreatedAfter  *string `json:"created_after" query:"created_after"`
+	CreatedBefore *string `json:"created_before" query:"created_before"`
 }
 
 // ReconcileImages handles POST /api/v1/admin/reconcile/images.
 func (h *DefaultHandler) ReconcileImages(c echo.Context) error {
 	// Feature flag check
-	if os.Getenv("RECONCILE_ENABLED") != "1" {
+	if !h.cfg.Enabled {
 		return c.JSON(http.StatusNotImplemented, map[string]string{
 			"error": "reconciliation is not enabled",
 		})
 	}
 
 	// TODO: Add role-gated auth check when admin roles are implemented
-	// For now, we rely on the RECONCILE_ENABLED flag for access control
+	// For now, we rely on the reconcile.enabled flag for access control
 
 	var req ReconcileImagesRequest
 
@@ -65,12 +71,10 @@ func (h *DefaultHandler) ReconcileImages(c echo.Context) error {
 		req.Limit = 1000
 	}
 
-	// Parse concurrency from env or query (query overrides env)
+	// Concurrency comes from config unless the query overrides it
 	concurrency := 5
-	if envConc := os.Getenv("RECONCILE_CONCURRENCY"); envConc != "" {
-		if parsed, err := strconv.Atoi(envConc); err == nil && parsed > 0 {
-			concurrency = parsed
-		}
+	if h.cfg.Concurrency > 0 {
+		concurrency = h.cfg.Concurrency
 	}
 	if concStr := c.QueryParam("concurrency"); concStr != "" {
 		if parsed, err := strconv.Atoi(concStr); err == nil && parsed > 0 {
@@ -78,13 +82,28 @@ func (h *DefaultHandler) ReconcileImages(c echo.Context) error {
 		}
 	}
 
+	createdAfter, err := parseTime(req.CreatedAfter)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "created_after must be an RFC 3339 timestamp",
+		})
+	}
+	createdBefore, err := parseTime(req.CreatedBefore)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "created_before must be an RFC 3339 timestamp",
+		})
+	}
+
 	opts := ReconcileOptions{
-		ProjectID:   req.ProjectID,
-		Status:      req.Status,
-		Limit:       req.Limit,
-		Cursor:      req.Cursor,
-		DryRun:      req.DryRun,
-		Concurrency: concurrency,
+		ProjectID:     req.ProjectID,
+		Status:        req.Status,
+		Limit:         req.Limit,
+		Cursor:        req.Cursor,
+		CreatedAfter:  createdAfter,
+		CreatedBefore: createdBefore,
+		DryRun:        req.DryRun,
+		Concurrency:   concurrency,
 	}
 
 	result, err := h.service.ReconcileImages(c.Request().Context(), opts)
@@ -96,3 +115,15 @@ func (h *DefaultHandler) ReconcileImages(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, result)
 }
+
+// parseTime parses an optional RFC 3339 timestamp.
+func parseTime(s *string) (*time.Time, error) {
+	if s == nil || *s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, *s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -9,26 +9,27 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
 )
 
 func TestDefaultHandler_ReconcileImages(t *testing.T) {
 	testCases := []struct {
 		name           string
-		envVars        map[string]string
+		cfg            config.Reconcile
 		queryParams    string
 		setupMock      func(*ServiceMock)
 		expectedStatus int
 		validateResp   func(t *testing.T, body string)
 	}{
 		{
-			name: "success: basic reconciliation",
-			envVars: map[string]string{
-				"RECONCILE_ENABLED": "1",
-			},
+			name:        "success: basic reconciliation",
+			cfg:         config.Reconcile{Enabled: true},
 			queryParams: "limit=50&dry_run=true",
 			setupMock: func(svcMock *ServiceMock) {
 				svcMock.ReconcileImagesFunc = func(ctx context.Context, opts ReconcileOptions) (*ReconcileResult, error) {
@@ -65,10 +66,8 @@ func TestDefaultHandler_ReconcileImages(t *testing.T) {
 			},
 		},
 		{
-			name: "success: with project_id filter",
-			envVars: map[string]string{
-				"RECONCILE_ENABLED": "1",
-			},
+			name:        "success: with project_id filter",
+			cfg:         config.Reconcile{Enabled: true},
 			queryParams: "project_id=550e8400-e29b-41d4-a716-446655440000&limit=100",
 			setupMock: func(svcMock *ServiceMock) {
 				svcMock.ReconcileImagesFunc = func(ctx context.Context, opts ReconcileOptions) (*ReconcileResult, error) {
@@ -85,10 +84,8 @@ func TestDefaultHandler_ReconcileImages(t *testing.T) {
 			},
 		},
 		{
-			name: "success: with status filter",
-			envVars: map[string]string{
-				"RECONCILE_ENABLED": "1",
-			},
+			name:        "success: with status filter",
+			cfg:         config.Reconcile{Enabled: true},
 			queryParams: "status=ready&limit=100",
 			setupMock: func(svcMock *ServiceMock) {
 				svcMock.ReconcileImagesFunc = func(ctx context.Context, opts ReconcileOptions) (*ReconcileResult, error) {
@@ -102,10 +99,8 @@ func TestDefaultHandler_ReconcileImages(t *testing.T) {
 			expectedStatus: http.StatusOK,
 		},
 		{
-			name: "success: custom concurrency from query",
-			envVars: map[string]string{
-				"RECONCILE_ENABLED": "1",
-			},
+			name:        "success: custom concurrency from query",
+			cfg:         config.Reconcile{Enabled: true},
 			queryParams: "concurrency=10&limit=100",
 			setupMock: func(svcMock *ServiceMock) {
 				svcMock.ReconcileImagesFunc = func(ctx context.Context, opts ReconcileOptions) (*ReconcileResult, error) {
@@ -121,11 +116,8 @@ func TestDefaultHandler_ReconcileImages(t *testing.T) {
 			expectedStatus: http.StatusOK,
 		},
 		{
-			name: "success: custom concurrency from env",
-			envVars: map[string]string{
-				"RECONCILE_ENABLED":     "1",
-				"RECONCILE_CONCURRENCY": "15",
-			},
+			name:        "success: custom concurrency from env",
+			cfg:         config.Reconcile{Enabled: true, Concurrency: 15},
 			queryParams: "limit=100",
 			setupMock: func(svcMock *ServiceMock) {
 				svcMock.ReconcileImagesFunc = func(ctx context.Context, opts ReconcileOptions) (*ReconcileResult, error) {
@@ -141,11 +133,8 @@ func TestDefaultHandler_ReconcileImages(t *testing.T) {
 			expectedStatus: http.StatusOK,
 		},
 		{
-			name: "success: query param concurrency overrides env",
-			envVars: map[string]string{
-				"RECONCILE_ENABLED":     "1",
-				"RECONCILE_CONCURRENCY": "15",
-			},
+			name:        "success: query param concurrency overrides env",
+			cfg:         config.Reconcile{Enabled: true, Concurrency: 15},
 			queryParams: "concurrency=20&limit=100",
 			setupMock: func(svcMock *ServiceMock) {
 				svcMock.ReconcileImagesFunc = func(ctx context.Context, opts ReconcileOptions) (*ReconcileResult, error) {
@@ -159,10 +148,8 @@ func TestDefaultHandler_ReconcileImages(t *testing.T) {
 			expectedStatus: http.StatusOK,
 		},
 		{
-			name: "success: default limit applied",
-			envVars: map[string]string{
-				"RECONCILE_ENABLED": "1",
-			},
+			name:        "success: default limit applied",
+			cfg:         config.Reconcile{Enabled: true},
 			queryParams: "",
 			setupMock: func(svcMock *ServiceMock) {
 				svcMock.ReconcileImagesFunc = func(ctx context.Context, opts ReconcileOptions) (*ReconcileResult, error) {
@@ -178,10 +165,8 @@ func TestDefaultHandler_ReconcileImages(t *testing.T) {
 			expectedStatus: http.StatusOK,
 		},
 		{
-			name: "success: max limit enforced",
-			envVars: map[string]string{
-				"RECONCILE_ENABLED": "1",
-			},
+			name:        "success: max limit enforced",
+			cfg:         config.Reconcile{Enabled: true},
 			queryParams: "limit=2000",
 			setupMock: func(svcMock *ServiceMock) {
 				svcMock.ReconcileImagesFunc = func(ctx context.Context, opts ReconcileOptions) (*ReconcileResult, error) {
@@ -194,9 +179,36 @@ func TestDefaultHandler_ReconcileImages(t *testing.T) {
 			},
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name:        "success: created_at window",
+			cfg:         config.Reconcile{Enabled: true},
+			queryParams: "created_after=2025-01-01T00:00:00Z&created_before=2025-02-01T00:00:00Z",
+			setupMock: func(svcMock *ServiceMock) {
+				svcMock.ReconcileImagesFunc = func(ctx context.Context, opts ReconcileOptions) (*ReconcileResult, error) {
+					if opts.CreatedAfter == nil || !opts.CreatedAfter.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+						return nil, errors.New("expected created_after 2025-01-01")
+					}
+					if opts.CreatedBefore == nil || !opts.CreatedBefore.Equal(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)) {
+						return nil, errors.New("expected created_before 2025-02-01")
+					}
+					return &ReconcileResult{Checked: 1}, nil
+				}
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "failure: invalid created_after",
+			cfg:            config.Reconcile{Enabled: true},
+			queryParams:    "created_after=yesterday",
+			setupMock:      func(svcMock *ServiceMock) {},
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, body string) {
+				assert.Contains(t, body, "created_after must be an RFC 3339 timestamp")
+			},
+		},
 		{
 			name:        "failure: feature not enabled",
-			envVars:     map[string]string{},
+			cfg:         config.Reconcile{},
 			queryParams: "limit=100",
 			setupMock: func(svcMock *ServiceMock) {
 				// Should not be called
@@ -207,10 +219,8 @@ func TestDefaultHandler_ReconcileImages(t *testing.T) {
 			},
 		},
 		{
-			name: "failure: service error",
-			envVars: map[string]string{
-				"RECONCILE_ENABLED": "1",
-			},
+			name:        "failure: service error",
+			cfg:         config.Reconcile{Enabled: true},
 			queryParams: "limit=100",
 			setupMock: func(svcMock *ServiceMock) {
 				svcMock.ReconcileImagesFunc = func(ctx context.Context, opts ReconcileOptions) (*ReconcileResult, error) {
@@ -226,17 +236,12 @@ func TestDefaultHandler_ReconcileImages(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			// Set environment variables
-			for key, val := range tc.envVars {
-				t.Setenv(key, val)
-			}
-
 			// Create mock service
 			svcMock := &ServiceMock{}
 			tc.setupMock(svcMock)
 
 			// Create handler
-			handler := NewDefaultHandler(svcMock)
+			handler := NewDefaultHandler(svcMock, tc.cfg)
 
 			// Create Echo context
 			e := echo.New()
@@ -272,8 +277,6 @@ func TestDefaultHandler_ReconcileImages(t *testing.T) {
 }
 
 func TestDefaultHandler_ReconcileImages_InvalidConcurrency(t *testing.T) {
-	t.Setenv("RECONCILE_ENABLED", "1")
-
 	// Test invalid concurrency values
 	invalidValues := []string{"0", "-1", "abc"}
 
@@ -288,7 +291,7 @@ func TestDefaultHandler_ReconcileImages_InvalidConcurrency(t *testing.T) {
 				},
 			}
 
-			handler := NewDefaultHandler(svcMock)
+			handler := NewDefaultHandler(svcMock, config.Reconcile{Enabled: true})
 			e := echo.New()
 
 			queryParam := "limit=100&concurrency=" + url.QueryEscape(val)
@@ -309,8 +312,6 @@ func TestDefaultHandler_ReconcileImages_InvalidConcurrency(t *testing.T) {
 }
 
 func TestDefaultHandler_ReconcileImages_JSONBody(t *testing.T) {
-	t.Setenv("RECONCILE_ENABLED", "1")
-
 	testCases := []struct {
 		name           string
 		body           string
@@ -353,7 +354,7 @@ func TestDefaultHandler_ReconcileImages_JSONBody(t *testing.T) {
 			svcMock := &ServiceMock{}
 			tc.setupMock(svcMock)
 
-			handler := NewDefaultHandler(svcMock)
+			handler := NewDefaultHandler(svcMock, config.Reconcile{Enabled: true})
 			e := echo.New()
 
 			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/reconcile/images", strings.NewReader(tc.body))
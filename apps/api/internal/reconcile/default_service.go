@@ -2,8 +2,8 @@ package reconcile
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"net/url"
 	"strings"
 	"sync"
 
@@ -19,30 +19,34 @@ import (
 // DefaultService implements the Service interface.
 type DefaultService struct {
 	querier queries.Querier
-	s3      storage.S3Service
+	buckets storage.Buckets
 }
 
 // NewDefaultService creates a new DefaultService with a database.
-func NewDefaultService(db storage.Database, s3 storage.S3Service) *DefaultService {
+func NewDefaultService(db storage.Database, buckets storage.Buckets) *DefaultService {
 	return &DefaultService{
 		querier: queries.New(db),
-		s3:      s3,
+		buckets: buckets,
 	}
 }
 
 // NewDefaultServiceWithQuerier creates a new DefaultService with a custom querier (for testing).
-func NewDefaultServiceWithQuerier(querier queries.Querier, s3 storage.S3Service) *DefaultService {
+func NewDefaultServiceWithQuerier(querier queries.Querier, buckets storage.Buckets) *DefaultService {
 	return &DefaultService{
 		querier: querier,
-		s3:      s3,
+		buckets: buckets,
 	}
 }
 
 // ReconcileImages checks S3 storage for original_url and staged_url existence and updates DB accordingly.
+// It then checks each image's status against its status event log, which is authoritative,
+// and puts images whose status has drifted back to the status their log ends in.
 func (s *DefaultService) ReconcileImages(ctx context.Context, opts ReconcileOptions) (*ReconcileResult, error) {
 	tracer := otel.Tracer("reconcile")
 	ctx, span := tracer.Start(ctx, "reconcile.images")
 	defer span.End()
+	// Listing candidates can scan most of the images table
+	ctx = storage.WithQueryClass(ctx, storage.QueryClassScan)
 	span.SetAttributes(
 		attribute.Bool("dry_run", opts.DryRun),
 		attribute.Int("limit", opts.Limit),
@@ -78,6 +82,12 @@ func (s *DefaultService) ReconcileImages(ctx context.Context, opts ReconcileOpti
 		}
 		params.Column3 = pgtype.UUID{Bytes: parsed, Valid: true}
 	}
+	if opts.CreatedAfter != nil {
+		params.Column5 = pgtype.Timestamptz{Time: *opts.CreatedAfter, Valid: true}
+	}
+	if opts.CreatedBefore != nil {
+		params.Column6 = pgtype.Timestamptz{Time: *opts.CreatedBefore, Valid: true}
+	}
 
 	// Fetch images
 	rows, err := s.querier.ListImagesForReconcile(ctx, params)
@@ -128,26 +138,17 @@ func (s *DefaultService) ReconcileImages(ctx context.Context, opts ReconcileOpti
 			checkSpan.SetAttributes(attribute.String("image.id", img.ID.String()))
 			defer checkSpan.End()
 
-			// Extract key from URL
-			origKey, origErr := extractS3Key(img.OriginalUrl)
-			var stagedKey string
-			var stagedErr error
-			if img.StagedUrl.Valid {
-				stagedKey, stagedErr = extractS3Key(img.StagedUrl.String)
-			}
-
 			// Check original_url
-			origMissing := false
-			if origErr == nil {
-				_, err := s.s3.HeadFile(checkCtx, origKey)
-				if err != nil {
-					origMissing = true
-					mu.Lock()
-					result.MissingOrig++
-					mu.Unlock()
-				}
-			} else {
-				origMissing = true
+			origMissing, err := s.objectMissing(checkCtx, img.OriginalUrl)
+			if errors.Is(err, storage.ErrUnknownBucket) {
+				recordExternal(checkCtx, result, &mu, img, err)
+				return
+			}
+			if err != nil {
+				recordCheckError(checkCtx, result, &mu, img, err)
+				return
+			}
+			if origMissing {
 				mu.Lock()
 				result.MissingOrig++
 				mu.Unlock()
@@ -156,16 +157,14 @@ func (s *DefaultService) ReconcileImages(ctx context.Context, opts ReconcileOpti
 			// Check staged_url if status=ready
 			stagedMissing := false
 			if img.Status == "ready" && img.StagedUrl.Valid {
-				if stagedErr == nil {
-					_, err := s.s3.HeadFile(checkCtx, stagedKey)
-					if err != nil {
-						stagedMissing = true
-						mu.Lock()
-						result.MissingStaged++
-						mu.Unlock()
-					}
-				} else {
-					stagedMissing = true
+				// A failed staged check only matters if the original is present.
+				missing, err := s.objectMissing(checkCtx, img.StagedUrl.String)
+				if err != nil && !origMissing {
+					recordCheckError(checkCtx, result, &mu, img, err)
+					return
+				}
+				stagedMissing = missing
+				if stagedMissing {
 					mu.Lock()
 					result.MissingStaged++
 					mu.Unlock()
@@ -215,10 +214,17 @@ func (s *DefaultService) ReconcileImages(ctx context.Context, opts ReconcileOpti
 
 	wg.Wait()
 
+	if err := s.reconcileStatuses(ctx, images, opts.DryRun, result); err != nil {
+		return nil, err
+	}
+
 	logger.Info(ctx, "reconcile: completed",
 		"checked", result.Checked,
 		"missing_original", result.MissingOrig,
 		"missing_staged", result.MissingStaged,
+		"check_errors", result.CheckErrors,
+		"external", result.External,
+		"status_drift", result.StatusDrift,
 		"updated", result.Updated,
 		"dry_run", result.DryRun,
 	)
@@ -226,30 +232,100 @@ func (s *DefaultService) ReconcileImages(ctx context.Context, opts ReconcileOpti
 	return result, nil
 }
 
-// extractS3Key extracts the object key from an S3 URL.
-// Supports https://bucket.s3.region.amazonaws.com/key and http://host/bucket/key formats.
-func extractS3Key(s3URL string) (string, error) {
-	parsed, err := url.Parse(s3URL)
-	if err != nil {
-		return "", fmt.Errorf("invalid URL: %w", err)
+// reconcileStatuses finds the images whose status differs from the latest event in their
+// status log. images.status is a copy of that event kept in step by the status trigger, so
+// drift only follows writes that bypassed the trigger; the log wins and the image is put
+// back to the status it ends in.
+func (s *DefaultService) reconcileStatuses(
+	ctx context.Context, images []*queries.Image, dryRun bool, result *ReconcileResult,
+) error {
+	if len(images) == 0 {
+		return nil
+	}
+	ids := make([]pgtype.UUID, len(images))
+	for i, img := range images {
+		ids[i] = img.ID
 	}
 
-	path := strings.TrimPrefix(parsed.Path, "/")
-	if path == "" {
-		return "", fmt.Errorf("empty path in URL")
+	drifted, err := s.querier.ListImageStatusDrift(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to check image statuses: %w", err)
 	}
 
-	// If hostname contains "s3", assume standard S3 URL format
-	if strings.Contains(parsed.Host, "s3") {
-		return path, nil
+	logger := logging.Default()
+	for _, d := range drifted {
+		result.StatusDrift++
+		if len(result.Examples) < 10 {
+			result.Examples = append(result.Examples, ReconcileError{
+				ImageID: d.ID.String(),
+				Status:  string(d.Status),
+				Error:   fmt.Sprintf("status differs from status log (%s)", d.EventStatus),
+			})
+		}
+		if dryRun {
+			continue
+		}
+
+		n, err := s.querier.SyncImageStatusFromEvents(ctx, queries.SyncImageStatusFromEventsParams{
+			EventStatus: d.EventStatus,
+			ID:          d.ID,
+			Status:      d.Status,
+		})
+		if err != nil {
+			logger.Warn(ctx, "reconcile: failed to sync image status", "image_id", d.ID.String(), "error", err)
+			continue
+		}
+		// Zero rows means the image changed status since the check; the next run looks again.
+		if n > 0 {
+			result.Updated++
+		}
 	}
+	return nil
+}
 
-	// Otherwise, assume path-style (MinIO): /bucket/key
-	parts := strings.SplitN(path, "/", 2)
-	if len(parts) < 2 {
-		return "", fmt.Errorf("cannot extract key from path-style URL")
+// objectMissing reports whether the object rawURL points at is absent from its bucket. A URL
+// that names no object counts as missing. Any other failure (timeouts, 5xx responses, a
+// customer role that can no longer be assumed) is returned so the image is skipped rather
+// than marked missing because storage was briefly unreachable.
+func (s *DefaultService) objectMissing(ctx context.Context, rawURL string) (bool, error) {
+	files, key, err := s.buckets.ForURL(ctx, rawURL)
+	if errors.Is(err, storage.ErrInvalidObjectURL) {
+		return true, nil
 	}
-	return parts[1], nil
+	if err != nil {
+		return false, err
+	}
+	if _, err := files.HeadFile(ctx, key); err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return true, nil
+		}
+		return false, err
+	}
+	return false, nil
+}
+
+// recordCheckError counts an image whose storage check failed; it is left unchanged
+// and picked up again by the next run.
+func recordCheckError(
+	ctx context.Context, result *ReconcileResult, mu *sync.Mutex, img *queries.Image, err error,
+) {
+	logging.Default().Warn(ctx, "reconcile: storage check failed; skipping image", "image_id", img.ID.String(), "error", err)
+	mu.Lock()
+	result.CheckErrors++
+	mu.Unlock()
+}
+
+// recordExternal counts an image stored in a bucket that is no longer configured, such as a
+// customer bucket whose settings were removed. Its objects may well still exist, so it is
+// left unchanged rather than marked missing.
+func recordExternal(
+	ctx context.Context, result *ReconcileResult, mu *sync.Mutex, img *queries.Image, err error,
+) {
+	logging.Default().Info(ctx, "reconcile: image is in an unconfigured bucket; skipping",
+		"image_id", img.ID.String(), "error", err)
+	mu.Lock()
+	result.External++
+	mu.Unlock()
 }
 
 // parseUUID parses a UUID string into [16]byte.
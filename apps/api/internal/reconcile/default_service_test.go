@@ -3,7 +3,9 @@ package reconcile
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -19,10 +21,10 @@ func TestNewDefaultService(t *testing.T) {
 	dbMock := &storage.DatabaseMock{}
 	s3Mock := &storage.S3ServiceMock{}
 
-	service := NewDefaultService(dbMock, s3Mock)
+	service := NewDefaultService(dbMock, storage.NewPlatformBuckets(s3Mock))
 	assert.NotNil(t, service)
 	assert.NotNil(t, service.querier)
-	assert.NotNil(t, service.s3)
+	assert.NotNil(t, service.buckets)
 }
 
 func TestReconcileService_ReconcileImages(t *testing.T) {
@@ -88,7 +90,7 @@ func TestReconcileService_ReconcileImages(t *testing.T) {
 					return []*queries.ListImagesForReconcileRow{img}, nil
 				}
 				s3Mock.HeadFileFunc = func(ctx context.Context, fileKey string) (interface{}, error) {
-					return nil, errors.New("not found")
+					return nil, fmt.Errorf("failed to get file metadata: %w", storage.ErrObjectNotFound)
 				}
 			},
 			expectError: false,
@@ -102,6 +104,45 @@ func TestReconcileService_ReconcileImages(t *testing.T) {
 				assert.Contains(t, result.Examples[0].Error, "original missing")
 			},
 		},
+		{
+			name: "success: storage errors skip the image instead of marking it missing",
+			opts: ReconcileOptions{
+				Limit:       100,
+				Concurrency: 5,
+				DryRun:      false,
+			},
+			setupMocks: func(qMock *queries.QuerierMock, s3Mock *storage.S3ServiceMock) {
+				img := createTestImage("img-1", "ready",
+					"http://s3.amazonaws.com/uploads/test.jpg",
+					"http://s3.amazonaws.com/uploads/test-staged.jpg")
+				qMock.ListImagesForReconcileFunc = func(
+					ctx context.Context, arg queries.ListImagesForReconcileParams,
+				) ([]*queries.ListImagesForReconcileRow, error) {
+					return []*queries.ListImagesForReconcileRow{img}, nil
+				}
+				s3Mock.HeadFileFunc = func(ctx context.Context, fileKey string) (interface{}, error) {
+					if fileKey == "uploads/test.jpg" {
+						return struct{}{}, nil
+					}
+					return nil, errors.New("failed to get file metadata: StatusCode: 503, SlowDown")
+				}
+				qMock.UpdateImageWithErrorFunc = func(
+					ctx context.Context, arg queries.UpdateImageWithErrorParams,
+				) (*queries.UpdateImageWithErrorRow, error) {
+					t.Error("image must not be updated when storage could not be checked")
+					return nil, nil
+				}
+			},
+			expectError: false,
+			validate: func(t *testing.T, result *ReconcileResult) {
+				assert.Equal(t, 1, result.Checked)
+				assert.Equal(t, 1, result.CheckErrors)
+				assert.Equal(t, 0, result.MissingOrig)
+				assert.Equal(t, 0, result.MissingStaged)
+				assert.Equal(t, 0, result.Updated)
+				assert.Empty(t, result.Examples)
+			},
+		},
 		{
 			name: "success: missing staged file (dry run)",
 			opts: ReconcileOptions{
@@ -122,7 +163,7 @@ func TestReconcileService_ReconcileImages(t *testing.T) {
 					if fileKey == "uploads/test.jpg" {
 						return struct{}{}, nil // Original exists
 					}
-					return nil, errors.New("staged not found")
+					return nil, fmt.Errorf("failed to get file metadata: %w", storage.ErrObjectNotFound)
 				}
 			},
 			expectError: false,
@@ -168,7 +209,7 @@ func TestReconcileService_ReconcileImages(t *testing.T) {
 					}, nil
 				}
 				s3Mock.HeadFileFunc = func(ctx context.Context, fileKey string) (interface{}, error) {
-					return nil, errors.New("not found")
+					return nil, fmt.Errorf("failed to get file metadata: %w", storage.ErrObjectNotFound)
 				}
 			},
 			expectError: false,
@@ -235,10 +276,10 @@ func TestReconcileService_ReconcileImages(t *testing.T) {
 				}
 				s3Mock.HeadFileFunc = func(ctx context.Context, fileKey string) (interface{}, error) {
 					if fileKey == "uploads/test1.jpg" {
-						return nil, errors.New("not found") // Missing
+						return nil, fmt.Errorf("failed to get file metadata: %w", storage.ErrObjectNotFound) // Missing
 					}
 					if fileKey == "uploads/test2-staged.jpg" {
-						return nil, errors.New("not found") // Missing staged
+						return nil, fmt.Errorf("failed to get file metadata: %w", storage.ErrObjectNotFound) // Missing staged
 					}
 					return struct{}{}, nil // Others exist
 				}
@@ -252,6 +293,29 @@ func TestReconcileService_ReconcileImages(t *testing.T) {
 				assert.True(t, result.DryRun)
 			},
 		},
+		{
+			name: "success: created_at window passed to query",
+			opts: ReconcileOptions{
+				Limit:         100,
+				Concurrency:   5,
+				CreatedAfter:  func() *time.Time { t := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC); return &t }(),
+				CreatedBefore: func() *time.Time { t := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC); return &t }(),
+				DryRun:        true,
+			},
+			setupMocks: func(qMock *queries.QuerierMock, s3Mock *storage.S3ServiceMock) {
+				qMock.ListImagesForReconcileFunc = func(
+					ctx context.Context, arg queries.ListImagesForReconcileParams,
+				) ([]*queries.ListImagesForReconcileRow, error) {
+					assert.Equal(t, pgtype.Timestamptz{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true}, arg.Column5)
+					assert.Equal(t, pgtype.Timestamptz{Time: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), Valid: true}, arg.Column6)
+					return []*queries.ListImagesForReconcileRow{}, nil
+				}
+			},
+			expectError: false,
+			validate: func(t *testing.T, result *ReconcileResult) {
+				assert.Equal(t, 0, result.Checked)
+			},
+		},
 		{
 			name: "success: defaults applied when limits are zero",
 			opts: ReconcileOptions{
@@ -333,7 +397,7 @@ func TestReconcileService_ReconcileImages(t *testing.T) {
 					return nil, errors.New("database update failed")
 				}
 				s3Mock.HeadFileFunc = func(ctx context.Context, fileKey string) (interface{}, error) {
-					return nil, errors.New("not found")
+					return nil, fmt.Errorf("failed to get file metadata: %w", storage.ErrObjectNotFound)
 				}
 			},
 			expectError: false,
@@ -404,11 +468,11 @@ func TestReconcileService_ReconcileImages(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			qMock := &queries.QuerierMock{}
-			s3Mock := &storage.S3ServiceMock{}
+			qMock := &queries.QuerierMock{ListImageStatusDriftFunc: noDrift}
+			s3Mock := &storage.S3ServiceMock{BucketNameFunc: func() string { return "real-staging" }}
 			tc.setupMocks(qMock, s3Mock)
 
-			service := NewDefaultServiceWithQuerier(qMock, s3Mock)
+			service := NewDefaultServiceWithQuerier(qMock, storage.NewPlatformBuckets(s3Mock))
 
 			result, err := service.ReconcileImages(context.Background(), tc.opts)
 
@@ -428,8 +492,181 @@ func TestReconcileService_ReconcileImages(t *testing.T) {
 	}
 }
 
+func TestReconcileService_ReconcileImages_CustomerBuckets(t *testing.T) {
+	qMock := &queries.QuerierMock{
+		ListImageStatusDriftFunc: noDrift,
+		ListImagesForReconcileFunc: func(
+			ctx context.Context, arg queries.ListImagesForReconcileParams,
+		) ([]*queries.ListImagesForReconcileRow, error) {
+			return []*queries.ListImagesForReconcileRow{
+				createTestImage("img-1", "queued", "https://removed.s3.amazonaws.com/uploads/a.jpg", ""),
+				createTestImage("img-2", "queued", "https://denied.s3.amazonaws.com/uploads/b.jpg", ""),
+				createTestImage("img-3", "queued", "https://customer.s3.amazonaws.com/uploads/c.jpg", ""),
+			}, nil
+		},
+	}
+	customer := &storage.S3ServiceMock{
+		HeadFileFunc: func(ctx context.Context, fileKey string) (interface{}, error) {
+			return nil, fmt.Errorf("head %s: %w", fileKey, storage.ErrObjectNotFound)
+		},
+	}
+	buckets := &storage.BucketsMock{
+		ForURLFunc: func(ctx context.Context, rawURL string) (storage.S3Service, string, error) {
+			bucket, key, _ := storage.ParseObjectURL(rawURL)
+			switch bucket {
+			case "removed":
+				return nil, "", storage.ErrUnknownBucket
+			case "denied":
+				return nil, "", errors.New("AccessDenied: not authorized to perform sts:AssumeRole")
+			}
+			return customer, key, nil
+		},
+	}
+
+	service := NewDefaultServiceWithQuerier(qMock, buckets)
+	result, err := service.ReconcileImages(context.Background(), ReconcileOptions{DryRun: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Checked)
+	assert.Equal(t, 1, result.External)
+	assert.Equal(t, 1, result.CheckErrors)
+	assert.Equal(t, 1, result.MissingOrig)
+	require.Len(t, customer.HeadFileCalls(), 1)
+	assert.Equal(t, "uploads/c.jpg", customer.HeadFileCalls()[0].FileKey)
+}
+
+func TestReconcileService_ReconcileImages_RegionBuckets(t *testing.T) {
+	qMock := &queries.QuerierMock{
+		ListImageStatusDriftFunc: noDrift,
+		ListImagesForReconcileFunc: func(
+			ctx context.Context, arg queries.ListImagesForReconcileParams,
+		) ([]*queries.ListImagesForReconcileRow, error) {
+			return []*queries.ListImagesForReconcileRow{
+				createTestImage("img-1", "ready", "s3://real-staging/uploads/a.jpg", "s3://real-staging/staged/a.jpg"),
+				createTestImage("img-2", "ready", "s3://real-staging-eu/uploads/b.jpg", "s3://real-staging-eu/staged/b.jpg"),
+			}, nil
+		},
+	}
+	present := func(ctx context.Context, fileKey string) (interface{}, error) { return struct{}{}, nil }
+	home := &storage.S3ServiceMock{BucketNameFunc: func() string { return "real-staging" }, HeadFileFunc: present}
+	eu := &storage.S3ServiceMock{
+		BucketNameFunc: func() string { return "real-staging-eu" },
+		HeadFileFunc: func(ctx context.Context, fileKey string) (interface{}, error) {
+			if fileKey == "staged/b.jpg" {
+				return nil, storage.ErrObjectNotFound
+			}
+			return struct{}{}, nil
+		},
+	}
+	buckets := storage.NewRegionalBuckets(home, map[string]storage.S3Service{"eu-central-1": eu}, nil)
+
+	service := NewDefaultServiceWithQuerier(qMock, buckets)
+	result, err := service.ReconcileImages(context.Background(), ReconcileOptions{DryRun: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Checked)
+	assert.Zero(t, result.External)
+	assert.Equal(t, 1, result.MissingStaged)
+	assert.Len(t, home.HeadFileCalls(), 2)
+	assert.Len(t, eu.HeadFileCalls(), 2)
+}
+
+func TestReconcileService_ReconcileImages_StatusDrift(t *testing.T) {
+	img := createTestImage("img-1", "ready", "http://s3.amazonaws.com/uploads/test.jpg", "")
+	present := &storage.S3ServiceMock{
+		BucketNameFunc: func() string { return "real-staging" },
+		HeadFileFunc:   func(ctx context.Context, fileKey string) (interface{}, error) { return struct{}{}, nil },
+	}
+
+	testCases := []struct {
+		name        string
+		dryRun      bool
+		drift       []*queries.ListImageStatusDriftRow
+		driftErr    error
+		synced      int64
+		wantSyncs   int
+		wantDrift   int
+		wantUpdated int
+		wantErr     bool
+	}{
+		{
+			name: "success: drifted image put back to its logged status",
+			drift: []*queries.ListImageStatusDriftRow{
+				{ID: img.ID, Status: queries.ImageStatusReady, EventStatus: queries.ImageStatusCanceled},
+			},
+			synced:      1,
+			wantSyncs:   1,
+			wantDrift:   1,
+			wantUpdated: 1,
+		},
+		{
+			name:   "success: dry run only reports drift",
+			dryRun: true,
+			drift: []*queries.ListImageStatusDriftRow{
+				{ID: img.ID, Status: queries.ImageStatusReady, EventStatus: queries.ImageStatusCanceled},
+			},
+			wantDrift: 1,
+		},
+		{
+			name: "success: image that moved on since the check is left alone",
+			drift: []*queries.ListImageStatusDriftRow{
+				{ID: img.ID, Status: queries.ImageStatusReady, EventStatus: queries.ImageStatusCanceled},
+			},
+			wantSyncs: 1,
+			wantDrift: 1,
+		},
+		{
+			name:     "fail: status check error",
+			driftErr: errors.New("db down"),
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			qMock := &queries.QuerierMock{
+				ListImagesForReconcileFunc: func(
+					ctx context.Context, arg queries.ListImagesForReconcileParams,
+				) ([]*queries.ListImagesForReconcileRow, error) {
+					return []*queries.ListImagesForReconcileRow{img}, nil
+				},
+				ListImageStatusDriftFunc: func(ctx context.Context, ids []pgtype.UUID) ([]*queries.ListImageStatusDriftRow, error) {
+					assert.Equal(t, []pgtype.UUID{img.ID}, ids)
+					return tc.drift, tc.driftErr
+				},
+				SyncImageStatusFromEventsFunc: func(
+					ctx context.Context, arg queries.SyncImageStatusFromEventsParams,
+				) (int64, error) {
+					assert.Equal(t, queries.ImageStatusCanceled, arg.EventStatus)
+					assert.Equal(t, queries.ImageStatusReady, arg.Status)
+					return tc.synced, nil
+				},
+			}
+			service := NewDefaultServiceWithQuerier(qMock, storage.NewPlatformBuckets(present))
+
+			result, err := service.ReconcileImages(context.Background(), ReconcileOptions{DryRun: tc.dryRun})
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantDrift, result.StatusDrift)
+			assert.Equal(t, tc.wantUpdated, result.Updated)
+			assert.Len(t, qMock.SyncImageStatusFromEventsCalls(), tc.wantSyncs)
+			if tc.wantDrift > 0 {
+				require.Len(t, result.Examples, 1)
+				assert.Contains(t, result.Examples[0].Error, "status log (canceled)")
+			}
+		})
+	}
+}
+
 // Helper functions
 
+func noDrift(ctx context.Context, ids []pgtype.UUID) ([]*queries.ListImageStatusDriftRow, error) {
+	return nil, nil
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
@@ -473,75 +710,6 @@ func createBasicHeadFileTestMock(
 
 // Tests for helper functions
 
-func TestExtractS3Key(t *testing.T) {
-	testCases := []struct {
-		name        string
-		url         string
-		expectedKey string
-		expectError bool
-		errorMsg    string
-	}{
-		{
-			name:        "success: standard S3 URL",
-			url:         "https://bucket.s3.amazonaws.com/uploads/test.jpg",
-			expectedKey: "uploads/test.jpg",
-			expectError: false,
-		},
-		{
-			name:        "success: S3 URL with region",
-			url:         "https://mybucket.s3.us-west-2.amazonaws.com/path/to/file.png",
-			expectedKey: "path/to/file.png",
-			expectError: false,
-		},
-		{
-			name:        "success: path-style URL (MinIO)",
-			url:         "http://localhost:9000/bucket/uploads/test.jpg",
-			expectedKey: "uploads/test.jpg",
-			expectError: false,
-		},
-		{
-			name:        "success: path-style URL with multiple path segments",
-			url:         "http://minio.example.com:9000/my-bucket/a/b/c/file.jpg",
-			expectedKey: "a/b/c/file.jpg",
-			expectError: false,
-		},
-		{
-			name:        "failure: invalid URL",
-			url:         "ht!tp://invalid-url",
-			expectError: true,
-			errorMsg:    "invalid URL",
-		},
-		{
-			name:        "failure: empty path",
-			url:         "https://bucket.s3.amazonaws.com/",
-			expectError: true,
-			errorMsg:    "empty path in URL",
-		},
-		{
-			name:        "failure: path-style with no key",
-			url:         "http://localhost:9000/bucket",
-			expectError: true,
-			errorMsg:    "cannot extract key from path-style URL",
-		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			key, err := extractS3Key(tc.url)
-
-			if tc.expectError {
-				assert.Error(t, err)
-				if tc.errorMsg != "" {
-					assert.Contains(t, err.Error(), tc.errorMsg)
-				}
-			} else {
-				assert.NoError(t, err)
-				assert.Equal(t, tc.expectedKey, key)
-			}
-		})
-	}
-}
-
 func TestParseUUID(t *testing.T) {
 	testCases := []struct {
 		name        string
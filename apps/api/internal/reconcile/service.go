@@ -1,6 +1,9 @@
 package reconcile
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 //go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
 
@@ -11,12 +14,14 @@ type Service interface {
 
 // ReconcileOptions configures a reconciliation run.
 type ReconcileOptions struct {
-	ProjectID   *string // Optional filter by project
-	Status      *string // Optional filter by status
-	Limit       int     // Max number of images to check
-	Cursor      *string // Optional cursor for pagination
-	DryRun      bool    // If true, don't apply changes
-	Concurrency int     // Worker pool size for S3 checks
+	ProjectID     *string    // Optional filter by project
+	Status        *string    // Optional filter by status
+	Limit         int        // Max number of images to check
+	Cursor        *string    // Optional cursor for pagination
+	CreatedAfter  *time.Time // Optional inclusive lower bound on created_at; prunes monthly partitions
+	CreatedBefore *time.Time // Optional exclusive upper bound on created_at; prunes monthly partitions
+	DryRun        bool       // If true, don't apply changes
+	Concurrency   int        // Worker pool size for S3 checks
 }
 
 // ReconcileResult summarizes what was checked and updated.
@@ -24,6 +29,9 @@ type ReconcileResult struct {
 	Checked       int              `json:"checked"`
 	MissingOrig   int              `json:"missing_original"`
 	MissingStaged int              `json:"missing_staged"`
+	CheckErrors   int              `json:"check_errors"` // Images skipped because storage could not be checked
+	External      int              `json:"external"`     // Images skipped because their bucket is not configured
+	StatusDrift   int              `json:"status_drift"` // Images whose status differs from their status event log
 	Updated       int              `json:"updated"`
 	Examples      []ReconcileError `json:"examples,omitempty"` // Up to 10 example errors
 	DryRun        bool             `json:"dry_run"`
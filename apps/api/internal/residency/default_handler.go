@@ -0,0 +1,66 @@
+package residency
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DefaultHandler serves storage regions over HTTP.
+type DefaultHandler struct {
+	service Service
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service) *DefaultHandler {
+	return &DefaultHandler{service: service}
+}
+
+// ErrorResponse is a simple JSON error envelope for handler responses.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// Get handles GET /api/v1/admin/users/:id/storage-region.
+func (h *DefaultHandler) Get(c echo.Context) error {
+	placement, err := h.service.Get(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, placement)
+}
+
+// Put handles PUT /api/v1/admin/users/:id/storage-region.
+func (h *DefaultHandler) Put(c echo.Context) error {
+	var req UpdateRequest
+	if err := c.Bind(&req); err != nil || req.Region == nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "region is required; use an empty string for the home bucket",
+		})
+	}
+	placement, err := h.service.Set(c.Request().Context(), c.Param("id"), *req.Region)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, placement)
+}
+
+func (h *DefaultHandler) writeError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "User not found"})
+	case errors.Is(err, ErrUnknownRegion):
+		return c.JSON(http.StatusUnprocessableEntity, ErrorResponse{Error: "validation_failed", Message: err.Error()})
+	}
+	c.Logger().Errorf("Failed to handle storage region: %v", err)
+	return c.JSON(http.StatusInternalServerError, ErrorResponse{
+		Error:   "internal_server_error",
+		Message: "Failed to handle storage region",
+	})
+}
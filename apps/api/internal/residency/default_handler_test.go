@@ -0,0 +1,81 @@
+package residency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: get", method: http.MethodGet, expectedStatus: http.StatusOK},
+		{name: "success: put", method: http.MethodPut, body: `{"region":"eu-central-1"}`, expectedStatus: http.StatusOK},
+		{name: "fail: put without region", method: http.MethodPut, body: `{}`, expectedStatus: http.StatusBadRequest},
+		{name: "fail: unknown region", method: http.MethodPut, body: `{"region":"us-east-2"}`,
+			err: fmt.Errorf("%w: us-east-2", ErrUnknownRegion), expectedStatus: http.StatusUnprocessableEntity},
+		{name: "fail: unknown user", method: http.MethodGet, err: ErrNotFound, expectedStatus: http.StatusNotFound},
+		{name: "fail: service error", method: http.MethodPut, body: `{"region":""}`,
+			err: errors.New("db down"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := func(userID, region string) (*Placement, error) {
+				if tc.err != nil {
+					return nil, tc.err
+				}
+				return &Placement{UserID: userID, Region: region}, nil
+			}
+			svc := &ServiceMock{
+				GetFunc: func(ctx context.Context, userID string) (*Placement, error) {
+					return result(userID, "eu-central-1")
+				},
+				SetFunc: func(ctx context.Context, userID, region string) (*Placement, error) {
+					return result(userID, region)
+				},
+			}
+			h := NewDefaultHandler(svc)
+
+			e := echo.New()
+			req := httptest.NewRequest(tc.method, "/api/v1/admin/users/"+userID+"/storage-region",
+				strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(userID)
+
+			handle := h.Get
+			if tc.method == http.MethodPut {
+				handle = h.Put
+			}
+			require.NoError(t, handle(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var body Placement
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Equal(t, userID, body.UserID)
+				assert.Equal(t, "eu-central-1", body.Region)
+			}
+			if tc.expectedStatus == http.StatusBadRequest {
+				assert.Empty(t, svc.SetCalls())
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package residency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// DefaultService implements Service.
+type DefaultService struct {
+	querier queries.Querier
+	s3      config.S3
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// Ensure Region can place users for storage.PlatformBuckets.
+var _ storage.UserRegions = (*DefaultService)(nil).Region
+
+// NewDefaultService creates a new DefaultService with a database. cfg supplies the
+// configured regions.
+func NewDefaultService(db storage.Database, cfg config.S3) *DefaultService {
+	return NewDefaultServiceWithQuerier(queries.New(db), cfg)
+}
+
+// NewDefaultServiceWithQuerier creates a new DefaultService with a custom querier (for testing).
+func NewDefaultServiceWithQuerier(querier queries.Querier, cfg config.S3) *DefaultService {
+	return &DefaultService{querier: querier, s3: cfg}
+}
+
+// Get returns the user's placement.
+func (s *DefaultService) Get(ctx context.Context, userID string) (*Placement, error) {
+	id, err := parseUUID(userID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	region, err := s.querier.GetUserStorageRegion(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage region: %w", err)
+	}
+	return s.placement(userID, region.String), nil
+}
+
+// Set moves the user's new images to region, which must have a bucket.
+func (s *DefaultService) Set(ctx context.Context, userID, region string) (*Placement, error) {
+	id, err := parseUUID(userID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	if _, ok := s.s3.ForRegion(region); region != "" && !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRegion, region)
+	}
+	n, err := s.querier.UpdateUserStorageRegion(ctx, queries.UpdateUserStorageRegionParams{
+		ID:            id,
+		StorageRegion: pgtype.Text{String: region, Valid: region != ""},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update storage region: %w", err)
+	}
+	if n == 0 {
+		return nil, ErrNotFound
+	}
+	return s.placement(userID, region), nil
+}
+
+// Region returns the user's region, or "" for the home bucket and unknown users.
+func (s *DefaultService) Region(ctx context.Context, userID string) (string, error) {
+	id, err := parseUUID(userID)
+	if err != nil {
+		return "", nil
+	}
+	region, err := s.querier.GetUserStorageRegion(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get storage region: %w", err)
+	}
+	return region.String, nil
+}
+
+// placement describes userID in region alongside every configured region.
+func (s *DefaultService) placement(userID, region string) *Placement {
+	regions := make([]string, 0, len(s.s3.RegionBuckets))
+	for r := range s.s3.RegionBuckets {
+		regions = append(regions, r)
+	}
+	sort.Strings(regions)
+	return &Placement{UserID: userID, Region: region, Regions: regions}
+}
+
+func parseUUID(id string) (pgtype.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+	return pgtype.UUID{Bytes: parsed, Valid: true}, nil
+}
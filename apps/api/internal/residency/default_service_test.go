@@ -0,0 +1,131 @@
+package residency
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+const userID = "3f0c9a52-7d4e-4b8f-9a61-2c5e8d1b7f40"
+
+var s3Cfg = config.S3{
+	BucketName:    "real-staging",
+	RegionBuckets: map[string]string{"eu-central-1": "real-staging-eu", "ap-southeast-2": "real-staging-au"},
+}
+
+func TestDefaultService_Get(t *testing.T) {
+	testCases := []struct {
+		name       string
+		userID     string
+		region     pgtype.Text
+		err        error
+		wantRegion string
+		wantErr    error
+	}{
+		{name: "success: home bucket", userID: userID},
+		{
+			name:       "success: placed in a region",
+			userID:     userID,
+			region:     pgtype.Text{String: "eu-central-1", Valid: true},
+			wantRegion: "eu-central-1",
+		},
+		{name: "fail: unknown user", userID: userID, err: pgx.ErrNoRows, wantErr: ErrNotFound},
+		{name: "fail: invalid user ID", userID: "nope", wantErr: ErrNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			querier := &queries.QuerierMock{
+				GetUserStorageRegionFunc: func(ctx context.Context, id pgtype.UUID) (pgtype.Text, error) {
+					return tc.region, tc.err
+				},
+			}
+
+			placement, err := NewDefaultServiceWithQuerier(querier, s3Cfg).Get(context.Background(), tc.userID)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRegion, placement.Region)
+			assert.Equal(t, []string{"ap-southeast-2", "eu-central-1"}, placement.Regions)
+		})
+	}
+}
+
+func TestDefaultService_Set(t *testing.T) {
+	testCases := []struct {
+		name      string
+		region    string
+		rows      int64
+		err       error
+		wantValid bool
+		wantErr   error
+	}{
+		{name: "success: move to a region", region: "eu-central-1", rows: 1, wantValid: true},
+		{name: "success: back to the home bucket", region: "", rows: 1},
+		{name: "fail: region without a bucket", region: "us-east-2", wantErr: ErrUnknownRegion},
+		{name: "fail: unknown user", region: "eu-central-1", wantErr: ErrNotFound},
+		{name: "fail: database error", region: "eu-central-1", err: errors.New("db down")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			querier := &queries.QuerierMock{
+				UpdateUserStorageRegionFunc: func(
+					ctx context.Context, arg queries.UpdateUserStorageRegionParams,
+				) (int64, error) {
+					return tc.rows, tc.err
+				},
+			}
+
+			placement, err := NewDefaultServiceWithQuerier(querier, s3Cfg).Set(context.Background(), userID, tc.region)
+			switch {
+			case tc.wantErr != nil:
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			case tc.err != nil:
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.region, placement.Region)
+			require.Len(t, querier.UpdateUserStorageRegionCalls(), 1)
+			arg := querier.UpdateUserStorageRegionCalls()[0].Arg
+			assert.Equal(t, tc.wantValid, arg.StorageRegion.Valid)
+			assert.Equal(t, tc.region, arg.StorageRegion.String)
+		})
+	}
+}
+
+func TestDefaultService_Region(t *testing.T) {
+	querier := &queries.QuerierMock{
+		GetUserStorageRegionFunc: func(ctx context.Context, id pgtype.UUID) (pgtype.Text, error) {
+			return pgtype.Text{}, pgx.ErrNoRows
+		},
+	}
+	svc := NewDefaultServiceWithQuerier(querier, s3Cfg)
+
+	region, err := svc.Region(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Empty(t, region, "unknown users stay in the home bucket")
+
+	region, err = svc.Region(context.Background(), "not-a-uuid")
+	require.NoError(t, err)
+	assert.Empty(t, region)
+	assert.Len(t, querier.GetUserStorageRegionCalls(), 1)
+
+	querier.GetUserStorageRegionFunc = func(ctx context.Context, id pgtype.UUID) (pgtype.Text, error) {
+		return pgtype.Text{}, errors.New("db down")
+	}
+	_, err = svc.Region(context.Background(), userID)
+	assert.Error(t, err, "a failed lookup must not silently use the home bucket")
+}
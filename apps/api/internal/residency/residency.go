@@ -0,0 +1,27 @@
+// Package residency places users in a data-residency region, so their uploads and staged
+// outputs are stored in that region's bucket (s3.region_buckets) instead of the home
+// bucket. Placement only affects new images; stored URLs keep naming their bucket.
+package residency
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when the user does not exist.
+	ErrNotFound = errors.New("user not found")
+	// ErrUnknownRegion is returned when a region has no configured bucket.
+	ErrUnknownRegion = errors.New("storage region is not configured")
+)
+
+// Placement is the region a user's new images are stored in.
+type Placement struct {
+	UserID string `json:"user_id"`
+	// Region is empty for users kept in the home bucket.
+	Region string `json:"region"`
+	// Regions lists every configured region, for choosing another.
+	Regions []string `json:"regions"`
+}
+
+// UpdateRequest moves a user to Region; an empty Region returns them to the home bucket.
+type UpdateRequest struct {
+	Region *string `json:"region"`
+}
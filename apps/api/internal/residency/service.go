@@ -0,0 +1,16 @@
+package residency
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service reads and changes users' storage regions.
+type Service interface {
+	// Get returns the user's placement.
+	Get(ctx context.Context, userID string) (*Placement, error)
+	// Set moves the user's new images to region, or to the home bucket when region is "".
+	Set(ctx context.Context, userID, region string) (*Placement, error)
+	// Region returns the user's region, or "" for the home bucket and unknown users. It
+	// satisfies storage.UserRegions.
+	Region(ctx context.Context, userID string) (string, error)
+}
@@ -0,0 +1,117 @@
+package roomgroup
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// DefaultHandler serves room groupings over HTTP.
+type DefaultHandler struct {
+	service  Service
+	userRepo user.Repository
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, userRepo user.Repository) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo}
+}
+
+// ErrorResponse is a simple JSON error envelope for handler responses.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// Create handles POST /api/v1/projects/:project_id/room-groupings. The analysis runs in the
+// background; clients poll GET /api/v1/projects/:project_id/room-groupings/:grouping_id
+// until it is ready.
+func (h *DefaultHandler) Create(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	grouping, err := h.service.Create(c.Request().Context(), userID, c.Param("project_id"))
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusAccepted, grouping)
+}
+
+// Get handles GET /api/v1/projects/:project_id/room-groupings/:grouping_id.
+func (h *DefaultHandler) Get(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	grouping, err := h.service.Get(c.Request().Context(), userID, c.Param("project_id"), c.Param("grouping_id"))
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, grouping)
+}
+
+// Latest handles GET /api/v1/projects/:project_id/room-groupings/latest.
+func (h *DefaultHandler) Latest(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	grouping, err := h.service.Latest(c.Request().Context(), userID, c.Param("project_id"))
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, grouping)
+}
+
+// Confirm handles POST /api/v1/projects/:project_id/room-groupings/:grouping_id/confirm.
+func (h *DefaultHandler) Confirm(c echo.Context) error {
+	var req ConfirmRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid request format"})
+	}
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	grouping, err := h.service.Confirm(c.Request().Context(), userID, c.Param("project_id"), c.Param("grouping_id"), req)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, grouping)
+}
+
+func unauthorized(c echo.Context) error {
+	return c.JSON(http.StatusUnauthorized, ErrorResponse{
+		Error:   "unauthorized",
+		Message: "Unable to resolve current user",
+	})
+}
+
+// writeError maps service errors to responses.
+func (h *DefaultHandler) writeError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, ErrInvalid):
+		return c.JSON(http.StatusUnprocessableEntity, ErrorResponse{Error: "validation_failed", Message: err.Error()})
+	case errors.Is(err, ErrNotFound):
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Project or room grouping not found"})
+	case errors.Is(err, ErrInProgress), errors.Is(err, ErrNotReady):
+		return c.JSON(http.StatusConflict, ErrorResponse{Error: "conflict", Message: err.Error()})
+	default:
+		c.Logger().Errorf("Room grouping request failed: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to process room grouping request",
+		})
+	}
+}
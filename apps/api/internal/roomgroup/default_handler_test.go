@@ -0,0 +1,151 @@
+package roomgroup
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func userRepoFor(userID uuid.UUID) *user.RepositoryMock {
+	return &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+		},
+	}
+}
+
+func newContext(method, target, body string, params ...string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("X-Test-User", "auth0|user")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("project_id", "grouping_id")
+	c.SetParamValues(params...)
+	return c, rec
+}
+
+func TestDefaultHandler_Create(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: accepted", expectedStatus: http.StatusAccepted},
+		{name: "fail: no images", err: ErrInvalid, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "fail: not found", err: ErrNotFound, expectedStatus: http.StatusNotFound},
+		{name: "fail: in progress", err: ErrInProgress, expectedStatus: http.StatusConflict},
+		{name: "fail: service error", err: errors.New("db down"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				CreateFunc: func(ctx context.Context, uid, projectID string) (*Grouping, error) {
+					assert.Equal(t, userID.String(), uid)
+					assert.Equal(t, "proj-1", projectID)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &Grouping{ID: "grp-1", ProjectID: projectID, Status: StatusQueued, Groups: []Group{}}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, userRepoFor(userID))
+
+			c, rec := newContext(http.MethodPost, "/api/v1/projects/proj-1/room-groupings", "", "proj-1", "")
+			require.NoError(t, h.Create(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusAccepted {
+				assert.Contains(t, rec.Body.String(), `"status":"queued"`)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_GetAndLatest(t *testing.T) {
+	userID := uuid.New()
+	svc := &ServiceMock{
+		GetFunc: func(ctx context.Context, uid, projectID, groupingID string) (*Grouping, error) {
+			if groupingID != "grp-1" {
+				return nil, ErrNotFound
+			}
+			return &Grouping{ID: groupingID, Status: StatusReady}, nil
+		},
+		LatestFunc: func(ctx context.Context, uid, projectID string) (*Grouping, error) {
+			return &Grouping{ID: "grp-1", Status: StatusReady}, nil
+		},
+	}
+	h := NewDefaultHandler(svc, userRepoFor(userID))
+
+	for groupingID, want := range map[string]int{"grp-1": http.StatusOK, "other": http.StatusNotFound} {
+		c, rec := newContext(http.MethodGet, "/api/v1/projects/proj-1/room-groupings/"+groupingID, "", "proj-1", groupingID)
+		require.NoError(t, h.Get(c))
+		assert.Equal(t, want, rec.Code, groupingID)
+	}
+
+	c, rec := newContext(http.MethodGet, "/api/v1/projects/proj-1/room-groupings/latest", "", "proj-1", "")
+	require.NoError(t, h.Latest(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"id":"grp-1"`)
+}
+
+func TestDefaultHandler_Confirm(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		body           string
+		err            error
+		wantGroups     bool
+		expectedStatus int
+	}{
+		{name: "success: as proposed", body: `{}`, expectedStatus: http.StatusOK},
+		{
+			name:           "success: edited groups",
+			body:           `{"groups":[{"room_type":"kitchen","label":"","image_ids":[]}]}`,
+			wantGroups:     true,
+			expectedStatus: http.StatusOK,
+		},
+		{name: "fail: malformed body", body: `{`, expectedStatus: http.StatusBadRequest},
+		{name: "fail: invalid", body: `{}`, err: ErrInvalid, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "fail: not ready", body: `{}`, err: ErrNotReady, expectedStatus: http.StatusConflict},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				ConfirmFunc: func(
+					ctx context.Context, uid, projectID, groupingID string, req ConfirmRequest,
+				) (*Grouping, error) {
+					assert.Equal(t, "grp-1", groupingID)
+					assert.Equal(t, tc.wantGroups, req.Groups != nil)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &Grouping{ID: groupingID, Status: StatusConfirmed}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, userRepoFor(userID))
+
+			c, rec := newContext(http.MethodPost, "/api/v1/projects/proj-1/room-groupings/grp-1/confirm",
+				tc.body, "proj-1", "grp-1")
+			require.NoError(t, h.Confirm(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
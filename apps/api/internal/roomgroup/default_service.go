@@ -0,0 +1,358 @@
+package roomgroup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/preset"
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// DefaultService implements Service.
+type DefaultService struct {
+	// db, when set, lets a grouping be confirmed in one transaction.
+	db       storage.Database
+	querier  queries.Querier
+	enqueuer queue.RoomGroupEnqueuer
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService with a database. Groupings are queued
+// through Redis when it is configured and dropped otherwise.
+func NewDefaultService(cfg *config.Config, db storage.Database) *DefaultService {
+	var enq queue.RoomGroupEnqueuer
+	if e, err := queue.NewAsynqRoomGroupEnqueuerFromConfig(cfg); err == nil {
+		enq = e
+	} else {
+		enq = queue.NoopRoomGroupEnqueuer{}
+	}
+	return &DefaultService{db: db, querier: queries.New(db), enqueuer: enq}
+}
+
+// NewDefaultServiceWithQuerier creates a new DefaultService with a custom querier and
+// enqueuer (for testing). Confirm then runs its writes without a transaction.
+func NewDefaultServiceWithQuerier(querier queries.Querier, enqueuer queue.RoomGroupEnqueuer) *DefaultService {
+	return &DefaultService{querier: querier, enqueuer: enqueuer}
+}
+
+// Create records a grouping of the user's project and queues its analysis. Returns
+// ErrInvalid when the project has no images and ErrInProgress when a grouping of it is
+// already queued or running.
+func (s *DefaultService) Create(ctx context.Context, userID, projectID string) (*Grouping, error) {
+	pid, err := s.ownedProject(ctx, userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.querier.CountProjectImages(ctx, pid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count project images: %w", err)
+	}
+	if count == 0 {
+		return nil, fmt.Errorf("%w: project has no images to group", ErrInvalid)
+	}
+	active, err := s.querier.HasActiveRoomGrouping(ctx, pid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check room groupings: %w", err)
+	}
+	if active {
+		return nil, ErrInProgress
+	}
+
+	row, err := s.querier.CreateRoomGrouping(ctx, pid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create room grouping: %w", err)
+	}
+	grouping, err := toGrouping(row)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.enqueuer.EnqueueRoomGroup(ctx, queue.RoomGroupPayload{
+		GroupingID: grouping.ID,
+		ProjectID:  grouping.ProjectID,
+	}); err != nil {
+		// Leave no grouping queued that no worker will pick up.
+		failErr := s.querier.FailRoomGrouping(ctx, queries.FailRoomGroupingParams{
+			ID:    row.ID,
+			Error: pgtype.Text{String: "failed to queue room grouping", Valid: true},
+		})
+		if failErr != nil {
+			logging.NewDefaultLogger().Error(ctx, "failed to mark room grouping failed",
+				"grouping_id", grouping.ID, "error", failErr)
+		}
+		return nil, fmt.Errorf("failed to enqueue room grouping: %w", err)
+	}
+	return grouping, nil
+}
+
+// Get returns one of the groupings of the user's project.
+func (s *DefaultService) Get(ctx context.Context, userID, projectID, groupingID string) (*Grouping, error) {
+	pid, err := s.ownedProject(ctx, userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.grouping(ctx, pid, groupingID)
+	if err != nil {
+		return nil, err
+	}
+	return toGrouping(row)
+}
+
+// Latest returns the most recent grouping of the user's project.
+func (s *DefaultService) Latest(ctx context.Context, userID, projectID string) (*Grouping, error) {
+	pid, err := s.ownedProject(ctx, userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.querier.GetLatestRoomGrouping(ctx, pid)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room grouping: %w", err)
+	}
+	return toGrouping(row)
+}
+
+// Confirm applies a ready grouping to the user's project: each group's images take its room
+// type, and the project's room checklist is replaced by one room per group. Rooms keep the
+// style the checklist already had for their room type. Returns ErrNotReady unless the
+// grouping is ready, and ErrInvalid when a group has no valid room type.
+func (s *DefaultService) Confirm(
+	ctx context.Context, userID, projectID, groupingID string, req ConfirmRequest,
+) (*Grouping, error) {
+	pid, err := s.ownedProject(ctx, userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.grouping(ctx, pid, groupingID)
+	if err != nil {
+		return nil, err
+	}
+	if row.Status != StatusReady {
+		return nil, ErrNotReady
+	}
+
+	groups := req.Groups
+	if groups == nil {
+		proposed, err := toGrouping(row)
+		if err != nil {
+			return nil, err
+		}
+		groups = &proposed.Groups
+	}
+	imageIDs, err := validateGroups(*groups)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := json.Marshal(*groups)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode room groups: %w", err)
+	}
+
+	var confirmed *queries.RoomGrouping
+	err = s.inTx(ctx, func(q queries.Querier) error {
+		for i, g := range *groups {
+			if len(imageIDs[i]) == 0 {
+				continue
+			}
+			if _, err := q.SetProjectImagesRoomType(ctx, queries.SetProjectImagesRoomTypeParams{
+				RoomType:  pgtype.Text{String: *g.RoomType, Valid: true},
+				ProjectID: pid,
+				ImageIds:  imageIDs[i],
+			}); err != nil {
+				return fmt.Errorf("failed to set image room types: %w", err)
+			}
+		}
+		if err := replaceRooms(ctx, q, pid, *groups); err != nil {
+			return err
+		}
+		confirmed, err = q.ConfirmRoomGrouping(ctx, queries.ConfirmRoomGroupingParams{ID: row.ID, Groups: stored})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotReady
+		}
+		if err != nil {
+			return fmt.Errorf("failed to confirm room grouping: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toGrouping(confirmed)
+}
+
+// replaceRooms replaces the project's room checklist with one room per group.
+func replaceRooms(ctx context.Context, q queries.Querier, projectID pgtype.UUID, groups []Group) error {
+	current, err := q.ListProjectRooms(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to list project rooms: %w", err)
+	}
+	styles := make(map[string]string)
+	for _, r := range current {
+		if _, ok := styles[r.RoomType]; !ok && r.Style.Valid {
+			styles[r.RoomType] = r.Style.String
+		}
+	}
+
+	if err := q.DeleteProjectRooms(ctx, projectID); err != nil {
+		return fmt.Errorf("failed to clear project rooms: %w", err)
+	}
+	if len(groups) == 0 {
+		return nil
+	}
+	params := queries.CreateProjectRoomsParams{
+		ProjectID: projectID,
+		RoomTypes: make([]string, len(groups)),
+		Styles:    make([]string, len(groups)),
+		Labels:    make([]string, len(groups)),
+	}
+	for i, g := range groups {
+		params.RoomTypes[i] = *g.RoomType
+		params.Styles[i] = styles[*g.RoomType]
+		params.Labels[i] = g.Label
+	}
+	if err := q.CreateProjectRooms(ctx, params); err != nil {
+		return fmt.Errorf("failed to save project rooms: %w", err)
+	}
+	return nil
+}
+
+// validateGroups checks groups to be confirmed, trimming labels in place, and returns each
+// group's image IDs. An image may be in one group at most.
+func validateGroups(groups []Group) ([][]pgtype.UUID, error) {
+	if len(groups) > project.MaxRooms {
+		return nil, fmt.Errorf("%w: groups must contain at most %d groups", ErrInvalid, project.MaxRooms)
+	}
+	ids := make([][]pgtype.UUID, len(groups))
+	seen := make(map[string]bool)
+	for i := range groups {
+		g := &groups[i]
+		if g.RoomType == nil || !slices.Contains(preset.RoomTypes, *g.RoomType) {
+			return nil, fmt.Errorf("%w: groups[%d].room_type must be one of %s",
+				ErrInvalid, i, strings.Join(preset.RoomTypes, ", "))
+		}
+		g.Label = strings.TrimSpace(g.Label)
+		if utf8.RuneCountInString(g.Label) > project.MaxRoomLabelLength {
+			return nil, fmt.Errorf("%w: groups[%d].label must be %d characters or less",
+				ErrInvalid, i, project.MaxRoomLabelLength)
+		}
+		if g.ImageIDs == nil {
+			g.ImageIDs = []string{}
+		}
+		for _, imageID := range g.ImageIDs {
+			id, err := parseUUID(imageID)
+			if err != nil {
+				return nil, fmt.Errorf("%w: groups[%d].image_ids has an invalid image ID", ErrInvalid, i)
+			}
+			if seen[imageID] {
+				return nil, fmt.Errorf("%w: image %s is in more than one group", ErrInvalid, imageID)
+			}
+			seen[imageID] = true
+			ids[i] = append(ids[i], id)
+		}
+	}
+	return ids, nil
+}
+
+// inTx runs fn in a transaction when the service has a database, and on the querier
+// otherwise.
+func (s *DefaultService) inTx(ctx context.Context, fn func(q queries.Querier) error) error {
+	if s.db == nil {
+		return fn(s.querier)
+	}
+	return s.db.WithTx(ctx, func(tx storage.Database) error {
+		return fn(queries.New(tx))
+	})
+}
+
+// ownedProject returns the project ID when the project is the user's.
+func (s *DefaultService) ownedProject(ctx context.Context, userID, projectID string) (pgtype.UUID, error) {
+	pid, err := parseUUID(projectID)
+	if err != nil {
+		return pgtype.UUID{}, ErrNotFound
+	}
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf("invalid user ID: %w", err)
+	}
+	_, err = s.querier.GetProjectByIDAndUserID(ctx, queries.GetProjectByIDAndUserIDParams{ID: pid, UserID: uid})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return pgtype.UUID{}, ErrNotFound
+	}
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf("failed to get project: %w", err)
+	}
+	return pid, nil
+}
+
+// grouping returns one of the project's groupings.
+func (s *DefaultService) grouping(
+	ctx context.Context, projectID pgtype.UUID, groupingID string,
+) (*queries.RoomGrouping, error) {
+	gid, err := parseUUID(groupingID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	row, err := s.querier.GetRoomGrouping(ctx, queries.GetRoomGroupingParams{ID: gid, ProjectID: projectID})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room grouping: %w", err)
+	}
+	return row, nil
+}
+
+func parseUUID(id string) (pgtype.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+	return pgtype.UUID{Bytes: parsed, Valid: true}, nil
+}
+
+func toGrouping(row *queries.RoomGrouping) (*Grouping, error) {
+	g := &Grouping{
+		ID:                 uuid.UUID(row.ID.Bytes).String(),
+		ProjectID:          uuid.UUID(row.ProjectID.Bytes).String(),
+		Status:             row.Status,
+		Groups:             []Group{},
+		UnreadableImageIDs: make([]string, 0, len(row.UnreadableImageIds)),
+		CreatedAt:          row.CreatedAt.Time,
+		UpdatedAt:          row.UpdatedAt.Time,
+	}
+	if len(row.Groups) > 0 {
+		if err := json.Unmarshal(row.Groups, &g.Groups); err != nil {
+			return nil, fmt.Errorf("failed to decode room groups: %w", err)
+		}
+	}
+	for _, id := range row.UnreadableImageIds {
+		g.UnreadableImageIDs = append(g.UnreadableImageIDs, uuid.UUID(id.Bytes).String())
+	}
+	if row.Error.Valid {
+		g.Error = &row.Error.String
+	}
+	if row.ConfirmedAt.Valid {
+		t := row.ConfirmedAt.Time
+		g.ConfirmedAt = &t
+	}
+	return g, nil
+}
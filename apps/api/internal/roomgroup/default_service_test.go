@@ -0,0 +1,323 @@
+package roomgroup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+type fixture struct {
+	userID     string
+	projectID  uuid.UUID
+	groupingID uuid.UUID
+	status     string
+	groups     string
+	querier    *queries.QuerierMock
+	enqueuer   *queue.RoomGroupEnqueuerMock
+	svc        *DefaultService
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	f := &fixture{
+		userID:     uuid.NewString(),
+		projectID:  uuid.New(),
+		groupingID: uuid.New(),
+		status:     StatusReady,
+		groups:     `[{"room_type":"bedroom","label":"Bedroom 1","image_ids":["` + imageA + `","` + imageB + `"]}]`,
+	}
+	f.querier = &queries.QuerierMock{
+		GetProjectByIDAndUserIDFunc: func(
+			ctx context.Context, arg queries.GetProjectByIDAndUserIDParams,
+		) (*queries.GetProjectByIDAndUserIDRow, error) {
+			if arg.ID.Bytes != f.projectID || uuid.UUID(arg.UserID.Bytes).String() != f.userID {
+				return nil, pgx.ErrNoRows
+			}
+			return &queries.GetProjectByIDAndUserIDRow{ID: arg.ID, UserID: arg.UserID}, nil
+		},
+		GetRoomGroupingFunc: func(ctx context.Context, arg queries.GetRoomGroupingParams) (*queries.RoomGrouping, error) {
+			if arg.ID.Bytes != f.groupingID {
+				return nil, pgx.ErrNoRows
+			}
+			return f.row(f.status), nil
+		},
+		CountProjectImagesFunc: func(ctx context.Context, projectID pgtype.UUID) (int64, error) {
+			return 3, nil
+		},
+		HasActiveRoomGroupingFunc: func(ctx context.Context, projectID pgtype.UUID) (bool, error) {
+			return false, nil
+		},
+		CreateRoomGroupingFunc: func(ctx context.Context, projectID pgtype.UUID) (*queries.RoomGrouping, error) {
+			row := f.row(StatusQueued)
+			row.Groups = []byte("[]")
+			return row, nil
+		},
+		FailRoomGroupingFunc: func(ctx context.Context, arg queries.FailRoomGroupingParams) error {
+			return nil
+		},
+		SetProjectImagesRoomTypeFunc: func(ctx context.Context, arg queries.SetProjectImagesRoomTypeParams) (int64, error) {
+			return int64(len(arg.ImageIds)), nil
+		},
+		ListProjectRoomsFunc: func(ctx context.Context, projectID pgtype.UUID) ([]*queries.ProjectRoom, error) {
+			return []*queries.ProjectRoom{
+				{RoomType: "kitchen", Position: 1},
+				{RoomType: "bedroom", Position: 2, Style: pgtype.Text{String: "scandinavian", Valid: true}},
+			}, nil
+		},
+		DeleteProjectRoomsFunc: func(ctx context.Context, projectID pgtype.UUID) error {
+			return nil
+		},
+		CreateProjectRoomsFunc: func(ctx context.Context, arg queries.CreateProjectRoomsParams) error {
+			return nil
+		},
+		ConfirmRoomGroupingFunc: func(ctx context.Context, arg queries.ConfirmRoomGroupingParams) (*queries.RoomGrouping, error) {
+			row := f.row(StatusConfirmed)
+			row.Groups = arg.Groups
+			return row, nil
+		},
+	}
+	f.enqueuer = &queue.RoomGroupEnqueuerMock{
+		EnqueueRoomGroupFunc: func(ctx context.Context, payload queue.RoomGroupPayload) (string, error) {
+			return payload.GroupingID, nil
+		},
+	}
+	f.svc = NewDefaultServiceWithQuerier(f.querier, f.enqueuer)
+	return f
+}
+
+const (
+	imageA = "0a6f1c2e-1111-4c6a-9d2e-000000000001"
+	imageB = "0a6f1c2e-1111-4c6a-9d2e-000000000002"
+	imageC = "0a6f1c2e-1111-4c6a-9d2e-000000000003"
+)
+
+func (f *fixture) row(status string) *queries.RoomGrouping {
+	return &queries.RoomGrouping{
+		ID:        pgtype.UUID{Bytes: f.groupingID, Valid: true},
+		ProjectID: pgtype.UUID{Bytes: f.projectID, Valid: true},
+		Status:    status,
+		Groups:    []byte(f.groups),
+		UnreadableImageIds: []pgtype.UUID{
+			{Bytes: uuid.MustParse(imageC), Valid: true},
+		},
+	}
+}
+
+func TestDefaultService_Create(t *testing.T) {
+	testCases := []struct {
+		name       string
+		setup      func(f *fixture)
+		projectID  func(f *fixture) string
+		wantErr    error
+		wantFailed bool
+	}{
+		{name: "success: queued"},
+		{
+			name:      "fail: not the user's project",
+			projectID: func(f *fixture) string { return uuid.NewString() },
+			wantErr:   ErrNotFound,
+		},
+		{
+			name: "fail: project has no images",
+			setup: func(f *fixture) {
+				f.querier.CountProjectImagesFunc = func(ctx context.Context, projectID pgtype.UUID) (int64, error) {
+					return 0, nil
+				}
+			},
+			wantErr: ErrInvalid,
+		},
+		{
+			name: "fail: grouping in progress",
+			setup: func(f *fixture) {
+				f.querier.HasActiveRoomGroupingFunc = func(ctx context.Context, projectID pgtype.UUID) (bool, error) {
+					return true, nil
+				}
+			},
+			wantErr: ErrInProgress,
+		},
+		{
+			name: "fail: enqueue error marks the grouping failed",
+			setup: func(f *fixture) {
+				f.enqueuer.EnqueueRoomGroupFunc = func(ctx context.Context, payload queue.RoomGroupPayload) (string, error) {
+					return "", errors.New("redis down")
+				}
+			},
+			wantFailed: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFixture(t)
+			if tc.setup != nil {
+				tc.setup(f)
+			}
+			projectID := f.projectID.String()
+			if tc.projectID != nil {
+				projectID = tc.projectID(f)
+			}
+
+			got, err := f.svc.Create(context.Background(), f.userID, projectID)
+			switch {
+			case tc.wantErr != nil:
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, f.querier.CreateRoomGroupingCalls())
+			case tc.wantFailed:
+				require.Error(t, err)
+				require.Len(t, f.querier.FailRoomGroupingCalls(), 1)
+				assert.Equal(t, f.groupingID, uuid.UUID(f.querier.FailRoomGroupingCalls()[0].Arg.ID.Bytes))
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, StatusQueued, got.Status)
+				assert.Equal(t, []Group{}, got.Groups)
+				require.Len(t, f.enqueuer.EnqueueRoomGroupCalls(), 1)
+				assert.Equal(t, queue.RoomGroupPayload{
+					GroupingID: f.groupingID.String(),
+					ProjectID:  f.projectID.String(),
+				}, f.enqueuer.EnqueueRoomGroupCalls()[0].Payload)
+			}
+		})
+	}
+}
+
+func TestDefaultService_GetAndLatest(t *testing.T) {
+	f := newFixture(t)
+	f.querier.GetLatestRoomGroupingFunc = func(ctx context.Context, projectID pgtype.UUID) (*queries.RoomGrouping, error) {
+		return f.row(StatusReady), nil
+	}
+
+	got, err := f.svc.Get(context.Background(), f.userID, f.projectID.String(), f.groupingID.String())
+	require.NoError(t, err)
+	bedroom := "bedroom"
+	assert.Equal(t, []Group{{RoomType: &bedroom, Label: "Bedroom 1", ImageIDs: []string{imageA, imageB}}}, got.Groups)
+	assert.Equal(t, []string{imageC}, got.UnreadableImageIDs)
+
+	latest, err := f.svc.Latest(context.Background(), f.userID, f.projectID.String())
+	require.NoError(t, err)
+	assert.Equal(t, got, latest)
+
+	_, err = f.svc.Get(context.Background(), f.userID, f.projectID.String(), uuid.NewString())
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	f.querier.GetLatestRoomGroupingFunc = func(ctx context.Context, projectID pgtype.UUID) (*queries.RoomGrouping, error) {
+		return nil, pgx.ErrNoRows
+	}
+	_, err = f.svc.Latest(context.Background(), f.userID, f.projectID.String())
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestDefaultService_Confirm(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	testCases := []struct {
+		name          string
+		status        string
+		groups        string
+		req           ConfirmRequest
+		confirmErr    error
+		wantErr       error
+		wantRoomTypes []string
+		wantStyles    []string
+		wantLabels    []string
+		wantUpdates   int
+	}{
+		{
+			name:          "success: proposal as is",
+			wantRoomTypes: []string{"bedroom"},
+			wantStyles:    []string{"scandinavian"},
+			wantLabels:    []string{"Bedroom 1"},
+			wantUpdates:   1,
+		},
+		{
+			name: "success: edited groups",
+			req: ConfirmRequest{Groups: &[]Group{
+				{RoomType: str("kitchen"), Label: "  ", ImageIDs: []string{imageA}},
+				{RoomType: str("bedroom"), Label: "Primary bedroom", ImageIDs: []string{imageB, imageC}},
+				{RoomType: str("bathroom")},
+			}},
+			wantRoomTypes: []string{"kitchen", "bedroom", "bathroom"},
+			wantStyles:    []string{"", "scandinavian", ""},
+			wantLabels:    []string{"", "Primary bedroom", ""},
+			wantUpdates:   2,
+		},
+		{
+			name:    "fail: grouping still processing",
+			status:  StatusProcessing,
+			wantErr: ErrNotReady,
+		},
+		{
+			name:    "fail: proposed group without a room type",
+			groups:  `[{"room_type":null,"label":"Room 1","image_ids":["` + imageA + `"]}]`,
+			wantErr: ErrInvalid,
+		},
+		{
+			name: "fail: image in two groups",
+			req: ConfirmRequest{Groups: &[]Group{
+				{RoomType: str("kitchen"), ImageIDs: []string{imageA}},
+				{RoomType: str("bedroom"), ImageIDs: []string{imageA}},
+			}},
+			wantErr: ErrInvalid,
+		},
+		{
+			name:    "fail: unknown room type",
+			req:     ConfirmRequest{Groups: &[]Group{{RoomType: str("garage")}}},
+			wantErr: ErrInvalid,
+		},
+		{
+			name:       "fail: confirmed concurrently",
+			confirmErr: pgx.ErrNoRows,
+			wantErr:    ErrNotReady,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFixture(t)
+			if tc.status != "" {
+				f.status = tc.status
+			}
+			if tc.groups != "" {
+				f.groups = tc.groups
+			}
+			if tc.confirmErr != nil {
+				f.querier.ConfirmRoomGroupingFunc = func(
+					ctx context.Context, arg queries.ConfirmRoomGroupingParams,
+				) (*queries.RoomGrouping, error) {
+					return nil, tc.confirmErr
+				}
+			}
+
+			got, err := f.svc.Confirm(context.Background(), f.userID, f.projectID.String(), f.groupingID.String(), tc.req)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				if tc.confirmErr == nil {
+					assert.Empty(t, f.querier.CreateProjectRoomsCalls())
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, StatusConfirmed, got.Status)
+			assert.Len(t, f.querier.SetProjectImagesRoomTypeCalls(), tc.wantUpdates)
+
+			require.Len(t, f.querier.CreateProjectRoomsCalls(), 1)
+			rooms := f.querier.CreateProjectRoomsCalls()[0].Arg
+			assert.Equal(t, tc.wantRoomTypes, rooms.RoomTypes)
+			assert.Equal(t, tc.wantStyles, rooms.Styles)
+			assert.Equal(t, tc.wantLabels, rooms.Labels)
+
+			var stored []Group
+			require.NoError(t, json.Unmarshal(f.querier.ConfirmRoomGroupingCalls()[0].Arg.Groups, &stored))
+			assert.Equal(t, got.Groups, stored)
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package roomgroup
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP-level contract for room groupings.
+// Implementations should be wired to Echo routes in the server.
+type Handler interface {
+	Create(c echo.Context) error
+	Get(c echo.Context) error
+	Latest(c echo.Context) error
+	Confirm(c echo.Context) error
+}
@@ -0,0 +1,63 @@
+// Package roomgroup proposes groupings of a project's uploads into rooms. A worker clusters
+// the photos by visual similarity, keeping apart photos already given different room
+// types; the owner reviews the groups, adjusts them if needed and confirms them, which sets
+// the photos' room types and replaces the project's room checklist.
+package roomgroup
+
+import (
+	"errors"
+	"time"
+)
+
+// Grouping statuses.
+const (
+	StatusQueued     = "queued"
+	StatusProcessing = "processing"
+	StatusReady      = "ready"
+	StatusError      = "error"
+	StatusConfirmed  = "confirmed"
+)
+
+var (
+	// ErrNotFound is returned when the project or the grouping does not exist or is not the
+	// user's.
+	ErrNotFound = errors.New("room grouping not found")
+	// ErrInvalid wraps validation failures of a request.
+	ErrInvalid = errors.New("invalid room grouping")
+	// ErrInProgress is returned when a grouping of the project is already queued or running.
+	ErrInProgress = errors.New("room grouping already in progress")
+	// ErrNotReady is returned when confirming a grouping that is not ready, or was already
+	// confirmed.
+	ErrNotReady = errors.New("room grouping is not ready to confirm")
+)
+
+// Grouping is a proposed grouping of a project's images into rooms.
+type Grouping struct {
+	ID        string  `json:"id"`
+	ProjectID string  `json:"project_id"`
+	Status    string  `json:"status"`
+	Groups    []Group `json:"groups"`
+	// UnreadableImageIDs are images the worker could not read, left out of every group.
+	UnreadableImageIDs []string   `json:"unreadable_image_ids"`
+	Error              *string    `json:"error,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+	ConfirmedAt        *time.Time `json:"confirmed_at,omitempty"`
+}
+
+// Group is one room of a grouping and the images taken in it. Its JSON form is also how
+// it is stored.
+type Group struct {
+	// RoomType is the room type most of the group's images already had. Nil when none had
+	// one; it must be set before the grouping is confirmed.
+	RoomType *string `json:"room_type"`
+	// Label tells apart groups of the same room type, e.g. "Bedroom 2".
+	Label    string   `json:"label"`
+	ImageIDs []string `json:"image_ids"`
+}
+
+// ConfirmRequest applies a grouping to its project. Omitted groups apply the grouping as
+// proposed.
+type ConfirmRequest struct {
+	Groups *[]Group `json:"groups"`
+}
@@ -0,0 +1,17 @@
+package roomgroup
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service proposes and applies room groupings of a user's projects.
+type Service interface {
+	// Create records a grouping of the project and queues its analysis.
+	Create(ctx context.Context, userID, projectID string) (*Grouping, error)
+	// Get returns one of the project's groupings.
+	Get(ctx context.Context, userID, projectID, groupingID string) (*Grouping, error)
+	// Latest returns the project's most recent grouping.
+	Latest(ctx context.Context, userID, projectID string) (*Grouping, error)
+	// Confirm applies a ready grouping to the project.
+	Confirm(ctx context.Context, userID, projectID, groupingID string, req ConfirmRequest) (*Grouping, error)
+}
@@ -0,0 +1,340 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// organizationKey is the context key Authenticate stores the organization ID under.
+const organizationKey = "scim_organization_id"
+
+// DefaultHandler serves SCIM over HTTP.
+type DefaultHandler struct {
+	service Service
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service) *DefaultHandler {
+	return &DefaultHandler{service: service}
+}
+
+// ServiceProviderConfigResponse describes the features served.
+type ServiceProviderConfigResponse struct {
+	Schemas               []string               `json:"schemas"`
+	Patch                 Supported              `json:"patch"`
+	Bulk                  BulkSupport            `json:"bulk"`
+	Filter                FilterSupport          `json:"filter"`
+	ChangePassword        Supported              `json:"changePassword"`
+	Sort                  Supported              `json:"sort"`
+	ETag                  Supported              `json:"etag"`
+	AuthenticationSchemes []AuthenticationScheme `json:"authenticationSchemes"`
+}
+
+// Supported reports whether a feature is served.
+type Supported struct {
+	Supported bool `json:"supported"`
+}
+
+// BulkSupport reports bulk operation support.
+type BulkSupport struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+// FilterSupport reports filter support.
+type FilterSupport struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+// AuthenticationScheme is a way clients authenticate.
+type AuthenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Primary     bool   `json:"primary"`
+}
+
+// Authenticate resolves the bearer token to an organization and rejects the request when it
+// does not resolve.
+func (h *DefaultHandler) Authenticate(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		scheme, token, ok := strings.Cut(c.Request().Header.Get(echo.HeaderAuthorization), " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") {
+			token = ""
+		}
+		orgID, err := h.service.Authenticate(c.Request().Context(), token)
+		if err != nil {
+			if errors.Is(err, ErrUnauthorized) {
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+			}
+			return h.writeError(c, err)
+		}
+		c.Set(organizationKey, orgID)
+		return next(c)
+	}
+}
+
+// ServiceProviderConfig handles GET /scim/v2/ServiceProviderConfig.
+func (h *DefaultHandler) ServiceProviderConfig(c echo.Context) error {
+	return respond(c, http.StatusOK, ServiceProviderConfigResponse{
+		Schemas:        []string{SchemaServiceProviderConfig},
+		Patch:          Supported{Supported: true},
+		Filter:         FilterSupport{Supported: true, MaxResults: maxCount},
+		ChangePassword: Supported{},
+		Sort:           Supported{},
+		ETag:           Supported{},
+		AuthenticationSchemes: []AuthenticationScheme{{
+			Type:        "oauthbearertoken",
+			Name:        "OAuth Bearer Token",
+			Description: "The organization's SCIM token",
+			Primary:     true,
+		}},
+	})
+}
+
+// ListUsers handles GET /scim/v2/Users.
+func (h *DefaultHandler) ListUsers(c echo.Context) error {
+	q, err := listQuery(c)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	res, err := h.service.ListUsers(c.Request().Context(), orgID(c), q)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	for _, u := range res.Resources {
+		setLocation(c, u.Meta, "Users", u.ID)
+	}
+	return respond(c, http.StatusOK, res)
+}
+
+// GetUser handles GET /scim/v2/Users/:id.
+func (h *DefaultHandler) GetUser(c echo.Context) error {
+	u, err := h.service.GetUser(c.Request().Context(), orgID(c), c.Param("id"))
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return h.writeUser(c, http.StatusOK, u)
+}
+
+// CreateUser handles POST /scim/v2/Users.
+func (h *DefaultHandler) CreateUser(c echo.Context) error {
+	var req User
+	if err := decode(c, &req); err != nil {
+		return h.writeError(c, err)
+	}
+	u, err := h.service.CreateUser(c.Request().Context(), orgID(c), &req)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return h.writeUser(c, http.StatusCreated, u)
+}
+
+// ReplaceUser handles PUT /scim/v2/Users/:id.
+func (h *DefaultHandler) ReplaceUser(c echo.Context) error {
+	var req User
+	if err := decode(c, &req); err != nil {
+		return h.writeError(c, err)
+	}
+	u, err := h.service.ReplaceUser(c.Request().Context(), orgID(c), c.Param("id"), &req)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return h.writeUser(c, http.StatusOK, u)
+}
+
+// PatchUser handles PATCH /scim/v2/Users/:id.
+func (h *DefaultHandler) PatchUser(c echo.Context) error {
+	var req PatchRequest
+	if err := decode(c, &req); err != nil {
+		return h.writeError(c, err)
+	}
+	u, err := h.service.PatchUser(c.Request().Context(), orgID(c), c.Param("id"), req)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return h.writeUser(c, http.StatusOK, u)
+}
+
+// DeleteUser handles DELETE /scim/v2/Users/:id.
+func (h *DefaultHandler) DeleteUser(c echo.Context) error {
+	if err := h.service.DeleteUser(c.Request().Context(), orgID(c), c.Param("id")); err != nil {
+		return h.writeError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListGroups handles GET /scim/v2/Groups.
+func (h *DefaultHandler) ListGroups(c echo.Context) error {
+	q, err := listQuery(c)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	res, err := h.service.ListGroups(c.Request().Context(), orgID(c), q)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	for _, g := range res.Resources {
+		setLocation(c, g.Meta, "Groups", g.ID)
+	}
+	return respond(c, http.StatusOK, res)
+}
+
+// GetGroup handles GET /scim/v2/Groups/:id.
+func (h *DefaultHandler) GetGroup(c echo.Context) error {
+	g, err := h.service.GetGroup(c.Request().Context(), orgID(c), c.Param("id"))
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return h.writeGroup(c, http.StatusOK, g)
+}
+
+// CreateGroup handles POST /scim/v2/Groups.
+func (h *DefaultHandler) CreateGroup(c echo.Context) error {
+	var req Group
+	if err := decode(c, &req); err != nil {
+		return h.writeError(c, err)
+	}
+	g, err := h.service.CreateGroup(c.Request().Context(), orgID(c), &req)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return h.writeGroup(c, http.StatusCreated, g)
+}
+
+// ReplaceGroup handles PUT /scim/v2/Groups/:id.
+func (h *DefaultHandler) ReplaceGroup(c echo.Context) error {
+	var req Group
+	if err := decode(c, &req); err != nil {
+		return h.writeError(c, err)
+	}
+	g, err := h.service.ReplaceGroup(c.Request().Context(), orgID(c), c.Param("id"), &req)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return h.writeGroup(c, http.StatusOK, g)
+}
+
+// PatchGroup handles PATCH /scim/v2/Groups/:id.
+func (h *DefaultHandler) PatchGroup(c echo.Context) error {
+	var req PatchRequest
+	if err := decode(c, &req); err != nil {
+		return h.writeError(c, err)
+	}
+	g, err := h.service.PatchGroup(c.Request().Context(), orgID(c), c.Param("id"), req)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return h.writeGroup(c, http.StatusOK, g)
+}
+
+// DeleteGroup handles DELETE /scim/v2/Groups/:id.
+func (h *DefaultHandler) DeleteGroup(c echo.Context) error {
+	if err := h.service.DeleteGroup(c.Request().Context(), orgID(c), c.Param("id")); err != nil {
+		return h.writeError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *DefaultHandler) writeUser(c echo.Context, status int, u *User) error {
+	setLocation(c, u.Meta, "Users", u.ID)
+	return respond(c, status, u)
+}
+
+func (h *DefaultHandler) writeGroup(c echo.Context, status int, g *Group) error {
+	setLocation(c, g.Meta, "Groups", g.ID)
+	return respond(c, status, g)
+}
+
+// writeError maps service errors to SCIM error responses.
+func (h *DefaultHandler) writeError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, ErrUnauthorized):
+		return scimError(c, http.StatusUnauthorized, "", err.Error())
+	case errors.Is(err, ErrNotAllowed):
+		return scimError(c, http.StatusForbidden, "", err.Error())
+	case errors.Is(err, ErrNotFound):
+		return scimError(c, http.StatusNotFound, "", err.Error())
+	case errors.Is(err, ErrUniqueness):
+		return scimError(c, http.StatusConflict, "uniqueness", err.Error())
+	case errors.Is(err, ErrInvalidValue):
+		return scimError(c, http.StatusBadRequest, "invalidValue", err.Error())
+	case errors.Is(err, ErrInvalidFilter):
+		return scimError(c, http.StatusBadRequest, "invalidFilter", err.Error())
+	case errors.Is(err, ErrInvalidPath):
+		return scimError(c, http.StatusBadRequest, "invalidPath", err.Error())
+	default:
+		c.Logger().Errorf("SCIM request failed: %v", err)
+		return scimError(c, http.StatusInternalServerError, "", "Failed to process SCIM request")
+	}
+}
+
+func scimError(c echo.Context, status int, scimType, detail string) error {
+	return respond(c, status, ErrorResponse{
+		Schemas:  []string{SchemaError},
+		Status:   strconv.Itoa(status),
+		SCIMType: scimType,
+		Detail:   detail,
+	})
+}
+
+// respond writes v as application/scim+json.
+func respond(c echo.Context, status int, v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.Blob(status, MIMEApplicationSCIM, body)
+}
+
+// decode reads a JSON request body. Clients send application/scim+json, which echo does not
+// bind.
+func decode(c echo.Context, v any) error {
+	if err := json.NewDecoder(c.Request().Body).Decode(v); err != nil {
+		return fmt.Errorf("%w: malformed request body", ErrInvalidValue)
+	}
+	return nil
+}
+
+// listQuery reads the filter and pagination parameters of a list request.
+func listQuery(c echo.Context) (ListQuery, error) {
+	q := ListQuery{Filter: c.QueryParam("filter"), StartIndex: 1, Count: defaultCount}
+	if raw := c.QueryParam("startIndex"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return q, fmt.Errorf("%w: startIndex must be an integer", ErrInvalidValue)
+		}
+		q.StartIndex = n
+	}
+	if raw := c.QueryParam("count"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return q, fmt.Errorf("%w: count must be an integer", ErrInvalidValue)
+		}
+		q.Count = n
+	}
+	return q, nil
+}
+
+func orgID(c echo.Context) string {
+	id, _ := c.Get(organizationKey).(string)
+	return id
+}
+
+func setLocation(c echo.Context, meta *Meta, resource, id string) {
+	if meta == nil {
+		return
+	}
+	meta.Location = c.Scheme() + "://" + c.Request().Host + "/scim/v2/" + resource + "/" + id
+}
@@ -0,0 +1,174 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newContext(method, target, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, MIMEApplicationSCIM)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set(organizationKey, "org-1")
+	return c, rec
+}
+
+func TestDefaultHandler_Authenticate(t *testing.T) {
+	testCases := []struct {
+		name           string
+		header         string
+		err            error
+		expectedToken  string
+		expectedStatus int
+	}{
+		{name: "success: bearer token", header: "Bearer scim_abc", expectedToken: "scim_abc", expectedStatus: http.StatusOK},
+		{name: "fail: other scheme", header: "Basic scim_abc", err: ErrUnauthorized, expectedStatus: http.StatusUnauthorized},
+		{name: "fail: unknown token", header: "Bearer scim_x", expectedToken: "scim_x", err: ErrUnauthorized, expectedStatus: http.StatusUnauthorized},
+		{name: "fail: plan without SSO", header: "Bearer scim_abc", expectedToken: "scim_abc", err: ErrNotAllowed, expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				AuthenticateFunc: func(ctx context.Context, token string) (string, error) {
+					assert.Equal(t, tc.expectedToken, token)
+					if tc.err != nil {
+						return "", tc.err
+					}
+					return "org-1", nil
+				},
+			}
+			h := NewDefaultHandler(svc)
+			c, rec := newContext(http.MethodGet, "/scim/v2/Users", "")
+			c.Request().Header.Set(echo.HeaderAuthorization, tc.header)
+
+			err := h.Authenticate(func(c echo.Context) error {
+				assert.Equal(t, "org-1", orgID(c))
+				return c.NoContent(http.StatusOK)
+			})(c)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusUnauthorized {
+				assert.Equal(t, "Bearer", rec.Header().Get(echo.HeaderWWWAuthenticate))
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_CreateUser(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+		expectedType   string
+	}{
+		{name: "success: created", body: `{"userName":"jane@acme.com"}`, expectedStatus: http.StatusCreated},
+		{name: "fail: malformed body", body: `{`, expectedStatus: http.StatusBadRequest, expectedType: "invalidValue"},
+		{
+			name: "fail: user name taken", body: `{"userName":"jane@acme.com"}`,
+			err: fmt.Errorf("%w: taken", ErrUniqueness), expectedStatus: http.StatusConflict, expectedType: "uniqueness",
+		},
+		{name: "fail: database error", body: `{"userName":"jane@acme.com"}`, err: errors.New("boom"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				CreateUserFunc: func(ctx context.Context, orgID string, user *User) (*User, error) {
+					assert.Equal(t, "org-1", orgID)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &User{Schemas: []string{SchemaUser}, ID: "u-1", UserName: user.UserName, Meta: &Meta{ResourceType: "User"}}, nil
+				},
+			}
+			h := NewDefaultHandler(svc)
+			c, rec := newContext(http.MethodPost, "/scim/v2/Users", tc.body)
+
+			require.NoError(t, h.CreateUser(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, MIMEApplicationSCIM, rec.Header().Get(echo.HeaderContentType))
+			if tc.expectedStatus == http.StatusCreated {
+				var u User
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &u))
+				assert.Equal(t, "http://example.com/scim/v2/Users/u-1", u.Meta.Location)
+				return
+			}
+			var res ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+			assert.Equal(t, fmt.Sprint(tc.expectedStatus), res.Status)
+			assert.Equal(t, tc.expectedType, res.SCIMType)
+		})
+	}
+}
+
+func TestDefaultHandler_ListUsers(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		expected       ListQuery
+		expectedStatus int
+	}{
+		{name: "success: defaults", expected: ListQuery{StartIndex: 1, Count: defaultCount}, expectedStatus: http.StatusOK},
+		{
+			name:           "success: filter and page",
+			query:          `?filter=userName+eq+"jane"&startIndex=3&count=0`,
+			expected:       ListQuery{Filter: `userName eq "jane"`, StartIndex: 3},
+			expectedStatus: http.StatusOK,
+		},
+		{name: "fail: malformed count", query: "?count=many", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				ListUsersFunc: func(ctx context.Context, orgID string, q ListQuery) (*ListResponse[*User], error) {
+					assert.Equal(t, tc.expected, q)
+					return &ListResponse[*User]{Schemas: []string{SchemaListResponse}, Resources: []*User{}}, nil
+				},
+			}
+			h := NewDefaultHandler(svc)
+			c, rec := newContext(http.MethodGet, "/scim/v2/Users"+tc.query, "")
+
+			require.NoError(t, h.ListUsers(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
+
+func TestDefaultHandler_DeleteGroup(t *testing.T) {
+	svc := &ServiceMock{
+		DeleteGroupFunc: func(ctx context.Context, orgID, id string) error {
+			if id == "missing" {
+				return ErrNotFound
+			}
+			return nil
+		},
+	}
+	h := NewDefaultHandler(svc)
+
+	c, rec := newContext(http.MethodDelete, "/scim/v2/Groups/g-1", "")
+	c.SetParamNames("id")
+	c.SetParamValues("g-1")
+	require.NoError(t, h.DeleteGroup(c))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	c, rec = newContext(http.MethodDelete, "/scim/v2/Groups/missing", "")
+	c.SetParamNames("id")
+	c.SetParamValues("missing")
+	require.NoError(t, h.DeleteGroup(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
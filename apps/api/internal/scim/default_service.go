@@ -0,0 +1,689 @@
+package scim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/organization"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// DefaultService implements Service.
+type DefaultService struct {
+	querier queries.Querier
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService with a database.
+func NewDefaultService(db storage.Database) *DefaultService {
+	return NewDefaultServiceWithQuerier(queries.New(db))
+}
+
+// NewDefaultServiceWithQuerier creates a new DefaultService with a custom querier (for testing).
+func NewDefaultServiceWithQuerier(querier queries.Querier) *DefaultService {
+	return &DefaultService{querier: querier}
+}
+
+// Authenticate looks the token up by its hash and checks the organization's plan.
+func (s *DefaultService) Authenticate(ctx context.Context, token string) (string, error) {
+	if token == "" {
+		return "", ErrUnauthorized
+	}
+	oid, err := s.querier.TouchOrganizationSCIMToken(ctx, organization.HashSCIMToken(token))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrUnauthorized
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to look up SCIM token: %w", err)
+	}
+	allowed, err := s.querier.GetOrganizationSSOAccess(ctx, oid)
+	if err != nil {
+		return "", fmt.Errorf("failed to check SSO access: %w", err)
+	}
+	if !allowed {
+		return "", ErrNotAllowed
+	}
+	return oid.String(), nil
+}
+
+// ListUsers returns a page of users.
+func (s *DefaultService) ListUsers(ctx context.Context, orgID string, q ListQuery) (*ListResponse[*User], error) {
+	oid, err := parseUUID(orgID)
+	if err != nil {
+		return nil, err
+	}
+	f, err := parseFilter(q.Filter, "userName", "externalId")
+	if err != nil {
+		return nil, err
+	}
+	var userName, externalID pgtype.Text
+	if f != nil && f.attribute == "username" {
+		userName = pgtype.Text{String: f.value, Valid: true}
+	} else if f != nil {
+		externalID = pgtype.Text{String: f.value, Valid: true}
+	}
+
+	start, count, offset := q.page()
+	total, err := s.querier.CountSCIMUsers(ctx, queries.CountSCIMUsersParams{
+		OrganizationID: oid,
+		UserName:       userName,
+		ExternalID:     externalID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count SCIM users: %w", err)
+	}
+	users := []*User{}
+	if count > 0 {
+		rows, err := s.querier.ListSCIMUsers(ctx, queries.ListSCIMUsersParams{
+			OrganizationID: oid,
+			UserName:       userName,
+			ExternalID:     externalID,
+			Limit:          int32(count),
+			Offset:         int32(offset),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list SCIM users: %w", err)
+		}
+		for _, row := range rows {
+			users = append(users, userFromRow(row))
+		}
+	}
+	return newListResponse(users, total, start), nil
+}
+
+// GetUser returns a user.
+func (s *DefaultService) GetUser(ctx context.Context, orgID, id string) (*User, error) {
+	row, err := s.getUser(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	return userFromRow(row), nil
+}
+
+// CreateUser creates a user with a new backing account.
+func (s *DefaultService) CreateUser(ctx context.Context, orgID string, user *User) (*User, error) {
+	oid, err := parseUUID(orgID)
+	if err != nil {
+		return nil, err
+	}
+	f := fieldsFromUser(user)
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+	row, err := s.querier.CreateSCIMUser(ctx, queries.CreateSCIMUserParams{
+		ID:             pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		OrganizationID: oid,
+		UserName:       f.userName,
+		ExternalID:     optionalText(f.externalID),
+		DisplayName:    f.displayName,
+		GivenName:      f.givenName,
+		FamilyName:     f.familyName,
+		Email:          f.email,
+		Active:         f.active,
+	})
+	if isUniqueViolation(err) {
+		return nil, fmt.Errorf("%w: userName %q is taken", ErrUniqueness, f.userName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SCIM user: %w", err)
+	}
+	if err := s.syncMember(ctx, row); err != nil {
+		return nil, err
+	}
+	return userFromRow(row), nil
+}
+
+// ReplaceUser replaces a user's attributes.
+func (s *DefaultService) ReplaceUser(ctx context.Context, orgID, id string, user *User) (*User, error) {
+	row, err := s.getUser(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateUser(ctx, row, fieldsFromUser(user))
+}
+
+// PatchUser applies req's operations in order.
+func (s *DefaultService) PatchUser(ctx context.Context, orgID, id string, req PatchRequest) (*User, error) {
+	row, err := s.getUser(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	f := userFields{
+		userName:    row.UserName,
+		externalID:  row.ExternalID.String,
+		displayName: row.DisplayName,
+		givenName:   row.GivenName,
+		familyName:  row.FamilyName,
+		email:       row.Email,
+		active:      row.Active,
+	}
+	for _, op := range req.Operations {
+		if err := f.apply(op); err != nil {
+			return nil, err
+		}
+	}
+	return s.updateUser(ctx, row, f)
+}
+
+// DeleteUser deletes a user; their account goes too unless they signed in.
+func (s *DefaultService) DeleteUser(ctx context.Context, orgID, id string) error {
+	oid, err := parseUUID(orgID)
+	if err != nil {
+		return err
+	}
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return ErrNotFound
+	}
+	n, err := s.querier.DeleteSCIMUser(ctx, queries.DeleteSCIMUserParams{
+		OrganizationID: oid,
+		ID:             pgtype.UUID{Bytes: uid, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete SCIM user: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ListGroups returns a page of groups with their members.
+func (s *DefaultService) ListGroups(ctx context.Context, orgID string, q ListQuery) (*ListResponse[*Group], error) {
+	oid, err := parseUUID(orgID)
+	if err != nil {
+		return nil, err
+	}
+	f, err := parseFilter(q.Filter, "displayName", "externalId")
+	if err != nil {
+		return nil, err
+	}
+	var displayName, externalID pgtype.Text
+	if f != nil && f.attribute == "displayname" {
+		displayName = pgtype.Text{String: f.value, Valid: true}
+	} else if f != nil {
+		externalID = pgtype.Text{String: f.value, Valid: true}
+	}
+
+	start, count, offset := q.page()
+	total, err := s.querier.CountSCIMGroups(ctx, queries.CountSCIMGroupsParams{
+		OrganizationID: oid,
+		DisplayName:    displayName,
+		ExternalID:     externalID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count SCIM groups: %w", err)
+	}
+	groups := []*Group{}
+	if count > 0 {
+		rows, err := s.querier.ListSCIMGroups(ctx, queries.ListSCIMGroupsParams{
+			OrganizationID: oid,
+			DisplayName:    displayName,
+			ExternalID:     externalID,
+			Limit:          int32(count),
+			Offset:         int32(offset),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list SCIM groups: %w", err)
+		}
+		groups, err = s.withMembers(ctx, rows...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return newListResponse(groups, total, start), nil
+}
+
+// GetGroup returns a group with its members.
+func (s *DefaultService) GetGroup(ctx context.Context, orgID, id string) (*Group, error) {
+	row, err := s.getGroup(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := s.withMembers(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	return groups[0], nil
+}
+
+// CreateGroup creates a group with the given members.
+func (s *DefaultService) CreateGroup(ctx context.Context, orgID string, group *Group) (*Group, error) {
+	oid, err := parseUUID(orgID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateDisplayName(group.DisplayName); err != nil {
+		return nil, err
+	}
+	ids, err := parseMembers(group.Members)
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.querier.CreateSCIMGroup(ctx, queries.CreateSCIMGroupParams{
+		OrganizationID: oid,
+		DisplayName:    group.DisplayName,
+		ExternalID:     optionalText(group.ExternalID),
+	})
+	if isUniqueViolation(err) {
+		return nil, fmt.Errorf("%w: displayName %q is taken", ErrUniqueness, group.DisplayName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SCIM group: %w", err)
+	}
+	members := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		members[id] = true
+	}
+	return s.saveGroup(ctx, row, row.DisplayName, row.ExternalID.String, members)
+}
+
+// ReplaceGroup replaces a group's attributes and members.
+func (s *DefaultService) ReplaceGroup(ctx context.Context, orgID, id string, group *Group) (*Group, error) {
+	row, err := s.getGroup(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := parseMembers(group.Members)
+	if err != nil {
+		return nil, err
+	}
+	members := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		members[id] = true
+	}
+	return s.saveGroup(ctx, row, group.DisplayName, group.ExternalID, members)
+}
+
+// PatchGroup applies req's operations in order.
+func (s *DefaultService) PatchGroup(ctx context.Context, orgID, id string, req PatchRequest) (*Group, error) {
+	row, err := s.getGroup(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.memberIDs(ctx, row.ID)
+	if err != nil {
+		return nil, err
+	}
+	change := groupChange{displayName: row.DisplayName, externalID: row.ExternalID.String, members: map[uuid.UUID]bool{}}
+	for id := range current {
+		change.members[id] = true
+	}
+	for _, op := range req.Operations {
+		if err := change.apply(op); err != nil {
+			return nil, err
+		}
+	}
+	return s.saveGroup(ctx, row, change.displayName, change.externalID, change.members)
+}
+
+// DeleteGroup deletes a group.
+func (s *DefaultService) DeleteGroup(ctx context.Context, orgID, id string) error {
+	row, err := s.getGroup(ctx, orgID, id)
+	if err != nil {
+		return err
+	}
+	members, err := s.memberIDs(ctx, row.ID)
+	if err != nil {
+		return err
+	}
+	n, err := s.querier.DeleteSCIMGroup(ctx, queries.DeleteSCIMGroupParams{OrganizationID: row.OrganizationID, ID: row.ID})
+	if err != nil {
+		return fmt.Errorf("failed to delete SCIM group: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return s.syncMembers(ctx, row.OrganizationID, keys(members))
+}
+
+func (s *DefaultService) getUser(ctx context.Context, orgID, id string) (*queries.OrganizationScimUser, error) {
+	oid, err := parseUUID(orgID)
+	if err != nil {
+		return nil, err
+	}
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	row, err := s.querier.GetSCIMUser(ctx, queries.GetSCIMUserParams{
+		OrganizationID: oid,
+		ID:             pgtype.UUID{Bytes: uid, Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SCIM user: %w", err)
+	}
+	return row, nil
+}
+
+func (s *DefaultService) updateUser(ctx context.Context, row *queries.OrganizationScimUser, f userFields) (*User, error) {
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+	updated, err := s.querier.UpdateSCIMUser(ctx, queries.UpdateSCIMUserParams{
+		UserName:       f.userName,
+		ExternalID:     optionalText(f.externalID),
+		DisplayName:    f.displayName,
+		GivenName:      f.givenName,
+		FamilyName:     f.familyName,
+		Email:          f.email,
+		Active:         f.active,
+		OrganizationID: row.OrganizationID,
+		ID:             row.ID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if isUniqueViolation(err) {
+		return nil, fmt.Errorf("%w: userName %q is taken", ErrUniqueness, f.userName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update SCIM user: %w", err)
+	}
+	if err := s.syncMember(ctx, updated); err != nil {
+		return nil, err
+	}
+	return userFromRow(updated), nil
+}
+
+// syncMember makes an active user an organization member with the role their groups map to,
+// and removes an inactive user's membership.
+func (s *DefaultService) syncMember(ctx context.Context, row *queries.OrganizationScimUser) error {
+	if !row.Active {
+		err := s.querier.DeleteOrganizationSCIMMember(ctx, queries.DeleteOrganizationSCIMMemberParams{
+			OrganizationID: row.OrganizationID,
+			UserID:         row.UserID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to remove organization member: %w", err)
+		}
+		return nil
+	}
+
+	groups, err := s.querier.ListSCIMUserGroupNames(ctx, row.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list SCIM user groups: %w", err)
+	}
+	sso, err := s.querier.GetOrganizationSSO(ctx, row.OrganizationID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		sso = nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get organization SSO: %w", err)
+	}
+	role, err := organization.GroupRole(sso, groups)
+	if err != nil {
+		return err
+	}
+	err = s.querier.UpsertOrganizationSCIMMember(ctx, queries.UpsertOrganizationSCIMMemberParams{
+		OrganizationID: row.OrganizationID,
+		UserID:         row.UserID,
+		Role:           role,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save organization member: %w", err)
+	}
+	return nil
+}
+
+// syncMembers updates the memberships of the users whose groups changed.
+func (s *DefaultService) syncMembers(ctx context.Context, oid pgtype.UUID, ids []uuid.UUID) error {
+	for _, id := range ids {
+		row, err := s.querier.GetSCIMUser(ctx, queries.GetSCIMUserParams{
+			OrganizationID: oid,
+			ID:             pgtype.UUID{Bytes: id, Valid: true},
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get SCIM user: %w", err)
+		}
+		if err := s.syncMember(ctx, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *DefaultService) getGroup(ctx context.Context, orgID, id string) (*queries.OrganizationScimGroup, error) {
+	oid, err := parseUUID(orgID)
+	if err != nil {
+		return nil, err
+	}
+	gid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	row, err := s.querier.GetSCIMGroup(ctx, queries.GetSCIMGroupParams{
+		OrganizationID: oid,
+		ID:             pgtype.UUID{Bytes: gid, Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SCIM group: %w", err)
+	}
+	return row, nil
+}
+
+// saveGroup stores the group's attributes and members, then updates the memberships of
+// every user whose role may have changed: all members when the group was renamed, else the
+// users added or removed.
+func (s *DefaultService) saveGroup(
+	ctx context.Context, row *queries.OrganizationScimGroup, displayName, externalID string, members map[uuid.UUID]bool,
+) (*Group, error) {
+	if err := validateDisplayName(displayName); err != nil {
+		return nil, err
+	}
+	renamed := displayName != row.DisplayName
+	if renamed || externalID != row.ExternalID.String {
+		updated, err := s.querier.UpdateSCIMGroup(ctx, queries.UpdateSCIMGroupParams{
+			OrganizationID: row.OrganizationID,
+			ID:             row.ID,
+			DisplayName:    displayName,
+			ExternalID:     optionalText(externalID),
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("%w: displayName %q is taken", ErrUniqueness, displayName)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to update SCIM group: %w", err)
+		}
+		row = updated
+	}
+
+	current, err := s.memberIDs(ctx, row.ID)
+	if err != nil {
+		return nil, err
+	}
+	var added, removed []pgtype.UUID
+	changed := map[uuid.UUID]bool{}
+	for id := range members {
+		if !current[id] {
+			added = append(added, pgtype.UUID{Bytes: id, Valid: true})
+			changed[id] = true
+		}
+	}
+	for id := range current {
+		if !members[id] {
+			removed = append(removed, pgtype.UUID{Bytes: id, Valid: true})
+			changed[id] = true
+		}
+	}
+	if len(added) > 0 {
+		_, err := s.querier.AddSCIMGroupMembers(ctx, queries.AddSCIMGroupMembersParams{
+			GroupID:        row.ID,
+			OrganizationID: row.OrganizationID,
+			ScimUserIds:    added,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to add SCIM group members: %w", err)
+		}
+	}
+	if len(removed) > 0 {
+		_, err := s.querier.RemoveSCIMGroupMembers(ctx, queries.RemoveSCIMGroupMembersParams{
+			GroupID:     row.ID,
+			ScimUserIds: removed,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to remove SCIM group members: %w", err)
+		}
+	}
+	if renamed {
+		for id := range members {
+			changed[id] = true
+		}
+	}
+	if err := s.syncMembers(ctx, row.OrganizationID, keys(changed)); err != nil {
+		return nil, err
+	}
+
+	groups, err := s.withMembers(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	return groups[0], nil
+}
+
+func (s *DefaultService) memberIDs(ctx context.Context, groupID pgtype.UUID) (map[uuid.UUID]bool, error) {
+	rows, err := s.querier.ListSCIMGroupMembers(ctx, []pgtype.UUID{groupID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SCIM group members: %w", err)
+	}
+	ids := make(map[uuid.UUID]bool, len(rows))
+	for _, row := range rows {
+		ids[row.ID.Bytes] = true
+	}
+	return ids, nil
+}
+
+// withMembers converts group rows to resources, loading their members in one query.
+func (s *DefaultService) withMembers(ctx context.Context, rows ...*queries.OrganizationScimGroup) ([]*Group, error) {
+	groups := make([]*Group, 0, len(rows))
+	if len(rows) == 0 {
+		return groups, nil
+	}
+	ids := make([]pgtype.UUID, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	members, err := s.querier.ListSCIMGroupMembers(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SCIM group members: %w", err)
+	}
+	byGroup := map[uuid.UUID][]GroupMember{}
+	for _, m := range members {
+		display := m.DisplayName
+		if display == "" {
+			display = m.UserName
+		}
+		byGroup[m.GroupID.Bytes] = append(byGroup[m.GroupID.Bytes], GroupMember{Value: m.ID.String(), Display: display})
+	}
+	for _, row := range rows {
+		groups = append(groups, groupFromRow(row, byGroup[row.ID.Bytes]))
+	}
+	return groups, nil
+}
+
+func userFromRow(row *queries.OrganizationScimUser) *User {
+	active := row.Active
+	u := &User{
+		Schemas:     []string{SchemaUser},
+		ID:          row.ID.String(),
+		ExternalID:  row.ExternalID.String,
+		UserName:    row.UserName,
+		DisplayName: row.DisplayName,
+		Active:      &active,
+		Meta: &Meta{
+			ResourceType: "User",
+			Created:      row.CreatedAt.Time,
+			LastModified: row.UpdatedAt.Time,
+		},
+	}
+	if row.GivenName != "" || row.FamilyName != "" {
+		u.Name = &Name{GivenName: row.GivenName, FamilyName: row.FamilyName}
+	}
+	if row.Email != "" {
+		u.Emails = []Email{{Value: row.Email, Type: "work", Primary: true}}
+	}
+	return u
+}
+
+func groupFromRow(row *queries.OrganizationScimGroup, members []GroupMember) *Group {
+	if members == nil {
+		members = []GroupMember{}
+	}
+	return &Group{
+		Schemas:     []string{SchemaGroup},
+		ID:          row.ID.String(),
+		ExternalID:  row.ExternalID.String,
+		DisplayName: row.DisplayName,
+		Members:     members,
+		Meta: &Meta{
+			ResourceType: "Group",
+			Created:      row.CreatedAt.Time,
+			LastModified: row.UpdatedAt.Time,
+		},
+	}
+}
+
+func newListResponse[T any](resources []T, total int64, start int) *ListResponse[T] {
+	return &ListResponse[T]{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: total,
+		StartIndex:   start,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+func validateDisplayName(name string) error {
+	if name == "" {
+		return fmt.Errorf("%w: displayName is required", ErrInvalidValue)
+	}
+	if len(name) > maxUserNameLength {
+		return fmt.Errorf("%w: displayName must be at most %d characters", ErrInvalidValue, maxUserNameLength)
+	}
+	return nil
+}
+
+func keys(m map[uuid.UUID]bool) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+func optionalText(s string) pgtype.Text {
+	return pgtype.Text{String: s, Valid: s != ""}
+}
+
+func parseUUID(id string) (pgtype.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf("invalid organization ID: %w", err)
+	}
+	return pgtype.UUID{Bytes: parsed, Valid: true}, nil
+}
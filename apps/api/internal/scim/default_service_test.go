@@ -0,0 +1,335 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/organization"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+func pgUUID(id uuid.UUID) pgtype.UUID {
+	return pgtype.UUID{Bytes: id, Valid: true}
+}
+
+func scimUserRow(orgID, id uuid.UUID, active bool) *queries.OrganizationScimUser {
+	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	return &queries.OrganizationScimUser{
+		ID:             pgUUID(id),
+		OrganizationID: pgUUID(orgID),
+		UserID:         pgUUID(uuid.New()),
+		UserName:       "jane@acme.com",
+		Email:          "jane@acme.com",
+		Active:         active,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+func scimGroupRow(orgID, id uuid.UUID, name string) *queries.OrganizationScimGroup {
+	return &queries.OrganizationScimGroup{ID: pgUUID(id), OrganizationID: pgUUID(orgID), DisplayName: name}
+}
+
+func ssoRow(orgID uuid.UUID) *queries.OrganizationSso {
+	return &queries.OrganizationSso{
+		OrganizationID: pgUUID(orgID),
+		RoleMappings:   []byte(`{"staging-admins":"admin"}`),
+		DefaultRole:    organization.RoleViewer,
+	}
+}
+
+func TestDefaultService_Authenticate(t *testing.T) {
+	orgID := uuid.New()
+
+	testCases := []struct {
+		name      string
+		token     string
+		lookupErr error
+		allowed   bool
+		expectErr error
+	}{
+		{name: "success: known token", token: "scim_abc", allowed: true},
+		{name: "fail: missing token", expectErr: ErrUnauthorized},
+		{name: "fail: unknown token", token: "scim_abc", lookupErr: pgx.ErrNoRows, expectErr: ErrUnauthorized},
+		{name: "fail: plan without SSO", token: "scim_abc", expectErr: ErrNotAllowed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				TouchOrganizationSCIMTokenFunc: func(ctx context.Context, tokenHash []byte) (pgtype.UUID, error) {
+					assert.Equal(t, organization.HashSCIMToken(tc.token), tokenHash)
+					return pgUUID(orgID), tc.lookupErr
+				},
+				GetOrganizationSSOAccessFunc: func(ctx context.Context, organizationID pgtype.UUID) (bool, error) {
+					return tc.allowed, nil
+				},
+			}
+			svc := NewDefaultServiceWithQuerier(q)
+
+			id, err := svc.Authenticate(context.Background(), tc.token)
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, orgID.String(), id)
+		})
+	}
+}
+
+func TestDefaultService_CreateUser(t *testing.T) {
+	orgID := uuid.New()
+	active := false
+
+	testCases := []struct {
+		name       string
+		user       *User
+		createErr  error
+		expectRole string
+		expectErr  error
+	}{
+		{
+			name:       "success: active user joins with the default role",
+			user:       &User{UserName: " jane@acme.com ", Emails: []Email{{Value: "jane@acme.com", Primary: true}}},
+			expectRole: organization.RoleViewer,
+		},
+		{name: "success: inactive user is no member", user: &User{UserName: "jane@acme.com", Active: &active}},
+		{name: "fail: missing user name", user: &User{}, expectErr: ErrInvalidValue},
+		{
+			name:      "fail: user name taken",
+			user:      &User{UserName: "jane@acme.com"},
+			createErr: &pgconn.PgError{Code: "23505"},
+			expectErr: ErrUniqueness,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var role string
+			removed := false
+			q := &queries.QuerierMock{
+				CreateSCIMUserFunc: func(ctx context.Context, arg queries.CreateSCIMUserParams) (*queries.OrganizationScimUser, error) {
+					if tc.createErr != nil {
+						return nil, tc.createErr
+					}
+					assert.Equal(t, "jane@acme.com", arg.UserName)
+					row := scimUserRow(orgID, arg.ID.Bytes, arg.Active)
+					row.Email = arg.Email
+					return row, nil
+				},
+				ListSCIMUserGroupNamesFunc: func(ctx context.Context, scimUserID pgtype.UUID) ([]string, error) {
+					return nil, nil
+				},
+				GetOrganizationSSOFunc: func(ctx context.Context, organizationID pgtype.UUID) (*queries.OrganizationSso, error) {
+					return ssoRow(orgID), nil
+				},
+				UpsertOrganizationSCIMMemberFunc: func(ctx context.Context, arg queries.UpsertOrganizationSCIMMemberParams) error {
+					role = arg.Role
+					return nil
+				},
+				DeleteOrganizationSCIMMemberFunc: func(ctx context.Context, arg queries.DeleteOrganizationSCIMMemberParams) error {
+					removed = true
+					return nil
+				},
+			}
+			svc := NewDefaultServiceWithQuerier(q)
+
+			u, err := svc.CreateUser(context.Background(), orgID.String(), tc.user)
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectRole, role)
+			assert.Equal(t, tc.expectRole == "", removed)
+			assert.Equal(t, SchemaUser, u.Schemas[0])
+		})
+	}
+}
+
+func TestDefaultService_PatchUser(t *testing.T) {
+	orgID := uuid.New()
+	userID := uuid.New()
+	removed := false
+	q := &queries.QuerierMock{
+		GetSCIMUserFunc: func(ctx context.Context, arg queries.GetSCIMUserParams) (*queries.OrganizationScimUser, error) {
+			if arg.ID != pgUUID(userID) {
+				return nil, pgx.ErrNoRows
+			}
+			return scimUserRow(orgID, userID, true), nil
+		},
+		UpdateSCIMUserFunc: func(ctx context.Context, arg queries.UpdateSCIMUserParams) (*queries.OrganizationScimUser, error) {
+			assert.Equal(t, "jane@acme.com", arg.UserName)
+			assert.False(t, arg.Active)
+			return scimUserRow(orgID, userID, arg.Active), nil
+		},
+		DeleteOrganizationSCIMMemberFunc: func(ctx context.Context, arg queries.DeleteOrganizationSCIMMemberParams) error {
+			removed = true
+			return nil
+		},
+	}
+	svc := NewDefaultServiceWithQuerier(q)
+	req := PatchRequest{Operations: []PatchOperation{{Op: "replace", Path: "active", Value: json.RawMessage(`false`)}}}
+
+	u, err := svc.PatchUser(context.Background(), orgID.String(), userID.String(), req)
+	require.NoError(t, err)
+	assert.False(t, *u.Active)
+	assert.True(t, removed)
+
+	_, err = svc.PatchUser(context.Background(), orgID.String(), uuid.NewString(), req)
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = svc.PatchUser(context.Background(), orgID.String(), "not-a-uuid", req)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestDefaultService_DeleteUser(t *testing.T) {
+	orgID := uuid.New()
+
+	testCases := []struct {
+		name      string
+		deleted   int64
+		deleteErr error
+		expectErr error
+	}{
+		{name: "success: deleted", deleted: 1},
+		{name: "fail: not found", expectErr: ErrNotFound},
+		{name: "fail: database error", deleteErr: errors.New("boom")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				DeleteSCIMUserFunc: func(ctx context.Context, arg queries.DeleteSCIMUserParams) (int64, error) {
+					return tc.deleted, tc.deleteErr
+				},
+			}
+			svc := NewDefaultServiceWithQuerier(q)
+
+			err := svc.DeleteUser(context.Background(), orgID.String(), uuid.NewString())
+			switch {
+			case tc.expectErr != nil:
+				assert.ErrorIs(t, err, tc.expectErr)
+			case tc.deleteErr != nil:
+				assert.Error(t, err)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDefaultService_PatchGroup(t *testing.T) {
+	orgID := uuid.New()
+	groupID := uuid.New()
+	kept, added, removed := uuid.New(), uuid.New(), uuid.New()
+
+	members := map[uuid.UUID]bool{kept: true, removed: true}
+	roles := map[uuid.UUID]string{}
+	q := &queries.QuerierMock{
+		GetSCIMGroupFunc: func(ctx context.Context, arg queries.GetSCIMGroupParams) (*queries.OrganizationScimGroup, error) {
+			return scimGroupRow(orgID, groupID, "editors"), nil
+		},
+		UpdateSCIMGroupFunc: func(ctx context.Context, arg queries.UpdateSCIMGroupParams) (*queries.OrganizationScimGroup, error) {
+			assert.Equal(t, "staging-admins", arg.DisplayName)
+			return scimGroupRow(orgID, groupID, arg.DisplayName), nil
+		},
+		ListSCIMGroupMembersFunc: func(ctx context.Context, groupIds []pgtype.UUID) ([]*queries.ListSCIMGroupMembersRow, error) {
+			var rows []*queries.ListSCIMGroupMembersRow
+			for id := range members {
+				rows = append(rows, &queries.ListSCIMGroupMembersRow{GroupID: pgUUID(groupID), ID: pgUUID(id), UserName: id.String()})
+			}
+			return rows, nil
+		},
+		AddSCIMGroupMembersFunc: func(ctx context.Context, arg queries.AddSCIMGroupMembersParams) (int64, error) {
+			assert.Equal(t, []pgtype.UUID{pgUUID(added)}, arg.ScimUserIds)
+			members[added] = true
+			return 1, nil
+		},
+		RemoveSCIMGroupMembersFunc: func(ctx context.Context, arg queries.RemoveSCIMGroupMembersParams) (int64, error) {
+			assert.Equal(t, []pgtype.UUID{pgUUID(removed)}, arg.ScimUserIds)
+			delete(members, removed)
+			return 1, nil
+		},
+		GetSCIMUserFunc: func(ctx context.Context, arg queries.GetSCIMUserParams) (*queries.OrganizationScimUser, error) {
+			row := scimUserRow(orgID, arg.ID.Bytes, true)
+			row.UserID = arg.ID
+			return row, nil
+		},
+		ListSCIMUserGroupNamesFunc: func(ctx context.Context, scimUserID pgtype.UUID) ([]string, error) {
+			if members[scimUserID.Bytes] {
+				return []string{"staging-admins"}, nil
+			}
+			return nil, nil
+		},
+		GetOrganizationSSOFunc: func(ctx context.Context, organizationID pgtype.UUID) (*queries.OrganizationSso, error) {
+			return ssoRow(orgID), nil
+		},
+		UpsertOrganizationSCIMMemberFunc: func(ctx context.Context, arg queries.UpsertOrganizationSCIMMemberParams) error {
+			roles[arg.UserID.Bytes] = arg.Role
+			return nil
+		},
+	}
+	svc := NewDefaultServiceWithQuerier(q)
+	req := PatchRequest{Operations: []PatchOperation{
+		{Op: "replace", Path: "displayName", Value: json.RawMessage(`"staging-admins"`)},
+		{Op: "add", Path: "members", Value: json.RawMessage(`[{"value":"` + added.String() + `"}]`)},
+		{Op: "remove", Path: `members[value eq "` + removed.String() + `"]`},
+	}}
+
+	g, err := svc.PatchGroup(context.Background(), orgID.String(), groupID.String(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "staging-admins", g.DisplayName)
+	assert.Len(t, g.Members, 2)
+	// The rename re-maps every member; the removed user falls back to the default role.
+	assert.Equal(t, map[uuid.UUID]string{
+		kept:    organization.RoleAdmin,
+		added:   organization.RoleAdmin,
+		removed: organization.RoleViewer,
+	}, roles)
+}
+
+func TestDefaultService_ListGroups(t *testing.T) {
+	orgID := uuid.New()
+	groupID := uuid.New()
+	q := &queries.QuerierMock{
+		CountSCIMGroupsFunc: func(ctx context.Context, arg queries.CountSCIMGroupsParams) (int64, error) {
+			assert.Equal(t, pgtype.Text{String: "Admins", Valid: true}, arg.DisplayName)
+			return 1, nil
+		},
+		ListSCIMGroupsFunc: func(ctx context.Context, arg queries.ListSCIMGroupsParams) ([]*queries.OrganizationScimGroup, error) {
+			assert.Equal(t, int32(defaultCount), arg.Limit)
+			return []*queries.OrganizationScimGroup{scimGroupRow(orgID, groupID, "Admins")}, nil
+		},
+		ListSCIMGroupMembersFunc: func(ctx context.Context, groupIds []pgtype.UUID) ([]*queries.ListSCIMGroupMembersRow, error) {
+			return []*queries.ListSCIMGroupMembersRow{
+				{GroupID: pgUUID(groupID), ID: pgUUID(uuid.New()), UserName: "jane@acme.com"},
+			}, nil
+		},
+	}
+	svc := NewDefaultServiceWithQuerier(q)
+
+	res, err := svc.ListGroups(context.Background(), orgID.String(), ListQuery{
+		Filter: `displayName eq "Admins"`, StartIndex: 1, Count: defaultCount,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), res.TotalResults)
+	require.Len(t, res.Resources, 1)
+	assert.Equal(t, "jane@acme.com", res.Resources[0].Members[0].Display)
+
+	// A count of 0 returns only the total.
+	res, err = svc.ListGroups(context.Background(), orgID.String(), ListQuery{Filter: `displayName eq "Admins"`})
+	require.NoError(t, err)
+	assert.Empty(t, res.Resources)
+	assert.Len(t, q.ListSCIMGroupsCalls(), 1)
+}
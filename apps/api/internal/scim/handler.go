@@ -0,0 +1,24 @@
+package scim
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP-level contract for the SCIM endpoints. Authenticate guards the
+// others and must run first.
+type Handler interface {
+	Authenticate(next echo.HandlerFunc) echo.HandlerFunc
+	ServiceProviderConfig(c echo.Context) error
+	ListUsers(c echo.Context) error
+	GetUser(c echo.Context) error
+	CreateUser(c echo.Context) error
+	ReplaceUser(c echo.Context) error
+	PatchUser(c echo.Context) error
+	DeleteUser(c echo.Context) error
+	ListGroups(c echo.Context) error
+	GetGroup(c echo.Context) error
+	CreateGroup(c echo.Context) error
+	ReplaceGroup(c echo.Context) error
+	PatchGroup(c echo.Context) error
+	DeleteGroup(c echo.Context) error
+}
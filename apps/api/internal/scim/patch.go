@@ -0,0 +1,234 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// memberFilterPattern matches the path clients remove a single group member with.
+var memberFilterPattern = regexp.MustCompile(`^members\[value eq "([^"]+)"\]$`)
+
+// userFields are the attributes of a user the platform keeps.
+type userFields struct {
+	userName    string
+	externalID  string
+	displayName string
+	givenName   string
+	familyName  string
+	email       string
+	active      bool
+}
+
+func fieldsFromUser(u *User) userFields {
+	f := userFields{
+		userName:    strings.TrimSpace(u.UserName),
+		externalID:  u.ExternalID,
+		displayName: u.DisplayName,
+		email:       primaryEmail(u.Emails),
+		active:      u.Active == nil || *u.Active,
+	}
+	if u.Name != nil {
+		f.givenName = u.Name.GivenName
+		f.familyName = u.Name.FamilyName
+	}
+	return f
+}
+
+func (f *userFields) validate() error {
+	f.userName = strings.TrimSpace(f.userName)
+	if f.userName == "" {
+		return fmt.Errorf("%w: userName is required", ErrInvalidValue)
+	}
+	if len(f.userName) > maxUserNameLength || len(f.displayName) > maxUserNameLength {
+		return fmt.Errorf("%w: userName and displayName must be at most %d characters", ErrInvalidValue, maxUserNameLength)
+	}
+	return nil
+}
+
+// apply applies one PATCH operation. Attributes the platform does not keep are ignored, so
+// clients can send their full attribute mappings.
+func (f *userFields) apply(op PatchOperation) error {
+	kind, err := opKind(op)
+	if err != nil {
+		return err
+	}
+	path := normalizePath(op.Path, SchemaUser)
+	if path == "" {
+		if kind == "remove" {
+			return fmt.Errorf("%w: remove needs a path", ErrInvalidPath)
+		}
+		var attrs map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &attrs); err != nil {
+			return fmt.Errorf("%w: value must be an object when path is omitted", ErrInvalidValue)
+		}
+		for key, value := range attrs {
+			if err := f.set(normalizePath(key, SchemaUser), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if kind == "remove" {
+		return f.set(path, nil)
+	}
+	return f.set(path, op.Value)
+}
+
+// set sets the attribute at a lowercase path; a nil value removes it.
+func (f *userFields) set(path string, value json.RawMessage) error {
+	var err error
+	switch {
+	case path == "active":
+		if value == nil {
+			return fmt.Errorf("%w: active cannot be removed", ErrInvalidPath)
+		}
+		f.active, err = decodeBool(value)
+	case path == "username":
+		f.userName, err = decodeString(value, "userName")
+	case path == "externalid":
+		f.externalID, err = decodeString(value, "externalId")
+	case path == "displayname":
+		f.displayName, err = decodeString(value, "displayName")
+	case path == "name.givenname":
+		f.givenName, err = decodeString(value, "name.givenName")
+	case path == "name.familyname":
+		f.familyName, err = decodeString(value, "name.familyName")
+	case path == "name":
+		f.givenName, f.familyName = "", ""
+		if value == nil || string(value) == "null" {
+			return nil
+		}
+		var parts map[string]json.RawMessage
+		if err := json.Unmarshal(value, &parts); err != nil {
+			return fmt.Errorf("%w: name must be an object", ErrInvalidValue)
+		}
+		for key, part := range parts {
+			if err := f.set("name."+strings.ToLower(key), part); err != nil {
+				return err
+			}
+		}
+	case path == "emails":
+		var emails []Email
+		if value != nil {
+			if err := json.Unmarshal(value, &emails); err != nil {
+				return fmt.Errorf("%w: emails must be a list", ErrInvalidValue)
+			}
+		}
+		f.email = primaryEmail(emails)
+	case strings.HasPrefix(path, "emails[") && strings.HasSuffix(path, "].value"):
+		f.email, err = decodeString(value, "emails.value")
+	}
+	return err
+}
+
+// groupChange is the outcome of applying PATCH operations to a group.
+type groupChange struct {
+	displayName string
+	externalID  string
+	members     map[uuid.UUID]bool
+}
+
+// apply applies one PATCH operation.
+func (g *groupChange) apply(op PatchOperation) error {
+	kind, err := opKind(op)
+	if err != nil {
+		return err
+	}
+	path := normalizePath(op.Path, SchemaGroup)
+	switch {
+	case path == "":
+		if kind == "remove" {
+			return fmt.Errorf("%w: remove needs a path", ErrInvalidPath)
+		}
+		var attrs map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &attrs); err != nil {
+			return fmt.Errorf("%w: value must be an object when path is omitted", ErrInvalidValue)
+		}
+		for key, value := range attrs {
+			key = normalizePath(key, SchemaGroup)
+			if key == "id" {
+				continue
+			}
+			if err := g.apply(PatchOperation{Op: kind, Path: key, Value: value}); err != nil {
+				return err
+			}
+		}
+		return nil
+	case path == "displayname":
+		if kind == "remove" {
+			return fmt.Errorf("%w: displayName cannot be removed", ErrInvalidPath)
+		}
+		g.displayName, err = decodeString(op.Value, "displayName")
+		return err
+	case path == "externalid":
+		if kind == "remove" {
+			g.externalID = ""
+			return nil
+		}
+		g.externalID, err = decodeString(op.Value, "externalId")
+		return err
+	case path == "members":
+		ids, err := memberIDs(op.Value)
+		if err != nil {
+			return err
+		}
+		if kind == "replace" || (kind == "remove" && len(ids) == 0) {
+			clear(g.members)
+		}
+		for _, id := range ids {
+			if kind == "remove" {
+				delete(g.members, id)
+			} else {
+				g.members[id] = true
+			}
+		}
+		return nil
+	}
+	if m := memberFilterPattern.FindStringSubmatch(path); m != nil && kind == "remove" {
+		id, err := uuid.Parse(m[1])
+		if err != nil {
+			return fmt.Errorf("%w: member %q is not a SCIM user ID", ErrInvalidValue, m[1])
+		}
+		delete(g.members, id)
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrInvalidPath, op.Path)
+}
+
+// opKind returns the lowercase operation, which clients send in any case.
+func opKind(op PatchOperation) (string, error) {
+	kind := strings.ToLower(op.Op)
+	switch kind {
+	case "add", "replace", "remove":
+		return kind, nil
+	}
+	return "", fmt.Errorf("%w: unknown operation %q", ErrInvalidValue, op.Op)
+}
+
+// memberIDs reads the user IDs of a members value.
+func memberIDs(value json.RawMessage) ([]uuid.UUID, error) {
+	if len(value) == 0 || string(value) == "null" {
+		return nil, nil
+	}
+	var members []GroupMember
+	if err := json.Unmarshal(value, &members); err != nil {
+		return nil, fmt.Errorf("%w: members must be a list", ErrInvalidValue)
+	}
+	return parseMembers(members)
+}
+
+func parseMembers(members []GroupMember) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(members))
+	for _, m := range members {
+		id, err := uuid.Parse(m.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: member %q is not a SCIM user ID", ErrInvalidValue, m.Value)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,118 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserFields_apply(t *testing.T) {
+	testCases := []struct {
+		name      string
+		op        PatchOperation
+		expected  userFields
+		expectErr error
+	}{
+		{
+			name:     "success: deactivate, Azure AD style",
+			op:       PatchOperation{Op: "Replace", Path: "active", Value: json.RawMessage(`"False"`)},
+			expected: userFields{userName: "jane@acme.com", email: "jane@acme.com"},
+		},
+		{
+			name: "success: no path",
+			op: PatchOperation{
+				Op:    "replace",
+				Value: json.RawMessage(`{"active":false,"name.givenName":"Jane","displayName":"Jane D"}`),
+			},
+			expected: userFields{userName: "jane@acme.com", email: "jane@acme.com", givenName: "Jane", displayName: "Jane D"},
+		},
+		{
+			name:     "success: filtered email",
+			op:       PatchOperation{Op: "replace", Path: `emails[type eq "work"].value`, Value: json.RawMessage(`"j@acme.com"`)},
+			expected: userFields{userName: "jane@acme.com", email: "j@acme.com", active: true},
+		},
+		{
+			name:     "success: unknown attribute ignored",
+			op:       PatchOperation{Op: "add", Path: "title", Value: json.RawMessage(`"Agent"`)},
+			expected: userFields{userName: "jane@acme.com", email: "jane@acme.com", active: true},
+		},
+		{
+			name:      "fail: remove active",
+			op:        PatchOperation{Op: "remove", Path: "active"},
+			expectErr: ErrInvalidPath,
+		},
+		{
+			name:      "fail: unknown operation",
+			op:        PatchOperation{Op: "move", Path: "active"},
+			expectErr: ErrInvalidValue,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := userFields{userName: "jane@acme.com", email: "jane@acme.com", active: true}
+			err := f.apply(tc.op)
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, f)
+		})
+	}
+}
+
+func TestGroupChange_apply(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	members := func(ids ...uuid.UUID) json.RawMessage {
+		list := make([]GroupMember, 0, len(ids))
+		for _, id := range ids {
+			list = append(list, GroupMember{Value: id.String()})
+		}
+		raw, _ := json.Marshal(list)
+		return raw
+	}
+
+	testCases := []struct {
+		name      string
+		op        PatchOperation
+		expected  map[uuid.UUID]bool
+		expectErr error
+	}{
+		{name: "success: add", op: PatchOperation{Op: "add", Path: "members", Value: members(c)}, expected: map[uuid.UUID]bool{a: true, b: true, c: true}},
+		{name: "success: remove some", op: PatchOperation{Op: "remove", Path: "members", Value: members(a)}, expected: map[uuid.UUID]bool{b: true}},
+		{name: "success: remove all", op: PatchOperation{Op: "remove", Path: "members"}, expected: map[uuid.UUID]bool{}},
+		{name: "success: replace", op: PatchOperation{Op: "replace", Path: "members", Value: members(c)}, expected: map[uuid.UUID]bool{c: true}},
+		{
+			name:     "success: remove by filter",
+			op:       PatchOperation{Op: "Remove", Path: `members[value eq "` + b.String() + `"]`},
+			expected: map[uuid.UUID]bool{a: true},
+		},
+		{name: "fail: member not a UUID", op: PatchOperation{Op: "add", Path: "members", Value: json.RawMessage(`[{"value":"x"}]`)}, expectErr: ErrInvalidValue},
+		{name: "fail: remove display name", op: PatchOperation{Op: "remove", Path: "displayName"}, expectErr: ErrInvalidPath},
+		{name: "fail: unknown path", op: PatchOperation{Op: "replace", Path: "owner", Value: json.RawMessage(`"x"`)}, expectErr: ErrInvalidPath},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := groupChange{displayName: "Admins", members: map[uuid.UUID]bool{a: true, b: true}}
+			err := g.apply(tc.op)
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, g.members)
+		})
+	}
+}
+
+func TestGroupChange_apply_rename(t *testing.T) {
+	g := groupChange{displayName: "Admins", members: map[uuid.UUID]bool{}}
+	err := g.apply(PatchOperation{Op: "replace", Value: json.RawMessage(`{"id":"ignored","displayName":"Editors"}`)})
+	require.NoError(t, err)
+	assert.Equal(t, "Editors", g.displayName)
+}
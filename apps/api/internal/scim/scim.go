@@ -0,0 +1,238 @@
+// Package scim serves SCIM 2.0 (RFC 7643, RFC 7644) so an organization's identity provider
+// can provision and deprovision its members. Clients authenticate with the organization's
+// SCIM token, issued by its admins. Users are backed by platform accounts that the person
+// signs in to through the organization's SSO connection; active users are organization
+// members, with the role their groups map to under the organization's SSO role mappings.
+// Deactivating or deleting a user removes the membership.
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Schema URNs of the resources and messages served.
+const (
+	SchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	SchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+)
+
+// MIMEApplicationSCIM is the media type of SCIM requests and responses.
+const MIMEApplicationSCIM = "application/scim+json"
+
+const (
+	// defaultCount is the page size when a list request does not set count.
+	defaultCount = 100
+	// maxCount caps the page size of list requests.
+	maxCount = 200
+	// maxUserNameLength caps user names and display names.
+	maxUserNameLength = 256
+)
+
+var (
+	// ErrUnauthorized is returned for a missing or unknown SCIM token.
+	ErrUnauthorized = errors.New("invalid SCIM token")
+	// ErrNotAllowed is returned when the organization owner's plan does not include SSO.
+	ErrNotAllowed = errors.New("plan does not include single sign-on")
+	// ErrNotFound is returned when the user or group does not exist in the organization.
+	ErrNotFound = errors.New("resource not found")
+	// ErrUniqueness is returned when another user has the user name, or another group the
+	// display name.
+	ErrUniqueness = errors.New("resource already exists")
+	// ErrInvalidValue is returned for missing or malformed attributes.
+	ErrInvalidValue = errors.New("invalid attribute value")
+	// ErrInvalidFilter is returned for filters other than an attribute equal to a string.
+	ErrInvalidFilter = errors.New("unsupported filter")
+	// ErrInvalidPath is returned for PATCH operations on paths that cannot be changed.
+	ErrInvalidPath = errors.New("unsupported patch path")
+)
+
+// Name is the components of a user's name.
+type Name struct {
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// Email is one of a user's email addresses. Only the primary one is kept.
+type Email struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// Meta describes a resource.
+type Meta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+	Location     string    `json:"location,omitempty"`
+}
+
+// User is a SCIM user. Attributes the platform does not keep are ignored.
+type User struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id,omitempty"`
+	ExternalID  string   `json:"externalId,omitempty"`
+	UserName    string   `json:"userName"`
+	Name        *Name    `json:"name,omitempty"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Emails      []Email  `json:"emails,omitempty"`
+	// Active is true unless set; inactive users are not organization members.
+	Active *bool `json:"active,omitempty"`
+	Meta   *Meta `json:"meta,omitempty"`
+}
+
+// GroupMember is a user in a group, referenced by the user's SCIM ID.
+type GroupMember struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+// Group is a SCIM group. Its display name is matched against the organization's SSO role
+// mappings to pick the role of its members.
+type Group struct {
+	Schemas     []string      `json:"schemas"`
+	ID          string        `json:"id,omitempty"`
+	ExternalID  string        `json:"externalId,omitempty"`
+	DisplayName string        `json:"displayName"`
+	Members     []GroupMember `json:"members"`
+	Meta        *Meta         `json:"meta,omitempty"`
+}
+
+// ListResponse is a page of resources.
+type ListResponse[T any] struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int64    `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []T      `json:"Resources"`
+}
+
+// ListQuery selects a page of resources. StartIndex is 1-based; a Count of 0 returns only
+// the total.
+type ListQuery struct {
+	Filter     string
+	StartIndex int
+	Count      int
+}
+
+// PatchRequest changes a resource in place.
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// PatchOperation is one change of a PatchRequest. Op is add, replace or remove, in any case.
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// ErrorResponse is a SCIM error.
+type ErrorResponse struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	SCIMType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// filterPattern matches the only filters served: an attribute equal to a string, which is
+// what identity providers send to find a resource before creating it.
+var filterPattern = regexp.MustCompile(`^\s*([A-Za-z][A-Za-z0-9.:]*)\s+(?i:eq)\s+"((?:[^"\\]|\\.)*)"\s*$`)
+
+// filter is a parsed "attribute eq value" filter. Attribute is lowercase.
+type filter struct {
+	attribute string
+	value     string
+}
+
+// parseFilter parses raw, allowing only the given attributes. An empty raw filter matches
+// everything and returns nil.
+func parseFilter(raw string, attributes ...string) (*filter, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+	m := filterPattern.FindStringSubmatch(raw)
+	if m == nil {
+		return nil, fmt.Errorf("%w: only 'attribute eq \"value\"' filters are supported", ErrInvalidFilter)
+	}
+	attribute := strings.ToLower(m[1])
+	for _, allowed := range attributes {
+		if attribute == strings.ToLower(allowed) {
+			var value string
+			if err := json.Unmarshal([]byte(`"`+m[2]+`"`), &value); err != nil {
+				return nil, fmt.Errorf("%w: malformed value", ErrInvalidFilter)
+			}
+			return &filter{attribute: attribute, value: value}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: cannot filter by %s", ErrInvalidFilter, m[1])
+}
+
+// page returns the 1-based start index, page size and row offset of q.
+func (q ListQuery) page() (start, count, offset int) {
+	start = max(q.StartIndex, 1)
+	count = min(max(q.Count, 0), maxCount)
+	return start, count, start - 1
+}
+
+// normalizePath lowercases a PATCH path and strips the core schema prefix clients may add.
+func normalizePath(path, schema string) string {
+	path = strings.TrimSpace(path)
+	if len(path) > len(schema) && strings.EqualFold(path[:len(schema)], schema) {
+		path = strings.TrimPrefix(path[len(schema):], ":")
+	}
+	return strings.ToLower(path)
+}
+
+// decodeBool reads a boolean that clients send either as JSON or, like Azure AD, as a string.
+func decodeBool(raw json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(raw, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		switch strings.ToLower(s) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("%w: active must be a boolean", ErrInvalidValue)
+}
+
+// decodeString reads a string attribute; null clears it.
+func decodeString(raw json.RawMessage, attribute string) (string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return "", nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return "", fmt.Errorf("%w: %s must be a string", ErrInvalidValue, attribute)
+	}
+	return s, nil
+}
+
+// primaryEmail returns the primary email of emails, or the first.
+func primaryEmail(emails []Email) string {
+	for _, e := range emails {
+		if e.Primary {
+			return e.Value
+		}
+	}
+	if len(emails) > 0 {
+		return emails[0].Value
+	}
+	return ""
+}
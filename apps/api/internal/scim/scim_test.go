@@ -0,0 +1,66 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	testCases := []struct {
+		name      string
+		raw       string
+		expected  *filter
+		expectErr bool
+	}{
+		{name: "success: empty", raw: " "},
+		{name: "success: user name", raw: `userName eq "Jane@Acme.com"`, expected: &filter{"username", "Jane@Acme.com"}},
+		{name: "success: operator in any case", raw: `externalId EQ "00u1"`, expected: &filter{"externalid", "00u1"}},
+		{name: "success: escaped quote", raw: `userName eq "a\"b"`, expected: &filter{"username", `a"b`}},
+		{name: "fail: other operator", raw: `userName co "jane"`, expectErr: true},
+		{name: "fail: compound", raw: `userName eq "a" and active eq true`, expectErr: true},
+		{name: "fail: attribute not allowed", raw: `emails eq "a@acme.com"`, expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := parseFilter(tc.raw, "userName", "externalId")
+			if tc.expectErr {
+				assert.ErrorIs(t, err, ErrInvalidFilter)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, f)
+		})
+	}
+}
+
+func TestListQuery_page(t *testing.T) {
+	start, count, offset := ListQuery{StartIndex: 0, Count: 1000}.page()
+	assert.Equal(t, 1, start)
+	assert.Equal(t, maxCount, count)
+	assert.Equal(t, 0, offset)
+
+	start, count, offset = ListQuery{StartIndex: 11, Count: -5}.page()
+	assert.Equal(t, 11, start)
+	assert.Equal(t, 0, count)
+	assert.Equal(t, 10, offset)
+}
+
+func TestDecodeBool(t *testing.T) {
+	for raw, expected := range map[string]bool{`true`: true, `false`: false, `"True"`: true, `"False"`: false} {
+		b, err := decodeBool(json.RawMessage(raw))
+		require.NoError(t, err, raw)
+		assert.Equal(t, expected, b, raw)
+	}
+	_, err := decodeBool(json.RawMessage(`"yes"`))
+	assert.ErrorIs(t, err, ErrInvalidValue)
+}
+
+func TestNormalizePath(t *testing.T) {
+	assert.Equal(t, "name.givenname", normalizePath(" name.givenName ", SchemaUser))
+	assert.Equal(t, "active", normalizePath(SchemaUser+":active", SchemaUser))
+	assert.Equal(t, "", normalizePath("", SchemaUser))
+}
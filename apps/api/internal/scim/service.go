@@ -0,0 +1,42 @@
+package scim
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service provisions an organization's users and groups. Calls other than Authenticate are
+// scoped to the organization Authenticate resolved the token to.
+type Service interface {
+	// Authenticate returns the ID of the organization whose SCIM token this is. It fails with
+	// ErrUnauthorized for unknown tokens and ErrNotAllowed when the plan lacks SSO.
+	Authenticate(ctx context.Context, token string) (string, error)
+
+	// ListUsers returns a page of the organization's users, optionally filtered by userName
+	// or externalId.
+	ListUsers(ctx context.Context, orgID string, q ListQuery) (*ListResponse[*User], error)
+	// GetUser returns one of the organization's users.
+	GetUser(ctx context.Context, orgID, id string) (*User, error)
+	// CreateUser creates a user and, when active, makes them an organization member.
+	CreateUser(ctx context.Context, orgID string, user *User) (*User, error)
+	// ReplaceUser replaces every attribute of a user.
+	ReplaceUser(ctx context.Context, orgID, id string, user *User) (*User, error)
+	// PatchUser changes some attributes of a user; clients deprovision users by setting
+	// active to false.
+	PatchUser(ctx context.Context, orgID, id string, req PatchRequest) (*User, error)
+	// DeleteUser deletes a user and removes their membership.
+	DeleteUser(ctx context.Context, orgID, id string) error
+
+	// ListGroups returns a page of the organization's groups, optionally filtered by
+	// displayName or externalId.
+	ListGroups(ctx context.Context, orgID string, q ListQuery) (*ListResponse[*Group], error)
+	// GetGroup returns one of the organization's groups with its members.
+	GetGroup(ctx context.Context, orgID, id string) (*Group, error)
+	// CreateGroup creates a group and updates the roles of its members.
+	CreateGroup(ctx context.Context, orgID string, group *Group) (*Group, error)
+	// ReplaceGroup replaces a group's attributes and members.
+	ReplaceGroup(ctx context.Context, orgID, id string, group *Group) (*Group, error)
+	// PatchGroup renames a group or adds and removes members.
+	PatchGroup(ctx context.Context, orgID, id string, req PatchRequest) (*Group, error)
+	// DeleteGroup deletes a group and updates the roles of its former members.
+	DeleteGroup(ctx context.Context, orgID, id string) error
+}
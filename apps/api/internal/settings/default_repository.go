@@ -5,43 +5,31 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
 )
 
 // DefaultRepository implements Repository using PostgreSQL.
 type DefaultRepository struct {
-	db storage.PgxPool
+	queries queries.Querier
 }
 
 // Ensure DefaultRepository implements Repository.
 var _ Repository = (*DefaultRepository)(nil)
 
-// NewDefaultRepository creates a new DefaultRepository.
-func NewDefaultRepository(db storage.PgxPool) *DefaultRepository {
-	return &DefaultRepository{db: db}
+// NewDefaultRepository creates a new DefaultRepository. Statements go through db, so a
+// storage.TenantDatabase sends sandbox requests to the sandbox.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{queries: queries.New(db)}
 }
 
 // GetByKey retrieves a setting by its key.
 func (r *DefaultRepository) GetByKey(ctx context.Context, key string) (*Setting, error) {
-	query := `
-		SELECT key, value, description, updated_at, updated_by
-		FROM settings
-		WHERE key = $1
-	`
-
-	var setting Setting
-	var updatedBy *string
-
-	err := r.db.QueryRow(ctx, query, key).Scan(
-		&setting.Key,
-		&setting.Value,
-		&setting.Description,
-		&setting.UpdatedAt,
-		&updatedBy,
-	)
-
+	row, err := r.queries.GetSetting(ctx, key)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, fmt.Errorf("setting not found: %s", key)
@@ -49,25 +37,27 @@ func (r *DefaultRepository) GetByKey(ctx context.Context, key string) (*Setting,
 		return nil, fmt.Errorf("failed to get setting: %w", err)
 	}
 
-	setting.UpdatedBy = updatedBy
-
+	setting := settingFromRow(row)
 	return &setting, nil
 }
 
 // Update updates a setting value.
 func (r *DefaultRepository) Update(ctx context.Context, key, value, userID string) error {
-	query := `
-		UPDATE settings
-		SET value = $1, updated_at = NOW(), updated_by = $2
-		WHERE key = $3
-	`
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
 
-	result, err := r.db.Exec(ctx, query, value, userID, key)
+	n, err := r.queries.UpdateSetting(ctx, queries.UpdateSettingParams{
+		Value:     value,
+		UpdatedBy: pgtype.UUID{Bytes: userUUID, Valid: true},
+		Key:       key,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to update setting: %w", err)
 	}
 
-	if result.RowsAffected() == 0 {
+	if n == 0 {
 		return fmt.Errorf("setting not found: %s", key)
 	}
 
@@ -76,41 +66,31 @@ func (r *DefaultRepository) Update(ctx context.Context, key, value, userID strin
 
 // List retrieves all settings.
 func (r *DefaultRepository) List(ctx context.Context) ([]Setting, error) {
-	query := `
-		SELECT key, value, description, updated_at, updated_by
-		FROM settings
-		ORDER BY key
-	`
-
-	rows, err := r.db.Query(ctx, query)
+	rows, err := r.queries.ListSettings(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list settings: %w", err)
 	}
-	defer rows.Close()
-
-	var settings []Setting
-	for rows.Next() {
-		var setting Setting
-		var updatedBy *string
-
-		err := rows.Scan(
-			&setting.Key,
-			&setting.Value,
-			&setting.Description,
-			&setting.UpdatedAt,
-			&updatedBy,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan setting: %w", err)
-		}
 
-		setting.UpdatedBy = updatedBy
-		settings = append(settings, setting)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating settings: %w", err)
+	settings := make([]Setting, len(rows))
+	for i, row := range rows {
+		settings[i] = settingFromRow(row)
 	}
 
 	return settings, nil
 }
+
+func settingFromRow(row *queries.Setting) Setting {
+	s := Setting{
+		Key:       row.Key,
+		Value:     row.Value,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+	if row.Description.Valid {
+		s.Description = &row.Description.String
+	}
+	if row.UpdatedBy.Valid {
+		updatedBy := uuid.UUID(row.UpdatedBy.Bytes).String()
+		s.UpdatedBy = &updatedBy
+	}
+	return s
+}
@@ -0,0 +1,37 @@
+package settings
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+func TestDefaultRepository_Update_Sandbox(t *testing.T) {
+	updated := func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+		return pgconn.NewCommandTag("UPDATE 1"), nil
+	}
+	production := &storage.DatabaseMock{ExecFunc: updated}
+	sandbox := &storage.DatabaseMock{ExecFunc: updated}
+	repo := NewDefaultRepository(storage.NewTenantDatabase(production, sandbox))
+
+	t.Run("success: sandbox write never reaches production", func(t *testing.T) {
+		ctx := storage.WithSandbox(context.Background())
+		require.NoError(t, repo.Update(ctx, "active_model", "qwen/qwen-image-edit", uuid.NewString()))
+
+		assert.Len(t, sandbox.ExecCalls(), 1)
+		assert.Empty(t, production.ExecCalls())
+	})
+
+	t.Run("success: production write stays in production", func(t *testing.T) {
+		require.NoError(t, repo.Update(context.Background(), "active_model", "qwen/qwen-image-edit", uuid.NewString()))
+
+		assert.Len(t, sandbox.ExecCalls(), 1)
+		assert.Len(t, production.ExecCalls(), 1)
+	})
+}
@@ -64,6 +64,14 @@ func (s *DefaultService) ListAvailableModels(ctx context.Context) ([]ModelInfo,
 			Version:     "v1",
 			IsActive:    activeModelID == "qwen/qwen-image-edit",
 		},
+		{
+			ID:   "qwen/qwen-image-edit-plus",
+			Name: "Qwen Image Edit Plus",
+			Description: "Qwen image editing with up to two reference images, " +
+				"so catalog photos guide the furniture it places. Requires input image.",
+			Version:  "v1",
+			IsActive: activeModelID == "qwen/qwen-image-edit-plus",
+		},
 		{
 			ID:   "black-forest-labs/flux-kontext-max",
 			Name: "Flux Kontext Max",
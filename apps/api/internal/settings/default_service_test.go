@@ -134,8 +134,8 @@ func TestDefaultService_ListAvailableModels(t *testing.T) {
 			t.Fatalf("unexpected error: %v", err)
 		}
 
-		if len(models) != 2 {
-			t.Fatalf("expected 2 models, got %d", len(models))
+		if len(models) != 3 {
+			t.Fatalf("expected 3 models, got %d", len(models))
 		}
 
 		// Check Qwen model
@@ -0,0 +1,134 @@
+package sharebrand
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// DefaultHandler serves the current user's share branding over HTTP.
+type DefaultHandler struct {
+	service  Service
+	userRepo user.Repository
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, userRepo user.Repository) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo}
+}
+
+// ErrorResponse is a simple JSON error envelope for handler responses.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// DomainCheckResponse is the body of a successful custom domain check.
+type DomainCheckResponse struct {
+	Domain string `json:"domain"`
+}
+
+// Get handles GET /api/v1/me/share-branding.
+func (h *DefaultHandler) Get(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	b, err := h.service.Get(c.Request().Context(), userID)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, b)
+}
+
+// Put handles PUT /api/v1/me/share-branding.
+func (h *DefaultHandler) Put(c echo.Context) error {
+	var req PutRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid request format"})
+	}
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	b, err := h.service.Put(c.Request().Context(), userID, req)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, b)
+}
+
+// Delete handles DELETE /api/v1/me/share-branding.
+func (h *DefaultHandler) Delete(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	if err := h.service.Delete(c.Request().Context(), userID); err != nil {
+		return h.writeError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// VerifyDomain handles POST /api/v1/me/share-branding/domain/verify.
+func (h *DefaultHandler) VerifyDomain(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	b, err := h.service.VerifyDomain(c.Request().Context(), userID)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, b)
+}
+
+// CheckDomain handles GET /share-domains/check?domain=. The proxy in front of the API asks
+// it before issuing a certificate, so only verified custom domains get one.
+func (h *DefaultHandler) CheckDomain(c echo.Context) error {
+	domain := normalizeHost(c.QueryParam("domain"))
+	if _, err := h.service.OwnerOfDomain(c.Request().Context(), domain); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Unknown domain"})
+		}
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, DomainCheckResponse{Domain: domain})
+}
+
+func unauthorized(c echo.Context) error {
+	return c.JSON(http.StatusUnauthorized, ErrorResponse{
+		Error:   "unauthorized",
+		Message: "Unable to resolve current user",
+	})
+}
+
+// writeError maps service errors to responses.
+func (h *DefaultHandler) writeError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "No share branding configured"})
+	case errors.Is(err, ErrInvalid):
+		return c.JSON(http.StatusUnprocessableEntity, ErrorResponse{Error: "validation_failed", Message: err.Error()})
+	case errors.Is(err, ErrDomainTaken):
+		return c.JSON(http.StatusConflict, ErrorResponse{Error: "domain_taken", Message: err.Error()})
+	case errors.Is(err, ErrNotVerified):
+		return c.JSON(http.StatusUnprocessableEntity, ErrorResponse{Error: "domain_not_verified", Message: err.Error()})
+	default:
+		c.Logger().Errorf("Share branding request failed: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to process share branding request",
+		})
+	}
+}
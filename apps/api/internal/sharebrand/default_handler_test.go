@@ -0,0 +1,227 @@
+package sharebrand
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func userRepoFor(userID uuid.UUID) *user.RepositoryMock {
+	return &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+		},
+	}
+}
+
+func newContext(method, target, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("X-Test-User", "auth0|user")
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
+
+func TestDefaultHandler_Put(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: saved", body: `{"accent_color":"#00ff00"}`, expectedStatus: http.StatusOK},
+		{name: "fail: malformed body", body: `{`, expectedStatus: http.StatusBadRequest},
+		{
+			name: "fail: invalid", body: `{}`, err: fmt.Errorf("%w: color", ErrInvalid),
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{name: "fail: domain taken", body: `{}`, err: ErrDomainTaken, expectedStatus: http.StatusConflict},
+		{name: "fail: service error", body: `{}`, err: errors.New("db down"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				PutFunc: func(ctx context.Context, uid string, req PutRequest) (*Branding, error) {
+					assert.Equal(t, userID.String(), uid)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &Branding{PrimaryColor: DefaultPrimaryColor, AccentColor: req.AccentColor}, nil
+				},
+			}
+			c, rec := newContext(http.MethodPut, "/api/v1/me/share-branding", tc.body)
+
+			require.NoError(t, NewDefaultHandler(svc, userRepoFor(userID)).Put(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Contains(t, rec.Body.String(), `"accent_color":"#00ff00"`)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_VerifyDomain(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: verified", expectedStatus: http.StatusOK},
+		{
+			name: "fail: records missing", err: fmt.Errorf("%w: CNAME", ErrNotVerified),
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{name: "fail: no branding", err: ErrNotFound, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				VerifyDomainFunc: func(ctx context.Context, uid string) (*Branding, error) {
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &Branding{Domain: &Domain{Name: "photos.example.com", Status: DomainStatusVerified}}, nil
+				},
+			}
+			c, rec := newContext(http.MethodPost, "/api/v1/me/share-branding/domain/verify", "")
+
+			require.NoError(t, NewDefaultHandler(svc, userRepoFor(userID)).VerifyDomain(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.err != nil {
+				assert.NotContains(t, rec.Body.String(), "photos.example.com")
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_Delete(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: deleted", expectedStatus: http.StatusNoContent},
+		{name: "fail: none configured", err: ErrNotFound, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{DeleteFunc: func(ctx context.Context, uid string) error { return tc.err }}
+			c, rec := newContext(http.MethodDelete, "/api/v1/me/share-branding", "")
+
+			require.NoError(t, NewDefaultHandler(svc, userRepoFor(userID)).Delete(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
+
+func TestDefaultHandler_CheckDomain(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: verified domain", query: "?domain=Photos.Example.com", expectedStatus: http.StatusOK},
+		{
+			name: "fail: unknown domain", query: "?domain=other.example.com", err: ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{name: "fail: lookup error", query: "?domain=x.example.com", err: errors.New("db down"),
+			expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				OwnerOfDomainFunc: func(ctx context.Context, host string) (string, error) {
+					return "owner", tc.err
+				},
+			}
+			c, rec := newContext(http.MethodGet, "/share-domains/check"+tc.query, "")
+
+			require.NoError(t, NewDefaultHandler(svc, nil).CheckDomain(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusOK {
+				assert.JSONEq(t, `{"domain":"photos.example.com"}`, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHostRouter(t *testing.T) {
+	cfg := config.ShareLinks{BaseURL: "https://api.realstaging.ai", CustomDomainTarget: target}
+	svc := &ServiceMock{
+		OwnerOfDomainFunc: func(ctx context.Context, host string) (string, error) {
+			switch host {
+			case "photos.example.com":
+				return "owner-1", nil
+			case "broken.example.com":
+				return "", errors.New("db down")
+			}
+			return "", ErrNotFound
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		host           string
+		path           string
+		expectedStatus int
+		wantOwner      string
+	}{
+		{name: "success: platform host", host: "api.realstaging.ai", path: "/api/v1/projects", expectedStatus: http.StatusOK},
+		{name: "success: IP address", host: "10.0.0.7:8080", path: "/health", expectedStatus: http.StatusOK},
+		{name: "success: unknown host", host: "internal.svc.cluster", path: "/health", expectedStatus: http.StatusOK},
+		{
+			name: "success: share page on custom domain", host: "Photos.Example.com", path: "/share/images/1/view",
+			expectedStatus: http.StatusOK, wantOwner: "owner-1",
+		},
+		{name: "success: lookup failure passes through", host: "broken.example.com", path: "/share/images/1",
+			expectedStatus: http.StatusOK},
+		{name: "fail: API route on custom domain", host: "photos.example.com", path: "/api/v1/projects",
+			expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			e.Use(HostRouter(svc, cfg))
+			e.Any("/*", func(c echo.Context) error {
+				owner, ok := DomainOwnerFromContext(c.Request().Context())
+				assert.Equal(t, tc.wantOwner != "", ok)
+				assert.Equal(t, tc.wantOwner, owner)
+				return c.NoContent(http.StatusOK)
+			})
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			req.Host = tc.host
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,341 @@
+package sharebrand
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// domainCacheTTL is how long OwnerOfDomain remembers an answer. Every request to the API
+// looks up its host, so answers are cached; a removed or changed domain stops being
+// served within this long on every instance.
+const domainCacheTTL = time.Minute
+
+// logoURLTTL is how long the logo URL of a rendered share page stays valid.
+const logoURLTTL = time.Hour
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out resolver_mock.go . Resolver
+
+// Resolver looks up the DNS records that verify a custom domain. net.DefaultResolver
+// satisfies it.
+type Resolver interface {
+	LookupCNAME(ctx context.Context, host string) (string, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// DefaultService implements Service.
+type DefaultService struct {
+	querier queries.Querier
+	// buckets checks logo uploads and presigns them for share pages. Nil fails every put
+	// request with a logo.
+	buckets  storage.Buckets
+	resolver Resolver
+	// target is the CNAME target of custom domains, empty when they are disabled.
+	target string
+	now    func() time.Time
+
+	mu      sync.Mutex
+	domains map[string]domainEntry
+}
+
+type domainEntry struct {
+	owner   string
+	expires time.Time
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService with a database.
+func NewDefaultService(db storage.Database, buckets storage.Buckets, cfg config.ShareLinks) *DefaultService {
+	return NewDefaultServiceWithQuerier(queries.New(db), buckets, net.DefaultResolver, cfg)
+}
+
+// NewDefaultServiceWithQuerier creates a new DefaultService with a custom querier and
+// resolver (for testing).
+func NewDefaultServiceWithQuerier(
+	querier queries.Querier, buckets storage.Buckets, resolver Resolver, cfg config.ShareLinks,
+) *DefaultService {
+	return &DefaultService{
+		querier:  querier,
+		buckets:  buckets,
+		resolver: resolver,
+		target:   normalizeHost(cfg.CustomDomainTarget),
+		now:      time.Now,
+		domains:  map[string]domainEntry{},
+	}
+}
+
+// Get returns the user's branding.
+func (s *DefaultService) Get(ctx context.Context, userID string) (*Branding, error) {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.querier.GetShareBranding(ctx, uid)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get share branding: %w", err)
+	}
+	return brandingFromRow(row, s.target), nil
+}
+
+// Put saves the user's branding. The logo must be the user's own upload, and the custom
+// domain must not be used by another account.
+func (s *DefaultService) Put(ctx context.Context, userID string, req PutRequest) (*Branding, error) {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.validate(s.target); err != nil {
+		return nil, err
+	}
+	params := queries.UpsertShareBrandingParams{
+		UserID:       uid,
+		PrimaryColor: req.PrimaryColor,
+		AccentColor:  req.AccentColor,
+	}
+	if req.LogoFileKey != "" {
+		logoURL, err := s.logoURL(ctx, userID, req.LogoFileKey)
+		if err != nil {
+			return nil, err
+		}
+		params.LogoUrl = pgtype.Text{String: logoURL, Valid: true}
+	}
+	if req.CustomDomain != "" {
+		params.CustomDomain = pgtype.Text{String: req.CustomDomain, Valid: true}
+		// Only used when the domain changes; keeping it keeps the current token.
+		params.DomainToken = pgtype.Text{String: newDomainToken(), Valid: true}
+	}
+
+	row, err := s.querier.UpsertShareBranding(ctx, params)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, ErrDomainTaken
+		}
+		return nil, fmt.Errorf("failed to save share branding: %w", err)
+	}
+	s.forgetDomains()
+	return brandingFromRow(row, s.target), nil
+}
+
+// Delete removes the user's branding.
+func (s *DefaultService) Delete(ctx context.Context, userID string) error {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return err
+	}
+	n, err := s.querier.DeleteShareBranding(ctx, uid)
+	if err != nil {
+		return fmt.Errorf("failed to delete share branding: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	s.forgetDomains()
+	return nil
+}
+
+// VerifyDomain checks that the user's custom domain is a CNAME of the configured target and
+// carries its verification TXT record. Verified domains stay verified until they change.
+func (s *DefaultService) VerifyDomain(ctx context.Context, userID string) (*Branding, error) {
+	b, err := s.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if b.Domain == nil {
+		return nil, fmt.Errorf("%w: no custom domain is set", ErrInvalid)
+	}
+	if b.Domain.Status == DomainStatusVerified {
+		return b, nil
+	}
+	if s.target == "" {
+		return nil, fmt.Errorf("%w: custom domains are not enabled", ErrInvalid)
+	}
+
+	cname, err := s.resolver.LookupCNAME(ctx, b.Domain.Name)
+	if err != nil || normalizeHost(cname) != s.target {
+		return nil, fmt.Errorf("%w: %s must be a CNAME of %s", ErrNotVerified, b.Domain.Name, s.target)
+	}
+	records, err := s.resolver.LookupTXT(ctx, b.Domain.TXTName)
+	if err != nil || !slices.Contains(records, b.Domain.TXTValue) {
+		return nil, fmt.Errorf("%w: TXT record %s must be %q", ErrNotVerified, b.Domain.TXTName, b.Domain.TXTValue)
+	}
+
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.querier.MarkShareBrandingDomainVerified(ctx, uid)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify custom domain: %w", err)
+	}
+	s.forgetDomains()
+	return brandingFromRow(row, s.target), nil
+}
+
+// Theme returns how the user's share pages look. A logo that can no longer be presigned is
+// left off rather than failing the page.
+func (s *DefaultService) Theme(ctx context.Context, userID string) (*Theme, error) {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.querier.GetShareBranding(ctx, uid)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return DefaultTheme(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get share branding: %w", err)
+	}
+	theme := &Theme{PrimaryColor: row.PrimaryColor, AccentColor: row.AccentColor}
+	if row.LogoUrl.Valid && s.buckets != nil {
+		theme.LogoURL, err = s.presignLogo(ctx, row.LogoUrl.String)
+		if err != nil {
+			logging.Default().Warn(ctx, "Failed to presign share page logo", "user_id", userID, "error", err)
+		}
+	}
+	return theme, nil
+}
+
+// LinkBase returns https://<domain> when the user has a verified custom domain and custom
+// domains are enabled.
+func (s *DefaultService) LinkBase(ctx context.Context, userID string) (string, error) {
+	if s.target == "" {
+		return "", nil
+	}
+	b, err := s.Get(ctx, userID)
+	if errors.Is(err, ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if b.Domain == nil || b.Domain.Status != DomainStatusVerified {
+		return "", nil
+	}
+	return "https://" + b.Domain.Name, nil
+}
+
+// OwnerOfDomain returns the user whose verified custom domain host is. Answers, including
+// misses, are cached for domainCacheTTL.
+func (s *DefaultService) OwnerOfDomain(ctx context.Context, host string) (string, error) {
+	host = normalizeHost(host)
+	if s.target == "" || host == "" {
+		return "", ErrNotFound
+	}
+
+	now := s.now()
+	s.mu.Lock()
+	entry, ok := s.domains[host]
+	s.mu.Unlock()
+	if !ok || now.After(entry.expires) {
+		row, err := s.querier.GetShareBrandingByVerifiedDomain(ctx, pgtype.Text{String: host, Valid: true})
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			entry = domainEntry{}
+		case err != nil:
+			return "", fmt.Errorf("failed to look up custom domain: %w", err)
+		default:
+			entry = domainEntry{owner: uuid.UUID(row.UserID.Bytes).String()}
+		}
+		entry.expires = now.Add(domainCacheTTL)
+		s.mu.Lock()
+		s.domains[host] = entry
+		s.mu.Unlock()
+	}
+
+	if entry.owner == "" {
+		return "", ErrNotFound
+	}
+	return entry.owner, nil
+}
+
+// forgetDomains drops cached domain lookups after a branding change on this instance.
+func (s *DefaultService) forgetDomains() {
+	s.mu.Lock()
+	clear(s.domains)
+	s.mu.Unlock()
+}
+
+func (s *DefaultService) presignLogo(ctx context.Context, rawURL string) (string, error) {
+	files, key, err := s.buckets.ForURL(ctx, rawURL)
+	if err != nil {
+		return "", err
+	}
+	return files.GeneratePresignedGetURL(ctx, key, int64(logoURLTTL.Seconds()), "")
+}
+
+// logoURL checks that key is userID's own upload of an allowed type and size, returning
+// its s3:// URL.
+func (s *DefaultService) logoURL(ctx context.Context, userID, key string) (string, error) {
+	// Presigned uploads are keyed under the uploader's ID; see storage.GeneratePresignedUploadURL.
+	if !strings.HasPrefix(key, storage.UploadPrefix(ctx, userID)) || strings.Contains(key, "..") {
+		return "", fmt.Errorf("%w: logo is not one of your uploads", ErrInvalid)
+	}
+
+	if s.buckets == nil {
+		return "", errors.New("share branding logos are unavailable: storage is not configured")
+	}
+	files, err := s.buckets.ForUser(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve logo storage: %w", err)
+	}
+	head, err := files.HeadFile(ctx, key)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return "", fmt.Errorf("%w: logo upload not found", ErrInvalid)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to check logo: %w", err)
+	}
+	if obj, ok := head.(*s3.HeadObjectOutput); ok {
+		if !storage.ValidateContentType(aws.ToString(obj.ContentType)) {
+			return "", fmt.Errorf("%w: logo must be a JPEG, PNG or WebP image", ErrInvalid)
+		}
+		if aws.ToInt64(obj.ContentLength) > MaxLogoBytes {
+			return "", fmt.Errorf("%w: logo must be 2MB or smaller", ErrInvalid)
+		}
+	}
+
+	return fmt.Sprintf("s3://%s/%s", files.BucketName(), key), nil
+}
+
+// normalizeHost lowercases host and drops its port and trailing dot.
+func normalizeHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.TrimSuffix(host, ".")
+}
+
+func parseUUID(id string) (pgtype.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf("invalid user ID: %w", err)
+	}
+	return pgtype.UUID{Bytes: parsed, Valid: true}, nil
+}
@@ -0,0 +1,354 @@
+package sharebrand
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+const target = "domains.realstaging.ai"
+
+func text(s string) pgtype.Text { return pgtype.Text{String: s, Valid: true} }
+
+func brandingRow(userID uuid.UUID, domain string, verified bool) *queries.ShareBranding {
+	row := &queries.ShareBranding{
+		UserID:       pgtype.UUID{Bytes: userID, Valid: true},
+		PrimaryColor: DefaultPrimaryColor,
+		AccentColor:  DefaultAccentColor,
+		UpdatedAt:    pgtype.Timestamptz{Time: time.Unix(1700000000, 0).UTC(), Valid: true},
+	}
+	if domain != "" {
+		row.CustomDomain, row.DomainToken = text(domain), text("realstaging-verify=token")
+	}
+	if verified {
+		row.DomainVerifiedAt = pgtype.Timestamptz{Time: time.Unix(1700000000, 0).UTC(), Valid: true}
+	}
+	return row
+}
+
+func TestDefaultService_Put(t *testing.T) {
+	userID := uuid.New()
+	logoKey := fmt.Sprintf("uploads/%s/logo.png", userID)
+	png := &s3.HeadObjectOutput{ContentType: aws.String("image/png"), ContentLength: aws.Int64(40 << 10)}
+
+	testCases := []struct {
+		name      string
+		target    string
+		req       PutRequest
+		head      interface{}
+		upsertErr error
+		wantRow   queries.UpsertShareBrandingParams
+		wantErr   error
+	}{
+		{
+			name:    "success: defaults",
+			target:  target,
+			req:     PutRequest{},
+			wantRow: queries.UpsertShareBrandingParams{PrimaryColor: DefaultPrimaryColor, AccentColor: DefaultAccentColor},
+		},
+		{
+			name:   "success: colors, logo and domain",
+			target: target,
+			req: PutRequest{
+				PrimaryColor: "#AABBCC", AccentColor: " #00ff00 ", LogoFileKey: logoKey, CustomDomain: "Photos.Example.com.",
+			},
+			head: png,
+			wantRow: queries.UpsertShareBrandingParams{
+				PrimaryColor: "#aabbcc", AccentColor: "#00ff00", LogoUrl: text("s3://bucket/" + logoKey),
+				CustomDomain: text("photos.example.com"),
+			},
+		},
+		{name: "fail: short color", target: target, req: PutRequest{PrimaryColor: "#abc"}, wantErr: ErrInvalid},
+		{name: "fail: named color", target: target, req: PutRequest{AccentColor: "red"}, wantErr: ErrInvalid},
+		{
+			name:    "fail: custom domains disabled",
+			req:     PutRequest{CustomDomain: "photos.example.com"},
+			wantErr: ErrInvalid,
+		},
+		{name: "fail: not a host", target: target, req: PutRequest{CustomDomain: "https://x.com/"}, wantErr: ErrInvalid},
+		{name: "fail: IP address", target: target, req: PutRequest{CustomDomain: "10.0.0.1"}, wantErr: ErrInvalid},
+		{name: "fail: platform host", target: target, req: PutRequest{CustomDomain: "a." + target}, wantErr: ErrInvalid},
+		{
+			name:    "fail: another user's logo",
+			target:  target,
+			req:     PutRequest{LogoFileKey: fmt.Sprintf("uploads/%s/logo.png", uuid.New())},
+			wantErr: ErrInvalid,
+		},
+		{
+			name:      "fail: domain taken",
+			target:    target,
+			req:       PutRequest{CustomDomain: "photos.example.com"},
+			upsertErr: &pgconn.PgError{Code: "23505"},
+			wantErr:   ErrDomainTaken,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				UpsertShareBrandingFunc: func(
+					ctx context.Context, arg queries.UpsertShareBrandingParams,
+				) (*queries.ShareBranding, error) {
+					if tc.upsertErr != nil {
+						return nil, tc.upsertErr
+					}
+					return &queries.ShareBranding{
+						UserID: arg.UserID, PrimaryColor: arg.PrimaryColor, AccentColor: arg.AccentColor,
+						LogoUrl: arg.LogoUrl, CustomDomain: arg.CustomDomain, DomainToken: arg.DomainToken,
+					}, nil
+				},
+			}
+			buckets := storage.NewPlatformBuckets(&storage.S3ServiceMock{
+				HeadFileFunc: func(ctx context.Context, fileKey string) (interface{}, error) {
+					return tc.head, nil
+				},
+				BucketNameFunc: func() string { return "bucket" },
+			})
+			svc := NewDefaultServiceWithQuerier(q, buckets, &ResolverMock{}, config.ShareLinks{CustomDomainTarget: tc.target})
+
+			b, err := svc.Put(context.Background(), userID.String(), tc.req)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				if !errors.Is(tc.wantErr, ErrDomainTaken) {
+					assert.Empty(t, q.UpsertShareBrandingCalls())
+				}
+				return
+			}
+			require.NoError(t, err)
+			calls := q.UpsertShareBrandingCalls()
+			require.Len(t, calls, 1)
+			got := calls[0].Arg
+			assert.Equal(t, userID, uuid.UUID(got.UserID.Bytes))
+			assert.Equal(t, tc.wantRow.PrimaryColor, got.PrimaryColor)
+			assert.Equal(t, tc.wantRow.AccentColor, got.AccentColor)
+			assert.Equal(t, tc.wantRow.LogoUrl, got.LogoUrl)
+			assert.Equal(t, tc.wantRow.CustomDomain, got.CustomDomain)
+			assert.Equal(t, got.CustomDomain.Valid, got.DomainToken.Valid)
+			if tc.req.CustomDomain != "" {
+				require.NotNil(t, b.Domain)
+				assert.Equal(t, DomainStatusPending, b.Domain.Status)
+				assert.Equal(t, target, b.Domain.CNAMETarget)
+				assert.Equal(t, VerificationPrefix+".photos.example.com", b.Domain.TXTName)
+				assert.Equal(t, got.DomainToken.String, b.Domain.TXTValue)
+			}
+			if tc.req.LogoFileKey != "" {
+				assert.Equal(t, logoKey, b.LogoFileKey)
+			}
+		})
+	}
+}
+
+func TestDefaultService_VerifyDomain(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name       string
+		row        *queries.ShareBranding
+		cname      string
+		txt        []string
+		wantErr    error
+		wantMarked bool
+	}{
+		{
+			name:       "success: records match",
+			row:        brandingRow(userID, "photos.example.com", false),
+			cname:      "Domains.RealStaging.ai.",
+			txt:        []string{"other", "realstaging-verify=token"},
+			wantMarked: true,
+		},
+		{name: "success: already verified", row: brandingRow(userID, "photos.example.com", true)},
+		{name: "fail: no branding", wantErr: ErrNotFound},
+		{name: "fail: no domain", row: brandingRow(userID, "", false), wantErr: ErrInvalid},
+		{
+			name:    "fail: wrong CNAME",
+			row:     brandingRow(userID, "photos.example.com", false),
+			cname:   "elsewhere.example.net.",
+			txt:     []string{"realstaging-verify=token"},
+			wantErr: ErrNotVerified,
+		},
+		{
+			name:    "fail: missing TXT",
+			row:     brandingRow(userID, "photos.example.com", false),
+			cname:   target + ".",
+			wantErr: ErrNotVerified,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				GetShareBrandingFunc: func(ctx context.Context, uid pgtype.UUID) (*queries.ShareBranding, error) {
+					if tc.row == nil {
+						return nil, pgx.ErrNoRows
+					}
+					return tc.row, nil
+				},
+				MarkShareBrandingDomainVerifiedFunc: func(ctx context.Context, uid pgtype.UUID) (*queries.ShareBranding, error) {
+					return brandingRow(userID, "photos.example.com", true), nil
+				},
+			}
+			resolver := &ResolverMock{
+				LookupCNAMEFunc: func(ctx context.Context, host string) (string, error) {
+					assert.Equal(t, "photos.example.com", host)
+					return tc.cname, nil
+				},
+				LookupTXTFunc: func(ctx context.Context, name string) ([]string, error) {
+					assert.Equal(t, VerificationPrefix+".photos.example.com", name)
+					return tc.txt, nil
+				},
+			}
+			svc := NewDefaultServiceWithQuerier(q, nil, resolver, config.ShareLinks{CustomDomainTarget: target})
+
+			b, err := svc.VerifyDomain(context.Background(), userID.String())
+			marks := 0
+			if tc.wantMarked {
+				marks = 1
+			}
+			assert.Len(t, q.MarkShareBrandingDomainVerifiedCalls(), marks)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, DomainStatusVerified, b.Domain.Status)
+			assert.NotNil(t, b.Domain.VerifiedAt)
+		})
+	}
+}
+
+func TestDefaultService_OwnerOfDomain(t *testing.T) {
+	userID := uuid.New()
+	lookups := 0
+	q := &queries.QuerierMock{
+		GetShareBrandingByVerifiedDomainFunc: func(ctx context.Context, domain pgtype.Text) (*queries.ShareBranding, error) {
+			lookups++
+			if domain.String != "photos.example.com" {
+				return nil, pgx.ErrNoRows
+			}
+			return brandingRow(userID, domain.String, true), nil
+		},
+	}
+	now := time.Unix(1700000000, 0)
+	svc := NewDefaultServiceWithQuerier(q, nil, &ResolverMock{}, config.ShareLinks{CustomDomainTarget: target})
+	svc.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	owner, err := svc.OwnerOfDomain(ctx, "Photos.Example.com:443")
+	require.NoError(t, err)
+	assert.Equal(t, userID.String(), owner)
+
+	_, err = svc.OwnerOfDomain(ctx, "unknown.example.com")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Both answers are cached until they expire.
+	_, _ = svc.OwnerOfDomain(ctx, "photos.example.com")
+	_, _ = svc.OwnerOfDomain(ctx, "unknown.example.com")
+	assert.Equal(t, 2, lookups)
+
+	now = now.Add(domainCacheTTL + time.Second)
+	_, _ = svc.OwnerOfDomain(ctx, "photos.example.com")
+	assert.Equal(t, 3, lookups)
+
+	disabled := NewDefaultServiceWithQuerier(q, nil, &ResolverMock{}, config.ShareLinks{})
+	_, err = disabled.OwnerOfDomain(ctx, "photos.example.com")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestDefaultService_Theme(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name    string
+		row     *queries.ShareBranding
+		getErr  error
+		want    *Theme
+		wantErr bool
+	}{
+		{name: "success: default theme", getErr: pgx.ErrNoRows, want: DefaultTheme()},
+		{
+			name: "success: branded with logo",
+			row: &queries.ShareBranding{
+				PrimaryColor: "#000000", AccentColor: "#ffffff", LogoUrl: text("s3://bucket/uploads/u/logo.png"),
+			},
+			want: &Theme{PrimaryColor: "#000000", AccentColor: "#ffffff", LogoURL: "https://signed/uploads/u/logo.png"},
+		},
+		{name: "fail: db error", getErr: errors.New("db down"), wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				GetShareBrandingFunc: func(ctx context.Context, uid pgtype.UUID) (*queries.ShareBranding, error) {
+					return tc.row, tc.getErr
+				},
+			}
+			buckets := storage.NewPlatformBuckets(&storage.S3ServiceMock{
+				BucketNameFunc: func() string { return "bucket" },
+				GeneratePresignedGetURLFunc: func(
+					ctx context.Context, fileKey string, expiresInSeconds int64, contentDisposition string,
+				) (string, error) {
+					return "https://signed/" + fileKey, nil
+				},
+			})
+			svc := NewDefaultServiceWithQuerier(q, buckets, &ResolverMock{}, config.ShareLinks{})
+
+			theme, err := svc.Theme(context.Background(), userID.String())
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, theme)
+		})
+	}
+}
+
+func TestDefaultService_LinkBase(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name   string
+		target string
+		row    *queries.ShareBranding
+		want   string
+	}{
+		{name: "success: verified domain", target: target, row: brandingRow(userID, "photos.example.com", true),
+			want: "https://photos.example.com"},
+		{name: "success: pending domain", target: target, row: brandingRow(userID, "photos.example.com", false)},
+		{name: "success: no branding", target: target},
+		{name: "success: custom domains disabled", row: brandingRow(userID, "photos.example.com", true)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				GetShareBrandingFunc: func(ctx context.Context, uid pgtype.UUID) (*queries.ShareBranding, error) {
+					if tc.row == nil {
+						return nil, pgx.ErrNoRows
+					}
+					return tc.row, nil
+				},
+			}
+			svc := NewDefaultServiceWithQuerier(q, nil, &ResolverMock{}, config.ShareLinks{CustomDomainTarget: tc.target})
+
+			base, err := svc.LinkBase(context.Background(), userID.String())
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, base)
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package sharebrand
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP-level contract for share branding endpoints.
+// Implementations should be wired to Echo routes in the server.
+type Handler interface {
+	Get(c echo.Context) error
+	Put(c echo.Context) error
+	Delete(c echo.Context) error
+	VerifyDomain(c echo.Context) error
+	CheckDomain(c echo.Context) error
+}
@@ -0,0 +1,71 @@
+package sharebrand
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+type domainOwnerKey struct{}
+
+// WithDomainOwner returns a copy of ctx recording that the request came in on userID's
+// custom domain.
+func WithDomainOwner(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, domainOwnerKey{}, userID)
+}
+
+// DomainOwnerFromContext returns the user whose custom domain the request came in on, or
+// false when it came in on one of the platform's hosts.
+func DomainOwnerFromContext(ctx context.Context) (string, bool) {
+	userID, ok := ctx.Value(domainOwnerKey{}).(string)
+	return userID, ok
+}
+
+// HostRouter serves share pages on verified custom domains. Requests to the platform's own
+// hosts pass through untouched. On a custom domain only /share/ routes are served, with
+// the domain's owner in the request context so links to other accounts' images are
+// refused; anything else is not found. It does nothing when custom domains are disabled.
+func HostRouter(svc Service, cfg config.ShareLinks) echo.MiddlewareFunc {
+	platform := map[string]bool{"localhost": true}
+	if u, err := url.Parse(cfg.BaseURL); err == nil && u.Hostname() != "" {
+		platform[normalizeHost(u.Hostname())] = true
+	}
+	target := normalizeHost(cfg.CustomDomainTarget)
+	platform[target] = true
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if target == "" {
+			return next
+		}
+		return func(c echo.Context) error {
+			host := normalizeHost(c.Request().Host)
+			if host == "" || platform[host] || net.ParseIP(host) != nil || !strings.Contains(host, ".") {
+				return next(c)
+			}
+
+			ctx := c.Request().Context()
+			owner, err := svc.OwnerOfDomain(ctx, host)
+			if errors.Is(err, ErrNotFound) {
+				return next(c)
+			}
+			if err != nil {
+				// Fail open: the request is still subject to every route's own checks.
+				logging.Default().Warn(ctx, "Failed to look up custom share domain", "host", host, "error", err)
+				return next(c)
+			}
+			if !strings.HasPrefix(c.Request().URL.Path, "/share/") {
+				return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Not found"})
+			}
+			c.SetRequest(c.Request().WithContext(WithDomainOwner(ctx, owner)))
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package sharebrand
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service manages share page branding. Account calls are scoped to the user.
+type Service interface {
+	// Get returns the user's branding, or ErrNotFound.
+	Get(ctx context.Context, userID string) (*Branding, error)
+	// Put saves the user's branding, replacing any earlier one.
+	Put(ctx context.Context, userID string, req PutRequest) (*Branding, error)
+	// Delete removes the user's branding; share links go back to the platform's host.
+	Delete(ctx context.Context, userID string) error
+	// VerifyDomain checks the DNS records of the user's custom domain and marks it verified,
+	// or returns ErrNotVerified with what is missing.
+	VerifyDomain(ctx context.Context, userID string) (*Branding, error)
+	// Theme returns how the user's share pages look, the default theme when they set none.
+	Theme(ctx context.Context, userID string) (*Theme, error)
+	// LinkBase returns the origin the user's share links point at, their verified custom
+	// domain, or "" to use the platform's.
+	LinkBase(ctx context.Context, userID string) (string, error)
+	// OwnerOfDomain returns the ID of the user whose verified custom domain host is, or
+	// ErrNotFound.
+	OwnerOfDomain(ctx context.Context, host string) (string, error)
+}
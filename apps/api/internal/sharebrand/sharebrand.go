@@ -0,0 +1,187 @@
+// Package sharebrand manages how an account's public share pages look: brand colors, a
+// logo and an optional custom domain the pages are served on. The account points the
+// domain at the platform with a CNAME record and proves control of it with a TXT record;
+// until then links keep using the platform's own host. A proxy in front of the API issues
+// certificates for custom domains on demand, asking the API which domains are verified.
+package sharebrand
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// Colors of share pages for accounts that set none, matching the share_brandings defaults.
+const (
+	DefaultPrimaryColor = "#111827"
+	DefaultAccentColor  = "#2563eb"
+)
+
+// Custom domain statuses.
+const (
+	DomainStatusPending  = "pending"
+	DomainStatusVerified = "verified"
+)
+
+// VerificationPrefix is the label under the custom domain that carries its verification
+// TXT record, e.g. _realstaging-challenge.photos.example.com.
+const VerificationPrefix = "_realstaging-challenge"
+
+// MaxLogoBytes caps the size of a logo upload.
+const MaxLogoBytes = 2 << 20
+
+var (
+	// ErrNotFound is returned when the account has no branding, or a host is not a verified
+	// custom domain.
+	ErrNotFound = errors.New("share branding not found")
+	// ErrInvalid is returned for malformed branding settings or an unusable logo.
+	ErrInvalid = errors.New("invalid share branding")
+	// ErrDomainTaken is returned when another account already uses the custom domain.
+	ErrDomainTaken = errors.New("custom domain is already in use")
+	// ErrNotVerified is returned when the custom domain's DNS records are missing or wrong.
+	ErrNotVerified = errors.New("custom domain could not be verified")
+)
+
+var colorPattern = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+
+// Branding is an account's share page branding.
+type Branding struct {
+	PrimaryColor string `json:"primary_color"`
+	AccentColor  string `json:"accent_color"`
+	// LogoFileKey is the key of the logo upload, omitted when the pages show none.
+	LogoFileKey string `json:"logo_file_key,omitempty"`
+	// Domain is the custom domain, omitted when links use the platform's host.
+	Domain    *Domain   `json:"custom_domain,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Domain is a custom domain with the DNS records that verify it.
+type Domain struct {
+	Name       string     `json:"name"`
+	Status     string     `json:"status"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+	// CNAMETarget is the host Name must be a CNAME of.
+	CNAMETarget string `json:"cname_target"`
+	// TXTName and TXTValue are the TXT record that proves control of Name.
+	TXTName  string `json:"txt_name"`
+	TXTValue string `json:"txt_value"`
+}
+
+// PutRequest sets the account's branding, replacing any earlier one.
+type PutRequest struct {
+	// PrimaryColor and AccentColor are #rrggbb colors; each defaults to the platform's.
+	PrimaryColor string `json:"primary_color"`
+	AccentColor  string `json:"accent_color"`
+	// LogoFileKey is the key of the logo, uploaded through /uploads/presign. Empty shows no logo.
+	LogoFileKey string `json:"logo_file_key"`
+	// CustomDomain is the host to serve share pages on, e.g. "photos.example.com". Changing it
+	// starts verification over; empty uses the platform's host.
+	CustomDomain string `json:"custom_domain"`
+}
+
+// Theme is how a share page looks.
+type Theme struct {
+	PrimaryColor string
+	AccentColor  string
+	// LogoURL is a short-lived URL of the logo, empty when the page shows none.
+	LogoURL string
+}
+
+// DefaultTheme is the look of share pages for accounts without branding.
+func DefaultTheme() *Theme {
+	return &Theme{PrimaryColor: DefaultPrimaryColor, AccentColor: DefaultAccentColor}
+}
+
+// validate checks r and fills in the defaults. target is the configured CNAME target,
+// empty when custom domains are disabled.
+func (r *PutRequest) validate(target string) error {
+	var err error
+	if r.PrimaryColor, err = normalizeColor("primary_color", r.PrimaryColor, DefaultPrimaryColor); err != nil {
+		return err
+	}
+	if r.AccentColor, err = normalizeColor("accent_color", r.AccentColor, DefaultAccentColor); err != nil {
+		return err
+	}
+	r.LogoFileKey = strings.TrimSpace(r.LogoFileKey)
+
+	r.CustomDomain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(r.CustomDomain)), ".")
+	if r.CustomDomain == "" {
+		return nil
+	}
+	if target == "" {
+		return fmt.Errorf("%w: custom domains are not enabled", ErrInvalid)
+	}
+	if !validDomain(r.CustomDomain) || r.CustomDomain == target || strings.HasSuffix(r.CustomDomain, "."+target) {
+		return fmt.Errorf("%w: custom_domain must be a host name such as photos.example.com", ErrInvalid)
+	}
+	return nil
+}
+
+func normalizeColor(field, value, fallback string) (string, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return fallback, nil
+	}
+	if !colorPattern.MatchString(value) {
+		return "", fmt.Errorf("%w: %s must be a #rrggbb color", ErrInvalid, field)
+	}
+	return value, nil
+}
+
+// validDomain reports whether host is a dotted DNS name that is not an IP address.
+func validDomain(host string) bool {
+	if len(host) > 253 || !strings.Contains(host, ".") || net.ParseIP(host) != nil {
+		return false
+	}
+	for _, label := range strings.Split(host, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, r := range label {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// brandingFromRow converts a share_brandings row. target is the configured CNAME target.
+func brandingFromRow(row *queries.ShareBranding, target string) *Branding {
+	b := &Branding{
+		PrimaryColor: row.PrimaryColor,
+		AccentColor:  row.AccentColor,
+		UpdatedAt:    row.UpdatedAt.Time,
+	}
+	if row.LogoUrl.Valid {
+		_, b.LogoFileKey, _ = storage.ParseObjectURL(row.LogoUrl.String)
+	}
+	if row.CustomDomain.Valid {
+		d := &Domain{
+			Name:        row.CustomDomain.String,
+			Status:      DomainStatusPending,
+			CNAMETarget: target,
+			TXTName:     VerificationPrefix + "." + row.CustomDomain.String,
+			TXTValue:    row.DomainToken.String,
+		}
+		if row.DomainVerifiedAt.Valid {
+			verifiedAt := row.DomainVerifiedAt.Time
+			d.Status, d.VerifiedAt = DomainStatusVerified, &verifiedAt
+		}
+		b.Domain = d
+	}
+	return b
+}
+
+// newDomainToken returns a fresh value for a domain's verification TXT record.
+func newDomainToken() string {
+	return "realstaging-verify=" + strings.ReplaceAll(uuid.NewString(), "-", "")
+}
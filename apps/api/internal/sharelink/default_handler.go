@@ -0,0 +1,180 @@
+package sharelink
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/sharebrand"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// DefaultHandler serves share links over HTTP.
+type DefaultHandler struct {
+	service  Service
+	userRepo user.Repository
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, userRepo user.Repository) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo}
+}
+
+// ErrorResponse is a simple JSON error envelope for handler responses.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// CreateImageLink handles POST /api/v1/images/:id/share-links.
+func (h *DefaultHandler) CreateImageLink(c echo.Context) error {
+	var req CreateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid request format"})
+	}
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	link, err := h.service.CreateImageLink(c.Request().Context(), userID, c.Param("id"), req)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusCreated, link)
+}
+
+// Revoke handles POST /api/v1/projects/:project_id/share-links/revoke.
+func (h *DefaultHandler) Revoke(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	version, err := h.service.Revoke(c.Request().Context(), userID, c.Param("project_id"))
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, version)
+}
+
+// Resolve handles GET /share/images/:id, redirecting valid links to a short-lived storage URL.
+// The redirect is not cacheable, so a revoked link stops working on the next request.
+func (h *DefaultHandler) Resolve(c echo.Context) error {
+	c.Response().Header().Set("Cache-Control", "no-store")
+	c.Response().Header().Set("Referrer-Policy", "no-referrer")
+
+	p, err := linkParams(c)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+
+	target, err := h.service.Resolve(c.Request().Context(), c.Param("id"), p, referrerHost(c.Request()))
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.Redirect(http.StatusFound, target)
+}
+
+// Page handles GET /share/images/:id/view, showing the image of a valid link on a page
+// themed with the account's share branding. Like the redirect, the page is not cacheable.
+func (h *DefaultHandler) Page(c echo.Context) error {
+	c.Response().Header().Set("Cache-Control", "no-store")
+	c.Response().Header().Set("Referrer-Policy", "no-referrer")
+	c.Response().Header().Set("Content-Security-Policy", pageCSP)
+
+	p, err := linkParams(c)
+	if err != nil {
+		return h.writePageError(c, err)
+	}
+
+	page, err := h.service.View(c.Request().Context(), c.Param("id"), p, referrerHost(c.Request()))
+	if err != nil {
+		return h.writePageError(c, err)
+	}
+	return renderPage(c, http.StatusOK, pageData{Page: page, Theme: page.Theme})
+}
+
+// linkParams reads a link's signed query parameters.
+func linkParams(c echo.Context) (Params, error) {
+	p := Params{Kind: c.QueryParam("kind"), Download: c.QueryParam("dl") == "1", Signature: c.QueryParam("sig")}
+	if origins := c.QueryParam("origins"); origins != "" {
+		p.Origins = strings.Split(origins, ",")
+	}
+	expires, errExp := strconv.ParseInt(c.QueryParam("exp"), 10, 64)
+	version, errVer := strconv.ParseInt(c.QueryParam("v"), 10, 32)
+	if errExp != nil || errVer != nil || p.Signature == "" {
+		return Params{}, ErrBadSignature
+	}
+	p.Expires, p.KeyVersion = expires, int32(version)
+	return p, nil
+}
+
+// referrerHost returns the host of the page a link was opened from: the Origin header of
+// cross-origin requests, otherwise the Referer of embedded images and followed links.
+func referrerHost(r *http.Request) string {
+	for _, v := range []string{r.Header.Get("Origin"), r.Header.Get("Referer")} {
+		if v == "" || v == "null" {
+			continue
+		}
+		if u, err := url.Parse(v); err == nil && u.Hostname() != "" {
+			return strings.ToLower(u.Hostname())
+		}
+	}
+	return ""
+}
+
+func unauthorized(c echo.Context) error {
+	return c.JSON(http.StatusUnauthorized, ErrorResponse{
+		Error:   "unauthorized",
+		Message: "Unable to resolve current user",
+	})
+}
+
+// writeError maps service errors to responses.
+func (h *DefaultHandler) writeError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, ErrInvalid):
+		return c.JSON(http.StatusUnprocessableEntity, ErrorResponse{Error: "validation_failed", Message: err.Error()})
+	case errors.Is(err, ErrNotFound):
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Image or project not found"})
+	case errors.Is(err, ErrNotStaged):
+		return c.JSON(http.StatusConflict, ErrorResponse{Error: "conflict", Message: err.Error()})
+	case errors.Is(err, ErrBadSignature), errors.Is(err, ErrOriginNotAllowed):
+		return c.JSON(http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: err.Error()})
+	case errors.Is(err, ErrRevoked), errors.Is(err, ErrExpired):
+		return c.JSON(http.StatusGone, ErrorResponse{Error: "gone", Message: err.Error()})
+	default:
+		c.Logger().Errorf("Share link request failed: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to process share link request",
+		})
+	}
+}
+
+// writePageError renders a share page for a link that cannot be shown, with the default theme
+// since the link's owner is not known.
+func (h *DefaultHandler) writePageError(c echo.Context, err error) error {
+	status, message := http.StatusInternalServerError, "This image could not be loaded. Please try again later."
+	switch {
+	case errors.Is(err, ErrNotFound):
+		status, message = http.StatusNotFound, "This image is no longer available."
+	case errors.Is(err, ErrNotStaged):
+		status, message = http.StatusConflict, "This image is still being staged."
+	case errors.Is(err, ErrBadSignature), errors.Is(err, ErrOriginNotAllowed):
+		status, message = http.StatusForbidden, "This link is not valid here."
+	case errors.Is(err, ErrRevoked), errors.Is(err, ErrExpired):
+		status, message = http.StatusGone, "This link has expired."
+	default:
+		c.Logger().Errorf("Share page request failed: %v", err)
+	}
+	return renderPage(c, status, pageData{Error: message, Theme: *sharebrand.DefaultTheme()})
+}
@@ -0,0 +1,250 @@
+package sharelink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/sharebrand"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func userRepoFor(userID uuid.UUID) *user.RepositoryMock {
+	return &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+		},
+	}
+}
+
+func TestDefaultHandler_CreateImageLink(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: created", body: `{"kind":"staged","expires_in":3600}`, expectedStatus: http.StatusCreated},
+		{name: "fail: malformed body", body: `{`, expectedStatus: http.StatusBadRequest},
+		{name: "fail: invalid", body: `{}`, err: ErrInvalid, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "fail: not found", body: `{}`, err: ErrNotFound, expectedStatus: http.StatusNotFound},
+		{name: "fail: not staged", body: `{}`, err: ErrNotStaged, expectedStatus: http.StatusConflict},
+		{
+			name:           "fail: service error",
+			body:           `{}`,
+			err:            errors.New("db down"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				CreateImageLinkFunc: func(ctx context.Context, uid, imageID string, req CreateRequest) (*Link, error) {
+					assert.Equal(t, userID.String(), uid)
+					assert.Equal(t, "img-1", imageID)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					assert.Equal(t, CreateRequest{Kind: KindStaged, ExpiresIn: 3600}, req)
+					return &Link{URL: "https://api.example.com/share/images/img-1?sig=x", Kind: KindStaged}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, userRepoFor(userID))
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/images/img-1/share-links", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("X-Test-User", "auth0|user")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("img-1")
+
+			require.NoError(t, h.CreateImageLink(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusCreated {
+				assert.Contains(t, rec.Body.String(), `"url":"https://api.example.com/share/images/img-1?sig=x"`)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_Revoke(t *testing.T) {
+	userID := uuid.New()
+	svc := &ServiceMock{
+		RevokeFunc: func(ctx context.Context, uid, projectID string) (*KeyVersion, error) {
+			if projectID != "proj-1" {
+				return nil, ErrNotFound
+			}
+			return &KeyVersion{ProjectID: projectID, KeyVersion: 3}, nil
+		},
+	}
+	h := NewDefaultHandler(svc, userRepoFor(userID))
+
+	for projectID, want := range map[string]int{"proj-1": http.StatusOK, "other": http.StatusNotFound} {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/projects/"+projectID+"/share-links/revoke", nil)
+		req.Header.Set("X-Test-User", "auth0|user")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("project_id")
+		c.SetParamValues(projectID)
+
+		require.NoError(t, h.Revoke(c))
+		assert.Equal(t, want, rec.Code, projectID)
+	}
+}
+
+func TestDefaultHandler_Resolve(t *testing.T) {
+	const query = "?kind=staged&exp=1777777777&v=2&dl=1&sig=abc"
+
+	testCases := []struct {
+		name           string
+		query          string
+		headers        map[string]string
+		wantOrigins    []string
+		wantReferrer   string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: redirects", query: query, expectedStatus: http.StatusFound},
+		{
+			name:           "success: restricted link opened from an embedding page",
+			query:          query + "&origins=listings.example.com,*.brokerage.com",
+			headers:        map[string]string{"Referer": "https://Listings.Example.com/homes/12"},
+			wantOrigins:    []string{"listings.example.com", "*.brokerage.com"},
+			wantReferrer:   "listings.example.com",
+			expectedStatus: http.StatusFound,
+		},
+		{
+			name:           "success: origin header preferred over referer",
+			query:          query,
+			headers:        map[string]string{"Origin": "https://www.brokerage.com", "Referer": "https://other.io/"},
+			wantReferrer:   "www.brokerage.com",
+			expectedStatus: http.StatusFound,
+		},
+		{name: "fail: origin not allowed", query: query, err: ErrOriginNotAllowed, expectedStatus: http.StatusForbidden},
+		{name: "fail: missing signature", query: "?kind=staged&exp=1&v=1", expectedStatus: http.StatusForbidden},
+		{name: "fail: malformed expiry", query: "?exp=soon&v=1&sig=abc", expectedStatus: http.StatusForbidden},
+		{name: "fail: bad signature", query: query, err: ErrBadSignature, expectedStatus: http.StatusForbidden},
+		{name: "fail: revoked", query: query, err: ErrRevoked, expectedStatus: http.StatusGone},
+		{name: "fail: expired", query: query, err: ErrExpired, expectedStatus: http.StatusGone},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				ResolveFunc: func(ctx context.Context, imageID string, p Params, referrer string) (string, error) {
+					assert.Equal(t, Params{
+						Kind: KindStaged, Expires: 1777777777, KeyVersion: 2, Download: true,
+						Origins: tc.wantOrigins, Signature: "abc",
+					}, p)
+					assert.Equal(t, tc.wantReferrer, referrer)
+					if tc.err != nil {
+						return "", tc.err
+					}
+					return "https://s3.example.com/staged/out.jpg?X-Amz-Signature=y", nil
+				},
+			}
+			h := NewDefaultHandler(svc, nil)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/share/images/img-1%s", tc.query), nil)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("img-1")
+
+			require.NoError(t, h.Resolve(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+			assert.Equal(t, "no-referrer", rec.Header().Get("Referrer-Policy"))
+			if tc.expectedStatus == http.StatusFound {
+				assert.Equal(t, "https://s3.example.com/staged/out.jpg?X-Amz-Signature=y", rec.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_Page(t *testing.T) {
+	const query = "?kind=staged&exp=1777777777&v=2&dl=1&sig=abc"
+
+	testCases := []struct {
+		name           string
+		query          string
+		err            error
+		expectedStatus int
+		wantBody       []string
+	}{
+		{
+			name:           "success: branded page",
+			query:          query,
+			expectedStatus: http.StatusOK,
+			wantBody: []string{
+				`<img src="https://s3.example.com/staged/out.jpg?X-Amz-Signature=y&amp;a=1"`,
+				"background: #123456", "background: #abcdef", `<img src="https://logo.example.com/l.png"`, "Download",
+			},
+		},
+		{name: "fail: missing signature", query: "?kind=staged&exp=1&v=1", expectedStatus: http.StatusForbidden,
+			wantBody: []string{"This link is not valid here."}},
+		{name: "fail: expired", query: query, err: ErrExpired, expectedStatus: http.StatusGone,
+			wantBody: []string{"This link has expired.", "background: " + sharebrand.DefaultPrimaryColor}},
+		{name: "fail: other account's domain", query: query, err: ErrNotFound, expectedStatus: http.StatusNotFound},
+		{name: "fail: service error", query: query, err: errors.New("db down"),
+			expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				ViewFunc: func(ctx context.Context, imageID string, p Params, referrer string) (*Page, error) {
+					assert.Equal(t, "img-1", imageID)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &Page{
+						ImageURL: "https://s3.example.com/staged/out.jpg?X-Amz-Signature=y&a=1",
+						Kind:     p.Kind,
+						Download: p.Download,
+						Theme: sharebrand.Theme{
+							PrimaryColor: "#123456", AccentColor: "#abcdef", LogoURL: "https://logo.example.com/l.png",
+						},
+					}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, nil)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/share/images/img-1/view"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("img-1")
+
+			require.NoError(t, h.Page(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+			assert.Contains(t, rec.Header().Get("Content-Type"), "text/html")
+			for _, want := range tc.wantBody {
+				assert.Contains(t, rec.Body.String(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,440 @@
+package sharelink
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/notification"
+	"github.com/real-staging-ai/api/internal/sharebrand"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// DefaultService implements Service.
+type DefaultService struct {
+	querier queries.Querier
+	buckets storage.Buckets
+	signer  signer
+	cfg     config.ShareLinks
+	now     func() time.Time
+	// notifier tells the project owner when a link is opened. Nil disables notifications.
+	notifier notification.Notifier
+	// branding themes share pages and moves links to verified custom domains. Nil serves every
+	// link from BaseURL with the default theme.
+	branding sharebrand.Service
+}
+
+// Option customizes a DefaultService.
+type Option func(*DefaultService)
+
+// WithNotifier notifies the project owner when one of their share links is opened.
+func WithNotifier(notifier notification.Notifier) Option {
+	return func(s *DefaultService) { s.notifier = notifier }
+}
+
+// WithBranding themes share pages with the owner's share branding and issues links on the
+// owner's verified custom domain.
+func WithBranding(branding sharebrand.Service) Option {
+	return func(s *DefaultService) { s.branding = branding }
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService with a database.
+func NewDefaultService(
+	db storage.Database, buckets storage.Buckets, cfg config.ShareLinks, opts ...Option,
+) *DefaultService {
+	return NewDefaultServiceWithQuerier(queries.New(db), buckets, cfg, opts...)
+}
+
+// NewDefaultServiceWithQuerier creates a new DefaultService with a custom querier (for testing).
+// Without a configured secret, links are signed with a random one and stop working when the
+// process restarts; config.Validate requires a secret outside development.
+func NewDefaultServiceWithQuerier(
+	querier queries.Querier, buckets storage.Buckets, cfg config.ShareLinks, opts ...Option,
+) *DefaultService {
+	secret := []byte(cfg.Secret)
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		_, _ = rand.Read(secret)
+		logging.Default().Warn(context.Background(),
+			"share_links.secret is not set; share links will stop working on restart")
+	}
+	if cfg.DefaultTTL <= 0 {
+		cfg.DefaultTTL = 7 * 24 * time.Hour
+	}
+	if cfg.MaxTTL < cfg.DefaultTTL {
+		cfg.MaxTTL = cfg.DefaultTTL
+	}
+	if cfg.RedirectTTL <= 0 {
+		cfg.RedirectTTL = time.Minute
+	}
+	s := &DefaultService{querier: querier, buckets: buckets, signer: signer{secret: secret}, cfg: cfg, now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// CreateImageLink signs a link to one of the user's images with the project's current key.
+func (s *DefaultService) CreateImageLink(
+	ctx context.Context, userID, imageID string, req CreateRequest,
+) (*Link, error) {
+	if req.Kind == "" {
+		req.Kind = KindOriginal
+	}
+	if req.Kind != KindOriginal && req.Kind != KindStaged {
+		return nil, fmt.Errorf("%w: kind must be original or staged", ErrInvalid)
+	}
+	ttl := s.cfg.DefaultTTL
+	if req.ExpiresIn < 0 {
+		return nil, fmt.Errorf("%w: expires_in must not be negative", ErrInvalid)
+	}
+	if req.ExpiresIn > 0 {
+		ttl = time.Duration(req.ExpiresIn) * time.Second
+	}
+	if ttl > s.cfg.MaxTTL {
+		return nil, fmt.Errorf("%w: expires_in must be at most %d seconds", ErrInvalid, int64(s.cfg.MaxTTL.Seconds()))
+	}
+	origins, err := normalizeOrigins(req.AllowedOrigins)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := s.ownedImage(ctx, userID, imageID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Kind == KindStaged && (!img.StagedUrl.Valid || img.StagedUrl.String == "") {
+		return nil, ErrNotStaged
+	}
+	version, err := s.keyVersion(ctx, img.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := s.now().Add(ttl).Truncate(time.Second)
+	p := Params{Kind: req.Kind, Expires: expiresAt.Unix(), KeyVersion: version, Download: req.Download, Origins: origins}
+	p.Signature = s.signer.sign(uuid.UUID(img.ProjectID.Bytes).String(), imageID, p)
+
+	base := s.linkBase(ctx, userID)
+	return &Link{
+		URL:            linkURL(base, "/share/images/"+imageID, p),
+		PageURL:        linkURL(base, "/share/images/"+imageID+"/view", p),
+		ImageID:        imageID,
+		Kind:           req.Kind,
+		ExpiresAt:      expiresAt.UTC(),
+		KeyVersion:     version,
+		AllowedOrigins: origins,
+	}, nil
+}
+
+// Resolve verifies a link and presigns the stored file for a short redirect.
+func (s *DefaultService) Resolve(ctx context.Context, imageID string, p Params, referrer string) (string, error) {
+	signed, _, err := s.resolve(ctx, imageID, p, referrer)
+	return signed, err
+}
+
+// View verifies a link and presigns the stored file for its share page.
+func (s *DefaultService) View(ctx context.Context, imageID string, p Params, referrer string) (*Page, error) {
+	signed, img, err := s.resolve(ctx, imageID, p, referrer)
+	if err != nil {
+		return nil, err
+	}
+	page := &Page{ImageURL: signed, Kind: p.Kind, Download: p.Download, Theme: *sharebrand.DefaultTheme()}
+	if s.branding != nil {
+		owner, err := s.projectOwner(ctx, img.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		theme, err := s.branding.Theme(ctx, owner)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get share page theme: %w", err)
+		}
+		page.Theme = *theme
+	}
+	return page, nil
+}
+
+// resolve verifies a link and presigns the stored file, returning the image. On a custom
+// domain, only links to its owner's images resolve.
+func (s *DefaultService) resolve(
+	ctx context.Context, imageID string, p Params, referrer string,
+) (string, *queries.GetImageByIDRow, error) {
+	id, err := parseUUID(imageID)
+	if err != nil {
+		return "", nil, ErrBadSignature
+	}
+	img, err := s.querier.GetImageByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil, ErrNotFound
+		}
+		return "", nil, fmt.Errorf("failed to get image: %w", err)
+	}
+	if !s.signer.verify(uuid.UUID(img.ProjectID.Bytes).String(), imageID, p) {
+		return "", nil, ErrBadSignature
+	}
+	current, err := s.keyVersion(ctx, img.ProjectID)
+	if err != nil {
+		return "", nil, err
+	}
+	if p.KeyVersion != current {
+		return "", nil, ErrRevoked
+	}
+	if s.now().Unix() >= p.Expires {
+		return "", nil, ErrExpired
+	}
+	if len(p.Origins) > 0 && !originAllowed(p.Origins, referrer) {
+		return "", nil, ErrOriginNotAllowed
+	}
+	if domainOwner, ok := sharebrand.DomainOwnerFromContext(ctx); ok {
+		owner, err := s.projectOwner(ctx, img.ProjectID)
+		if err != nil {
+			return "", nil, err
+		}
+		if owner != domainOwner {
+			return "", nil, ErrNotFound
+		}
+	}
+
+	rawURL := img.OriginalUrl
+	if p.Kind == KindStaged {
+		if !img.StagedUrl.Valid || img.StagedUrl.String == "" {
+			return "", nil, ErrNotStaged
+		}
+		rawURL = img.StagedUrl.String
+		// Shared staged images carry the account's watermark when it has one.
+		if img.WatermarkedUrl.Valid && img.WatermarkedUrl.String != "" {
+			rawURL = img.WatermarkedUrl.String
+		}
+	}
+	files, fileKey, err := s.buckets.ForURL(ctx, rawURL)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to resolve storage for image %s: %w", imageID, err)
+	}
+	disposition := ""
+	if p.Download {
+		disposition = "attachment"
+	}
+	signed, err := files.GeneratePresignedGetURL(ctx, fileKey, int64(s.cfg.RedirectTTL.Seconds()), disposition)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to presign image: %w", err)
+	}
+	s.notifyViewed(ctx, img.ProjectID, imageID)
+	return signed, img, nil
+}
+
+// projectOwner returns the ID of the user who owns the project.
+func (s *DefaultService) projectOwner(ctx context.Context, projectID pgtype.UUID) (string, error) {
+	project, err := s.querier.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get project: %w", err)
+	}
+	return uuid.UUID(project.UserID.Bytes).String(), nil
+}
+
+// linkBase returns the origin the user's links point at: their verified custom domain, or
+// BaseURL. A failed lookup falls back to BaseURL, where links work just the same.
+func (s *DefaultService) linkBase(ctx context.Context, userID string) string {
+	if s.branding != nil {
+		base, err := s.branding.LinkBase(ctx, userID)
+		if err != nil {
+			logging.Default().Warn(ctx, "Failed to look up custom share domain", "user_id", userID, "error", err)
+		}
+		if base != "" {
+			return base
+		}
+	}
+	return strings.TrimRight(s.cfg.BaseURL, "/")
+}
+
+// notifyViewed tells the project's owner that a share link to the image was opened.
+// Views are collapsed per image until the owner reads the notification, so a link
+// passed around a busy listing does not flood the notification center. Failures are
+// logged and never block the redirect.
+func (s *DefaultService) notifyViewed(ctx context.Context, projectID pgtype.UUID, imageID string) {
+	if s.notifier == nil {
+		return
+	}
+	project, err := s.querier.GetProjectByID(ctx, projectID)
+	if err != nil {
+		logging.Default().Warn(ctx, "Failed to look up share link owner", "image_id", imageID, "error", err)
+		return
+	}
+	if _, err := s.notifier.Notify(ctx, notification.NewNotification{
+		UserID: uuid.UUID(project.UserID.Bytes).String(),
+		Type:   notification.TypeShareLinkViewed,
+		Title:  "Your share link was opened",
+		Body:   fmt.Sprintf("Someone viewed an image from %s.", project.Name),
+		Data: map[string]string{
+			"image_id":   imageID,
+			"project_id": uuid.UUID(projectID.Bytes).String(),
+		},
+		DedupeKey: "share_link_viewed:" + imageID,
+	}); err != nil {
+		logging.Default().Warn(ctx, "Failed to notify share link view", "image_id", imageID, "error", err)
+	}
+}
+
+// Revoke moves the user's project to the next key version.
+func (s *DefaultService) Revoke(ctx context.Context, userID, projectID string) (*KeyVersion, error) {
+	pid, err := parseUUID(projectID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	if err := s.authorizeProject(ctx, userID, pid); err != nil {
+		return nil, err
+	}
+	row, err := s.querier.RotateProjectShareKey(ctx, pid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate share key: %w", err)
+	}
+	return &KeyVersion{ProjectID: projectID, KeyVersion: row.Version, RotatedAt: row.RotatedAt.Time.UTC()}, nil
+}
+
+// ownedImage returns the image when it belongs to one of the user's projects.
+func (s *DefaultService) ownedImage(ctx context.Context, userID, imageID string) (*queries.GetImageByIDRow, error) {
+	id, err := parseUUID(imageID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	img, err := s.querier.GetImageByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get image: %w", err)
+	}
+	if err := s.authorizeProject(ctx, userID, img.ProjectID); err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+func (s *DefaultService) authorizeProject(ctx context.Context, userID string, projectID pgtype.UUID) error {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+	_, err = s.querier.GetProjectByIDAndUserID(ctx, queries.GetProjectByIDAndUserIDParams{ID: projectID, UserID: uid})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+	return nil
+}
+
+// keyVersion returns the project's current key version; projects never rotated are at 1.
+func (s *DefaultService) keyVersion(ctx context.Context, projectID pgtype.UUID) (int32, error) {
+	row, err := s.querier.GetProjectShareKey(ctx, projectID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 1, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get share key: %w", err)
+	}
+	return row.Version, nil
+}
+
+// linkURL returns base+path with p as the query.
+func linkURL(base, path string, p Params) string {
+	q := url.Values{}
+	q.Set("kind", p.Kind)
+	q.Set("exp", strconv.FormatInt(p.Expires, 10))
+	q.Set("v", strconv.FormatInt(int64(p.KeyVersion), 10))
+	if p.Download {
+		q.Set("dl", "1")
+	}
+	if len(p.Origins) > 0 {
+		q.Set("origins", strings.Join(p.Origins, ","))
+	}
+	q.Set("sig", p.Signature)
+	return base + path + "?" + q.Encode()
+}
+
+// normalizeOrigins validates a link's allowed origins and reduces each to a lowercase host
+// or "*."-prefixed wildcard, dropping duplicates.
+func normalizeOrigins(raw []string) ([]string, error) {
+	if len(raw) > MaxAllowedOrigins {
+		return nil, fmt.Errorf("%w: at most %d allowed_origins", ErrInvalid, MaxAllowedOrigins)
+	}
+	var origins []string
+	for _, o := range raw {
+		host := strings.ToLower(strings.TrimSpace(o))
+		if strings.Contains(host, "://") {
+			u, err := url.Parse(host)
+			if err != nil || (u.Path != "" && u.Path != "/") || u.RawQuery != "" {
+				return nil, fmt.Errorf("%w: allowed origin %q must be a host or an origin", ErrInvalid, o)
+			}
+			host = u.Hostname()
+		}
+		if !validHost(strings.TrimPrefix(host, "*.")) {
+			return nil, fmt.Errorf("%w: allowed origin %q must be a host or an origin", ErrInvalid, o)
+		}
+		if !slices.Contains(origins, host) {
+			origins = append(origins, host)
+		}
+	}
+	return origins, nil
+}
+
+// validHost reports whether host is a dotted DNS name, e.g. "example.com".
+func validHost(host string) bool {
+	if len(host) > 253 || !strings.Contains(host, ".") {
+		return false
+	}
+	for _, label := range strings.Split(host, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, r := range label {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// originAllowed reports whether referrer matches one of the allowed origins. A wildcard
+// matches any subdomain but not the bare domain.
+func originAllowed(origins []string, referrer string) bool {
+	if referrer == "" {
+		return false
+	}
+	for _, o := range origins {
+		if suffix, ok := strings.CutPrefix(o, "*"); ok {
+			if strings.HasSuffix(referrer, suffix) {
+				return true
+			}
+		} else if referrer == o {
+			return true
+		}
+	}
+	return false
+}
+
+func parseUUID(id string) (pgtype.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+	return pgtype.UUID{Bytes: parsed, Valid: true}, nil
+}
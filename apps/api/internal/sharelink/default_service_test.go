@@ -0,0 +1,473 @@
+package sharelink
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/notification"
+	"github.com/real-staging-ai/api/internal/sharebrand"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+type fixture struct {
+	userID    string
+	projectID uuid.UUID
+	imageID   string
+	version   int32
+	staged    bool
+	// watermarked gives the staged image a watermarked copy.
+	watermarked bool
+	querier     *queries.QuerierMock
+	files       *storage.S3ServiceMock
+	svc         *DefaultService
+	now         time.Time
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	f := &fixture{
+		userID:    uuid.NewString(),
+		projectID: uuid.New(),
+		imageID:   uuid.NewString(),
+		version:   1,
+		staged:    true,
+		now:       time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+	f.querier = &queries.QuerierMock{
+		GetImageByIDFunc: func(ctx context.Context, id pgtype.UUID) (*queries.GetImageByIDRow, error) {
+			if uuid.UUID(id.Bytes).String() != f.imageID {
+				return nil, pgx.ErrNoRows
+			}
+			row := &queries.GetImageByIDRow{
+				ID:          id,
+				ProjectID:   pgtype.UUID{Bytes: f.projectID, Valid: true},
+				OriginalUrl: "http://localhost:9000/real-staging/uploads/in.jpg",
+			}
+			if f.staged {
+				row.StagedUrl = pgtype.Text{String: "http://localhost:9000/real-staging/staged/out.jpg", Valid: true}
+			}
+			if f.watermarked {
+				row.WatermarkedUrl = pgtype.Text{
+					String: "http://localhost:9000/real-staging/staged/out-watermarked.jpg", Valid: true,
+				}
+			}
+			return row, nil
+		},
+		GetProjectByIDAndUserIDFunc: func(
+			ctx context.Context, arg queries.GetProjectByIDAndUserIDParams,
+		) (*queries.GetProjectByIDAndUserIDRow, error) {
+			if uuid.UUID(arg.ID.Bytes) != f.projectID || uuid.UUID(arg.UserID.Bytes).String() != f.userID {
+				return nil, pgx.ErrNoRows
+			}
+			return &queries.GetProjectByIDAndUserIDRow{ID: arg.ID, UserID: arg.UserID}, nil
+		},
+		GetProjectShareKeyFunc: func(ctx context.Context, projectID pgtype.UUID) (*queries.ProjectShareKey, error) {
+			if f.version == 1 {
+				return nil, pgx.ErrNoRows
+			}
+			return &queries.ProjectShareKey{ProjectID: projectID, Version: f.version}, nil
+		},
+		RotateProjectShareKeyFunc: func(ctx context.Context, projectID pgtype.UUID) (*queries.ProjectShareKey, error) {
+			f.version++
+			return &queries.ProjectShareKey{
+				ProjectID: projectID,
+				Version:   f.version,
+				RotatedAt: pgtype.Timestamptz{Time: f.now, Valid: true},
+			}, nil
+		},
+	}
+	f.files = &storage.S3ServiceMock{
+		BucketNameFunc: func() string { return "real-staging" },
+		GeneratePresignedGetURLFunc: func(
+			ctx context.Context, fileKey string, expiresInSeconds int64, contentDisposition string,
+		) (string, error) {
+			expires := strconv.FormatInt(expiresInSeconds, 10)
+			return "https://s3.example.com/" + fileKey + "?X-Amz-Expires=" + expires, nil
+		},
+	}
+	f.svc = NewDefaultServiceWithQuerier(f.querier, storage.NewPlatformBuckets(f.files), config.ShareLinks{
+		Secret:      strings.Repeat("k", 32),
+		BaseURL:     "https://api.example.com/",
+		DefaultTTL:  24 * time.Hour,
+		MaxTTL:      48 * time.Hour,
+		RedirectTTL: time.Minute,
+	})
+	f.svc.now = func() time.Time { return f.now }
+	return f
+}
+
+// paramsOf reads the signed parameters back out of an issued link.
+func paramsOf(t *testing.T, link *Link) (string, Params) {
+	t.Helper()
+	u, err := url.Parse(link.URL)
+	require.NoError(t, err)
+	q := u.Query()
+	exp, err := strconv.ParseInt(q.Get("exp"), 10, 64)
+	require.NoError(t, err)
+	v, err := strconv.ParseInt(q.Get("v"), 10, 32)
+	require.NoError(t, err)
+	return strings.TrimPrefix(u.Path, "/share/images/"), Params{
+		Kind: q.Get("kind"), Expires: exp, KeyVersion: int32(v), Download: q.Get("dl") == "1",
+		Origins: originsOf(q.Get("origins")), Signature: q.Get("sig"),
+	}
+}
+
+func originsOf(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+	return strings.Split(raw, ",")
+}
+
+func TestDefaultService_CreateImageLink(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mutate   func(f *fixture)
+		stranger bool
+		req      CreateRequest
+		wantErr  error
+		validate func(t *testing.T, f *fixture, link *Link)
+	}{
+		{
+			name: "success: default kind and lifetime",
+			validate: func(t *testing.T, f *fixture, link *Link) {
+				assert.True(t, strings.HasPrefix(link.URL, "https://api.example.com/share/images/"+f.imageID+"?"))
+				assert.Equal(t, KindOriginal, link.Kind)
+				assert.Equal(t, f.now.Add(24*time.Hour), link.ExpiresAt)
+				assert.Equal(t, int32(1), link.KeyVersion)
+			},
+		},
+		{
+			name:   "success: staged download signed with the current version",
+			mutate: func(f *fixture) { f.version = 4 },
+			req:    CreateRequest{Kind: KindStaged, ExpiresIn: 3600, Download: true},
+			validate: func(t *testing.T, f *fixture, link *Link) {
+				_, p := paramsOf(t, link)
+				assert.Equal(t, int32(4), p.KeyVersion)
+				assert.True(t, p.Download)
+				assert.Equal(t, f.now.Add(time.Hour), link.ExpiresAt)
+			},
+		},
+		{
+			name: "success: allowed origins normalized into the signed link",
+			req: CreateRequest{AllowedOrigins: []string{
+				"https://Listings.Example.com/", "*.brokerage.com", "listings.example.com",
+			}},
+			validate: func(t *testing.T, f *fixture, link *Link) {
+				want := []string{"listings.example.com", "*.brokerage.com"}
+				assert.Equal(t, want, link.AllowedOrigins)
+				_, p := paramsOf(t, link)
+				assert.Equal(t, want, p.Origins)
+			},
+		},
+		{name: "fail: unknown kind", req: CreateRequest{Kind: "thumbnail"}, wantErr: ErrInvalid},
+		{name: "fail: origin with a path", req: CreateRequest{AllowedOrigins: []string{"https://example.com/listings"}},
+			wantErr: ErrInvalid},
+		{name: "fail: bare wildcard", req: CreateRequest{AllowedOrigins: []string{"*"}}, wantErr: ErrInvalid},
+		{name: "fail: not a host", req: CreateRequest{AllowedOrigins: []string{"localhost"}}, wantErr: ErrInvalid},
+		{
+			name:    "fail: too many origins",
+			req:     CreateRequest{AllowedOrigins: make([]string, MaxAllowedOrigins+1)},
+			wantErr: ErrInvalid,
+		},
+		{name: "fail: lifetime above the maximum", req: CreateRequest{ExpiresIn: 3 * 86400}, wantErr: ErrInvalid},
+		{
+			name:     "fail: another user's image",
+			stranger: true,
+			wantErr:  ErrNotFound,
+		},
+		{
+			name:    "fail: staged before staging finished",
+			mutate:  func(f *fixture) { f.staged = false },
+			req:     CreateRequest{Kind: KindStaged},
+			wantErr: ErrNotStaged,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFixture(t)
+			if tc.mutate != nil {
+				tc.mutate(f)
+			}
+			userID := f.userID
+			if tc.stranger {
+				userID = uuid.NewString()
+			}
+
+			link, err := f.svc.CreateImageLink(context.Background(), userID, f.imageID, tc.req)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			tc.validate(t, f, link)
+		})
+	}
+}
+
+func TestDefaultService_Resolve(t *testing.T) {
+	issue := func(t *testing.T, f *fixture, req CreateRequest) (string, Params) {
+		t.Helper()
+		link, err := f.svc.CreateImageLink(context.Background(), f.userID, f.imageID, req)
+		require.NoError(t, err)
+		return paramsOf(t, link)
+	}
+
+	t.Run("success: redirects to a short-lived storage URL", func(t *testing.T) {
+		f := newFixture(t)
+		imageID, p := issue(t, f, CreateRequest{Kind: KindStaged, Download: true})
+
+		target, err := f.svc.Resolve(context.Background(), imageID, p, "")
+		require.NoError(t, err)
+		assert.Equal(t, "https://s3.example.com/staged/out.jpg?X-Amz-Expires=60", target)
+		call := f.files.GeneratePresignedGetURLCalls()[0]
+		assert.Equal(t, "attachment", call.ContentDisposition)
+	})
+
+	t.Run("success: staged links serve the watermarked copy", func(t *testing.T) {
+		f := newFixture(t)
+		f.watermarked = true
+		imageID, p := issue(t, f, CreateRequest{Kind: KindStaged})
+
+		target, err := f.svc.Resolve(context.Background(), imageID, p, "")
+		require.NoError(t, err)
+		assert.Equal(t, "https://s3.example.com/staged/out-watermarked.jpg?X-Amz-Expires=60", target)
+
+		imageID, p = issue(t, f, CreateRequest{Kind: KindOriginal})
+		target, err = f.svc.Resolve(context.Background(), imageID, p, "")
+		require.NoError(t, err)
+		assert.Equal(t, "https://s3.example.com/uploads/in.jpg?X-Amz-Expires=60", target, "originals are never watermarked")
+	})
+
+	t.Run("success: notifies the project owner of the view", func(t *testing.T) {
+		f := newFixture(t)
+		f.querier.GetProjectByIDFunc = func(ctx context.Context, id pgtype.UUID) (*queries.GetProjectByIDRow, error) {
+			owner, _ := uuid.Parse(f.userID)
+			return &queries.GetProjectByIDRow{ID: id, Name: "Maple St", UserID: pgtype.UUID{Bytes: owner, Valid: true}}, nil
+		}
+		notifier := &notification.NotifierMock{
+			NotifyFunc: func(ctx context.Context, n notification.NewNotification) (*notification.Notification, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		WithNotifier(notifier)(f.svc)
+		imageID, p := issue(t, f, CreateRequest{})
+
+		_, err := f.svc.Resolve(context.Background(), imageID, p, "")
+		require.NoError(t, err, "notify failures never block the redirect")
+		require.Len(t, notifier.NotifyCalls(), 1)
+		n := notifier.NotifyCalls()[0].N
+		assert.Equal(t, f.userID, n.UserID)
+		assert.Equal(t, notification.TypeShareLinkViewed, n.Type)
+		assert.Equal(t, "share_link_viewed:"+imageID, n.DedupeKey)
+		assert.Equal(t, f.projectID.String(), n.Data["project_id"])
+		assert.Contains(t, n.Body, "Maple St")
+	})
+
+	t.Run("success: restricted link opened from an allowed site", func(t *testing.T) {
+		f := newFixture(t)
+		imageID, p := issue(t, f, CreateRequest{AllowedOrigins: []string{"listings.example.com", "*.brokerage.com"}})
+
+		for _, referrer := range []string{"listings.example.com", "www.brokerage.com", "a.b.brokerage.com"} {
+			_, err := f.svc.Resolve(context.Background(), imageID, p, referrer)
+			assert.NoError(t, err, referrer)
+		}
+	})
+
+	t.Run("fail: restricted link opened from another site", func(t *testing.T) {
+		f := newFixture(t)
+		imageID, p := issue(t, f, CreateRequest{AllowedOrigins: []string{"listings.example.com", "*.brokerage.com"}})
+
+		for _, referrer := range []string{"", "example.com", "brokerage.com", "evil-brokerage.com", "brokerage.com.evil.io"} {
+			_, err := f.svc.Resolve(context.Background(), imageID, p, referrer)
+			assert.ErrorIs(t, err, ErrOriginNotAllowed, referrer)
+		}
+
+		// Dropping or widening the restriction breaks the signature.
+		unrestricted := p
+		unrestricted.Origins = nil
+		_, err := f.svc.Resolve(context.Background(), imageID, unrestricted, "evil.io")
+		assert.ErrorIs(t, err, ErrBadSignature)
+		widened := p
+		widened.Origins = append([]string{"evil.io"}, p.Origins...)
+		_, err = f.svc.Resolve(context.Background(), imageID, widened, "evil.io")
+		assert.ErrorIs(t, err, ErrBadSignature)
+		assert.Empty(t, f.files.GeneratePresignedGetURLCalls())
+	})
+
+	t.Run("fail: revoking invalidates links issued before", func(t *testing.T) {
+		f := newFixture(t)
+		imageID, p := issue(t, f, CreateRequest{})
+
+		rotated, err := f.svc.Revoke(context.Background(), f.userID, f.projectID.String())
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), rotated.KeyVersion)
+
+		_, err = f.svc.Resolve(context.Background(), imageID, p, "")
+		assert.ErrorIs(t, err, ErrRevoked)
+
+		// Links issued after the rotation work.
+		imageID, p = issue(t, f, CreateRequest{})
+		_, err = f.svc.Resolve(context.Background(), imageID, p, "")
+		assert.NoError(t, err)
+	})
+
+	t.Run("fail: expired", func(t *testing.T) {
+		f := newFixture(t)
+		imageID, p := issue(t, f, CreateRequest{ExpiresIn: 60})
+		f.now = f.now.Add(time.Minute)
+
+		_, err := f.svc.Resolve(context.Background(), imageID, p, "")
+		assert.ErrorIs(t, err, ErrExpired)
+	})
+
+	t.Run("fail: tampered parameters", func(t *testing.T) {
+		f := newFixture(t)
+		imageID, p := issue(t, f, CreateRequest{ExpiresIn: 60})
+
+		extended := p
+		extended.Expires += 86400
+		_, err := f.svc.Resolve(context.Background(), imageID, extended, "")
+		assert.ErrorIs(t, err, ErrBadSignature)
+
+		staged := p
+		staged.Kind = KindStaged
+		_, err = f.svc.Resolve(context.Background(), imageID, staged, "")
+		assert.ErrorIs(t, err, ErrBadSignature)
+
+		_, err = f.svc.Resolve(context.Background(), "not-a-uuid", p, "")
+		assert.ErrorIs(t, err, ErrBadSignature)
+		assert.Empty(t, f.files.GeneratePresignedGetURLCalls())
+	})
+
+	t.Run("fail: link from another server secret", func(t *testing.T) {
+		f := newFixture(t)
+		imageID, p := issue(t, f, CreateRequest{})
+		f.svc.signer = signer{secret: []byte(strings.Repeat("x", 32))}
+
+		_, err := f.svc.Resolve(context.Background(), imageID, p, "")
+		assert.ErrorIs(t, err, ErrBadSignature)
+	})
+}
+
+func TestDefaultService_Revoke(t *testing.T) {
+	f := newFixture(t)
+
+	_, err := f.svc.Revoke(context.Background(), uuid.NewString(), f.projectID.String())
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = f.svc.Revoke(context.Background(), f.userID, "nope")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Empty(t, f.querier.RotateProjectShareKeyCalls())
+
+	f.querier.RotateProjectShareKeyFunc = func(
+		ctx context.Context, projectID pgtype.UUID,
+	) (*queries.ProjectShareKey, error) {
+		return nil, errors.New("db down")
+	}
+	_, err = f.svc.Revoke(context.Background(), f.userID, f.projectID.String())
+	assert.ErrorContains(t, err, "failed to rotate share key: db down")
+}
+
+func TestDefaultService_Branding(t *testing.T) {
+	branding := func(base string, baseErr error) *sharebrand.ServiceMock {
+		return &sharebrand.ServiceMock{
+			LinkBaseFunc: func(ctx context.Context, userID string) (string, error) { return base, baseErr },
+			ThemeFunc: func(ctx context.Context, userID string) (*sharebrand.Theme, error) {
+				return &sharebrand.Theme{PrimaryColor: "#000000", AccentColor: "#ffffff", LogoURL: "https://logo"}, nil
+			},
+		}
+	}
+	withOwner := func(f *fixture) {
+		f.querier.GetProjectByIDFunc = func(ctx context.Context, id pgtype.UUID) (*queries.GetProjectByIDRow, error) {
+			owner, _ := uuid.Parse(f.userID)
+			return &queries.GetProjectByIDRow{ID: id, UserID: pgtype.UUID{Bytes: owner, Valid: true}}, nil
+		}
+	}
+
+	t.Run("success: links on the platform host without branding", func(t *testing.T) {
+		f := newFixture(t)
+
+		link, err := f.svc.CreateImageLink(context.Background(), f.userID, f.imageID, CreateRequest{})
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(link.URL, "https://api.example.com/share/images/"+f.imageID+"?"))
+		assert.True(t, strings.HasPrefix(link.PageURL, "https://api.example.com/share/images/"+f.imageID+"/view?"))
+		assert.Equal(t, link.URL[strings.Index(link.URL, "?"):], link.PageURL[strings.Index(link.PageURL, "?"):])
+	})
+
+	t.Run("success: links on the owner's verified custom domain", func(t *testing.T) {
+		f := newFixture(t)
+		WithBranding(branding("https://photos.example.com", nil))(f.svc)
+
+		link, err := f.svc.CreateImageLink(context.Background(), f.userID, f.imageID, CreateRequest{})
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(link.URL, "https://photos.example.com/share/images/"))
+		assert.True(t, strings.HasPrefix(link.PageURL, "https://photos.example.com/share/images/"))
+	})
+
+	t.Run("success: branding lookup failure falls back to the platform host", func(t *testing.T) {
+		f := newFixture(t)
+		WithBranding(branding("", errors.New("db down")))(f.svc)
+
+		link, err := f.svc.CreateImageLink(context.Background(), f.userID, f.imageID, CreateRequest{})
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(link.URL, "https://api.example.com/share/images/"))
+	})
+
+	t.Run("success: page themed with the owner's branding", func(t *testing.T) {
+		f := newFixture(t)
+		withOwner(f)
+		brand := branding("", nil)
+		WithBranding(brand)(f.svc)
+		link, err := f.svc.CreateImageLink(context.Background(), f.userID, f.imageID, CreateRequest{Download: true})
+		require.NoError(t, err)
+		imageID, p := paramsOf(t, link)
+
+		page, err := f.svc.View(context.Background(), imageID, p, "")
+		require.NoError(t, err)
+		assert.Equal(t, "https://s3.example.com/uploads/in.jpg?X-Amz-Expires=60", page.ImageURL)
+		assert.True(t, page.Download)
+		assert.Equal(t, "https://logo", page.Theme.LogoURL)
+		require.Len(t, brand.ThemeCalls(), 1)
+		assert.Equal(t, f.userID, brand.ThemeCalls()[0].UserID)
+	})
+
+	t.Run("success: page without branding uses the default theme", func(t *testing.T) {
+		f := newFixture(t)
+		link, err := f.svc.CreateImageLink(context.Background(), f.userID, f.imageID, CreateRequest{})
+		require.NoError(t, err)
+		imageID, p := paramsOf(t, link)
+
+		page, err := f.svc.View(context.Background(), imageID, p, "")
+		require.NoError(t, err)
+		assert.Equal(t, *sharebrand.DefaultTheme(), page.Theme)
+	})
+
+	t.Run("fail: opened on another account's custom domain", func(t *testing.T) {
+		f := newFixture(t)
+		withOwner(f)
+		link, err := f.svc.CreateImageLink(context.Background(), f.userID, f.imageID, CreateRequest{})
+		require.NoError(t, err)
+		imageID, p := paramsOf(t, link)
+
+		_, err = f.svc.Resolve(sharebrand.WithDomainOwner(context.Background(), f.userID), imageID, p, "")
+		require.NoError(t, err)
+		_, err = f.svc.Resolve(sharebrand.WithDomainOwner(context.Background(), uuid.NewString()), imageID, p, "")
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = f.svc.View(sharebrand.WithDomainOwner(context.Background(), uuid.NewString()), imageID, p, "")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
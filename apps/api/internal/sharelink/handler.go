@@ -0,0 +1,14 @@
+package sharelink
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP-level contract for share links.
+// Implementations should be wired to Echo routes in the server.
+type Handler interface {
+	CreateImageLink(c echo.Context) error
+	Revoke(c echo.Context) error
+	Resolve(c echo.Context) error
+	Page(c echo.Context) error
+}
@@ -0,0 +1,67 @@
+package sharelink
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/sharebrand"
+)
+
+// pageCSP lets share pages load only their inline styles and images; images come from
+// storage, whose host depends on the account's bucket.
+const pageCSP = "default-src 'none'; img-src https: http:; style-src 'unsafe-inline'; base-uri 'none'; " +
+	"form-action 'none'; frame-ancestors *"
+
+// pageData is what pageTemplate renders: the page of a valid link, or an error message.
+type pageData struct {
+	Page  *Page
+	Error string
+	Theme sharebrand.Theme
+}
+
+// Theme colors are validated #rrggbb values, and html/template escapes everything else.
+var pageTemplate = template.Must(template.New("share").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>{{if .Error}}Link unavailable{{else}}Shared photo{{end}}</title>
+<style>
+body { margin: 0; font-family: system-ui, sans-serif; background: #f9fafb; color: #111827; }
+header { display: flex; align-items: center; min-height: 56px; padding: 0 24px; background: {{.Theme.PrimaryColor}}; }
+header img { max-height: 40px; max-width: 200px; }
+main { max-width: 1200px; margin: 0 auto; padding: 24px; text-align: center; }
+main img { max-width: 100%; height: auto; border-radius: 4px; }
+.download { display: inline-block; margin-top: 16px; padding: 10px 20px; border-radius: 4px;
+  background: {{.Theme.AccentColor}}; color: #fff; text-decoration: none; }
+.error { margin-top: 64px; font-size: 1.125rem; }
+</style>
+</head>
+<body>
+<header>{{if .Theme.LogoURL}}<img src="{{.Theme.LogoURL}}" alt="">{{end}}</header>
+<main>
+{{- if .Error}}
+<p class="error">{{.Error}}</p>
+{{- else}}
+<img src="{{.Page.ImageURL}}" alt="Shared {{.Page.Kind}} photo">
+{{- if .Page.Download}}
+<div><a class="download" href="{{.Page.ImageURL}}" download>Download</a></div>
+{{- end}}
+{{- end}}
+</main>
+</body>
+</html>
+`))
+
+// renderPage writes data as an HTML share page.
+func renderPage(c echo.Context, status int, data pageData) error {
+	var buf bytes.Buffer
+	if err := pageTemplate.Execute(&buf, data); err != nil {
+		return c.String(http.StatusInternalServerError, "failed to render page")
+	}
+	return c.HTMLBlob(status, buf.Bytes())
+}
@@ -0,0 +1,20 @@
+package sharelink
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service defines the interface for issuing, resolving and revoking share links.
+type Service interface {
+	// CreateImageLink signs a link to one of the user's images.
+	CreateImageLink(ctx context.Context, userID, imageID string, req CreateRequest) (*Link, error)
+	// Resolve checks a link's signature, key version, expiry and allowed origins and returns
+	// a short-lived storage URL to redirect to. referrer is the host of the page the link was
+	// opened from, empty when the browser did not say.
+	Resolve(ctx context.Context, imageID string, p Params, referrer string) (string, error)
+	// View checks a link like Resolve and returns the share page to show for it, themed with
+	// the project owner's share branding.
+	View(ctx context.Context, imageID string, p Params, referrer string) (*Page, error)
+	// Revoke rotates the project's key version, invalidating every link issued so far.
+	Revoke(ctx context.Context, userID, projectID string) (*KeyVersion, error)
+}
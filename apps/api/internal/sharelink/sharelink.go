@@ -0,0 +1,96 @@
+// Package sharelink issues signed image links that work without signing in, for sellers to
+// hand to buyers, and revokes them. Each project signs its links with a key derived from the
+// server secret and the project's key version; rotating the version invalidates every link
+// issued before, so a leaked gallery link can be killed immediately. A link may also be
+// restricted to the sites allowed to embed or link to it. Links also open as a page themed
+// with the account's share branding, on its custom domain once that is verified.
+package sharelink
+
+import (
+	"errors"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/sharebrand"
+)
+
+// MaxAllowedOrigins caps how many sites one link may be restricted to.
+const MaxAllowedOrigins = 10
+
+// Kinds of stored image a link may point to.
+const (
+	KindOriginal = "original"
+	KindStaged   = "staged"
+)
+
+var (
+	// ErrNotFound is returned when the image or project does not exist or belongs to another user.
+	ErrNotFound = errors.New("not found")
+	// ErrInvalid wraps validation failures of a create request.
+	ErrInvalid = errors.New("invalid share link request")
+	// ErrNotStaged is returned when a staged link is requested before the image is staged.
+	ErrNotStaged = errors.New("image has not been staged")
+	// ErrBadSignature is returned for links that were not issued by this server or were altered.
+	ErrBadSignature = errors.New("invalid share link")
+	// ErrRevoked is returned for links signed with a key version the project has rotated away from.
+	ErrRevoked = errors.New("share link has been revoked")
+	// ErrExpired is returned for links past their expiry.
+	ErrExpired = errors.New("share link has expired")
+	// ErrOriginNotAllowed is returned when a restricted link is opened from a site it does not
+	// allow, or without an Origin or Referer header.
+	ErrOriginNotAllowed = errors.New("share link cannot be used from this site")
+)
+
+// CreateRequest asks for a link to one image.
+type CreateRequest struct {
+	// Kind is original (default) or staged.
+	Kind string `json:"kind"`
+	// ExpiresIn is the link lifetime in seconds. Zero uses the configured default.
+	ExpiresIn int64 `json:"expires_in"`
+	// Download makes the browser save the file instead of displaying it.
+	Download bool `json:"download"`
+	// AllowedOrigins restricts the link to pages on these hosts, e.g. "listings.example.com"
+	// or "*.example.com" for any subdomain. A full origin such as "https://example.com" is
+	// reduced to its host. Empty allows any site.
+	AllowedOrigins []string `json:"allowed_origins"`
+}
+
+// Link is an issued share link.
+type Link struct {
+	// URL redirects to the image itself, for embedding.
+	URL string `json:"url"`
+	// PageURL shows the image on a page themed with the account's share branding.
+	PageURL    string    `json:"page_url"`
+	ImageID    string    `json:"image_id"`
+	Kind       string    `json:"kind"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	KeyVersion int32     `json:"key_version"`
+	// AllowedOrigins are the hosts the link is restricted to; omitted when unrestricted.
+	AllowedOrigins []string `json:"allowed_origins,omitempty"`
+}
+
+// KeyVersion is a project's current signing key version.
+type KeyVersion struct {
+	ProjectID  string    `json:"project_id"`
+	KeyVersion int32     `json:"key_version"`
+	RotatedAt  time.Time `json:"rotated_at"`
+}
+
+// Params are the signed query parameters of a link.
+type Params struct {
+	Kind       string
+	Expires    int64
+	KeyVersion int32
+	Download   bool
+	// Origins are the hosts the link is restricted to, empty when unrestricted.
+	Origins   []string
+	Signature string
+}
+
+// Page is what a share page shows.
+type Page struct {
+	// ImageURL is a short-lived storage URL of the image.
+	ImageURL string
+	Kind     string
+	Download bool
+	Theme    sharebrand.Theme
+}
@@ -0,0 +1,38 @@
+package slo
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DefaultHandler serves SLO reports over HTTP.
+type DefaultHandler struct {
+	service Service
+	now     func() time.Time
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service) *DefaultHandler {
+	return &DefaultHandler{service: service, now: time.Now}
+}
+
+// ReportResponse lists the routes that served requests within the longest window on the
+// API instance that answered.
+type ReportResponse struct {
+	// Routes are sorted with alerting routes first.
+	Routes      []RouteReport `json:"routes"`
+	GeneratedAt time.Time     `json:"generated_at"`
+}
+
+// GetReport handles GET /api/v1/admin/slo.
+func (h *DefaultHandler) GetReport(c echo.Context) error {
+	return c.JSON(http.StatusOK, ReportResponse{
+		Routes:      h.service.Report(c.Request().Context()),
+		GeneratedAt: h.now().UTC(),
+	})
+}
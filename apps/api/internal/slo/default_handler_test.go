@@ -0,0 +1,38 @@
+package slo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultHandler_GetReport(t *testing.T) {
+	svc := &ServiceMock{
+		ReportFunc: func(ctx context.Context) []RouteReport {
+			return []RouteReport{{Route: "GET /api/v1/projects", Alert: AlertSlowBurn}}
+		},
+	}
+	h := NewDefaultHandler(svc)
+	h.now = func() time.Time { return time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC) }
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/slo", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	require.NoError(t, h.GetReport(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var body ReportResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Len(t, body.Routes, 1)
+	assert.Equal(t, AlertSlowBurn, body.Routes[0].Alert)
+	assert.Equal(t, "2025-03-14T12:00:00Z", body.GeneratedAt.Format(time.RFC3339))
+}
@@ -0,0 +1,134 @@
+package slo
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+// bucketCount is how many one-minute buckets each route keeps: enough for the longest
+// window.
+var bucketCount = int64(Windows[len(Windows)-1].Duration / time.Minute)
+
+type counts struct {
+	requests int64
+	errors   int64
+	slow     int64
+}
+
+func (c *counts) add(o counts) {
+	c.requests += o.requests
+	c.errors += o.errors
+	c.slow += o.slow
+}
+
+// bucket holds the requests of the minute since the Unix epoch it is stamped with.
+type bucket struct {
+	minute int64
+	counts
+}
+
+// series is a ring of one-minute buckets for one route.
+type series []bucket
+
+// sum totals the buckets within d of minute, including minute itself.
+func (s series) sum(minute int64, d time.Duration) counts {
+	var total counts
+	for m := minute - int64(d/time.Minute) + 1; m <= minute; m++ {
+		if b := s[m%bucketCount]; b.minute == m {
+			total.add(b.counts)
+		}
+	}
+	return total
+}
+
+// DefaultService implements the Service interface in memory.
+type DefaultService struct {
+	objectives objectives
+	now        func() time.Time
+
+	mu     sync.Mutex
+	routes map[string]series
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService holding routes to cfg.
+func NewDefaultService(cfg config.SLO) *DefaultService {
+	return &DefaultService{objectives: newObjectives(cfg), now: time.Now, routes: map[string]series{}}
+}
+
+// Record counts the request in the current minute. A 5xx status is an error, and a request
+// slower than the route's latency threshold is slow whatever its status.
+func (s *DefaultService) Record(route string, status int, latency time.Duration) {
+	c := counts{requests: 1}
+	if status >= 500 {
+		c.errors = 1
+	}
+	if latency.Milliseconds() > s.objectives.For(route).LatencyThresholdMs {
+		c.slow = 1
+	}
+	minute := s.now().Unix() / 60
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ser, ok := s.routes[route]
+	if !ok {
+		ser = make(series, bucketCount)
+		s.routes[route] = ser
+	}
+	b := &ser[minute%bucketCount]
+	if b.minute != minute {
+		*b = bucket{minute: minute}
+	}
+	b.add(c)
+}
+
+// Report computes every window for every route, dropping routes idle for the longest
+// window.
+func (s *DefaultService) Report(ctx context.Context) []RouteReport {
+	minute := s.now().Unix() / 60
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reports := make([]RouteReport, 0, len(s.routes))
+	for route, ser := range s.routes {
+		obj := s.objectives.For(route)
+		r := RouteReport{Route: route, Objective: obj, Windows: make([]WindowReport, 0, len(Windows))}
+		availability, latency := map[string]float64{}, map[string]float64{}
+		for _, w := range Windows {
+			c := ser.sum(minute, w.Duration)
+			wr := WindowReport{
+				Window:               w.Name,
+				Requests:             c.requests,
+				Errors:               c.errors,
+				Slow:                 c.slow,
+				AvailabilityBurnRate: burnRate(c.errors, c.requests, obj.Availability),
+				LatencyBurnRate:      burnRate(c.slow, c.requests, obj.LatencyTarget),
+			}
+			availability[w.Name], latency[w.Name] = wr.AvailabilityBurnRate, wr.LatencyBurnRate
+			r.Windows = append(r.Windows, wr)
+		}
+		if r.Windows[len(r.Windows)-1].Requests == 0 {
+			delete(s.routes, route)
+			continue
+		}
+		r.Alert = alert(availability)
+		if a := alert(latency); severity[a] > severity[r.Alert] {
+			r.Alert = a
+		}
+		reports = append(reports, r)
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		if si, sj := severity[reports[i].Alert], severity[reports[j].Alert]; si != sj {
+			return si > sj
+		}
+		return reports[i].Route < reports[j].Route
+	})
+	return reports
+}
@@ -0,0 +1,160 @@
+package slo
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+var testConfig = config.SLO{
+	Enabled:          true,
+	Availability:     0.99,
+	LatencyThreshold: time.Second,
+	LatencyTarget:    0.9,
+	Routes: map[string]config.SLOObjective{
+		"POST /api/v1/images": {LatencyThreshold: 5 * time.Second},
+	},
+}
+
+func windowByName(t *testing.T, r RouteReport, name string) WindowReport {
+	t.Helper()
+	for _, w := range r.Windows {
+		if w.Window == name {
+			return w
+		}
+	}
+	t.Fatalf("no %s window", name)
+	return WindowReport{}
+}
+
+func TestDefaultService_Report(t *testing.T) {
+	start := time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name      string
+		record    func(s *DefaultService, now *time.Time)
+		wantAlert string
+		check     func(t *testing.T, r RouteReport)
+	}{
+		{
+			name: "success: healthy traffic",
+			record: func(s *DefaultService, now *time.Time) {
+				for i := 0; i < 100; i++ {
+					s.Record("GET /api/v1/projects", http.StatusOK, 100*time.Millisecond)
+				}
+				s.Record("GET /api/v1/projects", http.StatusNotFound, 2*time.Second)
+			},
+			wantAlert: AlertNone,
+			check: func(t *testing.T, r RouteReport) {
+				w := windowByName(t, r, "5m")
+				assert.Equal(t, int64(101), w.Requests)
+				assert.Equal(t, int64(0), w.Errors)
+				assert.Equal(t, int64(1), w.Slow)
+				assert.InDelta(t, 0.099, w.LatencyBurnRate, 0.001)
+			},
+		},
+		{
+			name: "success: fast burn on a sudden outage",
+			record: func(s *DefaultService, now *time.Time) {
+				for i := 0; i < 10; i++ {
+					s.Record("GET /api/v1/projects", http.StatusBadGateway, time.Millisecond)
+				}
+			},
+			wantAlert: AlertFastBurn,
+			check: func(t *testing.T, r RouteReport) {
+				assert.InDelta(t, 100, windowByName(t, r, "1h").AvailabilityBurnRate, 0.001)
+			},
+		},
+		{
+			name: "success: slow burn once the short window recovers from a fast one",
+			record: func(s *DefaultService, now *time.Time) {
+				// 10% errors for the first 50 minutes, then healthy traffic
+				for m := 0; m < 60; m++ {
+					for i := 0; i < 10; i++ {
+						status := http.StatusOK
+						if i == 0 && m < 50 {
+							status = http.StatusInternalServerError
+						}
+						s.Record("GET /api/v1/projects", status, time.Millisecond)
+					}
+					*now = now.Add(time.Minute)
+				}
+			},
+			wantAlert: AlertSlowBurn,
+			check: func(t *testing.T, r RouteReport) {
+				assert.Zero(t, windowByName(t, r, "5m").AvailabilityBurnRate)
+				assert.InDelta(t, 6.55, windowByName(t, r, "30m").AvailabilityBurnRate, 0.01)
+			},
+		},
+		{
+			name: "success: route objective override",
+			record: func(s *DefaultService, now *time.Time) {
+				for i := 0; i < 10; i++ {
+					s.Record("POST /api/v1/images", http.StatusAccepted, 3*time.Second)
+				}
+			},
+			wantAlert: AlertNone,
+			check: func(t *testing.T, r RouteReport) {
+				assert.Equal(t, Objective{Availability: 0.99, LatencyThresholdMs: 5000, LatencyTarget: 0.9}, r.Objective)
+				assert.Zero(t, windowByName(t, r, "6h").Slow)
+			},
+		},
+		{
+			name: "success: latency burn alerts on its own",
+			record: func(s *DefaultService, now *time.Time) {
+				for i := 0; i < 10; i++ {
+					s.Record("GET /api/v1/projects", http.StatusOK, 3*time.Second)
+				}
+			},
+			wantAlert: AlertSlowBurn,
+			check: func(t *testing.T, r RouteReport) {
+				assert.InDelta(t, 10, windowByName(t, r, "1h").LatencyBurnRate, 0.001)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			now := start
+			svc := NewDefaultService(testConfig)
+			svc.now = func() time.Time { return now }
+			tc.record(svc, &now)
+
+			reports := svc.Report(context.Background())
+			require.Len(t, reports, 1)
+			assert.Equal(t, tc.wantAlert, reports[0].Alert)
+			require.Len(t, reports[0].Windows, len(Windows))
+			tc.check(t, reports[0])
+		})
+	}
+}
+
+func TestDefaultService_Report_OrdersAndExpires(t *testing.T) {
+	now := time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)
+	svc := NewDefaultService(testConfig)
+	svc.now = func() time.Time { return now }
+
+	svc.Record("GET /api/v1/projects", http.StatusOK, time.Millisecond)
+	svc.Record("POST /api/v1/projects", http.StatusServiceUnavailable, time.Millisecond)
+	svc.Record("DELETE /api/v1/projects/:id", http.StatusNoContent, time.Millisecond)
+
+	var routes []string
+	for _, r := range svc.Report(context.Background()) {
+		routes = append(routes, r.Route)
+	}
+	assert.Equal(t, []string{"POST /api/v1/projects", "DELETE /api/v1/projects/:id", "GET /api/v1/projects"}, routes)
+
+	// Buckets older than the longest window are neither counted nor reported
+	now = now.Add(6 * time.Hour)
+	assert.Empty(t, svc.Report(context.Background()))
+	svc.Record("GET /api/v1/projects", http.StatusOK, time.Millisecond)
+	reports := svc.Report(context.Background())
+	require.Len(t, reports, 1)
+	assert.Equal(t, int64(1), windowByName(t, reports[0], "6h").Requests)
+}
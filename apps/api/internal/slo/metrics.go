@@ -0,0 +1,46 @@
+package slo
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// RegisterMetrics publishes the reports of svc on the global meter provider each time it
+// collects: slo.burn_rate per route, SLI and window, and slo.alert per route as 0 (none),
+// 1 (slow burn) or 2 (fast burn).
+func RegisterMetrics(svc Service) error {
+	meter := otel.Meter("real-staging-api/slo")
+	burn, err := meter.Float64ObservableGauge("slo.burn_rate",
+		metric.WithDescription("Error budget burn rate of a route's SLI over a window; 1 spends exactly the budget"))
+	if err != nil {
+		return fmt.Errorf("create burn rate gauge: %w", err)
+	}
+	alerting, err := meter.Int64ObservableGauge("slo.alert",
+		metric.WithDescription("Burn-rate alert of a route: 0 none, 1 slow burn, 2 fast burn"))
+	if err != nil {
+		return fmt.Errorf("create alert gauge: %w", err)
+	}
+
+	_, err = meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		for _, r := range svc.Report(ctx) {
+			route := attribute.String("route", r.Route)
+			for _, w := range r.Windows {
+				window := attribute.String("window", w.Window)
+				o.ObserveFloat64(burn, w.AvailabilityBurnRate,
+					metric.WithAttributes(route, window, attribute.String("sli", SLIAvailability)))
+				o.ObserveFloat64(burn, w.LatencyBurnRate,
+					metric.WithAttributes(route, window, attribute.String("sli", SLILatency)))
+			}
+			o.ObserveInt64(alerting, int64(severity[r.Alert]), metric.WithAttributes(route))
+		}
+		return nil
+	}, burn, alerting)
+	if err != nil {
+		return fmt.Errorf("register SLO callback: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package slo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestRegisterMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	prev := otel.GetMeterProvider()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	t.Cleanup(func() { otel.SetMeterProvider(prev) })
+
+	svc := &ServiceMock{
+		ReportFunc: func(ctx context.Context) []RouteReport {
+			return []RouteReport{{
+				Route:   "GET /api/v1/projects",
+				Windows: []WindowReport{{Window: "1h", AvailabilityBurnRate: 15, LatencyBurnRate: 0.5}},
+				Alert:   AlertFastBurn,
+			}}
+		},
+	}
+	require.NoError(t, RegisterMetrics(svc))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	got := map[string]float64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch data := m.Data.(type) {
+		case metricdata.Gauge[float64]:
+			for _, p := range data.DataPoints {
+				sli, _ := p.Attributes.Value(attribute.Key("sli"))
+				window, _ := p.Attributes.Value(attribute.Key("window"))
+				got[m.Name+"/"+sli.AsString()+"/"+window.AsString()] = p.Value
+			}
+		case metricdata.Gauge[int64]:
+			for _, p := range data.DataPoints {
+				route, _ := p.Attributes.Value(attribute.Key("route"))
+				got[m.Name+"/"+route.AsString()] = float64(p.Value)
+			}
+		}
+	}
+	assert.Equal(t, map[string]float64{
+		"slo.burn_rate/availability/1h":  15,
+		"slo.burn_rate/latency/1h":       0.5,
+		"slo.alert/GET /api/v1/projects": 2,
+	}, got)
+}
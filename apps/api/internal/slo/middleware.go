@@ -0,0 +1,41 @@
+package slo
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Middleware records every request that matched a route. Event streams are left out since
+// they stay open for as long as the client listens.
+func Middleware(svc Service) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+
+			path := c.Path()
+			if path == "" || strings.HasPrefix(c.Response().Header().Get(echo.HeaderContentType), "text/event-stream") {
+				return err
+			}
+			svc.Record(c.Request().Method+" "+path, status(c, err), time.Since(start))
+			return err
+		}
+	}
+}
+
+// status is the status the client receives. An error returned by the handler is only
+// written by Echo's error handler after the middleware chain unwinds.
+func status(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
+	}
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		return he.Code
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,55 @@
+package slo
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	testCases := []struct {
+		name       string
+		method     string
+		target     string
+		wantRoute  string
+		wantStatus int
+	}{
+		{name: "success: route template", method: http.MethodGet, target: "/projects/p1", wantRoute: "GET /projects/:id", wantStatus: http.StatusOK},
+		{name: "success: HTTP error", method: http.MethodPost, target: "/projects", wantRoute: "POST /projects", wantStatus: http.StatusConflict},
+		{name: "success: returned error", method: http.MethodDelete, target: "/projects/p1", wantRoute: "DELETE /projects/:id", wantStatus: http.StatusInternalServerError},
+		{name: "success: event stream skipped", method: http.MethodGet, target: "/events"},
+		{name: "success: unmatched route skipped", method: http.MethodGet, target: "/nope"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{RecordFunc: func(route string, status int, latency time.Duration) {}}
+			e := echo.New()
+			e.Use(Middleware(svc))
+			e.GET("/projects/:id", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+			e.POST("/projects", func(c echo.Context) error { return echo.NewHTTPError(http.StatusConflict) })
+			e.DELETE("/projects/:id", func(c echo.Context) error { return errors.New("boom") })
+			e.GET("/events", func(c echo.Context) error {
+				c.Response().Header().Set(echo.HeaderContentType, "text/event-stream")
+				return c.NoContent(http.StatusOK)
+			})
+
+			e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tc.method, tc.target, nil))
+
+			calls := svc.RecordCalls()
+			if tc.wantRoute == "" {
+				assert.Empty(t, calls)
+				return
+			}
+			if assert.Len(t, calls, 1) {
+				assert.Equal(t, tc.wantRoute, calls[0].Route)
+				assert.Equal(t, tc.wantStatus, calls[0].Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package slo
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service records requests and reports them against their objectives.
+type Service interface {
+	// Record counts one finished request to route, keyed by method and path template.
+	Record(route string, status int, latency time.Duration)
+	// Report returns every route that served requests within the longest window, alerting
+	// routes first.
+	Report(ctx context.Context) []RouteReport
+}
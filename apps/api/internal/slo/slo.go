@@ -0,0 +1,141 @@
+// Package slo measures every route against its service level objectives and computes
+// multiwindow error-budget burn rates, so degradation pages someone before customers
+// notice. Counts are kept in memory per API instance; the burn-rate gauges are the
+// fleet-wide view.
+package slo
+
+import (
+	"time"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+// Window is a period burn rates are computed over.
+type Window struct {
+	Name     string
+	Duration time.Duration
+}
+
+// Windows are the burn-rate windows, shortest first. The longest bounds how much history
+// is kept.
+var Windows = []Window{
+	{Name: "5m", Duration: 5 * time.Minute},
+	{Name: "30m", Duration: 30 * time.Minute},
+	{Name: "1h", Duration: time.Hour},
+	{Name: "6h", Duration: 6 * time.Hour},
+}
+
+// Burn-rate alert thresholds. A fast burn spends 2% of a 30-day budget in an hour and a
+// slow burn 5% in six hours; each must hold over a long and a short window so alerts fire
+// quickly and clear once the burn stops.
+const (
+	FastBurnRate = 14.4
+	SlowBurnRate = 6
+)
+
+// Alert states of a route, from best to worst.
+const (
+	AlertNone     = "none"
+	AlertSlowBurn = "slow_burn"
+	AlertFastBurn = "fast_burn"
+)
+
+// SLIs a route is measured on.
+const (
+	SLIAvailability = "availability"
+	SLILatency      = "latency"
+)
+
+// Objective is what one route is held to.
+type Objective struct {
+	// Availability is the share of requests that must not fail with a 5xx.
+	Availability float64 `json:"availability"`
+	// LatencyThresholdMs is how fast, in milliseconds, a request must be to count as fast.
+	LatencyThresholdMs int64 `json:"latency_threshold_ms"`
+	// LatencyTarget is the share of requests that must be fast.
+	LatencyTarget float64 `json:"latency_target"`
+}
+
+// objectives resolves the objective of each route from configuration.
+type objectives struct {
+	fallback Objective
+	routes   map[string]Objective
+}
+
+func newObjectives(cfg config.SLO) objectives {
+	o := objectives{
+		fallback: Objective{
+			Availability:       cfg.Availability,
+			LatencyThresholdMs: cfg.LatencyThreshold.Milliseconds(),
+			LatencyTarget:      cfg.LatencyTarget,
+		},
+		routes: map[string]Objective{},
+	}
+	for route, r := range cfg.Routes {
+		obj := o.fallback
+		if r.Availability != 0 {
+			obj.Availability = r.Availability
+		}
+		if r.LatencyThreshold != 0 {
+			obj.LatencyThresholdMs = r.LatencyThreshold.Milliseconds()
+		}
+		if r.LatencyTarget != 0 {
+			obj.LatencyTarget = r.LatencyTarget
+		}
+		o.routes[route] = obj
+	}
+	return o
+}
+
+func (o objectives) For(route string) Objective {
+	if obj, ok := o.routes[route]; ok {
+		return obj
+	}
+	return o.fallback
+}
+
+// WindowReport is a route's traffic and burn rates over one window.
+type WindowReport struct {
+	Window   string `json:"window"`
+	Requests int64  `json:"requests"`
+	Errors   int64  `json:"errors"`
+	Slow     int64  `json:"slow"`
+	// AvailabilityBurnRate and LatencyBurnRate are how many times faster than sustainable
+	// the error budget is being spent: 1 uses exactly the budget, 0 when there was no
+	// traffic.
+	AvailabilityBurnRate float64 `json:"availability_burn_rate"`
+	LatencyBurnRate      float64 `json:"latency_burn_rate"`
+}
+
+// RouteReport is one route measured against its objective.
+type RouteReport struct {
+	// Route is the method and path template, e.g. "GET /api/v1/projects/:id".
+	Route     string         `json:"route"`
+	Objective Objective      `json:"objective"`
+	Windows   []WindowReport `json:"windows"`
+	// Alert is the worst burn-rate alert either SLI is in.
+	Alert string `json:"alert"`
+}
+
+// burnRate is the observed bad ratio over the ratio the objective allows.
+func burnRate(bad, total int64, target float64) float64 {
+	if total == 0 || target >= 1 {
+		return 0
+	}
+	return (float64(bad) / float64(total)) / (1 - target)
+}
+
+// alert reports the worst alert one SLI's burn rates, keyed by window name, trigger.
+func alert(rates map[string]float64) string {
+	switch {
+	case rates["1h"] >= FastBurnRate && rates["5m"] >= FastBurnRate:
+		return AlertFastBurn
+	case rates["6h"] >= SlowBurnRate && rates["30m"] >= SlowBurnRate:
+		return AlertSlowBurn
+	default:
+		return AlertNone
+	}
+}
+
+// severity orders alert states from best to worst.
+var severity = map[string]int{AlertNone: 0, AlertSlowBurn: 1, AlertFastBurn: 2}
@@ -0,0 +1,29 @@
+package sms
+
+import (
+	"context"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// LogSender logs messages instead of sending them. It is the default provider.
+type LogSender struct {
+	log logging.Logger
+}
+
+// Ensure LogSender implements Sender.
+var _ Sender = (*LogSender)(nil)
+
+// NewLogSender creates a LogSender.
+func NewLogSender(log logging.Logger) *LogSender {
+	return &LogSender{log: log}
+}
+
+// Send logs the recipient. The body is left out since messages may carry one-time codes.
+func (s *LogSender) Send(ctx context.Context, msg Message) error {
+	if !validRecipient(msg.To) {
+		return ErrInvalidRecipient
+	}
+	s.log.Info(ctx, "SMS not sent (no SMS provider configured)", "to", msg.To, "length", len(msg.Body))
+	return nil
+}
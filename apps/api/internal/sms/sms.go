@@ -0,0 +1,59 @@
+// Package sms sends text messages from the API. Vendors are registered in providers and
+// selected by config.SMS.Provider; callers depend only on Sender.
+package sms
+
+import (
+	"context"
+	"errors"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/httpclient"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/provider"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out sender_mock.go . Sender
+
+// ErrInvalidRecipient is returned when a message's recipient is not an E.164 number.
+var ErrInvalidRecipient = errors.New("recipient must be an E.164 phone number")
+
+// Message is a plain-text message.
+type Message struct {
+	// To is the recipient in E.164 format, e.g. "+15558675310".
+	To   string
+	Body string
+}
+
+// Sender delivers text messages.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// providers are the SMS vendors, by the name config.SMS.Provider selects.
+var providers = provider.NewRegistry[config.SMS, Sender](provider.CapabilitySMS).
+	Register("log", func(cfg config.SMS) (Sender, error) { return NewLogSender(logging.Default()), nil }).
+	Register("twilio", func(cfg config.SMS) (Sender, error) {
+		return NewTwilioSender(httpclient.New(httpclient.DestinationSMS), cfg)
+	})
+
+// New returns the Sender configured by cfg. An empty provider logs messages.
+func New(cfg config.SMS) (Sender, error) {
+	name := cfg.Provider
+	if name == "" {
+		name = "log"
+	}
+	return providers.New(name, cfg)
+}
+
+// validRecipient reports whether to is an E.164 number: a plus and up to 15 digits.
+func validRecipient(to string) bool {
+	if len(to) < 3 || len(to) > 16 || to[0] != '+' || to[1] == '0' {
+		return false
+	}
+	for _, r := range to[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+package sms
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/provider"
+)
+
+// TwilioSender sends messages through Twilio's Messages API.
+type TwilioSender struct {
+	client     *http.Client
+	baseURL    string
+	accountSID string
+	authToken  string
+	from       string
+}
+
+// Ensure TwilioSender implements Sender and can report its health.
+var (
+	_ Sender                 = (*TwilioSender)(nil)
+	_ provider.HealthChecker = (*TwilioSender)(nil)
+)
+
+// NewTwilioSender creates a TwilioSender that sends requests through client.
+func NewTwilioSender(client *http.Client, cfg config.SMS) (*TwilioSender, error) {
+	if cfg.TwilioAccountSID == "" || cfg.TwilioAuthToken == "" {
+		return nil, fmt.Errorf("twilio account SID and auth token are required")
+	}
+	if !validRecipient(cfg.From) {
+		return nil, fmt.Errorf("sms from number must be in E.164 format")
+	}
+	return &TwilioSender{
+		client:     client,
+		baseURL:    strings.TrimRight(cfg.TwilioBaseURL, "/"),
+		accountSID: cfg.TwilioAccountSID,
+		authToken:  cfg.TwilioAuthToken,
+		from:       cfg.From,
+	}, nil
+}
+
+// Send creates a message resource. Twilio queues it and delivers it asynchronously.
+func (s *TwilioSender) Send(ctx context.Context, msg Message) error {
+	if !validRecipient(msg.To) {
+		return ErrInvalidRecipient
+	}
+	form := url.Values{"To": {msg.To}, "From": {s.from}, "Body": {msg.Body}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		s.accountURL()+"/Messages.json", strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to build sms request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return s.do(req, http.StatusCreated)
+}
+
+// CheckHealth fetches the account, which checks the credentials without sending anything.
+func (s *TwilioSender) CheckHealth(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.accountURL()+".json", nil)
+	if err != nil {
+		return fmt.Errorf("failed to build sms status request: %w", err)
+	}
+	return s.do(req, http.StatusOK)
+}
+
+func (s *TwilioSender) accountURL() string {
+	return s.baseURL + "/2010-04-01/Accounts/" + url.PathEscape(s.accountSID)
+}
+
+func (s *TwilioSender) do(req *http.Request, want int) error {
+	req.SetBasicAuth(s.accountSID, s.authToken)
+	req.Header.Set("Accept", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sms request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != want {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return fmt.Errorf("sms request failed with status %d: %s", resp.StatusCode, body)
+	}
+	return nil
+}
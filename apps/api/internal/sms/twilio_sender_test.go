@@ -0,0 +1,113 @@
+package sms
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/provider"
+)
+
+func TestTwilioSender_Send(t *testing.T) {
+	testCases := []struct {
+		name    string
+		to      string
+		status  int
+		wantErr string
+	}{
+		{name: "success: queued", to: "+15558675310", status: http.StatusCreated},
+		{name: "fail: rejected", to: "+15558675310", status: http.StatusBadRequest, wantErr: "status 400"},
+		{name: "fail: not E.164", to: "555-867-5310", wantErr: ErrInvalidRecipient.Error()},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got *http.Request
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r
+				require.NoError(t, r.ParseForm())
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(`{"code":21211}`))
+			}))
+			defer srv.Close()
+
+			s, err := NewTwilioSender(srv.Client(), config.SMS{
+				From: "+15017122661", TwilioAccountSID: "AC123", TwilioAuthToken: "token", TwilioBaseURL: srv.URL + "/",
+			})
+			require.NoError(t, err)
+			err = s.Send(context.Background(), Message{To: tc.to, Body: "Your code is 123456"})
+
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, got)
+			assert.Equal(t, "/2010-04-01/Accounts/AC123/Messages.json", got.URL.Path)
+			user, pass, ok := got.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "AC123", user)
+			assert.Equal(t, "token", pass)
+			assert.Equal(t, "+15558675310", got.PostForm.Get("To"))
+			assert.Equal(t, "+15017122661", got.PostForm.Get("From"))
+			assert.Equal(t, "Your code is 123456", got.PostForm.Get("Body"))
+		})
+	}
+}
+
+func TestTwilioSender_CheckHealth(t *testing.T) {
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/2010-04-01/Accounts/AC123.json", r.URL.Path)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+	s, err := NewTwilioSender(srv.Client(), config.SMS{
+		From: "+15017122661", TwilioAccountSID: "AC123", TwilioAuthToken: "token", TwilioBaseURL: srv.URL,
+	})
+	require.NoError(t, err)
+
+	assert.NoError(t, s.CheckHealth(context.Background()))
+	status = http.StatusUnauthorized
+	assert.ErrorContains(t, s.CheckHealth(context.Background()), "status 401")
+}
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		name    string
+		cfg     config.SMS
+		want    Sender
+		wantErr error
+	}{
+		{name: "success: logs by default", cfg: config.SMS{}, want: &LogSender{}},
+		{
+			name: "success: twilio",
+			cfg: config.SMS{
+				Provider: "twilio", From: "+15017122661", TwilioAccountSID: "AC123", TwilioAuthToken: "token",
+			},
+			want: &TwilioSender{},
+		},
+		{name: "fail: twilio without credentials", cfg: config.SMS{Provider: "twilio", From: "+15017122661"}},
+		{name: "fail: unknown provider", cfg: config.SMS{Provider: "pager"}, wantErr: provider.ErrUnknown},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := New(tc.cfg)
+			if tc.want == nil {
+				assert.Error(t, err)
+				if tc.wantErr != nil {
+					assert.ErrorIs(t, err, tc.wantErr)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.IsType(t, tc.want, s)
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package sse
 
 import (
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
 
@@ -11,6 +13,8 @@ import (
 // DefaultHandler provides Echo HTTP handlers for SSE endpoints.
 type DefaultHandler struct {
 	sse SSE
+	// closer releases resources the handler created for itself, if any.
+	closer io.Closer
 }
 
 // NewDefaultHandler constructs a DefaultHandler with the provided SSE implementation.
@@ -18,33 +22,44 @@ func NewDefaultHandler(s SSE) *DefaultHandler {
 	return &DefaultHandler{sse: s}
 }
 
-// NewDefaultHandlerFromEnv constructs a DefaultHandler using the default Redis-backed SSE implementation.
-// It reads REDIS_ADDR from the environment.
-func NewDefaultHandlerFromEnv(cfg Config) (*DefaultHandler, error) {
-	streamer, err := NewDefaultSSEFromEnv(cfg)
+// NewDefaultHandlerFromConfig constructs a DefaultHandler using the default Redis-backed SSE
+// implementation, connected to cfg.RedisAddr. Close releases the Redis client it creates.
+func NewDefaultHandlerFromConfig(cfg Config) (*DefaultHandler, error) {
+	streamer, err := NewDefaultSSEFromConfig(cfg)
 	if err != nil {
 		return nil, err
 	}
-	return &DefaultHandler{sse: streamer}, nil
+	return &DefaultHandler{sse: streamer, closer: streamer}, nil
+}
+
+// Close releases the Redis client created by NewDefaultHandlerFromConfig; it is a no-op
+// for handlers built around a caller-provided SSE.
+func (h *DefaultHandler) Close() error {
+	if h.closer == nil {
+		return nil
+	}
+	return h.closer.Close()
 }
 
 // Events is an Echo handler for GET /api/v1/events?image_id={id} that streams
 // Server-Sent Events scoped to a single image (per-image channel).
 //
 // It sets the appropriate SSE headers, validates the image_id query parameter,
-// and delegates streaming to the configured SSE implementation.
+// and delegates streaming to the configured SSE implementation. A reconnecting
+// client's Last-Event-ID header resumes the stream after that event.
 //
 // Expected minimal payloads are status-only job updates, e.g.:
 //
+//	id: 1700000000000-0
 //	event: job_update
 //	data: {"status":"processing"}
+//
+// An update announcing a low-resolution preview of the output, fetched with
+// GET /api/v1/images/{id}/presign?kind=preview, is flagged:
+//
+//	data: {"status":"processing","preview":true}
 func (h *DefaultHandler) Events(c echo.Context) error {
-	// Set SSE headers
-	c.Response().Header().Set("Content-Type", "text/event-stream")
-	c.Response().Header().Set("Cache-Control", "no-cache")
-	c.Response().Header().Set("Connection", "keep-alive")
-	c.Response().Header().Set("Access-Control-Allow-Origin", "*")
-	c.Response().Header().Set("Access-Control-Allow-Headers", "Cache-Control")
+	setStreamHeaders(c)
 
 	imageID := c.QueryParam("image_id")
 	if imageID == "" {
@@ -57,6 +72,58 @@ func (h *DefaultHandler) Events(c echo.Context) error {
 		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "pubsub not configured"})
 	}
 
+	clearDeadlines(c)
+
 	// Stream events until client disconnects (request context is cancelled)
-	return h.sse.StreamImage(c.Request().Context(), c.Response().Writer, imageID)
+	lastEventID := c.Request().Header.Get("Last-Event-ID")
+	return h.sse.StreamImage(c.Request().Context(), c.Response().Writer, imageID, lastEventID)
+}
+
+// ProjectEvents is an Echo handler for GET /api/v1/projects/:id/events that streams
+// the status transitions of every image in a project, so a project page can follow a
+// batch without opening a stream per image. Callers check the user may see the project.
+//
+// Each update names its image:
+//
+//	id: 1700000000000-0
+//	event: job_update
+//	data: {"image_id":"...","status":"ready"}
+//
+// A reconnecting client's Last-Event-ID header resumes the stream after that event.
+func (h *DefaultHandler) ProjectEvents(c echo.Context) error {
+	setStreamHeaders(c)
+
+	projectID := c.Param("id")
+	if projectID == "" {
+		logging.NewDefaultLogger().Warn(c.Request().Context(), "missing project id for SSE events")
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "missing project id"})
+	}
+
+	if h.sse == nil {
+		logging.NewDefaultLogger().Error(c.Request().Context(), "pubsub not configured for SSE", "project_id", projectID)
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "pubsub not configured"})
+	}
+
+	clearDeadlines(c)
+
+	lastEventID := c.Request().Header.Get("Last-Event-ID")
+	return h.sse.StreamProject(c.Request().Context(), c.Response().Writer, projectID, lastEventID)
+}
+
+// setStreamHeaders sets the SSE response headers.
+func setStreamHeaders(c echo.Context) {
+	c.Response().Header().Set("Content-Type", "text/event-stream")
+	c.Response().Header().Set("Cache-Control", "no-cache")
+	c.Response().Header().Set("Connection", "keep-alive")
+	c.Response().Header().Set("Access-Control-Allow-Origin", "*")
+	c.Response().Header().Set("Access-Control-Allow-Headers", "Cache-Control")
+}
+
+// clearDeadlines lifts the server's read/write timeouts, which are sized for ordinary
+// requests and would cut the stream off mid-flight; heartbeats detect dead clients
+// instead. Writers that do not support deadlines (e.g. test recorders) have nothing to clear.
+func clearDeadlines(c echo.Context) {
+	rc := http.NewResponseController(c.Response())
+	_ = rc.SetReadDeadline(time.Time{})
+	_ = rc.SetWriteDeadline(time.Time{})
 }
@@ -2,6 +2,7 @@ package sse
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -28,13 +29,12 @@ func waitForHandler(t *testing.T, timeout time.Duration, cond func() bool) {
 }
 
 func TestDefaultHandler_Events_ConnectedAndUpdate(t *testing.T) {
-	// Start in-memory Redis and set REDIS_ADDR for DefaultSSE
+	// Start in-memory Redis for DefaultSSE
 	mr := miniredis.RunT(t)
 	defer mr.Close()
-	t.Setenv("REDIS_ADDR", mr.Addr())
 
-	// Build handler from env (uses DefaultSSE)
-	h, err := NewDefaultHandlerFromEnv(Config{HeartbeatInterval: 50 * time.Millisecond})
+	// Build handler against miniredis (uses DefaultSSE)
+	h, err := NewDefaultHandlerFromConfig(Config{HeartbeatInterval: 50 * time.Millisecond, RedisAddr: mr.Addr()})
 	require.NoError(t, err)
 
 	// Prepare Echo context
@@ -59,11 +59,10 @@ func TestDefaultHandler_Events_ConnectedAndUpdate(t *testing.T) {
 		return strings.Contains(rec.Body.String(), "event: connected")
 	})
 
-	// Publish a status update to the per-image channel
+	// Append a status update to the per-image stream
 	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
 	defer func() { _ = rdb.Close() }()
-	err = rdb.Publish(ctx, "jobs:image:img-123", `{"status":"processing"}`).Err()
-	require.NoError(t, err)
+	appendUpdate(t, rdb, "img-123", `{"status":"processing"}`)
 
 	// Expect job_update event with status-only payload
 	waitForHandler(t, 500*time.Millisecond, func() bool {
@@ -84,6 +83,85 @@ func TestDefaultHandler_Events_ConnectedAndUpdate(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rec.Code)
 }
 
+func TestDefaultHandler_Events_PassesLastEventID(t *testing.T) {
+	streamer := &SSEMock{
+		StreamImageFunc: func(ctx context.Context, w io.Writer, imageID, lastEventID string) error {
+			return nil
+		},
+	}
+	h := NewDefaultHandler(streamer)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/events?image_id=img-1", nil)
+	req.Header.Set("Last-Event-ID", "1700000000000-0")
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	require.NoError(t, h.Events(c))
+	require.Len(t, streamer.StreamImageCalls(), 1)
+	assert.Equal(t, "img-1", streamer.StreamImageCalls()[0].ImageID)
+	assert.Equal(t, "1700000000000-0", streamer.StreamImageCalls()[0].LastEventID)
+	assert.NoError(t, h.Close())
+}
+
+func TestDefaultHandler_ProjectEvents(t *testing.T) {
+	testCases := []struct {
+		name        string
+		projectID   string
+		streamer    SSE
+		expectCode  int
+		expectCalls int
+	}{
+		{
+			name:      "success: streams the project with Last-Event-ID",
+			projectID: "proj-1",
+			streamer: &SSEMock{
+				StreamProjectFunc: func(ctx context.Context, w io.Writer, projectID, lastEventID string) error {
+					return nil
+				},
+			},
+			expectCode:  http.StatusOK,
+			expectCalls: 1,
+		},
+		{
+			name:       "fail: missing project id",
+			projectID:  "",
+			streamer:   &SSEMock{},
+			expectCode: http.StatusBadRequest,
+		},
+		{
+			name:       "fail: pubsub not configured",
+			projectID:  "proj-1",
+			streamer:   nil,
+			expectCode: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewDefaultHandler(tc.streamer)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/projects/"+tc.projectID+"/events", nil)
+			req.Header.Set("Last-Event-ID", "1700000000000-0")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.projectID)
+
+			require.NoError(t, h.ProjectEvents(c))
+			assert.Equal(t, tc.expectCode, rec.Code)
+			assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+			if mock, ok := tc.streamer.(*SSEMock); ok {
+				require.Len(t, mock.StreamProjectCalls(), tc.expectCalls)
+				if tc.expectCalls > 0 {
+					assert.Equal(t, "proj-1", mock.StreamProjectCalls()[0].ProjectID)
+					assert.Equal(t, "1700000000000-0", mock.StreamProjectCalls()[0].LastEventID)
+				}
+			}
+		})
+	}
+}
+
 func TestDefaultHandler_Events_MissingImageID(t *testing.T) {
 	// Handler with nil SSE is fine; missing image_id is validated before SSE use
 	h := NewDefaultHandler(nil)
@@ -117,13 +195,12 @@ func TestDefaultHandler_Events_NoPubSubConfigured(t *testing.T) {
 }
 
 func TestDefaultHandler_Events_MultiUpdates(t *testing.T) {
-	// Start in-memory Redis and set REDIS_ADDR
+	// Start in-memory Redis
 	mr := miniredis.RunT(t)
 	defer mr.Close()
-	t.Setenv("REDIS_ADDR", mr.Addr())
 
-	// Build handler from env (uses DefaultSSE)
-	h, err := NewDefaultHandlerFromEnv(Config{HeartbeatInterval: 50 * time.Millisecond})
+	// Build handler against miniredis (uses DefaultSSE)
+	h, err := NewDefaultHandlerFromConfig(Config{HeartbeatInterval: 50 * time.Millisecond, RedisAddr: mr.Addr()})
 	require.NoError(t, err)
 
 	// Prepare Echo context
@@ -148,18 +225,20 @@ func TestDefaultHandler_Events_MultiUpdates(t *testing.T) {
 		return strings.Contains(rec.Body.String(), "event: connected")
 	})
 
-	// Publish a sequence of status updates
+	// Append a sequence of status updates
 	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
 	defer func() { _ = rdb.Close() }()
-	_ = rdb.Publish(ctx, "jobs:image:img-abc", `{"status":"processing"}`).Err()
-	_ = rdb.Publish(ctx, "jobs:image:img-abc", `{"status":"ready"}`).Err()
-	_ = rdb.Publish(ctx, "jobs:image:img-abc", `{"status":"error"}`).Err()
+	appendUpdate(t, rdb, "img-abc", `{"status":"processing"}`)
+	appendUpdate(t, rdb, "img-abc", `{"status":"processing","preview":true}`)
+	appendUpdate(t, rdb, "img-abc", `{"status":"ready"}`)
+	appendUpdate(t, rdb, "img-abc", `{"status":"error"}`)
 
-	// Expect all three updates to appear in the stream
+	// Expect all four updates to appear in the stream
 	waitForHandler(t, 1*time.Second, func() bool {
 		s := rec.Body.String()
-		return strings.Count(s, "event: job_update") >= 3 &&
+		return strings.Count(s, "event: job_update") >= 4 &&
 			strings.Contains(s, `data: {"status":"processing"}`) &&
+			strings.Contains(s, `data: {"preview":true,"status":"processing"}`) &&
 			strings.Contains(s, `data: {"status":"ready"}`) &&
 			strings.Contains(s, `data: {"status":"error"}`)
 	})
@@ -6,34 +6,68 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"os"
+	"regexp"
 	"time"
 
 	redis "github.com/redis/go-redis/v9"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/real-staging-ai/api/internal/logging"
 )
 
-// DefaultSSE is a Redis Pub/Sub–backed implementation of SSE.
+// StreamKeyPrefix namespaces the per-image Redis streams the worker appends status
+// updates to. Streams are shared by every API replica, so a client can be served by
+// any of them and resume on another after a reconnect.
+const StreamKeyPrefix = "jobs:image:"
+
+// StreamKey returns the Redis stream holding the status updates for an image.
+func StreamKey(imageID string) string {
+	return StreamKeyPrefix + imageID + ":events"
+}
+
+// ProjectStreamKeyPrefix namespaces the per-project Redis streams carrying the status
+// transitions of every image in a project.
+const ProjectStreamKeyPrefix = "jobs:project:"
+
+// ProjectStreamKey returns the Redis stream holding the status transitions for a project's images.
+func ProjectStreamKey(projectID string) string {
+	return ProjectStreamKeyPrefix + projectID + ":events"
+}
+
+// streamPayloadField is the stream entry field holding the JSON status payload.
+const streamPayloadField = "data"
+
+// streamIDPattern matches Redis stream entry IDs, e.g. 1700000000000-0.
+var streamIDPattern = regexp.MustCompile(`^[0-9]+-[0-9]+$`)
+
+// defaultReadBlock bounds each blocking stream read so a stream whose client has
+// gone away releases its Redis connection promptly.
+const defaultReadBlock = 5 * time.Second
+
+// DefaultSSE is a Redis Streams–backed implementation of SSE.
 // It streams minimal, status-only job update payloads over Server-Sent Events.
 type DefaultSSE struct {
 	rdb              *redis.Client
 	heartbeat        time.Duration
-	channelFmt       string
 	subscribeTimeout time.Duration
+	readBlock        time.Duration
+	// ownsClient is set when the client was created for this DefaultSSE and Close releases it.
+	ownsClient bool
 }
 
-// NewDefaultSSEFromEnv constructs a DefaultSSE using REDIS_ADDR from the environment.
-func NewDefaultSSEFromEnv(cfg Config) (*DefaultSSE, error) {
-	addr := os.Getenv("REDIS_ADDR")
-	if addr == "" {
-		return nil, errors.New("REDIS_ADDR not set")
+// NewDefaultSSEFromConfig constructs a DefaultSSE connected to cfg.RedisAddr.
+// Close releases the Redis client it creates.
+func NewDefaultSSEFromConfig(cfg Config) (*DefaultSSE, error) {
+	if cfg.RedisAddr == "" {
+		return nil, errors.New("redis address is not configured")
 	}
-	rdb := redis.NewClient(&redis.Options{Addr: addr})
-	return NewDefaultSSE(rdb, cfg), nil
+	rdb := redis.NewClient(&redis.Options{Addr: cfg.RedisAddr})
+	d := NewDefaultSSE(rdb, cfg)
+	d.ownsClient = true
+	return d, nil
 }
 
 // NewDefaultSSE initializes a DefaultSSE with an existing Redis client.
@@ -46,95 +80,145 @@ func NewDefaultSSE(rdb *redis.Client, cfg Config) *DefaultSSE {
 	return &DefaultSSE{
 		rdb:              rdb,
 		heartbeat:        hb,
-		channelFmt:       "jobs:image:%s",
 		subscribeTimeout: cfg.SubscribeTimeout,
+		readBlock:        defaultReadBlock,
 	}
 }
 
-// StreamImage subscribes to a per-image channel and forwards status-only updates via SSE.
+// Close releases the Redis client if this DefaultSSE created it.
+func (d *DefaultSSE) Close() error {
+	if !d.ownsClient || d.rdb == nil {
+		return nil
+	}
+	return d.rdb.Close()
+}
+
+// StreamImage reads the image's Redis stream and forwards status-only updates via SSE.
 // It emits an initial "connected" event, periodic "heartbeat" events, and "job_update" events
-// containing a minimal payload: {"status":"..."}.
-func (d *DefaultSSE) StreamImage(ctx context.Context, w io.Writer, imageID string) error {
+// containing a minimal payload: {"status":"..."}, plus "preview":true when the worker has
+// published a low-resolution preview of the output. Each job_update carries the stream entry ID
+// as its SSE id, so a reconnecting client that sends it back as lastEventID resumes right
+// after it on any replica. Without one, the stream starts from the image's latest update so
+// a client that connects after the worker has already moved on still sees the current status.
+func (d *DefaultSSE) StreamImage(ctx context.Context, w io.Writer, imageID, lastEventID string) error {
 	tracer := otel.Tracer("real-staging-api/sse")
 	ctx, span := tracer.Start(ctx, "sse.StreamImage")
 	span.SetAttributes(attribute.String("image.id", imageID))
 	defer span.End()
-	log := logging.NewDefaultLogger()
 
-	if d.rdb == nil {
-		err := errors.New("redis client is nil")
+	if imageID == "" {
+		err := errors.New("imageID required")
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 		return err
 	}
-	if imageID == "" {
-		err := errors.New("imageID required")
+	return d.stream(ctx, span, w, StreamKey(imageID), lastEventID, true, "Connected to image stream")
+}
+
+// StreamProject reads the project's Redis stream and forwards the status transitions of
+// every image in it via SSE, as "job_update" events with the payload
+// {"image_id":"...","status":"..."}. Events are tagged and resumed with lastEventID like
+// StreamImage's. Without one, the stream starts with the next transition: a client that has
+// just listed the project's images already knows each one's current status.
+func (d *DefaultSSE) StreamProject(ctx context.Context, w io.Writer, projectID, lastEventID string) error {
+	tracer := otel.Tracer("real-staging-api/sse")
+	ctx, span := tracer.Start(ctx, "sse.StreamProject")
+	span.SetAttributes(attribute.String("project.id", projectID))
+	defer span.End()
+
+	if projectID == "" {
+		err := errors.New("projectID required")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	return d.stream(ctx, span, w, ProjectStreamKey(projectID), lastEventID, false, "Connected to project stream")
+}
+
+// stream serves the Redis stream at key until ctx is cancelled. replayLatest sends a fresh
+// client the stream's latest entry; otherwise it only sees entries added after it connects.
+func (d *DefaultSSE) stream(
+	ctx context.Context, span trace.Span, w io.Writer, key, lastEventID string, replayLatest bool, connected string,
+) error {
+	log := logging.NewDefaultLogger()
+
+	if d.rdb == nil {
+		err := errors.New("redis client is nil")
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 		return err
 	}
 
-	channel := fmt.Sprintf(d.channelFmt, imageID)
-	span.SetAttributes(attribute.String("sse.channel", channel))
-	sub := d.rdb.Subscribe(ctx, channel)
-	defer func() { _ = sub.Close() }()
+	if !streamIDPattern.MatchString(lastEventID) {
+		// Not an ID this stream handed out; start fresh rather than fail the request.
+		lastEventID = ""
+	}
+	span.SetAttributes(attribute.String("sse.stream", key), attribute.Bool("sse.resumed", lastEventID != ""))
 
-	// Optionally wait for subscription to be established
-	// (Receive returns a Subscription or PONG internally; ignore value).
-	if err := d.awaitSubscribe(ctx, sub); err != nil {
+	backlog, err := d.backlog(ctx, key, lastEventID)
+	if err != nil {
 		span.RecordError(err)
-		span.SetStatus(codes.Error, "subscribe failed")
-		log.Error(ctx, "sse subscribe failed", "sse.channel", channel, "image_id", imageID, "error", err)
-		return fmt.Errorf("subscribe to %s: %w", channel, err)
+		span.SetStatus(codes.Error, "read stream failed")
+		log.Error(ctx, "sse read stream failed", "sse.stream", key, "error", err)
+		return fmt.Errorf("read %s: %w", key, err)
 	}
 
 	// Initial "connected" event
-	if err := writeSSE(w, EventConnected, map[string]string{"message": "Connected to image stream"}); err != nil {
+	if err := writeSSE(w, "", EventConnected, map[string]string{"message": connected}); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "write connected event failed")
-		log.Error(ctx, "sse write connected failed", "sse.channel", channel, "image_id", imageID, "error", err)
+		log.Error(ctx, "sse write connected failed", "sse.stream", key, "error", err)
 		return err
 	}
 	flush(w)
 
+	lastID := "0-0"
+	if lastEventID == "" && !replayLatest && len(backlog) > 0 {
+		// Skip the latest entry, reading on from it.
+		lastID = backlog[0].ID
+		backlog = nil
+	}
+	for _, msg := range backlog {
+		if err := d.writeUpdate(ctx, w, key, msg); err != nil {
+			span.SetStatus(codes.Error, "write job_update failed")
+			return err
+		}
+		lastID = msg.ID
+	}
+	if len(backlog) == 0 && lastEventID != "" {
+		lastID = lastEventID
+	}
+	flush(w)
+
+	// Blocking reads run on their own goroutine so heartbeats keep their cadence.
+	readCtx, stopReading := context.WithCancel(ctx)
+	defer stopReading()
+	msgCh, errCh := d.read(readCtx, key, lastID)
+
 	// Heartbeat ticker
 	ticker := time.NewTicker(d.heartbeat)
 	defer ticker.Stop()
-	msgCh := sub.Channel()
 
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
 		case <-ticker.C:
-			if err := writeSSE(w, EventHeartbeat, map[string]any{"timestamp": time.Now().Unix()}); err != nil {
+			if err := writeSSE(w, "", EventHeartbeat, map[string]any{"timestamp": time.Now().Unix()}); err != nil {
 				span.RecordError(err)
 				span.SetStatus(codes.Error, "write heartbeat failed")
-				log.Error(ctx, "sse write heartbeat failed", "sse.channel", channel, "image_id", imageID, "error", err)
+				log.Error(ctx, "sse write heartbeat failed", "sse.stream", key, "error", err)
 				return err
 			}
 			flush(w)
-		case msg, ok := <-msgCh:
-			if !ok {
-				// Subscription channel closed (unsubscribe or Redis connection closed); exit gracefully.
-				log.Info(ctx, "sse subscription channel closed", "sse.channel", channel, "image_id", imageID)
-				return nil
-			}
-			// Expect minimal status-only JSON payload: {"status":"..."}
-			var payload struct {
-				Status string `json:"status"`
-			}
-			if err := json.Unmarshal([]byte(msg.Payload), &payload); err != nil || payload.Status == "" {
-				// Ignore malformed payloads to keep the stream healthy.
-				if err != nil {
-					log.Warn(ctx, "sse malformed payload", "sse.channel", channel, "image_id", imageID, "error", err)
-				}
-				continue
-			}
-			if err := writeSSE(w, EventJobUpdate, map[string]string{"status": payload.Status}); err != nil {
+		case err := <-errCh:
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "read stream failed")
+			log.Error(ctx, "sse read stream failed", "sse.stream", key, "error", err)
+			return fmt.Errorf("read %s: %w", key, err)
+		case msg := <-msgCh:
+			if err := d.writeUpdate(ctx, w, key, msg); err != nil {
 				span.SetStatus(codes.Error, "write job_update failed")
-				log.Error(ctx, "sse write job_update failed",
-					"sse.channel", channel, "image_id", imageID, "status", payload.Status, "error", err)
 				return err
 			}
 			flush(w)
@@ -142,20 +226,100 @@ func (d *DefaultSSE) StreamImage(ctx context.Context, w io.Writer, imageID strin
 	}
 }
 
-func (d *DefaultSSE) awaitSubscribe(ctx context.Context, sub *redis.PubSub) error {
+// backlog returns the entries a new stream starts with: those after lastEventID when the
+// client is resuming, otherwise the latest entry alone.
+func (d *DefaultSSE) backlog(ctx context.Context, key, lastEventID string) ([]redis.XMessage, error) {
 	callCtx := ctx
 	if d.subscribeTimeout > 0 {
 		var cancel context.CancelFunc
 		callCtx, cancel = context.WithTimeout(ctx, d.subscribeTimeout)
 		defer cancel()
 	}
-	// Receive returns when the subscription is created or on context cancellation/error.
-	_, err := sub.Receive(callCtx)
-	return err
+	if lastEventID != "" {
+		return d.rdb.XRange(callCtx, key, "("+lastEventID, "+").Result()
+	}
+	return d.rdb.XRevRangeN(callCtx, key, "+", "-", 1).Result()
+}
+
+// read delivers entries added to the stream after lastID until ctx is cancelled.
+// A read error is sent on the error channel and ends the reads.
+func (d *DefaultSSE) read(ctx context.Context, key, lastID string) (<-chan redis.XMessage, <-chan error) {
+	msgCh := make(chan redis.XMessage)
+	errCh := make(chan error, 1)
+	go func() {
+		for {
+			streams, err := d.rdb.XRead(ctx, &redis.XReadArgs{
+				Streams: []string{key, lastID},
+				Count:   100,
+				Block:   d.readBlock,
+			}).Result()
+			if ctx.Err() != nil {
+				return
+			}
+			if errors.Is(err, redis.Nil) {
+				continue
+			}
+			if err != nil {
+				errCh <- err
+				return
+			}
+			for _, stream := range streams {
+				for _, msg := range stream.Messages {
+					select {
+					case msgCh <- msg:
+						lastID = msg.ID
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+	return msgCh, errCh
+}
+
+// writeUpdate forwards one stream entry as a job_update event. Malformed entries are
+// skipped to keep the stream healthy.
+func (d *DefaultSSE) writeUpdate(ctx context.Context, w io.Writer, key string, msg redis.XMessage) error {
+	log := logging.NewDefaultLogger()
+
+	// Expect minimal JSON payload: {"status":"..."}, flagged "preview" when a preview is ready.
+	// Project stream entries also name the image: {"image_id":"...","status":"..."}.
+	raw, _ := msg.Values[streamPayloadField].(string)
+	var payload struct {
+		ImageID string `json:"image_id"`
+		Status  string `json:"status"`
+		Preview bool   `json:"preview"`
+	}
+	if err := json.Unmarshal([]byte(raw), &payload); err != nil || payload.Status == "" {
+		if err != nil {
+			log.Warn(ctx, "sse malformed payload", "sse.stream", key, "error", err)
+		}
+		return nil
+	}
+	data := map[string]any{"status": payload.Status}
+	if payload.ImageID != "" {
+		data["image_id"] = payload.ImageID
+	}
+	if payload.Preview {
+		data["preview"] = true
+	}
+	if err := writeSSE(w, msg.ID, EventJobUpdate, data); err != nil {
+		log.Error(ctx, "sse write job_update failed",
+			"sse.stream", key, "image_id", payload.ImageID, "status", payload.Status, "error", err)
+		return err
+	}
+	return nil
 }
 
 // writeSSE writes a single Server-Sent Event to w following the SSE wire format.
-func writeSSE(w io.Writer, event string, data any) error {
+// A non-empty id is sent as the event's id, which browsers return as Last-Event-ID.
+func writeSSE(w io.Writer, id, event string, data any) error {
+	if id != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
 	if event != "" {
 		if _, err := fmt.Fprintf(w, "event: %s\n", event); err != nil {
 			return err
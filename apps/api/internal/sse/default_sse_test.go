@@ -36,6 +36,19 @@ func (b *bufFlusher) String() string {
 	return b.buf.String()
 }
 
+// appendUpdate adds a status payload to the image's stream the way the worker does.
+func appendUpdate(t *testing.T, rdb *redis.Client, imageID, payload string) string {
+	t.Helper()
+	id, err := rdb.XAdd(context.Background(), &redis.XAddArgs{
+		Stream: StreamKey(imageID),
+		Values: map[string]any{streamPayloadField: payload},
+	}).Result()
+	if err != nil {
+		t.Fatalf("xadd failed: %v", err)
+	}
+	return id
+}
+
 func waitFor(t *testing.T, timeout time.Duration, cond func() bool) {
 	t.Helper()
 	deadline := time.Now().Add(timeout)
@@ -69,7 +82,7 @@ func TestDefaultSSE_StreamImage_StatusUpdate(t *testing.T) {
 	// Start streaming in a goroutine
 	done := make(chan struct{})
 	go func() {
-		_ = sse.StreamImage(ctx, w, "img-123")
+		_ = sse.StreamImage(ctx, w, "img-123", "")
 		close(done)
 	}()
 
@@ -78,16 +91,14 @@ func TestDefaultSSE_StreamImage_StatusUpdate(t *testing.T) {
 		return strings.Contains(w.String(), "event: connected")
 	})
 
-	// Publish a status update to per-image channel
-	channel := "jobs:image:img-123"
-	if err := rdb.Publish(ctx, channel, `{"status":"processing"}`).Err(); err != nil {
-		t.Fatalf("publish failed: %v", err)
-	}
+	// Append a status update to the per-image stream
+	id := appendUpdate(t, rdb, "img-123", `{"status":"processing"}`)
 
-	// Wait for job_update event to appear
+	// Wait for job_update event to appear, tagged with its stream ID
 	waitFor(t, 500*time.Millisecond, func() bool {
 		s := w.String()
-		return strings.Contains(s, "event: job_update") && strings.Contains(s, `data: {"status":"processing"}`)
+		return strings.Contains(s, "id: "+id+"\nevent: job_update") &&
+			strings.Contains(s, `data: {"status":"processing"}`)
 	})
 
 	// Cancel and ensure the goroutine exits
@@ -109,7 +120,7 @@ func TestDefaultSSE_StreamImage_MissingImageID(t *testing.T) {
 
 	sse := NewDefaultSSE(rdb, Config{})
 
-	err := sse.StreamImage(context.Background(), &bufFlusher{}, "")
+	err := sse.StreamImage(context.Background(), &bufFlusher{}, "", "")
 	if err == nil || !strings.Contains(err.Error(), "imageID required") {
 		t.Fatalf("expected imageID required error, got: %v", err)
 	}
@@ -130,7 +141,7 @@ func TestDefaultSSE_StreamImage_IgnoresMalformedPayload(t *testing.T) {
 	w := &bufFlusher{}
 	done := make(chan struct{})
 	go func() {
-		_ = sse.StreamImage(ctx, w, "img-bad")
+		_ = sse.StreamImage(ctx, w, "img-bad", "")
 		close(done)
 	}()
 
@@ -139,10 +150,9 @@ func TestDefaultSSE_StreamImage_IgnoresMalformedPayload(t *testing.T) {
 		return strings.Contains(w.String(), "event: connected")
 	})
 
-	// Publish malformed payloads
-	channel := "jobs:image:img-bad"
-	_ = rdb.Publish(ctx, channel, `{"foo":"bar"}`).Err() // missing status
-	_ = rdb.Publish(ctx, channel, `not-json`).Err()
+	// Append malformed payloads
+	appendUpdate(t, rdb, "img-bad", `{"foo":"bar"}`) // missing status
+	appendUpdate(t, rdb, "img-bad", `not-json`)
 
 	// Ensure no job_update event appears within a small window
 	time.Sleep(150 * time.Millisecond)
@@ -160,7 +170,7 @@ func TestDefaultSSE_StreamImage_IgnoresMalformedPayload(t *testing.T) {
 }
 
 func TestDefaultSSE_SubscribeError(t *testing.T) {
-	// Start and immediately close miniredis to induce a subscribe error
+	// Start and immediately close miniredis to induce a read error
 	mr := miniredis.RunT(t)
 	addr := mr.Addr()
 	mr.Close()
@@ -169,9 +179,9 @@ func TestDefaultSSE_SubscribeError(t *testing.T) {
 	defer func() { _ = rdb.Close() }()
 
 	sse := NewDefaultSSE(rdb, Config{})
-	err := sse.StreamImage(context.Background(), &bufFlusher{}, "img-sub-fail")
+	err := sse.StreamImage(context.Background(), &bufFlusher{}, "img-sub-fail", "")
 	if err == nil {
-		t.Fatal("expected error due to subscription failure, got nil")
+		t.Fatal("expected error due to read failure, got nil")
 	}
 }
 
@@ -192,7 +202,7 @@ func TestDefaultSSE_HeartbeatCadence(t *testing.T) {
 	w := &bufFlusher{}
 	done := make(chan struct{})
 	go func() {
-		_ = sse.StreamImage(ctx, w, "img-hb")
+		_ = sse.StreamImage(ctx, w, "img-hb", "")
 		close(done)
 	}()
 
@@ -238,11 +248,11 @@ func TestDefaultSSE_MultipleSubscribers_Isolation(t *testing.T) {
 	doneB := make(chan struct{})
 
 	go func() {
-		_ = sse.StreamImage(ctxA, wA, "img-A")
+		_ = sse.StreamImage(ctxA, wA, "img-A", "")
 		close(doneA)
 	}()
 	go func() {
-		_ = sse.StreamImage(ctxB, wB, "img-B")
+		_ = sse.StreamImage(ctxB, wB, "img-B", "")
 		close(doneB)
 	}()
 
@@ -254,11 +264,8 @@ func TestDefaultSSE_MultipleSubscribers_Isolation(t *testing.T) {
 		return strings.Contains(wB.String(), "event: connected")
 	})
 
-	// Publish to A only
-	channelA := "jobs:image:img-A"
-	if err := rdb.Publish(ctxA, channelA, `{"status":"processing"}`).Err(); err != nil {
-		t.Fatalf("publish to A failed: %v", err)
-	}
+	// Append to A only
+	appendUpdate(t, rdb, "img-A", `{"status":"processing"}`)
 
 	// A should see processing; B should not
 	waitFor(t, 500*time.Millisecond, func() bool {
@@ -271,11 +278,8 @@ func TestDefaultSSE_MultipleSubscribers_Isolation(t *testing.T) {
 		t.Fatalf("B received update intended for A: %s", wB.String())
 	}
 
-	// Publish to B only
-	channelB := "jobs:image:img-B"
-	if err := rdb.Publish(ctxB, channelB, `{"status":"ready"}`).Err(); err != nil {
-		t.Fatalf("publish to B failed: %v", err)
-	}
+	// Append to B only
+	appendUpdate(t, rdb, "img-B", `{"status":"ready"}`)
 
 	// B should see ready; A should not get B's ready
 	waitFor(t, 500*time.Millisecond, func() bool {
@@ -300,3 +304,241 @@ func TestDefaultSSE_MultipleSubscribers_Isolation(t *testing.T) {
 		t.Fatal("stream B did not stop after cancel")
 	}
 }
+
+func TestDefaultSSE_StreamImage_ReplaysLatestOnConnect(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = rdb.Close() }()
+
+	// Updates published before the client connected.
+	appendUpdate(t, rdb, "img-late", `{"status":"queued"}`)
+	latest := appendUpdate(t, rdb, "img-late", `{"status":"processing"}`)
+
+	sse := NewDefaultSSE(rdb, Config{HeartbeatInterval: time.Second})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := &bufFlusher{}
+	done := make(chan struct{})
+	go func() {
+		_ = sse.StreamImage(ctx, w, "img-late", "")
+		close(done)
+	}()
+
+	waitFor(t, 500*time.Millisecond, func() bool {
+		return strings.Contains(w.String(), "id: "+latest+"\nevent: job_update")
+	})
+	if strings.Contains(w.String(), `"status":"queued"`) {
+		t.Fatalf("only the latest update should be replayed: %s", w.String())
+	}
+
+	cancel()
+	<-done
+}
+
+func TestDefaultSSE_StreamImage_ResumesAfterLastEventID(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = rdb.Close() }()
+
+	seen := appendUpdate(t, rdb, "img-resume", `{"status":"queued"}`)
+	appendUpdate(t, rdb, "img-resume", `{"status":"processing"}`)
+	appendUpdate(t, rdb, "img-resume", `{"status":"ready"}`)
+
+	sse := NewDefaultSSE(rdb, Config{HeartbeatInterval: time.Second})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := &bufFlusher{}
+	done := make(chan struct{})
+	go func() {
+		_ = sse.StreamImage(ctx, w, "img-resume", seen)
+		close(done)
+	}()
+
+	waitFor(t, 500*time.Millisecond, func() bool {
+		return strings.Count(w.String(), "event: job_update") == 2
+	})
+	s := w.String()
+	if strings.Contains(s, `"status":"queued"`) {
+		t.Fatalf("already-seen update was replayed: %s", s)
+	}
+	if strings.Index(s, `"status":"processing"`) > strings.Index(s, `"status":"ready"`) {
+		t.Fatalf("updates replayed out of order: %s", s)
+	}
+
+	cancel()
+	<-done
+}
+
+func TestDefaultSSE_StreamImage_InvalidLastEventIDStartsFresh(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = rdb.Close() }()
+
+	appendUpdate(t, rdb, "img-bad-id", `{"status":"processing"}`)
+
+	sse := NewDefaultSSE(rdb, Config{HeartbeatInterval: time.Second})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := &bufFlusher{}
+	done := make(chan struct{})
+	go func() {
+		_ = sse.StreamImage(ctx, w, "img-bad-id", "not-an-id")
+		close(done)
+	}()
+
+	waitFor(t, 500*time.Millisecond, func() bool {
+		return strings.Contains(w.String(), `data: {"status":"processing"}`)
+	})
+
+	cancel()
+	<-done
+}
+
+func TestDefaultSSE_StreamImage_AllReplicasSeeEveryUpdate(t *testing.T) {
+	mr := miniredis.RunT(t)
+
+	// Two replicas with their own Redis clients serve the same image.
+	replicas := make([]*DefaultSSE, 2)
+	writers := make([]*bufFlusher, 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var wg sync.WaitGroup
+	for i := range replicas {
+		rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		defer func() { _ = rdb.Close() }()
+		replicas[i] = NewDefaultSSE(rdb, Config{HeartbeatInterval: time.Second})
+		writers[i] = &bufFlusher{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = replicas[i].StreamImage(ctx, writers[i], "img-shared", "")
+		}()
+	}
+	for _, w := range writers {
+		waitFor(t, 500*time.Millisecond, func() bool {
+			return strings.Contains(w.String(), "event: connected")
+		})
+	}
+
+	worker := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = worker.Close() }()
+	appendUpdate(t, worker, "img-shared", `{"status":"processing"}`)
+	appendUpdate(t, worker, "img-shared", `{"status":"ready"}`)
+
+	for _, w := range writers {
+		waitFor(t, time.Second, func() bool {
+			s := w.String()
+			return strings.Contains(s, `data: {"status":"processing"}`) &&
+				strings.Contains(s, `data: {"status":"ready"}`)
+		})
+	}
+
+	cancel()
+	wg.Wait()
+}
+
+func TestStreamKey(t *testing.T) {
+	if got := StreamKey("img-1"); got != "jobs:image:img-1:events" {
+		t.Fatalf("unexpected stream key %q", got)
+	}
+}
+
+func appendProjectUpdate(t *testing.T, rdb *redis.Client, projectID, payload string) string {
+	t.Helper()
+	id, err := rdb.XAdd(context.Background(), &redis.XAddArgs{
+		Stream: ProjectStreamKey(projectID),
+		Values: map[string]any{streamPayloadField: payload},
+	}).Result()
+	if err != nil {
+		t.Fatalf("xadd failed: %v", err)
+	}
+	return id
+}
+
+func TestDefaultSSE_StreamProject_StartsAfterLatest(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = rdb.Close() }()
+
+	// A transition made before the client connected is not sent.
+	appendProjectUpdate(t, rdb, "proj-1", `{"image_id":"img-1","status":"queued"}`)
+
+	sse := NewDefaultSSE(rdb, Config{HeartbeatInterval: time.Second})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := &bufFlusher{}
+	done := make(chan struct{})
+	go func() {
+		_ = sse.StreamProject(ctx, w, "proj-1", "")
+		close(done)
+	}()
+
+	waitFor(t, 500*time.Millisecond, func() bool {
+		return strings.Contains(w.String(), "event: connected")
+	})
+	id := appendProjectUpdate(t, rdb, "proj-1", `{"image_id":"img-2","status":"processing"}`)
+
+	waitFor(t, 500*time.Millisecond, func() bool {
+		s := w.String()
+		return strings.Contains(s, "id: "+id+"\nevent: job_update") &&
+			strings.Contains(s, `data: {"image_id":"img-2","status":"processing"}`)
+	})
+	if strings.Contains(w.String(), `"status":"queued"`) {
+		t.Fatalf("transition made before connecting was sent: %s", w.String())
+	}
+
+	cancel()
+	<-done
+}
+
+func TestDefaultSSE_StreamProject_ResumesAfterLastEventID(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = rdb.Close() }()
+
+	seen := appendProjectUpdate(t, rdb, "proj-resume", `{"image_id":"img-1","status":"queued"}`)
+	appendProjectUpdate(t, rdb, "proj-resume", `{"image_id":"img-1","status":"processing"}`)
+	appendProjectUpdate(t, rdb, "proj-resume", `{"image_id":"img-2","status":"error"}`)
+
+	sse := NewDefaultSSE(rdb, Config{HeartbeatInterval: time.Second})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := &bufFlusher{}
+	done := make(chan struct{})
+	go func() {
+		_ = sse.StreamProject(ctx, w, "proj-resume", seen)
+		close(done)
+	}()
+
+	waitFor(t, 500*time.Millisecond, func() bool {
+		return strings.Count(w.String(), "event: job_update") == 2
+	})
+	s := w.String()
+	if strings.Contains(s, `"status":"queued"`) {
+		t.Fatalf("already-seen transition was replayed: %s", s)
+	}
+	if strings.Index(s, `"status":"processing"`) > strings.Index(s, `"status":"error"`) {
+		t.Fatalf("transitions replayed out of order: %s", s)
+	}
+
+	cancel()
+	<-done
+}
+
+func TestDefaultSSE_StreamProject_MissingProjectID(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = rdb.Close() }()
+
+	sse := NewDefaultSSE(rdb, Config{})
+
+	err := sse.StreamProject(context.Background(), &bufFlusher{}, "", "")
+	if err == nil || !strings.Contains(err.Error(), "projectID required") {
+		t.Fatalf("expected projectID required error, got: %v", err)
+	}
+}
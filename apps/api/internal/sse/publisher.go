@@ -0,0 +1,80 @@
+package sse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	redis "github.com/redis/go-redis/v9"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out publisher_mock.go . Publisher
+
+const (
+	// projectStreamMaxLen and streamTTL match the worker's, which appends the later
+	// transitions to the same project streams.
+	projectStreamMaxLen = 1000
+	streamTTL           = 24 * time.Hour
+)
+
+// StatusQueued is the status an image is in once its staging job is queued.
+const StatusQueued = "queued"
+
+// Publisher appends the status transitions the API makes to the project streams that
+// StreamProject reads. The worker appends the transitions it makes.
+type Publisher interface {
+	// PublishQueued records that an image in the project was queued for staging.
+	PublishQueued(ctx context.Context, projectID, imageID string) error
+}
+
+// DefaultPublisher is a Redis Streams–backed Publisher.
+type DefaultPublisher struct {
+	rdb *redis.Client
+}
+
+// NewDefaultPublisherFromConfig constructs a DefaultPublisher connected to cfg.RedisAddr.
+func NewDefaultPublisherFromConfig(cfg Config) (*DefaultPublisher, error) {
+	if cfg.RedisAddr == "" {
+		return nil, errors.New("redis address is not configured")
+	}
+	return NewDefaultPublisher(redis.NewClient(&redis.Options{Addr: cfg.RedisAddr})), nil
+}
+
+// NewDefaultPublisher initializes a DefaultPublisher with an existing Redis client.
+func NewDefaultPublisher(rdb *redis.Client) *DefaultPublisher {
+	return &DefaultPublisher{rdb: rdb}
+}
+
+// PublishQueued appends {"image_id":"...","status":"queued"} to the project's stream.
+func (p *DefaultPublisher) PublishQueued(ctx context.Context, projectID, imageID string) error {
+	if projectID == "" || imageID == "" {
+		return errors.New("projectID and imageID required")
+	}
+	payload, err := json.Marshal(map[string]string{"image_id": imageID, "status": StatusQueued})
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+	key := ProjectStreamKey(projectID)
+	pipe := p.rdb.TxPipeline()
+	pipe.XAdd(ctx, &redis.XAddArgs{
+		Stream: key,
+		MaxLen: projectStreamMaxLen,
+		Approx: true,
+		Values: map[string]any{streamPayloadField: payload},
+	})
+	pipe.Expire(ctx, key, streamTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("append to %s: %w", key, err)
+	}
+	return nil
+}
+
+// NoopPublisher is a Publisher for when Redis is not configured.
+type NoopPublisher struct{}
+
+// PublishQueued does nothing and returns no error.
+func (NoopPublisher) PublishQueued(_ context.Context, _, _ string) error {
+	return nil
+}
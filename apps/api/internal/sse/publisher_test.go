@@ -0,0 +1,52 @@
+package sse
+
+import (
+	"context"
+	"testing"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultPublisher_PublishQueued(t *testing.T) {
+	testCases := []struct {
+		name      string
+		projectID string
+		imageID   string
+		expectErr bool
+	}{
+		{name: "success: appends to the project stream", projectID: "proj-1", imageID: "img-1"},
+		{name: "fail: missing project id", imageID: "img-1", expectErr: true},
+		{name: "fail: missing image id", projectID: "proj-1", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mr := miniredis.RunT(t)
+			rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+			defer func() { _ = rdb.Close() }()
+			pub := NewDefaultPublisher(rdb)
+
+			err := pub.PublishQueued(context.Background(), tc.projectID, tc.imageID)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			key := ProjectStreamKey(tc.projectID)
+			entries, err := rdb.XRange(context.Background(), key, "-", "+").Result()
+			require.NoError(t, err)
+			require.Len(t, entries, 1)
+			assert.JSONEq(t, `{"image_id":"img-1","status":"queued"}`, entries[0].Values[streamPayloadField].(string))
+			assert.Equal(t, streamTTL, mr.TTL(key))
+		})
+	}
+}
+
+func TestNewDefaultPublisherFromConfig_MissingRedisAddr(t *testing.T) {
+	_, err := NewDefaultPublisherFromConfig(Config{})
+	assert.Error(t, err)
+}
@@ -15,7 +15,7 @@ import (
 // Implementations should:
 // - Emit an initial "connected" event after a successful subscription.
 // - Periodically emit "heartbeat" events at the configured interval.
-// - Forward minimal, status-only job update messages received from a per-image channel.
+// - Forward minimal, status-only job update messages read from a Redis stream.
 // - Handle context cancellation for client disconnects and cleanup.
 //
 // The HTTP layer is responsible for setting appropriate SSE headers before
@@ -25,13 +25,22 @@ import (
 type SSE interface {
 	// StreamImage streams events for a single image identified by imageID.
 	//
-	// The implementation should subscribe to a per-image channel (e.g., jobs:image:{imageID}),
-	// forward status-only payloads as SSE "job_update" events, send an initial "connected"
-	// event, and send periodic "heartbeat" events until ctx is cancelled.
+	// The implementation should read the image's shared update stream (see StreamKey),
+	// forward status-only payloads as SSE "job_update" events tagged with their stream
+	// ID, send an initial "connected" event, and send periodic "heartbeat" events until
+	// ctx is cancelled. lastEventID is the client's Last-Event-ID; when set, updates after
+	// it are replayed first so a client that reconnects to another replica misses nothing.
 	//
 	// The writer is typically an http.ResponseWriter. If it implements Flusher,
 	// the implementation should call Flush() after sending events to reduce latency.
-	StreamImage(ctx context.Context, w io.Writer, imageID string) error
+	StreamImage(ctx context.Context, w io.Writer, imageID, lastEventID string) error
+
+	// StreamProject streams the status transitions of every image in the project
+	// identified by projectID, read from the project's shared stream (see
+	// ProjectStreamKey). Each "job_update" event names its image. Events, heartbeats and
+	// lastEventID behave as for StreamImage, except that a client that is not resuming
+	// is only sent transitions made after it connects.
+	StreamProject(ctx context.Context, w io.Writer, projectID, lastEventID string) error
 }
 
 // Handler defines the HTTP-level handler for SSE endpoints, typically using Echo.
@@ -39,6 +48,11 @@ type Handler interface {
 	// Events handles GET /api/v1/events?image_id={id}
 	// It should set SSE headers and delegate to an SSE implementation.
 	Events(c echo.Context) error
+
+	// ProjectEvents handles GET /api/v1/projects/:id/events
+	// It should set SSE headers and delegate to an SSE implementation. Callers check
+	// the user may see the project before invoking it.
+	ProjectEvents(c echo.Context) error
 }
 
 // Flusher is the minimal interface extracted from http.Flusher to avoid
@@ -61,8 +75,11 @@ type Config struct {
 	// If zero, a reasonable default (e.g., 30s) should be used.
 	HeartbeatInterval time.Duration
 
-	// SubscribeTimeout controls how long to wait when establishing a subscription
-	// to the underlying pub/sub before returning an error. If zero, implementations
-	// may choose a reasonable default or rely on context deadlines.
+	// SubscribeTimeout controls how long to wait for the initial read of the underlying
+	// stream before returning an error. If zero, implementations may choose a reasonable
+	// default or rely on context deadlines.
 	SubscribeTimeout time.Duration
+
+	// RedisAddr is the Redis server NewDefaultSSEFromConfig connects to.
+	RedisAddr string
 }
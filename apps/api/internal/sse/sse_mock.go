@@ -20,9 +20,12 @@ var _ SSE = &SSEMock{}
 //
 //		// make and configure a mocked SSE
 //		mockedSSE := &SSEMock{
-//			StreamImageFunc: func(ctx context.Context, w io.Writer, imageID string) error {
+//			StreamImageFunc: func(ctx context.Context, w io.Writer, imageID string, lastEventID string) error {
 //				panic("mock out the StreamImage method")
 //			},
+//			StreamProjectFunc: func(ctx context.Context, w io.Writer, projectID string, lastEventID string) error {
+//				panic("mock out the StreamProject method")
+//			},
 //		}
 //
 //		// use mockedSSE in code that requires SSE
@@ -31,7 +34,10 @@ var _ SSE = &SSEMock{}
 //	}
 type SSEMock struct {
 	// StreamImageFunc mocks the StreamImage method.
-	StreamImageFunc func(ctx context.Context, w io.Writer, imageID string) error
+	StreamImageFunc func(ctx context.Context, w io.Writer, imageID string, lastEventID string) error
+
+	// StreamProjectFunc mocks the StreamProject method.
+	StreamProjectFunc func(ctx context.Context, w io.Writer, projectID string, lastEventID string) error
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -43,29 +49,45 @@ type SSEMock struct {
 			W io.Writer
 			// ImageID is the imageID argument value.
 			ImageID string
+			// LastEventID is the lastEventID argument value.
+			LastEventID string
+		}
+		// StreamProject holds details about calls to the StreamProject method.
+		StreamProject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// W is the w argument value.
+			W io.Writer
+			// ProjectID is the projectID argument value.
+			ProjectID string
+			// LastEventID is the lastEventID argument value.
+			LastEventID string
 		}
 	}
-	lockStreamImage sync.RWMutex
+	lockStreamImage   sync.RWMutex
+	lockStreamProject sync.RWMutex
 }
 
 // StreamImage calls StreamImageFunc.
-func (mock *SSEMock) StreamImage(ctx context.Context, w io.Writer, imageID string) error {
+func (mock *SSEMock) StreamImage(ctx context.Context, w io.Writer, imageID string, lastEventID string) error {
 	if mock.StreamImageFunc == nil {
 		panic("SSEMock.StreamImageFunc: method is nil but SSE.StreamImage was just called")
 	}
 	callInfo := struct {
-		Ctx     context.Context
-		W       io.Writer
-		ImageID string
+		Ctx         context.Context
+		W           io.Writer
+		ImageID     string
+		LastEventID string
 	}{
-		Ctx:     ctx,
-		W:       w,
-		ImageID: imageID,
+		Ctx:         ctx,
+		W:           w,
+		ImageID:     imageID,
+		LastEventID: lastEventID,
 	}
 	mock.lockStreamImage.Lock()
 	mock.calls.StreamImage = append(mock.calls.StreamImage, callInfo)
 	mock.lockStreamImage.Unlock()
-	return mock.StreamImageFunc(ctx, w, imageID)
+	return mock.StreamImageFunc(ctx, w, imageID, lastEventID)
 }
 
 // StreamImageCalls gets all the calls that were made to StreamImage.
@@ -73,14 +95,16 @@ func (mock *SSEMock) StreamImage(ctx context.Context, w io.Writer, imageID strin
 //
 //	len(mockedSSE.StreamImageCalls())
 func (mock *SSEMock) StreamImageCalls() []struct {
-	Ctx     context.Context
-	W       io.Writer
-	ImageID string
+	Ctx         context.Context
+	W           io.Writer
+	ImageID     string
+	LastEventID string
 } {
 	var calls []struct {
-		Ctx     context.Context
-		W       io.Writer
-		ImageID string
+		Ctx         context.Context
+		W           io.Writer
+		ImageID     string
+		LastEventID string
 	}
 	mock.lockStreamImage.RLock()
 	calls = mock.calls.StreamImage
@@ -88,6 +112,50 @@ func (mock *SSEMock) StreamImageCalls() []struct {
 	return calls
 }
 
+// StreamProject calls StreamProjectFunc.
+func (mock *SSEMock) StreamProject(ctx context.Context, w io.Writer, projectID string, lastEventID string) error {
+	if mock.StreamProjectFunc == nil {
+		panic("SSEMock.StreamProjectFunc: method is nil but SSE.StreamProject was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		W           io.Writer
+		ProjectID   string
+		LastEventID string
+	}{
+		Ctx:         ctx,
+		W:           w,
+		ProjectID:   projectID,
+		LastEventID: lastEventID,
+	}
+	mock.lockStreamProject.Lock()
+	mock.calls.StreamProject = append(mock.calls.StreamProject, callInfo)
+	mock.lockStreamProject.Unlock()
+	return mock.StreamProjectFunc(ctx, w, projectID, lastEventID)
+}
+
+// StreamProjectCalls gets all the calls that were made to StreamProject.
+// Check the length with:
+//
+//	len(mockedSSE.StreamProjectCalls())
+func (mock *SSEMock) StreamProjectCalls() []struct {
+	Ctx         context.Context
+	W           io.Writer
+	ProjectID   string
+	LastEventID string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		W           io.Writer
+		ProjectID   string
+		LastEventID string
+	}
+	mock.lockStreamProject.RLock()
+	calls = mock.calls.StreamProject
+	mock.lockStreamProject.RUnlock()
+	return calls
+}
+
 // Ensure, that HandlerMock does implement Handler.
 // If this is not the case, regenerate this file with moq.
 var _ Handler = &HandlerMock{}
@@ -101,6 +169,9 @@ var _ Handler = &HandlerMock{}
 //			EventsFunc: func(c echo.Context) error {
 //				panic("mock out the Events method")
 //			},
+//			ProjectEventsFunc: func(c echo.Context) error {
+//				panic("mock out the ProjectEvents method")
+//			},
 //		}
 //
 //		// use mockedHandler in code that requires Handler
@@ -111,6 +182,9 @@ type HandlerMock struct {
 	// EventsFunc mocks the Events method.
 	EventsFunc func(c echo.Context) error
 
+	// ProjectEventsFunc mocks the ProjectEvents method.
+	ProjectEventsFunc func(c echo.Context) error
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// Events holds details about calls to the Events method.
@@ -118,8 +192,14 @@ type HandlerMock struct {
 			// C is the c argument value.
 			C echo.Context
 		}
+		// ProjectEvents holds details about calls to the ProjectEvents method.
+		ProjectEvents []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
 	}
-	lockEvents sync.RWMutex
+	lockEvents        sync.RWMutex
+	lockProjectEvents sync.RWMutex
 }
 
 // Events calls EventsFunc.
@@ -153,3 +233,35 @@ func (mock *HandlerMock) EventsCalls() []struct {
 	mock.lockEvents.RUnlock()
 	return calls
 }
+
+// ProjectEvents calls ProjectEventsFunc.
+func (mock *HandlerMock) ProjectEvents(c echo.Context) error {
+	if mock.ProjectEventsFunc == nil {
+		panic("HandlerMock.ProjectEventsFunc: method is nil but Handler.ProjectEvents was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockProjectEvents.Lock()
+	mock.calls.ProjectEvents = append(mock.calls.ProjectEvents, callInfo)
+	mock.lockProjectEvents.Unlock()
+	return mock.ProjectEventsFunc(c)
+}
+
+// ProjectEventsCalls gets all the calls that were made to ProjectEvents.
+// Check the length with:
+//
+//	len(mockedHandler.ProjectEventsCalls())
+func (mock *HandlerMock) ProjectEventsCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockProjectEvents.RLock()
+	calls = mock.calls.ProjectEvents
+	mock.lockProjectEvents.RUnlock()
+	return calls
+}
@@ -0,0 +1,29 @@
+package status
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DefaultHandler serves the public status over HTTP.
+type DefaultHandler struct {
+	service Service
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service) *DefaultHandler {
+	return &DefaultHandler{service: service}
+}
+
+// GetStatus handles GET /api/v1/status. It needs no authentication and may be cached by
+// browsers and CDNs for as long as the service caches it.
+func (h *DefaultHandler) GetStatus(c echo.Context) error {
+	s := h.service.GetStatus(c.Request().Context())
+	c.Response().Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(CacheTTL.Seconds())))
+	return c.JSON(http.StatusOK, s)
+}
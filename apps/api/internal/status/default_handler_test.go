@@ -0,0 +1,45 @@
+package status
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultHandler_GetStatus(t *testing.T) {
+	svc := &ServiceMock{
+		GetStatusFunc: func(ctx context.Context) *Status {
+			return &Status{
+				Status: LevelDegraded,
+				Components: []Component{
+					{Name: ComponentAPI, Status: LevelOperational},
+					{Name: ComponentProcessing, Status: LevelDegraded, Backlog: BacklogHigh},
+				},
+				UpdatedAt: time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC),
+			}
+		},
+	}
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/status", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	require.NoError(t, NewDefaultHandler(svc).GetStatus(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "public, max-age=30", rec.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{
+		"status": "degraded",
+		"components": [
+			{"name": "api", "status": "operational"},
+			{"name": "processing", "status": "degraded", "backlog": "high"}
+		],
+		"updated_at": "2025-03-14T15:09:26Z"
+	}`, rec.Body.String())
+}
@@ -0,0 +1,111 @@
+package status
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// DefaultService implements the Service interface, caching the computed status.
+type DefaultService struct {
+	querier queries.Querier
+	// db is pinged with SELECT 1 on the context's tenant, like the querier's statements.
+	db storage.Database
+	// depth reads the job queue. Nil reports the processing backlog as unknown.
+	depth queue.DepthReader
+	now   func() time.Time
+
+	mu      sync.Mutex
+	cached  *Status
+	expires time.Time
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService with a database and an optional queue
+// depth reader.
+func NewDefaultService(db storage.Database, depth queue.DepthReader) *DefaultService {
+	return NewDefaultServiceWithQuerier(queries.New(db), db, depth)
+}
+
+// NewDefaultServiceWithQuerier creates a new DefaultService with a custom querier and
+// database (for testing).
+func NewDefaultServiceWithQuerier(
+	querier queries.Querier, db storage.Database, depth queue.DepthReader,
+) *DefaultService {
+	return &DefaultService{querier: querier, db: db, depth: depth, now: time.Now}
+}
+
+// GetStatus returns the cached status, recomputing it once it is older than CacheTTL.
+// Concurrent callers wait for a single recomputation rather than each running the checks.
+func (s *DefaultService) GetStatus(ctx context.Context) *Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.cached != nil && now.Before(s.expires) {
+		return s.cached
+	}
+
+	s.cached = s.compute(ctx, now)
+	s.expires = now.Add(CacheTTL)
+	return s.cached
+}
+
+func (s *DefaultService) compute(ctx context.Context, now time.Time) *Status {
+	tracer := otel.Tracer("real-staging-api/status")
+	ctx, span := tracer.Start(ctx, "status.compute")
+	defer span.End()
+
+	components := []Component{s.api(ctx), s.processing(ctx), s.provider(ctx, now)}
+	for _, c := range components {
+		span.SetAttributes(attribute.String("status."+c.Name, c.Status))
+	}
+	return &Status{Status: overall(components), Components: components, UpdatedAt: now.UTC()}
+}
+
+// api is in outage when the database cannot be reached, since no request can be served
+// without it.
+func (s *DefaultService) api(ctx context.Context) Component {
+	ctx, cancel := context.WithTimeout(ctx, PingTimeout)
+	defer cancel()
+	if _, err := s.db.Exec(ctx, "SELECT 1"); err != nil {
+		return Component{Name: ComponentAPI, Status: LevelOutage}
+	}
+	return Component{Name: ComponentAPI, Status: LevelOperational}
+}
+
+// processing reports how many tasks are waiting to run. An unreachable queue is an outage,
+// since no new job can be enqueued.
+func (s *DefaultService) processing(ctx context.Context) Component {
+	c := Component{Name: ComponentProcessing, Status: LevelUnknown, Backlog: BacklogUnknown}
+	if s.depth == nil {
+		return c
+	}
+	depth, err := s.depth.Depth(ctx)
+	if err != nil {
+		c.Status = LevelOutage
+		return c
+	}
+	c.Backlog, c.Status = backlogLevel(depth.Pending + depth.Scheduled + depth.Retry)
+	return c
+}
+
+// provider reflects the staging provider through the share of recent jobs that failed.
+func (s *DefaultService) provider(ctx context.Context, now time.Time) Component {
+	since := pgtype.Timestamptz{Time: now.Add(-FailureWindow), Valid: true}
+	outcomes, err := s.querier.GetJobOutcomesSince(ctx, since)
+	if err != nil {
+		return Component{Name: ComponentProvider, Status: LevelUnknown}
+	}
+	return Component{Name: ComponentProvider, Status: failureLevel(outcomes.Completed, outcomes.Failed)}
+}
@@ -0,0 +1,163 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+func outcomes(completed, failed int64) *queries.QuerierMock {
+	return &queries.QuerierMock{
+		GetJobOutcomesSinceFunc: func(
+			ctx context.Context, since pgtype.Timestamptz,
+		) (*queries.GetJobOutcomesSinceRow, error) {
+			return &queries.GetJobOutcomesSinceRow{Completed: completed, Failed: failed}, nil
+		},
+	}
+}
+
+func depth(d *queue.Depth, err error) *queue.DepthReaderMock {
+	return &queue.DepthReaderMock{
+		DepthFunc: func(ctx context.Context) (*queue.Depth, error) { return d, err },
+	}
+}
+
+func reachable(err error) *storage.DatabaseMock {
+	return &storage.DatabaseMock{
+		ExecFunc: func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+			return pgconn.CommandTag{}, err
+		},
+	}
+}
+
+func TestDefaultService_GetStatus(t *testing.T) {
+	now := time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)
+	up := reachable(nil)
+
+	testCases := []struct {
+		name    string
+		querier *queries.QuerierMock
+		db      *storage.DatabaseMock
+		depth   queue.DepthReader
+		want    string
+		wantAll []Component
+	}{
+		{
+			name:    "success: all operational",
+			querier: outcomes(40, 2),
+			db:      up,
+			depth:   depth(&queue.Depth{Pending: 3, Active: 50}, nil),
+			want:    LevelOperational,
+			wantAll: []Component{
+				{Name: ComponentAPI, Status: LevelOperational},
+				{Name: ComponentProcessing, Status: LevelOperational, Backlog: BacklogNormal},
+				{Name: ComponentProvider, Status: LevelOperational},
+			},
+		},
+		{
+			name:    "success: elevated backlog is still operational",
+			querier: outcomes(40, 2),
+			db:      up,
+			depth:   depth(&queue.Depth{Pending: 60, Scheduled: 20, Retry: 20}, nil),
+			want:    LevelOperational,
+			wantAll: []Component{
+				{Name: ComponentAPI, Status: LevelOperational},
+				{Name: ComponentProcessing, Status: LevelOperational, Backlog: BacklogElevated},
+				{Name: ComponentProvider, Status: LevelOperational},
+			},
+		},
+		{
+			name:    "success: high backlog and failing provider",
+			querier: outcomes(30, 10),
+			db:      up,
+			depth:   depth(&queue.Depth{Pending: HighBacklogTasks}, nil),
+			want:    LevelDegraded,
+			wantAll: []Component{
+				{Name: ComponentAPI, Status: LevelOperational},
+				{Name: ComponentProcessing, Status: LevelDegraded, Backlog: BacklogHigh},
+				{Name: ComponentProvider, Status: LevelDegraded},
+			},
+		},
+		{
+			name:    "success: too few jobs to judge the provider",
+			querier: outcomes(1, 5),
+			db:      up,
+			want:    LevelOperational,
+			wantAll: []Component{
+				{Name: ComponentAPI, Status: LevelOperational},
+				{Name: ComponentProcessing, Status: LevelUnknown, Backlog: BacklogUnknown},
+				{Name: ComponentProvider, Status: LevelOperational},
+			},
+		},
+		{
+			name:    "success: provider outage",
+			querier: outcomes(5, 5),
+			db:      up,
+			depth:   depth(&queue.Depth{}, nil),
+			want:    LevelOutage,
+			wantAll: []Component{
+				{Name: ComponentAPI, Status: LevelOperational},
+				{Name: ComponentProcessing, Status: LevelOperational, Backlog: BacklogNormal},
+				{Name: ComponentProvider, Status: LevelOutage},
+			},
+		},
+		{
+			name: "fail: database and queue unreachable",
+			querier: &queries.QuerierMock{
+				GetJobOutcomesSinceFunc: func(
+					ctx context.Context, since pgtype.Timestamptz,
+				) (*queries.GetJobOutcomesSinceRow, error) {
+					return nil, errors.New("connection refused")
+				},
+			},
+			db:    reachable(errors.New("connection refused")),
+			depth: depth(nil, errors.New("redis down")),
+			want:  LevelOutage,
+			wantAll: []Component{
+				{Name: ComponentAPI, Status: LevelOutage},
+				{Name: ComponentProcessing, Status: LevelOutage, Backlog: BacklogUnknown},
+				{Name: ComponentProvider, Status: LevelUnknown},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := NewDefaultServiceWithQuerier(tc.querier, tc.db, tc.depth)
+			svc.now = func() time.Time { return now }
+
+			got := svc.GetStatus(context.Background())
+			assert.Equal(t, tc.want, got.Status)
+			assert.Equal(t, tc.wantAll, got.Components)
+			assert.Equal(t, now, got.UpdatedAt)
+			if calls := tc.querier.GetJobOutcomesSinceCalls(); len(calls) == 1 {
+				assert.Equal(t, now.Add(-FailureWindow), calls[0].Since.Time)
+			}
+		})
+	}
+}
+
+func TestDefaultService_GetStatus_Caches(t *testing.T) {
+	now := time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)
+	db := reachable(nil)
+	svc := NewDefaultServiceWithQuerier(outcomes(10, 0), db, nil)
+	svc.now = func() time.Time { return now }
+
+	first := svc.GetStatus(context.Background())
+	now = now.Add(CacheTTL - time.Second)
+	assert.Same(t, first, svc.GetStatus(context.Background()))
+	assert.Len(t, db.ExecCalls(), 1)
+
+	now = now.Add(time.Second)
+	assert.NotSame(t, first, svc.GetStatus(context.Background()))
+	assert.Len(t, db.ExecCalls(), 2)
+}
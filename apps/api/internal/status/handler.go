@@ -0,0 +1,11 @@
+package status
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP-level contract for the public status endpoint.
+// Implementations should be wired to Echo routes in the server.
+type Handler interface {
+	GetStatus(c echo.Context) error
+}
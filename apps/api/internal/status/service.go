@@ -0,0 +1,12 @@
+package status
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service defines the interface for the public status.
+type Service interface {
+	// GetStatus returns the component health, running the checks at most once per
+	// CacheTTL. Failed checks are reported as levels, never as errors.
+	GetStatus(ctx context.Context) *Status
+}
@@ -0,0 +1,115 @@
+// Package status reports coarse component health for the public status page. It maps
+// internal checks to a few fixed levels so that no counts, hosts or errors are exposed.
+package status
+
+import "time"
+
+const (
+	// CacheTTL is how long a computed status is served before the checks run again.
+	CacheTTL = 30 * time.Second
+	// PingTimeout bounds the database ping behind the api component.
+	PingTimeout = 2 * time.Second
+	// FailureWindow is how far back jobs count towards the provider failure rate.
+	FailureWindow = 15 * time.Minute
+	// MinFinishedJobs is how many jobs must finish within FailureWindow before the failure
+	// rate is trusted; with fewer the provider is reported operational.
+	MinFinishedJobs = 10
+	// DegradedFailureRate and OutageFailureRate are the shares of failed jobs at which the
+	// provider is reported degraded and in outage.
+	DegradedFailureRate = 0.2
+	OutageFailureRate   = 0.5
+	// ElevatedBacklogTasks and HighBacklogTasks are the numbers of waiting tasks at which
+	// the processing backlog is reported elevated and high.
+	ElevatedBacklogTasks = 100
+	HighBacklogTasks     = 1000
+)
+
+// Component levels, from best to worst. Unknown is used when a check cannot run and is
+// ignored when rolling components up into the overall level.
+const (
+	LevelOperational = "operational"
+	LevelDegraded    = "degraded"
+	LevelOutage      = "outage"
+	LevelUnknown     = "unknown"
+)
+
+// Backlog levels of the processing component.
+const (
+	BacklogNormal   = "normal"
+	BacklogElevated = "elevated"
+	BacklogHigh     = "high"
+	BacklogUnknown  = "unknown"
+)
+
+// Component names.
+const (
+	ComponentAPI        = "api"
+	ComponentProcessing = "processing"
+	ComponentProvider   = "provider"
+)
+
+// Component is the health of one part of the service.
+type Component struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	// Backlog is the coarse size of the job queue. It is only set on the processing
+	// component.
+	Backlog string `json:"backlog,omitempty"`
+}
+
+// Status is the public snapshot of component health.
+type Status struct {
+	// Status is the worst known level among Components.
+	Status     string      `json:"status"`
+	Components []Component `json:"components"`
+	// UpdatedAt is when the checks ran; responses are cached for CacheTTL.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// rank orders levels from best to worst.
+var rank = map[string]int{LevelOperational: 0, LevelDegraded: 1, LevelOutage: 2}
+
+// overall returns the worst known level among components, or unknown when none is known.
+func overall(components []Component) string {
+	worst := LevelUnknown
+	for _, c := range components {
+		r, ok := rank[c.Status]
+		if !ok {
+			continue
+		}
+		if worst == LevelUnknown || r > rank[worst] {
+			worst = c.Status
+		}
+	}
+	return worst
+}
+
+// backlogLevel maps the number of waiting tasks to a backlog level and the processing
+// component level.
+func backlogLevel(waiting int) (backlog, level string) {
+	switch {
+	case waiting >= HighBacklogTasks:
+		return BacklogHigh, LevelDegraded
+	case waiting >= ElevatedBacklogTasks:
+		return BacklogElevated, LevelOperational
+	default:
+		return BacklogNormal, LevelOperational
+	}
+}
+
+// failureLevel maps job outcomes within FailureWindow to the provider level.
+func failureLevel(completed, failed int64) string {
+	finished := completed + failed
+	if finished < MinFinishedJobs {
+		return LevelOperational
+	}
+	rate := float64(failed) / float64(finished)
+	switch {
+	case rate >= OutageFailureRate:
+		return LevelOutage
+	case rate >= DegradedFailureRate:
+		return LevelDegraded
+	default:
+		return LevelOperational
+	}
+}
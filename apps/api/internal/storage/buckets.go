@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	configLib "github.com/real-staging-ai/api/internal/config"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out buckets_mock.go . Buckets
+
+// Buckets resolves which S3Service holds an object. Accounts with customer-managed storage
+// keep their images in a bucket of their own; everything else is in the platform bucket.
+type Buckets interface {
+	// ForUser returns the storage new uploads by userID go to.
+	ForUser(ctx context.Context, userID string) (S3Service, error)
+	// ForURL returns the storage holding the object a stored image URL points at, and the
+	// object's key in it.
+	ForURL(ctx context.Context, rawURL string) (S3Service, string, error)
+	// Authorize returns an error wrapping ErrForeignBucket when rawURL is in a customer
+	// bucket that the owner of projectID does not own.
+	Authorize(ctx context.Context, projectID, rawURL string) error
+}
+
+var (
+	// ErrInvalidObjectURL is returned when a stored URL does not name an object.
+	ErrInvalidObjectURL = errors.New("invalid stored URL")
+	// ErrForeignBucket is returned when a URL points into another account's bucket.
+	ErrForeignBucket = errors.New("object is in another account's bucket")
+	// ErrUnknownBucket is returned when a URL names a bucket that is neither the platform
+	// bucket nor a configured customer bucket, typically because the customer removed it.
+	ErrUnknownBucket = errors.New("object is in a bucket that is not configured")
+	// ErrUnknownRegion is returned when a user is placed in a region with no bucket.
+	ErrUnknownRegion = errors.New("storage region is not configured")
+)
+
+// UserRegions returns the data-residency region userID's new images are stored in; ""
+// means the home bucket.
+type UserRegions func(ctx context.Context, userID string) (string, error)
+
+// PlatformBuckets keeps objects in the platform's own buckets: the home bucket, plus one
+// bucket per data-residency region.
+type PlatformBuckets struct {
+	files   S3Service
+	regions map[string]S3Service
+	users   UserRegions
+}
+
+// Ensure PlatformBuckets implements Buckets.
+var _ Buckets = PlatformBuckets{}
+
+// NewPlatformBuckets returns Buckets that resolve everything to files.
+func NewPlatformBuckets(files S3Service) PlatformBuckets {
+	return PlatformBuckets{files: files}
+}
+
+// NewRegionalBuckets returns Buckets that place each user's new objects in the bucket of
+// the region users returns for them, and everyone else's in files. regions is keyed by
+// region.
+func NewRegionalBuckets(files S3Service, regions map[string]S3Service, users UserRegions) PlatformBuckets {
+	return PlatformBuckets{files: files, regions: regions, users: users}
+}
+
+// OpenRegions opens storage for each of cfg's data-residency buckets, keyed by region.
+func OpenRegions(ctx context.Context, cfg *configLib.S3) (map[string]S3Service, error) {
+	regions := make(map[string]S3Service, len(cfg.RegionBuckets))
+	for region := range cfg.RegionBuckets {
+		regionCfg, _ := cfg.ForRegion(region)
+		files, err := NewDefaultS3Service(ctx, &regionCfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s storage: %w", region, err)
+		}
+		regions[region] = files
+	}
+	return regions, nil
+}
+
+// ForUser returns the bucket of the user's region, or the home bucket when they have none.
+func (b PlatformBuckets) ForUser(ctx context.Context, userID string) (S3Service, error) {
+	if len(b.regions) == 0 || b.users == nil {
+		return b.files, nil
+	}
+	region, err := b.users(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up storage region: %w", err)
+	}
+	if region == "" {
+		return b.files, nil
+	}
+	files, ok := b.regions[region]
+	if !ok {
+		// Falling back to the home bucket would break the user's residency guarantee
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRegion, region)
+	}
+	return files, nil
+}
+
+// ForURL returns the platform bucket rawURL points into and the object's key in it. URLs
+// that name no region's bucket resolve to the home bucket.
+func (b PlatformBuckets) ForURL(_ context.Context, rawURL string) (S3Service, string, error) {
+	if bucket, key, ok := ParseObjectURL(rawURL); ok {
+		for _, files := range b.regions {
+			if files.BucketName() == bucket {
+				return files, key, nil
+			}
+		}
+	}
+	key, ok := StoredObjectKey(rawURL, b.files.BucketName())
+	if !ok {
+		return nil, "", ErrInvalidObjectURL
+	}
+	return b.files, key, nil
+}
+
+// Owns reports whether bucket is the home bucket or a region's bucket.
+func (b PlatformBuckets) Owns(bucket string) bool {
+	if bucket == b.files.BucketName() {
+		return true
+	}
+	for _, files := range b.regions {
+		if files.BucketName() == bucket {
+			return true
+		}
+	}
+	return false
+}
+
+// HasRegion reports whether region has a bucket.
+func (b PlatformBuckets) HasRegion(region string) bool {
+	_, ok := b.regions[region]
+	return ok
+}
+
+// Authorize allows every URL: the platform bucket is shared.
+func (b PlatformBuckets) Authorize(context.Context, string, string) error {
+	return nil
+}
+
+// ParseObjectURL returns the bucket and key a stored image URL points at. It understands
+// s3://bucket/key, virtual-hosted AWS URLs (https://bucket.s3.region.amazonaws.com/key)
+// and path-style URLs (http://host/bucket/key), which is what MinIO presigns.
+func ParseObjectURL(rawURL string) (bucket, key string, ok bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "", "", false
+	}
+	path := strings.TrimPrefix(u.Path, "/")
+	if u.Scheme == "s3" {
+		return u.Host, path, path != ""
+	}
+	host := u.Hostname()
+	if name, rest, found := strings.Cut(host, ".s3"); found && strings.HasSuffix(rest, ".amazonaws.com") {
+		return name, path, name != "" && path != ""
+	}
+	bucket, key, _ = strings.Cut(path, "/")
+	return bucket, key, bucket != "" && key != ""
+}
@@ -0,0 +1,142 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseObjectURL(t *testing.T) {
+	testCases := []struct {
+		name       string
+		url        string
+		wantBucket string
+		wantKey    string
+		wantOK     bool
+	}{
+		{
+			name:       "success: s3 url",
+			url:        "s3://acme-listings/staged/0a1b2c3d/x-staged.jpg",
+			wantBucket: "acme-listings",
+			wantKey:    "staged/0a1b2c3d/x-staged.jpg",
+			wantOK:     true,
+		},
+		{
+			name:       "success: virtual-hosted url with region",
+			url:        "https://acme.listings.s3.eu-west-1.amazonaws.com/uploads/u/a.jpg",
+			wantBucket: "acme.listings",
+			wantKey:    "uploads/u/a.jpg",
+			wantOK:     true,
+		},
+		{
+			name:       "success: legacy virtual-hosted url",
+			url:        "https://acme.s3.amazonaws.com/uploads/u/a.jpg",
+			wantBucket: "acme",
+			wantKey:    "uploads/u/a.jpg",
+			wantOK:     true,
+		},
+		{
+			name:       "success: path-style url",
+			url:        "http://localhost:9000/real-staging/uploads/u/a.jpg",
+			wantBucket: "real-staging",
+			wantKey:    "uploads/u/a.jpg",
+			wantOK:     true,
+		},
+		{name: "fail: bucket without key", url: "s3://acme"},
+		{name: "fail: path-style without key", url: "http://localhost:9000/real-staging"},
+		{name: "fail: relative url", url: "uploads/u/a.jpg"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bucket, key, ok := ParseObjectURL(tc.url)
+			require.Equal(t, tc.wantOK, ok)
+			if ok {
+				assert.Equal(t, tc.wantBucket, bucket)
+				assert.Equal(t, tc.wantKey, key)
+			}
+		})
+	}
+}
+
+func TestPlatformBuckets(t *testing.T) {
+	files := &S3ServiceMock{BucketNameFunc: func() string { return "real-staging" }}
+	buckets := NewPlatformBuckets(files)
+
+	got, err := buckets.ForUser(context.Background(), "user-1")
+	require.NoError(t, err)
+	assert.Same(t, files, got)
+
+	got, key, err := buckets.ForURL(context.Background(), "http://localhost:9000/real-staging/uploads/u/a.jpg")
+	require.NoError(t, err)
+	assert.Same(t, files, got)
+	assert.Equal(t, "uploads/u/a.jpg", key)
+
+	_, _, err = buckets.ForURL(context.Background(), "%zz")
+	assert.ErrorIs(t, err, ErrInvalidObjectURL)
+
+	// Path-style URLs are stripped of the configured bucket, whatever the environment says.
+	t.Setenv("S3_BUCKET_NAME", "real-staging")
+	listings := NewPlatformBuckets(&S3ServiceMock{BucketNameFunc: func() string { return "listings" }})
+	_, key, err = listings.ForURL(context.Background(), "http://localhost:9000/listings/uploads/u/a.jpg")
+	require.NoError(t, err)
+	assert.Equal(t, "uploads/u/a.jpg", key)
+}
+
+func TestRegionalBuckets(t *testing.T) {
+	home := &S3ServiceMock{BucketNameFunc: func() string { return "real-staging" }}
+	eu := &S3ServiceMock{BucketNameFunc: func() string { return "real-staging-eu" }}
+	placed := map[string]string{"user-eu": "eu-central-1", "user-gone": "ap-southeast-2"}
+	users := func(ctx context.Context, userID string) (string, error) {
+		if userID == "user-broken" {
+			return "", errors.New("db down")
+		}
+		return placed[userID], nil
+	}
+	buckets := NewRegionalBuckets(home, map[string]S3Service{"eu-central-1": eu}, users)
+
+	t.Run("ForUser", func(t *testing.T) {
+		got, err := buckets.ForUser(context.Background(), "user-eu")
+		require.NoError(t, err)
+		assert.Same(t, eu, got)
+
+		got, err = buckets.ForUser(context.Background(), "user-us")
+		require.NoError(t, err)
+		assert.Same(t, home, got)
+
+		_, err = buckets.ForUser(context.Background(), "user-gone")
+		assert.ErrorIs(t, err, ErrUnknownRegion)
+
+		_, err = buckets.ForUser(context.Background(), "user-broken")
+		assert.Error(t, err)
+	})
+
+	t.Run("ForURL", func(t *testing.T) {
+		for _, url := range []string{
+			"s3://real-staging-eu/staged/0a1b2c3d/x-staged.jpg",
+			"https://real-staging-eu.s3.eu-central-1.amazonaws.com/staged/0a1b2c3d/x-staged.jpg",
+			"http://localhost:9000/real-staging-eu/staged/0a1b2c3d/x-staged.jpg",
+		} {
+			got, key, err := buckets.ForURL(context.Background(), url)
+			require.NoError(t, err, url)
+			assert.Same(t, eu, got, url)
+			assert.Equal(t, "staged/0a1b2c3d/x-staged.jpg", key, url)
+		}
+
+		got, key, err := buckets.ForURL(context.Background(), "s3://real-staging/uploads/u/a.jpg")
+		require.NoError(t, err)
+		assert.Same(t, home, got)
+		assert.Equal(t, "uploads/u/a.jpg", key)
+	})
+
+	t.Run("Owns", func(t *testing.T) {
+		assert.True(t, buckets.Owns("real-staging"))
+		assert.True(t, buckets.Owns("real-staging-eu"))
+		assert.False(t, buckets.Owns("acme-photos"))
+		assert.True(t, buckets.HasRegion("eu-central-1"))
+		assert.False(t, buckets.HasRegion("ap-southeast-2"))
+	})
+}
@@ -15,4 +15,10 @@ type Database interface {
 	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
 	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
 	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	// CopyFrom bulk-loads rows into tableName with the COPY protocol and returns the number of rows copied.
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+	// WithTx runs fn in a transaction, committing when fn returns nil and rolling back otherwise.
+	// Statements must go through the Database passed to fn to take part in the transaction.
+	// Calling WithTx on that Database nests the work in a savepoint.
+	WithTx(ctx context.Context, fn func(tx Database) error) error
 }
@@ -23,6 +23,9 @@ var _ Database = &DatabaseMock{}
 //			CloseFunc: func()  {
 //				panic("mock out the Close method")
 //			},
+//			CopyFromFunc: func(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+//				panic("mock out the CopyFrom method")
+//			},
 //			ExecFunc: func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
 //				panic("mock out the Exec method")
 //			},
@@ -35,6 +38,9 @@ var _ Database = &DatabaseMock{}
 //			QueryRowFunc: func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
 //				panic("mock out the QueryRow method")
 //			},
+//			WithTxFunc: func(ctx context.Context, fn func(tx Database) error) error {
+//				panic("mock out the WithTx method")
+//			},
 //		}
 //
 //		// use mockedDatabase in code that requires Database
@@ -45,6 +51,9 @@ type DatabaseMock struct {
 	// CloseFunc mocks the Close method.
 	CloseFunc func()
 
+	// CopyFromFunc mocks the CopyFrom method.
+	CopyFromFunc func(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+
 	// ExecFunc mocks the Exec method.
 	ExecFunc func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
 
@@ -57,11 +66,25 @@ type DatabaseMock struct {
 	// QueryRowFunc mocks the QueryRow method.
 	QueryRowFunc func(ctx context.Context, sql string, args ...interface{}) pgx.Row
 
+	// WithTxFunc mocks the WithTx method.
+	WithTxFunc func(ctx context.Context, fn func(tx Database) error) error
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// Close holds details about calls to the Close method.
 		Close []struct {
 		}
+		// CopyFrom holds details about calls to the CopyFrom method.
+		CopyFrom []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TableName is the tableName argument value.
+			TableName pgx.Identifier
+			// ColumnNames is the columnNames argument value.
+			ColumnNames []string
+			// RowSrc is the rowSrc argument value.
+			RowSrc pgx.CopyFromSource
+		}
 		// Exec holds details about calls to the Exec method.
 		Exec []struct {
 			// Ctx is the ctx argument value.
@@ -92,12 +115,21 @@ type DatabaseMock struct {
 			// Args is the args argument value.
 			Args []interface{}
 		}
+		// WithTx holds details about calls to the WithTx method.
+		WithTx []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Fn is the fn argument value.
+			Fn func(tx Database) error
+		}
 	}
 	lockClose    sync.RWMutex
+	lockCopyFrom sync.RWMutex
 	lockExec     sync.RWMutex
 	lockPool     sync.RWMutex
 	lockQuery    sync.RWMutex
 	lockQueryRow sync.RWMutex
+	lockWithTx   sync.RWMutex
 }
 
 // Close calls CloseFunc.
@@ -127,6 +159,50 @@ func (mock *DatabaseMock) CloseCalls() []struct {
 	return calls
 }
 
+// CopyFrom calls CopyFromFunc.
+func (mock *DatabaseMock) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	if mock.CopyFromFunc == nil {
+		panic("DatabaseMock.CopyFromFunc: method is nil but Database.CopyFrom was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		TableName   pgx.Identifier
+		ColumnNames []string
+		RowSrc      pgx.CopyFromSource
+	}{
+		Ctx:         ctx,
+		TableName:   tableName,
+		ColumnNames: columnNames,
+		RowSrc:      rowSrc,
+	}
+	mock.lockCopyFrom.Lock()
+	mock.calls.CopyFrom = append(mock.calls.CopyFrom, callInfo)
+	mock.lockCopyFrom.Unlock()
+	return mock.CopyFromFunc(ctx, tableName, columnNames, rowSrc)
+}
+
+// CopyFromCalls gets all the calls that were made to CopyFrom.
+// Check the length with:
+//
+//	len(mockedDatabase.CopyFromCalls())
+func (mock *DatabaseMock) CopyFromCalls() []struct {
+	Ctx         context.Context
+	TableName   pgx.Identifier
+	ColumnNames []string
+	RowSrc      pgx.CopyFromSource
+} {
+	var calls []struct {
+		Ctx         context.Context
+		TableName   pgx.Identifier
+		ColumnNames []string
+		RowSrc      pgx.CopyFromSource
+	}
+	mock.lockCopyFrom.RLock()
+	calls = mock.calls.CopyFrom
+	mock.lockCopyFrom.RUnlock()
+	return calls
+}
+
 // Exec calls ExecFunc.
 func (mock *DatabaseMock) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
 	if mock.ExecFunc == nil {
@@ -273,3 +349,39 @@ func (mock *DatabaseMock) QueryRowCalls() []struct {
 	mock.lockQueryRow.RUnlock()
 	return calls
 }
+
+// WithTx calls WithTxFunc.
+func (mock *DatabaseMock) WithTx(ctx context.Context, fn func(tx Database) error) error {
+	if mock.WithTxFunc == nil {
+		panic("DatabaseMock.WithTxFunc: method is nil but Database.WithTx was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Fn  func(tx Database) error
+	}{
+		Ctx: ctx,
+		Fn:  fn,
+	}
+	mock.lockWithTx.Lock()
+	mock.calls.WithTx = append(mock.calls.WithTx, callInfo)
+	mock.lockWithTx.Unlock()
+	return mock.WithTxFunc(ctx, fn)
+}
+
+// WithTxCalls gets all the calls that were made to WithTx.
+// Check the length with:
+//
+//	len(mockedDatabase.WithTxCalls())
+func (mock *DatabaseMock) WithTxCalls() []struct {
+	Ctx context.Context
+	Fn  func(tx Database) error
+} {
+	var calls []struct {
+		Ctx context.Context
+		Fn  func(tx Database) error
+	}
+	mock.lockWithTx.RLock()
+	calls = mock.calls.WithTx
+	mock.lockWithTx.RUnlock()
+	return calls
+}
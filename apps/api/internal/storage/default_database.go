@@ -8,6 +8,7 @@ import (
 	"strconv"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/multitracer"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.opentelemetry.io/otel"
@@ -15,16 +16,32 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/logging"
 )
 
-// DefaultDatabase wraps the pgx connection pool with tracing
+// ApplicationName is the Postgres application_name the API's connections report, unless
+// the database URL sets one.
+const ApplicationName = "real-staging-api"
+
+// DefaultDatabase wraps the pgx connection pool with tracing and statement timeouts
 type DefaultDatabase struct {
-	pool   PgxPool
-	tracer trace.Tracer
+	pool     PgxPool
+	tracer   trace.Tracer
+	timeouts Timeouts
 }
 
 // NewDefaultDatabase creates a new database connection with OpenTelemetry instrumentation.
 func NewDefaultDatabase(cfg *config.DB) (*DefaultDatabase, error) {
+	return newDefaultDatabase(cfg, "")
+}
+
+// NewSandboxDatabase creates a database connection like NewDefaultDatabase whose statements
+// act on the sandbox schema. Functions and types still resolve to public.
+func NewSandboxDatabase(cfg *config.DB) (*DefaultDatabase, error) {
+	return newDefaultDatabase(cfg, SandboxSchema+", public")
+}
+
+func newDefaultDatabase(cfg *config.DB, searchPath string) (*DefaultDatabase, error) {
 	if cfg == nil {
 		return nil, fmt.Errorf("database config is required")
 	}
@@ -41,8 +58,31 @@ func NewDefaultDatabase(cfg *config.DB) (*DefaultDatabase, error) {
 			cfg.User, cfg.Password, hostPort, cfg.Database, cfg.SSLMode)
 	}
 
+	poolConfig, err := pgxpool.ParseConfig(dbURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database URL: %w", err)
+	}
+	// Identifies the API's changes in image_status_transitions, unless the URL names the application.
+	if _, ok := poolConfig.ConnConfig.RuntimeParams["application_name"]; !ok {
+		poolConfig.ConnConfig.RuntimeParams["application_name"] = ApplicationName
+	}
+	if searchPath != "" {
+		poolConfig.ConnConfig.RuntimeParams["search_path"] = searchPath
+	}
+	timeouts := Timeouts{Fast: cfg.StatementTimeout, Scan: cfg.ScanStatementTimeout}
+	if backstop := timeouts.longest(); backstop > 0 {
+		// Statements are canceled through their contexts; the server-side timeout catches
+		// any that run without a deadline.
+		poolConfig.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(backstop.Milliseconds(), 10)
+	}
+	tracers := []pgx.QueryTracer{QueryCounter{}}
+	if cfg.SlowQueryThreshold > 0 {
+		tracers = append(tracers, NewSlowQueryTracer(cfg.SlowQueryThreshold, logging.Default()))
+	}
+	poolConfig.ConnConfig.Tracer = multitracer.New(tracers...)
+
 	// Create connection pool
-	pool, err := pgxpool.New(ctx, dbURL)
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create connection pool: %w", err)
 	}
@@ -53,8 +93,9 @@ func NewDefaultDatabase(cfg *config.DB) (*DefaultDatabase, error) {
 	}
 
 	return &DefaultDatabase{
-		pool:   pool,
-		tracer: otel.Tracer("real-staging-api/database"),
+		pool:     pool,
+		tracer:   otel.Tracer("real-staging-api/database"),
+		timeouts: timeouts,
 	}, nil
 }
 
@@ -82,7 +123,8 @@ func (db *DefaultDatabase) QueryRow(ctx context.Context, sql string, arguments .
 		attribute.Int("db.args.count", len(arguments)),
 	)
 
-	return db.pool.QueryRow(ctx, sql, arguments...)
+	ctx, cancel := db.timeouts.statementContext(ctx)
+	return timeoutRow{Row: db.pool.QueryRow(ctx, sql, arguments...), cancel: cancel}
 }
 
 // Query executes a query with tracing
@@ -99,12 +141,15 @@ func (db *DefaultDatabase) Query(ctx context.Context, sql string, arguments ...a
 		attribute.Int("db.args.count", len(arguments)),
 	)
 
+	ctx, cancel := db.timeouts.statementContext(ctx)
 	rows, err := db.pool.Query(ctx, sql, arguments...)
 	if err != nil {
+		cancel()
 		span.RecordError(err)
+		return rows, err
 	}
 
-	return rows, err
+	return timeoutRows{Rows: rows, cancel: cancel}, nil
 }
 
 // Exec executes a command with tracing
@@ -121,6 +166,9 @@ func (db *DefaultDatabase) Exec(ctx context.Context, sql string, arguments ...an
 		attribute.Int("db.args.count", len(arguments)),
 	)
 
+	ctx, cancel := db.timeouts.statementContext(ctx)
+	defer cancel()
+
 	tag, err := db.pool.Exec(ctx, sql, arguments...)
 	if err != nil {
 		span.RecordError(err)
@@ -129,6 +177,56 @@ func (db *DefaultDatabase) Exec(ctx context.Context, sql string, arguments ...an
 	return tag, err
 }
 
+// CopyFrom bulk-loads rows with the COPY protocol with tracing
+func (db *DefaultDatabase) CopyFrom(
+	ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource,
+) (int64, error) {
+	tr := db.tracer
+	if tr == nil {
+		tr = otel.Tracer("real-staging-api/database")
+	}
+	ctx, span := tr.Start(ctx, "db.copy_from")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.table", tableName.Sanitize()),
+		attribute.StringSlice("db.columns", columnNames),
+	)
+
+	ctx, cancel := db.timeouts.statementContext(ctx)
+	defer cancel()
+
+	n, err := db.pool.CopyFrom(ctx, tableName, columnNames, rowSrc)
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.SetAttributes(attribute.Int64("db.rows_affected", n))
+
+	return n, err
+}
+
+// WithTx runs fn in a transaction with tracing
+func (db *DefaultDatabase) WithTx(ctx context.Context, fn func(tx Database) error) error {
+	tr := db.tracer
+	if tr == nil {
+		tr = otel.Tracer("real-staging-api/database")
+	}
+	ctx, span := tr.Start(ctx, "db.tx")
+	defer span.End()
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	err = runTx(ctx, tx, &txDatabase{tx: tx, pool: db.pool, tracer: tr, timeouts: db.timeouts}, fn)
+	if err != nil {
+		span.RecordError(err)
+	}
+	return err
+}
+
 func getEnvOrDefault(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -479,3 +481,131 @@ func BenchmarkDefaultDatabase_Exec(b *testing.B) {
 		}
 	}
 }
+
+func TestDefaultDatabase_WithTx(t *testing.T) {
+	errFn := errors.New("fn failed")
+
+	tests := []struct {
+		name      string
+		setupMock func(mock pgxmock.PgxPoolIface)
+		fn        func(tx Database) error
+		wantErr   error
+	}{
+		{
+			name: "success: commits when fn succeeds",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM projects").WithArgs("p1").WillReturnResult(pgxmock.NewResult("DELETE", 1))
+				mock.ExpectCommit()
+			},
+			fn: func(tx Database) error {
+				_, err := tx.Exec(context.Background(), "DELETE FROM projects WHERE id = $1", "p1")
+				return err
+			},
+		},
+		{
+			name: "fail: rolls back when fn fails",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			fn:      func(tx Database) error { return errFn },
+			wantErr: errFn,
+		},
+		{
+			name: "fail: begin error",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectBegin().WillReturnError(errors.New("no connection"))
+			},
+			fn: func(tx Database) error {
+				t.Fatal("fn must not run when begin fails")
+				return nil
+			},
+			wantErr: errors.New("failed to begin transaction: no connection"),
+		},
+		{
+			name: "fail: commit error",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectBegin()
+				mock.ExpectCommit().WillReturnError(errors.New("serialization failure"))
+			},
+			fn:      func(tx Database) error { return nil },
+			wantErr: errors.New("failed to commit transaction: serialization failure"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+			tt.setupMock(mock)
+
+			db := &DefaultDatabase{pool: mock}
+			err = db.WithTx(context.Background(), tt.fn)
+
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDefaultDatabase_WithTx_RollsBackOnPanic(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	db := &DefaultDatabase{pool: mock}
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = db.WithTx(context.Background(), func(tx Database) error { panic("boom") })
+	})
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDefaultDatabase_WithTx_Nested(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	mock.ExpectBegin()
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	mock.ExpectCommit()
+
+	db := &DefaultDatabase{pool: mock}
+	err = db.WithTx(context.Background(), func(tx Database) error {
+		inner := tx.WithTx(context.Background(), func(Database) error { return errors.New("inner failed") })
+		assert.EqualError(t, inner, "inner failed")
+		// The outer transaction survives a failed savepoint.
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDefaultDatabase_StatementTimeouts(t *testing.T) {
+	var deadlines []time.Duration
+	mockPool := &PgxPoolMock{
+		ExecFunc: func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok, "statement context has no deadline")
+			deadlines = append(deadlines, time.Until(deadline))
+			return pgconn.NewCommandTag("UPDATE 1"), nil
+		},
+	}
+	db := &DefaultDatabase{pool: mockPool, timeouts: Timeouts{Fast: time.Second, Scan: time.Hour}}
+
+	_, err := db.Exec(context.Background(), "UPDATE users SET active = true")
+	require.NoError(t, err)
+	_, err = db.Exec(WithQueryClass(context.Background(), QueryClassScan), "UPDATE users SET active = true")
+	require.NoError(t, err)
+
+	require.Len(t, deadlines, 2)
+	assert.LessOrEqual(t, deadlines[0], time.Second)
+	assert.Greater(t, deadlines[1], time.Minute)
+}
@@ -1,9 +1,13 @@
 package storage
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"slices"
@@ -17,7 +21,9 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/google/uuid"
 
+	"github.com/real-staging-ai/api/internal/chaos"
 	configLib "github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/telemetry"
 )
 
 // PresignedUploadResult contains the result of generating a presigned upload URL.
@@ -31,29 +37,7 @@ type PresignedUploadResult struct {
 func (s *DefaultS3Service) GeneratePresignedGetURL(
 	ctx context.Context, fileKey string, expiresInSeconds int64, contentDisposition string,
 ) (string, error) {
-	// Choose a client for presigning that uses the public endpoint if set.
-	presignBase := s.client
-
-	// Use stored config for presign operations
-	if s.Cfg != nil && s.Cfg.PublicEndpoint != "" {
-		publicEndpoint := s.Cfg.PublicEndpoint
-		region := s.Cfg.Region
-		accessKey := s.Cfg.AccessKey
-		secretKey := s.Cfg.SecretKey
-		usePathStyle := s.Cfg.UsePathStyle
-		presignCfg, cfgErr := awsConfigLoader(ctx,
-			config.WithRegion(region),
-			config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(accessKey, secretKey, "")),
-		)
-		if cfgErr == nil {
-			presignBase = s3.NewFromConfig(presignCfg, func(o *s3.Options) {
-				o.BaseEndpoint = aws.String(publicEndpoint)
-				o.UsePathStyle = usePathStyle
-			})
-		}
-	}
-
-	presignClient := s3.NewPresignClient(presignBase)
+	presignClient := s.presignClient(ctx)
 	exp := time.Duration(expiresInSeconds) * time.Second
 	if exp <= 0 {
 		exp = 10 * time.Minute
@@ -83,6 +67,9 @@ type DefaultS3Service struct {
 	Cfg    *configLib.S3 // Store config for presign operations
 }
 
+// ErrObjectNotFound is returned (wrapped) when an object does not exist.
+var ErrObjectNotFound = errors.New("object not found")
+
 // Ensure DefaultS3Service implements S3Service interface.
 var _ S3Service = (*DefaultS3Service)(nil)
 
@@ -92,9 +79,26 @@ var awsConfigLoader = config.LoadDefaultConfig
 // NewDefaultS3Service creates a new DefaultS3Service instance.
 // s3Cfg must not be nil.
 func NewDefaultS3Service(ctx context.Context, s3Cfg *configLib.S3) (*DefaultS3Service, error) {
+	inj, err := chaos.FromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return NewDefaultS3ServiceWithFaults(ctx, s3Cfg, inj)
+}
+
+// NewDefaultS3ServiceWithFaults is NewDefaultS3Service with S3 requests routed through inj.
+// A nil injector leaves requests untouched.
+func NewDefaultS3ServiceWithFaults(
+	ctx context.Context, s3Cfg *configLib.S3, inj *chaos.Injector,
+) (*DefaultS3Service, error) {
 	if s3Cfg == nil {
 		return nil, fmt.Errorf("S3 config is required")
 	}
+	withFaults := func(o *s3.Options) {
+		if inj != nil {
+			o.HTTPClient = &http.Client{Transport: inj.Transport(chaos.PointS3, nil)}
+		}
+	}
 
 	var cfg aws.Config
 	var err error
@@ -118,7 +122,7 @@ func NewDefaultS3Service(ctx context.Context, s3Cfg *configLib.S3) (*DefaultS3Se
 		client := s3.NewFromConfig(cfg, func(o *s3.Options) {
 			o.BaseEndpoint = aws.String("http://localhost:4566")
 			o.UsePathStyle = true
-		})
+		}, withFaults, telemetry.CountS3Operations)
 
 		return &DefaultS3Service{
 			client: client,
@@ -140,7 +144,7 @@ func NewDefaultS3Service(ctx context.Context, s3Cfg *configLib.S3) (*DefaultS3Se
 		client := s3.NewFromConfig(cfg, func(o *s3.Options) {
 			o.BaseEndpoint = aws.String(endpoint)
 			o.UsePathStyle = usePathStyle
-		})
+		}, withFaults, telemetry.CountS3Operations)
 
 		return &DefaultS3Service{
 			client: client,
@@ -154,7 +158,7 @@ func NewDefaultS3Service(ctx context.Context, s3Cfg *configLib.S3) (*DefaultS3Se
 		return nil, fmt.Errorf("failed to load AWS config: %w", err)
 	}
 
-	client := s3.NewFromConfig(cfg)
+	client := s3.NewFromConfig(cfg, withFaults, telemetry.CountS3Operations)
 
 	return &DefaultS3Service{
 		client: client,
@@ -162,41 +166,19 @@ func NewDefaultS3Service(ctx context.Context, s3Cfg *configLib.S3) (*DefaultS3Se
 	}, nil
 }
 
+// NewDefaultS3ServiceWithClient wraps an already configured client, such as one holding
+// credentials for a customer's bucket. Without s3Cfg.PublicEndpoint, URLs are presigned
+// with client itself.
+func NewDefaultS3ServiceWithClient(client *s3.Client, s3Cfg *configLib.S3) *DefaultS3Service {
+	return &DefaultS3Service{client: client, Cfg: s3Cfg}
+}
+
 // GeneratePresignedUploadURL generates a presigned URL for uploading a file to S3.
 func (s *DefaultS3Service) GeneratePresignedUploadURL(
 	ctx context.Context, userID, filename, contentType string, fileSize int64,
 ) (*PresignedUploadResult, error) {
-	// Generate a unique file key
-	fileExt := filepath.Ext(filename)
-	baseName := strings.TrimSuffix(filename, fileExt)
-	uniqueID := uuid.New().String()
-	fileKey := fmt.Sprintf("uploads/%s/%s-%s%s", userID, baseName, uniqueID, fileExt)
-
-	// Choose a client for presigning. If public endpoint is set, use a client
-	// with that base endpoint so the URL host is browser-accessible. Provide
-	// static credentials to avoid IMDS.
-	presignBase := s.client
-
-	// Use stored config for presign operations
-	if s.Cfg != nil && s.Cfg.PublicEndpoint != "" {
-		publicEndpoint := s.Cfg.PublicEndpoint
-		region := s.Cfg.Region
-		accessKey := s.Cfg.AccessKey
-		secretKey := s.Cfg.SecretKey
-		usePathStyle := s.Cfg.UsePathStyle
-		presignCfg, cfgErr := awsConfigLoader(ctx,
-			config.WithRegion(region),
-			config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(accessKey, secretKey, "")),
-		)
-		if cfgErr == nil {
-			presignBase = s3.NewFromConfig(presignCfg, func(o *s3.Options) {
-				o.BaseEndpoint = aws.String(publicEndpoint)
-				o.UsePathStyle = usePathStyle
-			})
-		}
-	}
-	// Create the presign client
-	presignClient := s3.NewPresignClient(presignBase)
+	fileKey := uploadKey(ctx, userID, filename)
+	presignClient := s.presignClient(ctx)
 
 	// Set the expiration time (15 minutes)
 	expirationDuration := 15 * time.Minute
@@ -220,11 +202,45 @@ func (s *DefaultS3Service) GeneratePresignedUploadURL(
 	}, nil
 }
 
+// uploadKey returns a new, unique key for a file userID uploads.
+func uploadKey(ctx context.Context, userID, filename string) string {
+	fileExt := filepath.Ext(filename)
+	baseName := strings.TrimSuffix(filename, fileExt)
+	return fmt.Sprintf("%s%s-%s%s", UploadPrefix(ctx, userID), baseName, uuid.New().String(), fileExt)
+}
+
+// presignClient returns a client for presigning URLs. If a public endpoint is set, it
+// uses a client with that base endpoint so the URL host is browser-accessible, with
+// static credentials to avoid IMDS.
+func (s *DefaultS3Service) presignClient(ctx context.Context) *s3.PresignClient {
+	presignBase := s.client
+	if s.Cfg != nil && s.Cfg.PublicEndpoint != "" {
+		publicEndpoint := s.Cfg.PublicEndpoint
+		usePathStyle := s.Cfg.UsePathStyle
+		presignCfg, cfgErr := awsConfigLoader(ctx,
+			config.WithRegion(s.Cfg.Region),
+			config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(s.Cfg.AccessKey, s.Cfg.SecretKey, "")),
+		)
+		if cfgErr == nil {
+			presignBase = s3.NewFromConfig(presignCfg, func(o *s3.Options) {
+				o.BaseEndpoint = aws.String(publicEndpoint)
+				o.UsePathStyle = usePathStyle
+			})
+		}
+	}
+	return s3.NewPresignClient(presignBase)
+}
+
 // GetFileURL returns the public URL for a file in S3.
 func (s *DefaultS3Service) GetFileURL(fileKey string) string {
 	return fmt.Sprintf("https://%s.s3.amazonaws.com/%s", s.Cfg.BucketName, fileKey)
 }
 
+// BucketName returns the name of the bucket the service reads and writes.
+func (s *DefaultS3Service) BucketName() string {
+	return s.Cfg.BucketName
+}
+
 // DeleteFile deletes a file from S3.
 func (s *DefaultS3Service) DeleteFile(ctx context.Context, fileKey string) error {
 	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
@@ -238,13 +254,59 @@ func (s *DefaultS3Service) DeleteFile(ctx context.Context, fileKey string) error
 	return nil
 }
 
+// GetFile reads the full contents of a file from S3.
+// A missing object yields an error wrapping ErrObjectNotFound.
+func (s *DefaultS3Service) GetFile(ctx context.Context, fileKey string) ([]byte, error) {
+	result, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.Cfg.BucketName),
+		Key:    aws.String(fileKey),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, fmt.Errorf("failed to get file: %w: %w", ErrObjectNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+	defer func() { _ = result.Body.Close() }()
+
+	body, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	return body, nil
+}
+
+// PutFile writes body to fileKey, replacing any existing file.
+func (s *DefaultS3Service) PutFile(ctx context.Context, fileKey string, body []byte, contentType string) error {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(s.Cfg.BucketName),
+		Key:           aws.String(fileKey),
+		Body:          bytes.NewReader(body),
+		ContentLength: aws.Int64(int64(len(body))),
+		ContentType:   aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put file: %w", err)
+	}
+
+	return nil
+}
+
 // HeadFile checks if a file exists in S3 and returns its metadata.
+// A missing object yields an error wrapping ErrObjectNotFound; any other error means
+// existence could not be determined.
 func (s *DefaultS3Service) HeadFile(ctx context.Context, fileKey string) (interface{}, error) {
 	result, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
 		Bucket: aws.String(s.Cfg.BucketName),
 		Key:    aws.String(fileKey),
 	})
 	if err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return nil, fmt.Errorf("failed to get file metadata: %w: %w", ErrObjectNotFound, err)
+		}
 		return nil, fmt.Errorf("failed to get file metadata: %w", err)
 	}
 
@@ -281,6 +343,19 @@ func ValidateFilename(filename string) bool {
 	return slices.Contains(allowedExts, ext)
 }
 
+// StoredObjectKey derives the S3 object key from a stored image URL in bucket. Path-style
+// URLs are prefixed with the bucket; virtual-hosted and s3:// URLs are not.
+func StoredObjectKey(rawURL, bucket string) (string, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Path == "" {
+		return "", false
+	}
+
+	p := strings.TrimPrefix(u.Path, "/")
+	p = strings.TrimPrefix(p, bucket+"/")
+	return p, p != ""
+}
+
 // CreateBucket creates the S3 bucket if it doesn't exist.
 func (s *DefaultS3Service) CreateBucket(ctx context.Context) error {
 	_, err := s.client.CreateBucket(ctx, &s3.CreateBucketInput{
@@ -3,6 +3,8 @@ package storage
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -15,6 +17,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/real-staging-ai/api/internal/chaos"
 	configLib "github.com/real-staging-ai/api/internal/config"
 )
 
@@ -447,6 +450,21 @@ func TestDefaultS3Service_DeleteFile(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestDefaultS3Service_GetFile(t *testing.T) {
+	t.Setenv("APP_ENV", "test")
+	ctx := context.Background()
+
+	svc, err := NewDefaultS3Service(ctx, &configLib.S3{BucketName: "unit-bucket"})
+	require.NoError(t, err)
+	require.NotNil(t, svc)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	_, err = svc.GetFile(canceled, "staged/missing.jpg")
+	assert.Error(t, err)
+}
+
 func TestDefaultS3Service_HeadFile(t *testing.T) {
 	t.Setenv("APP_ENV", "test")
 	ctx := context.Background()
@@ -462,6 +480,36 @@ func TestDefaultS3Service_HeadFile(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestDefaultS3Service_HeadFile_Errors(t *testing.T) {
+	t.Setenv("APP_ENV", "dev")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	inj := chaos.New(map[chaos.Point]chaos.Fault{chaos.PointS3: {ErrorRate: 1}})
+	svc, err := NewDefaultS3ServiceWithFaults(context.Background(), &configLib.S3{
+		BucketName:   "unit-bucket",
+		Region:       "us-east-1",
+		Endpoint:     srv.URL,
+		AccessKey:    "test",
+		SecretKey:    "test",
+		UsePathStyle: true,
+	}, inj)
+	require.NoError(t, err)
+
+	// An unreachable store is not evidence that the object is gone.
+	_, err = svc.HeadFile(context.Background(), "uploads/user/photo.jpg")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, chaos.ErrInjected)
+	assert.NotErrorIs(t, err, ErrObjectNotFound)
+	assert.Positive(t, inj.Fired(chaos.PointS3))
+
+	inj.Clear(chaos.PointS3)
+	_, err = svc.HeadFile(context.Background(), "uploads/user/photo.jpg")
+	assert.ErrorIs(t, err, ErrObjectNotFound)
+}
+
 func TestDefaultS3Service_CreateBucket(t *testing.T) {
 	t.Setenv("APP_ENV", "test")
 	ctx := context.Background()
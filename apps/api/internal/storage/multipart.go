@@ -0,0 +1,148 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+const (
+	// MaxRawFileSize is the largest RAW file that can be uploaded.
+	MaxRawFileSize = 200 * 1024 * 1024 // 200MB
+	// MultipartPartSize is the size of every part of a multipart upload but the last.
+	MultipartPartSize = 16 * 1024 * 1024 // 16MiB
+	// multipartExpiry is how long a multipart upload's part URLs stay valid.
+	multipartExpiry = time.Hour
+)
+
+// rawContentTypes are the RAW camera formats accepted through multipart uploads, by file
+// extension. The worker converts them to JPEG before staging.
+var rawContentTypes = map[string]string{
+	".cr3": "image/x-canon-cr3",
+	".nef": "image/x-nikon-nef",
+	".arw": "image/x-sony-arw",
+}
+
+// MultipartUploadResult contains a started multipart upload and a presigned URL for each
+// of its parts.
+type MultipartUploadResult struct {
+	UploadID  string          `json:"upload_id"`
+	FileKey   string          `json:"file_key"`
+	PartSize  int64           `json:"part_size"`
+	Parts     []PresignedPart `json:"parts"`
+	ExpiresIn int64           `json:"expires_in"`
+}
+
+// PresignedPart is where one part of a multipart upload is PUT.
+type PresignedPart struct {
+	PartNumber int32  `json:"part_number"`
+	UploadURL  string `json:"upload_url"`
+}
+
+// CompletedPart is an uploaded part of a multipart upload, with the ETag S3 returned for it.
+type CompletedPart struct {
+	PartNumber int32  `json:"part_number"`
+	ETag       string `json:"etag"`
+}
+
+// RawContentType returns the content type of a RAW camera file by its extension, or ""
+// when filename is not one.
+func RawContentType(filename string) string {
+	return rawContentTypes[strings.ToLower(filepath.Ext(filename))]
+}
+
+// ValidateRawFileSize checks if a RAW file's size is within allowed limits.
+func ValidateRawFileSize(size int64) bool {
+	return size > 0 && size <= MaxRawFileSize
+}
+
+// MultipartPartCount returns how many parts an upload of fileSize bytes is split into.
+func MultipartPartCount(fileSize int64) int32 {
+	return int32((fileSize + MultipartPartSize - 1) / MultipartPartSize)
+}
+
+// CreateMultipartUpload starts a multipart upload of a file userID uploads and presigns a
+// PUT URL for each of its parts.
+func (s *DefaultS3Service) CreateMultipartUpload(
+	ctx context.Context, userID, filename, contentType string, fileSize int64,
+) (*MultipartUploadResult, error) {
+	fileKey := uploadKey(ctx, userID, filename)
+	out, err := s.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:      aws.String(s.Cfg.BucketName),
+		Key:         aws.String(fileKey),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start multipart upload: %w", err)
+	}
+
+	presignClient := s.presignClient(ctx)
+	parts := make([]PresignedPart, MultipartPartCount(fileSize))
+	for i := range parts {
+		partNumber := int32(i + 1)
+		req, err := presignClient.PresignUploadPart(ctx, &s3.UploadPartInput{
+			Bucket:     aws.String(s.Cfg.BucketName),
+			Key:        aws.String(fileKey),
+			UploadId:   out.UploadId,
+			PartNumber: aws.Int32(partNumber),
+		}, func(opts *s3.PresignOptions) {
+			opts.Expires = multipartExpiry
+		})
+		if err != nil {
+			_ = s.AbortMultipartUpload(ctx, fileKey, aws.ToString(out.UploadId))
+			return nil, fmt.Errorf("failed to presign part %d: %w", partNumber, err)
+		}
+		parts[i] = PresignedPart{PartNumber: partNumber, UploadURL: req.URL}
+	}
+
+	return &MultipartUploadResult{
+		UploadID:  aws.ToString(out.UploadId),
+		FileKey:   fileKey,
+		PartSize:  MultipartPartSize,
+		Parts:     parts,
+		ExpiresIn: int64(multipartExpiry.Seconds()),
+	}, nil
+}
+
+// CompleteMultipartUpload assembles the uploaded parts into the file at fileKey.
+func (s *DefaultS3Service) CompleteMultipartUpload(
+	ctx context.Context, fileKey, uploadID string, parts []CompletedPart,
+) error {
+	sorted := slices.SortedFunc(slices.Values(parts), func(a, b CompletedPart) int {
+		return int(a.PartNumber - b.PartNumber)
+	})
+	completed := make([]types.CompletedPart, len(sorted))
+	for i, p := range sorted {
+		completed[i] = types.CompletedPart{PartNumber: aws.Int32(p.PartNumber), ETag: aws.String(p.ETag)}
+	}
+	_, err := s.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(s.Cfg.BucketName),
+		Key:             aws.String(fileKey),
+		UploadId:        aws.String(uploadID),
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: completed},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+	return nil
+}
+
+// AbortMultipartUpload discards a multipart upload and the parts uploaded so far.
+func (s *DefaultS3Service) AbortMultipartUpload(ctx context.Context, fileKey, uploadID string) error {
+	_, err := s.client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(s.Cfg.BucketName),
+		Key:      aws.String(fileKey),
+		UploadId: aws.String(uploadID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to abort multipart upload: %w", err)
+	}
+	return nil
+}
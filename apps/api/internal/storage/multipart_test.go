@@ -0,0 +1,167 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configLib "github.com/real-staging-ai/api/internal/config"
+)
+
+func TestRawContentType(t *testing.T) {
+	tests := []struct {
+		filename string
+		want     string
+	}{
+		{"IMG_0001.CR3", "image/x-canon-cr3"},
+		{"DSC_0001.nef", "image/x-nikon-nef"},
+		{"DSC00001.ARW", "image/x-sony-arw"},
+		{"photo.jpg", ""},
+		{"cr3", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			assert.Equal(t, tt.want, RawContentType(tt.filename))
+		})
+	}
+}
+
+func TestMultipartPartCount(t *testing.T) {
+	assert.Equal(t, int32(1), MultipartPartCount(1))
+	assert.Equal(t, int32(1), MultipartPartCount(MultipartPartSize))
+	assert.Equal(t, int32(2), MultipartPartCount(MultipartPartSize+1))
+	assert.Equal(t, int32(13), MultipartPartCount(MaxRawFileSize))
+}
+
+// multipartServer fakes the S3 multipart API and records the requests it receives.
+func multipartServer(t *testing.T, status int) (*DefaultS3Service, func() []*http.Request, func() []string) {
+	t.Helper()
+	t.Setenv("APP_ENV", "dev")
+	var (
+		mu       sync.Mutex
+		requests []*http.Request
+		bodies   []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, r)
+		bodies = append(bodies, string(body))
+		mu.Unlock()
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Query().Has("uploads"):
+			_, _ = io.WriteString(w, `<InitiateMultipartUploadResult><UploadId>up-1</UploadId></InitiateMultipartUploadResult>`)
+		case r.Method == http.MethodPost:
+			_, _ = io.WriteString(w, `<CompleteMultipartUploadResult><ETag>"e"</ETag></CompleteMultipartUploadResult>`)
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	svc, err := NewDefaultS3ServiceWithFaults(context.Background(), &configLib.S3{
+		BucketName:   "unit-bucket",
+		Region:       "us-east-1",
+		Endpoint:     srv.URL,
+		AccessKey:    "test",
+		SecretKey:    "test",
+		UsePathStyle: true,
+	}, nil)
+	require.NoError(t, err)
+	return svc, func() []*http.Request {
+			mu.Lock()
+			defer mu.Unlock()
+			return requests
+		}, func() []string {
+			mu.Lock()
+			defer mu.Unlock()
+			return bodies
+		}
+}
+
+func TestDefaultS3Service_CreateMultipartUpload(t *testing.T) {
+	t.Run("success: presigns a URL per part", func(t *testing.T) {
+		svc, requests, _ := multipartServer(t, http.StatusOK)
+
+		res, err := svc.CreateMultipartUpload(context.Background(), "user-1", "IMG_0001.CR3",
+			"image/x-canon-cr3", 2*MultipartPartSize+1)
+		require.NoError(t, err)
+		assert.Equal(t, "up-1", res.UploadID)
+		assert.Regexp(t, `^uploads/user-1/IMG_0001-[0-9a-f-]{36}\.CR3$`, res.FileKey)
+		assert.Equal(t, int64(MultipartPartSize), res.PartSize)
+		assert.Equal(t, int64(3600), res.ExpiresIn)
+		require.Len(t, res.Parts, 3)
+		for i, p := range res.Parts {
+			assert.Equal(t, int32(i+1), p.PartNumber)
+			u, err := url.Parse(p.UploadURL)
+			require.NoError(t, err)
+			assert.Equal(t, "/unit-bucket/"+res.FileKey, u.Path)
+			assert.Equal(t, "up-1", u.Query().Get("uploadId"))
+			assert.Equal(t, strconv.Itoa(i+1), u.Query().Get("partNumber"))
+		}
+
+		reqs := requests()
+		require.Len(t, reqs, 1, "parts are presigned, not sent")
+		assert.Equal(t, "image/x-canon-cr3", reqs[0].Header.Get("Content-Type"))
+	})
+
+	t.Run("fail: S3 rejects the upload", func(t *testing.T) {
+		svc, _, _ := multipartServer(t, http.StatusForbidden)
+		_, err := svc.CreateMultipartUpload(context.Background(), "user-1", "IMG_0001.CR3", "image/x-canon-cr3", 10)
+		assert.ErrorContains(t, err, "failed to start multipart upload")
+	})
+}
+
+func TestDefaultS3Service_CompleteMultipartUpload(t *testing.T) {
+	t.Run("success: parts are sent in order", func(t *testing.T) {
+		svc, requests, bodies := multipartServer(t, http.StatusOK)
+
+		err := svc.CompleteMultipartUpload(context.Background(), "uploads/u/a.CR3", "up-1", []CompletedPart{
+			{PartNumber: 2, ETag: `"b"`},
+			{PartNumber: 1, ETag: `"a"`},
+		})
+		require.NoError(t, err)
+		reqs := requests()
+		require.Len(t, reqs, 1)
+		assert.Equal(t, "up-1", reqs[0].URL.Query().Get("uploadId"))
+		body := bodies()[0]
+		assert.Less(t, strings.Index(body, "<PartNumber>1</PartNumber>"), strings.Index(body, "<PartNumber>2</PartNumber>"))
+	})
+
+	t.Run("fail: S3 rejects the parts", func(t *testing.T) {
+		svc, _, _ := multipartServer(t, http.StatusBadRequest)
+		err := svc.CompleteMultipartUpload(context.Background(), "uploads/u/a.CR3", "up-1",
+			[]CompletedPart{{PartNumber: 1, ETag: `"a"`}})
+		assert.ErrorContains(t, err, "failed to complete multipart upload")
+	})
+}
+
+func TestDefaultS3Service_AbortMultipartUpload(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc, requests, _ := multipartServer(t, http.StatusOK)
+		require.NoError(t, svc.AbortMultipartUpload(context.Background(), "uploads/u/a.CR3", "up-1"))
+		reqs := requests()
+		require.Len(t, reqs, 1)
+		assert.Equal(t, http.MethodDelete, reqs[0].Method)
+		assert.Equal(t, "up-1", reqs[0].URL.Query().Get("uploadId"))
+	})
+
+	t.Run("fail: S3 error", func(t *testing.T) {
+		svc, _, _ := multipartServer(t, http.StatusForbidden)
+		err := svc.AbortMultipartUpload(context.Background(), "uploads/u/a.CR3", "up-1")
+		assert.ErrorContains(t, err, "failed to abort multipart upload")
+	})
+}
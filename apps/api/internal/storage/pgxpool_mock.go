@@ -20,9 +20,15 @@ var _ PgxPool = &PgxPoolMock{}
 //
 //		// make and configure a mocked PgxPool
 //		mockedPgxPool := &PgxPoolMock{
+//			BeginFunc: func(ctx context.Context) (pgx.Tx, error) {
+//				panic("mock out the Begin method")
+//			},
 //			CloseFunc: func()  {
 //				panic("mock out the Close method")
 //			},
+//			CopyFromFunc: func(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+//				panic("mock out the CopyFrom method")
+//			},
 //			ExecFunc: func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
 //				panic("mock out the Exec method")
 //			},
@@ -42,9 +48,15 @@ var _ PgxPool = &PgxPoolMock{}
 //
 //	}
 type PgxPoolMock struct {
+	// BeginFunc mocks the Begin method.
+	BeginFunc func(ctx context.Context) (pgx.Tx, error)
+
 	// CloseFunc mocks the Close method.
 	CloseFunc func()
 
+	// CopyFromFunc mocks the CopyFrom method.
+	CopyFromFunc func(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+
 	// ExecFunc mocks the Exec method.
 	ExecFunc func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
 
@@ -59,9 +71,25 @@ type PgxPoolMock struct {
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// Begin holds details about calls to the Begin method.
+		Begin []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// Close holds details about calls to the Close method.
 		Close []struct {
 		}
+		// CopyFrom holds details about calls to the CopyFrom method.
+		CopyFrom []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TableName is the tableName argument value.
+			TableName pgx.Identifier
+			// ColumnNames is the columnNames argument value.
+			ColumnNames []string
+			// RowSrc is the rowSrc argument value.
+			RowSrc pgx.CopyFromSource
+		}
 		// Exec holds details about calls to the Exec method.
 		Exec []struct {
 			// Ctx is the ctx argument value.
@@ -95,13 +123,47 @@ type PgxPoolMock struct {
 			Args []interface{}
 		}
 	}
+	lockBegin    sync.RWMutex
 	lockClose    sync.RWMutex
+	lockCopyFrom sync.RWMutex
 	lockExec     sync.RWMutex
 	lockPing     sync.RWMutex
 	lockQuery    sync.RWMutex
 	lockQueryRow sync.RWMutex
 }
 
+// Begin calls BeginFunc.
+func (mock *PgxPoolMock) Begin(ctx context.Context) (pgx.Tx, error) {
+	if mock.BeginFunc == nil {
+		panic("PgxPoolMock.BeginFunc: method is nil but PgxPool.Begin was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockBegin.Lock()
+	mock.calls.Begin = append(mock.calls.Begin, callInfo)
+	mock.lockBegin.Unlock()
+	return mock.BeginFunc(ctx)
+}
+
+// BeginCalls gets all the calls that were made to Begin.
+// Check the length with:
+//
+//	len(mockedPgxPool.BeginCalls())
+func (mock *PgxPoolMock) BeginCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockBegin.RLock()
+	calls = mock.calls.Begin
+	mock.lockBegin.RUnlock()
+	return calls
+}
+
 // Close calls CloseFunc.
 func (mock *PgxPoolMock) Close() {
 	if mock.CloseFunc == nil {
@@ -129,6 +191,50 @@ func (mock *PgxPoolMock) CloseCalls() []struct {
 	return calls
 }
 
+// CopyFrom calls CopyFromFunc.
+func (mock *PgxPoolMock) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	if mock.CopyFromFunc == nil {
+		panic("PgxPoolMock.CopyFromFunc: method is nil but PgxPool.CopyFrom was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		TableName   pgx.Identifier
+		ColumnNames []string
+		RowSrc      pgx.CopyFromSource
+	}{
+		Ctx:         ctx,
+		TableName:   tableName,
+		ColumnNames: columnNames,
+		RowSrc:      rowSrc,
+	}
+	mock.lockCopyFrom.Lock()
+	mock.calls.CopyFrom = append(mock.calls.CopyFrom, callInfo)
+	mock.lockCopyFrom.Unlock()
+	return mock.CopyFromFunc(ctx, tableName, columnNames, rowSrc)
+}
+
+// CopyFromCalls gets all the calls that were made to CopyFrom.
+// Check the length with:
+//
+//	len(mockedPgxPool.CopyFromCalls())
+func (mock *PgxPoolMock) CopyFromCalls() []struct {
+	Ctx         context.Context
+	TableName   pgx.Identifier
+	ColumnNames []string
+	RowSrc      pgx.CopyFromSource
+} {
+	var calls []struct {
+		Ctx         context.Context
+		TableName   pgx.Identifier
+		ColumnNames []string
+		RowSrc      pgx.CopyFromSource
+	}
+	mock.lockCopyFrom.RLock()
+	calls = mock.calls.CopyFrom
+	mock.lockCopyFrom.RUnlock()
+	return calls
+}
+
 // Exec calls ExecFunc.
 func (mock *PgxPoolMock) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
 	if mock.ExecFunc == nil {
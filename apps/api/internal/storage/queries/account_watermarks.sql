@@ -0,0 +1,20 @@
+-- name: GetAccountWatermark :one
+SELECT user_id, enabled, logo_url, position, opacity, scale, created_at, updated_at
+FROM account_watermarks
+WHERE user_id = $1;
+
+-- name: UpsertAccountWatermark :one
+INSERT INTO account_watermarks (user_id, enabled, logo_url, position, opacity, scale)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (user_id) DO UPDATE SET
+  enabled = EXCLUDED.enabled,
+  logo_url = EXCLUDED.logo_url,
+  position = EXCLUDED.position,
+  opacity = EXCLUDED.opacity,
+  scale = EXCLUDED.scale,
+  updated_at = now()
+RETURNING user_id, enabled, logo_url, position, opacity, scale, created_at, updated_at;
+
+-- name: DeleteAccountWatermark :execrows
+DELETE FROM account_watermarks
+WHERE user_id = $1;
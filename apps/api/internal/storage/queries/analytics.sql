@@ -0,0 +1,71 @@
+-- name: GetImageAnalyticsBuckets :many
+-- Aggregates image outcomes per UTC day or week. A NULL user_id aggregates across all users.
+SELECT
+  date_trunc(@granularity::text, i.created_at AT TIME ZONE 'UTC')::date AS bucket,
+  COUNT(*)::bigint AS total,
+  COUNT(*) FILTER (WHERE i.status = 'ready')::bigint AS ready,
+  COUNT(*) FILTER (WHERE i.status = 'error')::bigint AS failed,
+  COUNT(*) FILTER (WHERE i.status = 'canceled')::bigint AS canceled,
+  COALESCE(
+    AVG(EXTRACT(EPOCH FROM (i.updated_at - i.created_at))) FILTER (WHERE i.status = 'ready'),
+    0
+  )::float8 AS avg_turnaround_seconds
+FROM images i
+JOIN projects p ON p.id = i.project_id
+WHERE i.created_at >= @from_time
+  AND i.created_at < @to_time
+  AND (sqlc.narg('user_id')::uuid IS NULL OR p.user_id = sqlc.narg('user_id'))
+GROUP BY bucket
+ORDER BY bucket;
+
+-- name: GetStylePopularity :many
+-- Counts images per staging style in a date range. A NULL user_id counts across all users.
+SELECT
+  COALESCE(NULLIF(i.style, ''), 'unspecified')::text AS style,
+  COUNT(*)::bigint AS total
+FROM images i
+JOIN projects p ON p.id = i.project_id
+WHERE i.created_at >= @from_time
+  AND i.created_at < @to_time
+  AND (sqlc.narg('user_id')::uuid IS NULL OR p.user_id = sqlc.narg('user_id'))
+GROUP BY 1
+ORDER BY total DESC, style
+LIMIT @max_styles;
+
+-- name: GetTurnaroundSLAByPlan :many
+-- Measures SLA compliance per plan for images that became ready in a date range, counting
+-- only images measured against a target. A NULL user_id measures across all users.
+SELECT
+  t.plan_code,
+  MAX(t.target_ms)::bigint AS target_ms,
+  COALESCE(MAX(pl.sla_target_percent), 95)::float8 AS target_percent,
+  COUNT(*)::bigint AS measured,
+  COUNT(*) FILTER (WHERE t.met)::bigint AS met,
+  percentile_cont(0.95) WITHIN GROUP (ORDER BY t.turnaround_ms)::float8 AS p95_turnaround_ms,
+  COUNT(c.id)::bigint AS credits
+FROM image_turnarounds t
+LEFT JOIN plans pl ON pl.code = t.plan_code
+LEFT JOIN sla_credits c ON c.image_id = t.image_id
+WHERE t.ready_at >= @from_time
+  AND t.ready_at < @to_time
+  AND t.target_ms IS NOT NULL
+  AND (sqlc.narg('user_id')::uuid IS NULL OR t.user_id = sqlc.narg('user_id'))
+GROUP BY t.plan_code
+ORDER BY t.plan_code;
+
+-- name: GetImageSourceBreakdown :many
+-- Aggregates image outcomes per creating surface in a date range. A NULL user_id
+-- aggregates across all users.
+SELECT
+  i.source,
+  COUNT(*)::bigint AS total,
+  COUNT(*) FILTER (WHERE i.status = 'ready')::bigint AS ready,
+  COUNT(*) FILTER (WHERE i.status = 'error')::bigint AS failed,
+  COUNT(*) FILTER (WHERE i.status = 'canceled')::bigint AS canceled
+FROM images i
+JOIN projects p ON p.id = i.project_id
+WHERE i.created_at >= @from_time
+  AND i.created_at < @to_time
+  AND (sqlc.narg('user_id')::uuid IS NULL OR p.user_id = sqlc.narg('user_id'))
+GROUP BY i.source
+ORDER BY total DESC, i.source;
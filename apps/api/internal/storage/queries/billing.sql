@@ -1,5 +1,6 @@
+-- Billing: Stripe webhook idempotency, subscriptions and invoices
+
 -- Processed Events (Stripe Idempotency)
--- sqlc queries for processed_events and subscriptions
 
 -- name: GetProcessedEventByStripeID :one
 SELECT
@@ -21,6 +22,19 @@ RETURNING
   payload,
   received_at;
 
+-- Atomically claims an event for processing. Returns no row when another
+-- delivery of the same event already claimed it.
+-- name: ClaimProcessedEvent :one
+INSERT INTO processed_events (stripe_event_id, type, payload)
+VALUES ($1, $2, $3)
+ON CONFLICT (stripe_event_id) DO NOTHING
+RETURNING
+  id,
+  stripe_event_id,
+  type,
+  payload,
+  received_at;
+
 -- Optional: single-statement upsert that returns the existing/new row.
 -- Preserves existing values (no-op update) to obtain RETURNING without DO NOTHING.
 -- name: UpsertProcessedEventByStripeID :one
@@ -120,3 +134,75 @@ LIMIT $2 OFFSET $3;
 -- name: DeleteSubscriptionByStripeID :exec
 DELETE FROM subscriptions
 WHERE stripe_subscription_id = $1;
+
+
+
+-- Invoices
+
+-- Upsert by unique stripe_invoice_id. We do not modify user_id on conflict.
+-- name: UpsertInvoiceByStripeID :one
+INSERT INTO invoices (
+  user_id,
+  stripe_invoice_id,
+  stripe_subscription_id,
+  status,
+  amount_due,
+  amount_paid,
+  currency,
+  invoice_number
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (stripe_invoice_id) DO UPDATE SET
+  stripe_subscription_id = EXCLUDED.stripe_subscription_id,
+  status                 = EXCLUDED.status,
+  amount_due             = EXCLUDED.amount_due,
+  amount_paid            = EXCLUDED.amount_paid,
+  currency               = EXCLUDED.currency,
+  invoice_number         = EXCLUDED.invoice_number,
+  updated_at             = now()
+RETURNING
+  id,
+  user_id,
+  stripe_invoice_id,
+  stripe_subscription_id,
+  status,
+  amount_due,
+  amount_paid,
+  currency,
+  invoice_number,
+  created_at,
+  updated_at;
+
+-- name: GetInvoiceByStripeID :one
+SELECT
+  id,
+  user_id,
+  stripe_invoice_id,
+  stripe_subscription_id,
+  status,
+  amount_due,
+  amount_paid,
+  currency,
+  invoice_number,
+  created_at,
+  updated_at
+FROM invoices
+WHERE stripe_invoice_id = $1;
+
+-- name: ListInvoicesByUserID :many
+SELECT
+  id,
+  user_id,
+  stripe_invoice_id,
+  stripe_subscription_id,
+  status,
+  amount_due,
+  amount_paid,
+  currency,
+  invoice_number,
+  created_at,
+  updated_at
+FROM invoices
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
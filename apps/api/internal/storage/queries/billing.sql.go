@@ -1,7 +1,7 @@
 // Code generated by sqlc. DO NOT EDIT.
 // versions:
 //   sqlc v1.29.0
-// source: processed_events.sql
+// source: billing.sql
 
 package queries
 
@@ -11,6 +11,39 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const ClaimProcessedEvent = `-- name: ClaimProcessedEvent :one
+INSERT INTO processed_events (stripe_event_id, type, payload)
+VALUES ($1, $2, $3)
+ON CONFLICT (stripe_event_id) DO NOTHING
+RETURNING
+  id,
+  stripe_event_id,
+  type,
+  payload,
+  received_at
+`
+
+type ClaimProcessedEventParams struct {
+	StripeEventID string      `json:"stripe_event_id"`
+	Type          pgtype.Text `json:"type"`
+	Payload       []byte      `json:"payload"`
+}
+
+// Atomically claims an event for processing. Returns no row when another
+// delivery of the same event already claimed it.
+func (q *Queries) ClaimProcessedEvent(ctx context.Context, arg ClaimProcessedEventParams) (*ProcessedEvent, error) {
+	row := q.db.QueryRow(ctx, ClaimProcessedEvent, arg.StripeEventID, arg.Type, arg.Payload)
+	var i ProcessedEvent
+	err := row.Scan(
+		&i.ID,
+		&i.StripeEventID,
+		&i.Type,
+		&i.Payload,
+		&i.ReceivedAt,
+	)
+	return &i, err
+}
+
 const CreateProcessedEvent = `-- name: CreateProcessedEvent :one
 INSERT INTO processed_events (stripe_event_id, type, payload)
 VALUES ($1, $2, $3)
@@ -62,6 +95,42 @@ func (q *Queries) DeleteSubscriptionByStripeID(ctx context.Context, stripeSubscr
 	return err
 }
 
+const GetInvoiceByStripeID = `-- name: GetInvoiceByStripeID :one
+SELECT
+  id,
+  user_id,
+  stripe_invoice_id,
+  stripe_subscription_id,
+  status,
+  amount_due,
+  amount_paid,
+  currency,
+  invoice_number,
+  created_at,
+  updated_at
+FROM invoices
+WHERE stripe_invoice_id = $1
+`
+
+func (q *Queries) GetInvoiceByStripeID(ctx context.Context, stripeInvoiceID string) (*Invoice, error) {
+	row := q.db.QueryRow(ctx, GetInvoiceByStripeID, stripeInvoiceID)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.StripeInvoiceID,
+		&i.StripeSubscriptionID,
+		&i.Status,
+		&i.AmountDue,
+		&i.AmountPaid,
+		&i.Currency,
+		&i.InvoiceNumber,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
 const GetProcessedEventByStripeID = `-- name: GetProcessedEventByStripeID :one
 
 SELECT
@@ -74,8 +143,8 @@ FROM processed_events
 WHERE stripe_event_id = $1
 `
 
+// Billing: Stripe webhook idempotency, subscriptions and invoices
 // Processed Events (Stripe Idempotency)
-// sqlc queries for processed_events and subscriptions
 func (q *Queries) GetProcessedEventByStripeID(ctx context.Context, stripeEventID string) (*ProcessedEvent, error) {
 	row := q.db.QueryRow(ctx, GetProcessedEventByStripeID, stripeEventID)
 	var i ProcessedEvent
@@ -127,6 +196,63 @@ func (q *Queries) GetSubscriptionByStripeID(ctx context.Context, stripeSubscript
 	return &i, err
 }
 
+const ListInvoicesByUserID = `-- name: ListInvoicesByUserID :many
+SELECT
+  id,
+  user_id,
+  stripe_invoice_id,
+  stripe_subscription_id,
+  status,
+  amount_due,
+  amount_paid,
+  currency,
+  invoice_number,
+  created_at,
+  updated_at
+FROM invoices
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListInvoicesByUserIDParams struct {
+	UserID pgtype.UUID `json:"user_id"`
+	Limit  int32       `json:"limit"`
+	Offset int32       `json:"offset"`
+}
+
+func (q *Queries) ListInvoicesByUserID(ctx context.Context, arg ListInvoicesByUserIDParams) ([]*Invoice, error) {
+	rows, err := q.db.Query(ctx, ListInvoicesByUserID, arg.UserID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*Invoice{}
+	for rows.Next() {
+		var i Invoice
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.StripeInvoiceID,
+			&i.StripeSubscriptionID,
+			&i.Status,
+			&i.AmountDue,
+			&i.AmountPaid,
+			&i.Currency,
+			&i.InvoiceNumber,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const ListSubscriptionsByUserID = `-- name: ListSubscriptionsByUserID :many
 SELECT
   id,
@@ -186,6 +312,82 @@ func (q *Queries) ListSubscriptionsByUserID(ctx context.Context, arg ListSubscri
 	return items, nil
 }
 
+const UpsertInvoiceByStripeID = `-- name: UpsertInvoiceByStripeID :one
+
+INSERT INTO invoices (
+  user_id,
+  stripe_invoice_id,
+  stripe_subscription_id,
+  status,
+  amount_due,
+  amount_paid,
+  currency,
+  invoice_number
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (stripe_invoice_id) DO UPDATE SET
+  stripe_subscription_id = EXCLUDED.stripe_subscription_id,
+  status                 = EXCLUDED.status,
+  amount_due             = EXCLUDED.amount_due,
+  amount_paid            = EXCLUDED.amount_paid,
+  currency               = EXCLUDED.currency,
+  invoice_number         = EXCLUDED.invoice_number,
+  updated_at             = now()
+RETURNING
+  id,
+  user_id,
+  stripe_invoice_id,
+  stripe_subscription_id,
+  status,
+  amount_due,
+  amount_paid,
+  currency,
+  invoice_number,
+  created_at,
+  updated_at
+`
+
+type UpsertInvoiceByStripeIDParams struct {
+	UserID               pgtype.UUID `json:"user_id"`
+	StripeInvoiceID      string      `json:"stripe_invoice_id"`
+	StripeSubscriptionID pgtype.Text `json:"stripe_subscription_id"`
+	Status               string      `json:"status"`
+	AmountDue            int32       `json:"amount_due"`
+	AmountPaid           int32       `json:"amount_paid"`
+	Currency             pgtype.Text `json:"currency"`
+	InvoiceNumber        pgtype.Text `json:"invoice_number"`
+}
+
+// Invoices
+// Upsert by unique stripe_invoice_id. We do not modify user_id on conflict.
+func (q *Queries) UpsertInvoiceByStripeID(ctx context.Context, arg UpsertInvoiceByStripeIDParams) (*Invoice, error) {
+	row := q.db.QueryRow(ctx, UpsertInvoiceByStripeID,
+		arg.UserID,
+		arg.StripeInvoiceID,
+		arg.StripeSubscriptionID,
+		arg.Status,
+		arg.AmountDue,
+		arg.AmountPaid,
+		arg.Currency,
+		arg.InvoiceNumber,
+	)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.StripeInvoiceID,
+		&i.StripeSubscriptionID,
+		&i.Status,
+		&i.AmountDue,
+		&i.AmountPaid,
+		&i.Currency,
+		&i.InvoiceNumber,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
 const UpsertProcessedEventByStripeID = `-- name: UpsertProcessedEventByStripeID :one
 INSERT INTO processed_events (stripe_event_id, type, payload)
 VALUES ($1, $2, $3)
@@ -0,0 +1,32 @@
+-- name: CreateCatalog :one
+INSERT INTO catalogs (name, description, prompt_fragment, reference_image_urls, active)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: DeleteCatalog :execrows
+DELETE FROM catalogs
+WHERE id = $1;
+
+-- name: GetCatalogByID :one
+SELECT * FROM catalogs
+WHERE id = $1;
+
+-- name: ListActiveCatalogs :many
+SELECT * FROM catalogs
+WHERE active
+ORDER BY name;
+
+-- name: ListCatalogs :many
+SELECT * FROM catalogs
+ORDER BY name;
+
+-- name: UpdateCatalog :one
+UPDATE catalogs SET
+  name = $2,
+  description = $3,
+  prompt_fragment = $4,
+  reference_image_urls = $5,
+  active = $6,
+  updated_at = now()
+WHERE id = $1
+RETURNING *;
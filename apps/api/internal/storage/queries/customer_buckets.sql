@@ -0,0 +1,43 @@
+-- name: GetCustomerBucketByUserID :one
+SELECT user_id, bucket, region, role_arn, external_id, verified_at, created_at, updated_at
+FROM customer_buckets
+WHERE user_id = $1;
+
+-- name: GetCustomerBucketByName :one
+SELECT user_id, bucket, region, role_arn, external_id, verified_at, created_at, updated_at
+FROM customer_buckets
+WHERE bucket = $1;
+
+-- name: UpsertCustomerBucket :one
+-- Saves the account's bucket settings. The external ID is only set on the first save, and
+-- any change clears verified_at until the next access check passes.
+INSERT INTO customer_buckets (user_id, bucket, region, role_arn, external_id)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (user_id) DO UPDATE SET
+  bucket = EXCLUDED.bucket,
+  region = EXCLUDED.region,
+  role_arn = EXCLUDED.role_arn,
+  verified_at = NULL,
+  updated_at = now()
+RETURNING user_id, bucket, region, role_arn, external_id, verified_at, created_at, updated_at;
+
+-- name: MarkCustomerBucketVerified :one
+UPDATE customer_buckets
+SET verified_at = now(), updated_at = now()
+WHERE user_id = $1
+RETURNING user_id, bucket, region, role_arn, external_id, verified_at, created_at, updated_at;
+
+-- name: DeleteCustomerBucket :execrows
+DELETE FROM customer_buckets
+WHERE user_id = $1;
+
+-- name: GetCustomerBucketAccess :one
+-- Returns whether the user's active plan includes customer-managed storage.
+SELECT EXISTS (
+  SELECT 1
+  FROM subscriptions s
+  JOIN plans pl ON pl.price_id = s.price_id
+  WHERE s.user_id = $1
+    AND s.status IN ('active', 'trialing', 'past_due')
+    AND pl.customer_bucket
+) AS allowed;
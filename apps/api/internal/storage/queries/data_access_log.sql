@@ -0,0 +1,11 @@
+-- name: RecordDataAccess :one
+INSERT INTO data_access_log (owner_id, accessor_id, resource_type, resource_id, ticket, ip)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: ListDataAccessByOwner :many
+-- Reads of the owner's data, newest first.
+SELECT * FROM data_access_log
+WHERE owner_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3;
@@ -0,0 +1,18 @@
+-- name: ListDeadLetterJobs :many
+-- Tasks that used up their retries, most recently dead first.
+SELECT * FROM dead_letter_jobs
+WHERE dead_at IS NOT NULL
+ORDER BY dead_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: GetDeadLetterJob :one
+SELECT * FROM dead_letter_jobs
+WHERE id = $1;
+
+-- name: MarkDeadLetterJobRequeued :one
+-- Takes a dead task off the list once it is back in the queue. Only a dead task can be
+-- marked, so a task is requeued once however often the request is sent.
+UPDATE dead_letter_jobs
+SET dead_at = NULL, requeued_at = now(), requeue_count = requeue_count + 1
+WHERE id = $1 AND dead_at IS NOT NULL
+RETURNING *;
@@ -0,0 +1,14 @@
+-- name: GetUserEntitlements :one
+-- Returns the plan of the user's most recently updated active subscription.
+SELECT pl.code,
+       pl.monthly_limit,
+       pl.reference_images,
+       pl.expedite,
+       pl.customer_bucket,
+       s.status
+FROM subscriptions s
+JOIN plans pl ON pl.price_id = s.price_id
+WHERE s.user_id = $1
+  AND s.status IN ('active', 'trialing', 'past_due')
+ORDER BY s.updated_at DESC
+LIMIT 1;
@@ -0,0 +1,21 @@
+-- name: CreateImageEdit :one
+INSERT INTO image_edits (image_id, kind, base, mask_url)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- Marks an edit failed, used when it could not be queued.
+-- name: FailImageEdit :exec
+UPDATE image_edits SET status = 'error', error = $2, updated_at = now()
+WHERE id = $1;
+
+-- name: GetImageEdit :one
+SELECT * FROM image_edits
+WHERE id = $1 AND image_id = $2;
+
+-- Returns the image versions an edit can start from, its status, its project and the
+-- project owner, whose uploads a mask must be one of.
+-- name: GetImageEditSource :one
+SELECT i.original_url, i.staged_url, i.status, i.project_id, p.user_id
+FROM images i
+JOIN projects p ON p.id = i.project_id
+WHERE i.id = $1;
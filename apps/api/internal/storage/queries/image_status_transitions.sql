@@ -0,0 +1,6 @@
+-- name: ListImageStatusTransitions :many
+-- Lists an image's status events oldest first. The log outlives the image.
+SELECT id, image_id, project_id, from_status, to_status, error, created_at, source, job_id, attempt
+FROM image_status_transitions
+WHERE image_id = $1
+ORDER BY id;
@@ -1,15 +1,37 @@
 -- name: CreateImage :one
-INSERT INTO images (project_id, original_url, room_type, style, seed)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at;
+INSERT INTO images (project_id, original_url, room_type, style, seed, source)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at, source;
+
+-- name: CreateImages :copyfrom
+INSERT INTO images (id, project_id, original_url, room_type, style, seed, source)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: CreateImageAnnotations :exec
+INSERT INTO image_annotations (image_id, wall_length_m, ceiling_height_m)
+VALUES ($1, $2, $3);
+
+-- name: CreateImageBrackets :exec
+INSERT INTO image_brackets (image_id, urls)
+VALUES ($1, $2);
 
 -- name: GetImageByID :one
-SELECT id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at
+SELECT id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at, source, preview_url, watermarked_url, raw_url
 FROM images
 WHERE id = $1;
 
+-- name: GetImagesByIDs :many
+SELECT id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at, source, preview_url, watermarked_url, raw_url
+FROM images
+WHERE id = ANY(@ids::uuid[]);
+
+-- name: GetImageStatusesByIDs :many
+SELECT id, status
+FROM images
+WHERE project_id = @project_id AND id = ANY(@image_ids::uuid[]);
+
 -- name: GetImagesByProjectID :many
-SELECT id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at
+SELECT id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at, source, preview_url, watermarked_url, raw_url
 FROM images
 WHERE project_id = $1
 ORDER BY created_at DESC;
@@ -18,19 +40,63 @@ ORDER BY created_at DESC;
 UPDATE images
 SET status = $2, updated_at = now()
 WHERE id = $1
-RETURNING id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at;
+RETURNING id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at, source;
 
 -- name: UpdateImageWithStagedURL :one
 UPDATE images
 SET staged_url = $2, status = $3, updated_at = now()
 WHERE id = $1
-RETURNING id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at;
+RETURNING id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at, source;
 
 -- name: UpdateImageWithError :one
 UPDATE images
 SET status = 'error', error = $2, updated_at = now()
 WHERE id = $1
-RETURNING id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at;
+RETURNING id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at, source;
+
+-- name: BulkCancelImages :many
+-- Cancels every unfinished image in the list and its in-flight jobs in a single statement.
+WITH canceled AS (
+  UPDATE images
+  SET status = 'canceled', cost_usd = 0, updated_at = now()
+  WHERE project_id = @project_id AND id = ANY(@image_ids::uuid[]) AND status IN ('awaiting_upload', 'queued', 'processing')
+  RETURNING id, created_at
+), canceled_jobs AS (
+  UPDATE jobs
+  SET status = 'canceled', finished_at = now()
+  WHERE image_id IN (SELECT id FROM canceled) AND status IN ('queued', 'processing')
+  RETURNING id, image_id
+)
+SELECT canceled.id AS image_id, canceled.created_at, canceled_jobs.id AS job_id
+FROM canceled
+LEFT JOIN canceled_jobs ON canceled_jobs.image_id = canceled.id;
+
+-- name: BulkDeleteImages :many
+-- Deletes the listed images that are not under legal hold in a single statement. Held images
+-- are left in place and returned with held set.
+WITH targets AS (
+  SELECT i.id, i.status,
+         EXISTS (
+           SELECT 1 FROM legal_holds lh
+           WHERE lh.image_id = i.id OR lh.project_id = i.project_id
+         ) AS held
+  FROM images i
+  WHERE i.project_id = @project_id AND i.id = ANY(@image_ids::uuid[])
+), deleted AS (
+  DELETE FROM images
+  WHERE project_id = @project_id AND id IN (SELECT id FROM targets WHERE NOT held)
+  RETURNING id, status
+)
+SELECT id, status, false AS held FROM deleted
+UNION ALL
+SELECT id, status, true AS held FROM targets WHERE held;
+
+-- name: CancelImage :one
+-- Marks an unfinished image as canceled and zeroes its cost so canceled work is not billed.
+UPDATE images
+SET status = 'canceled', cost_usd = 0, updated_at = now()
+WHERE id = $1 AND status IN ('awaiting_upload', 'queued', 'processing')
+RETURNING id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at, source;
 
 -- name: DeleteImage :exec
 DELETE FROM images
@@ -40,11 +106,57 @@ WHERE id = $1;
 DELETE FROM images
 WHERE project_id = $1;
 
--- name: ListImagesForReconcile :many
-SELECT id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at
+-- name: UpdateImageCost :exec
+UPDATE images
+SET cost_usd = @cost_usd::float8,
+    model_used = @model_used,
+    processing_time_ms = @processing_time_ms,
+    replicate_prediction_id = @replicate_prediction_id,
+    updated_at = now()
+WHERE id = @id;
+
+-- name: GetProjectCostSummary :one
+SELECT COALESCE(SUM(cost_usd), 0)::float8 AS total_cost_usd,
+       COUNT(*) AS image_count,
+       COALESCE(AVG(cost_usd), 0)::float8 AS avg_cost_usd
 FROM images
-WHERE ($1::uuid IS NULL OR project_id = $1::uuid)
-  AND ($2::text IS NULL OR $2::text = '' OR status = $2::image_status)
-  AND ($3::uuid IS NULL OR id > $3::uuid)
-ORDER BY id ASC
-LIMIT $4;
+WHERE project_id = $1;
+
+-- name: GetReferenceImageAccess :one
+-- Returns the project's owner and whether their active plan allows reference images.
+SELECT p.user_id,
+       EXISTS (
+         SELECT 1
+         FROM subscriptions s
+         JOIN plans pl ON pl.price_id = s.price_id
+         WHERE s.user_id = p.user_id
+           AND s.status IN ('active', 'trialing', 'past_due')
+           AND pl.reference_images
+       ) AS allowed
+FROM projects p
+WHERE p.id = $1;
+
+-- name: GetExpediteAccess :one
+-- Returns the image's project and owner, whether the owner's active plan allows expediting,
+-- and how many images the owner has expedited across all of their projects since @since.
+SELECT i.project_id,
+       p.user_id,
+       EXISTS (
+         SELECT 1
+         FROM subscriptions s
+         JOIN plans pl ON pl.price_id = s.price_id
+         WHERE s.user_id = p.user_id
+           AND s.status IN ('active', 'trialing', 'past_due')
+           AND pl.expedite
+       ) AS allowed,
+       (
+         SELECT count(*)
+         FROM project_activity pa
+         JOIN projects op ON op.id = pa.project_id
+         WHERE op.user_id = p.user_id
+           AND pa.event_type = 'image.expedited'
+           AND pa.created_at >= @since
+       ) AS expedited_since
+FROM images i
+JOIN projects p ON p.id = i.project_id
+WHERE i.id = @image_id;
@@ -11,10 +11,154 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const BulkCancelImages = `-- name: BulkCancelImages :many
+WITH canceled AS (
+  UPDATE images
+  SET status = 'canceled', cost_usd = 0, updated_at = now()
+  WHERE project_id = $1 AND id = ANY($2::uuid[]) AND status IN ('awaiting_upload', 'queued', 'processing')
+  RETURNING id, created_at
+), canceled_jobs AS (
+  UPDATE jobs
+  SET status = 'canceled', finished_at = now()
+  WHERE image_id IN (SELECT id FROM canceled) AND status IN ('queued', 'processing')
+  RETURNING id, image_id
+)
+SELECT canceled.id AS image_id, canceled.created_at, canceled_jobs.id AS job_id
+FROM canceled
+LEFT JOIN canceled_jobs ON canceled_jobs.image_id = canceled.id
+`
+
+type BulkCancelImagesParams struct {
+	ProjectID pgtype.UUID   `json:"project_id"`
+	ImageIds  []pgtype.UUID `json:"image_ids"`
+}
+
+type BulkCancelImagesRow struct {
+	ImageID   pgtype.UUID        `json:"image_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	JobID     pgtype.UUID        `json:"job_id"`
+}
+
+// Cancels every unfinished image in the list and its in-flight jobs in a single statement.
+func (q *Queries) BulkCancelImages(ctx context.Context, arg BulkCancelImagesParams) ([]*BulkCancelImagesRow, error) {
+	rows, err := q.db.Query(ctx, BulkCancelImages, arg.ProjectID, arg.ImageIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*BulkCancelImagesRow{}
+	for rows.Next() {
+		var i BulkCancelImagesRow
+		if err := rows.Scan(&i.ImageID, &i.CreatedAt, &i.JobID); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const BulkDeleteImages = `-- name: BulkDeleteImages :many
+WITH targets AS (
+  SELECT i.id, i.status,
+         EXISTS (
+           SELECT 1 FROM legal_holds lh
+           WHERE lh.image_id = i.id OR lh.project_id = i.project_id
+         ) AS held
+  FROM images i
+  WHERE i.project_id = $1 AND i.id = ANY($2::uuid[])
+), deleted AS (
+  DELETE FROM images
+  WHERE project_id = $1 AND id IN (SELECT id FROM targets WHERE NOT held)
+  RETURNING id, status
+)
+SELECT id, status, false AS held FROM deleted
+UNION ALL
+SELECT id, status, true AS held FROM targets WHERE held
+`
+
+type BulkDeleteImagesParams struct {
+	ProjectID pgtype.UUID   `json:"project_id"`
+	ImageIds  []pgtype.UUID `json:"image_ids"`
+}
+
+type BulkDeleteImagesRow struct {
+	ID     pgtype.UUID `json:"id"`
+	Status ImageStatus `json:"status"`
+	Held   bool        `json:"held"`
+}
+
+// Deletes the listed images that are not under legal hold in a single statement. Held images
+// are left in place and returned with held set.
+func (q *Queries) BulkDeleteImages(ctx context.Context, arg BulkDeleteImagesParams) ([]*BulkDeleteImagesRow, error) {
+	rows, err := q.db.Query(ctx, BulkDeleteImages, arg.ProjectID, arg.ImageIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*BulkDeleteImagesRow{}
+	for rows.Next() {
+		var i BulkDeleteImagesRow
+		if err := rows.Scan(&i.ID, &i.Status, &i.Held); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const CancelImage = `-- name: CancelImage :one
+UPDATE images
+SET status = 'canceled', cost_usd = 0, updated_at = now()
+WHERE id = $1 AND status IN ('awaiting_upload', 'queued', 'processing')
+RETURNING id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at, source
+`
+
+type CancelImageRow struct {
+	ID          pgtype.UUID        `json:"id"`
+	ProjectID   pgtype.UUID        `json:"project_id"`
+	OriginalUrl string             `json:"original_url"`
+	StagedUrl   pgtype.Text        `json:"staged_url"`
+	RoomType    pgtype.Text        `json:"room_type"`
+	Style       pgtype.Text        `json:"style"`
+	Seed        pgtype.Int8        `json:"seed"`
+	Status      ImageStatus        `json:"status"`
+	Error       pgtype.Text        `json:"error"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	Source      string             `json:"source"`
+}
+
+// Marks an unfinished image as canceled and zeroes its cost so canceled work is not billed.
+func (q *Queries) CancelImage(ctx context.Context, id pgtype.UUID) (*CancelImageRow, error) {
+	row := q.db.QueryRow(ctx, CancelImage, id)
+	var i CancelImageRow
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.OriginalUrl,
+		&i.StagedUrl,
+		&i.RoomType,
+		&i.Style,
+		&i.Seed,
+		&i.Status,
+		&i.Error,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Source,
+	)
+	return &i, err
+}
+
 const CreateImage = `-- name: CreateImage :one
-INSERT INTO images (project_id, original_url, room_type, style, seed)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at
+INSERT INTO images (project_id, original_url, room_type, style, seed, source)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at, source
 `
 
 type CreateImageParams struct {
@@ -23,6 +167,7 @@ type CreateImageParams struct {
 	RoomType    pgtype.Text `json:"room_type"`
 	Style       pgtype.Text `json:"style"`
 	Seed        pgtype.Int8 `json:"seed"`
+	Source      string      `json:"source"`
 }
 
 type CreateImageRow struct {
@@ -37,6 +182,7 @@ type CreateImageRow struct {
 	Error       pgtype.Text        `json:"error"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	Source      string             `json:"source"`
 }
 
 func (q *Queries) CreateImage(ctx context.Context, arg CreateImageParams) (*CreateImageRow, error) {
@@ -46,6 +192,7 @@ func (q *Queries) CreateImage(ctx context.Context, arg CreateImageParams) (*Crea
 		arg.RoomType,
 		arg.Style,
 		arg.Seed,
+		arg.Source,
 	)
 	var i CreateImageRow
 	err := row.Scan(
@@ -60,10 +207,21 @@ func (q *Queries) CreateImage(ctx context.Context, arg CreateImageParams) (*Crea
 		&i.Error,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Source,
 	)
 	return &i, err
 }
 
+type CreateImagesParams struct {
+	ID          pgtype.UUID `json:"id"`
+	ProjectID   pgtype.UUID `json:"project_id"`
+	OriginalUrl string      `json:"original_url"`
+	RoomType    pgtype.Text `json:"room_type"`
+	Style       pgtype.Text `json:"style"`
+	Seed        pgtype.Int8 `json:"seed"`
+	Source      string      `json:"source"`
+}
+
 const DeleteImage = `-- name: DeleteImage :exec
 DELETE FROM images
 WHERE id = $1
@@ -84,24 +242,59 @@ func (q *Queries) DeleteImagesByProjectID(ctx context.Context, projectID pgtype.
 	return err
 }
 
+const CreateImageAnnotations = `-- name: CreateImageAnnotations :exec
+INSERT INTO image_annotations (image_id, wall_length_m, ceiling_height_m)
+VALUES ($1, $2, $3)
+`
+
+type CreateImageAnnotationsParams struct {
+	ImageID        pgtype.UUID   `json:"image_id"`
+	WallLengthM    pgtype.Float8 `json:"wall_length_m"`
+	CeilingHeightM pgtype.Float8 `json:"ceiling_height_m"`
+}
+
+func (q *Queries) CreateImageAnnotations(ctx context.Context, arg CreateImageAnnotationsParams) error {
+	_, err := q.db.Exec(ctx, CreateImageAnnotations, arg.ImageID, arg.WallLengthM, arg.CeilingHeightM)
+	return err
+}
+
+const CreateImageBrackets = `-- name: CreateImageBrackets :exec
+INSERT INTO image_brackets (image_id, urls)
+VALUES ($1, $2)
+`
+
+type CreateImageBracketsParams struct {
+	ImageID pgtype.UUID `json:"image_id"`
+	Urls    []string    `json:"urls"`
+}
+
+func (q *Queries) CreateImageBrackets(ctx context.Context, arg CreateImageBracketsParams) error {
+	_, err := q.db.Exec(ctx, CreateImageBrackets, arg.ImageID, arg.Urls)
+	return err
+}
+
 const GetImageByID = `-- name: GetImageByID :one
-SELECT id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at
+SELECT id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at, source, preview_url, watermarked_url, raw_url
 FROM images
 WHERE id = $1
 `
 
 type GetImageByIDRow struct {
-	ID          pgtype.UUID        `json:"id"`
-	ProjectID   pgtype.UUID        `json:"project_id"`
-	OriginalUrl string             `json:"original_url"`
-	StagedUrl   pgtype.Text        `json:"staged_url"`
-	RoomType    pgtype.Text        `json:"room_type"`
-	Style       pgtype.Text        `json:"style"`
-	Seed        pgtype.Int8        `json:"seed"`
-	Status      ImageStatus        `json:"status"`
-	Error       pgtype.Text        `json:"error"`
-	CreatedAt   pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	ID             pgtype.UUID        `json:"id"`
+	ProjectID      pgtype.UUID        `json:"project_id"`
+	OriginalUrl    string             `json:"original_url"`
+	StagedUrl      pgtype.Text        `json:"staged_url"`
+	RoomType       pgtype.Text        `json:"room_type"`
+	Style          pgtype.Text        `json:"style"`
+	Seed           pgtype.Int8        `json:"seed"`
+	Status         ImageStatus        `json:"status"`
+	Error          pgtype.Text        `json:"error"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	Source         string             `json:"source"`
+	PreviewUrl     pgtype.Text        `json:"preview_url"`
+	WatermarkedUrl pgtype.Text        `json:"watermarked_url"`
+	RawUrl         pgtype.Text        `json:"raw_url"`
 }
 
 func (q *Queries) GetImageByID(ctx context.Context, id pgtype.UUID) (*GetImageByIDRow, error) {
@@ -119,40 +312,83 @@ func (q *Queries) GetImageByID(ctx context.Context, id pgtype.UUID) (*GetImageBy
 		&i.Error,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Source,
+		&i.PreviewUrl,
+		&i.WatermarkedUrl,
+		&i.RawUrl,
 	)
 	return &i, err
 }
 
-const GetImagesByProjectID = `-- name: GetImagesByProjectID :many
-SELECT id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at
+const GetImageStatusesByIDs = `-- name: GetImageStatusesByIDs :many
+SELECT id, status
 FROM images
-WHERE project_id = $1
-ORDER BY created_at DESC
+WHERE project_id = $1 AND id = ANY($2::uuid[])
 `
 
-type GetImagesByProjectIDRow struct {
-	ID          pgtype.UUID        `json:"id"`
-	ProjectID   pgtype.UUID        `json:"project_id"`
-	OriginalUrl string             `json:"original_url"`
-	StagedUrl   pgtype.Text        `json:"staged_url"`
-	RoomType    pgtype.Text        `json:"room_type"`
-	Style       pgtype.Text        `json:"style"`
-	Seed        pgtype.Int8        `json:"seed"`
-	Status      ImageStatus        `json:"status"`
-	Error       pgtype.Text        `json:"error"`
-	CreatedAt   pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+type GetImageStatusesByIDsParams struct {
+	ProjectID pgtype.UUID   `json:"project_id"`
+	ImageIds  []pgtype.UUID `json:"image_ids"`
 }
 
-func (q *Queries) GetImagesByProjectID(ctx context.Context, projectID pgtype.UUID) ([]*GetImagesByProjectIDRow, error) {
-	rows, err := q.db.Query(ctx, GetImagesByProjectID, projectID)
+type GetImageStatusesByIDsRow struct {
+	ID     pgtype.UUID `json:"id"`
+	Status ImageStatus `json:"status"`
+}
+
+func (q *Queries) GetImageStatusesByIDs(ctx context.Context, arg GetImageStatusesByIDsParams) ([]*GetImageStatusesByIDsRow, error) {
+	rows, err := q.db.Query(ctx, GetImageStatusesByIDs, arg.ProjectID, arg.ImageIds)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []*GetImagesByProjectIDRow{}
+	items := []*GetImageStatusesByIDsRow{}
 	for rows.Next() {
-		var i GetImagesByProjectIDRow
+		var i GetImageStatusesByIDsRow
+		if err := rows.Scan(&i.ID, &i.Status); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetImagesByIDs = `-- name: GetImagesByIDs :many
+SELECT id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at, source, preview_url, watermarked_url, raw_url
+FROM images
+WHERE id = ANY($1::uuid[])
+`
+
+type GetImagesByIDsRow struct {
+	ID             pgtype.UUID        `json:"id"`
+	ProjectID      pgtype.UUID        `json:"project_id"`
+	OriginalUrl    string             `json:"original_url"`
+	StagedUrl      pgtype.Text        `json:"staged_url"`
+	RoomType       pgtype.Text        `json:"room_type"`
+	Style          pgtype.Text        `json:"style"`
+	Seed           pgtype.Int8        `json:"seed"`
+	Status         ImageStatus        `json:"status"`
+	Error          pgtype.Text        `json:"error"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	Source         string             `json:"source"`
+	PreviewUrl     pgtype.Text        `json:"preview_url"`
+	WatermarkedUrl pgtype.Text        `json:"watermarked_url"`
+	RawUrl         pgtype.Text        `json:"raw_url"`
+}
+
+func (q *Queries) GetImagesByIDs(ctx context.Context, ids []pgtype.UUID) ([]*GetImagesByIDsRow, error) {
+	rows, err := q.db.Query(ctx, GetImagesByIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*GetImagesByIDsRow{}
+	for rows.Next() {
+		var i GetImagesByIDsRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.ProjectID,
@@ -165,6 +401,10 @@ func (q *Queries) GetImagesByProjectID(ctx context.Context, projectID pgtype.UUI
 			&i.Error,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Source,
+			&i.PreviewUrl,
+			&i.WatermarkedUrl,
+			&i.RawUrl,
 		); err != nil {
 			return nil, err
 		}
@@ -176,51 +416,40 @@ func (q *Queries) GetImagesByProjectID(ctx context.Context, projectID pgtype.UUI
 	return items, nil
 }
 
-const ListImagesForReconcile = `-- name: ListImagesForReconcile :many
-SELECT id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at
+const GetImagesByProjectID = `-- name: GetImagesByProjectID :many
+SELECT id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at, source, preview_url, watermarked_url, raw_url
 FROM images
-WHERE ($1::uuid IS NULL OR project_id = $1::uuid)
-  AND ($2::text IS NULL OR $2::text = '' OR status = $2::image_status)
-  AND ($3::uuid IS NULL OR id > $3::uuid)
-ORDER BY id ASC
-LIMIT $4
+WHERE project_id = $1
+ORDER BY created_at DESC
 `
 
-type ListImagesForReconcileParams struct {
-	Column1 pgtype.UUID `json:"column_1"`
-	Column2 string      `json:"column_2"`
-	Column3 pgtype.UUID `json:"column_3"`
-	Limit   int32       `json:"limit"`
-}
-
-type ListImagesForReconcileRow struct {
-	ID          pgtype.UUID        `json:"id"`
-	ProjectID   pgtype.UUID        `json:"project_id"`
-	OriginalUrl string             `json:"original_url"`
-	StagedUrl   pgtype.Text        `json:"staged_url"`
-	RoomType    pgtype.Text        `json:"room_type"`
-	Style       pgtype.Text        `json:"style"`
-	Seed        pgtype.Int8        `json:"seed"`
-	Status      ImageStatus        `json:"status"`
-	Error       pgtype.Text        `json:"error"`
-	CreatedAt   pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+type GetImagesByProjectIDRow struct {
+	ID             pgtype.UUID        `json:"id"`
+	ProjectID      pgtype.UUID        `json:"project_id"`
+	OriginalUrl    string             `json:"original_url"`
+	StagedUrl      pgtype.Text        `json:"staged_url"`
+	RoomType       pgtype.Text        `json:"room_type"`
+	Style          pgtype.Text        `json:"style"`
+	Seed           pgtype.Int8        `json:"seed"`
+	Status         ImageStatus        `json:"status"`
+	Error          pgtype.Text        `json:"error"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	Source         string             `json:"source"`
+	PreviewUrl     pgtype.Text        `json:"preview_url"`
+	WatermarkedUrl pgtype.Text        `json:"watermarked_url"`
+	RawUrl         pgtype.Text        `json:"raw_url"`
 }
 
-func (q *Queries) ListImagesForReconcile(ctx context.Context, arg ListImagesForReconcileParams) ([]*ListImagesForReconcileRow, error) {
-	rows, err := q.db.Query(ctx, ListImagesForReconcile,
-		arg.Column1,
-		arg.Column2,
-		arg.Column3,
-		arg.Limit,
-	)
+func (q *Queries) GetImagesByProjectID(ctx context.Context, projectID pgtype.UUID) ([]*GetImagesByProjectIDRow, error) {
+	rows, err := q.db.Query(ctx, GetImagesByProjectID, projectID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []*ListImagesForReconcileRow{}
+	items := []*GetImagesByProjectIDRow{}
 	for rows.Next() {
-		var i ListImagesForReconcileRow
+		var i GetImagesByProjectIDRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.ProjectID,
@@ -233,6 +462,10 @@ func (q *Queries) ListImagesForReconcile(ctx context.Context, arg ListImagesForR
 			&i.Error,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Source,
+			&i.PreviewUrl,
+			&i.WatermarkedUrl,
+			&i.RawUrl,
 		); err != nil {
 			return nil, err
 		}
@@ -244,11 +477,138 @@ func (q *Queries) ListImagesForReconcile(ctx context.Context, arg ListImagesForR
 	return items, nil
 }
 
+const GetProjectCostSummary = `-- name: GetProjectCostSummary :one
+SELECT COALESCE(SUM(cost_usd), 0)::float8 AS total_cost_usd,
+       COUNT(*) AS image_count,
+       COALESCE(AVG(cost_usd), 0)::float8 AS avg_cost_usd
+FROM images
+WHERE project_id = $1
+`
+
+type GetProjectCostSummaryRow struct {
+	TotalCostUsd float64 `json:"total_cost_usd"`
+	ImageCount   int64   `json:"image_count"`
+	AvgCostUsd   float64 `json:"avg_cost_usd"`
+}
+
+func (q *Queries) GetProjectCostSummary(ctx context.Context, projectID pgtype.UUID) (*GetProjectCostSummaryRow, error) {
+	row := q.db.QueryRow(ctx, GetProjectCostSummary, projectID)
+	var i GetProjectCostSummaryRow
+	err := row.Scan(&i.TotalCostUsd, &i.ImageCount, &i.AvgCostUsd)
+	return &i, err
+}
+
+const GetReferenceImageAccess = `-- name: GetReferenceImageAccess :one
+SELECT p.user_id,
+       EXISTS (
+         SELECT 1
+         FROM subscriptions s
+         JOIN plans pl ON pl.price_id = s.price_id
+         WHERE s.user_id = p.user_id
+           AND s.status IN ('active', 'trialing', 'past_due')
+           AND pl.reference_images
+       ) AS allowed
+FROM projects p
+WHERE p.id = $1
+`
+
+type GetReferenceImageAccessRow struct {
+	UserID  pgtype.UUID `json:"user_id"`
+	Allowed bool        `json:"allowed"`
+}
+
+// Returns the project's owner and whether their active plan allows reference images.
+func (q *Queries) GetReferenceImageAccess(ctx context.Context, id pgtype.UUID) (*GetReferenceImageAccessRow, error) {
+	row := q.db.QueryRow(ctx, GetReferenceImageAccess, id)
+	var i GetReferenceImageAccessRow
+	err := row.Scan(&i.UserID, &i.Allowed)
+	return &i, err
+}
+
+const GetExpediteAccess = `-- name: GetExpediteAccess :one
+SELECT i.project_id,
+       p.user_id,
+       EXISTS (
+         SELECT 1
+         FROM subscriptions s
+         JOIN plans pl ON pl.price_id = s.price_id
+         WHERE s.user_id = p.user_id
+           AND s.status IN ('active', 'trialing', 'past_due')
+           AND pl.expedite
+       ) AS allowed,
+       (
+         SELECT count(*)
+         FROM project_activity pa
+         JOIN projects op ON op.id = pa.project_id
+         WHERE op.user_id = p.user_id
+           AND pa.event_type = 'image.expedited'
+           AND pa.created_at >= $1
+       ) AS expedited_since
+FROM images i
+JOIN projects p ON p.id = i.project_id
+WHERE i.id = $2
+`
+
+type GetExpediteAccessParams struct {
+	Since   pgtype.Timestamptz `json:"since"`
+	ImageID pgtype.UUID        `json:"image_id"`
+}
+
+type GetExpediteAccessRow struct {
+	ProjectID      pgtype.UUID `json:"project_id"`
+	UserID         pgtype.UUID `json:"user_id"`
+	Allowed        bool        `json:"allowed"`
+	ExpeditedSince int64       `json:"expedited_since"`
+}
+
+// Returns the image's project and owner, whether the owner's active plan allows expediting,
+// and how many images the owner has expedited across all of their projects since @since.
+func (q *Queries) GetExpediteAccess(ctx context.Context, arg GetExpediteAccessParams) (*GetExpediteAccessRow, error) {
+	row := q.db.QueryRow(ctx, GetExpediteAccess, arg.Since, arg.ImageID)
+	var i GetExpediteAccessRow
+	err := row.Scan(
+		&i.ProjectID,
+		&i.UserID,
+		&i.Allowed,
+		&i.ExpeditedSince,
+	)
+	return &i, err
+}
+
+const UpdateImageCost = `-- name: UpdateImageCost :exec
+UPDATE images
+SET cost_usd = $1::float8,
+    model_used = $2,
+    processing_time_ms = $3,
+    replicate_prediction_id = $4,
+    updated_at = now()
+WHERE id = $5
+`
+
+type UpdateImageCostParams struct {
+	CostUsd               float64     `json:"cost_usd"`
+	ModelUsed             pgtype.Text `json:"model_used"`
+	ProcessingTimeMs      pgtype.Int4 `json:"processing_time_ms"`
+	ReplicatePredictionID pgtype.Text `json:"replicate_prediction_id"`
+	ID                    pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) UpdateImageCost(ctx context.Context, arg UpdateImageCostParams) error {
+	_, err := q.db.Exec(ctx, UpdateImageCost,
+		arg.CostUsd,
+		arg.ModelUsed,
+		arg.ProcessingTimeMs,
+		arg.ReplicatePredictionID,
+		arg.ID,
+	)
+	return err
+}
+
 const UpdateImageStatus = `-- name: UpdateImageStatus :one
 UPDATE images
 SET status = $2, updated_at = now()
 WHERE id = $1
-RETURNING id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at
+RETURNING id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at, source
 `
 
 type UpdateImageStatusParams struct {
@@ -268,6 +628,7 @@ type UpdateImageStatusRow struct {
 	Error       pgtype.Text        `json:"error"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	Source      string             `json:"source"`
 }
 
 func (q *Queries) UpdateImageStatus(ctx context.Context, arg UpdateImageStatusParams) (*UpdateImageStatusRow, error) {
@@ -285,6 +646,7 @@ func (q *Queries) UpdateImageStatus(ctx context.Context, arg UpdateImageStatusPa
 		&i.Error,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Source,
 	)
 	return &i, err
 }
@@ -293,7 +655,7 @@ const UpdateImageWithError = `-- name: UpdateImageWithError :one
 UPDATE images
 SET status = 'error', error = $2, updated_at = now()
 WHERE id = $1
-RETURNING id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at
+RETURNING id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at, source
 `
 
 type UpdateImageWithErrorParams struct {
@@ -313,6 +675,7 @@ type UpdateImageWithErrorRow struct {
 	Error       pgtype.Text        `json:"error"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	Source      string             `json:"source"`
 }
 
 func (q *Queries) UpdateImageWithError(ctx context.Context, arg UpdateImageWithErrorParams) (*UpdateImageWithErrorRow, error) {
@@ -330,6 +693,7 @@ func (q *Queries) UpdateImageWithError(ctx context.Context, arg UpdateImageWithE
 		&i.Error,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Source,
 	)
 	return &i, err
 }
@@ -338,7 +702,7 @@ const UpdateImageWithStagedURL = `-- name: UpdateImageWithStagedURL :one
 UPDATE images
 SET staged_url = $2, status = $3, updated_at = now()
 WHERE id = $1
-RETURNING id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at
+RETURNING id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at, source
 `
 
 type UpdateImageWithStagedURLParams struct {
@@ -359,6 +723,7 @@ type UpdateImageWithStagedURLRow struct {
 	Error       pgtype.Text        `json:"error"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	Source      string             `json:"source"`
 }
 
 func (q *Queries) UpdateImageWithStagedURL(ctx context.Context, arg UpdateImageWithStagedURLParams) (*UpdateImageWithStagedURLRow, error) {
@@ -376,6 +741,7 @@ func (q *Queries) UpdateImageWithStagedURL(ctx context.Context, arg UpdateImageW
 		&i.Error,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Source,
 	)
 	return &i, err
 }
@@ -0,0 +1,9 @@
+-- name: GetJobLog :one
+-- Returns the sanitized processing log the worker recorded for a job, when the user owns or
+-- collaborates on the project of the job's image.
+SELECT l.job_id, l.image_id, l.log, l.truncated, l.updated_at
+FROM job_logs l
+JOIN images i ON i.id = l.image_id
+JOIN projects p ON p.id = i.project_id
+LEFT JOIN project_collaborators c ON c.project_id = p.id AND c.user_id = $2
+WHERE l.job_id = $1 AND (p.user_id = $2 OR c.user_id IS NOT NULL);
@@ -1,15 +1,22 @@
 -- name: CreateJob :one
 INSERT INTO jobs (image_id, type, payload_json)
 VALUES ($1, $2, $3)
-RETURNING id, image_id, type, payload_json, status, error, created_at, started_at, finished_at;
+RETURNING id, image_id, type, payload_json, status, error, created_at, started_at, finished_at,
+  prediction_id, output_key, model_version, provider_response;
+
+-- name: CreateJobs :copyfrom
+INSERT INTO jobs (id, image_id, type, payload_json)
+VALUES ($1, $2, $3, $4);
 
 -- name: GetJobByID :one
-SELECT id, image_id, type, payload_json, status, error, created_at, started_at, finished_at
+SELECT id, image_id, type, payload_json, status, error, created_at, started_at, finished_at,
+  prediction_id, output_key, model_version, provider_response
 FROM jobs
 WHERE id = $1;
 
 -- name: GetJobsByImageID :many
-SELECT id, image_id, type, payload_json, status, error, created_at, started_at, finished_at
+SELECT id, image_id, type, payload_json, status, error, created_at, started_at, finished_at,
+  prediction_id, output_key, model_version, provider_response
 FROM jobs
 WHERE image_id = $1
 ORDER BY created_at DESC;
@@ -18,28 +25,40 @@ ORDER BY created_at DESC;
 UPDATE jobs
 SET status = $2, updated_at = now()
 WHERE id = $1
-RETURNING id, image_id, type, payload_json, status, error, created_at, started_at, finished_at;
+RETURNING id, image_id, type, payload_json, status, error, created_at, started_at, finished_at,
+  prediction_id, output_key, model_version, provider_response;
+
+-- name: CancelJobsByImageID :many
+UPDATE jobs
+SET status = 'canceled', finished_at = now()
+WHERE image_id = $1 AND status IN ('queued', 'processing')
+RETURNING id, image_id, type, payload_json, status, error, created_at, started_at, finished_at,
+  prediction_id, output_key, model_version, provider_response;
 
 -- name: StartJob :one
 UPDATE jobs
 SET status = 'processing', started_at = now()
 WHERE id = $1
-RETURNING id, image_id, type, payload_json, status, error, created_at, started_at, finished_at;
+RETURNING id, image_id, type, payload_json, status, error, created_at, started_at, finished_at,
+  prediction_id, output_key, model_version, provider_response;
 
 -- name: CompleteJob :one
 UPDATE jobs
 SET status = 'completed', finished_at = now()
 WHERE id = $1
-RETURNING id, image_id, type, payload_json, status, error, created_at, started_at, finished_at;
+RETURNING id, image_id, type, payload_json, status, error, created_at, started_at, finished_at,
+  prediction_id, output_key, model_version, provider_response;
 
 -- name: FailJob :one
 UPDATE jobs
 SET status = 'failed', error = $2, finished_at = now()
 WHERE id = $1
-RETURNING id, image_id, type, payload_json, status, error, created_at, started_at, finished_at;
+RETURNING id, image_id, type, payload_json, status, error, created_at, started_at, finished_at,
+  prediction_id, output_key, model_version, provider_response;
 
 -- name: GetPendingJobs :many
-SELECT id, image_id, type, payload_json, status, error, created_at, started_at, finished_at
+SELECT id, image_id, type, payload_json, status, error, created_at, started_at, finished_at,
+  prediction_id, output_key, model_version, provider_response
 FROM jobs
 WHERE status = 'queued'
 ORDER BY created_at ASC
@@ -52,3 +71,12 @@ WHERE id = $1;
 -- name: DeleteJobsByImageID :exec
 DELETE FROM jobs
 WHERE image_id = $1;
+
+-- name: ListJobs :many
+-- Lists jobs newest first, optionally filtered by status.
+SELECT id, image_id, type, payload_json, status, error, created_at, started_at, finished_at,
+  prediction_id, output_key, model_version, provider_response
+FROM jobs
+WHERE (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
@@ -11,11 +11,54 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const CancelJobsByImageID = `-- name: CancelJobsByImageID :many
+UPDATE jobs
+SET status = 'canceled', finished_at = now()
+WHERE image_id = $1 AND status IN ('queued', 'processing')
+RETURNING id, image_id, type, payload_json, status, error, created_at, started_at, finished_at,
+  prediction_id, output_key, model_version, provider_response
+`
+
+func (q *Queries) CancelJobsByImageID(ctx context.Context, imageID pgtype.UUID) ([]*Job, error) {
+	rows, err := q.db.Query(ctx, CancelJobsByImageID, imageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*Job{}
+	for rows.Next() {
+		var i Job
+		if err := rows.Scan(
+			&i.ID,
+			&i.ImageID,
+			&i.Type,
+			&i.PayloadJson,
+			&i.Status,
+			&i.Error,
+			&i.CreatedAt,
+			&i.StartedAt,
+			&i.FinishedAt,
+			&i.PredictionID,
+			&i.OutputKey,
+			&i.ModelVersion,
+			&i.ProviderResponse,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const CompleteJob = `-- name: CompleteJob :one
 UPDATE jobs
 SET status = 'completed', finished_at = now()
 WHERE id = $1
-RETURNING id, image_id, type, payload_json, status, error, created_at, started_at, finished_at
+RETURNING id, image_id, type, payload_json, status, error, created_at, started_at, finished_at,
+  prediction_id, output_key, model_version, provider_response
 `
 
 func (q *Queries) CompleteJob(ctx context.Context, id pgtype.UUID) (*Job, error) {
@@ -31,6 +74,10 @@ func (q *Queries) CompleteJob(ctx context.Context, id pgtype.UUID) (*Job, error)
 		&i.CreatedAt,
 		&i.StartedAt,
 		&i.FinishedAt,
+		&i.PredictionID,
+		&i.OutputKey,
+		&i.ModelVersion,
+		&i.ProviderResponse,
 	)
 	return &i, err
 }
@@ -38,7 +85,8 @@ func (q *Queries) CompleteJob(ctx context.Context, id pgtype.UUID) (*Job, error)
 const CreateJob = `-- name: CreateJob :one
 INSERT INTO jobs (image_id, type, payload_json)
 VALUES ($1, $2, $3)
-RETURNING id, image_id, type, payload_json, status, error, created_at, started_at, finished_at
+RETURNING id, image_id, type, payload_json, status, error, created_at, started_at, finished_at,
+  prediction_id, output_key, model_version, provider_response
 `
 
 type CreateJobParams struct {
@@ -60,10 +108,21 @@ func (q *Queries) CreateJob(ctx context.Context, arg CreateJobParams) (*Job, err
 		&i.CreatedAt,
 		&i.StartedAt,
 		&i.FinishedAt,
+		&i.PredictionID,
+		&i.OutputKey,
+		&i.ModelVersion,
+		&i.ProviderResponse,
 	)
 	return &i, err
 }
 
+type CreateJobsParams struct {
+	ID          pgtype.UUID `json:"id"`
+	ImageID     pgtype.UUID `json:"image_id"`
+	Type        string      `json:"type"`
+	PayloadJson []byte      `json:"payload_json"`
+}
+
 const DeleteJob = `-- name: DeleteJob :exec
 DELETE FROM jobs
 WHERE id = $1
@@ -88,7 +147,8 @@ const FailJob = `-- name: FailJob :one
 UPDATE jobs
 SET status = 'failed', error = $2, finished_at = now()
 WHERE id = $1
-RETURNING id, image_id, type, payload_json, status, error, created_at, started_at, finished_at
+RETURNING id, image_id, type, payload_json, status, error, created_at, started_at, finished_at,
+  prediction_id, output_key, model_version, provider_response
 `
 
 type FailJobParams struct {
@@ -109,12 +169,17 @@ func (q *Queries) FailJob(ctx context.Context, arg FailJobParams) (*Job, error)
 		&i.CreatedAt,
 		&i.StartedAt,
 		&i.FinishedAt,
+		&i.PredictionID,
+		&i.OutputKey,
+		&i.ModelVersion,
+		&i.ProviderResponse,
 	)
 	return &i, err
 }
 
 const GetJobByID = `-- name: GetJobByID :one
-SELECT id, image_id, type, payload_json, status, error, created_at, started_at, finished_at
+SELECT id, image_id, type, payload_json, status, error, created_at, started_at, finished_at,
+  prediction_id, output_key, model_version, provider_response
 FROM jobs
 WHERE id = $1
 `
@@ -132,12 +197,17 @@ func (q *Queries) GetJobByID(ctx context.Context, id pgtype.UUID) (*Job, error)
 		&i.CreatedAt,
 		&i.StartedAt,
 		&i.FinishedAt,
+		&i.PredictionID,
+		&i.OutputKey,
+		&i.ModelVersion,
+		&i.ProviderResponse,
 	)
 	return &i, err
 }
 
 const GetJobsByImageID = `-- name: GetJobsByImageID :many
-SELECT id, image_id, type, payload_json, status, error, created_at, started_at, finished_at
+SELECT id, image_id, type, payload_json, status, error, created_at, started_at, finished_at,
+  prediction_id, output_key, model_version, provider_response
 FROM jobs
 WHERE image_id = $1
 ORDER BY created_at DESC
@@ -162,6 +232,10 @@ func (q *Queries) GetJobsByImageID(ctx context.Context, imageID pgtype.UUID) ([]
 			&i.CreatedAt,
 			&i.StartedAt,
 			&i.FinishedAt,
+			&i.PredictionID,
+			&i.OutputKey,
+			&i.ModelVersion,
+			&i.ProviderResponse,
 		); err != nil {
 			return nil, err
 		}
@@ -174,7 +248,8 @@ func (q *Queries) GetJobsByImageID(ctx context.Context, imageID pgtype.UUID) ([]
 }
 
 const GetPendingJobs = `-- name: GetPendingJobs :many
-SELECT id, image_id, type, payload_json, status, error, created_at, started_at, finished_at
+SELECT id, image_id, type, payload_json, status, error, created_at, started_at, finished_at,
+  prediction_id, output_key, model_version, provider_response
 FROM jobs
 WHERE status = 'queued'
 ORDER BY created_at ASC
@@ -200,6 +275,60 @@ func (q *Queries) GetPendingJobs(ctx context.Context, limit int32) ([]*Job, erro
 			&i.CreatedAt,
 			&i.StartedAt,
 			&i.FinishedAt,
+			&i.PredictionID,
+			&i.OutputKey,
+			&i.ModelVersion,
+			&i.ProviderResponse,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListJobs = `-- name: ListJobs :many
+SELECT id, image_id, type, payload_json, status, error, created_at, started_at, finished_at,
+  prediction_id, output_key, model_version, provider_response
+FROM jobs
+WHERE ($1::text IS NULL OR status = $1)
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListJobsParams struct {
+	Status pgtype.Text `json:"status"`
+	Limit  int32       `json:"limit"`
+	Offset int32       `json:"offset"`
+}
+
+// Lists jobs newest first, optionally filtered by status.
+func (q *Queries) ListJobs(ctx context.Context, arg ListJobsParams) ([]*Job, error) {
+	rows, err := q.db.Query(ctx, ListJobs, arg.Status, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*Job{}
+	for rows.Next() {
+		var i Job
+		if err := rows.Scan(
+			&i.ID,
+			&i.ImageID,
+			&i.Type,
+			&i.PayloadJson,
+			&i.Status,
+			&i.Error,
+			&i.CreatedAt,
+			&i.StartedAt,
+			&i.FinishedAt,
+			&i.PredictionID,
+			&i.OutputKey,
+			&i.ModelVersion,
+			&i.ProviderResponse,
 		); err != nil {
 			return nil, err
 		}
@@ -215,7 +344,8 @@ const StartJob = `-- name: StartJob :one
 UPDATE jobs
 SET status = 'processing', started_at = now()
 WHERE id = $1
-RETURNING id, image_id, type, payload_json, status, error, created_at, started_at, finished_at
+RETURNING id, image_id, type, payload_json, status, error, created_at, started_at, finished_at,
+  prediction_id, output_key, model_version, provider_response
 `
 
 func (q *Queries) StartJob(ctx context.Context, id pgtype.UUID) (*Job, error) {
@@ -231,6 +361,10 @@ func (q *Queries) StartJob(ctx context.Context, id pgtype.UUID) (*Job, error) {
 		&i.CreatedAt,
 		&i.StartedAt,
 		&i.FinishedAt,
+		&i.PredictionID,
+		&i.OutputKey,
+		&i.ModelVersion,
+		&i.ProviderResponse,
 	)
 	return &i, err
 }
@@ -239,7 +373,8 @@ const UpdateJobStatus = `-- name: UpdateJobStatus :one
 UPDATE jobs
 SET status = $2, updated_at = now()
 WHERE id = $1
-RETURNING id, image_id, type, payload_json, status, error, created_at, started_at, finished_at
+RETURNING id, image_id, type, payload_json, status, error, created_at, started_at, finished_at,
+  prediction_id, output_key, model_version, provider_response
 `
 
 type UpdateJobStatusParams struct {
@@ -260,6 +395,10 @@ func (q *Queries) UpdateJobStatus(ctx context.Context, arg UpdateJobStatusParams
 		&i.CreatedAt,
 		&i.StartedAt,
 		&i.FinishedAt,
+		&i.PredictionID,
+		&i.OutputKey,
+		&i.ModelVersion,
+		&i.ProviderResponse,
 	)
 	return &i, err
 }
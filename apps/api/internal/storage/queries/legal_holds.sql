@@ -0,0 +1,50 @@
+-- name: IsImageUnderLegalHold :one
+SELECT EXISTS (
+  SELECT 1
+  FROM images i
+  JOIN legal_holds lh ON lh.image_id = i.id OR lh.project_id = i.project_id
+  WHERE i.id = $1
+) AS held;
+
+-- name: IsProjectUnderLegalHold :one
+SELECT EXISTS (
+  SELECT 1
+  FROM legal_holds lh
+  LEFT JOIN images i ON i.id = lh.image_id
+  WHERE lh.project_id = $1 OR i.project_id = $1
+) AS held;
+
+-- name: ListLegalHeldImageIDs :many
+SELECT i.id
+FROM images i
+WHERE i.id = ANY(sqlc.arg(image_ids)::uuid[])
+  AND EXISTS (
+    SELECT 1 FROM legal_holds lh
+    WHERE lh.image_id = i.id OR lh.project_id = i.project_id
+  );
+
+-- name: ListLegalHolds :many
+SELECT * FROM legal_holds
+ORDER BY created_at DESC;
+
+-- name: PlaceImageLegalHold :one
+INSERT INTO legal_holds (image_id, reason, placed_by)
+VALUES ($1, $2, $3)
+ON CONFLICT (image_id) WHERE image_id IS NOT NULL
+DO UPDATE SET reason = EXCLUDED.reason
+RETURNING *;
+
+-- name: PlaceProjectLegalHold :one
+INSERT INTO legal_holds (project_id, reason, placed_by)
+VALUES ($1, $2, $3)
+ON CONFLICT (project_id) WHERE project_id IS NOT NULL
+DO UPDATE SET reason = EXCLUDED.reason
+RETURNING *;
+
+-- name: ReleaseImageLegalHold :execrows
+DELETE FROM legal_holds
+WHERE image_id = $1;
+
+-- name: ReleaseProjectLegalHold :execrows
+DELETE FROM legal_holds
+WHERE project_id = $1;
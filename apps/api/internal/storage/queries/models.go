@@ -14,10 +14,13 @@ import (
 type ImageStatus string
 
 const (
-	ImageStatusQueued     ImageStatus = "queued"
-	ImageStatusProcessing ImageStatus = "processing"
-	ImageStatusReady      ImageStatus = "ready"
-	ImageStatusError      ImageStatus = "error"
+	ImageStatusAwaitingUpload ImageStatus = "awaiting_upload"
+	ImageStatusQueued         ImageStatus = "queued"
+	ImageStatusProcessing     ImageStatus = "processing"
+	ImageStatusReady          ImageStatus = "ready"
+	ImageStatusError          ImageStatus = "error"
+	ImageStatusCanceled       ImageStatus = "canceled"
+	ImageStatusExpired        ImageStatus = "expired"
 )
 
 func (e *ImageStatus) Scan(src interface{}) error {
@@ -55,6 +58,122 @@ func (ns NullImageStatus) Value() (driver.Value, error) {
 	return string(ns.ImageStatus), nil
 }
 
+// Per-account logo watermark applied to exports and share-link renditions
+type AccountWatermark struct {
+	UserID  pgtype.UUID `json:"user_id"`
+	Enabled bool        `json:"enabled"`
+	// s3:// URL of the logo, one of the account's uploads
+	LogoUrl  string `json:"logo_url"`
+	Position string `json:"position"`
+	// Opacity of the logo from 0 to 1
+	Opacity float32 `json:"opacity"`
+	// Width of the logo as a fraction of the image width
+	Scale     float32            `json:"scale"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Keys that authenticate integrations as their owner, limited to their scopes
+type ApiKey struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+	Name   string      `json:"name"`
+	// Leading characters of the key, shown so owners can tell keys apart
+	Prefix string `json:"prefix"`
+	// SHA-256 of the key, which is only shown when issued
+	KeyHash []byte `json:"key_hash"`
+	// Granted scopes such as projects:read or images:*
+	Scopes     []string           `json:"scopes"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	LastUsedAt pgtype.Timestamptz `json:"last_used_at"`
+	// When the key stops working; NULL keeps it valid until revoked
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	RevokedAt pgtype.Timestamptz `json:"revoked_at"`
+	// Requests with the key act on the sandbox schema, which is reset nightly
+	Sandbox bool `json:"sandbox"`
+}
+
+// Furniture style packs selectable at staging time
+type Catalog struct {
+	ID          pgtype.UUID `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	// Appended to the staging prompt
+	PromptFragment string `json:"prompt_fragment"`
+	// Reference images passed to models that support image conditioning
+	ReferenceImageUrls []string `json:"reference_image_urls"`
+	// Inactive catalogs are hidden from users and cannot be picked
+	Active    bool               `json:"active"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Customer-owned S3 bucket, and the IAM role to reach it, per account
+type CustomerBucket struct {
+	UserID  pgtype.UUID `json:"user_id"`
+	Bucket  string      `json:"bucket"`
+	Region  string      `json:"region"`
+	RoleArn string      `json:"role_arn"`
+	// sts:ExternalId the role trust policy requires; generated by us and never changed
+	ExternalID string `json:"external_id"`
+	// Last passing access check; NULL keeps new uploads in the platform bucket
+	VerifiedAt pgtype.Timestamptz `json:"verified_at"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Append-only log of support reads of customer data through the admin API
+type DataAccessLog struct {
+	ID int64 `json:"id"`
+	// Customer whose data was read
+	OwnerID pgtype.UUID `json:"owner_id"`
+	// Authenticated admin who read it, taken from their token rather than the request
+	AccessorID   pgtype.UUID `json:"accessor_id"`
+	ResourceType string      `json:"resource_type"`
+	ResourceID   pgtype.UUID `json:"resource_id"`
+	// Support ticket the read was made for
+	Ticket string `json:"ticket"`
+	// Address the read was made from
+	Ip        string             `json:"ip"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+// Failed queue tasks with their payload and error history; dead once retries are exhausted
+type DeadLetterJob struct {
+	ID pgtype.UUID `json:"id"`
+	// Asynq task ID; stage:run tasks use their job row ID
+	TaskID   string `json:"task_id"`
+	TaskType string `json:"task_type"`
+	Queue    string `json:"queue"`
+	// Task payload as enqueued, still sealed when payload encryption is on
+	Payload []byte `json:"payload"`
+	// Image of the task, or the first image of a batch; null when the payload could not be read
+	ImageID  pgtype.UUID `json:"image_id"`
+	MaxRetry int32       `json:"max_retry"`
+	// JSON array of {attempt, error, failed_at}, oldest first
+	Errors        []byte             `json:"errors"`
+	FirstFailedAt pgtype.Timestamptz `json:"first_failed_at"`
+	LastFailedAt  pgtype.Timestamptz `json:"last_failed_at"`
+	// When the task failed its last attempt; cleared when it is requeued
+	DeadAt pgtype.Timestamptz `json:"dead_at"`
+	// When an admin last requeued the task
+	RequeuedAt   pgtype.Timestamptz `json:"requeued_at"`
+	RequeueCount int32              `json:"requeue_count"`
+}
+
+// Watermark-free project exports bought through a Stripe one-time checkout
+type ExportCredit struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+	// Stripe checkout session that paid for the credit; unique so webhook redeliveries add it once
+	CheckoutSessionID string `json:"checkout_session_id"`
+	// Project the credit unlocked; its later exports are watermark-free without another credit
+	ProjectID pgtype.UUID        `json:"project_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	// When the credit was spent on an export; stays set when the project is deleted
+	ConsumedAt pgtype.Timestamptz `json:"consumed_at"`
+}
+
 type Image struct {
 	ID          pgtype.UUID        `json:"id"`
 	ProjectID   pgtype.UUID        `json:"project_id"`
@@ -75,6 +194,99 @@ type Image struct {
 	ProcessingTimeMs pgtype.Int4 `json:"processing_time_ms"`
 	// Replicate prediction ID for tracking and billing
 	ReplicatePredictionID pgtype.Text `json:"replicate_prediction_id"`
+	// Size in bytes of the original upload; NULL until the worker reads it
+	OriginalSizeBytes pgtype.Int8 `json:"original_size_bytes"`
+	// Size in bytes of the staged output; NULL until the worker uploads it
+	StagedSizeBytes pgtype.Int8 `json:"staged_size_bytes"`
+	// Surface the image was created from: web, mobile, api or import; unknown before tracking
+	Source string `json:"source"`
+	// Low-resolution preview of the staged result, set while processing
+	PreviewUrl pgtype.Text `json:"preview_url"`
+	// Staged output with the account watermark, served to share links and exports
+	WatermarkedUrl pgtype.Text `json:"watermarked_url"`
+	// RAW upload the original was converted from; null for JPEG, PNG and WebP uploads
+	RawUrl pgtype.Text `json:"raw_url"`
+}
+
+// User-supplied room dimensions used to scale staged furniture
+type ImageAnnotation struct {
+	ImageID pgtype.UUID `json:"image_id"`
+	// Length in meters of the main wall facing the camera
+	WallLengthM pgtype.Float8 `json:"wall_length_m"`
+	// Floor-to-ceiling height in meters
+	CeilingHeightM pgtype.Float8      `json:"ceiling_height_m"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+// Bracketed exposures merged with the original into an HDR base image before staging
+type ImageBracket struct {
+	ImageID pgtype.UUID `json:"image_id"`
+	// Uploads of the other exposures of the shot, in the original's storage
+	Urls      []string           `json:"urls"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+// Quick edits of an image, run apart from staging jobs
+type ImageEdit struct {
+	ID      pgtype.UUID `json:"id"`
+	ImageID pgtype.UUID `json:"image_id"`
+	Kind    string      `json:"kind"`
+	// Which version of the image was edited: the original upload or the staged output
+	Base string `json:"base"`
+	// Mask upload; white pixels mark the area to erase
+	MaskUrl string `json:"mask_url"`
+	Status  string `json:"status"`
+	// Edited image, set once the edit is ready
+	ResultUrl pgtype.Text        `json:"result_url"`
+	Error     pgtype.Text        `json:"error"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Retention warning and purge state for original uploads
+type ImageOriginalPurge struct {
+	ImageID  pgtype.UUID        `json:"image_id"`
+	WarnedAt pgtype.Timestamptz `json:"warned_at"`
+	// Earliest time the original may be deleted, as communicated in the warning
+	PurgeAfter pgtype.Timestamptz `json:"purge_after"`
+	// When the original upload was deleted from storage
+	PurgedAt pgtype.Timestamptz `json:"purged_at"`
+}
+
+// Append-only log of image status changes
+type ImageStatusTransition struct {
+	ID        int64       `json:"id"`
+	ImageID   pgtype.UUID `json:"image_id"`
+	ProjectID pgtype.UUID `json:"project_id"`
+	// Status before the change; NULL when the image was created
+	FromStatus NullImageStatus `json:"from_status"`
+	ToStatus   ImageStatus     `json:"to_status"`
+	// The image error message at the time of the change, if any
+	Error     pgtype.Text        `json:"error"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	// What made the change, e.g. real-staging-api or real-staging-worker
+	Source string `json:"source"`
+	// Job whose attempt made the change, when a worker made it
+	JobID pgtype.Text `json:"job_id"`
+	// Attempt of job_id that made the change, starting at 1
+	Attempt pgtype.Int4 `json:"attempt"`
+}
+
+// Queued-to-ready time of each image, measured against its owner's plan SLA
+type ImageTurnaround struct {
+	ImageID pgtype.UUID `json:"image_id"`
+	UserID  pgtype.UUID `json:"user_id"`
+	// Plan of the owner when the image became ready; empty without an active plan
+	PlanCode string `json:"plan_code"`
+	// First time the image was queued; retries do not reset it
+	QueuedAt     pgtype.Timestamptz `json:"queued_at"`
+	ReadyAt      pgtype.Timestamptz `json:"ready_at"`
+	TurnaroundMs int64              `json:"turnaround_ms"`
+	// The plan's sla_target_seconds in milliseconds; NULL without an SLA
+	TargetMs pgtype.Int8 `json:"target_ms"`
+	// Whether turnaround_ms was within target_ms; NULL without an SLA
+	Met       pgtype.Bool        `json:"met"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
 type Invoice struct {
@@ -101,6 +313,180 @@ type Job struct {
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	StartedAt   pgtype.Timestamptz `json:"started_at"`
 	FinishedAt  pgtype.Timestamptz `json:"finished_at"`
+	// Provider prediction started for this job
+	PredictionID pgtype.Text `json:"prediction_id"`
+	// S3 key of the staged output once uploaded
+	OutputKey pgtype.Text `json:"output_key"`
+	// Provider model version that ran the prediction
+	ModelVersion pgtype.Text `json:"model_version"`
+	// Final provider prediction response, truncated
+	ProviderResponse pgtype.Text `json:"provider_response"`
+}
+
+// Sanitized, user-visible processing log for each stage job
+type JobLog struct {
+	JobID   pgtype.UUID `json:"job_id"`
+	ImageID pgtype.UUID `json:"image_id"`
+	// Newline-separated log lines, oldest first; only the most recent tail is kept
+	Log string `json:"log"`
+	// Whether older lines were dropped to keep the log under its size cap
+	Truncated bool               `json:"truncated"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Admin-placed holds that block deletion of a project or image
+type LegalHold struct {
+	ID        pgtype.UUID `json:"id"`
+	ProjectID pgtype.UUID `json:"project_id"`
+	ImageID   pgtype.UUID `json:"image_id"`
+	// Why the hold was placed, e.g. a dispute or case reference
+	Reason    string             `json:"reason"`
+	PlacedBy  pgtype.UUID        `json:"placed_by"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+// In-app notifications shown in a user's notification center
+type Notification struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+	Type   string      `json:"type"`
+	Title  string      `json:"title"`
+	Body   string      `json:"body"`
+	// Type-specific references for the client, such as image_id or project_id
+	Data []byte `json:"data"`
+	// Collapses repeats of the same event while an earlier one is still unread
+	DedupeKey pgtype.Text `json:"dedupe_key"`
+	// When the user marked the notification read; NULL while unread
+	ReadAt    pgtype.Timestamptz `json:"read_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+// Enterprise accounts that group users under one identity provider
+type Organization struct {
+	ID   pgtype.UUID `json:"id"`
+	Name string      `json:"name"`
+	// User whose subscription decides whether the organization may use SSO
+	OwnerID   pgtype.UUID        `json:"owner_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	// Canonical CIDR ranges members must connect from; empty allows any address
+	IpAllowlist []string `json:"ip_allowlist"`
+}
+
+// Append-only audit log of security events within an organization
+type OrganizationAuditLog struct {
+	ID             int64       `json:"id"`
+	OrganizationID pgtype.UUID `json:"organization_id"`
+	ActorID        pgtype.UUID `json:"actor_id"`
+	// Dotted event name, e.g. ip_allowlist.updated or break_glass.opened
+	EventType string `json:"event_type"`
+	Ip        string `json:"ip"`
+	// Event-specific details
+	Metadata  []byte             `json:"metadata"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+// Windows in which an admin bypasses the organization's IP allow-list
+type OrganizationBreakGlass struct {
+	ID             pgtype.UUID `json:"id"`
+	OrganizationID pgtype.UUID `json:"organization_id"`
+	UserID         pgtype.UUID `json:"user_id"`
+	Reason         string      `json:"reason"`
+	// Address the window was opened from
+	Ip        string             `json:"ip"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+type OrganizationMember struct {
+	OrganizationID pgtype.UUID `json:"organization_id"`
+	UserID         pgtype.UUID `json:"user_id"`
+	Role           string      `json:"role"`
+	// manual rows keep their role; sso rows take the mapped role at every login; scim rows are managed by the SCIM client
+	Source    string             `json:"source"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Groups an organization's identity provider pushed over SCIM
+type OrganizationScimGroup struct {
+	ID             pgtype.UUID `json:"id"`
+	OrganizationID pgtype.UUID `json:"organization_id"`
+	// Matched against the SSO role mappings to pick the role of the group's members
+	DisplayName string             `json:"display_name"`
+	ExternalID  pgtype.Text        `json:"external_id"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+type OrganizationScimGroupMember struct {
+	GroupID    pgtype.UUID `json:"group_id"`
+	ScimUserID pgtype.UUID `json:"scim_user_id"`
+}
+
+// Bearer token an organization's SCIM client authenticates with; one per organization
+type OrganizationScimToken struct {
+	OrganizationID pgtype.UUID `json:"organization_id"`
+	// SHA-256 of the token, which is only shown when issued
+	TokenHash  []byte             `json:"token_hash"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	LastUsedAt pgtype.Timestamptz `json:"last_used_at"`
+}
+
+// Users an organization's identity provider provisioned over SCIM
+type OrganizationScimUser struct {
+	ID             pgtype.UUID `json:"id"`
+	OrganizationID pgtype.UUID `json:"organization_id"`
+	// Backing account; its subject is scim|<id> until the first SSO sign-in claims it
+	UserID pgtype.UUID `json:"user_id"`
+	// Unique per organization, case-insensitively; matched against the email of SSO sign-ins
+	UserName    string      `json:"user_name"`
+	ExternalID  pgtype.Text `json:"external_id"`
+	DisplayName string      `json:"display_name"`
+	GivenName   string      `json:"given_name"`
+	FamilyName  string      `json:"family_name"`
+	Email       string      `json:"email"`
+	// Inactive users keep their record but are not organization members
+	Active    bool               `json:"active"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Identity provider an organization signs in with, federated through an Auth0 enterprise connection
+type OrganizationSso struct {
+	OrganizationID pgtype.UUID `json:"organization_id"`
+	Protocol       string      `json:"protocol"`
+	// Auth0 enterprise connection name; tokens carry it in the connection claim
+	Connection      string      `json:"connection"`
+	OidcIssuer      pgtype.Text `json:"oidc_issuer"`
+	OidcClientID    pgtype.Text `json:"oidc_client_id"`
+	SamlMetadataUrl pgtype.Text `json:"saml_metadata_url"`
+	// Lowercase email domains routed to the connection at login
+	EmailDomains []string `json:"email_domains"`
+	// Identity provider claim, e.g. groups, whose values role_mappings maps to roles
+	RoleClaim string `json:"role_claim"`
+	// Claim value to organization role; unmatched users get default_role
+	RoleMappings []byte             `json:"role_mappings"`
+	DefaultRole  string             `json:"default_role"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Stripe subscription and invoice updates waiting for their customer to be linked to a user
+type PendingBillingEvent struct {
+	// Stripe subscription or invoice ID; later events replace the parked state
+	StripeObjectID   string `json:"stripe_object_id"`
+	ObjectType       string `json:"object_type"`
+	EventType        string `json:"event_type"`
+	StripeCustomerID string `json:"stripe_customer_id"`
+	// Blind index of stripe_customer_id, matched against users when field encryption is active
+	StripeCustomerIDHash pgtype.Text `json:"stripe_customer_id_hash"`
+	Data                 []byte      `json:"data"`
+	// Worker runs that could not map the customer yet
+	Attempts      int32              `json:"attempts"`
+	LastAttemptAt pgtype.Timestamptz `json:"last_attempt_at"`
+	ExpiresAt     pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
 }
 
 type Plan struct {
@@ -108,6 +494,37 @@ type Plan struct {
 	Code         string      `json:"code"`
 	PriceID      string      `json:"price_id"`
 	MonthlyLimit int32       `json:"monthly_limit"`
+	// Days to keep original uploads; NULL keeps them forever
+	OriginalRetentionDays pgtype.Int4 `json:"original_retention_days"`
+	// Whether subscribers may attach a reference inspiration photo when staging
+	ReferenceImages bool `json:"reference_images"`
+	// Whether subscribers may move a queued image to the priority queue
+	Expedite bool `json:"expedite"`
+	// Whether subscribers may store images in their own S3 bucket
+	CustomerBucket bool `json:"customer_bucket"`
+	// Queued-to-ready target; NULL measures no SLA
+	SlaTargetSeconds pgtype.Int4 `json:"sla_target_seconds"`
+	// Share of images, in percent, that must meet sla_target_seconds
+	SlaTargetPercent pgtype.Numeric `json:"sla_target_percent"`
+	// Whether subscribers are credited an image when one misses the target
+	SlaCredit bool `json:"sla_credit"`
+	// Whether subscribers may configure single sign-on for their organizations
+	Sso bool `json:"sso"`
+}
+
+type Preset struct {
+	ID       pgtype.UUID `json:"id"`
+	UserID   pgtype.UUID `json:"user_id"`
+	Name     string      `json:"name"`
+	RoomType pgtype.Text `json:"room_type"`
+	Style    pgtype.Text `json:"style"`
+	Seed     pgtype.Int8 `json:"seed"`
+	// Furniture catalog to stage with; cleared when the catalog is deleted
+	CatalogID pgtype.UUID `json:"catalog_id"`
+	// Extra instructions appended to the staging prompt
+	Prompt    string             `json:"prompt"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 }
 
 type ProcessedEvent struct {
@@ -125,6 +542,163 @@ type Project struct {
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
+// Append-only audit log of events that happened within a project
+type ProjectActivity struct {
+	ID        int64       `json:"id"`
+	ProjectID pgtype.UUID `json:"project_id"`
+	ImageID   pgtype.UUID `json:"image_id"`
+	// Dotted event name, e.g. image.created or image.status_changed
+	EventType string `json:"event_type"`
+	// Event-specific details
+	Metadata  []byte             `json:"metadata"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+// Users with access to a project they do not own
+type ProjectCollaborator struct {
+	ProjectID pgtype.UUID `json:"project_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+	Role      string      `json:"role"`
+	// Invitation that granted the access; revoking it removes the row
+	InvitationID pgtype.UUID        `json:"invitation_id"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+// Confirmation tokens for deleting projects with staged images
+type ProjectDeletionIntent struct {
+	ProjectID pgtype.UUID `json:"project_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+	// SHA-256 of the confirmation token returned to the user
+	TokenHash []byte             `json:"token_hash"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+// Projects excluded from retention purging
+// Per-project disclosure banner rendered onto staged images
+type ProjectDisclosure struct {
+	ProjectID pgtype.UUID `json:"project_id"`
+	Enabled   bool        `json:"enabled"`
+	// Language of the default banner text, e.g. en or es
+	Locale string `json:"locale"`
+	// Custom banner text; NULL uses the default text for the locale
+	Text     pgtype.Text `json:"text"`
+	Position string      `json:"position"`
+	// Opacity of the banner background from 0 to 1; text is always opaque
+	Opacity   float32            `json:"opacity"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Zips of a project's staged photos, built by the worker
+type ProjectExport struct {
+	ID        pgtype.UUID `json:"id"`
+	ProjectID pgtype.UUID `json:"project_id"`
+	// Member who requested the export
+	UserID pgtype.UUID `json:"user_id"`
+	Status string      `json:"status"`
+	// Whether the worker stamps the platform mark on every photo
+	Watermarked bool `json:"watermarked"`
+	// Export credit that unlocked the project, when the export is watermark-free through one
+	CreditID pgtype.UUID `json:"credit_id"`
+	// s3:// URL of the zip once ready
+	ResultUrl  pgtype.Text        `json:"result_url"`
+	ImageCount int32              `json:"image_count"`
+	Error      pgtype.Text        `json:"error"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Email invitations to collaborate on a single project
+type ProjectInvitation struct {
+	ID        pgtype.UUID `json:"id"`
+	ProjectID pgtype.UUID `json:"project_id"`
+	InvitedBy pgtype.UUID `json:"invited_by"`
+	Email     string      `json:"email"`
+	// Access granted on acceptance: viewer or editor
+	Role string `json:"role"`
+	// pending until accepted or revoked; expiry is checked against expires_at
+	Status     string             `json:"status"`
+	AcceptedBy pgtype.UUID        `json:"accepted_by"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	AcceptedAt pgtype.Timestamptz `json:"accepted_at"`
+	RevokedAt  pgtype.Timestamptz `json:"revoked_at"`
+}
+
+// Listing address and MLS details of the property a project stages
+type ProjectListing struct {
+	ProjectID    pgtype.UUID `json:"project_id"`
+	AddressLine1 string      `json:"address_line1"`
+	AddressLine2 string      `json:"address_line2"`
+	City         string      `json:"city"`
+	// State, province or county
+	Region     string `json:"region"`
+	PostalCode string `json:"postal_code"`
+	// ISO 3166-1 alpha-2 country code; empty without an address
+	Country string `json:"country"`
+	// Listing number in the multiple listing service
+	MlsNumber  string `json:"mls_number"`
+	ListingUrl string `json:"listing_url"`
+	// Geocoded from the address; NULL until geocoding succeeds
+	Latitude  pgtype.Float8 `json:"latitude"`
+	Longitude pgtype.Float8 `json:"longitude"`
+	// When latitude and longitude were last geocoded
+	GeocodedAt pgtype.Timestamptz `json:"geocoded_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
+type ProjectRetentionExemption struct {
+	ProjectID pgtype.UUID        `json:"project_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+// Room checklist of a project, copied from its template or set directly
+type ProjectRoom struct {
+	ProjectID pgtype.UUID `json:"project_id"`
+	Position  int32       `json:"position"`
+	RoomType  string      `json:"room_type"`
+	// Style new images of the room type default to; NULL leaves it to the request
+	Style pgtype.Text `json:"style"`
+	Label string      `json:"label"`
+}
+
+// Current share link signing key version per project
+type ProjectShareKey struct {
+	ProjectID pgtype.UUID `json:"project_id"`
+	// Links signed with any other version are rejected
+	Version   int32              `json:"version"`
+	RotatedAt pgtype.Timestamptz `json:"rotated_at"`
+}
+
+// Saved project settings and room checklists that new projects can start from
+type ProjectTemplate struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+	Name   string      `json:"name"`
+	// Disclosure banner settings copied to new projects; NULL leaves the defaults
+	Disclosure []byte `json:"disclosure"`
+	// Room checklist as a JSON array of {room_type, style, label}
+	Rooms     []byte             `json:"rooms"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Proposed groupings of a project's images into rooms
+type RoomGrouping struct {
+	ID        pgtype.UUID `json:"id"`
+	ProjectID pgtype.UUID `json:"project_id"`
+	Status    string      `json:"status"`
+	// JSON array of {room_type, label, image_ids}; room_type is null when no image of the group had one
+	Groups []byte `json:"groups"`
+	// Images the worker could not read, left out of every group
+	UnreadableImageIds []pgtype.UUID      `json:"unreadable_image_ids"`
+	Error              pgtype.Text        `json:"error"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+	// When the owner applied the groups to the project
+	ConfirmedAt pgtype.Timestamptz `json:"confirmed_at"`
+}
+
 // System-wide configuration settings
 type Setting struct {
 	// Unique setting identifier
@@ -139,6 +713,36 @@ type Setting struct {
 	UpdatedBy pgtype.UUID `json:"updated_by"`
 }
 
+// Per-account branding and custom domain of public share pages
+type ShareBranding struct {
+	UserID       pgtype.UUID `json:"user_id"`
+	PrimaryColor string      `json:"primary_color"`
+	AccentColor  string      `json:"accent_color"`
+	// s3:// URL of the logo, one of the account's uploads
+	LogoUrl pgtype.Text `json:"logo_url"`
+	// Host share pages are served on; CNAMEs to the configured share domain target
+	CustomDomain pgtype.Text `json:"custom_domain"`
+	// Value the domain's verification TXT record must carry
+	DomainToken pgtype.Text `json:"domain_token"`
+	// When control of custom_domain was proved; NULL while pending
+	DomainVerifiedAt pgtype.Timestamptz `json:"domain_verified_at"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Images credited to users because one of theirs missed its plan's turnaround SLA
+type SlaCredit struct {
+	ID       pgtype.UUID `json:"id"`
+	UserID   pgtype.UUID `json:"user_id"`
+	ImageID  pgtype.UUID `json:"image_id"`
+	PlanCode string      `json:"plan_code"`
+	// Number of images credited
+	Images       int32              `json:"images"`
+	TurnaroundMs int64              `json:"turnaround_ms"`
+	TargetMs     int64              `json:"target_ms"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
 type Subscription struct {
 	ID                   pgtype.UUID        `json:"id"`
 	UserID               pgtype.UUID        `json:"user_id"`
@@ -154,6 +758,54 @@ type Subscription struct {
 	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
 }
 
+// Slack and Teams incoming webhooks that receive an account's team notifications
+type TeamWebhook struct {
+	ID       pgtype.UUID `json:"id"`
+	UserID   pgtype.UUID `json:"user_id"`
+	Provider string      `json:"provider"`
+	Url      string      `json:"url"`
+	// Events posted to the webhook: batch_complete, payment_failed
+	Events         []string           `json:"events"`
+	LastDeliveryAt pgtype.Timestamptz `json:"last_delivery_at"`
+	// Why the last delivery failed; NULL after a successful one
+	LastDeliveryError pgtype.Text        `json:"last_delivery_error"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+	// Secret deliveries are signed with, Standard Webhooks format
+	SigningSecret string `json:"signing_secret"`
+	// Secret replaced by the last rotation; also signs deliveries until previous_secret_expires_at
+	PreviousSigningSecret   pgtype.Text        `json:"previous_signing_secret"`
+	PreviousSecretExpiresAt pgtype.Timestamptz `json:"previous_secret_expires_at"`
+}
+
+// Messages posted to team webhooks, retried with exponential backoff for 24 hours
+type TeamWebhookDelivery struct {
+	ID        pgtype.UUID `json:"id"`
+	WebhookID pgtype.UUID `json:"webhook_id"`
+	Event     string      `json:"event"`
+	// Request body in the provider's format, as posted on every attempt
+	Payload []byte `json:"payload"`
+	// pending until an attempt succeeds (delivered) or the retry window ends (failed)
+	Status   string `json:"status"`
+	Attempts int32  `json:"attempts"`
+	// When a pending delivery is next tried
+	NextAttemptAt pgtype.Timestamptz `json:"next_attempt_at"`
+	LastAttemptAt pgtype.Timestamptz `json:"last_attempt_at"`
+	LastError     pgtype.Text        `json:"last_error"`
+	DeliveredAt   pgtype.Timestamptz `json:"delivered_at"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
+// Staged images created per user per billing period, checked against the plan monthly_limit
+type UsageCounter struct {
+	UserID pgtype.UUID `json:"user_id"`
+	// Start of the subscription period the count covers, or of the UTC calendar month without a subscription
+	PeriodStart pgtype.Timestamptz `json:"period_start"`
+	// Images created for staging in the period, including ones later canceled or deleted
+	StagedImages int32              `json:"staged_images"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+}
+
 type User struct {
 	ID               pgtype.UUID        `json:"id"`
 	Auth0Sub         string             `json:"auth0_sub"`
@@ -168,4 +820,8 @@ type User struct {
 	ProfilePhotoUrl  pgtype.Text        `json:"profile_photo_url"`
 	Preferences      []byte             `json:"preferences"`
 	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	// HMAC-SHA256 blind index of stripe_customer_id, used for lookups
+	StripeCustomerIDHash pgtype.Text `json:"stripe_customer_id_hash"`
+	// Region whose bucket new images are stored in; NULL uses the home bucket
+	StorageRegion pgtype.Text `json:"storage_region"`
 }
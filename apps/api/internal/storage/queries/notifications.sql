@@ -0,0 +1,34 @@
+-- Inserts a notification unless an unread one with the same dedupe key exists, in which
+-- case no row is returned.
+-- name: CreateNotification :one
+INSERT INTO notifications (user_id, type, title, body, data, dedupe_key)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (user_id, dedupe_key) WHERE read_at IS NULL AND dedupe_key IS NOT NULL DO NOTHING
+RETURNING id, user_id, type, title, body, data, dedupe_key, read_at, created_at;
+
+-- Lists a user's notifications newest first, optionally only the unread ones.
+-- name: ListNotifications :many
+SELECT id, user_id, type, title, body, data, dedupe_key, read_at, created_at
+FROM notifications
+WHERE user_id = sqlc.arg('user_id')
+  AND (NOT sqlc.arg('unread_only')::boolean OR read_at IS NULL)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountUnreadNotifications :one
+SELECT COUNT(*)::bigint AS count
+FROM notifications
+WHERE user_id = $1 AND read_at IS NULL;
+
+-- Marks one of the user's notifications read. Reading an already read notification keeps
+-- its original read_at. Returns no row when the notification is not the user's.
+-- name: MarkNotificationRead :one
+UPDATE notifications
+SET read_at = COALESCE(read_at, now())
+WHERE id = $1 AND user_id = $2
+RETURNING id, user_id, type, title, body, data, dedupe_key, read_at, created_at;
+
+-- name: MarkAllNotificationsRead :execrows
+UPDATE notifications
+SET read_at = now()
+WHERE user_id = $1 AND read_at IS NULL;
@@ -0,0 +1,42 @@
+-- name: GetOrganizationIPAllowlist :one
+SELECT ip_allowlist
+FROM organizations
+WHERE id = $1;
+
+-- name: SetOrganizationIPAllowlist :one
+UPDATE organizations
+SET ip_allowlist = $2
+WHERE id = $1
+RETURNING ip_allowlist;
+
+-- Lists the allow-lists the user behind a subject must satisfy: those of every organization
+-- they belong to that has one, each with the user's open break-glass window, if any.
+-- name: ListIPAllowlistsForSubject :many
+SELECT o.id AS organization_id, m.user_id, o.ip_allowlist, bg.id AS break_glass_id
+FROM users u
+JOIN organization_members m ON m.user_id = u.id
+JOIN organizations o ON o.id = m.organization_id
+LEFT JOIN LATERAL (
+  SELECT b.id
+  FROM organization_break_glass b
+  WHERE b.organization_id = o.id AND b.user_id = u.id AND b.expires_at > now()
+  ORDER BY b.expires_at DESC
+  LIMIT 1
+) bg ON true
+WHERE u.auth0_sub = $1 AND cardinality(o.ip_allowlist) > 0;
+
+-- name: CreateOrganizationBreakGlass :one
+INSERT INTO organization_break_glass (organization_id, user_id, reason, ip, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, organization_id, user_id, reason, ip, created_at, expires_at;
+
+-- name: RecordOrganizationAuditEvent :exec
+INSERT INTO organization_audit_log (organization_id, actor_id, event_type, ip, metadata)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListOrganizationAuditLog :many
+SELECT id, organization_id, actor_id, event_type, ip, metadata, created_at
+FROM organization_audit_log
+WHERE organization_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3;
@@ -0,0 +1,189 @@
+-- name: UpsertOrganizationSCIMToken :one
+INSERT INTO organization_scim_tokens (organization_id, token_hash)
+VALUES ($1, $2)
+ON CONFLICT (organization_id) DO UPDATE SET
+  token_hash = EXCLUDED.token_hash,
+  created_at = now(),
+  last_used_at = NULL
+RETURNING organization_id, token_hash, created_at, last_used_at;
+
+-- name: DeleteOrganizationSCIMToken :execrows
+DELETE FROM organization_scim_tokens
+WHERE organization_id = $1;
+
+-- Resolves a SCIM bearer token to its organization and records its use.
+-- name: TouchOrganizationSCIMToken :one
+UPDATE organization_scim_tokens
+SET last_used_at = now()
+WHERE token_hash = $1
+RETURNING organization_id;
+
+-- Creates a SCIM user together with the account backing it. The account's subject is a
+-- placeholder until the user's first SSO sign-in claims it.
+-- name: CreateSCIMUser :one
+WITH account AS (
+  INSERT INTO users (auth0_sub)
+  VALUES ('scim|' || sqlc.arg(id)::uuid::text)
+  RETURNING id
+)
+INSERT INTO organization_scim_users (
+  id, organization_id, user_id, user_name, external_id, display_name, given_name, family_name, email, active
+)
+SELECT sqlc.arg(id)::uuid, sqlc.arg(organization_id)::uuid, account.id, sqlc.arg(user_name)::text,
+       sqlc.narg(external_id)::text, sqlc.arg(display_name)::text, sqlc.arg(given_name)::text,
+       sqlc.arg(family_name)::text, sqlc.arg(email)::text, sqlc.arg(active)::boolean
+FROM account
+RETURNING id, organization_id, user_id, user_name, external_id, display_name, given_name, family_name, email, active,
+          created_at, updated_at;
+
+-- name: GetSCIMUser :one
+SELECT id, organization_id, user_id, user_name, external_id, display_name, given_name, family_name, email, active,
+       created_at, updated_at
+FROM organization_scim_users
+WHERE organization_id = $1 AND id = $2;
+
+-- Lists the organization's SCIM users, optionally filtered by user name (case-insensitively)
+-- or external ID.
+-- name: ListSCIMUsers :many
+SELECT id, organization_id, user_id, user_name, external_id, display_name, given_name, family_name, email, active,
+       created_at, updated_at
+FROM organization_scim_users
+WHERE organization_id = sqlc.arg(organization_id)
+  AND (sqlc.narg('user_name')::text IS NULL OR lower(user_name) = lower(sqlc.narg('user_name')))
+  AND (sqlc.narg('external_id')::text IS NULL OR external_id = sqlc.narg('external_id'))
+ORDER BY created_at, id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountSCIMUsers :one
+SELECT COUNT(*)
+FROM organization_scim_users
+WHERE organization_id = sqlc.arg(organization_id)
+  AND (sqlc.narg('user_name')::text IS NULL OR lower(user_name) = lower(sqlc.narg('user_name')))
+  AND (sqlc.narg('external_id')::text IS NULL OR external_id = sqlc.narg('external_id'));
+
+-- name: UpdateSCIMUser :one
+UPDATE organization_scim_users
+SET user_name = sqlc.arg(user_name),
+    external_id = sqlc.narg(external_id),
+    display_name = sqlc.arg(display_name),
+    given_name = sqlc.arg(given_name),
+    family_name = sqlc.arg(family_name),
+    email = sqlc.arg(email),
+    active = sqlc.arg(active),
+    updated_at = now()
+WHERE organization_id = sqlc.arg(organization_id) AND id = sqlc.arg(id)
+RETURNING id, organization_id, user_id, user_name, external_id, display_name, given_name, family_name, email, active,
+          created_at, updated_at;
+
+-- Deletes a SCIM user and their SCIM membership. The backing account is deleted too when
+-- the user never signed in.
+-- name: DeleteSCIMUser :one
+WITH deleted AS (
+  DELETE FROM organization_scim_users
+  WHERE organization_scim_users.organization_id = $1 AND organization_scim_users.id = $2
+  RETURNING id, organization_id, user_id
+), membership AS (
+  DELETE FROM organization_members m
+  USING deleted d
+  WHERE m.organization_id = d.organization_id AND m.user_id = d.user_id AND m.source = 'scim'
+), account AS (
+  DELETE FROM users u
+  USING deleted d
+  WHERE u.id = d.user_id AND u.auth0_sub = 'scim|' || d.id::text
+)
+SELECT COUNT(*)
+FROM deleted;
+
+-- Gives the SCIM user whose user name is the email of an SSO sign-in the signed-in subject,
+-- unless that subject already has an account. Only placeholder subjects are replaced.
+-- name: ClaimSCIMUser :execrows
+UPDATE users u
+SET auth0_sub = sqlc.arg(auth0_sub)
+FROM organization_scim_users s
+JOIN organization_sso o ON o.organization_id = s.organization_id
+WHERE o.connection = sqlc.arg(connection)
+  AND lower(s.user_name) = lower(sqlc.arg(email)::text)
+  AND u.id = s.user_id
+  AND u.auth0_sub = 'scim|' || s.id::text
+  AND NOT EXISTS (SELECT 1 FROM users taken WHERE taken.auth0_sub = sqlc.arg(auth0_sub));
+
+-- Makes an active SCIM user a member with role. Memberships added any other way are taken
+-- over by the SCIM client.
+-- name: UpsertOrganizationSCIMMember :exec
+INSERT INTO organization_members (organization_id, user_id, role, source)
+VALUES ($1, $2, $3, 'scim')
+ON CONFLICT (organization_id, user_id) DO UPDATE SET
+  role = EXCLUDED.role,
+  source = 'scim',
+  updated_at = now();
+
+-- name: DeleteOrganizationSCIMMember :exec
+DELETE FROM organization_members
+WHERE organization_id = $1 AND user_id = $2 AND source = 'scim';
+
+-- name: ListSCIMUserGroupNames :many
+SELECT g.display_name
+FROM organization_scim_group_members gm
+JOIN organization_scim_groups g ON g.id = gm.group_id
+WHERE gm.scim_user_id = $1
+ORDER BY g.display_name;
+
+-- name: CreateSCIMGroup :one
+INSERT INTO organization_scim_groups (organization_id, display_name, external_id)
+VALUES ($1, $2, $3)
+RETURNING id, organization_id, display_name, external_id, created_at, updated_at;
+
+-- name: GetSCIMGroup :one
+SELECT id, organization_id, display_name, external_id, created_at, updated_at
+FROM organization_scim_groups
+WHERE organization_id = $1 AND id = $2;
+
+-- Lists the organization's SCIM groups, optionally filtered by display name
+-- (case-insensitively) or external ID.
+-- name: ListSCIMGroups :many
+SELECT id, organization_id, display_name, external_id, created_at, updated_at
+FROM organization_scim_groups
+WHERE organization_id = sqlc.arg(organization_id)
+  AND (sqlc.narg('display_name')::text IS NULL OR lower(display_name) = lower(sqlc.narg('display_name')))
+  AND (sqlc.narg('external_id')::text IS NULL OR external_id = sqlc.narg('external_id'))
+ORDER BY created_at, id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountSCIMGroups :one
+SELECT COUNT(*)
+FROM organization_scim_groups
+WHERE organization_id = sqlc.arg(organization_id)
+  AND (sqlc.narg('display_name')::text IS NULL OR lower(display_name) = lower(sqlc.narg('display_name')))
+  AND (sqlc.narg('external_id')::text IS NULL OR external_id = sqlc.narg('external_id'));
+
+-- name: UpdateSCIMGroup :one
+UPDATE organization_scim_groups
+SET display_name = $3,
+    external_id = $4,
+    updated_at = now()
+WHERE organization_id = $1 AND id = $2
+RETURNING id, organization_id, display_name, external_id, created_at, updated_at;
+
+-- name: DeleteSCIMGroup :execrows
+DELETE FROM organization_scim_groups
+WHERE organization_id = $1 AND id = $2;
+
+-- name: ListSCIMGroupMembers :many
+SELECT gm.group_id, s.id, s.user_id, s.user_name, s.display_name
+FROM organization_scim_group_members gm
+JOIN organization_scim_users s ON s.id = gm.scim_user_id
+WHERE gm.group_id = ANY(sqlc.arg(group_ids)::uuid[])
+ORDER BY s.user_name;
+
+-- Adds the organization's SCIM users among scim_user_ids to the group; IDs of other
+-- organizations' users are ignored.
+-- name: AddSCIMGroupMembers :execrows
+INSERT INTO organization_scim_group_members (group_id, scim_user_id)
+SELECT sqlc.arg(group_id)::uuid, s.id
+FROM organization_scim_users s
+WHERE s.organization_id = sqlc.arg(organization_id) AND s.id = ANY(sqlc.arg(scim_user_ids)::uuid[])
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveSCIMGroupMembers :execrows
+DELETE FROM organization_scim_group_members
+WHERE group_id = sqlc.arg(group_id) AND scim_user_id = ANY(sqlc.arg(scim_user_ids)::uuid[]);
@@ -0,0 +1,111 @@
+-- Creates an organization and makes its owner an admin in one statement.
+-- name: CreateOrganization :one
+WITH org AS (
+  INSERT INTO organizations (name, owner_id)
+  VALUES ($1, $2)
+  RETURNING id, name, owner_id, created_at
+), owner AS (
+  INSERT INTO organization_members (organization_id, user_id, role)
+  SELECT id, owner_id, 'admin'
+  FROM org
+)
+SELECT id, name, owner_id, created_at
+FROM org;
+
+-- name: ListOrganizationsByUser :many
+SELECT o.id, o.name, o.owner_id, o.created_at, m.role
+FROM organization_members m
+JOIN organizations o ON o.id = m.organization_id
+WHERE m.user_id = $1
+ORDER BY o.created_at;
+
+-- name: GetOrganizationMember :one
+SELECT organization_id, user_id, role, source, created_at, updated_at
+FROM organization_members
+WHERE organization_id = $1 AND user_id = $2;
+
+-- name: ListOrganizationMembers :many
+SELECT organization_id, user_id, role, source, created_at, updated_at
+FROM organization_members
+WHERE organization_id = $1
+ORDER BY created_at;
+
+-- Adds a user signing in through the organization's connection, or moves an SSO-provisioned
+-- member to the role their claims now map to. Members added by hand or by SCIM keep their
+-- role, and users of the organization's SCIM client are never added; no row is returned for
+-- either.
+-- name: UpsertOrganizationSSOMember :one
+INSERT INTO organization_members (organization_id, user_id, role, source)
+SELECT sqlc.arg(organization_id)::uuid, sqlc.arg(user_id)::uuid, sqlc.arg(role)::text, 'sso'
+WHERE NOT EXISTS (
+  SELECT 1
+  FROM organization_scim_users
+  WHERE organization_id = sqlc.arg(organization_id) AND user_id = sqlc.arg(user_id)
+)
+ON CONFLICT (organization_id, user_id) DO UPDATE SET
+  role = EXCLUDED.role,
+  updated_at = now()
+WHERE organization_members.source = 'sso'
+RETURNING organization_id, user_id, role, source, created_at, updated_at;
+
+-- name: GetOrganizationSSO :one
+SELECT organization_id, protocol, connection, oidc_issuer, oidc_client_id, saml_metadata_url, email_domains,
+       role_claim, role_mappings, default_role, created_at, updated_at
+FROM organization_sso
+WHERE organization_id = $1;
+
+-- name: GetOrganizationSSOByConnection :one
+SELECT organization_id, protocol, connection, oidc_issuer, oidc_client_id, saml_metadata_url, email_domains,
+       role_claim, role_mappings, default_role, created_at, updated_at
+FROM organization_sso
+WHERE connection = $1;
+
+-- name: GetOrganizationSSOByEmailDomain :one
+SELECT organization_id, protocol, connection, oidc_issuer, oidc_client_id, saml_metadata_url, email_domains,
+       role_claim, role_mappings, default_role, created_at, updated_at
+FROM organization_sso
+WHERE email_domains @> ARRAY[sqlc.arg(domain)::text];
+
+-- Reports whether another organization already routes any of the email domains.
+-- name: OrganizationSSODomainsTaken :one
+SELECT EXISTS (
+  SELECT 1
+  FROM organization_sso
+  WHERE organization_id <> $1 AND email_domains && sqlc.arg(domains)::text[]
+) AS taken;
+
+-- name: UpsertOrganizationSSO :one
+INSERT INTO organization_sso (
+  organization_id, protocol, connection, oidc_issuer, oidc_client_id, saml_metadata_url, email_domains,
+  role_claim, role_mappings, default_role
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (organization_id) DO UPDATE SET
+  protocol = EXCLUDED.protocol,
+  connection = EXCLUDED.connection,
+  oidc_issuer = EXCLUDED.oidc_issuer,
+  oidc_client_id = EXCLUDED.oidc_client_id,
+  saml_metadata_url = EXCLUDED.saml_metadata_url,
+  email_domains = EXCLUDED.email_domains,
+  role_claim = EXCLUDED.role_claim,
+  role_mappings = EXCLUDED.role_mappings,
+  default_role = EXCLUDED.default_role,
+  updated_at = now()
+RETURNING organization_id, protocol, connection, oidc_issuer, oidc_client_id, saml_metadata_url, email_domains,
+          role_claim, role_mappings, default_role, created_at, updated_at;
+
+-- name: DeleteOrganizationSSO :execrows
+DELETE FROM organization_sso
+WHERE organization_id = $1;
+
+-- Returns whether the organization owner's active plan includes single sign-on.
+-- name: GetOrganizationSSOAccess :one
+SELECT EXISTS (
+  SELECT 1
+  FROM organizations o
+  JOIN subscriptions s ON s.user_id = o.owner_id
+  JOIN plans pl ON pl.price_id = s.price_id
+  WHERE o.id = $1
+    AND s.status IN ('active', 'trialing', 'past_due')
+    AND pl.sso
+) AS allowed;
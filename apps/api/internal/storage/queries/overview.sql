@@ -0,0 +1,29 @@
+-- name: CountJobsByStatus :many
+-- Counts every job per status.
+SELECT status, COUNT(*)::bigint AS total
+FROM jobs
+GROUP BY status
+ORDER BY status;
+
+-- name: GetJobOutcomesSince :one
+-- Counts the finished jobs created at or after since, by outcome.
+SELECT
+  COUNT(*) FILTER (WHERE status = 'completed')::bigint AS completed,
+  COUNT(*) FILTER (WHERE status = 'failed')::bigint AS failed
+FROM jobs
+WHERE created_at >= @since;
+
+-- name: CountActiveUsersSince :one
+-- Counts the users who created an image at or after since.
+SELECT COUNT(DISTINCT p.user_id)::bigint AS total
+FROM images i
+JOIN projects p ON p.id = i.project_id
+WHERE i.created_at >= @since;
+
+-- name: SumPaidInvoicesSince :many
+-- Totals the amount paid, in cents, per currency on paid invoices created at or after since.
+SELECT COALESCE(currency, '')::text AS currency, SUM(amount_paid)::bigint AS amount_paid
+FROM invoices
+WHERE status = 'paid' AND created_at >= @since
+GROUP BY 1
+ORDER BY 1;
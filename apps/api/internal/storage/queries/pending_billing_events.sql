@@ -0,0 +1,13 @@
+-- name: UpsertPendingBillingEvent :exec
+-- Parks the latest state of a subscription or invoice whose customer is not linked yet.
+-- A later event replaces the state but keeps the original expiry.
+INSERT INTO pending_billing_events (
+  stripe_object_id, object_type, event_type, stripe_customer_id, stripe_customer_id_hash, data, expires_at
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (stripe_object_id) DO UPDATE
+SET event_type = EXCLUDED.event_type,
+    stripe_customer_id = EXCLUDED.stripe_customer_id,
+    stripe_customer_id_hash = EXCLUDED.stripe_customer_id_hash,
+    data = EXCLUDED.data,
+    updated_at = now();
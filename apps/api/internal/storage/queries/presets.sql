@@ -0,0 +1,31 @@
+-- name: CreatePreset :one
+INSERT INTO presets (user_id, name, room_type, style, seed, catalog_id, prompt)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: DeletePreset :execrows
+DELETE FROM presets
+WHERE id = $1 AND user_id = $2;
+
+-- Resolves a preset for an image being created, only if it belongs to the project's owner.
+-- name: GetPresetForProject :one
+SELECT p.* FROM presets p
+JOIN projects pr ON pr.user_id = p.user_id
+WHERE p.id = $1 AND pr.id = sqlc.arg(project_id);
+
+-- name: ListPresetsByUser :many
+SELECT * FROM presets
+WHERE user_id = $1
+ORDER BY name;
+
+-- name: UpdatePreset :one
+UPDATE presets SET
+  name = $3,
+  room_type = $4,
+  style = $5,
+  seed = $6,
+  catalog_id = $7,
+  prompt = $8,
+  updated_at = now()
+WHERE id = $1 AND user_id = $2
+RETURNING *;
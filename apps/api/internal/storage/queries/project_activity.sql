@@ -0,0 +1,12 @@
+-- name: ListProjectActivity :many
+SELECT id, project_id, image_id, event_type, metadata, created_at
+FROM project_activity
+WHERE project_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: RecordImageExpedited :exec
+-- Appends an image.expedited event, which is both the audit record and what the daily limit counts.
+INSERT INTO project_activity (project_id, image_id, event_type, metadata)
+VALUES (@project_id, @image_id, 'image.expedited',
+        jsonb_build_object('job_id', @job_id::uuid, 'user_id', @user_id::uuid));
@@ -0,0 +1,14 @@
+-- name: UpsertProjectDeletionIntent :exec
+-- Issues the project's pending deletion, replacing any earlier token.
+INSERT INTO project_deletion_intents (project_id, user_id, token_hash, expires_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (project_id) DO UPDATE
+SET user_id = EXCLUDED.user_id,
+    token_hash = EXCLUDED.token_hash,
+    expires_at = EXCLUDED.expires_at,
+    created_at = now();
+
+-- name: ConsumeProjectDeletionIntent :execrows
+-- Removes the project's pending deletion if the token matches and has not expired.
+DELETE FROM project_deletion_intents
+WHERE project_id = $1 AND user_id = $2 AND token_hash = $3 AND expires_at > now();
@@ -0,0 +1,16 @@
+-- name: GetProjectDisclosure :one
+SELECT project_id, enabled, locale, text, position, opacity, updated_at
+FROM project_disclosures
+WHERE project_id = $1;
+
+-- name: UpsertProjectDisclosure :one
+INSERT INTO project_disclosures (project_id, enabled, locale, text, position, opacity)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (project_id) DO UPDATE SET
+  enabled = EXCLUDED.enabled,
+  locale = EXCLUDED.locale,
+  text = EXCLUDED.text,
+  position = EXCLUDED.position,
+  opacity = EXCLUDED.opacity,
+  updated_at = now()
+RETURNING project_id, enabled, locale, text, position, opacity, updated_at;
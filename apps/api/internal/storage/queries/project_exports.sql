@@ -0,0 +1,48 @@
+-- name: CreateExportCredit :execrows
+-- Records a paid export credit. Redeliveries of the checkout webhook add nothing.
+INSERT INTO export_credits (user_id, checkout_session_id)
+VALUES ($1, $2)
+ON CONFLICT (checkout_session_id) DO NOTHING;
+
+-- name: CountAvailableExportCredits :one
+SELECT COUNT(*) FROM export_credits
+WHERE user_id = $1 AND consumed_at IS NULL;
+
+-- name: GetProjectExportCredit :one
+-- Returns the credit that unlocked the project, if any.
+SELECT * FROM export_credits
+WHERE project_id = $1;
+
+-- name: ConsumeExportCredit :one
+-- Spends the user's oldest available credit on the project. Concurrent exports never take
+-- the same credit, and a second credit for an unlocked project violates
+-- idx_export_credits_project_id.
+UPDATE export_credits
+SET project_id = sqlc.arg(project_id), consumed_at = now()
+WHERE id = (
+  SELECT id FROM export_credits
+  WHERE user_id = sqlc.arg(user_id) AND consumed_at IS NULL
+  ORDER BY created_at, id
+  LIMIT 1
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: CountExportableImages :one
+-- Counts the project's images with a staged output, which are what an export bundles.
+SELECT COUNT(*) FROM images
+WHERE project_id = $1 AND status = 'ready' AND staged_url IS NOT NULL;
+
+-- name: CreateProjectExport :one
+INSERT INTO project_exports (project_id, user_id, watermarked, credit_id)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetProjectExport :one
+SELECT * FROM project_exports
+WHERE id = $1 AND project_id = $2;
+
+-- name: FailProjectExport :exec
+UPDATE project_exports
+SET status = 'error', error = $2, updated_at = now()
+WHERE id = $1;
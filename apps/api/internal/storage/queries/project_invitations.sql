@@ -0,0 +1,47 @@
+-- name: CreateProjectInvitation :one
+INSERT INTO project_invitations (project_id, invited_by, email, role, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, project_id, invited_by, email, role, status, accepted_by, expires_at, created_at, accepted_at, revoked_at;
+
+-- name: GetProjectInvitation :one
+SELECT id, project_id, invited_by, email, role, status, accepted_by, expires_at, created_at, accepted_at, revoked_at
+FROM project_invitations
+WHERE id = $1;
+
+-- name: ListProjectInvitations :many
+SELECT id, project_id, invited_by, email, role, status, accepted_by, expires_at, created_at, accepted_at, revoked_at
+FROM project_invitations
+WHERE project_id = $1
+ORDER BY created_at DESC;
+
+-- Marks a pending, unexpired invitation accepted and grants its role to the accepting user
+-- in one statement. Returns no row when the invitation is not pending or has expired.
+-- name: AcceptProjectInvitation :one
+WITH accepted AS (
+  UPDATE project_invitations
+  SET status = 'accepted', accepted_by = $2, accepted_at = now()
+  WHERE id = $1 AND status = 'pending' AND expires_at > now()
+  RETURNING id, project_id, role, accepted_by
+)
+INSERT INTO project_collaborators (project_id, user_id, role, invitation_id)
+SELECT project_id, accepted_by, role, id
+FROM accepted
+ON CONFLICT (project_id, user_id) DO UPDATE SET
+  role = EXCLUDED.role,
+  invitation_id = EXCLUDED.invitation_id
+RETURNING project_id, user_id, role, invitation_id, created_at;
+
+-- Revokes an invitation and removes the access it granted, if it was accepted. Returns no
+-- row when the invitation is not in the project or was already revoked.
+-- name: RevokeProjectInvitation :one
+WITH revoked AS (
+  UPDATE project_invitations
+  SET status = 'revoked', revoked_at = now()
+  WHERE id = $1 AND project_id = $2 AND status <> 'revoked'
+  RETURNING id, project_id, invited_by, email, role, status, accepted_by, expires_at, created_at, accepted_at, revoked_at
+), removed AS (
+  DELETE FROM project_collaborators
+  WHERE invitation_id IN (SELECT id FROM revoked)
+)
+SELECT id, project_id, invited_by, email, role, status, accepted_by, expires_at, created_at, accepted_at, revoked_at
+FROM revoked;
@@ -0,0 +1,27 @@
+-- name: GetProjectListing :one
+SELECT project_id, address_line1, address_line2, city, region, postal_code, country,
+       mls_number, listing_url, latitude, longitude, geocoded_at, updated_at
+FROM project_listings
+WHERE project_id = $1;
+
+-- name: UpsertProjectListing :one
+INSERT INTO project_listings (
+  project_id, address_line1, address_line2, city, region, postal_code, country,
+  mls_number, listing_url, latitude, longitude, geocoded_at
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+ON CONFLICT (project_id) DO UPDATE SET
+  address_line1 = EXCLUDED.address_line1,
+  address_line2 = EXCLUDED.address_line2,
+  city = EXCLUDED.city,
+  region = EXCLUDED.region,
+  postal_code = EXCLUDED.postal_code,
+  country = EXCLUDED.country,
+  mls_number = EXCLUDED.mls_number,
+  listing_url = EXCLUDED.listing_url,
+  latitude = EXCLUDED.latitude,
+  longitude = EXCLUDED.longitude,
+  geocoded_at = EXCLUDED.geocoded_at,
+  updated_at = now()
+RETURNING project_id, address_line1, address_line2, city, region, postal_code, country,
+          mls_number, listing_url, latitude, longitude, geocoded_at, updated_at;
@@ -0,0 +1,32 @@
+-- name: ListProjectRooms :many
+SELECT * FROM project_rooms
+WHERE project_id = $1
+ORDER BY position;
+
+-- name: DeleteProjectRooms :exec
+DELETE FROM project_rooms
+WHERE project_id = $1;
+
+-- name: CreateProjectRooms :exec
+-- Adds rooms to the project's checklist in the order given, numbered from 1. An empty style
+-- is stored as NULL.
+INSERT INTO project_rooms (project_id, position, room_type, style, label)
+SELECT sqlc.arg(project_id)::uuid, r.ord, r.room_type, NULLIF(r.style, ''), r.label
+FROM unnest(sqlc.arg(room_types)::text[], sqlc.arg(styles)::text[], sqlc.arg(labels)::text[])
+  WITH ORDINALITY AS r(room_type, style, label, ord);
+
+-- name: CountProjectImagesByRoomType :many
+-- How many of the project's images are of each room type, and how many of those are staged.
+SELECT room_type::text AS room_type,
+  COUNT(*) AS uploaded,
+  COUNT(*) FILTER (WHERE status = 'ready') AS staged
+FROM images
+WHERE project_id = $1 AND room_type IS NOT NULL
+GROUP BY room_type;
+
+-- name: GetProjectRoomStyle :one
+-- The style of the first room of the type on the project's checklist that has one.
+SELECT style::text FROM project_rooms
+WHERE project_id = $1 AND room_type = $2 AND style IS NOT NULL
+ORDER BY position
+LIMIT 1;
@@ -0,0 +1,13 @@
+-- name: GetProjectShareKey :one
+SELECT project_id, version, rotated_at
+FROM project_share_keys
+WHERE project_id = $1;
+
+-- Moves a project to the next share key version, from 1 when it has none yet.
+-- name: RotateProjectShareKey :one
+INSERT INTO project_share_keys (project_id, version)
+VALUES ($1, 2)
+ON CONFLICT (project_id) DO UPDATE SET
+  version = project_share_keys.version + 1,
+  rotated_at = now()
+RETURNING project_id, version, rotated_at;
@@ -0,0 +1,54 @@
+-- name: CreateProjectTemplate :one
+INSERT INTO project_templates (user_id, name, disclosure, rooms)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetProjectTemplate :one
+SELECT * FROM project_templates
+WHERE id = $1 AND user_id = $2;
+
+-- name: ListProjectTemplates :many
+SELECT * FROM project_templates
+WHERE user_id = $1
+ORDER BY name;
+
+-- name: CountProjectTemplates :one
+SELECT COUNT(*) FROM project_templates
+WHERE user_id = $1;
+
+-- name: DeleteProjectTemplate :execrows
+DELETE FROM project_templates
+WHERE id = $1 AND user_id = $2;
+
+-- name: ApplyProjectTemplateDisclosure :exec
+-- Copies the template's disclosure banner settings to the project. Templates without
+-- settings leave the project on the defaults.
+INSERT INTO project_disclosures (project_id, enabled, locale, text, position, opacity)
+SELECT sqlc.arg(project_id)::uuid, (t.disclosure->>'enabled')::boolean, t.disclosure->>'locale',
+  t.disclosure->>'text', t.disclosure->>'position', (t.disclosure->>'opacity')::real
+FROM project_templates t
+WHERE t.id = sqlc.arg(template_id) AND t.disclosure IS NOT NULL
+ON CONFLICT (project_id) DO UPDATE SET
+  enabled = EXCLUDED.enabled,
+  locale = EXCLUDED.locale,
+  text = EXCLUDED.text,
+  position = EXCLUDED.position,
+  opacity = EXCLUDED.opacity,
+  updated_at = now();
+
+-- name: ApplyProjectTemplateRooms :exec
+-- Replaces the project's room checklist with the template's.
+INSERT INTO project_rooms (project_id, position, room_type, style, label)
+SELECT sqlc.arg(project_id)::uuid, r.ord, r.room->>'room_type', r.room->>'style', COALESCE(r.room->>'label', '')
+FROM project_templates t, jsonb_array_elements(t.rooms) WITH ORDINALITY AS r(room, ord)
+WHERE t.id = sqlc.arg(template_id);
+
+-- name: ListProjectImageRooms :many
+-- The room types of the project's images in the order they were first uploaded, each with
+-- the style most of its images were staged in.
+SELECT room_type::text AS room_type,
+  COALESCE(mode() WITHIN GROUP (ORDER BY style), '')::text AS style
+FROM images
+WHERE project_id = $1 AND room_type IS NOT NULL
+GROUP BY room_type
+ORDER BY MIN(created_at);
@@ -8,6 +8,11 @@ SELECT id, name, user_id, created_at
 FROM projects
 WHERE id = $1;
 
+-- name: GetProjectByIDAndUserID :one
+SELECT id, name, user_id, created_at
+FROM projects
+WHERE id = $1 AND user_id = $2;
+
 -- name: GetProjectsByUserID :many
 SELECT id, name, user_id, created_at
 FROM projects
@@ -31,11 +36,11 @@ SET name = $3
 WHERE id = $1 AND user_id = $2
 RETURNING id, name, user_id, created_at;
 
--- name: DeleteProject :exec
+-- name: DeleteProject :execrows
 DELETE FROM projects
 WHERE id = $1;
 
--- name: DeleteProjectByUserID :exec
+-- name: DeleteProjectByUserID :execrows
 DELETE FROM projects
 WHERE id = $1 AND user_id = $2;
 
@@ -43,3 +48,73 @@ WHERE id = $1 AND user_id = $2;
 SELECT COUNT(*)
 FROM projects
 WHERE user_id = $1;
+
+-- Aggregates a project for its dashboard card. last_activity_at falls back to the
+-- project's creation time when nothing has happened in it yet.
+-- name: GetProjectSummary :one
+SELECT COUNT(i.id) AS total_images,
+       COUNT(i.id) FILTER (WHERE i.status = 'queued') AS queued_count,
+       COUNT(i.id) FILTER (WHERE i.status = 'processing') AS processing_count,
+       COUNT(i.id) FILTER (WHERE i.status = 'ready') AS ready_count,
+       COUNT(i.id) FILTER (WHERE i.status = 'error') AS error_count,
+       COUNT(i.id) FILTER (WHERE i.status = 'canceled') AS canceled_count,
+       COALESCE(SUM(COALESCE(i.original_size_bytes, 0) + COALESCE(i.staged_size_bytes, 0)), 0)::bigint AS storage_bytes,
+       GREATEST(
+         p.created_at,
+         MAX(i.updated_at),
+         (SELECT MAX(pa.created_at) FROM project_activity pa WHERE pa.project_id = p.id)
+       )::timestamptz AS last_activity_at
+FROM projects p
+LEFT JOIN images i ON i.project_id = p.id
+WHERE p.id = $1
+GROUP BY p.id, p.created_at;
+
+-- name: ListProjectThumbnails :many
+SELECT id, status, original_url, staged_url
+FROM images
+WHERE project_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2;
+
+-- Aggregates many of a user's projects for the dashboard grid in one round trip,
+-- including each project's most recent images as a JSON array. Projects the user
+-- doesn't own are left out.
+-- name: ListProjectSummariesByUser :many
+SELECT p.id AS project_id,
+       COUNT(i.id) AS total_images,
+       COUNT(i.id) FILTER (WHERE i.status = 'queued') AS queued_count,
+       COUNT(i.id) FILTER (WHERE i.status = 'processing') AS processing_count,
+       COUNT(i.id) FILTER (WHERE i.status = 'ready') AS ready_count,
+       COUNT(i.id) FILTER (WHERE i.status = 'error') AS error_count,
+       COUNT(i.id) FILTER (WHERE i.status = 'canceled') AS canceled_count,
+       COALESCE(SUM(COALESCE(i.original_size_bytes, 0) + COALESCE(i.staged_size_bytes, 0)), 0)::bigint AS storage_bytes,
+       GREATEST(
+         p.created_at,
+         MAX(i.updated_at),
+         (SELECT MAX(pa.created_at) FROM project_activity pa WHERE pa.project_id = p.id)
+       )::timestamptz AS last_activity_at,
+       COALESCE((
+         SELECT jsonb_agg(jsonb_build_object(
+                  'id', t.id, 'status', t.status, 'original_url', t.original_url, 'staged_url', t.staged_url
+                ) ORDER BY t.created_at DESC, t.id DESC)
+         FROM (
+           SELECT ti.id, ti.status, ti.original_url, ti.staged_url, ti.created_at
+           FROM images ti
+           WHERE ti.project_id = p.id
+           ORDER BY ti.created_at DESC, ti.id DESC
+           LIMIT @thumbnail_limit::int
+         ) t
+       ), '[]'::jsonb)::jsonb AS thumbnails
+FROM projects p
+LEFT JOIN images i ON i.project_id = p.id
+WHERE p.id = ANY(@project_ids::uuid[]) AND p.user_id = @user_id
+GROUP BY p.id, p.created_at;
+
+-- Returns the project when the user owns it or collaborates on it, with the user's role:
+-- owner, editor or viewer.
+-- name: GetProjectByIDForMember :one
+SELECT p.id, p.name, p.user_id, p.created_at,
+       (CASE WHEN p.user_id = $2 THEN 'owner' ELSE c.role END)::text AS role
+FROM projects p
+LEFT JOIN project_collaborators c ON c.project_id = p.id AND c.user_id = $2
+WHERE p.id = $1 AND (p.user_id = $2 OR c.user_id IS NOT NULL);
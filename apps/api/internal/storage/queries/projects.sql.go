@@ -54,17 +54,20 @@ func (q *Queries) CreateProject(ctx context.Context, arg CreateProjectParams) (*
 	return &i, err
 }
 
-const DeleteProject = `-- name: DeleteProject :exec
+const DeleteProject = `-- name: DeleteProject :execrows
 DELETE FROM projects
 WHERE id = $1
 `
 
-func (q *Queries) DeleteProject(ctx context.Context, id pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, DeleteProject, id)
-	return err
+func (q *Queries) DeleteProject(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, DeleteProject, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const DeleteProjectByUserID = `-- name: DeleteProjectByUserID :exec
+const DeleteProjectByUserID = `-- name: DeleteProjectByUserID :execrows
 DELETE FROM projects
 WHERE id = $1 AND user_id = $2
 `
@@ -74,9 +77,12 @@ type DeleteProjectByUserIDParams struct {
 	UserID pgtype.UUID `json:"user_id"`
 }
 
-func (q *Queries) DeleteProjectByUserID(ctx context.Context, arg DeleteProjectByUserIDParams) error {
-	_, err := q.db.Exec(ctx, DeleteProjectByUserID, arg.ID, arg.UserID)
-	return err
+func (q *Queries) DeleteProjectByUserID(ctx context.Context, arg DeleteProjectByUserIDParams) (int64, error) {
+	result, err := q.db.Exec(ctx, DeleteProjectByUserID, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const GetAllProjects = `-- name: GetAllProjects :many
@@ -142,6 +148,36 @@ func (q *Queries) GetProjectByID(ctx context.Context, id pgtype.UUID) (*GetProje
 	return &i, err
 }
 
+const GetProjectByIDAndUserID = `-- name: GetProjectByIDAndUserID :one
+SELECT id, name, user_id, created_at
+FROM projects
+WHERE id = $1 AND user_id = $2
+`
+
+type GetProjectByIDAndUserIDParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+type GetProjectByIDAndUserIDRow struct {
+	ID        pgtype.UUID        `json:"id"`
+	Name      string             `json:"name"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) GetProjectByIDAndUserID(ctx context.Context, arg GetProjectByIDAndUserIDParams) (*GetProjectByIDAndUserIDRow, error) {
+	row := q.db.QueryRow(ctx, GetProjectByIDAndUserID, arg.ID, arg.UserID)
+	var i GetProjectByIDAndUserIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UserID,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
 const GetProjectsByUserID = `-- name: GetProjectsByUserID :many
 SELECT id, name, user_id, created_at
 FROM projects
@@ -243,3 +279,217 @@ func (q *Queries) UpdateProjectByUserID(ctx context.Context, arg UpdateProjectBy
 	)
 	return &i, err
 }
+
+const GetProjectSummary = `-- name: GetProjectSummary :one
+SELECT COUNT(i.id) AS total_images,
+       COUNT(i.id) FILTER (WHERE i.status = 'queued') AS queued_count,
+       COUNT(i.id) FILTER (WHERE i.status = 'processing') AS processing_count,
+       COUNT(i.id) FILTER (WHERE i.status = 'ready') AS ready_count,
+       COUNT(i.id) FILTER (WHERE i.status = 'error') AS error_count,
+       COUNT(i.id) FILTER (WHERE i.status = 'canceled') AS canceled_count,
+       COALESCE(SUM(COALESCE(i.original_size_bytes, 0) + COALESCE(i.staged_size_bytes, 0)), 0)::bigint AS storage_bytes,
+       GREATEST(
+         p.created_at,
+         MAX(i.updated_at),
+         (SELECT MAX(pa.created_at) FROM project_activity pa WHERE pa.project_id = p.id)
+       )::timestamptz AS last_activity_at
+FROM projects p
+LEFT JOIN images i ON i.project_id = p.id
+WHERE p.id = $1
+GROUP BY p.id, p.created_at
+`
+
+type GetProjectSummaryRow struct {
+	TotalImages     int64              `json:"total_images"`
+	QueuedCount     int64              `json:"queued_count"`
+	ProcessingCount int64              `json:"processing_count"`
+	ReadyCount      int64              `json:"ready_count"`
+	ErrorCount      int64              `json:"error_count"`
+	CanceledCount   int64              `json:"canceled_count"`
+	StorageBytes    int64              `json:"storage_bytes"`
+	LastActivityAt  pgtype.Timestamptz `json:"last_activity_at"`
+}
+
+// Aggregates a project for its dashboard card. last_activity_at falls back to the
+// project's creation time when nothing has happened in it yet.
+func (q *Queries) GetProjectSummary(ctx context.Context, id pgtype.UUID) (*GetProjectSummaryRow, error) {
+	row := q.db.QueryRow(ctx, GetProjectSummary, id)
+	var i GetProjectSummaryRow
+	err := row.Scan(
+		&i.TotalImages,
+		&i.QueuedCount,
+		&i.ProcessingCount,
+		&i.ReadyCount,
+		&i.ErrorCount,
+		&i.CanceledCount,
+		&i.StorageBytes,
+		&i.LastActivityAt,
+	)
+	return &i, err
+}
+
+const ListProjectThumbnails = `-- name: ListProjectThumbnails :many
+SELECT id, status, original_url, staged_url
+FROM images
+WHERE project_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2
+`
+
+type ListProjectThumbnailsParams struct {
+	ProjectID pgtype.UUID `json:"project_id"`
+	Limit     int32       `json:"limit"`
+}
+
+type ListProjectThumbnailsRow struct {
+	ID          pgtype.UUID `json:"id"`
+	Status      ImageStatus `json:"status"`
+	OriginalUrl string      `json:"original_url"`
+	StagedUrl   pgtype.Text `json:"staged_url"`
+}
+
+func (q *Queries) ListProjectThumbnails(ctx context.Context, arg ListProjectThumbnailsParams) ([]*ListProjectThumbnailsRow, error) {
+	rows, err := q.db.Query(ctx, ListProjectThumbnails, arg.ProjectID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListProjectThumbnailsRow{}
+	for rows.Next() {
+		var i ListProjectThumbnailsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Status,
+			&i.OriginalUrl,
+			&i.StagedUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListProjectSummariesByUser = `-- name: ListProjectSummariesByUser :many
+SELECT p.id AS project_id,
+       COUNT(i.id) AS total_images,
+       COUNT(i.id) FILTER (WHERE i.status = 'queued') AS queued_count,
+       COUNT(i.id) FILTER (WHERE i.status = 'processing') AS processing_count,
+       COUNT(i.id) FILTER (WHERE i.status = 'ready') AS ready_count,
+       COUNT(i.id) FILTER (WHERE i.status = 'error') AS error_count,
+       COUNT(i.id) FILTER (WHERE i.status = 'canceled') AS canceled_count,
+       COALESCE(SUM(COALESCE(i.original_size_bytes, 0) + COALESCE(i.staged_size_bytes, 0)), 0)::bigint AS storage_bytes,
+       GREATEST(
+         p.created_at,
+         MAX(i.updated_at),
+         (SELECT MAX(pa.created_at) FROM project_activity pa WHERE pa.project_id = p.id)
+       )::timestamptz AS last_activity_at,
+       COALESCE((
+         SELECT jsonb_agg(jsonb_build_object(
+                  'id', t.id, 'status', t.status, 'original_url', t.original_url, 'staged_url', t.staged_url
+                ) ORDER BY t.created_at DESC, t.id DESC)
+         FROM (
+           SELECT ti.id, ti.status, ti.original_url, ti.staged_url, ti.created_at
+           FROM images ti
+           WHERE ti.project_id = p.id
+           ORDER BY ti.created_at DESC, ti.id DESC
+           LIMIT $1::int
+         ) t
+       ), '[]'::jsonb)::jsonb AS thumbnails
+FROM projects p
+LEFT JOIN images i ON i.project_id = p.id
+WHERE p.id = ANY($2::uuid[]) AND p.user_id = $3
+GROUP BY p.id, p.created_at
+`
+
+type ListProjectSummariesByUserParams struct {
+	ThumbnailLimit int32         `json:"thumbnail_limit"`
+	ProjectIds     []pgtype.UUID `json:"project_ids"`
+	UserID         pgtype.UUID   `json:"user_id"`
+}
+
+type ListProjectSummariesByUserRow struct {
+	ProjectID       pgtype.UUID        `json:"project_id"`
+	TotalImages     int64              `json:"total_images"`
+	QueuedCount     int64              `json:"queued_count"`
+	ProcessingCount int64              `json:"processing_count"`
+	ReadyCount      int64              `json:"ready_count"`
+	ErrorCount      int64              `json:"error_count"`
+	CanceledCount   int64              `json:"canceled_count"`
+	StorageBytes    int64              `json:"storage_bytes"`
+	LastActivityAt  pgtype.Timestamptz `json:"last_activity_at"`
+	Thumbnails      []byte             `json:"thumbnails"`
+}
+
+// Aggregates many of a user's projects for the dashboard grid in one round trip,
+// including each project's most recent images as a JSON array. Projects the user
+// doesn't own are left out.
+func (q *Queries) ListProjectSummariesByUser(ctx context.Context, arg ListProjectSummariesByUserParams) ([]*ListProjectSummariesByUserRow, error) {
+	rows, err := q.db.Query(ctx, ListProjectSummariesByUser, arg.ThumbnailLimit, arg.ProjectIds, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListProjectSummariesByUserRow{}
+	for rows.Next() {
+		var i ListProjectSummariesByUserRow
+		if err := rows.Scan(
+			&i.ProjectID,
+			&i.TotalImages,
+			&i.QueuedCount,
+			&i.ProcessingCount,
+			&i.ReadyCount,
+			&i.ErrorCount,
+			&i.CanceledCount,
+			&i.StorageBytes,
+			&i.LastActivityAt,
+			&i.Thumbnails,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetProjectByIDForMember = `-- name: GetProjectByIDForMember :one
+SELECT p.id, p.name, p.user_id, p.created_at,
+       (CASE WHEN p.user_id = $2 THEN 'owner' ELSE c.role END)::text AS role
+FROM projects p
+LEFT JOIN project_collaborators c ON c.project_id = p.id AND c.user_id = $2
+WHERE p.id = $1 AND (p.user_id = $2 OR c.user_id IS NOT NULL)
+`
+
+type GetProjectByIDForMemberParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+type GetProjectByIDForMemberRow struct {
+	ID        pgtype.UUID        `json:"id"`
+	Name      string             `json:"name"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	Role      string             `json:"role"`
+}
+
+// Returns the project when the user owns it or collaborates on it, with the user's role:
+// owner, editor or viewer.
+func (q *Queries) GetProjectByIDForMember(ctx context.Context, arg GetProjectByIDForMemberParams) (*GetProjectByIDForMemberRow, error) {
+	row := q.db.QueryRow(ctx, GetProjectByIDForMember, arg.ID, arg.UserID)
+	var i GetProjectByIDForMemberRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.Role,
+	)
+	return &i, err
+}
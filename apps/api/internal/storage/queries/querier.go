@@ -11,66 +11,386 @@ import (
 )
 
 type Querier interface {
+	// Marks a pending, unexpired invitation accepted and grants its role to the accepting user
+	// in one statement. Returns no row when the invitation is not pending or has expired.
+	AcceptProjectInvitation(ctx context.Context, arg AcceptProjectInvitationParams) (*ProjectCollaborator, error)
+	AddProjectRetentionExemption(ctx context.Context, projectID pgtype.UUID) error
+	// Adds the organization's SCIM users among scim_user_ids to the group; IDs of other
+	// organizations' users are ignored.
+	AddSCIMGroupMembers(ctx context.Context, arg AddSCIMGroupMembersParams) (int64, error)
+	// Copies the template's disclosure banner settings to the project. Templates without
+	// settings leave the project on the defaults.
+	ApplyProjectTemplateDisclosure(ctx context.Context, arg ApplyProjectTemplateDisclosureParams) error
+	// Replaces the project's room checklist with the template's.
+	ApplyProjectTemplateRooms(ctx context.Context, arg ApplyProjectTemplateRoomsParams) error
+	// Cancels every unfinished image in the list and its in-flight jobs in a single statement.
+	BulkCancelImages(ctx context.Context, arg BulkCancelImagesParams) ([]*BulkCancelImagesRow, error)
+	// Deletes the listed images that are not under legal hold in a single statement. Held images
+	// are left in place and returned with held set.
+	BulkDeleteImages(ctx context.Context, arg BulkDeleteImagesParams) ([]*BulkDeleteImagesRow, error)
+	// Marks an unfinished image as canceled and zeroes its cost so canceled work is not billed.
+	CancelImage(ctx context.Context, id pgtype.UUID) (*CancelImageRow, error)
+	CancelJobsByImageID(ctx context.Context, imageID pgtype.UUID) ([]*Job, error)
+	// Atomically claims an event for processing. Returns no row when another
+	// delivery of the same event already claimed it.
+	ClaimProcessedEvent(ctx context.Context, arg ClaimProcessedEventParams) (*ProcessedEvent, error)
+	// Gives the SCIM user whose user name is the email of an SSO sign-in the signed-in subject,
+	// unless that subject already has an account. Only placeholder subjects are replaced.
+	ClaimSCIMUser(ctx context.Context, arg ClaimSCIMUserParams) (int64, error)
 	CompleteJob(ctx context.Context, id pgtype.UUID) (*Job, error)
+	// Records the groups the owner applied. Only a ready grouping can be confirmed, once.
+	ConfirmRoomGrouping(ctx context.Context, arg ConfirmRoomGroupingParams) (*RoomGrouping, error)
+	// Spends the user's oldest available credit on the project. Concurrent exports never take
+	// the same credit, and a second credit for an unlocked project violates
+	// idx_export_credits_project_id.
+	ConsumeExportCredit(ctx context.Context, arg ConsumeExportCreditParams) (*ExportCredit, error)
+	// Removes the project's pending deletion if the token matches and has not expired.
+	ConsumeProjectDeletionIntent(ctx context.Context, arg ConsumeProjectDeletionIntentParams) (int64, error)
+	CountAPIKeysByUser(ctx context.Context, userID pgtype.UUID) (int64, error)
+	// Counts the users who created an image at or after since.
+	CountActiveUsersSince(ctx context.Context, since pgtype.Timestamptz) (int64, error)
+	CountAvailableExportCredits(ctx context.Context, userID pgtype.UUID) (int64, error)
+	// Counts the project's images with a staged output, which are what an export bundles.
+	CountExportableImages(ctx context.Context, projectID pgtype.UUID) (int64, error)
+	// Counts every job per status.
+	CountJobsByStatus(ctx context.Context) ([]*CountJobsByStatusRow, error)
+	CountProjectImages(ctx context.Context, projectID pgtype.UUID) (int64, error)
+	// How many of the project's images are of each room type, and how many of those are staged.
+	CountProjectImagesByRoomType(ctx context.Context, projectID pgtype.UUID) ([]*CountProjectImagesByRoomTypeRow, error)
+	CountProjectTemplates(ctx context.Context, userID pgtype.UUID) (int64, error)
 	CountProjectsByUserID(ctx context.Context, userID pgtype.UUID) (int64, error)
+	CountSCIMGroups(ctx context.Context, arg CountSCIMGroupsParams) (int64, error)
+	CountSCIMUsers(ctx context.Context, arg CountSCIMUsersParams) (int64, error)
+	CountUnreadNotifications(ctx context.Context, userID pgtype.UUID) (int64, error)
 	CountUsers(ctx context.Context) (int64, error)
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (*ApiKey, error)
+	CreateCatalog(ctx context.Context, arg CreateCatalogParams) (*Catalog, error)
+	// Records a paid export credit. Redeliveries of the checkout webhook add nothing.
+	CreateExportCredit(ctx context.Context, arg CreateExportCreditParams) (int64, error)
 	CreateImage(ctx context.Context, arg CreateImageParams) (*CreateImageRow, error)
+	CreateImageAnnotations(ctx context.Context, arg CreateImageAnnotationsParams) error
+	CreateImageBrackets(ctx context.Context, arg CreateImageBracketsParams) error
+	CreateImageEdit(ctx context.Context, arg CreateImageEditParams) (*ImageEdit, error)
+	CreateImages(ctx context.Context, arg []CreateImagesParams) (int64, error)
 	CreateJob(ctx context.Context, arg CreateJobParams) (*Job, error)
+	CreateJobs(ctx context.Context, arg []CreateJobsParams) (int64, error)
+	// Inserts a notification unless an unread one with the same dedupe key exists, in which
+	// case no row is returned.
+	CreateNotification(ctx context.Context, arg CreateNotificationParams) (*Notification, error)
+	// Creates an organization and makes its owner an admin in one statement.
+	CreateOrganization(ctx context.Context, arg CreateOrganizationParams) (*CreateOrganizationRow, error)
+	CreateOrganizationBreakGlass(ctx context.Context, arg CreateOrganizationBreakGlassParams) (*OrganizationBreakGlass, error)
+	CreatePreset(ctx context.Context, arg CreatePresetParams) (*Preset, error)
 	CreateProcessedEvent(ctx context.Context, arg CreateProcessedEventParams) (*ProcessedEvent, error)
 	CreateProject(ctx context.Context, arg CreateProjectParams) (*CreateProjectRow, error)
+	CreateProjectExport(ctx context.Context, arg CreateProjectExportParams) (*ProjectExport, error)
+	CreateProjectInvitation(ctx context.Context, arg CreateProjectInvitationParams) (*ProjectInvitation, error)
+	// Adds rooms to the project's checklist in the order given, numbered from 1. An empty style
+	// is stored as NULL.
+	CreateProjectRooms(ctx context.Context, arg CreateProjectRoomsParams) error
+	CreateProjectTemplate(ctx context.Context, arg CreateProjectTemplateParams) (*ProjectTemplate, error)
+	CreateRoomGrouping(ctx context.Context, projectID pgtype.UUID) (*RoomGrouping, error)
+	CreateSCIMGroup(ctx context.Context, arg CreateSCIMGroupParams) (*OrganizationScimGroup, error)
+	// Creates a SCIM user together with the account backing it. The account's subject is a
+	// placeholder until the user's first SSO sign-in claims it.
+	CreateSCIMUser(ctx context.Context, arg CreateSCIMUserParams) (*OrganizationScimUser, error)
+	// Logs a delivery, due at once.
+	CreateTeamWebhookDelivery(ctx context.Context, arg CreateTeamWebhookDeliveryParams) (*TeamWebhookDelivery, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (*CreateUserRow, error)
+	DeleteAccountWatermark(ctx context.Context, userID pgtype.UUID) (int64, error)
+	DeleteCatalog(ctx context.Context, id pgtype.UUID) (int64, error)
+	DeleteCustomerBucket(ctx context.Context, userID pgtype.UUID) (int64, error)
 	DeleteImage(ctx context.Context, id pgtype.UUID) error
 	DeleteImagesByProjectID(ctx context.Context, projectID pgtype.UUID) error
 	DeleteJob(ctx context.Context, id pgtype.UUID) error
 	DeleteJobsByImageID(ctx context.Context, imageID pgtype.UUID) error
 	// Optional maintenance: delete older processed events by timestamp (retention)
 	DeleteOldProcessedEvents(ctx context.Context, receivedAt pgtype.Timestamptz) error
-	DeleteProject(ctx context.Context, id pgtype.UUID) error
-	DeleteProjectByUserID(ctx context.Context, arg DeleteProjectByUserIDParams) error
+	DeleteOrganizationSCIMMember(ctx context.Context, arg DeleteOrganizationSCIMMemberParams) error
+	DeleteOrganizationSCIMToken(ctx context.Context, organizationID pgtype.UUID) (int64, error)
+	DeleteOrganizationSSO(ctx context.Context, organizationID pgtype.UUID) (int64, error)
+	DeletePreset(ctx context.Context, arg DeletePresetParams) (int64, error)
+	DeleteProject(ctx context.Context, id pgtype.UUID) (int64, error)
+	DeleteProjectByUserID(ctx context.Context, arg DeleteProjectByUserIDParams) (int64, error)
+	DeleteProjectRooms(ctx context.Context, projectID pgtype.UUID) error
+	DeleteProjectTemplate(ctx context.Context, arg DeleteProjectTemplateParams) (int64, error)
+	DeleteSCIMGroup(ctx context.Context, arg DeleteSCIMGroupParams) (int64, error)
+	// Deletes a SCIM user and their SCIM membership. The backing account is deleted too when
+	// the user never signed in.
+	DeleteSCIMUser(ctx context.Context, arg DeleteSCIMUserParams) (int64, error)
+	DeleteShareBranding(ctx context.Context, userID pgtype.UUID) (int64, error)
 	DeleteSubscriptionByStripeID(ctx context.Context, stripeSubscriptionID string) error
+	DeleteTeamWebhook(ctx context.Context, arg DeleteTeamWebhookParams) (int64, error)
 	DeleteUser(ctx context.Context, id pgtype.UUID) error
+	// Marks an edit failed, used when it could not be queued.
+	FailImageEdit(ctx context.Context, arg FailImageEditParams) error
+	FailProjectExport(ctx context.Context, arg FailProjectExportParams) error
 	FailJob(ctx context.Context, arg FailJobParams) (*Job, error)
+	FailRoomGrouping(ctx context.Context, arg FailRoomGroupingParams) error
+	// Resolves a key to its owner's subject; revoked and expired keys are not found.
+	GetAPIKeyByHash(ctx context.Context, keyHash []byte) (*GetAPIKeyByHashRow, error)
+	GetAccountWatermark(ctx context.Context, userID pgtype.UUID) (*AccountWatermark, error)
 	GetAllProjects(ctx context.Context) ([]*GetAllProjectsRow, error)
+	GetCatalogByID(ctx context.Context, id pgtype.UUID) (*Catalog, error)
+	// Returns whether the user's active plan includes customer-managed storage.
+	GetCustomerBucketAccess(ctx context.Context, userID pgtype.UUID) (bool, error)
+	GetCustomerBucketByName(ctx context.Context, bucket string) (*CustomerBucket, error)
+	GetCustomerBucketByUserID(ctx context.Context, userID pgtype.UUID) (*CustomerBucket, error)
+	GetDeadLetterJob(ctx context.Context, id pgtype.UUID) (*DeadLetterJob, error)
+	// Returns the image's project and owner, whether the owner's active plan allows expediting,
+	// and how many images the owner has expedited across all of their projects since @since.
+	GetExpediteAccess(ctx context.Context, arg GetExpediteAccessParams) (*GetExpediteAccessRow, error)
+	// Aggregates image outcomes per UTC day or week. A NULL user_id aggregates across all users.
+	GetImageAnalyticsBuckets(ctx context.Context, arg GetImageAnalyticsBucketsParams) ([]*GetImageAnalyticsBucketsRow, error)
 	GetImageByID(ctx context.Context, id pgtype.UUID) (*GetImageByIDRow, error)
+	GetImageEdit(ctx context.Context, arg GetImageEditParams) (*ImageEdit, error)
+	// Returns the image versions an edit can start from, its status, its project and the
+	// project owner, whose uploads a mask must be one of.
+	GetImageEditSource(ctx context.Context, id pgtype.UUID) (*GetImageEditSourceRow, error)
+	// Aggregates image outcomes per creating surface in a date range. A NULL user_id
+	// aggregates across all users.
+	GetImageSourceBreakdown(ctx context.Context, arg GetImageSourceBreakdownParams) ([]*GetImageSourceBreakdownRow, error)
+	GetImageStatusesByIDs(ctx context.Context, arg GetImageStatusesByIDsParams) ([]*GetImageStatusesByIDsRow, error)
+	GetImagesByIDs(ctx context.Context, ids []pgtype.UUID) ([]*GetImagesByIDsRow, error)
 	GetImagesByProjectID(ctx context.Context, projectID pgtype.UUID) ([]*GetImagesByProjectIDRow, error)
 	GetInvoiceByStripeID(ctx context.Context, stripeInvoiceID string) (*Invoice, error)
 	GetJobByID(ctx context.Context, id pgtype.UUID) (*Job, error)
+	// Returns the sanitized processing log the worker recorded for a job, when the user owns or
+	// collaborates on the project of the job's image.
+	GetJobLog(ctx context.Context, arg GetJobLogParams) (*JobLog, error)
+	// Counts the finished jobs created at or after since, by outcome.
+	GetJobOutcomesSince(ctx context.Context, since pgtype.Timestamptz) (*GetJobOutcomesSinceRow, error)
 	GetJobsByImageID(ctx context.Context, imageID pgtype.UUID) ([]*Job, error)
+	GetLatestRoomGrouping(ctx context.Context, projectID pgtype.UUID) (*RoomGrouping, error)
+	GetOrganizationIPAllowlist(ctx context.Context, id pgtype.UUID) ([]string, error)
+	GetOrganizationMember(ctx context.Context, arg GetOrganizationMemberParams) (*OrganizationMember, error)
+	GetOrganizationSSO(ctx context.Context, organizationID pgtype.UUID) (*OrganizationSso, error)
+	// Returns whether the organization owner's active plan includes single sign-on.
+	GetOrganizationSSOAccess(ctx context.Context, id pgtype.UUID) (bool, error)
+	GetOrganizationSSOByConnection(ctx context.Context, connection string) (*OrganizationSso, error)
+	GetOrganizationSSOByEmailDomain(ctx context.Context, domain string) (*OrganizationSso, error)
 	GetPendingJobs(ctx context.Context, limit int32) ([]*Job, error)
+	// Billing: Stripe webhook idempotency, subscriptions and invoices
 	// Processed Events (Stripe Idempotency)
-	// sqlc queries for processed_events and subscriptions
+	// Resolves a preset for an image being created, only if it belongs to the project's owner.
+	GetPresetForProject(ctx context.Context, arg GetPresetForProjectParams) (*Preset, error)
 	GetProcessedEventByStripeID(ctx context.Context, stripeEventID string) (*ProcessedEvent, error)
 	GetProjectByID(ctx context.Context, id pgtype.UUID) (*GetProjectByIDRow, error)
+	GetProjectByIDAndUserID(ctx context.Context, arg GetProjectByIDAndUserIDParams) (*GetProjectByIDAndUserIDRow, error)
+	// Returns the project when the user owns it or collaborates on it, with the user's role:
+	// owner, editor or viewer.
+	GetProjectByIDForMember(ctx context.Context, arg GetProjectByIDForMemberParams) (*GetProjectByIDForMemberRow, error)
+	GetProjectCostSummary(ctx context.Context, projectID pgtype.UUID) (*GetProjectCostSummaryRow, error)
+	GetProjectDisclosure(ctx context.Context, projectID pgtype.UUID) (*ProjectDisclosure, error)
+	GetProjectExport(ctx context.Context, arg GetProjectExportParams) (*ProjectExport, error)
+	// Returns the credit that unlocked the project, if any.
+	GetProjectExportCredit(ctx context.Context, projectID pgtype.UUID) (*ExportCredit, error)
+	GetProjectInvitation(ctx context.Context, id pgtype.UUID) (*ProjectInvitation, error)
+	GetProjectListing(ctx context.Context, projectID pgtype.UUID) (*ProjectListing, error)
+	// The style of the first room of the type on the project's checklist that has one.
+	GetProjectRoomStyle(ctx context.Context, arg GetProjectRoomStyleParams) (string, error)
+	GetProjectShareKey(ctx context.Context, projectID pgtype.UUID) (*ProjectShareKey, error)
+	// Aggregates a project for its dashboard card. last_activity_at falls back to the
+	// project's creation time when nothing has happened in it yet.
+	GetProjectSummary(ctx context.Context, id pgtype.UUID) (*GetProjectSummaryRow, error)
+	GetProjectTemplate(ctx context.Context, arg GetProjectTemplateParams) (*ProjectTemplate, error)
 	GetProjectsByUserID(ctx context.Context, userID pgtype.UUID) ([]*GetProjectsByUserIDRow, error)
+	// Returns the project's owner and whether their active plan allows reference images.
+	GetReferenceImageAccess(ctx context.Context, id pgtype.UUID) (*GetReferenceImageAccessRow, error)
+	GetRoomGrouping(ctx context.Context, arg GetRoomGroupingParams) (*RoomGrouping, error)
+	GetSCIMGroup(ctx context.Context, arg GetSCIMGroupParams) (*OrganizationScimGroup, error)
+	GetSCIMUser(ctx context.Context, arg GetSCIMUserParams) (*OrganizationScimUser, error)
+	// Counts images per staging style in a date range. A NULL user_id counts across all users.
+	GetSetting(ctx context.Context, key string) (*Setting, error)
+	GetShareBranding(ctx context.Context, userID pgtype.UUID) (*ShareBranding, error)
+	GetShareBrandingByVerifiedDomain(ctx context.Context, customDomain pgtype.Text) (*ShareBranding, error)
+	GetStylePopularity(ctx context.Context, arg GetStylePopularityParams) ([]*GetStylePopularityRow, error)
 	GetSubscriptionByStripeID(ctx context.Context, stripeSubscriptionID string) (*Subscription, error)
+	GetTeamWebhook(ctx context.Context, arg GetTeamWebhookParams) (*TeamWebhook, error)
+	GetTeamWebhookDelivery(ctx context.Context, arg GetTeamWebhookDeliveryParams) (*TeamWebhookDelivery, error)
+	// Measures SLA compliance per plan for images that became ready in a date range, counting
+	// only images measured against a target. A NULL user_id measures across all users.
+	GetTurnaroundSLAByPlan(ctx context.Context, arg GetTurnaroundSLAByPlanParams) ([]*GetTurnaroundSLAByPlanRow, error)
+	GetUsage(ctx context.Context, arg GetUsageParams) (int32, error)
+	// Returns the current period of the user's most recently updated active subscription, the
+	// one their entitlements come from.
+	GetUserBillingPeriod(ctx context.Context, userID pgtype.UUID) (*GetUserBillingPeriodRow, error)
 	GetUserByAuth0Sub(ctx context.Context, auth0Sub string) (*GetUserByAuth0SubRow, error)
 	GetUserByID(ctx context.Context, id pgtype.UUID) (*GetUserByIDRow, error)
 	GetUserByStripeCustomerID(ctx context.Context, stripeCustomerID pgtype.Text) (*GetUserByStripeCustomerIDRow, error)
+	GetUserByStripeCustomerIDHash(ctx context.Context, stripeCustomerIDHash pgtype.Text) (*GetUserByStripeCustomerIDHashRow, error)
+	// Returns the plan of the user's most recently updated active subscription.
+	GetUserEntitlements(ctx context.Context, userID pgtype.UUID) (*GetUserEntitlementsRow, error)
 	GetUserProfileByAuth0Sub(ctx context.Context, auth0Sub string) (*GetUserProfileByAuth0SubRow, error)
 	GetUserProfileByID(ctx context.Context, id pgtype.UUID) (*GetUserProfileByIDRow, error)
+	// Returns the data-residency region the user's new images are stored in; NULL is the
+	// home bucket.
+	GetUserStorageRegion(ctx context.Context, id pgtype.UUID) (pgtype.Text, error)
+	// Whether a grouping of the project is queued or running. Groupings left unfinished for
+	// longer than an hour are taken to have been lost with their worker.
+	HasActiveRoomGrouping(ctx context.Context, projectID pgtype.UUID) (bool, error)
+	IsImageUnderLegalHold(ctx context.Context, id pgtype.UUID) (bool, error)
+	IsProjectRetentionExempt(ctx context.Context, projectID pgtype.UUID) (bool, error)
+	IsProjectUnderLegalHold(ctx context.Context, projectID pgtype.UUID) (bool, error)
+	ListAPIKeysByUser(ctx context.Context, userID pgtype.UUID) ([]*ApiKey, error)
+	ListActiveCatalogs(ctx context.Context) ([]*Catalog, error)
+	ListCatalogs(ctx context.Context) ([]*Catalog, error)
+	// Reads of the owner's data, newest first.
+	ListDataAccessByOwner(ctx context.Context, arg ListDataAccessByOwnerParams) ([]*DataAccessLog, error)
+	// Tasks that used up their retries, most recently dead first.
+	ListDeadLetterJobs(ctx context.Context, arg ListDeadLetterJobsParams) ([]*DeadLetterJob, error)
+	// Lists the allow-lists the user behind a subject must satisfy: those of every organization
+	// they belong to that has one, each with the user's open break-glass window, if any.
+	ListIPAllowlistsForSubject(ctx context.Context, auth0Sub string) ([]*ListIPAllowlistsForSubjectRow, error)
+	// Returns the images among @image_ids whose status differs from the latest event in their
+	// status log, with the status that event recorded. Images without events are skipped.
+	ListImageStatusDrift(ctx context.Context, imageIds []pgtype.UUID) ([]*ListImageStatusDriftRow, error)
+	// Lists an image's status events oldest first. The log outlives the image.
+	ListImageStatusTransitions(ctx context.Context, imageID pgtype.UUID) ([]*ImageStatusTransition, error)
 	ListImagesForReconcile(ctx context.Context, arg ListImagesForReconcileParams) ([]*ListImagesForReconcileRow, error)
 	ListInvoicesByUserID(ctx context.Context, arg ListInvoicesByUserIDParams) ([]*Invoice, error)
+	// Lists jobs newest first, optionally filtered by status.
+	ListJobs(ctx context.Context, arg ListJobsParams) ([]*Job, error)
+	ListLegalHeldImageIDs(ctx context.Context, imageIds []pgtype.UUID) ([]pgtype.UUID, error)
+	ListLegalHolds(ctx context.Context) ([]*LegalHold, error)
+	// Lists a user's notifications newest first, optionally only the unread ones.
+	ListNotifications(ctx context.Context, arg ListNotificationsParams) ([]*Notification, error)
+	ListOrganizationAuditLog(ctx context.Context, arg ListOrganizationAuditLogParams) ([]*OrganizationAuditLog, error)
+	ListOrganizationMembers(ctx context.Context, organizationID pgtype.UUID) ([]*OrganizationMember, error)
+	ListOrganizationsByUser(ctx context.Context, userID pgtype.UUID) ([]*ListOrganizationsByUserRow, error)
+	ListPresetsByUser(ctx context.Context, userID pgtype.UUID) ([]*Preset, error)
+	ListProjectActivity(ctx context.Context, arg ListProjectActivityParams) ([]*ProjectActivity, error)
+	// The room types of the project's images in the order they were first uploaded, each with
+	// the style most of its images were staged in.
+	ListProjectImageRooms(ctx context.Context, projectID pgtype.UUID) ([]*ListProjectImageRoomsRow, error)
+	ListProjectInvitations(ctx context.Context, projectID pgtype.UUID) ([]*ProjectInvitation, error)
+	ListProjectRooms(ctx context.Context, projectID pgtype.UUID) ([]*ProjectRoom, error)
+	// Aggregates many of a user's projects for the dashboard grid in one round trip,
+	// including each project's most recent images as a JSON array. Projects the user
+	// doesn't own are left out.
+	ListProjectSummariesByUser(ctx context.Context, arg ListProjectSummariesByUserParams) ([]*ListProjectSummariesByUserRow, error)
+	ListProjectTemplates(ctx context.Context, userID pgtype.UUID) ([]*ProjectTemplate, error)
+	ListProjectThumbnails(ctx context.Context, arg ListProjectThumbnailsParams) ([]*ListProjectThumbnailsRow, error)
+	ListSCIMGroupMembers(ctx context.Context, groupIds []pgtype.UUID) ([]*ListSCIMGroupMembersRow, error)
+	// Lists the organization's SCIM groups, optionally filtered by display name
+	// (case-insensitively) or external ID.
+	ListSCIMGroups(ctx context.Context, arg ListSCIMGroupsParams) ([]*OrganizationScimGroup, error)
+	ListSCIMUserGroupNames(ctx context.Context, scimUserID pgtype.UUID) ([]string, error)
+	// Lists the organization's SCIM users, optionally filtered by user name (case-insensitively)
+	// or external ID.
+	ListSCIMUsers(ctx context.Context, arg ListSCIMUsersParams) ([]*OrganizationScimUser, error)
+	ListSettings(ctx context.Context) ([]*Setting, error)
 	ListSubscriptionsByUserID(ctx context.Context, arg ListSubscriptionsByUserIDParams) ([]*Subscription, error)
+	ListTeamWebhookDeliveries(ctx context.Context, arg ListTeamWebhookDeliveriesParams) ([]*TeamWebhookDelivery, error)
+	ListTeamWebhooks(ctx context.Context, userID pgtype.UUID) ([]*TeamWebhook, error)
+	// Lists the user's webhooks subscribed to an event.
+	ListTeamWebhooksForEvent(ctx context.Context, arg ListTeamWebhooksForEventParams) ([]*TeamWebhook, error)
+	ListUserEncryptedFields(ctx context.Context, arg ListUserEncryptedFieldsParams) ([]*ListUserEncryptedFieldsRow, error)
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]*ListUsersRow, error)
+	MarkAllNotificationsRead(ctx context.Context, userID pgtype.UUID) (int64, error)
+	MarkCustomerBucketVerified(ctx context.Context, userID pgtype.UUID) (*CustomerBucket, error)
+	// Takes a dead task off the list once it is back in the queue. Only a dead task can be
+	// marked, so a task is requeued once however often the request is sent.
+	MarkDeadLetterJobRequeued(ctx context.Context, id pgtype.UUID) (*DeadLetterJob, error)
+	// Marks one of the user's notifications read. Reading an already read notification keeps
+	// its original read_at. Returns no row when the notification is not the user's.
+	MarkNotificationRead(ctx context.Context, arg MarkNotificationReadParams) (*Notification, error)
+	MarkShareBrandingDomainVerified(ctx context.Context, userID pgtype.UUID) (*ShareBranding, error)
+	// Reports whether another organization already routes any of the email domains.
+	OrganizationSSODomainsTaken(ctx context.Context, arg OrganizationSSODomainsTakenParams) (bool, error)
+	PlaceImageLegalHold(ctx context.Context, arg PlaceImageLegalHoldParams) (*LegalHold, error)
+	PlaceProjectLegalHold(ctx context.Context, arg PlaceProjectLegalHoldParams) (*LegalHold, error)
+	RecordDataAccess(ctx context.Context, arg RecordDataAccessParams) (*DataAccessLog, error)
+	// Appends an image.expedited event, which is both the audit record and what the daily limit counts.
+	RecordImageExpedited(ctx context.Context, arg RecordImageExpeditedParams) error
+	RecordOrganizationAuditEvent(ctx context.Context, arg RecordOrganizationAuditEventParams) error
+	// Records the outcome of a delivery; a NULL error marks it successful.
+	RecordTeamWebhookDelivery(ctx context.Context, arg RecordTeamWebhookDeliveryParams) error
+	// Counts an attempt and moves the delivery to its new status; next_attempt_at is NULL
+	// unless it stays pending.
+	RecordTeamWebhookDeliveryAttempt(ctx context.Context, arg RecordTeamWebhookDeliveryAttemptParams) (*TeamWebhookDelivery, error)
+	ReleaseImageLegalHold(ctx context.Context, imageID pgtype.UUID) (int64, error)
+	ReleaseProjectLegalHold(ctx context.Context, projectID pgtype.UUID) (int64, error)
+	// Returns staged_images reserved for images that were never created.
+	ReleaseUsage(ctx context.Context, arg ReleaseUsageParams) error
+	RemoveProjectRetentionExemption(ctx context.Context, projectID pgtype.UUID) error
+	RemoveSCIMGroupMembers(ctx context.Context, arg RemoveSCIMGroupMembersParams) (int64, error)
+	// Adds staged_images to the user's count for the period unless the total would pass
+	// monthly_limit, in which case no row is returned.
+	ReserveUsage(ctx context.Context, arg ReserveUsageParams) (int32, error)
+	RevokeAPIKey(ctx context.Context, arg RevokeAPIKeyParams) (int64, error)
+	// Revokes an invitation and removes the access it granted, if it was accepted. Returns no
+	// row when the invitation is not in the project or was already revoked.
+	RevokeProjectInvitation(ctx context.Context, arg RevokeProjectInvitationParams) (*RevokeProjectInvitationRow, error)
+	// Moves a project to the next share key version, from 1 when it has none yet.
+	RotateProjectShareKey(ctx context.Context, projectID pgtype.UUID) (*ProjectShareKey, error)
+	// Replaces the signing secret; the old one keeps signing until previous_secret_expires_at.
+	RotateTeamWebhookSecret(ctx context.Context, arg RotateTeamWebhookSecretParams) (*TeamWebhook, error)
+	SetOrganizationIPAllowlist(ctx context.Context, arg SetOrganizationIPAllowlistParams) ([]string, error)
+	// Sets the room type of the listed images; images outside the project are left alone.
+	SetProjectImagesRoomType(ctx context.Context, arg SetProjectImagesRoomTypeParams) (int64, error)
 	StartJob(ctx context.Context, id pgtype.UUID) (*Job, error)
+	// Totals the amount paid, in cents, per currency on paid invoices created at or after since.
+	SumPaidInvoicesSince(ctx context.Context, since pgtype.Timestamptz) ([]*SumPaidInvoicesSinceRow, error)
+	// Puts the image back to @event_status, the status its log ends in, unless its status has
+	// moved on from @status since the drift was found. The status trigger logs the correction
+	// with the reconcile source, so the log still ends in @event_status.
+	SyncImageStatusFromEvents(ctx context.Context, arg SyncImageStatusFromEventsParams) (int64, error)
+	// Records use at most once a minute, so busy keys do not write on every request.
+	TouchAPIKey(ctx context.Context, id pgtype.UUID) error
+	// Resolves a SCIM bearer token to its organization and records its use.
+	TouchOrganizationSCIMToken(ctx context.Context, tokenHash []byte) (pgtype.UUID, error)
+	UpdateCatalog(ctx context.Context, arg UpdateCatalogParams) (*Catalog, error)
+	UpdateImageCost(ctx context.Context, arg UpdateImageCostParams) error
 	UpdateImageStatus(ctx context.Context, arg UpdateImageStatusParams) (*UpdateImageStatusRow, error)
 	UpdateImageWithError(ctx context.Context, arg UpdateImageWithErrorParams) (*UpdateImageWithErrorRow, error)
 	UpdateImageWithStagedURL(ctx context.Context, arg UpdateImageWithStagedURLParams) (*UpdateImageWithStagedURLRow, error)
 	UpdateJobStatus(ctx context.Context, arg UpdateJobStatusParams) (*Job, error)
+	UpdatePreset(ctx context.Context, arg UpdatePresetParams) (*Preset, error)
 	UpdateProject(ctx context.Context, arg UpdateProjectParams) (*UpdateProjectRow, error)
 	UpdateProjectByUserID(ctx context.Context, arg UpdateProjectByUserIDParams) (*UpdateProjectByUserIDRow, error)
+	UpdateSCIMGroup(ctx context.Context, arg UpdateSCIMGroupParams) (*OrganizationScimGroup, error)
+	UpdateSCIMUser(ctx context.Context, arg UpdateSCIMUserParams) (*OrganizationScimUser, error)
+	UpdateSetting(ctx context.Context, arg UpdateSettingParams) (int64, error)
+	UpdateUserEncryptedFields(ctx context.Context, arg UpdateUserEncryptedFieldsParams) error
 	UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (*UpdateUserProfileRow, error)
 	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (*UpdateUserRoleRow, error)
+	UpdateUserStorageRegion(ctx context.Context, arg UpdateUserStorageRegionParams) (int64, error)
 	UpdateUserStripeCustomerID(ctx context.Context, arg UpdateUserStripeCustomerIDParams) (*UpdateUserStripeCustomerIDRow, error)
-	// Invoices persistence queries for sqlc generation
+	UpsertAccountWatermark(ctx context.Context, arg UpsertAccountWatermarkParams) (*AccountWatermark, error)
+	// Saves the account's bucket settings. The external ID is only set on the first save, and
+	// any change clears verified_at until the next access check passes.
+	UpsertCustomerBucket(ctx context.Context, arg UpsertCustomerBucketParams) (*CustomerBucket, error)
+	// Invoices
 	// Upsert by unique stripe_invoice_id. We do not modify user_id on conflict.
 	UpsertInvoiceByStripeID(ctx context.Context, arg UpsertInvoiceByStripeIDParams) (*Invoice, error)
+	// Makes an active SCIM user a member with role. Memberships added any other way are taken
+	// over by the SCIM client.
+	UpsertOrganizationSCIMMember(ctx context.Context, arg UpsertOrganizationSCIMMemberParams) error
+	UpsertOrganizationSCIMToken(ctx context.Context, arg UpsertOrganizationSCIMTokenParams) (*OrganizationScimToken, error)
+	UpsertOrganizationSSO(ctx context.Context, arg UpsertOrganizationSSOParams) (*OrganizationSso, error)
+	// Adds a user signing in through the organization's connection, or moves an SSO-provisioned
+	// member to the role their claims now map to. Members added by hand or by SCIM keep their
+	// role, and users of the organization's SCIM client are never added; no row is returned for
+	// either.
+	UpsertOrganizationSSOMember(ctx context.Context, arg UpsertOrganizationSSOMemberParams) (*OrganizationMember, error)
+	// Parks the latest state of a subscription or invoice whose customer is not linked yet.
+	// A later event replaces the state but keeps the original expiry.
+	UpsertPendingBillingEvent(ctx context.Context, arg UpsertPendingBillingEventParams) error
 	// Optional: single-statement upsert that returns the existing/new row.
 	// Preserves existing values (no-op update) to obtain RETURNING without DO NOTHING.
 	UpsertProcessedEventByStripeID(ctx context.Context, arg UpsertProcessedEventByStripeIDParams) (*ProcessedEvent, error)
+	// Issues the project's pending deletion, replacing any earlier token.
+	UpsertProjectDeletionIntent(ctx context.Context, arg UpsertProjectDeletionIntentParams) error
+	UpsertProjectDisclosure(ctx context.Context, arg UpsertProjectDisclosureParams) (*ProjectDisclosure, error)
+	UpsertProjectListing(ctx context.Context, arg UpsertProjectListingParams) (*ProjectListing, error)
+	// Keeping the custom domain keeps its token and verification; changing it starts over.
+	UpsertShareBranding(ctx context.Context, arg UpsertShareBrandingParams) (*ShareBranding, error)
 	// Subscriptions (Stripe subscription state)
 	// Upsert by unique stripe_subscription_id. We do not modify user_id on conflict.
 	UpsertSubscriptionByStripeID(ctx context.Context, arg UpsertSubscriptionByStripeIDParams) (*Subscription, error)
+	// Saves the account's webhook for a provider. Changing it clears the last delivery error.
+	UpsertTeamWebhook(ctx context.Context, arg UpsertTeamWebhookParams) (*TeamWebhook, error)
 }
 
 var _ Querier = (*Queries)(nil)
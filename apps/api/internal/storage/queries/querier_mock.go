@@ -19,30 +19,174 @@ var _ Querier = &QuerierMock{}
 //
 //		// make and configure a mocked Querier
 //		mockedQuerier := &QuerierMock{
+//			AcceptProjectInvitationFunc: func(ctx context.Context, arg AcceptProjectInvitationParams) (*ProjectCollaborator, error) {
+//				panic("mock out the AcceptProjectInvitation method")
+//			},
+//			AddProjectRetentionExemptionFunc: func(ctx context.Context, projectID pgtype.UUID) error {
+//				panic("mock out the AddProjectRetentionExemption method")
+//			},
+//			AddSCIMGroupMembersFunc: func(ctx context.Context, arg AddSCIMGroupMembersParams) (int64, error) {
+//				panic("mock out the AddSCIMGroupMembers method")
+//			},
+//			ApplyProjectTemplateDisclosureFunc: func(ctx context.Context, arg ApplyProjectTemplateDisclosureParams) error {
+//				panic("mock out the ApplyProjectTemplateDisclosure method")
+//			},
+//			ApplyProjectTemplateRoomsFunc: func(ctx context.Context, arg ApplyProjectTemplateRoomsParams) error {
+//				panic("mock out the ApplyProjectTemplateRooms method")
+//			},
+//			BulkCancelImagesFunc: func(ctx context.Context, arg BulkCancelImagesParams) ([]*BulkCancelImagesRow, error) {
+//				panic("mock out the BulkCancelImages method")
+//			},
+//			BulkDeleteImagesFunc: func(ctx context.Context, arg BulkDeleteImagesParams) ([]*BulkDeleteImagesRow, error) {
+//				panic("mock out the BulkDeleteImages method")
+//			},
+//			CancelImageFunc: func(ctx context.Context, id pgtype.UUID) (*CancelImageRow, error) {
+//				panic("mock out the CancelImage method")
+//			},
+//			CancelJobsByImageIDFunc: func(ctx context.Context, imageID pgtype.UUID) ([]*Job, error) {
+//				panic("mock out the CancelJobsByImageID method")
+//			},
+//			ClaimProcessedEventFunc: func(ctx context.Context, arg ClaimProcessedEventParams) (*ProcessedEvent, error) {
+//				panic("mock out the ClaimProcessedEvent method")
+//			},
+//			ClaimSCIMUserFunc: func(ctx context.Context, arg ClaimSCIMUserParams) (int64, error) {
+//				panic("mock out the ClaimSCIMUser method")
+//			},
 //			CompleteJobFunc: func(ctx context.Context, id pgtype.UUID) (*Job, error) {
 //				panic("mock out the CompleteJob method")
 //			},
+//			ConfirmRoomGroupingFunc: func(ctx context.Context, arg ConfirmRoomGroupingParams) (*RoomGrouping, error) {
+//				panic("mock out the ConfirmRoomGrouping method")
+//			},
+//			ConsumeExportCreditFunc: func(ctx context.Context, arg ConsumeExportCreditParams) (*ExportCredit, error) {
+//				panic("mock out the ConsumeExportCredit method")
+//			},
+//			ConsumeProjectDeletionIntentFunc: func(ctx context.Context, arg ConsumeProjectDeletionIntentParams) (int64, error) {
+//				panic("mock out the ConsumeProjectDeletionIntent method")
+//			},
+//			CountAPIKeysByUserFunc: func(ctx context.Context, userID pgtype.UUID) (int64, error) {
+//				panic("mock out the CountAPIKeysByUser method")
+//			},
+//			CountActiveUsersSinceFunc: func(ctx context.Context, since pgtype.Timestamptz) (int64, error) {
+//				panic("mock out the CountActiveUsersSince method")
+//			},
+//			CountAvailableExportCreditsFunc: func(ctx context.Context, userID pgtype.UUID) (int64, error) {
+//				panic("mock out the CountAvailableExportCredits method")
+//			},
+//			CountExportableImagesFunc: func(ctx context.Context, projectID pgtype.UUID) (int64, error) {
+//				panic("mock out the CountExportableImages method")
+//			},
+//			CountJobsByStatusFunc: func(ctx context.Context) ([]*CountJobsByStatusRow, error) {
+//				panic("mock out the CountJobsByStatus method")
+//			},
+//			CountProjectImagesFunc: func(ctx context.Context, projectID pgtype.UUID) (int64, error) {
+//				panic("mock out the CountProjectImages method")
+//			},
+//			CountProjectImagesByRoomTypeFunc: func(ctx context.Context, projectID pgtype.UUID) ([]*CountProjectImagesByRoomTypeRow, error) {
+//				panic("mock out the CountProjectImagesByRoomType method")
+//			},
+//			CountProjectTemplatesFunc: func(ctx context.Context, userID pgtype.UUID) (int64, error) {
+//				panic("mock out the CountProjectTemplates method")
+//			},
 //			CountProjectsByUserIDFunc: func(ctx context.Context, userID pgtype.UUID) (int64, error) {
 //				panic("mock out the CountProjectsByUserID method")
 //			},
+//			CountSCIMGroupsFunc: func(ctx context.Context, arg CountSCIMGroupsParams) (int64, error) {
+//				panic("mock out the CountSCIMGroups method")
+//			},
+//			CountSCIMUsersFunc: func(ctx context.Context, arg CountSCIMUsersParams) (int64, error) {
+//				panic("mock out the CountSCIMUsers method")
+//			},
+//			CountUnreadNotificationsFunc: func(ctx context.Context, userID pgtype.UUID) (int64, error) {
+//				panic("mock out the CountUnreadNotifications method")
+//			},
 //			CountUsersFunc: func(ctx context.Context) (int64, error) {
 //				panic("mock out the CountUsers method")
 //			},
+//			CreateAPIKeyFunc: func(ctx context.Context, arg CreateAPIKeyParams) (*ApiKey, error) {
+//				panic("mock out the CreateAPIKey method")
+//			},
+//			CreateCatalogFunc: func(ctx context.Context, arg CreateCatalogParams) (*Catalog, error) {
+//				panic("mock out the CreateCatalog method")
+//			},
+//			CreateExportCreditFunc: func(ctx context.Context, arg CreateExportCreditParams) (int64, error) {
+//				panic("mock out the CreateExportCredit method")
+//			},
 //			CreateImageFunc: func(ctx context.Context, arg CreateImageParams) (*CreateImageRow, error) {
 //				panic("mock out the CreateImage method")
 //			},
+//			CreateImageAnnotationsFunc: func(ctx context.Context, arg CreateImageAnnotationsParams) error {
+//				panic("mock out the CreateImageAnnotations method")
+//			},
+//			CreateImageBracketsFunc: func(ctx context.Context, arg CreateImageBracketsParams) error {
+//				panic("mock out the CreateImageBrackets method")
+//			},
+//			CreateImageEditFunc: func(ctx context.Context, arg CreateImageEditParams) (*ImageEdit, error) {
+//				panic("mock out the CreateImageEdit method")
+//			},
+//			CreateImagesFunc: func(ctx context.Context, arg []CreateImagesParams) (int64, error) {
+//				panic("mock out the CreateImages method")
+//			},
 //			CreateJobFunc: func(ctx context.Context, arg CreateJobParams) (*Job, error) {
 //				panic("mock out the CreateJob method")
 //			},
+//			CreateJobsFunc: func(ctx context.Context, arg []CreateJobsParams) (int64, error) {
+//				panic("mock out the CreateJobs method")
+//			},
+//			CreateNotificationFunc: func(ctx context.Context, arg CreateNotificationParams) (*Notification, error) {
+//				panic("mock out the CreateNotification method")
+//			},
+//			CreateOrganizationFunc: func(ctx context.Context, arg CreateOrganizationParams) (*CreateOrganizationRow, error) {
+//				panic("mock out the CreateOrganization method")
+//			},
+//			CreateOrganizationBreakGlassFunc: func(ctx context.Context, arg CreateOrganizationBreakGlassParams) (*OrganizationBreakGlass, error) {
+//				panic("mock out the CreateOrganizationBreakGlass method")
+//			},
+//			CreatePresetFunc: func(ctx context.Context, arg CreatePresetParams) (*Preset, error) {
+//				panic("mock out the CreatePreset method")
+//			},
 //			CreateProcessedEventFunc: func(ctx context.Context, arg CreateProcessedEventParams) (*ProcessedEvent, error) {
 //				panic("mock out the CreateProcessedEvent method")
 //			},
 //			CreateProjectFunc: func(ctx context.Context, arg CreateProjectParams) (*CreateProjectRow, error) {
 //				panic("mock out the CreateProject method")
 //			},
+//			CreateProjectExportFunc: func(ctx context.Context, arg CreateProjectExportParams) (*ProjectExport, error) {
+//				panic("mock out the CreateProjectExport method")
+//			},
+//			CreateProjectInvitationFunc: func(ctx context.Context, arg CreateProjectInvitationParams) (*ProjectInvitation, error) {
+//				panic("mock out the CreateProjectInvitation method")
+//			},
+//			CreateProjectRoomsFunc: func(ctx context.Context, arg CreateProjectRoomsParams) error {
+//				panic("mock out the CreateProjectRooms method")
+//			},
+//			CreateProjectTemplateFunc: func(ctx context.Context, arg CreateProjectTemplateParams) (*ProjectTemplate, error) {
+//				panic("mock out the CreateProjectTemplate method")
+//			},
+//			CreateRoomGroupingFunc: func(ctx context.Context, projectID pgtype.UUID) (*RoomGrouping, error) {
+//				panic("mock out the CreateRoomGrouping method")
+//			},
+//			CreateSCIMGroupFunc: func(ctx context.Context, arg CreateSCIMGroupParams) (*OrganizationScimGroup, error) {
+//				panic("mock out the CreateSCIMGroup method")
+//			},
+//			CreateSCIMUserFunc: func(ctx context.Context, arg CreateSCIMUserParams) (*OrganizationScimUser, error) {
+//				panic("mock out the CreateSCIMUser method")
+//			},
+//			CreateTeamWebhookDeliveryFunc: func(ctx context.Context, arg CreateTeamWebhookDeliveryParams) (*TeamWebhookDelivery, error) {
+//				panic("mock out the CreateTeamWebhookDelivery method")
+//			},
 //			CreateUserFunc: func(ctx context.Context, arg CreateUserParams) (*CreateUserRow, error) {
 //				panic("mock out the CreateUser method")
 //			},
+//			DeleteAccountWatermarkFunc: func(ctx context.Context, userID pgtype.UUID) (int64, error) {
+//				panic("mock out the DeleteAccountWatermark method")
+//			},
+//			DeleteCatalogFunc: func(ctx context.Context, id pgtype.UUID) (int64, error) {
+//				panic("mock out the DeleteCatalog method")
+//			},
+//			DeleteCustomerBucketFunc: func(ctx context.Context, userID pgtype.UUID) (int64, error) {
+//				panic("mock out the DeleteCustomerBucket method")
+//			},
 //			DeleteImageFunc: func(ctx context.Context, id pgtype.UUID) error {
 //				panic("mock out the DeleteImage method")
 //			},
@@ -58,27 +202,108 @@ var _ Querier = &QuerierMock{}
 //			DeleteOldProcessedEventsFunc: func(ctx context.Context, receivedAt pgtype.Timestamptz) error {
 //				panic("mock out the DeleteOldProcessedEvents method")
 //			},
-//			DeleteProjectFunc: func(ctx context.Context, id pgtype.UUID) error {
+//			DeleteOrganizationSCIMMemberFunc: func(ctx context.Context, arg DeleteOrganizationSCIMMemberParams) error {
+//				panic("mock out the DeleteOrganizationSCIMMember method")
+//			},
+//			DeleteOrganizationSCIMTokenFunc: func(ctx context.Context, organizationID pgtype.UUID) (int64, error) {
+//				panic("mock out the DeleteOrganizationSCIMToken method")
+//			},
+//			DeleteOrganizationSSOFunc: func(ctx context.Context, organizationID pgtype.UUID) (int64, error) {
+//				panic("mock out the DeleteOrganizationSSO method")
+//			},
+//			DeletePresetFunc: func(ctx context.Context, arg DeletePresetParams) (int64, error) {
+//				panic("mock out the DeletePreset method")
+//			},
+//			DeleteProjectFunc: func(ctx context.Context, id pgtype.UUID) (int64, error) {
 //				panic("mock out the DeleteProject method")
 //			},
-//			DeleteProjectByUserIDFunc: func(ctx context.Context, arg DeleteProjectByUserIDParams) error {
+//			DeleteProjectByUserIDFunc: func(ctx context.Context, arg DeleteProjectByUserIDParams) (int64, error) {
 //				panic("mock out the DeleteProjectByUserID method")
 //			},
+//			DeleteProjectRoomsFunc: func(ctx context.Context, projectID pgtype.UUID) error {
+//				panic("mock out the DeleteProjectRooms method")
+//			},
+//			DeleteProjectTemplateFunc: func(ctx context.Context, arg DeleteProjectTemplateParams) (int64, error) {
+//				panic("mock out the DeleteProjectTemplate method")
+//			},
+//			DeleteSCIMGroupFunc: func(ctx context.Context, arg DeleteSCIMGroupParams) (int64, error) {
+//				panic("mock out the DeleteSCIMGroup method")
+//			},
+//			DeleteSCIMUserFunc: func(ctx context.Context, arg DeleteSCIMUserParams) (int64, error) {
+//				panic("mock out the DeleteSCIMUser method")
+//			},
+//			DeleteShareBrandingFunc: func(ctx context.Context, userID pgtype.UUID) (int64, error) {
+//				panic("mock out the DeleteShareBranding method")
+//			},
 //			DeleteSubscriptionByStripeIDFunc: func(ctx context.Context, stripeSubscriptionID string) error {
 //				panic("mock out the DeleteSubscriptionByStripeID method")
 //			},
+//			DeleteTeamWebhookFunc: func(ctx context.Context, arg DeleteTeamWebhookParams) (int64, error) {
+//				panic("mock out the DeleteTeamWebhook method")
+//			},
 //			DeleteUserFunc: func(ctx context.Context, id pgtype.UUID) error {
 //				panic("mock out the DeleteUser method")
 //			},
+//			FailImageEditFunc: func(ctx context.Context, arg FailImageEditParams) error {
+//				panic("mock out the FailImageEdit method")
+//			},
 //			FailJobFunc: func(ctx context.Context, arg FailJobParams) (*Job, error) {
 //				panic("mock out the FailJob method")
 //			},
+//			FailProjectExportFunc: func(ctx context.Context, arg FailProjectExportParams) error {
+//				panic("mock out the FailProjectExport method")
+//			},
+//			FailRoomGroupingFunc: func(ctx context.Context, arg FailRoomGroupingParams) error {
+//				panic("mock out the FailRoomGrouping method")
+//			},
+//			GetAPIKeyByHashFunc: func(ctx context.Context, keyHash []byte) (*GetAPIKeyByHashRow, error) {
+//				panic("mock out the GetAPIKeyByHash method")
+//			},
+//			GetAccountWatermarkFunc: func(ctx context.Context, userID pgtype.UUID) (*AccountWatermark, error) {
+//				panic("mock out the GetAccountWatermark method")
+//			},
 //			GetAllProjectsFunc: func(ctx context.Context) ([]*GetAllProjectsRow, error) {
 //				panic("mock out the GetAllProjects method")
 //			},
+//			GetCatalogByIDFunc: func(ctx context.Context, id pgtype.UUID) (*Catalog, error) {
+//				panic("mock out the GetCatalogByID method")
+//			},
+//			GetCustomerBucketAccessFunc: func(ctx context.Context, userID pgtype.UUID) (bool, error) {
+//				panic("mock out the GetCustomerBucketAccess method")
+//			},
+//			GetCustomerBucketByNameFunc: func(ctx context.Context, bucket string) (*CustomerBucket, error) {
+//				panic("mock out the GetCustomerBucketByName method")
+//			},
+//			GetCustomerBucketByUserIDFunc: func(ctx context.Context, userID pgtype.UUID) (*CustomerBucket, error) {
+//				panic("mock out the GetCustomerBucketByUserID method")
+//			},
+//			GetDeadLetterJobFunc: func(ctx context.Context, id pgtype.UUID) (*DeadLetterJob, error) {
+//				panic("mock out the GetDeadLetterJob method")
+//			},
+//			GetExpediteAccessFunc: func(ctx context.Context, arg GetExpediteAccessParams) (*GetExpediteAccessRow, error) {
+//				panic("mock out the GetExpediteAccess method")
+//			},
+//			GetImageAnalyticsBucketsFunc: func(ctx context.Context, arg GetImageAnalyticsBucketsParams) ([]*GetImageAnalyticsBucketsRow, error) {
+//				panic("mock out the GetImageAnalyticsBuckets method")
+//			},
 //			GetImageByIDFunc: func(ctx context.Context, id pgtype.UUID) (*GetImageByIDRow, error) {
 //				panic("mock out the GetImageByID method")
 //			},
+//			GetImageEditFunc: func(ctx context.Context, arg GetImageEditParams) (*ImageEdit, error) {
+//				panic("mock out the GetImageEdit method")
+//			},
+//			GetImageEditSourceFunc: func(ctx context.Context, id pgtype.UUID) (*GetImageEditSourceRow, error) {
+//				panic("mock out the GetImageEditSource method")
+//			},
+//			GetImageSourceBreakdownFunc: func(ctx context.Context, arg GetImageSourceBreakdownParams) ([]*GetImageSourceBreakdownRow, error) {
+//				panic("mock out the GetImageSourceBreakdown method")
+//			},
+//			GetImageStatusesByIDsFunc: func(ctx context.Context, arg GetImageStatusesByIDsParams) ([]*GetImageStatusesByIDsRow, error) {
+//				panic("mock out the GetImageStatusesByIDs method")
+//			},
+//			GetImagesByIDsFunc: func(ctx context.Context, ids []pgtype.UUID) ([]*GetImagesByIDsRow, error) {
+//				panic("mock out the GetImagesByIDs method")
+//			},
 //			GetImagesByProjectIDFunc: func(ctx context.Context, projectID pgtype.UUID) ([]*GetImagesByProjectIDRow, error) {
 //				panic("mock out the GetImagesByProjectID method")
 //			},
@@ -88,24 +313,129 @@ var _ Querier = &QuerierMock{}
 //			GetJobByIDFunc: func(ctx context.Context, id pgtype.UUID) (*Job, error) {
 //				panic("mock out the GetJobByID method")
 //			},
+//			GetJobLogFunc: func(ctx context.Context, arg GetJobLogParams) (*JobLog, error) {
+//				panic("mock out the GetJobLog method")
+//			},
+//			GetJobOutcomesSinceFunc: func(ctx context.Context, since pgtype.Timestamptz) (*GetJobOutcomesSinceRow, error) {
+//				panic("mock out the GetJobOutcomesSince method")
+//			},
 //			GetJobsByImageIDFunc: func(ctx context.Context, imageID pgtype.UUID) ([]*Job, error) {
 //				panic("mock out the GetJobsByImageID method")
 //			},
+//			GetLatestRoomGroupingFunc: func(ctx context.Context, projectID pgtype.UUID) (*RoomGrouping, error) {
+//				panic("mock out the GetLatestRoomGrouping method")
+//			},
+//			GetOrganizationIPAllowlistFunc: func(ctx context.Context, id pgtype.UUID) ([]string, error) {
+//				panic("mock out the GetOrganizationIPAllowlist method")
+//			},
+//			GetOrganizationMemberFunc: func(ctx context.Context, arg GetOrganizationMemberParams) (*OrganizationMember, error) {
+//				panic("mock out the GetOrganizationMember method")
+//			},
+//			GetOrganizationSSOFunc: func(ctx context.Context, organizationID pgtype.UUID) (*OrganizationSso, error) {
+//				panic("mock out the GetOrganizationSSO method")
+//			},
+//			GetOrganizationSSOAccessFunc: func(ctx context.Context, id pgtype.UUID) (bool, error) {
+//				panic("mock out the GetOrganizationSSOAccess method")
+//			},
+//			GetOrganizationSSOByConnectionFunc: func(ctx context.Context, connection string) (*OrganizationSso, error) {
+//				panic("mock out the GetOrganizationSSOByConnection method")
+//			},
+//			GetOrganizationSSOByEmailDomainFunc: func(ctx context.Context, domain string) (*OrganizationSso, error) {
+//				panic("mock out the GetOrganizationSSOByEmailDomain method")
+//			},
 //			GetPendingJobsFunc: func(ctx context.Context, limit int32) ([]*Job, error) {
 //				panic("mock out the GetPendingJobs method")
 //			},
+//			GetPresetForProjectFunc: func(ctx context.Context, arg GetPresetForProjectParams) (*Preset, error) {
+//				panic("mock out the GetPresetForProject method")
+//			},
 //			GetProcessedEventByStripeIDFunc: func(ctx context.Context, stripeEventID string) (*ProcessedEvent, error) {
 //				panic("mock out the GetProcessedEventByStripeID method")
 //			},
 //			GetProjectByIDFunc: func(ctx context.Context, id pgtype.UUID) (*GetProjectByIDRow, error) {
 //				panic("mock out the GetProjectByID method")
 //			},
+//			GetProjectByIDAndUserIDFunc: func(ctx context.Context, arg GetProjectByIDAndUserIDParams) (*GetProjectByIDAndUserIDRow, error) {
+//				panic("mock out the GetProjectByIDAndUserID method")
+//			},
+//			GetProjectByIDForMemberFunc: func(ctx context.Context, arg GetProjectByIDForMemberParams) (*GetProjectByIDForMemberRow, error) {
+//				panic("mock out the GetProjectByIDForMember method")
+//			},
+//			GetProjectCostSummaryFunc: func(ctx context.Context, projectID pgtype.UUID) (*GetProjectCostSummaryRow, error) {
+//				panic("mock out the GetProjectCostSummary method")
+//			},
+//			GetProjectDisclosureFunc: func(ctx context.Context, projectID pgtype.UUID) (*ProjectDisclosure, error) {
+//				panic("mock out the GetProjectDisclosure method")
+//			},
+//			GetProjectExportFunc: func(ctx context.Context, arg GetProjectExportParams) (*ProjectExport, error) {
+//				panic("mock out the GetProjectExport method")
+//			},
+//			GetProjectExportCreditFunc: func(ctx context.Context, projectID pgtype.UUID) (*ExportCredit, error) {
+//				panic("mock out the GetProjectExportCredit method")
+//			},
+//			GetProjectInvitationFunc: func(ctx context.Context, id pgtype.UUID) (*ProjectInvitation, error) {
+//				panic("mock out the GetProjectInvitation method")
+//			},
+//			GetProjectListingFunc: func(ctx context.Context, projectID pgtype.UUID) (*ProjectListing, error) {
+//				panic("mock out the GetProjectListing method")
+//			},
+//			GetProjectRoomStyleFunc: func(ctx context.Context, arg GetProjectRoomStyleParams) (string, error) {
+//				panic("mock out the GetProjectRoomStyle method")
+//			},
+//			GetProjectShareKeyFunc: func(ctx context.Context, projectID pgtype.UUID) (*ProjectShareKey, error) {
+//				panic("mock out the GetProjectShareKey method")
+//			},
+//			GetProjectSummaryFunc: func(ctx context.Context, id pgtype.UUID) (*GetProjectSummaryRow, error) {
+//				panic("mock out the GetProjectSummary method")
+//			},
+//			GetProjectTemplateFunc: func(ctx context.Context, arg GetProjectTemplateParams) (*ProjectTemplate, error) {
+//				panic("mock out the GetProjectTemplate method")
+//			},
 //			GetProjectsByUserIDFunc: func(ctx context.Context, userID pgtype.UUID) ([]*GetProjectsByUserIDRow, error) {
 //				panic("mock out the GetProjectsByUserID method")
 //			},
+//			GetReferenceImageAccessFunc: func(ctx context.Context, id pgtype.UUID) (*GetReferenceImageAccessRow, error) {
+//				panic("mock out the GetReferenceImageAccess method")
+//			},
+//			GetRoomGroupingFunc: func(ctx context.Context, arg GetRoomGroupingParams) (*RoomGrouping, error) {
+//				panic("mock out the GetRoomGrouping method")
+//			},
+//			GetSCIMGroupFunc: func(ctx context.Context, arg GetSCIMGroupParams) (*OrganizationScimGroup, error) {
+//				panic("mock out the GetSCIMGroup method")
+//			},
+//			GetSCIMUserFunc: func(ctx context.Context, arg GetSCIMUserParams) (*OrganizationScimUser, error) {
+//				panic("mock out the GetSCIMUser method")
+//			},
+//			GetSettingFunc: func(ctx context.Context, key string) (*Setting, error) {
+//				panic("mock out the GetSetting method")
+//			},
+//			GetShareBrandingFunc: func(ctx context.Context, userID pgtype.UUID) (*ShareBranding, error) {
+//				panic("mock out the GetShareBranding method")
+//			},
+//			GetShareBrandingByVerifiedDomainFunc: func(ctx context.Context, customDomain pgtype.Text) (*ShareBranding, error) {
+//				panic("mock out the GetShareBrandingByVerifiedDomain method")
+//			},
+//			GetStylePopularityFunc: func(ctx context.Context, arg GetStylePopularityParams) ([]*GetStylePopularityRow, error) {
+//				panic("mock out the GetStylePopularity method")
+//			},
 //			GetSubscriptionByStripeIDFunc: func(ctx context.Context, stripeSubscriptionID string) (*Subscription, error) {
 //				panic("mock out the GetSubscriptionByStripeID method")
 //			},
+//			GetTeamWebhookFunc: func(ctx context.Context, arg GetTeamWebhookParams) (*TeamWebhook, error) {
+//				panic("mock out the GetTeamWebhook method")
+//			},
+//			GetTeamWebhookDeliveryFunc: func(ctx context.Context, arg GetTeamWebhookDeliveryParams) (*TeamWebhookDelivery, error) {
+//				panic("mock out the GetTeamWebhookDelivery method")
+//			},
+//			GetTurnaroundSLAByPlanFunc: func(ctx context.Context, arg GetTurnaroundSLAByPlanParams) ([]*GetTurnaroundSLAByPlanRow, error) {
+//				panic("mock out the GetTurnaroundSLAByPlan method")
+//			},
+//			GetUsageFunc: func(ctx context.Context, arg GetUsageParams) (int32, error) {
+//				panic("mock out the GetUsage method")
+//			},
+//			GetUserBillingPeriodFunc: func(ctx context.Context, userID pgtype.UUID) (*GetUserBillingPeriodRow, error) {
+//				panic("mock out the GetUserBillingPeriod method")
+//			},
 //			GetUserByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*GetUserByAuth0SubRow, error) {
 //				panic("mock out the GetUserByAuth0Sub method")
 //			},
@@ -115,27 +445,237 @@ var _ Querier = &QuerierMock{}
 //			GetUserByStripeCustomerIDFunc: func(ctx context.Context, stripeCustomerID pgtype.Text) (*GetUserByStripeCustomerIDRow, error) {
 //				panic("mock out the GetUserByStripeCustomerID method")
 //			},
+//			GetUserByStripeCustomerIDHashFunc: func(ctx context.Context, stripeCustomerIDHash pgtype.Text) (*GetUserByStripeCustomerIDHashRow, error) {
+//				panic("mock out the GetUserByStripeCustomerIDHash method")
+//			},
+//			GetUserEntitlementsFunc: func(ctx context.Context, userID pgtype.UUID) (*GetUserEntitlementsRow, error) {
+//				panic("mock out the GetUserEntitlements method")
+//			},
 //			GetUserProfileByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*GetUserProfileByAuth0SubRow, error) {
 //				panic("mock out the GetUserProfileByAuth0Sub method")
 //			},
 //			GetUserProfileByIDFunc: func(ctx context.Context, id pgtype.UUID) (*GetUserProfileByIDRow, error) {
 //				panic("mock out the GetUserProfileByID method")
 //			},
+//			GetUserStorageRegionFunc: func(ctx context.Context, id pgtype.UUID) (pgtype.Text, error) {
+//				panic("mock out the GetUserStorageRegion method")
+//			},
+//			HasActiveRoomGroupingFunc: func(ctx context.Context, projectID pgtype.UUID) (bool, error) {
+//				panic("mock out the HasActiveRoomGrouping method")
+//			},
+//			IsImageUnderLegalHoldFunc: func(ctx context.Context, id pgtype.UUID) (bool, error) {
+//				panic("mock out the IsImageUnderLegalHold method")
+//			},
+//			IsProjectRetentionExemptFunc: func(ctx context.Context, projectID pgtype.UUID) (bool, error) {
+//				panic("mock out the IsProjectRetentionExempt method")
+//			},
+//			IsProjectUnderLegalHoldFunc: func(ctx context.Context, projectID pgtype.UUID) (bool, error) {
+//				panic("mock out the IsProjectUnderLegalHold method")
+//			},
+//			ListAPIKeysByUserFunc: func(ctx context.Context, userID pgtype.UUID) ([]*ApiKey, error) {
+//				panic("mock out the ListAPIKeysByUser method")
+//			},
+//			ListActiveCatalogsFunc: func(ctx context.Context) ([]*Catalog, error) {
+//				panic("mock out the ListActiveCatalogs method")
+//			},
+//			ListCatalogsFunc: func(ctx context.Context) ([]*Catalog, error) {
+//				panic("mock out the ListCatalogs method")
+//			},
+//			ListDataAccessByOwnerFunc: func(ctx context.Context, arg ListDataAccessByOwnerParams) ([]*DataAccessLog, error) {
+//				panic("mock out the ListDataAccessByOwner method")
+//			},
+//			ListDeadLetterJobsFunc: func(ctx context.Context, arg ListDeadLetterJobsParams) ([]*DeadLetterJob, error) {
+//				panic("mock out the ListDeadLetterJobs method")
+//			},
+//			ListIPAllowlistsForSubjectFunc: func(ctx context.Context, auth0Sub string) ([]*ListIPAllowlistsForSubjectRow, error) {
+//				panic("mock out the ListIPAllowlistsForSubject method")
+//			},
+//			ListImageStatusDriftFunc: func(ctx context.Context, imageIds []pgtype.UUID) ([]*ListImageStatusDriftRow, error) {
+//				panic("mock out the ListImageStatusDrift method")
+//			},
+//			ListImageStatusTransitionsFunc: func(ctx context.Context, imageID pgtype.UUID) ([]*ImageStatusTransition, error) {
+//				panic("mock out the ListImageStatusTransitions method")
+//			},
 //			ListImagesForReconcileFunc: func(ctx context.Context, arg ListImagesForReconcileParams) ([]*ListImagesForReconcileRow, error) {
 //				panic("mock out the ListImagesForReconcile method")
 //			},
 //			ListInvoicesByUserIDFunc: func(ctx context.Context, arg ListInvoicesByUserIDParams) ([]*Invoice, error) {
 //				panic("mock out the ListInvoicesByUserID method")
 //			},
+//			ListJobsFunc: func(ctx context.Context, arg ListJobsParams) ([]*Job, error) {
+//				panic("mock out the ListJobs method")
+//			},
+//			ListLegalHeldImageIDsFunc: func(ctx context.Context, imageIds []pgtype.UUID) ([]pgtype.UUID, error) {
+//				panic("mock out the ListLegalHeldImageIDs method")
+//			},
+//			ListLegalHoldsFunc: func(ctx context.Context) ([]*LegalHold, error) {
+//				panic("mock out the ListLegalHolds method")
+//			},
+//			ListNotificationsFunc: func(ctx context.Context, arg ListNotificationsParams) ([]*Notification, error) {
+//				panic("mock out the ListNotifications method")
+//			},
+//			ListOrganizationAuditLogFunc: func(ctx context.Context, arg ListOrganizationAuditLogParams) ([]*OrganizationAuditLog, error) {
+//				panic("mock out the ListOrganizationAuditLog method")
+//			},
+//			ListOrganizationMembersFunc: func(ctx context.Context, organizationID pgtype.UUID) ([]*OrganizationMember, error) {
+//				panic("mock out the ListOrganizationMembers method")
+//			},
+//			ListOrganizationsByUserFunc: func(ctx context.Context, userID pgtype.UUID) ([]*ListOrganizationsByUserRow, error) {
+//				panic("mock out the ListOrganizationsByUser method")
+//			},
+//			ListPresetsByUserFunc: func(ctx context.Context, userID pgtype.UUID) ([]*Preset, error) {
+//				panic("mock out the ListPresetsByUser method")
+//			},
+//			ListProjectActivityFunc: func(ctx context.Context, arg ListProjectActivityParams) ([]*ProjectActivity, error) {
+//				panic("mock out the ListProjectActivity method")
+//			},
+//			ListProjectImageRoomsFunc: func(ctx context.Context, projectID pgtype.UUID) ([]*ListProjectImageRoomsRow, error) {
+//				panic("mock out the ListProjectImageRooms method")
+//			},
+//			ListProjectInvitationsFunc: func(ctx context.Context, projectID pgtype.UUID) ([]*ProjectInvitation, error) {
+//				panic("mock out the ListProjectInvitations method")
+//			},
+//			ListProjectRoomsFunc: func(ctx context.Context, projectID pgtype.UUID) ([]*ProjectRoom, error) {
+//				panic("mock out the ListProjectRooms method")
+//			},
+//			ListProjectSummariesByUserFunc: func(ctx context.Context, arg ListProjectSummariesByUserParams) ([]*ListProjectSummariesByUserRow, error) {
+//				panic("mock out the ListProjectSummariesByUser method")
+//			},
+//			ListProjectTemplatesFunc: func(ctx context.Context, userID pgtype.UUID) ([]*ProjectTemplate, error) {
+//				panic("mock out the ListProjectTemplates method")
+//			},
+//			ListProjectThumbnailsFunc: func(ctx context.Context, arg ListProjectThumbnailsParams) ([]*ListProjectThumbnailsRow, error) {
+//				panic("mock out the ListProjectThumbnails method")
+//			},
+//			ListSCIMGroupMembersFunc: func(ctx context.Context, groupIds []pgtype.UUID) ([]*ListSCIMGroupMembersRow, error) {
+//				panic("mock out the ListSCIMGroupMembers method")
+//			},
+//			ListSCIMGroupsFunc: func(ctx context.Context, arg ListSCIMGroupsParams) ([]*OrganizationScimGroup, error) {
+//				panic("mock out the ListSCIMGroups method")
+//			},
+//			ListSCIMUserGroupNamesFunc: func(ctx context.Context, scimUserID pgtype.UUID) ([]string, error) {
+//				panic("mock out the ListSCIMUserGroupNames method")
+//			},
+//			ListSCIMUsersFunc: func(ctx context.Context, arg ListSCIMUsersParams) ([]*OrganizationScimUser, error) {
+//				panic("mock out the ListSCIMUsers method")
+//			},
+//			ListSettingsFunc: func(ctx context.Context) ([]*Setting, error) {
+//				panic("mock out the ListSettings method")
+//			},
 //			ListSubscriptionsByUserIDFunc: func(ctx context.Context, arg ListSubscriptionsByUserIDParams) ([]*Subscription, error) {
 //				panic("mock out the ListSubscriptionsByUserID method")
 //			},
+//			ListTeamWebhookDeliveriesFunc: func(ctx context.Context, arg ListTeamWebhookDeliveriesParams) ([]*TeamWebhookDelivery, error) {
+//				panic("mock out the ListTeamWebhookDeliveries method")
+//			},
+//			ListTeamWebhooksFunc: func(ctx context.Context, userID pgtype.UUID) ([]*TeamWebhook, error) {
+//				panic("mock out the ListTeamWebhooks method")
+//			},
+//			ListTeamWebhooksForEventFunc: func(ctx context.Context, arg ListTeamWebhooksForEventParams) ([]*TeamWebhook, error) {
+//				panic("mock out the ListTeamWebhooksForEvent method")
+//			},
+//			ListUserEncryptedFieldsFunc: func(ctx context.Context, arg ListUserEncryptedFieldsParams) ([]*ListUserEncryptedFieldsRow, error) {
+//				panic("mock out the ListUserEncryptedFields method")
+//			},
 //			ListUsersFunc: func(ctx context.Context, arg ListUsersParams) ([]*ListUsersRow, error) {
 //				panic("mock out the ListUsers method")
 //			},
+//			MarkAllNotificationsReadFunc: func(ctx context.Context, userID pgtype.UUID) (int64, error) {
+//				panic("mock out the MarkAllNotificationsRead method")
+//			},
+//			MarkCustomerBucketVerifiedFunc: func(ctx context.Context, userID pgtype.UUID) (*CustomerBucket, error) {
+//				panic("mock out the MarkCustomerBucketVerified method")
+//			},
+//			MarkDeadLetterJobRequeuedFunc: func(ctx context.Context, id pgtype.UUID) (*DeadLetterJob, error) {
+//				panic("mock out the MarkDeadLetterJobRequeued method")
+//			},
+//			MarkNotificationReadFunc: func(ctx context.Context, arg MarkNotificationReadParams) (*Notification, error) {
+//				panic("mock out the MarkNotificationRead method")
+//			},
+//			MarkShareBrandingDomainVerifiedFunc: func(ctx context.Context, userID pgtype.UUID) (*ShareBranding, error) {
+//				panic("mock out the MarkShareBrandingDomainVerified method")
+//			},
+//			OrganizationSSODomainsTakenFunc: func(ctx context.Context, arg OrganizationSSODomainsTakenParams) (bool, error) {
+//				panic("mock out the OrganizationSSODomainsTaken method")
+//			},
+//			PlaceImageLegalHoldFunc: func(ctx context.Context, arg PlaceImageLegalHoldParams) (*LegalHold, error) {
+//				panic("mock out the PlaceImageLegalHold method")
+//			},
+//			PlaceProjectLegalHoldFunc: func(ctx context.Context, arg PlaceProjectLegalHoldParams) (*LegalHold, error) {
+//				panic("mock out the PlaceProjectLegalHold method")
+//			},
+//			RecordDataAccessFunc: func(ctx context.Context, arg RecordDataAccessParams) (*DataAccessLog, error) {
+//				panic("mock out the RecordDataAccess method")
+//			},
+//			RecordImageExpeditedFunc: func(ctx context.Context, arg RecordImageExpeditedParams) error {
+//				panic("mock out the RecordImageExpedited method")
+//			},
+//			RecordOrganizationAuditEventFunc: func(ctx context.Context, arg RecordOrganizationAuditEventParams) error {
+//				panic("mock out the RecordOrganizationAuditEvent method")
+//			},
+//			RecordTeamWebhookDeliveryFunc: func(ctx context.Context, arg RecordTeamWebhookDeliveryParams) error {
+//				panic("mock out the RecordTeamWebhookDelivery method")
+//			},
+//			RecordTeamWebhookDeliveryAttemptFunc: func(ctx context.Context, arg RecordTeamWebhookDeliveryAttemptParams) (*TeamWebhookDelivery, error) {
+//				panic("mock out the RecordTeamWebhookDeliveryAttempt method")
+//			},
+//			ReleaseImageLegalHoldFunc: func(ctx context.Context, imageID pgtype.UUID) (int64, error) {
+//				panic("mock out the ReleaseImageLegalHold method")
+//			},
+//			ReleaseProjectLegalHoldFunc: func(ctx context.Context, projectID pgtype.UUID) (int64, error) {
+//				panic("mock out the ReleaseProjectLegalHold method")
+//			},
+//			ReleaseUsageFunc: func(ctx context.Context, arg ReleaseUsageParams) error {
+//				panic("mock out the ReleaseUsage method")
+//			},
+//			RemoveProjectRetentionExemptionFunc: func(ctx context.Context, projectID pgtype.UUID) error {
+//				panic("mock out the RemoveProjectRetentionExemption method")
+//			},
+//			RemoveSCIMGroupMembersFunc: func(ctx context.Context, arg RemoveSCIMGroupMembersParams) (int64, error) {
+//				panic("mock out the RemoveSCIMGroupMembers method")
+//			},
+//			ReserveUsageFunc: func(ctx context.Context, arg ReserveUsageParams) (int32, error) {
+//				panic("mock out the ReserveUsage method")
+//			},
+//			RevokeAPIKeyFunc: func(ctx context.Context, arg RevokeAPIKeyParams) (int64, error) {
+//				panic("mock out the RevokeAPIKey method")
+//			},
+//			RevokeProjectInvitationFunc: func(ctx context.Context, arg RevokeProjectInvitationParams) (*RevokeProjectInvitationRow, error) {
+//				panic("mock out the RevokeProjectInvitation method")
+//			},
+//			RotateProjectShareKeyFunc: func(ctx context.Context, projectID pgtype.UUID) (*ProjectShareKey, error) {
+//				panic("mock out the RotateProjectShareKey method")
+//			},
+//			RotateTeamWebhookSecretFunc: func(ctx context.Context, arg RotateTeamWebhookSecretParams) (*TeamWebhook, error) {
+//				panic("mock out the RotateTeamWebhookSecret method")
+//			},
+//			SetOrganizationIPAllowlistFunc: func(ctx context.Context, arg SetOrganizationIPAllowlistParams) ([]string, error) {
+//				panic("mock out the SetOrganizationIPAllowlist method")
+//			},
+//			SetProjectImagesRoomTypeFunc: func(ctx context.Context, arg SetProjectImagesRoomTypeParams) (int64, error) {
+//				panic("mock out the SetProjectImagesRoomType method")
+//			},
 //			StartJobFunc: func(ctx context.Context, id pgtype.UUID) (*Job, error) {
 //				panic("mock out the StartJob method")
 //			},
+//			SumPaidInvoicesSinceFunc: func(ctx context.Context, since pgtype.Timestamptz) ([]*SumPaidInvoicesSinceRow, error) {
+//				panic("mock out the SumPaidInvoicesSince method")
+//			},
+//			SyncImageStatusFromEventsFunc: func(ctx context.Context, arg SyncImageStatusFromEventsParams) (int64, error) {
+//				panic("mock out the SyncImageStatusFromEvents method")
+//			},
+//			TouchAPIKeyFunc: func(ctx context.Context, id pgtype.UUID) error {
+//				panic("mock out the TouchAPIKey method")
+//			},
+//			TouchOrganizationSCIMTokenFunc: func(ctx context.Context, tokenHash []byte) (pgtype.UUID, error) {
+//				panic("mock out the TouchOrganizationSCIMToken method")
+//			},
+//			UpdateCatalogFunc: func(ctx context.Context, arg UpdateCatalogParams) (*Catalog, error) {
+//				panic("mock out the UpdateCatalog method")
+//			},
+//			UpdateImageCostFunc: func(ctx context.Context, arg UpdateImageCostParams) error {
+//				panic("mock out the UpdateImageCost method")
+//			},
 //			UpdateImageStatusFunc: func(ctx context.Context, arg UpdateImageStatusParams) (*UpdateImageStatusRow, error) {
 //				panic("mock out the UpdateImageStatus method")
 //			},
@@ -148,30 +688,84 @@ var _ Querier = &QuerierMock{}
 //			UpdateJobStatusFunc: func(ctx context.Context, arg UpdateJobStatusParams) (*Job, error) {
 //				panic("mock out the UpdateJobStatus method")
 //			},
+//			UpdatePresetFunc: func(ctx context.Context, arg UpdatePresetParams) (*Preset, error) {
+//				panic("mock out the UpdatePreset method")
+//			},
 //			UpdateProjectFunc: func(ctx context.Context, arg UpdateProjectParams) (*UpdateProjectRow, error) {
 //				panic("mock out the UpdateProject method")
 //			},
 //			UpdateProjectByUserIDFunc: func(ctx context.Context, arg UpdateProjectByUserIDParams) (*UpdateProjectByUserIDRow, error) {
 //				panic("mock out the UpdateProjectByUserID method")
 //			},
+//			UpdateSCIMGroupFunc: func(ctx context.Context, arg UpdateSCIMGroupParams) (*OrganizationScimGroup, error) {
+//				panic("mock out the UpdateSCIMGroup method")
+//			},
+//			UpdateSCIMUserFunc: func(ctx context.Context, arg UpdateSCIMUserParams) (*OrganizationScimUser, error) {
+//				panic("mock out the UpdateSCIMUser method")
+//			},
+//			UpdateSettingFunc: func(ctx context.Context, arg UpdateSettingParams) (int64, error) {
+//				panic("mock out the UpdateSetting method")
+//			},
+//			UpdateUserEncryptedFieldsFunc: func(ctx context.Context, arg UpdateUserEncryptedFieldsParams) error {
+//				panic("mock out the UpdateUserEncryptedFields method")
+//			},
 //			UpdateUserProfileFunc: func(ctx context.Context, arg UpdateUserProfileParams) (*UpdateUserProfileRow, error) {
 //				panic("mock out the UpdateUserProfile method")
 //			},
 //			UpdateUserRoleFunc: func(ctx context.Context, arg UpdateUserRoleParams) (*UpdateUserRoleRow, error) {
 //				panic("mock out the UpdateUserRole method")
 //			},
+//			UpdateUserStorageRegionFunc: func(ctx context.Context, arg UpdateUserStorageRegionParams) (int64, error) {
+//				panic("mock out the UpdateUserStorageRegion method")
+//			},
 //			UpdateUserStripeCustomerIDFunc: func(ctx context.Context, arg UpdateUserStripeCustomerIDParams) (*UpdateUserStripeCustomerIDRow, error) {
 //				panic("mock out the UpdateUserStripeCustomerID method")
 //			},
+//			UpsertAccountWatermarkFunc: func(ctx context.Context, arg UpsertAccountWatermarkParams) (*AccountWatermark, error) {
+//				panic("mock out the UpsertAccountWatermark method")
+//			},
+//			UpsertCustomerBucketFunc: func(ctx context.Context, arg UpsertCustomerBucketParams) (*CustomerBucket, error) {
+//				panic("mock out the UpsertCustomerBucket method")
+//			},
 //			UpsertInvoiceByStripeIDFunc: func(ctx context.Context, arg UpsertInvoiceByStripeIDParams) (*Invoice, error) {
 //				panic("mock out the UpsertInvoiceByStripeID method")
 //			},
+//			UpsertOrganizationSCIMMemberFunc: func(ctx context.Context, arg UpsertOrganizationSCIMMemberParams) error {
+//				panic("mock out the UpsertOrganizationSCIMMember method")
+//			},
+//			UpsertOrganizationSCIMTokenFunc: func(ctx context.Context, arg UpsertOrganizationSCIMTokenParams) (*OrganizationScimToken, error) {
+//				panic("mock out the UpsertOrganizationSCIMToken method")
+//			},
+//			UpsertOrganizationSSOFunc: func(ctx context.Context, arg UpsertOrganizationSSOParams) (*OrganizationSso, error) {
+//				panic("mock out the UpsertOrganizationSSO method")
+//			},
+//			UpsertOrganizationSSOMemberFunc: func(ctx context.Context, arg UpsertOrganizationSSOMemberParams) (*OrganizationMember, error) {
+//				panic("mock out the UpsertOrganizationSSOMember method")
+//			},
+//			UpsertPendingBillingEventFunc: func(ctx context.Context, arg UpsertPendingBillingEventParams) error {
+//				panic("mock out the UpsertPendingBillingEvent method")
+//			},
 //			UpsertProcessedEventByStripeIDFunc: func(ctx context.Context, arg UpsertProcessedEventByStripeIDParams) (*ProcessedEvent, error) {
 //				panic("mock out the UpsertProcessedEventByStripeID method")
 //			},
+//			UpsertProjectDeletionIntentFunc: func(ctx context.Context, arg UpsertProjectDeletionIntentParams) error {
+//				panic("mock out the UpsertProjectDeletionIntent method")
+//			},
+//			UpsertProjectDisclosureFunc: func(ctx context.Context, arg UpsertProjectDisclosureParams) (*ProjectDisclosure, error) {
+//				panic("mock out the UpsertProjectDisclosure method")
+//			},
+//			UpsertProjectListingFunc: func(ctx context.Context, arg UpsertProjectListingParams) (*ProjectListing, error) {
+//				panic("mock out the UpsertProjectListing method")
+//			},
+//			UpsertShareBrandingFunc: func(ctx context.Context, arg UpsertShareBrandingParams) (*ShareBranding, error) {
+//				panic("mock out the UpsertShareBranding method")
+//			},
 //			UpsertSubscriptionByStripeIDFunc: func(ctx context.Context, arg UpsertSubscriptionByStripeIDParams) (*Subscription, error) {
 //				panic("mock out the UpsertSubscriptionByStripeID method")
 //			},
+//			UpsertTeamWebhookFunc: func(ctx context.Context, arg UpsertTeamWebhookParams) (*TeamWebhook, error) {
+//				panic("mock out the UpsertTeamWebhook method")
+//			},
 //		}
 //
 //		// use mockedQuerier in code that requires Querier
@@ -179,30 +773,174 @@ var _ Querier = &QuerierMock{}
 //
 //	}
 type QuerierMock struct {
+	// AcceptProjectInvitationFunc mocks the AcceptProjectInvitation method.
+	AcceptProjectInvitationFunc func(ctx context.Context, arg AcceptProjectInvitationParams) (*ProjectCollaborator, error)
+
+	// AddProjectRetentionExemptionFunc mocks the AddProjectRetentionExemption method.
+	AddProjectRetentionExemptionFunc func(ctx context.Context, projectID pgtype.UUID) error
+
+	// AddSCIMGroupMembersFunc mocks the AddSCIMGroupMembers method.
+	AddSCIMGroupMembersFunc func(ctx context.Context, arg AddSCIMGroupMembersParams) (int64, error)
+
+	// ApplyProjectTemplateDisclosureFunc mocks the ApplyProjectTemplateDisclosure method.
+	ApplyProjectTemplateDisclosureFunc func(ctx context.Context, arg ApplyProjectTemplateDisclosureParams) error
+
+	// ApplyProjectTemplateRoomsFunc mocks the ApplyProjectTemplateRooms method.
+	ApplyProjectTemplateRoomsFunc func(ctx context.Context, arg ApplyProjectTemplateRoomsParams) error
+
+	// BulkCancelImagesFunc mocks the BulkCancelImages method.
+	BulkCancelImagesFunc func(ctx context.Context, arg BulkCancelImagesParams) ([]*BulkCancelImagesRow, error)
+
+	// BulkDeleteImagesFunc mocks the BulkDeleteImages method.
+	BulkDeleteImagesFunc func(ctx context.Context, arg BulkDeleteImagesParams) ([]*BulkDeleteImagesRow, error)
+
+	// CancelImageFunc mocks the CancelImage method.
+	CancelImageFunc func(ctx context.Context, id pgtype.UUID) (*CancelImageRow, error)
+
+	// CancelJobsByImageIDFunc mocks the CancelJobsByImageID method.
+	CancelJobsByImageIDFunc func(ctx context.Context, imageID pgtype.UUID) ([]*Job, error)
+
+	// ClaimProcessedEventFunc mocks the ClaimProcessedEvent method.
+	ClaimProcessedEventFunc func(ctx context.Context, arg ClaimProcessedEventParams) (*ProcessedEvent, error)
+
+	// ClaimSCIMUserFunc mocks the ClaimSCIMUser method.
+	ClaimSCIMUserFunc func(ctx context.Context, arg ClaimSCIMUserParams) (int64, error)
+
 	// CompleteJobFunc mocks the CompleteJob method.
 	CompleteJobFunc func(ctx context.Context, id pgtype.UUID) (*Job, error)
 
+	// ConfirmRoomGroupingFunc mocks the ConfirmRoomGrouping method.
+	ConfirmRoomGroupingFunc func(ctx context.Context, arg ConfirmRoomGroupingParams) (*RoomGrouping, error)
+
+	// ConsumeExportCreditFunc mocks the ConsumeExportCredit method.
+	ConsumeExportCreditFunc func(ctx context.Context, arg ConsumeExportCreditParams) (*ExportCredit, error)
+
+	// ConsumeProjectDeletionIntentFunc mocks the ConsumeProjectDeletionIntent method.
+	ConsumeProjectDeletionIntentFunc func(ctx context.Context, arg ConsumeProjectDeletionIntentParams) (int64, error)
+
+	// CountAPIKeysByUserFunc mocks the CountAPIKeysByUser method.
+	CountAPIKeysByUserFunc func(ctx context.Context, userID pgtype.UUID) (int64, error)
+
+	// CountActiveUsersSinceFunc mocks the CountActiveUsersSince method.
+	CountActiveUsersSinceFunc func(ctx context.Context, since pgtype.Timestamptz) (int64, error)
+
+	// CountAvailableExportCreditsFunc mocks the CountAvailableExportCredits method.
+	CountAvailableExportCreditsFunc func(ctx context.Context, userID pgtype.UUID) (int64, error)
+
+	// CountExportableImagesFunc mocks the CountExportableImages method.
+	CountExportableImagesFunc func(ctx context.Context, projectID pgtype.UUID) (int64, error)
+
+	// CountJobsByStatusFunc mocks the CountJobsByStatus method.
+	CountJobsByStatusFunc func(ctx context.Context) ([]*CountJobsByStatusRow, error)
+
+	// CountProjectImagesFunc mocks the CountProjectImages method.
+	CountProjectImagesFunc func(ctx context.Context, projectID pgtype.UUID) (int64, error)
+
+	// CountProjectImagesByRoomTypeFunc mocks the CountProjectImagesByRoomType method.
+	CountProjectImagesByRoomTypeFunc func(ctx context.Context, projectID pgtype.UUID) ([]*CountProjectImagesByRoomTypeRow, error)
+
+	// CountProjectTemplatesFunc mocks the CountProjectTemplates method.
+	CountProjectTemplatesFunc func(ctx context.Context, userID pgtype.UUID) (int64, error)
+
 	// CountProjectsByUserIDFunc mocks the CountProjectsByUserID method.
 	CountProjectsByUserIDFunc func(ctx context.Context, userID pgtype.UUID) (int64, error)
 
+	// CountSCIMGroupsFunc mocks the CountSCIMGroups method.
+	CountSCIMGroupsFunc func(ctx context.Context, arg CountSCIMGroupsParams) (int64, error)
+
+	// CountSCIMUsersFunc mocks the CountSCIMUsers method.
+	CountSCIMUsersFunc func(ctx context.Context, arg CountSCIMUsersParams) (int64, error)
+
+	// CountUnreadNotificationsFunc mocks the CountUnreadNotifications method.
+	CountUnreadNotificationsFunc func(ctx context.Context, userID pgtype.UUID) (int64, error)
+
 	// CountUsersFunc mocks the CountUsers method.
 	CountUsersFunc func(ctx context.Context) (int64, error)
 
+	// CreateAPIKeyFunc mocks the CreateAPIKey method.
+	CreateAPIKeyFunc func(ctx context.Context, arg CreateAPIKeyParams) (*ApiKey, error)
+
+	// CreateCatalogFunc mocks the CreateCatalog method.
+	CreateCatalogFunc func(ctx context.Context, arg CreateCatalogParams) (*Catalog, error)
+
+	// CreateExportCreditFunc mocks the CreateExportCredit method.
+	CreateExportCreditFunc func(ctx context.Context, arg CreateExportCreditParams) (int64, error)
+
 	// CreateImageFunc mocks the CreateImage method.
 	CreateImageFunc func(ctx context.Context, arg CreateImageParams) (*CreateImageRow, error)
 
+	// CreateImageAnnotationsFunc mocks the CreateImageAnnotations method.
+	CreateImageAnnotationsFunc func(ctx context.Context, arg CreateImageAnnotationsParams) error
+
+	// CreateImageBracketsFunc mocks the CreateImageBrackets method.
+	CreateImageBracketsFunc func(ctx context.Context, arg CreateImageBracketsParams) error
+
+	// CreateImageEditFunc mocks the CreateImageEdit method.
+	CreateImageEditFunc func(ctx context.Context, arg CreateImageEditParams) (*ImageEdit, error)
+
+	// CreateImagesFunc mocks the CreateImages method.
+	CreateImagesFunc func(ctx context.Context, arg []CreateImagesParams) (int64, error)
+
 	// CreateJobFunc mocks the CreateJob method.
 	CreateJobFunc func(ctx context.Context, arg CreateJobParams) (*Job, error)
 
+	// CreateJobsFunc mocks the CreateJobs method.
+	CreateJobsFunc func(ctx context.Context, arg []CreateJobsParams) (int64, error)
+
+	// CreateNotificationFunc mocks the CreateNotification method.
+	CreateNotificationFunc func(ctx context.Context, arg CreateNotificationParams) (*Notification, error)
+
+	// CreateOrganizationFunc mocks the CreateOrganization method.
+	CreateOrganizationFunc func(ctx context.Context, arg CreateOrganizationParams) (*CreateOrganizationRow, error)
+
+	// CreateOrganizationBreakGlassFunc mocks the CreateOrganizationBreakGlass method.
+	CreateOrganizationBreakGlassFunc func(ctx context.Context, arg CreateOrganizationBreakGlassParams) (*OrganizationBreakGlass, error)
+
+	// CreatePresetFunc mocks the CreatePreset method.
+	CreatePresetFunc func(ctx context.Context, arg CreatePresetParams) (*Preset, error)
+
 	// CreateProcessedEventFunc mocks the CreateProcessedEvent method.
 	CreateProcessedEventFunc func(ctx context.Context, arg CreateProcessedEventParams) (*ProcessedEvent, error)
 
 	// CreateProjectFunc mocks the CreateProject method.
 	CreateProjectFunc func(ctx context.Context, arg CreateProjectParams) (*CreateProjectRow, error)
 
+	// CreateProjectExportFunc mocks the CreateProjectExport method.
+	CreateProjectExportFunc func(ctx context.Context, arg CreateProjectExportParams) (*ProjectExport, error)
+
+	// CreateProjectInvitationFunc mocks the CreateProjectInvitation method.
+	CreateProjectInvitationFunc func(ctx context.Context, arg CreateProjectInvitationParams) (*ProjectInvitation, error)
+
+	// CreateProjectRoomsFunc mocks the CreateProjectRooms method.
+	CreateProjectRoomsFunc func(ctx context.Context, arg CreateProjectRoomsParams) error
+
+	// CreateProjectTemplateFunc mocks the CreateProjectTemplate method.
+	CreateProjectTemplateFunc func(ctx context.Context, arg CreateProjectTemplateParams) (*ProjectTemplate, error)
+
+	// CreateRoomGroupingFunc mocks the CreateRoomGrouping method.
+	CreateRoomGroupingFunc func(ctx context.Context, projectID pgtype.UUID) (*RoomGrouping, error)
+
+	// CreateSCIMGroupFunc mocks the CreateSCIMGroup method.
+	CreateSCIMGroupFunc func(ctx context.Context, arg CreateSCIMGroupParams) (*OrganizationScimGroup, error)
+
+	// CreateSCIMUserFunc mocks the CreateSCIMUser method.
+	CreateSCIMUserFunc func(ctx context.Context, arg CreateSCIMUserParams) (*OrganizationScimUser, error)
+
+	// CreateTeamWebhookDeliveryFunc mocks the CreateTeamWebhookDelivery method.
+	CreateTeamWebhookDeliveryFunc func(ctx context.Context, arg CreateTeamWebhookDeliveryParams) (*TeamWebhookDelivery, error)
+
 	// CreateUserFunc mocks the CreateUser method.
 	CreateUserFunc func(ctx context.Context, arg CreateUserParams) (*CreateUserRow, error)
 
+	// DeleteAccountWatermarkFunc mocks the DeleteAccountWatermark method.
+	DeleteAccountWatermarkFunc func(ctx context.Context, userID pgtype.UUID) (int64, error)
+
+	// DeleteCatalogFunc mocks the DeleteCatalog method.
+	DeleteCatalogFunc func(ctx context.Context, id pgtype.UUID) (int64, error)
+
+	// DeleteCustomerBucketFunc mocks the DeleteCustomerBucket method.
+	DeleteCustomerBucketFunc func(ctx context.Context, userID pgtype.UUID) (int64, error)
+
 	// DeleteImageFunc mocks the DeleteImage method.
 	DeleteImageFunc func(ctx context.Context, id pgtype.UUID) error
 
@@ -218,27 +956,108 @@ type QuerierMock struct {
 	// DeleteOldProcessedEventsFunc mocks the DeleteOldProcessedEvents method.
 	DeleteOldProcessedEventsFunc func(ctx context.Context, receivedAt pgtype.Timestamptz) error
 
+	// DeleteOrganizationSCIMMemberFunc mocks the DeleteOrganizationSCIMMember method.
+	DeleteOrganizationSCIMMemberFunc func(ctx context.Context, arg DeleteOrganizationSCIMMemberParams) error
+
+	// DeleteOrganizationSCIMTokenFunc mocks the DeleteOrganizationSCIMToken method.
+	DeleteOrganizationSCIMTokenFunc func(ctx context.Context, organizationID pgtype.UUID) (int64, error)
+
+	// DeleteOrganizationSSOFunc mocks the DeleteOrganizationSSO method.
+	DeleteOrganizationSSOFunc func(ctx context.Context, organizationID pgtype.UUID) (int64, error)
+
+	// DeletePresetFunc mocks the DeletePreset method.
+	DeletePresetFunc func(ctx context.Context, arg DeletePresetParams) (int64, error)
+
 	// DeleteProjectFunc mocks the DeleteProject method.
-	DeleteProjectFunc func(ctx context.Context, id pgtype.UUID) error
+	DeleteProjectFunc func(ctx context.Context, id pgtype.UUID) (int64, error)
 
 	// DeleteProjectByUserIDFunc mocks the DeleteProjectByUserID method.
-	DeleteProjectByUserIDFunc func(ctx context.Context, arg DeleteProjectByUserIDParams) error
+	DeleteProjectByUserIDFunc func(ctx context.Context, arg DeleteProjectByUserIDParams) (int64, error)
+
+	// DeleteProjectRoomsFunc mocks the DeleteProjectRooms method.
+	DeleteProjectRoomsFunc func(ctx context.Context, projectID pgtype.UUID) error
+
+	// DeleteProjectTemplateFunc mocks the DeleteProjectTemplate method.
+	DeleteProjectTemplateFunc func(ctx context.Context, arg DeleteProjectTemplateParams) (int64, error)
+
+	// DeleteSCIMGroupFunc mocks the DeleteSCIMGroup method.
+	DeleteSCIMGroupFunc func(ctx context.Context, arg DeleteSCIMGroupParams) (int64, error)
+
+	// DeleteSCIMUserFunc mocks the DeleteSCIMUser method.
+	DeleteSCIMUserFunc func(ctx context.Context, arg DeleteSCIMUserParams) (int64, error)
+
+	// DeleteShareBrandingFunc mocks the DeleteShareBranding method.
+	DeleteShareBrandingFunc func(ctx context.Context, userID pgtype.UUID) (int64, error)
 
 	// DeleteSubscriptionByStripeIDFunc mocks the DeleteSubscriptionByStripeID method.
 	DeleteSubscriptionByStripeIDFunc func(ctx context.Context, stripeSubscriptionID string) error
 
+	// DeleteTeamWebhookFunc mocks the DeleteTeamWebhook method.
+	DeleteTeamWebhookFunc func(ctx context.Context, arg DeleteTeamWebhookParams) (int64, error)
+
 	// DeleteUserFunc mocks the DeleteUser method.
 	DeleteUserFunc func(ctx context.Context, id pgtype.UUID) error
 
+	// FailImageEditFunc mocks the FailImageEdit method.
+	FailImageEditFunc func(ctx context.Context, arg FailImageEditParams) error
+
 	// FailJobFunc mocks the FailJob method.
 	FailJobFunc func(ctx context.Context, arg FailJobParams) (*Job, error)
 
+	// FailProjectExportFunc mocks the FailProjectExport method.
+	FailProjectExportFunc func(ctx context.Context, arg FailProjectExportParams) error
+
+	// FailRoomGroupingFunc mocks the FailRoomGrouping method.
+	FailRoomGroupingFunc func(ctx context.Context, arg FailRoomGroupingParams) error
+
+	// GetAPIKeyByHashFunc mocks the GetAPIKeyByHash method.
+	GetAPIKeyByHashFunc func(ctx context.Context, keyHash []byte) (*GetAPIKeyByHashRow, error)
+
+	// GetAccountWatermarkFunc mocks the GetAccountWatermark method.
+	GetAccountWatermarkFunc func(ctx context.Context, userID pgtype.UUID) (*AccountWatermark, error)
+
 	// GetAllProjectsFunc mocks the GetAllProjects method.
 	GetAllProjectsFunc func(ctx context.Context) ([]*GetAllProjectsRow, error)
 
+	// GetCatalogByIDFunc mocks the GetCatalogByID method.
+	GetCatalogByIDFunc func(ctx context.Context, id pgtype.UUID) (*Catalog, error)
+
+	// GetCustomerBucketAccessFunc mocks the GetCustomerBucketAccess method.
+	GetCustomerBucketAccessFunc func(ctx context.Context, userID pgtype.UUID) (bool, error)
+
+	// GetCustomerBucketByNameFunc mocks the GetCustomerBucketByName method.
+	GetCustomerBucketByNameFunc func(ctx context.Context, bucket string) (*CustomerBucket, error)
+
+	// GetCustomerBucketByUserIDFunc mocks the GetCustomerBucketByUserID method.
+	GetCustomerBucketByUserIDFunc func(ctx context.Context, userID pgtype.UUID) (*CustomerBucket, error)
+
+	// GetDeadLetterJobFunc mocks the GetDeadLetterJob method.
+	GetDeadLetterJobFunc func(ctx context.Context, id pgtype.UUID) (*DeadLetterJob, error)
+
+	// GetExpediteAccessFunc mocks the GetExpediteAccess method.
+	GetExpediteAccessFunc func(ctx context.Context, arg GetExpediteAccessParams) (*GetExpediteAccessRow, error)
+
+	// GetImageAnalyticsBucketsFunc mocks the GetImageAnalyticsBuckets method.
+	GetImageAnalyticsBucketsFunc func(ctx context.Context, arg GetImageAnalyticsBucketsParams) ([]*GetImageAnalyticsBucketsRow, error)
+
 	// GetImageByIDFunc mocks the GetImageByID method.
 	GetImageByIDFunc func(ctx context.Context, id pgtype.UUID) (*GetImageByIDRow, error)
 
+	// GetImageEditFunc mocks the GetImageEdit method.
+	GetImageEditFunc func(ctx context.Context, arg GetImageEditParams) (*ImageEdit, error)
+
+	// GetImageEditSourceFunc mocks the GetImageEditSource method.
+	GetImageEditSourceFunc func(ctx context.Context, id pgtype.UUID) (*GetImageEditSourceRow, error)
+
+	// GetImageSourceBreakdownFunc mocks the GetImageSourceBreakdown method.
+	GetImageSourceBreakdownFunc func(ctx context.Context, arg GetImageSourceBreakdownParams) ([]*GetImageSourceBreakdownRow, error)
+
+	// GetImageStatusesByIDsFunc mocks the GetImageStatusesByIDs method.
+	GetImageStatusesByIDsFunc func(ctx context.Context, arg GetImageStatusesByIDsParams) ([]*GetImageStatusesByIDsRow, error)
+
+	// GetImagesByIDsFunc mocks the GetImagesByIDs method.
+	GetImagesByIDsFunc func(ctx context.Context, ids []pgtype.UUID) ([]*GetImagesByIDsRow, error)
+
 	// GetImagesByProjectIDFunc mocks the GetImagesByProjectID method.
 	GetImagesByProjectIDFunc func(ctx context.Context, projectID pgtype.UUID) ([]*GetImagesByProjectIDRow, error)
 
@@ -248,24 +1067,129 @@ type QuerierMock struct {
 	// GetJobByIDFunc mocks the GetJobByID method.
 	GetJobByIDFunc func(ctx context.Context, id pgtype.UUID) (*Job, error)
 
+	// GetJobLogFunc mocks the GetJobLog method.
+	GetJobLogFunc func(ctx context.Context, arg GetJobLogParams) (*JobLog, error)
+
+	// GetJobOutcomesSinceFunc mocks the GetJobOutcomesSince method.
+	GetJobOutcomesSinceFunc func(ctx context.Context, since pgtype.Timestamptz) (*GetJobOutcomesSinceRow, error)
+
 	// GetJobsByImageIDFunc mocks the GetJobsByImageID method.
 	GetJobsByImageIDFunc func(ctx context.Context, imageID pgtype.UUID) ([]*Job, error)
 
+	// GetLatestRoomGroupingFunc mocks the GetLatestRoomGrouping method.
+	GetLatestRoomGroupingFunc func(ctx context.Context, projectID pgtype.UUID) (*RoomGrouping, error)
+
+	// GetOrganizationIPAllowlistFunc mocks the GetOrganizationIPAllowlist method.
+	GetOrganizationIPAllowlistFunc func(ctx context.Context, id pgtype.UUID) ([]string, error)
+
+	// GetOrganizationMemberFunc mocks the GetOrganizationMember method.
+	GetOrganizationMemberFunc func(ctx context.Context, arg GetOrganizationMemberParams) (*OrganizationMember, error)
+
+	// GetOrganizationSSOFunc mocks the GetOrganizationSSO method.
+	GetOrganizationSSOFunc func(ctx context.Context, organizationID pgtype.UUID) (*OrganizationSso, error)
+
+	// GetOrganizationSSOAccessFunc mocks the GetOrganizationSSOAccess method.
+	GetOrganizationSSOAccessFunc func(ctx context.Context, id pgtype.UUID) (bool, error)
+
+	// GetOrganizationSSOByConnectionFunc mocks the GetOrganizationSSOByConnection method.
+	GetOrganizationSSOByConnectionFunc func(ctx context.Context, connection string) (*OrganizationSso, error)
+
+	// GetOrganizationSSOByEmailDomainFunc mocks the GetOrganizationSSOByEmailDomain method.
+	GetOrganizationSSOByEmailDomainFunc func(ctx context.Context, domain string) (*OrganizationSso, error)
+
 	// GetPendingJobsFunc mocks the GetPendingJobs method.
 	GetPendingJobsFunc func(ctx context.Context, limit int32) ([]*Job, error)
 
+	// GetPresetForProjectFunc mocks the GetPresetForProject method.
+	GetPresetForProjectFunc func(ctx context.Context, arg GetPresetForProjectParams) (*Preset, error)
+
 	// GetProcessedEventByStripeIDFunc mocks the GetProcessedEventByStripeID method.
 	GetProcessedEventByStripeIDFunc func(ctx context.Context, stripeEventID string) (*ProcessedEvent, error)
 
 	// GetProjectByIDFunc mocks the GetProjectByID method.
 	GetProjectByIDFunc func(ctx context.Context, id pgtype.UUID) (*GetProjectByIDRow, error)
 
+	// GetProjectByIDAndUserIDFunc mocks the GetProjectByIDAndUserID method.
+	GetProjectByIDAndUserIDFunc func(ctx context.Context, arg GetProjectByIDAndUserIDParams) (*GetProjectByIDAndUserIDRow, error)
+
+	// GetProjectByIDForMemberFunc mocks the GetProjectByIDForMember method.
+	GetProjectByIDForMemberFunc func(ctx context.Context, arg GetProjectByIDForMemberParams) (*GetProjectByIDForMemberRow, error)
+
+	// GetProjectCostSummaryFunc mocks the GetProjectCostSummary method.
+	GetProjectCostSummaryFunc func(ctx context.Context, projectID pgtype.UUID) (*GetProjectCostSummaryRow, error)
+
+	// GetProjectDisclosureFunc mocks the GetProjectDisclosure method.
+	GetProjectDisclosureFunc func(ctx context.Context, projectID pgtype.UUID) (*ProjectDisclosure, error)
+
+	// GetProjectExportFunc mocks the GetProjectExport method.
+	GetProjectExportFunc func(ctx context.Context, arg GetProjectExportParams) (*ProjectExport, error)
+
+	// GetProjectExportCreditFunc mocks the GetProjectExportCredit method.
+	GetProjectExportCreditFunc func(ctx context.Context, projectID pgtype.UUID) (*ExportCredit, error)
+
+	// GetProjectInvitationFunc mocks the GetProjectInvitation method.
+	GetProjectInvitationFunc func(ctx context.Context, id pgtype.UUID) (*ProjectInvitation, error)
+
+	// GetProjectListingFunc mocks the GetProjectListing method.
+	GetProjectListingFunc func(ctx context.Context, projectID pgtype.UUID) (*ProjectListing, error)
+
+	// GetProjectRoomStyleFunc mocks the GetProjectRoomStyle method.
+	GetProjectRoomStyleFunc func(ctx context.Context, arg GetProjectRoomStyleParams) (string, error)
+
+	// GetProjectShareKeyFunc mocks the GetProjectShareKey method.
+	GetProjectShareKeyFunc func(ctx context.Context, projectID pgtype.UUID) (*ProjectShareKey, error)
+
+	// GetProjectSummaryFunc mocks the GetProjectSummary method.
+	GetProjectSummaryFunc func(ctx context.Context, id pgtype.UUID) (*GetProjectSummaryRow, error)
+
+	// GetProjectTemplateFunc mocks the GetProjectTemplate method.
+	GetProjectTemplateFunc func(ctx context.Context, arg GetProjectTemplateParams) (*ProjectTemplate, error)
+
 	// GetProjectsByUserIDFunc mocks the GetProjectsByUserID method.
 	GetProjectsByUserIDFunc func(ctx context.Context, userID pgtype.UUID) ([]*GetProjectsByUserIDRow, error)
 
+	// GetReferenceImageAccessFunc mocks the GetReferenceImageAccess method.
+	GetReferenceImageAccessFunc func(ctx context.Context, id pgtype.UUID) (*GetReferenceImageAccessRow, error)
+
+	// GetRoomGroupingFunc mocks the GetRoomGrouping method.
+	GetRoomGroupingFunc func(ctx context.Context, arg GetRoomGroupingParams) (*RoomGrouping, error)
+
+	// GetSCIMGroupFunc mocks the GetSCIMGroup method.
+	GetSCIMGroupFunc func(ctx context.Context, arg GetSCIMGroupParams) (*OrganizationScimGroup, error)
+
+	// GetSCIMUserFunc mocks the GetSCIMUser method.
+	GetSCIMUserFunc func(ctx context.Context, arg GetSCIMUserParams) (*OrganizationScimUser, error)
+
+	// GetSettingFunc mocks the GetSetting method.
+	GetSettingFunc func(ctx context.Context, key string) (*Setting, error)
+
+	// GetShareBrandingFunc mocks the GetShareBranding method.
+	GetShareBrandingFunc func(ctx context.Context, userID pgtype.UUID) (*ShareBranding, error)
+
+	// GetShareBrandingByVerifiedDomainFunc mocks the GetShareBrandingByVerifiedDomain method.
+	GetShareBrandingByVerifiedDomainFunc func(ctx context.Context, customDomain pgtype.Text) (*ShareBranding, error)
+
+	// GetStylePopularityFunc mocks the GetStylePopularity method.
+	GetStylePopularityFunc func(ctx context.Context, arg GetStylePopularityParams) ([]*GetStylePopularityRow, error)
+
 	// GetSubscriptionByStripeIDFunc mocks the GetSubscriptionByStripeID method.
 	GetSubscriptionByStripeIDFunc func(ctx context.Context, stripeSubscriptionID string) (*Subscription, error)
 
+	// GetTeamWebhookFunc mocks the GetTeamWebhook method.
+	GetTeamWebhookFunc func(ctx context.Context, arg GetTeamWebhookParams) (*TeamWebhook, error)
+
+	// GetTeamWebhookDeliveryFunc mocks the GetTeamWebhookDelivery method.
+	GetTeamWebhookDeliveryFunc func(ctx context.Context, arg GetTeamWebhookDeliveryParams) (*TeamWebhookDelivery, error)
+
+	// GetTurnaroundSLAByPlanFunc mocks the GetTurnaroundSLAByPlan method.
+	GetTurnaroundSLAByPlanFunc func(ctx context.Context, arg GetTurnaroundSLAByPlanParams) ([]*GetTurnaroundSLAByPlanRow, error)
+
+	// GetUsageFunc mocks the GetUsage method.
+	GetUsageFunc func(ctx context.Context, arg GetUsageParams) (int32, error)
+
+	// GetUserBillingPeriodFunc mocks the GetUserBillingPeriod method.
+	GetUserBillingPeriodFunc func(ctx context.Context, userID pgtype.UUID) (*GetUserBillingPeriodRow, error)
+
 	// GetUserByAuth0SubFunc mocks the GetUserByAuth0Sub method.
 	GetUserByAuth0SubFunc func(ctx context.Context, auth0Sub string) (*GetUserByAuth0SubRow, error)
 
@@ -275,27 +1199,237 @@ type QuerierMock struct {
 	// GetUserByStripeCustomerIDFunc mocks the GetUserByStripeCustomerID method.
 	GetUserByStripeCustomerIDFunc func(ctx context.Context, stripeCustomerID pgtype.Text) (*GetUserByStripeCustomerIDRow, error)
 
+	// GetUserByStripeCustomerIDHashFunc mocks the GetUserByStripeCustomerIDHash method.
+	GetUserByStripeCustomerIDHashFunc func(ctx context.Context, stripeCustomerIDHash pgtype.Text) (*GetUserByStripeCustomerIDHashRow, error)
+
+	// GetUserEntitlementsFunc mocks the GetUserEntitlements method.
+	GetUserEntitlementsFunc func(ctx context.Context, userID pgtype.UUID) (*GetUserEntitlementsRow, error)
+
 	// GetUserProfileByAuth0SubFunc mocks the GetUserProfileByAuth0Sub method.
 	GetUserProfileByAuth0SubFunc func(ctx context.Context, auth0Sub string) (*GetUserProfileByAuth0SubRow, error)
 
 	// GetUserProfileByIDFunc mocks the GetUserProfileByID method.
 	GetUserProfileByIDFunc func(ctx context.Context, id pgtype.UUID) (*GetUserProfileByIDRow, error)
 
+	// GetUserStorageRegionFunc mocks the GetUserStorageRegion method.
+	GetUserStorageRegionFunc func(ctx context.Context, id pgtype.UUID) (pgtype.Text, error)
+
+	// HasActiveRoomGroupingFunc mocks the HasActiveRoomGrouping method.
+	HasActiveRoomGroupingFunc func(ctx context.Context, projectID pgtype.UUID) (bool, error)
+
+	// IsImageUnderLegalHoldFunc mocks the IsImageUnderLegalHold method.
+	IsImageUnderLegalHoldFunc func(ctx context.Context, id pgtype.UUID) (bool, error)
+
+	// IsProjectRetentionExemptFunc mocks the IsProjectRetentionExempt method.
+	IsProjectRetentionExemptFunc func(ctx context.Context, projectID pgtype.UUID) (bool, error)
+
+	// IsProjectUnderLegalHoldFunc mocks the IsProjectUnderLegalHold method.
+	IsProjectUnderLegalHoldFunc func(ctx context.Context, projectID pgtype.UUID) (bool, error)
+
+	// ListAPIKeysByUserFunc mocks the ListAPIKeysByUser method.
+	ListAPIKeysByUserFunc func(ctx context.Context, userID pgtype.UUID) ([]*ApiKey, error)
+
+	// ListActiveCatalogsFunc mocks the ListActiveCatalogs method.
+	ListActiveCatalogsFunc func(ctx context.Context) ([]*Catalog, error)
+
+	// ListCatalogsFunc mocks the ListCatalogs method.
+	ListCatalogsFunc func(ctx context.Context) ([]*Catalog, error)
+
+	// ListDataAccessByOwnerFunc mocks the ListDataAccessByOwner method.
+	ListDataAccessByOwnerFunc func(ctx context.Context, arg ListDataAccessByOwnerParams) ([]*DataAccessLog, error)
+
+	// ListDeadLetterJobsFunc mocks the ListDeadLetterJobs method.
+	ListDeadLetterJobsFunc func(ctx context.Context, arg ListDeadLetterJobsParams) ([]*DeadLetterJob, error)
+
+	// ListIPAllowlistsForSubjectFunc mocks the ListIPAllowlistsForSubject method.
+	ListIPAllowlistsForSubjectFunc func(ctx context.Context, auth0Sub string) ([]*ListIPAllowlistsForSubjectRow, error)
+
+	// ListImageStatusDriftFunc mocks the ListImageStatusDrift method.
+	ListImageStatusDriftFunc func(ctx context.Context, imageIds []pgtype.UUID) ([]*ListImageStatusDriftRow, error)
+
+	// ListImageStatusTransitionsFunc mocks the ListImageStatusTransitions method.
+	ListImageStatusTransitionsFunc func(ctx context.Context, imageID pgtype.UUID) ([]*ImageStatusTransition, error)
+
 	// ListImagesForReconcileFunc mocks the ListImagesForReconcile method.
 	ListImagesForReconcileFunc func(ctx context.Context, arg ListImagesForReconcileParams) ([]*ListImagesForReconcileRow, error)
 
 	// ListInvoicesByUserIDFunc mocks the ListInvoicesByUserID method.
 	ListInvoicesByUserIDFunc func(ctx context.Context, arg ListInvoicesByUserIDParams) ([]*Invoice, error)
 
+	// ListJobsFunc mocks the ListJobs method.
+	ListJobsFunc func(ctx context.Context, arg ListJobsParams) ([]*Job, error)
+
+	// ListLegalHeldImageIDsFunc mocks the ListLegalHeldImageIDs method.
+	ListLegalHeldImageIDsFunc func(ctx context.Context, imageIds []pgtype.UUID) ([]pgtype.UUID, error)
+
+	// ListLegalHoldsFunc mocks the ListLegalHolds method.
+	ListLegalHoldsFunc func(ctx context.Context) ([]*LegalHold, error)
+
+	// ListNotificationsFunc mocks the ListNotifications method.
+	ListNotificationsFunc func(ctx context.Context, arg ListNotificationsParams) ([]*Notification, error)
+
+	// ListOrganizationAuditLogFunc mocks the ListOrganizationAuditLog method.
+	ListOrganizationAuditLogFunc func(ctx context.Context, arg ListOrganizationAuditLogParams) ([]*OrganizationAuditLog, error)
+
+	// ListOrganizationMembersFunc mocks the ListOrganizationMembers method.
+	ListOrganizationMembersFunc func(ctx context.Context, organizationID pgtype.UUID) ([]*OrganizationMember, error)
+
+	// ListOrganizationsByUserFunc mocks the ListOrganizationsByUser method.
+	ListOrganizationsByUserFunc func(ctx context.Context, userID pgtype.UUID) ([]*ListOrganizationsByUserRow, error)
+
+	// ListPresetsByUserFunc mocks the ListPresetsByUser method.
+	ListPresetsByUserFunc func(ctx context.Context, userID pgtype.UUID) ([]*Preset, error)
+
+	// ListProjectActivityFunc mocks the ListProjectActivity method.
+	ListProjectActivityFunc func(ctx context.Context, arg ListProjectActivityParams) ([]*ProjectActivity, error)
+
+	// ListProjectImageRoomsFunc mocks the ListProjectImageRooms method.
+	ListProjectImageRoomsFunc func(ctx context.Context, projectID pgtype.UUID) ([]*ListProjectImageRoomsRow, error)
+
+	// ListProjectInvitationsFunc mocks the ListProjectInvitations method.
+	ListProjectInvitationsFunc func(ctx context.Context, projectID pgtype.UUID) ([]*ProjectInvitation, error)
+
+	// ListProjectRoomsFunc mocks the ListProjectRooms method.
+	ListProjectRoomsFunc func(ctx context.Context, projectID pgtype.UUID) ([]*ProjectRoom, error)
+
+	// ListProjectSummariesByUserFunc mocks the ListProjectSummariesByUser method.
+	ListProjectSummariesByUserFunc func(ctx context.Context, arg ListProjectSummariesByUserParams) ([]*ListProjectSummariesByUserRow, error)
+
+	// ListProjectTemplatesFunc mocks the ListProjectTemplates method.
+	ListProjectTemplatesFunc func(ctx context.Context, userID pgtype.UUID) ([]*ProjectTemplate, error)
+
+	// ListProjectThumbnailsFunc mocks the ListProjectThumbnails method.
+	ListProjectThumbnailsFunc func(ctx context.Context, arg ListProjectThumbnailsParams) ([]*ListProjectThumbnailsRow, error)
+
+	// ListSCIMGroupMembersFunc mocks the ListSCIMGroupMembers method.
+	ListSCIMGroupMembersFunc func(ctx context.Context, groupIds []pgtype.UUID) ([]*ListSCIMGroupMembersRow, error)
+
+	// ListSCIMGroupsFunc mocks the ListSCIMGroups method.
+	ListSCIMGroupsFunc func(ctx context.Context, arg ListSCIMGroupsParams) ([]*OrganizationScimGroup, error)
+
+	// ListSCIMUserGroupNamesFunc mocks the ListSCIMUserGroupNames method.
+	ListSCIMUserGroupNamesFunc func(ctx context.Context, scimUserID pgtype.UUID) ([]string, error)
+
+	// ListSCIMUsersFunc mocks the ListSCIMUsers method.
+	ListSCIMUsersFunc func(ctx context.Context, arg ListSCIMUsersParams) ([]*OrganizationScimUser, error)
+
+	// ListSettingsFunc mocks the ListSettings method.
+	ListSettingsFunc func(ctx context.Context) ([]*Setting, error)
+
 	// ListSubscriptionsByUserIDFunc mocks the ListSubscriptionsByUserID method.
 	ListSubscriptionsByUserIDFunc func(ctx context.Context, arg ListSubscriptionsByUserIDParams) ([]*Subscription, error)
 
+	// ListTeamWebhookDeliveriesFunc mocks the ListTeamWebhookDeliveries method.
+	ListTeamWebhookDeliveriesFunc func(ctx context.Context, arg ListTeamWebhookDeliveriesParams) ([]*TeamWebhookDelivery, error)
+
+	// ListTeamWebhooksFunc mocks the ListTeamWebhooks method.
+	ListTeamWebhooksFunc func(ctx context.Context, userID pgtype.UUID) ([]*TeamWebhook, error)
+
+	// ListTeamWebhooksForEventFunc mocks the ListTeamWebhooksForEvent method.
+	ListTeamWebhooksForEventFunc func(ctx context.Context, arg ListTeamWebhooksForEventParams) ([]*TeamWebhook, error)
+
+	// ListUserEncryptedFieldsFunc mocks the ListUserEncryptedFields method.
+	ListUserEncryptedFieldsFunc func(ctx context.Context, arg ListUserEncryptedFieldsParams) ([]*ListUserEncryptedFieldsRow, error)
+
 	// ListUsersFunc mocks the ListUsers method.
 	ListUsersFunc func(ctx context.Context, arg ListUsersParams) ([]*ListUsersRow, error)
 
+	// MarkAllNotificationsReadFunc mocks the MarkAllNotificationsRead method.
+	MarkAllNotificationsReadFunc func(ctx context.Context, userID pgtype.UUID) (int64, error)
+
+	// MarkCustomerBucketVerifiedFunc mocks the MarkCustomerBucketVerified method.
+	MarkCustomerBucketVerifiedFunc func(ctx context.Context, userID pgtype.UUID) (*CustomerBucket, error)
+
+	// MarkDeadLetterJobRequeuedFunc mocks the MarkDeadLetterJobRequeued method.
+	MarkDeadLetterJobRequeuedFunc func(ctx context.Context, id pgtype.UUID) (*DeadLetterJob, error)
+
+	// MarkNotificationReadFunc mocks the MarkNotificationRead method.
+	MarkNotificationReadFunc func(ctx context.Context, arg MarkNotificationReadParams) (*Notification, error)
+
+	// MarkShareBrandingDomainVerifiedFunc mocks the MarkShareBrandingDomainVerified method.
+	MarkShareBrandingDomainVerifiedFunc func(ctx context.Context, userID pgtype.UUID) (*ShareBranding, error)
+
+	// OrganizationSSODomainsTakenFunc mocks the OrganizationSSODomainsTaken method.
+	OrganizationSSODomainsTakenFunc func(ctx context.Context, arg OrganizationSSODomainsTakenParams) (bool, error)
+
+	// PlaceImageLegalHoldFunc mocks the PlaceImageLegalHold method.
+	PlaceImageLegalHoldFunc func(ctx context.Context, arg PlaceImageLegalHoldParams) (*LegalHold, error)
+
+	// PlaceProjectLegalHoldFunc mocks the PlaceProjectLegalHold method.
+	PlaceProjectLegalHoldFunc func(ctx context.Context, arg PlaceProjectLegalHoldParams) (*LegalHold, error)
+
+	// RecordDataAccessFunc mocks the RecordDataAccess method.
+	RecordDataAccessFunc func(ctx context.Context, arg RecordDataAccessParams) (*DataAccessLog, error)
+
+	// RecordImageExpeditedFunc mocks the RecordImageExpedited method.
+	RecordImageExpeditedFunc func(ctx context.Context, arg RecordImageExpeditedParams) error
+
+	// RecordOrganizationAuditEventFunc mocks the RecordOrganizationAuditEvent method.
+	RecordOrganizationAuditEventFunc func(ctx context.Context, arg RecordOrganizationAuditEventParams) error
+
+	// RecordTeamWebhookDeliveryFunc mocks the RecordTeamWebhookDelivery method.
+	RecordTeamWebhookDeliveryFunc func(ctx context.Context, arg RecordTeamWebhookDeliveryParams) error
+
+	// RecordTeamWebhookDeliveryAttemptFunc mocks the RecordTeamWebhookDeliveryAttempt method.
+	RecordTeamWebhookDeliveryAttemptFunc func(ctx context.Context, arg RecordTeamWebhookDeliveryAttemptParams) (*TeamWebhookDelivery, error)
+
+	// ReleaseImageLegalHoldFunc mocks the ReleaseImageLegalHold method.
+	ReleaseImageLegalHoldFunc func(ctx context.Context, imageID pgtype.UUID) (int64, error)
+
+	// ReleaseProjectLegalHoldFunc mocks the ReleaseProjectLegalHold method.
+	ReleaseProjectLegalHoldFunc func(ctx context.Context, projectID pgtype.UUID) (int64, error)
+
+	// ReleaseUsageFunc mocks the ReleaseUsage method.
+	ReleaseUsageFunc func(ctx context.Context, arg ReleaseUsageParams) error
+
+	// RemoveProjectRetentionExemptionFunc mocks the RemoveProjectRetentionExemption method.
+	RemoveProjectRetentionExemptionFunc func(ctx context.Context, projectID pgtype.UUID) error
+
+	// RemoveSCIMGroupMembersFunc mocks the RemoveSCIMGroupMembers method.
+	RemoveSCIMGroupMembersFunc func(ctx context.Context, arg RemoveSCIMGroupMembersParams) (int64, error)
+
+	// ReserveUsageFunc mocks the ReserveUsage method.
+	ReserveUsageFunc func(ctx context.Context, arg ReserveUsageParams) (int32, error)
+
+	// RevokeAPIKeyFunc mocks the RevokeAPIKey method.
+	RevokeAPIKeyFunc func(ctx context.Context, arg RevokeAPIKeyParams) (int64, error)
+
+	// RevokeProjectInvitationFunc mocks the RevokeProjectInvitation method.
+	RevokeProjectInvitationFunc func(ctx context.Context, arg RevokeProjectInvitationParams) (*RevokeProjectInvitationRow, error)
+
+	// RotateProjectShareKeyFunc mocks the RotateProjectShareKey method.
+	RotateProjectShareKeyFunc func(ctx context.Context, projectID pgtype.UUID) (*ProjectShareKey, error)
+
+	// RotateTeamWebhookSecretFunc mocks the RotateTeamWebhookSecret method.
+	RotateTeamWebhookSecretFunc func(ctx context.Context, arg RotateTeamWebhookSecretParams) (*TeamWebhook, error)
+
+	// SetOrganizationIPAllowlistFunc mocks the SetOrganizationIPAllowlist method.
+	SetOrganizationIPAllowlistFunc func(ctx context.Context, arg SetOrganizationIPAllowlistParams) ([]string, error)
+
+	// SetProjectImagesRoomTypeFunc mocks the SetProjectImagesRoomType method.
+	SetProjectImagesRoomTypeFunc func(ctx context.Context, arg SetProjectImagesRoomTypeParams) (int64, error)
+
 	// StartJobFunc mocks the StartJob method.
 	StartJobFunc func(ctx context.Context, id pgtype.UUID) (*Job, error)
 
+	// SumPaidInvoicesSinceFunc mocks the SumPaidInvoicesSince method.
+	SumPaidInvoicesSinceFunc func(ctx context.Context, since pgtype.Timestamptz) ([]*SumPaidInvoicesSinceRow, error)
+
+	// SyncImageStatusFromEventsFunc mocks the SyncImageStatusFromEvents method.
+	SyncImageStatusFromEventsFunc func(ctx context.Context, arg SyncImageStatusFromEventsParams) (int64, error)
+
+	// TouchAPIKeyFunc mocks the TouchAPIKey method.
+	TouchAPIKeyFunc func(ctx context.Context, id pgtype.UUID) error
+
+	// TouchOrganizationSCIMTokenFunc mocks the TouchOrganizationSCIMToken method.
+	TouchOrganizationSCIMTokenFunc func(ctx context.Context, tokenHash []byte) (pgtype.UUID, error)
+
+	// UpdateCatalogFunc mocks the UpdateCatalog method.
+	UpdateCatalogFunc func(ctx context.Context, arg UpdateCatalogParams) (*Catalog, error)
+
+	// UpdateImageCostFunc mocks the UpdateImageCost method.
+	UpdateImageCostFunc func(ctx context.Context, arg UpdateImageCostParams) error
+
 	// UpdateImageStatusFunc mocks the UpdateImageStatus method.
 	UpdateImageStatusFunc func(ctx context.Context, arg UpdateImageStatusParams) (*UpdateImageStatusRow, error)
 
@@ -308,763 +1442,9351 @@ type QuerierMock struct {
 	// UpdateJobStatusFunc mocks the UpdateJobStatus method.
 	UpdateJobStatusFunc func(ctx context.Context, arg UpdateJobStatusParams) (*Job, error)
 
+	// UpdatePresetFunc mocks the UpdatePreset method.
+	UpdatePresetFunc func(ctx context.Context, arg UpdatePresetParams) (*Preset, error)
+
 	// UpdateProjectFunc mocks the UpdateProject method.
 	UpdateProjectFunc func(ctx context.Context, arg UpdateProjectParams) (*UpdateProjectRow, error)
 
 	// UpdateProjectByUserIDFunc mocks the UpdateProjectByUserID method.
 	UpdateProjectByUserIDFunc func(ctx context.Context, arg UpdateProjectByUserIDParams) (*UpdateProjectByUserIDRow, error)
 
+	// UpdateSCIMGroupFunc mocks the UpdateSCIMGroup method.
+	UpdateSCIMGroupFunc func(ctx context.Context, arg UpdateSCIMGroupParams) (*OrganizationScimGroup, error)
+
+	// UpdateSCIMUserFunc mocks the UpdateSCIMUser method.
+	UpdateSCIMUserFunc func(ctx context.Context, arg UpdateSCIMUserParams) (*OrganizationScimUser, error)
+
+	// UpdateSettingFunc mocks the UpdateSetting method.
+	UpdateSettingFunc func(ctx context.Context, arg UpdateSettingParams) (int64, error)
+
+	// UpdateUserEncryptedFieldsFunc mocks the UpdateUserEncryptedFields method.
+	UpdateUserEncryptedFieldsFunc func(ctx context.Context, arg UpdateUserEncryptedFieldsParams) error
+
 	// UpdateUserProfileFunc mocks the UpdateUserProfile method.
 	UpdateUserProfileFunc func(ctx context.Context, arg UpdateUserProfileParams) (*UpdateUserProfileRow, error)
 
 	// UpdateUserRoleFunc mocks the UpdateUserRole method.
 	UpdateUserRoleFunc func(ctx context.Context, arg UpdateUserRoleParams) (*UpdateUserRoleRow, error)
 
+	// UpdateUserStorageRegionFunc mocks the UpdateUserStorageRegion method.
+	UpdateUserStorageRegionFunc func(ctx context.Context, arg UpdateUserStorageRegionParams) (int64, error)
+
 	// UpdateUserStripeCustomerIDFunc mocks the UpdateUserStripeCustomerID method.
 	UpdateUserStripeCustomerIDFunc func(ctx context.Context, arg UpdateUserStripeCustomerIDParams) (*UpdateUserStripeCustomerIDRow, error)
 
+	// UpsertAccountWatermarkFunc mocks the UpsertAccountWatermark method.
+	UpsertAccountWatermarkFunc func(ctx context.Context, arg UpsertAccountWatermarkParams) (*AccountWatermark, error)
+
+	// UpsertCustomerBucketFunc mocks the UpsertCustomerBucket method.
+	UpsertCustomerBucketFunc func(ctx context.Context, arg UpsertCustomerBucketParams) (*CustomerBucket, error)
+
 	// UpsertInvoiceByStripeIDFunc mocks the UpsertInvoiceByStripeID method.
 	UpsertInvoiceByStripeIDFunc func(ctx context.Context, arg UpsertInvoiceByStripeIDParams) (*Invoice, error)
 
+	// UpsertOrganizationSCIMMemberFunc mocks the UpsertOrganizationSCIMMember method.
+	UpsertOrganizationSCIMMemberFunc func(ctx context.Context, arg UpsertOrganizationSCIMMemberParams) error
+
+	// UpsertOrganizationSCIMTokenFunc mocks the UpsertOrganizationSCIMToken method.
+	UpsertOrganizationSCIMTokenFunc func(ctx context.Context, arg UpsertOrganizationSCIMTokenParams) (*OrganizationScimToken, error)
+
+	// UpsertOrganizationSSOFunc mocks the UpsertOrganizationSSO method.
+	UpsertOrganizationSSOFunc func(ctx context.Context, arg UpsertOrganizationSSOParams) (*OrganizationSso, error)
+
+	// UpsertOrganizationSSOMemberFunc mocks the UpsertOrganizationSSOMember method.
+	UpsertOrganizationSSOMemberFunc func(ctx context.Context, arg UpsertOrganizationSSOMemberParams) (*OrganizationMember, error)
+
+	// UpsertPendingBillingEventFunc mocks the UpsertPendingBillingEvent method.
+	UpsertPendingBillingEventFunc func(ctx context.Context, arg UpsertPendingBillingEventParams) error
+
 	// UpsertProcessedEventByStripeIDFunc mocks the UpsertProcessedEventByStripeID method.
 	UpsertProcessedEventByStripeIDFunc func(ctx context.Context, arg UpsertProcessedEventByStripeIDParams) (*ProcessedEvent, error)
 
+	// UpsertProjectDeletionIntentFunc mocks the UpsertProjectDeletionIntent method.
+	UpsertProjectDeletionIntentFunc func(ctx context.Context, arg UpsertProjectDeletionIntentParams) error
+
+	// UpsertProjectDisclosureFunc mocks the UpsertProjectDisclosure method.
+	UpsertProjectDisclosureFunc func(ctx context.Context, arg UpsertProjectDisclosureParams) (*ProjectDisclosure, error)
+
+	// UpsertProjectListingFunc mocks the UpsertProjectListing method.
+	UpsertProjectListingFunc func(ctx context.Context, arg UpsertProjectListingParams) (*ProjectListing, error)
+
+	// UpsertShareBrandingFunc mocks the UpsertShareBranding method.
+	UpsertShareBrandingFunc func(ctx context.Context, arg UpsertShareBrandingParams) (*ShareBranding, error)
+
 	// UpsertSubscriptionByStripeIDFunc mocks the UpsertSubscriptionByStripeID method.
 	UpsertSubscriptionByStripeIDFunc func(ctx context.Context, arg UpsertSubscriptionByStripeIDParams) (*Subscription, error)
 
+	// UpsertTeamWebhookFunc mocks the UpsertTeamWebhook method.
+	UpsertTeamWebhookFunc func(ctx context.Context, arg UpsertTeamWebhookParams) (*TeamWebhook, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
-		// CompleteJob holds details about calls to the CompleteJob method.
-		CompleteJob []struct {
-			// Ctx is the ctx argument value.
-			Ctx context.Context
-			// ID is the id argument value.
-			ID pgtype.UUID
-		}
-		// CountProjectsByUserID holds details about calls to the CountProjectsByUserID method.
-		CountProjectsByUserID []struct {
+		// AcceptProjectInvitation holds details about calls to the AcceptProjectInvitation method.
+		AcceptProjectInvitation []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// UserID is the userID argument value.
-			UserID pgtype.UUID
+			// Arg is the arg argument value.
+			Arg AcceptProjectInvitationParams
 		}
-		// CountUsers holds details about calls to the CountUsers method.
-		CountUsers []struct {
+		// AddProjectRetentionExemption holds details about calls to the AddProjectRetentionExemption method.
+		AddProjectRetentionExemption []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID pgtype.UUID
 		}
-		// CreateImage holds details about calls to the CreateImage method.
-		CreateImage []struct {
+		// AddSCIMGroupMembers holds details about calls to the AddSCIMGroupMembers method.
+		AddSCIMGroupMembers []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Arg is the arg argument value.
-			Arg CreateImageParams
+			Arg AddSCIMGroupMembersParams
 		}
-		// CreateJob holds details about calls to the CreateJob method.
-		CreateJob []struct {
+		// ApplyProjectTemplateDisclosure holds details about calls to the ApplyProjectTemplateDisclosure method.
+		ApplyProjectTemplateDisclosure []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Arg is the arg argument value.
-			Arg CreateJobParams
+			Arg ApplyProjectTemplateDisclosureParams
 		}
-		// CreateProcessedEvent holds details about calls to the CreateProcessedEvent method.
-		CreateProcessedEvent []struct {
+		// ApplyProjectTemplateRooms holds details about calls to the ApplyProjectTemplateRooms method.
+		ApplyProjectTemplateRooms []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Arg is the arg argument value.
-			Arg CreateProcessedEventParams
+			Arg ApplyProjectTemplateRoomsParams
 		}
-		// CreateProject holds details about calls to the CreateProject method.
-		CreateProject []struct {
+		// BulkCancelImages holds details about calls to the BulkCancelImages method.
+		BulkCancelImages []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Arg is the arg argument value.
-			Arg CreateProjectParams
+			Arg BulkCancelImagesParams
 		}
-		// CreateUser holds details about calls to the CreateUser method.
-		CreateUser []struct {
+		// BulkDeleteImages holds details about calls to the BulkDeleteImages method.
+		BulkDeleteImages []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Arg is the arg argument value.
-			Arg CreateUserParams
+			Arg BulkDeleteImagesParams
 		}
-		// DeleteImage holds details about calls to the DeleteImage method.
-		DeleteImage []struct {
+		// CancelImage holds details about calls to the CancelImage method.
+		CancelImage []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// ID is the id argument value.
 			ID pgtype.UUID
 		}
-		// DeleteImagesByProjectID holds details about calls to the DeleteImagesByProjectID method.
-		DeleteImagesByProjectID []struct {
-			// Ctx is the ctx argument value.
-			Ctx context.Context
-			// ProjectID is the projectID argument value.
-			ProjectID pgtype.UUID
-		}
-		// DeleteJob holds details about calls to the DeleteJob method.
-		DeleteJob []struct {
+		// CancelJobsByImageID holds details about calls to the CancelJobsByImageID method.
+		CancelJobsByImageID []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// ID is the id argument value.
-			ID pgtype.UUID
+			// ImageID is the imageID argument value.
+			ImageID pgtype.UUID
 		}
-		// DeleteJobsByImageID holds details about calls to the DeleteJobsByImageID method.
-		DeleteJobsByImageID []struct {
+		// ClaimProcessedEvent holds details about calls to the ClaimProcessedEvent method.
+		ClaimProcessedEvent []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// ImageID is the imageID argument value.
-			ImageID pgtype.UUID
+			// Arg is the arg argument value.
+			Arg ClaimProcessedEventParams
 		}
-		// DeleteOldProcessedEvents holds details about calls to the DeleteOldProcessedEvents method.
-		DeleteOldProcessedEvents []struct {
+		// ClaimSCIMUser holds details about calls to the ClaimSCIMUser method.
+		ClaimSCIMUser []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// ReceivedAt is the receivedAt argument value.
-			ReceivedAt pgtype.Timestamptz
+			// Arg is the arg argument value.
+			Arg ClaimSCIMUserParams
 		}
-		// DeleteProject holds details about calls to the DeleteProject method.
-		DeleteProject []struct {
+		// CompleteJob holds details about calls to the CompleteJob method.
+		CompleteJob []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// ID is the id argument value.
 			ID pgtype.UUID
 		}
-		// DeleteProjectByUserID holds details about calls to the DeleteProjectByUserID method.
-		DeleteProjectByUserID []struct {
+		// ConfirmRoomGrouping holds details about calls to the ConfirmRoomGrouping method.
+		ConfirmRoomGrouping []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Arg is the arg argument value.
-			Arg DeleteProjectByUserIDParams
+			Arg ConfirmRoomGroupingParams
 		}
-		// DeleteSubscriptionByStripeID holds details about calls to the DeleteSubscriptionByStripeID method.
-		DeleteSubscriptionByStripeID []struct {
+		// ConsumeExportCredit holds details about calls to the ConsumeExportCredit method.
+		ConsumeExportCredit []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// StripeSubscriptionID is the stripeSubscriptionID argument value.
-			StripeSubscriptionID string
+			// Arg is the arg argument value.
+			Arg ConsumeExportCreditParams
 		}
-		// DeleteUser holds details about calls to the DeleteUser method.
-		DeleteUser []struct {
+		// ConsumeProjectDeletionIntent holds details about calls to the ConsumeProjectDeletionIntent method.
+		ConsumeProjectDeletionIntent []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// ID is the id argument value.
-			ID pgtype.UUID
+			// Arg is the arg argument value.
+			Arg ConsumeProjectDeletionIntentParams
 		}
-		// FailJob holds details about calls to the FailJob method.
-		FailJob []struct {
+		// CountAPIKeysByUser holds details about calls to the CountAPIKeysByUser method.
+		CountAPIKeysByUser []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// Arg is the arg argument value.
-			Arg FailJobParams
+			// UserID is the userID argument value.
+			UserID pgtype.UUID
 		}
-		// GetAllProjects holds details about calls to the GetAllProjects method.
-		GetAllProjects []struct {
+		// CountActiveUsersSince holds details about calls to the CountActiveUsersSince method.
+		CountActiveUsersSince []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// Since is the since argument value.
+			Since pgtype.Timestamptz
 		}
-		// GetImageByID holds details about calls to the GetImageByID method.
-		GetImageByID []struct {
+		// CountAvailableExportCredits holds details about calls to the CountAvailableExportCredits method.
+		CountAvailableExportCredits []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// ID is the id argument value.
-			ID pgtype.UUID
+			// UserID is the userID argument value.
+			UserID pgtype.UUID
 		}
-		// GetImagesByProjectID holds details about calls to the GetImagesByProjectID method.
-		GetImagesByProjectID []struct {
+		// CountExportableImages holds details about calls to the CountExportableImages method.
+		CountExportableImages []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// ProjectID is the projectID argument value.
 			ProjectID pgtype.UUID
 		}
-		// GetInvoiceByStripeID holds details about calls to the GetInvoiceByStripeID method.
-		GetInvoiceByStripeID []struct {
+		// CountJobsByStatus holds details about calls to the CountJobsByStatus method.
+		CountJobsByStatus []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// StripeInvoiceID is the stripeInvoiceID argument value.
-			StripeInvoiceID string
 		}
-		// GetJobByID holds details about calls to the GetJobByID method.
-		GetJobByID []struct {
+		// CountProjectImages holds details about calls to the CountProjectImages method.
+		CountProjectImages []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// ID is the id argument value.
-			ID pgtype.UUID
+			// ProjectID is the projectID argument value.
+			ProjectID pgtype.UUID
 		}
-		// GetJobsByImageID holds details about calls to the GetJobsByImageID method.
-		GetJobsByImageID []struct {
+		// CountProjectImagesByRoomType holds details about calls to the CountProjectImagesByRoomType method.
+		CountProjectImagesByRoomType []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// ImageID is the imageID argument value.
-			ImageID pgtype.UUID
+			// ProjectID is the projectID argument value.
+			ProjectID pgtype.UUID
 		}
-		// GetPendingJobs holds details about calls to the GetPendingJobs method.
-		GetPendingJobs []struct {
+		// CountProjectTemplates holds details about calls to the CountProjectTemplates method.
+		CountProjectTemplates []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// Limit is the limit argument value.
-			Limit int32
+			// UserID is the userID argument value.
+			UserID pgtype.UUID
 		}
-		// GetProcessedEventByStripeID holds details about calls to the GetProcessedEventByStripeID method.
-		GetProcessedEventByStripeID []struct {
+		// CountProjectsByUserID holds details about calls to the CountProjectsByUserID method.
+		CountProjectsByUserID []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// StripeEventID is the stripeEventID argument value.
-			StripeEventID string
+			// UserID is the userID argument value.
+			UserID pgtype.UUID
 		}
-		// GetProjectByID holds details about calls to the GetProjectByID method.
-		GetProjectByID []struct {
+		// CountSCIMGroups holds details about calls to the CountSCIMGroups method.
+		CountSCIMGroups []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// ID is the id argument value.
-			ID pgtype.UUID
+			// Arg is the arg argument value.
+			Arg CountSCIMGroupsParams
 		}
-		// GetProjectsByUserID holds details about calls to the GetProjectsByUserID method.
-		GetProjectsByUserID []struct {
+		// CountSCIMUsers holds details about calls to the CountSCIMUsers method.
+		CountSCIMUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg CountSCIMUsersParams
+		}
+		// CountUnreadNotifications holds details about calls to the CountUnreadNotifications method.
+		CountUnreadNotifications []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// UserID is the userID argument value.
 			UserID pgtype.UUID
 		}
-		// GetSubscriptionByStripeID holds details about calls to the GetSubscriptionByStripeID method.
-		GetSubscriptionByStripeID []struct {
+		// CountUsers holds details about calls to the CountUsers method.
+		CountUsers []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// StripeSubscriptionID is the stripeSubscriptionID argument value.
-			StripeSubscriptionID string
 		}
-		// GetUserByAuth0Sub holds details about calls to the GetUserByAuth0Sub method.
-		GetUserByAuth0Sub []struct {
+		// CreateAPIKey holds details about calls to the CreateAPIKey method.
+		CreateAPIKey []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// Auth0Sub is the auth0Sub argument value.
-			Auth0Sub string
+			// Arg is the arg argument value.
+			Arg CreateAPIKeyParams
 		}
-		// GetUserByID holds details about calls to the GetUserByID method.
-		GetUserByID []struct {
+		// CreateCatalog holds details about calls to the CreateCatalog method.
+		CreateCatalog []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// ID is the id argument value.
-			ID pgtype.UUID
+			// Arg is the arg argument value.
+			Arg CreateCatalogParams
 		}
-		// GetUserByStripeCustomerID holds details about calls to the GetUserByStripeCustomerID method.
-		GetUserByStripeCustomerID []struct {
+		// CreateExportCredit holds details about calls to the CreateExportCredit method.
+		CreateExportCredit []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// StripeCustomerID is the stripeCustomerID argument value.
-			StripeCustomerID pgtype.Text
+			// Arg is the arg argument value.
+			Arg CreateExportCreditParams
 		}
-		// GetUserProfileByAuth0Sub holds details about calls to the GetUserProfileByAuth0Sub method.
-		GetUserProfileByAuth0Sub []struct {
+		// CreateImage holds details about calls to the CreateImage method.
+		CreateImage []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// Auth0Sub is the auth0Sub argument value.
-			Auth0Sub string
+			// Arg is the arg argument value.
+			Arg CreateImageParams
 		}
-		// GetUserProfileByID holds details about calls to the GetUserProfileByID method.
-		GetUserProfileByID []struct {
+		// CreateImageAnnotations holds details about calls to the CreateImageAnnotations method.
+		CreateImageAnnotations []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// ID is the id argument value.
-			ID pgtype.UUID
+			// Arg is the arg argument value.
+			Arg CreateImageAnnotationsParams
 		}
-		// ListImagesForReconcile holds details about calls to the ListImagesForReconcile method.
-		ListImagesForReconcile []struct {
+		// CreateImageBrackets holds details about calls to the CreateImageBrackets method.
+		CreateImageBrackets []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Arg is the arg argument value.
-			Arg ListImagesForReconcileParams
+			Arg CreateImageBracketsParams
 		}
-		// ListInvoicesByUserID holds details about calls to the ListInvoicesByUserID method.
-		ListInvoicesByUserID []struct {
+		// CreateImageEdit holds details about calls to the CreateImageEdit method.
+		CreateImageEdit []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Arg is the arg argument value.
-			Arg ListInvoicesByUserIDParams
+			Arg CreateImageEditParams
 		}
-		// ListSubscriptionsByUserID holds details about calls to the ListSubscriptionsByUserID method.
-		ListSubscriptionsByUserID []struct {
+		// CreateImages holds details about calls to the CreateImages method.
+		CreateImages []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Arg is the arg argument value.
-			Arg ListSubscriptionsByUserIDParams
+			Arg []CreateImagesParams
 		}
-		// ListUsers holds details about calls to the ListUsers method.
-		ListUsers []struct {
+		// CreateJob holds details about calls to the CreateJob method.
+		CreateJob []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Arg is the arg argument value.
-			Arg ListUsersParams
+			Arg CreateJobParams
 		}
-		// StartJob holds details about calls to the StartJob method.
-		StartJob []struct {
+		// CreateJobs holds details about calls to the CreateJobs method.
+		CreateJobs []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// ID is the id argument value.
-			ID pgtype.UUID
+			// Arg is the arg argument value.
+			Arg []CreateJobsParams
 		}
-		// UpdateImageStatus holds details about calls to the UpdateImageStatus method.
-		UpdateImageStatus []struct {
+		// CreateNotification holds details about calls to the CreateNotification method.
+		CreateNotification []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Arg is the arg argument value.
-			Arg UpdateImageStatusParams
+			Arg CreateNotificationParams
 		}
-		// UpdateImageWithError holds details about calls to the UpdateImageWithError method.
-		UpdateImageWithError []struct {
+		// CreateOrganization holds details about calls to the CreateOrganization method.
+		CreateOrganization []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Arg is the arg argument value.
-			Arg UpdateImageWithErrorParams
+			Arg CreateOrganizationParams
 		}
-		// UpdateImageWithStagedURL holds details about calls to the UpdateImageWithStagedURL method.
-		UpdateImageWithStagedURL []struct {
+		// CreateOrganizationBreakGlass holds details about calls to the CreateOrganizationBreakGlass method.
+		CreateOrganizationBreakGlass []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Arg is the arg argument value.
-			Arg UpdateImageWithStagedURLParams
+			Arg CreateOrganizationBreakGlassParams
 		}
-		// UpdateJobStatus holds details about calls to the UpdateJobStatus method.
-		UpdateJobStatus []struct {
+		// CreatePreset holds details about calls to the CreatePreset method.
+		CreatePreset []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Arg is the arg argument value.
-			Arg UpdateJobStatusParams
+			Arg CreatePresetParams
 		}
-		// UpdateProject holds details about calls to the UpdateProject method.
-		UpdateProject []struct {
+		// CreateProcessedEvent holds details about calls to the CreateProcessedEvent method.
+		CreateProcessedEvent []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Arg is the arg argument value.
-			Arg UpdateProjectParams
+			Arg CreateProcessedEventParams
 		}
-		// UpdateProjectByUserID holds details about calls to the UpdateProjectByUserID method.
-		UpdateProjectByUserID []struct {
+		// CreateProject holds details about calls to the CreateProject method.
+		CreateProject []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Arg is the arg argument value.
-			Arg UpdateProjectByUserIDParams
+			Arg CreateProjectParams
 		}
-		// UpdateUserProfile holds details about calls to the UpdateUserProfile method.
-		UpdateUserProfile []struct {
+		// CreateProjectExport holds details about calls to the CreateProjectExport method.
+		CreateProjectExport []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Arg is the arg argument value.
-			Arg UpdateUserProfileParams
+			Arg CreateProjectExportParams
 		}
-		// UpdateUserRole holds details about calls to the UpdateUserRole method.
-		UpdateUserRole []struct {
+		// CreateProjectInvitation holds details about calls to the CreateProjectInvitation method.
+		CreateProjectInvitation []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Arg is the arg argument value.
-			Arg UpdateUserRoleParams
+			Arg CreateProjectInvitationParams
 		}
-		// UpdateUserStripeCustomerID holds details about calls to the UpdateUserStripeCustomerID method.
-		UpdateUserStripeCustomerID []struct {
+		// CreateProjectRooms holds details about calls to the CreateProjectRooms method.
+		CreateProjectRooms []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Arg is the arg argument value.
-			Arg UpdateUserStripeCustomerIDParams
+			Arg CreateProjectRoomsParams
 		}
-		// UpsertInvoiceByStripeID holds details about calls to the UpsertInvoiceByStripeID method.
-		UpsertInvoiceByStripeID []struct {
+		// CreateProjectTemplate holds details about calls to the CreateProjectTemplate method.
+		CreateProjectTemplate []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Arg is the arg argument value.
-			Arg UpsertInvoiceByStripeIDParams
+			Arg CreateProjectTemplateParams
 		}
-		// UpsertProcessedEventByStripeID holds details about calls to the UpsertProcessedEventByStripeID method.
-		UpsertProcessedEventByStripeID []struct {
+		// CreateRoomGrouping holds details about calls to the CreateRoomGrouping method.
+		CreateRoomGrouping []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID pgtype.UUID
+		}
+		// CreateSCIMGroup holds details about calls to the CreateSCIMGroup method.
+		CreateSCIMGroup []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Arg is the arg argument value.
-			Arg UpsertProcessedEventByStripeIDParams
+			Arg CreateSCIMGroupParams
 		}
-		// UpsertSubscriptionByStripeID holds details about calls to the UpsertSubscriptionByStripeID method.
-		UpsertSubscriptionByStripeID []struct {
+		// CreateSCIMUser holds details about calls to the CreateSCIMUser method.
+		CreateSCIMUser []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Arg is the arg argument value.
-			Arg UpsertSubscriptionByStripeIDParams
+			Arg CreateSCIMUserParams
 		}
-	}
-	lockCompleteJob                    sync.RWMutex
-	lockCountProjectsByUserID          sync.RWMutex
-	lockCountUsers                     sync.RWMutex
-	lockCreateImage                    sync.RWMutex
-	lockCreateJob                      sync.RWMutex
-	lockCreateProcessedEvent           sync.RWMutex
-	lockCreateProject                  sync.RWMutex
-	lockCreateUser                     sync.RWMutex
-	lockDeleteImage                    sync.RWMutex
-	lockDeleteImagesByProjectID        sync.RWMutex
-	lockDeleteJob                      sync.RWMutex
-	lockDeleteJobsByImageID            sync.RWMutex
-	lockDeleteOldProcessedEvents       sync.RWMutex
-	lockDeleteProject                  sync.RWMutex
-	lockDeleteProjectByUserID          sync.RWMutex
-	lockDeleteSubscriptionByStripeID   sync.RWMutex
-	lockDeleteUser                     sync.RWMutex
-	lockFailJob                        sync.RWMutex
-	lockGetAllProjects                 sync.RWMutex
-	lockGetImageByID                   sync.RWMutex
-	lockGetImagesByProjectID           sync.RWMutex
-	lockGetInvoiceByStripeID           sync.RWMutex
-	lockGetJobByID                     sync.RWMutex
-	lockGetJobsByImageID               sync.RWMutex
-	lockGetPendingJobs                 sync.RWMutex
-	lockGetProcessedEventByStripeID    sync.RWMutex
-	lockGetProjectByID                 sync.RWMutex
-	lockGetProjectsByUserID            sync.RWMutex
-	lockGetSubscriptionByStripeID      sync.RWMutex
-	lockGetUserByAuth0Sub              sync.RWMutex
-	lockGetUserByID                    sync.RWMutex
-	lockGetUserByStripeCustomerID      sync.RWMutex
-	lockGetUserProfileByAuth0Sub       sync.RWMutex
-	lockGetUserProfileByID             sync.RWMutex
-	lockListImagesForReconcile         sync.RWMutex
-	lockListInvoicesByUserID           sync.RWMutex
-	lockListSubscriptionsByUserID      sync.RWMutex
-	lockListUsers                      sync.RWMutex
-	lockStartJob                       sync.RWMutex
-	lockUpdateImageStatus              sync.RWMutex
-	lockUpdateImageWithError           sync.RWMutex
-	lockUpdateImageWithStagedURL       sync.RWMutex
-	lockUpdateJobStatus                sync.RWMutex
-	lockUpdateProject                  sync.RWMutex
-	lockUpdateProjectByUserID          sync.RWMutex
-	lockUpdateUserProfile              sync.RWMutex
-	lockUpdateUserRole                 sync.RWMutex
-	lockUpdateUserStripeCustomerID     sync.RWMutex
-	lockUpsertInvoiceByStripeID        sync.RWMutex
-	lockUpsertProcessedEventByStripeID sync.RWMutex
-	lockUpsertSubscriptionByStripeID   sync.RWMutex
-}
-
-// CompleteJob calls CompleteJobFunc.
-func (mock *QuerierMock) CompleteJob(ctx context.Context, id pgtype.UUID) (*Job, error) {
-	if mock.CompleteJobFunc == nil {
-		panic("QuerierMock.CompleteJobFunc: method is nil but Querier.CompleteJob was just called")
-	}
-	callInfo := struct {
-		Ctx context.Context
-		ID  pgtype.UUID
-	}{
-		Ctx: ctx,
-		ID:  id,
-	}
-	mock.lockCompleteJob.Lock()
-	mock.calls.CompleteJob = append(mock.calls.CompleteJob, callInfo)
-	mock.lockCompleteJob.Unlock()
-	return mock.CompleteJobFunc(ctx, id)
-}
-
-// CompleteJobCalls gets all the calls that were made to CompleteJob.
-// Check the length with:
-//
-//	len(mockedQuerier.CompleteJobCalls())
-func (mock *QuerierMock) CompleteJobCalls() []struct {
-	Ctx context.Context
-	ID  pgtype.UUID
-} {
-	var calls []struct {
-		Ctx context.Context
-		ID  pgtype.UUID
-	}
-	mock.lockCompleteJob.RLock()
-	calls = mock.calls.CompleteJob
-	mock.lockCompleteJob.RUnlock()
-	return calls
-}
-
-// CountProjectsByUserID calls CountProjectsByUserIDFunc.
-func (mock *QuerierMock) CountProjectsByUserID(ctx context.Context, userID pgtype.UUID) (int64, error) {
-	if mock.CountProjectsByUserIDFunc == nil {
-		panic("QuerierMock.CountProjectsByUserIDFunc: method is nil but Querier.CountProjectsByUserID was just called")
-	}
-	callInfo := struct {
-		Ctx    context.Context
-		UserID pgtype.UUID
-	}{
-		Ctx:    ctx,
-		UserID: userID,
-	}
-	mock.lockCountProjectsByUserID.Lock()
-	mock.calls.CountProjectsByUserID = append(mock.calls.CountProjectsByUserID, callInfo)
-	mock.lockCountProjectsByUserID.Unlock()
-	return mock.CountProjectsByUserIDFunc(ctx, userID)
-}
-
-// CountProjectsByUserIDCalls gets all the calls that were made to CountProjectsByUserID.
-// Check the length with:
-//
-//	len(mockedQuerier.CountProjectsByUserIDCalls())
-func (mock *QuerierMock) CountProjectsByUserIDCalls() []struct {
-	Ctx    context.Context
-	UserID pgtype.UUID
+		// CreateTeamWebhookDelivery holds details about calls to the CreateTeamWebhookDelivery method.
+		CreateTeamWebhookDelivery []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg CreateTeamWebhookDeliveryParams
+		}
+		// CreateUser holds details about calls to the CreateUser method.
+		CreateUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg CreateUserParams
+		}
+		// DeleteAccountWatermark holds details about calls to the DeleteAccountWatermark method.
+		DeleteAccountWatermark []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID pgtype.UUID
+		}
+		// DeleteCatalog holds details about calls to the DeleteCatalog method.
+		DeleteCatalog []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID pgtype.UUID
+		}
+		// DeleteCustomerBucket holds details about calls to the DeleteCustomerBucket method.
+		DeleteCustomerBucket []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID pgtype.UUID
+		}
+		// DeleteImage holds details about calls to the DeleteImage method.
+		DeleteImage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID pgtype.UUID
+		}
+		// DeleteImagesByProjectID holds details about calls to the DeleteImagesByProjectID method.
+		DeleteImagesByProjectID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID pgtype.UUID
+		}
+		// DeleteJob holds details about calls to the DeleteJob method.
+		DeleteJob []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID pgtype.UUID
+		}
+		// DeleteJobsByImageID holds details about calls to the DeleteJobsByImageID method.
+		DeleteJobsByImageID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID pgtype.UUID
+		}
+		// DeleteOldProcessedEvents holds details about calls to the DeleteOldProcessedEvents method.
+		DeleteOldProcessedEvents []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ReceivedAt is the receivedAt argument value.
+			ReceivedAt pgtype.Timestamptz
+		}
+		// DeleteOrganizationSCIMMember holds details about calls to the DeleteOrganizationSCIMMember method.
+		DeleteOrganizationSCIMMember []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg DeleteOrganizationSCIMMemberParams
+		}
+		// DeleteOrganizationSCIMToken holds details about calls to the DeleteOrganizationSCIMToken method.
+		DeleteOrganizationSCIMToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrganizationID is the organizationID argument value.
+			OrganizationID pgtype.UUID
+		}
+		// DeleteOrganizationSSO holds details about calls to the DeleteOrganizationSSO method.
+		DeleteOrganizationSSO []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrganizationID is the organizationID argument value.
+			OrganizationID pgtype.UUID
+		}
+		// DeletePreset holds details about calls to the DeletePreset method.
+		DeletePreset []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg DeletePresetParams
+		}
+		// DeleteProject holds details about calls to the DeleteProject method.
+		DeleteProject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID pgtype.UUID
+		}
+		// DeleteProjectByUserID holds details about calls to the DeleteProjectByUserID method.
+		DeleteProjectByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg DeleteProjectByUserIDParams
+		}
+		// DeleteProjectRooms holds details about calls to the DeleteProjectRooms method.
+		DeleteProjectRooms []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID pgtype.UUID
+		}
+		// DeleteProjectTemplate holds details about calls to the DeleteProjectTemplate method.
+		DeleteProjectTemplate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg DeleteProjectTemplateParams
+		}
+		// DeleteSCIMGroup holds details about calls to the DeleteSCIMGroup method.
+		DeleteSCIMGroup []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg DeleteSCIMGroupParams
+		}
+		// DeleteSCIMUser holds details about calls to the DeleteSCIMUser method.
+		DeleteSCIMUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg DeleteSCIMUserParams
+		}
+		// DeleteShareBranding holds details about calls to the DeleteShareBranding method.
+		DeleteShareBranding []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID pgtype.UUID
+		}
+		// DeleteSubscriptionByStripeID holds details about calls to the DeleteSubscriptionByStripeID method.
+		DeleteSubscriptionByStripeID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// StripeSubscriptionID is the stripeSubscriptionID argument value.
+			StripeSubscriptionID string
+		}
+		// DeleteTeamWebhook holds details about calls to the DeleteTeamWebhook method.
+		DeleteTeamWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg DeleteTeamWebhookParams
+		}
+		// DeleteUser holds details about calls to the DeleteUser method.
+		DeleteUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID pgtype.UUID
+		}
+		// FailImageEdit holds details about calls to the FailImageEdit method.
+		FailImageEdit []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg FailImageEditParams
+		}
+		// FailJob holds details about calls to the FailJob method.
+		FailJob []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg FailJobParams
+		}
+		// FailProjectExport holds details about calls to the FailProjectExport method.
+		FailProjectExport []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg FailProjectExportParams
+		}
+		// FailRoomGrouping holds details about calls to the FailRoomGrouping method.
+		FailRoomGrouping []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg FailRoomGroupingParams
+		}
+		// GetAPIKeyByHash holds details about calls to the GetAPIKeyByHash method.
+		GetAPIKeyByHash []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// KeyHash is the keyHash argument value.
+			KeyHash []byte
+		}
+		// GetAccountWatermark holds details about calls to the GetAccountWatermark method.
+		GetAccountWatermark []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID pgtype.UUID
+		}
+		// GetAllProjects holds details about calls to the GetAllProjects method.
+		GetAllProjects []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetCatalogByID holds details about calls to the GetCatalogByID method.
+		GetCatalogByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID pgtype.UUID
+		}
+		// GetCustomerBucketAccess holds details about calls to the GetCustomerBucketAccess method.
+		GetCustomerBucketAccess []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID pgtype.UUID
+		}
+		// GetCustomerBucketByName holds details about calls to the GetCustomerBucketByName method.
+		GetCustomerBucketByName []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Bucket is the bucket argument value.
+			Bucket string
+		}
+		// GetCustomerBucketByUserID holds details about calls to the GetCustomerBucketByUserID method.
+		GetCustomerBucketByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID pgtype.UUID
+		}
+		// GetDeadLetterJob holds details about calls to the GetDeadLetterJob method.
+		GetDeadLetterJob []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID pgtype.UUID
+		}
+		// GetExpediteAccess holds details about calls to the GetExpediteAccess method.
+		GetExpediteAccess []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg GetExpediteAccessParams
+		}
+		// GetImageAnalyticsBuckets holds details about calls to the GetImageAnalyticsBuckets method.
+		GetImageAnalyticsBuckets []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg GetImageAnalyticsBucketsParams
+		}
+		// GetImageByID holds details about calls to the GetImageByID method.
+		GetImageByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID pgtype.UUID
+		}
+		// GetImageEdit holds details about calls to the GetImageEdit method.
+		GetImageEdit []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg GetImageEditParams
+		}
+		// GetImageEditSource holds details about calls to the GetImageEditSource method.
+		GetImageEditSource []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID pgtype.UUID
+		}
+		// GetImageSourceBreakdown holds details about calls to the GetImageSourceBreakdown method.
+		GetImageSourceBreakdown []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg GetImageSourceBreakdownParams
+		}
+		// GetImageStatusesByIDs holds details about calls to the GetImageStatusesByIDs method.
+		GetImageStatusesByIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg GetImageStatusesByIDsParams
+		}
+		// GetImagesByIDs holds details about calls to the GetImagesByIDs method.
+		GetImagesByIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Ids is the ids argument value.
+			Ids []pgtype.UUID
+		}
+		// GetImagesByProjectID holds details about calls to the GetImagesByProjectID method.
+		GetImagesByProjectID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID pgtype.UUID
+		}
+		// GetInvoiceByStripeID holds details about calls to the GetInvoiceByStripeID method.
+		GetInvoiceByStripeID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// StripeInvoiceID is the stripeInvoiceID argument value.
+			StripeInvoiceID string
+		}
+		// GetJobByID holds details about calls to the GetJobByID method.
+		GetJobByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID pgtype.UUID
+		}
+		// GetJobLog holds details about calls to the GetJobLog method.
+		GetJobLog []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg GetJobLogParams
+		}
+		// GetJobOutcomesSince holds details about calls to the GetJobOutcomesSince method.
+		GetJobOutcomesSince []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Since is the since argument value.
+			Since pgtype.Timestamptz
+		}
+		// GetJobsByImageID holds details about calls to the GetJobsByImageID method.
+		GetJobsByImageID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID pgtype.UUID
+		}
+		// GetLatestRoomGrouping holds details about calls to the GetLatestRoomGrouping method.
+		GetLatestRoomGrouping []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID pgtype.UUID
+		}
+		// GetOrganizationIPAllowlist holds details about calls to the GetOrganizationIPAllowlist method.
+		GetOrganizationIPAllowlist []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID pgtype.UUID
+		}
+		// GetOrganizationMember holds details about calls to the GetOrganizationMember method.
+		GetOrganizationMember []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg GetOrganizationMemberParams
+		}
+		// GetOrganizationSSO holds details about calls to the GetOrganizationSSO method.
+		GetOrganizationSSO []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrganizationID is the organizationID argument value.
+			OrganizationID pgtype.UUID
+		}
+		// GetOrganizationSSOAccess holds details about calls to the GetOrganizationSSOAccess method.
+		GetOrganizationSSOAccess []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID pgtype.UUID
+		}
+		// GetOrganizationSSOByConnection holds details about calls to the GetOrganizationSSOByConnection method.
+		GetOrganizationSSOByConnection []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Connection is the connection argument value.
+			Connection string
+		}
+		// GetOrganizationSSOByEmailDomain holds details about calls to the GetOrganizationSSOByEmailDomain method.
+		GetOrganizationSSOByEmailDomain []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Domain is the domain argument value.
+			Domain string
+		}
+		// GetPendingJobs holds details about calls to the GetPendingJobs method.
+		GetPendingJobs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Limit is the limit argument value.
+			Limit int32
+		}
+		// GetPresetForProject holds details about calls to the GetPresetForProject method.
+		GetPresetForProject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg GetPresetForProjectParams
+		}
+		// GetProcessedEventByStripeID holds details about calls to the GetProcessedEventByStripeID method.
+		GetProcessedEventByStripeID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// StripeEventID is the stripeEventID argument value.
+			StripeEventID string
+		}
+		// GetProjectByID holds details about calls to the GetProjectByID method.
+		GetProjectByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID pgtype.UUID
+		}
+		// GetProjectByIDAndUserID holds details about calls to the GetProjectByIDAndUserID method.
+		GetProjectByIDAndUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg GetProjectByIDAndUserIDParams
+		}
+		// GetProjectByIDForMember holds details about calls to the GetProjectByIDForMember method.
+		GetProjectByIDForMember []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg GetProjectByIDForMemberParams
+		}
+		// GetProjectCostSummary holds details about calls to the GetProjectCostSummary method.
+		GetProjectCostSummary []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID pgtype.UUID
+		}
+		// GetProjectDisclosure holds details about calls to the GetProjectDisclosure method.
+		GetProjectDisclosure []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID pgtype.UUID
+		}
+		// GetProjectExport holds details about calls to the GetProjectExport method.
+		GetProjectExport []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg GetProjectExportParams
+		}
+		// GetProjectExportCredit holds details about calls to the GetProjectExportCredit method.
+		GetProjectExportCredit []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID pgtype.UUID
+		}
+		// GetProjectInvitation holds details about calls to the GetProjectInvitation method.
+		GetProjectInvitation []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID pgtype.UUID
+		}
+		// GetProjectListing holds details about calls to the GetProjectListing method.
+		GetProjectListing []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID pgtype.UUID
+		}
+		// GetProjectRoomStyle holds details about calls to the GetProjectRoomStyle method.
+		GetProjectRoomStyle []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg GetProjectRoomStyleParams
+		}
+		// GetProjectShareKey holds details about calls to the GetProjectShareKey method.
+		GetProjectShareKey []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID pgtype.UUID
+		}
+		// GetProjectSummary holds details about calls to the GetProjectSummary method.
+		GetProjectSummary []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID pgtype.UUID
+		}
+		// GetProjectTemplate holds details about calls to the GetProjectTemplate method.
+		GetProjectTemplate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg GetProjectTemplateParams
+		}
+		// GetProjectsByUserID holds details about calls to the GetProjectsByUserID method.
+		GetProjectsByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID pgtype.UUID
+		}
+		// GetReferenceImageAccess holds details about calls to the GetReferenceImageAccess method.
+		GetReferenceImageAccess []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID pgtype.UUID
+		}
+		// GetRoomGrouping holds details about calls to the GetRoomGrouping method.
+		GetRoomGrouping []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg GetRoomGroupingParams
+		}
+		// GetSCIMGroup holds details about calls to the GetSCIMGroup method.
+		GetSCIMGroup []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg GetSCIMGroupParams
+		}
+		// GetSCIMUser holds details about calls to the GetSCIMUser method.
+		GetSCIMUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg GetSCIMUserParams
+		}
+		// GetSetting holds details about calls to the GetSetting method.
+		GetSetting []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// GetShareBranding holds details about calls to the GetShareBranding method.
+		GetShareBranding []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID pgtype.UUID
+		}
+		// GetShareBrandingByVerifiedDomain holds details about calls to the GetShareBrandingByVerifiedDomain method.
+		GetShareBrandingByVerifiedDomain []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CustomDomain is the customDomain argument value.
+			CustomDomain pgtype.Text
+		}
+		// GetStylePopularity holds details about calls to the GetStylePopularity method.
+		GetStylePopularity []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg GetStylePopularityParams
+		}
+		// GetSubscriptionByStripeID holds details about calls to the GetSubscriptionByStripeID method.
+		GetSubscriptionByStripeID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// StripeSubscriptionID is the stripeSubscriptionID argument value.
+			StripeSubscriptionID string
+		}
+		// GetTeamWebhook holds details about calls to the GetTeamWebhook method.
+		GetTeamWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg GetTeamWebhookParams
+		}
+		// GetTeamWebhookDelivery holds details about calls to the GetTeamWebhookDelivery method.
+		GetTeamWebhookDelivery []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg GetTeamWebhookDeliveryParams
+		}
+		// GetTurnaroundSLAByPlan holds details about calls to the GetTurnaroundSLAByPlan method.
+		GetTurnaroundSLAByPlan []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg GetTurnaroundSLAByPlanParams
+		}
+		// GetUsage holds details about calls to the GetUsage method.
+		GetUsage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg GetUsageParams
+		}
+		// GetUserBillingPeriod holds details about calls to the GetUserBillingPeriod method.
+		GetUserBillingPeriod []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID pgtype.UUID
+		}
+		// GetUserByAuth0Sub holds details about calls to the GetUserByAuth0Sub method.
+		GetUserByAuth0Sub []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Auth0Sub is the auth0Sub argument value.
+			Auth0Sub string
+		}
+		// GetUserByID holds details about calls to the GetUserByID method.
+		GetUserByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID pgtype.UUID
+		}
+		// GetUserByStripeCustomerID holds details about calls to the GetUserByStripeCustomerID method.
+		GetUserByStripeCustomerID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// StripeCustomerID is the stripeCustomerID argument value.
+			StripeCustomerID pgtype.Text
+		}
+		// GetUserByStripeCustomerIDHash holds details about calls to the GetUserByStripeCustomerIDHash method.
+		GetUserByStripeCustomerIDHash []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// StripeCustomerIDHash is the stripeCustomerIDHash argument value.
+			StripeCustomerIDHash pgtype.Text
+		}
+		// GetUserEntitlements holds details about calls to the GetUserEntitlements method.
+		GetUserEntitlements []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID pgtype.UUID
+		}
+		// GetUserProfileByAuth0Sub holds details about calls to the GetUserProfileByAuth0Sub method.
+		GetUserProfileByAuth0Sub []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Auth0Sub is the auth0Sub argument value.
+			Auth0Sub string
+		}
+		// GetUserProfileByID holds details about calls to the GetUserProfileByID method.
+		GetUserProfileByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID pgtype.UUID
+		}
+		// GetUserStorageRegion holds details about calls to the GetUserStorageRegion method.
+		GetUserStorageRegion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID pgtype.UUID
+		}
+		// HasActiveRoomGrouping holds details about calls to the HasActiveRoomGrouping method.
+		HasActiveRoomGrouping []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID pgtype.UUID
+		}
+		// IsImageUnderLegalHold holds details about calls to the IsImageUnderLegalHold method.
+		IsImageUnderLegalHold []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID pgtype.UUID
+		}
+		// IsProjectRetentionExempt holds details about calls to the IsProjectRetentionExempt method.
+		IsProjectRetentionExempt []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID pgtype.UUID
+		}
+		// IsProjectUnderLegalHold holds details about calls to the IsProjectUnderLegalHold method.
+		IsProjectUnderLegalHold []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID pgtype.UUID
+		}
+		// ListAPIKeysByUser holds details about calls to the ListAPIKeysByUser method.
+		ListAPIKeysByUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID pgtype.UUID
+		}
+		// ListActiveCatalogs holds details about calls to the ListActiveCatalogs method.
+		ListActiveCatalogs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ListCatalogs holds details about calls to the ListCatalogs method.
+		ListCatalogs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ListDataAccessByOwner holds details about calls to the ListDataAccessByOwner method.
+		ListDataAccessByOwner []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg ListDataAccessByOwnerParams
+		}
+		// ListDeadLetterJobs holds details about calls to the ListDeadLetterJobs method.
+		ListDeadLetterJobs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg ListDeadLetterJobsParams
+		}
+		// ListIPAllowlistsForSubject holds details about calls to the ListIPAllowlistsForSubject method.
+		ListIPAllowlistsForSubject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Auth0Sub is the auth0Sub argument value.
+			Auth0Sub string
+		}
+		// ListImageStatusDrift holds details about calls to the ListImageStatusDrift method.
+		ListImageStatusDrift []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageIds is the imageIds argument value.
+			ImageIds []pgtype.UUID
+		}
+		// ListImageStatusTransitions holds details about calls to the ListImageStatusTransitions method.
+		ListImageStatusTransitions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID pgtype.UUID
+		}
+		// ListImagesForReconcile holds details about calls to the ListImagesForReconcile method.
+		ListImagesForReconcile []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg ListImagesForReconcileParams
+		}
+		// ListInvoicesByUserID holds details about calls to the ListInvoicesByUserID method.
+		ListInvoicesByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg ListInvoicesByUserIDParams
+		}
+		// ListJobs holds details about calls to the ListJobs method.
+		ListJobs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg ListJobsParams
+		}
+		// ListLegalHeldImageIDs holds details about calls to the ListLegalHeldImageIDs method.
+		ListLegalHeldImageIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageIds is the imageIds argument value.
+			ImageIds []pgtype.UUID
+		}
+		// ListLegalHolds holds details about calls to the ListLegalHolds method.
+		ListLegalHolds []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ListNotifications holds details about calls to the ListNotifications method.
+		ListNotifications []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg ListNotificationsParams
+		}
+		// ListOrganizationAuditLog holds details about calls to the ListOrganizationAuditLog method.
+		ListOrganizationAuditLog []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg ListOrganizationAuditLogParams
+		}
+		// ListOrganizationMembers holds details about calls to the ListOrganizationMembers method.
+		ListOrganizationMembers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrganizationID is the organizationID argument value.
+			OrganizationID pgtype.UUID
+		}
+		// ListOrganizationsByUser holds details about calls to the ListOrganizationsByUser method.
+		ListOrganizationsByUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID pgtype.UUID
+		}
+		// ListPresetsByUser holds details about calls to the ListPresetsByUser method.
+		ListPresetsByUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID pgtype.UUID
+		}
+		// ListProjectActivity holds details about calls to the ListProjectActivity method.
+		ListProjectActivity []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg ListProjectActivityParams
+		}
+		// ListProjectImageRooms holds details about calls to the ListProjectImageRooms method.
+		ListProjectImageRooms []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID pgtype.UUID
+		}
+		// ListProjectInvitations holds details about calls to the ListProjectInvitations method.
+		ListProjectInvitations []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID pgtype.UUID
+		}
+		// ListProjectRooms holds details about calls to the ListProjectRooms method.
+		ListProjectRooms []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID pgtype.UUID
+		}
+		// ListProjectSummariesByUser holds details about calls to the ListProjectSummariesByUser method.
+		ListProjectSummariesByUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg ListProjectSummariesByUserParams
+		}
+		// ListProjectTemplates holds details about calls to the ListProjectTemplates method.
+		ListProjectTemplates []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID pgtype.UUID
+		}
+		// ListProjectThumbnails holds details about calls to the ListProjectThumbnails method.
+		ListProjectThumbnails []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg ListProjectThumbnailsParams
+		}
+		// ListSCIMGroupMembers holds details about calls to the ListSCIMGroupMembers method.
+		ListSCIMGroupMembers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// GroupIds is the groupIds argument value.
+			GroupIds []pgtype.UUID
+		}
+		// ListSCIMGroups holds details about calls to the ListSCIMGroups method.
+		ListSCIMGroups []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg ListSCIMGroupsParams
+		}
+		// ListSCIMUserGroupNames holds details about calls to the ListSCIMUserGroupNames method.
+		ListSCIMUserGroupNames []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ScimUserID is the scimUserID argument value.
+			ScimUserID pgtype.UUID
+		}
+		// ListSCIMUsers holds details about calls to the ListSCIMUsers method.
+		ListSCIMUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg ListSCIMUsersParams
+		}
+		// ListSettings holds details about calls to the ListSettings method.
+		ListSettings []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ListSubscriptionsByUserID holds details about calls to the ListSubscriptionsByUserID method.
+		ListSubscriptionsByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg ListSubscriptionsByUserIDParams
+		}
+		// ListTeamWebhookDeliveries holds details about calls to the ListTeamWebhookDeliveries method.
+		ListTeamWebhookDeliveries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg ListTeamWebhookDeliveriesParams
+		}
+		// ListTeamWebhooks holds details about calls to the ListTeamWebhooks method.
+		ListTeamWebhooks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID pgtype.UUID
+		}
+		// ListTeamWebhooksForEvent holds details about calls to the ListTeamWebhooksForEvent method.
+		ListTeamWebhooksForEvent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg ListTeamWebhooksForEventParams
+		}
+		// ListUserEncryptedFields holds details about calls to the ListUserEncryptedFields method.
+		ListUserEncryptedFields []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg ListUserEncryptedFieldsParams
+		}
+		// ListUsers holds details about calls to the ListUsers method.
+		ListUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg ListUsersParams
+		}
+		// MarkAllNotificationsRead holds details about calls to the MarkAllNotificationsRead method.
+		MarkAllNotificationsRead []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID pgtype.UUID
+		}
+		// MarkCustomerBucketVerified holds details about calls to the MarkCustomerBucketVerified method.
+		MarkCustomerBucketVerified []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID pgtype.UUID
+		}
+		// MarkDeadLetterJobRequeued holds details about calls to the MarkDeadLetterJobRequeued method.
+		MarkDeadLetterJobRequeued []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID pgtype.UUID
+		}
+		// MarkNotificationRead holds details about calls to the MarkNotificationRead method.
+		MarkNotificationRead []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg MarkNotificationReadParams
+		}
+		// MarkShareBrandingDomainVerified holds details about calls to the MarkShareBrandingDomainVerified method.
+		MarkShareBrandingDomainVerified []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID pgtype.UUID
+		}
+		// OrganizationSSODomainsTaken holds details about calls to the OrganizationSSODomainsTaken method.
+		OrganizationSSODomainsTaken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg OrganizationSSODomainsTakenParams
+		}
+		// PlaceImageLegalHold holds details about calls to the PlaceImageLegalHold method.
+		PlaceImageLegalHold []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg PlaceImageLegalHoldParams
+		}
+		// PlaceProjectLegalHold holds details about calls to the PlaceProjectLegalHold method.
+		PlaceProjectLegalHold []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg PlaceProjectLegalHoldParams
+		}
+		// RecordDataAccess holds details about calls to the RecordDataAccess method.
+		RecordDataAccess []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg RecordDataAccessParams
+		}
+		// RecordImageExpedited holds details about calls to the RecordImageExpedited method.
+		RecordImageExpedited []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg RecordImageExpeditedParams
+		}
+		// RecordOrganizationAuditEvent holds details about calls to the RecordOrganizationAuditEvent method.
+		RecordOrganizationAuditEvent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg RecordOrganizationAuditEventParams
+		}
+		// RecordTeamWebhookDelivery holds details about calls to the RecordTeamWebhookDelivery method.
+		RecordTeamWebhookDelivery []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg RecordTeamWebhookDeliveryParams
+		}
+		// RecordTeamWebhookDeliveryAttempt holds details about calls to the RecordTeamWebhookDeliveryAttempt method.
+		RecordTeamWebhookDeliveryAttempt []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg RecordTeamWebhookDeliveryAttemptParams
+		}
+		// ReleaseImageLegalHold holds details about calls to the ReleaseImageLegalHold method.
+		ReleaseImageLegalHold []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID pgtype.UUID
+		}
+		// ReleaseProjectLegalHold holds details about calls to the ReleaseProjectLegalHold method.
+		ReleaseProjectLegalHold []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID pgtype.UUID
+		}
+		// ReleaseUsage holds details about calls to the ReleaseUsage method.
+		ReleaseUsage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg ReleaseUsageParams
+		}
+		// RemoveProjectRetentionExemption holds details about calls to the RemoveProjectRetentionExemption method.
+		RemoveProjectRetentionExemption []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID pgtype.UUID
+		}
+		// RemoveSCIMGroupMembers holds details about calls to the RemoveSCIMGroupMembers method.
+		RemoveSCIMGroupMembers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg RemoveSCIMGroupMembersParams
+		}
+		// ReserveUsage holds details about calls to the ReserveUsage method.
+		ReserveUsage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg ReserveUsageParams
+		}
+		// RevokeAPIKey holds details about calls to the RevokeAPIKey method.
+		RevokeAPIKey []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg RevokeAPIKeyParams
+		}
+		// RevokeProjectInvitation holds details about calls to the RevokeProjectInvitation method.
+		RevokeProjectInvitation []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg RevokeProjectInvitationParams
+		}
+		// RotateProjectShareKey holds details about calls to the RotateProjectShareKey method.
+		RotateProjectShareKey []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID pgtype.UUID
+		}
+		// RotateTeamWebhookSecret holds details about calls to the RotateTeamWebhookSecret method.
+		RotateTeamWebhookSecret []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg RotateTeamWebhookSecretParams
+		}
+		// SetOrganizationIPAllowlist holds details about calls to the SetOrganizationIPAllowlist method.
+		SetOrganizationIPAllowlist []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg SetOrganizationIPAllowlistParams
+		}
+		// SetProjectImagesRoomType holds details about calls to the SetProjectImagesRoomType method.
+		SetProjectImagesRoomType []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg SetProjectImagesRoomTypeParams
+		}
+		// StartJob holds details about calls to the StartJob method.
+		StartJob []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID pgtype.UUID
+		}
+		// SumPaidInvoicesSince holds details about calls to the SumPaidInvoicesSince method.
+		SumPaidInvoicesSince []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Since is the since argument value.
+			Since pgtype.Timestamptz
+		}
+		// SyncImageStatusFromEvents holds details about calls to the SyncImageStatusFromEvents method.
+		SyncImageStatusFromEvents []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg SyncImageStatusFromEventsParams
+		}
+		// TouchAPIKey holds details about calls to the TouchAPIKey method.
+		TouchAPIKey []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID pgtype.UUID
+		}
+		// TouchOrganizationSCIMToken holds details about calls to the TouchOrganizationSCIMToken method.
+		TouchOrganizationSCIMToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TokenHash is the tokenHash argument value.
+			TokenHash []byte
+		}
+		// UpdateCatalog holds details about calls to the UpdateCatalog method.
+		UpdateCatalog []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpdateCatalogParams
+		}
+		// UpdateImageCost holds details about calls to the UpdateImageCost method.
+		UpdateImageCost []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpdateImageCostParams
+		}
+		// UpdateImageStatus holds details about calls to the UpdateImageStatus method.
+		UpdateImageStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpdateImageStatusParams
+		}
+		// UpdateImageWithError holds details about calls to the UpdateImageWithError method.
+		UpdateImageWithError []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpdateImageWithErrorParams
+		}
+		// UpdateImageWithStagedURL holds details about calls to the UpdateImageWithStagedURL method.
+		UpdateImageWithStagedURL []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpdateImageWithStagedURLParams
+		}
+		// UpdateJobStatus holds details about calls to the UpdateJobStatus method.
+		UpdateJobStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpdateJobStatusParams
+		}
+		// UpdatePreset holds details about calls to the UpdatePreset method.
+		UpdatePreset []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpdatePresetParams
+		}
+		// UpdateProject holds details about calls to the UpdateProject method.
+		UpdateProject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpdateProjectParams
+		}
+		// UpdateProjectByUserID holds details about calls to the UpdateProjectByUserID method.
+		UpdateProjectByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpdateProjectByUserIDParams
+		}
+		// UpdateSCIMGroup holds details about calls to the UpdateSCIMGroup method.
+		UpdateSCIMGroup []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpdateSCIMGroupParams
+		}
+		// UpdateSCIMUser holds details about calls to the UpdateSCIMUser method.
+		UpdateSCIMUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpdateSCIMUserParams
+		}
+		// UpdateSetting holds details about calls to the UpdateSetting method.
+		UpdateSetting []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpdateSettingParams
+		}
+		// UpdateUserEncryptedFields holds details about calls to the UpdateUserEncryptedFields method.
+		UpdateUserEncryptedFields []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpdateUserEncryptedFieldsParams
+		}
+		// UpdateUserProfile holds details about calls to the UpdateUserProfile method.
+		UpdateUserProfile []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpdateUserProfileParams
+		}
+		// UpdateUserRole holds details about calls to the UpdateUserRole method.
+		UpdateUserRole []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpdateUserRoleParams
+		}
+		// UpdateUserStorageRegion holds details about calls to the UpdateUserStorageRegion method.
+		UpdateUserStorageRegion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpdateUserStorageRegionParams
+		}
+		// UpdateUserStripeCustomerID holds details about calls to the UpdateUserStripeCustomerID method.
+		UpdateUserStripeCustomerID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpdateUserStripeCustomerIDParams
+		}
+		// UpsertAccountWatermark holds details about calls to the UpsertAccountWatermark method.
+		UpsertAccountWatermark []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpsertAccountWatermarkParams
+		}
+		// UpsertCustomerBucket holds details about calls to the UpsertCustomerBucket method.
+		UpsertCustomerBucket []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpsertCustomerBucketParams
+		}
+		// UpsertInvoiceByStripeID holds details about calls to the UpsertInvoiceByStripeID method.
+		UpsertInvoiceByStripeID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpsertInvoiceByStripeIDParams
+		}
+		// UpsertOrganizationSCIMMember holds details about calls to the UpsertOrganizationSCIMMember method.
+		UpsertOrganizationSCIMMember []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpsertOrganizationSCIMMemberParams
+		}
+		// UpsertOrganizationSCIMToken holds details about calls to the UpsertOrganizationSCIMToken method.
+		UpsertOrganizationSCIMToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpsertOrganizationSCIMTokenParams
+		}
+		// UpsertOrganizationSSO holds details about calls to the UpsertOrganizationSSO method.
+		UpsertOrganizationSSO []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpsertOrganizationSSOParams
+		}
+		// UpsertOrganizationSSOMember holds details about calls to the UpsertOrganizationSSOMember method.
+		UpsertOrganizationSSOMember []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpsertOrganizationSSOMemberParams
+		}
+		// UpsertPendingBillingEvent holds details about calls to the UpsertPendingBillingEvent method.
+		UpsertPendingBillingEvent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpsertPendingBillingEventParams
+		}
+		// UpsertProcessedEventByStripeID holds details about calls to the UpsertProcessedEventByStripeID method.
+		UpsertProcessedEventByStripeID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpsertProcessedEventByStripeIDParams
+		}
+		// UpsertProjectDeletionIntent holds details about calls to the UpsertProjectDeletionIntent method.
+		UpsertProjectDeletionIntent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpsertProjectDeletionIntentParams
+		}
+		// UpsertProjectDisclosure holds details about calls to the UpsertProjectDisclosure method.
+		UpsertProjectDisclosure []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpsertProjectDisclosureParams
+		}
+		// UpsertProjectListing holds details about calls to the UpsertProjectListing method.
+		UpsertProjectListing []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpsertProjectListingParams
+		}
+		// UpsertShareBranding holds details about calls to the UpsertShareBranding method.
+		UpsertShareBranding []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpsertShareBrandingParams
+		}
+		// UpsertSubscriptionByStripeID holds details about calls to the UpsertSubscriptionByStripeID method.
+		UpsertSubscriptionByStripeID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpsertSubscriptionByStripeIDParams
+		}
+		// UpsertTeamWebhook holds details about calls to the UpsertTeamWebhook method.
+		UpsertTeamWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg UpsertTeamWebhookParams
+		}
+	}
+	lockAcceptProjectInvitation          sync.RWMutex
+	lockAddProjectRetentionExemption     sync.RWMutex
+	lockAddSCIMGroupMembers              sync.RWMutex
+	lockApplyProjectTemplateDisclosure   sync.RWMutex
+	lockApplyProjectTemplateRooms        sync.RWMutex
+	lockBulkCancelImages                 sync.RWMutex
+	lockBulkDeleteImages                 sync.RWMutex
+	lockCancelImage                      sync.RWMutex
+	lockCancelJobsByImageID              sync.RWMutex
+	lockClaimProcessedEvent              sync.RWMutex
+	lockClaimSCIMUser                    sync.RWMutex
+	lockCompleteJob                      sync.RWMutex
+	lockConfirmRoomGrouping              sync.RWMutex
+	lockConsumeExportCredit              sync.RWMutex
+	lockConsumeProjectDeletionIntent     sync.RWMutex
+	lockCountAPIKeysByUser               sync.RWMutex
+	lockCountActiveUsersSince            sync.RWMutex
+	lockCountAvailableExportCredits      sync.RWMutex
+	lockCountExportableImages            sync.RWMutex
+	lockCountJobsByStatus                sync.RWMutex
+	lockCountProjectImages               sync.RWMutex
+	lockCountProjectImagesByRoomType     sync.RWMutex
+	lockCountProjectTemplates            sync.RWMutex
+	lockCountProjectsByUserID            sync.RWMutex
+	lockCountSCIMGroups                  sync.RWMutex
+	lockCountSCIMUsers                   sync.RWMutex
+	lockCountUnreadNotifications         sync.RWMutex
+	lockCountUsers                       sync.RWMutex
+	lockCreateAPIKey                     sync.RWMutex
+	lockCreateCatalog                    sync.RWMutex
+	lockCreateExportCredit               sync.RWMutex
+	lockCreateImage                      sync.RWMutex
+	lockCreateImageAnnotations           sync.RWMutex
+	lockCreateImageBrackets              sync.RWMutex
+	lockCreateImageEdit                  sync.RWMutex
+	lockCreateImages                     sync.RWMutex
+	lockCreateJob                        sync.RWMutex
+	lockCreateJobs                       sync.RWMutex
+	lockCreateNotification               sync.RWMutex
+	lockCreateOrganization               sync.RWMutex
+	lockCreateOrganizationBreakGlass     sync.RWMutex
+	lockCreatePreset                     sync.RWMutex
+	lockCreateProcessedEvent             sync.RWMutex
+	lockCreateProject                    sync.RWMutex
+	lockCreateProjectExport              sync.RWMutex
+	lockCreateProjectInvitation          sync.RWMutex
+	lockCreateProjectRooms               sync.RWMutex
+	lockCreateProjectTemplate            sync.RWMutex
+	lockCreateRoomGrouping               sync.RWMutex
+	lockCreateSCIMGroup                  sync.RWMutex
+	lockCreateSCIMUser                   sync.RWMutex
+	lockCreateTeamWebhookDelivery        sync.RWMutex
+	lockCreateUser                       sync.RWMutex
+	lockDeleteAccountWatermark           sync.RWMutex
+	lockDeleteCatalog                    sync.RWMutex
+	lockDeleteCustomerBucket             sync.RWMutex
+	lockDeleteImage                      sync.RWMutex
+	lockDeleteImagesByProjectID          sync.RWMutex
+	lockDeleteJob                        sync.RWMutex
+	lockDeleteJobsByImageID              sync.RWMutex
+	lockDeleteOldProcessedEvents         sync.RWMutex
+	lockDeleteOrganizationSCIMMember     sync.RWMutex
+	lockDeleteOrganizationSCIMToken      sync.RWMutex
+	lockDeleteOrganizationSSO            sync.RWMutex
+	lockDeletePreset                     sync.RWMutex
+	lockDeleteProject                    sync.RWMutex
+	lockDeleteProjectByUserID            sync.RWMutex
+	lockDeleteProjectRooms               sync.RWMutex
+	lockDeleteProjectTemplate            sync.RWMutex
+	lockDeleteSCIMGroup                  sync.RWMutex
+	lockDeleteSCIMUser                   sync.RWMutex
+	lockDeleteShareBranding              sync.RWMutex
+	lockDeleteSubscriptionByStripeID     sync.RWMutex
+	lockDeleteTeamWebhook                sync.RWMutex
+	lockDeleteUser                       sync.RWMutex
+	lockFailImageEdit                    sync.RWMutex
+	lockFailJob                          sync.RWMutex
+	lockFailProjectExport                sync.RWMutex
+	lockFailRoomGrouping                 sync.RWMutex
+	lockGetAPIKeyByHash                  sync.RWMutex
+	lockGetAccountWatermark              sync.RWMutex
+	lockGetAllProjects                   sync.RWMutex
+	lockGetCatalogByID                   sync.RWMutex
+	lockGetCustomerBucketAccess          sync.RWMutex
+	lockGetCustomerBucketByName          sync.RWMutex
+	lockGetCustomerBucketByUserID        sync.RWMutex
+	lockGetDeadLetterJob                 sync.RWMutex
+	lockGetExpediteAccess                sync.RWMutex
+	lockGetImageAnalyticsBuckets         sync.RWMutex
+	lockGetImageByID                     sync.RWMutex
+	lockGetImageEdit                     sync.RWMutex
+	lockGetImageEditSource               sync.RWMutex
+	lockGetImageSourceBreakdown          sync.RWMutex
+	lockGetImageStatusesByIDs            sync.RWMutex
+	lockGetImagesByIDs                   sync.RWMutex
+	lockGetImagesByProjectID             sync.RWMutex
+	lockGetInvoiceByStripeID             sync.RWMutex
+	lockGetJobByID                       sync.RWMutex
+	lockGetJobLog                        sync.RWMutex
+	lockGetJobOutcomesSince              sync.RWMutex
+	lockGetJobsByImageID                 sync.RWMutex
+	lockGetLatestRoomGrouping            sync.RWMutex
+	lockGetOrganizationIPAllowlist       sync.RWMutex
+	lockGetOrganizationMember            sync.RWMutex
+	lockGetOrganizationSSO               sync.RWMutex
+	lockGetOrganizationSSOAccess         sync.RWMutex
+	lockGetOrganizationSSOByConnection   sync.RWMutex
+	lockGetOrganizationSSOByEmailDomain  sync.RWMutex
+	lockGetPendingJobs                   sync.RWMutex
+	lockGetPresetForProject              sync.RWMutex
+	lockGetProcessedEventByStripeID      sync.RWMutex
+	lockGetProjectByID                   sync.RWMutex
+	lockGetProjectByIDAndUserID          sync.RWMutex
+	lockGetProjectByIDForMember          sync.RWMutex
+	lockGetProjectCostSummary            sync.RWMutex
+	lockGetProjectDisclosure             sync.RWMutex
+	lockGetProjectExport                 sync.RWMutex
+	lockGetProjectExportCredit           sync.RWMutex
+	lockGetProjectInvitation             sync.RWMutex
+	lockGetProjectListing                sync.RWMutex
+	lockGetProjectRoomStyle              sync.RWMutex
+	lockGetProjectShareKey               sync.RWMutex
+	lockGetProjectSummary                sync.RWMutex
+	lockGetProjectTemplate               sync.RWMutex
+	lockGetProjectsByUserID              sync.RWMutex
+	lockGetReferenceImageAccess          sync.RWMutex
+	lockGetRoomGrouping                  sync.RWMutex
+	lockGetSCIMGroup                     sync.RWMutex
+	lockGetSCIMUser                      sync.RWMutex
+	lockGetSetting                       sync.RWMutex
+	lockGetShareBranding                 sync.RWMutex
+	lockGetShareBrandingByVerifiedDomain sync.RWMutex
+	lockGetStylePopularity               sync.RWMutex
+	lockGetSubscriptionByStripeID        sync.RWMutex
+	lockGetTeamWebhook                   sync.RWMutex
+	lockGetTeamWebhookDelivery           sync.RWMutex
+	lockGetTurnaroundSLAByPlan           sync.RWMutex
+	lockGetUsage                         sync.RWMutex
+	lockGetUserBillingPeriod             sync.RWMutex
+	lockGetUserByAuth0Sub                sync.RWMutex
+	lockGetUserByID                      sync.RWMutex
+	lockGetUserByStripeCustomerID        sync.RWMutex
+	lockGetUserByStripeCustomerIDHash    sync.RWMutex
+	lockGetUserEntitlements              sync.RWMutex
+	lockGetUserProfileByAuth0Sub         sync.RWMutex
+	lockGetUserProfileByID               sync.RWMutex
+	lockGetUserStorageRegion             sync.RWMutex
+	lockHasActiveRoomGrouping            sync.RWMutex
+	lockIsImageUnderLegalHold            sync.RWMutex
+	lockIsProjectRetentionExempt         sync.RWMutex
+	lockIsProjectUnderLegalHold          sync.RWMutex
+	lockListAPIKeysByUser                sync.RWMutex
+	lockListActiveCatalogs               sync.RWMutex
+	lockListCatalogs                     sync.RWMutex
+	lockListDataAccessByOwner            sync.RWMutex
+	lockListDeadLetterJobs               sync.RWMutex
+	lockListIPAllowlistsForSubject       sync.RWMutex
+	lockListImageStatusDrift             sync.RWMutex
+	lockListImageStatusTransitions       sync.RWMutex
+	lockListImagesForReconcile           sync.RWMutex
+	lockListInvoicesByUserID             sync.RWMutex
+	lockListJobs                         sync.RWMutex
+	lockListLegalHeldImageIDs            sync.RWMutex
+	lockListLegalHolds                   sync.RWMutex
+	lockListNotifications                sync.RWMutex
+	lockListOrganizationAuditLog         sync.RWMutex
+	lockListOrganizationMembers          sync.RWMutex
+	lockListOrganizationsByUser          sync.RWMutex
+	lockListPresetsByUser                sync.RWMutex
+	lockListProjectActivity              sync.RWMutex
+	lockListProjectImageRooms            sync.RWMutex
+	lockListProjectInvitations           sync.RWMutex
+	lockListProjectRooms                 sync.RWMutex
+	lockListProjectSummariesByUser       sync.RWMutex
+	lockListProjectTemplates             sync.RWMutex
+	lockListProjectThumbnails            sync.RWMutex
+	lockListSCIMGroupMembers             sync.RWMutex
+	lockListSCIMGroups                   sync.RWMutex
+	lockListSCIMUserGroupNames           sync.RWMutex
+	lockListSCIMUsers                    sync.RWMutex
+	lockListSettings                     sync.RWMutex
+	lockListSubscriptionsByUserID        sync.RWMutex
+	lockListTeamWebhookDeliveries        sync.RWMutex
+	lockListTeamWebhooks                 sync.RWMutex
+	lockListTeamWebhooksForEvent         sync.RWMutex
+	lockListUserEncryptedFields          sync.RWMutex
+	lockListUsers                        sync.RWMutex
+	lockMarkAllNotificationsRead         sync.RWMutex
+	lockMarkCustomerBucketVerified       sync.RWMutex
+	lockMarkDeadLetterJobRequeued        sync.RWMutex
+	lockMarkNotificationRead             sync.RWMutex
+	lockMarkShareBrandingDomainVerified  sync.RWMutex
+	lockOrganizationSSODomainsTaken      sync.RWMutex
+	lockPlaceImageLegalHold              sync.RWMutex
+	lockPlaceProjectLegalHold            sync.RWMutex
+	lockRecordDataAccess                 sync.RWMutex
+	lockRecordImageExpedited             sync.RWMutex
+	lockRecordOrganizationAuditEvent     sync.RWMutex
+	lockRecordTeamWebhookDelivery        sync.RWMutex
+	lockRecordTeamWebhookDeliveryAttempt sync.RWMutex
+	lockReleaseImageLegalHold            sync.RWMutex
+	lockReleaseProjectLegalHold          sync.RWMutex
+	lockReleaseUsage                     sync.RWMutex
+	lockRemoveProjectRetentionExemption  sync.RWMutex
+	lockRemoveSCIMGroupMembers           sync.RWMutex
+	lockReserveUsage                     sync.RWMutex
+	lockRevokeAPIKey                     sync.RWMutex
+	lockRevokeProjectInvitation          sync.RWMutex
+	lockRotateProjectShareKey            sync.RWMutex
+	lockRotateTeamWebhookSecret          sync.RWMutex
+	lockSetOrganizationIPAllowlist       sync.RWMutex
+	lockSetProjectImagesRoomType         sync.RWMutex
+	lockStartJob                         sync.RWMutex
+	lockSumPaidInvoicesSince             sync.RWMutex
+	lockSyncImageStatusFromEvents        sync.RWMutex
+	lockTouchAPIKey                      sync.RWMutex
+	lockTouchOrganizationSCIMToken       sync.RWMutex
+	lockUpdateCatalog                    sync.RWMutex
+	lockUpdateImageCost                  sync.RWMutex
+	lockUpdateImageStatus                sync.RWMutex
+	lockUpdateImageWithError             sync.RWMutex
+	lockUpdateImageWithStagedURL         sync.RWMutex
+	lockUpdateJobStatus                  sync.RWMutex
+	lockUpdatePreset                     sync.RWMutex
+	lockUpdateProject                    sync.RWMutex
+	lockUpdateProjectByUserID            sync.RWMutex
+	lockUpdateSCIMGroup                  sync.RWMutex
+	lockUpdateSCIMUser                   sync.RWMutex
+	lockUpdateSetting                    sync.RWMutex
+	lockUpdateUserEncryptedFields        sync.RWMutex
+	lockUpdateUserProfile                sync.RWMutex
+	lockUpdateUserRole                   sync.RWMutex
+	lockUpdateUserStorageRegion          sync.RWMutex
+	lockUpdateUserStripeCustomerID       sync.RWMutex
+	lockUpsertAccountWatermark           sync.RWMutex
+	lockUpsertCustomerBucket             sync.RWMutex
+	lockUpsertInvoiceByStripeID          sync.RWMutex
+	lockUpsertOrganizationSCIMMember     sync.RWMutex
+	lockUpsertOrganizationSCIMToken      sync.RWMutex
+	lockUpsertOrganizationSSO            sync.RWMutex
+	lockUpsertOrganizationSSOMember      sync.RWMutex
+	lockUpsertPendingBillingEvent        sync.RWMutex
+	lockUpsertProcessedEventByStripeID   sync.RWMutex
+	lockUpsertProjectDeletionIntent      sync.RWMutex
+	lockUpsertProjectDisclosure          sync.RWMutex
+	lockUpsertProjectListing             sync.RWMutex
+	lockUpsertShareBranding              sync.RWMutex
+	lockUpsertSubscriptionByStripeID     sync.RWMutex
+	lockUpsertTeamWebhook                sync.RWMutex
+}
+
+// AcceptProjectInvitation calls AcceptProjectInvitationFunc.
+func (mock *QuerierMock) AcceptProjectInvitation(ctx context.Context, arg AcceptProjectInvitationParams) (*ProjectCollaborator, error) {
+	if mock.AcceptProjectInvitationFunc == nil {
+		panic("QuerierMock.AcceptProjectInvitationFunc: method is nil but Querier.AcceptProjectInvitation was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg AcceptProjectInvitationParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockAcceptProjectInvitation.Lock()
+	mock.calls.AcceptProjectInvitation = append(mock.calls.AcceptProjectInvitation, callInfo)
+	mock.lockAcceptProjectInvitation.Unlock()
+	return mock.AcceptProjectInvitationFunc(ctx, arg)
+}
+
+// AcceptProjectInvitationCalls gets all the calls that were made to AcceptProjectInvitation.
+// Check the length with:
+//
+//	len(mockedQuerier.AcceptProjectInvitationCalls())
+func (mock *QuerierMock) AcceptProjectInvitationCalls() []struct {
+	Ctx context.Context
+	Arg AcceptProjectInvitationParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg AcceptProjectInvitationParams
+	}
+	mock.lockAcceptProjectInvitation.RLock()
+	calls = mock.calls.AcceptProjectInvitation
+	mock.lockAcceptProjectInvitation.RUnlock()
+	return calls
+}
+
+// AddProjectRetentionExemption calls AddProjectRetentionExemptionFunc.
+func (mock *QuerierMock) AddProjectRetentionExemption(ctx context.Context, projectID pgtype.UUID) error {
+	if mock.AddProjectRetentionExemptionFunc == nil {
+		panic("QuerierMock.AddProjectRetentionExemptionFunc: method is nil but Querier.AddProjectRetentionExemption was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockAddProjectRetentionExemption.Lock()
+	mock.calls.AddProjectRetentionExemption = append(mock.calls.AddProjectRetentionExemption, callInfo)
+	mock.lockAddProjectRetentionExemption.Unlock()
+	return mock.AddProjectRetentionExemptionFunc(ctx, projectID)
+}
+
+// AddProjectRetentionExemptionCalls gets all the calls that were made to AddProjectRetentionExemption.
+// Check the length with:
+//
+//	len(mockedQuerier.AddProjectRetentionExemptionCalls())
+func (mock *QuerierMock) AddProjectRetentionExemptionCalls() []struct {
+	Ctx       context.Context
+	ProjectID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}
+	mock.lockAddProjectRetentionExemption.RLock()
+	calls = mock.calls.AddProjectRetentionExemption
+	mock.lockAddProjectRetentionExemption.RUnlock()
+	return calls
+}
+
+// AddSCIMGroupMembers calls AddSCIMGroupMembersFunc.
+func (mock *QuerierMock) AddSCIMGroupMembers(ctx context.Context, arg AddSCIMGroupMembersParams) (int64, error) {
+	if mock.AddSCIMGroupMembersFunc == nil {
+		panic("QuerierMock.AddSCIMGroupMembersFunc: method is nil but Querier.AddSCIMGroupMembers was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg AddSCIMGroupMembersParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockAddSCIMGroupMembers.Lock()
+	mock.calls.AddSCIMGroupMembers = append(mock.calls.AddSCIMGroupMembers, callInfo)
+	mock.lockAddSCIMGroupMembers.Unlock()
+	return mock.AddSCIMGroupMembersFunc(ctx, arg)
+}
+
+// AddSCIMGroupMembersCalls gets all the calls that were made to AddSCIMGroupMembers.
+// Check the length with:
+//
+//	len(mockedQuerier.AddSCIMGroupMembersCalls())
+func (mock *QuerierMock) AddSCIMGroupMembersCalls() []struct {
+	Ctx context.Context
+	Arg AddSCIMGroupMembersParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg AddSCIMGroupMembersParams
+	}
+	mock.lockAddSCIMGroupMembers.RLock()
+	calls = mock.calls.AddSCIMGroupMembers
+	mock.lockAddSCIMGroupMembers.RUnlock()
+	return calls
+}
+
+// ApplyProjectTemplateDisclosure calls ApplyProjectTemplateDisclosureFunc.
+func (mock *QuerierMock) ApplyProjectTemplateDisclosure(ctx context.Context, arg ApplyProjectTemplateDisclosureParams) error {
+	if mock.ApplyProjectTemplateDisclosureFunc == nil {
+		panic("QuerierMock.ApplyProjectTemplateDisclosureFunc: method is nil but Querier.ApplyProjectTemplateDisclosure was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg ApplyProjectTemplateDisclosureParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockApplyProjectTemplateDisclosure.Lock()
+	mock.calls.ApplyProjectTemplateDisclosure = append(mock.calls.ApplyProjectTemplateDisclosure, callInfo)
+	mock.lockApplyProjectTemplateDisclosure.Unlock()
+	return mock.ApplyProjectTemplateDisclosureFunc(ctx, arg)
+}
+
+// ApplyProjectTemplateDisclosureCalls gets all the calls that were made to ApplyProjectTemplateDisclosure.
+// Check the length with:
+//
+//	len(mockedQuerier.ApplyProjectTemplateDisclosureCalls())
+func (mock *QuerierMock) ApplyProjectTemplateDisclosureCalls() []struct {
+	Ctx context.Context
+	Arg ApplyProjectTemplateDisclosureParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg ApplyProjectTemplateDisclosureParams
+	}
+	mock.lockApplyProjectTemplateDisclosure.RLock()
+	calls = mock.calls.ApplyProjectTemplateDisclosure
+	mock.lockApplyProjectTemplateDisclosure.RUnlock()
+	return calls
+}
+
+// ApplyProjectTemplateRooms calls ApplyProjectTemplateRoomsFunc.
+func (mock *QuerierMock) ApplyProjectTemplateRooms(ctx context.Context, arg ApplyProjectTemplateRoomsParams) error {
+	if mock.ApplyProjectTemplateRoomsFunc == nil {
+		panic("QuerierMock.ApplyProjectTemplateRoomsFunc: method is nil but Querier.ApplyProjectTemplateRooms was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg ApplyProjectTemplateRoomsParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockApplyProjectTemplateRooms.Lock()
+	mock.calls.ApplyProjectTemplateRooms = append(mock.calls.ApplyProjectTemplateRooms, callInfo)
+	mock.lockApplyProjectTemplateRooms.Unlock()
+	return mock.ApplyProjectTemplateRoomsFunc(ctx, arg)
+}
+
+// ApplyProjectTemplateRoomsCalls gets all the calls that were made to ApplyProjectTemplateRooms.
+// Check the length with:
+//
+//	len(mockedQuerier.ApplyProjectTemplateRoomsCalls())
+func (mock *QuerierMock) ApplyProjectTemplateRoomsCalls() []struct {
+	Ctx context.Context
+	Arg ApplyProjectTemplateRoomsParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg ApplyProjectTemplateRoomsParams
+	}
+	mock.lockApplyProjectTemplateRooms.RLock()
+	calls = mock.calls.ApplyProjectTemplateRooms
+	mock.lockApplyProjectTemplateRooms.RUnlock()
+	return calls
+}
+
+// BulkCancelImages calls BulkCancelImagesFunc.
+func (mock *QuerierMock) BulkCancelImages(ctx context.Context, arg BulkCancelImagesParams) ([]*BulkCancelImagesRow, error) {
+	if mock.BulkCancelImagesFunc == nil {
+		panic("QuerierMock.BulkCancelImagesFunc: method is nil but Querier.BulkCancelImages was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg BulkCancelImagesParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockBulkCancelImages.Lock()
+	mock.calls.BulkCancelImages = append(mock.calls.BulkCancelImages, callInfo)
+	mock.lockBulkCancelImages.Unlock()
+	return mock.BulkCancelImagesFunc(ctx, arg)
+}
+
+// BulkCancelImagesCalls gets all the calls that were made to BulkCancelImages.
+// Check the length with:
+//
+//	len(mockedQuerier.BulkCancelImagesCalls())
+func (mock *QuerierMock) BulkCancelImagesCalls() []struct {
+	Ctx context.Context
+	Arg BulkCancelImagesParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg BulkCancelImagesParams
+	}
+	mock.lockBulkCancelImages.RLock()
+	calls = mock.calls.BulkCancelImages
+	mock.lockBulkCancelImages.RUnlock()
+	return calls
+}
+
+// BulkDeleteImages calls BulkDeleteImagesFunc.
+func (mock *QuerierMock) BulkDeleteImages(ctx context.Context, arg BulkDeleteImagesParams) ([]*BulkDeleteImagesRow, error) {
+	if mock.BulkDeleteImagesFunc == nil {
+		panic("QuerierMock.BulkDeleteImagesFunc: method is nil but Querier.BulkDeleteImages was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg BulkDeleteImagesParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockBulkDeleteImages.Lock()
+	mock.calls.BulkDeleteImages = append(mock.calls.BulkDeleteImages, callInfo)
+	mock.lockBulkDeleteImages.Unlock()
+	return mock.BulkDeleteImagesFunc(ctx, arg)
+}
+
+// BulkDeleteImagesCalls gets all the calls that were made to BulkDeleteImages.
+// Check the length with:
+//
+//	len(mockedQuerier.BulkDeleteImagesCalls())
+func (mock *QuerierMock) BulkDeleteImagesCalls() []struct {
+	Ctx context.Context
+	Arg BulkDeleteImagesParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg BulkDeleteImagesParams
+	}
+	mock.lockBulkDeleteImages.RLock()
+	calls = mock.calls.BulkDeleteImages
+	mock.lockBulkDeleteImages.RUnlock()
+	return calls
+}
+
+// CancelImage calls CancelImageFunc.
+func (mock *QuerierMock) CancelImage(ctx context.Context, id pgtype.UUID) (*CancelImageRow, error) {
+	if mock.CancelImageFunc == nil {
+		panic("QuerierMock.CancelImageFunc: method is nil but Querier.CancelImage was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockCancelImage.Lock()
+	mock.calls.CancelImage = append(mock.calls.CancelImage, callInfo)
+	mock.lockCancelImage.Unlock()
+	return mock.CancelImageFunc(ctx, id)
+}
+
+// CancelImageCalls gets all the calls that were made to CancelImage.
+// Check the length with:
+//
+//	len(mockedQuerier.CancelImageCalls())
+func (mock *QuerierMock) CancelImageCalls() []struct {
+	Ctx context.Context
+	ID  pgtype.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}
+	mock.lockCancelImage.RLock()
+	calls = mock.calls.CancelImage
+	mock.lockCancelImage.RUnlock()
+	return calls
+}
+
+// CancelJobsByImageID calls CancelJobsByImageIDFunc.
+func (mock *QuerierMock) CancelJobsByImageID(ctx context.Context, imageID pgtype.UUID) ([]*Job, error) {
+	if mock.CancelJobsByImageIDFunc == nil {
+		panic("QuerierMock.CancelJobsByImageIDFunc: method is nil but Querier.CancelJobsByImageID was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ImageID pgtype.UUID
+	}{
+		Ctx:     ctx,
+		ImageID: imageID,
+	}
+	mock.lockCancelJobsByImageID.Lock()
+	mock.calls.CancelJobsByImageID = append(mock.calls.CancelJobsByImageID, callInfo)
+	mock.lockCancelJobsByImageID.Unlock()
+	return mock.CancelJobsByImageIDFunc(ctx, imageID)
+}
+
+// CancelJobsByImageIDCalls gets all the calls that were made to CancelJobsByImageID.
+// Check the length with:
+//
+//	len(mockedQuerier.CancelJobsByImageIDCalls())
+func (mock *QuerierMock) CancelJobsByImageIDCalls() []struct {
+	Ctx     context.Context
+	ImageID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ImageID pgtype.UUID
+	}
+	mock.lockCancelJobsByImageID.RLock()
+	calls = mock.calls.CancelJobsByImageID
+	mock.lockCancelJobsByImageID.RUnlock()
+	return calls
+}
+
+// ClaimProcessedEvent calls ClaimProcessedEventFunc.
+func (mock *QuerierMock) ClaimProcessedEvent(ctx context.Context, arg ClaimProcessedEventParams) (*ProcessedEvent, error) {
+	if mock.ClaimProcessedEventFunc == nil {
+		panic("QuerierMock.ClaimProcessedEventFunc: method is nil but Querier.ClaimProcessedEvent was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg ClaimProcessedEventParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockClaimProcessedEvent.Lock()
+	mock.calls.ClaimProcessedEvent = append(mock.calls.ClaimProcessedEvent, callInfo)
+	mock.lockClaimProcessedEvent.Unlock()
+	return mock.ClaimProcessedEventFunc(ctx, arg)
+}
+
+// ClaimProcessedEventCalls gets all the calls that were made to ClaimProcessedEvent.
+// Check the length with:
+//
+//	len(mockedQuerier.ClaimProcessedEventCalls())
+func (mock *QuerierMock) ClaimProcessedEventCalls() []struct {
+	Ctx context.Context
+	Arg ClaimProcessedEventParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg ClaimProcessedEventParams
+	}
+	mock.lockClaimProcessedEvent.RLock()
+	calls = mock.calls.ClaimProcessedEvent
+	mock.lockClaimProcessedEvent.RUnlock()
+	return calls
+}
+
+// ClaimSCIMUser calls ClaimSCIMUserFunc.
+func (mock *QuerierMock) ClaimSCIMUser(ctx context.Context, arg ClaimSCIMUserParams) (int64, error) {
+	if mock.ClaimSCIMUserFunc == nil {
+		panic("QuerierMock.ClaimSCIMUserFunc: method is nil but Querier.ClaimSCIMUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg ClaimSCIMUserParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockClaimSCIMUser.Lock()
+	mock.calls.ClaimSCIMUser = append(mock.calls.ClaimSCIMUser, callInfo)
+	mock.lockClaimSCIMUser.Unlock()
+	return mock.ClaimSCIMUserFunc(ctx, arg)
+}
+
+// ClaimSCIMUserCalls gets all the calls that were made to ClaimSCIMUser.
+// Check the length with:
+//
+//	len(mockedQuerier.ClaimSCIMUserCalls())
+func (mock *QuerierMock) ClaimSCIMUserCalls() []struct {
+	Ctx context.Context
+	Arg ClaimSCIMUserParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg ClaimSCIMUserParams
+	}
+	mock.lockClaimSCIMUser.RLock()
+	calls = mock.calls.ClaimSCIMUser
+	mock.lockClaimSCIMUser.RUnlock()
+	return calls
+}
+
+// CompleteJob calls CompleteJobFunc.
+func (mock *QuerierMock) CompleteJob(ctx context.Context, id pgtype.UUID) (*Job, error) {
+	if mock.CompleteJobFunc == nil {
+		panic("QuerierMock.CompleteJobFunc: method is nil but Querier.CompleteJob was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockCompleteJob.Lock()
+	mock.calls.CompleteJob = append(mock.calls.CompleteJob, callInfo)
+	mock.lockCompleteJob.Unlock()
+	return mock.CompleteJobFunc(ctx, id)
+}
+
+// CompleteJobCalls gets all the calls that were made to CompleteJob.
+// Check the length with:
+//
+//	len(mockedQuerier.CompleteJobCalls())
+func (mock *QuerierMock) CompleteJobCalls() []struct {
+	Ctx context.Context
+	ID  pgtype.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}
+	mock.lockCompleteJob.RLock()
+	calls = mock.calls.CompleteJob
+	mock.lockCompleteJob.RUnlock()
+	return calls
+}
+
+// ConfirmRoomGrouping calls ConfirmRoomGroupingFunc.
+func (mock *QuerierMock) ConfirmRoomGrouping(ctx context.Context, arg ConfirmRoomGroupingParams) (*RoomGrouping, error) {
+	if mock.ConfirmRoomGroupingFunc == nil {
+		panic("QuerierMock.ConfirmRoomGroupingFunc: method is nil but Querier.ConfirmRoomGrouping was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg ConfirmRoomGroupingParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockConfirmRoomGrouping.Lock()
+	mock.calls.ConfirmRoomGrouping = append(mock.calls.ConfirmRoomGrouping, callInfo)
+	mock.lockConfirmRoomGrouping.Unlock()
+	return mock.ConfirmRoomGroupingFunc(ctx, arg)
+}
+
+// ConfirmRoomGroupingCalls gets all the calls that were made to ConfirmRoomGrouping.
+// Check the length with:
+//
+//	len(mockedQuerier.ConfirmRoomGroupingCalls())
+func (mock *QuerierMock) ConfirmRoomGroupingCalls() []struct {
+	Ctx context.Context
+	Arg ConfirmRoomGroupingParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg ConfirmRoomGroupingParams
+	}
+	mock.lockConfirmRoomGrouping.RLock()
+	calls = mock.calls.ConfirmRoomGrouping
+	mock.lockConfirmRoomGrouping.RUnlock()
+	return calls
+}
+
+// ConsumeExportCredit calls ConsumeExportCreditFunc.
+func (mock *QuerierMock) ConsumeExportCredit(ctx context.Context, arg ConsumeExportCreditParams) (*ExportCredit, error) {
+	if mock.ConsumeExportCreditFunc == nil {
+		panic("QuerierMock.ConsumeExportCreditFunc: method is nil but Querier.ConsumeExportCredit was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg ConsumeExportCreditParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockConsumeExportCredit.Lock()
+	mock.calls.ConsumeExportCredit = append(mock.calls.ConsumeExportCredit, callInfo)
+	mock.lockConsumeExportCredit.Unlock()
+	return mock.ConsumeExportCreditFunc(ctx, arg)
+}
+
+// ConsumeExportCreditCalls gets all the calls that were made to ConsumeExportCredit.
+// Check the length with:
+//
+//	len(mockedQuerier.ConsumeExportCreditCalls())
+func (mock *QuerierMock) ConsumeExportCreditCalls() []struct {
+	Ctx context.Context
+	Arg ConsumeExportCreditParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg ConsumeExportCreditParams
+	}
+	mock.lockConsumeExportCredit.RLock()
+	calls = mock.calls.ConsumeExportCredit
+	mock.lockConsumeExportCredit.RUnlock()
+	return calls
+}
+
+// ConsumeProjectDeletionIntent calls ConsumeProjectDeletionIntentFunc.
+func (mock *QuerierMock) ConsumeProjectDeletionIntent(ctx context.Context, arg ConsumeProjectDeletionIntentParams) (int64, error) {
+	if mock.ConsumeProjectDeletionIntentFunc == nil {
+		panic("QuerierMock.ConsumeProjectDeletionIntentFunc: method is nil but Querier.ConsumeProjectDeletionIntent was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg ConsumeProjectDeletionIntentParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockConsumeProjectDeletionIntent.Lock()
+	mock.calls.ConsumeProjectDeletionIntent = append(mock.calls.ConsumeProjectDeletionIntent, callInfo)
+	mock.lockConsumeProjectDeletionIntent.Unlock()
+	return mock.ConsumeProjectDeletionIntentFunc(ctx, arg)
+}
+
+// ConsumeProjectDeletionIntentCalls gets all the calls that were made to ConsumeProjectDeletionIntent.
+// Check the length with:
+//
+//	len(mockedQuerier.ConsumeProjectDeletionIntentCalls())
+func (mock *QuerierMock) ConsumeProjectDeletionIntentCalls() []struct {
+	Ctx context.Context
+	Arg ConsumeProjectDeletionIntentParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg ConsumeProjectDeletionIntentParams
+	}
+	mock.lockConsumeProjectDeletionIntent.RLock()
+	calls = mock.calls.ConsumeProjectDeletionIntent
+	mock.lockConsumeProjectDeletionIntent.RUnlock()
+	return calls
+}
+
+// CountAPIKeysByUser calls CountAPIKeysByUserFunc.
+func (mock *QuerierMock) CountAPIKeysByUser(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	if mock.CountAPIKeysByUserFunc == nil {
+		panic("QuerierMock.CountAPIKeysByUserFunc: method is nil but Querier.CountAPIKeysByUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockCountAPIKeysByUser.Lock()
+	mock.calls.CountAPIKeysByUser = append(mock.calls.CountAPIKeysByUser, callInfo)
+	mock.lockCountAPIKeysByUser.Unlock()
+	return mock.CountAPIKeysByUserFunc(ctx, userID)
+}
+
+// CountAPIKeysByUserCalls gets all the calls that were made to CountAPIKeysByUser.
+// Check the length with:
+//
+//	len(mockedQuerier.CountAPIKeysByUserCalls())
+func (mock *QuerierMock) CountAPIKeysByUserCalls() []struct {
+	Ctx    context.Context
+	UserID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}
+	mock.lockCountAPIKeysByUser.RLock()
+	calls = mock.calls.CountAPIKeysByUser
+	mock.lockCountAPIKeysByUser.RUnlock()
+	return calls
+}
+
+// CountActiveUsersSince calls CountActiveUsersSinceFunc.
+func (mock *QuerierMock) CountActiveUsersSince(ctx context.Context, since pgtype.Timestamptz) (int64, error) {
+	if mock.CountActiveUsersSinceFunc == nil {
+		panic("QuerierMock.CountActiveUsersSinceFunc: method is nil but Querier.CountActiveUsersSince was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Since pgtype.Timestamptz
+	}{
+		Ctx:   ctx,
+		Since: since,
+	}
+	mock.lockCountActiveUsersSince.Lock()
+	mock.calls.CountActiveUsersSince = append(mock.calls.CountActiveUsersSince, callInfo)
+	mock.lockCountActiveUsersSince.Unlock()
+	return mock.CountActiveUsersSinceFunc(ctx, since)
+}
+
+// CountActiveUsersSinceCalls gets all the calls that were made to CountActiveUsersSince.
+// Check the length with:
+//
+//	len(mockedQuerier.CountActiveUsersSinceCalls())
+func (mock *QuerierMock) CountActiveUsersSinceCalls() []struct {
+	Ctx   context.Context
+	Since pgtype.Timestamptz
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Since pgtype.Timestamptz
+	}
+	mock.lockCountActiveUsersSince.RLock()
+	calls = mock.calls.CountActiveUsersSince
+	mock.lockCountActiveUsersSince.RUnlock()
+	return calls
+}
+
+// CountAvailableExportCredits calls CountAvailableExportCreditsFunc.
+func (mock *QuerierMock) CountAvailableExportCredits(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	if mock.CountAvailableExportCreditsFunc == nil {
+		panic("QuerierMock.CountAvailableExportCreditsFunc: method is nil but Querier.CountAvailableExportCredits was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockCountAvailableExportCredits.Lock()
+	mock.calls.CountAvailableExportCredits = append(mock.calls.CountAvailableExportCredits, callInfo)
+	mock.lockCountAvailableExportCredits.Unlock()
+	return mock.CountAvailableExportCreditsFunc(ctx, userID)
+}
+
+// CountAvailableExportCreditsCalls gets all the calls that were made to CountAvailableExportCredits.
+// Check the length with:
+//
+//	len(mockedQuerier.CountAvailableExportCreditsCalls())
+func (mock *QuerierMock) CountAvailableExportCreditsCalls() []struct {
+	Ctx    context.Context
+	UserID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}
+	mock.lockCountAvailableExportCredits.RLock()
+	calls = mock.calls.CountAvailableExportCredits
+	mock.lockCountAvailableExportCredits.RUnlock()
+	return calls
+}
+
+// CountExportableImages calls CountExportableImagesFunc.
+func (mock *QuerierMock) CountExportableImages(ctx context.Context, projectID pgtype.UUID) (int64, error) {
+	if mock.CountExportableImagesFunc == nil {
+		panic("QuerierMock.CountExportableImagesFunc: method is nil but Querier.CountExportableImages was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockCountExportableImages.Lock()
+	mock.calls.CountExportableImages = append(mock.calls.CountExportableImages, callInfo)
+	mock.lockCountExportableImages.Unlock()
+	return mock.CountExportableImagesFunc(ctx, projectID)
+}
+
+// CountExportableImagesCalls gets all the calls that were made to CountExportableImages.
+// Check the length with:
+//
+//	len(mockedQuerier.CountExportableImagesCalls())
+func (mock *QuerierMock) CountExportableImagesCalls() []struct {
+	Ctx       context.Context
+	ProjectID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}
+	mock.lockCountExportableImages.RLock()
+	calls = mock.calls.CountExportableImages
+	mock.lockCountExportableImages.RUnlock()
+	return calls
+}
+
+// CountJobsByStatus calls CountJobsByStatusFunc.
+func (mock *QuerierMock) CountJobsByStatus(ctx context.Context) ([]*CountJobsByStatusRow, error) {
+	if mock.CountJobsByStatusFunc == nil {
+		panic("QuerierMock.CountJobsByStatusFunc: method is nil but Querier.CountJobsByStatus was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockCountJobsByStatus.Lock()
+	mock.calls.CountJobsByStatus = append(mock.calls.CountJobsByStatus, callInfo)
+	mock.lockCountJobsByStatus.Unlock()
+	return mock.CountJobsByStatusFunc(ctx)
+}
+
+// CountJobsByStatusCalls gets all the calls that were made to CountJobsByStatus.
+// Check the length with:
+//
+//	len(mockedQuerier.CountJobsByStatusCalls())
+func (mock *QuerierMock) CountJobsByStatusCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockCountJobsByStatus.RLock()
+	calls = mock.calls.CountJobsByStatus
+	mock.lockCountJobsByStatus.RUnlock()
+	return calls
+}
+
+// CountProjectImages calls CountProjectImagesFunc.
+func (mock *QuerierMock) CountProjectImages(ctx context.Context, projectID pgtype.UUID) (int64, error) {
+	if mock.CountProjectImagesFunc == nil {
+		panic("QuerierMock.CountProjectImagesFunc: method is nil but Querier.CountProjectImages was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockCountProjectImages.Lock()
+	mock.calls.CountProjectImages = append(mock.calls.CountProjectImages, callInfo)
+	mock.lockCountProjectImages.Unlock()
+	return mock.CountProjectImagesFunc(ctx, projectID)
+}
+
+// CountProjectImagesCalls gets all the calls that were made to CountProjectImages.
+// Check the length with:
+//
+//	len(mockedQuerier.CountProjectImagesCalls())
+func (mock *QuerierMock) CountProjectImagesCalls() []struct {
+	Ctx       context.Context
+	ProjectID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}
+	mock.lockCountProjectImages.RLock()
+	calls = mock.calls.CountProjectImages
+	mock.lockCountProjectImages.RUnlock()
+	return calls
+}
+
+// CountProjectImagesByRoomType calls CountProjectImagesByRoomTypeFunc.
+func (mock *QuerierMock) CountProjectImagesByRoomType(ctx context.Context, projectID pgtype.UUID) ([]*CountProjectImagesByRoomTypeRow, error) {
+	if mock.CountProjectImagesByRoomTypeFunc == nil {
+		panic("QuerierMock.CountProjectImagesByRoomTypeFunc: method is nil but Querier.CountProjectImagesByRoomType was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockCountProjectImagesByRoomType.Lock()
+	mock.calls.CountProjectImagesByRoomType = append(mock.calls.CountProjectImagesByRoomType, callInfo)
+	mock.lockCountProjectImagesByRoomType.Unlock()
+	return mock.CountProjectImagesByRoomTypeFunc(ctx, projectID)
+}
+
+// CountProjectImagesByRoomTypeCalls gets all the calls that were made to CountProjectImagesByRoomType.
+// Check the length with:
+//
+//	len(mockedQuerier.CountProjectImagesByRoomTypeCalls())
+func (mock *QuerierMock) CountProjectImagesByRoomTypeCalls() []struct {
+	Ctx       context.Context
+	ProjectID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}
+	mock.lockCountProjectImagesByRoomType.RLock()
+	calls = mock.calls.CountProjectImagesByRoomType
+	mock.lockCountProjectImagesByRoomType.RUnlock()
+	return calls
+}
+
+// CountProjectTemplates calls CountProjectTemplatesFunc.
+func (mock *QuerierMock) CountProjectTemplates(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	if mock.CountProjectTemplatesFunc == nil {
+		panic("QuerierMock.CountProjectTemplatesFunc: method is nil but Querier.CountProjectTemplates was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockCountProjectTemplates.Lock()
+	mock.calls.CountProjectTemplates = append(mock.calls.CountProjectTemplates, callInfo)
+	mock.lockCountProjectTemplates.Unlock()
+	return mock.CountProjectTemplatesFunc(ctx, userID)
+}
+
+// CountProjectTemplatesCalls gets all the calls that were made to CountProjectTemplates.
+// Check the length with:
+//
+//	len(mockedQuerier.CountProjectTemplatesCalls())
+func (mock *QuerierMock) CountProjectTemplatesCalls() []struct {
+	Ctx    context.Context
+	UserID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}
+	mock.lockCountProjectTemplates.RLock()
+	calls = mock.calls.CountProjectTemplates
+	mock.lockCountProjectTemplates.RUnlock()
+	return calls
+}
+
+// CountProjectsByUserID calls CountProjectsByUserIDFunc.
+func (mock *QuerierMock) CountProjectsByUserID(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	if mock.CountProjectsByUserIDFunc == nil {
+		panic("QuerierMock.CountProjectsByUserIDFunc: method is nil but Querier.CountProjectsByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockCountProjectsByUserID.Lock()
+	mock.calls.CountProjectsByUserID = append(mock.calls.CountProjectsByUserID, callInfo)
+	mock.lockCountProjectsByUserID.Unlock()
+	return mock.CountProjectsByUserIDFunc(ctx, userID)
+}
+
+// CountProjectsByUserIDCalls gets all the calls that were made to CountProjectsByUserID.
+// Check the length with:
+//
+//	len(mockedQuerier.CountProjectsByUserIDCalls())
+func (mock *QuerierMock) CountProjectsByUserIDCalls() []struct {
+	Ctx    context.Context
+	UserID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}
+	mock.lockCountProjectsByUserID.RLock()
+	calls = mock.calls.CountProjectsByUserID
+	mock.lockCountProjectsByUserID.RUnlock()
+	return calls
+}
+
+// CountSCIMGroups calls CountSCIMGroupsFunc.
+func (mock *QuerierMock) CountSCIMGroups(ctx context.Context, arg CountSCIMGroupsParams) (int64, error) {
+	if mock.CountSCIMGroupsFunc == nil {
+		panic("QuerierMock.CountSCIMGroupsFunc: method is nil but Querier.CountSCIMGroups was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg CountSCIMGroupsParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCountSCIMGroups.Lock()
+	mock.calls.CountSCIMGroups = append(mock.calls.CountSCIMGroups, callInfo)
+	mock.lockCountSCIMGroups.Unlock()
+	return mock.CountSCIMGroupsFunc(ctx, arg)
+}
+
+// CountSCIMGroupsCalls gets all the calls that were made to CountSCIMGroups.
+// Check the length with:
+//
+//	len(mockedQuerier.CountSCIMGroupsCalls())
+func (mock *QuerierMock) CountSCIMGroupsCalls() []struct {
+	Ctx context.Context
+	Arg CountSCIMGroupsParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg CountSCIMGroupsParams
+	}
+	mock.lockCountSCIMGroups.RLock()
+	calls = mock.calls.CountSCIMGroups
+	mock.lockCountSCIMGroups.RUnlock()
+	return calls
+}
+
+// CountSCIMUsers calls CountSCIMUsersFunc.
+func (mock *QuerierMock) CountSCIMUsers(ctx context.Context, arg CountSCIMUsersParams) (int64, error) {
+	if mock.CountSCIMUsersFunc == nil {
+		panic("QuerierMock.CountSCIMUsersFunc: method is nil but Querier.CountSCIMUsers was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg CountSCIMUsersParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCountSCIMUsers.Lock()
+	mock.calls.CountSCIMUsers = append(mock.calls.CountSCIMUsers, callInfo)
+	mock.lockCountSCIMUsers.Unlock()
+	return mock.CountSCIMUsersFunc(ctx, arg)
+}
+
+// CountSCIMUsersCalls gets all the calls that were made to CountSCIMUsers.
+// Check the length with:
+//
+//	len(mockedQuerier.CountSCIMUsersCalls())
+func (mock *QuerierMock) CountSCIMUsersCalls() []struct {
+	Ctx context.Context
+	Arg CountSCIMUsersParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg CountSCIMUsersParams
+	}
+	mock.lockCountSCIMUsers.RLock()
+	calls = mock.calls.CountSCIMUsers
+	mock.lockCountSCIMUsers.RUnlock()
+	return calls
+}
+
+// CountUnreadNotifications calls CountUnreadNotificationsFunc.
+func (mock *QuerierMock) CountUnreadNotifications(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	if mock.CountUnreadNotificationsFunc == nil {
+		panic("QuerierMock.CountUnreadNotificationsFunc: method is nil but Querier.CountUnreadNotifications was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockCountUnreadNotifications.Lock()
+	mock.calls.CountUnreadNotifications = append(mock.calls.CountUnreadNotifications, callInfo)
+	mock.lockCountUnreadNotifications.Unlock()
+	return mock.CountUnreadNotificationsFunc(ctx, userID)
+}
+
+// CountUnreadNotificationsCalls gets all the calls that were made to CountUnreadNotifications.
+// Check the length with:
+//
+//	len(mockedQuerier.CountUnreadNotificationsCalls())
+func (mock *QuerierMock) CountUnreadNotificationsCalls() []struct {
+	Ctx    context.Context
+	UserID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}
+	mock.lockCountUnreadNotifications.RLock()
+	calls = mock.calls.CountUnreadNotifications
+	mock.lockCountUnreadNotifications.RUnlock()
+	return calls
+}
+
+// CountUsers calls CountUsersFunc.
+func (mock *QuerierMock) CountUsers(ctx context.Context) (int64, error) {
+	if mock.CountUsersFunc == nil {
+		panic("QuerierMock.CountUsersFunc: method is nil but Querier.CountUsers was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockCountUsers.Lock()
+	mock.calls.CountUsers = append(mock.calls.CountUsers, callInfo)
+	mock.lockCountUsers.Unlock()
+	return mock.CountUsersFunc(ctx)
+}
+
+// CountUsersCalls gets all the calls that were made to CountUsers.
+// Check the length with:
+//
+//	len(mockedQuerier.CountUsersCalls())
+func (mock *QuerierMock) CountUsersCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockCountUsers.RLock()
+	calls = mock.calls.CountUsers
+	mock.lockCountUsers.RUnlock()
+	return calls
+}
+
+// CreateAPIKey calls CreateAPIKeyFunc.
+func (mock *QuerierMock) CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (*ApiKey, error) {
+	if mock.CreateAPIKeyFunc == nil {
+		panic("QuerierMock.CreateAPIKeyFunc: method is nil but Querier.CreateAPIKey was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg CreateAPIKeyParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCreateAPIKey.Lock()
+	mock.calls.CreateAPIKey = append(mock.calls.CreateAPIKey, callInfo)
+	mock.lockCreateAPIKey.Unlock()
+	return mock.CreateAPIKeyFunc(ctx, arg)
+}
+
+// CreateAPIKeyCalls gets all the calls that were made to CreateAPIKey.
+// Check the length with:
+//
+//	len(mockedQuerier.CreateAPIKeyCalls())
+func (mock *QuerierMock) CreateAPIKeyCalls() []struct {
+	Ctx context.Context
+	Arg CreateAPIKeyParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg CreateAPIKeyParams
+	}
+	mock.lockCreateAPIKey.RLock()
+	calls = mock.calls.CreateAPIKey
+	mock.lockCreateAPIKey.RUnlock()
+	return calls
+}
+
+// CreateCatalog calls CreateCatalogFunc.
+func (mock *QuerierMock) CreateCatalog(ctx context.Context, arg CreateCatalogParams) (*Catalog, error) {
+	if mock.CreateCatalogFunc == nil {
+		panic("QuerierMock.CreateCatalogFunc: method is nil but Querier.CreateCatalog was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg CreateCatalogParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCreateCatalog.Lock()
+	mock.calls.CreateCatalog = append(mock.calls.CreateCatalog, callInfo)
+	mock.lockCreateCatalog.Unlock()
+	return mock.CreateCatalogFunc(ctx, arg)
+}
+
+// CreateCatalogCalls gets all the calls that were made to CreateCatalog.
+// Check the length with:
+//
+//	len(mockedQuerier.CreateCatalogCalls())
+func (mock *QuerierMock) CreateCatalogCalls() []struct {
+	Ctx context.Context
+	Arg CreateCatalogParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg CreateCatalogParams
+	}
+	mock.lockCreateCatalog.RLock()
+	calls = mock.calls.CreateCatalog
+	mock.lockCreateCatalog.RUnlock()
+	return calls
+}
+
+// CreateExportCredit calls CreateExportCreditFunc.
+func (mock *QuerierMock) CreateExportCredit(ctx context.Context, arg CreateExportCreditParams) (int64, error) {
+	if mock.CreateExportCreditFunc == nil {
+		panic("QuerierMock.CreateExportCreditFunc: method is nil but Querier.CreateExportCredit was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg CreateExportCreditParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCreateExportCredit.Lock()
+	mock.calls.CreateExportCredit = append(mock.calls.CreateExportCredit, callInfo)
+	mock.lockCreateExportCredit.Unlock()
+	return mock.CreateExportCreditFunc(ctx, arg)
+}
+
+// CreateExportCreditCalls gets all the calls that were made to CreateExportCredit.
+// Check the length with:
+//
+//	len(mockedQuerier.CreateExportCreditCalls())
+func (mock *QuerierMock) CreateExportCreditCalls() []struct {
+	Ctx context.Context
+	Arg CreateExportCreditParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg CreateExportCreditParams
+	}
+	mock.lockCreateExportCredit.RLock()
+	calls = mock.calls.CreateExportCredit
+	mock.lockCreateExportCredit.RUnlock()
+	return calls
+}
+
+// CreateImage calls CreateImageFunc.
+func (mock *QuerierMock) CreateImage(ctx context.Context, arg CreateImageParams) (*CreateImageRow, error) {
+	if mock.CreateImageFunc == nil {
+		panic("QuerierMock.CreateImageFunc: method is nil but Querier.CreateImage was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg CreateImageParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCreateImage.Lock()
+	mock.calls.CreateImage = append(mock.calls.CreateImage, callInfo)
+	mock.lockCreateImage.Unlock()
+	return mock.CreateImageFunc(ctx, arg)
+}
+
+// CreateImageCalls gets all the calls that were made to CreateImage.
+// Check the length with:
+//
+//	len(mockedQuerier.CreateImageCalls())
+func (mock *QuerierMock) CreateImageCalls() []struct {
+	Ctx context.Context
+	Arg CreateImageParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg CreateImageParams
+	}
+	mock.lockCreateImage.RLock()
+	calls = mock.calls.CreateImage
+	mock.lockCreateImage.RUnlock()
+	return calls
+}
+
+// CreateImageAnnotations calls CreateImageAnnotationsFunc.
+func (mock *QuerierMock) CreateImageAnnotations(ctx context.Context, arg CreateImageAnnotationsParams) error {
+	if mock.CreateImageAnnotationsFunc == nil {
+		panic("QuerierMock.CreateImageAnnotationsFunc: method is nil but Querier.CreateImageAnnotations was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg CreateImageAnnotationsParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCreateImageAnnotations.Lock()
+	mock.calls.CreateImageAnnotations = append(mock.calls.CreateImageAnnotations, callInfo)
+	mock.lockCreateImageAnnotations.Unlock()
+	return mock.CreateImageAnnotationsFunc(ctx, arg)
+}
+
+// CreateImageAnnotationsCalls gets all the calls that were made to CreateImageAnnotations.
+// Check the length with:
+//
+//	len(mockedQuerier.CreateImageAnnotationsCalls())
+func (mock *QuerierMock) CreateImageAnnotationsCalls() []struct {
+	Ctx context.Context
+	Arg CreateImageAnnotationsParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg CreateImageAnnotationsParams
+	}
+	mock.lockCreateImageAnnotations.RLock()
+	calls = mock.calls.CreateImageAnnotations
+	mock.lockCreateImageAnnotations.RUnlock()
+	return calls
+}
+
+// CreateImageBrackets calls CreateImageBracketsFunc.
+func (mock *QuerierMock) CreateImageBrackets(ctx context.Context, arg CreateImageBracketsParams) error {
+	if mock.CreateImageBracketsFunc == nil {
+		panic("QuerierMock.CreateImageBracketsFunc: method is nil but Querier.CreateImageBrackets was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg CreateImageBracketsParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCreateImageBrackets.Lock()
+	mock.calls.CreateImageBrackets = append(mock.calls.CreateImageBrackets, callInfo)
+	mock.lockCreateImageBrackets.Unlock()
+	return mock.CreateImageBracketsFunc(ctx, arg)
+}
+
+// CreateImageBracketsCalls gets all the calls that were made to CreateImageBrackets.
+// Check the length with:
+//
+//	len(mockedQuerier.CreateImageBracketsCalls())
+func (mock *QuerierMock) CreateImageBracketsCalls() []struct {
+	Ctx context.Context
+	Arg CreateImageBracketsParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg CreateImageBracketsParams
+	}
+	mock.lockCreateImageBrackets.RLock()
+	calls = mock.calls.CreateImageBrackets
+	mock.lockCreateImageBrackets.RUnlock()
+	return calls
+}
+
+// CreateImageEdit calls CreateImageEditFunc.
+func (mock *QuerierMock) CreateImageEdit(ctx context.Context, arg CreateImageEditParams) (*ImageEdit, error) {
+	if mock.CreateImageEditFunc == nil {
+		panic("QuerierMock.CreateImageEditFunc: method is nil but Querier.CreateImageEdit was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg CreateImageEditParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCreateImageEdit.Lock()
+	mock.calls.CreateImageEdit = append(mock.calls.CreateImageEdit, callInfo)
+	mock.lockCreateImageEdit.Unlock()
+	return mock.CreateImageEditFunc(ctx, arg)
+}
+
+// CreateImageEditCalls gets all the calls that were made to CreateImageEdit.
+// Check the length with:
+//
+//	len(mockedQuerier.CreateImageEditCalls())
+func (mock *QuerierMock) CreateImageEditCalls() []struct {
+	Ctx context.Context
+	Arg CreateImageEditParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg CreateImageEditParams
+	}
+	mock.lockCreateImageEdit.RLock()
+	calls = mock.calls.CreateImageEdit
+	mock.lockCreateImageEdit.RUnlock()
+	return calls
+}
+
+// CreateImages calls CreateImagesFunc.
+func (mock *QuerierMock) CreateImages(ctx context.Context, arg []CreateImagesParams) (int64, error) {
+	if mock.CreateImagesFunc == nil {
+		panic("QuerierMock.CreateImagesFunc: method is nil but Querier.CreateImages was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg []CreateImagesParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCreateImages.Lock()
+	mock.calls.CreateImages = append(mock.calls.CreateImages, callInfo)
+	mock.lockCreateImages.Unlock()
+	return mock.CreateImagesFunc(ctx, arg)
+}
+
+// CreateImagesCalls gets all the calls that were made to CreateImages.
+// Check the length with:
+//
+//	len(mockedQuerier.CreateImagesCalls())
+func (mock *QuerierMock) CreateImagesCalls() []struct {
+	Ctx context.Context
+	Arg []CreateImagesParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg []CreateImagesParams
+	}
+	mock.lockCreateImages.RLock()
+	calls = mock.calls.CreateImages
+	mock.lockCreateImages.RUnlock()
+	return calls
+}
+
+// CreateJob calls CreateJobFunc.
+func (mock *QuerierMock) CreateJob(ctx context.Context, arg CreateJobParams) (*Job, error) {
+	if mock.CreateJobFunc == nil {
+		panic("QuerierMock.CreateJobFunc: method is nil but Querier.CreateJob was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg CreateJobParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCreateJob.Lock()
+	mock.calls.CreateJob = append(mock.calls.CreateJob, callInfo)
+	mock.lockCreateJob.Unlock()
+	return mock.CreateJobFunc(ctx, arg)
+}
+
+// CreateJobCalls gets all the calls that were made to CreateJob.
+// Check the length with:
+//
+//	len(mockedQuerier.CreateJobCalls())
+func (mock *QuerierMock) CreateJobCalls() []struct {
+	Ctx context.Context
+	Arg CreateJobParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg CreateJobParams
+	}
+	mock.lockCreateJob.RLock()
+	calls = mock.calls.CreateJob
+	mock.lockCreateJob.RUnlock()
+	return calls
+}
+
+// CreateJobs calls CreateJobsFunc.
+func (mock *QuerierMock) CreateJobs(ctx context.Context, arg []CreateJobsParams) (int64, error) {
+	if mock.CreateJobsFunc == nil {
+		panic("QuerierMock.CreateJobsFunc: method is nil but Querier.CreateJobs was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg []CreateJobsParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCreateJobs.Lock()
+	mock.calls.CreateJobs = append(mock.calls.CreateJobs, callInfo)
+	mock.lockCreateJobs.Unlock()
+	return mock.CreateJobsFunc(ctx, arg)
+}
+
+// CreateJobsCalls gets all the calls that were made to CreateJobs.
+// Check the length with:
+//
+//	len(mockedQuerier.CreateJobsCalls())
+func (mock *QuerierMock) CreateJobsCalls() []struct {
+	Ctx context.Context
+	Arg []CreateJobsParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg []CreateJobsParams
+	}
+	mock.lockCreateJobs.RLock()
+	calls = mock.calls.CreateJobs
+	mock.lockCreateJobs.RUnlock()
+	return calls
+}
+
+// CreateNotification calls CreateNotificationFunc.
+func (mock *QuerierMock) CreateNotification(ctx context.Context, arg CreateNotificationParams) (*Notification, error) {
+	if mock.CreateNotificationFunc == nil {
+		panic("QuerierMock.CreateNotificationFunc: method is nil but Querier.CreateNotification was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg CreateNotificationParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCreateNotification.Lock()
+	mock.calls.CreateNotification = append(mock.calls.CreateNotification, callInfo)
+	mock.lockCreateNotification.Unlock()
+	return mock.CreateNotificationFunc(ctx, arg)
+}
+
+// CreateNotificationCalls gets all the calls that were made to CreateNotification.
+// Check the length with:
+//
+//	len(mockedQuerier.CreateNotificationCalls())
+func (mock *QuerierMock) CreateNotificationCalls() []struct {
+	Ctx context.Context
+	Arg CreateNotificationParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg CreateNotificationParams
+	}
+	mock.lockCreateNotification.RLock()
+	calls = mock.calls.CreateNotification
+	mock.lockCreateNotification.RUnlock()
+	return calls
+}
+
+// CreateOrganization calls CreateOrganizationFunc.
+func (mock *QuerierMock) CreateOrganization(ctx context.Context, arg CreateOrganizationParams) (*CreateOrganizationRow, error) {
+	if mock.CreateOrganizationFunc == nil {
+		panic("QuerierMock.CreateOrganizationFunc: method is nil but Querier.CreateOrganization was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg CreateOrganizationParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCreateOrganization.Lock()
+	mock.calls.CreateOrganization = append(mock.calls.CreateOrganization, callInfo)
+	mock.lockCreateOrganization.Unlock()
+	return mock.CreateOrganizationFunc(ctx, arg)
+}
+
+// CreateOrganizationCalls gets all the calls that were made to CreateOrganization.
+// Check the length with:
+//
+//	len(mockedQuerier.CreateOrganizationCalls())
+func (mock *QuerierMock) CreateOrganizationCalls() []struct {
+	Ctx context.Context
+	Arg CreateOrganizationParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg CreateOrganizationParams
+	}
+	mock.lockCreateOrganization.RLock()
+	calls = mock.calls.CreateOrganization
+	mock.lockCreateOrganization.RUnlock()
+	return calls
+}
+
+// CreateOrganizationBreakGlass calls CreateOrganizationBreakGlassFunc.
+func (mock *QuerierMock) CreateOrganizationBreakGlass(ctx context.Context, arg CreateOrganizationBreakGlassParams) (*OrganizationBreakGlass, error) {
+	if mock.CreateOrganizationBreakGlassFunc == nil {
+		panic("QuerierMock.CreateOrganizationBreakGlassFunc: method is nil but Querier.CreateOrganizationBreakGlass was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg CreateOrganizationBreakGlassParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCreateOrganizationBreakGlass.Lock()
+	mock.calls.CreateOrganizationBreakGlass = append(mock.calls.CreateOrganizationBreakGlass, callInfo)
+	mock.lockCreateOrganizationBreakGlass.Unlock()
+	return mock.CreateOrganizationBreakGlassFunc(ctx, arg)
+}
+
+// CreateOrganizationBreakGlassCalls gets all the calls that were made to CreateOrganizationBreakGlass.
+// Check the length with:
+//
+//	len(mockedQuerier.CreateOrganizationBreakGlassCalls())
+func (mock *QuerierMock) CreateOrganizationBreakGlassCalls() []struct {
+	Ctx context.Context
+	Arg CreateOrganizationBreakGlassParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg CreateOrganizationBreakGlassParams
+	}
+	mock.lockCreateOrganizationBreakGlass.RLock()
+	calls = mock.calls.CreateOrganizationBreakGlass
+	mock.lockCreateOrganizationBreakGlass.RUnlock()
+	return calls
+}
+
+// CreatePreset calls CreatePresetFunc.
+func (mock *QuerierMock) CreatePreset(ctx context.Context, arg CreatePresetParams) (*Preset, error) {
+	if mock.CreatePresetFunc == nil {
+		panic("QuerierMock.CreatePresetFunc: method is nil but Querier.CreatePreset was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg CreatePresetParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCreatePreset.Lock()
+	mock.calls.CreatePreset = append(mock.calls.CreatePreset, callInfo)
+	mock.lockCreatePreset.Unlock()
+	return mock.CreatePresetFunc(ctx, arg)
+}
+
+// CreatePresetCalls gets all the calls that were made to CreatePreset.
+// Check the length with:
+//
+//	len(mockedQuerier.CreatePresetCalls())
+func (mock *QuerierMock) CreatePresetCalls() []struct {
+	Ctx context.Context
+	Arg CreatePresetParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg CreatePresetParams
+	}
+	mock.lockCreatePreset.RLock()
+	calls = mock.calls.CreatePreset
+	mock.lockCreatePreset.RUnlock()
+	return calls
+}
+
+// CreateProcessedEvent calls CreateProcessedEventFunc.
+func (mock *QuerierMock) CreateProcessedEvent(ctx context.Context, arg CreateProcessedEventParams) (*ProcessedEvent, error) {
+	if mock.CreateProcessedEventFunc == nil {
+		panic("QuerierMock.CreateProcessedEventFunc: method is nil but Querier.CreateProcessedEvent was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg CreateProcessedEventParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCreateProcessedEvent.Lock()
+	mock.calls.CreateProcessedEvent = append(mock.calls.CreateProcessedEvent, callInfo)
+	mock.lockCreateProcessedEvent.Unlock()
+	return mock.CreateProcessedEventFunc(ctx, arg)
+}
+
+// CreateProcessedEventCalls gets all the calls that were made to CreateProcessedEvent.
+// Check the length with:
+//
+//	len(mockedQuerier.CreateProcessedEventCalls())
+func (mock *QuerierMock) CreateProcessedEventCalls() []struct {
+	Ctx context.Context
+	Arg CreateProcessedEventParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg CreateProcessedEventParams
+	}
+	mock.lockCreateProcessedEvent.RLock()
+	calls = mock.calls.CreateProcessedEvent
+	mock.lockCreateProcessedEvent.RUnlock()
+	return calls
+}
+
+// CreateProject calls CreateProjectFunc.
+func (mock *QuerierMock) CreateProject(ctx context.Context, arg CreateProjectParams) (*CreateProjectRow, error) {
+	if mock.CreateProjectFunc == nil {
+		panic("QuerierMock.CreateProjectFunc: method is nil but Querier.CreateProject was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg CreateProjectParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCreateProject.Lock()
+	mock.calls.CreateProject = append(mock.calls.CreateProject, callInfo)
+	mock.lockCreateProject.Unlock()
+	return mock.CreateProjectFunc(ctx, arg)
+}
+
+// CreateProjectCalls gets all the calls that were made to CreateProject.
+// Check the length with:
+//
+//	len(mockedQuerier.CreateProjectCalls())
+func (mock *QuerierMock) CreateProjectCalls() []struct {
+	Ctx context.Context
+	Arg CreateProjectParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg CreateProjectParams
+	}
+	mock.lockCreateProject.RLock()
+	calls = mock.calls.CreateProject
+	mock.lockCreateProject.RUnlock()
+	return calls
+}
+
+// CreateProjectExport calls CreateProjectExportFunc.
+func (mock *QuerierMock) CreateProjectExport(ctx context.Context, arg CreateProjectExportParams) (*ProjectExport, error) {
+	if mock.CreateProjectExportFunc == nil {
+		panic("QuerierMock.CreateProjectExportFunc: method is nil but Querier.CreateProjectExport was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg CreateProjectExportParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCreateProjectExport.Lock()
+	mock.calls.CreateProjectExport = append(mock.calls.CreateProjectExport, callInfo)
+	mock.lockCreateProjectExport.Unlock()
+	return mock.CreateProjectExportFunc(ctx, arg)
+}
+
+// CreateProjectExportCalls gets all the calls that were made to CreateProjectExport.
+// Check the length with:
+//
+//	len(mockedQuerier.CreateProjectExportCalls())
+func (mock *QuerierMock) CreateProjectExportCalls() []struct {
+	Ctx context.Context
+	Arg CreateProjectExportParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg CreateProjectExportParams
+	}
+	mock.lockCreateProjectExport.RLock()
+	calls = mock.calls.CreateProjectExport
+	mock.lockCreateProjectExport.RUnlock()
+	return calls
+}
+
+// CreateProjectInvitation calls CreateProjectInvitationFunc.
+func (mock *QuerierMock) CreateProjectInvitation(ctx context.Context, arg CreateProjectInvitationParams) (*ProjectInvitation, error) {
+	if mock.CreateProjectInvitationFunc == nil {
+		panic("QuerierMock.CreateProjectInvitationFunc: method is nil but Querier.CreateProjectInvitation was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg CreateProjectInvitationParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCreateProjectInvitation.Lock()
+	mock.calls.CreateProjectInvitation = append(mock.calls.CreateProjectInvitation, callInfo)
+	mock.lockCreateProjectInvitation.Unlock()
+	return mock.CreateProjectInvitationFunc(ctx, arg)
+}
+
+// CreateProjectInvitationCalls gets all the calls that were made to CreateProjectInvitation.
+// Check the length with:
+//
+//	len(mockedQuerier.CreateProjectInvitationCalls())
+func (mock *QuerierMock) CreateProjectInvitationCalls() []struct {
+	Ctx context.Context
+	Arg CreateProjectInvitationParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg CreateProjectInvitationParams
+	}
+	mock.lockCreateProjectInvitation.RLock()
+	calls = mock.calls.CreateProjectInvitation
+	mock.lockCreateProjectInvitation.RUnlock()
+	return calls
+}
+
+// CreateProjectRooms calls CreateProjectRoomsFunc.
+func (mock *QuerierMock) CreateProjectRooms(ctx context.Context, arg CreateProjectRoomsParams) error {
+	if mock.CreateProjectRoomsFunc == nil {
+		panic("QuerierMock.CreateProjectRoomsFunc: method is nil but Querier.CreateProjectRooms was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg CreateProjectRoomsParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCreateProjectRooms.Lock()
+	mock.calls.CreateProjectRooms = append(mock.calls.CreateProjectRooms, callInfo)
+	mock.lockCreateProjectRooms.Unlock()
+	return mock.CreateProjectRoomsFunc(ctx, arg)
+}
+
+// CreateProjectRoomsCalls gets all the calls that were made to CreateProjectRooms.
+// Check the length with:
+//
+//	len(mockedQuerier.CreateProjectRoomsCalls())
+func (mock *QuerierMock) CreateProjectRoomsCalls() []struct {
+	Ctx context.Context
+	Arg CreateProjectRoomsParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg CreateProjectRoomsParams
+	}
+	mock.lockCreateProjectRooms.RLock()
+	calls = mock.calls.CreateProjectRooms
+	mock.lockCreateProjectRooms.RUnlock()
+	return calls
+}
+
+// CreateProjectTemplate calls CreateProjectTemplateFunc.
+func (mock *QuerierMock) CreateProjectTemplate(ctx context.Context, arg CreateProjectTemplateParams) (*ProjectTemplate, error) {
+	if mock.CreateProjectTemplateFunc == nil {
+		panic("QuerierMock.CreateProjectTemplateFunc: method is nil but Querier.CreateProjectTemplate was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg CreateProjectTemplateParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCreateProjectTemplate.Lock()
+	mock.calls.CreateProjectTemplate = append(mock.calls.CreateProjectTemplate, callInfo)
+	mock.lockCreateProjectTemplate.Unlock()
+	return mock.CreateProjectTemplateFunc(ctx, arg)
+}
+
+// CreateProjectTemplateCalls gets all the calls that were made to CreateProjectTemplate.
+// Check the length with:
+//
+//	len(mockedQuerier.CreateProjectTemplateCalls())
+func (mock *QuerierMock) CreateProjectTemplateCalls() []struct {
+	Ctx context.Context
+	Arg CreateProjectTemplateParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg CreateProjectTemplateParams
+	}
+	mock.lockCreateProjectTemplate.RLock()
+	calls = mock.calls.CreateProjectTemplate
+	mock.lockCreateProjectTemplate.RUnlock()
+	return calls
+}
+
+// CreateRoomGrouping calls CreateRoomGroupingFunc.
+func (mock *QuerierMock) CreateRoomGrouping(ctx context.Context, projectID pgtype.UUID) (*RoomGrouping, error) {
+	if mock.CreateRoomGroupingFunc == nil {
+		panic("QuerierMock.CreateRoomGroupingFunc: method is nil but Querier.CreateRoomGrouping was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockCreateRoomGrouping.Lock()
+	mock.calls.CreateRoomGrouping = append(mock.calls.CreateRoomGrouping, callInfo)
+	mock.lockCreateRoomGrouping.Unlock()
+	return mock.CreateRoomGroupingFunc(ctx, projectID)
+}
+
+// CreateRoomGroupingCalls gets all the calls that were made to CreateRoomGrouping.
+// Check the length with:
+//
+//	len(mockedQuerier.CreateRoomGroupingCalls())
+func (mock *QuerierMock) CreateRoomGroupingCalls() []struct {
+	Ctx       context.Context
+	ProjectID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}
+	mock.lockCreateRoomGrouping.RLock()
+	calls = mock.calls.CreateRoomGrouping
+	mock.lockCreateRoomGrouping.RUnlock()
+	return calls
+}
+
+// CreateSCIMGroup calls CreateSCIMGroupFunc.
+func (mock *QuerierMock) CreateSCIMGroup(ctx context.Context, arg CreateSCIMGroupParams) (*OrganizationScimGroup, error) {
+	if mock.CreateSCIMGroupFunc == nil {
+		panic("QuerierMock.CreateSCIMGroupFunc: method is nil but Querier.CreateSCIMGroup was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg CreateSCIMGroupParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCreateSCIMGroup.Lock()
+	mock.calls.CreateSCIMGroup = append(mock.calls.CreateSCIMGroup, callInfo)
+	mock.lockCreateSCIMGroup.Unlock()
+	return mock.CreateSCIMGroupFunc(ctx, arg)
+}
+
+// CreateSCIMGroupCalls gets all the calls that were made to CreateSCIMGroup.
+// Check the length with:
+//
+//	len(mockedQuerier.CreateSCIMGroupCalls())
+func (mock *QuerierMock) CreateSCIMGroupCalls() []struct {
+	Ctx context.Context
+	Arg CreateSCIMGroupParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg CreateSCIMGroupParams
+	}
+	mock.lockCreateSCIMGroup.RLock()
+	calls = mock.calls.CreateSCIMGroup
+	mock.lockCreateSCIMGroup.RUnlock()
+	return calls
+}
+
+// CreateSCIMUser calls CreateSCIMUserFunc.
+func (mock *QuerierMock) CreateSCIMUser(ctx context.Context, arg CreateSCIMUserParams) (*OrganizationScimUser, error) {
+	if mock.CreateSCIMUserFunc == nil {
+		panic("QuerierMock.CreateSCIMUserFunc: method is nil but Querier.CreateSCIMUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg CreateSCIMUserParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCreateSCIMUser.Lock()
+	mock.calls.CreateSCIMUser = append(mock.calls.CreateSCIMUser, callInfo)
+	mock.lockCreateSCIMUser.Unlock()
+	return mock.CreateSCIMUserFunc(ctx, arg)
+}
+
+// CreateSCIMUserCalls gets all the calls that were made to CreateSCIMUser.
+// Check the length with:
+//
+//	len(mockedQuerier.CreateSCIMUserCalls())
+func (mock *QuerierMock) CreateSCIMUserCalls() []struct {
+	Ctx context.Context
+	Arg CreateSCIMUserParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg CreateSCIMUserParams
+	}
+	mock.lockCreateSCIMUser.RLock()
+	calls = mock.calls.CreateSCIMUser
+	mock.lockCreateSCIMUser.RUnlock()
+	return calls
+}
+
+// CreateTeamWebhookDelivery calls CreateTeamWebhookDeliveryFunc.
+func (mock *QuerierMock) CreateTeamWebhookDelivery(ctx context.Context, arg CreateTeamWebhookDeliveryParams) (*TeamWebhookDelivery, error) {
+	if mock.CreateTeamWebhookDeliveryFunc == nil {
+		panic("QuerierMock.CreateTeamWebhookDeliveryFunc: method is nil but Querier.CreateTeamWebhookDelivery was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg CreateTeamWebhookDeliveryParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCreateTeamWebhookDelivery.Lock()
+	mock.calls.CreateTeamWebhookDelivery = append(mock.calls.CreateTeamWebhookDelivery, callInfo)
+	mock.lockCreateTeamWebhookDelivery.Unlock()
+	return mock.CreateTeamWebhookDeliveryFunc(ctx, arg)
+}
+
+// CreateTeamWebhookDeliveryCalls gets all the calls that were made to CreateTeamWebhookDelivery.
+// Check the length with:
+//
+//	len(mockedQuerier.CreateTeamWebhookDeliveryCalls())
+func (mock *QuerierMock) CreateTeamWebhookDeliveryCalls() []struct {
+	Ctx context.Context
+	Arg CreateTeamWebhookDeliveryParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg CreateTeamWebhookDeliveryParams
+	}
+	mock.lockCreateTeamWebhookDelivery.RLock()
+	calls = mock.calls.CreateTeamWebhookDelivery
+	mock.lockCreateTeamWebhookDelivery.RUnlock()
+	return calls
+}
+
+// CreateUser calls CreateUserFunc.
+func (mock *QuerierMock) CreateUser(ctx context.Context, arg CreateUserParams) (*CreateUserRow, error) {
+	if mock.CreateUserFunc == nil {
+		panic("QuerierMock.CreateUserFunc: method is nil but Querier.CreateUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg CreateUserParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCreateUser.Lock()
+	mock.calls.CreateUser = append(mock.calls.CreateUser, callInfo)
+	mock.lockCreateUser.Unlock()
+	return mock.CreateUserFunc(ctx, arg)
+}
+
+// CreateUserCalls gets all the calls that were made to CreateUser.
+// Check the length with:
+//
+//	len(mockedQuerier.CreateUserCalls())
+func (mock *QuerierMock) CreateUserCalls() []struct {
+	Ctx context.Context
+	Arg CreateUserParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg CreateUserParams
+	}
+	mock.lockCreateUser.RLock()
+	calls = mock.calls.CreateUser
+	mock.lockCreateUser.RUnlock()
+	return calls
+}
+
+// DeleteAccountWatermark calls DeleteAccountWatermarkFunc.
+func (mock *QuerierMock) DeleteAccountWatermark(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	if mock.DeleteAccountWatermarkFunc == nil {
+		panic("QuerierMock.DeleteAccountWatermarkFunc: method is nil but Querier.DeleteAccountWatermark was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockDeleteAccountWatermark.Lock()
+	mock.calls.DeleteAccountWatermark = append(mock.calls.DeleteAccountWatermark, callInfo)
+	mock.lockDeleteAccountWatermark.Unlock()
+	return mock.DeleteAccountWatermarkFunc(ctx, userID)
+}
+
+// DeleteAccountWatermarkCalls gets all the calls that were made to DeleteAccountWatermark.
+// Check the length with:
+//
+//	len(mockedQuerier.DeleteAccountWatermarkCalls())
+func (mock *QuerierMock) DeleteAccountWatermarkCalls() []struct {
+	Ctx    context.Context
+	UserID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}
+	mock.lockDeleteAccountWatermark.RLock()
+	calls = mock.calls.DeleteAccountWatermark
+	mock.lockDeleteAccountWatermark.RUnlock()
+	return calls
+}
+
+// DeleteCatalog calls DeleteCatalogFunc.
+func (mock *QuerierMock) DeleteCatalog(ctx context.Context, id pgtype.UUID) (int64, error) {
+	if mock.DeleteCatalogFunc == nil {
+		panic("QuerierMock.DeleteCatalogFunc: method is nil but Querier.DeleteCatalog was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDeleteCatalog.Lock()
+	mock.calls.DeleteCatalog = append(mock.calls.DeleteCatalog, callInfo)
+	mock.lockDeleteCatalog.Unlock()
+	return mock.DeleteCatalogFunc(ctx, id)
+}
+
+// DeleteCatalogCalls gets all the calls that were made to DeleteCatalog.
+// Check the length with:
+//
+//	len(mockedQuerier.DeleteCatalogCalls())
+func (mock *QuerierMock) DeleteCatalogCalls() []struct {
+	Ctx context.Context
+	ID  pgtype.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}
+	mock.lockDeleteCatalog.RLock()
+	calls = mock.calls.DeleteCatalog
+	mock.lockDeleteCatalog.RUnlock()
+	return calls
+}
+
+// DeleteCustomerBucket calls DeleteCustomerBucketFunc.
+func (mock *QuerierMock) DeleteCustomerBucket(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	if mock.DeleteCustomerBucketFunc == nil {
+		panic("QuerierMock.DeleteCustomerBucketFunc: method is nil but Querier.DeleteCustomerBucket was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockDeleteCustomerBucket.Lock()
+	mock.calls.DeleteCustomerBucket = append(mock.calls.DeleteCustomerBucket, callInfo)
+	mock.lockDeleteCustomerBucket.Unlock()
+	return mock.DeleteCustomerBucketFunc(ctx, userID)
+}
+
+// DeleteCustomerBucketCalls gets all the calls that were made to DeleteCustomerBucket.
+// Check the length with:
+//
+//	len(mockedQuerier.DeleteCustomerBucketCalls())
+func (mock *QuerierMock) DeleteCustomerBucketCalls() []struct {
+	Ctx    context.Context
+	UserID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}
+	mock.lockDeleteCustomerBucket.RLock()
+	calls = mock.calls.DeleteCustomerBucket
+	mock.lockDeleteCustomerBucket.RUnlock()
+	return calls
+}
+
+// DeleteImage calls DeleteImageFunc.
+func (mock *QuerierMock) DeleteImage(ctx context.Context, id pgtype.UUID) error {
+	if mock.DeleteImageFunc == nil {
+		panic("QuerierMock.DeleteImageFunc: method is nil but Querier.DeleteImage was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDeleteImage.Lock()
+	mock.calls.DeleteImage = append(mock.calls.DeleteImage, callInfo)
+	mock.lockDeleteImage.Unlock()
+	return mock.DeleteImageFunc(ctx, id)
+}
+
+// DeleteImageCalls gets all the calls that were made to DeleteImage.
+// Check the length with:
+//
+//	len(mockedQuerier.DeleteImageCalls())
+func (mock *QuerierMock) DeleteImageCalls() []struct {
+	Ctx context.Context
+	ID  pgtype.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}
+	mock.lockDeleteImage.RLock()
+	calls = mock.calls.DeleteImage
+	mock.lockDeleteImage.RUnlock()
+	return calls
+}
+
+// DeleteImagesByProjectID calls DeleteImagesByProjectIDFunc.
+func (mock *QuerierMock) DeleteImagesByProjectID(ctx context.Context, projectID pgtype.UUID) error {
+	if mock.DeleteImagesByProjectIDFunc == nil {
+		panic("QuerierMock.DeleteImagesByProjectIDFunc: method is nil but Querier.DeleteImagesByProjectID was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockDeleteImagesByProjectID.Lock()
+	mock.calls.DeleteImagesByProjectID = append(mock.calls.DeleteImagesByProjectID, callInfo)
+	mock.lockDeleteImagesByProjectID.Unlock()
+	return mock.DeleteImagesByProjectIDFunc(ctx, projectID)
+}
+
+// DeleteImagesByProjectIDCalls gets all the calls that were made to DeleteImagesByProjectID.
+// Check the length with:
+//
+//	len(mockedQuerier.DeleteImagesByProjectIDCalls())
+func (mock *QuerierMock) DeleteImagesByProjectIDCalls() []struct {
+	Ctx       context.Context
+	ProjectID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}
+	mock.lockDeleteImagesByProjectID.RLock()
+	calls = mock.calls.DeleteImagesByProjectID
+	mock.lockDeleteImagesByProjectID.RUnlock()
+	return calls
+}
+
+// DeleteJob calls DeleteJobFunc.
+func (mock *QuerierMock) DeleteJob(ctx context.Context, id pgtype.UUID) error {
+	if mock.DeleteJobFunc == nil {
+		panic("QuerierMock.DeleteJobFunc: method is nil but Querier.DeleteJob was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDeleteJob.Lock()
+	mock.calls.DeleteJob = append(mock.calls.DeleteJob, callInfo)
+	mock.lockDeleteJob.Unlock()
+	return mock.DeleteJobFunc(ctx, id)
+}
+
+// DeleteJobCalls gets all the calls that were made to DeleteJob.
+// Check the length with:
+//
+//	len(mockedQuerier.DeleteJobCalls())
+func (mock *QuerierMock) DeleteJobCalls() []struct {
+	Ctx context.Context
+	ID  pgtype.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}
+	mock.lockDeleteJob.RLock()
+	calls = mock.calls.DeleteJob
+	mock.lockDeleteJob.RUnlock()
+	return calls
+}
+
+// DeleteJobsByImageID calls DeleteJobsByImageIDFunc.
+func (mock *QuerierMock) DeleteJobsByImageID(ctx context.Context, imageID pgtype.UUID) error {
+	if mock.DeleteJobsByImageIDFunc == nil {
+		panic("QuerierMock.DeleteJobsByImageIDFunc: method is nil but Querier.DeleteJobsByImageID was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ImageID pgtype.UUID
+	}{
+		Ctx:     ctx,
+		ImageID: imageID,
+	}
+	mock.lockDeleteJobsByImageID.Lock()
+	mock.calls.DeleteJobsByImageID = append(mock.calls.DeleteJobsByImageID, callInfo)
+	mock.lockDeleteJobsByImageID.Unlock()
+	return mock.DeleteJobsByImageIDFunc(ctx, imageID)
+}
+
+// DeleteJobsByImageIDCalls gets all the calls that were made to DeleteJobsByImageID.
+// Check the length with:
+//
+//	len(mockedQuerier.DeleteJobsByImageIDCalls())
+func (mock *QuerierMock) DeleteJobsByImageIDCalls() []struct {
+	Ctx     context.Context
+	ImageID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ImageID pgtype.UUID
+	}
+	mock.lockDeleteJobsByImageID.RLock()
+	calls = mock.calls.DeleteJobsByImageID
+	mock.lockDeleteJobsByImageID.RUnlock()
+	return calls
+}
+
+// DeleteOldProcessedEvents calls DeleteOldProcessedEventsFunc.
+func (mock *QuerierMock) DeleteOldProcessedEvents(ctx context.Context, receivedAt pgtype.Timestamptz) error {
+	if mock.DeleteOldProcessedEventsFunc == nil {
+		panic("QuerierMock.DeleteOldProcessedEventsFunc: method is nil but Querier.DeleteOldProcessedEvents was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		ReceivedAt pgtype.Timestamptz
+	}{
+		Ctx:        ctx,
+		ReceivedAt: receivedAt,
+	}
+	mock.lockDeleteOldProcessedEvents.Lock()
+	mock.calls.DeleteOldProcessedEvents = append(mock.calls.DeleteOldProcessedEvents, callInfo)
+	mock.lockDeleteOldProcessedEvents.Unlock()
+	return mock.DeleteOldProcessedEventsFunc(ctx, receivedAt)
+}
+
+// DeleteOldProcessedEventsCalls gets all the calls that were made to DeleteOldProcessedEvents.
+// Check the length with:
+//
+//	len(mockedQuerier.DeleteOldProcessedEventsCalls())
+func (mock *QuerierMock) DeleteOldProcessedEventsCalls() []struct {
+	Ctx        context.Context
+	ReceivedAt pgtype.Timestamptz
+} {
+	var calls []struct {
+		Ctx        context.Context
+		ReceivedAt pgtype.Timestamptz
+	}
+	mock.lockDeleteOldProcessedEvents.RLock()
+	calls = mock.calls.DeleteOldProcessedEvents
+	mock.lockDeleteOldProcessedEvents.RUnlock()
+	return calls
+}
+
+// DeleteOrganizationSCIMMember calls DeleteOrganizationSCIMMemberFunc.
+func (mock *QuerierMock) DeleteOrganizationSCIMMember(ctx context.Context, arg DeleteOrganizationSCIMMemberParams) error {
+	if mock.DeleteOrganizationSCIMMemberFunc == nil {
+		panic("QuerierMock.DeleteOrganizationSCIMMemberFunc: method is nil but Querier.DeleteOrganizationSCIMMember was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg DeleteOrganizationSCIMMemberParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockDeleteOrganizationSCIMMember.Lock()
+	mock.calls.DeleteOrganizationSCIMMember = append(mock.calls.DeleteOrganizationSCIMMember, callInfo)
+	mock.lockDeleteOrganizationSCIMMember.Unlock()
+	return mock.DeleteOrganizationSCIMMemberFunc(ctx, arg)
+}
+
+// DeleteOrganizationSCIMMemberCalls gets all the calls that were made to DeleteOrganizationSCIMMember.
+// Check the length with:
+//
+//	len(mockedQuerier.DeleteOrganizationSCIMMemberCalls())
+func (mock *QuerierMock) DeleteOrganizationSCIMMemberCalls() []struct {
+	Ctx context.Context
+	Arg DeleteOrganizationSCIMMemberParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg DeleteOrganizationSCIMMemberParams
+	}
+	mock.lockDeleteOrganizationSCIMMember.RLock()
+	calls = mock.calls.DeleteOrganizationSCIMMember
+	mock.lockDeleteOrganizationSCIMMember.RUnlock()
+	return calls
+}
+
+// DeleteOrganizationSCIMToken calls DeleteOrganizationSCIMTokenFunc.
+func (mock *QuerierMock) DeleteOrganizationSCIMToken(ctx context.Context, organizationID pgtype.UUID) (int64, error) {
+	if mock.DeleteOrganizationSCIMTokenFunc == nil {
+		panic("QuerierMock.DeleteOrganizationSCIMTokenFunc: method is nil but Querier.DeleteOrganizationSCIMToken was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		OrganizationID pgtype.UUID
+	}{
+		Ctx:            ctx,
+		OrganizationID: organizationID,
+	}
+	mock.lockDeleteOrganizationSCIMToken.Lock()
+	mock.calls.DeleteOrganizationSCIMToken = append(mock.calls.DeleteOrganizationSCIMToken, callInfo)
+	mock.lockDeleteOrganizationSCIMToken.Unlock()
+	return mock.DeleteOrganizationSCIMTokenFunc(ctx, organizationID)
+}
+
+// DeleteOrganizationSCIMTokenCalls gets all the calls that were made to DeleteOrganizationSCIMToken.
+// Check the length with:
+//
+//	len(mockedQuerier.DeleteOrganizationSCIMTokenCalls())
+func (mock *QuerierMock) DeleteOrganizationSCIMTokenCalls() []struct {
+	Ctx            context.Context
+	OrganizationID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx            context.Context
+		OrganizationID pgtype.UUID
+	}
+	mock.lockDeleteOrganizationSCIMToken.RLock()
+	calls = mock.calls.DeleteOrganizationSCIMToken
+	mock.lockDeleteOrganizationSCIMToken.RUnlock()
+	return calls
+}
+
+// DeleteOrganizationSSO calls DeleteOrganizationSSOFunc.
+func (mock *QuerierMock) DeleteOrganizationSSO(ctx context.Context, organizationID pgtype.UUID) (int64, error) {
+	if mock.DeleteOrganizationSSOFunc == nil {
+		panic("QuerierMock.DeleteOrganizationSSOFunc: method is nil but Querier.DeleteOrganizationSSO was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		OrganizationID pgtype.UUID
+	}{
+		Ctx:            ctx,
+		OrganizationID: organizationID,
+	}
+	mock.lockDeleteOrganizationSSO.Lock()
+	mock.calls.DeleteOrganizationSSO = append(mock.calls.DeleteOrganizationSSO, callInfo)
+	mock.lockDeleteOrganizationSSO.Unlock()
+	return mock.DeleteOrganizationSSOFunc(ctx, organizationID)
+}
+
+// DeleteOrganizationSSOCalls gets all the calls that were made to DeleteOrganizationSSO.
+// Check the length with:
+//
+//	len(mockedQuerier.DeleteOrganizationSSOCalls())
+func (mock *QuerierMock) DeleteOrganizationSSOCalls() []struct {
+	Ctx            context.Context
+	OrganizationID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx            context.Context
+		OrganizationID pgtype.UUID
+	}
+	mock.lockDeleteOrganizationSSO.RLock()
+	calls = mock.calls.DeleteOrganizationSSO
+	mock.lockDeleteOrganizationSSO.RUnlock()
+	return calls
+}
+
+// DeletePreset calls DeletePresetFunc.
+func (mock *QuerierMock) DeletePreset(ctx context.Context, arg DeletePresetParams) (int64, error) {
+	if mock.DeletePresetFunc == nil {
+		panic("QuerierMock.DeletePresetFunc: method is nil but Querier.DeletePreset was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg DeletePresetParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockDeletePreset.Lock()
+	mock.calls.DeletePreset = append(mock.calls.DeletePreset, callInfo)
+	mock.lockDeletePreset.Unlock()
+	return mock.DeletePresetFunc(ctx, arg)
+}
+
+// DeletePresetCalls gets all the calls that were made to DeletePreset.
+// Check the length with:
+//
+//	len(mockedQuerier.DeletePresetCalls())
+func (mock *QuerierMock) DeletePresetCalls() []struct {
+	Ctx context.Context
+	Arg DeletePresetParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg DeletePresetParams
+	}
+	mock.lockDeletePreset.RLock()
+	calls = mock.calls.DeletePreset
+	mock.lockDeletePreset.RUnlock()
+	return calls
+}
+
+// DeleteProject calls DeleteProjectFunc.
+func (mock *QuerierMock) DeleteProject(ctx context.Context, id pgtype.UUID) (int64, error) {
+	if mock.DeleteProjectFunc == nil {
+		panic("QuerierMock.DeleteProjectFunc: method is nil but Querier.DeleteProject was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDeleteProject.Lock()
+	mock.calls.DeleteProject = append(mock.calls.DeleteProject, callInfo)
+	mock.lockDeleteProject.Unlock()
+	return mock.DeleteProjectFunc(ctx, id)
+}
+
+// DeleteProjectCalls gets all the calls that were made to DeleteProject.
+// Check the length with:
+//
+//	len(mockedQuerier.DeleteProjectCalls())
+func (mock *QuerierMock) DeleteProjectCalls() []struct {
+	Ctx context.Context
+	ID  pgtype.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}
+	mock.lockDeleteProject.RLock()
+	calls = mock.calls.DeleteProject
+	mock.lockDeleteProject.RUnlock()
+	return calls
+}
+
+// DeleteProjectByUserID calls DeleteProjectByUserIDFunc.
+func (mock *QuerierMock) DeleteProjectByUserID(ctx context.Context, arg DeleteProjectByUserIDParams) (int64, error) {
+	if mock.DeleteProjectByUserIDFunc == nil {
+		panic("QuerierMock.DeleteProjectByUserIDFunc: method is nil but Querier.DeleteProjectByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg DeleteProjectByUserIDParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockDeleteProjectByUserID.Lock()
+	mock.calls.DeleteProjectByUserID = append(mock.calls.DeleteProjectByUserID, callInfo)
+	mock.lockDeleteProjectByUserID.Unlock()
+	return mock.DeleteProjectByUserIDFunc(ctx, arg)
+}
+
+// DeleteProjectByUserIDCalls gets all the calls that were made to DeleteProjectByUserID.
+// Check the length with:
+//
+//	len(mockedQuerier.DeleteProjectByUserIDCalls())
+func (mock *QuerierMock) DeleteProjectByUserIDCalls() []struct {
+	Ctx context.Context
+	Arg DeleteProjectByUserIDParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg DeleteProjectByUserIDParams
+	}
+	mock.lockDeleteProjectByUserID.RLock()
+	calls = mock.calls.DeleteProjectByUserID
+	mock.lockDeleteProjectByUserID.RUnlock()
+	return calls
+}
+
+// DeleteProjectRooms calls DeleteProjectRoomsFunc.
+func (mock *QuerierMock) DeleteProjectRooms(ctx context.Context, projectID pgtype.UUID) error {
+	if mock.DeleteProjectRoomsFunc == nil {
+		panic("QuerierMock.DeleteProjectRoomsFunc: method is nil but Querier.DeleteProjectRooms was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockDeleteProjectRooms.Lock()
+	mock.calls.DeleteProjectRooms = append(mock.calls.DeleteProjectRooms, callInfo)
+	mock.lockDeleteProjectRooms.Unlock()
+	return mock.DeleteProjectRoomsFunc(ctx, projectID)
+}
+
+// DeleteProjectRoomsCalls gets all the calls that were made to DeleteProjectRooms.
+// Check the length with:
+//
+//	len(mockedQuerier.DeleteProjectRoomsCalls())
+func (mock *QuerierMock) DeleteProjectRoomsCalls() []struct {
+	Ctx       context.Context
+	ProjectID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}
+	mock.lockDeleteProjectRooms.RLock()
+	calls = mock.calls.DeleteProjectRooms
+	mock.lockDeleteProjectRooms.RUnlock()
+	return calls
+}
+
+// DeleteProjectTemplate calls DeleteProjectTemplateFunc.
+func (mock *QuerierMock) DeleteProjectTemplate(ctx context.Context, arg DeleteProjectTemplateParams) (int64, error) {
+	if mock.DeleteProjectTemplateFunc == nil {
+		panic("QuerierMock.DeleteProjectTemplateFunc: method is nil but Querier.DeleteProjectTemplate was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg DeleteProjectTemplateParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockDeleteProjectTemplate.Lock()
+	mock.calls.DeleteProjectTemplate = append(mock.calls.DeleteProjectTemplate, callInfo)
+	mock.lockDeleteProjectTemplate.Unlock()
+	return mock.DeleteProjectTemplateFunc(ctx, arg)
+}
+
+// DeleteProjectTemplateCalls gets all the calls that were made to DeleteProjectTemplate.
+// Check the length with:
+//
+//	len(mockedQuerier.DeleteProjectTemplateCalls())
+func (mock *QuerierMock) DeleteProjectTemplateCalls() []struct {
+	Ctx context.Context
+	Arg DeleteProjectTemplateParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg DeleteProjectTemplateParams
+	}
+	mock.lockDeleteProjectTemplate.RLock()
+	calls = mock.calls.DeleteProjectTemplate
+	mock.lockDeleteProjectTemplate.RUnlock()
+	return calls
+}
+
+// DeleteSCIMGroup calls DeleteSCIMGroupFunc.
+func (mock *QuerierMock) DeleteSCIMGroup(ctx context.Context, arg DeleteSCIMGroupParams) (int64, error) {
+	if mock.DeleteSCIMGroupFunc == nil {
+		panic("QuerierMock.DeleteSCIMGroupFunc: method is nil but Querier.DeleteSCIMGroup was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg DeleteSCIMGroupParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockDeleteSCIMGroup.Lock()
+	mock.calls.DeleteSCIMGroup = append(mock.calls.DeleteSCIMGroup, callInfo)
+	mock.lockDeleteSCIMGroup.Unlock()
+	return mock.DeleteSCIMGroupFunc(ctx, arg)
+}
+
+// DeleteSCIMGroupCalls gets all the calls that were made to DeleteSCIMGroup.
+// Check the length with:
+//
+//	len(mockedQuerier.DeleteSCIMGroupCalls())
+func (mock *QuerierMock) DeleteSCIMGroupCalls() []struct {
+	Ctx context.Context
+	Arg DeleteSCIMGroupParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg DeleteSCIMGroupParams
+	}
+	mock.lockDeleteSCIMGroup.RLock()
+	calls = mock.calls.DeleteSCIMGroup
+	mock.lockDeleteSCIMGroup.RUnlock()
+	return calls
+}
+
+// DeleteSCIMUser calls DeleteSCIMUserFunc.
+func (mock *QuerierMock) DeleteSCIMUser(ctx context.Context, arg DeleteSCIMUserParams) (int64, error) {
+	if mock.DeleteSCIMUserFunc == nil {
+		panic("QuerierMock.DeleteSCIMUserFunc: method is nil but Querier.DeleteSCIMUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg DeleteSCIMUserParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockDeleteSCIMUser.Lock()
+	mock.calls.DeleteSCIMUser = append(mock.calls.DeleteSCIMUser, callInfo)
+	mock.lockDeleteSCIMUser.Unlock()
+	return mock.DeleteSCIMUserFunc(ctx, arg)
+}
+
+// DeleteSCIMUserCalls gets all the calls that were made to DeleteSCIMUser.
+// Check the length with:
+//
+//	len(mockedQuerier.DeleteSCIMUserCalls())
+func (mock *QuerierMock) DeleteSCIMUserCalls() []struct {
+	Ctx context.Context
+	Arg DeleteSCIMUserParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg DeleteSCIMUserParams
+	}
+	mock.lockDeleteSCIMUser.RLock()
+	calls = mock.calls.DeleteSCIMUser
+	mock.lockDeleteSCIMUser.RUnlock()
+	return calls
+}
+
+// DeleteShareBranding calls DeleteShareBrandingFunc.
+func (mock *QuerierMock) DeleteShareBranding(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	if mock.DeleteShareBrandingFunc == nil {
+		panic("QuerierMock.DeleteShareBrandingFunc: method is nil but Querier.DeleteShareBranding was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockDeleteShareBranding.Lock()
+	mock.calls.DeleteShareBranding = append(mock.calls.DeleteShareBranding, callInfo)
+	mock.lockDeleteShareBranding.Unlock()
+	return mock.DeleteShareBrandingFunc(ctx, userID)
+}
+
+// DeleteShareBrandingCalls gets all the calls that were made to DeleteShareBranding.
+// Check the length with:
+//
+//	len(mockedQuerier.DeleteShareBrandingCalls())
+func (mock *QuerierMock) DeleteShareBrandingCalls() []struct {
+	Ctx    context.Context
+	UserID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}
+	mock.lockDeleteShareBranding.RLock()
+	calls = mock.calls.DeleteShareBranding
+	mock.lockDeleteShareBranding.RUnlock()
+	return calls
+}
+
+// DeleteSubscriptionByStripeID calls DeleteSubscriptionByStripeIDFunc.
+func (mock *QuerierMock) DeleteSubscriptionByStripeID(ctx context.Context, stripeSubscriptionID string) error {
+	if mock.DeleteSubscriptionByStripeIDFunc == nil {
+		panic("QuerierMock.DeleteSubscriptionByStripeIDFunc: method is nil but Querier.DeleteSubscriptionByStripeID was just called")
+	}
+	callInfo := struct {
+		Ctx                  context.Context
+		StripeSubscriptionID string
+	}{
+		Ctx:                  ctx,
+		StripeSubscriptionID: stripeSubscriptionID,
+	}
+	mock.lockDeleteSubscriptionByStripeID.Lock()
+	mock.calls.DeleteSubscriptionByStripeID = append(mock.calls.DeleteSubscriptionByStripeID, callInfo)
+	mock.lockDeleteSubscriptionByStripeID.Unlock()
+	return mock.DeleteSubscriptionByStripeIDFunc(ctx, stripeSubscriptionID)
+}
+
+// DeleteSubscriptionByStripeIDCalls gets all the calls that were made to DeleteSubscriptionByStripeID.
+// Check the length with:
+//
+//	len(mockedQuerier.DeleteSubscriptionByStripeIDCalls())
+func (mock *QuerierMock) DeleteSubscriptionByStripeIDCalls() []struct {
+	Ctx                  context.Context
+	StripeSubscriptionID string
+} {
+	var calls []struct {
+		Ctx                  context.Context
+		StripeSubscriptionID string
+	}
+	mock.lockDeleteSubscriptionByStripeID.RLock()
+	calls = mock.calls.DeleteSubscriptionByStripeID
+	mock.lockDeleteSubscriptionByStripeID.RUnlock()
+	return calls
+}
+
+// DeleteTeamWebhook calls DeleteTeamWebhookFunc.
+func (mock *QuerierMock) DeleteTeamWebhook(ctx context.Context, arg DeleteTeamWebhookParams) (int64, error) {
+	if mock.DeleteTeamWebhookFunc == nil {
+		panic("QuerierMock.DeleteTeamWebhookFunc: method is nil but Querier.DeleteTeamWebhook was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg DeleteTeamWebhookParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockDeleteTeamWebhook.Lock()
+	mock.calls.DeleteTeamWebhook = append(mock.calls.DeleteTeamWebhook, callInfo)
+	mock.lockDeleteTeamWebhook.Unlock()
+	return mock.DeleteTeamWebhookFunc(ctx, arg)
+}
+
+// DeleteTeamWebhookCalls gets all the calls that were made to DeleteTeamWebhook.
+// Check the length with:
+//
+//	len(mockedQuerier.DeleteTeamWebhookCalls())
+func (mock *QuerierMock) DeleteTeamWebhookCalls() []struct {
+	Ctx context.Context
+	Arg DeleteTeamWebhookParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg DeleteTeamWebhookParams
+	}
+	mock.lockDeleteTeamWebhook.RLock()
+	calls = mock.calls.DeleteTeamWebhook
+	mock.lockDeleteTeamWebhook.RUnlock()
+	return calls
+}
+
+// DeleteUser calls DeleteUserFunc.
+func (mock *QuerierMock) DeleteUser(ctx context.Context, id pgtype.UUID) error {
+	if mock.DeleteUserFunc == nil {
+		panic("QuerierMock.DeleteUserFunc: method is nil but Querier.DeleteUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDeleteUser.Lock()
+	mock.calls.DeleteUser = append(mock.calls.DeleteUser, callInfo)
+	mock.lockDeleteUser.Unlock()
+	return mock.DeleteUserFunc(ctx, id)
+}
+
+// DeleteUserCalls gets all the calls that were made to DeleteUser.
+// Check the length with:
+//
+//	len(mockedQuerier.DeleteUserCalls())
+func (mock *QuerierMock) DeleteUserCalls() []struct {
+	Ctx context.Context
+	ID  pgtype.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}
+	mock.lockDeleteUser.RLock()
+	calls = mock.calls.DeleteUser
+	mock.lockDeleteUser.RUnlock()
+	return calls
+}
+
+// FailImageEdit calls FailImageEditFunc.
+func (mock *QuerierMock) FailImageEdit(ctx context.Context, arg FailImageEditParams) error {
+	if mock.FailImageEditFunc == nil {
+		panic("QuerierMock.FailImageEditFunc: method is nil but Querier.FailImageEdit was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg FailImageEditParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockFailImageEdit.Lock()
+	mock.calls.FailImageEdit = append(mock.calls.FailImageEdit, callInfo)
+	mock.lockFailImageEdit.Unlock()
+	return mock.FailImageEditFunc(ctx, arg)
+}
+
+// FailImageEditCalls gets all the calls that were made to FailImageEdit.
+// Check the length with:
+//
+//	len(mockedQuerier.FailImageEditCalls())
+func (mock *QuerierMock) FailImageEditCalls() []struct {
+	Ctx context.Context
+	Arg FailImageEditParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg FailImageEditParams
+	}
+	mock.lockFailImageEdit.RLock()
+	calls = mock.calls.FailImageEdit
+	mock.lockFailImageEdit.RUnlock()
+	return calls
+}
+
+// FailJob calls FailJobFunc.
+func (mock *QuerierMock) FailJob(ctx context.Context, arg FailJobParams) (*Job, error) {
+	if mock.FailJobFunc == nil {
+		panic("QuerierMock.FailJobFunc: method is nil but Querier.FailJob was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg FailJobParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockFailJob.Lock()
+	mock.calls.FailJob = append(mock.calls.FailJob, callInfo)
+	mock.lockFailJob.Unlock()
+	return mock.FailJobFunc(ctx, arg)
+}
+
+// FailJobCalls gets all the calls that were made to FailJob.
+// Check the length with:
+//
+//	len(mockedQuerier.FailJobCalls())
+func (mock *QuerierMock) FailJobCalls() []struct {
+	Ctx context.Context
+	Arg FailJobParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg FailJobParams
+	}
+	mock.lockFailJob.RLock()
+	calls = mock.calls.FailJob
+	mock.lockFailJob.RUnlock()
+	return calls
+}
+
+// FailProjectExport calls FailProjectExportFunc.
+func (mock *QuerierMock) FailProjectExport(ctx context.Context, arg FailProjectExportParams) error {
+	if mock.FailProjectExportFunc == nil {
+		panic("QuerierMock.FailProjectExportFunc: method is nil but Querier.FailProjectExport was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg FailProjectExportParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockFailProjectExport.Lock()
+	mock.calls.FailProjectExport = append(mock.calls.FailProjectExport, callInfo)
+	mock.lockFailProjectExport.Unlock()
+	return mock.FailProjectExportFunc(ctx, arg)
+}
+
+// FailProjectExportCalls gets all the calls that were made to FailProjectExport.
+// Check the length with:
+//
+//	len(mockedQuerier.FailProjectExportCalls())
+func (mock *QuerierMock) FailProjectExportCalls() []struct {
+	Ctx context.Context
+	Arg FailProjectExportParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg FailProjectExportParams
+	}
+	mock.lockFailProjectExport.RLock()
+	calls = mock.calls.FailProjectExport
+	mock.lockFailProjectExport.RUnlock()
+	return calls
+}
+
+// FailRoomGrouping calls FailRoomGroupingFunc.
+func (mock *QuerierMock) FailRoomGrouping(ctx context.Context, arg FailRoomGroupingParams) error {
+	if mock.FailRoomGroupingFunc == nil {
+		panic("QuerierMock.FailRoomGroupingFunc: method is nil but Querier.FailRoomGrouping was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg FailRoomGroupingParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockFailRoomGrouping.Lock()
+	mock.calls.FailRoomGrouping = append(mock.calls.FailRoomGrouping, callInfo)
+	mock.lockFailRoomGrouping.Unlock()
+	return mock.FailRoomGroupingFunc(ctx, arg)
+}
+
+// FailRoomGroupingCalls gets all the calls that were made to FailRoomGrouping.
+// Check the length with:
+//
+//	len(mockedQuerier.FailRoomGroupingCalls())
+func (mock *QuerierMock) FailRoomGroupingCalls() []struct {
+	Ctx context.Context
+	Arg FailRoomGroupingParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg FailRoomGroupingParams
+	}
+	mock.lockFailRoomGrouping.RLock()
+	calls = mock.calls.FailRoomGrouping
+	mock.lockFailRoomGrouping.RUnlock()
+	return calls
+}
+
+// GetAPIKeyByHash calls GetAPIKeyByHashFunc.
+func (mock *QuerierMock) GetAPIKeyByHash(ctx context.Context, keyHash []byte) (*GetAPIKeyByHashRow, error) {
+	if mock.GetAPIKeyByHashFunc == nil {
+		panic("QuerierMock.GetAPIKeyByHashFunc: method is nil but Querier.GetAPIKeyByHash was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		KeyHash []byte
+	}{
+		Ctx:     ctx,
+		KeyHash: keyHash,
+	}
+	mock.lockGetAPIKeyByHash.Lock()
+	mock.calls.GetAPIKeyByHash = append(mock.calls.GetAPIKeyByHash, callInfo)
+	mock.lockGetAPIKeyByHash.Unlock()
+	return mock.GetAPIKeyByHashFunc(ctx, keyHash)
+}
+
+// GetAPIKeyByHashCalls gets all the calls that were made to GetAPIKeyByHash.
+// Check the length with:
+//
+//	len(mockedQuerier.GetAPIKeyByHashCalls())
+func (mock *QuerierMock) GetAPIKeyByHashCalls() []struct {
+	Ctx     context.Context
+	KeyHash []byte
+} {
+	var calls []struct {
+		Ctx     context.Context
+		KeyHash []byte
+	}
+	mock.lockGetAPIKeyByHash.RLock()
+	calls = mock.calls.GetAPIKeyByHash
+	mock.lockGetAPIKeyByHash.RUnlock()
+	return calls
+}
+
+// GetAccountWatermark calls GetAccountWatermarkFunc.
+func (mock *QuerierMock) GetAccountWatermark(ctx context.Context, userID pgtype.UUID) (*AccountWatermark, error) {
+	if mock.GetAccountWatermarkFunc == nil {
+		panic("QuerierMock.GetAccountWatermarkFunc: method is nil but Querier.GetAccountWatermark was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetAccountWatermark.Lock()
+	mock.calls.GetAccountWatermark = append(mock.calls.GetAccountWatermark, callInfo)
+	mock.lockGetAccountWatermark.Unlock()
+	return mock.GetAccountWatermarkFunc(ctx, userID)
+}
+
+// GetAccountWatermarkCalls gets all the calls that were made to GetAccountWatermark.
+// Check the length with:
+//
+//	len(mockedQuerier.GetAccountWatermarkCalls())
+func (mock *QuerierMock) GetAccountWatermarkCalls() []struct {
+	Ctx    context.Context
+	UserID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}
+	mock.lockGetAccountWatermark.RLock()
+	calls = mock.calls.GetAccountWatermark
+	mock.lockGetAccountWatermark.RUnlock()
+	return calls
+}
+
+// GetAllProjects calls GetAllProjectsFunc.
+func (mock *QuerierMock) GetAllProjects(ctx context.Context) ([]*GetAllProjectsRow, error) {
+	if mock.GetAllProjectsFunc == nil {
+		panic("QuerierMock.GetAllProjectsFunc: method is nil but Querier.GetAllProjects was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetAllProjects.Lock()
+	mock.calls.GetAllProjects = append(mock.calls.GetAllProjects, callInfo)
+	mock.lockGetAllProjects.Unlock()
+	return mock.GetAllProjectsFunc(ctx)
+}
+
+// GetAllProjectsCalls gets all the calls that were made to GetAllProjects.
+// Check the length with:
+//
+//	len(mockedQuerier.GetAllProjectsCalls())
+func (mock *QuerierMock) GetAllProjectsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetAllProjects.RLock()
+	calls = mock.calls.GetAllProjects
+	mock.lockGetAllProjects.RUnlock()
+	return calls
+}
+
+// GetCatalogByID calls GetCatalogByIDFunc.
+func (mock *QuerierMock) GetCatalogByID(ctx context.Context, id pgtype.UUID) (*Catalog, error) {
+	if mock.GetCatalogByIDFunc == nil {
+		panic("QuerierMock.GetCatalogByIDFunc: method is nil but Querier.GetCatalogByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetCatalogByID.Lock()
+	mock.calls.GetCatalogByID = append(mock.calls.GetCatalogByID, callInfo)
+	mock.lockGetCatalogByID.Unlock()
+	return mock.GetCatalogByIDFunc(ctx, id)
+}
+
+// GetCatalogByIDCalls gets all the calls that were made to GetCatalogByID.
+// Check the length with:
+//
+//	len(mockedQuerier.GetCatalogByIDCalls())
+func (mock *QuerierMock) GetCatalogByIDCalls() []struct {
+	Ctx context.Context
+	ID  pgtype.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}
+	mock.lockGetCatalogByID.RLock()
+	calls = mock.calls.GetCatalogByID
+	mock.lockGetCatalogByID.RUnlock()
+	return calls
+}
+
+// GetCustomerBucketAccess calls GetCustomerBucketAccessFunc.
+func (mock *QuerierMock) GetCustomerBucketAccess(ctx context.Context, userID pgtype.UUID) (bool, error) {
+	if mock.GetCustomerBucketAccessFunc == nil {
+		panic("QuerierMock.GetCustomerBucketAccessFunc: method is nil but Querier.GetCustomerBucketAccess was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetCustomerBucketAccess.Lock()
+	mock.calls.GetCustomerBucketAccess = append(mock.calls.GetCustomerBucketAccess, callInfo)
+	mock.lockGetCustomerBucketAccess.Unlock()
+	return mock.GetCustomerBucketAccessFunc(ctx, userID)
+}
+
+// GetCustomerBucketAccessCalls gets all the calls that were made to GetCustomerBucketAccess.
+// Check the length with:
+//
+//	len(mockedQuerier.GetCustomerBucketAccessCalls())
+func (mock *QuerierMock) GetCustomerBucketAccessCalls() []struct {
+	Ctx    context.Context
+	UserID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}
+	mock.lockGetCustomerBucketAccess.RLock()
+	calls = mock.calls.GetCustomerBucketAccess
+	mock.lockGetCustomerBucketAccess.RUnlock()
+	return calls
+}
+
+// GetCustomerBucketByName calls GetCustomerBucketByNameFunc.
+func (mock *QuerierMock) GetCustomerBucketByName(ctx context.Context, bucket string) (*CustomerBucket, error) {
+	if mock.GetCustomerBucketByNameFunc == nil {
+		panic("QuerierMock.GetCustomerBucketByNameFunc: method is nil but Querier.GetCustomerBucketByName was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Bucket string
+	}{
+		Ctx:    ctx,
+		Bucket: bucket,
+	}
+	mock.lockGetCustomerBucketByName.Lock()
+	mock.calls.GetCustomerBucketByName = append(mock.calls.GetCustomerBucketByName, callInfo)
+	mock.lockGetCustomerBucketByName.Unlock()
+	return mock.GetCustomerBucketByNameFunc(ctx, bucket)
+}
+
+// GetCustomerBucketByNameCalls gets all the calls that were made to GetCustomerBucketByName.
+// Check the length with:
+//
+//	len(mockedQuerier.GetCustomerBucketByNameCalls())
+func (mock *QuerierMock) GetCustomerBucketByNameCalls() []struct {
+	Ctx    context.Context
+	Bucket string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Bucket string
+	}
+	mock.lockGetCustomerBucketByName.RLock()
+	calls = mock.calls.GetCustomerBucketByName
+	mock.lockGetCustomerBucketByName.RUnlock()
+	return calls
+}
+
+// GetCustomerBucketByUserID calls GetCustomerBucketByUserIDFunc.
+func (mock *QuerierMock) GetCustomerBucketByUserID(ctx context.Context, userID pgtype.UUID) (*CustomerBucket, error) {
+	if mock.GetCustomerBucketByUserIDFunc == nil {
+		panic("QuerierMock.GetCustomerBucketByUserIDFunc: method is nil but Querier.GetCustomerBucketByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetCustomerBucketByUserID.Lock()
+	mock.calls.GetCustomerBucketByUserID = append(mock.calls.GetCustomerBucketByUserID, callInfo)
+	mock.lockGetCustomerBucketByUserID.Unlock()
+	return mock.GetCustomerBucketByUserIDFunc(ctx, userID)
+}
+
+// GetCustomerBucketByUserIDCalls gets all the calls that were made to GetCustomerBucketByUserID.
+// Check the length with:
+//
+//	len(mockedQuerier.GetCustomerBucketByUserIDCalls())
+func (mock *QuerierMock) GetCustomerBucketByUserIDCalls() []struct {
+	Ctx    context.Context
+	UserID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}
+	mock.lockGetCustomerBucketByUserID.RLock()
+	calls = mock.calls.GetCustomerBucketByUserID
+	mock.lockGetCustomerBucketByUserID.RUnlock()
+	return calls
+}
+
+// GetDeadLetterJob calls GetDeadLetterJobFunc.
+func (mock *QuerierMock) GetDeadLetterJob(ctx context.Context, id pgtype.UUID) (*DeadLetterJob, error) {
+	if mock.GetDeadLetterJobFunc == nil {
+		panic("QuerierMock.GetDeadLetterJobFunc: method is nil but Querier.GetDeadLetterJob was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetDeadLetterJob.Lock()
+	mock.calls.GetDeadLetterJob = append(mock.calls.GetDeadLetterJob, callInfo)
+	mock.lockGetDeadLetterJob.Unlock()
+	return mock.GetDeadLetterJobFunc(ctx, id)
+}
+
+// GetDeadLetterJobCalls gets all the calls that were made to GetDeadLetterJob.
+// Check the length with:
+//
+//	len(mockedQuerier.GetDeadLetterJobCalls())
+func (mock *QuerierMock) GetDeadLetterJobCalls() []struct {
+	Ctx context.Context
+	ID  pgtype.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}
+	mock.lockGetDeadLetterJob.RLock()
+	calls = mock.calls.GetDeadLetterJob
+	mock.lockGetDeadLetterJob.RUnlock()
+	return calls
+}
+
+// GetExpediteAccess calls GetExpediteAccessFunc.
+func (mock *QuerierMock) GetExpediteAccess(ctx context.Context, arg GetExpediteAccessParams) (*GetExpediteAccessRow, error) {
+	if mock.GetExpediteAccessFunc == nil {
+		panic("QuerierMock.GetExpediteAccessFunc: method is nil but Querier.GetExpediteAccess was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg GetExpediteAccessParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockGetExpediteAccess.Lock()
+	mock.calls.GetExpediteAccess = append(mock.calls.GetExpediteAccess, callInfo)
+	mock.lockGetExpediteAccess.Unlock()
+	return mock.GetExpediteAccessFunc(ctx, arg)
+}
+
+// GetExpediteAccessCalls gets all the calls that were made to GetExpediteAccess.
+// Check the length with:
+//
+//	len(mockedQuerier.GetExpediteAccessCalls())
+func (mock *QuerierMock) GetExpediteAccessCalls() []struct {
+	Ctx context.Context
+	Arg GetExpediteAccessParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg GetExpediteAccessParams
+	}
+	mock.lockGetExpediteAccess.RLock()
+	calls = mock.calls.GetExpediteAccess
+	mock.lockGetExpediteAccess.RUnlock()
+	return calls
+}
+
+// GetImageAnalyticsBuckets calls GetImageAnalyticsBucketsFunc.
+func (mock *QuerierMock) GetImageAnalyticsBuckets(ctx context.Context, arg GetImageAnalyticsBucketsParams) ([]*GetImageAnalyticsBucketsRow, error) {
+	if mock.GetImageAnalyticsBucketsFunc == nil {
+		panic("QuerierMock.GetImageAnalyticsBucketsFunc: method is nil but Querier.GetImageAnalyticsBuckets was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg GetImageAnalyticsBucketsParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockGetImageAnalyticsBuckets.Lock()
+	mock.calls.GetImageAnalyticsBuckets = append(mock.calls.GetImageAnalyticsBuckets, callInfo)
+	mock.lockGetImageAnalyticsBuckets.Unlock()
+	return mock.GetImageAnalyticsBucketsFunc(ctx, arg)
+}
+
+// GetImageAnalyticsBucketsCalls gets all the calls that were made to GetImageAnalyticsBuckets.
+// Check the length with:
+//
+//	len(mockedQuerier.GetImageAnalyticsBucketsCalls())
+func (mock *QuerierMock) GetImageAnalyticsBucketsCalls() []struct {
+	Ctx context.Context
+	Arg GetImageAnalyticsBucketsParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg GetImageAnalyticsBucketsParams
+	}
+	mock.lockGetImageAnalyticsBuckets.RLock()
+	calls = mock.calls.GetImageAnalyticsBuckets
+	mock.lockGetImageAnalyticsBuckets.RUnlock()
+	return calls
+}
+
+// GetImageByID calls GetImageByIDFunc.
+func (mock *QuerierMock) GetImageByID(ctx context.Context, id pgtype.UUID) (*GetImageByIDRow, error) {
+	if mock.GetImageByIDFunc == nil {
+		panic("QuerierMock.GetImageByIDFunc: method is nil but Querier.GetImageByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetImageByID.Lock()
+	mock.calls.GetImageByID = append(mock.calls.GetImageByID, callInfo)
+	mock.lockGetImageByID.Unlock()
+	return mock.GetImageByIDFunc(ctx, id)
+}
+
+// GetImageByIDCalls gets all the calls that were made to GetImageByID.
+// Check the length with:
+//
+//	len(mockedQuerier.GetImageByIDCalls())
+func (mock *QuerierMock) GetImageByIDCalls() []struct {
+	Ctx context.Context
+	ID  pgtype.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}
+	mock.lockGetImageByID.RLock()
+	calls = mock.calls.GetImageByID
+	mock.lockGetImageByID.RUnlock()
+	return calls
+}
+
+// GetImageEdit calls GetImageEditFunc.
+func (mock *QuerierMock) GetImageEdit(ctx context.Context, arg GetImageEditParams) (*ImageEdit, error) {
+	if mock.GetImageEditFunc == nil {
+		panic("QuerierMock.GetImageEditFunc: method is nil but Querier.GetImageEdit was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg GetImageEditParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockGetImageEdit.Lock()
+	mock.calls.GetImageEdit = append(mock.calls.GetImageEdit, callInfo)
+	mock.lockGetImageEdit.Unlock()
+	return mock.GetImageEditFunc(ctx, arg)
+}
+
+// GetImageEditCalls gets all the calls that were made to GetImageEdit.
+// Check the length with:
+//
+//	len(mockedQuerier.GetImageEditCalls())
+func (mock *QuerierMock) GetImageEditCalls() []struct {
+	Ctx context.Context
+	Arg GetImageEditParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg GetImageEditParams
+	}
+	mock.lockGetImageEdit.RLock()
+	calls = mock.calls.GetImageEdit
+	mock.lockGetImageEdit.RUnlock()
+	return calls
+}
+
+// GetImageEditSource calls GetImageEditSourceFunc.
+func (mock *QuerierMock) GetImageEditSource(ctx context.Context, id pgtype.UUID) (*GetImageEditSourceRow, error) {
+	if mock.GetImageEditSourceFunc == nil {
+		panic("QuerierMock.GetImageEditSourceFunc: method is nil but Querier.GetImageEditSource was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetImageEditSource.Lock()
+	mock.calls.GetImageEditSource = append(mock.calls.GetImageEditSource, callInfo)
+	mock.lockGetImageEditSource.Unlock()
+	return mock.GetImageEditSourceFunc(ctx, id)
+}
+
+// GetImageEditSourceCalls gets all the calls that were made to GetImageEditSource.
+// Check the length with:
+//
+//	len(mockedQuerier.GetImageEditSourceCalls())
+func (mock *QuerierMock) GetImageEditSourceCalls() []struct {
+	Ctx context.Context
+	ID  pgtype.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}
+	mock.lockGetImageEditSource.RLock()
+	calls = mock.calls.GetImageEditSource
+	mock.lockGetImageEditSource.RUnlock()
+	return calls
+}
+
+// GetImageSourceBreakdown calls GetImageSourceBreakdownFunc.
+func (mock *QuerierMock) GetImageSourceBreakdown(ctx context.Context, arg GetImageSourceBreakdownParams) ([]*GetImageSourceBreakdownRow, error) {
+	if mock.GetImageSourceBreakdownFunc == nil {
+		panic("QuerierMock.GetImageSourceBreakdownFunc: method is nil but Querier.GetImageSourceBreakdown was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg GetImageSourceBreakdownParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockGetImageSourceBreakdown.Lock()
+	mock.calls.GetImageSourceBreakdown = append(mock.calls.GetImageSourceBreakdown, callInfo)
+	mock.lockGetImageSourceBreakdown.Unlock()
+	return mock.GetImageSourceBreakdownFunc(ctx, arg)
+}
+
+// GetImageSourceBreakdownCalls gets all the calls that were made to GetImageSourceBreakdown.
+// Check the length with:
+//
+//	len(mockedQuerier.GetImageSourceBreakdownCalls())
+func (mock *QuerierMock) GetImageSourceBreakdownCalls() []struct {
+	Ctx context.Context
+	Arg GetImageSourceBreakdownParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg GetImageSourceBreakdownParams
+	}
+	mock.lockGetImageSourceBreakdown.RLock()
+	calls = mock.calls.GetImageSourceBreakdown
+	mock.lockGetImageSourceBreakdown.RUnlock()
+	return calls
+}
+
+// GetImageStatusesByIDs calls GetImageStatusesByIDsFunc.
+func (mock *QuerierMock) GetImageStatusesByIDs(ctx context.Context, arg GetImageStatusesByIDsParams) ([]*GetImageStatusesByIDsRow, error) {
+	if mock.GetImageStatusesByIDsFunc == nil {
+		panic("QuerierMock.GetImageStatusesByIDsFunc: method is nil but Querier.GetImageStatusesByIDs was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg GetImageStatusesByIDsParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockGetImageStatusesByIDs.Lock()
+	mock.calls.GetImageStatusesByIDs = append(mock.calls.GetImageStatusesByIDs, callInfo)
+	mock.lockGetImageStatusesByIDs.Unlock()
+	return mock.GetImageStatusesByIDsFunc(ctx, arg)
+}
+
+// GetImageStatusesByIDsCalls gets all the calls that were made to GetImageStatusesByIDs.
+// Check the length with:
+//
+//	len(mockedQuerier.GetImageStatusesByIDsCalls())
+func (mock *QuerierMock) GetImageStatusesByIDsCalls() []struct {
+	Ctx context.Context
+	Arg GetImageStatusesByIDsParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg GetImageStatusesByIDsParams
+	}
+	mock.lockGetImageStatusesByIDs.RLock()
+	calls = mock.calls.GetImageStatusesByIDs
+	mock.lockGetImageStatusesByIDs.RUnlock()
+	return calls
+}
+
+// GetImagesByIDs calls GetImagesByIDsFunc.
+func (mock *QuerierMock) GetImagesByIDs(ctx context.Context, ids []pgtype.UUID) ([]*GetImagesByIDsRow, error) {
+	if mock.GetImagesByIDsFunc == nil {
+		panic("QuerierMock.GetImagesByIDsFunc: method is nil but Querier.GetImagesByIDs was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Ids []pgtype.UUID
+	}{
+		Ctx: ctx,
+		Ids: ids,
+	}
+	mock.lockGetImagesByIDs.Lock()
+	mock.calls.GetImagesByIDs = append(mock.calls.GetImagesByIDs, callInfo)
+	mock.lockGetImagesByIDs.Unlock()
+	return mock.GetImagesByIDsFunc(ctx, ids)
+}
+
+// GetImagesByIDsCalls gets all the calls that were made to GetImagesByIDs.
+// Check the length with:
+//
+//	len(mockedQuerier.GetImagesByIDsCalls())
+func (mock *QuerierMock) GetImagesByIDsCalls() []struct {
+	Ctx context.Context
+	Ids []pgtype.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Ids []pgtype.UUID
+	}
+	mock.lockGetImagesByIDs.RLock()
+	calls = mock.calls.GetImagesByIDs
+	mock.lockGetImagesByIDs.RUnlock()
+	return calls
+}
+
+// GetImagesByProjectID calls GetImagesByProjectIDFunc.
+func (mock *QuerierMock) GetImagesByProjectID(ctx context.Context, projectID pgtype.UUID) ([]*GetImagesByProjectIDRow, error) {
+	if mock.GetImagesByProjectIDFunc == nil {
+		panic("QuerierMock.GetImagesByProjectIDFunc: method is nil but Querier.GetImagesByProjectID was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockGetImagesByProjectID.Lock()
+	mock.calls.GetImagesByProjectID = append(mock.calls.GetImagesByProjectID, callInfo)
+	mock.lockGetImagesByProjectID.Unlock()
+	return mock.GetImagesByProjectIDFunc(ctx, projectID)
+}
+
+// GetImagesByProjectIDCalls gets all the calls that were made to GetImagesByProjectID.
+// Check the length with:
+//
+//	len(mockedQuerier.GetImagesByProjectIDCalls())
+func (mock *QuerierMock) GetImagesByProjectIDCalls() []struct {
+	Ctx       context.Context
+	ProjectID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}
+	mock.lockGetImagesByProjectID.RLock()
+	calls = mock.calls.GetImagesByProjectID
+	mock.lockGetImagesByProjectID.RUnlock()
+	return calls
+}
+
+// GetInvoiceByStripeID calls GetInvoiceByStripeIDFunc.
+func (mock *QuerierMock) GetInvoiceByStripeID(ctx context.Context, stripeInvoiceID string) (*Invoice, error) {
+	if mock.GetInvoiceByStripeIDFunc == nil {
+		panic("QuerierMock.GetInvoiceByStripeIDFunc: method is nil but Querier.GetInvoiceByStripeID was just called")
+	}
+	callInfo := struct {
+		Ctx             context.Context
+		StripeInvoiceID string
+	}{
+		Ctx:             ctx,
+		StripeInvoiceID: stripeInvoiceID,
+	}
+	mock.lockGetInvoiceByStripeID.Lock()
+	mock.calls.GetInvoiceByStripeID = append(mock.calls.GetInvoiceByStripeID, callInfo)
+	mock.lockGetInvoiceByStripeID.Unlock()
+	return mock.GetInvoiceByStripeIDFunc(ctx, stripeInvoiceID)
+}
+
+// GetInvoiceByStripeIDCalls gets all the calls that were made to GetInvoiceByStripeID.
+// Check the length with:
+//
+//	len(mockedQuerier.GetInvoiceByStripeIDCalls())
+func (mock *QuerierMock) GetInvoiceByStripeIDCalls() []struct {
+	Ctx             context.Context
+	StripeInvoiceID string
+} {
+	var calls []struct {
+		Ctx             context.Context
+		StripeInvoiceID string
+	}
+	mock.lockGetInvoiceByStripeID.RLock()
+	calls = mock.calls.GetInvoiceByStripeID
+	mock.lockGetInvoiceByStripeID.RUnlock()
+	return calls
+}
+
+// GetJobByID calls GetJobByIDFunc.
+func (mock *QuerierMock) GetJobByID(ctx context.Context, id pgtype.UUID) (*Job, error) {
+	if mock.GetJobByIDFunc == nil {
+		panic("QuerierMock.GetJobByIDFunc: method is nil but Querier.GetJobByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetJobByID.Lock()
+	mock.calls.GetJobByID = append(mock.calls.GetJobByID, callInfo)
+	mock.lockGetJobByID.Unlock()
+	return mock.GetJobByIDFunc(ctx, id)
+}
+
+// GetJobByIDCalls gets all the calls that were made to GetJobByID.
+// Check the length with:
+//
+//	len(mockedQuerier.GetJobByIDCalls())
+func (mock *QuerierMock) GetJobByIDCalls() []struct {
+	Ctx context.Context
+	ID  pgtype.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}
+	mock.lockGetJobByID.RLock()
+	calls = mock.calls.GetJobByID
+	mock.lockGetJobByID.RUnlock()
+	return calls
+}
+
+// GetJobLog calls GetJobLogFunc.
+func (mock *QuerierMock) GetJobLog(ctx context.Context, arg GetJobLogParams) (*JobLog, error) {
+	if mock.GetJobLogFunc == nil {
+		panic("QuerierMock.GetJobLogFunc: method is nil but Querier.GetJobLog was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg GetJobLogParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockGetJobLog.Lock()
+	mock.calls.GetJobLog = append(mock.calls.GetJobLog, callInfo)
+	mock.lockGetJobLog.Unlock()
+	return mock.GetJobLogFunc(ctx, arg)
+}
+
+// GetJobLogCalls gets all the calls that were made to GetJobLog.
+// Check the length with:
+//
+//	len(mockedQuerier.GetJobLogCalls())
+func (mock *QuerierMock) GetJobLogCalls() []struct {
+	Ctx context.Context
+	Arg GetJobLogParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg GetJobLogParams
+	}
+	mock.lockGetJobLog.RLock()
+	calls = mock.calls.GetJobLog
+	mock.lockGetJobLog.RUnlock()
+	return calls
+}
+
+// GetJobOutcomesSince calls GetJobOutcomesSinceFunc.
+func (mock *QuerierMock) GetJobOutcomesSince(ctx context.Context, since pgtype.Timestamptz) (*GetJobOutcomesSinceRow, error) {
+	if mock.GetJobOutcomesSinceFunc == nil {
+		panic("QuerierMock.GetJobOutcomesSinceFunc: method is nil but Querier.GetJobOutcomesSince was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Since pgtype.Timestamptz
+	}{
+		Ctx:   ctx,
+		Since: since,
+	}
+	mock.lockGetJobOutcomesSince.Lock()
+	mock.calls.GetJobOutcomesSince = append(mock.calls.GetJobOutcomesSince, callInfo)
+	mock.lockGetJobOutcomesSince.Unlock()
+	return mock.GetJobOutcomesSinceFunc(ctx, since)
+}
+
+// GetJobOutcomesSinceCalls gets all the calls that were made to GetJobOutcomesSince.
+// Check the length with:
+//
+//	len(mockedQuerier.GetJobOutcomesSinceCalls())
+func (mock *QuerierMock) GetJobOutcomesSinceCalls() []struct {
+	Ctx   context.Context
+	Since pgtype.Timestamptz
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Since pgtype.Timestamptz
+	}
+	mock.lockGetJobOutcomesSince.RLock()
+	calls = mock.calls.GetJobOutcomesSince
+	mock.lockGetJobOutcomesSince.RUnlock()
+	return calls
+}
+
+// GetJobsByImageID calls GetJobsByImageIDFunc.
+func (mock *QuerierMock) GetJobsByImageID(ctx context.Context, imageID pgtype.UUID) ([]*Job, error) {
+	if mock.GetJobsByImageIDFunc == nil {
+		panic("QuerierMock.GetJobsByImageIDFunc: method is nil but Querier.GetJobsByImageID was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ImageID pgtype.UUID
+	}{
+		Ctx:     ctx,
+		ImageID: imageID,
+	}
+	mock.lockGetJobsByImageID.Lock()
+	mock.calls.GetJobsByImageID = append(mock.calls.GetJobsByImageID, callInfo)
+	mock.lockGetJobsByImageID.Unlock()
+	return mock.GetJobsByImageIDFunc(ctx, imageID)
+}
+
+// GetJobsByImageIDCalls gets all the calls that were made to GetJobsByImageID.
+// Check the length with:
+//
+//	len(mockedQuerier.GetJobsByImageIDCalls())
+func (mock *QuerierMock) GetJobsByImageIDCalls() []struct {
+	Ctx     context.Context
+	ImageID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ImageID pgtype.UUID
+	}
+	mock.lockGetJobsByImageID.RLock()
+	calls = mock.calls.GetJobsByImageID
+	mock.lockGetJobsByImageID.RUnlock()
+	return calls
+}
+
+// GetLatestRoomGrouping calls GetLatestRoomGroupingFunc.
+func (mock *QuerierMock) GetLatestRoomGrouping(ctx context.Context, projectID pgtype.UUID) (*RoomGrouping, error) {
+	if mock.GetLatestRoomGroupingFunc == nil {
+		panic("QuerierMock.GetLatestRoomGroupingFunc: method is nil but Querier.GetLatestRoomGrouping was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockGetLatestRoomGrouping.Lock()
+	mock.calls.GetLatestRoomGrouping = append(mock.calls.GetLatestRoomGrouping, callInfo)
+	mock.lockGetLatestRoomGrouping.Unlock()
+	return mock.GetLatestRoomGroupingFunc(ctx, projectID)
+}
+
+// GetLatestRoomGroupingCalls gets all the calls that were made to GetLatestRoomGrouping.
+// Check the length with:
+//
+//	len(mockedQuerier.GetLatestRoomGroupingCalls())
+func (mock *QuerierMock) GetLatestRoomGroupingCalls() []struct {
+	Ctx       context.Context
+	ProjectID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}
+	mock.lockGetLatestRoomGrouping.RLock()
+	calls = mock.calls.GetLatestRoomGrouping
+	mock.lockGetLatestRoomGrouping.RUnlock()
+	return calls
+}
+
+// GetOrganizationIPAllowlist calls GetOrganizationIPAllowlistFunc.
+func (mock *QuerierMock) GetOrganizationIPAllowlist(ctx context.Context, id pgtype.UUID) ([]string, error) {
+	if mock.GetOrganizationIPAllowlistFunc == nil {
+		panic("QuerierMock.GetOrganizationIPAllowlistFunc: method is nil but Querier.GetOrganizationIPAllowlist was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetOrganizationIPAllowlist.Lock()
+	mock.calls.GetOrganizationIPAllowlist = append(mock.calls.GetOrganizationIPAllowlist, callInfo)
+	mock.lockGetOrganizationIPAllowlist.Unlock()
+	return mock.GetOrganizationIPAllowlistFunc(ctx, id)
+}
+
+// GetOrganizationIPAllowlistCalls gets all the calls that were made to GetOrganizationIPAllowlist.
+// Check the length with:
+//
+//	len(mockedQuerier.GetOrganizationIPAllowlistCalls())
+func (mock *QuerierMock) GetOrganizationIPAllowlistCalls() []struct {
+	Ctx context.Context
+	ID  pgtype.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}
+	mock.lockGetOrganizationIPAllowlist.RLock()
+	calls = mock.calls.GetOrganizationIPAllowlist
+	mock.lockGetOrganizationIPAllowlist.RUnlock()
+	return calls
+}
+
+// GetOrganizationMember calls GetOrganizationMemberFunc.
+func (mock *QuerierMock) GetOrganizationMember(ctx context.Context, arg GetOrganizationMemberParams) (*OrganizationMember, error) {
+	if mock.GetOrganizationMemberFunc == nil {
+		panic("QuerierMock.GetOrganizationMemberFunc: method is nil but Querier.GetOrganizationMember was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg GetOrganizationMemberParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockGetOrganizationMember.Lock()
+	mock.calls.GetOrganizationMember = append(mock.calls.GetOrganizationMember, callInfo)
+	mock.lockGetOrganizationMember.Unlock()
+	return mock.GetOrganizationMemberFunc(ctx, arg)
+}
+
+// GetOrganizationMemberCalls gets all the calls that were made to GetOrganizationMember.
+// Check the length with:
+//
+//	len(mockedQuerier.GetOrganizationMemberCalls())
+func (mock *QuerierMock) GetOrganizationMemberCalls() []struct {
+	Ctx context.Context
+	Arg GetOrganizationMemberParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg GetOrganizationMemberParams
+	}
+	mock.lockGetOrganizationMember.RLock()
+	calls = mock.calls.GetOrganizationMember
+	mock.lockGetOrganizationMember.RUnlock()
+	return calls
+}
+
+// GetOrganizationSSO calls GetOrganizationSSOFunc.
+func (mock *QuerierMock) GetOrganizationSSO(ctx context.Context, organizationID pgtype.UUID) (*OrganizationSso, error) {
+	if mock.GetOrganizationSSOFunc == nil {
+		panic("QuerierMock.GetOrganizationSSOFunc: method is nil but Querier.GetOrganizationSSO was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		OrganizationID pgtype.UUID
+	}{
+		Ctx:            ctx,
+		OrganizationID: organizationID,
+	}
+	mock.lockGetOrganizationSSO.Lock()
+	mock.calls.GetOrganizationSSO = append(mock.calls.GetOrganizationSSO, callInfo)
+	mock.lockGetOrganizationSSO.Unlock()
+	return mock.GetOrganizationSSOFunc(ctx, organizationID)
+}
+
+// GetOrganizationSSOCalls gets all the calls that were made to GetOrganizationSSO.
+// Check the length with:
+//
+//	len(mockedQuerier.GetOrganizationSSOCalls())
+func (mock *QuerierMock) GetOrganizationSSOCalls() []struct {
+	Ctx            context.Context
+	OrganizationID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx            context.Context
+		OrganizationID pgtype.UUID
+	}
+	mock.lockGetOrganizationSSO.RLock()
+	calls = mock.calls.GetOrganizationSSO
+	mock.lockGetOrganizationSSO.RUnlock()
+	return calls
+}
+
+// GetOrganizationSSOAccess calls GetOrganizationSSOAccessFunc.
+func (mock *QuerierMock) GetOrganizationSSOAccess(ctx context.Context, id pgtype.UUID) (bool, error) {
+	if mock.GetOrganizationSSOAccessFunc == nil {
+		panic("QuerierMock.GetOrganizationSSOAccessFunc: method is nil but Querier.GetOrganizationSSOAccess was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetOrganizationSSOAccess.Lock()
+	mock.calls.GetOrganizationSSOAccess = append(mock.calls.GetOrganizationSSOAccess, callInfo)
+	mock.lockGetOrganizationSSOAccess.Unlock()
+	return mock.GetOrganizationSSOAccessFunc(ctx, id)
+}
+
+// GetOrganizationSSOAccessCalls gets all the calls that were made to GetOrganizationSSOAccess.
+// Check the length with:
+//
+//	len(mockedQuerier.GetOrganizationSSOAccessCalls())
+func (mock *QuerierMock) GetOrganizationSSOAccessCalls() []struct {
+	Ctx context.Context
+	ID  pgtype.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}
+	mock.lockGetOrganizationSSOAccess.RLock()
+	calls = mock.calls.GetOrganizationSSOAccess
+	mock.lockGetOrganizationSSOAccess.RUnlock()
+	return calls
+}
+
+// GetOrganizationSSOByConnection calls GetOrganizationSSOByConnectionFunc.
+func (mock *QuerierMock) GetOrganizationSSOByConnection(ctx context.Context, connection string) (*OrganizationSso, error) {
+	if mock.GetOrganizationSSOByConnectionFunc == nil {
+		panic("QuerierMock.GetOrganizationSSOByConnectionFunc: method is nil but Querier.GetOrganizationSSOByConnection was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Connection string
+	}{
+		Ctx:        ctx,
+		Connection: connection,
+	}
+	mock.lockGetOrganizationSSOByConnection.Lock()
+	mock.calls.GetOrganizationSSOByConnection = append(mock.calls.GetOrganizationSSOByConnection, callInfo)
+	mock.lockGetOrganizationSSOByConnection.Unlock()
+	return mock.GetOrganizationSSOByConnectionFunc(ctx, connection)
+}
+
+// GetOrganizationSSOByConnectionCalls gets all the calls that were made to GetOrganizationSSOByConnection.
+// Check the length with:
+//
+//	len(mockedQuerier.GetOrganizationSSOByConnectionCalls())
+func (mock *QuerierMock) GetOrganizationSSOByConnectionCalls() []struct {
+	Ctx        context.Context
+	Connection string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Connection string
+	}
+	mock.lockGetOrganizationSSOByConnection.RLock()
+	calls = mock.calls.GetOrganizationSSOByConnection
+	mock.lockGetOrganizationSSOByConnection.RUnlock()
+	return calls
+}
+
+// GetOrganizationSSOByEmailDomain calls GetOrganizationSSOByEmailDomainFunc.
+func (mock *QuerierMock) GetOrganizationSSOByEmailDomain(ctx context.Context, domain string) (*OrganizationSso, error) {
+	if mock.GetOrganizationSSOByEmailDomainFunc == nil {
+		panic("QuerierMock.GetOrganizationSSOByEmailDomainFunc: method is nil but Querier.GetOrganizationSSOByEmailDomain was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Domain string
+	}{
+		Ctx:    ctx,
+		Domain: domain,
+	}
+	mock.lockGetOrganizationSSOByEmailDomain.Lock()
+	mock.calls.GetOrganizationSSOByEmailDomain = append(mock.calls.GetOrganizationSSOByEmailDomain, callInfo)
+	mock.lockGetOrganizationSSOByEmailDomain.Unlock()
+	return mock.GetOrganizationSSOByEmailDomainFunc(ctx, domain)
+}
+
+// GetOrganizationSSOByEmailDomainCalls gets all the calls that were made to GetOrganizationSSOByEmailDomain.
+// Check the length with:
+//
+//	len(mockedQuerier.GetOrganizationSSOByEmailDomainCalls())
+func (mock *QuerierMock) GetOrganizationSSOByEmailDomainCalls() []struct {
+	Ctx    context.Context
+	Domain string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Domain string
+	}
+	mock.lockGetOrganizationSSOByEmailDomain.RLock()
+	calls = mock.calls.GetOrganizationSSOByEmailDomain
+	mock.lockGetOrganizationSSOByEmailDomain.RUnlock()
+	return calls
+}
+
+// GetPendingJobs calls GetPendingJobsFunc.
+func (mock *QuerierMock) GetPendingJobs(ctx context.Context, limit int32) ([]*Job, error) {
+	if mock.GetPendingJobsFunc == nil {
+		panic("QuerierMock.GetPendingJobsFunc: method is nil but Querier.GetPendingJobs was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Limit int32
+	}{
+		Ctx:   ctx,
+		Limit: limit,
+	}
+	mock.lockGetPendingJobs.Lock()
+	mock.calls.GetPendingJobs = append(mock.calls.GetPendingJobs, callInfo)
+	mock.lockGetPendingJobs.Unlock()
+	return mock.GetPendingJobsFunc(ctx, limit)
+}
+
+// GetPendingJobsCalls gets all the calls that were made to GetPendingJobs.
+// Check the length with:
+//
+//	len(mockedQuerier.GetPendingJobsCalls())
+func (mock *QuerierMock) GetPendingJobsCalls() []struct {
+	Ctx   context.Context
+	Limit int32
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Limit int32
+	}
+	mock.lockGetPendingJobs.RLock()
+	calls = mock.calls.GetPendingJobs
+	mock.lockGetPendingJobs.RUnlock()
+	return calls
+}
+
+// GetPresetForProject calls GetPresetForProjectFunc.
+func (mock *QuerierMock) GetPresetForProject(ctx context.Context, arg GetPresetForProjectParams) (*Preset, error) {
+	if mock.GetPresetForProjectFunc == nil {
+		panic("QuerierMock.GetPresetForProjectFunc: method is nil but Querier.GetPresetForProject was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg GetPresetForProjectParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockGetPresetForProject.Lock()
+	mock.calls.GetPresetForProject = append(mock.calls.GetPresetForProject, callInfo)
+	mock.lockGetPresetForProject.Unlock()
+	return mock.GetPresetForProjectFunc(ctx, arg)
+}
+
+// GetPresetForProjectCalls gets all the calls that were made to GetPresetForProject.
+// Check the length with:
+//
+//	len(mockedQuerier.GetPresetForProjectCalls())
+func (mock *QuerierMock) GetPresetForProjectCalls() []struct {
+	Ctx context.Context
+	Arg GetPresetForProjectParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg GetPresetForProjectParams
+	}
+	mock.lockGetPresetForProject.RLock()
+	calls = mock.calls.GetPresetForProject
+	mock.lockGetPresetForProject.RUnlock()
+	return calls
+}
+
+// GetProcessedEventByStripeID calls GetProcessedEventByStripeIDFunc.
+func (mock *QuerierMock) GetProcessedEventByStripeID(ctx context.Context, stripeEventID string) (*ProcessedEvent, error) {
+	if mock.GetProcessedEventByStripeIDFunc == nil {
+		panic("QuerierMock.GetProcessedEventByStripeIDFunc: method is nil but Querier.GetProcessedEventByStripeID was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		StripeEventID string
+	}{
+		Ctx:           ctx,
+		StripeEventID: stripeEventID,
+	}
+	mock.lockGetProcessedEventByStripeID.Lock()
+	mock.calls.GetProcessedEventByStripeID = append(mock.calls.GetProcessedEventByStripeID, callInfo)
+	mock.lockGetProcessedEventByStripeID.Unlock()
+	return mock.GetProcessedEventByStripeIDFunc(ctx, stripeEventID)
+}
+
+// GetProcessedEventByStripeIDCalls gets all the calls that were made to GetProcessedEventByStripeID.
+// Check the length with:
+//
+//	len(mockedQuerier.GetProcessedEventByStripeIDCalls())
+func (mock *QuerierMock) GetProcessedEventByStripeIDCalls() []struct {
+	Ctx           context.Context
+	StripeEventID string
+} {
+	var calls []struct {
+		Ctx           context.Context
+		StripeEventID string
+	}
+	mock.lockGetProcessedEventByStripeID.RLock()
+	calls = mock.calls.GetProcessedEventByStripeID
+	mock.lockGetProcessedEventByStripeID.RUnlock()
+	return calls
+}
+
+// GetProjectByID calls GetProjectByIDFunc.
+func (mock *QuerierMock) GetProjectByID(ctx context.Context, id pgtype.UUID) (*GetProjectByIDRow, error) {
+	if mock.GetProjectByIDFunc == nil {
+		panic("QuerierMock.GetProjectByIDFunc: method is nil but Querier.GetProjectByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetProjectByID.Lock()
+	mock.calls.GetProjectByID = append(mock.calls.GetProjectByID, callInfo)
+	mock.lockGetProjectByID.Unlock()
+	return mock.GetProjectByIDFunc(ctx, id)
+}
+
+// GetProjectByIDCalls gets all the calls that were made to GetProjectByID.
+// Check the length with:
+//
+//	len(mockedQuerier.GetProjectByIDCalls())
+func (mock *QuerierMock) GetProjectByIDCalls() []struct {
+	Ctx context.Context
+	ID  pgtype.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}
+	mock.lockGetProjectByID.RLock()
+	calls = mock.calls.GetProjectByID
+	mock.lockGetProjectByID.RUnlock()
+	return calls
+}
+
+// GetProjectByIDAndUserID calls GetProjectByIDAndUserIDFunc.
+func (mock *QuerierMock) GetProjectByIDAndUserID(ctx context.Context, arg GetProjectByIDAndUserIDParams) (*GetProjectByIDAndUserIDRow, error) {
+	if mock.GetProjectByIDAndUserIDFunc == nil {
+		panic("QuerierMock.GetProjectByIDAndUserIDFunc: method is nil but Querier.GetProjectByIDAndUserID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg GetProjectByIDAndUserIDParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockGetProjectByIDAndUserID.Lock()
+	mock.calls.GetProjectByIDAndUserID = append(mock.calls.GetProjectByIDAndUserID, callInfo)
+	mock.lockGetProjectByIDAndUserID.Unlock()
+	return mock.GetProjectByIDAndUserIDFunc(ctx, arg)
+}
+
+// GetProjectByIDAndUserIDCalls gets all the calls that were made to GetProjectByIDAndUserID.
+// Check the length with:
+//
+//	len(mockedQuerier.GetProjectByIDAndUserIDCalls())
+func (mock *QuerierMock) GetProjectByIDAndUserIDCalls() []struct {
+	Ctx context.Context
+	Arg GetProjectByIDAndUserIDParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg GetProjectByIDAndUserIDParams
+	}
+	mock.lockGetProjectByIDAndUserID.RLock()
+	calls = mock.calls.GetProjectByIDAndUserID
+	mock.lockGetProjectByIDAndUserID.RUnlock()
+	return calls
+}
+
+// GetProjectByIDForMember calls GetProjectByIDForMemberFunc.
+func (mock *QuerierMock) GetProjectByIDForMember(ctx context.Context, arg GetProjectByIDForMemberParams) (*GetProjectByIDForMemberRow, error) {
+	if mock.GetProjectByIDForMemberFunc == nil {
+		panic("QuerierMock.GetProjectByIDForMemberFunc: method is nil but Querier.GetProjectByIDForMember was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg GetProjectByIDForMemberParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockGetProjectByIDForMember.Lock()
+	mock.calls.GetProjectByIDForMember = append(mock.calls.GetProjectByIDForMember, callInfo)
+	mock.lockGetProjectByIDForMember.Unlock()
+	return mock.GetProjectByIDForMemberFunc(ctx, arg)
+}
+
+// GetProjectByIDForMemberCalls gets all the calls that were made to GetProjectByIDForMember.
+// Check the length with:
+//
+//	len(mockedQuerier.GetProjectByIDForMemberCalls())
+func (mock *QuerierMock) GetProjectByIDForMemberCalls() []struct {
+	Ctx context.Context
+	Arg GetProjectByIDForMemberParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg GetProjectByIDForMemberParams
+	}
+	mock.lockGetProjectByIDForMember.RLock()
+	calls = mock.calls.GetProjectByIDForMember
+	mock.lockGetProjectByIDForMember.RUnlock()
+	return calls
+}
+
+// GetProjectCostSummary calls GetProjectCostSummaryFunc.
+func (mock *QuerierMock) GetProjectCostSummary(ctx context.Context, projectID pgtype.UUID) (*GetProjectCostSummaryRow, error) {
+	if mock.GetProjectCostSummaryFunc == nil {
+		panic("QuerierMock.GetProjectCostSummaryFunc: method is nil but Querier.GetProjectCostSummary was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockGetProjectCostSummary.Lock()
+	mock.calls.GetProjectCostSummary = append(mock.calls.GetProjectCostSummary, callInfo)
+	mock.lockGetProjectCostSummary.Unlock()
+	return mock.GetProjectCostSummaryFunc(ctx, projectID)
+}
+
+// GetProjectCostSummaryCalls gets all the calls that were made to GetProjectCostSummary.
+// Check the length with:
+//
+//	len(mockedQuerier.GetProjectCostSummaryCalls())
+func (mock *QuerierMock) GetProjectCostSummaryCalls() []struct {
+	Ctx       context.Context
+	ProjectID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}
+	mock.lockGetProjectCostSummary.RLock()
+	calls = mock.calls.GetProjectCostSummary
+	mock.lockGetProjectCostSummary.RUnlock()
+	return calls
+}
+
+// GetProjectDisclosure calls GetProjectDisclosureFunc.
+func (mock *QuerierMock) GetProjectDisclosure(ctx context.Context, projectID pgtype.UUID) (*ProjectDisclosure, error) {
+	if mock.GetProjectDisclosureFunc == nil {
+		panic("QuerierMock.GetProjectDisclosureFunc: method is nil but Querier.GetProjectDisclosure was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockGetProjectDisclosure.Lock()
+	mock.calls.GetProjectDisclosure = append(mock.calls.GetProjectDisclosure, callInfo)
+	mock.lockGetProjectDisclosure.Unlock()
+	return mock.GetProjectDisclosureFunc(ctx, projectID)
+}
+
+// GetProjectDisclosureCalls gets all the calls that were made to GetProjectDisclosure.
+// Check the length with:
+//
+//	len(mockedQuerier.GetProjectDisclosureCalls())
+func (mock *QuerierMock) GetProjectDisclosureCalls() []struct {
+	Ctx       context.Context
+	ProjectID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}
+	mock.lockGetProjectDisclosure.RLock()
+	calls = mock.calls.GetProjectDisclosure
+	mock.lockGetProjectDisclosure.RUnlock()
+	return calls
+}
+
+// GetProjectExport calls GetProjectExportFunc.
+func (mock *QuerierMock) GetProjectExport(ctx context.Context, arg GetProjectExportParams) (*ProjectExport, error) {
+	if mock.GetProjectExportFunc == nil {
+		panic("QuerierMock.GetProjectExportFunc: method is nil but Querier.GetProjectExport was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg GetProjectExportParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockGetProjectExport.Lock()
+	mock.calls.GetProjectExport = append(mock.calls.GetProjectExport, callInfo)
+	mock.lockGetProjectExport.Unlock()
+	return mock.GetProjectExportFunc(ctx, arg)
+}
+
+// GetProjectExportCalls gets all the calls that were made to GetProjectExport.
+// Check the length with:
+//
+//	len(mockedQuerier.GetProjectExportCalls())
+func (mock *QuerierMock) GetProjectExportCalls() []struct {
+	Ctx context.Context
+	Arg GetProjectExportParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg GetProjectExportParams
+	}
+	mock.lockGetProjectExport.RLock()
+	calls = mock.calls.GetProjectExport
+	mock.lockGetProjectExport.RUnlock()
+	return calls
+}
+
+// GetProjectExportCredit calls GetProjectExportCreditFunc.
+func (mock *QuerierMock) GetProjectExportCredit(ctx context.Context, projectID pgtype.UUID) (*ExportCredit, error) {
+	if mock.GetProjectExportCreditFunc == nil {
+		panic("QuerierMock.GetProjectExportCreditFunc: method is nil but Querier.GetProjectExportCredit was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockGetProjectExportCredit.Lock()
+	mock.calls.GetProjectExportCredit = append(mock.calls.GetProjectExportCredit, callInfo)
+	mock.lockGetProjectExportCredit.Unlock()
+	return mock.GetProjectExportCreditFunc(ctx, projectID)
+}
+
+// GetProjectExportCreditCalls gets all the calls that were made to GetProjectExportCredit.
+// Check the length with:
+//
+//	len(mockedQuerier.GetProjectExportCreditCalls())
+func (mock *QuerierMock) GetProjectExportCreditCalls() []struct {
+	Ctx       context.Context
+	ProjectID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}
+	mock.lockGetProjectExportCredit.RLock()
+	calls = mock.calls.GetProjectExportCredit
+	mock.lockGetProjectExportCredit.RUnlock()
+	return calls
+}
+
+// GetProjectInvitation calls GetProjectInvitationFunc.
+func (mock *QuerierMock) GetProjectInvitation(ctx context.Context, id pgtype.UUID) (*ProjectInvitation, error) {
+	if mock.GetProjectInvitationFunc == nil {
+		panic("QuerierMock.GetProjectInvitationFunc: method is nil but Querier.GetProjectInvitation was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetProjectInvitation.Lock()
+	mock.calls.GetProjectInvitation = append(mock.calls.GetProjectInvitation, callInfo)
+	mock.lockGetProjectInvitation.Unlock()
+	return mock.GetProjectInvitationFunc(ctx, id)
+}
+
+// GetProjectInvitationCalls gets all the calls that were made to GetProjectInvitation.
+// Check the length with:
+//
+//	len(mockedQuerier.GetProjectInvitationCalls())
+func (mock *QuerierMock) GetProjectInvitationCalls() []struct {
+	Ctx context.Context
+	ID  pgtype.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}
+	mock.lockGetProjectInvitation.RLock()
+	calls = mock.calls.GetProjectInvitation
+	mock.lockGetProjectInvitation.RUnlock()
+	return calls
+}
+
+// GetProjectListing calls GetProjectListingFunc.
+func (mock *QuerierMock) GetProjectListing(ctx context.Context, projectID pgtype.UUID) (*ProjectListing, error) {
+	if mock.GetProjectListingFunc == nil {
+		panic("QuerierMock.GetProjectListingFunc: method is nil but Querier.GetProjectListing was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockGetProjectListing.Lock()
+	mock.calls.GetProjectListing = append(mock.calls.GetProjectListing, callInfo)
+	mock.lockGetProjectListing.Unlock()
+	return mock.GetProjectListingFunc(ctx, projectID)
+}
+
+// GetProjectListingCalls gets all the calls that were made to GetProjectListing.
+// Check the length with:
+//
+//	len(mockedQuerier.GetProjectListingCalls())
+func (mock *QuerierMock) GetProjectListingCalls() []struct {
+	Ctx       context.Context
+	ProjectID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}
+	mock.lockGetProjectListing.RLock()
+	calls = mock.calls.GetProjectListing
+	mock.lockGetProjectListing.RUnlock()
+	return calls
+}
+
+// GetProjectRoomStyle calls GetProjectRoomStyleFunc.
+func (mock *QuerierMock) GetProjectRoomStyle(ctx context.Context, arg GetProjectRoomStyleParams) (string, error) {
+	if mock.GetProjectRoomStyleFunc == nil {
+		panic("QuerierMock.GetProjectRoomStyleFunc: method is nil but Querier.GetProjectRoomStyle was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg GetProjectRoomStyleParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockGetProjectRoomStyle.Lock()
+	mock.calls.GetProjectRoomStyle = append(mock.calls.GetProjectRoomStyle, callInfo)
+	mock.lockGetProjectRoomStyle.Unlock()
+	return mock.GetProjectRoomStyleFunc(ctx, arg)
+}
+
+// GetProjectRoomStyleCalls gets all the calls that were made to GetProjectRoomStyle.
+// Check the length with:
+//
+//	len(mockedQuerier.GetProjectRoomStyleCalls())
+func (mock *QuerierMock) GetProjectRoomStyleCalls() []struct {
+	Ctx context.Context
+	Arg GetProjectRoomStyleParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg GetProjectRoomStyleParams
+	}
+	mock.lockGetProjectRoomStyle.RLock()
+	calls = mock.calls.GetProjectRoomStyle
+	mock.lockGetProjectRoomStyle.RUnlock()
+	return calls
+}
+
+// GetProjectShareKey calls GetProjectShareKeyFunc.
+func (mock *QuerierMock) GetProjectShareKey(ctx context.Context, projectID pgtype.UUID) (*ProjectShareKey, error) {
+	if mock.GetProjectShareKeyFunc == nil {
+		panic("QuerierMock.GetProjectShareKeyFunc: method is nil but Querier.GetProjectShareKey was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockGetProjectShareKey.Lock()
+	mock.calls.GetProjectShareKey = append(mock.calls.GetProjectShareKey, callInfo)
+	mock.lockGetProjectShareKey.Unlock()
+	return mock.GetProjectShareKeyFunc(ctx, projectID)
+}
+
+// GetProjectShareKeyCalls gets all the calls that were made to GetProjectShareKey.
+// Check the length with:
+//
+//	len(mockedQuerier.GetProjectShareKeyCalls())
+func (mock *QuerierMock) GetProjectShareKeyCalls() []struct {
+	Ctx       context.Context
+	ProjectID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}
+	mock.lockGetProjectShareKey.RLock()
+	calls = mock.calls.GetProjectShareKey
+	mock.lockGetProjectShareKey.RUnlock()
+	return calls
+}
+
+// GetProjectSummary calls GetProjectSummaryFunc.
+func (mock *QuerierMock) GetProjectSummary(ctx context.Context, id pgtype.UUID) (*GetProjectSummaryRow, error) {
+	if mock.GetProjectSummaryFunc == nil {
+		panic("QuerierMock.GetProjectSummaryFunc: method is nil but Querier.GetProjectSummary was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetProjectSummary.Lock()
+	mock.calls.GetProjectSummary = append(mock.calls.GetProjectSummary, callInfo)
+	mock.lockGetProjectSummary.Unlock()
+	return mock.GetProjectSummaryFunc(ctx, id)
+}
+
+// GetProjectSummaryCalls gets all the calls that were made to GetProjectSummary.
+// Check the length with:
+//
+//	len(mockedQuerier.GetProjectSummaryCalls())
+func (mock *QuerierMock) GetProjectSummaryCalls() []struct {
+	Ctx context.Context
+	ID  pgtype.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}
+	mock.lockGetProjectSummary.RLock()
+	calls = mock.calls.GetProjectSummary
+	mock.lockGetProjectSummary.RUnlock()
+	return calls
+}
+
+// GetProjectTemplate calls GetProjectTemplateFunc.
+func (mock *QuerierMock) GetProjectTemplate(ctx context.Context, arg GetProjectTemplateParams) (*ProjectTemplate, error) {
+	if mock.GetProjectTemplateFunc == nil {
+		panic("QuerierMock.GetProjectTemplateFunc: method is nil but Querier.GetProjectTemplate was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg GetProjectTemplateParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockGetProjectTemplate.Lock()
+	mock.calls.GetProjectTemplate = append(mock.calls.GetProjectTemplate, callInfo)
+	mock.lockGetProjectTemplate.Unlock()
+	return mock.GetProjectTemplateFunc(ctx, arg)
+}
+
+// GetProjectTemplateCalls gets all the calls that were made to GetProjectTemplate.
+// Check the length with:
+//
+//	len(mockedQuerier.GetProjectTemplateCalls())
+func (mock *QuerierMock) GetProjectTemplateCalls() []struct {
+	Ctx context.Context
+	Arg GetProjectTemplateParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg GetProjectTemplateParams
+	}
+	mock.lockGetProjectTemplate.RLock()
+	calls = mock.calls.GetProjectTemplate
+	mock.lockGetProjectTemplate.RUnlock()
+	return calls
+}
+
+// GetProjectsByUserID calls GetProjectsByUserIDFunc.
+func (mock *QuerierMock) GetProjectsByUserID(ctx context.Context, userID pgtype.UUID) ([]*GetProjectsByUserIDRow, error) {
+	if mock.GetProjectsByUserIDFunc == nil {
+		panic("QuerierMock.GetProjectsByUserIDFunc: method is nil but Querier.GetProjectsByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetProjectsByUserID.Lock()
+	mock.calls.GetProjectsByUserID = append(mock.calls.GetProjectsByUserID, callInfo)
+	mock.lockGetProjectsByUserID.Unlock()
+	return mock.GetProjectsByUserIDFunc(ctx, userID)
+}
+
+// GetProjectsByUserIDCalls gets all the calls that were made to GetProjectsByUserID.
+// Check the length with:
+//
+//	len(mockedQuerier.GetProjectsByUserIDCalls())
+func (mock *QuerierMock) GetProjectsByUserIDCalls() []struct {
+	Ctx    context.Context
+	UserID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}
+	mock.lockGetProjectsByUserID.RLock()
+	calls = mock.calls.GetProjectsByUserID
+	mock.lockGetProjectsByUserID.RUnlock()
+	return calls
+}
+
+// GetReferenceImageAccess calls GetReferenceImageAccessFunc.
+func (mock *QuerierMock) GetReferenceImageAccess(ctx context.Context, id pgtype.UUID) (*GetReferenceImageAccessRow, error) {
+	if mock.GetReferenceImageAccessFunc == nil {
+		panic("QuerierMock.GetReferenceImageAccessFunc: method is nil but Querier.GetReferenceImageAccess was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetReferenceImageAccess.Lock()
+	mock.calls.GetReferenceImageAccess = append(mock.calls.GetReferenceImageAccess, callInfo)
+	mock.lockGetReferenceImageAccess.Unlock()
+	return mock.GetReferenceImageAccessFunc(ctx, id)
+}
+
+// GetReferenceImageAccessCalls gets all the calls that were made to GetReferenceImageAccess.
+// Check the length with:
+//
+//	len(mockedQuerier.GetReferenceImageAccessCalls())
+func (mock *QuerierMock) GetReferenceImageAccessCalls() []struct {
+	Ctx context.Context
+	ID  pgtype.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}
+	mock.lockGetReferenceImageAccess.RLock()
+	calls = mock.calls.GetReferenceImageAccess
+	mock.lockGetReferenceImageAccess.RUnlock()
+	return calls
+}
+
+// GetRoomGrouping calls GetRoomGroupingFunc.
+func (mock *QuerierMock) GetRoomGrouping(ctx context.Context, arg GetRoomGroupingParams) (*RoomGrouping, error) {
+	if mock.GetRoomGroupingFunc == nil {
+		panic("QuerierMock.GetRoomGroupingFunc: method is nil but Querier.GetRoomGrouping was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg GetRoomGroupingParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockGetRoomGrouping.Lock()
+	mock.calls.GetRoomGrouping = append(mock.calls.GetRoomGrouping, callInfo)
+	mock.lockGetRoomGrouping.Unlock()
+	return mock.GetRoomGroupingFunc(ctx, arg)
+}
+
+// GetRoomGroupingCalls gets all the calls that were made to GetRoomGrouping.
+// Check the length with:
+//
+//	len(mockedQuerier.GetRoomGroupingCalls())
+func (mock *QuerierMock) GetRoomGroupingCalls() []struct {
+	Ctx context.Context
+	Arg GetRoomGroupingParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg GetRoomGroupingParams
+	}
+	mock.lockGetRoomGrouping.RLock()
+	calls = mock.calls.GetRoomGrouping
+	mock.lockGetRoomGrouping.RUnlock()
+	return calls
+}
+
+// GetSCIMGroup calls GetSCIMGroupFunc.
+func (mock *QuerierMock) GetSCIMGroup(ctx context.Context, arg GetSCIMGroupParams) (*OrganizationScimGroup, error) {
+	if mock.GetSCIMGroupFunc == nil {
+		panic("QuerierMock.GetSCIMGroupFunc: method is nil but Querier.GetSCIMGroup was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg GetSCIMGroupParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockGetSCIMGroup.Lock()
+	mock.calls.GetSCIMGroup = append(mock.calls.GetSCIMGroup, callInfo)
+	mock.lockGetSCIMGroup.Unlock()
+	return mock.GetSCIMGroupFunc(ctx, arg)
+}
+
+// GetSCIMGroupCalls gets all the calls that were made to GetSCIMGroup.
+// Check the length with:
+//
+//	len(mockedQuerier.GetSCIMGroupCalls())
+func (mock *QuerierMock) GetSCIMGroupCalls() []struct {
+	Ctx context.Context
+	Arg GetSCIMGroupParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg GetSCIMGroupParams
+	}
+	mock.lockGetSCIMGroup.RLock()
+	calls = mock.calls.GetSCIMGroup
+	mock.lockGetSCIMGroup.RUnlock()
+	return calls
+}
+
+// GetSCIMUser calls GetSCIMUserFunc.
+func (mock *QuerierMock) GetSCIMUser(ctx context.Context, arg GetSCIMUserParams) (*OrganizationScimUser, error) {
+	if mock.GetSCIMUserFunc == nil {
+		panic("QuerierMock.GetSCIMUserFunc: method is nil but Querier.GetSCIMUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg GetSCIMUserParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockGetSCIMUser.Lock()
+	mock.calls.GetSCIMUser = append(mock.calls.GetSCIMUser, callInfo)
+	mock.lockGetSCIMUser.Unlock()
+	return mock.GetSCIMUserFunc(ctx, arg)
+}
+
+// GetSCIMUserCalls gets all the calls that were made to GetSCIMUser.
+// Check the length with:
+//
+//	len(mockedQuerier.GetSCIMUserCalls())
+func (mock *QuerierMock) GetSCIMUserCalls() []struct {
+	Ctx context.Context
+	Arg GetSCIMUserParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg GetSCIMUserParams
+	}
+	mock.lockGetSCIMUser.RLock()
+	calls = mock.calls.GetSCIMUser
+	mock.lockGetSCIMUser.RUnlock()
+	return calls
+}
+
+// GetSetting calls GetSettingFunc.
+func (mock *QuerierMock) GetSetting(ctx context.Context, key string) (*Setting, error) {
+	if mock.GetSettingFunc == nil {
+		panic("QuerierMock.GetSettingFunc: method is nil but Querier.GetSetting was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockGetSetting.Lock()
+	mock.calls.GetSetting = append(mock.calls.GetSetting, callInfo)
+	mock.lockGetSetting.Unlock()
+	return mock.GetSettingFunc(ctx, key)
+}
+
+// GetSettingCalls gets all the calls that were made to GetSetting.
+// Check the length with:
+//
+//	len(mockedQuerier.GetSettingCalls())
+func (mock *QuerierMock) GetSettingCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockGetSetting.RLock()
+	calls = mock.calls.GetSetting
+	mock.lockGetSetting.RUnlock()
+	return calls
+}
+
+// GetShareBranding calls GetShareBrandingFunc.
+func (mock *QuerierMock) GetShareBranding(ctx context.Context, userID pgtype.UUID) (*ShareBranding, error) {
+	if mock.GetShareBrandingFunc == nil {
+		panic("QuerierMock.GetShareBrandingFunc: method is nil but Querier.GetShareBranding was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetShareBranding.Lock()
+	mock.calls.GetShareBranding = append(mock.calls.GetShareBranding, callInfo)
+	mock.lockGetShareBranding.Unlock()
+	return mock.GetShareBrandingFunc(ctx, userID)
+}
+
+// GetShareBrandingCalls gets all the calls that were made to GetShareBranding.
+// Check the length with:
+//
+//	len(mockedQuerier.GetShareBrandingCalls())
+func (mock *QuerierMock) GetShareBrandingCalls() []struct {
+	Ctx    context.Context
+	UserID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}
+	mock.lockGetShareBranding.RLock()
+	calls = mock.calls.GetShareBranding
+	mock.lockGetShareBranding.RUnlock()
+	return calls
+}
+
+// GetShareBrandingByVerifiedDomain calls GetShareBrandingByVerifiedDomainFunc.
+func (mock *QuerierMock) GetShareBrandingByVerifiedDomain(ctx context.Context, customDomain pgtype.Text) (*ShareBranding, error) {
+	if mock.GetShareBrandingByVerifiedDomainFunc == nil {
+		panic("QuerierMock.GetShareBrandingByVerifiedDomainFunc: method is nil but Querier.GetShareBrandingByVerifiedDomain was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		CustomDomain pgtype.Text
+	}{
+		Ctx:          ctx,
+		CustomDomain: customDomain,
+	}
+	mock.lockGetShareBrandingByVerifiedDomain.Lock()
+	mock.calls.GetShareBrandingByVerifiedDomain = append(mock.calls.GetShareBrandingByVerifiedDomain, callInfo)
+	mock.lockGetShareBrandingByVerifiedDomain.Unlock()
+	return mock.GetShareBrandingByVerifiedDomainFunc(ctx, customDomain)
+}
+
+// GetShareBrandingByVerifiedDomainCalls gets all the calls that were made to GetShareBrandingByVerifiedDomain.
+// Check the length with:
+//
+//	len(mockedQuerier.GetShareBrandingByVerifiedDomainCalls())
+func (mock *QuerierMock) GetShareBrandingByVerifiedDomainCalls() []struct {
+	Ctx          context.Context
+	CustomDomain pgtype.Text
+} {
+	var calls []struct {
+		Ctx          context.Context
+		CustomDomain pgtype.Text
+	}
+	mock.lockGetShareBrandingByVerifiedDomain.RLock()
+	calls = mock.calls.GetShareBrandingByVerifiedDomain
+	mock.lockGetShareBrandingByVerifiedDomain.RUnlock()
+	return calls
+}
+
+// GetStylePopularity calls GetStylePopularityFunc.
+func (mock *QuerierMock) GetStylePopularity(ctx context.Context, arg GetStylePopularityParams) ([]*GetStylePopularityRow, error) {
+	if mock.GetStylePopularityFunc == nil {
+		panic("QuerierMock.GetStylePopularityFunc: method is nil but Querier.GetStylePopularity was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg GetStylePopularityParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockGetStylePopularity.Lock()
+	mock.calls.GetStylePopularity = append(mock.calls.GetStylePopularity, callInfo)
+	mock.lockGetStylePopularity.Unlock()
+	return mock.GetStylePopularityFunc(ctx, arg)
+}
+
+// GetStylePopularityCalls gets all the calls that were made to GetStylePopularity.
+// Check the length with:
+//
+//	len(mockedQuerier.GetStylePopularityCalls())
+func (mock *QuerierMock) GetStylePopularityCalls() []struct {
+	Ctx context.Context
+	Arg GetStylePopularityParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg GetStylePopularityParams
+	}
+	mock.lockGetStylePopularity.RLock()
+	calls = mock.calls.GetStylePopularity
+	mock.lockGetStylePopularity.RUnlock()
+	return calls
+}
+
+// GetSubscriptionByStripeID calls GetSubscriptionByStripeIDFunc.
+func (mock *QuerierMock) GetSubscriptionByStripeID(ctx context.Context, stripeSubscriptionID string) (*Subscription, error) {
+	if mock.GetSubscriptionByStripeIDFunc == nil {
+		panic("QuerierMock.GetSubscriptionByStripeIDFunc: method is nil but Querier.GetSubscriptionByStripeID was just called")
+	}
+	callInfo := struct {
+		Ctx                  context.Context
+		StripeSubscriptionID string
+	}{
+		Ctx:                  ctx,
+		StripeSubscriptionID: stripeSubscriptionID,
+	}
+	mock.lockGetSubscriptionByStripeID.Lock()
+	mock.calls.GetSubscriptionByStripeID = append(mock.calls.GetSubscriptionByStripeID, callInfo)
+	mock.lockGetSubscriptionByStripeID.Unlock()
+	return mock.GetSubscriptionByStripeIDFunc(ctx, stripeSubscriptionID)
+}
+
+// GetSubscriptionByStripeIDCalls gets all the calls that were made to GetSubscriptionByStripeID.
+// Check the length with:
+//
+//	len(mockedQuerier.GetSubscriptionByStripeIDCalls())
+func (mock *QuerierMock) GetSubscriptionByStripeIDCalls() []struct {
+	Ctx                  context.Context
+	StripeSubscriptionID string
+} {
+	var calls []struct {
+		Ctx                  context.Context
+		StripeSubscriptionID string
+	}
+	mock.lockGetSubscriptionByStripeID.RLock()
+	calls = mock.calls.GetSubscriptionByStripeID
+	mock.lockGetSubscriptionByStripeID.RUnlock()
+	return calls
+}
+
+// GetTeamWebhook calls GetTeamWebhookFunc.
+func (mock *QuerierMock) GetTeamWebhook(ctx context.Context, arg GetTeamWebhookParams) (*TeamWebhook, error) {
+	if mock.GetTeamWebhookFunc == nil {
+		panic("QuerierMock.GetTeamWebhookFunc: method is nil but Querier.GetTeamWebhook was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg GetTeamWebhookParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockGetTeamWebhook.Lock()
+	mock.calls.GetTeamWebhook = append(mock.calls.GetTeamWebhook, callInfo)
+	mock.lockGetTeamWebhook.Unlock()
+	return mock.GetTeamWebhookFunc(ctx, arg)
+}
+
+// GetTeamWebhookCalls gets all the calls that were made to GetTeamWebhook.
+// Check the length with:
+//
+//	len(mockedQuerier.GetTeamWebhookCalls())
+func (mock *QuerierMock) GetTeamWebhookCalls() []struct {
+	Ctx context.Context
+	Arg GetTeamWebhookParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg GetTeamWebhookParams
+	}
+	mock.lockGetTeamWebhook.RLock()
+	calls = mock.calls.GetTeamWebhook
+	mock.lockGetTeamWebhook.RUnlock()
+	return calls
+}
+
+// GetTeamWebhookDelivery calls GetTeamWebhookDeliveryFunc.
+func (mock *QuerierMock) GetTeamWebhookDelivery(ctx context.Context, arg GetTeamWebhookDeliveryParams) (*TeamWebhookDelivery, error) {
+	if mock.GetTeamWebhookDeliveryFunc == nil {
+		panic("QuerierMock.GetTeamWebhookDeliveryFunc: method is nil but Querier.GetTeamWebhookDelivery was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg GetTeamWebhookDeliveryParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockGetTeamWebhookDelivery.Lock()
+	mock.calls.GetTeamWebhookDelivery = append(mock.calls.GetTeamWebhookDelivery, callInfo)
+	mock.lockGetTeamWebhookDelivery.Unlock()
+	return mock.GetTeamWebhookDeliveryFunc(ctx, arg)
+}
+
+// GetTeamWebhookDeliveryCalls gets all the calls that were made to GetTeamWebhookDelivery.
+// Check the length with:
+//
+//	len(mockedQuerier.GetTeamWebhookDeliveryCalls())
+func (mock *QuerierMock) GetTeamWebhookDeliveryCalls() []struct {
+	Ctx context.Context
+	Arg GetTeamWebhookDeliveryParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg GetTeamWebhookDeliveryParams
+	}
+	mock.lockGetTeamWebhookDelivery.RLock()
+	calls = mock.calls.GetTeamWebhookDelivery
+	mock.lockGetTeamWebhookDelivery.RUnlock()
+	return calls
+}
+
+// GetTurnaroundSLAByPlan calls GetTurnaroundSLAByPlanFunc.
+func (mock *QuerierMock) GetTurnaroundSLAByPlan(ctx context.Context, arg GetTurnaroundSLAByPlanParams) ([]*GetTurnaroundSLAByPlanRow, error) {
+	if mock.GetTurnaroundSLAByPlanFunc == nil {
+		panic("QuerierMock.GetTurnaroundSLAByPlanFunc: method is nil but Querier.GetTurnaroundSLAByPlan was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg GetTurnaroundSLAByPlanParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockGetTurnaroundSLAByPlan.Lock()
+	mock.calls.GetTurnaroundSLAByPlan = append(mock.calls.GetTurnaroundSLAByPlan, callInfo)
+	mock.lockGetTurnaroundSLAByPlan.Unlock()
+	return mock.GetTurnaroundSLAByPlanFunc(ctx, arg)
+}
+
+// GetTurnaroundSLAByPlanCalls gets all the calls that were made to GetTurnaroundSLAByPlan.
+// Check the length with:
+//
+//	len(mockedQuerier.GetTurnaroundSLAByPlanCalls())
+func (mock *QuerierMock) GetTurnaroundSLAByPlanCalls() []struct {
+	Ctx context.Context
+	Arg GetTurnaroundSLAByPlanParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg GetTurnaroundSLAByPlanParams
+	}
+	mock.lockGetTurnaroundSLAByPlan.RLock()
+	calls = mock.calls.GetTurnaroundSLAByPlan
+	mock.lockGetTurnaroundSLAByPlan.RUnlock()
+	return calls
+}
+
+// GetUsage calls GetUsageFunc.
+func (mock *QuerierMock) GetUsage(ctx context.Context, arg GetUsageParams) (int32, error) {
+	if mock.GetUsageFunc == nil {
+		panic("QuerierMock.GetUsageFunc: method is nil but Querier.GetUsage was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg GetUsageParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockGetUsage.Lock()
+	mock.calls.GetUsage = append(mock.calls.GetUsage, callInfo)
+	mock.lockGetUsage.Unlock()
+	return mock.GetUsageFunc(ctx, arg)
+}
+
+// GetUsageCalls gets all the calls that were made to GetUsage.
+// Check the length with:
+//
+//	len(mockedQuerier.GetUsageCalls())
+func (mock *QuerierMock) GetUsageCalls() []struct {
+	Ctx context.Context
+	Arg GetUsageParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg GetUsageParams
+	}
+	mock.lockGetUsage.RLock()
+	calls = mock.calls.GetUsage
+	mock.lockGetUsage.RUnlock()
+	return calls
+}
+
+// GetUserBillingPeriod calls GetUserBillingPeriodFunc.
+func (mock *QuerierMock) GetUserBillingPeriod(ctx context.Context, userID pgtype.UUID) (*GetUserBillingPeriodRow, error) {
+	if mock.GetUserBillingPeriodFunc == nil {
+		panic("QuerierMock.GetUserBillingPeriodFunc: method is nil but Querier.GetUserBillingPeriod was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetUserBillingPeriod.Lock()
+	mock.calls.GetUserBillingPeriod = append(mock.calls.GetUserBillingPeriod, callInfo)
+	mock.lockGetUserBillingPeriod.Unlock()
+	return mock.GetUserBillingPeriodFunc(ctx, userID)
+}
+
+// GetUserBillingPeriodCalls gets all the calls that were made to GetUserBillingPeriod.
+// Check the length with:
+//
+//	len(mockedQuerier.GetUserBillingPeriodCalls())
+func (mock *QuerierMock) GetUserBillingPeriodCalls() []struct {
+	Ctx    context.Context
+	UserID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}
+	mock.lockGetUserBillingPeriod.RLock()
+	calls = mock.calls.GetUserBillingPeriod
+	mock.lockGetUserBillingPeriod.RUnlock()
+	return calls
+}
+
+// GetUserByAuth0Sub calls GetUserByAuth0SubFunc.
+func (mock *QuerierMock) GetUserByAuth0Sub(ctx context.Context, auth0Sub string) (*GetUserByAuth0SubRow, error) {
+	if mock.GetUserByAuth0SubFunc == nil {
+		panic("QuerierMock.GetUserByAuth0SubFunc: method is nil but Querier.GetUserByAuth0Sub was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Auth0Sub string
+	}{
+		Ctx:      ctx,
+		Auth0Sub: auth0Sub,
+	}
+	mock.lockGetUserByAuth0Sub.Lock()
+	mock.calls.GetUserByAuth0Sub = append(mock.calls.GetUserByAuth0Sub, callInfo)
+	mock.lockGetUserByAuth0Sub.Unlock()
+	return mock.GetUserByAuth0SubFunc(ctx, auth0Sub)
+}
+
+// GetUserByAuth0SubCalls gets all the calls that were made to GetUserByAuth0Sub.
+// Check the length with:
+//
+//	len(mockedQuerier.GetUserByAuth0SubCalls())
+func (mock *QuerierMock) GetUserByAuth0SubCalls() []struct {
+	Ctx      context.Context
+	Auth0Sub string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Auth0Sub string
+	}
+	mock.lockGetUserByAuth0Sub.RLock()
+	calls = mock.calls.GetUserByAuth0Sub
+	mock.lockGetUserByAuth0Sub.RUnlock()
+	return calls
+}
+
+// GetUserByID calls GetUserByIDFunc.
+func (mock *QuerierMock) GetUserByID(ctx context.Context, id pgtype.UUID) (*GetUserByIDRow, error) {
+	if mock.GetUserByIDFunc == nil {
+		panic("QuerierMock.GetUserByIDFunc: method is nil but Querier.GetUserByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetUserByID.Lock()
+	mock.calls.GetUserByID = append(mock.calls.GetUserByID, callInfo)
+	mock.lockGetUserByID.Unlock()
+	return mock.GetUserByIDFunc(ctx, id)
+}
+
+// GetUserByIDCalls gets all the calls that were made to GetUserByID.
+// Check the length with:
+//
+//	len(mockedQuerier.GetUserByIDCalls())
+func (mock *QuerierMock) GetUserByIDCalls() []struct {
+	Ctx context.Context
+	ID  pgtype.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}
+	mock.lockGetUserByID.RLock()
+	calls = mock.calls.GetUserByID
+	mock.lockGetUserByID.RUnlock()
+	return calls
+}
+
+// GetUserByStripeCustomerID calls GetUserByStripeCustomerIDFunc.
+func (mock *QuerierMock) GetUserByStripeCustomerID(ctx context.Context, stripeCustomerID pgtype.Text) (*GetUserByStripeCustomerIDRow, error) {
+	if mock.GetUserByStripeCustomerIDFunc == nil {
+		panic("QuerierMock.GetUserByStripeCustomerIDFunc: method is nil but Querier.GetUserByStripeCustomerID was just called")
+	}
+	callInfo := struct {
+		Ctx              context.Context
+		StripeCustomerID pgtype.Text
+	}{
+		Ctx:              ctx,
+		StripeCustomerID: stripeCustomerID,
+	}
+	mock.lockGetUserByStripeCustomerID.Lock()
+	mock.calls.GetUserByStripeCustomerID = append(mock.calls.GetUserByStripeCustomerID, callInfo)
+	mock.lockGetUserByStripeCustomerID.Unlock()
+	return mock.GetUserByStripeCustomerIDFunc(ctx, stripeCustomerID)
+}
+
+// GetUserByStripeCustomerIDCalls gets all the calls that were made to GetUserByStripeCustomerID.
+// Check the length with:
+//
+//	len(mockedQuerier.GetUserByStripeCustomerIDCalls())
+func (mock *QuerierMock) GetUserByStripeCustomerIDCalls() []struct {
+	Ctx              context.Context
+	StripeCustomerID pgtype.Text
+} {
+	var calls []struct {
+		Ctx              context.Context
+		StripeCustomerID pgtype.Text
+	}
+	mock.lockGetUserByStripeCustomerID.RLock()
+	calls = mock.calls.GetUserByStripeCustomerID
+	mock.lockGetUserByStripeCustomerID.RUnlock()
+	return calls
+}
+
+// GetUserByStripeCustomerIDHash calls GetUserByStripeCustomerIDHashFunc.
+func (mock *QuerierMock) GetUserByStripeCustomerIDHash(ctx context.Context, stripeCustomerIDHash pgtype.Text) (*GetUserByStripeCustomerIDHashRow, error) {
+	if mock.GetUserByStripeCustomerIDHashFunc == nil {
+		panic("QuerierMock.GetUserByStripeCustomerIDHashFunc: method is nil but Querier.GetUserByStripeCustomerIDHash was just called")
+	}
+	callInfo := struct {
+		Ctx                  context.Context
+		StripeCustomerIDHash pgtype.Text
+	}{
+		Ctx:                  ctx,
+		StripeCustomerIDHash: stripeCustomerIDHash,
+	}
+	mock.lockGetUserByStripeCustomerIDHash.Lock()
+	mock.calls.GetUserByStripeCustomerIDHash = append(mock.calls.GetUserByStripeCustomerIDHash, callInfo)
+	mock.lockGetUserByStripeCustomerIDHash.Unlock()
+	return mock.GetUserByStripeCustomerIDHashFunc(ctx, stripeCustomerIDHash)
+}
+
+// GetUserByStripeCustomerIDHashCalls gets all the calls that were made to GetUserByStripeCustomerIDHash.
+// Check the length with:
+//
+//	len(mockedQuerier.GetUserByStripeCustomerIDHashCalls())
+func (mock *QuerierMock) GetUserByStripeCustomerIDHashCalls() []struct {
+	Ctx                  context.Context
+	StripeCustomerIDHash pgtype.Text
+} {
+	var calls []struct {
+		Ctx                  context.Context
+		StripeCustomerIDHash pgtype.Text
+	}
+	mock.lockGetUserByStripeCustomerIDHash.RLock()
+	calls = mock.calls.GetUserByStripeCustomerIDHash
+	mock.lockGetUserByStripeCustomerIDHash.RUnlock()
+	return calls
+}
+
+// GetUserEntitlements calls GetUserEntitlementsFunc.
+func (mock *QuerierMock) GetUserEntitlements(ctx context.Context, userID pgtype.UUID) (*GetUserEntitlementsRow, error) {
+	if mock.GetUserEntitlementsFunc == nil {
+		panic("QuerierMock.GetUserEntitlementsFunc: method is nil but Querier.GetUserEntitlements was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetUserEntitlements.Lock()
+	mock.calls.GetUserEntitlements = append(mock.calls.GetUserEntitlements, callInfo)
+	mock.lockGetUserEntitlements.Unlock()
+	return mock.GetUserEntitlementsFunc(ctx, userID)
+}
+
+// GetUserEntitlementsCalls gets all the calls that were made to GetUserEntitlements.
+// Check the length with:
+//
+//	len(mockedQuerier.GetUserEntitlementsCalls())
+func (mock *QuerierMock) GetUserEntitlementsCalls() []struct {
+	Ctx    context.Context
+	UserID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}
+	mock.lockGetUserEntitlements.RLock()
+	calls = mock.calls.GetUserEntitlements
+	mock.lockGetUserEntitlements.RUnlock()
+	return calls
+}
+
+// GetUserProfileByAuth0Sub calls GetUserProfileByAuth0SubFunc.
+func (mock *QuerierMock) GetUserProfileByAuth0Sub(ctx context.Context, auth0Sub string) (*GetUserProfileByAuth0SubRow, error) {
+	if mock.GetUserProfileByAuth0SubFunc == nil {
+		panic("QuerierMock.GetUserProfileByAuth0SubFunc: method is nil but Querier.GetUserProfileByAuth0Sub was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Auth0Sub string
+	}{
+		Ctx:      ctx,
+		Auth0Sub: auth0Sub,
+	}
+	mock.lockGetUserProfileByAuth0Sub.Lock()
+	mock.calls.GetUserProfileByAuth0Sub = append(mock.calls.GetUserProfileByAuth0Sub, callInfo)
+	mock.lockGetUserProfileByAuth0Sub.Unlock()
+	return mock.GetUserProfileByAuth0SubFunc(ctx, auth0Sub)
+}
+
+// GetUserProfileByAuth0SubCalls gets all the calls that were made to GetUserProfileByAuth0Sub.
+// Check the length with:
+//
+//	len(mockedQuerier.GetUserProfileByAuth0SubCalls())
+func (mock *QuerierMock) GetUserProfileByAuth0SubCalls() []struct {
+	Ctx      context.Context
+	Auth0Sub string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Auth0Sub string
+	}
+	mock.lockGetUserProfileByAuth0Sub.RLock()
+	calls = mock.calls.GetUserProfileByAuth0Sub
+	mock.lockGetUserProfileByAuth0Sub.RUnlock()
+	return calls
+}
+
+// GetUserProfileByID calls GetUserProfileByIDFunc.
+func (mock *QuerierMock) GetUserProfileByID(ctx context.Context, id pgtype.UUID) (*GetUserProfileByIDRow, error) {
+	if mock.GetUserProfileByIDFunc == nil {
+		panic("QuerierMock.GetUserProfileByIDFunc: method is nil but Querier.GetUserProfileByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetUserProfileByID.Lock()
+	mock.calls.GetUserProfileByID = append(mock.calls.GetUserProfileByID, callInfo)
+	mock.lockGetUserProfileByID.Unlock()
+	return mock.GetUserProfileByIDFunc(ctx, id)
+}
+
+// GetUserProfileByIDCalls gets all the calls that were made to GetUserProfileByID.
+// Check the length with:
+//
+//	len(mockedQuerier.GetUserProfileByIDCalls())
+func (mock *QuerierMock) GetUserProfileByIDCalls() []struct {
+	Ctx context.Context
+	ID  pgtype.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}
+	mock.lockGetUserProfileByID.RLock()
+	calls = mock.calls.GetUserProfileByID
+	mock.lockGetUserProfileByID.RUnlock()
+	return calls
+}
+
+// GetUserStorageRegion calls GetUserStorageRegionFunc.
+func (mock *QuerierMock) GetUserStorageRegion(ctx context.Context, id pgtype.UUID) (pgtype.Text, error) {
+	if mock.GetUserStorageRegionFunc == nil {
+		panic("QuerierMock.GetUserStorageRegionFunc: method is nil but Querier.GetUserStorageRegion was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetUserStorageRegion.Lock()
+	mock.calls.GetUserStorageRegion = append(mock.calls.GetUserStorageRegion, callInfo)
+	mock.lockGetUserStorageRegion.Unlock()
+	return mock.GetUserStorageRegionFunc(ctx, id)
+}
+
+// GetUserStorageRegionCalls gets all the calls that were made to GetUserStorageRegion.
+// Check the length with:
+//
+//	len(mockedQuerier.GetUserStorageRegionCalls())
+func (mock *QuerierMock) GetUserStorageRegionCalls() []struct {
+	Ctx context.Context
+	ID  pgtype.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}
+	mock.lockGetUserStorageRegion.RLock()
+	calls = mock.calls.GetUserStorageRegion
+	mock.lockGetUserStorageRegion.RUnlock()
+	return calls
+}
+
+// HasActiveRoomGrouping calls HasActiveRoomGroupingFunc.
+func (mock *QuerierMock) HasActiveRoomGrouping(ctx context.Context, projectID pgtype.UUID) (bool, error) {
+	if mock.HasActiveRoomGroupingFunc == nil {
+		panic("QuerierMock.HasActiveRoomGroupingFunc: method is nil but Querier.HasActiveRoomGrouping was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockHasActiveRoomGrouping.Lock()
+	mock.calls.HasActiveRoomGrouping = append(mock.calls.HasActiveRoomGrouping, callInfo)
+	mock.lockHasActiveRoomGrouping.Unlock()
+	return mock.HasActiveRoomGroupingFunc(ctx, projectID)
+}
+
+// HasActiveRoomGroupingCalls gets all the calls that were made to HasActiveRoomGrouping.
+// Check the length with:
+//
+//	len(mockedQuerier.HasActiveRoomGroupingCalls())
+func (mock *QuerierMock) HasActiveRoomGroupingCalls() []struct {
+	Ctx       context.Context
+	ProjectID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}
+	mock.lockHasActiveRoomGrouping.RLock()
+	calls = mock.calls.HasActiveRoomGrouping
+	mock.lockHasActiveRoomGrouping.RUnlock()
+	return calls
+}
+
+// IsImageUnderLegalHold calls IsImageUnderLegalHoldFunc.
+func (mock *QuerierMock) IsImageUnderLegalHold(ctx context.Context, id pgtype.UUID) (bool, error) {
+	if mock.IsImageUnderLegalHoldFunc == nil {
+		panic("QuerierMock.IsImageUnderLegalHoldFunc: method is nil but Querier.IsImageUnderLegalHold was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockIsImageUnderLegalHold.Lock()
+	mock.calls.IsImageUnderLegalHold = append(mock.calls.IsImageUnderLegalHold, callInfo)
+	mock.lockIsImageUnderLegalHold.Unlock()
+	return mock.IsImageUnderLegalHoldFunc(ctx, id)
+}
+
+// IsImageUnderLegalHoldCalls gets all the calls that were made to IsImageUnderLegalHold.
+// Check the length with:
+//
+//	len(mockedQuerier.IsImageUnderLegalHoldCalls())
+func (mock *QuerierMock) IsImageUnderLegalHoldCalls() []struct {
+	Ctx context.Context
+	ID  pgtype.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}
+	mock.lockIsImageUnderLegalHold.RLock()
+	calls = mock.calls.IsImageUnderLegalHold
+	mock.lockIsImageUnderLegalHold.RUnlock()
+	return calls
+}
+
+// IsProjectRetentionExempt calls IsProjectRetentionExemptFunc.
+func (mock *QuerierMock) IsProjectRetentionExempt(ctx context.Context, projectID pgtype.UUID) (bool, error) {
+	if mock.IsProjectRetentionExemptFunc == nil {
+		panic("QuerierMock.IsProjectRetentionExemptFunc: method is nil but Querier.IsProjectRetentionExempt was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockIsProjectRetentionExempt.Lock()
+	mock.calls.IsProjectRetentionExempt = append(mock.calls.IsProjectRetentionExempt, callInfo)
+	mock.lockIsProjectRetentionExempt.Unlock()
+	return mock.IsProjectRetentionExemptFunc(ctx, projectID)
+}
+
+// IsProjectRetentionExemptCalls gets all the calls that were made to IsProjectRetentionExempt.
+// Check the length with:
+//
+//	len(mockedQuerier.IsProjectRetentionExemptCalls())
+func (mock *QuerierMock) IsProjectRetentionExemptCalls() []struct {
+	Ctx       context.Context
+	ProjectID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}
+	mock.lockIsProjectRetentionExempt.RLock()
+	calls = mock.calls.IsProjectRetentionExempt
+	mock.lockIsProjectRetentionExempt.RUnlock()
+	return calls
+}
+
+// IsProjectUnderLegalHold calls IsProjectUnderLegalHoldFunc.
+func (mock *QuerierMock) IsProjectUnderLegalHold(ctx context.Context, projectID pgtype.UUID) (bool, error) {
+	if mock.IsProjectUnderLegalHoldFunc == nil {
+		panic("QuerierMock.IsProjectUnderLegalHoldFunc: method is nil but Querier.IsProjectUnderLegalHold was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockIsProjectUnderLegalHold.Lock()
+	mock.calls.IsProjectUnderLegalHold = append(mock.calls.IsProjectUnderLegalHold, callInfo)
+	mock.lockIsProjectUnderLegalHold.Unlock()
+	return mock.IsProjectUnderLegalHoldFunc(ctx, projectID)
+}
+
+// IsProjectUnderLegalHoldCalls gets all the calls that were made to IsProjectUnderLegalHold.
+// Check the length with:
+//
+//	len(mockedQuerier.IsProjectUnderLegalHoldCalls())
+func (mock *QuerierMock) IsProjectUnderLegalHoldCalls() []struct {
+	Ctx       context.Context
+	ProjectID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}
+	mock.lockIsProjectUnderLegalHold.RLock()
+	calls = mock.calls.IsProjectUnderLegalHold
+	mock.lockIsProjectUnderLegalHold.RUnlock()
+	return calls
+}
+
+// ListAPIKeysByUser calls ListAPIKeysByUserFunc.
+func (mock *QuerierMock) ListAPIKeysByUser(ctx context.Context, userID pgtype.UUID) ([]*ApiKey, error) {
+	if mock.ListAPIKeysByUserFunc == nil {
+		panic("QuerierMock.ListAPIKeysByUserFunc: method is nil but Querier.ListAPIKeysByUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockListAPIKeysByUser.Lock()
+	mock.calls.ListAPIKeysByUser = append(mock.calls.ListAPIKeysByUser, callInfo)
+	mock.lockListAPIKeysByUser.Unlock()
+	return mock.ListAPIKeysByUserFunc(ctx, userID)
+}
+
+// ListAPIKeysByUserCalls gets all the calls that were made to ListAPIKeysByUser.
+// Check the length with:
+//
+//	len(mockedQuerier.ListAPIKeysByUserCalls())
+func (mock *QuerierMock) ListAPIKeysByUserCalls() []struct {
+	Ctx    context.Context
+	UserID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}
+	mock.lockListAPIKeysByUser.RLock()
+	calls = mock.calls.ListAPIKeysByUser
+	mock.lockListAPIKeysByUser.RUnlock()
+	return calls
+}
+
+// ListActiveCatalogs calls ListActiveCatalogsFunc.
+func (mock *QuerierMock) ListActiveCatalogs(ctx context.Context) ([]*Catalog, error) {
+	if mock.ListActiveCatalogsFunc == nil {
+		panic("QuerierMock.ListActiveCatalogsFunc: method is nil but Querier.ListActiveCatalogs was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockListActiveCatalogs.Lock()
+	mock.calls.ListActiveCatalogs = append(mock.calls.ListActiveCatalogs, callInfo)
+	mock.lockListActiveCatalogs.Unlock()
+	return mock.ListActiveCatalogsFunc(ctx)
+}
+
+// ListActiveCatalogsCalls gets all the calls that were made to ListActiveCatalogs.
+// Check the length with:
+//
+//	len(mockedQuerier.ListActiveCatalogsCalls())
+func (mock *QuerierMock) ListActiveCatalogsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockListActiveCatalogs.RLock()
+	calls = mock.calls.ListActiveCatalogs
+	mock.lockListActiveCatalogs.RUnlock()
+	return calls
+}
+
+// ListCatalogs calls ListCatalogsFunc.
+func (mock *QuerierMock) ListCatalogs(ctx context.Context) ([]*Catalog, error) {
+	if mock.ListCatalogsFunc == nil {
+		panic("QuerierMock.ListCatalogsFunc: method is nil but Querier.ListCatalogs was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockListCatalogs.Lock()
+	mock.calls.ListCatalogs = append(mock.calls.ListCatalogs, callInfo)
+	mock.lockListCatalogs.Unlock()
+	return mock.ListCatalogsFunc(ctx)
+}
+
+// ListCatalogsCalls gets all the calls that were made to ListCatalogs.
+// Check the length with:
+//
+//	len(mockedQuerier.ListCatalogsCalls())
+func (mock *QuerierMock) ListCatalogsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockListCatalogs.RLock()
+	calls = mock.calls.ListCatalogs
+	mock.lockListCatalogs.RUnlock()
+	return calls
+}
+
+// ListDataAccessByOwner calls ListDataAccessByOwnerFunc.
+func (mock *QuerierMock) ListDataAccessByOwner(ctx context.Context, arg ListDataAccessByOwnerParams) ([]*DataAccessLog, error) {
+	if mock.ListDataAccessByOwnerFunc == nil {
+		panic("QuerierMock.ListDataAccessByOwnerFunc: method is nil but Querier.ListDataAccessByOwner was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg ListDataAccessByOwnerParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockListDataAccessByOwner.Lock()
+	mock.calls.ListDataAccessByOwner = append(mock.calls.ListDataAccessByOwner, callInfo)
+	mock.lockListDataAccessByOwner.Unlock()
+	return mock.ListDataAccessByOwnerFunc(ctx, arg)
+}
+
+// ListDataAccessByOwnerCalls gets all the calls that were made to ListDataAccessByOwner.
+// Check the length with:
+//
+//	len(mockedQuerier.ListDataAccessByOwnerCalls())
+func (mock *QuerierMock) ListDataAccessByOwnerCalls() []struct {
+	Ctx context.Context
+	Arg ListDataAccessByOwnerParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg ListDataAccessByOwnerParams
+	}
+	mock.lockListDataAccessByOwner.RLock()
+	calls = mock.calls.ListDataAccessByOwner
+	mock.lockListDataAccessByOwner.RUnlock()
+	return calls
+}
+
+// ListDeadLetterJobs calls ListDeadLetterJobsFunc.
+func (mock *QuerierMock) ListDeadLetterJobs(ctx context.Context, arg ListDeadLetterJobsParams) ([]*DeadLetterJob, error) {
+	if mock.ListDeadLetterJobsFunc == nil {
+		panic("QuerierMock.ListDeadLetterJobsFunc: method is nil but Querier.ListDeadLetterJobs was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg ListDeadLetterJobsParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockListDeadLetterJobs.Lock()
+	mock.calls.ListDeadLetterJobs = append(mock.calls.ListDeadLetterJobs, callInfo)
+	mock.lockListDeadLetterJobs.Unlock()
+	return mock.ListDeadLetterJobsFunc(ctx, arg)
+}
+
+// ListDeadLetterJobsCalls gets all the calls that were made to ListDeadLetterJobs.
+// Check the length with:
+//
+//	len(mockedQuerier.ListDeadLetterJobsCalls())
+func (mock *QuerierMock) ListDeadLetterJobsCalls() []struct {
+	Ctx context.Context
+	Arg ListDeadLetterJobsParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg ListDeadLetterJobsParams
+	}
+	mock.lockListDeadLetterJobs.RLock()
+	calls = mock.calls.ListDeadLetterJobs
+	mock.lockListDeadLetterJobs.RUnlock()
+	return calls
+}
+
+// ListIPAllowlistsForSubject calls ListIPAllowlistsForSubjectFunc.
+func (mock *QuerierMock) ListIPAllowlistsForSubject(ctx context.Context, auth0Sub string) ([]*ListIPAllowlistsForSubjectRow, error) {
+	if mock.ListIPAllowlistsForSubjectFunc == nil {
+		panic("QuerierMock.ListIPAllowlistsForSubjectFunc: method is nil but Querier.ListIPAllowlistsForSubject was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Auth0Sub string
+	}{
+		Ctx:      ctx,
+		Auth0Sub: auth0Sub,
+	}
+	mock.lockListIPAllowlistsForSubject.Lock()
+	mock.calls.ListIPAllowlistsForSubject = append(mock.calls.ListIPAllowlistsForSubject, callInfo)
+	mock.lockListIPAllowlistsForSubject.Unlock()
+	return mock.ListIPAllowlistsForSubjectFunc(ctx, auth0Sub)
+}
+
+// ListIPAllowlistsForSubjectCalls gets all the calls that were made to ListIPAllowlistsForSubject.
+// Check the length with:
+//
+//	len(mockedQuerier.ListIPAllowlistsForSubjectCalls())
+func (mock *QuerierMock) ListIPAllowlistsForSubjectCalls() []struct {
+	Ctx      context.Context
+	Auth0Sub string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Auth0Sub string
+	}
+	mock.lockListIPAllowlistsForSubject.RLock()
+	calls = mock.calls.ListIPAllowlistsForSubject
+	mock.lockListIPAllowlistsForSubject.RUnlock()
+	return calls
+}
+
+// ListImageStatusDrift calls ListImageStatusDriftFunc.
+func (mock *QuerierMock) ListImageStatusDrift(ctx context.Context, imageIds []pgtype.UUID) ([]*ListImageStatusDriftRow, error) {
+	if mock.ListImageStatusDriftFunc == nil {
+		panic("QuerierMock.ListImageStatusDriftFunc: method is nil but Querier.ListImageStatusDrift was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ImageIds []pgtype.UUID
+	}{
+		Ctx:      ctx,
+		ImageIds: imageIds,
+	}
+	mock.lockListImageStatusDrift.Lock()
+	mock.calls.ListImageStatusDrift = append(mock.calls.ListImageStatusDrift, callInfo)
+	mock.lockListImageStatusDrift.Unlock()
+	return mock.ListImageStatusDriftFunc(ctx, imageIds)
+}
+
+// ListImageStatusDriftCalls gets all the calls that were made to ListImageStatusDrift.
+// Check the length with:
+//
+//	len(mockedQuerier.ListImageStatusDriftCalls())
+func (mock *QuerierMock) ListImageStatusDriftCalls() []struct {
+	Ctx      context.Context
+	ImageIds []pgtype.UUID
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ImageIds []pgtype.UUID
+	}
+	mock.lockListImageStatusDrift.RLock()
+	calls = mock.calls.ListImageStatusDrift
+	mock.lockListImageStatusDrift.RUnlock()
+	return calls
+}
+
+// ListImageStatusTransitions calls ListImageStatusTransitionsFunc.
+func (mock *QuerierMock) ListImageStatusTransitions(ctx context.Context, imageID pgtype.UUID) ([]*ImageStatusTransition, error) {
+	if mock.ListImageStatusTransitionsFunc == nil {
+		panic("QuerierMock.ListImageStatusTransitionsFunc: method is nil but Querier.ListImageStatusTransitions was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ImageID pgtype.UUID
+	}{
+		Ctx:     ctx,
+		ImageID: imageID,
+	}
+	mock.lockListImageStatusTransitions.Lock()
+	mock.calls.ListImageStatusTransitions = append(mock.calls.ListImageStatusTransitions, callInfo)
+	mock.lockListImageStatusTransitions.Unlock()
+	return mock.ListImageStatusTransitionsFunc(ctx, imageID)
+}
+
+// ListImageStatusTransitionsCalls gets all the calls that were made to ListImageStatusTransitions.
+// Check the length with:
+//
+//	len(mockedQuerier.ListImageStatusTransitionsCalls())
+func (mock *QuerierMock) ListImageStatusTransitionsCalls() []struct {
+	Ctx     context.Context
+	ImageID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ImageID pgtype.UUID
+	}
+	mock.lockListImageStatusTransitions.RLock()
+	calls = mock.calls.ListImageStatusTransitions
+	mock.lockListImageStatusTransitions.RUnlock()
+	return calls
+}
+
+// ListImagesForReconcile calls ListImagesForReconcileFunc.
+func (mock *QuerierMock) ListImagesForReconcile(ctx context.Context, arg ListImagesForReconcileParams) ([]*ListImagesForReconcileRow, error) {
+	if mock.ListImagesForReconcileFunc == nil {
+		panic("QuerierMock.ListImagesForReconcileFunc: method is nil but Querier.ListImagesForReconcile was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg ListImagesForReconcileParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockListImagesForReconcile.Lock()
+	mock.calls.ListImagesForReconcile = append(mock.calls.ListImagesForReconcile, callInfo)
+	mock.lockListImagesForReconcile.Unlock()
+	return mock.ListImagesForReconcileFunc(ctx, arg)
+}
+
+// ListImagesForReconcileCalls gets all the calls that were made to ListImagesForReconcile.
+// Check the length with:
+//
+//	len(mockedQuerier.ListImagesForReconcileCalls())
+func (mock *QuerierMock) ListImagesForReconcileCalls() []struct {
+	Ctx context.Context
+	Arg ListImagesForReconcileParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg ListImagesForReconcileParams
+	}
+	mock.lockListImagesForReconcile.RLock()
+	calls = mock.calls.ListImagesForReconcile
+	mock.lockListImagesForReconcile.RUnlock()
+	return calls
+}
+
+// ListInvoicesByUserID calls ListInvoicesByUserIDFunc.
+func (mock *QuerierMock) ListInvoicesByUserID(ctx context.Context, arg ListInvoicesByUserIDParams) ([]*Invoice, error) {
+	if mock.ListInvoicesByUserIDFunc == nil {
+		panic("QuerierMock.ListInvoicesByUserIDFunc: method is nil but Querier.ListInvoicesByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg ListInvoicesByUserIDParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockListInvoicesByUserID.Lock()
+	mock.calls.ListInvoicesByUserID = append(mock.calls.ListInvoicesByUserID, callInfo)
+	mock.lockListInvoicesByUserID.Unlock()
+	return mock.ListInvoicesByUserIDFunc(ctx, arg)
+}
+
+// ListInvoicesByUserIDCalls gets all the calls that were made to ListInvoicesByUserID.
+// Check the length with:
+//
+//	len(mockedQuerier.ListInvoicesByUserIDCalls())
+func (mock *QuerierMock) ListInvoicesByUserIDCalls() []struct {
+	Ctx context.Context
+	Arg ListInvoicesByUserIDParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg ListInvoicesByUserIDParams
+	}
+	mock.lockListInvoicesByUserID.RLock()
+	calls = mock.calls.ListInvoicesByUserID
+	mock.lockListInvoicesByUserID.RUnlock()
+	return calls
+}
+
+// ListJobs calls ListJobsFunc.
+func (mock *QuerierMock) ListJobs(ctx context.Context, arg ListJobsParams) ([]*Job, error) {
+	if mock.ListJobsFunc == nil {
+		panic("QuerierMock.ListJobsFunc: method is nil but Querier.ListJobs was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg ListJobsParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockListJobs.Lock()
+	mock.calls.ListJobs = append(mock.calls.ListJobs, callInfo)
+	mock.lockListJobs.Unlock()
+	return mock.ListJobsFunc(ctx, arg)
+}
+
+// ListJobsCalls gets all the calls that were made to ListJobs.
+// Check the length with:
+//
+//	len(mockedQuerier.ListJobsCalls())
+func (mock *QuerierMock) ListJobsCalls() []struct {
+	Ctx context.Context
+	Arg ListJobsParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg ListJobsParams
+	}
+	mock.lockListJobs.RLock()
+	calls = mock.calls.ListJobs
+	mock.lockListJobs.RUnlock()
+	return calls
+}
+
+// ListLegalHeldImageIDs calls ListLegalHeldImageIDsFunc.
+func (mock *QuerierMock) ListLegalHeldImageIDs(ctx context.Context, imageIds []pgtype.UUID) ([]pgtype.UUID, error) {
+	if mock.ListLegalHeldImageIDsFunc == nil {
+		panic("QuerierMock.ListLegalHeldImageIDsFunc: method is nil but Querier.ListLegalHeldImageIDs was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ImageIds []pgtype.UUID
+	}{
+		Ctx:      ctx,
+		ImageIds: imageIds,
+	}
+	mock.lockListLegalHeldImageIDs.Lock()
+	mock.calls.ListLegalHeldImageIDs = append(mock.calls.ListLegalHeldImageIDs, callInfo)
+	mock.lockListLegalHeldImageIDs.Unlock()
+	return mock.ListLegalHeldImageIDsFunc(ctx, imageIds)
+}
+
+// ListLegalHeldImageIDsCalls gets all the calls that were made to ListLegalHeldImageIDs.
+// Check the length with:
+//
+//	len(mockedQuerier.ListLegalHeldImageIDsCalls())
+func (mock *QuerierMock) ListLegalHeldImageIDsCalls() []struct {
+	Ctx      context.Context
+	ImageIds []pgtype.UUID
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ImageIds []pgtype.UUID
+	}
+	mock.lockListLegalHeldImageIDs.RLock()
+	calls = mock.calls.ListLegalHeldImageIDs
+	mock.lockListLegalHeldImageIDs.RUnlock()
+	return calls
+}
+
+// ListLegalHolds calls ListLegalHoldsFunc.
+func (mock *QuerierMock) ListLegalHolds(ctx context.Context) ([]*LegalHold, error) {
+	if mock.ListLegalHoldsFunc == nil {
+		panic("QuerierMock.ListLegalHoldsFunc: method is nil but Querier.ListLegalHolds was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockListLegalHolds.Lock()
+	mock.calls.ListLegalHolds = append(mock.calls.ListLegalHolds, callInfo)
+	mock.lockListLegalHolds.Unlock()
+	return mock.ListLegalHoldsFunc(ctx)
+}
+
+// ListLegalHoldsCalls gets all the calls that were made to ListLegalHolds.
+// Check the length with:
+//
+//	len(mockedQuerier.ListLegalHoldsCalls())
+func (mock *QuerierMock) ListLegalHoldsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockListLegalHolds.RLock()
+	calls = mock.calls.ListLegalHolds
+	mock.lockListLegalHolds.RUnlock()
+	return calls
+}
+
+// ListNotifications calls ListNotificationsFunc.
+func (mock *QuerierMock) ListNotifications(ctx context.Context, arg ListNotificationsParams) ([]*Notification, error) {
+	if mock.ListNotificationsFunc == nil {
+		panic("QuerierMock.ListNotificationsFunc: method is nil but Querier.ListNotifications was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg ListNotificationsParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockListNotifications.Lock()
+	mock.calls.ListNotifications = append(mock.calls.ListNotifications, callInfo)
+	mock.lockListNotifications.Unlock()
+	return mock.ListNotificationsFunc(ctx, arg)
+}
+
+// ListNotificationsCalls gets all the calls that were made to ListNotifications.
+// Check the length with:
+//
+//	len(mockedQuerier.ListNotificationsCalls())
+func (mock *QuerierMock) ListNotificationsCalls() []struct {
+	Ctx context.Context
+	Arg ListNotificationsParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg ListNotificationsParams
+	}
+	mock.lockListNotifications.RLock()
+	calls = mock.calls.ListNotifications
+	mock.lockListNotifications.RUnlock()
+	return calls
+}
+
+// ListOrganizationAuditLog calls ListOrganizationAuditLogFunc.
+func (mock *QuerierMock) ListOrganizationAuditLog(ctx context.Context, arg ListOrganizationAuditLogParams) ([]*OrganizationAuditLog, error) {
+	if mock.ListOrganizationAuditLogFunc == nil {
+		panic("QuerierMock.ListOrganizationAuditLogFunc: method is nil but Querier.ListOrganizationAuditLog was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg ListOrganizationAuditLogParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockListOrganizationAuditLog.Lock()
+	mock.calls.ListOrganizationAuditLog = append(mock.calls.ListOrganizationAuditLog, callInfo)
+	mock.lockListOrganizationAuditLog.Unlock()
+	return mock.ListOrganizationAuditLogFunc(ctx, arg)
+}
+
+// ListOrganizationAuditLogCalls gets all the calls that were made to ListOrganizationAuditLog.
+// Check the length with:
+//
+//	len(mockedQuerier.ListOrganizationAuditLogCalls())
+func (mock *QuerierMock) ListOrganizationAuditLogCalls() []struct {
+	Ctx context.Context
+	Arg ListOrganizationAuditLogParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg ListOrganizationAuditLogParams
+	}
+	mock.lockListOrganizationAuditLog.RLock()
+	calls = mock.calls.ListOrganizationAuditLog
+	mock.lockListOrganizationAuditLog.RUnlock()
+	return calls
+}
+
+// ListOrganizationMembers calls ListOrganizationMembersFunc.
+func (mock *QuerierMock) ListOrganizationMembers(ctx context.Context, organizationID pgtype.UUID) ([]*OrganizationMember, error) {
+	if mock.ListOrganizationMembersFunc == nil {
+		panic("QuerierMock.ListOrganizationMembersFunc: method is nil but Querier.ListOrganizationMembers was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		OrganizationID pgtype.UUID
+	}{
+		Ctx:            ctx,
+		OrganizationID: organizationID,
+	}
+	mock.lockListOrganizationMembers.Lock()
+	mock.calls.ListOrganizationMembers = append(mock.calls.ListOrganizationMembers, callInfo)
+	mock.lockListOrganizationMembers.Unlock()
+	return mock.ListOrganizationMembersFunc(ctx, organizationID)
+}
+
+// ListOrganizationMembersCalls gets all the calls that were made to ListOrganizationMembers.
+// Check the length with:
+//
+//	len(mockedQuerier.ListOrganizationMembersCalls())
+func (mock *QuerierMock) ListOrganizationMembersCalls() []struct {
+	Ctx            context.Context
+	OrganizationID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx            context.Context
+		OrganizationID pgtype.UUID
+	}
+	mock.lockListOrganizationMembers.RLock()
+	calls = mock.calls.ListOrganizationMembers
+	mock.lockListOrganizationMembers.RUnlock()
+	return calls
+}
+
+// ListOrganizationsByUser calls ListOrganizationsByUserFunc.
+func (mock *QuerierMock) ListOrganizationsByUser(ctx context.Context, userID pgtype.UUID) ([]*ListOrganizationsByUserRow, error) {
+	if mock.ListOrganizationsByUserFunc == nil {
+		panic("QuerierMock.ListOrganizationsByUserFunc: method is nil but Querier.ListOrganizationsByUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockListOrganizationsByUser.Lock()
+	mock.calls.ListOrganizationsByUser = append(mock.calls.ListOrganizationsByUser, callInfo)
+	mock.lockListOrganizationsByUser.Unlock()
+	return mock.ListOrganizationsByUserFunc(ctx, userID)
+}
+
+// ListOrganizationsByUserCalls gets all the calls that were made to ListOrganizationsByUser.
+// Check the length with:
+//
+//	len(mockedQuerier.ListOrganizationsByUserCalls())
+func (mock *QuerierMock) ListOrganizationsByUserCalls() []struct {
+	Ctx    context.Context
+	UserID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}
+	mock.lockListOrganizationsByUser.RLock()
+	calls = mock.calls.ListOrganizationsByUser
+	mock.lockListOrganizationsByUser.RUnlock()
+	return calls
+}
+
+// ListPresetsByUser calls ListPresetsByUserFunc.
+func (mock *QuerierMock) ListPresetsByUser(ctx context.Context, userID pgtype.UUID) ([]*Preset, error) {
+	if mock.ListPresetsByUserFunc == nil {
+		panic("QuerierMock.ListPresetsByUserFunc: method is nil but Querier.ListPresetsByUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockListPresetsByUser.Lock()
+	mock.calls.ListPresetsByUser = append(mock.calls.ListPresetsByUser, callInfo)
+	mock.lockListPresetsByUser.Unlock()
+	return mock.ListPresetsByUserFunc(ctx, userID)
+}
+
+// ListPresetsByUserCalls gets all the calls that were made to ListPresetsByUser.
+// Check the length with:
+//
+//	len(mockedQuerier.ListPresetsByUserCalls())
+func (mock *QuerierMock) ListPresetsByUserCalls() []struct {
+	Ctx    context.Context
+	UserID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}
+	mock.lockListPresetsByUser.RLock()
+	calls = mock.calls.ListPresetsByUser
+	mock.lockListPresetsByUser.RUnlock()
+	return calls
+}
+
+// ListProjectActivity calls ListProjectActivityFunc.
+func (mock *QuerierMock) ListProjectActivity(ctx context.Context, arg ListProjectActivityParams) ([]*ProjectActivity, error) {
+	if mock.ListProjectActivityFunc == nil {
+		panic("QuerierMock.ListProjectActivityFunc: method is nil but Querier.ListProjectActivity was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg ListProjectActivityParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockListProjectActivity.Lock()
+	mock.calls.ListProjectActivity = append(mock.calls.ListProjectActivity, callInfo)
+	mock.lockListProjectActivity.Unlock()
+	return mock.ListProjectActivityFunc(ctx, arg)
+}
+
+// ListProjectActivityCalls gets all the calls that were made to ListProjectActivity.
+// Check the length with:
+//
+//	len(mockedQuerier.ListProjectActivityCalls())
+func (mock *QuerierMock) ListProjectActivityCalls() []struct {
+	Ctx context.Context
+	Arg ListProjectActivityParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg ListProjectActivityParams
+	}
+	mock.lockListProjectActivity.RLock()
+	calls = mock.calls.ListProjectActivity
+	mock.lockListProjectActivity.RUnlock()
+	return calls
+}
+
+// ListProjectImageRooms calls ListProjectImageRoomsFunc.
+func (mock *QuerierMock) ListProjectImageRooms(ctx context.Context, projectID pgtype.UUID) ([]*ListProjectImageRoomsRow, error) {
+	if mock.ListProjectImageRoomsFunc == nil {
+		panic("QuerierMock.ListProjectImageRoomsFunc: method is nil but Querier.ListProjectImageRooms was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockListProjectImageRooms.Lock()
+	mock.calls.ListProjectImageRooms = append(mock.calls.ListProjectImageRooms, callInfo)
+	mock.lockListProjectImageRooms.Unlock()
+	return mock.ListProjectImageRoomsFunc(ctx, projectID)
+}
+
+// ListProjectImageRoomsCalls gets all the calls that were made to ListProjectImageRooms.
+// Check the length with:
+//
+//	len(mockedQuerier.ListProjectImageRoomsCalls())
+func (mock *QuerierMock) ListProjectImageRoomsCalls() []struct {
+	Ctx       context.Context
+	ProjectID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}
+	mock.lockListProjectImageRooms.RLock()
+	calls = mock.calls.ListProjectImageRooms
+	mock.lockListProjectImageRooms.RUnlock()
+	return calls
+}
+
+// ListProjectInvitations calls ListProjectInvitationsFunc.
+func (mock *QuerierMock) ListProjectInvitations(ctx context.Context, projectID pgtype.UUID) ([]*ProjectInvitation, error) {
+	if mock.ListProjectInvitationsFunc == nil {
+		panic("QuerierMock.ListProjectInvitationsFunc: method is nil but Querier.ListProjectInvitations was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockListProjectInvitations.Lock()
+	mock.calls.ListProjectInvitations = append(mock.calls.ListProjectInvitations, callInfo)
+	mock.lockListProjectInvitations.Unlock()
+	return mock.ListProjectInvitationsFunc(ctx, projectID)
+}
+
+// ListProjectInvitationsCalls gets all the calls that were made to ListProjectInvitations.
+// Check the length with:
+//
+//	len(mockedQuerier.ListProjectInvitationsCalls())
+func (mock *QuerierMock) ListProjectInvitationsCalls() []struct {
+	Ctx       context.Context
+	ProjectID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}
+	mock.lockListProjectInvitations.RLock()
+	calls = mock.calls.ListProjectInvitations
+	mock.lockListProjectInvitations.RUnlock()
+	return calls
+}
+
+// ListProjectRooms calls ListProjectRoomsFunc.
+func (mock *QuerierMock) ListProjectRooms(ctx context.Context, projectID pgtype.UUID) ([]*ProjectRoom, error) {
+	if mock.ListProjectRoomsFunc == nil {
+		panic("QuerierMock.ListProjectRoomsFunc: method is nil but Querier.ListProjectRooms was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockListProjectRooms.Lock()
+	mock.calls.ListProjectRooms = append(mock.calls.ListProjectRooms, callInfo)
+	mock.lockListProjectRooms.Unlock()
+	return mock.ListProjectRoomsFunc(ctx, projectID)
+}
+
+// ListProjectRoomsCalls gets all the calls that were made to ListProjectRooms.
+// Check the length with:
+//
+//	len(mockedQuerier.ListProjectRoomsCalls())
+func (mock *QuerierMock) ListProjectRoomsCalls() []struct {
+	Ctx       context.Context
+	ProjectID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}
+	mock.lockListProjectRooms.RLock()
+	calls = mock.calls.ListProjectRooms
+	mock.lockListProjectRooms.RUnlock()
+	return calls
+}
+
+// ListProjectSummariesByUser calls ListProjectSummariesByUserFunc.
+func (mock *QuerierMock) ListProjectSummariesByUser(ctx context.Context, arg ListProjectSummariesByUserParams) ([]*ListProjectSummariesByUserRow, error) {
+	if mock.ListProjectSummariesByUserFunc == nil {
+		panic("QuerierMock.ListProjectSummariesByUserFunc: method is nil but Querier.ListProjectSummariesByUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg ListProjectSummariesByUserParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockListProjectSummariesByUser.Lock()
+	mock.calls.ListProjectSummariesByUser = append(mock.calls.ListProjectSummariesByUser, callInfo)
+	mock.lockListProjectSummariesByUser.Unlock()
+	return mock.ListProjectSummariesByUserFunc(ctx, arg)
+}
+
+// ListProjectSummariesByUserCalls gets all the calls that were made to ListProjectSummariesByUser.
+// Check the length with:
+//
+//	len(mockedQuerier.ListProjectSummariesByUserCalls())
+func (mock *QuerierMock) ListProjectSummariesByUserCalls() []struct {
+	Ctx context.Context
+	Arg ListProjectSummariesByUserParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg ListProjectSummariesByUserParams
+	}
+	mock.lockListProjectSummariesByUser.RLock()
+	calls = mock.calls.ListProjectSummariesByUser
+	mock.lockListProjectSummariesByUser.RUnlock()
+	return calls
+}
+
+// ListProjectTemplates calls ListProjectTemplatesFunc.
+func (mock *QuerierMock) ListProjectTemplates(ctx context.Context, userID pgtype.UUID) ([]*ProjectTemplate, error) {
+	if mock.ListProjectTemplatesFunc == nil {
+		panic("QuerierMock.ListProjectTemplatesFunc: method is nil but Querier.ListProjectTemplates was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockListProjectTemplates.Lock()
+	mock.calls.ListProjectTemplates = append(mock.calls.ListProjectTemplates, callInfo)
+	mock.lockListProjectTemplates.Unlock()
+	return mock.ListProjectTemplatesFunc(ctx, userID)
+}
+
+// ListProjectTemplatesCalls gets all the calls that were made to ListProjectTemplates.
+// Check the length with:
+//
+//	len(mockedQuerier.ListProjectTemplatesCalls())
+func (mock *QuerierMock) ListProjectTemplatesCalls() []struct {
+	Ctx    context.Context
+	UserID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}
+	mock.lockListProjectTemplates.RLock()
+	calls = mock.calls.ListProjectTemplates
+	mock.lockListProjectTemplates.RUnlock()
+	return calls
+}
+
+// ListProjectThumbnails calls ListProjectThumbnailsFunc.
+func (mock *QuerierMock) ListProjectThumbnails(ctx context.Context, arg ListProjectThumbnailsParams) ([]*ListProjectThumbnailsRow, error) {
+	if mock.ListProjectThumbnailsFunc == nil {
+		panic("QuerierMock.ListProjectThumbnailsFunc: method is nil but Querier.ListProjectThumbnails was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg ListProjectThumbnailsParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockListProjectThumbnails.Lock()
+	mock.calls.ListProjectThumbnails = append(mock.calls.ListProjectThumbnails, callInfo)
+	mock.lockListProjectThumbnails.Unlock()
+	return mock.ListProjectThumbnailsFunc(ctx, arg)
+}
+
+// ListProjectThumbnailsCalls gets all the calls that were made to ListProjectThumbnails.
+// Check the length with:
+//
+//	len(mockedQuerier.ListProjectThumbnailsCalls())
+func (mock *QuerierMock) ListProjectThumbnailsCalls() []struct {
+	Ctx context.Context
+	Arg ListProjectThumbnailsParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg ListProjectThumbnailsParams
+	}
+	mock.lockListProjectThumbnails.RLock()
+	calls = mock.calls.ListProjectThumbnails
+	mock.lockListProjectThumbnails.RUnlock()
+	return calls
+}
+
+// ListSCIMGroupMembers calls ListSCIMGroupMembersFunc.
+func (mock *QuerierMock) ListSCIMGroupMembers(ctx context.Context, groupIds []pgtype.UUID) ([]*ListSCIMGroupMembersRow, error) {
+	if mock.ListSCIMGroupMembersFunc == nil {
+		panic("QuerierMock.ListSCIMGroupMembersFunc: method is nil but Querier.ListSCIMGroupMembers was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		GroupIds []pgtype.UUID
+	}{
+		Ctx:      ctx,
+		GroupIds: groupIds,
+	}
+	mock.lockListSCIMGroupMembers.Lock()
+	mock.calls.ListSCIMGroupMembers = append(mock.calls.ListSCIMGroupMembers, callInfo)
+	mock.lockListSCIMGroupMembers.Unlock()
+	return mock.ListSCIMGroupMembersFunc(ctx, groupIds)
+}
+
+// ListSCIMGroupMembersCalls gets all the calls that were made to ListSCIMGroupMembers.
+// Check the length with:
+//
+//	len(mockedQuerier.ListSCIMGroupMembersCalls())
+func (mock *QuerierMock) ListSCIMGroupMembersCalls() []struct {
+	Ctx      context.Context
+	GroupIds []pgtype.UUID
+} {
+	var calls []struct {
+		Ctx      context.Context
+		GroupIds []pgtype.UUID
+	}
+	mock.lockListSCIMGroupMembers.RLock()
+	calls = mock.calls.ListSCIMGroupMembers
+	mock.lockListSCIMGroupMembers.RUnlock()
+	return calls
+}
+
+// ListSCIMGroups calls ListSCIMGroupsFunc.
+func (mock *QuerierMock) ListSCIMGroups(ctx context.Context, arg ListSCIMGroupsParams) ([]*OrganizationScimGroup, error) {
+	if mock.ListSCIMGroupsFunc == nil {
+		panic("QuerierMock.ListSCIMGroupsFunc: method is nil but Querier.ListSCIMGroups was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg ListSCIMGroupsParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockListSCIMGroups.Lock()
+	mock.calls.ListSCIMGroups = append(mock.calls.ListSCIMGroups, callInfo)
+	mock.lockListSCIMGroups.Unlock()
+	return mock.ListSCIMGroupsFunc(ctx, arg)
+}
+
+// ListSCIMGroupsCalls gets all the calls that were made to ListSCIMGroups.
+// Check the length with:
+//
+//	len(mockedQuerier.ListSCIMGroupsCalls())
+func (mock *QuerierMock) ListSCIMGroupsCalls() []struct {
+	Ctx context.Context
+	Arg ListSCIMGroupsParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg ListSCIMGroupsParams
+	}
+	mock.lockListSCIMGroups.RLock()
+	calls = mock.calls.ListSCIMGroups
+	mock.lockListSCIMGroups.RUnlock()
+	return calls
+}
+
+// ListSCIMUserGroupNames calls ListSCIMUserGroupNamesFunc.
+func (mock *QuerierMock) ListSCIMUserGroupNames(ctx context.Context, scimUserID pgtype.UUID) ([]string, error) {
+	if mock.ListSCIMUserGroupNamesFunc == nil {
+		panic("QuerierMock.ListSCIMUserGroupNamesFunc: method is nil but Querier.ListSCIMUserGroupNames was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		ScimUserID pgtype.UUID
+	}{
+		Ctx:        ctx,
+		ScimUserID: scimUserID,
+	}
+	mock.lockListSCIMUserGroupNames.Lock()
+	mock.calls.ListSCIMUserGroupNames = append(mock.calls.ListSCIMUserGroupNames, callInfo)
+	mock.lockListSCIMUserGroupNames.Unlock()
+	return mock.ListSCIMUserGroupNamesFunc(ctx, scimUserID)
+}
+
+// ListSCIMUserGroupNamesCalls gets all the calls that were made to ListSCIMUserGroupNames.
+// Check the length with:
+//
+//	len(mockedQuerier.ListSCIMUserGroupNamesCalls())
+func (mock *QuerierMock) ListSCIMUserGroupNamesCalls() []struct {
+	Ctx        context.Context
+	ScimUserID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx        context.Context
+		ScimUserID pgtype.UUID
+	}
+	mock.lockListSCIMUserGroupNames.RLock()
+	calls = mock.calls.ListSCIMUserGroupNames
+	mock.lockListSCIMUserGroupNames.RUnlock()
+	return calls
+}
+
+// ListSCIMUsers calls ListSCIMUsersFunc.
+func (mock *QuerierMock) ListSCIMUsers(ctx context.Context, arg ListSCIMUsersParams) ([]*OrganizationScimUser, error) {
+	if mock.ListSCIMUsersFunc == nil {
+		panic("QuerierMock.ListSCIMUsersFunc: method is nil but Querier.ListSCIMUsers was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg ListSCIMUsersParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockListSCIMUsers.Lock()
+	mock.calls.ListSCIMUsers = append(mock.calls.ListSCIMUsers, callInfo)
+	mock.lockListSCIMUsers.Unlock()
+	return mock.ListSCIMUsersFunc(ctx, arg)
+}
+
+// ListSCIMUsersCalls gets all the calls that were made to ListSCIMUsers.
+// Check the length with:
+//
+//	len(mockedQuerier.ListSCIMUsersCalls())
+func (mock *QuerierMock) ListSCIMUsersCalls() []struct {
+	Ctx context.Context
+	Arg ListSCIMUsersParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg ListSCIMUsersParams
+	}
+	mock.lockListSCIMUsers.RLock()
+	calls = mock.calls.ListSCIMUsers
+	mock.lockListSCIMUsers.RUnlock()
+	return calls
+}
+
+// ListSettings calls ListSettingsFunc.
+func (mock *QuerierMock) ListSettings(ctx context.Context) ([]*Setting, error) {
+	if mock.ListSettingsFunc == nil {
+		panic("QuerierMock.ListSettingsFunc: method is nil but Querier.ListSettings was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockListSettings.Lock()
+	mock.calls.ListSettings = append(mock.calls.ListSettings, callInfo)
+	mock.lockListSettings.Unlock()
+	return mock.ListSettingsFunc(ctx)
+}
+
+// ListSettingsCalls gets all the calls that were made to ListSettings.
+// Check the length with:
+//
+//	len(mockedQuerier.ListSettingsCalls())
+func (mock *QuerierMock) ListSettingsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockListSettings.RLock()
+	calls = mock.calls.ListSettings
+	mock.lockListSettings.RUnlock()
+	return calls
+}
+
+// ListSubscriptionsByUserID calls ListSubscriptionsByUserIDFunc.
+func (mock *QuerierMock) ListSubscriptionsByUserID(ctx context.Context, arg ListSubscriptionsByUserIDParams) ([]*Subscription, error) {
+	if mock.ListSubscriptionsByUserIDFunc == nil {
+		panic("QuerierMock.ListSubscriptionsByUserIDFunc: method is nil but Querier.ListSubscriptionsByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg ListSubscriptionsByUserIDParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockListSubscriptionsByUserID.Lock()
+	mock.calls.ListSubscriptionsByUserID = append(mock.calls.ListSubscriptionsByUserID, callInfo)
+	mock.lockListSubscriptionsByUserID.Unlock()
+	return mock.ListSubscriptionsByUserIDFunc(ctx, arg)
+}
+
+// ListSubscriptionsByUserIDCalls gets all the calls that were made to ListSubscriptionsByUserID.
+// Check the length with:
+//
+//	len(mockedQuerier.ListSubscriptionsByUserIDCalls())
+func (mock *QuerierMock) ListSubscriptionsByUserIDCalls() []struct {
+	Ctx context.Context
+	Arg ListSubscriptionsByUserIDParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg ListSubscriptionsByUserIDParams
+	}
+	mock.lockListSubscriptionsByUserID.RLock()
+	calls = mock.calls.ListSubscriptionsByUserID
+	mock.lockListSubscriptionsByUserID.RUnlock()
+	return calls
+}
+
+// ListTeamWebhookDeliveries calls ListTeamWebhookDeliveriesFunc.
+func (mock *QuerierMock) ListTeamWebhookDeliveries(ctx context.Context, arg ListTeamWebhookDeliveriesParams) ([]*TeamWebhookDelivery, error) {
+	if mock.ListTeamWebhookDeliveriesFunc == nil {
+		panic("QuerierMock.ListTeamWebhookDeliveriesFunc: method is nil but Querier.ListTeamWebhookDeliveries was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg ListTeamWebhookDeliveriesParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockListTeamWebhookDeliveries.Lock()
+	mock.calls.ListTeamWebhookDeliveries = append(mock.calls.ListTeamWebhookDeliveries, callInfo)
+	mock.lockListTeamWebhookDeliveries.Unlock()
+	return mock.ListTeamWebhookDeliveriesFunc(ctx, arg)
+}
+
+// ListTeamWebhookDeliveriesCalls gets all the calls that were made to ListTeamWebhookDeliveries.
+// Check the length with:
+//
+//	len(mockedQuerier.ListTeamWebhookDeliveriesCalls())
+func (mock *QuerierMock) ListTeamWebhookDeliveriesCalls() []struct {
+	Ctx context.Context
+	Arg ListTeamWebhookDeliveriesParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg ListTeamWebhookDeliveriesParams
+	}
+	mock.lockListTeamWebhookDeliveries.RLock()
+	calls = mock.calls.ListTeamWebhookDeliveries
+	mock.lockListTeamWebhookDeliveries.RUnlock()
+	return calls
+}
+
+// ListTeamWebhooks calls ListTeamWebhooksFunc.
+func (mock *QuerierMock) ListTeamWebhooks(ctx context.Context, userID pgtype.UUID) ([]*TeamWebhook, error) {
+	if mock.ListTeamWebhooksFunc == nil {
+		panic("QuerierMock.ListTeamWebhooksFunc: method is nil but Querier.ListTeamWebhooks was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockListTeamWebhooks.Lock()
+	mock.calls.ListTeamWebhooks = append(mock.calls.ListTeamWebhooks, callInfo)
+	mock.lockListTeamWebhooks.Unlock()
+	return mock.ListTeamWebhooksFunc(ctx, userID)
+}
+
+// ListTeamWebhooksCalls gets all the calls that were made to ListTeamWebhooks.
+// Check the length with:
+//
+//	len(mockedQuerier.ListTeamWebhooksCalls())
+func (mock *QuerierMock) ListTeamWebhooksCalls() []struct {
+	Ctx    context.Context
+	UserID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}
+	mock.lockListTeamWebhooks.RLock()
+	calls = mock.calls.ListTeamWebhooks
+	mock.lockListTeamWebhooks.RUnlock()
+	return calls
+}
+
+// ListTeamWebhooksForEvent calls ListTeamWebhooksForEventFunc.
+func (mock *QuerierMock) ListTeamWebhooksForEvent(ctx context.Context, arg ListTeamWebhooksForEventParams) ([]*TeamWebhook, error) {
+	if mock.ListTeamWebhooksForEventFunc == nil {
+		panic("QuerierMock.ListTeamWebhooksForEventFunc: method is nil but Querier.ListTeamWebhooksForEvent was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg ListTeamWebhooksForEventParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockListTeamWebhooksForEvent.Lock()
+	mock.calls.ListTeamWebhooksForEvent = append(mock.calls.ListTeamWebhooksForEvent, callInfo)
+	mock.lockListTeamWebhooksForEvent.Unlock()
+	return mock.ListTeamWebhooksForEventFunc(ctx, arg)
+}
+
+// ListTeamWebhooksForEventCalls gets all the calls that were made to ListTeamWebhooksForEvent.
+// Check the length with:
+//
+//	len(mockedQuerier.ListTeamWebhooksForEventCalls())
+func (mock *QuerierMock) ListTeamWebhooksForEventCalls() []struct {
+	Ctx context.Context
+	Arg ListTeamWebhooksForEventParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg ListTeamWebhooksForEventParams
+	}
+	mock.lockListTeamWebhooksForEvent.RLock()
+	calls = mock.calls.ListTeamWebhooksForEvent
+	mock.lockListTeamWebhooksForEvent.RUnlock()
+	return calls
+}
+
+// ListUserEncryptedFields calls ListUserEncryptedFieldsFunc.
+func (mock *QuerierMock) ListUserEncryptedFields(ctx context.Context, arg ListUserEncryptedFieldsParams) ([]*ListUserEncryptedFieldsRow, error) {
+	if mock.ListUserEncryptedFieldsFunc == nil {
+		panic("QuerierMock.ListUserEncryptedFieldsFunc: method is nil but Querier.ListUserEncryptedFields was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg ListUserEncryptedFieldsParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockListUserEncryptedFields.Lock()
+	mock.calls.ListUserEncryptedFields = append(mock.calls.ListUserEncryptedFields, callInfo)
+	mock.lockListUserEncryptedFields.Unlock()
+	return mock.ListUserEncryptedFieldsFunc(ctx, arg)
+}
+
+// ListUserEncryptedFieldsCalls gets all the calls that were made to ListUserEncryptedFields.
+// Check the length with:
+//
+//	len(mockedQuerier.ListUserEncryptedFieldsCalls())
+func (mock *QuerierMock) ListUserEncryptedFieldsCalls() []struct {
+	Ctx context.Context
+	Arg ListUserEncryptedFieldsParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg ListUserEncryptedFieldsParams
+	}
+	mock.lockListUserEncryptedFields.RLock()
+	calls = mock.calls.ListUserEncryptedFields
+	mock.lockListUserEncryptedFields.RUnlock()
+	return calls
+}
+
+// ListUsers calls ListUsersFunc.
+func (mock *QuerierMock) ListUsers(ctx context.Context, arg ListUsersParams) ([]*ListUsersRow, error) {
+	if mock.ListUsersFunc == nil {
+		panic("QuerierMock.ListUsersFunc: method is nil but Querier.ListUsers was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg ListUsersParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockListUsers.Lock()
+	mock.calls.ListUsers = append(mock.calls.ListUsers, callInfo)
+	mock.lockListUsers.Unlock()
+	return mock.ListUsersFunc(ctx, arg)
+}
+
+// ListUsersCalls gets all the calls that were made to ListUsers.
+// Check the length with:
+//
+//	len(mockedQuerier.ListUsersCalls())
+func (mock *QuerierMock) ListUsersCalls() []struct {
+	Ctx context.Context
+	Arg ListUsersParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg ListUsersParams
+	}
+	mock.lockListUsers.RLock()
+	calls = mock.calls.ListUsers
+	mock.lockListUsers.RUnlock()
+	return calls
+}
+
+// MarkAllNotificationsRead calls MarkAllNotificationsReadFunc.
+func (mock *QuerierMock) MarkAllNotificationsRead(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	if mock.MarkAllNotificationsReadFunc == nil {
+		panic("QuerierMock.MarkAllNotificationsReadFunc: method is nil but Querier.MarkAllNotificationsRead was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockMarkAllNotificationsRead.Lock()
+	mock.calls.MarkAllNotificationsRead = append(mock.calls.MarkAllNotificationsRead, callInfo)
+	mock.lockMarkAllNotificationsRead.Unlock()
+	return mock.MarkAllNotificationsReadFunc(ctx, userID)
+}
+
+// MarkAllNotificationsReadCalls gets all the calls that were made to MarkAllNotificationsRead.
+// Check the length with:
+//
+//	len(mockedQuerier.MarkAllNotificationsReadCalls())
+func (mock *QuerierMock) MarkAllNotificationsReadCalls() []struct {
+	Ctx    context.Context
+	UserID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}
+	mock.lockMarkAllNotificationsRead.RLock()
+	calls = mock.calls.MarkAllNotificationsRead
+	mock.lockMarkAllNotificationsRead.RUnlock()
+	return calls
+}
+
+// MarkCustomerBucketVerified calls MarkCustomerBucketVerifiedFunc.
+func (mock *QuerierMock) MarkCustomerBucketVerified(ctx context.Context, userID pgtype.UUID) (*CustomerBucket, error) {
+	if mock.MarkCustomerBucketVerifiedFunc == nil {
+		panic("QuerierMock.MarkCustomerBucketVerifiedFunc: method is nil but Querier.MarkCustomerBucketVerified was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockMarkCustomerBucketVerified.Lock()
+	mock.calls.MarkCustomerBucketVerified = append(mock.calls.MarkCustomerBucketVerified, callInfo)
+	mock.lockMarkCustomerBucketVerified.Unlock()
+	return mock.MarkCustomerBucketVerifiedFunc(ctx, userID)
+}
+
+// MarkCustomerBucketVerifiedCalls gets all the calls that were made to MarkCustomerBucketVerified.
+// Check the length with:
+//
+//	len(mockedQuerier.MarkCustomerBucketVerifiedCalls())
+func (mock *QuerierMock) MarkCustomerBucketVerifiedCalls() []struct {
+	Ctx    context.Context
+	UserID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}
+	mock.lockMarkCustomerBucketVerified.RLock()
+	calls = mock.calls.MarkCustomerBucketVerified
+	mock.lockMarkCustomerBucketVerified.RUnlock()
+	return calls
+}
+
+// MarkDeadLetterJobRequeued calls MarkDeadLetterJobRequeuedFunc.
+func (mock *QuerierMock) MarkDeadLetterJobRequeued(ctx context.Context, id pgtype.UUID) (*DeadLetterJob, error) {
+	if mock.MarkDeadLetterJobRequeuedFunc == nil {
+		panic("QuerierMock.MarkDeadLetterJobRequeuedFunc: method is nil but Querier.MarkDeadLetterJobRequeued was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockMarkDeadLetterJobRequeued.Lock()
+	mock.calls.MarkDeadLetterJobRequeued = append(mock.calls.MarkDeadLetterJobRequeued, callInfo)
+	mock.lockMarkDeadLetterJobRequeued.Unlock()
+	return mock.MarkDeadLetterJobRequeuedFunc(ctx, id)
+}
+
+// MarkDeadLetterJobRequeuedCalls gets all the calls that were made to MarkDeadLetterJobRequeued.
+// Check the length with:
+//
+//	len(mockedQuerier.MarkDeadLetterJobRequeuedCalls())
+func (mock *QuerierMock) MarkDeadLetterJobRequeuedCalls() []struct {
+	Ctx context.Context
+	ID  pgtype.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}
+	mock.lockMarkDeadLetterJobRequeued.RLock()
+	calls = mock.calls.MarkDeadLetterJobRequeued
+	mock.lockMarkDeadLetterJobRequeued.RUnlock()
+	return calls
+}
+
+// MarkNotificationRead calls MarkNotificationReadFunc.
+func (mock *QuerierMock) MarkNotificationRead(ctx context.Context, arg MarkNotificationReadParams) (*Notification, error) {
+	if mock.MarkNotificationReadFunc == nil {
+		panic("QuerierMock.MarkNotificationReadFunc: method is nil but Querier.MarkNotificationRead was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg MarkNotificationReadParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockMarkNotificationRead.Lock()
+	mock.calls.MarkNotificationRead = append(mock.calls.MarkNotificationRead, callInfo)
+	mock.lockMarkNotificationRead.Unlock()
+	return mock.MarkNotificationReadFunc(ctx, arg)
+}
+
+// MarkNotificationReadCalls gets all the calls that were made to MarkNotificationRead.
+// Check the length with:
+//
+//	len(mockedQuerier.MarkNotificationReadCalls())
+func (mock *QuerierMock) MarkNotificationReadCalls() []struct {
+	Ctx context.Context
+	Arg MarkNotificationReadParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg MarkNotificationReadParams
+	}
+	mock.lockMarkNotificationRead.RLock()
+	calls = mock.calls.MarkNotificationRead
+	mock.lockMarkNotificationRead.RUnlock()
+	return calls
+}
+
+// MarkShareBrandingDomainVerified calls MarkShareBrandingDomainVerifiedFunc.
+func (mock *QuerierMock) MarkShareBrandingDomainVerified(ctx context.Context, userID pgtype.UUID) (*ShareBranding, error) {
+	if mock.MarkShareBrandingDomainVerifiedFunc == nil {
+		panic("QuerierMock.MarkShareBrandingDomainVerifiedFunc: method is nil but Querier.MarkShareBrandingDomainVerified was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockMarkShareBrandingDomainVerified.Lock()
+	mock.calls.MarkShareBrandingDomainVerified = append(mock.calls.MarkShareBrandingDomainVerified, callInfo)
+	mock.lockMarkShareBrandingDomainVerified.Unlock()
+	return mock.MarkShareBrandingDomainVerifiedFunc(ctx, userID)
+}
+
+// MarkShareBrandingDomainVerifiedCalls gets all the calls that were made to MarkShareBrandingDomainVerified.
+// Check the length with:
+//
+//	len(mockedQuerier.MarkShareBrandingDomainVerifiedCalls())
+func (mock *QuerierMock) MarkShareBrandingDomainVerifiedCalls() []struct {
+	Ctx    context.Context
+	UserID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID pgtype.UUID
+	}
+	mock.lockMarkShareBrandingDomainVerified.RLock()
+	calls = mock.calls.MarkShareBrandingDomainVerified
+	mock.lockMarkShareBrandingDomainVerified.RUnlock()
+	return calls
+}
+
+// OrganizationSSODomainsTaken calls OrganizationSSODomainsTakenFunc.
+func (mock *QuerierMock) OrganizationSSODomainsTaken(ctx context.Context, arg OrganizationSSODomainsTakenParams) (bool, error) {
+	if mock.OrganizationSSODomainsTakenFunc == nil {
+		panic("QuerierMock.OrganizationSSODomainsTakenFunc: method is nil but Querier.OrganizationSSODomainsTaken was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg OrganizationSSODomainsTakenParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockOrganizationSSODomainsTaken.Lock()
+	mock.calls.OrganizationSSODomainsTaken = append(mock.calls.OrganizationSSODomainsTaken, callInfo)
+	mock.lockOrganizationSSODomainsTaken.Unlock()
+	return mock.OrganizationSSODomainsTakenFunc(ctx, arg)
+}
+
+// OrganizationSSODomainsTakenCalls gets all the calls that were made to OrganizationSSODomainsTaken.
+// Check the length with:
+//
+//	len(mockedQuerier.OrganizationSSODomainsTakenCalls())
+func (mock *QuerierMock) OrganizationSSODomainsTakenCalls() []struct {
+	Ctx context.Context
+	Arg OrganizationSSODomainsTakenParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg OrganizationSSODomainsTakenParams
+	}
+	mock.lockOrganizationSSODomainsTaken.RLock()
+	calls = mock.calls.OrganizationSSODomainsTaken
+	mock.lockOrganizationSSODomainsTaken.RUnlock()
+	return calls
+}
+
+// PlaceImageLegalHold calls PlaceImageLegalHoldFunc.
+func (mock *QuerierMock) PlaceImageLegalHold(ctx context.Context, arg PlaceImageLegalHoldParams) (*LegalHold, error) {
+	if mock.PlaceImageLegalHoldFunc == nil {
+		panic("QuerierMock.PlaceImageLegalHoldFunc: method is nil but Querier.PlaceImageLegalHold was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg PlaceImageLegalHoldParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockPlaceImageLegalHold.Lock()
+	mock.calls.PlaceImageLegalHold = append(mock.calls.PlaceImageLegalHold, callInfo)
+	mock.lockPlaceImageLegalHold.Unlock()
+	return mock.PlaceImageLegalHoldFunc(ctx, arg)
+}
+
+// PlaceImageLegalHoldCalls gets all the calls that were made to PlaceImageLegalHold.
+// Check the length with:
+//
+//	len(mockedQuerier.PlaceImageLegalHoldCalls())
+func (mock *QuerierMock) PlaceImageLegalHoldCalls() []struct {
+	Ctx context.Context
+	Arg PlaceImageLegalHoldParams
 } {
 	var calls []struct {
-		Ctx    context.Context
-		UserID pgtype.UUID
+		Ctx context.Context
+		Arg PlaceImageLegalHoldParams
 	}
-	mock.lockCountProjectsByUserID.RLock()
-	calls = mock.calls.CountProjectsByUserID
-	mock.lockCountProjectsByUserID.RUnlock()
+	mock.lockPlaceImageLegalHold.RLock()
+	calls = mock.calls.PlaceImageLegalHold
+	mock.lockPlaceImageLegalHold.RUnlock()
 	return calls
 }
 
-// CountUsers calls CountUsersFunc.
-func (mock *QuerierMock) CountUsers(ctx context.Context) (int64, error) {
-	if mock.CountUsersFunc == nil {
-		panic("QuerierMock.CountUsersFunc: method is nil but Querier.CountUsers was just called")
+// PlaceProjectLegalHold calls PlaceProjectLegalHoldFunc.
+func (mock *QuerierMock) PlaceProjectLegalHold(ctx context.Context, arg PlaceProjectLegalHoldParams) (*LegalHold, error) {
+	if mock.PlaceProjectLegalHoldFunc == nil {
+		panic("QuerierMock.PlaceProjectLegalHoldFunc: method is nil but Querier.PlaceProjectLegalHold was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
+		Arg PlaceProjectLegalHoldParams
 	}{
 		Ctx: ctx,
+		Arg: arg,
 	}
-	mock.lockCountUsers.Lock()
-	mock.calls.CountUsers = append(mock.calls.CountUsers, callInfo)
-	mock.lockCountUsers.Unlock()
-	return mock.CountUsersFunc(ctx)
+	mock.lockPlaceProjectLegalHold.Lock()
+	mock.calls.PlaceProjectLegalHold = append(mock.calls.PlaceProjectLegalHold, callInfo)
+	mock.lockPlaceProjectLegalHold.Unlock()
+	return mock.PlaceProjectLegalHoldFunc(ctx, arg)
 }
 
-// CountUsersCalls gets all the calls that were made to CountUsers.
+// PlaceProjectLegalHoldCalls gets all the calls that were made to PlaceProjectLegalHold.
 // Check the length with:
 //
-//	len(mockedQuerier.CountUsersCalls())
-func (mock *QuerierMock) CountUsersCalls() []struct {
+//	len(mockedQuerier.PlaceProjectLegalHoldCalls())
+func (mock *QuerierMock) PlaceProjectLegalHoldCalls() []struct {
 	Ctx context.Context
+	Arg PlaceProjectLegalHoldParams
 } {
 	var calls []struct {
 		Ctx context.Context
+		Arg PlaceProjectLegalHoldParams
 	}
-	mock.lockCountUsers.RLock()
-	calls = mock.calls.CountUsers
-	mock.lockCountUsers.RUnlock()
+	mock.lockPlaceProjectLegalHold.RLock()
+	calls = mock.calls.PlaceProjectLegalHold
+	mock.lockPlaceProjectLegalHold.RUnlock()
 	return calls
 }
 
-// CreateImage calls CreateImageFunc.
-func (mock *QuerierMock) CreateImage(ctx context.Context, arg CreateImageParams) (*CreateImageRow, error) {
-	if mock.CreateImageFunc == nil {
-		panic("QuerierMock.CreateImageFunc: method is nil but Querier.CreateImage was just called")
+// RecordDataAccess calls RecordDataAccessFunc.
+func (mock *QuerierMock) RecordDataAccess(ctx context.Context, arg RecordDataAccessParams) (*DataAccessLog, error) {
+	if mock.RecordDataAccessFunc == nil {
+		panic("QuerierMock.RecordDataAccessFunc: method is nil but Querier.RecordDataAccess was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Arg CreateImageParams
+		Arg RecordDataAccessParams
 	}{
 		Ctx: ctx,
 		Arg: arg,
 	}
-	mock.lockCreateImage.Lock()
-	mock.calls.CreateImage = append(mock.calls.CreateImage, callInfo)
-	mock.lockCreateImage.Unlock()
-	return mock.CreateImageFunc(ctx, arg)
+	mock.lockRecordDataAccess.Lock()
+	mock.calls.RecordDataAccess = append(mock.calls.RecordDataAccess, callInfo)
+	mock.lockRecordDataAccess.Unlock()
+	return mock.RecordDataAccessFunc(ctx, arg)
 }
 
-// CreateImageCalls gets all the calls that were made to CreateImage.
+// RecordDataAccessCalls gets all the calls that were made to RecordDataAccess.
 // Check the length with:
 //
-//	len(mockedQuerier.CreateImageCalls())
-func (mock *QuerierMock) CreateImageCalls() []struct {
+//	len(mockedQuerier.RecordDataAccessCalls())
+func (mock *QuerierMock) RecordDataAccessCalls() []struct {
 	Ctx context.Context
-	Arg CreateImageParams
+	Arg RecordDataAccessParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		Arg CreateImageParams
+		Arg RecordDataAccessParams
 	}
-	mock.lockCreateImage.RLock()
-	calls = mock.calls.CreateImage
-	mock.lockCreateImage.RUnlock()
+	mock.lockRecordDataAccess.RLock()
+	calls = mock.calls.RecordDataAccess
+	mock.lockRecordDataAccess.RUnlock()
 	return calls
 }
 
-// CreateJob calls CreateJobFunc.
-func (mock *QuerierMock) CreateJob(ctx context.Context, arg CreateJobParams) (*Job, error) {
-	if mock.CreateJobFunc == nil {
-		panic("QuerierMock.CreateJobFunc: method is nil but Querier.CreateJob was just called")
+// RecordImageExpedited calls RecordImageExpeditedFunc.
+func (mock *QuerierMock) RecordImageExpedited(ctx context.Context, arg RecordImageExpeditedParams) error {
+	if mock.RecordImageExpeditedFunc == nil {
+		panic("QuerierMock.RecordImageExpeditedFunc: method is nil but Querier.RecordImageExpedited was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Arg CreateJobParams
+		Arg RecordImageExpeditedParams
 	}{
 		Ctx: ctx,
 		Arg: arg,
 	}
-	mock.lockCreateJob.Lock()
-	mock.calls.CreateJob = append(mock.calls.CreateJob, callInfo)
-	mock.lockCreateJob.Unlock()
-	return mock.CreateJobFunc(ctx, arg)
+	mock.lockRecordImageExpedited.Lock()
+	mock.calls.RecordImageExpedited = append(mock.calls.RecordImageExpedited, callInfo)
+	mock.lockRecordImageExpedited.Unlock()
+	return mock.RecordImageExpeditedFunc(ctx, arg)
 }
 
-// CreateJobCalls gets all the calls that were made to CreateJob.
+// RecordImageExpeditedCalls gets all the calls that were made to RecordImageExpedited.
 // Check the length with:
 //
-//	len(mockedQuerier.CreateJobCalls())
-func (mock *QuerierMock) CreateJobCalls() []struct {
+//	len(mockedQuerier.RecordImageExpeditedCalls())
+func (mock *QuerierMock) RecordImageExpeditedCalls() []struct {
 	Ctx context.Context
-	Arg CreateJobParams
+	Arg RecordImageExpeditedParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		Arg CreateJobParams
+		Arg RecordImageExpeditedParams
 	}
-	mock.lockCreateJob.RLock()
-	calls = mock.calls.CreateJob
-	mock.lockCreateJob.RUnlock()
+	mock.lockRecordImageExpedited.RLock()
+	calls = mock.calls.RecordImageExpedited
+	mock.lockRecordImageExpedited.RUnlock()
 	return calls
 }
 
-// CreateProcessedEvent calls CreateProcessedEventFunc.
-func (mock *QuerierMock) CreateProcessedEvent(ctx context.Context, arg CreateProcessedEventParams) (*ProcessedEvent, error) {
-	if mock.CreateProcessedEventFunc == nil {
-		panic("QuerierMock.CreateProcessedEventFunc: method is nil but Querier.CreateProcessedEvent was just called")
+// RecordOrganizationAuditEvent calls RecordOrganizationAuditEventFunc.
+func (mock *QuerierMock) RecordOrganizationAuditEvent(ctx context.Context, arg RecordOrganizationAuditEventParams) error {
+	if mock.RecordOrganizationAuditEventFunc == nil {
+		panic("QuerierMock.RecordOrganizationAuditEventFunc: method is nil but Querier.RecordOrganizationAuditEvent was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Arg CreateProcessedEventParams
+		Arg RecordOrganizationAuditEventParams
 	}{
 		Ctx: ctx,
 		Arg: arg,
 	}
-	mock.lockCreateProcessedEvent.Lock()
-	mock.calls.CreateProcessedEvent = append(mock.calls.CreateProcessedEvent, callInfo)
-	mock.lockCreateProcessedEvent.Unlock()
-	return mock.CreateProcessedEventFunc(ctx, arg)
+	mock.lockRecordOrganizationAuditEvent.Lock()
+	mock.calls.RecordOrganizationAuditEvent = append(mock.calls.RecordOrganizationAuditEvent, callInfo)
+	mock.lockRecordOrganizationAuditEvent.Unlock()
+	return mock.RecordOrganizationAuditEventFunc(ctx, arg)
 }
 
-// CreateProcessedEventCalls gets all the calls that were made to CreateProcessedEvent.
+// RecordOrganizationAuditEventCalls gets all the calls that were made to RecordOrganizationAuditEvent.
 // Check the length with:
 //
-//	len(mockedQuerier.CreateProcessedEventCalls())
-func (mock *QuerierMock) CreateProcessedEventCalls() []struct {
+//	len(mockedQuerier.RecordOrganizationAuditEventCalls())
+func (mock *QuerierMock) RecordOrganizationAuditEventCalls() []struct {
 	Ctx context.Context
-	Arg CreateProcessedEventParams
+	Arg RecordOrganizationAuditEventParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		Arg CreateProcessedEventParams
+		Arg RecordOrganizationAuditEventParams
 	}
-	mock.lockCreateProcessedEvent.RLock()
-	calls = mock.calls.CreateProcessedEvent
-	mock.lockCreateProcessedEvent.RUnlock()
+	mock.lockRecordOrganizationAuditEvent.RLock()
+	calls = mock.calls.RecordOrganizationAuditEvent
+	mock.lockRecordOrganizationAuditEvent.RUnlock()
 	return calls
 }
 
-// CreateProject calls CreateProjectFunc.
-func (mock *QuerierMock) CreateProject(ctx context.Context, arg CreateProjectParams) (*CreateProjectRow, error) {
-	if mock.CreateProjectFunc == nil {
-		panic("QuerierMock.CreateProjectFunc: method is nil but Querier.CreateProject was just called")
+// RecordTeamWebhookDelivery calls RecordTeamWebhookDeliveryFunc.
+func (mock *QuerierMock) RecordTeamWebhookDelivery(ctx context.Context, arg RecordTeamWebhookDeliveryParams) error {
+	if mock.RecordTeamWebhookDeliveryFunc == nil {
+		panic("QuerierMock.RecordTeamWebhookDeliveryFunc: method is nil but Querier.RecordTeamWebhookDelivery was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Arg CreateProjectParams
+		Arg RecordTeamWebhookDeliveryParams
 	}{
 		Ctx: ctx,
 		Arg: arg,
 	}
-	mock.lockCreateProject.Lock()
-	mock.calls.CreateProject = append(mock.calls.CreateProject, callInfo)
-	mock.lockCreateProject.Unlock()
-	return mock.CreateProjectFunc(ctx, arg)
+	mock.lockRecordTeamWebhookDelivery.Lock()
+	mock.calls.RecordTeamWebhookDelivery = append(mock.calls.RecordTeamWebhookDelivery, callInfo)
+	mock.lockRecordTeamWebhookDelivery.Unlock()
+	return mock.RecordTeamWebhookDeliveryFunc(ctx, arg)
 }
 
-// CreateProjectCalls gets all the calls that were made to CreateProject.
+// RecordTeamWebhookDeliveryCalls gets all the calls that were made to RecordTeamWebhookDelivery.
 // Check the length with:
 //
-//	len(mockedQuerier.CreateProjectCalls())
-func (mock *QuerierMock) CreateProjectCalls() []struct {
+//	len(mockedQuerier.RecordTeamWebhookDeliveryCalls())
+func (mock *QuerierMock) RecordTeamWebhookDeliveryCalls() []struct {
 	Ctx context.Context
-	Arg CreateProjectParams
+	Arg RecordTeamWebhookDeliveryParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		Arg CreateProjectParams
+		Arg RecordTeamWebhookDeliveryParams
 	}
-	mock.lockCreateProject.RLock()
-	calls = mock.calls.CreateProject
-	mock.lockCreateProject.RUnlock()
+	mock.lockRecordTeamWebhookDelivery.RLock()
+	calls = mock.calls.RecordTeamWebhookDelivery
+	mock.lockRecordTeamWebhookDelivery.RUnlock()
 	return calls
 }
 
-// CreateUser calls CreateUserFunc.
-func (mock *QuerierMock) CreateUser(ctx context.Context, arg CreateUserParams) (*CreateUserRow, error) {
-	if mock.CreateUserFunc == nil {
-		panic("QuerierMock.CreateUserFunc: method is nil but Querier.CreateUser was just called")
+// RecordTeamWebhookDeliveryAttempt calls RecordTeamWebhookDeliveryAttemptFunc.
+func (mock *QuerierMock) RecordTeamWebhookDeliveryAttempt(ctx context.Context, arg RecordTeamWebhookDeliveryAttemptParams) (*TeamWebhookDelivery, error) {
+	if mock.RecordTeamWebhookDeliveryAttemptFunc == nil {
+		panic("QuerierMock.RecordTeamWebhookDeliveryAttemptFunc: method is nil but Querier.RecordTeamWebhookDeliveryAttempt was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Arg CreateUserParams
+		Arg RecordTeamWebhookDeliveryAttemptParams
 	}{
 		Ctx: ctx,
 		Arg: arg,
 	}
-	mock.lockCreateUser.Lock()
-	mock.calls.CreateUser = append(mock.calls.CreateUser, callInfo)
-	mock.lockCreateUser.Unlock()
-	return mock.CreateUserFunc(ctx, arg)
+	mock.lockRecordTeamWebhookDeliveryAttempt.Lock()
+	mock.calls.RecordTeamWebhookDeliveryAttempt = append(mock.calls.RecordTeamWebhookDeliveryAttempt, callInfo)
+	mock.lockRecordTeamWebhookDeliveryAttempt.Unlock()
+	return mock.RecordTeamWebhookDeliveryAttemptFunc(ctx, arg)
 }
 
-// CreateUserCalls gets all the calls that were made to CreateUser.
+// RecordTeamWebhookDeliveryAttemptCalls gets all the calls that were made to RecordTeamWebhookDeliveryAttempt.
 // Check the length with:
 //
-//	len(mockedQuerier.CreateUserCalls())
-func (mock *QuerierMock) CreateUserCalls() []struct {
+//	len(mockedQuerier.RecordTeamWebhookDeliveryAttemptCalls())
+func (mock *QuerierMock) RecordTeamWebhookDeliveryAttemptCalls() []struct {
 	Ctx context.Context
-	Arg CreateUserParams
+	Arg RecordTeamWebhookDeliveryAttemptParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		Arg CreateUserParams
+		Arg RecordTeamWebhookDeliveryAttemptParams
 	}
-	mock.lockCreateUser.RLock()
-	calls = mock.calls.CreateUser
-	mock.lockCreateUser.RUnlock()
+	mock.lockRecordTeamWebhookDeliveryAttempt.RLock()
+	calls = mock.calls.RecordTeamWebhookDeliveryAttempt
+	mock.lockRecordTeamWebhookDeliveryAttempt.RUnlock()
 	return calls
 }
 
-// DeleteImage calls DeleteImageFunc.
-func (mock *QuerierMock) DeleteImage(ctx context.Context, id pgtype.UUID) error {
-	if mock.DeleteImageFunc == nil {
-		panic("QuerierMock.DeleteImageFunc: method is nil but Querier.DeleteImage was just called")
+// ReleaseImageLegalHold calls ReleaseImageLegalHoldFunc.
+func (mock *QuerierMock) ReleaseImageLegalHold(ctx context.Context, imageID pgtype.UUID) (int64, error) {
+	if mock.ReleaseImageLegalHoldFunc == nil {
+		panic("QuerierMock.ReleaseImageLegalHoldFunc: method is nil but Querier.ReleaseImageLegalHold was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ImageID pgtype.UUID
+	}{
+		Ctx:     ctx,
+		ImageID: imageID,
+	}
+	mock.lockReleaseImageLegalHold.Lock()
+	mock.calls.ReleaseImageLegalHold = append(mock.calls.ReleaseImageLegalHold, callInfo)
+	mock.lockReleaseImageLegalHold.Unlock()
+	return mock.ReleaseImageLegalHoldFunc(ctx, imageID)
+}
+
+// ReleaseImageLegalHoldCalls gets all the calls that were made to ReleaseImageLegalHold.
+// Check the length with:
+//
+//	len(mockedQuerier.ReleaseImageLegalHoldCalls())
+func (mock *QuerierMock) ReleaseImageLegalHoldCalls() []struct {
+	Ctx     context.Context
+	ImageID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ImageID pgtype.UUID
+	}
+	mock.lockReleaseImageLegalHold.RLock()
+	calls = mock.calls.ReleaseImageLegalHold
+	mock.lockReleaseImageLegalHold.RUnlock()
+	return calls
+}
+
+// ReleaseProjectLegalHold calls ReleaseProjectLegalHoldFunc.
+func (mock *QuerierMock) ReleaseProjectLegalHold(ctx context.Context, projectID pgtype.UUID) (int64, error) {
+	if mock.ReleaseProjectLegalHoldFunc == nil {
+		panic("QuerierMock.ReleaseProjectLegalHoldFunc: method is nil but Querier.ReleaseProjectLegalHold was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockReleaseProjectLegalHold.Lock()
+	mock.calls.ReleaseProjectLegalHold = append(mock.calls.ReleaseProjectLegalHold, callInfo)
+	mock.lockReleaseProjectLegalHold.Unlock()
+	return mock.ReleaseProjectLegalHoldFunc(ctx, projectID)
+}
+
+// ReleaseProjectLegalHoldCalls gets all the calls that were made to ReleaseProjectLegalHold.
+// Check the length with:
+//
+//	len(mockedQuerier.ReleaseProjectLegalHoldCalls())
+func (mock *QuerierMock) ReleaseProjectLegalHoldCalls() []struct {
+	Ctx       context.Context
+	ProjectID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID pgtype.UUID
+	}
+	mock.lockReleaseProjectLegalHold.RLock()
+	calls = mock.calls.ReleaseProjectLegalHold
+	mock.lockReleaseProjectLegalHold.RUnlock()
+	return calls
+}
+
+// ReleaseUsage calls ReleaseUsageFunc.
+func (mock *QuerierMock) ReleaseUsage(ctx context.Context, arg ReleaseUsageParams) error {
+	if mock.ReleaseUsageFunc == nil {
+		panic("QuerierMock.ReleaseUsageFunc: method is nil but Querier.ReleaseUsage was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		ID  pgtype.UUID
+		Arg ReleaseUsageParams
 	}{
 		Ctx: ctx,
-		ID:  id,
+		Arg: arg,
 	}
-	mock.lockDeleteImage.Lock()
-	mock.calls.DeleteImage = append(mock.calls.DeleteImage, callInfo)
-	mock.lockDeleteImage.Unlock()
-	return mock.DeleteImageFunc(ctx, id)
+	mock.lockReleaseUsage.Lock()
+	mock.calls.ReleaseUsage = append(mock.calls.ReleaseUsage, callInfo)
+	mock.lockReleaseUsage.Unlock()
+	return mock.ReleaseUsageFunc(ctx, arg)
 }
 
-// DeleteImageCalls gets all the calls that were made to DeleteImage.
+// ReleaseUsageCalls gets all the calls that were made to ReleaseUsage.
 // Check the length with:
 //
-//	len(mockedQuerier.DeleteImageCalls())
-func (mock *QuerierMock) DeleteImageCalls() []struct {
+//	len(mockedQuerier.ReleaseUsageCalls())
+func (mock *QuerierMock) ReleaseUsageCalls() []struct {
 	Ctx context.Context
-	ID  pgtype.UUID
+	Arg ReleaseUsageParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		ID  pgtype.UUID
+		Arg ReleaseUsageParams
 	}
-	mock.lockDeleteImage.RLock()
-	calls = mock.calls.DeleteImage
-	mock.lockDeleteImage.RUnlock()
+	mock.lockReleaseUsage.RLock()
+	calls = mock.calls.ReleaseUsage
+	mock.lockReleaseUsage.RUnlock()
 	return calls
 }
 
-// DeleteImagesByProjectID calls DeleteImagesByProjectIDFunc.
-func (mock *QuerierMock) DeleteImagesByProjectID(ctx context.Context, projectID pgtype.UUID) error {
-	if mock.DeleteImagesByProjectIDFunc == nil {
-		panic("QuerierMock.DeleteImagesByProjectIDFunc: method is nil but Querier.DeleteImagesByProjectID was just called")
+// RemoveProjectRetentionExemption calls RemoveProjectRetentionExemptionFunc.
+func (mock *QuerierMock) RemoveProjectRetentionExemption(ctx context.Context, projectID pgtype.UUID) error {
+	if mock.RemoveProjectRetentionExemptionFunc == nil {
+		panic("QuerierMock.RemoveProjectRetentionExemptionFunc: method is nil but Querier.RemoveProjectRetentionExemption was just called")
 	}
 	callInfo := struct {
 		Ctx       context.Context
@@ -1073,17 +10795,17 @@ func (mock *QuerierMock) DeleteImagesByProjectID(ctx context.Context, projectID
 		Ctx:       ctx,
 		ProjectID: projectID,
 	}
-	mock.lockDeleteImagesByProjectID.Lock()
-	mock.calls.DeleteImagesByProjectID = append(mock.calls.DeleteImagesByProjectID, callInfo)
-	mock.lockDeleteImagesByProjectID.Unlock()
-	return mock.DeleteImagesByProjectIDFunc(ctx, projectID)
+	mock.lockRemoveProjectRetentionExemption.Lock()
+	mock.calls.RemoveProjectRetentionExemption = append(mock.calls.RemoveProjectRetentionExemption, callInfo)
+	mock.lockRemoveProjectRetentionExemption.Unlock()
+	return mock.RemoveProjectRetentionExemptionFunc(ctx, projectID)
 }
 
-// DeleteImagesByProjectIDCalls gets all the calls that were made to DeleteImagesByProjectID.
+// RemoveProjectRetentionExemptionCalls gets all the calls that were made to RemoveProjectRetentionExemption.
 // Check the length with:
 //
-//	len(mockedQuerier.DeleteImagesByProjectIDCalls())
-func (mock *QuerierMock) DeleteImagesByProjectIDCalls() []struct {
+//	len(mockedQuerier.RemoveProjectRetentionExemptionCalls())
+func (mock *QuerierMock) RemoveProjectRetentionExemptionCalls() []struct {
 	Ctx       context.Context
 	ProjectID pgtype.UUID
 } {
@@ -1091,232 +10813,304 @@ func (mock *QuerierMock) DeleteImagesByProjectIDCalls() []struct {
 		Ctx       context.Context
 		ProjectID pgtype.UUID
 	}
-	mock.lockDeleteImagesByProjectID.RLock()
-	calls = mock.calls.DeleteImagesByProjectID
-	mock.lockDeleteImagesByProjectID.RUnlock()
+	mock.lockRemoveProjectRetentionExemption.RLock()
+	calls = mock.calls.RemoveProjectRetentionExemption
+	mock.lockRemoveProjectRetentionExemption.RUnlock()
 	return calls
 }
 
-// DeleteJob calls DeleteJobFunc.
-func (mock *QuerierMock) DeleteJob(ctx context.Context, id pgtype.UUID) error {
-	if mock.DeleteJobFunc == nil {
-		panic("QuerierMock.DeleteJobFunc: method is nil but Querier.DeleteJob was just called")
+// RemoveSCIMGroupMembers calls RemoveSCIMGroupMembersFunc.
+func (mock *QuerierMock) RemoveSCIMGroupMembers(ctx context.Context, arg RemoveSCIMGroupMembersParams) (int64, error) {
+	if mock.RemoveSCIMGroupMembersFunc == nil {
+		panic("QuerierMock.RemoveSCIMGroupMembersFunc: method is nil but Querier.RemoveSCIMGroupMembers was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		ID  pgtype.UUID
+		Arg RemoveSCIMGroupMembersParams
 	}{
 		Ctx: ctx,
-		ID:  id,
+		Arg: arg,
 	}
-	mock.lockDeleteJob.Lock()
-	mock.calls.DeleteJob = append(mock.calls.DeleteJob, callInfo)
-	mock.lockDeleteJob.Unlock()
-	return mock.DeleteJobFunc(ctx, id)
+	mock.lockRemoveSCIMGroupMembers.Lock()
+	mock.calls.RemoveSCIMGroupMembers = append(mock.calls.RemoveSCIMGroupMembers, callInfo)
+	mock.lockRemoveSCIMGroupMembers.Unlock()
+	return mock.RemoveSCIMGroupMembersFunc(ctx, arg)
 }
 
-// DeleteJobCalls gets all the calls that were made to DeleteJob.
+// RemoveSCIMGroupMembersCalls gets all the calls that were made to RemoveSCIMGroupMembers.
 // Check the length with:
 //
-//	len(mockedQuerier.DeleteJobCalls())
-func (mock *QuerierMock) DeleteJobCalls() []struct {
+//	len(mockedQuerier.RemoveSCIMGroupMembersCalls())
+func (mock *QuerierMock) RemoveSCIMGroupMembersCalls() []struct {
 	Ctx context.Context
-	ID  pgtype.UUID
+	Arg RemoveSCIMGroupMembersParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		ID  pgtype.UUID
+		Arg RemoveSCIMGroupMembersParams
 	}
-	mock.lockDeleteJob.RLock()
-	calls = mock.calls.DeleteJob
-	mock.lockDeleteJob.RUnlock()
+	mock.lockRemoveSCIMGroupMembers.RLock()
+	calls = mock.calls.RemoveSCIMGroupMembers
+	mock.lockRemoveSCIMGroupMembers.RUnlock()
 	return calls
 }
 
-// DeleteJobsByImageID calls DeleteJobsByImageIDFunc.
-func (mock *QuerierMock) DeleteJobsByImageID(ctx context.Context, imageID pgtype.UUID) error {
-	if mock.DeleteJobsByImageIDFunc == nil {
-		panic("QuerierMock.DeleteJobsByImageIDFunc: method is nil but Querier.DeleteJobsByImageID was just called")
+// ReserveUsage calls ReserveUsageFunc.
+func (mock *QuerierMock) ReserveUsage(ctx context.Context, arg ReserveUsageParams) (int32, error) {
+	if mock.ReserveUsageFunc == nil {
+		panic("QuerierMock.ReserveUsageFunc: method is nil but Querier.ReserveUsage was just called")
 	}
 	callInfo := struct {
-		Ctx     context.Context
-		ImageID pgtype.UUID
+		Ctx context.Context
+		Arg ReserveUsageParams
 	}{
-		Ctx:     ctx,
-		ImageID: imageID,
+		Ctx: ctx,
+		Arg: arg,
 	}
-	mock.lockDeleteJobsByImageID.Lock()
-	mock.calls.DeleteJobsByImageID = append(mock.calls.DeleteJobsByImageID, callInfo)
-	mock.lockDeleteJobsByImageID.Unlock()
-	return mock.DeleteJobsByImageIDFunc(ctx, imageID)
+	mock.lockReserveUsage.Lock()
+	mock.calls.ReserveUsage = append(mock.calls.ReserveUsage, callInfo)
+	mock.lockReserveUsage.Unlock()
+	return mock.ReserveUsageFunc(ctx, arg)
+}
+
+// ReserveUsageCalls gets all the calls that were made to ReserveUsage.
+// Check the length with:
+//
+//	len(mockedQuerier.ReserveUsageCalls())
+func (mock *QuerierMock) ReserveUsageCalls() []struct {
+	Ctx context.Context
+	Arg ReserveUsageParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg ReserveUsageParams
+	}
+	mock.lockReserveUsage.RLock()
+	calls = mock.calls.ReserveUsage
+	mock.lockReserveUsage.RUnlock()
+	return calls
+}
+
+// RevokeAPIKey calls RevokeAPIKeyFunc.
+func (mock *QuerierMock) RevokeAPIKey(ctx context.Context, arg RevokeAPIKeyParams) (int64, error) {
+	if mock.RevokeAPIKeyFunc == nil {
+		panic("QuerierMock.RevokeAPIKeyFunc: method is nil but Querier.RevokeAPIKey was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg RevokeAPIKeyParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockRevokeAPIKey.Lock()
+	mock.calls.RevokeAPIKey = append(mock.calls.RevokeAPIKey, callInfo)
+	mock.lockRevokeAPIKey.Unlock()
+	return mock.RevokeAPIKeyFunc(ctx, arg)
+}
+
+// RevokeAPIKeyCalls gets all the calls that were made to RevokeAPIKey.
+// Check the length with:
+//
+//	len(mockedQuerier.RevokeAPIKeyCalls())
+func (mock *QuerierMock) RevokeAPIKeyCalls() []struct {
+	Ctx context.Context
+	Arg RevokeAPIKeyParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg RevokeAPIKeyParams
+	}
+	mock.lockRevokeAPIKey.RLock()
+	calls = mock.calls.RevokeAPIKey
+	mock.lockRevokeAPIKey.RUnlock()
+	return calls
+}
+
+// RevokeProjectInvitation calls RevokeProjectInvitationFunc.
+func (mock *QuerierMock) RevokeProjectInvitation(ctx context.Context, arg RevokeProjectInvitationParams) (*RevokeProjectInvitationRow, error) {
+	if mock.RevokeProjectInvitationFunc == nil {
+		panic("QuerierMock.RevokeProjectInvitationFunc: method is nil but Querier.RevokeProjectInvitation was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg RevokeProjectInvitationParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockRevokeProjectInvitation.Lock()
+	mock.calls.RevokeProjectInvitation = append(mock.calls.RevokeProjectInvitation, callInfo)
+	mock.lockRevokeProjectInvitation.Unlock()
+	return mock.RevokeProjectInvitationFunc(ctx, arg)
 }
 
-// DeleteJobsByImageIDCalls gets all the calls that were made to DeleteJobsByImageID.
+// RevokeProjectInvitationCalls gets all the calls that were made to RevokeProjectInvitation.
 // Check the length with:
 //
-//	len(mockedQuerier.DeleteJobsByImageIDCalls())
-func (mock *QuerierMock) DeleteJobsByImageIDCalls() []struct {
-	Ctx     context.Context
-	ImageID pgtype.UUID
+//	len(mockedQuerier.RevokeProjectInvitationCalls())
+func (mock *QuerierMock) RevokeProjectInvitationCalls() []struct {
+	Ctx context.Context
+	Arg RevokeProjectInvitationParams
 } {
 	var calls []struct {
-		Ctx     context.Context
-		ImageID pgtype.UUID
+		Ctx context.Context
+		Arg RevokeProjectInvitationParams
 	}
-	mock.lockDeleteJobsByImageID.RLock()
-	calls = mock.calls.DeleteJobsByImageID
-	mock.lockDeleteJobsByImageID.RUnlock()
+	mock.lockRevokeProjectInvitation.RLock()
+	calls = mock.calls.RevokeProjectInvitation
+	mock.lockRevokeProjectInvitation.RUnlock()
 	return calls
 }
 
-// DeleteOldProcessedEvents calls DeleteOldProcessedEventsFunc.
-func (mock *QuerierMock) DeleteOldProcessedEvents(ctx context.Context, receivedAt pgtype.Timestamptz) error {
-	if mock.DeleteOldProcessedEventsFunc == nil {
-		panic("QuerierMock.DeleteOldProcessedEventsFunc: method is nil but Querier.DeleteOldProcessedEvents was just called")
+// RotateProjectShareKey calls RotateProjectShareKeyFunc.
+func (mock *QuerierMock) RotateProjectShareKey(ctx context.Context, projectID pgtype.UUID) (*ProjectShareKey, error) {
+	if mock.RotateProjectShareKeyFunc == nil {
+		panic("QuerierMock.RotateProjectShareKeyFunc: method is nil but Querier.RotateProjectShareKey was just called")
 	}
 	callInfo := struct {
-		Ctx        context.Context
-		ReceivedAt pgtype.Timestamptz
+		Ctx       context.Context
+		ProjectID pgtype.UUID
 	}{
-		Ctx:        ctx,
-		ReceivedAt: receivedAt,
+		Ctx:       ctx,
+		ProjectID: projectID,
 	}
-	mock.lockDeleteOldProcessedEvents.Lock()
-	mock.calls.DeleteOldProcessedEvents = append(mock.calls.DeleteOldProcessedEvents, callInfo)
-	mock.lockDeleteOldProcessedEvents.Unlock()
-	return mock.DeleteOldProcessedEventsFunc(ctx, receivedAt)
+	mock.lockRotateProjectShareKey.Lock()
+	mock.calls.RotateProjectShareKey = append(mock.calls.RotateProjectShareKey, callInfo)
+	mock.lockRotateProjectShareKey.Unlock()
+	return mock.RotateProjectShareKeyFunc(ctx, projectID)
 }
 
-// DeleteOldProcessedEventsCalls gets all the calls that were made to DeleteOldProcessedEvents.
+// RotateProjectShareKeyCalls gets all the calls that were made to RotateProjectShareKey.
 // Check the length with:
 //
-//	len(mockedQuerier.DeleteOldProcessedEventsCalls())
-func (mock *QuerierMock) DeleteOldProcessedEventsCalls() []struct {
-	Ctx        context.Context
-	ReceivedAt pgtype.Timestamptz
+//	len(mockedQuerier.RotateProjectShareKeyCalls())
+func (mock *QuerierMock) RotateProjectShareKeyCalls() []struct {
+	Ctx       context.Context
+	ProjectID pgtype.UUID
 } {
 	var calls []struct {
-		Ctx        context.Context
-		ReceivedAt pgtype.Timestamptz
+		Ctx       context.Context
+		ProjectID pgtype.UUID
 	}
-	mock.lockDeleteOldProcessedEvents.RLock()
-	calls = mock.calls.DeleteOldProcessedEvents
-	mock.lockDeleteOldProcessedEvents.RUnlock()
+	mock.lockRotateProjectShareKey.RLock()
+	calls = mock.calls.RotateProjectShareKey
+	mock.lockRotateProjectShareKey.RUnlock()
 	return calls
 }
 
-// DeleteProject calls DeleteProjectFunc.
-func (mock *QuerierMock) DeleteProject(ctx context.Context, id pgtype.UUID) error {
-	if mock.DeleteProjectFunc == nil {
-		panic("QuerierMock.DeleteProjectFunc: method is nil but Querier.DeleteProject was just called")
+// RotateTeamWebhookSecret calls RotateTeamWebhookSecretFunc.
+func (mock *QuerierMock) RotateTeamWebhookSecret(ctx context.Context, arg RotateTeamWebhookSecretParams) (*TeamWebhook, error) {
+	if mock.RotateTeamWebhookSecretFunc == nil {
+		panic("QuerierMock.RotateTeamWebhookSecretFunc: method is nil but Querier.RotateTeamWebhookSecret was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		ID  pgtype.UUID
+		Arg RotateTeamWebhookSecretParams
 	}{
 		Ctx: ctx,
-		ID:  id,
+		Arg: arg,
 	}
-	mock.lockDeleteProject.Lock()
-	mock.calls.DeleteProject = append(mock.calls.DeleteProject, callInfo)
-	mock.lockDeleteProject.Unlock()
-	return mock.DeleteProjectFunc(ctx, id)
+	mock.lockRotateTeamWebhookSecret.Lock()
+	mock.calls.RotateTeamWebhookSecret = append(mock.calls.RotateTeamWebhookSecret, callInfo)
+	mock.lockRotateTeamWebhookSecret.Unlock()
+	return mock.RotateTeamWebhookSecretFunc(ctx, arg)
 }
 
-// DeleteProjectCalls gets all the calls that were made to DeleteProject.
+// RotateTeamWebhookSecretCalls gets all the calls that were made to RotateTeamWebhookSecret.
 // Check the length with:
 //
-//	len(mockedQuerier.DeleteProjectCalls())
-func (mock *QuerierMock) DeleteProjectCalls() []struct {
+//	len(mockedQuerier.RotateTeamWebhookSecretCalls())
+func (mock *QuerierMock) RotateTeamWebhookSecretCalls() []struct {
 	Ctx context.Context
-	ID  pgtype.UUID
+	Arg RotateTeamWebhookSecretParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		ID  pgtype.UUID
+		Arg RotateTeamWebhookSecretParams
 	}
-	mock.lockDeleteProject.RLock()
-	calls = mock.calls.DeleteProject
-	mock.lockDeleteProject.RUnlock()
+	mock.lockRotateTeamWebhookSecret.RLock()
+	calls = mock.calls.RotateTeamWebhookSecret
+	mock.lockRotateTeamWebhookSecret.RUnlock()
 	return calls
 }
 
-// DeleteProjectByUserID calls DeleteProjectByUserIDFunc.
-func (mock *QuerierMock) DeleteProjectByUserID(ctx context.Context, arg DeleteProjectByUserIDParams) error {
-	if mock.DeleteProjectByUserIDFunc == nil {
-		panic("QuerierMock.DeleteProjectByUserIDFunc: method is nil but Querier.DeleteProjectByUserID was just called")
+// SetOrganizationIPAllowlist calls SetOrganizationIPAllowlistFunc.
+func (mock *QuerierMock) SetOrganizationIPAllowlist(ctx context.Context, arg SetOrganizationIPAllowlistParams) ([]string, error) {
+	if mock.SetOrganizationIPAllowlistFunc == nil {
+		panic("QuerierMock.SetOrganizationIPAllowlistFunc: method is nil but Querier.SetOrganizationIPAllowlist was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Arg DeleteProjectByUserIDParams
+		Arg SetOrganizationIPAllowlistParams
 	}{
 		Ctx: ctx,
 		Arg: arg,
 	}
-	mock.lockDeleteProjectByUserID.Lock()
-	mock.calls.DeleteProjectByUserID = append(mock.calls.DeleteProjectByUserID, callInfo)
-	mock.lockDeleteProjectByUserID.Unlock()
-	return mock.DeleteProjectByUserIDFunc(ctx, arg)
+	mock.lockSetOrganizationIPAllowlist.Lock()
+	mock.calls.SetOrganizationIPAllowlist = append(mock.calls.SetOrganizationIPAllowlist, callInfo)
+	mock.lockSetOrganizationIPAllowlist.Unlock()
+	return mock.SetOrganizationIPAllowlistFunc(ctx, arg)
 }
 
-// DeleteProjectByUserIDCalls gets all the calls that were made to DeleteProjectByUserID.
+// SetOrganizationIPAllowlistCalls gets all the calls that were made to SetOrganizationIPAllowlist.
 // Check the length with:
 //
-//	len(mockedQuerier.DeleteProjectByUserIDCalls())
-func (mock *QuerierMock) DeleteProjectByUserIDCalls() []struct {
+//	len(mockedQuerier.SetOrganizationIPAllowlistCalls())
+func (mock *QuerierMock) SetOrganizationIPAllowlistCalls() []struct {
 	Ctx context.Context
-	Arg DeleteProjectByUserIDParams
+	Arg SetOrganizationIPAllowlistParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		Arg DeleteProjectByUserIDParams
+		Arg SetOrganizationIPAllowlistParams
 	}
-	mock.lockDeleteProjectByUserID.RLock()
-	calls = mock.calls.DeleteProjectByUserID
-	mock.lockDeleteProjectByUserID.RUnlock()
+	mock.lockSetOrganizationIPAllowlist.RLock()
+	calls = mock.calls.SetOrganizationIPAllowlist
+	mock.lockSetOrganizationIPAllowlist.RUnlock()
 	return calls
 }
 
-// DeleteSubscriptionByStripeID calls DeleteSubscriptionByStripeIDFunc.
-func (mock *QuerierMock) DeleteSubscriptionByStripeID(ctx context.Context, stripeSubscriptionID string) error {
-	if mock.DeleteSubscriptionByStripeIDFunc == nil {
-		panic("QuerierMock.DeleteSubscriptionByStripeIDFunc: method is nil but Querier.DeleteSubscriptionByStripeID was just called")
+// SetProjectImagesRoomType calls SetProjectImagesRoomTypeFunc.
+func (mock *QuerierMock) SetProjectImagesRoomType(ctx context.Context, arg SetProjectImagesRoomTypeParams) (int64, error) {
+	if mock.SetProjectImagesRoomTypeFunc == nil {
+		panic("QuerierMock.SetProjectImagesRoomTypeFunc: method is nil but Querier.SetProjectImagesRoomType was just called")
 	}
 	callInfo := struct {
-		Ctx                  context.Context
-		StripeSubscriptionID string
+		Ctx context.Context
+		Arg SetProjectImagesRoomTypeParams
 	}{
-		Ctx:                  ctx,
-		StripeSubscriptionID: stripeSubscriptionID,
+		Ctx: ctx,
+		Arg: arg,
 	}
-	mock.lockDeleteSubscriptionByStripeID.Lock()
-	mock.calls.DeleteSubscriptionByStripeID = append(mock.calls.DeleteSubscriptionByStripeID, callInfo)
-	mock.lockDeleteSubscriptionByStripeID.Unlock()
-	return mock.DeleteSubscriptionByStripeIDFunc(ctx, stripeSubscriptionID)
+	mock.lockSetProjectImagesRoomType.Lock()
+	mock.calls.SetProjectImagesRoomType = append(mock.calls.SetProjectImagesRoomType, callInfo)
+	mock.lockSetProjectImagesRoomType.Unlock()
+	return mock.SetProjectImagesRoomTypeFunc(ctx, arg)
 }
 
-// DeleteSubscriptionByStripeIDCalls gets all the calls that were made to DeleteSubscriptionByStripeID.
+// SetProjectImagesRoomTypeCalls gets all the calls that were made to SetProjectImagesRoomType.
 // Check the length with:
 //
-//	len(mockedQuerier.DeleteSubscriptionByStripeIDCalls())
-func (mock *QuerierMock) DeleteSubscriptionByStripeIDCalls() []struct {
-	Ctx                  context.Context
-	StripeSubscriptionID string
+//	len(mockedQuerier.SetProjectImagesRoomTypeCalls())
+func (mock *QuerierMock) SetProjectImagesRoomTypeCalls() []struct {
+	Ctx context.Context
+	Arg SetProjectImagesRoomTypeParams
 } {
 	var calls []struct {
-		Ctx                  context.Context
-		StripeSubscriptionID string
+		Ctx context.Context
+		Arg SetProjectImagesRoomTypeParams
 	}
-	mock.lockDeleteSubscriptionByStripeID.RLock()
-	calls = mock.calls.DeleteSubscriptionByStripeID
-	mock.lockDeleteSubscriptionByStripeID.RUnlock()
+	mock.lockSetProjectImagesRoomType.RLock()
+	calls = mock.calls.SetProjectImagesRoomType
+	mock.lockSetProjectImagesRoomType.RUnlock()
 	return calls
 }
 
-// DeleteUser calls DeleteUserFunc.
-func (mock *QuerierMock) DeleteUser(ctx context.Context, id pgtype.UUID) error {
-	if mock.DeleteUserFunc == nil {
-		panic("QuerierMock.DeleteUserFunc: method is nil but Querier.DeleteUser was just called")
+// StartJob calls StartJobFunc.
+func (mock *QuerierMock) StartJob(ctx context.Context, id pgtype.UUID) (*Job, error) {
+	if mock.StartJobFunc == nil {
+		panic("QuerierMock.StartJobFunc: method is nil but Querier.StartJob was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
@@ -1325,17 +11119,17 @@ func (mock *QuerierMock) DeleteUser(ctx context.Context, id pgtype.UUID) error {
 		Ctx: ctx,
 		ID:  id,
 	}
-	mock.lockDeleteUser.Lock()
-	mock.calls.DeleteUser = append(mock.calls.DeleteUser, callInfo)
-	mock.lockDeleteUser.Unlock()
-	return mock.DeleteUserFunc(ctx, id)
+	mock.lockStartJob.Lock()
+	mock.calls.StartJob = append(mock.calls.StartJob, callInfo)
+	mock.lockStartJob.Unlock()
+	return mock.StartJobFunc(ctx, id)
 }
 
-// DeleteUserCalls gets all the calls that were made to DeleteUser.
+// StartJobCalls gets all the calls that were made to StartJob.
 // Check the length with:
 //
-//	len(mockedQuerier.DeleteUserCalls())
-func (mock *QuerierMock) DeleteUserCalls() []struct {
+//	len(mockedQuerier.StartJobCalls())
+func (mock *QuerierMock) StartJobCalls() []struct {
 	Ctx context.Context
 	ID  pgtype.UUID
 } {
@@ -1343,84 +11137,88 @@ func (mock *QuerierMock) DeleteUserCalls() []struct {
 		Ctx context.Context
 		ID  pgtype.UUID
 	}
-	mock.lockDeleteUser.RLock()
-	calls = mock.calls.DeleteUser
-	mock.lockDeleteUser.RUnlock()
+	mock.lockStartJob.RLock()
+	calls = mock.calls.StartJob
+	mock.lockStartJob.RUnlock()
 	return calls
 }
 
-// FailJob calls FailJobFunc.
-func (mock *QuerierMock) FailJob(ctx context.Context, arg FailJobParams) (*Job, error) {
-	if mock.FailJobFunc == nil {
-		panic("QuerierMock.FailJobFunc: method is nil but Querier.FailJob was just called")
+// SumPaidInvoicesSince calls SumPaidInvoicesSinceFunc.
+func (mock *QuerierMock) SumPaidInvoicesSince(ctx context.Context, since pgtype.Timestamptz) ([]*SumPaidInvoicesSinceRow, error) {
+	if mock.SumPaidInvoicesSinceFunc == nil {
+		panic("QuerierMock.SumPaidInvoicesSinceFunc: method is nil but Querier.SumPaidInvoicesSince was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
-		Arg FailJobParams
+		Ctx   context.Context
+		Since pgtype.Timestamptz
 	}{
-		Ctx: ctx,
-		Arg: arg,
+		Ctx:   ctx,
+		Since: since,
 	}
-	mock.lockFailJob.Lock()
-	mock.calls.FailJob = append(mock.calls.FailJob, callInfo)
-	mock.lockFailJob.Unlock()
-	return mock.FailJobFunc(ctx, arg)
+	mock.lockSumPaidInvoicesSince.Lock()
+	mock.calls.SumPaidInvoicesSince = append(mock.calls.SumPaidInvoicesSince, callInfo)
+	mock.lockSumPaidInvoicesSince.Unlock()
+	return mock.SumPaidInvoicesSinceFunc(ctx, since)
 }
 
-// FailJobCalls gets all the calls that were made to FailJob.
+// SumPaidInvoicesSinceCalls gets all the calls that were made to SumPaidInvoicesSince.
 // Check the length with:
 //
-//	len(mockedQuerier.FailJobCalls())
-func (mock *QuerierMock) FailJobCalls() []struct {
-	Ctx context.Context
-	Arg FailJobParams
+//	len(mockedQuerier.SumPaidInvoicesSinceCalls())
+func (mock *QuerierMock) SumPaidInvoicesSinceCalls() []struct {
+	Ctx   context.Context
+	Since pgtype.Timestamptz
 } {
 	var calls []struct {
-		Ctx context.Context
-		Arg FailJobParams
+		Ctx   context.Context
+		Since pgtype.Timestamptz
 	}
-	mock.lockFailJob.RLock()
-	calls = mock.calls.FailJob
-	mock.lockFailJob.RUnlock()
+	mock.lockSumPaidInvoicesSince.RLock()
+	calls = mock.calls.SumPaidInvoicesSince
+	mock.lockSumPaidInvoicesSince.RUnlock()
 	return calls
 }
 
-// GetAllProjects calls GetAllProjectsFunc.
-func (mock *QuerierMock) GetAllProjects(ctx context.Context) ([]*GetAllProjectsRow, error) {
-	if mock.GetAllProjectsFunc == nil {
-		panic("QuerierMock.GetAllProjectsFunc: method is nil but Querier.GetAllProjects was just called")
+// SyncImageStatusFromEvents calls SyncImageStatusFromEventsFunc.
+func (mock *QuerierMock) SyncImageStatusFromEvents(ctx context.Context, arg SyncImageStatusFromEventsParams) (int64, error) {
+	if mock.SyncImageStatusFromEventsFunc == nil {
+		panic("QuerierMock.SyncImageStatusFromEventsFunc: method is nil but Querier.SyncImageStatusFromEvents was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
+		Arg SyncImageStatusFromEventsParams
 	}{
 		Ctx: ctx,
+		Arg: arg,
 	}
-	mock.lockGetAllProjects.Lock()
-	mock.calls.GetAllProjects = append(mock.calls.GetAllProjects, callInfo)
-	mock.lockGetAllProjects.Unlock()
-	return mock.GetAllProjectsFunc(ctx)
+	mock.lockSyncImageStatusFromEvents.Lock()
+	mock.calls.SyncImageStatusFromEvents = append(mock.calls.SyncImageStatusFromEvents, callInfo)
+	mock.lockSyncImageStatusFromEvents.Unlock()
+	return mock.SyncImageStatusFromEventsFunc(ctx, arg)
 }
 
-// GetAllProjectsCalls gets all the calls that were made to GetAllProjects.
+// SyncImageStatusFromEventsCalls gets all the calls that were made to SyncImageStatusFromEvents.
 // Check the length with:
 //
-//	len(mockedQuerier.GetAllProjectsCalls())
-func (mock *QuerierMock) GetAllProjectsCalls() []struct {
+//	len(mockedQuerier.SyncImageStatusFromEventsCalls())
+func (mock *QuerierMock) SyncImageStatusFromEventsCalls() []struct {
 	Ctx context.Context
+	Arg SyncImageStatusFromEventsParams
 } {
 	var calls []struct {
 		Ctx context.Context
+		Arg SyncImageStatusFromEventsParams
 	}
-	mock.lockGetAllProjects.RLock()
-	calls = mock.calls.GetAllProjects
-	mock.lockGetAllProjects.RUnlock()
+	mock.lockSyncImageStatusFromEvents.RLock()
+	calls = mock.calls.SyncImageStatusFromEvents
+	mock.lockSyncImageStatusFromEvents.RUnlock()
 	return calls
 }
 
-// GetImageByID calls GetImageByIDFunc.
-func (mock *QuerierMock) GetImageByID(ctx context.Context, id pgtype.UUID) (*GetImageByIDRow, error) {
-	if mock.GetImageByIDFunc == nil {
-		panic("QuerierMock.GetImageByIDFunc: method is nil but Querier.GetImageByID was just called")
+// TouchAPIKey calls TouchAPIKeyFunc.
+func (mock *QuerierMock) TouchAPIKey(ctx context.Context, id pgtype.UUID) error {
+	if mock.TouchAPIKeyFunc == nil {
+		panic("QuerierMock.TouchAPIKeyFunc: method is nil but Querier.TouchAPIKey was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
@@ -1429,17 +11227,17 @@ func (mock *QuerierMock) GetImageByID(ctx context.Context, id pgtype.UUID) (*Get
 		Ctx: ctx,
 		ID:  id,
 	}
-	mock.lockGetImageByID.Lock()
-	mock.calls.GetImageByID = append(mock.calls.GetImageByID, callInfo)
-	mock.lockGetImageByID.Unlock()
-	return mock.GetImageByIDFunc(ctx, id)
+	mock.lockTouchAPIKey.Lock()
+	mock.calls.TouchAPIKey = append(mock.calls.TouchAPIKey, callInfo)
+	mock.lockTouchAPIKey.Unlock()
+	return mock.TouchAPIKeyFunc(ctx, id)
 }
 
-// GetImageByIDCalls gets all the calls that were made to GetImageByID.
+// TouchAPIKeyCalls gets all the calls that were made to TouchAPIKey.
 // Check the length with:
 //
-//	len(mockedQuerier.GetImageByIDCalls())
-func (mock *QuerierMock) GetImageByIDCalls() []struct {
+//	len(mockedQuerier.TouchAPIKeyCalls())
+func (mock *QuerierMock) TouchAPIKeyCalls() []struct {
 	Ctx context.Context
 	ID  pgtype.UUID
 } {
@@ -1447,1089 +11245,1125 @@ func (mock *QuerierMock) GetImageByIDCalls() []struct {
 		Ctx context.Context
 		ID  pgtype.UUID
 	}
-	mock.lockGetImageByID.RLock()
-	calls = mock.calls.GetImageByID
-	mock.lockGetImageByID.RUnlock()
+	mock.lockTouchAPIKey.RLock()
+	calls = mock.calls.TouchAPIKey
+	mock.lockTouchAPIKey.RUnlock()
 	return calls
 }
 
-// GetImagesByProjectID calls GetImagesByProjectIDFunc.
-func (mock *QuerierMock) GetImagesByProjectID(ctx context.Context, projectID pgtype.UUID) ([]*GetImagesByProjectIDRow, error) {
-	if mock.GetImagesByProjectIDFunc == nil {
-		panic("QuerierMock.GetImagesByProjectIDFunc: method is nil but Querier.GetImagesByProjectID was just called")
+// TouchOrganizationSCIMToken calls TouchOrganizationSCIMTokenFunc.
+func (mock *QuerierMock) TouchOrganizationSCIMToken(ctx context.Context, tokenHash []byte) (pgtype.UUID, error) {
+	if mock.TouchOrganizationSCIMTokenFunc == nil {
+		panic("QuerierMock.TouchOrganizationSCIMTokenFunc: method is nil but Querier.TouchOrganizationSCIMToken was just called")
 	}
 	callInfo := struct {
 		Ctx       context.Context
-		ProjectID pgtype.UUID
+		TokenHash []byte
 	}{
 		Ctx:       ctx,
-		ProjectID: projectID,
+		TokenHash: tokenHash,
 	}
-	mock.lockGetImagesByProjectID.Lock()
-	mock.calls.GetImagesByProjectID = append(mock.calls.GetImagesByProjectID, callInfo)
-	mock.lockGetImagesByProjectID.Unlock()
-	return mock.GetImagesByProjectIDFunc(ctx, projectID)
+	mock.lockTouchOrganizationSCIMToken.Lock()
+	mock.calls.TouchOrganizationSCIMToken = append(mock.calls.TouchOrganizationSCIMToken, callInfo)
+	mock.lockTouchOrganizationSCIMToken.Unlock()
+	return mock.TouchOrganizationSCIMTokenFunc(ctx, tokenHash)
 }
 
-// GetImagesByProjectIDCalls gets all the calls that were made to GetImagesByProjectID.
+// TouchOrganizationSCIMTokenCalls gets all the calls that were made to TouchOrganizationSCIMToken.
 // Check the length with:
 //
-//	len(mockedQuerier.GetImagesByProjectIDCalls())
-func (mock *QuerierMock) GetImagesByProjectIDCalls() []struct {
+//	len(mockedQuerier.TouchOrganizationSCIMTokenCalls())
+func (mock *QuerierMock) TouchOrganizationSCIMTokenCalls() []struct {
 	Ctx       context.Context
-	ProjectID pgtype.UUID
+	TokenHash []byte
 } {
 	var calls []struct {
 		Ctx       context.Context
-		ProjectID pgtype.UUID
+		TokenHash []byte
 	}
-	mock.lockGetImagesByProjectID.RLock()
-	calls = mock.calls.GetImagesByProjectID
-	mock.lockGetImagesByProjectID.RUnlock()
+	mock.lockTouchOrganizationSCIMToken.RLock()
+	calls = mock.calls.TouchOrganizationSCIMToken
+	mock.lockTouchOrganizationSCIMToken.RUnlock()
 	return calls
 }
 
-// GetInvoiceByStripeID calls GetInvoiceByStripeIDFunc.
-func (mock *QuerierMock) GetInvoiceByStripeID(ctx context.Context, stripeInvoiceID string) (*Invoice, error) {
-	if mock.GetInvoiceByStripeIDFunc == nil {
-		panic("QuerierMock.GetInvoiceByStripeIDFunc: method is nil but Querier.GetInvoiceByStripeID was just called")
+// UpdateCatalog calls UpdateCatalogFunc.
+func (mock *QuerierMock) UpdateCatalog(ctx context.Context, arg UpdateCatalogParams) (*Catalog, error) {
+	if mock.UpdateCatalogFunc == nil {
+		panic("QuerierMock.UpdateCatalogFunc: method is nil but Querier.UpdateCatalog was just called")
 	}
 	callInfo := struct {
-		Ctx             context.Context
-		StripeInvoiceID string
+		Ctx context.Context
+		Arg UpdateCatalogParams
 	}{
-		Ctx:             ctx,
-		StripeInvoiceID: stripeInvoiceID,
+		Ctx: ctx,
+		Arg: arg,
 	}
-	mock.lockGetInvoiceByStripeID.Lock()
-	mock.calls.GetInvoiceByStripeID = append(mock.calls.GetInvoiceByStripeID, callInfo)
-	mock.lockGetInvoiceByStripeID.Unlock()
-	return mock.GetInvoiceByStripeIDFunc(ctx, stripeInvoiceID)
+	mock.lockUpdateCatalog.Lock()
+	mock.calls.UpdateCatalog = append(mock.calls.UpdateCatalog, callInfo)
+	mock.lockUpdateCatalog.Unlock()
+	return mock.UpdateCatalogFunc(ctx, arg)
 }
 
-// GetInvoiceByStripeIDCalls gets all the calls that were made to GetInvoiceByStripeID.
+// UpdateCatalogCalls gets all the calls that were made to UpdateCatalog.
 // Check the length with:
 //
-//	len(mockedQuerier.GetInvoiceByStripeIDCalls())
-func (mock *QuerierMock) GetInvoiceByStripeIDCalls() []struct {
-	Ctx             context.Context
-	StripeInvoiceID string
+//	len(mockedQuerier.UpdateCatalogCalls())
+func (mock *QuerierMock) UpdateCatalogCalls() []struct {
+	Ctx context.Context
+	Arg UpdateCatalogParams
 } {
 	var calls []struct {
-		Ctx             context.Context
-		StripeInvoiceID string
+		Ctx context.Context
+		Arg UpdateCatalogParams
 	}
-	mock.lockGetInvoiceByStripeID.RLock()
-	calls = mock.calls.GetInvoiceByStripeID
-	mock.lockGetInvoiceByStripeID.RUnlock()
+	mock.lockUpdateCatalog.RLock()
+	calls = mock.calls.UpdateCatalog
+	mock.lockUpdateCatalog.RUnlock()
 	return calls
 }
 
-// GetJobByID calls GetJobByIDFunc.
-func (mock *QuerierMock) GetJobByID(ctx context.Context, id pgtype.UUID) (*Job, error) {
-	if mock.GetJobByIDFunc == nil {
-		panic("QuerierMock.GetJobByIDFunc: method is nil but Querier.GetJobByID was just called")
+// UpdateImageCost calls UpdateImageCostFunc.
+func (mock *QuerierMock) UpdateImageCost(ctx context.Context, arg UpdateImageCostParams) error {
+	if mock.UpdateImageCostFunc == nil {
+		panic("QuerierMock.UpdateImageCostFunc: method is nil but Querier.UpdateImageCost was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg UpdateImageCostParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockUpdateImageCost.Lock()
+	mock.calls.UpdateImageCost = append(mock.calls.UpdateImageCost, callInfo)
+	mock.lockUpdateImageCost.Unlock()
+	return mock.UpdateImageCostFunc(ctx, arg)
+}
+
+// UpdateImageCostCalls gets all the calls that were made to UpdateImageCost.
+// Check the length with:
+//
+//	len(mockedQuerier.UpdateImageCostCalls())
+func (mock *QuerierMock) UpdateImageCostCalls() []struct {
+	Ctx context.Context
+	Arg UpdateImageCostParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg UpdateImageCostParams
+	}
+	mock.lockUpdateImageCost.RLock()
+	calls = mock.calls.UpdateImageCost
+	mock.lockUpdateImageCost.RUnlock()
+	return calls
+}
+
+// UpdateImageStatus calls UpdateImageStatusFunc.
+func (mock *QuerierMock) UpdateImageStatus(ctx context.Context, arg UpdateImageStatusParams) (*UpdateImageStatusRow, error) {
+	if mock.UpdateImageStatusFunc == nil {
+		panic("QuerierMock.UpdateImageStatusFunc: method is nil but Querier.UpdateImageStatus was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		ID  pgtype.UUID
+		Arg UpdateImageStatusParams
 	}{
 		Ctx: ctx,
-		ID:  id,
+		Arg: arg,
 	}
-	mock.lockGetJobByID.Lock()
-	mock.calls.GetJobByID = append(mock.calls.GetJobByID, callInfo)
-	mock.lockGetJobByID.Unlock()
-	return mock.GetJobByIDFunc(ctx, id)
+	mock.lockUpdateImageStatus.Lock()
+	mock.calls.UpdateImageStatus = append(mock.calls.UpdateImageStatus, callInfo)
+	mock.lockUpdateImageStatus.Unlock()
+	return mock.UpdateImageStatusFunc(ctx, arg)
 }
 
-// GetJobByIDCalls gets all the calls that were made to GetJobByID.
+// UpdateImageStatusCalls gets all the calls that were made to UpdateImageStatus.
 // Check the length with:
 //
-//	len(mockedQuerier.GetJobByIDCalls())
-func (mock *QuerierMock) GetJobByIDCalls() []struct {
+//	len(mockedQuerier.UpdateImageStatusCalls())
+func (mock *QuerierMock) UpdateImageStatusCalls() []struct {
 	Ctx context.Context
-	ID  pgtype.UUID
+	Arg UpdateImageStatusParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		ID  pgtype.UUID
+		Arg UpdateImageStatusParams
 	}
-	mock.lockGetJobByID.RLock()
-	calls = mock.calls.GetJobByID
-	mock.lockGetJobByID.RUnlock()
+	mock.lockUpdateImageStatus.RLock()
+	calls = mock.calls.UpdateImageStatus
+	mock.lockUpdateImageStatus.RUnlock()
 	return calls
 }
 
-// GetJobsByImageID calls GetJobsByImageIDFunc.
-func (mock *QuerierMock) GetJobsByImageID(ctx context.Context, imageID pgtype.UUID) ([]*Job, error) {
-	if mock.GetJobsByImageIDFunc == nil {
-		panic("QuerierMock.GetJobsByImageIDFunc: method is nil but Querier.GetJobsByImageID was just called")
+// UpdateImageWithError calls UpdateImageWithErrorFunc.
+func (mock *QuerierMock) UpdateImageWithError(ctx context.Context, arg UpdateImageWithErrorParams) (*UpdateImageWithErrorRow, error) {
+	if mock.UpdateImageWithErrorFunc == nil {
+		panic("QuerierMock.UpdateImageWithErrorFunc: method is nil but Querier.UpdateImageWithError was just called")
 	}
 	callInfo := struct {
-		Ctx     context.Context
-		ImageID pgtype.UUID
+		Ctx context.Context
+		Arg UpdateImageWithErrorParams
 	}{
-		Ctx:     ctx,
-		ImageID: imageID,
+		Ctx: ctx,
+		Arg: arg,
 	}
-	mock.lockGetJobsByImageID.Lock()
-	mock.calls.GetJobsByImageID = append(mock.calls.GetJobsByImageID, callInfo)
-	mock.lockGetJobsByImageID.Unlock()
-	return mock.GetJobsByImageIDFunc(ctx, imageID)
+	mock.lockUpdateImageWithError.Lock()
+	mock.calls.UpdateImageWithError = append(mock.calls.UpdateImageWithError, callInfo)
+	mock.lockUpdateImageWithError.Unlock()
+	return mock.UpdateImageWithErrorFunc(ctx, arg)
 }
 
-// GetJobsByImageIDCalls gets all the calls that were made to GetJobsByImageID.
+// UpdateImageWithErrorCalls gets all the calls that were made to UpdateImageWithError.
 // Check the length with:
 //
-//	len(mockedQuerier.GetJobsByImageIDCalls())
-func (mock *QuerierMock) GetJobsByImageIDCalls() []struct {
-	Ctx     context.Context
-	ImageID pgtype.UUID
+//	len(mockedQuerier.UpdateImageWithErrorCalls())
+func (mock *QuerierMock) UpdateImageWithErrorCalls() []struct {
+	Ctx context.Context
+	Arg UpdateImageWithErrorParams
 } {
 	var calls []struct {
-		Ctx     context.Context
-		ImageID pgtype.UUID
+		Ctx context.Context
+		Arg UpdateImageWithErrorParams
 	}
-	mock.lockGetJobsByImageID.RLock()
-	calls = mock.calls.GetJobsByImageID
-	mock.lockGetJobsByImageID.RUnlock()
+	mock.lockUpdateImageWithError.RLock()
+	calls = mock.calls.UpdateImageWithError
+	mock.lockUpdateImageWithError.RUnlock()
 	return calls
 }
 
-// GetPendingJobs calls GetPendingJobsFunc.
-func (mock *QuerierMock) GetPendingJobs(ctx context.Context, limit int32) ([]*Job, error) {
-	if mock.GetPendingJobsFunc == nil {
-		panic("QuerierMock.GetPendingJobsFunc: method is nil but Querier.GetPendingJobs was just called")
+// UpdateImageWithStagedURL calls UpdateImageWithStagedURLFunc.
+func (mock *QuerierMock) UpdateImageWithStagedURL(ctx context.Context, arg UpdateImageWithStagedURLParams) (*UpdateImageWithStagedURLRow, error) {
+	if mock.UpdateImageWithStagedURLFunc == nil {
+		panic("QuerierMock.UpdateImageWithStagedURLFunc: method is nil but Querier.UpdateImageWithStagedURL was just called")
 	}
 	callInfo := struct {
-		Ctx   context.Context
-		Limit int32
+		Ctx context.Context
+		Arg UpdateImageWithStagedURLParams
 	}{
-		Ctx:   ctx,
-		Limit: limit,
+		Ctx: ctx,
+		Arg: arg,
 	}
-	mock.lockGetPendingJobs.Lock()
-	mock.calls.GetPendingJobs = append(mock.calls.GetPendingJobs, callInfo)
-	mock.lockGetPendingJobs.Unlock()
-	return mock.GetPendingJobsFunc(ctx, limit)
+	mock.lockUpdateImageWithStagedURL.Lock()
+	mock.calls.UpdateImageWithStagedURL = append(mock.calls.UpdateImageWithStagedURL, callInfo)
+	mock.lockUpdateImageWithStagedURL.Unlock()
+	return mock.UpdateImageWithStagedURLFunc(ctx, arg)
 }
 
-// GetPendingJobsCalls gets all the calls that were made to GetPendingJobs.
+// UpdateImageWithStagedURLCalls gets all the calls that were made to UpdateImageWithStagedURL.
 // Check the length with:
 //
-//	len(mockedQuerier.GetPendingJobsCalls())
-func (mock *QuerierMock) GetPendingJobsCalls() []struct {
-	Ctx   context.Context
-	Limit int32
+//	len(mockedQuerier.UpdateImageWithStagedURLCalls())
+func (mock *QuerierMock) UpdateImageWithStagedURLCalls() []struct {
+	Ctx context.Context
+	Arg UpdateImageWithStagedURLParams
 } {
 	var calls []struct {
-		Ctx   context.Context
-		Limit int32
+		Ctx context.Context
+		Arg UpdateImageWithStagedURLParams
 	}
-	mock.lockGetPendingJobs.RLock()
-	calls = mock.calls.GetPendingJobs
-	mock.lockGetPendingJobs.RUnlock()
+	mock.lockUpdateImageWithStagedURL.RLock()
+	calls = mock.calls.UpdateImageWithStagedURL
+	mock.lockUpdateImageWithStagedURL.RUnlock()
 	return calls
 }
 
-// GetProcessedEventByStripeID calls GetProcessedEventByStripeIDFunc.
-func (mock *QuerierMock) GetProcessedEventByStripeID(ctx context.Context, stripeEventID string) (*ProcessedEvent, error) {
-	if mock.GetProcessedEventByStripeIDFunc == nil {
-		panic("QuerierMock.GetProcessedEventByStripeIDFunc: method is nil but Querier.GetProcessedEventByStripeID was just called")
+// UpdateJobStatus calls UpdateJobStatusFunc.
+func (mock *QuerierMock) UpdateJobStatus(ctx context.Context, arg UpdateJobStatusParams) (*Job, error) {
+	if mock.UpdateJobStatusFunc == nil {
+		panic("QuerierMock.UpdateJobStatusFunc: method is nil but Querier.UpdateJobStatus was just called")
 	}
 	callInfo := struct {
-		Ctx           context.Context
-		StripeEventID string
+		Ctx context.Context
+		Arg UpdateJobStatusParams
 	}{
-		Ctx:           ctx,
-		StripeEventID: stripeEventID,
+		Ctx: ctx,
+		Arg: arg,
 	}
-	mock.lockGetProcessedEventByStripeID.Lock()
-	mock.calls.GetProcessedEventByStripeID = append(mock.calls.GetProcessedEventByStripeID, callInfo)
-	mock.lockGetProcessedEventByStripeID.Unlock()
-	return mock.GetProcessedEventByStripeIDFunc(ctx, stripeEventID)
+	mock.lockUpdateJobStatus.Lock()
+	mock.calls.UpdateJobStatus = append(mock.calls.UpdateJobStatus, callInfo)
+	mock.lockUpdateJobStatus.Unlock()
+	return mock.UpdateJobStatusFunc(ctx, arg)
 }
 
-// GetProcessedEventByStripeIDCalls gets all the calls that were made to GetProcessedEventByStripeID.
+// UpdateJobStatusCalls gets all the calls that were made to UpdateJobStatus.
 // Check the length with:
 //
-//	len(mockedQuerier.GetProcessedEventByStripeIDCalls())
-func (mock *QuerierMock) GetProcessedEventByStripeIDCalls() []struct {
-	Ctx           context.Context
-	StripeEventID string
+//	len(mockedQuerier.UpdateJobStatusCalls())
+func (mock *QuerierMock) UpdateJobStatusCalls() []struct {
+	Ctx context.Context
+	Arg UpdateJobStatusParams
 } {
 	var calls []struct {
-		Ctx           context.Context
-		StripeEventID string
+		Ctx context.Context
+		Arg UpdateJobStatusParams
 	}
-	mock.lockGetProcessedEventByStripeID.RLock()
-	calls = mock.calls.GetProcessedEventByStripeID
-	mock.lockGetProcessedEventByStripeID.RUnlock()
+	mock.lockUpdateJobStatus.RLock()
+	calls = mock.calls.UpdateJobStatus
+	mock.lockUpdateJobStatus.RUnlock()
 	return calls
 }
 
-// GetProjectByID calls GetProjectByIDFunc.
-func (mock *QuerierMock) GetProjectByID(ctx context.Context, id pgtype.UUID) (*GetProjectByIDRow, error) {
-	if mock.GetProjectByIDFunc == nil {
-		panic("QuerierMock.GetProjectByIDFunc: method is nil but Querier.GetProjectByID was just called")
+// UpdatePreset calls UpdatePresetFunc.
+func (mock *QuerierMock) UpdatePreset(ctx context.Context, arg UpdatePresetParams) (*Preset, error) {
+	if mock.UpdatePresetFunc == nil {
+		panic("QuerierMock.UpdatePresetFunc: method is nil but Querier.UpdatePreset was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		ID  pgtype.UUID
+		Arg UpdatePresetParams
 	}{
 		Ctx: ctx,
-		ID:  id,
+		Arg: arg,
 	}
-	mock.lockGetProjectByID.Lock()
-	mock.calls.GetProjectByID = append(mock.calls.GetProjectByID, callInfo)
-	mock.lockGetProjectByID.Unlock()
-	return mock.GetProjectByIDFunc(ctx, id)
+	mock.lockUpdatePreset.Lock()
+	mock.calls.UpdatePreset = append(mock.calls.UpdatePreset, callInfo)
+	mock.lockUpdatePreset.Unlock()
+	return mock.UpdatePresetFunc(ctx, arg)
 }
 
-// GetProjectByIDCalls gets all the calls that were made to GetProjectByID.
+// UpdatePresetCalls gets all the calls that were made to UpdatePreset.
 // Check the length with:
 //
-//	len(mockedQuerier.GetProjectByIDCalls())
-func (mock *QuerierMock) GetProjectByIDCalls() []struct {
+//	len(mockedQuerier.UpdatePresetCalls())
+func (mock *QuerierMock) UpdatePresetCalls() []struct {
 	Ctx context.Context
-	ID  pgtype.UUID
+	Arg UpdatePresetParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		ID  pgtype.UUID
+		Arg UpdatePresetParams
 	}
-	mock.lockGetProjectByID.RLock()
-	calls = mock.calls.GetProjectByID
-	mock.lockGetProjectByID.RUnlock()
+	mock.lockUpdatePreset.RLock()
+	calls = mock.calls.UpdatePreset
+	mock.lockUpdatePreset.RUnlock()
 	return calls
 }
 
-// GetProjectsByUserID calls GetProjectsByUserIDFunc.
-func (mock *QuerierMock) GetProjectsByUserID(ctx context.Context, userID pgtype.UUID) ([]*GetProjectsByUserIDRow, error) {
-	if mock.GetProjectsByUserIDFunc == nil {
-		panic("QuerierMock.GetProjectsByUserIDFunc: method is nil but Querier.GetProjectsByUserID was just called")
+// UpdateProject calls UpdateProjectFunc.
+func (mock *QuerierMock) UpdateProject(ctx context.Context, arg UpdateProjectParams) (*UpdateProjectRow, error) {
+	if mock.UpdateProjectFunc == nil {
+		panic("QuerierMock.UpdateProjectFunc: method is nil but Querier.UpdateProject was just called")
 	}
 	callInfo := struct {
-		Ctx    context.Context
-		UserID pgtype.UUID
+		Ctx context.Context
+		Arg UpdateProjectParams
 	}{
-		Ctx:    ctx,
-		UserID: userID,
+		Ctx: ctx,
+		Arg: arg,
 	}
-	mock.lockGetProjectsByUserID.Lock()
-	mock.calls.GetProjectsByUserID = append(mock.calls.GetProjectsByUserID, callInfo)
-	mock.lockGetProjectsByUserID.Unlock()
-	return mock.GetProjectsByUserIDFunc(ctx, userID)
+	mock.lockUpdateProject.Lock()
+	mock.calls.UpdateProject = append(mock.calls.UpdateProject, callInfo)
+	mock.lockUpdateProject.Unlock()
+	return mock.UpdateProjectFunc(ctx, arg)
 }
 
-// GetProjectsByUserIDCalls gets all the calls that were made to GetProjectsByUserID.
+// UpdateProjectCalls gets all the calls that were made to UpdateProject.
 // Check the length with:
 //
-//	len(mockedQuerier.GetProjectsByUserIDCalls())
-func (mock *QuerierMock) GetProjectsByUserIDCalls() []struct {
-	Ctx    context.Context
-	UserID pgtype.UUID
+//	len(mockedQuerier.UpdateProjectCalls())
+func (mock *QuerierMock) UpdateProjectCalls() []struct {
+	Ctx context.Context
+	Arg UpdateProjectParams
 } {
 	var calls []struct {
-		Ctx    context.Context
-		UserID pgtype.UUID
+		Ctx context.Context
+		Arg UpdateProjectParams
 	}
-	mock.lockGetProjectsByUserID.RLock()
-	calls = mock.calls.GetProjectsByUserID
-	mock.lockGetProjectsByUserID.RUnlock()
+	mock.lockUpdateProject.RLock()
+	calls = mock.calls.UpdateProject
+	mock.lockUpdateProject.RUnlock()
 	return calls
 }
 
-// GetSubscriptionByStripeID calls GetSubscriptionByStripeIDFunc.
-func (mock *QuerierMock) GetSubscriptionByStripeID(ctx context.Context, stripeSubscriptionID string) (*Subscription, error) {
-	if mock.GetSubscriptionByStripeIDFunc == nil {
-		panic("QuerierMock.GetSubscriptionByStripeIDFunc: method is nil but Querier.GetSubscriptionByStripeID was just called")
+// UpdateProjectByUserID calls UpdateProjectByUserIDFunc.
+func (mock *QuerierMock) UpdateProjectByUserID(ctx context.Context, arg UpdateProjectByUserIDParams) (*UpdateProjectByUserIDRow, error) {
+	if mock.UpdateProjectByUserIDFunc == nil {
+		panic("QuerierMock.UpdateProjectByUserIDFunc: method is nil but Querier.UpdateProjectByUserID was just called")
 	}
 	callInfo := struct {
-		Ctx                  context.Context
-		StripeSubscriptionID string
+		Ctx context.Context
+		Arg UpdateProjectByUserIDParams
 	}{
-		Ctx:                  ctx,
-		StripeSubscriptionID: stripeSubscriptionID,
+		Ctx: ctx,
+		Arg: arg,
 	}
-	mock.lockGetSubscriptionByStripeID.Lock()
-	mock.calls.GetSubscriptionByStripeID = append(mock.calls.GetSubscriptionByStripeID, callInfo)
-	mock.lockGetSubscriptionByStripeID.Unlock()
-	return mock.GetSubscriptionByStripeIDFunc(ctx, stripeSubscriptionID)
+	mock.lockUpdateProjectByUserID.Lock()
+	mock.calls.UpdateProjectByUserID = append(mock.calls.UpdateProjectByUserID, callInfo)
+	mock.lockUpdateProjectByUserID.Unlock()
+	return mock.UpdateProjectByUserIDFunc(ctx, arg)
 }
 
-// GetSubscriptionByStripeIDCalls gets all the calls that were made to GetSubscriptionByStripeID.
+// UpdateProjectByUserIDCalls gets all the calls that were made to UpdateProjectByUserID.
 // Check the length with:
 //
-//	len(mockedQuerier.GetSubscriptionByStripeIDCalls())
-func (mock *QuerierMock) GetSubscriptionByStripeIDCalls() []struct {
-	Ctx                  context.Context
-	StripeSubscriptionID string
+//	len(mockedQuerier.UpdateProjectByUserIDCalls())
+func (mock *QuerierMock) UpdateProjectByUserIDCalls() []struct {
+	Ctx context.Context
+	Arg UpdateProjectByUserIDParams
 } {
 	var calls []struct {
-		Ctx                  context.Context
-		StripeSubscriptionID string
+		Ctx context.Context
+		Arg UpdateProjectByUserIDParams
 	}
-	mock.lockGetSubscriptionByStripeID.RLock()
-	calls = mock.calls.GetSubscriptionByStripeID
-	mock.lockGetSubscriptionByStripeID.RUnlock()
+	mock.lockUpdateProjectByUserID.RLock()
+	calls = mock.calls.UpdateProjectByUserID
+	mock.lockUpdateProjectByUserID.RUnlock()
 	return calls
 }
 
-// GetUserByAuth0Sub calls GetUserByAuth0SubFunc.
-func (mock *QuerierMock) GetUserByAuth0Sub(ctx context.Context, auth0Sub string) (*GetUserByAuth0SubRow, error) {
-	if mock.GetUserByAuth0SubFunc == nil {
-		panic("QuerierMock.GetUserByAuth0SubFunc: method is nil but Querier.GetUserByAuth0Sub was just called")
+// UpdateSCIMGroup calls UpdateSCIMGroupFunc.
+func (mock *QuerierMock) UpdateSCIMGroup(ctx context.Context, arg UpdateSCIMGroupParams) (*OrganizationScimGroup, error) {
+	if mock.UpdateSCIMGroupFunc == nil {
+		panic("QuerierMock.UpdateSCIMGroupFunc: method is nil but Querier.UpdateSCIMGroup was just called")
 	}
 	callInfo := struct {
-		Ctx      context.Context
-		Auth0Sub string
+		Ctx context.Context
+		Arg UpdateSCIMGroupParams
 	}{
-		Ctx:      ctx,
-		Auth0Sub: auth0Sub,
+		Ctx: ctx,
+		Arg: arg,
 	}
-	mock.lockGetUserByAuth0Sub.Lock()
-	mock.calls.GetUserByAuth0Sub = append(mock.calls.GetUserByAuth0Sub, callInfo)
-	mock.lockGetUserByAuth0Sub.Unlock()
-	return mock.GetUserByAuth0SubFunc(ctx, auth0Sub)
+	mock.lockUpdateSCIMGroup.Lock()
+	mock.calls.UpdateSCIMGroup = append(mock.calls.UpdateSCIMGroup, callInfo)
+	mock.lockUpdateSCIMGroup.Unlock()
+	return mock.UpdateSCIMGroupFunc(ctx, arg)
 }
 
-// GetUserByAuth0SubCalls gets all the calls that were made to GetUserByAuth0Sub.
+// UpdateSCIMGroupCalls gets all the calls that were made to UpdateSCIMGroup.
 // Check the length with:
 //
-//	len(mockedQuerier.GetUserByAuth0SubCalls())
-func (mock *QuerierMock) GetUserByAuth0SubCalls() []struct {
-	Ctx      context.Context
-	Auth0Sub string
+//	len(mockedQuerier.UpdateSCIMGroupCalls())
+func (mock *QuerierMock) UpdateSCIMGroupCalls() []struct {
+	Ctx context.Context
+	Arg UpdateSCIMGroupParams
 } {
 	var calls []struct {
-		Ctx      context.Context
-		Auth0Sub string
+		Ctx context.Context
+		Arg UpdateSCIMGroupParams
 	}
-	mock.lockGetUserByAuth0Sub.RLock()
-	calls = mock.calls.GetUserByAuth0Sub
-	mock.lockGetUserByAuth0Sub.RUnlock()
+	mock.lockUpdateSCIMGroup.RLock()
+	calls = mock.calls.UpdateSCIMGroup
+	mock.lockUpdateSCIMGroup.RUnlock()
 	return calls
 }
 
-// GetUserByID calls GetUserByIDFunc.
-func (mock *QuerierMock) GetUserByID(ctx context.Context, id pgtype.UUID) (*GetUserByIDRow, error) {
-	if mock.GetUserByIDFunc == nil {
-		panic("QuerierMock.GetUserByIDFunc: method is nil but Querier.GetUserByID was just called")
+// UpdateSCIMUser calls UpdateSCIMUserFunc.
+func (mock *QuerierMock) UpdateSCIMUser(ctx context.Context, arg UpdateSCIMUserParams) (*OrganizationScimUser, error) {
+	if mock.UpdateSCIMUserFunc == nil {
+		panic("QuerierMock.UpdateSCIMUserFunc: method is nil but Querier.UpdateSCIMUser was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		ID  pgtype.UUID
+		Arg UpdateSCIMUserParams
 	}{
 		Ctx: ctx,
-		ID:  id,
+		Arg: arg,
 	}
-	mock.lockGetUserByID.Lock()
-	mock.calls.GetUserByID = append(mock.calls.GetUserByID, callInfo)
-	mock.lockGetUserByID.Unlock()
-	return mock.GetUserByIDFunc(ctx, id)
+	mock.lockUpdateSCIMUser.Lock()
+	mock.calls.UpdateSCIMUser = append(mock.calls.UpdateSCIMUser, callInfo)
+	mock.lockUpdateSCIMUser.Unlock()
+	return mock.UpdateSCIMUserFunc(ctx, arg)
 }
 
-// GetUserByIDCalls gets all the calls that were made to GetUserByID.
+// UpdateSCIMUserCalls gets all the calls that were made to UpdateSCIMUser.
 // Check the length with:
 //
-//	len(mockedQuerier.GetUserByIDCalls())
-func (mock *QuerierMock) GetUserByIDCalls() []struct {
+//	len(mockedQuerier.UpdateSCIMUserCalls())
+func (mock *QuerierMock) UpdateSCIMUserCalls() []struct {
 	Ctx context.Context
-	ID  pgtype.UUID
+	Arg UpdateSCIMUserParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		ID  pgtype.UUID
+		Arg UpdateSCIMUserParams
 	}
-	mock.lockGetUserByID.RLock()
-	calls = mock.calls.GetUserByID
-	mock.lockGetUserByID.RUnlock()
+	mock.lockUpdateSCIMUser.RLock()
+	calls = mock.calls.UpdateSCIMUser
+	mock.lockUpdateSCIMUser.RUnlock()
 	return calls
 }
 
-// GetUserByStripeCustomerID calls GetUserByStripeCustomerIDFunc.
-func (mock *QuerierMock) GetUserByStripeCustomerID(ctx context.Context, stripeCustomerID pgtype.Text) (*GetUserByStripeCustomerIDRow, error) {
-	if mock.GetUserByStripeCustomerIDFunc == nil {
-		panic("QuerierMock.GetUserByStripeCustomerIDFunc: method is nil but Querier.GetUserByStripeCustomerID was just called")
+// UpdateSetting calls UpdateSettingFunc.
+func (mock *QuerierMock) UpdateSetting(ctx context.Context, arg UpdateSettingParams) (int64, error) {
+	if mock.UpdateSettingFunc == nil {
+		panic("QuerierMock.UpdateSettingFunc: method is nil but Querier.UpdateSetting was just called")
 	}
 	callInfo := struct {
-		Ctx              context.Context
-		StripeCustomerID pgtype.Text
+		Ctx context.Context
+		Arg UpdateSettingParams
 	}{
-		Ctx:              ctx,
-		StripeCustomerID: stripeCustomerID,
+		Ctx: ctx,
+		Arg: arg,
 	}
-	mock.lockGetUserByStripeCustomerID.Lock()
-	mock.calls.GetUserByStripeCustomerID = append(mock.calls.GetUserByStripeCustomerID, callInfo)
-	mock.lockGetUserByStripeCustomerID.Unlock()
-	return mock.GetUserByStripeCustomerIDFunc(ctx, stripeCustomerID)
+	mock.lockUpdateSetting.Lock()
+	mock.calls.UpdateSetting = append(mock.calls.UpdateSetting, callInfo)
+	mock.lockUpdateSetting.Unlock()
+	return mock.UpdateSettingFunc(ctx, arg)
 }
 
-// GetUserByStripeCustomerIDCalls gets all the calls that were made to GetUserByStripeCustomerID.
+// UpdateSettingCalls gets all the calls that were made to UpdateSetting.
 // Check the length with:
 //
-//	len(mockedQuerier.GetUserByStripeCustomerIDCalls())
-func (mock *QuerierMock) GetUserByStripeCustomerIDCalls() []struct {
-	Ctx              context.Context
-	StripeCustomerID pgtype.Text
+//	len(mockedQuerier.UpdateSettingCalls())
+func (mock *QuerierMock) UpdateSettingCalls() []struct {
+	Ctx context.Context
+	Arg UpdateSettingParams
 } {
 	var calls []struct {
-		Ctx              context.Context
-		StripeCustomerID pgtype.Text
+		Ctx context.Context
+		Arg UpdateSettingParams
 	}
-	mock.lockGetUserByStripeCustomerID.RLock()
-	calls = mock.calls.GetUserByStripeCustomerID
-	mock.lockGetUserByStripeCustomerID.RUnlock()
+	mock.lockUpdateSetting.RLock()
+	calls = mock.calls.UpdateSetting
+	mock.lockUpdateSetting.RUnlock()
 	return calls
 }
 
-// GetUserProfileByAuth0Sub calls GetUserProfileByAuth0SubFunc.
-func (mock *QuerierMock) GetUserProfileByAuth0Sub(ctx context.Context, auth0Sub string) (*GetUserProfileByAuth0SubRow, error) {
-	if mock.GetUserProfileByAuth0SubFunc == nil {
-		panic("QuerierMock.GetUserProfileByAuth0SubFunc: method is nil but Querier.GetUserProfileByAuth0Sub was just called")
+// UpdateUserEncryptedFields calls UpdateUserEncryptedFieldsFunc.
+func (mock *QuerierMock) UpdateUserEncryptedFields(ctx context.Context, arg UpdateUserEncryptedFieldsParams) error {
+	if mock.UpdateUserEncryptedFieldsFunc == nil {
+		panic("QuerierMock.UpdateUserEncryptedFieldsFunc: method is nil but Querier.UpdateUserEncryptedFields was just called")
 	}
 	callInfo := struct {
-		Ctx      context.Context
-		Auth0Sub string
+		Ctx context.Context
+		Arg UpdateUserEncryptedFieldsParams
 	}{
-		Ctx:      ctx,
-		Auth0Sub: auth0Sub,
+		Ctx: ctx,
+		Arg: arg,
 	}
-	mock.lockGetUserProfileByAuth0Sub.Lock()
-	mock.calls.GetUserProfileByAuth0Sub = append(mock.calls.GetUserProfileByAuth0Sub, callInfo)
-	mock.lockGetUserProfileByAuth0Sub.Unlock()
-	return mock.GetUserProfileByAuth0SubFunc(ctx, auth0Sub)
+	mock.lockUpdateUserEncryptedFields.Lock()
+	mock.calls.UpdateUserEncryptedFields = append(mock.calls.UpdateUserEncryptedFields, callInfo)
+	mock.lockUpdateUserEncryptedFields.Unlock()
+	return mock.UpdateUserEncryptedFieldsFunc(ctx, arg)
 }
 
-// GetUserProfileByAuth0SubCalls gets all the calls that were made to GetUserProfileByAuth0Sub.
+// UpdateUserEncryptedFieldsCalls gets all the calls that were made to UpdateUserEncryptedFields.
 // Check the length with:
 //
-//	len(mockedQuerier.GetUserProfileByAuth0SubCalls())
-func (mock *QuerierMock) GetUserProfileByAuth0SubCalls() []struct {
-	Ctx      context.Context
-	Auth0Sub string
+//	len(mockedQuerier.UpdateUserEncryptedFieldsCalls())
+func (mock *QuerierMock) UpdateUserEncryptedFieldsCalls() []struct {
+	Ctx context.Context
+	Arg UpdateUserEncryptedFieldsParams
 } {
 	var calls []struct {
-		Ctx      context.Context
-		Auth0Sub string
+		Ctx context.Context
+		Arg UpdateUserEncryptedFieldsParams
 	}
-	mock.lockGetUserProfileByAuth0Sub.RLock()
-	calls = mock.calls.GetUserProfileByAuth0Sub
-	mock.lockGetUserProfileByAuth0Sub.RUnlock()
+	mock.lockUpdateUserEncryptedFields.RLock()
+	calls = mock.calls.UpdateUserEncryptedFields
+	mock.lockUpdateUserEncryptedFields.RUnlock()
 	return calls
 }
 
-// GetUserProfileByID calls GetUserProfileByIDFunc.
-func (mock *QuerierMock) GetUserProfileByID(ctx context.Context, id pgtype.UUID) (*GetUserProfileByIDRow, error) {
-	if mock.GetUserProfileByIDFunc == nil {
-		panic("QuerierMock.GetUserProfileByIDFunc: method is nil but Querier.GetUserProfileByID was just called")
+// UpdateUserProfile calls UpdateUserProfileFunc.
+func (mock *QuerierMock) UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (*UpdateUserProfileRow, error) {
+	if mock.UpdateUserProfileFunc == nil {
+		panic("QuerierMock.UpdateUserProfileFunc: method is nil but Querier.UpdateUserProfile was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		ID  pgtype.UUID
+		Arg UpdateUserProfileParams
 	}{
 		Ctx: ctx,
-		ID:  id,
+		Arg: arg,
 	}
-	mock.lockGetUserProfileByID.Lock()
-	mock.calls.GetUserProfileByID = append(mock.calls.GetUserProfileByID, callInfo)
-	mock.lockGetUserProfileByID.Unlock()
-	return mock.GetUserProfileByIDFunc(ctx, id)
+	mock.lockUpdateUserProfile.Lock()
+	mock.calls.UpdateUserProfile = append(mock.calls.UpdateUserProfile, callInfo)
+	mock.lockUpdateUserProfile.Unlock()
+	return mock.UpdateUserProfileFunc(ctx, arg)
 }
 
-// GetUserProfileByIDCalls gets all the calls that were made to GetUserProfileByID.
+// UpdateUserProfileCalls gets all the calls that were made to UpdateUserProfile.
 // Check the length with:
 //
-//	len(mockedQuerier.GetUserProfileByIDCalls())
-func (mock *QuerierMock) GetUserProfileByIDCalls() []struct {
+//	len(mockedQuerier.UpdateUserProfileCalls())
+func (mock *QuerierMock) UpdateUserProfileCalls() []struct {
 	Ctx context.Context
-	ID  pgtype.UUID
+	Arg UpdateUserProfileParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		ID  pgtype.UUID
+		Arg UpdateUserProfileParams
 	}
-	mock.lockGetUserProfileByID.RLock()
-	calls = mock.calls.GetUserProfileByID
-	mock.lockGetUserProfileByID.RUnlock()
+	mock.lockUpdateUserProfile.RLock()
+	calls = mock.calls.UpdateUserProfile
+	mock.lockUpdateUserProfile.RUnlock()
 	return calls
 }
 
-// ListImagesForReconcile calls ListImagesForReconcileFunc.
-func (mock *QuerierMock) ListImagesForReconcile(ctx context.Context, arg ListImagesForReconcileParams) ([]*ListImagesForReconcileRow, error) {
-	if mock.ListImagesForReconcileFunc == nil {
-		panic("QuerierMock.ListImagesForReconcileFunc: method is nil but Querier.ListImagesForReconcile was just called")
+// UpdateUserRole calls UpdateUserRoleFunc.
+func (mock *QuerierMock) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (*UpdateUserRoleRow, error) {
+	if mock.UpdateUserRoleFunc == nil {
+		panic("QuerierMock.UpdateUserRoleFunc: method is nil but Querier.UpdateUserRole was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Arg ListImagesForReconcileParams
+		Arg UpdateUserRoleParams
 	}{
 		Ctx: ctx,
 		Arg: arg,
 	}
-	mock.lockListImagesForReconcile.Lock()
-	mock.calls.ListImagesForReconcile = append(mock.calls.ListImagesForReconcile, callInfo)
-	mock.lockListImagesForReconcile.Unlock()
-	return mock.ListImagesForReconcileFunc(ctx, arg)
+	mock.lockUpdateUserRole.Lock()
+	mock.calls.UpdateUserRole = append(mock.calls.UpdateUserRole, callInfo)
+	mock.lockUpdateUserRole.Unlock()
+	return mock.UpdateUserRoleFunc(ctx, arg)
 }
 
-// ListImagesForReconcileCalls gets all the calls that were made to ListImagesForReconcile.
+// UpdateUserRoleCalls gets all the calls that were made to UpdateUserRole.
 // Check the length with:
 //
-//	len(mockedQuerier.ListImagesForReconcileCalls())
-func (mock *QuerierMock) ListImagesForReconcileCalls() []struct {
+//	len(mockedQuerier.UpdateUserRoleCalls())
+func (mock *QuerierMock) UpdateUserRoleCalls() []struct {
 	Ctx context.Context
-	Arg ListImagesForReconcileParams
+	Arg UpdateUserRoleParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		Arg ListImagesForReconcileParams
+		Arg UpdateUserRoleParams
 	}
-	mock.lockListImagesForReconcile.RLock()
-	calls = mock.calls.ListImagesForReconcile
-	mock.lockListImagesForReconcile.RUnlock()
+	mock.lockUpdateUserRole.RLock()
+	calls = mock.calls.UpdateUserRole
+	mock.lockUpdateUserRole.RUnlock()
 	return calls
 }
 
-// ListInvoicesByUserID calls ListInvoicesByUserIDFunc.
-func (mock *QuerierMock) ListInvoicesByUserID(ctx context.Context, arg ListInvoicesByUserIDParams) ([]*Invoice, error) {
-	if mock.ListInvoicesByUserIDFunc == nil {
-		panic("QuerierMock.ListInvoicesByUserIDFunc: method is nil but Querier.ListInvoicesByUserID was just called")
+// UpdateUserStorageRegion calls UpdateUserStorageRegionFunc.
+func (mock *QuerierMock) UpdateUserStorageRegion(ctx context.Context, arg UpdateUserStorageRegionParams) (int64, error) {
+	if mock.UpdateUserStorageRegionFunc == nil {
+		panic("QuerierMock.UpdateUserStorageRegionFunc: method is nil but Querier.UpdateUserStorageRegion was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Arg ListInvoicesByUserIDParams
+		Arg UpdateUserStorageRegionParams
 	}{
 		Ctx: ctx,
 		Arg: arg,
 	}
-	mock.lockListInvoicesByUserID.Lock()
-	mock.calls.ListInvoicesByUserID = append(mock.calls.ListInvoicesByUserID, callInfo)
-	mock.lockListInvoicesByUserID.Unlock()
-	return mock.ListInvoicesByUserIDFunc(ctx, arg)
+	mock.lockUpdateUserStorageRegion.Lock()
+	mock.calls.UpdateUserStorageRegion = append(mock.calls.UpdateUserStorageRegion, callInfo)
+	mock.lockUpdateUserStorageRegion.Unlock()
+	return mock.UpdateUserStorageRegionFunc(ctx, arg)
 }
 
-// ListInvoicesByUserIDCalls gets all the calls that were made to ListInvoicesByUserID.
+// UpdateUserStorageRegionCalls gets all the calls that were made to UpdateUserStorageRegion.
 // Check the length with:
 //
-//	len(mockedQuerier.ListInvoicesByUserIDCalls())
-func (mock *QuerierMock) ListInvoicesByUserIDCalls() []struct {
+//	len(mockedQuerier.UpdateUserStorageRegionCalls())
+func (mock *QuerierMock) UpdateUserStorageRegionCalls() []struct {
 	Ctx context.Context
-	Arg ListInvoicesByUserIDParams
+	Arg UpdateUserStorageRegionParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		Arg ListInvoicesByUserIDParams
+		Arg UpdateUserStorageRegionParams
 	}
-	mock.lockListInvoicesByUserID.RLock()
-	calls = mock.calls.ListInvoicesByUserID
-	mock.lockListInvoicesByUserID.RUnlock()
+	mock.lockUpdateUserStorageRegion.RLock()
+	calls = mock.calls.UpdateUserStorageRegion
+	mock.lockUpdateUserStorageRegion.RUnlock()
 	return calls
 }
 
-// ListSubscriptionsByUserID calls ListSubscriptionsByUserIDFunc.
-func (mock *QuerierMock) ListSubscriptionsByUserID(ctx context.Context, arg ListSubscriptionsByUserIDParams) ([]*Subscription, error) {
-	if mock.ListSubscriptionsByUserIDFunc == nil {
-		panic("QuerierMock.ListSubscriptionsByUserIDFunc: method is nil but Querier.ListSubscriptionsByUserID was just called")
+// UpdateUserStripeCustomerID calls UpdateUserStripeCustomerIDFunc.
+func (mock *QuerierMock) UpdateUserStripeCustomerID(ctx context.Context, arg UpdateUserStripeCustomerIDParams) (*UpdateUserStripeCustomerIDRow, error) {
+	if mock.UpdateUserStripeCustomerIDFunc == nil {
+		panic("QuerierMock.UpdateUserStripeCustomerIDFunc: method is nil but Querier.UpdateUserStripeCustomerID was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Arg ListSubscriptionsByUserIDParams
+		Arg UpdateUserStripeCustomerIDParams
 	}{
 		Ctx: ctx,
 		Arg: arg,
 	}
-	mock.lockListSubscriptionsByUserID.Lock()
-	mock.calls.ListSubscriptionsByUserID = append(mock.calls.ListSubscriptionsByUserID, callInfo)
-	mock.lockListSubscriptionsByUserID.Unlock()
-	return mock.ListSubscriptionsByUserIDFunc(ctx, arg)
+	mock.lockUpdateUserStripeCustomerID.Lock()
+	mock.calls.UpdateUserStripeCustomerID = append(mock.calls.UpdateUserStripeCustomerID, callInfo)
+	mock.lockUpdateUserStripeCustomerID.Unlock()
+	return mock.UpdateUserStripeCustomerIDFunc(ctx, arg)
 }
 
-// ListSubscriptionsByUserIDCalls gets all the calls that were made to ListSubscriptionsByUserID.
+// UpdateUserStripeCustomerIDCalls gets all the calls that were made to UpdateUserStripeCustomerID.
 // Check the length with:
 //
-//	len(mockedQuerier.ListSubscriptionsByUserIDCalls())
-func (mock *QuerierMock) ListSubscriptionsByUserIDCalls() []struct {
+//	len(mockedQuerier.UpdateUserStripeCustomerIDCalls())
+func (mock *QuerierMock) UpdateUserStripeCustomerIDCalls() []struct {
 	Ctx context.Context
-	Arg ListSubscriptionsByUserIDParams
+	Arg UpdateUserStripeCustomerIDParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		Arg ListSubscriptionsByUserIDParams
+		Arg UpdateUserStripeCustomerIDParams
 	}
-	mock.lockListSubscriptionsByUserID.RLock()
-	calls = mock.calls.ListSubscriptionsByUserID
-	mock.lockListSubscriptionsByUserID.RUnlock()
+	mock.lockUpdateUserStripeCustomerID.RLock()
+	calls = mock.calls.UpdateUserStripeCustomerID
+	mock.lockUpdateUserStripeCustomerID.RUnlock()
 	return calls
 }
 
-// ListUsers calls ListUsersFunc.
-func (mock *QuerierMock) ListUsers(ctx context.Context, arg ListUsersParams) ([]*ListUsersRow, error) {
-	if mock.ListUsersFunc == nil {
-		panic("QuerierMock.ListUsersFunc: method is nil but Querier.ListUsers was just called")
+// UpsertAccountWatermark calls UpsertAccountWatermarkFunc.
+func (mock *QuerierMock) UpsertAccountWatermark(ctx context.Context, arg UpsertAccountWatermarkParams) (*AccountWatermark, error) {
+	if mock.UpsertAccountWatermarkFunc == nil {
+		panic("QuerierMock.UpsertAccountWatermarkFunc: method is nil but Querier.UpsertAccountWatermark was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Arg ListUsersParams
+		Arg UpsertAccountWatermarkParams
 	}{
 		Ctx: ctx,
 		Arg: arg,
 	}
-	mock.lockListUsers.Lock()
-	mock.calls.ListUsers = append(mock.calls.ListUsers, callInfo)
-	mock.lockListUsers.Unlock()
-	return mock.ListUsersFunc(ctx, arg)
+	mock.lockUpsertAccountWatermark.Lock()
+	mock.calls.UpsertAccountWatermark = append(mock.calls.UpsertAccountWatermark, callInfo)
+	mock.lockUpsertAccountWatermark.Unlock()
+	return mock.UpsertAccountWatermarkFunc(ctx, arg)
 }
 
-// ListUsersCalls gets all the calls that were made to ListUsers.
+// UpsertAccountWatermarkCalls gets all the calls that were made to UpsertAccountWatermark.
 // Check the length with:
 //
-//	len(mockedQuerier.ListUsersCalls())
-func (mock *QuerierMock) ListUsersCalls() []struct {
+//	len(mockedQuerier.UpsertAccountWatermarkCalls())
+func (mock *QuerierMock) UpsertAccountWatermarkCalls() []struct {
 	Ctx context.Context
-	Arg ListUsersParams
+	Arg UpsertAccountWatermarkParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		Arg ListUsersParams
+		Arg UpsertAccountWatermarkParams
 	}
-	mock.lockListUsers.RLock()
-	calls = mock.calls.ListUsers
-	mock.lockListUsers.RUnlock()
+	mock.lockUpsertAccountWatermark.RLock()
+	calls = mock.calls.UpsertAccountWatermark
+	mock.lockUpsertAccountWatermark.RUnlock()
 	return calls
 }
-
-// StartJob calls StartJobFunc.
-func (mock *QuerierMock) StartJob(ctx context.Context, id pgtype.UUID) (*Job, error) {
-	if mock.StartJobFunc == nil {
-		panic("QuerierMock.StartJobFunc: method is nil but Querier.StartJob was just called")
+
+// UpsertCustomerBucket calls UpsertCustomerBucketFunc.
+func (mock *QuerierMock) UpsertCustomerBucket(ctx context.Context, arg UpsertCustomerBucketParams) (*CustomerBucket, error) {
+	if mock.UpsertCustomerBucketFunc == nil {
+		panic("QuerierMock.UpsertCustomerBucketFunc: method is nil but Querier.UpsertCustomerBucket was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		ID  pgtype.UUID
+		Arg UpsertCustomerBucketParams
 	}{
 		Ctx: ctx,
-		ID:  id,
+		Arg: arg,
 	}
-	mock.lockStartJob.Lock()
-	mock.calls.StartJob = append(mock.calls.StartJob, callInfo)
-	mock.lockStartJob.Unlock()
-	return mock.StartJobFunc(ctx, id)
+	mock.lockUpsertCustomerBucket.Lock()
+	mock.calls.UpsertCustomerBucket = append(mock.calls.UpsertCustomerBucket, callInfo)
+	mock.lockUpsertCustomerBucket.Unlock()
+	return mock.UpsertCustomerBucketFunc(ctx, arg)
 }
 
-// StartJobCalls gets all the calls that were made to StartJob.
+// UpsertCustomerBucketCalls gets all the calls that were made to UpsertCustomerBucket.
 // Check the length with:
 //
-//	len(mockedQuerier.StartJobCalls())
-func (mock *QuerierMock) StartJobCalls() []struct {
+//	len(mockedQuerier.UpsertCustomerBucketCalls())
+func (mock *QuerierMock) UpsertCustomerBucketCalls() []struct {
 	Ctx context.Context
-	ID  pgtype.UUID
+	Arg UpsertCustomerBucketParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		ID  pgtype.UUID
+		Arg UpsertCustomerBucketParams
 	}
-	mock.lockStartJob.RLock()
-	calls = mock.calls.StartJob
-	mock.lockStartJob.RUnlock()
+	mock.lockUpsertCustomerBucket.RLock()
+	calls = mock.calls.UpsertCustomerBucket
+	mock.lockUpsertCustomerBucket.RUnlock()
 	return calls
 }
 
-// UpdateImageStatus calls UpdateImageStatusFunc.
-func (mock *QuerierMock) UpdateImageStatus(ctx context.Context, arg UpdateImageStatusParams) (*UpdateImageStatusRow, error) {
-	if mock.UpdateImageStatusFunc == nil {
-		panic("QuerierMock.UpdateImageStatusFunc: method is nil but Querier.UpdateImageStatus was just called")
+// UpsertInvoiceByStripeID calls UpsertInvoiceByStripeIDFunc.
+func (mock *QuerierMock) UpsertInvoiceByStripeID(ctx context.Context, arg UpsertInvoiceByStripeIDParams) (*Invoice, error) {
+	if mock.UpsertInvoiceByStripeIDFunc == nil {
+		panic("QuerierMock.UpsertInvoiceByStripeIDFunc: method is nil but Querier.UpsertInvoiceByStripeID was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Arg UpdateImageStatusParams
+		Arg UpsertInvoiceByStripeIDParams
 	}{
 		Ctx: ctx,
 		Arg: arg,
 	}
-	mock.lockUpdateImageStatus.Lock()
-	mock.calls.UpdateImageStatus = append(mock.calls.UpdateImageStatus, callInfo)
-	mock.lockUpdateImageStatus.Unlock()
-	return mock.UpdateImageStatusFunc(ctx, arg)
+	mock.lockUpsertInvoiceByStripeID.Lock()
+	mock.calls.UpsertInvoiceByStripeID = append(mock.calls.UpsertInvoiceByStripeID, callInfo)
+	mock.lockUpsertInvoiceByStripeID.Unlock()
+	return mock.UpsertInvoiceByStripeIDFunc(ctx, arg)
 }
 
-// UpdateImageStatusCalls gets all the calls that were made to UpdateImageStatus.
+// UpsertInvoiceByStripeIDCalls gets all the calls that were made to UpsertInvoiceByStripeID.
 // Check the length with:
 //
-//	len(mockedQuerier.UpdateImageStatusCalls())
-func (mock *QuerierMock) UpdateImageStatusCalls() []struct {
+//	len(mockedQuerier.UpsertInvoiceByStripeIDCalls())
+func (mock *QuerierMock) UpsertInvoiceByStripeIDCalls() []struct {
 	Ctx context.Context
-	Arg UpdateImageStatusParams
+	Arg UpsertInvoiceByStripeIDParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		Arg UpdateImageStatusParams
+		Arg UpsertInvoiceByStripeIDParams
 	}
-	mock.lockUpdateImageStatus.RLock()
-	calls = mock.calls.UpdateImageStatus
-	mock.lockUpdateImageStatus.RUnlock()
+	mock.lockUpsertInvoiceByStripeID.RLock()
+	calls = mock.calls.UpsertInvoiceByStripeID
+	mock.lockUpsertInvoiceByStripeID.RUnlock()
 	return calls
 }
 
-// UpdateImageWithError calls UpdateImageWithErrorFunc.
-func (mock *QuerierMock) UpdateImageWithError(ctx context.Context, arg UpdateImageWithErrorParams) (*UpdateImageWithErrorRow, error) {
-	if mock.UpdateImageWithErrorFunc == nil {
-		panic("QuerierMock.UpdateImageWithErrorFunc: method is nil but Querier.UpdateImageWithError was just called")
+// UpsertOrganizationSCIMMember calls UpsertOrganizationSCIMMemberFunc.
+func (mock *QuerierMock) UpsertOrganizationSCIMMember(ctx context.Context, arg UpsertOrganizationSCIMMemberParams) error {
+	if mock.UpsertOrganizationSCIMMemberFunc == nil {
+		panic("QuerierMock.UpsertOrganizationSCIMMemberFunc: method is nil but Querier.UpsertOrganizationSCIMMember was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Arg UpdateImageWithErrorParams
+		Arg UpsertOrganizationSCIMMemberParams
 	}{
 		Ctx: ctx,
 		Arg: arg,
 	}
-	mock.lockUpdateImageWithError.Lock()
-	mock.calls.UpdateImageWithError = append(mock.calls.UpdateImageWithError, callInfo)
-	mock.lockUpdateImageWithError.Unlock()
-	return mock.UpdateImageWithErrorFunc(ctx, arg)
+	mock.lockUpsertOrganizationSCIMMember.Lock()
+	mock.calls.UpsertOrganizationSCIMMember = append(mock.calls.UpsertOrganizationSCIMMember, callInfo)
+	mock.lockUpsertOrganizationSCIMMember.Unlock()
+	return mock.UpsertOrganizationSCIMMemberFunc(ctx, arg)
 }
 
-// UpdateImageWithErrorCalls gets all the calls that were made to UpdateImageWithError.
+// UpsertOrganizationSCIMMemberCalls gets all the calls that were made to UpsertOrganizationSCIMMember.
 // Check the length with:
 //
-//	len(mockedQuerier.UpdateImageWithErrorCalls())
-func (mock *QuerierMock) UpdateImageWithErrorCalls() []struct {
+//	len(mockedQuerier.UpsertOrganizationSCIMMemberCalls())
+func (mock *QuerierMock) UpsertOrganizationSCIMMemberCalls() []struct {
 	Ctx context.Context
-	Arg UpdateImageWithErrorParams
+	Arg UpsertOrganizationSCIMMemberParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		Arg UpdateImageWithErrorParams
+		Arg UpsertOrganizationSCIMMemberParams
 	}
-	mock.lockUpdateImageWithError.RLock()
-	calls = mock.calls.UpdateImageWithError
-	mock.lockUpdateImageWithError.RUnlock()
+	mock.lockUpsertOrganizationSCIMMember.RLock()
+	calls = mock.calls.UpsertOrganizationSCIMMember
+	mock.lockUpsertOrganizationSCIMMember.RUnlock()
 	return calls
 }
 
-// UpdateImageWithStagedURL calls UpdateImageWithStagedURLFunc.
-func (mock *QuerierMock) UpdateImageWithStagedURL(ctx context.Context, arg UpdateImageWithStagedURLParams) (*UpdateImageWithStagedURLRow, error) {
-	if mock.UpdateImageWithStagedURLFunc == nil {
-		panic("QuerierMock.UpdateImageWithStagedURLFunc: method is nil but Querier.UpdateImageWithStagedURL was just called")
+// UpsertOrganizationSCIMToken calls UpsertOrganizationSCIMTokenFunc.
+func (mock *QuerierMock) UpsertOrganizationSCIMToken(ctx context.Context, arg UpsertOrganizationSCIMTokenParams) (*OrganizationScimToken, error) {
+	if mock.UpsertOrganizationSCIMTokenFunc == nil {
+		panic("QuerierMock.UpsertOrganizationSCIMTokenFunc: method is nil but Querier.UpsertOrganizationSCIMToken was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Arg UpdateImageWithStagedURLParams
+		Arg UpsertOrganizationSCIMTokenParams
 	}{
 		Ctx: ctx,
 		Arg: arg,
 	}
-	mock.lockUpdateImageWithStagedURL.Lock()
-	mock.calls.UpdateImageWithStagedURL = append(mock.calls.UpdateImageWithStagedURL, callInfo)
-	mock.lockUpdateImageWithStagedURL.Unlock()
-	return mock.UpdateImageWithStagedURLFunc(ctx, arg)
+	mock.lockUpsertOrganizationSCIMToken.Lock()
+	mock.calls.UpsertOrganizationSCIMToken = append(mock.calls.UpsertOrganizationSCIMToken, callInfo)
+	mock.lockUpsertOrganizationSCIMToken.Unlock()
+	return mock.UpsertOrganizationSCIMTokenFunc(ctx, arg)
 }
 
-// UpdateImageWithStagedURLCalls gets all the calls that were made to UpdateImageWithStagedURL.
+// UpsertOrganizationSCIMTokenCalls gets all the calls that were made to UpsertOrganizationSCIMToken.
 // Check the length with:
 //
-//	len(mockedQuerier.UpdateImageWithStagedURLCalls())
-func (mock *QuerierMock) UpdateImageWithStagedURLCalls() []struct {
+//	len(mockedQuerier.UpsertOrganizationSCIMTokenCalls())
+func (mock *QuerierMock) UpsertOrganizationSCIMTokenCalls() []struct {
 	Ctx context.Context
-	Arg UpdateImageWithStagedURLParams
+	Arg UpsertOrganizationSCIMTokenParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		Arg UpdateImageWithStagedURLParams
+		Arg UpsertOrganizationSCIMTokenParams
 	}
-	mock.lockUpdateImageWithStagedURL.RLock()
-	calls = mock.calls.UpdateImageWithStagedURL
-	mock.lockUpdateImageWithStagedURL.RUnlock()
+	mock.lockUpsertOrganizationSCIMToken.RLock()
+	calls = mock.calls.UpsertOrganizationSCIMToken
+	mock.lockUpsertOrganizationSCIMToken.RUnlock()
 	return calls
 }
 
-// UpdateJobStatus calls UpdateJobStatusFunc.
-func (mock *QuerierMock) UpdateJobStatus(ctx context.Context, arg UpdateJobStatusParams) (*Job, error) {
-	if mock.UpdateJobStatusFunc == nil {
-		panic("QuerierMock.UpdateJobStatusFunc: method is nil but Querier.UpdateJobStatus was just called")
+// UpsertOrganizationSSO calls UpsertOrganizationSSOFunc.
+func (mock *QuerierMock) UpsertOrganizationSSO(ctx context.Context, arg UpsertOrganizationSSOParams) (*OrganizationSso, error) {
+	if mock.UpsertOrganizationSSOFunc == nil {
+		panic("QuerierMock.UpsertOrganizationSSOFunc: method is nil but Querier.UpsertOrganizationSSO was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Arg UpdateJobStatusParams
+		Arg UpsertOrganizationSSOParams
 	}{
 		Ctx: ctx,
 		Arg: arg,
 	}
-	mock.lockUpdateJobStatus.Lock()
-	mock.calls.UpdateJobStatus = append(mock.calls.UpdateJobStatus, callInfo)
-	mock.lockUpdateJobStatus.Unlock()
-	return mock.UpdateJobStatusFunc(ctx, arg)
+	mock.lockUpsertOrganizationSSO.Lock()
+	mock.calls.UpsertOrganizationSSO = append(mock.calls.UpsertOrganizationSSO, callInfo)
+	mock.lockUpsertOrganizationSSO.Unlock()
+	return mock.UpsertOrganizationSSOFunc(ctx, arg)
 }
 
-// UpdateJobStatusCalls gets all the calls that were made to UpdateJobStatus.
+// UpsertOrganizationSSOCalls gets all the calls that were made to UpsertOrganizationSSO.
 // Check the length with:
 //
-//	len(mockedQuerier.UpdateJobStatusCalls())
-func (mock *QuerierMock) UpdateJobStatusCalls() []struct {
+//	len(mockedQuerier.UpsertOrganizationSSOCalls())
+func (mock *QuerierMock) UpsertOrganizationSSOCalls() []struct {
 	Ctx context.Context
-	Arg UpdateJobStatusParams
+	Arg UpsertOrganizationSSOParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		Arg UpdateJobStatusParams
+		Arg UpsertOrganizationSSOParams
 	}
-	mock.lockUpdateJobStatus.RLock()
-	calls = mock.calls.UpdateJobStatus
-	mock.lockUpdateJobStatus.RUnlock()
+	mock.lockUpsertOrganizationSSO.RLock()
+	calls = mock.calls.UpsertOrganizationSSO
+	mock.lockUpsertOrganizationSSO.RUnlock()
 	return calls
 }
 
-// UpdateProject calls UpdateProjectFunc.
-func (mock *QuerierMock) UpdateProject(ctx context.Context, arg UpdateProjectParams) (*UpdateProjectRow, error) {
-	if mock.UpdateProjectFunc == nil {
-		panic("QuerierMock.UpdateProjectFunc: method is nil but Querier.UpdateProject was just called")
+// UpsertOrganizationSSOMember calls UpsertOrganizationSSOMemberFunc.
+func (mock *QuerierMock) UpsertOrganizationSSOMember(ctx context.Context, arg UpsertOrganizationSSOMemberParams) (*OrganizationMember, error) {
+	if mock.UpsertOrganizationSSOMemberFunc == nil {
+		panic("QuerierMock.UpsertOrganizationSSOMemberFunc: method is nil but Querier.UpsertOrganizationSSOMember was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Arg UpdateProjectParams
+		Arg UpsertOrganizationSSOMemberParams
 	}{
 		Ctx: ctx,
 		Arg: arg,
 	}
-	mock.lockUpdateProject.Lock()
-	mock.calls.UpdateProject = append(mock.calls.UpdateProject, callInfo)
-	mock.lockUpdateProject.Unlock()
-	return mock.UpdateProjectFunc(ctx, arg)
+	mock.lockUpsertOrganizationSSOMember.Lock()
+	mock.calls.UpsertOrganizationSSOMember = append(mock.calls.UpsertOrganizationSSOMember, callInfo)
+	mock.lockUpsertOrganizationSSOMember.Unlock()
+	return mock.UpsertOrganizationSSOMemberFunc(ctx, arg)
 }
 
-// UpdateProjectCalls gets all the calls that were made to UpdateProject.
+// UpsertOrganizationSSOMemberCalls gets all the calls that were made to UpsertOrganizationSSOMember.
 // Check the length with:
 //
-//	len(mockedQuerier.UpdateProjectCalls())
-func (mock *QuerierMock) UpdateProjectCalls() []struct {
+//	len(mockedQuerier.UpsertOrganizationSSOMemberCalls())
+func (mock *QuerierMock) UpsertOrganizationSSOMemberCalls() []struct {
 	Ctx context.Context
-	Arg UpdateProjectParams
+	Arg UpsertOrganizationSSOMemberParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		Arg UpdateProjectParams
+		Arg UpsertOrganizationSSOMemberParams
 	}
-	mock.lockUpdateProject.RLock()
-	calls = mock.calls.UpdateProject
-	mock.lockUpdateProject.RUnlock()
+	mock.lockUpsertOrganizationSSOMember.RLock()
+	calls = mock.calls.UpsertOrganizationSSOMember
+	mock.lockUpsertOrganizationSSOMember.RUnlock()
 	return calls
 }
 
-// UpdateProjectByUserID calls UpdateProjectByUserIDFunc.
-func (mock *QuerierMock) UpdateProjectByUserID(ctx context.Context, arg UpdateProjectByUserIDParams) (*UpdateProjectByUserIDRow, error) {
-	if mock.UpdateProjectByUserIDFunc == nil {
-		panic("QuerierMock.UpdateProjectByUserIDFunc: method is nil but Querier.UpdateProjectByUserID was just called")
+// UpsertPendingBillingEvent calls UpsertPendingBillingEventFunc.
+func (mock *QuerierMock) UpsertPendingBillingEvent(ctx context.Context, arg UpsertPendingBillingEventParams) error {
+	if mock.UpsertPendingBillingEventFunc == nil {
+		panic("QuerierMock.UpsertPendingBillingEventFunc: method is nil but Querier.UpsertPendingBillingEvent was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Arg UpdateProjectByUserIDParams
+		Arg UpsertPendingBillingEventParams
 	}{
 		Ctx: ctx,
 		Arg: arg,
 	}
-	mock.lockUpdateProjectByUserID.Lock()
-	mock.calls.UpdateProjectByUserID = append(mock.calls.UpdateProjectByUserID, callInfo)
-	mock.lockUpdateProjectByUserID.Unlock()
-	return mock.UpdateProjectByUserIDFunc(ctx, arg)
+	mock.lockUpsertPendingBillingEvent.Lock()
+	mock.calls.UpsertPendingBillingEvent = append(mock.calls.UpsertPendingBillingEvent, callInfo)
+	mock.lockUpsertPendingBillingEvent.Unlock()
+	return mock.UpsertPendingBillingEventFunc(ctx, arg)
 }
 
-// UpdateProjectByUserIDCalls gets all the calls that were made to UpdateProjectByUserID.
+// UpsertPendingBillingEventCalls gets all the calls that were made to UpsertPendingBillingEvent.
 // Check the length with:
 //
-//	len(mockedQuerier.UpdateProjectByUserIDCalls())
-func (mock *QuerierMock) UpdateProjectByUserIDCalls() []struct {
+//	len(mockedQuerier.UpsertPendingBillingEventCalls())
+func (mock *QuerierMock) UpsertPendingBillingEventCalls() []struct {
 	Ctx context.Context
-	Arg UpdateProjectByUserIDParams
+	Arg UpsertPendingBillingEventParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		Arg UpdateProjectByUserIDParams
+		Arg UpsertPendingBillingEventParams
 	}
-	mock.lockUpdateProjectByUserID.RLock()
-	calls = mock.calls.UpdateProjectByUserID
-	mock.lockUpdateProjectByUserID.RUnlock()
+	mock.lockUpsertPendingBillingEvent.RLock()
+	calls = mock.calls.UpsertPendingBillingEvent
+	mock.lockUpsertPendingBillingEvent.RUnlock()
 	return calls
 }
 
-// UpdateUserProfile calls UpdateUserProfileFunc.
-func (mock *QuerierMock) UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (*UpdateUserProfileRow, error) {
-	if mock.UpdateUserProfileFunc == nil {
-		panic("QuerierMock.UpdateUserProfileFunc: method is nil but Querier.UpdateUserProfile was just called")
+// UpsertProcessedEventByStripeID calls UpsertProcessedEventByStripeIDFunc.
+func (mock *QuerierMock) UpsertProcessedEventByStripeID(ctx context.Context, arg UpsertProcessedEventByStripeIDParams) (*ProcessedEvent, error) {
+	if mock.UpsertProcessedEventByStripeIDFunc == nil {
+		panic("QuerierMock.UpsertProcessedEventByStripeIDFunc: method is nil but Querier.UpsertProcessedEventByStripeID was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Arg UpdateUserProfileParams
+		Arg UpsertProcessedEventByStripeIDParams
 	}{
 		Ctx: ctx,
 		Arg: arg,
 	}
-	mock.lockUpdateUserProfile.Lock()
-	mock.calls.UpdateUserProfile = append(mock.calls.UpdateUserProfile, callInfo)
-	mock.lockUpdateUserProfile.Unlock()
-	return mock.UpdateUserProfileFunc(ctx, arg)
+	mock.lockUpsertProcessedEventByStripeID.Lock()
+	mock.calls.UpsertProcessedEventByStripeID = append(mock.calls.UpsertProcessedEventByStripeID, callInfo)
+	mock.lockUpsertProcessedEventByStripeID.Unlock()
+	return mock.UpsertProcessedEventByStripeIDFunc(ctx, arg)
 }
 
-// UpdateUserProfileCalls gets all the calls that were made to UpdateUserProfile.
+// UpsertProcessedEventByStripeIDCalls gets all the calls that were made to UpsertProcessedEventByStripeID.
 // Check the length with:
 //
-//	len(mockedQuerier.UpdateUserProfileCalls())
-func (mock *QuerierMock) UpdateUserProfileCalls() []struct {
+//	len(mockedQuerier.UpsertProcessedEventByStripeIDCalls())
+func (mock *QuerierMock) UpsertProcessedEventByStripeIDCalls() []struct {
 	Ctx context.Context
-	Arg UpdateUserProfileParams
+	Arg UpsertProcessedEventByStripeIDParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		Arg UpdateUserProfileParams
+		Arg UpsertProcessedEventByStripeIDParams
 	}
-	mock.lockUpdateUserProfile.RLock()
-	calls = mock.calls.UpdateUserProfile
-	mock.lockUpdateUserProfile.RUnlock()
+	mock.lockUpsertProcessedEventByStripeID.RLock()
+	calls = mock.calls.UpsertProcessedEventByStripeID
+	mock.lockUpsertProcessedEventByStripeID.RUnlock()
 	return calls
 }
 
-// UpdateUserRole calls UpdateUserRoleFunc.
-func (mock *QuerierMock) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (*UpdateUserRoleRow, error) {
-	if mock.UpdateUserRoleFunc == nil {
-		panic("QuerierMock.UpdateUserRoleFunc: method is nil but Querier.UpdateUserRole was just called")
+// UpsertProjectDeletionIntent calls UpsertProjectDeletionIntentFunc.
+func (mock *QuerierMock) UpsertProjectDeletionIntent(ctx context.Context, arg UpsertProjectDeletionIntentParams) error {
+	if mock.UpsertProjectDeletionIntentFunc == nil {
+		panic("QuerierMock.UpsertProjectDeletionIntentFunc: method is nil but Querier.UpsertProjectDeletionIntent was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Arg UpdateUserRoleParams
+		Arg UpsertProjectDeletionIntentParams
 	}{
 		Ctx: ctx,
 		Arg: arg,
 	}
-	mock.lockUpdateUserRole.Lock()
-	mock.calls.UpdateUserRole = append(mock.calls.UpdateUserRole, callInfo)
-	mock.lockUpdateUserRole.Unlock()
-	return mock.UpdateUserRoleFunc(ctx, arg)
+	mock.lockUpsertProjectDeletionIntent.Lock()
+	mock.calls.UpsertProjectDeletionIntent = append(mock.calls.UpsertProjectDeletionIntent, callInfo)
+	mock.lockUpsertProjectDeletionIntent.Unlock()
+	return mock.UpsertProjectDeletionIntentFunc(ctx, arg)
 }
 
-// UpdateUserRoleCalls gets all the calls that were made to UpdateUserRole.
+// UpsertProjectDeletionIntentCalls gets all the calls that were made to UpsertProjectDeletionIntent.
 // Check the length with:
 //
-//	len(mockedQuerier.UpdateUserRoleCalls())
-func (mock *QuerierMock) UpdateUserRoleCalls() []struct {
+//	len(mockedQuerier.UpsertProjectDeletionIntentCalls())
+func (mock *QuerierMock) UpsertProjectDeletionIntentCalls() []struct {
 	Ctx context.Context
-	Arg UpdateUserRoleParams
+	Arg UpsertProjectDeletionIntentParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		Arg UpdateUserRoleParams
+		Arg UpsertProjectDeletionIntentParams
 	}
-	mock.lockUpdateUserRole.RLock()
-	calls = mock.calls.UpdateUserRole
-	mock.lockUpdateUserRole.RUnlock()
+	mock.lockUpsertProjectDeletionIntent.RLock()
+	calls = mock.calls.UpsertProjectDeletionIntent
+	mock.lockUpsertProjectDeletionIntent.RUnlock()
 	return calls
 }
 
-// UpdateUserStripeCustomerID calls UpdateUserStripeCustomerIDFunc.
-func (mock *QuerierMock) UpdateUserStripeCustomerID(ctx context.Context, arg UpdateUserStripeCustomerIDParams) (*UpdateUserStripeCustomerIDRow, error) {
-	if mock.UpdateUserStripeCustomerIDFunc == nil {
-		panic("QuerierMock.UpdateUserStripeCustomerIDFunc: method is nil but Querier.UpdateUserStripeCustomerID was just called")
+// UpsertProjectDisclosure calls UpsertProjectDisclosureFunc.
+func (mock *QuerierMock) UpsertProjectDisclosure(ctx context.Context, arg UpsertProjectDisclosureParams) (*ProjectDisclosure, error) {
+	if mock.UpsertProjectDisclosureFunc == nil {
+		panic("QuerierMock.UpsertProjectDisclosureFunc: method is nil but Querier.UpsertProjectDisclosure was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Arg UpdateUserStripeCustomerIDParams
+		Arg UpsertProjectDisclosureParams
 	}{
 		Ctx: ctx,
 		Arg: arg,
 	}
-	mock.lockUpdateUserStripeCustomerID.Lock()
-	mock.calls.UpdateUserStripeCustomerID = append(mock.calls.UpdateUserStripeCustomerID, callInfo)
-	mock.lockUpdateUserStripeCustomerID.Unlock()
-	return mock.UpdateUserStripeCustomerIDFunc(ctx, arg)
+	mock.lockUpsertProjectDisclosure.Lock()
+	mock.calls.UpsertProjectDisclosure = append(mock.calls.UpsertProjectDisclosure, callInfo)
+	mock.lockUpsertProjectDisclosure.Unlock()
+	return mock.UpsertProjectDisclosureFunc(ctx, arg)
 }
 
-// UpdateUserStripeCustomerIDCalls gets all the calls that were made to UpdateUserStripeCustomerID.
+// UpsertProjectDisclosureCalls gets all the calls that were made to UpsertProjectDisclosure.
 // Check the length with:
 //
-//	len(mockedQuerier.UpdateUserStripeCustomerIDCalls())
-func (mock *QuerierMock) UpdateUserStripeCustomerIDCalls() []struct {
+//	len(mockedQuerier.UpsertProjectDisclosureCalls())
+func (mock *QuerierMock) UpsertProjectDisclosureCalls() []struct {
 	Ctx context.Context
-	Arg UpdateUserStripeCustomerIDParams
+	Arg UpsertProjectDisclosureParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		Arg UpdateUserStripeCustomerIDParams
+		Arg UpsertProjectDisclosureParams
 	}
-	mock.lockUpdateUserStripeCustomerID.RLock()
-	calls = mock.calls.UpdateUserStripeCustomerID
-	mock.lockUpdateUserStripeCustomerID.RUnlock()
+	mock.lockUpsertProjectDisclosure.RLock()
+	calls = mock.calls.UpsertProjectDisclosure
+	mock.lockUpsertProjectDisclosure.RUnlock()
 	return calls
 }
 
-// UpsertInvoiceByStripeID calls UpsertInvoiceByStripeIDFunc.
-func (mock *QuerierMock) UpsertInvoiceByStripeID(ctx context.Context, arg UpsertInvoiceByStripeIDParams) (*Invoice, error) {
-	if mock.UpsertInvoiceByStripeIDFunc == nil {
-		panic("QuerierMock.UpsertInvoiceByStripeIDFunc: method is nil but Querier.UpsertInvoiceByStripeID was just called")
+// UpsertProjectListing calls UpsertProjectListingFunc.
+func (mock *QuerierMock) UpsertProjectListing(ctx context.Context, arg UpsertProjectListingParams) (*ProjectListing, error) {
+	if mock.UpsertProjectListingFunc == nil {
+		panic("QuerierMock.UpsertProjectListingFunc: method is nil but Querier.UpsertProjectListing was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Arg UpsertInvoiceByStripeIDParams
+		Arg UpsertProjectListingParams
 	}{
 		Ctx: ctx,
 		Arg: arg,
 	}
-	mock.lockUpsertInvoiceByStripeID.Lock()
-	mock.calls.UpsertInvoiceByStripeID = append(mock.calls.UpsertInvoiceByStripeID, callInfo)
-	mock.lockUpsertInvoiceByStripeID.Unlock()
-	return mock.UpsertInvoiceByStripeIDFunc(ctx, arg)
+	mock.lockUpsertProjectListing.Lock()
+	mock.calls.UpsertProjectListing = append(mock.calls.UpsertProjectListing, callInfo)
+	mock.lockUpsertProjectListing.Unlock()
+	return mock.UpsertProjectListingFunc(ctx, arg)
 }
 
-// UpsertInvoiceByStripeIDCalls gets all the calls that were made to UpsertInvoiceByStripeID.
+// UpsertProjectListingCalls gets all the calls that were made to UpsertProjectListing.
 // Check the length with:
 //
-//	len(mockedQuerier.UpsertInvoiceByStripeIDCalls())
-func (mock *QuerierMock) UpsertInvoiceByStripeIDCalls() []struct {
+//	len(mockedQuerier.UpsertProjectListingCalls())
+func (mock *QuerierMock) UpsertProjectListingCalls() []struct {
 	Ctx context.Context
-	Arg UpsertInvoiceByStripeIDParams
+	Arg UpsertProjectListingParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		Arg UpsertInvoiceByStripeIDParams
+		Arg UpsertProjectListingParams
 	}
-	mock.lockUpsertInvoiceByStripeID.RLock()
-	calls = mock.calls.UpsertInvoiceByStripeID
-	mock.lockUpsertInvoiceByStripeID.RUnlock()
+	mock.lockUpsertProjectListing.RLock()
+	calls = mock.calls.UpsertProjectListing
+	mock.lockUpsertProjectListing.RUnlock()
 	return calls
 }
 
-// UpsertProcessedEventByStripeID calls UpsertProcessedEventByStripeIDFunc.
-func (mock *QuerierMock) UpsertProcessedEventByStripeID(ctx context.Context, arg UpsertProcessedEventByStripeIDParams) (*ProcessedEvent, error) {
-	if mock.UpsertProcessedEventByStripeIDFunc == nil {
-		panic("QuerierMock.UpsertProcessedEventByStripeIDFunc: method is nil but Querier.UpsertProcessedEventByStripeID was just called")
+// UpsertShareBranding calls UpsertShareBrandingFunc.
+func (mock *QuerierMock) UpsertShareBranding(ctx context.Context, arg UpsertShareBrandingParams) (*ShareBranding, error) {
+	if mock.UpsertShareBrandingFunc == nil {
+		panic("QuerierMock.UpsertShareBrandingFunc: method is nil but Querier.UpsertShareBranding was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Arg UpsertProcessedEventByStripeIDParams
+		Arg UpsertShareBrandingParams
 	}{
 		Ctx: ctx,
 		Arg: arg,
 	}
-	mock.lockUpsertProcessedEventByStripeID.Lock()
-	mock.calls.UpsertProcessedEventByStripeID = append(mock.calls.UpsertProcessedEventByStripeID, callInfo)
-	mock.lockUpsertProcessedEventByStripeID.Unlock()
-	return mock.UpsertProcessedEventByStripeIDFunc(ctx, arg)
+	mock.lockUpsertShareBranding.Lock()
+	mock.calls.UpsertShareBranding = append(mock.calls.UpsertShareBranding, callInfo)
+	mock.lockUpsertShareBranding.Unlock()
+	return mock.UpsertShareBrandingFunc(ctx, arg)
 }
 
-// UpsertProcessedEventByStripeIDCalls gets all the calls that were made to UpsertProcessedEventByStripeID.
+// UpsertShareBrandingCalls gets all the calls that were made to UpsertShareBranding.
 // Check the length with:
 //
-//	len(mockedQuerier.UpsertProcessedEventByStripeIDCalls())
-func (mock *QuerierMock) UpsertProcessedEventByStripeIDCalls() []struct {
+//	len(mockedQuerier.UpsertShareBrandingCalls())
+func (mock *QuerierMock) UpsertShareBrandingCalls() []struct {
 	Ctx context.Context
-	Arg UpsertProcessedEventByStripeIDParams
+	Arg UpsertShareBrandingParams
 } {
 	var calls []struct {
 		Ctx context.Context
-		Arg UpsertProcessedEventByStripeIDParams
+		Arg UpsertShareBrandingParams
 	}
-	mock.lockUpsertProcessedEventByStripeID.RLock()
-	calls = mock.calls.UpsertProcessedEventByStripeID
-	mock.lockUpsertProcessedEventByStripeID.RUnlock()
+	mock.lockUpsertShareBranding.RLock()
+	calls = mock.calls.UpsertShareBranding
+	mock.lockUpsertShareBranding.RUnlock()
 	return calls
 }
 
@@ -2568,3 +12402,39 @@ func (mock *QuerierMock) UpsertSubscriptionByStripeIDCalls() []struct {
 	mock.lockUpsertSubscriptionByStripeID.RUnlock()
 	return calls
 }
+
+// UpsertTeamWebhook calls UpsertTeamWebhookFunc.
+func (mock *QuerierMock) UpsertTeamWebhook(ctx context.Context, arg UpsertTeamWebhookParams) (*TeamWebhook, error) {
+	if mock.UpsertTeamWebhookFunc == nil {
+		panic("QuerierMock.UpsertTeamWebhookFunc: method is nil but Querier.UpsertTeamWebhook was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg UpsertTeamWebhookParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockUpsertTeamWebhook.Lock()
+	mock.calls.UpsertTeamWebhook = append(mock.calls.UpsertTeamWebhook, callInfo)
+	mock.lockUpsertTeamWebhook.Unlock()
+	return mock.UpsertTeamWebhookFunc(ctx, arg)
+}
+
+// UpsertTeamWebhookCalls gets all the calls that were made to UpsertTeamWebhook.
+// Check the length with:
+//
+//	len(mockedQuerier.UpsertTeamWebhookCalls())
+func (mock *QuerierMock) UpsertTeamWebhookCalls() []struct {
+	Ctx context.Context
+	Arg UpsertTeamWebhookParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg UpsertTeamWebhookParams
+	}
+	mock.lockUpsertTeamWebhook.RLock()
+	calls = mock.calls.UpsertTeamWebhook
+	mock.lockUpsertTeamWebhook.RUnlock()
+	return calls
+}
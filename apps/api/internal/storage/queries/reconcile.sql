@@ -0,0 +1,40 @@
+-- Reconcile: compares image rows against S3 objects
+
+-- name: ListImagesForReconcile :many
+-- The optional created_at window ($5, $6) lets the planner skip monthly partitions outside it.
+SELECT id, project_id, original_url, staged_url, room_type, style, seed, status, error, created_at, updated_at
+FROM images
+WHERE ($1::uuid IS NULL OR project_id = $1::uuid)
+  AND ($2::text IS NULL OR $2::text = '' OR status = $2::image_status)
+  AND ($3::uuid IS NULL OR id > $3::uuid)
+  AND created_at >= COALESCE($5::timestamptz, '-infinity')
+  AND created_at < COALESCE($6::timestamptz, 'infinity')
+ORDER BY id ASC
+LIMIT $4;
+
+-- name: ListImageStatusDrift :many
+-- Returns the images among @image_ids whose status differs from the latest event in their
+-- status log, with the status that event recorded. Images without events are skipped.
+SELECT i.id, i.status, e.to_status AS event_status
+FROM images i
+JOIN LATERAL (
+  SELECT t.to_status
+  FROM image_status_transitions t
+  WHERE t.image_id = i.id
+  ORDER BY t.id DESC
+  LIMIT 1
+) e ON true
+WHERE i.id = ANY(@image_ids::uuid[])
+  AND i.status <> e.to_status;
+
+-- name: SyncImageStatusFromEvents :execrows
+-- Puts the image back to @event_status, the status its log ends in, unless its status has
+-- moved on from @status since the drift was found. The status trigger logs the correction
+-- with the reconcile source, so the log still ends in @event_status.
+WITH source AS (
+  SELECT set_config('app.status_source', 'real-staging-reconcile', true)
+)
+UPDATE images
+SET status = @event_status, updated_at = now()
+FROM source
+WHERE images.id = @id AND images.status = @status;
@@ -0,0 +1,13 @@
+-- name: AddProjectRetentionExemption :exec
+INSERT INTO project_retention_exemptions (project_id)
+VALUES ($1)
+ON CONFLICT (project_id) DO NOTHING;
+
+-- name: IsProjectRetentionExempt :one
+SELECT EXISTS (
+  SELECT 1 FROM project_retention_exemptions WHERE project_id = $1
+) AS exempt;
+
+-- name: RemoveProjectRetentionExemption :exec
+DELETE FROM project_retention_exemptions
+WHERE project_id = $1;
@@ -0,0 +1,46 @@
+-- name: CreateRoomGrouping :one
+INSERT INTO room_groupings (project_id)
+VALUES ($1)
+RETURNING *;
+
+-- name: GetRoomGrouping :one
+SELECT * FROM room_groupings
+WHERE id = $1 AND project_id = $2;
+
+-- name: GetLatestRoomGrouping :one
+SELECT * FROM room_groupings
+WHERE project_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: HasActiveRoomGrouping :one
+-- Whether a grouping of the project is queued or running. Groupings left unfinished for
+-- longer than an hour are taken to have been lost with their worker.
+SELECT EXISTS (
+  SELECT 1 FROM room_groupings
+  WHERE project_id = $1
+    AND status IN ('queued', 'processing')
+    AND updated_at > now() - interval '1 hour'
+);
+
+-- name: CountProjectImages :one
+SELECT COUNT(*) FROM images
+WHERE project_id = $1;
+
+-- name: FailRoomGrouping :exec
+UPDATE room_groupings
+SET status = 'error', error = $2, updated_at = now()
+WHERE id = $1;
+
+-- name: ConfirmRoomGrouping :one
+-- Records the groups the owner applied. Only a ready grouping can be confirmed, once.
+UPDATE room_groupings
+SET status = 'confirmed', groups = $2, confirmed_at = now(), updated_at = now()
+WHERE id = $1 AND status = 'ready'
+RETURNING *;
+
+-- name: SetProjectImagesRoomType :execrows
+-- Sets the room type of the listed images; images outside the project are left alone.
+UPDATE images
+SET room_type = sqlc.arg(room_type), updated_at = now()
+WHERE project_id = sqlc.arg(project_id) AND id = ANY(sqlc.arg(image_ids)::uuid[]);
@@ -0,0 +1,14 @@
+-- name: GetSetting :one
+SELECT key, value, description, updated_at, updated_by
+FROM settings
+WHERE key = $1;
+
+-- name: ListSettings :many
+SELECT key, value, description, updated_at, updated_by
+FROM settings
+ORDER BY key;
+
+-- name: UpdateSetting :execrows
+UPDATE settings
+SET value = $1, updated_at = now(), updated_by = $2
+WHERE key = $3;
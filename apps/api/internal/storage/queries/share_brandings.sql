@@ -0,0 +1,40 @@
+-- name: GetShareBranding :one
+SELECT user_id, primary_color, accent_color, logo_url, custom_domain, domain_token, domain_verified_at,
+       created_at, updated_at
+FROM share_brandings
+WHERE user_id = $1;
+
+-- name: GetShareBrandingByVerifiedDomain :one
+SELECT user_id, primary_color, accent_color, logo_url, custom_domain, domain_token, domain_verified_at,
+       created_at, updated_at
+FROM share_brandings
+WHERE custom_domain = $1 AND domain_verified_at IS NOT NULL;
+
+-- name: UpsertShareBranding :one
+-- Keeping the custom domain keeps its token and verification; changing it starts over.
+INSERT INTO share_brandings (user_id, primary_color, accent_color, logo_url, custom_domain, domain_token)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (user_id) DO UPDATE SET
+  primary_color = EXCLUDED.primary_color,
+  accent_color = EXCLUDED.accent_color,
+  logo_url = EXCLUDED.logo_url,
+  custom_domain = EXCLUDED.custom_domain,
+  domain_token = CASE WHEN share_brandings.custom_domain IS NOT DISTINCT FROM EXCLUDED.custom_domain
+    THEN share_brandings.domain_token ELSE EXCLUDED.domain_token END,
+  domain_verified_at = CASE WHEN share_brandings.custom_domain IS NOT DISTINCT FROM EXCLUDED.custom_domain
+    THEN share_brandings.domain_verified_at END,
+  updated_at = now()
+RETURNING user_id, primary_color, accent_color, logo_url, custom_domain, domain_token, domain_verified_at,
+          created_at, updated_at;
+
+-- name: MarkShareBrandingDomainVerified :one
+UPDATE share_brandings
+SET domain_verified_at = COALESCE(domain_verified_at, now()),
+    updated_at = now()
+WHERE user_id = $1 AND custom_domain IS NOT NULL
+RETURNING user_id, primary_color, accent_color, logo_url, custom_domain, domain_token, domain_verified_at,
+          created_at, updated_at;
+
+-- name: DeleteShareBranding :execrows
+DELETE FROM share_brandings
+WHERE user_id = $1;
@@ -0,0 +1,85 @@
+-- name: ListTeamWebhooks :many
+SELECT id, user_id, provider, url, events, last_delivery_at, last_delivery_error, created_at, updated_at,
+  signing_secret, previous_signing_secret, previous_secret_expires_at
+FROM team_webhooks
+WHERE user_id = $1
+ORDER BY provider;
+
+-- name: GetTeamWebhook :one
+SELECT id, user_id, provider, url, events, last_delivery_at, last_delivery_error, created_at, updated_at,
+  signing_secret, previous_signing_secret, previous_secret_expires_at
+FROM team_webhooks
+WHERE user_id = $1 AND provider = $2;
+
+-- name: ListTeamWebhooksForEvent :many
+-- Lists the user's webhooks subscribed to an event.
+SELECT id, user_id, provider, url, events, last_delivery_at, last_delivery_error, created_at, updated_at,
+  signing_secret, previous_signing_secret, previous_secret_expires_at
+FROM team_webhooks
+WHERE user_id = sqlc.arg('user_id') AND sqlc.arg('event')::text = ANY(events)
+ORDER BY provider;
+
+-- name: UpsertTeamWebhook :one
+-- Saves the account's webhook for a provider. Changing it clears the last delivery error.
+INSERT INTO team_webhooks (user_id, provider, url, events)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id, provider) DO UPDATE SET
+  url = EXCLUDED.url,
+  events = EXCLUDED.events,
+  last_delivery_error = NULL,
+  updated_at = now()
+RETURNING id, user_id, provider, url, events, last_delivery_at, last_delivery_error, created_at, updated_at,
+  signing_secret, previous_signing_secret, previous_secret_expires_at;
+
+-- name: DeleteTeamWebhook :execrows
+DELETE FROM team_webhooks
+WHERE user_id = $1 AND provider = $2;
+
+-- name: RecordTeamWebhookDelivery :exec
+-- Records the outcome of a delivery; a NULL error marks it successful.
+UPDATE team_webhooks
+SET last_delivery_at = now(), last_delivery_error = $2
+WHERE id = $1;
+
+-- name: RotateTeamWebhookSecret :one
+-- Replaces the signing secret; the old one keeps signing until previous_secret_expires_at.
+UPDATE team_webhooks
+SET previous_signing_secret = signing_secret,
+  signing_secret = sqlc.arg('signing_secret'),
+  previous_secret_expires_at = sqlc.arg('previous_secret_expires_at'),
+  updated_at = now()
+WHERE user_id = sqlc.arg('user_id') AND provider = sqlc.arg('provider')
+RETURNING id, user_id, provider, url, events, last_delivery_at, last_delivery_error, created_at, updated_at,
+  signing_secret, previous_signing_secret, previous_secret_expires_at;
+
+-- name: CreateTeamWebhookDelivery :one
+-- Logs a delivery, due at once.
+INSERT INTO team_webhook_deliveries (webhook_id, event, payload, next_attempt_at)
+VALUES ($1, $2, $3, now())
+RETURNING *;
+
+-- name: RecordTeamWebhookDeliveryAttempt :one
+-- Counts an attempt and moves the delivery to its new status; next_attempt_at is NULL
+-- unless it stays pending.
+UPDATE team_webhook_deliveries
+SET attempts = attempts + 1,
+  last_attempt_at = now(),
+  status = sqlc.arg('status'),
+  next_attempt_at = sqlc.arg('next_attempt_at'),
+  last_error = sqlc.arg('last_error'),
+  delivered_at = CASE WHEN sqlc.arg('status')::text = 'delivered' THEN now() ELSE delivered_at END
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: ListTeamWebhookDeliveries :many
+SELECT d.*
+FROM team_webhook_deliveries d
+JOIN team_webhooks w ON w.id = d.webhook_id
+WHERE w.user_id = $1 AND w.provider = $2
+ORDER BY d.created_at DESC
+LIMIT $3;
+
+-- name: GetTeamWebhookDelivery :one
+SELECT *
+FROM team_webhook_deliveries
+WHERE id = $1 AND webhook_id = $2;
@@ -0,0 +1,31 @@
+-- name: ReserveUsage :one
+-- Adds staged_images to the user's count for the period unless the total would pass
+-- monthly_limit, in which case no row is returned.
+INSERT INTO usage_counters (user_id, period_start, staged_images)
+VALUES (@user_id, @period_start, @staged_images)
+ON CONFLICT (user_id, period_start) DO UPDATE
+SET staged_images = usage_counters.staged_images + EXCLUDED.staged_images,
+    updated_at = now()
+WHERE usage_counters.staged_images + EXCLUDED.staged_images <= @monthly_limit
+RETURNING staged_images;
+
+-- name: ReleaseUsage :exec
+-- Returns staged_images reserved for images that were never created.
+UPDATE usage_counters
+SET staged_images = GREATEST(staged_images - @staged_images, 0),
+    updated_at = now()
+WHERE user_id = @user_id AND period_start = @period_start;
+
+-- name: GetUsage :one
+SELECT staged_images FROM usage_counters
+WHERE user_id = $1 AND period_start = $2;
+
+-- name: GetUserBillingPeriod :one
+-- Returns the current period of the user's most recently updated active subscription, the
+-- one their entitlements come from.
+SELECT current_period_start, current_period_end
+FROM subscriptions
+WHERE user_id = $1
+  AND status IN ('active', 'trialing', 'past_due')
+ORDER BY updated_at DESC
+LIMIT 1;
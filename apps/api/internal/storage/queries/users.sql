@@ -1,6 +1,6 @@
 -- name: CreateUser :one
-INSERT INTO users (auth0_sub, stripe_customer_id, role)
-VALUES ($1, $2, $3)
+INSERT INTO users (auth0_sub, stripe_customer_id, role, stripe_customer_id_hash)
+VALUES ($1, $2, $3, $4)
 RETURNING id, auth0_sub, stripe_customer_id, role, created_at;
 
 -- name: GetUserByID :one
@@ -18,9 +18,14 @@ SELECT id, auth0_sub, stripe_customer_id, role, created_at
 FROM users
 WHERE stripe_customer_id = $1;
 
+-- name: GetUserByStripeCustomerIDHash :one
+SELECT id, auth0_sub, stripe_customer_id, role, created_at
+FROM users
+WHERE stripe_customer_id_hash = $1;
+
 -- name: UpdateUserStripeCustomerID :one
 UPDATE users
-SET stripe_customer_id = $2
+SET stripe_customer_id = $2, stripe_customer_id_hash = $3
 WHERE id = $1
 RETURNING id, auth0_sub, stripe_customer_id, role, created_at;
 
@@ -105,3 +110,31 @@ RETURNING
   preferences,
   created_at,
   updated_at;
+
+-- name: ListUserEncryptedFields :many
+SELECT id, stripe_customer_id, stripe_customer_id_hash, phone, billing_address
+FROM users
+WHERE id > $1
+ORDER BY id
+LIMIT $2;
+
+-- name: UpdateUserEncryptedFields :exec
+UPDATE users
+SET
+  stripe_customer_id = $2,
+  stripe_customer_id_hash = $3,
+  phone = $4,
+  billing_address = $5
+WHERE id = $1;
+
+-- name: GetUserStorageRegion :one
+-- Returns the data-residency region the user's new images are stored in; NULL is the
+-- home bucket.
+SELECT storage_region
+FROM users
+WHERE id = $1;
+
+-- name: UpdateUserStorageRegion :execrows
+UPDATE users
+SET storage_region = $2, updated_at = now()
+WHERE id = $1;
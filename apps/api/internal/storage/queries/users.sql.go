@@ -24,15 +24,16 @@ func (q *Queries) CountUsers(ctx context.Context) (int64, error) {
 }
 
 const CreateUser = `-- name: CreateUser :one
-INSERT INTO users (auth0_sub, stripe_customer_id, role)
-VALUES ($1, $2, $3)
+INSERT INTO users (auth0_sub, stripe_customer_id, role, stripe_customer_id_hash)
+VALUES ($1, $2, $3, $4)
 RETURNING id, auth0_sub, stripe_customer_id, role, created_at
 `
 
 type CreateUserParams struct {
-	Auth0Sub         string      `json:"auth0_sub"`
-	StripeCustomerID pgtype.Text `json:"stripe_customer_id"`
-	Role             string      `json:"role"`
+	Auth0Sub             string      `json:"auth0_sub"`
+	StripeCustomerID     pgtype.Text `json:"stripe_customer_id"`
+	Role                 string      `json:"role"`
+	StripeCustomerIDHash pgtype.Text `json:"stripe_customer_id_hash"`
 }
 
 type CreateUserRow struct {
@@ -44,7 +45,12 @@ type CreateUserRow struct {
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (*CreateUserRow, error) {
-	row := q.db.QueryRow(ctx, CreateUser, arg.Auth0Sub, arg.StripeCustomerID, arg.Role)
+	row := q.db.QueryRow(ctx, CreateUser,
+		arg.Auth0Sub,
+		arg.StripeCustomerID,
+		arg.Role,
+		arg.StripeCustomerIDHash,
+	)
 	var i CreateUserRow
 	err := row.Scan(
 		&i.ID,
@@ -147,6 +153,33 @@ func (q *Queries) GetUserByStripeCustomerID(ctx context.Context, stripeCustomerI
 	return &i, err
 }
 
+const GetUserByStripeCustomerIDHash = `-- name: GetUserByStripeCustomerIDHash :one
+SELECT id, auth0_sub, stripe_customer_id, role, created_at
+FROM users
+WHERE stripe_customer_id_hash = $1
+`
+
+type GetUserByStripeCustomerIDHashRow struct {
+	ID               pgtype.UUID        `json:"id"`
+	Auth0Sub         string             `json:"auth0_sub"`
+	StripeCustomerID pgtype.Text        `json:"stripe_customer_id"`
+	Role             string             `json:"role"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) GetUserByStripeCustomerIDHash(ctx context.Context, stripeCustomerIDHash pgtype.Text) (*GetUserByStripeCustomerIDHashRow, error) {
+	row := q.db.QueryRow(ctx, GetUserByStripeCustomerIDHash, stripeCustomerIDHash)
+	var i GetUserByStripeCustomerIDHashRow
+	err := row.Scan(
+		&i.ID,
+		&i.Auth0Sub,
+		&i.StripeCustomerID,
+		&i.Role,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
 const GetUserProfileByAuth0Sub = `-- name: GetUserProfileByAuth0Sub :one
 SELECT 
   id, 
@@ -259,6 +292,68 @@ func (q *Queries) GetUserProfileByID(ctx context.Context, id pgtype.UUID) (*GetU
 	return &i, err
 }
 
+const GetUserStorageRegion = `-- name: GetUserStorageRegion :one
+SELECT storage_region
+FROM users
+WHERE id = $1
+`
+
+// Returns the data-residency region the user's new images are stored in; NULL is the
+// home bucket.
+func (q *Queries) GetUserStorageRegion(ctx context.Context, id pgtype.UUID) (pgtype.Text, error) {
+	row := q.db.QueryRow(ctx, GetUserStorageRegion, id)
+	var storage_region pgtype.Text
+	err := row.Scan(&storage_region)
+	return storage_region, err
+}
+
+const ListUserEncryptedFields = `-- name: ListUserEncryptedFields :many
+SELECT id, stripe_customer_id, stripe_customer_id_hash, phone, billing_address
+FROM users
+WHERE id > $1
+ORDER BY id
+LIMIT $2
+`
+
+type ListUserEncryptedFieldsParams struct {
+	ID    pgtype.UUID `json:"id"`
+	Limit int32       `json:"limit"`
+}
+
+type ListUserEncryptedFieldsRow struct {
+	ID                   pgtype.UUID `json:"id"`
+	StripeCustomerID     pgtype.Text `json:"stripe_customer_id"`
+	StripeCustomerIDHash pgtype.Text `json:"stripe_customer_id_hash"`
+	Phone                pgtype.Text `json:"phone"`
+	BillingAddress       []byte      `json:"billing_address"`
+}
+
+func (q *Queries) ListUserEncryptedFields(ctx context.Context, arg ListUserEncryptedFieldsParams) ([]*ListUserEncryptedFieldsRow, error) {
+	rows, err := q.db.Query(ctx, ListUserEncryptedFields, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListUserEncryptedFieldsRow{}
+	for rows.Next() {
+		var i ListUserEncryptedFieldsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.StripeCustomerID,
+			&i.StripeCustomerIDHash,
+			&i.Phone,
+			&i.BillingAddress,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const ListUsers = `-- name: ListUsers :many
 SELECT id, auth0_sub, stripe_customer_id, role, created_at
 FROM users
@@ -305,6 +400,35 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]*ListUs
 	return items, nil
 }
 
+const UpdateUserEncryptedFields = `-- name: UpdateUserEncryptedFields :exec
+UPDATE users
+SET
+  stripe_customer_id = $2,
+  stripe_customer_id_hash = $3,
+  phone = $4,
+  billing_address = $5
+WHERE id = $1
+`
+
+type UpdateUserEncryptedFieldsParams struct {
+	ID                   pgtype.UUID `json:"id"`
+	StripeCustomerID     pgtype.Text `json:"stripe_customer_id"`
+	StripeCustomerIDHash pgtype.Text `json:"stripe_customer_id_hash"`
+	Phone                pgtype.Text `json:"phone"`
+	BillingAddress       []byte      `json:"billing_address"`
+}
+
+func (q *Queries) UpdateUserEncryptedFields(ctx context.Context, arg UpdateUserEncryptedFieldsParams) error {
+	_, err := q.db.Exec(ctx, UpdateUserEncryptedFields,
+		arg.ID,
+		arg.StripeCustomerID,
+		arg.StripeCustomerIDHash,
+		arg.Phone,
+		arg.BillingAddress,
+	)
+	return err
+}
+
 const UpdateUserProfile = `-- name: UpdateUserProfile :one
 UPDATE users
 SET 
@@ -422,16 +546,36 @@ func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams)
 	return &i, err
 }
 
+const UpdateUserStorageRegion = `-- name: UpdateUserStorageRegion :execrows
+UPDATE users
+SET storage_region = $2, updated_at = now()
+WHERE id = $1
+`
+
+type UpdateUserStorageRegionParams struct {
+	ID            pgtype.UUID `json:"id"`
+	StorageRegion pgtype.Text `json:"storage_region"`
+}
+
+func (q *Queries) UpdateUserStorageRegion(ctx context.Context, arg UpdateUserStorageRegionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, UpdateUserStorageRegion, arg.ID, arg.StorageRegion)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const UpdateUserStripeCustomerID = `-- name: UpdateUserStripeCustomerID :one
 UPDATE users
-SET stripe_customer_id = $2
+SET stripe_customer_id = $2, stripe_customer_id_hash = $3
 WHERE id = $1
 RETURNING id, auth0_sub, stripe_customer_id, role, created_at
 `
 
 type UpdateUserStripeCustomerIDParams struct {
-	ID               pgtype.UUID `json:"id"`
-	StripeCustomerID pgtype.Text `json:"stripe_customer_id"`
+	ID                   pgtype.UUID `json:"id"`
+	StripeCustomerID     pgtype.Text `json:"stripe_customer_id"`
+	StripeCustomerIDHash pgtype.Text `json:"stripe_customer_id_hash"`
 }
 
 type UpdateUserStripeCustomerIDRow struct {
@@ -443,7 +587,7 @@ type UpdateUserStripeCustomerIDRow struct {
 }
 
 func (q *Queries) UpdateUserStripeCustomerID(ctx context.Context, arg UpdateUserStripeCustomerIDParams) (*UpdateUserStripeCustomerIDRow, error) {
-	row := q.db.QueryRow(ctx, UpdateUserStripeCustomerID, arg.ID, arg.StripeCustomerID)
+	row := q.db.QueryRow(ctx, UpdateUserStripeCustomerID, arg.ID, arg.StripeCustomerID, arg.StripeCustomerIDHash)
 	var i UpdateUserStripeCustomerIDRow
 	err := row.Scan(
 		&i.ID,
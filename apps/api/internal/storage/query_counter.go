@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/telemetry"
+)
+
+// QueryCounter counts every statement into the Usage of the statement's context, so a
+// request's span reports how many queries it ran. It is installed as one of the pool's
+// pgx.QueryTracers.
+type QueryCounter struct{}
+
+// Ensure QueryCounter implements pgx.QueryTracer.
+var _ pgx.QueryTracer = QueryCounter{}
+
+// TraceQueryStart counts the statement.
+func (QueryCounter) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	telemetry.UsageFrom(ctx).AddDBQuery()
+	return ctx
+}
+
+// TraceQueryEnd does nothing.
+func (QueryCounter) TraceQueryEnd(context.Context, *pgx.Conn, pgx.TraceQueryEndData) {}
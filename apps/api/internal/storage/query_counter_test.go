@@ -0,0 +1,25 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/real-staging-ai/api/internal/telemetry"
+)
+
+func TestQueryCounter(t *testing.T) {
+	ctx, usage := telemetry.WithUsage(context.Background())
+	counter := QueryCounter{}
+	for range 3 {
+		qctx := counter.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+		counter.TraceQueryEnd(qctx, nil, pgx.TraceQueryEndData{})
+	}
+	// Statements outside a request are not counted anywhere.
+	counter.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+
+	assert.Contains(t, usage.Attributes(), attribute.Int64(telemetry.AttrDBQueryCount, 3))
+}
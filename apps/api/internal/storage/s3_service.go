@@ -7,15 +7,32 @@ import "context"
 // S3Service defines the interface for S3 storage operations.
 type S3Service interface {
 	// HeadFile checks if a file exists in S3 and returns its metadata.
+	// It returns an error wrapping ErrObjectNotFound when the file does not exist.
 	HeadFile(ctx context.Context, fileKey string) (interface{}, error)
 	// DeleteFile deletes a file from S3.
 	DeleteFile(ctx context.Context, fileKey string) error
+	// GetFile reads the full contents of a file from S3.
+	// It returns an error wrapping ErrObjectNotFound when the file does not exist.
+	GetFile(ctx context.Context, fileKey string) ([]byte, error)
+	// PutFile writes body to fileKey, replacing any existing file.
+	PutFile(ctx context.Context, fileKey string, body []byte, contentType string) error
 	// GetFileURL returns the public URL for a file in S3.
 	GetFileURL(fileKey string) string
+	// BucketName returns the name of the bucket the service reads and writes.
+	BucketName() string
 	// GeneratePresignedUploadURL generates a presigned URL for uploading a file to S3.
 	GeneratePresignedUploadURL(
 		ctx context.Context, userID, filename, contentType string, fileSize int64,
 	) (*PresignedUploadResult, error)
+	// CreateMultipartUpload starts a multipart upload, for files too large for one PUT, and
+	// presigns a URL for each of its parts.
+	CreateMultipartUpload(
+		ctx context.Context, userID, filename, contentType string, fileSize int64,
+	) (*MultipartUploadResult, error)
+	// CompleteMultipartUpload assembles the uploaded parts of a multipart upload.
+	CompleteMultipartUpload(ctx context.Context, fileKey, uploadID string, parts []CompletedPart) error
+	// AbortMultipartUpload discards a multipart upload and its uploaded parts.
+	AbortMultipartUpload(ctx context.Context, fileKey, uploadID string) error
 	// CreateBucket creates the S3 bucket if it doesn't exist.
 	CreateBucket(ctx context.Context) error
 	// GeneratePresignedGetURL generates a presigned URL for downloading a file from S3.
@@ -18,9 +18,21 @@ var _ S3Service = &S3ServiceMock{}
 //
 //		// make and configure a mocked S3Service
 //		mockedS3Service := &S3ServiceMock{
+//			AbortMultipartUploadFunc: func(ctx context.Context, fileKey string, uploadID string) error {
+//				panic("mock out the AbortMultipartUpload method")
+//			},
+//			BucketNameFunc: func() string {
+//				panic("mock out the BucketName method")
+//			},
+//			CompleteMultipartUploadFunc: func(ctx context.Context, fileKey string, uploadID string, parts []CompletedPart) error {
+//				panic("mock out the CompleteMultipartUpload method")
+//			},
 //			CreateBucketFunc: func(ctx context.Context) error {
 //				panic("mock out the CreateBucket method")
 //			},
+//			CreateMultipartUploadFunc: func(ctx context.Context, userID string, filename string, contentType string, fileSize int64) (*MultipartUploadResult, error) {
+//				panic("mock out the CreateMultipartUpload method")
+//			},
 //			DeleteFileFunc: func(ctx context.Context, fileKey string) error {
 //				panic("mock out the DeleteFile method")
 //			},
@@ -30,12 +42,18 @@ var _ S3Service = &S3ServiceMock{}
 //			GeneratePresignedUploadURLFunc: func(ctx context.Context, userID string, filename string, contentType string, fileSize int64) (*PresignedUploadResult, error) {
 //				panic("mock out the GeneratePresignedUploadURL method")
 //			},
+//			GetFileFunc: func(ctx context.Context, fileKey string) ([]byte, error) {
+//				panic("mock out the GetFile method")
+//			},
 //			GetFileURLFunc: func(fileKey string) string {
 //				panic("mock out the GetFileURL method")
 //			},
 //			HeadFileFunc: func(ctx context.Context, fileKey string) (interface{}, error) {
 //				panic("mock out the HeadFile method")
 //			},
+//			PutFileFunc: func(ctx context.Context, fileKey string, body []byte, contentType string) error {
+//				panic("mock out the PutFile method")
+//			},
 //		}
 //
 //		// use mockedS3Service in code that requires S3Service
@@ -43,9 +61,21 @@ var _ S3Service = &S3ServiceMock{}
 //
 //	}
 type S3ServiceMock struct {
+	// AbortMultipartUploadFunc mocks the AbortMultipartUpload method.
+	AbortMultipartUploadFunc func(ctx context.Context, fileKey string, uploadID string) error
+
+	// BucketNameFunc mocks the BucketName method.
+	BucketNameFunc func() string
+
+	// CompleteMultipartUploadFunc mocks the CompleteMultipartUpload method.
+	CompleteMultipartUploadFunc func(ctx context.Context, fileKey string, uploadID string, parts []CompletedPart) error
+
 	// CreateBucketFunc mocks the CreateBucket method.
 	CreateBucketFunc func(ctx context.Context) error
 
+	// CreateMultipartUploadFunc mocks the CreateMultipartUpload method.
+	CreateMultipartUploadFunc func(ctx context.Context, userID string, filename string, contentType string, fileSize int64) (*MultipartUploadResult, error)
+
 	// DeleteFileFunc mocks the DeleteFile method.
 	DeleteFileFunc func(ctx context.Context, fileKey string) error
 
@@ -55,19 +85,61 @@ type S3ServiceMock struct {
 	// GeneratePresignedUploadURLFunc mocks the GeneratePresignedUploadURL method.
 	GeneratePresignedUploadURLFunc func(ctx context.Context, userID string, filename string, contentType string, fileSize int64) (*PresignedUploadResult, error)
 
+	// GetFileFunc mocks the GetFile method.
+	GetFileFunc func(ctx context.Context, fileKey string) ([]byte, error)
+
 	// GetFileURLFunc mocks the GetFileURL method.
 	GetFileURLFunc func(fileKey string) string
 
 	// HeadFileFunc mocks the HeadFile method.
 	HeadFileFunc func(ctx context.Context, fileKey string) (interface{}, error)
 
+	// PutFileFunc mocks the PutFile method.
+	PutFileFunc func(ctx context.Context, fileKey string, body []byte, contentType string) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// AbortMultipartUpload holds details about calls to the AbortMultipartUpload method.
+		AbortMultipartUpload []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FileKey is the fileKey argument value.
+			FileKey string
+			// UploadID is the uploadID argument value.
+			UploadID string
+		}
+		// BucketName holds details about calls to the BucketName method.
+		BucketName []struct {
+		}
+		// CompleteMultipartUpload holds details about calls to the CompleteMultipartUpload method.
+		CompleteMultipartUpload []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FileKey is the fileKey argument value.
+			FileKey string
+			// UploadID is the uploadID argument value.
+			UploadID string
+			// Parts is the parts argument value.
+			Parts []CompletedPart
+		}
 		// CreateBucket holds details about calls to the CreateBucket method.
 		CreateBucket []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
+		// CreateMultipartUpload holds details about calls to the CreateMultipartUpload method.
+		CreateMultipartUpload []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+			// Filename is the filename argument value.
+			Filename string
+			// ContentType is the contentType argument value.
+			ContentType string
+			// FileSize is the fileSize argument value.
+			FileSize int64
+		}
 		// DeleteFile holds details about calls to the DeleteFile method.
 		DeleteFile []struct {
 			// Ctx is the ctx argument value.
@@ -99,6 +171,13 @@ type S3ServiceMock struct {
 			// FileSize is the fileSize argument value.
 			FileSize int64
 		}
+		// GetFile holds details about calls to the GetFile method.
+		GetFile []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FileKey is the fileKey argument value.
+			FileKey string
+		}
 		// GetFileURL holds details about calls to the GetFileURL method.
 		GetFileURL []struct {
 			// FileKey is the fileKey argument value.
@@ -111,13 +190,141 @@ type S3ServiceMock struct {
 			// FileKey is the fileKey argument value.
 			FileKey string
 		}
+		// PutFile holds details about calls to the PutFile method.
+		PutFile []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FileKey is the fileKey argument value.
+			FileKey string
+			// Body is the body argument value.
+			Body []byte
+			// ContentType is the contentType argument value.
+			ContentType string
+		}
 	}
+	lockAbortMultipartUpload       sync.RWMutex
+	lockBucketName                 sync.RWMutex
+	lockCompleteMultipartUpload    sync.RWMutex
 	lockCreateBucket               sync.RWMutex
+	lockCreateMultipartUpload      sync.RWMutex
 	lockDeleteFile                 sync.RWMutex
 	lockGeneratePresignedGetURL    sync.RWMutex
 	lockGeneratePresignedUploadURL sync.RWMutex
+	lockGetFile                    sync.RWMutex
 	lockGetFileURL                 sync.RWMutex
 	lockHeadFile                   sync.RWMutex
+	lockPutFile                    sync.RWMutex
+}
+
+// AbortMultipartUpload calls AbortMultipartUploadFunc.
+func (mock *S3ServiceMock) AbortMultipartUpload(ctx context.Context, fileKey string, uploadID string) error {
+	if mock.AbortMultipartUploadFunc == nil {
+		panic("S3ServiceMock.AbortMultipartUploadFunc: method is nil but S3Service.AbortMultipartUpload was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		FileKey  string
+		UploadID string
+	}{
+		Ctx:      ctx,
+		FileKey:  fileKey,
+		UploadID: uploadID,
+	}
+	mock.lockAbortMultipartUpload.Lock()
+	mock.calls.AbortMultipartUpload = append(mock.calls.AbortMultipartUpload, callInfo)
+	mock.lockAbortMultipartUpload.Unlock()
+	return mock.AbortMultipartUploadFunc(ctx, fileKey, uploadID)
+}
+
+// AbortMultipartUploadCalls gets all the calls that were made to AbortMultipartUpload.
+// Check the length with:
+//
+//	len(mockedS3Service.AbortMultipartUploadCalls())
+func (mock *S3ServiceMock) AbortMultipartUploadCalls() []struct {
+	Ctx      context.Context
+	FileKey  string
+	UploadID string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		FileKey  string
+		UploadID string
+	}
+	mock.lockAbortMultipartUpload.RLock()
+	calls = mock.calls.AbortMultipartUpload
+	mock.lockAbortMultipartUpload.RUnlock()
+	return calls
+}
+
+// BucketName calls BucketNameFunc.
+func (mock *S3ServiceMock) BucketName() string {
+	if mock.BucketNameFunc == nil {
+		panic("S3ServiceMock.BucketNameFunc: method is nil but S3Service.BucketName was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockBucketName.Lock()
+	mock.calls.BucketName = append(mock.calls.BucketName, callInfo)
+	mock.lockBucketName.Unlock()
+	return mock.BucketNameFunc()
+}
+
+// BucketNameCalls gets all the calls that were made to BucketName.
+// Check the length with:
+//
+//	len(mockedS3Service.BucketNameCalls())
+func (mock *S3ServiceMock) BucketNameCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockBucketName.RLock()
+	calls = mock.calls.BucketName
+	mock.lockBucketName.RUnlock()
+	return calls
+}
+
+// CompleteMultipartUpload calls CompleteMultipartUploadFunc.
+func (mock *S3ServiceMock) CompleteMultipartUpload(ctx context.Context, fileKey string, uploadID string, parts []CompletedPart) error {
+	if mock.CompleteMultipartUploadFunc == nil {
+		panic("S3ServiceMock.CompleteMultipartUploadFunc: method is nil but S3Service.CompleteMultipartUpload was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		FileKey  string
+		UploadID string
+		Parts    []CompletedPart
+	}{
+		Ctx:      ctx,
+		FileKey:  fileKey,
+		UploadID: uploadID,
+		Parts:    parts,
+	}
+	mock.lockCompleteMultipartUpload.Lock()
+	mock.calls.CompleteMultipartUpload = append(mock.calls.CompleteMultipartUpload, callInfo)
+	mock.lockCompleteMultipartUpload.Unlock()
+	return mock.CompleteMultipartUploadFunc(ctx, fileKey, uploadID, parts)
+}
+
+// CompleteMultipartUploadCalls gets all the calls that were made to CompleteMultipartUpload.
+// Check the length with:
+//
+//	len(mockedS3Service.CompleteMultipartUploadCalls())
+func (mock *S3ServiceMock) CompleteMultipartUploadCalls() []struct {
+	Ctx      context.Context
+	FileKey  string
+	UploadID string
+	Parts    []CompletedPart
+} {
+	var calls []struct {
+		Ctx      context.Context
+		FileKey  string
+		UploadID string
+		Parts    []CompletedPart
+	}
+	mock.lockCompleteMultipartUpload.RLock()
+	calls = mock.calls.CompleteMultipartUpload
+	mock.lockCompleteMultipartUpload.RUnlock()
+	return calls
 }
 
 // CreateBucket calls CreateBucketFunc.
@@ -152,6 +359,54 @@ func (mock *S3ServiceMock) CreateBucketCalls() []struct {
 	return calls
 }
 
+// CreateMultipartUpload calls CreateMultipartUploadFunc.
+func (mock *S3ServiceMock) CreateMultipartUpload(ctx context.Context, userID string, filename string, contentType string, fileSize int64) (*MultipartUploadResult, error) {
+	if mock.CreateMultipartUploadFunc == nil {
+		panic("S3ServiceMock.CreateMultipartUploadFunc: method is nil but S3Service.CreateMultipartUpload was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		UserID      string
+		Filename    string
+		ContentType string
+		FileSize    int64
+	}{
+		Ctx:         ctx,
+		UserID:      userID,
+		Filename:    filename,
+		ContentType: contentType,
+		FileSize:    fileSize,
+	}
+	mock.lockCreateMultipartUpload.Lock()
+	mock.calls.CreateMultipartUpload = append(mock.calls.CreateMultipartUpload, callInfo)
+	mock.lockCreateMultipartUpload.Unlock()
+	return mock.CreateMultipartUploadFunc(ctx, userID, filename, contentType, fileSize)
+}
+
+// CreateMultipartUploadCalls gets all the calls that were made to CreateMultipartUpload.
+// Check the length with:
+//
+//	len(mockedS3Service.CreateMultipartUploadCalls())
+func (mock *S3ServiceMock) CreateMultipartUploadCalls() []struct {
+	Ctx         context.Context
+	UserID      string
+	Filename    string
+	ContentType string
+	FileSize    int64
+} {
+	var calls []struct {
+		Ctx         context.Context
+		UserID      string
+		Filename    string
+		ContentType string
+		FileSize    int64
+	}
+	mock.lockCreateMultipartUpload.RLock()
+	calls = mock.calls.CreateMultipartUpload
+	mock.lockCreateMultipartUpload.RUnlock()
+	return calls
+}
+
 // DeleteFile calls DeleteFileFunc.
 func (mock *S3ServiceMock) DeleteFile(ctx context.Context, fileKey string) error {
 	if mock.DeleteFileFunc == nil {
@@ -280,6 +535,42 @@ func (mock *S3ServiceMock) GeneratePresignedUploadURLCalls() []struct {
 	return calls
 }
 
+// GetFile calls GetFileFunc.
+func (mock *S3ServiceMock) GetFile(ctx context.Context, fileKey string) ([]byte, error) {
+	if mock.GetFileFunc == nil {
+		panic("S3ServiceMock.GetFileFunc: method is nil but S3Service.GetFile was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		FileKey string
+	}{
+		Ctx:     ctx,
+		FileKey: fileKey,
+	}
+	mock.lockGetFile.Lock()
+	mock.calls.GetFile = append(mock.calls.GetFile, callInfo)
+	mock.lockGetFile.Unlock()
+	return mock.GetFileFunc(ctx, fileKey)
+}
+
+// GetFileCalls gets all the calls that were made to GetFile.
+// Check the length with:
+//
+//	len(mockedS3Service.GetFileCalls())
+func (mock *S3ServiceMock) GetFileCalls() []struct {
+	Ctx     context.Context
+	FileKey string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		FileKey string
+	}
+	mock.lockGetFile.RLock()
+	calls = mock.calls.GetFile
+	mock.lockGetFile.RUnlock()
+	return calls
+}
+
 // GetFileURL calls GetFileURLFunc.
 func (mock *S3ServiceMock) GetFileURL(fileKey string) string {
 	if mock.GetFileURLFunc == nil {
@@ -347,3 +638,47 @@ func (mock *S3ServiceMock) HeadFileCalls() []struct {
 	mock.lockHeadFile.RUnlock()
 	return calls
 }
+
+// PutFile calls PutFileFunc.
+func (mock *S3ServiceMock) PutFile(ctx context.Context, fileKey string, body []byte, contentType string) error {
+	if mock.PutFileFunc == nil {
+		panic("S3ServiceMock.PutFileFunc: method is nil but S3Service.PutFile was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		FileKey     string
+		Body        []byte
+		ContentType string
+	}{
+		Ctx:         ctx,
+		FileKey:     fileKey,
+		Body:        body,
+		ContentType: contentType,
+	}
+	mock.lockPutFile.Lock()
+	mock.calls.PutFile = append(mock.calls.PutFile, callInfo)
+	mock.lockPutFile.Unlock()
+	return mock.PutFileFunc(ctx, fileKey, body, contentType)
+}
+
+// PutFileCalls gets all the calls that were made to PutFile.
+// Check the length with:
+//
+//	len(mockedS3Service.PutFileCalls())
+func (mock *S3ServiceMock) PutFileCalls() []struct {
+	Ctx         context.Context
+	FileKey     string
+	Body        []byte
+	ContentType string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		FileKey     string
+		Body        []byte
+		ContentType string
+	}
+	mock.lockPutFile.RLock()
+	calls = mock.calls.PutFile
+	mock.lockPutFile.RUnlock()
+	return calls
+}
@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	// SandboxSchema is the Postgres schema sandbox requests act on. It mirrors public and
+	// is reset nightly by the worker serving the sandbox queue.
+	SandboxSchema = "sandbox"
+	// SandboxKeyPrefix starts the S3 key of every object sandbox requests store, so the
+	// nightly reset can delete them all.
+	SandboxKeyPrefix = "sandbox/"
+)
+
+type sandboxKey struct{}
+
+// WithSandbox returns a copy of ctx whose work belongs to the sandbox tenant.
+func WithSandbox(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sandboxKey{}, true)
+}
+
+// SandboxFrom reports whether ctx belongs to the sandbox tenant.
+func SandboxFrom(ctx context.Context) bool {
+	sandbox, _ := ctx.Value(sandboxKey{}).(bool)
+	return sandbox
+}
+
+// UploadPrefix returns the key prefix of userID's uploads: uploads/<userID>/, under
+// SandboxKeyPrefix when ctx belongs to the sandbox tenant.
+func UploadPrefix(ctx context.Context, userID string) string {
+	prefix := "uploads/" + userID + "/"
+	if SandboxFrom(ctx) {
+		return SandboxKeyPrefix + prefix
+	}
+	return prefix
+}
+
+// TenantDatabase sends statements whose context belongs to the sandbox tenant to the
+// sandbox database and all others to production.
+type TenantDatabase struct {
+	production Database
+	sandbox    Database
+}
+
+// Ensure TenantDatabase implements Database.
+var _ Database = (*TenantDatabase)(nil)
+
+// NewTenantDatabase creates a TenantDatabase.
+func NewTenantDatabase(production, sandbox Database) *TenantDatabase {
+	return &TenantDatabase{production: production, sandbox: sandbox}
+}
+
+func (t *TenantDatabase) of(ctx context.Context) Database {
+	if SandboxFrom(ctx) {
+		return t.sandbox
+	}
+	return t.production
+}
+
+// Close closes both databases.
+func (t *TenantDatabase) Close() {
+	t.production.Close()
+	t.sandbox.Close()
+}
+
+// Pool returns the production pool; statements run on it never reach the sandbox, so
+// request paths use QueryRow, Query and Exec, which follow the context's tenant.
+func (t *TenantDatabase) Pool() PgxPool {
+	return t.production.Pool()
+}
+
+// QueryRow runs the query on the context's tenant.
+func (t *TenantDatabase) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return t.of(ctx).QueryRow(ctx, sql, args...)
+}
+
+// Query runs the query on the context's tenant.
+func (t *TenantDatabase) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return t.of(ctx).Query(ctx, sql, args...)
+}
+
+// Exec runs the command on the context's tenant.
+func (t *TenantDatabase) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return t.of(ctx).Exec(ctx, sql, args...)
+}
+
+// CopyFrom bulk-loads rows into the context's tenant.
+func (t *TenantDatabase) CopyFrom(
+	ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource,
+) (int64, error) {
+	return t.of(ctx).CopyFrom(ctx, tableName, columnNames, rowSrc)
+}
+
+// WithTx runs fn in a transaction on the context's tenant.
+func (t *TenantDatabase) WithTx(ctx context.Context, fn func(tx Database) error) error {
+	return t.of(ctx).WithTx(ctx, fn)
+}
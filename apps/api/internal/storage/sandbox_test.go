@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantDatabase(t *testing.T) {
+	testCases := []struct {
+		name          string
+		ctx           context.Context
+		expectSandbox bool
+	}{
+		{name: "success: production request", ctx: context.Background()},
+		{name: "success: sandbox request", ctx: WithSandbox(context.Background()), expectSandbox: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			newDB := func() *DatabaseMock {
+				return &DatabaseMock{
+					ExecFunc: func(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+						return pgconn.NewCommandTag("UPDATE 1"), nil
+					},
+					WithTxFunc: func(ctx context.Context, fn func(tx Database) error) error {
+						return nil
+					},
+				}
+			}
+			production, sandbox := newDB(), newDB()
+			db := NewTenantDatabase(production, sandbox)
+
+			_, err := db.Exec(tc.ctx, "UPDATE projects SET name = $1", "Kitchen")
+			require.NoError(t, err)
+			require.NoError(t, db.WithTx(tc.ctx, func(tx Database) error { return nil }))
+
+			used, unused := production, sandbox
+			if tc.expectSandbox {
+				used, unused = sandbox, production
+			}
+			assert.Len(t, used.ExecCalls(), 1)
+			assert.Len(t, used.WithTxCalls(), 1)
+			assert.Empty(t, unused.ExecCalls())
+			assert.Empty(t, unused.WithTxCalls())
+		})
+	}
+}
+
+func TestUploadPrefix(t *testing.T) {
+	assert.Equal(t, "uploads/u-1/", UploadPrefix(context.Background(), "u-1"))
+	assert.Equal(t, "sandbox/uploads/u-1/", UploadPrefix(WithSandbox(context.Background()), "u-1"))
+}
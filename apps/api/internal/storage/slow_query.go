@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// SlowQueryTracer logs every statement that takes at least a threshold to complete. It is
+// installed as one of the pool's pgx.QueryTracers, so it also sees queries issued through Pool().
+// Only the Go types of the bound parameters are logged, never their values, since they
+// may carry user data.
+type SlowQueryTracer struct {
+	threshold time.Duration
+	log       logging.Logger
+	now       func() time.Time
+}
+
+// Ensure SlowQueryTracer implements pgx.QueryTracer.
+var _ pgx.QueryTracer = (*SlowQueryTracer)(nil)
+
+// NewSlowQueryTracer creates a SlowQueryTracer that logs statements slower than threshold to log.
+func NewSlowQueryTracer(threshold time.Duration, log logging.Logger) *SlowQueryTracer {
+	return &SlowQueryTracer{threshold: threshold, log: log, now: time.Now}
+}
+
+type slowQueryKey struct{}
+
+type slowQueryStart struct {
+	at   time.Time
+	sql  string
+	args []any
+}
+
+// TraceQueryStart records when the statement started.
+func (t *SlowQueryTracer) TraceQueryStart(
+	ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData,
+) context.Context {
+	return context.WithValue(ctx, slowQueryKey{}, slowQueryStart{at: t.now(), sql: data.SQL, args: data.Args})
+}
+
+// TraceQueryEnd logs the statement when it ran for at least the threshold. For queries that
+// return rows it is called once the rows are closed, so the time spent reading them counts.
+func (t *SlowQueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	start, ok := ctx.Value(slowQueryKey{}).(slowQueryStart)
+	if !ok {
+		return
+	}
+	elapsed := t.now().Sub(start.at)
+	if elapsed < t.threshold {
+		return
+	}
+
+	kv := []any{
+		"query", QueryName(start.sql),
+		"duration_ms", elapsed.Milliseconds(),
+		"query_class", string(QueryClassFrom(ctx)),
+		"arg_types", argTypes(start.args),
+		"rows", data.CommandTag.RowsAffected(),
+		"sql", compactSQL(start.sql),
+	}
+	if data.Err != nil {
+		kv = append(kv, "error", data.Err.Error())
+	}
+	t.log.Warn(ctx, "slow query", kv...)
+}
+
+// QueryName returns the sqlc name of a statement, taken from its leading "-- name: X :kind"
+// comment, or "unnamed" for hand-written SQL.
+func QueryName(sql string) string {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(sql), "-- name:")
+	if !ok {
+		return "unnamed"
+	}
+	line, _, _ := strings.Cut(rest, "\n")
+	if fields := strings.Fields(line); len(fields) > 0 {
+		return fields[0]
+	}
+	return "unnamed"
+}
+
+// argTypes describes the bound parameters by type only.
+func argTypes(args []any) []string {
+	types := make([]string, len(args))
+	for i, arg := range args {
+		if arg == nil {
+			types[i] = "nil"
+			continue
+		}
+		types[i] = fmt.Sprintf("%T", arg)
+	}
+	return types
+}
+
+// compactSQL drops the sqlc name comment and collapses whitespace so a statement fits on
+// one log line.
+func compactSQL(sql string) string {
+	sql = strings.TrimSpace(sql)
+	if strings.HasPrefix(sql, "-- name:") {
+		_, sql, _ = strings.Cut(sql, "\n")
+	}
+	return strings.Join(strings.Fields(sql), " ")
+}
@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+func TestSlowQueryTracer(t *testing.T) {
+	const sql = "-- name: GetUserByID :one\nSELECT id, email\nFROM users WHERE id = $1"
+
+	testCases := []struct {
+		name     string
+		elapsed  time.Duration
+		err      error
+		wantLog  bool
+		validate func(t *testing.T, kv map[string]any)
+	}{
+		{
+			name:    "success: fast statement is not logged",
+			elapsed: 10 * time.Millisecond,
+		},
+		{
+			name:    "success: slow statement logs name and argument types only",
+			elapsed: 750 * time.Millisecond,
+			wantLog: true,
+			validate: func(t *testing.T, kv map[string]any) {
+				assert.Equal(t, "GetUserByID", kv["query"])
+				assert.Equal(t, int64(750), kv["duration_ms"])
+				assert.Equal(t, []string{"string", "nil"}, kv["arg_types"])
+				assert.Equal(t, "SELECT id, email FROM users WHERE id = $1", kv["sql"])
+				assert.Equal(t, int64(1), kv["rows"])
+				assert.NotContains(t, kv, "error")
+			},
+		},
+		{
+			name:    "success: failed slow statement includes the error",
+			elapsed: time.Second,
+			err:     errors.New("canceling statement due to statement timeout"),
+			wantLog: true,
+			validate: func(t *testing.T, kv map[string]any) {
+				assert.Equal(t, "canceling statement due to statement timeout", kv["error"])
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			log := &logging.LoggerMock{WarnFunc: func(ctx context.Context, msg string, keysAndValues ...any) {}}
+			tracer := NewSlowQueryTracer(500*time.Millisecond, log)
+			now := time.Now()
+			tracer.now = func() time.Time { return now }
+
+			ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{
+				SQL: sql, Args: []any{"secret@example.com", nil},
+			})
+			now = now.Add(tc.elapsed)
+			tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{
+				CommandTag: pgconn.NewCommandTag("SELECT 1"), Err: tc.err,
+			})
+
+			if !tc.wantLog {
+				assert.Empty(t, log.WarnCalls())
+				return
+			}
+			require.Len(t, log.WarnCalls(), 1)
+			call := log.WarnCalls()[0]
+			assert.Equal(t, "slow query", call.Msg)
+			kv := map[string]any{}
+			for i := 0; i+1 < len(call.KeysAndValues); i += 2 {
+				kv[call.KeysAndValues[i].(string)] = call.KeysAndValues[i+1]
+			}
+			for _, v := range kv {
+				assert.NotEqual(t, "secret@example.com", v, "parameter values must not be logged")
+			}
+			tc.validate(t, kv)
+		})
+	}
+}
+
+func TestQueryName(t *testing.T) {
+	assert.Equal(t, "ListProjects", QueryName("-- name: ListProjects :many\nSELECT 1"))
+	assert.Equal(t, "ListProjects", QueryName("\n  -- name: ListProjects :many\nSELECT 1"))
+	assert.Equal(t, "unnamed", QueryName("SELECT count(*) FROM users"))
+	assert.Equal(t, "unnamed", QueryName("-- name:\nSELECT 1"))
+}
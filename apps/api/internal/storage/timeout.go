@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// QueryClass groups statements that share a statement timeout.
+type QueryClass string
+
+const (
+	// QueryClassFast covers ordinary request-path reads and writes. It is the default.
+	QueryClassFast QueryClass = "fast"
+	// QueryClassScan covers deliberate long-running work such as reconciliation scans,
+	// backup verification and key rotation.
+	QueryClassScan QueryClass = "scan"
+)
+
+type queryClassKey struct{}
+
+// WithQueryClass returns a context whose statements run with the timeout of class.
+func WithQueryClass(ctx context.Context, class QueryClass) context.Context {
+	return context.WithValue(ctx, queryClassKey{}, class)
+}
+
+// QueryClassFrom returns the query class set on ctx, or QueryClassFast.
+func QueryClassFrom(ctx context.Context) QueryClass {
+	if class, ok := ctx.Value(queryClassKey{}).(QueryClass); ok {
+		return class
+	}
+	return QueryClassFast
+}
+
+// Timeouts bounds how long one statement of each query class may run. A statement is
+// also canceled, on the server too, as soon as its context is, so a client that
+// disconnects does not leave its query holding a connection. Zero disables a bound.
+type Timeouts struct {
+	Fast time.Duration
+	Scan time.Duration
+}
+
+// For returns the timeout for class.
+func (t Timeouts) For(class QueryClass) time.Duration {
+	if class == QueryClassScan {
+		return t.Scan
+	}
+	return t.Fast
+}
+
+// longest returns the largest timeout, which the server enforces as a backstop for
+// statements whose context never expires.
+func (t Timeouts) longest() time.Duration {
+	return max(t.Fast, t.Scan)
+}
+
+// statementContext derives the context one statement runs with. The returned cancel must be
+// called once the statement's results have been read.
+func (t Timeouts) statementContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := t.For(QueryClassFrom(ctx))
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// timeoutRow releases a statement context once the row has been scanned.
+type timeoutRow struct {
+	pgx.Row
+	cancel context.CancelFunc
+}
+
+func (r timeoutRow) Scan(dest ...any) error {
+	defer r.cancel()
+	return r.Row.Scan(dest...)
+}
+
+// timeoutRows releases a statement context once the rows are exhausted or closed.
+type timeoutRows struct {
+	pgx.Rows
+	cancel context.CancelFunc
+}
+
+func (r timeoutRows) Next() bool {
+	if r.Rows.Next() {
+		return true
+	}
+	r.cancel()
+	return false
+}
+
+func (r timeoutRows) Close() {
+	r.Rows.Close()
+	r.cancel()
+}
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryClassFrom(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, QueryClassFast, QueryClassFrom(ctx))
+	assert.Equal(t, QueryClassScan, QueryClassFrom(WithQueryClass(ctx, QueryClassScan)))
+}
+
+func TestTimeouts_StatementContext(t *testing.T) {
+	timeouts := Timeouts{Fast: time.Second, Scan: time.Hour}
+
+	testCases := []struct {
+		name         string
+		timeouts     Timeouts
+		ctx          context.Context
+		wantDeadline bool
+		wantMax      time.Duration
+	}{
+		{
+			name:         "success: fast class by default",
+			timeouts:     timeouts,
+			ctx:          context.Background(),
+			wantDeadline: true,
+			wantMax:      time.Second,
+		},
+		{
+			name:         "success: scan class",
+			timeouts:     timeouts,
+			ctx:          WithQueryClass(context.Background(), QueryClassScan),
+			wantDeadline: true,
+			wantMax:      time.Hour,
+		},
+		{
+			name:     "success: zero timeout leaves the context unbounded",
+			timeouts: Timeouts{Scan: time.Hour},
+			ctx:      context.Background(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := tc.timeouts.statementContext(tc.ctx)
+			defer cancel()
+
+			deadline, ok := ctx.Deadline()
+			require.Equal(t, tc.wantDeadline, ok)
+			if ok {
+				remaining := time.Until(deadline)
+				assert.LessOrEqual(t, remaining, tc.wantMax)
+				assert.Greater(t, remaining, tc.wantMax/2)
+			}
+		})
+	}
+}
+
+func TestTimeouts_StatementContext_ParentCanceled(t *testing.T) {
+	parent, cancelParent := context.WithCancel(context.Background())
+	ctx, cancel := Timeouts{Fast: time.Hour}.statementContext(parent)
+	defer cancel()
+
+	cancelParent()
+	<-ctx.Done()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
+// stubRows yields n rows.
+type stubRows struct {
+	pgx.Rows
+	n      int
+	closed bool
+}
+
+func (r *stubRows) Next() bool {
+	r.n--
+	return r.n >= 0
+}
+
+func (r *stubRows) Close() { r.closed = true }
+
+func (r *stubRows) CommandTag() pgconn.CommandTag { return pgconn.NewCommandTag("SELECT 0") }
+
+func TestTimeoutRows(t *testing.T) {
+	t.Run("success: cancels once exhausted", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		rows := timeoutRows{Rows: &stubRows{n: 2}, cancel: cancel}
+
+		assert.True(t, rows.Next())
+		assert.True(t, rows.Next())
+		assert.NoError(t, ctx.Err())
+		assert.False(t, rows.Next())
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	})
+
+	t.Run("success: cancels on early close", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		stub := &stubRows{n: 5}
+		rows := timeoutRows{Rows: stub, cancel: cancel}
+
+		assert.True(t, rows.Next())
+		rows.Close()
+		assert.True(t, stub.closed)
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	})
+}
@@ -0,0 +1,140 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// txDatabase is the Database handed to WithTx callbacks; every statement runs in tx.
+type txDatabase struct {
+	tx       pgx.Tx
+	pool     PgxPool
+	tracer   trace.Tracer
+	timeouts Timeouts
+}
+
+// Ensure txDatabase implements Database.
+var _ Database = (*txDatabase)(nil)
+
+// Close is a no-op; the transaction is ended by the WithTx call that created it.
+func (t *txDatabase) Close() {}
+
+// Pool returns the parent pool. Statements run on it are outside the transaction.
+func (t *txDatabase) Pool() PgxPool {
+	return t.pool
+}
+
+// QueryRow executes a query in the transaction with tracing
+func (t *txDatabase) QueryRow(ctx context.Context, sql string, arguments ...any) pgx.Row {
+	ctx, span := t.start(ctx, "db.query_row", sql, len(arguments))
+	defer span.End()
+
+	ctx, cancel := t.timeouts.statementContext(ctx)
+	return timeoutRow{Row: t.tx.QueryRow(ctx, sql, arguments...), cancel: cancel}
+}
+
+// Query executes a query in the transaction with tracing
+func (t *txDatabase) Query(ctx context.Context, sql string, arguments ...any) (pgx.Rows, error) {
+	ctx, span := t.start(ctx, "db.query", sql, len(arguments))
+	defer span.End()
+
+	ctx, cancel := t.timeouts.statementContext(ctx)
+	rows, err := t.tx.Query(ctx, sql, arguments...)
+	if err != nil {
+		cancel()
+		span.RecordError(err)
+		return rows, err
+	}
+
+	return timeoutRows{Rows: rows, cancel: cancel}, nil
+}
+
+// Exec executes a command in the transaction with tracing
+func (t *txDatabase) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	ctx, span := t.start(ctx, "db.exec", sql, len(arguments))
+	defer span.End()
+
+	ctx, cancel := t.timeouts.statementContext(ctx)
+	defer cancel()
+
+	tag, err := t.tx.Exec(ctx, sql, arguments...)
+	if err != nil {
+		span.RecordError(err)
+	}
+
+	return tag, err
+}
+
+// CopyFrom bulk-loads rows with the COPY protocol in the transaction with tracing
+func (t *txDatabase) CopyFrom(
+	ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource,
+) (int64, error) {
+	ctx, span := t.tracer.Start(ctx, "db.copy_from")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.table", tableName.Sanitize()),
+		attribute.StringSlice("db.columns", columnNames),
+		attribute.Bool("db.transaction", true),
+	)
+
+	ctx, cancel := t.timeouts.statementContext(ctx)
+	defer cancel()
+
+	n, err := t.tx.CopyFrom(ctx, tableName, columnNames, rowSrc)
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.SetAttributes(attribute.Int64("db.rows_affected", n))
+
+	return n, err
+}
+
+// WithTx runs fn in a savepoint nested in the current transaction.
+func (t *txDatabase) WithTx(ctx context.Context, fn func(tx Database) error) error {
+	sp, err := t.tx.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create savepoint: %w", err)
+	}
+	return runTx(ctx, sp, &txDatabase{tx: sp, pool: t.pool, tracer: t.tracer, timeouts: t.timeouts}, fn)
+}
+
+func (t *txDatabase) start(ctx context.Context, name, sql string, argCount int) (context.Context, trace.Span) {
+	ctx, span := t.tracer.Start(ctx, name)
+	span.SetAttributes(
+		attribute.String("db.statement", sql),
+		attribute.Int("db.args.count", argCount),
+		attribute.Bool("db.transaction", true),
+	)
+	return ctx, span
+}
+
+// runTx calls fn and then commits tx, or rolls it back if fn fails or panics.
+func runTx(ctx context.Context, tx pgx.Tx, txdb Database, fn func(tx Database) error) error {
+	// Roll back even if ctx was canceled, so the connection is returned to the pool cleanly.
+	rollbackCtx := context.WithoutCancel(ctx)
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(rollbackCtx)
+			panic(p)
+		}
+	}()
+
+	if err := fn(txdb); err != nil {
+		if rbErr := tx.Rollback(rollbackCtx); rbErr != nil {
+			return errors.Join(err, fmt.Errorf("failed to roll back transaction: %w", rbErr))
+		}
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
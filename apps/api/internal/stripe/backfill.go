@@ -0,0 +1,32 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// Backfill stores an invoice, and the subscription it bills, whose webhooks were skipped
+// because the customer was not yet linked to a user. Call it once the customer is linked.
+// The subscription is fetched with client; a nil client stores only the invoice. opts apply
+// as they do to NewDefaultHandler.
+func Backfill(ctx context.Context, db storage.Database, client Client, inv *Invoice, opts ...HandlerOption) error {
+	h := &DefaultHandler{db: db, client: client}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	if _, err := h.persistInvoice(ctx, inv, "backfill"); err != nil {
+		return err
+	}
+	if inv.SubscriptionID == "" || client == nil {
+		return nil
+	}
+
+	sub, err := client.GetSubscription(ctx, inv.SubscriptionID)
+	if err != nil {
+		return fmt.Errorf("fetch subscription %s: %w", inv.SubscriptionID, err)
+	}
+	return h.persistSubscription(ctx, sub, sub.Status, "backfill")
+}
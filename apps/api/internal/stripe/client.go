@@ -0,0 +1,82 @@
+package stripe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out client_mock.go . Client
+
+// Client calls the Stripe API.
+type Client interface {
+	// CreateCheckoutSession starts a hosted checkout; send the customer to the session's URL.
+	CreateCheckoutSession(ctx context.Context, params CheckoutSessionParams) (*CheckoutSession, error)
+	// CreatePortalSession opens the customer portal for an existing customer.
+	CreatePortalSession(ctx context.Context, params PortalSessionParams) (*PortalSession, error)
+	// GetSubscription fetches a subscription by its Stripe ID.
+	GetSubscription(ctx context.Context, id string) (*Subscription, error)
+	// FindInvoiceByNumber looks up an invoice by the number printed on it. Returns
+	// ErrNotFound when no invoice has that number.
+	FindInvoiceByNumber(ctx context.Context, number string) (*Invoice, error)
+}
+
+// ErrNotFound is returned when a Stripe lookup matches nothing.
+var ErrNotFound = errors.New("stripe: not found")
+
+// CheckoutSessionParams describes a checkout session for one price.
+type CheckoutSessionParams struct {
+	// PriceID is the Stripe price to check out, quantity one. Unused in setup mode.
+	PriceID string
+	// Mode is "subscription" (the default), "payment", or "setup" for a zero-amount checkout
+	// that only collects a payment method.
+	Mode string
+	// Currency is required in setup mode, e.g. "usd".
+	Currency string
+	// CustomerID reuses an existing Stripe customer. Empty lets Stripe create one.
+	CustomerID string
+	// CustomerEmail prefills the email of a customer Stripe creates.
+	CustomerEmail string
+	// ClientReferenceID is echoed back on checkout.session.completed; we use the Auth0 sub
+	// so the webhook can link the customer to the user.
+	ClientReferenceID string
+	SuccessURL        string
+	CancelURL         string
+	// Metadata is echoed back on the session's webhooks, e.g. to tell one-off purchases apart.
+	Metadata map[string]string
+}
+
+// Checkout metadata telling one-off purchases apart from plan checkouts.
+const (
+	// MetadataPurpose is the metadata key naming what a one-time checkout buys.
+	MetadataPurpose = "purpose"
+	// PurposeExportCredit marks a checkout buying one watermark-free project export.
+	PurposeExportCredit = "export_credit"
+)
+
+// PortalSessionParams describes a customer portal session.
+type PortalSessionParams struct {
+	CustomerID string
+	// ReturnURL is where the portal's back link leads.
+	ReturnURL string
+}
+
+// APIError is an error response from the Stripe API.
+type APIError struct {
+	StatusCode int
+	Type       string `json:"type"`
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	detail := strconv.Itoa(e.StatusCode)
+	if e.Type != "" {
+		detail += " " + e.Type
+	}
+	if e.Code != "" {
+		detail += "/" + e.Code
+	}
+	return fmt.Sprintf("stripe: %s (%s)", e.Message, detail)
+}
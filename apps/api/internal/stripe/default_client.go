@@ -0,0 +1,172 @@
+package stripe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/real-staging-ai/api/internal/httpclient"
+)
+
+// defaultBaseURL is the Stripe API endpoint.
+const defaultBaseURL = "https://api.stripe.com"
+
+// maxResponseBytes bounds how much of a Stripe response is read.
+const maxResponseBytes = 1 << 20
+
+// DefaultClient calls the Stripe REST API over the stripe httpclient destination, which
+// times out, pools connections and retries rate-limited or unavailable responses.
+type DefaultClient struct {
+	httpClient *http.Client
+	baseURL    string
+	secretKey  string
+}
+
+// NewDefaultClient returns a client authenticated with secretKey.
+func NewDefaultClient(secretKey string) *DefaultClient {
+	return &DefaultClient{
+		httpClient: httpclient.New(httpclient.DestinationStripe),
+		baseURL:    defaultBaseURL,
+		secretKey:  secretKey,
+	}
+}
+
+// CreateCheckoutSession implements Client.
+func (c *DefaultClient) CreateCheckoutSession(
+	ctx context.Context, params CheckoutSessionParams,
+) (*CheckoutSession, error) {
+	mode := params.Mode
+	if mode == "" {
+		mode = "subscription"
+	}
+	form := url.Values{"mode": {mode}}
+	if mode == "setup" {
+		if params.Currency == "" {
+			return nil, fmt.Errorf("currency is required in setup mode")
+		}
+		form.Set("currency", params.Currency)
+	} else {
+		if params.PriceID == "" {
+			return nil, fmt.Errorf("price id is required")
+		}
+		form.Set("line_items[0][price]", params.PriceID)
+		form.Set("line_items[0][quantity]", "1")
+	}
+	setIfNotEmpty(form, "customer", params.CustomerID)
+	setIfNotEmpty(form, "customer_email", params.CustomerEmail)
+	setIfNotEmpty(form, "client_reference_id", params.ClientReferenceID)
+	setIfNotEmpty(form, "success_url", params.SuccessURL)
+	setIfNotEmpty(form, "cancel_url", params.CancelURL)
+	for k, v := range params.Metadata {
+		form.Set("metadata["+k+"]", v)
+	}
+
+	var session CheckoutSession
+	if err := c.do(ctx, http.MethodPost, "/v1/checkout/sessions", form, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// CreatePortalSession implements Client.
+func (c *DefaultClient) CreatePortalSession(ctx context.Context, params PortalSessionParams) (*PortalSession, error) {
+	if params.CustomerID == "" {
+		return nil, fmt.Errorf("customer id is required")
+	}
+	form := url.Values{"customer": {params.CustomerID}}
+	setIfNotEmpty(form, "return_url", params.ReturnURL)
+
+	var session PortalSession
+	if err := c.do(ctx, http.MethodPost, "/v1/billing_portal/sessions", form, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// GetSubscription implements Client.
+func (c *DefaultClient) GetSubscription(ctx context.Context, id string) (*Subscription, error) {
+	if id == "" {
+		return nil, fmt.Errorf("subscription id is required")
+	}
+	var sub Subscription
+	if err := c.do(ctx, http.MethodGet, "/v1/subscriptions/"+url.PathEscape(id), nil, &sub); err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+// FindInvoiceByNumber implements Client.
+func (c *DefaultClient) FindInvoiceByNumber(ctx context.Context, number string) (*Invoice, error) {
+	if number == "" || strings.ContainsAny(number, `'"\`) {
+		return nil, fmt.Errorf("invalid invoice number")
+	}
+	query := url.Values{"query": {"number:'" + number + "'"}, "limit": {"1"}}
+
+	var result struct {
+		Data []Invoice `json:"data"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/v1/invoices/search?"+query.Encode(), nil, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Data) == 0 {
+		return nil, ErrNotFound
+	}
+	return &result.Data[0], nil
+}
+
+// do sends a request to path and decodes the response into out. POSTs are form-encoded,
+// as the Stripe API expects, and carry an Idempotency-Key so they are safe to retry.
+func (c *DefaultClient) do(ctx context.Context, method, path string, form url.Values, out any) error {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("stripe: build request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.secretKey)
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Idempotency-Key", uuid.NewString())
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("stripe: %s %s: %w", method, path, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return fmt.Errorf("stripe: read response: %w", err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		var envelope struct {
+			Error *APIError `json:"error"`
+		}
+		envelope.Error = apiErr
+		if json.Unmarshal(data, &envelope) != nil || apiErr.Message == "" {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+		return apiErr
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("stripe: decode response: %w", err)
+	}
+	return nil
+}
+
+// setIfNotEmpty sets form[key] to value unless value is empty.
+func setIfNotEmpty(form url.Values, key, value string) {
+	if value != "" {
+		form.Set(key, value)
+	}
+}
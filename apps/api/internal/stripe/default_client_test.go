@@ -0,0 +1,278 @@
+package stripe
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *DefaultClient {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	c := NewDefaultClient("sk_test_123")
+	c.baseURL = srv.URL
+	return c
+}
+
+func TestDefaultClient_CreateCheckoutSession(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   CheckoutSessionParams
+		status   int
+		response string
+		wantForm url.Values
+		want     *CheckoutSession
+		wantErr  string
+	}{
+		{
+			name: "success: subscription checkout",
+			params: CheckoutSessionParams{
+				PriceID:           "price_1",
+				CustomerID:        "cus_1",
+				ClientReferenceID: "auth0|u1",
+				SuccessURL:        "https://app/ok",
+				CancelURL:         "https://app/cancel",
+			},
+			status:   http.StatusOK,
+			response: `{"id":"cs_1","url":"https://checkout.stripe.com/c/cs_1","mode":"subscription"}`,
+			wantForm: url.Values{
+				"mode":                    {"subscription"},
+				"line_items[0][price]":    {"price_1"},
+				"line_items[0][quantity]": {"1"},
+				"customer":                {"cus_1"},
+				"client_reference_id":     {"auth0|u1"},
+				"success_url":             {"https://app/ok"},
+				"cancel_url":              {"https://app/cancel"},
+			},
+			want: &CheckoutSession{ID: "cs_1", URL: "https://checkout.stripe.com/c/cs_1", Mode: "subscription"},
+		},
+		{
+			name:     "fail: stripe error envelope",
+			params:   CheckoutSessionParams{PriceID: "price_missing", Mode: "payment"},
+			status:   http.StatusBadRequest,
+			response: `{"error":{"type":"invalid_request_error","code":"resource_missing","message":"No such price"}}`,
+			wantForm: url.Values{
+				"mode":                    {"payment"},
+				"line_items[0][price]":    {"price_missing"},
+				"line_items[0][quantity]": {"1"},
+			},
+			wantErr: "stripe: No such price (400 invalid_request_error/resource_missing)",
+		},
+		{
+			name: "success: zero-amount setup checkout",
+			params: CheckoutSessionParams{
+				Mode:              "setup",
+				Currency:          "usd",
+				CustomerEmail:     "a@example.com",
+				ClientReferenceID: "auth0|u1",
+			},
+			status:   http.StatusOK,
+			response: `{"id":"cs_2","url":"https://checkout.stripe.com/c/cs_2","mode":"setup"}`,
+			wantForm: url.Values{
+				"mode":                {"setup"},
+				"currency":            {"usd"},
+				"customer_email":      {"a@example.com"},
+				"client_reference_id": {"auth0|u1"},
+			},
+			want: &CheckoutSession{ID: "cs_2", URL: "https://checkout.stripe.com/c/cs_2", Mode: "setup"},
+		},
+		{
+			name: "success: one-time payment checkout with metadata",
+			params: CheckoutSessionParams{
+				Mode:              "payment",
+				PriceID:           "price_export",
+				ClientReferenceID: "auth0|u1",
+				Metadata:          map[string]string{"purpose": "export_credit"},
+			},
+			status:   http.StatusOK,
+			response: `{"id":"cs_3","url":"https://checkout.stripe.com/c/cs_3","mode":"payment","metadata":{"purpose":"export_credit"}}`,
+			wantForm: url.Values{
+				"mode":                    {"payment"},
+				"line_items[0][price]":    {"price_export"},
+				"line_items[0][quantity]": {"1"},
+				"client_reference_id":     {"auth0|u1"},
+				"metadata[purpose]":       {"export_credit"},
+			},
+			want: &CheckoutSession{
+				ID: "cs_3", URL: "https://checkout.stripe.com/c/cs_3", Mode: "payment",
+				Metadata: map[string]string{"purpose": "export_credit"},
+			},
+		},
+		{
+			name:    "fail: missing price",
+			wantErr: "price id is required",
+		},
+		{
+			name:    "fail: setup without currency",
+			params:  CheckoutSessionParams{Mode: "setup"},
+			wantErr: "currency is required in setup mode",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/v1/checkout/sessions", r.URL.Path)
+				assert.Equal(t, "Bearer sk_test_123", r.Header.Get("Authorization"))
+				assert.NotEmpty(t, r.Header.Get("Idempotency-Key"))
+				require.NoError(t, r.ParseForm())
+				assert.Equal(t, tt.wantForm, r.PostForm)
+				w.WriteHeader(tt.status)
+				_, _ = io.WriteString(w, tt.response)
+			})
+
+			got, err := c.CreateCheckoutSession(context.Background(), tt.params)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDefaultClient_CreatePortalSession(t *testing.T) {
+	t.Run("success: portal for customer", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/billing_portal/sessions", r.URL.Path)
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "cus_1", r.PostForm.Get("customer"))
+			assert.Equal(t, "https://app/billing", r.PostForm.Get("return_url"))
+			_, _ = io.WriteString(w, `{"id":"bps_1","url":"https://billing.stripe.com/p/bps_1"}`)
+		})
+
+		got, err := c.CreatePortalSession(context.Background(),
+			PortalSessionParams{CustomerID: "cus_1", ReturnURL: "https://app/billing"})
+		require.NoError(t, err)
+		assert.Equal(t, &PortalSession{ID: "bps_1", URL: "https://billing.stripe.com/p/bps_1"}, got)
+	})
+
+	t.Run("fail: missing customer", func(t *testing.T) {
+		_, err := NewDefaultClient("sk").CreatePortalSession(context.Background(), PortalSessionParams{})
+		assert.EqualError(t, err, "customer id is required")
+	})
+}
+
+func TestDefaultClient_GetSubscription(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		response string
+		want     *Subscription
+		wantErr  string
+	}{
+		{
+			name:   "success: typed subscription",
+			status: http.StatusOK,
+			response: `{"id":"sub_1","customer":"cus_1","status":"active","current_period_end":1700000000,
+				"cancel_at":null,"items":{"data":[{"id":"si_1","price":{"id":"price_1"}}]}}`,
+			want: &Subscription{
+				ID:               "sub_1",
+				CustomerID:       "cus_1",
+				Status:           "active",
+				CurrentPeriodEnd: 1700000000,
+				Items:            SubscriptionItems{Data: []SubscriptionItem{{ID: "si_1", Price: Price{ID: "price_1"}}}},
+			},
+		},
+		{
+			name:     "fail: not found",
+			status:   http.StatusNotFound,
+			response: `{"error":{"type":"invalid_request_error","message":"No such subscription"}}`,
+			wantErr:  "stripe: No such subscription (404 invalid_request_error)",
+		},
+		{
+			name:     "fail: error without envelope",
+			status:   http.StatusUnauthorized,
+			response: `unauthorized`,
+			wantErr:  "stripe: Unauthorized (401)",
+		},
+		{
+			name:     "fail: malformed body",
+			status:   http.StatusOK,
+			response: `{"id":1}`,
+			wantErr:  "stripe: decode response",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/v1/subscriptions/sub_1", r.URL.Path)
+				assert.Empty(t, r.Header.Get("Idempotency-Key"))
+				w.WriteHeader(tt.status)
+				_, _ = io.WriteString(w, tt.response)
+			})
+
+			got, err := c.GetSubscription(context.Background(), "sub_1")
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				var apiErr *APIError
+				if tt.status >= http.StatusBadRequest {
+					require.True(t, errors.As(err, &apiErr))
+					assert.Equal(t, tt.status, apiErr.StatusCode)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, "price_1", *got.PriceID())
+		})
+	}
+}
+
+func TestDefaultClient_FindInvoiceByNumber(t *testing.T) {
+	tests := []struct {
+		name     string
+		number   string
+		response string
+		want     *Invoice
+		wantErr  error
+	}{
+		{
+			name:   "success: first match",
+			number: "ABCD-0001",
+			response: `{"data":[{"id":"in_1","customer":"cus_1",
+				"customer_email":"a@example.com","number":"ABCD-0001"}]}`,
+			want: &Invoice{ID: "in_1", CustomerID: "cus_1", CustomerEmail: "a@example.com", Number: "ABCD-0001"},
+		},
+		{
+			name:     "fail: no match",
+			number:   "ABCD-0002",
+			response: `{"data":[]}`,
+			wantErr:  ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/v1/invoices/search", r.URL.Path)
+				assert.Equal(t, "number:'"+tt.number+"'", r.URL.Query().Get("query"))
+				_, _ = io.WriteString(w, tt.response)
+			})
+
+			got, err := c.FindInvoiceByNumber(context.Background(), tt.number)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("fail: quote in number", func(t *testing.T) {
+		_, err := NewDefaultClient("sk").FindInvoiceByNumber(context.Background(), "A' OR customer:'x")
+		assert.EqualError(t, err, "invalid invoice number")
+	})
+}
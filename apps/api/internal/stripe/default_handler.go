@@ -6,58 +6,99 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
 
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/entitlement"
 	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/notification"
 	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/teamwebhook"
 	"github.com/real-staging-ai/api/internal/user"
+	"github.com/real-staging-ai/api/internal/webhookarchive"
 )
 
 // DefaultHandler handles Stripe webhooks and related event processing.
 type DefaultHandler struct {
-	db storage.Database
+	db  storage.Database
+	cfg config.Stripe
+	// devLike allows unsigned webhooks when no secret is configured.
+	devLike bool
+	// client fetches objects from the Stripe API. Nil when no secret key is configured.
+	client Client
+	// archive keeps verified payloads for Replay. Nil when archiving is disabled.
+	archive webhookarchive.Service
+	// notifier tells users about failed payments. Nil disables notifications.
+	notifier notification.Notifier
+	// teamHooks posts failed payments to the customer's Slack or Teams. Nil disables it.
+	teamHooks teamwebhook.Notifier
+	// entitlements is told when a user's subscription changes. Nil skips invalidation.
+	entitlements entitlement.Service
+	// committed collects side effects to run once withTx's transaction commits. Nil
+	// outside withTx, where they run immediately.
+	committed *[]func()
 }
 
-// NewDefaultHandler constructs a Stripe DefaultHandler.
-func NewDefaultHandler(db storage.Database) *DefaultHandler {
-	return &DefaultHandler{db: db}
+// HandlerOption customizes a DefaultHandler.
+type HandlerOption func(*DefaultHandler)
+
+// WithArchive stores every verified webhook payload in archive, so it can be replayed.
+func WithArchive(archive webhookarchive.Service) HandlerOption {
+	return func(h *DefaultHandler) { h.archive = archive }
 }
 
-// errorResponse is a simple JSON error envelope for handler responses.
-type errorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message"`
+// WithNotifier adds an in-app notification for the customer when an invoice payment fails.
+func WithNotifier(notifier notification.Notifier) HandlerOption {
+	return func(h *DefaultHandler) { h.notifier = notifier }
+}
+
+// WithTeamNotifier posts failed invoice payments to the customer's team webhooks.
+func WithTeamNotifier(notifier teamwebhook.Notifier) HandlerOption {
+	return func(h *DefaultHandler) { h.teamHooks = notifier }
+}
+
+// WithEntitlements invalidates a user's cached entitlements whenever one of their
+// subscriptions is stored, so plan changes and revocations apply within seconds.
+func WithEntitlements(entitlements entitlement.Service) HandlerOption {
+	return func(h *DefaultHandler) { h.entitlements = entitlements }
 }
 
-// StripeEvent represents a Stripe webhook event (subset).
-type StripeEvent struct {
-	ID      string                 `json:"id"`
-	Type    string                 `json:"type"`
-	Data    map[string]interface{} `json:"data"`
-	Created int64                  `json:"created"`
+// NewDefaultHandler constructs a Stripe DefaultHandler. Webhooks are verified with
+// cfg.WebhookSecret; outside dev-like environments a missing secret fails closed. With
+// cfg.SecretKey set, completed checkouts also fetch their subscription from Stripe.
+func NewDefaultHandler(
+	db storage.Database, cfg config.Stripe, app config.App, opts ...HandlerOption,
+) *DefaultHandler {
+	h := &DefaultHandler{db: db, cfg: cfg, devLike: app.IsDevLike()}
+	if cfg.SecretKey != "" {
+		h.client = NewDefaultClient(cfg.SecretKey)
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
-// CheckoutSession represents a Stripe checkout session (subset).
-type CheckoutSession struct {
-	ID                string `json:"id"`
-	CustomerID        string `json:"customer"`
-	PaymentStatus     string `json:"payment_status"`
-	SubscriptionID    string `json:"subscription"`
-	ClientReferenceID string `json:"client_reference_id"`
+// errorResponse is a simple JSON error envelope for handler responses.
+type errorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
 }
 
 // Webhook handles POST /api/v1/stripe/webhook requests.
 func (h *DefaultHandler) Webhook(c echo.Context) error {
 	log := logging.Default()
-	ctx := context.Background()
+	ctx := c.Request().Context()
 
 	// Read the request body
 	body, err := io.ReadAll(c.Request().Body)
@@ -78,10 +119,10 @@ func (h *DefaultHandler) Webhook(c echo.Context) error {
 		})
 	}
 
-	// Enforce STRIPE_WEBHOOK_SECRET in non-dev environments and verify signature when present
-	webhookSecret := os.Getenv("STRIPE_WEBHOOK_SECRET")
+	// Enforce the webhook secret in non-dev environments and verify signature when present
+	webhookSecret := h.cfg.WebhookSecret
 	// If secret is missing in a non-dev environment, fail fast
-	if webhookSecret == "" && !isDevLikeEnv() {
+	if webhookSecret == "" && !h.devLike {
 		log.Error(ctx, "Stripe webhook misconfiguration: STRIPE_WEBHOOK_SECRET not set in non-dev environment")
 		return c.JSON(http.StatusServiceUnavailable, errorResponse{
 			Error:   "service_unavailable",
@@ -91,7 +132,11 @@ func (h *DefaultHandler) Webhook(c echo.Context) error {
 	// Verify signature when a secret is configured
 	if webhookSecret != "" {
 		stripeSignature := c.Request().Header.Get("Stripe-Signature")
-		if err := verifyStripeSignature(body, stripeSignature, webhookSecret, 5*time.Minute, time.Now); err != nil {
+		tolerance := h.cfg.WebhookTolerance
+		if tolerance <= 0 {
+			tolerance = 5 * time.Minute
+		}
+		if err := verifyStripeSignature(body, stripeSignature, webhookSecret, tolerance, time.Now); err != nil {
 			log.Error(ctx, fmt.Sprintf("Stripe signature verification failed: %v", err))
 			return c.JSON(http.StatusUnauthorized, errorResponse{
 				Error:   "unauthorized",
@@ -115,151 +160,180 @@ func (h *DefaultHandler) Webhook(c echo.Context) error {
 
 	log.Error(ctx, fmt.Sprintf("Received Stripe webhook event: %s (ID: %s)", event.Type, event.ID))
 
-	// Idempotency check: ensure the event hasn't already been processed
-	processed, err := h.alreadyProcessedStripeEvent(c.Request().Context(), event.ID)
+	// Archive before processing so an event a handler mishandles can be replayed once it is fixed.
+	// Duplicate deliveries overwrite the same object. Archiving is best-effort.
+	if h.archive != nil && event.ID != "" {
+		if err := h.archive.Put(ctx, webhookarchive.SourceStripe, event.ID, body); err != nil {
+			log.Error(ctx, fmt.Sprintf("Failed to archive Stripe event %s: %v", event.ID, err))
+		}
+	}
+
+	// Idempotency gate: claim the event with INSERT ... ON CONFLICT DO NOTHING in the same transaction as
+	// its side effects. A concurrent delivery of the same event blocks on the claim until this one commits
+	// (and is then reported as a duplicate) or rolls back (and is then processed).
+	var claimed bool
+	err = h.withTx(ctx, func(tx *DefaultHandler) error {
+		var err error
+		claimed, err = tx.claimStripeEvent(ctx, event.ID, event.Type, body)
+		if err != nil {
+			return fmt.Errorf("claim event: %w", err)
+		}
+		if !claimed {
+			return nil
+		}
+		if err := tx.processEvent(ctx, &event); err != nil {
+			return fmt.Errorf("handle %s: %w", event.Type, err)
+		}
+		return nil
+	})
 	if err != nil {
-		log.Error(ctx, fmt.Sprintf("Error checking Stripe event idempotency: %v", err))
+		log.Error(ctx, fmt.Sprintf("Error processing Stripe event %s: %v", event.ID, err))
 		return c.JSON(http.StatusInternalServerError, errorResponse{
 			Error:   "internal_server_error",
 			Message: "Failed to process webhook",
 		})
 	}
-	if processed {
+	if !claimed {
 		// Already processed; acknowledge to prevent retries
 		return c.JSON(http.StatusOK, map[string]string{
 			"status": "duplicate",
 		})
 	}
 
-	// Handle different event types
-	switch event.Type {
-	case "checkout.session.completed":
-		if err := h.handleCheckoutSessionCompleted(c.Request().Context(), &event); err != nil {
-			log.Error(ctx, fmt.Sprintf("Error handling checkout.session.completed: %v", err))
-			return c.JSON(http.StatusInternalServerError, errorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to process webhook",
-			})
-		}
-
-	case "customer.subscription.created":
-		if err := h.handleSubscriptionCreated(c.Request().Context(), &event); err != nil {
-			log.Error(ctx, fmt.Sprintf("Error handling customer.subscription.created: %v", err))
-			return c.JSON(http.StatusInternalServerError, errorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to process webhook",
-			})
-		}
+	// Return 200 to acknowledge receipt of the webhook
+	return c.JSON(http.StatusOK, map[string]string{
+		"status": "received",
+	})
+}
 
-	case "customer.subscription.updated":
-		if err := h.handleSubscriptionUpdated(c.Request().Context(), &event); err != nil {
-			log.Error(ctx, fmt.Sprintf("Error handling customer.subscription.updated: %v", err))
-			return c.JSON(http.StatusInternalServerError, errorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to process webhook",
-			})
-		}
+// Replay handles POST /api/v1/admin/webhooks/stripe/:event_id/replay. It feeds the archived
+// payload of the event through its handler again, even if it was processed before, so state
+// left wrong by a since-fixed handler bug can be rebuilt. The signature is not checked again;
+// only verified payloads are archived.
+func (h *DefaultHandler) Replay(c echo.Context) error {
+	log := logging.Default()
+	ctx := c.Request().Context()
 
-	case "customer.subscription.deleted":
-		if err := h.handleSubscriptionDeleted(c.Request().Context(), &event); err != nil {
-			log.Error(ctx, fmt.Sprintf("Error handling customer.subscription.deleted: %v", err))
-			return c.JSON(http.StatusInternalServerError, errorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to process webhook",
-			})
-		}
+	if h.archive == nil {
+		return c.JSON(http.StatusServiceUnavailable, errorResponse{
+			Error:   "service_unavailable",
+			Message: "Webhook archive is not enabled",
+		})
+	}
 
-	case "customer.created":
-		if err := h.handleCustomerCreated(c.Request().Context(), &event); err != nil {
-			log.Error(ctx, fmt.Sprintf("Error handling customer.created: %v", err))
-			return c.JSON(http.StatusInternalServerError, errorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to process webhook",
-			})
-		}
+	eventID := c.Param("event_id")
+	body, err := h.archive.Get(ctx, webhookarchive.SourceStripe, eventID)
+	switch {
+	case errors.Is(err, webhookarchive.ErrInvalidEventID):
+		return c.JSON(http.StatusBadRequest, errorResponse{
+			Error:   "bad_request",
+			Message: "Invalid event ID",
+		})
+	case errors.Is(err, webhookarchive.ErrNotFound):
+		return c.JSON(http.StatusNotFound, errorResponse{
+			Error:   "not_found",
+			Message: "No archived payload for this event",
+		})
+	case err != nil:
+		log.Error(ctx, fmt.Sprintf("Error loading archived Stripe event %s: %v", eventID, err))
+		return c.JSON(http.StatusInternalServerError, errorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to load archived webhook",
+		})
+	}
 
-	case "customer.updated":
-		if err := h.handleCustomerUpdated(c.Request().Context(), &event); err != nil {
-			log.Error(ctx, fmt.Sprintf("Error handling customer.updated: %v", err))
-			return c.JSON(http.StatusInternalServerError, errorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to process webhook",
-			})
-		}
+	var event StripeEvent
+	if err := json.Unmarshal(body, &event); err != nil || event.ID != eventID {
+		log.Error(ctx, fmt.Sprintf("Archived Stripe event %s is unreadable or mismatched: %v", eventID, err))
+		return c.JSON(http.StatusInternalServerError, errorResponse{
+			Error:   "internal_server_error",
+			Message: "Archived webhook is corrupt",
+		})
+	}
 
-	case "customer.deleted":
-		if err := h.handleCustomerDeleted(c.Request().Context(), &event); err != nil {
-			log.Error(ctx, fmt.Sprintf("Error handling customer.deleted: %v", err))
-			return c.JSON(http.StatusInternalServerError, errorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to process webhook",
-			})
-		}
+	log.Error(ctx, fmt.Sprintf("Replaying Stripe webhook event: %s (ID: %s)", event.Type, event.ID))
 
-	case "invoice.payment_succeeded":
-		if err := h.handleInvoicePaymentSucceeded(c.Request().Context(), &event); err != nil {
-			log.Error(ctx, fmt.Sprintf("Error handling invoice.payment_succeeded: %v", err))
-			return c.JSON(http.StatusInternalServerError, errorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to process webhook",
-			})
+	// Claim the event too, in case its original delivery failed, so a late redelivery from
+	// Stripe is reported as a duplicate rather than processed a third time.
+	err = h.withTx(ctx, func(tx *DefaultHandler) error {
+		if _, err := tx.claimStripeEvent(ctx, event.ID, event.Type, body); err != nil {
+			return fmt.Errorf("claim event: %w", err)
 		}
-
-	case "invoice.payment_failed":
-		if err := h.handleInvoicePaymentFailed(c.Request().Context(), &event); err != nil {
-			log.Error(ctx, fmt.Sprintf("Error handling invoice.payment_failed: %v", err))
-			return c.JSON(http.StatusInternalServerError, errorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to process webhook",
-			})
+		if err := tx.processEvent(ctx, &event); err != nil {
+			return fmt.Errorf("handle %s: %w", event.Type, err)
 		}
-
-	default:
-		log.Error(ctx, fmt.Sprintf("Unhandled webhook event type: %s", event.Type))
-	}
-
-	// Mark event as processed (idempotency scaffold). If this fails, log and still acknowledge.
-	if err := h.markStripeEventProcessed(c.Request().Context(), event.ID, event.Type, body); err != nil {
-		log.Error(ctx, fmt.Sprintf("Failed to mark Stripe event processed: %v", err))
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Sprintf("Error replaying Stripe event %s: %v", event.ID, err))
+		return c.JSON(http.StatusInternalServerError, errorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to replay webhook",
+		})
 	}
 
-	// Return 200 to acknowledge receipt of the webhook
 	return c.JSON(http.StatusOK, map[string]string{
-		"status": "received",
+		"status":   "replayed",
+		"event_id": event.ID,
+		"type":     event.Type,
 	})
 }
 
+// processEvent dispatches a webhook event to its type-specific handler.
+func (h *DefaultHandler) processEvent(ctx context.Context, event *StripeEvent) error {
+	switch event.Type {
+	case "checkout.session.completed":
+		return h.handleCheckoutSessionCompleted(ctx, event)
+	case "checkout.session.async_payment_succeeded":
+		return h.handleCheckoutAsyncPaymentSucceeded(ctx, event)
+	case "customer.subscription.created":
+		return h.handleSubscriptionCreated(ctx, event)
+	case "customer.subscription.updated":
+		return h.handleSubscriptionUpdated(ctx, event)
+	case "customer.subscription.deleted":
+		return h.handleSubscriptionDeleted(ctx, event)
+	case "customer.created":
+		return h.handleCustomerCreated(ctx, event)
+	case "customer.updated":
+		return h.handleCustomerUpdated(ctx, event)
+	case "customer.deleted":
+		return h.handleCustomerDeleted(ctx, event)
+	case "invoice.payment_succeeded":
+		return h.handleInvoicePaymentSucceeded(ctx, event)
+	case "invoice.payment_failed":
+		return h.handleInvoicePaymentFailed(ctx, event)
+	default:
+		logging.Default().Error(ctx, fmt.Sprintf("Unhandled webhook event type: %s", event.Type))
+		return nil
+	}
+}
+
 // ---------------------------- Event Handlers ----------------------------
 
 // handleCheckoutSessionCompleted processes successful checkout sessions.
 func (h *DefaultHandler) handleCheckoutSessionCompleted(ctx context.Context, event *StripeEvent) error {
 	log := logging.Default()
 
-	// Extract checkout session data
-	sessionData, ok := event.Data["object"].(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("invalid checkout session data")
+	var session CheckoutSession
+	if err := event.decodeObject(&session); err != nil {
+		return fmt.Errorf("invalid checkout session data: %w", err)
 	}
 	if h.db == nil {
 		// No database configured (e.g., in tests). Skip persistence.
 		return nil
 	}
 
-	customerID, _ := sessionData["customer"].(string)
-	paymentStatus, _ := sessionData["payment_status"].(string)
-	clientReferenceID, _ := sessionData["client_reference_id"].(string)
-
 	log.Error(ctx, fmt.Sprintf("Checkout completed - Customer: %s, Payment Status: %s, Reference: %s",
-		customerID, paymentStatus, clientReferenceID))
+		session.CustomerID, session.PaymentStatus, session.ClientReferenceID))
 
 	// Link Stripe customer to a user by client_reference_id (Auth0 sub or internal user ref)
-	if clientReferenceID != "" && customerID != "" {
+	if session.ClientReferenceID != "" && session.CustomerID != "" {
 		userRepo := user.NewDefaultRepository(h.db)
-		u, err := userRepo.GetByAuth0Sub(ctx, clientReferenceID)
+		u, err := userRepo.GetByAuth0Sub(ctx, session.ClientReferenceID)
 		if err == nil {
 			// Only set stripe_customer_id if it's not already set
 			if !u.StripeCustomerID.Valid || u.StripeCustomerID.String == "" {
-				if _, err := userRepo.UpdateStripeCustomerID(ctx, u.ID.String(), customerID); err != nil {
+				if _, err := userRepo.UpdateStripeCustomerID(ctx, u.ID.String(), session.CustomerID); err != nil {
 					log.Error(ctx, fmt.Sprintf("Failed to update user's Stripe customer ID: %v", err))
 				}
 			}
@@ -267,17 +341,76 @@ func (h *DefaultHandler) handleCheckoutSessionCompleted(ctx context.Context, eve
 			// Not fatal for webhook handling; just log
 			log.Error(ctx, fmt.Sprintf(
 				"Could not find user by client_reference_id=%s to link Stripe customer=%s: %v",
-				clientReferenceID, customerID, err))
+				session.ClientReferenceID, session.CustomerID, err))
 		}
 	}
 
+	if session.Metadata[MetadataPurpose] == PurposeExportCredit {
+		return h.recordExportCredit(ctx, &session)
+	}
+
+	// customer.subscription.created usually arrives before the customer is linked above and
+	// finds no user, so fetch the subscription now that it can be attributed.
+	if session.SubscriptionID != "" && h.client != nil {
+		sub, err := h.client.GetSubscription(ctx, session.SubscriptionID)
+		if err != nil {
+			log.Error(ctx, fmt.Sprintf("Failed to fetch subscription %s after checkout: %v", session.SubscriptionID, err))
+			return nil
+		}
+		return h.persistSubscription(ctx, sub, sub.Status, "checkout")
+	}
+
 	return nil
 }
 
-// persistSubscription is a helper to persist subscription data to the database.
-func (h *DefaultHandler) persistSubscription(
-	ctx context.Context, subscriptionData map[string]interface{}, eventType string,
-) error {
+// handleCheckoutAsyncPaymentSucceeded records export credits paid with a delayed payment
+// method, whose checkout completed unpaid.
+func (h *DefaultHandler) handleCheckoutAsyncPaymentSucceeded(ctx context.Context, event *StripeEvent) error {
+	var session CheckoutSession
+	if err := event.decodeObject(&session); err != nil {
+		return fmt.Errorf("invalid checkout session data: %w", err)
+	}
+	if h.db == nil || session.Metadata[MetadataPurpose] != PurposeExportCredit {
+		return nil
+	}
+	return h.recordExportCredit(ctx, &session)
+}
+
+// recordExportCredit adds the export credit a paid one-time checkout bought for the user in its
+// client reference. Credits are keyed by the checkout session, so redeliveries add nothing.
+// Unpaid sessions are skipped until checkout.session.async_payment_succeeded.
+func (h *DefaultHandler) recordExportCredit(ctx context.Context, session *CheckoutSession) error {
+	log := logging.Default()
+	if session.PaymentStatus != "paid" {
+		log.Info(ctx, "Export credit checkout not paid yet", "session_id", session.ID,
+			"payment_status", session.PaymentStatus)
+		return nil
+	}
+
+	u, err := user.NewDefaultRepository(h.db).GetByAuth0Sub(ctx, session.ClientReferenceID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		log.Error(ctx, fmt.Sprintf("No user for export credit checkout %s (client_reference_id=%s)",
+			session.ID, session.ClientReferenceID))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get user for export credit: %w", err)
+	}
+
+	// The customer paid, so a failed insert fails the webhook for Stripe to redeliver.
+	if _, err := queries.New(h.db).CreateExportCredit(ctx, queries.CreateExportCreditParams{
+		UserID:            u.ID,
+		CheckoutSessionID: session.ID,
+	}); err != nil {
+		return fmt.Errorf("record export credit: %w", err)
+	}
+	return nil
+}
+
+// persistSubscription stores the subscription with status for the user its customer is
+// linked to. Subscriptions of unknown customers are parked for the worker to apply once the
+// customer is linked.
+func (h *DefaultHandler) persistSubscription(ctx context.Context, sub *Subscription, status, eventType string) error {
 	log := logging.Default()
 
 	if h.db == nil {
@@ -285,14 +418,10 @@ func (h *DefaultHandler) persistSubscription(
 		return nil
 	}
 
-	customerID, _ := subscriptionData["customer"].(string)
-	subscriptionID, _ := subscriptionData["id"].(string)
-	status, _ := subscriptionData["status"].(string)
-
 	log.Error(ctx, fmt.Sprintf("Subscription %s - Customer: %s, Subscription: %s, Status: %s",
-		eventType, customerID, subscriptionID, status))
+		eventType, sub.CustomerID, sub.ID, status))
 
-	if customerID == "" || subscriptionID == "" {
+	if sub.CustomerID == "" || sub.ID == "" {
 		return nil
 	}
 
@@ -300,57 +429,32 @@ func (h *DefaultHandler) persistSubscription(
 	subRepo := NewSubscriptionsRepository(h.db)
 	userRepo := user.NewDefaultRepository(h.db)
 
-	u, err := userRepo.GetByStripeCustomerID(ctx, customerID)
+	u, err := userRepo.GetByStripeCustomerID(ctx, sub.CustomerID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return h.parkSubscription(ctx, sub, status, eventType)
+	}
 	if err != nil {
 		log.Error(ctx, fmt.Sprintf(
-			"No user found for Stripe customer on subscription.%s: %s (err=%v)", eventType, customerID, err))
+			"No user found for Stripe customer on subscription.%s: %s (err=%v)", eventType, sub.CustomerID, err))
 		return nil
 	}
 
-	// Extract optional subscription details
-	var priceIDPtr *string
-	if itemsRaw, ok := subscriptionData["items"].(map[string]interface{}); ok {
-		if dataArr, ok := itemsRaw["data"].([]interface{}); ok && len(dataArr) > 0 {
-			if firstItem, ok := dataArr[0].(map[string]interface{}); ok {
-				if priceRaw, ok := firstItem["price"].(map[string]interface{}); ok {
-					if pid, ok := priceRaw["id"].(string); ok && pid != "" {
-						priceIDPtr = &pid
-					}
-				}
-			}
-		}
-	}
-
-	var (
-		cpsPtr, cpePtr, cancelAtPtr, canceledAtPtr *time.Time
-		cancelAtPeriodEnd                          bool
-	)
-
-	if v, ok := subscriptionData["current_period_start"].(float64); ok && v > 0 {
-		t := time.Unix(int64(v), 0)
-		cpsPtr = &t
-	}
-	if v, ok := subscriptionData["current_period_end"].(float64); ok && v > 0 {
-		t := time.Unix(int64(v), 0)
-		cpePtr = &t
-	}
-	if v, ok := subscriptionData["cancel_at"].(float64); ok && v > 0 {
-		t := time.Unix(int64(v), 0)
-		cancelAtPtr = &t
-	}
-	if v, ok := subscriptionData["canceled_at"].(float64); ok && v > 0 {
-		t := time.Unix(int64(v), 0)
-		canceledAtPtr = &t
-	}
-	if v, ok := subscriptionData["cancel_at_period_end"].(bool); ok {
-		cancelAtPeriodEnd = v
-	}
-
 	if _, err := subRepo.UpsertByStripeID(
-		ctx, u.ID.String(), subscriptionID, status, priceIDPtr, cpsPtr, cpePtr,
-		cancelAtPtr, canceledAtPtr, cancelAtPeriodEnd,
+		ctx, u.ID.String(), sub.ID, status, sub.PriceID(),
+		unixTime(sub.CurrentPeriodStart), unixTime(sub.CurrentPeriodEnd),
+		unixTime(sub.CancelAt), unixTime(sub.CanceledAt), sub.CancelAtPeriodEnd,
 	); err != nil {
 		log.Error(ctx, fmt.Sprintf("Failed to upsert subscription (%s): %v", eventType, err))
+		return nil
+	}
+
+	if h.entitlements != nil {
+		userID := u.ID.String()
+		h.afterCommit(func() {
+			if err := h.entitlements.Invalidate(ctx, userID); err != nil {
+				log.Error(ctx, fmt.Sprintf("Failed to invalidate entitlements (%s): %v", eventType, err))
+			}
+		})
 	}
 
 	return nil
@@ -358,243 +462,154 @@ func (h *DefaultHandler) persistSubscription(
 
 // handleSubscriptionCreated processes new subscription events.
 func (h *DefaultHandler) handleSubscriptionCreated(ctx context.Context, event *StripeEvent) error {
-	subscriptionData, ok := event.Data["object"].(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("invalid subscription data")
+	var sub Subscription
+	if err := event.decodeObject(&sub); err != nil {
+		return fmt.Errorf("invalid subscription data: %w", err)
 	}
-	return h.persistSubscription(ctx, subscriptionData, "created")
+	return h.persistSubscription(ctx, &sub, sub.Status, "created")
 }
 
 // handleSubscriptionUpdated processes subscription update events.
 func (h *DefaultHandler) handleSubscriptionUpdated(ctx context.Context, event *StripeEvent) error {
-	subscriptionData, ok := event.Data["object"].(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("invalid subscription data")
+	var sub Subscription
+	if err := event.decodeObject(&sub); err != nil {
+		return fmt.Errorf("invalid subscription data: %w", err)
 	}
-	return h.persistSubscription(ctx, subscriptionData, "updated")
+	return h.persistSubscription(ctx, &sub, sub.Status, "updated")
 }
 
-// handleSubscriptionDeleted processes subscription cancellation events.
+// handleSubscriptionDeleted processes subscription cancellation events. The subscription's
+// final state is persisted with status "canceled".
 func (h *DefaultHandler) handleSubscriptionDeleted(ctx context.Context, event *StripeEvent) error {
-	log := logging.Default()
+	var sub Subscription
+	if err := event.decodeObject(&sub); err != nil {
+		return fmt.Errorf("invalid subscription data: %w", err)
+	}
+	return h.persistSubscription(ctx, &sub, "canceled", "deleted")
+}
 
-	subscriptionData, ok := event.Data["object"].(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("invalid subscription data")
+// handleInvoicePaymentSucceeded processes successful payment events.
+func (h *DefaultHandler) handleInvoicePaymentSucceeded(ctx context.Context, event *StripeEvent) error {
+	var inv Invoice
+	if err := event.decodeObject(&inv); err != nil {
+		return fmt.Errorf("invalid invoice data: %w", err)
 	}
 	if h.db == nil {
 		// No database configured (e.g., in tests). Skip persistence.
 		return nil
 	}
-
-	customerID, _ := subscriptionData["customer"].(string)
-	subscriptionID, _ := subscriptionData["id"].(string)
-
-	log.Error(ctx, fmt.Sprintf("Subscription deleted - Customer: %s, Subscription: %s",
-		customerID, subscriptionID))
-
-	// Mark subscription as canceled/deactivated
-	subRepo := NewSubscriptionsRepository(h.db)
-	userRepo := user.NewDefaultRepository(h.db)
-	if customerID != "" && subscriptionID != "" {
-		// Stripe sends a final status (typically "canceled"); persist it
-		if u, err := userRepo.GetByStripeCustomerID(ctx, customerID); err == nil {
-			// Extract optional subscription details even on deletion (Stripe often includes final state)
-			var priceIDPtr *string
-			if itemsRaw, ok := subscriptionData["items"].(map[string]interface{}); ok {
-				if dataArr, ok := itemsRaw["data"].([]interface{}); ok && len(dataArr) > 0 {
-					if firstItem, ok := dataArr[0].(map[string]interface{}); ok {
-						if priceRaw, ok := firstItem["price"].(map[string]interface{}); ok {
-							if pid, ok := priceRaw["id"].(string); ok && pid != "" {
-								priceIDPtr = &pid
-							}
-						}
-					}
-				}
-			}
-
-			var (
-				cpsPtr, cpePtr, cancelAtPtr, canceledAtPtr *time.Time
-				cancelAtPeriodEnd                          bool
-			)
-
-			if v, ok := subscriptionData["current_period_start"].(float64); ok && v > 0 {
-				t := time.Unix(int64(v), 0)
-				cpsPtr = &t
-			}
-			if v, ok := subscriptionData["current_period_end"].(float64); ok && v > 0 {
-				t := time.Unix(int64(v), 0)
-				cpePtr = &t
-			}
-			if v, ok := subscriptionData["cancel_at"].(float64); ok && v > 0 {
-				t := time.Unix(int64(v), 0)
-				cancelAtPtr = &t
-			}
-			if v, ok := subscriptionData["canceled_at"].(float64); ok && v > 0 {
-				t := time.Unix(int64(v), 0)
-				canceledAtPtr = &t
-			}
-			if v, ok := subscriptionData["cancel_at_period_end"].(bool); ok {
-				cancelAtPeriodEnd = v
-			}
-
-			if _, err := subRepo.UpsertByStripeID(
-				ctx, u.ID.String(), subscriptionID, "canceled", priceIDPtr, cpsPtr, cpePtr,
-				cancelAtPtr, canceledAtPtr, cancelAtPeriodEnd,
-			); err != nil {
-				log.Error(ctx, fmt.Sprintf("Failed to upsert subscription (deleted): %v", err))
-			}
-		} else {
-			log.Error(ctx, fmt.Sprintf(
-				"No user found for Stripe customer on subscription.deleted: %s (err=%v)", customerID, err))
-		}
+	if inv.Status == "" {
+		inv.Status = "paid"
 	}
-	return nil
+	logging.Default().Error(ctx, fmt.Sprintf(
+		"Invoice payment succeeded - Invoice: %s, Customer: %s, Subscription: %s, AmountPaid: %.2f",
+		inv.ID, inv.CustomerID, inv.SubscriptionID, float64(inv.AmountPaid)/100))
+	_, err := h.persistInvoice(ctx, &inv, "payment_succeeded")
+	return err
 }
 
-// handleInvoicePaymentSucceeded processes successful payment events.
-func (h *DefaultHandler) handleInvoicePaymentSucceeded(ctx context.Context, event *StripeEvent) error {
-	log := logging.Default()
-
-	invoiceData, ok := event.Data["object"].(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("invalid invoice data")
+// handleInvoicePaymentFailed processes failed payment events.
+func (h *DefaultHandler) handleInvoicePaymentFailed(ctx context.Context, event *StripeEvent) error {
+	var inv Invoice
+	if err := event.decodeObject(&inv); err != nil {
+		return fmt.Errorf("invalid invoice data: %w", err)
 	}
 	if h.db == nil {
 		// No database configured (e.g., in tests). Skip persistence.
 		return nil
 	}
-
-	invoiceID, _ := invoiceData["id"].(string)
-	customerID, _ := invoiceData["customer"].(string)
-	subscriptionID, _ := invoiceData["subscription"].(string)
-	status, _ := invoiceData["status"].(string)
-	if status == "" {
-		status = "paid"
-	}
-
-	var amountDueI, amountPaidI int32
-	if v, ok := invoiceData["amount_due"].(float64); ok {
-		amountDueI = int32(v)
+	if inv.Status == "" {
+		inv.Status = "failed"
 	}
-	if v, ok := invoiceData["amount_paid"].(float64); ok {
-		amountPaidI = int32(v)
+	logging.Default().Error(ctx, fmt.Sprintf("Invoice payment failed - Invoice: %s, Customer: %s, Subscription: %s",
+		inv.ID, inv.CustomerID, inv.SubscriptionID))
+	userID, err := h.persistInvoice(ctx, &inv, "payment_failed")
+	if err != nil || userID == "" {
+		return err
 	}
-	currency, _ := invoiceData["currency"].(string)
-	invoiceNumber, _ := invoiceData["number"].(string)
-
-	log.Error(ctx, fmt.Sprintf("Invoice payment succeeded - Invoice: %s, Customer: %s, Subscription: %s, AmountPaid: %.2f",
-		invoiceID, customerID, subscriptionID, float64(amountPaidI)/100))
-
-	// Persist invoice
-	if customerID != "" && invoiceID != "" {
-		userRepo := user.NewDefaultRepository(h.db)
-		if u, err := userRepo.GetByStripeCustomerID(ctx, customerID); err == nil {
-			invRepo := NewInvoicesRepository(h.db)
-
-			var subIDPtr, currencyPtr, invNumPtr *string
-			if subscriptionID != "" {
-				subIDPtr = &subscriptionID
-			}
-			if currency != "" {
-				currencyPtr = &currency
-			}
-			if invoiceNumber != "" {
-				invNumPtr = &invoiceNumber
-			}
+	h.notifyPaymentFailed(ctx, userID, &inv)
+	return nil
+}
 
-			if _, err := invRepo.Upsert(
-				ctx, u.ID.String(), invoiceID, subIDPtr, status, amountDueI, amountPaidI, currencyPtr, invNumPtr,
-			); err != nil {
-				log.Error(ctx, fmt.Sprintf("Failed to upsert invoice (payment_succeeded): %v", err))
+// notifyPaymentFailed tells the customer about a failed invoice payment in the app and on
+// their team webhooks once the invoice is committed. Failures are logged; the event is still
+// acknowledged.
+func (h *DefaultHandler) notifyPaymentFailed(ctx context.Context, userID string, inv *Invoice) {
+	// Stripe retries delivery, so the dedupe key keeps one unread notification per invoice.
+	if h.notifier != nil {
+		h.afterCommit(func() {
+			if _, err := h.notifier.Notify(ctx, notification.NewNotification{
+				UserID:    userID,
+				Type:      notification.TypePaymentFailed,
+				Title:     "Payment failed",
+				Body:      "We couldn't charge your payment method. Update it to keep your subscription active.",
+				Data:      map[string]string{"invoice_id": inv.ID},
+				DedupeKey: "payment_failed:" + inv.ID,
+			}); err != nil {
+				logging.Default().Error(ctx, fmt.Sprintf("Failed to notify user of failed payment (%s): %v", inv.ID, err))
 			}
-		} else {
-			log.Error(ctx, fmt.Sprintf(
-				"No user found for Stripe customer on invoice.payment_succeeded: %s (err=%v)", customerID, err))
+		})
+	}
+	if h.teamHooks != nil {
+		invoice := inv.Number
+		if invoice == "" {
+			invoice = inv.ID
+		}
+		data := map[string]string{"invoice": invoice}
+		if inv.AmountDue > 0 && inv.Currency != "" {
+			data["amount"] = fmt.Sprintf("%s %.2f", strings.ToUpper(inv.Currency), float64(inv.AmountDue)/100)
 		}
+		h.afterCommit(func() {
+			if err := h.teamHooks.Notify(ctx, userID, teamwebhook.EventPaymentFailed, data); err != nil {
+				logging.Default().Error(ctx, fmt.Sprintf("Failed to post failed payment (%s) to team webhooks: %v", inv.ID, err))
+			}
+		})
 	}
-
-	return nil
 }
 
-// handleInvoicePaymentFailed processes failed payment events.
-func (h *DefaultHandler) handleInvoicePaymentFailed(ctx context.Context, event *StripeEvent) error {
+// persistInvoice stores the invoice for the user its customer is linked to and returns
+// that user's ID. Invoices of unknown customers are parked for the worker to apply once the
+// customer is linked, returning "".
+func (h *DefaultHandler) persistInvoice(ctx context.Context, inv *Invoice, eventType string) (string, error) {
 	log := logging.Default()
 
-	invoiceData, ok := event.Data["object"].(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("invalid invoice data")
+	if inv.CustomerID == "" || inv.ID == "" {
+		return "", nil
 	}
-	if h.db == nil {
-		// No database configured (e.g., in tests). Skip persistence.
-		return nil
-	}
-
-	invoiceID, _ := invoiceData["id"].(string)
-	customerID, _ := invoiceData["customer"].(string)
-	subscriptionID, _ := invoiceData["subscription"].(string)
-	status, _ := invoiceData["status"].(string)
-	if status == "" {
-		status = "failed"
+	amountDue, amountPaid, err := inv.amounts()
+	if err != nil {
+		return "", err
 	}
 
-	var amountDueI, amountPaidI int32
-	if v, ok := invoiceData["amount_due"].(float64); ok {
-		amountDueI = int32(v)
+	userRepo := user.NewDefaultRepository(h.db)
+	u, err := userRepo.GetByStripeCustomerID(ctx, inv.CustomerID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", h.parkInvoice(ctx, inv, amountDue, amountPaid, eventType)
 	}
-	if v, ok := invoiceData["amount_paid"].(float64); ok {
-		amountPaidI = int32(v)
+	if err != nil {
+		log.Error(ctx, fmt.Sprintf(
+			"No user found for Stripe customer on invoice.%s: %s (err=%v)", eventType, inv.CustomerID, err))
+		return "", nil
 	}
-	currency, _ := invoiceData["currency"].(string)
-	invoiceNumber, _ := invoiceData["number"].(string)
-
-	log.Error(ctx, fmt.Sprintf("Invoice payment failed - Invoice: %s, Customer: %s, Subscription: %s",
-		invoiceID, customerID, subscriptionID))
-
-	// Persist invoice with failed status
-	if customerID != "" && invoiceID != "" {
-		userRepo := user.NewDefaultRepository(h.db)
-		if u, err := userRepo.GetByStripeCustomerID(ctx, customerID); err == nil {
-			invRepo := NewInvoicesRepository(h.db)
 
-			var subIDPtr, currencyPtr, invNumPtr *string
-			if subscriptionID != "" {
-				subIDPtr = &subscriptionID
-			}
-			if currency != "" {
-				currencyPtr = &currency
-			}
-			if invoiceNumber != "" {
-				invNumPtr = &invoiceNumber
-			}
-
-			if _, err := invRepo.Upsert(
-				ctx, u.ID.String(), invoiceID, subIDPtr, status, amountDueI, amountPaidI, currencyPtr, invNumPtr,
-			); err != nil {
-				log.Error(ctx, fmt.Sprintf("Failed to upsert invoice (payment_failed): %v", err))
-			}
-		} else {
-			log.Error(ctx, fmt.Sprintf(
-				"No user found for Stripe customer on invoice.payment_failed: %s (err=%v)", customerID, err))
-		}
+	invRepo := NewInvoicesRepository(h.db)
+	if _, err := invRepo.Upsert(
+		ctx, u.ID.String(), inv.ID, optionalString(inv.SubscriptionID), inv.Status, amountDue, amountPaid,
+		optionalString(inv.Currency), optionalString(inv.Number),
+	); err != nil {
+		log.Error(ctx, fmt.Sprintf("Failed to upsert invoice (%s): %v", eventType, err))
 	}
-
-	return nil
+	return u.ID.String(), nil
 }
 
 // handleCustomerCreated processes new customer events.
 func (h *DefaultHandler) handleCustomerCreated(ctx context.Context, event *StripeEvent) error {
-	log := logging.Default()
-
-	customerData, ok := event.Data["object"].(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("invalid customer data")
+	var customer Customer
+	if err := event.decodeObject(&customer); err != nil {
+		return fmt.Errorf("invalid customer data: %w", err)
 	}
 
-	customerID, _ := customerData["id"].(string)
-	email, _ := customerData["email"].(string)
-
-	log.Error(ctx, fmt.Sprintf("Customer created: ID=%s, Email=%s", customerID, email))
+	logging.Default().Error(ctx, fmt.Sprintf("Customer created: ID=%s, Email=%s", customer.ID, customer.Email))
 	// TODO: Implement customer creation logic (optional)
 
 	return nil
@@ -602,17 +617,12 @@ func (h *DefaultHandler) handleCustomerCreated(ctx context.Context, event *Strip
 
 // handleCustomerUpdated processes customer update events.
 func (h *DefaultHandler) handleCustomerUpdated(ctx context.Context, event *StripeEvent) error {
-	log := logging.Default()
-
-	customerData, ok := event.Data["object"].(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("invalid customer data")
+	var customer Customer
+	if err := event.decodeObject(&customer); err != nil {
+		return fmt.Errorf("invalid customer data: %w", err)
 	}
 
-	customerID, _ := customerData["id"].(string)
-	email, _ := customerData["email"].(string)
-
-	log.Error(ctx, fmt.Sprintf("Customer updated: ID=%s, Email=%s", customerID, email))
+	logging.Default().Error(ctx, fmt.Sprintf("Customer updated: ID=%s, Email=%s", customer.ID, customer.Email))
 	// TODO: Implement customer update logic (optional)
 
 	return nil
@@ -620,16 +630,12 @@ func (h *DefaultHandler) handleCustomerUpdated(ctx context.Context, event *Strip
 
 // handleCustomerDeleted processes customer deletion events.
 func (h *DefaultHandler) handleCustomerDeleted(ctx context.Context, event *StripeEvent) error {
-	log := logging.Default()
-
-	customerData, ok := event.Data["object"].(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("invalid customer data")
+	var customer Customer
+	if err := event.decodeObject(&customer); err != nil {
+		return fmt.Errorf("invalid customer data: %w", err)
 	}
 
-	customerID, _ := customerData["id"].(string)
-
-	log.Error(ctx, fmt.Sprintf("Customer deleted: ID=%s", customerID))
+	logging.Default().Error(ctx, fmt.Sprintf("Customer deleted: ID=%s", customer.ID))
 	// TODO: Implement customer deletion logic (optional)
 
 	return nil
@@ -715,48 +721,51 @@ func computeStripeSignature(body []byte, ts int64, secret string) []byte {
 	return mac.Sum(nil)
 }
 
-// isDevLikeEnv reports whether the current process appears to be running in a dev/test environment.
-func isDevLikeEnv() bool {
-	// Check common env markers
-	for _, key := range []string{"APP_ENV", "GO_ENV", "ENV", "NODE_ENV"} {
-		val := strings.ToLower(strings.TrimSpace(os.Getenv(key)))
-		switch val {
-		case "", "dev", "development", "local", "test":
-			return true
-		case "prod", "production", "staging":
-			// explicit non-dev markers; continue checking others but prefer non-dev
+// ---------------------------- Idempotency Helpers ----------------------------
+
+// withTx runs fn with a copy of h whose database calls share one transaction. Side effects
+// fn registers with afterCommit run once the transaction commits, and not at all if it rolls
+// back. When db is nil (tests), fn runs without a transaction.
+func (h *DefaultHandler) withTx(ctx context.Context, fn func(tx *DefaultHandler) error) error {
+	var committed []func()
+	cp := *h
+	cp.committed = &committed
+	if h.db == nil {
+		if err := fn(&cp); err != nil {
+			return err
 		}
+	} else if err := h.db.WithTx(ctx, func(tx storage.Database) error {
+		committed = committed[:0]
+		cp.db = tx
+		return fn(&cp)
+	}); err != nil {
+		return err
 	}
-	// Heuristic: go test binaries typically end with ".test"
-	if strings.HasSuffix(os.Args[0], ".test") {
-		return true
+	for _, effect := range committed {
+		effect()
 	}
-	return false
+	return nil
 }
 
-// ---------------------------- Idempotency Helpers ----------------------------
-
-// alreadyProcessedStripeEvent checks if the event was already processed (idempotency scaffold).
-// Backed by processed_events; when db is nil (tests), treat as not processed.
-func (h *DefaultHandler) alreadyProcessedStripeEvent(ctx context.Context, eventID string) (bool, error) {
-	if h.db == nil {
-		// No database configured (e.g., in tests). Treat as not processed.
-		return false, nil
+// afterCommit runs effect once the transaction of the enclosing withTx commits, so caches
+// and customers never see rows that may still roll back. Outside withTx it runs immediately.
+func (h *DefaultHandler) afterCommit(effect func()) {
+	if h.committed == nil {
+		effect()
+		return
 	}
-	repo := NewProcessedEventsRepository(h.db)
-	return repo.IsProcessed(ctx, eventID)
+	*h.committed = append(*h.committed, effect)
 }
 
-// markStripeEventProcessed records the processed event (idempotency scaffold).
-// Stores the type and raw payload when available.
-func (h *DefaultHandler) markStripeEventProcessed(
+// claimStripeEvent records the event and reports whether this delivery claimed it.
+// Stores the type and raw payload. When db is nil (tests), every event is claimed.
+func (h *DefaultHandler) claimStripeEvent(
 	ctx context.Context, eventID, eventType string, payload []byte,
-) error {
+) (bool, error) {
 	if h.db == nil {
-		// No database configured (e.g., in tests). Skip persistence.
-		return nil
+		// No database configured (e.g., in tests). Treat as not processed.
+		return true, nil
 	}
 	repo := NewProcessedEventsRepository(h.db)
-	_, err := repo.Upsert(ctx, eventID, &eventType, payload)
-	return err
+	return repo.Claim(ctx, eventID, &eventType, payload)
 }
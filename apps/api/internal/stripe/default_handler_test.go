@@ -3,21 +3,34 @@ package stripe
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
-	"os"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"context"
 	"encoding/json"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/entitlement"
+	"github.com/real-staging-ai/api/internal/notification"
 	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/teamwebhook"
+	"github.com/real-staging-ai/api/internal/webhookarchive"
 )
 
 // helper to build Stripe-Signature header string with given timestamp and one or more v1 signatures.
@@ -209,6 +222,13 @@ func (s *simpleDB) Close() {}
 
 func (s *simpleDB) Pool() storage.PgxPool { return nil }
 
+func (s *simpleDB) CopyFrom(context.Context, pgx.Identifier, []string, pgx.CopyFromSource) (int64, error) {
+	return 0, nil
+}
+func (s *simpleDB) WithTx(ctx context.Context, fn func(storage.Database) error) error {
+	return fn(s)
+}
+
 func (s *simpleDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
 	return okRow{}
 }
@@ -222,16 +242,16 @@ func (s *simpleDB) Exec(ctx context.Context, sql string, args ...interface{}) (p
 }
 
 func Test_handleSubscriptionCreated_DB_Branch(t *testing.T) {
-	h := NewDefaultHandler(&simpleDB{})
+	h := NewDefaultHandler(&simpleDB{}, config.Stripe{}, config.App{})
 
 	evt := StripeEvent{
-		Data: map[string]interface{}{
+		Data: eventData(map[string]interface{}{
 			"object": map[string]interface{}{
 				"customer": "cus_1",
 				"id":       "sub_1",
 				"status":   "active",
 			},
-		},
+		}),
 	}
 
 	if err := h.handleSubscriptionCreated(context.Background(), &evt); err != nil {
@@ -239,17 +259,113 @@ func Test_handleSubscriptionCreated_DB_Branch(t *testing.T) {
 	}
 }
 
+// userFoundDB answers every query with a row whose leading UUID column is id.
+type userFoundDB struct {
+	simpleDB
+	id uuid.UUID
+}
+
+func (u *userFoundDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return idRow{id: u.id}
+}
+
+type idRow struct{ id uuid.UUID }
+
+func (r idRow) Scan(dest ...any) error {
+	if id, ok := dest[0].(*pgtype.UUID); ok {
+		*id = pgtype.UUID{Bytes: r.id, Valid: true}
+	}
+	return nil
+}
+
+func Test_persistSubscription_InvalidatesEntitlements(t *testing.T) {
+	userID := uuid.New()
+	entitlements := &entitlement.ServiceMock{
+		InvalidateFunc: func(ctx context.Context, userID string) error { return errors.New("redis down") },
+	}
+	h := NewDefaultHandler(&userFoundDB{id: userID}, config.Stripe{}, config.App{}, WithEntitlements(entitlements))
+
+	// A failed invalidation is logged; the subscription is already stored.
+	err := h.persistSubscription(context.Background(),
+		&Subscription{ID: "sub_1", CustomerID: "cus_1", Status: "canceled"}, "canceled", "deleted")
+	require.NoError(t, err)
+	require.Len(t, entitlements.InvalidateCalls(), 1)
+	assert.Equal(t, userID.String(), entitlements.InvalidateCalls()[0].UserID)
+}
+
+// txDB runs WithTx against a transaction that resolves every Stripe customer to userID,
+// failing the commit with commitErr. committed reports whether the transaction committed.
+func txDB(userID uuid.UUID, commitErr error, committed *bool) *storage.DatabaseMock {
+	tx := &storage.DatabaseMock{
+		QueryRowFunc: func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+			return userIDRow{id: pgtype.UUID{Bytes: userID, Valid: true}}
+		},
+	}
+	return &storage.DatabaseMock{
+		WithTxFunc: func(ctx context.Context, fn func(tx storage.Database) error) error {
+			if err := fn(tx); err != nil {
+				return err
+			}
+			if commitErr != nil {
+				return commitErr
+			}
+			*committed = true
+			return nil
+		},
+	}
+}
+
+func TestWebhook_SubscriptionDeleted_InvalidatesAfterCommit(t *testing.T) {
+	testCases := []struct {
+		name           string
+		commitErr      error
+		wantCode       int
+		wantInvalidate bool
+	}{
+		{name: "success: invalidates once committed", wantCode: http.StatusOK, wantInvalidate: true},
+		{name: "fail: failed commit leaves the cache alone", commitErr: errors.New("serialization failure"),
+			wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userID := uuid.New()
+			var committed bool
+			entitlements := &entitlement.ServiceMock{
+				InvalidateFunc: func(ctx context.Context, id string) error {
+					assert.True(t, committed, "invalidated before the subscription committed")
+					return nil
+				},
+			}
+			db := txDB(userID, tc.commitErr, &committed)
+			h := NewDefaultHandler(db, config.Stripe{}, config.App{}, WithEntitlements(entitlements))
+			body := makeEvent("customer.subscription.deleted", map[string]any{"id": "sub_1", "customer": "cus_1"})
+			c, rec := newEchoCtx(http.MethodPost, body, nil)
+
+			require.NoError(t, h.Webhook(c))
+			assert.Equal(t, tc.wantCode, rec.Code)
+			require.Len(t, db.WithTxCalls(), 1)
+			if !tc.wantInvalidate {
+				assert.Empty(t, entitlements.InvalidateCalls())
+				return
+			}
+			require.Len(t, entitlements.InvalidateCalls(), 1)
+			assert.Equal(t, userID.String(), entitlements.InvalidateCalls()[0].UserID)
+		})
+	}
+}
+
 func Test_handleSubscriptionUpdated_DB_Branch(t *testing.T) {
-	h := NewDefaultHandler(&simpleDB{})
+	h := NewDefaultHandler(&simpleDB{}, config.Stripe{}, config.App{})
 
 	evt := StripeEvent{
-		Data: map[string]interface{}{
+		Data: eventData(map[string]interface{}{
 			"object": map[string]interface{}{
 				"customer": "cus_2",
 				"id":       "sub_2",
 				"status":   "past_due",
 			},
-		},
+		}),
 	}
 
 	if err := h.handleSubscriptionUpdated(context.Background(), &evt); err != nil {
@@ -258,15 +374,15 @@ func Test_handleSubscriptionUpdated_DB_Branch(t *testing.T) {
 }
 
 func Test_handleSubscriptionDeleted_DB_Branch(t *testing.T) {
-	h := NewDefaultHandler(&simpleDB{})
+	h := NewDefaultHandler(&simpleDB{}, config.Stripe{}, config.App{})
 
 	evt := StripeEvent{
-		Data: map[string]interface{}{
+		Data: eventData(map[string]interface{}{
 			"object": map[string]interface{}{
 				"customer": "cus_3",
 				"id":       "sub_3",
 			},
-		},
+		}),
 	}
 
 	if err := h.handleSubscriptionDeleted(context.Background(), &evt); err != nil {
@@ -275,10 +391,10 @@ func Test_handleSubscriptionDeleted_DB_Branch(t *testing.T) {
 }
 
 func Test_handleInvoicePaymentSucceeded_DB_Branch(t *testing.T) {
-	h := NewDefaultHandler(&simpleDB{})
+	h := NewDefaultHandler(&simpleDB{}, config.Stripe{}, config.App{})
 
 	evt := StripeEvent{
-		Data: map[string]interface{}{
+		Data: eventData(map[string]interface{}{
 			"object": map[string]interface{}{
 				"id":           "in_1",
 				"customer":     "cus_1",
@@ -289,7 +405,7 @@ func Test_handleInvoicePaymentSucceeded_DB_Branch(t *testing.T) {
 				"currency":     "usd",
 				"number":       "F-1001",
 			},
-		},
+		}),
 	}
 
 	if err := h.handleInvoicePaymentSucceeded(context.Background(), &evt); err != nil {
@@ -298,10 +414,10 @@ func Test_handleInvoicePaymentSucceeded_DB_Branch(t *testing.T) {
 }
 
 func Test_handleInvoicePaymentFailed_DB_Branch(t *testing.T) {
-	h := NewDefaultHandler(&simpleDB{})
+	h := NewDefaultHandler(&simpleDB{}, config.Stripe{}, config.App{})
 
 	evt := StripeEvent{
-		Data: map[string]interface{}{
+		Data: eventData(map[string]interface{}{
 			"object": map[string]interface{}{
 				"id":           "in_2",
 				"customer":     "cus_2",
@@ -312,7 +428,7 @@ func Test_handleInvoicePaymentFailed_DB_Branch(t *testing.T) {
 				"currency":     "usd",
 				"number":       "F-1002",
 			},
-		},
+		}),
 	}
 
 	if err := h.handleInvoicePaymentFailed(context.Background(), &evt); err != nil {
@@ -327,6 +443,13 @@ type userNotFoundDB struct{}
 
 func (u *userNotFoundDB) Close()                {}
 func (u *userNotFoundDB) Pool() storage.PgxPool { return nil }
+
+func (u *userNotFoundDB) CopyFrom(context.Context, pgx.Identifier, []string, pgx.CopyFromSource) (int64, error) {
+	return 0, nil
+}
+func (u *userNotFoundDB) WithTx(ctx context.Context, fn func(storage.Database) error) error {
+	return fn(u)
+}
 func (u *userNotFoundDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
 	return errRow{}
 }
@@ -338,42 +461,269 @@ func (u *userNotFoundDB) Exec(ctx context.Context, sql string, args ...interface
 }
 
 func Test_handleCheckoutSessionCompleted_UserNotFound_OK(t *testing.T) {
-	h := NewDefaultHandler(&userNotFoundDB{})
+	h := NewDefaultHandler(&userNotFoundDB{}, config.Stripe{}, config.App{})
 	evt := StripeEvent{
-		Data: map[string]interface{}{
+		Data: eventData(map[string]interface{}{
 			"object": map[string]interface{}{
 				"id":                  "cs_unf",
 				"customer":            "cus_unf",
 				"payment_status":      "paid",
 				"client_reference_id": "auth0|missing",
 			},
-		},
+		}),
 	}
 	if err := h.handleCheckoutSessionCompleted(context.Background(), &evt); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
 
+func Test_handleCheckoutSessionCompleted_FetchesSubscription(t *testing.T) {
+	tests := []struct {
+		name   string
+		subErr error
+	}{
+		{name: "success: subscription fetched and persisted"},
+		{name: "success: fetch error is logged, not returned", subErr: errors.New("stripe down")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &ClientMock{
+				GetSubscriptionFunc: func(ctx context.Context, id string) (*Subscription, error) {
+					if tt.subErr != nil {
+						return nil, tt.subErr
+					}
+					return &Subscription{ID: id, CustomerID: "cus_1", Status: "active"}, nil
+				},
+			}
+			h := NewDefaultHandler(&userNotFoundDB{}, config.Stripe{}, config.App{})
+			h.client = client
+			evt := StripeEvent{
+				Data: eventData(map[string]interface{}{
+					"object": map[string]interface{}{
+						"id":                  "cs_1",
+						"customer":            "cus_1",
+						"subscription":        "sub_1",
+						"client_reference_id": "auth0|u1",
+					},
+				}),
+			}
+			if err := h.handleCheckoutSessionCompleted(context.Background(), &evt); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if calls := client.GetSubscriptionCalls(); len(calls) != 1 || calls[0].ID != "sub_1" {
+				t.Fatalf("expected one fetch of sub_1, got %+v", calls)
+			}
+		})
+	}
+}
+
+func Test_handleCheckoutSessionCompleted_ExportCredit(t *testing.T) {
+	userID := uuid.New()
+	tests := []struct {
+		name          string
+		eventType     string
+		paymentStatus string
+		userMissing   bool
+		execErr       error
+		wantCredit    bool
+		wantErr       bool
+	}{
+		{name: "success: paid checkout records a credit", paymentStatus: "paid", wantCredit: true},
+		{name: "success: async payment records a credit", eventType: "checkout.session.async_payment_succeeded",
+			paymentStatus: "paid", wantCredit: true},
+		{name: "success: unpaid checkout waits for the async payment", paymentStatus: "unpaid"},
+		{name: "success: unknown user is logged", paymentStatus: "paid", userMissing: true},
+		{name: "fail: insert error is returned for redelivery", paymentStatus: "paid",
+			execErr: errors.New("db down"), wantCredit: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &storage.DatabaseMock{
+				QueryRowFunc: func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+					if tt.userMissing {
+						return errRow{}
+					}
+					return userIDRow{id: pgtype.UUID{Bytes: userID, Valid: true}}
+				},
+				ExecFunc: func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+					return pgconn.NewCommandTag("INSERT 0 1"), tt.execErr
+				},
+			}
+			h := NewDefaultHandler(db, config.Stripe{}, config.App{})
+			evt := StripeEvent{
+				Type: tt.eventType,
+				Data: eventData(map[string]interface{}{
+					"object": map[string]interface{}{
+						"id":                  "cs_export",
+						"mode":                "payment",
+						"payment_status":      tt.paymentStatus,
+						"client_reference_id": "auth0|u1",
+						"metadata":            map[string]string{MetadataPurpose: PurposeExportCredit},
+					},
+				}),
+			}
+
+			var err error
+			if tt.eventType != "" {
+				err = h.handleCheckoutAsyncPaymentSucceeded(context.Background(), &evt)
+			} else {
+				err = h.handleCheckoutSessionCompleted(context.Background(), &evt)
+			}
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			if !tt.wantCredit {
+				assert.Empty(t, db.ExecCalls())
+				return
+			}
+			require.Len(t, db.ExecCalls(), 1)
+			call := db.ExecCalls()[0]
+			assert.Equal(t, queries.CreateExportCredit, call.SQL)
+			assert.Equal(t, []interface{}{pgtype.UUID{Bytes: userID, Valid: true}, "cs_export"}, call.Args)
+		})
+	}
+}
+
+func Test_handleSubscriptionCreated_WrongFieldType_Error(t *testing.T) {
+	h := NewDefaultHandler(&userNotFoundDB{}, config.Stripe{}, config.App{})
+	evt := StripeEvent{
+		Data: eventData(map[string]interface{}{
+			"object": map[string]interface{}{
+				"id":       "sub_1",
+				"customer": "cus_1",
+				"items":    "not_a_list",
+			},
+		}),
+	}
+	if err := h.handleSubscriptionCreated(context.Background(), &evt); err == nil {
+		t.Fatal("expected error for mistyped items")
+	}
+}
+
 func Test_handleSubscriptionCreated_UserNotFound_OK(t *testing.T) {
-	h := NewDefaultHandler(&userNotFoundDB{})
+	h := NewDefaultHandler(&userNotFoundDB{}, config.Stripe{}, config.App{})
 	evt := StripeEvent{
-		Data: map[string]interface{}{
+		Data: eventData(map[string]interface{}{
 			"object": map[string]interface{}{
 				"customer": "cus_unf",
 				"id":       "sub_unf",
 				"status":   "active",
 			},
-		},
+		}),
 	}
 	if err := h.handleSubscriptionCreated(context.Background(), &evt); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
 
+// parkingDB finds no users and records the statements executed, failing them with execErr.
+type parkingDB struct {
+	userNotFoundDB
+	execs   [][]interface{}
+	execErr error
+}
+
+func (p *parkingDB) WithTx(ctx context.Context, fn func(storage.Database) error) error {
+	return fn(p)
+}
+
+func (p *parkingDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	if strings.Contains(sql, "INSERT INTO pending_billing_events") {
+		p.execs = append(p.execs, args)
+	}
+	return pgconn.CommandTag{}, p.execErr
+}
+
+func Test_persist_UserNotFound_Parks(t *testing.T) {
+	tests := []struct {
+		name       string
+		handle     func(h *DefaultHandler, evt *StripeEvent) error
+		object     map[string]interface{}
+		execErr    error
+		wantErr    bool
+		wantID     string
+		wantType   string
+		wantEvent  string
+		wantFields map[string]interface{}
+	}{
+		{
+			name: "success: deleted subscription parked as canceled",
+			handle: func(h *DefaultHandler, evt *StripeEvent) error {
+				return h.handleSubscriptionDeleted(context.Background(), evt)
+			},
+			object: map[string]interface{}{
+				"id": "sub_unf", "customer": "cus_unf", "status": "active", "cancel_at_period_end": true,
+				"current_period_end": float64(1700000000),
+			},
+			wantID:    "sub_unf",
+			wantType:  "subscription",
+			wantEvent: "subscription.deleted",
+			wantFields: map[string]interface{}{
+				"status": "canceled", "cancel_at_period_end": true, "current_period_end": "2023-11-14T22:13:20Z",
+			},
+		},
+		{
+			name: "success: paid invoice parked",
+			handle: func(h *DefaultHandler, evt *StripeEvent) error {
+				return h.handleInvoicePaymentSucceeded(context.Background(), evt)
+			},
+			object: map[string]interface{}{
+				"id": "in_unf", "customer": "cus_unf", "subscription": "sub_unf",
+				"amount_due": float64(900), "amount_paid": float64(900), "currency": "usd", "number": "F-1",
+			},
+			wantID:    "in_unf",
+			wantType:  "invoice",
+			wantEvent: "invoice.payment_succeeded",
+			wantFields: map[string]interface{}{
+				"status": "paid", "amount_paid": float64(900), "stripe_subscription_id": "sub_unf", "invoice_number": "F-1",
+			},
+		},
+		{
+			name: "fail: park error is returned so Stripe redelivers",
+			handle: func(h *DefaultHandler, evt *StripeEvent) error {
+				return h.handleSubscriptionCreated(context.Background(), evt)
+			},
+			object:  map[string]interface{}{"id": "sub_unf", "customer": "cus_unf", "status": "active"},
+			execErr: errors.New("db down"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &parkingDB{execErr: tt.execErr}
+			h := NewDefaultHandler(db, config.Stripe{}, config.App{})
+			evt := StripeEvent{Data: eventData(map[string]interface{}{"object": tt.object})}
+
+			err := tt.handle(h, &evt)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, db.execs, 1)
+			args := db.execs[0]
+			assert.Equal(t, tt.wantID, args[0])
+			assert.Equal(t, tt.wantType, args[1])
+			assert.Equal(t, tt.wantEvent, args[2])
+			assert.Equal(t, "cus_unf", args[3])
+
+			var data map[string]interface{}
+			require.NoError(t, json.Unmarshal(args[5].([]byte), &data))
+			for k, v := range tt.wantFields {
+				assert.Equal(t, v, data[k], k)
+			}
+			expires := args[6].(pgtype.Timestamptz).Time
+			assert.WithinDuration(t, time.Now().Add(PendingEventTTL), expires, time.Minute)
+		})
+	}
+}
+
 func Test_handleInvoicePaymentSucceeded_UserNotFound_OK(t *testing.T) {
-	h := NewDefaultHandler(&userNotFoundDB{})
+	h := NewDefaultHandler(&userNotFoundDB{}, config.Stripe{}, config.App{})
 	evt := StripeEvent{
-		Data: map[string]interface{}{
+		Data: eventData(map[string]interface{}{
 			"object": map[string]interface{}{
 				"id":           "in_unf",
 				"customer":     "cus_unf",
@@ -384,7 +734,7 @@ func Test_handleInvoicePaymentSucceeded_UserNotFound_OK(t *testing.T) {
 				"currency":     "usd",
 				"number":       "F-UNF",
 			},
-		},
+		}),
 	}
 	if err := h.handleInvoicePaymentSucceeded(context.Background(), &evt); err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -402,6 +752,14 @@ func newEchoCtx(method string, body []byte, headers map[string]string) (echo.Con
 	return e.NewContext(req, rec), rec
 }
 
+// eventData encodes data, a map holding the event's "object", as it arrives in a webhook.
+func eventData(data map[string]interface{}) EventData {
+	b, _ := json.Marshal(data)
+	var d EventData
+	_ = json.Unmarshal(b, &d)
+	return d
+}
+
 func makeEvent(typ string, object map[string]any) []byte {
 	body := map[string]any{
 		"id":      "evt_test",
@@ -425,13 +783,21 @@ type errRow struct{}
 
 func (errRow) Scan(dest ...any) error { return pgx.ErrNoRows }
 
-// fakeDBAlreadyProcessed makes alreadyProcessedStripeEvent return true.
+// fakeDBAlreadyProcessed makes claimStripeEvent report a conflict (the event was already claimed).
 type fakeDBAlreadyProcessed struct{}
 
 func (f *fakeDBAlreadyProcessed) Close()                {}
 func (f *fakeDBAlreadyProcessed) Pool() storage.PgxPool { return nil }
+
+func (f *fakeDBAlreadyProcessed) CopyFrom(context.Context, pgx.Identifier, []string, pgx.CopyFromSource) (int64, error) {
+	return 0, nil
+}
+func (f *fakeDBAlreadyProcessed) WithTx(ctx context.Context, fn func(storage.Database) error) error {
+	return fn(f)
+}
 func (f *fakeDBAlreadyProcessed) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
-	return okRow{}
+	// INSERT ... ON CONFLICT DO NOTHING RETURNING yields no row on conflict.
+	return errRow{}
 }
 func (f *fakeDBAlreadyProcessed) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
 	return nil, nil
@@ -440,38 +806,48 @@ func (f *fakeDBAlreadyProcessed) Exec(ctx context.Context, sql string, args ...i
 	return pgconn.CommandTag{}, nil
 }
 
-// fakeDBIdemFirstMissingThenUpsertOK simulates IsProcessed=false then Upsert success.
-type fakeDBIdemFirstMissingThenUpsertOK struct {
-	calls int
+// fakeDBClaimOK makes claimStripeEvent succeed and records every statement and transaction outcome.
+type fakeDBClaimOK struct {
+	calls     int
+	execSQLs  []string
+	commits   int
+	rollbacks int
 }
 
-func (f *fakeDBIdemFirstMissingThenUpsertOK) Close()                {}
-func (f *fakeDBIdemFirstMissingThenUpsertOK) Pool() storage.PgxPool { return nil }
-func (f *fakeDBIdemFirstMissingThenUpsertOK) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
-	f.calls++
-	if f.calls == 1 {
-		// First call for GetProcessedEventByStripeID -> not found
-		return errRow{}
+func (f *fakeDBClaimOK) Close()                {}
+func (f *fakeDBClaimOK) Pool() storage.PgxPool { return nil }
+
+func (f *fakeDBClaimOK) CopyFrom(context.Context, pgx.Identifier, []string, pgx.CopyFromSource) (int64, error) {
+	return 0, nil
+}
+func (f *fakeDBClaimOK) WithTx(ctx context.Context, fn func(storage.Database) error) error {
+	if err := fn(f); err != nil {
+		f.rollbacks++
+		return err
 	}
-	// Second call for UpsertProcessedEventByStripeID -> ok
+	f.commits++
+	return nil
+}
+func (f *fakeDBClaimOK) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	f.calls++
 	return okRow{}
 }
-func (f *fakeDBIdemFirstMissingThenUpsertOK) Query(
+func (f *fakeDBClaimOK) Query(
 	ctx context.Context, sql string, args ...interface{},
 ) (pgx.Rows, error) {
 	return nil, nil
 }
-func (f *fakeDBIdemFirstMissingThenUpsertOK) Exec(
+func (f *fakeDBClaimOK) Exec(
 	ctx context.Context, sql string, args ...interface{},
 ) (pgconn.CommandTag, error) {
+	f.execSQLs = append(f.execSQLs, sql)
 	return pgconn.CommandTag{}, nil
 }
 
 // ---------------------------- Webhook tests ----------------------------
 
 func TestWebhook_EmptyBody_BadRequest(t *testing.T) {
-	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, config.Stripe{}, config.App{})
 	c, rec := newEchoCtx(http.MethodPost, []byte{}, nil)
 
 	if err := h.Webhook(c); err != nil {
@@ -483,8 +859,7 @@ func TestWebhook_EmptyBody_BadRequest(t *testing.T) {
 }
 
 func TestWebhook_InvalidJSON_BadRequest(t *testing.T) {
-	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, config.Stripe{}, config.App{})
 	body := []byte("{invalid json")
 	c, rec := newEchoCtx(http.MethodPost, body, nil)
 
@@ -497,8 +872,7 @@ func TestWebhook_InvalidJSON_BadRequest(t *testing.T) {
 }
 
 func TestWebhook_UnhandledType_OK(t *testing.T) {
-	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, config.Stripe{}, config.App{})
 
 	body := makeEvent("unhandled.event", map[string]any{"x": 1})
 	c, rec := newEchoCtx(http.MethodPost, body, nil)
@@ -516,8 +890,7 @@ func TestWebhook_UnhandledType_OK(t *testing.T) {
 
 func TestWebhook_Signature_MissingHeader_Unauthorized(t *testing.T) {
 	secret := "whsec_test"
-	t.Setenv("STRIPE_WEBHOOK_SECRET", secret)
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, config.Stripe{WebhookSecret: secret}, config.App{})
 
 	body := makeEvent("customer.created", map[string]any{"id": "cus_123"})
 	c, rec := newEchoCtx(http.MethodPost, body, nil)
@@ -533,8 +906,7 @@ func TestWebhook_Signature_MissingHeader_Unauthorized(t *testing.T) {
 
 func TestWebhook_Signature_Valid_OK(t *testing.T) {
 	secret := "whsec_test"
-	t.Setenv("STRIPE_WEBHOOK_SECRET", secret)
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, config.Stripe{WebhookSecret: secret}, config.App{})
 
 	body := makeEvent("customer.created", map[string]any{"id": "cus_123", "email": "a@b"})
 	ts := time.Now().Unix()
@@ -555,6 +927,31 @@ func TestWebhook_Signature_Valid_OK(t *testing.T) {
 	}
 }
 
+func TestWebhook_Signature_ConfiguredTolerance(t *testing.T) {
+	secret := "whsec_test"
+	body := makeEvent("customer.created", map[string]any{"id": "cus_123"})
+	ts := time.Now().Add(-10 * time.Minute).Unix()
+	headers := map[string]string{
+		"Stripe-Signature": makeSigHeader(ts, computeStripeSignature(body, ts, secret)),
+	}
+
+	h := NewDefaultHandler(nil, config.Stripe{WebhookSecret: secret}, config.App{Env: "prod"})
+	c, rec := newEchoCtx(http.MethodPost, body, headers)
+	_ = h.Webhook(c)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 outside the default tolerance, got %d", rec.Code)
+	}
+
+	h = NewDefaultHandler(nil, config.Stripe{WebhookSecret: secret, WebhookTolerance: 15 * time.Minute}, config.App{})
+	c, rec = newEchoCtx(http.MethodPost, body, headers)
+	if err := h.Webhook(c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 within the configured tolerance, got %d", rec.Code)
+	}
+}
+
 // ---------------------------- Additional edge-case tests ----------------------------
 
 type badReader struct{}
@@ -562,8 +959,7 @@ type badReader struct{}
 func (badReader) Read(p []byte) (int, error) { return 0, fmt.Errorf("read error") }
 
 func TestWebhook_ReadBodyError_BadRequest(t *testing.T) {
-	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, config.Stripe{}, config.App{})
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/stripe/webhook", badReader{})
@@ -589,6 +985,13 @@ func (f *fakeDBIdemError) Close() {}
 
 func (f *fakeDBIdemError) Pool() storage.PgxPool { return nil }
 
+func (f *fakeDBIdemError) CopyFrom(context.Context, pgx.Identifier, []string, pgx.CopyFromSource) (int64, error) {
+	return 0, nil
+}
+func (f *fakeDBIdemError) WithTx(ctx context.Context, fn func(storage.Database) error) error {
+	return fn(f)
+}
+
 func (f *fakeDBIdemError) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
 	return failingRow{}
 }
@@ -602,8 +1005,7 @@ func (f *fakeDBIdemError) Exec(ctx context.Context, sql string, args ...interfac
 }
 
 func TestWebhook_IdempotencyError_500(t *testing.T) {
-	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(&fakeDBIdemError{})
+	h := NewDefaultHandler(&fakeDBIdemError{}, config.Stripe{}, config.App{})
 
 	body := makeEvent("unhandled.event", map[string]any{"x": "y"})
 	c, rec := newEchoCtx(http.MethodPost, body, nil)
@@ -617,16 +1019,16 @@ func TestWebhook_IdempotencyError_500(t *testing.T) {
 }
 
 func Test_handleCheckoutSessionCompleted_DB_Branch(t *testing.T) {
-	h := NewDefaultHandler(&simpleDB{})
+	h := NewDefaultHandler(&simpleDB{}, config.Stripe{}, config.App{})
 	evt := StripeEvent{
-		Data: map[string]interface{}{
+		Data: eventData(map[string]interface{}{
 			"object": map[string]interface{}{
 				"id":                  "cs_123",
 				"customer":            "cus_link",
 				"payment_status":      "paid",
 				"client_reference_id": "auth0|user123",
 			},
-		},
+		}),
 	}
 	if err := h.handleCheckoutSessionCompleted(context.Background(), &evt); err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -634,11 +1036,11 @@ func Test_handleCheckoutSessionCompleted_DB_Branch(t *testing.T) {
 }
 
 func Test_handleCheckoutSessionCompleted_InvalidData(t *testing.T) {
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, config.Stripe{}, config.App{})
 	evt := StripeEvent{
-		Data: map[string]interface{}{
+		Data: eventData(map[string]interface{}{
 			"object": "not_a_map",
-		},
+		}),
 	}
 	if err := h.handleCheckoutSessionCompleted(context.Background(), &evt); err == nil {
 		t.Fatalf("expected error, got nil")
@@ -646,11 +1048,11 @@ func Test_handleCheckoutSessionCompleted_InvalidData(t *testing.T) {
 }
 
 func Test_handleSubscriptionCreated_InvalidData(t *testing.T) {
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, config.Stripe{}, config.App{})
 	evt := StripeEvent{
-		Data: map[string]interface{}{
+		Data: eventData(map[string]interface{}{
 			"object": "not_a_map",
-		},
+		}),
 	}
 	if err := h.handleSubscriptionCreated(context.Background(), &evt); err == nil {
 		t.Fatalf("expected error, got nil")
@@ -658,11 +1060,11 @@ func Test_handleSubscriptionCreated_InvalidData(t *testing.T) {
 }
 
 func Test_handleInvoicePaymentSucceeded_InvalidData(t *testing.T) {
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, config.Stripe{}, config.App{})
 	evt := StripeEvent{
-		Data: map[string]interface{}{
+		Data: eventData(map[string]interface{}{
 			"object": "not_a_map",
-		},
+		}),
 	}
 	if err := h.handleInvoicePaymentSucceeded(context.Background(), &evt); err == nil {
 		t.Fatalf("expected error, got nil")
@@ -670,11 +1072,11 @@ func Test_handleInvoicePaymentSucceeded_InvalidData(t *testing.T) {
 }
 
 func Test_handleCustomerCreated_InvalidData(t *testing.T) {
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, config.Stripe{}, config.App{})
 	evt := StripeEvent{
-		Data: map[string]interface{}{
+		Data: eventData(map[string]interface{}{
 			"object": "not_a_map",
-		},
+		}),
 	}
 	if err := h.handleCustomerCreated(context.Background(), &evt); err == nil {
 		t.Fatalf("expected error, got nil")
@@ -682,11 +1084,11 @@ func Test_handleCustomerCreated_InvalidData(t *testing.T) {
 }
 
 func Test_handleCustomerUpdated_InvalidData(t *testing.T) {
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, config.Stripe{}, config.App{})
 	evt := StripeEvent{
-		Data: map[string]interface{}{
+		Data: eventData(map[string]interface{}{
 			"object": "not_a_map",
-		},
+		}),
 	}
 	if err := h.handleCustomerUpdated(context.Background(), &evt); err == nil {
 		t.Fatalf("expected error, got nil")
@@ -694,11 +1096,11 @@ func Test_handleCustomerUpdated_InvalidData(t *testing.T) {
 }
 
 func Test_handleCustomerDeleted_InvalidData(t *testing.T) {
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, config.Stripe{}, config.App{})
 	evt := StripeEvent{
-		Data: map[string]interface{}{
+		Data: eventData(map[string]interface{}{
 			"object": "not_a_map",
-		},
+		}),
 	}
 	if err := h.handleCustomerDeleted(context.Background(), &evt); err == nil {
 		t.Fatalf("expected error, got nil")
@@ -706,11 +1108,11 @@ func Test_handleCustomerDeleted_InvalidData(t *testing.T) {
 }
 
 func Test_handleSubscriptionUpdated_InvalidData(t *testing.T) {
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, config.Stripe{}, config.App{})
 	evt := StripeEvent{
-		Data: map[string]interface{}{
+		Data: eventData(map[string]interface{}{
 			"object": "not_a_map",
-		},
+		}),
 	}
 	if err := h.handleSubscriptionUpdated(context.Background(), &evt); err == nil {
 		t.Fatalf("expected error, got nil")
@@ -718,11 +1120,11 @@ func Test_handleSubscriptionUpdated_InvalidData(t *testing.T) {
 }
 
 func Test_handleSubscriptionDeleted_InvalidData(t *testing.T) {
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, config.Stripe{}, config.App{})
 	evt := StripeEvent{
-		Data: map[string]interface{}{
+		Data: eventData(map[string]interface{}{
 			"object": "not_a_map",
-		},
+		}),
 	}
 	if err := h.handleSubscriptionDeleted(context.Background(), &evt); err == nil {
 		t.Fatalf("expected error, got nil")
@@ -730,11 +1132,11 @@ func Test_handleSubscriptionDeleted_InvalidData(t *testing.T) {
 }
 
 func Test_handleInvoicePaymentFailed_InvalidData(t *testing.T) {
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, config.Stripe{}, config.App{})
 	evt := StripeEvent{
-		Data: map[string]interface{}{
+		Data: eventData(map[string]interface{}{
 			"object": "not_a_map",
-		},
+		}),
 	}
 	if err := h.handleInvoicePaymentFailed(context.Background(), &evt); err == nil {
 		t.Fatalf("expected error, got nil")
@@ -742,8 +1144,7 @@ func Test_handleInvoicePaymentFailed_InvalidData(t *testing.T) {
 }
 
 func TestWebhook_CheckoutSessionCompleted_OK(t *testing.T) {
-	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, config.Stripe{}, config.App{})
 
 	obj := map[string]any{"id": "cs_123", "customer": "cus_1", "payment_status": "paid", "client_reference_id": "auth0|u1"}
 	body := makeEvent("checkout.session.completed", obj)
@@ -758,8 +1159,7 @@ func TestWebhook_CheckoutSessionCompleted_OK(t *testing.T) {
 }
 
 func TestWebhook_SubscriptionCreated_OK(t *testing.T) {
-	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, config.Stripe{}, config.App{})
 
 	obj := map[string]any{"id": "sub_1", "customer": "cus_1", "status": "active"}
 	body := makeEvent("customer.subscription.created", obj)
@@ -774,8 +1174,7 @@ func TestWebhook_SubscriptionCreated_OK(t *testing.T) {
 }
 
 func TestWebhook_SubscriptionUpdated_OK(t *testing.T) {
-	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, config.Stripe{}, config.App{})
 
 	obj := map[string]any{"id": "sub_2", "customer": "cus_2", "status": "past_due"}
 	body := makeEvent("customer.subscription.updated", obj)
@@ -790,8 +1189,7 @@ func TestWebhook_SubscriptionUpdated_OK(t *testing.T) {
 }
 
 func TestWebhook_SubscriptionDeleted_OK(t *testing.T) {
-	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, config.Stripe{}, config.App{})
 
 	obj := map[string]any{"id": "sub_3", "customer": "cus_3"}
 	body := makeEvent("customer.subscription.deleted", obj)
@@ -806,8 +1204,7 @@ func TestWebhook_SubscriptionDeleted_OK(t *testing.T) {
 }
 
 func TestWebhook_InvoicePaymentSucceeded_OK(t *testing.T) {
-	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, config.Stripe{}, config.App{})
 
 	obj := map[string]any{
 		"id": "in_1", "customer": "cus_1", "subscription": "sub_1", "status": "paid",
@@ -825,8 +1222,7 @@ func TestWebhook_InvoicePaymentSucceeded_OK(t *testing.T) {
 }
 
 func TestWebhook_InvoicePaymentFailed_OK(t *testing.T) {
-	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, config.Stripe{}, config.App{})
 
 	obj := map[string]any{
 		"id": "in_2", "customer": "cus_2", "subscription": "sub_2", "status": "failed",
@@ -844,8 +1240,7 @@ func TestWebhook_InvoicePaymentFailed_OK(t *testing.T) {
 }
 
 func TestWebhook_CustomerCreated_OK(t *testing.T) {
-	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, config.Stripe{}, config.App{})
 
 	obj := map[string]any{"id": "cus_x", "email": "x@y"}
 	body := makeEvent("customer.created", obj)
@@ -860,8 +1255,7 @@ func TestWebhook_CustomerCreated_OK(t *testing.T) {
 }
 
 func TestWebhook_CustomerUpdated_OK(t *testing.T) {
-	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, config.Stripe{}, config.App{})
 
 	obj := map[string]any{"id": "cus_y", "email": "y@z"}
 	body := makeEvent("customer.updated", obj)
@@ -876,8 +1270,7 @@ func TestWebhook_CustomerUpdated_OK(t *testing.T) {
 }
 
 func TestWebhook_CustomerDeleted_OK(t *testing.T) {
-	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, config.Stripe{}, config.App{})
 
 	obj := map[string]any{"id": "cus_z"}
 	body := makeEvent("customer.deleted", obj)
@@ -892,8 +1285,7 @@ func TestWebhook_CustomerDeleted_OK(t *testing.T) {
 }
 
 func TestWebhook_Idempotent_Duplicate(t *testing.T) {
-	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(&fakeDBAlreadyProcessed{})
+	h := NewDefaultHandler(&fakeDBAlreadyProcessed{}, config.Stripe{}, config.App{})
 
 	body := makeEvent("customer.created", map[string]any{"id": "cus_dup"})
 	c, rec := newEchoCtx(http.MethodPost, body, nil)
@@ -909,9 +1301,8 @@ func TestWebhook_Idempotent_Duplicate(t *testing.T) {
 	}
 }
 
-func TestWebhook_MarkProcessed_DB_Success(t *testing.T) {
-	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(&fakeDBIdemFirstMissingThenUpsertOK{})
+func TestWebhook_Claim_DB_Success(t *testing.T) {
+	h := NewDefaultHandler(&fakeDBClaimOK{}, config.Stripe{}, config.App{})
 
 	body := makeEvent("unhandled.event", map[string]any{"x": "y"})
 	c, rec := newEchoCtx(http.MethodPost, body, nil)
@@ -927,11 +1318,10 @@ func TestWebhook_MarkProcessed_DB_Success(t *testing.T) {
 	}
 }
 
-// Ensure we actually attempt Upsert (IsProcessed + Upsert -> 2 QueryRow calls)
-func TestWebhook_MarkProcessed_Upsert_CalledTwice(t *testing.T) {
-	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	f := &fakeDBIdemFirstMissingThenUpsertOK{}
-	h := NewDefaultHandler(f)
+// The claim is a single INSERT ... ON CONFLICT DO NOTHING committed together with the event's side effects.
+func TestWebhook_Claim_SingleStatement(t *testing.T) {
+	f := &fakeDBClaimOK{}
+	h := NewDefaultHandler(f, config.Stripe{}, config.App{})
 
 	body := makeEvent("unhandled.event", map[string]any{"ok": true})
 	c, _ := newEchoCtx(http.MethodPost, body, nil)
@@ -939,67 +1329,98 @@ func TestWebhook_MarkProcessed_Upsert_CalledTwice(t *testing.T) {
 	if err := h.Webhook(c); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if f.calls != 2 {
-		t.Fatalf("expected 2 QueryRow calls (IsProcessed + Upsert), got %d", f.calls)
+	if f.calls != 1 {
+		t.Fatalf("expected 1 QueryRow call (claim), got %d", f.calls)
 	}
-}
-
-// fakeDBIdemUpsertError simulates IsProcessed=false then Upsert failing (mark processed error)
-// Webhook should still acknowledge with 200.
-type fakeDBIdemUpsertError struct {
-	calls int
-}
-
-func (f *fakeDBIdemUpsertError) Close()                {}
-func (f *fakeDBIdemUpsertError) Pool() storage.PgxPool { return nil }
-func (f *fakeDBIdemUpsertError) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
-	f.calls++
-	if f.calls == 1 {
-		// IsProcessed -> not found
-		return errRow{}
+	if f.commits != 1 || f.rollbacks != 0 {
+		t.Fatalf("expected claim and processing to commit once, got commits=%d rollbacks=%d", f.commits, f.rollbacks)
 	}
-	// Upsert -> error on scan
-	return failingRow{}
-}
-func (f *fakeDBIdemUpsertError) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
-	return nil, nil
-}
-func (f *fakeDBIdemUpsertError) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
-	return pgconn.CommandTag{}, nil
 }
 
-func TestWebhook_MarkProcessed_DB_UpsertError_OK(t *testing.T) {
-	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(&fakeDBIdemUpsertError{})
+// A failed event rolls back its claim so Stripe's retry is processed instead of reported as a duplicate.
+func TestWebhook_HandlerError_RollsBackClaim(t *testing.T) {
+	f := &fakeDBClaimOK{}
+	h := NewDefaultHandler(f, config.Stripe{}, config.App{})
 
-	body := makeEvent("unhandled.event", map[string]any{"x": "y"})
+	body := []byte(`{"id":"evt_fail","type":"checkout.session.completed","data":{"object":"not-an-object"}}`)
 	c, rec := newEchoCtx(http.MethodPost, body, nil)
 
 	if err := h.Webhook(c); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if rec.Code != http.StatusOK {
-		t.Fatalf("expected 200 even if mark-processed fails, got %d", rec.Code)
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", rec.Code)
 	}
-	if !contains(rec.Body.String(), "received") {
-		t.Fatalf("expected response to contain 'received', got %s", rec.Body.String())
+	if f.rollbacks != 1 || f.commits != 0 {
+		t.Fatalf("expected claim to be rolled back, got commits=%d rollbacks=%d", f.commits, f.rollbacks)
+	}
+}
+
+func TestWebhook_ConcurrentDuplicates_ProcessedOnce(t *testing.T) {
+	db := &fakeDBClaimOnce{}
+	h := NewDefaultHandler(db, config.Stripe{}, config.App{})
+
+	const deliveries = 8
+	codes := make(chan string, deliveries)
+	var wg sync.WaitGroup
+	for range deliveries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			body := makeEvent("unhandled.event", map[string]any{"x": 1})
+			c, rec := newEchoCtx(http.MethodPost, body, nil)
+			_ = h.Webhook(c)
+			codes <- rec.Body.String()
+		}()
+	}
+	wg.Wait()
+	close(codes)
+
+	received := 0
+	for body := range codes {
+		if contains(body, "received") {
+			received++
+		}
+	}
+	if received != 1 {
+		t.Fatalf("expected exactly one delivery to be processed, got %d", received)
 	}
 }
 
-// Enforce STRIPE_WEBHOOK_SECRET in non-dev envs (simulate non-dev by setting envs and tweaking os.Args[0])
+// fakeDBClaimOnce emulates the unique constraint on stripe_event_id: only the first claim gets a row.
+type fakeDBClaimOnce struct {
+	mu      sync.Mutex
+	claimed bool
+}
+
+func (f *fakeDBClaimOnce) Close()                {}
+func (f *fakeDBClaimOnce) Pool() storage.PgxPool { return nil }
+
+func (f *fakeDBClaimOnce) CopyFrom(context.Context, pgx.Identifier, []string, pgx.CopyFromSource) (int64, error) {
+	return 0, nil
+}
+func (f *fakeDBClaimOnce) WithTx(ctx context.Context, fn func(storage.Database) error) error {
+	return fn(f)
+}
+func (f *fakeDBClaimOnce) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.claimed {
+		return errRow{}
+	}
+	f.claimed = true
+	return okRow{}
+}
+func (f *fakeDBClaimOnce) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return nil, nil
+}
+func (f *fakeDBClaimOnce) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, nil
+}
+
+// Enforce the webhook secret in non-dev environments
 func TestWebhook_MissingSecret_NonDev_ServiceUnavailable(t *testing.T) {
-	// Force non-dev
-	t.Setenv("APP_ENV", "production")
-	t.Setenv("GO_ENV", "production")
-	t.Setenv("ENV", "production")
-	t.Setenv("NODE_ENV", "production")
-	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-
-	orig := os.Args[0]
-	os.Args[0] = "api-server" // avoid ".test" heuristic
-	defer func() { os.Args[0] = orig }()
-
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, config.Stripe{}, config.App{Env: "prod"})
 	body := makeEvent("customer.created", map[string]any{"id": "cus_nondev"})
 	c, rec := newEchoCtx(http.MethodPost, body, nil)
 
@@ -1015,11 +1436,11 @@ func TestWebhook_MissingSecret_NonDev_ServiceUnavailable(t *testing.T) {
 // Mapping edge-case: ensure subscription handler tolerates nested price/timestamps presence
 // even when user lookup fails (no-rows), exercising mapping paths.
 func Test_handleSubscriptionCreated_Mapping_PriceAndTimes_OK(t *testing.T) {
-	h := NewDefaultHandler(&userNotFoundDB{})
+	h := NewDefaultHandler(&userNotFoundDB{}, config.Stripe{}, config.App{})
 	now := float64(time.Now().Unix())
 
 	evt := StripeEvent{
-		Data: map[string]interface{}{
+		Data: eventData(map[string]interface{}{
 			"object": map[string]interface{}{
 				"customer": "cus_map",
 				"id":       "sub_map",
@@ -1037,7 +1458,7 @@ func Test_handleSubscriptionCreated_Mapping_PriceAndTimes_OK(t *testing.T) {
 				"canceled_at":          now - 7200,
 				"cancel_at_period_end": true,
 			},
-		},
+		}),
 	}
 
 	if err := h.handleSubscriptionCreated(context.Background(), &evt); err != nil {
@@ -1047,10 +1468,10 @@ func Test_handleSubscriptionCreated_Mapping_PriceAndTimes_OK(t *testing.T) {
 
 // Mapping edge-case: invoice with partial data (no currency/number) should still process OK
 func Test_handleInvoicePaymentSucceeded_PartialData_OK(t *testing.T) {
-	h := NewDefaultHandler(&userNotFoundDB{})
+	h := NewDefaultHandler(&userNotFoundDB{}, config.Stripe{}, config.App{})
 
 	evt := StripeEvent{
-		Data: map[string]interface{}{
+		Data: eventData(map[string]interface{}{
 			"object": map[string]interface{}{
 				"id":           "in_partial",
 				"customer":     "cus_partial",
@@ -1060,10 +1481,308 @@ func Test_handleInvoicePaymentSucceeded_PartialData_OK(t *testing.T) {
 				"amount_paid":  float64(1234),
 				// currency, number omitted intentionally
 			},
-		},
+		}),
 	}
 
 	if err := h.handleInvoicePaymentSucceeded(context.Background(), &evt); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestWebhook_ArchivesPayload(t *testing.T) {
+	tests := []struct {
+		name   string
+		putErr error
+	}{
+		{name: "success: payload archived"},
+		{name: "success: archive failure does not fail the webhook", putErr: errors.New("s3 down")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive := &webhookarchive.ServiceMock{
+				PutFunc: func(ctx context.Context, source webhookarchive.Source, eventID string, payload []byte) error {
+					return tt.putErr
+				},
+			}
+			h := NewDefaultHandler(nil, config.Stripe{}, config.App{}, WithArchive(archive))
+			body := makeEvent("customer.created", map[string]any{"id": "cus_1"})
+			c, rec := newEchoCtx(http.MethodPost, body, nil)
+
+			if err := h.Webhook(c); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d", rec.Code)
+			}
+			calls := archive.PutCalls()
+			if len(calls) != 1 {
+				t.Fatalf("expected one archive call, got %d", len(calls))
+			}
+			if calls[0].Source != webhookarchive.SourceStripe || calls[0].EventID != "evt_test" ||
+				!bytes.Equal(calls[0].Payload, body) {
+				t.Fatalf("unexpected archive call: %+v", calls[0])
+			}
+		})
+	}
+}
+
+func TestReplay(t *testing.T) {
+	archived := []byte(`{"id":"evt_1","type":"customer.created","data":{"object":{"id":"cus_1"}}}`)
+	tests := []struct {
+		name     string
+		archive  bool
+		eventID  string
+		payload  []byte
+		getErr   error
+		wantCode int
+		wantTx   bool
+	}{
+		{name: "success: event replayed", archive: true, eventID: "evt_1", payload: archived,
+			wantCode: http.StatusOK, wantTx: true},
+		{name: "fail: archive disabled", eventID: "evt_1", wantCode: http.StatusServiceUnavailable},
+		{name: "fail: not archived", archive: true, eventID: "evt_2", getErr: webhookarchive.ErrNotFound,
+			wantCode: http.StatusNotFound},
+		{name: "fail: invalid event id", archive: true, eventID: "..", getErr: webhookarchive.ErrInvalidEventID,
+			wantCode: http.StatusBadRequest},
+		{name: "fail: storage error", archive: true, eventID: "evt_1", getErr: errors.New("s3 down"),
+			wantCode: http.StatusInternalServerError},
+		{name: "fail: payload of another event", archive: true, eventID: "evt_9", payload: archived,
+			wantCode: http.StatusInternalServerError},
+		{name: "fail: handler error rolls back", archive: true, eventID: "evt_3",
+			payload:  []byte(`{"id":"evt_3","type":"customer.created","data":{"object":"not-an-object"}}`),
+			wantCode: http.StatusInternalServerError, wantTx: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeDBClaimOK{}
+			var opts []HandlerOption
+			if tt.archive {
+				opts = append(opts, WithArchive(&webhookarchive.ServiceMock{
+					GetFunc: func(ctx context.Context, source webhookarchive.Source, eventID string) ([]byte, error) {
+						return tt.payload, tt.getErr
+					},
+				}))
+			}
+			h := NewDefaultHandler(f, config.Stripe{}, config.App{}, opts...)
+			c, rec := newEchoCtx(http.MethodPost, nil, nil)
+			c.SetParamNames("event_id")
+			c.SetParamValues(tt.eventID)
+
+			if err := h.Replay(c); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if rec.Code != tt.wantCode {
+				t.Fatalf("expected %d, got %d: %s", tt.wantCode, rec.Code, rec.Body.String())
+			}
+			if got := f.commits + f.rollbacks; (got == 1) != tt.wantTx {
+				t.Fatalf("expected transaction=%v, got commits=%d rollbacks=%d", tt.wantTx, f.commits, f.rollbacks)
+			}
+			if tt.wantCode == http.StatusOK && f.commits != 1 {
+				t.Fatalf("expected replay to commit, got commits=%d", f.commits)
+			}
+		})
+	}
+}
+
+// customerDB resolves every Stripe customer to the same user.
+type customerDB struct {
+	simpleDB
+	userID pgtype.UUID
+}
+
+func (d *customerDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return userIDRow{id: d.userID}
+}
+
+// userIDRow scans id into the first column and leaves the rest zero.
+type userIDRow struct{ id pgtype.UUID }
+
+func (r userIDRow) Scan(dest ...any) error {
+	if len(dest) > 0 {
+		if p, ok := dest[0].(*pgtype.UUID); ok {
+			*p = r.id
+		}
+	}
+	return nil
+}
+
+func Test_handleInvoicePaymentFailed_Notifies(t *testing.T) {
+	userID := uuid.New()
+	evt := StripeEvent{
+		Data: eventData(map[string]interface{}{
+			"object": map[string]interface{}{"id": "in_3", "customer": "cus_3", "status": "open"},
+		}),
+	}
+
+	testCases := []struct {
+		name      string
+		notifyErr error
+	}{
+		{name: "success: notifies the customer"},
+		{name: "success: notify failure does not fail the webhook", notifyErr: errors.New("db down")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []notification.NewNotification
+			notifier := &notification.NotifierMock{
+				NotifyFunc: func(ctx context.Context, n notification.NewNotification) (*notification.Notification, error) {
+					got = append(got, n)
+					return nil, tc.notifyErr
+				},
+			}
+			db := &customerDB{userID: pgtype.UUID{Bytes: userID, Valid: true}}
+			h := NewDefaultHandler(db, config.Stripe{}, config.App{}, WithNotifier(notifier))
+
+			if err := h.handleInvoicePaymentFailed(context.Background(), &evt); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != 1 {
+				t.Fatalf("expected 1 notification, got %d", len(got))
+			}
+			n := got[0]
+			if n.UserID != userID.String() || n.Type != notification.TypePaymentFailed {
+				t.Fatalf("unexpected notification: %+v", n)
+			}
+			if n.Data["invoice_id"] != "in_3" || n.DedupeKey != "payment_failed:in_3" {
+				t.Fatalf("unexpected notification data: %+v", n)
+			}
+		})
+	}
+}
+
+func TestWebhook_InvoicePaymentFailed_NotifiesAfterCommit(t *testing.T) {
+	testCases := []struct {
+		name       string
+		commitErr  error
+		wantCode   int
+		wantNotify bool
+	}{
+		{name: "success: notifies once committed", wantCode: http.StatusOK, wantNotify: true},
+		{name: "fail: failed commit sends nothing", commitErr: errors.New("serialization failure"),
+			wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userID := uuid.New()
+			var committed bool
+			notifier := &notification.NotifierMock{
+				NotifyFunc: func(ctx context.Context, n notification.NewNotification) (*notification.Notification, error) {
+					assert.True(t, committed, "notified before the invoice committed")
+					return nil, nil
+				},
+			}
+			db := txDB(userID, tc.commitErr, &committed)
+			h := NewDefaultHandler(db, config.Stripe{}, config.App{}, WithNotifier(notifier))
+			body := makeEvent("invoice.payment_failed", map[string]any{"id": "in_6", "customer": "cus_6"})
+			c, rec := newEchoCtx(http.MethodPost, body, nil)
+
+			require.NoError(t, h.Webhook(c))
+			assert.Equal(t, tc.wantCode, rec.Code)
+			require.Len(t, db.WithTxCalls(), 1)
+			if !tc.wantNotify {
+				assert.Empty(t, notifier.NotifyCalls())
+				return
+			}
+			require.Len(t, notifier.NotifyCalls(), 1)
+			n := notifier.NotifyCalls()[0].N
+			assert.Equal(t, userID.String(), n.UserID)
+			assert.Equal(t, notification.TypePaymentFailed, n.Type)
+		})
+	}
+}
+
+func Test_handleInvoicePaymentFailed_PostsToTeamWebhooks(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name      string
+		object    map[string]interface{}
+		notifyErr error
+		wantData  map[string]string
+	}{
+		{
+			name: "success: invoice number and amount",
+			object: map[string]interface{}{
+				"id": "in_4", "customer": "cus_4", "number": "RS-0042", "amount_due": 4900, "currency": "usd",
+			},
+			wantData: map[string]string{"invoice": "RS-0042", "amount": "USD 49.00"},
+		},
+		{
+			name:      "success: falls back to the invoice id; post failure does not fail the webhook",
+			object:    map[string]interface{}{"id": "in_5", "customer": "cus_5"},
+			notifyErr: errors.New("slack webhook: Slack responded 404: no_service"),
+			wantData:  map[string]string{"invoice": "in_5"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hooks := &teamwebhook.NotifierMock{
+				NotifyFunc: func(ctx context.Context, uid, event string, data map[string]string) error {
+					return tc.notifyErr
+				},
+			}
+			db := &customerDB{userID: pgtype.UUID{Bytes: userID, Valid: true}}
+			h := NewDefaultHandler(db, config.Stripe{}, config.App{}, WithTeamNotifier(hooks))
+			evt := StripeEvent{Data: eventData(map[string]interface{}{"object": tc.object})}
+
+			if err := h.handleInvoicePaymentFailed(context.Background(), &evt); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			calls := hooks.NotifyCalls()
+			if len(calls) != 1 {
+				t.Fatalf("expected 1 team webhook post, got %d", len(calls))
+			}
+			if calls[0].UserID != userID.String() || calls[0].Event != teamwebhook.EventPaymentFailed {
+				t.Fatalf("unexpected post: %+v", calls[0])
+			}
+			if !reflect.DeepEqual(calls[0].Data, tc.wantData) {
+				t.Fatalf("expected data %v, got %v", tc.wantData, calls[0].Data)
+			}
+		})
+	}
+}
+
+func TestWebhook_InvoicePaymentFailed_PostsToTeamWebhooksAfterCommit(t *testing.T) {
+	testCases := []struct {
+		name      string
+		commitErr error
+		wantCode  int
+		wantPost  bool
+	}{
+		{name: "success: posts once committed", wantCode: http.StatusOK, wantPost: true},
+		{name: "fail: failed commit posts nothing", commitErr: errors.New("serialization failure"),
+			wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userID := uuid.New()
+			var committed bool
+			hooks := &teamwebhook.NotifierMock{
+				NotifyFunc: func(ctx context.Context, uid, event string, data map[string]string) error {
+					assert.True(t, committed, "posted before the invoice committed")
+					return nil
+				},
+			}
+			db := txDB(userID, tc.commitErr, &committed)
+			h := NewDefaultHandler(db, config.Stripe{}, config.App{}, WithTeamNotifier(hooks))
+			body := makeEvent("invoice.payment_failed", map[string]any{"id": "in_7", "customer": "cus_7"})
+			c, rec := newEchoCtx(http.MethodPost, body, nil)
+
+			require.NoError(t, h.Webhook(c))
+			assert.Equal(t, tc.wantCode, rec.Code)
+			require.Len(t, db.WithTxCalls(), 1)
+			if !tc.wantPost {
+				assert.Empty(t, hooks.NotifyCalls())
+				return
+			}
+			require.Len(t, hooks.NotifyCalls(), 1)
+			assert.Equal(t, userID.String(), hooks.NotifyCalls()[0].UserID)
+			assert.Equal(t, teamwebhook.EventPaymentFailed, hooks.NotifyCalls()[0].Event)
+			assert.Equal(t, map[string]string{"invoice": "in_7"}, hooks.NotifyCalls()[0].Data)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package stripe
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// StripeEvent represents a Stripe webhook event (subset).
+type StripeEvent struct {
+	ID      string    `json:"id"`
+	Type    string    `json:"type"`
+	Data    EventData `json:"data"`
+	Created int64     `json:"created"`
+}
+
+// EventData holds the object an event is about, left raw until the event's handler
+// decodes it into the type it expects.
+type EventData struct {
+	Object json.RawMessage `json:"object"`
+}
+
+// decodeObject decodes the event's object into v. Unlike map lookups, a missing object or a
+// field of the wrong type is an error rather than a silently empty value.
+func (e *StripeEvent) decodeObject(v any) error {
+	obj := bytes.TrimSpace(e.Data.Object)
+	if len(obj) == 0 || obj[0] != '{' {
+		return errors.New("event object is not a JSON object")
+	}
+	if err := json.Unmarshal(obj, v); err != nil {
+		return fmt.Errorf("decode event object: %w", err)
+	}
+	return nil
+}
+
+// CheckoutSession represents a Stripe checkout session (subset).
+type CheckoutSession struct {
+	ID                string `json:"id"`
+	CustomerID        string `json:"customer"`
+	PaymentStatus     string `json:"payment_status"`
+	SubscriptionID    string `json:"subscription"`
+	ClientReferenceID string `json:"client_reference_id"`
+	Mode              string `json:"mode"`
+	// Metadata is what the session was created with.
+	Metadata map[string]string `json:"metadata"`
+	// URL is where to send the customer to pay; only set while the session is open.
+	URL string `json:"url"`
+}
+
+// PortalSession is a Stripe customer portal session (subset).
+type PortalSession struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// Subscription represents a Stripe subscription (subset). Timestamps are Unix seconds,
+// zero when Stripe sends null.
+type Subscription struct {
+	ID                 string            `json:"id"`
+	CustomerID         string            `json:"customer"`
+	Status             string            `json:"status"`
+	Items              SubscriptionItems `json:"items"`
+	CurrentPeriodStart int64             `json:"current_period_start"`
+	CurrentPeriodEnd   int64             `json:"current_period_end"`
+	CancelAt           int64             `json:"cancel_at"`
+	CanceledAt         int64             `json:"canceled_at"`
+	CancelAtPeriodEnd  bool              `json:"cancel_at_period_end"`
+}
+
+// SubscriptionItems is the list of a subscription's items.
+type SubscriptionItems struct {
+	Data []SubscriptionItem `json:"data"`
+}
+
+// SubscriptionItem is one priced line of a subscription.
+type SubscriptionItem struct {
+	ID    string `json:"id"`
+	Price Price  `json:"price"`
+}
+
+// Price is a Stripe price (subset).
+type Price struct {
+	ID string `json:"id"`
+}
+
+// PriceID returns the price of the subscription's first item, or nil when it has none.
+func (s *Subscription) PriceID() *string {
+	if len(s.Items.Data) == 0 || s.Items.Data[0].Price.ID == "" {
+		return nil
+	}
+	id := s.Items.Data[0].Price.ID
+	return &id
+}
+
+// Invoice represents a Stripe invoice (subset). Amounts are in the currency's minor unit.
+type Invoice struct {
+	ID             string `json:"id"`
+	CustomerID     string `json:"customer"`
+	CustomerEmail  string `json:"customer_email"`
+	SubscriptionID string `json:"subscription"`
+	Status         string `json:"status"`
+	AmountDue      int64  `json:"amount_due"`
+	AmountPaid     int64  `json:"amount_paid"`
+	Currency       string `json:"currency"`
+	Number         string `json:"number"`
+}
+
+// amounts returns the invoice's amounts in the int32 the invoices table stores.
+func (i *Invoice) amounts() (due, paid int32, err error) {
+	if i.AmountDue < math.MinInt32 || i.AmountDue > math.MaxInt32 ||
+		i.AmountPaid < math.MinInt32 || i.AmountPaid > math.MaxInt32 {
+		return 0, 0, fmt.Errorf("invoice %s amount out of range", i.ID)
+	}
+	return int32(i.AmountDue), int32(i.AmountPaid), nil
+}
+
+// Customer represents a Stripe customer (subset).
+type Customer struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+}
+
+// unixTime converts a Stripe timestamp to a time, nil when unset.
+func unixTime(sec int64) *time.Time {
+	if sec <= 0 {
+		return nil
+	}
+	t := time.Unix(sec, 0)
+	return &t
+}
+
+// optionalString returns a pointer to s, nil when s is empty.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,86 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// PendingEventTTL is how long the worker keeps trying to map a parked update's customer
+// before the update is dropped.
+const PendingEventTTL = 7 * 24 * time.Hour
+
+// Object types of parked updates.
+const (
+	pendingObjectSubscription = "subscription"
+	pendingObjectInvoice      = "invoice"
+)
+
+// pendingSubscription is a parked subscription, shaped like the subscriptions row the worker
+// writes from it.
+type pendingSubscription struct {
+	Status             string     `json:"status"`
+	PriceID            *string    `json:"price_id,omitempty"`
+	CurrentPeriodStart *time.Time `json:"current_period_start,omitempty"`
+	CurrentPeriodEnd   *time.Time `json:"current_period_end,omitempty"`
+	CancelAt           *time.Time `json:"cancel_at,omitempty"`
+	CanceledAt         *time.Time `json:"canceled_at,omitempty"`
+	CancelAtPeriodEnd  bool       `json:"cancel_at_period_end"`
+}
+
+// pendingInvoice is a parked invoice, shaped like the invoices row the worker writes from it.
+type pendingInvoice struct {
+	StripeSubscriptionID *string `json:"stripe_subscription_id,omitempty"`
+	Status               string  `json:"status"`
+	AmountDue            int32   `json:"amount_due"`
+	AmountPaid           int32   `json:"amount_paid"`
+	Currency             *string `json:"currency,omitempty"`
+	InvoiceNumber        *string `json:"invoice_number,omitempty"`
+}
+
+// parkSubscription keeps a subscription of an unknown customer for the worker to apply once
+// the customer is linked.
+func (h *DefaultHandler) parkSubscription(ctx context.Context, sub *Subscription, status, eventType string) error {
+	data := pendingSubscription{
+		Status:             status,
+		PriceID:            sub.PriceID(),
+		CurrentPeriodStart: unixTime(sub.CurrentPeriodStart),
+		CurrentPeriodEnd:   unixTime(sub.CurrentPeriodEnd),
+		CancelAt:           unixTime(sub.CancelAt),
+		CanceledAt:         unixTime(sub.CanceledAt),
+		CancelAtPeriodEnd:  sub.CancelAtPeriodEnd,
+	}
+	return h.park(ctx, sub.ID, pendingObjectSubscription, "subscription."+eventType, sub.CustomerID, data)
+}
+
+// parkInvoice keeps an invoice of an unknown customer for the worker to apply once the
+// customer is linked.
+func (h *DefaultHandler) parkInvoice(
+	ctx context.Context, inv *Invoice, amountDue, amountPaid int32, eventType string,
+) error {
+	data := pendingInvoice{
+		StripeSubscriptionID: optionalString(inv.SubscriptionID),
+		Status:               inv.Status,
+		AmountDue:            amountDue,
+		AmountPaid:           amountPaid,
+		Currency:             optionalString(inv.Currency),
+		InvoiceNumber:        optionalString(inv.Number),
+	}
+	return h.park(ctx, inv.ID, pendingObjectInvoice, "invoice."+eventType, inv.CustomerID, data)
+}
+
+// park stores an update for the worker's retry. A failure is returned so Stripe redelivers
+// the event rather than the update being lost.
+func (h *DefaultHandler) park(ctx context.Context, objectID, objectType, eventType, customerID string, data any) error {
+	expiresAt := time.Now().Add(PendingEventTTL)
+	if err := NewPendingEventsRepository(h.db).Park(
+		ctx, objectID, objectType, eventType, customerID, data, expiresAt,
+	); err != nil {
+		return err
+	}
+	logging.Default().Warn(ctx, fmt.Sprintf(
+		"Parked %s %s until Stripe customer %s is linked to a user", eventType, objectID, customerID))
+	return nil
+}
@@ -2,6 +2,7 @@ package stripe
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
+	"github.com/real-staging-ai/api/internal/fieldcrypt"
 	"github.com/real-staging-ai/api/internal/storage"
 	"github.com/real-staging-ai/api/internal/storage/queries"
 )
@@ -18,6 +20,11 @@ import (
 type ProcessedEventsRepository interface {
 	// IsProcessed returns true if the given Stripe event ID has already been processed.
 	IsProcessed(ctx context.Context, stripeEventID string) (bool, error)
+	// Claim atomically records the event and reports whether this call recorded it.
+	// It returns false when another delivery of the same event already claimed it,
+	// so concurrent retries cannot both pass the idempotency check. Run it in the
+	// same transaction as the event's side effects so a failure releases the claim.
+	Claim(ctx context.Context, stripeEventID string, eventType *string, payload []byte) (bool, error)
 	// Upsert marks the given event as processed; if it already exists, it is a no-op.
 	// eventType may be nil. payload should be a JSON-encoded body (may be nil/empty).
 	Upsert(ctx context.Context, stripeEventID string, eventType *string, payload []byte) (*queries.ProcessedEvent, error)
@@ -77,6 +84,16 @@ type InvoicesRepository interface {
 	ListByUserID(ctx context.Context, userID string, limit, offset int32) ([]*queries.Invoice, error)
 }
 
+// PendingEventsRepository parks subscription and invoice updates whose customer is not linked
+// to a user yet. The worker applies them once a user has the customer.
+type PendingEventsRepository interface {
+	// Park stores the object's latest state, JSON-encoded from data, until expiresAt. Parking
+	// the same object again replaces its state but keeps the first expiry.
+	Park(
+		ctx context.Context, objectID, objectType, eventType, customerID string, data any, expiresAt time.Time,
+	) error
+}
+
 /* ---------------------------- Implementations ---------------------------- */
 
 type processedEventsRepo struct {
@@ -91,6 +108,10 @@ type invoicesRepo struct {
 	q *queries.Queries
 }
 
+type pendingEventsRepo struct {
+	q *queries.Queries
+}
+
 // NewProcessedEventsRepository returns a sqlc-backed ProcessedEventsRepository.
 func NewProcessedEventsRepository(db storage.Database) ProcessedEventsRepository {
 	return &processedEventsRepo{q: queries.New(db)}
@@ -106,6 +127,11 @@ func NewInvoicesRepository(db storage.Database) InvoicesRepository {
 	return &invoicesRepo{q: queries.New(db)}
 }
 
+// NewPendingEventsRepository returns a sqlc-backed PendingEventsRepository.
+func NewPendingEventsRepository(db storage.Database) PendingEventsRepository {
+	return &pendingEventsRepo{q: queries.New(db)}
+}
+
 /* ----------------------- ProcessedEventsRepository ----------------------- */
 
 func (r *processedEventsRepo) IsProcessed(ctx context.Context, stripeEventID string) (bool, error) {
@@ -119,6 +145,33 @@ func (r *processedEventsRepo) IsProcessed(ctx context.Context, stripeEventID str
 	return true, nil
 }
 
+func (r *processedEventsRepo) Claim(
+	ctx context.Context, stripeEventID string, eventType *string, payload []byte,
+) (bool, error) {
+	var et pgtype.Text
+	if eventType != nil {
+		et = pgtype.Text{String: *eventType, Valid: true}
+	}
+	var data []byte
+	if len(payload) > 0 {
+		data = payload
+	}
+
+	_, err := r.q.ClaimProcessedEvent(ctx, queries.ClaimProcessedEventParams{
+		StripeEventID: stripeEventID,
+		Type:          et,
+		Payload:       data,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// ON CONFLICT DO NOTHING returns no row: already claimed.
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to claim processed event: %w", err)
+	}
+	return true, nil
+}
+
 func (r *processedEventsRepo) Upsert(
 	ctx context.Context, stripeEventID string, eventType *string, payload []byte,
 ) (*queries.ProcessedEvent, error) {
@@ -342,3 +395,31 @@ func (r *invoicesRepo) ListByUserID(
 	}
 	return results, nil
 }
+
+/* ------------------------- PendingEventsRepository ------------------------- */
+
+func (r *pendingEventsRepo) Park(
+	ctx context.Context, objectID, objectType, eventType, customerID string, data any, expiresAt time.Time,
+) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode pending %s: %w", objectType, err)
+	}
+	// The worker matches the customer the same way user lookups do: by blind index when
+	// field encryption is active, otherwise by the plaintext ID.
+	hash := fieldcrypt.Default().BlindIndex(customerID)
+
+	err = r.q.UpsertPendingBillingEvent(ctx, queries.UpsertPendingBillingEventParams{
+		StripeObjectID:       objectID,
+		ObjectType:           objectType,
+		EventType:            eventType,
+		StripeCustomerID:     customerID,
+		StripeCustomerIDHash: pgtype.Text{String: hash, Valid: hash != ""},
+		Data:                 encoded,
+		ExpiresAt:            pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to park pending %s: %w", objectType, err)
+	}
+	return nil
+}
@@ -28,6 +28,13 @@ type fakeDB struct {
 func (f *fakeDB) Close() {}
 
 func (f *fakeDB) Pool() storage.PgxPool { return nil }
+func (f *fakeDB) CopyFrom(context.Context, pgx.Identifier, []string, pgx.CopyFromSource) (int64, error) {
+	return 0, nil
+}
+
+func (f *fakeDB) WithTx(ctx context.Context, fn func(storage.Database) error) error {
+	return fn(f)
+}
 
 func (f *fakeDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
 	return f.row
@@ -193,6 +200,34 @@ func TestProcessedEventsRepository_Upsert_And_Get_Success(t *testing.T) {
 	}
 }
 
+func TestProcessedEventsRepository_Claim(t *testing.T) {
+	ctx := context.Background()
+	etype := "invoice.paid"
+
+	claimedDB := &fakeDB{row: &processedEventRowStub{pe: queries.ProcessedEvent{StripeEventID: "evt_new"}}}
+	ok, err := NewProcessedEventsRepository(claimedDB).Claim(ctx, "evt_new", &etype, []byte(`{}`))
+	if err != nil {
+		t.Fatalf("Claim error: %v", err)
+	}
+	if !ok {
+		t.Fatalf("Claim = false, want true for a new event")
+	}
+
+	conflictDB := &fakeDB{row: &processedEventRowStub{err: pgx.ErrNoRows}}
+	ok, err = NewProcessedEventsRepository(conflictDB).Claim(ctx, "evt_dup", &etype, nil)
+	if err != nil {
+		t.Fatalf("Claim error: %v", err)
+	}
+	if ok {
+		t.Fatalf("Claim = true, want false for an already claimed event")
+	}
+
+	errDB := &fakeDB{row: &processedEventRowStub{err: fmt.Errorf("boom")}}
+	if _, err := NewProcessedEventsRepository(errDB).Claim(ctx, "evt_err", nil, nil); err == nil {
+		t.Fatalf("expected Claim error")
+	}
+}
+
 func TestProcessedEventsRepository_DeleteOlderThan_Exec(t *testing.T) {
 	ctx := context.Background()
 	db := &fakeDB{}
@@ -24,7 +24,7 @@ func (r *rowStub) Scan(dest ...any) error {
 	if r.err != nil {
 		return r.err
 	}
-	// Expect sqlc to scan in this exact order (see invoices.sql.go)
+	// Expect sqlc to scan in this exact order (see billing.sql.go)
 	// id, user_id, stripe_invoice_id, stripe_subscription_id, status,
 	// amount_due, amount_paid, currency, invoice_number, created_at, updated_at
 	if len(dest) < 11 {
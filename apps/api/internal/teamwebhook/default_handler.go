@@ -0,0 +1,204 @@
+package teamwebhook
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// DefaultHandler serves the current user's team webhooks over HTTP.
+type DefaultHandler struct {
+	service  Service
+	userRepo user.Repository
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, userRepo user.Repository) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo}
+}
+
+// ErrorResponse is a simple JSON error envelope for handler responses.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// ListResponse wraps the user's webhooks.
+type ListResponse struct {
+	Webhooks []*Webhook `json:"webhooks"`
+}
+
+// TestFailedResponse reports a test message the provider did not accept, with the
+// webhook's recorded delivery state.
+type TestFailedResponse struct {
+	ErrorResponse
+	Webhook *Webhook `json:"webhook"`
+}
+
+// DeliveryListResponse wraps a webhook's latest deliveries.
+type DeliveryListResponse struct {
+	Deliveries []*Delivery `json:"deliveries"`
+}
+
+// RedeliveryFailedResponse reports a redelivery the provider did not accept, with the
+// delivery's recorded state.
+type RedeliveryFailedResponse struct {
+	ErrorResponse
+	Delivery *Delivery `json:"delivery"`
+}
+
+// List handles GET /api/v1/me/integrations/webhooks.
+func (h *DefaultHandler) List(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	hooks, err := h.service.List(c.Request().Context(), userID)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, ListResponse{Webhooks: hooks})
+}
+
+// Put handles PUT /api/v1/me/integrations/webhooks/:provider.
+func (h *DefaultHandler) Put(c echo.Context) error {
+	var req PutRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid request format"})
+	}
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	hook, err := h.service.Put(c.Request().Context(), userID, c.Param("provider"), req)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, hook)
+}
+
+// Delete handles DELETE /api/v1/me/integrations/webhooks/:provider.
+func (h *DefaultHandler) Delete(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	if err := h.service.Delete(c.Request().Context(), userID, c.Param("provider")); err != nil {
+		return h.writeError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Test handles POST /api/v1/me/integrations/webhooks/:provider/test.
+func (h *DefaultHandler) Test(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	hook, err := h.service.SendTest(c.Request().Context(), userID, c.Param("provider"))
+	if errors.Is(err, ErrDelivery) && hook != nil {
+		return c.JSON(http.StatusBadGateway, TestFailedResponse{
+			ErrorResponse: ErrorResponse{Error: "bad_gateway", Message: err.Error()},
+			Webhook:       hook,
+		})
+	}
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, hook)
+}
+
+// RotateSecret handles POST /api/v1/me/integrations/webhooks/:provider/rotate-secret.
+func (h *DefaultHandler) RotateSecret(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	hook, err := h.service.RotateSecret(c.Request().Context(), userID, c.Param("provider"))
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, hook)
+}
+
+// ListDeliveries handles GET /api/v1/me/integrations/webhooks/:provider/deliveries.
+func (h *DefaultHandler) ListDeliveries(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	limit := DefaultDeliveryLimit
+	if v := c.QueryParam("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 && n <= int(MaxDeliveryLimit) {
+			// #nosec G109,G115 -- Value is validated to be positive and within MaxDeliveryLimit
+			limit = int32(n)
+		}
+	}
+	deliveries, err := h.service.ListDeliveries(c.Request().Context(), userID, c.Param("provider"), limit)
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, DeliveryListResponse{Deliveries: deliveries})
+}
+
+// Redeliver handles POST /api/v1/me/integrations/webhooks/:provider/deliveries/:id/redeliver.
+func (h *DefaultHandler) Redeliver(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return unauthorized(c)
+	}
+
+	delivery, err := h.service.Redeliver(c.Request().Context(), userID, c.Param("provider"), c.Param("id"))
+	if errors.Is(err, ErrDelivery) && delivery != nil {
+		return c.JSON(http.StatusBadGateway, RedeliveryFailedResponse{
+			ErrorResponse: ErrorResponse{Error: "bad_gateway", Message: err.Error()},
+			Delivery:      delivery,
+		})
+	}
+	if err != nil {
+		return h.writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, delivery)
+}
+
+func unauthorized(c echo.Context) error {
+	return c.JSON(http.StatusUnauthorized, ErrorResponse{
+		Error:   "unauthorized",
+		Message: "Unable to resolve current user",
+	})
+}
+
+// writeError maps service errors to responses.
+func (h *DefaultHandler) writeError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "No webhook configured"})
+	case errors.Is(err, ErrDeliveryNotFound):
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Delivery not found"})
+	case errors.Is(err, ErrNotRedeliverable):
+		return c.JSON(http.StatusConflict, ErrorResponse{Error: "conflict", Message: err.Error()})
+	case errors.Is(err, ErrInvalid):
+		return c.JSON(http.StatusUnprocessableEntity, ErrorResponse{Error: "validation_failed", Message: err.Error()})
+	case errors.Is(err, ErrDelivery):
+		return c.JSON(http.StatusBadGateway, ErrorResponse{Error: "bad_gateway", Message: err.Error()})
+	default:
+		c.Logger().Errorf("Team webhook request failed: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to process webhook request",
+		})
+	}
+}
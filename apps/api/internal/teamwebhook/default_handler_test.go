@@ -0,0 +1,223 @@
+package teamwebhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func userRepoFor(userID uuid.UUID) *user.RepositoryMock {
+	return &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+		},
+	}
+}
+
+func newContext(method, target, body, provider string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("X-Test-User", "auth0|user")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	if provider != "" {
+		c.SetParamNames("provider")
+		c.SetParamValues(provider)
+	}
+	return c, rec
+}
+
+func TestDefaultHandler_Put(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: saved", body: `{"url":"https://hooks.slack.com/services/x","events":["batch_complete"]}`, expectedStatus: http.StatusOK},
+		{name: "fail: malformed body", body: `{`, expectedStatus: http.StatusBadRequest},
+		{name: "fail: invalid", body: `{}`, err: fmt.Errorf("%w: url", ErrInvalid), expectedStatus: http.StatusUnprocessableEntity},
+		{name: "fail: service error", body: `{}`, err: errors.New("db down"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				PutFunc: func(ctx context.Context, uid, provider string, req PutRequest) (*Webhook, error) {
+					assert.Equal(t, userID.String(), uid)
+					assert.Equal(t, ProviderSlack, provider)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					assert.Equal(t, []string{EventBatchComplete}, req.Events)
+					return &Webhook{Provider: provider, URL: maskURL(req.URL), Events: req.Events}, nil
+				},
+			}
+			c, rec := newContext(http.MethodPut, "/api/v1/me/integrations/webhooks/slack", tc.body, ProviderSlack)
+
+			require.NoError(t, NewDefaultHandler(svc, userRepoFor(userID)).Put(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Contains(t, rec.Body.String(), `"url":"https://hooks.slack.com/…es/x"`)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_List(t *testing.T) {
+	userID := uuid.New()
+	svc := &ServiceMock{
+		ListFunc: func(ctx context.Context, uid string) ([]*Webhook, error) {
+			return []*Webhook{{Provider: ProviderTeams, Events: Events}}, nil
+		},
+	}
+	c, rec := newContext(http.MethodGet, "/api/v1/me/integrations/webhooks", "", "")
+
+	require.NoError(t, NewDefaultHandler(svc, userRepoFor(userID)).List(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"webhooks":[{"provider":"teams"`)
+}
+
+func TestDefaultHandler_Delete(t *testing.T) {
+	userID := uuid.New()
+	svc := &ServiceMock{
+		DeleteFunc: func(ctx context.Context, uid, provider string) error {
+			if provider != ProviderSlack {
+				return ErrNotFound
+			}
+			return nil
+		},
+	}
+
+	for provider, want := range map[string]int{ProviderSlack: http.StatusNoContent, ProviderTeams: http.StatusNotFound} {
+		c, rec := newContext(http.MethodDelete, "/api/v1/me/integrations/webhooks/"+provider, "", provider)
+		require.NoError(t, NewDefaultHandler(svc, userRepoFor(userID)).Delete(c))
+		assert.Equal(t, want, rec.Code, provider)
+	}
+}
+
+func TestDefaultHandler_Test(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		hook           *Webhook
+		err            error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "success: delivered", hook: &Webhook{Provider: ProviderSlack}, expectedStatus: http.StatusOK},
+		{
+			name:           "fail: rejected by provider",
+			hook:           &Webhook{Provider: ProviderSlack, LastDeliveryError: "Slack responded 404: no_service"},
+			err:            fmt.Errorf("%w: Slack responded 404: no_service", ErrDelivery),
+			expectedStatus: http.StatusBadGateway,
+			expectedBody:   `"webhook":{"provider":"slack","url":"","events":null,"last_delivery_error":"Slack responded 404: no_service"`,
+		},
+		{name: "fail: none configured", err: ErrNotFound, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				SendTestFunc: func(ctx context.Context, uid, provider string) (*Webhook, error) {
+					assert.Equal(t, userID.String(), uid)
+					return tc.hook, tc.err
+				},
+			}
+			c, rec := newContext(http.MethodPost, "/api/v1/me/integrations/webhooks/slack/test", "", ProviderSlack)
+
+			require.NoError(t, NewDefaultHandler(svc, userRepoFor(userID)).Test(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tc.expectedBody)
+		})
+	}
+}
+
+func TestDefaultHandler_ListDeliveries(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name      string
+		query     string
+		wantLimit int32
+	}{
+		{name: "success: default limit", wantLimit: DefaultDeliveryLimit},
+		{name: "success: requested limit", query: "?limit=10", wantLimit: 10},
+		{name: "success: limit over the cap ignored", query: "?limit=1000", wantLimit: DefaultDeliveryLimit},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				ListDeliveriesFunc: func(ctx context.Context, uid, provider string, limit int32) ([]*Delivery, error) {
+					assert.Equal(t, tc.wantLimit, limit)
+					return []*Delivery{{ID: "d1", Status: DeliveryFailed}}, nil
+				},
+			}
+			c, rec := newContext(http.MethodGet, "/api/v1/me/integrations/webhooks/slack/deliveries"+tc.query, "", ProviderSlack)
+
+			require.NoError(t, NewDefaultHandler(svc, userRepoFor(userID)).ListDeliveries(c))
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Contains(t, rec.Body.String(), `"deliveries":[{"id":"d1","event":"","status":"failed"`)
+		})
+	}
+}
+
+func TestDefaultHandler_Redeliver(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		delivery       *Delivery
+		err            error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "success: delivered", delivery: &Delivery{ID: "d1", Status: DeliveryDelivered}, expectedStatus: http.StatusOK},
+		{
+			name:           "fail: rejected by provider",
+			delivery:       &Delivery{ID: "d1", Status: DeliveryFailed, LastError: "Slack responded 410: gone"},
+			err:            fmt.Errorf("%w: Slack responded 410: gone", ErrDelivery),
+			expectedStatus: http.StatusBadGateway,
+			expectedBody:   `"delivery":{"id":"d1","event":"","status":"failed"`,
+		},
+		{name: "fail: not failed", err: ErrNotRedeliverable, expectedStatus: http.StatusConflict},
+		{name: "fail: unknown delivery", err: ErrDeliveryNotFound, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				RedeliverFunc: func(ctx context.Context, uid, provider, deliveryID string) (*Delivery, error) {
+					assert.Equal(t, ProviderSlack, provider)
+					assert.Equal(t, "d1", deliveryID)
+					return tc.delivery, tc.err
+				},
+			}
+			c, rec := newContext(http.MethodPost, "/api/v1/me/integrations/webhooks/slack/deliveries/d1/redeliver", "", "")
+			c.SetParamNames("provider", "id")
+			c.SetParamValues(ProviderSlack, "d1")
+
+			require.NoError(t, NewDefaultHandler(svc, userRepoFor(userID)).Redeliver(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tc.expectedBody)
+		})
+	}
+}
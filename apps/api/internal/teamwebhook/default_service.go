@@ -0,0 +1,374 @@
+package teamwebhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/httpclient"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// maxErrorBody bounds how much of a rejected delivery's response is kept as its error.
+const maxErrorBody = 256
+
+// DefaultService implements Service and Notifier.
+type DefaultService struct {
+	querier queries.Querier
+	client  *http.Client
+	now     func() time.Time
+}
+
+// Ensure DefaultService implements Service and Notifier.
+var (
+	_ Service  = (*DefaultService)(nil)
+	_ Notifier = (*DefaultService)(nil)
+)
+
+// NewDefaultService creates a new DefaultService with a database. Messages are posted
+// through the customer webhooks HTTP client.
+func NewDefaultService(db storage.Database) *DefaultService {
+	return &DefaultService{
+		querier: queries.New(db),
+		client:  httpclient.New(httpclient.DestinationWebhooks),
+		now:     time.Now,
+	}
+}
+
+// NewDefaultServiceWithQuerier creates a new DefaultService with a custom querier and
+// HTTP client (for testing).
+func NewDefaultServiceWithQuerier(querier queries.Querier, client *http.Client) *DefaultService {
+	return &DefaultService{querier: querier, client: client, now: time.Now}
+}
+
+// List returns the user's webhooks.
+func (s *DefaultService) List(ctx context.Context, userID string) ([]*Webhook, error) {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.querier.ListTeamWebhooks(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list team webhooks: %w", err)
+	}
+	hooks := make([]*Webhook, 0, len(rows))
+	for _, row := range rows {
+		hooks = append(hooks, webhookFromRow(row, s.now()))
+	}
+	return hooks, nil
+}
+
+// Put saves the user's webhook for provider.
+func (s *DefaultService) Put(ctx context.Context, userID, provider string, req PutRequest) (*Webhook, error) {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.validate(provider); err != nil {
+		return nil, err
+	}
+	row, err := s.querier.UpsertTeamWebhook(ctx, queries.UpsertTeamWebhookParams{
+		UserID:   uid,
+		Provider: provider,
+		Url:      req.URL,
+		Events:   req.Events,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save team webhook: %w", err)
+	}
+	hook := webhookFromRow(row, s.now())
+	hook.SigningSecret = row.SigningSecret
+	return hook, nil
+}
+
+// Delete removes the user's webhook for provider.
+func (s *DefaultService) Delete(ctx context.Context, userID, provider string) error {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return err
+	}
+	n, err := s.querier.DeleteTeamWebhook(ctx, queries.DeleteTeamWebhookParams{UserID: uid, Provider: provider})
+	if err != nil {
+		return fmt.Errorf("failed to delete team webhook: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// RotateSecret replaces the signing secret of the user's webhook for provider. The old
+// secret keeps signing deliveries alongside the new one for rotationOverlap.
+func (s *DefaultService) RotateSecret(ctx context.Context, userID, provider string) (*Webhook, error) {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	row, err := s.querier.RotateTeamWebhookSecret(ctx, queries.RotateTeamWebhookSecretParams{
+		SigningSecret:           secret,
+		PreviousSecretExpiresAt: pgtype.Timestamptz{Time: now.Add(rotationOverlap), Valid: true},
+		UserID:                  uid,
+		Provider:                provider,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate team webhook secret: %w", err)
+	}
+	hook := webhookFromRow(row, now)
+	hook.SigningSecret = row.SigningSecret
+	return hook, nil
+}
+
+// SendTest posts a test message to the user's webhook for provider. A rejected delivery
+// is recorded on the webhook and returned as ErrDelivery. Test messages are signed but not
+// logged or retried.
+func (s *DefaultService) SendTest(ctx context.Context, userID, provider string) (*Webhook, error) {
+	row, err := s.getWebhook(ctx, userID, provider)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := Render(eventTest, map[string]string{"provider": providerName(provider)})
+	if err != nil {
+		return nil, err
+	}
+	payload, err := Payload(provider, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveryErr := s.post(ctx, row, uuid.NewString(), payload)
+	s.recordOutcome(ctx, row, deliveryErr)
+	row.LastDeliveryAt = pgtype.Timestamptz{Time: s.now(), Valid: true}
+	row.LastDeliveryError = pgtype.Text{}
+	if deliveryErr != nil {
+		row.LastDeliveryError = pgtype.Text{String: deliveryErr.Error(), Valid: true}
+		return webhookFromRow(row, s.now()), fmt.Errorf("%w: %v", ErrDelivery, deliveryErr)
+	}
+	return webhookFromRow(row, s.now()), nil
+}
+
+// ListDeliveries returns the latest deliveries of the user's webhook for provider, newest
+// first.
+func (s *DefaultService) ListDeliveries(ctx context.Context, userID, provider string, limit int32) ([]*Delivery, error) {
+	row, err := s.getWebhook(ctx, userID, provider)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.querier.ListTeamWebhookDeliveries(ctx, queries.ListTeamWebhookDeliveriesParams{
+		UserID:   row.UserID,
+		Provider: provider,
+		Limit:    limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list team webhook deliveries: %w", err)
+	}
+	deliveries := make([]*Delivery, 0, len(rows))
+	for _, d := range rows {
+		deliveries = append(deliveries, deliveryFromRow(d))
+	}
+	return deliveries, nil
+}
+
+// Redeliver posts a failed delivery once more, signed with the webhook's current secrets.
+// A rejected redelivery is recorded on the delivery, which stays failed, and returned with
+// ErrDelivery.
+func (s *DefaultService) Redeliver(ctx context.Context, userID, provider, deliveryID string) (*Delivery, error) {
+	row, err := s.getWebhook(ctx, userID, provider)
+	if err != nil {
+		return nil, err
+	}
+	id, err := uuid.Parse(deliveryID)
+	if err != nil {
+		return nil, ErrDeliveryNotFound
+	}
+	d, err := s.querier.GetTeamWebhookDelivery(ctx, queries.GetTeamWebhookDeliveryParams{
+		ID:        pgtype.UUID{Bytes: id, Valid: true},
+		WebhookID: row.ID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrDeliveryNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team webhook delivery: %w", err)
+	}
+	if d.Status != DeliveryFailed {
+		return nil, ErrNotRedeliverable
+	}
+
+	updated, deliveryErr := s.attempt(ctx, row, d)
+	if deliveryErr != nil {
+		return deliveryFromRow(updated), fmt.Errorf("%w: %v", ErrDelivery, deliveryErr)
+	}
+	return deliveryFromRow(updated), nil
+}
+
+// Notify posts the event to each of the user's webhooks subscribed to it, returning the
+// joined delivery errors.
+func (s *DefaultService) Notify(ctx context.Context, userID, event string, data map[string]string) error {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return err
+	}
+	rows, err := s.querier.ListTeamWebhooksForEvent(ctx, queries.ListTeamWebhooksForEventParams{
+		UserID: uid,
+		Event:  event,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list team webhooks: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	msg, err := Render(event, data)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, row := range rows {
+		if err := s.deliver(ctx, row, event, msg); err != nil {
+			errs = append(errs, fmt.Errorf("%s webhook: %w", row.Provider, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// deliver logs msg as a delivery to the webhook and makes its first attempt; the worker
+// retries it if that fails. When the delivery cannot be logged, msg is still posted once.
+func (s *DefaultService) deliver(ctx context.Context, row *queries.TeamWebhook, event string, msg Message) error {
+	payload, err := Payload(row.Provider, msg)
+	if err != nil {
+		return err
+	}
+	d, err := s.querier.CreateTeamWebhookDelivery(ctx, queries.CreateTeamWebhookDeliveryParams{
+		WebhookID: row.ID,
+		Event:     event,
+		Payload:   payload,
+	})
+	if err != nil {
+		logging.Default().Warn(ctx, "Failed to log team webhook delivery; it will not be retried",
+			"provider", row.Provider, "error", err)
+		err = s.post(ctx, row, uuid.NewString(), payload)
+		s.recordOutcome(ctx, row, err)
+		return err
+	}
+	_, err = s.attempt(ctx, row, d)
+	return err
+}
+
+// attempt posts a logged delivery and records the outcome on it and on the webhook. A
+// pending delivery that fails is scheduled for its next attempt under the retry policy,
+// or marked failed once the retry window is spent. Failing to record the outcome is
+// logged, not returned; d is then returned as it was.
+func (s *DefaultService) attempt(
+	ctx context.Context, row *queries.TeamWebhook, d *queries.TeamWebhookDelivery,
+) (*queries.TeamWebhookDelivery, error) {
+	err := s.post(ctx, row, d.ID.String(), d.Payload)
+	s.recordOutcome(ctx, row, err)
+
+	arg := queries.RecordTeamWebhookDeliveryAttemptParams{ID: d.ID, Status: DeliveryDelivered}
+	if err != nil {
+		arg.Status = DeliveryFailed
+		arg.LastError = pgtype.Text{String: err.Error(), Valid: true}
+		if next, ok := NextAttempt(d.CreatedAt.Time, d.Attempts+1, s.now()); ok && d.Status == DeliveryPending {
+			arg.Status = DeliveryPending
+			arg.NextAttemptAt = pgtype.Timestamptz{Time: next, Valid: true}
+		}
+	}
+	updated, recErr := s.querier.RecordTeamWebhookDeliveryAttempt(ctx, arg)
+	if recErr != nil {
+		logging.Default().Warn(ctx, "Failed to record team webhook delivery attempt",
+			"provider", row.Provider, "delivery_id", d.ID.String(), "error", recErr)
+		return d, err
+	}
+	return updated, err
+}
+
+// recordOutcome records a delivery's outcome as the webhook's last delivery. Failing to
+// record it is logged, not returned.
+func (s *DefaultService) recordOutcome(ctx context.Context, row *queries.TeamWebhook, deliveryErr error) {
+	outcome := pgtype.Text{}
+	if deliveryErr != nil {
+		outcome = pgtype.Text{String: deliveryErr.Error(), Valid: true}
+	}
+	if err := s.querier.RecordTeamWebhookDelivery(ctx, queries.RecordTeamWebhookDeliveryParams{
+		ID:                row.ID,
+		LastDeliveryError: outcome,
+	}); err != nil {
+		logging.Default().Warn(ctx, "Failed to record team webhook delivery", "provider", row.Provider, "error", err)
+	}
+}
+
+// post sends payload to the webhook URL, signed as delivery id. Anything but a 2xx response
+// is an error carrying the start of the response body, which is where Slack and Teams
+// explain rejections.
+func (s *DefaultService) post(ctx context.Context, row *queries.TeamWebhook, id string, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, row.Url, bytes.NewReader(payload))
+	if err != nil {
+		return errors.New("build request: invalid webhook URL")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if err := signRequest(req, row, id, s.now(), payload); err != nil {
+		return err
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		// The URL is a credential; keep it out of the error.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("post message: %w", err)
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBody))
+		return fmt.Errorf("%s responded %d: %s", providerName(row.Provider), res.StatusCode, strings.TrimSpace(string(body)))
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	return nil
+}
+
+// getWebhook returns the user's webhook for provider.
+func (s *DefaultService) getWebhook(ctx context.Context, userID, provider string) (*queries.TeamWebhook, error) {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.querier.GetTeamWebhook(ctx, queries.GetTeamWebhookParams{UserID: uid, Provider: provider})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team webhook: %w", err)
+	}
+	return row, nil
+}
+
+func parseUUID(id string) (pgtype.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf("invalid user ID: %w", err)
+	}
+	return pgtype.UUID{Bytes: parsed, Valid: true}, nil
+}
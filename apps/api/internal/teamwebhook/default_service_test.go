@@ -0,0 +1,416 @@
+package teamwebhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+const testSecret = "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw"
+
+func webhookRow(userID uuid.UUID, provider, url string) *queries.TeamWebhook {
+	return &queries.TeamWebhook{
+		ID:            pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		UserID:        pgtype.UUID{Bytes: userID, Valid: true},
+		Provider:      provider,
+		Url:           url,
+		Events:        []string{EventBatchComplete, EventPaymentFailed},
+		UpdatedAt:     pgtype.Timestamptz{Time: time.Unix(1700000000, 0).UTC(), Valid: true},
+		SigningSecret: testSecret,
+	}
+}
+
+// loggedDelivery stands in for CreateTeamWebhookDelivery, logging the delivery at created.
+func loggedDelivery(arg queries.CreateTeamWebhookDeliveryParams, created time.Time) *queries.TeamWebhookDelivery {
+	return &queries.TeamWebhookDelivery{
+		ID:            pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		WebhookID:     arg.WebhookID,
+		Event:         arg.Event,
+		Payload:       arg.Payload,
+		Status:        DeliveryPending,
+		NextAttemptAt: pgtype.Timestamptz{Time: created, Valid: true},
+		CreatedAt:     pgtype.Timestamptz{Time: created, Valid: true},
+	}
+}
+
+// receiver is a stand-in for a provider's incoming webhook endpoint.
+type receiver struct {
+	status  int
+	bodies  [][]byte
+	headers []http.Header
+}
+
+func (r *receiver) serve(t *testing.T) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		r.bodies = append(r.bodies, body)
+		r.headers = append(r.headers, req.Header.Clone())
+		w.WriteHeader(r.status)
+		_, _ = w.Write([]byte("invalid_token"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestDefaultService_Put(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name       string
+		provider   string
+		req        PutRequest
+		wantEvents []string
+		wantErr    error
+	}{
+		{
+			name:       "success: slack with every event by default",
+			provider:   ProviderSlack,
+			req:        PutRequest{URL: " https://hooks.slack.com/services/T0/B0/abc "},
+			wantEvents: Events,
+		},
+		{
+			name:       "success: teams workflow with one event",
+			provider:   ProviderTeams,
+			req:        PutRequest{URL: "https://prod-12.westus.logic.azure.com/workflows/abc", Events: []string{EventPaymentFailed}},
+			wantEvents: []string{EventPaymentFailed},
+		},
+		{name: "fail: unknown provider", provider: "discord", req: PutRequest{URL: "https://hooks.slack.com/x"}, wantErr: ErrInvalid},
+		{name: "fail: plain http", provider: ProviderSlack, req: PutRequest{URL: "http://hooks.slack.com/x"}, wantErr: ErrInvalid},
+		{name: "fail: other host", provider: ProviderSlack, req: PutRequest{URL: "https://evil.example.com/x"}, wantErr: ErrInvalid},
+		{name: "fail: lookalike host", provider: ProviderTeams, req: PutRequest{URL: "https://evilwebhook.office.com/x"}, wantErr: ErrInvalid},
+		{
+			name:     "fail: unknown event",
+			provider: ProviderSlack,
+			req:      PutRequest{URL: "https://hooks.slack.com/x", Events: []string{"image_ready"}},
+			wantErr:  ErrInvalid,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				UpsertTeamWebhookFunc: func(ctx context.Context, arg queries.UpsertTeamWebhookParams) (*queries.TeamWebhook, error) {
+					assert.Equal(t, userID, uuid.UUID(arg.UserID.Bytes))
+					row := webhookRow(userID, arg.Provider, arg.Url)
+					row.Events = arg.Events
+					return row, nil
+				},
+			}
+			hook, err := NewDefaultServiceWithQuerier(q, http.DefaultClient).Put(context.Background(), userID.String(), tc.provider, tc.req)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, q.UpsertTeamWebhookCalls())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantEvents, hook.Events)
+			assert.NotContains(t, hook.URL, "/services/T0", "the URL must be masked")
+			assert.Equal(t, testSecret, hook.SigningSecret)
+		})
+	}
+}
+
+func TestDefaultService_SendTest(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name      string
+		status    int
+		lookupErr error
+		wantErr   error
+	}{
+		{name: "success: delivered", status: http.StatusOK},
+		{name: "fail: rejected by provider", status: http.StatusForbidden, wantErr: ErrDelivery},
+		{name: "fail: none configured", lookupErr: pgx.ErrNoRows, wantErr: ErrNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rcv := &receiver{status: tc.status}
+			srv := rcv.serve(t)
+			var recorded *queries.RecordTeamWebhookDeliveryParams
+			q := &queries.QuerierMock{
+				GetTeamWebhookFunc: func(ctx context.Context, arg queries.GetTeamWebhookParams) (*queries.TeamWebhook, error) {
+					assert.Equal(t, ProviderTeams, arg.Provider)
+					if tc.lookupErr != nil {
+						return nil, tc.lookupErr
+					}
+					return webhookRow(userID, arg.Provider, srv.URL+"/hook"), nil
+				},
+				RecordTeamWebhookDeliveryFunc: func(ctx context.Context, arg queries.RecordTeamWebhookDeliveryParams) error {
+					recorded = &arg
+					return nil
+				},
+			}
+
+			hook, err := NewDefaultServiceWithQuerier(q, srv.Client()).SendTest(context.Background(), userID.String(), ProviderTeams)
+			if tc.lookupErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, rcv.bodies)
+				return
+			}
+			require.Len(t, rcv.bodies, 1)
+			assert.Contains(t, string(rcv.bodies[0]), "application/vnd.microsoft.card.adaptive")
+			assert.Contains(t, string(rcv.bodies[0]), "This Microsoft Teams webhook is set up")
+			assert.True(t, strings.HasPrefix(rcv.headers[0].Get(HeaderSignature), "v1,"))
+			assert.Empty(t, hook.SigningSecret)
+			require.NotNil(t, recorded)
+			require.NotNil(t, hook)
+			require.NotNil(t, hook.LastDeliveryAt)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Equal(t, "Microsoft Teams responded 403: invalid_token", recorded.LastDeliveryError.String)
+				assert.Equal(t, recorded.LastDeliveryError.String, hook.LastDeliveryError)
+				return
+			}
+			require.NoError(t, err)
+			assert.False(t, recorded.LastDeliveryError.Valid)
+			assert.Empty(t, hook.LastDeliveryError)
+		})
+	}
+}
+
+func TestDefaultService_Notify(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("success: posts to every subscribed webhook", func(t *testing.T) {
+		slack, teams := &receiver{status: http.StatusOK}, &receiver{status: http.StatusOK}
+		slackSrv, teamsSrv := slack.serve(t), teams.serve(t)
+		q := &queries.QuerierMock{
+			ListTeamWebhooksForEventFunc: func(ctx context.Context, arg queries.ListTeamWebhooksForEventParams) ([]*queries.TeamWebhook, error) {
+				assert.Equal(t, EventPaymentFailed, arg.Event)
+				return []*queries.TeamWebhook{
+					webhookRow(userID, ProviderSlack, slackSrv.URL),
+					webhookRow(userID, ProviderTeams, teamsSrv.URL),
+				}, nil
+			},
+			CreateTeamWebhookDeliveryFunc: func(ctx context.Context, arg queries.CreateTeamWebhookDeliveryParams) (*queries.TeamWebhookDelivery, error) {
+				assert.Equal(t, EventPaymentFailed, arg.Event)
+				return loggedDelivery(arg, time.Now()), nil
+			},
+			RecordTeamWebhookDeliveryAttemptFunc: func(ctx context.Context, arg queries.RecordTeamWebhookDeliveryAttemptParams) (*queries.TeamWebhookDelivery, error) {
+				assert.Equal(t, DeliveryDelivered, arg.Status)
+				assert.False(t, arg.NextAttemptAt.Valid)
+				return &queries.TeamWebhookDelivery{ID: arg.ID, Status: arg.Status}, nil
+			},
+			RecordTeamWebhookDeliveryFunc: func(ctx context.Context, arg queries.RecordTeamWebhookDeliveryParams) error {
+				return errors.New("db down")
+			},
+		}
+
+		err := NewDefaultServiceWithQuerier(q, http.DefaultClient).Notify(context.Background(), userID.String(),
+			EventPaymentFailed, map[string]string{"invoice": "INV-1 <b>", "amount": "USD 49.00"})
+		require.NoError(t, err, "failing to record a delivery is not an error")
+		require.Len(t, q.CreateTeamWebhookDeliveryCalls(), 2)
+		assert.Len(t, q.RecordTeamWebhookDeliveryAttemptCalls(), 2)
+
+		require.Len(t, slack.bodies, 1)
+		var payload struct {
+			Text string `json:"text"`
+		}
+		require.NoError(t, json.Unmarshal(slack.bodies[0], &payload))
+		assert.Equal(t, "Payment failed: We couldn't charge the payment method for invoice INV-1 &lt;b&gt; (USD 49.00). "+
+			"Update it to keep the subscription active.", payload.Text)
+		require.Len(t, teams.bodies, 1)
+		assert.Contains(t, string(teams.bodies[0]), "invoice INV-1 \\u003cb\\u003e (USD 49.00)")
+
+		h := slack.headers[0]
+		ts, err := strconv.ParseInt(h.Get(HeaderTimestamp), 10, 64)
+		require.NoError(t, err)
+		want, err := Sign([]string{testSecret}, h.Get(HeaderID), time.Unix(ts, 0), slack.bodies[0])
+		require.NoError(t, err)
+		assert.Equal(t, want, h.Get(HeaderSignature))
+	})
+
+	t.Run("success: posted once when the delivery cannot be logged", func(t *testing.T) {
+		rcv := &receiver{status: http.StatusOK}
+		srv := rcv.serve(t)
+		q := &queries.QuerierMock{
+			ListTeamWebhooksForEventFunc: func(ctx context.Context, arg queries.ListTeamWebhooksForEventParams) ([]*queries.TeamWebhook, error) {
+				return []*queries.TeamWebhook{webhookRow(userID, ProviderSlack, srv.URL)}, nil
+			},
+			CreateTeamWebhookDeliveryFunc: func(ctx context.Context, arg queries.CreateTeamWebhookDeliveryParams) (*queries.TeamWebhookDelivery, error) {
+				return nil, errors.New("db down")
+			},
+			RecordTeamWebhookDeliveryFunc: func(ctx context.Context, arg queries.RecordTeamWebhookDeliveryParams) error {
+				return nil
+			},
+		}
+		err := NewDefaultServiceWithQuerier(q, http.DefaultClient).Notify(context.Background(), userID.String(), EventPaymentFailed, nil)
+		require.NoError(t, err)
+		assert.Len(t, rcv.bodies, 1)
+	})
+
+	t.Run("success: no webhooks", func(t *testing.T) {
+		q := &queries.QuerierMock{
+			ListTeamWebhooksForEventFunc: func(ctx context.Context, arg queries.ListTeamWebhooksForEventParams) ([]*queries.TeamWebhook, error) {
+				return []*queries.TeamWebhook{}, nil
+			},
+		}
+		err := NewDefaultServiceWithQuerier(q, http.DefaultClient).Notify(context.Background(), userID.String(), EventPaymentFailed, nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("fail: delivery error is returned and the delivery scheduled for retry", func(t *testing.T) {
+		rcv := &receiver{status: http.StatusNotFound}
+		srv := rcv.serve(t)
+		now := time.Now()
+		q := &queries.QuerierMock{
+			ListTeamWebhooksForEventFunc: func(ctx context.Context, arg queries.ListTeamWebhooksForEventParams) ([]*queries.TeamWebhook, error) {
+				return []*queries.TeamWebhook{webhookRow(userID, ProviderSlack, srv.URL)}, nil
+			},
+			CreateTeamWebhookDeliveryFunc: func(ctx context.Context, arg queries.CreateTeamWebhookDeliveryParams) (*queries.TeamWebhookDelivery, error) {
+				return loggedDelivery(arg, now), nil
+			},
+			RecordTeamWebhookDeliveryAttemptFunc: func(ctx context.Context, arg queries.RecordTeamWebhookDeliveryAttemptParams) (*queries.TeamWebhookDelivery, error) {
+				return &queries.TeamWebhookDelivery{ID: arg.ID, Status: arg.Status}, nil
+			},
+			RecordTeamWebhookDeliveryFunc: func(ctx context.Context, arg queries.RecordTeamWebhookDeliveryParams) error {
+				return nil
+			},
+		}
+		svc := NewDefaultServiceWithQuerier(q, http.DefaultClient)
+		svc.now = func() time.Time { return now }
+		err := svc.Notify(context.Background(), userID.String(), EventPaymentFailed, nil)
+		assert.ErrorContains(t, err, "slack webhook: Slack responded 404")
+
+		calls := q.RecordTeamWebhookDeliveryAttemptCalls()
+		require.Len(t, calls, 1)
+		assert.Equal(t, DeliveryPending, calls[0].Arg.Status)
+		assert.Equal(t, now.Add(retryBase), calls[0].Arg.NextAttemptAt.Time)
+		assert.Equal(t, "Slack responded 404: invalid_token", calls[0].Arg.LastError.String)
+	})
+}
+
+func TestDefaultService_RotateSecret(t *testing.T) {
+	userID := uuid.New()
+	now := time.Unix(1700000000, 0).UTC()
+
+	testCases := []struct {
+		name    string
+		err     error
+		wantErr error
+	}{
+		{name: "success: new secret, old one kept for a day"},
+		{name: "fail: none configured", err: pgx.ErrNoRows, wantErr: ErrNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				RotateTeamWebhookSecretFunc: func(ctx context.Context, arg queries.RotateTeamWebhookSecretParams) (*queries.TeamWebhook, error) {
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					row := webhookRow(userID, arg.Provider, "https://hooks.slack.com/services/T0/B0/abc")
+					row.PreviousSigningSecret = pgtype.Text{String: row.SigningSecret, Valid: true}
+					row.SigningSecret = arg.SigningSecret
+					row.PreviousSecretExpiresAt = arg.PreviousSecretExpiresAt
+					return row, nil
+				},
+			}
+			svc := NewDefaultServiceWithQuerier(q, http.DefaultClient)
+			svc.now = func() time.Time { return now }
+
+			hook, err := svc.RotateSecret(context.Background(), userID.String(), ProviderSlack)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(hook.SigningSecret, secretPrefix))
+			assert.NotEqual(t, testSecret, hook.SigningSecret)
+			require.NotNil(t, hook.PreviousSecretExpiresAt)
+			assert.Equal(t, now.Add(rotationOverlap), *hook.PreviousSecretExpiresAt)
+		})
+	}
+}
+
+func TestDefaultService_Redeliver(t *testing.T) {
+	userID := uuid.New()
+	deliveryID := uuid.New()
+
+	testCases := []struct {
+		name       string
+		status     int
+		delivery   string
+		lookupErr  error
+		wantStatus string
+		wantErr    error
+	}{
+		{name: "success: delivered", status: http.StatusOK, delivery: DeliveryFailed, wantStatus: DeliveryDelivered},
+		{name: "fail: rejected again stays failed", status: http.StatusGone, delivery: DeliveryFailed, wantStatus: DeliveryFailed, wantErr: ErrDelivery},
+		{name: "fail: still pending", delivery: DeliveryPending, wantErr: ErrNotRedeliverable},
+		{name: "fail: unknown delivery", lookupErr: pgx.ErrNoRows, wantErr: ErrDeliveryNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rcv := &receiver{status: tc.status}
+			srv := rcv.serve(t)
+			hook := webhookRow(userID, ProviderSlack, srv.URL)
+			q := &queries.QuerierMock{
+				GetTeamWebhookFunc: func(ctx context.Context, arg queries.GetTeamWebhookParams) (*queries.TeamWebhook, error) {
+					return hook, nil
+				},
+				GetTeamWebhookDeliveryFunc: func(ctx context.Context, arg queries.GetTeamWebhookDeliveryParams) (*queries.TeamWebhookDelivery, error) {
+					assert.Equal(t, deliveryID, uuid.UUID(arg.ID.Bytes))
+					assert.Equal(t, hook.ID, arg.WebhookID)
+					if tc.lookupErr != nil {
+						return nil, tc.lookupErr
+					}
+					return &queries.TeamWebhookDelivery{
+						ID:        arg.ID,
+						WebhookID: arg.WebhookID,
+						Payload:   []byte(`{"text":"hi"}`),
+						Status:    tc.delivery,
+						Attempts:  13,
+						CreatedAt: pgtype.Timestamptz{Time: time.Now().Add(-2 * retryWindow), Valid: true},
+					}, nil
+				},
+				RecordTeamWebhookDeliveryAttemptFunc: func(ctx context.Context, arg queries.RecordTeamWebhookDeliveryAttemptParams) (*queries.TeamWebhookDelivery, error) {
+					return &queries.TeamWebhookDelivery{ID: arg.ID, Status: arg.Status, Attempts: 14, LastError: arg.LastError}, nil
+				},
+				RecordTeamWebhookDeliveryFunc: func(ctx context.Context, arg queries.RecordTeamWebhookDeliveryParams) error {
+					return nil
+				},
+			}
+
+			d, err := NewDefaultServiceWithQuerier(q, http.DefaultClient).Redeliver(context.Background(),
+				userID.String(), ProviderSlack, deliveryID.String())
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			if tc.wantStatus == "" {
+				assert.Nil(t, d)
+				assert.Empty(t, rcv.bodies)
+				return
+			}
+			require.NotNil(t, d)
+			assert.Equal(t, tc.wantStatus, d.Status)
+			require.Len(t, rcv.bodies, 1)
+			assert.JSONEq(t, `{"text":"hi"}`, string(rcv.bodies[0]))
+			assert.Equal(t, deliveryID.String(), rcv.headers[0].Get(HeaderID))
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package teamwebhook
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// Delivery statuses. A delivery is pending until an attempt succeeds or its retry window
+// ends.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+// Retry policy. Failed attempts are retried after retryBase, doubling each time up to
+// retryMaxDelay, for as long as the next attempt falls within retryWindow of the delivery
+// being logged: about 13 attempts.
+const (
+	retryBase     = time.Minute
+	retryMaxDelay = 4 * time.Hour
+	retryWindow   = 24 * time.Hour
+)
+
+const (
+	// DefaultDeliveryLimit is how many deliveries are listed when no limit is requested.
+	DefaultDeliveryLimit int32 = 50
+	// MaxDeliveryLimit caps how many deliveries are listed.
+	MaxDeliveryLimit int32 = 200
+)
+
+// NextAttempt returns when a delivery logged at created should be tried again after its
+// attempts-th failed attempt at now. ok is false once the retry window is spent.
+func NextAttempt(created time.Time, attempts int32, now time.Time) (next time.Time, ok bool) {
+	delay := retryMaxDelay
+	if attempts >= 1 && attempts <= 16 {
+		delay = min(retryBase<<(attempts-1), retryMaxDelay)
+	}
+	next = now.Add(delay)
+	if next.After(created.Add(retryWindow)) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+// Delivery is one message logged for a webhook, with its attempts so far.
+type Delivery struct {
+	ID       string `json:"id"`
+	Event    string `json:"event"`
+	Status   string `json:"status"`
+	Attempts int32  `json:"attempts"`
+	// NextAttemptAt is when a pending delivery is next tried.
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	LastAttemptAt *time.Time `json:"last_attempt_at,omitempty"`
+	// LastError says why the last attempt failed.
+	LastError   string     `json:"last_error,omitempty"`
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// deliveryFromRow converts a team_webhook_deliveries row. The payload is left out; it is
+// the message as the provider renders it.
+func deliveryFromRow(row *queries.TeamWebhookDelivery) *Delivery {
+	return &Delivery{
+		ID:            row.ID.String(),
+		Event:         row.Event,
+		Status:        row.Status,
+		Attempts:      row.Attempts,
+		NextAttemptAt: optionalTime(row.NextAttemptAt),
+		LastAttemptAt: optionalTime(row.LastAttemptAt),
+		LastError:     row.LastError.String,
+		DeliveredAt:   optionalTime(row.DeliveredAt),
+		CreatedAt:     row.CreatedAt.Time,
+	}
+}
+
+func optionalTime(ts pgtype.Timestamptz) *time.Time {
+	if !ts.Valid {
+		return nil
+	}
+	t := ts.Time
+	return &t
+}
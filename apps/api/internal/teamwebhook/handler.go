@@ -0,0 +1,17 @@
+package teamwebhook
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP-level contract for team webhook endpoints.
+// Implementations should be wired to Echo routes in the server.
+type Handler interface {
+	List(c echo.Context) error
+	Put(c echo.Context) error
+	Delete(c echo.Context) error
+	Test(c echo.Context) error
+	RotateSecret(c echo.Context) error
+	ListDeliveries(c echo.Context) error
+	Redeliver(c echo.Context) error
+}
@@ -0,0 +1,93 @@
+package teamwebhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Message is a rendered notification, before it is shaped for a provider.
+type Message struct {
+	Title string
+	Text  string
+}
+
+// messageTemplate renders one event's message from the event's data.
+type messageTemplate struct {
+	title *template.Template
+	text  *template.Template
+}
+
+func newTemplate(event, title, text string) messageTemplate {
+	return messageTemplate{
+		title: template.Must(template.New(event + ".title").Option("missingkey=zero").Parse(title)),
+		text:  template.Must(template.New(event + ".text").Option("missingkey=zero").Parse(text)),
+	}
+}
+
+// templates holds the messages the API posts. The worker renders batch_complete itself.
+var templates = map[string]messageTemplate{
+	EventPaymentFailed: newTemplate(EventPaymentFailed,
+		"Payment failed",
+		"We couldn't charge the payment method for invoice {{.invoice}}{{with .amount}} ({{.}}){{end}}. "+
+			"Update it to keep the subscription active."),
+	eventTest: newTemplate(eventTest,
+		"Test message from Real Staging AI",
+		"This {{.provider}} webhook is set up to receive Real Staging AI notifications."),
+}
+
+// Render executes the event's template with data.
+func Render(event string, data map[string]string) (Message, error) {
+	t, ok := templates[event]
+	if !ok {
+		return Message{}, fmt.Errorf("no message template for event %q", event)
+	}
+	var title, text strings.Builder
+	if err := t.title.Execute(&title, data); err != nil {
+		return Message{}, fmt.Errorf("render %s title: %w", event, err)
+	}
+	if err := t.text.Execute(&text, data); err != nil {
+		return Message{}, fmt.Errorf("render %s text: %w", event, err)
+	}
+	return Message{Title: title.String(), Text: text.String()}, nil
+}
+
+// Payload shapes m as the provider's incoming webhook expects it: Block Kit for Slack and
+// an Adaptive Card message, which both Teams connectors and Workflows accept, for Teams.
+func Payload(provider string, m Message) ([]byte, error) {
+	switch provider {
+	case ProviderSlack:
+		text := slackEscape(m.Text)
+		return json.Marshal(map[string]any{
+			"text": slackEscape(m.Title) + ": " + text,
+			"blocks": []map[string]any{
+				{"type": "header", "text": map[string]string{"type": "plain_text", "text": m.Title}},
+				{"type": "section", "text": map[string]string{"type": "mrkdwn", "text": text}},
+			},
+		})
+	case ProviderTeams:
+		return json.Marshal(map[string]any{
+			"type": "message",
+			"attachments": []map[string]any{{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]any{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"body": []map[string]any{
+						{"type": "TextBlock", "text": m.Title, "weight": "Bolder", "size": "Medium", "wrap": true},
+						{"type": "TextBlock", "text": m.Text, "wrap": true},
+					},
+				},
+			}},
+		})
+	default:
+		return nil, fmt.Errorf("%w: unknown provider %q", ErrInvalid, provider)
+	}
+}
+
+// slackEscape escapes the characters Slack treats as markup in mrkdwn and plain text.
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
@@ -0,0 +1,33 @@
+package teamwebhook
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service Notifier
+
+// Service manages a user's team webhooks. Every call is scoped to the user.
+type Service interface {
+	// List returns the user's webhooks, at most one per provider.
+	List(ctx context.Context, userID string) ([]*Webhook, error)
+	// Put saves the user's webhook for provider, replacing any earlier one.
+	Put(ctx context.Context, userID, provider string, req PutRequest) (*Webhook, error)
+	// Delete removes the user's webhook for provider.
+	Delete(ctx context.Context, userID, provider string) error
+	// RotateSecret replaces the signing secret of the user's webhook for provider and
+	// returns the webhook with the new secret. The old one keeps signing for a day.
+	RotateSecret(ctx context.Context, userID, provider string) (*Webhook, error)
+	// SendTest posts a test message to the user's webhook for provider and records the outcome.
+	SendTest(ctx context.Context, userID, provider string) (*Webhook, error)
+	// ListDeliveries returns up to limit of the latest deliveries of the user's webhook for
+	// provider.
+	ListDeliveries(ctx context.Context, userID, provider string, limit int32) ([]*Delivery, error)
+	// Redeliver posts a failed delivery of the user's webhook for provider once more.
+	Redeliver(ctx context.Context, userID, provider, deliveryID string) (*Delivery, error)
+}
+
+// Notifier posts events to the webhooks of the user they concern. Producers depend on it
+// rather than on Service.
+type Notifier interface {
+	// Notify renders the event's message from data and posts it to each of the user's
+	// webhooks subscribed to the event. A user without webhooks is not an error.
+	Notify(ctx context.Context, userID, event string, data map[string]string) error
+}
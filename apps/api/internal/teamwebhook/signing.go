@@ -0,0 +1,82 @@
+package teamwebhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// Deliveries are signed as Standard Webhooks (https://www.standardwebhooks.com) describes:
+// webhook-signature carries "v1,<base64 HMAC-SHA256>" of "<webhook-id>.<webhook-timestamp>.<body>"
+// for each current secret, space-separated. The worker signs batch_complete the same way.
+const (
+	HeaderID        = "webhook-id"
+	HeaderTimestamp = "webhook-timestamp"
+	HeaderSignature = "webhook-signature"
+)
+
+const (
+	// secretPrefix marks signing secrets; the key is the base64 that follows it.
+	secretPrefix = "whsec_"
+	// secretBytes is the length of a signing key.
+	secretBytes = 32
+	// rotationOverlap is how long a rotated-out secret keeps signing deliveries, giving
+	// receivers time to switch to the new one.
+	rotationOverlap = 24 * time.Hour
+)
+
+// newSecret returns a random signing secret.
+func newSecret() (string, error) {
+	key := make([]byte, secretBytes)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("failed to generate signing secret: %w", err)
+	}
+	return secretPrefix + base64.StdEncoding.EncodeToString(key), nil
+}
+
+// Sign returns the webhook-signature value for body under each secret.
+func Sign(secrets []string, id string, ts time.Time, body []byte) (string, error) {
+	signed := id + "." + strconv.FormatInt(ts.Unix(), 10) + "."
+	sigs := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, secretPrefix))
+		if err != nil {
+			return "", fmt.Errorf("invalid signing secret: %w", err)
+		}
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(signed))
+		mac.Write(body)
+		sigs = append(sigs, "v1,"+base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	}
+	return strings.Join(sigs, " "), nil
+}
+
+// signingSecrets returns the secrets the webhook's deliveries are signed with at now: its
+// current secret, and the previous one until it expires.
+func signingSecrets(row *queries.TeamWebhook, now time.Time) []string {
+	secrets := []string{row.SigningSecret}
+	if row.PreviousSigningSecret.Valid && row.PreviousSecretExpiresAt.Valid && now.Before(row.PreviousSecretExpiresAt.Time) {
+		secrets = append(secrets, row.PreviousSigningSecret.String)
+	}
+	return secrets
+}
+
+// signRequest sets the signature headers of a delivery of body to the webhook.
+func signRequest(req *http.Request, row *queries.TeamWebhook, id string, now time.Time, body []byte) error {
+	sig, err := Sign(signingSecrets(row, now), id, now, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(HeaderID, id)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(HeaderSignature, sig)
+	return nil
+}
@@ -0,0 +1,75 @@
+package teamwebhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+func TestSign(t *testing.T) {
+	// Test vector from the Standard Webhooks reference libraries.
+	sig, err := Sign([]string{testSecret}, "msg_p5jXN8AQM9LWM0D4loKWxJek", time.Unix(1614265330, 0),
+		[]byte(`{"test": 2432232314}`))
+	require.NoError(t, err)
+	assert.Equal(t, "v1,g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE=", sig)
+
+	_, err = Sign([]string{"whsec_not base64"}, "msg", time.Unix(0, 0), nil)
+	assert.Error(t, err)
+}
+
+func TestSigningSecrets(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	previous := pgtype.Text{String: "whsec_b2xk", Valid: true}
+
+	testCases := []struct {
+		name    string
+		expires time.Time
+		want    []string
+	}{
+		{name: "success: previous secret within overlap", expires: now.Add(time.Hour), want: []string{testSecret, "whsec_b2xk"}},
+		{name: "success: previous secret expired", expires: now.Add(-time.Hour), want: []string{testSecret}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			row := &queries.TeamWebhook{
+				SigningSecret:           testSecret,
+				PreviousSigningSecret:   previous,
+				PreviousSecretExpiresAt: pgtype.Timestamptz{Time: tc.expires, Valid: true},
+			}
+			assert.Equal(t, tc.want, signingSecrets(row, now))
+		})
+	}
+}
+
+func TestNextAttempt(t *testing.T) {
+	created := time.Unix(1700000000, 0)
+
+	testCases := []struct {
+		name     string
+		attempts int32
+		now      time.Time
+		wantWait time.Duration
+		wantOK   bool
+	}{
+		{name: "success: first retry after a minute", attempts: 1, now: created, wantWait: time.Minute, wantOK: true},
+		{name: "success: doubles", attempts: 4, now: created.Add(time.Hour), wantWait: 8 * time.Minute, wantOK: true},
+		{name: "success: capped", attempts: 12, now: created.Add(10 * time.Hour), wantWait: retryMaxDelay, wantOK: true},
+		{name: "fail: past the retry window", attempts: 13, now: created.Add(21 * time.Hour)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			next, ok := NextAttempt(created, tc.attempts, tc.now)
+			assert.Equal(t, tc.wantOK, ok)
+			if tc.wantOK {
+				assert.Equal(t, tc.now.Add(tc.wantWait), next)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+// Package teamwebhook posts an account's team notifications to Slack and Microsoft Teams
+// through incoming webhooks the account registers, one per provider. Each webhook is
+// subscribed to a set of events; messages are rendered from per-event templates into the
+// provider's payload format. The webhook URL is its only credential, so it is stored as
+// given but never returned in full.
+//
+// Every message is logged as a delivery, signed with the webhook's secret, and retried with
+// exponential backoff for 24 hours until the provider accepts it; the worker runs the
+// retries. Deliveries that exhaust their retries can be redelivered by hand.
+package teamwebhook
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// Providers a webhook can be registered for.
+const (
+	ProviderSlack = "slack"
+	ProviderTeams = "teams"
+)
+
+// Events a webhook can subscribe to. The worker posts batch_complete; the API posts
+// payment_failed.
+const (
+	EventBatchComplete = "batch_complete"
+	EventPaymentFailed = "payment_failed"
+)
+
+// Events lists every event, and is what a webhook subscribes to when none are given.
+var Events = []string{EventBatchComplete, EventPaymentFailed}
+
+// eventTest is the event of the message SendTest posts. Webhooks cannot subscribe to it.
+const eventTest = "test"
+
+var (
+	// ErrNotFound is returned when the user has no webhook for the provider.
+	ErrNotFound = errors.New("no webhook configured")
+	// ErrInvalid is returned for an unknown provider or malformed webhook settings.
+	ErrInvalid = errors.New("invalid webhook settings")
+	// ErrDelivery is returned when the provider did not accept a test message or redelivery.
+	ErrDelivery = errors.New("webhook delivery failed")
+	// ErrDeliveryNotFound is returned for a delivery the user's webhook does not have.
+	ErrDeliveryNotFound = errors.New("delivery not found")
+	// ErrNotRedeliverable is returned when redelivering a delivery that has not failed.
+	ErrNotRedeliverable = errors.New("only failed deliveries can be redelivered")
+)
+
+// providerHosts are the hosts each provider issues incoming webhook URLs on. A leading dot
+// matches any subdomain. Restricting hosts keeps the API from posting to arbitrary URLs.
+var providerHosts = map[string][]string{
+	ProviderSlack: {"hooks.slack.com"},
+	// Office 365 connectors, and Workflows webhooks on Logic Apps or Power Platform.
+	ProviderTeams: {".webhook.office.com", ".logic.azure.com", ".powerplatform.com"},
+}
+
+// Webhook is an account's webhook for one provider, with its URL masked.
+type Webhook struct {
+	Provider string   `json:"provider"`
+	URL      string   `json:"url"`
+	Events   []string `json:"events"`
+	// LastDeliveryAt is when a message was last posted, successfully or not.
+	LastDeliveryAt *time.Time `json:"last_delivery_at,omitempty"`
+	// LastDeliveryError says why the last delivery failed. It is cleared by a successful one.
+	LastDeliveryError string `json:"last_delivery_error,omitempty"`
+	// SigningSecret is returned only when the webhook is saved or its secret rotated.
+	SigningSecret string `json:"signing_secret,omitempty"`
+	// PreviousSecretExpiresAt is when the secret replaced by the last rotation stops signing
+	// deliveries; absent once it has.
+	PreviousSecretExpiresAt *time.Time `json:"previous_secret_expires_at,omitempty"`
+	UpdatedAt               time.Time  `json:"updated_at"`
+}
+
+// PutRequest sets the webhook for a provider.
+type PutRequest struct {
+	URL string `json:"url"`
+	// Events the webhook receives; empty subscribes it to all of them.
+	Events []string `json:"events"`
+}
+
+// validate checks r for provider and fills in the default events.
+func (r *PutRequest) validate(provider string) error {
+	hosts, ok := providerHosts[provider]
+	if !ok {
+		return fmt.Errorf("%w: provider must be slack or teams", ErrInvalid)
+	}
+	r.URL = strings.TrimSpace(r.URL)
+	u, err := url.Parse(r.URL)
+	if err != nil || u.Scheme != "https" || u.User != nil || !hostAllowed(u.Hostname(), hosts) {
+		return fmt.Errorf("%w: url must be an https %s incoming webhook URL", ErrInvalid, providerName(provider))
+	}
+	if len(r.Events) == 0 {
+		r.Events = slices.Clone(Events)
+	}
+	for _, e := range r.Events {
+		if !slices.Contains(Events, e) {
+			return fmt.Errorf("%w: unknown event %q", ErrInvalid, e)
+		}
+	}
+	slices.Sort(r.Events)
+	r.Events = slices.Compact(r.Events)
+	return nil
+}
+
+func hostAllowed(host string, allowed []string) bool {
+	host = strings.ToLower(host)
+	for _, h := range allowed {
+		if host == h || (strings.HasPrefix(h, ".") && strings.HasSuffix(host, h)) {
+			return true
+		}
+	}
+	return false
+}
+
+// providerName is the provider as written in messages.
+func providerName(provider string) string {
+	if provider == ProviderTeams {
+		return "Microsoft Teams"
+	}
+	return "Slack"
+}
+
+// maskURL keeps the scheme, host and last four characters of a webhook URL.
+func maskURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "…"
+	}
+	tail := raw
+	if len(tail) > 4 {
+		tail = tail[len(tail)-4:]
+	}
+	return u.Scheme + "://" + u.Host + "/…" + tail
+}
+
+// webhookFromRow converts a team_webhooks row, leaving out its signing secret.
+func webhookFromRow(row *queries.TeamWebhook, now time.Time) *Webhook {
+	w := &Webhook{
+		Provider:          row.Provider,
+		URL:               maskURL(row.Url),
+		Events:            row.Events,
+		LastDeliveryError: row.LastDeliveryError.String,
+		UpdatedAt:         row.UpdatedAt.Time,
+	}
+	if w.Events == nil {
+		w.Events = []string{}
+	}
+	if row.LastDeliveryAt.Valid {
+		t := row.LastDeliveryAt.Time
+		w.LastDeliveryAt = &t
+	}
+	if row.PreviousSigningSecret.Valid && row.PreviousSecretExpiresAt.Time.After(now) {
+		w.PreviousSecretExpiresAt = optionalTime(row.PreviousSecretExpiresAt)
+	}
+	return w
+}
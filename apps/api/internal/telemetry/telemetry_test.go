@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -81,3 +82,13 @@ func TestInitTracing(t *testing.T) {
 		})
 	}
 }
+
+func TestInitMetrics(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4319")
+
+	shutdown, err := InitMetrics(context.Background(), "test-service", time.Hour)
+	assert.NoError(t, err)
+	assert.NotNil(t, shutdown)
+	// Shutdown flushes to the collector, which is not running in tests
+	_ = shutdown(context.Background())
+}
@@ -0,0 +1,93 @@
+package telemetry
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Span attributes summarizing what a request cost. The collector's tail sampling keeps
+// every trace whose root span crosses its thresholds (see infra/otelcol.yaml).
+const (
+	AttrDBQueryCount     = "db.query_count"
+	AttrS3OperationCount = "s3.operation_count"
+)
+
+// Usage accumulates the work done for one request. It is safe for concurrent use. A nil
+// *Usage ignores everything recorded on it.
+type Usage struct {
+	dbQueries    atomic.Int64
+	s3Operations atomic.Int64
+}
+
+type usageKey struct{}
+
+// WithUsage returns a context carrying a new Usage, which the DB and S3 calls made with it
+// record into.
+func WithUsage(ctx context.Context) (context.Context, *Usage) {
+	u := &Usage{}
+	return context.WithValue(ctx, usageKey{}, u), u
+}
+
+// UsageFrom returns the Usage carried by ctx, or nil.
+func UsageFrom(ctx context.Context) *Usage {
+	u, _ := ctx.Value(usageKey{}).(*Usage)
+	return u
+}
+
+// AddDBQuery counts a database statement.
+func (u *Usage) AddDBQuery() {
+	if u != nil {
+		u.dbQueries.Add(1)
+	}
+}
+
+// AddS3Operation counts an S3 request sent, retries included.
+func (u *Usage) AddS3Operation() {
+	if u != nil {
+		u.s3Operations.Add(1)
+	}
+}
+
+// Attributes returns the span attributes summarizing the usage.
+func (u *Usage) Attributes() []attribute.KeyValue {
+	if u == nil {
+		return nil
+	}
+	return []attribute.KeyValue{
+		attribute.Int64(AttrDBQueryCount, u.dbQueries.Load()),
+		attribute.Int64(AttrS3OperationCount, u.s3Operations.Load()),
+	}
+}
+
+// UsageMiddleware records each request's usage and summarizes it on the request's span.
+// It must run inside otelecho's middleware, which starts that span.
+func UsageMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx, usage := WithUsage(c.Request().Context())
+			c.SetRequest(c.Request().WithContext(ctx))
+			defer func() { trace.SpanFromContext(ctx).SetAttributes(usage.Attributes()...) }()
+			return next(c)
+		}
+	}
+}
+
+// CountS3Operations is an s3.Options function that counts each request the client sends
+// into the Usage of its context. Presigning sends nothing and is not counted.
+func CountS3Operations(o *s3.Options) {
+	o.APIOptions = append(o.APIOptions, func(stack *middleware.Stack) error {
+		return stack.Deserialize.Add(middleware.DeserializeMiddlewareFunc("CountS3Operation",
+			func(
+				ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler,
+			) (middleware.DeserializeOutput, middleware.Metadata, error) {
+				UsageFrom(ctx).AddS3Operation()
+				return next.HandleDeserialize(ctx, in)
+			}), middleware.After)
+	})
+}
@@ -0,0 +1,86 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestUsage_Attributes(t *testing.T) {
+	ctx, u := WithUsage(context.Background())
+	require.Same(t, u, UsageFrom(ctx))
+	u.AddDBQuery()
+	u.AddDBQuery()
+	u.AddS3Operation()
+
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.Int64(AttrDBQueryCount, 2),
+		attribute.Int64(AttrS3OperationCount, 1),
+	}, u.Attributes())
+}
+
+func TestUsage_NilIgnoresRecords(t *testing.T) {
+	u := UsageFrom(context.Background())
+	require.Nil(t, u)
+	u.AddDBQuery()
+	u.AddS3Operation()
+	assert.Nil(t, u.Attributes())
+}
+
+func TestUsageMiddleware(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, span := tp.Tracer("test").Start(context.Background(), "GET /api/v1/projects")
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/projects", nil).WithContext(ctx)
+	c := e.NewContext(req, httptest.NewRecorder())
+	handler := UsageMiddleware()(func(c echo.Context) error {
+		require.Equal(t, span.SpanContext(), trace.SpanFromContext(c.Request().Context()).SpanContext())
+		UsageFrom(c.Request().Context()).AddDBQuery()
+		return c.NoContent(http.StatusOK)
+	})
+
+	require.NoError(t, handler(c))
+	span.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Contains(t, spans[0].Attributes(), attribute.Int64(AttrDBQueryCount, 1))
+	assert.Contains(t, spans[0].Attributes(), attribute.Int64(AttrS3OperationCount, 0))
+}
+
+func TestCountS3Operations(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := s3.New(s3.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(srv.URL),
+		UsePathStyle: true,
+		Credentials:  credentials.NewStaticCredentialsProvider("test", "test", ""),
+	}, CountS3Operations)
+	ctx, u := WithUsage(context.Background())
+
+	_, err := client.HeadObject(ctx, &s3.HeadObjectInput{Bucket: aws.String("b"), Key: aws.String("k")})
+	require.NoError(t, err)
+	_, err = s3.NewPresignClient(client).PresignGetObject(ctx,
+		&s3.GetObjectInput{Bucket: aws.String("b"), Key: aws.String("k")})
+	require.NoError(t, err)
+
+	assert.Contains(t, u.Attributes(), attribute.Int64(AttrS3OperationCount, 1))
+}
@@ -0,0 +1,168 @@
+// Package uploadhint recommends how a client should prepare an upload for its connection.
+//
+// Phones shoot 48MP originals that are far larger than the staging model uses, so sending
+// them as-is over LTE wastes the user's data and time. Given what a device reports about
+// its connection and the original photo, Negotiate returns the resolution, format and
+// quality to re-encode at and the chunk size for multipart transfers.
+package uploadhint
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Connection classes, from fastest to slowest.
+const (
+	ClassFast        = "fast"
+	ClassCellular    = "cellular"
+	ClassConstrained = "constrained"
+)
+
+// MaxUploadBytes is the largest original POST /uploads/presign accepts.
+const MaxUploadBytes = 10 * 1024 * 1024
+
+// MinChunkBytes is the smallest multipart part S3 accepts, other than the last.
+const MinChunkBytes = 5 * 1024 * 1024
+
+// policy is how uploads are prepared on one connection class.
+type policy struct {
+	// maxLongEdge caps the longer side of the uploaded image, in pixels.
+	maxLongEdge int
+	// quality is the lossy encoder quality, 1-100.
+	quality int
+	// chunkBytes is the multipart part size.
+	chunkBytes int64
+}
+
+// policies are per connection class. The staging model works at 2048px or less, so even
+// fast connections gain nothing from originals beyond 4096px. Fast connections send any
+// allowed upload in one request; slower ones use the smallest parts S3 accepts, so a
+// dropped connection costs at most one part.
+var policies = map[string]policy{
+	ClassFast:        {maxLongEdge: 4096, quality: 90, chunkBytes: MaxUploadBytes},
+	ClassCellular:    {maxLongEdge: 3072, quality: 85, chunkBytes: MinChunkBytes},
+	ClassConstrained: {maxLongEdge: 2048, quality: 80, chunkBytes: MinChunkBytes},
+}
+
+// bytesPerPixel estimates encoded size per pixel at the policy qualities when the original
+// file size is unknown.
+var bytesPerPixel = map[string]float64{"image/jpeg": 0.35, "image/webp": 0.25}
+
+// ErrInvalidCapabilities is returned when the reported capabilities cannot be used.
+var ErrInvalidCapabilities = errors.New("invalid upload capabilities")
+
+// Capabilities is what a device reports about its connection and the photo it will upload.
+type Capabilities struct {
+	// ConnectionType is the network the device is on: wifi, ethernet, cellular or unknown.
+	ConnectionType string `json:"connection_type,omitempty"`
+	// EffectiveType is the Network Information API effective type: slow-2g, 2g, 3g or 4g.
+	EffectiveType string `json:"effective_type,omitempty"`
+	// DownlinkMbps is the estimated downlink bandwidth.
+	DownlinkMbps float64 `json:"downlink_mbps,omitempty"`
+	// SaveData is set when the user asked for reduced data usage.
+	SaveData bool `json:"save_data,omitempty"`
+	// Width and Height are the original's dimensions in pixels.
+	Width  int `json:"width"`
+	Height int `json:"height"`
+	// FileSize is the original's size in bytes, 0 when unknown.
+	FileSize int64 `json:"file_size,omitempty"`
+	// SupportsWebP is set when the device can encode WebP.
+	SupportsWebP bool `json:"supports_webp,omitempty"`
+}
+
+// Recommendation is how to prepare and send the upload.
+type Recommendation struct {
+	// Class is the connection class the recommendation was made for.
+	Class string `json:"connection_class"`
+	// Resize is set when the original should be scaled down to TargetWidth x TargetHeight.
+	Resize       bool `json:"resize"`
+	TargetWidth  int  `json:"target_width"`
+	TargetHeight int  `json:"target_height"`
+	// Format is the content type to encode as, image/webp or image/jpeg.
+	Format string `json:"format"`
+	// Quality is the encoder quality, 1-100.
+	Quality int `json:"quality"`
+	// EstimatedBytes is the expected size of the encoded upload.
+	EstimatedBytes int64 `json:"estimated_bytes"`
+	// MaxFileSize is the largest upload the presign endpoint accepts.
+	MaxFileSize int64 `json:"max_file_size"`
+	// ChunkSizeBytes is the part size for multipart transfers. Uploads no larger than it
+	// are sent in one request.
+	ChunkSizeBytes int64 `json:"chunk_size_bytes"`
+}
+
+// Validate checks that the capabilities describe a photo.
+func (c Capabilities) Validate() error {
+	switch {
+	case c.Width <= 0 || c.Height <= 0:
+		return fmt.Errorf("%w: width and height must be positive", ErrInvalidCapabilities)
+	case c.FileSize < 0:
+		return fmt.Errorf("%w: file_size must not be negative", ErrInvalidCapabilities)
+	case c.DownlinkMbps < 0:
+		return fmt.Errorf("%w: downlink_mbps must not be negative", ErrInvalidCapabilities)
+	}
+	return nil
+}
+
+// Class returns the connection class of c. Save-Data and 2g/3g effective types are
+// constrained whatever the network; cellular and 4g are cellular unless the downlink is
+// fast; wifi and ethernet are fast. Unknown connections are treated as cellular.
+func (c Capabilities) Class() string {
+	effective := strings.ToLower(strings.TrimSpace(c.EffectiveType))
+	switch {
+	case c.SaveData, effective == "slow-2g", effective == "2g", effective == "3g":
+		return ClassConstrained
+	case c.DownlinkMbps >= 50:
+		return ClassFast
+	}
+	switch strings.ToLower(strings.TrimSpace(c.ConnectionType)) {
+	case "wifi", "ethernet":
+		return ClassFast
+	default:
+		return ClassCellular
+	}
+}
+
+// Negotiate recommends how to prepare the upload described by c. It never recommends
+// scaling up.
+func Negotiate(c Capabilities) (Recommendation, error) {
+	if err := c.Validate(); err != nil {
+		return Recommendation{}, err
+	}
+
+	class := c.Class()
+	p := policies[class]
+	rec := Recommendation{
+		Class:          class,
+		Format:         "image/jpeg",
+		Quality:        p.quality,
+		MaxFileSize:    MaxUploadBytes,
+		ChunkSizeBytes: p.chunkBytes,
+	}
+	if c.SupportsWebP {
+		rec.Format = "image/webp"
+	}
+
+	longEdge := max(c.Width, c.Height)
+	scale := math.Min(1, float64(p.maxLongEdge)/float64(longEdge))
+
+	rec.TargetWidth = max(1, int(math.Floor(float64(c.Width)*scale)))
+	rec.TargetHeight = max(1, int(math.Floor(float64(c.Height)*scale)))
+	rec.Resize = rec.TargetWidth < c.Width || rec.TargetHeight < c.Height
+	rec.EstimatedBytes = int64(math.Ceil(estimateBytes(c, rec.Format, scale)))
+	return rec, nil
+}
+
+// estimateBytes estimates the encoded size at scale, from the original's size when known
+// and from the pixel count otherwise. The original is assumed to be a high-quality JPEG, so
+// re-encoding as WebP saves the difference in bytes per pixel.
+func estimateBytes(c Capabilities, format string, scale float64) float64 {
+	pixels := float64(c.Width) * float64(c.Height)
+	perPixel := bytesPerPixel[format]
+	if c.FileSize > 0 {
+		perPixel = math.Min(perPixel, float64(c.FileSize)/pixels*perPixel/bytesPerPixel["image/jpeg"])
+	}
+	return pixels * scale * scale * perPixel
+}
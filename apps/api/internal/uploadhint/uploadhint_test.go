@@ -0,0 +1,89 @@
+package uploadhint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapabilities_Class(t *testing.T) {
+	testCases := []struct {
+		name string
+		caps Capabilities
+		want string
+	}{
+		{name: "success: wifi is fast", caps: Capabilities{ConnectionType: "WiFi"}, want: ClassFast},
+		{name: "success: cellular", caps: Capabilities{ConnectionType: "cellular", EffectiveType: "4g"}, want: ClassCellular},
+		{name: "success: unknown counts as cellular", caps: Capabilities{}, want: ClassCellular},
+		{name: "success: fast downlink", caps: Capabilities{ConnectionType: "cellular", DownlinkMbps: 120}, want: ClassFast},
+		{
+			name: "success: 3g is constrained",
+			caps: Capabilities{ConnectionType: "wifi", EffectiveType: "3g"},
+			want: ClassConstrained,
+		},
+		{name: "success: save-data wins", caps: Capabilities{ConnectionType: "wifi", SaveData: true}, want: ClassConstrained},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.caps.Class())
+		})
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		caps    Capabilities
+		want    Recommendation
+		wantErr bool
+	}{
+		{
+			name: "success: 48MP over LTE is scaled to the cellular edge",
+			caps: Capabilities{ConnectionType: "cellular", Width: 8000, Height: 6000, FileSize: 16_800_000},
+			want: Recommendation{
+				Class: ClassCellular, Resize: true, TargetWidth: 3072, TargetHeight: 2304, Format: "image/jpeg",
+				Quality: 85, EstimatedBytes: 2_477_261, MaxFileSize: MaxUploadBytes, ChunkSizeBytes: MinChunkBytes,
+			},
+		},
+		{
+			name: "success: small photo on wifi is left alone",
+			caps: Capabilities{ConnectionType: "wifi", Width: 1600, Height: 1200, SupportsWebP: true},
+			want: Recommendation{
+				Class: ClassFast, TargetWidth: 1600, TargetHeight: 1200, Format: "image/webp",
+				Quality: 90, EstimatedBytes: 480_000, MaxFileSize: MaxUploadBytes, ChunkSizeBytes: MaxUploadBytes,
+			},
+		},
+		{
+			name: "success: portrait on a constrained link",
+			caps: Capabilities{EffectiveType: "2g", Width: 3000, Height: 4000, SupportsWebP: true},
+			want: Recommendation{
+				Class: ClassConstrained, Resize: true, TargetWidth: 1536, TargetHeight: 2048, Format: "image/webp",
+				Quality: 80, EstimatedBytes: 786_432, MaxFileSize: MaxUploadBytes, ChunkSizeBytes: MinChunkBytes,
+			},
+		},
+		{
+			name: "success: well compressed original keeps its density as webp",
+			caps: Capabilities{ConnectionType: "wifi", Width: 4000, Height: 3000, FileSize: 2_100_000, SupportsWebP: true},
+			want: Recommendation{
+				Class: ClassFast, TargetWidth: 4000, TargetHeight: 3000, Format: "image/webp",
+				Quality: 90, EstimatedBytes: 1_500_000, MaxFileSize: MaxUploadBytes, ChunkSizeBytes: MaxUploadBytes,
+			},
+		},
+		{name: "fail: missing dimensions", caps: Capabilities{ConnectionType: "wifi"}, wantErr: true},
+		{name: "fail: negative file size", caps: Capabilities{Width: 10, Height: 10, FileSize: -1}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Negotiate(tc.caps)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidCapabilities)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package usage
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// DefaultHandler serves the current user's usage over HTTP.
+type DefaultHandler struct {
+	service  Service
+	userRepo user.Repository
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, userRepo user.Repository) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo}
+}
+
+// ErrorResponse is a simple JSON error envelope for handler responses.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// QuotaExceededResponse represents a refusal to create images past the monthly limit.
+type QuotaExceededResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+	// Requested is how many images the refused request would have created.
+	Requested int   `json:"requested"`
+	Usage     Usage `json:"usage"`
+}
+
+// WriteQuotaExceeded responds to a request refused by the monthly limit: 402 when the owner
+// has no plan, so subscribing is the way forward, and 429 with Retry-After until the period
+// resets when their plan's limit is used up.
+func WriteQuotaExceeded(c echo.Context, err *QuotaError) error {
+	if err.PaymentRequired() {
+		return c.JSON(http.StatusPaymentRequired, QuotaExceededResponse{
+			Error:     "payment_required",
+			Message:   "Your free monthly images are used up; subscribe to a plan to stage more",
+			Requested: err.Requested,
+			Usage:     err.Usage,
+		})
+	}
+	retryAfter := int(time.Until(err.Usage.PeriodEnd).Seconds()) + 1
+	c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(max(retryAfter, 1)))
+	return c.JSON(http.StatusTooManyRequests, QuotaExceededResponse{
+		Error:     "quota_exceeded",
+		Message:   "Your plan's monthly image limit is reached",
+		Requested: err.Requested,
+		Usage:     err.Usage,
+	})
+}
+
+// Get handles GET /api/v1/me/usage.
+func (h *DefaultHandler) Get(c echo.Context) error {
+	userID, err := user.ResolveID(c, h.userRepo)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Unable to resolve current user",
+		})
+	}
+
+	u, err := h.service.Get(c.Request().Context(), userID)
+	if err != nil {
+		c.Logger().Errorf("Failed to get usage: %v", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to retrieve usage",
+		})
+	}
+	return c.JSON(http.StatusOK, u)
+}
@@ -0,0 +1,65 @@
+package usage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func TestDefaultHandler_Get(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: usage returned", expectedStatus: http.StatusOK},
+		{name: "fail: usage unavailable", err: errors.New("db down"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				GetFunc: func(ctx context.Context, id string) (*Usage, error) {
+					assert.Equal(t, userID.String(), id)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &Usage{Plan: "pro", Limit: 100, Used: 40, Remaining: 60, Enforced: true}, nil
+				},
+			}
+			userRepo := &user.RepositoryMock{
+				GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+					return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, userRepo)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/me/usage", nil)
+			req.Header.Set("X-Test-User", "auth0|user")
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, h.Get(e.NewContext(req, rec)))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusOK {
+				var got Usage
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+				assert.Equal(t, 60, got.Remaining)
+			}
+		})
+	}
+}
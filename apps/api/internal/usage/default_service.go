@@ -0,0 +1,204 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/entitlement"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// DefaultService implements Service with a counter row per user per period, reading limits
+// from the user's entitlements.
+type DefaultService struct {
+	querier      queries.Querier
+	entitlements entitlement.Service
+	cfg          config.Usage
+	now          func() time.Time
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService with a database. entitlements, when nil,
+// are read from the database with each call.
+func NewDefaultService(db storage.Database, entitlements entitlement.Service, cfg config.Usage) *DefaultService {
+	if entitlements == nil {
+		entitlements = entitlement.NewDefaultService(db)
+	}
+	return NewDefaultServiceWithQuerier(queries.New(db), entitlements, cfg)
+}
+
+// NewDefaultServiceWithQuerier creates a new DefaultService with a custom querier (for testing).
+func NewDefaultServiceWithQuerier(
+	querier queries.Querier, entitlements entitlement.Service, cfg config.Usage,
+) *DefaultService {
+	return &DefaultService{querier: querier, entitlements: entitlements, cfg: cfg, now: time.Now}
+}
+
+// Get returns the user's usage in the current period.
+func (s *DefaultService) Get(ctx context.Context, userID string) (*Usage, error) {
+	id, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	u, err := s.current(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	used, err := s.used(ctx, id, u.PeriodStart)
+	if err != nil {
+		return nil, err
+	}
+	u.setUsed(used)
+	return u, nil
+}
+
+// Reserve counts n images against the user's current period. The check and the count are
+// one statement, so concurrent requests cannot together pass the limit.
+func (s *DefaultService) Reserve(ctx context.Context, userID string, n int) (*Usage, error) {
+	id, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: image count must be positive", ErrInvalid)
+	}
+	u, err := s.current(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := math.MaxInt32
+	if u.Enforced {
+		limit = u.Limit
+	}
+	if n > limit {
+		return nil, s.exceeded(ctx, id, u, n)
+	}
+	used, err := s.querier.ReserveUsage(ctx, queries.ReserveUsageParams{
+		UserID:       id,
+		PeriodStart:  timestamptz(u.PeriodStart),
+		StagedImages: clampInt32(n),
+		MonthlyLimit: clampInt32(limit),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, s.exceeded(ctx, id, u, n)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve usage: %w", err)
+	}
+	u.setUsed(int(used))
+	return u, nil
+}
+
+// Release takes back n images reserved in the period starting at periodStart.
+func (s *DefaultService) Release(ctx context.Context, userID string, periodStart time.Time, n int) error {
+	id, err := parseUserID(userID)
+	if err != nil {
+		return err
+	}
+	if n <= 0 {
+		return nil
+	}
+	err = s.querier.ReleaseUsage(ctx, queries.ReleaseUsageParams{
+		StagedImages: clampInt32(n),
+		UserID:       id,
+		PeriodStart:  timestamptz(periodStart),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release usage: %w", err)
+	}
+	return nil
+}
+
+// current returns the user's plan, limit and current period, with nothing used.
+func (s *DefaultService) current(ctx context.Context, id pgtype.UUID, userID string) (*Usage, error) {
+	ents, err := s.entitlements.Get(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entitlements: %w", err)
+	}
+	start, end, err := s.period(ctx, id, ents.Plan)
+	if err != nil {
+		return nil, err
+	}
+	u := &Usage{
+		Plan:        ents.Plan,
+		Limit:       ents.MonthlyLimit,
+		Enforced:    s.cfg.Enforce,
+		PeriodStart: start,
+		PeriodEnd:   end,
+	}
+	if ents.Plan == "" {
+		u.Limit = s.cfg.FreeMonthlyLimit
+	}
+	u.setUsed(0)
+	return u, nil
+}
+
+// period returns the user's current billing period: their subscription's current period
+// when they have a plan and Stripe has reported its period, and otherwise the UTC calendar month.
+func (s *DefaultService) period(ctx context.Context, id pgtype.UUID, plan string) (time.Time, time.Time, error) {
+	now := s.now()
+	if plan != "" {
+		row, err := s.querier.GetUserBillingPeriod(ctx, id)
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+		case err != nil:
+			return time.Time{}, time.Time{}, fmt.Errorf("failed to get billing period: %w", err)
+		case row.CurrentPeriodStart.Valid && row.CurrentPeriodEnd.Valid &&
+			row.CurrentPeriodStart.Time.Before(row.CurrentPeriodEnd.Time):
+			start, end := subscriptionPeriod(row.CurrentPeriodStart.Time, row.CurrentPeriodEnd.Time, now)
+			return start, end, nil
+		}
+	}
+	start, end := calendarMonth(now)
+	return start, end, nil
+}
+
+// used returns the images counted in the period starting at periodStart.
+func (s *DefaultService) used(ctx context.Context, id pgtype.UUID, periodStart time.Time) (int, error) {
+	used, err := s.querier.GetUsage(ctx, queries.GetUsageParams{UserID: id, PeriodStart: timestamptz(periodStart)})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get usage: %w", err)
+	}
+	return int(used), nil
+}
+
+// exceeded returns the QuotaError for requesting n images with u's limit.
+func (s *DefaultService) exceeded(ctx context.Context, id pgtype.UUID, u *Usage, n int) error {
+	used, err := s.used(ctx, id, u.PeriodStart)
+	if err != nil {
+		return err
+	}
+	u.setUsed(used)
+	return &QuotaError{Usage: *u, Requested: n}
+}
+
+func parseUserID(userID string) (pgtype.UUID, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf("%w: invalid user ID", ErrInvalid)
+	}
+	return pgtype.UUID{Bytes: id, Valid: true}, nil
+}
+
+func timestamptz(t time.Time) pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: t, Valid: true}
+}
+
+func clampInt32(n int) int32 {
+	return int32(min(n, math.MaxInt32)) // #nosec G115 -- clamped to the int32 range
+}
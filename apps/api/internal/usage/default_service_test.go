@@ -0,0 +1,342 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/entitlement"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+var (
+	// The evening of March 31 in California is already April in UTC.
+	now         = time.Date(2026, 3, 31, 20, 0, 0, 0, time.FixedZone("PDT", -7*60*60))
+	periodStart = time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd   = time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func entitlementsFor(e entitlement.Entitlements) *entitlement.ServiceMock {
+	return &entitlement.ServiceMock{
+		GetFunc: func(ctx context.Context, userID string) (*entitlement.Entitlements, error) {
+			return &e, nil
+		},
+	}
+}
+
+func newService(q queries.Querier, e entitlement.Service, cfg config.Usage) *DefaultService {
+	s := NewDefaultServiceWithQuerier(q, e, cfg)
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func noSubscription(ctx context.Context, userID pgtype.UUID) (*queries.GetUserBillingPeriodRow, error) {
+	return nil, pgx.ErrNoRows
+}
+
+func TestCalendarMonth(t *testing.T) {
+	start, end := calendarMonth(now)
+	assert.Equal(t, periodStart, start)
+	assert.Equal(t, periodEnd, end)
+
+	start, end = calendarMonth(time.Date(2026, 12, 31, 23, 59, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), end)
+}
+
+func TestSubscriptionPeriod(t *testing.T) {
+	start := time.Date(2026, 3, 17, 9, 30, 0, 0, time.UTC)
+	end := time.Date(2026, 4, 17, 9, 30, 0, 0, time.UTC)
+
+	gotStart, gotEnd := subscriptionPeriod(start, end, now)
+	assert.Equal(t, start, gotStart)
+	assert.Equal(t, end, gotEnd)
+
+	// Two renewals without a webhook.
+	gotStart, gotEnd = subscriptionPeriod(start, end, time.Date(2026, 5, 20, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2026, 5, 17, 9, 30, 0, 0, time.UTC), gotStart)
+	assert.Equal(t, time.Date(2026, 6, 17, 9, 30, 0, 0, time.UTC), gotEnd)
+}
+
+func TestDefaultService_Get_Period(t *testing.T) {
+	userID := uuid.NewString()
+	pro := entitlement.Entitlements{Plan: "pro", MonthlyLimit: 100}
+	subStart := time.Date(2026, 3, 17, 9, 30, 0, 0, time.UTC)
+	subEnd := time.Date(2026, 4, 17, 9, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name      string
+		ents      entitlement.Entitlements
+		row       *queries.GetUserBillingPeriodRow
+		periodErr error
+		wantQuery bool
+		wantStart time.Time
+		wantEnd   time.Time
+		wantErr   bool
+	}{
+		{
+			name: "success: subscription's current period",
+			ents: pro,
+			row: &queries.GetUserBillingPeriodRow{
+				CurrentPeriodStart: pgtype.Timestamptz{Time: subStart, Valid: true},
+				CurrentPeriodEnd:   pgtype.Timestamptz{Time: subEnd, Valid: true},
+			},
+			wantQuery: true,
+			wantStart: subStart,
+			wantEnd:   subEnd,
+		},
+		{
+			name:      "success: calendar month without a subscription",
+			ents:      pro,
+			periodErr: pgx.ErrNoRows,
+			wantQuery: true,
+			wantStart: periodStart,
+			wantEnd:   periodEnd,
+		},
+		{
+			name:      "success: calendar month when the period is unknown",
+			ents:      pro,
+			row:       &queries.GetUserBillingPeriodRow{},
+			wantQuery: true,
+			wantStart: periodStart,
+			wantEnd:   periodEnd,
+		},
+		{
+			name:      "success: calendar month on the free allowance",
+			wantStart: periodStart,
+			wantEnd:   periodEnd,
+		},
+		{
+			name:      "fail: billing period unreadable",
+			ents:      pro,
+			periodErr: errors.New("db down"),
+			wantQuery: true,
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var queried bool
+			q := &queries.QuerierMock{
+				GetUserBillingPeriodFunc: func(ctx context.Context, id pgtype.UUID) (*queries.GetUserBillingPeriodRow, error) {
+					queried = true
+					assert.Equal(t, userID, uuid.UUID(id.Bytes).String())
+					return tc.row, tc.periodErr
+				},
+				GetUsageFunc: func(ctx context.Context, arg queries.GetUsageParams) (int32, error) {
+					assert.Equal(t, tc.wantStart, arg.PeriodStart.Time)
+					return 0, nil
+				},
+			}
+			s := newService(q, entitlementsFor(tc.ents), config.Usage{Enforce: true})
+
+			got, err := s.Get(context.Background(), userID)
+			assert.Equal(t, tc.wantQuery, queried)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantStart, got.PeriodStart)
+			assert.Equal(t, tc.wantEnd, got.PeriodEnd)
+		})
+	}
+}
+
+func TestDefaultService_Get(t *testing.T) {
+	userID := uuid.NewString()
+
+	testCases := []struct {
+		name     string
+		ents     entitlement.Entitlements
+		used     int32
+		usageErr error
+		want     Usage
+		wantErr  bool
+	}{
+		{
+			name: "success: plan limit",
+			ents: entitlement.Entitlements{Plan: "pro", MonthlyLimit: 100},
+			used: 40,
+			want: Usage{Plan: "pro", Limit: 100, Used: 40, Remaining: 60, Enforced: true},
+		},
+		{
+			name:     "success: nothing used this period",
+			ents:     entitlement.Entitlements{Plan: "pro", MonthlyLimit: 100},
+			usageErr: pgx.ErrNoRows,
+			want:     Usage{Plan: "pro", Limit: 100, Remaining: 100, Enforced: true},
+		},
+		{
+			name: "success: free allowance without a plan",
+			used: 7,
+			want: Usage{Limit: 5, Used: 7, Enforced: true},
+		},
+		{
+			name:     "fail: usage unreadable",
+			ents:     entitlement.Entitlements{Plan: "pro", MonthlyLimit: 100},
+			usageErr: errors.New("db down"),
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				GetUserBillingPeriodFunc: noSubscription,
+				GetUsageFunc: func(ctx context.Context, arg queries.GetUsageParams) (int32, error) {
+					assert.Equal(t, userID, uuid.UUID(arg.UserID.Bytes).String())
+					assert.Equal(t, periodStart, arg.PeriodStart.Time)
+					return tc.used, tc.usageErr
+				},
+			}
+			s := newService(q, entitlementsFor(tc.ents), config.Usage{Enforce: true, FreeMonthlyLimit: 5})
+
+			got, err := s.Get(context.Background(), userID)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			tc.want.PeriodStart, tc.want.PeriodEnd = periodStart, periodEnd
+			assert.Equal(t, tc.want, *got)
+		})
+	}
+}
+
+func TestDefaultService_Reserve(t *testing.T) {
+	userID := uuid.NewString()
+	pro := entitlement.Entitlements{Plan: "pro", MonthlyLimit: 100}
+
+	testCases := []struct {
+		name         string
+		ents         entitlement.Entitlements
+		cfg          config.Usage
+		n            int
+		reserved     int32
+		reserveErr   error
+		used         int32
+		wantLimitArg int32
+		wantReserve  bool
+		want         Usage
+		wantQuota    bool
+		wantErr      error
+	}{
+		{
+			name:         "success: within the plan limit",
+			ents:         pro,
+			cfg:          config.Usage{Enforce: true},
+			n:            3,
+			reserved:     43,
+			wantLimitArg: 100,
+			wantReserve:  true,
+			want:         Usage{Plan: "pro", Limit: 100, Used: 43, Remaining: 57, Enforced: true},
+		},
+		{
+			name:         "success: counted past the limit when not enforced",
+			ents:         pro,
+			n:            2,
+			reserved:     101,
+			wantLimitArg: math.MaxInt32,
+			wantReserve:  true,
+			want:         Usage{Plan: "pro", Limit: 100, Used: 101},
+		},
+		{
+			name:         "fail: plan limit reached",
+			ents:         pro,
+			cfg:          config.Usage{Enforce: true},
+			n:            3,
+			reserveErr:   pgx.ErrNoRows,
+			used:         99,
+			wantLimitArg: 100,
+			wantReserve:  true,
+			want:         Usage{Plan: "pro", Limit: 100, Used: 99, Remaining: 1, Enforced: true},
+			wantQuota:    true,
+		},
+		{
+			name:      "fail: request larger than the free allowance",
+			cfg:       config.Usage{Enforce: true, FreeMonthlyLimit: 2},
+			n:         3,
+			used:      1,
+			want:      Usage{Limit: 2, Used: 1, Remaining: 1, Enforced: true},
+			wantQuota: true,
+		},
+		{
+			name:    "fail: non-positive count",
+			ents:    pro,
+			n:       0,
+			wantErr: ErrInvalid,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var reserved bool
+			q := &queries.QuerierMock{
+				GetUserBillingPeriodFunc: noSubscription,
+				ReserveUsageFunc: func(ctx context.Context, arg queries.ReserveUsageParams) (int32, error) {
+					reserved = true
+					assert.Equal(t, userID, uuid.UUID(arg.UserID.Bytes).String())
+					assert.Equal(t, periodStart, arg.PeriodStart.Time)
+					assert.Equal(t, int32(tc.n), arg.StagedImages)
+					assert.Equal(t, tc.wantLimitArg, arg.MonthlyLimit)
+					return tc.reserved, tc.reserveErr
+				},
+				GetUsageFunc: func(ctx context.Context, arg queries.GetUsageParams) (int32, error) {
+					return tc.used, nil
+				},
+			}
+			s := newService(q, entitlementsFor(tc.ents), tc.cfg)
+
+			got, err := s.Reserve(context.Background(), userID, tc.n)
+			assert.Equal(t, tc.wantReserve, reserved)
+			tc.want.PeriodStart, tc.want.PeriodEnd = periodStart, periodEnd
+			switch {
+			case tc.wantErr != nil:
+				require.ErrorIs(t, err, tc.wantErr)
+			case tc.wantQuota:
+				var quotaErr *QuotaError
+				require.ErrorAs(t, err, &quotaErr)
+				assert.ErrorIs(t, err, ErrQuotaExceeded)
+				assert.Equal(t, tc.want, quotaErr.Usage)
+				assert.Equal(t, tc.n, quotaErr.Requested)
+				assert.Equal(t, tc.ents.Plan == "", quotaErr.PaymentRequired())
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, *got)
+			}
+		})
+	}
+}
+
+func TestDefaultService_Release(t *testing.T) {
+	userID := uuid.NewString()
+
+	t.Run("success: count taken back from the reserved period", func(t *testing.T) {
+		q := &queries.QuerierMock{
+			ReleaseUsageFunc: func(ctx context.Context, arg queries.ReleaseUsageParams) error {
+				assert.Equal(t, userID, uuid.UUID(arg.UserID.Bytes).String())
+				assert.Equal(t, periodStart, arg.PeriodStart.Time)
+				assert.Equal(t, int32(3), arg.StagedImages)
+				return nil
+			},
+		}
+		s := newService(q, entitlementsFor(entitlement.Entitlements{}), config.Usage{})
+		require.NoError(t, s.Release(context.Background(), userID, periodStart, 3))
+		assert.Len(t, q.ReleaseUsageCalls(), 1)
+	})
+
+	t.Run("fail: invalid user ID", func(t *testing.T) {
+		s := newService(&queries.QuerierMock{}, entitlementsFor(entitlement.Entitlements{}), config.Usage{})
+		require.ErrorIs(t, s.Release(context.Background(), "nope", periodStart, 3), ErrInvalid)
+	})
+}
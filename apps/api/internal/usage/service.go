@@ -0,0 +1,21 @@
+package usage
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service meters and limits the images users create.
+type Service interface {
+	// Get returns the user's usage in the current period.
+	Get(ctx context.Context, userID string) (*Usage, error)
+	// Reserve counts n images against the user's current period and returns the usage after
+	// them. When enforcement is on and they would pass the limit, nothing is counted and a
+	// *QuotaError is returned.
+	Reserve(ctx context.Context, userID string, n int) (*Usage, error)
+	// Release takes back n images reserved in the period starting at periodStart, for images
+	// that were never created.
+	Release(ctx context.Context, userID string, periodStart time.Time, n int) error
+}
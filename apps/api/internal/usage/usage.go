@@ -0,0 +1,73 @@
+// Package usage meters the images each user creates for staging per billing period, their
+// subscription's current period or the UTC calendar month without one, and enforces their
+// plan's monthly limit before images are queued.
+package usage
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrQuotaExceeded is wrapped by QuotaError when creating images would pass the monthly limit.
+var ErrQuotaExceeded = errors.New("monthly image quota exceeded")
+
+// ErrInvalid is returned when a usage request is malformed.
+var ErrInvalid = errors.New("invalid usage request")
+
+// Usage is a user's staged-image count in the current billing period against their plan.
+type Usage struct {
+	// Plan is the plan code, or "" without an active subscription.
+	Plan string `json:"plan"`
+	// Limit is the number of images the plan includes per period.
+	Limit int `json:"limit"`
+	// Used counts the images created in the period, including ones later canceled or deleted.
+	Used int `json:"used"`
+	// Remaining is how many more images may be created before the period ends.
+	Remaining int `json:"remaining"`
+	// Enforced reports whether images past Limit are refused; otherwise they are only counted.
+	Enforced    bool      `json:"enforced"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+}
+
+// setUsed sets Used and the Remaining it leaves.
+func (u *Usage) setUsed(used int) {
+	u.Used = used
+	u.Remaining = max(u.Limit-used, 0)
+}
+
+// QuotaError is returned when creating Requested images would take the user past Limit.
+type QuotaError struct {
+	Usage     Usage
+	Requested int
+}
+
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("%s: %d of %d images used, %d requested", ErrQuotaExceeded, e.Usage.Used, e.Usage.Limit, e.Requested)
+}
+
+func (e *QuotaError) Unwrap() error { return ErrQuotaExceeded }
+
+// PaymentRequired reports whether the user has no active plan, so only subscribing lets
+// them create more images this period.
+func (e *QuotaError) PaymentRequired() bool { return e.Usage.Plan == "" }
+
+// calendarMonth returns the UTC calendar month containing t, the billing period of users
+// without a subscription.
+func calendarMonth(t time.Time) (time.Time, time.Time) {
+	t = t.UTC()
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// subscriptionPeriod returns the period containing t of a subscription whose recorded current
+// period runs from start to end. A renewal whose webhook has not arrived yet leaves t past
+// end; the period is then rolled forward a month at a time, as Stripe renews it.
+func subscriptionPeriod(start, end, t time.Time) (time.Time, time.Time) {
+	start, end = start.UTC(), end.UTC()
+	for !t.Before(end) {
+		start, end = end, end.AddDate(0, 1, 0)
+	}
+	return start, end
+}
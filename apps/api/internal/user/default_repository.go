@@ -9,22 +9,35 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
+	"github.com/real-staging-ai/api/internal/fieldcrypt"
 	"github.com/real-staging-ai/api/internal/storage"
 	"github.com/real-staging-ai/api/internal/storage/queries"
 )
 
+// Columns encrypted at rest. Each ciphertext is bound to the column it is stored in.
+const (
+	columnStripeCustomerID = "users.stripe_customer_id"
+	columnPhone            = "users.phone"
+	columnBillingAddress   = "users.billing_address"
+)
+
 // DefaultRepository handles the database operations for users using sqlc-generated queries.
+// Phone numbers, billing addresses, and Stripe customer IDs are encrypted on write and
+// decrypted on read, so callers only ever see plaintext.
 type DefaultRepository struct {
 	queries queries.Querier
+	keys    *fieldcrypt.Keyring
 }
 
 // Ensure DefaultRepository implements UserRepository interface.
 var _ Repository = (*DefaultRepository)(nil)
 
-// NewDefaultRepository creates a new DefaultRepository instance.
+// NewDefaultRepository creates a new DefaultRepository instance that encrypts with the
+// process-wide field encryption keyring.
 func NewDefaultRepository(db storage.Database) *DefaultRepository {
 	return &DefaultRepository{
 		queries: queries.New(db),
+		keys:    fieldcrypt.Default(),
 	}
 }
 
@@ -34,19 +47,27 @@ func (r *DefaultRepository) Create(
 ) (*queries.CreateUserRow, error) {
 	var stripeCustomerIDType pgtype.Text
 	if stripeCustomerID != "" {
-		stripeCustomerIDType = pgtype.Text{String: stripeCustomerID, Valid: true}
+		sealed, err := r.keys.Encrypt(columnStripeCustomerID, stripeCustomerID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encrypt Stripe customer ID: %w", err)
+		}
+		stripeCustomerIDType = pgtype.Text{String: sealed, Valid: true}
 	}
 
 	params := queries.CreateUserParams{
-		Auth0Sub:         auth0Sub,
-		StripeCustomerID: stripeCustomerIDType,
-		Role:             role,
+		Auth0Sub:             auth0Sub,
+		StripeCustomerID:     stripeCustomerIDType,
+		Role:                 role,
+		StripeCustomerIDHash: r.stripeCustomerIDHash(stripeCustomerID),
 	}
 
 	user, err := r.queries.CreateUser(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create user: %w", err)
 	}
+	if err := r.openText(columnStripeCustomerID, &user.StripeCustomerID); err != nil {
+		return nil, err
+	}
 
 	return user, nil
 }
@@ -67,6 +88,9 @@ func (r *DefaultRepository) GetByID(ctx context.Context, userID string) (*querie
 		}
 		return nil, fmt.Errorf("unable to get user by ID: %w", err)
 	}
+	if err := r.openText(columnStripeCustomerID, &user.StripeCustomerID); err != nil {
+		return nil, err
+	}
 
 	return user, nil
 }
@@ -80,22 +104,42 @@ func (r *DefaultRepository) GetByAuth0Sub(ctx context.Context, auth0Sub string)
 		}
 		return nil, fmt.Errorf("unable to get user by Auth0 sub: %w", err)
 	}
+	if err := r.openText(columnStripeCustomerID, &user.StripeCustomerID); err != nil {
+		return nil, err
+	}
 
 	return user, nil
 }
 
-// GetByStripeCustomerID retrieves a user by their Stripe customer ID.
+// GetByStripeCustomerID retrieves a user by their Stripe customer ID. Encrypted IDs are
+// found through their blind index; rows not yet encrypted are matched on the plaintext.
 func (r *DefaultRepository) GetByStripeCustomerID(
 	ctx context.Context, stripeCustomerID string,
 ) (*queries.GetUserByStripeCustomerIDRow, error) {
-	stripeCustomerIDType := pgtype.Text{String: stripeCustomerID, Valid: true}
+	var user *queries.GetUserByStripeCustomerIDRow
+	if hash := r.stripeCustomerIDHash(stripeCustomerID); hash.Valid {
+		row, err := r.queries.GetUserByStripeCustomerIDHash(ctx, hash)
+		switch {
+		case err == nil:
+			user = (*queries.GetUserByStripeCustomerIDRow)(row)
+		case !errors.Is(err, pgx.ErrNoRows):
+			return nil, fmt.Errorf("unable to get user by Stripe customer ID: %w", err)
+		}
+	}
 
-	user, err := r.queries.GetUserByStripeCustomerID(ctx, stripeCustomerIDType)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, pgx.ErrNoRows
+	if user == nil {
+		stripeCustomerIDType := pgtype.Text{String: stripeCustomerID, Valid: true}
+		row, err := r.queries.GetUserByStripeCustomerID(ctx, stripeCustomerIDType)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, pgx.ErrNoRows
+			}
+			return nil, fmt.Errorf("unable to get user by Stripe customer ID: %w", err)
 		}
-		return nil, fmt.Errorf("unable to get user by Stripe customer ID: %w", err)
+		user = row
+	}
+	if err := r.openText(columnStripeCustomerID, &user.StripeCustomerID); err != nil {
+		return nil, err
 	}
 
 	return user, nil
@@ -112,11 +156,15 @@ func (r *DefaultRepository) UpdateStripeCustomerID(
 
 	userUUIDType := pgtype.UUID{Bytes: userUUID, Valid: true}
 
-	stripeCustomerIDType := pgtype.Text{String: stripeCustomerID, Valid: true}
+	sealed, err := r.keys.Encrypt(columnStripeCustomerID, stripeCustomerID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encrypt Stripe customer ID: %w", err)
+	}
 
 	params := queries.UpdateUserStripeCustomerIDParams{
-		ID:               userUUIDType,
-		StripeCustomerID: stripeCustomerIDType,
+		ID:                   userUUIDType,
+		StripeCustomerID:     pgtype.Text{String: sealed, Valid: true},
+		StripeCustomerIDHash: r.stripeCustomerIDHash(stripeCustomerID),
 	}
 
 	user, err := r.queries.UpdateUserStripeCustomerID(ctx, params)
@@ -126,6 +174,9 @@ func (r *DefaultRepository) UpdateStripeCustomerID(
 		}
 		return nil, fmt.Errorf("unable to update user Stripe customer ID: %w", err)
 	}
+	if err := r.openText(columnStripeCustomerID, &user.StripeCustomerID); err != nil {
+		return nil, err
+	}
 
 	return user, nil
 }
@@ -151,6 +202,9 @@ func (r *DefaultRepository) UpdateRole(ctx context.Context, userID, role string)
 		}
 		return nil, fmt.Errorf("unable to update user role: %w", err)
 	}
+	if err := r.openText(columnStripeCustomerID, &user.StripeCustomerID); err != nil {
+		return nil, err
+	}
 
 	return user, nil
 }
@@ -183,6 +237,11 @@ func (r *DefaultRepository) List(ctx context.Context, limit, offset int) ([]*que
 	if err != nil {
 		return nil, fmt.Errorf("unable to list users: %w", err)
 	}
+	for _, u := range users {
+		if err := r.openText(columnStripeCustomerID, &u.StripeCustomerID); err != nil {
+			return nil, err
+		}
+	}
 
 	return users, nil
 }
@@ -213,6 +272,9 @@ func (r *DefaultRepository) GetProfileByID(ctx context.Context, userID string) (
 		}
 		return nil, fmt.Errorf("unable to get user profile by ID: %w", err)
 	}
+	if err := r.openProfile(&profile.StripeCustomerID, &profile.Phone, &profile.BillingAddress); err != nil {
+		return nil, err
+	}
 
 	return profile, nil
 }
@@ -228,6 +290,9 @@ func (r *DefaultRepository) GetProfileByAuth0Sub(
 		}
 		return nil, fmt.Errorf("unable to get user profile by Auth0 sub: %w", err)
 	}
+	if err := r.openProfile(&profile.StripeCustomerID, &profile.Phone, &profile.BillingAddress); err != nil {
+		return nil, err
+	}
 
 	return profile, nil
 }
@@ -257,13 +322,20 @@ func (r *DefaultRepository) UpdateProfile(
 		companyNameType = pgtype.Text{String: *profile.CompanyName, Valid: true}
 	}
 	if profile.Phone != nil {
-		phoneType = pgtype.Text{String: *profile.Phone, Valid: true}
+		sealed, err := r.keys.Encrypt(columnPhone, *profile.Phone)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encrypt phone: %w", err)
+		}
+		phoneType = pgtype.Text{String: sealed, Valid: true}
 	}
 	if profile.ProfilePhotoURL != nil {
 		profilePhotoURLType = pgtype.Text{String: *profile.ProfilePhotoURL, Valid: true}
 	}
 	if profile.BillingAddress != nil {
-		billingAddressType = profile.BillingAddress
+		billingAddressType, err = r.keys.EncryptJSON(columnBillingAddress, profile.BillingAddress)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encrypt billing address: %w", err)
+		}
 	}
 	if profile.Preferences != nil {
 		preferencesType = profile.Preferences
@@ -287,6 +359,45 @@ func (r *DefaultRepository) UpdateProfile(
 		}
 		return nil, fmt.Errorf("unable to update user profile: %w", err)
 	}
+	if err := r.openProfile(&updated.StripeCustomerID, &updated.Phone, &updated.BillingAddress); err != nil {
+		return nil, err
+	}
 
 	return updated, nil
 }
+
+// stripeCustomerIDHash returns the blind index of a Stripe customer ID, or an invalid
+// value when no index key is configured.
+func (r *DefaultRepository) stripeCustomerIDHash(stripeCustomerID string) pgtype.Text {
+	hash := r.keys.BlindIndex(stripeCustomerID)
+	return pgtype.Text{String: hash, Valid: hash != ""}
+}
+
+// openText decrypts an encrypted text column in place.
+func (r *DefaultRepository) openText(column string, value *pgtype.Text) error {
+	if !value.Valid {
+		return nil
+	}
+	plaintext, err := r.keys.Decrypt(column, value.String)
+	if err != nil {
+		return fmt.Errorf("unable to decrypt %s: %w", column, err)
+	}
+	value.String = plaintext
+	return nil
+}
+
+// openProfile decrypts the encrypted columns of a profile row in place.
+func (r *DefaultRepository) openProfile(stripeCustomerID, phone *pgtype.Text, billingAddress *[]byte) error {
+	if err := r.openText(columnStripeCustomerID, stripeCustomerID); err != nil {
+		return err
+	}
+	if err := r.openText(columnPhone, phone); err != nil {
+		return err
+	}
+	opened, err := r.keys.DecryptJSON(columnBillingAddress, *billingAddress)
+	if err != nil {
+		return fmt.Errorf("unable to decrypt %s: %w", columnBillingAddress, err)
+	}
+	*billingAddress = opened
+	return nil
+}
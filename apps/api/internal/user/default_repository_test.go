@@ -1,15 +1,21 @@
 package user
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/fieldcrypt"
 	"github.com/real-staging-ai/api/internal/storage/queries"
 )
 
@@ -604,3 +610,135 @@ func TestDefaultRepository_Count(t *testing.T) {
 		})
 	}
 }
+
+func testKeyring(t *testing.T, active string, ids ...string) *fieldcrypt.Keyring {
+	t.Helper()
+	keys := make(map[string]string, len(ids))
+	for i, id := range ids {
+		keys[id] = base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{byte(i + 1)}, 32))
+	}
+	k, err := fieldcrypt.NewKeyring(config.FieldEncryption{
+		ActiveKey: active,
+		Keys:      keys,
+		IndexKey:  base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{9}, 32)),
+	})
+	require.NoError(t, err)
+	return k
+}
+
+func TestDefaultRepository_FieldEncryption(t *testing.T) {
+	ctx := context.Background()
+	keys := testKeyring(t, "k1", "k1")
+	userID := uuid.New()
+
+	t.Run("success: create stores ciphertext and blind index", func(t *testing.T) {
+		mock := &mockQuerier{}
+		var stored queries.CreateUserParams
+		mock.CreateUserFunc = func(ctx context.Context, arg queries.CreateUserParams) (*queries.CreateUserRow, error) {
+			stored = arg
+			return &queries.CreateUserRow{StripeCustomerID: arg.StripeCustomerID}, nil
+		}
+
+		repo := &DefaultRepository{queries: mock, keys: keys}
+		created, err := repo.Create(ctx, "auth0|123", "cus_123", "user")
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(stored.StripeCustomerID.String, "enc:v1:k1:"))
+		assert.Equal(t, keys.BlindIndex("cus_123"), stored.StripeCustomerIDHash.String)
+		assert.True(t, stored.StripeCustomerIDHash.Valid)
+		assert.Equal(t, "cus_123", created.StripeCustomerID.String)
+	})
+
+	t.Run("success: lookup by blind index", func(t *testing.T) {
+		sealed, err := keys.Encrypt(columnStripeCustomerID, "cus_123")
+		require.NoError(t, err)
+		mock := &mockQuerier{}
+		mock.GetUserByStripeCustomerIDHashFunc = func(
+			ctx context.Context, hash pgtype.Text,
+		) (*queries.GetUserByStripeCustomerIDHashRow, error) {
+			assert.Equal(t, keys.BlindIndex("cus_123"), hash.String)
+			return &queries.GetUserByStripeCustomerIDHashRow{
+				StripeCustomerID: pgtype.Text{String: sealed, Valid: true},
+			}, nil
+		}
+
+		repo := &DefaultRepository{queries: mock, keys: keys}
+		found, err := repo.GetByStripeCustomerID(ctx, "cus_123")
+		require.NoError(t, err)
+		assert.Equal(t, "cus_123", found.StripeCustomerID.String)
+		assert.Empty(t, mock.GetUserByStripeCustomerIDCalls())
+	})
+
+	t.Run("success: lookup falls back to rows not yet encrypted", func(t *testing.T) {
+		mock := &mockQuerier{}
+		mock.GetUserByStripeCustomerIDHashFunc = func(
+			ctx context.Context, hash pgtype.Text,
+		) (*queries.GetUserByStripeCustomerIDHashRow, error) {
+			return nil, pgx.ErrNoRows
+		}
+		mock.GetUserByStripeCustomerIDFunc = func(
+			ctx context.Context, stripeCustomerID pgtype.Text,
+		) (*queries.GetUserByStripeCustomerIDRow, error) {
+			return &queries.GetUserByStripeCustomerIDRow{StripeCustomerID: stripeCustomerID}, nil
+		}
+
+		repo := &DefaultRepository{queries: mock, keys: keys}
+		found, err := repo.GetByStripeCustomerID(ctx, "cus_legacy")
+		require.NoError(t, err)
+		assert.Equal(t, "cus_legacy", found.StripeCustomerID.String)
+	})
+
+	t.Run("fail: blind index lookup error", func(t *testing.T) {
+		mock := &mockQuerier{}
+		mock.GetUserByStripeCustomerIDHashFunc = func(
+			ctx context.Context, hash pgtype.Text,
+		) (*queries.GetUserByStripeCustomerIDHashRow, error) {
+			return nil, fmt.Errorf("db error")
+		}
+
+		repo := &DefaultRepository{queries: mock, keys: keys}
+		_, err := repo.GetByStripeCustomerID(ctx, "cus_123")
+		assert.ErrorContains(t, err, "unable to get user by Stripe customer ID")
+		assert.Empty(t, mock.GetUserByStripeCustomerIDCalls())
+	})
+
+	t.Run("success: profile update encrypts and returns plaintext", func(t *testing.T) {
+		mock := &mockQuerier{}
+		var stored queries.UpdateUserProfileParams
+		mock.UpdateUserProfileFunc = func(
+			ctx context.Context, arg queries.UpdateUserProfileParams,
+		) (*queries.UpdateUserProfileRow, error) {
+			stored = arg
+			return &queries.UpdateUserProfileRow{Phone: arg.Phone, BillingAddress: arg.BillingAddress}, nil
+		}
+
+		phone := "+1 555 0100"
+		address := []byte(`{"line1":"1 Main St"}`)
+		repo := &DefaultRepository{queries: mock, keys: keys}
+		updated, err := repo.UpdateProfile(ctx, userID.String(), &ProfileUpdate{
+			Phone: &phone, BillingAddress: address,
+		})
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(stored.Phone.String, "enc:v1:k1:"))
+		assert.NotContains(t, string(stored.BillingAddress), "Main St")
+		assert.Equal(t, phone, updated.Phone.String)
+		assert.Equal(t, address, updated.BillingAddress)
+	})
+
+	t.Run("fail: profile sealed with an unknown key", func(t *testing.T) {
+		other := testKeyring(t, "k2", "k2")
+		sealed, err := other.Encrypt(columnPhone, "+1 555 0100")
+		require.NoError(t, err)
+		mock := &mockQuerier{}
+		mock.GetUserProfileByIDFunc = func(
+			ctx context.Context, id pgtype.UUID,
+		) (*queries.GetUserProfileByIDRow, error) {
+			return &queries.GetUserProfileByIDRow{Phone: pgtype.Text{String: sealed, Valid: true}}, nil
+		}
+
+		repo := &DefaultRepository{queries: mock, keys: keys}
+		_, err = repo.GetProfileByID(ctx, userID.String())
+		assert.ErrorContains(t, err, "unable to decrypt users.phone")
+	})
+}
@@ -0,0 +1,41 @@
+package user
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/auth"
+)
+
+// ErrNoSubject is returned when the request carries no Auth0 sub to resolve.
+var ErrNoSubject = errors.New("no authenticated user")
+
+// ResolveID returns the ID of the request's user, looked up by their Auth0 sub and created
+// on their first request.
+func ResolveID(c echo.Context, repo Repository) (string, error) {
+	ctx := c.Request().Context()
+
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return "", err
+	}
+	if auth0Sub == "" {
+		return "", ErrNoSubject
+	}
+
+	existingUser, err := repo.GetByAuth0Sub(ctx, auth0Sub)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			newUser, err := repo.Create(ctx, auth0Sub, "", "user")
+			if err != nil {
+				return "", err
+			}
+			return newUser.ID.String(), nil
+		}
+		return "", err
+	}
+
+	return existingUser.ID.String(), nil
+}
@@ -0,0 +1,85 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+func TestResolveID(t *testing.T) {
+	existingID, createdID := uuid.New(), uuid.New()
+
+	testCases := []struct {
+		name       string
+		getErr     error
+		createErr  error
+		wantID     string
+		wantCreate bool
+		wantErr    bool
+	}{
+		{name: "success: existing user", wantID: existingID.String()},
+		{name: "success: user created on first request", getErr: pgx.ErrNoRows, wantID: createdID.String(), wantCreate: true},
+		{name: "fail: user lookup error", getErr: errors.New("db down"), wantErr: true},
+		{
+			name: "fail: user creation error", getErr: pgx.ErrNoRows, createErr: errors.New("db down"),
+			wantCreate: true, wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &RepositoryMock{
+				GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+					assert.Equal(t, "auth0|user", auth0Sub)
+					if tc.getErr != nil {
+						return nil, tc.getErr
+					}
+					return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: existingID, Valid: true}}, nil
+				},
+				CreateFunc: func(ctx context.Context, auth0Sub, stripeCustomerID, role string) (*queries.CreateUserRow, error) {
+					assert.Equal(t, "auth0|user", auth0Sub)
+					assert.Equal(t, "user", role)
+					if tc.createErr != nil {
+						return nil, tc.createErr
+					}
+					return &queries.CreateUserRow{ID: pgtype.UUID{Bytes: createdID, Valid: true}}, nil
+				},
+			}
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("X-Test-User", "auth0|user")
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+
+			id, err := ResolveID(c, repo)
+			assert.Equal(t, tc.wantCreate, len(repo.CreateCalls()) == 1)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantID, id)
+		})
+	}
+}
+
+func TestResolveID_NoSubject(t *testing.T) {
+	repo := &RepositoryMock{}
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	c.Set("user", &jwt.Token{Claims: jwt.MapClaims{"sub": ""}})
+
+	_, err := ResolveID(c, repo)
+	assert.ErrorIs(t, err, ErrNoSubject)
+	assert.Empty(t, repo.GetByAuth0SubCalls())
+	assert.Empty(t, repo.CreateCalls())
+}
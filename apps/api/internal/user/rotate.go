@@ -0,0 +1,141 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/fieldcrypt"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// RotateOptions controls a RotateFieldKeys run.
+type RotateOptions struct {
+	// BatchSize is how many users are read per query. Values below 1 use 100.
+	BatchSize int
+	// DryRun counts the rows that would change without writing them.
+	DryRun bool
+	// Decrypt writes every row back in plaintext instead of re-encrypting it, e.g. before
+	// disabling field encryption or rolling back its migration.
+	Decrypt bool
+}
+
+// RotateResult summarizes a RotateFieldKeys run.
+type RotateResult struct {
+	Scanned int
+	Updated int
+}
+
+// RotateFieldKeys re-encrypts the encrypted user columns of every row with the keyring's
+// active key and refreshes the Stripe customer blind index. Rows written before
+// encryption was enabled are encrypted too, and rows already up to date are skipped, so
+// it is safe to re-run. Keep retired keys in the keyring until a run has completed.
+func RotateFieldKeys(
+	ctx context.Context, q queries.Querier, keys *fieldcrypt.Keyring, opts RotateOptions,
+) (RotateResult, error) {
+	var res RotateResult
+	if !opts.Decrypt && keys.Active() == "" {
+		return res, errors.New("field encryption has no active key to rotate to")
+	}
+	if opts.BatchSize < 1 {
+		opts.BatchSize = 100
+	}
+	r := &DefaultRepository{queries: q, keys: keys}
+	// Rotation walks every user row, so its statements get the scan timeout.
+	ctx = storage.WithQueryClass(ctx, storage.QueryClassScan)
+
+	var after pgtype.UUID
+	after.Valid = true
+	for {
+		rows, err := q.ListUserEncryptedFields(ctx, queries.ListUserEncryptedFieldsParams{
+			ID:    after,
+			Limit: int32(opts.BatchSize), // #nosec G115 -- batch size is a small CLI flag
+		})
+		if err != nil {
+			return res, fmt.Errorf("unable to list users: %w", err)
+		}
+		for _, row := range rows {
+			res.Scanned++
+			after = row.ID
+			params, changed, err := r.rotateRow(row, opts.Decrypt)
+			if err != nil {
+				return res, fmt.Errorf("user %s: %w", row.ID.String(), err)
+			}
+			if !changed {
+				continue
+			}
+			res.Updated++
+			if opts.DryRun {
+				continue
+			}
+			if err := q.UpdateUserEncryptedFields(ctx, params); err != nil {
+				return res, fmt.Errorf("unable to update user %s: %w", row.ID.String(), err)
+			}
+		}
+		if len(rows) < opts.BatchSize {
+			return res, nil
+		}
+	}
+}
+
+// rotateRow returns the row's columns re-encrypted with the active key, or in plaintext
+// when decrypting, and whether that differs from what is stored. The blind index is
+// recomputed either way, so a changed index key is picked up too.
+func (r *DefaultRepository) rotateRow(
+	row *queries.ListUserEncryptedFieldsRow, decrypt bool,
+) (queries.UpdateUserEncryptedFieldsParams, bool, error) {
+	params := queries.UpdateUserEncryptedFieldsParams{
+		ID:               row.ID,
+		StripeCustomerID: row.StripeCustomerID,
+		Phone:            row.Phone,
+		BillingAddress:   row.BillingAddress,
+	}
+	if err := r.openProfile(&params.StripeCustomerID, &params.Phone, &params.BillingAddress); err != nil {
+		return params, false, err
+	}
+	if params.StripeCustomerID.Valid {
+		params.StripeCustomerIDHash = r.stripeCustomerIDHash(params.StripeCustomerID.String)
+	}
+	changed := params.StripeCustomerIDHash != row.StripeCustomerIDHash
+
+	if decrypt {
+		changed = changed || params.StripeCustomerID != row.StripeCustomerID || params.Phone != row.Phone ||
+			!bytes.Equal(params.BillingAddress, row.BillingAddress)
+		return params, changed, nil
+	}
+
+	changed = changed || r.keys.NeedsRotation(row.StripeCustomerID.String) ||
+		r.keys.NeedsRotation(row.Phone.String) || r.keys.NeedsRotation(string(row.BillingAddress))
+	if !changed {
+		return params, false, nil
+	}
+	if err := r.sealText(columnStripeCustomerID, &params.StripeCustomerID); err != nil {
+		return params, false, err
+	}
+	if err := r.sealText(columnPhone, &params.Phone); err != nil {
+		return params, false, err
+	}
+	sealed, err := r.keys.EncryptJSON(columnBillingAddress, params.BillingAddress)
+	if err != nil {
+		return params, false, fmt.Errorf("unable to encrypt %s: %w", columnBillingAddress, err)
+	}
+	params.BillingAddress = sealed
+	return params, true, nil
+}
+
+// sealText encrypts a text column in place.
+func (r *DefaultRepository) sealText(column string, value *pgtype.Text) error {
+	if !value.Valid {
+		return nil
+	}
+	sealed, err := r.keys.Encrypt(column, value.String)
+	if err != nil {
+		return fmt.Errorf("unable to encrypt %s: %w", column, err)
+	}
+	value.String = sealed
+	return nil
+}
@@ -0,0 +1,134 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/fieldcrypt"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+func TestRotateFieldKeys(t *testing.T) {
+	ctx := context.Background()
+	oldKeys := testKeyring(t, "k1", "k1")
+	newKeys := testKeyring(t, "k2", "k1", "k2")
+
+	sealedStripe, err := oldKeys.Encrypt(columnStripeCustomerID, "cus_old")
+	require.NoError(t, err)
+	currentStripe, err := newKeys.Encrypt(columnStripeCustomerID, "cus_current")
+	require.NoError(t, err)
+
+	newRows := func() []*queries.ListUserEncryptedFieldsRow {
+		return []*queries.ListUserEncryptedFieldsRow{
+			{
+				// Written before encryption was enabled.
+				ID:               pgtype.UUID{Bytes: uuid.New(), Valid: true},
+				StripeCustomerID: pgtype.Text{String: "cus_plain", Valid: true},
+				Phone:            pgtype.Text{String: "+1 555 0100", Valid: true},
+				BillingAddress:   []byte(`{"line1":"1 Main St"}`),
+			},
+			{
+				// Encrypted with the retired key.
+				ID:                   pgtype.UUID{Bytes: uuid.New(), Valid: true},
+				StripeCustomerID:     pgtype.Text{String: sealedStripe, Valid: true},
+				StripeCustomerIDHash: pgtype.Text{String: oldKeys.BlindIndex("cus_old"), Valid: true},
+			},
+			{
+				// Already up to date.
+				ID:                   pgtype.UUID{Bytes: uuid.New(), Valid: true},
+				StripeCustomerID:     pgtype.Text{String: currentStripe, Valid: true},
+				StripeCustomerIDHash: pgtype.Text{String: newKeys.BlindIndex("cus_current"), Valid: true},
+			},
+		}
+	}
+
+	// listMock pages through rows in batches, honouring the id cursor.
+	listMock := func(rows []*queries.ListUserEncryptedFieldsRow) *mockQuerier {
+		mock := &mockQuerier{}
+		mock.ListUserEncryptedFieldsFunc = func(
+			ctx context.Context, arg queries.ListUserEncryptedFieldsParams,
+		) ([]*queries.ListUserEncryptedFieldsRow, error) {
+			start := 0
+			for i, row := range rows {
+				if row.ID == arg.ID {
+					start = i + 1
+				}
+			}
+			end := min(start+int(arg.Limit), len(rows))
+			return rows[start:end], nil
+		}
+		mock.UpdateUserEncryptedFieldsFunc = func(context.Context, queries.UpdateUserEncryptedFieldsParams) error {
+			return nil
+		}
+		return mock
+	}
+
+	t.Run("success: re-encrypts stale rows with the active key", func(t *testing.T) {
+		mock := listMock(newRows())
+
+		res, err := RotateFieldKeys(ctx, mock, newKeys, RotateOptions{BatchSize: 2})
+		require.NoError(t, err)
+		assert.Equal(t, RotateResult{Scanned: 3, Updated: 2}, res)
+		assert.Len(t, mock.ListUserEncryptedFieldsCalls(), 2)
+
+		updates := mock.UpdateUserEncryptedFieldsCalls()
+		require.Len(t, updates, 2)
+		plain := updates[0].Arg
+		assert.True(t, strings.HasPrefix(plain.StripeCustomerID.String, "enc:v1:k2:"))
+		assert.True(t, strings.HasPrefix(plain.Phone.String, "enc:v1:k2:"))
+		assert.NotContains(t, string(plain.BillingAddress), "Main St")
+		assert.Equal(t, newKeys.BlindIndex("cus_plain"), plain.StripeCustomerIDHash.String)
+
+		retired := updates[1].Arg
+		assert.True(t, strings.HasPrefix(retired.StripeCustomerID.String, "enc:v1:k2:"))
+		opened, err := newKeys.Decrypt(columnStripeCustomerID, retired.StripeCustomerID.String)
+		require.NoError(t, err)
+		assert.Equal(t, "cus_old", opened)
+	})
+
+	t.Run("success: dry run writes nothing", func(t *testing.T) {
+		mock := listMock(newRows())
+
+		res, err := RotateFieldKeys(ctx, mock, newKeys, RotateOptions{DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, RotateResult{Scanned: 3, Updated: 2}, res)
+		assert.Empty(t, mock.UpdateUserEncryptedFieldsCalls())
+	})
+
+	t.Run("success: decrypt writes plaintext back", func(t *testing.T) {
+		mock := listMock(newRows())
+		decryptOnly := testKeyring(t, "", "k1", "k2")
+
+		res, err := RotateFieldKeys(ctx, mock, decryptOnly, RotateOptions{Decrypt: true})
+		require.NoError(t, err)
+		// The first row is only missing its blind index; the other two are decrypted.
+		assert.Equal(t, RotateResult{Scanned: 3, Updated: 3}, res)
+		for _, call := range mock.UpdateUserEncryptedFieldsCalls() {
+			assert.False(t, strings.HasPrefix(call.Arg.StripeCustomerID.String, "enc:"))
+		}
+	})
+
+	t.Run("fail: no active key", func(t *testing.T) {
+		var nilKeys *fieldcrypt.Keyring
+		_, err := RotateFieldKeys(ctx, &mockQuerier{}, nilKeys, RotateOptions{})
+		assert.ErrorContains(t, err, "no active key")
+	})
+
+	t.Run("fail: update error stops the run", func(t *testing.T) {
+		mock := listMock(newRows())
+		mock.UpdateUserEncryptedFieldsFunc = func(context.Context, queries.UpdateUserEncryptedFieldsParams) error {
+			return fmt.Errorf("db error")
+		}
+
+		res, err := RotateFieldKeys(ctx, mock, newKeys, RotateOptions{})
+		assert.ErrorContains(t, err, "unable to update user")
+		assert.Equal(t, 1, res.Scanned)
+	})
+}
@@ -0,0 +1,107 @@
+package watermark
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func userRepoFor(userID uuid.UUID) *user.RepositoryMock {
+	return &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+		},
+	}
+}
+
+func newContext(method, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, "/api/v1/me/watermark", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("X-Test-User", "auth0|user")
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
+
+func TestDefaultHandler_Put(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "success: saved", body: `{"logo_file_key":"uploads/u/logo.png","opacity":0.5}`, expectedStatus: http.StatusOK},
+		{name: "fail: malformed body", body: `{`, expectedStatus: http.StatusBadRequest},
+		{name: "fail: invalid", body: `{}`, err: fmt.Errorf("%w: logo", ErrInvalid), expectedStatus: http.StatusUnprocessableEntity},
+		{name: "fail: service error", body: `{}`, err: errors.New("db down"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				PutFunc: func(ctx context.Context, uid string, req PutRequest) (*Watermark, error) {
+					assert.Equal(t, userID.String(), uid)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					require.NotNil(t, req.Opacity)
+					assert.InDelta(t, 0.5, *req.Opacity, 1e-6)
+					return &Watermark{Enabled: true, LogoFileKey: req.LogoFileKey, Position: PositionBottomRight}, nil
+				},
+			}
+			c, rec := newContext(http.MethodPut, tc.body)
+
+			require.NoError(t, NewDefaultHandler(svc, userRepoFor(userID)).Put(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Contains(t, rec.Body.String(), `"logo_file_key":"uploads/u/logo.png"`)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_Get(t *testing.T) {
+	userID := uuid.New()
+
+	for err, want := range map[error]int{nil: http.StatusOK, ErrNotFound: http.StatusNotFound} {
+		svc := &ServiceMock{
+			GetFunc: func(ctx context.Context, uid string) (*Watermark, error) {
+				if err != nil {
+					return nil, err
+				}
+				return &Watermark{Position: PositionCenter}, nil
+			},
+		}
+		c, rec := newContext(http.MethodGet, "")
+		require.NoError(t, NewDefaultHandler(svc, userRepoFor(userID)).Get(c))
+		assert.Equal(t, want, rec.Code, err)
+	}
+}
+
+func TestDefaultHandler_Delete(t *testing.T) {
+	userID := uuid.New()
+
+	for err, want := range map[error]int{nil: http.StatusNoContent, ErrNotFound: http.StatusNotFound} {
+		svc := &ServiceMock{
+			DeleteFunc: func(ctx context.Context, uid string) error { return err },
+		}
+		c, rec := newContext(http.MethodDelete, "")
+		require.NoError(t, NewDefaultHandler(svc, userRepoFor(userID)).Delete(c))
+		assert.Equal(t, want, rec.Code, err)
+	}
+}
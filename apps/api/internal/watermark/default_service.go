@@ -0,0 +1,138 @@
+package watermark
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// DefaultService implements Service.
+type DefaultService struct {
+	querier queries.Querier
+	// buckets checks logo uploads. Nil fails every put request.
+	buckets storage.Buckets
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService with a database.
+func NewDefaultService(db storage.Database, buckets storage.Buckets) *DefaultService {
+	return &DefaultService{querier: queries.New(db), buckets: buckets}
+}
+
+// NewDefaultServiceWithQuerier creates a new DefaultService with a custom querier (for testing).
+func NewDefaultServiceWithQuerier(querier queries.Querier, buckets storage.Buckets) *DefaultService {
+	return &DefaultService{querier: querier, buckets: buckets}
+}
+
+// Get returns the user's watermark.
+func (s *DefaultService) Get(ctx context.Context, userID string) (*Watermark, error) {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.querier.GetAccountWatermark(ctx, uid)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watermark: %w", err)
+	}
+	return watermarkFromRow(row), nil
+}
+
+// Put saves the user's watermark. The logo must be the user's own upload.
+func (s *DefaultService) Put(ctx context.Context, userID string, req PutRequest) (*Watermark, error) {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+	logoURL, err := s.logoURL(ctx, userID, req.LogoFileKey)
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.querier.UpsertAccountWatermark(ctx, queries.UpsertAccountWatermarkParams{
+		UserID:   uid,
+		Enabled:  *req.Enabled,
+		LogoUrl:  logoURL,
+		Position: req.Position,
+		Opacity:  *req.Opacity,
+		Scale:    *req.Scale,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save watermark: %w", err)
+	}
+	return watermarkFromRow(row), nil
+}
+
+// Delete removes the user's watermark.
+func (s *DefaultService) Delete(ctx context.Context, userID string) error {
+	uid, err := parseUUID(userID)
+	if err != nil {
+		return err
+	}
+	n, err := s.querier.DeleteAccountWatermark(ctx, uid)
+	if err != nil {
+		return fmt.Errorf("failed to delete watermark: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// logoURL checks that key is userID's own upload of an allowed type and size, returning
+// its s3:// URL.
+func (s *DefaultService) logoURL(ctx context.Context, userID, key string) (string, error) {
+	// Presigned uploads are keyed under the uploader's ID; see storage.GeneratePresignedUploadURL.
+	if !strings.HasPrefix(key, storage.UploadPrefix(ctx, userID)) || strings.Contains(key, "..") {
+		return "", fmt.Errorf("%w: logo is not one of your uploads", ErrInvalid)
+	}
+
+	if s.buckets == nil {
+		return "", errors.New("watermarks are unavailable: storage is not configured")
+	}
+	files, err := s.buckets.ForUser(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve logo storage: %w", err)
+	}
+	head, err := files.HeadFile(ctx, key)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return "", fmt.Errorf("%w: logo upload not found", ErrInvalid)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to check logo: %w", err)
+	}
+	if obj, ok := head.(*s3.HeadObjectOutput); ok {
+		if !storage.ValidateContentType(aws.ToString(obj.ContentType)) {
+			return "", fmt.Errorf("%w: logo must be a JPEG, PNG or WebP image", ErrInvalid)
+		}
+		if aws.ToInt64(obj.ContentLength) > MaxLogoBytes {
+			return "", fmt.Errorf("%w: logo must be 2MB or smaller", ErrInvalid)
+		}
+	}
+
+	return fmt.Sprintf("s3://%s/%s", files.BucketName(), key), nil
+}
+
+func parseUUID(id string) (pgtype.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf("invalid user ID: %w", err)
+	}
+	return pgtype.UUID{Bytes: parsed, Valid: true}, nil
+}
@@ -0,0 +1,185 @@
+package watermark
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+func float32Ptr(v float32) *float32 { return &v }
+
+func TestDefaultService_Put(t *testing.T) {
+	userID := uuid.New()
+	logoKey := fmt.Sprintf("uploads/%s/logo.png", userID)
+	png := &s3.HeadObjectOutput{ContentType: aws.String("image/png"), ContentLength: aws.Int64(40 << 10)}
+	disabled := false
+
+	testCases := []struct {
+		name         string
+		req          PutRequest
+		head         interface{}
+		headErr      error
+		wantRow      queries.UpsertAccountWatermarkParams
+		wantErr      error
+		wantErrSubst string
+	}{
+		{
+			name: "success: defaults",
+			req:  PutRequest{LogoFileKey: " " + logoKey + " "},
+			head: png,
+			wantRow: queries.UpsertAccountWatermarkParams{
+				Enabled: true, LogoUrl: "s3://bucket/" + logoKey, Position: PositionBottomRight,
+				Opacity: DefaultOpacity, Scale: DefaultScale,
+			},
+		},
+		{
+			name: "success: explicit settings",
+			req: PutRequest{
+				LogoFileKey: logoKey, Enabled: &disabled, Position: PositionTopLeft,
+				Opacity: float32Ptr(0.5), Scale: float32Ptr(0.1),
+			},
+			head: png,
+			wantRow: queries.UpsertAccountWatermarkParams{
+				Enabled: false, LogoUrl: "s3://bucket/" + logoKey, Position: PositionTopLeft, Opacity: 0.5, Scale: 0.1,
+			},
+		},
+		{name: "fail: missing logo", req: PutRequest{}, wantErr: ErrInvalid},
+		{name: "fail: unknown position", req: PutRequest{LogoFileKey: logoKey, Position: "left"}, wantErr: ErrInvalid},
+		{name: "fail: zero opacity", req: PutRequest{LogoFileKey: logoKey, Opacity: float32Ptr(0)}, wantErr: ErrInvalid},
+		{name: "fail: scale too large", req: PutRequest{LogoFileKey: logoKey, Scale: float32Ptr(0.9)}, wantErr: ErrInvalid},
+		{
+			name:    "fail: another user's upload",
+			req:     PutRequest{LogoFileKey: fmt.Sprintf("uploads/%s/logo.png", uuid.New())},
+			wantErr: ErrInvalid,
+		},
+		{
+			name:    "fail: path traversal",
+			req:     PutRequest{LogoFileKey: fmt.Sprintf("uploads/%s/../other/logo.png", userID)},
+			wantErr: ErrInvalid,
+		},
+		{name: "fail: upload missing", req: PutRequest{LogoFileKey: logoKey}, headErr: storage.ErrObjectNotFound, wantErr: ErrInvalid},
+		{
+			name:    "fail: not an image",
+			req:     PutRequest{LogoFileKey: logoKey},
+			head:    &s3.HeadObjectOutput{ContentType: aws.String("application/pdf"), ContentLength: aws.Int64(1)},
+			wantErr: ErrInvalid,
+		},
+		{
+			name:    "fail: logo too large",
+			req:     PutRequest{LogoFileKey: logoKey},
+			head:    &s3.HeadObjectOutput{ContentType: aws.String("image/png"), ContentLength: aws.Int64(MaxLogoBytes + 1)},
+			wantErr: ErrInvalid,
+		},
+		{
+			name:         "fail: head error",
+			req:          PutRequest{LogoFileKey: logoKey},
+			headErr:      errors.New("s3 down"),
+			wantErrSubst: "failed to check logo",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				UpsertAccountWatermarkFunc: func(
+					ctx context.Context, arg queries.UpsertAccountWatermarkParams,
+				) (*queries.AccountWatermark, error) {
+					return &queries.AccountWatermark{
+						UserID: arg.UserID, Enabled: arg.Enabled, LogoUrl: arg.LogoUrl, Position: arg.Position,
+						Opacity: arg.Opacity, Scale: arg.Scale,
+						UpdatedAt: pgtype.Timestamptz{Time: time.Unix(1700000000, 0).UTC(), Valid: true},
+					}, nil
+				},
+			}
+			buckets := storage.NewPlatformBuckets(&storage.S3ServiceMock{
+				HeadFileFunc: func(ctx context.Context, fileKey string) (interface{}, error) {
+					return tc.head, tc.headErr
+				},
+				BucketNameFunc: func() string { return "bucket" },
+			})
+
+			wm, err := NewDefaultServiceWithQuerier(q, buckets).Put(context.Background(), userID.String(), tc.req)
+			switch {
+			case tc.wantErr != nil:
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, q.UpsertAccountWatermarkCalls())
+				return
+			case tc.wantErrSubst != "":
+				assert.ErrorContains(t, err, tc.wantErrSubst)
+				assert.Empty(t, q.UpsertAccountWatermarkCalls())
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, q.UpsertAccountWatermarkCalls(), 1)
+			tc.wantRow.UserID = pgtype.UUID{Bytes: userID, Valid: true}
+			assert.Equal(t, tc.wantRow, q.UpsertAccountWatermarkCalls()[0].Arg)
+			assert.Equal(t, logoKey, wm.LogoFileKey)
+			assert.Equal(t, tc.wantRow.Position, wm.Position)
+		})
+	}
+}
+
+func TestDefaultService_Get(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name    string
+		row     *queries.AccountWatermark
+		err     error
+		wantErr error
+	}{
+		{
+			name: "success: found",
+			row: &queries.AccountWatermark{
+				Enabled: true, LogoUrl: "s3://bucket/uploads/u/logo.png", Position: PositionCenter, Opacity: 0.8, Scale: 0.2,
+			},
+		},
+		{name: "fail: none configured", err: pgx.ErrNoRows, wantErr: ErrNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &queries.QuerierMock{
+				GetAccountWatermarkFunc: func(ctx context.Context, uid pgtype.UUID) (*queries.AccountWatermark, error) {
+					assert.Equal(t, userID, uuid.UUID(uid.Bytes))
+					return tc.row, tc.err
+				},
+			}
+			wm, err := NewDefaultServiceWithQuerier(q, nil).Get(context.Background(), userID.String())
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "uploads/u/logo.png", wm.LogoFileKey)
+			assert.Equal(t, PositionCenter, wm.Position)
+		})
+	}
+}
+
+func TestDefaultService_Delete(t *testing.T) {
+	userID := uuid.New()
+
+	for rows, want := range map[int64]error{1: nil, 0: ErrNotFound} {
+		q := &queries.QuerierMock{
+			DeleteAccountWatermarkFunc: func(ctx context.Context, uid pgtype.UUID) (int64, error) {
+				return rows, nil
+			},
+		}
+		err := NewDefaultServiceWithQuerier(q, nil).Delete(context.Background(), userID.String())
+		assert.ErrorIs(t, err, want, "rows=%d", rows)
+	}
+}
@@ -0,0 +1,15 @@
+package watermark
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service manages a user's watermark. Every call is scoped to the user.
+type Service interface {
+	// Get returns the user's watermark, or ErrNotFound.
+	Get(ctx context.Context, userID string) (*Watermark, error)
+	// Put saves the user's watermark, replacing any earlier one.
+	Put(ctx context.Context, userID string, req PutRequest) (*Watermark, error)
+	// Delete removes the user's watermark. Images already watermarked keep their copy.
+	Delete(ctx context.Context, userID string) error
+}
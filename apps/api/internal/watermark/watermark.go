@@ -0,0 +1,124 @@
+// Package watermark manages an account's logo watermark: the logo, where it sits and how
+// strongly it shows. The worker renders it onto a separate copy of each staged output,
+// which share links and staged downloads serve; the stored original and staged output are
+// never watermarked. Settings apply to images staged after they are saved.
+package watermark
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// Logo positions. They mirror the project disclosure banner's, plus center.
+const (
+	PositionTopLeft      = "top_left"
+	PositionTopCenter    = "top_center"
+	PositionTopRight     = "top_right"
+	PositionCenter       = "center"
+	PositionBottomLeft   = "bottom_left"
+	PositionBottomCenter = "bottom_center"
+	PositionBottomRight  = "bottom_right"
+)
+
+// Positions lists every position a logo can be placed at.
+var Positions = []string{
+	PositionTopLeft, PositionTopCenter, PositionTopRight, PositionCenter,
+	PositionBottomLeft, PositionBottomCenter, PositionBottomRight,
+}
+
+// Defaults and bounds of the logo's appearance, matching the account_watermarks checks.
+const (
+	DefaultOpacity = 0.8
+	DefaultScale   = 0.2
+	MinScale       = 0.05
+	MaxScale       = 0.5
+)
+
+// MaxLogoBytes caps the size of a logo upload.
+const MaxLogoBytes = 2 << 20
+
+var (
+	// ErrNotFound is returned when the account has no watermark.
+	ErrNotFound = errors.New("no watermark configured")
+	// ErrInvalid is returned for malformed watermark settings or an unusable logo.
+	ErrInvalid = errors.New("invalid watermark settings")
+)
+
+// Watermark is an account's watermark settings.
+type Watermark struct {
+	Enabled bool `json:"enabled"`
+	// LogoFileKey is the key of the logo upload.
+	LogoFileKey string    `json:"logo_file_key"`
+	Position    string    `json:"position"`
+	Opacity     float32   `json:"opacity"`
+	Scale       float32   `json:"scale"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// PutRequest sets the account's watermark, replacing any earlier one.
+type PutRequest struct {
+	// LogoFileKey is the key of the logo, uploaded through /uploads/presign. A PNG with a
+	// transparent background works best.
+	LogoFileKey string `json:"logo_file_key"`
+	// Enabled defaults to true; false keeps the settings but stops watermarking.
+	Enabled *bool `json:"enabled"`
+	// Position defaults to bottom_right.
+	Position string `json:"position"`
+	// Opacity of the logo from 0 (exclusive) to 1; defaults to DefaultOpacity.
+	Opacity *float32 `json:"opacity"`
+	// Scale is the logo's width as a fraction of the image's, from MinScale to MaxScale;
+	// defaults to DefaultScale.
+	Scale *float32 `json:"scale"`
+}
+
+// validate checks r and fills in the defaults.
+func (r *PutRequest) validate() error {
+	r.LogoFileKey = strings.TrimSpace(r.LogoFileKey)
+	if r.LogoFileKey == "" {
+		return fmt.Errorf("%w: logo_file_key is required", ErrInvalid)
+	}
+	if r.Enabled == nil {
+		enabled := true
+		r.Enabled = &enabled
+	}
+	if r.Position == "" {
+		r.Position = PositionBottomRight
+	}
+	if !slices.Contains(Positions, r.Position) {
+		return fmt.Errorf("%w: position must be one of %s", ErrInvalid, strings.Join(Positions, ", "))
+	}
+	if r.Opacity == nil {
+		opacity := float32(DefaultOpacity)
+		r.Opacity = &opacity
+	}
+	if *r.Opacity <= 0 || *r.Opacity > 1 {
+		return fmt.Errorf("%w: opacity must be greater than 0 and at most 1", ErrInvalid)
+	}
+	if r.Scale == nil {
+		scale := float32(DefaultScale)
+		r.Scale = &scale
+	}
+	if *r.Scale < MinScale || *r.Scale > MaxScale {
+		return fmt.Errorf("%w: scale must be between %.2f and %.2f", ErrInvalid, MinScale, MaxScale)
+	}
+	return nil
+}
+
+// watermarkFromRow converts an account_watermarks row.
+func watermarkFromRow(row *queries.AccountWatermark) *Watermark {
+	_, key, _ := storage.ParseObjectURL(row.LogoUrl)
+	return &Watermark{
+		Enabled:     row.Enabled,
+		LogoFileKey: key,
+		Position:    row.Position,
+		Opacity:     row.Opacity,
+		Scale:       row.Scale,
+		UpdatedAt:   row.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,71 @@
+package webhookarchive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// eventIDPattern matches the event IDs providers issue (e.g. Stripe's evt_1NqK...), and
+// keeps IDs from escaping their source's folder.
+var eventIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,254}$`)
+
+// DefaultService implements Service on an S3 bucket.
+type DefaultService struct {
+	files  storage.S3Service
+	prefix string
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService that keeps payloads in files under
+// cfg.Prefix.
+func NewDefaultService(files storage.S3Service, cfg config.WebhookArchive) *DefaultService {
+	prefix := cfg.Prefix
+	if prefix == "" {
+		prefix = "webhooks"
+	}
+	return &DefaultService{files: files, prefix: prefix}
+}
+
+// Put archives payload as the event's payload.
+func (s *DefaultService) Put(ctx context.Context, source Source, eventID string, payload []byte) error {
+	key, err := s.key(source, eventID)
+	if err != nil {
+		return err
+	}
+	if err := s.files.PutFile(ctx, key, payload, "application/json"); err != nil {
+		return fmt.Errorf("failed to archive %s webhook %s: %w", source, eventID, err)
+	}
+	return nil
+}
+
+// Get returns the archived payload of the event.
+func (s *DefaultService) Get(ctx context.Context, source Source, eventID string) ([]byte, error) {
+	key, err := s.key(source, eventID)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := s.files.GetFile(ctx, key)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return nil, fmt.Errorf("%w: %s %s", ErrNotFound, source, eventID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s webhook %s: %w", source, eventID, err)
+	}
+	return payload, nil
+}
+
+// key returns the object key of the event's payload.
+func (s *DefaultService) key(source Source, eventID string) (string, error) {
+	if !eventIDPattern.MatchString(eventID) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidEventID, eventID)
+	}
+	return path.Join(s.prefix, string(source), eventID+".json"), nil
+}
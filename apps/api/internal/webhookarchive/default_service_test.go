@@ -0,0 +1,96 @@
+package webhookarchive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+func TestDefaultService_Put(t *testing.T) {
+	testCases := []struct {
+		name    string
+		prefix  string
+		eventID string
+		putErr  error
+		wantKey string
+		wantErr error
+	}{
+		{name: "success: default prefix", eventID: "evt_123", wantKey: "webhooks/stripe/evt_123.json"},
+		{name: "success: custom prefix", prefix: "archive/hooks", eventID: "evt_1", wantKey: "archive/hooks/stripe/evt_1.json"},
+		{name: "fail: path in event id", eventID: "../evt_1", wantErr: ErrInvalidEventID},
+		{name: "fail: empty event id", eventID: "", wantErr: ErrInvalidEventID},
+		{name: "fail: storage error", eventID: "evt_1", putErr: errors.New("boom"), wantKey: "webhooks/stripe/evt_1.json"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			files := &storage.S3ServiceMock{
+				PutFileFunc: func(ctx context.Context, fileKey string, body []byte, contentType string) error {
+					assert.Equal(t, tc.wantKey, fileKey)
+					assert.Equal(t, `{"id":"evt"}`, string(body))
+					assert.Equal(t, "application/json", contentType)
+					return tc.putErr
+				},
+			}
+			svc := NewDefaultService(files, config.WebhookArchive{Prefix: tc.prefix})
+
+			err := svc.Put(context.Background(), SourceStripe, tc.eventID, []byte(`{"id":"evt"}`))
+			switch {
+			case tc.wantErr != nil:
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, files.PutFileCalls())
+			case tc.putErr != nil:
+				assert.ErrorIs(t, err, tc.putErr)
+			default:
+				require.NoError(t, err)
+				assert.Len(t, files.PutFileCalls(), 1)
+			}
+		})
+	}
+}
+
+func TestDefaultService_Get(t *testing.T) {
+	testCases := []struct {
+		name    string
+		eventID string
+		body    []byte
+		getErr  error
+		wantErr error
+	}{
+		{name: "success: archived payload", eventID: "evt_1", body: []byte(`{"id":"evt_1"}`)},
+		{
+			name:    "fail: not archived",
+			eventID: "evt_1",
+			getErr:  fmt.Errorf("failed to get file: %w", storage.ErrObjectNotFound),
+			wantErr: ErrNotFound,
+		},
+		{name: "fail: invalid event id", eventID: "evt/1", wantErr: ErrInvalidEventID},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			files := &storage.S3ServiceMock{
+				GetFileFunc: func(ctx context.Context, fileKey string) ([]byte, error) {
+					assert.Equal(t, "webhooks/replicate/evt_1.json", fileKey)
+					return tc.body, tc.getErr
+				},
+			}
+			svc := NewDefaultService(files, config.WebhookArchive{})
+
+			got, err := svc.Get(context.Background(), SourceReplicate, tc.eventID)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.body, got)
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package webhookarchive
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service stores and loads archived webhook payloads.
+type Service interface {
+	// Put archives payload as the event's payload, replacing an earlier delivery of it.
+	Put(ctx context.Context, source Source, eventID string, payload []byte) error
+	// Get returns the archived payload of the event, or an error wrapping ErrNotFound.
+	Get(ctx context.Context, source Source, eventID string) ([]byte, error)
+}
@@ -0,0 +1,22 @@
+// Package webhookarchive keeps a copy of every verified inbound webhook payload in S3, so
+// an event can be fed through its handler again after a handler bug has been fixed.
+package webhookarchive
+
+import "errors"
+
+// Source is the service a webhook came from. It names the folder its payloads are kept in.
+type Source string
+
+const (
+	// SourceStripe is Stripe billing webhooks.
+	SourceStripe Source = "stripe"
+	// SourceReplicate is Replicate prediction webhooks.
+	SourceReplicate Source = "replicate"
+)
+
+var (
+	// ErrNotFound is returned when no payload is archived for an event.
+	ErrNotFound = errors.New("webhook payload not archived")
+	// ErrInvalidEventID is returned for event IDs that cannot name an archived payload.
+	ErrInvalidEventID = errors.New("invalid webhook event id")
+)
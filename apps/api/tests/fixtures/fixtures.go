@@ -0,0 +1,83 @@
+// Package fixtures builds integration test data with fluent builders, so tests describe the
+// rows they need instead of hand-writing SQL seeds and UUID literals. It is shared by the API
+// and worker integration suites and works with both pgx pools and database/sql.
+//
+//	user, err := fixtures.NewUser().
+//		WithSubscription("pro").
+//		WithProject(fixtures.NewProject("Listing").WithImage(fixtures.NewImage())).
+//		Create(ctx, fixtures.Pgx(db.Pool()))
+package fixtures
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Seed IDs match testdata/seed.sql in the API integration suite.
+var (
+	SeedUserID    = uuid.MustParse("a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11")
+	SeedProjectID = uuid.MustParse("b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12")
+)
+
+// truncateQuery clears every table the builders and the seed write to.
+const truncateQuery = `
+	TRUNCATE TABLE processed_events, pending_billing_events, invoices, subscriptions, images, jobs,
+		image_original_purges, legal_holds, projects, users, plans RESTART IDENTITY CASCADE`
+
+// DB executes statements. Use Pgx or SQL to adapt a connection.
+type DB interface {
+	Exec(ctx context.Context, query string, args ...any) error
+}
+
+// PgxExecer is the part of a pgx pool or connection the builders use.
+type PgxExecer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// SQLExecer is the part of a *sql.DB or *sql.Tx the builders use.
+type SQLExecer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+type pgxDB struct{ db PgxExecer }
+
+func (p pgxDB) Exec(ctx context.Context, query string, args ...any) error {
+	_, err := p.db.Exec(ctx, query, args...)
+	return err
+}
+
+type sqlDB struct{ db SQLExecer }
+
+func (s sqlDB) Exec(ctx context.Context, query string, args ...any) error {
+	_, err := s.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// Pgx adapts a pgx pool, connection, or transaction.
+func Pgx(db PgxExecer) DB {
+	return pgxDB{db: db}
+}
+
+// SQL adapts a database/sql DB or transaction.
+func SQL(db SQLExecer) DB {
+	return sqlDB{db: db}
+}
+
+// Truncate empties every table the fixtures and the seed write to and resets sequences.
+func Truncate(ctx context.Context, db DB) error {
+	return db.Exec(ctx, truncateQuery)
+}
+
+// Seed creates the user and project of testdata/seed.sql.
+func Seed(ctx context.Context, db DB) error {
+	_, err := NewUser().
+		WithID(SeedUserID).
+		WithAuth0Sub("auth0|testuser").
+		WithStripeCustomer("cus_test").
+		WithProject(NewProject("Test Project 1").WithID(SeedProjectID)).
+		Create(ctx, db)
+	return err
+}
@@ -0,0 +1,138 @@
+package fixtures
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingDB records statements and fails the one whose query contains failOn.
+type recordingDB struct {
+	queries []string
+	args    [][]any
+	failOn  string
+}
+
+func (r *recordingDB) Exec(_ context.Context, query string, args ...any) error {
+	if r.failOn != "" && strings.Contains(query, r.failOn) {
+		return errors.New("boom")
+	}
+	r.queries = append(r.queries, query)
+	r.args = append(r.args, args)
+	return nil
+}
+
+func (r *recordingDB) tables() []string {
+	var tables []string
+	for _, q := range r.queries {
+		fields := strings.Fields(q)
+		tables = append(tables, fields[2])
+	}
+	return tables
+}
+
+func TestUserBuilder_Create(t *testing.T) {
+	testCases := []struct {
+		name       string
+		build      func() *UserBuilder
+		failOn     string
+		wantTables []string
+		wantErr    string
+		check      func(t *testing.T, db *recordingDB, user *User)
+	}{
+		{
+			name:       "success: user only",
+			build:      NewUser,
+			wantTables: []string{"users"},
+			check: func(t *testing.T, db *recordingDB, user *User) {
+				assert.Equal(t, user.ID, db.args[0][0])
+				assert.Equal(t, "auth0|fixture-"+user.ID.String(), user.Auth0Sub)
+				assert.Nil(t, db.args[0][2], "no stripe customer")
+				assert.Equal(t, "user", db.args[0][3])
+			},
+		},
+		{
+			name: "success: subscription, project, and images",
+			build: func() *UserBuilder {
+				return NewUser().
+					WithStripeCustomer("cus_1").
+					WithRole("admin").
+					WithSubscription("pro").
+					WithProject(NewProject("Listing").
+						WithImage(NewImage()).
+						WithImage(NewImage().WithStatus("ready").WithRoomType("kitchen")))
+			},
+			wantTables: []string{"users", "plans", "subscriptions", "projects", "images", "images"},
+			check: func(t *testing.T, db *recordingDB, user *User) {
+				assert.Equal(t, "cus_1", db.args[0][2])
+				assert.Equal(t, "admin", db.args[0][3])
+				assert.Equal(t, []any{"pro", "price_pro", defaultMonthlyLimit}, db.args[1])
+				assert.Equal(t, "price_pro", db.args[2][2])
+
+				require.Len(t, user.Projects, 1)
+				project := user.Projects[0]
+				assert.Equal(t, user.ID, project.UserID)
+				require.Len(t, project.Images, 2)
+				assert.Equal(t, "queued", project.Images[0].Status)
+				assert.Contains(t, project.Images[0].OriginalURL, project.Images[0].ID.String())
+				assert.Equal(t, project.ID, db.args[4][1])
+				assert.Nil(t, db.args[4][4], "no room type")
+				assert.Equal(t, "ready", db.args[5][3])
+				assert.Equal(t, "kitchen", db.args[5][4])
+			},
+		},
+		{
+			name:    "fail: user insert error",
+			build:   NewUser,
+			failOn:  "INSERT INTO users",
+			wantErr: "create user",
+		},
+		{
+			name:    "fail: subscription insert error",
+			build:   func() *UserBuilder { return NewUser().WithSubscription("pro") },
+			failOn:  "INSERT INTO subscriptions",
+			wantErr: "create subscription to pro",
+		},
+		{
+			name: "fail: image insert error",
+			build: func() *UserBuilder {
+				return NewUser().WithProject(NewProject("Listing").WithImage(NewImage()))
+			},
+			failOn:  "INSERT INTO images",
+			wantErr: "create image",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := &recordingDB{failOn: tc.failOn}
+			user, err := tc.build().Create(context.Background(), db)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantTables, db.tables())
+			if tc.check != nil {
+				tc.check(t, db, user)
+			}
+		})
+	}
+}
+
+func TestSeed(t *testing.T) {
+	t.Run("success: creates the seed user and project", func(t *testing.T) {
+		db := &recordingDB{}
+		require.NoError(t, Seed(context.Background(), db))
+		assert.Equal(t, []string{"users", "projects"}, db.tables())
+		assert.Equal(t, SeedUserID, db.args[0][0])
+		assert.Equal(t, "auth0|testuser", db.args[0][1])
+		assert.Equal(t, SeedProjectID, db.args[1][0])
+		assert.Equal(t, SeedUserID, db.args[1][1])
+	})
+}
@@ -0,0 +1,120 @@
+package fixtures
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Project is a created project and its images.
+type Project struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+	Images []*Image
+}
+
+// ProjectBuilder builds a project row with its images. It is created through
+// UserBuilder.WithProject.
+type ProjectBuilder struct {
+	id     uuid.UUID
+	name   string
+	images []*ImageBuilder
+}
+
+// NewProject starts a project with a random ID.
+func NewProject(name string) *ProjectBuilder {
+	return &ProjectBuilder{id: uuid.New(), name: name}
+}
+
+// WithID sets the project's ID.
+func (b *ProjectBuilder) WithID(id uuid.UUID) *ProjectBuilder {
+	b.id = id
+	return b
+}
+
+// WithImage adds an image to the project.
+func (b *ProjectBuilder) WithImage(image *ImageBuilder) *ProjectBuilder {
+	b.images = append(b.images, image)
+	return b
+}
+
+func (b *ProjectBuilder) create(ctx context.Context, db DB, userID uuid.UUID) (*Project, error) {
+	if err := db.Exec(ctx, `INSERT INTO projects (id, user_id, name) VALUES ($1, $2, $3)`,
+		b.id, userID, b.name); err != nil {
+		return nil, fmt.Errorf("create project: %w", err)
+	}
+	project := &Project{ID: b.id, UserID: userID}
+	for _, ib := range b.images {
+		image, err := ib.create(ctx, db, b.id)
+		if err != nil {
+			return nil, err
+		}
+		project.Images = append(project.Images, image)
+	}
+	return project, nil
+}
+
+// Image is a created image.
+type Image struct {
+	ID          uuid.UUID
+	ProjectID   uuid.UUID
+	OriginalURL string
+	Status      string
+}
+
+// ImageBuilder builds an image row. It is created through ProjectBuilder.WithImage.
+type ImageBuilder struct {
+	id          uuid.UUID
+	originalURL string
+	status      string
+	roomType    string
+}
+
+// NewImage starts a queued image with a random ID and an original under uploads/<id>.
+func NewImage() *ImageBuilder {
+	id := uuid.New()
+	return &ImageBuilder{
+		id:          id,
+		originalURL: fmt.Sprintf("http://localhost:4566/real-staging/uploads/%s/original.jpg", id),
+		status:      "queued",
+	}
+}
+
+// WithID sets the image's ID. The original URL is not changed.
+func (b *ImageBuilder) WithID(id uuid.UUID) *ImageBuilder {
+	b.id = id
+	return b
+}
+
+// WithOriginalURL sets the image's original URL.
+func (b *ImageBuilder) WithOriginalURL(url string) *ImageBuilder {
+	b.originalURL = url
+	return b
+}
+
+// WithStatus sets the image's status.
+func (b *ImageBuilder) WithStatus(status string) *ImageBuilder {
+	b.status = status
+	return b
+}
+
+// WithRoomType sets the image's room type.
+func (b *ImageBuilder) WithRoomType(roomType string) *ImageBuilder {
+	b.roomType = roomType
+	return b
+}
+
+func (b *ImageBuilder) create(ctx context.Context, db DB, projectID uuid.UUID) (*Image, error) {
+	var roomType any
+	if b.roomType != "" {
+		roomType = b.roomType
+	}
+	if err := db.Exec(ctx, `
+		INSERT INTO images (id, project_id, original_url, status, room_type)
+		VALUES ($1, $2, $3, $4::image_status, $5)`,
+		b.id, projectID, b.originalURL, b.status, roomType); err != nil {
+		return nil, fmt.Errorf("create image: %w", err)
+	}
+	return &Image{ID: b.id, ProjectID: projectID, OriginalURL: b.originalURL, Status: b.status}, nil
+}
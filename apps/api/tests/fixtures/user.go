@@ -0,0 +1,110 @@
+package fixtures
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// defaultMonthlyLimit is the monthly image limit of plans created for WithSubscription.
+const defaultMonthlyLimit = 100
+
+// User is a created user and the rows created with it.
+type User struct {
+	ID       uuid.UUID
+	Auth0Sub string
+	Projects []*Project
+}
+
+// UserBuilder builds a user row with its subscriptions and projects.
+type UserBuilder struct {
+	id               uuid.UUID
+	auth0Sub         string
+	stripeCustomerID string
+	role             string
+	plans            []string
+	projects         []*ProjectBuilder
+}
+
+// NewUser starts a user with a random ID, a matching auth0 subject, and the user role.
+func NewUser() *UserBuilder {
+	id := uuid.New()
+	return &UserBuilder{id: id, auth0Sub: "auth0|fixture-" + id.String(), role: "user"}
+}
+
+// WithID sets the user's ID.
+func (b *UserBuilder) WithID(id uuid.UUID) *UserBuilder {
+	b.id = id
+	return b
+}
+
+// WithAuth0Sub sets the user's auth0 subject.
+func (b *UserBuilder) WithAuth0Sub(sub string) *UserBuilder {
+	b.auth0Sub = sub
+	return b
+}
+
+// WithStripeCustomer links the user to a Stripe customer.
+func (b *UserBuilder) WithStripeCustomer(customerID string) *UserBuilder {
+	b.stripeCustomerID = customerID
+	return b
+}
+
+// WithRole sets the user's role.
+func (b *UserBuilder) WithRole(role string) *UserBuilder {
+	b.role = role
+	return b
+}
+
+// WithSubscription gives the user an active subscription to the plan with the given code,
+// creating the plan with price ID "price_<code>" when it does not exist.
+func (b *UserBuilder) WithSubscription(plan string) *UserBuilder {
+	b.plans = append(b.plans, plan)
+	return b
+}
+
+// WithProject adds a project owned by the user.
+func (b *UserBuilder) WithProject(project *ProjectBuilder) *UserBuilder {
+	b.projects = append(b.projects, project)
+	return b
+}
+
+// Create inserts the user, then its subscriptions and projects.
+func (b *UserBuilder) Create(ctx context.Context, db DB) (*User, error) {
+	var customerID any
+	if b.stripeCustomerID != "" {
+		customerID = b.stripeCustomerID
+	}
+	if err := db.Exec(ctx, `INSERT INTO users (id, auth0_sub, stripe_customer_id, role) VALUES ($1, $2, $3, $4)`,
+		b.id, b.auth0Sub, customerID, b.role); err != nil {
+		return nil, fmt.Errorf("create user: %w", err)
+	}
+
+	for i, plan := range b.plans {
+		priceID := "price_" + plan
+		if err := db.Exec(ctx, `
+			INSERT INTO plans (code, price_id, monthly_limit) VALUES ($1, $2, $3)
+			ON CONFLICT (code) DO NOTHING`,
+			plan, priceID, defaultMonthlyLimit); err != nil {
+			return nil, fmt.Errorf("create plan %s: %w", plan, err)
+		}
+		subscriptionID := fmt.Sprintf("sub_fixture_%s_%d", b.id, i)
+		if err := db.Exec(ctx, `
+			INSERT INTO subscriptions (user_id, stripe_subscription_id, status, price_id)
+			VALUES ($1, $2, 'active', $3)`,
+			b.id, subscriptionID, priceID); err != nil {
+			return nil, fmt.Errorf("create subscription to %s: %w", plan, err)
+		}
+	}
+
+	user := &User{ID: b.id, Auth0Sub: b.auth0Sub}
+	for _, pb := range b.projects {
+		project, err := pb.create(ctx, db, b.id)
+		if err != nil {
+			return nil, err
+		}
+		user.Projects = append(user.Projects, project)
+	}
+	return user, nil
+}
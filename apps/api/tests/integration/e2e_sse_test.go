@@ -26,8 +26,10 @@ import (
 	"github.com/real-staging-ai/api/internal/image"
 	"github.com/real-staging-ai/api/internal/job"
 	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/sse"
 	"github.com/real-staging-ai/api/internal/storage"
 	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/tests/fixtures"
 )
 
 func startAsynqWorker(t *testing.T, db storage.Database, emitError bool) (stop func()) {
@@ -54,12 +56,16 @@ func startAsynqWorker(t *testing.T, db storage.Database, emitError bool) (stop f
 		// processing
 		parsed, _ := uuid.Parse(payload.ImageID)
 		_, _ = q.UpdateImageStatus(ctx, queries.UpdateImageStatusParams{ID: pgtype.UUID{Bytes: parsed, Valid: true}, Status: queries.ImageStatus("processing")})
-		_ = rdb.Publish(ctx, fmt.Sprintf("jobs:image:%s", payload.ImageID), `{"status":"processing"}`).Err()
+		_ = rdb.XAdd(ctx, &redis.XAddArgs{
+			Stream: sse.StreamKey(payload.ImageID), Values: map[string]any{"data": `{"status":"processing"}`},
+		}).Err()
 
 		if emitError {
 			// error path
 			_, _ = q.UpdateImageWithError(ctx, queries.UpdateImageWithErrorParams{ID: pgtype.UUID{Bytes: parsed, Valid: true}, Error: text("processor failed")})
-			_ = rdb.Publish(ctx, fmt.Sprintf("jobs:image:%s", payload.ImageID), `{"status":"error"}`).Err()
+			_ = rdb.XAdd(ctx, &redis.XAddArgs{
+				Stream: sse.StreamKey(payload.ImageID), Values: map[string]any{"data": `{"status":"error"}`},
+			}).Err()
 			return fmt.Errorf("fail to trigger retry")
 		}
 
@@ -67,7 +73,9 @@ func startAsynqWorker(t *testing.T, db storage.Database, emitError bool) (stop f
 		time.Sleep(100 * time.Millisecond)
 		staged := payload.OriginalURL + "-staged.jpg"
 		_, _ = q.UpdateImageWithStagedURL(ctx, queries.UpdateImageWithStagedURLParams{ID: pgtype.UUID{Bytes: parsed, Valid: true}, StagedUrl: text(staged), Status: queries.ImageStatus("ready")})
-		_ = rdb.Publish(ctx, fmt.Sprintf("jobs:image:%s", payload.ImageID), `{"status":"ready"}`).Err()
+		_ = rdb.XAdd(ctx, &redis.XAddArgs{
+			Stream: sse.StreamKey(payload.ImageID), Values: map[string]any{"data": `{"status":"ready"}`},
+		}).Err()
 		return nil
 	})
 
@@ -111,7 +119,7 @@ func TestE2E_SSE_ProcessingReady(t *testing.T) {
 	defer ts.Close()
 
 	// Create image
-	reqBody := map[string]any{"project_id": "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12", "original_url": "http://example.com/one.jpg"}
+	reqBody := map[string]any{"project_id": fixtures.SeedProjectID.String(), "original_url": "http://example.com/one.jpg"}
 	b, _ := json.Marshal(reqBody)
 	req, _ := http.NewRequest(http.MethodPost, ts.URL+"/api/v1/images", strings.NewReader(string(b)))
 	req.Header.Set("Content-Type", "application/json")
@@ -175,7 +183,7 @@ func TestE2E_SSE_ProcessingError(t *testing.T) {
 	defer ts.Close()
 
 	// Create image
-	reqBody := map[string]any{"project_id": "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12", "original_url": "http://example.com/two.jpg"}
+	reqBody := map[string]any{"project_id": fixtures.SeedProjectID.String(), "original_url": "http://example.com/two.jpg"}
 	b, _ := json.Marshal(reqBody)
 	req, _ := http.NewRequest(http.MethodPost, ts.URL+"/api/v1/images", strings.NewReader(string(b)))
 	req.Header.Set("Content-Type", "application/json")
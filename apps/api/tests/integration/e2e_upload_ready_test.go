@@ -19,6 +19,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/tests/fixtures"
 )
 
 type presignReq struct {
@@ -83,7 +84,7 @@ func TestE2E_Presign_Upload_CreateImage_ReadyViaSSE(t *testing.T) {
 
 	// 3) Create image pointing to uploaded object (use a plausible public URL form)
 	origURL := "https://test-bucket.s3.amazonaws.com/" + p.FileKey
-	imgBody := map[string]any{"project_id": "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12", "original_url": origURL}
+	imgBody := map[string]any{"project_id": fixtures.SeedProjectID.String(), "original_url": origURL}
 	imgPayload, _ := json.Marshal(imgBody)
 	imgReq, _ := http.NewRequest(http.MethodPost, ts.URL+"/api/v1/images", bytes.NewReader(imgPayload))
 	imgReq.Header.Set("Content-Type", "application/json")
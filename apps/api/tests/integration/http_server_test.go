@@ -13,6 +13,7 @@ import (
 	httpLib "github.com/real-staging-ai/api/internal/http"
 	"github.com/real-staging-ai/api/internal/image"
 	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/tests/fixtures"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -100,7 +101,7 @@ func TestGetProjectsRoute_HTTP(t *testing.T) {
 	assert.GreaterOrEqual(t, len(response.Projects), 1)
 	found := false
 	for _, p := range response.Projects {
-		if p.ID == "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12" && p.Name == "Test Project 1" {
+		if p.ID == fixtures.SeedProjectID.String() && p.Name == "Test Project 1" {
 			found = true
 			break
 		}
@@ -132,7 +133,7 @@ func TestGetProjectByIDRoute_HTTP(t *testing.T) {
 		var p project.Project
 		err := json.Unmarshal(rec.Body.Bytes(), &p)
 		assert.NoError(t, err)
-		assert.Equal(t, "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12", p.ID)
+		assert.Equal(t, fixtures.SeedProjectID.String(), p.ID)
 		assert.Equal(t, "Test Project 1", p.Name)
 	})
 
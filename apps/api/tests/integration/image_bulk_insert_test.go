@@ -0,0 +1,114 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/image"
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/tests/fixtures"
+)
+
+var seedUserID = fixtures.SeedUserID.String()
+
+// newBulkInsertFixture resets the database and returns a project with no images.
+func newBulkInsertFixture(tb testing.TB, db *storage.DefaultDatabase) uuid.UUID {
+	tb.Helper()
+	ctx := context.Background()
+	require.NoError(tb, ResetDatabase(ctx, db.Pool()))
+
+	p, err := project.NewDefaultStorageSQLc(db).CreateProject(ctx, &project.Project{Name: "Bulk"}, seedUserID)
+	require.NoError(tb, err)
+	return uuid.MustParse(p.ID)
+}
+
+func imageRequests(projectID uuid.UUID, n int) []image.CreateImageRequest {
+	style := "modern"
+	reqs := make([]image.CreateImageRequest, n)
+	for i := range reqs {
+		reqs[i] = image.CreateImageRequest{
+			ProjectID:   projectID,
+			OriginalURL: fmt.Sprintf("https://example.com/bulk/%d.jpg", i),
+			Style:       &style,
+		}
+	}
+	return reqs
+}
+
+func TestImageRepository_CreateImages(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+
+	projectID := newBulkInsertFixture(t, db)
+	repo := image.NewDefaultRepository(db)
+	reqs := imageRequests(projectID, 150)
+
+	created, err := repo.CreateImages(ctx, reqs)
+	require.NoError(t, err)
+	require.Len(t, created, len(reqs))
+	for i, img := range created {
+		assert.Equal(t, reqs[i].OriginalURL, img.OriginalUrl)
+		assert.Equal(t, "modern", img.Style.String)
+		assert.True(t, img.CreatedAt.Valid)
+	}
+
+	stored, err := repo.GetImagesByProjectID(ctx, projectID.String())
+	require.NoError(t, err)
+	assert.Len(t, stored, len(reqs))
+}
+
+// BenchmarkImageRepository_CreateImages compares one INSERT per image with the COPY path
+// used by batch creation. Run with:
+//
+//	go test -tags integration -run '^$' -bench CreateImages ./tests/integration
+func BenchmarkImageRepository_CreateImages(b *testing.B) {
+	cfg, err := config.Load()
+	require.NoError(b, err)
+	db, err := storage.NewDefaultDatabase(&cfg.DB)
+	require.NoError(b, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	projectID := newBulkInsertFixture(b, db)
+
+	for _, n := range []int{100, 500, 1000} {
+		reqs := imageRequests(projectID, n)
+
+		b.Run(fmt.Sprintf("sequential/%d", n), func(b *testing.B) {
+			for b.Loop() {
+				err := db.WithTx(ctx, func(tx storage.Database) error {
+					txRepo := image.NewDefaultRepository(tx)
+					for _, req := range reqs {
+						if _, err := txRepo.CreateImage(
+							ctx, req.ProjectID.String(), req.OriginalURL, req.RoomType, req.Style, req.Seed, req.Source,
+						); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+				require.NoError(b, err)
+			}
+		})
+
+		b.Run(fmt.Sprintf("copy/%d", n), func(b *testing.B) {
+			for b.Loop() {
+				err := db.WithTx(ctx, func(tx storage.Database) error {
+					_, err := image.NewDefaultRepository(tx).CreateImages(ctx, reqs)
+					return err
+				})
+				require.NoError(b, err)
+			}
+		})
+	}
+}
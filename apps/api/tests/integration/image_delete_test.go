@@ -0,0 +1,92 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/image"
+	"github.com/real-staging-ai/api/tests/fixtures"
+)
+
+func TestImageDelete_RemovesJobLogs(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+
+	truncateTables(t, db.Pool())
+	seedTables(t, db.Pool())
+
+	var imageID, otherID string
+	err := db.Pool().QueryRow(ctx,
+		`INSERT INTO images (project_id, original_url) VALUES ($1, 's3://bucket/a.jpg') RETURNING id`,
+		fixtures.SeedProjectID.String(),
+	).Scan(&imageID)
+	require.NoError(t, err)
+	err = db.Pool().QueryRow(ctx,
+		`INSERT INTO images (project_id, original_url) VALUES ($1, 's3://bucket/b.jpg') RETURNING id`,
+		fixtures.SeedProjectID.String(),
+	).Scan(&otherID)
+	require.NoError(t, err)
+
+	_, err = db.Pool().Exec(ctx, `
+		INSERT INTO job_logs (job_id, image_id, log)
+		VALUES (gen_random_uuid(), $1, 'started'), (gen_random_uuid(), $1, 'retrying'), (gen_random_uuid(), $2, 'started')`,
+		imageID, otherID,
+	)
+	require.NoError(t, err)
+
+	_, err = db.Pool().Exec(ctx, `DELETE FROM images WHERE id = $1`, imageID)
+	require.NoError(t, err)
+
+	var deleted, kept int
+	err = db.Pool().QueryRow(ctx, `SELECT count(*) FROM job_logs WHERE image_id = $1`, imageID).Scan(&deleted)
+	require.NoError(t, err)
+	err = db.Pool().QueryRow(ctx, `SELECT count(*) FROM job_logs WHERE image_id = $1`, otherID).Scan(&kept)
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+	assert.Equal(t, 1, kept)
+}
+
+func TestImageBulkDelete_SkipsLegalHeldImages(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+
+	truncateTables(t, db.Pool())
+	seedTables(t, db.Pool())
+
+	var imageID, heldID string
+	err := db.Pool().QueryRow(ctx,
+		`INSERT INTO images (project_id, original_url) VALUES ($1, 's3://bucket/a.jpg') RETURNING id`,
+		fixtures.SeedProjectID.String(),
+	).Scan(&imageID)
+	require.NoError(t, err)
+	err = db.Pool().QueryRow(ctx,
+		`INSERT INTO images (project_id, original_url) VALUES ($1, 's3://bucket/b.jpg') RETURNING id`,
+		fixtures.SeedProjectID.String(),
+	).Scan(&heldID)
+	require.NoError(t, err)
+	_, err = db.Pool().Exec(ctx, `INSERT INTO legal_holds (image_id, reason) VALUES ($1, 'dispute')`, heldID)
+	require.NoError(t, err)
+
+	repo := image.NewDefaultRepository(db)
+	rows, err := repo.BulkDeleteImages(ctx, fixtures.SeedProjectID.String(), []string{imageID, heldID})
+	require.NoError(t, err)
+
+	held := map[string]bool{}
+	for _, row := range rows {
+		held[row.ID.String()] = row.Held
+	}
+	assert.Equal(t, map[string]bool{imageID: false, heldID: true}, held)
+
+	var remaining int
+	err = db.Pool().QueryRow(ctx, `SELECT count(*) FROM images WHERE id = ANY($1::uuid[])`, []string{imageID, heldID}).
+		Scan(&remaining)
+	require.NoError(t, err)
+	assert.Equal(t, 1, remaining)
+}
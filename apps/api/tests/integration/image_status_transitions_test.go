@@ -0,0 +1,148 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/tests/fixtures"
+)
+
+func TestImageStatusTransitions_RecordsStatusChanges(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+
+	truncateTables(t, db.Pool())
+	seedTables(t, db.Pool())
+
+	projectID := fixtures.SeedProjectID.String() // from seed data
+
+	var imageID string
+	err := db.Pool().QueryRow(ctx,
+		`INSERT INTO images (project_id, original_url, status) VALUES ($1, 's3://bucket/a.jpg', 'awaiting_upload') RETURNING id`,
+		projectID,
+	).Scan(&imageID)
+	require.NoError(t, err)
+
+	_, err = db.Pool().Exec(ctx, `UPDATE images SET status = 'queued' WHERE id = $1`, imageID)
+	require.NoError(t, err)
+	// A worker describes its changes with transaction-local settings.
+	tx, err := db.Pool().Begin(ctx)
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, `SELECT set_config('app.status_source', 'real-staging-worker', true),
+		set_config('app.job_id', 'job-1', true), set_config('app.job_attempt', '2', true)`)
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, `UPDATE images SET status = 'processing' WHERE id = $1`, imageID)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit(ctx))
+	// Updates that leave the status unchanged are not recorded.
+	_, err = db.Pool().Exec(ctx, `UPDATE images SET status = 'processing' WHERE id = $1`, imageID)
+	require.NoError(t, err)
+	_, err = db.Pool().Exec(ctx, `UPDATE images SET status = 'error', error = 'provider timeout' WHERE id = $1`, imageID)
+	require.NoError(t, err)
+	// The log outlives the image.
+	_, err = db.Pool().Exec(ctx, `DELETE FROM images WHERE id = $1`, imageID)
+	require.NoError(t, err)
+
+	rows, err := db.Pool().Query(ctx, `
+		SELECT COALESCE(from_status::text, ''), to_status::text, COALESCE(error, ''),
+		       source, COALESCE(job_id, ''), COALESCE(attempt, 0)
+		FROM image_status_transitions
+		WHERE image_id = $1
+		ORDER BY id`, imageID)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	type event struct {
+		from, to, msg, source, jobID string
+		attempt                      int
+	}
+	var got []event
+	for rows.Next() {
+		var ev event
+		require.NoError(t, rows.Scan(&ev.from, &ev.to, &ev.msg, &ev.source, &ev.jobID, &ev.attempt))
+		got = append(got, ev)
+	}
+	require.NoError(t, rows.Err())
+
+	api := storage.ApplicationName
+	assert.Equal(t, []event{
+		{"", "awaiting_upload", "", api, "", 0},
+		{"awaiting_upload", "queued", "", api, "", 0},
+		{"queued", "processing", "", "real-staging-worker", "job-1", 2},
+		{"processing", "error", "provider timeout", api, "", 0},
+	}, got)
+
+	var current string
+	err = db.Pool().QueryRow(ctx, `SELECT status::text FROM image_current_status WHERE image_id = $1`, imageID).Scan(&current)
+	require.NoError(t, err)
+	assert.Equal(t, "error", current)
+
+	// Events are immutable.
+	_, err = db.Pool().Exec(ctx, `UPDATE image_status_transitions SET to_status = 'ready' WHERE image_id = $1`, imageID)
+	assert.Error(t, err)
+	_, err = db.Pool().Exec(ctx, `DELETE FROM image_status_transitions WHERE image_id = $1`, imageID)
+	assert.Error(t, err)
+}
+
+func TestImageStatusTransitions_SyncsDriftedStatus(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+
+	truncateTables(t, db.Pool())
+	seedTables(t, db.Pool())
+
+	var imageID string
+	err := db.Pool().QueryRow(ctx,
+		`INSERT INTO images (project_id, original_url, status) VALUES ($1, 's3://bucket/a.jpg', 'queued') RETURNING id`,
+		fixtures.SeedProjectID.String(),
+	).Scan(&imageID)
+	require.NoError(t, err)
+	id := pgtype.UUID{Bytes: uuid.MustParse(imageID), Valid: true}
+
+	// A write with triggers disabled leaves the status ahead of its log.
+	tx, err := db.Pool().Begin(ctx)
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, `SET LOCAL session_replication_role = replica`)
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, `UPDATE images SET status = 'ready' WHERE id = $1`, imageID)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit(ctx))
+
+	q := queries.New(db)
+	drift, err := q.ListImageStatusDrift(ctx, []pgtype.UUID{id})
+	require.NoError(t, err)
+	require.Len(t, drift, 1)
+	assert.Equal(t, queries.ImageStatusReady, drift[0].Status)
+	assert.Equal(t, queries.ImageStatusQueued, drift[0].EventStatus)
+
+	n, err := q.SyncImageStatusFromEvents(ctx, queries.SyncImageStatusFromEventsParams{
+		EventStatus: drift[0].EventStatus, ID: id, Status: drift[0].Status,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	var status, source string
+	err = db.Pool().QueryRow(ctx, `SELECT status::text FROM images WHERE id = $1`, imageID).Scan(&status)
+	require.NoError(t, err)
+	assert.Equal(t, "queued", status)
+	err = db.Pool().QueryRow(ctx,
+		`SELECT source FROM image_status_transitions WHERE image_id = $1 ORDER BY id DESC LIMIT 1`, imageID,
+	).Scan(&source)
+	require.NoError(t, err)
+	assert.Equal(t, "real-staging-reconcile", source)
+
+	drift, err = q.ListImageStatusDrift(ctx, []pgtype.UUID{id})
+	require.NoError(t, err)
+	assert.Empty(t, drift)
+}
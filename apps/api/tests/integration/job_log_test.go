@@ -0,0 +1,57 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/job"
+	"github.com/real-staging-ai/api/tests/fixtures"
+)
+
+func TestJobLog_OnlyProjectMembers(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+
+	truncateTables(t, db.Pool())
+	seedTables(t, db.Pool())
+
+	var imageID, jobID, viewerID, strangerID string
+	err := db.Pool().QueryRow(ctx,
+		`INSERT INTO images (project_id, original_url) VALUES ($1, 's3://bucket/a.jpg') RETURNING id`,
+		fixtures.SeedProjectID.String(),
+	).Scan(&imageID)
+	require.NoError(t, err)
+	err = db.Pool().QueryRow(ctx,
+		`INSERT INTO job_logs (job_id, image_id, log) VALUES (gen_random_uuid(), $1, 'started') RETURNING job_id`,
+		imageID,
+	).Scan(&jobID)
+	require.NoError(t, err)
+	err = db.Pool().QueryRow(ctx, `INSERT INTO users (auth0_sub) VALUES ('auth0|viewer') RETURNING id`).Scan(&viewerID)
+	require.NoError(t, err)
+	err = db.Pool().QueryRow(ctx, `INSERT INTO users (auth0_sub) VALUES ('auth0|stranger') RETURNING id`).
+		Scan(&strangerID)
+	require.NoError(t, err)
+	_, err = db.Pool().Exec(ctx,
+		`INSERT INTO project_collaborators (project_id, user_id, role) VALUES ($1, $2, 'viewer')`,
+		fixtures.SeedProjectID.String(), viewerID,
+	)
+	require.NoError(t, err)
+
+	repo := job.NewDefaultRepository(db)
+	for _, userID := range []string{fixtures.SeedUserID.String(), viewerID} {
+		jobLog, err := repo.GetJobLog(ctx, jobID, userID)
+		require.NoError(t, err)
+		assert.Equal(t, "started", jobLog.Log)
+	}
+
+	_, err = repo.GetJobLog(ctx, jobID, strangerID)
+	assert.True(t, errors.Is(err, pgx.ErrNoRows), "got %v", err)
+}
@@ -13,6 +13,7 @@ import (
 	httpLib "github.com/real-staging-ai/api/internal/http"
 	"github.com/real-staging-ai/api/internal/image"
 	"github.com/real-staging-ai/api/internal/user"
+	"github.com/real-staging-ai/api/tests/fixtures"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -44,7 +45,7 @@ func TestGetProfile_Integration(t *testing.T) {
 				var profile user.ProfileResponse
 				err := json.Unmarshal(response, &profile)
 				require.NoError(t, err)
-				assert.Equal(t, "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", profile.ID)
+				assert.Equal(t, fixtures.SeedUserID.String(), profile.ID)
 				assert.Equal(t, "user", profile.Role)
 				assert.NotNil(t, profile.StripeCustomerID)
 				assert.Equal(t, "cus_test", *profile.StripeCustomerID)
@@ -59,7 +60,7 @@ func TestGetProfile_Integration(t *testing.T) {
 				err := json.Unmarshal(response, &profile)
 				require.NoError(t, err)
 				// Default test user should return the seeded user
-				assert.Equal(t, "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", profile.ID)
+				assert.Equal(t, fixtures.SeedUserID.String(), profile.ID)
 			},
 		},
 		{
@@ -142,7 +143,7 @@ func TestUpdateProfile_Integration(t *testing.T) {
 				var profile user.ProfileResponse
 				err := json.Unmarshal(response, &profile)
 				require.NoError(t, err)
-				assert.Equal(t, "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", profile.ID)
+				assert.Equal(t, fixtures.SeedUserID.String(), profile.ID)
 				assert.NotNil(t, profile.Email)
 				assert.Equal(t, "updated@example.com", *profile.Email)
 				assert.NotNil(t, profile.FullName)
@@ -0,0 +1,74 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/tests/fixtures"
+)
+
+func TestProjectActivity_RecordsImageLifecycle(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+
+	truncateTables(t, db.Pool())
+	seedTables(t, db.Pool())
+
+	projectID := fixtures.SeedProjectID.String() // from seed data
+
+	var imageID string
+	err := db.Pool().QueryRow(ctx,
+		`INSERT INTO images (project_id, original_url, room_type) VALUES ($1, 's3://bucket/a.jpg', 'living_room') RETURNING id`,
+		projectID,
+	).Scan(&imageID)
+	require.NoError(t, err)
+
+	_, err = db.Pool().Exec(ctx, `UPDATE images SET status = 'processing' WHERE id = $1`, imageID)
+	require.NoError(t, err)
+	_, err = db.Pool().Exec(ctx, `UPDATE images SET status = 'ready', staged_url = 's3://bucket/b.jpg' WHERE id = $1`, imageID)
+	require.NoError(t, err)
+	// Updates that leave the status unchanged are not recorded.
+	_, err = db.Pool().Exec(ctx, `UPDATE images SET staged_url = 's3://bucket/c.jpg' WHERE id = $1`, imageID)
+	require.NoError(t, err)
+	_, err = db.Pool().Exec(ctx, `DELETE FROM images WHERE id = $1`, imageID)
+	require.NoError(t, err)
+
+	repo := project.NewDefaultStorageSQLc(db)
+	events, err := repo.ListProjectActivity(ctx, projectID, 10, 0)
+	require.NoError(t, err)
+
+	var types []string
+	for _, ev := range events {
+		types = append(types, ev.Type)
+		require.NotNil(t, ev.ImageID)
+		assert.Equal(t, imageID, *ev.ImageID)
+	}
+	assert.Equal(t, []string{
+		project.ActivityImageDeleted,
+		project.ActivityImageStatusChanged,
+		project.ActivityImageStatusChanged,
+		project.ActivityImageCreated,
+	}, types)
+	assert.JSONEq(t, `{"from":"processing","to":"ready","error":null}`, string(events[1].Metadata))
+
+	page, err := repo.ListProjectActivity(ctx, projectID, 2, 2)
+	require.NoError(t, err)
+	assert.Len(t, page, 2)
+	assert.Equal(t, project.ActivityImageCreated, page[1].Type)
+
+	// Deleting the project removes its timeline without tripping the delete trigger.
+	_, err = db.Pool().Exec(ctx, `INSERT INTO images (project_id, original_url) VALUES ($1, 's3://bucket/d.jpg')`, projectID)
+	require.NoError(t, err)
+	_, err = db.Pool().Exec(ctx, `DELETE FROM projects WHERE id = $1`, projectID)
+	require.NoError(t, err)
+	events, err = repo.ListProjectActivity(ctx, projectID, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
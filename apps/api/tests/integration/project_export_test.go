@@ -0,0 +1,76 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/tests/fixtures"
+)
+
+func TestExportCredits_SpentOncePerProject(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+
+	truncateTables(t, db.Pool())
+	seedTables(t, db.Pool())
+
+	q := queries.New(db)
+	userID := pgtype.UUID{Bytes: fixtures.SeedUserID, Valid: true}
+	projectID := pgtype.UUID{Bytes: fixtures.SeedProjectID, Valid: true}
+
+	// A redelivered checkout webhook records the credit once.
+	for _, session := range []string{"cs_1", "cs_1", "cs_2"} {
+		_, err := q.CreateExportCredit(ctx, queries.CreateExportCreditParams{UserID: userID, CheckoutSessionID: session})
+		require.NoError(t, err)
+	}
+	available, err := q.CountAvailableExportCredits(ctx, userID)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, available)
+
+	_, err = q.GetProjectExportCredit(ctx, projectID)
+	assert.True(t, errors.Is(err, pgx.ErrNoRows), "got %v", err)
+
+	credit, err := q.ConsumeExportCredit(ctx, queries.ConsumeExportCreditParams{ProjectID: projectID, UserID: userID})
+	require.NoError(t, err)
+	assert.Equal(t, projectID, credit.ProjectID)
+	assert.True(t, credit.ConsumedAt.Valid)
+
+	unlocked, err := q.GetProjectExportCredit(ctx, projectID)
+	require.NoError(t, err)
+	assert.Equal(t, credit.ID, unlocked.ID)
+
+	// A second credit cannot be spent on an unlocked project.
+	_, err = q.ConsumeExportCredit(ctx, queries.ConsumeExportCreditParams{ProjectID: projectID, UserID: userID})
+	var pgErr *pgconn.PgError
+	require.True(t, errors.As(err, &pgErr), "got %v", err)
+	assert.Equal(t, "idx_export_credits_project_id", pgErr.ConstraintName)
+
+	available, err = q.CountAvailableExportCredits(ctx, userID)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, available)
+
+	export, err := q.CreateProjectExport(ctx, queries.CreateProjectExportParams{
+		ProjectID: projectID, UserID: userID, CreditID: credit.ID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "queued", export.Status)
+	assert.False(t, export.Watermarked)
+
+	require.NoError(t, q.FailProjectExport(ctx, queries.FailProjectExportParams{
+		ID: export.ID, Error: pgtype.Text{String: "failed to queue project export", Valid: true},
+	}))
+	failed, err := q.GetProjectExport(ctx, queries.GetProjectExportParams{ID: export.ID, ProjectID: projectID})
+	require.NoError(t, err)
+	assert.Equal(t, "error", failed.Status)
+}
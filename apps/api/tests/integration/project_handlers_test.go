@@ -17,6 +17,7 @@ import (
 	httpLib "github.com/real-staging-ai/api/internal/http"
 	"github.com/real-staging-ai/api/internal/image"
 	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/tests/fixtures"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -218,7 +219,7 @@ func TestGetProjects_Handlers(t *testing.T) {
 				assert.GreaterOrEqual(t, len(listResp.Projects), 1)
 				found := false
 				for _, p := range listResp.Projects {
-					if p.ID == "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12" && p.Name == "Test Project 1" {
+					if p.ID == fixtures.SeedProjectID.String() && p.Name == "Test Project 1" {
 						found = true
 						break
 					}
@@ -305,15 +306,15 @@ func TestGetProjectByID_Handlers(t *testing.T) {
 	}{
 		{
 			name:           "success: get existing project",
-			projectID:      "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
+			projectID:      fixtures.SeedProjectID.String(),
 			expectedStatus: http.StatusOK,
 			validate: func(t *testing.T, response []byte) {
 				var project ProjectResponse
 				err := json.Unmarshal(response, &project)
 				require.NoError(t, err)
-				assert.Equal(t, "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12", project.ID)
+				assert.Equal(t, fixtures.SeedProjectID.String(), project.ID)
 				assert.Equal(t, "Test Project 1", project.Name)
-				assert.Equal(t, "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", project.UserID)
+				assert.Equal(t, fixtures.SeedUserID.String(), project.UserID)
 			},
 		},
 		{
@@ -385,7 +386,7 @@ func TestUpdateProject_Handlers(t *testing.T) {
 	}{
 		{
 			name:      "success: update existing project",
-			projectID: "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
+			projectID: fixtures.SeedProjectID.String(),
 			requestBody: map[string]any{
 				"name": "Updated Project Name",
 			},
@@ -398,7 +399,7 @@ func TestUpdateProject_Handlers(t *testing.T) {
 				var project ProjectResponse
 				err := json.Unmarshal(response, &project)
 				require.NoError(t, err)
-				assert.Equal(t, "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12", project.ID)
+				assert.Equal(t, fixtures.SeedProjectID.String(), project.ID)
 				assert.Equal(t, "Updated Project Name", project.Name)
 			},
 		},
@@ -430,7 +431,7 @@ func TestUpdateProject_Handlers(t *testing.T) {
 		},
 		{
 			name:      "fail: empty name",
-			projectID: "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
+			projectID: fixtures.SeedProjectID.String(),
 			requestBody: map[string]any{
 				"name": "",
 			},
@@ -443,7 +444,7 @@ func TestUpdateProject_Handlers(t *testing.T) {
 		},
 		{
 			name:      "fail: name too long",
-			projectID: "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
+			projectID: fixtures.SeedProjectID.String(),
 			requestBody: map[string]any{
 				"name": strings.Repeat("A", 101),
 			},
@@ -513,7 +514,7 @@ func TestDeleteProject_Handlers(t *testing.T) {
 	}{
 		{
 			name:      "success: delete existing project",
-			projectID: "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
+			projectID: fixtures.SeedProjectID.String(),
 			setupData: func(t *testing.T, db storage.Database) {
 				TruncateAllTables(ctx, db.Pool())
 				SeedDatabase(ctx, db.Pool())
@@ -524,7 +525,7 @@ func TestDeleteProject_Handlers(t *testing.T) {
 				var count int
 				err := db.Pool().QueryRow(context.Background(),
 					"SELECT COUNT(*) FROM projects WHERE id = $1",
-					"b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12").Scan(&count)
+					fixtures.SeedProjectID.String()).Scan(&count)
 				require.NoError(t, err)
 				assert.Equal(t, 0, count)
 			},
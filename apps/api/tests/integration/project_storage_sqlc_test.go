@@ -9,6 +9,7 @@ import (
 
 	"github.com/real-staging-ai/api/internal/project"
 	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/tests/fixtures"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -22,7 +23,7 @@ func TestProjectStorageSQLc_CreateProject(t *testing.T) {
 	seedTables(t, db.Pool())
 
 	storageInstance := project.NewDefaultStorageSQLc(db)
-	userID := "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11" // from seed data
+	userID := fixtures.SeedUserID.String() // from seed data
 
 	testCases := []struct {
 		name         string
@@ -82,9 +83,9 @@ func TestProjectStorageSQLc_GetProjects(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Len(t, projects, 1)
-	assert.Equal(t, "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12", projects[0].ID)
+	assert.Equal(t, fixtures.SeedProjectID.String(), projects[0].ID)
 	assert.Equal(t, "Test Project 1", projects[0].Name)
-	assert.Equal(t, "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", projects[0].UserID)
+	assert.Equal(t, fixtures.SeedUserID.String(), projects[0].UserID)
 }
 
 func TestProjectStorageSQLc_GetProjectsByUserID(t *testing.T) {
@@ -96,7 +97,7 @@ func TestProjectStorageSQLc_GetProjectsByUserID(t *testing.T) {
 	seedTables(t, db.Pool())
 
 	storageInstance := project.NewDefaultStorageSQLc(db)
-	userID := "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+	userID := fixtures.SeedUserID.String()
 
 	testCases := []struct {
 		name            string
@@ -164,7 +165,7 @@ func TestProjectStorageSQLc_GetProjectByID(t *testing.T) {
 	}{
 		{
 			name:         "success: get existing project",
-			projectID:    "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
+			projectID:    fixtures.SeedProjectID.String(),
 			expectError:  false,
 			expectedName: "Test Project 1",
 		},
@@ -216,7 +217,7 @@ func TestProjectStorageSQLc_UpdateProject(t *testing.T) {
 	}{
 		{
 			name:         "success: update existing project",
-			projectID:    "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
+			projectID:    fixtures.SeedProjectID.String(),
 			newName:      "Updated Project Name",
 			expectError:  false,
 			expectedName: "Updated Project Name",
@@ -260,7 +261,7 @@ func TestProjectStorageSQLc_UpdateProjectByUserID(t *testing.T) {
 	seedTables(t, db.Pool())
 
 	storageInstance := project.NewDefaultStorageSQLc(db)
-	userID := "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+	userID := fixtures.SeedUserID.String()
 
 	testCases := []struct {
 		name         string
@@ -272,7 +273,7 @@ func TestProjectStorageSQLc_UpdateProjectByUserID(t *testing.T) {
 	}{
 		{
 			name:         "success: update project by correct user",
-			projectID:    "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
+			projectID:    fixtures.SeedProjectID.String(),
 			userID:       userID,
 			newName:      "Updated by User",
 			expectError:  false,
@@ -280,7 +281,7 @@ func TestProjectStorageSQLc_UpdateProjectByUserID(t *testing.T) {
 		},
 		{
 			name:        "fail: update project by wrong user",
-			projectID:   "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
+			projectID:   fixtures.SeedProjectID.String(),
 			userID:      "550e8400-e29b-41d4-a716-446655440000",
 			newName:     "Updated by Wrong User",
 			expectError: true,
@@ -294,7 +295,7 @@ func TestProjectStorageSQLc_UpdateProjectByUserID(t *testing.T) {
 		},
 		{
 			name:        "fail: invalid user ID format",
-			projectID:   "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
+			projectID:   fixtures.SeedProjectID.String(),
 			userID:      "invalid-uuid",
 			newName:     "Updated Name",
 			expectError: true,
@@ -330,7 +331,7 @@ func TestProjectStorageSQLc_DeleteProject(t *testing.T) {
 	}{
 		{
 			name:        "success: delete existing project",
-			projectID:   "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
+			projectID:   fixtures.SeedProjectID.String(),
 			expectError: false,
 		},
 		{
@@ -363,7 +364,7 @@ func TestProjectStorageSQLc_DeleteProject(t *testing.T) {
 			require.NoError(t, err)
 
 			// Verify project is deleted if it was an existing project
-			if tc.projectID == "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12" {
+			if tc.projectID == fixtures.SeedProjectID.String() {
 				_, err := storageInstance.GetProjectByID(ctx, tc.projectID)
 				assert.Error(t, err) // Should not be found
 			}
@@ -376,7 +377,7 @@ func TestProjectStorageSQLc_DeleteProjectByUserID(t *testing.T) {
 	db := SetupTestDatabase(t)
 	defer db.Close()
 
-	userID := "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+	userID := fixtures.SeedUserID.String()
 
 	testCases := []struct {
 		name        string
@@ -386,13 +387,13 @@ func TestProjectStorageSQLc_DeleteProjectByUserID(t *testing.T) {
 	}{
 		{
 			name:        "success: delete project by correct user",
-			projectID:   "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
+			projectID:   fixtures.SeedProjectID.String(),
 			userID:      userID,
 			expectError: false,
 		},
 		{
 			name:        "success: delete project by wrong user (no error but no deletion)",
-			projectID:   "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
+			projectID:   fixtures.SeedProjectID.String(),
 			userID:      "550e8400-e29b-41d4-a716-446655440000",
 			expectError: false,
 		},
@@ -404,7 +405,7 @@ func TestProjectStorageSQLc_DeleteProjectByUserID(t *testing.T) {
 		},
 		{
 			name:        "fail: invalid user ID format",
-			projectID:   "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
+			projectID:   fixtures.SeedProjectID.String(),
 			userID:      "invalid-uuid",
 			expectError: true,
 		},
@@ -450,7 +451,7 @@ func TestProjectStorageSQLc_CountProjectsByUserID(t *testing.T) {
 	seedTables(t, db.Pool())
 
 	storageInstance := project.NewDefaultStorageSQLc(db)
-	userID := "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+	userID := fixtures.SeedUserID.String()
 
 	testCases := []struct {
 		name          string
@@ -501,7 +502,7 @@ func TestProjectStorageSQLc_Integration(t *testing.T) {
 	seedTables(t, db.Pool())
 
 	storageInstance := project.NewDefaultStorageSQLc(db)
-	userID := "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+	userID := fixtures.SeedUserID.String()
 
 	// Test complete workflow
 	// 1. Create a project
@@ -546,7 +547,7 @@ func truncateTables(t *testing.T, pool storage.PgxPool) {
 	t.Helper()
 
 	query := `
-		TRUNCATE TABLE processed_events, invoices, subscriptions, images, jobs, projects, users, plans RESTART IDENTITY CASCADE
+		TRUNCATE TABLE processed_events, pending_billing_events, invoices, subscriptions, images, image_status_transitions, jobs, job_logs, image_original_purges, legal_holds, projects, users, plans RESTART IDENTITY CASCADE
 	`
 	_, err := pool.Exec(context.Background(), query)
 	require.NoError(t, err)
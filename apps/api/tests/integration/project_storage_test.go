@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/tests/fixtures"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -25,7 +26,7 @@ func TestProjectStorage_CreateProject(t *testing.T) {
 	newProject := &project.Project{
 		Name: "Test Project",
 	}
-	userID := "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11" // Use seeded user ID
+	userID := fixtures.SeedUserID.String() // Use seeded user ID
 	createdProject, err := projectStorage.CreateProject(ctx, newProject, userID)
 
 	// Assertions
@@ -0,0 +1,199 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/tests/fixtures"
+)
+
+// planSeedSQL adds enough rows to the seed data for the planner statistics to be meaningful.
+const planSeedSQL = `
+	INSERT INTO images (project_id, original_url, status, created_at)
+	SELECT 'b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12',
+		'https://example.com/plan/' || g || '.jpg',
+		(ARRAY['queued', 'processing', 'ready', 'error'])[1 + g % 4]::image_status,
+		now() - make_interval(mins => g)
+	FROM generate_series(1, 2000) g;
+
+	INSERT INTO jobs (image_id, type, payload_json, status, created_at)
+	SELECT id, 'stage:run', '{}', CASE status WHEN 'ready' THEN 'completed' ELSE 'queued' END, created_at
+	FROM images;
+
+	INSERT INTO subscriptions (user_id, stripe_subscription_id, status, price_id)
+	SELECT 'a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11', 'sub_plan_' || g,
+		(ARRAY['active', 'canceled', 'past_due'])[1 + g % 3], 'price_test'
+	FROM generate_series(1, 50) g;
+
+	ANALYZE images;
+	ANALYZE jobs;
+	ANALYZE subscriptions;
+`
+
+type planNode struct {
+	NodeType     string     `json:"Node Type"`
+	RelationName string     `json:"Relation Name"`
+	Plans        []planNode `json:"Plans"`
+}
+
+// seqScans returns the relations read with a sequential scan anywhere in the plan.
+func seqScans(n planNode) []string {
+	var rels []string
+	if n.NodeType == "Seq Scan" {
+		rels = append(rels, n.RelationName)
+	}
+	for _, child := range n.Plans {
+		rels = append(rels, seqScans(child)...)
+	}
+	return rels
+}
+
+// relations returns every relation read anywhere in the plan.
+func relations(n planNode) []string {
+	var rels []string
+	if n.RelationName != "" {
+		rels = append(rels, n.RelationName)
+	}
+	for _, child := range n.Plans {
+		rels = append(rels, relations(child)...)
+	}
+	return rels
+}
+
+// explain returns the JSON plan of query with sequential scans disabled.
+func explain(ctx context.Context, t *testing.T, db storage.Database, query string, args ...any) planNode {
+	t.Helper()
+
+	var raw []byte
+	err := db.WithTx(ctx, func(tx storage.Database) error {
+		if _, err := tx.Exec(ctx, "SET LOCAL enable_seqscan = off"); err != nil {
+			return err
+		}
+		return tx.QueryRow(ctx, "EXPLAIN (FORMAT JSON) "+query, args...).Scan(&raw)
+	})
+	require.NoError(t, err)
+
+	var plans []struct {
+		Plan planNode `json:"Plan"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &plans))
+	require.NotEmpty(t, plans)
+	return plans[0].Plan
+}
+
+// TestQueryPlans runs EXPLAIN on hot sqlc queries and fails when any of them sequentially scans a table.
+// Sequential scans are disabled for the session so the planner only picks one when no usable index
+// exists; without this, a small seeded table would be scanned regardless of its indexes.
+func TestQueryPlans(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+
+	require.NoError(t, ResetDatabase(ctx, db.Pool()))
+	_, err := db.Pool().Exec(ctx, planSeedSQL)
+	require.NoError(t, err)
+
+	userID := pgtype.UUID{Bytes: fixtures.SeedUserID, Valid: true}
+	projectID := pgtype.UUID{Bytes: fixtures.SeedProjectID, Valid: true}
+	imageID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	since := pgtype.Timestamptz{Time: time.Now().Add(-24 * time.Hour), Valid: true}
+
+	testCases := []struct {
+		name  string
+		query string
+		args  []any
+	}{
+		{name: "GetImageByID", query: queries.GetImageByID, args: []any{imageID}},
+		{name: "GetImagesByProjectID", query: queries.GetImagesByProjectID, args: []any{projectID}},
+		{
+			name:  "GetImageStatusesByIDs",
+			query: queries.GetImageStatusesByIDs,
+			args:  []any{projectID, []pgtype.UUID{imageID}},
+		},
+		{name: "GetProjectCostSummary", query: queries.GetProjectCostSummary, args: []any{projectID}},
+		{name: "GetProjectsByUserID", query: queries.GetProjectsByUserID, args: []any{userID}},
+		{name: "CountProjectsByUserID", query: queries.CountProjectsByUserID, args: []any{userID}},
+		{name: "ListProjectActivity", query: queries.ListProjectActivity, args: []any{projectID, int32(50), int32(0)}},
+		{name: "GetProjectSummary", query: queries.GetProjectSummary, args: []any{projectID}},
+		{name: "ListProjectThumbnails", query: queries.ListProjectThumbnails, args: []any{projectID, int32(4)}},
+		{
+			name:  "ListProjectSummariesByUser",
+			query: queries.ListProjectSummariesByUser,
+			args:  []any{int32(4), []pgtype.UUID{projectID}, userID},
+		},
+		{name: "GetExpediteAccess", query: queries.GetExpediteAccess, args: []any{since, imageID}},
+		{name: "GetJobsByImageID", query: queries.GetJobsByImageID, args: []any{imageID}},
+		{name: "CountJobsByStatus", query: queries.CountJobsByStatus},
+		{name: "GetJobOutcomesSince", query: queries.GetJobOutcomesSince, args: []any{since}},
+		{name: "CountActiveUsersSince", query: queries.CountActiveUsersSince, args: []any{since}},
+		{name: "SumPaidInvoicesSince", query: queries.SumPaidInvoicesSince, args: []any{since}},
+		{
+			name:  "ListImagesForReconcile",
+			query: queries.ListImagesForReconcile,
+			args:  []any{projectID, "", pgtype.UUID{}, int32(100), pgtype.Timestamptz{}, pgtype.Timestamptz{}},
+		},
+		{name: "GetPendingJobs", query: queries.GetPendingJobs, args: []any{int32(10)}},
+		{
+			name:  "ListJobs by status",
+			query: queries.ListJobs,
+			args:  []any{pgtype.Text{String: "queued", Valid: true}, int32(50), int32(0)},
+		},
+		{
+			name:  "ListSubscriptionsByUserID",
+			query: queries.ListSubscriptionsByUserID,
+			args:  []any{userID, int32(10), int32(0)},
+		},
+		{
+			name:  "ListInvoicesByUserID",
+			query: queries.ListInvoicesByUserID,
+			args:  []any{userID, int32(10), int32(0)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			plan := explain(ctx, t, db, tc.query, tc.args...)
+			if rels := seqScans(plan); len(rels) > 0 {
+				t.Fatalf("%s sequentially scans %s; add an index or adjust the query", tc.name, strings.Join(rels, ", "))
+			}
+		})
+	}
+}
+
+// TestPartitionPruning checks that a created_at window limits reconcile to the matching monthly partition.
+func TestPartitionPruning(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+
+	require.NoError(t, ResetDatabase(ctx, db.Pool()))
+
+	now := time.Now().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	partition := fmt.Sprintf("images_p%04d_%02d", monthStart.Year(), int(monthStart.Month()))
+
+	plan := explain(ctx, t, db, queries.ListImagesForReconcile,
+		pgtype.UUID{}, "", pgtype.UUID{}, int32(100),
+		pgtype.Timestamptz{Time: monthStart, Valid: true},
+		pgtype.Timestamptz{Time: monthStart.AddDate(0, 1, 0), Valid: true},
+	)
+
+	rels := relations(plan)
+	require.NotEmpty(t, rels)
+	for _, rel := range rels {
+		assert.Equal(t, partition, rel, "reconcile window should only read %s, read %v", partition, rels)
+	}
+}
@@ -0,0 +1,82 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/chaos"
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/reconcile"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/tests/fixtures"
+)
+
+// TestReconcileImages_StorageFaults checks that reconcile treats an unreachable object
+// store as unknown rather than as missing objects, and recovers once storage does.
+func TestReconcileImages_StorageFaults(t *testing.T) {
+	ctx := context.Background()
+
+	db := SetupTestDatabase(t)
+	defer db.Close()
+
+	TruncateAllTables(ctx, db.Pool())
+	SeedDatabase(ctx, db.Pool())
+
+	cfg, err := config.Load()
+	require.NoError(t, err, "failed to load config")
+	faults := chaos.New(map[chaos.Point]chaos.Fault{chaos.PointS3: {ErrorRate: 1}})
+	s3Service, err := storage.NewDefaultS3ServiceWithFaults(ctx, &cfg.S3, faults)
+	require.NoError(t, err)
+
+	svc := reconcile.NewDefaultService(db, storage.NewPlatformBuckets(s3Service))
+
+	user, err := fixtures.NewUser().
+		WithProject(fixtures.NewProject("Reconcile Chaos").WithImage(fixtures.NewImage())).
+		Create(ctx, fixtures.Pgx(db.Pool()))
+	require.NoError(t, err)
+	userID := user.ID
+	projectID := user.Projects[0].ID
+	imageID := user.Projects[0].Images[0].ID
+
+	project := projectID.String()
+	opts := reconcile.ReconcileOptions{ProjectID: &project, Concurrency: 2}
+
+	t.Run("success: storage outage leaves images untouched", func(t *testing.T) {
+		result, err := svc.ReconcileImages(ctx, opts)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Checked)
+		assert.Equal(t, 1, result.CheckErrors)
+		assert.Zero(t, result.MissingOrig)
+		assert.Zero(t, result.Updated)
+		assert.Positive(t, faults.Fired(chaos.PointS3))
+
+		var status string
+		err = db.Pool().QueryRow(ctx, `SELECT status FROM images WHERE id = $1`, imageID).Scan(&status)
+		require.NoError(t, err)
+		assert.Equal(t, "queued", status)
+	})
+
+	t.Run("success: missing original detected once storage recovers", func(t *testing.T) {
+		faults.Clear(chaos.PointS3)
+
+		result, err := svc.ReconcileImages(ctx, opts)
+		require.NoError(t, err)
+		assert.Zero(t, result.CheckErrors)
+		assert.Equal(t, 1, result.MissingOrig)
+		assert.Equal(t, 1, result.Updated)
+
+		var status string
+		err = db.Pool().QueryRow(ctx, `SELECT status FROM images WHERE id = $1`, imageID).Scan(&status)
+		require.NoError(t, err)
+		assert.Equal(t, "error", status)
+	})
+
+	_, _ = db.Pool().Exec(ctx, `DELETE FROM images WHERE id = $1`, imageID)
+	_, _ = db.Pool().Exec(ctx, `DELETE FROM projects WHERE id = $1`, projectID)
+	_, _ = db.Pool().Exec(ctx, `DELETE FROM users WHERE id = $1`, userID)
+}
@@ -4,14 +4,14 @@ package integration
 
 import (
 	"context"
-	"fmt"
 	"testing"
 
-	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/real-staging-ai/api/internal/reconcile"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/tests/fixtures"
 )
 
 func TestReconcileImages_Integration(t *testing.T) {
@@ -28,26 +28,17 @@ func TestReconcileImages_Integration(t *testing.T) {
 	s3Service := SetupTestS3Service(t, ctx)
 
 	// Create service
-	svc := reconcile.NewDefaultService(db, s3Service)
+	svc := reconcile.NewDefaultService(db, storage.NewPlatformBuckets(s3Service))
 
 	t.Run("success: detects missing original file", func(t *testing.T) {
-		// Create test user and project
-		userID := uuid.New()
-		projectID := uuid.New()
-		imageID := uuid.New()
-
-		_, _ = db.Pool().Exec(ctx, `INSERT INTO users (id, auth0_sub) VALUES ($1, $2)`,
-			userID, "auth0|test-reconcile-1")
-
-		_, _ = db.Pool().Exec(ctx, `INSERT INTO projects (id, user_id, name) VALUES ($1, $2, $3)`,
-			projectID, userID, "Test Project Reconcile")
-
-		// Insert image with original_url that doesn't exist in S3
-		originalURL := fmt.Sprintf("http://localhost:4566/real-staging/uploads/%s/original.jpg", imageID)
-		_, _ = db.Pool().Exec(ctx, `
-			INSERT INTO images (id, project_id, original_url, status, created_at, updated_at)
-			VALUES ($1, $2, $3, 'queued', NOW(), NOW())`,
-			imageID, projectID, originalURL)
+		// Create a user and project with an image whose original doesn't exist in S3
+		user, err := fixtures.NewUser().
+			WithProject(fixtures.NewProject("Test Project Reconcile").WithImage(fixtures.NewImage())).
+			Create(ctx, fixtures.Pgx(db.Pool()))
+		require.NoError(t, err)
+		userID := user.ID
+		projectID := user.Projects[0].ID
+		imageID := user.Projects[0].Images[0].ID
 
 		// Run reconciliation
 		result, err := svc.ReconcileImages(ctx, reconcile.ReconcileOptions{
@@ -74,23 +65,14 @@ func TestReconcileImages_Integration(t *testing.T) {
 	})
 
 	t.Run("success: dry run mode does not update database", func(t *testing.T) {
-		// Create test user and project
-		userID := uuid.New()
-		projectID := uuid.New()
-		imageID := uuid.New()
-
-		_, _ = db.Pool().Exec(ctx, `INSERT INTO users (id, auth0_sub) VALUES ($1, $2)`,
-			userID, "auth0|test-reconcile-2")
-
-		_, _ = db.Pool().Exec(ctx, `INSERT INTO projects (id, user_id, name) VALUES ($1, $2, $3)`,
-			projectID, userID, "Test Project Reconcile 2")
-
-		// Insert image with original_url that doesn't exist
-		originalURL := fmt.Sprintf("http://localhost:4566/real-staging/uploads/%s/original.jpg", imageID)
-		_, _ = db.Pool().Exec(ctx, `
-			INSERT INTO images (id, project_id, original_url, status, created_at, updated_at)
-			VALUES ($1, $2, $3, 'queued', NOW(), NOW())`,
-			imageID, projectID, originalURL)
+		// Create a user and project with an image whose original doesn't exist in S3
+		user, err := fixtures.NewUser().
+			WithProject(fixtures.NewProject("Test Project Reconcile 2").WithImage(fixtures.NewImage())).
+			Create(ctx, fixtures.Pgx(db.Pool()))
+		require.NoError(t, err)
+		userID := user.ID
+		projectID := user.Projects[0].ID
+		imageID := user.Projects[0].Images[0].ID
 
 		// Run reconciliation in dry-run mode
 		result, err := svc.ReconcileImages(ctx, reconcile.ReconcileOptions{
@@ -119,27 +101,15 @@ func TestReconcileImages_Integration(t *testing.T) {
 	})
 
 	t.Run("success: filters by project_id", func(t *testing.T) {
-		// Create test users and projects
-		userID := uuid.New()
-		project1 := uuid.New()
-		project2 := uuid.New()
-		image1 := uuid.New()
-		image2 := uuid.New()
-
-		_, _ = db.Pool().Exec(ctx, `INSERT INTO users (id, auth0_sub) VALUES ($1, $2)`,
-			userID, "auth0|test-reconcile-3")
-
-		_, _ = db.Pool().Exec(ctx, `INSERT INTO projects (id, user_id, name) VALUES ($1, $2, $3), ($4, $2, $5)`,
-			project1, userID, "Project 1", project2, "Project 2")
-
-		// Insert images for both projects
-		originalURL1 := fmt.Sprintf("http://localhost:4566/real-staging/uploads/%s/original.jpg", image1)
-		originalURL2 := fmt.Sprintf("http://localhost:4566/real-staging/uploads/%s/original.jpg", image2)
-
-		_, _ = db.Pool().Exec(ctx, `
-			INSERT INTO images (id, project_id, original_url, status, created_at, updated_at)
-			VALUES ($1, $2, $3, 'queued', NOW(), NOW()), ($4, $5, $6, 'queued', NOW(), NOW())`,
-			image1, project1, originalURL1, image2, project2, originalURL2)
+		// Create a user with two projects, each with one image
+		user, err := fixtures.NewUser().
+			WithProject(fixtures.NewProject("Project 1").WithImage(fixtures.NewImage())).
+			WithProject(fixtures.NewProject("Project 2").WithImage(fixtures.NewImage())).
+			Create(ctx, fixtures.Pgx(db.Pool()))
+		require.NoError(t, err)
+		userID := user.ID
+		project1, project2 := user.Projects[0].ID, user.Projects[1].ID
+		image1, image2 := user.Projects[0].Images[0].ID, user.Projects[1].Images[0].ID
 
 		// Run reconciliation filtered by project1
 		projectIDStr := project1.String()
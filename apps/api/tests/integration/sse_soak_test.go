@@ -0,0 +1,218 @@
+//go:build integration
+
+package integration
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+	httpLib "github.com/real-staging-ai/api/internal/http"
+	"github.com/real-staging-ai/api/internal/sse"
+)
+
+// idleStreamer emits a connected event and then heartbeats until the client leaves,
+// which is all an idle SSE subscriber ever sees.
+type idleStreamer struct {
+	heartbeat time.Duration
+}
+
+func (s idleStreamer) StreamImage(ctx context.Context, w io.Writer, imageID, lastEventID string) error {
+	write := func(event string) error {
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: {}\n\n", event); err != nil {
+			return err
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+		return nil
+	}
+	if err := write(sse.EventConnected); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(s.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := write(sse.EventHeartbeat); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s idleStreamer) StreamProject(ctx context.Context, w io.Writer, projectID, lastEventID string) error {
+	return s.StreamImage(ctx, w, projectID, lastEventID)
+}
+
+// readEvent returns the name of the next SSE event on r.
+func readEvent(r *bufio.Reader) (string, error) {
+	var name string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		line = strings.TrimRight(line, "\n")
+		if line == "" && name != "" {
+			return name, nil
+		}
+		if v, ok := strings.CutPrefix(line, "event: "); ok {
+			name = v
+		}
+	}
+}
+
+// raiseFileLimit lifts the soft open-file limit to the hard limit and reports
+// whether at least need descriptors are available.
+func raiseFileLimit(need uint64) bool {
+	var lim syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &lim); err != nil {
+		return false
+	}
+	if lim.Cur < lim.Max {
+		lim.Cur = lim.Max
+		_ = syscall.Setrlimit(syscall.RLIMIT_NOFILE, &lim)
+		_ = syscall.Getrlimit(syscall.RLIMIT_NOFILE, &lim)
+	}
+	return lim.Cur >= need
+}
+
+func envInt(key string, def int) int {
+	if n, err := strconv.Atoi(os.Getenv(key)); err == nil && n > 0 {
+		return n
+	}
+	return def
+}
+
+// TestSSESoak_IdleConnections holds SSE_SOAK_CONNECTIONS (default 5000) idle streams
+// open for several multiples of the server's read/write timeouts and checks that every
+// one of them is still receiving heartbeats afterwards.
+func TestSSESoak_IdleConnections(t *testing.T) {
+	if os.Getenv("RUN_SSE_SOAK") != "1" {
+		t.Skip("set RUN_SSE_SOAK=1 to run the SSE soak test")
+	}
+	conns := envInt("SSE_SOAK_CONNECTIONS", 5000)
+	// Client and server each hold one descriptor per connection.
+	if !raiseFileLimit(uint64(2*conns + 512)) {
+		t.Skipf("open-file limit too low for %d connections", conns)
+	}
+
+	const (
+		heartbeat = 500 * time.Millisecond
+		timeout   = time.Second
+		hold      = 5 * time.Second
+	)
+
+	e := echo.New()
+	e.GET("/api/v1/events", sse.NewDefaultHandler(idleStreamer{heartbeat: heartbeat}).Events)
+	srv := httpLib.NewHTTPServer(config.HTTP{
+		ReadHeaderTimeout: timeout,
+		ReadTimeout:       timeout,
+		WriteTimeout:      timeout,
+		IdleTimeout:       timeout,
+	}, e)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = srv.Serve(ln) }()
+	defer func() { _ = srv.Close() }()
+
+	client := &http.Client{Transport: &http.Transport{MaxIdleConnsPerHost: -1}}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	type stream struct {
+		body   io.Closer
+		reader *bufio.Reader
+	}
+	streams := make([]stream, conns)
+	errs := make(chan error, conns)
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, 200) // bound the dial rate so the accept backlog keeps up
+	for i := 0; i < conns; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			url := fmt.Sprintf("http://%s/api/v1/events?image_id=soak-%d", ln.Addr(), i)
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+			if err != nil {
+				errs <- err
+				return
+			}
+			res, err := client.Do(req)
+			if err != nil {
+				errs <- fmt.Errorf("connection %d: %w", i, err)
+				return
+			}
+			r := bufio.NewReader(res.Body)
+			if ev, err := readEvent(r); err != nil || ev != sse.EventConnected {
+				errs <- fmt.Errorf("connection %d: first event %q: %v", i, ev, err)
+				return
+			}
+			streams[i] = stream{body: res.Body, reader: r}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	t.Logf("opened %d SSE connections", conns)
+
+	// Drain heartbeats while idling so client buffers never back up.
+	drainCtx, stopDrain := context.WithTimeout(ctx, hold)
+	defer stopDrain()
+	var alive sync.WaitGroup
+	failures := make(chan error, conns)
+	for i, s := range streams {
+		alive.Add(1)
+		go func(i int, s stream) {
+			defer alive.Done()
+			defer func() { _ = s.body.Close() }()
+			for {
+				ev, err := readEvent(s.reader)
+				if err != nil {
+					failures <- fmt.Errorf("connection %d dropped: %w", i, err)
+					return
+				}
+				if drainCtx.Err() != nil {
+					if ev != sse.EventHeartbeat {
+						failures <- fmt.Errorf("connection %d: unexpected event %q", i, ev)
+					}
+					return
+				}
+			}
+		}(i, s)
+	}
+	alive.Wait()
+	close(failures)
+
+	var dropped int
+	for err := range failures {
+		dropped++
+		if dropped <= 5 {
+			t.Log(err)
+		}
+	}
+	assert.Zero(t, dropped, "%d of %d connections failed after %s idle", dropped, conns, hold)
+}
@@ -1,3 +1,5 @@
+-- Mirrors fixtures.Seed in apps/api/tests/fixtures; keep the two in sync.
+
 -- Seed data for the users table
 INSERT INTO users (id, auth0_sub, stripe_customer_id, role) VALUES
 ('a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11', 'auth0|testuser', 'cus_test', 'user');
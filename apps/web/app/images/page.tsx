@@ -37,6 +37,7 @@ type ImageRecord = {
   project_id: string;
   original_url: string;
   staged_url?: string | null;
+  preview_url?: string | null;
   status: string;
   error?: string | null;
   room_type?: string | null;
@@ -59,7 +60,7 @@ export default function ImagesPage() {
   const [loadingProjects, setLoadingProjects] = useState(false);
   const [loadingImages, setLoadingImages] = useState(false);
   const [viewMode, setViewMode] = useState<'grid' | 'list'>('grid');
-  const [imageUrls, setImageUrls] = useState<Record<string, { original?: string; staged?: string }>>({});
+  const [imageUrls, setImageUrls] = useState<Record<string, { original?: string; staged?: string; preview?: string }>>({});
   const [hoveredImageId, setHoveredImageId] = useState<string | null>(null);
   const [downloadType, setDownloadType] = useState<'original' | 'staged'>('staged');
   const [pollingInterval, setPollingInterval] = useState<NodeJS.Timeout | null>(null);
@@ -90,7 +91,7 @@ export default function ImagesPage() {
   }, []);
 
   // Fetch presigned URL for viewing
-  async function getPresignedUrl(imageId: string, kind: 'original' | 'staged'): Promise<string | null> {
+  async function getPresignedUrl(imageId: string, kind: 'original' | 'staged' | 'preview'): Promise<string | null> {
     try {
       const params = new URLSearchParams({ kind });
       const res = await apiFetch<{ url: string }>(`/v1/images/${imageId}/presign?${params.toString()}`);
@@ -116,7 +117,16 @@ export default function ImagesPage() {
 
   // Prefetch image URLs for display
   const prefetchImageUrls = useCallback(async (imageList: ImageRecord[]) => {
-    const urlMap: Record<string, { original?: string; staged?: string }> = {};
+    const urlMap: Record<string, { original?: string; staged?: string; preview?: string }> = {};
+
+    // Processing images only have a preview, and only once the worker has published one
+    const previewsToFetch = imageList.filter(img => img.status === 'processing' && img.preview_url);
+    await Promise.all(
+      previewsToFetch.map(async (image) => {
+        const previewUrl = await getPresignedUrl(image.id, 'preview');
+        urlMap[image.id] = { preview: previewUrl || undefined };
+      })
+    );
     
     // Only fetch URLs for images that have been uploaded (not still processing)
     const imagesToFetch = imageList.filter(img => 
@@ -794,7 +804,21 @@ export default function ImagesPage() {
                       <td className="px-4 py-4">
                         <div className="relative h-16 w-24 rounded-lg overflow-hidden bg-gray-100 dark:bg-gray-800">
                           {/* Processing State - Single spinner */}
-                          {(image.status === 'queued' || image.status === 'processing') ? (
+                          {image.status === 'processing' && imageUrls[image.id]?.preview ? (
+                            /* Low-resolution preview while the full render completes */
+                            <>
+                              <NextImage
+                                src={imageUrls[image.id]?.preview as string}
+                                alt="Preview"
+                                width={96}
+                                height={64}
+                                className="h-full w-full object-cover opacity-80"
+                              />
+                              <div className="absolute inset-0 flex items-center justify-center">
+                                <Loader2 className="h-5 w-5 text-white animate-spin drop-shadow" />
+                              </div>
+                            </>
+                          ) : (image.status === 'queued' || image.status === 'processing') ? (
                             <div className="flex items-center justify-center h-full bg-gradient-to-br from-gray-700 to-gray-800">
                               <Loader2 className="h-6 w-6 text-white animate-spin" />
                             </div>
@@ -892,6 +916,7 @@ export default function ImagesPage() {
                   loadImages(selectedProjectId, true); // Use background refresh to avoid page jump
                 }
               }}
+              onPreview={() => loadImages(selectedProjectId, true)}
             />
           </div>
         </div>
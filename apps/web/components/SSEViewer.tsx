@@ -5,9 +5,11 @@ import { useEffect, useRef, useState } from "react";
 type SSEViewerProps = {
   initialImageId?: string;
   onStatus?: (status: string) => void;
+  // Called when the worker publishes a new low-resolution preview of the result.
+  onPreview?: () => void;
 };
 
-export default function SSEViewer({ initialImageId, onStatus }: SSEViewerProps = {}) {
+export default function SSEViewer({ initialImageId, onStatus, onPreview }: SSEViewerProps = {}) {
   const [imageId, setImageId] = useState(initialImageId ?? "");
   const [connected, setConnected] = useState(false);
   const [log, setLog] = useState<string[]>([]);
@@ -90,6 +92,7 @@ export default function SSEViewer({ initialImageId, onStatus }: SSEViewerProps =
           const parsed = JSON.parse(dataStr);
           const status = parsed?.status as string | undefined;
           if (status && onStatus) onStatus(status);
+          if (parsed?.preview === true && onPreview) onPreview();
         } catch {
           // ignore parse errors for callback
         }
@@ -48,6 +48,7 @@ describe('apiFetch', () => {
       expect(secondCall[0]).toBe('/api/v1/projects');
       expect(secondCall[1]?.headers).toMatchObject({
         'Content-Type': 'application/json',
+        'X-Client-Source': 'web',
         'Authorization': `Bearer ${mockToken}`,
       });
     });
@@ -32,6 +32,8 @@ export async function apiFetch<T = Json>(path: string, options: RequestInit = {}
   const url = `${API_BASE}${path}`
   const headers: HeadersInit = {
     'Content-Type': 'application/json',
+    // Lets the API attribute created images to the web app
+    'X-Client-Source': 'web',
     ...(options.headers || {}),
   }
   
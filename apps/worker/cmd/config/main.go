@@ -0,0 +1,37 @@
+// Command config inspects the worker configuration.
+//
+//	config dump [--redacted=false]
+//
+// dump prints the effective configuration after every layer has been applied (shared.yml,
+// the APP_ENV overlay, secrets.yml, then environment variables), with secrets redacted
+// unless --redacted=false is passed.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/real-staging-ai/worker/internal/config"
+)
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != "dump" {
+		fmt.Fprintln(os.Stderr, "Usage: config dump [--redacted=false]")
+		os.Exit(2)
+	}
+
+	fs := flag.NewFlagSet("dump", flag.ExitOnError)
+	redacted := fs.Bool("redacted", true, "Replace secret values with "+config.Redacted)
+	_ = fs.Parse(os.Args[2:])
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+	if err := cfg.Dump(os.Stdout, *redacted); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
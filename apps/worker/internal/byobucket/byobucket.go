@@ -0,0 +1,54 @@
+// Package byobucket opens the S3 buckets that accounts configured for their own images.
+// The API verifies each bucket and routes uploads to it; the worker only follows the
+// bucket named in an image's URL, assuming the account's IAM role to reach it.
+package byobucket
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Bucket is an account's bucket and the role that grants access to it.
+type Bucket struct {
+	Name       string
+	Region     string
+	RoleARN    string
+	ExternalID string
+}
+
+// Repository looks up customer buckets.
+type Repository interface {
+	// ByName returns the customer bucket called name, or nil when no account configured it.
+	ByName(ctx context.Context, name string) (*Bucket, error)
+}
+
+// SQLRepository reads customer_buckets with database/sql.
+type SQLRepository struct {
+	db *sql.DB
+}
+
+// Ensure SQLRepository implements Repository.
+var _ Repository = (*SQLRepository)(nil)
+
+// NewSQLRepository creates a SQLRepository.
+func NewSQLRepository(db *sql.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+// ByName returns the customer bucket called name.
+func (r *SQLRepository) ByName(ctx context.Context, name string) (*Bucket, error) {
+	const q = `SELECT bucket, region, role_arn, external_id FROM customer_buckets WHERE bucket = $1`
+	var b Bucket
+	err := r.db.QueryRowContext(ctx, q, name).Scan(&b.Name, &b.Region, &b.RoleARN, &b.ExternalID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get customer bucket: %w", err)
+	}
+	return &b, nil
+}
@@ -0,0 +1,93 @@
+package byobucket
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"github.com/real-staging-ai/worker/internal/config"
+	"github.com/real-staging-ai/worker/internal/telemetry"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out clients_mock.go . Source
+
+// Source returns S3 clients for customer buckets.
+type Source interface {
+	// Client returns a client for the customer bucket called name, or nil when no account
+	// configured it.
+	Client(ctx context.Context, name string) (*s3.Client, error)
+}
+
+// sessionName identifies our sessions in the customer's CloudTrail. It matches the API's.
+const sessionName = "real-staging"
+
+// Clients assumes each bucket's role with the worker's own AWS credentials. Clients are
+// kept per bucket with cached credentials that refresh shortly before the session
+// expires, so STS is called about once per session rather than per object.
+type Clients struct {
+	repo            Repository
+	base            aws.Config
+	sessionDuration time.Duration
+
+	mu      sync.Mutex
+	clients map[string]cachedClient
+}
+
+type cachedClient struct {
+	bucket Bucket
+	client *s3.Client
+}
+
+// Ensure Clients implements Source.
+var _ Source = (*Clients)(nil)
+
+// awsConfigLoader allows overriding AWS config loading in tests.
+var awsConfigLoader = awsconfig.LoadDefaultConfig
+
+// NewClients creates Clients using the default AWS credential chain.
+func NewClients(ctx context.Context, repo Repository, cfg config.CustomerBuckets) (*Clients, error) {
+	base, err := awsConfigLoader(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	return &Clients{
+		repo:            repo,
+		base:            base,
+		sessionDuration: cfg.SessionDuration,
+		clients:         map[string]cachedClient{},
+	}, nil
+}
+
+// Client returns a client for the customer bucket called name. The bucket's settings are
+// read on every call so a changed role takes effect at once; the client, and with it the
+// assumed-role session, is only rebuilt when they change.
+func (c *Clients) Client(ctx context.Context, name string) (*s3.Client, error) {
+	b, err := c.repo.ByName(ctx, name)
+	if err != nil || b == nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cached, ok := c.clients[name]; ok && cached.bucket == *b {
+		return cached.client, nil
+	}
+	creds := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(c.base), b.RoleARN, func(o *stscreds.AssumeRoleOptions) {
+		o.ExternalID = aws.String(b.ExternalID)
+		o.RoleSessionName = sessionName
+		o.Duration = c.sessionDuration
+	})
+	client := s3.NewFromConfig(c.base, func(o *s3.Options) {
+		o.Region = b.Region
+		o.Credentials = aws.NewCredentialsCache(creds)
+	}, telemetry.CountS3Operations)
+	c.clients[name] = cachedClient{bucket: *b, client: client}
+	return client, nil
+}
@@ -0,0 +1,31 @@
+// Package cancellation lets the worker observe user-initiated cancel requests
+// that the API records in Redis for in-flight staging jobs.
+package cancellation
+
+import (
+	"context"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out checker_mock.go . Checker
+
+// KeyPrefix mirrors the API's queue.CancelKeyPrefix.
+const KeyPrefix = "jobs:cancel:"
+
+// Key returns the Redis key the API sets when an image is canceled.
+func Key(imageID string) string {
+	return KeyPrefix + imageID
+}
+
+// Checker reports whether processing for an image has been canceled.
+type Checker interface {
+	// IsCanceled returns true when the API has signaled that the image's job should be aborted.
+	IsCanceled(ctx context.Context, imageID string) (bool, error)
+}
+
+// NoopChecker is a Checker that never reports cancellation, for when Redis is not configured.
+type NoopChecker struct{}
+
+// IsCanceled always returns false.
+func (n *NoopChecker) IsCanceled(_ context.Context, _ string) (bool, error) {
+	return false, nil
+}
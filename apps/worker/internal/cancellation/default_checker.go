@@ -0,0 +1,49 @@
+package cancellation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	redis "github.com/redis/go-redis/v9"
+
+	"github.com/real-staging-ai/worker/internal/chaos"
+	"github.com/real-staging-ai/worker/internal/config"
+)
+
+// NewDefaultChecker returns a Redis-backed checker if cfg.Redis.Addr is set.
+func NewDefaultChecker(cfg *config.Config) (Checker, error) {
+	if cfg == nil {
+		return nil, errors.New("config is nil")
+	}
+
+	if cfg.Redis.Addr == "" {
+		return nil, errors.New("redis address is not configured. Set REDIS_ADDR or redis.addr")
+	}
+	rdb := redis.NewClient(&redis.Options{Addr: cfg.Redis.Addr})
+	inj, err := chaos.FromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if inj != nil {
+		rdb.AddHook(inj.RedisHook())
+	}
+	return NewDefaultCheckerWithClient(rdb), nil
+}
+
+// NewDefaultCheckerWithClient constructs a checker with a provided redis client.
+func NewDefaultCheckerWithClient(rdb *redis.Client) Checker {
+	return &defaultRedisChecker{rdb: rdb}
+}
+
+type defaultRedisChecker struct {
+	rdb *redis.Client
+}
+
+func (c *defaultRedisChecker) IsCanceled(ctx context.Context, imageID string) (bool, error) {
+	n, err := c.rdb.Exists(ctx, Key(imageID)).Result()
+	if err != nil {
+		return false, fmt.Errorf("check cancel signal: %w", err)
+	}
+	return n > 0, nil
+}
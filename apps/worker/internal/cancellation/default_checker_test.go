@@ -0,0 +1,66 @@
+package cancellation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/worker/internal/config"
+)
+
+func TestNewDefaultChecker(t *testing.T) {
+	t.Run("fail: nil config", func(t *testing.T) {
+		_, err := NewDefaultChecker(nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("fail: no redis address", func(t *testing.T) {
+		t.Setenv("REDIS_ADDR", "localhost:6379")
+		_, err := NewDefaultChecker(&config.Config{})
+		assert.Error(t, err)
+	})
+
+	t.Run("success: address from config", func(t *testing.T) {
+		cfg := &config.Config{}
+		cfg.Redis.Addr = "localhost:6379"
+		c, err := NewDefaultChecker(cfg)
+		require.NoError(t, err)
+		assert.NotNil(t, c)
+	})
+}
+
+func TestDefaultChecker_IsCanceled(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	c := NewDefaultCheckerWithClient(rdb)
+	ctx := context.Background()
+
+	canceled, err := c.IsCanceled(ctx, "img-1")
+	require.NoError(t, err)
+	assert.False(t, canceled)
+
+	require.NoError(t, mr.Set(Key("img-1"), "1"))
+	canceled, err = c.IsCanceled(ctx, "img-1")
+	require.NoError(t, err)
+	assert.True(t, canceled)
+
+	t.Run("fail: redis unavailable", func(t *testing.T) {
+		bad := NewDefaultCheckerWithClient(redis.NewClient(&redis.Options{
+			Addr:        "127.0.0.1:6392",
+			DialTimeout: 50 * time.Millisecond,
+		}))
+		_, err := bad.IsCanceled(ctx, "img-1")
+		assert.Error(t, err)
+	})
+}
+
+func TestNoopChecker(t *testing.T) {
+	canceled, err := (&NoopChecker{}).IsCanceled(context.Background(), "img-1")
+	assert.NoError(t, err)
+	assert.False(t, canceled)
+}
@@ -0,0 +1,185 @@
+// Package chaos injects faults at dependency boundaries (object storage, Redis, the model
+// provider, the database) so retry and recovery paths can be exercised on purpose.
+//
+// Injectors can always be built directly, which is how tests use them. FromEnv, the
+// entry point services call at startup, only returns one in binaries built with the
+// "chaos" build tag, so production builds cannot enable faults by configuration.
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Point names a dependency boundary where faults can be injected.
+type Point string
+
+// Fault points.
+const (
+	PointS3        Point = "s3"
+	PointRedis     Point = "redis"
+	PointReplicate Point = "replicate"
+	PointDB        Point = "db"
+)
+
+// ErrInjected is returned (wrapped) for every injected failure.
+var ErrInjected = errors.New("chaos: injected fault")
+
+// Fault describes what happens at a point.
+type Fault struct {
+	// ErrorRate is the probability (0-1) that a call fails.
+	ErrorRate float64
+	// Latency is added to every call before it proceeds or fails.
+	Latency time.Duration
+	// Status, for HTTP points, makes failures return a response with this status code
+	// instead of a transport error. Use 500 to mimic an upstream outage.
+	Status int
+	// Count limits the fault to the first Count matching failures; zero means unlimited.
+	// With ErrorRate 1 this fails exactly Count calls, which keeps retry tests deterministic.
+	Count int
+}
+
+// Injector decides, per call, whether a fault fires. A nil *Injector never injects.
+type Injector struct {
+	mu     sync.Mutex
+	faults map[Point]Fault
+	fired  map[Point]int
+	rand   func() float64
+}
+
+// New returns an Injector with the given faults.
+func New(faults map[Point]Fault) *Injector {
+	i := &Injector{faults: make(map[Point]Fault), fired: make(map[Point]int), rand: rand.Float64}
+	for p, f := range faults {
+		i.faults[p] = f
+	}
+	return i
+}
+
+// Set replaces the fault at p and resets its Count.
+func (i *Injector) Set(p Point, f Fault) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.faults[p] = f
+	i.fired[p] = 0
+}
+
+// Clear removes the fault at p.
+func (i *Injector) Clear(p Point) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.faults, p)
+}
+
+// Fired reports how many failures have been injected at p since it was last Set.
+func (i *Injector) Fired(p Point) int {
+	if i == nil {
+		return 0
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.fired[p]
+}
+
+// Inject applies the fault at p: it waits out any latency, then returns an error wrapping
+// ErrInjected if the call should fail. The returned Fault carries the HTTP status to use.
+func (i *Injector) Inject(ctx context.Context, p Point) (Fault, error) {
+	if i == nil {
+		return Fault{}, nil
+	}
+	i.mu.Lock()
+	f, ok := i.faults[p]
+	i.mu.Unlock()
+	if !ok {
+		return Fault{}, nil
+	}
+
+	if f.Latency > 0 {
+		t := time.NewTimer(f.Latency)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return f, ctx.Err()
+		case <-t.C:
+		}
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if f.ErrorRate <= 0 || (f.Count > 0 && i.fired[p] >= f.Count) || i.rand() >= f.ErrorRate {
+		return f, nil
+	}
+	i.fired[p]++
+	return f, fmt.Errorf("%s: %w", p, ErrInjected)
+}
+
+// Parse reads a fault spec such as "s3:error=0.2,latency=300ms;replicate:error=1,status=500".
+// Points are separated by ";" and their settings by ",". Settings are error (0-1), latency
+// (a Go duration), status (an HTTP status code) and count.
+func Parse(spec string) (map[Point]Fault, error) {
+	faults := make(map[Point]Fault)
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, settings, _ := strings.Cut(entry, ":")
+		p := Point(strings.TrimSpace(name))
+		switch p {
+		case PointS3, PointRedis, PointReplicate, PointDB:
+		default:
+			return nil, fmt.Errorf("unknown fault point %q", p)
+		}
+
+		var f Fault
+		for _, kv := range strings.Split(settings, ",") {
+			key, value, ok := strings.Cut(strings.TrimSpace(kv), "=")
+			if !ok {
+				return nil, fmt.Errorf("%s: setting %q must be key=value", p, kv)
+			}
+			var err error
+			switch key {
+			case "error":
+				f.ErrorRate, err = strconv.ParseFloat(value, 64)
+				if err == nil && (f.ErrorRate < 0 || f.ErrorRate > 1) {
+					err = errors.New("must be between 0 and 1")
+				}
+			case "latency":
+				f.Latency, err = time.ParseDuration(value)
+			case "status":
+				f.Status, err = strconv.Atoi(value)
+			case "count":
+				f.Count, err = strconv.Atoi(value)
+			default:
+				err = errors.New("unknown setting")
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s=%s: %w", p, key, value, err)
+			}
+		}
+		faults[p] = f
+	}
+	return faults, nil
+}
+
+// FromEnv returns an Injector configured from CHAOS_FAULTS. It returns nil, meaning no
+// faults, unless the binary was built with the "chaos" tag, CHAOS_FAULTS is set, and
+// APP_ENV is not prod.
+func FromEnv() (*Injector, error) {
+	spec := os.Getenv("CHAOS_FAULTS")
+	if !compiledIn || spec == "" || os.Getenv("APP_ENV") == "prod" {
+		return nil, nil
+	}
+	faults, err := Parse(spec)
+	if err != nil {
+		return nil, fmt.Errorf("parse CHAOS_FAULTS: %w", err)
+	}
+	return New(faults), nil
+}
@@ -0,0 +1,131 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name    string
+		spec    string
+		want    map[Point]Fault
+		wantErr string
+	}{
+		{
+			name: "success: several points",
+			spec: "s3:error=0.25,latency=300ms; replicate:error=1,status=500,count=2",
+			want: map[Point]Fault{
+				PointS3:        {ErrorRate: 0.25, Latency: 300 * time.Millisecond},
+				PointReplicate: {ErrorRate: 1, Status: 500, Count: 2},
+			},
+		},
+		{name: "success: empty spec", spec: "", want: map[Point]Fault{}},
+		{name: "fail: unknown point", spec: "kafka:error=1", wantErr: `unknown fault point "kafka"`},
+		{name: "fail: unknown setting", spec: "s3:jitter=1s", wantErr: "unknown setting"},
+		{name: "fail: rate out of range", spec: "db:error=2", wantErr: "between 0 and 1"},
+		{name: "fail: bad duration", spec: "redis:latency=soon", wantErr: "redis: latency=soon"},
+		{name: "fail: missing value", spec: "s3:error", wantErr: "must be key=value"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Parse(tc.spec)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestInjector_Inject(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: nil injector never fails", func(t *testing.T) {
+		var inj *Injector
+		_, err := inj.Inject(ctx, PointS3)
+		assert.NoError(t, err)
+		assert.Zero(t, inj.Fired(PointS3))
+	})
+
+	t.Run("success: unconfigured point passes", func(t *testing.T) {
+		inj := New(map[Point]Fault{PointDB: {ErrorRate: 1}})
+		_, err := inj.Inject(ctx, PointS3)
+		assert.NoError(t, err)
+	})
+
+	t.Run("success: error rate uses the random source", func(t *testing.T) {
+		inj := New(map[Point]Fault{PointS3: {ErrorRate: 0.5}})
+		rolls := []float64{0.9, 0.1, 0.5, 0.49}
+		inj.rand = func() float64 { r := rolls[0]; rolls = rolls[1:]; return r }
+
+		var failures []bool
+		for range 4 {
+			_, err := inj.Inject(ctx, PointS3)
+			failures = append(failures, err != nil)
+			if err != nil {
+				assert.ErrorIs(t, err, ErrInjected)
+			}
+		}
+		assert.Equal(t, []bool{false, true, false, true}, failures)
+		assert.Equal(t, 2, inj.Fired(PointS3))
+	})
+
+	t.Run("success: count limits failures and Set resets it", func(t *testing.T) {
+		inj := New(map[Point]Fault{PointRedis: {ErrorRate: 1, Count: 2}})
+		var errs int
+		for range 5 {
+			if _, err := inj.Inject(ctx, PointRedis); err != nil {
+				errs++
+			}
+		}
+		assert.Equal(t, 2, errs)
+
+		inj.Set(PointRedis, Fault{ErrorRate: 1, Count: 1})
+		_, err := inj.Inject(ctx, PointRedis)
+		assert.Error(t, err)
+		inj.Clear(PointRedis)
+		_, err = inj.Inject(ctx, PointRedis)
+		assert.NoError(t, err)
+	})
+
+	t.Run("success: latency is applied", func(t *testing.T) {
+		inj := New(map[Point]Fault{PointS3: {Latency: 20 * time.Millisecond}})
+		start := time.Now()
+		_, err := inj.Inject(ctx, PointS3)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+	})
+
+	t.Run("fail: latency honors cancellation", func(t *testing.T) {
+		inj := New(map[Point]Fault{PointS3: {Latency: time.Hour}})
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := inj.Inject(cctx, PointS3)
+		assert.True(t, errors.Is(err, context.Canceled))
+	})
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("CHAOS_FAULTS", "s3:error=1")
+	t.Setenv("APP_ENV", "dev")
+
+	inj, err := FromEnv()
+	require.NoError(t, err)
+	// Only builds tagged "chaos" honor CHAOS_FAULTS.
+	assert.Equal(t, compiledIn, inj != nil)
+
+	t.Setenv("APP_ENV", "prod")
+	inj, err = FromEnv()
+	require.NoError(t, err)
+	assert.Nil(t, inj)
+}
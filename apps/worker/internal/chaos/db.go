@@ -0,0 +1,124 @@
+package chaos
+
+import (
+	"context"
+	"database/sql/driver"
+)
+
+// Connector wraps base so new connections, and statements on open connections, pass
+// through the fault at PointDB. Failures on an open connection surface as
+// driver.ErrBadConn, the way a failover looks to database/sql: the connection is
+// discarded and the pool retries on a fresh one. A nil Injector returns base unchanged.
+func (i *Injector) Connector(base driver.Connector) driver.Connector {
+	if i == nil {
+		return base
+	}
+	return &connector{inj: i, base: base}
+}
+
+type connector struct {
+	inj  *Injector
+	base driver.Connector
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	if _, err := c.inj.Inject(ctx, PointDB); err != nil {
+		return nil, err
+	}
+	dc, err := c.base.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{inj: c.inj, Conn: dc}, nil
+}
+
+func (c *connector) Driver() driver.Driver { return c.base.Driver() }
+
+// conn forwards to the wrapped driver connection, failing calls that reach the server.
+type conn struct {
+	inj *Injector
+	driver.Conn
+}
+
+func (c *conn) fault(ctx context.Context) error {
+	if _, err := c.inj.Inject(ctx, PointDB); err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+		return driver.ErrBadConn
+	}
+	return nil
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if err := c.fault(ctx); err != nil {
+		return nil, err
+	}
+	if p, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return p.PrepareContext(ctx, query)
+	}
+	return c.Prepare(query)
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if err := c.fault(ctx); err != nil {
+		return nil, err
+	}
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return b.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin() //nolint:staticcheck // fallback for drivers without BeginTx
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	q, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	if err := c.fault(ctx); err != nil {
+		return nil, err
+	}
+	return q.QueryContext(ctx, query, args)
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	e, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	if err := c.fault(ctx); err != nil {
+		return nil, err
+	}
+	return e.ExecContext(ctx, query, args)
+}
+
+func (c *conn) Ping(ctx context.Context) error {
+	if err := c.fault(ctx); err != nil {
+		return err
+	}
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *conn) ResetSession(ctx context.Context) error {
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *conn) IsValid() bool {
+	if v, ok := c.Conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	if n, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return n.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
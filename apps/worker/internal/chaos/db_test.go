@@ -0,0 +1,79 @@
+package chaos
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDriver counts connections and executed statements.
+type fakeDriver struct {
+	connects atomic.Int32
+	execs    atomic.Int32
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return d.Connect(context.Background()) }
+func (d *fakeDriver) Connect(context.Context) (driver.Conn, error) {
+	d.connects.Add(1)
+	return &fakeConn{d: d}, nil
+}
+func (d *fakeDriver) Driver() driver.Driver { return d }
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not implemented") }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not implemented") }
+func (c *fakeConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	c.d.execs.Add(1)
+	return driver.RowsAffected(1), nil
+}
+
+func TestInjector_Connector(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: failover is retried on a fresh connection", func(t *testing.T) {
+		d := &fakeDriver{}
+		inj := New(nil)
+		db := sql.OpenDB(inj.Connector(d))
+		defer func() { _ = db.Close() }()
+
+		// Open a connection before the fault so the failure hits a pooled one.
+		require.NoError(t, db.PingContext(ctx))
+		inj.Set(PointDB, Fault{ErrorRate: 1, Count: 1})
+
+		_, err := db.ExecContext(ctx, "UPDATE images SET status = 'ready'")
+		require.NoError(t, err)
+		assert.Equal(t, 1, inj.Fired(PointDB))
+		assert.Equal(t, int32(1), d.execs.Load())
+		assert.Equal(t, int32(2), d.connects.Load())
+	})
+
+	t.Run("fail: outage outlasts database/sql retries", func(t *testing.T) {
+		d := &fakeDriver{}
+		inj := New(map[Point]Fault{PointDB: {ErrorRate: 1}})
+		db := sql.OpenDB(inj.Connector(d))
+		defer func() { _ = db.Close() }()
+
+		_, err := db.ExecContext(ctx, "UPDATE images SET status = 'ready'")
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInjected)
+		assert.Zero(t, d.execs.Load())
+
+		inj.Clear(PointDB)
+		_, err = db.ExecContext(ctx, "UPDATE images SET status = 'ready'")
+		assert.NoError(t, err)
+	})
+
+	t.Run("success: nil injector returns base", func(t *testing.T) {
+		d := &fakeDriver{}
+		var inj *Injector
+		assert.Equal(t, driver.Connector(d), inj.Connector(d))
+	})
+}
@@ -0,0 +1,49 @@
+package chaos
+
+import (
+	"context"
+	"net"
+
+	redis "github.com/redis/go-redis/v9"
+)
+
+// RedisHook returns a go-redis hook that fails dials, commands and pipelines with the
+// fault at PointRedis, as a dropped connection would.
+func (i *Injector) RedisHook() redis.Hook {
+	return redisHook{inj: i}
+}
+
+type redisHook struct {
+	inj *Injector
+}
+
+func (h redisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if _, err := h.inj.Inject(ctx, PointRedis); err != nil {
+			return nil, err
+		}
+		return next(ctx, network, addr)
+	}
+}
+
+func (h redisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if _, err := h.inj.Inject(ctx, PointRedis); err != nil {
+			cmd.SetErr(err)
+			return err
+		}
+		return next(ctx, cmd)
+	}
+}
+
+func (h redisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if _, err := h.inj.Inject(ctx, PointRedis); err != nil {
+			for _, cmd := range cmds {
+				cmd.SetErr(err)
+			}
+			return err
+		}
+		return next(ctx, cmds)
+	}
+}
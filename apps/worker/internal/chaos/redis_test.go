@@ -0,0 +1,36 @@
+package chaos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInjector_RedisHook(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1})
+	defer func() { _ = rdb.Close() }()
+
+	inj := New(map[Point]Fault{PointRedis: {ErrorRate: 1, Count: 1}})
+	rdb.AddHook(inj.RedisHook())
+
+	err := rdb.Set(ctx, "k", "v", 0).Err()
+	assert.ErrorIs(t, err, ErrInjected)
+	require.NoError(t, rdb.Set(ctx, "k", "v", 0).Err())
+
+	inj.Set(PointRedis, Fault{ErrorRate: 1, Count: 1})
+	_, err = rdb.Pipelined(ctx, func(p redis.Pipeliner) error {
+		p.Get(ctx, "k")
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrInjected)
+
+	v, err := rdb.Get(ctx, "k").Result()
+	require.NoError(t, err)
+	assert.Equal(t, "v", v)
+}
@@ -0,0 +1,52 @@
+package chaos
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Transport wraps base so requests pass through the fault at p. Failing requests get a
+// synthetic response when the fault has a Status, and a transport error otherwise.
+// A nil Injector returns base unchanged.
+func (i *Injector) Transport(p Point, base http.RoundTripper) http.RoundTripper {
+	if i == nil {
+		return base
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{inj: i, point: p, base: base}
+}
+
+type transport struct {
+	inj   *Injector
+	point Point
+	base  http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	f, err := t.inj.Inject(req.Context(), t.point)
+	if err == nil {
+		return t.base.RoundTrip(req)
+	}
+	if f.Status == 0 || req.Context().Err() != nil {
+		return nil, err
+	}
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	body := `{"error":"` + err.Error() + `"}`
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", f.Status, http.StatusText(f.Status)),
+		StatusCode:    f.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
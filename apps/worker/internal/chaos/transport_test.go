@@ -0,0 +1,54 @@
+package chaos
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInjector_Transport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	testCases := []struct {
+		name       string
+		fault      *Fault
+		wantStatus int
+		wantErr    bool
+	}{
+		{name: "success: no fault reaches the server", wantStatus: http.StatusOK},
+		{name: "success: status fault returns a synthetic response", fault: &Fault{ErrorRate: 1, Status: 500}, wantStatus: 500},
+		{name: "fail: fault without status is a transport error", fault: &Fault{ErrorRate: 1}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			inj := New(nil)
+			if tc.fault != nil {
+				inj.Set(PointReplicate, *tc.fault)
+			}
+			client := &http.Client{Transport: inj.Transport(PointReplicate, nil)}
+
+			res, err := client.Get(srv.URL)
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInjected)
+				return
+			}
+			require.NoError(t, err)
+			defer func() { _ = res.Body.Close() }()
+			assert.Equal(t, tc.wantStatus, res.StatusCode)
+		})
+	}
+
+	t.Run("success: nil injector returns base", func(t *testing.T) {
+		var inj *Injector
+		assert.Equal(t, http.DefaultTransport, inj.Transport(PointS3, http.DefaultTransport))
+	})
+}
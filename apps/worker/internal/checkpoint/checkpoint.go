@@ -0,0 +1,135 @@
+// Package checkpoint records how far a stage:run job got, so a job retried after a worker
+// crash can skip the stages that already completed.
+package checkpoint
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/real-staging-ai/worker/internal/dbretry"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Checkpoint is the recorded progress of an image's current stage:run job.
+type Checkpoint struct {
+	// PredictionID is the provider prediction started for the job, if any.
+	PredictionID string
+	// OutputKey is the S3 key of the staged output, set once it has been uploaded.
+	OutputKey string
+}
+
+// Recorder records stages as they complete.
+type Recorder interface {
+	// RecordPrediction records the prediction started for the image.
+	RecordPrediction(ctx context.Context, imageID, predictionID string) error
+	// RecordOutput records the S3 key of the uploaded staged output.
+	RecordOutput(ctx context.Context, imageID, outputKey string) error
+	// RecordProviderResponse records the model version and the provider's final response
+	// for the prediction, for debugging from the admin job view.
+	RecordProviderResponse(ctx context.Context, imageID, modelVersion, response string) error
+}
+
+// Repository reads and records checkpoints on the image's most recent stage:run job.
+type Repository interface {
+	Recorder
+	// Load returns the checkpoint for the image, empty when nothing was recorded.
+	Load(ctx context.Context, imageID string) (Checkpoint, error)
+}
+
+// SQLRepository stores checkpoints on the jobs table with database/sql.
+type SQLRepository struct {
+	db *sql.DB
+}
+
+// Ensure SQLRepository implements Repository.
+var _ Repository = (*SQLRepository)(nil)
+
+// MaxProviderResponse caps the stored provider response; longer responses (usually from
+// verbose prediction logs) are truncated.
+const MaxProviderResponse = 8 << 10
+
+// NewSQLRepository creates a SQLRepository.
+func NewSQLRepository(db *sql.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+// currentJob selects the image's most recent stage:run job. Regenerating an image creates
+// a new job, so checkpoints from an earlier run are never reused.
+const currentJob = `
+	SELECT id, created_at
+	FROM jobs
+	WHERE image_id = $1::uuid AND type = 'stage:run'
+	ORDER BY created_at DESC
+	LIMIT 1`
+
+// Load returns the checkpoint recorded on the image's current job.
+func (r *SQLRepository) Load(ctx context.Context, imageID string) (Checkpoint, error) {
+	q := `
+		SELECT COALESCE(prediction_id, ''), COALESCE(output_key, '')
+		FROM jobs
+		WHERE (id, created_at) IN (` + currentJob + `)`
+	var c Checkpoint
+	err := r.db.QueryRowContext(ctx, q, imageID).Scan(&c.PredictionID, &c.OutputKey)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Checkpoint{}, nil
+	}
+	if err != nil {
+		return Checkpoint{}, fmt.Errorf("load job checkpoint: %w", err)
+	}
+	return c, nil
+}
+
+// RecordPrediction stores the prediction ID on the image's current job.
+func (r *SQLRepository) RecordPrediction(ctx context.Context, imageID, predictionID string) error {
+	q := `UPDATE jobs SET prediction_id = $2 WHERE (id, created_at) IN (` + currentJob + `)`
+	err := dbretry.Do(ctx, "record prediction checkpoint", func(ctx context.Context) error {
+		_, err := r.db.ExecContext(ctx, q, imageID, predictionID)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("record prediction checkpoint: %w", err)
+	}
+	return nil
+}
+
+// RecordOutput stores the staged output key on the image's current job.
+func (r *SQLRepository) RecordOutput(ctx context.Context, imageID, outputKey string) error {
+	q := `UPDATE jobs SET output_key = $2 WHERE (id, created_at) IN (` + currentJob + `)`
+	err := dbretry.Do(ctx, "record output checkpoint", func(ctx context.Context) error {
+		_, err := r.db.ExecContext(ctx, q, imageID, outputKey)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("record output checkpoint: %w", err)
+	}
+	return nil
+}
+
+// RecordProviderResponse stores the model version and the provider response, truncated to
+// MaxProviderResponse bytes, on the image's current job.
+func (r *SQLRepository) RecordProviderResponse(ctx context.Context, imageID, modelVersion, response string) error {
+	q := `UPDATE jobs SET model_version = $2, provider_response = $3 WHERE (id, created_at) IN (` + currentJob + `)`
+	err := dbretry.Do(ctx, "record provider response", func(ctx context.Context) error {
+		_, err := r.db.ExecContext(ctx, q, imageID, modelVersion, truncate(response, MaxProviderResponse))
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("record provider response: %w", err)
+	}
+	return nil
+}
+
+// truncate shortens s to at most n bytes without splitting a UTF-8 sequence.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
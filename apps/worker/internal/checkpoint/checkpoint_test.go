@@ -0,0 +1,138 @@
+package checkpoint
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const imageID = "2e1aa86e-0f27-4f0f-9e57-0d7b53b4d9b9"
+
+func TestSQLRepository_Load(t *testing.T) {
+	testCases := []struct {
+		name    string
+		setup   func(mock sqlmock.Sqlmock)
+		want    Checkpoint
+		wantErr bool
+	}{
+		{
+			name: "success: recorded checkpoint",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT COALESCE\(prediction_id, ''\), COALESCE\(output_key, ''\)\s+FROM jobs`).
+					WithArgs(imageID).
+					WillReturnRows(sqlmock.NewRows([]string{"prediction_id", "output_key"}).
+						AddRow("pred-1", "staged/2e1aa86e/x-staged.jpg"))
+			},
+			want: Checkpoint{PredictionID: "pred-1", OutputKey: "staged/2e1aa86e/x-staged.jpg"},
+		},
+		{
+			name: "success: no job yields an empty checkpoint",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM jobs`).WithArgs(imageID).
+					WillReturnRows(sqlmock.NewRows([]string{"prediction_id", "output_key"}))
+			},
+		},
+		{
+			name: "fail: query error",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM jobs`).WithArgs(imageID).WillReturnError(errors.New("boom"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+			tc.setup(mock)
+
+			got, err := NewSQLRepository(db).Load(context.Background(), imageID)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSQLRepository_Record(t *testing.T) {
+	testCases := []struct {
+		name    string
+		column  string
+		record  func(r *SQLRepository) error
+		execErr error
+	}{
+		{
+			name:   "success: prediction",
+			column: "prediction_id",
+			record: func(r *SQLRepository) error {
+				return r.RecordPrediction(context.Background(), imageID, "pred-1")
+			},
+		},
+		{
+			name:   "success: output",
+			column: "output_key",
+			record: func(r *SQLRepository) error {
+				return r.RecordOutput(context.Background(), imageID, "staged/key.jpg")
+			},
+		},
+		{
+			name:   "fail: exec error",
+			column: "prediction_id",
+			record: func(r *SQLRepository) error {
+				return r.RecordPrediction(context.Background(), imageID, "pred-1")
+			},
+			execErr: errors.New("boom"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+
+			exp := mock.ExpectExec(`UPDATE jobs SET `+tc.column+` = \$2 WHERE \(id, created_at\) IN`).
+				WithArgs(imageID, sqlmock.AnyArg())
+			if tc.execErr != nil {
+				exp.WillReturnError(tc.execErr)
+			} else {
+				exp.WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			err = tc.record(NewSQLRepository(db))
+			if tc.execErr != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSQLRepository_RecordProviderResponse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	// A multi-byte rune straddles the cap, so it is dropped rather than split.
+	long := strings.Repeat("a", MaxProviderResponse-1) + "é" + "tail"
+	mock.ExpectExec(`UPDATE jobs SET model_version = \$2, provider_response = \$3 WHERE \(id, created_at\) IN`).
+		WithArgs(imageID, "v1abc", strings.Repeat("a", MaxProviderResponse-1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = NewSQLRepository(db).RecordProviderResponse(context.Background(), imageID, "v1abc", long)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,190 @@
+// Package compaction reclaims storage that failed or abandoned uploads leave behind in the
+// platform's buckets: multipart uploads that were never completed, whose parts are billed
+// but invisible to listings, and objects left under temporary prefixes.
+package compaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/schedule"
+)
+
+// Kinds of reclaimed storage, reported as the kind attribute of the reclaimed bytes metric.
+const (
+	KindMultipart = "multipart"
+	KindTemp      = "temp"
+)
+
+// Config controls a compaction run.
+type Config struct {
+	// Buckets are the buckets compacted.
+	Buckets []string
+	// MaxAge is how old an upload or temporary object must be before it is removed, so
+	// uploads still in progress are left alone.
+	MaxAge time.Duration
+	// TempPrefixes are the key prefixes holding temporary objects.
+	TempPrefixes []string
+}
+
+// Result summarizes a compaction run.
+type Result struct {
+	AbortedUploads int
+	DeletedObjects int
+	ReclaimedBytes int64
+	Failed         int
+}
+
+// Compactor aborts stale multipart uploads and deletes stale temporary objects.
+type Compactor struct {
+	store     Store
+	locker    Locker
+	cfg       Config
+	log       logging.Logger
+	now       func() time.Time
+	reclaimed metric.Int64Counter
+}
+
+// NewCompactor creates a Compactor reporting reclaimed bytes on the global meter provider.
+func NewCompactor(store Store, locker Locker, cfg Config, log logging.Logger) (*Compactor, error) {
+	if cfg.MaxAge <= 0 {
+		cfg.MaxAge = 24 * time.Hour
+	}
+	reclaimed, err := newReclaimedCounter(otel.Meter("real-staging-worker/compaction"))
+	if err != nil {
+		return nil, err
+	}
+	return &Compactor{store: store, locker: locker, cfg: cfg, log: log, now: time.Now, reclaimed: reclaimed}, nil
+}
+
+func newReclaimedCounter(meter metric.Meter) (metric.Int64Counter, error) {
+	c, err := meter.Int64Counter("storage.compaction.reclaimed_bytes",
+		metric.WithUnit("By"),
+		metric.WithDescription("Bytes of abandoned multipart uploads and temporary objects removed from storage"))
+	if err != nil {
+		return nil, fmt.Errorf("create reclaimed bytes counter: %w", err)
+	}
+	return c, nil
+}
+
+// ErrLocked is returned by Run when another worker is already compacting.
+var ErrLocked = errors.New("storage compaction already running")
+
+// Run compacts every configured bucket. A failure to abort or delete one item is counted
+// and logged, and the run goes on; a failure to list a bucket ends the run.
+func (c *Compactor) Run(ctx context.Context) (Result, error) {
+	var res Result
+	cutoff := c.now().UTC().Add(-c.cfg.MaxAge)
+
+	unlock, ok, err := c.locker.TryLock(ctx)
+	if err != nil {
+		return res, err
+	}
+	if !ok {
+		return res, ErrLocked
+	}
+	defer unlock()
+
+	for _, bucket := range c.cfg.Buckets {
+		if err := c.abortUploads(ctx, bucket, cutoff, &res); err != nil {
+			return res, err
+		}
+		for _, prefix := range c.cfg.TempPrefixes {
+			if err := c.deleteObjects(ctx, bucket, prefix, cutoff, &res); err != nil {
+				return res, err
+			}
+		}
+	}
+	return res, nil
+}
+
+func (c *Compactor) abortUploads(ctx context.Context, bucket string, cutoff time.Time, res *Result) error {
+	uploads, err := c.store.StaleUploads(ctx, bucket, cutoff)
+	if err != nil {
+		return err
+	}
+	for _, u := range uploads {
+		if err := c.store.AbortUpload(ctx, u); err != nil {
+			c.log.Error(ctx, "Failed to abort multipart upload", "bucket", bucket, "key", u.Key, "error", err)
+			res.Failed++
+			continue
+		}
+		res.AbortedUploads++
+		c.record(ctx, bucket, KindMultipart, u.Size, res)
+	}
+	return nil
+}
+
+func (c *Compactor) deleteObjects(ctx context.Context, bucket, prefix string, cutoff time.Time, res *Result) error {
+	objects, err := c.store.StaleObjects(ctx, bucket, prefix, cutoff)
+	if err != nil {
+		return err
+	}
+	for _, o := range objects {
+		if err := c.store.DeleteObject(ctx, o); err != nil {
+			c.log.Error(ctx, "Failed to delete temporary object", "bucket", bucket, "key", o.Key, "error", err)
+			res.Failed++
+			continue
+		}
+		res.DeletedObjects++
+		c.record(ctx, bucket, KindTemp, o.Size, res)
+	}
+	return nil
+}
+
+func (c *Compactor) record(ctx context.Context, bucket, kind string, size int64, res *Result) {
+	res.ReclaimedBytes += size
+	c.reclaimed.Add(ctx, size, metric.WithAttributes(
+		attribute.String("bucket", bucket),
+		attribute.String("kind", kind),
+	))
+}
+
+// RunDaily blocks until ctx is done, compacting each day at hourUTC.
+func (c *Compactor) RunDaily(ctx context.Context, hourUTC int) {
+	schedule.Daily(ctx, hourUTC, func(ctx context.Context, _ time.Time) {
+		res, err := c.Run(ctx)
+		if errors.Is(err, ErrLocked) {
+			c.log.Info(ctx, "Storage compaction skipped; another worker is running it")
+			return
+		}
+		if err != nil {
+			c.log.Error(ctx, "Storage compaction failed", "error", err,
+				"aborted_uploads", res.AbortedUploads, "deleted_objects", res.DeletedObjects)
+			return
+		}
+		c.log.Info(ctx, "Storage compaction completed", "aborted_uploads", res.AbortedUploads,
+			"deleted_objects", res.DeletedObjects, "reclaimed_bytes", res.ReclaimedBytes, "failed", res.Failed)
+	})
+}
+
+// PlatformBuckets returns the uploads bucket and the data-residency region buckets, each once.
+func PlatformBuckets(home string, regionBuckets map[string]string) []string {
+	seen := map[string]bool{}
+	var buckets []string
+	for _, b := range append([]string{home}, sortedValues(regionBuckets)...) {
+		if b == "" || seen[b] {
+			continue
+		}
+		seen[b] = true
+		buckets = append(buckets, b)
+	}
+	return buckets
+}
+
+func sortedValues(m map[string]string) []string {
+	values := make([]string, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+	return values
+}
@@ -0,0 +1,179 @@
+package compaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+)
+
+func newLogger() *logging.LoggerMock {
+	return &logging.LoggerMock{
+		InfoFunc:  func(ctx context.Context, msg string, keysAndValues ...any) {},
+		WarnFunc:  func(ctx context.Context, msg string, keysAndValues ...any) {},
+		ErrorFunc: func(ctx context.Context, msg string, keysAndValues ...any) {},
+	}
+}
+
+func unlocked() *LockerMock {
+	return &LockerMock{
+		TryLockFunc: func(ctx context.Context) (func(), bool, error) {
+			return func() {}, true, nil
+		},
+	}
+}
+
+// newCompactor returns a Compactor whose metrics are collected by the returned reader.
+func newCompactor(t *testing.T, store Store, locker Locker, cfg Config) (*Compactor, *sdkmetric.ManualReader) {
+	t.Helper()
+	c, err := NewCompactor(store, locker, cfg, newLogger())
+	require.NoError(t, err)
+	reader := sdkmetric.NewManualReader()
+	c.reclaimed, err = newReclaimedCounter(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test"))
+	require.NoError(t, err)
+	return c, reader
+}
+
+// reclaimedByKind returns the reclaimed bytes recorded per bucket and kind.
+func reclaimedByKind(t *testing.T, reader *sdkmetric.ManualReader) map[[2]string]int64 {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	got := map[[2]string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "storage.compaction.reclaimed_bytes" {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				bucket, _ := dp.Attributes.Value(attribute.Key("bucket"))
+				kind, _ := dp.Attributes.Value(attribute.Key("kind"))
+				got[[2]string{bucket.AsString(), kind.AsString()}] = dp.Value
+			}
+		}
+	}
+	return got
+}
+
+func TestCompactor_Run(t *testing.T) {
+	now := time.Date(2026, 10, 17, 4, 0, 0, 0, time.UTC)
+
+	t.Run("success: aborts stale uploads, deletes stale temp objects and reports reclaimed bytes", func(t *testing.T) {
+		var cutoffs []time.Time
+		var aborted, deleted []string
+		store := &StoreMock{
+			StaleUploadsFunc: func(ctx context.Context, bucket string, cutoff time.Time) ([]Upload, error) {
+				cutoffs = append(cutoffs, cutoff)
+				if bucket != "real-staging" {
+					return nil, nil
+				}
+				return []Upload{
+					{Bucket: bucket, Key: "uploads/u1/a.cr2", UploadID: "up-1", Size: 50 << 20},
+					{Bucket: bucket, Key: "uploads/u2/b.nef", UploadID: "up-2", Size: 10 << 20},
+				}, nil
+			},
+			AbortUploadFunc: func(ctx context.Context, u Upload) error {
+				aborted = append(aborted, u.UploadID)
+				return nil
+			},
+			StaleObjectsFunc: func(ctx context.Context, bucket, prefix string, cutoff time.Time) ([]Object, error) {
+				cutoffs = append(cutoffs, cutoff)
+				return []Object{{Bucket: bucket, Key: prefix + "x.tmp", Size: 1000}}, nil
+			},
+			DeleteObjectFunc: func(ctx context.Context, o Object) error {
+				deleted = append(deleted, o.Bucket+"/"+o.Key)
+				return nil
+			},
+		}
+		c, reader := newCompactor(t, store, unlocked(), Config{
+			Buckets:      []string{"real-staging", "real-staging-eu"},
+			TempPrefixes: []string{"temp/"},
+		})
+		c.now = func() time.Time { return now }
+
+		res, err := c.Run(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, Result{AbortedUploads: 2, DeletedObjects: 2, ReclaimedBytes: 60<<20 + 2000}, res)
+		assert.Equal(t, []string{"up-1", "up-2"}, aborted)
+		assert.Equal(t, []string{"real-staging/temp/x.tmp", "real-staging-eu/temp/x.tmp"}, deleted)
+		for _, cutoff := range cutoffs {
+			assert.Equal(t, now.Add(-24*time.Hour), cutoff)
+		}
+		assert.Equal(t, map[[2]string]int64{
+			{"real-staging", KindMultipart}: 60 << 20,
+			{"real-staging", KindTemp}:      1000,
+			{"real-staging-eu", KindTemp}:   1000,
+		}, reclaimedByKind(t, reader))
+	})
+
+	t.Run("success: failed removals are counted and not reported as reclaimed", func(t *testing.T) {
+		store := &StoreMock{
+			StaleUploadsFunc: func(ctx context.Context, bucket string, cutoff time.Time) ([]Upload, error) {
+				return []Upload{{Bucket: bucket, Key: "a", UploadID: "up-1", Size: 100}}, nil
+			},
+			AbortUploadFunc: func(ctx context.Context, u Upload) error {
+				return errors.New("access denied")
+			},
+			StaleObjectsFunc: func(ctx context.Context, bucket, prefix string, cutoff time.Time) ([]Object, error) {
+				return []Object{{Bucket: bucket, Key: "temp/a", Size: 10}, {Bucket: bucket, Key: "temp/b", Size: 20}}, nil
+			},
+			DeleteObjectFunc: func(ctx context.Context, o Object) error {
+				if o.Key == "temp/a" {
+					return errors.New("access denied")
+				}
+				return nil
+			},
+		}
+		c, reader := newCompactor(t, store, unlocked(), Config{Buckets: []string{"real-staging"}, TempPrefixes: []string{"temp/"}})
+
+		res, err := c.Run(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, Result{DeletedObjects: 1, ReclaimedBytes: 20, Failed: 2}, res)
+		assert.Equal(t, map[[2]string]int64{{"real-staging", KindTemp}: 20}, reclaimedByKind(t, reader))
+	})
+
+	t.Run("fail: listing a bucket ends the run", func(t *testing.T) {
+		store := &StoreMock{
+			StaleUploadsFunc: func(ctx context.Context, bucket string, cutoff time.Time) ([]Upload, error) {
+				return nil, errors.New("list failed")
+			},
+		}
+		c, _ := newCompactor(t, store, unlocked(), Config{Buckets: []string{"a", "b"}, TempPrefixes: []string{"temp/"}})
+
+		_, err := c.Run(context.Background())
+		assert.EqualError(t, err, "list failed")
+		assert.Len(t, store.StaleUploadsCalls(), 1)
+		assert.Empty(t, store.StaleObjectsCalls())
+	})
+
+	t.Run("fail: another worker holds the lock", func(t *testing.T) {
+		store := &StoreMock{}
+		locker := &LockerMock{
+			TryLockFunc: func(ctx context.Context) (func(), bool, error) { return nil, false, nil },
+		}
+		c, _ := newCompactor(t, store, locker, Config{Buckets: []string{"real-staging"}})
+
+		_, err := c.Run(context.Background())
+		assert.ErrorIs(t, err, ErrLocked)
+		assert.Empty(t, store.StaleUploadsCalls())
+	})
+}
+
+func TestPlatformBuckets(t *testing.T) {
+	got := PlatformBuckets("real-staging", map[string]string{
+		"eu-central-1": "real-staging-eu",
+		"ap-south-1":   "real-staging-ap",
+		"us-east-1":    "real-staging",
+	})
+	assert.Equal(t, []string{"real-staging", "real-staging-ap", "real-staging-eu"}, got)
+}
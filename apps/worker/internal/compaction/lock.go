@@ -0,0 +1,55 @@
+package compaction
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out lock_mock.go . Locker
+
+// Locker keeps workers from compacting at the same time, which would count the same
+// reclaimed bytes twice.
+type Locker interface {
+	// TryLock takes the compaction lock. ok is false when another worker holds it.
+	// unlock must be called when ok is true.
+	TryLock(ctx context.Context) (unlock func(), ok bool, err error)
+}
+
+// advisoryLockKey identifies the compaction job's Postgres advisory lock.
+const advisoryLockKey = 0x636d7063 // "cmpc"
+
+// AdvisoryLocker is a Locker backed by a Postgres advisory lock.
+type AdvisoryLocker struct {
+	db *sql.DB
+}
+
+// Ensure AdvisoryLocker implements Locker.
+var _ Locker = (*AdvisoryLocker)(nil)
+
+// NewAdvisoryLocker creates an AdvisoryLocker.
+func NewAdvisoryLocker(db *sql.DB) *AdvisoryLocker {
+	return &AdvisoryLocker{db: db}
+}
+
+// TryLock takes a Postgres advisory lock on a dedicated connection.
+func (l *AdvisoryLocker) TryLock(ctx context.Context) (func(), bool, error) {
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("get connection for compaction lock: %w", err)
+	}
+
+	var ok bool
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, advisoryLockKey).Scan(&ok); err != nil {
+		_ = conn.Close()
+		return nil, false, fmt.Errorf("acquire compaction lock: %w", err)
+	}
+	if !ok {
+		_ = conn.Close()
+		return nil, false, nil
+	}
+	return func() {
+		_, _ = conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, advisoryLockKey)
+		_ = conn.Close()
+	}, true, nil
+}
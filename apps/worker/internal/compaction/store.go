@@ -0,0 +1,179 @@
+package compaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/real-staging-ai/worker/internal/config"
+	"github.com/real-staging-ai/worker/internal/s3client"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out store_mock.go . Store
+
+// Upload is an incomplete multipart upload.
+type Upload struct {
+	Bucket    string
+	Key       string
+	UploadID  string
+	Initiated time.Time
+	// Size is the total size in bytes of the parts uploaded so far.
+	Size int64
+}
+
+// Object is a stored object.
+type Object struct {
+	Bucket       string
+	Key          string
+	LastModified time.Time
+	Size         int64
+}
+
+// Store lists and removes abandoned storage in a bucket.
+type Store interface {
+	// StaleUploads returns the multipart uploads in bucket initiated before cutoff.
+	StaleUploads(ctx context.Context, bucket string, cutoff time.Time) ([]Upload, error)
+	// AbortUpload aborts u, freeing its parts. Aborting an upload that no longer exists succeeds.
+	AbortUpload(ctx context.Context, u Upload) error
+	// StaleObjects returns the objects in bucket under prefix last modified before cutoff.
+	StaleObjects(ctx context.Context, bucket, prefix string, cutoff time.Time) ([]Object, error)
+	// DeleteObject deletes o. Deleting a missing object succeeds.
+	DeleteObject(ctx context.Context, o Object) error
+}
+
+// S3Store is an S3-backed Store.
+type S3Store struct {
+	client *s3.Client
+}
+
+// Ensure S3Store implements Store.
+var _ Store = (*S3Store)(nil)
+
+// NewS3Store creates an S3Store with the platform's S3 client.
+func NewS3Store(ctx context.Context, cfg *config.Config) (*S3Store, error) {
+	client, err := s3client.New(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &S3Store{client: client}, nil
+}
+
+// StaleUploads lists bucket's multipart uploads and sizes the stale ones from their parts.
+func (s *S3Store) StaleUploads(ctx context.Context, bucket string, cutoff time.Time) ([]Upload, error) {
+	var uploads []Upload
+	in := &s3.ListMultipartUploadsInput{Bucket: aws.String(bucket)}
+	for {
+		out, err := s.client.ListMultipartUploads(ctx, in)
+		if err != nil {
+			return nil, fmt.Errorf("list multipart uploads in %s: %w", bucket, err)
+		}
+		for _, mu := range out.Uploads {
+			initiated := aws.ToTime(mu.Initiated)
+			if !initiated.Before(cutoff) {
+				continue
+			}
+			u := Upload{
+				Bucket:    bucket,
+				Key:       aws.ToString(mu.Key),
+				UploadID:  aws.ToString(mu.UploadId),
+				Initiated: initiated,
+			}
+			if u.Size, err = s.partsSize(ctx, u); err != nil {
+				return nil, err
+			}
+			uploads = append(uploads, u)
+		}
+		if !aws.ToBool(out.IsTruncated) {
+			return uploads, nil
+		}
+		in.KeyMarker = out.NextKeyMarker
+		in.UploadIdMarker = out.NextUploadIdMarker
+	}
+}
+
+// partsSize sums the sizes of u's uploaded parts.
+func (s *S3Store) partsSize(ctx context.Context, u Upload) (int64, error) {
+	var size int64
+	p := s3.NewListPartsPaginator(s.client, &s3.ListPartsInput{
+		Bucket:   aws.String(u.Bucket),
+		Key:      aws.String(u.Key),
+		UploadId: aws.String(u.UploadID),
+	})
+	for p.HasMorePages() {
+		out, err := p.NextPage(ctx)
+		if err != nil {
+			var noSuchUpload *types.NoSuchUpload
+			if errors.As(err, &noSuchUpload) {
+				// Completed or aborted since it was listed.
+				return size, nil
+			}
+			return 0, fmt.Errorf("list parts of s3://%s/%s: %w", u.Bucket, u.Key, err)
+		}
+		for _, part := range out.Parts {
+			size += aws.ToInt64(part.Size)
+		}
+	}
+	return size, nil
+}
+
+// AbortUpload aborts u.
+func (s *S3Store) AbortUpload(ctx context.Context, u Upload) error {
+	_, err := s.client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(u.Bucket),
+		Key:      aws.String(u.Key),
+		UploadId: aws.String(u.UploadID),
+	})
+	if err != nil {
+		var noSuchUpload *types.NoSuchUpload
+		if errors.As(err, &noSuchUpload) {
+			return nil
+		}
+		return fmt.Errorf("abort multipart upload of s3://%s/%s: %w", u.Bucket, u.Key, err)
+	}
+	return nil
+}
+
+// StaleObjects lists the objects under prefix and keeps those modified before cutoff.
+func (s *S3Store) StaleObjects(ctx context.Context, bucket, prefix string, cutoff time.Time) ([]Object, error) {
+	var objects []Object
+	p := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	})
+	for p.HasMorePages() {
+		out, err := p.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list s3://%s/%s: %w", bucket, prefix, err)
+		}
+		for _, obj := range out.Contents {
+			modified := aws.ToTime(obj.LastModified)
+			if !modified.Before(cutoff) {
+				continue
+			}
+			objects = append(objects, Object{
+				Bucket:       bucket,
+				Key:          aws.ToString(obj.Key),
+				LastModified: modified,
+				Size:         aws.ToInt64(obj.Size),
+			})
+		}
+	}
+	return objects, nil
+}
+
+// DeleteObject deletes o.
+func (s *S3Store) DeleteObject(ctx context.Context, o Object) error {
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(o.Bucket),
+		Key:    aws.String(o.Key),
+	})
+	if err != nil {
+		return fmt.Errorf("delete s3://%s/%s: %w", o.Bucket, o.Key, err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package compaction
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestStore returns an S3Store talking to handler.
+func newTestStore(t *testing.T, handler http.HandlerFunc) *S3Store {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	client := s3.New(s3.Options{
+		Region:       "us-west-1",
+		BaseEndpoint: aws.String(srv.URL),
+		UsePathStyle: true,
+		Credentials:  aws.AnonymousCredentials{},
+	})
+	return &S3Store{client: client}
+}
+
+func TestS3Store_StaleUploads(t *testing.T) {
+	store := newTestStore(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch {
+		case q.Has("uploads"):
+			_, _ = fmt.Fprint(w, `<ListMultipartUploadsResult>
+				<Bucket>real-staging</Bucket><IsTruncated>false</IsTruncated>
+				<Upload><Key>uploads/u1/old.cr2</Key><UploadId>old</UploadId><Initiated>2026-10-15T00:00:00Z</Initiated></Upload>
+				<Upload><Key>uploads/u1/new.cr2</Key><UploadId>new</UploadId><Initiated>2026-10-17T00:00:00Z</Initiated></Upload>
+			</ListMultipartUploadsResult>`)
+		case q.Get("uploadId") == "old":
+			_, _ = fmt.Fprint(w, `<ListPartsResult>
+				<IsTruncated>false</IsTruncated>
+				<Part><PartNumber>1</PartNumber><Size>5242880</Size></Part>
+				<Part><PartNumber>2</PartNumber><Size>1024</Size></Part>
+			</ListPartsResult>`)
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	got, err := store.StaleUploads(context.Background(), "real-staging", time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, []Upload{{
+		Bucket:    "real-staging",
+		Key:       "uploads/u1/old.cr2",
+		UploadID:  "old",
+		Initiated: time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC),
+		Size:      5242880 + 1024,
+	}}, got)
+}
+
+func TestS3Store_StaleObjects(t *testing.T) {
+	store := newTestStore(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "temp/", r.URL.Query().Get("prefix"))
+		_, _ = fmt.Fprint(w, `<ListBucketResult>
+			<IsTruncated>false</IsTruncated>
+			<Contents><Key>temp/old.jpg</Key><LastModified>2026-10-15T00:00:00Z</LastModified><Size>300</Size></Contents>
+			<Contents><Key>temp/new.jpg</Key><LastModified>2026-10-17T00:00:00Z</LastModified><Size>400</Size></Contents>
+		</ListBucketResult>`)
+	})
+
+	got, err := store.StaleObjects(context.Background(), "real-staging", "temp/", time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, []Object{{
+		Bucket:       "real-staging",
+		Key:          "temp/old.jpg",
+		LastModified: time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC),
+		Size:         300,
+	}}, got)
+}
+
+func TestS3Store_AbortUpload_Missing(t *testing.T) {
+	store := newTestStore(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprint(w, `<Error><Code>NoSuchUpload</Code><Message>gone</Message></Error>`)
+	})
+
+	err := store.AbortUpload(context.Background(), Upload{Bucket: "real-staging", Key: "a", UploadID: "up-1"})
+	assert.NoError(t, err)
+}
@@ -1,43 +1,158 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"net"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
-// Config represents the application configuration.
+// Config represents the application configuration. Fields tagged secret:"true" are
+// redacted by Dump.
 type Config struct {
-	App       App       `yaml:"app"`
-	DB        DB        `yaml:"db"`
-	Job       Job       `yaml:"job"`
-	Logging   Logging   `yaml:"logging"`
-	OTEL      OTEL      `yaml:"otel"`
-	Redis     Redis     `yaml:"redis"`
-	Replicate Replicate `yaml:"replicate"`
-	S3        S3        `yaml:"s3"`
+	App               App               `yaml:"app"`
+	Compaction        Compaction        `yaml:"compaction"`
+	CustomerBuckets   CustomerBuckets   `yaml:"customer_buckets"`
+	DB                DB                `yaml:"db"`
+	Ensemble          Ensemble          `yaml:"ensemble"`
+	FairShare         FairShare         `yaml:"fair_share"`
+	Job               Job               `yaml:"job"`
+	JobArchive        JobArchive        `yaml:"job_archive"`
+	KeepWarm          KeepWarm          `yaml:"keep_warm"`
+	Logging           Logging           `yaml:"logging"`
+	OTEL              OTEL              `yaml:"otel"`
+	Partitions        Partitions        `yaml:"partitions"`
+	PayloadEncryption PayloadEncryption `yaml:"payload_encryption"`
+	PendingBilling    PendingBilling    `yaml:"pending_billing"`
+	Provenance        Provenance        `yaml:"provenance"`
+	Provider          Provider          `yaml:"provider"`
+	Raw               Raw               `yaml:"raw"`
+	Redis             Redis             `yaml:"redis"`
+	Replicate         Replicate         `yaml:"replicate"`
+	Retention         Retention         `yaml:"retention"`
+	S3                S3                `yaml:"s3"`
+	Sandbox           Sandbox           `yaml:"sandbox"`
+	SandboxTenant     SandboxTenant     `yaml:"sandbox_tenant"`
+	SMTP              SMTP              `yaml:"smtp"`
+	Spend             Spend             `yaml:"spend"`
+	TeamWebhookRetry  TeamWebhookRetry  `yaml:"team_webhook_retry"`
+	Warehouse         Warehouse         `yaml:"warehouse"`
+
+	sources []string
 }
 
 type App struct {
 	Env string `yaml:"env" env:"APP_ENV" env-default:"dev"`
 }
 
+// Compaction configures the daily cleanup of abandoned storage in the platform's buckets:
+// multipart uploads never completed and objects under the temporary prefixes, once both
+// are older than MaxAge.
+type Compaction struct {
+	Enabled      bool          `yaml:"enabled" env:"COMPACTION_ENABLED"`
+	MaxAge       time.Duration `yaml:"max_age" env:"COMPACTION_MAX_AGE" env-default:"24h"`
+	RunHourUTC   int           `yaml:"run_hour_utc" env:"COMPACTION_RUN_HOUR_UTC" env-default:"5"`
+	TempPrefixes []string      `yaml:"temp_prefixes" env:"COMPACTION_TEMP_PREFIXES" env-default:"temp/"`
+}
+
+// CustomerBuckets lets staging read originals from, and write outputs to, buckets that
+// accounts configured in the API, through the IAM role each account granted.
+type CustomerBuckets struct {
+	Enabled bool `yaml:"enabled" env:"CUSTOMER_BUCKETS_ENABLED"`
+	// SessionDuration is how long each assumed-role session lasts before it is renewed.
+	SessionDuration time.Duration `yaml:"session_duration" env:"CUSTOMER_BUCKETS_SESSION_DURATION" env-default:"1h"`
+}
+
 type DB struct {
+	URL        string `yaml:"url" env:"DATABASE_URL" secret:"true"` // Full connection URL (takes precedence)
 	PGDatabase string `yaml:"pgdatabase" env:"PGDATABASE" env-default:"realstaging"`
 	PGHost     string `yaml:"pghost" env:"PGHOST" env-default:"localhost"`
-	PGPassword string `yaml:"pgpassword" env:"PGPASSWORD" env-default:"postgres"`
+	PGPassword string `yaml:"pgpassword" env:"PGPASSWORD" env-default:"postgres" secret:"true"`
 	PGPort     int    `yaml:"pgport" env:"PGPORT" env-default:"5432"`
 	PGUser     string `yaml:"pguser" env:"PGUSER" env-default:"postgres"`
 	PGSSLMode  string `yaml:"pgsslmode" env:"PGSSLMODE" env-default:"disable"`
 }
 
+// Ensemble configures the experimental ensemble mode, which stages an image with two
+// model/prompt variants and keeps the output the quality scorer prefers.
+type Ensemble struct {
+	Enabled bool `yaml:"enabled" env:"ENSEMBLE_ENABLED"`
+	// ChallengerModel runs alongside the production model. Empty uses the production model.
+	ChallengerModel string `yaml:"challenger_model" env:"ENSEMBLE_CHALLENGER_MODEL"`
+	// ChallengerPromptSuffix is appended to the challenger's prompt.
+	ChallengerPromptSuffix string `yaml:"challenger_prompt_suffix" env:"ENSEMBLE_CHALLENGER_PROMPT_SUFFIX"`
+	// SampleRate is the fraction of jobs, in [0, 1], that run both variants.
+	SampleRate float64 `yaml:"sample_rate" env:"ENSEMBLE_SAMPLE_RATE" env-default:"1"`
+}
+
+// FairShare shares the worker between users: jobs are run round-robin across users
+// within each priority tier, so one user's large batch cannot starve everyone else.
+type FairShare struct {
+	Enabled bool `yaml:"enabled" env:"FAIR_SHARE_ENABLED" env-default:"true"`
+	// Lookahead is how many tasks beyond job.worker_concurrency the worker takes from the
+	// queue to choose among. Zero uses job.worker_concurrency.
+	Lookahead int `yaml:"lookahead" env:"FAIR_SHARE_LOOKAHEAD"`
+	// MaxPerUser is the most of one user's tasks the worker holds, running or waiting.
+	// Further tasks go back to the queue for DeferDelay. Zero uses job.worker_concurrency.
+	MaxPerUser int `yaml:"max_per_user" env:"FAIR_SHARE_MAX_PER_USER"`
+	// DeferDelay is how long a task over MaxPerUser waits before it is taken again.
+	DeferDelay time.Duration `yaml:"defer_delay" env:"FAIR_SHARE_DEFER_DELAY" env-default:"5s"`
+	// Weights gives users more than one turn per round, e.g. "<user id>:3". Users not
+	// listed get one.
+	Weights map[string]int `yaml:"weights" env:"FAIR_SHARE_WEIGHTS"`
+}
+
 type Job struct {
-	QueueName         string `yaml:"queue_name" env:"JOB_QUEUE_NAME" env-default:"default"`
-	WorkerConcurrency int    `yaml:"worker_concurrency" env:"WORKER_CONCURRENCY" env-default:"5"`
+	// BatchMaxSize is the most images one batch job runs; a full group is run at once.
+	BatchMaxSize int `yaml:"batch_max_size" env:"JOB_BATCH_MAX_SIZE" env-default:"20"`
+	// BatchMaxAttempts, EditMaxAttempts and StageMaxAttempts are how many times an image
+	// of a batch job, a quick edit and a staged image are tried when they fail with a
+	// transient provider or storage error, before the failure is recorded. 1 disables
+	// retries.
+	BatchMaxAttempts int `yaml:"batch_max_attempts" env:"JOB_BATCH_MAX_ATTEMPTS" env-default:"3"`
+	// BatchWindow is how long images the API grouped into a batch are collected before
+	// they run as one batch job. Values under a second use one second.
+	BatchWindow time.Duration `yaml:"batch_window" env:"JOB_BATCH_WINDOW"`
+	// CriticalQueueName holds images the API expedited; it is drained before QueueName.
+	CriticalQueueName string `yaml:"critical_queue_name" env:"JOB_CRITICAL_QUEUE_NAME" env-default:"critical"`
+	EditMaxAttempts   int    `yaml:"edit_max_attempts" env:"JOB_EDIT_MAX_ATTEMPTS" env-default:"2"`
+	// EditQueueName holds quick edits; it is drained before every other queue.
+	EditQueueName string `yaml:"edit_queue_name" env:"JOB_EDIT_QUEUE_NAME" env-default:"edits"`
+	QueueName     string `yaml:"queue_name" env:"JOB_QUEUE_NAME" env-default:"default"`
+	// RetryBaseDelay is the wait before the first retry of a transient error. It doubles,
+	// with jitter, for each retry after that, up to RetryMaxDelay.
+	RetryBaseDelay    time.Duration `yaml:"retry_base_delay" env:"JOB_RETRY_BASE_DELAY" env-default:"2s"`
+	RetryMaxDelay     time.Duration `yaml:"retry_max_delay" env:"JOB_RETRY_MAX_DELAY" env-default:"30s"`
+	StageMaxAttempts  int           `yaml:"stage_max_attempts" env:"JOB_STAGE_MAX_ATTEMPTS" env-default:"3"`
+	WorkerConcurrency int           `yaml:"worker_concurrency" env:"WORKER_CONCURRENCY" env-default:"5"`
+}
+
+// JobArchive configures the daily move of finished jobs into jobs_history.
+type JobArchive struct {
+	AfterDays  int  `yaml:"after_days" env:"JOB_ARCHIVE_AFTER_DAYS" env-default:"30"`
+	BatchSize  int  `yaml:"batch_size" env:"JOB_ARCHIVE_BATCH_SIZE" env-default:"1000"`
+	Enabled    bool `yaml:"enabled" env:"JOB_ARCHIVE_ENABLED"`
+	RunHourUTC int  `yaml:"run_hour_utc" env:"JOB_ARCHIVE_RUN_HOUR_UTC" env-default:"4"`
+}
+
+// KeepWarm configures warm-up predictions that keep the production model from going cold
+// during quiet periods. StartHourUTC and EndHourUTC bound the hours warm-ups run in; equal
+// values run all day. DailyBudgetUSD caps what one worker spends on warm-ups per UTC day.
+type KeepWarm struct {
+	DailyBudgetUSD float64       `yaml:"daily_budget_usd" env:"KEEP_WARM_DAILY_BUDGET_USD" env-default:"1"`
+	Enabled        bool          `yaml:"enabled" env:"KEEP_WARM_ENABLED"`
+	EndHourUTC     int           `yaml:"end_hour_utc" env:"KEEP_WARM_END_HOUR_UTC" env-default:"22"`
+	Interval       time.Duration `yaml:"interval" env:"KEEP_WARM_INTERVAL" env-default:"5m"`
+	StartHourUTC   int           `yaml:"start_hour_utc" env:"KEEP_WARM_START_HOUR_UTC" env-default:"12"`
 }
 
 type Logging struct {
@@ -46,6 +161,69 @@ type Logging struct {
 
 type OTEL struct {
 	ExporterOTLPEndpoint string `yaml:"exporter_otlp_endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	// MetricsInterval is how often the worker exports metrics, such as reclaimed storage.
+	MetricsInterval time.Duration `yaml:"metrics_interval" env:"OTEL_METRICS_INTERVAL" env-default:"30s"`
+}
+
+// PayloadEncryption holds the keys job payloads sealed by the API are opened with. Keys may
+// be injected from a KMS or secret store through PAYLOAD_ENCRYPTION_KEYS.
+type PayloadEncryption struct {
+	// Keys maps key ids to base64-encoded 32-byte AES-256-GCM keys, e.g. "2026-10:<base64>".
+	// List every key the API may still have sealed queued payloads with, including retired ones.
+	Keys map[string]string `yaml:"keys" env:"PAYLOAD_ENCRYPTION_KEYS" secret:"true"`
+}
+
+// PendingBilling configures the retry of Stripe updates parked because their customer was
+// not yet linked to a user.
+type PendingBilling struct {
+	BatchSize int           `yaml:"batch_size" env:"PENDING_BILLING_BATCH_SIZE" env-default:"500"`
+	Enabled   bool          `yaml:"enabled" env:"PENDING_BILLING_ENABLED"`
+	Interval  time.Duration `yaml:"interval" env:"PENDING_BILLING_INTERVAL" env-default:"1h"`
+}
+
+// Provenance configures the C2PA Content Credentials embedded in staged images.
+type Provenance struct {
+	// CertFile and KeyFile are PEM files for an ECDSA P-256 signing identity.
+	// An ephemeral self-signed certificate is generated when either is empty.
+	CertFile       string `yaml:"cert_file" env:"PROVENANCE_CERT_FILE"`
+	ClaimGenerator string `yaml:"claim_generator" env:"PROVENANCE_CLAIM_GENERATOR" env-default:"Real Staging AI"`
+	Enabled        bool   `yaml:"enabled" env:"PROVENANCE_ENABLED" env-default:"true"`
+	KeyFile        string `yaml:"key_file" env:"PROVENANCE_KEY_FILE"`
+}
+
+// Provider configures how many predictions run against the model provider at once.
+type Provider struct {
+	// AdaptiveConcurrency halves the limit on 429s and latency spikes and raises it again
+	// on healthy responses, between MinConcurrency and the job's worker concurrency.
+	// Without it predictions only share the worker concurrency.
+	AdaptiveConcurrency bool `yaml:"adaptive_concurrency" env:"PROVIDER_ADAPTIVE_CONCURRENCY" env-default:"true"`
+	// LatencyThresholdMs is the response time above which a provider response counts as
+	// a spike; 0 only backs off on 429s.
+	LatencyThresholdMs int `yaml:"latency_threshold_ms" env:"PROVIDER_LATENCY_THRESHOLD_MS" env-default:"10000"`
+	MinConcurrency     int `yaml:"min_concurrency" env:"PROVIDER_MIN_CONCURRENCY" env-default:"1"`
+	// RampUpAfter is how many healthy responses in a row raise the limit by one.
+	RampUpAfter int `yaml:"ramp_up_after" env:"PROVIDER_RAMP_UP_AFTER" env-default:"20"`
+}
+
+// Partitions configures maintenance of the monthly images and jobs partitions.
+type Partitions struct {
+	Enabled     bool `yaml:"enabled" env:"PARTITION_MAINTENANCE_ENABLED" env-default:"true"`
+	MonthsAhead int  `yaml:"months_ahead" env:"PARTITION_MONTHS_AHEAD" env-default:"3"`
+	RunHourUTC  int  `yaml:"run_hour_utc" env:"PARTITION_RUN_HOUR_UTC" env-default:"1"`
+}
+
+// Raw configures the conversion of RAW uploads (CR3, NEF, ARW) to JPEG and how the RAW
+// file is archived afterwards.
+type Raw struct {
+	// ArchiveStorageClass is the S3 storage class converted RAW files move to, e.g.
+	// GLACIER_IR. Empty keeps the bucket's default.
+	ArchiveStorageClass string `yaml:"archive_storage_class" env:"RAW_ARCHIVE_STORAGE_CLASS"`
+	// ArchiveTag is the value of the "lifecycle" tag converted RAW files get, for bucket
+	// lifecycle rules to match. Empty leaves them untagged.
+	ArchiveTag string `yaml:"archive_tag" env:"RAW_ARCHIVE_TAG" env-default:"raw-archive"`
+	// DecoderCommand develops RAW files to a PPM on stdout, e.g. "dcraw -c -w -q 3". Empty
+	// uses the full-size JPEG the camera embedded in the file.
+	DecoderCommand string `yaml:"decoder_command" env:"RAW_DECODER_COMMAND"`
 }
 
 type Redis struct {
@@ -53,89 +231,208 @@ type Redis struct {
 }
 
 type Replicate struct {
-	APIToken string `yaml:"api_token" env:"REPLICATE_API_TOKEN"`
+	APIToken string `yaml:"api_token" env:"REPLICATE_API_TOKEN" secret:"true"`
+}
+
+// Retention configures the nightly purge of original uploads past their plan's retention.
+type Retention struct {
+	BatchSize   int  `yaml:"batch_size" env:"RETENTION_BATCH_SIZE" env-default:"500"`
+	Enabled     bool `yaml:"enabled" env:"RETENTION_PURGE_ENABLED"`
+	RunHourUTC  int  `yaml:"run_hour_utc" env:"RETENTION_RUN_HOUR_UTC" env-default:"2"`
+	WarningDays int  `yaml:"warning_days" env:"RETENTION_WARNING_DAYS" env-default:"7"`
 }
 
 type S3 struct {
-	AccessKey      string `yaml:"access_key" env:"S3_ACCESS_KEY"`
-	BucketName     string `yaml:"bucket_name" env:"S3_BUCKET_NAME" env-default:"real-staging"`
+	AccessKey string `yaml:"access_key" env:"S3_ACCESS_KEY" secret:"true"`
+	// BucketName also reads the legacy S3_BUCKET variable, which wins when both are set.
+	BucketName     string `yaml:"bucket_name" env:"S3_BUCKET,S3_BUCKET_NAME" env-default:"real-staging"`
 	Endpoint       string `yaml:"endpoint" env:"S3_ENDPOINT"`
 	PublicEndpoint string `yaml:"public_endpoint" env:"S3_PUBLIC_ENDPOINT"`
 	Region         string `yaml:"region" env:"S3_REGION" env-default:"us-west-1"`
-	SecretKey      string `yaml:"secret_key" env:"S3_SECRET_KEY"`
-	UsePathStyle   bool   `yaml:"use_path_style" env:"S3_USE_PATH_STYLE"`
+	// RegionBuckets maps data-residency regions to their bucket, e.g.
+	// "eu-central-1:real-staging-eu". They share the endpoint and credentials above.
+	RegionBuckets map[string]string `yaml:"region_buckets" env:"S3_REGION_BUCKETS"`
+	SecretKey     string            `yaml:"secret_key" env:"S3_SECRET_KEY" secret:"true"`
+	UsePathStyle  bool              `yaml:"use_path_style" env:"S3_USE_PATH_STYLE"`
 }
 
-// Load loads configuration from YAML files based on APP_ENV.
-// It loads config/shared.yml first, then overlays config/{env}.yml,
-// then apps/worker/secrets.yml (if present).
-// Environment variables take precedence over YAML values.
-func Load() (*Config, error) {
-	cfg := &Config{}
+// Sandbox routes staging away from the production model, for development and testing.
+type Sandbox struct {
+	// Enabled sends every job to the sandbox; without it jobs can still opt in one at a time.
+	Enabled bool `yaml:"enabled" env:"SANDBOX_MODE"`
+	// Model is the cheap/fast model sandbox jobs run on. Empty uses the fake provider,
+	// which returns the original image without calling Replicate.
+	Model string `yaml:"model" env:"SANDBOX_MODEL"`
+}
+
+// SandboxTenant configures a worker for the sandbox tenant, which serves the jobs of requests
+// made with sandbox API keys. Such a worker drains only QueueName, acts on the sandbox
+// database schema, writes outputs under the sandbox/ key prefix and resets the tenant's
+// data each day at ResetHourUTC.
+type SandboxTenant struct {
+	Worker       bool   `yaml:"worker" env:"SANDBOX_TENANT_WORKER"`
+	QueueName    string `yaml:"queue_name" env:"SANDBOX_TENANT_QUEUE_NAME" env-default:"sandbox"`
+	ResetHourUTC int    `yaml:"reset_hour_utc" env:"SANDBOX_TENANT_RESET_HOUR_UTC" env-default:"3"`
+}
+
+// SandboxSchema is the Postgres schema the sandbox tenant's data lives in.
+const SandboxSchema = "sandbox"
+
+// SMTP configures outgoing email. Email is logged instead of sent when Host is empty.
+type SMTP struct {
+	From     string `yaml:"from" env:"SMTP_FROM"`
+	Host     string `yaml:"host" env:"SMTP_HOST"`
+	Password string `yaml:"password" env:"SMTP_PASSWORD" secret:"true"`
+	Port     int    `yaml:"port" env:"SMTP_PORT" env-default:"587"`
+	Username string `yaml:"username" env:"SMTP_USERNAME"`
+}
+
+// Spend caps provider spend.
+type Spend struct {
+	// DailyCeilingUSD is the most spent on predictions per UTC day. Zero disables the ceiling.
+	DailyCeilingUSD float64 `yaml:"daily_ceiling_usd" env:"SPEND_DAILY_CEILING_USD"`
+}
 
-	// Determine environment
+// TeamWebhookRetry configures the retry of team webhook deliveries that failed.
+type TeamWebhookRetry struct {
+	BatchSize int           `yaml:"batch_size" env:"TEAM_WEBHOOK_RETRY_BATCH_SIZE" env-default:"100"`
+	Enabled   bool          `yaml:"enabled" env:"TEAM_WEBHOOK_RETRY_ENABLED" env-default:"true"`
+	Interval  time.Duration `yaml:"interval" env:"TEAM_WEBHOOK_RETRY_INTERVAL" env-default:"1m"`
+}
+
+// Warehouse configures the nightly Parquet export used by the data warehouse.
+type Warehouse struct {
+	// Bucket defaults to the S3 bucket used for images when empty.
+	Bucket         string `yaml:"bucket" env:"WAREHOUSE_BUCKET"`
+	Enabled        bool   `yaml:"enabled" env:"WAREHOUSE_EXPORT_ENABLED"`
+	MaxRowsPerFile int    `yaml:"max_rows_per_file" env:"WAREHOUSE_MAX_ROWS_PER_FILE" env-default:"250000"`
+	Prefix         string `yaml:"prefix" env:"WAREHOUSE_PREFIX" env-default:"warehouse"`
+	RunHourUTC     int    `yaml:"run_hour_utc" env:"WAREHOUSE_RUN_HOUR_UTC" env-default:"3"`
+}
+
+// Config layers, lowest precedence first. Environment variables override all of them.
+const (
+	baseFile    = "shared.yml"  // Shared by every environment, in CONFIG_DIR
+	secretsFile = "secrets.yml" // Per-app secrets, in the working directory
+)
+
+// LayerFiles returns the YAML files Load reads for env, lowest precedence first: the shared
+// base and the env overlay in configDir, then the app's secrets.yml.
+func LayerFiles(configDir, env string) []string {
+	return []string{
+		filepath.Join(configDir, baseFile),
+		filepath.Join(configDir, env+".yml"),
+		secretsFile,
+	}
+}
+
+// Load loads the configuration layers for APP_ENV (default "dev") from CONFIG_DIR
+// (default "config"), in the precedence order described by LayerFiles and LoadFrom.
+func Load() (*Config, error) {
 	env := os.Getenv("APP_ENV")
 	if env == "" {
 		env = "dev"
 	}
-
-	// Determine config directory (project root /config)
 	configDir := os.Getenv("CONFIG_DIR")
 	if configDir == "" {
-		// Default to config/ relative to project root
 		configDir = "config"
 	}
+	return LoadFrom(LayerFiles(configDir, env)...)
+}
 
-	// Load shared config first
-	sharedPath := filepath.Join(configDir, "shared.yml")
-	if _, err := os.Stat(sharedPath); err == nil {
-		if err := cleanenv.ReadConfig(sharedPath, cfg); err != nil {
-			return nil, fmt.Errorf("failed to read shared config: %w", err)
-		}
-	}
-
-	// Load environment-specific config
-	envPath := filepath.Join(configDir, fmt.Sprintf("%s.yml", env))
-	if _, err := os.Stat(envPath); err == nil {
-		if err := cleanenv.ReadConfig(envPath, cfg); err != nil {
-			return nil, fmt.Errorf("failed to read %s config: %w", env, err)
+// LoadFrom reads files in order, each overriding the ones before it, then applies
+// environment variables, which take precedence over every file. Missing files are skipped.
+func LoadFrom(files ...string) (*Config, error) {
+	cfg := &Config{}
+	for _, path := range files {
+		if _, err := os.Stat(path); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read config %s: %w", path, err)
 		}
-	}
-
-	// Load app-specific secrets (if present)
-	secretsPath := "secrets.yml"
-	if _, err := os.Stat(secretsPath); err == nil {
-		if err := cleanenv.ReadConfig(secretsPath, cfg); err != nil {
-			return nil, fmt.Errorf("failed to read secrets: %w", err)
+		if err := readFile(path, cfg); err != nil {
+			return nil, fmt.Errorf("failed to read config %s: %w", path, err)
 		}
+		cfg.sources = append(cfg.sources, path)
 	}
 
-	// Read environment variables (these override YAML values)
 	if err := cleanenv.ReadEnv(cfg); err != nil {
 		return nil, fmt.Errorf("failed to read environment variables: %w", err)
 	}
-
+	cfg.sources = append(cfg.sources, SourceEnv)
 	return cfg, nil
 }
 
+// placeholder matches ${NAME} and ${NAME:default} in config files.
+var placeholder = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::([^}]*))?\}`)
+
+// readFile parses a YAML layer into cfg after replacing each placeholder with the named
+// environment variable, or its default when the variable is unset or empty.
+func readFile(path string, cfg *Config) error {
+	// #nosec G304 -- Config layers come from CONFIG_DIR and the app directory
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	expanded := placeholder.ReplaceAllStringFunc(string(data), func(m string) string {
+		sub := placeholder.FindStringSubmatch(m)
+		if v := os.Getenv(sub[1]); v != "" {
+			return v
+		}
+		return sub[2]
+	})
+	return cleanenv.ParseYAML(strings.NewReader(expanded), cfg)
+}
+
+// FromEnv builds a Config from environment variables and defaults alone, without reading
+// any files. Tests and tools that run outside the repository use it.
+func FromEnv() (*Config, error) {
+	return LoadFrom()
+}
+
+// SourceEnv marks environment variables in Sources.
+const SourceEnv = "env"
+
+// Sources lists the layers cfg was loaded from, lowest precedence first. Files that did not
+// exist are left out; SourceEnv is always last.
+func (c *Config) Sources() []string {
+	return c.sources
+}
+
 // DatabaseURL constructs and returns the full PostgreSQL connection URL.
 func (c *Config) DatabaseURL() string {
-	// Check if DATABASE_URL is set in environment
-	if dbURL := os.Getenv("DATABASE_URL"); dbURL != "" {
+	// Use URL if set, otherwise construct from individual components
+	dbURL := c.DB.URL
+	if dbURL == "" {
+		hostPort := net.JoinHostPort(c.DB.PGHost, strconv.Itoa(c.DB.PGPort))
+		dbURL = fmt.Sprintf("postgres://%s:%s@%s/%s?sslmode=%s",
+			c.DB.PGUser, c.DB.PGPassword, hostPort, c.DB.PGDatabase, c.DB.PGSSLMode)
+	}
+	if !c.SandboxTenant.Worker {
 		return dbURL
 	}
 
-	// Construct from individual components
-	hostPort := net.JoinHostPort(c.DB.PGHost, strconv.Itoa(c.DB.PGPort))
-	return fmt.Sprintf("postgres://%s:%s@%s/%s?sslmode=%s",
-		c.DB.PGUser, c.DB.PGPassword, hostPort, c.DB.PGDatabase, c.DB.PGSSLMode)
+	// The sandbox tenant's tables shadow production's; tables it lacks fall through to public
+	u, err := url.Parse(dbURL)
+	if err != nil {
+		return dbURL
+	}
+	q := u.Query()
+	q.Set("search_path", SandboxSchema+",public")
+	u.RawQuery = q.Encode()
+	return u.String()
 }
 
-// S3Bucket returns the S3 bucket name, supporting legacy S3_BUCKET env var.
+// S3Bucket returns the S3 bucket name.
 func (c *Config) S3Bucket() string {
-	// Support legacy S3_BUCKET environment variable
-	if bucket := os.Getenv("S3_BUCKET"); bucket != "" {
-		return bucket
-	}
 	return c.S3.BucketName
 }
+
+// WarehouseBucket returns the bucket for warehouse exports, falling back to the image bucket.
+func (c *Config) WarehouseBucket() string {
+	if c.Warehouse.Bucket != "" {
+		return c.Warehouse.Bucket
+	}
+	return c.S3Bucket()
+}
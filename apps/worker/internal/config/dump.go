@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Redacted replaces the value of a non-empty secret in Dump output.
+const Redacted = "[REDACTED]"
+
+// Dump writes the effective configuration to w as YAML, in field order, preceded by a
+// comment listing the layers it was loaded from. With redacted set, every non-empty field
+// tagged secret:"true" is replaced by Redacted, so the output is safe to paste into a ticket.
+func (c *Config) Dump(w io.Writer, redacted bool) error {
+	if sources := c.Sources(); len(sources) > 0 {
+		_, err := fmt.Fprintf(w, "# Layers (lowest precedence first): %s\n", strings.Join(sources, ", "))
+		if err != nil {
+			return err
+		}
+	}
+	out, err := yaml.Marshal(dumpStruct(reflect.ValueOf(*c), redacted))
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	_, err = w.Write(out)
+	return err
+}
+
+// dumpStruct converts a config struct to an ordered YAML map keyed by yaml tags.
+func dumpStruct(v reflect.Value, redacted bool) yaml.MapSlice {
+	t := v.Type()
+	out := make(yaml.MapSlice, 0, t.NumField())
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if !field.IsExported() || name == "" || name == "-" {
+			continue
+		}
+		secret := redacted && field.Tag.Get("secret") == "true"
+		out = append(out, yaml.MapItem{Key: name, Value: dumpValue(v.Field(i), secret, redacted)})
+	}
+	return out
+}
+
+func dumpValue(v reflect.Value, secret, redacted bool) any {
+	if secret && !v.IsZero() {
+		if v.Kind() != reflect.Map {
+			return Redacted
+		}
+		// Keep the key ids of secret maps, such as encryption keyrings, visible.
+		keys := make([]string, 0, v.Len())
+		for _, k := range v.MapKeys() {
+			keys = append(keys, fmt.Sprint(k.Interface()))
+		}
+		slices.Sort(keys)
+		items := make(yaml.MapSlice, 0, len(keys))
+		for _, k := range keys {
+			items = append(items, yaml.MapItem{Key: k, Value: Redacted})
+		}
+		return items
+	}
+	if d, ok := v.Interface().(time.Duration); ok {
+		return d.String()
+	}
+	if v.Kind() == reflect.Struct {
+		return dumpStruct(v, redacted)
+	}
+	return v.Interface()
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Dump(t *testing.T) {
+	cfg := &Config{
+		Job:               Job{BatchWindow: 1500 * time.Millisecond},
+		PayloadEncryption: PayloadEncryption{Keys: map[string]string{"k1": "b2xk"}},
+		Replicate:         Replicate{APIToken: "r8_secret"},
+		S3:                S3{BucketName: "bucket"},
+		sources:           []string{"config/shared.yml", SourceEnv},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, cfg.Dump(&buf, true))
+	out := buf.String()
+
+	assert.Contains(t, out, "# Layers (lowest precedence first): config/shared.yml, env\n")
+	assert.NotContains(t, out, "r8_secret")
+	assert.NotContains(t, out, "b2xk")
+	assert.Contains(t, out, "api_token: '[REDACTED]'")
+	assert.Contains(t, out, "    k1: '[REDACTED]'")
+	assert.Contains(t, out, "batch_window: 1.5s")
+	assert.Contains(t, out, "bucket_name: bucket")
+
+	buf.Reset()
+	require.NoError(t, cfg.Dump(&buf, false))
+	assert.Contains(t, buf.String(), "api_token: r8_secret")
+}
@@ -0,0 +1,82 @@
+package costguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	redis "github.com/redis/go-redis/v9"
+
+	"github.com/real-staging-ai/worker/internal/chaos"
+	"github.com/real-staging-ai/worker/internal/config"
+)
+
+// microsPerUSD converts USD to the integer micro-dollars stored in Redis, so concurrent
+// reservations add up exactly.
+const microsPerUSD = 1_000_000
+
+// keyTTL keeps a day's counter around a little past the day for inspection.
+const keyTTL = 48 * time.Hour
+
+// reserveScript adds ARGV[1] to the day's spend unless that would exceed the ceiling
+// ARGV[2], returning -1 in that case. Checking and adding in one script keeps workers
+// running in parallel from overshooting the ceiling together.
+var reserveScript = redis.NewScript(`
+local spent = tonumber(redis.call("GET", KEYS[1]) or "0")
+if spent + tonumber(ARGV[1]) > tonumber(ARGV[2]) then
+  return -1
+end
+local total = redis.call("INCRBY", KEYS[1], ARGV[1])
+redis.call("EXPIRE", KEYS[1], ARGV[3])
+return total`)
+
+// NewDefaultGuard returns a Redis-backed guard for cfg.Spend.DailyCeilingUSD if cfg.Redis.Addr is set.
+func NewDefaultGuard(cfg *config.Config) (Guard, error) {
+	if cfg == nil {
+		return nil, errors.New("config is nil")
+	}
+
+	if cfg.Redis.Addr == "" {
+		return nil, errors.New("redis address is not configured. Set REDIS_ADDR or redis.addr")
+	}
+	rdb := redis.NewClient(&redis.Options{Addr: cfg.Redis.Addr})
+	inj, err := chaos.FromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if inj != nil {
+		rdb.AddHook(inj.RedisHook())
+	}
+	return NewDefaultGuardWithClient(rdb, cfg.Spend.DailyCeilingUSD), nil
+}
+
+// NewDefaultGuardWithClient constructs a guard with a provided redis client.
+func NewDefaultGuardWithClient(rdb *redis.Client, ceilingUSD float64) Guard {
+	return &defaultRedisGuard{rdb: rdb, ceiling: ceilingUSD, now: time.Now}
+}
+
+type defaultRedisGuard struct {
+	rdb     *redis.Client
+	ceiling float64
+	now     func() time.Time
+}
+
+func (g *defaultRedisGuard) Reserve(ctx context.Context, cost float64) error {
+	if cost <= 0 {
+		return nil
+	}
+	now := g.now()
+	amount := int64(math.Round(cost * microsPerUSD))
+	ceiling := int64(math.Round(g.ceiling * microsPerUSD))
+
+	total, err := reserveScript.Run(ctx, g.rdb, []string{Key(now)}, amount, ceiling, int(keyTTL.Seconds())).Int64()
+	if err != nil {
+		return fmt.Errorf("reserve spend: %w", err)
+	}
+	if total < 0 {
+		return &CeilingError{Ceiling: g.ceiling, ResumeAt: NextDay(now)}
+	}
+	return nil
+}
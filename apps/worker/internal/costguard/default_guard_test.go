@@ -0,0 +1,79 @@
+package costguard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/worker/internal/config"
+)
+
+func TestNewDefaultGuard(t *testing.T) {
+	t.Run("fail: nil config", func(t *testing.T) {
+		_, err := NewDefaultGuard(nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("fail: no redis address", func(t *testing.T) {
+		t.Setenv("REDIS_ADDR", "localhost:6379")
+		_, err := NewDefaultGuard(&config.Config{})
+		assert.Error(t, err)
+	})
+
+	t.Run("success: address from config", func(t *testing.T) {
+		cfg := &config.Config{}
+		cfg.Redis.Addr = "localhost:6379"
+		cfg.Spend.DailyCeilingUSD = 10
+		g, err := NewDefaultGuard(cfg)
+		require.NoError(t, err)
+		assert.NotNil(t, g)
+	})
+}
+
+func TestDefaultGuard_Reserve(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	now := time.Date(2025, 3, 4, 22, 30, 0, 0, time.UTC)
+	g := NewDefaultGuardWithClient(rdb, 0.20).(*defaultRedisGuard)
+	g.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// 0.08 + 0.08 fits under 0.20; a third would not.
+	require.NoError(t, g.Reserve(ctx, 0.08))
+	require.NoError(t, g.Reserve(ctx, 0.08))
+
+	err := g.Reserve(ctx, 0.08)
+	var ceilErr *CeilingError
+	require.ErrorAs(t, err, &ceilErr)
+	assert.Equal(t, 0.20, ceilErr.Ceiling)
+	assert.Equal(t, time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC), ceilErr.ResumeAt)
+
+	// A refused reservation is not counted, so a cheaper job still fits.
+	require.NoError(t, g.Reserve(ctx, 0.03))
+	spent, err := mr.Get(Key(now))
+	require.NoError(t, err)
+	assert.Equal(t, "190000", spent)
+	assert.Greater(t, mr.TTL(Key(now)), 24*time.Hour)
+
+	// Free predictions are never refused.
+	assert.NoError(t, g.Reserve(ctx, 0))
+
+	// The next day starts from zero.
+	now = now.Add(2 * time.Hour)
+	assert.NoError(t, g.Reserve(ctx, 0.08))
+
+	t.Run("fail: redis unavailable", func(t *testing.T) {
+		bad := NewDefaultGuardWithClient(redis.NewClient(&redis.Options{
+			Addr:        "127.0.0.1:6392",
+			DialTimeout: 50 * time.Millisecond,
+		}), 1)
+		err := bad.Reserve(ctx, 0.08)
+		assert.Error(t, err)
+		assert.NotErrorAs(t, err, &ceilErr)
+	})
+}
@@ -0,0 +1,45 @@
+// Package costguard caps how much the worker spends on the model provider per day.
+// Jobs that would push the day's spend past the ceiling are held until the next UTC day
+// instead of running, so a runaway queue cannot run up an unbounded provider bill.
+package costguard
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out guard_mock.go . Guard
+
+// KeyPrefix prefixes the Redis key holding a day's spend.
+const KeyPrefix = "spend:daily:"
+
+// Key returns the Redis key holding the spend for day's UTC date.
+func Key(day time.Time) string {
+	return KeyPrefix + day.UTC().Format(time.DateOnly)
+}
+
+// Guard tracks provider spend against a daily ceiling.
+type Guard interface {
+	// Reserve counts cost (in USD) against today's spend before a prediction starts.
+	// It returns a *CeilingError, and counts nothing, when cost would exceed the ceiling.
+	Reserve(ctx context.Context, cost float64) error
+}
+
+// CeilingError reports that the daily spend ceiling has been reached.
+type CeilingError struct {
+	// Ceiling is the configured daily ceiling in USD.
+	Ceiling float64
+	// ResumeAt is when the ceiling resets: the start of the next UTC day.
+	ResumeAt time.Time
+}
+
+func (e *CeilingError) Error() string {
+	return fmt.Sprintf("daily spend ceiling of $%.2f reached; resuming at %s", e.Ceiling, e.ResumeAt.Format(time.RFC3339))
+}
+
+// NextDay returns the start of the UTC day after t.
+func NextDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,100 @@
+// Package dbretry retries database writes that failed for a transient reason, such as a
+// serialization failure or a connection reset during a failover. Only wrap writes that are
+// idempotent: a write whose commit was lost with the connection may run twice.
+package dbretry
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+)
+
+// Policy bounds how often and how far apart a write is retried.
+type Policy struct {
+	// Attempts is the total number of tries, including the first; at least 1.
+	Attempts int
+	// BaseDelay is the wait before the first retry. It doubles for each retry after that.
+	BaseDelay time.Duration
+	// MaxDelay caps the wait between tries.
+	MaxDelay time.Duration
+}
+
+// DefaultPolicy rides out a typical Postgres failover (a few seconds) without holding a
+// job for long when the database is really down.
+var DefaultPolicy = Policy{Attempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: 2 * time.Second}
+
+// Do runs fn under DefaultPolicy.
+func Do(ctx context.Context, op string, fn func(ctx context.Context) error) error {
+	return DefaultPolicy.Do(ctx, op, fn)
+}
+
+// Do runs fn until it succeeds, fails with an error that is not transient, runs out of
+// attempts, or ctx ends. Waits between tries grow exponentially, with jitter. op
+// names the write in logs. The last error is returned.
+func (p Policy) Do(ctx context.Context, op string, fn func(ctx context.Context) error) error {
+	attempts := max(p.Attempts, 1)
+	delay := p.BaseDelay
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn(ctx)
+		if err == nil || attempt >= attempts || !IsTransient(err) {
+			return err
+		}
+
+		wait := delay
+		if wait > 0 {
+			wait = rand.N(wait) + wait/2
+		}
+		logging.Default().Warn(ctx, "transient database error; retrying",
+			"op", op, "attempt", attempt, "retry_in", wait.String(), "error", err)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		delay = min(delay*2, p.MaxDelay)
+	}
+}
+
+// transientStates are the Postgres SQLSTATEs worth retrying. Classes 08 (connection
+// exception) and 57P (operator intervention, e.g. a shutdown during failover) are matched
+// by prefix.
+var transientStates = map[pq.ErrorCode]bool{
+	"40001": true, // serialization_failure
+	"40P01": true, // deadlock_detected
+	"55P03": true, // lock_not_available
+	"53300": true, // too_many_connections
+}
+
+// IsTransient reports whether err is a database error that may succeed when retried.
+// Context cancellation and deadlines are never transient.
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		code := string(pqErr.Code)
+		return transientStates[pqErr.Code] || strings.HasPrefix(code, "08") || strings.HasPrefix(code, "57P")
+	}
+
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var netErr *net.OpError
+	return errors.As(err, &netErr)
+}
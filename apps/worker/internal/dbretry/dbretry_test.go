@@ -0,0 +1,93 @@
+package dbretry
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "success: serialization failure", err: &pq.Error{Code: "40001"}, want: true},
+		{name: "success: deadlock", err: &pq.Error{Code: "40P01"}, want: true},
+		{name: "success: connection failure", err: &pq.Error{Code: "08006"}, want: true},
+		{name: "success: admin shutdown", err: &pq.Error{Code: "57P01"}, want: true},
+		{name: "success: bad connection", err: fmt.Errorf("exec: %w", driver.ErrBadConn), want: true},
+		{name: "success: unexpected EOF", err: io.ErrUnexpectedEOF, want: true},
+		{name: "success: connection reset", err: &net.OpError{Op: "read", Err: syscall.ECONNRESET}, want: true},
+		{name: "fail: nil", err: nil},
+		{name: "fail: unique violation", err: &pq.Error{Code: "23505"}},
+		{name: "fail: syntax error", err: &pq.Error{Code: "42601"}},
+		{name: "fail: canceled", err: context.Canceled},
+		{name: "fail: deadline", err: fmt.Errorf("exec: %w", context.DeadlineExceeded)},
+		{name: "fail: plain error", err: errors.New("boom")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsTransient(tt.err))
+		})
+	}
+}
+
+func TestPolicy_Do(t *testing.T) {
+	transient := &pq.Error{Code: "40001"}
+	permanent := errors.New("boom")
+
+	tests := []struct {
+		name      string
+		errs      []error
+		wantCalls int
+		wantErr   error
+	}{
+		{name: "success: first try", wantCalls: 1},
+		{name: "success: after transient errors", errs: []error{transient, transient}, wantCalls: 3},
+		{name: "fail: permanent error is not retried", errs: []error{permanent}, wantCalls: 1, wantErr: permanent},
+		{
+			name:      "fail: gives up after the last attempt",
+			errs:      []error{transient, transient, transient, transient},
+			wantCalls: 3,
+			wantErr:   transient,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Policy{Attempts: 3, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond}
+			calls := 0
+			err := p.Do(context.Background(), "test", func(ctx context.Context) error {
+				calls++
+				if calls <= len(tt.errs) {
+					return tt.errs[calls-1]
+				}
+				return nil
+			})
+			assert.Equal(t, tt.wantCalls, calls)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+
+	t.Run("fail: context canceled while waiting", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		p := Policy{Attempts: 5, BaseDelay: time.Hour}
+		calls := 0
+		err := p.Do(ctx, "test", func(ctx context.Context) error {
+			calls++
+			cancel()
+			return transient
+		})
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, transient, err)
+	})
+}
@@ -0,0 +1,47 @@
+// Package disclosure renders the "virtually staged" banner that some
+// real-estate advertising rules require on staged photos.
+package disclosure
+
+import "strings"
+
+// Banner positions, mirroring the API's project disclosure settings.
+const (
+	PositionTopLeft      = "top_left"
+	PositionTopCenter    = "top_center"
+	PositionTopRight     = "top_right"
+	PositionBottomLeft   = "bottom_left"
+	PositionBottomCenter = "bottom_center"
+	PositionBottomRight  = "bottom_right"
+)
+
+// defaultTexts is the banner text per base language.
+var defaultTexts = map[string]string{
+	"de": "Virtuell eingerichtet",
+	"en": "Virtually Staged",
+	"es": "Amueblado virtualmente",
+	"fr": "Meublé virtuellement",
+	"it": "Arredato virtualmente",
+	"pt": "Mobiliado virtualmente",
+}
+
+// Settings describes the banner for one project.
+type Settings struct {
+	Locale   string
+	Text     string
+	Position string
+	// Opacity of the banner background from 0 to 1; text is always opaque.
+	Opacity float64
+}
+
+// Label returns the custom text, or the default text for the locale. Regional
+// locales such as es-MX fall back to their base language, then to English.
+func (s Settings) Label() string {
+	if s.Text != "" {
+		return s.Text
+	}
+	base, _, _ := strings.Cut(strings.ToLower(strings.ReplaceAll(s.Locale, "_", "-")), "-")
+	if text, ok := defaultTexts[base]; ok {
+		return text
+	}
+	return defaultTexts["en"]
+}
@@ -0,0 +1,191 @@
+package disclosure
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettings_Label(t *testing.T) {
+	testCases := []struct {
+		name     string
+		settings Settings
+		want     string
+	}{
+		{name: "success: english default", settings: Settings{Locale: "en"}, want: "Virtually Staged"},
+		{name: "success: regional locale uses base language", settings: Settings{Locale: "es-MX"}, want: "Amueblado virtualmente"},
+		{name: "success: underscore locale", settings: Settings{Locale: "fr_CA"}, want: "Meublé virtuellement"},
+		{name: "success: unknown locale falls back to english", settings: Settings{Locale: "nl"}, want: "Virtually Staged"},
+		{name: "success: custom text wins", settings: Settings{Locale: "de", Text: "Digital möbliert"}, want: "Digital möbliert"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.settings.Label())
+		})
+	}
+}
+
+func TestPlace(t *testing.T) {
+	bounds := image.Rect(0, 0, 400, 300)
+	size := image.Pt(100, 20)
+
+	testCases := []struct {
+		position string
+		want     image.Rectangle
+	}{
+		{position: PositionTopLeft, want: image.Rect(10, 10, 110, 30)},
+		{position: PositionTopCenter, want: image.Rect(150, 10, 250, 30)},
+		{position: PositionTopRight, want: image.Rect(290, 10, 390, 30)},
+		{position: PositionBottomLeft, want: image.Rect(10, 270, 110, 290)},
+		{position: PositionBottomCenter, want: image.Rect(150, 270, 250, 290)},
+		{position: PositionBottomRight, want: image.Rect(290, 270, 390, 290)},
+		{position: "", want: image.Rect(290, 270, 390, 290)},
+	}
+
+	for _, tc := range testCases {
+		t.Run("success: "+tc.position, func(t *testing.T) {
+			assert.Equal(t, tc.want, place(bounds, size, tc.position, 10))
+		})
+	}
+}
+
+func grayPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := range w {
+		for y := range h {
+			img.Set(x, y, color.RGBA{R: 128, G: 128, B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// changed reports whether any pixel in r differs noticeably from mid-gray.
+func changed(img image.Image, r image.Rectangle) bool {
+	for x := r.Min.X; x < r.Max.X; x++ {
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			c := color.GrayModel.Convert(img.At(x, y)).(color.Gray)
+			if c.Y < 110 || c.Y > 146 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestRender(t *testing.T) {
+	src := grayPNG(t, 600, 400)
+	topLeft := image.Rect(0, 0, 300, 100)
+	bottomRight := image.Rect(300, 300, 600, 400)
+
+	testCases := []struct {
+		name        string
+		img         []byte
+		settings    Settings
+		wantChanged image.Rectangle
+		wantSame    image.Rectangle
+		wantErr     bool
+	}{
+		{
+			name:        "success: bottom right",
+			img:         src,
+			settings:    Settings{Locale: "en", Position: PositionBottomRight, Opacity: 0.6},
+			wantChanged: bottomRight,
+			wantSame:    topLeft,
+		},
+		{
+			name:        "success: top left with long custom text is shrunk to fit",
+			img:         src,
+			settings:    Settings{Text: "Virtually staged image: furniture shown is not included in the sale", Position: PositionTopLeft},
+			wantChanged: topLeft,
+			wantSame:    bottomRight,
+		},
+		{
+			name:    "fail: not an image",
+			img:     []byte("nope"),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := Render(tc.img, tc.settings)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			decoded, format, err := image.Decode(bytes.NewReader(out))
+			require.NoError(t, err)
+			assert.Equal(t, "jpeg", format)
+			assert.Equal(t, image.Rect(0, 0, 600, 400), decoded.Bounds())
+			assert.True(t, changed(decoded, tc.wantChanged), "banner not drawn")
+			assert.False(t, changed(decoded, tc.wantSame), "unexpected change outside banner")
+		})
+	}
+}
+
+func TestSQLRepository_ForImage(t *testing.T) {
+	imageID := "3f2a5d1e-0000-0000-0000-000000000001"
+
+	testCases := []struct {
+		name    string
+		setup   func(mock sqlmock.Sqlmock)
+		want    *Settings
+		wantErr bool
+	}{
+		{
+			name: "success: enabled banner",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM images i\s+JOIN project_disclosures d`).WithArgs(imageID).
+					WillReturnRows(sqlmock.NewRows([]string{"locale", "text", "position", "opacity"}).
+						AddRow("es", "", PositionTopLeft, 0.5))
+			},
+			want: &Settings{Locale: "es", Position: PositionTopLeft, Opacity: 0.5},
+		},
+		{
+			name: "success: no banner",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM images i`).WithArgs(imageID).WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name: "fail: query error",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM images i`).WithArgs(imageID).WillReturnError(errors.New("db down"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+			tc.setup(mock)
+
+			got, err := NewSQLRepository(db).ForImage(context.Background(), imageID)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package disclosure
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png" // register decoder for model outputs
+	"math"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	_ "golang.org/x/image/webp" // register decoder for model outputs
+)
+
+const (
+	jpegQuality = 92
+
+	// textScale sizes the text relative to the image's shorter edge.
+	textScale = 1.0 / 22
+	// minFontSize keeps the banner legible on thumbnails.
+	minFontSize = 10
+)
+
+var goRegular = sync.OnceValues(func() (*opentype.Font, error) {
+	return opentype.Parse(goregular.TTF)
+})
+
+// Render draws the banner described by s onto img and returns the result as a JPEG.
+func Render(img []byte, s Settings) ([]byte, error) {
+	src, _, err := image.Decode(bytes.NewReader(img))
+	if err != nil {
+		return nil, fmt.Errorf("decode image: %w", err)
+	}
+	b := src.Bounds()
+	dst := image.NewRGBA(b)
+	draw.Draw(dst, b, src, b.Min, draw.Src)
+
+	f, err := goRegular()
+	if err != nil {
+		return nil, fmt.Errorf("load font: %w", err)
+	}
+
+	label := s.Label()
+	size := math.Max(float64(min(b.Dx(), b.Dy()))*textScale, minFontSize)
+	margin := int(size / 2)
+	face, textW, err := fitFace(f, label, size, b.Dx()-4*margin)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = face.Close() }()
+
+	metrics := face.Metrics()
+	pad := margin
+	bannerSize := image.Pt(textW+2*pad, (metrics.Ascent+metrics.Descent).Ceil()+pad)
+	banner := place(b, bannerSize, s.Position, margin)
+
+	alpha := uint8(math.Round(math.Min(math.Max(s.Opacity, 0), 1) * 255))
+	draw.Draw(dst, banner, image.NewUniform(color.NRGBA{A: alpha}), image.Point{}, draw.Over)
+
+	d := font.Drawer{
+		Dst:  dst,
+		Src:  image.White,
+		Face: face,
+		Dot:  fixed.P(banner.Min.X+pad, banner.Min.Y+pad/2+metrics.Ascent.Ceil()),
+	}
+	d.DrawString(label)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, fmt.Errorf("encode jpeg: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// fitFace returns a face at size, shrunk if needed so label fits within maxWidth.
+func fitFace(f *opentype.Font, label string, size float64, maxWidth int) (font.Face, int, error) {
+	face, err := newFace(f, size)
+	if err != nil {
+		return nil, 0, err
+	}
+	width := font.MeasureString(face, label).Ceil()
+	if width <= maxWidth || width == 0 {
+		return face, width, nil
+	}
+
+	_ = face.Close()
+	size = math.Max(size*float64(maxWidth)/float64(width), minFontSize)
+	if face, err = newFace(f, size); err != nil {
+		return nil, 0, err
+	}
+	return face, font.MeasureString(face, label).Ceil(), nil
+}
+
+func newFace(f *opentype.Font, size float64) (font.Face, error) {
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, fmt.Errorf("create font face: %w", err)
+	}
+	return face, nil
+}
+
+// place positions a banner of the given size inside bounds.
+func place(bounds image.Rectangle, size image.Point, position string, margin int) image.Rectangle {
+	x := bounds.Max.X - margin - size.X
+	y := bounds.Max.Y - margin - size.Y
+
+	switch position {
+	case PositionTopLeft, PositionBottomLeft:
+		x = bounds.Min.X + margin
+	case PositionTopCenter, PositionBottomCenter:
+		x = bounds.Min.X + (bounds.Dx()-size.X)/2
+	}
+	switch position {
+	case PositionTopLeft, PositionTopCenter, PositionTopRight:
+		y = bounds.Min.Y + margin
+	}
+
+	return image.Rectangle{Min: image.Pt(x, y), Max: image.Pt(x+size.X, y+size.Y)}.Intersect(bounds)
+}
@@ -0,0 +1,49 @@
+package disclosure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository looks up the disclosure banner for an image's project.
+type Repository interface {
+	// ForImage returns the banner settings for the image's project, or nil when
+	// the project has no banner enabled.
+	ForImage(ctx context.Context, imageID string) (*Settings, error)
+}
+
+// SQLRepository reads project_disclosures with database/sql.
+type SQLRepository struct {
+	db *sql.DB
+}
+
+// Ensure SQLRepository implements Repository.
+var _ Repository = (*SQLRepository)(nil)
+
+// NewSQLRepository creates a SQLRepository.
+func NewSQLRepository(db *sql.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+// ForImage returns the enabled banner settings for the image's project.
+func (r *SQLRepository) ForImage(ctx context.Context, imageID string) (*Settings, error) {
+	const q = `
+		SELECT d.locale, COALESCE(d.text, ''), d.position, d.opacity
+		FROM images i
+		JOIN project_disclosures d ON d.project_id = i.project_id
+		WHERE i.id = $1::uuid AND d.enabled
+	`
+	var s Settings
+	err := r.db.QueryRowContext(ctx, q, imageID).Scan(&s.Locale, &s.Text, &s.Position, &s.Opacity)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get project disclosure: %w", err)
+	}
+	return &s, nil
+}
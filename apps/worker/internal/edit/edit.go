@@ -0,0 +1,67 @@
+// Package edit records the progress of quick edits, such as object erasure, on the
+// image_edits rows the API created for them.
+package edit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/real-staging-ai/worker/internal/dbretry"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository moves an edit through its statuses.
+type Repository interface {
+	// MarkProcessing records that a worker has started on the edit.
+	MarkProcessing(ctx context.Context, editID string) error
+	// MarkReady records the s3:// URL of the edit's result.
+	MarkReady(ctx context.Context, editID, resultURL string) error
+	// MarkError records why the edit failed.
+	MarkError(ctx context.Context, editID, message string) error
+}
+
+// SQLRepository updates image_edits with database/sql.
+type SQLRepository struct {
+	db *sql.DB
+}
+
+// Ensure SQLRepository implements Repository.
+var _ Repository = (*SQLRepository)(nil)
+
+// NewSQLRepository creates a SQLRepository.
+func NewSQLRepository(db *sql.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+// MarkProcessing sets the edit's status to processing.
+func (r *SQLRepository) MarkProcessing(ctx context.Context, editID string) error {
+	q := `UPDATE image_edits SET status = 'processing', updated_at = now() WHERE id = $1::uuid`
+	return r.exec(ctx, "mark edit processing", q, editID)
+}
+
+// MarkReady sets the edit's status to ready with its result.
+func (r *SQLRepository) MarkReady(ctx context.Context, editID, resultURL string) error {
+	q := `UPDATE image_edits SET status = 'ready', result_url = $2, error = NULL, updated_at = now()
+		WHERE id = $1::uuid`
+	return r.exec(ctx, "mark edit ready", q, editID, resultURL)
+}
+
+// MarkError sets the edit's status to error with the reason.
+func (r *SQLRepository) MarkError(ctx context.Context, editID, message string) error {
+	q := `UPDATE image_edits SET status = 'error', error = $2, updated_at = now() WHERE id = $1::uuid`
+	return r.exec(ctx, "mark edit error", q, editID, message)
+}
+
+// exec runs an update, retrying transient failures.
+func (r *SQLRepository) exec(ctx context.Context, op, q string, args ...any) error {
+	err := dbretry.Do(ctx, op, func(ctx context.Context) error {
+		_, err := r.db.ExecContext(ctx, q, args...)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package edit
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const editID = "7c4b8f9e-3d1a-4b2c-9e8f-1a2b3c4d5e6f"
+
+func TestSQLRepository(t *testing.T) {
+	testCases := []struct {
+		name    string
+		query   string
+		args    []driver.Value
+		mark    func(r *SQLRepository) error
+		execErr error
+	}{
+		{
+			name:  "success: processing",
+			query: `UPDATE image_edits SET status = 'processing'`,
+			args:  []driver.Value{editID},
+			mark:  func(r *SQLRepository) error { return r.MarkProcessing(context.Background(), editID) },
+		},
+		{
+			name:  "success: ready",
+			query: `UPDATE image_edits SET status = 'ready', result_url = \$2, error = NULL`,
+			args:  []driver.Value{editID, "s3://bucket/staged/x-edit.png"},
+			mark: func(r *SQLRepository) error {
+				return r.MarkReady(context.Background(), editID, "s3://bucket/staged/x-edit.png")
+			},
+		},
+		{
+			name:  "success: error",
+			query: `UPDATE image_edits SET status = 'error', error = \$2`,
+			args:  []driver.Value{editID, "prediction failed"},
+			mark:  func(r *SQLRepository) error { return r.MarkError(context.Background(), editID, "prediction failed") },
+		},
+		{
+			name:    "fail: exec error",
+			query:   `UPDATE image_edits SET status = 'processing'`,
+			args:    []driver.Value{editID},
+			mark:    func(r *SQLRepository) error { return r.MarkProcessing(context.Background(), editID) },
+			execErr: errors.New("boom"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+
+			exp := mock.ExpectExec(tc.query).WithArgs(tc.args...)
+			if tc.execErr != nil {
+				exp.WillReturnError(tc.execErr)
+			} else {
+				exp.WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			err = tc.mark(NewSQLRepository(db))
+			if tc.execErr != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package ensemble
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/real-staging-ai/worker/internal/dbretry"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Candidate is one model/prompt variant in a comparison.
+type Candidate struct {
+	Model        string `json:"model"`
+	Prompt       string `json:"prompt"`
+	PredictionID string `json:"prediction_id,omitempty"`
+	// OutputKey is the S3 key the candidate's raw output was kept under.
+	OutputKey string `json:"output_key,omitempty"`
+	// Score is nil when the candidate failed.
+	Score *Score `json:"score,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// Comparison is the outcome of staging one image with every candidate.
+type Comparison struct {
+	ImageID    string
+	Candidates []Candidate
+	// Selected is the index of the candidate used as the staged output.
+	Selected int
+}
+
+// Best returns the index of the highest-scoring candidate, or -1 when none was scored.
+// Ties go to the earlier candidate, so the primary model wins unless beaten.
+func Best(candidates []Candidate) int {
+	best := -1
+	for i, c := range candidates {
+		if c.Score == nil {
+			continue
+		}
+		if best < 0 || c.Score.Total > candidates[best].Score.Total {
+			best = i
+		}
+	}
+	return best
+}
+
+// Repository records comparisons for offline evaluation.
+type Repository interface {
+	Record(ctx context.Context, c Comparison) error
+}
+
+// SQLRepository stores comparisons in ensemble_comparisons with database/sql.
+type SQLRepository struct {
+	db *sql.DB
+}
+
+// Ensure SQLRepository implements Repository.
+var _ Repository = (*SQLRepository)(nil)
+
+// NewSQLRepository creates a SQLRepository.
+func NewSQLRepository(db *sql.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+// Record inserts the comparison. The row's ID is picked up front, so a retried insert
+// whose first attempt did commit records the comparison once.
+func (r *SQLRepository) Record(ctx context.Context, c Comparison) error {
+	candidates, err := json.Marshal(c.Candidates)
+	if err != nil {
+		return fmt.Errorf("encode ensemble candidates: %w", err)
+	}
+	const q = `
+		INSERT INTO ensemble_comparisons (id, image_id, candidates, selected)
+		VALUES ($1::uuid, $2::uuid, $3, $4)
+		ON CONFLICT (id) DO NOTHING`
+	id := uuid.NewString()
+	err = dbretry.Do(ctx, "record ensemble comparison", func(ctx context.Context) error {
+		_, err := r.db.ExecContext(ctx, q, id, c.ImageID, candidates, c.Selected)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("record ensemble comparison: %w", err)
+	}
+	return nil
+}
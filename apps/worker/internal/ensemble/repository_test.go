@@ -0,0 +1,118 @@
+package ensemble
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/worker/internal/dbretry"
+)
+
+func TestBest(t *testing.T) {
+	testCases := []struct {
+		name       string
+		candidates []Candidate
+		want       int
+	}{
+		{
+			name:       "success: higher score wins",
+			candidates: []Candidate{{Score: &Score{Total: 0.4}}, {Score: &Score{Total: 0.7}}},
+			want:       1,
+		},
+		{
+			name:       "success: tie keeps the primary",
+			candidates: []Candidate{{Score: &Score{Total: 0.5}}, {Score: &Score{Total: 0.5}}},
+			want:       0,
+		},
+		{
+			name:       "success: failed candidate is skipped",
+			candidates: []Candidate{{Error: "prediction failed"}, {Score: &Score{Total: 0.1}}},
+			want:       1,
+		},
+		{
+			name:       "success: nothing scored",
+			candidates: []Candidate{{Error: "a"}, {Error: "b"}},
+			want:       -1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Best(tc.candidates))
+		})
+	}
+}
+
+func TestSQLRepository_Record(t *testing.T) {
+	comparison := Comparison{
+		ImageID: "2e1aa86e-0f27-4f0f-9e57-0d7b53b4d9b9",
+		Candidates: []Candidate{
+			{Model: "a/b", Prompt: "p", PredictionID: "pred-1", Score: &Score{Total: 0.5}},
+			{Model: "a/b", Prompt: "p more", Error: "prediction failed"},
+		},
+	}
+	wantJSON := `[{"model":"a/b","prompt":"p","prediction_id":"pred-1",` +
+		`"score":{"sharpness":0,"exposure":0,"contrast":0,"colorfulness":0,"total":0.5}},` +
+		`{"model":"a/b","prompt":"p more","error":"prediction failed"}]`
+
+	prev := dbretry.DefaultPolicy
+	dbretry.DefaultPolicy = dbretry.Policy{Attempts: 3}
+	t.Cleanup(func() { dbretry.DefaultPolicy = prev })
+
+	testCases := []struct {
+		name     string
+		execErrs []error
+		wantErr  error
+	}{
+		{name: "success: comparison recorded"},
+		{name: "success: retried after a serialization failure", execErrs: []error{&pq.Error{Code: "40001"}}},
+		{name: "fail: exec error", execErrs: []error{errors.New("boom")}, wantErr: errors.New("boom")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+
+			// Every attempt must reuse the ID the first one picked
+			id := &sameArg{}
+			for _, execErr := range tc.execErrs {
+				mock.ExpectExec(`INSERT INTO ensemble_comparisons`).
+					WithArgs(id, comparison.ImageID, []byte(wantJSON), 0).
+					WillReturnError(execErr)
+			}
+			if tc.wantErr == nil {
+				mock.ExpectExec(`INSERT INTO ensemble_comparisons`).
+					WithArgs(id, comparison.ImageID, []byte(wantJSON), 0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			err = NewSQLRepository(db).Record(context.Background(), comparison)
+			if tc.wantErr != nil {
+				assert.ErrorContains(t, err, tc.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// sameArg matches any first value and then only that value again.
+type sameArg struct {
+	seen driver.Value
+}
+
+func (a *sameArg) Match(v driver.Value) bool {
+	if a.seen == nil {
+		a.seen = v
+	}
+	return v == a.seen
+}
@@ -0,0 +1,120 @@
+// Package ensemble supports the experimental ensemble mode, which stages an image with
+// two model/prompt variants, scores both outputs, and keeps the better one. Every
+// comparison is recorded so the scorer's picks can be evaluated offline.
+package ensemble
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg" // register decoder for model outputs
+	_ "image/png"  // register decoder for model outputs
+	"math"
+
+	_ "golang.org/x/image/webp" // register decoder for model outputs
+)
+
+// Score is a lightweight, reference-free estimate of an image's technical quality.
+// Every component is in [0, 1], higher is better.
+type Score struct {
+	// Sharpness is the normalized variance of the Laplacian; blurry outputs score low.
+	Sharpness float64 `json:"sharpness"`
+	// Exposure is how close mean luminance is to mid-grey.
+	Exposure float64 `json:"exposure"`
+	// Contrast is the normalized standard deviation of luminance.
+	Contrast float64 `json:"contrast"`
+	// Colorfulness is the normalized Hasler-Süsstrunk colorfulness metric.
+	Colorfulness float64 `json:"colorfulness"`
+	// Total is the weighted sum of the components.
+	Total float64 `json:"total"`
+}
+
+// Weights of each component in Score.Total. Sharpness dominates: the common failure of
+// image-edit models is a soft, smeared result rather than a badly exposed one.
+const (
+	weightSharpness    = 0.4
+	weightExposure     = 0.25
+	weightContrast     = 0.2
+	weightColorfulness = 0.15
+)
+
+// Normalization constants: the raw value at which a component reaches 0.5 (sharpness)
+// or 1 (contrast, colorfulness), tuned on typical interior photos.
+const (
+	sharpnessKnee   = 0.0025
+	contrastFull    = 0.25
+	colorfulnessMax = 0.43
+)
+
+// scoreSize is the longest side, in pixels, an image is sampled down to before scoring.
+const scoreSize = 256
+
+// ScoreImage decodes a JPEG, PNG or WebP image and scores it.
+func ScoreImage(img []byte) (Score, error) {
+	src, _, err := image.Decode(bytes.NewReader(img))
+	if err != nil {
+		return Score{}, fmt.Errorf("decode image: %w", err)
+	}
+
+	b := src.Bounds()
+	step := max(1, max(b.Dx(), b.Dy())/scoreSize)
+	w, h := b.Dx()/step, b.Dy()/step
+	if w < 3 || h < 3 {
+		return Score{}, errors.New("image too small to score")
+	}
+
+	lum := make([]float64, w*h)
+	var lumSum, rgSum, ybSum, rgSq, ybSq float64
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			r, g, bl, _ := src.At(b.Min.X+x*step, b.Min.Y+y*step).RGBA()
+			rf, gf, bf := float64(r)/0xffff, float64(g)/0xffff, float64(bl)/0xffff
+			l := 0.2126*rf + 0.7152*gf + 0.0722*bf
+			lum[y*w+x] = l
+			lumSum += l
+
+			rg := rf - gf
+			yb := 0.5*(rf+gf) - bf
+			rgSum += rg
+			ybSum += yb
+			rgSq += rg * rg
+			ybSq += yb * yb
+		}
+	}
+	n := float64(w * h)
+
+	mean := lumSum / n
+	var lumVar float64
+	for _, l := range lum {
+		lumVar += (l - mean) * (l - mean)
+	}
+	lumVar /= n
+
+	var lapSum, lapSq float64
+	for y := 1; y < h-1; y++ {
+		for x := 1; x < w-1; x++ {
+			i := y*w + x
+			lap := 4*lum[i] - lum[i-1] - lum[i+1] - lum[i-w] - lum[i+w]
+			lapSum += lap
+			lapSq += lap * lap
+		}
+	}
+	m := float64((w - 2) * (h - 2))
+	lapVar := lapSq/m - (lapSum/m)*(lapSum/m)
+
+	rgMean, ybMean := rgSum/n, ybSum/n
+	rgStd := math.Sqrt(max(rgSq/n-rgMean*rgMean, 0))
+	ybStd := math.Sqrt(max(ybSq/n-ybMean*ybMean, 0))
+	colorfulness := math.Hypot(rgStd, ybStd) + 0.3*math.Hypot(rgMean, ybMean)
+
+	s := Score{
+		Sharpness:    lapVar / (lapVar + sharpnessKnee),
+		Exposure:     1 - math.Abs(mean-0.5)*2,
+		Contrast:     math.Min(math.Sqrt(lumVar)/contrastFull, 1),
+		Colorfulness: math.Min(colorfulness/colorfulnessMax, 1),
+	}
+	s.Total = weightSharpness*s.Sharpness + weightExposure*s.Exposure +
+		weightContrast*s.Contrast + weightColorfulness*s.Colorfulness
+	return s, nil
+}
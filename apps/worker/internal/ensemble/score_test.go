@@ -0,0 +1,93 @@
+package ensemble
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// texture returns a colorful, high-frequency test image: a checkerboard over a gradient.
+func texture(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := uint8(60 + 120*x/w)
+			if (x/2+y/2)%2 == 0 {
+				v += 60
+			}
+			img.Set(x, y, color.RGBA{R: v, G: uint8(40 + 150*y/h), B: 255 - v, A: 255})
+		}
+	}
+	return img
+}
+
+// blur box-blurs img with the given radius.
+func blur(img *image.RGBA, radius int) *image.RGBA {
+	b := img.Bounds()
+	out := image.NewRGBA(b)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			var r, g, bl, n int
+			for dy := -radius; dy <= radius; dy++ {
+				for dx := -radius; dx <= radius; dx++ {
+					p := image.Pt(x+dx, y+dy)
+					if !p.In(b) {
+						continue
+					}
+					c := img.RGBAAt(p.X, p.Y)
+					r, g, bl, n = r+int(c.R), g+int(c.G), bl+int(c.B), n+1
+				}
+			}
+			out.SetRGBA(x, y, color.RGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(bl / n), A: 255})
+		}
+	}
+	return out
+}
+
+func encode(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestScoreImage(t *testing.T) {
+	sharp := texture(128, 96)
+
+	sharpScore, err := ScoreImage(encode(t, sharp))
+	require.NoError(t, err)
+	blurredScore, err := ScoreImage(encode(t, blur(sharp, 3)))
+	require.NoError(t, err)
+
+	white := image.NewRGBA(image.Rect(0, 0, 128, 96))
+	for i := range white.Pix {
+		white.Pix[i] = 0xff
+	}
+	whiteScore, err := ScoreImage(encode(t, white))
+	require.NoError(t, err)
+
+	for _, s := range []Score{sharpScore, blurredScore, whiteScore} {
+		for _, v := range []float64{s.Sharpness, s.Exposure, s.Contrast, s.Colorfulness, s.Total} {
+			assert.GreaterOrEqual(t, v, 0.0)
+			assert.LessOrEqual(t, v, 1.0)
+		}
+	}
+	assert.Greater(t, sharpScore.Sharpness, blurredScore.Sharpness)
+	assert.Greater(t, sharpScore.Total, blurredScore.Total)
+	assert.InDelta(t, 0, whiteScore.Exposure, 1e-9)
+	assert.InDelta(t, 0, whiteScore.Sharpness, 1e-9)
+	assert.Greater(t, blurredScore.Total, whiteScore.Total)
+}
+
+func TestScoreImage_Errors(t *testing.T) {
+	_, err := ScoreImage([]byte("not an image"))
+	assert.ErrorContains(t, err, "decode image")
+
+	_, err = ScoreImage(encode(t, image.NewRGBA(image.Rect(0, 0, 2, 2))))
+	assert.ErrorContains(t, err, "too small")
+}
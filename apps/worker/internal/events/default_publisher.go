@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"math"
-	"os"
 	"time"
 
 	redis "github.com/redis/go-redis/v9"
@@ -14,10 +13,21 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 
+	"github.com/real-staging-ai/worker/internal/chaos"
 	"github.com/real-staging-ai/worker/internal/config"
 	"github.com/real-staging-ai/worker/internal/logging"
 )
 
+const (
+	// streamMaxLen caps each image's event stream; only recent updates are needed for replay.
+	streamMaxLen = 100
+	// projectStreamMaxLen caps each project's event stream, which carries every image's
+	// status transitions.
+	projectStreamMaxLen = 1000
+	// streamTTL expires an event stream once its jobs have gone quiet.
+	streamTTL = 24 * time.Hour
+)
+
 // Options controls retry/backoff behavior for the default publisher.
 // Zero values select sensible defaults.
 type Options struct {
@@ -26,21 +36,23 @@ type Options struct {
 	MaxDelay    time.Duration
 }
 
-// NewDefaultPublisher returns a Redis-backed publisher if REDIS_ADDR is set.
+// NewDefaultPublisher returns a Redis-backed publisher if cfg.Redis.Addr is set.
 func NewDefaultPublisher(cfg *config.Config) (Publisher, error) {
 	if cfg == nil {
 		return nil, errors.New("config is nil")
 	}
 
-	// Pull from config if env is not available
-	addr := os.Getenv("REDIS_ADDR")
-	if addr == "" && cfg.Redis.Addr != "" {
-		addr = cfg.Redis.Addr
+	if cfg.Redis.Addr == "" {
+		return nil, errors.New("redis address is not configured. Set REDIS_ADDR or redis.addr")
 	}
-	if addr == "" {
-		return nil, errors.New("redis address is not set. Please set REDIS_ADDR or configure Redis in config file")
+	rdb := redis.NewClient(&redis.Options{Addr: cfg.Redis.Addr})
+	inj, err := chaos.FromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if inj != nil {
+		rdb.AddHook(inj.RedisHook())
 	}
-	rdb := redis.NewClient(&redis.Options{Addr: addr})
 	return NewDefaultPublisherWithClient(rdb, Options{}), nil
 }
 
@@ -87,18 +99,37 @@ func (p *defaultRedisPublisher) PublishJobUpdate(ctx context.Context, ev JobUpda
 		span.SetStatus(codes.Error, err.Error())
 		return err
 	}
-	// Minimal payload: status only (SSE contract)
-	payload, err := json.Marshal(map[string]string{"status": ev.Status})
+	// Minimal payload: status, plus the preview flag when a preview is announced (SSE contract)
+	body := map[string]any{"status": ev.Status}
+	if ev.Preview {
+		body["preview"] = true
+	}
+	payload, err := json.Marshal(body)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "marshal payload")
 		return fmt.Errorf("marshal payload: %w", err)
 	}
 	channel := fmt.Sprintf("jobs:image:%s", ev.ImageID)
+	stream := channel + ":events"
+	// Previews are not status transitions, so only the image's own stream carries them.
+	var projectStream string
+	var projectPayload []byte
+	if ev.ProjectID != "" && !ev.Preview {
+		projectStream = fmt.Sprintf("jobs:project:%s:events", ev.ProjectID)
+		projectPayload, err = json.Marshal(map[string]any{"image_id": ev.ImageID, "status": ev.Status})
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "marshal project payload")
+			return fmt.Errorf("marshal project payload: %w", err)
+		}
+		span.SetAttributes(attribute.String("project.id", ev.ProjectID))
+	}
 	span.SetAttributes(
 		attribute.String("image.id", ev.ImageID),
 		attribute.String("event.status", ev.Status),
 		attribute.String("events.channel", channel),
+		attribute.String("events.stream", stream),
 	)
 
 	logger := p.logger
@@ -109,7 +140,7 @@ func (p *defaultRedisPublisher) PublishJobUpdate(ctx context.Context, ev JobUpda
 	var attempt int
 	for {
 		attempt++
-		err = p.rdb.Publish(ctx, channel, payload).Err()
+		err = p.publish(ctx, stream, channel, payload, projectStream, projectPayload)
 		if err == nil {
 			return nil
 		}
@@ -135,6 +166,35 @@ func (p *defaultRedisPublisher) PublishJobUpdate(ctx context.Context, ev JobUpda
 	}
 }
 
+// publish appends the payload to the image's stream, which every API replica reads so any
+// of them can serve the client and replay what it missed. The payload is also published on
+// the legacy channel for replicas still subscribing to it during a rolling deploy. When
+// projectStream is set, projectPayload is appended to it in the same transaction.
+func (p *defaultRedisPublisher) publish(
+	ctx context.Context, stream, channel string, payload []byte, projectStream string, projectPayload []byte,
+) error {
+	pipe := p.rdb.TxPipeline()
+	pipe.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream,
+		MaxLen: streamMaxLen,
+		Approx: true,
+		Values: map[string]any{"data": payload},
+	})
+	pipe.Expire(ctx, stream, streamTTL)
+	pipe.Publish(ctx, channel, payload)
+	if projectStream != "" {
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: projectStream,
+			MaxLen: projectStreamMaxLen,
+			Approx: true,
+			Values: map[string]any{"data": projectPayload},
+		})
+		pipe.Expire(ctx, projectStream, streamTTL)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
 func (p *defaultRedisPublisher) backoffDelay(attempt int) time.Duration {
 	// attempt starts at 1; compute delay = base * 2^(attempt-1) up to maxDelay
 	exp := math.Pow(2, float64(attempt-1))
@@ -49,6 +49,56 @@ func TestDefaultPublisher_Success_NoRetry(t *testing.T) {
 	}
 }
 
+func TestDefaultPublisher_AppendsToStream(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	pub := NewDefaultPublisherWithClient(rdb, Options{MaxAttempts: 1})
+	ctx := context.Background()
+
+	imageID := "img-stream"
+	require.NoError(t, pub.PublishJobUpdate(ctx, JobUpdateEvent{JobID: "j1", ImageID: imageID, Status: "queued"}))
+	require.NoError(t, pub.PublishJobUpdate(ctx,
+		JobUpdateEvent{JobID: "j1", ImageID: imageID, Status: "processing", Preview: true}))
+	require.NoError(t, pub.PublishJobUpdate(ctx, JobUpdateEvent{JobID: "j1", ImageID: imageID, Status: "completed"}))
+
+	stream := "jobs:image:" + imageID + ":events"
+	entries, err := rdb.XRange(ctx, stream, "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.JSONEq(t, `{"status":"queued"}`, entries[0].Values["data"].(string))
+	assert.JSONEq(t, `{"status":"processing","preview":true}`, entries[1].Values["data"].(string))
+	assert.JSONEq(t, `{"status":"completed"}`, entries[2].Values["data"].(string))
+	assert.Equal(t, streamTTL, mr.TTL(stream))
+}
+
+func TestDefaultPublisher_AppendsStatusToProjectStream(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	pub := NewDefaultPublisherWithClient(rdb, Options{MaxAttempts: 1})
+	ctx := context.Background()
+
+	projectID := "proj-1"
+	require.NoError(t, pub.PublishJobUpdate(ctx,
+		JobUpdateEvent{JobID: "j1", ImageID: "img-1", ProjectID: projectID, Status: "processing"}))
+	require.NoError(t, pub.PublishJobUpdate(ctx,
+		JobUpdateEvent{JobID: "j1", ImageID: "img-1", ProjectID: projectID, Status: "processing", Preview: true}))
+	require.NoError(t, pub.PublishJobUpdate(ctx,
+		JobUpdateEvent{JobID: "j2", ImageID: "img-2", ProjectID: projectID, Status: "error", Error: "boom"}))
+	require.NoError(t, pub.PublishJobUpdate(ctx, JobUpdateEvent{JobID: "j3", ImageID: "img-3", Status: "ready"}))
+
+	stream := "jobs:project:" + projectID + ":events"
+	entries, err := rdb.XRange(ctx, stream, "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.JSONEq(t, `{"image_id":"img-1","status":"processing"}`, entries[0].Values["data"].(string))
+	assert.JSONEq(t, `{"image_id":"img-2","status":"error"}`, entries[1].Values["data"].(string))
+	assert.Equal(t, streamTTL, mr.TTL(stream))
+
+	imageEntries, err := rdb.XRange(ctx, "jobs:image:img-1:events", "-", "+").Result()
+	require.NoError(t, err)
+	assert.Len(t, imageEntries, 2)
+}
+
 func TestDefaultPublisher_RetryAndFail_Logs(t *testing.T) {
 	prev := logging.Default()
 	memLogger := &memoryLogger{}
@@ -8,15 +8,19 @@ import (
 
 // JobUpdateEvent mirrors the API's SSE payload for job updates.
 type JobUpdateEvent struct {
-	JobID    string `json:"job_id"`
-	ImageID  string `json:"image_id"`
-	Status   string `json:"status"`
-	Error    string `json:"error,omitempty"`
-	Progress int    `json:"progress,omitempty"`
+	JobID   string `json:"job_id"`
+	ImageID string `json:"image_id"`
+	// ProjectID also appends status transitions to the project's stream when set.
+	ProjectID string `json:"project_id,omitempty"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	Progress  int    `json:"progress,omitempty"`
+	// Preview is set on the update announcing a new low-resolution preview of the output.
+	Preview bool `json:"preview,omitempty"`
 }
 
-// Publisher publishes job update events to a pub/sub backend (Redis),
-// which the API consumes to stream Server-Sent Events (SSE).
+// Publisher publishes job update events to per-image and per-project Redis streams,
+// which every API replica reads to stream Server-Sent Events (SSE).
 type Publisher interface {
 	// PublishJobUpdate publishes a minimal status-only payload for a given image.
 	PublishJobUpdate(ctx context.Context, ev JobUpdateEvent) error
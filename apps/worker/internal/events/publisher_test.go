@@ -13,12 +13,12 @@ import (
 	"github.com/real-staging-ai/worker/internal/config"
 )
 
-func TestNewDefaultPublisher_MissingEnv(t *testing.T) {
-	// Ensure REDIS_ADDR is unset
-	t.Setenv("REDIS_ADDR", "")
+func TestNewDefaultPublisher_MissingRedisAddr(t *testing.T) {
+	// The environment is only read through config loading
+	t.Setenv("REDIS_ADDR", "localhost:6379")
 
 	_, err := NewDefaultPublisher(&config.Config{})
-	require.Error(t, err, "expected error when REDIS_ADDR is not set")
+	require.Error(t, err, "expected error when redis.addr is not configured")
 }
 
 func TestRedisPublisher_PublishJobUpdate_SendsStatusOnly(t *testing.T) {
@@ -30,10 +30,10 @@ func TestRedisPublisher_PublishJobUpdate_SendsStatusOnly(t *testing.T) {
 	mr := miniredis.RunT(t)
 	defer mr.Close()
 
-	// Configure env for publisher
-	t.Setenv("REDIS_ADDR", mr.Addr())
+	// Point the publisher at miniredis
+	cfg.Redis.Addr = mr.Addr()
 
-	// Build publisher from env
+	// Build publisher from config
 	pub, err := NewDefaultPublisher(cfg)
 	require.NoError(t, err)
 
@@ -93,7 +93,7 @@ func TestRedisPublisher_PublishJobUpdate_DifferentImageChannel(t *testing.T) {
 	mr := miniredis.RunT(t)
 	defer mr.Close()
 
-	t.Setenv("REDIS_ADDR", mr.Addr())
+	cfg.Redis.Addr = mr.Addr()
 	pub, err := NewDefaultPublisher(cfg)
 	require.NoError(t, err)
 
@@ -0,0 +1,169 @@
+// Package fairshare shares the worker between users. Tasks wait in one sub-queue per user
+// within each priority tier and are handed out round-robin, weighted per user, so one
+// user's 500-image batch does not hold up everyone else queued behind it.
+package fairshare
+
+import "sync"
+
+// Scheduler holds tasks in per-user sub-queues and hands them out in weighted round-robin
+// order, draining higher tiers first. It is safe for concurrent use.
+type Scheduler[T comparable] struct {
+	maxPerUser int
+	weights    map[string]int
+
+	mu    sync.Mutex
+	tiers []*tier[T]
+	held  map[string]int
+}
+
+// tier is one priority level: a sub-queue per user and the ring of users with tasks.
+type tier[T comparable] struct {
+	queues map[string][]T
+	ring   []string
+	// next indexes the user whose turn it is; turns counts the tasks they took this turn.
+	next  int
+	turns int
+}
+
+// NewScheduler creates a Scheduler with tiers priority levels, 0 being the highest.
+// maxPerUser caps the tasks a user may hold, waiting or running; weights gives users more
+// than one task per turn.
+func NewScheduler[T comparable](tiers, maxPerUser int, weights map[string]int) *Scheduler[T] {
+	s := &Scheduler[T]{maxPerUser: maxPerUser, weights: weights, held: map[string]int{}}
+	for range max(tiers, 1) {
+		s.tiers = append(s.tiers, &tier[T]{queues: map[string][]T{}})
+	}
+	return s
+}
+
+// Admit reserves a place for one more of user's tasks, reporting false when user already
+// holds maxPerUser. Every admitted task must be released with Release.
+func (s *Scheduler[T]) Admit(user string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.maxPerUser > 0 && s.held[user] >= s.maxPerUser {
+		return false
+	}
+	s.held[user]++
+	return true
+}
+
+// Hold counts one more of user's tasks as held even when they are over maxPerUser, for
+// tasks that cannot be turned away. It must be released with Release like an admitted one.
+func (s *Scheduler[T]) Hold(user string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.held[user]++
+}
+
+// Release frees the place of one of user's admitted tasks once it finished or was dropped.
+func (s *Scheduler[T]) Release(user string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.held[user] <= 1 {
+		delete(s.held, user)
+		return
+	}
+	s.held[user]--
+}
+
+// Push appends task to user's sub-queue in tier. Tiers out of range use the lowest.
+func (s *Scheduler[T]) Push(tierIndex int, user string, task T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := s.tier(tierIndex)
+	if len(t.queues[user]) == 0 {
+		t.ring = append(t.ring, user)
+	}
+	t.queues[user] = append(t.queues[user], task)
+}
+
+// Pop returns the next task, or false when none is waiting. A user keeps the turn for as
+// many tasks as their weight, then it passes to the next user in the tier.
+func (s *Scheduler[T]) Pop() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, t := range s.tiers {
+		if len(t.ring) == 0 {
+			continue
+		}
+		user := t.ring[t.next]
+		task := t.queues[user][0]
+		t.queues[user] = t.queues[user][1:]
+		t.turns++
+		switch {
+		case len(t.queues[user]) == 0:
+			t.removeUser(t.next)
+		case t.turns >= s.weight(user):
+			t.next = (t.next + 1) % len(t.ring)
+			t.turns = 0
+		}
+		return task, true
+	}
+	var zero T
+	return zero, false
+}
+
+// Remove drops task from user's sub-queue in tier, reporting whether it was still waiting.
+func (s *Scheduler[T]) Remove(tierIndex int, user string, task T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := s.tier(tierIndex)
+	q := t.queues[user]
+	for i, queued := range q {
+		if queued != task {
+			continue
+		}
+		t.queues[user] = append(q[:i:i], q[i+1:]...)
+		if len(t.queues[user]) == 0 {
+			for j, u := range t.ring {
+				if u == user {
+					t.removeUser(j)
+					break
+				}
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// Waiting returns how many tasks are waiting across every tier.
+func (s *Scheduler[T]) Waiting() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, t := range s.tiers {
+		for _, q := range t.queues {
+			n += len(q)
+		}
+	}
+	return n
+}
+
+func (s *Scheduler[T]) tier(i int) *tier[T] {
+	return s.tiers[min(max(i, 0), len(s.tiers)-1)]
+}
+
+func (s *Scheduler[T]) weight(user string) int {
+	if w := s.weights[user]; w > 0 {
+		return w
+	}
+	return 1
+}
+
+// removeUser takes the user at ring index i out of the ring, passing the turn on if it was
+// theirs.
+func (t *tier[T]) removeUser(i int) {
+	delete(t.queues, t.ring[i])
+	t.ring = append(t.ring[:i], t.ring[i+1:]...)
+	switch {
+	case i < t.next:
+		t.next--
+	case i == t.next:
+		t.turns = 0
+	}
+	if t.next >= len(t.ring) {
+		t.next = 0
+	}
+}
@@ -0,0 +1,133 @@
+package fairshare
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func drain(s *Scheduler[string]) []string {
+	var got []string
+	for {
+		task, ok := s.Pop()
+		if !ok {
+			return got
+		}
+		got = append(got, task)
+	}
+}
+
+func TestScheduler_Pop(t *testing.T) {
+	testCases := []struct {
+		name    string
+		weights map[string]int
+		push    func(s *Scheduler[string])
+		want    []string
+	}{
+		{
+			name: "success: a large batch does not starve a later user",
+			push: func(s *Scheduler[string]) {
+				for _, task := range []string{"a1", "a2", "a3", "a4", "a5"} {
+					s.Push(1, "alice", task)
+				}
+				s.Push(1, "bob", "b1")
+				s.Push(1, "bob", "b2")
+			},
+			want: []string{"a1", "b1", "a2", "b2", "a3", "a4", "a5"},
+		},
+		{
+			name:    "success: weights give more turns per round",
+			weights: map[string]int{"alice": 2},
+			push: func(s *Scheduler[string]) {
+				for _, task := range []string{"a1", "a2", "a3", "a4"} {
+					s.Push(1, "alice", task)
+				}
+				s.Push(1, "bob", "b1")
+				s.Push(1, "bob", "b2")
+			},
+			want: []string{"a1", "a2", "b1", "a3", "a4", "b2"},
+		},
+		{
+			name: "success: higher tiers drain first",
+			push: func(s *Scheduler[string]) {
+				s.Push(1, "alice", "a1")
+				s.Push(0, "bob", "b1")
+				s.Push(1, "bob", "b2")
+				s.Push(0, "alice", "a2")
+			},
+			want: []string{"b1", "a2", "a1", "b2"},
+		},
+		{
+			name: "success: out of range tiers use the lowest",
+			push: func(s *Scheduler[string]) {
+				s.Push(7, "alice", "a1")
+				s.Push(0, "bob", "b1")
+			},
+			want: []string{"b1", "a1"},
+		},
+		{
+			name: "success: nothing waiting",
+			push: func(s *Scheduler[string]) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewScheduler[string](2, 0, tc.weights)
+			tc.push(s)
+			assert.Equal(t, len(tc.want), s.Waiting())
+			assert.Equal(t, tc.want, drain(s))
+			assert.Zero(t, s.Waiting())
+		})
+	}
+}
+
+func TestScheduler_PopInterleavedWithPush(t *testing.T) {
+	s := NewScheduler[string](1, 0, nil)
+	s.Push(0, "alice", "a1")
+	s.Push(0, "alice", "a2")
+	s.Push(0, "bob", "b1")
+
+	first, _ := s.Pop()
+	assert.Equal(t, "a1", first)
+	// Carol joins the end of the round, after bob's turn
+	s.Push(0, "carol", "c1")
+	assert.Equal(t, []string{"b1", "c1", "a2"}, drain(s))
+}
+
+func TestScheduler_Remove(t *testing.T) {
+	s := NewScheduler[string](1, 0, nil)
+	s.Push(0, "alice", "a1")
+	s.Push(0, "alice", "a2")
+	s.Push(0, "bob", "b1")
+
+	assert.True(t, s.Remove(0, "bob", "b1"))
+	assert.False(t, s.Remove(0, "bob", "b1"))
+	assert.True(t, s.Remove(0, "alice", "a1"))
+	assert.Equal(t, []string{"a2"}, drain(s))
+}
+
+func TestScheduler_Admit(t *testing.T) {
+	s := NewScheduler[string](1, 2, nil)
+
+	assert.True(t, s.Admit("alice"))
+	assert.True(t, s.Admit("alice"))
+	assert.False(t, s.Admit("alice"))
+	assert.True(t, s.Admit("bob"))
+
+	s.Release("alice")
+	assert.True(t, s.Admit("alice"))
+
+	// Held tasks count against the cap until released
+	s.Hold("alice")
+	s.Release("alice")
+	assert.False(t, s.Admit("alice"))
+	s.Release("alice")
+	s.Release("alice")
+	assert.True(t, s.Admit("alice"))
+
+	unlimited := NewScheduler[string](1, 0, nil)
+	for range 100 {
+		assert.True(t, unlimited.Admit("alice"))
+	}
+}
@@ -0,0 +1,73 @@
+package fairshare
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/real-staging-ai/worker/internal/dbretry"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out owners_mock.go . OwnerResolver
+
+// OwnerResolver finds the user a task belongs to.
+type OwnerResolver interface {
+	// OwnerOf returns the id of the user who owns imageID's project.
+	OwnerOf(ctx context.Context, imageID string) (string, error)
+}
+
+// maxCachedOwners bounds the owner cache. Deferred tasks come back to the worker that
+// deferred them, so recent images are the ones looked up again.
+const maxCachedOwners = 10000
+
+// DefaultOwnerResolver looks owners up in Postgres and caches them, since an image never
+// changes hands.
+type DefaultOwnerResolver struct {
+	db *sql.DB
+
+	mu     sync.Mutex
+	owners map[string]string
+}
+
+// Ensure DefaultOwnerResolver implements OwnerResolver.
+var _ OwnerResolver = (*DefaultOwnerResolver)(nil)
+
+// NewDefaultOwnerResolver creates a DefaultOwnerResolver.
+func NewDefaultOwnerResolver(db *sql.DB) *DefaultOwnerResolver {
+	return &DefaultOwnerResolver{db: db, owners: map[string]string{}}
+}
+
+// OwnerOf returns the cached owner of imageID, looking it up on a miss.
+func (r *DefaultOwnerResolver) OwnerOf(ctx context.Context, imageID string) (string, error) {
+	r.mu.Lock()
+	owner, ok := r.owners[imageID]
+	r.mu.Unlock()
+	if ok {
+		return owner, nil
+	}
+
+	const q = `
+		SELECT p.user_id::text
+		FROM images i
+		JOIN projects p ON p.id = i.project_id
+		WHERE i.id = $1::uuid`
+	err := dbretry.Do(ctx, "get image owner", func(ctx context.Context) error {
+		return r.db.QueryRowContext(ctx, q, imageID).Scan(&owner)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("image %s not found", imageID)
+	}
+	if err != nil {
+		return "", fmt.Errorf("get image owner: %w", err)
+	}
+
+	r.mu.Lock()
+	if len(r.owners) >= maxCachedOwners {
+		clear(r.owners)
+	}
+	r.owners[imageID] = owner
+	r.mu.Unlock()
+	return owner, nil
+}
@@ -0,0 +1,66 @@
+package fairshare
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultOwnerResolver_OwnerOf(t *testing.T) {
+	testCases := []struct {
+		name      string
+		setup     func(mock sqlmock.Sqlmock)
+		want      string
+		errSubstr string
+	}{
+		{
+			name: "success: looked up once, then cached",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM images i`).WithArgs("img-1").
+					WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow("user-1"))
+			},
+			want: "user-1",
+		},
+		{
+			name: "fail: unknown image",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM images i`).WithArgs("img-1").WillReturnError(sql.ErrNoRows)
+			},
+			errSubstr: "image img-1 not found",
+		},
+		{
+			name: "fail: query error",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM images i`).WithArgs("img-1").WillReturnError(errors.New("boom"))
+			},
+			errSubstr: "get image owner: boom",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+			tc.setup(mock)
+
+			r := NewDefaultOwnerResolver(db)
+			got, err := r.OwnerOf(context.Background(), "img-1")
+			if tc.errSubstr != "" {
+				assert.ErrorContains(t, err, tc.errSubstr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+				again, err := r.OwnerOf(context.Background(), "img-1")
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, again)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
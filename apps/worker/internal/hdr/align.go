@@ -0,0 +1,125 @@
+package hdr
+
+import "image"
+
+const (
+	// alignMinEdge is the shorter edge of the coarsest level the alignment search starts on.
+	alignMinEdge = 16
+	// alignNoise is how close to the median, out of 255, a pixel may be and still be
+	// ignored when comparing exposures; such pixels flip with sensor noise.
+	alignNoise = 4
+	// alignSearch is how far each level may move the shift found on the level above. More
+	// than one pixel lets a level recover when the level above could not tell shifts apart.
+	alignSearch = 2
+)
+
+// align sets the shift of every frame after the first that best lines it up with the
+// first, for exposures shot handheld. It compares median threshold bitmaps (Ward), which
+// look alike across exposures, searching from a coarse level down to full resolution.
+func align(frames []*frame) {
+	ref := bitmapPyramid(frames[0].img)
+	for _, f := range frames[1:] {
+		f.dx, f.dy = offset(ref, bitmapPyramid(f.img))
+	}
+}
+
+// bitmap marks the pixels of an exposure above its median, and the pixels far enough
+// from the median to compare.
+type bitmap struct {
+	w, h  int
+	above []bool
+	keep  []bool
+}
+
+// bitmapPyramid returns the bitmaps of img and of successive halvings of it, finest first.
+func bitmapPyramid(img *image.RGBA) []*bitmap {
+	w, h := img.Rect.Dx(), img.Rect.Dy()
+	gray := make([]uint8, w*h)
+	for y := range h {
+		for x := range w {
+			o := img.PixOffset(x, y)
+			gray[y*w+x] = uint8((299*int(img.Pix[o]) + 587*int(img.Pix[o+1]) + 114*int(img.Pix[o+2])) / 1000)
+		}
+	}
+
+	var pyr []*bitmap
+	for {
+		pyr = append(pyr, threshold(gray, w, h))
+		if min(w, h)/2 < alignMinEdge {
+			return pyr
+		}
+		gray, w, h = halve(gray, w, h)
+	}
+}
+
+// threshold builds the bitmap of a w by h gray image.
+func threshold(gray []uint8, w, h int) *bitmap {
+	var hist [256]int
+	for _, v := range gray {
+		hist[v]++
+	}
+	median, seen := 0, 0
+	for v, n := range hist {
+		seen += n
+		if 2*seen >= len(gray) {
+			median = v
+			break
+		}
+	}
+
+	b := &bitmap{w: w, h: h, above: make([]bool, len(gray)), keep: make([]bool, len(gray))}
+	for i, v := range gray {
+		b.above[i] = int(v) > median
+		b.keep[i] = int(v) > median+alignNoise || int(v) < median-alignNoise
+	}
+	return b
+}
+
+// halve averages every 2x2 block of a w by h gray image.
+func halve(gray []uint8, w, h int) ([]uint8, int, int) {
+	hw, hh := w/2, h/2
+	out := make([]uint8, hw*hh)
+	for y := range hh {
+		for x := range hw {
+			i := 2*y*w + 2*x
+			out[y*hw+x] = uint8((int(gray[i]) + int(gray[i+1]) + int(gray[i+w]) + int(gray[i+w+1]) + 2) / 4)
+		}
+	}
+	return out, hw, hh
+}
+
+// offset finds the shift of the exposure with bitmaps pyr that best lines it up with the
+// exposure with bitmaps ref. Each level refines the doubled shift of the level above by
+// up to alignSearch pixels each way.
+func offset(ref, pyr []*bitmap) (int, int) {
+	dx, dy := 0, 0
+	for l := len(ref) - 1; l >= 0; l-- {
+		dx, dy = 2*dx, 2*dy
+		// Ties keep the unrefined shift, so levels too coarse to tell leave it alone.
+		bestX, bestY, best := dx, dy, mismatches(ref[l], pyr[l], dx, dy)
+		for sy := dy - alignSearch; sy <= dy+alignSearch; sy++ {
+			for sx := dx - alignSearch; sx <= dx+alignSearch; sx++ {
+				if n := mismatches(ref[l], pyr[l], sx, sy); n < best {
+					bestX, bestY, best = sx, sy, n
+				}
+			}
+		}
+		dx, dy = bestX, bestY
+	}
+	return dx, dy
+}
+
+// mismatches counts the pixels where a and b, shifted by (dx, dy), disagree, among the
+// pixels both can compare.
+func mismatches(a, b *bitmap, dx, dy int) int {
+	n := 0
+	for y := max(0, -dy); y < min(a.h, b.h-dy); y++ {
+		for x := max(0, -dx); x < min(a.w, b.w-dx); x++ {
+			i, j := y*a.w+x, (y+dy)*b.w+x+dx
+			if a.keep[i] && b.keep[j] && a.above[i] != b.above[j] {
+				n++
+			}
+		}
+	}
+	return n
+}
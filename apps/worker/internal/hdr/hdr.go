@@ -0,0 +1,221 @@
+// Package hdr merges bracketed exposures of one shot into a single balanced image, so dim
+// interiors with bright windows stage from a base where both are visible. Exposures are
+// lined up with the first one and blended with exposure fusion (Mertens et al.): every
+// pixel is weighted by its contrast, saturation and how well exposed it is, and the
+// weighted exposures are combined across a Laplacian pyramid to avoid seams.
+package hdr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	_ "image/png" // register decoder for uploaded exposures
+	"math"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // register decoder for uploaded exposures
+)
+
+const (
+	// MaxEdge caps the long edge of the merged image; larger exposures are scaled down.
+	MaxEdge = 2048
+	// MaxExposures caps how many exposures one merge takes.
+	MaxExposures = 7
+
+	jpegQuality = 92
+
+	// exposureSigma is how fast the well-exposedness weight falls off away from mid-gray.
+	exposureSigma = 0.2
+	// aspectTolerance is how far apart the aspect ratios of exposures of one shot may be.
+	aspectTolerance = 0.02
+	// minPyramidEdge is the shorter edge below which the blending pyramid stops.
+	minPyramidEdge = 8
+)
+
+// ErrMismatch is returned when the exposures cannot be of the same shot.
+var ErrMismatch = errors.New("exposures are not of the same shot")
+
+// Merge fuses exposures of one shot, the first of which sets the framing, and returns
+// the result as a JPEG no larger than MaxEdge on its long edge.
+func Merge(exposures [][]byte) ([]byte, error) {
+	if len(exposures) < 2 || len(exposures) > MaxExposures {
+		return nil, fmt.Errorf("merge needs 2 to %d exposures, got %d", MaxExposures, len(exposures))
+	}
+
+	frames := make([]*frame, len(exposures))
+	var size image.Point
+	for i, b := range exposures {
+		img, _, err := image.Decode(bytes.NewReader(b))
+		if err != nil {
+			return nil, fmt.Errorf("decode exposure %d: %w", i+1, err)
+		}
+		bounds := img.Bounds()
+		if bounds.Empty() {
+			return nil, fmt.Errorf("decode exposure %d: empty image", i+1)
+		}
+		if i == 0 {
+			size = fit(bounds.Size(), MaxEdge)
+		} else if !sameAspect(bounds.Size(), size) {
+			return nil, fmt.Errorf("%w: exposure %d is %dx%d, the first is %dx%d",
+				ErrMismatch, i+1, bounds.Dx(), bounds.Dy(), frames[0].src.Dx(), frames[0].src.Dy())
+		}
+		frames[i] = newFrame(img, size)
+	}
+
+	align(frames)
+	out := fuse(frames)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, out, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, fmt.Errorf("encode jpeg: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// fit scales size down, keeping its aspect ratio, until its long edge is at most edge.
+func fit(size image.Point, edge int) image.Point {
+	long := max(size.X, size.Y)
+	if long <= edge {
+		return size
+	}
+	scale := float64(edge) / float64(long)
+	return image.Pt(max(int(math.Round(float64(size.X)*scale)), 1), max(int(math.Round(float64(size.Y)*scale)), 1))
+}
+
+// sameAspect reports whether a and b have the same aspect ratio, within aspectTolerance.
+func sameAspect(a, b image.Point) bool {
+	ra := float64(a.X) / float64(a.Y)
+	rb := float64(b.X) / float64(b.Y)
+	return math.Abs(ra-rb) <= aspectTolerance*rb
+}
+
+// frame is one exposure scaled to the merge size, and the shift that lines it up with the
+// first exposure: its pixel (x+dx, y+dy) shows what the first shows at (x, y).
+type frame struct {
+	src    image.Rectangle
+	img    *image.RGBA
+	dx, dy int
+}
+
+// newFrame scales img to size.
+func newFrame(img image.Image, size image.Point) *frame {
+	dst := image.NewRGBA(image.Rectangle{Max: size})
+	if img.Bounds().Size() == size {
+		draw.Draw(dst, dst.Bounds(), img, img.Bounds().Min, draw.Src)
+	} else {
+		draw.CatmullRom.Scale(dst, dst.Bounds(), img, img.Bounds(), draw.Src, nil)
+	}
+	return &frame{src: img.Bounds(), img: dst}
+}
+
+// channels returns the frame's shifted red, green and blue planes, from 0 to 1. Pixels
+// shifted in from outside the frame repeat its edge.
+func (f *frame) channels() [3]*plane {
+	w, h := f.img.Rect.Dx(), f.img.Rect.Dy()
+	var c [3]*plane
+	for i := range c {
+		c[i] = newPlane(w, h)
+	}
+	for y := range h {
+		sy := clamp(y+f.dy, h-1)
+		for x := range w {
+			o := f.img.PixOffset(clamp(x+f.dx, w-1), sy)
+			for i := range c {
+				c[i].pix[y*w+x] = float32(f.img.Pix[o+i]) / 255
+			}
+		}
+	}
+	return c
+}
+
+// fuse blends the frames by exposure fusion.
+func fuse(frames []*frame) *image.RGBA {
+	w, h := frames[0].img.Rect.Dx(), frames[0].img.Rect.Dy()
+
+	// Weigh every frame, then normalize the weights of each pixel to sum to one.
+	weights := make([]*plane, len(frames))
+	total := newPlane(w, h)
+	for k, f := range frames {
+		weights[k] = weigh(f.channels())
+		for i, v := range weights[k].pix {
+			total.pix[i] += v
+		}
+	}
+
+	levels := pyramidLevels(w, h)
+	var result [3][]*plane
+	for k, f := range frames {
+		for i, v := range weights[k].pix {
+			weights[k].pix[i] = v / total.pix[i]
+		}
+		gw := gaussianPyramid(weights[k], levels)
+		for c, ch := range f.channels() {
+			lp := laplacianPyramid(ch, levels)
+			if result[c] == nil {
+				result[c] = make([]*plane, levels)
+				for l, p := range lp {
+					result[c][l] = newPlane(p.w, p.h)
+				}
+			}
+			for l, p := range lp {
+				acc := result[c][l].pix
+				for i, v := range p.pix {
+					acc[i] += v * gw[l].pix[i]
+				}
+			}
+		}
+		weights[k] = nil
+	}
+
+	out := image.NewRGBA(image.Rect(0, 0, w, h))
+	for c := range result {
+		merged := collapse(result[c])
+		for i, v := range merged.pix {
+			out.Pix[i*4+c] = uint8(math.Round(float64(min(max(v, 0), 1)) * 255))
+		}
+	}
+	for i := 3; i < len(out.Pix); i += 4 {
+		out.Pix[i] = 0xff
+	}
+	return out
+}
+
+// weigh returns the fusion weight of every pixel of an exposure: the product of its local
+// contrast, its saturation and how close it is to mid-gray.
+func weigh(c [3]*plane) *plane {
+	w, h := c[0].w, c[0].h
+	gray := newPlane(w, h)
+	for i := range gray.pix {
+		gray.pix[i] = 0.299*c[0].pix[i] + 0.587*c[1].pix[i] + 0.114*c[2].pix[i]
+	}
+
+	const denom = 2 * exposureSigma * exposureSigma
+	out := newPlane(w, h)
+	for y := range h {
+		for x := range w {
+			i := y*w + x
+			contrast := math.Abs(float64(4*gray.pix[i] - gray.at(x-1, y) - gray.at(x+1, y) -
+				gray.at(x, y-1) - gray.at(x, y+1)))
+
+			r, g, b := float64(c[0].pix[i]), float64(c[1].pix[i]), float64(c[2].pix[i])
+			mean := (r + g + b) / 3
+			saturation := math.Sqrt(((r-mean)*(r-mean) + (g-mean)*(g-mean) + (b-mean)*(b-mean)) / 3)
+			exposedness := math.Exp(-((r-0.5)*(r-0.5) + (g-0.5)*(g-0.5) + (b-0.5)*(b-0.5)) / denom)
+
+			// The epsilon keeps flat, gray areas, weighted zero by every exposure, an average.
+			out.pix[i] = float32(contrast*saturation*exposedness) + 1e-12
+		}
+	}
+	return out
+}
+
+// pyramidLevels is how many levels the blending pyramid of a w by h image has.
+func pyramidLevels(w, h int) int {
+	levels := 1
+	for edge := min(w, h); edge/2 >= minPyramidEdge; edge /= 2 {
+		levels++
+	}
+	return levels
+}
@@ -0,0 +1,184 @@
+package hdr
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	_ "image/jpeg"
+	"image/png"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scene is the radiance of a dim room with a bright window on the right, with texture at
+// several scales so exposures can be lined up. Values are relative to a normal exposure's
+// white.
+func scene(w, h int) [][3]float64 {
+	rng := rand.New(rand.NewSource(7))
+	blocks := []int{4, 13, 37}
+	textures := make([][]float64, len(blocks))
+	for i, b := range blocks {
+		textures[i] = make([]float64, (w/b+1)*(h/b+1))
+		for j := range textures[i] {
+			textures[i][j] = rng.Float64() - 0.5
+		}
+	}
+	out := make([][3]float64, w*h)
+	for y := range h {
+		for x := range w {
+			t := 1.0
+			for i, b := range blocks {
+				t += 0.4 * textures[i][(y/b)*(w/b+1)+x/b]
+			}
+			if x >= w*3/4 {
+				out[y*w+x] = [3]float64{1.1 * t, 1.15 * t, 1.3 * t}
+			} else {
+				out[y*w+x] = [3]float64{0.2 * t, 0.17 * t, 0.14 * t}
+			}
+		}
+	}
+	return out
+}
+
+// exposure renders radiance at gain, clipping highlights, shifted by (dx, dy): the result's
+// pixel (x+dx, y+dy) shows the scene at (x, y).
+func exposure(t *testing.T, radiance [][3]float64, w, h int, gain float64, dx, dy int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			sx, sy := min(max(x-dx, 0), w-1), min(max(y-dy, 0), h-1)
+			r := radiance[sy*w+sx]
+			c := func(v float64) uint8 { return uint8(min(v*gain, 1) * 255) }
+			img.Set(x, y, color.RGBA{R: c(r[0]), G: c(r[1]), B: c(r[2]), A: 0xff})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// mean returns the average luminance, out of 255, of img within r.
+func mean(img image.Image, r image.Rectangle) float64 {
+	sum := 0.0
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			c := color.GrayModel.Convert(img.At(x, y)).(color.Gray)
+			sum += float64(c.Y)
+		}
+	}
+	return sum / float64(r.Dx()*r.Dy())
+}
+
+func TestMerge(t *testing.T) {
+	const w, h = 160, 120
+	radiance := scene(w, h)
+	normal := exposure(t, radiance, w, h, 1, 0, 0)
+	over := exposure(t, radiance, w, h, 4, 0, 0)
+	under := exposure(t, radiance, w, h, 0.35, 0, 0)
+
+	decode := func(t *testing.T, b []byte) image.Image {
+		t.Helper()
+		img, _, err := image.Decode(bytes.NewReader(b))
+		require.NoError(t, err)
+		return img
+	}
+	room := image.Rect(10, 10, w/2, h-10)
+	window := image.Rect(w*3/4+10, 10, w-10, h-10)
+
+	t.Run("success: lifts the room and recovers the window", func(t *testing.T) {
+		out, err := Merge([][]byte{normal, over, under})
+		require.NoError(t, err)
+
+		merged, base := decode(t, out), decode(t, exposure(t, radiance, w, h, 1, 0, 0))
+		assert.Equal(t, image.Pt(w, h), merged.Bounds().Size())
+		assert.Greater(t, mean(merged, room), mean(base, room)+10, "room should be brighter than the normal exposure")
+		assert.Less(t, mean(merged, window), 250.0, "window should not be blown out")
+	})
+
+	t.Run("success: handheld exposures are lined up", func(t *testing.T) {
+		shifted := exposure(t, radiance, w, h, 4, 5, -3)
+		aligned, err := Merge([][]byte{normal, shifted})
+		require.NoError(t, err)
+		unshifted, err := Merge([][]byte{normal, over})
+		require.NoError(t, err)
+
+		a, b := decode(t, aligned), decode(t, unshifted)
+		inner := image.Rect(20, 20, w-20, h-20)
+		assert.InDelta(t, mean(b, inner), mean(a, inner), 2)
+	})
+
+	t.Run("success: large exposures are scaled down", func(t *testing.T) {
+		big := image.NewGray(image.Rect(0, 0, MaxEdge*2, 64))
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, big))
+
+		out, err := Merge([][]byte{buf.Bytes(), buf.Bytes()})
+		require.NoError(t, err)
+		assert.Equal(t, image.Pt(MaxEdge, 32), decode(t, out).Bounds().Size())
+	})
+
+	t.Run("fail: different shots", func(t *testing.T) {
+		_, err := Merge([][]byte{normal, exposure(t, scene(h, w), h, w, 4, 0, 0)})
+		assert.ErrorIs(t, err, ErrMismatch)
+	})
+
+	t.Run("fail: one exposure", func(t *testing.T) {
+		_, err := Merge([][]byte{normal})
+		assert.ErrorContains(t, err, "merge needs 2 to 7 exposures, got 1")
+	})
+
+	t.Run("fail: corrupt exposure", func(t *testing.T) {
+		_, err := Merge([][]byte{normal, []byte("not an image")})
+		assert.ErrorContains(t, err, "decode exposure 2")
+	})
+}
+
+func TestAlign(t *testing.T) {
+	const w, h = 200, 150
+	radiance := scene(w, h)
+
+	testCases := []struct {
+		name           string
+		dx, dy         int
+		gain           float64
+		wantDX, wantDY int
+	}{
+		{name: "success: no shift", gain: 1},
+		{name: "success: shifted brighter exposure", dx: 7, dy: -4, gain: 3, wantDX: 7, wantDY: -4},
+		{name: "success: shifted darker exposure", dx: -12, dy: 9, gain: 0.5, wantDX: -12, wantDY: 9},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var frames []*frame
+			for _, b := range [][]byte{
+				exposure(t, radiance, w, h, 1, 0, 0),
+				exposure(t, radiance, w, h, tc.gain, tc.dx, tc.dy),
+			} {
+				img, err := png.Decode(bytes.NewReader(b))
+				require.NoError(t, err)
+				frames = append(frames, newFrame(img, image.Pt(w, h)))
+			}
+
+			align(frames)
+			assert.Equal(t, [2]int{tc.wantDX, tc.wantDY}, [2]int{frames[1].dx, frames[1].dy})
+		})
+	}
+}
+
+func TestPyramid(t *testing.T) {
+	p := newPlane(37, 23)
+	rng := rand.New(rand.NewSource(1))
+	for i := range p.pix {
+		p.pix[i] = rng.Float32()
+	}
+
+	got := collapse(laplacianPyramid(p, pyramidLevels(p.w, p.h)))
+	require.Equal(t, p.w, got.w)
+	require.Equal(t, p.h, got.h)
+	assert.InDeltaSlice(t, p.pix, got.pix, 1e-5)
+}
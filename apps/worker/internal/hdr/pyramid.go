@@ -0,0 +1,124 @@
+package hdr
+
+// plane is one channel of an image.
+type plane struct {
+	w, h int
+	pix  []float32
+}
+
+// newPlane returns a w by h plane of zeros.
+func newPlane(w, h int) *plane {
+	return &plane{w: w, h: h, pix: make([]float32, w*h)}
+}
+
+// at returns the value at (x, y), repeating the edge outside the plane.
+func (p *plane) at(x, y int) float32 {
+	return p.pix[clamp(y, p.h-1)*p.w+clamp(x, p.w-1)]
+}
+
+// clamp limits i to [0, n].
+func clamp(i, n int) int {
+	return min(max(i, 0), n)
+}
+
+// reduce blurs s with the 5-tap binomial kernel at i.
+func reduce(s []float32, i int) float32 {
+	n := len(s) - 1
+	return (s[clamp(i-2, n)] + 4*s[clamp(i-1, n)] + 6*s[clamp(i, n)] + 4*s[clamp(i+1, n)] + s[clamp(i+2, n)]) / 16
+}
+
+// expand interpolates s, upsampled by two, at i with the binomial kernel.
+func expand(s []float32, i int) float32 {
+	n := len(s) - 1
+	j := i / 2
+	if i%2 == 0 {
+		return (s[clamp(j-1, n)] + 6*s[clamp(j, n)] + s[clamp(j+1, n)]) / 8
+	}
+	return (s[clamp(j, n)] + s[clamp(j+1, n)]) / 2
+}
+
+// downsample blurs p and halves it.
+func downsample(p *plane) *plane {
+	w, h := (p.w+1)/2, (p.h+1)/2
+	rows := newPlane(w, p.h)
+	for y := range p.h {
+		row := p.pix[y*p.w : (y+1)*p.w]
+		for x := range w {
+			rows.pix[y*w+x] = reduce(row, 2*x)
+		}
+	}
+	out := newPlane(w, h)
+	col := make([]float32, p.h)
+	for x := range w {
+		for y := range p.h {
+			col[y] = rows.pix[y*w+x]
+		}
+		for y := range h {
+			out.pix[y*w+x] = reduce(col, 2*y)
+		}
+	}
+	return out
+}
+
+// upsample doubles p to w by h, the size of the plane it was downsampled from.
+func upsample(p *plane, w, h int) *plane {
+	rows := newPlane(w, p.h)
+	for y := range p.h {
+		row := p.pix[y*p.w : (y+1)*p.w]
+		for x := range w {
+			rows.pix[y*w+x] = expand(row, x)
+		}
+	}
+	out := newPlane(w, h)
+	col := make([]float32, p.h)
+	for x := range w {
+		for y := range p.h {
+			col[y] = rows.pix[y*w+x]
+		}
+		for y := range h {
+			out.pix[y*w+x] = expand(col, y)
+		}
+	}
+	return out
+}
+
+// gaussianPyramid returns p and successively halved blurs of it.
+func gaussianPyramid(p *plane, levels int) []*plane {
+	pyr := make([]*plane, levels)
+	pyr[0] = p
+	for l := 1; l < levels; l++ {
+		pyr[l] = downsample(pyr[l-1])
+	}
+	return pyr
+}
+
+// laplacianPyramid returns the detail p loses at every halving, then the coarsest level.
+// collapse puts p back together from it.
+func laplacianPyramid(p *plane, levels int) []*plane {
+	pyr := make([]*plane, levels)
+	cur := p
+	for l := 0; l < levels-1; l++ {
+		next := downsample(cur)
+		detail := upsample(next, cur.w, cur.h)
+		for i, v := range cur.pix {
+			detail.pix[i] = v - detail.pix[i]
+		}
+		pyr[l] = detail
+		cur = next
+	}
+	pyr[levels-1] = cur
+	return pyr
+}
+
+// collapse rebuilds an image from its Laplacian pyramid.
+func collapse(pyr []*plane) *plane {
+	cur := pyr[len(pyr)-1]
+	for l := len(pyr) - 2; l >= 0; l-- {
+		up := upsample(cur, pyr[l].w, pyr[l].h)
+		for i, v := range pyr[l].pix {
+			up.pix[i] += v
+		}
+		cur = up
+	}
+	return cur
+}
@@ -0,0 +1,235 @@
+// Package httpclient builds the http.Clients the worker uses to call other services. Each
+// destination gets its own timeout, retry policy and connection pool, and every request
+// is traced with OpenTelemetry, so no outbound call goes through http.DefaultClient.
+package httpclient
+
+import (
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope of outbound request spans.
+const tracerName = "real-staging-worker/httpclient"
+
+// Policy configures the client for one destination.
+type Policy struct {
+	// Timeout bounds a whole request, retries included. Zero means no limit.
+	Timeout time.Duration
+	// MaxAttempts is how many times a retryable request is tried; at least 1.
+	MaxAttempts int
+	// RetryBackoff is the wait before the first retry. It doubles for each retry after that.
+	RetryBackoff time.Duration
+	// MaxIdleConnsPerHost caps the idle connections kept for reuse per host.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost caps all connections per host. Zero means no limit.
+	MaxConnsPerHost int
+	// IdleConnTimeout closes idle connections after this long.
+	IdleConnTimeout time.Duration
+}
+
+// Option customizes a client built by New.
+type Option func(*options)
+
+type options struct {
+	wrap   func(http.RoundTripper) http.RoundTripper
+	policy *Policy
+}
+
+// WithTransport wraps the client's pooled transport, e.g. for fault injection. Retries
+// and tracing sit outside the wrapper, so each attempt passes through it.
+func WithTransport(wrap func(base http.RoundTripper) http.RoundTripper) Option {
+	return func(o *options) { o.wrap = wrap }
+}
+
+// WithPolicy replaces the destination's default policy.
+func WithPolicy(p Policy) Option {
+	return func(o *options) { o.policy = &p }
+}
+
+// New returns a client for dest, configured with its policy from Policies.
+func New(dest Destination, opts ...Option) *http.Client {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	policy := PolicyFor(dest)
+	if o.policy != nil {
+		policy = *o.policy
+	}
+
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.MaxIdleConnsPerHost = policy.MaxIdleConnsPerHost
+	base.MaxConnsPerHost = policy.MaxConnsPerHost
+	if policy.IdleConnTimeout > 0 {
+		base.IdleConnTimeout = policy.IdleConnTimeout
+	}
+	var rt http.RoundTripper = base
+	if o.wrap != nil {
+		rt = o.wrap(rt)
+	}
+
+	return &http.Client{
+		Timeout: policy.Timeout,
+		Transport: &transport{
+			dest:   dest,
+			policy: policy,
+			base:   rt,
+			sleep:  sleep,
+		},
+	}
+}
+
+// PolicyFor returns the policy for dest, or DefaultPolicy when it has none.
+func PolicyFor(dest Destination) Policy {
+	if p, ok := Policies[dest]; ok {
+		return p
+	}
+	return DefaultPolicy
+}
+
+// transport traces each request and retries the ones that are safe to repeat.
+type transport struct {
+	dest   Destination
+	policy Policy
+	base   http.RoundTripper
+	sleep  func(req *http.Request, d time.Duration) error
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := otel.Tracer(tracerName).Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", req.URL.Hostname()),
+			attribute.String("url.path", req.URL.Path),
+			attribute.String("http.destination", string(t.dest)),
+		),
+	)
+	defer span.End()
+
+	req = req.Clone(ctx)
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	attempts := max(t.policy.MaxAttempts, 1)
+	if !retryable(req) {
+		attempts = 1
+	}
+	backoff := t.policy.RetryBackoff
+	for attempt := 1; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if attempt >= attempts || !shouldRetry(req, resp, err) {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "request failed")
+				return nil, err
+			}
+			span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+			if resp.StatusCode >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, resp.Status)
+			}
+			return resp, nil
+		}
+
+		span.AddEvent("retry", trace.WithAttributes(
+			attribute.Int("http.request.resend_count", attempt),
+			attribute.String("retry.reason", retryReason(resp, err)),
+		))
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4<<10))
+			_ = resp.Body.Close()
+		}
+		if err := t.sleep(req, jitter(backoff)); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "request canceled")
+			return nil, err
+		}
+		backoff *= 2
+		if req, err = rewind(req); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "request body not replayable")
+			return nil, err
+		}
+	}
+}
+
+// retryable reports whether req may be sent again: its method is idempotent, or it
+// carries an Idempotency-Key, and its body, if any, can be replayed.
+func retryable(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return req.Header.Get("Idempotency-Key") != ""
+	}
+}
+
+// shouldRetry reports whether the attempt failed in a way a later attempt may not:
+// a transport error, rate limiting, or the upstream being unavailable.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		return req.Context().Err() == nil
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryReason describes a failed attempt for the retry span event.
+func retryReason(resp *http.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return resp.Status
+}
+
+// rewind returns a copy of req with a fresh body for the next attempt.
+func rewind(req *http.Request) (*http.Request, error) {
+	if req.GetBody == nil {
+		return req, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("rewind request body: %w", err)
+	}
+	next := req.Clone(req.Context())
+	next.Body = body
+	return next, nil
+}
+
+// jitter spreads d over [d/2, 3d/2) so clients retrying together do not stay in step.
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d)
+}
+
+// sleep waits d, or returns the request's context error if it ends first.
+func sleep(req *http.Request, d time.Duration) error {
+	if d <= 0 {
+		return req.Context().Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-timer.C:
+		return nil
+	}
+}
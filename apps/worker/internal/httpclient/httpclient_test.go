@@ -0,0 +1,159 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success: applies the destination policy", func(t *testing.T) {
+		c := New(DestinationReplicateCDN)
+		assert.Equal(t, Policies[DestinationReplicateCDN].Timeout, c.Timeout)
+		tr := c.Transport.(*transport)
+		assert.Equal(t, DestinationReplicateCDN, tr.dest)
+		base := tr.base.(*http.Transport)
+		assert.Equal(t, Policies[DestinationReplicateCDN].MaxIdleConnsPerHost, base.MaxIdleConnsPerHost)
+	})
+
+	t.Run("success: unknown destination uses the default policy", func(t *testing.T) {
+		c := New(Destination("unknown"))
+		assert.Equal(t, DefaultPolicy.Timeout, c.Timeout)
+	})
+
+	t.Run("success: transport wrapper sees every attempt", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer srv.Close()
+
+		var wrapped atomic.Int32
+		c := New(DestinationReplicateCDN,
+			WithPolicy(Policy{MaxAttempts: 3}),
+			WithTransport(func(base http.RoundTripper) http.RoundTripper {
+				return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					wrapped.Add(1)
+					return base.RoundTrip(req)
+				})
+			}),
+		)
+		resp, err := c.Get(srv.URL)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, int32(2), wrapped.Load())
+	})
+}
+
+func TestTransport_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		header     http.Header
+		statuses   []int
+		wantStatus int
+		wantCalls  int32
+	}{
+		{
+			name:       "success: GET retried after 503",
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusServiceUnavailable, http.StatusOK},
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+		},
+		{
+			name:       "success: POST with idempotency key retried with its body",
+			method:     http.MethodPost,
+			body:       `{"a":1}`,
+			header:     http.Header{"Idempotency-Key": []string{"key-1"}},
+			statuses:   []int{http.StatusTooManyRequests, http.StatusOK},
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+		},
+		{
+			name:       "fail: POST without idempotency key not retried",
+			method:     http.MethodPost,
+			body:       `{"a":1}`,
+			statuses:   []int{http.StatusServiceUnavailable, http.StatusOK},
+			wantStatus: http.StatusServiceUnavailable,
+			wantCalls:  1,
+		},
+		{
+			name:       "fail: client error not retried",
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusBadRequest, http.StatusOK},
+			wantStatus: http.StatusBadRequest,
+			wantCalls:  1,
+		},
+		{
+			name:       "fail: gives up after the last attempt",
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusOK},
+			wantStatus: http.StatusBadGateway,
+			wantCalls:  3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := calls.Add(1)
+				body, _ := io.ReadAll(r.Body)
+				assert.Equal(t, tt.body, string(body))
+				w.WriteHeader(tt.statuses[n-1])
+			}))
+			defer srv.Close()
+
+			c := New(DestinationReplicate, WithPolicy(Policy{MaxAttempts: 3, RetryBackoff: time.Millisecond}))
+			req, err := http.NewRequestWithContext(context.Background(), tt.method, srv.URL, strings.NewReader(tt.body))
+			require.NoError(t, err)
+			for k, v := range tt.header {
+				req.Header[k] = v
+			}
+
+			resp, err := c.Do(req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantCalls, calls.Load())
+		})
+	}
+
+	t.Run("fail: context canceled during backoff", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer srv.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		c := New(DestinationReplicateCDN, WithPolicy(Policy{MaxAttempts: 3, RetryBackoff: time.Hour}))
+		c.Transport.(*transport).sleep = func(req *http.Request, d time.Duration) error {
+			cancel()
+			return sleep(req, d)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+		require.NoError(t, err)
+
+		_, err = c.Do(req)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
@@ -0,0 +1,55 @@
+package httpclient
+
+import "time"
+
+// Destination names a service the worker calls.
+type Destination string
+
+const (
+	// DestinationReplicate is the Replicate API: creating, polling and canceling predictions.
+	DestinationReplicate Destination = "replicate"
+	// DestinationReplicateCDN serves prediction outputs for download.
+	DestinationReplicateCDN Destination = "replicate_cdn"
+	// DestinationWebhooks is customer-owned webhook endpoints, such as Slack and Teams.
+	DestinationWebhooks Destination = "webhooks"
+)
+
+// DefaultPolicy applies to destinations missing from Policies.
+var DefaultPolicy = Policy{
+	Timeout:             30 * time.Second,
+	MaxAttempts:         3,
+	RetryBackoff:        200 * time.Millisecond,
+	MaxIdleConnsPerHost: 4,
+	IdleConnTimeout:     90 * time.Second,
+}
+
+// Policies holds each destination's policy.
+var Policies = map[Destination]Policy{
+	// The Replicate client retries 429s and 5xx itself, honoring Retry-After, so requests
+	// are sent once here. Every in-flight job polls its prediction, so keep a connection
+	// per concurrent job.
+	DestinationReplicate: {
+		Timeout:             30 * time.Second,
+		MaxAttempts:         1,
+		MaxIdleConnsPerHost: 16,
+		IdleConnTimeout:     90 * time.Second,
+	},
+	// Outputs are several megabytes and lost once they expire, so give downloads time and
+	// retry them.
+	DestinationReplicateCDN: {
+		Timeout:             60 * time.Second,
+		MaxAttempts:         3,
+		RetryBackoff:        500 * time.Millisecond,
+		MaxIdleConnsPerHost: 8,
+		IdleConnTimeout:     90 * time.Second,
+	},
+	// Customer endpoints are slow and untrusted: a short timeout, a small pool per host, and
+	// no retries, since a delivery is not known to be idempotent.
+	DestinationWebhooks: {
+		Timeout:             10 * time.Second,
+		MaxAttempts:         1,
+		MaxIdleConnsPerHost: 1,
+		MaxConnsPerHost:     4,
+		IdleConnTimeout:     30 * time.Second,
+	},
+}
@@ -0,0 +1,68 @@
+package jobarchive
+
+import (
+	"context"
+	"time"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/schedule"
+)
+
+// Config controls an archive run.
+type Config struct {
+	// AfterDays is how long a job stays in jobs after it finishes.
+	AfterDays int
+	// BatchSize is the number of jobs moved per statement.
+	BatchSize int
+}
+
+// Archiver moves finished jobs older than the configured age to jobs_history.
+type Archiver struct {
+	repo Repository
+	cfg  Config
+	log  logging.Logger
+	now  func() time.Time
+}
+
+// NewArchiver creates an Archiver.
+func NewArchiver(repo Repository, cfg Config, log logging.Logger) *Archiver {
+	if cfg.AfterDays <= 0 {
+		cfg.AfterDays = 30
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 1000
+	}
+	return &Archiver{repo: repo, cfg: cfg, log: log, now: time.Now}
+}
+
+// Run archives batches until fewer than a full batch is moved and returns the total.
+// Each batch commits on its own, so a failure keeps the batches already moved.
+func (a *Archiver) Run(ctx context.Context) (int, error) {
+	cutoff := a.now().UTC().AddDate(0, 0, -a.cfg.AfterDays)
+	total := 0
+	for {
+		n, err := a.repo.ArchiveBatch(ctx, cutoff, a.cfg.BatchSize)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		if n < a.cfg.BatchSize {
+			return total, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+	}
+}
+
+// RunDaily blocks until ctx is done, archiving jobs each day at hourUTC.
+func (a *Archiver) RunDaily(ctx context.Context, hourUTC int) {
+	schedule.Daily(ctx, hourUTC, func(ctx context.Context, _ time.Time) {
+		n, err := a.Run(ctx)
+		if err != nil {
+			a.log.Error(ctx, "Job archive failed", "error", err, "archived", n)
+			return
+		}
+		a.log.Info(ctx, "Job archive completed", "archived", n, "after_days", a.cfg.AfterDays)
+	})
+}
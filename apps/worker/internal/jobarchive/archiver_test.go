@@ -0,0 +1,72 @@
+package jobarchive
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+)
+
+func TestArchiver_Run(t *testing.T) {
+	now := time.Date(2025, 3, 31, 4, 0, 0, 0, time.UTC)
+
+	t.Run("success: archives until a partial batch", func(t *testing.T) {
+		batches := []int{10, 10, 3}
+		repo := &RepositoryMock{
+			ArchiveBatchFunc: func(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+				assert.Equal(t, time.Date(2025, 3, 1, 4, 0, 0, 0, time.UTC), cutoff)
+				assert.Equal(t, 10, limit)
+				n := batches[0]
+				batches = batches[1:]
+				return n, nil
+			},
+		}
+		a := NewArchiver(repo, Config{AfterDays: 30, BatchSize: 10}, &logging.LoggerMock{})
+		a.now = func() time.Time { return now }
+
+		n, err := a.Run(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 23, n)
+		assert.Len(t, repo.ArchiveBatchCalls(), 3)
+	})
+
+	t.Run("fail: returns archived count on error", func(t *testing.T) {
+		calls := 0
+		repo := &RepositoryMock{
+			ArchiveBatchFunc: func(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+				calls++
+				if calls == 2 {
+					return 0, errors.New("boom")
+				}
+				return limit, nil
+			},
+		}
+		a := NewArchiver(repo, Config{BatchSize: 5}, &logging.LoggerMock{})
+		a.now = func() time.Time { return now }
+
+		n, err := a.Run(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, 5, n)
+	})
+
+	t.Run("success: defaults applied", func(t *testing.T) {
+		repo := &RepositoryMock{
+			ArchiveBatchFunc: func(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+				assert.Equal(t, now.AddDate(0, 0, -30), cutoff)
+				assert.Equal(t, 1000, limit)
+				return 0, nil
+			},
+		}
+		a := NewArchiver(repo, Config{}, &logging.LoggerMock{})
+		a.now = func() time.Time { return now }
+
+		n, err := a.Run(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, n)
+	})
+}
@@ -0,0 +1,74 @@
+// Package jobarchive moves finished jobs out of the hot jobs table into jobs_history
+// so pending-job polling and admin job searches only scan recent work.
+package jobarchive
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository moves terminal jobs to the history table.
+type Repository interface {
+	// ArchiveBatch moves up to limit completed, failed or canceled jobs that finished
+	// before cutoff into jobs_history and returns how many were moved.
+	ArchiveBatch(ctx context.Context, cutoff time.Time, limit int) (int, error)
+}
+
+// DefaultRepository is a sql.DB-backed Repository.
+type DefaultRepository struct {
+	db *sql.DB
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewRepository creates a DefaultRepository.
+func NewRepository(db *sql.DB) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+// archiveBatchQuery deletes a batch of terminal jobs and inserts them into jobs_history in
+// one statement. SKIP LOCKED lets several workers archive at once without waiting on each
+// other. A job is always created before it finishes, so created_at < cutoff is implied by
+// finished_at < cutoff; stating it lets the planner skip newer monthly partitions.
+const archiveBatchQuery = `
+	WITH moved AS (
+		DELETE FROM jobs
+		WHERE (id, created_at) IN (
+			SELECT id, created_at
+			FROM jobs
+			WHERE status IN ('completed', 'failed', 'canceled')
+			  AND created_at < $1
+			  AND finished_at < $1
+			ORDER BY created_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, image_id, type, payload_json, status, error, created_at, started_at, finished_at,
+			prediction_id, output_key, model_version, provider_response
+	)
+	INSERT INTO jobs_history (
+		id, image_id, type, payload_json, status, error, created_at, started_at, finished_at,
+		prediction_id, output_key, model_version, provider_response
+	)
+	SELECT id, image_id, type, payload_json, status, error, created_at, started_at, finished_at,
+		prediction_id, output_key, model_version, provider_response
+	FROM moved
+	ON CONFLICT (id) DO NOTHING`
+
+// ArchiveBatch moves one batch of jobs.
+func (r *DefaultRepository) ArchiveBatch(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	res, err := r.db.ExecContext(ctx, archiveBatchQuery, cutoff, limit)
+	if err != nil {
+		return 0, fmt.Errorf("archive jobs: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("archive jobs rows affected: %w", err)
+	}
+	return int(n), nil
+}
@@ -0,0 +1,58 @@
+package jobarchive
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultRepository_ArchiveBatch(t *testing.T) {
+	cutoff := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	query := regexp.QuoteMeta(archiveBatchQuery)
+
+	testCases := []struct {
+		name    string
+		setup   func(mock sqlmock.Sqlmock)
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "success: moves a batch",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs(cutoff, 500).WillReturnResult(sqlmock.NewResult(0, 42))
+			},
+			want: 42,
+		},
+		{
+			name: "fail: exec error",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs(cutoff, 500).WillReturnError(errors.New("boom"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+			tc.setup(mock)
+
+			got, err := NewRepository(db).ArchiveBatch(context.Background(), cutoff, 500)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Package joblog records the processing log a user sees for a stage:run job: provider
+// progress lines, retry notices and the outcome. Every line is sanitized before it is
+// stored, so the log can be returned to the image owner as-is.
+package joblog
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/real-staging-ai/worker/internal/dbretry"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out recorder_mock.go . Recorder
+
+// Recorder appends lines to the processing log of an image's current job.
+type Recorder interface {
+	// Append sanitizes line and adds it, timestamped, to the log of the image's current job.
+	Append(ctx context.Context, imageID, line string) error
+}
+
+// SQLRepository stores job logs in the job_logs table with database/sql.
+type SQLRepository struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+// Ensure SQLRepository implements Recorder.
+var _ Recorder = (*SQLRepository)(nil)
+
+const (
+	// MaxLog caps a job's log, in characters. Once it is exceeded the oldest lines are
+	// dropped and the log is marked truncated.
+	MaxLog = 16 << 10
+	// MaxLine caps a single sanitized line, in bytes.
+	MaxLine = 300
+)
+
+// NewSQLRepository creates a SQLRepository.
+func NewSQLRepository(db *sql.DB) *SQLRepository {
+	return &SQLRepository{db: db, now: time.Now}
+}
+
+// appendQuery adds a line to the log of the image's most recent stage:run job, keeping
+// only the last MaxLog characters. Trimming starts at a line boundary, so the log never
+// begins with half a line. Without a job there is nothing to log against and no row is
+// written.
+const appendQuery = `
+	WITH job AS (
+		SELECT id
+		FROM jobs
+		WHERE image_id = $1::uuid AND type = 'stage:run'
+		ORDER BY created_at DESC
+		LIMIT 1
+	)
+	INSERT INTO job_logs (job_id, image_id, log)
+	SELECT id, $1::uuid, $2 FROM job
+	ON CONFLICT (job_id) DO UPDATE SET
+		log = CASE
+			WHEN char_length(job_logs.log || EXCLUDED.log) > $3
+			THEN regexp_replace(right(job_logs.log || EXCLUDED.log, $3), '^[^\n]*\n', '')
+			ELSE job_logs.log || EXCLUDED.log
+		END,
+		truncated = job_logs.truncated OR char_length(job_logs.log || EXCLUDED.log) > $3,
+		updated_at = now()`
+
+// Append adds the sanitized line to the image's current job log. Lines that are empty
+// after sanitizing are dropped.
+func (r *SQLRepository) Append(ctx context.Context, imageID, line string) error {
+	line = Sanitize(line)
+	if line == "" {
+		return nil
+	}
+	entry := r.now().UTC().Format(time.RFC3339) + " " + line + "\n"
+	err := dbretry.Do(ctx, "append job log", func(ctx context.Context) error {
+		_, err := r.db.ExecContext(ctx, appendQuery, imageID, entry, MaxLog)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("append job log: %w", err)
+	}
+	return nil
+}
+
+var (
+	// ansiEscape matches terminal color and cursor sequences.
+	ansiEscape = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+	// urlPattern matches URLs, which may be signed or point at private buckets.
+	urlPattern = regexp.MustCompile(`(?i)\b(?:https?|s3|gs|data):\S+`)
+	// secretParam matches key=value pairs whose value is a credential.
+	secretParam = regexp.MustCompile(`(?i)\b(token|key|secret|signature|password|credential)=\S+`)
+	// bearerToken matches authorization headers echoed into logs.
+	bearerToken = regexp.MustCompile(`(?i)\bbearer\s+\S+`)
+	// providerToken matches Replicate API tokens.
+	providerToken = regexp.MustCompile(`\br8_[A-Za-z0-9]+`)
+)
+
+// Sanitize makes a provider or worker message safe to show the image owner. Progress
+// bars redrawn with carriage returns keep only their final state, terminal escapes and
+// control characters are removed, URLs and credentials are redacted, and the result is
+// capped at MaxLine bytes.
+func Sanitize(s string) string {
+	if i := strings.LastIndex(strings.TrimRight(s, "\r\n"), "\r"); i >= 0 {
+		s = s[i+1:]
+	}
+	s = ansiEscape.ReplaceAllString(s, "")
+	s = urlPattern.ReplaceAllString(s, "[url]")
+	s = secretParam.ReplaceAllString(s, "$1=[redacted]")
+	s = bearerToken.ReplaceAllString(s, "Bearer [redacted]")
+	s = providerToken.ReplaceAllString(s, "[redacted]")
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, s)
+	s = strings.Join(strings.Fields(s), " ")
+	if len(s) > MaxLine {
+		s = truncate(s, MaxLine-len("…")) + "…"
+	}
+	return s
+}
+
+// LastLine returns the last non-blank line of a multi-line provider log.
+func LastLine(logs string) string {
+	lines := strings.Split(strings.TrimRight(logs, " \t\r\n"), "\n")
+	return lines[len(lines)-1]
+}
+
+// truncate shortens s to at most n bytes without splitting a UTF-8 sequence.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,102 @@
+package joblog
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const imageID = "2e1aa86e-0f27-4f0f-9e57-0d7b53b4d9b9"
+
+func TestSQLRepository_Append(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 5, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		line    string
+		setup   func(mock sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name: "success: sanitized line appended to the current job",
+			line: "Downloading https://bucket.s3.amazonaws.com/a.jpg?X-Amz-Signature=abc",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO job_logs`).
+					WithArgs(imageID, "2026-03-01T12:00:05Z Downloading [url]\n", MaxLog).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:  "success: blank line is dropped",
+			line:  "\x1b[0m \r\n",
+			setup: func(mock sqlmock.Sqlmock) {},
+		},
+		{
+			name: "fail: exec error",
+			line: "Prediction started",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO job_logs`).WillReturnError(errors.New("boom"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+			tc.setup(mock)
+
+			repo := NewSQLRepository(db)
+			repo.now = func() time.Time { return now }
+			err = repo.Append(context.Background(), imageID, tc.line)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	testCases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "success: plain line kept", in: "Prediction started", want: "Prediction started"},
+		{name: "success: progress bar keeps its final state", in: " 10%|█ | 1/10\r 50%|█████ | 5/10\r",
+			want: "50%|█████ | 5/10"},
+		{name: "success: terminal escapes removed", in: "\x1b[32mdone\x1b[0m", want: "done"},
+		{name: "success: control characters become spaces", in: "a\tb\x00c", want: "a b c"},
+		{name: "success: urls redacted", in: "fetching s3://bucket/uploads/u1/a.jpg now",
+			want: "fetching [url] now"},
+		{name: "success: credentials redacted", in: "auth token=abc123 Bearer xyz r8_AbC123",
+			want: "auth token=[redacted] Bearer [redacted] [redacted]"},
+		{name: "success: long line capped", in: strings.Repeat("é", MaxLine),
+			want: strings.Repeat("é", (MaxLine-len("…"))/2) + "…"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Sanitize(tc.in)
+			assert.Equal(t, tc.want, got)
+			assert.LessOrEqual(t, len(got), MaxLine)
+		})
+	}
+}
+
+func TestLastLine(t *testing.T) {
+	assert.Equal(t, "step 3/3", LastLine("step 1/3\nstep 2/3\nstep 3/3\n\n"))
+	assert.Equal(t, "only", LastLine("only"))
+	assert.Equal(t, "", LastLine(""))
+}
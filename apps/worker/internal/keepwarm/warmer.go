@@ -0,0 +1,146 @@
+// Package keepwarm keeps the production model warm on Replicate during quiet periods.
+//
+// Replicate scales an idle model down to zero, so the first prediction after a lull waits
+// 60-90s for a cold boot. A Warmer issues a minimal prediction whenever no prediction has
+// run for an interval, inside configured hours and within a daily budget.
+package keepwarm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/real-staging-ai/worker/internal/costguard"
+	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/schedule"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out predictor_mock.go . Predictor
+
+// Predictor runs warm-up predictions. staging.DefaultService implements it.
+type Predictor interface {
+	// WarmUp runs a minimal prediction on the production model.
+	WarmUp(ctx context.Context) error
+	// WarmUpCost returns the expected provider cost in USD of one WarmUp.
+	WarmUpCost() float64
+	// LastPrediction returns when the last prediction was created, zero if none was.
+	LastPrediction() time.Time
+}
+
+// Config controls when warm-ups run.
+type Config struct {
+	// Interval is the time between checks, and how long the model may sit idle before it
+	// is warmed.
+	Interval time.Duration
+	// StartHourUTC and EndHourUTC bound the hours warm-ups run in, wrapping past midnight
+	// when StartHourUTC is later. Equal values run all day.
+	StartHourUTC int
+	EndHourUTC   int
+	// DailyBudgetUSD caps warm-up spend per UTC day.
+	DailyBudgetUSD float64
+}
+
+// Outcome is what a check did.
+type Outcome string
+
+// Check outcomes.
+const (
+	OutcomeWarmed       Outcome = "warmed"
+	OutcomeOutsideHours Outcome = "outside_hours"
+	OutcomeRecentlyUsed Outcome = "recently_used"
+	OutcomeOverBudget   Outcome = "over_budget"
+	OutcomeSpendCeiling Outcome = "spend_ceiling"
+	OutcomeFailed       Outcome = "failed"
+)
+
+// warmUpTimeout bounds one warm-up, cold boot included.
+const warmUpTimeout = 5 * time.Minute
+
+// Warmer issues warm-up predictions. Its budget is kept in memory per worker, so each
+// worker that enables it may spend DailyBudgetUSD.
+type Warmer struct {
+	predictor Predictor
+	// spend is the daily spend ceiling warm-ups count against. Nil skips it.
+	spend costguard.Guard
+	cfg   Config
+	log   logging.Logger
+	now   func() time.Time
+
+	day   time.Time
+	spent float64
+}
+
+// NewWarmer creates a Warmer. spend, when not nil, is the daily spend ceiling shared with
+// staging jobs.
+func NewWarmer(predictor Predictor, spend costguard.Guard, cfg Config, log logging.Logger) *Warmer {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 5 * time.Minute
+	}
+	return &Warmer{predictor: predictor, spend: spend, cfg: cfg, log: log, now: time.Now}
+}
+
+// Run warms the model unless it is outside the configured hours, a prediction was created
+// within the interval, or the warm-up would exceed the daily budget or spend ceiling.
+func (w *Warmer) Run(ctx context.Context) (Outcome, error) {
+	now := w.now().UTC()
+	if !w.inHours(now) {
+		return OutcomeOutsideHours, nil
+	}
+	if last := w.predictor.LastPrediction(); !last.IsZero() && now.Sub(last) < w.cfg.Interval {
+		return OutcomeRecentlyUsed, nil
+	}
+
+	day := now.Truncate(24 * time.Hour)
+	if !day.Equal(w.day) {
+		w.day, w.spent = day, 0
+	}
+	cost := w.predictor.WarmUpCost()
+	if w.spent+cost > w.cfg.DailyBudgetUSD {
+		return OutcomeOverBudget, nil
+	}
+	if w.spend != nil {
+		if err := w.spend.Reserve(ctx, cost); err != nil {
+			var ceiling *costguard.CeilingError
+			if errors.As(err, &ceiling) {
+				return OutcomeSpendCeiling, nil
+			}
+			return OutcomeFailed, err
+		}
+	}
+	w.spent += cost
+
+	ctx, cancel := context.WithTimeout(ctx, warmUpTimeout)
+	defer cancel()
+	if err := w.predictor.WarmUp(ctx); err != nil {
+		return OutcomeFailed, err
+	}
+	return OutcomeWarmed, nil
+}
+
+// inHours reports whether now falls within the configured hours.
+func (w *Warmer) inHours(now time.Time) bool {
+	start, end, hour := w.cfg.StartHourUTC, w.cfg.EndHourUTC, now.Hour()
+	switch {
+	case start == end:
+		return true
+	case start < end:
+		return hour >= start && hour < end
+	default:
+		return hour >= start || hour < end
+	}
+}
+
+// RunEvery blocks until ctx is done, checking every configured interval.
+func (w *Warmer) RunEvery(ctx context.Context) {
+	schedule.Every(ctx, w.cfg.Interval, func(ctx context.Context) {
+		outcome, err := w.Run(ctx)
+		switch {
+		case err != nil:
+			w.log.Warn(ctx, "Model warm-up failed", "error", err)
+		case outcome == OutcomeWarmed:
+			w.log.Info(ctx, "Model warmed", "spent_today_usd", w.spent)
+		case outcome == OutcomeOverBudget || outcome == OutcomeSpendCeiling:
+			w.log.Debug(ctx, "Model warm-up skipped", "reason", string(outcome))
+		}
+	})
+}
@@ -0,0 +1,144 @@
+package keepwarm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/real-staging-ai/worker/internal/costguard"
+	"github.com/real-staging-ai/worker/internal/logging"
+)
+
+func TestWarmer_Run(t *testing.T) {
+	now := time.Date(2025, 3, 8, 14, 0, 0, 0, time.UTC)
+	cfg := Config{Interval: 5 * time.Minute, StartHourUTC: 12, EndHourUTC: 22, DailyBudgetUSD: 0.1}
+
+	cases := []struct {
+		name        string
+		cfg         Config
+		now         time.Time
+		last        time.Time
+		spentToday  float64
+		reserveErr  error
+		warmUpErr   error
+		want        Outcome
+		wantErr     bool
+		wantWarmUps int
+	}{
+		{
+			name:        "success: idle model is warmed",
+			cfg:         cfg,
+			now:         now,
+			last:        now.Add(-10 * time.Minute),
+			want:        OutcomeWarmed,
+			wantWarmUps: 1,
+		},
+		{
+			name:        "success: never used model is warmed",
+			cfg:         cfg,
+			now:         now,
+			want:        OutcomeWarmed,
+			wantWarmUps: 1,
+		},
+		{
+			name:        "success: hours wrap past midnight",
+			cfg:         Config{Interval: 5 * time.Minute, StartHourUTC: 22, EndHourUTC: 6, DailyBudgetUSD: 1},
+			now:         time.Date(2025, 3, 8, 3, 0, 0, 0, time.UTC),
+			want:        OutcomeWarmed,
+			wantWarmUps: 1,
+		},
+		{
+			name: "success: recent prediction skips the warm-up",
+			cfg:  cfg,
+			now:  now,
+			last: now.Add(-time.Minute),
+			want: OutcomeRecentlyUsed,
+		},
+		{
+			name: "success: outside hours skips the warm-up",
+			cfg:  cfg,
+			now:  time.Date(2025, 3, 8, 23, 0, 0, 0, time.UTC),
+			want: OutcomeOutsideHours,
+		},
+		{
+			name:       "success: spent budget skips the warm-up",
+			cfg:        cfg,
+			now:        now,
+			spentToday: 0.09,
+			want:       OutcomeOverBudget,
+		},
+		{
+			name:       "success: spend ceiling skips the warm-up",
+			cfg:        cfg,
+			now:        now,
+			reserveErr: &costguard.CeilingError{Ceiling: 10},
+			want:       OutcomeSpendCeiling,
+		},
+		{
+			name:       "fail: spend guard unavailable",
+			cfg:        cfg,
+			now:        now,
+			reserveErr: errors.New("redis down"),
+			want:       OutcomeFailed,
+			wantErr:    true,
+		},
+		{
+			name:        "fail: prediction fails",
+			cfg:         cfg,
+			now:         now,
+			warmUpErr:   errors.New("prediction failed"),
+			want:        OutcomeFailed,
+			wantErr:     true,
+			wantWarmUps: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			predictor := &PredictorMock{
+				WarmUpFunc:         func(ctx context.Context) error { return tc.warmUpErr },
+				WarmUpCostFunc:     func() float64 { return 0.03 },
+				LastPredictionFunc: func() time.Time { return tc.last },
+			}
+			spend := &costguard.GuardMock{
+				ReserveFunc: func(ctx context.Context, cost float64) error { return tc.reserveErr },
+			}
+			w := NewWarmer(predictor, spend, tc.cfg, &logging.LoggerMock{})
+			w.now = func() time.Time { return tc.now }
+			w.day, w.spent = tc.now.Truncate(24*time.Hour), tc.spentToday
+
+			got, err := w.Run(context.Background())
+
+			assert.Equal(t, tc.want, got)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Len(t, predictor.WarmUpCalls(), tc.wantWarmUps)
+		})
+	}
+}
+
+func TestWarmer_Run_BudgetResetsDaily(t *testing.T) {
+	day := time.Date(2025, 3, 8, 14, 0, 0, 0, time.UTC)
+	predictor := &PredictorMock{
+		WarmUpFunc:         func(ctx context.Context) error { return nil },
+		WarmUpCostFunc:     func() float64 { return 0.03 },
+		LastPredictionFunc: func() time.Time { return time.Time{} },
+	}
+	w := NewWarmer(predictor, nil, Config{Interval: time.Minute, DailyBudgetUSD: 0.05}, &logging.LoggerMock{})
+
+	w.now = func() time.Time { return day }
+	got, _ := w.Run(context.Background())
+	assert.Equal(t, OutcomeWarmed, got)
+	got, _ = w.Run(context.Background())
+	assert.Equal(t, OutcomeOverBudget, got)
+
+	w.now = func() time.Time { return day.Add(24 * time.Hour) }
+	got, _ = w.Run(context.Background())
+	assert.Equal(t, OutcomeWarmed, got)
+}
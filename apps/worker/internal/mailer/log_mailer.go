@@ -0,0 +1,26 @@
+package mailer
+
+import (
+	"context"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+)
+
+// LogMailer logs messages instead of sending them. It is used when SMTP is not configured.
+type LogMailer struct {
+	log logging.Logger
+}
+
+// Ensure LogMailer implements Mailer.
+var _ Mailer = (*LogMailer)(nil)
+
+// NewLogMailer creates a LogMailer.
+func NewLogMailer(log logging.Logger) *LogMailer {
+	return &LogMailer{log: log}
+}
+
+// Send logs the recipient and subject.
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	m.log.Info(ctx, "Email not sent (SMTP not configured)", "to", msg.To, "subject", msg.Subject)
+	return nil
+}
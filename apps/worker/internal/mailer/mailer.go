@@ -0,0 +1,20 @@
+// Package mailer sends transactional email from the worker.
+package mailer
+
+import (
+	"context"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out mailer_mock.go . Mailer
+
+// Message is a plain-text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers email messages.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
@@ -0,0 +1,82 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"net/smtp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/worker/internal/config"
+)
+
+func TestNewSMTPMailer(t *testing.T) {
+	testCases := []struct {
+		name    string
+		cfg     config.SMTP
+		wantErr bool
+	}{
+		{name: "success: with auth", cfg: config.SMTP{Host: "smtp.example.com", Port: 587, Username: "u", From: "a@b.c"}},
+		{name: "success: without auth", cfg: config.SMTP{Host: "localhost", Port: 1025, From: "a@b.c"}},
+		{name: "fail: missing host", cfg: config.SMTP{From: "a@b.c"}, wantErr: true},
+		{name: "fail: missing from", cfg: config.SMTP{Host: "localhost"}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := NewSMTPMailer(tc.cfg)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.cfg.Username != "", m.auth != nil)
+		})
+	}
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	newMailer := func(sendErr error, sent *[]byte) *SMTPMailer {
+		m, err := NewSMTPMailer(config.SMTP{Host: "localhost", Port: 1025, From: "noreply@example.com"})
+		require.NoError(t, err)
+		m.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+		m.send = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+			assert.Equal(t, "localhost:1025", addr)
+			assert.Equal(t, []string{"user@example.com"}, to)
+			*sent = msg
+			return sendErr
+		}
+		return m
+	}
+
+	t.Run("success: formats headers and body", func(t *testing.T) {
+		var sent []byte
+		err := newMailer(nil, &sent).Send(context.Background(), Message{
+			To: "user@example.com", Subject: "Hello", Body: "line 1\nline 2",
+		})
+		require.NoError(t, err)
+		assert.Contains(t, string(sent), "From: noreply@example.com\r\n")
+		assert.Contains(t, string(sent), "Subject: Hello\r\n")
+		assert.Contains(t, string(sent), "\r\n\r\nline 1\r\nline 2")
+	})
+
+	t.Run("fail: header injection", func(t *testing.T) {
+		var sent []byte
+		err := newMailer(nil, &sent).Send(context.Background(), Message{
+			To: "user@example.com", Subject: "Hi\r\nBcc: x@y.z", Body: "b",
+		})
+		assert.Error(t, err)
+		assert.Nil(t, sent)
+	})
+
+	t.Run("fail: relay error", func(t *testing.T) {
+		var sent []byte
+		err := newMailer(errors.New("refused"), &sent).Send(context.Background(), Message{
+			To: "user@example.com", Subject: "Hello", Body: "b",
+		})
+		assert.ErrorContains(t, err, "refused")
+	})
+}
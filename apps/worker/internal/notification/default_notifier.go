@@ -0,0 +1,129 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	redis "github.com/redis/go-redis/v9"
+
+	"github.com/real-staging-ai/worker/internal/dbretry"
+	"github.com/real-staging-ai/worker/internal/logging"
+)
+
+// DefaultNotifier inserts notifications into Postgres and publishes them through Redis.
+type DefaultNotifier struct {
+	db *sql.DB
+	// rdb publishes new notifications to open streams. Nil records them without pushing.
+	rdb *redis.Client
+}
+
+// Ensure DefaultNotifier implements Notifier.
+var _ Notifier = (*DefaultNotifier)(nil)
+
+// NewDefaultNotifier creates a DefaultNotifier. A nil rdb records notifications that
+// users see the next time they list them.
+func NewDefaultNotifier(db *sql.DB, rdb *redis.Client) *DefaultNotifier {
+	return &DefaultNotifier{db: db, rdb: rdb}
+}
+
+// onConflict drops a notification while an unread one with the same dedupe key exists,
+// which also makes the inserts safe to retry.
+const onConflict = `
+	ON CONFLICT (user_id, dedupe_key) WHERE read_at IS NULL AND dedupe_key IS NOT NULL DO NOTHING
+	RETURNING id, user_id, type, title, body, data, created_at`
+
+// ImageReady notifies the owner of the image's project. Retried jobs do not notify twice
+// while the first notification is unread.
+func (n *DefaultNotifier) ImageReady(ctx context.Context, imageID string) error {
+	const q = `
+		INSERT INTO notifications (user_id, type, title, body, data, dedupe_key)
+		SELECT p.user_id, 'image_ready', 'Your staged image is ready',
+			'An image in ' || p.name || ' has finished staging.',
+			jsonb_build_object('image_id', i.id::text, 'project_id', p.id::text),
+			'image_ready:' || i.id::text
+		FROM images i
+		JOIN projects p ON p.id = i.project_id
+		WHERE i.id = $1::uuid` + onConflict
+	return n.insert(ctx, "notify image ready", q, imageID)
+}
+
+// ExportComplete notifies every admin that the partition was exported.
+func (n *DefaultNotifier) ExportComplete(ctx context.Context, partition, runID string) error {
+	const q = `
+		INSERT INTO notifications (user_id, type, title, body, data, dedupe_key)
+		SELECT u.id, 'export_complete', 'Warehouse export complete',
+			'The nightly export of ' || $1::text || ' finished.',
+			jsonb_build_object('partition', $1::text, 'run_id', $2::text),
+			'export_complete:' || $1::text
+		FROM users u
+		WHERE u.role = 'admin'` + onConflict
+	return n.insert(ctx, "notify export complete", q, partition, runID)
+}
+
+// SLACredit notifies the owner of the credit granted for the image. It notifies nothing
+// when the image was not credited.
+func (n *DefaultNotifier) SLACredit(ctx context.Context, imageID string) error {
+	const q = `
+		INSERT INTO notifications (user_id, type, title, body, data, dedupe_key)
+		SELECT c.user_id, 'sla_credit', 'You earned an image credit',
+			'An image in ' || p.name || ' took longer than your plan''s turnaround target, ' ||
+				'so we credited you one image.',
+			jsonb_build_object('image_id', i.id::text, 'project_id', p.id::text,
+				'turnaround_seconds', (c.turnaround_ms / 1000)::text),
+			'sla_credit:' || i.id::text
+		FROM sla_credits c
+		JOIN images i ON i.id = c.image_id
+		JOIN projects p ON p.id = i.project_id
+		WHERE c.image_id = $1::uuid` + onConflict
+	return n.insert(ctx, "notify sla credit", q, imageID)
+}
+
+// insert runs an INSERT ... RETURNING of notifications and publishes each row created.
+func (n *DefaultNotifier) insert(ctx context.Context, op, q string, args ...any) error {
+	type created struct {
+		userID string
+		n      Notification
+	}
+	var rows []created
+	err := dbretry.Do(ctx, op, func(ctx context.Context) error {
+		rows = rows[:0]
+		res, err := n.db.QueryContext(ctx, q, args...)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = res.Close() }()
+		for res.Next() {
+			var c created
+			var data []byte
+			if err := res.Scan(&c.n.ID, &c.userID, &c.n.Type, &c.n.Title, &c.n.Body, &data, &c.n.CreatedAt); err != nil {
+				return err
+			}
+			if err := json.Unmarshal(data, &c.n.Data); err != nil {
+				return fmt.Errorf("decode notification data: %w", err)
+			}
+			rows = append(rows, c)
+		}
+		return res.Err()
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if n.rdb == nil {
+		return nil
+	}
+	for _, c := range rows {
+		payload, err := json.Marshal(c.n)
+		if err != nil {
+			return err
+		}
+		if err := n.rdb.Publish(ctx, Channel(c.userID), payload).Err(); err != nil {
+			// The row is stored; the user sees it the next time they list notifications.
+			logging.Default().Warn(ctx, "Failed to publish notification",
+				"notification_id", c.n.ID, "user_id", c.userID, "error", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	miniredis "github.com/alicebob/miniredis/v2"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var notificationColumns = []string{"id", "user_id", "type", "title", "body", "data", "created_at"}
+
+func TestDefaultNotifier_ImageReady(t *testing.T) {
+	created := time.Unix(1700000000, 0).UTC()
+
+	testCases := []struct {
+		name        string
+		setup       func(mock sqlmock.Sqlmock)
+		wantErr     bool
+		wantPublish bool
+	}{
+		{
+			name: "success: notifies and publishes to the owner",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO notifications`).WithArgs("img-1").
+					WillReturnRows(sqlmock.NewRows(notificationColumns).AddRow(
+						"n-1", "user-1", TypeImageReady, "Your staged image is ready", "An image in Maple St has finished staging.",
+						[]byte(`{"image_id":"img-1","project_id":"proj-1"}`), created,
+					))
+			},
+			wantPublish: true,
+		},
+		{
+			name: "success: unread duplicate inserts nothing",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO notifications`).WithArgs("img-1").
+					WillReturnRows(sqlmock.NewRows(notificationColumns))
+			},
+		},
+		{
+			name: "fail: insert error",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO notifications`).WithArgs("img-1").WillReturnError(errors.New("boom"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+			tc.setup(mock)
+
+			mr := miniredis.RunT(t)
+			rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			sub := rdb.Subscribe(ctx, Channel("user-1"))
+			_, err = sub.Receive(ctx)
+			require.NoError(t, err)
+			defer func() { _ = sub.Close() }()
+
+			err = NewDefaultNotifier(db, rdb).ImageReady(ctx, "img-1")
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, mock.ExpectationsWereMet())
+
+			if !tc.wantPublish {
+				return
+			}
+			select {
+			case msg := <-sub.Channel():
+				assert.JSONEq(t, `{
+					"id":"n-1","type":"image_ready","title":"Your staged image is ready",
+					"body":"An image in Maple St has finished staging.",
+					"data":{"image_id":"img-1","project_id":"proj-1"},
+					"created_at":"2023-11-14T22:13:20Z"
+				}`, msg.Payload)
+			case <-ctx.Done():
+				t.Fatal("timed out waiting for notification")
+			}
+		})
+	}
+}
+
+func TestDefaultNotifier_ExportComplete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	rows := sqlmock.NewRows(notificationColumns)
+	for _, admin := range []string{"admin-1", "admin-2"} {
+		rows.AddRow("n-"+admin, admin, TypeExportComplete, "Warehouse export complete", "",
+			[]byte(`{"partition":"dt=2026-10-16","run_id":"run-1"}`), time.Now())
+	}
+	mock.ExpectQuery(`WHERE u.role = 'admin'`).WithArgs("dt=2026-10-16", "run-1").WillReturnRows(rows)
+
+	// Without Redis the rows are still recorded.
+	require.NoError(t, NewDefaultNotifier(db, nil).ExportComplete(context.Background(), "dt=2026-10-16", "run-1"))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDefaultNotifier_SLACredit(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	rows := sqlmock.NewRows(notificationColumns).AddRow("n-1", "user-1", TypeSLACredit,
+		"You earned an image credit", "", []byte(`{"image_id":"img-1","turnaround_seconds":"720"}`), time.Now())
+	mock.ExpectQuery(`FROM sla_credits c`).WithArgs("img-1").WillReturnRows(rows)
+
+	require.NoError(t, NewDefaultNotifier(db, nil).SLACredit(context.Background(), "img-1"))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,48 @@
+// Package notification records in-app notifications for events the worker finishes, such
+// as a staged image or a warehouse export, and publishes them to each user's Redis channel
+// so the API can push them to open notification streams.
+package notification
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out notifier_mock.go . Notifier
+
+// Notification types, matching the API's notification package.
+const (
+	TypeImageReady     = "image_ready"
+	TypeExportComplete = "export_complete"
+	TypeSLACredit      = "sla_credit"
+)
+
+// ChannelPrefix namespaces the per-user Redis channels the API's notification streams read.
+const ChannelPrefix = "notifications:user:"
+
+// Channel returns the Redis channel a user's new notifications are published to.
+func Channel(userID string) string {
+	return ChannelPrefix + userID
+}
+
+// Notification mirrors the API's JSON for a notification.
+type Notification struct {
+	ID        string            `json:"id"`
+	Type      string            `json:"type"`
+	Title     string            `json:"title"`
+	Body      string            `json:"body"`
+	Data      map[string]string `json:"data"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// Notifier tells users about work the worker finished. Implementations must not fail
+// the job that triggered them: callers log returned errors and carry on.
+type Notifier interface {
+	// ImageReady notifies the owner of the image's project that it finished staging.
+	ImageReady(ctx context.Context, imageID string) error
+	// ExportComplete notifies every admin that the warehouse export of partition finished.
+	ExportComplete(ctx context.Context, partition, runID string) error
+	// SLACredit notifies the owner of the image that its missed turnaround target was
+	// credited.
+	SLACredit(ctx context.Context, imageID string) error
+}
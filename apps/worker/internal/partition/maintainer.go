@@ -0,0 +1,80 @@
+package partition
+
+import (
+	"context"
+	"time"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/schedule"
+)
+
+// Config controls a maintenance run.
+type Config struct {
+	// MonthsAhead is how many months after the current one get a partition.
+	MonthsAhead int
+}
+
+// Result summarizes a maintenance run.
+type Result struct {
+	// Created is the number of partitions created, per table.
+	Created map[string]int
+	// DefaultRows is the number of rows in each table's default partition.
+	DefaultRows map[string]int64
+}
+
+// Maintainer creates upcoming monthly partitions and reports rows stranded in default partitions.
+type Maintainer struct {
+	repo Repository
+	cfg  Config
+	log  logging.Logger
+}
+
+// NewMaintainer creates a Maintainer.
+func NewMaintainer(repo Repository, cfg Config, log logging.Logger) *Maintainer {
+	if cfg.MonthsAhead <= 0 {
+		cfg.MonthsAhead = 3
+	}
+	return &Maintainer{repo: repo, cfg: cfg, log: log}
+}
+
+// Run ensures partitions exist for every table in Tables. Rows in a default partition
+// mean a month was written before its partition existed; that month's partition cannot
+// be created until the rows are moved, so they are logged as a warning for an operator.
+func (m *Maintainer) Run(ctx context.Context) (Result, error) {
+	res := Result{Created: map[string]int{}, DefaultRows: map[string]int64{}}
+	for _, table := range Tables {
+		created, err := m.repo.EnsureMonthlyPartitions(ctx, table, m.cfg.MonthsAhead)
+		if err != nil {
+			return res, err
+		}
+		res.Created[table] = created
+
+		n, err := m.repo.CountDefaultRows(ctx, table)
+		if err != nil {
+			return res, err
+		}
+		res.DefaultRows[table] = n
+		if n > 0 {
+			m.log.Warn(ctx, "Rows found in default partition", "table", table, "rows", n)
+		}
+	}
+	return res, nil
+}
+
+// RunDaily runs maintenance immediately, so a fresh deployment has its partitions, and
+// then each day at hourUTC until ctx is done.
+func (m *Maintainer) RunDaily(ctx context.Context, hourUTC int) {
+	m.runAndLog(ctx)
+	schedule.Daily(ctx, hourUTC, func(ctx context.Context, _ time.Time) {
+		m.runAndLog(ctx)
+	})
+}
+
+func (m *Maintainer) runAndLog(ctx context.Context) {
+	res, err := m.Run(ctx)
+	if err != nil {
+		m.log.Error(ctx, "Partition maintenance failed", "error", err)
+		return
+	}
+	m.log.Info(ctx, "Partition maintenance completed", "created", res.Created, "default_rows", res.DefaultRows)
+}
@@ -0,0 +1,75 @@
+package partition
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+)
+
+func newLogger() *logging.LoggerMock {
+	return &logging.LoggerMock{
+		InfoFunc:  func(ctx context.Context, msg string, keysAndValues ...any) {},
+		WarnFunc:  func(ctx context.Context, msg string, keysAndValues ...any) {},
+		ErrorFunc: func(ctx context.Context, msg string, keysAndValues ...any) {},
+	}
+}
+
+func TestMaintainer_Run(t *testing.T) {
+	t.Run("success: ensures every table and warns about default rows", func(t *testing.T) {
+		repo := &RepositoryMock{
+			EnsureMonthlyPartitionsFunc: func(ctx context.Context, table string, monthsAhead int) (int, error) {
+				assert.Equal(t, 3, monthsAhead)
+				if table == "images" {
+					return 1, nil
+				}
+				return 0, nil
+			},
+			CountDefaultRowsFunc: func(ctx context.Context, table string) (int64, error) {
+				if table == "jobs" {
+					return 4, nil
+				}
+				return 0, nil
+			},
+		}
+		log := newLogger()
+
+		res, err := NewMaintainer(repo, Config{}, log).Run(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"images": 1, "jobs": 0}, res.Created)
+		assert.Equal(t, map[string]int64{"images": 0, "jobs": 4}, res.DefaultRows)
+		require.Len(t, log.WarnCalls(), 1)
+		assert.Equal(t, []any{"table", "jobs", "rows", int64(4)}, log.WarnCalls()[0].KeysAndValues)
+	})
+
+	t.Run("fail: ensure error stops the run", func(t *testing.T) {
+		repo := &RepositoryMock{
+			EnsureMonthlyPartitionsFunc: func(ctx context.Context, table string, monthsAhead int) (int, error) {
+				return 0, errors.New("boom")
+			},
+		}
+
+		_, err := NewMaintainer(repo, Config{MonthsAhead: 6}, newLogger()).Run(context.Background())
+		assert.Error(t, err)
+		assert.Len(t, repo.EnsureMonthlyPartitionsCalls(), 1)
+		assert.Empty(t, repo.CountDefaultRowsCalls())
+	})
+
+	t.Run("fail: count error", func(t *testing.T) {
+		repo := &RepositoryMock{
+			EnsureMonthlyPartitionsFunc: func(ctx context.Context, table string, monthsAhead int) (int, error) {
+				return 0, nil
+			},
+			CountDefaultRowsFunc: func(ctx context.Context, table string) (int64, error) {
+				return 0, errors.New("boom")
+			},
+		}
+
+		_, err := NewMaintainer(repo, Config{}, newLogger()).Run(context.Background())
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,68 @@
+// Package partition keeps the monthly partitions of images and jobs created ahead of time
+// so new rows never land in the default partition.
+package partition
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Tables are the partitioned tables maintained by the job.
+var Tables = []string{"images", "jobs"}
+
+// Repository creates partitions and inspects default partitions.
+type Repository interface {
+	// EnsureMonthlyPartitions creates partitions of table for the current UTC month and the
+	// next monthsAhead months, returning how many were created.
+	EnsureMonthlyPartitions(ctx context.Context, table string, monthsAhead int) (int, error)
+	// CountDefaultRows returns the number of rows in table's default partition.
+	CountDefaultRows(ctx context.Context, table string) (int64, error)
+}
+
+// DefaultRepository is a sql.DB-backed Repository.
+type DefaultRepository struct {
+	db *sql.DB
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewRepository creates a DefaultRepository.
+func NewRepository(db *sql.DB) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+// EnsureMonthlyPartitions calls ensure_monthly_partitions, which serializes concurrent callers.
+func (r *DefaultRepository) EnsureMonthlyPartitions(ctx context.Context, table string, monthsAhead int) (int, error) {
+	var created int
+	err := r.db.QueryRowContext(ctx, `SELECT ensure_monthly_partitions($1::regclass, $2)`, table, monthsAhead).Scan(&created)
+	if err != nil {
+		return 0, fmt.Errorf("ensure %s partitions: %w", table, err)
+	}
+	return created, nil
+}
+
+// CountDefaultRows counts rows in the table's default partition.
+func (r *DefaultRepository) CountDefaultRows(ctx context.Context, table string) (int64, error) {
+	if !isTable(table) {
+		return 0, fmt.Errorf("unknown partitioned table %q", table)
+	}
+	var n int64
+	// table is one of Tables, so interpolating it is safe.
+	if err := r.db.QueryRowContext(ctx, `SELECT count(*) FROM `+table+`_default`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count %s default partition rows: %w", table, err)
+	}
+	return n, nil
+}
+
+func isTable(table string) bool {
+	for _, t := range Tables {
+		if t == table {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package partition
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultRepository_EnsureMonthlyPartitions(t *testing.T) {
+	query := regexp.QuoteMeta(`SELECT ensure_monthly_partitions($1::regclass, $2)`)
+
+	testCases := []struct {
+		name    string
+		setup   func(mock sqlmock.Sqlmock)
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "success: partitions created",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs("images", 3).
+					WillReturnRows(sqlmock.NewRows([]string{"ensure_monthly_partitions"}).AddRow(2))
+			},
+			want: 2,
+		},
+		{
+			name: "fail: query error",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs("images", 3).WillReturnError(errors.New("boom"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+			tc.setup(mock)
+
+			got, err := NewRepository(db).EnsureMonthlyPartitions(context.Background(), "images", 3)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDefaultRepository_CountDefaultRows(t *testing.T) {
+	testCases := []struct {
+		name    string
+		table   string
+		setup   func(mock sqlmock.Sqlmock)
+		want    int64
+		wantErr bool
+	}{
+		{
+			name:  "success: counts default partition",
+			table: "jobs",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM jobs_default`)).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+			},
+			want: 7,
+		},
+		{
+			name:    "fail: unknown table",
+			table:   "users; DROP TABLE users",
+			setup:   func(mock sqlmock.Sqlmock) {},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+			tc.setup(mock)
+
+			got, err := NewRepository(db).CountDefaultRows(context.Background(), tc.table)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package pendingbilling
+
+import (
+	"context"
+	"fmt"
+
+	redis "github.com/redis/go-redis/v9"
+)
+
+// invalidationChannel and genKeyPrefix match the API's entitlement cache.
+const (
+	invalidationChannel = "entitlements:invalidate"
+	genKeyPrefix        = "entitlements:gen:"
+)
+
+// Invalidator drops a user's cached plan entitlements after their subscriptions change.
+type Invalidator interface {
+	Invalidate(ctx context.Context, userID string) error
+}
+
+// RedisInvalidator invalidates the API's entitlement cache: it bumps the user's generation,
+// which makes cached copies stale, and publishes the user so API instances drop their
+// in-process copy.
+type RedisInvalidator struct {
+	rdb *redis.Client
+}
+
+// Ensure RedisInvalidator implements Invalidator.
+var _ Invalidator = (*RedisInvalidator)(nil)
+
+// NewRedisInvalidator creates a RedisInvalidator.
+func NewRedisInvalidator(rdb *redis.Client) *RedisInvalidator {
+	return &RedisInvalidator{rdb: rdb}
+}
+
+// Invalidate makes the user's cached entitlements stale on every API instance.
+func (i *RedisInvalidator) Invalidate(ctx context.Context, userID string) error {
+	if err := i.rdb.Incr(ctx, genKeyPrefix+userID).Err(); err != nil {
+		return fmt.Errorf("bump entitlement generation: %w", err)
+	}
+	if err := i.rdb.Publish(ctx, invalidationChannel, userID).Err(); err != nil {
+		return fmt.Errorf("publish %s: %w", invalidationChannel, err)
+	}
+	return nil
+}
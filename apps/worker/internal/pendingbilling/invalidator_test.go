@@ -0,0 +1,47 @@
+package pendingbilling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisInvalidator_Invalidate(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	t.Run("success: bumps generation and publishes", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		defer func() { _ = rdb.Close() }()
+		sub := rdb.Subscribe(ctx, invalidationChannel)
+		defer func() { _ = sub.Close() }()
+		_, err := sub.Receive(ctx)
+		require.NoError(t, err)
+
+		inv := NewRedisInvalidator(rdb)
+		require.NoError(t, inv.Invalidate(ctx, "user-1"))
+		require.NoError(t, inv.Invalidate(ctx, "user-1"))
+
+		gen, err := mr.Get(genKeyPrefix + "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, "2", gen)
+		msg, err := sub.ReceiveMessage(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", msg.Payload)
+	})
+
+	t.Run("fail: redis down", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		defer func() { _ = rdb.Close() }()
+		mr.Close()
+
+		assert.Error(t, NewRedisInvalidator(rdb).Invalidate(ctx, "user-1"))
+	})
+}
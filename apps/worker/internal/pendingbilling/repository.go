@@ -0,0 +1,177 @@
+// Package pendingbilling applies Stripe subscription and invoice updates that the API parked
+// because their customer was not yet linked to a user, once a user has the customer.
+package pendingbilling
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository applies and expires parked billing updates.
+type Repository interface {
+	// ApplySubscriptions writes up to limit parked subscriptions whose customer is now linked
+	// to a user into subscriptions, removes them, and returns the user of each one taken.
+	ApplySubscriptions(ctx context.Context, limit int) ([]string, error)
+	// ApplyInvoices is ApplySubscriptions for invoices, returning how many were taken.
+	ApplyInvoices(ctx context.Context, limit int) (int, error)
+	// MarkAttempted counts a failed mapping attempt against every unexpired parked update.
+	MarkAttempted(ctx context.Context) error
+	// DeleteExpired drops parked updates whose retry window ended before now and returns
+	// how many were dropped.
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+}
+
+// DefaultRepository is a sql.DB-backed Repository.
+type DefaultRepository struct {
+	db *sql.DB
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewRepository creates a DefaultRepository.
+func NewRepository(db *sql.DB) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+// mappedBatch deletes a batch of unexpired parked updates of one object type whose customer
+// a user now has, returning each with that user. The customer is matched as the API looks
+// users up: by blind index when the row has one, otherwise by the plaintext ID. SKIP LOCKED
+// lets several workers run at once.
+const mappedBatch = `
+	mapped AS (
+		DELETE FROM pending_billing_events p
+		USING users u
+		WHERE p.stripe_object_id IN (
+			SELECT pe.stripe_object_id
+			FROM pending_billing_events pe
+			JOIN users pu ON pu.stripe_customer_id_hash = pe.stripe_customer_id_hash
+				OR pu.stripe_customer_id = pe.stripe_customer_id
+			WHERE pe.object_type = $2 AND pe.expires_at > now()
+			ORDER BY pe.updated_at
+			LIMIT $1
+			FOR UPDATE OF pe SKIP LOCKED
+		)
+		AND (u.stripe_customer_id_hash = p.stripe_customer_id_hash OR u.stripe_customer_id = p.stripe_customer_id)
+		RETURNING u.id AS user_id, p.stripe_object_id, p.data, p.updated_at
+	)`
+
+// applySubscriptionsQuery writes mapped subscriptions. A subscription the API has written
+// since it was parked is newer than the parked state, which is then only removed.
+const applySubscriptionsQuery = `
+	WITH ` + mappedBatch + `,
+	applied AS (
+		INSERT INTO subscriptions (
+			user_id, stripe_subscription_id, status, price_id, current_period_start, current_period_end,
+			cancel_at, canceled_at, cancel_at_period_end
+		)
+		SELECT m.user_id, m.stripe_object_id, m.data->>'status', m.data->>'price_id',
+			(m.data->>'current_period_start')::timestamptz, (m.data->>'current_period_end')::timestamptz,
+			(m.data->>'cancel_at')::timestamptz, (m.data->>'canceled_at')::timestamptz,
+			COALESCE((m.data->>'cancel_at_period_end')::boolean, false)
+		FROM mapped m
+		WHERE NOT EXISTS (
+			SELECT 1 FROM subscriptions s
+			WHERE s.stripe_subscription_id = m.stripe_object_id AND s.updated_at > m.updated_at
+		)
+		ON CONFLICT (stripe_subscription_id) DO UPDATE SET
+			status = EXCLUDED.status,
+			price_id = EXCLUDED.price_id,
+			current_period_start = EXCLUDED.current_period_start,
+			current_period_end = EXCLUDED.current_period_end,
+			cancel_at = EXCLUDED.cancel_at,
+			canceled_at = EXCLUDED.canceled_at,
+			cancel_at_period_end = EXCLUDED.cancel_at_period_end,
+			updated_at = now()
+	)
+	SELECT m.user_id::text FROM mapped m`
+
+// applyInvoicesQuery writes mapped invoices, skipping those written since they were parked.
+const applyInvoicesQuery = `
+	WITH ` + mappedBatch + `,
+	applied AS (
+		INSERT INTO invoices (
+			user_id, stripe_invoice_id, stripe_subscription_id, status, amount_due, amount_paid,
+			currency, invoice_number
+		)
+		SELECT m.user_id, m.stripe_object_id, m.data->>'stripe_subscription_id', m.data->>'status',
+			COALESCE((m.data->>'amount_due')::integer, 0), COALESCE((m.data->>'amount_paid')::integer, 0),
+			m.data->>'currency', m.data->>'invoice_number'
+		FROM mapped m
+		WHERE NOT EXISTS (
+			SELECT 1 FROM invoices i
+			WHERE i.stripe_invoice_id = m.stripe_object_id AND i.updated_at > m.updated_at
+		)
+		ON CONFLICT (stripe_invoice_id) DO UPDATE SET
+			stripe_subscription_id = EXCLUDED.stripe_subscription_id,
+			status = EXCLUDED.status,
+			amount_due = EXCLUDED.amount_due,
+			amount_paid = EXCLUDED.amount_paid,
+			currency = EXCLUDED.currency,
+			invoice_number = EXCLUDED.invoice_number,
+			updated_at = now()
+	)
+	SELECT count(*) FROM mapped`
+
+const markAttemptedQuery = `
+	UPDATE pending_billing_events
+	SET attempts = attempts + 1, last_attempt_at = now()
+	WHERE expires_at > now()`
+
+const deleteExpiredQuery = `DELETE FROM pending_billing_events WHERE expires_at <= $1`
+
+// ApplySubscriptions applies one batch of subscriptions.
+func (r *DefaultRepository) ApplySubscriptions(ctx context.Context, limit int) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, applySubscriptionsQuery, limit, "subscription")
+	if err != nil {
+		return nil, fmt.Errorf("apply pending subscriptions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var userIDs []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("scan applied subscription: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("apply pending subscriptions: %w", err)
+	}
+	return userIDs, nil
+}
+
+// ApplyInvoices applies one batch of invoices.
+func (r *DefaultRepository) ApplyInvoices(ctx context.Context, limit int) (int, error) {
+	var n int
+	if err := r.db.QueryRowContext(ctx, applyInvoicesQuery, limit, "invoice").Scan(&n); err != nil {
+		return 0, fmt.Errorf("apply pending invoices: %w", err)
+	}
+	return n, nil
+}
+
+// MarkAttempted records an attempt on the remaining parked updates.
+func (r *DefaultRepository) MarkAttempted(ctx context.Context) error {
+	if _, err := r.db.ExecContext(ctx, markAttemptedQuery); err != nil {
+		return fmt.Errorf("mark pending billing events attempted: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired drops expired parked updates.
+func (r *DefaultRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	res, err := r.db.ExecContext(ctx, deleteExpiredQuery, now)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired pending billing events: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("delete expired pending billing events rows affected: %w", err)
+	}
+	return int(n), nil
+}
@@ -0,0 +1,177 @@
+package pendingbilling
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultRepository_ApplySubscriptions(t *testing.T) {
+	query := regexp.QuoteMeta(applySubscriptionsQuery)
+
+	testCases := []struct {
+		name    string
+		setup   func(mock sqlmock.Sqlmock)
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "success: returns the user of each applied subscription",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(100, "subscription").
+					WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow("user-1").AddRow("user-2"))
+			},
+			want: []string{"user-1", "user-2"},
+		},
+		{
+			name: "success: nothing mapped",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(100, "subscription").WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+			},
+		},
+		{
+			name: "fail: query error",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(100, "subscription").WillReturnError(errors.New("boom"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+			tc.setup(mock)
+
+			got, err := NewRepository(db).ApplySubscriptions(context.Background(), 100)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDefaultRepository_ApplyInvoices(t *testing.T) {
+	query := regexp.QuoteMeta(applyInvoicesQuery)
+
+	testCases := []struct {
+		name    string
+		setup   func(mock sqlmock.Sqlmock)
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "success: applies invoices",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(100, "invoice").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+			},
+			want: 2,
+		},
+		{
+			name: "fail: query error",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(100, "invoice").WillReturnError(errors.New("boom"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+			tc.setup(mock)
+
+			got, err := NewRepository(db).ApplyInvoices(context.Background(), 100)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDefaultRepository_MarkAttempted(t *testing.T) {
+	query := regexp.QuoteMeta(markAttemptedQuery)
+
+	t.Run("success: marks remaining events", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+		mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 4))
+
+		require.NoError(t, NewRepository(db).MarkAttempted(context.Background()))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail: exec error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+		mock.ExpectExec(query).WillReturnError(errors.New("boom"))
+
+		assert.Error(t, NewRepository(db).MarkAttempted(context.Background()))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDefaultRepository_DeleteExpired(t *testing.T) {
+	now := time.Date(2025, 3, 8, 12, 0, 0, 0, time.UTC)
+	query := regexp.QuoteMeta(deleteExpiredQuery)
+
+	testCases := []struct {
+		name    string
+		setup   func(mock sqlmock.Sqlmock)
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "success: deletes expired events",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs(now).WillReturnResult(sqlmock.NewResult(0, 6))
+			},
+			want: 6,
+		},
+		{
+			name: "fail: exec error",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs(now).WillReturnError(errors.New("boom"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+			tc.setup(mock)
+
+			got, err := NewRepository(db).DeleteExpired(context.Background(), now)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package pendingbilling
+
+import (
+	"context"
+	"time"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/schedule"
+)
+
+// Config controls a retry run.
+type Config struct {
+	// Interval is the time between runs.
+	Interval time.Duration
+	// BatchSize is the number of parked updates applied per statement.
+	BatchSize int
+}
+
+// Result counts what a run did.
+type Result struct {
+	Subscriptions int
+	Invoices      int
+	Expired       int
+}
+
+// Retrier periodically applies parked billing updates whose customer has been linked and
+// drops those whose retry window has ended.
+type Retrier struct {
+	repo Repository
+	// invalidator is told about users whose subscriptions were applied. Nil skips it.
+	invalidator Invalidator
+	cfg         Config
+	log         logging.Logger
+	now         func() time.Time
+}
+
+// NewRetrier creates a Retrier. invalidator, when not nil, drops the API's cached
+// entitlements of users whose subscriptions are applied.
+func NewRetrier(repo Repository, invalidator Invalidator, cfg Config, log logging.Logger) *Retrier {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Hour
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 500
+	}
+	return &Retrier{repo: repo, invalidator: invalidator, cfg: cfg, log: log, now: time.Now}
+}
+
+// Run applies mapped subscriptions, then invoices, counts an attempt against the updates
+// still unmapped, and drops expired ones. Each batch commits on its own, so a failure keeps
+// the batches already applied.
+func (r *Retrier) Run(ctx context.Context) (Result, error) {
+	var res Result
+	var err error
+	if res.Subscriptions, err = r.drain(ctx, r.applySubscriptions); err != nil {
+		return res, err
+	}
+	if res.Invoices, err = r.drain(ctx, r.repo.ApplyInvoices); err != nil {
+		return res, err
+	}
+	if err := r.repo.MarkAttempted(ctx); err != nil {
+		return res, err
+	}
+	if res.Expired, err = r.repo.DeleteExpired(ctx, r.now().UTC()); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// applySubscriptions applies one batch of subscriptions and invalidates their users'
+// entitlements. A failed invalidation is logged; the API's cache TTL bounds the staleness.
+func (r *Retrier) applySubscriptions(ctx context.Context, limit int) (int, error) {
+	userIDs, err := r.repo.ApplySubscriptions(ctx, limit)
+	if err != nil || r.invalidator == nil {
+		return len(userIDs), err
+	}
+	seen := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		if err := r.invalidator.Invalidate(ctx, userID); err != nil {
+			r.log.Warn(ctx, "Failed to invalidate entitlements", "user_id", userID, "error", err)
+		}
+	}
+	return len(userIDs), nil
+}
+
+// drain applies batches until fewer than a full batch is applied and returns the total.
+func (r *Retrier) drain(ctx context.Context, apply func(ctx context.Context, limit int) (int, error)) (int, error) {
+	total := 0
+	for {
+		n, err := apply(ctx, r.cfg.BatchSize)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		if n < r.cfg.BatchSize {
+			return total, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+	}
+}
+
+// RunEvery blocks until ctx is done, retrying parked updates every configured interval.
+func (r *Retrier) RunEvery(ctx context.Context) {
+	schedule.Every(ctx, r.cfg.Interval, func(ctx context.Context) {
+		res, err := r.Run(ctx)
+		if err != nil {
+			r.log.Error(ctx, "Pending billing retry failed", "error", err,
+				"subscriptions", res.Subscriptions, "invoices", res.Invoices)
+			return
+		}
+		if res.Expired > 0 {
+			r.log.Warn(ctx, "Dropped pending billing events whose customer was never linked", "expired", res.Expired)
+		}
+		r.log.Info(ctx, "Pending billing retry completed",
+			"subscriptions", res.Subscriptions, "invoices", res.Invoices, "expired", res.Expired)
+	})
+}
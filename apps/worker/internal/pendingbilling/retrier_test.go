@@ -0,0 +1,110 @@
+package pendingbilling
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+)
+
+// invalidatorFunc adapts a function to Invalidator.
+type invalidatorFunc func(ctx context.Context, userID string) error
+
+func (f invalidatorFunc) Invalidate(ctx context.Context, userID string) error { return f(ctx, userID) }
+
+func TestRetrier_Run(t *testing.T) {
+	now := time.Date(2025, 3, 8, 12, 0, 0, 0, time.UTC)
+
+	users := func(n int) []string {
+		ids := make([]string, n)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("user-%d", i%3)
+		}
+		return ids
+	}
+	subscriptionBatches := func(sizes ...int) func(ctx context.Context, limit int) ([]string, error) {
+		return func(ctx context.Context, limit int) ([]string, error) {
+			n := sizes[0]
+			sizes = sizes[1:]
+			return users(n), nil
+		}
+	}
+	invoiceBatches := func(sizes ...int) func(ctx context.Context, limit int) (int, error) {
+		return func(ctx context.Context, limit int) (int, error) {
+			n := sizes[0]
+			sizes = sizes[1:]
+			return n, nil
+		}
+	}
+
+	t.Run("success: applies until partial batches then expires", func(t *testing.T) {
+		repo := &RepositoryMock{
+			ApplySubscriptionsFunc: subscriptionBatches(10, 4),
+			ApplyInvoicesFunc:      invoiceBatches(7),
+			MarkAttemptedFunc:      func(ctx context.Context) error { return nil },
+			DeleteExpiredFunc: func(ctx context.Context, cutoff time.Time) (int, error) {
+				assert.Equal(t, now, cutoff)
+				return 2, nil
+			},
+		}
+		var invalidated []string
+		invalidator := invalidatorFunc(func(ctx context.Context, userID string) error {
+			invalidated = append(invalidated, userID)
+			return nil
+		})
+		r := NewRetrier(repo, invalidator, Config{BatchSize: 10}, &logging.LoggerMock{})
+		r.now = func() time.Time { return now }
+
+		res, err := r.Run(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, Result{Subscriptions: 14, Invoices: 7, Expired: 2}, res)
+		assert.Len(t, repo.ApplySubscriptionsCalls(), 2)
+		assert.Len(t, repo.MarkAttemptedCalls(), 1)
+		// Each user is invalidated once per batch.
+		assert.Equal(t, []string{"user-0", "user-1", "user-2", "user-0", "user-1", "user-2"}, invalidated)
+	})
+
+	t.Run("success: failed invalidation is logged", func(t *testing.T) {
+		repo := &RepositoryMock{
+			ApplySubscriptionsFunc: subscriptionBatches(1),
+			ApplyInvoicesFunc:      invoiceBatches(0),
+			MarkAttemptedFunc:      func(ctx context.Context) error { return nil },
+			DeleteExpiredFunc:      func(ctx context.Context, cutoff time.Time) (int, error) { return 0, nil },
+		}
+		log := &logging.LoggerMock{WarnFunc: func(ctx context.Context, msg string, keysAndValues ...any) {}}
+		invalidator := invalidatorFunc(func(ctx context.Context, userID string) error { return errors.New("down") })
+		r := NewRetrier(repo, invalidator, Config{}, log)
+
+		res, err := r.Run(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, res.Subscriptions)
+		assert.Len(t, log.WarnCalls(), 1)
+	})
+
+	t.Run("fail: stops before expiring on apply error", func(t *testing.T) {
+		repo := &RepositoryMock{
+			ApplySubscriptionsFunc: subscriptionBatches(3),
+			ApplyInvoicesFunc: func(ctx context.Context, limit int) (int, error) {
+				return 0, errors.New("boom")
+			},
+		}
+		r := NewRetrier(repo, nil, Config{}, &logging.LoggerMock{})
+
+		res, err := r.Run(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, Result{Subscriptions: 3}, res)
+		assert.Empty(t, repo.MarkAttemptedCalls())
+		assert.Empty(t, repo.DeleteExpiredCalls())
+	})
+
+	t.Run("success: defaults applied", func(t *testing.T) {
+		r := NewRetrier(&RepositoryMock{}, nil, Config{}, &logging.LoggerMock{})
+		assert.Equal(t, Config{Interval: time.Hour, BatchSize: 500}, r.cfg)
+	})
+}
@@ -0,0 +1,155 @@
+package perspective
+
+import (
+	"image"
+	"math"
+	"slices"
+
+	"golang.org/x/image/draw"
+)
+
+const (
+	// analysisEdge is the long edge photos are scaled to before lines are looked for.
+	analysisEdge = 640
+	// maxLean is how far from vertical, as dx/dy, an edge may lean and still count.
+	maxLean = 0.36 // about 20 degrees
+	// leanBins is how many leans the line search tries across [-maxLean, maxLean].
+	leanBins = 81
+	// minLineFraction is the shortest line, as a fraction of the height, that counts.
+	minLineFraction = 0.2
+	// maxLines caps how many lines the fit uses, strongest first.
+	maxLines = 40
+	// suppressX and suppressLean are how close, in pixels and lean bins, a weaker line
+	// may be to a stronger one before it is taken for the same edge.
+	suppressX    = 4
+	suppressLean = 4
+	// lineWidth is how far, in pixels, an edge pixel may be from a line and belong to it.
+	lineWidth = 1.5
+)
+
+// line is a near-vertical line x = x0 + lean*(y - cy) in analysis pixels, where cy is the
+// vertical center of the photo.
+type line struct {
+	x0, lean float64
+	votes    int
+}
+
+// edgePixel is a pixel on a strong, near-vertical edge.
+type edgePixel struct {
+	x, y int
+}
+
+// analysisGray returns img scaled down to analysisEdge as luminance from 0 to 1.
+func analysisGray(img image.Image) (gray []float64, w, h int) {
+	b := img.Bounds()
+	scale := math.Min(1, float64(analysisEdge)/float64(max(b.Dx(), b.Dy())))
+	w, h = max(int(math.Round(float64(b.Dx())*scale)), 1), max(int(math.Round(float64(b.Dy())*scale)), 1)
+	small := image.NewGray(image.Rect(0, 0, w, h))
+	draw.ApproxBiLinear.Scale(small, small.Bounds(), img, b, draw.Src, nil)
+	gray = make([]float64, w*h)
+	for i, v := range small.Pix[:w*h] {
+		gray[i] = float64(v) / 255
+	}
+	return gray, w, h
+}
+
+// verticalEdges returns the pixels of gray whose Sobel gradient is strong and within
+// maxLean of horizontal, which is to say the pixel lies on a near-vertical edge.
+func verticalEdges(gray []float64, w, h int) []edgePixel {
+	at := func(x, y int) float64 { return gray[y*w+x] }
+	var edges []edgePixel
+	const threshold = 0.35 // a step of about a tenth of the range, Sobel-weighted
+	for y := 1; y < h-1; y++ {
+		for x := 1; x < w-1; x++ {
+			gx := at(x+1, y-1) + 2*at(x+1, y) + at(x+1, y+1) - at(x-1, y-1) - 2*at(x-1, y) - at(x-1, y+1)
+			gy := at(x-1, y+1) + 2*at(x, y+1) + at(x+1, y+1) - at(x-1, y-1) - 2*at(x, y-1) - at(x+1, y-1)
+			if math.Abs(gx) >= threshold && math.Abs(gy) <= math.Abs(gx)*maxLean {
+				edges = append(edges, edgePixel{x, y})
+			}
+		}
+	}
+	return edges
+}
+
+// findLines returns the near-vertical lines the edges lie on, strongest first. Lines are
+// found with a Hough transform over position and lean, then refined by a least-squares
+// fit of the edge pixels on them.
+func findLines(edges []edgePixel, w, h int) []line {
+	cy := float64(h-1) / 2
+	leanStep := 2 * maxLean / (leanBins - 1)
+	// x0 may fall outside the photo for leaning lines near its sides.
+	pad := int(math.Ceil(maxLean * cy))
+	cols := w + 2*pad
+	votes := make([]int, leanBins*cols)
+	for _, e := range edges {
+		for b := range leanBins {
+			lean := -maxLean + float64(b)*leanStep
+			x0 := int(math.Round(float64(e.x)-lean*(float64(e.y)-cy))) + pad
+			if x0 >= 0 && x0 < cols {
+				votes[b*cols+x0]++
+			}
+		}
+	}
+
+	type peak struct{ b, x, votes int }
+	minVotes := int(minLineFraction * float64(h))
+	var peaks []peak
+	for b := range leanBins {
+		for x := range cols {
+			if v := votes[b*cols+x]; v >= minVotes {
+				peaks = append(peaks, peak{b, x, v})
+			}
+		}
+	}
+	slices.SortStableFunc(peaks, func(p, q peak) int { return q.votes - p.votes })
+
+	var kept []peak
+	for _, p := range peaks {
+		if len(kept) == maxLines {
+			break
+		}
+		near := slices.ContainsFunc(kept, func(k peak) bool {
+			return abs(k.x-p.x) <= suppressX && abs(k.b-p.b) <= suppressLean
+		})
+		if !near {
+			kept = append(kept, p)
+		}
+	}
+
+	lines := make([]line, 0, len(kept))
+	for _, p := range kept {
+		l := line{x0: float64(p.x - pad), lean: -maxLean + float64(p.b)*leanStep, votes: p.votes}
+		lines = append(lines, refine(l, edges, cy))
+	}
+	return lines
+}
+
+// refine fits l to the edge pixels within lineWidth of it.
+func refine(l line, edges []edgePixel, cy float64) line {
+	var n, sy, sx, syy, sxy float64
+	for _, e := range edges {
+		y := float64(e.y) - cy
+		if math.Abs(float64(e.x)-(l.x0+l.lean*y)) > lineWidth {
+			continue
+		}
+		n++
+		sy += y
+		sx += float64(e.x)
+		syy += y * y
+		sxy += y * float64(e.x)
+	}
+	det := n*syy - sy*sy
+	if n < 2 || det == 0 {
+		return l
+	}
+	l.lean = (n*sxy - sy*sx) / det
+	l.x0 = (sx - l.lean*sy) / n
+	return l
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
@@ -0,0 +1,212 @@
+// Package perspective straightens the vertical lines of interior photos before staging.
+// Photos taken with the camera tilted up or down show walls, door frames and windows
+// converging toward the top or bottom (keystoning), and a camera that is not level leans
+// them all to one side; staging models keep the tilt, so furniture sits at odd angles.
+//
+// Near-vertical lines are found with a Hough transform and their lean is fitted as
+// keystone plus roll: lean = keystone*u + roll, where u is a line's position from -1 at
+// the left edge to 1 at the right. Each row is then scaled about the center and shifted so
+// the lines stand upright, and the result is cropped to the area the photo still covers
+// and scaled back to the photo's size.
+package perspective
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png" // register decoder for uploaded photos
+	"math"
+
+	_ "golang.org/x/image/webp" // register decoder for uploaded photos
+)
+
+const (
+	jpegQuality = 92
+
+	// maxKeystone and maxRoll are the strongest corrections applied, as the lean of the
+	// outermost verticals and of every vertical. Stronger fits are more likely lines that
+	// are not vertical in the room, such as stairs or sloped ceilings.
+	maxKeystone = 0.27 // about 15 degrees
+	maxRoll     = 0.09 // about 5 degrees
+	// maxRowScale caps how much wider or narrower the top or bottom row is made.
+	maxRowScale = 0.35
+	// minKeystone and minRoll are the weakest corrections worth a resample.
+	minKeystone = 0.005
+	minRoll     = 0.0035 // about 0.2 degrees
+
+	// minFitLines is how many lines the fit needs, and minFitSpread how far apart, in
+	// units of half the width, the outermost must be to tell keystone from roll.
+	minFitLines  = 3
+	minFitSpread = 0.5
+	// maxResidual is how far, as dx/dy, a line's lean may be from the fit before it is
+	// dropped as not vertical in the room.
+	maxResidual = 0.015
+)
+
+var (
+	// ErrNoVerticals is returned when too few vertical lines are found to tell how the
+	// photo is tilted.
+	ErrNoVerticals = errors.New("no vertical lines found")
+	// ErrTooSkewed is returned when the lines found lean further than a camera tilt
+	// plausibly explains.
+	ErrTooSkewed = errors.New("vertical lines lean too far to correct")
+)
+
+// Result describes a correction.
+type Result struct {
+	// Applied is false when the verticals were already straight and the photo was left
+	// as shot.
+	Applied bool
+	// Keystone is the lean, in degrees, of the verticals at the left and right edges
+	// that converged toward the top or bottom.
+	Keystone float64
+	// Roll is the lean, in degrees, every vertical shared from the camera not being level.
+	Roll float64
+}
+
+// Correct straightens the verticals of photo and returns the result as a JPEG of the same
+// size. When they are already straight it returns nil and a Result that is not Applied.
+func Correct(photo []byte) ([]byte, Result, error) {
+	img, _, err := image.Decode(bytes.NewReader(photo))
+	if err != nil {
+		return nil, Result{}, fmt.Errorf("decode photo: %w", err)
+	}
+	if img.Bounds().Empty() {
+		return nil, Result{}, errors.New("decode photo: empty image")
+	}
+
+	gray, w, h := analysisGray(img)
+	keystone, roll, err := fit(findLines(verticalEdges(gray, w, h), w, h), w)
+	if err != nil {
+		return nil, Result{}, err
+	}
+	res := Result{
+		Keystone: math.Atan(keystone) * 180 / math.Pi,
+		Roll:     math.Atan(roll) * 180 / math.Pi,
+	}
+	size := img.Bounds().Size()
+	if math.Abs(keystone) > maxKeystone || math.Abs(roll) > maxRoll ||
+		math.Abs(keystone)*float64(size.Y)/float64(size.X) > maxRowScale {
+		return nil, res, fmt.Errorf("%w: keystone %.1f°, roll %.1f°", ErrTooSkewed, res.Keystone, res.Roll)
+	}
+	if math.Abs(keystone) < minKeystone && math.Abs(roll) < minRoll {
+		return nil, res, nil
+	}
+
+	out := warp(img, keystone, roll)
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, out, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, res, fmt.Errorf("encode jpeg: %w", err)
+	}
+	res.Applied = true
+	return buf.Bytes(), res, nil
+}
+
+// fit finds the keystone and roll that best explain the leans of lines in a photo w
+// pixels wide, dropping lines that do not fit until the rest do.
+func fit(lines []line, w int) (keystone, roll float64, err error) {
+	half := float64(w) / 2
+	kept := lines
+	for {
+		if len(kept) < minFitLines {
+			return 0, 0, fmt.Errorf("%w: %d lines", ErrNoVerticals, len(kept))
+		}
+		var sw, su, sl, suu, sul float64
+		minU, maxU := math.Inf(1), math.Inf(-1)
+		for _, l := range kept {
+			u := (l.x0 - (float64(w)-1)/2) / half
+			wt := float64(l.votes)
+			sw += wt
+			su += wt * u
+			sl += wt * l.lean
+			suu += wt * u * u
+			sul += wt * u * l.lean
+			minU, maxU = math.Min(minU, u), math.Max(maxU, u)
+		}
+		if maxU-minU < minFitSpread {
+			return 0, 0, fmt.Errorf("%w: lines span %.2f of the width", ErrNoVerticals, (maxU-minU)/2)
+		}
+		det := sw*suu - su*su
+		keystone = (sw*sul - su*sl) / det
+		roll = (sl - keystone*su) / sw
+
+		// Drop the worst line if it does not fit, and fit again without it.
+		worst, worstResidual := -1, maxResidual
+		for i, l := range kept {
+			u := (l.x0 - (float64(w)-1)/2) / half
+			if r := math.Abs(l.lean - (keystone*u + roll)); r > worstResidual {
+				worst, worstResidual = i, r
+			}
+		}
+		if worst < 0 {
+			return keystone, roll, nil
+		}
+		kept = append(kept[:worst:worst], kept[worst+1:]...)
+	}
+}
+
+// warp straightens img by the fitted keystone and roll. A source line at x0 (at the
+// vertical center) leans by keystone*u + roll; mapping every output row y to the source
+// row scaled by 1 + k*(y-cy) about the center and shifted by roll*(y-cy), with k the
+// keystone per pixel from the center, puts the whole line at x0. The output is cropped
+// to the part every row covers, keeping the photo's aspect ratio, and scaled to its size.
+func warp(img image.Image, keystone, roll float64) *image.RGBA {
+	b := img.Bounds()
+	src := image.NewRGBA(image.Rectangle{Max: b.Size()})
+	draw.Draw(src, src.Bounds(), img, b.Min, draw.Src)
+	w, h := b.Dx(), b.Dy()
+	cx, cy := float64(w-1)/2, float64(h-1)/2
+	k := keystone / (float64(w) / 2)
+
+	scale := func(y float64) float64 { return 1 + k*(y-cy) }
+	shift := func(y float64) float64 { return roll * (y - cy) }
+
+	// Row scale and shift are linear in y, so the columns every row covers are bounded
+	// by the top and bottom rows.
+	left, right := math.Inf(-1), math.Inf(1)
+	for _, y := range []float64{0, float64(h - 1)} {
+		left = math.Max(left, cx+(-cx-shift(y))/scale(y))
+		right = math.Min(right, cx+(float64(w-1)-cx-shift(y))/scale(y))
+	}
+	cropW := right - left
+	cropH := cropW * float64(h-1) / float64(w-1)
+	top := cy - cropH/2
+
+	out := image.NewRGBA(image.Rect(0, 0, w, h))
+	for oy := range h {
+		yd := top
+		if h > 1 {
+			yd += float64(oy) * cropH / float64(h-1)
+		}
+		a, s := scale(yd), shift(yd)
+		for ox := range w {
+			xd := left
+			if w > 1 {
+				xd += float64(ox) * cropW / float64(w-1)
+			}
+			sample(src, cx+(xd-cx)*a+s, yd, out.Pix[out.PixOffset(ox, oy):])
+		}
+	}
+	return out
+}
+
+// sample writes the bilinear interpolation of src at (x, y) to dst, repeating the edge
+// outside src.
+func sample(src *image.RGBA, x, y float64, dst []uint8) {
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	x = math.Min(math.Max(x, 0), float64(w-1))
+	y = math.Min(math.Max(y, 0), float64(h-1))
+	x0, y0 := int(x), int(y)
+	x1, y1 := min(x0+1, w-1), min(y0+1, h-1)
+	fx, fy := x-float64(x0), y-float64(y0)
+	p00, p10 := src.PixOffset(x0, y0), src.PixOffset(x1, y0)
+	p01, p11 := src.PixOffset(x0, y1), src.PixOffset(x1, y1)
+	for c := range 4 {
+		top := float64(src.Pix[p00+c])*(1-fx) + float64(src.Pix[p10+c])*fx
+		bottom := float64(src.Pix[p01+c])*(1-fx) + float64(src.Pix[p11+c])*fx
+		dst[c] = uint8(math.Round(top*(1-fy) + bottom*fy))
+	}
+}
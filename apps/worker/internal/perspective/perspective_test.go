@@ -0,0 +1,150 @@
+package perspective
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// room renders a w by h wall with door frames and window edges at fixed positions, seen
+// through a camera whose verticals lean by keystone*u + roll, u running from -1 at the
+// left edge to 1 at the right.
+func room(t *testing.T, w, h int, keystone, roll float64) []byte {
+	t.Helper()
+	rng := rand.New(rand.NewSource(3))
+	frames := []float64{0.08, 0.22, 0.38, 0.52, 0.67, 0.8, 0.93}
+	cx, cy := float64(w-1)/2, float64(h-1)/2
+	k := keystone / (float64(w) / 2)
+
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			// The position, at the vertical center, of the vertical through this pixel.
+			x0 := cx + (float64(x)-cx-roll*(float64(y)-cy))/(1+k*(float64(y)-cy))
+			v := 200 + rng.Intn(12)
+			if float64(y) > 0.08*float64(h) && float64(y) < 0.94*float64(h) {
+				for _, f := range frames {
+					if math.Abs(x0-f*float64(w)) < 3 {
+						v = 60
+					}
+				}
+			}
+			if y == h*3/4 {
+				v = 90 // a skirting board
+			}
+			img.Set(x, y, color.RGBA{R: uint8(v), G: uint8(v - 8), B: uint8(v - 20), A: 0xff})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// measure returns the keystone and roll fitted to photo.
+func measure(t *testing.T, photo []byte) (float64, float64) {
+	t.Helper()
+	img, _, err := image.Decode(bytes.NewReader(photo))
+	require.NoError(t, err)
+	gray, w, h := analysisGray(img)
+	keystone, roll, err := fit(findLines(verticalEdges(gray, w, h), w, h), w)
+	require.NoError(t, err)
+	return keystone, roll
+}
+
+func degrees(v float64) float64 { return math.Atan(v) * 180 / math.Pi }
+
+func TestCorrect(t *testing.T) {
+	const w, h = 480, 360
+
+	t.Run("success: converging and leaning verticals are straightened", func(t *testing.T) {
+		testCases := []struct {
+			name           string
+			keystone, roll float64
+		}{
+			{name: "camera tilted up", keystone: 0.1},
+			{name: "camera tilted down and not level", keystone: -0.08, roll: 0.03},
+			{name: "camera not level", roll: -0.04},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				out, res, err := Correct(room(t, w, h, tc.keystone, tc.roll))
+				require.NoError(t, err)
+				require.True(t, res.Applied)
+				assert.InDelta(t, degrees(tc.keystone), res.Keystone, 0.5)
+				assert.InDelta(t, degrees(tc.roll), res.Roll, 0.3)
+
+				cfg, err := jpegConfig(out)
+				require.NoError(t, err)
+				assert.Equal(t, image.Pt(w, h), cfg)
+
+				keystone, roll := measure(t, out)
+				assert.InDelta(t, 0, degrees(keystone), 0.4, "keystone left after correction")
+				assert.InDelta(t, 0, degrees(roll), 0.3, "roll left after correction")
+			})
+		}
+	})
+
+	t.Run("success: straight verticals are left as shot", func(t *testing.T) {
+		out, res, err := Correct(room(t, w, h, 0, 0))
+		require.NoError(t, err)
+		assert.False(t, res.Applied)
+		assert.Nil(t, out)
+	})
+
+	t.Run("fail: no vertical lines", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, w, h))))
+		_, _, err := Correct(buf.Bytes())
+		assert.ErrorIs(t, err, ErrNoVerticals)
+	})
+
+	t.Run("fail: lean too strong for a camera tilt", func(t *testing.T) {
+		_, _, err := Correct(room(t, w, h, 0, 0.15))
+		assert.ErrorIs(t, err, ErrTooSkewed)
+	})
+
+	t.Run("fail: corrupt photo", func(t *testing.T) {
+		_, _, err := Correct([]byte("not an image"))
+		assert.ErrorContains(t, err, "decode photo")
+	})
+}
+
+func TestFit(t *testing.T) {
+	lines := func(keystone, roll float64, xs ...float64) []line {
+		out := make([]line, len(xs))
+		for i, x := range xs {
+			out[i] = line{x0: x, lean: keystone*(x-99.5)/100 + roll, votes: 50}
+		}
+		return out
+	}
+
+	t.Run("success: lines that are not vertical in the room are dropped", func(t *testing.T) {
+		ls := append(lines(0.1, 0.02, 10, 60, 140, 190), line{x0: 100, lean: 0.3, votes: 80})
+		keystone, roll, err := fit(ls, 200)
+		require.NoError(t, err)
+		assert.InDelta(t, 0.1, keystone, 1e-9)
+		assert.InDelta(t, 0.02, roll, 1e-9)
+	})
+
+	t.Run("fail: too few lines", func(t *testing.T) {
+		_, _, err := fit(lines(0.1, 0, 10, 190), 200)
+		assert.ErrorIs(t, err, ErrNoVerticals)
+	})
+
+	t.Run("fail: lines too close together to tell keystone from roll", func(t *testing.T) {
+		_, _, err := fit(lines(0.1, 0, 90, 100, 110), 200)
+		assert.ErrorIs(t, err, ErrNoVerticals)
+	})
+}
+
+func jpegConfig(b []byte) (image.Point, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(b))
+	return image.Pt(cfg.Width, cfg.Height), err
+}
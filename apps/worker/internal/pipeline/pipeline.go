@@ -0,0 +1,231 @@
+// Package pipeline runs a job through an ordered set of registered stages, grouped into
+// phases: pre-process, stage, post-process, and publish. Features such as moderation,
+// thumbnails, and watermarking plug in as stages instead of being wired into the
+// processor, and every stage gets its own span and run metrics.
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+)
+
+// Phase groups stages; phases run in the order they are declared.
+type Phase int
+
+const (
+	// PhasePreProcess prepares the input, e.g. moderation of the original.
+	PhasePreProcess Phase = iota
+	// PhaseStage produces the staged output.
+	PhaseStage
+	// PhasePostProcess transforms the output, e.g. banners, watermarks, and credentials.
+	PhasePostProcess
+	// PhasePublish stores the output and anything derived from it, e.g. thumbnails.
+	PhasePublish
+)
+
+// String returns the phase's name as used in spans and logs.
+func (p Phase) String() string {
+	switch p {
+	case PhasePreProcess:
+		return "pre_process"
+	case PhaseStage:
+		return "stage"
+	case PhasePostProcess:
+		return "post_process"
+	case PhasePublish:
+		return "publish"
+	default:
+		return fmt.Sprintf("phase(%d)", int(p))
+	}
+}
+
+// Stage is one step of a pipeline. Run works on the job in place; an error stops the
+// pipeline unless the stage was registered as optional.
+type Stage[T any] interface {
+	// Name identifies the stage in spans, logs, and metrics. It must be unique per pipeline.
+	Name() string
+	// Run performs the stage on job.
+	Run(ctx context.Context, job T) error
+}
+
+// StageFunc adapts a function to a Stage.
+type StageFunc[T any] struct {
+	StageName string
+	Fn        func(ctx context.Context, job T) error
+}
+
+// Name returns the stage's name.
+func (f StageFunc[T]) Name() string { return f.StageName }
+
+// Run calls the function.
+func (f StageFunc[T]) Run(ctx context.Context, job T) error { return f.Fn(ctx, job) }
+
+// Registration places a stage in a pipeline.
+type Registration[T any] struct {
+	Stage Stage[T]
+	Phase Phase
+	// Order sorts stages within a phase, lowest first. Stages with the same order run in
+	// the order they were registered.
+	Order int
+	// Optional stages log their failures and let the pipeline continue. A failing optional
+	// stage must leave the job as it found it.
+	Optional bool
+}
+
+// Pipeline runs registered stages in phase and order.
+type Pipeline[T any] struct {
+	name string
+
+	mu      sync.Mutex
+	stages  []Registration[T]
+	metrics map[string]*StageStats
+}
+
+// New creates an empty pipeline. name prefixes its spans.
+func New[T any](name string) *Pipeline[T] {
+	return &Pipeline[T]{name: name, metrics: make(map[string]*StageStats)}
+}
+
+// Register adds a stage. It fails when the stage is nil or its name is already taken.
+func (p *Pipeline[T]) Register(reg Registration[T]) error {
+	if reg.Stage == nil {
+		return fmt.Errorf("pipeline %s: stage is required", p.name)
+	}
+	name := reg.Stage.Name()
+	if name == "" {
+		return fmt.Errorf("pipeline %s: stage name is required", p.name)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.metrics[name]; ok {
+		return fmt.Errorf("pipeline %s: stage %q already registered", p.name, name)
+	}
+	p.stages = append(p.stages, reg)
+	sort.SliceStable(p.stages, func(i, j int) bool {
+		if p.stages[i].Phase != p.stages[j].Phase {
+			return p.stages[i].Phase < p.stages[j].Phase
+		}
+		return p.stages[i].Order < p.stages[j].Order
+	})
+	p.metrics[name] = &StageStats{Name: name, Phase: reg.Phase}
+	return nil
+}
+
+// Stages returns the names of the registered stages in the order they run.
+func (p *Pipeline[T]) Stages() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	names := make([]string, len(p.stages))
+	for i, reg := range p.stages {
+		names[i] = reg.Stage.Name()
+	}
+	return names
+}
+
+// Run passes job through every stage. It stops at the first required stage that fails
+// and returns its error wrapped in a *StageError.
+func (p *Pipeline[T]) Run(ctx context.Context, job T) error {
+	p.mu.Lock()
+	stages := append([]Registration[T](nil), p.stages...)
+	p.mu.Unlock()
+
+	for _, reg := range stages {
+		if err := p.runStage(ctx, reg, job); err != nil {
+			if reg.Optional {
+				logging.Default().Warn(ctx, "optional pipeline stage failed",
+					"pipeline", p.name, "stage", reg.Stage.Name(), "error", err)
+				continue
+			}
+			return &StageError{Stage: reg.Stage.Name(), Phase: reg.Phase, Err: err}
+		}
+	}
+	return nil
+}
+
+// runStage runs one stage in its own span and records its metrics.
+func (p *Pipeline[T]) runStage(ctx context.Context, reg Registration[T], job T) error {
+	name := reg.Stage.Name()
+	tracer := otel.Tracer("real-staging-worker/pipeline")
+	ctx, span := tracer.Start(ctx, p.name+"."+name)
+	span.SetAttributes(
+		attribute.String("pipeline.stage", name),
+		attribute.String("pipeline.phase", reg.Phase.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := reg.Stage.Run(ctx, job)
+	p.record(name, time.Since(start), err)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "stage failed")
+		return err
+	}
+	span.SetStatus(codes.Ok, "stage complete")
+	return nil
+}
+
+// record adds a run of the named stage to its metrics.
+func (p *Pipeline[T]) record(name string, took time.Duration, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats := p.metrics[name]
+	stats.Runs++
+	stats.TotalDuration += took
+	if err != nil {
+		stats.Failures++
+	}
+}
+
+// Metrics returns a snapshot of every stage's metrics, in the order the stages run.
+func (p *Pipeline[T]) Metrics() []StageStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]StageStats, len(p.stages))
+	for i, reg := range p.stages {
+		out[i] = *p.metrics[reg.Stage.Name()]
+	}
+	return out
+}
+
+// StageStats counts a stage's runs since the pipeline was created.
+type StageStats struct {
+	Name          string
+	Phase         Phase
+	Runs          int64
+	Failures      int64
+	TotalDuration time.Duration
+}
+
+// MeanDuration returns the average time a run of the stage took.
+func (s StageStats) MeanDuration() time.Duration {
+	if s.Runs == 0 {
+		return 0
+	}
+	return s.TotalDuration / time.Duration(s.Runs)
+}
+
+// StageError is returned by Run when a required stage fails.
+type StageError struct {
+	Stage string
+	Phase Phase
+	Err   error
+}
+
+// Error implements error.
+func (e *StageError) Error() string {
+	return fmt.Sprintf("%s stage %s: %v", e.Phase, e.Stage, e.Err)
+}
+
+// Unwrap returns the stage's error.
+func (e *StageError) Unwrap() error { return e.Err }
@@ -0,0 +1,156 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// trace records the stages that ran, in order.
+type trace struct {
+	ran []string
+}
+
+// step returns a stage that appends name to the trace and returns err.
+func step(name string, err error) Stage[*trace] {
+	return StageFunc[*trace]{StageName: name, Fn: func(ctx context.Context, t *trace) error {
+		t.ran = append(t.ran, name)
+		return err
+	}}
+}
+
+func TestPipeline_Register(t *testing.T) {
+	tests := []struct {
+		name    string
+		regs    []Registration[*trace]
+		wantErr string
+	}{
+		{
+			name: "success: distinct stages",
+			regs: []Registration[*trace]{{Stage: step("a", nil)}, {Stage: step("b", nil)}},
+		},
+		{
+			name:    "fail: nil stage",
+			regs:    []Registration[*trace]{{}},
+			wantErr: "pipeline test: stage is required",
+		},
+		{
+			name:    "fail: empty name",
+			regs:    []Registration[*trace]{{Stage: step("", nil)}},
+			wantErr: "pipeline test: stage name is required",
+		},
+		{
+			name:    "fail: duplicate name",
+			regs:    []Registration[*trace]{{Stage: step("a", nil)}, {Stage: step("a", nil), Phase: PhasePublish}},
+			wantErr: `pipeline test: stage "a" already registered`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New[*trace]("test")
+			var err error
+			for _, reg := range tt.regs {
+				if err = p.Register(reg); err != nil {
+					break
+				}
+			}
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPipeline_Run(t *testing.T) {
+	boom := errors.New("boom")
+	tests := []struct {
+		name      string
+		regs      []Registration[*trace]
+		wantRan   []string
+		wantStage string
+	}{
+		{
+			name: "success: runs by phase, then order, then registration",
+			regs: []Registration[*trace]{
+				{Stage: step("upload", nil), Phase: PhasePublish},
+				{Stage: step("provenance", nil), Phase: PhasePostProcess, Order: 900},
+				{Stage: step("watermark", nil), Phase: PhasePostProcess, Order: 500},
+				{Stage: step("predict", nil), Phase: PhaseStage},
+				{Stage: step("moderate", nil), Phase: PhasePreProcess},
+				{Stage: step("banner", nil), Phase: PhasePostProcess, Order: 500},
+			},
+			wantRan: []string{"moderate", "predict", "watermark", "banner", "provenance", "upload"},
+		},
+		{
+			name: "success: optional failure continues",
+			regs: []Registration[*trace]{
+				{Stage: step("thumbnail", boom), Phase: PhasePostProcess, Optional: true},
+				{Stage: step("upload", nil), Phase: PhasePublish},
+			},
+			wantRan: []string{"thumbnail", "upload"},
+		},
+		{
+			name: "fail: required failure stops the pipeline",
+			regs: []Registration[*trace]{
+				{Stage: step("moderate", boom), Phase: PhasePreProcess},
+				{Stage: step("predict", nil), Phase: PhaseStage},
+			},
+			wantRan:   []string{"moderate"},
+			wantStage: "moderate",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New[*trace]("test")
+			for _, reg := range tt.regs {
+				require.NoError(t, p.Register(reg))
+			}
+			job := &trace{}
+			err := p.Run(context.Background(), job)
+			assert.Equal(t, tt.wantRan, job.ran)
+			if tt.wantStage == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var stageErr *StageError
+			require.ErrorAs(t, err, &stageErr)
+			assert.Equal(t, tt.wantStage, stageErr.Stage)
+			assert.ErrorIs(t, err, boom)
+			assert.Equal(t, "pre_process stage moderate: boom", err.Error())
+		})
+	}
+}
+
+func TestPipeline_Metrics(t *testing.T) {
+	t.Run("success: counts runs and failures per stage", func(t *testing.T) {
+		p := New[*trace]("test")
+		require.NoError(t, p.Register(Registration[*trace]{Stage: step("predict", nil), Phase: PhaseStage}))
+		require.NoError(t, p.Register(Registration[*trace]{
+			Stage: step("thumbnail", errors.New("boom")), Phase: PhasePublish, Optional: true,
+		}))
+
+		for range 3 {
+			require.NoError(t, p.Run(context.Background(), &trace{}))
+		}
+
+		stats := p.Metrics()
+		require.Len(t, stats, 2)
+		assert.Equal(t, "predict", stats[0].Name)
+		assert.Equal(t, PhaseStage, stats[0].Phase)
+		assert.Equal(t, int64(3), stats[0].Runs)
+		assert.Equal(t, int64(0), stats[0].Failures)
+		assert.Equal(t, "thumbnail", stats[1].Name)
+		assert.Equal(t, int64(3), stats[1].Runs)
+		assert.Equal(t, int64(3), stats[1].Failures)
+		assert.Equal(t, []string{"predict", "thumbnail"}, p.Stages())
+	})
+
+	t.Run("success: mean duration of an unrun stage is zero", func(t *testing.T) {
+		assert.Zero(t, StageStats{}.MeanDuration())
+	})
+}
@@ -0,0 +1,53 @@
+// Package preview renders the low-resolution previews published while a staging prediction
+// is still running, so the UI can show the result taking shape within seconds instead of
+// waiting for the full-resolution render.
+package preview
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/jpeg"
+	_ "image/png" // register decoder for model outputs
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // register decoder for model outputs
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out publisher_mock.go . Publisher
+
+const (
+	// MaxEdge caps the longer side of a preview, in pixels.
+	MaxEdge = 512
+	// jpegQuality is low on purpose: a preview is replaced by the final output.
+	jpegQuality = 70
+)
+
+// Publisher makes a preview of an image's output visible to its owner.
+type Publisher interface {
+	// PublishPreview records previewURL as the image's latest preview and announces it.
+	PublishPreview(ctx context.Context, imageID, previewURL string) error
+}
+
+// Render scales img down to fit MaxEdge and returns it as a JPEG. Images already within
+// MaxEdge are re-encoded at their own size.
+func Render(img []byte) ([]byte, error) {
+	src, _, err := image.Decode(bytes.NewReader(img))
+	if err != nil {
+		return nil, fmt.Errorf("decode image: %w", err)
+	}
+	b := src.Bounds()
+	scale := min(1, float64(MaxEdge)/float64(max(b.Dx(), b.Dy())))
+	w := max(1, int(float64(b.Dx())*scale))
+	h := max(1, int(float64(b.Dy())*scale))
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.ApproxBiLinear.Scale(dst, dst.Bounds(), src, b, draw.Src, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, fmt.Errorf("encode jpeg: %w", err)
+	}
+	return buf.Bytes(), nil
+}
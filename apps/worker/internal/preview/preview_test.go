@@ -0,0 +1,53 @@
+package preview
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodePNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		img.Set(x, 0, color.RGBA{R: 200, A: 255})
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestRender(t *testing.T) {
+	testCases := []struct {
+		name         string
+		img          []byte
+		wantW, wantH int
+		wantErr      bool
+	}{
+		{name: "success: landscape is scaled to the max edge", img: encodePNG(t, 2048, 1536), wantW: 512, wantH: 384},
+		{name: "success: portrait is scaled to the max edge", img: encodePNG(t, 1000, 4000), wantW: 128, wantH: 512},
+		{name: "success: small image keeps its size", img: encodePNG(t, 300, 200), wantW: 300, wantH: 200},
+		{name: "fail: not an image", img: []byte("not an image"), wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := Render(tc.img)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			cfg, err := jpeg.DecodeConfig(bytes.NewReader(out))
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantW, cfg.Width)
+			assert.Equal(t, tc.wantH, cfg.Height)
+		})
+	}
+}
@@ -3,46 +3,157 @@ package processor
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
+	"github.com/real-staging-ai/worker/internal/cancellation"
+	"github.com/real-staging-ai/worker/internal/checkpoint"
+	"github.com/real-staging-ai/worker/internal/costguard"
+	"github.com/real-staging-ai/worker/internal/edit"
 	"github.com/real-staging-ai/worker/internal/events"
+	"github.com/real-staging-ai/worker/internal/joblog"
 	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/notification"
+	"github.com/real-staging-ai/worker/internal/preview"
+	"github.com/real-staging-ai/worker/internal/projectexport"
 	"github.com/real-staging-ai/worker/internal/queue"
 	"github.com/real-staging-ai/worker/internal/repository"
+	"github.com/real-staging-ai/worker/internal/roomgroup"
 	"github.com/real-staging-ai/worker/internal/staging"
+	"github.com/real-staging-ai/worker/internal/teamwebhook"
+	"github.com/real-staging-ai/worker/internal/telemetry"
+	"github.com/real-staging-ai/worker/internal/turnaround"
 )
 
+// Ensure ImageProcessor publishes the previews its staging requests produce.
+var _ preview.Publisher = (*ImageProcessor)(nil)
+
+// errCanceled is the context cause used when the user cancels an in-flight job.
+var errCanceled = errors.New("job canceled by user")
+
+// cancelPollInterval controls how often an in-flight job checks for a cancel signal.
+var cancelPollInterval = 2 * time.Second
+
+// batchParallelism caps how many images of a batch job are staged at once.
+var batchParallelism = 4
+
 // ImageProcessor handles image processing jobs.
 type ImageProcessor struct {
 	imageRepo      repository.ImageRepository
 	stagingService staging.Service
 	publisher      events.Publisher
+	canceler       cancellation.Checker
+	checkpoints    checkpoint.Repository
+	spend          costguard.Guard
+	notifier       notification.Notifier
+	teamHooks      teamwebhook.Notifier
+	jobLog         joblog.Recorder
+	turnarounds    turnaround.Recorder
+	edits          edit.Repository
+	rooms          roomgroup.Analyzer
+	exports        projectexport.Exporter
+	retries        RetryPolicies
 }
 
-// NewImageProcessor creates a new image processor.
+// NewImageProcessor creates a new image processor. A nil checkpoints repository
+// disables resuming, so a retried job always runs every stage again. A nil spend
+// guard disables the daily spend ceiling. A nil notifier disables "image ready"
+// notifications, nil teamHooks disables posting finished batches to team webhooks, a
+// nil jobLog disables the user-visible processing log, nil turnarounds disables
+// turnaround SLA tracking and credits, nil edits fails every quick-edit job, nil rooms
+// fails every room grouping job, nil exports fails every project export job, and nil
+// retries tries every job once.
 func NewImageProcessor(
 	imageRepo repository.ImageRepository,
 	stagingService staging.Service,
 	publisher events.Publisher,
+	canceler cancellation.Checker,
+	checkpoints checkpoint.Repository,
+	spend costguard.Guard,
+	notifier notification.Notifier,
+	teamHooks teamwebhook.Notifier,
+	jobLog joblog.Recorder,
+	turnarounds turnaround.Recorder,
+	edits edit.Repository,
+	rooms roomgroup.Analyzer,
+	exports projectexport.Exporter,
+	retries RetryPolicies,
 ) *ImageProcessor {
+	if canceler == nil {
+		canceler = &cancellation.NoopChecker{}
+	}
 	return &ImageProcessor{
 		imageRepo:      imageRepo,
 		stagingService: stagingService,
 		publisher:      publisher,
+		canceler:       canceler,
+		checkpoints:    checkpoints,
+		spend:          spend,
+		notifier:       notifier,
+		teamHooks:      teamHooks,
+		jobLog:         jobLog,
+		turnarounds:    turnarounds,
+		edits:          edits,
+		rooms:          rooms,
+		exports:        exports,
+		retries:        retries,
 	}
 }
 
 // JobPayload represents the payload for an image processing job.
 type JobPayload struct {
-	ImageID     string  `json:"image_id"`
+	ImageID string `json:"image_id"`
+	// ProjectID is the image's project, whose event stream also carries its status changes.
+	ProjectID   string  `json:"project_id,omitempty"`
 	OriginalURL string  `json:"original_url"`
 	RoomType    *string `json:"room_type,omitempty"`
 	Style       *string `json:"style,omitempty"`
 	Seed        *int64  `json:"seed,omitempty"`
+	Sandbox     bool    `json:"sandbox,omitempty"`
+	// Catalog is a copy of the furniture catalog picked for the image, if any.
+	Catalog *staging.Catalog `json:"catalog,omitempty"`
+	// ReferenceImageURL is the s3:// URL of the user's inspiration photo, if any.
+	ReferenceImageURL string `json:"reference_image_url,omitempty"`
+	// BracketURLs are s3:// URLs of other exposures of the original's shot, if any.
+	BracketURLs []string `json:"bracket_urls,omitempty"`
+	// CorrectPerspective straightens converging and leaning verticals before staging.
+	CorrectPerspective bool `json:"correct_perspective,omitempty"`
+	// Annotations are the room measurements given with the image, if any.
+	Annotations *staging.Annotations `json:"annotations,omitempty"`
+	// Instructions are extra prompt instructions from the user's preset, if any.
+	Instructions string `json:"instructions,omitempty"`
+}
+
+// EditPayload represents the payload for a quick-edit job.
+type EditPayload struct {
+	EditID  string `json:"edit_id"`
+	ImageID string `json:"image_id"`
+	// Base is the image version being edited: "original" or "staged".
+	Base string `json:"base"`
+	// BaseURL is the s3:// URL of that version.
+	BaseURL string `json:"base_url"`
+	// MaskURL is the s3:// URL of the mask marking the area to edit.
+	MaskURL string `json:"mask_url"`
+	Sandbox bool   `json:"sandbox,omitempty"`
+}
+
+// RoomGroupPayload represents the payload for a room grouping job.
+type RoomGroupPayload struct {
+	GroupingID string `json:"grouping_id"`
+	ProjectID  string `json:"project_id"`
+}
+
+// ExportPayload represents the payload for a project export job.
+type ExportPayload struct {
+	ExportID  string `json:"export_id"`
+	ProjectID string `json:"project_id"`
 }
 
 // ProcessJob processes a job based on its type.
@@ -56,10 +167,21 @@ func (p *ImageProcessor) ProcessJob(ctx context.Context, job *queue.Job) error {
 		)
 	}
 	defer span.End()
+	// Summarize the job's DB, S3 and provider usage on its span for tail sampling.
+	ctx, usage := telemetry.WithUsage(ctx)
+	defer func() { span.SetAttributes(usage.Attributes()...) }()
 
 	switch job.Type {
 	case "stage:run":
 		return p.processStageJob(ctx, job)
+	case queue.TaskTypeStageBatch:
+		return p.processBatchJob(ctx, job)
+	case queue.TaskTypeEditErase:
+		return p.processEditJob(ctx, job)
+	case queue.TaskTypeRoomsGroup:
+		return p.processRoomGroupJob(ctx, job)
+	case queue.TaskTypeProjectExport:
+		return p.processExportJob(ctx, job)
 	default:
 		err := fmt.Errorf("unknown job type: %s", job.Type)
 		span.RecordError(err)
@@ -70,7 +192,6 @@ func (p *ImageProcessor) ProcessJob(ctx context.Context, job *queue.Job) error {
 
 // processStageJob processes an image staging job.
 func (p *ImageProcessor) processStageJob(ctx context.Context, job *queue.Job) error {
-	log := logging.Default()
 	tracer := otel.Tracer("real-staging-worker/processor")
 	ctx, span := tracer.Start(ctx, "processor.processStageJob")
 	defer span.End()
@@ -83,6 +204,111 @@ func (p *ImageProcessor) processStageJob(ctx context.Context, job *queue.Job) er
 		return fmt.Errorf("failed to unmarshal job payload: %w", err)
 	}
 
+	return p.stage(ctx, payload, p.retries["stage:run"])
+}
+
+// processBatchJob stages every image of a stage:batch job, up to batchParallelism at a
+// time. Images an earlier attempt finished are skipped, so a retried batch only redoes
+// the images that did not. If any image is deferred the whole batch is deferred;
+// otherwise the batch fails if any image failed.
+func (p *ImageProcessor) processBatchJob(ctx context.Context, job *queue.Job) error {
+	tracer := otel.Tracer("real-staging-worker/processor")
+	ctx, span := tracer.Start(ctx, "processor.processBatchJob")
+	defer span.End()
+
+	var batch queue.BatchPayload
+	if err := json.Unmarshal(job.Payload, &batch); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unmarshal batch payload")
+		return fmt.Errorf("failed to unmarshal batch payload: %w", err)
+	}
+	span.SetAttributes(attribute.Int("batch.size", len(batch.Items)))
+	logging.Default().Info(ctx, "Processing batch job", "job_id", job.ID, "images", len(batch.Items))
+
+	errs := make([]error, len(batch.Items))
+	slots := make(chan struct{}, batchParallelism)
+	var wg sync.WaitGroup
+	for i, item := range batch.Items {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			errs[i] = p.processBatchItem(ctx, item)
+		}()
+	}
+	wg.Wait()
+
+	var failed []error
+	for _, err := range errs {
+		var deferred *queue.DeferredError
+		if errors.As(err, &deferred) {
+			span.SetStatus(codes.Error, "batch deferred")
+			return err
+		}
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+	if len(failed) > 0 {
+		err := fmt.Errorf("%d of %d batch images failed: %w", len(failed), len(batch.Items), errors.Join(failed...))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "batch images failed")
+		return err
+	}
+	p.notifyBatchComplete(ctx, batch.Items)
+	span.SetStatus(codes.Ok, "batch complete")
+	return nil
+}
+
+// notifyBatchComplete posts a finished batch to the account's team webhooks. Only batches
+// whose every image finished are posted, so a batch retried after a failure is posted
+// once. Errors are logged and never fail the batch.
+func (p *ImageProcessor) notifyBatchComplete(ctx context.Context, items []json.RawMessage) {
+	if p.teamHooks == nil || len(items) == 0 {
+		return
+	}
+	var first JobPayload
+	if err := json.Unmarshal(items[0], &first); err != nil || first.ImageID == "" {
+		return
+	}
+	if err := p.teamHooks.BatchComplete(ctx, first.ImageID, len(items)); err != nil {
+		logging.Default().Error(ctx, "Failed to post batch to team webhooks", "image_id", first.ImageID, "error", err)
+	}
+}
+
+// processBatchItem stages one image of a batch job unless it is already ready or canceled.
+// Status lookup errors are logged and the image is staged anyway.
+func (p *ImageProcessor) processBatchItem(ctx context.Context, item json.RawMessage) error {
+	tracer := otel.Tracer("real-staging-worker/processor")
+	ctx, span := tracer.Start(ctx, "processor.processBatchItem")
+	defer span.End()
+
+	var payload JobPayload
+	if err := json.Unmarshal(item, &payload); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unmarshal batch item")
+		return fmt.Errorf("failed to unmarshal batch item: %w", err)
+	}
+	if payload.ImageID != "" {
+		status, err := p.imageRepo.GetStatus(ctx, payload.ImageID)
+		switch {
+		case err != nil:
+			logging.Default().Warn(ctx, "Failed to check batch image status", "image_id", payload.ImageID, "error", err)
+		case status == "ready" || status == "canceled":
+			logging.Default().Info(ctx, "Skipping finished batch image", "image_id", payload.ImageID, "status", status)
+			return nil
+		}
+	}
+	return p.stage(ctx, payload, p.retries[queue.TaskTypeStageBatch])
+}
+
+// stage runs the staging pipeline for one image, recording errors on the span in ctx.
+// Transient provider and storage errors are retried under retry before the image fails.
+func (p *ImageProcessor) stage(ctx context.Context, payload JobPayload, retry RetryPolicy) error {
+	log := logging.Default()
+	span := trace.SpanFromContext(ctx)
+
 	// Validate required fields
 	if payload.ImageID == "" {
 		err := fmt.Errorf("missing required field: image_id")
@@ -103,6 +329,45 @@ func (p *ImageProcessor) processStageJob(ctx context.Context, job *queue.Job) er
 
 	log.Info(ctx, fmt.Sprintf("Processing stage job for image %s", payload.ImageID))
 
+	// Checkpoint: the image may have been canceled while the task sat in the queue
+	if p.isCanceled(ctx, payload.ImageID) {
+		return p.finishCanceled(ctx, payload.ImageID, payload.ProjectID)
+	}
+
+	// A retry after a worker crash resumes from the stages the last attempt recorded.
+	req := &staging.StagingRequest{
+		ImageID:            payload.ImageID,
+		OriginalURL:        payload.OriginalURL,
+		RoomType:           payload.RoomType,
+		Style:              payload.Style,
+		Seed:               payload.Seed,
+		Sandbox:            payload.Sandbox,
+		Catalog:            payload.Catalog,
+		ReferenceImageURL:  payload.ReferenceImageURL,
+		BracketURLs:        payload.BracketURLs,
+		CorrectPerspective: payload.CorrectPerspective,
+		Annotations:        payload.Annotations,
+		Instructions:       payload.Instructions,
+	}
+	if p.checkpoints != nil {
+		req.Resume = p.loadCheckpoint(ctx, payload.ImageID)
+		req.Checkpoints = p.checkpoints
+	}
+	if p.jobLog != nil {
+		req.Log = p.jobLog
+	}
+	req.Previews = p
+	if job, ok := repository.JobFrom(ctx); ok && job.Attempt > 1 {
+		p.appendLog(ctx, payload.ImageID, fmt.Sprintf("Retrying: attempt %d", job.Attempt))
+	}
+
+	// Hold the job in the queue, still queued, once today's provider spend is used up
+	estimate := func() float64 { return p.stagingService.EstimateCost(req) }
+	if err := p.reserveSpend(ctx, payload.ImageID, estimate); err != nil {
+		span.SetStatus(codes.Error, "spend ceiling reached")
+		return err
+	}
+
 	// Mark image as processing
 	if err := p.imageRepo.SetProcessing(ctx, payload.ImageID); err != nil {
 		span.RecordError(err)
@@ -110,28 +375,49 @@ func (p *ImageProcessor) processStageJob(ctx context.Context, job *queue.Job) er
 		log.Error(ctx, "Failed to mark image as processing", "image_id", payload.ImageID, "error", err)
 		return fmt.Errorf("failed to mark image as processing: %w", err)
 	}
+	p.appendLog(ctx, payload.ImageID, "Staging started")
 
 	// Publish processing status
 	if err := p.publisher.PublishJobUpdate(ctx, events.JobUpdateEvent{
-		ImageID: payload.ImageID,
-		Status:  "processing",
+		ImageID:   payload.ImageID,
+		ProjectID: payload.ProjectID,
+		Status:    "processing",
 	}); err != nil {
 		log.Error(ctx, "Failed to publish processing status", "image_id", payload.ImageID, "error", err)
 		// Don't fail the job if SSE publish fails
 	}
 
-	// Stage the image with AI
-	stagedURL, err := p.stagingService.StageImage(ctx, &staging.StagingRequest{
-		ImageID:     payload.ImageID,
-		OriginalURL: payload.OriginalURL,
-		RoomType:    payload.RoomType,
-		Style:       payload.Style,
-		Seed:        payload.Seed,
-	})
+	// Stage the image with AI, aborting the provider call if the user cancels.
+	stageCtx, stopWatching := p.watchCancellation(ctx, payload.ImageID)
+	var stagedURL string
+	err := retry.do(stageCtx, func(ctx context.Context, attempt int) error {
+		// Pick up the prediction the failed try started rather than paying for another
+		if attempt > 1 && p.checkpoints != nil {
+			req.Resume = p.loadCheckpoint(ctx, payload.ImageID)
+		}
+		var err error
+		stagedURL, err = p.stagingService.StageImage(ctx, req)
+		return err
+	}, p.logRetry(ctx, payload.ImageID, retry))
+	canceled := errors.Is(context.Cause(stageCtx), errCanceled)
+	stopWatching()
+
+	// Point the image at the JPEG converted from its RAW upload, even when staging then
+	// failed, so it is shown from the JPEG rather than the RAW file
+	if req.RawURL != "" {
+		if err := p.imageRepo.SetRawOriginal(ctx, payload.ImageID, req.OriginalURL, req.RawURL); err != nil {
+			log.Warn(ctx, "Failed to record RAW conversion", "image_id", payload.ImageID, "error", err)
+		}
+	}
+
+	if canceled {
+		return p.finishCanceled(ctx, payload.ImageID, payload.ProjectID)
+	}
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "staging failed")
 		log.Error(ctx, "Failed to stage image", "image_id", payload.ImageID, "error", err)
+		p.appendLog(ctx, payload.ImageID, "Staging failed: "+err.Error())
 
 		// Mark image as error
 		if setErr := p.imageRepo.SetError(ctx, payload.ImageID, err.Error()); setErr != nil {
@@ -140,9 +426,10 @@ func (p *ImageProcessor) processStageJob(ctx context.Context, job *queue.Job) er
 
 		// Publish error status
 		if pubErr := p.publisher.PublishJobUpdate(ctx, events.JobUpdateEvent{
-			ImageID: payload.ImageID,
-			Status:  "error",
-			Error:   err.Error(),
+			ImageID:   payload.ImageID,
+			ProjectID: payload.ProjectID,
+			Status:    "error",
+			Error:     err.Error(),
 		}); pubErr != nil {
 			log.Error(ctx, "Failed to publish error status", "image_id", payload.ImageID, "error", pubErr)
 		}
@@ -152,6 +439,17 @@ func (p *ImageProcessor) processStageJob(ctx context.Context, job *queue.Job) er
 
 	log.Info(ctx, fmt.Sprintf("Successfully staged image: %s", stagedURL))
 
+	// Checkpoint: don't publish a result the user no longer wants
+	if p.isCanceled(ctx, payload.ImageID) {
+		return p.finishCanceled(ctx, payload.ImageID, payload.ProjectID)
+	}
+
+	// Record the watermarked copy before the image is ready, so share links never serve
+	// the clean output of a watermarked account; a stale copy is cleared
+	if err := p.imageRepo.SetWatermarked(ctx, payload.ImageID, req.WatermarkedURL); err != nil {
+		log.Warn(ctx, "Failed to record watermarked copy", "image_id", payload.ImageID, "error", err)
+	}
+
 	// Mark image as ready with staged URL
 	if err := p.imageRepo.SetReady(ctx, payload.ImageID, stagedURL); err != nil {
 		span.RecordError(err)
@@ -159,18 +457,357 @@ func (p *ImageProcessor) processStageJob(ctx context.Context, job *queue.Job) er
 		log.Error(ctx, "Failed to mark image as ready", "image_id", payload.ImageID, "error", err)
 		return fmt.Errorf("failed to mark image as ready: %w", err)
 	}
+	p.appendLog(ctx, payload.ImageID, "Staged image ready")
+
+	// Record what the image takes up in storage; only the project summary reads it
+	if req.OriginalBytes > 0 || req.StagedBytes > 0 {
+		if err := p.imageRepo.SetSizes(ctx, payload.ImageID, req.OriginalBytes, req.StagedBytes); err != nil {
+			log.Warn(ctx, "Failed to record image sizes", "image_id", payload.ImageID, "error", err)
+		}
+	}
 
 	// Publish ready status
 	if err := p.publisher.PublishJobUpdate(ctx, events.JobUpdateEvent{
-		ImageID: payload.ImageID,
-		Status:  "ready",
+		ImageID:   payload.ImageID,
+		ProjectID: payload.ProjectID,
+		Status:    "ready",
 	}); err != nil {
 		log.Error(ctx, "Failed to publish ready status", "image_id", payload.ImageID, "error", err)
 		// Don't fail the job if SSE publish fails
 	}
 
+	// Tell the owner in their notification center; like the SSE update, this never fails the job
+	if p.notifier != nil {
+		if err := p.notifier.ImageReady(ctx, payload.ImageID); err != nil {
+			log.Error(ctx, "Failed to notify image ready", "image_id", payload.ImageID, "error", err)
+		}
+	}
+	p.recordTurnaround(ctx, payload.ImageID)
+
 	log.Info(ctx, fmt.Sprintf("Image %s processing complete", payload.ImageID))
 	span.SetStatus(codes.Ok, "processing complete")
 
 	return nil
 }
+
+// processEditJob runs a quick edit of an image and records its outcome on the edit. The
+// image itself is untouched: the result is a separate file the user can download. Edits
+// are queued without retries, so a failed edit is marked failed straight away.
+func (p *ImageProcessor) processEditJob(ctx context.Context, job *queue.Job) error {
+	log := logging.Default()
+	tracer := otel.Tracer("real-staging-worker/processor")
+	ctx, span := tracer.Start(ctx, "processor.processEditJob")
+	defer span.End()
+
+	var payload EditPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unmarshal edit payload")
+		return fmt.Errorf("failed to unmarshal edit payload: %w", err)
+	}
+	if payload.EditID == "" || payload.ImageID == "" || payload.BaseURL == "" || payload.MaskURL == "" {
+		err := fmt.Errorf("missing required field: edit_id, image_id, base_url and mask_url are required")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	span.SetAttributes(
+		attribute.String("image.id", payload.ImageID),
+		attribute.String("edit.id", payload.EditID),
+	)
+	if p.edits == nil {
+		err := fmt.Errorf("quick edits are not configured")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	req := &staging.EraseRequest{
+		EditID:     payload.EditID,
+		ImageID:    payload.ImageID,
+		BaseURL:    payload.BaseURL,
+		BaseStaged: payload.Base == "staged",
+		MaskURL:    payload.MaskURL,
+		Sandbox:    payload.Sandbox,
+	}
+	estimate := func() float64 { return p.stagingService.EstimateEraseCost(req) }
+	if err := p.reserveSpend(ctx, payload.ImageID, estimate); err != nil {
+		span.SetStatus(codes.Error, "spend ceiling reached")
+		return err
+	}
+
+	if err := p.edits.MarkProcessing(ctx, payload.EditID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "mark processing failed")
+		return fmt.Errorf("failed to mark edit as processing: %w", err)
+	}
+
+	// The processing log belongs to the image's staging job, so edit retries are not noted in it
+	retry := p.retries[queue.TaskTypeEditErase]
+	var resultURL string
+	err := retry.do(ctx, func(ctx context.Context, _ int) error {
+		var err error
+		resultURL, err = p.stagingService.EraseObject(ctx, req)
+		return err
+	}, nil)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "edit failed")
+		log.Error(ctx, "Failed to edit image", "image_id", payload.ImageID, "edit_id", payload.EditID, "error", err)
+		if markErr := p.edits.MarkError(ctx, payload.EditID, err.Error()); markErr != nil {
+			log.Error(ctx, "Failed to mark edit as error", "edit_id", payload.EditID, "error", markErr)
+		}
+		return fmt.Errorf("failed to edit image: %w", err)
+	}
+
+	if err := p.edits.MarkReady(ctx, payload.EditID, resultURL); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "mark ready failed")
+		return fmt.Errorf("failed to mark edit as ready: %w", err)
+	}
+	log.Info(ctx, "Image edit ready", "image_id", payload.ImageID, "edit_id", payload.EditID)
+	span.SetStatus(codes.Ok, "edit complete")
+	return nil
+}
+
+// processRoomGroupJob proposes room groupings for a project's uploads. The analyzer
+// records the outcome on the grouping itself, so a failed job leaves it in error.
+func (p *ImageProcessor) processRoomGroupJob(ctx context.Context, job *queue.Job) error {
+	tracer := otel.Tracer("real-staging-worker/processor")
+	ctx, span := tracer.Start(ctx, "processor.processRoomGroupJob")
+	defer span.End()
+
+	var payload RoomGroupPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unmarshal room group payload")
+		return fmt.Errorf("failed to unmarshal room group payload: %w", err)
+	}
+	if payload.GroupingID == "" || payload.ProjectID == "" {
+		err := fmt.Errorf("missing required field: grouping_id and project_id are required")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	span.SetAttributes(
+		attribute.String("project.id", payload.ProjectID),
+		attribute.String("grouping.id", payload.GroupingID),
+	)
+	if p.rooms == nil {
+		err := fmt.Errorf("room grouping is not configured")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	if err := p.rooms.Analyze(ctx, payload.GroupingID, payload.ProjectID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "room grouping failed")
+		logging.Default().Error(ctx, "Failed to group project images", "project_id", payload.ProjectID,
+			"grouping_id", payload.GroupingID, "error", err)
+		return fmt.Errorf("failed to group project images: %w", err)
+	}
+	logging.Default().Info(ctx, "Room grouping ready", "project_id", payload.ProjectID,
+		"grouping_id", payload.GroupingID)
+	span.SetStatus(codes.Ok, "room grouping complete")
+	return nil
+}
+
+// processExportJob zips a project's staged photos for a project export.
+func (p *ImageProcessor) processExportJob(ctx context.Context, job *queue.Job) error {
+	tracer := otel.Tracer("real-staging-worker/processor")
+	ctx, span := tracer.Start(ctx, "processor.processExportJob")
+	defer span.End()
+
+	var payload ExportPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unmarshal export payload")
+		return fmt.Errorf("failed to unmarshal export payload: %w", err)
+	}
+	if payload.ExportID == "" || payload.ProjectID == "" {
+		err := fmt.Errorf("missing required field: export_id and project_id are required")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	span.SetAttributes(
+		attribute.String("project.id", payload.ProjectID),
+		attribute.String("export.id", payload.ExportID),
+	)
+	if p.exports == nil {
+		err := fmt.Errorf("project exports are not configured")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	if err := p.exports.Export(ctx, payload.ExportID, payload.ProjectID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "project export failed")
+		logging.Default().Error(ctx, "Failed to export project", "project_id", payload.ProjectID,
+			"export_id", payload.ExportID, "error", err)
+		return fmt.Errorf("failed to export project: %w", err)
+	}
+	logging.Default().Info(ctx, "Project export ready", "project_id", payload.ProjectID,
+		"export_id", payload.ExportID)
+	span.SetStatus(codes.Ok, "project export complete")
+	return nil
+}
+
+// logRetry returns a callback that notes a retry of the image's job in its processing log.
+func (p *ImageProcessor) logRetry(ctx context.Context, imageID string, retry RetryPolicy) func(int, time.Duration, error) {
+	return func(attempt int, wait time.Duration, err error) {
+		p.appendLog(ctx, imageID, fmt.Sprintf("Temporary error (%v); trying again in %s (attempt %d of %d)",
+			err, wait.Round(time.Second), attempt+1, max(retry.MaxAttempts, 1)))
+	}
+}
+
+// recordTurnaround measures the ready image against its owner's plan SLA and tells the
+// owner when a miss was credited. Like notifications, it never fails the job.
+func (p *ImageProcessor) recordTurnaround(ctx context.Context, imageID string) {
+	if p.turnarounds == nil {
+		return
+	}
+	log := logging.Default()
+	res, err := p.turnarounds.Record(ctx, imageID)
+	if err != nil {
+		log.Warn(ctx, "Failed to record image turnaround", "image_id", imageID, "error", err)
+		return
+	}
+	if !res.Recorded || res.Met || res.Target == 0 {
+		return
+	}
+	log.Info(ctx, "Image missed its turnaround target", "image_id", imageID, "plan", res.Plan,
+		"turnaround", res.Turnaround.String(), "target", res.Target.String(), "credited", res.Credited)
+	if res.Credited && p.notifier != nil {
+		if err := p.notifier.SLACredit(ctx, imageID); err != nil {
+			log.Error(ctx, "Failed to notify sla credit", "image_id", imageID, "error", err)
+		}
+	}
+}
+
+// loadCheckpoint returns the progress recorded by an earlier attempt at the image's job.
+// Lookup errors are logged and treated as "no progress": the job then re-runs every
+// stage, which costs a prediction but never loses work.
+func (p *ImageProcessor) loadCheckpoint(ctx context.Context, imageID string) checkpoint.Checkpoint {
+	cp, err := p.checkpoints.Load(ctx, imageID)
+	if err != nil {
+		logging.Default().Warn(ctx, "Failed to load job checkpoint", "image_id", imageID, "error", err)
+		return checkpoint.Checkpoint{}
+	}
+	if cp.PredictionID != "" || cp.OutputKey != "" {
+		logging.Default().Info(ctx, "Resuming stage job from checkpoint", "image_id", imageID,
+			"prediction_id", cp.PredictionID, "output_key", cp.OutputKey)
+	}
+	return cp
+}
+
+// reserveSpend counts the job's expected provider cost against the daily spend ceiling.
+// Over the ceiling it returns a *queue.DeferredError so the job waits until the ceiling
+// resets. Guard errors are logged and the job runs: a Redis hiccup should not stop staging.
+// estimate is only called when a guard is configured.
+func (p *ImageProcessor) reserveSpend(ctx context.Context, imageID string, estimate func() float64) error {
+	if p.spend == nil {
+		return nil
+	}
+	err := p.spend.Reserve(ctx, estimate())
+	var ceiling *costguard.CeilingError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &ceiling):
+		logging.Default().Warn(ctx, "Daily spend ceiling reached; deferring job",
+			"image_id", imageID, "ceiling_usd", ceiling.Ceiling, "resume_at", ceiling.ResumeAt)
+		p.appendLog(ctx, imageID, "Queued: daily processing capacity reached; resuming at "+
+			ceiling.ResumeAt.UTC().Format(time.RFC3339))
+		return &queue.DeferredError{Until: ceiling.ResumeAt, Reason: ceiling.Error()}
+	default:
+		logging.Default().Warn(ctx, "Failed to check spend ceiling", "image_id", imageID, "error", err)
+		return nil
+	}
+}
+
+// PublishPreview records previewURL as the image's latest preview and tells the image's SSE
+// subscribers a preview is ready. Publishing is best-effort, like every SSE update.
+func (p *ImageProcessor) PublishPreview(ctx context.Context, imageID, previewURL string) error {
+	if err := p.imageRepo.SetPreview(ctx, imageID, previewURL); err != nil {
+		return fmt.Errorf("failed to record preview: %w", err)
+	}
+	if err := p.publisher.PublishJobUpdate(ctx, events.JobUpdateEvent{
+		ImageID: imageID,
+		Status:  "processing",
+		Preview: true,
+	}); err != nil {
+		logging.Default().Error(ctx, "Failed to publish preview", "image_id", imageID, "error", err)
+	}
+	return nil
+}
+
+// appendLog adds a line to the image's user-visible job log. Write errors are logged and
+// otherwise ignored, like SSE publishes: the job log never fails a job.
+func (p *ImageProcessor) appendLog(ctx context.Context, imageID, line string) {
+	if p.jobLog == nil {
+		return
+	}
+	if err := p.jobLog.Append(ctx, imageID, line); err != nil {
+		logging.Default().Warn(ctx, "Failed to append job log", "image_id", imageID, "error", err)
+	}
+}
+
+// isCanceled reports whether the API has signaled cancellation for the image.
+// Lookup errors are logged and treated as "not canceled" so a Redis hiccup never fails a job.
+func (p *ImageProcessor) isCanceled(ctx context.Context, imageID string) bool {
+	canceled, err := p.canceler.IsCanceled(ctx, imageID)
+	if err != nil {
+		logging.Default().Warn(ctx, "Failed to check cancel signal", "image_id", imageID, "error", err)
+		return false
+	}
+	return canceled
+}
+
+// watchCancellation returns a context that is canceled with errCanceled once the
+// API signals cancellation for the image. The returned stop func must be called
+// when the guarded work finishes.
+func (p *ImageProcessor) watchCancellation(ctx context.Context, imageID string) (context.Context, func()) {
+	watchCtx, cancel := context.WithCancelCause(ctx)
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(cancelPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-watchCtx.Done():
+				return
+			case <-ticker.C:
+				if p.isCanceled(watchCtx, imageID) {
+					cancel(errCanceled)
+					return
+				}
+			}
+		}
+	}()
+	return watchCtx, func() {
+		close(done)
+		cancel(nil)
+	}
+}
+
+// finishCanceled publishes the canceled status and acknowledges the job without error
+// so the queue does not retry it. The API has already marked the image as canceled.
+func (p *ImageProcessor) finishCanceled(ctx context.Context, imageID, projectID string) error {
+	log := logging.Default()
+	log.Info(ctx, "Stage job canceled by user", "image_id", imageID)
+	p.appendLog(ctx, imageID, "Canceled by user")
+
+	if err := p.publisher.PublishJobUpdate(ctx, events.JobUpdateEvent{
+		ImageID:   imageID,
+		ProjectID: projectID,
+		Status:    "canceled",
+	}); err != nil {
+		log.Error(ctx, "Failed to publish canceled status", "image_id", imageID, "error", err)
+	}
+	return nil
+}
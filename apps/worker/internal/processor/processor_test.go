@@ -0,0 +1,1045 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/replicate/replicate-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/worker/internal/cancellation"
+	"github.com/real-staging-ai/worker/internal/checkpoint"
+	"github.com/real-staging-ai/worker/internal/costguard"
+	"github.com/real-staging-ai/worker/internal/edit"
+	"github.com/real-staging-ai/worker/internal/events"
+	"github.com/real-staging-ai/worker/internal/joblog"
+	"github.com/real-staging-ai/worker/internal/notification"
+	"github.com/real-staging-ai/worker/internal/projectexport"
+	"github.com/real-staging-ai/worker/internal/queue"
+	"github.com/real-staging-ai/worker/internal/repository"
+	"github.com/real-staging-ai/worker/internal/roomgroup"
+	"github.com/real-staging-ai/worker/internal/staging"
+	"github.com/real-staging-ai/worker/internal/teamwebhook"
+	"github.com/real-staging-ai/worker/internal/turnaround"
+)
+
+func newStageJob(t *testing.T, imageID string) *queue.Job {
+	t.Helper()
+	payload, err := json.Marshal(JobPayload{
+		ImageID: imageID, ProjectID: "proj-1", OriginalURL: "s3://bucket/uploads/a.jpg",
+	})
+	require.NoError(t, err)
+	return &queue.Job{ID: "job-1", Type: "stage:run", Payload: payload}
+}
+
+func TestImageProcessor_ProcessJob_Cancellation(t *testing.T) {
+	prevInterval := cancelPollInterval
+	cancelPollInterval = 5 * time.Millisecond
+	t.Cleanup(func() { cancelPollInterval = prevInterval })
+
+	testCases := []struct {
+		name            string
+		isCanceled      func(calls *int32) func(ctx context.Context, imageID string) (bool, error)
+		stageImage      func(ctx context.Context, req *staging.StagingRequest) (string, error)
+		expectStaged    bool
+		expectReady     bool
+		expectPublished []string
+	}{
+		{
+			name: "success: canceled before processing starts",
+			isCanceled: func(_ *int32) func(context.Context, string) (bool, error) {
+				return func(context.Context, string) (bool, error) { return true, nil }
+			},
+			expectStaged:    false,
+			expectReady:     false,
+			expectPublished: []string{"canceled"},
+		},
+		{
+			name: "success: canceled while provider call is in flight",
+			isCanceled: func(calls *int32) func(context.Context, string) (bool, error) {
+				return func(context.Context, string) (bool, error) {
+					// First checkpoint passes; the in-flight watcher then observes the signal.
+					return atomic.AddInt32(calls, 1) > 1, nil
+				}
+			},
+			stageImage: func(ctx context.Context, req *staging.StagingRequest) (string, error) {
+				<-ctx.Done()
+				return "", context.Cause(ctx)
+			},
+			expectStaged:    true,
+			expectReady:     false,
+			expectPublished: []string{"processing", "canceled"},
+		},
+		{
+			name: "success: not canceled completes normally",
+			isCanceled: func(_ *int32) func(context.Context, string) (bool, error) {
+				return func(context.Context, string) (bool, error) { return false, nil }
+			},
+			stageImage: func(ctx context.Context, req *staging.StagingRequest) (string, error) {
+				return "s3://bucket/staged/a.jpg", nil
+			},
+			expectStaged:    true,
+			expectReady:     true,
+			expectPublished: []string{"processing", "ready"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int32
+			repo := &repository.ImageRepositoryMock{
+				SetProcessingFunc:  func(ctx context.Context, imageID string) error { return nil },
+				SetReadyFunc:       func(ctx context.Context, imageID, stagedURL string) error { return nil },
+				SetWatermarkedFunc: func(ctx context.Context, imageID, watermarkedURL string) error { return nil },
+				SetErrorFunc:       func(ctx context.Context, imageID, errorMsg string) error { return nil },
+			}
+			svc := &staging.ServiceMock{StageImageFunc: tc.stageImage}
+			pub := &events.PublisherMock{
+				PublishJobUpdateFunc: func(ctx context.Context, ev events.JobUpdateEvent) error { return nil },
+			}
+			checker := &cancellation.CheckerMock{IsCanceledFunc: tc.isCanceled(&calls)}
+
+			p := NewImageProcessor(repo, svc, pub, checker, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+			err := p.ProcessJob(context.Background(), newStageJob(t, "img-1"))
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectStaged, len(svc.StageImageCalls()) == 1)
+			assert.Equal(t, tc.expectReady, len(repo.SetReadyCalls()) == 1)
+			assert.Empty(t, repo.SetErrorCalls())
+
+			var published []string
+			for _, call := range pub.PublishJobUpdateCalls() {
+				published = append(published, call.Ev.Status)
+				assert.Equal(t, "proj-1", call.Ev.ProjectID)
+			}
+			assert.Equal(t, tc.expectPublished, published)
+		})
+	}
+}
+
+func TestImageProcessor_ProcessJob_Checkpoints(t *testing.T) {
+	testCases := []struct {
+		name       string
+		load       func(ctx context.Context, imageID string) (checkpoint.Checkpoint, error)
+		wantResume checkpoint.Checkpoint
+	}{
+		{
+			name: "success: recorded progress is passed to staging",
+			load: func(context.Context, string) (checkpoint.Checkpoint, error) {
+				return checkpoint.Checkpoint{PredictionID: "pred-1"}, nil
+			},
+			wantResume: checkpoint.Checkpoint{PredictionID: "pred-1"},
+		},
+		{
+			name: "success: load failure runs every stage",
+			load: func(context.Context, string) (checkpoint.Checkpoint, error) {
+				return checkpoint.Checkpoint{}, errors.New("db down")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &repository.ImageRepositoryMock{
+				SetProcessingFunc:  func(ctx context.Context, imageID string) error { return nil },
+				SetReadyFunc:       func(ctx context.Context, imageID, stagedURL string) error { return nil },
+				SetWatermarkedFunc: func(ctx context.Context, imageID, watermarkedURL string) error { return nil },
+			}
+			svc := &staging.ServiceMock{
+				StageImageFunc: func(ctx context.Context, req *staging.StagingRequest) (string, error) {
+					return "s3://bucket/staged/a.jpg", nil
+				},
+			}
+			pub := &events.PublisherMock{
+				PublishJobUpdateFunc: func(ctx context.Context, ev events.JobUpdateEvent) error { return nil },
+			}
+			checkpoints := &checkpoint.RepositoryMock{LoadFunc: tc.load}
+
+			p := NewImageProcessor(repo, svc, pub, nil, checkpoints, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+			require.NoError(t, p.ProcessJob(context.Background(), newStageJob(t, "img-1")))
+
+			require.Len(t, svc.StageImageCalls(), 1)
+			req := svc.StageImageCalls()[0].Req
+			assert.Equal(t, tc.wantResume, req.Resume)
+			assert.Equal(t, checkpoint.Recorder(checkpoints), req.Checkpoints)
+			require.Len(t, repo.SetReadyCalls(), 1)
+		})
+	}
+}
+
+func TestImageProcessor_ProcessJob_JobLog(t *testing.T) {
+	testCases := []struct {
+		name      string
+		attempt   int
+		stageErr  error
+		wantLines []string
+	}{
+		{
+			name:      "success: first attempt",
+			attempt:   1,
+			wantLines: []string{"Staging started", "Staged image ready"},
+		},
+		{
+			name:      "success: retry is noted",
+			attempt:   3,
+			wantLines: []string{"Retrying: attempt 3", "Staging started", "Staged image ready"},
+		},
+		{
+			name:      "fail: staging error is logged",
+			attempt:   1,
+			stageErr:  errors.New("prediction failed: out of memory"),
+			wantLines: []string{"Staging started", "Staging failed: prediction failed: out of memory"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &repository.ImageRepositoryMock{
+				SetProcessingFunc:  func(ctx context.Context, imageID string) error { return nil },
+				SetReadyFunc:       func(ctx context.Context, imageID, stagedURL string) error { return nil },
+				SetWatermarkedFunc: func(ctx context.Context, imageID, watermarkedURL string) error { return nil },
+				SetErrorFunc:       func(ctx context.Context, imageID, errorMsg string) error { return nil },
+			}
+			jobLog := &joblog.RecorderMock{
+				AppendFunc: func(ctx context.Context, imageID, line string) error { return nil },
+			}
+			svc := &staging.ServiceMock{
+				StageImageFunc: func(ctx context.Context, req *staging.StagingRequest) (string, error) {
+					assert.Equal(t, joblog.Recorder(jobLog), req.Log)
+					return "s3://bucket/staged/a.jpg", tc.stageErr
+				},
+			}
+			pub := &events.PublisherMock{
+				PublishJobUpdateFunc: func(ctx context.Context, ev events.JobUpdateEvent) error { return nil },
+			}
+
+			p := NewImageProcessor(repo, svc, pub, nil, nil, nil, nil, nil, jobLog, nil, nil, nil, nil, nil)
+			ctx := repository.WithJob(context.Background(), "task-1", tc.attempt)
+			err := p.ProcessJob(ctx, newStageJob(t, "img-1"))
+			if tc.stageErr != nil {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			var lines []string
+			for _, call := range jobLog.AppendCalls() {
+				assert.Equal(t, "img-1", call.ImageID)
+				lines = append(lines, call.Line)
+			}
+			assert.Equal(t, tc.wantLines, lines)
+		})
+	}
+}
+
+func TestImageProcessor_ProcessJob_Retries(t *testing.T) {
+	unavailable := &replicate.APIError{Status: 503, Detail: "service unavailable"}
+	retries := RetryPolicies{"stage:run": {MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}}
+
+	testCases := []struct {
+		name       string
+		retries    RetryPolicies
+		errs       []error
+		wantCalls  int
+		wantStatus string
+		wantResume checkpoint.Checkpoint
+	}{
+		{
+			name:       "success: transient error is retried from the recorded prediction",
+			retries:    retries,
+			errs:       []error{unavailable, nil},
+			wantCalls:  2,
+			wantStatus: "ready",
+			wantResume: checkpoint.Checkpoint{PredictionID: "pred-1"},
+		},
+		{
+			name:       "fail: permanent error fails straight away",
+			retries:    retries,
+			errs:       []error{errors.New("prediction failed: out of memory")},
+			wantCalls:  1,
+			wantStatus: "error",
+		},
+		{
+			name:       "fail: transient errors use up the attempts",
+			retries:    retries,
+			errs:       []error{unavailable, unavailable, unavailable},
+			wantCalls:  3,
+			wantStatus: "error",
+			wantResume: checkpoint.Checkpoint{PredictionID: "pred-1"},
+		},
+		{
+			name:       "fail: no policy tries once",
+			errs:       []error{unavailable},
+			wantCalls:  1,
+			wantStatus: "error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &repository.ImageRepositoryMock{
+				SetProcessingFunc:  func(ctx context.Context, imageID string) error { return nil },
+				SetReadyFunc:       func(ctx context.Context, imageID, stagedURL string) error { return nil },
+				SetWatermarkedFunc: func(ctx context.Context, imageID, watermarkedURL string) error { return nil },
+				SetErrorFunc:       func(ctx context.Context, imageID, errorMsg string) error { return nil },
+			}
+			var resumed checkpoint.Checkpoint
+			calls := 0
+			svc := &staging.ServiceMock{
+				StageImageFunc: func(ctx context.Context, req *staging.StagingRequest) (string, error) {
+					calls++
+					resumed = req.Resume
+					return "s3://bucket/staged/a.jpg", tc.errs[calls-1]
+				},
+			}
+			var statuses []string
+			pub := &events.PublisherMock{
+				PublishJobUpdateFunc: func(ctx context.Context, ev events.JobUpdateEvent) error {
+					statuses = append(statuses, ev.Status)
+					return nil
+				},
+			}
+			// The first try starts a prediction before it fails
+			checkpoints := &checkpoint.RepositoryMock{
+				LoadFunc: func(ctx context.Context, imageID string) (checkpoint.Checkpoint, error) {
+					if calls == 0 {
+						return checkpoint.Checkpoint{}, nil
+					}
+					return checkpoint.Checkpoint{PredictionID: "pred-1"}, nil
+				},
+			}
+			jobLog := &joblog.RecorderMock{
+				AppendFunc: func(ctx context.Context, imageID, line string) error { return nil },
+			}
+
+			p := NewImageProcessor(repo, svc, pub, nil, checkpoints, nil, nil, nil, jobLog, nil, nil, nil, nil, tc.retries)
+			err := p.ProcessJob(context.Background(), newStageJob(t, "img-1"))
+			if tc.wantStatus == "ready" {
+				require.NoError(t, err)
+				assert.Empty(t, repo.SetErrorCalls())
+			} else {
+				require.Error(t, err)
+				assert.Len(t, repo.SetErrorCalls(), 1)
+			}
+			assert.Equal(t, tc.wantCalls, calls)
+			assert.Equal(t, []string{"processing", tc.wantStatus}, statuses)
+			assert.Equal(t, tc.wantResume, resumed)
+
+			var retried int
+			for _, call := range jobLog.AppendCalls() {
+				if strings.HasPrefix(call.Line, "Temporary error") {
+					retried++
+				}
+			}
+			assert.Equal(t, tc.wantCalls-1, retried)
+		})
+	}
+}
+
+func TestImageProcessor_ProcessJob_SpendCeiling(t *testing.T) {
+	resumeAt := time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		reserve     func(ctx context.Context, cost float64) error
+		wantDefer   bool
+		wantStaging bool
+	}{
+		{
+			name:        "success: under the ceiling stages the image",
+			reserve:     func(context.Context, float64) error { return nil },
+			wantStaging: true,
+		},
+		{
+			name: "success: over the ceiling defers the job untouched",
+			reserve: func(context.Context, float64) error {
+				return &costguard.CeilingError{Ceiling: 50, ResumeAt: resumeAt}
+			},
+			wantDefer: true,
+		},
+		{
+			name:        "success: guard failure does not block staging",
+			reserve:     func(context.Context, float64) error { return errors.New("redis down") },
+			wantStaging: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &repository.ImageRepositoryMock{
+				SetProcessingFunc:  func(ctx context.Context, imageID string) error { return nil },
+				SetReadyFunc:       func(ctx context.Context, imageID, stagedURL string) error { return nil },
+				SetWatermarkedFunc: func(ctx context.Context, imageID, watermarkedURL string) error { return nil },
+			}
+			svc := &staging.ServiceMock{
+				EstimateCostFunc: func(req *staging.StagingRequest) float64 { return 0.08 },
+				StageImageFunc: func(ctx context.Context, req *staging.StagingRequest) (string, error) {
+					return "s3://bucket/staged/a.jpg", nil
+				},
+			}
+			pub := &events.PublisherMock{
+				PublishJobUpdateFunc: func(ctx context.Context, ev events.JobUpdateEvent) error { return nil },
+			}
+			guard := &costguard.GuardMock{ReserveFunc: tc.reserve}
+
+			p := NewImageProcessor(repo, svc, pub, nil, nil, guard, nil, nil, nil, nil, nil, nil, nil, nil)
+			err := p.ProcessJob(context.Background(), newStageJob(t, "img-1"))
+
+			require.Len(t, guard.ReserveCalls(), 1)
+			assert.Equal(t, 0.08, guard.ReserveCalls()[0].Cost)
+			if tc.wantDefer {
+				var deferred *queue.DeferredError
+				require.ErrorAs(t, err, &deferred)
+				assert.Equal(t, resumeAt, deferred.Until)
+				assert.Empty(t, repo.SetProcessingCalls())
+				assert.Empty(t, pub.PublishJobUpdateCalls())
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantStaging, len(svc.StageImageCalls()) == 1)
+		})
+	}
+}
+
+func TestImageProcessor_ProcessJob_Sandbox(t *testing.T) {
+	repo := &repository.ImageRepositoryMock{
+		SetProcessingFunc:  func(ctx context.Context, imageID string) error { return nil },
+		SetReadyFunc:       func(ctx context.Context, imageID, stagedURL string) error { return nil },
+		SetWatermarkedFunc: func(ctx context.Context, imageID, watermarkedURL string) error { return nil },
+	}
+	svc := &staging.ServiceMock{
+		StageImageFunc: func(ctx context.Context, req *staging.StagingRequest) (string, error) {
+			return "s3://bucket/staged/a.jpg", nil
+		},
+	}
+	pub := &events.PublisherMock{
+		PublishJobUpdateFunc: func(ctx context.Context, ev events.JobUpdateEvent) error { return nil },
+	}
+
+	payload, err := json.Marshal(JobPayload{
+		ImageID: "img-1", OriginalURL: "s3://bucket/a.jpg", Sandbox: true,
+		BracketURLs:        []string{"s3://bucket/a+2.jpg", "s3://bucket/a-2.jpg"},
+		CorrectPerspective: true,
+	})
+	require.NoError(t, err)
+	job := &queue.Job{ID: "job-1", Type: "stage:run", Payload: payload}
+
+	p := NewImageProcessor(repo, svc, pub, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	require.NoError(t, p.ProcessJob(context.Background(), job))
+	require.Len(t, svc.StageImageCalls(), 1)
+	assert.True(t, svc.StageImageCalls()[0].Req.Sandbox)
+	assert.Equal(t, []string{"s3://bucket/a+2.jpg", "s3://bucket/a-2.jpg"}, svc.StageImageCalls()[0].Req.BracketURLs)
+	assert.True(t, svc.StageImageCalls()[0].Req.CorrectPerspective)
+}
+
+func TestImageProcessor_ProcessJob_RecordsSizes(t *testing.T) {
+	cases := []struct {
+		name          string
+		originalBytes int64
+		stagedBytes   int64
+		setSizesErr   error
+		wantCalls     int
+	}{
+		{name: "success: sizes recorded", originalBytes: 2048, stagedBytes: 4096, wantCalls: 1},
+		{name: "success: nothing to record when resumed from output", wantCalls: 0},
+		{name: "success: record failure does not fail the job", stagedBytes: 4096,
+			setSizesErr: errors.New("db down"), wantCalls: 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &repository.ImageRepositoryMock{
+				SetProcessingFunc:  func(ctx context.Context, imageID string) error { return nil },
+				SetReadyFunc:       func(ctx context.Context, imageID, stagedURL string) error { return nil },
+				SetWatermarkedFunc: func(ctx context.Context, imageID, watermarkedURL string) error { return nil },
+				SetSizesFunc: func(ctx context.Context, imageID string, originalBytes, stagedBytes int64) error {
+					return tc.setSizesErr
+				},
+			}
+			svc := &staging.ServiceMock{
+				StageImageFunc: func(ctx context.Context, req *staging.StagingRequest) (string, error) {
+					req.OriginalBytes = tc.originalBytes
+					req.StagedBytes = tc.stagedBytes
+					return "s3://bucket/staged/a.jpg", nil
+				},
+			}
+			pub := &events.PublisherMock{
+				PublishJobUpdateFunc: func(ctx context.Context, ev events.JobUpdateEvent) error { return nil },
+			}
+
+			p := NewImageProcessor(repo, svc, pub, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+			require.NoError(t, p.ProcessJob(context.Background(), newStageJob(t, "img-1")))
+			require.Len(t, repo.SetSizesCalls(), tc.wantCalls)
+			if tc.wantCalls > 0 {
+				assert.Equal(t, tc.originalBytes, repo.SetSizesCalls()[0].OriginalBytes)
+				assert.Equal(t, tc.stagedBytes, repo.SetSizesCalls()[0].StagedBytes)
+			}
+		})
+	}
+}
+
+func TestImageProcessor_ProcessJob_RecordsWatermarkedCopy(t *testing.T) {
+	cases := []struct {
+		name           string
+		watermarkedURL string
+		setErr         error
+	}{
+		{name: "success: watermarked copy recorded", watermarkedURL: "s3://bucket/staged/a-watermarked.jpg"},
+		{name: "success: no watermark clears any earlier copy"},
+		{name: "success: record failure does not fail the job", watermarkedURL: "s3://bucket/w.jpg",
+			setErr: errors.New("db down")},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var order []string
+			repo := &repository.ImageRepositoryMock{
+				SetProcessingFunc: func(ctx context.Context, imageID string) error { return nil },
+				SetReadyFunc: func(ctx context.Context, imageID, stagedURL string) error {
+					order = append(order, "ready")
+					return nil
+				},
+				SetWatermarkedFunc: func(ctx context.Context, imageID, watermarkedURL string) error {
+					order = append(order, "watermarked")
+					return tc.setErr
+				},
+			}
+			svc := &staging.ServiceMock{
+				StageImageFunc: func(ctx context.Context, req *staging.StagingRequest) (string, error) {
+					req.WatermarkedURL = tc.watermarkedURL
+					return "s3://bucket/staged/a.jpg", nil
+				},
+			}
+			pub := &events.PublisherMock{
+				PublishJobUpdateFunc: func(ctx context.Context, ev events.JobUpdateEvent) error { return nil },
+			}
+
+			p := NewImageProcessor(repo, svc, pub, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+			require.NoError(t, p.ProcessJob(context.Background(), newStageJob(t, "img-1")))
+			require.Len(t, repo.SetWatermarkedCalls(), 1)
+			assert.Equal(t, tc.watermarkedURL, repo.SetWatermarkedCalls()[0].WatermarkedURL)
+			assert.Equal(t, []string{"watermarked", "ready"}, order)
+		})
+	}
+}
+
+func TestImageProcessor_ProcessJob_RecordsRawOriginal(t *testing.T) {
+	const (
+		jpegURL = "s3://bucket/uploads/u1/IMG_0001.jpg"
+		rawURL  = "s3://bucket/uploads/u1/IMG_0001.CR3"
+	)
+	cases := []struct {
+		name      string
+		rawURL    string
+		stageErr  error
+		wantCalls int
+	}{
+		{name: "success: converted RAW recorded", rawURL: rawURL, wantCalls: 1},
+		{name: "success: recorded even when staging fails", rawURL: rawURL, stageErr: errors.New("model failed"), wantCalls: 1},
+		{name: "success: nothing to record for other uploads", wantCalls: 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &repository.ImageRepositoryMock{
+				SetProcessingFunc:  func(ctx context.Context, imageID string) error { return nil },
+				SetReadyFunc:       func(ctx context.Context, imageID, stagedURL string) error { return nil },
+				SetErrorFunc:       func(ctx context.Context, imageID, errorMsg string) error { return nil },
+				SetWatermarkedFunc: func(ctx context.Context, imageID, watermarkedURL string) error { return nil },
+				SetRawOriginalFunc: func(ctx context.Context, imageID, originalURL, rawURL string) error { return nil },
+			}
+			svc := &staging.ServiceMock{
+				StageImageFunc: func(ctx context.Context, req *staging.StagingRequest) (string, error) {
+					if tc.rawURL != "" {
+						req.RawURL, req.OriginalURL = tc.rawURL, jpegURL
+					}
+					return "s3://bucket/staged/a.jpg", tc.stageErr
+				},
+			}
+			pub := &events.PublisherMock{
+				PublishJobUpdateFunc: func(ctx context.Context, ev events.JobUpdateEvent) error { return nil },
+			}
+
+			p := NewImageProcessor(repo, svc, pub, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+			err := p.ProcessJob(context.Background(), newStageJob(t, "img-1"))
+			if tc.stageErr != nil {
+				require.ErrorIs(t, err, tc.stageErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Len(t, repo.SetRawOriginalCalls(), tc.wantCalls)
+			if tc.wantCalls > 0 {
+				assert.Equal(t, jpegURL, repo.SetRawOriginalCalls()[0].OriginalURL)
+				assert.Equal(t, rawURL, repo.SetRawOriginalCalls()[0].RawURL)
+			}
+		})
+	}
+}
+
+func TestImageProcessor_ProcessJob_NotifiesImageReady(t *testing.T) {
+	cases := []struct {
+		name      string
+		notifyErr error
+	}{
+		{name: "success: owner notified"},
+		{name: "success: notify failure does not fail the job", notifyErr: errors.New("db down")},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &repository.ImageRepositoryMock{
+				SetProcessingFunc:  func(ctx context.Context, imageID string) error { return nil },
+				SetReadyFunc:       func(ctx context.Context, imageID, stagedURL string) error { return nil },
+				SetWatermarkedFunc: func(ctx context.Context, imageID, watermarkedURL string) error { return nil },
+			}
+			svc := &staging.ServiceMock{
+				StageImageFunc: func(ctx context.Context, req *staging.StagingRequest) (string, error) {
+					return "s3://bucket/staged/a.jpg", nil
+				},
+			}
+			pub := &events.PublisherMock{
+				PublishJobUpdateFunc: func(ctx context.Context, ev events.JobUpdateEvent) error { return nil },
+			}
+			notifier := &notification.NotifierMock{
+				ImageReadyFunc: func(ctx context.Context, imageID string) error { return tc.notifyErr },
+			}
+
+			p := NewImageProcessor(repo, svc, pub, nil, nil, nil, notifier, nil, nil, nil, nil, nil, nil, nil)
+			require.NoError(t, p.ProcessJob(context.Background(), newStageJob(t, "img-1")))
+			require.Len(t, notifier.ImageReadyCalls(), 1)
+			assert.Equal(t, "img-1", notifier.ImageReadyCalls()[0].ImageID)
+		})
+	}
+}
+
+func TestImageProcessor_ProcessJob_RecordsTurnaround(t *testing.T) {
+	cases := []struct {
+		name        string
+		result      turnaround.Result
+		recordErr   error
+		wantCredits int
+	}{
+		{
+			name:   "success: target met",
+			result: turnaround.Result{Recorded: true, Plan: "pro", Turnaround: time.Minute, Target: 10 * time.Minute, Met: true},
+		},
+		{
+			name: "success: credited miss notifies the owner",
+			result: turnaround.Result{
+				Recorded: true, Plan: "pro", Turnaround: 12 * time.Minute, Target: 10 * time.Minute, Credited: true,
+			},
+			wantCredits: 1,
+		},
+		{
+			name:   "success: uncredited miss does not notify",
+			result: turnaround.Result{Recorded: true, Plan: "basic", Turnaround: 12 * time.Minute, Target: 10 * time.Minute},
+		},
+		{name: "success: record failure does not fail the job", recordErr: errors.New("db down")},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &repository.ImageRepositoryMock{
+				SetProcessingFunc:  func(ctx context.Context, imageID string) error { return nil },
+				SetReadyFunc:       func(ctx context.Context, imageID, stagedURL string) error { return nil },
+				SetWatermarkedFunc: func(ctx context.Context, imageID, watermarkedURL string) error { return nil },
+			}
+			svc := &staging.ServiceMock{
+				StageImageFunc: func(ctx context.Context, req *staging.StagingRequest) (string, error) {
+					return "s3://bucket/staged/a.jpg", nil
+				},
+			}
+			pub := &events.PublisherMock{
+				PublishJobUpdateFunc: func(ctx context.Context, ev events.JobUpdateEvent) error { return nil },
+			}
+			notifier := &notification.NotifierMock{
+				ImageReadyFunc: func(ctx context.Context, imageID string) error { return nil },
+				SLACreditFunc:  func(ctx context.Context, imageID string) error { return nil },
+			}
+			turnarounds := &turnaround.RecorderMock{
+				RecordFunc: func(ctx context.Context, imageID string) (turnaround.Result, error) {
+					return tc.result, tc.recordErr
+				},
+			}
+
+			p := NewImageProcessor(repo, svc, pub, nil, nil, nil, notifier, nil, nil, turnarounds, nil, nil, nil, nil)
+			require.NoError(t, p.ProcessJob(context.Background(), newStageJob(t, "img-1")))
+			require.Len(t, turnarounds.RecordCalls(), 1)
+			assert.Equal(t, "img-1", turnarounds.RecordCalls()[0].ImageID)
+			assert.Len(t, notifier.SLACreditCalls(), tc.wantCredits)
+		})
+	}
+}
+
+func newBatchJob(t *testing.T, imageIDs ...string) *queue.Job {
+	t.Helper()
+	var batch queue.BatchPayload
+	for _, id := range imageIDs {
+		item, err := json.Marshal(JobPayload{ImageID: id, OriginalURL: "s3://bucket/uploads/" + id + ".jpg"})
+		require.NoError(t, err)
+		batch.Items = append(batch.Items, item)
+	}
+	payload, err := json.Marshal(batch)
+	require.NoError(t, err)
+	return &queue.Job{ID: "job-1", Type: queue.TaskTypeStageBatch, Payload: payload}
+}
+
+func TestImageProcessor_ProcessJob_Batch(t *testing.T) {
+	testCases := []struct {
+		name       string
+		statuses   map[string]string
+		stageErr   map[string]error
+		reserve    func(ctx context.Context, cost float64) error
+		wantStaged []string
+		wantErr    string
+		wantDefer  bool
+		wantPosted bool
+	}{
+		{
+			name:       "success: every image staged",
+			wantStaged: []string{"img-1", "img-2", "img-3"},
+			wantPosted: true,
+		},
+		{
+			name:       "success: images finished by an earlier attempt are skipped",
+			statuses:   map[string]string{"img-1": "ready", "img-3": "canceled"},
+			wantStaged: []string{"img-2"},
+			wantPosted: true,
+		},
+		{
+			name:       "fail: one failed image fails the batch after the rest finish",
+			stageErr:   map[string]error{"img-2": errors.New("provider down")},
+			wantStaged: []string{"img-1", "img-2", "img-3"},
+			wantErr:    "1 of 3 batch images failed",
+		},
+		{
+			name: "success: a deferred image defers the batch",
+			reserve: func(context.Context, float64) error {
+				return &costguard.CeilingError{Ceiling: 50, ResumeAt: time.Now().Add(time.Hour)}
+			},
+			wantDefer: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &repository.ImageRepositoryMock{
+				GetStatusFunc: func(ctx context.Context, imageID string) (string, error) {
+					if s, ok := tc.statuses[imageID]; ok {
+						return s, nil
+					}
+					return "queued", nil
+				},
+				SetProcessingFunc:  func(ctx context.Context, imageID string) error { return nil },
+				SetReadyFunc:       func(ctx context.Context, imageID, stagedURL string) error { return nil },
+				SetWatermarkedFunc: func(ctx context.Context, imageID, watermarkedURL string) error { return nil },
+				SetErrorFunc:       func(ctx context.Context, imageID, errorMsg string) error { return nil },
+			}
+			svc := &staging.ServiceMock{
+				EstimateCostFunc: func(req *staging.StagingRequest) float64 { return 0.08 },
+				StageImageFunc: func(ctx context.Context, req *staging.StagingRequest) (string, error) {
+					if err := tc.stageErr[req.ImageID]; err != nil {
+						return "", err
+					}
+					return "s3://bucket/staged/" + req.ImageID + ".jpg", nil
+				},
+			}
+			pub := &events.PublisherMock{
+				PublishJobUpdateFunc: func(ctx context.Context, ev events.JobUpdateEvent) error { return nil },
+			}
+			var guard costguard.Guard
+			if tc.reserve != nil {
+				guard = &costguard.GuardMock{ReserveFunc: tc.reserve}
+			}
+
+			teamHooks := &teamwebhook.NotifierMock{
+				BatchCompleteFunc: func(ctx context.Context, imageID string, images int) error {
+					return errors.New("slack webhook: Slack responded 404: no_service")
+				},
+			}
+
+			p := NewImageProcessor(repo, svc, pub, nil, nil, guard, nil, teamHooks, nil, nil, nil, nil, nil, nil)
+			err := p.ProcessJob(context.Background(), newBatchJob(t, "img-1", "img-2", "img-3"))
+
+			switch {
+			case tc.wantDefer:
+				var deferred *queue.DeferredError
+				require.ErrorAs(t, err, &deferred)
+			case tc.wantErr != "":
+				assert.ErrorContains(t, err, tc.wantErr)
+				assert.ErrorContains(t, err, "provider down")
+			default:
+				require.NoError(t, err)
+			}
+
+			var staged []string
+			for _, call := range svc.StageImageCalls() {
+				staged = append(staged, call.Req.ImageID)
+			}
+			assert.ElementsMatch(t, tc.wantStaged, staged)
+
+			if !tc.wantPosted {
+				assert.Empty(t, teamHooks.BatchCompleteCalls())
+				return
+			}
+			require.Len(t, teamHooks.BatchCompleteCalls(), 1, "a post failure must not fail the batch")
+			assert.Equal(t, "img-1", teamHooks.BatchCompleteCalls()[0].ImageID)
+			assert.Equal(t, 3, teamHooks.BatchCompleteCalls()[0].Images)
+		})
+	}
+}
+
+func TestImageProcessor_PublishPreview(t *testing.T) {
+	cases := []struct {
+		name          string
+		setPreviewErr error
+		publishErr    error
+		wantErr       bool
+		wantPublished int
+	}{
+		{name: "success: preview recorded and announced", wantPublished: 1},
+		{name: "success: publish failure is not an error", publishErr: errors.New("redis down"), wantPublished: 1},
+		{name: "fail: record failure skips the announcement", setPreviewErr: errors.New("db down"), wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &repository.ImageRepositoryMock{
+				SetPreviewFunc: func(ctx context.Context, imageID, previewURL string) error { return tc.setPreviewErr },
+			}
+			pub := &events.PublisherMock{
+				PublishJobUpdateFunc: func(ctx context.Context, ev events.JobUpdateEvent) error { return tc.publishErr },
+			}
+
+			p := NewImageProcessor(repo, &staging.ServiceMock{}, pub, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+			err := p.PublishPreview(context.Background(), "img-1", "s3://bucket/staged/img-1-preview.jpg")
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Len(t, pub.PublishJobUpdateCalls(), tc.wantPublished)
+			if tc.wantPublished > 0 {
+				assert.Equal(t, events.JobUpdateEvent{ImageID: "img-1", Status: "processing", Preview: true},
+					pub.PublishJobUpdateCalls()[0].Ev)
+				assert.Equal(t, "s3://bucket/staged/img-1-preview.jpg", repo.SetPreviewCalls()[0].PreviewURL)
+			}
+		})
+	}
+}
+
+func TestImageProcessor_ProcessJob_Edit(t *testing.T) {
+	newEditJob := func(t *testing.T, payload EditPayload) *queue.Job {
+		t.Helper()
+		b, err := json.Marshal(payload)
+		require.NoError(t, err)
+		return &queue.Job{ID: "job-1", Type: queue.TaskTypeEditErase, Payload: b}
+	}
+	valid := EditPayload{
+		EditID:  "edit-1",
+		ImageID: "img-1",
+		Base:    "staged",
+		BaseURL: "s3://bucket/staged/img-1-staged.jpg",
+		MaskURL: "s3://bucket/uploads/u/mask.png",
+	}
+	resumeAt := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name      string
+		payload   EditPayload
+		noEdits   bool
+		reserve   func(context.Context, float64) error
+		eraseErr  error
+		wantReady bool
+		wantError bool
+		wantDefer bool
+		wantErr   bool
+	}{
+		{name: "success: edit is marked ready", payload: valid, wantReady: true},
+		{
+			name:      "success: over the ceiling defers the edit untouched",
+			payload:   valid,
+			reserve:   func(context.Context, float64) error { return &costguard.CeilingError{ResumeAt: resumeAt} },
+			wantDefer: true,
+			wantErr:   true,
+		},
+		{
+			name:      "fail: erase error marks the edit failed",
+			payload:   valid,
+			eraseErr:  errors.New("prediction failed"),
+			wantError: true,
+			wantErr:   true,
+		},
+		{
+			name:    "fail: missing mask",
+			payload: EditPayload{EditID: "edit-1", ImageID: "img-1", BaseURL: "s3://x"},
+			wantErr: true,
+		},
+		{name: "fail: edits not configured", payload: valid, noEdits: true, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &staging.ServiceMock{
+				EstimateEraseCostFunc: func(req *staging.EraseRequest) float64 { return 0.002 },
+				EraseObjectFunc: func(ctx context.Context, req *staging.EraseRequest) (string, error) {
+					return "s3://bucket/staged/img-1-edit-edit-1", tc.eraseErr
+				},
+			}
+			edits := &edit.RepositoryMock{
+				MarkProcessingFunc: func(ctx context.Context, editID string) error { return nil },
+				MarkReadyFunc:      func(ctx context.Context, editID, resultURL string) error { return nil },
+				MarkErrorFunc:      func(ctx context.Context, editID, message string) error { return nil },
+			}
+			var guard costguard.Guard
+			if tc.reserve != nil {
+				guard = &costguard.GuardMock{ReserveFunc: tc.reserve}
+			}
+			var repo edit.Repository = edits
+			if tc.noEdits {
+				repo = nil
+			}
+
+			p := NewImageProcessor(&repository.ImageRepositoryMock{}, svc, &events.PublisherMock{},
+				nil, nil, guard, nil, nil, nil, nil, repo, nil, nil, nil)
+			err := p.ProcessJob(context.Background(), newEditJob(t, tc.payload))
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			if tc.wantDefer {
+				var deferred *queue.DeferredError
+				require.ErrorAs(t, err, &deferred)
+				assert.Empty(t, edits.MarkProcessingCalls())
+			}
+
+			require.Len(t, edits.MarkReadyCalls(), map[bool]int{true: 1}[tc.wantReady])
+			require.Len(t, edits.MarkErrorCalls(), map[bool]int{true: 1}[tc.wantError])
+			if tc.wantReady {
+				assert.Equal(t, "s3://bucket/staged/img-1-edit-edit-1", edits.MarkReadyCalls()[0].ResultURL)
+				require.Len(t, svc.EraseObjectCalls(), 1)
+				assert.True(t, svc.EraseObjectCalls()[0].Req.BaseStaged)
+			}
+			if tc.wantError {
+				assert.Equal(t, "prediction failed", edits.MarkErrorCalls()[0].Message)
+			}
+		})
+	}
+}
+
+func TestImageProcessor_ProcessJob_RoomGroup(t *testing.T) {
+	testCases := []struct {
+		name       string
+		payload    string
+		noAnalyzer bool
+		analyzeErr error
+		wantCalls  int
+		wantErr    bool
+	}{
+		{name: "success: project is analyzed", payload: `{"grouping_id":"g-1","project_id":"p-1"}`, wantCalls: 1},
+		{
+			name:       "fail: analysis error",
+			payload:    `{"grouping_id":"g-1","project_id":"p-1"}`,
+			analyzeErr: errors.New("no image could be read"),
+			wantCalls:  1,
+			wantErr:    true,
+		},
+		{name: "fail: missing grouping", payload: `{"project_id":"p-1"}`, wantErr: true},
+		{name: "fail: malformed payload", payload: `{`, wantErr: true},
+		{
+			name:       "fail: grouping not configured",
+			payload:    `{"grouping_id":"g-1","project_id":"p-1"}`,
+			noAnalyzer: true,
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			analyzer := &roomgroup.AnalyzerMock{
+				AnalyzeFunc: func(ctx context.Context, groupingID, projectID string) error {
+					assert.Equal(t, "g-1", groupingID)
+					assert.Equal(t, "p-1", projectID)
+					return tc.analyzeErr
+				},
+			}
+			var rooms roomgroup.Analyzer = analyzer
+			if tc.noAnalyzer {
+				rooms = nil
+			}
+
+			p := NewImageProcessor(&repository.ImageRepositoryMock{}, &staging.ServiceMock{}, &events.PublisherMock{},
+				nil, nil, nil, nil, nil, nil, nil, nil, rooms, nil, nil)
+			err := p.ProcessJob(context.Background(),
+				&queue.Job{ID: "job-1", Type: queue.TaskTypeRoomsGroup, Payload: []byte(tc.payload)})
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Len(t, analyzer.AnalyzeCalls(), tc.wantCalls)
+		})
+	}
+}
+
+func TestImageProcessor_ProcessJob_ProjectExport(t *testing.T) {
+	testCases := []struct {
+		name       string
+		payload    string
+		noExporter bool
+		exportErr  error
+		wantCalls  int
+		wantErr    bool
+	}{
+		{name: "success: project is exported", payload: `{"export_id":"e-1","project_id":"p-1"}`, wantCalls: 1},
+		{
+			name:      "fail: export error",
+			payload:   `{"export_id":"e-1","project_id":"p-1"}`,
+			exportErr: errors.New("s3 down"),
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{name: "fail: missing export", payload: `{"project_id":"p-1"}`, wantErr: true},
+		{name: "fail: malformed payload", payload: `{`, wantErr: true},
+		{
+			name:       "fail: exports not configured",
+			payload:    `{"export_id":"e-1","project_id":"p-1"}`,
+			noExporter: true,
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &projectexport.ExporterMock{
+				ExportFunc: func(ctx context.Context, exportID, projectID string) error {
+					assert.Equal(t, "e-1", exportID)
+					assert.Equal(t, "p-1", projectID)
+					return tc.exportErr
+				},
+			}
+			var exports projectexport.Exporter = mock
+			if tc.noExporter {
+				exports = nil
+			}
+
+			p := NewImageProcessor(&repository.ImageRepositoryMock{}, &staging.ServiceMock{}, &events.PublisherMock{},
+				nil, nil, nil, nil, nil, nil, nil, nil, nil, exports, nil)
+			err := p.ProcessJob(context.Background(),
+				&queue.Job{ID: "job-1", Type: queue.TaskTypeProjectExport, Payload: []byte(tc.payload)})
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Len(t, mock.ExportCalls(), tc.wantCalls)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/replicate/replicate-go"
+
+	"github.com/real-staging-ai/worker/internal/config"
+	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/queue"
+)
+
+// RetryPolicy bounds how often a job's provider and storage calls are retried after a
+// transient error. Retries run in process, before the image is marked as failed, so a
+// brief Replicate or S3 outage never reaches the user.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of tries, including the first; at least 1.
+	MaxAttempts int
+	// BaseDelay is the wait before the first retry. It doubles for each retry after that.
+	BaseDelay time.Duration
+	// MaxDelay caps the wait between tries.
+	MaxDelay time.Duration
+}
+
+// RetryPolicies holds the retry policy of each job type. Job types missing from it are
+// tried once.
+type RetryPolicies map[string]RetryPolicy
+
+// RetryPoliciesFromConfig builds the retry policies of the job types the worker retries.
+// Room grouping is left out: it records its outcome as it goes, so a retry would flip a
+// failed grouping back to processing.
+func RetryPoliciesFromConfig(cfg config.Job) RetryPolicies {
+	policy := func(attempts int) RetryPolicy {
+		return RetryPolicy{MaxAttempts: attempts, BaseDelay: cfg.RetryBaseDelay, MaxDelay: cfg.RetryMaxDelay}
+	}
+	return RetryPolicies{
+		"stage:run":              policy(cfg.StageMaxAttempts),
+		queue.TaskTypeStageBatch: policy(cfg.BatchMaxAttempts),
+		queue.TaskTypeEditErase:  policy(cfg.EditMaxAttempts),
+	}
+}
+
+// do runs fn until it succeeds, fails with an error that is not transient, runs out of
+// attempts, or ctx ends. Waits between tries grow exponentially, with jitter. onRetry,
+// if set, is called before each wait. The last error is returned.
+func (p RetryPolicy) do(
+	ctx context.Context,
+	fn func(ctx context.Context, attempt int) error,
+	onRetry func(attempt int, wait time.Duration, err error),
+) error {
+	attempts := max(p.MaxAttempts, 1)
+	delay := p.BaseDelay
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn(ctx, attempt)
+		if err == nil || attempt >= attempts || ctx.Err() != nil || !isTransient(err) {
+			return err
+		}
+
+		wait := delay
+		if wait > 0 {
+			wait = rand.N(wait) + wait/2
+		}
+		logging.Default().Warn(ctx, "transient job error; retrying",
+			"attempt", attempt, "max_attempts", attempts, "retry_in", wait.String(), "error", err)
+		if onRetry != nil {
+			onRetry(attempt, wait, err)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		delay = min(delay*2, p.MaxDelay)
+	}
+}
+
+// isTransient reports whether err is a provider or storage error that may succeed when
+// retried: a rate limit or server error from Replicate or S3, a dropped connection, or a
+// request timeout. Cancellation never is; callers check their own context for deadlines.
+func isTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var apiErr *replicate.APIError
+	if errors.As(err, &apiErr) {
+		return retryableStatus(apiErr.Status)
+	}
+	// S3 errors carry the HTTP status of the response that failed
+	var httpErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &httpErr) {
+		return retryableStatus(httpErr.HTTPStatusCode())
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// retryableStatus reports whether an HTTP status is a rate limit or server error.
+func retryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
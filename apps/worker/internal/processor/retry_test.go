@@ -0,0 +1,141 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/replicate/replicate-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/worker/internal/config"
+	"github.com/real-staging-ai/worker/internal/queue"
+)
+
+// statusError stands in for an S3 response error.
+type statusError struct{ status int }
+
+func (e statusError) Error() string       { return fmt.Sprintf("status %d", e.status) }
+func (e statusError) HTTPStatusCode() int { return e.status }
+
+func TestRetryPolicy_do(t *testing.T) {
+	transient := &replicate.APIError{Status: 503, Detail: "unavailable"}
+	permanent := errors.New("prediction failed: NSFW content detected")
+
+	testCases := []struct {
+		name        string
+		policy      RetryPolicy
+		errs        []error
+		wantCalls   int
+		wantRetries int
+		wantErr     error
+	}{
+		{
+			name:      "success: first try",
+			policy:    RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond},
+			errs:      []error{nil},
+			wantCalls: 1,
+		},
+		{
+			name:        "success: transient error then success",
+			policy:      RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond},
+			errs:        []error{transient, transient, nil},
+			wantCalls:   3,
+			wantRetries: 2,
+		},
+		{
+			name:      "fail: permanent error is not retried",
+			policy:    RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond},
+			errs:      []error{permanent},
+			wantCalls: 1,
+			wantErr:   permanent,
+		},
+		{
+			name:        "fail: attempts used up",
+			policy:      RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond},
+			errs:        []error{transient, transient},
+			wantCalls:   2,
+			wantRetries: 1,
+			wantErr:     transient,
+		},
+		{
+			name:      "fail: zero policy tries once",
+			errs:      []error{transient},
+			wantCalls: 1,
+			wantErr:   transient,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls, retries int
+			err := tc.policy.do(context.Background(), func(ctx context.Context, attempt int) error {
+				calls++
+				assert.Equal(t, calls, attempt)
+				return tc.errs[attempt-1]
+			}, func(attempt int, wait time.Duration, err error) {
+				retries++
+				assert.Equal(t, calls, attempt)
+			})
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCalls, calls)
+			assert.Equal(t, tc.wantRetries, retries)
+		})
+	}
+
+	t.Run("fail: context ends during the wait", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var calls int
+		err := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour}.do(ctx, func(context.Context, int) error {
+			calls++
+			return transient
+		}, func(int, time.Duration, error) { cancel() })
+		assert.Equal(t, transient, err)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestIsTransient(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "replicate rate limit", err: fmt.Errorf("create: %w", &replicate.APIError{Status: 429}), want: true},
+		{name: "replicate server error", err: &replicate.APIError{Status: 502}, want: true},
+		{name: "replicate bad input", err: &replicate.APIError{Status: 422}, want: false},
+		{name: "s3 slow down", err: fmt.Errorf("get object: %w", statusError{503}), want: true},
+		{name: "s3 access denied", err: statusError{403}, want: false},
+		{name: "connection reset", err: fmt.Errorf("read: %w", syscall.ECONNRESET), want: true},
+		{name: "network error", err: &net.OpError{Op: "dial", Err: errors.New("no route to host")}, want: true},
+		{name: "request timeout", err: fmt.Errorf("download: %w", context.DeadlineExceeded), want: true},
+		{name: "canceled", err: fmt.Errorf("prediction aborted: %w", context.Canceled), want: false},
+		{name: "prediction failed", err: errors.New("prediction failed: out of memory"), want: false},
+		{name: "nil", err: nil, want: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, isTransient(tc.err))
+		})
+	}
+}
+
+func TestRetryPoliciesFromConfig(t *testing.T) {
+	policies := RetryPoliciesFromConfig(config.Job{
+		StageMaxAttempts: 3,
+		BatchMaxAttempts: 2,
+		EditMaxAttempts:  1,
+		RetryBaseDelay:   time.Second,
+		RetryMaxDelay:    10 * time.Second,
+	})
+	require.Len(t, policies, 3)
+	assert.Equal(t, RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: 10 * time.Second}, policies["stage:run"])
+	assert.Equal(t, 2, policies[queue.TaskTypeStageBatch].MaxAttempts)
+	assert.Equal(t, 1, policies[queue.TaskTypeEditErase].MaxAttempts)
+	assert.Zero(t, policies[queue.TaskTypeRoomsGroup])
+}
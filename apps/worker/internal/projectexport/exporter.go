@@ -0,0 +1,140 @@
+package projectexport
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/watermark"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out exporter_mock.go . Exporter ImageReader
+
+// MaxImages is how many of a project's staged photos an export holds, oldest first.
+const MaxImages = 500
+
+// Exporter builds a project export.
+type Exporter interface {
+	// Export zips the staged photos of the export's project and records the zip on it.
+	Export(ctx context.Context, exportID, projectID string) error
+}
+
+// ImageReader downloads an image by its s3:// URL.
+type ImageReader interface {
+	ReadImage(ctx context.Context, rawURL string) ([]byte, error)
+}
+
+// DefaultExporter implements Exporter.
+type DefaultExporter struct {
+	repo   Repository
+	images ImageReader
+	store  Store
+}
+
+// Ensure DefaultExporter implements Exporter.
+var _ Exporter = (*DefaultExporter)(nil)
+
+// NewDefaultExporter creates a DefaultExporter that reads staged photos with images and
+// keeps zips in store.
+func NewDefaultExporter(repo Repository, images ImageReader, store Store) *DefaultExporter {
+	return &DefaultExporter{repo: repo, images: images, store: store}
+}
+
+// Export marks the export processing, zips up to MaxImages of the project's staged photos,
+// stores the zip and marks the export ready. Failures are recorded on the export; a retry
+// marks it processing again.
+func (e *DefaultExporter) Export(ctx context.Context, exportID, projectID string) error {
+	export, err := e.repo.Get(ctx, exportID)
+	if err != nil {
+		return err
+	}
+	if export.ProjectID != projectID {
+		return fmt.Errorf("project export %s belongs to project %s, not %s", exportID, export.ProjectID, projectID)
+	}
+	if err := e.repo.MarkProcessing(ctx, exportID); err != nil {
+		return err
+	}
+
+	resultURL, count, err := e.build(ctx, export)
+	if err != nil {
+		if markErr := e.repo.MarkError(ctx, exportID, err.Error()); markErr != nil {
+			logging.Default().Error(ctx, "Failed to mark project export as error", "export_id", exportID, "error", markErr)
+		}
+		return err
+	}
+	return e.repo.MarkReady(ctx, exportID, resultURL, count)
+}
+
+// build zips the project's staged photos, marking them when the export is watermarked, and
+// stores the zip. It returns the zip's URL and the number of photos in it.
+func (e *DefaultExporter) build(ctx context.Context, export *Export) (string, int, error) {
+	images, err := e.repo.ListImages(ctx, export.ProjectID, MaxImages)
+	if err != nil {
+		return "", 0, err
+	}
+	if len(images) == 0 {
+		return "", 0, errors.New("project has no staged photos to export")
+	}
+	var mark []byte
+	if export.Watermarked {
+		if mark, err = watermark.PlatformMark(); err != nil {
+			return "", 0, fmt.Errorf("render platform mark: %w", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for i, img := range images {
+		body, err := e.images.ReadImage(ctx, img.StagedURL)
+		if err != nil {
+			return "", 0, fmt.Errorf("read staged image %s: %w", img.ID, err)
+		}
+		ext := extension(img.StagedURL)
+		if mark != nil {
+			if body, err = watermark.Render(body, mark, watermark.PlatformSettings); err != nil {
+				return "", 0, fmt.Errorf("mark staged image %s: %w", img.ID, err)
+			}
+			ext = ".jpg"
+		}
+		// Photos are already compressed, so they are stored as they are.
+		w, err := zw.CreateHeader(&zip.FileHeader{
+			Name:   fmt.Sprintf("%03d-%s%s", i+1, img.ID, ext),
+			Method: zip.Store,
+		})
+		if err != nil {
+			return "", 0, fmt.Errorf("add staged image %s: %w", img.ID, err)
+		}
+		if _, err := w.Write(body); err != nil {
+			return "", 0, fmt.Errorf("add staged image %s: %w", img.ID, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return "", 0, fmt.Errorf("close zip: %w", err)
+	}
+
+	key := fmt.Sprintf("exports/%s/%s.zip", export.ProjectID, export.ID)
+	resultURL, err := e.store.Put(ctx, key, buf.Bytes(), "application/zip")
+	if err != nil {
+		return "", 0, err
+	}
+	return resultURL, len(images), nil
+}
+
+// extension returns the file extension of a stored image URL, defaulting to .jpg.
+func extension(rawURL string) string {
+	p := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		p = u.Path
+	}
+	ext := strings.ToLower(path.Ext(p))
+	if ext == "" {
+		return ".jpg"
+	}
+	return ext
+}
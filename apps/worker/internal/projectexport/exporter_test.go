@@ -0,0 +1,150 @@
+package projectexport
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func grayPNG(t *testing.T) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 300, 200))
+	for y := range 200 {
+		for x := range 300 {
+			img.Set(x, y, color.RGBA{R: 128, G: 128, B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestDefaultExporter_Export(t *testing.T) {
+	photo := grayPNG(t)
+
+	testCases := []struct {
+		name        string
+		watermarked bool
+		images      []Image
+		readErr     error
+		putErr      error
+		payloadID   string
+		wantErr     bool
+		wantNames   []string
+		wantMarked  bool
+	}{
+		{
+			name:      "success: clean export keeps the staged files",
+			images:    []Image{{ID: "img-1", StagedURL: "s3://bucket/stage/a.png"}, {ID: "img-2", StagedURL: "s3://bucket/stage/b"}},
+			wantNames: []string{"001-img-1.png", "002-img-2.jpg"},
+		},
+		{
+			name:        "success: watermarked export marks every photo",
+			watermarked: true,
+			images:      []Image{{ID: "img-1", StagedURL: "s3://bucket/stage/a.png"}},
+			wantNames:   []string{"001-img-1.jpg"},
+			wantMarked:  true,
+		},
+		{
+			name:    "fail: no staged photos",
+			wantErr: true,
+		},
+		{
+			name:    "fail: read error",
+			images:  []Image{{ID: "img-1", StagedURL: "s3://bucket/stage/a.png"}},
+			readErr: errors.New("s3 down"),
+			wantErr: true,
+		},
+		{
+			name:    "fail: store error",
+			images:  []Image{{ID: "img-1", StagedURL: "s3://bucket/stage/a.png"}},
+			putErr:  errors.New("s3 down"),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &RepositoryMock{
+				GetFunc: func(ctx context.Context, id string) (*Export, error) {
+					return &Export{ID: id, ProjectID: projectID, Watermarked: tc.watermarked}, nil
+				},
+				ListImagesFunc: func(ctx context.Context, pid string, limit int) ([]Image, error) {
+					assert.Equal(t, MaxImages, limit)
+					return tc.images, nil
+				},
+				MarkProcessingFunc: func(ctx context.Context, id string) error { return nil },
+				MarkReadyFunc: func(ctx context.Context, id, resultURL string, imageCount int) error {
+					return nil
+				},
+				MarkErrorFunc: func(ctx context.Context, id, message string) error { return nil },
+			}
+			reader := &ImageReaderMock{
+				ReadImageFunc: func(ctx context.Context, rawURL string) ([]byte, error) {
+					return photo, tc.readErr
+				},
+			}
+			var zipped []byte
+			store := &StoreMock{
+				PutFunc: func(ctx context.Context, key string, body []byte, contentType string) (string, error) {
+					if tc.putErr != nil {
+						return "", tc.putErr
+					}
+					zipped = body
+					return "s3://bucket/" + key, nil
+				},
+			}
+
+			err := NewDefaultExporter(repo, reader, store).Export(context.Background(), exportID, projectID)
+			if tc.wantErr {
+				require.Error(t, err)
+				require.Len(t, repo.MarkErrorCalls(), 1)
+				assert.Empty(t, repo.MarkReadyCalls())
+				return
+			}
+			require.NoError(t, err)
+			assert.Empty(t, repo.MarkErrorCalls())
+
+			ready := repo.MarkReadyCalls()
+			require.Len(t, ready, 1)
+			assert.Equal(t, "s3://bucket/exports/"+projectID+"/"+exportID+".zip", ready[0].ResultURL)
+			assert.Equal(t, len(tc.images), ready[0].ImageCount)
+			assert.Equal(t, "application/zip", store.PutCalls()[0].ContentType)
+
+			zr, err := zip.NewReader(bytes.NewReader(zipped), int64(len(zipped)))
+			require.NoError(t, err)
+			var names []string
+			for _, f := range zr.File {
+				names = append(names, f.Name)
+				rc, err := f.Open()
+				require.NoError(t, err)
+				body, err := io.ReadAll(rc)
+				require.NoError(t, err)
+				_ = rc.Close()
+				assert.Equal(t, tc.wantMarked, !bytes.Equal(photo, body))
+			}
+			assert.Equal(t, tc.wantNames, names)
+		})
+	}
+
+	t.Run("fail: export of another project", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetFunc: func(ctx context.Context, id string) (*Export, error) {
+				return &Export{ID: id, ProjectID: "other"}, nil
+			},
+		}
+		err := NewDefaultExporter(repo, &ImageReaderMock{}, &StoreMock{}).
+			Export(context.Background(), exportID, projectID)
+		require.Error(t, err)
+		assert.Empty(t, repo.MarkProcessingCalls())
+	})
+}
@@ -0,0 +1,140 @@
+// Package projectexport bundles a project's staged photos into one zip for the API's
+// project exports. Exports of projects whose owner has no active plan and that were not
+// unlocked with an export credit carry the platform mark on every photo.
+package projectexport
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/real-staging-ai/worker/internal/dbretry"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// ErrNotFound is returned when the export does not exist.
+var ErrNotFound = errors.New("project export not found")
+
+// Export is the part of a project export the worker reads.
+type Export struct {
+	ID        string
+	ProjectID string
+	// Watermarked is set when the photos get the platform mark.
+	Watermarked bool
+}
+
+// Image is a staged photo of the project being exported.
+type Image struct {
+	ID        string
+	StagedURL string
+}
+
+// Repository reads exports and the photos they bundle and moves an export through its
+// statuses.
+type Repository interface {
+	// Get returns the export.
+	Get(ctx context.Context, exportID string) (*Export, error)
+	// ListImages returns up to limit of the project's staged photos, oldest first.
+	ListImages(ctx context.Context, projectID string, limit int) ([]Image, error)
+	// MarkProcessing records that a worker has started on the export.
+	MarkProcessing(ctx context.Context, exportID string) error
+	// MarkReady records where the zip is stored and how many photos it holds.
+	MarkReady(ctx context.Context, exportID, resultURL string, imageCount int) error
+	// MarkError records why the export failed.
+	MarkError(ctx context.Context, exportID, message string) error
+}
+
+// SQLRepository reads and updates project_exports with database/sql.
+type SQLRepository struct {
+	db *sql.DB
+}
+
+// Ensure SQLRepository implements Repository.
+var _ Repository = (*SQLRepository)(nil)
+
+// NewSQLRepository creates a SQLRepository.
+func NewSQLRepository(db *sql.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+// Get returns the export, or ErrNotFound.
+func (r *SQLRepository) Get(ctx context.Context, exportID string) (*Export, error) {
+	const q = `SELECT id::text, project_id::text, watermarked FROM project_exports WHERE id = $1::uuid`
+	var e Export
+	err := dbretry.Do(ctx, "get project export", func(ctx context.Context) error {
+		return r.db.QueryRowContext(ctx, q, exportID).Scan(&e.ID, &e.ProjectID, &e.Watermarked)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get project export: %w", err)
+	}
+	return &e, nil
+}
+
+// ListImages returns the project's ready images in upload order.
+func (r *SQLRepository) ListImages(ctx context.Context, projectID string, limit int) ([]Image, error) {
+	const q = `SELECT id::text, staged_url FROM images
+		WHERE project_id = $1::uuid AND status = 'ready' AND staged_url IS NOT NULL
+		ORDER BY created_at, id
+		LIMIT $2`
+	var images []Image
+	err := dbretry.Do(ctx, "list staged images", func(ctx context.Context) error {
+		images = nil
+		rows, err := r.db.QueryContext(ctx, q, projectID, limit)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = rows.Close() }()
+		for rows.Next() {
+			var img Image
+			if err := rows.Scan(&img.ID, &img.StagedURL); err != nil {
+				return err
+			}
+			images = append(images, img)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list staged images: %w", err)
+	}
+	return images, nil
+}
+
+// MarkProcessing sets the export's status to processing, clearing the error of an earlier
+// attempt.
+func (r *SQLRepository) MarkProcessing(ctx context.Context, exportID string) error {
+	q := `UPDATE project_exports SET status = 'processing', error = NULL, updated_at = now()
+		WHERE id = $1::uuid AND status <> 'ready'`
+	return r.exec(ctx, "mark project export processing", q, exportID)
+}
+
+// MarkReady sets the export's status to ready with its zip.
+func (r *SQLRepository) MarkReady(ctx context.Context, exportID, resultURL string, imageCount int) error {
+	q := `UPDATE project_exports SET status = 'ready', result_url = $2, image_count = $3, error = NULL,
+		updated_at = now()
+		WHERE id = $1::uuid`
+	return r.exec(ctx, "mark project export ready", q, exportID, resultURL, imageCount)
+}
+
+// MarkError sets the export's status to error with the reason.
+func (r *SQLRepository) MarkError(ctx context.Context, exportID, message string) error {
+	q := `UPDATE project_exports SET status = 'error', error = $2, updated_at = now()
+		WHERE id = $1::uuid AND status <> 'ready'`
+	return r.exec(ctx, "mark project export error", q, exportID, message)
+}
+
+// exec runs an update, retrying transient failures.
+func (r *SQLRepository) exec(ctx context.Context, op, q string, args ...any) error {
+	err := dbretry.Do(ctx, op, func(ctx context.Context) error {
+		_, err := r.db.ExecContext(ctx, q, args...)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
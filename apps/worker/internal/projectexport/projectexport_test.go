@@ -0,0 +1,147 @@
+package projectexport
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	exportID  = "3c1d9e7a-2b4f-4a6c-9d8e-7f1a2b3c4d5e"
+	projectID = "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"
+)
+
+func TestSQLRepository_Get(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rows     *sqlmock.Rows
+		queryErr error
+		want     *Export
+		wantErr  error
+	}{
+		{
+			name: "success: watermarked export",
+			rows: sqlmock.NewRows([]string{"id", "project_id", "watermarked"}).AddRow(exportID, projectID, true),
+			want: &Export{ID: exportID, ProjectID: projectID, Watermarked: true},
+		},
+		{
+			name:     "fail: not found",
+			queryErr: sql.ErrNoRows,
+			wantErr:  ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+
+			exp := mock.ExpectQuery(`SELECT id::text, project_id::text, watermarked FROM project_exports`).
+				WithArgs(exportID)
+			if tc.queryErr != nil {
+				exp.WillReturnError(tc.queryErr)
+			} else {
+				exp.WillReturnRows(tc.rows)
+			}
+
+			got, err := NewSQLRepository(db).Get(context.Background(), exportID)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSQLRepository_ListImages(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	mock.ExpectQuery(`SELECT id::text, staged_url FROM images\s+WHERE project_id = \$1::uuid AND status = 'ready'`).
+		WithArgs(projectID, 500).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "staged_url"}).
+			AddRow("img-1", "s3://bucket/stage/u/a.png").
+			AddRow("img-2", "s3://bucket/stage/u/b.jpg"))
+
+	images, err := NewSQLRepository(db).ListImages(context.Background(), projectID, 500)
+	require.NoError(t, err)
+	assert.Equal(t, []Image{
+		{ID: "img-1", StagedURL: "s3://bucket/stage/u/a.png"},
+		{ID: "img-2", StagedURL: "s3://bucket/stage/u/b.jpg"},
+	}, images)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSQLRepository_Mark(t *testing.T) {
+	testCases := []struct {
+		name    string
+		query   string
+		args    []driver.Value
+		mark    func(r *SQLRepository) error
+		execErr error
+	}{
+		{
+			name:  "success: processing",
+			query: `UPDATE project_exports SET status = 'processing', error = NULL`,
+			args:  []driver.Value{exportID},
+			mark:  func(r *SQLRepository) error { return r.MarkProcessing(context.Background(), exportID) },
+		},
+		{
+			name:  "success: ready",
+			query: `UPDATE project_exports SET status = 'ready', result_url = \$2, image_count = \$3`,
+			args:  []driver.Value{exportID, "s3://bucket/exports/p/e.zip", 2},
+			mark: func(r *SQLRepository) error {
+				return r.MarkReady(context.Background(), exportID, "s3://bucket/exports/p/e.zip", 2)
+			},
+		},
+		{
+			name:  "success: error",
+			query: `UPDATE project_exports SET status = 'error', error = \$2`,
+			args:  []driver.Value{exportID, "read failed"},
+			mark: func(r *SQLRepository) error {
+				return r.MarkError(context.Background(), exportID, "read failed")
+			},
+		},
+		{
+			name:    "fail: exec error",
+			query:   `UPDATE project_exports SET status = 'processing'`,
+			args:    []driver.Value{exportID},
+			mark:    func(r *SQLRepository) error { return r.MarkProcessing(context.Background(), exportID) },
+			execErr: errors.New("boom"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+
+			exp := mock.ExpectExec(tc.query).WithArgs(tc.args...)
+			if tc.execErr != nil {
+				exp.WillReturnError(tc.execErr)
+			} else {
+				exp.WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			err = tc.mark(NewSQLRepository(db))
+			if tc.execErr != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
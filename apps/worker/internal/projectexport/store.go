@@ -0,0 +1,58 @@
+package projectexport
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/real-staging-ai/worker/internal/config"
+	"github.com/real-staging-ai/worker/internal/s3client"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out store_mock.go . Store
+
+// Store keeps finished export zips.
+type Store interface {
+	// Put writes body to key, replacing any existing object, and returns its s3:// URL.
+	Put(ctx context.Context, key string, body []byte, contentType string) (string, error)
+}
+
+// S3Store is an S3-backed Store in the image bucket, where the API presigns downloads.
+type S3Store struct {
+	client *s3.Client
+	bucket string
+}
+
+// Ensure S3Store implements Store.
+var _ Store = (*S3Store)(nil)
+
+// NewS3Store creates an S3Store for the configured image bucket.
+func NewS3Store(ctx context.Context, cfg *config.Config) (*S3Store, error) {
+	bucket := cfg.S3Bucket()
+	if bucket == "" {
+		return nil, fmt.Errorf("s3 bucket is required")
+	}
+
+	client, err := s3client.New(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &S3Store{client: client, bucket: bucket}, nil
+}
+
+// Put writes body to key.
+func (s *S3Store) Put(ctx context.Context, key string, body []byte, contentType string) (string, error) {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(body),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return "", fmt.Errorf("put s3://%s/%s: %w", s.bucket, key, err)
+	}
+	return fmt.Sprintf("s3://%s/%s", s.bucket, key), nil
+}
@@ -0,0 +1,236 @@
+package provenance
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/google/uuid"
+)
+
+// Labels used inside the manifest. The API verifier mirrors these.
+const (
+	labelManifestStore = "c2pa"
+	labelAssertions    = "c2pa.assertions"
+	labelActions       = "c2pa.actions"
+	labelDataHash      = "c2pa.hash.data"
+	labelClaim         = "c2pa.claim"
+	labelSignature     = "c2pa.signature"
+
+	hashAlg = "sha256"
+
+	// maxSizeIterations bounds the fixed-point search for the exclusion length.
+	maxSizeIterations = 4
+)
+
+type action struct {
+	Action            string            `cbor:"action"`
+	When              string            `cbor:"when,omitempty"`
+	SoftwareAgent     string            `cbor:"softwareAgent,omitempty"`
+	DigitalSourceType string            `cbor:"digitalSourceType,omitempty"`
+	Parameters        map[string]string `cbor:"parameters,omitempty"`
+}
+
+type actionsAssertion struct {
+	Actions []action `cbor:"actions"`
+}
+
+type exclusion struct {
+	Start  int `cbor:"start"`
+	Length int `cbor:"length"`
+}
+
+type dataHashAssertion struct {
+	Exclusions []exclusion `cbor:"exclusions"`
+	Name       string      `cbor:"name"`
+	Alg        string      `cbor:"alg"`
+	Hash       []byte      `cbor:"hash"`
+	Pad        []byte      `cbor:"pad"`
+}
+
+type hashedURI struct {
+	URL  string `cbor:"url"`
+	Alg  string `cbor:"alg"`
+	Hash []byte `cbor:"hash"`
+}
+
+type claim struct {
+	ClaimGenerator string      `cbor:"claim_generator"`
+	Signature      string      `cbor:"signature"`
+	Assertions     []hashedURI `cbor:"assertions"`
+	Format         string      `cbor:"dc:format"`
+	InstanceID     string      `cbor:"instanceID"`
+	Alg            string      `cbor:"alg"`
+}
+
+// C2PAEmbedder writes a signed C2PA manifest store into JPEG APP11 segments.
+type C2PAEmbedder struct {
+	signer         *Signer
+	claimGenerator string
+	enc            cbor.EncMode
+	now            func() time.Time
+}
+
+// Ensure C2PAEmbedder implements Embedder.
+var _ Embedder = (*C2PAEmbedder)(nil)
+
+// NewC2PAEmbedder creates an embedder that records claimGenerator as the producing tool.
+func NewC2PAEmbedder(signer *Signer, claimGenerator string) (*C2PAEmbedder, error) {
+	if signer == nil {
+		return nil, fmt.Errorf("signer is required")
+	}
+	enc, err := cbor.CoreDetEncOptions().EncMode()
+	if err != nil {
+		return nil, fmt.Errorf("cbor encoder: %w", err)
+	}
+	return &C2PAEmbedder{
+		signer:         signer,
+		claimGenerator: claimGenerator,
+		enc:            enc,
+		now:            time.Now,
+	}, nil
+}
+
+// Embed transcodes img to JPEG if needed and inserts the manifest store right
+// after the SOI marker. The data hash covers every byte except the inserted
+// segments, so it equals the hash of the JPEG before insertion.
+func (e *C2PAEmbedder) Embed(_ context.Context, img []byte, info Info) ([]byte, error) {
+	jpg, err := toJPEG(img)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256(jpg)
+
+	stagedAt := info.StagedAt
+	if stagedAt.IsZero() {
+		stagedAt = e.now()
+	}
+	actionsCBOR, err := e.enc.Marshal(actionsAssertion{Actions: []action{{
+		Action:            "c2pa.edited",
+		When:              stagedAt.UTC().Format(time.RFC3339),
+		SoftwareAgent:     e.claimGenerator,
+		DigitalSourceType: DigitalSourceType,
+		Parameters:        e.parameters(info),
+	}}})
+	if err != nil {
+		return nil, fmt.Errorf("encode actions: %w", err)
+	}
+
+	manifestLabel := "urn:uuid:" + uuid.NewString()
+	instanceID := "xmp:iid:" + uuid.NewString()
+
+	// The exclusion length is part of the manifest it describes; rebuild
+	// until the encoded size stops changing.
+	exclLen := 0
+	for range maxSizeIterations {
+		store, err := e.manifestStore(manifestLabel, instanceID, actionsCBOR, digest[:], exclLen)
+		if err != nil {
+			return nil, err
+		}
+		segments := app11Segments(store)
+		if len(segments) == exclLen {
+			return insertAfterSOI(jpg, segments)
+		}
+		exclLen = len(segments)
+	}
+	return nil, fmt.Errorf("manifest size did not converge")
+}
+
+func (e *C2PAEmbedder) parameters(info Info) map[string]string {
+	params := map[string]string{
+		"description": "Virtually staged: furniture and decor were added by an AI model",
+	}
+	if info.Model != "" {
+		params["model"] = info.Model
+	}
+	if info.RoomType != "" {
+		params["room_type"] = info.RoomType
+	}
+	if info.Style != "" {
+		params["style"] = info.Style
+	}
+	return params
+}
+
+func (e *C2PAEmbedder) manifestStore(
+	manifestLabel, instanceID string, actionsCBOR, digest []byte, exclLen int,
+) ([]byte, error) {
+	hashCBOR, err := e.enc.Marshal(dataHashAssertion{
+		Exclusions: []exclusion{{Start: 2, Length: exclLen}},
+		Name:       "jumbf manifest",
+		Alg:        hashAlg,
+		Hash:       digest,
+		Pad:        []byte{},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encode data hash: %w", err)
+	}
+
+	actionsBox := superbox(uuidCBORAssertion, labelActions, box(boxTypeCBOR, actionsCBOR))
+	hashBox := superbox(uuidCBORAssertion, labelDataHash, box(boxTypeCBOR, hashCBOR))
+
+	claimCBOR, err := e.enc.Marshal(claim{
+		ClaimGenerator: e.claimGenerator,
+		Signature:      "self#jumbf=" + labelSignature,
+		Assertions: []hashedURI{
+			e.hashedURI(labelActions, actionsBox),
+			e.hashedURI(labelDataHash, hashBox),
+		},
+		Format:     "image/jpeg",
+		InstanceID: instanceID,
+		Alg:        hashAlg,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encode claim: %w", err)
+	}
+
+	sigCBOR, err := e.sign(claimCBOR)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := superbox(uuidManifest, manifestLabel,
+		superbox(uuidAssertionStore, labelAssertions, actionsBox, hashBox),
+		superbox(uuidClaim, labelClaim, box(boxTypeCBOR, claimCBOR)),
+		superbox(uuidSignature, labelSignature, box(boxTypeCBOR, sigCBOR)),
+	)
+	return superbox(uuidManifestStore, labelManifestStore, manifest), nil
+}
+
+// hashedURI references an assertion by label, hashing its superbox contents
+// (everything after the 8 byte box header).
+func (e *C2PAEmbedder) hashedURI(label string, assertionBox []byte) hashedURI {
+	sum := sha256.Sum256(assertionBox[8:])
+	return hashedURI{
+		URL:  "self#jumbf=" + labelAssertions + "/" + label,
+		Alg:  hashAlg,
+		Hash: sum[:],
+	}
+}
+
+// sign produces a COSE_Sign1 with a detached payload over the claim bytes.
+// The signing certificate travels in the protected x5chain header.
+func (e *C2PAEmbedder) sign(claimCBOR []byte) ([]byte, error) {
+	protected, err := e.enc.Marshal(map[int]any{1: coseAlgES256, 33: e.signer.CertDER()})
+	if err != nil {
+		return nil, fmt.Errorf("encode protected header: %w", err)
+	}
+	toBeSigned, err := e.enc.Marshal([]any{"Signature1", protected, []byte{}, claimCBOR})
+	if err != nil {
+		return nil, fmt.Errorf("encode sig structure: %w", err)
+	}
+	sig, err := e.signer.Sign(toBeSigned)
+	if err != nil {
+		return nil, err
+	}
+	out, err := e.enc.Marshal(cbor.Tag{
+		Number:  18,
+		Content: []any{protected, map[int]any{}, nil, sig},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encode COSE_Sign1: %w", err)
+	}
+	return out, nil
+}
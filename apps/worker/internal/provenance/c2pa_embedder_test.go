@@ -0,0 +1,187 @@
+package provenance
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testImage(t *testing.T, encode func(*bytes.Buffer, image.Image) error) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 32, 24))
+	for x := range 32 {
+		for y := range 24 {
+			img.Set(x, y, color.RGBA{R: uint8(x * 8), G: uint8(y * 10), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, encode(&buf, img))
+	return buf.Bytes()
+}
+
+func pngImage(t *testing.T) []byte {
+	return testImage(t, func(b *bytes.Buffer, i image.Image) error { return png.Encode(b, i) })
+}
+
+func jpegImage(t *testing.T) []byte {
+	return testImage(t, func(b *bytes.Buffer, i image.Image) error { return jpeg.Encode(b, i, nil) })
+}
+
+// splitAPP11 returns the APP11 segments directly after SOI and the image with them removed.
+func splitAPP11(t *testing.T, img []byte) (segments, rest []byte) {
+	t.Helper()
+	require.True(t, len(img) > 4 && img[0] == 0xFF && img[1] == markerSOI)
+	pos := 2
+	for pos+4 <= len(img) && img[pos] == 0xFF && img[pos+1] == markerAPP11 {
+		pos += 2 + int(binary.BigEndian.Uint16(img[pos+2:]))
+	}
+	rest = append(append([]byte{}, img[:2]...), img[pos:]...)
+	return img[2:pos], rest
+}
+
+func TestC2PAEmbedder_Embed(t *testing.T) {
+	signer, err := NewEphemeralSigner("Real Staging AI (test)")
+	require.NoError(t, err)
+	e, err := NewC2PAEmbedder(signer, "Real Staging AI/test")
+	require.NoError(t, err)
+	e.now = func() time.Time { return time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC) }
+
+	testCases := []struct {
+		name    string
+		input   []byte
+		wantErr bool
+	}{
+		{name: "success: png is transcoded", input: pngImage(t)},
+		{name: "success: jpeg passes through", input: jpegImage(t)},
+		{name: "fail: not an image", input: []byte("plain text"), wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := e.Embed(context.Background(), tc.input, Info{Model: "black-forest-labs/flux-kontext-max"})
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			decoded, format, err := image.Decode(bytes.NewReader(out))
+			require.NoError(t, err)
+			assert.Equal(t, "jpeg", format)
+			assert.Equal(t, image.Rect(0, 0, 32, 24), decoded.Bounds())
+
+			segments, rest := splitAPP11(t, out)
+			require.NotEmpty(t, segments)
+			assert.Equal(t, []byte("JP"), segments[4:6])
+			assert.True(t, bytes.Contains(segments, []byte(labelActions)))
+			assert.True(t, bytes.Contains(segments, []byte("black-forest-labs/flux-kontext-max")))
+			assert.True(t, bytes.Contains(segments, []byte("2025-06-01T12:00:00Z")))
+
+			// The data hash must cover the image minus exactly the inserted segments.
+			var dh dataHashAssertion
+			require.NoError(t, cbor.Unmarshal(cborAfterLabel(t, segments, labelDataHash), &dh))
+			require.Len(t, dh.Exclusions, 1)
+			assert.Equal(t, 2, dh.Exclusions[0].Start)
+			assert.Equal(t, len(segments), dh.Exclusions[0].Length)
+			sum := sha256.Sum256(rest)
+			assert.Equal(t, sum[:], dh.Hash)
+
+			if bytes.Equal(tc.input[:2], []byte{0xFF, markerSOI}) {
+				assert.Equal(t, tc.input, rest)
+			}
+		})
+	}
+}
+
+// cborAfterLabel returns the payload of the cbor box that follows the description box labelled label.
+func cborAfterLabel(t *testing.T, data []byte, label string) []byte {
+	t.Helper()
+	idx := bytes.Index(data, append([]byte(label), 0))
+	require.GreaterOrEqual(t, idx, 0)
+	pos := idx + len(label) + 1
+	size := int(binary.BigEndian.Uint32(data[pos:]))
+	require.Equal(t, boxTypeCBOR, string(data[pos+4:pos+8]))
+	return data[pos+8 : pos+size]
+}
+
+func TestApp11Segments(t *testing.T) {
+	payload := bytes.Repeat([]byte{0xAB}, maxSegmentPayload*2+10)
+	jumbf := box(boxTypeSuperbox, payload)
+
+	out := app11Segments(jumbf)
+
+	var seqs []uint32
+	var reassembled []byte
+	for pos := 0; pos < len(out); {
+		require.Equal(t, []byte{0xFF, markerAPP11}, out[pos:pos+2])
+		lp := int(binary.BigEndian.Uint16(out[pos+2:]))
+		seqs = append(seqs, binary.BigEndian.Uint32(out[pos+8:]))
+		assert.Equal(t, jumbf[:8], out[pos+12:pos+20])
+		reassembled = append(reassembled, out[pos+20:pos+2+lp]...)
+		pos += 2 + lp
+	}
+	assert.Equal(t, []uint32{1, 2, 3}, seqs)
+	assert.Equal(t, payload, reassembled)
+}
+
+func TestLoadSigner(t *testing.T) {
+	dir := t.TempDir()
+	ephemeral, err := NewEphemeralSigner("loader")
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(ephemeral.key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ephemeral.CertDER()}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherDER, err := x509.MarshalECPrivateKey(otherKey)
+	require.NoError(t, err)
+	otherFile := filepath.Join(dir, "other.pem")
+	require.NoError(t, os.WriteFile(otherFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: otherDER}), 0o600))
+
+	testCases := []struct {
+		name     string
+		certFile string
+		keyFile  string
+		wantErr  bool
+	}{
+		{name: "success: matching pair", certFile: certFile, keyFile: keyFile},
+		{name: "fail: key does not match certificate", certFile: certFile, keyFile: otherFile, wantErr: true},
+		{name: "fail: missing certificate", certFile: filepath.Join(dir, "missing.pem"), keyFile: keyFile, wantErr: true},
+		{name: "fail: certificate is not PEM", certFile: keyFile + "x", keyFile: keyFile, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := LoadSigner(tc.certFile, tc.keyFile)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, ephemeral.CertDER(), s.CertDER())
+		})
+	}
+}
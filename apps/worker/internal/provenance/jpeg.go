@@ -0,0 +1,79 @@
+package provenance
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	_ "image/png" // register decoder for model outputs
+	"net/http"
+
+	_ "golang.org/x/image/webp" // register decoder for model outputs
+)
+
+const (
+	jpegQuality = 92
+
+	markerSOI   = 0xD8
+	markerAPP11 = 0xEB
+
+	// app11Overhead is Lp, CI, En and Z in every APP11 JUMBF segment.
+	app11Overhead = 2 + 2 + 2 + 4
+	// maxSegmentPayload is the box data (after the repeated 8 byte box
+	// header) that fits in one APP11 segment.
+	maxSegmentPayload = 0xFFFF - app11Overhead - 8
+)
+
+// toJPEG returns img unchanged when it is already a JPEG and re-encodes it otherwise.
+func toJPEG(img []byte) ([]byte, error) {
+	if http.DetectContentType(img) == "image/jpeg" {
+		return img, nil
+	}
+	decoded, _, err := image.Decode(bytes.NewReader(img))
+	if err != nil {
+		return nil, fmt.Errorf("decode image: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, decoded, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, fmt.Errorf("encode jpeg: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// app11Segments splits a JUMBF box into JPEG APP11 marker segments. Each
+// segment repeats the box header, as required by ISO/IEC 19566-5 Annex B.
+func app11Segments(jumbf []byte) []byte {
+	header, payload := jumbf[:8], jumbf[8:]
+
+	var out []byte
+	for seq := uint32(1); ; seq++ {
+		n := min(len(payload), maxSegmentPayload)
+		seg := make([]byte, 2+app11Overhead, 2+app11Overhead+8+n)
+		seg[0], seg[1] = 0xFF, markerAPP11
+		binary.BigEndian.PutUint16(seg[2:], uint16(app11Overhead+8+n))
+		copy(seg[4:], "JP")
+		binary.BigEndian.PutUint16(seg[6:], 1)
+		binary.BigEndian.PutUint32(seg[8:], seq)
+		seg = append(seg, header...)
+		seg = append(seg, payload[:n]...)
+		out = append(out, seg...)
+
+		payload = payload[n:]
+		if len(payload) == 0 {
+			return out
+		}
+	}
+}
+
+// insertAfterSOI returns a copy of img with segments placed directly after the SOI marker.
+func insertAfterSOI(img, segments []byte) ([]byte, error) {
+	if len(img) < 2 || img[0] != 0xFF || img[1] != markerSOI {
+		return nil, errors.New("not a jpeg")
+	}
+	out := make([]byte, 0, len(img)+len(segments))
+	out = append(out, img[:2]...)
+	out = append(out, segments...)
+	return append(out, img[2:]...), nil
+}
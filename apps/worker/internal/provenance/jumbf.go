@@ -0,0 +1,54 @@
+package provenance
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+)
+
+// JUMBF (ISO/IEC 19566-5) box types and the C2PA content type UUIDs.
+const (
+	boxTypeSuperbox    = "jumb"
+	boxTypeDescription = "jumd"
+	boxTypeCBOR        = "cbor"
+
+	// toggleRequestableLabel marks a description box as requestable with a label.
+	toggleRequestableLabel = 0x03
+)
+
+var (
+	uuidManifestStore  = c2paUUID("c2pa")
+	uuidManifest       = c2paUUID("c2ma")
+	uuidAssertionStore = c2paUUID("c2as")
+	uuidClaim          = c2paUUID("c2cl")
+	uuidSignature      = c2paUUID("c2cs")
+	uuidCBORAssertion  = c2paUUID("cbor")
+)
+
+// c2paUUID builds a JUMBF content type UUID from its four character code.
+func c2paUUID(code string) []byte {
+	suffix, _ := hex.DecodeString("00110010800000AA00389B71")
+	return append([]byte(code), suffix...)
+}
+
+// box encodes a single ISO BMFF style box.
+func box(boxType string, payload []byte) []byte {
+	out := make([]byte, 8, 8+len(payload))
+	binary.BigEndian.PutUint32(out, uint32(8+len(payload)))
+	copy(out[4:], boxType)
+	return append(out, payload...)
+}
+
+// superbox encodes a labelled JUMBF superbox wrapping children.
+func superbox(contentType []byte, label string, children ...[]byte) []byte {
+	desc := make([]byte, 0, len(contentType)+len(label)+2)
+	desc = append(desc, contentType...)
+	desc = append(desc, toggleRequestableLabel)
+	desc = append(desc, label...)
+	desc = append(desc, 0)
+
+	payload := box(boxTypeDescription, desc)
+	for _, c := range children {
+		payload = append(payload, c...)
+	}
+	return box(boxTypeSuperbox, payload)
+}
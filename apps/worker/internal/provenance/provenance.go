@@ -0,0 +1,33 @@
+// Package provenance embeds C2PA Content Credentials into staged images so
+// that downstream consumers (MLS boards, listing portals) can tell a photo
+// was virtually staged, by which tool and when.
+package provenance
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out embedder_mock.go . Embedder
+
+// DigitalSourceType is the IPTC source type recorded for staged outputs: a
+// real photograph composited with content from a generative model.
+const DigitalSourceType = "http://cv.iptc.org/newscodes/digitalsourcetype/compositeWithTrainedAlgorithmicMedia"
+
+// Info describes the edit recorded in the manifest.
+type Info struct {
+	// Model is the AI model that produced the staged image.
+	Model string
+	// RoomType and Style are the staging parameters, if any.
+	RoomType string
+	Style    string
+	// StagedAt is when the edit happened.
+	StagedAt time.Time
+}
+
+// Embedder attaches Content Credentials to an image.
+type Embedder interface {
+	// Embed returns img as a JPEG carrying a signed C2PA manifest. Non-JPEG
+	// inputs are transcoded first.
+	Embed(ctx context.Context, img []byte, info Info) ([]byte, error)
+}
@@ -0,0 +1,126 @@
+package provenance
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+)
+
+// coseAlgES256 is the COSE algorithm identifier for ECDSA P-256 with SHA-256.
+const coseAlgES256 = -7
+
+// Signer signs C2PA claims with an ECDSA P-256 key (ES256).
+type Signer struct {
+	key  *ecdsa.PrivateKey
+	cert *x509.Certificate
+}
+
+// NewSigner creates a Signer from a certificate and its private key.
+func NewSigner(cert *x509.Certificate, key *ecdsa.PrivateKey) (*Signer, error) {
+	pub, ok := cert.PublicKey.(*ecdsa.PublicKey)
+	if !ok || !pub.Equal(&key.PublicKey) {
+		return nil, errors.New("certificate does not match signing key")
+	}
+	if key.Curve != elliptic.P256() {
+		return nil, errors.New("signing key must use P-256")
+	}
+	return &Signer{key: key, cert: cert}, nil
+}
+
+// LoadSigner reads a PEM certificate and a PEM (PKCS#8 or SEC 1) EC private key.
+func LoadSigner(certFile, keyFile string) (*Signer, error) {
+	certPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		return nil, fmt.Errorf("read certificate: %w", err)
+	}
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data in %s", certFile)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse certificate: %w", err)
+	}
+
+	keyPEM, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("read key: %w", err)
+	}
+	block, _ = pem.Decode(keyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data in %s", keyFile)
+	}
+	key, err := parseECKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return NewSigner(cert, key)
+}
+
+// NewEphemeralSigner generates a throwaway self-signed certificate. Manifests
+// signed with it verify, but the signer is not trusted by anyone else.
+func NewEphemeralSigner(commonName string) (*Signer, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate key: %w", err)
+	}
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(now.UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.AddDate(1, 0, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("create certificate: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("parse certificate: %w", err)
+	}
+	return NewSigner(cert, key)
+}
+
+// CertDER returns the DER encoded signing certificate.
+func (s *Signer) CertDER() []byte {
+	return s.cert.Raw
+}
+
+// Sign returns the fixed-width r||s ES256 signature of data.
+func (s *Signer) Sign(data []byte) ([]byte, error) {
+	digest := sha256.Sum256(data)
+	r, ss, err := ecdsa.Sign(rand.Reader, s.key, digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("sign: %w", err)
+	}
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	ss.FillBytes(sig[32:])
+	return sig, nil
+}
+
+func parseECKey(der []byte) (*ecdsa.PrivateKey, error) {
+	if key, err := x509.ParseECPrivateKey(der); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("parse key: %w", err)
+	}
+	key, ok := parsed.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("signing key must be an EC private key")
+	}
+	return key, nil
+}
@@ -0,0 +1,95 @@
+package queue
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/real-staging-ai/worker/internal/dbretry"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out deadletter_mock.go . DeadLetterStore
+
+// DeadLetterStore keeps the failed attempts of queue tasks, so a task that runs out of
+// retries can be found with its payload and error history and requeued.
+type DeadLetterStore interface {
+	// RecordFailure adds a failed attempt to its task's history. A final attempt marks the
+	// task dead.
+	RecordFailure(ctx context.Context, f Failure) error
+}
+
+// Failure is one failed attempt of a queue task.
+type Failure struct {
+	TaskID   string
+	TaskType string
+	Queue    string
+	// Payload is the task payload as enqueued, still sealed if it was.
+	Payload []byte
+	// ImageID is the task's image, or the first image of a batch; empty when unknown.
+	ImageID  string
+	Attempt  int
+	MaxRetry int
+	Error    string
+	FailedAt time.Time
+	// Final is set when the task has no retries left.
+	Final bool
+}
+
+// failureEntry is one element of a dead letter's error history.
+type failureEntry struct {
+	Attempt  int       `json:"attempt"`
+	Error    string    `json:"error"`
+	FailedAt time.Time `json:"failed_at"`
+}
+
+// SQLDeadLetterStore is a Postgres-backed DeadLetterStore.
+type SQLDeadLetterStore struct {
+	db *sql.DB
+}
+
+// Ensure SQLDeadLetterStore implements DeadLetterStore.
+var _ DeadLetterStore = (*SQLDeadLetterStore)(nil)
+
+// NewSQLDeadLetterStore creates a SQLDeadLetterStore.
+func NewSQLDeadLetterStore(db *sql.DB) *SQLDeadLetterStore {
+	return &SQLDeadLetterStore{db: db}
+}
+
+// recordFailureQuery adds a task's row on its first failure and appends later failures
+// to it. dead_at follows the latest attempt, so a requeued task that fails again without
+// using up its retries is not listed as dead until it does.
+const recordFailureQuery = `
+	INSERT INTO dead_letter_jobs (
+		task_id, task_type, queue, payload, image_id, max_retry, errors,
+		first_failed_at, last_failed_at, dead_at
+	)
+	VALUES ($1, $2, $3, $4, NULLIF($5, '')::uuid, $6, jsonb_build_array($7::jsonb), $8, $8,
+		CASE WHEN $9::boolean THEN $8::timestamptz END)
+	ON CONFLICT (task_id) DO UPDATE SET
+		task_type = EXCLUDED.task_type,
+		queue = EXCLUDED.queue,
+		payload = EXCLUDED.payload,
+		image_id = EXCLUDED.image_id,
+		max_retry = EXCLUDED.max_retry,
+		errors = dead_letter_jobs.errors || EXCLUDED.errors,
+		last_failed_at = EXCLUDED.last_failed_at,
+		dead_at = EXCLUDED.dead_at`
+
+// RecordFailure upserts the task's row with the attempt appended to its errors.
+func (s *SQLDeadLetterStore) RecordFailure(ctx context.Context, f Failure) error {
+	entry, err := json.Marshal(failureEntry{Attempt: f.Attempt, Error: f.Error, FailedAt: f.FailedAt})
+	if err != nil {
+		return fmt.Errorf("marshal failure: %w", err)
+	}
+	err = dbretry.Do(ctx, "record task failure", func(ctx context.Context) error {
+		_, err := s.db.ExecContext(ctx, recordFailureQuery,
+			f.TaskID, f.TaskType, f.Queue, f.Payload, f.ImageID, f.MaxRetry, string(entry), f.FailedAt, f.Final)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("record task failure: %w", err)
+	}
+	return nil
+}
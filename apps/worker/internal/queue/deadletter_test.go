@@ -0,0 +1,72 @@
+package queue
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLDeadLetterStore_RecordFailure(t *testing.T) {
+	failedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	failure := Failure{
+		TaskID:   "job-1",
+		TaskType: "stage:run",
+		Queue:    "default",
+		Payload:  []byte(`{"image_id":"img-1"}`),
+		ImageID:  "img-1",
+		Attempt:  4,
+		MaxRetry: 3,
+		Error:    "provider timeout",
+		FailedAt: failedAt,
+		Final:    true,
+	}
+	query := regexp.QuoteMeta(recordFailureQuery)
+	args := []driver.Value{
+		"job-1", "stage:run", "default", failure.Payload, "img-1", 3,
+		`{"attempt":4,"error":"provider timeout","failed_at":"2025-03-01T12:00:00Z"}`, failedAt, true,
+	}
+
+	testCases := []struct {
+		name      string
+		setup     func(mock sqlmock.Sqlmock)
+		errSubstr string
+	}{
+		{
+			name: "success: failure recorded",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs(args...).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "fail: exec error",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs(args...).WillReturnError(errors.New("boom"))
+			},
+			errSubstr: "record task failure: boom",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+			tc.setup(mock)
+
+			err = NewSQLDeadLetterStore(db).RecordFailure(context.Background(), failure)
+			if tc.errSubstr != "" {
+				assert.ErrorContains(t, err, tc.errSubstr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
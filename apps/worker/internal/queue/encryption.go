@@ -0,0 +1,104 @@
+package queue
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/real-staging-ai/worker/internal/config"
+)
+
+// PayloadCipher names the algorithm the API seals payloads with.
+const PayloadCipher = "aes-256-gcm"
+
+// SealedPayload mirrors the API's envelope for an encrypted task payload.
+type SealedPayload struct {
+	Enc        string `json:"enc"`
+	KeyID      string `json:"kid"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Keyring opens payloads the API sealed with any of its keys. A nil Keyring only accepts
+// plaintext payloads.
+type Keyring struct {
+	aeads map[string]cipher.AEAD
+}
+
+// NewKeyring builds a keyring from the payload encryption config. It returns nil when no
+// keys are configured.
+func NewKeyring(cfg config.PayloadEncryption) (*Keyring, error) {
+	if len(cfg.Keys) == 0 {
+		return nil, nil
+	}
+	k := &Keyring{aeads: make(map[string]cipher.AEAD, len(cfg.Keys))}
+	for id, encoded := range cfg.Keys {
+		raw, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("decode payload encryption key %q: %w", id, err)
+		}
+		if len(raw) != 32 {
+			return nil, fmt.Errorf("payload encryption key %q must be 32 bytes, got %d", id, len(raw))
+		}
+		block, err := aes.NewCipher(raw)
+		if err != nil {
+			return nil, fmt.Errorf("payload encryption key %q: %w", id, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("payload encryption key %q: %w", id, err)
+		}
+		k.aeads[id] = aead
+	}
+	return k, nil
+}
+
+// Open decrypts a sealed payload. Plaintext payloads, such as those enqueued before
+// encryption was enabled, are returned as is.
+func (k *Keyring) Open(payload []byte) ([]byte, error) {
+	var sealed SealedPayload
+	if err := json.Unmarshal(payload, &sealed); err != nil || sealed.Enc == "" {
+		return payload, nil
+	}
+	if sealed.Enc != PayloadCipher {
+		return nil, fmt.Errorf("unsupported payload cipher %q", sealed.Enc)
+	}
+	if k == nil {
+		return nil, errors.New("payload is encrypted but no payload encryption keys are configured")
+	}
+	aead, ok := k.aeads[sealed.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("payload encryption key %q is not configured", sealed.KeyID)
+	}
+	if len(sealed.Nonce) != aead.NonceSize() {
+		return nil, errors.New("invalid payload nonce")
+	}
+	plaintext, err := aead.Open(nil, sealed.Nonce, sealed.Ciphertext, []byte(sealed.KeyID))
+	if err != nil {
+		return nil, fmt.Errorf("decrypt payload: %w", err)
+	}
+	return plaintext, nil
+}
+
+// openTaskPayload decrypts a task's payload for processing. Batch tasks keep each item
+// sealed in Redis, so their items are opened one by one.
+func openTaskPayload(k *Keyring, taskType string, payload []byte) ([]byte, error) {
+	if taskType != TaskTypeStageBatch {
+		return k.Open(payload)
+	}
+	var batch BatchPayload
+	if err := json.Unmarshal(payload, &batch); err != nil {
+		return nil, fmt.Errorf("decode batch payload: %w", err)
+	}
+	for i, item := range batch.Items {
+		opened, err := k.Open(item)
+		if err != nil {
+			return nil, fmt.Errorf("batch item %d: %w", i, err)
+		}
+		batch.Items[i] = opened
+	}
+	return json.Marshal(batch)
+}
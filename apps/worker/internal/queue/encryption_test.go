@@ -0,0 +1,119 @@
+package queue
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/worker/internal/config"
+)
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, 32))
+}
+
+// seal encrypts payload the way the API's enqueuer does.
+func seal(t *testing.T, keyID string, keyByte byte, payload []byte) []byte {
+	t.Helper()
+	block, err := aes.NewCipher(bytes.Repeat([]byte{keyByte}, 32))
+	require.NoError(t, err)
+	aead, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	nonce := bytes.Repeat([]byte{7}, aead.NonceSize())
+	sealed, err := json.Marshal(SealedPayload{
+		Enc:        PayloadCipher,
+		KeyID:      keyID,
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, payload, []byte(keyID)),
+	})
+	require.NoError(t, err)
+	return sealed
+}
+
+func TestNewKeyring(t *testing.T) {
+	k, err := NewKeyring(config.PayloadEncryption{})
+	require.NoError(t, err)
+	assert.Nil(t, k)
+
+	_, err = NewKeyring(config.PayloadEncryption{Keys: map[string]string{"k1": "not base64!"}})
+	assert.ErrorContains(t, err, `decode payload encryption key "k1"`)
+
+	_, err = NewKeyring(config.PayloadEncryption{
+		Keys: map[string]string{"k1": base64.StdEncoding.EncodeToString([]byte("short"))},
+	})
+	assert.ErrorContains(t, err, `payload encryption key "k1" must be 32 bytes, got 5`)
+}
+
+func TestKeyring_Open(t *testing.T) {
+	plaintext := []byte(`{"image_id":"img-1","original_url":"s3://bucket/uploads/user-1/img.jpg"}`)
+	keys, err := NewKeyring(config.PayloadEncryption{Keys: map[string]string{"k1": testKey(1), "k2": testKey(2)}})
+	require.NoError(t, err)
+
+	t.Run("success: opens payloads sealed with current and retired keys", func(t *testing.T) {
+		for kid, b := range map[string]byte{"k1": 1, "k2": 2} {
+			opened, err := keys.Open(seal(t, kid, b, plaintext))
+			require.NoError(t, err)
+			assert.Equal(t, plaintext, opened)
+		}
+	})
+
+	t.Run("success: plaintext payloads open as is", func(t *testing.T) {
+		var nilKeys *Keyring
+		opened, err := nilKeys.Open(plaintext)
+		require.NoError(t, err)
+		assert.Equal(t, plaintext, opened)
+	})
+
+	t.Run("fail: sealed payload without keys", func(t *testing.T) {
+		var nilKeys *Keyring
+		_, err := nilKeys.Open(seal(t, "k1", 1, plaintext))
+		assert.ErrorContains(t, err, "no payload encryption keys are configured")
+	})
+
+	t.Run("fail: unknown key id", func(t *testing.T) {
+		_, err := keys.Open(seal(t, "k3", 3, plaintext))
+		assert.ErrorContains(t, err, `payload encryption key "k3" is not configured`)
+	})
+
+	t.Run("fail: wrong key material", func(t *testing.T) {
+		_, err := keys.Open(seal(t, "k1", 2, plaintext))
+		assert.ErrorContains(t, err, "decrypt payload")
+	})
+}
+
+func TestOpenTaskPayload(t *testing.T) {
+	keys, err := NewKeyring(config.PayloadEncryption{Keys: map[string]string{"k1": testKey(1)}})
+	require.NoError(t, err)
+
+	t.Run("success: stage:run", func(t *testing.T) {
+		opened, err := openTaskPayload(keys, "stage:run", seal(t, "k1", 1, []byte(`{"image_id":"img-1"}`)))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"image_id":"img-1"}`, string(opened))
+	})
+
+	t.Run("success: stage:batch opens each item", func(t *testing.T) {
+		batch, err := json.Marshal(BatchPayload{Items: []json.RawMessage{
+			seal(t, "k1", 1, []byte(`{"image_id":"img-1"}`)),
+			json.RawMessage(`{"image_id":"img-2"}`),
+		}})
+		require.NoError(t, err)
+
+		opened, err := openTaskPayload(keys, TaskTypeStageBatch, batch)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"items":[{"image_id":"img-1"},{"image_id":"img-2"}]}`, string(opened))
+	})
+
+	t.Run("fail: stage:batch item sealed with an unknown key", func(t *testing.T) {
+		batch, err := json.Marshal(BatchPayload{Items: []json.RawMessage{seal(t, "k9", 9, []byte(`{}`))}})
+		require.NoError(t, err)
+
+		_, err = openTaskPayload(keys, TaskTypeStageBatch, batch)
+		assert.ErrorContains(t, err, "batch item 0")
+	})
+}
@@ -5,23 +5,46 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"os"
-	"strconv"
 	"sync"
 	"time"
 
 	"github.com/hibiken/asynq"
 
 	"github.com/real-staging-ai/worker/internal/config"
+	"github.com/real-staging-ai/worker/internal/fairshare"
 	"github.com/real-staging-ai/worker/internal/logging"
 )
 
+// TaskTypeStageBatch is the task type grouped stage:run tasks are aggregated into once
+// their batching window closes.
+const TaskTypeStageBatch = "stage:batch"
+
+// TaskTypeEditErase is the task type of a quick edit that erases an object from an image.
+// The API puts these on the edit queue, which is drained before every other queue.
+const TaskTypeEditErase = "edit:erase"
+
+// TaskTypeRoomsGroup is the task type of a room grouping analysis over a project's uploads.
+const TaskTypeRoomsGroup = "rooms:group"
+
+// TaskTypeProjectExport is the task type of a zip of a project's staged photos.
+const TaskTypeProjectExport = "projects:export"
+
+// BatchPayload is the payload of a stage:batch task.
+type BatchPayload struct {
+	// Items are the stage:run payloads of the batched images, oldest first.
+	Items []json.RawMessage `json:"items"`
+}
+
 // Job represents a processing job.
 type Job struct {
 	ID      string          `json:"id"`
 	Type    string          `json:"type"`
 	Payload json.RawMessage `json:"payload"`
 	Status  string          `json:"status"`
+	// TaskID is the queue task's ID, which the API sets to the job row's ID.
+	TaskID string `json:"task_id,omitempty"`
+	// Attempt counts runs of the task, starting at 1.
+	Attempt int `json:"attempt,omitempty"`
 }
 
 // QueueClient defines the interface for job queue operations.
@@ -29,6 +52,21 @@ type QueueClient interface {
 	GetNextJob(ctx context.Context) (*Job, error)
 	MarkJobCompleted(ctx context.Context, jobID string) error
 	MarkJobFailed(ctx context.Context, jobID string, errorMsg string) error
+	// DeferJob returns a job to the queue to run again at until, without counting the
+	// attempt against its retries. reason is recorded as the task's last error.
+	DeferJob(ctx context.Context, jobID string, until time.Time, reason string) error
+}
+
+// DeferredError is returned when a job should wait in the queue rather than run now.
+type DeferredError struct {
+	// Until is when the job may run again.
+	Until time.Time
+	// Reason says why the job was deferred.
+	Reason string
+}
+
+func (e *DeferredError) Error() string {
+	return fmt.Sprintf("job deferred until %s: %s", e.Until.Format(time.RFC3339), e.Reason)
 }
 
 // MockQueueClient is a mock implementation for development/testing.
@@ -61,6 +99,11 @@ func (m *MockQueueClient) MarkJobFailed(ctx context.Context, jobID string, error
 	return nil
 }
 
+// DeferJob is a no-op; the mock queue holds no jobs.
+func (m *MockQueueClient) DeferJob(ctx context.Context, jobID string, until time.Time, reason string) error {
+	return nil
+}
+
 // AsynqQueueClient is a production-ready queue client backed by Redis + asynq.
 // It adapts asynq's push-based handler model into our pull-based QueueClient API
 // by bridging tasks through an internal channel and result signaling.
@@ -69,67 +112,163 @@ type AsynqQueueClient struct {
 	jobs    chan *Job
 	mu      sync.Mutex
 	results map[string]chan error
+
+	// fair replaces jobs when fair share is enabled, handing jobs out round-robin across
+	// the users owners resolves.
+	fair          *fairshare.Scheduler[*Job]
+	owners        fairshare.OwnerResolver
+	deferDelay    time.Duration
+	criticalQueue string
+	editQueue     string
+
+	// deadLetters records failed attempts, when set.
+	deadLetters DeadLetterStore
+}
+
+// Option configures an AsynqQueueClient.
+type Option func(*AsynqQueueClient)
+
+// WithOwners resolves the user each task belongs to for fair share. Without it every task
+// counts as the same user, which leaves the queue first in, first out.
+func WithOwners(owners fairshare.OwnerResolver) Option {
+	return func(c *AsynqQueueClient) { c.owners = owners }
+}
+
+// WithDeadLetters records every failed attempt in store, marking tasks dead once their
+// retries are used up.
+func WithDeadLetters(store DeadLetterStore) Option {
+	return func(c *AsynqQueueClient) { c.deadLetters = store }
 }
 
 // NewAsynqQueueClient initializes an Asynq-backed queue client.
-// Required env: REDIS_ADDR
-// Optional env: JOB_QUEUE_NAME (default: "default"), WORKER_CONCURRENCY (default: 5)
-func NewAsynqQueueClient(cfg *config.Config) (*AsynqQueueClient, error) {
-	// check if REDIS_ADDR is set or cfg.Redis.Addr
-	addr := os.Getenv("REDIS_ADDR")
-	if addr == "" {
-		addr = cfg.Redis.Addr
+// Requires cfg.Redis.Addr; reads the queue names and concurrency from cfg.Job, the keys
+// sealed payloads are opened with from cfg.PayloadEncryption and the user scheduling from
+// cfg.FairShare.
+func NewAsynqQueueClient(cfg *config.Config, opts ...Option) (*AsynqQueueClient, error) {
+	if cfg.Redis.Addr == "" {
+		return nil, errors.New("redis address is not configured. Set REDIS_ADDR or redis.addr")
 	}
-	if addr == "" {
-		return nil, errors.New("unable to determine redis address. REDIS_ADDR env var is not set and cfg.Redis.Addr is empty")
+	addr := cfg.Redis.Addr
+	queueName := cfg.Job.QueueName
+	criticalQueueName := cfg.Job.CriticalQueueName
+	editQueueName := cfg.Job.EditQueueName
+
+	concurrency := cfg.Job.WorkerConcurrency
+	if concurrency <= 0 {
+		concurrency = 5
 	}
 
-	queueName := os.Getenv("JOB_QUEUE_NAME")
-	if queueName == "" {
-		queueName = cfg.Job.QueueName
+	keys, err := NewKeyring(cfg.PayloadEncryption)
+	if err != nil {
+		return nil, err
 	}
 
-	concurrency := cfg.Job.WorkerConcurrency
-	if v := os.Getenv("WORKER_CONCURRENCY"); v != "" {
-		if n, err := strconv.Atoi(v); err == nil && n > 0 {
-			concurrency = n
+	c := &AsynqQueueClient{
+		jobs:          make(chan *Job, concurrency*2),
+		results:       make(map[string]chan error),
+		criticalQueue: criticalQueueName,
+		editQueue:     editQueueName,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	// With fair share the worker takes tasks beyond its concurrency from the queue, so it
+	// has other users' tasks to choose from while one user's batch is running.
+	serverConcurrency := concurrency
+	if fair := cfg.FairShare; fair.Enabled {
+		lookahead := fair.Lookahead
+		if lookahead <= 0 {
+			lookahead = concurrency
+		}
+		maxPerUser := fair.MaxPerUser
+		if maxPerUser <= 0 {
+			maxPerUser = concurrency
 		}
+		c.fair = fairshare.NewScheduler[*Job](2, maxPerUser, fair.Weights)
+		c.deferDelay = max(fair.DeferDelay, time.Second)
+		serverConcurrency += lookahead
 	}
 
 	logger := logging.Default()
+	batchWindow := max(cfg.Job.BatchWindow, time.Second)
 
 	srv := asynq.NewServer(
 		asynq.RedisClientOpt{Addr: addr},
 		asynq.Config{
-			Concurrency: concurrency,
-			Queues:      map[string]int{queueName: 1},
+			Concurrency: serverConcurrency,
+			Queues:      queuePriorities(queueName, criticalQueueName, editQueueName),
+			// Quick edits always run first, then expedited images in the critical queue,
+			// then the default queue.
+			StrictPriority: true,
 			ErrorHandler: asynq.ErrorHandlerFunc(func(ctx context.Context, t *asynq.Task, err error) {
+				var deferred *DeferredError
+				if errors.As(err, &deferred) {
+					logger.Info(ctx, "asynq task deferred", "type", t.Type(), "until", deferred.Until)
+					return
+				}
 				logger.Error(ctx, "asynq handler error", "type", t.Type(), "error", err)
 			}),
+			RetryDelayFunc: retryDelay,
+			IsFailure:      isFailure,
+			// Tasks the API grouped for batching run as one stage:batch task once the
+			// window closes or the group is full.
+			GroupAggregator:  asynq.GroupAggregatorFunc(aggregateBatch),
+			GroupGracePeriod: batchWindow,
+			GroupMaxDelay:    batchWindow,
+			GroupMaxSize:     cfg.Job.BatchMaxSize,
 		},
 	)
 
-	c := &AsynqQueueClient{
-		srv:     srv,
-		jobs:    make(chan *Job, concurrency*2),
-		results: make(map[string]chan error),
-	}
+	c.srv = srv
 
 	mux := asynq.NewServeMux()
-	// Register exact task type used by the API enqueuer.
-	// Wildcards are not supported by asynq mux.
-	logger.Info(context.Background(), "Registering asynq handler", "task_type", "stage:run")
+	// Register the exact task types: stage:run, edit:erase, rooms:group and projects:export from the API
+	// enqueuers and stage:batch from the batch aggregator. Wildcards are not supported by asynq mux.
+	logger.Info(context.Background(), "Registering asynq handlers",
+		"task_types", []string{"stage:run", TaskTypeStageBatch, TaskTypeEditErase, TaskTypeRoomsGroup,
+			TaskTypeProjectExport})
 
-	mux.HandleFunc("stage:run", func(ctx context.Context, t *asynq.Task) error {
+	handle := func(ctx context.Context, t *asynq.Task) error {
 		logger.Info(ctx, "=== ASYNQ HANDLER CALLED ===", "task_type", t.Type())
 
+		// Sealed payloads stay encrypted in Redis and are only opened here. Retrying cannot
+		// fix a payload that fails to open, so it is archived for an operator to re-run.
+		payload, err := openTaskPayload(keys, t.Type(), t.Payload())
+		if err != nil {
+			logger.Error(ctx, "open task payload failed", "task_type", t.Type(), "error", err)
+			err = fmt.Errorf("open payload: %w: %w", err, asynq.SkipRetry)
+			c.recordFailure(ctx, t, "", err)
+			return err
+		}
+
+		// A user holding their share of the worker waits at the back of the queue, so the
+		// tasks of other users behind them are taken first.
+		var owner string
+		if c.fair != nil {
+			owner = c.ownerOf(ctx, t.Type(), payload)
+			if !c.fair.Admit(owner) {
+				if !lastAttempt(ctx) {
+					return &DeferredError{Until: time.Now().Add(c.deferDelay), Reason: "fair share: user holds their share of the worker"}
+				}
+				// A deferral on the last attempt would archive the task, so it waits here
+				c.fair.Hold(owner)
+			}
+			defer c.fair.Release(owner)
+		}
+
 		// Create a local job id to correlate completion/failure.
 		jobID := fmt.Sprintf("%d", time.Now().UnixNano())
 		jb := &Job{
 			ID:      jobID,
 			Type:    t.Type(),
-			Payload: t.Payload(),
+			Payload: payload,
 			Status:  "queued",
+			Attempt: 1,
+		}
+		jb.TaskID, _ = asynq.GetTaskID(ctx)
+		if retried, ok := asynq.GetRetryCount(ctx); ok {
+			jb.Attempt = retried + 1
 		}
 		resCh := make(chan error, 1)
 		c.mu.Lock()
@@ -137,15 +276,22 @@ func NewAsynqQueueClient(cfg *config.Config) (*AsynqQueueClient, error) {
 		c.mu.Unlock()
 
 		// Deliver job to consumer
-		logger.Info(ctx, "asynq task received, delivering to job channel",
-			"task_type", t.Type(), "job_id", jobID, "channel_len", len(c.jobs))
-		select {
-		case c.jobs <- jb:
-		case <-ctx.Done():
-			c.mu.Lock()
-			delete(c.results, jobID)
-			c.mu.Unlock()
-			return ctx.Err()
+		tier := c.tier(ctx)
+		if c.fair != nil {
+			logger.Info(ctx, "asynq task received, delivering to fair share scheduler",
+				"task_type", t.Type(), "job_id", jobID, "owner", owner, "waiting", c.fair.Waiting())
+			c.fair.Push(tier, owner, jb)
+		} else {
+			logger.Info(ctx, "asynq task received, delivering to job channel",
+				"task_type", t.Type(), "job_id", jobID, "channel_len", len(c.jobs))
+			select {
+			case c.jobs <- jb:
+			case <-ctx.Done():
+				c.mu.Lock()
+				delete(c.results, jobID)
+				c.mu.Unlock()
+				return ctx.Err()
+			}
 		}
 
 		// Wait for processing result from the worker.
@@ -153,6 +299,7 @@ func NewAsynqQueueClient(cfg *config.Config) (*AsynqQueueClient, error) {
 		case err := <-resCh:
 			if err != nil {
 				logger.Warn(ctx, "worker marked task failed", "task_type", t.Type(), "job_id", jobID, "error", err)
+				c.recordFailure(ctx, t, firstImageID(t.Type(), payload), err)
 			} else {
 				logger.Info(ctx, "worker marked task completed", "task_type", t.Type(), "job_id", jobID)
 			}
@@ -161,9 +308,17 @@ func NewAsynqQueueClient(cfg *config.Config) (*AsynqQueueClient, error) {
 			c.mu.Lock()
 			delete(c.results, jobID)
 			c.mu.Unlock()
+			if c.fair != nil {
+				c.fair.Remove(tier, owner, jb)
+			}
 			return ctx.Err()
 		}
-	})
+	}
+	mux.HandleFunc("stage:run", handle)
+	mux.HandleFunc(TaskTypeStageBatch, handle)
+	mux.HandleFunc(TaskTypeEditErase, handle)
+	mux.HandleFunc(TaskTypeRoomsGroup, handle)
+	mux.HandleFunc(TaskTypeProjectExport, handle)
 
 	// Start the asynq server in the background.
 	logger.Info(context.Background(), "starting asynq server",
@@ -177,8 +332,139 @@ func NewAsynqQueueClient(cfg *config.Config) (*AsynqQueueClient, error) {
 	return c, nil
 }
 
-// GetNextJob returns the next available job if present (non-blocking).
+// aggregateBatch combines the stage:run tasks of a batch group into one stage:batch task.
+// Payloads that are not valid JSON are dropped, since they could never be processed.
+func aggregateBatch(group string, tasks []*asynq.Task) *asynq.Task {
+	items := make([]json.RawMessage, 0, len(tasks))
+	for _, t := range tasks {
+		if !json.Valid(t.Payload()) {
+			logging.Default().Error(context.Background(), "dropping invalid task payload from batch", "group", group)
+			continue
+		}
+		items = append(items, t.Payload())
+	}
+	// Marshaling cannot fail: every item is valid JSON.
+	payload, _ := json.Marshal(BatchPayload{Items: items})
+	logging.Default().Info(context.Background(), "aggregated batch", "group", group, "items", len(items))
+	return asynq.NewTask(TaskTypeStageBatch, payload)
+}
+
+// recordFailure adds the failed attempt of t in ctx to the dead letter store. Deferrals
+// are not failures. Errors are logged rather than returned, so the task's outcome stands.
+func (c *AsynqQueueClient) recordFailure(ctx context.Context, t *asynq.Task, imageID string, err error) {
+	if c.deadLetters == nil || !isFailure(err) {
+		return
+	}
+	f := Failure{
+		TaskType: t.Type(),
+		Payload:  t.Payload(),
+		ImageID:  imageID,
+		Attempt:  1,
+		Error:    err.Error(),
+		FailedAt: time.Now().UTC(),
+		Final:    lastAttempt(ctx) || errors.Is(err, asynq.SkipRetry),
+	}
+	f.TaskID, _ = asynq.GetTaskID(ctx)
+	f.Queue, _ = asynq.GetQueueName(ctx)
+	f.MaxRetry, _ = asynq.GetMaxRetry(ctx)
+	if retried, ok := asynq.GetRetryCount(ctx); ok {
+		f.Attempt = retried + 1
+	}
+	if err := c.deadLetters.RecordFailure(ctx, f); err != nil {
+		logging.Default().Error(ctx, "record task failure failed", "task_type", t.Type(), "task_id", f.TaskID, "error", err)
+	}
+}
+
+// ownerOf returns the user the task belongs to, or "" when it cannot be told, which
+// shares one sub-queue with other such tasks rather than failing the task.
+func (c *AsynqQueueClient) ownerOf(ctx context.Context, taskType string, payload []byte) string {
+	if c.owners == nil {
+		return ""
+	}
+	imageID := firstImageID(taskType, payload)
+	if imageID == "" {
+		return ""
+	}
+	owner, err := c.owners.OwnerOf(ctx, imageID)
+	if err != nil {
+		logging.Default().Warn(ctx, "fair share: could not resolve task owner", "image_id", imageID, "error", err)
+		return ""
+	}
+	return owner
+}
+
+// firstImageID returns the image of a stage:run or edit task, or the first image of a batch.
+func firstImageID(taskType string, payload []byte) string {
+	var item struct {
+		ImageID string `json:"image_id"`
+	}
+	if taskType == TaskTypeStageBatch {
+		var batch BatchPayload
+		if json.Unmarshal(payload, &batch) != nil || len(batch.Items) == 0 {
+			return ""
+		}
+		payload = batch.Items[0]
+	}
+	if json.Unmarshal(payload, &item) != nil {
+		return ""
+	}
+	return item.ImageID
+}
+
+// tier is the fair share tier of the task in ctx: quick edits and expedited tasks in the
+// critical queue come before every user's regular tasks.
+func (c *AsynqQueueClient) tier(ctx context.Context) int {
+	name, ok := asynq.GetQueueName(ctx)
+	if ok && name != "" && (name == c.criticalQueue || name == c.editQueue) {
+		return 0
+	}
+	return 1
+}
+
+// lastAttempt reports whether the task in ctx has no retries left.
+func lastAttempt(ctx context.Context) bool {
+	retried, ok := asynq.GetRetryCount(ctx)
+	maxRetry, ok2 := asynq.GetMaxRetry(ctx)
+	return ok && ok2 && retried >= maxRetry
+}
+
+// queuePriorities returns the queues the worker serves, with the critical queue weighted
+// above the default one and the edit queue above both. An empty or duplicate critical or
+// edit queue is left out.
+func queuePriorities(queueName, criticalQueueName, editQueueName string) map[string]int {
+	queues := map[string]int{queueName: 1}
+	if criticalQueueName != "" && criticalQueueName != queueName {
+		queues[criticalQueueName] = 2
+	}
+	if _, taken := queues[editQueueName]; editQueueName != "" && !taken {
+		queues[editQueueName] = 3
+	}
+	return queues
+}
+
+// retryDelay schedules a deferred job for its requested time and any other failure
+// with asynq's default backoff.
+func retryDelay(n int, err error, t *asynq.Task) time.Duration {
+	var deferred *DeferredError
+	if errors.As(err, &deferred) {
+		return max(time.Until(deferred.Until), 0)
+	}
+	return asynq.DefaultRetryDelayFunc(n, err, t)
+}
+
+// isFailure keeps deferrals from using up a job's retries.
+func isFailure(err error) bool {
+	var deferred *DeferredError
+	return err != nil && !errors.As(err, &deferred)
+}
+
+// GetNextJob returns the next available job if present (non-blocking). With fair share it
+// is the next user's turn rather than the oldest task.
 func (c *AsynqQueueClient) GetNextJob(ctx context.Context) (*Job, error) {
+	if c.fair != nil {
+		jb, _ := c.fair.Pop()
+		return jb, nil
+	}
 	select {
 	case jb := <-c.jobs:
 		return jb, nil
@@ -225,3 +511,28 @@ func (c *AsynqQueueClient) MarkJobFailed(ctx context.Context, jobID string, erro
 		return ctx.Err()
 	}
 }
+
+// DeferJob reports a deferral back to the asynq handler, which reschedules the task for until.
+func (c *AsynqQueueClient) DeferJob(ctx context.Context, jobID string, until time.Time, reason string) error {
+	c.mu.Lock()
+	ch, ok := c.results[jobID]
+	if ok {
+		delete(c.results, jobID)
+	}
+	c.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	select {
+	case ch <- &DeferredError{Until: until, Reason: reason}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Shutdown stops taking tasks from Redis and waits for the handlers of tasks already taken
+// to return; tasks that do not finish in time go back to the queue.
+func (c *AsynqQueueClient) Shutdown() {
+	c.srv.Shutdown()
+}
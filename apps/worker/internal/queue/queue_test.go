@@ -0,0 +1,169 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/worker/internal/fairshare"
+)
+
+func TestRetryPolicy_Deferral(t *testing.T) {
+	task := asynq.NewTask("stage:run", nil)
+	until := time.Now().Add(time.Hour)
+	deferred := fmt.Errorf("wrapped: %w", &DeferredError{Until: until, Reason: "ceiling"})
+
+	assert.InDelta(t, time.Hour, retryDelay(3, deferred, task), float64(time.Second))
+	assert.Zero(t, retryDelay(0, &DeferredError{Until: time.Now().Add(-time.Minute)}, task))
+	assert.False(t, isFailure(deferred))
+
+	plain := errors.New("boom")
+	assert.Positive(t, retryDelay(1, plain, task))
+	assert.True(t, isFailure(plain))
+	assert.False(t, isFailure(nil))
+}
+
+func TestAsynqQueueClient_DeferJob(t *testing.T) {
+	resCh := make(chan error, 1)
+	c := &AsynqQueueClient{results: map[string]chan error{"job-1": resCh}}
+	until := time.Now().Add(time.Hour)
+
+	require.NoError(t, c.DeferJob(context.Background(), "job-1", until, "ceiling reached"))
+
+	var deferred *DeferredError
+	require.ErrorAs(t, <-resCh, &deferred)
+	assert.Equal(t, until, deferred.Until)
+	assert.Equal(t, "ceiling reached", deferred.Reason)
+
+	// Unknown jobs are ignored, as with MarkJobCompleted.
+	assert.NoError(t, c.DeferJob(context.Background(), "job-2", until, "ceiling reached"))
+}
+
+func TestAggregateBatch(t *testing.T) {
+	tasks := []*asynq.Task{
+		asynq.NewTask("stage:run", []byte(`{"image_id":"img-1"}`)),
+		asynq.NewTask("stage:run", []byte(`not json`)),
+		asynq.NewTask("stage:run", []byte(`{"image_id":"img-2"}`)),
+	}
+
+	batch := aggregateBatch("project:p1", tasks)
+	assert.Equal(t, TaskTypeStageBatch, batch.Type())
+
+	var payload BatchPayload
+	require.NoError(t, json.Unmarshal(batch.Payload(), &payload))
+	require.Len(t, payload.Items, 2)
+	assert.JSONEq(t, `{"image_id":"img-1"}`, string(payload.Items[0]))
+	assert.JSONEq(t, `{"image_id":"img-2"}`, string(payload.Items[1]))
+}
+
+func TestQueuePriorities(t *testing.T) {
+	assert.Equal(t, map[string]int{"default": 1, "critical": 2}, queuePriorities("default", "critical", ""))
+	assert.Equal(t, map[string]int{"default": 1}, queuePriorities("default", "", ""))
+	assert.Equal(t, map[string]int{"default": 1}, queuePriorities("default", "default", ""))
+	assert.Equal(t, map[string]int{"default": 1, "critical": 2, "edits": 3},
+		queuePriorities("default", "critical", "edits"))
+	assert.Equal(t, map[string]int{"default": 1, "critical": 2}, queuePriorities("default", "critical", "critical"))
+}
+
+func TestFirstImageID(t *testing.T) {
+	assert.Equal(t, "img-1", firstImageID("stage:run", []byte(`{"image_id":"img-1"}`)))
+	assert.Equal(t, "img-2", firstImageID(TaskTypeStageBatch,
+		[]byte(`{"items":[{"image_id":"img-2"},{"image_id":"img-3"}]}`)))
+	assert.Empty(t, firstImageID(TaskTypeStageBatch, []byte(`{"items":[]}`)))
+	assert.Empty(t, firstImageID("stage:run", []byte(`not json`)))
+	assert.Equal(t, "img-4", firstImageID(TaskTypeEditErase, []byte(`{"edit_id":"e1","image_id":"img-4"}`)))
+	assert.Empty(t, firstImageID(TaskTypeRoomsGroup, []byte(`{"grouping_id":"g1","project_id":"p1"}`)))
+}
+
+func TestAsynqQueueClient_ownerOf(t *testing.T) {
+	owners := &fairshare.OwnerResolverMock{
+		OwnerOfFunc: func(ctx context.Context, imageID string) (string, error) {
+			if imageID == "img-1" {
+				return "user-1", nil
+			}
+			return "", errors.New("image not found")
+		},
+	}
+	c := &AsynqQueueClient{owners: owners}
+	ctx := context.Background()
+
+	assert.Equal(t, "user-1", c.ownerOf(ctx, "stage:run", []byte(`{"image_id":"img-1"}`)))
+	assert.Empty(t, c.ownerOf(ctx, "stage:run", []byte(`{"image_id":"img-9"}`)))
+	assert.Empty(t, c.ownerOf(ctx, "stage:run", []byte(`{}`)))
+	assert.Len(t, owners.OwnerOfCalls(), 2)
+
+	assert.Empty(t, (&AsynqQueueClient{}).ownerOf(ctx, "stage:run", []byte(`{"image_id":"img-1"}`)))
+}
+
+func TestAsynqQueueClient_GetNextJob_FairShare(t *testing.T) {
+	c := &AsynqQueueClient{fair: fairshare.NewScheduler[*Job](2, 0, nil)}
+	for _, j := range []struct{ id, owner string }{{"a1", "alice"}, {"a2", "alice"}, {"b1", "bob"}} {
+		c.fair.Push(1, j.owner, &Job{ID: j.id})
+	}
+
+	var got []string
+	for {
+		jb, err := c.GetNextJob(context.Background())
+		require.NoError(t, err)
+		if jb == nil {
+			break
+		}
+		got = append(got, jb.ID)
+	}
+	assert.Equal(t, []string{"a1", "b1", "a2"}, got)
+}
+
+func TestAsynqQueueClient_recordFailure(t *testing.T) {
+	task := asynq.NewTask("stage:run", []byte(`sealed`))
+
+	t.Run("success: failed attempt recorded with the task's payload", func(t *testing.T) {
+		store := &DeadLetterStoreMock{
+			RecordFailureFunc: func(ctx context.Context, f Failure) error { return nil },
+		}
+		c := &AsynqQueueClient{deadLetters: store}
+
+		c.recordFailure(context.Background(), task, "img-1", errors.New("provider timeout"))
+
+		calls := store.RecordFailureCalls()
+		require.Len(t, calls, 1)
+		f := calls[0].F
+		assert.Equal(t, "stage:run", f.TaskType)
+		assert.Equal(t, []byte(`sealed`), f.Payload)
+		assert.Equal(t, "img-1", f.ImageID)
+		assert.Equal(t, 1, f.Attempt)
+		assert.Equal(t, "provider timeout", f.Error)
+		assert.False(t, f.Final)
+		assert.False(t, f.FailedAt.IsZero())
+	})
+
+	t.Run("success: skipped retries make the task dead", func(t *testing.T) {
+		store := &DeadLetterStoreMock{
+			RecordFailureFunc: func(ctx context.Context, f Failure) error { return errors.New("db down") },
+		}
+		c := &AsynqQueueClient{deadLetters: store}
+
+		c.recordFailure(context.Background(), task, "", fmt.Errorf("open payload: %w", asynq.SkipRetry))
+
+		calls := store.RecordFailureCalls()
+		require.Len(t, calls, 1)
+		assert.True(t, calls[0].F.Final)
+	})
+
+	t.Run("success: deferrals are not recorded", func(t *testing.T) {
+		store := &DeadLetterStoreMock{}
+		c := &AsynqQueueClient{deadLetters: store}
+
+		c.recordFailure(context.Background(), task, "img-1", &DeferredError{Until: time.Now(), Reason: "ceiling"})
+		assert.Empty(t, store.RecordFailureCalls())
+
+		// Without a store nothing is recorded either.
+		(&AsynqQueueClient{}).recordFailure(context.Background(), task, "img-1", errors.New("boom"))
+	})
+}
@@ -0,0 +1,150 @@
+package rawimage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+var (
+	// canonUUID names the box in moov that holds the CR3 metadata (CMT1 to CMT4) and thumbnail.
+	canonUUID = []byte{0x85, 0xc0, 0xb6, 0x87, 0x82, 0x0f, 0x11, 0xe0, 0x81, 0x11, 0xf4, 0xce, 0x46, 0x2b, 0x6a, 0x48}
+	// previewUUID names the top-level box that holds the 1620x1080 preview.
+	previewUUID = []byte{0xea, 0xf4, 0x2b, 0x5e, 0x1c, 0x98, 0x4b, 0x88, 0xb9, 0xfb, 0xb7, 0xdc, 0x40, 0x6e, 0x4d, 0x16}
+)
+
+var errNotCR3 = errors.New("not a CR3 file")
+
+// box is an ISO base media file format box.
+type box struct {
+	typ string
+	// uuid is the extended type of "uuid" boxes.
+	uuid []byte
+	// data is the box's payload, after the header and extended type.
+	data []byte
+	// off is where data starts in the file.
+	off int
+}
+
+// boxes reads the sibling boxes in b, which starts at off in the file. Reading stops at
+// the first box that does not fit.
+func boxes(b []byte, off int) []box {
+	var out []box
+	for pos := 0; pos+8 <= len(b); {
+		size := uint64(binary.BigEndian.Uint32(b[pos:]))
+		typ := string(b[pos+4 : pos+8])
+		hdr := uint64(8)
+		switch size {
+		case 0:
+			size = uint64(len(b) - pos)
+		case 1:
+			if pos+16 > len(b) {
+				return out
+			}
+			size, hdr = binary.BigEndian.Uint64(b[pos+8:]), 16
+		}
+		if size < hdr || uint64(pos)+size > uint64(len(b)) {
+			return out
+		}
+		bx := box{typ: typ, data: b[uint64(pos)+hdr : uint64(pos)+size], off: off + pos + int(hdr)}
+		if typ == "uuid" {
+			if len(bx.data) < 16 {
+				return out
+			}
+			bx.uuid, bx.data, bx.off = bx.data[:16], bx.data[16:], bx.off+16
+		}
+		out = append(out, bx)
+		pos += int(size)
+	}
+	return out
+}
+
+// child returns the first box of typ among bs, and for "uuid" boxes, of uuid.
+func child(bs []box, typ string, uuid []byte) (box, bool) {
+	for _, b := range bs {
+		if b.typ == typ && (uuid == nil || bytes.Equal(b.uuid, uuid)) {
+			return b, true
+		}
+	}
+	return box{}, false
+}
+
+// path follows the box types from bs down, taking the first box of each type.
+func path(bs []box, types ...string) (box, bool) {
+	var b box
+	for i, typ := range types {
+		var ok bool
+		if b, ok = child(bs, typ, nil); !ok {
+			return box{}, false
+		}
+		if i < len(types)-1 {
+			bs = boxes(b.data, b.off)
+		}
+	}
+	return b, true
+}
+
+// scanCR3 returns the JPEGs in a CR3 file, the full-size image of its first track and the
+// preview, and the orientation recorded in its CMT1 metadata, or 0 when there is none.
+func scanCR3(b []byte) (jpegs [][]byte, orientation int, err error) {
+	top := boxes(b, 0)
+	ftyp, ok := child(top, "ftyp", nil)
+	if !ok || len(ftyp.data) < 4 || string(ftyp.data[:4]) != "crx " {
+		return nil, 0, errNotCR3
+	}
+
+	if moov, ok := child(top, "moov", nil); ok {
+		inMoov := boxes(moov.data, moov.off)
+		if canon, ok := child(inMoov, "uuid", canonUUID); ok {
+			if cmt1, ok := child(boxes(canon.data, canon.off), "CMT1", nil); ok {
+				if t, first, err := newTIFF(cmt1.data); err == nil {
+					if entries, _, err := t.ifd(first); err == nil {
+						for _, e := range entries {
+							if v, ok := t.value(e, 0); ok && e.tag == tagOrientation {
+								orientation = int(v)
+							}
+						}
+					}
+				}
+			}
+		}
+		// The first track holds one sample: the full-size JPEG
+		if trak, ok := child(inMoov, "trak", nil); ok {
+			if jpeg := firstSample(b, boxes(trak.data, trak.off)); jpeg != nil {
+				jpegs = append(jpegs, jpeg)
+			}
+		}
+	}
+
+	if prvw, ok := child(top, "uuid", previewUUID); ok {
+		if i := bytes.Index(prvw.data, []byte{0xff, 0xd8, 0xff}); i >= 0 {
+			jpegs = append(jpegs, prvw.data[i:])
+		}
+	}
+	return jpegs, orientation, nil
+}
+
+// firstSample returns the first sample of a track when it starts like a JPEG.
+func firstSample(b []byte, trak []box) []byte {
+	stsz, ok := path(trak, "mdia", "minf", "stbl", "stsz")
+	if !ok || len(stsz.data) < 12 {
+		return nil
+	}
+	stbl, _ := path(trak, "mdia", "minf", "stbl")
+	inStbl := boxes(stbl.data, stbl.off)
+
+	size := uint64(binary.BigEndian.Uint32(stsz.data[4:]))
+	if size == 0 && len(stsz.data) >= 16 {
+		size = uint64(binary.BigEndian.Uint32(stsz.data[12:]))
+	}
+	var off uint64
+	if co64, ok := child(inStbl, "co64", nil); ok && len(co64.data) >= 16 {
+		off = binary.BigEndian.Uint64(co64.data[8:])
+	} else if stco, ok := child(inStbl, "stco", nil); ok && len(stco.data) >= 12 {
+		off = uint64(binary.BigEndian.Uint32(stco.data[8:]))
+	}
+	if size < 4 || off+size > uint64(len(b)) || b[off] != 0xff || b[off+1] != 0xd8 {
+		return nil
+	}
+	return b[off : off+size]
+}
@@ -0,0 +1,39 @@
+package rawimage
+
+import (
+	"image"
+	"image/draw"
+)
+
+// orient turns img upright for its TIFF orientation: 3 is upside down, 6 needs a quarter
+// turn clockwise and 8 a quarter turn counterclockwise. Mirrored orientations, which
+// cameras do not record, are left as they are.
+func orient(img image.Image, orientation int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	var out *image.RGBA
+	var at func(x, y int) (int, int)
+	switch orientation {
+	case 3:
+		out = image.NewRGBA(image.Rect(0, 0, w, h))
+		at = func(x, y int) (int, int) { return w - 1 - x, h - 1 - y }
+	case 6:
+		out = image.NewRGBA(image.Rect(0, 0, h, w))
+		at = func(x, y int) (int, int) { return y, h - 1 - x }
+	case 8:
+		out = image.NewRGBA(image.Rect(0, 0, h, w))
+		at = func(x, y int) (int, int) { return w - 1 - y, x }
+	default:
+		return img
+	}
+	src := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(src, src.Bounds(), img, b.Min, draw.Src)
+	ob := out.Bounds()
+	for y := range ob.Dy() {
+		for x := range ob.Dx() {
+			sx, sy := at(x, y)
+			copy(out.Pix[out.PixOffset(x, y):][:4], src.Pix[src.PixOffset(sx, sy):])
+		}
+	}
+	return out
+}
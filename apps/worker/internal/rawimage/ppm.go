@@ -0,0 +1,88 @@
+package rawimage
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+)
+
+// decodePPM decodes a binary PPM (P6), the format RAW decoders such as dcraw write to
+// stdout. Both 8-bit and 16-bit samples are read; 16-bit samples keep their high byte.
+func decodePPM(b []byte) (image.Image, error) {
+	r := bufio.NewReader(bytes.NewReader(b))
+	var header [4]int
+	magic, err := ppmToken(r)
+	if err != nil {
+		return nil, err
+	}
+	if magic != "P6" {
+		return nil, fmt.Errorf("decode ppm: unsupported format %q", magic)
+	}
+	for i := 1; i < len(header); i++ {
+		tok, err := ppmToken(r)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := fmt.Sscanf(tok, "%d", &header[i]); err != nil || header[i] <= 0 {
+			return nil, fmt.Errorf("decode ppm: bad header value %q", tok)
+		}
+	}
+	w, h, maxVal := header[1], header[2], header[3]
+	if maxVal > 65535 || w > 1<<15 || h > 1<<15 {
+		return nil, errors.New("decode ppm: image too large")
+	}
+	sample := 1
+	if maxVal > 255 {
+		sample = 2
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	row := make([]byte, w*3*sample)
+	for y := range h {
+		if _, err := io.ReadFull(r, row); err != nil {
+			return nil, fmt.Errorf("decode ppm: %w", err)
+		}
+		pix := img.Pix[y*img.Stride:]
+		for x := range w {
+			for c := range 3 {
+				v := int(row[(x*3+c)*sample])
+				if sample == 2 {
+					v = v<<8 | int(row[(x*3+c)*2+1])
+				}
+				pix[x*4+c] = uint8(v * 255 / maxVal)
+			}
+			pix[x*4+3] = 0xff
+		}
+	}
+	return img, nil
+}
+
+// ppmToken reads the next header token, skipping whitespace and comments. The single
+// whitespace byte after the last header token is consumed with it.
+func ppmToken(r *bufio.Reader) (string, error) {
+	var tok []byte
+	for {
+		c, err := r.ReadByte()
+		if err != nil {
+			if len(tok) > 0 && errors.Is(err, io.EOF) {
+				return string(tok), nil
+			}
+			return "", fmt.Errorf("decode ppm: %w", err)
+		}
+		switch {
+		case c == '#' && len(tok) == 0:
+			if _, err := r.ReadString('\n'); err != nil {
+				return "", fmt.Errorf("decode ppm: %w", err)
+			}
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			if len(tok) > 0 {
+				return string(tok), nil
+			}
+		default:
+			tok = append(tok, c)
+		}
+	}
+}
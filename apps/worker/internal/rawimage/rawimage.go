@@ -0,0 +1,165 @@
+// Package rawimage converts RAW photos from pro cameras (Canon CR3, Nikon NEF and Sony
+// ARW) into JPEGs the staging models accept.
+//
+// With a decoder command, such as dcraw, the sensor data is developed by it. Without one,
+// the largest JPEG the camera embedded in the file is used: Canon and Nikon embed one at
+// the sensor's full resolution, Sony one about 1600 pixels wide, which is still close to
+// the 2048 pixels the staging models work at.
+package rawimage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Format is a RAW file format, named by its file extension.
+type Format string
+
+// Supported RAW formats.
+const (
+	FormatCR3 Format = "cr3"
+	FormatNEF Format = "nef"
+	FormatARW Format = "arw"
+)
+
+const (
+	jpegQuality = 92
+	// minPreviewEdge is the shortest long edge, in pixels, an embedded JPEG must have to
+	// be staged. Smaller ones are thumbnails.
+	minPreviewEdge = 1024
+	// maxStderr caps how much of a decoder command's error output is kept for the error.
+	maxStderr = 512
+)
+
+var (
+	// ErrNoPreview is returned when a RAW file has no embedded JPEG large enough to stage
+	// and no decoder command is configured.
+	ErrNoPreview = errors.New("RAW file has no full-size embedded preview")
+	// ErrUnsupported is returned for files that are not in a supported RAW format.
+	ErrUnsupported = errors.New("unsupported RAW file")
+)
+
+// FormatOf returns the RAW format of a file name or object key, by its extension.
+func FormatOf(name string) (Format, bool) {
+	f := Format(strings.TrimPrefix(strings.ToLower(filepath.Ext(name)), "."))
+	switch f {
+	case FormatCR3, FormatNEF, FormatARW:
+		return f, true
+	}
+	return "", false
+}
+
+// Converter converts RAW files to JPEG.
+type Converter struct {
+	command []string
+}
+
+// NewConverter creates a Converter. command is a RAW decoder command line that is given
+// the path of a RAW file as its last argument and writes a binary PPM to stdout, such as
+// "dcraw -c -w -q 3". An empty command uses the JPEG embedded in each file instead.
+func NewConverter(command string) *Converter {
+	return &Converter{command: strings.Fields(command)}
+}
+
+// Convert returns raw, a file in format, as a JPEG.
+func (c *Converter) Convert(ctx context.Context, raw []byte, format Format) ([]byte, error) {
+	if len(c.command) > 0 {
+		return c.develop(ctx, raw, format)
+	}
+	return embeddedJPEG(raw, format)
+}
+
+// develop runs the decoder command on raw and encodes its output as a JPEG.
+func (c *Converter) develop(ctx context.Context, raw []byte, format Format) ([]byte, error) {
+	f, err := os.CreateTemp("", "raw-*."+string(format))
+	if err != nil {
+		return nil, fmt.Errorf("create temp file: %w", err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	_, err = f.Write(raw)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("write temp file: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.command[0], append(c.command[1:], f.Name())...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if len(msg) > maxStderr {
+			msg = msg[len(msg)-maxStderr:]
+		}
+		return nil, fmt.Errorf("run RAW decoder: %w: %s", err, msg)
+	}
+	img, err := decodePPM(stdout.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return encode(img)
+}
+
+// embeddedJPEG returns the largest JPEG embedded in raw, turned upright.
+func embeddedJPEG(raw []byte, format Format) ([]byte, error) {
+	var (
+		jpegs       [][]byte
+		orientation int
+	)
+	switch format {
+	case FormatCR3:
+		var err error
+		if jpegs, orientation, err = scanCR3(raw); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrUnsupported, err)
+		}
+	case FormatNEF, FormatARW:
+		t, first, err := newTIFF(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrUnsupported, err)
+		}
+		jpegs, orientation = t.scan(first)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupported, format)
+	}
+
+	var best []byte
+	var bestSize image.Point
+	for _, b := range jpegs {
+		// Lossless JPEG sensor data fails here and is skipped
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(b))
+		if err != nil || cfg.Width*cfg.Height <= bestSize.X*bestSize.Y {
+			continue
+		}
+		best, bestSize = b, image.Pt(cfg.Width, cfg.Height)
+	}
+	if max(bestSize.X, bestSize.Y) < minPreviewEdge {
+		return nil, fmt.Errorf("%w: largest is %dx%d", ErrNoPreview, bestSize.X, bestSize.Y)
+	}
+	if orientation != 3 && orientation != 6 && orientation != 8 {
+		return best, nil
+	}
+
+	img, err := jpeg.Decode(bytes.NewReader(best))
+	if err != nil {
+		return nil, fmt.Errorf("decode preview: %w", err)
+	}
+	return encode(orient(img, orientation))
+}
+
+// encode writes img as a JPEG.
+func encode(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, fmt.Errorf("encode jpeg: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,317 @@
+package rawimage
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// photo returns a w by h JPEG whose top-left quadrant is red, so its orientation can be
+// checked after conversion.
+func photo(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			c := color.RGBA{R: 40, G: 40, B: 200, A: 0xff}
+			if x < w/2 && y < h/2 {
+				c = color.RGBA{R: 220, G: 30, B: 30, A: 0xff}
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}))
+	return buf.Bytes()
+}
+
+// tiffEntry is an IFD entry for tiffFile: a LONG value, or an offset to another IFD or
+// blob by its index when ref is set.
+type tiffEntry struct {
+	tag   uint16
+	value uint32
+	ref   string
+	index int
+}
+
+// tiffFile lays out a little-endian TIFF with the IFDs in order, the first chained to
+// none, followed by the blobs. Entries refer to IFDs and blobs by index.
+func tiffFile(ifds [][]tiffEntry, blobs [][]byte) []byte {
+	le := binary.LittleEndian
+	ifdOffs := make([]uint32, len(ifds))
+	off := uint32(8)
+	for i, ifd := range ifds {
+		ifdOffs[i] = off
+		off += 2 + 12*uint32(len(ifd)) + 4
+	}
+	blobOffs := make([]uint32, len(blobs))
+	for i, b := range blobs {
+		blobOffs[i] = off
+		off += uint32(len(b))
+	}
+
+	out := []byte("II*\x00")
+	out = le.AppendUint32(out, 8)
+	for _, ifd := range ifds {
+		out = le.AppendUint16(out, uint16(len(ifd)))
+		for _, e := range ifd {
+			v := e.value
+			switch e.ref {
+			case "ifd":
+				v = ifdOffs[e.index]
+			case "blob":
+				v = blobOffs[e.index]
+			}
+			out = le.AppendUint16(out, e.tag)
+			out = le.AppendUint16(out, 4)
+			out = le.AppendUint32(out, 1)
+			out = le.AppendUint32(out, v)
+		}
+		out = le.AppendUint32(out, 0)
+	}
+	for _, b := range blobs {
+		out = append(out, b...)
+	}
+	return out
+}
+
+// nef lays out a NEF-like file: IFD0 with the orientation and a thumbnail, and a sub-IFD
+// with the full-size preview as a JPEG-compressed strip and lossless sensor data.
+func nef(t *testing.T, orientation uint32, full []byte) []byte {
+	thumb := photo(t, 160, 120)
+	sensor := []byte{0xff, 0xd8, 0xff, 0xc3, 0x00, 0x01, 0x02, 0x03}
+	return tiffFile([][]tiffEntry{
+		{
+			{tag: tagOrientation, value: orientation},
+			{tag: tagSubIFDs, ref: "ifd", index: 1},
+			{tag: tagJPEGOffset, ref: "blob", index: 0},
+			{tag: tagJPEGLength, value: uint32(len(thumb))},
+		},
+		{
+			{tag: tagCompression, value: 6},
+			{tag: tagStripOffsets, ref: "blob", index: 1},
+			{tag: tagStripByteCounts, value: uint32(len(full))},
+			{tag: tagExifIFD, ref: "ifd", index: 2},
+		},
+		{
+			{tag: tagCompression, value: 7},
+			{tag: tagStripOffsets, ref: "blob", index: 2},
+			{tag: tagStripByteCounts, value: uint32(len(sensor))},
+		},
+	}, [][]byte{thumb, full, sensor})
+}
+
+// isoBox encodes an ISO base media box.
+func isoBox(typ string, payload ...[]byte) []byte {
+	data := bytes.Join(payload, nil)
+	out := binary.BigEndian.AppendUint32(nil, uint32(8+len(data)))
+	return append(append(out, typ...), data...)
+}
+
+// cr3 lays out a CR3-like file: the orientation in CMT1, the full-size JPEG as the first
+// track's sample in mdat, and a smaller preview.
+func cr3(t *testing.T, orientation uint32, full []byte) []byte {
+	cmt1 := tiffFile([][]tiffEntry{{{tag: tagOrientation, value: orientation}}}, nil)
+	meta := isoBox("uuid", canonUUID, isoBox("CMT1", cmt1))
+	preview := isoBox("uuid", previewUUID, make([]byte, 8), isoBox("PRVW", make([]byte, 12), photo(t, 800, 600)))
+
+	trak := func(off uint64) []byte {
+		stsz := binary.BigEndian.AppendUint32(make([]byte, 4), 0)
+		stsz = binary.BigEndian.AppendUint32(stsz, 1)
+		stsz = binary.BigEndian.AppendUint32(stsz, uint32(len(full)))
+		co64 := binary.BigEndian.AppendUint32(make([]byte, 4), 1)
+		co64 = binary.BigEndian.AppendUint64(co64, off)
+		return isoBox("trak", isoBox("mdia", isoBox("minf", isoBox("stbl", isoBox("stsz", stsz), isoBox("co64", co64)))))
+	}
+	head := func(off uint64) []byte {
+		return bytes.Join([][]byte{
+			isoBox("ftyp", []byte("crx "), make([]byte, 4)),
+			isoBox("moov", meta, trak(off)),
+			preview,
+		}, nil)
+	}
+	// The layout does not depend on the offset, so it is laid out once to find it.
+	off := uint64(len(head(0)) + 8)
+	return append(head(off), isoBox("mdat", full)...)
+}
+
+// ppm encodes a w by h binary PPM with 16-bit samples of v.
+func ppm(w, h int, v uint16) []byte {
+	out := fmt.Appendf(nil, "P6\n# written by a test\n%d %d\n65535\n", w, h)
+	for range w * h * 3 {
+		out = binary.BigEndian.AppendUint16(out, v)
+	}
+	return out
+}
+
+// decoded decodes a converted JPEG.
+func decoded(t *testing.T, b []byte) image.Image {
+	t.Helper()
+	img, err := jpeg.Decode(bytes.NewReader(b))
+	require.NoError(t, err)
+	return img
+}
+
+// redCorner returns which corner of img photo's red quadrant ended up in, or "" when
+// none is red.
+func redCorner(img image.Image) string {
+	b := img.Bounds()
+	corners := []struct {
+		name string
+		at   image.Point
+	}{
+		{"top-left", image.Pt(b.Min.X+4, b.Min.Y+4)},
+		{"top-right", image.Pt(b.Max.X-5, b.Min.Y+4)},
+		{"bottom-left", image.Pt(b.Min.X+4, b.Max.Y-5)},
+		{"bottom-right", image.Pt(b.Max.X-5, b.Max.Y-5)},
+	}
+	for _, c := range corners {
+		if r, _, bl, _ := img.At(c.at.X, c.at.Y).RGBA(); r > bl {
+			return c.name
+		}
+	}
+	return ""
+}
+
+func TestFormatOf(t *testing.T) {
+	testCases := []struct {
+		name string
+		want Format
+		ok   bool
+	}{
+		{name: "uploads/u1/IMG_0001.CR3", want: FormatCR3, ok: true},
+		{name: "uploads/u1/DSC_0001.nef", want: FormatNEF, ok: true},
+		{name: "DSC00001.ARW", want: FormatARW, ok: true},
+		{name: "uploads/u1/room.jpg"},
+		{name: "uploads/u1/cr3"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := FormatOf(tc.name)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestConverter_Convert(t *testing.T) {
+	ctx := context.Background()
+	full := photo(t, 1600, 1200)
+
+	t.Run("success: largest embedded JPEG of a NEF is returned as is", func(t *testing.T) {
+		out, err := NewConverter("").Convert(ctx, nef(t, 1, full), FormatNEF)
+		require.NoError(t, err)
+		assert.Equal(t, full, out)
+	})
+
+	t.Run("success: rotated embedded JPEGs are turned upright", func(t *testing.T) {
+		testCases := []struct {
+			name      string
+			file      []byte
+			format    Format
+			size      image.Point
+			redCorner string
+		}{
+			{name: "NEF turned clockwise", file: nef(t, 6, full), format: FormatNEF, size: image.Pt(1200, 1600), redCorner: "top-right"},
+			{name: "ARW turned counterclockwise", file: nef(t, 8, full), format: FormatARW, size: image.Pt(1200, 1600), redCorner: "bottom-left"},
+			{name: "CR3 upside down", file: cr3(t, 3, full), format: FormatCR3, size: image.Pt(1600, 1200), redCorner: "bottom-right"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				out, err := NewConverter("").Convert(ctx, tc.file, tc.format)
+				require.NoError(t, err)
+				img := decoded(t, out)
+				assert.Equal(t, tc.size, img.Bounds().Size())
+				assert.Equal(t, tc.redCorner, redCorner(img))
+			})
+		}
+	})
+
+	t.Run("success: full-size JPEG of a CR3 track is preferred to the preview", func(t *testing.T) {
+		out, err := NewConverter("").Convert(ctx, cr3(t, 1, full), FormatCR3)
+		require.NoError(t, err)
+		assert.Equal(t, full, out)
+	})
+
+	t.Run("success: decoder command output is encoded as JPEG", func(t *testing.T) {
+		// cat writes the "RAW" file, here already a PPM, to stdout.
+		out, err := NewConverter("cat").Convert(ctx, ppm(6, 4, 0x8000), FormatCR3)
+		require.NoError(t, err)
+		img := decoded(t, out)
+		assert.Equal(t, image.Pt(6, 4), img.Bounds().Size())
+		_, g, _, _ := img.At(3, 2).RGBA()
+		assert.InDelta(t, 127, g>>8, 3)
+	})
+
+	t.Run("fail: only a thumbnail is embedded", func(t *testing.T) {
+		_, err := NewConverter("").Convert(ctx, nef(t, 1, photo(t, 640, 480)), FormatNEF)
+		assert.ErrorIs(t, err, ErrNoPreview)
+	})
+
+	t.Run("fail: not a RAW file", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			format Format
+		}{
+			{name: "NEF", format: FormatNEF},
+			{name: "CR3", format: FormatCR3},
+			{name: "unknown format", format: Format("dng")},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := NewConverter("").Convert(ctx, full, tc.format)
+				assert.ErrorIs(t, err, ErrUnsupported)
+			})
+		}
+	})
+
+	t.Run("fail: decoder command fails", func(t *testing.T) {
+		if _, err := exec.LookPath("false"); err != nil {
+			t.Skip("false not found")
+		}
+		_, err := NewConverter("false").Convert(ctx, full, FormatNEF)
+		assert.ErrorContains(t, err, "run RAW decoder")
+	})
+
+	t.Run("fail: decoder command writes something other than a PPM", func(t *testing.T) {
+		_, err := NewConverter("cat").Convert(ctx, full, FormatNEF)
+		assert.ErrorContains(t, err, "decode ppm")
+	})
+}
+
+func TestDecodePPM(t *testing.T) {
+	t.Run("success: 8-bit samples", func(t *testing.T) {
+		b := append([]byte("P6 2 1 255\n"), 255, 0, 0, 0, 0, 255)
+		img, err := decodePPM(b)
+		require.NoError(t, err)
+		assert.Equal(t, color.RGBA{R: 255, A: 255}, img.At(0, 0))
+		assert.Equal(t, color.RGBA{B: 255, A: 255}, img.At(1, 0))
+	})
+
+	t.Run("fail: bad input", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			b       []byte
+			wantErr string
+		}{
+			{name: "ASCII PPM", b: []byte("P3 1 1 255\n255 0 0"), wantErr: "unsupported format"},
+			{name: "bad width", b: []byte("P6 x 1 255\n"), wantErr: "bad header value"},
+			{name: "truncated pixels", b: []byte("P6 2 2 255\n\x00\x00"), wantErr: "unexpected EOF"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := decodePPM(tc.b)
+				assert.ErrorContains(t, err, tc.wantErr)
+			})
+		}
+	})
+}
@@ -0,0 +1,183 @@
+package rawimage
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// TIFF tags read from NEF and ARW files and from the metadata boxes of CR3 files.
+const (
+	tagCompression     = 0x0103
+	tagStripOffsets    = 0x0111
+	tagOrientation     = 0x0112
+	tagStripByteCounts = 0x0117
+	tagSubIFDs         = 0x014a
+	tagJPEGOffset      = 0x0201
+	tagJPEGLength      = 0x0202
+	tagExifIFD         = 0x8769
+)
+
+// maxIFDs bounds how many IFDs are read from one file, so a file whose IFDs point at each
+// other cannot loop.
+const maxIFDs = 64
+
+// typeSizes are the sizes in bytes of TIFF field types, by type code.
+var typeSizes = map[uint16]uint64{1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 6: 1, 7: 1, 8: 2, 9: 4, 10: 8, 11: 4, 12: 8, 13: 4}
+
+var errNotTIFF = errors.New("not a TIFF structure")
+
+// tiff reads the IFDs of a TIFF structure.
+type tiff struct {
+	b     []byte
+	order binary.ByteOrder
+}
+
+// ifdEntry is one field of an IFD, with its values still encoded.
+type ifdEntry struct {
+	tag, typ uint16
+	count    uint32
+	data     []byte
+}
+
+// newTIFF reads the header of the TIFF structure in b and returns the offset of its
+// first IFD.
+func newTIFF(b []byte) (*tiff, uint32, error) {
+	if len(b) < 8 {
+		return nil, 0, errNotTIFF
+	}
+	var order binary.ByteOrder
+	switch string(b[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil, 0, errNotTIFF
+	}
+	// NEF and ARW use the standard TIFF magic number; CR3 metadata does too.
+	if order.Uint16(b[2:]) != 42 {
+		return nil, 0, errNotTIFF
+	}
+	return &tiff{b: b, order: order}, order.Uint32(b[4:]), nil
+}
+
+// ifd reads the entries of the IFD at off and the offset of the next IFD. Entries of
+// unknown types or whose values fall outside the file are skipped.
+func (t *tiff) ifd(off uint32) ([]ifdEntry, uint32, error) {
+	size := uint64(len(t.b))
+	if uint64(off)+2 > size {
+		return nil, 0, errNotTIFF
+	}
+	n := uint64(t.order.Uint16(t.b[off:]))
+	end := uint64(off) + 2 + n*12
+	if end > size {
+		return nil, 0, errNotTIFF
+	}
+	entries := make([]ifdEntry, 0, n)
+	for i := range n {
+		e := t.b[uint64(off)+2+i*12:]
+		entry := ifdEntry{tag: t.order.Uint16(e), typ: t.order.Uint16(e[2:]), count: t.order.Uint32(e[4:])}
+		total := typeSizes[entry.typ] * uint64(entry.count)
+		switch {
+		case total == 0:
+			continue
+		case total <= 4:
+			entry.data = e[8 : 8+total]
+		default:
+			p := uint64(t.order.Uint32(e[8:]))
+			if p+total > size {
+				continue
+			}
+			entry.data = t.b[p : p+total]
+		}
+		entries = append(entries, entry)
+	}
+	var next uint32
+	if end+4 <= size {
+		next = t.order.Uint32(t.b[end:])
+	}
+	return entries, next, nil
+}
+
+// value returns the i-th value of a SHORT or LONG entry.
+func (t *tiff) value(e ifdEntry, i int) (uint32, bool) {
+	if i < 0 || uint32(i) >= e.count {
+		return 0, false
+	}
+	switch e.typ {
+	case 3:
+		return uint32(t.order.Uint16(e.data[2*i:])), true
+	case 4, 13:
+		return t.order.Uint32(e.data[4*i:]), true
+	}
+	return 0, false
+}
+
+// scan returns the JPEGs embedded in the structure, from the JPEG interchange tags and
+// from single-strip JPEG-compressed images, and the orientation recorded in the first
+// IFD, or 0 when there is none. The IFD chain, sub-IFDs and the Exif IFD are followed.
+func (t *tiff) scan(first uint32) (jpegs [][]byte, orientation int) {
+	queue := []uint32{first}
+	seen := map[uint32]bool{}
+	for len(queue) > 0 && len(seen) < maxIFDs {
+		off := queue[0]
+		queue = queue[1:]
+		if off == 0 || seen[off] {
+			continue
+		}
+		seen[off] = true
+		entries, next, err := t.ifd(off)
+		if err != nil {
+			continue
+		}
+		queue = append(queue, next)
+
+		var jpegOff, jpegLen, compression uint32
+		var strips, stripLens ifdEntry
+		for _, e := range entries {
+			switch e.tag {
+			case tagOrientation:
+				if v, ok := t.value(e, 0); ok && off == first {
+					orientation = int(v)
+				}
+			case tagSubIFDs, tagExifIFD:
+				for i := range int(e.count) {
+					if v, ok := t.value(e, i); ok {
+						queue = append(queue, v)
+					}
+				}
+			case tagJPEGOffset:
+				jpegOff, _ = t.value(e, 0)
+			case tagJPEGLength:
+				jpegLen, _ = t.value(e, 0)
+			case tagCompression:
+				compression, _ = t.value(e, 0)
+			case tagStripOffsets:
+				strips = e
+			case tagStripByteCounts:
+				stripLens = e
+			}
+		}
+		if b := t.slice(jpegOff, jpegLen); b != nil {
+			jpegs = append(jpegs, b)
+		}
+		// Old-style (6) and new-style (7) JPEG compression
+		if (compression == 6 || compression == 7) && strips.count == 1 && stripLens.count == 1 {
+			o, _ := t.value(strips, 0)
+			n, _ := t.value(stripLens, 0)
+			if b := t.slice(o, n); b != nil {
+				jpegs = append(jpegs, b)
+			}
+		}
+	}
+	return jpegs, orientation
+}
+
+// slice returns the n bytes at off when they are inside the file and start like a JPEG.
+func (t *tiff) slice(off, n uint32) []byte {
+	end := uint64(off) + uint64(n)
+	if n < 4 || end > uint64(len(t.b)) || t.b[off] != 0xff || t.b[off+1] != 0xd8 {
+		return nil
+	}
+	return t.b[off:end]
+}
@@ -18,15 +18,30 @@ var _ ImageRepository = &ImageRepositoryMock{}
 //
 //		// make and configure a mocked ImageRepository
 //		mockedImageRepository := &ImageRepositoryMock{
+//			GetStatusFunc: func(ctx context.Context, imageID string) (string, error) {
+//				panic("mock out the GetStatus method")
+//			},
 //			SetErrorFunc: func(ctx context.Context, imageID string, errorMsg string) error {
 //				panic("mock out the SetError method")
 //			},
+//			SetPreviewFunc: func(ctx context.Context, imageID string, previewURL string) error {
+//				panic("mock out the SetPreview method")
+//			},
 //			SetProcessingFunc: func(ctx context.Context, imageID string) error {
 //				panic("mock out the SetProcessing method")
 //			},
+//			SetRawOriginalFunc: func(ctx context.Context, imageID string, originalURL string, rawURL string) error {
+//				panic("mock out the SetRawOriginal method")
+//			},
 //			SetReadyFunc: func(ctx context.Context, imageID string, stagedURL string) error {
 //				panic("mock out the SetReady method")
 //			},
+//			SetSizesFunc: func(ctx context.Context, imageID string, originalBytes int64, stagedBytes int64) error {
+//				panic("mock out the SetSizes method")
+//			},
+//			SetWatermarkedFunc: func(ctx context.Context, imageID string, watermarkedURL string) error {
+//				panic("mock out the SetWatermarked method")
+//			},
 //		}
 //
 //		// use mockedImageRepository in code that requires ImageRepository
@@ -34,17 +49,39 @@ var _ ImageRepository = &ImageRepositoryMock{}
 //
 //	}
 type ImageRepositoryMock struct {
+	// GetStatusFunc mocks the GetStatus method.
+	GetStatusFunc func(ctx context.Context, imageID string) (string, error)
+
 	// SetErrorFunc mocks the SetError method.
 	SetErrorFunc func(ctx context.Context, imageID string, errorMsg string) error
 
+	// SetPreviewFunc mocks the SetPreview method.
+	SetPreviewFunc func(ctx context.Context, imageID string, previewURL string) error
+
 	// SetProcessingFunc mocks the SetProcessing method.
 	SetProcessingFunc func(ctx context.Context, imageID string) error
 
+	// SetRawOriginalFunc mocks the SetRawOriginal method.
+	SetRawOriginalFunc func(ctx context.Context, imageID string, originalURL string, rawURL string) error
+
 	// SetReadyFunc mocks the SetReady method.
 	SetReadyFunc func(ctx context.Context, imageID string, stagedURL string) error
 
+	// SetSizesFunc mocks the SetSizes method.
+	SetSizesFunc func(ctx context.Context, imageID string, originalBytes int64, stagedBytes int64) error
+
+	// SetWatermarkedFunc mocks the SetWatermarked method.
+	SetWatermarkedFunc func(ctx context.Context, imageID string, watermarkedURL string) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// GetStatus holds details about calls to the GetStatus method.
+		GetStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID string
+		}
 		// SetError holds details about calls to the SetError method.
 		SetError []struct {
 			// Ctx is the ctx argument value.
@@ -54,6 +91,15 @@ type ImageRepositoryMock struct {
 			// ErrorMsg is the errorMsg argument value.
 			ErrorMsg string
 		}
+		// SetPreview holds details about calls to the SetPreview method.
+		SetPreview []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID string
+			// PreviewURL is the previewURL argument value.
+			PreviewURL string
+		}
 		// SetProcessing holds details about calls to the SetProcessing method.
 		SetProcessing []struct {
 			// Ctx is the ctx argument value.
@@ -61,6 +107,17 @@ type ImageRepositoryMock struct {
 			// ImageID is the imageID argument value.
 			ImageID string
 		}
+		// SetRawOriginal holds details about calls to the SetRawOriginal method.
+		SetRawOriginal []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID string
+			// OriginalURL is the originalURL argument value.
+			OriginalURL string
+			// RawURL is the rawURL argument value.
+			RawURL string
+		}
 		// SetReady holds details about calls to the SetReady method.
 		SetReady []struct {
 			// Ctx is the ctx argument value.
@@ -70,10 +127,71 @@ type ImageRepositoryMock struct {
 			// StagedURL is the stagedURL argument value.
 			StagedURL string
 		}
+		// SetSizes holds details about calls to the SetSizes method.
+		SetSizes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID string
+			// OriginalBytes is the originalBytes argument value.
+			OriginalBytes int64
+			// StagedBytes is the stagedBytes argument value.
+			StagedBytes int64
+		}
+		// SetWatermarked holds details about calls to the SetWatermarked method.
+		SetWatermarked []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID string
+			// WatermarkedURL is the watermarkedURL argument value.
+			WatermarkedURL string
+		}
+	}
+	lockGetStatus      sync.RWMutex
+	lockSetError       sync.RWMutex
+	lockSetPreview     sync.RWMutex
+	lockSetProcessing  sync.RWMutex
+	lockSetRawOriginal sync.RWMutex
+	lockSetReady       sync.RWMutex
+	lockSetSizes       sync.RWMutex
+	lockSetWatermarked sync.RWMutex
+}
+
+// GetStatus calls GetStatusFunc.
+func (mock *ImageRepositoryMock) GetStatus(ctx context.Context, imageID string) (string, error) {
+	if mock.GetStatusFunc == nil {
+		panic("ImageRepositoryMock.GetStatusFunc: method is nil but ImageRepository.GetStatus was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ImageID string
+	}{
+		Ctx:     ctx,
+		ImageID: imageID,
+	}
+	mock.lockGetStatus.Lock()
+	mock.calls.GetStatus = append(mock.calls.GetStatus, callInfo)
+	mock.lockGetStatus.Unlock()
+	return mock.GetStatusFunc(ctx, imageID)
+}
+
+// GetStatusCalls gets all the calls that were made to GetStatus.
+// Check the length with:
+//
+//	len(mockedImageRepository.GetStatusCalls())
+func (mock *ImageRepositoryMock) GetStatusCalls() []struct {
+	Ctx     context.Context
+	ImageID string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ImageID string
 	}
-	lockSetError      sync.RWMutex
-	lockSetProcessing sync.RWMutex
-	lockSetReady      sync.RWMutex
+	mock.lockGetStatus.RLock()
+	calls = mock.calls.GetStatus
+	mock.lockGetStatus.RUnlock()
+	return calls
 }
 
 // SetError calls SetErrorFunc.
@@ -116,6 +234,46 @@ func (mock *ImageRepositoryMock) SetErrorCalls() []struct {
 	return calls
 }
 
+// SetPreview calls SetPreviewFunc.
+func (mock *ImageRepositoryMock) SetPreview(ctx context.Context, imageID string, previewURL string) error {
+	if mock.SetPreviewFunc == nil {
+		panic("ImageRepositoryMock.SetPreviewFunc: method is nil but ImageRepository.SetPreview was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		ImageID    string
+		PreviewURL string
+	}{
+		Ctx:        ctx,
+		ImageID:    imageID,
+		PreviewURL: previewURL,
+	}
+	mock.lockSetPreview.Lock()
+	mock.calls.SetPreview = append(mock.calls.SetPreview, callInfo)
+	mock.lockSetPreview.Unlock()
+	return mock.SetPreviewFunc(ctx, imageID, previewURL)
+}
+
+// SetPreviewCalls gets all the calls that were made to SetPreview.
+// Check the length with:
+//
+//	len(mockedImageRepository.SetPreviewCalls())
+func (mock *ImageRepositoryMock) SetPreviewCalls() []struct {
+	Ctx        context.Context
+	ImageID    string
+	PreviewURL string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		ImageID    string
+		PreviewURL string
+	}
+	mock.lockSetPreview.RLock()
+	calls = mock.calls.SetPreview
+	mock.lockSetPreview.RUnlock()
+	return calls
+}
+
 // SetProcessing calls SetProcessingFunc.
 func (mock *ImageRepositoryMock) SetProcessing(ctx context.Context, imageID string) error {
 	if mock.SetProcessingFunc == nil {
@@ -152,6 +310,50 @@ func (mock *ImageRepositoryMock) SetProcessingCalls() []struct {
 	return calls
 }
 
+// SetRawOriginal calls SetRawOriginalFunc.
+func (mock *ImageRepositoryMock) SetRawOriginal(ctx context.Context, imageID string, originalURL string, rawURL string) error {
+	if mock.SetRawOriginalFunc == nil {
+		panic("ImageRepositoryMock.SetRawOriginalFunc: method is nil but ImageRepository.SetRawOriginal was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		ImageID     string
+		OriginalURL string
+		RawURL      string
+	}{
+		Ctx:         ctx,
+		ImageID:     imageID,
+		OriginalURL: originalURL,
+		RawURL:      rawURL,
+	}
+	mock.lockSetRawOriginal.Lock()
+	mock.calls.SetRawOriginal = append(mock.calls.SetRawOriginal, callInfo)
+	mock.lockSetRawOriginal.Unlock()
+	return mock.SetRawOriginalFunc(ctx, imageID, originalURL, rawURL)
+}
+
+// SetRawOriginalCalls gets all the calls that were made to SetRawOriginal.
+// Check the length with:
+//
+//	len(mockedImageRepository.SetRawOriginalCalls())
+func (mock *ImageRepositoryMock) SetRawOriginalCalls() []struct {
+	Ctx         context.Context
+	ImageID     string
+	OriginalURL string
+	RawURL      string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		ImageID     string
+		OriginalURL string
+		RawURL      string
+	}
+	mock.lockSetRawOriginal.RLock()
+	calls = mock.calls.SetRawOriginal
+	mock.lockSetRawOriginal.RUnlock()
+	return calls
+}
+
 // SetReady calls SetReadyFunc.
 func (mock *ImageRepositoryMock) SetReady(ctx context.Context, imageID string, stagedURL string) error {
 	if mock.SetReadyFunc == nil {
@@ -191,3 +393,87 @@ func (mock *ImageRepositoryMock) SetReadyCalls() []struct {
 	mock.lockSetReady.RUnlock()
 	return calls
 }
+
+// SetSizes calls SetSizesFunc.
+func (mock *ImageRepositoryMock) SetSizes(ctx context.Context, imageID string, originalBytes int64, stagedBytes int64) error {
+	if mock.SetSizesFunc == nil {
+		panic("ImageRepositoryMock.SetSizesFunc: method is nil but ImageRepository.SetSizes was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		ImageID       string
+		OriginalBytes int64
+		StagedBytes   int64
+	}{
+		Ctx:           ctx,
+		ImageID:       imageID,
+		OriginalBytes: originalBytes,
+		StagedBytes:   stagedBytes,
+	}
+	mock.lockSetSizes.Lock()
+	mock.calls.SetSizes = append(mock.calls.SetSizes, callInfo)
+	mock.lockSetSizes.Unlock()
+	return mock.SetSizesFunc(ctx, imageID, originalBytes, stagedBytes)
+}
+
+// SetSizesCalls gets all the calls that were made to SetSizes.
+// Check the length with:
+//
+//	len(mockedImageRepository.SetSizesCalls())
+func (mock *ImageRepositoryMock) SetSizesCalls() []struct {
+	Ctx           context.Context
+	ImageID       string
+	OriginalBytes int64
+	StagedBytes   int64
+} {
+	var calls []struct {
+		Ctx           context.Context
+		ImageID       string
+		OriginalBytes int64
+		StagedBytes   int64
+	}
+	mock.lockSetSizes.RLock()
+	calls = mock.calls.SetSizes
+	mock.lockSetSizes.RUnlock()
+	return calls
+}
+
+// SetWatermarked calls SetWatermarkedFunc.
+func (mock *ImageRepositoryMock) SetWatermarked(ctx context.Context, imageID string, watermarkedURL string) error {
+	if mock.SetWatermarkedFunc == nil {
+		panic("ImageRepositoryMock.SetWatermarkedFunc: method is nil but ImageRepository.SetWatermarked was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		ImageID        string
+		WatermarkedURL string
+	}{
+		Ctx:            ctx,
+		ImageID:        imageID,
+		WatermarkedURL: watermarkedURL,
+	}
+	mock.lockSetWatermarked.Lock()
+	mock.calls.SetWatermarked = append(mock.calls.SetWatermarked, callInfo)
+	mock.lockSetWatermarked.Unlock()
+	return mock.SetWatermarkedFunc(ctx, imageID, watermarkedURL)
+}
+
+// SetWatermarkedCalls gets all the calls that were made to SetWatermarked.
+// Check the length with:
+//
+//	len(mockedImageRepository.SetWatermarkedCalls())
+func (mock *ImageRepositoryMock) SetWatermarkedCalls() []struct {
+	Ctx            context.Context
+	ImageID        string
+	WatermarkedURL string
+} {
+	var calls []struct {
+		Ctx            context.Context
+		ImageID        string
+		WatermarkedURL string
+	}
+	mock.lockSetWatermarked.RLock()
+	calls = mock.calls.SetWatermarked
+	mock.lockSetWatermarked.RUnlock()
+	return calls
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
 
 	_ "github.com/lib/pq"
+
+	"github.com/real-staging-ai/worker/internal/dbretry"
 )
 
 //go:generate go run github.com/matryer/moq@v0.5.3 -out image_repository_mock.go . ImageRepository
@@ -19,6 +22,51 @@ type ImageRepository interface {
 	SetReady(ctx context.Context, imageID string, stagedURL string) error
 	// SetError marks the image as "error" and sets the error message.
 	SetError(ctx context.Context, imageID string, errorMsg string) error
+	// SetSizes records the byte sizes of the original and staged output. A zero size
+	// leaves the stored value unchanged.
+	SetSizes(ctx context.Context, imageID string, originalBytes, stagedBytes int64) error
+	// SetPreview records the URL of a low-resolution preview of the output while the image
+	// is still processing.
+	SetPreview(ctx context.Context, imageID string, previewURL string) error
+	// SetWatermarked records the URL of the watermarked copy of the staged output. An
+	// empty URL clears it, so a copy from an earlier staging run is not served.
+	SetWatermarked(ctx context.Context, imageID string, watermarkedURL string) error
+	// SetRawOriginal records that the uploaded RAW file at rawURL was converted to the
+	// JPEG at originalURL, which is staged and shown in its place.
+	SetRawOriginal(ctx context.Context, imageID string, originalURL, rawURL string) error
+	// GetStatus returns the image's status, such as "queued" or "ready".
+	GetStatus(ctx context.Context, imageID string) (string, error)
+}
+
+// statusSource identifies the worker in image_status_transitions.source.
+const statusSource = "real-staging-worker"
+
+// setStatusSourceSQL describes the status change to the image_status_transitions trigger
+// for the rest of the transaction.
+const setStatusSourceSQL = `
+	SELECT set_config('app.status_source', $1, true),
+	       set_config('app.job_id', $2, true),
+	       set_config('app.job_attempt', $3, true);
+`
+
+type jobKey struct{}
+
+// JobRef identifies the job attempt a status change is made for.
+type JobRef struct {
+	ID      string
+	Attempt int
+}
+
+// WithJob returns a context whose image status changes are recorded as made by the
+// given attempt of job jobID.
+func WithJob(ctx context.Context, jobID string, attempt int) context.Context {
+	return context.WithValue(ctx, jobKey{}, JobRef{ID: jobID, Attempt: attempt})
+}
+
+// JobFrom returns the job attempt set on ctx by WithJob.
+func JobFrom(ctx context.Context) (JobRef, bool) {
+	ref, ok := ctx.Value(jobKey{}).(JobRef)
+	return ref, ok
 }
 
 // DefaultImageRepository is a sql.DB-backed implementation using plain SQL.
@@ -38,7 +86,7 @@ func (r *DefaultImageRepository) SetProcessing(ctx context.Context, imageID stri
 		SET status = 'processing', updated_at = now()
 		WHERE id = $1::uuid AND status IN ('queued','processing');
 	`
-	if _, err := r.db.ExecContext(ctx, q, imageID); err != nil {
+	if err := r.updateStatus(ctx, "set image processing", q, imageID); err != nil {
 		return fmt.Errorf("update image status to processing: %w", err)
 	}
 	return nil
@@ -56,7 +104,7 @@ func (r *DefaultImageRepository) SetReady(ctx context.Context, imageID string, s
 		SET staged_url = $2, status = 'ready', updated_at = now()
 		WHERE id = $1::uuid AND status IN ('queued','processing');
 	`
-	if _, err := r.db.ExecContext(ctx, q, imageID, stagedURL); err != nil {
+	if err := r.updateStatus(ctx, "set image ready", q, imageID, stagedURL); err != nil {
 		return fmt.Errorf("update image with staged url: %w", err)
 	}
 	return nil
@@ -72,8 +120,128 @@ func (r *DefaultImageRepository) SetError(ctx context.Context, imageID string, e
 		SET status = 'error', error = $2, updated_at = now()
 		WHERE id = $1::uuid AND status IN ('queued','processing');
 	`
-	if _, err := r.db.ExecContext(ctx, q, imageID, errorMsg); err != nil {
+	if err := r.updateStatus(ctx, "set image error", q, imageID, errorMsg); err != nil {
 		return fmt.Errorf("update image with error: %w", err)
 	}
 	return nil
 }
+
+// updateStatus runs a status update in a transaction that first records the worker and,
+// when ctx carries one, the job attempt making it.
+func (r *DefaultImageRepository) updateStatus(ctx context.Context, op string, q string, args ...any) error {
+	var jobID, attempt string
+	if ref, ok := JobFrom(ctx); ok {
+		jobID = ref.ID
+		if ref.Attempt > 0 {
+			attempt = strconv.Itoa(ref.Attempt)
+		}
+	}
+	return dbretry.Do(ctx, op, func(ctx context.Context) error {
+		tx, err := r.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		if _, err := tx.ExecContext(ctx, setStatusSourceSQL, statusSource, jobID, attempt); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+			return err
+		}
+		return tx.Commit()
+	})
+}
+
+// SetSizes records the byte sizes of the original and staged output, which the API
+// adds up for project storage use. A zero size leaves the stored value unchanged.
+func (r *DefaultImageRepository) SetSizes(ctx context.Context, imageID string, originalBytes, stagedBytes int64) error {
+	const q = `
+		UPDATE images
+		SET original_size_bytes = COALESCE(NULLIF($2::bigint, 0), original_size_bytes),
+		    staged_size_bytes = COALESCE(NULLIF($3::bigint, 0), staged_size_bytes)
+		WHERE id = $1::uuid;
+	`
+	err := dbretry.Do(ctx, "set image sizes", func(ctx context.Context) error {
+		_, err := r.db.ExecContext(ctx, q, imageID, originalBytes, stagedBytes)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("update image sizes: %w", err)
+	}
+	return nil
+}
+
+// SetPreview records the URL of a low-resolution preview of the output. It only applies
+// while the image is processing, so a late preview never lands on a finished image.
+func (r *DefaultImageRepository) SetPreview(ctx context.Context, imageID string, previewURL string) error {
+	if previewURL == "" {
+		return fmt.Errorf("previewURL cannot be empty")
+	}
+	const q = `
+		UPDATE images
+		SET preview_url = $2
+		WHERE id = $1::uuid AND status = 'processing';
+	`
+	err := dbretry.Do(ctx, "set image preview", func(ctx context.Context) error {
+		_, err := r.db.ExecContext(ctx, q, imageID, previewURL)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("update image preview: %w", err)
+	}
+	return nil
+}
+
+// SetWatermarked records the URL of the watermarked copy of the staged output, or clears
+// it when watermarkedURL is empty.
+func (r *DefaultImageRepository) SetWatermarked(ctx context.Context, imageID string, watermarkedURL string) error {
+	const q = `
+		UPDATE images
+		SET watermarked_url = NULLIF($2, '')
+		WHERE id = $1::uuid;
+	`
+	err := dbretry.Do(ctx, "set image watermarked copy", func(ctx context.Context) error {
+		_, err := r.db.ExecContext(ctx, q, imageID, watermarkedURL)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("update image watermarked copy: %w", err)
+	}
+	return nil
+}
+
+// SetRawOriginal points the image's original at the JPEG converted from its RAW upload
+// and keeps the RAW file's URL as its archival original.
+func (r *DefaultImageRepository) SetRawOriginal(ctx context.Context, imageID string, originalURL, rawURL string) error {
+	if originalURL == "" || rawURL == "" {
+		return fmt.Errorf("originalURL and rawURL cannot be empty")
+	}
+	const q = `
+		UPDATE images
+		SET original_url = $2, raw_url = $3
+		WHERE id = $1::uuid;
+	`
+	err := dbretry.Do(ctx, "set image raw original", func(ctx context.Context) error {
+		_, err := r.db.ExecContext(ctx, q, imageID, originalURL, rawURL)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("update image raw original: %w", err)
+	}
+	return nil
+}
+
+// GetStatus returns the image's status.
+func (r *DefaultImageRepository) GetStatus(ctx context.Context, imageID string) (string, error) {
+	const q = `
+		SELECT status
+		FROM images
+		WHERE id = $1::uuid;
+	`
+	var status string
+	if err := r.db.QueryRowContext(ctx, q, imageID).Scan(&status); err != nil {
+		return "", fmt.Errorf("get image status: %w", err)
+	}
+	return status, nil
+}
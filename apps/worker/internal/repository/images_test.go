@@ -6,8 +6,11 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/worker/internal/dbretry"
 )
 
 func newMockRepo(t *testing.T) (*DefaultImageRepository, sqlmock.Sqlmock, func()) {
@@ -21,6 +24,15 @@ func newMockRepo(t *testing.T) (*DefaultImageRepository, sqlmock.Sqlmock, func()
 	return repo, mock, cleanup
 }
 
+// expectStatusSource expects the transaction a status update runs in to begin and
+// describe the change for the image_status_transitions trigger.
+func expectStatusSource(mock sqlmock.Sqlmock, jobID, attempt string) {
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT set_config('app.status_source', $1, true)")).
+		WithArgs("real-staging-worker", jobID, attempt).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
 func TestDefaultImageRepository_SetProcessing_Success(t *testing.T) {
 	repo, mock, cleanup := newMockRepo(t)
 	defer cleanup()
@@ -32,9 +44,29 @@ func TestDefaultImageRepository_SetProcessing_Success(t *testing.T) {
 	query := regexp.QuoteMeta(
 		"UPDATE images SET status = 'processing', updated_at = now() " +
 			"WHERE id = $1::uuid AND status IN ('queued','processing');")
+	expectStatusSource(mock, "", "")
 	mock.ExpectExec(query).
 		WithArgs(imageID).
 		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.SetProcessing(ctx, imageID)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDefaultImageRepository_SetProcessing_RecordsJob(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	ctx := WithJob(context.Background(), "7f8d7a64-1c7e-4d43-9a5d-2f3c4b5a6e7d", 2)
+	imageID := "2e1aa86e-0f27-4f0f-9e57-0d7b53b4d9b9"
+
+	expectStatusSource(mock, "7f8d7a64-1c7e-4d43-9a5d-2f3c4b5a6e7d", "2")
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE images SET status = 'processing'")).
+		WithArgs(imageID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
 	err := repo.SetProcessing(ctx, imageID)
 	assert.NoError(t, err)
@@ -51,9 +83,11 @@ func TestDefaultImageRepository_SetProcessing_DBError(t *testing.T) {
 	query := regexp.QuoteMeta(
 		"UPDATE images SET status = 'processing', updated_at = now() " +
 			"WHERE id = $1::uuid AND status IN ('queued','processing');")
+	expectStatusSource(mock, "", "")
 	mock.ExpectExec(query).
 		WithArgs(imageID).
 		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
 
 	err := repo.SetProcessing(ctx, imageID)
 	assert.Error(t, err)
@@ -72,9 +106,11 @@ func TestDefaultImageRepository_SetReady_Success(t *testing.T) {
 	query := regexp.QuoteMeta(
 		"UPDATE images SET staged_url = $2, status = 'ready', updated_at = now() " +
 			"WHERE id = $1::uuid AND status IN ('queued','processing');")
+	expectStatusSource(mock, "", "")
 	mock.ExpectExec(query).
 		WithArgs(imageID, stagedURL).
 		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
 	err := repo.SetReady(ctx, imageID, stagedURL)
 	assert.NoError(t, err)
@@ -106,9 +142,11 @@ func TestDefaultImageRepository_SetReady_DBError(t *testing.T) {
 	query := regexp.QuoteMeta(
 		"UPDATE images SET staged_url = $2, status = 'ready', updated_at = now() " +
 			"WHERE id = $1::uuid AND status IN ('queued','processing');")
+	expectStatusSource(mock, "", "")
 	mock.ExpectExec(query).
 		WithArgs(imageID, stagedURL).
 		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
 
 	err := repo.SetReady(ctx, imageID, stagedURL)
 	assert.Error(t, err)
@@ -116,6 +154,37 @@ func TestDefaultImageRepository_SetReady_DBError(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestDefaultImageRepository_SetReady_RetriesTransientError(t *testing.T) {
+	prev := dbretry.DefaultPolicy
+	dbretry.DefaultPolicy = dbretry.Policy{Attempts: 3}
+	t.Cleanup(func() { dbretry.DefaultPolicy = prev })
+
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	imageID := "b5a4b7a1-3584-4b09-9b6a-6a6e1f2d9e90"
+	stagedURL := "https://example.com/image-staged.jpg"
+
+	query := regexp.QuoteMeta(
+		"UPDATE images SET staged_url = $2, status = 'ready', updated_at = now() " +
+			"WHERE id = $1::uuid AND status IN ('queued','processing');")
+	expectStatusSource(mock, "", "")
+	mock.ExpectExec(query).
+		WithArgs(imageID, stagedURL).
+		WillReturnError(&pq.Error{Code: "57P01"})
+	mock.ExpectRollback()
+	expectStatusSource(mock, "", "")
+	mock.ExpectExec(query).
+		WithArgs(imageID, stagedURL).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.SetReady(ctx, imageID, stagedURL)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestDefaultImageRepository_SetError_Success(t *testing.T) {
 	repo, mock, cleanup := newMockRepo(t)
 	defer cleanup()
@@ -127,9 +196,11 @@ func TestDefaultImageRepository_SetError_Success(t *testing.T) {
 	query := regexp.QuoteMeta(
 		"UPDATE images SET status = 'error', error = $2, updated_at = now() " +
 			"WHERE id = $1::uuid AND status IN ('queued','processing');")
+	expectStatusSource(mock, "", "")
 	mock.ExpectExec(query).
 		WithArgs(imageID, errMsg).
 		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
 	err := repo.SetError(ctx, imageID, errMsg)
 	assert.NoError(t, err)
@@ -161,12 +232,215 @@ func TestDefaultImageRepository_SetError_DBError(t *testing.T) {
 	query := regexp.QuoteMeta(
 		"UPDATE images SET status = 'error', error = $2, updated_at = now() " +
 			"WHERE id = $1::uuid AND status IN ('queued','processing');")
+	expectStatusSource(mock, "", "")
 	mock.ExpectExec(query).
 		WithArgs(imageID, errMsg).
 		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
 
 	err := repo.SetError(ctx, imageID, errMsg)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "update image with error")
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestDefaultImageRepository_SetSizes_Success(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	imageID := "5b0f9a44-6c1e-4f3d-9a3b-2f1b0c7d8e9f"
+
+	query := regexp.QuoteMeta(
+		"UPDATE images SET original_size_bytes = COALESCE(NULLIF($2::bigint, 0), original_size_bytes), " +
+			"staged_size_bytes = COALESCE(NULLIF($3::bigint, 0), staged_size_bytes) " +
+			"WHERE id = $1::uuid;")
+	mock.ExpectExec(query).
+		WithArgs(imageID, int64(2048), int64(0)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.SetSizes(ctx, imageID, 2048, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDefaultImageRepository_SetSizes_DBError(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	imageID := "5b0f9a44-6c1e-4f3d-9a3b-2f1b0c7d8e9f"
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE images SET original_size_bytes")).
+		WithArgs(imageID, int64(2048), int64(4096)).
+		WillReturnError(assert.AnError)
+
+	err := repo.SetSizes(ctx, imageID, 2048, 4096)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "update image sizes")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDefaultImageRepository_SetPreview_Success(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	imageID := "5b0f9a44-6c1e-4f3d-9a3b-2f1b0c7d8e9f"
+	previewURL := "s3://bucket/staged/5b0f9a44/preview.jpg"
+
+	query := regexp.QuoteMeta("UPDATE images SET preview_url = $2 WHERE id = $1::uuid AND status = 'processing';")
+	mock.ExpectExec(query).
+		WithArgs(imageID, previewURL).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.SetPreview(ctx, imageID, previewURL)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDefaultImageRepository_SetPreview_EmptyURL(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	err := repo.SetPreview(context.Background(), "5b0f9a44-6c1e-4f3d-9a3b-2f1b0c7d8e9f", "")
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDefaultImageRepository_SetPreview_DBError(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	imageID := "5b0f9a44-6c1e-4f3d-9a3b-2f1b0c7d8e9f"
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE images SET preview_url")).
+		WithArgs(imageID, "s3://bucket/preview.jpg").
+		WillReturnError(assert.AnError)
+
+	err := repo.SetPreview(ctx, imageID, "s3://bucket/preview.jpg")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "update image preview")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDefaultImageRepository_SetWatermarked(t *testing.T) {
+	imageID := "5b0f9a44-6c1e-4f3d-9a3b-2f1b0c7d8e9f"
+
+	testCases := []struct {
+		name    string
+		url     string
+		dbErr   error
+		wantErr bool
+	}{
+		{name: "success: records the copy", url: "s3://bucket/staged/5b0f9a44/watermarked.jpg"},
+		{name: "success: empty URL clears the copy"},
+		{name: "fail: db error", url: "s3://bucket/watermarked.jpg", dbErr: assert.AnError, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, mock, cleanup := newMockRepo(t)
+			defer cleanup()
+
+			exec := mock.ExpectExec(regexp.QuoteMeta("UPDATE images SET watermarked_url = NULLIF($2, '') WHERE id = $1::uuid;")).
+				WithArgs(imageID, tc.url)
+			if tc.dbErr != nil {
+				exec.WillReturnError(tc.dbErr)
+			} else {
+				exec.WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			err := repo.SetWatermarked(context.Background(), imageID, tc.url)
+			if tc.wantErr {
+				assert.ErrorContains(t, err, "update image watermarked copy")
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDefaultImageRepository_SetRawOriginal(t *testing.T) {
+	imageID := "5b0f9a44-6c1e-4f3d-9a3b-2f1b0c7d8e9f"
+	const (
+		originalURL = "s3://bucket/uploads/u1/IMG_0001.jpg"
+		rawURL      = "s3://bucket/uploads/u1/IMG_0001.CR3"
+	)
+
+	testCases := []struct {
+		name        string
+		originalURL string
+		rawURL      string
+		dbErr       error
+		wantErr     string
+	}{
+		{name: "success: records the conversion", originalURL: originalURL, rawURL: rawURL},
+		{name: "fail: empty URL", originalURL: originalURL, wantErr: "cannot be empty"},
+		{
+			name: "fail: db error", originalURL: originalURL, rawURL: rawURL,
+			dbErr: assert.AnError, wantErr: "update image raw original",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, mock, cleanup := newMockRepo(t)
+			defer cleanup()
+
+			if tc.rawURL != "" {
+				exec := mock.ExpectExec(regexp.QuoteMeta("UPDATE images SET original_url = $2, raw_url = $3 WHERE id = $1::uuid;")).
+					WithArgs(imageID, tc.originalURL, tc.rawURL)
+				if tc.dbErr != nil {
+					exec.WillReturnError(tc.dbErr)
+				} else {
+					exec.WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+			}
+
+			err := repo.SetRawOriginal(context.Background(), imageID, tc.originalURL, tc.rawURL)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDefaultImageRepository_GetStatus_Success(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	imageID := "5b0f9a44-6c1e-4f3d-9a3b-2f1b0c7d8e9f"
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT status FROM images WHERE id = $1::uuid;")).
+		WithArgs(imageID).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("ready"))
+
+	status, err := repo.GetStatus(ctx, imageID)
+	assert.NoError(t, err)
+	assert.Equal(t, "ready", status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDefaultImageRepository_GetStatus_DBError(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	imageID := "5b0f9a44-6c1e-4f3d-9a3b-2f1b0c7d8e9f"
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT status FROM images")).
+		WithArgs(imageID).
+		WillReturnError(assert.AnError)
+
+	_, err := repo.GetStatus(ctx, imageID)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "get image status")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
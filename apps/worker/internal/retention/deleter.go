@@ -0,0 +1,63 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/real-staging-ai/worker/internal/config"
+	"github.com/real-staging-ai/worker/internal/s3client"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out deleter_mock.go . ObjectDeleter
+
+// ObjectDeleter deletes stored originals by URL.
+type ObjectDeleter interface {
+	Delete(ctx context.Context, objectURL string) error
+}
+
+// S3Deleter deletes originals from the platform's buckets: the uploads bucket and the
+// data-residency region buckets.
+type S3Deleter struct {
+	buckets *s3client.Buckets
+}
+
+// Ensure S3Deleter implements ObjectDeleter.
+var _ ObjectDeleter = (*S3Deleter)(nil)
+
+// NewS3Deleter creates an S3Deleter for the configured uploads and region buckets.
+func NewS3Deleter(ctx context.Context, cfg *config.Config) (*S3Deleter, error) {
+	bucket := cfg.S3Bucket()
+	if bucket == "" {
+		return nil, fmt.Errorf("s3 bucket name is required")
+	}
+	client, err := s3client.New(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &S3Deleter{buckets: s3client.NewBuckets(bucket, client, cfg.S3.RegionBuckets)}, nil
+}
+
+// Delete removes the object behind objectURL from the bucket it names. Deleting a missing
+// object succeeds. URLs naming another bucket, such as one a customer since removed from
+// their settings, are refused rather than resolved against the uploads bucket.
+func (d *S3Deleter) Delete(ctx context.Context, objectURL string) error {
+	bucket, client, ok := d.buckets.BucketOf(objectURL)
+	if !ok {
+		return fmt.Errorf("refusing to delete from bucket %s: not a platform bucket", bucket)
+	}
+	key, err := s3client.KeyIn(objectURL, bucket)
+	if err != nil {
+		return err
+	}
+	_, err = client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("delete s3://%s/%s: %w", bucket, key, err)
+	}
+	return nil
+}
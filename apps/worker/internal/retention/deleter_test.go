@@ -0,0 +1,24 @@
+package retention
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/real-staging-ai/worker/internal/s3client"
+)
+
+func TestS3Deleter_Delete_OtherBucket(t *testing.T) {
+	client := s3.New(s3.Options{Region: "us-west-1"})
+	d := &S3Deleter{buckets: s3client.NewBuckets("real-staging", client, map[string]string{"eu-central-1": "real-staging-eu"})}
+
+	for _, url := range []string{
+		"s3://acme-photos/uploads/u1/a.jpg",
+		"https://acme-photos.s3.eu-west-1.amazonaws.com/uploads/u1/a.jpg",
+	} {
+		err := d.Delete(context.Background(), url)
+		assert.ErrorContains(t, err, "refusing to delete from bucket acme-photos")
+	}
+}
@@ -0,0 +1,209 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/mailer"
+	"github.com/real-staging-ai/worker/internal/schedule"
+)
+
+// Config controls a purge run.
+type Config struct {
+	// WarningDays is the minimum time between the warning email and deletion.
+	WarningDays int
+	// BatchSize is the number of candidates read per query.
+	BatchSize int
+}
+
+// Result summarizes a purge run.
+type Result struct {
+	Warned int
+	Purged int
+	Failed int
+}
+
+// Purger warns owners about expiring originals and deletes them once the warning period has passed.
+type Purger struct {
+	repo    Repository
+	deleter ObjectDeleter
+	mail    mailer.Mailer
+	cfg     Config
+	log     logging.Logger
+	now     func() time.Time
+}
+
+// NewPurger creates a Purger.
+func NewPurger(repo Repository, deleter ObjectDeleter, mail mailer.Mailer, cfg Config, log logging.Logger) *Purger {
+	if cfg.WarningDays <= 0 {
+		cfg.WarningDays = 7
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 500
+	}
+	return &Purger{repo: repo, deleter: deleter, mail: mail, cfg: cfg, log: log, now: time.Now}
+}
+
+// ErrLocked is returned by Run when another worker is already running the purge.
+var ErrLocked = errors.New("retention purge already running")
+
+// Run purges originals whose warning period has passed, then warns owners of
+// originals expiring within the warning period. Purging first means an image is
+// never warned about and deleted in the same run.
+func (p *Purger) Run(ctx context.Context) (Result, error) {
+	var res Result
+	now := p.now().UTC()
+
+	unlock, ok, err := p.repo.TryLock(ctx)
+	if err != nil {
+		return res, err
+	}
+	if !ok {
+		return res, ErrLocked
+	}
+	defer unlock()
+
+	defaultDays, err := p.repo.DefaultOriginalRetentionDays(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	if err := p.purge(ctx, defaultDays, now, &res); err != nil {
+		return res, err
+	}
+	if err := p.warn(ctx, defaultDays, now, &res); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+func (p *Purger) purge(ctx context.Context, defaultDays *int, now time.Time, res *Result) error {
+	afterID := ""
+	for {
+		batch, err := p.repo.ListDueForPurge(ctx, defaultDays, now, afterID, p.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+		for _, c := range batch {
+			if err := p.deleter.Delete(ctx, c.OriginalURL); err != nil {
+				p.log.Error(ctx, "Failed to delete original", "image_id", c.ImageID, "error", err)
+				res.Failed++
+				continue
+			}
+			if err := p.repo.MarkPurged(ctx, c.ImageID, now); err != nil {
+				p.log.Error(ctx, "Failed to record purged original", "image_id", c.ImageID, "error", err)
+				res.Failed++
+				continue
+			}
+			res.Purged++
+		}
+		if len(batch) < p.cfg.BatchSize {
+			return nil
+		}
+		afterID = batch[len(batch)-1].ImageID
+	}
+}
+
+func (p *Purger) warn(ctx context.Context, defaultDays *int, now time.Time, res *Result) error {
+	purgeAfter := now.AddDate(0, 0, p.cfg.WarningDays)
+
+	// Collect every candidate first so each user gets a single email.
+	byUser := map[string][]Candidate{}
+	afterID := ""
+	for {
+		batch, err := p.repo.ListDueForWarning(ctx, defaultDays, purgeAfter, afterID, p.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+		for _, c := range batch {
+			byUser[c.UserID] = append(byUser[c.UserID], c)
+		}
+		if len(batch) < p.cfg.BatchSize {
+			break
+		}
+		afterID = batch[len(batch)-1].ImageID
+	}
+
+	userIDs := make([]string, 0, len(byUser))
+	for id := range byUser {
+		userIDs = append(userIDs, id)
+	}
+	sort.Strings(userIDs)
+
+	for _, userID := range userIDs {
+		candidates := byUser[userID]
+		if email := candidates[0].Email; email != nil {
+			if err := p.mail.Send(ctx, warningMessage(*email, candidates, purgeAfter)); err != nil {
+				// Not marked as warned, so the next run retries the email.
+				p.log.Error(ctx, "Failed to send retention warning", "user_id", userID, "error", err)
+				res.Failed += len(candidates)
+				continue
+			}
+		} else {
+			p.log.Warn(ctx, "User has no email; recording retention warning without sending", "user_id", userID, "images", len(candidates))
+		}
+
+		ids := make([]string, len(candidates))
+		for i, c := range candidates {
+			ids[i] = c.ImageID
+		}
+		if err := p.repo.MarkWarned(ctx, ids, now, purgeAfter); err != nil {
+			p.log.Error(ctx, "Failed to record retention warning", "user_id", userID, "error", err)
+			res.Failed += len(candidates)
+			continue
+		}
+		res.Warned += len(candidates)
+	}
+	return nil
+}
+
+// warningMessage builds the pre-deletion email for one user.
+func warningMessage(to string, candidates []Candidate, purgeAfter time.Time) mailer.Message {
+	counts := map[string]int{}
+	names := map[string]string{}
+	for _, c := range candidates {
+		counts[c.ProjectID]++
+		names[c.ProjectID] = c.ProjectName
+	}
+	projectIDs := make([]string, 0, len(counts))
+	for id := range counts {
+		projectIDs = append(projectIDs, id)
+	}
+	sort.Slice(projectIDs, func(i, j int) bool { return names[projectIDs[i]] < names[projectIDs[j]] })
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d original upload(s) will reach the end of your plan's retention period and be deleted on or after %s.\n\n",
+		len(candidates), purgeAfter.Format("January 2, 2006"))
+	for _, id := range projectIDs {
+		fmt.Fprintf(&b, "- %s: %d image(s)\n", names[id], counts[id])
+	}
+	b.WriteString("\nStaged images are not affected. To keep these originals, download them, ")
+	b.WriteString("upgrade your plan, or exclude the project from retention in its settings.\n")
+
+	return mailer.Message{
+		To:      to,
+		Subject: "Your original uploads will be deleted soon",
+		Body:    b.String(),
+	}
+}
+
+// RunDaily blocks until ctx is done, running the purge each day at hourUTC.
+func (p *Purger) RunDaily(ctx context.Context, hourUTC int) {
+	schedule.Daily(ctx, hourUTC, func(ctx context.Context, _ time.Time) {
+		res, err := p.Run(ctx)
+		if errors.Is(err, ErrLocked) {
+			p.log.Info(ctx, "Retention purge skipped; another worker is running it")
+			return
+		}
+		if err != nil {
+			p.log.Error(ctx, "Retention purge failed", "error", err, "warned", res.Warned, "purged", res.Purged)
+			return
+		}
+		p.log.Info(ctx, "Retention purge completed", "warned", res.Warned, "purged", res.Purged, "failed", res.Failed)
+	})
+}
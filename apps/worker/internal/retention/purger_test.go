@@ -0,0 +1,155 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/mailer"
+)
+
+func newLogger() *logging.LoggerMock {
+	return &logging.LoggerMock{
+		InfoFunc:  func(ctx context.Context, msg string, keysAndValues ...any) {},
+		WarnFunc:  func(ctx context.Context, msg string, keysAndValues ...any) {},
+		ErrorFunc: func(ctx context.Context, msg string, keysAndValues ...any) {},
+	}
+}
+
+func strPtr(s string) *string { return &s }
+
+func newRepo(purge, warn []Candidate) *RepositoryMock {
+	days := 90
+	return &RepositoryMock{
+		TryLockFunc: func(ctx context.Context) (func(), bool, error) {
+			return func() {}, true, nil
+		},
+		DefaultOriginalRetentionDaysFunc: func(ctx context.Context) (*int, error) {
+			return &days, nil
+		},
+		ListDueForPurgeFunc: func(ctx context.Context, defaultDays *int, now time.Time, afterID string, limit int) ([]Candidate, error) {
+			if afterID != "" {
+				return nil, nil
+			}
+			return purge, nil
+		},
+		ListDueForWarningFunc: func(ctx context.Context, defaultDays *int, horizon time.Time, afterID string, limit int) ([]Candidate, error) {
+			if afterID != "" {
+				return nil, nil
+			}
+			return warn, nil
+		},
+		MarkWarnedFunc: func(ctx context.Context, imageIDs []string, warnedAt, purgeAfter time.Time) error {
+			return nil
+		},
+		MarkPurgedFunc: func(ctx context.Context, imageID string, purgedAt time.Time) error {
+			return nil
+		},
+	}
+}
+
+func TestPurger_Run(t *testing.T) {
+	now := time.Date(2025, 3, 1, 2, 0, 0, 0, time.UTC)
+
+	t.Run("success: purges due originals and warns each user once", func(t *testing.T) {
+		repo := newRepo(
+			[]Candidate{{ImageID: "img-old", OriginalURL: "s3://bucket/uploads/old.jpg"}},
+			[]Candidate{
+				{ImageID: "img-1", ProjectID: "p1", ProjectName: "Maple St", UserID: "u1", Email: strPtr("a@example.com")},
+				{ImageID: "img-2", ProjectID: "p1", ProjectName: "Maple St", UserID: "u1", Email: strPtr("a@example.com")},
+				{ImageID: "img-3", ProjectID: "p2", ProjectName: "Oak Ave", UserID: "u2"},
+			},
+		)
+		deleter := &ObjectDeleterMock{DeleteFunc: func(ctx context.Context, objectURL string) error { return nil }}
+		mail := &mailer.MailerMock{SendFunc: func(ctx context.Context, msg mailer.Message) error { return nil }}
+
+		p := NewPurger(repo, deleter, mail, Config{WarningDays: 7, BatchSize: 10}, newLogger())
+		p.now = func() time.Time { return now }
+
+		res, err := p.Run(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, Result{Warned: 3, Purged: 1}, res)
+
+		require.Len(t, deleter.DeleteCalls(), 1)
+		assert.Equal(t, "s3://bucket/uploads/old.jpg", deleter.DeleteCalls()[0].ObjectURL)
+		require.Len(t, repo.MarkPurgedCalls(), 1)
+		assert.Equal(t, "img-old", repo.MarkPurgedCalls()[0].ImageID)
+
+		// Only u1 has an email; u2 is still marked as warned.
+		require.Len(t, mail.SendCalls(), 1)
+		msg := mail.SendCalls()[0].Msg
+		assert.Equal(t, "a@example.com", msg.To)
+		assert.Contains(t, msg.Body, "Maple St: 2 image(s)")
+		assert.Contains(t, msg.Body, "March 8, 2025")
+
+		require.Len(t, repo.MarkWarnedCalls(), 2)
+		assert.Equal(t, []string{"img-1", "img-2"}, repo.MarkWarnedCalls()[0].ImageIDs)
+		assert.Equal(t, []string{"img-3"}, repo.MarkWarnedCalls()[1].ImageIDs)
+		assert.Equal(t, now.AddDate(0, 0, 7), repo.MarkWarnedCalls()[0].PurgeAfter)
+		assert.Equal(t, now.AddDate(0, 0, 7), repo.ListDueForWarningCalls()[0].Horizon)
+	})
+
+	t.Run("success: pages through batches", func(t *testing.T) {
+		repo := newRepo(nil, nil)
+		repo.ListDueForPurgeFunc = func(ctx context.Context, defaultDays *int, now time.Time, afterID string, limit int) ([]Candidate, error) {
+			switch afterID {
+			case "":
+				return []Candidate{{ImageID: "a"}, {ImageID: "b"}}, nil
+			case "b":
+				return []Candidate{{ImageID: "c"}}, nil
+			}
+			t.Fatalf("unexpected afterID %q", afterID)
+			return nil, nil
+		}
+		deleter := &ObjectDeleterMock{DeleteFunc: func(ctx context.Context, objectURL string) error { return nil }}
+
+		p := NewPurger(repo, deleter, &mailer.MailerMock{}, Config{BatchSize: 2}, newLogger())
+		res, err := p.Run(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 3, res.Purged)
+		assert.Len(t, repo.ListDueForPurgeCalls(), 2)
+	})
+
+	t.Run("success: failed deletes and emails are counted and not recorded", func(t *testing.T) {
+		repo := newRepo(
+			[]Candidate{{ImageID: "img-old"}},
+			[]Candidate{{ImageID: "img-1", UserID: "u1", Email: strPtr("a@example.com")}},
+		)
+		deleter := &ObjectDeleterMock{DeleteFunc: func(ctx context.Context, objectURL string) error { return errors.New("denied") }}
+		mail := &mailer.MailerMock{SendFunc: func(ctx context.Context, msg mailer.Message) error { return errors.New("smtp down") }}
+
+		p := NewPurger(repo, deleter, mail, Config{}, newLogger())
+		res, err := p.Run(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, Result{Failed: 2}, res)
+		assert.Empty(t, repo.MarkPurgedCalls())
+		assert.Empty(t, repo.MarkWarnedCalls())
+	})
+
+	t.Run("fail: another worker holds the lock", func(t *testing.T) {
+		repo := newRepo(nil, nil)
+		repo.TryLockFunc = func(ctx context.Context) (func(), bool, error) { return nil, false, nil }
+
+		p := NewPurger(repo, &ObjectDeleterMock{}, &mailer.MailerMock{}, Config{}, newLogger())
+		_, err := p.Run(context.Background())
+		assert.ErrorIs(t, err, ErrLocked)
+		assert.Empty(t, repo.ListDueForPurgeCalls())
+	})
+
+	t.Run("fail: listing error", func(t *testing.T) {
+		repo := newRepo(nil, nil)
+		repo.ListDueForPurgeFunc = func(ctx context.Context, defaultDays *int, now time.Time, afterID string, limit int) ([]Candidate, error) {
+			return nil, errors.New("db down")
+		}
+
+		p := NewPurger(repo, &ObjectDeleterMock{}, &mailer.MailerMock{}, Config{}, newLogger())
+		_, err := p.Run(context.Background())
+		assert.ErrorContains(t, err, "db down")
+		assert.Empty(t, repo.ListDueForWarningCalls())
+	})
+}
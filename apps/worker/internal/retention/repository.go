@@ -0,0 +1,257 @@
+// Package retention purges original uploads once they outlive their owner's
+// plan retention, warning owners by email before anything is deleted.
+package retention
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/real-staging-ai/worker/internal/dbretry"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// DefaultDaysSettingKey is the settings key holding retention for users without an active subscription.
+const DefaultDaysSettingKey = "retention_default_original_days"
+
+// Candidate is an original upload that is due for a warning or purge.
+type Candidate struct {
+	ImageID     string
+	ProjectID   string
+	ProjectName string
+	UserID      string
+	Email       *string
+	OriginalURL string
+	ExpiresAt   time.Time
+}
+
+// Repository reads retention state and records warnings and purges.
+type Repository interface {
+	// DefaultOriginalRetentionDays returns retention for users without an active
+	// subscription, or nil when their originals are kept forever.
+	DefaultOriginalRetentionDays(ctx context.Context) (*int, error)
+	// ListDueForWarning lists unwarned originals that expire at or before horizon, ordered by image ID after afterID.
+	ListDueForWarning(ctx context.Context, defaultDays *int, horizon time.Time, afterID string, limit int) ([]Candidate, error)
+	// ListDueForPurge lists warned originals whose purge_after has passed and that are still expired, ordered by image ID after afterID.
+	ListDueForPurge(ctx context.Context, defaultDays *int, now time.Time, afterID string, limit int) ([]Candidate, error)
+	// MarkWarned records that the owners of imageIDs were warned and when the originals may be purged.
+	MarkWarned(ctx context.Context, imageIDs []string, warnedAt, purgeAfter time.Time) error
+	// MarkPurged records that an image's original was deleted.
+	MarkPurged(ctx context.Context, imageID string, purgedAt time.Time) error
+	// TryLock takes a session-level lock so only one worker runs the purge at a time.
+	// ok is false when another worker holds it. unlock must be called when ok is true.
+	TryLock(ctx context.Context) (unlock func(), ok bool, err error)
+}
+
+// DefaultRepository is a sql.DB-backed Repository.
+type DefaultRepository struct {
+	db *sql.DB
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewRepository creates a DefaultRepository.
+func NewRepository(db *sql.DB) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+// advisoryLockKey identifies the purge job's Postgres advisory lock.
+const advisoryLockKey = 0x7265746e // "retn"
+
+// firstID sorts before every UUID and starts keyset pagination.
+const firstID = "00000000-0000-0000-0000-000000000000"
+
+// retentionCTE resolves each image's effective retention in days. Users with an
+// active subscription get their plan's retention (NULL when the plan keeps
+// originals forever or is unknown); everyone else gets the default in $1.
+// Exempt projects, legal holds, images still being processed, and originals in a
+// customer's own bucket (their retention is the customer's business) are excluded.
+const retentionCTE = `
+	WITH active_subscriptions AS (
+		SELECT DISTINCT ON (user_id) user_id, price_id
+		FROM subscriptions
+		WHERE status IN ('active', 'trialing', 'past_due')
+		ORDER BY user_id, updated_at DESC
+	),
+	retained AS (
+		SELECT i.id, i.project_id, p.name AS project_name, p.user_id, u.email, i.original_url,
+			i.created_at + make_interval(days => CASE WHEN s.user_id IS NULL THEN $1::int ELSE pl.original_retention_days END) AS expires_at
+		FROM images i
+		JOIN projects p ON p.id = i.project_id
+		JOIN users u ON u.id = p.user_id
+		LEFT JOIN active_subscriptions s ON s.user_id = p.user_id
+		LEFT JOIN plans pl ON pl.price_id = s.price_id
+		WHERE i.status NOT IN ('queued', 'processing')
+			AND NOT EXISTS (SELECT 1 FROM project_retention_exemptions e WHERE e.project_id = i.project_id)
+			AND NOT EXISTS (
+				SELECT 1 FROM legal_holds lh WHERE lh.image_id = i.id OR lh.project_id = i.project_id
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM customer_buckets cb
+				WHERE i.original_url LIKE 's3://' || cb.bucket || '/%'
+					OR i.original_url LIKE 'https://' || cb.bucket || '.s3.%'
+			)
+	)`
+
+// DefaultOriginalRetentionDays reads the default retention setting.
+func (r *DefaultRepository) DefaultOriginalRetentionDays(ctx context.Context) (*int, error) {
+	var value string
+	err := r.db.QueryRowContext(ctx, `SELECT value FROM settings WHERE key = $1`, DefaultDaysSettingKey).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get %s setting: %w", DefaultDaysSettingKey, err)
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		return nil, fmt.Errorf("invalid %s setting %q", DefaultDaysSettingKey, value)
+	}
+	if days == 0 {
+		return nil, nil
+	}
+	return &days, nil
+}
+
+// ListDueForWarning lists originals that expire by horizon and have not been warned about.
+func (r *DefaultRepository) ListDueForWarning(
+	ctx context.Context, defaultDays *int, horizon time.Time, afterID string, limit int,
+) ([]Candidate, error) {
+	q := retentionCTE + `
+		SELECT r.id, r.project_id, r.project_name, r.user_id, r.email, r.original_url, r.expires_at
+		FROM retained r
+		LEFT JOIN image_original_purges ip ON ip.image_id = r.id
+		WHERE ip.image_id IS NULL
+			AND r.expires_at <= $2
+			AND r.id > $3::uuid
+		ORDER BY r.id
+		LIMIT $4;
+	`
+	return r.list(ctx, q, defaultDays, horizon, afterID, limit)
+}
+
+// ListDueForPurge lists warned originals that may now be deleted. Images whose
+// retention was extended after the warning (plan upgrade or project exemption)
+// are no longer expired and are skipped.
+func (r *DefaultRepository) ListDueForPurge(
+	ctx context.Context, defaultDays *int, now time.Time, afterID string, limit int,
+) ([]Candidate, error) {
+	q := retentionCTE + `
+		SELECT r.id, r.project_id, r.project_name, r.user_id, r.email, r.original_url, r.expires_at
+		FROM retained r
+		JOIN image_original_purges ip ON ip.image_id = r.id
+		WHERE ip.purged_at IS NULL
+			AND ip.purge_after <= $2
+			AND r.expires_at <= $2
+			AND r.id > $3::uuid
+		ORDER BY r.id
+		LIMIT $4;
+	`
+	return r.list(ctx, q, defaultDays, now, afterID, limit)
+}
+
+func (r *DefaultRepository) list(
+	ctx context.Context, q string, defaultDays *int, at time.Time, afterID string, limit int,
+) ([]Candidate, error) {
+	if afterID == "" {
+		afterID = firstID
+	}
+	var days any
+	if defaultDays != nil {
+		days = int64(*defaultDays)
+	}
+
+	rows, err := r.db.QueryContext(ctx, q, days, at, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list retention candidates: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []Candidate
+	for rows.Next() {
+		var c Candidate
+		var email sql.NullString
+		if err := rows.Scan(&c.ImageID, &c.ProjectID, &c.ProjectName, &c.UserID, &email, &c.OriginalURL, &c.ExpiresAt); err != nil {
+			return nil, fmt.Errorf("scan retention candidate: %w", err)
+		}
+		if email.Valid && email.String != "" {
+			c.Email = &email.String
+		}
+		out = append(out, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate retention candidates: %w", err)
+	}
+	return out, nil
+}
+
+// MarkWarned records warnings for imageIDs. Existing rows are left untouched so
+// a re-run never moves purge_after later than what the owner was told.
+func (r *DefaultRepository) MarkWarned(ctx context.Context, imageIDs []string, warnedAt, purgeAfter time.Time) error {
+	if len(imageIDs) == 0 {
+		return nil
+	}
+	const q = `
+		INSERT INTO image_original_purges (image_id, warned_at, purge_after)
+		SELECT id, $2, $3 FROM unnest($1::uuid[]) AS id
+		ON CONFLICT (image_id) DO NOTHING;
+	`
+	err := dbretry.Do(ctx, "mark retention warned", func(ctx context.Context) error {
+		_, err := r.db.ExecContext(ctx, q, pq.Array(imageIDs), warnedAt, purgeAfter)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("mark retention warned: %w", err)
+	}
+	return nil
+}
+
+// MarkPurged records that imageID's original was deleted.
+func (r *DefaultRepository) MarkPurged(ctx context.Context, imageID string, purgedAt time.Time) error {
+	const q = `UPDATE image_original_purges SET purged_at = $2 WHERE image_id = $1::uuid;`
+	err := dbretry.Do(ctx, "mark original purged", func(ctx context.Context) error {
+		_, err := r.db.ExecContext(ctx, q, imageID, purgedAt)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("mark original purged: %w", err)
+	}
+	return nil
+}
+
+// TryLock takes a Postgres advisory lock on a dedicated connection.
+func (r *DefaultRepository) TryLock(ctx context.Context) (func(), bool, error) {
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("get connection for retention lock: %w", err)
+	}
+
+	var ok bool
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, advisoryLockKey).Scan(&ok); err != nil {
+		_ = conn.Close()
+		return nil, false, fmt.Errorf("acquire retention lock: %w", err)
+	}
+	if !ok {
+		_ = conn.Close()
+		return nil, false, nil
+	}
+
+	unlock := func() {
+		_, _ = conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, advisoryLockKey)
+		_ = conn.Close()
+	}
+	return unlock, true, nil
+}
@@ -0,0 +1,135 @@
+package retention
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultRepository_DefaultOriginalRetentionDays(t *testing.T) {
+	query := regexp.QuoteMeta(`SELECT value FROM settings WHERE key = $1`)
+
+	testCases := []struct {
+		name    string
+		setup   func(mock sqlmock.Sqlmock)
+		want    *int
+		wantErr bool
+	}{
+		{
+			name: "success: days",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(DefaultDaysSettingKey).
+					WillReturnRows(sqlmock.NewRows([]string{"value"}).AddRow(" 30 "))
+			},
+			want: func() *int { d := 30; return &d }(),
+		},
+		{
+			name: "success: zero keeps forever",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(DefaultDaysSettingKey).
+					WillReturnRows(sqlmock.NewRows([]string{"value"}).AddRow("0"))
+			},
+		},
+		{
+			name: "success: empty keeps forever",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(DefaultDaysSettingKey).
+					WillReturnRows(sqlmock.NewRows([]string{"value"}).AddRow(""))
+			},
+		},
+		{
+			name: "success: missing keeps forever",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(DefaultDaysSettingKey).WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name: "fail: invalid value",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(DefaultDaysSettingKey).
+					WillReturnRows(sqlmock.NewRows([]string{"value"}).AddRow("ninety"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+			tc.setup(mock)
+
+			got, err := NewRepository(db).DefaultOriginalRetentionDays(context.Background())
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDefaultRepository_ListDueForWarning(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	horizon := time.Date(2025, 3, 8, 2, 0, 0, 0, time.UTC)
+	expires := time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`FROM retained r\s+LEFT JOIN image_original_purges`).
+		WithArgs(int64(90), horizon, firstID, 100).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "project_id", "project_name", "user_id", "email", "original_url", "expires_at"}).
+			AddRow("img-1", "p1", "Maple St", "u1", "a@example.com", "s3://b/k1", expires).
+			AddRow("img-2", "p1", "Maple St", "u1", nil, "s3://b/k2", expires))
+
+	days := 90
+	got, err := NewRepository(db).ListDueForWarning(context.Background(), &days, horizon, "", 100)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "a@example.com", *got[0].Email)
+	assert.Nil(t, got[1].Email)
+	assert.Equal(t, expires, got[1].ExpiresAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDefaultRepository_ListDueForPurge(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	now := time.Date(2025, 3, 8, 2, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`FROM retained r\s+JOIN image_original_purges`).
+		WithArgs(nil, now, "img-1", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "project_id", "project_name", "user_id", "email", "original_url", "expires_at"}))
+
+	got, err := NewRepository(db).ListDueForPurge(context.Background(), nil, now, "img-1", 10)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDefaultRepository_MarkWarned(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	warnedAt := time.Date(2025, 3, 1, 2, 0, 0, 0, time.UTC)
+	purgeAfter := warnedAt.AddDate(0, 0, 7)
+	mock.ExpectExec(`INSERT INTO image_original_purges`).
+		WithArgs(sqlmock.AnyArg(), warnedAt, purgeAfter).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	repo := NewRepository(db)
+	require.NoError(t, repo.MarkWarned(context.Background(), []string{"img-1", "img-2"}, warnedAt, purgeAfter))
+	require.NoError(t, repo.MarkWarned(context.Background(), nil, warnedAt, purgeAfter))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
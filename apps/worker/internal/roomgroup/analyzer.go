@@ -0,0 +1,120 @@
+package roomgroup
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out analyzer_mock.go . Analyzer ImageReader
+
+// Analyzer groups a project's images into proposed rooms.
+type Analyzer interface {
+	// Analyze groups the images of the grouping's project and records the proposal on it.
+	Analyze(ctx context.Context, groupingID, projectID string) error
+}
+
+// ImageReader downloads an image by its s3:// URL.
+type ImageReader interface {
+	ReadImage(ctx context.Context, rawURL string) ([]byte, error)
+}
+
+// Analysis limits.
+const (
+	// MaxImages is how many of a project's images are grouped, oldest first.
+	MaxImages = 300
+	// MaxGroups matches the most rooms a project's checklist holds.
+	MaxGroups = 30
+	// DefaultThreshold is the Distance below which photos are taken to be of the same
+	// room, tuned on typical listing shoots.
+	DefaultThreshold = 0.3
+)
+
+// readParallelism caps how many images are downloaded and fingerprinted at once.
+var readParallelism = 4
+
+// DefaultAnalyzer implements Analyzer by fingerprinting and clustering the originals.
+type DefaultAnalyzer struct {
+	repo      Repository
+	images    ImageReader
+	threshold float64
+}
+
+// Ensure DefaultAnalyzer implements Analyzer.
+var _ Analyzer = (*DefaultAnalyzer)(nil)
+
+// NewDefaultAnalyzer creates a DefaultAnalyzer that reads originals with images.
+func NewDefaultAnalyzer(repo Repository, images ImageReader) *DefaultAnalyzer {
+	return &DefaultAnalyzer{repo: repo, images: images, threshold: DefaultThreshold}
+}
+
+// Analyze marks the grouping processing, groups up to MaxImages of the project's images and
+// marks it ready. Images that cannot be downloaded or decoded are left out and listed on
+// the grouping, unless no image could be read, which fails the attempt so the queue
+// retries it. Failures are recorded on the grouping; a retry marks it processing again.
+func (a *DefaultAnalyzer) Analyze(ctx context.Context, groupingID, projectID string) error {
+	if err := a.repo.MarkProcessing(ctx, groupingID); err != nil {
+		return err
+	}
+	groups, unreadable, err := a.group(ctx, projectID)
+	if err != nil {
+		if markErr := a.repo.MarkError(ctx, groupingID, err.Error()); markErr != nil {
+			logging.Default().Error(ctx, "Failed to mark room grouping as error", "grouping_id", groupingID, "error", markErr)
+		}
+		return err
+	}
+	return a.repo.MarkReady(ctx, groupingID, groups, unreadable)
+}
+
+// group fingerprints the project's images and clusters them.
+func (a *DefaultAnalyzer) group(ctx context.Context, projectID string) ([]Group, []string, error) {
+	images, err := a.repo.ListImages(ctx, projectID, MaxImages)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	photos := make([]*Photo, len(images))
+	errs := make([]error, len(images))
+	slots := make(chan struct{}, readParallelism)
+	var wg sync.WaitGroup
+	for i, img := range images {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			photos[i], errs[i] = a.fingerprint(ctx, img)
+		}()
+	}
+	wg.Wait()
+
+	var readable []Photo
+	unreadable := []string{}
+	for i, p := range photos {
+		if errs[i] != nil {
+			logging.Default().Warn(ctx, "Leaving image out of room grouping", "image_id", images[i].ID, "error", errs[i])
+			unreadable = append(unreadable, images[i].ID)
+			continue
+		}
+		readable = append(readable, *p)
+	}
+	if len(images) > 0 && len(readable) == 0 {
+		return nil, nil, fmt.Errorf("none of the project's %d images could be read: %w", len(images), errs[0])
+	}
+	return Groups(Cluster(readable, a.threshold, MaxGroups)), unreadable, nil
+}
+
+// fingerprint downloads an image and extracts its features.
+func (a *DefaultAnalyzer) fingerprint(ctx context.Context, img Image) (*Photo, error) {
+	data, err := a.images.ReadImage(ctx, img.OriginalURL)
+	if err != nil {
+		return nil, fmt.Errorf("read image: %w", err)
+	}
+	f, err := Extract(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Photo{ImageID: img.ID, RoomType: img.RoomType, Features: f}, nil
+}
@@ -0,0 +1,101 @@
+package roomgroup
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultAnalyzer_Analyze(t *testing.T) {
+	images := []Image{
+		{ID: "k1", OriginalURL: "s3://bucket/k1.jpg", RoomType: "kitchen"},
+		{ID: "b1", OriginalURL: "s3://bucket/b1.jpg"},
+		{ID: "k2", OriginalURL: "s3://bucket/k2.jpg"},
+		{ID: "broken", OriginalURL: "s3://bucket/broken.jpg"},
+	}
+
+	testCases := []struct {
+		name           string
+		listErr        error
+		read           func(t *testing.T, url string) ([]byte, error)
+		wantErr        string
+		wantGroups     [][]string
+		wantUnreadable []string
+	}{
+		{
+			name: "success: groups readable images and lists the rest",
+			read: func(t *testing.T, url string) ([]byte, error) {
+				switch url {
+				case "s3://bucket/k1.jpg":
+					return room(t, white, oak, 60), nil
+				case "s3://bucket/k2.jpg":
+					return room(t, white, oak, 63), nil
+				case "s3://bucket/b1.jpg":
+					return room(t, navy, tile, 40), nil
+				}
+				return []byte("corrupt"), nil
+			},
+			wantGroups:     [][]string{{"k1", "k2"}, {"b1"}},
+			wantUnreadable: []string{"broken"},
+		},
+		{
+			name: "fail: no image could be read",
+			read: func(t *testing.T, url string) ([]byte, error) {
+				return nil, errors.New("s3 unavailable")
+			},
+			wantErr: "none of the project's 4 images could be read",
+		},
+		{
+			name:    "fail: listing images",
+			listErr: errors.New("db down"),
+			wantErr: "db down",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &RepositoryMock{
+				MarkProcessingFunc: func(ctx context.Context, id string) error { return nil },
+				ListImagesFunc: func(ctx context.Context, pid string, limit int) ([]Image, error) {
+					assert.Equal(t, projectID, pid)
+					assert.Equal(t, MaxImages, limit)
+					return images, tc.listErr
+				},
+				MarkReadyFunc: func(ctx context.Context, id string, groups []Group, unreadable []string) error {
+					return nil
+				},
+				MarkErrorFunc: func(ctx context.Context, id, message string) error { return nil },
+			}
+			reader := &ImageReaderMock{
+				ReadImageFunc: func(ctx context.Context, rawURL string) ([]byte, error) {
+					return tc.read(t, rawURL)
+				},
+			}
+
+			err := NewDefaultAnalyzer(repo, reader).Analyze(context.Background(), groupingID, projectID)
+			require.Len(t, repo.MarkProcessingCalls(), 1)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				require.Len(t, repo.MarkErrorCalls(), 1)
+				assert.Contains(t, repo.MarkErrorCalls()[0].Message, tc.wantErr)
+				assert.Empty(t, repo.MarkReadyCalls())
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, repo.MarkReadyCalls(), 1)
+			call := repo.MarkReadyCalls()[0]
+			assert.Equal(t, groupingID, call.GroupingID)
+			var got [][]string
+			for _, g := range call.Groups {
+				got = append(got, g.ImageIDs)
+			}
+			assert.Equal(t, tc.wantGroups, got)
+			require.NotNil(t, call.Groups[0].RoomType)
+			assert.Equal(t, "kitchen", *call.Groups[0].RoomType)
+			assert.Equal(t, tc.wantUnreadable, call.UnreadableImageIDs)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package roomgroup
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Photo is an image ready to be grouped.
+type Photo struct {
+	ImageID  string
+	RoomType string
+	Features Features
+}
+
+// Cluster groups photos by average-linkage clustering: the two closest clusters merge while
+// the mean distance between their photos is below threshold, and past it while there are
+// more than maxClusters. Photos with different room types never share a cluster. Clusters
+// are returned in the order of their first photo, photos in input order.
+func Cluster(photos []Photo, threshold float64, maxClusters int) [][]Photo {
+	n := len(photos)
+	dist := make([][]float64, n)
+	for i := range dist {
+		dist[i] = make([]float64, n)
+		for j := range i {
+			dist[i][j] = Distance(photos[i].Features, photos[j].Features)
+			dist[j][i] = dist[i][j]
+		}
+	}
+	members := make([][]int, n)
+	roomTypes := make([]string, n)
+	for i, p := range photos {
+		members[i] = []int{i}
+		roomTypes[i] = p.RoomType
+	}
+
+	for clusters := n; clusters > 1; clusters-- {
+		a, b := -1, -1
+		for i := range n {
+			if members[i] == nil {
+				continue
+			}
+			for j := i + 1; j < n; j++ {
+				if members[j] == nil || !compatible(roomTypes[i], roomTypes[j]) {
+					continue
+				}
+				if a < 0 || dist[i][j] < dist[a][b] {
+					a, b = i, j
+				}
+			}
+		}
+		if a < 0 || (dist[a][b] >= threshold && clusters <= maxClusters) {
+			break
+		}
+
+		// Lance-Williams update for average linkage: the merged cluster's distance to
+		// every other is the size-weighted mean of its parts'.
+		na, nb := float64(len(members[a])), float64(len(members[b]))
+		for k := range n {
+			if members[k] == nil || k == a || k == b {
+				continue
+			}
+			dist[a][k] = (na*dist[a][k] + nb*dist[b][k]) / (na + nb)
+			dist[k][a] = dist[a][k]
+		}
+		members[a] = mergeSorted(members[a], members[b])
+		members[b] = nil
+		if roomTypes[a] == "" {
+			roomTypes[a] = roomTypes[b]
+		}
+	}
+
+	var out [][]Photo
+	for _, m := range members {
+		if m == nil {
+			continue
+		}
+		cluster := make([]Photo, len(m))
+		for i, idx := range m {
+			cluster[i] = photos[idx]
+		}
+		out = append(out, cluster)
+	}
+	return out
+}
+
+// compatible reports whether clusters of the two room types may merge.
+func compatible(a, b string) bool {
+	return a == "" || b == "" || a == b
+}
+
+// mergeSorted merges two ascending index lists.
+func mergeSorted(a, b []int) []int {
+	out := make([]int, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		if a[0] < b[0] {
+			out, a = append(out, a[0]), a[1:]
+		} else {
+			out, b = append(out, b[0]), b[1:]
+		}
+	}
+	return append(append(out, a...), b...)
+}
+
+// Groups turns clusters into proposed rooms. A cluster takes the room type of its photos
+// that have one. Room types with several groups are numbered ("Bedroom 1", "Bedroom 2"), as
+// are groups without a room type ("Room 1").
+func Groups(clusters [][]Photo) []Group {
+	groups := make([]Group, len(clusters))
+	perType := make(map[string]int)
+	for i, cluster := range clusters {
+		g := Group{ImageIDs: make([]string, len(cluster))}
+		for j, p := range cluster {
+			g.ImageIDs[j] = p.ImageID
+			if p.RoomType != "" && g.RoomType == nil {
+				roomType := p.RoomType
+				g.RoomType = &roomType
+			}
+		}
+		groups[i] = g
+		perType[roomTypeOf(g)]++
+	}
+
+	seen := make(map[string]int)
+	for i := range groups {
+		t := roomTypeOf(groups[i])
+		if t != "" && perType[t] == 1 {
+			continue
+		}
+		seen[t]++
+		groups[i].Label = fmt.Sprintf("%s %d", roomName(t), seen[t])
+	}
+	return groups
+}
+
+func roomTypeOf(g Group) string {
+	if g.RoomType == nil {
+		return ""
+	}
+	return *g.RoomType
+}
+
+// roomName is how a room type reads in a label: "living_room" is "Living room".
+func roomName(roomType string) string {
+	if roomType == "" {
+		return "Room"
+	}
+	name := strings.ReplaceAll(roomType, "_", " ")
+	return strings.ToUpper(name[:1]) + name[1:]
+}
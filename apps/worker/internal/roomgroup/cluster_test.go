@@ -0,0 +1,172 @@
+package roomgroup
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// room renders a photo-like test image: a wall of one colour over a floor of another, with
+// the horizon at horizon percent of the height.
+func room(t *testing.T, wall, floor color.RGBA, horizon int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 160, 120))
+	for y := 0; y < 120; y++ {
+		for x := 0; x < 160; x++ {
+			c := wall
+			if y*100/120 >= horizon {
+				c = floor
+			}
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+var (
+	white = color.RGBA{R: 240, G: 240, B: 235, A: 255}
+	oak   = color.RGBA{R: 170, G: 120, B: 70, A: 255}
+	navy  = color.RGBA{R: 30, G: 40, B: 90, A: 255}
+	tile  = color.RGBA{R: 120, G: 160, B: 200, A: 255}
+	green = color.RGBA{R: 60, G: 130, B: 70, A: 255}
+)
+
+func photo(t *testing.T, id, roomType string, wall, floor color.RGBA, horizon int) Photo {
+	t.Helper()
+	f, err := Extract(room(t, wall, floor, horizon))
+	require.NoError(t, err)
+	return Photo{ImageID: id, RoomType: roomType, Features: f}
+}
+
+func TestExtract(t *testing.T) {
+	_, err := Extract([]byte("not an image"))
+	assert.ErrorContains(t, err, "decode image")
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 4))))
+	_, err = Extract(buf.Bytes())
+	assert.ErrorContains(t, err, "too small")
+
+	a, err := Extract(room(t, white, oak, 60))
+	require.NoError(t, err)
+	b, err := Extract(room(t, white, oak, 65))
+	require.NoError(t, err)
+	c, err := Extract(room(t, navy, tile, 40))
+	require.NoError(t, err)
+	assert.Zero(t, Distance(a, a))
+	assert.Less(t, Distance(a, b), DefaultThreshold)
+	assert.Greater(t, Distance(a, c), DefaultThreshold)
+}
+
+func ids(clusters [][]Photo) [][]string {
+	out := make([][]string, len(clusters))
+	for i, c := range clusters {
+		for _, p := range c {
+			out[i] = append(out[i], p.ImageID)
+		}
+	}
+	return out
+}
+
+func TestCluster(t *testing.T) {
+	testCases := []struct {
+		name        string
+		photos      func(t *testing.T) []Photo
+		maxClusters int
+		want        [][]string
+	}{
+		{
+			name:        "success: no photos",
+			photos:      func(t *testing.T) []Photo { return nil },
+			maxClusters: MaxGroups,
+			want:        [][]string{},
+		},
+		{
+			name: "success: similar photos group together in upload order",
+			photos: func(t *testing.T) []Photo {
+				return []Photo{
+					photo(t, "a1", "", white, oak, 60),
+					photo(t, "b1", "", navy, tile, 40),
+					photo(t, "a2", "", white, oak, 65),
+					photo(t, "c1", "", green, oak, 50),
+					photo(t, "b2", "", navy, tile, 45),
+				}
+			},
+			maxClusters: MaxGroups,
+			want:        [][]string{{"a1", "a2"}, {"b1", "b2"}, {"c1"}},
+		},
+		{
+			name: "success: different room types stay apart",
+			photos: func(t *testing.T) []Photo {
+				return []Photo{
+					photo(t, "bed1", "bedroom", white, oak, 60),
+					photo(t, "bed2", "", white, oak, 61),
+					photo(t, "office", "office", white, oak, 65),
+				}
+			},
+			maxClusters: MaxGroups,
+			want:        [][]string{{"bed1", "bed2"}, {"office"}},
+		},
+		{
+			name: "success: the same photos without room types form one room",
+			photos: func(t *testing.T) []Photo {
+				return []Photo{
+					photo(t, "bed1", "", white, oak, 60),
+					photo(t, "bed2", "", white, oak, 61),
+					photo(t, "office", "", white, oak, 65),
+				}
+			},
+			maxClusters: MaxGroups,
+			want:        [][]string{{"bed1", "bed2", "office"}},
+		},
+		{
+			name: "success: merges past the threshold down to max clusters",
+			photos: func(t *testing.T) []Photo {
+				return []Photo{
+					photo(t, "a1", "", white, oak, 60),
+					photo(t, "b1", "", navy, tile, 40),
+					photo(t, "c1", "", green, oak, 50),
+				}
+			},
+			maxClusters: 2,
+			want:        [][]string{{"a1", "c1"}, {"b1"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ids(Cluster(tc.photos(t), DefaultThreshold, tc.maxClusters))
+			if len(tc.want) == 0 {
+				assert.Empty(t, got)
+				return
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestGroups(t *testing.T) {
+	clusters := [][]Photo{
+		{{ImageID: "1", RoomType: "bedroom"}, {ImageID: "2"}},
+		{{ImageID: "3"}},
+		{{ImageID: "4", RoomType: "living_room"}},
+		{{ImageID: "5", RoomType: "bedroom"}},
+		{{ImageID: "6"}},
+	}
+	bedroom, living := "bedroom", "living_room"
+
+	assert.Equal(t, []Group{
+		{RoomType: &bedroom, Label: "Bedroom 1", ImageIDs: []string{"1", "2"}},
+		{Label: "Room 1", ImageIDs: []string{"3"}},
+		{RoomType: &living, ImageIDs: []string{"4"}},
+		{RoomType: &bedroom, Label: "Bedroom 2", ImageIDs: []string{"5"}},
+		{Label: "Room 2", ImageIDs: []string{"6"}},
+	}, Groups(clusters))
+}
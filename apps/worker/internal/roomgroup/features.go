@@ -0,0 +1,108 @@
+package roomgroup
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg" // register decoder for uploads
+	_ "image/png"  // register decoder for uploads
+	"math/bits"
+
+	_ "golang.org/x/image/webp" // register decoder for uploads
+)
+
+// Features is a compact visual fingerprint of a photo. Photos of the same room share
+// colours (walls, floor, cabinetry) even from different angles, and shots from nearby
+// angles share layout too.
+type Features struct {
+	// Layout is a 64-bit difference hash of the photo's luminance.
+	Layout uint64
+	// Colors is the share of the photo in each of colorLevels³ RGB bins.
+	Colors [colorLevels * colorLevels * colorLevels]float64
+}
+
+// colorLevels is how many levels each RGB channel is quantized to in Features.Colors.
+const colorLevels = 4
+
+// Weights of layout and colour in Distance. Colour dominates: agents shoot each room from
+// several corners, which changes the layout far more than the palette.
+const (
+	weightLayout = 0.35
+	weightColors = 0.65
+)
+
+// sampleSize is the longest side, in pixels, a photo is sampled down to.
+const sampleSize = 256
+
+// hashCols and hashRows are the grid the layout hash compares neighbouring cells of.
+const (
+	hashCols = 9
+	hashRows = 8
+)
+
+// Extract decodes a JPEG, PNG or WebP photo and fingerprints it.
+func Extract(img []byte) (Features, error) {
+	src, _, err := image.Decode(bytes.NewReader(img))
+	if err != nil {
+		return Features{}, fmt.Errorf("decode image: %w", err)
+	}
+
+	b := src.Bounds()
+	step := max(1, max(b.Dx(), b.Dy())/sampleSize)
+	w, h := b.Dx()/step, b.Dy()/step
+	if w < hashCols || h < hashRows {
+		return Features{}, errors.New("image too small to group")
+	}
+
+	var f Features
+	var cells [hashRows][hashCols]float64
+	var counts [hashRows][hashCols]int
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			r, g, bl, _ := src.At(b.Min.X+x*step, b.Min.Y+y*step).RGBA()
+			cy, cx := y*hashRows/h, x*hashCols/w
+			cells[cy][cx] += 0.2126*float64(r) + 0.7152*float64(g) + 0.0722*float64(bl)
+			counts[cy][cx]++
+
+			bin := level(r)*colorLevels*colorLevels + level(g)*colorLevels + level(bl)
+			f.Colors[bin]++
+		}
+	}
+
+	n := float64(w * h)
+	for i := range f.Colors {
+		f.Colors[i] /= n
+	}
+	for y := 0; y < hashRows; y++ {
+		for x := 0; x < hashCols-1; x++ {
+			left := cells[y][x] / float64(counts[y][x])
+			right := cells[y][x+1] / float64(counts[y][x+1])
+			f.Layout <<= 1
+			if left < right {
+				f.Layout |= 1
+			}
+		}
+	}
+	return f, nil
+}
+
+// level quantizes a 16-bit colour channel to one of colorLevels levels.
+func level(c uint32) int {
+	return int(c) * colorLevels / 0x10000
+}
+
+// Distance returns how different two photos look, from 0 (identical) to 1.
+func Distance(a, b Features) float64 {
+	layout := float64(bits.OnesCount64(a.Layout^b.Layout)) / 64
+
+	var colors float64
+	for i := range a.Colors {
+		d := a.Colors[i] - b.Colors[i]
+		if d < 0 {
+			d = -d
+		}
+		colors += d
+	}
+	return weightLayout*layout + weightColors*colors/2
+}
@@ -0,0 +1,134 @@
+// Package roomgroup proposes how a project's uploads split into rooms. Photos are
+// fingerprinted by layout and colour and clustered, keeping apart photos the user already
+// gave different room types; the API shows the groups to the owner to confirm.
+package roomgroup
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/real-staging-ai/worker/internal/dbretry"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Image is an upload of the project being grouped.
+type Image struct {
+	ID          string
+	OriginalURL string
+	// RoomType is the room type the user gave the image, or "" when it has none.
+	RoomType string
+}
+
+// Group is one proposed room and the images taken in it. Its JSON form is how the API
+// stores and returns it.
+type Group struct {
+	// RoomType is the room type of the group's images that had one, or nil when none did.
+	RoomType *string `json:"room_type"`
+	// Label tells apart groups of the same room type, e.g. "Bedroom 2".
+	Label    string   `json:"label"`
+	ImageIDs []string `json:"image_ids"`
+}
+
+// Repository reads a project's images and moves a grouping through its statuses.
+type Repository interface {
+	// ListImages returns up to limit of the project's images, oldest first.
+	ListImages(ctx context.Context, projectID string, limit int) ([]Image, error)
+	// MarkProcessing records that a worker has started on the grouping.
+	MarkProcessing(ctx context.Context, groupingID string) error
+	// MarkReady records the proposed groups and the images that could not be read.
+	MarkReady(ctx context.Context, groupingID string, groups []Group, unreadableImageIDs []string) error
+	// MarkError records why the grouping failed.
+	MarkError(ctx context.Context, groupingID, message string) error
+}
+
+// SQLRepository reads images and updates room_groupings with database/sql. A grouping the
+// owner already confirmed is never changed.
+type SQLRepository struct {
+	db *sql.DB
+}
+
+// Ensure SQLRepository implements Repository.
+var _ Repository = (*SQLRepository)(nil)
+
+// NewSQLRepository creates a SQLRepository.
+func NewSQLRepository(db *sql.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+// ListImages returns the project's images in upload order.
+func (r *SQLRepository) ListImages(ctx context.Context, projectID string, limit int) ([]Image, error) {
+	const q = `SELECT id::text, original_url, COALESCE(room_type, '') FROM images
+		WHERE project_id = $1::uuid
+		ORDER BY created_at, id
+		LIMIT $2`
+	var images []Image
+	err := dbretry.Do(ctx, "list project images", func(ctx context.Context) error {
+		images = nil
+		rows, err := r.db.QueryContext(ctx, q, projectID, limit)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = rows.Close() }()
+		for rows.Next() {
+			var img Image
+			if err := rows.Scan(&img.ID, &img.OriginalURL, &img.RoomType); err != nil {
+				return err
+			}
+			images = append(images, img)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list project images: %w", err)
+	}
+	return images, nil
+}
+
+// MarkProcessing sets the grouping's status to processing, clearing the error of an
+// earlier attempt.
+func (r *SQLRepository) MarkProcessing(ctx context.Context, groupingID string) error {
+	q := `UPDATE room_groupings SET status = 'processing', error = NULL, updated_at = now()
+		WHERE id = $1::uuid AND status <> 'confirmed'`
+	return r.exec(ctx, "mark room grouping processing", q, groupingID)
+}
+
+// MarkReady sets the grouping's status to ready with its groups.
+func (r *SQLRepository) MarkReady(
+	ctx context.Context, groupingID string, groups []Group, unreadableImageIDs []string,
+) error {
+	if groups == nil {
+		groups = []Group{}
+	}
+	b, err := json.Marshal(groups)
+	if err != nil {
+		return fmt.Errorf("encode room groups: %w", err)
+	}
+	q := `UPDATE room_groupings SET status = 'ready', groups = $2::jsonb, unreadable_image_ids = $3::uuid[],
+		error = NULL, updated_at = now()
+		WHERE id = $1::uuid AND status <> 'confirmed'`
+	return r.exec(ctx, "mark room grouping ready", q, groupingID, string(b), pq.Array(unreadableImageIDs))
+}
+
+// MarkError sets the grouping's status to error with the reason.
+func (r *SQLRepository) MarkError(ctx context.Context, groupingID, message string) error {
+	q := `UPDATE room_groupings SET status = 'error', error = $2, updated_at = now()
+		WHERE id = $1::uuid AND status <> 'confirmed'`
+	return r.exec(ctx, "mark room grouping error", q, groupingID, message)
+}
+
+// exec runs an update, retrying transient failures.
+func (r *SQLRepository) exec(ctx context.Context, op, q string, args ...any) error {
+	err := dbretry.Do(ctx, op, func(ctx context.Context) error {
+		_, err := r.db.ExecContext(ctx, q, args...)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package roomgroup
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	groupingID = "5d2f8a1c-7e3b-4c9d-8a6f-2b1e0c9d8f7a"
+	projectID  = "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"
+)
+
+func TestSQLRepository_Mark(t *testing.T) {
+	bedroom := "bedroom"
+	testCases := []struct {
+		name    string
+		query   string
+		args    []driver.Value
+		mark    func(r *SQLRepository) error
+		execErr error
+	}{
+		{
+			name:  "success: processing",
+			query: `UPDATE room_groupings SET status = 'processing', error = NULL`,
+			args:  []driver.Value{groupingID},
+			mark:  func(r *SQLRepository) error { return r.MarkProcessing(context.Background(), groupingID) },
+		},
+		{
+			name:  "success: ready",
+			query: `UPDATE room_groupings SET status = 'ready', groups = \$2::jsonb, unreadable_image_ids = \$3::uuid\[\]`,
+			args: []driver.Value{
+				groupingID,
+				`[{"room_type":"bedroom","label":"","image_ids":["img-1"]}]`,
+				`{"img-2"}`,
+			},
+			mark: func(r *SQLRepository) error {
+				return r.MarkReady(context.Background(), groupingID,
+					[]Group{{RoomType: &bedroom, ImageIDs: []string{"img-1"}}}, []string{"img-2"})
+			},
+		},
+		{
+			name:  "success: ready without groups",
+			query: `UPDATE room_groupings SET status = 'ready'`,
+			args:  []driver.Value{groupingID, `[]`, `{}`},
+			mark: func(r *SQLRepository) error {
+				return r.MarkReady(context.Background(), groupingID, nil, []string{})
+			},
+		},
+		{
+			name:  "success: error",
+			query: `UPDATE room_groupings SET status = 'error', error = \$2`,
+			args:  []driver.Value{groupingID, "no images could be read"},
+			mark: func(r *SQLRepository) error {
+				return r.MarkError(context.Background(), groupingID, "no images could be read")
+			},
+		},
+		{
+			name:    "fail: exec error",
+			query:   `UPDATE room_groupings SET status = 'processing'`,
+			args:    []driver.Value{groupingID},
+			mark:    func(r *SQLRepository) error { return r.MarkProcessing(context.Background(), groupingID) },
+			execErr: errors.New("boom"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+
+			exp := mock.ExpectExec(tc.query).WithArgs(tc.args...)
+			if tc.execErr != nil {
+				exp.WillReturnError(tc.execErr)
+			} else {
+				exp.WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			err = tc.mark(NewSQLRepository(db))
+			if tc.execErr != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSQLRepository_ListImages(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	mock.ExpectQuery(`SELECT id::text, original_url, COALESCE\(room_type, ''\) FROM images`).
+		WithArgs(projectID, 300).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "original_url", "room_type"}).
+			AddRow("img-1", "s3://bucket/uploads/u/a.jpg", "kitchen").
+			AddRow("img-2", "s3://bucket/uploads/u/b.jpg", ""))
+
+	images, err := NewSQLRepository(db).ListImages(context.Background(), projectID, 300)
+	require.NoError(t, err)
+	assert.Equal(t, []Image{
+		{ID: "img-1", OriginalURL: "s3://bucket/uploads/u/a.jpg", RoomType: "kitchen"},
+		{ID: "img-2", OriginalURL: "s3://bucket/uploads/u/b.jpg"},
+	}, images)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
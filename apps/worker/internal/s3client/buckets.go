@@ -0,0 +1,62 @@
+package s3client
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// Buckets are the platform's own buckets: the home bucket and one per data-residency
+// region, all reached with the same endpoint and credentials.
+type Buckets struct {
+	home    string
+	clients map[string]*s3.Client
+}
+
+// NewBuckets returns Buckets with home served by client, and each of regionBuckets
+// (region to bucket) by a copy of client that signs for its region.
+func NewBuckets(home string, client *s3.Client, regionBuckets map[string]string) *Buckets {
+	clients := map[string]*s3.Client{home: client}
+	for region, bucket := range regionBuckets {
+		clients[bucket] = s3.New(client.Options(), func(o *s3.Options) { o.Region = region })
+	}
+	return &Buckets{home: home, clients: clients}
+}
+
+// BucketOf returns the platform bucket rawURL points into and its client. ok is false
+// when the URL names another bucket, such as a customer's, and bucket is that name.
+// Path-style URLs resolve to the region bucket their first segment names, and to the
+// home bucket otherwise.
+func (b *Buckets) BucketOf(rawURL string) (bucket string, client *s3.Client, ok bool) {
+	if named := BucketFromURL(rawURL); named != "" {
+		client, ok = b.clients[named]
+		return named, client, ok
+	}
+	if first, _, found := strings.Cut(pathOf(rawURL), "/"); found && first != b.home {
+		if client, ok := b.clients[first]; ok {
+			return first, client, true
+		}
+	}
+	return b.home, b.clients[b.home], true
+}
+
+// KeyIn returns the key of the object rawURL points at in bucket. Unlike KeyFromURL it
+// strips any bucket name from path-style URLs, not only the home bucket's.
+func KeyIn(rawURL, bucket string) (string, error) {
+	if !strings.HasPrefix(rawURL, "s3://") {
+		if first, key, found := strings.Cut(pathOf(rawURL), "/"); found && first == bucket {
+			return key, nil
+		}
+	}
+	return KeyFromURL(rawURL)
+}
+
+// pathOf returns rawURL's path without its leading slash, or "" when it does not parse.
+func pathOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(u.Path, "/")
+}
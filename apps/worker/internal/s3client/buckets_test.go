@@ -0,0 +1,68 @@
+package s3client
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuckets_BucketOf(t *testing.T) {
+	home := s3.New(s3.Options{Region: "us-west-1"})
+	buckets := NewBuckets("real-staging", home, map[string]string{"eu-central-1": "eu-photos"})
+
+	testCases := []struct {
+		name       string
+		url        string
+		wantBucket string
+		wantRegion string
+		wantOK     bool
+	}{
+		{name: "success: home s3 url", url: "s3://real-staging/uploads/a.jpg",
+			wantBucket: "real-staging", wantRegion: "us-west-1", wantOK: true},
+		{name: "success: home path-style url", url: "http://localhost:9000/real-staging/uploads/a.jpg",
+			wantBucket: "real-staging", wantRegion: "us-west-1", wantOK: true},
+		{name: "success: region s3 url", url: "s3://eu-photos/uploads/a.jpg",
+			wantBucket: "eu-photos", wantRegion: "eu-central-1", wantOK: true},
+		{name: "success: region virtual-hosted url", url: "https://eu-photos.s3.eu-central-1.amazonaws.com/uploads/a.jpg",
+			wantBucket: "eu-photos", wantRegion: "eu-central-1", wantOK: true},
+		{name: "success: region path-style url", url: "http://localhost:9000/eu-photos/uploads/a.jpg",
+			wantBucket: "eu-photos", wantRegion: "eu-central-1", wantOK: true},
+		{name: "fail: customer bucket", url: "s3://acme-photos/uploads/a.jpg", wantBucket: "acme-photos"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bucket, client, ok := buckets.BucketOf(tc.url)
+			assert.Equal(t, tc.wantBucket, bucket)
+			require.Equal(t, tc.wantOK, ok)
+			if tc.wantOK {
+				assert.Equal(t, tc.wantRegion, client.Options().Region)
+			}
+		})
+	}
+}
+
+func TestKeyIn(t *testing.T) {
+	testCases := []struct {
+		name   string
+		url    string
+		bucket string
+		want   string
+	}{
+		{name: "success: path-style region bucket", url: "http://localhost:9000/eu-photos/uploads/a.jpg",
+			bucket: "eu-photos", want: "uploads/a.jpg"},
+		{name: "success: s3 url", url: "s3://eu-photos/uploads/a.jpg", bucket: "eu-photos", want: "uploads/a.jpg"},
+		{name: "success: home path-style url", url: "http://localhost:9000/real-staging/uploads/a.jpg",
+			bucket: "real-staging", want: "uploads/a.jpg"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := KeyIn(tc.url, tc.bucket)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package s3client
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// KeyFromURL extracts the S3 object key from a stored image URL.
+// Handles formats like:
+//   - http://localhost:9000/bucket-name/uploads/...
+//   - https://bucket-name.s3.amazonaws.com/uploads/...
+//   - s3://bucket-name/uploads/...
+func KeyFromURL(rawURL string) (string, error) {
+	// Handle s3:// URLs
+	if strings.HasPrefix(rawURL, "s3://") {
+		parts := strings.SplitN(strings.TrimPrefix(rawURL, "s3://"), "/", 2)
+		if len(parts) < 2 {
+			return "", fmt.Errorf("invalid s3:// URL format")
+		}
+		return parts[1], nil
+	}
+
+	// Parse HTTP(S) URLs
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	// Remove leading slash and bucket name if path-style
+	path := strings.TrimPrefix(u.Path, "/")
+
+	// If the first path segment is the bucket name (path-style), remove it
+	parts := strings.SplitN(path, "/", 2)
+	if len(parts) == 2 {
+		// Check if first part looks like a bucket name
+		if strings.Contains(parts[0], "real-staging") {
+			return parts[1], nil
+		}
+	}
+
+	return path, nil
+}
+
+// BucketFromURL returns the bucket an s3:// or virtual-hosted AWS URL names, or "" for
+// path-style URLs, whose first segment is not reliably a bucket.
+func BucketFromURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	if u.Scheme == "s3" {
+		return u.Host
+	}
+	host := u.Hostname()
+	if name, rest, found := strings.Cut(host, ".s3"); found && strings.HasSuffix(rest, ".amazonaws.com") {
+		return name
+	}
+	return ""
+}
@@ -0,0 +1,55 @@
+package s3client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyFromURL(t *testing.T) {
+	testCases := []struct {
+		name    string
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{name: "success: s3 url", url: "s3://real-staging/uploads/a.jpg", want: "uploads/a.jpg"},
+		{name: "success: path-style url", url: "http://localhost:9000/real-staging/uploads/a.jpg", want: "uploads/a.jpg"},
+		{name: "success: virtual-hosted url", url: "https://bucket.s3.amazonaws.com/uploads/a.jpg", want: "uploads/a.jpg"},
+		{name: "fail: s3 url without key", url: "s3://real-staging", wantErr: true},
+		{name: "fail: unparsable url", url: "http://[::1", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := KeyFromURL(tc.url)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestBucketFromURL(t *testing.T) {
+	testCases := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{name: "success: s3 url", url: "s3://acme-photos/staged/a.jpg", want: "acme-photos"},
+		{name: "success: virtual-hosted url", url: "https://acme-photos.s3.eu-west-1.amazonaws.com/uploads/a.jpg", want: "acme-photos"},
+		{name: "success: path-style url names no bucket", url: "http://localhost:9000/real-staging/uploads/a.jpg"},
+		{name: "success: global endpoint names no bucket", url: "https://s3.amazonaws.com/uploads/a.jpg"},
+		{name: "success: unparsable url", url: "http://[::1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, BucketFromURL(tc.url))
+		})
+	}
+}
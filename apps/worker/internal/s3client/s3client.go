@@ -0,0 +1,45 @@
+// Package s3client builds S3 clients from worker configuration.
+package s3client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/real-staging-ai/worker/internal/config"
+	"github.com/real-staging-ai/worker/internal/telemetry"
+)
+
+// New creates an S3 client. When a custom endpoint is configured (MinIO,
+// LocalStack) static credentials and the configured addressing style are
+// used; otherwise the default AWS credential chain applies.
+func New(ctx context.Context, cfg *config.Config) (*s3.Client, error) {
+	if cfg.S3.Endpoint == "" {
+		awsCfg, err := awsconfig.LoadDefaultConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load AWS config: %w", err)
+		}
+		return s3.NewFromConfig(awsCfg, telemetry.CountS3Operations), nil
+	}
+
+	region := cfg.S3.Region
+	if region == "" {
+		region = "us-west-1"
+	}
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx,
+		awsconfig.WithRegion(region),
+		awsconfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(cfg.S3.AccessKey, cfg.S3.SecretKey, "")),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	return s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		o.BaseEndpoint = aws.String(cfg.S3.Endpoint)
+		o.UsePathStyle = cfg.S3.UsePathStyle
+	}, telemetry.CountS3Operations), nil
+}
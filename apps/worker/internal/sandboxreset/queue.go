@@ -0,0 +1,78 @@
+package sandboxreset
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hibiken/asynq"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out queue_mock.go . Queue
+
+// Queue empties the sandbox tenant's job queue.
+type Queue interface {
+	// Purge deletes every task that is not running and returns how many were deleted.
+	Purge(ctx context.Context) (int, error)
+}
+
+// AsynqQueue is a Queue backed by an asynq queue.
+type AsynqQueue struct {
+	inspector *asynq.Inspector
+	name      string
+}
+
+// Ensure AsynqQueue implements Queue.
+var _ Queue = (*AsynqQueue)(nil)
+
+// NewAsynqQueue creates an AsynqQueue for the queue called name on the Redis at addr.
+func NewAsynqQueue(addr, name string) *AsynqQueue {
+	return &AsynqQueue{inspector: asynq.NewInspector(asynq.RedisClientOpt{Addr: addr}), name: name}
+}
+
+// Purge deletes the queue's pending, scheduled, retrying, archived, completed and
+// aggregating tasks. Running tasks are left to finish; their images are gone once the
+// schema is reset, so they fail without writing anything back.
+func (q *AsynqQueue) Purge(_ context.Context) (int, error) {
+	queues, err := q.inspector.Queues()
+	if err != nil {
+		return 0, fmt.Errorf("list queues: %w", err)
+	}
+	if !slices.Contains(queues, q.name) {
+		// Nothing was ever enqueued on the queue
+		return 0, nil
+	}
+
+	total := 0
+	for _, deleteAll := range []func(string) (int, error){
+		q.inspector.DeleteAllPendingTasks,
+		q.inspector.DeleteAllScheduledTasks,
+		q.inspector.DeleteAllRetryTasks,
+		q.inspector.DeleteAllArchivedTasks,
+		q.inspector.DeleteAllCompletedTasks,
+	} {
+		n, err := deleteAll(q.name)
+		if err != nil {
+			return total, fmt.Errorf("purge queue %s: %w", q.name, err)
+		}
+		total += n
+	}
+
+	groups, err := q.inspector.Groups(q.name)
+	if err != nil {
+		return total, fmt.Errorf("list groups of queue %s: %w", q.name, err)
+	}
+	for _, g := range groups {
+		n, err := q.inspector.DeleteAllAggregatingTasks(q.name, g.Group)
+		if err != nil {
+			return total, fmt.Errorf("purge group %s of queue %s: %w", g.Group, q.name, err)
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// Close releases the inspector's Redis connection.
+func (q *AsynqQueue) Close() error {
+	return q.inspector.Close()
+}
@@ -0,0 +1,49 @@
+package sandboxreset
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsynqQueue_Purge(t *testing.T) {
+	t.Run("success: deletes pending, scheduled and aggregating tasks of the queue only", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		client := asynq.NewClient(asynq.RedisClientOpt{Addr: mr.Addr()})
+		t.Cleanup(func() { _ = client.Close() })
+		for _, opts := range [][]asynq.Option{
+			{asynq.Queue("sandbox")},
+			{asynq.Queue("sandbox"), asynq.ProcessIn(time.Hour)},
+			{asynq.Queue("sandbox"), asynq.Group("project:p1")},
+			{asynq.Queue("default")},
+		} {
+			_, err := client.Enqueue(asynq.NewTask("stage:run", []byte(`{}`)), opts...)
+			require.NoError(t, err)
+		}
+
+		q := NewAsynqQueue(mr.Addr(), "sandbox")
+		t.Cleanup(func() { _ = q.Close() })
+		n, err := q.Purge(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 3, n)
+
+		info, err := q.inspector.GetQueueInfo("default")
+		require.NoError(t, err)
+		assert.Equal(t, 1, info.Pending)
+	})
+
+	t.Run("success: queue never used", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		q := NewAsynqQueue(mr.Addr(), "sandbox")
+		t.Cleanup(func() { _ = q.Close() })
+
+		n, err := q.Purge(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, n)
+	})
+}
@@ -0,0 +1,41 @@
+package sandboxreset
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository resets the sandbox tenant's database schema.
+type Repository interface {
+	// Reset recreates the sandbox schema from the production table definitions and
+	// returns it to its seed data.
+	Reset(ctx context.Context) error
+}
+
+// DefaultRepository is a sql.DB-backed Repository.
+type DefaultRepository struct {
+	db *sql.DB
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewRepository creates a DefaultRepository.
+func NewRepository(db *sql.DB) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+// resetQuery calls the function migration 0060 installs. It runs in one transaction under
+// an advisory lock, so workers resetting at once take turns.
+const resetQuery = `SELECT public.reset_sandbox()`
+
+// Reset recreates the sandbox schema.
+func (r *DefaultRepository) Reset(ctx context.Context) error {
+	if _, err := r.db.ExecContext(ctx, resetQuery); err != nil {
+		return fmt.Errorf("reset sandbox schema: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+// Package sandboxreset returns the sandbox tenant, which integration partners test against
+// with sandbox API keys, to a clean slate each night: its queue is emptied, its database
+// schema recreated and its objects removed from the platform's buckets.
+package sandboxreset
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/real-staging-ai/worker/internal/compaction"
+	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/schedule"
+)
+
+// Config controls a reset.
+type Config struct {
+	// Buckets are the buckets the sandbox tenant stores objects in.
+	Buckets []string
+	// KeyPrefix starts the key of every object the sandbox tenant stores.
+	KeyPrefix string
+}
+
+// Result summarizes a reset.
+type Result struct {
+	PurgedTasks    int
+	DeletedObjects int
+	AbortedUploads int
+	Failed         int
+}
+
+// Resetter empties the sandbox tenant's queue, database schema and storage.
+type Resetter struct {
+	repo  Repository
+	queue Queue
+	store compaction.Store
+	cfg   Config
+	log   logging.Logger
+	now   func() time.Time
+}
+
+// NewResetter creates a Resetter.
+func NewResetter(repo Repository, queue Queue, store compaction.Store, cfg Config, log logging.Logger) *Resetter {
+	if cfg.KeyPrefix == "" {
+		cfg.KeyPrefix = "sandbox/"
+	}
+	return &Resetter{repo: repo, queue: queue, store: store, cfg: cfg, log: log, now: time.Now}
+}
+
+// Run resets the sandbox tenant. The queue is emptied first so no queued job writes into
+// the fresh schema. A failure to remove one object is counted and logged, and the run goes
+// on; any other failure ends the run.
+func (r *Resetter) Run(ctx context.Context) (Result, error) {
+	var res Result
+	cutoff := r.now().UTC()
+
+	purged, err := r.queue.Purge(ctx)
+	res.PurgedTasks = purged
+	if err != nil {
+		return res, err
+	}
+	if err := r.repo.Reset(ctx); err != nil {
+		return res, err
+	}
+
+	for _, bucket := range r.cfg.Buckets {
+		uploads, err := r.store.StaleUploads(ctx, bucket, cutoff)
+		if err != nil {
+			return res, err
+		}
+		for _, u := range uploads {
+			if !strings.HasPrefix(u.Key, r.cfg.KeyPrefix) {
+				continue
+			}
+			if err := r.store.AbortUpload(ctx, u); err != nil {
+				r.log.Error(ctx, "Failed to abort sandbox multipart upload", "bucket", bucket, "key", u.Key, "error", err)
+				res.Failed++
+				continue
+			}
+			res.AbortedUploads++
+		}
+
+		objects, err := r.store.StaleObjects(ctx, bucket, r.cfg.KeyPrefix, cutoff)
+		if err != nil {
+			return res, err
+		}
+		for _, o := range objects {
+			if err := r.store.DeleteObject(ctx, o); err != nil {
+				r.log.Error(ctx, "Failed to delete sandbox object", "bucket", bucket, "key", o.Key, "error", err)
+				res.Failed++
+				continue
+			}
+			res.DeletedObjects++
+		}
+	}
+	return res, nil
+}
+
+// RunDaily blocks until ctx is done, resetting the sandbox tenant each day at hourUTC.
+func (r *Resetter) RunDaily(ctx context.Context, hourUTC int) {
+	schedule.Daily(ctx, hourUTC, func(ctx context.Context, _ time.Time) {
+		res, err := r.Run(ctx)
+		if err != nil {
+			r.log.Error(ctx, "Sandbox reset failed", "error", err, "purged_tasks", res.PurgedTasks,
+				"deleted_objects", res.DeletedObjects)
+			return
+		}
+		r.log.Info(ctx, "Sandbox reset completed", "purged_tasks", res.PurgedTasks,
+			"deleted_objects", res.DeletedObjects, "aborted_uploads", res.AbortedUploads, "failed", res.Failed)
+	})
+}
@@ -0,0 +1,119 @@
+package sandboxreset
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/worker/internal/compaction"
+	"github.com/real-staging-ai/worker/internal/logging"
+)
+
+func newLogger() *logging.LoggerMock {
+	return &logging.LoggerMock{
+		InfoFunc:  func(ctx context.Context, msg string, keysAndValues ...any) {},
+		ErrorFunc: func(ctx context.Context, msg string, keysAndValues ...any) {},
+	}
+}
+
+func TestResetter_Run(t *testing.T) {
+	now := time.Date(2026, 10, 17, 3, 0, 0, 0, time.UTC)
+
+	t.Run("success: purges the queue, resets the schema and removes sandbox objects", func(t *testing.T) {
+		var steps, aborted, deleted []string
+		queue := &QueueMock{
+			PurgeFunc: func(ctx context.Context) (int, error) {
+				steps = append(steps, "purge")
+				return 4, nil
+			},
+		}
+		repo := &RepositoryMock{
+			ResetFunc: func(ctx context.Context) error {
+				steps = append(steps, "reset")
+				return nil
+			},
+		}
+		store := &compaction.StoreMock{
+			StaleUploadsFunc: func(ctx context.Context, bucket string, cutoff time.Time) ([]compaction.Upload, error) {
+				assert.Equal(t, now, cutoff)
+				return []compaction.Upload{
+					{Bucket: bucket, Key: "sandbox/uploads/u1/a.cr2", UploadID: "up-1"},
+					{Bucket: bucket, Key: "uploads/u2/b.cr2", UploadID: "up-2"},
+				}, nil
+			},
+			AbortUploadFunc: func(ctx context.Context, u compaction.Upload) error {
+				aborted = append(aborted, u.Bucket+"/"+u.UploadID)
+				return nil
+			},
+			StaleObjectsFunc: func(ctx context.Context, bucket, prefix string, cutoff time.Time) ([]compaction.Object, error) {
+				assert.Equal(t, "sandbox/", prefix)
+				return []compaction.Object{{Bucket: bucket, Key: "sandbox/staged/x.jpg"}}, nil
+			},
+			DeleteObjectFunc: func(ctx context.Context, o compaction.Object) error {
+				deleted = append(deleted, o.Bucket+"/"+o.Key)
+				return nil
+			},
+		}
+		r := NewResetter(repo, queue, store, Config{Buckets: []string{"real-staging", "real-staging-eu"}}, newLogger())
+		r.now = func() time.Time { return now }
+
+		res, err := r.Run(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, Result{PurgedTasks: 4, AbortedUploads: 2, DeletedObjects: 2}, res)
+		assert.Equal(t, []string{"purge", "reset"}, steps)
+		assert.Equal(t, []string{"real-staging/up-1", "real-staging-eu/up-1"}, aborted)
+		assert.Equal(t, []string{"real-staging/sandbox/staged/x.jpg", "real-staging-eu/sandbox/staged/x.jpg"}, deleted)
+	})
+
+	t.Run("success: failed removals are counted", func(t *testing.T) {
+		store := &compaction.StoreMock{
+			StaleUploadsFunc: func(ctx context.Context, bucket string, cutoff time.Time) ([]compaction.Upload, error) {
+				return nil, nil
+			},
+			StaleObjectsFunc: func(ctx context.Context, bucket, prefix string, cutoff time.Time) ([]compaction.Object, error) {
+				return []compaction.Object{{Bucket: bucket, Key: "sandbox/a"}, {Bucket: bucket, Key: "sandbox/b"}}, nil
+			},
+			DeleteObjectFunc: func(ctx context.Context, o compaction.Object) error {
+				if o.Key == "sandbox/a" {
+					return errors.New("access denied")
+				}
+				return nil
+			},
+		}
+		r := NewResetter(&RepositoryMock{ResetFunc: func(ctx context.Context) error { return nil }},
+			&QueueMock{PurgeFunc: func(ctx context.Context) (int, error) { return 0, nil }},
+			store, Config{Buckets: []string{"real-staging"}}, newLogger())
+
+		res, err := r.Run(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, Result{DeletedObjects: 1, Failed: 1}, res)
+	})
+
+	t.Run("fail: a failed schema reset leaves storage alone", func(t *testing.T) {
+		store := &compaction.StoreMock{}
+		r := NewResetter(&RepositoryMock{ResetFunc: func(ctx context.Context) error { return errors.New("db down") }},
+			&QueueMock{PurgeFunc: func(ctx context.Context) (int, error) { return 1, nil }},
+			store, Config{Buckets: []string{"real-staging"}}, newLogger())
+
+		res, err := r.Run(context.Background())
+		assert.EqualError(t, err, "db down")
+		assert.Equal(t, Result{PurgedTasks: 1}, res)
+		assert.Empty(t, store.StaleUploadsCalls())
+	})
+
+	t.Run("fail: a failed purge leaves the schema alone", func(t *testing.T) {
+		repo := &RepositoryMock{}
+		r := NewResetter(repo, &QueueMock{PurgeFunc: func(ctx context.Context) (int, error) {
+			return 0, errors.New("redis down")
+		}}, &compaction.StoreMock{}, Config{}, newLogger())
+
+		_, err := r.Run(context.Background())
+		assert.EqualError(t, err, "redis down")
+		assert.Empty(t, repo.ResetCalls())
+	})
+}
@@ -0,0 +1,51 @@
+// Package schedule runs background tasks on a fixed daily UTC schedule or at a fixed interval.
+package schedule
+
+import (
+	"context"
+	"time"
+)
+
+// NextDaily returns the first time strictly after now at hourUTC:00 UTC.
+func NextDaily(now time.Time, hourUTC int) time.Time {
+	now = now.UTC()
+	next := time.Date(now.Year(), now.Month(), now.Day(), hourUTC, 0, 0, 0, time.UTC)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// Daily blocks until ctx is done, calling fn once a day at hourUTC:00 UTC with
+// the scheduled time. Runs never overlap: the next run is scheduled after fn returns.
+func Daily(ctx context.Context, hourUTC int, fn func(ctx context.Context, at time.Time)) {
+	for {
+		next := NextDaily(time.Now(), hourUTC)
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		fn(ctx, next)
+	}
+}
+
+// Every blocks until ctx is done, calling fn every interval, starting one interval from
+// now. Runs never overlap: the next run is scheduled after fn returns.
+func Every(ctx context.Context, interval time.Duration, fn func(ctx context.Context)) {
+	for {
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		fn(ctx)
+	}
+}
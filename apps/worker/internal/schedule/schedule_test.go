@@ -0,0 +1,61 @@
+package schedule
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextDaily(t *testing.T) {
+	testCases := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "success: later today",
+			now:  time.Date(2025, 3, 14, 1, 30, 0, 0, time.UTC),
+			want: time.Date(2025, 3, 14, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "success: exactly at run hour schedules tomorrow",
+			now:  time.Date(2025, 3, 14, 3, 0, 0, 0, time.UTC),
+			want: time.Date(2025, 3, 15, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "success: non-UTC input",
+			now:  time.Date(2025, 3, 14, 22, 0, 0, 0, time.FixedZone("PDT", -7*3600)),
+			want: time.Date(2025, 3, 16, 3, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, NextDaily(tc.now, 3))
+		})
+	}
+}
+
+func TestEvery(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	runs := 0
+	done := make(chan struct{})
+	go func() {
+		Every(ctx, time.Millisecond, func(context.Context) {
+			runs++
+			if runs == 3 {
+				cancel()
+			}
+		})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Every did not return after cancel")
+	}
+	assert.Equal(t, 3, runs)
+}
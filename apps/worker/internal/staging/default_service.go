@@ -4,11 +4,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net/http"
-	"net/url"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -20,22 +24,59 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 
+	"github.com/real-staging-ai/worker/internal/byobucket"
+	"github.com/real-staging-ai/worker/internal/chaos"
+	"github.com/real-staging-ai/worker/internal/disclosure"
+	"github.com/real-staging-ai/worker/internal/ensemble"
+	"github.com/real-staging-ai/worker/internal/httpclient"
+	"github.com/real-staging-ai/worker/internal/joblog"
 	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/pipeline"
+	"github.com/real-staging-ai/worker/internal/provenance"
+	"github.com/real-staging-ai/worker/internal/rawimage"
+	"github.com/real-staging-ai/worker/internal/s3client"
 	"github.com/real-staging-ai/worker/internal/staging/model"
+	"github.com/real-staging-ai/worker/internal/telemetry"
+	"github.com/real-staging-ai/worker/internal/throttle"
+	"github.com/real-staging-ai/worker/internal/watermark"
 )
 
 // DefaultService implements the Service interface using Replicate AI and S3.
 type DefaultService struct {
 	s3Client        *s3.Client
 	bucketName      string
+	keyPrefix       string
+	regions         *s3client.Buckets
+	customerBuckets byobucket.Source
 	replicateClient *replicate.Client
 	modelID         model.ModelID
 	registry        *model.ModelRegistry
+	provenance      provenance.Embedder
+	disclosures     disclosure.Repository
+	watermarks      watermark.Repository
+	sandbox         bool
+	sandboxModelID  model.ModelID
+	ensemble        EnsembleConfig
+	limiter         *throttle.AdaptiveLimiter
+	raw             RawConfig
+	rawConverter    *rawimage.Converter
+	pipeline        *pipeline.Pipeline[*Artifact]
+	// lastPrediction is when the last Replicate prediction was created, in Unix nanoseconds.
+	lastPrediction atomic.Int64
 }
 
 // Ensure DefaultService implements Service interface.
 var _ Service = (*DefaultService)(nil)
 
+// predictionPollInterval controls how often a running prediction's status is checked.
+var predictionPollInterval = 2 * time.Second
+
+// progressLogInterval is the least time between provider progress lines in the job log.
+var progressLogInterval = 15 * time.Second
+
+// cdnClient downloads prediction outputs.
+var cdnClient = httpclient.New(httpclient.DestinationReplicateCDN)
+
 // awsConfigLoader allows overriding AWS config loading in tests.
 var awsConfigLoader = config.LoadDefaultConfig
 
@@ -50,6 +91,67 @@ type ServiceConfig struct {
 	S3SecretKey    string
 	S3UsePathStyle bool
 	AppEnv         string
+	// Provenance embeds Content Credentials into staged outputs. Nil disables it.
+	Provenance provenance.Embedder
+	// Disclosures looks up per-project disclosure banners. Nil disables them.
+	Disclosures disclosure.Repository
+	// Watermarks looks up per-account logo watermarks. Nil disables them.
+	Watermarks watermark.Repository
+	// Faults routes S3 and Replicate requests through fault injection. Nil disables it.
+	Faults *chaos.Injector
+	// Sandbox routes every request to the sandbox; requests can also opt in one at a time.
+	Sandbox bool
+	// SandboxModelID is the cheap/fast model sandbox requests run on. Empty uses the fake
+	// provider, which returns the original image without calling Replicate.
+	SandboxModelID model.ModelID
+	// Ensemble stages a sample of requests with a second model/prompt variant.
+	Ensemble EnsembleConfig
+	// Limiter caps concurrent predictions and learns from Replicate's responses. Nil runs
+	// predictions without a limit.
+	Limiter *throttle.AdaptiveLimiter
+	// CustomerBuckets opens buckets that accounts store their images in. Nil keeps every
+	// object in BucketName.
+	CustomerBuckets byobucket.Source
+	// RegionBuckets maps data-residency regions to their bucket. Images stored in one are
+	// staged into the same bucket.
+	RegionBuckets map[string]string
+	// Raw configures how RAW uploads are converted and archived.
+	Raw RawConfig
+	// KeyPrefix starts the key of every object written to BucketName and the region
+	// buckets, e.g. "sandbox/" for the sandbox tenant. Customer buckets are not prefixed.
+	KeyPrefix string
+	// Stages are extra pipeline stages, e.g. moderation, run alongside the built-in
+	// predict, disclosure, watermark, provenance and upload stages.
+	Stages []pipeline.Registration[*Artifact]
+}
+
+// RawConfig configures RAW uploads: how they are converted to the JPEG that is staged,
+// and how the RAW file is kept as the archival original.
+type RawConfig struct {
+	// DecoderCommand develops RAW files, given as its last argument, to a PPM on stdout,
+	// e.g. "dcraw -c -w -q 3". Empty uses the JPEG the camera embedded in the file.
+	DecoderCommand string
+	// ArchiveStorageClass is the S3 storage class RAW files are moved to once converted,
+	// e.g. "GLACIER_IR". Empty keeps the bucket's default.
+	ArchiveStorageClass string
+	// ArchiveTag is the value of the "lifecycle" tag RAW files get once converted, for
+	// bucket lifecycle rules to match. Empty leaves them untagged.
+	ArchiveTag string
+}
+
+// EnsembleConfig configures the experimental ensemble mode: sampled requests run the
+// primary model and a challenger side by side, and the output with the higher quality
+// score is kept. Sandbox requests never run in ensemble mode.
+type EnsembleConfig struct {
+	Enabled bool
+	// ChallengerModelID runs alongside the primary model. Empty uses the primary model.
+	ChallengerModelID model.ModelID
+	// ChallengerPromptSuffix is appended to the challenger's prompt.
+	ChallengerPromptSuffix string
+	// SampleRate is the fraction of requests, in [0, 1], that run both variants.
+	SampleRate float64
+	// Comparisons records each comparison for offline evaluation. Nil skips recording.
+	Comparisons ensemble.Repository
 }
 
 // NewDefaultService creates a new DefaultService instance using provided configuration.
@@ -75,18 +177,41 @@ func NewDefaultService(ctx context.Context, cfg *ServiceConfig) (*DefaultService
 	if !registry.Exists(modelID) {
 		return nil, fmt.Errorf("unsupported model: %s", modelID)
 	}
+	if cfg.SandboxModelID != "" && !registry.Exists(cfg.SandboxModelID) {
+		return nil, fmt.Errorf("unsupported sandbox model: %s", cfg.SandboxModelID)
+	}
+	ensembleCfg := cfg.Ensemble
+	if ensembleCfg.ChallengerModelID == "" {
+		ensembleCfg.ChallengerModelID = modelID
+	}
+	if ensembleCfg.Enabled && !registry.Exists(ensembleCfg.ChallengerModelID) {
+		return nil, fmt.Errorf("unsupported ensemble challenger model: %s", ensembleCfg.ChallengerModelID)
+	}
 
 	bucketName := cfg.BucketName
 	replicateToken := cfg.ReplicateToken
 
 	// Create Replicate client
-	replicateClient, err := replicate.NewClient(replicate.WithToken(replicateToken))
+	replicateHTTP := httpclient.New(httpclient.DestinationReplicate,
+		httpclient.WithTransport(func(transport http.RoundTripper) http.RoundTripper {
+			transport = cfg.Faults.Transport(chaos.PointReplicate, transport)
+			if cfg.Limiter != nil {
+				transport = cfg.Limiter.Transport(transport)
+			}
+			return transport
+		}))
+	replicateClient, err := replicate.NewClient(replicate.WithToken(replicateToken), replicate.WithHTTPClient(replicateHTTP))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Replicate client: %w", err)
 	}
 
 	// Initialize S3 client
 	var awsCfg aws.Config
+	withFaults := func(o *s3.Options) {
+		if cfg.Faults != nil {
+			o.HTTPClient = &http.Client{Transport: cfg.Faults.Transport(chaos.PointS3, nil)}
+		}
+	}
 
 	if cfg.AppEnv == "test" {
 		awsCfg, err = awsConfigLoader(ctx,
@@ -100,15 +225,27 @@ func NewDefaultService(ctx context.Context, cfg *ServiceConfig) (*DefaultService
 		s3Client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
 			o.BaseEndpoint = aws.String("http://localhost:4566")
 			o.UsePathStyle = true
-		})
+		}, withFaults, telemetry.CountS3Operations)
 
-		return &DefaultService{
+		return withPipeline(&DefaultService{
 			s3Client:        s3Client,
 			bucketName:      bucketName,
+			keyPrefix:       cfg.KeyPrefix,
 			replicateClient: replicateClient,
 			modelID:         modelID,
 			registry:        registry,
-		}, nil
+			provenance:      cfg.Provenance,
+			disclosures:     cfg.Disclosures,
+			watermarks:      cfg.Watermarks,
+			sandbox:         cfg.Sandbox,
+			sandboxModelID:  cfg.SandboxModelID,
+			ensemble:        ensembleCfg,
+			limiter:         cfg.Limiter,
+			raw:             cfg.Raw,
+			rawConverter:    rawimage.NewConverter(cfg.Raw.DecoderCommand),
+			customerBuckets: cfg.CustomerBuckets,
+			regions:         s3client.NewBuckets(bucketName, s3Client, cfg.RegionBuckets),
+		}, cfg.Stages)
 	}
 
 	// If a custom S3 endpoint is provided (e.g., MinIO), configure client for dev/local
@@ -130,15 +267,27 @@ func NewDefaultService(ctx context.Context, cfg *ServiceConfig) (*DefaultService
 			if cfg.S3UsePathStyle {
 				o.UsePathStyle = true
 			}
-		})
+		}, withFaults, telemetry.CountS3Operations)
 
-		return &DefaultService{
+		return withPipeline(&DefaultService{
 			s3Client:        s3Client,
 			bucketName:      bucketName,
+			keyPrefix:       cfg.KeyPrefix,
 			replicateClient: replicateClient,
 			modelID:         modelID,
 			registry:        registry,
-		}, nil
+			provenance:      cfg.Provenance,
+			disclosures:     cfg.Disclosures,
+			watermarks:      cfg.Watermarks,
+			sandbox:         cfg.Sandbox,
+			sandboxModelID:  cfg.SandboxModelID,
+			ensemble:        ensembleCfg,
+			limiter:         cfg.Limiter,
+			raw:             cfg.Raw,
+			rawConverter:    rawimage.NewConverter(cfg.Raw.DecoderCommand),
+			customerBuckets: cfg.CustomerBuckets,
+			regions:         s3client.NewBuckets(bucketName, s3Client, cfg.RegionBuckets),
+		}, cfg.Stages)
 	}
 
 	// Use default AWS config for production
@@ -147,15 +296,27 @@ func NewDefaultService(ctx context.Context, cfg *ServiceConfig) (*DefaultService
 		return nil, fmt.Errorf("failed to load AWS config: %w", err)
 	}
 
-	s3Client := s3.NewFromConfig(awsCfg)
+	s3Client := s3.NewFromConfig(awsCfg, withFaults, telemetry.CountS3Operations)
 
-	return &DefaultService{
+	return withPipeline(&DefaultService{
 		s3Client:        s3Client,
 		bucketName:      bucketName,
+		keyPrefix:       cfg.KeyPrefix,
 		replicateClient: replicateClient,
 		modelID:         modelID,
 		registry:        registry,
-	}, nil
+		provenance:      cfg.Provenance,
+		disclosures:     cfg.Disclosures,
+		watermarks:      cfg.Watermarks,
+		sandbox:         cfg.Sandbox,
+		sandboxModelID:  cfg.SandboxModelID,
+		ensemble:        ensembleCfg,
+		limiter:         cfg.Limiter,
+		raw:             cfg.Raw,
+		rawConverter:    rawimage.NewConverter(cfg.Raw.DecoderCommand),
+		customerBuckets: cfg.CustomerBuckets,
+		regions:         s3client.NewBuckets(bucketName, s3Client, cfg.RegionBuckets),
+	}, cfg.Stages)
 }
 
 // StageImage processes an image with AI staging and returns the staged image URL in S3.
@@ -169,24 +330,86 @@ func (s *DefaultService) StageImage(ctx context.Context, req *StagingRequest) (s
 	)
 	defer span.End()
 
-	// Extract the S3 file key from the original URL
-	fileKey, err := extractS3KeyFromURL(req.OriginalURL)
+	// The output goes next to the original, in the account's own bucket if it has one
+	store, err := s.storeFor(ctx, req.OriginalURL)
 	if err != nil {
 		span.RecordError(err)
-		span.SetStatus(codes.Error, "invalid S3 URL")
-		return "", fmt.Errorf("failed to extract S3 key from URL: %w", err)
+		span.SetStatus(codes.Error, "bucket lookup failed")
+		return "", err
+	}
+	req.store = store
+
+	// A previous attempt already uploaded the output; only the DB update is left
+	if req.Resume.OutputKey != "" {
+		log.Info(ctx, "Resuming from output checkpoint", "image_id", req.ImageID, "output_key", req.Resume.OutputKey)
+		span.SetAttributes(attribute.String("checkpoint", "output"))
+		span.SetStatus(codes.Ok, "staging resumed from checkpoint")
+		return store.objectURL(req.Resume.OutputKey), nil
+	}
+
+	// Predict, post-process and upload the output through the staging pipeline
+	artifact := &Artifact{Request: req}
+	if err := s.pipeline.Run(ctx, artifact); err != nil {
+		var stageErr *pipeline.StageError
+		if errors.As(err, &stageErr) {
+			span.SetAttributes(attribute.String("pipeline.failed_stage", stageErr.Stage))
+			err = stageErr.Err
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "pipeline failed")
+		return "", err
+	}
+
+	span.SetStatus(codes.Ok, "staging completed")
+	return artifact.URL, nil
+}
+
+// EstimateCost returns what staging req is expected to cost with the provider. Requests
+// resuming from a checkpoint are free, as are sandbox requests on the fake provider.
+// With ensemble mode on, the challenger's cost is always included: sampling happens
+// later, so this is an upper bound.
+func (s *DefaultService) EstimateCost(req *StagingRequest) float64 {
+	if req.Resume.OutputKey != "" || req.Resume.PredictionID != "" {
+		return 0
+	}
+	cost := GetModelCost(s.modelFor(req))
+	if s.ensembleEligible(req) {
+		cost += GetModelCost(s.ensemble.ChallengerModelID)
+	}
+	return cost
+}
+
+// ensembleEligible reports whether req may run in ensemble mode.
+func (s *DefaultService) ensembleEligible(req *StagingRequest) bool {
+	return s.ensemble.Enabled && !s.sandbox && !req.Sandbox
+}
+
+// modelFor returns the model req runs on, or "" for sandbox requests on the fake provider.
+func (s *DefaultService) modelFor(req *StagingRequest) model.ModelID {
+	if s.sandbox || req.Sandbox {
+		return s.sandboxModelID
+	}
+	return s.modelID
+}
+
+// readOriginal downloads the request's original image from its bucket.
+func (s *DefaultService) readOriginal(ctx context.Context, req *StagingRequest) ([]byte, error) {
+	log := logging.Default()
+
+	// Extract the S3 file key from the original URL
+	store := s.storeOf(req)
+	fileKey, err := s3client.KeyIn(req.OriginalURL, store.bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract S3 key from URL: %w", err)
 	}
 
 	// Download the original image from S3
-	originalImage, err := s.DownloadFromS3(ctx, fileKey)
+	originalImage, err := s.download(ctx, store, fileKey)
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, "S3 download failed")
-		return "", fmt.Errorf("failed to download original image: %w", err)
+		return nil, fmt.Errorf("failed to download original image: %w", err)
 	}
 	defer func() {
 		if err := originalImage.Close(); err != nil {
-			span.RecordError(err)
 			log.Error(ctx, "failed to close original image", "error", err)
 		}
 	}()
@@ -194,55 +417,308 @@ func (s *DefaultService) StageImage(ctx context.Context, req *StagingRequest) (s
 	// Read the image content
 	imageBytes, err := io.ReadAll(originalImage)
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, "read image failed")
-		return "", fmt.Errorf("failed to read image content: %w", err)
+		return nil, fmt.Errorf("failed to read image content: %w", err)
+	}
+	return imageBytes, nil
+}
+
+// runPrediction stages the original image, or its HDR merge, with a new prediction and
+// returns the provider's output and the model that produced it.
+func (s *DefaultService) runPrediction(ctx context.Context, req *StagingRequest) ([]byte, model.ModelID, error) {
+	log := logging.Default()
+
+	imageBytes := req.input
+	if imageBytes == nil {
+		var err error
+		if imageBytes, err = s.readOriginal(ctx, req); err != nil {
+			return nil, "", err
+		}
+	}
+	req.OriginalBytes = int64(len(imageBytes))
+
+	// The sandbox's fake provider stages nothing: the original stands in for the output
+	modelID := s.modelFor(req)
+	if modelID == "" {
+		log.Info(ctx, "Sandbox request; returning the original image as the staged output", "image_id", req.ImageID)
+		return imageBytes, "", nil
 	}
 
 	// Convert to base64 data URL for Replicate
 	mimeType := http.DetectContentType(imageBytes)
 	dataURL := fmt.Sprintf("data:%s;base64,%s", mimeType, base64.StdEncoding.EncodeToString(imageBytes))
 
-	// Build the prompt based on room type and style
-	prompt := s.buildPrompt(req.RoomType, req.Style)
+	// Build the prompt based on room type and style, then the picked catalog, the room
+	// measurements and the preset's instructions
+	prompt := withAnnotations(withCatalog(s.buildPrompt(req.RoomType, req.Style), req.Catalog), req.Annotations)
+	input := &model.ModelInputRequest{
+		ImageDataURL: dataURL,
+		Prompt:       withInstructions(prompt, req.Instructions),
+		Seed:         req.Seed,
+	}
+	ensembleRun := s.ensembleEligible(req) && rand.Float64() < s.ensemble.SampleRate
+	if refs := req.referenceImageURLs(); len(refs) > 0 {
+		limit := s.maxReferenceImages(modelID)
+		if ensembleRun {
+			limit = max(limit, s.maxReferenceImages(s.ensemble.ChallengerModelID))
+		}
+		input.ReferenceImageURLs = s.referenceImages(ctx, req.ImageID, refs, limit)
+	}
+
+	if ensembleRun {
+		return s.runEnsemble(ctx, req, modelID, input)
+	}
 
 	// Call Replicate AI to stage the image
-	stagedImageURL, err := s.callReplicateAPI(ctx, dataURL, prompt, req.Seed)
+	stagedImageURL, err := s.callReplicateAPI(ctx, modelID, input, func(predictionID string) {
+		appendJobLog(ctx, req, "Prediction started")
+		if req.Checkpoints == nil {
+			return
+		}
+		if err := req.Checkpoints.RecordPrediction(ctx, req.ImageID, predictionID); err != nil {
+			log.Warn(ctx, "failed to record prediction checkpoint", "image_id", req.ImageID, "error", err)
+		}
+	}, s.predictionObserver(ctx, req))
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, "Replicate API failed")
-		return "", fmt.Errorf("failed to stage image with Replicate: %w", err)
+		return nil, "", fmt.Errorf("failed to stage image with Replicate: %w", err)
 	}
+	appendJobLog(ctx, req, "Prediction finished")
 
 	// Download the staged image from Replicate's CDN
 	stagedImageBytes, err := s.downloadFromURL(ctx, stagedImageURL)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to download staged image: %w", err)
+	}
+	return stagedImageBytes, modelID, nil
+}
+
+// runEnsemble stages the image with the primary model and the challenger concurrently,
+// scores both outputs, and returns the better one with the model that produced it. The
+// scored outputs are kept in S3 and the comparison is recorded for offline evaluation.
+// No prediction checkpoint is written, so a retry runs both variants again.
+func (s *DefaultService) runEnsemble(
+	ctx context.Context, req *StagingRequest, primary model.ModelID, input *model.ModelInputRequest,
+) ([]byte, model.ModelID, error) {
+	log := logging.Default()
+	tracer := otel.Tracer("real-staging-worker/staging")
+	ctx, span := tracer.Start(ctx, "staging.runEnsemble")
+	defer span.End()
+
+	candidates := []ensemble.Candidate{
+		{Model: string(primary), Prompt: input.Prompt},
+		{Model: string(s.ensemble.ChallengerModelID), Prompt: input.Prompt + s.ensemble.ChallengerPromptSuffix},
+	}
+	outputs := make([][]byte, len(candidates))
+	preds := make([]*replicate.Prediction, len(candidates))
+
+	var wg sync.WaitGroup
+	for i := range candidates {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c := &candidates[i]
+			onPoll := func(pred *replicate.Prediction) {
+				if pred.Status.Terminated() {
+					preds[i] = pred
+				}
+			}
+			candidateInput := *input
+			candidateInput.Prompt = c.Prompt
+			outputURL, err := s.callReplicateAPI(ctx, model.ModelID(c.Model), &candidateInput,
+				func(predictionID string) { c.PredictionID = predictionID }, onPoll)
+			if err != nil {
+				c.Error = err.Error()
+				return
+			}
+			out, err := s.downloadFromURL(ctx, outputURL)
+			if err != nil {
+				c.Error = fmt.Sprintf("download output: %v", err)
+				return
+			}
+			score, err := ensemble.ScoreImage(out)
+			if err != nil {
+				c.Error = fmt.Sprintf("score output: %v", err)
+				return
+			}
+			c.Score = &score
+			outputs[i] = out
+		}()
+	}
+	wg.Wait()
+
+	selected := ensemble.Best(candidates)
+	if selected < 0 {
+		err := fmt.Errorf("failed to stage image with Replicate: every ensemble candidate failed: %s", candidates[0].Error)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "ensemble failed")
+		return nil, "", err
+	}
+
+	for i, out := range outputs {
+		if out == nil {
+			continue
+		}
+		key := candidateKey(req.ImageID, i)
+		if err := s.putObject(ctx, s.storeOf(req), key, bytes.NewReader(out), http.DetectContentType(out)); err != nil {
+			log.Warn(ctx, "failed to keep ensemble candidate", "image_id", req.ImageID, "candidate", i, "error", err)
+			continue
+		}
+		candidates[i].OutputKey = key
+	}
+
+	if s.ensemble.Comparisons != nil {
+		comparison := ensemble.Comparison{ImageID: req.ImageID, Candidates: candidates, Selected: selected}
+		if err := s.ensemble.Comparisons.Record(ctx, comparison); err != nil {
+			log.Warn(ctx, "failed to record ensemble comparison", "image_id", req.ImageID, "error", err)
+		}
+	}
+	if onDone := s.providerRecorder(ctx, req); onDone != nil && preds[selected] != nil {
+		onDone(preds[selected])
+	}
+
+	winner := candidates[selected]
+	span.SetAttributes(
+		attribute.Int("ensemble.selected", selected),
+		attribute.String("ensemble.selected_model", winner.Model),
+		attribute.Float64("ensemble.selected_score", winner.Score.Total),
+	)
+	span.SetStatus(codes.Ok, "ensemble completed")
+	log.Info(ctx, "Ensemble candidate selected",
+		"image_id", req.ImageID, "selected", selected, "model", winner.Model, "score", winner.Score.Total)
+	return outputs[selected], model.ModelID(winner.Model), nil
+}
+
+// resumePrediction waits for a prediction started by an earlier attempt and returns its
+// output. It returns nil when the prediction cannot be resumed (it failed, was canceled,
+// or its output has expired), in which case the caller starts a new one.
+func (s *DefaultService) resumePrediction(
+	ctx context.Context, imageID, predictionID string, onPoll func(*replicate.Prediction),
+) []byte {
+	log := logging.Default()
+	tracer := otel.Tracer("real-staging-worker/staging")
+	ctx, span := tracer.Start(ctx, "staging.resumePrediction")
+	span.SetAttributes(attribute.String("prediction.id", predictionID))
+	defer span.End()
+
+	pred, err := s.replicateClient.GetPrediction(ctx, predictionID)
+	if err != nil {
+		log.Warn(ctx, "cannot resume prediction; starting a new one",
+			"image_id", imageID, "prediction_id", predictionID, "error", err)
+		return nil
+	}
+	if pred.Status == replicate.Failed || pred.Status == replicate.Canceled {
+		if onPoll != nil {
+			onPoll(pred)
+		}
+		log.Info(ctx, "previous prediction did not succeed; starting a new one",
+			"image_id", imageID, "prediction_id", predictionID, "status", pred.Status)
+		return nil
+	}
+
+	log.Info(ctx, "Resuming prediction from checkpoint", "image_id", imageID, "prediction_id", predictionID)
+	outputURL, err := s.awaitPrediction(ctx, predictionID, onPoll)
+	if err != nil {
+		log.Warn(ctx, "resumed prediction did not complete; starting a new one",
+			"image_id", imageID, "prediction_id", predictionID, "error", err)
+		return nil
+	}
+	out, err := s.downloadFromURL(ctx, outputURL)
+	if err != nil {
+		log.Warn(ctx, "resumed prediction output unavailable; starting a new one",
+			"image_id", imageID, "prediction_id", predictionID, "error", err)
+		return nil
+	}
+	span.SetStatus(codes.Ok, "prediction resumed")
+	return out
+}
+
+// applyDisclosure renders the project's disclosure banner onto the staged
+// image. Unlike provenance, failures fail the job: publishing a photo without
+// a disclosure the project requires is worse than retrying.
+func (s *DefaultService) applyDisclosure(ctx context.Context, img []byte, req *StagingRequest) ([]byte, error) {
+	if s.disclosures == nil {
+		return img, nil
+	}
+	tracer := otel.Tracer("real-staging-worker/staging")
+	ctx, span := tracer.Start(ctx, "staging.applyDisclosure")
+	defer span.End()
+
+	settings, err := s.disclosures.ForImage(ctx, req.ImageID)
 	if err != nil {
 		span.RecordError(err)
-		span.SetStatus(codes.Error, "download staged image failed")
-		return "", fmt.Errorf("failed to download staged image: %w", err)
+		span.SetStatus(codes.Error, "disclosure lookup failed")
+		return nil, err
+	}
+	if settings == nil {
+		return img, nil
 	}
+	span.SetAttributes(
+		attribute.String("disclosure.locale", settings.Locale),
+		attribute.String("disclosure.position", settings.Position),
+	)
 
-	// Upload the staged image to S3
-	stagedURL, err := s.UploadToS3(ctx, req.ImageID, bytes.NewReader(stagedImageBytes), "image/jpeg")
+	out, err := disclosure.Render(img, *settings)
 	if err != nil {
 		span.RecordError(err)
-		span.SetStatus(codes.Error, "S3 upload failed")
-		return "", fmt.Errorf("failed to upload staged image: %w", err)
+		span.SetStatus(codes.Error, "disclosure render failed")
+		return nil, err
 	}
+	span.SetStatus(codes.Ok, "disclosure rendered")
+	return out, nil
+}
 
-	span.SetStatus(codes.Ok, "staging completed")
-	return stagedURL, nil
+// embedProvenance attaches Content Credentials to the staged image. Failures
+// are logged and the image is returned unchanged so staging still completes.
+func (s *DefaultService) embedProvenance(
+	ctx context.Context, img []byte, req *StagingRequest, stagedBy model.ModelID,
+) []byte {
+	if s.provenance == nil {
+		return img
+	}
+	tracer := otel.Tracer("real-staging-worker/staging")
+	ctx, span := tracer.Start(ctx, "staging.embedProvenance")
+	defer span.End()
+
+	modelName := string(stagedBy)
+	if modelName == "" {
+		modelName = "sandbox"
+	}
+	info := provenance.Info{Model: modelName, StagedAt: time.Now()}
+	if req.RoomType != nil {
+		info.RoomType = *req.RoomType
+	}
+	if req.Style != nil {
+		info.Style = *req.Style
+	}
+	out, err := s.provenance.Embed(ctx, img, info)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "provenance embed failed")
+		logging.Default().Warn(ctx, "failed to embed content credentials", "image_id", req.ImageID, "error", err)
+		return img
+	}
+	span.SetStatus(codes.Ok, "provenance embedded")
+	return out
+}
+
+// ReadImage downloads an s3:// URL from its bucket.
+func (s *DefaultService) ReadImage(ctx context.Context, rawURL string) ([]byte, error) {
+	return s.readS3Object(ctx, rawURL)
 }
 
 // DownloadFromS3 downloads a file from S3 and returns its content.
 func (s *DefaultService) DownloadFromS3(ctx context.Context, fileKey string) (io.ReadCloser, error) {
+	return s.download(ctx, s.platformStore(), fileKey)
+}
+
+// download reads fileKey from store.
+func (s *DefaultService) download(ctx context.Context, store objectStore, fileKey string) (io.ReadCloser, error) {
 	tracer := otel.Tracer("real-staging-worker/staging")
 	_, span := tracer.Start(ctx, "staging.DownloadFromS3")
-	span.SetAttributes(attribute.String("s3.key", fileKey))
+	span.SetAttributes(attribute.String("s3.bucket", store.bucket), attribute.String("s3.key", fileKey))
 	defer span.End()
 
-	result, err := s.s3Client.GetObject(ctx, &s3.GetObjectInput{
-		Bucket: aws.String(s.bucketName),
+	result, err := store.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(store.bucket),
 		Key:    aws.String(fileKey),
 	})
 	if err != nil {
@@ -258,51 +734,138 @@ func (s *DefaultService) DownloadFromS3(ctx context.Context, fileKey string) (io
 // UploadToS3 uploads a file to S3 and returns the public URL.
 func (s *DefaultService) UploadToS3(
 	ctx context.Context, imageID string, content io.Reader, contentType string,
+) (string, error) {
+	return s.upload(ctx, s.platformStore(), imageID, content, contentType)
+}
+
+// upload writes an image's staged output to store and returns its URL.
+func (s *DefaultService) upload(
+	ctx context.Context, store objectStore, imageID string, content io.Reader, contentType string,
 ) (string, error) {
 	tracer := otel.Tracer("real-staging-worker/staging")
 	_, span := tracer.Start(ctx, "staging.UploadToS3")
-	span.SetAttributes(attribute.String("image.id", imageID))
+	span.SetAttributes(attribute.String("image.id", imageID), attribute.String("s3.bucket", store.bucket))
 	defer span.End()
 
 	// Generate the S3 key for the staged image
-	fileKey := fmt.Sprintf("staged/%s/%s-staged.jpg", imageID[:8], imageID)
+	fileKey := stagedKey(imageID)
+
+	if err := s.putObject(ctx, store, fileKey, content, contentType); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "PutObject failed")
+		return "", err
+	}
+
+	span.SetStatus(codes.Ok, "upload completed")
+	return store.objectURL(fileKey), nil
+}
 
-	// Upload to S3
-	_, err := s.s3Client.PutObject(ctx, &s3.PutObjectInput{
-		Bucket:      aws.String(s.bucketName),
-		Key:         aws.String(fileKey),
+// putObject writes content to fileKey in store.
+func (s *DefaultService) putObject(
+	ctx context.Context, store objectStore, fileKey string, content io.Reader, contentType string,
+) error {
+	_, err := store.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(store.bucket),
+		Key:         aws.String(store.key(fileKey)),
 		Body:        content,
 		ContentType: aws.String(contentType),
 	})
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, "PutObject failed")
-		return "", fmt.Errorf("failed to upload to S3: %w", err)
+		return fmt.Errorf("failed to upload to S3: %w", err)
+	}
+	return nil
+}
+
+// objectStore is a bucket and the client that can reach it.
+type objectStore struct {
+	client *s3.Client
+	bucket string
+	// prefix starts the key of every object written to the bucket.
+	prefix string
+}
+
+// key returns the key fileKey is written to. Keys derived from an object already under
+// the prefix, e.g. a converted RAW original, are kept as they are.
+func (o objectStore) key(fileKey string) string {
+	if strings.HasPrefix(fileKey, o.prefix) {
+		return fileKey
 	}
+	return o.prefix + fileKey
+}
 
-	// Construct the public URL
-	// In production, this would be the S3 URL or CloudFront URL
-	// For now, we'll return the key which can be used with presigned URLs
-	publicURL := fmt.Sprintf("s3://%s/%s", s.bucketName, fileKey)
+// platformStore is the worker's own bucket.
+func (s *DefaultService) platformStore() objectStore {
+	return objectStore{client: s.s3Client, bucket: s.bucketName, prefix: s.keyPrefix}
+}
 
-	span.SetStatus(codes.Ok, "upload completed")
-	return publicURL, nil
+// storeOf returns the bucket StageImage resolved for req, or the worker's own bucket when
+// req did not come through StageImage.
+func (s *DefaultService) storeOf(req *StagingRequest) objectStore {
+	if req.store.client == nil {
+		return s.platformStore()
+	}
+	return req.store
+}
+
+// storeFor returns the bucket rawURL points into: the home or a region bucket, or a
+// customer bucket when the URL names one.
+func (s *DefaultService) storeFor(ctx context.Context, rawURL string) (objectStore, error) {
+	regions := s.regions
+	if regions == nil {
+		regions = s3client.NewBuckets(s.bucketName, s.s3Client, nil)
+	}
+	bucket, client, ok := regions.BucketOf(rawURL)
+	if ok {
+		return objectStore{client: client, bucket: bucket, prefix: s.keyPrefix}, nil
+	}
+	if s.customerBuckets == nil {
+		return s.platformStore(), nil
+	}
+	client, err := s.customerBuckets.Client(ctx, bucket)
+	if err != nil {
+		return objectStore{}, fmt.Errorf("failed to open customer bucket %s: %w", bucket, err)
+	}
+	if client == nil {
+		return s.platformStore(), nil
+	}
+	return objectStore{client: client, bucket: bucket}, nil
 }
 
-// callReplicateAPI calls the Replicate API to stage an image.
+// stagedKey is the S3 key of an image's staged output.
+func stagedKey(imageID string) string {
+	return fmt.Sprintf("staged/%s/%s-staged.jpg", imageID[:8], imageID)
+}
+
+// candidateKey is the S3 key an ensemble candidate's raw output is kept under.
+func candidateKey(imageID string, candidate int) string {
+	return fmt.Sprintf("staged/%s/%s-candidate-%d", imageID[:8], imageID, candidate)
+}
+
+// objectURL constructs the URL stored for an object.
+// In production, this would be the S3 URL or CloudFront URL
+// For now, we'll return the key which can be used with presigned URLs
+func (o objectStore) objectURL(fileKey string) string {
+	return fmt.Sprintf("s3://%s/%s", o.bucket, o.key(fileKey))
+}
+
+// callReplicateAPI calls the Replicate API to stage an image. Reference images beyond
+// what the model accepts are dropped. onStart, if set, is called with the prediction ID
+// as soon as the prediction is created, and onPoll with the prediction each time it is
+// polled.
 func (s *DefaultService) callReplicateAPI(
-	ctx context.Context, imageDataURL, prompt string, seed *int64,
+	ctx context.Context, modelID model.ModelID, inputReq *model.ModelInputRequest,
+	onStart func(predictionID string), onPoll func(*replicate.Prediction),
 ) (string, error) {
 	tracer := otel.Tracer("real-staging-worker/staging")
 	ctx, span := tracer.Start(ctx, "staging.callReplicateAPI")
 	span.SetAttributes(
-		attribute.String("model", string(s.modelID)),
-		attribute.String("prompt", prompt),
+		attribute.String("model", string(modelID)),
+		attribute.String("prompt", inputReq.Prompt),
 	)
 	defer span.End()
 
 	// Get the model metadata from registry
-	modelMeta, err := s.registry.Get(s.modelID)
+	modelMeta, err := s.registry.Get(modelID)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "model not found")
@@ -310,11 +873,12 @@ func (s *DefaultService) callReplicateAPI(
 	}
 
 	// Build the input parameters using the model's input builder
-	inputReq := &model.ModelInputRequest{
-		ImageDataURL: imageDataURL,
-		Prompt:       prompt,
-		Seed:         seed,
+	if len(inputReq.ReferenceImageURLs) > modelMeta.MaxReferenceImages {
+		trimmed := *inputReq
+		trimmed.ReferenceImageURLs = inputReq.ReferenceImageURLs[:modelMeta.MaxReferenceImages]
+		inputReq = &trimmed
 	}
+	span.SetAttributes(attribute.Int("reference_images", len(inputReq.ReferenceImageURLs)))
 
 	input, err := modelMeta.InputBuilder.BuildInput(ctx, inputReq)
 	if err != nil {
@@ -323,27 +887,81 @@ func (s *DefaultService) callReplicateAPI(
 		return "", fmt.Errorf("failed to build model input: %w", err)
 	}
 
+	// Hold a provider slot from creating the prediction until it finishes
+	if s.limiter != nil {
+		release, err := s.limiter.Acquire(ctx)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "waiting for provider capacity failed")
+			return "", fmt.Errorf("failed to wait for provider capacity: %w", err)
+		}
+		defer release()
+		span.SetAttributes(attribute.Int("provider.concurrency_limit", s.limiter.Limit()))
+	}
+
 	// Create and run the prediction
 	webhook := replicate.Webhook{
 		URL:    "", // No webhook for now
 		Events: []replicate.WebhookEventType{},
 	}
 
-	prediction, err := s.replicateClient.CreatePrediction(ctx, string(s.modelID), input, &webhook, false)
+	prediction, err := s.replicateClient.CreatePrediction(ctx, string(modelID), input, &webhook, false)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "CreatePrediction failed")
 		return "", fmt.Errorf("failed to create prediction: %w", err)
 	}
+	span.SetAttributes(attribute.String("prediction.id", prediction.ID))
+	s.lastPrediction.Store(time.Now().UnixNano())
+	telemetry.UsageFrom(ctx).AddEstimatedCost(GetModelCost(modelID))
+	if onStart != nil {
+		onStart(prediction.ID)
+	}
+
+	outputURL, err := s.awaitPrediction(ctx, prediction.ID, onPoll)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "prediction failed")
+		return "", err
+	}
+	span.SetStatus(codes.Ok, "prediction succeeded")
+	return outputURL, nil
+}
+
+// awaitPrediction polls a prediction until it finishes and returns its output URL.
+// If ctx ends first, the prediction is canceled so it stops costing money. onPoll, if
+// set, is called with the prediction after every poll; the last call carries its
+// terminal status.
+func (s *DefaultService) awaitPrediction(
+	ctx context.Context, predictionID string, onPoll func(*replicate.Prediction),
+) (string, error) {
+	tracer := otel.Tracer("real-staging-worker/staging")
+	ctx, span := tracer.Start(ctx, "staging.awaitPrediction")
+	span.SetAttributes(attribute.String("prediction.id", predictionID))
+	defer span.End()
+	defer func(start time.Time) { telemetry.UsageFrom(ctx).AddProviderLatency(time.Since(start)) }(time.Now())
 
 	// Wait for the prediction to complete (with timeout)
-	ticker := time.NewTicker(2 * time.Second)
+	ticker := time.NewTicker(predictionPollInterval)
 	defer ticker.Stop()
 
 	timeout := time.After(5 * time.Minute)
 
 	for {
 		select {
+		case <-ctx.Done():
+			// The job was canceled or the worker is shutting down; stop paying for the prediction.
+			// Use a detached context since ctx is already done.
+			cancelCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+			if _, cancelErr := s.replicateClient.CancelPrediction(cancelCtx, predictionID); cancelErr != nil {
+				span.RecordError(cancelErr)
+			}
+			cancel()
+			err := fmt.Errorf("prediction aborted: %w", context.Cause(ctx))
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "prediction aborted")
+			return "", err
+
 		case <-timeout:
 			err := fmt.Errorf("prediction timed out after 5 minutes")
 			span.RecordError(err)
@@ -351,12 +969,15 @@ func (s *DefaultService) callReplicateAPI(
 			return "", err
 
 		case <-ticker.C:
-			pred, err := s.replicateClient.GetPrediction(ctx, prediction.ID)
+			pred, err := s.replicateClient.GetPrediction(ctx, predictionID)
 			if err != nil {
 				span.RecordError(err)
 				span.SetStatus(codes.Error, "GetPrediction failed")
 				return "", fmt.Errorf("failed to get prediction status: %w", err)
 			}
+			if onPoll != nil {
+				onPoll(pred)
+			}
 
 			switch pred.Status {
 			case replicate.Succeeded:
@@ -417,6 +1038,183 @@ func (s *DefaultService) callReplicateAPI(
 	}
 }
 
+// providerRecorder returns a callback that records the final prediction on the job, or
+// nil when the request carries no recorder. The input is dropped since it holds the
+// base64-encoded original image.
+func (s *DefaultService) providerRecorder(ctx context.Context, req *StagingRequest) func(*replicate.Prediction) {
+	if req.Checkpoints == nil {
+		return nil
+	}
+	return func(pred *replicate.Prediction) {
+		log := logging.Default()
+		redacted := *pred
+		redacted.Input = nil
+		raw, err := json.Marshal(redacted)
+		if err != nil {
+			log.Warn(ctx, "failed to encode provider response", "image_id", req.ImageID, "error", err)
+			return
+		}
+		version := pred.Version
+		if version == "" {
+			version = string(s.modelFor(req))
+		}
+		if err := req.Checkpoints.RecordProviderResponse(ctx, req.ImageID, version, string(raw)); err != nil {
+			log.Warn(ctx, "failed to record provider response", "image_id", req.ImageID, "error", err)
+		}
+	}
+}
+
+// predictionObserver returns a callback for every poll of the request's prediction: it
+// copies provider progress to the job log, publishes previews of partial outputs and
+// records the final prediction on the job. It returns nil when the request carries no job
+// log, preview publisher or checkpoints.
+func (s *DefaultService) predictionObserver(ctx context.Context, req *StagingRequest) func(*replicate.Prediction) {
+	record := s.providerRecorder(ctx, req)
+	progress := progressLogger(ctx, req)
+	previews := s.previewPublisher(ctx, req)
+	if record == nil && progress == nil && previews == nil {
+		return nil
+	}
+	return func(pred *replicate.Prediction) {
+		if progress != nil {
+			progress(pred)
+		}
+		if previews != nil {
+			previews(pred)
+		}
+		if record != nil && pred.Status.Terminated() {
+			record(pred)
+		}
+	}
+}
+
+// progressLogger returns a callback that copies the provider's latest log line to the job
+// log, at most once per progressLogInterval and only when it changed, or nil when the
+// request carries no job log. A failed prediction's error is always logged.
+func progressLogger(ctx context.Context, req *StagingRequest) func(*replicate.Prediction) {
+	if req.Log == nil {
+		return nil
+	}
+	var last string
+	var lastAt time.Time
+	return func(pred *replicate.Prediction) {
+		if pred.Status == replicate.Failed {
+			appendJobLog(ctx, req, fmt.Sprintf("Prediction failed: %v", pred.Error))
+			return
+		}
+		if pred.Logs == nil {
+			return
+		}
+		line := joblog.LastLine(*pred.Logs)
+		if line == "" || line == last || time.Since(lastAt) < progressLogInterval {
+			return
+		}
+		last, lastAt = line, time.Now()
+		appendJobLog(ctx, req, "Provider: "+line)
+	}
+}
+
+// appendJobLog adds a line to the request's job log, if it has one. A failed write is
+// logged and otherwise ignored: the job log never fails a job.
+func appendJobLog(ctx context.Context, req *StagingRequest, line string) {
+	if req.Log == nil {
+		return
+	}
+	if err := req.Log.Append(ctx, req.ImageID, line); err != nil {
+		logging.Default().Warn(ctx, "failed to append job log", "image_id", req.ImageID, "error", err)
+	}
+}
+
+// withCatalog appends the catalog's prompt fragment to prompt. A nil catalog leaves it as is.
+func withCatalog(prompt string, c *Catalog) string {
+	if c == nil || c.PromptFragment == "" {
+		return prompt
+	}
+	return fmt.Sprintf("%s\n- Furnish the room from the %s collection: %s", prompt, c.Name, c.PromptFragment)
+}
+
+// feetPerMeter converts room measurements for the prompt, which gives both units.
+const feetPerMeter = 3.28084
+
+// withAnnotations appends the known room measurements to prompt so furniture is drawn
+// at a believable scale. Nil annotations leave it as is.
+func withAnnotations(prompt string, a *Annotations) string {
+	if a == nil || (a.WallLengthM == nil && a.CeilingHeightM == nil) {
+		return prompt
+	}
+	var b strings.Builder
+	b.WriteString(prompt)
+	if a.WallLengthM != nil {
+		fmt.Fprintf(&b, "\n- The wall facing the camera is %.1f m (%.1f ft) long.",
+			*a.WallLengthM, *a.WallLengthM*feetPerMeter)
+	}
+	if a.CeilingHeightM != nil {
+		fmt.Fprintf(&b, "\n- The ceiling is %.1f m (%.1f ft) high.", *a.CeilingHeightM, *a.CeilingHeightM*feetPerMeter)
+	}
+	b.WriteString("\n- Size every piece of furniture to real-world dimensions for a room of this size; " +
+		"a sofa is about 2.1 m (7 ft) long and a dining table about 0.75 m (2.5 ft) high.")
+	return b.String()
+}
+
+// withInstructions appends a preset's extra instructions to prompt. Empty instructions
+// leave it as is.
+func withInstructions(prompt, instructions string) string {
+	instructions = strings.TrimSpace(instructions)
+	if instructions == "" {
+		return prompt
+	}
+	return fmt.Sprintf("%s\n- Additional instructions: %s", prompt, instructions)
+}
+
+// maxReferenceImages returns how many reference images modelID accepts.
+func (s *DefaultService) maxReferenceImages(modelID model.ModelID) int {
+	meta, err := s.registry.Get(modelID)
+	if err != nil {
+		return 0
+	}
+	return meta.MaxReferenceImages
+}
+
+// referenceImages resolves up to limit of urls for the model: https:// URLs are passed
+// through and s3:// URLs are downloaded and sent inline. A reference that cannot be
+// fetched is skipped, so the job still stages on the prompt.
+func (s *DefaultService) referenceImages(ctx context.Context, imageID string, urls []string, limit int) []string {
+	log := logging.Default()
+	if limit == 0 {
+		log.Info(ctx, "Model does not support reference images; staging with the prompt only",
+			"image_id", imageID, "reference_images", len(urls))
+		return nil
+	}
+
+	var refs []string
+	for _, raw := range urls {
+		if len(refs) == limit {
+			break
+		}
+		if !strings.HasPrefix(raw, "s3://") {
+			refs = append(refs, raw)
+			continue
+		}
+		dataURL, err := s.inlineS3Image(ctx, raw)
+		if err != nil {
+			log.Warn(ctx, "failed to fetch reference image; skipping it",
+				"image_id", imageID, "url", raw, "error", err)
+			continue
+		}
+		refs = append(refs, dataURL)
+	}
+	return refs
+}
+
+// inlineS3Image downloads an s3:// URL from its bucket and returns it as a data URL.
+func (s *DefaultService) inlineS3Image(ctx context.Context, rawURL string) (string, error) {
+	img, err := s.readS3Object(ctx, rawURL)
+	if err != nil {
+		return "", err
+	}
+	return encodeDataURL(img), nil
+}
+
 // buildPrompt constructs the AI prompt based on room type and style.
 func (s *DefaultService) buildPrompt(roomType, style *string) string {
 	// Determine the style theme
@@ -464,8 +1262,7 @@ func (s *DefaultService) downloadFromURL(ctx context.Context, url string) ([]byt
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+	resp, err := cdnClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to download from URL: %w", err)
 	}
@@ -481,39 +1278,3 @@ func (s *DefaultService) downloadFromURL(ctx context.Context, url string) ([]byt
 
 	return io.ReadAll(resp.Body)
 }
-
-// extractS3KeyFromURL extracts the S3 key from a URL.
-// Handles formats like:
-//   - http://localhost:9000/bucket-name/uploads/...
-//   - https://bucket-name.s3.amazonaws.com/uploads/...
-//   - s3://bucket-name/uploads/...
-func extractS3KeyFromURL(rawURL string) (string, error) {
-	// Handle s3:// URLs
-	if strings.HasPrefix(rawURL, "s3://") {
-		parts := strings.SplitN(strings.TrimPrefix(rawURL, "s3://"), "/", 2)
-		if len(parts) < 2 {
-			return "", fmt.Errorf("invalid s3:// URL format")
-		}
-		return parts[1], nil
-	}
-
-	// Parse HTTP(S) URLs
-	u, err := url.Parse(rawURL)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse URL: %w", err)
-	}
-
-	// Remove leading slash and bucket name if path-style
-	path := strings.TrimPrefix(u.Path, "/")
-
-	// If the first path segment is the bucket name (path-style), remove it
-	parts := strings.SplitN(path, "/", 2)
-	if len(parts) == 2 {
-		// Check if first part looks like a bucket name
-		if strings.Contains(parts[0], "real-staging") {
-			return parts[1], nil
-		}
-	}
-
-	return path, nil
-}
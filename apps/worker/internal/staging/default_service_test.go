@@ -1,14 +1,43 @@
 package staging
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/replicate/replicate-go"
 
+	"github.com/real-staging-ai/worker/internal/byobucket"
+	"github.com/real-staging-ai/worker/internal/chaos"
+	"github.com/real-staging-ai/worker/internal/checkpoint"
+	"github.com/real-staging-ai/worker/internal/disclosure"
+	"github.com/real-staging-ai/worker/internal/ensemble"
+	"github.com/real-staging-ai/worker/internal/joblog"
+	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/pipeline"
+	"github.com/real-staging-ai/worker/internal/provenance"
+	"github.com/real-staging-ai/worker/internal/s3client"
 	"github.com/real-staging-ai/worker/internal/staging/model"
+	"github.com/real-staging-ai/worker/internal/throttle"
 )
 
 func TestNewDefaultService(t *testing.T) {
@@ -166,6 +195,20 @@ func TestNewDefaultService(t *testing.T) {
 		}
 	})
 
+	t.Run("fail: unsupported sandbox model", func(t *testing.T) {
+		cfg := &ServiceConfig{
+			BucketName:     "test-bucket",
+			ReplicateToken: "test-token",
+			SandboxModelID: model.ModelID("unsupported/model"),
+			S3Endpoint:     "http://localhost:9000",
+		}
+
+		_, err := NewDefaultService(ctx, cfg)
+		if err == nil || err.Error() != "unsupported sandbox model: unsupported/model" {
+			t.Errorf("expected unsupported sandbox model error, got %v", err)
+		}
+	})
+
 	t.Run("fail: AWS config load error", func(t *testing.T) {
 		// Temporarily override the AWS config loader to return an error
 		awsConfigLoader = func(ctx context.Context, optFns ...func(*config.LoadOptions) error) (aws.Config, error) {
@@ -229,7 +272,9 @@ func TestDefaultService_CallReplicateAPI_ModelRegistry(t *testing.T) {
 		service.modelID = model.ModelID("invalid/model")
 
 		// Try to call the API - should fail with model not found
-		_, err = service.callReplicateAPI(ctx, "data:image/jpeg;base64,test", "test prompt", nil)
+		_, err = service.callReplicateAPI(ctx, service.modelID, &model.ModelInputRequest{
+			ImageDataURL: "data:image/jpeg;base64,test", Prompt: "test prompt",
+		}, nil, nil)
 		if err == nil {
 			t.Fatal("expected error for invalid model")
 		}
@@ -259,7 +304,9 @@ func TestDefaultService_CallReplicateAPI_ModelRegistry(t *testing.T) {
 		}
 
 		// Try to call the API with empty prompt - should fail validation
-		_, err = service.callReplicateAPI(ctx, "data:image/jpeg;base64,test", "", nil)
+		_, err = service.callReplicateAPI(ctx, service.modelID, &model.ModelInputRequest{
+			ImageDataURL: "data:image/jpeg;base64,test",
+		}, nil, nil)
 		if err == nil {
 			t.Fatal("expected error for empty prompt")
 		}
@@ -270,6 +317,98 @@ func TestDefaultService_CallReplicateAPI_ModelRegistry(t *testing.T) {
 	})
 }
 
+func TestDefaultService_CallReplicateAPI_ProviderOutage(t *testing.T) {
+	ctx := context.Background()
+
+	originalLoader := awsConfigLoader
+	defer func() { awsConfigLoader = originalLoader }()
+	awsConfigLoader = func(ctx context.Context, optFns ...func(*config.LoadOptions) error) (aws.Config, error) {
+		return aws.Config{Region: "us-west-1"}, nil
+	}
+
+	// The fault answers before any request leaves the process.
+	faults := chaos.New(map[chaos.Point]chaos.Fault{
+		chaos.PointReplicate: {ErrorRate: 1, Status: 500},
+	})
+	service, err := NewDefaultService(ctx, &ServiceConfig{
+		BucketName:     "test-bucket",
+		ReplicateToken: "test-token",
+		ModelID:        model.ModelQwenImageEdit,
+		S3Endpoint:     "http://localhost:9000",
+		AppEnv:         "dev",
+		Faults:         faults,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating service: %v", err)
+	}
+
+	_, err = service.callReplicateAPI(ctx, service.modelID, &model.ModelInputRequest{
+		ImageDataURL: "data:image/jpeg;base64,test", Prompt: "stage this room",
+	}, nil, nil)
+	if err == nil {
+		t.Fatal("expected error when the provider returns 500")
+	}
+	if !strings.Contains(err.Error(), "failed to create prediction") {
+		t.Errorf("unexpected error message: %v", err)
+	}
+	// Prediction creation is not idempotent, so the client must not retry it.
+	if got := faults.Fired(chaos.PointReplicate); got != 1 {
+		t.Errorf("expected 1 request to reach the provider, got %d", got)
+	}
+}
+
+func TestDefaultService_CallReplicateAPI_ProviderThrottled(t *testing.T) {
+	ctx := context.Background()
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	limiter := throttle.NewAdaptiveLimiter(throttle.Config{Min: 1, Max: 4}, logging.Default())
+	client, err := replicate.NewClient(
+		replicate.WithToken("test-token"),
+		replicate.WithBaseURL(srv.URL),
+		replicate.WithHTTPClient(&http.Client{Transport: limiter.Transport(nil)}),
+		replicate.WithRetryPolicy(0, &replicate.ConstantBackoff{}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	service := &DefaultService{
+		replicateClient: client,
+		modelID:         model.ModelQwenImageEdit,
+		registry:        model.NewModelRegistry(),
+		limiter:         limiter,
+	}
+
+	_, err = service.callReplicateAPI(ctx, service.modelID, &model.ModelInputRequest{
+		ImageDataURL: "data:image/jpeg;base64,test", Prompt: "stage this room",
+	}, nil, nil)
+	if err == nil {
+		t.Fatal("expected error when the provider returns 429")
+	}
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("expected 1 request to reach the provider, got %d", got)
+	}
+	if got := limiter.Limit(); got != 2 {
+		t.Errorf("expected the limit to back off to 2, got %d", got)
+	}
+
+	// The failed call gave its slot back, so both remaining slots are free.
+	acquireCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	for range 2 {
+		release, err := limiter.Acquire(acquireCtx)
+		if err != nil {
+			t.Fatalf("expected a free provider slot: %v", err)
+		}
+		defer release()
+	}
+}
+
 func TestDefaultService_BuildPrompt(t *testing.T) {
 	ctx := context.Background()
 
@@ -358,3 +497,801 @@ func containsHelper(s, substr string) bool {
 	}
 	return false
 }
+
+func TestDefaultService_EmbedProvenance(t *testing.T) {
+	ctx := context.Background()
+	roomType := "living_room"
+	req := &StagingRequest{ImageID: "img-123", RoomType: &roomType}
+	input := []byte("staged")
+
+	tests := []struct {
+		name     string
+		embedder provenance.Embedder
+		want     string
+	}{
+		{
+			name: "success: disabled returns input",
+			want: "staged",
+		},
+		{
+			name: "success: embeds credentials",
+			embedder: &provenance.EmbedderMock{
+				EmbedFunc: func(ctx context.Context, img []byte, info provenance.Info) ([]byte, error) {
+					if info.Model != string(model.ModelFluxKontextMax) || info.RoomType != roomType {
+						t.Errorf("unexpected info: %+v", info)
+					}
+					return append([]byte("c2pa:"), img...), nil
+				},
+			},
+			want: "c2pa:staged",
+		},
+		{
+			name: "fail: embed error falls back to input",
+			embedder: &provenance.EmbedderMock{
+				EmbedFunc: func(ctx context.Context, img []byte, info provenance.Info) ([]byte, error) {
+					return nil, errors.New("decode image: unknown format")
+				},
+			},
+			want: "staged",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &DefaultService{modelID: model.ModelFluxKontextMax, provenance: tt.embedder}
+			if got := string(s.embedProvenance(ctx, input, req, s.modelID)); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDefaultService_ApplyDisclosure(t *testing.T) {
+	ctx := context.Background()
+	req := &StagingRequest{ImageID: "img-123"}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 200, 100))); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	input := buf.Bytes()
+
+	tests := []struct {
+		name        string
+		repo        disclosure.Repository
+		wantErr     bool
+		wantChanged bool
+	}{
+		{
+			name: "success: disabled returns input",
+		},
+		{
+			name: "success: project without banner returns input",
+			repo: &disclosure.RepositoryMock{
+				ForImageFunc: func(ctx context.Context, imageID string) (*disclosure.Settings, error) {
+					return nil, nil
+				},
+			},
+		},
+		{
+			name: "success: renders banner",
+			repo: &disclosure.RepositoryMock{
+				ForImageFunc: func(ctx context.Context, imageID string) (*disclosure.Settings, error) {
+					return &disclosure.Settings{Locale: "en", Position: disclosure.PositionBottomRight, Opacity: 0.6}, nil
+				},
+			},
+			wantChanged: true,
+		},
+		{
+			name: "fail: lookup error",
+			repo: &disclosure.RepositoryMock{
+				ForImageFunc: func(ctx context.Context, imageID string) (*disclosure.Settings, error) {
+					return nil, errors.New("db down")
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &DefaultService{disclosures: tt.repo}
+			got, err := s.applyDisclosure(ctx, input, req)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if changed := !bytes.Equal(got, input); changed != tt.wantChanged {
+				t.Errorf("expected changed=%v, got %v", tt.wantChanged, changed)
+			}
+		})
+	}
+}
+
+func TestDefaultService_StageImage_ResumeFromOutput(t *testing.T) {
+	// No clients: a recorded output must not touch S3 or the provider.
+	service := &DefaultService{bucketName: "test-bucket"}
+
+	url, err := service.StageImage(context.Background(), &StagingRequest{
+		ImageID:     "2e1aa86e-0f27-4f0f-9e57-0d7b53b4d9b9",
+		OriginalURL: "s3://test-bucket/uploads/a.jpg",
+		Resume:      checkpoint.Checkpoint{PredictionID: "pred-1", OutputKey: "staged/2e1aa86e/out.jpg"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if url != "s3://test-bucket/staged/2e1aa86e/out.jpg" {
+		t.Errorf("unexpected staged URL: %s", url)
+	}
+}
+
+func TestDefaultService_Pipeline(t *testing.T) {
+	t.Run("success: extra stages run in phase order around the built-ins", func(t *testing.T) {
+		noop := func(ctx context.Context, a *Artifact) error { return nil }
+		s, err := withPipeline(&DefaultService{}, []pipeline.Registration[*Artifact]{
+			{Stage: pipeline.StageFunc[*Artifact]{StageName: "thumbnail", Fn: noop}, Phase: pipeline.PhasePublish, Order: 10},
+			{Stage: pipeline.StageFunc[*Artifact]{StageName: "face_blur", Fn: noop}, Phase: pipeline.PhasePostProcess, Order: 300},
+			{Stage: pipeline.StageFunc[*Artifact]{StageName: "moderation", Fn: noop}, Phase: pipeline.PhasePreProcess},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []string{
+			"raw_convert", "hdr_merge", "perspective", "moderation", "predict", "disclosure", "face_blur",
+			"watermark", "provenance", "upload", "thumbnail",
+		}
+		if got := s.PipelineStages(); !slices.Equal(got, want) {
+			t.Errorf("expected stages %v, got %v", want, got)
+		}
+	})
+
+	t.Run("fail: duplicate built-in stage name", func(t *testing.T) {
+		_, err := withPipeline(&DefaultService{}, []pipeline.Registration[*Artifact]{
+			{Stage: pipeline.StageFunc[*Artifact]{StageName: StageUpload}, Phase: pipeline.PhasePublish},
+		})
+		if err == nil || !strings.Contains(err.Error(), `stage "upload" already registered`) {
+			t.Errorf("expected duplicate stage error, got %v", err)
+		}
+	})
+
+	t.Run("fail: failing stage returns its own error", func(t *testing.T) {
+		rejected := errors.New("image rejected by moderation")
+		s, err := withPipeline(&DefaultService{bucketName: "test-bucket"}, []pipeline.Registration[*Artifact]{
+			{
+				Stage: pipeline.StageFunc[*Artifact]{StageName: "moderation", Fn: func(ctx context.Context, a *Artifact) error {
+					return rejected
+				}},
+				Phase: pipeline.PhasePreProcess,
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_, err = s.StageImage(context.Background(), &StagingRequest{
+			ImageID:     "2e1aa86e-0f27-4f0f-9e57-0d7b53b4d9b9",
+			OriginalURL: "s3://test-bucket/uploads/a.jpg",
+		})
+		if err != rejected {
+			t.Errorf("expected %v, got %v", rejected, err)
+		}
+		for _, stats := range s.PipelineMetrics() {
+			if stats.Name == "moderation" && (stats.Runs != 1 || stats.Failures != 1) {
+				t.Errorf("unexpected moderation metrics: %+v", stats)
+			}
+			if stats.Name == StagePredict && stats.Runs != 0 {
+				t.Errorf("predict ran after a failed pre-process stage: %+v", stats)
+			}
+		}
+	})
+}
+
+func TestDefaultService_ResumePrediction(t *testing.T) {
+	prevInterval := predictionPollInterval
+	predictionPollInterval = 5 * time.Millisecond
+	t.Cleanup(func() { predictionPollInterval = prevInterval })
+
+	testCases := []struct {
+		name   string
+		status int
+		body   string
+		want   []byte
+	}{
+		{
+			name:   "success: succeeded prediction output is reused",
+			status: http.StatusOK,
+			body:   `{"id":"pred-1","status":"succeeded","output":"%s/output.jpg"}`,
+			want:   []byte("staged-bytes"),
+		},
+		{
+			name:   "success: failed prediction is not resumed",
+			status: http.StatusOK,
+			body:   `{"id":"pred-1","status":"failed","error":"nsfw"}`,
+		},
+		{
+			name:   "success: unknown prediction is not resumed",
+			status: http.StatusNotFound,
+			body:   `{"detail":"Not found."}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var srv *httptest.Server
+			srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/predictions/pred-1":
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(tc.status)
+					body := tc.body
+					if strings.Contains(body, "%s") {
+						body = fmt.Sprintf(body, srv.URL)
+					}
+					_, _ = w.Write([]byte(body))
+				case "/output.jpg":
+					_, _ = w.Write([]byte("staged-bytes"))
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer srv.Close()
+
+			client, err := replicate.NewClient(replicate.WithToken("test-token"), replicate.WithBaseURL(srv.URL))
+			if err != nil {
+				t.Fatalf("unexpected error creating client: %v", err)
+			}
+			service := &DefaultService{replicateClient: client}
+
+			got := service.resumePrediction(context.Background(), "img-1", "pred-1", nil)
+			if !bytes.Equal(got, tc.want) {
+				t.Errorf("resumePrediction() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDefaultService_ProviderRecorder(t *testing.T) {
+	var gotVersion, gotResponse string
+	recorder := &checkpoint.RepositoryMock{
+		RecordProviderResponseFunc: func(ctx context.Context, imageID, modelVersion, response string) error {
+			gotVersion, gotResponse = modelVersion, response
+			return nil
+		},
+	}
+	service := &DefaultService{modelID: "owner/model"}
+
+	onDone := service.providerRecorder(context.Background(), &StagingRequest{ImageID: "img-1", Checkpoints: recorder})
+	onDone(&replicate.Prediction{
+		ID:     "pred-1",
+		Status: replicate.Succeeded,
+		Input:  replicate.PredictionInput{"image": "data:image/jpeg;base64,AAAA"},
+		Output: "https://cdn.example.com/out.jpg",
+	})
+
+	if gotVersion != "owner/model" {
+		t.Errorf("model version = %q, want the configured model when the provider omits it", gotVersion)
+	}
+	if !strings.Contains(gotResponse, `"id":"pred-1"`) || !strings.Contains(gotResponse, "out.jpg") {
+		t.Errorf("unexpected provider response: %s", gotResponse)
+	}
+	if strings.Contains(gotResponse, "base64") {
+		t.Errorf("provider response must not include the input image: %s", gotResponse)
+	}
+
+	if service.providerRecorder(context.Background(), &StagingRequest{ImageID: "img-1"}) != nil {
+		t.Error("expected no recorder without checkpoints")
+	}
+}
+
+func TestDefaultService_PredictionObserver(t *testing.T) {
+	logs := func(s string) *string { return &s }
+	var lines []string
+	jobLog := &joblog.RecorderMock{
+		AppendFunc: func(ctx context.Context, imageID, line string) error {
+			lines = append(lines, line)
+			return nil
+		},
+	}
+	var recorded int
+	checkpoints := &checkpoint.RepositoryMock{
+		RecordProviderResponseFunc: func(ctx context.Context, imageID, modelVersion, response string) error {
+			recorded++
+			return nil
+		},
+	}
+	service := &DefaultService{modelID: "owner/model"}
+	onPoll := service.predictionObserver(context.Background(),
+		&StagingRequest{ImageID: "img-1", Checkpoints: checkpoints, Log: jobLog})
+
+	onPoll(&replicate.Prediction{Status: replicate.Starting})
+	onPoll(&replicate.Prediction{Status: replicate.Processing, Logs: logs("loading model\n 10%")})
+	onPoll(&replicate.Prediction{Status: replicate.Processing, Logs: logs("loading model\n 10%")})
+	onPoll(&replicate.Prediction{Status: replicate.Processing, Logs: logs("loading model\n 10%\n 20%")})
+	onPoll(&replicate.Prediction{Status: replicate.Failed, Error: "out of memory"})
+
+	// The 20% line is throttled: it arrives within progressLogInterval of the 10% line.
+	want := []string{"Provider:  10%", "Prediction failed: out of memory"}
+	if !slices.Equal(lines, want) {
+		t.Errorf("job log lines = %q, want %q", lines, want)
+	}
+	if recorded != 1 {
+		t.Errorf("recorded %d provider responses, want only the final prediction", recorded)
+	}
+
+	if service.predictionObserver(context.Background(), &StagingRequest{ImageID: "img-1"}) != nil {
+		t.Error("expected no observer without a job log or checkpoints")
+	}
+}
+
+func TestDefaultService_EstimateCost(t *testing.T) {
+	testCases := []struct {
+		name    string
+		service *DefaultService
+		req     *StagingRequest
+		want    float64
+	}{
+		{
+			name:    "success: production model",
+			service: &DefaultService{modelID: model.ModelFluxKontextMax},
+			req:     &StagingRequest{},
+			want:    GetModelCost(model.ModelFluxKontextMax),
+		},
+		{
+			name:    "success: resumed prediction is free",
+			service: &DefaultService{modelID: model.ModelFluxKontextMax},
+			req:     &StagingRequest{Resume: checkpoint.Checkpoint{PredictionID: "pred-1"}},
+		},
+		{
+			name:    "success: per-request sandbox uses the sandbox model",
+			service: &DefaultService{modelID: model.ModelFluxKontextMax, sandboxModelID: model.ModelQwenImageEdit},
+			req:     &StagingRequest{Sandbox: true},
+			want:    GetModelCost(model.ModelQwenImageEdit),
+		},
+		{
+			name: "success: ensemble adds the challenger",
+			service: &DefaultService{
+				modelID:  model.ModelFluxKontextMax,
+				ensemble: EnsembleConfig{Enabled: true, ChallengerModelID: model.ModelQwenImageEdit},
+			},
+			req:  &StagingRequest{},
+			want: GetModelCost(model.ModelFluxKontextMax) + GetModelCost(model.ModelQwenImageEdit),
+		},
+		{
+			name:    "success: global sandbox on the fake provider is free",
+			service: &DefaultService{modelID: model.ModelFluxKontextMax, sandbox: true},
+			req:     &StagingRequest{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.service.EstimateCost(tc.req); got != tc.want {
+				t.Errorf("EstimateCost() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDefaultService_RunEnsemble(t *testing.T) {
+	prevInterval := predictionPollInterval
+	predictionPollInterval = 5 * time.Millisecond
+	t.Cleanup(func() { predictionPollInterval = prevInterval })
+
+	const imageID = "2e1aa86e-0f27-4f0f-9e57-0d7b53b4d9b9"
+
+	// The primary returns a flat image and the challenger a detailed one, so the
+	// challenger should win.
+	encodePNG := func(img image.Image) []byte {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			t.Fatalf("encode png: %v", err)
+		}
+		return buf.Bytes()
+	}
+	flat := image.NewRGBA(image.Rect(0, 0, 64, 64))
+	detailed := image.NewRGBA(image.Rect(0, 0, 64, 64))
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			flat.Set(x, y, color.RGBA{R: 128, G: 128, B: 128, A: 255})
+			v := uint8(40)
+			if (x+y)%2 == 0 {
+				v = 210
+			}
+			detailed.Set(x, y, color.RGBA{R: v, G: uint8(4 * y), B: 255 - v, A: 255})
+		}
+	}
+	outputs := map[string][]byte{"/out-1.png": encodePNG(flat), "/out-2.png": encodePNG(detailed)}
+
+	var (
+		mu   sync.Mutex
+		puts []string
+	)
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/predictions":
+			var body struct {
+				Input map[string]any `json:"input"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode prediction request: %v", err)
+			}
+			id := "pred-1"
+			if strings.HasSuffix(body.Input["prompt"].(string), " Use warm lighting.") {
+				id = "pred-2"
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"id":%q,"status":"starting"}`, id)
+		case strings.HasPrefix(r.URL.Path, "/predictions/"):
+			id := strings.TrimPrefix(r.URL.Path, "/predictions/")
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"id":%q,"status":"succeeded","output":"%s/out-%s.png"}`,
+				id, srv.URL, strings.TrimPrefix(id, "pred-"))
+		case r.Method == http.MethodGet && outputs[r.URL.Path] != nil:
+			_, _ = w.Write(outputs[r.URL.Path])
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/test-bucket/"):
+			mu.Lock()
+			puts = append(puts, strings.TrimPrefix(r.URL.Path, "/test-bucket/"))
+			mu.Unlock()
+			_, _ = io.Copy(io.Discard, r.Body)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := replicate.NewClient(replicate.WithToken("test-token"), replicate.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	s3Client := s3.New(s3.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(srv.URL),
+		UsePathStyle: true,
+		Credentials:  credentials.NewStaticCredentialsProvider("test", "test", ""),
+	})
+
+	var recorded ensemble.Comparison
+	var recordedResponse string
+	service := &DefaultService{
+		s3Client:        s3Client,
+		bucketName:      "test-bucket",
+		replicateClient: client,
+		modelID:         model.ModelQwenImageEdit,
+		registry:        model.NewModelRegistry(),
+		ensemble: EnsembleConfig{
+			Enabled:                true,
+			ChallengerModelID:      model.ModelQwenImageEdit,
+			ChallengerPromptSuffix: " Use warm lighting.",
+			SampleRate:             1,
+			Comparisons: &ensemble.RepositoryMock{
+				RecordFunc: func(ctx context.Context, c ensemble.Comparison) error {
+					recorded = c
+					return nil
+				},
+			},
+		},
+	}
+	req := &StagingRequest{
+		ImageID: imageID,
+		Checkpoints: &checkpoint.RepositoryMock{
+			RecordProviderResponseFunc: func(ctx context.Context, imageID, modelVersion, response string) error {
+				recordedResponse = response
+				return nil
+			},
+		},
+	}
+
+	out, stagedBy, err := service.runEnsemble(context.Background(), req, model.ModelQwenImageEdit,
+		&model.ModelInputRequest{ImageDataURL: "data:image/png;base64,AAAA", Prompt: "stage this room"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(out, outputs["/out-2.png"]) {
+		t.Error("expected the challenger's output to be selected")
+	}
+	if stagedBy != model.ModelQwenImageEdit {
+		t.Errorf("stagedBy = %q", stagedBy)
+	}
+	if recorded.ImageID != imageID || recorded.Selected != 1 || len(recorded.Candidates) != 2 {
+		t.Fatalf("unexpected comparison: %+v", recorded)
+	}
+	for i, c := range recorded.Candidates {
+		if c.Score == nil || c.PredictionID != fmt.Sprintf("pred-%d", i+1) {
+			t.Errorf("candidate %d: unexpected %+v", i, c)
+		}
+		if want := candidateKey(imageID, i); c.OutputKey != want {
+			t.Errorf("candidate %d: output key = %q, want %q", i, c.OutputKey, want)
+		}
+	}
+	if recorded.Candidates[1].Prompt != "stage this room Use warm lighting." {
+		t.Errorf("unexpected challenger prompt: %q", recorded.Candidates[1].Prompt)
+	}
+	if len(puts) != 2 {
+		t.Errorf("expected both candidates kept in S3, got %v", puts)
+	}
+	if !strings.Contains(recordedResponse, `"id":"pred-2"`) {
+		t.Errorf("expected the winner's provider response to be recorded, got %s", recordedResponse)
+	}
+}
+
+func TestWithCatalog(t *testing.T) {
+	if got := withCatalog("stage this room", nil); got != "stage this room" {
+		t.Errorf("withCatalog(nil) = %q, want the prompt unchanged", got)
+	}
+	got := withCatalog("stage this room", &Catalog{Name: "Nordic Home", PromptFragment: "pale oak, linen, wool throws"})
+	want := "stage this room\n- Furnish the room from the Nordic Home collection: pale oak, linen, wool throws"
+	if got != want {
+		t.Errorf("withCatalog() = %q, want %q", got, want)
+	}
+}
+
+func TestWithInstructions(t *testing.T) {
+	testCases := []struct {
+		name         string
+		instructions string
+		want         string
+	}{
+		{name: "success: empty leaves the prompt unchanged", want: "stage this room"},
+		{name: "success: whitespace leaves the prompt unchanged", instructions: "  ", want: "stage this room"},
+		{
+			name:         "success: instructions appended",
+			instructions: " keep the fireplace visible ",
+			want:         "stage this room\n- Additional instructions: keep the fireplace visible",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := withInstructions("stage this room", tc.instructions); got != tc.want {
+				t.Errorf("withInstructions() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWithAnnotations(t *testing.T) {
+	wall, ceiling := 4.2, 2.4
+
+	testCases := []struct {
+		name        string
+		annotations *Annotations
+		want        []string
+		dontWant    []string
+	}{
+		{name: "success: nil leaves the prompt unchanged", dontWant: []string{"real-world"}},
+		{
+			name:        "success: wall length only",
+			annotations: &Annotations{WallLengthM: &wall},
+			want:        []string{"wall facing the camera is 4.2 m (13.8 ft) long", "real-world dimensions"},
+			dontWant:    []string{"ceiling"},
+		},
+		{
+			name:        "success: wall length and ceiling height",
+			annotations: &Annotations{WallLengthM: &wall, CeilingHeightM: &ceiling},
+			want:        []string{"4.2 m (13.8 ft) long", "ceiling is 2.4 m (7.9 ft) high"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := withAnnotations("stage this room", tc.annotations)
+			if !strings.HasPrefix(got, "stage this room") {
+				t.Errorf("withAnnotations() = %q, want the base prompt first", got)
+			}
+			for _, w := range tc.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("withAnnotations() = %q, want it to contain %q", got, w)
+				}
+			}
+			for _, w := range tc.dontWant {
+				if strings.Contains(got, w) {
+					t.Errorf("withAnnotations() = %q, want it not to contain %q", got, w)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultService_ReferenceImages(t *testing.T) {
+	refPNG := []byte("\x89PNG\r\n\x1a\nreference")
+	inspirationJPEG := []byte("\xff\xd8\xff\xe0inspiration")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/test-bucket/catalogs/nordic/sofa.png":
+			_, _ = w.Write(refPNG)
+		case "/test-bucket/uploads/u1/inspiration.jpg":
+			_, _ = w.Write(inspirationJPEG)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	service := &DefaultService{
+		s3Client: s3.New(s3.Options{
+			Region:       "us-east-1",
+			BaseEndpoint: aws.String(srv.URL),
+			UsePathStyle: true,
+			Credentials:  credentials.NewStaticCredentialsProvider("test", "test", ""),
+		}),
+		bucketName: "test-bucket",
+		registry:   model.NewModelRegistry(),
+	}
+	req := &StagingRequest{
+		ImageID:           "img-1",
+		ReferenceImageURL: "s3://test-bucket/uploads/u1/inspiration.jpg",
+		Catalog: &Catalog{
+			ID: "cat-1",
+			ReferenceImageURLs: []string{
+				"s3://test-bucket/catalogs/nordic/missing.png",
+				"s3://test-bucket/catalogs/nordic/sofa.png",
+				"https://cdn.example.com/nordic/rug.jpg",
+				"https://cdn.example.com/nordic/lamp.jpg",
+			},
+		},
+	}
+
+	testCases := []struct {
+		name  string
+		limit int
+		want  []string
+	}{
+		{
+			name:  "success: model without reference support gets none",
+			limit: 0,
+		},
+		{
+			name:  "success: inspiration photo first, then catalog references up to the limit",
+			limit: 2,
+			want: []string{
+				"data:image/jpeg;base64," + base64.StdEncoding.EncodeToString(inspirationJPEG),
+				"data:image/png;base64," + base64.StdEncoding.EncodeToString(refPNG),
+			},
+		},
+		{
+			name:  "success: skips unreadable references and passes https ones through",
+			limit: 3,
+			want: []string{
+				"data:image/jpeg;base64," + base64.StdEncoding.EncodeToString(inspirationJPEG),
+				"data:image/png;base64," + base64.StdEncoding.EncodeToString(refPNG),
+				"https://cdn.example.com/nordic/rug.jpg",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := service.referenceImages(context.Background(), req.ImageID, req.referenceImageURLs(), tc.limit)
+			if len(got) != len(tc.want) {
+				t.Fatalf("referenceImages() = %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("referenceImages()[%d] = %q, want %q", i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+
+	if got := service.maxReferenceImages(model.ModelQwenImageEditPlus); got != 2 {
+		t.Errorf("maxReferenceImages(qwen plus) = %d, want 2", got)
+	}
+	if got := service.maxReferenceImages(model.ModelQwenImageEdit); got != 0 {
+		t.Errorf("maxReferenceImages(qwen) = %d, want 0", got)
+	}
+}
+
+func TestDefaultService_StoreFor(t *testing.T) {
+	platform := s3.New(s3.Options{Region: "us-east-1"})
+	customer := s3.New(s3.Options{Region: "eu-west-1"})
+
+	testCases := []struct {
+		name       string
+		url        string
+		noSource   bool
+		lookupErr  error
+		wantBucket string
+		wantErr    bool
+	}{
+		{name: "success: customer bucket", url: "https://acme-photos.s3.eu-west-1.amazonaws.com/uploads/a.jpg",
+			wantBucket: "acme-photos"},
+		{name: "success: customer s3 url", url: "s3://acme-photos/staged/a.jpg", wantBucket: "acme-photos"},
+		{name: "success: platform bucket", url: "s3://test-bucket/uploads/a.jpg", wantBucket: "test-bucket"},
+		{name: "success: path-style url", url: "http://localhost:9000/test-bucket/uploads/a.jpg",
+			wantBucket: "test-bucket"},
+		{name: "success: region bucket", url: "https://eu-bucket.s3.eu-central-1.amazonaws.com/uploads/a.jpg",
+			wantBucket: "eu-bucket"},
+		{name: "success: path-style region url", url: "http://localhost:9000/eu-bucket/uploads/a.jpg",
+			wantBucket: "eu-bucket"},
+		{name: "success: unconfigured bucket falls back", url: "s3://other/uploads/a.jpg", wantBucket: "test-bucket"},
+		{name: "success: customer buckets disabled", url: "s3://acme-photos/uploads/a.jpg", noSource: true,
+			wantBucket: "test-bucket"},
+		{name: "fail: lookup error", url: "s3://acme-photos/uploads/a.jpg", lookupErr: errors.New("db down"),
+			wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := &DefaultService{
+				s3Client:   platform,
+				bucketName: "test-bucket",
+				regions:    s3client.NewBuckets("test-bucket", platform, map[string]string{"eu-central-1": "eu-bucket"}),
+			}
+			if !tc.noSource {
+				service.customerBuckets = &byobucket.SourceMock{
+					ClientFunc: func(ctx context.Context, name string) (*s3.Client, error) {
+						if tc.lookupErr != nil {
+							return nil, tc.lookupErr
+						}
+						if name == "acme-photos" {
+							return customer, nil
+						}
+						return nil, nil
+					},
+				}
+			}
+
+			store, err := service.storeFor(context.Background(), tc.url)
+			if tc.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "db down") {
+					t.Fatalf("expected lookup error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if store.bucket != tc.wantBucket {
+				t.Errorf("expected bucket %s, got %s", tc.wantBucket, store.bucket)
+			}
+			switch tc.wantBucket {
+			case "acme-photos":
+				if store.client != customer {
+					t.Errorf("expected the customer client")
+				}
+			case "eu-bucket":
+				if region := store.client.Options().Region; region != "eu-central-1" {
+					t.Errorf("expected a client for eu-central-1, got %s", region)
+				}
+			default:
+				if store.client != platform {
+					t.Errorf("expected the platform client")
+				}
+			}
+			if got, want := store.objectURL("staged/x"), "s3://"+tc.wantBucket+"/staged/x"; got != want {
+				t.Errorf("expected object URL %s, got %s", want, got)
+			}
+		})
+	}
+}
+
+func TestObjectStore_KeyPrefix(t *testing.T) {
+	service := &DefaultService{bucketName: "test-bucket", keyPrefix: "sandbox/"}
+	store := service.platformStore()
+
+	testCases := []struct {
+		name    string
+		fileKey string
+		want    string
+	}{
+		{name: "success: output key is prefixed", fileKey: "staged/0a1b2c3d/x-staged.jpg",
+			want: "s3://test-bucket/sandbox/staged/0a1b2c3d/x-staged.jpg"},
+		{name: "success: key already under the prefix is kept", fileKey: "sandbox/uploads/u/a.jpg",
+			want: "s3://test-bucket/sandbox/uploads/u/a.jpg"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := store.objectURL(tc.fileKey); got != tc.want {
+				t.Errorf("expected object URL %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
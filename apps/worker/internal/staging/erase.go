@@ -0,0 +1,150 @@
+package staging
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/s3client"
+	"github.com/real-staging-ai/worker/internal/staging/model"
+)
+
+// eraseModelID is the inpainting model object erasures run on.
+const eraseModelID = model.ModelLamaInpaint
+
+// EraseRequest contains the parameters for erasing an object from an image.
+type EraseRequest struct {
+	EditID  string
+	ImageID string
+	// BaseURL is the s3:// URL of the image version to edit.
+	BaseURL string
+	// BaseStaged reports whether BaseURL is the staged output, which already carries the
+	// project's disclosure banner.
+	BaseStaged bool
+	// MaskURL is the s3:// URL of the mask; white pixels mark the area to erase.
+	MaskURL string
+	// Sandbox returns the base unchanged instead of calling the inpainting model.
+	Sandbox bool
+}
+
+// editKey is the S3 key of an edit's result. It sits next to the staged output so it is
+// cleaned up with the image.
+func editKey(imageID, editID string) string {
+	return fmt.Sprintf("staged/%s/%s-edit-%s", imageID[:8], imageID, editID)
+}
+
+// EraseObject fills in the area the mask covers with the inpainting model and uploads the
+// result next to the base. An edit of the original gets the project's disclosure banner,
+// as staging would; an edit of the staged output keeps the one it has. Neither changes
+// the image's staged output.
+func (s *DefaultService) EraseObject(ctx context.Context, req *EraseRequest) (string, error) {
+	log := logging.Default()
+	tracer := otel.Tracer("real-staging-worker/staging")
+	ctx, span := tracer.Start(ctx, "staging.EraseObject")
+	span.SetAttributes(
+		attribute.String("image.id", req.ImageID),
+		attribute.String("edit.id", req.EditID),
+		attribute.Bool("edit.base_staged", req.BaseStaged),
+	)
+	defer span.End()
+
+	store, err := s.storeFor(ctx, req.BaseURL)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "bucket lookup failed")
+		return "", err
+	}
+	base, err := s.readS3Object(ctx, req.BaseURL)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "base download failed")
+		return "", fmt.Errorf("failed to read image to edit: %w", err)
+	}
+
+	out := base
+	modelID := eraseModelID
+	if s.sandbox || req.Sandbox {
+		log.Info(ctx, "Sandbox edit; returning the image unchanged", "edit_id", req.EditID)
+		modelID = ""
+	} else {
+		mask, err := s.readS3Object(ctx, req.MaskURL)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "mask download failed")
+			return "", fmt.Errorf("failed to read mask: %w", err)
+		}
+		outputURL, err := s.callReplicateAPI(ctx, modelID, &model.ModelInputRequest{
+			ImageDataURL: encodeDataURL(base),
+			MaskDataURL:  encodeDataURL(mask),
+		}, nil, nil)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "prediction failed")
+			return "", fmt.Errorf("failed to erase object with Replicate: %w", err)
+		}
+		if out, err = s.downloadFromURL(ctx, outputURL); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "output download failed")
+			return "", fmt.Errorf("failed to download edited image: %w", err)
+		}
+	}
+
+	stagingReq := &StagingRequest{ImageID: req.ImageID}
+	if !req.BaseStaged {
+		if out, err = s.applyDisclosure(ctx, out, stagingReq); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "disclosure failed")
+			return "", fmt.Errorf("failed to apply disclosure: %w", err)
+		}
+	}
+	out = s.embedProvenance(ctx, out, stagingReq, modelID)
+
+	key := editKey(req.ImageID, req.EditID)
+	if err := s.putObject(ctx, store, key, bytes.NewReader(out), http.DetectContentType(out)); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "PutObject failed")
+		return "", err
+	}
+	span.SetStatus(codes.Ok, "object erased")
+	return store.objectURL(key), nil
+}
+
+// EstimateEraseCost returns what erasing an object for req is expected to cost with the
+// provider; sandbox edits are free.
+func (s *DefaultService) EstimateEraseCost(req *EraseRequest) float64 {
+	if s.sandbox || req.Sandbox {
+		return 0
+	}
+	return GetModelCost(eraseModelID)
+}
+
+// readS3Object downloads an s3:// URL from its bucket.
+func (s *DefaultService) readS3Object(ctx context.Context, rawURL string) ([]byte, error) {
+	store, err := s.storeFor(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	key, err := s3client.KeyIn(rawURL, store.bucket)
+	if err != nil {
+		return nil, err
+	}
+	body, err := s.download(ctx, store, key)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = body.Close() }()
+	return io.ReadAll(body)
+}
+
+// encodeDataURL encodes an image as a data: URL for the provider.
+func encodeDataURL(img []byte) string {
+	return fmt.Sprintf("data:%s;base64,%s", http.DetectContentType(img), base64.StdEncoding.EncodeToString(img))
+}
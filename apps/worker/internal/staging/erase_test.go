@@ -0,0 +1,205 @@
+package staging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/replicate/replicate-go"
+
+	"github.com/real-staging-ai/worker/internal/disclosure"
+	"github.com/real-staging-ai/worker/internal/staging/model"
+)
+
+func TestDefaultService_EraseObject(t *testing.T) {
+	prevInterval := predictionPollInterval
+	predictionPollInterval = 5 * time.Millisecond
+	t.Cleanup(func() { predictionPollInterval = prevInterval })
+
+	const (
+		imageID = "2e1aa86e-0f27-4f0f-9e57-0d7b53b4d9b9"
+		editID  = "7c4b8f9e-3d1a-4b2c-9e8f-1a2b3c4d5e6f"
+	)
+	base := []byte("\x89PNG\r\n\x1a\nbase")
+	mask := []byte("\x89PNG\r\n\x1a\nmask")
+	erased := []byte("\x89PNG\r\n\x1a\nerased")
+
+	var (
+		mu     sync.Mutex
+		puts   map[string][]byte
+		inputs []map[string]any
+	)
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/test-bucket/staged/base.png":
+			_, _ = w.Write(base)
+		case r.Method == http.MethodGet && r.URL.Path == "/test-bucket/uploads/mask.png":
+			_, _ = w.Write(mask)
+		case r.Method == http.MethodPost && r.URL.Path == "/predictions":
+			var body struct {
+				Input map[string]any `json:"input"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode prediction request: %v", err)
+			}
+			mu.Lock()
+			inputs = append(inputs, body.Input)
+			mu.Unlock()
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"id":"pred-1","status":"starting"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/predictions/pred-1":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"id":"pred-1","status":"succeeded","output":"%s/out.png"}`, srv.URL)
+		case r.Method == http.MethodGet && r.URL.Path == "/out.png":
+			_, _ = w.Write(erased)
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/test-bucket/"):
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			puts[strings.TrimPrefix(r.URL.Path, "/test-bucket/")] = body
+			mu.Unlock()
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := replicate.NewClient(replicate.WithToken("test-token"), replicate.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	s3Client := s3.New(s3.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(srv.URL),
+		UsePathStyle: true,
+		Credentials:  credentials.NewStaticCredentialsProvider("test", "test", ""),
+	})
+
+	testCases := []struct {
+		name           string
+		req            EraseRequest
+		wantOutput     []byte
+		wantPrediction bool
+		wantDisclosure bool
+		errSubstr      string
+	}{
+		{
+			name: "success: staged base is inpainted and keeps its banner",
+			req: EraseRequest{
+				BaseURL:    "s3://test-bucket/staged/base.png",
+				BaseStaged: true,
+				MaskURL:    "s3://test-bucket/uploads/mask.png",
+			},
+			wantOutput:     erased,
+			wantPrediction: true,
+		},
+		{
+			name: "success: original base gets the disclosure banner",
+			req: EraseRequest{
+				BaseURL: "s3://test-bucket/staged/base.png",
+				MaskURL: "s3://test-bucket/uploads/mask.png",
+			},
+			wantOutput:     erased,
+			wantPrediction: true,
+			wantDisclosure: true,
+		},
+		{
+			name: "success: sandbox returns the base unchanged",
+			req: EraseRequest{
+				BaseURL:    "s3://test-bucket/staged/base.png",
+				BaseStaged: true,
+				MaskURL:    "s3://test-bucket/uploads/mask.png",
+				Sandbox:    true,
+			},
+			wantOutput: base,
+		},
+		{
+			name:      "fail: base missing",
+			req:       EraseRequest{BaseURL: "s3://test-bucket/staged/gone.png", MaskURL: "s3://test-bucket/uploads/mask.png"},
+			errSubstr: "failed to read image to edit",
+		},
+		{
+			name:      "fail: mask missing",
+			req:       EraseRequest{BaseURL: "s3://test-bucket/staged/base.png", MaskURL: "s3://test-bucket/uploads/gone.png"},
+			errSubstr: "failed to read mask",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			puts, inputs = map[string][]byte{}, nil
+			disclosures := &disclosure.RepositoryMock{
+				ForImageFunc: func(ctx context.Context, id string) (*disclosure.Settings, error) {
+					return nil, nil
+				},
+			}
+			service := &DefaultService{
+				s3Client:        s3Client,
+				bucketName:      "test-bucket",
+				replicateClient: client,
+				registry:        model.NewModelRegistry(),
+				disclosures:     disclosures,
+			}
+			req := tc.req
+			req.ImageID, req.EditID = imageID, editID
+
+			got, err := service.EraseObject(context.Background(), &req)
+			if tc.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.errSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.errSubstr, err)
+				}
+				if len(puts) != 0 {
+					t.Errorf("expected nothing uploaded, got %v", puts)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			key := editKey(imageID, editID)
+			if want := "s3://test-bucket/" + key; got != want {
+				t.Errorf("result URL = %q, want %q", got, want)
+			}
+			if string(puts[key]) != string(tc.wantOutput) {
+				t.Errorf("uploaded %q, want %q", puts[key], tc.wantOutput)
+			}
+			if tc.wantPrediction {
+				if len(inputs) != 1 {
+					t.Fatalf("expected one prediction, got %d", len(inputs))
+				}
+				if inputs[0]["image"] != encodeDataURL(base) || inputs[0]["mask"] != encodeDataURL(mask) {
+					t.Errorf("unexpected prediction input: %v", inputs[0])
+				}
+			} else if len(inputs) != 0 {
+				t.Errorf("expected no prediction, got %d", len(inputs))
+			}
+			if consulted := len(disclosures.ForImageCalls()) > 0; consulted != tc.wantDisclosure {
+				t.Errorf("disclosure consulted = %v, want %v", consulted, tc.wantDisclosure)
+			}
+		})
+	}
+}
+
+func TestDefaultService_EstimateEraseCost(t *testing.T) {
+	service := &DefaultService{}
+	if got, want := service.EstimateEraseCost(&EraseRequest{}), GetModelCost(model.ModelLamaInpaint); got != want {
+		t.Errorf("EstimateEraseCost() = %v, want %v", got, want)
+	}
+	if got := service.EstimateEraseCost(&EraseRequest{Sandbox: true}); got != 0 {
+		t.Errorf("sandbox EstimateEraseCost() = %v, want 0", got)
+	}
+	if got := (&DefaultService{sandbox: true}).EstimateEraseCost(&EraseRequest{}); got != 0 {
+		t.Errorf("sandbox service EstimateEraseCost() = %v, want 0", got)
+	}
+}
@@ -0,0 +1,47 @@
+package staging
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/real-staging-ai/worker/internal/hdr"
+)
+
+// mergeExposures reads the original and the other exposures of its shot and merges them
+// into an HDR base image.
+func (s *DefaultService) mergeExposures(ctx context.Context, req *StagingRequest) ([]byte, error) {
+	tracer := otel.Tracer("real-staging-worker/staging")
+	ctx, span := tracer.Start(ctx, "staging.mergeExposures")
+	span.SetAttributes(attribute.Int("hdr.exposures", len(req.BracketURLs)+1))
+	defer span.End()
+
+	original, err := s.readOriginal(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "original download failed")
+		return nil, err
+	}
+	exposures := [][]byte{original}
+	for _, rawURL := range req.BracketURLs {
+		img, err := s.readS3Object(ctx, rawURL)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "exposure download failed")
+			return nil, fmt.Errorf("failed to read exposure %s: %w", rawURL, err)
+		}
+		exposures = append(exposures, img)
+	}
+
+	merged, err := hdr.Merge(exposures)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "merge failed")
+		return nil, err
+	}
+	span.SetStatus(codes.Ok, "exposures merged")
+	return merged, nil
+}
@@ -0,0 +1,158 @@
+package staging
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/real-staging-ai/worker/internal/checkpoint"
+)
+
+// bracket renders a 64x48 gradient exposure at gain.
+func bracket(t *testing.T, gain float64) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 64, 48))
+	for y := range 48 {
+		for x := range 64 {
+			v := uint8(min(float64(x*4+y)*gain, 255))
+			img.Set(x, y, color.RGBA{R: v, G: v, B: v / 2, A: 0xff})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("encode exposure: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDefaultService_hdrMergeStage(t *testing.T) {
+	objects := map[string][]byte{
+		"/test-bucket/uploads/u/shot.png":   bracket(t, 1),
+		"/test-bucket/uploads/u/shot+2.png": bracket(t, 4),
+		"/test-bucket/uploads/u/shot-2.png": bracket(t, 0.25),
+	}
+	var (
+		gets int
+		puts = map[string][]byte{}
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			puts[r.URL.Path], _ = io.ReadAll(r.Body)
+			return
+		}
+		gets++
+		if b, ok := objects[r.URL.Path]; ok && r.Method == http.MethodGet {
+			_, _ = w.Write(b)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+	service := &DefaultService{
+		s3Client: s3.New(s3.Options{
+			Region:       "us-east-1",
+			BaseEndpoint: aws.String(srv.URL),
+			UsePathStyle: true,
+			Credentials:  credentials.NewStaticCredentialsProvider("test", "test", ""),
+		}),
+		bucketName: "test-bucket",
+	}
+
+	testCases := []struct {
+		name      string
+		brackets  []string
+		resume    checkpoint.Checkpoint
+		wantMerge bool
+		wantGets  int
+		errSubstr string
+	}{
+		{
+			name:      "success: exposures are merged",
+			brackets:  []string{"s3://test-bucket/uploads/u/shot+2.png", "s3://test-bucket/uploads/u/shot-2.png"},
+			wantMerge: true,
+			wantGets:  3,
+		},
+		{name: "success: no brackets", wantGets: 0},
+		{
+			name:     "success: resumed prediction skips the merge",
+			brackets: []string{"s3://test-bucket/uploads/u/shot+2.png"},
+			resume:   checkpoint.Checkpoint{PredictionID: "pred-1"},
+			wantGets: 0,
+		},
+		{
+			name:      "fail: exposure missing",
+			brackets:  []string{"s3://test-bucket/uploads/u/gone.png"},
+			wantGets:  2,
+			errSubstr: "failed to read exposure s3://test-bucket/uploads/u/gone.png",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gets = 0
+			req := &StagingRequest{
+				ImageID:     "2e1aa86e-0f27-4f0f-9e57-0d7b53b4d9b9",
+				OriginalURL: "s3://test-bucket/uploads/u/shot.png",
+				BracketURLs: tc.brackets,
+				Resume:      tc.resume,
+			}
+
+			err := service.hdrMergeStage(context.Background(), &Artifact{Request: req})
+			if tc.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.errSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.errSubstr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gets != tc.wantGets {
+				t.Errorf("expected %d downloads, got %d", tc.wantGets, gets)
+			}
+			if !tc.wantMerge {
+				if req.input != nil {
+					t.Errorf("expected no merged input, got %d bytes", len(req.input))
+				}
+				return
+			}
+			merged, err := jpeg.Decode(bytes.NewReader(req.input))
+			if err != nil {
+				t.Fatalf("merged input is not a JPEG: %v", err)
+			}
+			if got := merged.Bounds().Size(); got != image.Pt(64, 48) {
+				t.Errorf("merged size = %v, want 64x48", got)
+			}
+		})
+	}
+
+	t.Run("success: the merge is staged instead of the original", func(t *testing.T) {
+		s, err := withPipeline(&DefaultService{s3Client: service.s3Client, bucketName: "test-bucket", sandbox: true}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, err = s.StageImage(context.Background(), &StagingRequest{
+			ImageID:     "2e1aa86e-0f27-4f0f-9e57-0d7b53b4d9b9",
+			OriginalURL: "s3://test-bucket/uploads/u/shot.png",
+			BracketURLs: []string{"s3://test-bucket/uploads/u/shot+2.png"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// The sandbox's fake provider returns its input as the output.
+		staged := puts["/test-bucket/"+stagedKey("2e1aa86e-0f27-4f0f-9e57-0d7b53b4d9b9")]
+		if _, err := jpeg.Decode(bytes.NewReader(staged)); err != nil {
+			t.Errorf("expected the merged JPEG to be staged, got %d bytes: %v", len(staged), err)
+		}
+	})
+}
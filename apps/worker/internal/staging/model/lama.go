@@ -0,0 +1,46 @@
+package model
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/replicate/replicate-go"
+)
+
+// LamaInputBuilder builds input parameters for the LaMa inpainting model, which erases
+// whatever a mask covers and fills the area in from its surroundings. It takes no prompt.
+type LamaInputBuilder struct{}
+
+// Ensure LamaInputBuilder implements ModelInputBuilder.
+var _ ModelInputBuilder = (*LamaInputBuilder)(nil)
+
+// NewLamaInputBuilder creates a new LamaInputBuilder.
+func NewLamaInputBuilder() *LamaInputBuilder {
+	return &LamaInputBuilder{}
+}
+
+// BuildInput creates the input parameters for the LaMa inpainting model.
+func (b *LamaInputBuilder) BuildInput(ctx context.Context, req *ModelInputRequest) (replicate.PredictionInput, error) {
+	if err := b.Validate(req); err != nil {
+		return nil, err
+	}
+
+	return replicate.PredictionInput{
+		"image": req.ImageDataURL,
+		"mask":  req.MaskDataURL,
+	}, nil
+}
+
+// Validate checks if the request is valid for the LaMa inpainting model.
+func (b *LamaInputBuilder) Validate(req *ModelInputRequest) error {
+	if req == nil {
+		return fmt.Errorf("request cannot be nil")
+	}
+	if req.ImageDataURL == "" {
+		return fmt.Errorf("image data URL is required")
+	}
+	if req.MaskDataURL == "" {
+		return fmt.Errorf("mask data URL is required")
+	}
+	return nil
+}
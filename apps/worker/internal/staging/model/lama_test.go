@@ -0,0 +1,76 @@
+package model
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLamaInputBuilder_BuildInput(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: builds input from image and mask", func(t *testing.T) {
+		req := &ModelInputRequest{
+			ImageDataURL: "data:image/jpeg;base64,/9j/4AAQSkZJRg==",
+			MaskDataURL:  "data:image/png;base64,iVBORw0KGgo=",
+		}
+
+		input, err := NewLamaInputBuilder().BuildInput(ctx, req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if input["image"] != req.ImageDataURL {
+			t.Errorf("expected image to be %s, got %v", req.ImageDataURL, input["image"])
+		}
+		if input["mask"] != req.MaskDataURL {
+			t.Errorf("expected mask to be %s, got %v", req.MaskDataURL, input["mask"])
+		}
+		if _, exists := input["prompt"]; exists {
+			t.Error("expected prompt to not be set")
+		}
+	})
+
+	t.Run("fail: validation error", func(t *testing.T) {
+		if _, err := NewLamaInputBuilder().BuildInput(ctx, &ModelInputRequest{}); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestLamaInputBuilder_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *ModelInputRequest
+		wantErr string
+	}{
+		{
+			name: "success: image and mask",
+			req:  &ModelInputRequest{ImageDataURL: "data:image/jpeg;base64,abc", MaskDataURL: "data:image/png;base64,def"},
+		},
+		{name: "fail: nil request", wantErr: "request cannot be nil"},
+		{
+			name:    "fail: missing image",
+			req:     &ModelInputRequest{MaskDataURL: "data:image/png;base64,def"},
+			wantErr: "image data URL is required",
+		},
+		{
+			name:    "fail: missing mask",
+			req:     &ModelInputRequest{ImageDataURL: "data:image/jpeg;base64,abc", Prompt: "remove the chair"},
+			wantErr: "mask data URL is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewLamaInputBuilder().Validate(tt.req)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
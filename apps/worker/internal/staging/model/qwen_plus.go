@@ -0,0 +1,62 @@
+package model
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/replicate/replicate-go"
+)
+
+// QwenPlusInputBuilder builds input parameters for the Qwen Image Edit Plus model, which
+// takes the input image followed by any reference images in one list.
+type QwenPlusInputBuilder struct{}
+
+// Ensure QwenPlusInputBuilder implements ModelInputBuilder.
+var _ ModelInputBuilder = (*QwenPlusInputBuilder)(nil)
+
+// NewQwenPlusInputBuilder creates a new QwenPlusInputBuilder.
+func NewQwenPlusInputBuilder() *QwenPlusInputBuilder {
+	return &QwenPlusInputBuilder{}
+}
+
+// BuildInput creates the input parameters for the Qwen Image Edit Plus model.
+func (b *QwenPlusInputBuilder) BuildInput(
+	ctx context.Context, req *ModelInputRequest,
+) (replicate.PredictionInput, error) {
+	if err := b.Validate(req); err != nil {
+		return nil, err
+	}
+
+	images := make([]string, 0, 1+len(req.ReferenceImageURLs))
+	images = append(images, req.ImageDataURL)
+	images = append(images, req.ReferenceImageURLs...)
+
+	input := replicate.PredictionInput{
+		"image":          images,
+		"prompt":         req.Prompt,
+		"go_fast":        true,
+		"aspect_ratio":   "match_input_image",
+		"output_format":  "webp",
+		"output_quality": 80,
+	}
+
+	if req.Seed != nil {
+		input["seed"] = *req.Seed
+	}
+
+	return input, nil
+}
+
+// Validate checks if the request is valid for the Qwen Image Edit Plus model.
+func (b *QwenPlusInputBuilder) Validate(req *ModelInputRequest) error {
+	if req == nil {
+		return fmt.Errorf("request cannot be nil")
+	}
+	if req.ImageDataURL == "" {
+		return fmt.Errorf("image data URL is required")
+	}
+	if req.Prompt == "" {
+		return fmt.Errorf("prompt is required")
+	}
+	return nil
+}
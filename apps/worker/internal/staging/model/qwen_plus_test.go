@@ -0,0 +1,75 @@
+package model
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestQwenPlusInputBuilder_BuildInput(t *testing.T) {
+	ctx := context.Background()
+	seed := int64(7)
+
+	testCases := []struct {
+		name       string
+		req        *ModelInputRequest
+		wantImages []string
+		wantErr    string
+	}{
+		{
+			name:       "success: input image only",
+			req:        &ModelInputRequest{ImageDataURL: "data:image/jpeg;base64,AAAA", Prompt: "stage"},
+			wantImages: []string{"data:image/jpeg;base64,AAAA"},
+		},
+		{
+			name: "success: reference images follow the input image",
+			req: &ModelInputRequest{
+				ImageDataURL:       "data:image/jpeg;base64,AAAA",
+				Prompt:             "stage",
+				Seed:               &seed,
+				ReferenceImageURLs: []string{"https://cdn.example.com/sofa.jpg", "data:image/png;base64,BBBB"},
+			},
+			wantImages: []string{
+				"data:image/jpeg;base64,AAAA", "https://cdn.example.com/sofa.jpg", "data:image/png;base64,BBBB",
+			},
+		},
+		{
+			name:    "fail: missing prompt",
+			req:     &ModelInputRequest{ImageDataURL: "data:image/jpeg;base64,AAAA"},
+			wantErr: "prompt is required",
+		},
+		{
+			name:    "fail: missing image",
+			req:     &ModelInputRequest{Prompt: "stage"},
+			wantErr: "image data URL is required",
+		},
+		{
+			name:    "fail: nil request",
+			wantErr: "request cannot be nil",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input, err := NewQwenPlusInputBuilder().BuildInput(ctx, tc.req)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(input["image"], tc.wantImages) {
+				t.Errorf("image = %v, want %v", input["image"], tc.wantImages)
+			}
+			if input["prompt"] != "stage" {
+				t.Errorf("unexpected prompt: %v", input["prompt"])
+			}
+			if tc.req.Seed != nil && input["seed"] != *tc.req.Seed {
+				t.Errorf("unexpected seed: %v", input["seed"])
+			}
+		})
+	}
+}
@@ -12,8 +12,11 @@ type ModelID string
 
 // Supported models
 const (
-	ModelQwenImageEdit  ModelID = "qwen/qwen-image-edit"
-	ModelFluxKontextMax ModelID = "black-forest-labs/flux-kontext-max"
+	ModelQwenImageEdit     ModelID = "qwen/qwen-image-edit"
+	ModelQwenImageEditPlus ModelID = "qwen/qwen-image-edit-plus"
+	ModelFluxKontextMax    ModelID = "black-forest-labs/flux-kontext-max"
+	// ModelLamaInpaint erases masked objects for quick edits; it cannot stage a room.
+	ModelLamaInpaint ModelID = "allenhooo/lama"
 )
 
 // ModelInputRequest contains the parameters needed to build model input.
@@ -21,7 +24,12 @@ type ModelInputRequest struct {
 	ImageDataURL string
 	Prompt       string
 	Seed         *int64
-	// Future: additional parameters for other models
+	// ReferenceImageURLs condition the output on additional images, for models that accept
+	// them. Each is an https:// or data: URL. Callers trim it to the model's MaxReferenceImages.
+	ReferenceImageURLs []string
+	// MaskDataURL marks the area an inpainting model fills in, as a data: URL whose white
+	// pixels cover the area. Other models ignore it.
+	MaskDataURL string
 }
 
 // ModelInputBuilder defines the interface for building model-specific input parameters.
@@ -42,6 +50,9 @@ type ModelMetadata struct {
 	Description  string
 	Version      string
 	InputBuilder ModelInputBuilder
+	// MaxReferenceImages is how many reference images the model accepts besides the input
+	// image. Zero means the model does not support image conditioning.
+	MaxReferenceImages int
 }
 
 // ModelRegistry manages the available AI models and their configurations.
@@ -64,6 +75,16 @@ func NewModelRegistry() *ModelRegistry {
 		InputBuilder: NewQwenInputBuilder(),
 	})
 
+	// Register Qwen Image Edit Plus model
+	registry.Register(&ModelMetadata{
+		ID:                 ModelQwenImageEditPlus,
+		Name:               "Qwen Image Edit Plus",
+		Description:        "Multi-image editing model; stages with reference images for furniture catalogs",
+		Version:            "latest",
+		InputBuilder:       NewQwenPlusInputBuilder(),
+		MaxReferenceImages: 2,
+	})
+
 	// Register Flux Kontext Max model
 	registry.Register(&ModelMetadata{
 		ID:           ModelFluxKontextMax,
@@ -73,6 +94,15 @@ func NewModelRegistry() *ModelRegistry {
 		InputBuilder: NewFluxKontextInputBuilder(),
 	})
 
+	// Register LaMa inpainting model
+	registry.Register(&ModelMetadata{
+		ID:           ModelLamaInpaint,
+		Name:         "LaMa",
+		Description:  "Fast inpainting model that erases masked objects for quick edits",
+		Version:      "latest",
+		InputBuilder: NewLamaInputBuilder(),
+	})
+
 	return registry
 }
 
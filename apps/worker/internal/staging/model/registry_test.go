@@ -17,18 +17,32 @@ func TestNewModelRegistry(t *testing.T) {
 			t.Error("expected Qwen model to be registered")
 		}
 
+		// Verify Qwen Plus model is registered with reference image support
+		meta, err := registry.Get(ModelQwenImageEditPlus)
+		if err != nil {
+			t.Fatalf("expected Qwen Plus model to be registered: %v", err)
+		}
+		if meta.MaxReferenceImages == 0 {
+			t.Error("expected Qwen Plus model to accept reference images")
+		}
+
 		// Verify Flux Kontext model is registered
 		if !registry.Exists(ModelFluxKontextMax) {
 			t.Error("expected Flux Kontext model to be registered")
 		}
+
+		// Verify LaMa inpainting model is registered
+		if !registry.Exists(ModelLamaInpaint) {
+			t.Error("expected LaMa model to be registered")
+		}
 	})
 
 	t.Run("success: registry has correct model count", func(t *testing.T) {
 		registry := NewModelRegistry()
 
 		models := registry.List()
-		if len(models) != 2 {
-			t.Errorf("expected 2 models to be registered, got %d", len(models))
+		if len(models) != 4 {
+			t.Errorf("expected 4 models to be registered, got %d", len(models))
 		}
 	})
 }
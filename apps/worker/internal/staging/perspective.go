@@ -0,0 +1,47 @@
+package staging
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/real-staging-ai/worker/internal/perspective"
+)
+
+// straightenVerticals corrects the keystoning and lean of the image being staged: the
+// HDR merge when there is one, otherwise the original.
+func (s *DefaultService) straightenVerticals(ctx context.Context, req *StagingRequest) ([]byte, perspective.Result, error) {
+	tracer := otel.Tracer("real-staging-worker/staging")
+	ctx, span := tracer.Start(ctx, "staging.straightenVerticals")
+	defer span.End()
+
+	img := req.input
+	if img == nil {
+		original, err := s.readOriginal(ctx, req)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "original download failed")
+			return nil, perspective.Result{}, err
+		}
+		img = original
+	}
+
+	out, res, err := perspective.Correct(img)
+	span.SetAttributes(
+		attribute.Bool("perspective.applied", res.Applied),
+		attribute.Float64("perspective.keystone_deg", res.Keystone),
+		attribute.Float64("perspective.roll_deg", res.Roll),
+	)
+	if err != nil {
+		if !errors.Is(err, perspective.ErrNoVerticals) && !errors.Is(err, perspective.ErrTooSkewed) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "correction failed")
+		}
+		return nil, res, err
+	}
+	span.SetStatus(codes.Ok, "verticals checked")
+	return out, res, nil
+}
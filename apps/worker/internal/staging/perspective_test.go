@@ -0,0 +1,156 @@
+package staging
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/real-staging-ai/worker/internal/checkpoint"
+)
+
+// tiltedRoom renders a 240x180 wall with door frames whose lines converge toward the top
+// by keystone, as the lean of the outermost frames.
+func tiltedRoom(t *testing.T, keystone float64) []byte {
+	t.Helper()
+	const w, h = 240, 180
+	cx, cy := float64(w-1)/2, float64(h-1)/2
+	k := keystone / (w / 2)
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			x0 := cx + (float64(x)-cx)/(1+k*(float64(y)-cy))
+			v := uint8(210)
+			for _, f := range []float64{0.1, 0.3, 0.5, 0.7, 0.9} {
+				if x0 > f*w-2 && x0 < f*w+2 {
+					v = 60
+				}
+			}
+			img.Set(x, y, color.RGBA{R: v, G: v, B: v, A: 0xff})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("encode room: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDefaultService_perspectiveStage(t *testing.T) {
+	objects := map[string][]byte{
+		"/test-bucket/uploads/u/tilted.png":   tiltedRoom(t, 0.12),
+		"/test-bucket/uploads/u/straight.png": tiltedRoom(t, 0),
+		"/test-bucket/uploads/u/corrupt.png":  []byte("not an image"),
+	}
+	var gets int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		if b, ok := objects[r.URL.Path]; ok && r.Method == http.MethodGet {
+			_, _ = w.Write(b)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+	service := &DefaultService{
+		s3Client: s3.New(s3.Options{
+			Region:       "us-east-1",
+			BaseEndpoint: aws.String(srv.URL),
+			UsePathStyle: true,
+			Credentials:  credentials.NewStaticCredentialsProvider("test", "test", ""),
+		}),
+		bucketName: "test-bucket",
+	}
+
+	testCases := []struct {
+		name        string
+		original    string
+		input       []byte
+		disabled    bool
+		resume      checkpoint.Checkpoint
+		wantCorrect bool
+		wantGets    int
+		errSubstr   string
+	}{
+		{name: "success: converging verticals are straightened", original: "tilted.png", wantCorrect: true, wantGets: 1},
+		{
+			name:        "success: the HDR merge is corrected instead of the original",
+			original:    "straight.png",
+			input:       tiltedRoom(t, 0.12),
+			wantCorrect: true,
+			wantGets:    0,
+		},
+		{name: "success: straight verticals are staged as shot", original: "straight.png", wantGets: 1},
+		{name: "success: not requested", original: "tilted.png", disabled: true, wantGets: 0},
+		{
+			name:     "success: resumed prediction skips the correction",
+			original: "tilted.png",
+			resume:   checkpoint.Checkpoint{PredictionID: "pred-1"},
+			wantGets: 0,
+		},
+		{name: "fail: original missing", original: "gone.png", wantGets: 1, errSubstr: "failed to correct perspective"},
+		{name: "fail: original corrupt", original: "corrupt.png", wantGets: 1, errSubstr: "decode photo"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gets = 0
+			req := &StagingRequest{
+				ImageID:            "2e1aa86e-0f27-4f0f-9e57-0d7b53b4d9b9",
+				OriginalURL:        "s3://test-bucket/uploads/u/" + tc.original,
+				CorrectPerspective: !tc.disabled,
+				Resume:             tc.resume,
+				input:              tc.input,
+			}
+
+			err := service.perspectiveStage(context.Background(), &Artifact{Request: req})
+			if tc.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.errSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.errSubstr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gets != tc.wantGets {
+				t.Errorf("expected %d downloads, got %d", tc.wantGets, gets)
+			}
+			if !tc.wantCorrect {
+				if !bytes.Equal(req.input, tc.input) {
+					t.Errorf("expected the input to be left alone, got %d bytes", len(req.input))
+				}
+				return
+			}
+			corrected, err := jpeg.Decode(bytes.NewReader(req.input))
+			if err != nil {
+				t.Fatalf("corrected input is not a JPEG: %v", err)
+			}
+			if got := corrected.Bounds().Size(); got != image.Pt(240, 180) {
+				t.Errorf("corrected size = %v, want 240x180", got)
+			}
+		})
+	}
+
+	t.Run("success: a photo without verticals is staged as shot", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 240, 180))); err != nil {
+			t.Fatalf("encode photo: %v", err)
+		}
+		req := &StagingRequest{OriginalURL: "s3://test-bucket/uploads/u/blank.png", CorrectPerspective: true, input: buf.Bytes()}
+		if err := service.perspectiveStage(context.Background(), &Artifact{Request: req}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !bytes.Equal(req.input, buf.Bytes()) {
+			t.Errorf("expected the input to be left alone")
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package staging
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/replicate/replicate-go"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/preview"
+)
+
+// previewInterval is the least time between previews of one prediction.
+var previewInterval = 5 * time.Second
+
+// previewKey is the S3 key of an image's latest preview. Each preview overwrites the last.
+func previewKey(imageID string) string {
+	return fmt.Sprintf("staged/%s/%s-preview.jpg", imageID[:8], imageID)
+}
+
+// previewPublisher returns a callback that publishes a low-resolution preview of each new
+// partial output a running prediction reports, at most once per previewInterval, or nil
+// when the request carries no preview publisher. Models that only report their output
+// once finished produce no preview. Previews skip post-processing: they are shown while
+// the job runs and replaced by the final output.
+func (s *DefaultService) previewPublisher(ctx context.Context, req *StagingRequest) func(*replicate.Prediction) {
+	if req.Previews == nil {
+		return nil
+	}
+	var last string
+	var lastAt time.Time
+	return func(pred *replicate.Prediction) {
+		if pred.Status != replicate.Processing {
+			return
+		}
+		outputURL := latestOutputURL(pred.Output)
+		if outputURL == "" || outputURL == last || time.Since(lastAt) < previewInterval {
+			return
+		}
+		last, lastAt = outputURL, time.Now()
+		if err := s.publishPreview(ctx, req, outputURL); err != nil {
+			logging.Default().Warn(ctx, "failed to publish preview", "image_id", req.ImageID, "error", err)
+		}
+	}
+}
+
+// publishPreview renders a preview of the partial output at outputURL, stores it next to
+// the staged output and hands its URL to the request's preview publisher.
+func (s *DefaultService) publishPreview(ctx context.Context, req *StagingRequest, outputURL string) error {
+	partial, err := s.downloadFromURL(ctx, outputURL)
+	if err != nil {
+		return fmt.Errorf("download partial output: %w", err)
+	}
+	img, err := preview.Render(partial)
+	if err != nil {
+		return fmt.Errorf("render preview: %w", err)
+	}
+	store := s.storeOf(req)
+	key := previewKey(req.ImageID)
+	if err := s.putObject(ctx, store, key, bytes.NewReader(img), "image/jpeg"); err != nil {
+		return err
+	}
+	return req.Previews.PublishPreview(ctx, req.ImageID, store.objectURL(key))
+}
+
+// latestOutputURL returns the newest output a prediction reported: the output itself when
+// it is a URL, or the last URL of a streamed list.
+func latestOutputURL(output any) string {
+	switch v := output.(type) {
+	case string:
+		return v
+	case []any:
+		for i := len(v) - 1; i >= 0; i-- {
+			if url, ok := v[i].(string); ok && url != "" {
+				return url
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,136 @@
+package staging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/replicate/replicate-go"
+
+	"github.com/real-staging-ai/worker/internal/preview"
+)
+
+func TestDefaultService_PreviewPublisher(t *testing.T) {
+	const imageID = "2e1aa86e-0f27-4f0f-9e57-0d7b53b4d9b9"
+
+	var partial bytes.Buffer
+	if err := png.Encode(&partial, image.NewRGBA(image.Rect(0, 0, 1024, 768))); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+
+	var (
+		mu   sync.Mutex
+		puts []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/partial-"):
+			_, _ = w.Write(partial.Bytes())
+		case r.Method == http.MethodGet && r.URL.Path == "/broken.png":
+			_, _ = w.Write([]byte("not an image"))
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/test-bucket/"):
+			mu.Lock()
+			puts = append(puts, strings.TrimPrefix(r.URL.Path, "/test-bucket/"))
+			mu.Unlock()
+			_, _ = io.Copy(io.Discard, r.Body)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	service := &DefaultService{
+		s3Client: s3.New(s3.Options{
+			Region:       "us-east-1",
+			BaseEndpoint: aws.String(srv.URL),
+			UsePathStyle: true,
+			Credentials:  credentials.NewStaticCredentialsProvider("test", "test", ""),
+		}),
+		bucketName: "test-bucket",
+	}
+
+	t.Run("success: each new partial output is published once", func(t *testing.T) {
+		prevInterval := previewInterval
+		previewInterval = 0
+		t.Cleanup(func() { previewInterval = prevInterval })
+		puts = nil
+
+		var published []string
+		previews := &preview.PublisherMock{
+			PublishPreviewFunc: func(ctx context.Context, id, previewURL string) error {
+				published = append(published, previewURL)
+				return nil
+			},
+		}
+		onPoll := service.previewPublisher(context.Background(), &StagingRequest{ImageID: imageID, Previews: previews})
+
+		onPoll(&replicate.Prediction{Status: replicate.Starting})
+		onPoll(&replicate.Prediction{Status: replicate.Processing, Output: []any{srv.URL + "/partial-1.png"}})
+		onPoll(&replicate.Prediction{Status: replicate.Processing, Output: []any{srv.URL + "/partial-1.png"}})
+		onPoll(&replicate.Prediction{
+			Status: replicate.Processing, Output: []any{srv.URL + "/partial-1.png", srv.URL + "/partial-2.png"},
+		})
+		onPoll(&replicate.Prediction{Status: replicate.Succeeded, Output: srv.URL + "/partial-3.png"})
+
+		wantURL := "s3://test-bucket/" + previewKey(imageID)
+		if !slices.Equal(published, []string{wantURL, wantURL}) {
+			t.Errorf("published %q, want two previews at %s", published, wantURL)
+		}
+		if len(puts) != 2 || puts[0] != previewKey(imageID) {
+			t.Errorf("uploaded %q, want two uploads to %s", puts, previewKey(imageID))
+		}
+	})
+
+	t.Run("success: previews are throttled", func(t *testing.T) {
+		var published int
+		previews := &preview.PublisherMock{
+			PublishPreviewFunc: func(ctx context.Context, id, previewURL string) error {
+				published++
+				return nil
+			},
+		}
+		onPoll := service.previewPublisher(context.Background(), &StagingRequest{ImageID: imageID, Previews: previews})
+
+		onPoll(&replicate.Prediction{Status: replicate.Processing, Output: srv.URL + "/partial-1.png"})
+		onPoll(&replicate.Prediction{Status: replicate.Processing, Output: srv.URL + "/partial-2.png"})
+
+		if published != 1 {
+			t.Errorf("published %d previews, want 1 within previewInterval", published)
+		}
+	})
+
+	t.Run("fail: undecodable partial output is skipped", func(t *testing.T) {
+		previews := &preview.PublisherMock{
+			PublishPreviewFunc: func(ctx context.Context, id, previewURL string) error {
+				return errors.New("unexpected publish")
+			},
+		}
+		err := service.publishPreview(context.Background(),
+			&StagingRequest{ImageID: imageID, Previews: previews}, srv.URL+"/broken.png")
+		if err == nil || !strings.Contains(err.Error(), "render preview") {
+			t.Errorf("expected a render error, got %v", err)
+		}
+		if len(previews.PublishPreviewCalls()) != 0 {
+			t.Error("expected no preview to be published")
+		}
+	})
+
+	t.Run("success: no publisher without previews", func(t *testing.T) {
+		if service.previewPublisher(context.Background(), &StagingRequest{ImageID: imageID}) != nil {
+			t.Error("expected no preview publisher")
+		}
+	})
+}
@@ -15,10 +15,18 @@ var modelPricingTable = []ModelPricing{
 		ModelID:      model.ModelQwenImageEdit,
 		CostPerImage: 0.03, // $0.03 per image (estimated)
 	},
+	{
+		ModelID:      model.ModelQwenImageEditPlus,
+		CostPerImage: 0.03, // $0.03 per image (estimated)
+	},
 	{
 		ModelID:      model.ModelFluxKontextMax,
 		CostPerImage: 0.08, // $0.08 per image (estimated)
 	},
+	{
+		ModelID:      model.ModelLamaInpaint,
+		CostPerImage: 0.002, // $0.002 per image (estimated)
+	},
 }
 
 // GetModelCost returns the cost per image for a given model.
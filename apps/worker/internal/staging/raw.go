@@ -0,0 +1,119 @@
+package staging
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/real-staging-ai/worker/internal/rawimage"
+	"github.com/real-staging-ai/worker/internal/s3client"
+)
+
+// rawArchiveTagKey is the object tag archived RAW files get, with RawConfig.ArchiveTag as
+// its value, for bucket lifecycle rules to match.
+const rawArchiveTagKey = "lifecycle"
+
+// convertRaw converts the request's RAW original to a JPEG, stores it next to the RAW
+// file and returns it with its URL. The JPEG's key is the RAW file's with a .jpg
+// extension, so a retry overwrites rather than duplicates it.
+func (s *DefaultService) convertRaw(
+	ctx context.Context, req *StagingRequest, format rawimage.Format,
+) ([]byte, string, error) {
+	tracer := otel.Tracer("real-staging-worker/staging")
+	ctx, span := tracer.Start(ctx, "staging.convertRaw")
+	span.SetAttributes(attribute.String("raw.format", string(format)))
+	defer span.End()
+
+	store := s.storeOf(req)
+	rawKey, err := s3client.KeyIn(req.OriginalURL, store.bucket)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "bad original URL")
+		return nil, "", fmt.Errorf("failed to extract S3 key from URL: %w", err)
+	}
+	raw, err := s.readOriginal(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "original download failed")
+		return nil, "", err
+	}
+	span.SetAttributes(attribute.Int("raw.bytes", len(raw)))
+
+	converter := s.rawConverter
+	if converter == nil {
+		converter = rawimage.NewConverter("")
+	}
+	img, err := converter.Convert(ctx, raw, format)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "conversion failed")
+		return nil, "", err
+	}
+
+	jpegKey := strings.TrimSuffix(rawKey, path.Ext(rawKey)) + ".jpg"
+	if err := s.putObject(ctx, store, jpegKey, bytes.NewReader(img), "image/jpeg"); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "PutObject failed")
+		return nil, "", err
+	}
+	span.SetStatus(codes.Ok, "RAW converted")
+	return img, store.objectURL(jpegKey), nil
+}
+
+// archiveRaw moves the request's RAW original to the archive storage class and tags it
+// for the archive lifecycle rules. The file stays at its key, so the conversion can be
+// retried from it.
+func (s *DefaultService) archiveRaw(ctx context.Context, req *StagingRequest) error {
+	if s.raw.ArchiveStorageClass == "" && s.raw.ArchiveTag == "" {
+		return nil
+	}
+	store := s.storeOf(req)
+	key, err := s3client.KeyIn(req.OriginalURL, store.bucket)
+	if err != nil {
+		return fmt.Errorf("failed to extract S3 key from URL: %w", err)
+	}
+	var tagging *string
+	if s.raw.ArchiveTag != "" {
+		tagging = aws.String(url.Values{rawArchiveTagKey: {s.raw.ArchiveTag}}.Encode())
+	}
+
+	// Changing the storage class means copying the object onto itself; the copy takes
+	// the tag along
+	if s.raw.ArchiveStorageClass != "" {
+		in := &s3.CopyObjectInput{
+			Bucket:            aws.String(store.bucket),
+			Key:               aws.String(key),
+			CopySource:        aws.String((&url.URL{Path: store.bucket + "/" + key}).EscapedPath()),
+			MetadataDirective: types.MetadataDirectiveCopy,
+			StorageClass:      types.StorageClass(s.raw.ArchiveStorageClass),
+		}
+		if tagging != nil {
+			in.Tagging, in.TaggingDirective = tagging, types.TaggingDirectiveReplace
+		}
+		if _, err := store.client.CopyObject(ctx, in); err != nil {
+			return fmt.Errorf("failed to change RAW storage class: %w", err)
+		}
+		return nil
+	}
+	_, err = store.client.PutObjectTagging(ctx, &s3.PutObjectTaggingInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(key),
+		Tagging: &types.Tagging{TagSet: []types.Tag{
+			{Key: aws.String(rawArchiveTagKey), Value: aws.String(s.raw.ArchiveTag)},
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to tag RAW file: %w", err)
+	}
+	return nil
+}
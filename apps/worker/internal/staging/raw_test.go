@@ -0,0 +1,209 @@
+package staging
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/real-staging-ai/worker/internal/rawimage"
+)
+
+// rawRequest is an S3 request the RAW tests' fake bucket received.
+type rawRequest struct {
+	method, path, query string
+	header              http.Header
+	body                []byte
+}
+
+func TestDefaultService_rawConvertStage(t *testing.T) {
+	const imageID = "2e1aa86e-0f27-4f0f-9e57-0d7b53b4d9b9"
+	// The decoder command is cat, so the "RAW" file is the PPM it is developed to.
+	ppm := append([]byte("P6 32 24 255\n"), bytes.Repeat([]byte{200, 120, 40}, 32*24)...)
+	objects := map[string][]byte{
+		"/test-bucket/uploads/u/IMG_0001.CR3": ppm,
+		"/test-bucket/uploads/u/DSC_0001.NEF": []byte("not a PPM"),
+	}
+
+	var (
+		mu       sync.Mutex
+		requests []rawRequest
+		failCopy bool
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, rawRequest{r.Method, r.URL.Path, r.URL.RawQuery, r.Header, body})
+		fail := failCopy
+		mu.Unlock()
+		switch {
+		case r.Method == http.MethodGet:
+			if b, ok := objects[r.URL.Path]; ok {
+				_, _ = w.Write(b)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		case r.Header.Get("X-Amz-Copy-Source") != "":
+			if fail {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = fmt.Fprint(w, "<CopyObjectResult><ETag>\"e\"</ETag></CopyObjectResult>")
+		}
+	}))
+	defer srv.Close()
+	client := s3.New(s3.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(srv.URL),
+		UsePathStyle: true,
+		Credentials:  credentials.NewStaticCredentialsProvider("test", "test", ""),
+	})
+
+	// find returns the first request matching method, path and a query prefix.
+	find := func(method, path, query string) (rawRequest, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, r := range requests {
+			if r.method == method && r.path == path && strings.HasPrefix(r.query, query) {
+				return r, true
+			}
+		}
+		return rawRequest{}, false
+	}
+
+	testCases := []struct {
+		name        string
+		originalURL string
+		raw         RawConfig
+		failCopy    bool
+		check       func(t *testing.T, req *StagingRequest)
+		errSubstr   string
+	}{
+		{
+			name:        "success: RAW is converted and archived with its storage class and tag",
+			originalURL: "s3://test-bucket/uploads/u/IMG_0001.CR3",
+			raw:         RawConfig{ArchiveStorageClass: "GLACIER_IR", ArchiveTag: "raw-archive"},
+			check: func(t *testing.T, req *StagingRequest) {
+				if req.RawURL != "s3://test-bucket/uploads/u/IMG_0001.CR3" {
+					t.Errorf("RawURL = %q", req.RawURL)
+				}
+				if req.OriginalURL != "s3://test-bucket/uploads/u/IMG_0001.jpg" {
+					t.Errorf("OriginalURL = %q", req.OriginalURL)
+				}
+				put, ok := find(http.MethodPut, "/test-bucket/uploads/u/IMG_0001.jpg", "")
+				if !ok {
+					t.Fatal("expected the JPEG to be uploaded")
+				}
+				if !bytes.Equal(put.body, req.input) {
+					t.Error("expected the uploaded JPEG to be staged")
+				}
+				img, err := jpeg.Decode(bytes.NewReader(req.input))
+				if err != nil {
+					t.Fatalf("converted input is not a JPEG: %v", err)
+				}
+				if got := img.Bounds().Size(); got != image.Pt(32, 24) {
+					t.Errorf("converted size = %v, want 32x24", got)
+				}
+
+				cp, ok := find(http.MethodPut, "/test-bucket/uploads/u/IMG_0001.CR3", "")
+				if !ok {
+					t.Fatal("expected the RAW file to be copied onto itself")
+				}
+				if got := cp.header.Get("X-Amz-Copy-Source"); got != "test-bucket/uploads/u/IMG_0001.CR3" {
+					t.Errorf("copy source = %q", got)
+				}
+				if got := cp.header.Get("X-Amz-Storage-Class"); got != "GLACIER_IR" {
+					t.Errorf("storage class = %q", got)
+				}
+				if got := cp.header.Get("X-Amz-Tagging"); got != "lifecycle=raw-archive" {
+					t.Errorf("tagging = %q", got)
+				}
+			},
+		},
+		{
+			name:        "success: tag only is set without a copy",
+			originalURL: "s3://test-bucket/uploads/u/IMG_0001.CR3",
+			raw:         RawConfig{ArchiveTag: "raw-archive"},
+			check: func(t *testing.T, req *StagingRequest) {
+				tag, ok := find(http.MethodPut, "/test-bucket/uploads/u/IMG_0001.CR3", "tagging")
+				if !ok {
+					t.Fatal("expected the RAW file to be tagged")
+				}
+				if !strings.Contains(string(tag.body), "<Value>raw-archive</Value>") {
+					t.Errorf("tagging body = %s", tag.body)
+				}
+			},
+		},
+		{
+			name:        "success: archive failure does not stop staging",
+			originalURL: "s3://test-bucket/uploads/u/IMG_0001.CR3",
+			raw:         RawConfig{ArchiveStorageClass: "GLACIER_IR"},
+			failCopy:    true,
+			check: func(t *testing.T, req *StagingRequest) {
+				if req.input == nil || req.RawURL == "" {
+					t.Error("expected the RAW file to be converted")
+				}
+			},
+		},
+		{
+			name:        "success: other originals are left alone",
+			originalURL: "s3://test-bucket/uploads/u/room.jpg",
+			check: func(t *testing.T, req *StagingRequest) {
+				if req.input != nil || req.RawURL != "" || len(requests) != 0 {
+					t.Errorf("expected no conversion, got %d requests", len(requests))
+				}
+			},
+		},
+		{
+			name:        "fail: RAW cannot be decoded",
+			originalURL: "s3://test-bucket/uploads/u/DSC_0001.NEF",
+			errSubstr:   "failed to convert RAW file: decode ppm",
+		},
+		{
+			name:        "fail: RAW missing",
+			originalURL: "s3://test-bucket/uploads/u/gone.ARW",
+			errSubstr:   "failed to download original image",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mu.Lock()
+			requests, failCopy = nil, tc.failCopy
+			mu.Unlock()
+			service := &DefaultService{
+				s3Client:     client,
+				bucketName:   "test-bucket",
+				raw:          tc.raw,
+				rawConverter: rawimage.NewConverter("cat"),
+			}
+			req := &StagingRequest{ImageID: imageID, OriginalURL: tc.originalURL}
+
+			err := service.rawConvertStage(context.Background(), &Artifact{Request: req})
+			if tc.errSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.errSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.errSubstr, err)
+				}
+				if req.RawURL != "" {
+					t.Errorf("expected no RawURL on failure, got %q", req.RawURL)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tc.check(t, req)
+		})
+	}
+}
@@ -3,6 +3,10 @@ package staging
 import (
 	"context"
 	"io"
+
+	"github.com/real-staging-ai/worker/internal/checkpoint"
+	"github.com/real-staging-ai/worker/internal/joblog"
+	"github.com/real-staging-ai/worker/internal/preview"
 )
 
 //go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
@@ -14,6 +18,81 @@ type StagingRequest struct {
 	RoomType    *string
 	Style       *string
 	Seed        *int64
+	// Catalog is the furniture catalog to stage with. Nil uses the base prompt alone.
+	Catalog *Catalog
+	// ReferenceImageURL is the s3:// URL of the user's inspiration photo, if any.
+	ReferenceImageURL string
+	// BracketURLs are s3:// URLs of other exposures of the original's shot. When set, the
+	// exposures are merged into an HDR base image that is staged in place of the original.
+	BracketURLs []string
+	// CorrectPerspective straightens converging and leaning verticals before staging.
+	CorrectPerspective bool
+	// Annotations are known room measurements the furniture is sized to. Nil leaves
+	// scale to the model.
+	Annotations *Annotations
+	// Instructions are extra prompt instructions from the user's preset, if any.
+	Instructions string
+	// Sandbox routes this request to the sandbox provider instead of the production model.
+	Sandbox bool
+	// Resume is the checkpoint an earlier attempt at this job left behind. A recorded
+	// output is returned as-is; a recorded prediction is awaited instead of starting another.
+	Resume checkpoint.Checkpoint
+	// Checkpoints records the prediction and output as they complete. Nil disables it.
+	Checkpoints checkpoint.Recorder
+	// Log records the user-visible processing log of the job. Nil disables it.
+	Log joblog.Recorder
+	// Previews receives low-resolution previews of partial outputs while the prediction
+	// runs. Nil disables them.
+	Previews preview.Publisher
+
+	// OriginalBytes and StagedBytes are set by StageImage to the sizes of the original it
+	// read and the output it uploaded. Either stays zero when that step was skipped.
+	OriginalBytes int64
+	StagedBytes   int64
+	// WatermarkedURL is set by StageImage to the watermarked copy of the output when the
+	// account has a watermark, and stays empty otherwise.
+	WatermarkedURL string
+	// RawURL is set by StageImage to the RAW upload when the original was one. OriginalURL
+	// then points at the JPEG converted from it.
+	RawURL string
+
+	// store is the bucket the original is in and the output goes to, set by StageImage.
+	store objectStore
+	// input is the image staged in place of the original, set by the RAW conversion, HDR
+	// merge and perspective stages.
+	input []byte
+}
+
+// Catalog is a furniture style pack picked for a request: a prompt fragment appended to
+// the staging prompt and reference images for models that support image conditioning.
+type Catalog struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	PromptFragment string `json:"prompt_fragment"`
+	// ReferenceImageURLs are https:// URLs, passed through, or s3:// URLs in the images
+	// bucket, which are sent inline.
+	ReferenceImageURLs []string `json:"reference_image_urls,omitempty"`
+}
+
+// Annotations are known room measurements, in meters.
+type Annotations struct {
+	// WallLengthM is the length of the main wall facing the camera.
+	WallLengthM *float64 `json:"wall_length_m,omitempty"`
+	// CeilingHeightM is the floor-to-ceiling height.
+	CeilingHeightM *float64 `json:"ceiling_height_m,omitempty"`
+}
+
+// referenceImageURLs lists the request's reference images, the user's own inspiration
+// photo ahead of the catalog's.
+func (r *StagingRequest) referenceImageURLs() []string {
+	var urls []string
+	if r.ReferenceImageURL != "" {
+		urls = append(urls, r.ReferenceImageURL)
+	}
+	if r.Catalog != nil {
+		urls = append(urls, r.Catalog.ReferenceImageURLs...)
+	}
+	return urls
 }
 
 // Service defines the interface for AI-powered virtual staging operations.
@@ -23,6 +102,21 @@ type Service interface {
 	// and uploads the result back to S3.
 	StageImage(ctx context.Context, req *StagingRequest) (string, error)
 
+	// EstimateCost returns the expected provider cost in USD of staging req, so spend can be
+	// checked before any work starts.
+	EstimateCost(req *StagingRequest) float64
+
+	// EraseObject erases what the request's mask covers from an image version with a fast
+	// inpainting model and returns the edited image's URL in S3.
+	EraseObject(ctx context.Context, req *EraseRequest) (string, error)
+
+	// EstimateEraseCost returns the expected provider cost in USD of req, like EstimateCost.
+	EstimateEraseCost(req *EraseRequest) float64
+
+	// ReadImage downloads an s3:// URL from whichever bucket holds it: the home, a region
+	// or a customer bucket.
+	ReadImage(ctx context.Context, rawURL string) ([]byte, error)
+
 	// DownloadFromS3 downloads a file from S3 and returns its content.
 	DownloadFromS3(ctx context.Context, fileKey string) (io.ReadCloser, error)
 
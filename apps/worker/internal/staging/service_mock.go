@@ -22,6 +22,18 @@ var _ Service = &ServiceMock{}
 //			DownloadFromS3Func: func(ctx context.Context, fileKey string) (io.ReadCloser, error) {
 //				panic("mock out the DownloadFromS3 method")
 //			},
+//			EraseObjectFunc: func(ctx context.Context, req *EraseRequest) (string, error) {
+//				panic("mock out the EraseObject method")
+//			},
+//			EstimateCostFunc: func(req *StagingRequest) float64 {
+//				panic("mock out the EstimateCost method")
+//			},
+//			EstimateEraseCostFunc: func(req *EraseRequest) float64 {
+//				panic("mock out the EstimateEraseCost method")
+//			},
+//			ReadImageFunc: func(ctx context.Context, rawURL string) ([]byte, error) {
+//				panic("mock out the ReadImage method")
+//			},
 //			StageImageFunc: func(ctx context.Context, req *StagingRequest) (string, error) {
 //				panic("mock out the StageImage method")
 //			},
@@ -38,6 +50,18 @@ type ServiceMock struct {
 	// DownloadFromS3Func mocks the DownloadFromS3 method.
 	DownloadFromS3Func func(ctx context.Context, fileKey string) (io.ReadCloser, error)
 
+	// EraseObjectFunc mocks the EraseObject method.
+	EraseObjectFunc func(ctx context.Context, req *EraseRequest) (string, error)
+
+	// EstimateCostFunc mocks the EstimateCost method.
+	EstimateCostFunc func(req *StagingRequest) float64
+
+	// EstimateEraseCostFunc mocks the EstimateEraseCost method.
+	EstimateEraseCostFunc func(req *EraseRequest) float64
+
+	// ReadImageFunc mocks the ReadImage method.
+	ReadImageFunc func(ctx context.Context, rawURL string) ([]byte, error)
+
 	// StageImageFunc mocks the StageImage method.
 	StageImageFunc func(ctx context.Context, req *StagingRequest) (string, error)
 
@@ -53,6 +77,30 @@ type ServiceMock struct {
 			// FileKey is the fileKey argument value.
 			FileKey string
 		}
+		// EraseObject holds details about calls to the EraseObject method.
+		EraseObject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *EraseRequest
+		}
+		// EstimateCost holds details about calls to the EstimateCost method.
+		EstimateCost []struct {
+			// Req is the req argument value.
+			Req *StagingRequest
+		}
+		// EstimateEraseCost holds details about calls to the EstimateEraseCost method.
+		EstimateEraseCost []struct {
+			// Req is the req argument value.
+			Req *EraseRequest
+		}
+		// ReadImage holds details about calls to the ReadImage method.
+		ReadImage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// RawURL is the rawURL argument value.
+			RawURL string
+		}
 		// StageImage holds details about calls to the StageImage method.
 		StageImage []struct {
 			// Ctx is the ctx argument value.
@@ -72,9 +120,13 @@ type ServiceMock struct {
 			ContentType string
 		}
 	}
-	lockDownloadFromS3 sync.RWMutex
-	lockStageImage     sync.RWMutex
-	lockUploadToS3     sync.RWMutex
+	lockDownloadFromS3    sync.RWMutex
+	lockEraseObject       sync.RWMutex
+	lockEstimateCost      sync.RWMutex
+	lockEstimateEraseCost sync.RWMutex
+	lockReadImage         sync.RWMutex
+	lockStageImage        sync.RWMutex
+	lockUploadToS3        sync.RWMutex
 }
 
 // DownloadFromS3 calls DownloadFromS3Func.
@@ -113,6 +165,142 @@ func (mock *ServiceMock) DownloadFromS3Calls() []struct {
 	return calls
 }
 
+// EraseObject calls EraseObjectFunc.
+func (mock *ServiceMock) EraseObject(ctx context.Context, req *EraseRequest) (string, error) {
+	if mock.EraseObjectFunc == nil {
+		panic("ServiceMock.EraseObjectFunc: method is nil but Service.EraseObject was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req *EraseRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockEraseObject.Lock()
+	mock.calls.EraseObject = append(mock.calls.EraseObject, callInfo)
+	mock.lockEraseObject.Unlock()
+	return mock.EraseObjectFunc(ctx, req)
+}
+
+// EraseObjectCalls gets all the calls that were made to EraseObject.
+// Check the length with:
+//
+//	len(mockedService.EraseObjectCalls())
+func (mock *ServiceMock) EraseObjectCalls() []struct {
+	Ctx context.Context
+	Req *EraseRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req *EraseRequest
+	}
+	mock.lockEraseObject.RLock()
+	calls = mock.calls.EraseObject
+	mock.lockEraseObject.RUnlock()
+	return calls
+}
+
+// EstimateCost calls EstimateCostFunc.
+func (mock *ServiceMock) EstimateCost(req *StagingRequest) float64 {
+	if mock.EstimateCostFunc == nil {
+		panic("ServiceMock.EstimateCostFunc: method is nil but Service.EstimateCost was just called")
+	}
+	callInfo := struct {
+		Req *StagingRequest
+	}{
+		Req: req,
+	}
+	mock.lockEstimateCost.Lock()
+	mock.calls.EstimateCost = append(mock.calls.EstimateCost, callInfo)
+	mock.lockEstimateCost.Unlock()
+	return mock.EstimateCostFunc(req)
+}
+
+// EstimateCostCalls gets all the calls that were made to EstimateCost.
+// Check the length with:
+//
+//	len(mockedService.EstimateCostCalls())
+func (mock *ServiceMock) EstimateCostCalls() []struct {
+	Req *StagingRequest
+} {
+	var calls []struct {
+		Req *StagingRequest
+	}
+	mock.lockEstimateCost.RLock()
+	calls = mock.calls.EstimateCost
+	mock.lockEstimateCost.RUnlock()
+	return calls
+}
+
+// EstimateEraseCost calls EstimateEraseCostFunc.
+func (mock *ServiceMock) EstimateEraseCost(req *EraseRequest) float64 {
+	if mock.EstimateEraseCostFunc == nil {
+		panic("ServiceMock.EstimateEraseCostFunc: method is nil but Service.EstimateEraseCost was just called")
+	}
+	callInfo := struct {
+		Req *EraseRequest
+	}{
+		Req: req,
+	}
+	mock.lockEstimateEraseCost.Lock()
+	mock.calls.EstimateEraseCost = append(mock.calls.EstimateEraseCost, callInfo)
+	mock.lockEstimateEraseCost.Unlock()
+	return mock.EstimateEraseCostFunc(req)
+}
+
+// EstimateEraseCostCalls gets all the calls that were made to EstimateEraseCost.
+// Check the length with:
+//
+//	len(mockedService.EstimateEraseCostCalls())
+func (mock *ServiceMock) EstimateEraseCostCalls() []struct {
+	Req *EraseRequest
+} {
+	var calls []struct {
+		Req *EraseRequest
+	}
+	mock.lockEstimateEraseCost.RLock()
+	calls = mock.calls.EstimateEraseCost
+	mock.lockEstimateEraseCost.RUnlock()
+	return calls
+}
+
+// ReadImage calls ReadImageFunc.
+func (mock *ServiceMock) ReadImage(ctx context.Context, rawURL string) ([]byte, error) {
+	if mock.ReadImageFunc == nil {
+		panic("ServiceMock.ReadImageFunc: method is nil but Service.ReadImage was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		RawURL string
+	}{
+		Ctx:    ctx,
+		RawURL: rawURL,
+	}
+	mock.lockReadImage.Lock()
+	mock.calls.ReadImage = append(mock.calls.ReadImage, callInfo)
+	mock.lockReadImage.Unlock()
+	return mock.ReadImageFunc(ctx, rawURL)
+}
+
+// ReadImageCalls gets all the calls that were made to ReadImage.
+// Check the length with:
+//
+//	len(mockedService.ReadImageCalls())
+func (mock *ServiceMock) ReadImageCalls() []struct {
+	Ctx    context.Context
+	RawURL string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		RawURL string
+	}
+	mock.lockReadImage.RLock()
+	calls = mock.calls.ReadImage
+	mock.lockReadImage.RUnlock()
+	return calls
+}
+
 // StageImage calls StageImageFunc.
 func (mock *ServiceMock) StageImage(ctx context.Context, req *StagingRequest) (string, error) {
 	if mock.StageImageFunc == nil {
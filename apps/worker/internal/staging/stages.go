@@ -0,0 +1,266 @@
+package staging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/perspective"
+	"github.com/real-staging-ai/worker/internal/pipeline"
+	"github.com/real-staging-ai/worker/internal/rawimage"
+	"github.com/real-staging-ai/worker/internal/staging/model"
+)
+
+// Artifact is what the staging pipeline works on: the request and its output so far.
+type Artifact struct {
+	Request *StagingRequest
+	// Image is the current output. Post-process stages replace it with their result.
+	Image []byte
+	// Model produced Image, or is "" for sandbox requests on the fake provider.
+	Model model.ModelID
+	// URL is where the publish phase stored Image.
+	URL string
+	// Watermarked is a copy of Image with the account's logo, or nil when the account has
+	// no watermark. Image itself is never watermarked.
+	Watermarked []byte
+}
+
+// Names of the built-in staging pipeline stages.
+const (
+	StageRawConvert  = "raw_convert"
+	StageHDRMerge    = "hdr_merge"
+	StagePerspective = "perspective"
+	StagePredict     = "predict"
+	StageDisclosure  = "disclosure"
+	StageWatermark   = "watermark"
+	StageProvenance  = "provenance"
+	StageUpload      = "upload"
+)
+
+// Orders of the built-in post-process stages. Watermarks and other overlays go before
+// provenance so the credentials cover them.
+const (
+	OrderDisclosure = 100
+	OrderWatermark  = 500
+	OrderProvenance = 900
+)
+
+// newPipeline builds the staging pipeline from the built-in stages and extra.
+func (s *DefaultService) newPipeline(extra []pipeline.Registration[*Artifact]) (*pipeline.Pipeline[*Artifact], error) {
+	p := pipeline.New[*Artifact]("staging")
+	builtins := []pipeline.Registration[*Artifact]{
+		{
+			Stage: pipeline.StageFunc[*Artifact]{StageName: StageRawConvert, Fn: s.rawConvertStage},
+			Phase: pipeline.PhasePreProcess,
+		},
+		{
+			Stage: pipeline.StageFunc[*Artifact]{StageName: StageHDRMerge, Fn: s.hdrMergeStage},
+			Phase: pipeline.PhasePreProcess, Optional: true,
+		},
+		{
+			Stage: pipeline.StageFunc[*Artifact]{StageName: StagePerspective, Fn: s.perspectiveStage},
+			Phase: pipeline.PhasePreProcess, Optional: true,
+		},
+		{Stage: pipeline.StageFunc[*Artifact]{StageName: StagePredict, Fn: s.predictStage}, Phase: pipeline.PhaseStage},
+		{
+			Stage: pipeline.StageFunc[*Artifact]{StageName: StageDisclosure, Fn: s.disclosureStage},
+			Phase: pipeline.PhasePostProcess, Order: OrderDisclosure,
+		},
+		{
+			Stage: pipeline.StageFunc[*Artifact]{StageName: StageWatermark, Fn: s.watermarkStage},
+			Phase: pipeline.PhasePostProcess, Order: OrderWatermark, Optional: true,
+		},
+		{
+			Stage: pipeline.StageFunc[*Artifact]{StageName: StageProvenance, Fn: s.provenanceStage},
+			Phase: pipeline.PhasePostProcess, Order: OrderProvenance,
+		},
+		{Stage: pipeline.StageFunc[*Artifact]{StageName: StageUpload, Fn: s.uploadStage}, Phase: pipeline.PhasePublish},
+	}
+	for _, reg := range append(builtins, extra...) {
+		if err := p.Register(reg); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// withPipeline finishes a DefaultService by building its pipeline.
+func withPipeline(s *DefaultService, extra []pipeline.Registration[*Artifact]) (*DefaultService, error) {
+	p, err := s.newPipeline(extra)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build staging pipeline: %w", err)
+	}
+	s.pipeline = p
+	return s, nil
+}
+
+// PipelineMetrics returns the run metrics of every staging pipeline stage.
+func (s *DefaultService) PipelineMetrics() []pipeline.StageStats {
+	if s.pipeline == nil {
+		return nil
+	}
+	return s.pipeline.Metrics()
+}
+
+// PipelineStages returns the names of the staging pipeline's stages in the order they run.
+func (s *DefaultService) PipelineStages() []string {
+	if s.pipeline == nil {
+		return nil
+	}
+	return s.pipeline.Stages()
+}
+
+// predictStage produces the staged image, picking up the prediction a previous attempt
+// started rather than paying for another.
+func (s *DefaultService) predictStage(ctx context.Context, a *Artifact) error {
+	req := a.Request
+	a.Model = s.modelFor(req)
+	if req.Resume.PredictionID != "" {
+		appendJobLog(ctx, req, "Resuming the prediction started by the previous attempt")
+		a.Image = s.resumePrediction(ctx, req.ImageID, req.Resume.PredictionID, s.predictionObserver(ctx, req))
+	}
+	if a.Image != nil {
+		return nil
+	}
+	img, stagedBy, err := s.runPrediction(ctx, req)
+	if err != nil {
+		return err
+	}
+	a.Image, a.Model = img, stagedBy
+	return nil
+}
+
+// rawConvertStage converts a RAW original to the JPEG that is staged in its place and
+// archives the RAW file. A RAW file cannot be staged as-is, so failing to convert it is
+// fatal; failing to archive it is not.
+func (s *DefaultService) rawConvertStage(ctx context.Context, a *Artifact) error {
+	req := a.Request
+	format, ok := rawimage.FormatOf(req.OriginalURL)
+	if !ok {
+		return nil
+	}
+	img, jpegURL, err := s.convertRaw(ctx, req, format)
+	if err != nil {
+		appendJobLog(ctx, req, "Could not convert the RAW file")
+		return fmt.Errorf("failed to convert RAW file: %w", err)
+	}
+	if err := s.archiveRaw(ctx, req); err != nil {
+		logging.Default().Warn(ctx, "failed to archive RAW original", "image_id", req.ImageID, "error", err)
+	}
+	req.RawURL, req.OriginalURL, req.input = req.OriginalURL, jpegURL, img
+	appendJobLog(ctx, req, fmt.Sprintf("Converted the %s RAW file to JPEG", strings.ToUpper(string(format))))
+	return nil
+}
+
+// hdrMergeStage merges the original with the other exposures of its shot, if the request
+// has any. Without the merge the original is staged as-is, so failures are not fatal.
+func (s *DefaultService) hdrMergeStage(ctx context.Context, a *Artifact) error {
+	req := a.Request
+	// A resumed prediction already has its input
+	if len(req.BracketURLs) == 0 || req.Resume.PredictionID != "" {
+		return nil
+	}
+	img, err := s.mergeExposures(ctx, req)
+	if err != nil {
+		appendJobLog(ctx, req, "Could not merge the bracketed exposures; staging the original photo")
+		return fmt.Errorf("failed to merge exposures: %w", err)
+	}
+	req.input = img
+	appendJobLog(ctx, req, fmt.Sprintf("Merged %d exposures into an HDR base image", len(req.BracketURLs)+1))
+	return nil
+}
+
+// perspectiveStage straightens converging and leaning verticals, if the request asks for
+// it. Photos it cannot measure are staged as shot, so failures are not fatal.
+func (s *DefaultService) perspectiveStage(ctx context.Context, a *Artifact) error {
+	req := a.Request
+	// A resumed prediction already has its input
+	if !req.CorrectPerspective || req.Resume.PredictionID != "" {
+		return nil
+	}
+	img, res, err := s.straightenVerticals(ctx, req)
+	switch {
+	case errors.Is(err, perspective.ErrNoVerticals):
+		appendJobLog(ctx, req, "Found too few vertical lines to correct the perspective; staging the photo as shot")
+		return nil
+	case errors.Is(err, perspective.ErrTooSkewed):
+		appendJobLog(ctx, req, "Vertical lines lean too far to be a camera tilt; staging the photo as shot")
+		return nil
+	case err != nil:
+		appendJobLog(ctx, req, "Could not correct the perspective; staging the photo as shot")
+		return fmt.Errorf("failed to correct perspective: %w", err)
+	case !res.Applied:
+		appendJobLog(ctx, req, "Vertical lines are already straight")
+		return nil
+	}
+	req.input = img
+	appendJobLog(ctx, req, fmt.Sprintf("Straightened vertical lines (keystone %.1f°, roll %.1f°)", res.Keystone, res.Roll))
+	return nil
+}
+
+// disclosureStage renders the project's disclosure banner, where advertising rules
+// require one.
+func (s *DefaultService) disclosureStage(ctx context.Context, a *Artifact) error {
+	img, err := s.applyDisclosure(ctx, a.Image, a.Request)
+	if err != nil {
+		return fmt.Errorf("failed to apply disclosure banner: %w", err)
+	}
+	a.Image = img
+	return nil
+}
+
+// watermarkStage renders the account's logo onto a copy of the output for share links
+// and downloads. Without it those serve the clean output, so failures are not fatal.
+func (s *DefaultService) watermarkStage(ctx context.Context, a *Artifact) error {
+	img, err := s.renderWatermark(ctx, a.Image, a.Request)
+	if err != nil {
+		return fmt.Errorf("failed to apply watermark: %w", err)
+	}
+	a.Watermarked = img
+	return nil
+}
+
+// provenanceStage marks the output, and its watermarked copy, as AI-modified before they
+// leave the pipeline.
+func (s *DefaultService) provenanceStage(ctx context.Context, a *Artifact) error {
+	a.Image = s.embedProvenance(ctx, a.Image, a.Request, a.Model)
+	if a.Watermarked != nil {
+		a.Watermarked = s.embedProvenance(ctx, a.Watermarked, a.Request, a.Model)
+	}
+	return nil
+}
+
+// uploadWatermarked stores the watermarked copy next to the staged image. Failures are
+// logged; share links and downloads then serve the clean output.
+func (s *DefaultService) uploadWatermarked(ctx context.Context, req *StagingRequest, img []byte) {
+	store, key := s.storeOf(req), watermarkedKey(req.ImageID)
+	if err := s.putObject(ctx, store, key, bytes.NewReader(img), "image/jpeg"); err != nil {
+		logging.Default().Warn(ctx, "failed to upload watermarked image", "image_id", req.ImageID, "error", err)
+		return
+	}
+	req.WatermarkedURL = store.objectURL(key)
+}
+
+// uploadStage stores the staged image next to the original and checkpoints its key.
+func (s *DefaultService) uploadStage(ctx context.Context, a *Artifact) error {
+	req := a.Request
+	url, err := s.upload(ctx, s.storeOf(req), req.ImageID, bytes.NewReader(a.Image), "image/jpeg")
+	if err != nil {
+		return fmt.Errorf("failed to upload staged image: %w", err)
+	}
+	a.URL = url
+	req.StagedBytes = int64(len(a.Image))
+	appendJobLog(ctx, req, "Staged image uploaded")
+	if a.Watermarked != nil {
+		s.uploadWatermarked(ctx, req, a.Watermarked)
+	}
+	if req.Checkpoints != nil {
+		if err := req.Checkpoints.RecordOutput(ctx, req.ImageID, stagedKey(req.ImageID)); err != nil {
+			logging.Default().Warn(ctx, "failed to record output checkpoint", "image_id", req.ImageID, "error", err)
+		}
+	}
+	return nil
+}
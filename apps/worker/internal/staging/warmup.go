@@ -0,0 +1,54 @@
+package staging
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"sync"
+	"time"
+
+	"github.com/real-staging-ai/worker/internal/staging/model"
+)
+
+// warmUpPrompt asks for as little work as possible; the output is discarded.
+const warmUpPrompt = "Return this image unchanged."
+
+// warmUpImage is a small gray PNG data URL, the cheapest input every model accepts.
+var warmUpImage = sync.OnceValue(func() string {
+	img := image.NewGray(image.Rect(0, 0, 64, 64))
+	for i := range img.Pix {
+		img.Pix[i] = color.Gray{Y: 128}.Y
+	}
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, img)
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())
+})
+
+// WarmUp runs a minimal prediction on the production model so the next real request does
+// not wait for Replicate to boot it. It waits for the prediction to finish and discards
+// the output.
+func (s *DefaultService) WarmUp(ctx context.Context) error {
+	input := &model.ModelInputRequest{ImageDataURL: warmUpImage(), Prompt: warmUpPrompt}
+	if _, err := s.callReplicateAPI(ctx, s.modelID, input, nil, nil); err != nil {
+		return fmt.Errorf("failed to warm up %s: %w", s.modelID, err)
+	}
+	return nil
+}
+
+// WarmUpCost returns the expected provider cost in USD of one WarmUp.
+func (s *DefaultService) WarmUpCost() float64 {
+	return GetModelCost(s.modelID)
+}
+
+// LastPrediction returns when this service last created a prediction, real or warm-up.
+// It is zero before the first.
+func (s *DefaultService) LastPrediction() time.Time {
+	if ns := s.lastPrediction.Load(); ns != 0 {
+		return time.Unix(0, ns)
+	}
+	return time.Time{}
+}
@@ -0,0 +1,89 @@
+package staging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/replicate/replicate-go"
+
+	"github.com/real-staging-ai/worker/internal/staging/model"
+)
+
+func TestDefaultService_WarmUp(t *testing.T) {
+	prevInterval := predictionPollInterval
+	predictionPollInterval = 5 * time.Millisecond
+	t.Cleanup(func() { predictionPollInterval = prevInterval })
+
+	testCases := []struct {
+		name    string
+		status  string
+		wantErr bool
+	}{
+		{name: "success: prediction finishes", status: "succeeded"},
+		{name: "fail: prediction fails", status: "failed", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var input map[string]any
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/predictions":
+					var body struct {
+						Input map[string]any `json:"input"`
+					}
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Errorf("decode prediction request: %v", err)
+					}
+					input = body.Input
+					_, _ = fmt.Fprint(w, `{"id":"pred-warm","status":"starting"}`)
+				case r.URL.Path == "/predictions/pred-warm":
+					_, _ = fmt.Fprintf(w, `{"id":"pred-warm","status":%q,"output":"https://cdn.example.com/out.png"}`,
+						tc.status)
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer srv.Close()
+
+			client, err := replicate.NewClient(replicate.WithToken("test-token"), replicate.WithBaseURL(srv.URL))
+			if err != nil {
+				t.Fatalf("unexpected error creating client: %v", err)
+			}
+			service := &DefaultService{
+				replicateClient: client,
+				modelID:         model.ModelQwenImageEdit,
+				registry:        model.NewModelRegistry(),
+			}
+			if !service.LastPrediction().IsZero() {
+				t.Fatal("expected no prediction before the warm-up")
+			}
+
+			err = service.WarmUp(context.Background())
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("WarmUp() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if image, _ := input["image"].(string); !strings.HasPrefix(image, "data:image/png;base64,") {
+				t.Errorf("expected the warm-up image inline, got %q", image)
+			}
+			if time.Since(service.LastPrediction()) > time.Minute {
+				t.Errorf("expected the warm-up to count as the last prediction, got %v", service.LastPrediction())
+			}
+		})
+	}
+}
+
+func TestDefaultService_WarmUpCost(t *testing.T) {
+	service := &DefaultService{modelID: model.ModelFluxKontextMax}
+	if got := service.WarmUpCost(); got != GetModelCost(model.ModelFluxKontextMax) {
+		t.Errorf("WarmUpCost() = %v", got)
+	}
+}
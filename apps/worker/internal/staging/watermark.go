@@ -0,0 +1,54 @@
+package staging
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/real-staging-ai/worker/internal/watermark"
+)
+
+// watermarkedKey is the S3 key of the watermarked copy of an image's staged output.
+func watermarkedKey(imageID string) string {
+	return fmt.Sprintf("staged/%s/%s-watermarked.jpg", imageID[:8], imageID)
+}
+
+// renderWatermark draws the account's logo onto a copy of img. It returns nil when the
+// account has no watermark enabled.
+func (s *DefaultService) renderWatermark(ctx context.Context, img []byte, req *StagingRequest) ([]byte, error) {
+	if s.watermarks == nil {
+		return nil, nil
+	}
+	tracer := otel.Tracer("real-staging-worker/staging")
+	ctx, span := tracer.Start(ctx, "staging.renderWatermark")
+	defer span.End()
+
+	settings, err := s.watermarks.ForImage(ctx, req.ImageID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "watermark lookup failed")
+		return nil, err
+	}
+	if settings == nil {
+		return nil, nil
+	}
+	span.SetAttributes(attribute.String("watermark.position", settings.Position))
+
+	logo, err := s.readS3Object(ctx, settings.LogoURL)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "logo download failed")
+		return nil, fmt.Errorf("failed to read logo: %w", err)
+	}
+	out, err := watermark.Render(img, logo, *settings)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "watermark render failed")
+		return nil, err
+	}
+	span.SetStatus(codes.Ok, "watermark rendered")
+	return out, nil
+}
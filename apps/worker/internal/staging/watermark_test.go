@@ -0,0 +1,155 @@
+package staging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/real-staging-ai/worker/internal/watermark"
+)
+
+func testPNG(t *testing.T, w, h int, c color.Color) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := range w {
+		for y := range h {
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDefaultService_WatermarkStage(t *testing.T) {
+	const imageID = "2e1aa86e-0f27-4f0f-9e57-0d7b53b4d9b9"
+	staged := testPNG(t, 300, 200, color.Gray{Y: 128})
+	logo := testPNG(t, 60, 30, color.White)
+
+	var (
+		mu   sync.Mutex
+		puts map[string][]byte
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/test-bucket/uploads/logo.png":
+			_, _ = w.Write(logo)
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/test-bucket/"):
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			puts[strings.TrimPrefix(r.URL.Path, "/test-bucket/")] = body
+			mu.Unlock()
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	s3Client := s3.New(s3.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(srv.URL),
+		UsePathStyle: true,
+		Credentials:  credentials.NewStaticCredentialsProvider("test", "test", ""),
+	})
+
+	withLogo := func(logoURL string) *watermark.RepositoryMock {
+		return &watermark.RepositoryMock{
+			ForImageFunc: func(ctx context.Context, id string) (*watermark.Settings, error) {
+				return &watermark.Settings{LogoURL: logoURL, Position: watermark.PositionCenter, Opacity: 1, Scale: 0.3}, nil
+			},
+		}
+	}
+
+	testCases := []struct {
+		name            string
+		repo            watermark.Repository
+		wantWatermarked bool
+		wantErr         bool
+	}{
+		{name: "success: disabled leaves no copy"},
+		{
+			name: "success: account without watermark leaves no copy",
+			repo: &watermark.RepositoryMock{
+				ForImageFunc: func(ctx context.Context, id string) (*watermark.Settings, error) { return nil, nil },
+			},
+		},
+		{
+			name:            "success: renders and uploads a watermarked copy",
+			repo:            withLogo("s3://test-bucket/uploads/logo.png"),
+			wantWatermarked: true,
+		},
+		{
+			name: "fail: lookup error",
+			repo: &watermark.RepositoryMock{
+				ForImageFunc: func(ctx context.Context, id string) (*watermark.Settings, error) {
+					return nil, errors.New("db down")
+				},
+			},
+			wantErr: true,
+		},
+		{name: "fail: logo missing", repo: withLogo("s3://test-bucket/uploads/gone.png"), wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			puts = map[string][]byte{}
+			service := &DefaultService{s3Client: s3Client, bucketName: "test-bucket", watermarks: tc.repo}
+			req := &StagingRequest{ImageID: imageID}
+			a := &Artifact{Request: req, Image: staged}
+
+			err := service.watermarkStage(context.Background(), a)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				if a.Watermarked != nil {
+					t.Error("a failed stage must leave the artifact unchanged")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := a.Watermarked != nil; got != tc.wantWatermarked {
+				t.Fatalf("watermarked = %v, want %v", got, tc.wantWatermarked)
+			}
+			if !bytes.Equal(a.Image, staged) {
+				t.Error("the staged output must stay clean")
+			}
+
+			if err := service.uploadStage(context.Background(), a); err != nil {
+				t.Fatalf("unexpected upload error: %v", err)
+			}
+			key := watermarkedKey(imageID)
+			if !tc.wantWatermarked {
+				if req.WatermarkedURL != "" || puts[key] != nil {
+					t.Errorf("unexpected watermarked copy %q", req.WatermarkedURL)
+				}
+				return
+			}
+			if want := "s3://test-bucket/" + key; req.WatermarkedURL != want {
+				t.Errorf("WatermarkedURL = %q, want %q", req.WatermarkedURL, want)
+			}
+			if !bytes.Equal(puts[key], a.Watermarked) {
+				t.Error("uploaded copy differs from the rendered one")
+			}
+			if !bytes.Equal(puts[stagedKey(imageID)], staged) {
+				t.Error("staged output was not uploaded unchanged")
+			}
+		})
+	}
+}
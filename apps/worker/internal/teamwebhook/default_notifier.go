@@ -0,0 +1,215 @@
+package teamwebhook
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/real-staging-ai/worker/internal/dbretry"
+	"github.com/real-staging-ai/worker/internal/httpclient"
+	"github.com/real-staging-ai/worker/internal/logging"
+)
+
+// maxErrorBody bounds how much of a rejected delivery's response is kept as its error.
+const maxErrorBody = 256
+
+// DefaultNotifier reads an account's webhooks from Postgres and posts to them over HTTP.
+type DefaultNotifier struct {
+	db     *sql.DB
+	client *http.Client
+	now    func() time.Time
+}
+
+// Ensure DefaultNotifier implements Notifier.
+var _ Notifier = (*DefaultNotifier)(nil)
+
+// NewDefaultNotifier creates a DefaultNotifier that posts through the customer webhooks
+// HTTP client.
+func NewDefaultNotifier(db *sql.DB) *DefaultNotifier {
+	return &DefaultNotifier{db: db, client: httpclient.New(httpclient.DestinationWebhooks), now: time.Now}
+}
+
+// NewDefaultNotifierWithClient creates a DefaultNotifier with a custom HTTP client (for testing).
+func NewDefaultNotifierWithClient(db *sql.DB, client *http.Client) *DefaultNotifier {
+	return &DefaultNotifier{db: db, client: client, now: time.Now}
+}
+
+// webhook is a team_webhooks row, joined with the project the batch belongs to when posting
+// batch_complete.
+type webhook struct {
+	id          string
+	provider    string
+	url         string
+	secrets     secrets
+	projectName string
+}
+
+// BatchComplete posts to the webhooks of the project owner subscribed to batch_complete,
+// returning the joined delivery errors. Each post is logged as a delivery, which the
+// Retrier retries if this first attempt fails.
+func (n *DefaultNotifier) BatchComplete(ctx context.Context, imageID string, images int) error {
+	const q = `
+		SELECT w.id, w.provider, w.url, w.signing_secret, w.previous_signing_secret, w.previous_secret_expires_at, p.name
+		FROM images i
+		JOIN projects p ON p.id = i.project_id
+		JOIN team_webhooks w ON w.user_id = p.user_id
+		WHERE i.id = $1::uuid AND 'batch_complete' = ANY(w.events)
+		ORDER BY w.provider`
+	var hooks []webhook
+	err := dbretry.Do(ctx, "list team webhooks", func(ctx context.Context) error {
+		hooks = hooks[:0]
+		rows, err := n.db.QueryContext(ctx, q, imageID)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = rows.Close() }()
+		for rows.Next() {
+			var h webhook
+			err := rows.Scan(&h.id, &h.provider, &h.url,
+				&h.secrets.current, &h.secrets.previous, &h.secrets.previousExpires, &h.projectName)
+			if err != nil {
+				return err
+			}
+			hooks = append(hooks, h)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return fmt.Errorf("list team webhooks: %w", err)
+	}
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	msg, err := renderBatchComplete(map[string]string{
+		"images":       strconv.Itoa(images),
+		"project_name": hooks[0].projectName,
+	})
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, h := range hooks {
+		if err := n.deliver(ctx, h, msg); err != nil {
+			errs = append(errs, fmt.Errorf("%s webhook: %w", h.provider, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// deliver logs msg as a delivery to the webhook and makes its first attempt. When the
+// delivery cannot be logged, msg is still posted once.
+func (n *DefaultNotifier) deliver(ctx context.Context, h webhook, msg Message) error {
+	body, err := payload(h.provider, msg)
+	if err != nil {
+		return err
+	}
+	const q = `
+		INSERT INTO team_webhook_deliveries (webhook_id, event, payload, next_attempt_at)
+		VALUES ($1, $2, $3, now())
+		RETURNING id, created_at`
+	d := delivery{payload: body, status: deliveryPending}
+	err = dbretry.Do(ctx, "log team webhook delivery", func(ctx context.Context) error {
+		return n.db.QueryRowContext(ctx, q, h.id, EventBatchComplete, body).Scan(&d.id, &d.createdAt)
+	})
+	if err != nil {
+		logging.Default().Warn(ctx, "Failed to log team webhook delivery; it will not be retried",
+			"provider", h.provider, "error", err)
+		err = n.post(ctx, h, uuid.NewString(), body)
+		n.recordOutcome(ctx, h, err)
+		return err
+	}
+	_, err = n.attempt(ctx, h, d)
+	return err
+}
+
+// attempt posts a logged delivery and records the outcome on it and on the webhook. A
+// failed delivery is scheduled for its next attempt under the retry policy, or marked
+// failed once the retry window is spent; the returned status says which. Failing to
+// record the outcome is logged, not returned.
+func (n *DefaultNotifier) attempt(ctx context.Context, h webhook, d delivery) (string, error) {
+	err := n.post(ctx, h, d.id, d.payload)
+	n.recordOutcome(ctx, h, err)
+
+	status := deliveryDelivered
+	var next sql.NullTime
+	var lastErr sql.NullString
+	if err != nil {
+		status = deliveryFailed
+		lastErr = sql.NullString{String: err.Error(), Valid: true}
+		if at, ok := nextAttempt(d.createdAt, d.attempts+1, n.now()); ok && d.status == deliveryPending {
+			status = deliveryPending
+			next = sql.NullTime{Time: at, Valid: true}
+		}
+	}
+	const q = `
+		UPDATE team_webhook_deliveries
+		SET attempts = attempts + 1, last_attempt_at = now(), status = $2, next_attempt_at = $3, last_error = $4,
+			delivered_at = CASE WHEN $2 = 'delivered' THEN now() ELSE delivered_at END
+		WHERE id = $1`
+	if recErr := dbretry.Do(ctx, "record team webhook delivery attempt", func(ctx context.Context) error {
+		_, err := n.db.ExecContext(ctx, q, d.id, status, next, lastErr)
+		return err
+	}); recErr != nil {
+		logging.Default().Warn(ctx, "Failed to record team webhook delivery attempt",
+			"provider", h.provider, "delivery_id", d.id, "error", recErr)
+	}
+	return status, err
+}
+
+// recordOutcome records a delivery's outcome as the webhook's last delivery. Failing to
+// record it is logged, not returned.
+func (n *DefaultNotifier) recordOutcome(ctx context.Context, h webhook, deliveryErr error) {
+	var outcome sql.NullString
+	if deliveryErr != nil {
+		outcome = sql.NullString{String: deliveryErr.Error(), Valid: true}
+	}
+	const q = `UPDATE team_webhooks SET last_delivery_at = now(), last_delivery_error = $2 WHERE id = $1`
+	if err := dbretry.Do(ctx, "record team webhook delivery", func(ctx context.Context) error {
+		_, err := n.db.ExecContext(ctx, q, h.id, outcome)
+		return err
+	}); err != nil {
+		logging.Default().Warn(ctx, "Failed to record team webhook delivery", "provider", h.provider, "error", err)
+	}
+}
+
+// post sends body to the webhook URL, signed as delivery id. Anything but a 2xx response is
+// an error carrying the start of the response body, which is where Slack and Teams explain
+// rejections.
+func (n *DefaultNotifier) post(ctx context.Context, h webhook, id string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.New("build request: invalid webhook URL")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if err := signRequest(req, h.secrets, id, n.now(), body); err != nil {
+		return err
+	}
+
+	res, err := n.client.Do(req)
+	if err != nil {
+		// The URL is a credential; keep it out of the error.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("post message: %w", err)
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBody))
+		return fmt.Errorf("%s responded %d: %s", providerName(h.provider), res.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	return nil
+}
@@ -0,0 +1,134 @@
+package teamwebhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var webhookColumns = []string{"id", "provider", "url", "signing_secret", "previous_signing_secret", "previous_secret_expires_at", "name"}
+
+const testSecret = "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw"
+
+// expectDelivery expects a delivery of the webhook to be logged, recorded on the webhook
+// with outcome, and its attempt recorded with status.
+func expectDelivery(mock sqlmock.Sqlmock, webhookID, deliveryID string, outcome any, status string) {
+	mock.ExpectQuery(`INSERT INTO team_webhook_deliveries`).WithArgs(webhookID, EventBatchComplete, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(deliveryID, time.Now()))
+	mock.ExpectExec(`UPDATE team_webhooks SET`).WithArgs(webhookID, outcome).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE team_webhook_deliveries`).WithArgs(deliveryID, status, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func TestDefaultNotifier_BatchComplete(t *testing.T) {
+	bodies := map[string][]byte{}
+	headers := map[string]http.Header{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		bodies[r.URL.Path] = body
+		headers[r.URL.Path] = r.Header.Clone()
+		if r.URL.Path == "/gone" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("no_service"))
+		}
+	}))
+	defer srv.Close()
+
+	testCases := []struct {
+		name      string
+		images    int
+		setup     func(mock sqlmock.Sqlmock)
+		errSubstr string
+		check     func(t *testing.T)
+	}{
+		{
+			name:   "success: posts to slack and teams",
+			images: 3,
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM images i`).WithArgs("img-1").
+					WillReturnRows(sqlmock.NewRows(webhookColumns).
+						AddRow("wh-1", ProviderSlack, srv.URL+"/slack", testSecret, nil, nil, "Maple & Main").
+						AddRow("wh-2", ProviderTeams, srv.URL+"/teams", testSecret, nil, nil, "Maple & Main"))
+				expectDelivery(mock, "wh-1", "d-1", nil, deliveryDelivered)
+				expectDelivery(mock, "wh-2", "d-2", nil, deliveryDelivered)
+			},
+			check: func(t *testing.T) {
+				var slack struct {
+					Text string `json:"text"`
+				}
+				require.NoError(t, json.Unmarshal(bodies["/slack"], &slack))
+				assert.Equal(t, "Batch staging complete: 3 images in Maple &amp; Main finished staging and are ready to download.", slack.Text)
+				assert.Contains(t, string(bodies["/teams"]), "application/vnd.microsoft.card.adaptive")
+				assert.Contains(t, string(bodies["/teams"]), `3 images in Maple \u0026 Main finished staging`)
+
+				h := headers["/slack"]
+				assert.Equal(t, "d-1", h.Get(headerID))
+				ts, err := strconv.ParseInt(h.Get(headerTimestamp), 10, 64)
+				require.NoError(t, err)
+				want, err := sign([]string{testSecret}, "d-1", time.Unix(ts, 0), bodies["/slack"])
+				require.NoError(t, err)
+				assert.Equal(t, want, h.Get(headerSignature))
+			},
+		},
+		{
+			name:   "success: no webhooks subscribed",
+			images: 1,
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM images i`).WithArgs("img-1").WillReturnRows(sqlmock.NewRows(webhookColumns))
+			},
+		},
+		{
+			name:   "fail: rejected delivery is recorded and returned",
+			images: 1,
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM images i`).WithArgs("img-1").
+					WillReturnRows(sqlmock.NewRows(webhookColumns).
+						AddRow("wh-1", ProviderSlack, srv.URL+"/gone", testSecret, nil, nil, "Maple"))
+				expectDelivery(mock, "wh-1", "d-1", "Slack responded 404: no_service", deliveryPending)
+			},
+			errSubstr: "slack webhook: Slack responded 404: no_service",
+			check: func(t *testing.T) {
+				assert.Contains(t, string(bodies["/gone"]), "1 image in Maple finished staging and is ready")
+			},
+		},
+		{
+			name:   "fail: lookup error",
+			images: 1,
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM images i`).WithArgs("img-1").WillReturnError(errors.New("boom"))
+			},
+			errSubstr: "list team webhooks: boom",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+			tc.setup(mock)
+
+			err = NewDefaultNotifierWithClient(db, srv.Client()).BatchComplete(context.Background(), "img-1", tc.images)
+			if tc.errSubstr != "" {
+				assert.ErrorContains(t, err, tc.errSubstr)
+			} else {
+				require.NoError(t, err)
+			}
+			if tc.check != nil {
+				tc.check(t)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package teamwebhook
+
+import "time"
+
+// Delivery statuses, as the API's teamwebhook package records them.
+const (
+	deliveryPending   = "pending"
+	deliveryDelivered = "delivered"
+	deliveryFailed    = "failed"
+)
+
+// Retry policy, matching the API's: failed attempts are retried after retryBase, doubling
+// each time up to retryMaxDelay, for as long as the next attempt falls within retryWindow
+// of the delivery being logged.
+const (
+	retryBase     = time.Minute
+	retryMaxDelay = 4 * time.Hour
+	retryWindow   = 24 * time.Hour
+)
+
+// nextAttempt returns when a delivery logged at created should be tried again after its
+// attempts-th failed attempt at now. ok is false once the retry window is spent.
+func nextAttempt(created time.Time, attempts int32, now time.Time) (next time.Time, ok bool) {
+	delay := retryMaxDelay
+	if attempts >= 1 && attempts <= 16 {
+		delay = min(retryBase<<(attempts-1), retryMaxDelay)
+	}
+	next = now.Add(delay)
+	if next.After(created.Add(retryWindow)) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+// delivery is a team_webhook_deliveries row, with what an attempt needs of it.
+type delivery struct {
+	id        string
+	payload   []byte
+	status    string
+	attempts  int32
+	createdAt time.Time
+}
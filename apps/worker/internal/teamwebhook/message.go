@@ -0,0 +1,75 @@
+package teamwebhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Message is a rendered notification, before it is shaped for a provider.
+type Message struct {
+	Title string
+	Text  string
+}
+
+// batchCompleteText is the batch_complete template, executed with the batch's data.
+var batchCompleteText = template.Must(template.New(EventBatchComplete).Option("missingkey=zero").Parse(
+	"{{.images}} {{if eq .images \"1\"}}image{{else}}images{{end}} in {{.project_name}} finished staging " +
+		"and {{if eq .images \"1\"}}is{{else}}are{{end}} ready to download."))
+
+// renderBatchComplete renders the batch_complete message.
+func renderBatchComplete(data map[string]string) (Message, error) {
+	var text strings.Builder
+	if err := batchCompleteText.Execute(&text, data); err != nil {
+		return Message{}, fmt.Errorf("render %s: %w", EventBatchComplete, err)
+	}
+	return Message{Title: "Batch staging complete", Text: text.String()}, nil
+}
+
+// payload shapes m as the provider's incoming webhook expects it: Block Kit for Slack and
+// an Adaptive Card message, which both Teams connectors and Workflows accept, for Teams.
+func payload(provider string, m Message) ([]byte, error) {
+	switch provider {
+	case ProviderSlack:
+		text := slackEscape(m.Text)
+		return json.Marshal(map[string]any{
+			"text": slackEscape(m.Title) + ": " + text,
+			"blocks": []map[string]any{
+				{"type": "header", "text": map[string]string{"type": "plain_text", "text": m.Title}},
+				{"type": "section", "text": map[string]string{"type": "mrkdwn", "text": text}},
+			},
+		})
+	case ProviderTeams:
+		return json.Marshal(map[string]any{
+			"type": "message",
+			"attachments": []map[string]any{{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]any{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"body": []map[string]any{
+						{"type": "TextBlock", "text": m.Title, "weight": "Bolder", "size": "Medium", "wrap": true},
+						{"type": "TextBlock", "text": m.Text, "wrap": true},
+					},
+				},
+			}},
+		})
+	default:
+		return nil, fmt.Errorf("unknown provider %q", provider)
+	}
+}
+
+// slackEscape escapes the characters Slack treats as markup in mrkdwn and plain text.
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// providerName is the provider as written in errors.
+func providerName(provider string) string {
+	if provider == ProviderTeams {
+		return "Microsoft Teams"
+	}
+	return "Slack"
+}
@@ -0,0 +1,26 @@
+// Package teamwebhook posts team notifications for work the worker finishes to the Slack
+// and Microsoft Teams incoming webhooks an account registered through the API. The payload
+// formats, signatures and delivery log match the API's teamwebhook package, and the
+// Retrier retries every logged delivery that failed, whichever service first posted it.
+package teamwebhook
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out notifier_mock.go . Notifier
+
+// Providers a webhook can be registered for.
+const (
+	ProviderSlack = "slack"
+	ProviderTeams = "teams"
+)
+
+// EventBatchComplete is posted when a batch job finishes staging all of its images.
+const EventBatchComplete = "batch_complete"
+
+// Notifier posts to the webhooks of the account a batch belongs to. Implementations must
+// not fail the job that triggered them: callers log returned errors and carry on.
+type Notifier interface {
+	// BatchComplete posts that a batch of images finished staging. imageID is any image of
+	// the batch; a batch never spans projects, so it identifies the project and account.
+	BatchComplete(ctx context.Context, imageID string, images int) error
+}
@@ -0,0 +1,167 @@
+package teamwebhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/real-staging-ai/worker/internal/dbretry"
+	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/schedule"
+)
+
+const (
+	// claimLease is how long a claimed delivery is held by the worker attempting it. A
+	// worker that stops mid-attempt leaves the delivery due again once the lease ends.
+	claimLease = 5 * time.Minute
+	// deliveryRetention is how long deliveries are kept once they stop being pending.
+	deliveryRetention = 30 * 24 * time.Hour
+)
+
+// Config controls a retry run.
+type Config struct {
+	// Interval is the time between runs.
+	Interval time.Duration
+	// BatchSize is the number of due deliveries claimed at a time.
+	BatchSize int
+}
+
+// Result counts what a run did.
+type Result struct {
+	Delivered int
+	// Rescheduled deliveries failed again and will be retried.
+	Rescheduled int
+	// Failed deliveries failed again with their retry window spent.
+	Failed int
+	Purged int
+}
+
+// Retrier periodically retries team webhook deliveries whose next attempt is due, and
+// purges old deliveries. Workers claim deliveries with SKIP LOCKED, so several can run
+// at once without posting a delivery twice.
+type Retrier struct {
+	notifier *DefaultNotifier
+	cfg      Config
+	log      logging.Logger
+}
+
+// NewRetrier creates a Retrier that posts through notifier.
+func NewRetrier(notifier *DefaultNotifier, cfg Config, log logging.Logger) *Retrier {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Minute
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	return &Retrier{notifier: notifier, cfg: cfg, log: log}
+}
+
+// Run attempts due deliveries a batch at a time until fewer than a full batch is due, then
+// purges deliveries past their retention.
+func (r *Retrier) Run(ctx context.Context) (Result, error) {
+	var res Result
+	for {
+		claimed, err := r.claim(ctx)
+		if err != nil {
+			return res, err
+		}
+		for _, c := range claimed {
+			status, _ := r.notifier.attempt(ctx, c.webhook, c.delivery)
+			switch status {
+			case deliveryDelivered:
+				res.Delivered++
+			case deliveryPending:
+				res.Rescheduled++
+			default:
+				res.Failed++
+			}
+		}
+		if len(claimed) < r.cfg.BatchSize {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+	}
+
+	const q = `DELETE FROM team_webhook_deliveries WHERE status <> 'pending' AND created_at < $1`
+	err := dbretry.Do(ctx, "purge team webhook deliveries", func(ctx context.Context) error {
+		result, err := r.notifier.db.ExecContext(ctx, q, r.notifier.now().Add(-deliveryRetention))
+		if err != nil {
+			return err
+		}
+		n, err := result.RowsAffected()
+		res.Purged = int(n)
+		return err
+	})
+	if err != nil {
+		return res, fmt.Errorf("purge team webhook deliveries: %w", err)
+	}
+	return res, nil
+}
+
+// claimed is a due delivery with the webhook it goes to.
+type claimed struct {
+	webhook  webhook
+	delivery delivery
+}
+
+// claim leases a batch of due deliveries by pushing their next attempt past claimLease.
+func (r *Retrier) claim(ctx context.Context) ([]claimed, error) {
+	const q = `
+		UPDATE team_webhook_deliveries d
+		SET next_attempt_at = $2
+		FROM (
+			SELECT id FROM team_webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= now()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		) due, team_webhooks w
+		WHERE d.id = due.id AND w.id = d.webhook_id
+		RETURNING d.id, d.payload, d.attempts, d.created_at,
+			w.id, w.provider, w.url, w.signing_secret, w.previous_signing_secret, w.previous_secret_expires_at`
+	var out []claimed
+	err := dbretry.Do(ctx, "claim team webhook deliveries", func(ctx context.Context) error {
+		out = out[:0]
+		rows, err := r.notifier.db.QueryContext(ctx, q, r.cfg.BatchSize, r.notifier.now().Add(claimLease))
+		if err != nil {
+			return err
+		}
+		defer func() { _ = rows.Close() }()
+		for rows.Next() {
+			c := claimed{delivery: delivery{status: deliveryPending}}
+			err := rows.Scan(&c.delivery.id, &c.delivery.payload, &c.delivery.attempts, &c.delivery.createdAt,
+				&c.webhook.id, &c.webhook.provider, &c.webhook.url,
+				&c.webhook.secrets.current, &c.webhook.secrets.previous, &c.webhook.secrets.previousExpires)
+			if err != nil {
+				return err
+			}
+			out = append(out, c)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("claim team webhook deliveries: %w", err)
+	}
+	return out, nil
+}
+
+// RunEvery blocks until ctx is done, retrying due deliveries every configured interval.
+func (r *Retrier) RunEvery(ctx context.Context) {
+	schedule.Every(ctx, r.cfg.Interval, func(ctx context.Context) {
+		res, err := r.Run(ctx)
+		if err != nil {
+			r.log.Error(ctx, "Team webhook delivery retry failed", "error", err,
+				"delivered", res.Delivered, "rescheduled", res.Rescheduled, "failed", res.Failed)
+			return
+		}
+		if res.Failed > 0 {
+			r.log.Warn(ctx, "Team webhook deliveries failed after their last retry", "failed", res.Failed)
+		}
+		if res.Delivered+res.Rescheduled+res.Failed+res.Purged > 0 {
+			r.log.Info(ctx, "Team webhook delivery retry completed", "delivered", res.Delivered,
+				"rescheduled", res.Rescheduled, "failed", res.Failed, "purged", res.Purged)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package teamwebhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+)
+
+var claimColumns = []string{
+	"id", "payload", "attempts", "created_at",
+	"webhook_id", "provider", "url", "signing_secret", "previous_signing_secret", "previous_secret_expires_at",
+}
+
+func TestRetrier_Run(t *testing.T) {
+	now := time.Date(2025, 3, 8, 12, 0, 0, 0, time.UTC)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/gone" {
+			w.WriteHeader(http.StatusGone)
+		}
+	}))
+	defer srv.Close()
+
+	testCases := []struct {
+		name      string
+		setup     func(mock sqlmock.Sqlmock)
+		want      Result
+		errSubstr string
+	}{
+		{
+			name: "success: delivers, reschedules and gives up",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE team_webhook_deliveries d`).WithArgs(2, now.Add(claimLease)).
+					WillReturnRows(sqlmock.NewRows(claimColumns).
+						AddRow("d-1", []byte(`{}`), 2, now.Add(-time.Hour), "wh-1", ProviderSlack, srv.URL+"/ok", testSecret, nil, nil).
+						AddRow("d-2", []byte(`{}`), 2, now.Add(-time.Hour), "wh-2", ProviderTeams, srv.URL+"/gone", testSecret, nil, nil))
+				mock.ExpectExec(`UPDATE team_webhooks SET`).WithArgs("wh-1", nil).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`UPDATE team_webhook_deliveries`).WithArgs("d-1", deliveryDelivered, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`UPDATE team_webhooks SET`).WithArgs("wh-2", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`UPDATE team_webhook_deliveries`).WithArgs("d-2", deliveryPending, now.Add(4*retryBase), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(`UPDATE team_webhook_deliveries d`).WithArgs(2, now.Add(claimLease)).
+					WillReturnRows(sqlmock.NewRows(claimColumns).
+						AddRow("d-3", []byte(`{}`), 12, now.Add(-23*time.Hour), "wh-2", ProviderTeams, srv.URL+"/gone", testSecret, nil, nil))
+				mock.ExpectExec(`UPDATE team_webhooks SET`).WithArgs("wh-2", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`UPDATE team_webhook_deliveries`).WithArgs("d-3", deliveryFailed, nil, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`DELETE FROM team_webhook_deliveries`).WithArgs(now.Add(-deliveryRetention)).
+					WillReturnResult(sqlmock.NewResult(0, 5))
+			},
+			want: Result{Delivered: 1, Rescheduled: 1, Failed: 1, Purged: 5},
+		},
+		{
+			name: "fail: claim error",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE team_webhook_deliveries d`).WillReturnError(errors.New("boom"))
+			},
+			errSubstr: "claim team webhook deliveries: boom",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+			tc.setup(mock)
+
+			n := NewDefaultNotifierWithClient(db, srv.Client())
+			n.now = func() time.Time { return now }
+			res, err := NewRetrier(n, Config{BatchSize: 2}, &logging.LoggerMock{}).Run(context.Background())
+			if tc.errSubstr != "" {
+				assert.ErrorContains(t, err, tc.errSubstr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, res)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package teamwebhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Signature headers, as the API's teamwebhook package sets them: webhook-signature carries
+// "v1,<base64 HMAC-SHA256>" of "<webhook-id>.<webhook-timestamp>.<body>" for each current
+// secret, space-separated.
+const (
+	headerID        = "webhook-id"
+	headerTimestamp = "webhook-timestamp"
+	headerSignature = "webhook-signature"
+)
+
+// secretPrefix marks signing secrets; the key is the base64 that follows it.
+const secretPrefix = "whsec_"
+
+// secrets are a webhook's signing secrets. The previous one, left by a rotation, signs
+// alongside the current one until it expires.
+type secrets struct {
+	current         string
+	previous        sql.NullString
+	previousExpires sql.NullTime
+}
+
+// at returns the secrets deliveries are signed with at now.
+func (s secrets) at(now time.Time) []string {
+	keys := []string{s.current}
+	if s.previous.Valid && s.previousExpires.Valid && now.Before(s.previousExpires.Time) {
+		keys = append(keys, s.previous.String)
+	}
+	return keys
+}
+
+// sign returns the webhook-signature value for body under each secret.
+func sign(keys []string, id string, ts time.Time, body []byte) (string, error) {
+	signed := id + "." + strconv.FormatInt(ts.Unix(), 10) + "."
+	sigs := make([]string, 0, len(keys))
+	for _, secret := range keys {
+		key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, secretPrefix))
+		if err != nil {
+			return "", fmt.Errorf("invalid signing secret: %w", err)
+		}
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(signed))
+		mac.Write(body)
+		sigs = append(sigs, "v1,"+base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	}
+	return strings.Join(sigs, " "), nil
+}
+
+// signRequest sets the signature headers of delivery id of body.
+func signRequest(req *http.Request, s secrets, id string, now time.Time, body []byte) error {
+	sig, err := sign(s.at(now), id, now, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(headerID, id)
+	req.Header.Set(headerTimestamp, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(headerSignature, sig)
+	return nil
+}
@@ -0,0 +1,44 @@
+package teamwebhook
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	// Test vector from the Standard Webhooks reference libraries.
+	sig, err := sign([]string{testSecret}, "msg_p5jXN8AQM9LWM0D4loKWxJek", time.Unix(1614265330, 0),
+		[]byte(`{"test": 2432232314}`))
+	require.NoError(t, err)
+	assert.Equal(t, "v1,g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE=", sig)
+}
+
+func TestSecrets_At(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	s := secrets{
+		current:         testSecret,
+		previous:        sql.NullString{String: "whsec_b2xk", Valid: true},
+		previousExpires: sql.NullTime{Time: now.Add(time.Hour), Valid: true},
+	}
+	assert.Equal(t, []string{testSecret, "whsec_b2xk"}, s.at(now))
+	assert.Equal(t, []string{testSecret}, s.at(now.Add(2*time.Hour)))
+}
+
+func TestNextAttempt(t *testing.T) {
+	created := time.Unix(1700000000, 0)
+
+	next, ok := nextAttempt(created, 1, created)
+	assert.True(t, ok)
+	assert.Equal(t, created.Add(retryBase), next)
+
+	next, ok = nextAttempt(created, 12, created.Add(10*time.Hour))
+	assert.True(t, ok)
+	assert.Equal(t, created.Add(10*time.Hour+retryMaxDelay), next)
+
+	_, ok = nextAttempt(created, 13, created.Add(21*time.Hour))
+	assert.False(t, ok)
+}
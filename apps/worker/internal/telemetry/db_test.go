@@ -0,0 +1,46 @@
+package telemetry
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// fakeDriver runs every statement successfully without a server.
+type fakeDriver struct{}
+
+func (d fakeDriver) Open(string) (driver.Conn, error)             { return fakeConn{}, nil }
+func (d fakeDriver) Connect(context.Context) (driver.Conn, error) { return fakeConn{}, nil }
+func (d fakeDriver) Driver() driver.Driver                        { return d }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not implemented") }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not implemented") }
+func (fakeConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+
+func TestCountQueries(t *testing.T) {
+	db := sql.OpenDB(CountQueries(fakeDriver{}))
+	defer func() { _ = db.Close() }()
+
+	ctx, u := WithUsage(context.Background())
+	require.NoError(t, db.PingContext(ctx))
+	for range 3 {
+		_, err := db.ExecContext(ctx, "UPDATE images SET status = 'ready'")
+		require.NoError(t, err)
+	}
+	// Statements outside a job are not counted anywhere.
+	_, err := db.ExecContext(context.Background(), "UPDATE images SET status = 'ready'")
+	require.NoError(t, err)
+
+	assert.Contains(t, u.Attributes(), attribute.Int64(AttrDBQueryCount, 3))
+}
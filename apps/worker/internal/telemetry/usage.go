@@ -0,0 +1,107 @@
+package telemetry
+
+import (
+	"context"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go/middleware"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Span attributes summarizing what a job cost. The collector's tail sampling keeps every
+// trace whose root span crosses its thresholds (see infra/otelcol.yaml).
+const (
+	AttrDBQueryCount      = "db.query_count"
+	AttrS3OperationCount  = "s3.operation_count"
+	AttrProviderLatencyMS = "provider.latency_ms"
+	AttrEstimatedCostUSD  = "job.estimated_cost_usd"
+)
+
+// Usage accumulates the work done for one job. It is safe for concurrent use, since a batch
+// job stages its images in parallel. A nil *Usage ignores everything recorded on it.
+type Usage struct {
+	dbQueries       atomic.Int64
+	s3Operations    atomic.Int64
+	providerLatency atomic.Int64
+	// costMicroUSD is the estimated provider cost in millionths of a dollar.
+	costMicroUSD atomic.Int64
+}
+
+type usageKey struct{}
+
+// WithUsage returns a context carrying a new Usage, which the DB, S3 and provider calls
+// made with it record into.
+func WithUsage(ctx context.Context) (context.Context, *Usage) {
+	u := &Usage{}
+	return context.WithValue(ctx, usageKey{}, u), u
+}
+
+// UsageFrom returns the Usage carried by ctx, or nil.
+func UsageFrom(ctx context.Context) *Usage {
+	u, _ := ctx.Value(usageKey{}).(*Usage)
+	return u
+}
+
+// AddDBQuery counts a database statement.
+func (u *Usage) AddDBQuery() {
+	if u != nil {
+		u.dbQueries.Add(1)
+	}
+}
+
+// AddS3Operation counts an S3 request sent, retries included.
+func (u *Usage) AddS3Operation() {
+	if u != nil {
+		u.s3Operations.Add(1)
+	}
+}
+
+// AddProviderLatency adds time spent waiting on a provider prediction.
+func (u *Usage) AddProviderLatency(d time.Duration) {
+	if u != nil {
+		u.providerLatency.Add(int64(d))
+	}
+}
+
+// AddEstimatedCost adds the expected provider cost, in USD, of a prediction.
+func (u *Usage) AddEstimatedCost(usd float64) {
+	if u != nil {
+		u.costMicroUSD.Add(int64(math.Round(usd * 1e6)))
+	}
+}
+
+// Attributes returns the span attributes summarizing the usage. Provider latency and cost
+// are only included once known.
+func (u *Usage) Attributes() []attribute.KeyValue {
+	if u == nil {
+		return nil
+	}
+	attrs := []attribute.KeyValue{
+		attribute.Int64(AttrDBQueryCount, u.dbQueries.Load()),
+		attribute.Int64(AttrS3OperationCount, u.s3Operations.Load()),
+	}
+	if latency := time.Duration(u.providerLatency.Load()); latency > 0 {
+		attrs = append(attrs, attribute.Int64(AttrProviderLatencyMS, latency.Milliseconds()))
+	}
+	if cost := u.costMicroUSD.Load(); cost > 0 {
+		attrs = append(attrs, attribute.Float64(AttrEstimatedCostUSD, float64(cost)/1e6))
+	}
+	return attrs
+}
+
+// CountS3Operations is an s3.Options function that counts each request the client sends
+// into the Usage of its context. Presigning sends nothing and is not counted.
+func CountS3Operations(o *s3.Options) {
+	o.APIOptions = append(o.APIOptions, func(stack *middleware.Stack) error {
+		return stack.Deserialize.Add(middleware.DeserializeMiddlewareFunc("CountS3Operation",
+			func(
+				ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler,
+			) (middleware.DeserializeOutput, middleware.Metadata, error) {
+				UsageFrom(ctx).AddS3Operation()
+				return next.HandleDeserialize(ctx, in)
+			}), middleware.After)
+	})
+}
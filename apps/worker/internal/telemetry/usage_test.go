@@ -0,0 +1,107 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestUsage_Attributes(t *testing.T) {
+	testCases := []struct {
+		name   string
+		record func(u *Usage)
+		want   []attribute.KeyValue
+	}{
+		{
+			name:   "success: provider attributes omitted until known",
+			record: func(u *Usage) { u.AddDBQuery(); u.AddDBQuery(); u.AddS3Operation() },
+			want: []attribute.KeyValue{
+				attribute.Int64(AttrDBQueryCount, 2),
+				attribute.Int64(AttrS3OperationCount, 1),
+			},
+		},
+		{
+			name: "success: provider latency and cost add up",
+			record: func(u *Usage) {
+				u.AddProviderLatency(1500 * time.Millisecond)
+				u.AddProviderLatency(500 * time.Millisecond)
+				u.AddEstimatedCost(0.08)
+				u.AddEstimatedCost(0.03)
+			},
+			want: []attribute.KeyValue{
+				attribute.Int64(AttrDBQueryCount, 0),
+				attribute.Int64(AttrS3OperationCount, 0),
+				attribute.Int64(AttrProviderLatencyMS, 2000),
+				attribute.Float64(AttrEstimatedCostUSD, 0.11),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, u := WithUsage(context.Background())
+			require.Same(t, u, UsageFrom(ctx))
+			tc.record(u)
+			assert.Equal(t, tc.want, u.Attributes())
+		})
+	}
+}
+
+func TestUsage_NilIgnoresRecords(t *testing.T) {
+	u := UsageFrom(context.Background())
+	require.Nil(t, u)
+	u.AddDBQuery()
+	u.AddS3Operation()
+	u.AddProviderLatency(time.Second)
+	u.AddEstimatedCost(1)
+	assert.Nil(t, u.Attributes())
+}
+
+func TestUsage_ConcurrentRecords(t *testing.T) {
+	_, u := WithUsage(context.Background())
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			u.AddDBQuery()
+			u.AddEstimatedCost(0.002)
+		}()
+	}
+	wg.Wait()
+	assert.Contains(t, u.Attributes(), attribute.Int64(AttrDBQueryCount, 50))
+	assert.Contains(t, u.Attributes(), attribute.Float64(AttrEstimatedCostUSD, 0.1))
+}
+
+func TestCountS3Operations(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := s3.New(s3.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(srv.URL),
+		UsePathStyle: true,
+		Credentials:  credentials.NewStaticCredentialsProvider("test", "test", ""),
+	}, CountS3Operations)
+	ctx, u := WithUsage(context.Background())
+
+	_, err := client.HeadObject(ctx, &s3.HeadObjectInput{Bucket: aws.String("b"), Key: aws.String("k")})
+	require.NoError(t, err)
+	_, err = s3.NewPresignClient(client).PresignGetObject(ctx,
+		&s3.GetObjectInput{Bucket: aws.String("b"), Key: aws.String("k")})
+	require.NoError(t, err)
+
+	assert.Contains(t, u.Attributes(), attribute.Int64(AttrS3OperationCount, 1))
+}
@@ -0,0 +1,190 @@
+// Package throttle adapts how many predictions the worker runs against the model provider
+// at once. Rate limiting (429) and latency spikes cut the limit in half; a run of healthy
+// responses raises it by one, so a struggling provider is backed off quickly and load
+// returns gradually once it recovers.
+package throttle
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+)
+
+// DefaultCooldown is how long after a backoff further backoffs are ignored, so one burst
+// of throttled responses halves the limit once instead of collapsing it to the minimum.
+const DefaultCooldown = 5 * time.Second
+
+// Config bounds and tunes an AdaptiveLimiter.
+type Config struct {
+	// Min is the floor the limit backs off to; at least 1.
+	Min int
+	// Max is the ceiling the limit ramps up to and its starting value.
+	Max int
+	// LatencyThreshold is the response time above which a response counts as a spike.
+	// Zero only backs off on 429s.
+	LatencyThreshold time.Duration
+	// RampUpAfter is how many healthy responses in a row raise the limit by one.
+	RampUpAfter int
+	// Cooldown ignores backoffs this soon after the last one. Zero uses DefaultCooldown.
+	Cooldown time.Duration
+}
+
+// AdaptiveLimiter caps concurrent provider predictions with an additive-increase,
+// multiplicative-decrease limit fed by the provider's responses.
+type AdaptiveLimiter struct {
+	cfg Config
+	log logging.Logger
+	now func() time.Time
+
+	mu          sync.Mutex
+	limit       int
+	inFlight    int
+	successes   int
+	lastBackoff time.Time
+	// changed is closed and replaced whenever a slot frees up or the limit grows.
+	changed chan struct{}
+}
+
+// NewAdaptiveLimiter returns a limiter starting at cfg.Max.
+func NewAdaptiveLimiter(cfg Config, log logging.Logger) *AdaptiveLimiter {
+	if cfg.Max < 1 {
+		cfg.Max = 1
+	}
+	if cfg.Min < 1 {
+		cfg.Min = 1
+	}
+	if cfg.Min > cfg.Max {
+		cfg.Min = cfg.Max
+	}
+	if cfg.RampUpAfter < 1 {
+		cfg.RampUpAfter = 1
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = DefaultCooldown
+	}
+	return &AdaptiveLimiter{
+		cfg:     cfg,
+		log:     log,
+		now:     time.Now,
+		limit:   cfg.Max,
+		changed: make(chan struct{}),
+	}
+}
+
+// Limit returns the current concurrency limit.
+func (l *AdaptiveLimiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limit
+}
+
+// Acquire waits for a free slot under the current limit. The returned release func must
+// be called once the prediction finishes; it is safe to call more than once.
+func (l *AdaptiveLimiter) Acquire(ctx context.Context) (release func(), err error) {
+	for {
+		l.mu.Lock()
+		if l.inFlight < l.limit {
+			l.inFlight++
+			l.mu.Unlock()
+			var once sync.Once
+			return func() { once.Do(l.release) }, nil
+		}
+		changed := l.changed
+		l.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+func (l *AdaptiveLimiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inFlight--
+	l.broadcast()
+}
+
+// Observe feeds one provider response into the limit. A 429 or a response slower than
+// the latency threshold backs off; any other response counts towards ramping up.
+func (l *AdaptiveLimiter) Observe(status int, latency time.Duration) {
+	if status == http.StatusTooManyRequests ||
+		(l.cfg.LatencyThreshold > 0 && latency > l.cfg.LatencyThreshold) {
+		l.backoff(status, latency)
+		return
+	}
+	l.success()
+}
+
+func (l *AdaptiveLimiter) backoff(status int, latency time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.successes = 0
+	now := l.now()
+	if !l.lastBackoff.IsZero() && now.Sub(l.lastBackoff) < l.cfg.Cooldown {
+		return
+	}
+	l.lastBackoff = now
+
+	next := max(l.cfg.Min, l.limit/2)
+	if next == l.limit {
+		return
+	}
+	l.log.Warn(context.Background(), "Backing off provider concurrency",
+		"from", l.limit, "to", next, "status", status, "latency_ms", latency.Milliseconds())
+	l.limit = next
+}
+
+func (l *AdaptiveLimiter) success() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.limit >= l.cfg.Max {
+		l.successes = 0
+		return
+	}
+	l.successes++
+	if l.successes < l.cfg.RampUpAfter {
+		return
+	}
+	l.successes = 0
+	l.limit++
+	l.log.Info(context.Background(), "Raising provider concurrency", "to", l.limit)
+	l.broadcast()
+}
+
+// broadcast wakes every waiting Acquire. Callers hold mu.
+func (l *AdaptiveLimiter) broadcast() {
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+// Transport wraps base so every response it returns is observed by the limiter. A nil
+// base uses http.DefaultTransport. Transport errors are not observed.
+func (l *AdaptiveLimiter) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &observingTransport{base: base, limiter: l}
+}
+
+type observingTransport struct {
+	base    http.RoundTripper
+	limiter *AdaptiveLimiter
+}
+
+func (t *observingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := t.limiter.now()
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	t.limiter.Observe(resp.StatusCode, t.limiter.now().Sub(start))
+	return resp, nil
+}
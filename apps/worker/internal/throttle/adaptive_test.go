@@ -0,0 +1,201 @@
+package throttle
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+)
+
+func newLogger() *logging.LoggerMock {
+	return &logging.LoggerMock{
+		InfoFunc: func(ctx context.Context, msg string, keysAndValues ...any) {},
+		WarnFunc: func(ctx context.Context, msg string, keysAndValues ...any) {},
+	}
+}
+
+// newTestLimiter returns a limiter whose clock only moves when the returned func is called.
+func newTestLimiter(cfg Config) (*AdaptiveLimiter, func(time.Duration)) {
+	l := NewAdaptiveLimiter(cfg, newLogger())
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+	return l, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestNewAdaptiveLimiter(t *testing.T) {
+	t.Run("success: starts at max", func(t *testing.T) {
+		l := NewAdaptiveLimiter(Config{Min: 2, Max: 8}, newLogger())
+		assert.Equal(t, 8, l.Limit())
+	})
+
+	t.Run("success: clamps invalid bounds", func(t *testing.T) {
+		l := NewAdaptiveLimiter(Config{Min: 5, Max: 0}, newLogger())
+		assert.Equal(t, 1, l.Limit())
+		assert.Equal(t, 1, l.cfg.Min)
+		assert.Equal(t, 1, l.cfg.RampUpAfter)
+		assert.Equal(t, DefaultCooldown, l.cfg.Cooldown)
+	})
+}
+
+func TestAdaptiveLimiter_Observe(t *testing.T) {
+	t.Run("success: 429 halves the limit down to min", func(t *testing.T) {
+		l, advance := newTestLimiter(Config{Min: 2, Max: 8, Cooldown: time.Second})
+		l.Observe(http.StatusTooManyRequests, 0)
+		assert.Equal(t, 4, l.Limit())
+		advance(2 * time.Second)
+		l.Observe(http.StatusTooManyRequests, 0)
+		assert.Equal(t, 2, l.Limit())
+		advance(2 * time.Second)
+		l.Observe(http.StatusTooManyRequests, 0)
+		assert.Equal(t, 2, l.Limit())
+	})
+
+	t.Run("success: latency spike backs off", func(t *testing.T) {
+		l, _ := newTestLimiter(Config{Min: 1, Max: 8, LatencyThreshold: time.Second})
+		l.Observe(http.StatusOK, 500*time.Millisecond)
+		assert.Equal(t, 8, l.Limit())
+		l.Observe(http.StatusOK, 3*time.Second)
+		assert.Equal(t, 4, l.Limit())
+	})
+
+	t.Run("success: zero threshold ignores latency", func(t *testing.T) {
+		l, _ := newTestLimiter(Config{Min: 1, Max: 8})
+		l.Observe(http.StatusOK, time.Hour)
+		assert.Equal(t, 8, l.Limit())
+	})
+
+	t.Run("success: backoffs within the cooldown are ignored", func(t *testing.T) {
+		l, advance := newTestLimiter(Config{Min: 1, Max: 8, Cooldown: 5 * time.Second})
+		l.Observe(http.StatusTooManyRequests, 0)
+		advance(time.Second)
+		l.Observe(http.StatusTooManyRequests, 0)
+		assert.Equal(t, 4, l.Limit())
+		advance(5 * time.Second)
+		l.Observe(http.StatusTooManyRequests, 0)
+		assert.Equal(t, 2, l.Limit())
+	})
+
+	t.Run("success: ramps up one step per run of healthy responses", func(t *testing.T) {
+		l, _ := newTestLimiter(Config{Min: 1, Max: 4, RampUpAfter: 3})
+		l.Observe(http.StatusTooManyRequests, 0)
+		require.Equal(t, 2, l.Limit())
+
+		l.Observe(http.StatusOK, 0)
+		l.Observe(http.StatusCreated, 0)
+		assert.Equal(t, 2, l.Limit())
+		l.Observe(http.StatusOK, 0)
+		assert.Equal(t, 3, l.Limit())
+
+		for range 6 {
+			l.Observe(http.StatusOK, 0)
+		}
+		assert.Equal(t, 4, l.Limit(), "limit never exceeds max")
+	})
+
+	t.Run("success: a backoff resets the healthy run", func(t *testing.T) {
+		l, _ := newTestLimiter(Config{Min: 1, Max: 8, RampUpAfter: 2, Cooldown: time.Second})
+		l.Observe(http.StatusTooManyRequests, 0)
+		l.Observe(http.StatusOK, 0)
+		l.Observe(http.StatusTooManyRequests, 0) // within cooldown: no change, but resets the run
+		l.Observe(http.StatusOK, 0)
+		assert.Equal(t, 4, l.Limit())
+		l.Observe(http.StatusOK, 0)
+		assert.Equal(t, 5, l.Limit())
+	})
+}
+
+func TestAdaptiveLimiter_Acquire(t *testing.T) {
+	t.Run("success: blocks at the limit until a slot is released", func(t *testing.T) {
+		l := NewAdaptiveLimiter(Config{Min: 1, Max: 1}, newLogger())
+		release, err := l.Acquire(context.Background())
+		require.NoError(t, err)
+
+		acquired := make(chan struct{})
+		go func() {
+			second, err := l.Acquire(context.Background())
+			assert.NoError(t, err)
+			second()
+			close(acquired)
+		}()
+
+		select {
+		case <-acquired:
+			t.Fatal("acquired a slot over the limit")
+		case <-time.After(50 * time.Millisecond):
+		}
+		release()
+		release() // a second release is a no-op
+		select {
+		case <-acquired:
+		case <-time.After(time.Second):
+			t.Fatal("waiter was not woken by release")
+		}
+		assert.Equal(t, 0, l.inFlight)
+	})
+
+	t.Run("success: ramping up wakes waiters", func(t *testing.T) {
+		l := NewAdaptiveLimiter(Config{Min: 1, Max: 2, RampUpAfter: 1}, newLogger())
+		l.Observe(http.StatusTooManyRequests, 0)
+		require.Equal(t, 1, l.Limit())
+		release, err := l.Acquire(context.Background())
+		require.NoError(t, err)
+		defer release()
+
+		acquired := make(chan struct{})
+		go func() {
+			second, err := l.Acquire(context.Background())
+			assert.NoError(t, err)
+			second()
+			close(acquired)
+		}()
+		time.Sleep(20 * time.Millisecond)
+		l.Observe(http.StatusOK, 0)
+		select {
+		case <-acquired:
+		case <-time.After(time.Second):
+			t.Fatal("waiter was not woken by the higher limit")
+		}
+	})
+
+	t.Run("fail: context canceled while waiting", func(t *testing.T) {
+		l := NewAdaptiveLimiter(Config{Min: 1, Max: 1}, newLogger())
+		release, err := l.Acquire(context.Background())
+		require.NoError(t, err)
+		defer release()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err = l.Acquire(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestAdaptiveLimiter_Transport(t *testing.T) {
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	l := NewAdaptiveLimiter(Config{Min: 1, Max: 4, RampUpAfter: 1}, newLogger())
+	client := &http.Client{Transport: l.Transport(nil)}
+
+	status = http.StatusTooManyRequests
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, 2, l.Limit())
+
+	status = http.StatusOK
+	resp, err = client.Get(srv.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, 3, l.Limit())
+}
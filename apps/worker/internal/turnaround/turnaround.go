@@ -0,0 +1,131 @@
+// Package turnaround measures how long images take from first being queued to ready
+// against their owner's plan SLA, and credits owners on premium plans for misses.
+package turnaround
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/real-staging-ai/worker/internal/dbretry"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out recorder_mock.go . Recorder
+
+// Result is the measurement of one image.
+type Result struct {
+	// Recorded is false when the image was not ready or was measured before, e.g. by an
+	// earlier attempt of the same job. The other fields are then zero.
+	Recorded bool
+	// Plan is the owner's plan code, or "" without an active plan.
+	Plan       string
+	Turnaround time.Duration
+	// Target is the plan's SLA target, or zero when the plan has none.
+	Target time.Duration
+	// Met reports whether Turnaround was within Target. It is false without a Target.
+	Met bool
+	// Credited reports whether the miss earned the owner a one-image credit.
+	Credited bool
+}
+
+// Recorder measures images as they become ready.
+type Recorder interface {
+	// Record measures the ready image's turnaround, stores it and, when the owner's plan
+	// credits misses and the target was missed, grants a credit. Each image is measured once.
+	Record(ctx context.Context, imageID string) (Result, error)
+}
+
+// SQLRecorder stores turnarounds and credits with database/sql.
+type SQLRecorder struct {
+	db *sql.DB
+}
+
+// Ensure SQLRecorder implements Recorder.
+var _ Recorder = (*SQLRecorder)(nil)
+
+// NewSQLRecorder creates a SQLRecorder.
+func NewSQLRecorder(db *sql.DB) *SQLRecorder {
+	return &SQLRecorder{db: db}
+}
+
+// recordQuery measures from the first transition to queued, or the image's creation when
+// it was created queued before transitions were logged, to the last transition to ready.
+// The plan is the one entitlements resolve: the most recently updated active subscription.
+const recordQuery = `
+	WITH img AS (
+		SELECT i.id, p.user_id,
+		       COALESCE((SELECT min(t.created_at) FROM image_status_transitions t
+		                 WHERE t.image_id = i.id AND t.to_status = 'queued'), i.created_at) AS queued_at,
+		       COALESCE((SELECT max(t.created_at) FROM image_status_transitions t
+		                 WHERE t.image_id = i.id AND t.to_status = 'ready'), now()) AS ready_at
+		FROM images i
+		JOIN projects p ON p.id = i.project_id
+		WHERE i.id = $1::uuid AND i.status = 'ready'
+	),
+	plan AS (
+		SELECT pl.code, pl.sla_target_seconds, pl.sla_credit
+		FROM img
+		JOIN subscriptions s ON s.user_id = img.user_id AND s.status IN ('active', 'trialing', 'past_due')
+		JOIN plans pl ON pl.price_id = s.price_id
+		ORDER BY s.updated_at DESC
+		LIMIT 1
+	),
+	measured AS (
+		SELECT img.id, img.user_id, img.queued_at, img.ready_at,
+		       COALESCE(plan.code, '') AS plan_code,
+		       COALESCE(plan.sla_credit, false) AS sla_credit,
+		       (EXTRACT(EPOCH FROM img.ready_at - img.queued_at) * 1000)::bigint AS turnaround_ms,
+		       plan.sla_target_seconds::bigint * 1000 AS target_ms
+		FROM img
+		LEFT JOIN plan ON true
+	),
+	recorded AS (
+		INSERT INTO image_turnarounds (image_id, user_id, plan_code, queued_at, ready_at, turnaround_ms, target_ms, met)
+		SELECT id, user_id, plan_code, queued_at, ready_at, turnaround_ms, target_ms, turnaround_ms <= target_ms
+		FROM measured
+		ON CONFLICT (image_id) DO NOTHING
+		RETURNING image_id, user_id, plan_code, turnaround_ms, target_ms, met
+	),
+	credited AS (
+		INSERT INTO sla_credits (user_id, image_id, plan_code, turnaround_ms, target_ms)
+		SELECT r.user_id, r.image_id, r.plan_code, r.turnaround_ms, r.target_ms
+		FROM recorded r
+		JOIN measured m ON m.id = r.image_id
+		WHERE r.met = false AND m.sla_credit
+		ON CONFLICT (image_id) DO NOTHING
+		RETURNING image_id
+	)
+	SELECT r.plan_code, r.turnaround_ms, r.target_ms, r.met, EXISTS (SELECT 1 FROM credited)
+	FROM recorded r;
+`
+
+// Record measures the ready image. Retries are safe: an image is measured and credited
+// at most once.
+func (r *SQLRecorder) Record(ctx context.Context, imageID string) (Result, error) {
+	var res Result
+	err := dbretry.Do(ctx, "record turnaround", func(ctx context.Context) error {
+		var turnaroundMs int64
+		var targetMs sql.NullInt64
+		var met sql.NullBool
+		err := r.db.QueryRowContext(ctx, recordQuery, imageID).
+			Scan(&res.Plan, &turnaroundMs, &targetMs, &met, &res.Credited)
+		if errors.Is(err, sql.ErrNoRows) {
+			res = Result{}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		res.Recorded = true
+		res.Turnaround = time.Duration(turnaroundMs) * time.Millisecond
+		res.Target = time.Duration(targetMs.Int64) * time.Millisecond
+		res.Met = met.Valid && met.Bool
+		return nil
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("record turnaround: %w", err)
+	}
+	return res, nil
+}
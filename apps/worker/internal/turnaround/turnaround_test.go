@@ -0,0 +1,88 @@
+package turnaround
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/worker/internal/dbretry"
+)
+
+func TestSQLRecorder_Record(t *testing.T) {
+	prev := dbretry.DefaultPolicy
+	dbretry.DefaultPolicy = dbretry.Policy{Attempts: 3}
+	t.Cleanup(func() { dbretry.DefaultPolicy = prev })
+
+	columns := []string{"plan_code", "turnaround_ms", "target_ms", "met", "credited"}
+	testCases := []struct {
+		name     string
+		queryErr []error
+		rows     *sqlmock.Rows
+		want     Result
+		wantErr  string
+	}{
+		{
+			name: "success: target met",
+			rows: sqlmock.NewRows(columns).AddRow("pro", int64(90_000), int64(600_000), true, false),
+			want: Result{Recorded: true, Plan: "pro", Turnaround: 90 * time.Second, Target: 10 * time.Minute, Met: true},
+		},
+		{
+			name: "success: missed target is credited",
+			rows: sqlmock.NewRows(columns).AddRow("pro", int64(720_000), int64(600_000), false, true),
+			want: Result{
+				Recorded: true, Plan: "pro", Turnaround: 12 * time.Minute, Target: 10 * time.Minute, Credited: true,
+			},
+		},
+		{
+			name: "success: no plan has no target",
+			rows: sqlmock.NewRows(columns).AddRow("", int64(30_000), nil, nil, false),
+			want: Result{Recorded: true, Turnaround: 30 * time.Second},
+		},
+		{
+			name: "success: already recorded",
+			rows: sqlmock.NewRows(columns),
+			want: Result{},
+		},
+		{
+			name:     "success: retried after a serialization failure",
+			queryErr: []error{&pq.Error{Code: "40001"}},
+			rows:     sqlmock.NewRows(columns).AddRow("pro", int64(90_000), int64(600_000), true, false),
+			want:     Result{Recorded: true, Plan: "pro", Turnaround: 90 * time.Second, Target: 10 * time.Minute, Met: true},
+		},
+		{
+			name:     "fail: query error",
+			queryErr: []error{errors.New("boom")},
+			wantErr:  "record turnaround: boom",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+
+			for _, queryErr := range tc.queryErr {
+				mock.ExpectQuery(`INSERT INTO image_turnarounds`).WithArgs("img-1").WillReturnError(queryErr)
+			}
+			if tc.rows != nil {
+				mock.ExpectQuery(`INSERT INTO image_turnarounds`).WithArgs("img-1").WillReturnRows(tc.rows)
+			}
+
+			got, err := NewSQLRecorder(db).Record(context.Background(), "img-1")
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,328 @@
+package warehouse
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xitongsys/parquet-go/writer"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/notification"
+)
+
+const (
+	parquetContentType  = "application/vnd.apache.parquet"
+	manifestContentType = "application/json"
+
+	// defaultMaxRowsPerFile is used when Config.MaxRowsPerFile is not set.
+	defaultMaxRowsPerFile = 250000
+)
+
+// Config controls where and how an Exporter writes files.
+type Config struct {
+	Prefix         string
+	MaxRowsPerFile int
+}
+
+// Exporter snapshots datasets from Postgres into a Store as Parquet.
+type Exporter struct {
+	db       *sql.DB
+	store    Store
+	cfg      Config
+	datasets []Dataset
+	log      logging.Logger
+	now      func() time.Time
+	notifier notification.Notifier
+}
+
+// NewExporter constructs an Exporter for DefaultDatasets. A nil notifier disables the
+// "export complete" notification to admins.
+func NewExporter(db *sql.DB, store Store, cfg Config, log logging.Logger, notifier notification.Notifier) *Exporter {
+	if cfg.MaxRowsPerFile <= 0 {
+		cfg.MaxRowsPerFile = defaultMaxRowsPerFile
+	}
+	return &Exporter{
+		db:       db,
+		store:    store,
+		cfg:      cfg,
+		datasets: DefaultDatasets,
+		log:      log,
+		now:      time.Now,
+		notifier: notifier,
+	}
+}
+
+// Run exports every dataset into the dt= partition for day and writes the run manifest.
+// It returns ErrAlreadyExported if the partition already has a manifest.
+func (e *Exporter) Run(ctx context.Context, day time.Time) (*Manifest, error) {
+	tracer := otel.Tracer("real-staging-worker/warehouse")
+	ctx, span := tracer.Start(ctx, "warehouse.Run")
+	defer span.End()
+
+	partition := "dt=" + day.UTC().Format(partitionLayout)
+	span.SetAttributes(attribute.String("warehouse.partition", partition))
+
+	manifestKey := path.Join(e.cfg.Prefix, "_manifests", partition, "manifest.json")
+	if _, err := e.store.Get(ctx, manifestKey); err == nil {
+		return nil, ErrAlreadyExported
+	} else if !errors.Is(err, ErrNotFound) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "check manifest failed")
+		return nil, fmt.Errorf("check manifest: %w", err)
+	}
+
+	prev, err := e.latestManifest(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "load previous manifest failed")
+		return nil, err
+	}
+
+	m := &Manifest{
+		RunID:     uuid.NewString(),
+		Partition: partition,
+		StartedAt: e.now().UTC(),
+	}
+	for _, ds := range e.datasets {
+		dm, err := e.exportDataset(ctx, ds, partition, prev.dataset(ds.Name))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "export dataset failed")
+			return nil, fmt.Errorf("export %s: %w", ds.Name, err)
+		}
+		if dm.SchemaChanges != nil {
+			e.log.Warn(ctx, "warehouse schema changed",
+				"dataset", ds.Name,
+				"schema_version", dm.SchemaVersion,
+				"added", dm.SchemaChanges.Added,
+				"removed", dm.SchemaChanges.Removed,
+				"retyped", dm.SchemaChanges.Retyped)
+		}
+		m.Datasets = append(m.Datasets, *dm)
+	}
+	m.CompletedAt = e.now().UTC()
+
+	body, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal manifest: %w", err)
+	}
+	if err := e.store.Put(ctx, manifestKey, body, manifestContentType); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "write manifest failed")
+		return nil, fmt.Errorf("write manifest: %w", err)
+	}
+	if err := e.store.Put(ctx, e.latestKey(), body, manifestContentType); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "write latest manifest failed")
+		return nil, fmt.Errorf("write latest manifest: %w", err)
+	}
+
+	// Only the run that wrote the manifest gets here, so admins hear about each day once.
+	if e.notifier != nil {
+		if err := e.notifier.ExportComplete(ctx, m.Partition, m.RunID); err != nil {
+			e.log.Warn(ctx, "warehouse export notification failed", "partition", m.Partition, "error", err)
+		}
+	}
+
+	return m, nil
+}
+
+// latestKey is a copy of the most recent manifest, used to detect schema changes.
+func (e *Exporter) latestKey() string {
+	return path.Join(e.cfg.Prefix, "_manifests", "latest.json")
+}
+
+// latestManifest loads the previous run's manifest, or nil on the first run.
+func (e *Exporter) latestManifest(ctx context.Context) (*Manifest, error) {
+	body, err := e.store.Get(ctx, e.latestKey())
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load latest manifest: %w", err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(body, &m); err != nil {
+		return nil, fmt.Errorf("decode latest manifest: %w", err)
+	}
+	return &m, nil
+}
+
+// exportDataset streams the dataset query into one or more Parquet files.
+func (e *Exporter) exportDataset(
+	ctx context.Context, ds Dataset, partition string, prev *DatasetManifest,
+) (*DatasetManifest, error) {
+	rows, err := e.db.QueryContext(ctx, ds.Query)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	colTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("column types: %w", err)
+	}
+	columns := make([]Column, len(colTypes))
+	metadata := make([]string, len(colTypes))
+	for i, ct := range colTypes {
+		columns[i] = Column{Name: ct.Name(), Type: columnTypeFor(ct.DatabaseTypeName())}
+		metadata[i] = parquetMetadata(columns[i])
+	}
+
+	dm := &DatasetManifest{Name: ds.Name, Columns: columns}
+	if prev != nil {
+		changes := diffSchema(prev.Columns, columns)
+		if !changes.Empty() {
+			dm.SchemaChanges = &changes
+		}
+		dm.SchemaVersion = nextSchemaVersion(prev, changes)
+	} else {
+		dm.SchemaVersion = nextSchemaVersion(nil, SchemaChanges{})
+	}
+	dir := path.Join(e.cfg.Prefix, ds.Name, "schema_v"+strconv.Itoa(dm.SchemaVersion), partition)
+
+	values := make([]any, len(columns))
+	scanArgs := make([]any, len(columns))
+	for i := range values {
+		scanArgs[i] = &values[i]
+	}
+
+	var (
+		buf      bytes.Buffer
+		pw       *writer.CSVWriter
+		fileRows int64
+	)
+	flush := func() error {
+		if err := pw.WriteStop(); err != nil {
+			return fmt.Errorf("finalize parquet: %w", err)
+		}
+		key := path.Join(dir, fmt.Sprintf("part-%05d.parquet", len(dm.Files)))
+		if err := e.store.Put(ctx, key, buf.Bytes(), parquetContentType); err != nil {
+			return err
+		}
+		dm.Files = append(dm.Files, File{Key: key, Rows: fileRows, Bytes: int64(buf.Len())})
+		pw = nil
+		return nil
+	}
+	open := func() error {
+		buf.Reset()
+		fileRows = 0
+		w, err := writer.NewCSVWriterFromWriter(metadata, &buf, 1)
+		if err != nil {
+			return fmt.Errorf("create parquet writer: %w", err)
+		}
+		pw = w
+		return nil
+	}
+
+	if err := open(); err != nil {
+		return nil, err
+	}
+	record := make([]any, len(columns))
+	for rows.Next() {
+		if err := rows.Scan(scanArgs...); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		for i, c := range columns {
+			v, err := toParquetValue(c.Type, values[i])
+			if err != nil {
+				return nil, fmt.Errorf("column %s: %w", c.Name, err)
+			}
+			record[i] = v
+		}
+		if err := pw.Write(record); err != nil {
+			return nil, fmt.Errorf("write row: %w", err)
+		}
+		fileRows++
+		dm.Rows++
+
+		if fileRows >= int64(e.cfg.MaxRowsPerFile) {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			if err := open(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate rows: %w", err)
+	}
+
+	// Always write the trailing file when nothing else was written so that
+	// empty datasets still publish their schema for the partition.
+	if fileRows > 0 || len(dm.Files) == 0 {
+		if err := flush(); err != nil {
+			return nil, err
+		}
+	}
+
+	return dm, nil
+}
+
+// toParquetValue converts a value scanned by database/sql into the Go type
+// parquet-go expects for the column. NULLs stay nil.
+func toParquetValue(t ColumnType, v any) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	switch t {
+	case ColumnInt64:
+		switch x := v.(type) {
+		case int64:
+			return x, nil
+		case []byte:
+			return strconv.ParseInt(string(x), 10, 64)
+		case string:
+			return strconv.ParseInt(x, 10, 64)
+		}
+	case ColumnDouble:
+		switch x := v.(type) {
+		case float64:
+			return x, nil
+		case int64:
+			return float64(x), nil
+		case []byte:
+			return strconv.ParseFloat(string(x), 64)
+		case string:
+			return strconv.ParseFloat(x, 64)
+		}
+	case ColumnBoolean:
+		if x, ok := v.(bool); ok {
+			return x, nil
+		}
+	case ColumnTimestamp:
+		if x, ok := v.(time.Time); ok {
+			return x.UnixMicro(), nil
+		}
+	case ColumnDate:
+		if x, ok := v.(time.Time); ok {
+			y, m, d := x.Date()
+			days := time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400
+			return int32(days), nil // #nosec G115 -- dates fit comfortably in int32 days since epoch
+		}
+	default:
+		switch x := v.(type) {
+		case string:
+			return x, nil
+		case []byte:
+			return string(x), nil
+		case time.Time:
+			return x.UTC().Format(time.RFC3339Nano), nil
+		default:
+			return fmt.Sprint(x), nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected %T for %s column", v, t)
+}
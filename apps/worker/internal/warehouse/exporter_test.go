@@ -0,0 +1,238 @@
+package warehouse
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/notification"
+)
+
+// memStore is an in-memory Store backed by StoreMock for call assertions.
+func memStore(initial map[string][]byte) (*StoreMock, map[string][]byte) {
+	var mu sync.Mutex
+	objects := map[string][]byte{}
+	for k, v := range initial {
+		objects[k] = v
+	}
+	return &StoreMock{
+		PutFunc: func(ctx context.Context, key string, body []byte, contentType string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			objects[key] = append([]byte(nil), body...)
+			return nil
+		},
+		GetFunc: func(ctx context.Context, key string) ([]byte, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			body, ok := objects[key]
+			if !ok {
+				return nil, ErrNotFound
+			}
+			return body, nil
+		},
+	}, objects
+}
+
+func newLogger() *logging.LoggerMock {
+	return &logging.LoggerMock{
+		InfoFunc:  func(ctx context.Context, msg string, keysAndValues ...any) {},
+		WarnFunc:  func(ctx context.Context, msg string, keysAndValues ...any) {},
+		ErrorFunc: func(ctx context.Context, msg string, keysAndValues ...any) {},
+	}
+}
+
+func imageRows(n int, withCost bool) *sqlmock.Rows {
+	cols := []*sqlmock.Column{
+		sqlmock.NewColumn("id").OfType("UUID", ""),
+		sqlmock.NewColumn("status").OfType("image_status", ""),
+		sqlmock.NewColumn("seed").OfType("INT8", int64(0)),
+		sqlmock.NewColumn("created_at").OfType("TIMESTAMPTZ", time.Time{}),
+	}
+	if withCost {
+		cols = append(cols, sqlmock.NewColumn("cost_usd").OfType("NUMERIC", ""))
+	}
+	rows := sqlmock.NewRowsWithColumnDefinition(cols...)
+	for i := 0; i < n; i++ {
+		values := []driver.Value{"2e1aa86e-0f27-4f0f-9e57-0d7b53b4d9b9", "ready", nil, time.Unix(1700000000, 0)}
+		if withCost {
+			values = append(values, []byte("0.0400"))
+		}
+		rows.AddRow(values...)
+	}
+	return rows
+}
+
+func parquetRows(t *testing.T, body []byte) int64 {
+	t.Helper()
+	pf, err := buffer.NewBufferFile(body)
+	require.NoError(t, err)
+	pr, err := reader.NewParquetReader(pf, nil, 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+	return pr.GetNumRows()
+}
+
+func TestExporter_Run(t *testing.T) {
+	day := time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success: exports datasets in parts and writes manifests", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery("FROM images").WillReturnRows(imageRows(3, true))
+		store, objects := memStore(nil)
+
+		e := NewExporter(db, store, Config{Prefix: "wh", MaxRowsPerFile: 2}, newLogger(), nil)
+		e.datasets = []Dataset{{Name: "images", Query: "SELECT * FROM images"}}
+
+		m, err := e.Run(context.Background(), day)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+
+		assert.Equal(t, "dt=2025-03-14", m.Partition)
+		require.Len(t, m.Datasets, 1)
+		ds := m.Datasets[0]
+		assert.Equal(t, 1, ds.SchemaVersion)
+		assert.Nil(t, ds.SchemaChanges)
+		assert.Equal(t, int64(3), ds.Rows)
+		assert.Equal(t, []Column{
+			{Name: "id", Type: ColumnString},
+			{Name: "status", Type: ColumnString},
+			{Name: "seed", Type: ColumnInt64},
+			{Name: "created_at", Type: ColumnTimestamp},
+			{Name: "cost_usd", Type: ColumnDouble},
+		}, ds.Columns)
+
+		require.Len(t, ds.Files, 2)
+		assert.Equal(t, "wh/images/schema_v1/dt=2025-03-14/part-00000.parquet", ds.Files[0].Key)
+		assert.Equal(t, "wh/images/schema_v1/dt=2025-03-14/part-00001.parquet", ds.Files[1].Key)
+		assert.Equal(t, int64(2), parquetRows(t, objects[ds.Files[0].Key]))
+		assert.Equal(t, int64(1), parquetRows(t, objects[ds.Files[1].Key]))
+
+		var written Manifest
+		require.NoError(t, json.Unmarshal(objects["wh/_manifests/dt=2025-03-14/manifest.json"], &written))
+		assert.Equal(t, m.RunID, written.RunID)
+		assert.Equal(t, objects["wh/_manifests/dt=2025-03-14/manifest.json"], objects["wh/_manifests/latest.json"])
+	})
+
+	t.Run("success: admins are notified once the manifest is written", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery("FROM images").WillReturnRows(imageRows(1, false))
+		store, _ := memStore(nil)
+		notifier := &notification.NotifierMock{
+			ExportCompleteFunc: func(ctx context.Context, partition, runID string) error { return assert.AnError },
+		}
+		log := newLogger()
+
+		e := NewExporter(db, store, Config{Prefix: "wh"}, log, notifier)
+		e.datasets = []Dataset{{Name: "images", Query: "SELECT * FROM images"}}
+
+		m, err := e.Run(context.Background(), day)
+		require.NoError(t, err, "a failed notification does not fail the export")
+		require.Len(t, notifier.ExportCompleteCalls(), 1)
+		assert.Equal(t, "dt=2025-03-14", notifier.ExportCompleteCalls()[0].Partition)
+		assert.Equal(t, m.RunID, notifier.ExportCompleteCalls()[0].RunID)
+		assert.Len(t, log.WarnCalls(), 1)
+	})
+
+	t.Run("success: empty dataset still writes a schema file", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery("FROM images").WillReturnRows(imageRows(0, false))
+		store, objects := memStore(nil)
+
+		e := NewExporter(db, store, Config{Prefix: "wh"}, newLogger(), nil)
+		e.datasets = []Dataset{{Name: "images", Query: "SELECT * FROM images"}}
+
+		m, err := e.Run(context.Background(), day)
+		require.NoError(t, err)
+		require.Len(t, m.Datasets[0].Files, 1)
+		assert.Equal(t, int64(0), parquetRows(t, objects[m.Datasets[0].Files[0].Key]))
+	})
+
+	t.Run("success: schema changes are recorded against the previous run", func(t *testing.T) {
+		prev := Manifest{Datasets: []DatasetManifest{{
+			Name:          "images",
+			SchemaVersion: 2,
+			Columns: []Column{
+				{Name: "id", Type: ColumnString},
+				{Name: "status", Type: ColumnString},
+				{Name: "seed", Type: ColumnString},
+				{Name: "created_at", Type: ColumnTimestamp},
+				{Name: "legacy", Type: ColumnString},
+			},
+		}}}
+		prevBody, err := json.Marshal(prev)
+		require.NoError(t, err)
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery("FROM images").WillReturnRows(imageRows(1, true))
+		store, _ := memStore(map[string][]byte{"wh/_manifests/latest.json": prevBody})
+		log := newLogger()
+
+		e := NewExporter(db, store, Config{Prefix: "wh"}, log, nil)
+		e.datasets = []Dataset{{Name: "images", Query: "SELECT * FROM images"}}
+
+		m, err := e.Run(context.Background(), day)
+		require.NoError(t, err)
+
+		ds := m.Datasets[0]
+		assert.Equal(t, 3, ds.SchemaVersion)
+		require.NotNil(t, ds.SchemaChanges)
+		assert.Equal(t, []string{"cost_usd"}, ds.SchemaChanges.Added)
+		assert.Equal(t, []string{"legacy"}, ds.SchemaChanges.Removed)
+		assert.Equal(t, []string{"seed"}, ds.SchemaChanges.Retyped)
+		assert.Equal(t, "wh/images/schema_v3/dt=2025-03-14/part-00000.parquet", ds.Files[0].Key)
+		assert.Len(t, log.WarnCalls(), 1)
+	})
+
+	t.Run("fail: partition already exported", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		store, _ := memStore(map[string][]byte{"wh/_manifests/dt=2025-03-14/manifest.json": []byte(`{}`)})
+
+		e := NewExporter(db, store, Config{Prefix: "wh"}, newLogger(), nil)
+		_, err = e.Run(context.Background(), day)
+		assert.ErrorIs(t, err, ErrAlreadyExported)
+		assert.Empty(t, store.PutCalls())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail: query error leaves no manifest", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery("FROM images").WillReturnError(assert.AnError)
+		store, objects := memStore(nil)
+
+		e := NewExporter(db, store, Config{Prefix: "wh"}, newLogger(), nil)
+		e.datasets = []Dataset{{Name: "images", Query: "SELECT * FROM images"}}
+
+		_, err = e.Run(context.Background(), day)
+		assert.ErrorContains(t, err, "export images")
+		assert.NotContains(t, objects, "wh/_manifests/dt=2025-03-14/manifest.json")
+	})
+}
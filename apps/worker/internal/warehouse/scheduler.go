@@ -0,0 +1,27 @@
+package warehouse
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/real-staging-ai/worker/internal/schedule"
+)
+
+// RunNightly blocks until ctx is done, exporting the previous UTC day each
+// night at hourUTC. Runs are idempotent per partition, so several workers
+// running the schedule at once only produce one manifest per day.
+func (e *Exporter) RunNightly(ctx context.Context, hourUTC int) {
+	schedule.Daily(ctx, hourUTC, func(ctx context.Context, at time.Time) {
+		day := at.AddDate(0, 0, -1)
+		m, err := e.Run(ctx, day)
+		switch {
+		case errors.Is(err, ErrAlreadyExported):
+			e.log.Info(ctx, "Warehouse partition already exported", "day", day.Format(partitionLayout))
+		case err != nil:
+			e.log.Error(ctx, "Warehouse export failed", "day", day.Format(partitionLayout), "error", err)
+		default:
+			e.log.Info(ctx, "Warehouse export completed", "partition", m.Partition, "run_id", m.RunID)
+		}
+	})
+}
@@ -0,0 +1,19 @@
+package warehouse
+
+import (
+	"context"
+	"errors"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out store_mock.go . Store
+
+// ErrNotFound is returned by Store.Get when the key does not exist.
+var ErrNotFound = errors.New("object not found")
+
+// Store persists exported files and manifests.
+type Store interface {
+	// Put writes body to key, replacing any existing object.
+	Put(ctx context.Context, key string, body []byte, contentType string) error
+	// Get reads the object at key, returning ErrNotFound when it does not exist.
+	Get(ctx context.Context, key string) ([]byte, error)
+}
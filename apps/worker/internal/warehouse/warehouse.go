@@ -0,0 +1,199 @@
+// Package warehouse exports operational tables as partitioned Parquet files
+// for ingestion into Athena/BigQuery.
+//
+// Each run writes one snapshot per dataset under
+//
+//	<prefix>/<dataset>/schema_v<N>/dt=<YYYY-MM-DD>/part-00000.parquet
+//
+// followed by a manifest at <prefix>/_manifests/dt=<YYYY-MM-DD>/manifest.json.
+// The manifest is written last, so its presence marks a complete run.
+package warehouse
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrAlreadyExported is returned by Run when the partition already has a manifest.
+var ErrAlreadyExported = errors.New("warehouse partition already exported")
+
+// partitionLayout formats the dt= partition value.
+const partitionLayout = "2006-01-02"
+
+// Dataset is a named query whose result set is exported as one table.
+//
+// Queries select whole rows (SELECT *) so that columns added by migrations
+// flow into the warehouse without code changes.
+type Dataset struct {
+	Name  string
+	Query string
+}
+
+// DefaultDatasets are exported on every run.
+var DefaultDatasets = []Dataset{
+	{Name: "images", Query: `SELECT * FROM images ORDER BY created_at, id`},
+	{Name: "jobs", Query: `SELECT * FROM jobs ORDER BY created_at, id`},
+	{Name: "jobs_history", Query: `SELECT * FROM jobs_history ORDER BY created_at, id`},
+	{Name: "subscriptions", Query: `SELECT * FROM subscriptions ORDER BY created_at, id`},
+	{Name: "usage", Query: `
+		SELECT p.user_id,
+		       (i.created_at AT TIME ZONE 'UTC')::date AS usage_date,
+		       COUNT(*) AS images,
+		       COUNT(*) FILTER (WHERE i.status = 'ready') AS images_ready,
+		       COUNT(*) FILTER (WHERE i.status = 'error') AS images_failed,
+		       COALESCE(SUM(i.cost_usd), 0)::float8 AS cost_usd
+		FROM images i
+		JOIN projects p ON p.id = i.project_id
+		GROUP BY p.user_id, usage_date
+		ORDER BY usage_date, p.user_id`},
+}
+
+// ColumnType is the warehouse type of an exported column.
+type ColumnType string
+
+const (
+	ColumnString    ColumnType = "STRING"
+	ColumnInt64     ColumnType = "INT64"
+	ColumnDouble    ColumnType = "DOUBLE"
+	ColumnBoolean   ColumnType = "BOOLEAN"
+	ColumnTimestamp ColumnType = "TIMESTAMP_MICROS"
+	ColumnDate      ColumnType = "DATE"
+)
+
+// Column describes one exported column.
+type Column struct {
+	Name string     `json:"name"`
+	Type ColumnType `json:"type"`
+}
+
+// SchemaChanges lists differences from the previous run's schema for a dataset.
+type SchemaChanges struct {
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	Retyped []string `json:"retyped,omitempty"`
+}
+
+// Empty reports whether the schema is unchanged.
+func (c SchemaChanges) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Retyped) == 0
+}
+
+// File is a single Parquet object written during a run.
+type File struct {
+	Key   string `json:"key"`
+	Rows  int64  `json:"rows"`
+	Bytes int64  `json:"bytes"`
+}
+
+// DatasetManifest describes the files written for one dataset.
+type DatasetManifest struct {
+	Name          string         `json:"name"`
+	SchemaVersion int            `json:"schema_version"`
+	Columns       []Column       `json:"columns"`
+	SchemaChanges *SchemaChanges `json:"schema_changes,omitempty"`
+	Rows          int64          `json:"rows"`
+	Files         []File         `json:"files"`
+}
+
+// Manifest describes a complete export run.
+type Manifest struct {
+	RunID       string            `json:"run_id"`
+	Partition   string            `json:"partition"`
+	StartedAt   time.Time         `json:"started_at"`
+	CompletedAt time.Time         `json:"completed_at"`
+	Datasets    []DatasetManifest `json:"datasets"`
+}
+
+// dataset returns the manifest entry for name, or nil.
+func (m *Manifest) dataset(name string) *DatasetManifest {
+	if m == nil {
+		return nil
+	}
+	for i := range m.Datasets {
+		if m.Datasets[i].Name == name {
+			return &m.Datasets[i]
+		}
+	}
+	return nil
+}
+
+// columnTypeFor maps a Postgres type name (as reported by database/sql) to a warehouse type.
+// Anything without a direct Parquet equivalent (UUID, TEXT, JSONB, enums) is exported as a string.
+func columnTypeFor(dbType string) ColumnType {
+	switch dbType {
+	case "INT2", "INT4", "INT8":
+		return ColumnInt64
+	case "FLOAT4", "FLOAT8", "NUMERIC":
+		return ColumnDouble
+	case "BOOL":
+		return ColumnBoolean
+	case "TIMESTAMP", "TIMESTAMPTZ":
+		return ColumnTimestamp
+	case "DATE":
+		return ColumnDate
+	default:
+		return ColumnString
+	}
+}
+
+// parquetMetadata returns the parquet-go CSV writer metadata for a column.
+// Every column is OPTIONAL so that added columns read as NULL in older partitions.
+func parquetMetadata(c Column) string {
+	switch c.Type {
+	case ColumnInt64:
+		return fmt.Sprintf("name=%s, type=INT64, repetitiontype=OPTIONAL", c.Name)
+	case ColumnDouble:
+		return fmt.Sprintf("name=%s, type=DOUBLE, repetitiontype=OPTIONAL", c.Name)
+	case ColumnBoolean:
+		return fmt.Sprintf("name=%s, type=BOOLEAN, repetitiontype=OPTIONAL", c.Name)
+	case ColumnTimestamp:
+		return fmt.Sprintf("name=%s, type=INT64, convertedtype=TIMESTAMP_MICROS, repetitiontype=OPTIONAL", c.Name)
+	case ColumnDate:
+		return fmt.Sprintf("name=%s, type=INT32, convertedtype=DATE, repetitiontype=OPTIONAL", c.Name)
+	default:
+		return fmt.Sprintf("name=%s, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL", c.Name)
+	}
+}
+
+// diffSchema compares the current columns against the previous run's columns.
+func diffSchema(prev, cur []Column) SchemaChanges {
+	var changes SchemaChanges
+	prevTypes := make(map[string]ColumnType, len(prev))
+	for _, c := range prev {
+		prevTypes[c.Name] = c.Type
+	}
+	seen := make(map[string]bool, len(cur))
+	for _, c := range cur {
+		seen[c.Name] = true
+		t, ok := prevTypes[c.Name]
+		switch {
+		case !ok:
+			changes.Added = append(changes.Added, c.Name)
+		case t != c.Type:
+			changes.Retyped = append(changes.Retyped, c.Name)
+		}
+	}
+	for _, c := range prev {
+		if !seen[c.Name] {
+			changes.Removed = append(changes.Removed, c.Name)
+		}
+	}
+	return changes
+}
+
+// nextSchemaVersion decides the schema version for a dataset.
+//
+// Added and removed columns are compatible with Parquet's by-name column
+// resolution, so they keep the current version. A changed column type is
+// not, so it starts a new schema_v<N> location that can be registered as a
+// separate table.
+func nextSchemaVersion(prev *DatasetManifest, changes SchemaChanges) int {
+	if prev == nil {
+		return 1
+	}
+	if len(changes.Retyped) > 0 {
+		return prev.SchemaVersion + 1
+	}
+	return prev.SchemaVersion
+}
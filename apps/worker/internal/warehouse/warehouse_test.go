@@ -0,0 +1,53 @@
+package warehouse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToParquetValue(t *testing.T) {
+	ts := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		colType ColumnType
+		in      any
+		want    any
+		wantErr bool
+	}{
+		{name: "success: null", colType: ColumnInt64, in: nil, want: nil},
+		{name: "success: int64", colType: ColumnInt64, in: int64(7), want: int64(7)},
+		{name: "success: numeric bytes", colType: ColumnDouble, in: []byte("1.25"), want: 1.25},
+		{name: "success: bool", colType: ColumnBoolean, in: true, want: true},
+		{name: "success: timestamp", colType: ColumnTimestamp, in: ts, want: ts.UnixMicro()},
+		{name: "success: date", colType: ColumnDate, in: ts, want: int32(20090)},
+		{name: "success: uuid bytes", colType: ColumnString, in: []byte("abc"), want: "abc"},
+		{name: "success: enum string", colType: ColumnString, in: "ready", want: "ready"},
+		{name: "fail: bad numeric", colType: ColumnDouble, in: []byte("x"), wantErr: true},
+		{name: "fail: unexpected type", colType: ColumnTimestamp, in: "2025-01-02", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := toParquetValue(tc.colType, tc.in)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestNextSchemaVersion(t *testing.T) {
+	prev := &DatasetManifest{SchemaVersion: 4}
+
+	assert.Equal(t, 1, nextSchemaVersion(nil, SchemaChanges{}))
+	assert.Equal(t, 4, nextSchemaVersion(prev, SchemaChanges{}))
+	assert.Equal(t, 4, nextSchemaVersion(prev, SchemaChanges{Added: []string{"a"}, Removed: []string{"b"}}))
+	assert.Equal(t, 5, nextSchemaVersion(prev, SchemaChanges{Retyped: []string{"c"}}))
+}
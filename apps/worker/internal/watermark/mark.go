@@ -0,0 +1,54 @@
+package watermark
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// platformText is the text of the mark on exports of projects without a plan.
+const platformText = "Real Staging AI"
+
+// PlatformSettings places the platform mark across the middle of a photo, where it cannot
+// be cropped out.
+var PlatformSettings = Settings{Position: PositionCenter, Opacity: 0.45, Scale: 0.5}
+
+var platformMark = sync.OnceValues(renderPlatformMark)
+
+// PlatformMark returns the platform mark as a PNG, to draw with Render and
+// PlatformSettings.
+func PlatformMark() ([]byte, error) {
+	return platformMark()
+}
+
+// renderPlatformMark writes platformText in white on a dark outline, so the mark reads on
+// light and dark photos alike.
+func renderPlatformMark() ([]byte, error) {
+	face := basicfont.Face7x13
+	width := font.MeasureString(face, platformText).Ceil() + 2
+	height := face.Metrics().Height.Ceil() + 2
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	d := &font.Drawer{Dst: img, Face: face}
+	baseline := face.Metrics().Ascent.Ceil() + 1
+	d.Src = image.NewUniform(color.RGBA{A: 0xff})
+	for _, off := range []image.Point{{0, 1}, {2, 1}, {1, 0}, {1, 2}} {
+		d.Dot = fixed.P(off.X, baseline+off.Y-1)
+		d.DrawString(platformText)
+	}
+	d.Src = image.White
+	d.Dot = fixed.P(1, baseline)
+	d.DrawString(platformText)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
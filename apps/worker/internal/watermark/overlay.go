@@ -0,0 +1,79 @@
+package watermark
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png" // register decoder for logos and model outputs
+	"math"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // register decoder for logos and model outputs
+)
+
+const (
+	jpegQuality = 92
+
+	// marginScale sets the gap between the logo and the image's edge relative to the
+	// image's shorter edge.
+	marginScale = 1.0 / 40
+)
+
+// Render draws logo onto img as described by s and returns the result as a JPEG.
+func Render(img, logo []byte, s Settings) ([]byte, error) {
+	src, _, err := image.Decode(bytes.NewReader(img))
+	if err != nil {
+		return nil, fmt.Errorf("decode image: %w", err)
+	}
+	mark, _, err := image.Decode(bytes.NewReader(logo))
+	if err != nil {
+		return nil, fmt.Errorf("decode logo: %w", err)
+	}
+	b := src.Bounds()
+	dst := image.NewRGBA(b)
+	draw.Draw(dst, b, src, b.Min, draw.Src)
+
+	mb := mark.Bounds()
+	if mb.Empty() {
+		return nil, fmt.Errorf("decode logo: empty image")
+	}
+	width := max(int(math.Round(float64(b.Dx())*s.Scale)), 1)
+	height := max(int(math.Round(float64(width)*float64(mb.Dy())/float64(mb.Dx()))), 1)
+	margin := int(float64(min(b.Dx(), b.Dy())) * marginScale)
+
+	scaled := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(scaled, scaled.Bounds(), mark, mb, draw.Src, nil)
+
+	r := place(b, scaled.Bounds().Size(), s.Position, margin)
+	alpha := uint8(math.Round(math.Min(math.Max(s.Opacity, 0), 1) * 255))
+	draw.DrawMask(dst, r, scaled, image.Point{}, image.NewUniform(color.Alpha{A: alpha}), image.Point{}, draw.Over)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, fmt.Errorf("encode jpeg: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// place positions a logo of the given size inside bounds.
+func place(bounds image.Rectangle, size image.Point, position string, margin int) image.Rectangle {
+	x := bounds.Max.X - margin - size.X
+	y := bounds.Max.Y - margin - size.Y
+
+	switch position {
+	case PositionTopLeft, PositionBottomLeft:
+		x = bounds.Min.X + margin
+	case PositionTopCenter, PositionBottomCenter, PositionCenter:
+		x = bounds.Min.X + (bounds.Dx()-size.X)/2
+	}
+	switch position {
+	case PositionTopLeft, PositionTopCenter, PositionTopRight:
+		y = bounds.Min.Y + margin
+	case PositionCenter:
+		y = bounds.Min.Y + (bounds.Dy()-size.Y)/2
+	}
+
+	return image.Rectangle{Min: image.Pt(x, y), Max: image.Pt(x+size.X, y+size.Y)}.Intersect(bounds)
+}
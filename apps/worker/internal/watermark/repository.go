@@ -0,0 +1,50 @@
+package watermark
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository looks up the watermark of an image's account.
+type Repository interface {
+	// ForImage returns the watermark of the account that owns the image's project, or
+	// nil when the account has no watermark enabled.
+	ForImage(ctx context.Context, imageID string) (*Settings, error)
+}
+
+// SQLRepository reads account_watermarks with database/sql.
+type SQLRepository struct {
+	db *sql.DB
+}
+
+// Ensure SQLRepository implements Repository.
+var _ Repository = (*SQLRepository)(nil)
+
+// NewSQLRepository creates a SQLRepository.
+func NewSQLRepository(db *sql.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+// ForImage returns the enabled watermark of the image's account.
+func (r *SQLRepository) ForImage(ctx context.Context, imageID string) (*Settings, error) {
+	const q = `
+		SELECT w.logo_url, w.position, w.opacity, w.scale
+		FROM images i
+		JOIN projects p ON p.id = i.project_id
+		JOIN account_watermarks w ON w.user_id = p.user_id
+		WHERE i.id = $1::uuid AND w.enabled
+	`
+	var s Settings
+	err := r.db.QueryRowContext(ctx, q, imageID).Scan(&s.LogoURL, &s.Position, &s.Opacity, &s.Scale)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get account watermark: %w", err)
+	}
+	return &s, nil
+}
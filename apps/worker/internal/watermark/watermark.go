@@ -0,0 +1,26 @@
+// Package watermark renders an account's logo onto a copy of its staged photos. The
+// copy is what share links and staged downloads serve; the staged output itself is left
+// clean.
+package watermark
+
+// Logo positions, mirroring the API's account watermark settings.
+const (
+	PositionTopLeft      = "top_left"
+	PositionTopCenter    = "top_center"
+	PositionTopRight     = "top_right"
+	PositionCenter       = "center"
+	PositionBottomLeft   = "bottom_left"
+	PositionBottomCenter = "bottom_center"
+	PositionBottomRight  = "bottom_right"
+)
+
+// Settings describes the watermark of one account.
+type Settings struct {
+	// LogoURL is the stored URL of the logo upload.
+	LogoURL  string
+	Position string
+	// Opacity of the logo from 0 to 1.
+	Opacity float64
+	// Scale is the logo's width as a fraction of the image's.
+	Scale float64
+}
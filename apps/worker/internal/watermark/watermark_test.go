@@ -0,0 +1,201 @@
+package watermark
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlace(t *testing.T) {
+	bounds := image.Rect(0, 0, 400, 300)
+	size := image.Pt(100, 20)
+
+	testCases := []struct {
+		position string
+		want     image.Rectangle
+	}{
+		{position: PositionTopLeft, want: image.Rect(10, 10, 110, 30)},
+		{position: PositionTopCenter, want: image.Rect(150, 10, 250, 30)},
+		{position: PositionTopRight, want: image.Rect(290, 10, 390, 30)},
+		{position: PositionCenter, want: image.Rect(150, 140, 250, 160)},
+		{position: PositionBottomLeft, want: image.Rect(10, 270, 110, 290)},
+		{position: PositionBottomCenter, want: image.Rect(150, 270, 250, 290)},
+		{position: PositionBottomRight, want: image.Rect(290, 270, 390, 290)},
+		{position: "", want: image.Rect(290, 270, 390, 290)},
+	}
+
+	for _, tc := range testCases {
+		t.Run("success: "+tc.position, func(t *testing.T) {
+			assert.Equal(t, tc.want, place(bounds, size, tc.position, 10))
+		})
+	}
+}
+
+func solidPNG(t *testing.T, w, h int, c color.Color) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := range w {
+		for y := range h {
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// changed reports whether any pixel in r differs noticeably from mid-gray.
+func changed(img image.Image, r image.Rectangle) bool {
+	for x := r.Min.X; x < r.Max.X; x++ {
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			c := color.GrayModel.Convert(img.At(x, y)).(color.Gray)
+			if c.Y < 110 || c.Y > 146 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestRender(t *testing.T) {
+	src := solidPNG(t, 600, 400, color.RGBA{R: 128, G: 128, B: 128, A: 255})
+	logo := solidPNG(t, 200, 100, color.White)
+	topLeft := image.Rect(0, 0, 200, 100)
+	center := image.Rect(250, 170, 350, 230)
+	bottomRight := image.Rect(400, 300, 600, 400)
+
+	testCases := []struct {
+		name        string
+		img         []byte
+		logo        []byte
+		settings    Settings
+		wantChanged image.Rectangle
+		wantSame    image.Rectangle
+		wantErr     bool
+	}{
+		{
+			name:        "success: bottom right",
+			img:         src,
+			logo:        logo,
+			settings:    Settings{Position: PositionBottomRight, Opacity: 0.8, Scale: 0.2},
+			wantChanged: bottomRight,
+			wantSame:    topLeft,
+		},
+		{
+			name:        "success: center",
+			img:         src,
+			logo:        logo,
+			settings:    Settings{Position: PositionCenter, Opacity: 1, Scale: 0.3},
+			wantChanged: center,
+			wantSame:    bottomRight,
+		},
+		{
+			name:    "fail: not an image",
+			img:     []byte("nope"),
+			logo:    logo,
+			wantErr: true,
+		},
+		{
+			name:    "fail: logo not an image",
+			img:     src,
+			logo:    []byte("nope"),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := Render(tc.img, tc.logo, tc.settings)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			decoded, format, err := image.Decode(bytes.NewReader(out))
+			require.NoError(t, err)
+			assert.Equal(t, "jpeg", format)
+			assert.Equal(t, image.Rect(0, 0, 600, 400), decoded.Bounds())
+			assert.True(t, changed(decoded, tc.wantChanged), "logo not drawn")
+			assert.False(t, changed(decoded, tc.wantSame), "unexpected change outside logo")
+		})
+	}
+}
+
+func TestPlatformMark(t *testing.T) {
+	mark, err := PlatformMark()
+	require.NoError(t, err)
+	decoded, format, err := image.Decode(bytes.NewReader(mark))
+	require.NoError(t, err)
+	assert.Equal(t, "png", format)
+	assert.Greater(t, decoded.Bounds().Dx(), decoded.Bounds().Dy())
+
+	src := solidPNG(t, 600, 400, color.RGBA{R: 128, G: 128, B: 128, A: 255})
+	out, err := Render(src, mark, PlatformSettings)
+	require.NoError(t, err)
+	marked, _, err := image.Decode(bytes.NewReader(out))
+	require.NoError(t, err)
+	assert.True(t, changed(marked, image.Rect(150, 180, 450, 220)), "mark not drawn across the middle")
+	assert.False(t, changed(marked, image.Rect(0, 0, 100, 100)), "unexpected change outside the mark")
+}
+
+func TestSQLRepository_ForImage(t *testing.T) {
+	imageID := "3f2a5d1e-0000-0000-0000-000000000001"
+
+	testCases := []struct {
+		name    string
+		setup   func(mock sqlmock.Sqlmock)
+		want    *Settings
+		wantErr bool
+	}{
+		{
+			name: "success: enabled watermark",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`JOIN account_watermarks w ON w.user_id = p.user_id`).WithArgs(imageID).
+					WillReturnRows(sqlmock.NewRows([]string{"logo_url", "position", "opacity", "scale"}).
+						AddRow("s3://bucket/uploads/u/logo.png", PositionCenter, 0.5, 0.25))
+			},
+			want: &Settings{LogoURL: "s3://bucket/uploads/u/logo.png", Position: PositionCenter, Opacity: 0.5, Scale: 0.25},
+		},
+		{
+			name: "success: no watermark",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM images i`).WithArgs(imageID).WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name: "fail: query error",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM images i`).WithArgs(imageID).WillReturnError(errors.New("db down"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+			tc.setup(mock)
+
+			got, err := NewSQLRepository(db).ForImage(context.Background(), imageID)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -3,23 +3,53 @@ package main
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
+	redis "github.com/redis/go-redis/v9"
 
+	"github.com/real-staging-ai/worker/internal/byobucket"
+	"github.com/real-staging-ai/worker/internal/cancellation"
+	"github.com/real-staging-ai/worker/internal/chaos"
+	"github.com/real-staging-ai/worker/internal/checkpoint"
+	"github.com/real-staging-ai/worker/internal/compaction"
 	"github.com/real-staging-ai/worker/internal/config"
+	"github.com/real-staging-ai/worker/internal/costguard"
+	"github.com/real-staging-ai/worker/internal/disclosure"
+	"github.com/real-staging-ai/worker/internal/edit"
+	"github.com/real-staging-ai/worker/internal/ensemble"
 	"github.com/real-staging-ai/worker/internal/events"
+	"github.com/real-staging-ai/worker/internal/fairshare"
+	"github.com/real-staging-ai/worker/internal/jobarchive"
+	"github.com/real-staging-ai/worker/internal/joblog"
+	"github.com/real-staging-ai/worker/internal/keepwarm"
 	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/mailer"
+	"github.com/real-staging-ai/worker/internal/notification"
+	"github.com/real-staging-ai/worker/internal/partition"
+	"github.com/real-staging-ai/worker/internal/pendingbilling"
 	"github.com/real-staging-ai/worker/internal/processor"
+	"github.com/real-staging-ai/worker/internal/projectexport"
+	"github.com/real-staging-ai/worker/internal/provenance"
 	"github.com/real-staging-ai/worker/internal/queue"
 	"github.com/real-staging-ai/worker/internal/repository"
+	"github.com/real-staging-ai/worker/internal/retention"
+	"github.com/real-staging-ai/worker/internal/roomgroup"
+	"github.com/real-staging-ai/worker/internal/sandboxreset"
 	"github.com/real-staging-ai/worker/internal/staging"
 	"github.com/real-staging-ai/worker/internal/staging/model"
+	"github.com/real-staging-ai/worker/internal/teamwebhook"
 	"github.com/real-staging-ai/worker/internal/telemetry"
+	"github.com/real-staging-ai/worker/internal/throttle"
+	"github.com/real-staging-ai/worker/internal/turnaround"
+	"github.com/real-staging-ai/worker/internal/warehouse"
+	"github.com/real-staging-ai/worker/internal/watermark"
 )
 
 func main() {
@@ -34,6 +64,25 @@ func main() {
 	}
 	log.Info(ctx, fmt.Sprintf("Loaded configuration for environment: %s", cfg.App.Env))
 
+	// A sandbox tenant worker drains only the sandbox queue, and its jobs never reach the
+	// production model; cfg.DatabaseURL points it at the sandbox schema
+	if cfg.SandboxTenant.Worker {
+		cfg.Job.QueueName = cfg.SandboxTenant.QueueName
+		cfg.Job.CriticalQueueName = cfg.SandboxTenant.QueueName
+		cfg.Job.EditQueueName = cfg.SandboxTenant.QueueName
+		cfg.Sandbox.Enabled = true
+		// Scheduled maintenance is left to the production workers; here it would act on the
+		// sandbox schema, which the nightly reset takes care of
+		cfg.Compaction.Enabled = false
+		cfg.JobArchive.Enabled = false
+		cfg.Partitions.Enabled = false
+		cfg.PendingBilling.Enabled = false
+		cfg.Retention.Enabled = false
+		cfg.TeamWebhookRetry.Enabled = false
+		cfg.Warehouse.Enabled = false
+		log.Warn(ctx, "Running as the sandbox tenant worker", "queue", cfg.SandboxTenant.QueueName)
+	}
+
 	// Initialize OpenTelemetry
 	shutdown, err := telemetry.InitTracing(ctx, "real-staging-worker")
 	if err != nil {
@@ -45,13 +94,31 @@ func main() {
 		}
 	}()
 
+	// Metrics such as reclaimed storage are exported to the collector; work goes on without it
+	if shutdown, err := telemetry.InitMetrics(ctx, "real-staging-worker", cfg.OTEL.MetricsInterval); err != nil {
+		log.Warn(ctx, "metrics export disabled", "error", err)
+	} else {
+		defer func() { _ = shutdown(context.Background()) }()
+	}
+
+	// Fault injection for chaos testing; always nil outside builds tagged "chaos"
+	faults, err := chaos.FromEnv()
+	if err != nil {
+		log.Error(ctx, fmt.Sprintf("Failed to configure fault injection: %v", err))
+		return
+	}
+	if faults != nil {
+		log.Warn(ctx, "Chaos fault injection enabled", "faults", os.Getenv("CHAOS_FAULTS"))
+	}
+
 	// Initialize database connection using config
 	dsn := cfg.DatabaseURL()
-	db, err := sql.Open("postgres", dsn)
+	connector, err := pq.NewConnector(dsn)
 	if err != nil {
 		log.Error(ctx, fmt.Sprintf("Failed to open database: %v", err))
 		return
 	}
+	db := sql.OpenDB(telemetry.CountQueries(faults.Connector(connector)))
 	defer func() {
 		if err := db.Close(); err != nil {
 			log.Error(ctx, fmt.Sprintf("Failed to close database: %v", err))
@@ -64,6 +131,40 @@ func main() {
 
 	imgRepo := repository.NewImageRepository(db)
 
+	// Content Credentials mark staged outputs as AI-modified
+	var embedder provenance.Embedder
+	if cfg.Provenance.Enabled {
+		var signer *provenance.Signer
+		if cfg.Provenance.CertFile != "" && cfg.Provenance.KeyFile != "" {
+			signer, err = provenance.LoadSigner(cfg.Provenance.CertFile, cfg.Provenance.KeyFile)
+		} else {
+			log.Warn(ctx, "No provenance signing certificate configured; using an ephemeral self-signed certificate")
+			signer, err = provenance.NewEphemeralSigner(cfg.Provenance.ClaimGenerator)
+		}
+		if err != nil {
+			log.Error(ctx, fmt.Sprintf("Failed to initialize provenance signer: %v", err))
+			return
+		}
+		if embedder, err = provenance.NewC2PAEmbedder(signer, cfg.Provenance.ClaimGenerator); err != nil {
+			log.Error(ctx, fmt.Sprintf("Failed to initialize provenance embedder: %v", err))
+			return
+		}
+		log.Info(ctx, "Content Credentials enabled", "claim_generator", cfg.Provenance.ClaimGenerator)
+	}
+
+	// Adapt provider concurrency to Replicate's health, up to the job concurrency
+	var limiter *throttle.AdaptiveLimiter
+	if cfg.Provider.AdaptiveConcurrency {
+		limiter = throttle.NewAdaptiveLimiter(throttle.Config{
+			Min:              cfg.Provider.MinConcurrency,
+			Max:              cfg.Job.WorkerConcurrency,
+			LatencyThreshold: time.Duration(cfg.Provider.LatencyThresholdMs) * time.Millisecond,
+			RampUpAfter:      cfg.Provider.RampUpAfter,
+		}, log)
+		log.Info(ctx, "Adaptive provider concurrency enabled",
+			"min", cfg.Provider.MinConcurrency, "max", cfg.Job.WorkerConcurrency)
+	}
+
 	// Initialize the staging service with config
 	stagingCfg := &staging.ServiceConfig{
 		BucketName:     cfg.S3Bucket(),
@@ -74,13 +175,54 @@ func main() {
 		S3AccessKey:    cfg.S3.AccessKey,
 		S3SecretKey:    cfg.S3.SecretKey,
 		S3UsePathStyle: cfg.S3.UsePathStyle,
+		RegionBuckets:  cfg.S3.RegionBuckets,
 		AppEnv:         cfg.App.Env,
+		Provenance:     embedder,
+		Disclosures:    disclosure.NewSQLRepository(db),
+		Watermarks:     watermark.NewSQLRepository(db),
+		Faults:         faults,
+		Sandbox:        cfg.Sandbox.Enabled,
+		SandboxModelID: model.ModelID(cfg.Sandbox.Model),
+		Limiter:        limiter,
+		KeyPrefix:      sandboxKeyPrefix(cfg),
+		Raw: staging.RawConfig{
+			DecoderCommand:      cfg.Raw.DecoderCommand,
+			ArchiveStorageClass: cfg.Raw.ArchiveStorageClass,
+			ArchiveTag:          cfg.Raw.ArchiveTag,
+		},
+	}
+	if cfg.Ensemble.Enabled {
+		stagingCfg.Ensemble = staging.EnsembleConfig{
+			Enabled:                true,
+			ChallengerModelID:      model.ModelID(cfg.Ensemble.ChallengerModel),
+			ChallengerPromptSuffix: cfg.Ensemble.ChallengerPromptSuffix,
+			SampleRate:             cfg.Ensemble.SampleRate,
+			Comparisons:            ensemble.NewSQLRepository(db),
+		}
+	}
+	if cfg.CustomerBuckets.Enabled {
+		clients, err := byobucket.NewClients(ctx, byobucket.NewSQLRepository(db), cfg.CustomerBuckets)
+		if err != nil {
+			log.Error(ctx, fmt.Sprintf("Failed to initialize customer buckets: %v", err))
+			return
+		}
+		stagingCfg.CustomerBuckets = clients
+		log.Info(ctx, "Customer buckets enabled")
 	}
 	stagingService, err := staging.NewDefaultService(ctx, stagingCfg)
 	if err != nil {
 		log.Error(ctx, fmt.Sprintf("Failed to initialize staging service: %v", err))
 		return
 	}
+	log.Info(ctx, "Staging pipeline configured", "stages", strings.Join(stagingService.PipelineStages(), ","))
+
+	if cfg.Sandbox.Enabled {
+		log.Warn(ctx, "Sandbox mode enabled; jobs skip the production model", "sandbox_model", cfg.Sandbox.Model)
+	}
+	if cfg.Ensemble.Enabled {
+		log.Info(ctx, "Ensemble mode enabled",
+			"challenger_model", cfg.Ensemble.ChallengerModel, "sample_rate", cfg.Ensemble.SampleRate)
+	}
 
 	log.Info(ctx, "Starting Real Staging AI Worker...")
 	// Create context that listens for the interrupt signal from the OS
@@ -97,8 +239,51 @@ func main() {
 		pub = &events.NoopPublisher{}
 	}
 
-	// Initialize the job processor
-	proc := processor.NewImageProcessor(imgRepo, stagingService, pub)
+	// Initialize cancel signal checker (Redis) if configured
+	var canceler cancellation.Checker
+	if c, err := cancellation.NewDefaultChecker(cfg); err == nil {
+		canceler = c
+		log.Info(ctx, "Cancellation checks enabled")
+	} else {
+		log.Info(ctx, "Cancellation checks disabled (no REDIS_ADDR)")
+		canceler = &cancellation.NoopChecker{}
+	}
+
+	// Cap daily provider spend (Redis) if a ceiling is configured
+	var spend costguard.Guard
+	if cfg.Spend.DailyCeilingUSD > 0 {
+		if g, err := costguard.NewDefaultGuard(cfg); err == nil {
+			spend = g
+			log.Info(ctx, "Daily spend ceiling enabled", "ceiling_usd", cfg.Spend.DailyCeilingUSD)
+		} else {
+			log.Warn(ctx, fmt.Sprintf("Daily spend ceiling disabled: %v", err))
+		}
+	}
+
+	// In-app notifications are always recorded; with Redis they are also pushed to open streams
+	var notifyRedis *redis.Client
+	if cfg.Redis.Addr != "" {
+		notifyRedis = redis.NewClient(&redis.Options{Addr: cfg.Redis.Addr})
+	}
+	notifier := notification.NewDefaultNotifier(db, notifyRedis)
+	// Finished batches are posted to the Slack and Teams webhooks accounts register in the API
+	teamHooks := teamwebhook.NewDefaultNotifier(db)
+
+	// Project exports are zipped into the image bucket, where the API presigns their downloads
+	var exporter projectexport.Exporter
+	if store, err := projectexport.NewS3Store(ctx, cfg); err == nil {
+		exporter = projectexport.NewDefaultExporter(projectexport.NewSQLRepository(db), stagingService, store)
+	} else {
+		log.Warn(ctx, fmt.Sprintf("Project exports disabled: %v", err))
+	}
+
+	// Initialize the job processor; transient Replicate and S3 errors are retried in process
+	proc := processor.NewImageProcessor(
+		imgRepo, stagingService, pub, canceler, checkpoint.NewSQLRepository(db), spend, notifier, teamHooks,
+		joblog.NewSQLRepository(db), turnaround.NewSQLRecorder(db), edit.NewSQLRepository(db),
+		roomgroup.NewDefaultAnalyzer(roomgroup.NewSQLRepository(db), stagingService),
+		exporter,
+		processor.RetryPoliciesFromConfig(cfg.Job))
 
 	// Initialize the queue client (Redis/asynq in production)
 	var queueClient queue.QueueClient
@@ -107,7 +292,11 @@ func main() {
 	queueName := cfg.Job.QueueName
 	concurrency := cfg.Job.WorkerConcurrency
 	log.Info(ctx, "Queue configuration", "redis_addr", redisAddr, "queue", queueName, "concurrency", concurrency)
-	if qc, err := queue.NewAsynqQueueClient(cfg); err == nil {
+	// Fair share runs jobs round-robin across the users who own them, and failed attempts
+	// are kept in the dead letter table for admins to requeue
+	owners := fairshare.NewDefaultOwnerResolver(db)
+	deadLetters := queue.NewSQLDeadLetterStore(db)
+	if qc, err := queue.NewAsynqQueueClient(cfg, queue.WithOwners(owners), queue.WithDeadLetters(deadLetters)); err == nil {
 		queueClient = qc
 		log.Info(ctx, "Using Asynq queue backend")
 	} else {
@@ -115,7 +304,163 @@ func main() {
 		log.Info(ctx, "Using mock queue backend (no Redis Address configured)")
 	}
 
-	// Start processing jobs
+	// Keep monthly images and jobs partitions created ahead of time
+	if cfg.Partitions.Enabled {
+		maintainer := partition.NewMaintainer(partition.NewRepository(db), partition.Config{
+			MonthsAhead: cfg.Partitions.MonthsAhead,
+		}, log)
+		go maintainer.RunDaily(ctx, cfg.Partitions.RunHourUTC)
+		log.Info(ctx, "Partition maintenance enabled", "months_ahead", cfg.Partitions.MonthsAhead)
+	}
+
+	// Schedule the daily archive of finished jobs if enabled
+	if cfg.JobArchive.Enabled {
+		archiver := jobarchive.NewArchiver(jobarchive.NewRepository(db), jobarchive.Config{
+			AfterDays: cfg.JobArchive.AfterDays,
+			BatchSize: cfg.JobArchive.BatchSize,
+		}, log)
+		go archiver.RunDaily(ctx, cfg.JobArchive.RunHourUTC)
+		log.Info(ctx, "Job archive enabled", "after_days", cfg.JobArchive.AfterDays)
+	}
+
+	// Retry Stripe updates parked until their customer is linked to a user
+	if cfg.PendingBilling.Enabled {
+		// Applied subscriptions invalidate the API's cached entitlements when Redis is configured
+		var invalidator pendingbilling.Invalidator
+		if cfg.Redis.Addr != "" {
+			invalidator = pendingbilling.NewRedisInvalidator(redis.NewClient(&redis.Options{Addr: cfg.Redis.Addr}))
+		}
+		retrier := pendingbilling.NewRetrier(pendingbilling.NewRepository(db), invalidator, pendingbilling.Config{
+			Interval:  cfg.PendingBilling.Interval,
+			BatchSize: cfg.PendingBilling.BatchSize,
+		}, log)
+		go retrier.RunEvery(ctx)
+		log.Info(ctx, "Pending billing retry enabled", "interval", cfg.PendingBilling.Interval.String())
+	}
+
+	if cfg.TeamWebhookRetry.Enabled {
+		retrier := teamwebhook.NewRetrier(teamHooks, teamwebhook.Config{
+			Interval:  cfg.TeamWebhookRetry.Interval,
+			BatchSize: cfg.TeamWebhookRetry.BatchSize,
+		}, log)
+		go retrier.RunEvery(ctx)
+		log.Info(ctx, "Team webhook delivery retry enabled", "interval", cfg.TeamWebhookRetry.Interval.String())
+	}
+
+	// Keep the production model warm through quiet periods; sandbox jobs never run on it
+	if cfg.KeepWarm.Enabled && !cfg.Sandbox.Enabled {
+		warmer := keepwarm.NewWarmer(stagingService, spend, keepwarm.Config{
+			Interval:       cfg.KeepWarm.Interval,
+			StartHourUTC:   cfg.KeepWarm.StartHourUTC,
+			EndHourUTC:     cfg.KeepWarm.EndHourUTC,
+			DailyBudgetUSD: cfg.KeepWarm.DailyBudgetUSD,
+		}, log)
+		go warmer.RunEvery(ctx)
+		log.Info(ctx, "Model keep-warm enabled", "interval", cfg.KeepWarm.Interval.String(),
+			"daily_budget_usd", cfg.KeepWarm.DailyBudgetUSD)
+	}
+
+	// Schedule the nightly data warehouse export if enabled
+	if cfg.Warehouse.Enabled {
+		if store, err := warehouse.NewS3Store(ctx, cfg); err == nil {
+			exporter := warehouse.NewExporter(db, store, warehouse.Config{
+				Prefix:         cfg.Warehouse.Prefix,
+				MaxRowsPerFile: cfg.Warehouse.MaxRowsPerFile,
+			}, log, notifier)
+			go exporter.RunNightly(ctx, cfg.Warehouse.RunHourUTC)
+			log.Info(ctx, "Warehouse export enabled", "bucket", cfg.WarehouseBucket(), "prefix", cfg.Warehouse.Prefix)
+		} else {
+			log.Error(ctx, fmt.Sprintf("Failed to initialize warehouse export: %v", err))
+		}
+	}
+
+	// Schedule the daily retention purge of original uploads if enabled
+	if cfg.Retention.Enabled {
+		if deleter, err := retention.NewS3Deleter(ctx, cfg); err == nil {
+			var mail mailer.Mailer = mailer.NewLogMailer(log)
+			if cfg.SMTP.Host != "" {
+				if smtpMailer, err := mailer.NewSMTPMailer(cfg.SMTP); err == nil {
+					mail = smtpMailer
+				} else {
+					log.Error(ctx, fmt.Sprintf("Failed to initialize SMTP mailer, logging emails instead: %v", err))
+				}
+			}
+			purger := retention.NewPurger(retention.NewRepository(db), deleter, mail, retention.Config{
+				WarningDays: cfg.Retention.WarningDays,
+				BatchSize:   cfg.Retention.BatchSize,
+			}, log)
+			go purger.RunDaily(ctx, cfg.Retention.RunHourUTC)
+			log.Info(ctx, "Retention purge enabled", "warning_days", cfg.Retention.WarningDays)
+		} else {
+			log.Error(ctx, fmt.Sprintf("Failed to initialize retention purge: %v", err))
+		}
+	}
+
+	// Schedule the daily cleanup of abandoned multipart uploads and temporary objects if enabled
+	if cfg.Compaction.Enabled {
+		var compactor *compaction.Compactor
+		store, err := compaction.NewS3Store(ctx, cfg)
+		if err == nil {
+			compactor, err = compaction.NewCompactor(store, compaction.NewAdvisoryLocker(db), compaction.Config{
+				Buckets:      compaction.PlatformBuckets(cfg.S3Bucket(), cfg.S3.RegionBuckets),
+				MaxAge:       cfg.Compaction.MaxAge,
+				TempPrefixes: cfg.Compaction.TempPrefixes,
+			}, log)
+		}
+		if err == nil {
+			go compactor.RunDaily(ctx, cfg.Compaction.RunHourUTC)
+			log.Info(ctx, "Storage compaction enabled", "max_age", cfg.Compaction.MaxAge.String(),
+				"temp_prefixes", strings.Join(cfg.Compaction.TempPrefixes, ","))
+		} else {
+			log.Error(ctx, fmt.Sprintf("Failed to initialize storage compaction: %v", err))
+		}
+	}
+
+	// Reset the sandbox tenant's queue, schema and objects each night
+	if cfg.SandboxTenant.Worker {
+		if store, err := compaction.NewS3Store(ctx, cfg); err == nil {
+			sandboxQueue := sandboxreset.NewAsynqQueue(cfg.Redis.Addr, cfg.SandboxTenant.QueueName)
+			defer func() { _ = sandboxQueue.Close() }()
+			resetter := sandboxreset.NewResetter(sandboxreset.NewRepository(db), sandboxQueue, store, sandboxreset.Config{
+				Buckets:   compaction.PlatformBuckets(cfg.S3Bucket(), cfg.S3.RegionBuckets),
+				KeyPrefix: sandboxKeyPrefix(cfg),
+			}, log)
+			go resetter.RunDaily(ctx, cfg.SandboxTenant.ResetHourUTC)
+			log.Info(ctx, "Sandbox reset enabled", "run_hour_utc", cfg.SandboxTenant.ResetHourUTC)
+		} else {
+			log.Error(ctx, fmt.Sprintf("Failed to initialize sandbox reset: %v", err))
+		}
+	}
+
+	// handleJob runs one job and reports its outcome back to the queue.
+	handleJob := func(job *queue.Job) {
+		log.Info(ctx, fmt.Sprintf("Processing job %s of type %s", job.ID, job.Type))
+
+		// The processor handles all DB updates and SSE events internally. Image status
+		// changes are recorded against the job's task and attempt.
+		err := proc.ProcessJob(repository.WithJob(ctx, job.TaskID, job.Attempt), job)
+		var deferred *queue.DeferredError
+		if errors.As(err, &deferred) {
+			log.Info(ctx, fmt.Sprintf("Deferred job %s until %s", job.ID, deferred.Until.Format(time.RFC3339)))
+			if deferErr := queueClient.DeferJob(ctx, job.ID, deferred.Until, deferred.Reason); deferErr != nil {
+				log.Error(ctx, fmt.Sprintf("Failed to defer job %s: %v", job.ID, deferErr))
+			}
+		} else if err != nil {
+			log.Error(ctx, fmt.Sprintf("Error processing job %s: %v", job.ID, err))
+			if markErr := queueClient.MarkJobFailed(ctx, job.ID, err.Error()); markErr != nil {
+				log.Error(ctx, fmt.Sprintf("Failed to mark job %s as failed: %v", job.ID, markErr))
+			}
+		} else {
+			log.Info(ctx, fmt.Sprintf("Successfully processed job %s", job.ID))
+			if markErr := queueClient.MarkJobCompleted(ctx, job.ID); markErr != nil {
+				log.Error(ctx, fmt.Sprintf("Failed to mark job %s as completed: %v", job.ID, markErr))
+			}
+		}
+	}
+
+	// Start processing jobs, up to the worker concurrency at a time. Predictions within
+	// those jobs are further limited by the adaptive provider concurrency when enabled.
+	slots := make(chan struct{}, max(concurrency, 1))
 	go func() {
 		log.Info(ctx, "Job polling loop started")
 		pollCount := 0
@@ -124,16 +469,18 @@ func main() {
 			case <-ctx.Done():
 				log.Info(ctx, "Shutting down worker...")
 				return
-			default:
+			case slots <- struct{}{}:
 				// Poll for jobs
 				job, err := queueClient.GetNextJob(ctx)
 				if err != nil {
+					<-slots
 					log.Error(ctx, fmt.Sprintf("Error getting next job: %v", err))
 					time.Sleep(5 * time.Second)
 					continue
 				}
 
 				if job == nil {
+					<-slots
 					// No jobs available, wait a bit
 					pollCount++
 					if pollCount%30 == 0 {
@@ -146,26 +493,28 @@ func main() {
 				// Reset counter when job is found
 				pollCount = 0
 
-				// Process the job
-				log.Info(ctx, fmt.Sprintf("Processing job %s of type %s", job.ID, job.Type))
-
-				// The processor handles all DB updates and SSE events internally
-				if err := proc.ProcessJob(ctx, job); err != nil {
-					log.Error(ctx, fmt.Sprintf("Error processing job %s: %v", job.ID, err))
-					if markErr := queueClient.MarkJobFailed(ctx, job.ID, err.Error()); markErr != nil {
-						log.Error(ctx, fmt.Sprintf("Failed to mark job %s as failed: %v", job.ID, markErr))
-					}
-				} else {
-					log.Info(ctx, fmt.Sprintf("Successfully processed job %s", job.ID))
-					if markErr := queueClient.MarkJobCompleted(ctx, job.ID); markErr != nil {
-						log.Error(ctx, fmt.Sprintf("Failed to mark job %s as completed: %v", job.ID, markErr))
-					}
-				}
+				go func() {
+					defer func() { <-slots }()
+					handleJob(job)
+				}()
 			}
 		}
 	}()
 
 	log.Info(ctx, "Worker started. Press Ctrl+C to stop.")
 	<-ctx.Done()
+	for _, stats := range stagingService.PipelineMetrics() {
+		log.Info(ctx, "Staging pipeline stage metrics", "stage", stats.Name, "phase", stats.Phase.String(),
+			"runs", stats.Runs, "failures", stats.Failures, "mean_duration", stats.MeanDuration().String())
+	}
 	log.Info(ctx, "Worker stopped.")
 }
+
+// sandboxKeyPrefix returns the key prefix of the objects the worker writes: sandbox/ for
+// the sandbox tenant worker, matching the API's storage.SandboxKeyPrefix, and none otherwise.
+func sandboxKeyPrefix(cfg *config.Config) string {
+	if cfg.SandboxTenant.Worker {
+		return "sandbox/"
+	}
+	return ""
+}
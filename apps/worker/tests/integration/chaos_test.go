@@ -0,0 +1,125 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/tests/fixtures"
+	"github.com/real-staging-ai/worker/internal/chaos"
+	"github.com/real-staging-ai/worker/internal/events"
+	"github.com/real-staging-ai/worker/internal/repository"
+)
+
+// chaosDSN builds the test database DSN from the PG* variables the Makefile sets.
+func chaosDSN() string {
+	env := func(k, def string) string {
+		if v := os.Getenv(k); v != "" {
+			return v
+		}
+		return def
+	}
+	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s",
+		env("PGUSER", "testuser"), env("PGPASSWORD", "testpassword"), env("PGHOST", "localhost"),
+		env("PGPORT", "5433"), env("PGDATABASE", "testdb"), env("PGSSLMODE", "disable"))
+}
+
+func TestChaos_PublisherRetriesRedisDrops(t *testing.T) {
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		t.Skip("REDIS_ADDR not set; integration infra must start redis-test")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	sub := redis.NewClient(&redis.Options{Addr: addr})
+	defer func() { _ = sub.Close() }()
+	imageID := uuid.NewString()
+	ps := sub.Subscribe(ctx, "jobs:image:"+imageID)
+	defer func() { _ = ps.Close() }()
+	_, err := ps.Receive(ctx)
+	require.NoError(t, err)
+
+	faults := chaos.New(map[chaos.Point]chaos.Fault{chaos.PointRedis: {ErrorRate: 1, Count: 2}})
+	rdb := redis.NewClient(&redis.Options{Addr: addr})
+	defer func() { _ = rdb.Close() }()
+	rdb.AddHook(faults.RedisHook())
+	pub := events.NewDefaultPublisherWithClient(rdb, events.Options{
+		MaxAttempts: 3, BaseDelay: 10 * time.Millisecond,
+	})
+
+	require.NoError(t, pub.PublishJobUpdate(ctx, events.JobUpdateEvent{ImageID: imageID, Status: "processing"}))
+	assert.Equal(t, 2, faults.Fired(chaos.PointRedis))
+
+	msg, err := ps.ReceiveMessage(ctx)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"status":"processing"}`, msg.Payload)
+
+	t.Run("fail: drops outlast the retry budget", func(t *testing.T) {
+		faults.Set(chaos.PointRedis, chaos.Fault{ErrorRate: 1})
+		err := pub.PublishJobUpdate(ctx, events.JobUpdateEvent{ImageID: imageID, Status: "ready"})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, chaos.ErrInjected)
+		assert.Equal(t, 3, faults.Fired(chaos.PointRedis))
+	})
+}
+
+func TestChaos_RepositorySurvivesDatabaseFailover(t *testing.T) {
+	ctx := context.Background()
+
+	connector, err := pq.NewConnector(chaosDSN())
+	require.NoError(t, err)
+	faults := chaos.New(nil)
+	db := sql.OpenDB(faults.Connector(connector))
+	defer func() { _ = db.Close() }()
+	require.NoError(t, db.PingContext(ctx))
+
+	user, err := fixtures.NewUser().
+		WithProject(fixtures.NewProject("Worker Chaos").WithImage(fixtures.NewImage())).
+		Create(ctx, fixtures.SQL(db))
+	require.NoError(t, err)
+	userID, projectID := user.ID, user.Projects[0].ID
+	imageID := user.Projects[0].Images[0].ID
+	t.Cleanup(func() {
+		faults.Clear(chaos.PointDB)
+		_, _ = db.ExecContext(ctx, `DELETE FROM images WHERE id = $1`, imageID)
+		_, _ = db.ExecContext(ctx, `DELETE FROM projects WHERE id = $1`, projectID)
+		_, _ = db.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, userID)
+	})
+
+	repo := repository.NewImageRepository(db)
+	status := func() string {
+		var s string
+		require.NoError(t, db.QueryRowContext(ctx, `SELECT status FROM images WHERE id = $1`, imageID).Scan(&s))
+		return s
+	}
+
+	t.Run("success: a dropped connection is retried on a fresh one", func(t *testing.T) {
+		faults.Set(chaos.PointDB, chaos.Fault{ErrorRate: 1, Count: 1})
+		require.NoError(t, repo.SetProcessing(ctx, imageID.String()))
+		assert.Equal(t, 1, faults.Fired(chaos.PointDB))
+		assert.Equal(t, "processing", status())
+	})
+
+	t.Run("fail: an outage surfaces and the update applies after recovery", func(t *testing.T) {
+		faults.Set(chaos.PointDB, chaos.Fault{ErrorRate: 1})
+		err := repo.SetReady(ctx, imageID.String(), "http://localhost:4566/real-staging/staged/chaos.jpg")
+		require.Error(t, err)
+		assert.ErrorIs(t, err, chaos.ErrInjected)
+
+		faults.Clear(chaos.PointDB)
+		require.NoError(t, repo.SetReady(ctx, imageID.String(), "http://localhost:4566/real-staging/staged/chaos.jpg"))
+		assert.Equal(t, "ready", status())
+	})
+}
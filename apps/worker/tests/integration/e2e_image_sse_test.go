@@ -27,6 +27,7 @@ import (
 	"github.com/real-staging-ai/api/internal/job"
 	"github.com/real-staging-ai/api/internal/storage"
 	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/tests/fixtures"
 	workerEvents "github.com/real-staging-ai/worker/internal/events"
 	workerQueue "github.com/real-staging-ai/worker/internal/queue"
 	workerRepo "github.com/real-staging-ai/worker/internal/repository"
@@ -66,7 +67,7 @@ func TestEndToEnd_ImageLifecycle_SSE_Error(t *testing.T) {
 	defer ts.Close()
 
 	reqBody := createImageReq{
-		ProjectID:   "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
+		ProjectID:   fixtures.SeedProjectID.String(),
 		OriginalURL: "http://example.com/original2.jpg",
 	}
 	b, err := json.Marshal(reqBody)
@@ -168,22 +169,8 @@ func TestEndToEnd_ImageLifecycle_SSE_Error(t *testing.T) {
 
 func truncateAndSeed(t *testing.T, pool storage.PgxPool) {
 	ctx := context.Background()
-	// Truncate all tables (same as apps/api/tests/integration/storage_fixtures.go)
-	_, err := pool.Exec(ctx, `
-		TRUNCATE TABLE processed_events, invoices, subscriptions, images, jobs, projects, users, plans RESTART IDENTITY CASCADE
-	`)
-	require.NoError(t, err)
-	// Seed minimal user+project (same values as seed.sql)
-	_, err = pool.Exec(ctx, `
-		INSERT INTO users (id, auth0_sub, stripe_customer_id, role) VALUES
-		('a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11', 'auth0|testuser', 'cus_test', 'user');
-	`)
-	require.NoError(t, err)
-	_, err = pool.Exec(ctx, `
-		INSERT INTO projects (id, user_id, name) VALUES
-		('b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12', 'a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11', 'Test Project 1');
-	`)
-	require.NoError(t, err)
+	require.NoError(t, fixtures.Truncate(ctx, fixtures.Pgx(pool)))
+	require.NoError(t, fixtures.Seed(ctx, fixtures.Pgx(pool)))
 }
 
 func openSQLDBFromEnv(t *testing.T) *sql.DB {
@@ -245,7 +232,7 @@ func TestEndToEnd_ImageLifecycle_SSE(t *testing.T) {
 
 	// Create image via API (this enqueues a stage:run task via asynq)
 	reqBody := createImageReq{
-		ProjectID:   "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
+		ProjectID:   fixtures.SeedProjectID.String(),
 		OriginalURL: "http://example.com/original.jpg",
 	}
 	b, err := json.Marshal(reqBody)
@@ -0,0 +1,143 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/docker/go-connections/nat"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/real-staging-ai/worker/internal/config"
+	"github.com/real-staging-ai/worker/internal/s3client"
+)
+
+// Container images match docker-compose.test.yml and the MinIO service of docker-compose.yml.
+const (
+	postgresImage = "postgres:17.6"
+	redisImage    = "redis:8.2"
+	minioImage    = "minio/minio:latest"
+)
+
+// migrationsDir holds the schema migrations, relative to this package.
+const migrationsDir = "../../../../infra/migrations"
+
+// harness is a throwaway Postgres, Redis and MinIO for one test, with the schema migrated
+// and the worker's bucket created. Unlike the rest of this suite it needs no
+// infrastructure from the Makefile, only a Docker daemon.
+type harness struct {
+	DB     *sql.DB
+	S3     *s3.Client
+	Config *config.Config
+}
+
+// newHarness starts the containers, which are removed when t ends. It skips t when Docker
+// is not available.
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+	ctx := context.Background()
+
+	pgAddr := startContainer(t, ctx, testcontainers.ContainerRequest{
+		Image:        postgresImage,
+		ExposedPorts: []string{"5432/tcp"},
+		Env: map[string]string{
+			"POSTGRES_USER":     "testuser",
+			"POSTGRES_PASSWORD": "testpassword",
+			"POSTGRES_DB":       "testdb",
+		},
+		// The server restarts once after initdb; only the second start takes connections.
+		WaitingFor: wait.ForLog("database system is ready to accept connections").
+			WithOccurrence(2).WithStartupTimeout(time.Minute),
+	}, "5432/tcp")
+	redisAddr := startContainer(t, ctx, testcontainers.ContainerRequest{
+		Image:        redisImage,
+		ExposedPorts: []string{"6379/tcp"},
+		WaitingFor:   wait.ForListeningPort("6379/tcp"),
+	}, "6379/tcp")
+	minioAddr := startContainer(t, ctx, testcontainers.ContainerRequest{
+		Image:        minioImage,
+		ExposedPorts: []string{"9000/tcp"},
+		Env:          map[string]string{"MINIO_ROOT_USER": "minioadmin", "MINIO_ROOT_PASSWORD": "minioadmin"},
+		Cmd:          []string{"server", "/data"},
+		WaitingFor:   wait.ForHTTP("/minio/health/live").WithPort("9000/tcp"),
+	}, "9000/tcp")
+
+	cfg := &config.Config{
+		Job:   config.Job{QueueName: "default", CriticalQueueName: "critical", WorkerConcurrency: 1},
+		Redis: config.Redis{Addr: redisAddr},
+		S3: config.S3{
+			AccessKey:    "minioadmin",
+			SecretKey:    "minioadmin",
+			BucketName:   "real-staging-it",
+			Endpoint:     "http://" + minioAddr,
+			Region:       "us-east-1",
+			UsePathStyle: true,
+		},
+	}
+
+	db, err := sql.Open("postgres",
+		fmt.Sprintf("postgres://testuser:testpassword@%s/testdb?sslmode=disable", pgAddr))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, applyMigrations(ctx, db))
+
+	s3Client, err := s3client.New(ctx, cfg)
+	require.NoError(t, err)
+	_, err = s3Client.CreateBucket(ctx, &s3.CreateBucketInput{Bucket: aws.String(cfg.S3Bucket())})
+	require.NoError(t, err)
+
+	return &harness{DB: db, S3: s3Client, Config: cfg}
+}
+
+// startContainer starts req and returns the host:port its port is reachable on.
+func startContainer(t *testing.T, ctx context.Context, req testcontainers.ContainerRequest, port nat.Port) string {
+	t.Helper()
+	ctr, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	testcontainers.CleanupContainer(t, ctr)
+	require.NoError(t, err, "start %s", req.Image)
+
+	host, err := ctr.Host(ctx)
+	require.NoError(t, err)
+	mapped, err := ctr.MappedPort(ctx, port)
+	require.NoError(t, err)
+	return fmt.Sprintf("%s:%s", host, mapped.Port())
+}
+
+// applyMigrations runs every up migration in order, as golang-migrate does for the other
+// suites.
+func applyMigrations(ctx context.Context, db *sql.DB) error {
+	files, err := filepath.Glob(filepath.Join(migrationsDir, "*.up.sql"))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no migrations found in %s", migrationsDir)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		migration, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if _, err := db.ExecContext(ctx, string(migration)); err != nil {
+			return fmt.Errorf("apply %s: %w", filepath.Base(file), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/tests/fixtures"
+	"github.com/real-staging-ai/worker/internal/events"
+	"github.com/real-staging-ai/worker/internal/processor"
+	"github.com/real-staging-ai/worker/internal/queue"
+	"github.com/real-staging-ai/worker/internal/repository"
+	"github.com/real-staging-ai/worker/internal/s3client"
+	"github.com/real-staging-ai/worker/internal/staging"
+)
+
+// pngHeader is enough of a PNG for content sniffing; the sandbox's fake provider never
+// decodes it.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x02\x00\x00\x00")
+
+// TestPipeline_StageJobSideEffects enqueues a stage:run task the way the API does and runs
+// it through the asynq queue, processor and staging pipeline on the sandbox's fake
+// provider, then checks every side effect: the staged object in S3, the image row and its
+// status history, and the events published for SSE.
+func TestPipeline_StageJobSideEffects(t *testing.T) {
+	h := newHarness(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	bucket := h.Config.S3Bucket()
+
+	imageID := uuid.New()
+	originalKey := fmt.Sprintf("uploads/%s/original.png", imageID)
+	originalURL := fmt.Sprintf("s3://%s/%s", bucket, originalKey)
+	_, err := h.S3.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(originalKey),
+		Body:   bytes.NewReader(pngHeader),
+	})
+	require.NoError(t, err)
+
+	_, err = fixtures.NewUser().
+		WithProject(fixtures.NewProject("Pipeline").
+			WithImage(fixtures.NewImage().WithID(imageID).WithOriginalURL(originalURL))).
+		Create(ctx, fixtures.SQL(h.DB))
+	require.NoError(t, err)
+
+	// Subscribe before enqueueing so no update is missed
+	rdb := redis.NewClient(&redis.Options{Addr: h.Config.Redis.Addr})
+	t.Cleanup(func() { _ = rdb.Close() })
+	sub := rdb.Subscribe(ctx, fmt.Sprintf("jobs:image:%s", imageID))
+	t.Cleanup(func() { _ = sub.Close() })
+	_, err = sub.Receive(ctx)
+	require.NoError(t, err)
+
+	stagingService, err := staging.NewDefaultService(ctx, &staging.ServiceConfig{
+		BucketName: bucket,
+		// Sandbox requests never reach Replicate, but the client still needs a token.
+		ReplicateToken: "unused-in-sandbox",
+		S3Endpoint:     h.Config.S3.Endpoint,
+		S3Region:       h.Config.S3.Region,
+		S3AccessKey:    h.Config.S3.AccessKey,
+		S3SecretKey:    h.Config.S3.SecretKey,
+		S3UsePathStyle: h.Config.S3.UsePathStyle,
+		Sandbox:        true,
+	})
+	require.NoError(t, err)
+	proc := processor.NewImageProcessor(
+		repository.NewImageRepository(h.DB), stagingService,
+		events.NewDefaultPublisherWithClient(rdb, events.Options{}),
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	qc, err := queue.NewAsynqQueueClient(h.Config)
+	require.NoError(t, err)
+	t.Cleanup(qc.Shutdown)
+	go runJobs(ctx, t, qc, proc)
+
+	payload, err := json.Marshal(processor.JobPayload{ImageID: imageID.String(), OriginalURL: originalURL})
+	require.NoError(t, err)
+	enqueuer := asynq.NewClient(asynq.RedisClientOpt{Addr: h.Config.Redis.Addr})
+	t.Cleanup(func() { _ = enqueuer.Close() })
+	taskID := uuid.NewString()
+	_, err = enqueuer.EnqueueContext(ctx, asynq.NewTask("stage:run", payload),
+		asynq.Queue(h.Config.Job.QueueName), asynq.TaskID(taskID))
+	require.NoError(t, err)
+
+	t.Run("success: events are published for processing then ready", func(t *testing.T) {
+		var statuses []string
+		for len(statuses) == 0 || statuses[len(statuses)-1] != "ready" {
+			select {
+			case msg := <-sub.Channel():
+				var ev events.JobUpdateEvent
+				require.NoError(t, json.Unmarshal([]byte(msg.Payload), &ev))
+				assert.Equal(t, imageID.String(), ev.ImageID)
+				statuses = append(statuses, ev.Status)
+			case <-ctx.Done():
+				require.FailNow(t, "timed out waiting for the ready event", "received %v", statuses)
+			}
+		}
+		assert.Equal(t, []string{"processing", "ready"}, statuses)
+	})
+
+	stagedKey := fmt.Sprintf("staged/%s/%s-staged.jpg", imageID.String()[:8], imageID)
+
+	t.Run("success: image row is ready with the staged URL", func(t *testing.T) {
+		var status, stagedURL string
+		require.NoError(t, h.DB.QueryRowContext(ctx,
+			`SELECT status, staged_url FROM images WHERE id = $1`, imageID).Scan(&status, &stagedURL))
+		assert.Equal(t, "ready", status)
+		assert.Equal(t, fmt.Sprintf("s3://%s/%s", bucket, stagedKey), stagedURL)
+	})
+
+	t.Run("success: status history names the worker and its task", func(t *testing.T) {
+		var source, jobID string
+		require.NoError(t, h.DB.QueryRowContext(ctx, `
+			SELECT source, job_id FROM image_status_transitions
+			WHERE image_id = $1 AND to_status = 'ready'`, imageID).Scan(&source, &jobID))
+		assert.Equal(t, "real-staging-worker", source)
+		assert.Equal(t, taskID, jobID)
+	})
+
+	t.Run("success: staged object is in the bucket", func(t *testing.T) {
+		key, err := s3client.KeyIn(fmt.Sprintf("s3://%s/%s", bucket, stagedKey), bucket)
+		require.NoError(t, err)
+		head, err := h.S3.HeadObject(ctx, &s3.HeadObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)})
+		require.NoError(t, err)
+		assert.Positive(t, aws.ToInt64(head.ContentLength))
+	})
+}
+
+// runJobs takes jobs from qc until ctx is done, processing each and reporting its outcome
+// the way the worker's main loop does.
+func runJobs(ctx context.Context, t *testing.T, qc queue.QueueClient, proc *processor.ImageProcessor) {
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		job, err := qc.GetNextJob(ctx)
+		if err != nil || job == nil {
+			continue
+		}
+		if err := proc.ProcessJob(repository.WithJob(ctx, job.TaskID, job.Attempt), job); err != nil {
+			t.Logf("job %s failed: %v", job.ID, err)
+			_ = qc.MarkJobFailed(ctx, job.ID, err.Error())
+			continue
+		}
+		_ = qc.MarkJobCompleted(ctx, job.ID)
+	}
+}
@@ -0,0 +1,13 @@
+-- Postgres cannot drop a single enum value, so rebuild the type without it.
+-- Canceled images are folded back into the error state.
+ALTER TABLE images ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE images ALTER COLUMN status TYPE TEXT;
+
+UPDATE images SET status = 'error', error = COALESCE(error, 'canceled') WHERE status = 'canceled';
+UPDATE jobs SET status = 'failed', error = COALESCE(error, 'canceled') WHERE status = 'canceled';
+
+DROP TYPE image_status;
+CREATE TYPE image_status AS ENUM ('queued','processing','ready','error');
+
+ALTER TABLE images ALTER COLUMN status TYPE image_status USING status::image_status;
+ALTER TABLE images ALTER COLUMN status SET DEFAULT 'queued';
@@ -0,0 +1,2 @@
+-- Allow images to be canceled by their owner before processing completes
+ALTER TYPE image_status ADD VALUE IF NOT EXISTS 'canceled';
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS trigger_images_activity ON images;
+DROP FUNCTION IF EXISTS record_image_activity();
+
+DROP TABLE IF EXISTS project_activity;
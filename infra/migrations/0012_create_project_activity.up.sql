@@ -0,0 +1,56 @@
+-- Append-only audit log backing the project activity timeline.
+-- image_id is intentionally not a foreign key so history survives image deletion.
+CREATE TABLE project_activity (
+  id BIGSERIAL PRIMARY KEY,
+  project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+  image_id UUID,
+  event_type TEXT NOT NULL,
+  metadata JSONB NOT NULL DEFAULT '{}'::jsonb,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_project_activity_project_created ON project_activity(project_id, created_at DESC, id DESC);
+
+-- Record image lifecycle events from the database so transitions written by
+-- the worker are captured without it needing to know about the audit log.
+CREATE OR REPLACE FUNCTION record_image_activity()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF TG_OP = 'INSERT' THEN
+    INSERT INTO project_activity (project_id, image_id, event_type, metadata)
+    VALUES (NEW.project_id, NEW.id, 'image.created',
+            jsonb_build_object('status', NEW.status, 'room_type', NEW.room_type, 'style', NEW.style));
+    RETURN NEW;
+  ELSIF TG_OP = 'UPDATE' THEN
+    IF NEW.status IS DISTINCT FROM OLD.status THEN
+      INSERT INTO project_activity (project_id, image_id, event_type, metadata)
+      VALUES (NEW.project_id, NEW.id, 'image.status_changed',
+              jsonb_build_object('from', OLD.status, 'to', NEW.status, 'error', NEW.error));
+    END IF;
+    RETURN NEW;
+  ELSIF TG_OP = 'DELETE' THEN
+    -- Skip when the whole project is being deleted; its activity goes with it.
+    IF EXISTS (SELECT 1 FROM projects WHERE id = OLD.project_id) THEN
+      INSERT INTO project_activity (project_id, image_id, event_type, metadata)
+      VALUES (OLD.project_id, OLD.id, 'image.deleted', jsonb_build_object('status', OLD.status));
+    END IF;
+    RETURN OLD;
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_images_activity
+  AFTER INSERT OR UPDATE OF status OR DELETE ON images
+  FOR EACH ROW
+  EXECUTE FUNCTION record_image_activity();
+
+-- Backfill creation events for existing images so older projects have a timeline.
+INSERT INTO project_activity (project_id, image_id, event_type, metadata, created_at)
+SELECT project_id, id, 'image.created',
+       jsonb_build_object('status', status, 'room_type', room_type, 'style', style), created_at
+FROM images;
+
+COMMENT ON TABLE project_activity IS 'Append-only audit log of events that happened within a project';
+COMMENT ON COLUMN project_activity.event_type IS 'Dotted event name, e.g. image.created or image.status_changed';
+COMMENT ON COLUMN project_activity.metadata IS 'Event-specific details';
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS image_original_purges;
+DROP TABLE IF EXISTS project_retention_exemptions;
+
+DELETE FROM settings WHERE key = 'retention_default_original_days';
+
+ALTER TABLE plans DROP COLUMN IF EXISTS original_retention_days;
@@ -0,0 +1,40 @@
+-- Image retention: how long original uploads are kept, per plan.
+
+-- Days to keep original uploads for subscribers on this plan. NULL keeps them forever.
+ALTER TABLE plans
+  ADD COLUMN original_retention_days INT CHECK (original_retention_days IS NULL OR original_retention_days > 0);
+
+COMMENT ON COLUMN plans.original_retention_days IS 'Days to keep original uploads; NULL keeps them forever';
+
+-- Basic keeps originals for 90 days; other plans (e.g. pro) keep them forever.
+UPDATE plans SET original_retention_days = 90 WHERE code = 'basic';
+
+-- Retention for users without an active subscription. Empty or 0 keeps originals forever.
+INSERT INTO settings (key, value, description)
+VALUES (
+    'retention_default_original_days',
+    '90',
+    'Days to keep original uploads for users without an active subscription (empty or 0 = forever)'
+) ON CONFLICT (key) DO NOTHING;
+
+-- Projects whose images are never purged, regardless of plan.
+CREATE TABLE project_retention_exemptions (
+  project_id UUID PRIMARY KEY REFERENCES projects(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE project_retention_exemptions IS 'Projects excluded from retention purging';
+
+-- Purge state for original uploads. A row exists once the owner has been warned.
+CREATE TABLE image_original_purges (
+  image_id UUID PRIMARY KEY REFERENCES images(id) ON DELETE CASCADE,
+  warned_at TIMESTAMPTZ NOT NULL,
+  purge_after TIMESTAMPTZ NOT NULL,
+  purged_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_image_original_purges_pending ON image_original_purges (purge_after) WHERE purged_at IS NULL;
+
+COMMENT ON TABLE image_original_purges IS 'Retention warning and purge state for original uploads';
+COMMENT ON COLUMN image_original_purges.purge_after IS 'Earliest time the original may be deleted, as communicated in the warning';
+COMMENT ON COLUMN image_original_purges.purged_at IS 'When the original upload was deleted from storage';
@@ -0,0 +1,18 @@
+-- Legal holds: admin-placed flags that block deletion of a project or image.
+
+CREATE TABLE legal_holds (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  -- RESTRICT makes the database refuse to delete held rows, including through cascades.
+  project_id UUID REFERENCES projects(id) ON DELETE RESTRICT,
+  image_id UUID REFERENCES images(id) ON DELETE RESTRICT,
+  reason TEXT NOT NULL,
+  placed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT chk_legal_holds_one_target CHECK (num_nonnulls(project_id, image_id) = 1)
+);
+
+CREATE UNIQUE INDEX uq_legal_holds_project_id ON legal_holds (project_id) WHERE project_id IS NOT NULL;
+CREATE UNIQUE INDEX uq_legal_holds_image_id ON legal_holds (image_id) WHERE image_id IS NOT NULL;
+
+COMMENT ON TABLE legal_holds IS 'Admin-placed holds that block deletion of a project or image';
+COMMENT ON COLUMN legal_holds.reason IS 'Why the hold was placed, e.g. a dispute or case reference';
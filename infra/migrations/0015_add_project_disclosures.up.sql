@@ -0,0 +1,17 @@
+-- Project disclosures: "virtually staged" banner rendered onto staged outputs.
+
+CREATE TABLE project_disclosures (
+  project_id UUID PRIMARY KEY REFERENCES projects(id) ON DELETE CASCADE,
+  enabled BOOLEAN NOT NULL DEFAULT false,
+  locale TEXT NOT NULL DEFAULT 'en',
+  text TEXT CHECK (text IS NULL OR char_length(text) BETWEEN 1 AND 80),
+  position TEXT NOT NULL DEFAULT 'bottom_right'
+    CHECK (position IN ('top_left', 'top_center', 'top_right', 'bottom_left', 'bottom_center', 'bottom_right')),
+  opacity REAL NOT NULL DEFAULT 0.6 CHECK (opacity >= 0 AND opacity <= 1),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE project_disclosures IS 'Per-project disclosure banner rendered onto staged images';
+COMMENT ON COLUMN project_disclosures.locale IS 'Language of the default banner text, e.g. en or es';
+COMMENT ON COLUMN project_disclosures.text IS 'Custom banner text; NULL uses the default text for the locale';
+COMMENT ON COLUMN project_disclosures.opacity IS 'Opacity of the banner background from 0 to 1; text is always opaque';
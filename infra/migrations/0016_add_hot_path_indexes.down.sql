@@ -0,0 +1,7 @@
+CREATE INDEX IF NOT EXISTS idx_subscriptions_user_status ON subscriptions (user_id, status);
+DROP INDEX IF EXISTS idx_subscriptions_user_status_updated;
+
+DROP INDEX IF EXISTS idx_jobs_status_created;
+
+CREATE INDEX IF NOT EXISTS idx_images_project ON images (project_id);
+DROP INDEX IF EXISTS idx_images_project_status_created;
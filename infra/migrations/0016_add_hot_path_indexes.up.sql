@@ -0,0 +1,15 @@
+-- Composite indexes for hot query paths. tests/integration/query_plan_test.go fails if
+-- the queries they serve fall back to sequential scans.
+
+-- Project image listings and status filters (gallery, bulk actions, retention).
+-- Supersedes idx_images_project, which is a prefix of this index.
+CREATE INDEX IF NOT EXISTS idx_images_project_status_created ON images (project_id, status, created_at DESC);
+DROP INDEX IF EXISTS idx_images_project;
+
+-- Pending job polling and the admin job list filtered by status.
+CREATE INDEX IF NOT EXISTS idx_jobs_status_created ON jobs (status, created_at);
+
+-- Active subscription lookups per user, newest first (entitlements, retention).
+-- Supersedes idx_subscriptions_user_status.
+CREATE INDEX IF NOT EXISTS idx_subscriptions_user_status_updated ON subscriptions (user_id, status, updated_at DESC);
+DROP INDEX IF EXISTS idx_subscriptions_user_status;
@@ -0,0 +1,76 @@
+-- Restore unpartitioned images and jobs with their original foreign keys.
+
+DROP TRIGGER IF EXISTS trigger_legal_holds_image_reference ON legal_holds;
+DROP TRIGGER IF EXISTS trigger_image_original_purges_image_reference ON image_original_purges;
+DROP TRIGGER IF EXISTS trigger_jobs_image_reference ON jobs;
+DROP TRIGGER IF EXISTS trigger_images_cascade_delete ON images;
+DROP TRIGGER IF EXISTS trigger_images_restrict_held ON images;
+DROP TRIGGER IF EXISTS trigger_images_activity ON images;
+DROP FUNCTION IF EXISTS check_image_reference();
+DROP FUNCTION IF EXISTS cascade_image_delete();
+DROP FUNCTION IF EXISTS restrict_held_image_delete();
+
+ALTER TABLE images RENAME TO images_partitioned;
+ALTER TABLE jobs RENAME TO jobs_partitioned;
+ALTER INDEX images_pkey RENAME TO images_partitioned_pkey;
+ALTER INDEX jobs_pkey RENAME TO jobs_partitioned_pkey;
+
+CREATE TABLE images (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+  original_url TEXT NOT NULL,
+  staged_url TEXT,
+  room_type TEXT,
+  style TEXT,
+  seed BIGINT,
+  status image_status NOT NULL DEFAULT 'queued',
+  error TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  cost_usd DECIMAL(10, 4) DEFAULT 0.00,
+  model_used VARCHAR(255) CHECK (model_used ~ '^[a-zA-Z0-9_-]*$'),
+  processing_time_ms INTEGER,
+  replicate_prediction_id VARCHAR(255) CHECK (replicate_prediction_id ~ '^[a-zA-Z0-9_-]*$')
+);
+
+CREATE TABLE jobs (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  image_id UUID NOT NULL REFERENCES images(id) ON DELETE CASCADE,
+  type TEXT NOT NULL,
+  payload_json JSONB NOT NULL,
+  status TEXT NOT NULL DEFAULT 'queued',
+  error TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  started_at TIMESTAMPTZ,
+  finished_at TIMESTAMPTZ
+);
+
+INSERT INTO images SELECT * FROM images_partitioned;
+INSERT INTO jobs SELECT * FROM jobs_partitioned;
+
+DROP TABLE jobs_partitioned;
+DROP TABLE images_partitioned;
+
+CREATE INDEX idx_images_project_status_created ON images (project_id, status, created_at DESC);
+CREATE INDEX idx_images_project_cost ON images (project_id, cost_usd);
+CREATE INDEX idx_images_model ON images (model_used);
+CREATE INDEX idx_jobs_image ON jobs (image_id);
+CREATE INDEX idx_jobs_status_created ON jobs (status, created_at);
+
+COMMENT ON COLUMN images.cost_usd IS 'Cost in USD for processing this image';
+COMMENT ON COLUMN images.model_used IS 'The AI model ID used to process this image';
+COMMENT ON COLUMN images.processing_time_ms IS 'Processing time in milliseconds';
+COMMENT ON COLUMN images.replicate_prediction_id IS 'Replicate prediction ID for tracking and billing';
+
+CREATE TRIGGER trigger_images_activity
+  AFTER INSERT OR UPDATE OF status OR DELETE ON images
+  FOR EACH ROW
+  EXECUTE FUNCTION record_image_activity();
+
+ALTER TABLE image_original_purges
+  ADD CONSTRAINT image_original_purges_image_id_fkey FOREIGN KEY (image_id) REFERENCES images(id) ON DELETE CASCADE;
+ALTER TABLE legal_holds
+  ADD CONSTRAINT legal_holds_image_id_fkey FOREIGN KEY (image_id) REFERENCES images(id) ON DELETE RESTRICT;
+
+DROP FUNCTION IF EXISTS ensure_monthly_partitions(regclass, int);
+DROP FUNCTION IF EXISTS create_monthly_partition(regclass, date);
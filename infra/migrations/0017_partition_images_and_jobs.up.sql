@@ -0,0 +1,218 @@
+-- Monthly range partitioning of images and jobs on created_at.
+--
+-- Partitioned tables cannot enforce uniqueness on id alone, so the primary keys become
+-- (id, created_at) and foreign keys that pointed at images(id) are replaced by triggers
+-- with the same behavior: jobs and purge state cascade, legal holds restrict, and
+-- references to missing images raise foreign_key_violation (23503).
+--
+-- Rows outside every monthly partition land in the DEFAULT partition. The worker's
+-- partition maintenance job creates upcoming months ahead of time so it stays empty.
+
+-- create_monthly_partition creates the partition of parent covering the UTC month that
+-- contains month. It returns false when the partition already exists, or when rows for
+-- that month are already in the default partition (which would make the CREATE fail).
+CREATE OR REPLACE FUNCTION create_monthly_partition(parent regclass, month date)
+RETURNS boolean AS $$
+DECLARE
+  start_at timestamptz := date_trunc('month', month::timestamp) AT TIME ZONE 'UTC';
+  end_at timestamptz := (date_trunc('month', month::timestamp) + interval '1 month') AT TIME ZONE 'UTC';
+  partition_name text := format('%s_p%s', parent::text, to_char(date_trunc('month', month::timestamp), 'YYYY_MM'));
+  default_name text := format('%s_default', parent::text);
+  stranded boolean;
+BEGIN
+  IF to_regclass(partition_name) IS NOT NULL THEN
+    RETURN false;
+  END IF;
+
+  IF to_regclass(default_name) IS NOT NULL THEN
+    EXECUTE format('SELECT EXISTS (SELECT 1 FROM %s WHERE created_at >= $1 AND created_at < $2)', default_name)
+      INTO stranded USING start_at, end_at;
+    IF stranded THEN
+      RAISE WARNING '% has rows for %; partition % not created', default_name, to_char(start_at, 'YYYY-MM'), partition_name;
+      RETURN false;
+    END IF;
+  END IF;
+
+  EXECUTE format('CREATE TABLE %I PARTITION OF %s FOR VALUES FROM (%L) TO (%L)',
+                 partition_name, parent, start_at, end_at);
+  RETURN true;
+END;
+$$ LANGUAGE plpgsql;
+
+-- ensure_monthly_partitions creates partitions of parent for the current UTC month and
+-- the next months_ahead months, returning how many were created. Concurrent callers are
+-- serialized so two workers never race on the same CREATE TABLE.
+CREATE OR REPLACE FUNCTION ensure_monthly_partitions(parent regclass, months_ahead int)
+RETURNS int AS $$
+DECLARE
+  this_month date := date_trunc('month', now() AT TIME ZONE 'UTC')::date;
+  created int := 0;
+BEGIN
+  PERFORM pg_advisory_xact_lock(hashtext('ensure_monthly_partitions'));
+  FOR i IN 0..months_ahead LOOP
+    IF create_monthly_partition(parent, (this_month + make_interval(months => i))::date) THEN
+      created := created + 1;
+    END IF;
+  END LOOP;
+  RETURN created;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Move the existing tables aside. Incoming foreign keys and the activity trigger are
+-- dropped first so they are not carried over to the legacy tables.
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_image_id_fkey;
+ALTER TABLE image_original_purges DROP CONSTRAINT IF EXISTS image_original_purges_image_id_fkey;
+ALTER TABLE legal_holds DROP CONSTRAINT IF EXISTS legal_holds_image_id_fkey;
+DROP TRIGGER IF EXISTS trigger_images_activity ON images;
+
+ALTER TABLE images RENAME TO images_legacy;
+ALTER TABLE jobs RENAME TO jobs_legacy;
+ALTER INDEX images_pkey RENAME TO images_legacy_pkey;
+ALTER INDEX jobs_pkey RENAME TO jobs_legacy_pkey;
+
+-- Column order matches the original tables so SELECT * consumers are unaffected.
+CREATE TABLE images (
+  id UUID NOT NULL DEFAULT gen_random_uuid(),
+  project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+  original_url TEXT NOT NULL,
+  staged_url TEXT,
+  room_type TEXT,
+  style TEXT,
+  seed BIGINT,
+  status image_status NOT NULL DEFAULT 'queued',
+  error TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  cost_usd DECIMAL(10, 4) DEFAULT 0.00,
+  model_used VARCHAR(255) CHECK (model_used ~ '^[a-zA-Z0-9_-]*$'),
+  processing_time_ms INTEGER,
+  replicate_prediction_id VARCHAR(255) CHECK (replicate_prediction_id ~ '^[a-zA-Z0-9_-]*$'),
+  PRIMARY KEY (id, created_at)
+) PARTITION BY RANGE (created_at);
+
+CREATE TABLE jobs (
+  id UUID NOT NULL DEFAULT gen_random_uuid(),
+  image_id UUID NOT NULL,
+  type TEXT NOT NULL,
+  payload_json JSONB NOT NULL,
+  status TEXT NOT NULL DEFAULT 'queued',
+  error TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  started_at TIMESTAMPTZ,
+  finished_at TIMESTAMPTZ,
+  PRIMARY KEY (id, created_at)
+) PARTITION BY RANGE (created_at);
+
+CREATE TABLE images_default PARTITION OF images DEFAULT;
+CREATE TABLE jobs_default PARTITION OF jobs DEFAULT;
+
+-- One partition per month of existing data, plus the next three months.
+DO $$
+DECLARE
+  first_month date;
+  this_month date := date_trunc('month', now() AT TIME ZONE 'UTC')::date;
+BEGIN
+  SELECT date_trunc('month', min(created_at) AT TIME ZONE 'UTC')::date INTO first_month
+  FROM (SELECT created_at FROM images_legacy UNION ALL SELECT created_at FROM jobs_legacy) t;
+  first_month := LEAST(COALESCE(first_month, this_month), this_month);
+
+  WHILE first_month < this_month LOOP
+    PERFORM create_monthly_partition('images', first_month);
+    PERFORM create_monthly_partition('jobs', first_month);
+    first_month := (first_month + interval '1 month')::date;
+  END LOOP;
+END;
+$$;
+
+SELECT ensure_monthly_partitions('images', 3);
+SELECT ensure_monthly_partitions('jobs', 3);
+
+INSERT INTO images SELECT * FROM images_legacy;
+INSERT INTO jobs SELECT * FROM jobs_legacy;
+
+DROP TABLE jobs_legacy;
+DROP TABLE images_legacy;
+
+-- Indexes are created on the parents and cascade to every partition, including future ones.
+-- Lookups by id alone use the leading column of each partition's primary key.
+CREATE INDEX idx_images_project_status_created ON images (project_id, status, created_at DESC);
+CREATE INDEX idx_images_project_cost ON images (project_id, cost_usd);
+CREATE INDEX idx_images_model ON images (model_used);
+CREATE INDEX idx_jobs_image ON jobs (image_id, created_at);
+CREATE INDEX idx_jobs_status_created ON jobs (status, created_at);
+
+COMMENT ON COLUMN images.cost_usd IS 'Cost in USD for processing this image';
+COMMENT ON COLUMN images.model_used IS 'The AI model ID used to process this image';
+COMMENT ON COLUMN images.processing_time_ms IS 'Processing time in milliseconds';
+COMMENT ON COLUMN images.replicate_prediction_id IS 'Replicate prediction ID for tracking and billing';
+COMMENT ON TABLE images IS 'Uploaded images, partitioned by month of created_at';
+COMMENT ON TABLE jobs IS 'Image processing jobs, partitioned by month of created_at';
+
+CREATE TRIGGER trigger_images_activity
+  AFTER INSERT OR UPDATE OF status OR DELETE ON images
+  FOR EACH ROW
+  EXECUTE FUNCTION record_image_activity();
+
+-- Replaces the ON DELETE RESTRICT foreign key from legal_holds.image_id.
+CREATE OR REPLACE FUNCTION restrict_held_image_delete()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF EXISTS (SELECT 1 FROM legal_holds WHERE image_id = OLD.id) THEN
+    RAISE EXCEPTION 'image % is under legal hold', OLD.id
+      USING ERRCODE = 'foreign_key_violation', TABLE = 'legal_holds';
+  END IF;
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_images_restrict_held
+  BEFORE DELETE ON images
+  FOR EACH ROW
+  EXECUTE FUNCTION restrict_held_image_delete();
+
+-- Replaces the ON DELETE CASCADE foreign keys from jobs and image_original_purges.
+CREATE OR REPLACE FUNCTION cascade_image_delete()
+RETURNS TRIGGER AS $$
+BEGIN
+  DELETE FROM jobs WHERE image_id = OLD.id;
+  DELETE FROM image_original_purges WHERE image_id = OLD.id;
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_images_cascade_delete
+  AFTER DELETE ON images
+  FOR EACH ROW
+  EXECUTE FUNCTION cascade_image_delete();
+
+-- Replaces the referencing side of the dropped foreign keys. FOR KEY SHARE locks the
+-- image like a real foreign key check so it cannot be deleted before the insert commits.
+CREATE OR REPLACE FUNCTION check_image_reference()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF NEW.image_id IS NULL THEN
+    RETURN NEW;
+  END IF;
+  PERFORM 1 FROM images WHERE id = NEW.image_id FOR KEY SHARE;
+  IF NOT FOUND THEN
+    RAISE EXCEPTION 'image % does not exist', NEW.image_id
+      USING ERRCODE = 'foreign_key_violation', TABLE = TG_TABLE_NAME;
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_jobs_image_reference
+  BEFORE INSERT OR UPDATE OF image_id ON jobs
+  FOR EACH ROW
+  EXECUTE FUNCTION check_image_reference();
+
+CREATE TRIGGER trigger_image_original_purges_image_reference
+  BEFORE INSERT OR UPDATE OF image_id ON image_original_purges
+  FOR EACH ROW
+  EXECUTE FUNCTION check_image_reference();
+
+CREATE TRIGGER trigger_legal_holds_image_reference
+  BEFORE INSERT OR UPDATE OF image_id ON legal_holds
+  FOR EACH ROW
+  EXECUTE FUNCTION check_image_reference();
@@ -0,0 +1,15 @@
+CREATE OR REPLACE FUNCTION cascade_image_delete()
+RETURNS TRIGGER AS $$
+BEGIN
+  DELETE FROM jobs WHERE image_id = OLD.id;
+  DELETE FROM image_original_purges WHERE image_id = OLD.id;
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Move archived jobs back so rolling back does not lose them.
+INSERT INTO jobs (id, image_id, type, payload_json, status, error, created_at, started_at, finished_at)
+SELECT id, image_id, type, payload_json, status, error, created_at, started_at, finished_at
+FROM jobs_history;
+
+DROP TABLE IF EXISTS jobs_history;
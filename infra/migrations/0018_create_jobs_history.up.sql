@@ -0,0 +1,31 @@
+-- Archive of terminal jobs moved out of the hot jobs table by the worker's archive job.
+-- Columns match jobs, plus archived_at, so rows move with INSERT ... SELECT.
+CREATE TABLE jobs_history (
+  id UUID PRIMARY KEY,
+  image_id UUID NOT NULL,
+  type TEXT NOT NULL,
+  payload_json JSONB NOT NULL,
+  status TEXT NOT NULL,
+  error TEXT,
+  created_at TIMESTAMPTZ NOT NULL,
+  started_at TIMESTAMPTZ,
+  finished_at TIMESTAMPTZ,
+  archived_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_jobs_history_image ON jobs_history (image_id);
+CREATE INDEX idx_jobs_history_status_created ON jobs_history (status, created_at);
+
+COMMENT ON TABLE jobs_history IS 'Completed, failed and canceled jobs archived from jobs';
+COMMENT ON COLUMN jobs_history.archived_at IS 'When the job was moved out of jobs';
+
+-- Archived jobs go with their image, like live jobs.
+CREATE OR REPLACE FUNCTION cascade_image_delete()
+RETURNS TRIGGER AS $$
+BEGIN
+  DELETE FROM jobs WHERE image_id = OLD.id;
+  DELETE FROM jobs_history WHERE image_id = OLD.id;
+  DELETE FROM image_original_purges WHERE image_id = OLD.id;
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,7 @@
+ALTER TABLE jobs_history
+  DROP COLUMN IF EXISTS output_key,
+  DROP COLUMN IF EXISTS prediction_id;
+
+ALTER TABLE jobs
+  DROP COLUMN IF EXISTS output_key,
+  DROP COLUMN IF EXISTS prediction_id;
@@ -0,0 +1,12 @@
+-- Processing checkpoints the worker records as a stage:run job progresses, so a job retried
+-- after a worker crash resumes the recorded prediction or output instead of re-running the model.
+ALTER TABLE jobs
+  ADD COLUMN prediction_id TEXT,
+  ADD COLUMN output_key TEXT;
+
+ALTER TABLE jobs_history
+  ADD COLUMN prediction_id TEXT,
+  ADD COLUMN output_key TEXT;
+
+COMMENT ON COLUMN jobs.prediction_id IS 'Provider prediction started for this job';
+COMMENT ON COLUMN jobs.output_key IS 'S3 key of the staged output once uploaded';
@@ -0,0 +1,7 @@
+ALTER TABLE jobs_history
+  DROP COLUMN IF EXISTS provider_response,
+  DROP COLUMN IF EXISTS model_version;
+
+ALTER TABLE jobs
+  DROP COLUMN IF EXISTS provider_response,
+  DROP COLUMN IF EXISTS model_version;
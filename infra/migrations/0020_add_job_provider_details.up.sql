@@ -0,0 +1,13 @@
+-- Provider details recorded by the worker when a prediction finishes, for debugging failed
+-- or suspicious jobs and for the recovery tooling. provider_response is truncated JSON and
+-- may not parse, so it is stored as text.
+ALTER TABLE jobs
+  ADD COLUMN model_version TEXT,
+  ADD COLUMN provider_response TEXT;
+
+ALTER TABLE jobs_history
+  ADD COLUMN model_version TEXT,
+  ADD COLUMN provider_response TEXT;
+
+COMMENT ON COLUMN jobs.model_version IS 'Provider model version that ran the prediction';
+COMMENT ON COLUMN jobs.provider_response IS 'Final provider prediction response, truncated';
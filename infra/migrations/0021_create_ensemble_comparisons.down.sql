@@ -0,0 +1,11 @@
+CREATE OR REPLACE FUNCTION cascade_image_delete()
+RETURNS TRIGGER AS $$
+BEGIN
+  DELETE FROM jobs WHERE image_id = OLD.id;
+  DELETE FROM jobs_history WHERE image_id = OLD.id;
+  DELETE FROM image_original_purges WHERE image_id = OLD.id;
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TABLE IF EXISTS ensemble_comparisons;
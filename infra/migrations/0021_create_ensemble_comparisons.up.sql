@@ -0,0 +1,32 @@
+-- Outcomes of the worker's experimental ensemble mode, kept for offline evaluation of
+-- the quality scorer's picks.
+CREATE TABLE ensemble_comparisons (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  image_id UUID NOT NULL,
+  candidates JSONB NOT NULL,
+  selected INT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_ensemble_comparisons_image ON ensemble_comparisons (image_id);
+CREATE INDEX idx_ensemble_comparisons_created ON ensemble_comparisons (created_at);
+
+COMMENT ON TABLE ensemble_comparisons IS 'Ensemble mode candidates and the one kept as the staged output';
+COMMENT ON COLUMN ensemble_comparisons.candidates IS 'Model, prompt, prediction, S3 output key, quality score or error of each candidate';
+COMMENT ON COLUMN ensemble_comparisons.selected IS 'Index into candidates of the output that was kept';
+
+CREATE TRIGGER trigger_ensemble_comparisons_image_reference
+  BEFORE INSERT OR UPDATE OF image_id ON ensemble_comparisons
+  FOR EACH ROW
+  EXECUTE FUNCTION check_image_reference();
+
+CREATE OR REPLACE FUNCTION cascade_image_delete()
+RETURNS TRIGGER AS $$
+BEGIN
+  DELETE FROM jobs WHERE image_id = OLD.id;
+  DELETE FROM jobs_history WHERE image_id = OLD.id;
+  DELETE FROM image_original_purges WHERE image_id = OLD.id;
+  DELETE FROM ensemble_comparisons WHERE image_id = OLD.id;
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
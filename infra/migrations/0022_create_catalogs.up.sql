@@ -0,0 +1,18 @@
+-- Admin-defined furniture catalogs ("brand packs") users can pick when staging an image.
+-- The chosen catalog is copied into the job payload, so editing or deleting a catalog
+-- never changes a job that was already queued.
+CREATE TABLE catalogs (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  name TEXT NOT NULL UNIQUE,
+  description TEXT NOT NULL DEFAULT '',
+  prompt_fragment TEXT NOT NULL,
+  reference_image_urls TEXT[] NOT NULL DEFAULT '{}',
+  active BOOLEAN NOT NULL DEFAULT true,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE catalogs IS 'Furniture style packs selectable at staging time';
+COMMENT ON COLUMN catalogs.prompt_fragment IS 'Appended to the staging prompt';
+COMMENT ON COLUMN catalogs.reference_image_urls IS 'Reference images passed to models that support image conditioning';
+COMMENT ON COLUMN catalogs.active IS 'Inactive catalogs are hidden from users and cannot be picked';
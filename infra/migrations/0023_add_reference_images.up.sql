@@ -0,0 +1,9 @@
+-- Reference-image-guided staging: users on eligible plans can attach an inspiration photo.
+
+ALTER TABLE plans
+  ADD COLUMN reference_images BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN plans.reference_images IS 'Whether subscribers may attach a reference inspiration photo when staging';
+
+-- Every plan above basic (e.g. pro) includes reference images.
+UPDATE plans SET reference_images = true WHERE code <> 'basic';
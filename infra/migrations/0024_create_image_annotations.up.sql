@@ -0,0 +1,29 @@
+-- Room measurements users supply with an image so staged furniture is sized to the room.
+CREATE TABLE image_annotations (
+  image_id UUID PRIMARY KEY,
+  wall_length_m DOUBLE PRECISION CHECK (wall_length_m > 0),
+  ceiling_height_m DOUBLE PRECISION CHECK (ceiling_height_m > 0),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CHECK (wall_length_m IS NOT NULL OR ceiling_height_m IS NOT NULL)
+);
+
+COMMENT ON TABLE image_annotations IS 'User-supplied room dimensions used to scale staged furniture';
+COMMENT ON COLUMN image_annotations.wall_length_m IS 'Length in meters of the main wall facing the camera';
+COMMENT ON COLUMN image_annotations.ceiling_height_m IS 'Floor-to-ceiling height in meters';
+
+CREATE TRIGGER trigger_image_annotations_image_reference
+  BEFORE INSERT OR UPDATE OF image_id ON image_annotations
+  FOR EACH ROW
+  EXECUTE FUNCTION check_image_reference();
+
+CREATE OR REPLACE FUNCTION cascade_image_delete()
+RETURNS TRIGGER AS $$
+BEGIN
+  DELETE FROM jobs WHERE image_id = OLD.id;
+  DELETE FROM jobs_history WHERE image_id = OLD.id;
+  DELETE FROM image_original_purges WHERE image_id = OLD.id;
+  DELETE FROM ensemble_comparisons WHERE image_id = OLD.id;
+  DELETE FROM image_annotations WHERE image_id = OLD.id;
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,20 @@
+-- Named staging presets users save to reuse the same room type, style, seed, catalog and
+-- extra prompt instructions across images. Like catalogs, a preset is resolved into the
+-- job payload when an image is created, so editing it never changes a queued job.
+CREATE TABLE presets (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  room_type TEXT,
+  style TEXT,
+  seed BIGINT,
+  catalog_id UUID REFERENCES catalogs(id) ON DELETE SET NULL,
+  prompt TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  UNIQUE (user_id, name)
+);
+
+COMMENT ON TABLE presets IS 'Per-user saved staging options selectable by preset_id on image creation';
+COMMENT ON COLUMN presets.prompt IS 'Extra instructions appended to the staging prompt';
+COMMENT ON COLUMN presets.catalog_id IS 'Furniture catalog to stage with; cleared when the catalog is deleted';
@@ -0,0 +1,3 @@
+ALTER TABLE images
+  DROP COLUMN IF EXISTS staged_size_bytes,
+  DROP COLUMN IF EXISTS original_size_bytes;
@@ -0,0 +1,9 @@
+-- Byte sizes of an image's original upload and staged output, recorded by the worker
+-- when it stages the image. Project summaries add them up as the storage a project uses.
+-- Images staged before this migration, or resumed from an output checkpoint, leave them NULL.
+ALTER TABLE images
+  ADD COLUMN original_size_bytes BIGINT,
+  ADD COLUMN staged_size_bytes BIGINT;
+
+COMMENT ON COLUMN images.original_size_bytes IS 'Size in bytes of the original upload; NULL until the worker reads it';
+COMMENT ON COLUMN images.staged_size_bytes IS 'Size in bytes of the staged output; NULL until the worker uploads it';
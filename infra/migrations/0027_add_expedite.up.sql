@@ -0,0 +1,11 @@
+-- Expedited staging: users on eligible plans can move a queued image ahead of the
+-- regular queue. Each expedite is written to project_activity as image.expedited,
+-- which doubles as the audit trail and the source of the daily per-user limit.
+
+ALTER TABLE plans
+  ADD COLUMN expedite BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN plans.expedite IS 'Whether subscribers may move a queued image to the priority queue';
+
+-- Every plan above basic (e.g. pro) includes expediting.
+UPDATE plans SET expedite = true WHERE code <> 'basic';
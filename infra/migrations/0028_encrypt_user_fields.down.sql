@@ -0,0 +1,4 @@
+-- Rows must be decrypted with cmd/fieldkeys -decrypt before rolling back, or lookups by
+-- Stripe customer id will miss encrypted rows.
+DROP INDEX IF EXISTS idx_users_stripe_customer_id_hash;
+ALTER TABLE users DROP COLUMN IF EXISTS stripe_customer_id_hash;
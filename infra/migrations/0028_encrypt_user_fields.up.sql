@@ -0,0 +1,10 @@
+-- Application-level encryption of sensitive user columns. phone, billing_address and
+-- stripe_customer_id may now hold AES-GCM ciphertext written by the API, which cannot be
+-- compared directly, so Stripe customers are looked up through a keyed blind index.
+
+ALTER TABLE users
+  ADD COLUMN stripe_customer_id_hash TEXT;
+
+COMMENT ON COLUMN users.stripe_customer_id_hash IS 'HMAC-SHA256 blind index of stripe_customer_id, used for lookups';
+
+CREATE INDEX IF NOT EXISTS idx_users_stripe_customer_id_hash ON users (stripe_customer_id_hash);
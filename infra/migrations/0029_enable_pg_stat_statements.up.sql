@@ -0,0 +1,4 @@
+-- Statement statistics for GET /api/v1/admin/db/queries. The view only returns rows when
+-- the server preloads the module (shared_preload_libraries = 'pg_stat_statements'); until
+-- then the endpoint answers 503.
+CREATE EXTENSION IF NOT EXISTS pg_stat_statements;
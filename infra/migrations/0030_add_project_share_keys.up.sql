@@ -0,0 +1,11 @@
+-- Version of the key a project's share links are signed with. Bumping it invalidates every
+-- link issued before, so a leaked gallery link can be killed without touching the images.
+-- Projects without a row sign with version 1.
+CREATE TABLE project_share_keys (
+  project_id UUID PRIMARY KEY REFERENCES projects(id) ON DELETE CASCADE,
+  version INTEGER NOT NULL DEFAULT 1,
+  rotated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE project_share_keys IS 'Current share link signing key version per project';
+COMMENT ON COLUMN project_share_keys.version IS 'Links signed with any other version are rejected';
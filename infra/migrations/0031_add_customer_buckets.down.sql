@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS customer_buckets;
+
+ALTER TABLE plans
+  DROP COLUMN IF EXISTS customer_bucket;
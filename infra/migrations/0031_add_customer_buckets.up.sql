@@ -0,0 +1,25 @@
+-- Customer-managed storage: accounts on eligible plans keep their images in an S3 bucket
+-- of their own. The API and worker reach it by assuming role_arn with external_id, which
+-- the customer's trust policy must require. New uploads only go to the bucket once an
+-- access check has passed (verified_at); images already stored there stay readable
+-- through the row until it is removed.
+
+ALTER TABLE plans
+  ADD COLUMN customer_bucket BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN plans.customer_bucket IS 'Whether subscribers may store images in their own S3 bucket';
+
+CREATE TABLE customer_buckets (
+  user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+  bucket TEXT NOT NULL UNIQUE,
+  region TEXT NOT NULL,
+  role_arn TEXT NOT NULL,
+  external_id TEXT NOT NULL,
+  verified_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE customer_buckets IS 'Customer-owned S3 bucket, and the IAM role to reach it, per account';
+COMMENT ON COLUMN customer_buckets.external_id IS 'sts:ExternalId the role trust policy requires; generated by us and never changed';
+COMMENT ON COLUMN customer_buckets.verified_at IS 'Last passing access check; NULL keeps new uploads in the platform bucket';
@@ -0,0 +1,7 @@
+-- Data residency: users placed in a region keep their uploads and staged outputs in that
+-- region's bucket (s3.region_buckets). NULL keeps them in the home bucket. Images already
+-- stored stay where they are; the stored URL names their bucket.
+ALTER TABLE users
+  ADD COLUMN storage_region TEXT;
+
+COMMENT ON COLUMN users.storage_region IS 'Region whose bucket new images are stored in; NULL uses the home bucket';
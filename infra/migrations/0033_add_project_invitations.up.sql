@@ -0,0 +1,37 @@
+-- Project collaborators: an owner invites someone by email to view or edit one project
+-- without giving them the rest of the account. The invitation email carries a signed
+-- acceptance link; accepting it as a signed-in user adds a collaborator row. Revoking an
+-- invitation also removes the access it granted.
+CREATE TABLE project_invitations (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+  invited_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  email TEXT NOT NULL,
+  role TEXT NOT NULL CHECK (role IN ('viewer', 'editor')),
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'revoked')),
+  accepted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+  expires_at TIMESTAMPTZ NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  accepted_at TIMESTAMPTZ,
+  revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_project_invitations_project_id ON project_invitations(project_id, created_at DESC);
+
+COMMENT ON TABLE project_invitations IS 'Email invitations to collaborate on a single project';
+COMMENT ON COLUMN project_invitations.role IS 'Access granted on acceptance: viewer or editor';
+COMMENT ON COLUMN project_invitations.status IS 'pending until accepted or revoked; expiry is checked against expires_at';
+
+CREATE TABLE project_collaborators (
+  project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  role TEXT NOT NULL CHECK (role IN ('viewer', 'editor')),
+  invitation_id UUID REFERENCES project_invitations(id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (project_id, user_id)
+);
+
+CREATE INDEX idx_project_collaborators_user_id ON project_collaborators(user_id);
+
+COMMENT ON TABLE project_collaborators IS 'Users with access to a project they do not own';
+COMMENT ON COLUMN project_collaborators.invitation_id IS 'Invitation that granted the access; revoking it removes the row';
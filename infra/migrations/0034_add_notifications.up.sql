@@ -0,0 +1,25 @@
+-- In-app notifications: the API and the worker insert a row when something a user cares
+-- about happens (an image finished staging, a payment failed, an export completed, a share
+-- link was opened) and publish it to the user's Redis channel so open sessions see it at
+-- once. The table is the source of truth; a client that was offline lists it on return.
+CREATE TABLE notifications (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  type TEXT NOT NULL CHECK (type IN ('image_ready', 'payment_failed', 'export_complete', 'share_link_viewed')),
+  title TEXT NOT NULL,
+  body TEXT NOT NULL DEFAULT '',
+  data JSONB NOT NULL DEFAULT '{}'::jsonb,
+  dedupe_key TEXT,
+  read_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_notifications_user_id_created_at ON notifications(user_id, created_at DESC);
+CREATE INDEX idx_notifications_user_id_unread ON notifications(user_id) WHERE read_at IS NULL;
+CREATE UNIQUE INDEX idx_notifications_user_id_dedupe_key_unread
+  ON notifications(user_id, dedupe_key) WHERE read_at IS NULL AND dedupe_key IS NOT NULL;
+
+COMMENT ON TABLE notifications IS 'In-app notifications shown in a user''s notification center';
+COMMENT ON COLUMN notifications.data IS 'Type-specific references for the client, such as image_id or project_id';
+COMMENT ON COLUMN notifications.dedupe_key IS 'Collapses repeats of the same event while an earlier one is still unread';
+COMMENT ON COLUMN notifications.read_at IS 'When the user marked the notification read; NULL while unread';
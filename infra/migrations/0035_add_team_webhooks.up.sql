@@ -0,0 +1,20 @@
+-- Slack and Microsoft Teams incoming webhooks an account posts team notifications to: a
+-- batch of images finished staging, or a subscription payment failed. An account has at
+-- most one webhook per provider. The URL is the webhook's only credential and is never
+-- returned in full by the API.
+CREATE TABLE team_webhooks (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  provider TEXT NOT NULL CHECK (provider IN ('slack', 'teams')),
+  url TEXT NOT NULL,
+  events TEXT[] NOT NULL DEFAULT ARRAY['batch_complete', 'payment_failed']::text[],
+  last_delivery_at TIMESTAMPTZ,
+  last_delivery_error TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  UNIQUE (user_id, provider)
+);
+
+COMMENT ON TABLE team_webhooks IS 'Slack and Teams incoming webhooks that receive an account''s team notifications';
+COMMENT ON COLUMN team_webhooks.events IS 'Events posted to the webhook: batch_complete, payment_failed';
+COMMENT ON COLUMN team_webhooks.last_delivery_error IS 'Why the last delivery failed; NULL after a successful one';
@@ -0,0 +1,17 @@
+DROP TRIGGER IF EXISTS trigger_images_status_transitions ON images;
+DROP FUNCTION IF EXISTS record_image_status_transition();
+DROP TABLE IF EXISTS image_status_transitions;
+
+-- Postgres cannot drop a single enum value, so rebuild the type without the new ones.
+-- Images awaiting upload go back to queued; expired images keep their last result as ready.
+ALTER TABLE images ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE images ALTER COLUMN status TYPE TEXT;
+
+UPDATE images SET status = 'queued' WHERE status = 'awaiting_upload';
+UPDATE images SET status = 'ready' WHERE status = 'expired';
+
+DROP TYPE image_status;
+CREATE TYPE image_status AS ENUM ('queued','processing','ready','error','canceled');
+
+ALTER TABLE images ALTER COLUMN status TYPE image_status USING status::image_status;
+ALTER TABLE images ALTER COLUMN status SET DEFAULT 'queued';
@@ -0,0 +1,44 @@
+-- Image lifecycle states beyond the processing pipeline: awaiting_upload for images
+-- registered before their original has finished uploading, and expired for images whose
+-- files have been removed under retention. Allowed transitions are enforced by the API's
+-- image service; the worker only moves queued or processing images.
+ALTER TYPE image_status ADD VALUE IF NOT EXISTS 'awaiting_upload' BEFORE 'queued';
+ALTER TYPE image_status ADD VALUE IF NOT EXISTS 'expired';
+
+-- Append-only log of every image status change, written by trigger so transitions made
+-- by the worker are captured too. image_id is not a foreign key so the log survives
+-- image deletion.
+CREATE TABLE image_status_transitions (
+  id BIGSERIAL PRIMARY KEY,
+  image_id UUID NOT NULL,
+  project_id UUID NOT NULL,
+  from_status image_status,
+  to_status image_status NOT NULL,
+  error TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_image_status_transitions_image ON image_status_transitions (image_id, created_at, id);
+
+CREATE OR REPLACE FUNCTION record_image_status_transition()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF TG_OP = 'INSERT' THEN
+    INSERT INTO image_status_transitions (image_id, project_id, from_status, to_status, error)
+    VALUES (NEW.id, NEW.project_id, NULL, NEW.status, NEW.error);
+  ELSIF NEW.status IS DISTINCT FROM OLD.status THEN
+    INSERT INTO image_status_transitions (image_id, project_id, from_status, to_status, error)
+    VALUES (NEW.id, NEW.project_id, OLD.status, NEW.status, NEW.error);
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_images_status_transitions
+  AFTER INSERT OR UPDATE OF status ON images
+  FOR EACH ROW
+  EXECUTE FUNCTION record_image_status_transition();
+
+COMMENT ON TABLE image_status_transitions IS 'Append-only log of image status changes';
+COMMENT ON COLUMN image_status_transitions.from_status IS 'Status before the change; NULL when the image was created';
+COMMENT ON COLUMN image_status_transitions.error IS 'The image error message at the time of the change, if any';
@@ -0,0 +1,22 @@
+DROP VIEW IF EXISTS image_current_status;
+DROP TRIGGER IF EXISTS trigger_image_status_transitions_immutable ON image_status_transitions;
+DROP FUNCTION IF EXISTS reject_image_status_transition_change();
+
+CREATE OR REPLACE FUNCTION record_image_status_transition()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF TG_OP = 'INSERT' THEN
+    INSERT INTO image_status_transitions (image_id, project_id, from_status, to_status, error)
+    VALUES (NEW.id, NEW.project_id, NULL, NEW.status, NEW.error);
+  ELSIF NEW.status IS DISTINCT FROM OLD.status THEN
+    INSERT INTO image_status_transitions (image_id, project_id, from_status, to_status, error)
+    VALUES (NEW.id, NEW.project_id, OLD.status, NEW.status, NEW.error);
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE image_status_transitions
+  DROP COLUMN IF EXISTS attempt,
+  DROP COLUMN IF EXISTS job_id,
+  DROP COLUMN IF EXISTS source;
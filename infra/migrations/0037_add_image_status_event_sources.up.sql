@@ -0,0 +1,56 @@
+-- Turns image_status_transitions into an immutable event log that records who made each
+-- change. Writers describe themselves with transaction-local settings the trigger reads:
+--   app.status_source  what made the change, e.g. real-staging-worker; defaults to the connection's application_name
+--   app.job_id         the job whose attempt made the change
+--   app.job_attempt    which attempt of that job, starting at 1
+ALTER TABLE image_status_transitions
+  ADD COLUMN source TEXT NOT NULL DEFAULT 'unknown',
+  ADD COLUMN job_id TEXT,
+  ADD COLUMN attempt INT;
+
+CREATE OR REPLACE FUNCTION record_image_status_transition()
+RETURNS TRIGGER AS $$
+DECLARE
+  v_source TEXT := COALESCE(
+    NULLIF(current_setting('app.status_source', true), ''),
+    NULLIF(current_setting('application_name', true), ''),
+    'unknown');
+  v_job_id TEXT := NULLIF(current_setting('app.job_id', true), '');
+  v_attempt INT := NULLIF(current_setting('app.job_attempt', true), '')::int;
+BEGIN
+  IF TG_OP = 'INSERT' THEN
+    INSERT INTO image_status_transitions (image_id, project_id, from_status, to_status, error, source, job_id, attempt)
+    VALUES (NEW.id, NEW.project_id, NULL, NEW.status, NEW.error, v_source, v_job_id, v_attempt);
+  ELSIF NEW.status IS DISTINCT FROM OLD.status THEN
+    INSERT INTO image_status_transitions (image_id, project_id, from_status, to_status, error, source, job_id, attempt)
+    VALUES (NEW.id, NEW.project_id, OLD.status, NEW.status, NEW.error, v_source, v_job_id, v_attempt);
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Events are never edited or removed one by one; TRUNCATE is left to tests and operators.
+CREATE OR REPLACE FUNCTION reject_image_status_transition_change()
+RETURNS TRIGGER AS $$
+BEGIN
+  RAISE EXCEPTION 'image_status_transitions is append-only'
+    USING ERRCODE = 'insufficient_privilege';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_image_status_transitions_immutable
+  BEFORE UPDATE OR DELETE ON image_status_transitions
+  FOR EACH ROW
+  EXECUTE FUNCTION reject_image_status_transition_change();
+
+-- The status each image's event log ends in. It matches images.status for every image
+-- still stored, and keeps the last status of deleted ones.
+CREATE VIEW image_current_status AS
+SELECT DISTINCT ON (image_id) image_id, project_id, to_status AS status, created_at AS changed_at
+FROM image_status_transitions
+ORDER BY image_id, id DESC;
+
+COMMENT ON COLUMN image_status_transitions.source IS 'What made the change, e.g. real-staging-api or real-staging-worker';
+COMMENT ON COLUMN image_status_transitions.job_id IS 'Job whose attempt made the change, when a worker made it';
+COMMENT ON COLUMN image_status_transitions.attempt IS 'Attempt of job_id that made the change, starting at 1';
+COMMENT ON VIEW image_current_status IS 'Latest status of every image, derived from image_status_transitions';
@@ -0,0 +1,19 @@
+-- User-visible processing log for a stage job: provider progress lines, retry notices and
+-- the outcome, written by the worker as the job runs. Lines are sanitized before they are
+-- stored (no URLs, credentials or terminal escapes) and the log keeps only its most recent
+-- tail, so it is safe to return to the image owner. jobs is partitioned and its rows move
+-- to jobs_history once finished, so the log references the job by ID without a foreign key
+-- and stays readable after the job is archived.
+CREATE TABLE job_logs (
+  job_id UUID PRIMARY KEY,
+  image_id UUID NOT NULL,
+  log TEXT NOT NULL DEFAULT '',
+  truncated BOOLEAN NOT NULL DEFAULT false,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_job_logs_image_id ON job_logs(image_id);
+
+COMMENT ON TABLE job_logs IS 'Sanitized, user-visible processing log for each stage job';
+COMMENT ON COLUMN job_logs.log IS 'Newline-separated log lines, oldest first; only the most recent tail is kept';
+COMMENT ON COLUMN job_logs.truncated IS 'Whether older lines were dropped to keep the log under its size cap';
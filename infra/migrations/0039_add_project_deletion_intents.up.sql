@@ -0,0 +1,14 @@
+-- Pending deletions of projects with staged work. Deleting a project that has ready images
+-- first issues a confirmation token; a second DELETE with the token, before it expires,
+-- deletes the project. Only the token's SHA-256 is stored. A project has at most one
+-- pending deletion: asking again replaces the token.
+CREATE TABLE project_deletion_intents (
+  project_id UUID PRIMARY KEY REFERENCES projects(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  token_hash BYTEA NOT NULL,
+  expires_at TIMESTAMPTZ NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE project_deletion_intents IS 'Confirmation tokens for deleting projects with staged images';
+COMMENT ON COLUMN project_deletion_intents.token_hash IS 'SHA-256 of the confirmation token returned to the user';
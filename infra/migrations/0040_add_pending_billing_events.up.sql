@@ -0,0 +1,26 @@
+-- Stripe subscription and invoice state whose customer is not yet linked to a user, for
+-- example when a webhook arrives before checkout.session.completed links the customer.
+-- Instead of dropping the update, the API parks the object's latest state here and the
+-- worker retries mapping the customer until expires_at; once a user has the customer the
+-- row is applied to subscriptions or invoices and removed. data holds the columns to
+-- write, already normalized by the API (timestamps as RFC 3339, amounts in cents).
+CREATE TABLE pending_billing_events (
+  stripe_object_id TEXT PRIMARY KEY,
+  object_type TEXT NOT NULL CHECK (object_type IN ('subscription', 'invoice')),
+  event_type TEXT NOT NULL,
+  stripe_customer_id TEXT NOT NULL,
+  stripe_customer_id_hash TEXT,
+  data JSONB NOT NULL,
+  attempts INTEGER NOT NULL DEFAULT 0,
+  last_attempt_at TIMESTAMPTZ,
+  expires_at TIMESTAMPTZ NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_pending_billing_events_expires_at ON pending_billing_events(expires_at);
+
+COMMENT ON TABLE pending_billing_events IS 'Stripe subscription and invoice updates waiting for their customer to be linked to a user';
+COMMENT ON COLUMN pending_billing_events.stripe_object_id IS 'Stripe subscription or invoice ID; later events replace the parked state';
+COMMENT ON COLUMN pending_billing_events.stripe_customer_id_hash IS 'Blind index of stripe_customer_id, matched against users when field encryption is active';
+COMMENT ON COLUMN pending_billing_events.attempts IS 'Worker runs that could not map the customer yet';
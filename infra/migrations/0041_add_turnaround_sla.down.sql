@@ -0,0 +1,12 @@
+DELETE FROM notifications WHERE type = 'sla_credit';
+ALTER TABLE notifications DROP CONSTRAINT notifications_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_type_check
+  CHECK (type IN ('image_ready', 'payment_failed', 'export_complete', 'share_link_viewed'));
+
+DROP TABLE IF EXISTS sla_credits;
+DROP TABLE IF EXISTS image_turnarounds;
+
+ALTER TABLE plans
+  DROP COLUMN IF EXISTS sla_credit,
+  DROP COLUMN IF EXISTS sla_target_percent,
+  DROP COLUMN IF EXISTS sla_target_seconds;
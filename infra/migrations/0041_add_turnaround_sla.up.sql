@@ -0,0 +1,64 @@
+-- Turnaround SLA: each plan promises that a share of its images (sla_target_percent) are
+-- ready within sla_target_seconds of first being queued. When an image becomes ready the
+-- worker records its turnaround against the owner's plan; on plans with sla_credit a
+-- missed target also grants the owner a one-image credit.
+
+ALTER TABLE plans
+  ADD COLUMN sla_target_seconds INTEGER CHECK (sla_target_seconds > 0),
+  ADD COLUMN sla_target_percent NUMERIC(5, 2) NOT NULL DEFAULT 95
+    CHECK (sla_target_percent > 0 AND sla_target_percent <= 100),
+  ADD COLUMN sla_credit BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN plans.sla_target_seconds IS 'Queued-to-ready target; NULL measures no SLA';
+COMMENT ON COLUMN plans.sla_target_percent IS 'Share of images, in percent, that must meet sla_target_seconds';
+COMMENT ON COLUMN plans.sla_credit IS 'Whether subscribers are credited an image when one misses the target';
+
+-- Every plan targets 95% under 10 minutes; plans above basic are credited for misses.
+UPDATE plans SET sla_target_seconds = 600;
+UPDATE plans SET sla_credit = true WHERE code <> 'basic';
+
+-- One row per image that became ready. plan_code and target_ms are copied from the plan at
+-- that time, so later plan changes do not rewrite history. image_id is not a foreign key so
+-- the measurement survives image deletion.
+CREATE TABLE image_turnarounds (
+  image_id UUID PRIMARY KEY,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  plan_code TEXT NOT NULL DEFAULT '',
+  queued_at TIMESTAMPTZ NOT NULL,
+  ready_at TIMESTAMPTZ NOT NULL,
+  turnaround_ms BIGINT NOT NULL,
+  target_ms BIGINT,
+  met BOOLEAN,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_image_turnarounds_ready_at ON image_turnarounds(ready_at);
+CREATE INDEX idx_image_turnarounds_user_id_ready_at ON image_turnarounds(user_id, ready_at);
+
+COMMENT ON TABLE image_turnarounds IS 'Queued-to-ready time of each image, measured against its owner''s plan SLA';
+COMMENT ON COLUMN image_turnarounds.plan_code IS 'Plan of the owner when the image became ready; empty without an active plan';
+COMMENT ON COLUMN image_turnarounds.queued_at IS 'First time the image was queued; retries do not reset it';
+COMMENT ON COLUMN image_turnarounds.target_ms IS 'The plan''s sla_target_seconds in milliseconds; NULL without an SLA';
+COMMENT ON COLUMN image_turnarounds.met IS 'Whether turnaround_ms was within target_ms; NULL without an SLA';
+
+-- Ledger of images credited to users for missed SLAs. At most one credit per image.
+CREATE TABLE sla_credits (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  image_id UUID NOT NULL UNIQUE,
+  plan_code TEXT NOT NULL,
+  images INTEGER NOT NULL DEFAULT 1 CHECK (images > 0),
+  turnaround_ms BIGINT NOT NULL,
+  target_ms BIGINT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_sla_credits_user_id_created_at ON sla_credits(user_id, created_at);
+
+COMMENT ON TABLE sla_credits IS 'Images credited to users because one of theirs missed its plan''s turnaround SLA';
+COMMENT ON COLUMN sla_credits.images IS 'Number of images credited';
+
+-- Users are told about their credits in the notification center.
+ALTER TABLE notifications DROP CONSTRAINT notifications_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_type_check
+  CHECK (type IN ('image_ready', 'payment_failed', 'export_complete', 'share_link_viewed', 'sla_credit'));
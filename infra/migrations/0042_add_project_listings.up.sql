@@ -0,0 +1,27 @@
+-- Project listings: the property a project stages, for map views and MLS export naming.
+
+CREATE TABLE project_listings (
+  project_id UUID PRIMARY KEY REFERENCES projects(id) ON DELETE CASCADE,
+  address_line1 TEXT NOT NULL DEFAULT '' CHECK (char_length(address_line1) <= 200),
+  address_line2 TEXT NOT NULL DEFAULT '' CHECK (char_length(address_line2) <= 200),
+  city TEXT NOT NULL DEFAULT '' CHECK (char_length(city) <= 100),
+  region TEXT NOT NULL DEFAULT '' CHECK (char_length(region) <= 100),
+  postal_code TEXT NOT NULL DEFAULT '' CHECK (char_length(postal_code) <= 20),
+  country TEXT NOT NULL DEFAULT '' CHECK (country = '' OR country ~ '^[A-Z]{2}$'),
+  mls_number TEXT NOT NULL DEFAULT '' CHECK (mls_number ~ '^[A-Za-z0-9-]{0,32}$'),
+  listing_url TEXT NOT NULL DEFAULT '' CHECK (char_length(listing_url) <= 2048),
+  latitude DOUBLE PRECISION CHECK (latitude BETWEEN -90 AND 90),
+  longitude DOUBLE PRECISION CHECK (longitude BETWEEN -180 AND 180),
+  geocoded_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CHECK ((latitude IS NULL) = (longitude IS NULL))
+);
+
+CREATE INDEX idx_project_listings_mls_number ON project_listings (mls_number) WHERE mls_number <> '';
+
+COMMENT ON TABLE project_listings IS 'Listing address and MLS details of the property a project stages';
+COMMENT ON COLUMN project_listings.region IS 'State, province or county';
+COMMENT ON COLUMN project_listings.country IS 'ISO 3166-1 alpha-2 country code; empty without an address';
+COMMENT ON COLUMN project_listings.mls_number IS 'Listing number in the multiple listing service';
+COMMENT ON COLUMN project_listings.latitude IS 'Geocoded from the address; NULL until geocoding succeeds';
+COMMENT ON COLUMN project_listings.geocoded_at IS 'When latitude and longitude were last geocoded';
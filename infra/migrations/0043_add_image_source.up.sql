@@ -0,0 +1,7 @@
+-- The surface each image was created from, for channel volume and failure reporting.
+-- Images created before sources were tracked are 'unknown'.
+ALTER TABLE images
+  ADD COLUMN source TEXT NOT NULL DEFAULT 'unknown'
+    CHECK (source IN ('unknown', 'web', 'mobile', 'api', 'import'));
+
+COMMENT ON COLUMN images.source IS 'Surface the image was created from: web, mobile, api or import; unknown before tracking';
@@ -0,0 +1,5 @@
+-- A low-resolution preview of the staged result, published by the worker while the
+-- full-resolution render completes. NULL until the provider reports a partial output.
+ALTER TABLE images ADD COLUMN preview_url TEXT;
+
+COMMENT ON COLUMN images.preview_url IS 'Low-resolution preview of the staged result, set while processing';
@@ -0,0 +1,40 @@
+-- Quick edits of an image, such as erasing an object under a mask. Edits run on their own
+-- queue with a fast inpainting model and leave the image's staged output unchanged.
+CREATE TABLE image_edits (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  image_id UUID NOT NULL,
+  kind TEXT NOT NULL CHECK (kind IN ('erase')),
+  base TEXT NOT NULL CHECK (base IN ('original', 'staged')),
+  mask_url TEXT NOT NULL,
+  status TEXT NOT NULL DEFAULT 'queued' CHECK (status IN ('queued', 'processing', 'ready', 'error')),
+  result_url TEXT,
+  error TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CHECK (status <> 'ready' OR result_url IS NOT NULL)
+);
+
+CREATE INDEX idx_image_edits_image_id ON image_edits (image_id, created_at DESC);
+
+COMMENT ON TABLE image_edits IS 'Quick edits of an image, run apart from staging jobs';
+COMMENT ON COLUMN image_edits.base IS 'Which version of the image was edited: the original upload or the staged output';
+COMMENT ON COLUMN image_edits.mask_url IS 'Mask upload; white pixels mark the area to erase';
+COMMENT ON COLUMN image_edits.result_url IS 'Edited image, set once the edit is ready';
+
+CREATE TRIGGER trigger_image_edits_image_reference
+  BEFORE INSERT OR UPDATE OF image_id ON image_edits
+  FOR EACH ROW
+  EXECUTE FUNCTION check_image_reference();
+
+CREATE OR REPLACE FUNCTION cascade_image_delete()
+RETURNS TRIGGER AS $$
+BEGIN
+  DELETE FROM jobs WHERE image_id = OLD.id;
+  DELETE FROM jobs_history WHERE image_id = OLD.id;
+  DELETE FROM image_original_purges WHERE image_id = OLD.id;
+  DELETE FROM ensemble_comparisons WHERE image_id = OLD.id;
+  DELETE FROM image_annotations WHERE image_id = OLD.id;
+  DELETE FROM image_edits WHERE image_id = OLD.id;
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
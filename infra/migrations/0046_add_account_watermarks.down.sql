@@ -0,0 +1,2 @@
+ALTER TABLE images DROP COLUMN IF EXISTS watermarked_url;
+DROP TABLE IF EXISTS account_watermarks;
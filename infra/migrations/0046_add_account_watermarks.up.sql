@@ -0,0 +1,26 @@
+-- An account's logo watermark, rendered by the worker onto a separate copy of each staged
+-- output. Share links and staged downloads serve that copy; the stored original and staged
+-- output are never watermarked.
+CREATE TABLE account_watermarks (
+  user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+  enabled BOOLEAN NOT NULL DEFAULT true,
+  logo_url TEXT NOT NULL,
+  position TEXT NOT NULL DEFAULT 'bottom_right'
+    CHECK (position IN ('top_left', 'top_center', 'top_right', 'center',
+                        'bottom_left', 'bottom_center', 'bottom_right')),
+  opacity REAL NOT NULL DEFAULT 0.8 CHECK (opacity > 0 AND opacity <= 1),
+  scale REAL NOT NULL DEFAULT 0.2 CHECK (scale >= 0.05 AND scale <= 0.5),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE account_watermarks IS 'Per-account logo watermark applied to exports and share-link renditions';
+COMMENT ON COLUMN account_watermarks.logo_url IS 's3:// URL of the logo, one of the account''s uploads';
+COMMENT ON COLUMN account_watermarks.opacity IS 'Opacity of the logo from 0 to 1';
+COMMENT ON COLUMN account_watermarks.scale IS 'Width of the logo as a fraction of the image width';
+
+-- The watermarked copy of the staged output, set by the worker when the image is staged
+-- while its account has a watermark enabled.
+ALTER TABLE images ADD COLUMN watermarked_url TEXT;
+
+COMMENT ON COLUMN images.watermarked_url IS 'Staged output with the account watermark, served to share links and exports';
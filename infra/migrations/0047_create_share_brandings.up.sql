@@ -0,0 +1,21 @@
+-- An account's branding for public share pages: colors, a logo and optionally a custom
+-- domain the pages are served on. A domain only serves pages once the account has proved
+-- control of it through DNS.
+CREATE TABLE share_brandings (
+  user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+  primary_color TEXT NOT NULL DEFAULT '#111827' CHECK (primary_color ~ '^#[0-9a-f]{6}$'),
+  accent_color TEXT NOT NULL DEFAULT '#2563eb' CHECK (accent_color ~ '^#[0-9a-f]{6}$'),
+  logo_url TEXT,
+  custom_domain TEXT UNIQUE CHECK (custom_domain = lower(custom_domain)),
+  domain_token TEXT,
+  domain_verified_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CHECK ((custom_domain IS NULL) = (domain_token IS NULL))
+);
+
+COMMENT ON TABLE share_brandings IS 'Per-account branding and custom domain of public share pages';
+COMMENT ON COLUMN share_brandings.logo_url IS 's3:// URL of the logo, one of the account''s uploads';
+COMMENT ON COLUMN share_brandings.custom_domain IS 'Host share pages are served on; CNAMEs to the configured share domain target';
+COMMENT ON COLUMN share_brandings.domain_token IS 'Value the domain''s verification TXT record must carry';
+COMMENT ON COLUMN share_brandings.domain_verified_at IS 'When control of custom_domain was proved; NULL while pending';
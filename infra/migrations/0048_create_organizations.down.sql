@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS organization_sso;
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
+
+ALTER TABLE plans
+  DROP COLUMN IF EXISTS sso;
@@ -0,0 +1,60 @@
+-- Enterprise organizations and their single sign-on. An organization on a plan with SSO
+-- federates its identity provider, over OIDC or SAML, through an Auth0 enterprise
+-- connection. Users signing in through that connection are provisioned into the
+-- organization on first login, with a role mapped from their identity provider's claims.
+
+ALTER TABLE plans
+  ADD COLUMN sso BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN plans.sso IS 'Whether subscribers may configure single sign-on for their organizations';
+
+CREATE TABLE organizations (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  name TEXT NOT NULL,
+  owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_organizations_owner_id ON organizations(owner_id);
+
+COMMENT ON TABLE organizations IS 'Enterprise accounts that group users under one identity provider';
+COMMENT ON COLUMN organizations.owner_id IS 'User whose subscription decides whether the organization may use SSO';
+
+CREATE TABLE organization_members (
+  organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  role TEXT NOT NULL CHECK (role IN ('admin', 'member', 'viewer')),
+  source TEXT NOT NULL DEFAULT 'manual' CHECK (source IN ('manual', 'sso')),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE INDEX idx_organization_members_user_id ON organization_members(user_id);
+
+COMMENT ON COLUMN organization_members.source IS 'manual rows keep their role; sso rows take the mapped role at every login';
+
+CREATE TABLE organization_sso (
+  organization_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+  protocol TEXT NOT NULL CHECK (protocol IN ('oidc', 'saml')),
+  connection TEXT NOT NULL UNIQUE,
+  oidc_issuer TEXT,
+  oidc_client_id TEXT,
+  saml_metadata_url TEXT,
+  email_domains TEXT[] NOT NULL DEFAULT '{}',
+  role_claim TEXT NOT NULL DEFAULT '',
+  role_mappings JSONB NOT NULL DEFAULT '{}',
+  default_role TEXT NOT NULL DEFAULT 'member' CHECK (default_role IN ('admin', 'member', 'viewer')),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CHECK (protocol <> 'oidc' OR (oidc_issuer IS NOT NULL AND oidc_client_id IS NOT NULL)),
+  CHECK (protocol <> 'saml' OR saml_metadata_url IS NOT NULL)
+);
+
+CREATE INDEX idx_organization_sso_email_domains ON organization_sso USING GIN (email_domains);
+
+COMMENT ON TABLE organization_sso IS 'Identity provider an organization signs in with, federated through an Auth0 enterprise connection';
+COMMENT ON COLUMN organization_sso.connection IS 'Auth0 enterprise connection name; tokens carry it in the connection claim';
+COMMENT ON COLUMN organization_sso.email_domains IS 'Lowercase email domains routed to the connection at login';
+COMMENT ON COLUMN organization_sso.role_claim IS 'Identity provider claim, e.g. groups, whose values role_mappings maps to roles';
+COMMENT ON COLUMN organization_sso.role_mappings IS 'Claim value to organization role; unmatched users get default_role';
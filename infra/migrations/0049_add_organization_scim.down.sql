@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS organization_scim_group_members;
+DROP TABLE IF EXISTS organization_scim_groups;
+DROP TABLE IF EXISTS organization_scim_users;
+DROP TABLE IF EXISTS organization_scim_tokens;
+
+DELETE FROM organization_members WHERE source = 'scim';
+
+ALTER TABLE organization_members
+  DROP CONSTRAINT organization_members_source_check,
+  ADD CONSTRAINT organization_members_source_check CHECK (source IN ('manual', 'sso'));
+
+COMMENT ON COLUMN organization_members.source IS 'manual rows keep their role; sso rows take the mapped role at every login';
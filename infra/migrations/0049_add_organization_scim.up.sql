@@ -0,0 +1,66 @@
+-- SCIM 2.0 provisioning of organization members. An organization's identity provider pushes
+-- users and groups with a bearer token the organization's admins issue. Each SCIM user is
+-- backed by an account that holds a placeholder subject until the person first signs in
+-- through the organization's SSO connection; active SCIM users are organization members with
+-- the role their groups map to.
+
+ALTER TABLE organization_members
+  DROP CONSTRAINT organization_members_source_check,
+  ADD CONSTRAINT organization_members_source_check CHECK (source IN ('manual', 'sso', 'scim'));
+
+COMMENT ON COLUMN organization_members.source IS 'manual rows keep their role; sso rows take the mapped role at every login; scim rows are managed by the SCIM client';
+
+CREATE TABLE organization_scim_tokens (
+  organization_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+  token_hash BYTEA NOT NULL UNIQUE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  last_used_at TIMESTAMPTZ
+);
+
+COMMENT ON TABLE organization_scim_tokens IS 'Bearer token an organization''s SCIM client authenticates with; one per organization';
+COMMENT ON COLUMN organization_scim_tokens.token_hash IS 'SHA-256 of the token, which is only shown when issued';
+
+CREATE TABLE organization_scim_users (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  user_name TEXT NOT NULL,
+  external_id TEXT,
+  display_name TEXT NOT NULL DEFAULT '',
+  given_name TEXT NOT NULL DEFAULT '',
+  family_name TEXT NOT NULL DEFAULT '',
+  email TEXT NOT NULL DEFAULT '',
+  active BOOLEAN NOT NULL DEFAULT true,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  UNIQUE (organization_id, user_id)
+);
+
+CREATE UNIQUE INDEX idx_organization_scim_users_user_name ON organization_scim_users(organization_id, lower(user_name));
+
+COMMENT ON TABLE organization_scim_users IS 'Users an organization''s identity provider provisioned over SCIM';
+COMMENT ON COLUMN organization_scim_users.user_id IS 'Backing account; its subject is scim|<id> until the first SSO sign-in claims it';
+COMMENT ON COLUMN organization_scim_users.user_name IS 'Unique per organization, case-insensitively; matched against the email of SSO sign-ins';
+COMMENT ON COLUMN organization_scim_users.active IS 'Inactive users keep their record but are not organization members';
+
+CREATE TABLE organization_scim_groups (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  display_name TEXT NOT NULL,
+  external_id TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX idx_organization_scim_groups_display_name ON organization_scim_groups(organization_id, lower(display_name));
+
+COMMENT ON TABLE organization_scim_groups IS 'Groups an organization''s identity provider pushed over SCIM';
+COMMENT ON COLUMN organization_scim_groups.display_name IS 'Matched against the SSO role mappings to pick the role of the group''s members';
+
+CREATE TABLE organization_scim_group_members (
+  group_id UUID NOT NULL REFERENCES organization_scim_groups(id) ON DELETE CASCADE,
+  scim_user_id UUID NOT NULL REFERENCES organization_scim_users(id) ON DELETE CASCADE,
+  PRIMARY KEY (group_id, scim_user_id)
+);
+
+CREATE INDEX idx_organization_scim_group_members_scim_user_id ON organization_scim_group_members(scim_user_id);
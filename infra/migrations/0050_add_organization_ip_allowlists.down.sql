@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS organization_audit_log;
+DROP TABLE IF EXISTS organization_break_glass;
+
+ALTER TABLE organizations
+  DROP COLUMN IF EXISTS ip_allowlist;
@@ -0,0 +1,41 @@
+-- Per-organization IP allow-lists. When an organization sets one, its members can only
+-- reach the API, and so the dashboard, from the listed ranges. An admin locked out can open
+-- a short break-glass window from anywhere; opening it and every request made through it
+-- are written to the organization's audit log.
+
+ALTER TABLE organizations
+  ADD COLUMN ip_allowlist TEXT[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN organizations.ip_allowlist IS 'Canonical CIDR ranges members must connect from; empty allows any address';
+
+CREATE TABLE organization_break_glass (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  reason TEXT NOT NULL,
+  ip TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_organization_break_glass_user_expires ON organization_break_glass(user_id, expires_at DESC);
+
+COMMENT ON TABLE organization_break_glass IS 'Windows in which an admin bypasses the organization''s IP allow-list';
+COMMENT ON COLUMN organization_break_glass.ip IS 'Address the window was opened from';
+
+-- actor_id is intentionally not a foreign key so history survives user deletion.
+CREATE TABLE organization_audit_log (
+  id BIGSERIAL PRIMARY KEY,
+  organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  actor_id UUID,
+  event_type TEXT NOT NULL,
+  ip TEXT NOT NULL DEFAULT '',
+  metadata JSONB NOT NULL DEFAULT '{}'::jsonb,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_organization_audit_log_org_created ON organization_audit_log(organization_id, created_at DESC, id DESC);
+
+COMMENT ON TABLE organization_audit_log IS 'Append-only audit log of security events within an organization';
+COMMENT ON COLUMN organization_audit_log.event_type IS 'Dotted event name, e.g. ip_allowlist.updated or break_glass.opened';
+COMMENT ON COLUMN organization_audit_log.metadata IS 'Event-specific details';
@@ -0,0 +1,23 @@
+-- API keys let integrations, such as an MLS feed, call the API on behalf of an account without
+-- an Auth0 sign-in. Each key is limited to the scopes it was issued with.
+
+CREATE TABLE api_keys (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  prefix TEXT NOT NULL,
+  key_hash BYTEA NOT NULL UNIQUE,
+  scopes TEXT[] NOT NULL CHECK (cardinality(scopes) > 0),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  last_used_at TIMESTAMPTZ,
+  expires_at TIMESTAMPTZ,
+  revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_api_keys_user_id ON api_keys(user_id, created_at DESC);
+
+COMMENT ON TABLE api_keys IS 'Keys that authenticate integrations as their owner, limited to their scopes';
+COMMENT ON COLUMN api_keys.prefix IS 'Leading characters of the key, shown so owners can tell keys apart';
+COMMENT ON COLUMN api_keys.key_hash IS 'SHA-256 of the key, which is only shown when issued';
+COMMENT ON COLUMN api_keys.scopes IS 'Granted scopes such as projects:read or images:*';
+COMMENT ON COLUMN api_keys.expires_at IS 'When the key stops working; NULL keeps it valid until revoked';
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS team_webhook_deliveries;
+
+ALTER TABLE team_webhooks
+  DROP COLUMN IF EXISTS previous_secret_expires_at,
+  DROP COLUMN IF EXISTS previous_signing_secret,
+  DROP COLUMN IF EXISTS signing_secret;
@@ -0,0 +1,37 @@
+-- Team webhook deliveries are logged and retried with exponential backoff for 24 hours, and
+-- signed so receivers can check they came from us. Each webhook has a signing secret; after a
+-- rotation the previous secret keeps signing alongside the new one until it expires, so
+-- receivers can switch over without dropping deliveries.
+
+-- gen_random_uuid draws from a cryptographic source; two of them make a 32-byte secret.
+ALTER TABLE team_webhooks
+  ADD COLUMN signing_secret TEXT NOT NULL DEFAULT (
+    'whsec_' || encode(decode(replace(gen_random_uuid()::text || gen_random_uuid()::text, '-', ''), 'hex'), 'base64')
+  ),
+  ADD COLUMN previous_signing_secret TEXT,
+  ADD COLUMN previous_secret_expires_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN team_webhooks.signing_secret IS 'Secret deliveries are signed with, Standard Webhooks format';
+COMMENT ON COLUMN team_webhooks.previous_signing_secret IS 'Secret replaced by the last rotation; also signs deliveries until previous_secret_expires_at';
+
+CREATE TABLE team_webhook_deliveries (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  webhook_id UUID NOT NULL REFERENCES team_webhooks(id) ON DELETE CASCADE,
+  event TEXT NOT NULL,
+  payload JSONB NOT NULL,
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'failed')),
+  attempts INTEGER NOT NULL DEFAULT 0,
+  next_attempt_at TIMESTAMPTZ,
+  last_attempt_at TIMESTAMPTZ,
+  last_error TEXT,
+  delivered_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_team_webhook_deliveries_webhook ON team_webhook_deliveries(webhook_id, created_at DESC);
+CREATE INDEX idx_team_webhook_deliveries_due ON team_webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+
+COMMENT ON TABLE team_webhook_deliveries IS 'Messages posted to team webhooks, retried with exponential backoff for 24 hours';
+COMMENT ON COLUMN team_webhook_deliveries.payload IS 'Request body in the provider''s format, as posted on every attempt';
+COMMENT ON COLUMN team_webhook_deliveries.status IS 'pending until an attempt succeeds (delivered) or the retry window ends (failed)';
+COMMENT ON COLUMN team_webhook_deliveries.next_attempt_at IS 'When a pending delivery is next tried';
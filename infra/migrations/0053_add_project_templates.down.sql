@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS project_rooms;
+DROP TABLE IF EXISTS project_templates;
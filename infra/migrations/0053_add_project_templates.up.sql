@@ -0,0 +1,30 @@
+-- Project templates: a project's settings and room checklist saved for reuse. Projects created
+-- from a template copy its disclosure banner and rooms; later edits to the template do not
+-- change projects already created from it.
+
+CREATE TABLE project_templates (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name TEXT NOT NULL CHECK (char_length(name) BETWEEN 1 AND 100),
+  disclosure JSONB,
+  rooms JSONB NOT NULL DEFAULT '[]',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  UNIQUE (user_id, name)
+);
+
+COMMENT ON TABLE project_templates IS 'Saved project settings and room checklists that new projects can start from';
+COMMENT ON COLUMN project_templates.disclosure IS 'Disclosure banner settings copied to new projects; NULL leaves the defaults';
+COMMENT ON COLUMN project_templates.rooms IS 'Room checklist as a JSON array of {room_type, style, label}';
+
+CREATE TABLE project_rooms (
+  project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+  position INTEGER NOT NULL,
+  room_type TEXT NOT NULL,
+  style TEXT,
+  label TEXT NOT NULL DEFAULT '',
+  PRIMARY KEY (project_id, position)
+);
+
+COMMENT ON TABLE project_rooms IS 'Room checklist of a project created from a template';
+COMMENT ON COLUMN project_rooms.style IS 'Style new images of the room type default to; NULL leaves it to the request';
@@ -0,0 +1 @@
+COMMENT ON TABLE project_rooms IS 'Room checklist of a project created from a template';
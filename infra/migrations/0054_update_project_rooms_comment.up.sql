@@ -0,0 +1 @@
+COMMENT ON TABLE project_rooms IS 'Room checklist of a project, copied from its template or set directly';
@@ -0,0 +1,23 @@
+-- Proposed groupings of a project's uploads into rooms. A worker clusters the photos by
+-- visual similarity and the room types already set on them; the owner then confirms the
+-- groups, which sets the images' room types and replaces the project's room checklist.
+CREATE TABLE room_groupings (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+  status TEXT NOT NULL DEFAULT 'queued'
+    CHECK (status IN ('queued', 'processing', 'ready', 'error', 'confirmed')),
+  groups JSONB NOT NULL DEFAULT '[]'::jsonb,
+  unreadable_image_ids UUID[] NOT NULL DEFAULT '{}',
+  error TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  confirmed_at TIMESTAMPTZ,
+  CHECK (status <> 'confirmed' OR confirmed_at IS NOT NULL)
+);
+
+CREATE INDEX idx_room_groupings_project_id ON room_groupings (project_id, created_at DESC);
+
+COMMENT ON TABLE room_groupings IS 'Proposed groupings of a project''s images into rooms';
+COMMENT ON COLUMN room_groupings.groups IS 'JSON array of {room_type, label, image_ids}; room_type is null when no image of the group had one';
+COMMENT ON COLUMN room_groupings.unreadable_image_ids IS 'Images the worker could not read, left out of every group';
+COMMENT ON COLUMN room_groupings.confirmed_at IS 'When the owner applied the groups to the project';
@@ -0,0 +1,29 @@
+-- Other exposures of an image's shot, uploaded with it for an HDR merge. The worker merges
+-- them with the original into the base image it stages.
+CREATE TABLE image_brackets (
+  image_id UUID PRIMARY KEY,
+  urls TEXT[] NOT NULL CHECK (cardinality(urls) BETWEEN 1 AND 6),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE image_brackets IS 'Bracketed exposures merged with the original into an HDR base image before staging';
+COMMENT ON COLUMN image_brackets.urls IS 'Uploads of the other exposures of the shot, in the original''s storage';
+
+CREATE TRIGGER trigger_image_brackets_image_reference
+  BEFORE INSERT OR UPDATE OF image_id ON image_brackets
+  FOR EACH ROW
+  EXECUTE FUNCTION check_image_reference();
+
+CREATE OR REPLACE FUNCTION cascade_image_delete()
+RETURNS TRIGGER AS $$
+BEGIN
+  DELETE FROM jobs WHERE image_id = OLD.id;
+  DELETE FROM jobs_history WHERE image_id = OLD.id;
+  DELETE FROM image_original_purges WHERE image_id = OLD.id;
+  DELETE FROM ensemble_comparisons WHERE image_id = OLD.id;
+  DELETE FROM image_annotations WHERE image_id = OLD.id;
+  DELETE FROM image_edits WHERE image_id = OLD.id;
+  DELETE FROM image_brackets WHERE image_id = OLD.id;
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
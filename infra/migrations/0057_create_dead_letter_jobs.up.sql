@@ -0,0 +1,28 @@
+-- Queue tasks that failed, kept with their payload and every failed attempt. Workers add a
+-- row the first time a task fails and set dead_at once it has no retries left; admins list
+-- the dead tasks and requeue them once the cause is fixed.
+CREATE TABLE dead_letter_jobs (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  task_id TEXT NOT NULL UNIQUE,
+  task_type TEXT NOT NULL,
+  queue TEXT NOT NULL,
+  payload BYTEA NOT NULL,
+  image_id UUID,
+  max_retry INT NOT NULL DEFAULT 0,
+  errors JSONB NOT NULL DEFAULT '[]'::jsonb,
+  first_failed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  last_failed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  dead_at TIMESTAMPTZ,
+  requeued_at TIMESTAMPTZ,
+  requeue_count INT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_dead_letter_jobs_dead_at ON dead_letter_jobs (dead_at DESC) WHERE dead_at IS NOT NULL;
+
+COMMENT ON TABLE dead_letter_jobs IS 'Failed queue tasks with their payload and error history; dead once retries are exhausted';
+COMMENT ON COLUMN dead_letter_jobs.task_id IS 'Asynq task ID; stage:run tasks use their job row ID';
+COMMENT ON COLUMN dead_letter_jobs.payload IS 'Task payload as enqueued, still sealed when payload encryption is on';
+COMMENT ON COLUMN dead_letter_jobs.image_id IS 'Image of the task, or the first image of a batch; null when the payload could not be read';
+COMMENT ON COLUMN dead_letter_jobs.errors IS 'JSON array of {attempt, error, failed_at}, oldest first';
+COMMENT ON COLUMN dead_letter_jobs.dead_at IS 'When the task failed its last attempt; cleared when it is requeued';
+COMMENT ON COLUMN dead_letter_jobs.requeued_at IS 'When an admin last requeued the task';
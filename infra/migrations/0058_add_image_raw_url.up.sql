@@ -0,0 +1,6 @@
+-- RAW uploads (CR3, NEF, ARW) are converted to a JPEG before staging. original_url then
+-- points at the JPEG and raw_url keeps the RAW file, which stays in the bucket as the
+-- archival original under its own storage class and lifecycle tag.
+ALTER TABLE images ADD COLUMN raw_url TEXT;
+
+COMMENT ON COLUMN images.raw_url IS 'RAW upload the original was converted from; null for JPEG, PNG and WebP uploads';
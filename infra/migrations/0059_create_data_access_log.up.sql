@@ -0,0 +1,23 @@
+-- Support's reads of customer data through the admin API. Every read names the ticket it
+-- was made for and is recorded before any data is returned; customers list the entries of
+-- their own data on request.
+
+-- accessor_id is intentionally not a foreign key so history survives staff user deletion.
+CREATE TABLE data_access_log (
+  id BIGSERIAL PRIMARY KEY,
+  owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  accessor_id UUID NOT NULL,
+  resource_type TEXT NOT NULL CHECK (resource_type IN ('project')),
+  resource_id UUID NOT NULL,
+  ticket TEXT NOT NULL CHECK (length(ticket) BETWEEN 1 AND 200),
+  ip TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_data_access_log_owner_created ON data_access_log(owner_id, created_at DESC, id DESC);
+
+COMMENT ON TABLE data_access_log IS 'Append-only log of support reads of customer data through the admin API';
+COMMENT ON COLUMN data_access_log.owner_id IS 'Customer whose data was read';
+COMMENT ON COLUMN data_access_log.accessor_id IS 'Authenticated admin who read it, taken from their token rather than the request';
+COMMENT ON COLUMN data_access_log.ticket IS 'Support ticket the read was made for';
+COMMENT ON COLUMN data_access_log.ip IS 'Address the read was made from';
@@ -0,0 +1,3 @@
+DROP FUNCTION IF EXISTS reset_sandbox();
+DROP SCHEMA IF EXISTS sandbox CASCADE;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS sandbox;
@@ -0,0 +1,80 @@
+-- Sandbox tenant for integration partners. Requests made with a sandbox API key act on
+-- the sandbox schema, a copy of public that starts empty, store uploads under the sandbox/
+-- key prefix and queue jobs on the sandbox queue. The worker serving that queue calls
+-- reset_sandbox() every night, so nothing a partner does reaches production data.
+
+ALTER TABLE api_keys ADD COLUMN sandbox BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN api_keys.sandbox IS 'Requests with the key act on the sandbox schema, which is reset nightly';
+
+-- reset_sandbox drops the sandbox schema and recreates it with an empty copy of every
+-- table in public, including indexes, constraints, foreign keys and triggers, and of every
+-- view. Plans and settings are copied with their rows, since requests read them as
+-- reference data. Tables added by later migrations are picked up at the next reset.
+-- Concurrent callers are serialized.
+CREATE OR REPLACE FUNCTION reset_sandbox()
+RETURNS void AS $$
+DECLARE
+  r record;
+  stmt text;
+  tables text[] := '{}';
+  foreign_keys text[] := '{}';
+  triggers text[] := '{}';
+  views text[] := '{}';
+BEGIN
+  PERFORM pg_advisory_xact_lock(hashtext('reset_sandbox'));
+
+  -- Definitions are read while only public is on the search path, so they name its
+  -- objects unqualified; replayed with sandbox first on the path, they resolve to the copies.
+  FOR r IN
+    SELECT c.relname FROM pg_class c
+    WHERE c.relnamespace = 'public'::regnamespace AND c.relkind IN ('r', 'p')
+      AND NOT c.relispartition AND c.relname <> 'schema_migrations'
+    ORDER BY c.relname
+  LOOP
+    tables := tables || format('CREATE TABLE sandbox.%I (LIKE public.%I INCLUDING ALL)', r.relname, r.relname);
+  END LOOP;
+
+  FOR r IN
+    SELECT c.relname, con.conname, pg_get_constraintdef(con.oid) AS def
+    FROM pg_constraint con
+    JOIN pg_class c ON c.oid = con.conrelid
+    WHERE con.contype = 'f' AND con.conparentid = 0
+      AND c.relnamespace = 'public'::regnamespace AND NOT c.relispartition
+    ORDER BY c.relname, con.conname
+  LOOP
+    foreign_keys := foreign_keys || format('ALTER TABLE sandbox.%I ADD CONSTRAINT %I %s', r.relname, r.conname, r.def);
+  END LOOP;
+
+  FOR r IN
+    SELECT pg_get_triggerdef(t.oid) AS def
+    FROM pg_trigger t
+    JOIN pg_class c ON c.oid = t.tgrelid
+    WHERE NOT t.tgisinternal AND t.tgparentid = 0
+      AND c.relnamespace = 'public'::regnamespace AND NOT c.relispartition
+    ORDER BY c.relname, t.tgname
+  LOOP
+    triggers := triggers || r.def;
+  END LOOP;
+
+  FOR r IN
+    SELECT c.relname, pg_get_viewdef(c.oid) AS def FROM pg_class c
+    WHERE c.relnamespace = 'public'::regnamespace AND c.relkind = 'v'
+    ORDER BY c.oid
+  LOOP
+    views := views || format('CREATE VIEW sandbox.%I AS %s', r.relname, r.def);
+  END LOOP;
+
+  DROP SCHEMA IF EXISTS sandbox CASCADE;
+  CREATE SCHEMA sandbox;
+  PERFORM set_config('search_path', 'sandbox, public', true);
+  FOREACH stmt IN ARRAY tables || foreign_keys || triggers || views LOOP
+    EXECUTE stmt;
+  END LOOP;
+
+  INSERT INTO sandbox.plans SELECT * FROM public.plans;
+  INSERT INTO sandbox.settings SELECT * FROM public.settings;
+END;
+$$ LANGUAGE plpgsql SET search_path = public;
+
+SELECT reset_sandbox();
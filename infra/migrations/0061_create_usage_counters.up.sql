@@ -0,0 +1,15 @@
+-- Staged-image usage per user per billing period. Creating images adds to the owner's row
+-- for their subscription's current period, or the UTC calendar month without one, which is
+-- refused when it would pass their plan's monthly_limit; GET /api/v1/me/usage reads it back.
+
+CREATE TABLE usage_counters (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  period_start TIMESTAMPTZ NOT NULL,
+  staged_images INT NOT NULL DEFAULT 0 CHECK (staged_images >= 0),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (user_id, period_start)
+);
+
+COMMENT ON TABLE usage_counters IS 'Staged images created per user per billing period, checked against the plan monthly_limit';
+COMMENT ON COLUMN usage_counters.period_start IS 'Start of the subscription period the count covers, or of the UTC calendar month without a subscription';
+COMMENT ON COLUMN usage_counters.staged_images IS 'Images created for staging in the period, including ones later canceled or deleted';
@@ -0,0 +1,2 @@
+COMMENT ON COLUMN images.status IS NULL;
+COMMENT ON VIEW image_current_status IS 'Latest status of every image, derived from image_status_transitions';
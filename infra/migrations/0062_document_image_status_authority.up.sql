@@ -0,0 +1,6 @@
+-- An image's status event log is authoritative for its current status. images.status is the
+-- copy reads and filters use: the status trigger writes each event in the statement that
+-- changes the column, and storage reconciliation puts back any image whose copy drifted
+-- through a write that bypassed the trigger.
+COMMENT ON COLUMN images.status IS 'Copy of the image''s latest image_status_transitions event, which is authoritative; reconciliation repairs drift';
+COMMENT ON VIEW image_current_status IS 'Latest status of every image, derived from image_status_transitions; authoritative over images.status';
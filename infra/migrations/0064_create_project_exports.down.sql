@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS project_exports;
+DROP TABLE IF EXISTS export_credits;
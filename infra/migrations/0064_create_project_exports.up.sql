@@ -0,0 +1,46 @@
+-- Project exports bundle a project's staged photos into one zip. Exports of projects whose
+-- owner has no active plan carry the platform mark on every photo, unless the project was
+-- unlocked with an export credit: a one-off purchase made through a Stripe one-time checkout.
+
+CREATE TABLE export_credits (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  checkout_session_id TEXT NOT NULL UNIQUE,
+  project_id UUID REFERENCES projects(id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  consumed_at TIMESTAMPTZ,
+  CHECK (project_id IS NULL OR consumed_at IS NOT NULL)
+);
+
+CREATE INDEX idx_export_credits_available ON export_credits (user_id, created_at) WHERE consumed_at IS NULL;
+-- One credit unlocks a project; a concurrent second unlock fails rather than spend another.
+CREATE UNIQUE INDEX idx_export_credits_project_id ON export_credits (project_id) WHERE project_id IS NOT NULL;
+
+COMMENT ON TABLE export_credits IS 'Watermark-free project exports bought through a Stripe one-time checkout';
+COMMENT ON COLUMN export_credits.checkout_session_id IS 'Stripe checkout session that paid for the credit; unique so webhook redeliveries add it once';
+COMMENT ON COLUMN export_credits.project_id IS 'Project the credit unlocked; its later exports are watermark-free without another credit';
+COMMENT ON COLUMN export_credits.consumed_at IS 'When the credit was spent on an export; stays set when the project is deleted';
+
+CREATE TABLE project_exports (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  status TEXT NOT NULL DEFAULT 'queued'
+    CHECK (status IN ('queued', 'processing', 'ready', 'error')),
+  watermarked BOOLEAN NOT NULL,
+  credit_id UUID REFERENCES export_credits(id) ON DELETE SET NULL,
+  result_url TEXT,
+  image_count INT NOT NULL DEFAULT 0,
+  error TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CHECK (status <> 'ready' OR result_url IS NOT NULL)
+);
+
+CREATE INDEX idx_project_exports_project_id ON project_exports (project_id, created_at DESC);
+
+COMMENT ON TABLE project_exports IS 'Zips of a project''s staged photos, built by the worker';
+COMMENT ON COLUMN project_exports.user_id IS 'Member who requested the export';
+COMMENT ON COLUMN project_exports.watermarked IS 'Whether the worker stamps the platform mark on every photo';
+COMMENT ON COLUMN project_exports.credit_id IS 'Export credit that unlocked the project, when the export is watermark-free through one';
+COMMENT ON COLUMN project_exports.result_url IS 's3:// URL of the zip once ready';